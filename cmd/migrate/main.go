@@ -0,0 +1,251 @@
+// Package main is the entry point for the QuickQuote migration tool, which
+// applies, rolls back and reports database migrations outside the server,
+// and restores database backups.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/backup"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up            Apply all pending migrations
+  down [N]      Roll back the last N applied migrations (default 1)
+  status        List migrations and whether each is applied
+  force VERSION Record migrations up to VERSION as applied and later ones as
+                not applied, without running any SQL
+  restore FILE  Load a backup downloaded from /admin/backups into the
+                database with pg_restore, in one transaction; FILE may be -
+                to read standard input. Run up afterwards to apply
+                migrations added since the backup was taken
+
+Flags:
+`
+
+func main() {
+	dir := flag.String("dir", "migrations", "directory containing migration files")
+	dryRun := flag.Bool("dry-run", false, "print the SQL up or down would run, or the restore command, without running it")
+	clean := flag.Bool("clean", false, "restore: drop the database's existing objects before recreating them")
+	pgRestore := flag.String("pg-restore", "pg_restore", "restore: pg_restore executable")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	if flag.Arg(0) == "restore" {
+		err = restore(*pgRestore, *clean, *dryRun, flag.Args()[1:])
+	} else {
+		err = run(*dir, *dryRun, flag.Args())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, dryRun bool, args []string) error {
+	command, args := args[0], args[1:]
+
+	steps := 1
+	var version int
+	switch command {
+	case "up", "status":
+		if len(args) > 0 {
+			return fmt.Errorf("%s takes no arguments", command)
+		}
+	case "down":
+		if len(args) > 1 {
+			return fmt.Errorf("down takes at most one argument")
+		}
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of migrations: %q", args[0])
+			}
+			steps = n
+		}
+	case "force":
+		if len(args) != 1 {
+			return fmt.Errorf("force takes a version")
+		}
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid version: %q", args[0])
+		}
+		version = v
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	migrations, err := database.LoadMigrations(dir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+
+	// Migrations only touch the primary
+	cfg.Database.ReplicaDSN = ""
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := database.New(ctx, &cfg.Database, logger)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator := database.NewMigrator(db.Pool, logger)
+
+	switch command {
+	case "up":
+		if dryRun {
+			pending, err := migrator.Pending(ctx, migrations)
+			if err != nil {
+				return err
+			}
+			printSQL(pending, false)
+			return nil
+		}
+		applied, err := migrator.Up(ctx, migrations)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migration(s)\n", len(applied))
+
+	case "down":
+		if dryRun {
+			rollback, err := migrator.PendingRollback(ctx, migrations, steps)
+			if err != nil {
+				return err
+			}
+			printSQL(rollback, true)
+			return nil
+		}
+		reverted, err := migrator.Down(ctx, migrations, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx, migrations)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+
+	case "force":
+		if dryRun {
+			fmt.Printf("Would record migrations up to version %d as applied and later ones as not applied\n", version)
+			return nil
+		}
+		if err := migrator.Force(ctx, migrations, version); err != nil {
+			return err
+		}
+		fmt.Printf("Forced migration version to %d\n", version)
+	}
+
+	return nil
+}
+
+// restore loads a backup file into the configured database.
+func restore(pgRestore string, clean, dryRun bool, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("restore takes a backup file")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	restorer := &backup.Restorer{Path: pgRestore, DB: &cfg.Database, Clean: clean}
+
+	if dryRun {
+		fmt.Printf("Would run: %s < %s\n", restorer.Command(), args[0])
+		return nil
+	}
+
+	in := os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := restorer.Restore(ctx, in); err != nil {
+		return err
+	}
+	fmt.Printf("Restored %s into %s\n", args[0], cfg.Database.Name)
+	return nil
+}
+
+// printSQL prints the SQL a dry run would execute, in order.
+func printSQL(migrations []database.Migration, down bool) {
+	if len(migrations) == 0 {
+		fmt.Println("-- Nothing to do")
+		return
+	}
+	for _, m := range migrations {
+		sql := m.Up
+		if down {
+			sql = m.Down
+		}
+		fmt.Printf("-- %s (version %d)\n%s\n\n", m.Filename, m.Version, sql)
+	}
+}
+
+// printStatus prints a table of migrations and when each was applied.
+func printStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tMIGRATION\tAPPLIED AT")
+	pending := 0
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		} else {
+			pending++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Filename, appliedAt)
+	}
+	w.Flush()
+	fmt.Printf("\n%d migration(s), %d pending\n", len(statuses), pending)
+}
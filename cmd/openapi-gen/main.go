@@ -0,0 +1,47 @@
+// Package main generates the OpenAPI document served at /api/v1/openapi.json
+// from the annotations on the API handlers.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jkindrix/quickquote/internal/openapi"
+)
+
+func main() {
+	root := flag.String("root", ".", "module root to read handlers and types from")
+	output := flag.String("o", "internal/openapi/openapi.json", "file to write the document to")
+	check := flag.Bool("check", false, "fail if the file is out of date instead of writing it")
+	flag.Parse()
+
+	if err := run(*root, *output, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "openapi-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(root, output string, check bool) error {
+	doc, err := openapi.Generate(root)
+	if err != nil {
+		return err
+	}
+	data, err := openapi.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	if check {
+		current, err := os.ReadFile(output)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, data) {
+			return fmt.Errorf("%s is out of date; run make openapi", output)
+		}
+		return nil
+	}
+	return os.WriteFile(output, data, 0o644)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// call is the part of an API call qq shows.
+type call struct {
+	ID          string    `json:"id"`
+	Provider    string    `json:"provider"`
+	PhoneNumber string    `json:"phone_number"`
+	FromNumber  string    `json:"from_number"`
+	CallerName  *string   `json:"caller_name"`
+	Status      string    `json:"status"`
+	Duration    *int      `json:"duration_seconds"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// callsListOptions are the flags of "qq calls list".
+type callsListOptions struct {
+	status, provider, from, to string
+	limit                      int
+	asJSON                     bool
+}
+
+// newCallsListCommand builds "qq calls list".
+func newCallsListCommand(connect connectFunc) *cobra.Command {
+	var opts callsListOptions
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List calls, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := connect()
+			if err != nil {
+				return err
+			}
+			return listCalls(cmd.Context(), c, &opts)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&opts.status, "status", "", "only calls with this status")
+	f.StringVar(&opts.provider, "provider", "", "only calls through this voice provider")
+	f.StringVar(&opts.from, "from", "", "only calls created on or after this date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&opts.to, "to", "", "only calls created before this time, or on this date")
+	f.IntVar(&opts.limit, "limit", 20, "maximum calls to list")
+	f.BoolVar(&opts.asJSON, "json", false, "print calls as JSON, one per line")
+	return cmd
+}
+
+// listCalls runs "qq calls list".
+func listCalls(ctx context.Context, c *client, opts *callsListOptions) error {
+	if opts.limit < 1 {
+		return fmt.Errorf("invalid limit %d", opts.limit)
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{"status": opts.status, "provider": opts.provider, "from": opts.from, "to": opts.to} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	// Follow cursors until there are enough calls
+	var calls []json.RawMessage
+	for len(calls) < opts.limit {
+		query.Set("limit", strconv.Itoa(min(opts.limit-len(calls), 100)))
+		var page struct {
+			Calls      []json.RawMessage `json:"calls"`
+			NextCursor string            `json:"next_cursor"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/calls", query, nil, &page); err != nil {
+			return err
+		}
+		calls = append(calls, page.Calls...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+
+	if opts.asJSON {
+		for _, raw := range calls {
+			fmt.Println(string(raw))
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED\tSTATUS\tPROVIDER\tFROM\tTO\tCALLER\tDURATION")
+	for _, raw := range calls {
+		var c call
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return fmt.Errorf("failed to decode call: %w", err)
+		}
+		caller, duration := "-", "-"
+		if c.CallerName != nil && *c.CallerName != "" {
+			caller = *c.CallerName
+		}
+		if c.Duration != nil {
+			duration = (time.Duration(*c.Duration) * time.Second).String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.ID, c.CreatedAt.Local().Format("2006-01-02 15:04"), c.Status, c.Provider,
+			c.FromNumber, c.PhoneNumber, caller, duration)
+	}
+	return w.Flush()
+}
+
+// callStartOptions are the flags of "qq call start".
+type callStartOptions struct {
+	number, promptID, task, firstSentence, voice string
+	maxDuration                                  int
+}
+
+// newCallStartCommand builds "qq call start".
+func newCallStartCommand(connect connectFunc) *cobra.Command {
+	var opts callStartOptions
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Place an outbound call",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := connect()
+			if err != nil {
+				return err
+			}
+			return startCall(cmd.Context(), c, &opts)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&opts.number, "number", "", "number to call, in E.164 format")
+	f.StringVar(&opts.promptID, "prompt", "", "ID of the prompt to call with; the default prompt if empty")
+	f.StringVar(&opts.task, "task", "", "instructions for the agent, instead of a prompt's")
+	f.StringVar(&opts.firstSentence, "first-sentence", "", "what the agent says first")
+	f.StringVar(&opts.voice, "voice", "", "voice to use")
+	f.IntVar(&opts.maxDuration, "max-duration", 0, "maximum call length in minutes")
+	_ = cmd.MarkFlagRequired("number")
+	return cmd
+}
+
+// startCall runs "qq call start".
+func startCall(ctx context.Context, c *client, opts *callStartOptions) error {
+	req := map[string]interface{}{"phone_number": opts.number}
+	for key, value := range map[string]string{"prompt_id": opts.promptID, "task": opts.task, "first_sentence": opts.firstSentence, "voice": opts.voice} {
+		if value != "" {
+			req[key] = value
+		}
+	}
+	if opts.maxDuration > 0 {
+		req["max_duration"] = opts.maxDuration
+	}
+
+	var resp struct {
+		CallID     string `json:"call_id"`
+		Status     string `json:"status"`
+		PromptName string `json:"prompt_name"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/calls", nil, req, &resp); err != nil {
+		return err
+	}
+
+	fmt.Printf("Call %s to %s: %s", resp.CallID, opts.number, resp.Status)
+	if resp.PromptName != "" {
+		fmt.Printf(" (prompt %q)", resp.PromptName)
+	}
+	fmt.Println()
+	return nil
+}
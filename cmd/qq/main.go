@@ -0,0 +1,85 @@
+// Package main is the entry point for qq, the QuickQuote command line client,
+// which calls the JSON API with an API key for scripting and CI seeding.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// connectFunc returns the API client for a command, once the root flags
+// are parsed.
+type connectFunc func() (*client, error)
+
+func main() {
+	var (
+		baseURL string
+		apiKey  string
+		timeout time.Duration
+	)
+	root := newRootCommand(func() (*client, error) {
+		if apiKey == "" {
+			return nil, errors.New("an API key is required: set QQ_API_KEY or pass --key")
+		}
+		return newClient(baseURL, apiKey, timeout), nil
+	})
+	root.PersistentFlags().StringVar(&baseURL, "url", envOr("QQ_URL", "http://localhost:8080"), "QuickQuote server URL (or set QQ_URL)")
+	root.PersistentFlags().StringVar(&apiKey, "key", os.Getenv("QQ_API_KEY"), "API key (or set QQ_API_KEY)")
+	root.PersistentFlags().DurationVar(&timeout, "timeout", 60*time.Second, "timeout for each API request")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := root.ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "qq:", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCommand builds the qq command tree. Commands get their API client
+// from connect.
+func newRootCommand(connect connectFunc) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "qq",
+		Short: "Command line client for the QuickQuote API",
+		// Errors are printed once, by main, rather than with the usage
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	calls := &cobra.Command{Use: "calls", Short: "List calls"}
+	calls.AddCommand(newCallsListCommand(connect))
+
+	call := &cobra.Command{Use: "call", Short: "Place calls"}
+	call.AddCommand(newCallStartCommand(connect))
+
+	prompt := &cobra.Command{Use: "prompt", Short: "Sync prompts with YAML files"}
+	prompt.AddCommand(newPromptPullCommand(connect), newPromptPushCommand(connect))
+
+	quotes := &cobra.Command{Use: "quotes", Short: "Export quotes"}
+	quotes.AddCommand(newQuotesExportCommand(connect))
+
+	root.AddCommand(calls, call, prompt, quotes)
+	return root
+}
+
+// run runs the command named by args with c as its client.
+func run(ctx context.Context, c *client, args []string) error {
+	root := newRootCommand(func() (*client, error) { return c, nil })
+	root.SetArgs(args)
+	return root.ExecuteContext(ctx)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
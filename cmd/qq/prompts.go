@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Prompt fields the server manages, which are left out of prompt files.
+var serverPromptFields = []string{"id", "created_at", "updated_at", "deleted_at", "is_default"}
+
+// Prompt fields written first in prompt files, in this order; the rest
+// follow alphabetically.
+var leadingPromptFields = []string{"name", "description", "task"}
+
+// newPromptPullCommand builds "qq prompt pull".
+func newPromptPullCommand(connect connectFunc) *cobra.Command {
+	var dir string
+	var includeInactive bool
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Write prompts to YAML files, one per prompt",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := connect()
+			if err != nil {
+				return err
+			}
+			return pullPrompts(cmd.Context(), c, dir, includeInactive)
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "prompts", "directory to write prompt files to")
+	cmd.Flags().BoolVar(&includeInactive, "all", false, "include inactive prompts")
+	return cmd
+}
+
+// pullPrompts runs "qq prompt pull".
+func pullPrompts(ctx context.Context, c *client, dir string, includeInactive bool) error {
+	prompts, err := listPrompts(ctx, c, !includeInactive)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, prompt := range prompts {
+		name, _ := prompt["name"].(string)
+		file := promptFileName(name, used)
+		for _, field := range serverPromptFields {
+			delete(prompt, field)
+		}
+
+		data, err := marshalPrompt(prompt)
+		if err != nil {
+			return fmt.Errorf("failed to encode prompt %q: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), data, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("Pulled %q to %s\n", name, filepath.Join(dir, file))
+	}
+	fmt.Printf("%d prompt(s) pulled\n", len(prompts))
+	return nil
+}
+
+// newPromptPushCommand builds "qq prompt push".
+func newPromptPushCommand(connect connectFunc) *cobra.Command {
+	var dir string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "push [file...]",
+		Short: "Create or update prompts from YAML files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := connect()
+			if err != nil {
+				return err
+			}
+			return pushPrompts(cmd.Context(), c, dir, dryRun, args)
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "prompts", "directory to read prompt files from, if no files are named")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would change without changing it")
+	return cmd
+}
+
+// pushPrompts runs "qq prompt push". Prompts are matched to existing ones
+// by name; fields left out of a file are left as they are.
+func pushPrompts(ctx context.Context, c *client, dir string, dryRun bool, files []string) error {
+	if len(files) == 0 {
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				return err
+			}
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no prompt files in %s", dir)
+	}
+
+	// Read every file before changing anything, so a bad file stops the push
+	prompts := make([]map[string]interface{}, len(files))
+	for i, file := range files {
+		prompt, err := readPromptFile(file)
+		if err != nil {
+			return err
+		}
+		prompts[i] = prompt
+	}
+
+	existing, err := listPrompts(ctx, c, false)
+	if err != nil {
+		return err
+	}
+	ids := make(map[string]string, len(existing))
+	for _, prompt := range existing {
+		name, _ := prompt["name"].(string)
+		id, _ := prompt["id"].(string)
+		ids[name] = id
+	}
+
+	verb := ""
+	if dryRun {
+		verb = "Would have "
+	}
+	var created, updated int
+	for i, prompt := range prompts {
+		name := prompt["name"].(string)
+		if id, ok := ids[name]; ok {
+			if !dryRun {
+				if err := c.do(ctx, http.MethodPut, "/prompts/"+url.PathEscape(id), nil, prompt, nil); err != nil {
+					return fmt.Errorf("%s: %w", files[i], err)
+				}
+			}
+			fmt.Printf("%supdated %q from %s\n", verb, name, files[i])
+			updated++
+			continue
+		}
+
+		if !dryRun {
+			var resp struct {
+				ID string `json:"id"`
+			}
+			if err := c.do(ctx, http.MethodPost, "/prompts", nil, prompt, &resp); err != nil {
+				return fmt.Errorf("%s: %w", files[i], err)
+			}
+			ids[name] = resp.ID
+		}
+		fmt.Printf("%screated %q from %s\n", verb, name, files[i])
+		created++
+	}
+	fmt.Printf("%d prompt(s) created, %d updated\n", created, updated)
+	return nil
+}
+
+// listPrompts returns every prompt, or only active ones.
+func listPrompts(ctx context.Context, c *client, activeOnly bool) ([]map[string]interface{}, error) {
+	var prompts []map[string]interface{}
+	for page := 1; ; page++ {
+		query := url.Values{
+			"page":        {strconv.Itoa(page)},
+			"page_size":   {"100"},
+			"active_only": {strconv.FormatBool(activeOnly)},
+		}
+		var resp struct {
+			Prompts []map[string]interface{} `json:"prompts"`
+			Total   int                      `json:"total"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/prompts", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, resp.Prompts...)
+		if len(resp.Prompts) == 0 || len(prompts) >= resp.Total {
+			return prompts, nil
+		}
+	}
+}
+
+// readPromptFile reads a prompt from a YAML file, whose keys are the
+// API's JSON field names.
+func readPromptFile(file string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var prompt map[string]interface{}
+	if err := yaml.Unmarshal(data, &prompt); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for _, field := range []string{"name", "task"} {
+		if value, _ := prompt[field].(string); strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("%s: %s is required", file, field)
+		}
+	}
+	for _, field := range serverPromptFields {
+		delete(prompt, field)
+	}
+
+	// Check that the prompt survives the trip to JSON
+	if _, err := json.Marshal(prompt); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return prompt, nil
+}
+
+// marshalPrompt encodes a prompt as YAML with its leading fields first.
+func marshalPrompt(prompt map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(prompt))
+	for key := range prompt {
+		keys = append(keys, key)
+	}
+	rank := func(key string) int {
+		for i, leading := range leadingPromptFields {
+			if key == leading {
+				return i
+			}
+		}
+		return len(leadingPromptFields)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if ri, rj := rank(keys[i]), rank(keys[j]); ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range keys {
+		var value yaml.Node
+		if err := value.Encode(prompt[key]); err != nil {
+			return nil, err
+		}
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+	}
+	return yaml.Marshal(doc)
+}
+
+// promptFileName returns a file name for the prompt called name that isn't
+// in used, and marks it used.
+func promptFileName(name string, used map[string]bool) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	base := strings.TrimSuffix(b.String(), "-")
+	if base == "" {
+		base = "prompt"
+	}
+
+	file := base + ".yaml"
+	for n := 2; used[file]; n++ {
+		file = base + "-" + strconv.Itoa(n) + ".yaml"
+	}
+	used[file] = true
+	return file
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePromptAPI serves the prompt endpoints from memory.
+type fakePromptAPI struct {
+	prompts []map[string]interface{}
+	puts    int
+}
+
+func (api *fakePromptAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer qq_test" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "Unauthorized", "code": "UNAUTHORIZED", "message": "invalid API key"})
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/prompts":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"prompts": api.prompts, "total": len(api.prompts)})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/prompts":
+		var prompt map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&prompt)
+		prompt["id"] = "prompt-new"
+		api.prompts = append(api.prompts, prompt)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(prompt)
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/v1/prompts/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/prompts/")
+		var update map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		for _, prompt := range api.prompts {
+			if prompt["id"] == id {
+				for key, value := range update {
+					prompt[key] = value
+				}
+			}
+		}
+		api.puts++
+		_ = json.NewEncoder(w).Encode(update)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler, apiKey string) *client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return newClient(server.URL, apiKey, 5*time.Second)
+}
+
+func TestPromptPullPush(t *testing.T) {
+	api := &fakePromptAPI{prompts: []map[string]interface{}{
+		{"id": "prompt-1", "name": "Web App Intake", "task": "Ask about the web app.\nGet a budget.", "is_active": true, "created_at": "2026-01-02T00:00:00Z"},
+	}}
+	c := newTestClient(t, api, "qq_test")
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	if err := run(ctx, c, []string{"prompt", "pull", "--dir", dir}); err != nil {
+		t.Fatalf("pull error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "web-app-intake.yaml"))
+	if err != nil {
+		t.Fatalf("expected the prompt pulled to web-app-intake.yaml: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "name: Web App Intake\n") || strings.Contains(string(data), "created_at") {
+		t.Errorf("expected the name first and server fields left out, got:\n%s", data)
+	}
+
+	// Edit the pulled prompt and add a new one
+	edited := strings.Replace(string(data), "Get a budget.", "Get a budget and a timeline.", 1)
+	if err := os.WriteFile(filepath.Join(dir, "web-app-intake.yaml"), []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mobile.yml"), []byte("name: Mobile App Intake\ntask: Ask about the app.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(ctx, c, []string{"prompt", "push", "--dir", dir}); err != nil {
+		t.Fatalf("push error = %v", err)
+	}
+	if api.puts != 1 || len(api.prompts) != 2 {
+		t.Fatalf("expected one update and one create, got %d updates and %d prompts", api.puts, len(api.prompts))
+	}
+	if task := api.prompts[0]["task"]; task != "Ask about the web app.\nGet a budget and a timeline." {
+		t.Errorf("task = %q", task)
+	}
+}
+
+func TestPromptPush_RequiresTask(t *testing.T) {
+	api := &fakePromptAPI{}
+	c := newTestClient(t, api, "qq_test")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("name: No Task\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := run(context.Background(), c, []string{"prompt", "push", "--dir", dir})
+	if err == nil || !strings.Contains(err.Error(), "task is required") || len(api.prompts) != 0 {
+		t.Errorf("expected the push refused without changes, got %v", err)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	c := newTestClient(t, &fakePromptAPI{}, "qq_wrong")
+
+	_, err := listPrompts(context.Background(), c, true)
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized || apiErr.Code != "UNAUTHORIZED" || apiErr.Message != "invalid API key" {
+		t.Errorf("expected the API's error message, got %v", err)
+	}
+}
+
+func TestPromptFileName(t *testing.T) {
+	used := make(map[string]bool)
+	for _, tt := range []struct{ name, want string }{
+		{"Web App Intake", "web-app-intake.yaml"},
+		{"web app  intake!", "web-app-intake-2.yaml"},
+		{"???", "prompt.yaml"},
+	} {
+		if got := promptFileName(tt.name, used); got != tt.want {
+			t.Errorf("promptFileName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
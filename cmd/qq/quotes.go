@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// quotesExportOptions are the flags of "qq quotes export".
+type quotesExportOptions struct {
+	format, from, to, status, output string
+}
+
+// newQuotesExportCommand builds "qq quotes export".
+func newQuotesExportCommand(connect connectFunc) *cobra.Command {
+	var opts quotesExportOptions
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Download quotes as CSV or XLSX",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := connect()
+			if err != nil {
+				return err
+			}
+			return exportQuotes(cmd.Context(), c, &opts)
+		},
+	}
+	f := cmd.Flags()
+	f.StringVar(&opts.format, "format", "csv", "csv or xlsx")
+	f.StringVar(&opts.from, "from", "", "only quotes for calls created on or after this date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&opts.to, "to", "", "only quotes for calls created before this time, or on this date")
+	f.StringVar(&opts.status, "status", "", "only quotes for calls with this status")
+	f.StringVarP(&opts.output, "output", "o", "", "file to write the export to; standard output if empty")
+	return cmd
+}
+
+// exportQuotes runs "qq quotes export".
+func exportQuotes(ctx context.Context, c *client, opts *quotesExportOptions) error {
+	if opts.format != "csv" && opts.format != "xlsx" {
+		return fmt.Errorf("invalid format %q: use csv or xlsx", opts.format)
+	}
+	if opts.format == "xlsx" && opts.output == "" {
+		return fmt.Errorf("--output is required for xlsx exports")
+	}
+
+	query := url.Values{"format": {opts.format}}
+	for key, value := range map[string]string{"from": opts.from, "to": opts.to, "status": opts.status} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := c.download(ctx, "/quotes/export", query, w); err != nil {
+		if opts.output != "" {
+			os.Remove(opts.output)
+		}
+		return err
+	}
+	if opts.output != "" {
+		fmt.Fprintf(os.Stderr, "Quotes exported to %s\n", opts.output)
+	}
+	return nil
+}
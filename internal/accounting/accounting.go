@@ -0,0 +1,164 @@
+// Package accounting pushes accepted quotes to an accounting system as
+// estimates or invoices.
+package accounting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone       = ""
+	ProviderQuickBooks = "quickbooks"
+	ProviderXero       = "xero"
+)
+
+// DisplayName returns a provider's name as shown to staff.
+func DisplayName(provider string) string {
+	switch provider {
+	case ProviderQuickBooks:
+		return "QuickBooks"
+	case ProviderXero:
+		return "Xero"
+	default:
+		return provider
+	}
+}
+
+// DocumentKind is the kind of document a quote is pushed as.
+type DocumentKind string
+
+// Document kinds.
+const (
+	DocumentEstimate DocumentKind = "estimate" // A Xero quote
+	DocumentInvoice  DocumentKind = "invoice"
+)
+
+// ParseDocumentKind parses a document kind, defaulting to an invoice.
+func ParseDocumentKind(s string) (DocumentKind, error) {
+	switch DocumentKind(strings.ToLower(strings.TrimSpace(s))) {
+	case "", DocumentInvoice:
+		return DocumentInvoice, nil
+	case DocumentEstimate:
+		return DocumentEstimate, nil
+	default:
+		return "", fmt.Errorf("unknown accounting document %q", s)
+	}
+}
+
+// Token is an OAuth grant for one company (QuickBooks) or organisation
+// (Xero).
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	TenantID     string // QuickBooks realm ID or Xero tenant ID
+}
+
+// Expired reports whether the access token expires within a minute of now.
+func (t *Token) Expired(now time.Time) bool {
+	return !now.Add(time.Minute).Before(t.ExpiresAt)
+}
+
+// Line is a line on a pushed document.
+type Line struct {
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	ItemCode    string // QuickBooks item ID or Xero item code; empty for none
+}
+
+// Document is a quote to push.
+type Document struct {
+	Kind          DocumentKind
+	Number        string
+	Date          time.Time
+	CustomerName  string
+	CustomerEmail string
+	CustomerPhone string
+	Lines         []Line
+	TaxCode       string // QuickBooks tax code ID or Xero tax type applied to every line; empty for none
+	Currency      string // ISO 4217 code of the line prices; empty for the organization's home currency
+	Notes         string
+}
+
+// Validate checks that the document can be pushed.
+func (d *Document) Validate() error {
+	if d.Kind != DocumentEstimate && d.Kind != DocumentInvoice {
+		return fmt.Errorf("unknown accounting document %q", d.Kind)
+	}
+	if strings.TrimSpace(d.CustomerName) == "" {
+		return errors.New("customer name is required")
+	}
+	if len(d.Lines) == 0 {
+		return errors.New("document has no lines")
+	}
+	return nil
+}
+
+// Result identifies a document created in the accounting system.
+type Result struct {
+	ID     string
+	Number string
+}
+
+// Connector connects to an accounting system with OAuth and creates
+// documents in it.
+type Connector interface {
+	// AuthURL returns the page an admin is sent to to grant access.
+	AuthURL(state, redirectURL string) string
+
+	// Exchange trades the code from the OAuth callback, whose query is
+	// params, for a token.
+	Exchange(ctx context.Context, params url.Values, redirectURL string) (*Token, error)
+
+	// Refresh obtains a new access token.
+	Refresh(ctx context.Context, token *Token) (*Token, error)
+
+	// Push creates the document and returns its ID and number.
+	Push(ctx context.Context, token *Token, doc *Document) (*Result, error)
+
+	// Name returns the backend name.
+	Name() string
+}
+
+// Config holds accounting system settings.
+type Config struct {
+	Provider     string // "quickbooks", "xero", or empty to disable
+	ClientID     string // OAuth app client ID
+	ClientSecret string
+	Sandbox      bool   // Use the QuickBooks sandbox
+	APIURL       string // Overrides the API URL, for tests
+	AuthURL      string // Overrides the authorization page URL, for tests
+	TokenURL     string // Overrides the token URL, for tests
+}
+
+// Enabled reports whether an accounting system is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != ProviderNone
+}
+
+// New creates the connector for the configured provider. It returns nil
+// when no provider is configured.
+func New(cfg Config) (Connector, error) {
+	provider := strings.ToLower(cfg.Provider)
+	if provider == ProviderNone {
+		return nil, nil
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("%s client ID and secret are required", provider)
+	}
+	switch provider {
+	case ProviderQuickBooks:
+		return NewQuickBooks(cfg, nil, nil), nil
+	case ProviderXero:
+		return NewXero(cfg, nil, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown accounting provider %q", cfg.Provider)
+	}
+}
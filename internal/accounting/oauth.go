@@ -0,0 +1,192 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+// oauthClient requests tokens and calls JSON APIs for both providers, which
+// use the same OAuth 2.0 authorization code flow with the client ID and
+// secret sent as basic auth.
+type oauthClient struct {
+	clientID     string
+	clientSecret string
+	tokenURL     string
+	client       *http.Client
+
+	clock clock.Clock
+}
+
+// authURL builds the authorization page URL.
+func (c *oauthClient) authURL(base, scope, state, redirectURL string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {c.clientID},
+		"redirect_uri":  {redirectURL},
+		"scope":         {scope},
+		"state":         {state},
+	}
+	return base + "?" + q.Encode()
+}
+
+// exchange trades an authorization code for a token.
+func (c *oauthClient) exchange(ctx context.Context, code, redirectURL string) (*Token, error) {
+	if code == "" {
+		return nil, fmt.Errorf("authorization code is missing")
+	}
+	return c.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURL},
+	})
+}
+
+// refresh obtains a new access token, keeping the tenant.
+func (c *oauthClient) refresh(ctx context.Context, token *Token) (*Token, error) {
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("no refresh token; reconnect the accounting system")
+	}
+	refreshed, err := c.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {token.RefreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	refreshed.TenantID = token.TenantID
+	return refreshed, nil
+}
+
+func (c *oauthClient) token(ctx context.Context, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var resp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+	return &Token{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    c.clock.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// call sends a JSON API request with the access token and decodes the JSON
+// response into out. headers are added to the request.
+func (c *oauthClient) call(ctx context.Context, method, endpoint string, token *Token, headers map[string]string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return c.do(req, out)
+}
+
+func (c *oauthClient) do(req *http.Request, out interface{}) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, errorMessage(data))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// errorMessage picks a readable message out of an error response from
+// either provider, falling back to the raw body.
+func errorMessage(data []byte) string {
+	var body struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		Message          string `json:"Message"`
+		Fault            struct {
+			Error []struct {
+				Message string `json:"Message"`
+				Detail  string `json:"Detail"`
+			} `json:"Error"`
+		} `json:"Fault"`
+		Elements []struct {
+			ValidationErrors []struct {
+				Message string `json:"Message"`
+			} `json:"ValidationErrors"`
+		} `json:"Elements"`
+	}
+	if json.Unmarshal(data, &body) == nil {
+		if len(body.Fault.Error) > 0 {
+			e := body.Fault.Error[0]
+			if e.Detail != "" {
+				return e.Message + ": " + e.Detail
+			}
+			return e.Message
+		}
+		for _, el := range body.Elements {
+			if len(el.ValidationErrors) > 0 {
+				return el.ValidationErrors[0].Message
+			}
+		}
+		if body.ErrorDescription != "" {
+			return body.ErrorDescription
+		}
+		if body.Message != "" {
+			return body.Message
+		}
+		if body.Error != "" {
+			return body.Error
+		}
+	}
+	msg := strings.TrimSpace(string(data))
+	if len(msg) > 200 {
+		msg = msg[:200]
+	}
+	return msg
+}
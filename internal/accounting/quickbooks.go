@@ -0,0 +1,225 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+const (
+	quickBooksAuthURL       = "https://appcenter.intuit.com/connect/oauth2"
+	quickBooksTokenURL      = "https://oauth.platform.intuit.com/oauth2/v1/tokens/bearer"
+	quickBooksAPIURL        = "https://quickbooks.api.intuit.com/v3/company"
+	quickBooksSandboxAPIURL = "https://sandbox-quickbooks.api.intuit.com/v3/company"
+	quickBooksScope         = "com.intuit.quickbooks.accounting"
+	quickBooksMinorVersion  = "65"
+)
+
+// QuickBooks creates estimates and invoices in QuickBooks Online.
+type QuickBooks struct {
+	oauth   oauthClient
+	authURL string
+	apiURL  string
+}
+
+// NewQuickBooks creates a QuickBooks Online connector. A client with a 30
+// second timeout is used when client is nil.
+func NewQuickBooks(cfg Config, client *http.Client, clk clock.Clock) *QuickBooks {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = quickBooksAPIURL
+		if cfg.Sandbox {
+			apiURL = quickBooksSandboxAPIURL
+		}
+	}
+	return &QuickBooks{
+		oauth: oauthClient{
+			clientID:     cfg.ClientID,
+			clientSecret: cfg.ClientSecret,
+			tokenURL:     firstNonEmpty(cfg.TokenURL, quickBooksTokenURL),
+			client:       client,
+			clock:        clk,
+		},
+		authURL: firstNonEmpty(cfg.AuthURL, quickBooksAuthURL),
+		apiURL:  strings.TrimRight(apiURL, "/"),
+	}
+}
+
+// Name returns the backend name.
+func (q *QuickBooks) Name() string {
+	return ProviderQuickBooks
+}
+
+// AuthURL returns the Intuit page where an admin picks a company to connect.
+func (q *QuickBooks) AuthURL(state, redirectURL string) string {
+	return q.oauth.authURL(q.authURL, quickBooksScope, state, redirectURL)
+}
+
+// Exchange trades the callback's code for a token for the company in its
+// realmId parameter.
+func (q *QuickBooks) Exchange(ctx context.Context, params url.Values, redirectURL string) (*Token, error) {
+	realmID := params.Get("realmId")
+	if realmID == "" {
+		return nil, fmt.Errorf("quickbooks callback has no company (realmId)")
+	}
+	token, err := q.oauth.exchange(ctx, params.Get("code"), redirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("quickbooks: %w", err)
+	}
+	token.TenantID = realmID
+	return token, nil
+}
+
+// Refresh obtains a new access token. QuickBooks may also rotate the
+// refresh token.
+func (q *QuickBooks) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	refreshed, err := q.oauth.refresh(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("quickbooks: %w", err)
+	}
+	return refreshed, nil
+}
+
+// qbRef is a QuickBooks reference to another entity.
+type qbRef struct {
+	Value string `json:"value"`
+}
+
+type qbLine struct {
+	DetailType          string              `json:"DetailType"`
+	Amount              float64             `json:"Amount"`
+	Description         string              `json:"Description,omitempty"`
+	SalesItemLineDetail qbSalesItemLineInfo `json:"SalesItemLineDetail"`
+}
+
+type qbSalesItemLineInfo struct {
+	ItemRef    *qbRef  `json:"ItemRef,omitempty"`
+	Qty        float64 `json:"Qty"`
+	UnitPrice  float64 `json:"UnitPrice"`
+	TaxCodeRef *qbRef  `json:"TaxCodeRef,omitempty"`
+}
+
+type qbDocument struct {
+	ID        string `json:"Id"`
+	DocNumber string `json:"DocNumber"`
+}
+
+// Push finds or creates the customer by display name, then creates the
+// estimate or invoice for them.
+func (q *QuickBooks) Push(ctx context.Context, token *Token, doc *Document) (*Result, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	customerID, err := q.customer(ctx, token, doc)
+	if err != nil {
+		return nil, fmt.Errorf("quickbooks customer: %w", err)
+	}
+
+	lines := make([]qbLine, 0, len(doc.Lines))
+	for _, l := range doc.Lines {
+		line := qbLine{
+			DetailType:  "SalesItemLineDetail",
+			Amount:      math.Round(l.Quantity*l.UnitPrice*100) / 100,
+			Description: l.Description,
+			SalesItemLineDetail: qbSalesItemLineInfo{
+				Qty:       l.Quantity,
+				UnitPrice: l.UnitPrice,
+			},
+		}
+		if l.ItemCode != "" {
+			line.SalesItemLineDetail.ItemRef = &qbRef{Value: l.ItemCode}
+		}
+		if doc.TaxCode != "" {
+			line.SalesItemLineDetail.TaxCodeRef = &qbRef{Value: doc.TaxCode}
+		}
+		lines = append(lines, line)
+	}
+	body := map[string]interface{}{
+		"CustomerRef": qbRef{Value: customerID},
+		"DocNumber":   doc.Number,
+		"TxnDate":     doc.Date.Format("2006-01-02"),
+		"Line":        lines,
+	}
+	if doc.Notes != "" {
+		body["CustomerMemo"] = qbRef{Value: doc.Notes}
+	}
+	if doc.Currency != "" {
+		body["CurrencyRef"] = qbRef{Value: doc.Currency}
+	}
+
+	entity := "Invoice"
+	if doc.Kind == DocumentEstimate {
+		entity = "Estimate"
+	}
+	var resp map[string]qbDocument
+	if err := q.oauth.call(ctx, http.MethodPost, q.endpoint(token, strings.ToLower(entity)), token, nil, body, &resp); err != nil {
+		return nil, fmt.Errorf("quickbooks %s: %w", strings.ToLower(entity), err)
+	}
+	created := resp[entity]
+	if created.ID == "" {
+		return nil, fmt.Errorf("quickbooks returned no %s", strings.ToLower(entity))
+	}
+	return &Result{ID: created.ID, Number: created.DocNumber}, nil
+}
+
+// customer returns the ID of the customer with the document's name,
+// creating them if needed.
+func (q *QuickBooks) customer(ctx context.Context, token *Token, doc *Document) (string, error) {
+	name := strings.TrimSpace(doc.CustomerName)
+	query := "select Id from Customer where DisplayName = '" + strings.ReplaceAll(name, "'", `\'`) + "'"
+	var found struct {
+		QueryResponse struct {
+			Customer []qbDocument `json:"Customer"`
+		} `json:"QueryResponse"`
+	}
+	endpoint := q.endpoint(token, "query") + "&query=" + url.QueryEscape(query)
+	if err := q.oauth.call(ctx, http.MethodGet, endpoint, token, nil, nil, &found); err != nil {
+		return "", err
+	}
+	if len(found.QueryResponse.Customer) > 0 {
+		return found.QueryResponse.Customer[0].ID, nil
+	}
+
+	body := map[string]interface{}{"DisplayName": name}
+	if doc.CustomerEmail != "" {
+		body["PrimaryEmailAddr"] = map[string]string{"Address": doc.CustomerEmail}
+	}
+	if doc.CustomerPhone != "" {
+		body["PrimaryPhone"] = map[string]string{"FreeFormNumber": doc.CustomerPhone}
+	}
+	var created struct {
+		Customer qbDocument `json:"Customer"`
+	}
+	if err := q.oauth.call(ctx, http.MethodPost, q.endpoint(token, "customer"), token, nil, body, &created); err != nil {
+		return "", err
+	}
+	if created.Customer.ID == "" {
+		return "", fmt.Errorf("no customer returned")
+	}
+	return created.Customer.ID, nil
+}
+
+func (q *QuickBooks) endpoint(token *Token, path string) string {
+	return q.apiURL + "/" + url.PathEscape(token.TenantID) + "/" + path + "?minorversion=" + quickBooksMinorVersion
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
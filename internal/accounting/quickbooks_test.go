@@ -0,0 +1,158 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+func TestQuickBooks_AuthAndExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, _ := r.BasicAuth(); id != "client" || secret != "secret" {
+			t.Errorf("unexpected client credentials %q/%q", id, secret)
+		}
+		_ = r.ParseForm()
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			if r.Form.Get("code") != "code-1" || r.Form.Get("redirect_uri") != "https://qq.example.com/cb" {
+				t.Errorf("unexpected exchange form %v", r.Form)
+			}
+			w.Write([]byte(`{"access_token":"at-1","refresh_token":"rt-1","expires_in":3600}`))
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != "rt-1" {
+				t.Errorf("unexpected refresh token %q", r.Form.Get("refresh_token"))
+			}
+			w.Write([]byte(`{"access_token":"at-2","refresh_token":"rt-2","expires_in":3600}`))
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	qb := NewQuickBooks(Config{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL}, server.Client(), clock.NewMock(now))
+
+	auth, err := url.Parse(qb.AuthURL("state-1", "https://qq.example.com/cb"))
+	if err != nil {
+		t.Fatalf("parse auth URL: %v", err)
+	}
+	if auth.Host != "appcenter.intuit.com" || auth.Query().Get("state") != "state-1" || auth.Query().Get("scope") != quickBooksScope {
+		t.Errorf("unexpected auth URL %s", auth)
+	}
+
+	if _, err := qb.Exchange(context.Background(), url.Values{"code": {"code-1"}}, "https://qq.example.com/cb"); err == nil {
+		t.Error("expected error without a realmId")
+	}
+	token, err := qb.Exchange(context.Background(), url.Values{"code": {"code-1"}, "realmId": {"123"}}, "https://qq.example.com/cb")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if token.AccessToken != "at-1" || token.TenantID != "123" || !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected token %+v", token)
+	}
+
+	refreshed, err := qb.Refresh(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.AccessToken != "at-2" || refreshed.RefreshToken != "rt-2" || refreshed.TenantID != "123" {
+		t.Errorf("unexpected refreshed token %+v", refreshed)
+	}
+}
+
+func TestQuickBooks_Push(t *testing.T) {
+	var invoice map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/123/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		if q := r.URL.Query().Get("query"); q != `select Id from Customer where DisplayName = 'Jane O\'Neil'` {
+			t.Errorf("unexpected query %q", q)
+		}
+		w.Write([]byte(`{"QueryResponse":{}}`))
+	})
+	mux.HandleFunc("/123/customer", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["DisplayName"] != "Jane O'Neil" {
+			t.Errorf("unexpected customer %v", body)
+		}
+		w.Write([]byte(`{"Customer":{"Id":"58"}}`))
+	})
+	mux.HandleFunc("/123/invoice", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&invoice)
+		w.Write([]byte(`{"Invoice":{"Id":"901","DocNumber":"Q-1"}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	qb := NewQuickBooks(Config{ClientID: "client", ClientSecret: "secret", APIURL: server.URL}, server.Client(), nil)
+	result, err := qb.Push(context.Background(), &Token{AccessToken: "at-1", TenantID: "123"}, &Document{
+		Kind:         DocumentInvoice,
+		Number:       "Q-1",
+		Date:         time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		CustomerName: "Jane O'Neil",
+		Lines:        []Line{{Description: "Deck", Quantity: 2, UnitPrice: 600.125, ItemCode: "7"}},
+		TaxCode:      "TAX",
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if result.ID != "901" || result.Number != "Q-1" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	lines, _ := invoice["Line"].([]interface{})
+	if len(lines) != 1 || invoice["TxnDate"] != "2026-03-01" {
+		t.Fatalf("unexpected invoice %v", invoice)
+	}
+	line := lines[0].(map[string]interface{})
+	detail := line["SalesItemLineDetail"].(map[string]interface{})
+	if line["Amount"] != 1200.25 || detail["ItemRef"].(map[string]interface{})["value"] != "7" || detail["TaxCodeRef"].(map[string]interface{})["value"] != "TAX" {
+		t.Errorf("unexpected line %v", line)
+	}
+}
+
+func TestQuickBooks_PushError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/query") {
+			w.Write([]byte(`{"QueryResponse":{"Customer":[{"Id":"58"}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"Fault":{"Error":[{"Message":"Invalid Reference Id","Detail":"Item 7 does not exist"}]}}`))
+	}))
+	defer server.Close()
+
+	qb := NewQuickBooks(Config{APIURL: server.URL}, server.Client(), nil)
+	_, err := qb.Push(context.Background(), &Token{AccessToken: "at-1", TenantID: "123"}, &Document{
+		Kind:         DocumentEstimate,
+		CustomerName: "Jane",
+		Lines:        []Line{{Description: "Deck", Quantity: 1, UnitPrice: 100, ItemCode: "7"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "Item 7 does not exist") {
+		t.Errorf("expected QuickBooks' error detail, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if c, err := New(Config{}); c != nil || err != nil {
+		t.Errorf("expected no connector when disabled, got %v, %v", c, err)
+	}
+	if _, err := New(Config{Provider: ProviderXero}); err == nil {
+		t.Error("expected error without client credentials")
+	}
+	if _, err := New(Config{Provider: "sage", ClientID: "id", ClientSecret: "secret"}); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	c, err := New(Config{Provider: "QuickBooks", ClientID: "id", ClientSecret: "secret"})
+	if err != nil || c.Name() != ProviderQuickBooks {
+		t.Errorf("expected quickbooks connector, got %v, %v", c, err)
+	}
+}
@@ -0,0 +1,206 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+const (
+	xeroAuthURL  = "https://login.xero.com/identity/connect/authorize"
+	xeroTokenURL = "https://identity.xero.com/connect/token"
+	xeroAPIURL   = "https://api.xero.com"
+	xeroScope    = "offline_access accounting.transactions accounting.contacts"
+)
+
+// Xero creates quotes and invoices in Xero. Documents are created as drafts
+// for staff to approve in Xero.
+type Xero struct {
+	oauth   oauthClient
+	authURL string
+	apiURL  string
+}
+
+// NewXero creates a Xero connector. A client with a 30 second timeout is
+// used when client is nil.
+func NewXero(cfg Config, client *http.Client, clk clock.Clock) *Xero {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Xero{
+		oauth: oauthClient{
+			clientID:     cfg.ClientID,
+			clientSecret: cfg.ClientSecret,
+			tokenURL:     firstNonEmpty(cfg.TokenURL, xeroTokenURL),
+			client:       client,
+			clock:        clk,
+		},
+		authURL: firstNonEmpty(cfg.AuthURL, xeroAuthURL),
+		apiURL:  strings.TrimRight(firstNonEmpty(cfg.APIURL, xeroAPIURL), "/"),
+	}
+}
+
+// Name returns the backend name.
+func (x *Xero) Name() string {
+	return ProviderXero
+}
+
+// AuthURL returns the Xero page where an admin picks an organisation to
+// connect.
+func (x *Xero) AuthURL(state, redirectURL string) string {
+	return x.oauth.authURL(x.authURL, xeroScope, state, redirectURL)
+}
+
+// Exchange trades the callback's code for a token and looks up the
+// organisation that was connected.
+func (x *Xero) Exchange(ctx context.Context, params url.Values, redirectURL string) (*Token, error) {
+	token, err := x.oauth.exchange(ctx, params.Get("code"), redirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("xero: %w", err)
+	}
+
+	var connections []struct {
+		TenantID   string `json:"tenantId"`
+		TenantType string `json:"tenantType"`
+	}
+	if err := x.oauth.call(ctx, http.MethodGet, x.apiURL+"/connections", token, nil, nil, &connections); err != nil {
+		return nil, fmt.Errorf("xero connections: %w", err)
+	}
+	for _, c := range connections {
+		if c.TenantType == "ORGANISATION" {
+			token.TenantID = c.TenantID
+			return token, nil
+		}
+	}
+	return nil, fmt.Errorf("xero: no organisation was connected")
+}
+
+// Refresh obtains a new access token and refresh token.
+func (x *Xero) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	refreshed, err := x.oauth.refresh(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("xero: %w", err)
+	}
+	return refreshed, nil
+}
+
+type xeroLineItem struct {
+	Description string  `json:"Description"`
+	Quantity    float64 `json:"Quantity"`
+	UnitAmount  float64 `json:"UnitAmount"`
+	ItemCode    string  `json:"ItemCode,omitempty"`
+	TaxType     string  `json:"TaxType,omitempty"`
+}
+
+type xeroContact struct {
+	ContactID string `json:"ContactID"`
+}
+
+// Push finds or creates the contact by name, then creates a draft quote or
+// invoice for them.
+func (x *Xero) Push(ctx context.Context, token *Token, doc *Document) (*Result, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	contactID, err := x.contact(ctx, token, doc)
+	if err != nil {
+		return nil, fmt.Errorf("xero contact: %w", err)
+	}
+
+	items := make([]xeroLineItem, 0, len(doc.Lines))
+	for _, l := range doc.Lines {
+		items = append(items, xeroLineItem{
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitAmount:  l.UnitPrice,
+			ItemCode:    l.ItemCode,
+			TaxType:     doc.TaxCode,
+		})
+	}
+	record := map[string]interface{}{
+		"Contact":         xeroContact{ContactID: contactID},
+		"Date":            doc.Date.Format("2006-01-02"),
+		"LineAmountTypes": "Exclusive",
+		"Status":          "DRAFT",
+		"LineItems":       items,
+	}
+	if doc.Currency != "" {
+		record["CurrencyCode"] = doc.Currency
+	}
+
+	collection, idField, numberField := "Invoices", "InvoiceID", "InvoiceNumber"
+	if doc.Kind == DocumentEstimate {
+		collection, idField, numberField = "Quotes", "QuoteID", "QuoteNumber"
+		record["QuoteNumber"] = doc.Number
+		if doc.Notes != "" {
+			record["Terms"] = doc.Notes
+		}
+	} else {
+		record["Type"] = "ACCREC"
+		record["Reference"] = doc.Number
+	}
+
+	var resp map[string][]map[string]interface{}
+	body := map[string]interface{}{collection: []interface{}{record}}
+	if err := x.oauth.call(ctx, http.MethodPost, x.apiURL+"/api.xro/2.0/"+collection, token, x.headers(token), body, &resp); err != nil {
+		return nil, fmt.Errorf("xero %s: %w", strings.ToLower(collection), err)
+	}
+	created := resp[collection]
+	if len(created) == 0 {
+		return nil, fmt.Errorf("xero returned no %s", strings.ToLower(collection))
+	}
+	id, _ := created[0][idField].(string)
+	number, _ := created[0][numberField].(string)
+	if id == "" {
+		return nil, fmt.Errorf("xero returned no %s", strings.ToLower(collection))
+	}
+	return &Result{ID: id, Number: number}, nil
+}
+
+// contact returns the ID of the contact with the document's name, creating
+// them if needed.
+func (x *Xero) contact(ctx context.Context, token *Token, doc *Document) (string, error) {
+	name := strings.TrimSpace(doc.CustomerName)
+	where := `Name=="` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+	var found struct {
+		Contacts []xeroContact `json:"Contacts"`
+	}
+	endpoint := x.apiURL + "/api.xro/2.0/Contacts?where=" + url.QueryEscape(where)
+	if err := x.oauth.call(ctx, http.MethodGet, endpoint, token, x.headers(token), nil, &found); err != nil {
+		return "", err
+	}
+	if len(found.Contacts) > 0 {
+		return found.Contacts[0].ContactID, nil
+	}
+
+	contact := map[string]interface{}{"Name": name}
+	if doc.CustomerEmail != "" {
+		contact["EmailAddress"] = doc.CustomerEmail
+	}
+	if doc.CustomerPhone != "" {
+		contact["Phones"] = []map[string]string{{"PhoneType": "DEFAULT", "PhoneNumber": doc.CustomerPhone}}
+	}
+	var created struct {
+		Contacts []xeroContact `json:"Contacts"`
+	}
+	body := map[string]interface{}{"Contacts": []interface{}{contact}}
+	if err := x.oauth.call(ctx, http.MethodPost, x.apiURL+"/api.xro/2.0/Contacts", token, x.headers(token), body, &created); err != nil {
+		return "", err
+	}
+	if len(created.Contacts) == 0 || created.Contacts[0].ContactID == "" {
+		return "", fmt.Errorf("no contact returned")
+	}
+	return created.Contacts[0].ContactID, nil
+}
+
+func (x *Xero) headers(token *Token) map[string]string {
+	return map[string]string{"Xero-Tenant-Id": token.TenantID}
+}
@@ -0,0 +1,133 @@
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestXero_ExchangeFindsOrganisation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"at-1","refresh_token":"rt-1","expires_in":1800}`))
+	})
+	mux.HandleFunc("/connections", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`[{"tenantId":"practice-1","tenantType":"PRACTICE"},{"tenantId":"org-1","tenantType":"ORGANISATION"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	x := NewXero(Config{ClientID: "client", ClientSecret: "secret", APIURL: server.URL, TokenURL: server.URL + "/token"}, server.Client(), nil)
+	token, err := x.Exchange(context.Background(), url.Values{"code": {"code-1"}}, "https://qq.example.com/cb")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if token.TenantID != "org-1" || token.RefreshToken != "rt-1" {
+		t.Errorf("unexpected token %+v", token)
+	}
+}
+
+func TestXero_PushEstimate(t *testing.T) {
+	var quote map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api.xro/2.0/Contacts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Xero-Tenant-Id") != "org-1" {
+			t.Errorf("unexpected tenant %q", r.Header.Get("Xero-Tenant-Id"))
+		}
+		if r.Method == http.MethodGet {
+			if where := r.URL.Query().Get("where"); where != `Name=="Jane Doe"` {
+				t.Errorf("unexpected where %q", where)
+			}
+			w.Write([]byte(`{"Contacts":[{"ContactID":"c-1"}]}`))
+			return
+		}
+		t.Error("expected the existing contact to be used")
+	})
+	mux.HandleFunc("/api.xro/2.0/Quotes", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Quotes []map[string]interface{} `json:"Quotes"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Quotes) == 1 {
+			quote = body.Quotes[0]
+		}
+		w.Write([]byte(`{"Quotes":[{"QuoteID":"q-1","QuoteNumber":"QU-0042"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	x := NewXero(Config{APIURL: server.URL}, server.Client(), nil)
+	result, err := x.Push(context.Background(), &Token{AccessToken: "at-1", TenantID: "org-1"}, &Document{
+		Kind:         DocumentEstimate,
+		Number:       "Q-1",
+		Date:         time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		CustomerName: "Jane Doe",
+		Lines:        []Line{{Description: "Deck", Quantity: 1, UnitPrice: 1200, ItemCode: "DECK"}},
+		TaxCode:      "OUTPUT",
+		Currency:     "NZD",
+		Notes:        "Valid 30 days",
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if result.ID != "q-1" || result.Number != "QU-0042" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if quote["QuoteNumber"] != "Q-1" || quote["Status"] != "DRAFT" || quote["Terms"] != "Valid 30 days" || quote["CurrencyCode"] != "NZD" {
+		t.Errorf("unexpected quote %v", quote)
+	}
+	item := quote["LineItems"].([]interface{})[0].(map[string]interface{})
+	if item["ItemCode"] != "DECK" || item["TaxType"] != "OUTPUT" || item["UnitAmount"] != 1200.0 {
+		t.Errorf("unexpected line item %v", item)
+	}
+}
+
+func TestXero_PushCreatesContact(t *testing.T) {
+	created := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api.xro/2.0/Contacts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"Contacts":[]}`))
+			return
+		}
+		created = true
+		w.Write([]byte(`{"Contacts":[{"ContactID":"c-2"}]}`))
+	})
+	mux.HandleFunc("/api.xro/2.0/Invoices", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Invoices []struct {
+				Type      string
+				Reference string
+				Contact   struct{ ContactID string }
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Invoices) != 1 || body.Invoices[0].Type != "ACCREC" || body.Invoices[0].Contact.ContactID != "c-2" || body.Invoices[0].Reference != "Q-2" {
+			t.Errorf("unexpected invoice %+v", body)
+		}
+		w.Write([]byte(`{"Invoices":[{"InvoiceID":"i-1","InvoiceNumber":"INV-0007"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	x := NewXero(Config{APIURL: server.URL}, server.Client(), nil)
+	result, err := x.Push(context.Background(), &Token{AccessToken: "at-1", TenantID: "org-1"}, &Document{
+		Kind:         DocumentInvoice,
+		Number:       "Q-2",
+		CustomerName: "New Customer",
+		Lines:        []Line{{Description: "Fence", Quantity: 1, UnitPrice: 500}},
+	})
+	if err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	if !created || result.Number != "INV-0007" {
+		t.Errorf("expected a new contact and invoice, got created=%v %+v", created, result)
+	}
+}
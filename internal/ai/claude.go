@@ -2,27 +2,38 @@
 package ai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 	"github.com/jkindrix/quickquote/internal/config"
 	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/tracing"
 )
 
 // ClaudeClient handles communication with the Anthropic API.
 type ClaudeClient struct {
+	mu             sync.RWMutex
 	apiKey         string
 	model          string
 	httpClient     *http.Client
 	circuitBreaker *circuitbreaker.CircuitBreaker
+	pricing        metrics.ModelPricing
+	metrics        *metrics.Metrics
 	logger         *zap.Logger
 }
 
@@ -43,15 +54,46 @@ func NewClaudeClient(cfg *config.AnthropicConfig, logger *zap.Logger) *ClaudeCli
 			Timeout: 60 * time.Second,
 		},
 		circuitBreaker: circuitbreaker.New("claude-api", cbConfig, logger),
-		logger:         logger,
+		pricing: metrics.ModelPricing{
+			InputPerMTok:  cfg.InputCostPerMTok,
+			OutputPerMTok: cfg.OutputCostPerMTok,
+		},
+		logger: logger,
 	}
 }
 
+// SetMetrics sets the collector for API call latency and token usage.
+func (c *ClaudeClient) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
+}
+
+// SetHTTPClient replaces the client used for API calls, such as one built
+// from the configured timeouts, retries and proxy.
+func (c *ClaudeClient) SetHTTPClient(client *http.Client) {
+	c.httpClient = client
+}
+
+// SetAPIKey replaces the API key used for requests, such as after the key
+// is rotated. Requests already sent keep the old key.
+func (c *ClaudeClient) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = key
+}
+
+// key returns the current API key.
+func (c *ClaudeClient) key() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey
+}
+
 // ClaudeRequest represents a request to the Claude API.
 type ClaudeRequest struct {
 	Model     string          `json:"model"`
 	MaxTokens int             `json:"max_tokens"`
 	Messages  []ClaudeMessage `json:"messages"`
+	Stream    bool            `json:"stream,omitempty"`
 }
 
 // ClaudeMessage represents a message in a Claude conversation.
@@ -103,11 +145,45 @@ func (c *ClaudeClient) GenerateQuote(ctx context.Context, transcript string, ext
 	return response, nil
 }
 
+// GenerateQuoteStream generates a quote summary like GenerateQuote, calling
+// onText with each chunk of text as Claude produces it. It returns the full
+// quote once the response is complete.
+func (c *ClaudeClient) GenerateQuoteStream(ctx context.Context, transcript string, extractedData *domain.ExtractedData, onText func(string)) (string, error) {
+	prompt := buildQuotePrompt(transcript, extractedData)
+
+	c.logger.Debug("streaming quote with Claude",
+		zap.Int("transcript_length", len(transcript)),
+	)
+
+	ctx, span := c.startSpan(ctx, true)
+	defer span.End()
+
+	var result string
+	start := time.Now()
+	err := c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
+		var execErr error
+		result, execErr = c.doStreamMessage(ctx, prompt, onText)
+		return execErr
+	})
+	c.recordCall(start, err)
+	tracing.RecordError(span, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate quote: %w", err)
+	}
+
+	return result, nil
+}
+
 // CircuitBreakerStats returns the current circuit breaker statistics.
 func (c *ClaudeClient) CircuitBreakerStats() circuitbreaker.Stats {
 	return c.circuitBreaker.Stats()
 }
 
+// CircuitBreaker returns the breaker guarding the Claude API.
+func (c *ClaudeClient) CircuitBreaker() *circuitbreaker.CircuitBreaker {
+	return c.circuitBreaker
+}
+
 // IsCircuitOpen returns true if the circuit breaker is open.
 func (c *ClaudeClient) IsCircuitOpen() bool {
 	return c.circuitBreaker.IsOpen()
@@ -121,13 +197,19 @@ func (c *ClaudeClient) ResetCircuitBreaker() {
 
 // sendMessage sends a message to Claude and returns the response text.
 func (c *ClaudeClient) sendMessage(ctx context.Context, message string) (string, error) {
+	ctx, span := c.startSpan(ctx, false)
+	defer span.End()
+
 	var result string
 
+	start := time.Now()
 	err := c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
 		var execErr error
 		result, execErr = c.doSendMessage(ctx, message)
 		return execErr
 	})
+	c.recordCall(start, err)
+	tracing.RecordError(span, err)
 
 	if err != nil {
 		return "", err
@@ -160,7 +242,7 @@ func (c *ClaudeClient) doSendMessage(ctx context.Context, message string) (strin
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("x-api-key", c.key())
 	req.Header.Set("anthropic-version", "2023-06-01")
 
 	resp, err := c.httpClient.Do(req)
@@ -191,6 +273,7 @@ func (c *ClaudeClient) doSendMessage(ctx context.Context, message string) (strin
 		return "", fmt.Errorf("empty response from Claude")
 	}
 
+	c.recordUsage(ctx, claudeResp.Usage.InputTokens, claudeResp.Usage.OutputTokens)
 	c.logger.Debug("quote generated",
 		zap.Int("input_tokens", claudeResp.Usage.InputTokens),
 		zap.Int("output_tokens", claudeResp.Usage.OutputTokens),
@@ -199,29 +282,185 @@ func (c *ClaudeClient) doSendMessage(ctx context.Context, message string) (strin
 	return claudeResp.Content[0].Text, nil
 }
 
-// buildQuotePrompt constructs the prompt for generating a quote.
-func buildQuotePrompt(transcript string, extractedData *domain.ExtractedData) string {
-	var context string
-	if extractedData != nil {
-		if extractedData.ProjectType != "" {
-			context += fmt.Sprintf("- Project Type: %s\n", extractedData.ProjectType)
-		}
-		if extractedData.Requirements != "" {
-			context += fmt.Sprintf("- Requirements: %s\n", extractedData.Requirements)
-		}
-		if extractedData.Timeline != "" {
-			context += fmt.Sprintf("- Timeline: %s\n", extractedData.Timeline)
+// startSpan starts a client span for a Messages API request.
+func (c *ClaudeClient) startSpan(ctx context.Context, stream bool) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "chat "+c.model,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("gen_ai.system", "anthropic"),
+			attribute.String("gen_ai.operation.name", "chat"),
+			attribute.String("gen_ai.request.model", c.model),
+			attribute.Bool("gen_ai.request.stream", stream),
+		),
+	)
+}
+
+// recordCall records the outcome and duration of an API call.
+func (c *ClaudeClient) recordCall(start time.Time, err error) {
+	if c.metrics == nil {
+		return
+	}
+	if errors.Is(err, circuitbreaker.ErrCircuitOpen) {
+		c.metrics.ClaudeAPICallsTotal.WithLabelValues("circuit_open").Inc()
+		return
+	}
+	c.metrics.RecordClaudeAPICall(err == nil, time.Since(start))
+}
+
+// recordUsage records the tokens used by an API call and their estimated
+// cost, both globally and against any TokenUsage carried by ctx, and notes
+// them on the request's span.
+func (c *ClaudeClient) recordUsage(ctx context.Context, inputTokens, outputTokens int) {
+	cost := c.pricing.Cost(inputTokens, outputTokens)
+	metrics.AddTokenUsage(ctx, inputTokens, outputTokens, cost)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("gen_ai.usage.input_tokens", inputTokens),
+		attribute.Int("gen_ai.usage.output_tokens", outputTokens),
+	)
+	if c.metrics != nil {
+		c.metrics.RecordClaudeUsage(inputTokens, outputTokens, cost)
+	}
+}
+
+// doStreamMessage performs a streaming request to the Claude API.
+func (c *ClaudeClient) doStreamMessage(ctx context.Context, message string, onText func(string)) (string, error) {
+	reqBody := ClaudeRequest{
+		Model:     c.model,
+		MaxTokens: 2048,
+		Messages: []ClaudeMessage{
+			{
+				Role:    "user",
+				Content: message,
+			},
+		},
+		Stream: true,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewReader(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("x-api-key", c.key())
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		var errResp ClaudeError
+		if err := json.Unmarshal(body, &errResp); err == nil {
+			return "", fmt.Errorf("Claude API error: %s - %s", errResp.Error.Type, errResp.Error.Message)
 		}
-		if extractedData.BudgetRange != "" {
-			context += fmt.Sprintf("- Budget Range: %s\n", extractedData.BudgetRange)
+		return "", fmt.Errorf("Claude API error: status %d", resp.StatusCode)
+	}
+
+	text, usage, err := readMessageStream(resp.Body, onText)
+	// Tokens are billed even when the stream fails part way through.
+	c.recordUsage(ctx, usage.InputTokens, usage.OutputTokens)
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug("quote streamed",
+		zap.Int("input_tokens", usage.InputTokens),
+		zap.Int("output_tokens", usage.OutputTokens),
+	)
+
+	return text, nil
+}
+
+// streamUsage holds the token counts reported during a streamed response.
+type streamUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// streamEvent is the subset of a Claude server-sent event that we use.
+type streamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Usage streamUsage `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage streamUsage `json:"usage"`
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// readMessageStream reads Claude server-sent events from r, passing each text
+// delta to onText, and returns the assembled text.
+func readMessageStream(r io.Reader, onText func(string)) (string, streamUsage, error) {
+	var (
+		text    strings.Builder
+		usage   streamUsage
+		stopped bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
 		}
-		if extractedData.ContactPreference != "" {
-			context += fmt.Sprintf("- Contact Preference: %s\n", extractedData.ContactPreference)
+
+		var event streamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			return "", usage, fmt.Errorf("failed to parse stream event: %w", err)
 		}
-		if extractedData.CallerName != "" {
-			context += fmt.Sprintf("- Caller Name: %s\n", extractedData.CallerName)
+
+		switch event.Type {
+		case "message_start":
+			usage.InputTokens = event.Message.Usage.InputTokens
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" && event.Delta.Text != "" {
+				text.WriteString(event.Delta.Text)
+				if onText != nil {
+					onText(event.Delta.Text)
+				}
+			}
+		case "message_delta":
+			usage.OutputTokens = event.Usage.OutputTokens
+		case "message_stop":
+			stopped = true
+		case "error":
+			return "", usage, fmt.Errorf("Claude API error: %s - %s", event.Error.Type, event.Error.Message)
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return "", usage, fmt.Errorf("failed to read stream: %w", err)
+	}
+
+	if !stopped {
+		return "", usage, errors.New("stream ended before message was complete")
+	}
+	if text.Len() == 0 {
+		return "", usage, fmt.Errorf("empty response from Claude")
+	}
+
+	return text.String(), usage, nil
+}
+
+// buildQuotePrompt constructs the prompt for generating a quote.
+func buildQuotePrompt(transcript string, extractedData *domain.ExtractedData) string {
+	context := describeExtractedData(extractedData)
 
 	prompt := `You are a professional quote generator for a services business. Based on the following phone call transcript, generate a clear and professional quote summary.
 
@@ -229,13 +468,26 @@ The quote summary should include:
 1. **Project Overview** - A brief summary of what the caller is looking for
 2. **Key Requirements** - Bullet points of the main requirements discussed
 3. **Timeline** - The discussed or recommended timeline
-4. **Budget Considerations** - Any budget information mentioned, or a general range if not specified
+4. **Budget Considerations** - Any budget information the caller mentioned
 5. **Next Steps** - Recommended actions for both parties
 6. **Notes** - Any important details or concerns from the conversation
 
 Keep the tone professional but friendly. Be specific where possible, but if information is missing, note that it needs to be clarified.
+
+Do not state any prices, rates, or cost estimates. Pricing is calculated separately from the business's rates and added below your summary.
 `
 
+	// Quotes for calls held in another language are written in it, and
+	// quotes are priced in the deployment's currency
+	if extractedData != nil {
+		if base := domain.BaseLanguage(extractedData.Language); base != "" && base != "en" {
+			prompt += fmt.Sprintf("\nThe call was held in the language with tag %s. Write the quote summary, including its headings, in that language.\n", extractedData.Language)
+		}
+		if currency := domain.NormalizeCurrency(extractedData.Currency); currency != "" && currency != domain.DefaultCurrency {
+			prompt += fmt.Sprintf("\nQuote every amount in %s (ISO 4217), not US dollars.\n", currency)
+		}
+	}
+
 	if context != "" {
 		prompt += fmt.Sprintf("\n**Extracted Information:**\n%s\n", context)
 	}
@@ -244,3 +496,30 @@ Keep the tone professional but friendly. Be specific where possible, but if info
 
 	return prompt
 }
+
+// describeExtractedData lists the details a voice provider extracted from a
+// call, for inclusion in a prompt.
+func describeExtractedData(extractedData *domain.ExtractedData) string {
+	var context string
+	if extractedData != nil {
+		if extractedData.ProjectType != "" {
+			context += fmt.Sprintf("- Project Type: %s\n", extractedData.ProjectType)
+		}
+		if extractedData.Requirements != "" {
+			context += fmt.Sprintf("- Requirements: %s\n", extractedData.Requirements)
+		}
+		if extractedData.Timeline != "" {
+			context += fmt.Sprintf("- Timeline: %s\n", extractedData.Timeline)
+		}
+		if extractedData.BudgetRange != "" {
+			context += fmt.Sprintf("- Budget Range: %s\n", extractedData.BudgetRange)
+		}
+		if extractedData.ContactPreference != "" {
+			context += fmt.Sprintf("- Contact Preference: %s\n", extractedData.ContactPreference)
+		}
+		if extractedData.CallerName != "" {
+			context += fmt.Sprintf("- Caller Name: %s\n", extractedData.CallerName)
+		}
+	}
+	return context
+}
@@ -9,10 +9,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 	"github.com/jkindrix/quickquote/internal/config"
 	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/metrics"
 )
 
 func TestNewClaudeClient(t *testing.T) {
@@ -187,6 +191,30 @@ func TestBuildQuotePrompt_PartialExtractedData(t *testing.T) {
 	}
 }
 
+func TestBuildQuotePrompt_Language(t *testing.T) {
+	prompt := buildQuotePrompt("Hola, necesito una tienda en línea.", &domain.ExtractedData{Language: "es-MX"})
+	if !strings.Contains(prompt, "es-MX") {
+		t.Error("expected the call's language in prompt")
+	}
+
+	prompt = buildQuotePrompt("Hello, I need a website.", &domain.ExtractedData{Language: "en-GB"})
+	if strings.Contains(prompt, "en-GB") {
+		t.Error("did not expect a language instruction for English calls")
+	}
+}
+
+func TestBuildQuotePrompt_Currency(t *testing.T) {
+	prompt := buildQuotePrompt("I need a website.", &domain.ExtractedData{Currency: "eur"})
+	if !strings.Contains(prompt, "amount in EUR") {
+		t.Error("expected the quote's currency in prompt")
+	}
+
+	prompt = buildQuotePrompt("I need a website.", &domain.ExtractedData{Currency: "USD"})
+	if strings.Contains(prompt, "ISO 4217") {
+		t.Error("did not expect a currency instruction for US dollars")
+	}
+}
+
 func TestClaudeRequest_JSONMarshal(t *testing.T) {
 	req := ClaudeRequest{
 		Model:     "claude-3-sonnet-20240229",
@@ -376,3 +404,131 @@ func TestClaudeClient_ContextCancellation(t *testing.T) {
 		t.Error("expected error with cancelled context")
 	}
 }
+
+func TestReadMessageStream(t *testing.T) {
+	stream := `event: message_start
+data: {"type":"message_start","message":{"id":"msg_123","usage":{"input_tokens":25,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}
+
+event: ping
+data: {"type": "ping"}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"**Project"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":" Overview**\n"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":15}}
+
+event: message_stop
+data: {"type":"message_stop"}
+`
+
+	var chunks []string
+	text, usage, err := readMessageStream(strings.NewReader(stream), func(s string) {
+		chunks = append(chunks, s)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "**Project Overview**\n" {
+		t.Errorf("unexpected text %q", text)
+	}
+	if len(chunks) != 2 {
+		t.Errorf("expected 2 chunks, got %d", len(chunks))
+	}
+	if usage.InputTokens != 25 || usage.OutputTokens != 15 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+}
+
+func TestReadMessageStream_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+	}{
+		{
+			name: "error event",
+			stream: `event: error
+data: {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+`,
+		},
+		{
+			name: "truncated",
+			stream: `event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hello"}}
+`,
+		},
+		{
+			name: "malformed",
+			stream: `data: {not json
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := readMessageStream(strings.NewReader(tt.stream), nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestClaudeRequest_StreamFlag(t *testing.T) {
+	data, _ := json.Marshal(ClaudeRequest{Model: "m", MaxTokens: 1})
+	if strings.Contains(string(data), "stream") {
+		t.Errorf("expected stream to be omitted, got %s", data)
+	}
+	data, _ = json.Marshal(ClaudeRequest{Model: "m", MaxTokens: 1, Stream: true})
+	if !strings.Contains(string(data), `"stream":true`) {
+		t.Errorf("expected stream flag, got %s", data)
+	}
+}
+
+func TestClaudeClient_RecordUsage(t *testing.T) {
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	client := NewClaudeClient(&config.AnthropicConfig{
+		APIKey:            "test-api-key",
+		InputCostPerMTok:  3,
+		OutputCostPerMTok: 15,
+	}, zap.NewNop())
+	client.SetMetrics(m)
+
+	ctx, usage := metrics.WithTokenUsage(context.Background())
+	client.recordUsage(ctx, 1000, 200)
+
+	got := usage.Snapshot()
+	if got.InputTokens != 1000 || got.OutputTokens != 200 {
+		t.Errorf("unexpected usage %+v", got)
+	}
+	if got.Cost < 0.00599 || got.Cost > 0.00601 {
+		t.Errorf("cost = %f, expected 0.006", got.Cost)
+	}
+	if v := testutil.ToFloat64(m.ClaudeTokensTotal.WithLabelValues("output")); v != 200 {
+		t.Errorf("output tokens = %f, expected 200", v)
+	}
+}
+
+func TestClaudeClient_RecordCall_CircuitOpen(t *testing.T) {
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	client := NewClaudeClient(&config.AnthropicConfig{APIKey: "test-api-key"}, zap.NewNop())
+	client.SetMetrics(m)
+
+	client.recordCall(time.Now(), circuitbreaker.ErrCircuitOpen)
+	client.recordCall(time.Now(), nil)
+
+	if v := testutil.ToFloat64(m.ClaudeAPICallsTotal.WithLabelValues("circuit_open")); v != 1 {
+		t.Errorf("circuit_open count = %f, expected 1", v)
+	}
+	if v := testutil.ToFloat64(m.ClaudeAPICallsTotal.WithLabelValues("success")); v != 1 {
+		t.Errorf("success count = %f, expected 1", v)
+	}
+}
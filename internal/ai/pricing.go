@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// ExtractPricingInputs asks Claude how many of each of rule's units the
+// call's project involves and which of its multipliers apply. Claude only
+// reports facts from the call; the price is computed from the rule.
+func (c *ClaudeClient) ExtractPricingInputs(ctx context.Context, transcript string, extractedData *domain.ExtractedData, rule *domain.PricingRule) (*domain.PricingInputs, error) {
+	if len(rule.UnitRates) == 0 && len(rule.Multipliers) == 0 {
+		return &domain.PricingInputs{}, nil
+	}
+
+	response, err := c.sendMessage(ctx, buildPricingPrompt(transcript, extractedData, rule))
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract pricing inputs: %w", err)
+	}
+
+	return parsePricingInputs(response, rule)
+}
+
+// buildPricingPrompt constructs the prompt for extracting pricing inputs.
+func buildPricingPrompt(transcript string, extractedData *domain.ExtractedData, rule *domain.PricingRule) string {
+	var b strings.Builder
+	b.WriteString(`You extract pricing facts from phone call transcripts for a services business. Do not estimate prices; only report what the call establishes about the project.
+`)
+
+	if len(rule.UnitRates) > 0 {
+		b.WriteString("\n**Units of work** - how many of each the project involves. Use your best estimate from the requirements discussed, or 0 if the call gives no indication:\n")
+		for _, u := range rule.UnitRates {
+			fmt.Fprintf(&b, "- %s: %s\n", u.Key, u.Label)
+		}
+	}
+	if len(rule.Multipliers) > 0 {
+		b.WriteString("\n**Conditions** - list only those the caller clearly asked for or described:\n")
+		for _, m := range rule.Multipliers {
+			fmt.Fprintf(&b, "- %s: %s\n", m.Key, m.Label)
+		}
+	}
+
+	example := struct {
+		Quantities  map[string]int `json:"quantities"`
+		Multipliers []string       `json:"multipliers"`
+	}{Quantities: map[string]int{}, Multipliers: []string{}}
+	for _, u := range rule.UnitRates {
+		example.Quantities[u.Key] = 0
+	}
+	exampleJSON, _ := json.Marshal(example)
+	fmt.Fprintf(&b, "\nRespond with only a JSON object in this form, with no other text:\n%s\n", exampleJSON)
+
+	if details := describeExtractedData(extractedData); details != "" {
+		fmt.Fprintf(&b, "\n**Extracted Information:**\n%s", details)
+	}
+	fmt.Fprintf(&b, "\n**Call Transcript:**\n%s\n", transcript)
+
+	return b.String()
+}
+
+// parsePricingInputs reads the JSON object in Claude's response, keeping only
+// the rule's keys and sensible quantities.
+func parsePricingInputs(response string, rule *domain.PricingRule) (*domain.PricingInputs, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("pricing response contained no JSON object")
+	}
+
+	var raw struct {
+		Quantities  map[string]float64 `json:"quantities"`
+		Multipliers []string           `json:"multipliers"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing response: %w", err)
+	}
+
+	inputs := &domain.PricingInputs{}
+	for _, u := range rule.UnitRates {
+		qty, ok := raw.Quantities[u.Key]
+		if !ok || qty <= 0 || math.IsNaN(qty) || math.IsInf(qty, 0) {
+			continue
+		}
+		if inputs.Quantities == nil {
+			inputs.Quantities = make(map[string]float64)
+		}
+		inputs.Quantities[u.Key] = qty
+	}
+
+	requested := make(map[string]bool)
+	for _, key := range raw.Multipliers {
+		requested[strings.TrimSpace(key)] = true
+	}
+	for _, m := range rule.Multipliers {
+		if requested[m.Key] {
+			inputs.Multipliers = append(inputs.Multipliers, m.Key)
+		}
+	}
+
+	return inputs, nil
+}
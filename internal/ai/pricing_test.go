@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+func testPricingRule() *domain.PricingRule {
+	rule := domain.NewPricingRule("default", "Project setup", 2500)
+	rule.UnitRates = []domain.PricingUnitRate{{Key: "page", Label: "Pages", Rate: 750}}
+	rule.Multipliers = []domain.PricingMultiplier{{Key: "rush", Label: "Rush delivery", Factor: 1.25}}
+	return rule
+}
+
+func TestBuildPricingPrompt(t *testing.T) {
+	prompt := buildPricingPrompt("I need a five page site by Friday.", &domain.ExtractedData{ProjectType: "Website"}, testPricingRule())
+
+	for _, want := range []string{
+		"- page: Pages",
+		"- rush: Rush delivery",
+		`{"quantities":{"page":0},"multipliers":[]}`,
+		"Project Type: Website",
+		"I need a five page site by Friday.",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "750") {
+		t.Error("prompt should not reveal rates to the model")
+	}
+}
+
+func TestParsePricingInputs(t *testing.T) {
+	response := "Here you go:\n```json\n" +
+		`{"quantities": {"page": 5, "logo": 2}, "multipliers": ["rush", "weekend"]}` +
+		"\n```"
+
+	inputs, err := parsePricingInputs(response, testPricingRule())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inputs.Quantities) != 1 || inputs.Quantities["page"] != 5 {
+		t.Errorf("Quantities = %v, want only page=5", inputs.Quantities)
+	}
+	if len(inputs.Multipliers) != 1 || inputs.Multipliers[0] != "rush" {
+		t.Errorf("Multipliers = %v, want [rush]", inputs.Multipliers)
+	}
+}
+
+func TestParsePricingInputs_DropsNegativeQuantities(t *testing.T) {
+	inputs, err := parsePricingInputs(`{"quantities": {"page": -3}}`, testPricingRule())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inputs.Quantities) != 0 || len(inputs.Multipliers) != 0 {
+		t.Errorf("expected empty inputs, got %+v", inputs)
+	}
+}
+
+func TestParsePricingInputs_NoJSON(t *testing.T) {
+	if _, err := parsePricingInputs("I cannot determine that.", testPricingRule()); err == nil {
+		t.Error("expected error for a response without JSON")
+	}
+}
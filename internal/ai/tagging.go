@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// ClassifyCall asks Claude for the caller's sentiment, why they called, how
+// urgently they need a response and how the call ended.
+func (c *ClaudeClient) ClassifyCall(ctx context.Context, transcript string) (*domain.CallTags, error) {
+	response, err := c.sendMessage(ctx, buildTaggingPrompt(transcript))
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify call: %w", err)
+	}
+
+	tags, err := parseCallTags(response)
+	if err != nil {
+		return nil, err
+	}
+	tags.TaggedAt = time.Now().UTC()
+	return tags, nil
+}
+
+// buildTaggingPrompt constructs the prompt for classifying a call.
+func buildTaggingPrompt(transcript string) string {
+	return fmt.Sprintf(`You classify phone calls to a services business from their transcripts.
+
+Classify the caller, not the business's agent:
+- sentiment: how the caller came across - positive, neutral or negative
+- intent: why they called - new_project (they want work done), pricing_question (they only asked about prices or rates), complaint (about work done or service received), spam (robocalls, sales pitches, wrong numbers) or other
+- urgency: how soon they need a response - low, medium or high (an emergency, or a deadline within days)
+- disposition: how the call ended - quoted (the caller was given or promised a quote), callback_scheduled (a time was agreed to call back), not_interested, wrong_number, voicemail (no one answered and a message was left), spam, or none if it is unclear
+
+Respond with only a JSON object in this form, with no other text:
+{"sentiment": "neutral", "intent": "new_project", "urgency": "medium", "disposition": "quoted"}
+
+**Call Transcript:**
+%s
+`, transcript)
+}
+
+// parseCallTags reads the JSON object in Claude's response. An intent
+// outside the known ones is tagged other and an unknown disposition is left
+// empty; any other unknown value is an error.
+func parseCallTags(response string) (*domain.CallTags, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, errors.New("classification response contained no JSON object")
+	}
+
+	var raw struct {
+		Sentiment   string `json:"sentiment"`
+		Intent      string `json:"intent"`
+		Urgency     string `json:"urgency"`
+		Disposition string `json:"disposition"`
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse classification response: %w", err)
+	}
+
+	sentiment, err := domain.ParseCallSentiment(raw.Sentiment)
+	if err != nil {
+		return nil, err
+	}
+	urgency, err := domain.ParseCallUrgency(raw.Urgency)
+	if err != nil {
+		return nil, err
+	}
+	intent, err := domain.ParseCallIntent(raw.Intent)
+	if err != nil {
+		intent = domain.CallIntentOther
+	}
+	// "none" and anything unknown leave the disposition to other sources
+	disposition, _ := domain.ParseCallDisposition(raw.Disposition)
+
+	return &domain.CallTags{
+		Sentiment:   sentiment,
+		Intent:      intent,
+		Urgency:     urgency,
+		Disposition: disposition,
+	}, nil
+}
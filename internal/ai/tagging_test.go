@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+func TestBuildTaggingPrompt(t *testing.T) {
+	prompt := buildTaggingPrompt("My basement is flooding, can someone come today?")
+
+	for _, want := range []string{
+		"pricing_question",
+		"callback_scheduled",
+		`{"sentiment": "neutral", "intent": "new_project", "urgency": "medium", "disposition": "quoted"}`,
+		"My basement is flooding, can someone come today?",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestParseCallTags(t *testing.T) {
+	response := "```json\n" + `{"sentiment": "Negative", "intent": "Pricing Question", "urgency": "HIGH", "disposition": "Not Interested"}` + "\n```"
+
+	tags, err := parseCallTags(response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags.Sentiment != domain.CallSentimentNegative || tags.Intent != domain.CallIntentPricingQuestion || tags.Urgency != domain.CallUrgencyHigh ||
+		tags.Disposition != domain.CallDispositionNotInterested {
+		t.Errorf("tags = %+v", tags)
+	}
+}
+
+func TestParseCallTags_UnknownIntentIsOther(t *testing.T) {
+	tags, err := parseCallTags(`{"sentiment": "neutral", "intent": "job_application", "urgency": "low"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags.Intent != domain.CallIntentOther {
+		t.Errorf("Intent = %q, want other", tags.Intent)
+	}
+	if tags.Disposition != "" {
+		t.Errorf("Disposition = %q, want none without one in the response", tags.Disposition)
+	}
+}
+
+func TestParseCallTags_NoDisposition(t *testing.T) {
+	tags, err := parseCallTags(`{"sentiment": "neutral", "intent": "other", "urgency": "low", "disposition": "none"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags.Disposition != "" {
+		t.Errorf("Disposition = %q, want none", tags.Disposition)
+	}
+}
+
+func TestParseCallTags_Invalid(t *testing.T) {
+	for _, response := range []string{
+		"I cannot classify this call.",
+		`{"sentiment": "ecstatic", "intent": "spam", "urgency": "low"}`,
+		`{"sentiment": "neutral", "intent": "spam"}`,
+	} {
+		if _, err := parseCallTags(response); err == nil {
+			t.Errorf("expected error for %q", response)
+		}
+	}
+}
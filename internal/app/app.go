@@ -0,0 +1,480 @@
+// Package app wires QuickQuote together: it builds the repositories,
+// services, workers and HTTP routes from configuration and manages their
+// lifecycle, so the server, tools and integration tests share one setup.
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/grpcapi"
+	"github.com/jkindrix/quickquote/internal/jobs"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/shutdown"
+	"github.com/jkindrix/quickquote/internal/tracing"
+)
+
+// App is a fully wired QuickQuote server.
+type App struct {
+	Config   *config.Config
+	Logger   *zap.Logger
+	Metrics  *metrics.Metrics
+	DB       *database.DB
+	Services Services
+	Mode     Mode
+	// Handler serves every HTTP route, or in worker mode only the health
+	// checks and metrics; Server serves it on the configured address.
+	Handler http.Handler
+	Server  *http.Server
+	// GRPC is nil unless the gRPC API is enabled and the mode serves routes.
+	GRPC *grpcapi.Server
+	Jobs *jobs.Scheduler
+	// Leader chooses which instance runs the singleton background jobs.
+	Leader   *database.LeaderElector
+	Reloader *config.Reloader
+	// Shutdown stops the app in phases; see Stop.
+	Shutdown *shutdown.Coordinator
+
+	startHooks []startHook
+}
+
+// Services are the services other entry points, such as command-line
+// tools, are most likely to need.
+type Services struct {
+	Auth    *service.AuthService
+	Users   *service.UserService
+	APIKeys *service.APIKeyService
+	Calls   *service.CallService
+	Quotes  *service.QuoteService
+	Prompts *service.PromptService
+}
+
+type startHook struct {
+	name   string
+	fn     func(ctx context.Context) error
+	worker bool // Skipped in server mode
+}
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	logLevel zap.AtomicLevel
+	metrics  *metrics.Metrics
+	mode     Mode
+	rootDir  string
+	version  string
+}
+
+// WithLogLevel sets the level that /admin/log-level adjusts. It should be
+// the level of the logger passed to New.
+func WithLogLevel(level zap.AtomicLevel) Option {
+	return func(o *options) { o.logLevel = level }
+}
+
+// WithMetrics sets the metrics to record to. By default they are
+// registered with the default Prometheus registry, which can only be done
+// once per process.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(o *options) { o.metrics = m }
+}
+
+// WithMode sets which parts of the app run. It defaults to ModeAll.
+func WithMode(mode Mode) Option {
+	return func(o *options) { o.mode = mode }
+}
+
+// WithRootDir sets the directory holding migrations/ and web/. It defaults
+// to the working directory.
+func WithRootDir(dir string) Option {
+	return func(o *options) { o.rootDir = dir }
+}
+
+// WithVersion sets the build version, used to version static assets.
+func WithVersion(version string) Option {
+	return func(o *options) { o.version = version }
+}
+
+// New connects to the database, applies migrations and wires every
+// repository, service, worker and route. Nothing runs in the background
+// until Start. ctx is used by setup and by callbacks for configuration and
+// provider key changes, so it should last as long as the app.
+func New(ctx context.Context, cfg *config.Config, logger *zap.Logger, opts ...Option) (_ *App, retErr error) {
+	o := options{
+		logLevel: zap.NewAtomicLevelAt(zap.InfoLevel),
+		mode:     ModeAll,
+		rootDir:  ".",
+		version:  "dev",
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	a := &App{Logger: logger, Mode: o.mode}
+	configReloader := config.NewReloader(cfg)
+
+	appMetrics := o.metrics
+	if appMetrics == nil {
+		appMetrics = metrics.NewMetrics()
+	}
+
+	// Initialize tracing (disabled unless an OTLP endpoint is configured)
+	tracerProvider, err := tracing.NewProvider(&cfg.Tracing, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+	if tracerProvider != nil {
+		tracing.SetProvider(tracerProvider)
+		logger.Info("tracing enabled",
+			zap.String("endpoint", cfg.Tracing.Endpoint),
+			zap.String("service_name", cfg.Tracing.ServiceName),
+			zap.Float64("sample_ratio", cfg.Tracing.SampleRatio),
+		)
+	}
+
+	logger.Info("starting QuickQuote server",
+		zap.String("mode", string(o.mode)),
+		zap.String("host", cfg.Server.Host),
+		zap.Int("port", cfg.Server.Port),
+		zap.String("env", cfg.Server.Environment),
+	)
+
+	// Initialize database with query logging
+	var queryLoggerCfg *database.QueryLoggerConfig
+	if cfg.Database.SlowQueryThreshold > 0 {
+		queryLoggerCfg = &database.QueryLoggerConfig{
+			SlowQueryThreshold:     cfg.Database.SlowQueryThreshold,
+			VerySlowQueryThreshold: cfg.Database.VerySlowQueryThreshold,
+			LogAllQueries:          cfg.Database.LogAllQueries,
+			SampleRate:             0.1, // Sample 10% of queries when logging all
+		}
+	}
+	db, err := database.NewWithQueryLogger(ctx, &cfg.Database, queryLoggerCfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	// Note: db.Close() is handled by shutdown coordinator once the app is
+	// built; until then a failure closes it here
+	defer func() {
+		if retErr != nil {
+			db.Close()
+		}
+	}()
+
+	// Run database migrations automatically on startup
+	migrator := database.NewMigrator(db.Pool, logger)
+	if err := migrator.MigrateFromDir(ctx, filepath.Join(o.rootDir, "migrations")); err != nil {
+		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	logger.Info("database migrations completed successfully")
+
+	// Initialize shutdown coordinator. It is created before the handlers so
+	// the server can be drained ahead of shutdown.
+	shutdownCoord := shutdown.NewCoordinator(&shutdown.Config{
+		Timeout: 30 * time.Second,
+	}, logger)
+
+	// Each subsystem is built on the ones before it
+	repos := newRepositories(db, logger)
+	svcs, err := newServices(ctx, cfg, logger, db, appMetrics, configReloader, repos)
+	if err != nil {
+		return nil, err
+	}
+	web, err := newRoutes(cfg, logger, o, db, appMetrics, configReloader, shutdownCoord, repos, svcs)
+	if err != nil {
+		return nil, err
+	}
+	bg, err := newWorkers(cfg, logger, a, db, appMetrics, repos, svcs)
+	if err != nil {
+		return nil, err
+	}
+	registerShutdown(cfg, db, tracerProvider, shutdownCoord, svcs, web, bg)
+
+	// Apply reloadable settings when configuration is reloaded
+	configReloader.Subscribe(func(reloaded *config.Config) {
+		web.rateLimiter.SetLimit(reloaded.RateLimit.Requests, reloaded.RateLimit.Window)
+		web.quotePortalRateLimiter.SetLimit(reloaded.QuotePortal.RateLimit, time.Minute)
+		web.quoteFormRateLimiter.SetLimit(reloaded.QuoteForm.RateLimit, time.Minute)
+		svcs.quoteService.SetApprovalConfig(service.QuoteApprovalConfig{
+			Threshold: reloaded.QuoteApproval.Threshold,
+			Approvers: reloaded.QuoteApproval.GetApprovers(),
+		})
+		// Stored provider keys still take the place of the environment's;
+		// keys set on another instance are also picked up between reloads
+		// by the provider-key-refresh job
+		if keys, err := svcs.secretsService.Keys(ctx); err != nil {
+			logger.Warn("failed to load stored provider keys after config reload", zap.Error(err))
+		} else {
+			reloaded = withProviderKeys(reloaded, keys)
+			svcs.claudeClient.SetAPIKey(reloaded.Anthropic.APIKey)
+			if reloaded.VoiceProvider.Bland.APIKey != "" {
+				svcs.blandClient.SetAPIKey(reloaded.VoiceProvider.Bland.APIKey)
+			}
+		}
+		svcs.providerRegistry.Replace(initVoiceProviders(reloaded, logger))
+		svcs.providerBreakers.sync(svcs.providerRegistry)
+		if err := svcs.budgetGuard.SetLimits(ctx, reloaded.Budget.MonthlyHardCap, reloaded.Budget.CostPerMinute); err != nil {
+			logger.Warn("failed to refresh budget after config reload", zap.Error(err))
+		}
+	})
+
+	a.Config = cfg
+	a.Metrics = appMetrics
+	a.DB = db
+	a.Services = Services{
+		Auth:    svcs.authService,
+		Users:   svcs.userService,
+		APIKeys: svcs.apiKeyService,
+		Calls:   svcs.callService,
+		Quotes:  svcs.quoteService,
+		Prompts: svcs.promptService,
+	}
+	a.Handler = web.router
+	a.Server = web.server
+	a.GRPC = web.grpcServer
+	a.Jobs = svcs.jobScheduler
+	a.Leader = bg.leaderElector
+	a.Reloader = configReloader
+	a.Shutdown = shutdownCoord
+	return a, nil
+}
+
+// registerShutdown registers the servers, workers and connections with
+// shutdownCoord, in the phases they stop in.
+func registerShutdown(cfg *config.Config, db *database.DB, tracerProvider *sdktrace.TracerProvider, shutdownCoord *shutdown.Coordinator, svcs *appServices, web *routes, bg *workers) {
+	// Register services for graceful shutdown (in order of shutdown phases)
+	// Phase 1 (PreDrain): Stop accepting new work. Runs on POST /admin/drain,
+	// or on signal receipt if the server wasn't drained first. /ready fails
+	// from here on, via the readiness probe.
+	shutdownCoord.RegisterFunc(shutdown.PhasePreDrain, "job-processor-claims", func(ctx context.Context) error {
+		svcs.jobProcessor.Drain()
+		return nil
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhasePreDrain, "campaign-dialing", func(ctx context.Context) error {
+		svcs.campaignScheduler.Drain()
+		return nil
+	})
+	// Phase 2 (Drain): Let in-flight requests complete
+	shutdownCoord.RegisterFunc(shutdown.PhaseDrain, "http-server", func(ctx context.Context) error {
+		return web.server.Shutdown(ctx)
+	})
+	if web.grpcServer != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseDrain, "grpc-server", func(ctx context.Context) error {
+			return web.grpcServer.Shutdown(ctx)
+		})
+	}
+
+	// Phase 3 (Shutdown): Stop background workers
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "job-processor", func(ctx context.Context) error {
+		return svcs.jobProcessor.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "webhook-event-processor", func(ctx context.Context) error {
+		return svcs.webhookEventProcessor.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "campaign-scheduler", func(ctx context.Context) error {
+		return svcs.campaignScheduler.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "budget-guard", func(ctx context.Context) error {
+		return svcs.budgetGuard.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "health-monitor", func(ctx context.Context) error {
+		return svcs.healthMonitor.Stop(ctx)
+	})
+	if svcs.fakeProvider != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "fake-voice-provider", func(ctx context.Context) error {
+			return svcs.fakeProvider.Stop(ctx)
+		})
+	}
+	if svcs.recordingService != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "recording-worker", func(ctx context.Context) error {
+			return svcs.recordingService.Stop(ctx)
+		})
+	}
+	if svcs.transcriptionService != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "transcription-worker", func(ctx context.Context) error {
+			return svcs.transcriptionService.Stop(ctx)
+		})
+	}
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "callback-worker", func(ctx context.Context) error {
+		return svcs.callbackService.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "followup-worker", func(ctx context.Context) error {
+		return svcs.followUpService.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "call-retry-worker", func(ctx context.Context) error {
+		return svcs.callRetryService.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "outgoing-webhook-worker", func(ctx context.Context) error {
+		return svcs.outgoingWebhookService.Stop(ctx)
+	})
+	if svcs.runNumberHealth {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "number-health-worker", func(ctx context.Context) error {
+			return svcs.numberHealthService.Stop(ctx)
+		})
+	}
+	if cfg.Analytics.RollupEnabled {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "analytics-rollup-worker", func(ctx context.Context) error {
+			return svcs.analyticsRollupService.Stop(ctx)
+		})
+	}
+	if cfg.Tagging.Enabled {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "call-tagging-worker", func(ctx context.Context) error {
+			return svcs.callTaggingService.Stop(ctx)
+		})
+	}
+	if svcs.runSchedule {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "schedule-worker", func(ctx context.Context) error {
+			return svcs.scheduleService.Stop(ctx)
+		})
+	}
+	if svcs.runBlandSync {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "bland-sync-worker", func(ctx context.Context) error {
+			return svcs.blandSyncService.Stop(ctx)
+		})
+	}
+	if svcs.accountingService != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "accounting-sync", func(ctx context.Context) error {
+			return svcs.accountingService.Stop(ctx)
+		})
+	}
+	if svcs.crmService != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "crm-sync-worker", func(ctx context.Context) error {
+			return svcs.crmService.Stop(ctx)
+		})
+	}
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "csrf-protection", func(ctx context.Context) error {
+		return web.csrfProtection.Shutdown(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "api-key-limiter", func(ctx context.Context) error {
+		web.apiKeyLimiter.Stop()
+		return nil
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "email-notifier", func(ctx context.Context) error {
+		return svcs.emailNotifier.Close(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "chatops-notifier", func(ctx context.Context) error {
+		return svcs.chatNotifier.Close(ctx)
+	})
+	if svcs.backupService != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "backups", func(ctx context.Context) error {
+			return svcs.backupService.Stop(ctx)
+		})
+	}
+	shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "job-scheduler", func(ctx context.Context) error {
+		if err := svcs.jobScheduler.Stop(ctx); err != nil {
+			return err
+		}
+		// Hand leadership over once this instance's singleton jobs are done
+		return bg.leaderElector.Stop(ctx)
+	})
+	if svcs.smsNotifier != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseShutdown, "sms-notifier", func(ctx context.Context) error {
+			return svcs.smsNotifier.Close(ctx)
+		})
+	}
+
+	// Phase 4 (Cleanup): Close connections and flush buffers
+	// Hand worker leadership over once the workers above have stopped
+	shutdownCoord.RegisterFunc(shutdown.PhaseCleanup, "worker-leader-election", func(ctx context.Context) error {
+		return bg.workerElector.Stop(ctx)
+	})
+	shutdownCoord.RegisterFunc(shutdown.PhaseCleanup, "database", func(ctx context.Context) error {
+		db.Close()
+		return nil
+	})
+	if tracerProvider != nil {
+		shutdownCoord.RegisterFunc(shutdown.PhaseCleanup, "tracing", func(ctx context.Context) error {
+			return tracerProvider.Shutdown(ctx)
+		})
+	}
+}
+
+// OnStart adds a function run by Start, after those already added. The
+// background workers are added by New.
+func (a *App) OnStart(name string, fn func(ctx context.Context) error) {
+	a.startHooks = append(a.startHooks, startHook{name: name, fn: fn})
+}
+
+// OnStartWorker is like OnStart, but the function is skipped in server
+// mode, leaving it to worker processes.
+func (a *App) OnStartWorker(name string, fn func(ctx context.Context) error) {
+	a.startHooks = append(a.startHooks, startHook{name: name, fn: fn, worker: true})
+}
+
+// Start starts the background workers and scheduled jobs. ctx should last
+// as long as they run.
+func (a *App) Start(ctx context.Context) error {
+	for _, hook := range a.startHooks {
+		if hook.worker && !a.Mode.runsWorkers() {
+			continue
+		}
+		if err := hook.fn(ctx); err != nil {
+			return fmt.Errorf("failed to start %s: %w", hook.name, err)
+		}
+	}
+	return nil
+}
+
+// Serve serves HTTP, and gRPC when it is enabled, until Stop. It returns
+// the first error either server fails with.
+func (a *App) Serve() error {
+	servers := 1
+	errs := make(chan error, 2)
+
+	if a.GRPC != nil {
+		grpcAddr := net.JoinHostPort(a.Config.GRPC.Host, strconv.Itoa(a.Config.GRPC.Port))
+		grpcListener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC on %s: %w", grpcAddr, err)
+		}
+		servers++
+		go func() {
+			a.Logger.Info("grpc server listening", zap.String("addr", grpcAddr), zap.Bool("tls", !a.Config.GRPC.Insecure))
+			if err := a.GRPC.Serve(grpcListener); err != nil {
+				errs <- fmt.Errorf("grpc server failed: %w", err)
+				return
+			}
+			errs <- nil
+		}()
+	}
+
+	go func() {
+		var err error
+		if a.Server.TLSConfig != nil {
+			a.Logger.Info("server listening", zap.String("addr", a.Server.Addr), zap.Bool("tls", true), zap.String("client_auth", a.Config.Server.ClientAuth))
+			err = a.Server.ListenAndServeTLS(a.Config.Server.TLSCertFile, a.Config.Server.TLSKeyFile)
+		} else {
+			a.Logger.Info("server listening", zap.String("addr", a.Server.Addr))
+			err = a.Server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			errs <- err
+			return
+		}
+		errs <- nil
+	}()
+
+	for i := 0; i < servers; i++ {
+		if err := <-errs; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop drains and shuts down the servers and workers, then closes the
+// database.
+func (a *App) Stop(ctx context.Context) error {
+	return a.Shutdown.Shutdown(ctx)
+}
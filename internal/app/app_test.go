@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// TestApp_BootsAgainstDatabase builds and starts the whole app against a
+// real database. It runs when QUICKQUOTE_INTEGRATION is set, with
+// DATABASE_* pointing at a disposable database: migrations are applied to
+// it.
+func TestApp_BootsAgainstDatabase(t *testing.T) {
+	if os.Getenv("QUICKQUOTE_INTEGRATION") == "" {
+		t.Skip("set QUICKQUOTE_INTEGRATION and DATABASE_* to run against a test database")
+	}
+	t.Setenv("VOICE_PROVIDER_FAKE_ENABLED", "true")
+	t.Setenv("VOICE_PROVIDER_PRIMARY", "fake")
+	t.Setenv("ANTHROPIC_API_KEY", "test-key")
+	t.Setenv("SESSION_SECRET", "integration-test-session-secret-0123456789")
+	t.Setenv("APP_PUBLIC_URL", "http://localhost:8080")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a, err := New(ctx, cfg, zap.NewNop(),
+		WithMetrics(metrics.NewMetricsWithRegistry(prometheus.NewRegistry())),
+		WithRootDir("../.."),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := a.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := a.Stop(stopCtx); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	}()
+
+	for _, path := range []string{"/live", "/ready", "/login"} {
+		rec := httptest.NewRecorder()
+		a.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, expected 200", path, rec.Code)
+		}
+	}
+
+	// The API needs a session or key
+	rec := httptest.NewRecorder()
+	a.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/calls", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("GET /api/v1/calls = %d, expected 401", rec.Code)
+	}
+
+	if len(a.Jobs.Status()) == 0 {
+		t.Error("expected the background jobs registered")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"all", "server", "worker"} {
+		if m, err := ParseMode(s); err != nil || string(m) != s {
+			t.Errorf("ParseMode(%q) = %q, %v", s, m, err)
+		}
+	}
+	if _, err := ParseMode("scheduler"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestApp_StartSkipsWorkersInServerMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode Mode
+		want []string
+	}{
+		{ModeAll, []string{"health monitor", "job processor", "job scheduler"}},
+		{ModeServer, []string{"health monitor", "job scheduler"}},
+		{ModeWorker, []string{"health monitor", "job processor", "job scheduler"}},
+	} {
+		var started []string
+		hook := func(name string) func(context.Context) error {
+			return func(context.Context) error {
+				started = append(started, name)
+				return nil
+			}
+		}
+		a := &App{Mode: tt.mode}
+		a.OnStart("health monitor", hook("health monitor"))
+		a.OnStartWorker("job processor", hook("job processor"))
+		a.OnStart("job scheduler", hook("job scheduler"))
+
+		if err := a.Start(context.Background()); err != nil {
+			t.Fatalf("%s: Start() error = %v", tt.mode, err)
+		}
+		if strings.Join(started, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: started %v, expected %v", tt.mode, started, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/oidc"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// authServices are sign-in, users and API keys.
+type authServices struct {
+	authService   *service.AuthService
+	ssoProvider   *oidc.Provider
+	apiKeyService *service.APIKeyService
+	userService   *service.UserService
+}
+
+// newAuthServices builds the auth services and seeds the admin user.
+func newAuthServices(ctx context.Context, cfg *config.Config, logger *zap.Logger, db *database.DB, appMetrics *metrics.Metrics, repos *repositories, platform *platformServices) (*authServices, error) {
+	authService := service.NewAuthService(
+		repos.userRepo,
+		repos.sessionRepo,
+		cfg.Auth.SessionDuration,
+		logger,
+		appMetrics,
+	)
+	authService.SetTwoFactor(repository.NewRecoveryCodeRepository(db.Pool), cfg.Auth.TOTPIssuer)
+
+	// Single sign-on with an OpenID Connect identity provider
+	var ssoRedirectURL string
+	if cfg.App.PublicURL != "" {
+		ssoRedirectURL = cfg.App.PublicURL + "/auth/sso/callback"
+	}
+	ssoProvider, err := oidc.New(oidc.Config{
+		IssuerURL:    cfg.Auth.OIDCIssuerURL,
+		ClientID:     cfg.Auth.OIDCClientID,
+		ClientSecret: cfg.Auth.OIDCClientSecret,
+		RedirectURL:  ssoRedirectURL,
+		Scopes:       cfg.Auth.GetOIDCScopes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure single sign-on: %w", err)
+	}
+	if ssoProvider != nil {
+		defaultRole := domain.UserRole(cfg.Auth.OIDCDefaultRole)
+		if !defaultRole.IsValid() {
+			return nil, fmt.Errorf("invalid AUTH_OIDC_DEFAULT_ROLE %q; use member or admin", cfg.Auth.OIDCDefaultRole)
+		}
+		authService.SetSSO(service.SSOConfig{
+			AllowedDomains: cfg.Auth.GetOIDCAllowedDomains(),
+			AutoProvision:  cfg.Auth.OIDCAutoProvision,
+			DefaultRole:    defaultRole,
+		})
+		logger.Info("single sign-on enabled",
+			zap.String("issuer", cfg.Auth.OIDCIssuerURL),
+			zap.Strings("allowed_domains", cfg.Auth.GetOIDCAllowedDomains()),
+		)
+	}
+
+	// Seed initial admin user if no users exist (enables zero-config deployment)
+	adminEmail := os.Getenv("ADMIN_EMAIL")
+	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if adminEmail != "" && adminPassword != "" {
+		created, err := authService.EnsureAdminUser(ctx, adminEmail, adminPassword)
+		if err != nil {
+			logger.Warn("failed to ensure admin user", zap.Error(err))
+		} else if created {
+			logger.Info("initial admin user created from environment variables")
+		}
+	} else {
+		logger.Debug("ADMIN_EMAIL/ADMIN_PASSWORD not set, skipping admin user seed")
+	}
+
+	return &authServices{
+		authService: authService,
+		ssoProvider: ssoProvider,
+		// API keys for machine-to-machine API access
+		apiKeyService: service.NewAPIKeyService(repos.apiKeyRepo, repos.userRepo, logger),
+		// Users, managed by admins
+		userService: service.NewUserService(repos.userRepo, repos.sessionRepo, authService, platform.auditLogger, logger),
+	}, nil
+}
+
+// authRoutes are sign-in, account security and user management.
+type authRoutes struct {
+	auth     *handler.AuthHandler
+	security *handler.SecurityHandler
+	users    *handler.UserHandler
+	apiKeys  *handler.APIKeyHandler
+	userAPI  *handler.UserAPIHandler
+}
+
+// newAuthRoutes builds the auth handlers.
+func newAuthRoutes(cfg *config.Config, logger *zap.Logger, base handler.BaseHandlerConfig, appMetrics *metrics.Metrics, apiKeyLimiter *ratelimit.KeyRateLimiter, svcs *appServices) *authRoutes {
+	return &authRoutes{
+		// Auth handler for login/logout/session management
+		auth: handler.NewAuthHandler(handler.AuthHandlerConfig{
+			Base:             base,
+			AuthService:      svcs.authService,
+			LoginRateLimiter: middleware.NewLoginRateLimiter(logger),
+			APIKeyService:    svcs.apiKeyService,
+			APIKeyLimiter:    apiKeyLimiter,
+			RequireTwoFactor: cfg.Auth.RequireTwoFactor,
+			SSO:              svcs.ssoProvider,
+			SSOName:          cfg.Auth.OIDCProviderName,
+			Metrics:          appMetrics,
+		}),
+		// Security handler for two-factor authentication setup
+		security: handler.NewSecurityHandler(handler.SecurityHandlerConfig{
+			Base:        base,
+			AuthService: svcs.authService,
+		}),
+		// User management for admins
+		users: handler.NewUserHandler(handler.UserHandlerConfig{
+			Base:        base,
+			UserService: svcs.userService,
+		}),
+		apiKeys: handler.NewAPIKeyHandler(svcs.apiKeyService, svcs.auditLogger, logger),
+		userAPI: handler.NewUserAPIHandler(svcs.userService, logger),
+	}
+}
+
+// registerPages registers the signed-in user's account security page.
+func (a *authRoutes) registerPages(r chi.Router) {
+	// Account security (two-factor authentication)
+	a.security.RegisterRoutes(r)
+}
+
+// registerAdminPages registers API key and user management.
+func (a *authRoutes) registerAdminPages(r chi.Router) {
+	// Admin API for API key management
+	a.apiKeys.RegisterRoutes(r)
+
+	// User management
+	a.users.RegisterRoutes(r)
+}
+
+// registerAPI registers the user API routes.
+func (a *authRoutes) registerAPI(r chi.Router) {
+	a.userAPI.RegisterRoutes(r)
+}
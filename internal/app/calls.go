@@ -0,0 +1,699 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/ai"
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/calendar"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/exchangerate"
+	"github.com/jkindrix/quickquote/internal/export"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/realtime"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/reputation"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/storage"
+	"github.com/jkindrix/quickquote/internal/transcription"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+	"github.com/jkindrix/quickquote/internal/voiceprovider/fake"
+)
+
+// callServices are the voice providers, the calls placed and received
+// through them with the quote generation each call feeds, and the search,
+// tags, saved views, analytics and exports of the calls list.
+type callServices struct {
+	claudeClient           *ai.ClaudeClient
+	blandClient            *bland.Client
+	blandEnabled           bool // A Bland API key is configured
+	providerRegistry       *voiceprovider.Registry
+	providerBreakers       *providerBreakers
+	providerDialer         *service.ProviderDialer
+	fakeProvider           *fake.Provider
+	jobProcessor           *service.QuoteJobProcessor
+	quoteJobEvents         *service.QuoteJobEvents
+	pricingService         *service.PricingService
+	quoteTemplateService   *service.QuoteTemplateService
+	callService            *service.CallService
+	callTaggingService     *service.CallTaggingService
+	recordingService       *service.RecordingService
+	transcriptionService   *service.TranscriptionService
+	callArchiveService     *service.CallArchiveService
+	callCostService        *service.CallCostService
+	blandService           *service.BlandService
+	blandSyncService       *service.BlandSyncService
+	runBlandSync           bool
+	pathwayVersionService  *service.PathwayVersionService
+	promptService          *service.PromptService
+	promptBundleService    *service.PromptBundleService
+	callRetryService       *service.CallRetryService
+	experimentService      *service.ExperimentService
+	customerService        *service.CustomerService
+	budgetGuard            *service.BudgetGuard
+	campaignService        *service.CampaignService
+	campaignScheduler      *service.CampaignScheduler
+	callbackService        *service.CallbackService
+	toolSecret             string
+	numberHealthService    *service.NumberHealthService
+	runNumberHealth        bool
+	scheduleService        *service.ScheduleService
+	runSchedule            bool
+	routingService         *service.RoutingService
+	overflowService        *service.OverflowService
+	tagService             *service.TagService
+	savedViewService       *service.SavedViewService
+	searchService          *service.SearchService
+	analyticsService       *service.AnalyticsService
+	analyticsRollupService *service.AnalyticsRollupService
+	exportService          *export.Service
+}
+
+// newCallServices builds the voice provider clients and the call services.
+// cfg carries the stored provider keys.
+func newCallServices(ctx context.Context, cfg *config.Config, logger *zap.Logger, db *database.DB, appMetrics *metrics.Metrics, configReloader *config.Reloader, repos *repositories, platform *platformServices) (*callServices, error) {
+	settingsService := platform.settingsService
+
+	// Initialize AI client
+	claudeClient := ai.NewClaudeClient(&cfg.Anthropic, logger)
+	claudeClient.SetMetrics(appMetrics)
+	if client := newProviderHTTPClient("anthropic", cfg.HTTPClient.Anthropic, logger); client != nil {
+		claudeClient.SetHTTPClient(client)
+	}
+
+	// Initialize Bland API client (for full API capabilities)
+	blandAPIKey := cfg.VoiceProvider.Bland.APIKey
+	if blandAPIKey == "" {
+		blandAPIKey = cfg.Bland.APIKey
+	}
+	blandClient := bland.New(&bland.Config{
+		APIKey:     blandAPIKey,
+		HTTPClient: newProviderHTTPClient("bland", cfg.HTTPClient.Bland, logger),
+	}, logger)
+	blandClient.SetMetrics(appMetrics)
+	platform.breakers.Register(claudeClient.CircuitBreaker(), blandClient.CircuitBreaker())
+	logger.Info("initialized Bland API client")
+
+	// Initialize voice provider registry (Bland calls go through blandClient,
+	// so its circuit decides whether Bland is healthy)
+	providerRegistry := initVoiceProviders(cfg, logger)
+	providerRegistry.SetCircuit(voiceprovider.ProviderBland, blandClient)
+	providerBreakers := newProviderBreakers(platform.breakers)
+	providerBreakers.sync(providerRegistry)
+
+	// Switch clients to a provider key as soon as it is set or removed
+	secretsService := platform.secretsService
+	secretsService.Subscribe(func(provider domain.CredentialProvider) {
+		keys, err := secretsService.Keys(ctx)
+		if err != nil {
+			logger.Error("failed to load stored provider keys", zap.String("provider", string(provider)), zap.Error(err))
+			return
+		}
+		current := withProviderKeys(configReloader.Current(), keys)
+		switch provider {
+		case domain.CredentialAnthropic:
+			claudeClient.SetAPIKey(current.Anthropic.APIKey)
+		case domain.CredentialBland:
+			blandAPIKey := current.VoiceProvider.Bland.APIKey
+			if blandAPIKey == "" {
+				blandAPIKey = current.Bland.APIKey
+			}
+			blandClient.SetAPIKey(blandAPIKey)
+			providerRegistry.Replace(initVoiceProviders(current, logger))
+			providerBreakers.sync(providerRegistry)
+		default:
+			providerRegistry.Replace(initVoiceProviders(current, logger))
+			providerBreakers.sync(providerRegistry)
+		}
+		logger.Info("switched provider key", zap.String("provider", string(provider)))
+	})
+
+	// The simulated provider serves its recordings and calls in on a timer
+	var fakeProvider *fake.Provider
+	if p, err := providerRegistry.Get(voiceprovider.ProviderFake); err == nil {
+		fakeProvider, _ = p.(*fake.Provider)
+	}
+
+	// Initialize quote rate limiter for cost control
+	quoteLimiterConfig := ratelimit.DefaultQuoteLimiterConfig()
+	quoteLimiter := ratelimit.NewQuoteLimiter(quoteLimiterConfig, logger)
+	logger.Info("initialized quote rate limiter",
+		zap.Int("max_per_minute", quoteLimiterConfig.MaxRequestsPerMinute),
+		zap.Int("max_per_hour", quoteLimiterConfig.MaxRequestsPerHour),
+		zap.Int("max_per_day", quoteLimiterConfig.MaxRequestsPerDay),
+		zap.Int("max_concurrent", quoteLimiterConfig.MaxConcurrent),
+	)
+
+	// Initialize quote job processor
+	jobProcessorConfig := service.DefaultQuoteJobProcessorConfig()
+	jobProcessor := service.NewQuoteJobProcessor(
+		repos.quoteJobRepo,
+		repos.callRepo,
+		claudeClient,
+		quoteLimiter,
+		logger,
+		jobProcessorConfig,
+	)
+	quoteJobEvents := service.NewQuoteJobEvents()
+	jobProcessor.SetEvents(quoteJobEvents)
+	jobProcessor.SetMetrics(appMetrics)
+
+	// Initialize pricing (quote amounts come from pricing rules; the AI only
+	// extracts the quantities they need)
+	pricingService := service.NewPricingService(repos.pricingRuleRepo, claudeClient, logger)
+	jobProcessor.SetPricing(pricingService)
+
+	// Initialize quote templates (default line items, sections and assumptions
+	// per project type)
+	quoteTemplateService := service.NewQuoteTemplateService(repos.quoteTemplateRepo, logger)
+	pricingService.SetTemplates(quoteTemplateService)
+
+	// Initialize services
+	callService := service.NewCallService(repos.callRepo, claudeClient, jobProcessor, quoteLimiter, logger, appMetrics)
+	callService.SetPricing(pricingService)
+	callService.SetCallEvents(repos.callEventRepo)
+
+	// Initialize call tagging (sentiment, intent and urgency from the transcript)
+	callTaggingService := service.NewCallTaggingService(claudeClient, repos.callRepo, logger, &service.CallTaggingServiceConfig{
+		Workers: cfg.Tagging.Workers,
+	})
+	if cfg.Tagging.Enabled {
+		callService.SetCallTagger(callTaggingService)
+	}
+
+	// Initialize recording archiving (provider recording URLs expire, so
+	// completed calls' recordings are copied to our own storage)
+	recordingStore, err := storage.New(storage.Config{
+		Backend:           cfg.Recordings.Storage,
+		LocalPath:         cfg.Recordings.LocalPath,
+		S3Endpoint:        cfg.Recordings.S3Endpoint,
+		S3Region:          cfg.Recordings.S3Region,
+		S3Bucket:          cfg.Recordings.S3Bucket,
+		S3AccessKeyID:     cfg.Recordings.S3AccessKeyID,
+		S3SecretAccessKey: cfg.Recordings.S3SecretAccessKey,
+		S3PathStyle:       cfg.Recordings.S3PathStyle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure recording storage: %w", err)
+	}
+	var recordingService *service.RecordingService
+	if recordingStore != nil {
+		recordingRetention := time.Duration(cfg.Recordings.RetentionDays) * 24 * time.Hour
+		if cfg.Retention.Enabled && cfg.Retention.Recordings > 0 {
+			// The retention rules delete recordings, so new ones get no expiry of their own
+			recordingRetention = 0
+		}
+		recordingService = service.NewRecordingService(repos.recordingRepo, recordingStore, logger, &service.RecordingServiceConfig{
+			Retention:       recordingRetention,
+			MaxSize:         int64(cfg.Recordings.MaxSizeMB) << 20,
+			PollInterval:    15 * time.Second,
+			CleanupInterval: time.Hour,
+			BatchSize:       10,
+		})
+		callService.SetRecordingArchiver(recordingService)
+	}
+
+	// Initialize transcription fallback for calls whose provider sends a
+	// recording but no transcript
+	transcriber, err := transcription.New(transcription.Config{
+		Provider:      cfg.Transcription.Provider,
+		Language:      cfg.Transcription.Language,
+		OpenAIAPIKey:  cfg.Transcription.OpenAIAPIKey,
+		OpenAIModel:   cfg.Transcription.OpenAIModel,
+		OpenAIAPIURL:  cfg.Transcription.OpenAIAPIURL,
+		WhisperCppURL: cfg.Transcription.WhisperCppURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure transcription: %w", err)
+	}
+	var transcriptionService *service.TranscriptionService
+	if transcriber != nil {
+		transcriptionConfig := service.DefaultTranscriptionServiceConfig()
+		transcriptionConfig.MaxSize = int64(cfg.Transcription.MaxSizeMB) << 20
+		transcriptionService = service.NewTranscriptionService(transcriber, callService, logger, transcriptionConfig)
+		if recordingService != nil {
+			transcriptionService.SetRecordings(recordingService)
+		}
+	}
+
+	// Archive, soft-delete and restore calls with their quotes
+	callArchiveService := service.NewCallArchiveService(repos.callRepo, service.CallArchivePolicy{
+		CallsAfter: cfg.Archival.CallsAfter,
+		BatchSize:  cfg.Archival.BatchSize,
+	}, logger, nil)
+
+	// Quotes are priced in the currency from the settings, recorded on the
+	// call when its quote is generated
+	jobProcessor.SetCurrencies(settingsService)
+	callService.SetCurrencies(settingsService)
+
+	// Attribute estimated costs to calls as they end, priced from the settings
+	callCostService := service.NewCallCostService(repos.callRepo, settingsService, logger, nil)
+	callService.SetCallCoster(callCostService)
+
+	// Build webhook URL for Bland callbacks
+	// In production, this should be configured to your public URL
+	webhookURL := fmt.Sprintf("http://%s:%d/webhook/bland", cfg.Server.Host, cfg.Server.Port)
+	if os.Getenv("WEBHOOK_BASE_URL") != "" {
+		webhookURL = os.Getenv("WEBHOOK_BASE_URL") + "/webhook/bland"
+	}
+
+	// Initialize Bland service (for full API access)
+	blandService := service.NewBlandService(
+		blandClient,
+		repos.callRepo,
+		repos.promptRepo,
+		settingsService,
+		webhookURL,
+		repos.idempotencyRepo,
+		logger,
+	)
+	logger.Info("initialized Bland service", zap.String("webhook_url", webhookURL))
+
+	// Initialize the Bland entity cache (list endpoints and admin pages read
+	// voices, personas, pathways, knowledge bases and phone numbers from it)
+	blandSyncService := service.NewBlandSyncService(blandService, repos.blandEntityRepo, logger, &service.BlandSyncServiceConfig{
+		Interval:       cfg.VoiceProvider.Bland.SyncInterval,
+		ReplayInterval: cfg.VoiceProvider.Bland.ReplayInterval,
+	})
+	blandSyncService.SetLocalRepositories(repos.knowledgeBaseRepo, repos.pathwayRepo, repos.personaRepo)
+	runBlandSync := blandAPIKey != "" && cfg.VoiceProvider.Bland.SyncInterval > 0
+	if runBlandSync {
+		// While Bland is unreachable, serve the cache and queue writes for
+		// the sync worker to replay
+		blandService.SetWriteQueue(repos.blandWriteRepo)
+		blandSyncService.SetWriteReplayer(blandService)
+	}
+
+	// Initialize pathway version history (snapshots, diffs and rollback)
+	pathwayVersionService := service.NewPathwayVersionService(blandService, repos.pathwayRepo, logger)
+
+	// Initialize prompt service
+	promptService := service.NewPromptService(repos.promptRepo, logger)
+	promptService.SetVersionRepository(repos.promptVersionRepo)
+	promptService.SetCacheTTL(cfg.Cache.TTL)
+
+	// Initialize prompt bundles (prompts, settings and citation schemas
+	// exported and imported as one document)
+	promptBundleService := service.NewPromptBundleService(promptService, logger)
+	promptBundleService.SetSettingsStore(settingsService)
+	promptBundleService.SetCitationSchemaStore(blandService)
+	promptBundleService.SetTransactor(db.TxManager)
+
+	// Re-dial numbers whose call went to voicemail or was not answered, by
+	// the retry policy of the prompt the call was placed with
+	callRetryService := service.NewCallRetryService(repos.callAttemptRepo, repos.callRepo, promptService, blandService, logger, nil, nil)
+	callService.SetCallRetrier(callRetryService)
+
+	// Initialize prompt experiments (outbound calls are split as they are
+	// placed, inbound numbers are switched between variants after each call,
+	// and webhooks attribute every call to its variant)
+	experimentService := service.NewExperimentService(repos.experimentRepo, repos.promptRepo, logger)
+	experimentService.SetInboundConfigurer(blandService)
+	blandService.SetExperimentAssigner(experimentService)
+	callService.SetExperimentRecorder(experimentService)
+
+	// Initialize customers and link calls to them as they are placed or received
+	customerService := service.NewCustomerService(repos.customerRepo, repos.callRepo, repos.quoteRepo, logger)
+	callService.SetCustomerLinker(customerService)
+	blandService.SetCustomerLinker(customerService)
+	blandService.SetCallEvents(repos.callEventRepo)
+
+	// Initialize budget enforcement (refuses new calls and batches and pauses
+	// running batches once month-to-date spend reaches the hard cap). The
+	// guard is always wired so a cap set by a config reload takes effect.
+	budgetGuard := service.NewBudgetGuard(blandService, repos.budgetRepo, logger, &service.BudgetGuardConfig{
+		HardCap:         cfg.Budget.MonthlyHardCap,
+		CostPerMinute:   cfg.Budget.CostPerMinute,
+		RefreshInterval: cfg.Budget.RefreshInterval,
+	}, nil)
+	blandService.SetBudget(budgetGuard)
+
+	// Initialize outbound call campaigns (scheduler dials through the primary
+	// voice provider and holds back whenever the quote limiter is out of
+	// capacity or the budget is exhausted)
+	providerDialer := service.NewProviderDialer(providerRegistry, blandService, repos.callRepo, logger)
+	providerDialer.SetMetrics(appMetrics)
+	providerDialer.SetCustomerLinker(customerService)
+	providerDialer.SetBudget(budgetGuard)
+	providerDialer.SetCallEvents(repos.callEventRepo)
+	campaignService := service.NewCampaignService(repos.campaignRepo, logger)
+	campaignScheduler := service.NewCampaignScheduler(
+		repos.campaignRepo,
+		providerDialer,
+		quoteLimiter,
+		logger,
+		service.DefaultCampaignSchedulerConfig(),
+	)
+	campaignScheduler.SetBudget(budgetGuard)
+
+	// Initialize callback scheduling (the schedule_callback tool records
+	// callbacks; a worker puts them on the calendar when one is configured)
+	businessCalendar, err := calendar.New(calendar.Config{
+		Provider:              cfg.Calendar.Provider,
+		GoogleCalendarID:      cfg.Calendar.GoogleCalendarID,
+		GoogleCredentialsFile: cfg.Calendar.GoogleCredentialsFile,
+		CalDAVURL:             cfg.Calendar.CalDAVURL,
+		CalDAVUsername:        cfg.Calendar.CalDAVUsername,
+		CalDAVPassword:        cfg.Calendar.CalDAVPassword,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure calendar: %w", err)
+	}
+	callbackService := service.NewCallbackService(repos.callbackRepo, repos.callRepo, businessCalendar, logger, &service.CallbackServiceConfig{
+		Location:      platform.location,
+		EventDuration: cfg.Calendar.EventDuration,
+		PublicURL:     cfg.App.PublicURL,
+		PollInterval:  10 * time.Second,
+		BatchSize:     20,
+	})
+
+	toolSecret := cfg.VoiceProvider.Bland.WebhookSecret
+	if toolSecret == "" {
+		toolSecret = cfg.Bland.WebhookSecret
+	}
+	blandService.SetToolSecret(toolSecret)
+
+	// Caller ID health: scores owned numbers, rotates flagged ones out of
+	// dialing pools and raises usage alerts
+	numberHealthService := service.NewNumberHealthService(repository.NewNumberHealthRepository(db.Pool), blandService, logger, &service.NumberHealthServiceConfig{
+		Interval: cfg.NumberHealth.Interval,
+		Lookback: cfg.NumberHealth.Lookback,
+		MinCalls: cfg.NumberHealth.MinCalls,
+	}, nil)
+	numberHealthService.SetDialingPools(blandService)
+	if cfg.NumberHealth.ReputationURL != "" {
+		numberHealthService.SetReputationChecker(reputation.New(cfg.NumberHealth.ReputationURL, cfg.NumberHealth.ReputationAPIKey, nil))
+	}
+	runNumberHealth := blandAPIKey != "" && cfg.NumberHealth.Enabled
+
+	// Business hours: inbound numbers are switched to an after hours agent
+	// outside them
+	scheduleService := service.NewScheduleService(settingsService, blandService, blandService, repos.promptRepo, logger, nil)
+	runSchedule := blandAPIKey != ""
+
+	// Geographic routing: inbound callers are routed by area code or state
+	routingService := service.NewRoutingService(repository.NewRoutingRuleRepository(db.Pool), repos.promptRepo, blandClient, logger, nil)
+
+	// Inbound overflow: calls over the concurrency threshold get another
+	// prompt, a text offering a callback, or a transfer
+	overflowConfig := service.OverflowServiceConfig{
+		Threshold:      cfg.Overflow.Threshold,
+		Policy:         service.OverflowPolicy(cfg.Overflow.Policy),
+		TransferNumber: cfg.Overflow.TransferNumber,
+		SMSMessage:     cfg.Overflow.SMSMessage,
+	}
+	if cfg.Overflow.PromptID != "" {
+		overflowPromptID, err := uuid.Parse(cfg.Overflow.PromptID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid overflow prompt ID %q: %w", cfg.Overflow.PromptID, err)
+		}
+		overflowConfig.PromptID = &overflowPromptID
+	}
+	if err := overflowConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid overflow configuration: %w", err)
+	}
+	var overflowService *service.OverflowService
+	if blandAPIKey != "" && overflowConfig.Threshold > 0 {
+		overflowService = service.NewOverflowService(overflowConfig, blandClient, blandService, scheduleService, repos.promptRepo, blandService, logger, nil)
+	}
+
+	// Tags on calls, quotes and customers, and the saved views built on them
+	tagService := service.NewTagService(repos.tagRepo, repos.callRepo, repos.customerRepo, platform.auditLogger, logger, &service.TagServiceConfig{
+		FreeForm:     cfg.Tags.FreeForm,
+		MaxPerRecord: cfg.Tags.MaxPerRecord,
+	})
+
+	// Users' saved calls list views
+	savedViewService := service.NewSavedViewService(repos.savedViewRepo, logger, &service.SavedViewServiceConfig{
+		MaxPerUser: cfg.SavedViews.MaxPerUser,
+	})
+
+	// Dashboard analytics
+	analyticsService := service.NewAnalyticsService(repos.analyticsRepo, logger, nil)
+	var exchangeRates service.ExchangeRateSource
+	if cfg.ExchangeRates.URL != "" {
+		exchangeRates = exchangerate.New(cfg.ExchangeRates.URL, cfg.ExchangeRates.RefreshInterval, nil, nil)
+		logger.Info("exchange rates configured", zap.String("url", cfg.ExchangeRates.URL))
+	}
+	analyticsService.SetExchangeRates(exchangeRates, settingsService)
+	analyticsService.SetRollups(repos.analyticsRollupRepo)
+	analyticsRollupService := service.NewAnalyticsRollupService(repos.analyticsRollupRepo, logger, &service.AnalyticsRollupServiceConfig{
+		Hour:         cfg.Analytics.RollupHour,
+		LookbackDays: cfg.Analytics.RollupLookbackDays,
+	}, nil)
+
+	return &callServices{
+		claudeClient:          claudeClient,
+		blandClient:           blandClient,
+		blandEnabled:          blandAPIKey != "",
+		providerRegistry:      providerRegistry,
+		providerBreakers:      providerBreakers,
+		providerDialer:        providerDialer,
+		fakeProvider:          fakeProvider,
+		jobProcessor:          jobProcessor,
+		quoteJobEvents:        quoteJobEvents,
+		pricingService:        pricingService,
+		quoteTemplateService:  quoteTemplateService,
+		callService:           callService,
+		callTaggingService:    callTaggingService,
+		recordingService:      recordingService,
+		transcriptionService:  transcriptionService,
+		callArchiveService:    callArchiveService,
+		callCostService:       callCostService,
+		blandService:          blandService,
+		blandSyncService:      blandSyncService,
+		runBlandSync:          runBlandSync,
+		pathwayVersionService: pathwayVersionService,
+		promptService:         promptService,
+		promptBundleService:   promptBundleService,
+		callRetryService:      callRetryService,
+		experimentService:     experimentService,
+		customerService:       customerService,
+		budgetGuard:           budgetGuard,
+		campaignService:       campaignService,
+		campaignScheduler:     campaignScheduler,
+		callbackService:       callbackService,
+		toolSecret:            toolSecret,
+		numberHealthService:   numberHealthService,
+		runNumberHealth:       runNumberHealth,
+		scheduleService:       scheduleService,
+		runSchedule:           runSchedule,
+		routingService:        routingService,
+		overflowService:       overflowService,
+		tagService:            tagService,
+		savedViewService:      savedViewService,
+		// Transcript search
+		searchService:          service.NewSearchService(repos.callRepo, logger),
+		analyticsService:       analyticsService,
+		analyticsRollupService: analyticsRollupService,
+		// CSV and XLSX exports of calls and quotes
+		exportService: export.NewService(repos.callRepo, export.DefaultBatchSize, logger),
+	}, nil
+}
+
+// callRoutes are the dashboard, call management and voice agent pages and
+// APIs.
+type callRoutes struct {
+	liveHub       *realtime.Hub
+	calls         *handler.CallsHandler
+	live          *handler.LiveHandler
+	admin         *handler.AdminHandler
+	campaigns     *handler.CampaignHandler
+	experiments   *handler.ExperimentHandler
+	schedule      *handler.ScheduleHandler
+	routing       *handler.RoutingHandler
+	customers     *handler.CustomerHandler
+	archivedCalls *handler.ArchivedCallsHandler
+	callbackTool  *handler.CallbackToolHandler
+	fakeProvider  *fake.Provider
+	callAPI       *handler.CallAPIHandler
+	promptAPI     *handler.PromptAPIHandler
+	blandAPI      *handler.BlandAPIHandler
+	customerAPI   *handler.CustomerAPIHandler
+	experimentAPI *handler.ExperimentAPIHandler
+	scheduleAPI   *handler.ScheduleAPIHandler
+	analyticsAPI  *handler.AnalyticsAPIHandler
+	budgetAPI     *handler.BudgetAPIHandler
+	tagAPI        *handler.TagAPIHandler
+	savedViewAPI  *handler.SavedViewAPIHandler
+}
+
+// newCallRoutes builds the call handlers.
+func newCallRoutes(logger *zap.Logger, base handler.BaseHandlerConfig, repos *repositories, svcs *appServices) *callRoutes {
+	// Live call activity from provider webhooks, pushed to the live calls page
+	liveHub := realtime.NewHub(nil)
+
+	// Initialize API handlers
+	callAPIHandler := handler.NewCallAPIHandler(svcs.blandService, svcs.auditLogger, logger)
+	callAPIHandler.SetCallService(svcs.callService)
+	callAPIHandler.SetExportService(svcs.exportService)
+	callAPIHandler.SetSearchService(svcs.searchService)
+	callAPIHandler.SetRecordingService(svcs.recordingService)
+	callAPIHandler.SetCostService(svcs.callCostService)
+	callAPIHandler.SetCallControlService(service.NewCallControlService(repos.callRepo, svcs.blandClient, logger))
+	callAPIHandler.SetCallRetryService(svcs.callRetryService)
+	callAPIHandler.SetCallArchiveService(svcs.callArchiveService)
+	callAPIHandler.SetBulkService(svcs.bulkService)
+	promptAPIHandler := handler.NewPromptAPIHandler(svcs.promptService, svcs.auditLogger, logger)
+	promptAPIHandler.SetBlandService(svcs.blandService)
+	// Enable apply-to-inbound functionality
+	promptAPIHandler.SetBundleService(svcs.promptBundleService)
+	blandAPIHandler := handler.NewBlandAPIHandler(svcs.blandService, logger)
+	blandAPIHandler.SetSyncService(svcs.blandSyncService)
+	blandAPIHandler.SetPathwayVersionService(svcs.pathwayVersionService)
+	blandAPIHandler.SetBlocklistService(svcs.blocklistService)
+	blandAPIHandler.SetNumberHealthService(svcs.numberHealthService)
+	blandAPIHandler.SetNumberProvisioningService(service.NewNumberProvisioningService(svcs.blandClient, svcs.promptService, logger))
+	customerAPIHandler := handler.NewCustomerAPIHandler(svcs.customerService, logger)
+	customerAPIHandler.SetConversations(svcs.conversationService)
+
+	return &callRoutes{
+		liveHub: liveHub,
+		// Calls handler for dashboard and call management
+		calls: handler.NewCallsHandler(handler.CallsHandlerConfig{
+			Base:            base,
+			CallService:     svcs.callService,
+			SearchService:   svcs.searchService,
+			CallbackService: svcs.callbackService,
+			TagService:      svcs.tagService,
+			SavedViews:      svcs.savedViewService,
+			Bulk:            svcs.bulkService,
+			Exports:         svcs.exportService,
+		}),
+		// Live calls page and its WebSocket
+		live: handler.NewLiveHandler(handler.LiveHandlerConfig{
+			Base: base,
+			Hub:  liveHub,
+		}),
+		// Admin handler for settings, voices, usage, etc.
+		admin: handler.NewAdminHandler(handler.AdminHandlerConfig{
+			Base:            base,
+			BlandService:    svcs.blandService,
+			SyncService:     svcs.blandSyncService,
+			PromptService:   svcs.promptService,
+			SettingsService: svcs.settingsService,
+			QuoteJobRepo:    repos.quoteJobRepo,
+			CallCosts:       svcs.callCostService,
+			Blocklist:       svcs.blocklistService,
+			NumberHealth:    svcs.numberHealthService,
+		}),
+		// Campaign handler for scheduled outbound call lists
+		campaigns: handler.NewCampaignHandler(handler.CampaignHandlerConfig{
+			Base:            base,
+			CampaignService: svcs.campaignService,
+			PromptService:   svcs.promptService,
+		}),
+		// Experiment handler for prompt A/B tests
+		experiments: handler.NewExperimentHandler(handler.ExperimentHandlerConfig{
+			Base:              base,
+			ExperimentService: svcs.experimentService,
+			PromptService:     svcs.promptService,
+		}),
+		// Schedule handler for the business hours admin page
+		schedule: handler.NewScheduleHandler(handler.ScheduleHandlerConfig{
+			Base:            base,
+			ScheduleService: svcs.scheduleService,
+			PromptService:   svcs.promptService,
+		}),
+		// Routing handler for the inbound routing rule admin pages
+		routing: handler.NewRoutingHandler(handler.RoutingHandlerConfig{
+			Base:           base,
+			RoutingService: svcs.routingService,
+			PromptService:  svcs.promptService,
+		}),
+		// Customer handler for the customer list and history pages
+		customers: handler.NewCustomerHandler(handler.CustomerHandlerConfig{
+			Base:            base,
+			CustomerService: svcs.customerService,
+			Conversations:   svcs.conversationService,
+		}),
+		// Archived calls admin page
+		archivedCalls: handler.NewArchivedCallsHandler(handler.ArchivedCallsHandlerConfig{
+			Base:        base,
+			CallService: svcs.callService,
+			Archive:     svcs.callArchiveService,
+			AuditLogger: svcs.auditLogger,
+		}),
+		// Tool webhooks called by the voice agent during calls
+		callbackTool:  handler.NewCallbackToolHandler(svcs.callbackService, svcs.toolSecret, logger),
+		fakeProvider:  svcs.fakeProvider,
+		callAPI:       callAPIHandler,
+		promptAPI:     promptAPIHandler,
+		blandAPI:      blandAPIHandler,
+		customerAPI:   customerAPIHandler,
+		experimentAPI: handler.NewExperimentAPIHandler(svcs.experimentService, logger),
+		scheduleAPI:   handler.NewScheduleAPIHandler(svcs.scheduleService, logger),
+		analyticsAPI:  handler.NewAnalyticsAPIHandler(svcs.analyticsService, logger),
+		budgetAPI:     handler.NewBudgetAPIHandler(svcs.budgetGuard, logger),
+		tagAPI:        handler.NewTagAPIHandler(svcs.tagService, logger),
+		savedViewAPI:  handler.NewSavedViewAPIHandler(svcs.savedViewService, logger),
+	}
+}
+
+// registerWebhooks registers the voice agent's tool webhooks and the
+// simulated provider's recordings.
+func (c *callRoutes) registerWebhooks(r chi.Router) {
+	if c.fakeProvider != nil {
+		r.Get(fake.RecordingPath+"*", c.fakeProvider.ServeRecording)
+	}
+	c.callbackTool.RegisterRoutes(r)
+}
+
+// registerPages registers the dashboard, calls, campaigns and customers.
+func (c *callRoutes) registerPages(r chi.Router) {
+	// Dashboard and calls
+	c.calls.RegisterRoutes(r)
+
+	// Live call monitoring
+	c.live.RegisterRoutes(r)
+
+	// Outbound call campaigns
+	c.campaigns.RegisterRoutes(r)
+
+	// Customers
+	c.customers.RegisterRoutes(r)
+}
+
+// registerAdminPages registers the voice agent admin pages.
+func (c *callRoutes) registerAdminPages(r chi.Router) {
+	// Admin pages (settings, phone numbers, voices, usage, knowledge bases, presets)
+	c.admin.RegisterRoutes(r)
+
+	// Prompt experiments
+	c.experiments.RegisterRoutes(r)
+
+	// Business hours and holidays
+	c.schedule.RegisterRoutes(r)
+
+	// Inbound routing rules
+	c.routing.RegisterRoutes(r)
+
+	// Archived calls
+	c.archivedCalls.RegisterRoutes(r)
+}
+
+// registerAPI registers the call API routes.
+func (c *callRoutes) registerAPI(r chi.Router) {
+	c.callAPI.RegisterRoutes(r)
+	c.promptAPI.RegisterRoutes(r)
+	c.blandAPI.RegisterRoutes(r)
+	c.customerAPI.RegisterRoutes(r)
+	c.experimentAPI.RegisterRoutes(r)
+	c.scheduleAPI.RegisterRoutes(r)
+	c.analyticsAPI.RegisterRoutes(r)
+	c.budgetAPI.RegisterRoutes(r)
+	c.tagAPI.RegisterRoutes(r)
+	c.savedViewAPI.RegisterRoutes(r)
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/reputation"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// complianceServices are calling compliance, blocked and spam callers,
+// privacy requests and data retention.
+type complianceServices struct {
+	complianceService *service.ComplianceService
+	blocklistService  *service.BlocklistService
+	spamService       *service.SpamService
+	spamScreen        *service.SpamService // spamService when inbound callers are screened
+	privacyService    *service.PrivacyService
+	retentionService  *service.RetentionService
+}
+
+// newComplianceServices builds the compliance services and has calls
+// placed by the call services checked against them.
+func newComplianceServices(cfg *config.Config, logger *zap.Logger, db *database.DB, repos *repositories, platform *platformServices, calls *callServices) (*complianceServices, error) {
+	// Calling compliance (blocks calls to numbers on the do-not-call list and
+	// outside the calling window in the called number's time zone)
+	complianceLocation := platform.location
+	if cfg.Compliance.DefaultTimezone != "" {
+		var err error
+		if complianceLocation, err = time.LoadLocation(cfg.Compliance.DefaultTimezone); err != nil {
+			return nil, fmt.Errorf("invalid compliance timezone %q: %w", cfg.Compliance.DefaultTimezone, err)
+		}
+	}
+	callingWindow, err := domain.ParseCallingWindow(cfg.Compliance.CallingWindowStart, cfg.Compliance.CallingWindowEnd, complianceLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calling window: %w", err)
+	}
+	complianceService := service.NewComplianceService(repos.dncRepo, logger, &service.ComplianceServiceConfig{
+		CallingWindow: callingWindow,
+	}, nil)
+	calls.blandService.SetCompliance(complianceService)
+	calls.providerDialer.SetCompliance(complianceService)
+	if calls.overflowService != nil {
+		calls.overflowService.SetDoNotCall(complianceService)
+	}
+
+	// Initialize blocklist (local mirror of Bland's, plus prefix blocks)
+	blocklistService := service.NewBlocklistService(repository.NewBlocklistRepository(db.Pool), calls.blandService, logger)
+	blocklistService.SetHangup(calls.blandClient)
+	calls.blandService.SetBlocklist(blocklistService)
+	calls.providerDialer.SetBlocklist(blocklistService)
+
+	// Spam scoring: inbound callers are scored when their calls' webhooks
+	// arrive; flagged callers are queued for review on the spam page
+	spamService := service.NewSpamService(repository.NewSpamCallerRepository(db.Pool), repos.callRepo, logger, &service.SpamServiceConfig{
+		Prefixes:        cfg.Spam.GetPrefixes(),
+		MaxCallsPerHour: cfg.Spam.MaxCallsPerHour,
+		AutoBlockAfter:  cfg.Spam.AutoBlockAfter,
+	}, nil)
+	spamService.SetBlocker(blocklistService)
+	if cfg.Spam.LookupURL != "" {
+		spamService.SetLookup(reputation.New(cfg.Spam.LookupURL, cfg.Spam.LookupAPIKey, nil))
+	}
+	var spamScreen *service.SpamService
+	if cfg.Spam.Enabled {
+		spamScreen = spamService
+	}
+
+	// Initialize privacy service (data subject export and deletion)
+	privacySigningKey := cfg.Privacy.ReportSigningKey
+	if privacySigningKey == "" {
+		privacySigningKey = cfg.Auth.SessionSecret
+	}
+	privacyService := service.NewPrivacyService(repos.privacyRepo, repos.customerRepo, repos.quoteRepo, repos.recordingRepo, repos.followUpRepo, repos.dncRepo, []byte(privacySigningKey), logger, nil)
+	privacyService.SetProvider(calls.blandService)
+	if calls.recordingService != nil {
+		privacyService.SetRecordingPurger(calls.recordingService)
+	}
+
+	// Per-entity retention rules and the legal holds that exempt calls from them
+	retentionService := service.NewRetentionService(repos.retentionRepo, platform.auditLogger, service.RetentionPolicy{
+		Transcripts: cfg.Retention.Transcripts,
+		Recordings:  cfg.Retention.Recordings,
+		AuditEvents: cfg.Retention.AuditEvents,
+		BatchSize:   cfg.Retention.BatchSize,
+		DryRun:      cfg.Retention.DryRun,
+	}, logger, nil)
+	if calls.recordingService != nil {
+		retentionService.SetRecordings(calls.recordingService)
+	}
+
+	return &complianceServices{
+		complianceService: complianceService,
+		blocklistService:  blocklistService,
+		spamService:       spamService,
+		spamScreen:        spamScreen,
+		privacyService:    privacyService,
+		retentionService:  retentionService,
+	}, nil
+}
+
+// complianceRoutes are the spam review queue and the compliance, privacy
+// and retention APIs.
+type complianceRoutes struct {
+	spam          *handler.SpamHandler
+	complianceAPI *handler.ComplianceAPIHandler
+	privacyAPI    *handler.PrivacyAPIHandler
+	retentionAPI  *handler.RetentionAPIHandler
+}
+
+// newComplianceRoutes builds the compliance handlers.
+func newComplianceRoutes(logger *zap.Logger, base handler.BaseHandlerConfig, svcs *appServices) *complianceRoutes {
+	return &complianceRoutes{
+		// Spam handler for the flagged caller review queue
+		spam: handler.NewSpamHandler(handler.SpamHandlerConfig{
+			Base:        base,
+			SpamService: svcs.spamService,
+		}),
+		complianceAPI: handler.NewComplianceAPIHandler(svcs.complianceService, logger),
+		privacyAPI:    handler.NewPrivacyAPIHandler(svcs.privacyService, logger),
+		retentionAPI:  handler.NewRetentionAPIHandler(svcs.retentionService, svcs.auditLogger, logger),
+	}
+}
+
+// registerAdminPages registers the spam review queue.
+func (c *complianceRoutes) registerAdminPages(r chi.Router) {
+	// Spam review queue
+	c.spam.RegisterRoutes(r)
+}
+
+// registerAPI registers the compliance API routes.
+func (c *complianceRoutes) registerAPI(r chi.Router) {
+	c.complianceAPI.RegisterRoutes(r)
+	c.privacyAPI.RegisterRoutes(r)
+	c.retentionAPI.RegisterRoutes(r)
+}
@@ -0,0 +1,325 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/accounting"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/crm"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/realtime"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+	"github.com/jkindrix/quickquote/internal/whatsapp"
+)
+
+// integrationServices are the webhooks received from voice providers, the
+// events and webhooks sent to other systems, customer messaging, and the
+// accounting and CRM syncs.
+type integrationServices struct {
+	webhookEventProcessor  *service.WebhookEventProcessor
+	webhookDedup           *service.WebhookDeduplicator
+	webhookArchive         *service.WebhookArchiveService
+	outgoingWebhookService *service.OutgoingWebhookService
+	eventService           *service.EventService
+	conversationService    *service.ConversationService
+	whatsAppClient         *whatsapp.Client
+	accountingService      *service.AccountingService
+	crmService             *service.CRMService
+}
+
+// newIntegrationServices builds the integration services and has the call
+// and quote services publish their changes to them.
+func newIntegrationServices(cfg *config.Config, logger *zap.Logger, db *database.DB, appMetrics *metrics.Metrics, repos *repositories, platform *platformServices, calls *callServices, compliance *complianceServices, quotes *quoteServices, notifications *notificationServices) (*integrationServices, error) {
+	quoteService := quotes.quoteService
+
+	// Initialize webhook event processor (durable webhook log with retries)
+	webhookEventProcessor := service.NewWebhookEventProcessor(
+		repos.webhookEventRepo,
+		calls.callService,
+		logger,
+		service.DefaultWebhookEventProcessorConfig(),
+	)
+	webhookEventProcessor.SetNotifier(notifications.notifier)
+
+	// Skip provider webhooks redelivered after they were processed
+	webhookDedup := service.NewWebhookDeduplicator(repos.processedWebhookRepo, cfg.VoiceProvider.WebhookDedupTTL, logger)
+	webhookDedup.SetMetrics(appMetrics)
+
+	// Keep raw webhook bodies for inspection unless disabled
+	var webhookArchive *service.WebhookArchiveService
+	if cfg.VoiceProvider.WebhookArchiveRetention > 0 {
+		webhookArchive = service.NewWebhookArchiveService(repos.webhookArchiveRepo, calls.providerRegistry, calls.callService, cfg.VoiceProvider.WebhookArchiveRetention, logger)
+	}
+
+	// Outgoing webhooks (events are queued as deliveries and a worker sends
+	// them signed, retrying with backoff)
+	outgoingWebhookService := service.NewOutgoingWebhookService(
+		repos.webhookSubscriptionRepo,
+		repos.webhookDeliveryRepo,
+		logger,
+		service.DefaultOutgoingWebhookConfig(),
+	)
+	outgoingWebhookService.SetBreakerRegistry(platform.breakers)
+
+	// Events feed (every call and quote change is recorded for polling
+	// integrations and passed on to webhook subscribers)
+	eventService := service.NewEventService(repos.eventRepo, logger, nil)
+	eventService.SetWebhooks(outgoingWebhookService)
+	calls.callService.SetEventPublisher(eventService)
+	calls.blandService.SetEventPublisher(eventService)
+	calls.providerDialer.SetEventPublisher(eventService)
+	calls.jobProcessor.SetEventPublisher(eventService)
+	quoteService.SetEventPublisher(eventService)
+
+	// Customer conversations (texts and WhatsApp messages, sent on the
+	// channel each customer prefers, kept as the inbox)
+	conversationService := service.NewConversationService(
+		repository.NewConversationMessageRepository(db.Pool),
+		repository.NewWhatsAppOptInRepository(db.Pool),
+		repos.customerRepo,
+		repos.callRepo,
+		repos.quoteRepo,
+		logger,
+		&service.ConversationServiceConfig{
+			BusinessName:     cfg.CallSettings.BusinessName,
+			SMSFrom:          cfg.Messaging.SMSFrom,
+			Currency:         cfg.QuotePDF.Currency,
+			WhatsAppTemplate: cfg.WhatsApp.QuoteTemplate,
+			WhatsAppLanguage: cfg.WhatsApp.TemplateLanguage,
+		},
+	)
+	if calls.blandEnabled {
+		conversationService.SetSMS(calls.blandService)
+	}
+	conversationService.SetDoNotCall(compliance.complianceService)
+	var whatsAppClient *whatsapp.Client
+	if cfg.WhatsApp.Enabled() {
+		var err error
+		whatsAppClient, err = whatsapp.New(whatsapp.Config{
+			AccessToken:   cfg.WhatsApp.AccessToken,
+			PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+			AppSecret:     cfg.WhatsApp.AppSecret,
+			VerifyToken:   cfg.WhatsApp.VerifyToken,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure WhatsApp: %w", err)
+		}
+		conversationService.SetWhatsApp(whatsAppClient)
+		logger.Info("WhatsApp messaging enabled", zap.String("phone_number_id", cfg.WhatsApp.PhoneNumberID))
+	}
+	conversationService.SetQuoteLinks(quotes.quotePortalService)
+	if cfg.Messaging.QuoteReady {
+		// Text (or WhatsApp) customers when their quote is sent
+		quoteService.SetMessenger(conversationService)
+	}
+
+	// Accounting sync (accepted quotes pushed to QuickBooks Online or Xero)
+	accountingConnector, err := accounting.New(accounting.Config{
+		Provider:     cfg.Accounting.Provider,
+		ClientID:     cfg.Accounting.ClientID,
+		ClientSecret: cfg.Accounting.ClientSecret,
+		Sandbox:      cfg.Accounting.Sandbox,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure accounting sync: %w", err)
+	}
+	accountingDocument, err := accounting.ParseDocumentKind(cfg.Accounting.Document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure accounting sync: %w", err)
+	}
+	var accountingService *service.AccountingService
+	if accountingConnector != nil {
+		if cfg.App.PublicURL == "" {
+			return nil, errors.New("APP_PUBLIC_URL is required for the accounting OAuth callback")
+		}
+		accountingService = service.NewAccountingService(repository.NewAccountingRepository(db.Pool), quoteService, repos.callRepo, accountingConnector, service.AccountingConfig{
+			Document:    accountingDocument,
+			AutoSync:    cfg.Accounting.AutoSync,
+			RedirectURL: cfg.App.PublicURL + "/accounting/callback",
+		}, logger, nil)
+		quoteService.SetAccounting(accountingService)
+		logger.Info("accounting sync enabled",
+			zap.String("provider", accountingConnector.Name()),
+			zap.String("document", string(accountingDocument)),
+			zap.Bool("auto_sync", cfg.Accounting.AutoSync),
+		)
+	}
+
+	// CRM sync (optional; queues a sync for each completed call and quote change)
+	crmClient, err := crm.New(crm.Config{
+		Provider:               cfg.CRM.Provider,
+		HubSpotToken:           cfg.CRM.HubSpotToken,
+		SalesforceURL:          cfg.CRM.SalesforceURL,
+		SalesforceClientID:     cfg.CRM.SalesforceClientID,
+		SalesforceClientSecret: cfg.CRM.SalesforceClientSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure crm sync: %w", err)
+	}
+	var crmService *service.CRMService
+	if crmClient != nil {
+		crmService = service.NewCRMService(repository.NewCRMSyncRepository(db.Pool), repos.callRepo, quoteService, platform.settingsService, crmClient, logger, nil, nil)
+		eventService.SetCRM(crmService)
+		logger.Info("crm sync enabled", zap.String("provider", crmClient.Name()))
+	}
+
+	return &integrationServices{
+		webhookEventProcessor:  webhookEventProcessor,
+		webhookDedup:           webhookDedup,
+		webhookArchive:         webhookArchive,
+		outgoingWebhookService: outgoingWebhookService,
+		eventService:           eventService,
+		conversationService:    conversationService,
+		whatsAppClient:         whatsAppClient,
+		accountingService:      accountingService,
+		crmService:             crmService,
+	}, nil
+}
+
+// integrationRoutes are the provider and WhatsApp webhooks, the message
+// inbox, and the outgoing webhook, event, accounting and CRM pages and
+// APIs.
+type integrationRoutes struct {
+	webhooks             *handler.WebhookHandler
+	whatsAppWebhooks     *handler.WhatsAppWebhookHandler
+	conversations        *handler.ConversationHandler
+	webhookSubscriptions *handler.WebhookSubscriptionHandler
+	webhookArchive       *handler.WebhookArchiveHandler
+	accounting           *handler.AccountingHandler
+	crm                  *handler.CRMHandler
+	webhookAPI           *handler.WebhookAPIHandler
+	eventAPI             *handler.EventAPIHandler
+}
+
+// newIntegrationRoutes builds the integration handlers. Provider webhooks
+// report live call activity to liveHub.
+func newIntegrationRoutes(cfg *config.Config, logger *zap.Logger, base handler.BaseHandlerConfig, appMetrics *metrics.Metrics, liveHub *realtime.Hub, svcs *appServices) *integrationRoutes {
+	var whatsAppWebhookHandler *handler.WhatsAppWebhookHandler
+	if svcs.whatsAppClient != nil {
+		whatsAppWebhookHandler = handler.NewWhatsAppWebhookHandler(svcs.whatsAppClient, svcs.conversationService, logger)
+	}
+
+	// Webhook handler for voice provider callbacks
+	// Bland call and SMS webhooks must be signed with the webhook secret;
+	// without a secret (only allowed outside production) they are not
+	// verified
+	var blandSignature func(http.Handler) http.Handler
+	if svcs.toolSecret != "" {
+		blandSignature = middleware.WebhookSignature(middleware.WebhookSignatureConfig{
+			Provider:  string(voiceprovider.ProviderBland),
+			Secret:    svcs.toolSecret,
+			Mode:      cfg.VoiceProvider.Bland.SignatureMode,
+			Tolerance: cfg.VoiceProvider.Bland.SignatureTolerance,
+			Headers:   []string{middleware.WebhookSignatureHeader, "X-Webhook-Secret", "X-Bland-Signature"},
+			Logger:    logger,
+			Metrics:   appMetrics,
+		})
+		logger.Info("verifying Bland webhook signatures", zap.String("mode", cfg.VoiceProvider.Bland.SignatureMode))
+	} else {
+		logger.Warn("Bland webhooks are not verified: no webhook secret configured")
+	}
+	webhookHandler := handler.NewWebhookHandler(handler.WebhookHandlerConfig{
+		CallService:      svcs.callService,
+		WebhookEvents:    svcs.webhookEventProcessor,
+		Dedup:            svcs.webhookDedup,
+		Archive:          svcs.webhookArchive,
+		ProviderRegistry: svcs.providerRegistry,
+		Logger:           logger,
+		Metrics:          appMetrics,
+		Notifier:         svcs.notifier,
+		Transcription:    svcs.transcriptionService,
+		Compliance:       svcs.complianceService,
+		Live:             liveHub,
+		Blocklist:        svcs.blocklistService,
+		Overflow:         svcs.overflowService,
+		Routing:          svcs.routingService,
+		Spam:             svcs.spamScreen,
+		Conversations:    svcs.conversationService,
+		BlandSignature:   blandSignature,
+	})
+
+	// Webhook archive handler for inspecting raw provider webhooks
+	var webhookArchiveHandler *handler.WebhookArchiveHandler
+	if svcs.webhookArchive != nil {
+		webhookArchiveHandler = handler.NewWebhookArchiveHandler(handler.WebhookArchiveHandlerConfig{
+			Base:    base,
+			Archive: svcs.webhookArchive,
+		})
+	}
+
+	return &integrationRoutes{
+		webhooks:         webhookHandler,
+		whatsAppWebhooks: whatsAppWebhookHandler,
+		// Conversation handler for the customer message inbox
+		conversations: handler.NewConversationHandler(handler.ConversationHandlerConfig{
+			Base:          base,
+			Conversations: svcs.conversationService,
+		}),
+		// Webhook handler for the outgoing webhook admin pages
+		webhookSubscriptions: handler.NewWebhookSubscriptionHandler(handler.WebhookSubscriptionHandlerConfig{
+			Base:           base,
+			WebhookService: svcs.outgoingWebhookService,
+		}),
+		webhookArchive: webhookArchiveHandler,
+		// Accounting handler for the accounting connection admin page
+		accounting: handler.NewAccountingHandler(handler.AccountingHandlerConfig{
+			Base:              base,
+			AccountingService: svcs.accountingService,
+			ProjectTypes:      cfg.CallSettings.GetProjectTypes(),
+		}),
+		// CRM handler for the field mapping and sync error queue admin page
+		crm: handler.NewCRMHandler(handler.CRMHandlerConfig{
+			Base:       base,
+			CRMService: svcs.crmService,
+		}),
+		webhookAPI: handler.NewWebhookAPIHandler(svcs.outgoingWebhookService, logger),
+		eventAPI:   handler.NewEventAPIHandler(svcs.eventService, logger),
+	}
+}
+
+// registerWebhooks registers the voice provider and WhatsApp webhooks.
+func (i *integrationRoutes) registerWebhooks(r chi.Router) {
+	i.webhooks.RegisterRoutes(r)
+	if i.whatsAppWebhooks != nil {
+		i.whatsAppWebhooks.RegisterRoutes(r)
+	}
+}
+
+// registerPages registers the customer message inbox.
+func (i *integrationRoutes) registerPages(r chi.Router) {
+	i.conversations.RegisterRoutes(r)
+}
+
+// registerAdminPages registers the accounting, CRM, outgoing webhook and
+// webhook archive admin pages.
+func (i *integrationRoutes) registerAdminPages(r chi.Router) {
+	// Accounting connection and item mapping
+	i.accounting.RegisterRoutes(r)
+
+	// CRM field mapping and sync errors
+	i.crm.RegisterRoutes(r)
+
+	// Outgoing webhooks
+	i.webhookSubscriptions.RegisterRoutes(r)
+
+	// Raw provider webhook archive
+	if i.webhookArchive != nil {
+		i.webhookArchive.RegisterRoutes(r)
+	}
+}
+
+// registerAPI registers the webhook and event API routes.
+func (i *integrationRoutes) registerAPI(r chi.Router) {
+	i.webhookAPI.RegisterRoutes(r)
+	i.eventAPI.RegisterRoutes(r)
+}
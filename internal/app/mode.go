@@ -0,0 +1,39 @@
+package app
+
+import "fmt"
+
+// Mode chooses which parts of the app a process runs, so the web server
+// and the background workers can be deployed and scaled separately against
+// the same database.
+type Mode string
+
+const (
+	// ModeAll serves every route and runs every worker, as a single
+	// deployment does.
+	ModeAll Mode = "all"
+	// ModeServer serves every route but leaves the queue and sync workers
+	// to worker processes.
+	ModeServer Mode = "server"
+	// ModeWorker runs the queue and sync workers, serving only health
+	// checks and metrics.
+	ModeWorker Mode = "worker"
+)
+
+// ParseMode parses a --mode value.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeAll, ModeServer, ModeWorker:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid mode %q; use all, server or worker", s)
+}
+
+// runsWorkers reports whether the mode runs the queue and sync workers.
+func (m Mode) runsWorkers() bool {
+	return m != ModeServer
+}
+
+// servesRoutes reports whether the mode serves the web app and APIs.
+func (m Mode) servesRoutes() bool {
+	return m != ModeWorker
+}
@@ -0,0 +1,169 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/notification/chatops"
+	"github.com/jkindrix/quickquote/internal/notification/email"
+	"github.com/jkindrix/quickquote/internal/notification/sms"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// notificationServices are the email, chat and SMS notifications sent to
+// staff and customers, and the preferences of who gets which.
+type notificationServices struct {
+	emailNotifier           *email.Notifier
+	chatNotifier            *chatops.Notifier
+	smsNotifier             *sms.Notifier
+	notificationPreferences *service.NotificationPreferenceService
+	notifier                service.MultiNotifier
+}
+
+// newNotificationServices builds the notifiers and hands them to the
+// services that send notifications.
+func newNotificationServices(cfg *config.Config, logger *zap.Logger, db *database.DB, platform *platformServices, auth *authServices, calls *callServices, quotes *quoteServices) (*notificationServices, error) {
+	// Initialize email notifications (no-op sender when no provider is configured)
+	emailSender, err := email.NewSender(email.Config{
+		Provider:       cfg.Email.Provider,
+		From:           cfg.Email.From,
+		FromName:       cfg.Email.FromName,
+		SMTPHost:       cfg.Email.SMTPHost,
+		SMTPPort:       cfg.Email.SMTPPort,
+		SMTPUsername:   cfg.Email.SMTPUsername,
+		SMTPPassword:   cfg.Email.SMTPPassword,
+		SendGridAPIKey: cfg.Email.SendGridAPIKey,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure email: %w", err)
+	}
+	if _, disabled := emailSender.(*email.NoopSender); !disabled {
+		breakerSender := email.NewBreakerSender(emailSender, circuitbreaker.New("email", nil, logger))
+		platform.breakers.Register(breakerSender.CircuitBreaker())
+		emailSender = breakerSender
+	}
+	emailNotifier := email.NewNotifier(emailSender, quotes.quotePDFService, email.NotifierConfig{
+		BusinessName:    cfg.CallSettings.BusinessName,
+		StaffRecipients: cfg.Email.GetStaffRecipients(),
+		PublicURL:       cfg.App.PublicURL,
+	}, logger, nil)
+	logger.Info("email notifications configured", zap.String("backend", emailSender.Name()))
+
+	// Chat notifications post to Slack or Teams channels alongside email
+	chatNotifierConfig := chatops.Config{
+		URLs: map[chatops.Event]string{
+			chatops.EventCallCompleted: cfg.ChatOps.CallCompletedURL,
+			chatops.EventQuoteReady:    cfg.ChatOps.QuoteReadyURL,
+			chatops.EventUsageAlert:    cfg.ChatOps.UsageAlertURL,
+			chatops.EventJobFailed:     cfg.ChatOps.JobFailedURL,
+		},
+		QuoteReadyMinTotal: cfg.ChatOps.QuoteReadyMinTotal,
+		Currency:           cfg.QuotePDF.Currency,
+		Templates: map[chatops.Event]string{
+			chatops.EventCallCompleted: cfg.ChatOps.CallCompletedTemplate,
+			chatops.EventQuoteReady:    cfg.ChatOps.QuoteReadyTemplate,
+			chatops.EventUsageAlert:    cfg.ChatOps.UsageAlertTemplate,
+			chatops.EventJobFailed:     cfg.ChatOps.JobFailedTemplate,
+		},
+		BusinessName: cfg.CallSettings.BusinessName,
+		PublicURL:    cfg.App.PublicURL,
+	}
+	chatNotifier, err := chatops.NewNotifier(chatNotifierConfig, logger, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure chat notifications: %w", err)
+	}
+	if chatNotifierConfig.Enabled() {
+		logger.Info("chat notifications configured", zap.Int("events", len(chatNotifier.Configured())))
+	}
+
+	// Per-user notification preferences add the users who opted in to each
+	// event's email, SMS and personal Slack recipients
+	var notificationChannels []domain.NotificationChannel
+	if cfg.Email.Provider != email.ProviderNone {
+		notificationChannels = append(notificationChannels, domain.NotificationChannelEmail)
+	}
+	if calls.blandEnabled && cfg.Notifications.SMSEnabled {
+		notificationChannels = append(notificationChannels, domain.NotificationChannelSMS)
+	}
+	notificationChannels = append(notificationChannels, domain.NotificationChannelSlack)
+	notificationPreferences := service.NewNotificationPreferenceService(
+		repository.NewNotificationPreferenceRepository(db.Pool),
+		notificationChannels,
+		logger,
+		nil,
+	)
+	emailNotifier.SetRecipients(notificationPreferences)
+	chatNotifier.SetRecipients(notificationPreferences)
+	notifier := service.MultiNotifier{emailNotifier, chatNotifier}
+	var smsNotifier *sms.Notifier
+	if notificationPreferences.Available(domain.NotificationChannelSMS) {
+		smsNotifier = sms.NewNotifier(calls.blandService, notificationPreferences, sms.Config{
+			From:         cfg.Notifications.SMSFrom,
+			BusinessName: cfg.CallSettings.BusinessName,
+			PublicURL:    cfg.App.PublicURL,
+			Currency:     cfg.QuotePDF.Currency,
+		}, logger, nil)
+		notifier = append(notifier, smsNotifier)
+	}
+	calls.callService.SetNotifier(notifier)
+	calls.jobProcessor.SetNotifier(notifier)
+	calls.numberHealthService.SetNotifier(notifier)
+	quotes.quotePortalService.SetNotifier(emailNotifier)
+
+	// Password reset and email verification need a real email backend and
+	// a public URL for the links. Invitations work without them; admins
+	// share the link themselves.
+	var accountMailer service.AccountMailer
+	if cfg.Email.Provider != email.ProviderNone && cfg.App.PublicURL != "" {
+		accountMailer = emailNotifier
+		logger.Info("password reset and email verification enabled")
+	} else {
+		logger.Info("password reset and email verification disabled (needs EMAIL_PROVIDER and APP_PUBLIC_URL)")
+	}
+	auth.authService.SetAccountEmail(repository.NewUserTokenRepository(db.Pool), accountMailer, cfg.App.PublicURL)
+
+	return &notificationServices{
+		emailNotifier:           emailNotifier,
+		chatNotifier:            chatNotifier,
+		smsNotifier:             smsNotifier,
+		notificationPreferences: notificationPreferences,
+		notifier:                notifier,
+	}, nil
+}
+
+// notificationRoutes are each user's notification preferences and the chat
+// notification API.
+type notificationRoutes struct {
+	preferences     *handler.NotificationPreferenceHandler
+	notificationAPI *handler.NotificationAPIHandler
+}
+
+// newNotificationRoutes builds the notification handlers.
+func newNotificationRoutes(logger *zap.Logger, base handler.BaseHandlerConfig, svcs *appServices) *notificationRoutes {
+	return &notificationRoutes{
+		// Notification preferences handler for each user's alert settings
+		preferences: handler.NewNotificationPreferenceHandler(handler.NotificationPreferenceHandlerConfig{
+			Base:        base,
+			Preferences: svcs.notificationPreferences,
+		}),
+		notificationAPI: handler.NewNotificationAPIHandler(svcs.chatNotifier, logger),
+	}
+}
+
+// registerPages registers the notification preferences page.
+func (n *notificationRoutes) registerPages(r chi.Router) {
+	n.preferences.RegisterRoutes(r)
+}
+
+// registerAPI registers the notification API routes.
+func (n *notificationRoutes) registerAPI(r chi.Router) {
+	n.notificationAPI.RegisterRoutes(r)
+}
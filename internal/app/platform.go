@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/backup"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/jobs"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/shutdown"
+	"github.com/jkindrix/quickquote/internal/storage"
+)
+
+// platformServices are what every subsystem builds on: the audit log,
+// stored provider keys, circuit breakers, settings, feature flags, backups
+// and the job scheduler.
+type platformServices struct {
+	auditLogger        *audit.Logger
+	secretsService     *service.SecretsService
+	breakers           *circuitbreaker.Registry
+	settingsService    *service.SettingsService
+	featureFlagService *service.FeatureFlagService
+	backupService      *service.BackupService
+	jobScheduler       *jobs.Scheduler
+
+	// location is the business's time zone, which callbacks, calling
+	// windows and follow-up quiet hours default to
+	location *time.Location
+}
+
+// newPlatformServices builds the platform services and re-encrypts stored
+// provider keys under the current master key.
+func newPlatformServices(ctx context.Context, cfg *config.Config, logger *zap.Logger, db *database.DB, appMetrics *metrics.Metrics, repos *repositories) (*platformServices, error) {
+	location, err := time.LoadLocation(cfg.Calendar.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calendar timezone %q: %w", cfg.Calendar.Timezone, err)
+	}
+
+	// Initialize audit logger
+	auditLogger := audit.NewLogger(logger)
+	auditLogger.SetStore(repository.NewAuditRepository(repos.pools))
+	logger.Info("initialized audit logger")
+
+	// Provider API keys set at runtime are stored encrypted and take the
+	// place of the environment's keys when the clients are built
+	secretsService, err := newSecretsService(cfg, repos.providerCredentialRepo, auditLogger, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize provider key storage: %w", err)
+	}
+	if n, err := secretsService.Reseal(ctx); err != nil {
+		logger.Error("failed to re-encrypt stored provider keys", zap.Error(err))
+	} else if n > 0 {
+		logger.Info("re-encrypted stored provider keys under the current master key", zap.Int("count", n))
+	}
+
+	// Circuit breakers guarding outbound dependencies, reported together
+	// through the API and metrics
+	breakers := circuitbreaker.NewRegistry()
+	breakers.OnStateChange(appMetrics.CircuitBreakerStateChanged)
+
+	// Initialize settings service (needed by BlandService)
+	settingsService := service.NewSettingsService(repos.settingsRepo, logger)
+	settingsService.SetCacheTTL(cfg.Cache.TTL)
+	settingsService.SetDefaultCurrency(cfg.QuotePDF.Currency)
+	logger.Info("initialized settings service")
+
+	// Feature flags, evaluated per user on each authenticated request
+	featureFlagService := service.NewFeatureFlagService(repos.featureFlagRepo, auditLogger, logger)
+	featureFlagService.SetCacheTTL(cfg.Cache.TTL)
+
+	// Initialize database backups, kept in their own storage
+	backupStore, err := storage.New(storage.Config{
+		Backend:           cfg.Backup.Storage,
+		LocalPath:         cfg.Backup.LocalPath,
+		S3Endpoint:        cfg.Backup.S3Endpoint,
+		S3Region:          cfg.Backup.S3Region,
+		S3Bucket:          cfg.Backup.S3Bucket,
+		S3AccessKeyID:     cfg.Backup.S3AccessKeyID,
+		S3SecretAccessKey: cfg.Backup.S3SecretAccessKey,
+		S3PathStyle:       cfg.Backup.S3PathStyle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure backup storage: %w", err)
+	}
+	var backupService *service.BackupService
+	if backupStore != nil {
+		backupService = service.NewBackupService(
+			repository.NewBackupRepository(db.Pool),
+			backupStore,
+			&backup.Dumper{Path: cfg.Backup.PgDumpPath, DB: &cfg.Database},
+			logger,
+			&service.BackupServiceConfig{
+				Retention: time.Duration(cfg.Backup.RetentionDays) * 24 * time.Hour,
+				Timeout:   cfg.Backup.Timeout,
+				BatchSize: 50,
+			},
+		)
+	}
+
+	// Background jobs, registered and started once everything is wired
+	jobScheduler := jobs.NewScheduler(logger)
+	jobScheduler.SetMetrics(appMetrics)
+
+	return &platformServices{
+		auditLogger:        auditLogger,
+		secretsService:     secretsService,
+		breakers:           breakers,
+		settingsService:    settingsService,
+		featureFlagService: featureFlagService,
+		backupService:      backupService,
+		jobScheduler:       jobScheduler,
+		location:           location,
+	}, nil
+}
+
+// platformRoutes are the health checks and the admin tools for running the
+// server.
+type platformRoutes struct {
+	health            *handler.HealthHandler
+	logLevel          *handler.LogLevelHandler
+	config            *handler.ConfigHandler
+	drain             *handler.DrainHandler
+	cache             *handler.CacheHandler
+	jobs              *handler.JobsHandler
+	backups           *handler.BackupHandler
+	featureFlags      *handler.FeatureFlagHandler
+	audit             *handler.AuditHandler
+	featureFlagAPI    *handler.FeatureFlagAPIHandler
+	auditAPI          *handler.AuditAPIHandler
+	providerKeyAPI    *handler.ProviderKeyAPIHandler
+	circuitBreakerAPI *handler.CircuitBreakerAPIHandler
+}
+
+// newPlatformRoutes builds the platform handlers.
+func newPlatformRoutes(logger *zap.Logger, o options, base handler.BaseHandlerConfig, db *database.DB, configReloader *config.Reloader, shutdownCoord *shutdown.Coordinator, svcs *appServices) *platformRoutes {
+	// Health handler for health check endpoints
+	readinessProbe := shutdown.NewReadinessProbe(shutdownCoord)
+	healthHandler := handler.NewHealthHandler(handler.HealthHandlerConfig{
+		HealthChecker:    db,
+		AIHealthChecker:  svcs.claudeClient,
+		ProviderRegistry: svcs.providerRegistry,
+		Monitor:          svcs.healthMonitor,
+		Readiness:        readinessProbe,
+		Logger:           logger,
+	})
+
+	// Background jobs and backups, run on demand by admins
+	var backupManager handler.BackupManager
+	if svcs.backupService != nil {
+		backupManager = svcs.backupService
+	}
+
+	return &platformRoutes{
+		health: healthHandler,
+		// Log level handler for runtime adjustment
+		logLevel: handler.NewLogLevelHandler(o.logLevel, logger),
+		config:   handler.NewConfigHandler(configReloader),
+		drain:    handler.NewDrainHandler(shutdownCoord, logger),
+		cache:    handler.NewCacheHandler(svcs.settingsService, svcs.promptService),
+		jobs:     handler.NewJobsHandler(svcs.jobScheduler, svcs.auditLogger, logger),
+		backups:  handler.NewBackupHandler(backupManager, svcs.auditLogger, logger),
+		// Feature flag admin page
+		featureFlags: handler.NewFeatureFlagHandler(handler.FeatureFlagHandlerConfig{
+			Base:        base,
+			FlagService: svcs.featureFlagService,
+			UserService: svcs.userService,
+		}),
+		// Audit log for admins
+		audit: handler.NewAuditHandler(handler.AuditHandlerConfig{
+			Base:        base,
+			AuditLogger: svcs.auditLogger,
+		}),
+		featureFlagAPI:    handler.NewFeatureFlagAPIHandler(svcs.featureFlagService, logger),
+		auditAPI:          handler.NewAuditAPIHandler(svcs.auditLogger, logger),
+		providerKeyAPI:    handler.NewProviderKeyAPIHandler(svcs.secretsService, logger),
+		circuitBreakerAPI: handler.NewCircuitBreakerAPIHandler(svcs.breakers, svcs.auditLogger, logger),
+	}
+}
+
+// registerAdminPages registers the admin tools.
+func (p *platformRoutes) registerAdminPages(r chi.Router) {
+	// Admin API for runtime log level adjustment
+	r.Handle("/admin/log-level", p.logLevel)
+
+	// Effective configuration, secrets masked
+	r.Handle("/admin/config", p.config)
+
+	// Drain ahead of a deploy: fail /ready and stop taking on new work
+	r.Handle("/admin/drain", p.drain)
+
+	// Settings and prompt cache statistics
+	r.Handle("/admin/cache", p.cache)
+
+	// Background job status, and running a job now
+	p.jobs.RegisterRoutes(r)
+
+	// Database backups: list, take one now, download
+	p.backups.RegisterRoutes(r)
+
+	// Audit log
+	p.audit.RegisterRoutes(r)
+
+	// Feature flags
+	p.featureFlags.RegisterRoutes(r)
+}
+
+// registerAPI registers the platform API routes.
+func (p *platformRoutes) registerAPI(r chi.Router) {
+	p.featureFlagAPI.RegisterRoutes(r)
+	p.providerKeyAPI.RegisterRoutes(r)
+	p.circuitBreakerAPI.RegisterRoutes(r)
+	p.auditAPI.RegisterRoutes(r)
+}
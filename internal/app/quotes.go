@@ -0,0 +1,266 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/captcha"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/payments"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// quoteServices are the quote review workflow, quote PDFs, customer quote
+// links, follow-ups, the public quote form and payments.
+type quoteServices struct {
+	quoteService             *service.QuoteService
+	quotePDFService          *quotepdf.Service
+	bulkService              *service.BulkService
+	followUpService          *service.FollowUpService
+	quotePortalService       *service.QuotePortalService
+	quoteRequestService      *service.QuoteRequestService
+	quoteFormCaptchaProvider string
+	paymentService           *service.PaymentService
+}
+
+// newQuoteServices builds the quote services.
+func newQuoteServices(cfg *config.Config, logger *zap.Logger, db *database.DB, repos *repositories, platform *platformServices, calls *callServices, compliance *complianceServices) (*quoteServices, error) {
+	// Initialize quote PDF service
+	quotePDFService := quotepdf.NewService(repos.callRepo, platform.settingsService, quotepdf.Config{
+		ValidityDays:         cfg.QuotePDF.ValidityDays,
+		Currency:             cfg.QuotePDF.Currency,
+		AttachToFollowUps:    cfg.QuotePDF.AttachToFollowUps,
+		FallbackBusinessName: cfg.CallSettings.BusinessName,
+	}, logger)
+	quotePDFService.SetQuoteReader(repos.quoteRepo)
+	quotePDFService.SetTemplates(calls.quoteTemplateService)
+
+	// Quote review workflow
+	quoteService := service.NewQuoteService(repos.quoteRepo, repos.callRepo, platform.auditLogger, service.QuoteApprovalConfig{
+		Threshold: cfg.QuoteApproval.Threshold,
+		Approvers: cfg.QuoteApproval.GetApprovers(),
+	}, logger)
+	quoteService.SetCurrencies(platform.settingsService)
+
+	// Bulk actions on calls and quotes from the API and the calls list
+	bulkService := service.NewBulkService(repos.callRepo, repos.callRepo, repos.userRepo, calls.callArchiveService, quoteService, calls.tagService, calls.jobProcessor, platform.auditLogger, logger, &service.BulkServiceConfig{
+		MaxItems: cfg.Bulk.MaxItems,
+	})
+
+	// Quote follow-ups (texts and calls scheduled when a quote is sent,
+	// skipped in quiet hours and stopped once the customer responds)
+	followUpSequence, err := domain.ParseFollowUpSequence(cfg.FollowUp.Sequence)
+	if err != nil {
+		return nil, fmt.Errorf("invalid follow-up sequence %q: %w", cfg.FollowUp.Sequence, err)
+	}
+	followUpLocation := platform.location
+	if cfg.FollowUp.Timezone != "" {
+		if followUpLocation, err = time.LoadLocation(cfg.FollowUp.Timezone); err != nil {
+			return nil, fmt.Errorf("invalid follow-up timezone %q: %w", cfg.FollowUp.Timezone, err)
+		}
+	}
+	quietHours, err := domain.ParseQuietHours(cfg.FollowUp.QuietHoursStart, cfg.FollowUp.QuietHoursEnd, followUpLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid follow-up quiet hours: %w", err)
+	}
+	followUpConfig := service.DefaultFollowUpServiceConfig()
+	followUpConfig.Sequence = followUpSequence
+	followUpConfig.QuietHours = quietHours
+	followUpConfig.PollInterval = cfg.FollowUp.PollInterval
+	followUpService := service.NewFollowUpService(repos.followUpRepo, repos.quoteRepo, repos.callRepo, repos.customerRepo, calls.blandService, logger, followUpConfig, nil)
+	followUpService.SetDoNotCall(compliance.complianceService)
+	quoteService.SetFollowUps(followUpService)
+
+	// Customer quote links (customers view, accept or decline a sent quote
+	// without signing in)
+	quotePortalService := service.NewQuotePortalService(repository.NewQuoteLinkRepository(db.Pool), quoteService, repos.callRepo, service.QuotePortalConfig{
+		LinkTTL:   cfg.QuotePortal.LinkTTL,
+		PublicURL: cfg.App.PublicURL,
+	}, logger, nil)
+	if cfg.QuotePortal.ConfirmationSMS {
+		quotePortalService.SetConfirmationSender(calls.blandService)
+		quotePortalService.SetDoNotCall(compliance.complianceService)
+	}
+
+	// Public quote form: website submissions become calls that are quoted
+	// like phone calls, with an optional AI callback
+	quoteRequestConfig := &service.QuoteRequestServiceConfig{Callbacks: cfg.QuoteForm.Callbacks}
+	if cfg.QuoteForm.CallbackPromptID != "" {
+		callbackPromptID, err := uuid.Parse(cfg.QuoteForm.CallbackPromptID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quote form callback prompt ID %q: %w", cfg.QuoteForm.CallbackPromptID, err)
+		}
+		quoteRequestConfig.CallbackPromptID = &callbackPromptID
+	}
+	quoteRequestService := service.NewQuoteRequestService(repos.callRepo, calls.jobProcessor, logger, quoteRequestConfig)
+	quoteRequestService.SetCustomers(calls.customerService)
+	if calls.blandEnabled {
+		quoteRequestService.SetDialer(calls.blandService)
+	}
+	quoteFormCaptcha, err := captcha.New(cfg.QuoteForm.CaptchaProvider, cfg.QuoteForm.CaptchaSecret, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure quote form captcha: %w", err)
+	}
+	var quoteFormCaptchaProvider string
+	if quoteFormCaptcha != nil {
+		quoteRequestService.SetCaptcha(quoteFormCaptcha)
+		quoteFormCaptchaProvider = quoteFormCaptcha.Provider()
+	} else if cfg.QuoteForm.Enabled {
+		logger.Warn("quote form enabled without a captcha (set QUOTE_FORM_CAPTCHA_PROVIDER)")
+	}
+
+	// Quote payments (payment links for accepted quotes, marked paid by the
+	// processor's webhook)
+	paymentProcessor, err := payments.New(payments.Config{
+		Provider:            cfg.Payments.Provider,
+		StripeSecretKey:     cfg.Payments.StripeSecretKey,
+		StripeWebhookSecret: cfg.Payments.StripeWebhookSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure payments: %w", err)
+	}
+	var paymentService *service.PaymentService
+	if paymentProcessor != nil {
+		quotePaymentRepo := repository.NewQuotePaymentRepository(db.Pool)
+		quoteService.SetPayments(quotePaymentRepo)
+		paymentService, err = service.NewPaymentService(quotePaymentRepo, quoteService, paymentProcessor, service.PaymentConfig{
+			DepositPercent: cfg.Payments.DepositPercent,
+			Currency:       cfg.QuotePDF.Currency,
+		}, logger, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure payments: %w", err)
+		}
+		logger.Info("quote payments enabled",
+			zap.String("provider", paymentProcessor.Name()),
+			zap.Float64("deposit_percent", cfg.Payments.DepositPercent),
+		)
+	}
+
+	return &quoteServices{
+		quoteService:             quoteService,
+		quotePDFService:          quotePDFService,
+		bulkService:              bulkService,
+		followUpService:          followUpService,
+		quotePortalService:       quotePortalService,
+		quoteRequestService:      quoteRequestService,
+		quoteFormCaptchaProvider: quoteFormCaptchaProvider,
+		paymentService:           paymentService,
+	}, nil
+}
+
+// quoteRoutes are the quote editor, the customer quote pages, the public
+// quote form, pricing and the quote APIs.
+type quoteRoutes struct {
+	quotes          *handler.QuoteHandler
+	portal          *handler.QuotePortalHandler
+	requestAPI      *handler.QuoteRequestAPIHandler
+	pricing         *handler.PricingHandler
+	templates       *handler.QuoteTemplateHandler
+	jobs            *handler.QuoteJobHandler
+	paymentWebhooks *handler.PaymentWebhookHandler
+	quoteAPI        *handler.QuoteAPIHandler
+	templateAPI     *handler.QuoteTemplateAPIHandler
+	jobAPI          *handler.QuoteJobAPIHandler
+}
+
+// newQuoteRoutes builds the quote handlers.
+func newQuoteRoutes(cfg *config.Config, logger *zap.Logger, base handler.BaseHandlerConfig, svcs *appServices) *quoteRoutes {
+	// Payment processor webhooks mark quotes paid
+	var paymentWebhookHandler *handler.PaymentWebhookHandler
+	if svcs.paymentService != nil {
+		paymentWebhookHandler = handler.NewPaymentWebhookHandler(svcs.paymentService, logger)
+	}
+
+	quoteAPIHandler := handler.NewQuoteAPIHandler(svcs.quotePDFService, logger)
+	quoteAPIHandler.SetExportService(svcs.exportService)
+	quoteAPIHandler.SetQuoteService(svcs.quoteService)
+	quoteAPIHandler.SetFollowUpService(svcs.followUpService)
+	quoteAPIHandler.SetQuotePortalService(svcs.quotePortalService)
+	quoteAPIHandler.SetPaymentService(svcs.paymentService)
+	quoteAPIHandler.SetAccountingService(svcs.accountingService)
+	quoteAPIHandler.SetBulkService(svcs.bulkService)
+
+	return &quoteRoutes{
+		// Quote handler for the quote editor
+		quotes: handler.NewQuoteHandler(handler.QuoteHandlerConfig{
+			Base:              base,
+			QuoteService:      svcs.quoteService,
+			PortalService:     svcs.quotePortalService,
+			PaymentService:    svcs.paymentService,
+			AccountingService: svcs.accountingService,
+		}),
+		// Quote portal handler for customers following their quote link
+		portal: handler.NewQuotePortalHandler(handler.QuotePortalHandlerConfig{
+			Base:          base,
+			PortalService: svcs.quotePortalService,
+			PDFService:    svcs.quotePDFService,
+		}),
+		// Public quote request API for the embeddable form
+		requestAPI: handler.NewQuoteRequestAPIHandler(svcs.quoteRequestService, handler.QuoteRequestAPIConfig{
+			CaptchaProvider: svcs.quoteFormCaptchaProvider,
+			CaptchaSiteKey:  cfg.QuoteForm.CaptchaSiteKey,
+			ProjectTypes:    cfg.CallSettings.GetProjectTypes(),
+		}, logger),
+		// Pricing handler for the pricing rule admin pages
+		pricing: handler.NewPricingHandler(handler.PricingHandlerConfig{
+			Base:           base,
+			PricingService: svcs.pricingService,
+		}),
+		// Quote template handler for the quote template admin pages
+		templates: handler.NewQuoteTemplateHandler(handler.QuoteTemplateHandlerConfig{
+			Base:            base,
+			TemplateService: svcs.quoteTemplateService,
+		}),
+		// Quote job handler for the dead-letter queue admin pages
+		jobs: handler.NewQuoteJobHandler(handler.QuoteJobHandlerConfig{
+			Base:         base,
+			JobProcessor: svcs.jobProcessor,
+			CallService:  svcs.callService,
+		}),
+		paymentWebhooks: paymentWebhookHandler,
+		quoteAPI:        quoteAPIHandler,
+		templateAPI:     handler.NewQuoteTemplateAPIHandler(svcs.quoteTemplateService, logger),
+		jobAPI:          handler.NewQuoteJobAPIHandler(svcs.jobProcessor, svcs.quoteJobEvents, logger),
+	}
+}
+
+// registerWebhooks registers the payment processor's webhook.
+func (q *quoteRoutes) registerWebhooks(r chi.Router) {
+	if q.paymentWebhooks != nil {
+		q.paymentWebhooks.RegisterRoutes(r)
+	}
+}
+
+// registerPages registers the quote editor.
+func (q *quoteRoutes) registerPages(r chi.Router) {
+	q.quotes.RegisterRoutes(r)
+}
+
+// registerAdminPages registers pricing, quote templates and the quote job
+// dead-letter queue.
+func (q *quoteRoutes) registerAdminPages(r chi.Router) {
+	// Pricing rules
+	q.pricing.RegisterRoutes(r)
+
+	// Quote templates
+	q.templates.RegisterRoutes(r)
+
+	// Quote job dead-letter queue
+	q.jobs.RegisterRoutes(r)
+}
+
+// registerAPI registers the quote API routes.
+func (q *quoteRoutes) registerAPI(r chi.Router) {
+	q.quoteAPI.RegisterRoutes(r)
+	q.jobAPI.RegisterRoutes(r)
+	q.templateAPI.RegisterRoutes(r)
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/repository"
+)
+
+// repositories are the repositories the services, handlers and workers
+// use.
+type repositories struct {
+	userRepo                *repository.UserRepository
+	sessionRepo             *repository.SessionRepository
+	pools                   repository.Pools
+	callRepo                *repository.CallRepository
+	callEventRepo           *repository.CallEventRepository
+	quoteJobRepo            *repository.QuoteJobRepository
+	webhookEventRepo        *repository.WebhookEventRepository
+	processedWebhookRepo    *repository.ProcessedWebhookRepository
+	webhookArchiveRepo      *repository.WebhookArchiveRepository
+	csrfRepo                *repository.CSRFRepository
+	promptRepo              *repository.PromptRepository
+	promptVersionRepo       *repository.PromptVersionRepository
+	settingsRepo            *repository.SettingsRepository
+	idempotencyRepo         *repository.IdempotencyRepository
+	campaignRepo            *repository.CampaignRepository
+	experimentRepo          *repository.ExperimentRepository
+	analyticsRepo           *repository.AnalyticsRepository
+	analyticsRollupRepo     *repository.AnalyticsRollupRepository
+	webhookSubscriptionRepo *repository.WebhookSubscriptionRepository
+	webhookDeliveryRepo     *repository.WebhookDeliveryRepository
+	eventRepo               *repository.EventRepository
+	budgetRepo              *repository.BudgetRepository
+	quoteRepo               *repository.QuoteRepository
+	customerRepo            *repository.CustomerRepository
+	pricingRuleRepo         *repository.PricingRuleRepository
+	quoteTemplateRepo       *repository.QuoteTemplateRepository
+	callbackRepo            *repository.CallbackRepository
+	followUpRepo            *repository.FollowUpRepository
+	callAttemptRepo         *repository.CallAttemptRepository
+	dncRepo                 *repository.DNCRepository
+	privacyRepo             *repository.PrivacyRepository
+	recordingRepo           *repository.RecordingRepository
+	retentionRepo           *repository.RetentionRepository
+	featureFlagRepo         *repository.FeatureFlagRepository
+	tagRepo                 *repository.TagRepository
+	savedViewRepo           *repository.SavedViewRepository
+	providerCredentialRepo  *repository.ProviderCredentialRepository
+	userRateLimitRepo       *repository.UserRateLimitRepository
+	knowledgeBaseRepo       *repository.KnowledgeBaseRepository
+	pathwayRepo             *repository.PathwayRepository
+	personaRepo             *repository.PersonaRepository
+	blandEntityRepo         *repository.BlandEntityRepository
+	blandWriteRepo          *repository.BlandWriteRepository
+	apiKeyRepo              *repository.APIKeyRepository
+}
+
+// newRepositories builds the repositories. Heavy list, search and analytics
+// queries go to the read replica when one is configured.
+func newRepositories(db *database.DB, logger *zap.Logger) *repositories {
+	pools := repository.NewPools(db.Pool, db.Reader())
+	return &repositories{
+		userRepo:                repository.NewUserRepository(db.Pool),
+		sessionRepo:             repository.NewSessionRepository(db.Pool),
+		pools:                   pools,
+		callRepo:                repository.NewCallRepository(pools),
+		callEventRepo:           repository.NewCallEventRepository(db.Pool),
+		quoteJobRepo:            repository.NewQuoteJobRepository(db.Pool),
+		webhookEventRepo:        repository.NewWebhookEventRepository(db.Pool),
+		processedWebhookRepo:    repository.NewProcessedWebhookRepository(db.Pool),
+		webhookArchiveRepo:      repository.NewWebhookArchiveRepository(db.Pool),
+		csrfRepo:                repository.NewCSRFRepository(db.Pool),
+		promptRepo:              repository.NewPromptRepository(db.Pool),
+		promptVersionRepo:       repository.NewPromptVersionRepository(db.Pool),
+		settingsRepo:            repository.NewSettingsRepository(db.Pool),
+		idempotencyRepo:         repository.NewIdempotencyRepository(db.Pool, logger),
+		campaignRepo:            repository.NewCampaignRepository(db.Pool),
+		experimentRepo:          repository.NewExperimentRepository(db.Pool),
+		analyticsRepo:           repository.NewAnalyticsRepository(pools),
+		analyticsRollupRepo:     repository.NewAnalyticsRollupRepository(pools),
+		webhookSubscriptionRepo: repository.NewWebhookSubscriptionRepository(db.Pool),
+		webhookDeliveryRepo:     repository.NewWebhookDeliveryRepository(db.Pool),
+		eventRepo:               repository.NewEventRepository(db.Pool),
+		budgetRepo:              repository.NewBudgetRepository(db.Pool),
+		quoteRepo:               repository.NewQuoteRepository(db.Pool),
+		customerRepo:            repository.NewCustomerRepository(pools),
+		pricingRuleRepo:         repository.NewPricingRuleRepository(db.Pool),
+		quoteTemplateRepo:       repository.NewQuoteTemplateRepository(db.Pool),
+		callbackRepo:            repository.NewCallbackRepository(db.Pool),
+		followUpRepo:            repository.NewFollowUpRepository(db.Pool),
+		callAttemptRepo:         repository.NewCallAttemptRepository(db.Pool),
+		dncRepo:                 repository.NewDNCRepository(db.Pool),
+		privacyRepo:             repository.NewPrivacyRepository(db.Pool),
+		recordingRepo:           repository.NewRecordingRepository(db.Pool),
+		retentionRepo:           repository.NewRetentionRepository(db.Pool),
+		featureFlagRepo:         repository.NewFeatureFlagRepository(db.Pool),
+		tagRepo:                 repository.NewTagRepository(db.Pool),
+		savedViewRepo:           repository.NewSavedViewRepository(db.Pool),
+		providerCredentialRepo:  repository.NewProviderCredentialRepository(db.Pool),
+		userRateLimitRepo:       repository.NewUserRateLimitRepository(db.Pool, logger),
+		knowledgeBaseRepo:       repository.NewKnowledgeBaseRepository(db.Pool),
+		pathwayRepo:             repository.NewPathwayRepository(db.Pool),
+		personaRepo:             repository.NewPersonaRepository(db.Pool),
+		blandEntityRepo:         repository.NewBlandEntityRepository(db.Pool),
+		blandWriteRepo:          repository.NewBlandWriteRepository(db.Pool),
+		apiKeyRepo:              repository.NewAPIKeyRepository(db.Pool),
+	}
+}
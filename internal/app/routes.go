@@ -0,0 +1,309 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/graphql"
+	"github.com/jkindrix/quickquote/internal/grpcapi"
+	"github.com/jkindrix/quickquote/internal/handler"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/openapi"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/shutdown"
+)
+
+// routes are the router and the servers serving it.
+type routes struct {
+	rateLimiter            *middleware.RateLimiter
+	apiKeyLimiter          *ratelimit.KeyRateLimiter
+	csrfProtection         *middleware.CSRFProtection
+	router                 *chi.Mux
+	quotePortalRateLimiter *middleware.RateLimiter
+	quoteFormRateLimiter   *middleware.RateLimiter
+	server                 *http.Server
+	grpcServer             *grpcapi.Server
+}
+
+// newRoutes builds the handlers, the router serving them and the HTTP and
+// gRPC servers. A worker's server only serves health checks and metrics.
+func newRoutes(cfg *config.Config, logger *zap.Logger, o options, db *database.DB, appMetrics *metrics.Metrics, configReloader *config.Reloader, shutdownCoord *shutdown.Coordinator, repos *repositories, svcs *appServices) (*routes, error) {
+	// Initialize rate limiters
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit.Requests, cfg.RateLimit.Window, logger)
+	userRateLimiter := ratelimit.NewUserRateLimiter(ratelimit.DefaultUserRateLimitConfig(), repos.userRateLimitRepo, logger)
+	apiKeyLimiter := ratelimit.NewKeyRateLimiter()
+
+	// Initialize CSRF protection with database persistence
+	csrfProtection := middleware.NewCSRFProtectionWithRepo(repos.csrfRepo, logger)
+	logger.Info("initialized CSRF protection with database persistence")
+
+	// Initialize template engine
+	templateEngine, err := handler.NewTemplateEngine(filepath.Join(o.rootDir, "web", "templates"), logger)
+	if err != nil {
+		logger.Warn("failed to initialize template engine, using inline templates", zap.Error(err))
+	}
+
+	assetVersion := os.Getenv("ASSET_VERSION")
+	if assetVersion == "" {
+		assetVersion = o.version
+	}
+	if assetVersion == "" || assetVersion == "dev" {
+		assetVersion = fmt.Sprintf("%s-%d", o.version, time.Now().Unix())
+	}
+
+	// Initialize focused handlers with constructor injection
+	baseHandlerCfg := handler.BaseHandlerConfig{
+		TemplateEngine: templateEngine,
+		CSRFProtection: csrfProtection,
+		Logger:         logger,
+		AssetVersion:   assetVersion,
+	}
+
+	platform := newPlatformRoutes(logger, o, baseHandlerCfg, db, configReloader, shutdownCoord, svcs)
+	auth := newAuthRoutes(cfg, logger, baseHandlerCfg, appMetrics, apiKeyLimiter, svcs)
+	calls := newCallRoutes(logger, baseHandlerCfg, repos, svcs)
+	compliance := newComplianceRoutes(logger, baseHandlerCfg, svcs)
+	quotes := newQuoteRoutes(cfg, logger, baseHandlerCfg, svcs)
+	notifications := newNotificationRoutes(logger, baseHandlerCfg, svcs)
+	integrations := newIntegrationRoutes(cfg, logger, baseHandlerCfg, appMetrics, calls.liveHub, svcs)
+
+	// GraphQL endpoint for dashboards, reading calls, quotes, customers and analytics
+	var graphQLAPIHandler *handler.GraphQLAPIHandler
+	if cfg.GraphQL.Enabled {
+		var persisted *graphql.PersistedQueries
+		if dir := cfg.GraphQL.PersistedQueriesDir; dir != "" {
+			persisted, err = graphql.LoadPersistedQueries(dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load persisted GraphQL queries: %w", err)
+			}
+			logger.Info("persisted GraphQL queries loaded",
+				zap.Int("queries", persisted.Len()),
+				zap.Bool("allowlist_only", cfg.GraphQL.AllowlistOnly),
+			)
+		}
+		graphQLAPIHandler, err = handler.NewGraphQLAPIHandler(svcs.callService, svcs.customerService, svcs.quoteService, svcs.analyticsService, graphql.Config{
+			MaxBatchSize:  cfg.GraphQL.MaxBatchSize,
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			Persisted:     persisted,
+			AllowlistOnly: cfg.GraphQL.AllowlistOnly,
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build the GraphQL schema: %w", err)
+		}
+	}
+
+	// Initialize request correlation
+	correlation := middleware.NewRequestCorrelation(logger)
+
+	// Initialize ingress restrictions
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Ingress.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	webhookIngress, err := ingressMiddleware("webhooks", cfg.Ingress.WebhookAllowedIPs, cfg.Ingress.WebhookRequireClientCert, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook allowlist: %w", err)
+	}
+	metricsIngress, err := ingressMiddleware("metrics", cfg.Ingress.MetricsAllowedIPs, cfg.Ingress.MetricsRequireClientCert, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics allowlist: %w", err)
+	}
+	apiIngress, err := ingressMiddleware("api", cfg.Ingress.APIAllowedIPs, cfg.Ingress.APIRequireClientCert, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API allowlist: %w", err)
+	}
+
+	// Initialize router
+	r := chi.NewRouter()
+
+	// Global middleware (order matters)
+	r.Use(middleware.Tracing())
+	// First: start the request span
+	r.Use(correlation.Middleware)
+	// Then: add correlation IDs to it
+	r.Use(middleware.RealIP(trustedProxies))
+	r.Use(middleware.RequestLogger(logger))
+	r.Use(middleware.Recovery(logger))
+	r.Use(chimiddleware.Compress(5))
+	r.Use(middleware.RateLimit(rateLimiter, appMetrics))
+	r.Use(appMetrics.Middleware)
+
+	// CSRF protection (skip webhook endpoints and API routes)
+	r.Use(csrfProtection.SkipPath("/webhook/bland", "/webhook/bland/sms", "/webhook/bland/tools/", handler.PaymentWebhookPath, "/api/public/", "/health", "/ready", "/live", "/metrics"))
+
+	// Serve static files
+	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir(filepath.Join(o.rootDir, "web", "static")))))
+	r.With(metricsIngress...).Handle("/metrics", appMetrics.Handler())
+
+	// Register public routes (auth handlers)
+	auth.auth.RegisterRoutes(r)
+
+	// Register webhook routes (no auth required)
+	r.Group(func(r chi.Router) {
+		r.Use(webhookIngress...)
+		integrations.registerWebhooks(r)
+		calls.registerWebhooks(r)
+		quotes.registerWebhooks(r)
+	})
+
+	// Register customer quote routes (no auth, with their own stricter limit)
+	quotePortalRateLimiter := middleware.NewRateLimiter(cfg.QuotePortal.RateLimit, time.Minute, logger)
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RateLimit(quotePortalRateLimiter, appMetrics))
+		quotes.portal.RegisterRoutes(r)
+	})
+
+	// Register the public quote form API (no auth, callable from other
+	// sites, with its own stricter limit)
+	quoteFormRateLimiter := middleware.NewRateLimiter(cfg.QuoteForm.RateLimit, time.Minute, logger)
+	if cfg.QuoteForm.Enabled {
+		r.Route("/api/public", func(r chi.Router) {
+			r.Use(middleware.CORS(cfg.QuoteForm.GetAllowedOrigins(), http.MethodGet, http.MethodPost))
+			r.Use(middleware.RateLimit(quoteFormRateLimiter, appMetrics))
+			quotes.requestAPI.RegisterRoutes(r)
+		})
+	}
+
+	// Register health check routes
+	platform.health.RegisterRoutes(r)
+
+	// Register protected routes (require authentication)
+	var routeErr error
+	r.Group(func(r chi.Router) {
+		r.Use(auth.auth.Middleware)
+		r.Use(middleware.UserRateLimit(userRateLimiter, logger, appMetrics))
+		r.Use(handler.FeatureFlagMiddleware(svcs.featureFlagService))
+
+		calls.registerPages(r)
+		integrations.registerPages(r)
+		quotes.registerPages(r)
+		auth.registerPages(r)
+		notifications.registerPages(r)
+
+		// Sensitive admin routes, for admins only and gated on two-factor
+		// authentication when required
+		r.Group(func(r chi.Router) {
+			r.Use(auth.auth.RequireAdmin)
+			r.Use(auth.auth.RequireTwoFactor)
+
+			calls.registerAdminPages(r)
+			quotes.registerAdminPages(r)
+			integrations.registerAdminPages(r)
+			compliance.registerAdminPages(r)
+			platform.registerAdminPages(r)
+			auth.registerAdminPages(r)
+		})
+	})
+
+	// Authenticated API routes (JSON responses, no redirects).
+	// Accepts either a session cookie or an API key bearer token.
+	r.Group(func(r chi.Router) {
+		r.Use(apiIngress...)
+		r.Use(auth.auth.APIKeyAuthMiddleware)
+		r.Use(middleware.UserRateLimit(userRateLimiter, logger, appMetrics))
+		r.Use(handler.FeatureFlagMiddleware(svcs.featureFlagService))
+
+		apiRouter := chi.NewRouter()
+		apiRouter.Use(middleware.BodySizeLimiterJSON())
+		apiRouter.Use(middleware.Idempotency(repos.idempotencyRepo, logger))
+		calls.registerAPI(apiRouter)
+		quotes.registerAPI(apiRouter)
+		auth.registerAPI(apiRouter)
+		integrations.registerAPI(apiRouter)
+		notifications.registerAPI(apiRouter)
+		compliance.registerAPI(apiRouter)
+		platform.registerAPI(apiRouter)
+
+		// OpenAPI document and Swagger UI, listing the routes above
+		if cfg.APIDocs.Enabled {
+			openapiHandler, err := openapi.NewHandler(apiRouter, "/api/v1", cfg.APIDocs.SwaggerUIURL, logger)
+			if err != nil {
+				routeErr = fmt.Errorf("failed to load the OpenAPI document: %w", err)
+				return
+			}
+			openapiHandler.RegisterRoutes(apiRouter)
+		}
+		r.Mount("/api/v1", apiRouter)
+
+		if graphQLAPIHandler != nil {
+			graphQLRouter := chi.NewRouter()
+			graphQLRouter.Use(middleware.BodySizeLimiterJSON())
+			graphQLAPIHandler.RegisterRoutes(graphQLRouter)
+			r.Mount("/api/graphql", graphQLRouter)
+		}
+	})
+
+	if routeErr != nil {
+		return nil, routeErr
+	}
+
+	// Workers serve only health checks and metrics, for probes and scraping
+	var serverHandler http.Handler = r
+	if !o.mode.servesRoutes() {
+		wr := chi.NewRouter()
+		wr.Use(middleware.RealIP(trustedProxies))
+		wr.Use(middleware.Recovery(logger))
+		wr.With(metricsIngress...).Handle("/metrics", appMetrics.Handler())
+		platform.health.RegisterRoutes(wr)
+		serverHandler = wr
+	}
+
+	// Create server
+	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      serverHandler,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+	if cfg.Server.TLSCertFile != "" {
+		server.TLSConfig, err = newServerTLSConfig(&cfg.Server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure HTTPS: %w", err)
+		}
+	}
+
+	// Create gRPC server for internal integrations
+	var grpcServer *grpcapi.Server
+	if cfg.GRPC.Enabled && o.mode.servesRoutes() {
+		var creds credentials.TransportCredentials
+		if !cfg.GRPC.Insecure {
+			creds, err = credentials.NewServerTLSFromFile(cfg.GRPC.TLSCertFile, cfg.GRPC.TLSKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load gRPC TLS certificate: %w", err)
+			}
+		}
+		grpcServer = grpcapi.NewServer(grpcapi.Config{
+			Calls:   svcs.callService,
+			Quotes:  svcs.quoteService,
+			Prompts: svcs.promptService,
+			Auth:    svcs.apiKeyService,
+			Limiter: apiKeyLimiter,
+			Creds:   creds,
+			Logger:  logger,
+		})
+	}
+
+	return &routes{
+		rateLimiter:            rateLimiter,
+		apiKeyLimiter:          apiKeyLimiter,
+		csrfProtection:         csrfProtection,
+		router:                 r,
+		quotePortalRateLimiter: quotePortalRateLimiter,
+		quoteFormRateLimiter:   quoteFormRateLimiter,
+		server:                 server,
+		grpcServer:             grpcServer,
+	}, nil
+}
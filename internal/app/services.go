@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/health"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// appServices are the services, clients and notifiers built by New, grouped
+// by subsystem, and the settings derived from the config that the routes
+// and workers share.
+type appServices struct {
+	*platformServices
+	*authServices
+	*callServices
+	*complianceServices
+	*quoteServices
+	*notificationServices
+	*integrationServices
+
+	healthMonitor *health.Monitor
+}
+
+// newServices builds each subsystem's services in turn; each wires itself
+// into the subsystems built before it. It seeds the admin user and
+// re-encrypts stored provider keys, but starts nothing in the background.
+func newServices(ctx context.Context, cfg *config.Config, logger *zap.Logger, db *database.DB, appMetrics *metrics.Metrics, configReloader *config.Reloader, repos *repositories) (*appServices, error) {
+	platform, err := newPlatformServices(ctx, cfg, logger, db, appMetrics, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored provider keys take the place of the environment's when the
+	// clients are built
+	if providerKeys, err := platform.secretsService.Keys(ctx); err != nil {
+		logger.Error("failed to load stored provider keys; using the environment's", zap.Error(err))
+	} else {
+		cfg = withProviderKeys(cfg, providerKeys)
+	}
+
+	auth, err := newAuthServices(ctx, cfg, logger, db, appMetrics, repos, platform)
+	if err != nil {
+		return nil, err
+	}
+	calls, err := newCallServices(ctx, cfg, logger, db, appMetrics, configReloader, repos, platform)
+	if err != nil {
+		return nil, err
+	}
+	compliance, err := newComplianceServices(cfg, logger, db, repos, platform, calls)
+	if err != nil {
+		return nil, err
+	}
+	quotes, err := newQuoteServices(cfg, logger, db, repos, platform, calls, compliance)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := newNotificationServices(cfg, logger, db, platform, auth, calls, quotes)
+	if err != nil {
+		return nil, err
+	}
+	integrations, err := newIntegrationServices(cfg, logger, db, appMetrics, repos, platform, calls, compliance, quotes, notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appServices{
+		platformServices:     platform,
+		authServices:         auth,
+		callServices:         calls,
+		complianceServices:   compliance,
+		quoteServices:        quotes,
+		notificationServices: notifications,
+		integrationServices:  integrations,
+		// Dependency health checks, run in the background and reported by
+		// the health endpoints
+		healthMonitor: initHealthMonitor(cfg, db, calls.claudeClient, calls.blandClient, logger),
+	}, nil
+}
@@ -0,0 +1,349 @@
+package app
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/ai"
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/health"
+	"github.com/jkindrix/quickquote/internal/httpclient"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/secrets"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+	blandprovider "github.com/jkindrix/quickquote/internal/voiceprovider/bland"
+	"github.com/jkindrix/quickquote/internal/voiceprovider/fake"
+	"github.com/jkindrix/quickquote/internal/voiceprovider/retell"
+	"github.com/jkindrix/quickquote/internal/voiceprovider/twilio"
+	"github.com/jkindrix/quickquote/internal/voiceprovider/vapi"
+)
+
+// initVoiceProviders initializes and registers all configured voice providers.
+// initHealthMonitor registers the dependencies reported at /health. Only the
+// database is critical: without it no request can be served, while the
+// others fail over, retry, or degrade a single feature.
+func initHealthMonitor(cfg *config.Config, db *database.DB, claudeClient *ai.ClaudeClient, blandClient *bland.Client, logger *zap.Logger) *health.Monitor {
+	monitor := health.NewMonitor(health.DefaultConfig(), logger, nil)
+	client := &http.Client{}
+
+	monitor.Register(health.Dependency{Name: "database", Critical: true, Check: db.Ping})
+	if db.Replica != nil {
+		// Heavy reads fail while the replica is down, but everything else works
+		monitor.Register(health.Dependency{Name: "database_replica", Check: db.PingReplica})
+	}
+
+	if cfg.VoiceProvider.Bland.Enabled || cfg.Bland.APIKey != "" {
+		monitor.Register(health.Dependency{
+			Name:    "bland",
+			Check:   health.HTTPCheck(client, cfg.VoiceProvider.Bland.APIURL),
+			Breaker: blandClient,
+		})
+	}
+	if cfg.VoiceProvider.Vapi.Enabled {
+		monitor.Register(health.Dependency{Name: "vapi", Check: health.HTTPCheck(client, cfg.VoiceProvider.Vapi.APIURL)})
+	}
+	if cfg.VoiceProvider.Retell.Enabled {
+		monitor.Register(health.Dependency{Name: "retell", Check: health.HTTPCheck(client, cfg.VoiceProvider.Retell.APIURL)})
+	}
+	if cfg.VoiceProvider.Twilio.Enabled {
+		monitor.Register(health.Dependency{Name: "twilio", Check: health.HTTPCheck(client, cfg.VoiceProvider.Twilio.APIURL)})
+	}
+
+	monitor.Register(health.Dependency{
+		Name:    "anthropic",
+		Check:   health.HTTPCheck(client, "https://api.anthropic.com"),
+		Breaker: claudeClient,
+	})
+
+	switch cfg.Email.Provider {
+	case "smtp":
+		addr := net.JoinHostPort(cfg.Email.SMTPHost, strconv.Itoa(cfg.Email.SMTPPort))
+		monitor.Register(health.Dependency{Name: "smtp", Check: health.SMTPCheck(addr)})
+	case "sendgrid":
+		monitor.Register(health.Dependency{Name: "sendgrid", Check: health.HTTPCheck(client, "https://api.sendgrid.com")})
+	}
+
+	return monitor
+}
+
+// newSecretsService creates the service for provider keys stored in the
+// database. Without SECRETS_MASTER_KEY keys can't be stored, and only the
+// environment's keys are used.
+// ingressMiddleware returns the middleware restricting a group of routes to
+// the allowed IPs and, if required, to clients with a verified certificate.
+func ingressMiddleware(name, allowedIPs string, requireClientCert bool, logger *zap.Logger) ([]func(http.Handler) http.Handler, error) {
+	nets, err := middleware.ParseIPNets(allowedIPs)
+	if err != nil {
+		return nil, err
+	}
+	mws := []func(http.Handler) http.Handler{middleware.IPAllowlist(name, nets, logger)}
+	if requireClientCert {
+		mws = append(mws, middleware.RequireClientCert(name, logger))
+	}
+	return mws, nil
+}
+
+// newServerTLSConfig builds the HTTPS settings, including verification of
+// client certificates when SERVER_CLIENT_AUTH asks for them.
+func newServerTLSConfig(cfg *config.ServerConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	switch cfg.ClientAuth {
+	case "optional":
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	case "require":
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.ClientCAFile)
+	}
+	tlsConfig.ClientCAs = pool
+	return tlsConfig, nil
+}
+
+func newSecretsService(cfg *config.Config, repo domain.ProviderCredentialRepository, auditLogger *audit.Logger, logger *zap.Logger) (*service.SecretsService, error) {
+	var keyring *secrets.Keyring
+	if cfg.Secrets.MasterKey != "" {
+		current, err := secrets.ParseKey(cfg.Secrets.MasterKey)
+		if err != nil {
+			return nil, err
+		}
+		var previous [][]byte
+		for _, encoded := range cfg.Secrets.GetPreviousMasterKeys() {
+			key, err := secrets.ParseKey(encoded)
+			if err != nil {
+				return nil, err
+			}
+			previous = append(previous, key)
+		}
+		if keyring, err = secrets.NewKeyring(current, previous...); err != nil {
+			return nil, err
+		}
+		logger.Info("initialized provider key storage", zap.String("master_key_id", keyring.KeyID()))
+	}
+
+	svc := service.NewSecretsService(repo, keyring, auditLogger, logger)
+	var fromEnv []domain.CredentialProvider
+	if cfg.VoiceProvider.Bland.APIKey != "" || cfg.Bland.APIKey != "" {
+		fromEnv = append(fromEnv, domain.CredentialBland)
+	}
+	if cfg.VoiceProvider.Vapi.APIKey != "" {
+		fromEnv = append(fromEnv, domain.CredentialVapi)
+	}
+	if cfg.VoiceProvider.Retell.APIKey != "" {
+		fromEnv = append(fromEnv, domain.CredentialRetell)
+	}
+	if cfg.Anthropic.APIKey != "" {
+		fromEnv = append(fromEnv, domain.CredentialAnthropic)
+	}
+	svc.SetEnvironmentKeys(fromEnv...)
+	return svc, nil
+}
+
+// withProviderKeys returns a copy of cfg with the stored provider keys in
+// place of the environment's.
+func withProviderKeys(cfg *config.Config, keys map[domain.CredentialProvider]string) *config.Config {
+	if len(keys) == 0 {
+		return cfg
+	}
+	overlaid := *cfg
+	if key, ok := keys[domain.CredentialBland]; ok {
+		overlaid.VoiceProvider.Bland.APIKey = key
+	}
+	if key, ok := keys[domain.CredentialVapi]; ok {
+		overlaid.VoiceProvider.Vapi.APIKey = key
+	}
+	if key, ok := keys[domain.CredentialRetell]; ok {
+		overlaid.VoiceProvider.Retell.APIKey = key
+	}
+	if key, ok := keys[domain.CredentialAnthropic]; ok {
+		overlaid.Anthropic.APIKey = key
+	}
+	return &overlaid
+}
+
+// newProviderHTTPClient builds the HTTP client for a provider's API from
+// its configured policy. It returns nil, leaving the provider's default
+// client in place, if the policy can't be applied.
+func newProviderHTTPClient(name string, policy config.HTTPClientPolicy, logger *zap.Logger) *http.Client {
+	client, err := httpclient.New(name, httpclient.Config{
+		Timeout:             policy.Timeout,
+		DialTimeout:         policy.DialTimeout,
+		MaxRetries:          policy.MaxRetries,
+		RetryBaseDelay:      policy.RetryBaseDelay,
+		RetryMaxDelay:       policy.RetryMaxDelay,
+		RetryBudget:         policy.RetryBudget,
+		ProxyURL:            policy.ProxyURL,
+		MaxIdleConns:        policy.MaxIdleConns,
+		MaxIdleConnsPerHost: policy.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     policy.MaxConnsPerHost,
+		IdleConnTimeout:     policy.IdleConnTimeout,
+	}, logger)
+	if err != nil {
+		logger.Error("invalid HTTP client policy; using the default client", zap.String("provider", name), zap.Error(err))
+		return nil
+	}
+	return client
+}
+
+// providerBreakers keeps the breaker registry in step with the voice
+// providers, whose clients are rebuilt when keys or configuration change.
+type providerBreakers struct {
+	mu       sync.Mutex
+	breakers *circuitbreaker.Registry
+	names    []string
+}
+
+func newProviderBreakers(breakers *circuitbreaker.Registry) *providerBreakers {
+	return &providerBreakers{breakers: breakers}
+}
+
+// sync registers the breakers of the providers now in the registry and
+// drops those of providers that were removed.
+func (p *providerBreakers) sync(providers *voiceprovider.Registry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, name := range p.names {
+		p.breakers.Unregister(name)
+	}
+	p.names = p.names[:0]
+	for _, provider := range providers.GetAll() {
+		guarded, ok := provider.(interface {
+			CircuitBreaker() *circuitbreaker.CircuitBreaker
+		})
+		if !ok {
+			continue
+		}
+		cb := guarded.CircuitBreaker()
+		p.breakers.Register(cb)
+		p.names = append(p.names, cb.Name())
+	}
+}
+
+func initVoiceProviders(cfg *config.Config, logger *zap.Logger) *voiceprovider.Registry {
+	registry := voiceprovider.NewRegistry(logger)
+
+	// Register Bland provider if enabled
+	if cfg.VoiceProvider.Bland.Enabled || cfg.Bland.APIKey != "" {
+		blandCfg := &blandprovider.Config{
+			APIKey:        cfg.VoiceProvider.Bland.APIKey,
+			WebhookSecret: cfg.VoiceProvider.Bland.WebhookSecret,
+			APIURL:        cfg.VoiceProvider.Bland.APIURL,
+		}
+		// Fallback to legacy config
+		if blandCfg.APIKey == "" {
+			blandCfg.APIKey = cfg.Bland.APIKey
+			blandCfg.WebhookSecret = cfg.Bland.WebhookSecret
+			blandCfg.APIURL = cfg.Bland.APIURL
+		}
+		registry.Register(blandprovider.New(blandCfg, logger))
+		logger.Info("registered Bland voice provider")
+	}
+
+	// Register Vapi provider if enabled
+	if cfg.VoiceProvider.Vapi.Enabled && cfg.VoiceProvider.Vapi.APIKey != "" {
+		vapiCfg := &vapi.Config{
+			APIKey:        cfg.VoiceProvider.Vapi.APIKey,
+			WebhookSecret: cfg.VoiceProvider.Vapi.WebhookSecret,
+			APIURL:        cfg.VoiceProvider.Vapi.APIURL,
+			AssistantID:   cfg.VoiceProvider.Vapi.AssistantID,
+			PhoneNumberID: cfg.VoiceProvider.Vapi.PhoneNumberID,
+			HTTPClient:    newProviderHTTPClient("vapi", cfg.HTTPClient.Vapi, logger),
+		}
+		registry.Register(vapi.New(vapiCfg, logger))
+		logger.Info("registered Vapi voice provider")
+	}
+
+	// Register Retell provider if enabled
+	if cfg.VoiceProvider.Retell.Enabled && cfg.VoiceProvider.Retell.APIKey != "" {
+		retellCfg := &retell.Config{
+			APIKey:        cfg.VoiceProvider.Retell.APIKey,
+			WebhookSecret: cfg.VoiceProvider.Retell.WebhookSecret,
+			APIURL:        cfg.VoiceProvider.Retell.APIURL,
+			FromNumber:    cfg.VoiceProvider.Retell.FromNumber,
+			AgentID:       cfg.VoiceProvider.Retell.AgentID,
+			HTTPClient:    newProviderHTTPClient("retell", cfg.HTTPClient.Retell, logger),
+		}
+		registry.Register(retell.New(retellCfg, logger))
+		logger.Info("registered Retell voice provider")
+	}
+
+	// Register Twilio provider if enabled
+	if cfg.VoiceProvider.Twilio.Enabled && cfg.VoiceProvider.Twilio.AccountSID != "" && cfg.VoiceProvider.Twilio.AuthToken != "" {
+		twilioCfg := &twilio.Config{
+			AccountSID:     cfg.VoiceProvider.Twilio.AccountSID,
+			AuthToken:      cfg.VoiceProvider.Twilio.AuthToken,
+			APIURL:         cfg.VoiceProvider.Twilio.APIURL,
+			WebhookBaseURL: cfg.VoiceProvider.Twilio.WebhookBaseURL,
+		}
+		// Twilio signs the public URL it calls; default to the app's public URL
+		if twilioCfg.WebhookBaseURL == "" {
+			twilioCfg.WebhookBaseURL = os.Getenv("WEBHOOK_BASE_URL")
+		}
+		if twilioCfg.WebhookBaseURL == "" {
+			twilioCfg.WebhookBaseURL = cfg.App.PublicURL
+		}
+		registry.Register(twilio.New(twilioCfg, logger))
+		logger.Info("registered Twilio voice provider")
+	}
+
+	// Register the simulated provider if enabled; its webhooks come back to
+	// this server unless pointed elsewhere
+	if cfg.VoiceProvider.Fake.Enabled {
+		fakeCfg := &fake.Config{
+			WebhookURL:      cfg.VoiceProvider.Fake.WebhookURL,
+			WebhookSecret:   cfg.VoiceProvider.Fake.WebhookSecret,
+			FromNumber:      cfg.VoiceProvider.Fake.FromNumber,
+			RingDelay:       cfg.VoiceProvider.Fake.RingDelay,
+			CallDuration:    cfg.VoiceProvider.Fake.CallDuration,
+			InboundInterval: cfg.VoiceProvider.Fake.InboundInterval,
+		}
+		if fakeCfg.WebhookURL == "" {
+			fakeCfg.WebhookURL = fmt.Sprintf("http://127.0.0.1:%d", cfg.Server.Port)
+		}
+		registry.Register(fake.New(fakeCfg, logger))
+		logger.Warn("registered simulated voice provider; its calls are not real")
+	}
+
+	// Set primary provider
+	primary := cfg.VoiceProvider.Primary
+	if primary == "" {
+		primary = "bland" // Default to bland for backward compatibility
+	}
+	if err := registry.SetPrimary(voiceprovider.ProviderType(primary)); err != nil {
+		logger.Warn("could not set primary provider, using first registered", zap.Error(err))
+	}
+
+	// Outbound calls the primary can't place fail over to these, in order
+	var fallbacks []voiceprovider.ProviderType
+	for _, name := range cfg.VoiceProvider.GetFailover() {
+		fallbacks = append(fallbacks, voiceprovider.ProviderType(name))
+	}
+	registry.SetFallbacks(fallbacks)
+	registry.SetNumberMap(cfg.VoiceProvider.GetFailoverNumbers())
+
+	return registry
+}
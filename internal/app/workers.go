@@ -0,0 +1,296 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/database"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/jobs"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// workers are the leader elections that keep background work to one
+// instance.
+type workers struct {
+	workerElector *database.LeaderElector
+	leaderElector *database.LeaderElector
+}
+
+// newWorkers adds the background workers and periodic jobs to a, started
+// by Start, along with the leader elections they run under.
+func newWorkers(cfg *config.Config, logger *zap.Logger, a *App, db *database.DB, appMetrics *metrics.Metrics, repos *repositories, svcs *appServices) (*workers, error) {
+	// Background workers, started in this order by Start. Those added with
+	// OnStartWorker run only in worker and all modes.
+
+	// Workers that pick up shared work with plain reads run on one worker
+	// at a time, elected among the workers, so replicas don't dial or
+	// deliver the same thing twice.
+	workerElector := database.NewWorkerLeaderElector(db.Pool, cfg.Jobs.LeaderCheckInterval, logger.Named("worker"))
+	svcs.campaignScheduler.SetElector(workerElector)
+	svcs.callbackService.SetElector(workerElector)
+	svcs.outgoingWebhookService.SetElector(workerElector)
+	if svcs.recordingService != nil {
+		svcs.recordingService.SetElector(workerElector)
+	}
+	if svcs.crmService != nil {
+		svcs.crmService.SetElector(workerElector)
+	}
+	a.OnStartWorker("worker leader election", workerElector.Start)
+
+	// Start quote job processor
+	a.OnStartWorker("job processor", svcs.jobProcessor.Start)
+
+	// Start webhook retry worker
+	a.OnStartWorker("webhook event processor", svcs.webhookEventProcessor.Start)
+
+	// Start dependency health checks
+	a.OnStart("health monitor", svcs.healthMonitor.Start)
+
+	// Start budget guard
+	a.OnStart("budget guard", svcs.budgetGuard.Start)
+
+	// Start campaign scheduler
+	a.OnStartWorker("campaign scheduler", svcs.campaignScheduler.Start)
+
+	// Start recording download and retention worker
+	if svcs.recordingService != nil {
+		a.OnStartWorker("recording worker", svcs.recordingService.Start)
+	}
+
+	// Start transcription worker
+	if svcs.transcriptionService != nil {
+		a.OnStartWorker("transcription worker", svcs.transcriptionService.Start)
+	}
+
+	// Start Bland entity sync worker
+	if svcs.runBlandSync {
+		a.OnStartWorker("bland sync worker", svcs.blandSyncService.Start)
+	}
+
+	// Start caller ID health worker
+	if svcs.runNumberHealth {
+		a.OnStartWorker("number health worker", svcs.numberHealthService.Start)
+	}
+
+	// Start nightly analytics rollup worker
+	if cfg.Analytics.RollupEnabled {
+		a.OnStartWorker("analytics rollup worker", svcs.analyticsRollupService.Start)
+	}
+
+	// Start call tagging worker
+	if cfg.Tagging.Enabled {
+		a.OnStartWorker("call tagging worker", svcs.callTaggingService.Start)
+	}
+
+	// Start business hours worker
+	if svcs.runSchedule {
+		a.OnStartWorker("schedule worker", svcs.scheduleService.Start)
+	}
+
+	// Start simulated inbound calls
+	if svcs.fakeProvider != nil {
+		a.OnStart("simulated voice provider", svcs.fakeProvider.Start)
+	}
+
+	// Start callback calendar worker
+	a.OnStartWorker("callback worker", svcs.callbackService.Start)
+
+	// Start quote follow-up worker
+	a.OnStartWorker("follow-up worker", svcs.followUpService.Start)
+
+	// Start voicemail and no-answer retry worker
+	a.OnStartWorker("call retry worker", svcs.callRetryService.Start)
+
+	// Import the national do-not-call list in the background; calls are
+	// checked against the previous import until it finishes
+	if cfg.Compliance.NationalDNCFile != "" {
+		a.OnStartWorker("national do-not-call import", func(ctx context.Context) error {
+			go func() {
+				f, err := os.Open(cfg.Compliance.NationalDNCFile)
+				if err != nil {
+					logger.Error("failed to open national do-not-call file", zap.Error(err))
+					return
+				}
+				defer f.Close()
+				if _, err := svcs.complianceService.ImportNational(ctx, f, true); err != nil {
+					logger.Error("failed to import national do-not-call file", zap.Error(err))
+				}
+			}()
+			return nil
+		})
+	}
+
+	// Start outgoing webhook delivery worker
+	a.OnStartWorker("outgoing webhook worker", svcs.outgoingWebhookService.Start)
+
+	// Start CRM sync worker
+	if svcs.crmService != nil {
+		a.OnStartWorker("crm sync worker", svcs.crmService.Start)
+	}
+
+	// Periodic background jobs: cleanups, syncs and retention. Singleton jobs
+	// run on one instance at a time, chosen by leader election.
+	backgroundJobs := []jobs.Job{
+		{
+			Name:        "db-metrics",
+			Description: "Report database connection pool usage",
+			Schedule:    jobs.Every(30 * time.Second),
+			Run: func(ctx context.Context) error {
+				if stats := db.Stats(); stats != nil {
+					appMetrics.UpdateDBConnections(int(stats.TotalConns()), int(stats.AcquiredConns()))
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "user-rate-limit-reset",
+			Description: "Reset expired per-user rate limit windows",
+			Schedule:    jobs.Every(5 * time.Minute),
+			Singleton:   true,
+			Timeout:     5 * time.Second,
+			Run:         repos.userRateLimitRepo.ResetExpiredWindows,
+		},
+		{
+			Name:        "session-cleanup",
+			Description: "Remove expired sessions and account tokens",
+			Schedule:    jobs.Every(time.Hour),
+			Singleton:   true,
+			Jitter:      time.Minute,
+			Run: func(ctx context.Context) error {
+				if err := svcs.authService.CleanupExpiredSessions(ctx); err != nil {
+					return fmt.Errorf("failed to cleanup expired sessions: %w", err)
+				}
+				if err := svcs.authService.CleanupExpiredTokens(ctx); err != nil {
+					return fmt.Errorf("failed to cleanup expired account tokens: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "idempotency-cleanup",
+			Description: "Remove expired idempotency keys",
+			Schedule:    jobs.Every(6 * time.Hour),
+			Singleton:   true,
+			Timeout:     10 * time.Second,
+			Jitter:      5 * time.Minute,
+			Run:         repos.idempotencyRepo.CleanupExpired,
+		},
+		{
+			Name:        "webhook-dedup-cleanup",
+			Description: "Remove expired processed-webhook records",
+			Schedule:    jobs.Every(6 * time.Hour),
+			Singleton:   true,
+			Timeout:     10 * time.Second,
+			Jitter:      5 * time.Minute,
+			Run:         svcs.webhookDedup.CleanupExpired,
+		},
+		{
+			// Pick up numbers blocked directly in Bland; webhooks check the
+			// previous mirror until the first sync finishes
+			Name:        "blocklist-sync",
+			Description: "Mirror the numbers blocked in Bland",
+			Schedule:    jobs.Every(6 * time.Hour),
+			Singleton:   true,
+			Timeout:     time.Minute,
+			Jitter:      5 * time.Minute,
+			RunAtStart:  true,
+			Run:         svcs.blocklistService.Sync,
+		},
+	}
+	if svcs.secretsService.Enabled() {
+		// Every instance switches to provider keys set through another
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "provider-key-refresh",
+			Description: "Pick up provider keys set or removed on other instances",
+			Schedule:    jobs.Every(cfg.Secrets.RefreshInterval),
+			Timeout:     10 * time.Second,
+			Run:         svcs.secretsService.Refresh,
+		})
+	}
+	if svcs.webhookArchive != nil {
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "webhook-archive-cleanup",
+			Description: "Remove archived webhooks past their retention",
+			Schedule:    jobs.Every(6 * time.Hour),
+			Singleton:   true,
+			Timeout:     time.Minute,
+			Jitter:      5 * time.Minute,
+			Run:         svcs.webhookArchive.CleanupExpired,
+		})
+	}
+	if cfg.Archival.Enabled {
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "call-archival",
+			Description: "Archive calls past the archival policy",
+			Schedule:    jobs.Every(cfg.Archival.Interval),
+			Singleton:   true,
+			Timeout:     10 * time.Minute,
+			Jitter:      5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := svcs.callArchiveService.ApplyPolicy(ctx)
+				return err
+			},
+		})
+	}
+	if svcs.backupService != nil && cfg.Backup.Enabled {
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "database-backup",
+			Description: "Back up the database to backup storage",
+			Schedule:    jobs.Every(cfg.Backup.Interval),
+			Singleton:   true,
+			Jitter:      5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := svcs.backupService.Backup(ctx, domain.BackupTriggerScheduled)
+				return err
+			},
+		})
+	}
+	if svcs.backupService != nil {
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "backup-cleanup",
+			Description: "Delete backups past their retention",
+			Schedule:    jobs.Every(time.Hour),
+			Singleton:   true,
+			Timeout:     10 * time.Minute,
+			Jitter:      5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := svcs.backupService.DeleteExpired(ctx)
+				return err
+			},
+		})
+	}
+	if cfg.Retention.Enabled {
+		backgroundJobs = append(backgroundJobs, jobs.Job{
+			Name:        "retention",
+			Description: "Enforce retention rules",
+			Schedule:    jobs.Every(cfg.Retention.Interval),
+			Singleton:   true,
+			Timeout:     30 * time.Minute,
+			Jitter:      5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				_, err := svcs.retentionService.Enforce(ctx)
+				return err
+			},
+		})
+	}
+	for _, job := range backgroundJobs {
+		if err := svcs.jobScheduler.Register(job); err != nil {
+			return nil, fmt.Errorf("failed to register background job %s: %w", job.Name, err)
+		}
+	}
+	leaderElector := database.NewLeaderElector(db.Pool, cfg.Jobs.LeaderCheckInterval, logger)
+	svcs.jobScheduler.SetElector(leaderElector)
+	a.OnStart("leader election", leaderElector.Start)
+	a.OnStart("job scheduler", svcs.jobScheduler.Start)
+
+	return &workers{
+		workerElector: workerElector,
+		leaderElector: leaderElector,
+	}, nil
+}
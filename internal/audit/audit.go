@@ -4,6 +4,7 @@ package audit
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -42,6 +43,10 @@ const (
 	EventAPICallFailed  EventType = "api.call.failed"
 	EventQuoteGenerated EventType = "quote.generated"
 
+	// Quote workflow events
+	EventQuoteStatusChanged EventType = "quote.status.changed"
+	EventQuoteEdited        EventType = "quote.edited"
+
 	// System events
 	EventServiceStarted  EventType = "system.started"
 	EventServiceStopping EventType = "system.stopping"
@@ -54,7 +59,55 @@ const (
 	EventAdminSettingChanged EventType = "admin.setting.changed"
 	EventAdminCallInitiated  EventType = "admin.call.initiated"
 	EventAdminCallEnded      EventType = "admin.call.ended"
+	EventAdminCallTransfer   EventType = "admin.call.transferred"
+	EventAdminCallMessage    EventType = "admin.call.message_sent"
 	EventAdminCallAnalyzed   EventType = "admin.call.analyzed"
+	EventAdminAPIKeyCreated  EventType = "admin.api_key.created"
+	EventAdminAPIKeyRotated  EventType = "admin.api_key.rotated"
+	EventAdminAPIKeyRevoked  EventType = "admin.api_key.revoked"
+	EventAdminUserInvited    EventType = "admin.user.invited"
+	EventAdminUserReinvited  EventType = "admin.user.reinvited"
+	EventAdminUserRoleSet    EventType = "admin.user.role_changed"
+	EventAdminUserDisabled   EventType = "admin.user.disabled"
+	EventAdminUserEnabled    EventType = "admin.user.enabled"
+	EventAdminUserDeleted    EventType = "admin.user.deleted"
+
+	// Archival and soft deletion
+	EventCallArchived    EventType = "call.archived"
+	EventCallUnarchived  EventType = "call.unarchived"
+	EventCallDeleted     EventType = "call.deleted"
+	EventCallRestored    EventType = "call.restored"
+	EventQuoteArchived   EventType = "quote.archived"
+	EventQuoteUnarchived EventType = "quote.unarchived"
+	EventCallAssigned    EventType = "call.assigned"
+
+	// Retention events
+	EventLegalHoldPlaced   EventType = "retention.legal_hold.placed"
+	EventLegalHoldReleased EventType = "retention.legal_hold.released"
+	EventRetentionEnforced EventType = "retention.enforced"
+
+	// Feature flag events
+	EventAdminFeatureFlagChanged EventType = "admin.feature_flag.changed"
+	EventAdminFeatureFlagDeleted EventType = "admin.feature_flag.deleted"
+
+	// Provider key events
+	EventAdminProviderKeySet     EventType = "admin.provider_key.set"
+	EventAdminProviderKeyDeleted EventType = "admin.provider_key.deleted"
+
+	// Circuit breaker events
+	EventAdminCircuitBreakerTripped EventType = "admin.circuit_breaker.tripped"
+	EventAdminCircuitBreakerReset   EventType = "admin.circuit_breaker.reset"
+
+	// Background job events
+	EventAdminJobRun EventType = "admin.job.run"
+
+	// Database backup events
+	EventAdminBackupStarted    EventType = "admin.backup.started"
+	EventAdminBackupDownloaded EventType = "admin.backup.downloaded"
+
+	// Managed tag events
+	EventAdminTagChanged EventType = "admin.tag.changed"
+	EventAdminTagDeleted EventType = "admin.tag.deleted"
 )
 
 // Severity represents the severity level of an audit event.
@@ -101,13 +154,46 @@ type Event struct {
 	Outcome string `json:"outcome"`         // "success", "failure", "denied"
 	Reason  string `json:"reason,omitempty"` // Failure/denial reason
 
+	// State of the resource before and after a change.
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+
 	// Additional context.
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// Filter narrows a search of stored audit events. Empty fields match any
+// event.
+type Filter struct {
+	Type         EventType  // Exact type, or a prefix ending in "." such as "admin."
+	ActorID      string
+	ResourceType string
+	ResourceID   string
+	RequestID    string
+	Outcome      string
+	Since        *time.Time // Events at or after
+	Until        *time.Time // Events before
+}
+
+// Store persists audit events so they can be searched later.
+type Store interface {
+	// Save stores an event.
+	Save(ctx context.Context, event *Event) error
+
+	// List retrieves events matching filter, newest first.
+	List(ctx context.Context, filter *Filter, limit, offset int) ([]*Event, error)
+
+	// Count returns the number of events matching filter.
+	Count(ctx context.Context, filter *Filter) (int, error)
+}
+
+// ErrNoStore is returned when searching a logger that only writes to the log.
+var ErrNoStore = errors.New("audit events are not stored")
+
 // Logger provides audit logging capabilities.
 type Logger struct {
 	logger *zap.Logger
+	store  Store
 }
 
 // NewLogger creates a new audit logger.
@@ -117,7 +203,31 @@ func NewLogger(baseLogger *zap.Logger) *Logger {
 	}
 }
 
-// Log records an audit event.
+// SetStore sets where events are saved in addition to the log.
+func (l *Logger) SetStore(store Store) {
+	l.store = store
+}
+
+// Search returns a page of stored events matching filter, newest first,
+// and the number of events that match.
+func (l *Logger) Search(ctx context.Context, filter *Filter, limit, offset int) ([]*Event, int, error) {
+	if l.store == nil {
+		return nil, 0, ErrNoStore
+	}
+	events, err := l.store.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := l.store.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// Log records an audit event. Events are always logged; when a store is
+// set they are saved as well, even if the request that caused them has
+// been cancelled. A failed save is logged rather than returned.
 func (l *Logger) Log(ctx context.Context, event *Event) {
 	// Ensure ID and timestamp are set
 	if event.ID == "" {
@@ -141,12 +251,7 @@ func (l *Logger) Log(ctx context.Context, event *Event) {
 	// Convert metadata to JSON for logging
 	var metadataJSON []byte
 	if len(event.Metadata) > 0 {
-		var err error
-		metadataJSON, err = json.Marshal(event.Metadata)
-		if err != nil {
-			// If metadata can't be marshaled, log the error but continue
-			metadataJSON = []byte(`{"error":"failed to marshal metadata"}`)
-		}
+		metadataJSON = marshalForLog(event.Metadata, "metadata")
 	}
 
 	// Log the event with structured fields
@@ -190,6 +295,12 @@ func (l *Logger) Log(ctx context.Context, event *Event) {
 	if event.Reason != "" {
 		fields = append(fields, zap.String("reason", event.Reason))
 	}
+	if event.Before != nil {
+		fields = append(fields, zap.ByteString("before", marshalForLog(event.Before, "before")))
+	}
+	if event.After != nil {
+		fields = append(fields, zap.ByteString("after", marshalForLog(event.After, "after")))
+	}
 	if len(metadataJSON) > 0 {
 		fields = append(fields, zap.ByteString("metadata", metadataJSON))
 	}
@@ -198,6 +309,22 @@ func (l *Logger) Log(ctx context.Context, event *Event) {
 	if ce := l.logger.Check(level, "security audit event"); ce != nil {
 		ce.Write(fields...)
 	}
+
+	if l.store != nil {
+		if err := l.store.Save(context.WithoutCancel(ctx), event); err != nil {
+			l.logger.Error("failed to save audit event", zap.String("audit_id", event.ID), zap.Error(err))
+		}
+	}
+}
+
+// marshalForLog encodes v as JSON, or a placeholder naming what failed to
+// encode, so a bad value never stops an event being logged.
+func marshalForLog(v interface{}, name string) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return []byte(`{"error":"failed to marshal ` + name + `"}`)
+	}
+	return b
 }
 
 // Helper methods for common audit scenarios
@@ -369,6 +496,54 @@ func (l *Logger) QuoteGenerated(ctx context.Context, callID, requestID string, d
 	})
 }
 
+// QuoteStatusChanged logs a quote moving through the review workflow.
+func (l *Logger) QuoteStatusChanged(ctx context.Context, userID, userName, callID, fromStatus, toStatus, note, ip, requestID string, amount float64) {
+	l.Log(ctx, &Event{
+		Type:         EventQuoteStatusChanged,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "user",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "quote",
+		ResourceID:   callID,
+		Action:       "quote " + toStatus,
+		Outcome:      "success",
+		Before:       map[string]interface{}{"status": fromStatus},
+		After:        map[string]interface{}{"status": toStatus, "total_amount": amount},
+		Metadata: map[string]interface{}{
+			"from_status":  fromStatus,
+			"to_status":    toStatus,
+			"total_amount": amount,
+			"note":         note,
+		},
+	})
+}
+
+// QuoteEdited logs staff changing a draft quote's line items.
+func (l *Logger) QuoteEdited(ctx context.Context, userID, userName, callID, ip, requestID string, lineItems int, before, after float64) {
+	l.Log(ctx, &Event{
+		Type:         EventQuoteEdited,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "user",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "quote",
+		ResourceID:   callID,
+		Action:       "quote edited",
+		Outcome:      "success",
+		Before:       map[string]interface{}{"total_amount": before},
+		After:        map[string]interface{}{"total_amount": after},
+		Metadata: map[string]interface{}{
+			"line_items":   lineItems,
+			"total_amount": after,
+		},
+	})
+}
+
 // APICallFailed logs a failed external API call.
 func (l *Logger) APICallFailed(ctx context.Context, service, operation, requestID, reason string) {
 	l.Log(ctx, &Event{
@@ -490,6 +665,8 @@ func (l *Logger) SettingChanged(ctx context.Context, userID, userName, settingKe
 		ResourceID:   settingKey,
 		Action:       "setting changed",
 		Outcome:      "success",
+		Before:       oldValue,
+		After:        newValue,
 		Metadata: map[string]interface{}{
 			"key":       settingKey,
 			"old_value": oldValue,
@@ -535,6 +712,46 @@ func (l *Logger) CallEnded(ctx context.Context, userID, userName, callID, ip, re
 	})
 }
 
+// CallTransferred logs an admin transferring a call in progress.
+func (l *Logger) CallTransferred(ctx context.Context, userID, userName, callID, toNumber, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminCallTransfer,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "call",
+		ResourceID:   callID,
+		Action:       "call transferred",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"to_number": toNumber,
+		},
+	})
+}
+
+// CallMessageSent logs an admin injecting a message into a call in progress.
+func (l *Logger) CallMessageSent(ctx context.Context, userID, userName, callID, message, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminCallMessage,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "call",
+		ResourceID:   callID,
+		Action:       "message sent to call",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"message": message,
+		},
+	})
+}
+
 // CallAnalyzed logs a call analysis request by an admin.
 func (l *Logger) CallAnalyzed(ctx context.Context, userID, userName, callID, ip, requestID string) {
 	l.Log(ctx, &Event{
@@ -551,3 +768,440 @@ func (l *Logger) CallAnalyzed(ctx context.Context, userID, userName, callID, ip,
 		Outcome:      "success",
 	})
 }
+
+// CallArchived logs a user archiving a call and its quote.
+func (l *Logger) CallArchived(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventCallArchived, SeverityInfo, "call", "call archived", userID, userName, callID, ip, requestID)
+}
+
+// CallUnarchived logs a user returning an archived call to everyday lists.
+func (l *Logger) CallUnarchived(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventCallUnarchived, SeverityInfo, "call", "call unarchived", userID, userName, callID, ip, requestID)
+}
+
+// CallDeleted logs an admin soft-deleting a call and its quote.
+func (l *Logger) CallDeleted(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventCallDeleted, SeverityWarning, "call", "call deleted", userID, userName, callID, ip, requestID)
+}
+
+// CallRestored logs an admin restoring a soft-deleted call.
+func (l *Logger) CallRestored(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventCallRestored, SeverityInfo, "call", "call restored", userID, userName, callID, ip, requestID)
+}
+
+// CallAssigned logs a user assigning a call and its quote to a user, or
+// unassigning it when assigneeID is empty.
+func (l *Logger) CallAssigned(ctx context.Context, userID, userName, callID, assigneeID, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventCallAssigned,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "user",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "call",
+		ResourceID:   callID,
+		Action:       "call assigned",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"assigned_to": assigneeID,
+		},
+	})
+}
+
+// QuoteArchived logs a user archiving a quote.
+func (l *Logger) QuoteArchived(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventQuoteArchived, SeverityInfo, "quote", "quote archived", userID, userName, callID, ip, requestID)
+}
+
+// QuoteUnarchived logs a user unarchiving a quote.
+func (l *Logger) QuoteUnarchived(ctx context.Context, userID, userName, callID, ip, requestID string) {
+	l.logRecordChange(ctx, EventQuoteUnarchived, SeverityInfo, "quote", "quote unarchived", userID, userName, callID, ip, requestID)
+}
+
+// LegalHoldPlaced logs an admin placing a call or customer on legal hold.
+func (l *Logger) LegalHoldPlaced(ctx context.Context, userID, userName, target, targetID, reason, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventLegalHoldPlaced,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: target,
+		ResourceID:   targetID,
+		Action:       "legal hold placed",
+		Outcome:      "success",
+		Reason:       reason,
+	})
+}
+
+// LegalHoldReleased logs an admin releasing a legal hold.
+func (l *Logger) LegalHoldReleased(ctx context.Context, userID, userName, target, targetID, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventLegalHoldReleased,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: target,
+		ResourceID:   targetID,
+		Action:       "legal hold released",
+		Outcome:      "success",
+	})
+}
+
+// RetentionEnforced logs the retention rules removing records, with the
+// number removed per entity.
+func (l *Logger) RetentionEnforced(ctx context.Context, purged map[string]int) {
+	metadata := make(map[string]interface{}, len(purged))
+	for entity, count := range purged {
+		metadata[entity] = count
+	}
+	l.Log(ctx, &Event{
+		Type:      EventRetentionEnforced,
+		Severity:  SeverityInfo,
+		ActorType: "system",
+		Action:    "retention enforced",
+		Outcome:   "success",
+		Metadata:  metadata,
+	})
+}
+
+// FeatureFlagChanged logs an admin creating or changing a feature flag.
+// before is nil when the flag was created.
+func (l *Logger) FeatureFlagChanged(ctx context.Context, userID, userName, key, ip, requestID string, before, after interface{}) {
+	action := "feature flag changed"
+	if before == nil {
+		action = "feature flag created"
+	}
+	l.Log(ctx, &Event{
+		Type:         EventAdminFeatureFlagChanged,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "feature_flag",
+		ResourceID:   key,
+		Action:       action,
+		Outcome:      "success",
+		Before:       before,
+		After:        after,
+	})
+}
+
+// FeatureFlagDeleted logs an admin deleting a feature flag.
+func (l *Logger) FeatureFlagDeleted(ctx context.Context, userID, userName, key, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminFeatureFlagDeleted,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "feature_flag",
+		ResourceID:   key,
+		Action:       "feature flag deleted",
+		Outcome:      "success",
+	})
+}
+
+// ProviderKeySet logs an admin storing or rotating a provider API key. Only
+// the key's hint is logged.
+func (l *Logger) ProviderKeySet(ctx context.Context, userID, userName, provider, hint, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminProviderKeySet,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "provider_key",
+		ResourceID:   provider,
+		Action:       "provider key set",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"hint": hint,
+		},
+	})
+}
+
+// ProviderKeyDeleted logs an admin removing a stored provider API key.
+func (l *Logger) ProviderKeyDeleted(ctx context.Context, userID, userName, provider, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminProviderKeyDeleted,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "provider_key",
+		ResourceID:   provider,
+		Action:       "provider key removed",
+		Outcome:      "success",
+	})
+}
+
+// CircuitBreakerTripped logs an admin forcing a dependency's circuit open.
+func (l *Logger) CircuitBreakerTripped(ctx context.Context, userID, userName, breaker, ip, requestID string) {
+	l.logCircuitBreaker(ctx, EventAdminCircuitBreakerTripped, SeverityWarning, "circuit breaker tripped", userID, userName, breaker, ip, requestID)
+}
+
+// CircuitBreakerReset logs an admin closing a dependency's circuit.
+func (l *Logger) CircuitBreakerReset(ctx context.Context, userID, userName, breaker, ip, requestID string) {
+	l.logCircuitBreaker(ctx, EventAdminCircuitBreakerReset, SeverityInfo, "circuit breaker reset", userID, userName, breaker, ip, requestID)
+}
+
+// JobRun logs an admin running a background job outside its schedule.
+func (l *Logger) JobRun(ctx context.Context, userID, userName, job, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminJobRun,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "job",
+		ResourceID:   job,
+		Action:       "job run requested",
+		Outcome:      "success",
+	})
+}
+
+// BackupStarted logs an admin starting a database backup.
+func (l *Logger) BackupStarted(ctx context.Context, userID, userName, backupID, ip, requestID string) {
+	l.logBackup(ctx, EventAdminBackupStarted, SeverityInfo, "backup started", userID, userName, backupID, ip, requestID)
+}
+
+// BackupDownloaded logs an admin downloading a database backup, which holds
+// a copy of every record.
+func (l *Logger) BackupDownloaded(ctx context.Context, userID, userName, backupID, ip, requestID string) {
+	l.logBackup(ctx, EventAdminBackupDownloaded, SeverityWarning, "backup downloaded", userID, userName, backupID, ip, requestID)
+}
+
+// logBackup logs an admin acting on a database backup.
+func (l *Logger) logBackup(ctx context.Context, eventType EventType, severity Severity, action, userID, userName, backupID, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         eventType,
+		Severity:     severity,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "backup",
+		ResourceID:   backupID,
+		Action:       action,
+		Outcome:      "success",
+	})
+}
+
+// logCircuitBreaker logs an admin acting on a circuit breaker.
+func (l *Logger) logCircuitBreaker(ctx context.Context, eventType EventType, severity Severity, action, userID, userName, breaker, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         eventType,
+		Severity:     severity,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "circuit_breaker",
+		ResourceID:   breaker,
+		Action:       action,
+		Outcome:      "success",
+	})
+}
+
+// logRecordChange logs a user archiving, deleting or restoring a record.
+func (l *Logger) logRecordChange(ctx context.Context, eventType EventType, severity Severity, resourceType, action, userID, userName, resourceID, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         eventType,
+		Severity:     severity,
+		ActorID:      userID,
+		ActorType:    "user",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Action:       action,
+		Outcome:      "success",
+	})
+}
+
+// APIKeyCreated logs the creation of an API key by an admin.
+func (l *Logger) APIKeyCreated(ctx context.Context, userID, userName, keyID, keyPrefix string, scopes []string, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminAPIKeyCreated,
+		Severity:     SeverityInfo,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "api_key",
+		ResourceID:   keyID,
+		Action:       "api key created",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"prefix": keyPrefix,
+			"scopes": scopes,
+		},
+	})
+}
+
+// APIKeyRotated logs the rotation of an API key secret by an admin.
+func (l *Logger) APIKeyRotated(ctx context.Context, userID, userName, keyID, keyPrefix, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminAPIKeyRotated,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "api_key",
+		ResourceID:   keyID,
+		Action:       "api key rotated",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"prefix": keyPrefix,
+		},
+	})
+}
+
+// APIKeyRevoked logs the revocation of an API key by an admin.
+func (l *Logger) APIKeyRevoked(ctx context.Context, userID, userName, keyID, keyPrefix, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminAPIKeyRevoked,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "api_key",
+		ResourceID:   keyID,
+		Action:       "api key revoked",
+		Outcome:      "success",
+		Metadata: map[string]interface{}{
+			"prefix": keyPrefix,
+		},
+	})
+}
+
+// UserInvited logs an admin inviting a new dashboard user.
+func (l *Logger) UserInvited(ctx context.Context, userID, userName, targetID, targetEmail, role, ip, requestID string) {
+	l.logUserAdmin(ctx, EventAdminUserInvited, SeverityInfo, "user invited", userID, userName, targetID, ip, requestID, map[string]interface{}{
+		"email": targetEmail,
+		"role":  role,
+	})
+}
+
+// UserReinvited logs an admin issuing a fresh invitation link.
+func (l *Logger) UserReinvited(ctx context.Context, userID, userName, targetID, targetEmail, ip, requestID string) {
+	l.logUserAdmin(ctx, EventAdminUserReinvited, SeverityInfo, "user invitation resent", userID, userName, targetID, ip, requestID, map[string]interface{}{
+		"email": targetEmail,
+	})
+}
+
+// UserRoleChanged logs an admin changing a user's role.
+func (l *Logger) UserRoleChanged(ctx context.Context, userID, userName, targetID, targetEmail, oldRole, newRole, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         EventAdminUserRoleSet,
+		Severity:     SeverityWarning,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "user",
+		ResourceID:   targetID,
+		Action:       "user role changed",
+		Outcome:      "success",
+		Before:       map[string]interface{}{"role": oldRole},
+		After:        map[string]interface{}{"role": newRole},
+		Metadata: map[string]interface{}{
+			"email":    targetEmail,
+			"old_role": oldRole,
+			"new_role": newRole,
+		},
+	})
+}
+
+// UserDisabled logs an admin disabling a user.
+func (l *Logger) UserDisabled(ctx context.Context, userID, userName, targetID, targetEmail, ip, requestID string) {
+	l.logUserAdmin(ctx, EventAdminUserDisabled, SeverityWarning, "user disabled", userID, userName, targetID, ip, requestID, map[string]interface{}{
+		"email": targetEmail,
+	})
+}
+
+// UserEnabled logs an admin re-enabling a disabled user.
+func (l *Logger) UserEnabled(ctx context.Context, userID, userName, targetID, targetEmail, ip, requestID string) {
+	l.logUserAdmin(ctx, EventAdminUserEnabled, SeverityInfo, "user enabled", userID, userName, targetID, ip, requestID, map[string]interface{}{
+		"email": targetEmail,
+	})
+}
+
+// UserDeleted logs an admin deleting a user.
+func (l *Logger) UserDeleted(ctx context.Context, userID, userName, targetID, targetEmail, ip, requestID string) {
+	l.logUserAdmin(ctx, EventAdminUserDeleted, SeverityWarning, "user deleted", userID, userName, targetID, ip, requestID, map[string]interface{}{
+		"email": targetEmail,
+	})
+}
+
+// logUserAdmin logs a successful user management action by an admin.
+func (l *Logger) logUserAdmin(ctx context.Context, eventType EventType, severity Severity, action, userID, userName, targetID, ip, requestID string, metadata map[string]interface{}) {
+	l.Log(ctx, &Event{
+		Type:         eventType,
+		Severity:     severity,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "user",
+		ResourceID:   targetID,
+		Action:       action,
+		Outcome:      "success",
+		Metadata:     metadata,
+	})
+}
+
+// TagChanged logs an admin creating or updating a managed tag.
+func (l *Logger) TagChanged(ctx context.Context, userID, userName, tag, action, ip, requestID string) {
+	l.logTag(ctx, EventAdminTagChanged, SeverityInfo, action, userID, userName, tag, ip, requestID)
+}
+
+// TagDeleted logs an admin deleting a tag, which takes it off every record.
+func (l *Logger) TagDeleted(ctx context.Context, userID, userName, tag, ip, requestID string) {
+	l.logTag(ctx, EventAdminTagDeleted, SeverityWarning, "tag deleted", userID, userName, tag, ip, requestID)
+}
+
+// logTag logs an admin acting on a tag.
+func (l *Logger) logTag(ctx context.Context, eventType EventType, severity Severity, action, userID, userName, tag, ip, requestID string) {
+	l.Log(ctx, &Event{
+		Type:         eventType,
+		Severity:     severity,
+		ActorID:      userID,
+		ActorType:    "admin",
+		ActorName:    userName,
+		SourceIP:     ip,
+		RequestID:    requestID,
+		ResourceType: "tag",
+		ResourceID:   tag,
+		Action:       action,
+		Outcome:      "success",
+	})
+}
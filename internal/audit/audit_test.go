@@ -2,6 +2,7 @@ package audit
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -387,3 +388,111 @@ func TestEvent_Timestamp(t *testing.T) {
 		t.Errorf("custom timestamp should be preserved: got %v, expected %v", event2.Timestamp, customTime)
 	}
 }
+
+func TestLogger_UserRoleChanged(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	baseLogger := zap.New(core)
+	auditLogger := NewLogger(baseLogger)
+
+	auditLogger.UserRoleChanged(context.Background(), "admin-1", "admin@example.com", "user-2", "user@example.com", "member", "admin", "10.0.0.1", "req-1")
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected 1 log entry, got %d", logs.Len())
+	}
+
+	fieldMap := getFieldMap(logs.All()[0].Context)
+	if fieldMap["event_type"] != "admin.user.role_changed" {
+		t.Errorf("event_type = %v, expected admin.user.role_changed", fieldMap["event_type"])
+	}
+	if fieldMap["resource_id"] != "user-2" {
+		t.Errorf("resource_id = %v, expected user-2", fieldMap["resource_id"])
+	}
+	if fieldMap["actor_id"] != "admin-1" {
+		t.Errorf("actor_id = %v, expected admin-1", fieldMap["actor_id"])
+	}
+	if fieldMap["before"] != `{"role":"member"}` || fieldMap["after"] != `{"role":"admin"}` {
+		t.Errorf("before = %v, after = %v; expected the old and new roles", fieldMap["before"], fieldMap["after"])
+	}
+}
+
+// memoryStore keeps saved events in memory.
+type memoryStore struct {
+	events []*Event
+	err    error
+}
+
+func (s *memoryStore) Save(ctx context.Context, event *Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memoryStore) List(ctx context.Context, filter *Filter, limit, offset int) ([]*Event, error) {
+	var events []*Event
+	for i := len(s.events) - 1; i >= 0; i-- {
+		if filter.ActorID == "" || s.events[i].ActorID == filter.ActorID {
+			events = append(events, s.events[i])
+		}
+	}
+	if offset > len(events) {
+		return nil, nil
+	}
+	events = events[offset:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (s *memoryStore) Count(ctx context.Context, filter *Filter) (int, error) {
+	events, err := s.List(ctx, filter, len(s.events), 0)
+	return len(events), err
+}
+
+func TestLogger_Store(t *testing.T) {
+	auditLogger := NewLogger(zap.NewNop())
+	if _, _, err := auditLogger.Search(context.Background(), &Filter{}, 10, 0); !errors.Is(err, ErrNoStore) {
+		t.Errorf("Search() without a store error = %v, expected ErrNoStore", err)
+	}
+
+	store := &memoryStore{}
+	auditLogger.SetStore(store)
+
+	// Events are saved even when the request has gone away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	auditLogger.SettingChanged(ctx, "admin-1", "admin@example.com", "quote.tax_rate", "10.0.0.1", "req-1", "0.05", "0.07")
+	auditLogger.LoginSuccess(context.Background(), "user-2", "User", "user@example.com", "10.0.0.2", "TestBrowser/1.0", "req-2")
+
+	if len(store.events) != 2 {
+		t.Fatalf("saved %d events, expected 2", len(store.events))
+	}
+	if got := store.events[0]; got.ID == "" || got.Before != "0.05" || got.After != "0.07" {
+		t.Errorf("saved setting change = %+v, expected an ID and the old and new values", got)
+	}
+
+	events, total, err := auditLogger.Search(context.Background(), &Filter{ActorID: "admin-1"}, 10, 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 1 || len(events) != 1 || events[0].Type != EventAdminSettingChanged {
+		t.Errorf("Search() = %d events of %d, expected the setting change", len(events), total)
+	}
+}
+
+func TestLogger_StoreFailureStillLogs(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	auditLogger := NewLogger(zap.New(core))
+	auditLogger.SetStore(&memoryStore{err: errors.New("database down")})
+
+	auditLogger.LoginFailure(context.Background(), "user@example.com", "10.0.0.1", "TestBrowser/1.0", "req-1", "bad password")
+
+	if logs.Len() != 2 {
+		t.Fatalf("expected the event and the save failure to be logged, got %d entries", logs.Len())
+	}
+	if msg := logs.All()[1].Message; msg != "failed to save audit event" {
+		t.Errorf("second entry = %q, expected the save failure", msg)
+	}
+}
@@ -0,0 +1,126 @@
+// Package backup takes and restores logical backups of the database with
+// the PostgreSQL client tools, pg_dump and pg_restore, in pg_dump's custom
+// format.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/jkindrix/quickquote/internal/config"
+)
+
+// maxStderr is how much of a tool's error output is kept for its error.
+const maxStderr = 4 << 10
+
+// Dumper writes a backup of the database with pg_dump.
+type Dumper struct {
+	Path string // pg_dump executable; empty finds pg_dump on PATH
+	DB   *config.DatabaseConfig
+}
+
+// Dump writes a custom-format backup of the database to w.
+func (d *Dumper) Dump(ctx context.Context, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, tool(d.Path, "pg_dump"), d.Args()...)
+	cmd.Env = connEnv(d.DB)
+	cmd.Stdout = w
+	return runTool(cmd, "pg_dump")
+}
+
+// Args returns the arguments pg_dump is run with. The connection is passed
+// in the environment, keeping the password off the command line.
+func (d *Dumper) Args() []string {
+	return []string{"--format=custom", "--no-owner", "--no-privileges"}
+}
+
+// Restorer loads a backup into the database with pg_restore.
+type Restorer struct {
+	Path string // pg_restore executable; empty finds pg_restore on PATH
+	DB   *config.DatabaseConfig
+	// Clean drops the database's existing objects before recreating them,
+	// for restoring over a database that isn't empty.
+	Clean bool
+}
+
+// Restore loads a custom-format backup read from r, in one transaction so
+// a failed restore leaves the database as it was.
+func (r *Restorer) Restore(ctx context.Context, backup io.Reader) error {
+	cmd := exec.CommandContext(ctx, tool(r.Path, "pg_restore"), r.Args()...)
+	cmd.Env = connEnv(r.DB)
+	cmd.Stdin = backup
+	return runTool(cmd, "pg_restore")
+}
+
+// Args returns the arguments pg_restore is run with.
+func (r *Restorer) Args() []string {
+	args := []string{"--single-transaction", "--exit-on-error", "--no-owner", "--no-privileges", "--dbname=" + r.DB.Name}
+	if r.Clean {
+		args = append(args, "--clean", "--if-exists")
+	}
+	return args
+}
+
+// Command describes the pg_restore command Restore runs, without the
+// password, for dry runs.
+func (r *Restorer) Command() string {
+	return fmt.Sprintf("PGHOST=%s PGPORT=%d PGUSER=%s PGSSLMODE=%s %s %s",
+		r.DB.Host, r.DB.Port, r.DB.User, r.DB.SSLMode, tool(r.Path, "pg_restore"), strings.Join(r.Args(), " "))
+}
+
+func tool(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path
+}
+
+// connEnv returns the process environment with the libpq connection
+// variables set for db.
+func connEnv(db *config.DatabaseConfig) []string {
+	return append(os.Environ(),
+		"PGHOST="+db.Host,
+		"PGPORT="+strconv.Itoa(db.Port),
+		"PGUSER="+db.User,
+		"PGPASSWORD="+db.Password,
+		"PGDATABASE="+db.Name,
+		"PGSSLMODE="+db.SSLMode,
+	)
+}
+
+// runTool runs cmd, including the start of its error output in the error
+// if it fails.
+func runTool(cmd *exec.Cmd, name string) error {
+	stderr := &limitedBuffer{max: maxStderr}
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the
+// rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
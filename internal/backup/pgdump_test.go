@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jkindrix/quickquote/internal/config"
+)
+
+// fakeTool writes a shell script standing in for pg_dump or pg_restore.
+func fakeTool(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tools are shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var testDB = &config.DatabaseConfig{
+	Host:     "db.internal",
+	Port:     5433,
+	User:     "quickquote",
+	Password: "s3cret",
+	Name:     "quickquote",
+	SSLMode:  "require",
+}
+
+func TestDumper_PassesTheConnectionInTheEnvironment(t *testing.T) {
+	d := &Dumper{
+		Path: fakeTool(t, `echo "$PGHOST:$PGPORT $PGUSER $PGPASSWORD $PGDATABASE $PGSSLMODE $*"`),
+		DB:   testDB,
+	}
+
+	var out bytes.Buffer
+	if err := d.Dump(context.Background(), &out); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	want := "db.internal:5433 quickquote s3cret quickquote require --format=custom --no-owner --no-privileges\n"
+	if out.String() != want {
+		t.Errorf("Dump() wrote %q, expected %q", out.String(), want)
+	}
+}
+
+func TestDumper_ReportsToolErrors(t *testing.T) {
+	d := &Dumper{
+		Path: fakeTool(t, `echo 'pg_dump: error: connection refused' >&2; exit 1`),
+		DB:   testDB,
+	}
+
+	err := d.Dump(context.Background(), &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Dump() error = %v, expected the tool's error output", err)
+	}
+}
+
+func TestRestorer_ReadsTheBackupFromStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "restored")
+	r := &Restorer{
+		Path:  fakeTool(t, `cat > `+out+`; echo "$*" >> `+out),
+		DB:    testDB,
+		Clean: true,
+	}
+
+	if err := r.Restore(context.Background(), strings.NewReader("dump\n")); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "dump\n--single-transaction --exit-on-error --no-owner --no-privileges --dbname=quickquote --clean --if-exists\n"
+	if string(got) != want {
+		t.Errorf("restored %q, expected %q", got, want)
+	}
+	if cmd := r.Command(); strings.Contains(cmd, "s3cret") {
+		t.Errorf("Command() = %q, expected the password left out", cmd)
+	}
+}
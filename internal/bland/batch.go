@@ -39,7 +39,7 @@ type BatchCall struct {
 
 // BatchCallTarget represents a target for a batch call.
 type BatchCallTarget struct {
-	PhoneNumber string                 `json:"phone_number"`
+	PhoneNumber string                 `json:"phone_number" validate:"required,e164"`
 	Variables   map[string]interface{} `json:"variables,omitempty"` // Per-call variable substitution
 }
 
@@ -52,7 +52,7 @@ type CreateBatchRequest struct {
 	BasePrompt string `json:"base_prompt,omitempty"`
 
 	// Calls: List of call targets with optional per-call variables
-	Calls []BatchCallTarget `json:"calls"`
+	Calls []BatchCallTarget `json:"calls" validate:"required"`
 
 	// CallParams: Shared call parameters for all calls in batch
 	// These are the same parameters as SendCallRequest
@@ -60,19 +60,19 @@ type CreateBatchRequest struct {
 	PathwayID         string  `json:"pathway_id,omitempty"`
 	Model             string  `json:"model,omitempty"`
 	Language          string  `json:"language,omitempty"`
-	MaxDuration       int     `json:"max_duration,omitempty"`
-	Temperature       float64 `json:"temperature,omitempty"`
+	MaxDuration       int     `json:"max_duration,omitempty" validate:"duration"`
+	Temperature       float64 `json:"temperature,omitempty" validate:"temperature"`
 	WaitForGreeting   bool    `json:"wait_for_greeting,omitempty"`
 	Record            bool    `json:"record,omitempty"`
-	WebhookURL        string  `json:"webhook,omitempty"`
+	WebhookURL        string  `json:"webhook,omitempty" validate:"url"`
 	WebhookEvents     []string `json:"webhook_events,omitempty"`
 	AnalyzeAfter      bool    `json:"analyze,omitempty"`
 	SummaryPrompt     string  `json:"summary_prompt,omitempty"`
 
 	// Scheduling
 	ScheduledTime     *time.Time `json:"scheduled_time,omitempty"`
-	CallsPerMinute    int        `json:"calls_per_minute,omitempty"` // Rate limiting
-	MaxConcurrentCalls int       `json:"max_concurrent_calls,omitempty"`
+	CallsPerMinute    int        `json:"calls_per_minute,omitempty" validate:"min=1"` // Rate limiting
+	MaxConcurrentCalls int       `json:"max_concurrent_calls,omitempty" validate:"min=1"`
 
 	// Metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
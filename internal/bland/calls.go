@@ -335,6 +335,44 @@ func (c *Client) EndCall(ctx context.Context, callID string) error {
 	return nil
 }
 
+// TransferCall hands an active call over to a phone number, such as a
+// human agent's line.
+func (c *Client) TransferCall(ctx context.Context, callID, phoneNumber string) error {
+	if callID == "" {
+		return fmt.Errorf("call_id is required")
+	}
+	if phoneNumber == "" {
+		return fmt.Errorf("phone_number is required")
+	}
+
+	body := map[string]string{"phone_number": phoneNumber}
+	if err := c.request(ctx, "POST", "/calls/"+callID+"/transfer", body, nil); err != nil {
+		return err
+	}
+
+	c.logger.Info("call transferred", zap.String("call_id", callID))
+	return nil
+}
+
+// SendCallMessage injects a message into an active call for the agent to
+// act on, for example to say something or change course.
+func (c *Client) SendCallMessage(ctx context.Context, callID, message string) error {
+	if callID == "" {
+		return fmt.Errorf("call_id is required")
+	}
+	if message == "" {
+		return fmt.Errorf("message is required")
+	}
+
+	body := map[string]string{"message": message}
+	if err := c.request(ctx, "POST", "/calls/"+callID+"/inject", body, nil); err != nil {
+		return err
+	}
+
+	c.logger.Info("message injected into call", zap.String("call_id", callID))
+	return nil
+}
+
 // AnalyzeCall performs post-call analysis to extract structured data.
 func (c *Client) AnalyzeCall(ctx context.Context, callID string, req *AnalyzeCallRequest) (*AnalyzeCallResponse, error) {
 	if callID == "" {
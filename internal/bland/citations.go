@@ -46,9 +46,9 @@ type SchemaField struct {
 
 // CreateCitationSchemaRequest contains parameters for creating a citation schema.
 type CreateCitationSchemaRequest struct {
-	Name        string                 `json:"name"`
+	Name        string                 `json:"name" validate:"required"`
 	Description string                 `json:"description,omitempty"`
-	Schema      map[string]SchemaField `json:"schema"`
+	Schema      map[string]SchemaField `json:"schema" validate:"required"`
 }
 
 // UpdateCitationSchemaRequest contains parameters for updating a citation schema.
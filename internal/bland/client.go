@@ -10,11 +10,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/tracing"
 )
 
 const (
@@ -30,18 +36,21 @@ const (
 
 // Client is the Bland AI API client.
 type Client struct {
+	mu             sync.RWMutex
 	apiKey         string
 	baseURL        string
 	httpClient     *http.Client
 	circuitBreaker *circuitbreaker.CircuitBreaker
+	metrics        *metrics.Metrics
 	logger         *zap.Logger
 }
 
 // Config holds configuration for the Bland API client.
 type Config struct {
-	APIKey  string
-	BaseURL string
-	Timeout time.Duration
+	APIKey     string
+	BaseURL    string
+	Timeout    time.Duration
+	HTTPClient *http.Client // Client for API calls; nil builds one with Timeout
 }
 
 // New creates a new Bland AI API client.
@@ -61,22 +70,47 @@ func New(cfg *Config, logger *zap.Logger) *Client {
 		HalfOpenMaxRequests: 3,
 	}
 
-	return &Client{
-		apiKey:  cfg.APIKey,
-		baseURL: cfg.BaseURL,
-		httpClient: &http.Client{
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Timeout: cfg.Timeout,
 			Transport: &http.Transport{
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
 				IdleConnTimeout:     90 * time.Second,
 			},
-		},
+		}
+	}
+
+	return &Client{
+		apiKey:         cfg.APIKey,
+		baseURL:        cfg.BaseURL,
+		httpClient:     httpClient,
 		circuitBreaker: circuitbreaker.New("bland-api", cbConfig, logger),
 		logger:         logger,
 	}
 }
 
+// SetMetrics sets the collector for API call latency and failures.
+func (c *Client) SetMetrics(m *metrics.Metrics) {
+	c.metrics = m
+}
+
+// SetAPIKey replaces the API key used for requests, such as after the key
+// is rotated. Requests already sent keep the old key.
+func (c *Client) SetAPIKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = key
+}
+
+// key returns the current API key.
+func (c *Client) key() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey
+}
+
 // APIError represents an error response from the Bland API.
 type APIError struct {
 	Status  string   `json:"status"`
@@ -93,9 +127,16 @@ func (e *APIError) Error() string {
 
 // request performs an HTTP request to the Bland API with circuit breaker protection.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	return c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
+	ctx, span := startSpan(ctx, method, path)
+	defer span.End()
+
+	start := time.Now()
+	err := c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
 		return c.doRequest(ctx, method, path, body, result)
 	})
+	c.recordCall(method, path, start, err)
+	tracing.RecordError(span, err)
+	return err
 }
 
 // doRequest performs the actual HTTP request.
@@ -116,9 +157,10 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", c.apiKey)
+	req.Header.Set("Authorization", c.key())
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	tracing.Inject(ctx, req.Header)
 
 	c.logger.Debug("bland API request",
 		zap.String("method", method),
@@ -130,6 +172,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -163,9 +206,43 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 
 // requestMultipart performs a multipart form request (for file uploads).
 func (c *Client) requestMultipart(ctx context.Context, path string, body io.Reader, contentType string, result interface{}) error {
-	return c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
+	ctx, span := startSpan(ctx, http.MethodPost, path)
+	defer span.End()
+
+	start := time.Now()
+	err := c.circuitBreaker.Execute(ctx, func(ctx context.Context) error {
 		return c.doRequestMultipart(ctx, path, body, contentType, result)
 	})
+	c.recordCall(http.MethodPost, path, start, err)
+	tracing.RecordError(span, err)
+	return err
+}
+
+// startSpan starts a client span for an API call, named like its metrics.
+func startSpan(ctx context.Context, method, path string) (context.Context, trace.Span) {
+	path, _, _ = strings.Cut(path, "?")
+	return tracing.Start(ctx, "bland "+apiOperation(method, path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", path),
+		),
+	)
+}
+
+// recordCall records the duration and outcome of an API call.
+func (c *Client) recordCall(method, path string, start time.Time, err error) {
+	if c.metrics != nil {
+		c.metrics.RecordProviderAPICall("bland", apiOperation(method, path), time.Since(start), err)
+	}
+}
+
+// apiOperation names an API call for metrics by its method and the first
+// segment of its path, keeping IDs out of metric labels.
+func apiOperation(method, path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return method + " /" + resource
 }
 
 // doRequestMultipart performs the actual multipart HTTP request.
@@ -177,15 +254,17 @@ func (c *Client) doRequestMultipart(ctx context.Context, path string, body io.Re
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", c.apiKey)
+	req.Header.Set("Authorization", c.key())
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
+	tracing.Inject(ctx, req.Header)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -214,6 +293,11 @@ func (c *Client) CircuitBreakerStats() circuitbreaker.Stats {
 	return c.circuitBreaker.Stats()
 }
 
+// CircuitBreaker returns the breaker guarding the Bland API.
+func (c *Client) CircuitBreaker() *circuitbreaker.CircuitBreaker {
+	return c.circuitBreaker
+}
+
 // IsCircuitOpen returns true if the circuit breaker is open.
 func (c *Client) IsCircuitOpen() bool {
 	return c.circuitBreaker.IsOpen()
@@ -60,9 +60,9 @@ type DynamicVariable struct {
 
 // CreateDynamicDataSourceRequest contains parameters for creating a data source.
 type CreateDynamicDataSourceRequest struct {
-	Name          string                   `json:"name"`
+	Name          string                   `json:"name" validate:"required"`
 	Description   string                   `json:"description,omitempty"`
-	Type          string                   `json:"type"`
+	Type          string                   `json:"type" validate:"required"`
 	Config        *DynamicDataSourceConfig `json:"config,omitempty"`
 	Variables     []DynamicVariable        `json:"variables,omitempty"`
 	DefaultValues map[string]interface{}   `json:"default_values,omitempty"`
@@ -29,9 +29,9 @@ type TwilioAccount struct {
 
 // CreateTwilioAccountRequest contains parameters for connecting a Twilio account.
 type CreateTwilioAccountRequest struct {
-	Name        string `json:"name"`
-	AccountSID  string `json:"account_sid"`
-	AuthToken   string `json:"auth_token"`
+	Name        string `json:"name" validate:"required"`
+	AccountSID  string `json:"account_sid" validate:"required"`
+	AuthToken   string `json:"auth_token" validate:"required"`
 	TrunkSID    string `json:"trunk_sid,omitempty"` // For SIP trunking
 }
 
@@ -171,10 +171,10 @@ type SIPTrunk struct {
 
 // CreateSIPTrunkRequest contains parameters for creating a SIP trunk.
 type CreateSIPTrunkRequest struct {
-	Name            string            `json:"name"`
+	Name            string            `json:"name" validate:"required"`
 	Domain          string            `json:"domain"`
-	Host            string            `json:"host"`
-	Port            int               `json:"port,omitempty"`
+	Host            string            `json:"host" validate:"required"`
+	Port            int               `json:"port,omitempty" validate:"min=1,max=65535"`
 	Transport       string            `json:"transport,omitempty"`
 	Username        string            `json:"username,omitempty"`
 	Password        string            `json:"password,omitempty"`
@@ -359,7 +359,7 @@ type DialingPool struct {
 
 // PoolNumber represents a phone number in a dialing pool.
 type PoolNumber struct {
-	PhoneNumber   string    `json:"phone_number"`
+	PhoneNumber   string    `json:"phone_number" validate:"required,e164"`
 	Weight        int       `json:"weight,omitempty"`
 	AreaCode      string    `json:"area_code,omitempty"`
 	Region        string    `json:"region,omitempty"`
@@ -381,12 +381,12 @@ type DialingPoolStats struct {
 
 // CreateDialingPoolRequest contains parameters for creating a dialing pool.
 type CreateDialingPoolRequest struct {
-	Name           string       `json:"name"`
+	Name           string       `json:"name" validate:"required"`
 	Description    string       `json:"description,omitempty"`
 	PhoneNumbers   []PoolNumber `json:"phone_numbers,omitempty"`
 	Strategy       string       `json:"strategy,omitempty"`
-	MaxConcurrent  int          `json:"max_concurrent,omitempty"`
-	CooldownPeriod int          `json:"cooldown_period,omitempty"`
+	MaxConcurrent  int          `json:"max_concurrent,omitempty" validate:"min=1"`
+	CooldownPeriod int          `json:"cooldown_period,omitempty" validate:"min=0"`
 	LocalPresence  bool         `json:"local_presence,omitempty"`
 }
 
@@ -24,13 +24,13 @@ type KnowledgeBase struct {
 // CreateKnowledgeBaseRequest contains parameters for creating a knowledge base.
 type CreateKnowledgeBaseRequest struct {
 	// Name: A clear name that describes the contents
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required"`
 
 	// Description: Visible to AI, helps it understand when to use this KB
 	Description string `json:"description"`
 
 	// Text: The full text document to be vectorized
-	Text string `json:"text"`
+	Text string `json:"text" validate:"required"`
 }
 
 // CreateKnowledgeBaseResponse contains the response from creating a KB.
@@ -112,7 +112,7 @@ type SearchAvailableNumbersResponse struct {
 
 // PurchaseNumberRequest contains parameters for purchasing a phone number.
 type PurchaseNumberRequest struct {
-	PhoneNumber   string         `json:"phone_number"`
+	PhoneNumber   string         `json:"phone_number" validate:"required,e164"`
 	InboundConfig *InboundConfig `json:"inbound_config,omitempty"`
 	Labels        map[string]string `json:"labels,omitempty"`
 }
@@ -130,7 +130,7 @@ type UpdatePhoneNumberRequest struct {
 	InboundPathwayID  *string           `json:"inbound_pathway_id,omitempty"`
 	InboundPrompt     *string           `json:"inbound_prompt,omitempty"`
 	InboundVoice      *string           `json:"inbound_voice,omitempty"`
-	InboundWebhookURL *string           `json:"inbound_webhook_url,omitempty"`
+	InboundWebhookURL *string           `json:"inbound_webhook_url,omitempty" validate:"url"`
 	InboundConfig     *InboundConfig    `json:"inbound_config,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
 }
@@ -152,9 +152,9 @@ type ListBlockedNumbersResponse struct {
 
 // BlockNumberRequest contains parameters for blocking a number.
 type BlockNumberRequest struct {
-	PhoneNumber string `json:"phone_number"`
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
 	Reason      string `json:"reason,omitempty"`
-	Direction   string `json:"direction,omitempty"` // inbound, outbound, both
+	Direction   string `json:"direction,omitempty" validate:"oneof=inbound outbound both"` // inbound, outbound, both
 }
 
 // ListPhoneNumbers retrieves all phone numbers in the account.
@@ -129,7 +129,7 @@ type PathwayFolder struct {
 
 // CreatePathwayRequest contains parameters for creating a pathway.
 type CreatePathwayRequest struct {
-	Name        string        `json:"name"`
+	Name        string        `json:"name" validate:"required"`
 	Description string        `json:"description,omitempty"`
 	Nodes       []PathwayNode `json:"nodes,omitempty"`
 	Edges       []PathwayEdge `json:"edges,omitempty"`
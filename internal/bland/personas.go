@@ -62,7 +62,7 @@ type Persona struct {
 
 // CreatePersonaRequest contains parameters for creating a persona.
 type CreatePersonaRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" validate:"required"`
 	Description string `json:"description,omitempty"`
 
 	// Agent configuration
@@ -70,23 +70,23 @@ type CreatePersonaRequest struct {
 	Voice              string  `json:"voice,omitempty"`
 	Language           string  `json:"language,omitempty"`
 	Model              string  `json:"model,omitempty"`
-	Temperature        float64 `json:"temperature,omitempty"`
+	Temperature        float64 `json:"temperature,omitempty" validate:"temperature"`
 	FirstSentence      string  `json:"first_sentence,omitempty"`
 	WaitForGreeting    bool    `json:"wait_for_greeting,omitempty"`
-	InterruptThreshold int     `json:"interruption_threshold,omitempty"`
+	InterruptThreshold int     `json:"interruption_threshold,omitempty" validate:"min=0"`
 
 	// Call settings
-	MaxDuration       int    `json:"max_duration,omitempty"`
+	MaxDuration       int    `json:"max_duration,omitempty" validate:"duration"`
 	Record            bool   `json:"record,omitempty"`
 	BackgroundTrack   string `json:"background_track,omitempty"`
 	NoiseCancellation bool   `json:"noise_cancellation,omitempty"`
 
 	// Transfer
-	TransferPhoneNumber string            `json:"transfer_phone_number,omitempty"`
+	TransferPhoneNumber string            `json:"transfer_phone_number,omitempty" validate:"e164"`
 	TransferList        map[string]string `json:"transfer_list,omitempty"`
 
 	// Voicemail
-	VoicemailAction  string `json:"voicemail_action,omitempty"`
+	VoicemailAction  string `json:"voicemail_action,omitempty" validate:"oneof=hangup leave_message ignore"`
 	VoicemailMessage string `json:"voicemail_message,omitempty"`
 
 	// Tools
@@ -111,20 +111,20 @@ type UpdatePersonaRequest struct {
 	Voice              *string  `json:"voice,omitempty"`
 	Language           *string  `json:"language,omitempty"`
 	Model              *string  `json:"model,omitempty"`
-	Temperature        *float64 `json:"temperature,omitempty"`
+	Temperature        *float64 `json:"temperature,omitempty" validate:"temperature"`
 	FirstSentence      *string  `json:"first_sentence,omitempty"`
 	WaitForGreeting    *bool    `json:"wait_for_greeting,omitempty"`
-	InterruptThreshold *int     `json:"interruption_threshold,omitempty"`
+	InterruptThreshold *int     `json:"interruption_threshold,omitempty" validate:"min=0"`
 
-	MaxDuration       *int    `json:"max_duration,omitempty"`
+	MaxDuration       *int    `json:"max_duration,omitempty" validate:"duration"`
 	Record            *bool   `json:"record,omitempty"`
 	BackgroundTrack   *string `json:"background_track,omitempty"`
 	NoiseCancellation *bool   `json:"noise_cancellation,omitempty"`
 
-	TransferPhoneNumber *string           `json:"transfer_phone_number,omitempty"`
+	TransferPhoneNumber *string           `json:"transfer_phone_number,omitempty" validate:"e164"`
 	TransferList        map[string]string `json:"transfer_list,omitempty"`
 
-	VoicemailAction  *string `json:"voicemail_action,omitempty"`
+	VoicemailAction  *string `json:"voicemail_action,omitempty" validate:"oneof=hangup leave_message ignore"`
 	VoicemailMessage *string `json:"voicemail_message,omitempty"`
 
 	Tools            []string `json:"tools,omitempty"`
@@ -40,13 +40,13 @@ type SMSConversation struct {
 // SendSMSRequest contains parameters for sending an SMS.
 type SendSMSRequest struct {
 	// To: The recipient phone number (E.164 format)
-	To string `json:"to"`
+	To string `json:"to" validate:"required,e164"`
 
 	// From: The sender phone number (must be a Bland number)
-	From string `json:"from,omitempty"`
+	From string `json:"from,omitempty" validate:"e164"`
 
 	// Body: The message content
-	Body string `json:"body"`
+	Body string `json:"body" validate:"required"`
 
 	// MediaURLs: URLs for MMS attachments (images, etc.)
 	MediaURLs []string `json:"media_urls,omitempty"`
@@ -55,7 +55,7 @@ type SendSMSRequest struct {
 	Task string `json:"task,omitempty"` // AI task for responding to replies
 
 	// Webhook for delivery status updates
-	WebhookURL string `json:"webhook_url,omitempty"`
+	WebhookURL string `json:"webhook_url,omitempty" validate:"url"`
 
 	// Scheduling
 	ScheduledTime *time.Time `json:"scheduled_time,omitempty"`
@@ -77,13 +77,13 @@ type SendSMSResponse struct {
 // StartSMSConversationRequest contains parameters for starting an AI SMS conversation.
 type StartSMSConversationRequest struct {
 	// To: The recipient phone number
-	To string `json:"to"`
+	To string `json:"to" validate:"required,e164"`
 
 	// From: The sender phone number (Bland number)
-	From string `json:"from,omitempty"`
+	From string `json:"from,omitempty" validate:"e164"`
 
 	// Task: The AI task/prompt for managing the conversation
-	Task string `json:"task"`
+	Task string `json:"task" validate:"required"`
 
 	// FirstMessage: The initial message to send
 	FirstMessage string `json:"first_message,omitempty"`
@@ -92,7 +92,7 @@ type StartSMSConversationRequest struct {
 	Model string `json:"model,omitempty"`
 
 	// Temperature: AI response creativity
-	Temperature float64 `json:"temperature,omitempty"`
+	Temperature float64 `json:"temperature,omitempty" validate:"temperature"`
 
 	// PathwayID: Use a conversational pathway
 	PathwayID string `json:"pathway_id,omitempty"`
@@ -101,10 +101,10 @@ type StartSMSConversationRequest struct {
 	KnowledgeBaseIDs []string `json:"knowledge_base_ids,omitempty"`
 
 	// WebhookURL: Webhook for conversation events
-	WebhookURL string `json:"webhook_url,omitempty"`
+	WebhookURL string `json:"webhook_url,omitempty" validate:"url"`
 
 	// MaxMessages: Maximum messages in conversation
-	MaxMessages int `json:"max_messages,omitempty"`
+	MaxMessages int `json:"max_messages,omitempty" validate:"min=1"`
 
 	// Metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
@@ -286,6 +286,20 @@ func (c *Client) SendQuoteFollowUp(ctx context.Context, phoneNumber, customerNam
 	})
 }
 
+// SendQuoteAcceptedSMS confirms to a customer that their acceptance of a
+// quote was received.
+func (c *Client) SendQuoteAcceptedSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*SendSMSResponse, error) {
+	body := fmt.Sprintf("Thanks %s! We've received your acceptance of quote %s and will be in touch shortly to get started.", customerName, quoteID)
+	return c.SendSMS(ctx, &SendSMSRequest{
+		To:   phoneNumber,
+		Body: body,
+		Metadata: map[string]interface{}{
+			"type":     "quote_accepted",
+			"quote_id": quoteID,
+		},
+	})
+}
+
 // SendQuoteReadySMS notifies customer their quote is ready.
 func (c *Client) SendQuoteReadySMS(ctx context.Context, phoneNumber, quoteID string, amount float64) (*SendSMSResponse, error) {
 	body := fmt.Sprintf("Great news! Your quote is ready. Quote ID: %s, Estimated: $%.2f. Reply YES to accept or call us to discuss.", quoteID, amount)
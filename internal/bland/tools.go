@@ -3,6 +3,7 @@ package bland
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -73,19 +74,19 @@ type ToolSpeechConfig struct {
 // CreateToolRequest contains parameters for creating a custom tool.
 type CreateToolRequest struct {
 	// Name: A clear name for the tool (AI uses this to decide when to call)
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required"`
 
 	// Description: Explains to AI when/why to use this tool
 	Description string `json:"description"`
 
 	// Type: "webhook" for HTTP calls, "function" for built-in functions
-	Type string `json:"type"`
+	Type string `json:"type" validate:"required,oneof=webhook function"`
 
 	// URL: The endpoint to call (for webhook type)
-	URL string `json:"url,omitempty"`
+	URL string `json:"url,omitempty" validate:"url"`
 
 	// Method: HTTP method (default: POST)
-	Method string `json:"method,omitempty"`
+	Method string `json:"method,omitempty" validate:"oneof=GET POST PUT PATCH DELETE"`
 
 	// Headers: Additional HTTP headers
 	Headers map[string]string `json:"headers,omitempty"`
@@ -100,11 +101,11 @@ type CreateToolRequest struct {
 	SpeechConfig *ToolSpeechConfig `json:"speech,omitempty"`
 
 	// Timeout: Maximum time to wait for response (seconds)
-	Timeout int `json:"timeout,omitempty"`
+	Timeout int `json:"timeout,omitempty" validate:"duration"`
 
 	// RetryConfig: Retry behavior on failure
-	RetryCount int `json:"retry_count,omitempty"`
-	RetryDelay int `json:"retry_delay,omitempty"` // seconds
+	RetryCount int `json:"retry_count,omitempty" validate:"min=0"`
+	RetryDelay int `json:"retry_delay,omitempty" validate:"min=0"` // seconds
 }
 
 // UpdateToolRequest contains parameters for updating a tool.
@@ -314,14 +315,35 @@ func NewQuoteLookupTool(webhookBaseURL string) *CreateToolRequest {
 	}
 }
 
+// ScheduleCallbackToolPath is where the schedule_callback tool is served,
+// relative to the server root.
+const ScheduleCallbackToolPath = "/webhook/bland/tools/schedule-callback"
+
+// Headers Bland sends with schedule_callback tool requests.
+const (
+	ToolCallIDHeader = "X-Call-ID"     // The call the tool was used on
+	ToolSecretHeader = "X-Tool-Secret" // Shared secret authenticating the request
+)
+
 // NewScheduleCallbackTool creates a tool for scheduling callback appointments.
-func NewScheduleCallbackTool(webhookBaseURL string) *CreateToolRequest {
+// serverBaseURL is the public URL of the QuickQuote server; secret, if set,
+// is sent with every request for the server to verify.
+func NewScheduleCallbackTool(serverBaseURL, secret string) *CreateToolRequest {
+	headers := map[string]string{
+		"Content-Type":   "application/json",
+		ToolCallIDHeader: "{{call_id}}",
+	}
+	if secret != "" {
+		headers[ToolSecretHeader] = secret
+	}
+
 	return &CreateToolRequest{
 		Name:        "schedule_callback",
 		Description: "Schedule a callback appointment for the customer. Use when they want to speak with a representative later.",
 		Type:        "webhook",
-		URL:         webhookBaseURL + "/api/v1/tools/schedule-callback",
+		URL:         strings.TrimRight(serverBaseURL, "/") + ScheduleCallbackToolPath,
 		Method:      "POST",
+		Headers:     headers,
 		Parameters: []ToolParameter{
 			{
 				Name:        "preferred_date",
@@ -342,6 +364,14 @@ func NewScheduleCallbackTool(webhookBaseURL string) *CreateToolRequest {
 				Required:    false,
 			},
 		},
+		ResponseMap: &ResponseMapping{
+			SuccessPath: "$.success",
+			ErrorPath:   "$.error",
+			FieldMappings: map[string]string{
+				"date": "$.date",
+				"time": "$.time",
+			},
+		},
 		SpeechConfig: &ToolSpeechConfig{
 			BeforeExecution: "I'm scheduling that callback for you now.",
 			OnSuccess:       "I've scheduled your callback for {{date}} at {{time}}. You'll receive a confirmation.",
@@ -43,7 +43,7 @@ type ListVoicesResponse struct {
 
 // CloneVoiceRequest contains parameters for cloning a voice.
 type CloneVoiceRequest struct {
-	Name         string        `json:"name"`
+	Name         string        `json:"name" validate:"required"`
 	Description  string        `json:"description,omitempty"`
 	AudioSamples []io.Reader   `json:"-"` // Audio files for cloning
 }
@@ -57,7 +57,7 @@ type CloneVoiceResponse struct {
 
 // GenerateSampleRequest contains parameters for generating a voice sample.
 type GenerateSampleRequest struct {
-	Text          string         `json:"text"`
+	Text          string         `json:"text" validate:"required"`
 	VoiceSettings *VoiceSettings `json:"voice_settings,omitempty"`
 	Language      string         `json:"language,omitempty"`
 }
@@ -0,0 +1,138 @@
+// Package cache provides a small in-process cache with a TTL, for data that
+// is read far more often than it is written, such as settings and prompts.
+//
+// Each process has its own cache. Writes made through a service invalidate
+// that process's entries immediately; other replicas see the change once
+// their entries expire, so the TTL bounds how stale a replica can be.
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+// Stats describes a cache's contents and how well it is doing.
+type Stats struct {
+	Name          string  `json:"name"`
+	Entries       int     `json:"entries"`
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	HitRate       float64 `json:"hit_rate"`
+	Invalidations uint64  `json:"invalidations"`
+	TTLSeconds    float64 `json:"ttl_seconds"`
+}
+
+// Cache is a map whose entries expire after a TTL. A zero TTL disables it:
+// nothing is stored and every Get misses.
+type Cache[K comparable, V any] struct {
+	name  string
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu      sync.RWMutex
+	entries map[K]entry[V]
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// New creates a cache. A nil clock uses the real time.
+func New[K comparable, V any](name string, ttl time.Duration, clk clock.Clock) *Cache[K, V] {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Cache[K, V]{
+		name:    name,
+		ttl:     ttl,
+		clock:   clk,
+		entries: make(map[K]entry[V]),
+	}
+}
+
+// Get returns the value cached for key, if it hasn't expired.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || !c.clock.Now().Before(e.expiresAt) {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// Set caches value for key until the TTL passes.
+func (c *Cache[K, V]) Set(key K, value V) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	now := c.clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired entries so keys that are never read again don't pile up
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Delete removes key from the cache.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+	c.invalidations.Add(1)
+}
+
+// Clear removes every entry from the cache.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	c.entries = make(map[K]entry[V])
+	c.mu.Unlock()
+	c.invalidations.Add(1)
+}
+
+// Stats returns the cache's current statistics. Entries counts only those
+// that haven't expired.
+func (c *Cache[K, V]) Stats() Stats {
+	now := c.clock.Now()
+	c.mu.RLock()
+	entries := 0
+	for _, e := range c.entries {
+		if now.Before(e.expiresAt) {
+			entries++
+		}
+	}
+	c.mu.RUnlock()
+
+	hits, misses := c.hits.Load(), c.misses.Load()
+	var hitRate float64
+	if hits+misses > 0 {
+		hitRate = float64(hits) / float64(hits+misses)
+	}
+	return Stats{
+		Name:          c.name,
+		Entries:       entries,
+		Hits:          hits,
+		Misses:        misses,
+		HitRate:       hitRate,
+		Invalidations: c.invalidations.Load(),
+		TTLSeconds:    c.ttl.Seconds(),
+	}
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+func TestCache_GetSet(t *testing.T) {
+	clk := clock.NewMock(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC))
+	c := New[string, int]("test", time.Minute, clk)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get() hit on an empty cache")
+	}
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get() = %d, %v, expected 1, true", v, ok)
+	}
+
+	clk.Advance(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() hit after the TTL passed")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Entries != 0 {
+		t.Errorf("Stats() = %+v, expected 1 hit, 2 misses and no entries", stats)
+	}
+	if stats.HitRate != 1.0/3 {
+		t.Errorf("HitRate = %v, expected 1/3", stats.HitRate)
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c := New[string, int]("test", time.Minute, nil)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() hit after Delete()")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Error("Delete() removed another key")
+	}
+
+	c.Clear()
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get() hit after Clear()")
+	}
+	if stats := c.Stats(); stats.Invalidations != 2 {
+		t.Errorf("Invalidations = %d, expected 2", stats.Invalidations)
+	}
+}
+
+func TestCache_ZeroTTLDisables(t *testing.T) {
+	c := New[string, int]("test", 0, nil)
+	c.Set("a", 1)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get() hit with caching disabled")
+	}
+}
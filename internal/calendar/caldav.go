@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// icsTimeFormat is the iCalendar UTC date-time format.
+const icsTimeFormat = "20060102T150405Z"
+
+// CalDAVCalendar creates events on a CalDAV calendar collection, such as
+// Nextcloud, Fastmail or iCloud.
+type CalDAVCalendar struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewCalDAVCalendar creates a CalDAV calendar. A default HTTP client is used
+// when client is nil.
+func NewCalDAVCalendar(cfg Config, client *http.Client) *CalDAVCalendar {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &CalDAVCalendar{
+		url:      strings.TrimRight(cfg.CalDAVURL, "/"),
+		username: cfg.CalDAVUsername,
+		password: cfg.CalDAVPassword,
+		client:   client,
+	}
+}
+
+// Name returns the backend name.
+func (c *CalDAVCalendar) Name() string {
+	return ProviderCalDAV
+}
+
+// CreateEvent stores the event as <uid>.ics in the collection and returns
+// its URL.
+func (c *CalDAVCalendar) CreateEvent(ctx context.Context, event *Event) (string, error) {
+	if err := event.Validate(); err != nil {
+		return "", err
+	}
+
+	resource := c.url + "/" + event.UID + ".ics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, resource, strings.NewReader(buildICS(event, time.Now())))
+	if err != nil {
+		return "", fmt.Errorf("failed to create caldav request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	// Never overwrite: an existing resource means an earlier attempt succeeded.
+	req.Header.Set("If-None-Match", "*")
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("caldav request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return resource, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("caldav server returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resource, nil
+}
+
+// buildICS renders the event as an iCalendar object.
+func buildICS(event *Event, now time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//QuickQuote//Callbacks//EN",
+		"BEGIN:VEVENT",
+		"UID:" + event.UID,
+		"DTSTAMP:" + now.UTC().Format(icsTimeFormat),
+		"DTSTART:" + event.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + event.End.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + escapeICSText(event.Summary),
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICSText(event.Description))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545 section 3.3.11).
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldICSLine splits a content line into 75-octet lines, without breaking
+// UTF-8 sequences (RFC 5545 section 3.1).
+func foldICSLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
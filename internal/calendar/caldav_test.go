@@ -0,0 +1,133 @@
+package calendar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEvent() *Event {
+	start := time.Date(2026, 3, 5, 14, 0, 0, 0, time.FixedZone("CST", -6*60*60))
+	return &Event{
+		UID:         "0f3c9a1e2b7d4c6e8a5b9d0e1f2a3b4c",
+		Summary:     "Call back Dana",
+		Description: "Phone: +15550001111\nReason: pricing, timeline; next steps",
+		Start:       start,
+		End:         start.Add(30 * time.Minute),
+	}
+}
+
+func TestCalDAVCalendar_CreateEvent(t *testing.T) {
+	var gotPath, gotBody string
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		gotPath = r.URL.Path
+		gotHeader = r.Header
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cal := NewCalDAVCalendar(Config{
+		CalDAVURL:      server.URL + "/dav/calendars/office/callbacks/",
+		CalDAVUsername: "office",
+		CalDAVPassword: "secret",
+	}, server.Client())
+
+	event := testEvent()
+	id, err := cal.CreateEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	wantPath := "/dav/calendars/office/callbacks/" + event.UID + ".ics"
+	if gotPath != wantPath {
+		t.Errorf("path = %q, want %q", gotPath, wantPath)
+	}
+	if id != server.URL+wantPath {
+		t.Errorf("id = %q, want the resource URL", id)
+	}
+	if gotHeader.Get("If-None-Match") != "*" {
+		t.Errorf("If-None-Match = %q, want *", gotHeader.Get("If-None-Match"))
+	}
+	if !strings.HasPrefix(gotHeader.Get("Content-Type"), "text/calendar") {
+		t.Errorf("Content-Type = %q", gotHeader.Get("Content-Type"))
+	}
+	if user, pass, ok := (&http.Request{Header: gotHeader}).BasicAuth(); !ok || user != "office" || pass != "secret" {
+		t.Errorf("basic auth = %q/%q, %v", user, pass, ok)
+	}
+	for _, want := range []string{
+		"UID:" + event.UID + "\r\n",
+		"DTSTART:20260305T200000Z\r\n",
+		"DTEND:20260305T203000Z\r\n",
+		"SUMMARY:Call back Dana\r\n",
+	} {
+		if !strings.Contains(gotBody, want) {
+			t.Errorf("body missing %q:\n%s", want, gotBody)
+		}
+	}
+}
+
+func TestCalDAVCalendar_CreateEvent_AlreadyExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+	}))
+	defer server.Close()
+
+	cal := NewCalDAVCalendar(Config{CalDAVURL: server.URL}, server.Client())
+	if _, err := cal.CreateEvent(context.Background(), testEvent()); err != nil {
+		t.Errorf("CreateEvent() error = %v, want success for an existing event", err)
+	}
+}
+
+func TestCalDAVCalendar_CreateEvent_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	cal := NewCalDAVCalendar(Config{CalDAVURL: server.URL}, server.Client())
+	_, err := cal.CreateEvent(context.Background(), testEvent())
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("CreateEvent() error = %v, want a 403 error", err)
+	}
+}
+
+func TestBuildICS_EscapesAndFolds(t *testing.T) {
+	event := testEvent()
+	event.Description = strings.Repeat("é", 60) + "\nnext line"
+
+	ics := buildICS(event, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Errorf("ics is not a VCALENDAR:\n%s", ics)
+	}
+	if !strings.Contains(ics, "DTSTAMP:20260301T000000Z\r\n") {
+		t.Errorf("ics missing DTSTAMP:\n%s", ics)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is %d octets, want at most 75: %q", len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, "DESCRIPTION:"+strings.Repeat("é", 60)+`\nnext line`) {
+		t.Errorf("description not escaped and folded correctly:\n%s", ics)
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	got := escapeICSText("a\\b; c, d\r\ne")
+	want := `a\\b\; c\, d\ne`
+	if got != want {
+		t.Errorf("escapeICSText() = %q, want %q", got, want)
+	}
+}
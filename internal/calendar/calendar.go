@@ -0,0 +1,95 @@
+// Package calendar puts events on a Google or CalDAV calendar.
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone   = ""
+	ProviderGoogle = "google"
+	ProviderCalDAV = "caldav"
+)
+
+// Event is a calendar event to create.
+type Event struct {
+	// UID identifies the event. Creating an event whose UID already exists
+	// succeeds without creating a duplicate, so failed attempts can be
+	// retried safely. It must be lowercase hex, such as a UUID without dashes.
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Validate checks that the event can be created.
+func (e *Event) Validate() error {
+	if e.UID == "" {
+		return errors.New("event uid is required")
+	}
+	if strings.TrimSpace(e.Summary) == "" {
+		return errors.New("event summary is required")
+	}
+	if !e.End.After(e.Start) {
+		return errors.New("event must end after it starts")
+	}
+	return nil
+}
+
+// Calendar creates events on a calendar.
+type Calendar interface {
+	// CreateEvent creates an event and returns its ID on the calendar.
+	CreateEvent(ctx context.Context, event *Event) (string, error)
+
+	// Name returns the backend name for logging.
+	Name() string
+}
+
+// Config holds calendar backend settings.
+type Config struct {
+	Provider string // "google", "caldav", or empty to disable
+
+	GoogleCalendarID      string // e.g. "primary" or the calendar's email address
+	GoogleCredentialsFile string // Service account JSON key with access to the calendar
+	GoogleAPIURL          string // Overrides the Calendar API URL, for tests
+
+	CalDAVURL      string // Calendar collection URL; events are PUT beneath it
+	CalDAVUsername string
+	CalDAVPassword string
+}
+
+// Enabled reports whether a calendar backend is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != ProviderNone
+}
+
+// New creates the calendar for the configured provider. It returns nil when
+// no provider is configured.
+func New(cfg Config) (Calendar, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderNone:
+		return nil, nil
+	case ProviderGoogle:
+		if cfg.GoogleCredentialsFile == "" {
+			return nil, errors.New("google calendar credentials file is required")
+		}
+		g, err := NewGoogleCalendar(cfg, nil)
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	case ProviderCalDAV:
+		if cfg.CalDAVURL == "" {
+			return nil, errors.New("caldav url is required")
+		}
+		return NewCalDAVCalendar(cfg, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown calendar provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,59 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvent_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC)
+	valid := Event{UID: "abc123", Summary: "Call back Dana", Start: start, End: start.Add(30 * time.Minute)}
+
+	tests := []struct {
+		name    string
+		modify  func(*Event)
+		wantErr bool
+	}{
+		{"valid", func(e *Event) {}, false},
+		{"missing uid", func(e *Event) { e.UID = "" }, true},
+		{"blank summary", func(e *Event) { e.Summary = "  " }, true},
+		{"ends at start", func(e *Event) { e.End = e.Start }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := valid
+			tt.modify(&e)
+			if err := e.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	cal, err := New(Config{})
+	if err != nil || cal != nil {
+		t.Errorf("New() with no provider = %v, %v; want nil, nil", cal, err)
+	}
+
+	cal, err = New(Config{Provider: "CalDAV", CalDAVURL: "https://dav.example.com/cal/"})
+	if err != nil {
+		t.Fatalf("New(caldav) error = %v", err)
+	}
+	if cal.Name() != ProviderCalDAV {
+		t.Errorf("Name() = %q, want caldav", cal.Name())
+	}
+
+	invalid := []Config{
+		{Provider: "caldav"},
+		{Provider: "google"},
+		{Provider: "google", GoogleCredentialsFile: "/nonexistent/key.json"},
+		{Provider: "outlook"},
+	}
+	for _, cfg := range invalid {
+		if cal, err := New(cfg); err == nil || cal != nil {
+			t.Errorf("New(%+v) = %v, %v; want an error", cfg, cal, err)
+		}
+	}
+}
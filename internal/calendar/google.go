@@ -0,0 +1,257 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultGoogleAPIURL is the Google Calendar v3 API.
+	defaultGoogleAPIURL = "https://www.googleapis.com/calendar/v3"
+
+	// googleCalendarScope allows managing events on calendars the service
+	// account has been shared.
+	googleCalendarScope = "https://www.googleapis.com/auth/calendar.events"
+)
+
+// GoogleCalendar creates events through the Google Calendar API,
+// authenticating as a service account.
+type GoogleCalendar struct {
+	calendarID string
+	apiURL     string
+	creds      *serviceAccountKey
+	client     *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// serviceAccountKey is the part of a Google service account JSON key used to
+// obtain access tokens.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	key *rsa.PrivateKey
+}
+
+// NewGoogleCalendar creates a Google calendar from the service account key in
+// cfg.GoogleCredentialsFile. A default HTTP client is used when client is nil.
+func NewGoogleCalendar(cfg Config, client *http.Client) (*GoogleCalendar, error) {
+	data, err := os.ReadFile(cfg.GoogleCredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read google credentials: %w", err)
+	}
+	creds, err := parseServiceAccountKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	calendarID := cfg.GoogleCalendarID
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	apiURL := cfg.GoogleAPIURL
+	if apiURL == "" {
+		apiURL = defaultGoogleAPIURL
+	}
+	return &GoogleCalendar{
+		calendarID: calendarID,
+		apiURL:     strings.TrimRight(apiURL, "/"),
+		creds:      creds,
+		client:     client,
+	}, nil
+}
+
+// Name returns the backend name.
+func (g *GoogleCalendar) Name() string {
+	return ProviderGoogle
+}
+
+type googleEventTime struct {
+	DateTime string `json:"dateTime"`
+}
+
+type googleEvent struct {
+	ID          string          `json:"id,omitempty"`
+	Summary     string          `json:"summary"`
+	Description string          `json:"description,omitempty"`
+	Start       googleEventTime `json:"start"`
+	End         googleEventTime `json:"end"`
+}
+
+// CreateEvent creates an event, using event.UID as the Google event ID.
+func (g *GoogleCalendar) CreateEvent(ctx context.Context, event *Event) (string, error) {
+	if err := event.Validate(); err != nil {
+		return "", err
+	}
+
+	token, err := g.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(googleEvent{
+		ID:          event.UID,
+		Summary:     event.Summary,
+		Description: event.Description,
+		Start:       googleEventTime{DateTime: event.Start.Format(time.RFC3339)},
+		End:         googleEventTime{DateTime: event.End.Format(time.RFC3339)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal google event: %w", err)
+	}
+
+	endpoint := g.apiURL + "/calendars/" + url.PathEscape(g.calendarID) + "/events"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create google calendar request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("google calendar request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	// 409 means an earlier attempt already created the event.
+	if resp.StatusCode == http.StatusConflict {
+		return event.UID, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("google calendar returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var created googleEvent
+	if err := json.Unmarshal(respBody, &created); err != nil || created.ID == "" {
+		return event.UID, nil
+	}
+	return created.ID, nil
+}
+
+// token returns a cached access token, requesting a new one shortly before
+// the current one expires.
+func (g *GoogleCalendar) token(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Now().Before(g.tokenExpiry) {
+		return g.accessToken, nil
+	}
+
+	assertion, err := g.creds.assertion(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.creds.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create google token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("google token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("google token endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil || tok.AccessToken == "" {
+		return "", errors.New("google token endpoint returned no access token")
+	}
+
+	g.accessToken = tok.AccessToken
+	g.tokenExpiry = time.Now().Add(time.Duration(tok.ExpiresIn)*time.Second - time.Minute)
+	return g.accessToken, nil
+}
+
+// parseServiceAccountKey reads a service account JSON key.
+func parseServiceAccountKey(data []byte) (*serviceAccountKey, error) {
+	var creds serviceAccountKey
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse google credentials: %w", err)
+	}
+	if creds.ClientEmail == "" || creds.PrivateKey == "" {
+		return nil, errors.New("google credentials must be a service account key")
+	}
+	if creds.TokenURI == "" {
+		creds.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	block, _ := pem.Decode([]byte(creds.PrivateKey))
+	if block == nil {
+		return nil, errors.New("google credentials private key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if pkcs1, err1 := x509.ParsePKCS1PrivateKey(block.Bytes); err1 == nil {
+			parsed = pkcs1
+		} else {
+			return nil, fmt.Errorf("failed to parse google credentials private key: %w", err)
+		}
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("google credentials private key is not an RSA key")
+	}
+	creds.key = key
+	return &creds, nil
+}
+
+// assertion builds the signed JWT exchanged for an access token.
+func (k *serviceAccountKey) assertion(now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss":   k.ClientEmail,
+		"scope": googleCalendarScope,
+		"aud":   k.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, k.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign google token request: %w", err)
+	}
+	return signingInput + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// writeServiceAccountKey writes a service account key for tokenURI and
+// returns its path.
+func writeServiceAccountKey(t *testing.T, tokenURI string) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	data, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "callbacks@example.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    tokenURI,
+	})
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestGoogleCalendar_CreateEvent(t *testing.T) {
+	var tokenRequests int32
+	var gotEvent googleEvent
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		if err := r.ParseForm(); err != nil || r.Form.Get("assertion") == "" {
+			t.Errorf("token request missing assertion")
+		}
+		if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("grant_type = %q", r.Form.Get("grant_type"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok-1", "expires_in": 3600})
+	})
+	mux.HandleFunc("/calendar/calendars/office@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok-1" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&gotEvent)
+		json.NewEncoder(w).Encode(gotEvent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cal, err := NewGoogleCalendar(Config{
+		GoogleCalendarID:      "office@example.com",
+		GoogleCredentialsFile: writeServiceAccountKey(t, server.URL+"/token"),
+		GoogleAPIURL:          server.URL + "/calendar",
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("NewGoogleCalendar() error = %v", err)
+	}
+
+	event := testEvent()
+	for i := 0; i < 2; i++ {
+		id, err := cal.CreateEvent(context.Background(), event)
+		if err != nil {
+			t.Fatalf("CreateEvent() error = %v", err)
+		}
+		if id != event.UID {
+			t.Errorf("id = %q, want %q", id, event.UID)
+		}
+	}
+
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Errorf("token requested %d times, want 1", n)
+	}
+	if gotEvent.Summary != event.Summary || gotEvent.Description != event.Description {
+		t.Errorf("event = %+v", gotEvent)
+	}
+	if gotEvent.Start.DateTime != "2026-03-05T14:00:00-06:00" || gotEvent.End.DateTime != "2026-03-05T14:30:00-06:00" {
+		t.Errorf("times = %q to %q", gotEvent.Start.DateTime, gotEvent.End.DateTime)
+	}
+}
+
+func TestGoogleCalendar_CreateEvent_Conflict(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok", "expires_in": 3600})
+	})
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"The requested identifier already exists."}}`, http.StatusConflict)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cal, err := NewGoogleCalendar(Config{
+		GoogleCredentialsFile: writeServiceAccountKey(t, server.URL+"/token"),
+		GoogleAPIURL:          server.URL,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("NewGoogleCalendar() error = %v", err)
+	}
+
+	event := testEvent()
+	id, err := cal.CreateEvent(context.Background(), event)
+	if err != nil || id != event.UID {
+		t.Errorf("CreateEvent() = %q, %v; want the UID for an existing event", id, err)
+	}
+}
+
+func TestParseServiceAccountKey_Invalid(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`{"type":"authorized_user","client_id":"x"}`,
+		`{"client_email":"a@b.c","private_key":"not pem"}`,
+	}
+	for _, data := range tests {
+		if _, err := parseServiceAccountKey([]byte(data)); err == nil {
+			t.Errorf("parseServiceAccountKey(%q) succeeded, want error", data)
+		}
+	}
+}
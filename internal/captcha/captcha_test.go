@@ -0,0 +1,74 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestVerifier(t *testing.T, handler http.HandlerFunc) *Verifier {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	v, err := New(ProviderTurnstile, "captcha-secret", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	v.url = srv.URL
+	return v
+}
+
+func TestNew(t *testing.T) {
+	if v, err := New(ProviderNone, "", nil); v != nil || err != nil {
+		t.Errorf("New(none) = %v, %v; want nil, nil", v, err)
+	}
+	if _, err := New("captchaville", "secret", nil); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	if _, err := New(ProviderHCaptcha, "", nil); err == nil {
+		t.Error("expected error without a secret")
+	}
+	if v, err := New(" ReCAPTCHA ", "secret", nil); err != nil || v.Provider() != ProviderReCAPTCHA {
+		t.Errorf("New(recaptcha) = %v, %v", v, err)
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	var secret, token, remoteIP string
+	v := newTestVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		secret, token, remoteIP = r.FormValue("secret"), r.FormValue("response"), r.FormValue("remoteip")
+		if token == "good" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	})
+
+	if err := v.Verify(context.Background(), "good", "203.0.113.9"); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if secret != "captcha-secret" || token != "good" || remoteIP != "203.0.113.9" {
+		t.Errorf("request had secret %q, response %q and remoteip %q", secret, token, remoteIP)
+	}
+
+	if err := v.Verify(context.Background(), "bad", ""); !errors.Is(err, ErrFailed) {
+		t.Errorf("Verify(bad) = %v, want ErrFailed", err)
+	}
+	if err := v.Verify(context.Background(), " ", ""); !errors.Is(err, ErrFailed) {
+		t.Errorf("Verify(empty) = %v, want ErrFailed", err)
+	}
+}
+
+func TestVerifier_VerifyProviderError(t *testing.T) {
+	v := newTestVerifier(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	err := v.Verify(context.Background(), "good", "")
+	if err == nil || errors.Is(err, ErrFailed) {
+		t.Errorf("Verify() = %v, want a provider error", err)
+	}
+}
@@ -82,10 +82,19 @@ type CircuitBreaker struct {
 	totalRejected      int64
 	lastError          error
 
+	// forced holds the circuit open until Reset, after an operator trips it
+	forced bool
+	// onStateChange is called, with the lock held, when the state changes
+	onStateChange StateChangeFunc
+
 	logger *zap.Logger
 	name   string
 }
 
+// StateChangeFunc is called when a circuit breaker changes state. It must
+// not call back into the breaker.
+type StateChangeFunc func(name string, from, to State)
+
 // New creates a new circuit breaker.
 func New(name string, config *Config, logger *zap.Logger) *CircuitBreaker {
 	if config == nil {
@@ -129,7 +138,7 @@ func (cb *CircuitBreaker) beforeRequest() error {
 
 	case StateOpen:
 		// Check if we should transition to half-open
-		if now.Sub(cb.lastFailure) >= cb.config.OpenTimeout {
+		if !cb.forced && now.Sub(cb.lastFailure) >= cb.config.OpenTimeout {
 			cb.setState(StateHalfOpen)
 			cb.halfOpenRequests = 1
 			cb.logger.Info("circuit breaker transitioning to half-open",
@@ -213,11 +222,21 @@ func (cb *CircuitBreaker) recordSuccess() {
 
 // setState changes the circuit breaker state.
 func (cb *CircuitBreaker) setState(newState State) {
+	oldState := cb.state
 	cb.state = newState
 	cb.lastStateChange = time.Now()
 	cb.consecutiveFailures = 0
 	cb.consecutiveSuccesses = 0
 	cb.halfOpenRequests = 0
+
+	if cb.onStateChange != nil && oldState != newState {
+		cb.onStateChange(cb.name, oldState, newState)
+	}
+}
+
+// Name returns the name the breaker was created with.
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
 }
 
 // State returns the current state.
@@ -256,6 +275,7 @@ func (cb *CircuitBreaker) Stats() Stats {
 		LastFailure:          cb.lastFailure,
 		LastStateChange:      cb.lastStateChange,
 		LastError:            lastError,
+		Forced:               cb.forced,
 	}
 }
 
@@ -272,6 +292,7 @@ type Stats struct {
 	LastFailure          time.Time `json:"last_failure,omitempty"`
 	LastStateChange      time.Time `json:"last_state_change"`
 	LastError            string    `json:"last_error,omitempty"`
+	Forced               bool      `json:"forced,omitempty"` // Tripped by an operator; stays open until reset
 }
 
 // Reset forces the circuit breaker to the closed state.
@@ -281,6 +302,7 @@ func (cb *CircuitBreaker) Reset() {
 	defer cb.mu.Unlock()
 
 	oldState := cb.state
+	cb.forced = false
 	cb.setState(StateClosed)
 	cb.totalRejected = 0
 	cb.lastError = nil
@@ -291,6 +313,22 @@ func (cb *CircuitBreaker) Reset() {
 	)
 }
 
+// Trip forces the circuit open, so requests fail fast, until Reset. Use it
+// to take a dependency out of service, such as during a provider outage.
+func (cb *CircuitBreaker) Trip() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	oldState := cb.state
+	cb.forced = true
+	cb.setState(StateOpen)
+
+	cb.logger.Warn("circuit breaker tripped",
+		zap.String("name", cb.name),
+		zap.String("from_state", oldState.String()),
+	)
+}
+
 // ShouldRetry determines if an error should trigger circuit breaker failure tracking.
 // Some errors (like context cancellation) shouldn't count against the circuit.
 func ShouldRetry(err error) bool {
@@ -284,6 +284,30 @@ func TestCircuitBreaker_Reset(t *testing.T) {
 	}
 }
 
+func TestCircuitBreaker_TripHoldsOpenUntilReset(t *testing.T) {
+	cb := newTestBreaker(nil)
+	ctx := context.Background()
+
+	cb.Trip()
+	if !cb.IsOpen() || !cb.Stats().Forced {
+		t.Fatalf("expected a forced open circuit, got %+v", cb.Stats())
+	}
+
+	// A tripped circuit doesn't test recovery after the open timeout
+	time.Sleep(150 * time.Millisecond)
+	if err := cb.Execute(ctx, func(ctx context.Context) error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	cb.Reset()
+	if cb.IsOpen() || cb.Stats().Forced {
+		t.Fatalf("expected a closed circuit after reset, got %+v", cb.Stats())
+	}
+	if err := cb.Execute(ctx, func(ctx context.Context) error { return nil }); err != nil {
+		t.Errorf("expected the request through, got %v", err)
+	}
+}
+
 func TestCircuitBreaker_Stats(t *testing.T) {
 	cb := newTestBreaker(nil)
 	ctx := context.Background()
@@ -0,0 +1,78 @@
+package circuitbreaker
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry holds the circuit breakers guarding outbound dependencies, so
+// their state can be reported together and operators can trip or reset
+// them by name.
+type Registry struct {
+	mu            sync.RWMutex
+	breakers      map[string]*CircuitBreaker
+	onStateChange StateChangeFunc
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*CircuitBreaker)}
+}
+
+// OnStateChange sets a function called whenever a registered breaker
+// changes state, and once with its current state when it is registered.
+// Set it before registering breakers.
+func (r *Registry) OnStateChange(fn StateChangeFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onStateChange = fn
+}
+
+// Register adds breakers, replacing any registered under the same name,
+// such as when a client is rebuilt on a configuration reload.
+func (r *Registry) Register(breakers ...*CircuitBreaker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cb := range breakers {
+		r.breakers[cb.name] = cb
+
+		cb.mu.Lock()
+		cb.onStateChange = r.onStateChange
+		if cb.onStateChange != nil {
+			cb.onStateChange(cb.name, cb.state, cb.state)
+		}
+		cb.mu.Unlock()
+	}
+}
+
+// Unregister removes the breaker with the given name, if there is one.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.breakers, name)
+}
+
+// Get returns the breaker with the given name.
+func (r *Registry) Get(name string) (*CircuitBreaker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cb, ok := r.breakers[name]
+	return cb, ok
+}
+
+// Stats returns the statistics of every registered breaker, ordered by name.
+func (r *Registry) Stats() []Stats {
+	r.mu.RLock()
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, cb := range r.breakers {
+		breakers = append(breakers, cb)
+	}
+	r.mu.RUnlock()
+
+	stats := make([]Stats, 0, len(breakers))
+	for _, cb := range breakers {
+		stats = append(stats, cb.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
@@ -0,0 +1,59 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type stateChange struct {
+	name     string
+	from, to State
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	var changes []stateChange
+	registry.OnStateChange(func(name string, from, to State) {
+		changes = append(changes, stateChange{name, from, to})
+	})
+
+	claude := New("claude-api", &Config{FailureThreshold: 1, SuccessThreshold: 1, OpenTimeout: time.Hour, HalfOpenMaxRequests: 1}, zap.NewNop())
+	smtp := New("email", nil, zap.NewNop())
+	registry.Register(smtp, claude)
+
+	stats := registry.Stats()
+	if len(stats) != 2 || stats[0].Name != "claude-api" || stats[1].Name != "email" {
+		t.Fatalf("expected both breakers ordered by name, got %+v", stats)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected the current states reported on registration, got %+v", changes)
+	}
+
+	_ = claude.Execute(context.Background(), func(ctx context.Context) error { return errors.New("overloaded") })
+	if last := changes[len(changes)-1]; last != (stateChange{"claude-api", StateClosed, StateOpen}) {
+		t.Errorf("expected the opening reported, got %+v", last)
+	}
+
+	if cb, ok := registry.Get("email"); !ok || cb != smtp {
+		t.Error("Get() did not return the registered breaker")
+	}
+
+	// Registering under a taken name replaces the breaker
+	replacement := New("email", nil, zap.NewNop())
+	registry.Register(replacement)
+	if cb, _ := registry.Get("email"); cb != replacement {
+		t.Error("expected the replacement breaker")
+	}
+
+	registry.Unregister("email")
+	if _, ok := registry.Get("email"); ok {
+		t.Error("expected the breaker removed")
+	}
+	if len(registry.Stats()) != 1 {
+		t.Errorf("expected one breaker left, got %d", len(registry.Stats()))
+	}
+}
@@ -5,10 +5,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/jkindrix/quickquote/internal/httpclient"
+	"github.com/jkindrix/quickquote/internal/secrets"
 )
 
 // Config holds all application configuration.
@@ -22,6 +26,46 @@ type Config struct {
 	Log           LogConfig
 	RateLimit     RateLimitConfig
 	CallSettings  CallSettingsConfig
+	QuotePDF      QuotePDFConfig
+	ExchangeRates ExchangeRatesConfig
+	QuoteApproval QuoteApprovalConfig
+	QuotePortal   QuotePortalConfig
+	QuoteForm     QuoteFormConfig
+	Payments      PaymentsConfig
+	Accounting    AccountingConfig
+	CRM           CRMConfig
+	ChatOps       ChatOpsConfig
+	Notifications NotificationsConfig
+	Messaging     MessagingConfig
+	WhatsApp      WhatsAppConfig
+	Email         EmailConfig
+	Tracing       TracingConfig
+	Calendar      CalendarConfig
+	Recordings    RecordingsConfig
+	Transcription TranscriptionConfig
+	Budget        BudgetConfig
+	FollowUp      FollowUpConfig
+	Compliance    ComplianceConfig
+	NumberHealth  NumberHealthConfig
+	Overflow      OverflowConfig
+	Analytics     AnalyticsConfig
+	Tagging       TaggingConfig
+	Spam          SpamConfig
+	Privacy       PrivacyConfig
+	Cache         CacheConfig
+	APIDocs       APIDocsConfig
+	GraphQL       GraphQLConfig
+	GRPC          GRPCConfig
+	Archival      ArchivalConfig
+	Retention     RetentionConfig
+	Secrets       SecretsConfig
+	Ingress       IngressConfig
+	HTTPClient    HTTPClientConfig
+	Jobs          JobsConfig
+	Backup        BackupConfig
+	Tags          TagsConfig
+	SavedViews    SavedViewsConfig
+	Bulk          BulkConfig
 
 	// Backward compatibility - deprecated, use VoiceProvider.Bland instead
 	Bland BlandConfig
@@ -29,9 +73,13 @@ type Config struct {
 
 // ServerConfig holds HTTP server settings.
 type ServerConfig struct {
-	Host        string
-	Port        int
-	Environment string
+	Host         string
+	Port         int
+	Environment  string
+	TLSCertFile  string // PEM certificate; serves HTTPS when set
+	TLSKeyFile   string // PEM private key for TLSCertFile
+	ClientCAFile string // PEM CA bundle client certificates are verified against
+	ClientAuth   string // none, optional or require
 }
 
 // DatabaseConfig holds PostgreSQL connection settings.
@@ -48,6 +96,7 @@ type DatabaseConfig struct {
 	SlowQueryThreshold     time.Duration
 	VerySlowQueryThreshold time.Duration
 	LogAllQueries          bool
+	ReplicaDSN             string // Connection string of a read replica for heavy reads; empty reads from the primary
 }
 
 // ConnectionString returns a PostgreSQL connection string.
@@ -60,9 +109,25 @@ func (d *DatabaseConfig) ConnectionString() string {
 
 // VoiceProviderConfig holds configuration for voice AI providers.
 type VoiceProviderConfig struct {
-	// Primary provider to use (bland, vapi, retell)
+	// Primary provider to use (bland, vapi, retell, twilio)
 	Primary string
 
+	// Failover lists the providers outbound calls fail over to, in order
+	// (comma-separated)
+	Failover string
+
+	// FailoverNumbers maps caller IDs to the numbers calls are placed from
+	// after failing over (comma-separated from=to pairs)
+	FailoverNumbers string
+
+	// WebhookDedupTTL is how long processed webhooks are remembered, so
+	// redeliveries within it are skipped
+	WebhookDedupTTL time.Duration
+
+	// WebhookArchiveRetention is how long raw webhook bodies are kept; 0
+	// disables the archive
+	WebhookArchiveRetention time.Duration
+
 	// Bland AI configuration
 	Bland BlandProviderConfig
 
@@ -71,15 +136,29 @@ type VoiceProviderConfig struct {
 
 	// Retell configuration
 	Retell RetellProviderConfig
+
+	// Twilio configuration
+	Twilio TwilioProviderConfig
+
+	// Simulated provider for development and demos
+	Fake FakeProviderConfig
 }
 
 // BlandProviderConfig holds Bland AI API settings.
 type BlandProviderConfig struct {
-	Enabled       bool
-	APIKey        string
-	InboundNumber string
-	WebhookSecret string
-	APIURL        string
+	Enabled        bool
+	APIKey         string
+	InboundNumber  string
+	WebhookSecret  string
+	APIURL         string
+	SyncInterval   time.Duration // How often voices, personas, etc. are copied from Bland; 0 disables the worker
+	ReplayInterval time.Duration // How often writes queued while Bland was unreachable are retried
+
+	// SignatureMode is what happens to webhooks that fail signature
+	// verification: "reject" or "log"
+	SignatureMode string
+	// SignatureTolerance is how far a webhook's signed timestamp may be from now
+	SignatureTolerance time.Duration
 }
 
 // VapiProviderConfig holds Vapi API settings.
@@ -88,6 +167,8 @@ type VapiProviderConfig struct {
 	APIKey        string
 	WebhookSecret string
 	APIURL        string
+	AssistantID   string // Assistant that handles outbound calls
+	PhoneNumberID string // Vapi phone number outbound calls are placed from
 }
 
 // RetellProviderConfig holds Retell AI API settings.
@@ -96,6 +177,30 @@ type RetellProviderConfig struct {
 	APIKey        string
 	WebhookSecret string
 	APIURL        string
+	FromNumber    string // Retell number outbound calls are placed from
+	AgentID       string // Overrides the agent bound to FromNumber
+}
+
+// TwilioProviderConfig holds Twilio Voice API settings.
+type TwilioProviderConfig struct {
+	Enabled        bool
+	AccountSID     string
+	AuthToken      string // Also used to verify X-Twilio-Signature
+	APIURL         string
+	WebhookBaseURL string // Public base URL Twilio signs requests against
+}
+
+// FakeProviderConfig holds simulated provider settings. The simulated
+// provider never dials: it posts the webhooks a real provider would, so it
+// must not be enabled in production.
+type FakeProviderConfig struct {
+	Enabled         bool
+	WebhookURL      string // Base URL webhooks are posted to; defaults to this server on localhost
+	WebhookSecret   string
+	FromNumber      string
+	RingDelay       time.Duration // Time from placing a call to it being answered
+	CallDuration    time.Duration // Time from answering a call to it ending
+	InboundInterval time.Duration // How often a simulated customer calls in; 0 disables inbound calls
 }
 
 // BlandConfig holds Bland AI API settings (deprecated - for backward compatibility).
@@ -110,12 +215,28 @@ type BlandConfig struct {
 type AnthropicConfig struct {
 	APIKey string
 	Model  string
+
+	// Token prices in US dollars per million, used to estimate cost in metrics.
+	InputCostPerMTok  float64
+	OutputCostPerMTok float64
 }
 
 // AuthConfig holds authentication settings.
 type AuthConfig struct {
-	SessionSecret   string
-	SessionDuration time.Duration
+	SessionSecret    string
+	SessionDuration  time.Duration
+	RequireTwoFactor bool   // Admin pages need two-factor authentication to be enabled
+	TOTPIssuer       string // Account name shown in authenticator apps
+
+	// Single sign-on with an OpenID Connect identity provider
+	OIDCIssuerURL      string // e.g. https://accounts.google.com; empty disables single sign-on
+	OIDCClientID       string
+	OIDCClientSecret   string
+	OIDCProviderName   string // Shown on the sign-in button, e.g. "Google"
+	OIDCScopes         string // Comma-separated scopes requested besides openid, email and profile
+	OIDCAllowedDomains string // Comma-separated email domains that may sign in; empty allows any
+	OIDCAutoProvision  bool   // Create users on their first sign-in; requires OIDCAllowedDomains
+	OIDCDefaultRole    string // Role of users created on first sign-in: "member" or "admin"
 }
 
 // AppConfig holds general application settings.
@@ -123,6 +244,396 @@ type AppConfig struct {
 	PublicURL string
 }
 
+// QuotePDFConfig holds quote document settings.
+type QuotePDFConfig struct {
+	ValidityDays      int
+	Currency          string
+	AttachToFollowUps bool
+}
+
+// ExchangeRatesConfig holds the exchange rate source used to total quotes
+// priced in different currencies for reporting.
+type ExchangeRatesConfig struct {
+	URL             string        // Exchange rate API base URL; empty leaves quotes in other currencies unconverted
+	RefreshInterval time.Duration // How long fetched rates are used before fetching again
+}
+
+// QuoteApprovalConfig holds quote review workflow settings.
+type QuoteApprovalConfig struct {
+	Threshold float64 // Quotes above this total need approval from a designated approver
+	Approvers string  // Comma-separated approver emails; empty allows any user but the submitter
+}
+
+// QuotePortalConfig holds customer quote link settings.
+type QuotePortalConfig struct {
+	LinkTTL         time.Duration // How long a customer link works after it is created
+	ConfirmationSMS bool          // Text customers to confirm they accepted
+	RateLimit       int           // Requests per minute per IP to customer links
+}
+
+// QuoteFormConfig holds settings for the public quote request form that
+// contractors embed on their websites.
+type QuoteFormConfig struct {
+	Enabled          bool
+	AllowedOrigins   string // Comma-separated origins allowed to post the form, e.g. https://example.com; empty allows any
+	RateLimit        int    // Submissions per minute per IP
+	CaptchaProvider  string // "turnstile", "hcaptcha", "recaptcha", or empty to accept submissions unchecked
+	CaptchaSiteKey   string // Public key the widget renders the challenge with
+	CaptchaSecret    string
+	Callbacks        bool   // Let submitters ask for an AI callback call
+	CallbackPromptID string // Prompt for callback calls; empty uses the default prompt
+}
+
+// PaymentsConfig holds settings for collecting payment on accepted quotes.
+type PaymentsConfig struct {
+	Provider            string  // "stripe", or empty to disable
+	DepositPercent      float64 // Share of the quote total requested, above 0 and at most 100
+	StripeSecretKey     string
+	StripeWebhookSecret string // Signing secret of the /webhook/stripe endpoint
+}
+
+// AccountingConfig holds settings for pushing accepted quotes to an
+// accounting system.
+type AccountingConfig struct {
+	Provider     string // "quickbooks", "xero", or empty to disable
+	ClientID     string
+	ClientSecret string
+	Sandbox      bool   // Use the QuickBooks sandbox company API
+	Document     string // "invoice" or "estimate"
+	AutoSync     bool   // Push quotes as soon as they are accepted
+}
+
+// CRMConfig holds settings for syncing calls and quotes to a CRM.
+type CRMConfig struct {
+	Provider               string // "hubspot", "salesforce", or empty to disable
+	HubSpotToken           string // Private app access token
+	SalesforceURL          string // My Domain URL, e.g. https://acme.my.salesforce.com
+	SalesforceClientID     string
+	SalesforceClientSecret string
+}
+
+// ChatOpsConfig holds Slack and Teams notification settings. Each event is
+// posted to its incoming webhook URL; events without one are not posted.
+type ChatOpsConfig struct {
+	CallCompletedURL      string
+	QuoteReadyURL         string
+	QuoteReadyMinTotal    float64 // Only quotes with at least this total are posted
+	UsageAlertURL         string
+	JobFailedURL          string
+	CallCompletedTemplate string // Go text/template overriding the default message
+	QuoteReadyTemplate    string
+	UsageAlertTemplate    string
+	JobFailedTemplate     string
+}
+
+// NotificationsConfig holds per-user notification settings. Users choose
+// their own events and channels on the notification settings page.
+type NotificationsConfig struct {
+	SMSEnabled bool   // Offer SMS notifications; also needs a Bland API key
+	SMSFrom    string // Number SMS notifications are sent from; empty uses Bland's default
+}
+
+// MessagingConfig holds settings for messaging customers about their quotes.
+type MessagingConfig struct {
+	QuoteReady bool   // Message customers when their quote is marked sent, on their preferred channel
+	SMSFrom    string // Number customer texts are sent from; empty uses Bland's default
+}
+
+// WhatsAppConfig holds WhatsApp Business Cloud API settings. WhatsApp is
+// offered to customers when an access token is set.
+type WhatsAppConfig struct {
+	AccessToken      string
+	PhoneNumberID    string // Business phone number messages are sent from
+	AppSecret        string // Verifies /webhook/whatsapp deliveries
+	VerifyToken      string // Chosen when subscribing the webhook in the Meta app dashboard
+	QuoteTemplate    string // Approved template for quote-ready messages; empty sends text
+	TemplateLanguage string
+}
+
+// Enabled reports whether WhatsApp is configured.
+func (c *WhatsAppConfig) Enabled() bool {
+	return c.AccessToken != ""
+}
+
+// EmailConfig holds outbound email settings.
+type EmailConfig struct {
+	Provider        string // "smtp", "sendgrid", or empty to disable
+	From            string
+	FromName        string
+	StaffRecipients string // Comma-separated addresses for staff notifications
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SendGridAPIKey  string
+}
+
+// TracingConfig holds distributed tracing settings.
+type TracingConfig struct {
+	Endpoint    string  // OTLP/HTTP collector URL, e.g. http://localhost:4318; empty disables tracing
+	Headers     string  // Comma-separated key=value headers sent with each export, e.g. for auth
+	ServiceName string  // service.name reported on every span
+	SampleRatio float64 // Fraction of new traces recorded, 0 to 1; propagated traces keep the caller's decision
+}
+
+// CalendarConfig holds callback calendar settings.
+type CalendarConfig struct {
+	Provider              string        // "google", "caldav", or empty to only list callbacks on the dashboard
+	Timezone              string        // Business timezone callers' requested times are read in
+	EventDuration         time.Duration // Length of callback events
+	GoogleCalendarID      string
+	GoogleCredentialsFile string // Service account JSON key; share the calendar with its client_email
+	CalDAVURL             string // Calendar collection URL
+	CalDAVUsername        string
+	CalDAVPassword        string
+}
+
+// RecordingsConfig holds call recording storage and retention settings.
+type RecordingsConfig struct {
+	Storage           string // "local", "s3", or empty to leave recordings with the provider
+	LocalPath         string // Directory for local storage
+	S3Endpoint        string // Defaults to AWS S3 in S3Region; set for MinIO, R2, etc.
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool // Put the bucket in the URL path; most S3-compatible services need this
+	RetentionDays     int  // Days stored recordings are kept; 0 keeps them forever
+	MaxSizeMB         int  // Largest recording downloaded
+}
+
+// TranscriptionConfig holds settings for transcribing recordings of calls
+// whose provider sent no transcript.
+type TranscriptionConfig struct {
+	Provider      string // "openai", "whisper_cpp", or empty to disable
+	Language      string // ISO-639-1 code; empty lets the model detect it
+	OpenAIAPIKey  string
+	OpenAIModel   string
+	OpenAIAPIURL  string
+	WhisperCppURL string // Base URL of a whisper.cpp server
+	MaxSizeMB     int    // Largest recording transcribed
+}
+
+// BudgetConfig holds monthly spend enforcement settings.
+type BudgetConfig struct {
+	MonthlyHardCap  float64       // Month-to-date spend at which new calls and batches are refused; 0 disables enforcement
+	CostPerMinute   float64       // Estimated cost of a call minute not yet in Bland's usage
+	RefreshInterval time.Duration // How often month-to-date spend is recomputed
+}
+
+// FollowUpConfig holds quote follow-up sequence settings.
+type FollowUpConfig struct {
+	Sequence        string        // Steps after a quote is sent, e.g. "sms:1h,call:24h,sms:72h"; empty disables follow-ups
+	QuietHoursStart string        // HH:MM when customers stop being contacted, e.g. "21:00"
+	QuietHoursEnd   string        // HH:MM when contact resumes, e.g. "09:00"
+	Timezone        string        // Timezone quiet hours are in; defaults to the calendar timezone
+	PollInterval    time.Duration // How often due steps are checked
+}
+
+// ComplianceConfig holds do-not-call and calling window settings.
+type ComplianceConfig struct {
+	CallingWindowStart string // HH:MM local to the called number when calls may start, e.g. "08:00"; empty with CallingWindowEnd disables the window
+	CallingWindowEnd   string // HH:MM local to the called number when calls must stop, e.g. "21:00"
+	DefaultTimezone    string // Timezone for numbers outside North America; defaults to the calendar timezone
+	NationalDNCFile    string // Path to a national do-not-call download imported at startup, replacing the previous import
+}
+
+// NumberHealthConfig holds caller ID health monitoring settings.
+type NumberHealthConfig struct {
+	Enabled          bool
+	Interval         time.Duration // How often every owned number is checked
+	Lookback         time.Duration // How far back outbound calls are counted
+	MinCalls         int           // Fewest calls in the lookback window to score a number's answer rate by
+	ReputationURL    string        // Reputation API checked as well as answer rates; empty uses answer rates alone
+	ReputationAPIKey string
+}
+
+// OverflowConfig holds inbound call spike settings.
+type OverflowConfig struct {
+	Threshold      int    // Most inbound calls handled normally at once; 0 disables overflow handling
+	Policy         string // What happens to calls over the threshold: "prompt", "sms" or "transfer"
+	PromptID       string // Prompt busy numbers answer with under the prompt policy
+	TransferNumber string // Where calls are transferred under the transfer policy
+	SMSMessage     string // Text offering a callback under the sms policy; empty uses a default
+}
+
+// AnalyticsConfig holds analytics rollup settings.
+type AnalyticsConfig struct {
+	RollupEnabled      bool
+	RollupHour         int // UTC hour the nightly rollup by prompt and number runs at
+	RollupLookbackDays int // Days before today rolled up each night and at startup
+}
+
+// TaggingConfig holds call sentiment and intent tagging settings.
+type TaggingConfig struct {
+	Enabled bool
+	Workers int // Calls classified at once
+}
+
+// SpamConfig holds inbound spam scoring settings.
+type SpamConfig struct {
+	Enabled         bool
+	Prefixes        string // Comma-separated known spam prefixes, e.g. +1900,+1976
+	MaxCallsPerHour int    // Calls from one number in an hour before it scores as a robocaller
+	LookupURL       string // Reputation API checked as well as the heuristics; empty uses the heuristics alone
+	LookupAPIKey    string
+	AutoBlockAfter  int // Flagged calls before a caller is blocked automatically; 0 leaves blocking to reviewers
+}
+
+// PrivacyConfig holds data subject request settings.
+type PrivacyConfig struct {
+	ReportSigningKey string // HMAC key for signing deletion reports; defaults to the session secret
+}
+
+// CacheConfig holds in-process cache settings.
+type CacheConfig struct {
+	TTL time.Duration // How long cached settings and prompts are served before reloading; 0 disables caching
+}
+
+// APIDocsConfig holds settings for the API's OpenAPI document and
+// Swagger UI.
+type APIDocsConfig struct {
+	Enabled      bool   // Serve /api/v1/openapi.json and /api/v1/docs
+	SwaggerUIURL string // Where the Swagger UI assets (swagger-ui.css, swagger-ui-bundle.js) are loaded from
+}
+
+// GraphQLConfig holds settings for the read-only GraphQL endpoint.
+type GraphQLConfig struct {
+	Enabled             bool   // Serve /api/graphql
+	PersistedQueriesDir string // Directory of .graphql files clients may send by hash
+	AllowlistOnly       bool   // Refuse queries that are not persisted
+	MaxBatchSize        int    // Most queries in one batched request
+	MaxDepth            int    // Deepest nesting of fields a query may select
+}
+
+// GRPCConfig holds settings for the gRPC server internal integrations use.
+type GRPCConfig struct {
+	Enabled     bool   // Serve gRPC alongside HTTP
+	Host        string
+	Port        int
+	TLSCertFile string // PEM certificate; required unless Insecure
+	TLSKeyFile  string // PEM private key for TLSCertFile
+	Insecure    bool   // Serve plaintext; refused in production
+}
+
+// ArchivalConfig holds the policy that archives old calls and their quotes.
+type ArchivalConfig struct {
+	Enabled    bool          // Archive calls on a schedule
+	CallsAfter time.Duration // Age at which a finished call is archived
+	Interval   time.Duration // How often the policy runs
+	BatchSize  int           // Most calls archived per run
+}
+
+// RetentionConfig holds the per-entity retention rules. A zero age keeps
+// that kind of record forever.
+type RetentionConfig struct {
+	Enabled     bool          // Enforce the rules on a schedule
+	DryRun      bool          // Only report what the rules would remove
+	Transcripts time.Duration // Age, from call creation, at which transcripts are removed
+	Recordings  time.Duration // Age, from storage, at which recordings are deleted
+	AuditEvents time.Duration // Age at which audit events are deleted
+	Interval    time.Duration // How often the rules run
+	BatchSize   int           // Most records removed per statement
+}
+
+// SecretsConfig holds the master keys provider API keys stored in the
+// database are encrypted with, and how often each instance reloads them.
+type SecretsConfig struct {
+	MasterKey          string        // Base64-encoded 32-byte key new keys are encrypted with; empty disables stored keys
+	PreviousMasterKeys string        // Comma-separated keys still accepted for decrypting, while rotating the master key
+	RefreshInterval    time.Duration // How often keys set through other instances are picked up
+}
+
+// IngressConfig holds who may reach which routes. IP lists are
+// comma-separated CIDR ranges and addresses; an empty list allows everyone.
+type IngressConfig struct {
+	TrustedProxies           string // Proxies whose forwarding headers are believed: "*", "none" or an IP list
+	WebhookAllowedIPs        string // Clients allowed to call the webhooks
+	MetricsAllowedIPs        string // Clients allowed to scrape /metrics
+	APIAllowedIPs            string // Clients allowed to call the REST API
+	WebhookRequireClientCert bool   // Webhooks need a verified client certificate
+	MetricsRequireClientCert bool   // /metrics needs a verified client certificate
+	APIRequireClientCert     bool   // The REST API needs a verified client certificate
+}
+
+// JobsConfig holds settings for the periodic background jobs.
+type JobsConfig struct {
+	// How often instances sharing the database try to become leader, and
+	// the leader checks it still is. Singleton jobs run only on the leader.
+	LeaderCheckInterval time.Duration
+}
+
+// BackupConfig holds settings for logical database backups and where they
+// are kept.
+type BackupConfig struct {
+	Enabled           bool          // Take backups on a schedule
+	Interval          time.Duration // How often scheduled backups are taken
+	RetentionDays     int           // Days backups are kept; 0 keeps them forever
+	Timeout           time.Duration // Longest a backup may take
+	PgDumpPath        string        // pg_dump executable; empty finds it on PATH
+	Storage           string        // "local", "s3", or empty to disable backups
+	LocalPath         string        // Directory for local storage
+	S3Endpoint        string        // Defaults to AWS S3 in S3Region; set for MinIO, R2, etc.
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool // Put the bucket in the URL path; most S3-compatible services need this
+}
+
+// TagsConfig holds settings for tagging calls, quotes and customers.
+type TagsConfig struct {
+	// Let anyone apply a tag that doesn't exist yet, creating it. When off,
+	// only the managed tags admins create can be applied.
+	FreeForm     bool
+	MaxPerRecord int // Most tags one record can carry; 0 is unlimited
+}
+
+// SavedViewsConfig holds settings for users' saved calls list views.
+type SavedViewsConfig struct {
+	MaxPerUser int // Most views one user can save; 0 is unlimited
+}
+
+// BulkConfig holds settings for bulk actions on calls and quotes.
+type BulkConfig struct {
+	MaxItems int // Most records one bulk action can name; 0 is unlimited
+}
+
+// HTTPClientConfig holds the HTTP client policy for each provider API.
+type HTTPClientConfig struct {
+	Bland     HTTPClientPolicy
+	Vapi      HTTPClientPolicy
+	Retell    HTTPClientPolicy
+	Anthropic HTTPClientPolicy
+}
+
+// HTTPClientPolicy holds the timeouts, retries, proxy and connection pool
+// settings for calls to one provider's API.
+type HTTPClientPolicy struct {
+	Timeout             time.Duration // Whole request, including reading the response
+	DialTimeout         time.Duration // Connecting and the TLS handshake
+	MaxRetries          int           // Retries per request; zero disables retries
+	RetryBaseDelay      time.Duration // Upper bound of the first retry's jittered delay; doubles per retry
+	RetryMaxDelay       time.Duration // Longest wait before a retry, including Retry-After
+	RetryBudget         float64       // Retries allowed per request sent, beyond a small reserve
+	ProxyURL            string        // http, https or socks5 proxy; empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int // Zero is unlimited
+	IdleConnTimeout     time.Duration
+}
+
+// GetPreviousMasterKeys returns the previous master keys as a slice.
+func (c *SecretsConfig) GetPreviousMasterKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(c.PreviousMasterKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // LogConfig holds logging settings.
 type LogConfig struct {
 	Level  string
@@ -203,9 +714,13 @@ func Load() (*Config, error) {
 	// Build config struct
 	cfg := &Config{
 		Server: ServerConfig{
-			Host:        v.GetString("server.host"),
-			Port:        v.GetInt("server.port"),
-			Environment: v.GetString("server.env"),
+			Host:         v.GetString("server.host"),
+			Port:         v.GetInt("server.port"),
+			Environment:  v.GetString("server.env"),
+			TLSCertFile:  v.GetString("server.tls_cert_file"),
+			TLSKeyFile:   v.GetString("server.tls_key_file"),
+			ClientCAFile: v.GetString("server.client_ca_file"),
+			ClientAuth:   v.GetString("server.client_auth"),
 		},
 		Database: DatabaseConfig{
 			Host:                   v.GetString("database.host"),
@@ -220,27 +735,57 @@ func Load() (*Config, error) {
 			SlowQueryThreshold:     v.GetDuration("database.slow_query_threshold"),
 			VerySlowQueryThreshold: v.GetDuration("database.very_slow_query_threshold"),
 			LogAllQueries:          v.GetBool("database.log_all_queries"),
+			ReplicaDSN:             v.GetString("database.replica_dsn"),
 		},
 		VoiceProvider: VoiceProviderConfig{
-			Primary: v.GetString("voice_provider.primary"),
+			Primary:                 v.GetString("voice_provider.primary"),
+			Failover:                v.GetString("voice_provider.failover"),
+			FailoverNumbers:         v.GetString("voice_provider.failover_numbers"),
+			WebhookDedupTTL:         v.GetDuration("voice_provider.webhook_dedup_ttl"),
+			WebhookArchiveRetention: v.GetDuration("voice_provider.webhook_archive_retention"),
 			Bland: BlandProviderConfig{
-				Enabled:       v.GetBool("voice_provider.bland.enabled"),
-				APIKey:        v.GetString("voice_provider.bland.api_key"),
-				InboundNumber: v.GetString("voice_provider.bland.inbound_number"),
-				WebhookSecret: v.GetString("voice_provider.bland.webhook_secret"),
-				APIURL:        v.GetString("voice_provider.bland.api_url"),
+				Enabled:        v.GetBool("voice_provider.bland.enabled"),
+				APIKey:         v.GetString("voice_provider.bland.api_key"),
+				InboundNumber:  v.GetString("voice_provider.bland.inbound_number"),
+				WebhookSecret:  v.GetString("voice_provider.bland.webhook_secret"),
+				APIURL:         v.GetString("voice_provider.bland.api_url"),
+				SyncInterval:   v.GetDuration("voice_provider.bland.sync_interval"),
+				ReplayInterval: v.GetDuration("voice_provider.bland.replay_interval"),
+
+				SignatureMode:      v.GetString("voice_provider.bland.signature_mode"),
+				SignatureTolerance: v.GetDuration("voice_provider.bland.signature_tolerance"),
 			},
 			Vapi: VapiProviderConfig{
 				Enabled:       v.GetBool("voice_provider.vapi.enabled"),
 				APIKey:        v.GetString("voice_provider.vapi.api_key"),
 				WebhookSecret: v.GetString("voice_provider.vapi.webhook_secret"),
 				APIURL:        v.GetString("voice_provider.vapi.api_url"),
+				AssistantID:   v.GetString("voice_provider.vapi.assistant_id"),
+				PhoneNumberID: v.GetString("voice_provider.vapi.phone_number_id"),
 			},
 			Retell: RetellProviderConfig{
 				Enabled:       v.GetBool("voice_provider.retell.enabled"),
 				APIKey:        v.GetString("voice_provider.retell.api_key"),
 				WebhookSecret: v.GetString("voice_provider.retell.webhook_secret"),
 				APIURL:        v.GetString("voice_provider.retell.api_url"),
+				FromNumber:    v.GetString("voice_provider.retell.from_number"),
+				AgentID:       v.GetString("voice_provider.retell.agent_id"),
+			},
+			Twilio: TwilioProviderConfig{
+				Enabled:        v.GetBool("voice_provider.twilio.enabled"),
+				AccountSID:     v.GetString("voice_provider.twilio.account_sid"),
+				AuthToken:      v.GetString("voice_provider.twilio.auth_token"),
+				APIURL:         v.GetString("voice_provider.twilio.api_url"),
+				WebhookBaseURL: v.GetString("voice_provider.twilio.webhook_base_url"),
+			},
+			Fake: FakeProviderConfig{
+				Enabled:         v.GetBool("voice_provider.fake.enabled"),
+				WebhookURL:      v.GetString("voice_provider.fake.webhook_url"),
+				WebhookSecret:   v.GetString("voice_provider.fake.webhook_secret"),
+				FromNumber:      v.GetString("voice_provider.fake.from_number"),
+				RingDelay:       v.GetDuration("voice_provider.fake.ring_delay"),
+				CallDuration:    v.GetDuration("voice_provider.fake.call_duration"),
+				InboundInterval: v.GetDuration("voice_provider.fake.inbound_interval"),
 			},
 		},
 		// Backward compatibility - copy from legacy or new config
@@ -251,12 +796,25 @@ func Load() (*Config, error) {
 			APIURL:        v.GetString("bland.api_url"),
 		},
 		Anthropic: AnthropicConfig{
-			APIKey: v.GetString("anthropic.api_key"),
-			Model:  v.GetString("anthropic.model"),
+			APIKey:            v.GetString("anthropic.api_key"),
+			Model:             v.GetString("anthropic.model"),
+			InputCostPerMTok:  v.GetFloat64("anthropic.input_cost_per_mtok"),
+			OutputCostPerMTok: v.GetFloat64("anthropic.output_cost_per_mtok"),
 		},
 		Auth: AuthConfig{
-			SessionSecret:   v.GetString("session.secret"),
-			SessionDuration: v.GetDuration("session.duration"),
+			SessionSecret:    v.GetString("session.secret"),
+			SessionDuration:  v.GetDuration("session.duration"),
+			RequireTwoFactor: v.GetBool("auth.require_two_factor"),
+			TOTPIssuer:       v.GetString("auth.totp_issuer"),
+
+			OIDCIssuerURL:      v.GetString("auth.oidc_issuer_url"),
+			OIDCClientID:       v.GetString("auth.oidc_client_id"),
+			OIDCClientSecret:   v.GetString("auth.oidc_client_secret"),
+			OIDCProviderName:   v.GetString("auth.oidc_provider_name"),
+			OIDCScopes:         v.GetString("auth.oidc_scopes"),
+			OIDCAllowedDomains: v.GetString("auth.oidc_allowed_domains"),
+			OIDCAutoProvision:  v.GetBool("auth.oidc_auto_provision"),
+			OIDCDefaultRole:    v.GetString("auth.oidc_default_role"),
 		},
 		App: AppConfig{
 			PublicURL: v.GetString("app.public_url"),
@@ -289,6 +847,268 @@ func Load() (*Config, error) {
 			CustomGreeting:        v.GetString("call.custom_greeting"),
 			ProjectTypes:          v.GetString("call.project_types"),
 		},
+		QuotePDF: QuotePDFConfig{
+			ValidityDays:      v.GetInt("quote_pdf.validity_days"),
+			Currency:          v.GetString("quote_pdf.currency"),
+			AttachToFollowUps: v.GetBool("quote_pdf.attach_to_followups"),
+		},
+		ExchangeRates: ExchangeRatesConfig{
+			URL:             v.GetString("exchange_rates.url"),
+			RefreshInterval: v.GetDuration("exchange_rates.refresh_interval"),
+		},
+		QuoteApproval: QuoteApprovalConfig{
+			Threshold: v.GetFloat64("quote_approval.threshold"),
+			Approvers: v.GetString("quote_approval.approvers"),
+		},
+		QuotePortal: QuotePortalConfig{
+			LinkTTL:         v.GetDuration("quote_portal.link_ttl"),
+			ConfirmationSMS: v.GetBool("quote_portal.confirmation_sms"),
+			RateLimit:       v.GetInt("quote_portal.rate_limit"),
+		},
+		QuoteForm: QuoteFormConfig{
+			Enabled:          v.GetBool("quote_form.enabled"),
+			AllowedOrigins:   v.GetString("quote_form.allowed_origins"),
+			RateLimit:        v.GetInt("quote_form.rate_limit"),
+			CaptchaProvider:  v.GetString("quote_form.captcha_provider"),
+			CaptchaSiteKey:   v.GetString("quote_form.captcha_site_key"),
+			CaptchaSecret:    v.GetString("quote_form.captcha_secret"),
+			Callbacks:        v.GetBool("quote_form.callbacks"),
+			CallbackPromptID: v.GetString("quote_form.callback_prompt_id"),
+		},
+		Payments: PaymentsConfig{
+			Provider:            v.GetString("payments.provider"),
+			DepositPercent:      v.GetFloat64("payments.deposit_percent"),
+			StripeSecretKey:     v.GetString("payments.stripe_secret_key"),
+			StripeWebhookSecret: v.GetString("payments.stripe_webhook_secret"),
+		},
+		Accounting: AccountingConfig{
+			Provider:     v.GetString("accounting.provider"),
+			ClientID:     v.GetString("accounting.client_id"),
+			ClientSecret: v.GetString("accounting.client_secret"),
+			Sandbox:      v.GetBool("accounting.sandbox"),
+			Document:     v.GetString("accounting.document"),
+			AutoSync:     v.GetBool("accounting.auto_sync"),
+		},
+		CRM: CRMConfig{
+			Provider:               v.GetString("crm.provider"),
+			HubSpotToken:           v.GetString("crm.hubspot_token"),
+			SalesforceURL:          v.GetString("crm.salesforce_url"),
+			SalesforceClientID:     v.GetString("crm.salesforce_client_id"),
+			SalesforceClientSecret: v.GetString("crm.salesforce_client_secret"),
+		},
+		ChatOps: ChatOpsConfig{
+			CallCompletedURL:      v.GetString("chatops.call_completed_url"),
+			QuoteReadyURL:         v.GetString("chatops.quote_ready_url"),
+			QuoteReadyMinTotal:    v.GetFloat64("chatops.quote_ready_min_total"),
+			UsageAlertURL:         v.GetString("chatops.usage_alert_url"),
+			JobFailedURL:          v.GetString("chatops.job_failed_url"),
+			CallCompletedTemplate: v.GetString("chatops.call_completed_template"),
+			QuoteReadyTemplate:    v.GetString("chatops.quote_ready_template"),
+			UsageAlertTemplate:    v.GetString("chatops.usage_alert_template"),
+			JobFailedTemplate:     v.GetString("chatops.job_failed_template"),
+		},
+		Notifications: NotificationsConfig{
+			SMSEnabled: v.GetBool("notifications.sms_enabled"),
+			SMSFrom:    v.GetString("notifications.sms_from"),
+		},
+		Messaging: MessagingConfig{
+			QuoteReady: v.GetBool("messaging.quote_ready"),
+			SMSFrom:    v.GetString("messaging.sms_from"),
+		},
+		WhatsApp: WhatsAppConfig{
+			AccessToken:      v.GetString("whatsapp.access_token"),
+			PhoneNumberID:    v.GetString("whatsapp.phone_number_id"),
+			AppSecret:        v.GetString("whatsapp.app_secret"),
+			VerifyToken:      v.GetString("whatsapp.verify_token"),
+			QuoteTemplate:    v.GetString("whatsapp.quote_template"),
+			TemplateLanguage: v.GetString("whatsapp.template_language"),
+		},
+		Email: EmailConfig{
+			Provider:        v.GetString("email.provider"),
+			From:            v.GetString("email.from"),
+			FromName:        v.GetString("email.from_name"),
+			StaffRecipients: v.GetString("email.staff_recipients"),
+			SMTPHost:        v.GetString("email.smtp_host"),
+			SMTPPort:        v.GetInt("email.smtp_port"),
+			SMTPUsername:    v.GetString("email.smtp_username"),
+			SMTPPassword:    v.GetString("email.smtp_password"),
+			SendGridAPIKey:  v.GetString("email.sendgrid_api_key"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    v.GetString("tracing.endpoint"),
+			Headers:     v.GetString("tracing.headers"),
+			ServiceName: v.GetString("tracing.service_name"),
+			SampleRatio: v.GetFloat64("tracing.sample_ratio"),
+		},
+		Calendar: CalendarConfig{
+			Provider:              v.GetString("calendar.provider"),
+			Timezone:              v.GetString("calendar.timezone"),
+			EventDuration:         v.GetDuration("calendar.event_duration"),
+			GoogleCalendarID:      v.GetString("calendar.google_calendar_id"),
+			GoogleCredentialsFile: v.GetString("calendar.google_credentials_file"),
+			CalDAVURL:             v.GetString("calendar.caldav_url"),
+			CalDAVUsername:        v.GetString("calendar.caldav_username"),
+			CalDAVPassword:        v.GetString("calendar.caldav_password"),
+		},
+		Recordings: RecordingsConfig{
+			Storage:           v.GetString("recordings.storage"),
+			LocalPath:         v.GetString("recordings.local_path"),
+			S3Endpoint:        v.GetString("recordings.s3_endpoint"),
+			S3Region:          v.GetString("recordings.s3_region"),
+			S3Bucket:          v.GetString("recordings.s3_bucket"),
+			S3AccessKeyID:     v.GetString("recordings.s3_access_key_id"),
+			S3SecretAccessKey: v.GetString("recordings.s3_secret_access_key"),
+			S3PathStyle:       v.GetBool("recordings.s3_path_style"),
+			RetentionDays:     v.GetInt("recordings.retention_days"),
+			MaxSizeMB:         v.GetInt("recordings.max_size_mb"),
+		},
+		Transcription: TranscriptionConfig{
+			Provider:      v.GetString("transcription.provider"),
+			Language:      v.GetString("transcription.language"),
+			OpenAIAPIKey:  v.GetString("transcription.openai_api_key"),
+			OpenAIModel:   v.GetString("transcription.openai_model"),
+			OpenAIAPIURL:  v.GetString("transcription.openai_api_url"),
+			WhisperCppURL: v.GetString("transcription.whisper_cpp_url"),
+			MaxSizeMB:     v.GetInt("transcription.max_size_mb"),
+		},
+		Budget: BudgetConfig{
+			MonthlyHardCap:  v.GetFloat64("budget.monthly_hard_cap"),
+			CostPerMinute:   v.GetFloat64("budget.cost_per_minute"),
+			RefreshInterval: v.GetDuration("budget.refresh_interval"),
+		},
+		FollowUp: FollowUpConfig{
+			Sequence:        v.GetString("followup.sequence"),
+			QuietHoursStart: v.GetString("followup.quiet_hours_start"),
+			QuietHoursEnd:   v.GetString("followup.quiet_hours_end"),
+			Timezone:        v.GetString("followup.timezone"),
+			PollInterval:    v.GetDuration("followup.poll_interval"),
+		},
+		Compliance: ComplianceConfig{
+			CallingWindowStart: v.GetString("compliance.calling_window_start"),
+			CallingWindowEnd:   v.GetString("compliance.calling_window_end"),
+			DefaultTimezone:    v.GetString("compliance.default_timezone"),
+			NationalDNCFile:    v.GetString("compliance.national_dnc_file"),
+		},
+		NumberHealth: NumberHealthConfig{
+			Enabled:          v.GetBool("number_health.enabled"),
+			Interval:         v.GetDuration("number_health.interval"),
+			Lookback:         v.GetDuration("number_health.lookback"),
+			MinCalls:         v.GetInt("number_health.min_calls"),
+			ReputationURL:    v.GetString("number_health.reputation_url"),
+			ReputationAPIKey: v.GetString("number_health.reputation_api_key"),
+		},
+		Overflow: OverflowConfig{
+			Threshold:      v.GetInt("overflow.threshold"),
+			Policy:         v.GetString("overflow.policy"),
+			PromptID:       v.GetString("overflow.prompt_id"),
+			TransferNumber: v.GetString("overflow.transfer_number"),
+			SMSMessage:     v.GetString("overflow.sms_message"),
+		},
+		Analytics: AnalyticsConfig{
+			RollupEnabled:      v.GetBool("analytics.rollup_enabled"),
+			RollupHour:         v.GetInt("analytics.rollup_hour"),
+			RollupLookbackDays: v.GetInt("analytics.rollup_lookback_days"),
+		},
+		Tagging: TaggingConfig{
+			Enabled: v.GetBool("tagging.enabled"),
+			Workers: v.GetInt("tagging.workers"),
+		},
+		Spam: SpamConfig{
+			Enabled:         v.GetBool("spam.enabled"),
+			Prefixes:        v.GetString("spam.prefixes"),
+			MaxCallsPerHour: v.GetInt("spam.max_calls_per_hour"),
+			LookupURL:       v.GetString("spam.lookup_url"),
+			LookupAPIKey:    v.GetString("spam.lookup_api_key"),
+			AutoBlockAfter:  v.GetInt("spam.auto_block_after"),
+		},
+		Privacy: PrivacyConfig{
+			ReportSigningKey: v.GetString("privacy.report_signing_key"),
+		},
+		Cache: CacheConfig{
+			TTL: v.GetDuration("cache.ttl"),
+		},
+		APIDocs: APIDocsConfig{
+			Enabled:      v.GetBool("api_docs.enabled"),
+			SwaggerUIURL: v.GetString("api_docs.swagger_ui_url"),
+		},
+		GraphQL: GraphQLConfig{
+			Enabled:             v.GetBool("graphql.enabled"),
+			PersistedQueriesDir: v.GetString("graphql.persisted_queries_dir"),
+			AllowlistOnly:       v.GetBool("graphql.allowlist_only"),
+			MaxBatchSize:        v.GetInt("graphql.max_batch_size"),
+			MaxDepth:            v.GetInt("graphql.max_depth"),
+		},
+		GRPC: GRPCConfig{
+			Enabled:     v.GetBool("grpc.enabled"),
+			Host:        v.GetString("grpc.host"),
+			Port:        v.GetInt("grpc.port"),
+			TLSCertFile: v.GetString("grpc.tls_cert_file"),
+			TLSKeyFile:  v.GetString("grpc.tls_key_file"),
+			Insecure:    v.GetBool("grpc.insecure"),
+		},
+		Archival: ArchivalConfig{
+			Enabled:    v.GetBool("archival.enabled"),
+			CallsAfter: v.GetDuration("archival.calls_after"),
+			Interval:   v.GetDuration("archival.interval"),
+			BatchSize:  v.GetInt("archival.batch_size"),
+		},
+		Retention: RetentionConfig{
+			Enabled:     v.GetBool("retention.enabled"),
+			DryRun:      v.GetBool("retention.dry_run"),
+			Transcripts: v.GetDuration("retention.transcripts"),
+			Recordings:  v.GetDuration("retention.recordings"),
+			AuditEvents: v.GetDuration("retention.audit_events"),
+			Interval:    v.GetDuration("retention.interval"),
+			BatchSize:   v.GetInt("retention.batch_size"),
+		},
+		Secrets: SecretsConfig{
+			MasterKey:          v.GetString("secrets.master_key"),
+			PreviousMasterKeys: v.GetString("secrets.previous_master_keys"),
+			RefreshInterval:    v.GetDuration("secrets.refresh_interval"),
+		},
+		HTTPClient: HTTPClientConfig{
+			Bland:     loadHTTPClientPolicy(v, "bland"),
+			Vapi:      loadHTTPClientPolicy(v, "vapi"),
+			Retell:    loadHTTPClientPolicy(v, "retell"),
+			Anthropic: loadHTTPClientPolicy(v, "anthropic"),
+		},
+		Jobs: JobsConfig{
+			LeaderCheckInterval: v.GetDuration("jobs.leader_check_interval"),
+		},
+		Backup: BackupConfig{
+			Enabled:           v.GetBool("backup.enabled"),
+			Interval:          v.GetDuration("backup.interval"),
+			RetentionDays:     v.GetInt("backup.retention_days"),
+			Timeout:           v.GetDuration("backup.timeout"),
+			PgDumpPath:        v.GetString("backup.pg_dump_path"),
+			Storage:           v.GetString("backup.storage"),
+			LocalPath:         v.GetString("backup.local_path"),
+			S3Endpoint:        v.GetString("backup.s3_endpoint"),
+			S3Region:          v.GetString("backup.s3_region"),
+			S3Bucket:          v.GetString("backup.s3_bucket"),
+			S3AccessKeyID:     v.GetString("backup.s3_access_key_id"),
+			S3SecretAccessKey: v.GetString("backup.s3_secret_access_key"),
+			S3PathStyle:       v.GetBool("backup.s3_path_style"),
+		},
+		Tags: TagsConfig{
+			FreeForm:     v.GetBool("tags.free_form"),
+			MaxPerRecord: v.GetInt("tags.max_per_record"),
+		},
+		SavedViews: SavedViewsConfig{
+			MaxPerUser: v.GetInt("saved_views.max_per_user"),
+		},
+		Bulk: BulkConfig{
+			MaxItems: v.GetInt("bulk.max_items"),
+		},
+		Ingress: IngressConfig{
+			TrustedProxies:           v.GetString("ingress.trusted_proxies"),
+			WebhookAllowedIPs:        v.GetString("ingress.webhook_allowed_ips"),
+			MetricsAllowedIPs:        v.GetString("ingress.metrics_allowed_ips"),
+			APIAllowedIPs:            v.GetString("ingress.api_allowed_ips"),
+			WebhookRequireClientCert: v.GetBool("ingress.webhook_require_client_cert"),
+			MetricsRequireClientCert: v.GetBool("ingress.metrics_require_client_cert"),
+			APIRequireClientCert:     v.GetBool("ingress.api_require_client_cert"),
+		},
 	}
 
 	// Backward compatibility: if legacy Bland config is set but new config is not,
@@ -311,11 +1131,33 @@ func Load() (*Config, error) {
 }
 
 // setDefaults configures default values for all settings.
+// loadHTTPClientPolicy reads the HTTP client policy for a provider.
+func loadHTTPClientPolicy(v *viper.Viper, provider string) HTTPClientPolicy {
+	prefix := "http_client." + provider + "."
+	return HTTPClientPolicy{
+		Timeout:             v.GetDuration(prefix + "timeout"),
+		DialTimeout:         v.GetDuration(prefix + "dial_timeout"),
+		MaxRetries:          v.GetInt(prefix + "max_retries"),
+		RetryBaseDelay:      v.GetDuration(prefix + "retry_base_delay"),
+		RetryMaxDelay:       v.GetDuration(prefix + "retry_max_delay"),
+		RetryBudget:         v.GetFloat64(prefix + "retry_budget"),
+		ProxyURL:            v.GetString(prefix + "proxy_url"),
+		MaxIdleConns:        v.GetInt(prefix + "max_idle_conns"),
+		MaxIdleConnsPerHost: v.GetInt(prefix + "max_idle_conns_per_host"),
+		MaxConnsPerHost:     v.GetInt(prefix + "max_conns_per_host"),
+		IdleConnTimeout:     v.GetDuration(prefix + "idle_conn_timeout"),
+	}
+}
+
 func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.host", "0.0.0.0")
 	v.SetDefault("server.port", 8080)
 	v.SetDefault("server.env", "development")
+	v.SetDefault("server.tls_cert_file", "")
+	v.SetDefault("server.tls_key_file", "")
+	v.SetDefault("server.client_ca_file", "")
+	v.SetDefault("server.client_auth", "none")
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -329,24 +1171,44 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.slow_query_threshold", "100ms")
 	v.SetDefault("database.very_slow_query_threshold", "500ms")
 	v.SetDefault("database.log_all_queries", false)
+	v.SetDefault("database.replica_dsn", "")
 
 	// Voice provider defaults
 	v.SetDefault("voice_provider.primary", "bland")
+	v.SetDefault("voice_provider.webhook_dedup_ttl", "72h")
+	v.SetDefault("voice_provider.webhook_archive_retention", "720h")
 	v.SetDefault("voice_provider.bland.enabled", true)
 	v.SetDefault("voice_provider.bland.api_url", "https://api.bland.ai/v1")
+	v.SetDefault("voice_provider.bland.sync_interval", "15m")
+	v.SetDefault("voice_provider.bland.replay_interval", "30s")
+	v.SetDefault("voice_provider.bland.signature_mode", "reject")
+	v.SetDefault("voice_provider.bland.signature_tolerance", "5m")
 	v.SetDefault("voice_provider.vapi.enabled", false)
 	v.SetDefault("voice_provider.vapi.api_url", "https://api.vapi.ai")
 	v.SetDefault("voice_provider.retell.enabled", false)
 	v.SetDefault("voice_provider.retell.api_url", "https://api.retellai.com")
+	v.SetDefault("voice_provider.twilio.enabled", false)
+	v.SetDefault("voice_provider.twilio.api_url", "https://api.twilio.com/2010-04-01")
+	v.SetDefault("voice_provider.fake.enabled", false)
+	v.SetDefault("voice_provider.fake.ring_delay", "2s")
+	v.SetDefault("voice_provider.fake.call_duration", "20s")
+	v.SetDefault("voice_provider.fake.inbound_interval", "0")
 
 	// Legacy Bland AI defaults (for backward compatibility)
 	v.SetDefault("bland.api_url", "https://api.bland.ai/v1")
 
 	// Anthropic defaults
 	v.SetDefault("anthropic.model", "claude-sonnet-4-20250514")
+	v.SetDefault("anthropic.input_cost_per_mtok", 3.0)
+	v.SetDefault("anthropic.output_cost_per_mtok", 15.0)
 
 	// Auth defaults
 	v.SetDefault("session.duration", "24h")
+	v.SetDefault("auth.require_two_factor", false)
+	v.SetDefault("auth.totp_issuer", "QuickQuote")
+	v.SetDefault("auth.oidc_provider_name", "SSO")
+	v.SetDefault("auth.oidc_auto_provision", true)
+	v.SetDefault("auth.oidc_default_role", "member")
 
 	// Log defaults
 	v.SetDefault("log.level", "info")
@@ -377,6 +1239,246 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("call.quality_preset", "default")      // Technical default
 	v.SetDefault("call.project_types", "")              // MUST be set by user
 	v.SetDefault("call.custom_greeting", "")            // MUST be set by user if needed
+
+	// Quote PDF defaults
+	v.SetDefault("quote_pdf.validity_days", 30)
+	v.SetDefault("quote_pdf.currency", "USD")
+	v.SetDefault("quote_pdf.attach_to_followups", true)
+
+	// Exchange rate defaults
+	v.SetDefault("exchange_rates.url", "")
+	v.SetDefault("exchange_rates.refresh_interval", "12h")
+
+	// Quote approval defaults
+	v.SetDefault("quote_approval.threshold", 10000)
+	v.SetDefault("quote_approval.approvers", "")
+
+	// Customer quote link defaults
+	v.SetDefault("quote_portal.link_ttl", "720h")
+	v.SetDefault("quote_portal.confirmation_sms", false)
+	v.SetDefault("quote_portal.rate_limit", 30)
+
+	// Public quote form defaults
+	v.SetDefault("quote_form.enabled", false)
+	v.SetDefault("quote_form.allowed_origins", "")
+	v.SetDefault("quote_form.rate_limit", 5)
+	v.SetDefault("quote_form.captcha_provider", "")
+	v.SetDefault("quote_form.captcha_site_key", "")
+	v.SetDefault("quote_form.captcha_secret", "")
+	v.SetDefault("quote_form.callbacks", false)
+	v.SetDefault("quote_form.callback_prompt_id", "")
+
+	// Payment defaults
+	v.SetDefault("payments.provider", "")
+	v.SetDefault("payments.deposit_percent", 100)
+
+	// Accounting defaults (disabled unless a provider is set)
+	v.SetDefault("accounting.provider", "")
+	v.SetDefault("accounting.sandbox", false)
+	v.SetDefault("accounting.document", "invoice")
+	v.SetDefault("accounting.auto_sync", true)
+
+	// CRM defaults (disabled unless a provider is set)
+	v.SetDefault("crm.provider", "")
+
+	// Chat notification defaults (disabled unless a webhook URL is set)
+	v.SetDefault("chatops.quote_ready_min_total", 0)
+
+	// Per-user notification defaults
+	v.SetDefault("notifications.sms_enabled", true)
+
+	// Customer messaging defaults (WhatsApp is disabled unless an access
+	// token is set)
+	v.SetDefault("messaging.quote_ready", false)
+	v.SetDefault("messaging.sms_from", "")
+	v.SetDefault("whatsapp.access_token", "")
+	v.SetDefault("whatsapp.phone_number_id", "")
+	v.SetDefault("whatsapp.app_secret", "")
+	v.SetDefault("whatsapp.verify_token", "")
+	v.SetDefault("whatsapp.quote_template", "")
+	v.SetDefault("whatsapp.template_language", "en_US")
+
+	// Email defaults (disabled unless a provider is set)
+	v.SetDefault("email.provider", "")
+	v.SetDefault("email.smtp_port", 587)
+
+	// Tracing defaults (disabled unless an endpoint is set). The standard
+	// OpenTelemetry variables are honored as well as TRACING_*.
+	v.SetDefault("tracing.service_name", "quickquote")
+	v.SetDefault("tracing.sample_ratio", 1.0)
+	_ = v.BindEnv("tracing.endpoint", "TRACING_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT")
+	_ = v.BindEnv("tracing.headers", "TRACING_HEADERS", "OTEL_EXPORTER_OTLP_HEADERS")
+	_ = v.BindEnv("tracing.service_name", "TRACING_SERVICE_NAME", "OTEL_SERVICE_NAME")
+
+	// Callback calendar defaults
+	v.SetDefault("calendar.provider", "")
+	v.SetDefault("calendar.timezone", "UTC")
+	v.SetDefault("calendar.event_duration", "30m")
+	v.SetDefault("calendar.google_calendar_id", "primary")
+
+	// Recording storage defaults
+	v.SetDefault("recordings.storage", "")
+	v.SetDefault("recordings.local_path", "data/recordings")
+	v.SetDefault("recordings.s3_region", "us-east-1")
+	v.SetDefault("recordings.s3_path_style", false)
+	v.SetDefault("recordings.retention_days", 90)
+	v.SetDefault("recordings.max_size_mb", 100)
+
+	// Transcription fallback defaults
+	v.SetDefault("transcription.provider", "")
+	v.SetDefault("transcription.openai_model", "whisper-1")
+	v.SetDefault("transcription.openai_api_url", "https://api.openai.com/v1")
+	v.SetDefault("transcription.max_size_mb", 25)
+
+	// Budget enforcement defaults (disabled unless a hard cap is set)
+	v.SetDefault("budget.monthly_hard_cap", 0)
+	v.SetDefault("budget.cost_per_minute", 0.14)
+	v.SetDefault("budget.refresh_interval", "1m")
+
+	// Quote follow-up defaults (disabled unless a sequence is set)
+	v.SetDefault("followup.sequence", "")
+	v.SetDefault("followup.quiet_hours_start", "21:00")
+	v.SetDefault("followup.quiet_hours_end", "09:00")
+	v.SetDefault("followup.timezone", "")
+	v.SetDefault("followup.poll_interval", "30s")
+
+	// Compliance defaults: the TCPA's 8 AM to 9 PM calling window
+	v.SetDefault("compliance.calling_window_start", "08:00")
+	v.SetDefault("compliance.calling_window_end", "21:00")
+	v.SetDefault("compliance.default_timezone", "")
+	v.SetDefault("compliance.national_dnc_file", "")
+
+	// Caller ID health defaults
+	v.SetDefault("number_health.enabled", true)
+	v.SetDefault("number_health.interval", "6h")
+	v.SetDefault("number_health.lookback", "168h")
+	v.SetDefault("number_health.min_calls", 20)
+	v.SetDefault("number_health.reputation_url", "")
+	v.SetDefault("number_health.reputation_api_key", "")
+
+	// Inbound overflow defaults (disabled unless a threshold is set)
+	v.SetDefault("overflow.threshold", 0)
+	v.SetDefault("overflow.policy", "sms")
+	v.SetDefault("overflow.prompt_id", "")
+	v.SetDefault("overflow.transfer_number", "")
+	v.SetDefault("overflow.sms_message", "")
+
+	// Analytics rollup defaults
+	v.SetDefault("analytics.rollup_enabled", true)
+	v.SetDefault("analytics.rollup_hour", 2)
+	v.SetDefault("analytics.rollup_lookback_days", 7)
+
+	// Call tagging defaults
+	v.SetDefault("tagging.enabled", true)
+	v.SetDefault("tagging.workers", 2)
+
+	// Spam scoring defaults
+	v.SetDefault("spam.enabled", true)
+	v.SetDefault("spam.prefixes", "")
+	v.SetDefault("spam.max_calls_per_hour", 5)
+	v.SetDefault("spam.lookup_url", "")
+	v.SetDefault("spam.lookup_api_key", "")
+	v.SetDefault("spam.auto_block_after", 0)
+
+	// Privacy defaults
+	v.SetDefault("privacy.report_signing_key", "")
+
+	// Cache defaults
+	v.SetDefault("cache.ttl", "1m")
+
+	// API docs defaults
+	v.SetDefault("api_docs.enabled", true)
+	v.SetDefault("api_docs.swagger_ui_url", "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5")
+
+	// GraphQL defaults
+	v.SetDefault("graphql.enabled", true)
+	v.SetDefault("graphql.persisted_queries_dir", "")
+	v.SetDefault("graphql.allowlist_only", false)
+	v.SetDefault("graphql.max_batch_size", 10)
+	v.SetDefault("graphql.max_depth", 10)
+
+	// gRPC defaults
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.host", "0.0.0.0")
+	v.SetDefault("grpc.port", 9090)
+	v.SetDefault("grpc.tls_cert_file", "")
+	v.SetDefault("grpc.tls_key_file", "")
+	v.SetDefault("grpc.insecure", false)
+
+	// Archival policy defaults
+	v.SetDefault("archival.enabled", false)
+	v.SetDefault("archival.calls_after", "8760h")
+	v.SetDefault("archival.interval", "24h")
+	v.SetDefault("archival.batch_size", 500)
+
+	// Retention rule defaults: transcripts 2 years, recordings 90 days,
+	// audit events 7 years
+	v.SetDefault("retention.enabled", false)
+	v.SetDefault("retention.dry_run", true)
+	v.SetDefault("retention.transcripts", "17520h")
+	v.SetDefault("retention.recordings", "2160h")
+	v.SetDefault("retention.audit_events", "61320h")
+	v.SetDefault("retention.interval", "24h")
+	v.SetDefault("retention.batch_size", 1000)
+
+	// Stored provider keys are off until a master key is set
+	v.SetDefault("secrets.master_key", "")
+	v.SetDefault("secrets.previous_master_keys", "")
+	v.SetDefault("secrets.refresh_interval", "1m")
+
+	// HTTP client defaults; Claude responses take longer than the voice APIs'
+	for provider, timeout := range map[string]time.Duration{
+		"bland":     30 * time.Second,
+		"vapi":      30 * time.Second,
+		"retell":    30 * time.Second,
+		"anthropic": 60 * time.Second,
+	} {
+		prefix := "http_client." + provider + "."
+		v.SetDefault(prefix+"timeout", timeout)
+		v.SetDefault(prefix+"dial_timeout", 10*time.Second)
+		v.SetDefault(prefix+"max_retries", 2)
+		v.SetDefault(prefix+"retry_base_delay", 200*time.Millisecond)
+		v.SetDefault(prefix+"retry_max_delay", 5*time.Second)
+		v.SetDefault(prefix+"retry_budget", 0.2)
+		v.SetDefault(prefix+"proxy_url", "")
+		v.SetDefault(prefix+"max_idle_conns", 100)
+		v.SetDefault(prefix+"max_idle_conns_per_host", 10)
+		v.SetDefault(prefix+"max_conns_per_host", 0)
+		v.SetDefault(prefix+"idle_conn_timeout", 90*time.Second)
+	}
+
+	// Background job defaults
+	v.SetDefault("jobs.leader_check_interval", 15*time.Second)
+
+	// Backup defaults: nightly, kept two weeks
+	v.SetDefault("backup.enabled", false)
+	v.SetDefault("backup.interval", "24h")
+	v.SetDefault("backup.retention_days", 14)
+	v.SetDefault("backup.timeout", "1h")
+	v.SetDefault("backup.pg_dump_path", "pg_dump")
+	v.SetDefault("backup.storage", "")
+	v.SetDefault("backup.local_path", "data/backups")
+	v.SetDefault("backup.s3_region", "us-east-1")
+	v.SetDefault("backup.s3_path_style", false)
+
+	// Tag defaults
+	v.SetDefault("tags.free_form", true)
+	v.SetDefault("tags.max_per_record", 20)
+
+	// Saved view defaults
+	v.SetDefault("saved_views.max_per_user", 50)
+
+	// Bulk action defaults
+	v.SetDefault("bulk.max_items", 200)
+
+	// Ingress defaults
+	v.SetDefault("ingress.trusted_proxies", "*")
+	v.SetDefault("ingress.webhook_allowed_ips", "")
+	v.SetDefault("ingress.metrics_allowed_ips", "")
+	v.SetDefault("ingress.api_allowed_ips", "")
+	v.SetDefault("ingress.webhook_require_client_cert", false)
+	v.SetDefault("ingress.metrics_require_client_cert", false)
+	v.SetDefault("ingress.api_require_client_cert", false)
 }
 
 // Validate checks that all required configuration values are present.
@@ -398,12 +1500,18 @@ func (c *Config) Validate() error {
 	if c.VoiceProvider.Retell.Enabled && c.VoiceProvider.Retell.APIKey != "" {
 		hasVoiceProvider = true
 	}
+	if c.VoiceProvider.Twilio.Enabled && c.VoiceProvider.Twilio.AccountSID != "" && c.VoiceProvider.Twilio.AuthToken != "" {
+		hasVoiceProvider = true
+	}
+	if c.VoiceProvider.Fake.Enabled {
+		hasVoiceProvider = true
+	}
 	// Backward compatibility: check legacy Bland config
 	if c.Bland.APIKey != "" {
 		hasVoiceProvider = true
 	}
 	if !hasVoiceProvider {
-		missing = append(missing, "VOICE_PROVIDER (at least one of BLAND_API_KEY, VAPI_API_KEY, or RETELL_API_KEY)")
+		missing = append(missing, "VOICE_PROVIDER (at least one of BLAND_API_KEY, VAPI_API_KEY, RETELL_API_KEY, or TWILIO credentials)")
 	}
 
 	if c.Anthropic.APIKey == "" {
@@ -436,12 +1544,189 @@ func (c *Config) Validate() error {
 		if c.CallSettings.BusinessName == "" {
 			missing = append(missing, "CALL_BUSINESS_NAME (required in production)")
 		}
+		// The public quote form must not take submissions from bots
+		if c.QuoteForm.Enabled && c.QuoteForm.CaptchaSecret == "" {
+			missing = append(missing, "QUOTE_FORM_CAPTCHA_SECRET (required in production when QUOTE_FORM_ENABLED)")
+		}
+		if c.WhatsApp.Enabled() && c.WhatsApp.AppSecret == "" {
+			missing = append(missing, "WHATSAPP_APP_SECRET (required in production when WHATSAPP_ACCESS_TOKEN is set)")
+		}
 	}
 
 	if len(missing) > 0 {
 		return fmt.Errorf("missing required configuration: %s", strings.Join(missing, ", "))
 	}
 
+	// Simulated calls would be taken for real ones
+	if c.IsProduction() && c.VoiceProvider.Fake.Enabled {
+		return fmt.Errorf("VOICE_PROVIDER_FAKE_ENABLED must not be set in production")
+	}
+
+	// Anyone with an account at the identity provider, such as any Google
+	// account, would get a login
+	if c.Auth.OIDCIssuerURL != "" && c.Auth.OIDCAutoProvision && len(c.Auth.GetOIDCAllowedDomains()) == 0 {
+		return fmt.Errorf("AUTH_OIDC_AUTO_PROVISION requires AUTH_OIDC_ALLOWED_DOMAINS; set the domains or turn auto-provisioning off")
+	}
+
+	// An allowlist without persisted queries would refuse every query
+	if c.GraphQL.Enabled && c.GraphQL.AllowlistOnly && c.GraphQL.PersistedQueriesDir == "" {
+		return fmt.Errorf("GRAPHQL_ALLOWLIST_ONLY requires GRAPHQL_PERSISTED_QUERIES_DIR")
+	}
+
+	// API keys would cross the network in the clear
+	if c.GRPC.Enabled {
+		if c.GRPC.Insecure && c.IsProduction() {
+			return fmt.Errorf("GRPC_INSECURE must not be set in production")
+		}
+		if !c.GRPC.Insecure && (c.GRPC.TLSCertFile == "" || c.GRPC.TLSKeyFile == "") {
+			return fmt.Errorf("GRPC_ENABLED requires GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE, or GRPC_INSECURE outside production")
+		}
+	}
+
+	if c.Jobs.LeaderCheckInterval < 0 {
+		return fmt.Errorf("JOBS_LEADER_CHECK_INTERVAL must not be negative")
+	}
+
+	// Scheduled backups need somewhere to go and a schedule
+	if c.Backup.Enabled && (c.Backup.Storage == "" || c.Backup.Interval <= 0) {
+		return fmt.Errorf("BACKUP_ENABLED requires BACKUP_STORAGE and a positive BACKUP_INTERVAL")
+	}
+	if c.Backup.RetentionDays < 0 || c.Backup.Timeout < 0 {
+		return fmt.Errorf("BACKUP_RETENTION_DAYS and BACKUP_TIMEOUT must not be negative")
+	}
+
+	if c.Tags.MaxPerRecord < 0 {
+		return fmt.Errorf("TAGS_MAX_PER_RECORD must not be negative")
+	}
+
+	if c.SavedViews.MaxPerUser < 0 {
+		return fmt.Errorf("SAVED_VIEWS_MAX_PER_USER must not be negative")
+	}
+
+	if c.Bulk.MaxItems < 0 {
+		return fmt.Errorf("BULK_MAX_ITEMS must not be negative")
+	}
+
+	// The policy needs an age to archive at, a schedule and a batch size
+	if c.Archival.Enabled && (c.Archival.CallsAfter <= 0 || c.Archival.Interval <= 0 || c.Archival.BatchSize <= 0) {
+		return fmt.Errorf("ARCHIVAL_ENABLED requires positive ARCHIVAL_CALLS_AFTER, ARCHIVAL_INTERVAL and ARCHIVAL_BATCH_SIZE")
+	}
+
+	// Rule ages may be zero to keep records forever, but never negative
+	if c.Retention.Transcripts < 0 || c.Retention.Recordings < 0 || c.Retention.AuditEvents < 0 {
+		return fmt.Errorf("RETENTION_TRANSCRIPTS, RETENTION_RECORDINGS and RETENTION_AUDIT_EVENTS must not be negative")
+	}
+	if c.Retention.Enabled && (c.Retention.Interval <= 0 || c.Retention.BatchSize <= 0) {
+		return fmt.Errorf("RETENTION_ENABLED requires positive RETENTION_INTERVAL and RETENTION_BATCH_SIZE")
+	}
+
+	// Master keys must be usable; previous keys only make sense with a current one
+	if c.Secrets.MasterKey != "" {
+		if _, err := secrets.ParseKey(c.Secrets.MasterKey); err != nil {
+			return fmt.Errorf("SECRETS_MASTER_KEY is invalid: %w", err)
+		}
+	}
+	for _, key := range c.Secrets.GetPreviousMasterKeys() {
+		if _, err := secrets.ParseKey(key); err != nil {
+			return fmt.Errorf("SECRETS_PREVIOUS_MASTER_KEYS is invalid: %w", err)
+		}
+	}
+	if c.Secrets.MasterKey == "" && c.Secrets.PreviousMasterKeys != "" {
+		return fmt.Errorf("SECRETS_PREVIOUS_MASTER_KEYS requires SECRETS_MASTER_KEY")
+	}
+	if c.Secrets.MasterKey != "" && c.Secrets.RefreshInterval <= 0 {
+		return fmt.Errorf("SECRETS_MASTER_KEY requires a positive SECRETS_REFRESH_INTERVAL")
+	}
+
+	// HTTPS needs both halves of the key pair; client certificates need HTTPS
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE must be set together")
+	}
+	switch c.Server.ClientAuth {
+	case "", "none":
+	case "optional", "require":
+		if c.Server.TLSCertFile == "" || c.Server.ClientCAFile == "" {
+			return fmt.Errorf("SERVER_CLIENT_AUTH %q requires SERVER_TLS_CERT_FILE and SERVER_CLIENT_CA_FILE", c.Server.ClientAuth)
+		}
+	default:
+		return fmt.Errorf("SERVER_CLIENT_AUTH must be none, optional or require, got %q", c.Server.ClientAuth)
+	}
+	if (c.Ingress.WebhookRequireClientCert || c.Ingress.MetricsRequireClientCert || c.Ingress.APIRequireClientCert) &&
+		(c.Server.ClientAuth == "" || c.Server.ClientAuth == "none") {
+		return fmt.Errorf("INGRESS_*_REQUIRE_CLIENT_CERT requires SERVER_CLIENT_AUTH optional or require")
+	}
+
+	if c.Ingress.TrustedProxies != "*" && c.Ingress.TrustedProxies != "none" {
+		if err := validateIPList(c.Ingress.TrustedProxies); err != nil {
+			return fmt.Errorf("INGRESS_TRUSTED_PROXIES is invalid: %w", err)
+		}
+	}
+	for _, allowlist := range []struct{ env, list string }{
+		{"INGRESS_WEBHOOK_ALLOWED_IPS", c.Ingress.WebhookAllowedIPs},
+		{"INGRESS_METRICS_ALLOWED_IPS", c.Ingress.MetricsAllowedIPs},
+		{"INGRESS_API_ALLOWED_IPS", c.Ingress.APIAllowedIPs},
+	} {
+		if err := validateIPList(allowlist.list); err != nil {
+			return fmt.Errorf("%s is invalid: %w", allowlist.env, err)
+		}
+		// Anyone could pass the allowlist with a forged X-Forwarded-For
+		if allowlist.list != "" && c.Ingress.TrustedProxies == "*" {
+			return fmt.Errorf("%s requires INGRESS_TRUSTED_PROXIES to list the proxies in front of the server, or none, rather than *", allowlist.env)
+		}
+	}
+
+	for _, client := range []struct {
+		env    string
+		policy HTTPClientPolicy
+	}{
+		{"HTTP_CLIENT_BLAND", c.HTTPClient.Bland},
+		{"HTTP_CLIENT_VAPI", c.HTTPClient.Vapi},
+		{"HTTP_CLIENT_RETELL", c.HTTPClient.Retell},
+		{"HTTP_CLIENT_ANTHROPIC", c.HTTPClient.Anthropic},
+	} {
+		if err := client.policy.validate(); err != nil {
+			return fmt.Errorf("%s_%w", client.env, err)
+		}
+	}
+
+	return nil
+}
+
+// validate checks an HTTP client policy. Errors start with the name of the
+// setting, so the caller can prefix the provider's.
+func (p HTTPClientPolicy) validate() error {
+	if p.Timeout < 0 || p.DialTimeout < 0 || p.RetryBaseDelay < 0 || p.RetryMaxDelay < 0 || p.IdleConnTimeout < 0 {
+		return fmt.Errorf("* timeouts and delays must not be negative")
+	}
+	if p.MaxRetries < 0 || p.MaxIdleConns < 0 || p.MaxIdleConnsPerHost < 0 || p.MaxConnsPerHost < 0 {
+		return fmt.Errorf("* retry and connection limits must not be negative")
+	}
+	if p.RetryBudget < 0 {
+		return fmt.Errorf("RETRY_BUDGET must not be negative")
+	}
+	if p.ProxyURL != "" {
+		if _, err := httpclient.ParseProxyURL(p.ProxyURL); err != nil {
+			return fmt.Errorf("PROXY_URL is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateIPList checks a comma-separated list of CIDR ranges and addresses.
+func validateIPList(list string) error {
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return fmt.Errorf("invalid CIDR range %q", entry)
+			}
+		} else if net.ParseIP(entry) == nil {
+			return fmt.Errorf("invalid IP address %q", entry)
+		}
+	}
 	return nil
 }
 
@@ -474,3 +1759,95 @@ func (c *CallSettingsConfig) HasProjectTypes() bool {
 	return c.ProjectTypes != ""
 }
 
+// GetStaffRecipients returns the staff notification addresses as a slice.
+func (c *EmailConfig) GetStaffRecipients() []string {
+	var recipients []string
+	for _, addr := range strings.Split(c.StaffRecipients, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return recipients
+}
+
+// GetAllowedOrigins returns the origins allowed to post the quote form as a
+// slice; an empty slice allows any origin.
+func (c *QuoteFormConfig) GetAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(c.AllowedOrigins, ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// GetOIDCScopes returns the extra single sign-on scopes as a slice.
+func (c *AuthConfig) GetOIDCScopes() []string {
+	var scopes []string
+	for _, scope := range strings.Split(c.OIDCScopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// GetOIDCAllowedDomains returns the email domains allowed to sign in with
+// single sign-on as a slice.
+func (c *AuthConfig) GetOIDCAllowedDomains() []string {
+	var domains []string
+	for _, domain := range strings.Split(c.OIDCAllowedDomains, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, strings.ToLower(domain))
+		}
+	}
+	return domains
+}
+
+// GetApprovers returns the designated quote approver emails as a slice.
+func (c *QuoteApprovalConfig) GetApprovers() []string {
+	var approvers []string
+	for _, addr := range strings.Split(c.Approvers, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			approvers = append(approvers, strings.ToLower(addr))
+		}
+	}
+	return approvers
+}
+
+// GetFailover returns the providers outbound calls fail over to as a slice.
+func (c *VoiceProviderConfig) GetFailover() []string {
+	var providers []string
+	for _, provider := range strings.Split(c.Failover, ",") {
+		if provider = strings.TrimSpace(provider); provider != "" {
+			providers = append(providers, strings.ToLower(provider))
+		}
+	}
+	return providers
+}
+
+// GetFailoverNumbers returns the caller ID mapping used after failing over.
+// Malformed pairs are skipped.
+func (c *VoiceProviderConfig) GetFailoverNumbers() map[string]string {
+	numbers := make(map[string]string)
+	for _, pair := range strings.Split(c.FailoverNumbers, ",") {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if ok && from != "" && to != "" {
+			numbers[from] = to
+		}
+	}
+	return numbers
+}
+
+// GetPrefixes returns the known spam prefixes as a slice.
+func (c *SpamConfig) GetPrefixes() []string {
+	var prefixes []string
+	for _, prefix := range strings.Split(c.Prefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
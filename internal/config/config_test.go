@@ -80,6 +80,36 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config with simulated provider",
+			config: Config{
+				Database: DatabaseConfig{Password: "pass"},
+				VoiceProvider: VoiceProviderConfig{
+					Primary: "fake",
+					Fake:    FakeProviderConfig{Enabled: true},
+				},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "simulated provider in production",
+			config: Config{
+				Server:   ServerConfig{Environment: "production"},
+				Database: DatabaseConfig{Password: "pass"},
+				VoiceProvider: VoiceProviderConfig{
+					Primary: "fake",
+					Fake:    FakeProviderConfig{Enabled: true},
+				},
+				Anthropic:    AnthropicConfig{APIKey: "key"},
+				Auth:         AuthConfig{SessionSecret: "secret"},
+				App:          AppConfig{PublicURL: "http://localhost"},
+				CallSettings: CallSettingsConfig{BusinessName: "Acme Software"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing database password",
 			config: Config{
@@ -144,6 +174,230 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "grpc without tls",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				GRPC:      GRPCConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "insecure grpc outside production",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				GRPC:      GRPCConfig{Enabled: true, Insecure: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "archival without an age",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Archival:  ArchivalConfig{Enabled: true, Interval: time.Hour, BatchSize: 100},
+			},
+			wantErr: true,
+		},
+		{
+			name: "scheduled backups without storage",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Backup:    BackupConfig{Enabled: true, Interval: 24 * time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative leader check interval",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Jobs:      JobsConfig{LeaderCheckInterval: -time.Second},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative tags per record",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Tags:      TagsConfig{MaxPerRecord: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative saved views per user",
+			config: Config{
+				Database:   DatabaseConfig{Password: "pass"},
+				Bland:      BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic:  AnthropicConfig{APIKey: "key"},
+				Auth:       AuthConfig{SessionSecret: "secret"},
+				App:        AppConfig{PublicURL: "http://localhost"},
+				SavedViews: SavedViewsConfig{MaxPerUser: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative bulk items",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Bulk:      BulkConfig{MaxItems: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "retention with a negative age",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Retention: RetentionConfig{Transcripts: -time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secrets master key of the wrong length",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Secrets:   SecretsConfig{MasterKey: "c2hvcnQ="},
+			},
+			wantErr: true,
+		},
+		{
+			name: "client auth without a CA",
+			config: Config{
+				Server:    ServerConfig{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", ClientAuth: "require"},
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid webhook allowlist",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Ingress:   IngressConfig{TrustedProxies: "none", WebhookAllowedIPs: "10.0.0.0/8, not-an-ip"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowlist while trusting every proxy",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Ingress:   IngressConfig{TrustedProxies: "*", APIAllowedIPs: "10.0.0.0/8"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "http client proxy with an unsupported scheme",
+			config: Config{
+				Database:   DatabaseConfig{Password: "pass"},
+				Bland:      BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic:  AnthropicConfig{APIKey: "key"},
+				Auth:       AuthConfig{SessionSecret: "secret"},
+				App:        AppConfig{PublicURL: "http://localhost"},
+				HTTPClient: HTTPClientConfig{Bland: HTTPClientPolicy{ProxyURL: "ftp://proxy:21"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative http client retries",
+			config: Config{
+				Database:   DatabaseConfig{Password: "pass"},
+				Bland:      BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic:  AnthropicConfig{APIKey: "key"},
+				Auth:       AuthConfig{SessionSecret: "secret"},
+				App:        AppConfig{PublicURL: "http://localhost"},
+				HTTPClient: HTTPClientConfig{Anthropic: HTTPClientPolicy{MaxRetries: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "single sign-on auto-provisioning without allowed domains",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret", OIDCIssuerURL: "https://accounts.google.com", OIDCAutoProvision: true},
+				App:       AppConfig{PublicURL: "http://localhost"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "single sign-on auto-provisioning with allowed domains",
+			config: Config{
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth: AuthConfig{
+					SessionSecret:      "secret",
+					OIDCIssuerURL:      "https://accounts.google.com",
+					OIDCAutoProvision:  true,
+					OIDCAllowedDomains: "example.com",
+				},
+				App: AppConfig{PublicURL: "http://localhost"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid ingress restrictions",
+			config: Config{
+				Server:    ServerConfig{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", ClientCAFile: "ca.pem", ClientAuth: "optional"},
+				Database:  DatabaseConfig{Password: "pass"},
+				Bland:     BlandConfig{APIKey: "key", InboundNumber: "+1234567890"},
+				Anthropic: AnthropicConfig{APIKey: "key"},
+				Auth:      AuthConfig{SessionSecret: "secret"},
+				App:       AppConfig{PublicURL: "http://localhost"},
+				Ingress: IngressConfig{
+					TrustedProxies:       "10.0.0.0/8",
+					MetricsAllowedIPs:    "10.0.0.0/8, 192.0.2.10",
+					APIRequireClientCert: true,
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -211,3 +465,19 @@ func TestRateLimitConfig(t *testing.T) {
 		t.Errorf("Window = %v, expected %v", cfg.Window, time.Minute)
 	}
 }
+
+func TestVoiceProviderConfig_Failover(t *testing.T) {
+	cfg := VoiceProviderConfig{
+		Failover:        " Retell, vapi,,",
+		FailoverNumbers: "+15550001111=+15550009999, +15550002222 = +15550008888, broken",
+	}
+
+	providers := cfg.GetFailover()
+	if len(providers) != 2 || providers[0] != "retell" || providers[1] != "vapi" {
+		t.Errorf("GetFailover() = %v", providers)
+	}
+	numbers := cfg.GetFailoverNumbers()
+	if len(numbers) != 2 || numbers["+15550002222"] != "+15550008888" {
+		t.Errorf("GetFailoverNumbers() = %v", numbers)
+	}
+}
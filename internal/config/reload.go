@@ -0,0 +1,211 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReloadableSettings lists the settings, as Settings keys, that take effect
+// when configuration is reloaded. Everything else needs a restart.
+var ReloadableSettings = []string{
+	"RateLimit.Requests",
+	"RateLimit.Window",
+	"QuoteApproval.Threshold",
+	"QuoteApproval.Approvers",
+	"Budget.MonthlyHardCap",
+	"Budget.CostPerMinute",
+	"VoiceProvider.Primary",
+	"VoiceProvider.Bland.Enabled",
+	"VoiceProvider.Vapi.Enabled",
+	"VoiceProvider.Retell.Enabled",
+	"VoiceProvider.Twilio.Enabled",
+}
+
+// redactedValue replaces secrets that are set in Redacted settings.
+const redactedValue = "********"
+
+// secretSuffixes are the endings of setting names that hold secrets.
+var secretSuffixes = []string{"Password", "Secret", "APIKey", "Token", "AccessKeyID", "SecretAccessKey", "SigningKey", "DSN", "MasterKey", "MasterKeys"}
+
+// ReloadResult describes what a reload changed.
+type ReloadResult struct {
+	Applied         []string `json:"applied"`          // Reloadable settings that changed and are now in effect
+	RestartRequired []string `json:"restart_required"` // Settings that changed but wait for a restart
+}
+
+// Reloader holds the running configuration and re-reads it on demand,
+// applying the reloadable settings and telling subscribers about them.
+type Reloader struct {
+	mu          sync.RWMutex
+	current     *Config
+	loadedAt    time.Time
+	reloadedAt  *time.Time
+	load        func() (*Config, error)
+	subscribers []func(cfg *Config)
+}
+
+// NewReloader creates a Reloader for the configuration the server started
+// with.
+func NewReloader(cfg *Config) *Reloader {
+	return &Reloader{
+		current:  cfg,
+		loadedAt: time.Now(),
+		load:     Load,
+	}
+}
+
+// Current returns the configuration in effect. Callers must not modify it.
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// LoadedAt returns when the server loaded its configuration and when it was
+// last reloaded, if ever.
+func (r *Reloader) LoadedAt() (time.Time, *time.Time) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.loadedAt, r.reloadedAt
+}
+
+// Subscribe registers fn to be called with the new configuration whenever a
+// reload changes a reloadable setting.
+func (r *Reloader) Subscribe(fn func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload reads configuration again and applies the reloadable settings that
+// changed. Other changes are reported but left for the next restart, so the
+// configuration in effect always matches what the server is running with.
+func (r *Reloader) Reload() (*ReloadResult, error) {
+	next, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	before := r.current.Settings()
+	after := next.Settings()
+
+	result := &ReloadResult{Applied: []string{}, RestartRequired: []string{}}
+	effective := *r.current
+	for _, key := range sortedKeys(after) {
+		if before[key] == after[key] {
+			continue
+		}
+		if isReloadable(key) {
+			settingField(&effective, key).Set(settingField(next, key))
+			result.Applied = append(result.Applied, key)
+		} else {
+			result.RestartRequired = append(result.RestartRequired, key)
+		}
+	}
+
+	now := time.Now()
+	r.reloadedAt = &now
+	if len(result.Applied) == 0 {
+		r.mu.Unlock()
+		return result, nil
+	}
+	r.current = &effective
+	subscribers := append([]func(*Config){}, r.subscribers...)
+	r.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(&effective)
+	}
+	return result, nil
+}
+
+// Settings returns every setting keyed by its path in Config, such as
+// "RateLimit.Requests". Durations are formatted as strings.
+func (c *Config) Settings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, v reflect.Value) {
+		settings[key] = settingValue(v)
+	})
+	return settings
+}
+
+// Redacted returns Settings with secrets replaced, for display.
+func (c *Config) Redacted() map[string]interface{} {
+	settings := c.Settings()
+	for key, value := range settings {
+		if !isSecret(key) {
+			continue
+		}
+		if value != "" {
+			settings[key] = redactedValue
+		}
+	}
+	return settings
+}
+
+// walkSettings calls fn for each leaf field of the struct v.
+func walkSettings(v reflect.Value, prefix string, fn func(key string, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := prefix + field.Name
+		if field.Type.Kind() == reflect.Struct {
+			walkSettings(v.Field(i), key+".", fn)
+			continue
+		}
+		fn(key, v.Field(i))
+	}
+}
+
+func settingValue(v reflect.Value) interface{} {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return v.Interface()
+}
+
+// settingField returns the field of cfg named by a Settings key.
+func settingField(cfg *Config, key string) reflect.Value {
+	v := reflect.ValueOf(cfg).Elem()
+	for _, name := range strings.Split(key, ".") {
+		v = v.FieldByName(name)
+	}
+	return v
+}
+
+func isReloadable(key string) bool {
+	for _, k := range ReloadableSettings {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecret(key string) bool {
+	if key == "Tracing.Headers" { // Usually carries collector credentials
+		return true
+	}
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
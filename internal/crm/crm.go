@@ -0,0 +1,224 @@
+// Package crm upserts contacts and deals in a CRM for calls and their quotes.
+package crm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone       = ""
+	ProviderHubSpot    = "hubspot"
+	ProviderSalesforce = "salesforce"
+)
+
+// DisplayName returns a provider's name as shown to staff.
+func DisplayName(provider string) string {
+	switch provider {
+	case ProviderHubSpot:
+		return "HubSpot"
+	case ProviderSalesforce:
+		return "Salesforce"
+	default:
+		return provider
+	}
+}
+
+// Contact is the caller as a CRM contact.
+type Contact struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Phone     string
+	Company   string
+
+	// Properties are extra CRM fields set from the field mapping, keyed by
+	// the CRM's field name.
+	Properties map[string]string
+}
+
+// Deal is a quote as a CRM deal (a Salesforce opportunity).
+type Deal struct {
+	Name      string
+	Amount    float64
+	Stage     string
+	CloseDate time.Time
+
+	// Properties are extra CRM fields set from the field mapping, keyed by
+	// the CRM's field name.
+	Properties map[string]string
+}
+
+// Client upserts records in a CRM.
+type Client interface {
+	// UpsertContact updates the contact with ID id, or when id is empty (or
+	// the contact was deleted in the CRM) the contact with the same email
+	// or phone number, creating one if there is none. It returns the
+	// contact's ID.
+	UpsertContact(ctx context.Context, id string, contact *Contact) (string, error)
+
+	// UpsertDeal updates the deal with ID id, or when id is empty (or the
+	// deal was deleted in the CRM) creates one for the contact. It returns
+	// the deal's ID.
+	UpsertDeal(ctx context.Context, id, contactID string, deal *Deal) (string, error)
+
+	// Name returns the backend name.
+	Name() string
+}
+
+// Config selects and configures a CRM.
+type Config struct {
+	Provider string // "hubspot", "salesforce", or empty to disable
+
+	// HubSpotToken is a private app access token with the contacts and
+	// deals read and write scopes.
+	HubSpotToken string
+
+	// SalesforceURL is the org's My Domain URL, e.g.
+	// https://acme.my.salesforce.com. The connected app must have the
+	// client credentials flow enabled with a run-as user.
+	SalesforceURL          string
+	SalesforceClientID     string
+	SalesforceClientSecret string
+
+	// APIURL overrides the HubSpot API base URL; used in tests.
+	APIURL string
+}
+
+// Enabled reports whether a CRM is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != ProviderNone
+}
+
+// New creates the configured client, or returns nil when none is configured.
+func New(cfg Config) (Client, error) {
+	provider := strings.ToLower(cfg.Provider)
+	switch provider {
+	case ProviderNone:
+		return nil, nil
+	case ProviderHubSpot:
+		if cfg.HubSpotToken == "" {
+			return nil, errors.New("hubspot access token is required")
+		}
+		return NewHubSpot(cfg, nil), nil
+	case ProviderSalesforce:
+		if cfg.SalesforceURL == "" || cfg.SalesforceClientID == "" || cfg.SalesforceClientSecret == "" {
+			return nil, errors.New("salesforce URL, client ID and client secret are required")
+		}
+		return NewSalesforce(cfg, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown crm provider %q", cfg.Provider)
+	}
+}
+
+// DefaultDealStages returns the deal stages of a CRM's standard sales
+// pipeline for each quote status.
+func DefaultDealStages(provider string) map[string]string {
+	switch provider {
+	case ProviderHubSpot:
+		return map[string]string{
+			"draft":          "appointmentscheduled",
+			"pending_review": "appointmentscheduled",
+			"approved":       "appointmentscheduled",
+			"sent":           "contractsent",
+			"accepted":       "closedwon",
+			"declined":       "closedlost",
+		}
+	case ProviderSalesforce:
+		return map[string]string{
+			"draft":          "Prospecting",
+			"pending_review": "Prospecting",
+			"approved":       "Prospecting",
+			"sent":           "Proposal/Price Quote",
+			"accepted":       "Closed Won",
+			"declined":       "Closed Lost",
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+// SplitName splits a full name into first and last names. CRMs require a
+// last name, so a single name is used as the last name.
+func SplitName(name string) (first, last string) {
+	name = strings.TrimSpace(name)
+	i := strings.LastIndex(name, " ")
+	if i < 0 {
+		return "", name
+	}
+	return strings.TrimSpace(name[:i]), name[i+1:]
+}
+
+// APIError is an error response from a CRM.
+type APIError struct {
+	Status  int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.Status, e.Message)
+}
+
+// isNotFound reports whether err is a 404 from the CRM.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// doJSON sends a JSON request and decodes the JSON response into out.
+// message extracts the error message from an error response body.
+func doJSON(ctx context.Context, client *http.Client, method, endpoint, token string, body, out interface{}, message func([]byte) string) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := message(data)
+		if msg == "" {
+			msg = strings.TrimSpace(string(data))
+			if len(msg) > 200 {
+				msg = msg[:200]
+			}
+		}
+		return &APIError{Status: resp.StatusCode, Message: msg}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
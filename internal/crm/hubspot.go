@@ -0,0 +1,180 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	hubSpotAPIURL = "https://api.hubapi.com"
+
+	// hubSpotDealToContact is HubSpot's association type for a deal's contact.
+	hubSpotDealToContact = 3
+)
+
+// HubSpot upserts contacts and deals with a HubSpot private app token.
+type HubSpot struct {
+	token  string
+	apiURL string
+	client *http.Client
+}
+
+// NewHubSpot creates a HubSpot client. A client with a 30 second timeout is
+// used when client is nil.
+func NewHubSpot(cfg Config, client *http.Client) *HubSpot {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = hubSpotAPIURL
+	}
+	return &HubSpot{
+		token:  cfg.HubSpotToken,
+		apiURL: strings.TrimRight(apiURL, "/"),
+		client: client,
+	}
+}
+
+// Name returns the backend name.
+func (h *HubSpot) Name() string {
+	return ProviderHubSpot
+}
+
+type hubSpotObject struct {
+	ID string `json:"id"`
+}
+
+// UpsertContact updates or creates the contact.
+func (h *HubSpot) UpsertContact(ctx context.Context, id string, contact *Contact) (string, error) {
+	props := map[string]string{}
+	setIf(props, "firstname", contact.FirstName)
+	setIf(props, "lastname", contact.LastName)
+	setIf(props, "email", contact.Email)
+	setIf(props, "phone", contact.Phone)
+	setIf(props, "company", contact.Company)
+	for k, v := range contact.Properties {
+		props[k] = v
+	}
+
+	if id == "" {
+		found, err := h.findContact(ctx, contact)
+		if err != nil {
+			return "", fmt.Errorf("hubspot contact search: %w", err)
+		}
+		id = found
+	}
+	if id != "" {
+		err := h.call(ctx, http.MethodPatch, "/crm/v3/objects/contacts/"+url.PathEscape(id), map[string]interface{}{"properties": props}, nil)
+		if err == nil {
+			return id, nil
+		}
+		if !isNotFound(err) {
+			return "", fmt.Errorf("hubspot contact update: %w", err)
+		}
+	}
+
+	var created hubSpotObject
+	if err := h.call(ctx, http.MethodPost, "/crm/v3/objects/contacts", map[string]interface{}{"properties": props}, &created); err != nil {
+		return "", fmt.Errorf("hubspot contact create: %w", err)
+	}
+	return created.ID, nil
+}
+
+// findContact returns the ID of a contact with the same email or phone
+// number, or "" if there is none.
+func (h *HubSpot) findContact(ctx context.Context, contact *Contact) (string, error) {
+	var groups []map[string]interface{}
+	for _, f := range [][2]string{{"email", contact.Email}, {"phone", contact.Phone}} {
+		if f[1] == "" {
+			continue
+		}
+		groups = append(groups, map[string]interface{}{
+			"filters": []map[string]string{{"propertyName": f[0], "operator": "EQ", "value": f[1]}},
+		})
+	}
+	if len(groups) == 0 {
+		return "", nil
+	}
+
+	var resp struct {
+		Results []hubSpotObject `json:"results"`
+	}
+	body := map[string]interface{}{"filterGroups": groups, "limit": 1}
+	if err := h.call(ctx, http.MethodPost, "/crm/v3/objects/contacts/search", body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Results) == 0 {
+		return "", nil
+	}
+	return resp.Results[0].ID, nil
+}
+
+// UpsertDeal updates or creates the deal, associating a new deal with the
+// contact.
+func (h *HubSpot) UpsertDeal(ctx context.Context, id, contactID string, deal *Deal) (string, error) {
+	props := map[string]string{
+		"dealname": deal.Name,
+		"amount":   strconv.FormatFloat(deal.Amount, 'f', 2, 64),
+	}
+	setIf(props, "dealstage", deal.Stage)
+	if !deal.CloseDate.IsZero() {
+		props["closedate"] = deal.CloseDate.UTC().Format(time.RFC3339)
+	}
+	for k, v := range deal.Properties {
+		props[k] = v
+	}
+
+	if id != "" {
+		err := h.call(ctx, http.MethodPatch, "/crm/v3/objects/deals/"+url.PathEscape(id), map[string]interface{}{"properties": props}, nil)
+		if err == nil {
+			return id, nil
+		}
+		if !isNotFound(err) {
+			return "", fmt.Errorf("hubspot deal update: %w", err)
+		}
+	}
+
+	body := map[string]interface{}{"properties": props}
+	if contactID != "" {
+		body["associations"] = []map[string]interface{}{{
+			"to": map[string]string{"id": contactID},
+			"types": []map[string]interface{}{{
+				"associationCategory": "HUBSPOT_DEFINED",
+				"associationTypeId":   hubSpotDealToContact,
+			}},
+		}}
+	}
+	var created hubSpotObject
+	if err := h.call(ctx, http.MethodPost, "/crm/v3/objects/deals", body, &created); err != nil {
+		return "", fmt.Errorf("hubspot deal create: %w", err)
+	}
+	return created.ID, nil
+}
+
+func (h *HubSpot) call(ctx context.Context, method, path string, body, out interface{}) error {
+	return doJSON(ctx, h.client, method, h.apiURL+path, h.token, body, out, hubSpotErrorMessage)
+}
+
+// hubSpotErrorMessage reads the message from a HubSpot error response.
+func hubSpotErrorMessage(data []byte) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &body) == nil {
+		return body.Message
+	}
+	return ""
+}
+
+func setIf(props map[string]string, key, value string) {
+	if value != "" {
+		props[key] = value
+	}
+}
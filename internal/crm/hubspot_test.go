@@ -0,0 +1,150 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHubSpot_UpsertContactUpdatesMatch(t *testing.T) {
+	var updated map[string]map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crm/v3/objects/contacts/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		var body struct {
+			FilterGroups []struct {
+				Filters []map[string]string `json:"filters"`
+			} `json:"filterGroups"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body.FilterGroups) != 2 || body.FilterGroups[1].Filters[0]["value"] != "+15551234567" {
+			t.Errorf("unexpected filters %+v", body.FilterGroups)
+		}
+		w.Write([]byte(`{"results":[{"id":"101"}]}`))
+	})
+	mux.HandleFunc("/crm/v3/objects/contacts/101", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&updated)
+		w.Write([]byte(`{"id":"101"}`))
+	})
+	mux.HandleFunc("/crm/v3/objects/contacts", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the matching contact to be updated")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	h := NewHubSpot(Config{HubSpotToken: "pat-1", APIURL: server.URL}, server.Client())
+	id, err := h.UpsertContact(context.Background(), "", &Contact{
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		Email:      "jane@example.com",
+		Phone:      "+15551234567",
+		Properties: map[string]string{"project_type": "Roofing"},
+	})
+	if err != nil {
+		t.Fatalf("UpsertContact() error = %v", err)
+	}
+	if id != "101" {
+		t.Errorf("expected contact 101, got %q", id)
+	}
+	props := updated["properties"]
+	if props["firstname"] != "Jane" || props["lastname"] != "Doe" || props["project_type"] != "Roofing" {
+		t.Errorf("unexpected properties %v", props)
+	}
+}
+
+func TestHubSpot_UpsertDealRecreatesDeletedDeal(t *testing.T) {
+	var created map[string]interface{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crm/v3/objects/deals/55", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status":"error","message":"Object not found"}`))
+	})
+	mux.HandleFunc("/crm/v3/objects/deals", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"56"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	h := NewHubSpot(Config{HubSpotToken: "pat-1", APIURL: server.URL}, server.Client())
+	id, err := h.UpsertDeal(context.Background(), "55", "101", &Deal{
+		Name:      "Q-1 Jane Doe",
+		Amount:    1234.5,
+		Stage:     "closedwon",
+		CloseDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("UpsertDeal() error = %v", err)
+	}
+	if id != "56" {
+		t.Errorf("expected deal 56, got %q", id)
+	}
+	props, _ := created["properties"].(map[string]interface{})
+	if props["amount"] != "1234.50" || props["dealstage"] != "closedwon" || props["closedate"] != "2026-03-01T00:00:00Z" {
+		t.Errorf("unexpected properties %v", props)
+	}
+	assoc, _ := created["associations"].([]interface{})
+	if len(assoc) != 1 {
+		t.Fatalf("expected the deal to be associated with the contact, got %v", created["associations"])
+	}
+	if to := assoc[0].(map[string]interface{})["to"].(map[string]interface{}); to["id"] != "101" {
+		t.Errorf("unexpected association %v", assoc[0])
+	}
+}
+
+func TestHubSpot_ErrorMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"error","message":"Property values were not valid"}`))
+	}))
+	defer server.Close()
+
+	h := NewHubSpot(Config{HubSpotToken: "pat-1", APIURL: server.URL}, server.Client())
+	_, err := h.UpsertContact(context.Background(), "", &Contact{LastName: "Doe"})
+	if err == nil || err.Error() != "hubspot contact create: status 400: Property values were not valid" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if c, err := New(Config{}); c != nil || err != nil {
+		t.Errorf("expected no client when disabled, got %v, %v", c, err)
+	}
+	if _, err := New(Config{Provider: ProviderHubSpot}); err == nil {
+		t.Error("expected error without a hubspot token")
+	}
+	if _, err := New(Config{Provider: ProviderSalesforce, SalesforceURL: "https://acme.my.salesforce.com"}); err == nil {
+		t.Error("expected error without salesforce credentials")
+	}
+	if _, err := New(Config{Provider: "pipedrive"}); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	c, err := New(Config{Provider: "HubSpot", HubSpotToken: "pat-1"})
+	if err != nil || c.Name() != ProviderHubSpot {
+		t.Errorf("expected hubspot client, got %v, %v", c, err)
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	tests := []struct{ name, first, last string }{
+		{"Jane Doe", "Jane", "Doe"},
+		{"Mary Ann Smith", "Mary Ann", "Smith"},
+		{"Cher", "", "Cher"},
+		{"  ", "", ""},
+	}
+	for _, tt := range tests {
+		first, last := SplitName(tt.name)
+		if first != tt.first || last != tt.last {
+			t.Errorf("SplitName(%q) = %q, %q; want %q, %q", tt.name, first, last, tt.first, tt.last)
+		}
+	}
+}
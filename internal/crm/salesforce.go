@@ -0,0 +1,251 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// salesforceAPIVersion is the REST API version used.
+const salesforceAPIVersion = "v59.0"
+
+// Salesforce upserts contacts and opportunities through a connected app
+// using the OAuth client credentials flow.
+type Salesforce struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu          sync.Mutex
+	token       string
+	instanceURL string
+}
+
+// NewSalesforce creates a Salesforce client. A client with a 30 second
+// timeout is used when client is nil.
+func NewSalesforce(cfg Config, client *http.Client) *Salesforce {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Salesforce{
+		baseURL:      strings.TrimRight(cfg.SalesforceURL, "/"),
+		clientID:     cfg.SalesforceClientID,
+		clientSecret: cfg.SalesforceClientSecret,
+		client:       client,
+	}
+}
+
+// Name returns the backend name.
+func (s *Salesforce) Name() string {
+	return ProviderSalesforce
+}
+
+type salesforceCreated struct {
+	ID string `json:"id"`
+}
+
+// UpsertContact updates or creates the contact. Salesforce contacts have no
+// company field; map "company" to a custom field to keep it.
+func (s *Salesforce) UpsertContact(ctx context.Context, id string, contact *Contact) (string, error) {
+	fields := map[string]string{}
+	setIf(fields, "FirstName", contact.FirstName)
+	setIf(fields, "LastName", contact.LastName)
+	setIf(fields, "Email", contact.Email)
+	setIf(fields, "Phone", contact.Phone)
+	for k, v := range contact.Properties {
+		fields[k] = v
+	}
+
+	if id == "" {
+		found, err := s.findContact(ctx, contact)
+		if err != nil {
+			return "", fmt.Errorf("salesforce contact search: %w", err)
+		}
+		id = found
+	}
+	if id != "" {
+		err := s.call(ctx, http.MethodPatch, "/sobjects/Contact/"+url.PathEscape(id), fields, nil)
+		if err == nil {
+			return id, nil
+		}
+		if !isNotFound(err) {
+			return "", fmt.Errorf("salesforce contact update: %w", err)
+		}
+	}
+
+	// Salesforce requires a last name on new contacts.
+	if fields["LastName"] == "" {
+		fields["LastName"] = "Unknown"
+	}
+	var created salesforceCreated
+	if err := s.call(ctx, http.MethodPost, "/sobjects/Contact", fields, &created); err != nil {
+		return "", fmt.Errorf("salesforce contact create: %w", err)
+	}
+	return created.ID, nil
+}
+
+// findContact returns the ID of a contact with the same email or phone
+// number, or "" if there is none.
+func (s *Salesforce) findContact(ctx context.Context, contact *Contact) (string, error) {
+	var conditions []string
+	if contact.Email != "" {
+		conditions = append(conditions, "Email = '"+soqlEscape(contact.Email)+"'")
+	}
+	if contact.Phone != "" {
+		conditions = append(conditions, "Phone = '"+soqlEscape(contact.Phone)+"'")
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	query := "SELECT Id FROM Contact WHERE " + strings.Join(conditions, " OR ") + " LIMIT 1"
+	var resp struct {
+		Records []struct {
+			ID string `json:"Id"`
+		} `json:"records"`
+	}
+	if err := s.call(ctx, http.MethodGet, "/query?q="+url.QueryEscape(query), nil, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Records) == 0 {
+		return "", nil
+	}
+	return resp.Records[0].ID, nil
+}
+
+// UpsertDeal updates or creates the opportunity, linking a new opportunity
+// to the contact.
+func (s *Salesforce) UpsertDeal(ctx context.Context, id, contactID string, deal *Deal) (string, error) {
+	closeDate := deal.CloseDate
+	if closeDate.IsZero() {
+		closeDate = time.Now()
+	}
+	fields := map[string]interface{}{
+		"Name":      deal.Name,
+		"Amount":    deal.Amount,
+		"CloseDate": closeDate.UTC().Format("2006-01-02"),
+	}
+	if deal.Stage != "" {
+		fields["StageName"] = deal.Stage
+	}
+	for k, v := range deal.Properties {
+		fields[k] = v
+	}
+
+	if id != "" {
+		err := s.call(ctx, http.MethodPatch, "/sobjects/Opportunity/"+url.PathEscape(id), fields, nil)
+		if err == nil {
+			return id, nil
+		}
+		if !isNotFound(err) {
+			return "", fmt.Errorf("salesforce opportunity update: %w", err)
+		}
+	}
+
+	if contactID != "" {
+		fields["ContactId"] = contactID
+	}
+	var created salesforceCreated
+	if err := s.call(ctx, http.MethodPost, "/sobjects/Opportunity", fields, &created); err != nil {
+		return "", fmt.Errorf("salesforce opportunity create: %w", err)
+	}
+	return created.ID, nil
+}
+
+// call sends a REST API request, getting a new access token first if there
+// is none or the current one has expired.
+func (s *Salesforce) call(ctx context.Context, method, path string, body, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		token, instanceURL, err := s.accessToken(ctx, attempt > 0)
+		if err != nil {
+			return err
+		}
+		endpoint := instanceURL + "/services/data/" + salesforceAPIVersion + path
+		err = doJSON(ctx, s.client, method, endpoint, token, body, out, salesforceErrorMessage)
+		var apiErr *APIError
+		if attempt == 0 && errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+			continue
+		}
+		return err
+	}
+}
+
+// accessToken returns the cached access token, or requests a new one when
+// there is none or renew is set.
+func (s *Salesforce) accessToken(ctx context.Context, renew bool) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && !renew {
+		return s.token, s.instanceURL, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/services/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("salesforce token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		InstanceURL      string `json:"instance_url"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", "", fmt.Errorf("failed to decode salesforce token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		msg := body.ErrorDescription
+		if msg == "" {
+			msg = body.Error
+		}
+		return "", "", fmt.Errorf("salesforce token request failed: status %d: %s", resp.StatusCode, msg)
+	}
+
+	s.token = body.AccessToken
+	s.instanceURL = strings.TrimRight(body.InstanceURL, "/")
+	if s.instanceURL == "" {
+		s.instanceURL = s.baseURL
+	}
+	return s.token, s.instanceURL, nil
+}
+
+// salesforceErrorMessage reads the first message from a Salesforce error
+// response.
+func salesforceErrorMessage(data []byte) string {
+	var errs []struct {
+		Message   string `json:"message"`
+		ErrorCode string `json:"errorCode"`
+	}
+	if json.Unmarshal(data, &errs) == nil && len(errs) > 0 {
+		if errs[0].ErrorCode != "" {
+			return errs[0].ErrorCode + ": " + errs[0].Message
+		}
+		return errs[0].Message
+	}
+	return ""
+}
+
+// soqlEscape escapes a value for a quoted SOQL string literal.
+func soqlEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
@@ -0,0 +1,106 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSalesforce_UpsertContactCreates(t *testing.T) {
+	var created map[string]string
+	var tokens int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "cid" {
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		w.Write([]byte(`{"access_token":"sf-1"}`))
+	})
+	mux.HandleFunc("/services/data/v59.0/query", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != `SELECT Id FROM Contact WHERE Email = 'o\'neil@example.com' LIMIT 1` {
+			t.Errorf("unexpected query %q", q)
+		}
+		w.Write([]byte(`{"totalSize":0,"records":[]}`))
+	})
+	mux.HandleFunc("/services/data/v59.0/sobjects/Contact", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sf-1" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"003xx1","success":true}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := NewSalesforce(Config{SalesforceURL: server.URL, SalesforceClientID: "cid", SalesforceClientSecret: "secret"}, server.Client())
+	id, err := s.UpsertContact(context.Background(), "", &Contact{Email: "o'neil@example.com"})
+	if err != nil {
+		t.Fatalf("UpsertContact() error = %v", err)
+	}
+	if id != "003xx1" {
+		t.Errorf("expected contact 003xx1, got %q", id)
+	}
+	if created["LastName"] != "Unknown" || created["Email"] != "o'neil@example.com" {
+		t.Errorf("unexpected contact %v", created)
+	}
+	if tokens != 1 {
+		t.Errorf("expected the token to be reused, got %d token requests", tokens)
+	}
+}
+
+func TestSalesforce_RenewsExpiredToken(t *testing.T) {
+	var tokens int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokens++
+		if tokens == 1 {
+			w.Write([]byte(`{"access_token":"old"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"new"}`))
+	})
+	mux.HandleFunc("/services/data/v59.0/sobjects/Opportunity/006xx1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`[{"message":"Session expired or invalid","errorCode":"INVALID_SESSION_ID"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := NewSalesforce(Config{SalesforceURL: server.URL, SalesforceClientID: "cid", SalesforceClientSecret: "secret"}, server.Client())
+	id, err := s.UpsertDeal(context.Background(), "006xx1", "003xx1", &Deal{Name: "Q-1", Amount: 100, Stage: "Closed Won"})
+	if err != nil {
+		t.Fatalf("UpsertDeal() error = %v", err)
+	}
+	if id != "006xx1" || tokens != 2 {
+		t.Errorf("expected opportunity 006xx1 after renewing the token, got %q with %d token requests", id, tokens)
+	}
+}
+
+func TestSalesforce_ErrorMessage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"sf-1"}`))
+	})
+	mux.HandleFunc("/services/data/v59.0/sobjects/Opportunity", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`[{"message":"Stage: bad value for restricted picklist field: Won","errorCode":"INVALID_OR_NULL_FOR_RESTRICTED_PICKLIST"}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := NewSalesforce(Config{SalesforceURL: server.URL, SalesforceClientID: "cid", SalesforceClientSecret: "secret"}, server.Client())
+	_, err := s.UpsertDeal(context.Background(), "", "", &Deal{Name: "Q-1", Stage: "Won"})
+	want := "salesforce opportunity create: status 400: INVALID_OR_NULL_FOR_RESTRICTED_PICKLIST: Stage: bad value for restricted picklist field: Won"
+	if err == nil || err.Error() != want {
+		t.Errorf("unexpected error %v", err)
+	}
+}
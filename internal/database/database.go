@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
@@ -15,6 +16,7 @@ import (
 // DB wraps the pgx connection pool with additional functionality.
 type DB struct {
 	Pool        *pgxpool.Pool
+	Replica     *pgxpool.Pool // Read replica pool; nil unless a replica DSN is configured
 	TxManager   *TxManager
 	QueryLogger *QueryLogger
 	logger      *zap.Logger
@@ -27,40 +29,24 @@ func New(ctx context.Context, cfg *config.DatabaseConfig, logger *zap.Logger) (*
 
 // NewWithQueryLogger creates a new database connection pool with optional query logging.
 func NewWithQueryLogger(ctx context.Context, cfg *config.DatabaseConfig, queryLoggerCfg *QueryLoggerConfig, logger *zap.Logger) (*DB, error) {
-	poolConfig, err := pgxpool.ParseConfig(cfg.ConnectionString())
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse database config: %w", err)
-	}
-
-	// Configure pool settings
-	poolConfig.MaxConns = int32(cfg.MaxConnections)
-	poolConfig.MinConns = int32(cfg.MaxIdleConnections)
-	poolConfig.MaxConnLifetime = cfg.ConnectionMaxLifetime
-	poolConfig.MaxConnIdleTime = 5 * time.Minute
-	poolConfig.HealthCheckPeriod = 1 * time.Minute
-
-	// Create query logger and attach to pool config
+	// Create query logger; it is attached to each pool behind the span
+	// tracer, which records nothing until tracing is enabled.
 	var queryLogger *QueryLogger
+	var nextTracer pgx.QueryTracer
 	if queryLoggerCfg != nil {
 		queryLogger = NewQueryLogger(queryLoggerCfg, logger)
-		poolConfig.ConnConfig.Tracer = queryLogger
+		nextTracer = queryLogger
 		logger.Info("query logging enabled",
 			zap.Duration("slow_threshold", queryLoggerCfg.SlowQueryThreshold),
 			zap.Duration("very_slow_threshold", queryLoggerCfg.VerySlowQueryThreshold),
 			zap.Bool("log_all_queries", queryLoggerCfg.LogAllQueries),
 		)
 	}
+	tracer := NewQuerySpanTracer(cfg.Name, nextTracer)
 
-	// Create connection pool
-	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	pool, err := newPool(ctx, cfg.ConnectionString(), cfg, tracer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create connection pool: %w", err)
-	}
-
-	// Verify connection
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, err
 	}
 
 	logger.Info("database connection established",
@@ -77,11 +63,69 @@ func NewWithQueryLogger(ctx context.Context, cfg *config.DatabaseConfig, queryLo
 	}
 	db.TxManager = NewTxManager(pool, logger)
 
+	if cfg.ReplicaDSN != "" {
+		replica, err := newPool(ctx, cfg.ReplicaDSN, cfg, tracer)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("read replica: %w", err)
+		}
+		db.Replica = replica
+		logger.Info("read replica connection established")
+	}
+
 	return db, nil
 }
 
+// newPool creates a connection pool with the configured pool settings and
+// verifies it can connect.
+func newPool(ctx context.Context, connString string, cfg *config.DatabaseConfig, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %w", err)
+	}
+
+	// Configure pool settings
+	poolConfig.MaxConns = int32(cfg.MaxConnections)
+	poolConfig.MinConns = int32(cfg.MaxIdleConnections)
+	poolConfig.MaxConnLifetime = cfg.ConnectionMaxLifetime
+	poolConfig.MaxConnIdleTime = 5 * time.Minute
+	poolConfig.HealthCheckPeriod = 1 * time.Minute
+	poolConfig.ConnConfig.Tracer = tracer
+
+	// Create connection pool
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	// Verify connection
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return pool, nil
+}
+
+// Reader returns the pool for heavy read-only queries: the read replica if
+// one is configured, otherwise the primary.
+func (db *DB) Reader() *pgxpool.Pool {
+	if db.Replica != nil {
+		return db.Replica
+	}
+	return db.Pool
+}
+
+// PingReplica checks the read replica connection.
+func (db *DB) PingReplica(ctx context.Context) error {
+	return db.Replica.Ping(ctx)
+}
+
 // Close closes the database connection pool.
 func (db *DB) Close() {
+	if db.Replica != nil {
+		db.Replica.Close()
+	}
 	if db.Pool != nil {
 		db.Pool.Close()
 		db.logger.Info("database connection closed")
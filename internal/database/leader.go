@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// leaderLockID is the Postgres advisory lock key held by the instance that
+// runs singleton background jobs.
+const leaderLockID int64 = 0x71716c6561646572 // "qqleader"
+
+// workerLeaderLockID is the advisory lock key held by the worker that runs
+// the polling workers only one instance may run. It is separate from
+// leaderLockID because any instance can lead the jobs, but only workers run
+// these.
+const workerLeaderLockID int64 = 0x7171776f726b6572 // "qqworker"
+
+// defaultLeaderCheckInterval is used when NewLeaderElector is given none.
+const defaultLeaderCheckInterval = 15 * time.Second
+
+// LeaderElector chooses one of the instances sharing a database as the
+// leader, using a session-level advisory lock held on a dedicated
+// connection. Instances that don't hold the lock retry each interval, so
+// another takes over soon after the leader stops or loses its connection.
+type LeaderElector struct {
+	pool     *pgxpool.Pool
+	interval time.Duration
+	lockID   int64
+	logger   *zap.Logger
+
+	leader atomic.Bool
+
+	mu      sync.Mutex
+	conn    *pgxpool.Conn // Holds the lock while leader
+	cancel  context.CancelFunc
+	done    chan struct{}
+	running bool
+}
+
+// NewLeaderElector creates an elector that checks for leadership every
+// interval, or every 15 seconds if interval is zero.
+func NewLeaderElector(pool *pgxpool.Pool, interval time.Duration, logger *zap.Logger) *LeaderElector {
+	if interval <= 0 {
+		interval = defaultLeaderCheckInterval
+	}
+	return &LeaderElector{
+		pool:     pool,
+		interval: interval,
+		lockID:   leaderLockID,
+		logger:   logger,
+	}
+}
+
+// NewWorkerLeaderElector creates an elector like NewLeaderElector's, but
+// for a lock of its own, so the instances that run background workers
+// choose a leader among themselves.
+func NewWorkerLeaderElector(pool *pgxpool.Pool, interval time.Duration, logger *zap.Logger) *LeaderElector {
+	e := NewLeaderElector(pool, interval, logger)
+	e.lockID = workerLeaderLockID
+	return e
+}
+
+// IsLeader reports whether this instance currently holds leadership.
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Start begins campaigning for leadership until ctx is done or Stop is
+// called.
+func (e *LeaderElector) Start(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return nil
+	}
+	e.running = true
+
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.done = make(chan struct{})
+	go e.loop(ctx)
+
+	e.logger.Info("leader election started", zap.Duration("interval", e.interval))
+	return nil
+}
+
+// Stop stops campaigning and gives up leadership, so another instance can
+// take over without waiting for this one's connection to close.
+func (e *LeaderElector) Stop(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.running {
+		e.mu.Unlock()
+		return nil
+	}
+	e.running = false
+	e.cancel()
+	done := e.done
+	e.mu.Unlock()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	e.resign(ctx)
+	e.logger.Info("leader election stopped")
+	return nil
+}
+
+func (e *LeaderElector) loop(ctx context.Context) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check tries to take leadership, or while leader, confirms the connection
+// holding the lock is still alive.
+func (e *LeaderElector) check(ctx context.Context) {
+	e.mu.Lock()
+	conn := e.conn
+	e.mu.Unlock()
+
+	if conn != nil {
+		if err := conn.Ping(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// The session, and with it the lock, may be gone
+			e.logger.Warn("lost leadership, lock connection failed", zap.Error(err))
+			e.leader.Store(false)
+			conn.Conn().Close(context.Background())
+			conn.Release()
+			e.mu.Lock()
+			e.conn = nil
+			e.mu.Unlock()
+		}
+		return
+	}
+
+	conn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Warn("failed to acquire connection for leader election", zap.Error(err))
+		}
+		return
+	}
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.lockID).Scan(&locked); err != nil || !locked {
+		if err != nil && ctx.Err() == nil {
+			e.logger.Warn("failed to try leader lock", zap.Error(err))
+		}
+		conn.Release()
+		return
+	}
+
+	e.mu.Lock()
+	e.conn = conn
+	e.mu.Unlock()
+	e.leader.Store(true)
+	e.logger.Info("became leader")
+}
+
+// resign releases the lock if this instance holds it.
+func (e *LeaderElector) resign(ctx context.Context) {
+	e.mu.Lock()
+	conn := e.conn
+	e.conn = nil
+	e.mu.Unlock()
+	if conn == nil {
+		return
+	}
+
+	e.leader.Store(false)
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", e.lockID); err != nil {
+		// Closing the connection releases the lock
+		e.logger.Warn("failed to release leader lock", zap.Error(err))
+		conn.Conn().Close(context.Background())
+	}
+	conn.Release()
+}
@@ -3,18 +3,41 @@ package database
 
 import (
 	"context"
-	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+// migrationLockID is the Postgres advisory lock key held while migrations
+// run, so replicas starting together apply each migration once.
+const migrationLockID int64 = 0x71716d6967726174 // "qqmigrat"
+
+// Migration is a schema migration read from NNN_description.up.sql and its
+// optional NNN_description.down.sql.
+type Migration struct {
+	Version  int
+	Filename string // The .up.sql file, as recorded in schema_migrations
+	Up       string
+	Down     string // Empty if there is no .down.sql file
+}
+
+// MigrationStatus is whether a migration has been applied.
+type MigrationStatus struct {
+	Version   int
+	Filename  string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
 // Migrator handles database schema migrations.
 type Migrator struct {
 	pool   *pgxpool.Pool
@@ -30,119 +53,233 @@ func NewMigrator(pool *pgxpool.Pool, logger *zap.Logger) *Migrator {
 }
 
 // MigrateFromFS runs all pending migrations from an embedded filesystem.
-func (m *Migrator) MigrateFromFS(ctx context.Context, fs embed.FS, dir string) error {
-	// Ensure schema_migrations table exists
-	if err := m.ensureMigrationsTable(ctx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
-	}
-
-	// Get applied migrations
-	applied, err := m.getAppliedMigrations(ctx)
+func (m *Migrator) MigrateFromFS(ctx context.Context, fsys fs.FS, dir string) error {
+	migrations, err := LoadMigrationsFS(fsys, dir)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return err
 	}
+	_, err = m.Up(ctx, migrations)
+	return err
+}
 
-	// Read migration files from embedded FS
-	entries, err := fs.ReadDir(dir)
+// MigrateFromDir runs all pending migrations from a directory on disk.
+func (m *Migrator) MigrateFromDir(ctx context.Context, dir string) error {
+	return m.MigrateFromFS(ctx, os.DirFS(dir), ".")
+}
+
+// LoadMigrationsFS reads the migrations in dir, sorted by version. Files
+// whose name doesn't start with a version number are ignored.
+func LoadMigrationsFS(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
 
-	// Collect and sort .up.sql files
-	var migrations []string
+	var migrations []Migration
 	for _, entry := range entries {
-		if entry.IsDir() {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
 			continue
 		}
-		name := entry.Name()
-		if strings.HasSuffix(name, ".up.sql") {
-			migrations = append(migrations, name)
+		version := extractVersion(name)
+		if version == 0 {
+			continue
 		}
+
+		up, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		down, err := fs.ReadFile(fsys, path.Join(dir, strings.TrimSuffix(name, ".up.sql")+".down.sql"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Filename: name,
+			Up:       string(up),
+			Down:     string(down),
+		})
 	}
-	sort.Strings(migrations)
 
-	// Apply pending migrations
-	for _, filename := range migrations {
-		version := extractVersion(filename)
-		if version == 0 {
-			m.logger.Warn("skipping migration with invalid version", zap.String("file", filename))
-			continue
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", migrations[i-1].Filename, migrations[i].Filename)
 		}
+	}
+	return migrations, nil
+}
 
-		if applied[version] {
-			continue
+// LoadMigrations reads the migrations in a directory on disk.
+func LoadMigrations(dir string) ([]Migration, error) {
+	return LoadMigrationsFS(os.DirFS(dir), ".")
+}
+
+// Up applies the pending migrations in order and returns them.
+func (m *Migrator) Up(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.getAppliedMigrations(ctx, conn)
+		if err != nil {
+			return err
 		}
 
-		m.logger.Info("applying migration", zap.String("file", filename), zap.Int("version", version))
+		for _, migration := range pendingMigrations(migrations, applied) {
+			m.logger.Info("applying migration", zap.String("file", migration.Filename), zap.Int("version", migration.Version))
+
+			if err := m.applyMigration(ctx, conn, migration); err != nil {
+				return fmt.Errorf("failed to apply migration %s: %w", migration.Filename, err)
+			}
+			done = append(done, migration)
+
+			m.logger.Info("migration applied successfully", zap.String("file", migration.Filename))
+		}
+		return nil
+	})
+	return done, err
+}
 
-		content, err := fs.ReadFile(filepath.Join(dir, filename))
+// Down rolls back the latest steps applied migrations, newest first, and
+// returns them.
+func (m *Migrator) Down(ctx context.Context, migrations []Migration, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.getAppliedMigrations(ctx, conn)
 		if err != nil {
-			return fmt.Errorf("failed to read migration %s: %w", filename, err)
+			return err
 		}
 
-		if err := m.applyMigration(ctx, version, filename, string(content)); err != nil {
-			return fmt.Errorf("failed to apply migration %s: %w", filename, err)
+		rollback, err := rollbackMigrations(migrations, applied, steps)
+		if err != nil {
+			return err
 		}
+		for _, migration := range rollback {
+			m.logger.Info("rolling back migration", zap.String("file", migration.Filename), zap.Int("version", migration.Version))
 
-		m.logger.Info("migration applied successfully", zap.String("file", filename))
-	}
+			if err := m.revertMigration(ctx, conn, migration); err != nil {
+				return fmt.Errorf("failed to roll back migration %s: %w", migration.Filename, err)
+			}
+			done = append(done, migration)
 
-	return nil
+			m.logger.Info("migration rolled back successfully", zap.String("file", migration.Filename))
+		}
+		return nil
+	})
+	return done, err
 }
 
-// MigrateFromDir runs all pending migrations from a directory on disk.
-func (m *Migrator) MigrateFromDir(ctx context.Context, dir string) error {
-	// Ensure schema_migrations table exists
-	if err := m.ensureMigrationsTable(ctx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+// Pending returns the migrations Up would apply.
+func (m *Migrator) Pending(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	applied, err := m.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return pendingMigrations(migrations, applied), nil
+}
 
-	// Get applied migrations
-	applied, err := m.getAppliedMigrations(ctx)
+// PendingRollback returns the migrations Down would roll back.
+func (m *Migrator) PendingRollback(ctx context.Context, migrations []Migration, steps int) ([]Migration, error) {
+	applied, err := m.appliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return nil, err
 	}
+	return rollbackMigrations(migrations, applied, steps)
+}
 
-	// Read migration files
-	pattern := filepath.Join(dir, "*.up.sql")
-	files, err := filepath.Glob(pattern)
+// Status reports which migrations have been applied. Versions recorded in
+// schema_migrations with no file are included so they aren't overlooked.
+func (m *Migrator) Status(ctx context.Context, migrations []Migration) ([]MigrationStatus, error) {
+	applied, err := m.appliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to glob migrations: %w", err)
+		return nil, err
 	}
-	sort.Strings(files)
 
-	// Apply pending migrations
-	for _, path := range files {
-		filename := filepath.Base(path)
-		version := extractVersion(filename)
-		if version == 0 {
-			m.logger.Warn("skipping migration with invalid version", zap.String("file", filename))
-			continue
-		}
-
-		if applied[version] {
-			continue
+	var statuses []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Filename: migration.Filename}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.appliedAt
+			delete(applied, migration.Version)
 		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range applied {
+		appliedAt := record.appliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Filename:  record.filename,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+		})
+	}
 
-		m.logger.Info("applying migration", zap.String("file", filename), zap.Int("version", version))
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
 
-		content, err := readFile(path)
+// Force records migrations up to and including version as applied and the
+// rest as not applied, without running any SQL. Use it to bring
+// schema_migrations back in line after fixing the schema by hand.
+func (m *Migrator) Force(ctx context.Context, migrations []Migration, version int) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		tx, err := conn.Begin(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to read migration %s: %w", filename, err)
+			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
+		defer tx.Rollback(ctx)
 
-		if err := m.applyMigration(ctx, version, filename, string(content)); err != nil {
-			return fmt.Errorf("failed to apply migration %s: %w", filename, err)
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version > $1", version); err != nil {
+			return fmt.Errorf("failed to clear migrations: %w", err)
+		}
+		for _, migration := range migrations {
+			if migration.Version > version {
+				break
+			}
+			_, err := tx.Exec(ctx,
+				"INSERT INTO schema_migrations (version, filename) VALUES ($1, $2) ON CONFLICT (version) DO NOTHING",
+				migration.Version, migration.Filename,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to record migration %s: %w", migration.Filename, err)
+			}
 		}
 
-		m.logger.Info("migration applied successfully", zap.String("file", filename))
+		m.logger.Warn("forced migration version", zap.Int("version", version))
+		return tx.Commit(ctx)
+	})
+}
+
+// withLock runs fn on a connection holding the migration advisory lock,
+// waiting for any other migrator to finish first.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
 	}
+	defer conn.Release()
 
-	return nil
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			// Closing the connection releases the lock
+			m.logger.Warn("failed to release migration lock", zap.Error(err))
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	if err := m.ensureMigrationsTable(ctx, conn); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return fn(conn)
 }
 
 // ensureMigrationsTable creates the schema_migrations table if it doesn't exist.
-func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
+func (m *Migrator) ensureMigrationsTable(ctx context.Context, conn *pgxpool.Conn) error {
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version INTEGER PRIMARY KEY,
@@ -150,47 +287,66 @@ func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
 			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 		)
 	`
-	_, err := m.pool.Exec(ctx, query)
+	_, err := conn.Exec(ctx, query)
 	return err
 }
 
-// getAppliedMigrations returns a map of applied migration versions.
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]bool, error) {
-	rows, err := m.pool.Query(ctx, "SELECT version FROM schema_migrations")
+// appliedMigration is a row of schema_migrations.
+type appliedMigration struct {
+	filename  string
+	appliedAt time.Time
+}
+
+// appliedMigrations reads schema_migrations for the read-only commands,
+// waiting for any running migration to finish so the answer is settled.
+func (m *Migrator) appliedMigrations(ctx context.Context) (map[int]appliedMigration, error) {
+	var applied map[int]appliedMigration
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		var err error
+		applied, err = m.getAppliedMigrations(ctx, conn)
+		return err
+	})
+	return applied, err
+}
+
+// getAppliedMigrations returns the applied migrations by version.
+func (m *Migrator) getAppliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.Query(ctx, "SELECT version, filename, applied_at FROM schema_migrations")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 	defer rows.Close()
 
-	applied := make(map[int]bool)
+	applied := make(map[int]appliedMigration)
 	for rows.Next() {
 		var version int
-		if err := rows.Scan(&version); err != nil {
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.filename, &record.appliedAt); err != nil {
 			return nil, err
 		}
-		applied[version] = true
+		applied[version] = record
 	}
 
 	return applied, rows.Err()
 }
 
 // applyMigration runs a single migration in a transaction.
-func (m *Migrator) applyMigration(ctx context.Context, version int, filename, sql string) error {
-	tx, err := m.pool.Begin(ctx)
+func (m *Migrator) applyMigration(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	tx, err := conn.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
 	// Execute migration SQL
-	if _, err := tx.Exec(ctx, sql); err != nil {
+	if _, err := tx.Exec(ctx, migration.Up); err != nil {
 		return fmt.Errorf("migration SQL failed: %w", err)
 	}
 
 	// Record migration
 	_, err = tx.Exec(ctx,
 		"INSERT INTO schema_migrations (version, filename) VALUES ($1, $2)",
-		version, filename,
+		migration.Version, migration.Filename,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to record migration: %w", err)
@@ -199,6 +355,52 @@ func (m *Migrator) applyMigration(ctx context.Context, version int, filename, sq
 	return tx.Commit(ctx)
 }
 
+// revertMigration runs a migration's down SQL in a transaction.
+func (m *Migrator) revertMigration(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, migration.Down); err != nil {
+		return fmt.Errorf("migration SQL failed: %w", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("failed to record rollback: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// pendingMigrations returns the migrations not yet applied, in order.
+func pendingMigrations(migrations []Migration, applied map[int]appliedMigration) []Migration {
+	var pending []Migration
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// rollbackMigrations returns the latest steps applied migrations, newest
+// first. Every one must have down SQL.
+func rollbackMigrations(migrations []Migration, applied map[int]appliedMigration, steps int) ([]Migration, error) {
+	var rollback []Migration
+	for i := len(migrations) - 1; i >= 0 && len(rollback) < steps; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %s has no down migration", migration.Filename)
+		}
+		rollback = append(rollback, migration)
+	}
+	return rollback, nil
+}
+
 // extractVersion extracts the version number from a migration filename.
 // Expected format: NNN_description.up.sql (e.g., 001_initial.up.sql)
 func extractVersion(filename string) int {
@@ -212,8 +414,3 @@ func extractVersion(filename string) int {
 	}
 	return version
 }
-
-// readFile reads file content from disk (for non-embedded migrations).
-func readFile(path string) ([]byte, error) {
-	return os.ReadFile(path)
-}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrationsFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/002_calls.up.sql":   {Data: []byte("CREATE TABLE calls ();")},
+		"migrations/002_calls.down.sql": {Data: []byte("DROP TABLE calls;")},
+		"migrations/001_users.up.sql":   {Data: []byte("CREATE TABLE users ();")},
+		"migrations/003_seed.up.sql":    {Data: []byte("INSERT INTO users DEFAULT VALUES;")},
+		"migrations/README.md":          {Data: []byte("notes")},
+		"migrations/draft.up.sql":       {Data: []byte("SELECT 1;")},
+	}
+
+	migrations, err := LoadMigrationsFS(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("LoadMigrationsFS() error = %v", err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("loaded %d migrations, expected 3", len(migrations))
+	}
+	for i, version := range []int{1, 2, 3} {
+		if migrations[i].Version != version {
+			t.Errorf("migrations[%d].Version = %d, expected %d", i, migrations[i].Version, version)
+		}
+	}
+	if migrations[1].Filename != "002_calls.up.sql" || migrations[1].Down != "DROP TABLE calls;" {
+		t.Errorf("migrations[1] = %+v, expected the calls migration with its down SQL", migrations[1])
+	}
+	if migrations[2].Down != "" {
+		t.Errorf("migration without a down file has Down = %q", migrations[2].Down)
+	}
+}
+
+func TestLoadMigrationsFS_DuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"001_users.up.sql": {Data: []byte("SELECT 1;")},
+		"001_calls.up.sql": {Data: []byte("SELECT 1;")},
+	}
+	if _, err := LoadMigrationsFS(fsys, "."); err == nil {
+		t.Error("LoadMigrationsFS() succeeded with two migrations sharing a version")
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+	applied := map[int]appliedMigration{1: {}, 3: {}}
+
+	pending := pendingMigrations(migrations, applied)
+	if len(pending) != 1 || pending[0].Version != 2 {
+		t.Errorf("pending = %+v, expected version 2", pending)
+	}
+}
+
+func TestRollbackMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Filename: "001_users.up.sql", Down: "DROP TABLE users;"},
+		{Version: 2, Filename: "002_calls.up.sql", Down: "DROP TABLE calls;"},
+		{Version: 3, Filename: "003_quotes.up.sql", Down: "DROP TABLE quotes;"},
+	}
+	applied := map[int]appliedMigration{1: {}, 2: {}}
+
+	rollback, err := rollbackMigrations(migrations, applied, 1)
+	if err != nil {
+		t.Fatalf("rollbackMigrations() error = %v", err)
+	}
+	if len(rollback) != 1 || rollback[0].Version != 2 {
+		t.Errorf("rollback = %+v, expected the latest applied migration", rollback)
+	}
+
+	rollback, err = rollbackMigrations(migrations, applied, 5)
+	if err != nil {
+		t.Fatalf("rollbackMigrations() error = %v", err)
+	}
+	if len(rollback) != 2 || rollback[0].Version != 2 || rollback[1].Version != 1 {
+		t.Errorf("rollback = %+v, expected versions 2 then 1", rollback)
+	}
+
+	migrations[1].Down = ""
+	if _, err := rollbackMigrations(migrations, applied, 1); err == nil {
+		t.Error("rollbackMigrations() succeeded for a migration without down SQL")
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jkindrix/quickquote/internal/tracing"
+)
+
+// querySpanKey is the context key for a query's span between trace calls.
+type querySpanKey struct{}
+
+// QuerySpanTracer records a span for each query, then hands the query to
+// the next tracer, if any, so spans and query logging can be used together.
+type QuerySpanTracer struct {
+	next     pgx.QueryTracer
+	database string
+}
+
+// NewQuerySpanTracer creates a tracer for queries against the named
+// database. next may be nil.
+func NewQuerySpanTracer(database string, next pgx.QueryTracer) *QuerySpanTracer {
+	return &QuerySpanTracer{next: next, database: database}
+}
+
+// TraceQueryStart is called at the beginning of query execution.
+// It implements pgx.QueryTracer interface.
+func (t *QuerySpanTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, span := tracing.Start(ctx, operation+" "+t.database,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.name", t.database),
+			attribute.String("db.operation", operation),
+			attribute.String("db.statement", truncateSQL(data.SQL, 1000)),
+		),
+	)
+	ctx = context.WithValue(ctx, querySpanKey{}, span)
+
+	if t.next != nil {
+		ctx = t.next.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+// TraceQueryEnd is called at the end of query execution.
+// It implements pgx.QueryTracer interface.
+func (t *QuerySpanTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if t.next != nil {
+		t.next.TraceQueryEnd(ctx, conn, data)
+	}
+
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		tracing.RecordError(span, data.Err)
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// queryOperation returns the SQL command of a query, such as SELECT, for
+// naming its span without the statement's variable parts.
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// recordingQueryTracer records the calls it receives.
+type recordingQueryTracer struct {
+	started, ended bool
+	sawStartValue  bool
+}
+
+type recordingKey struct{}
+
+func (r *recordingQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	r.started = true
+	return context.WithValue(ctx, recordingKey{}, true)
+}
+
+func (r *recordingQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	r.ended = true
+	r.sawStartValue, _ = ctx.Value(recordingKey{}).(bool)
+}
+
+func TestQuerySpanTracer_ForwardsToNext(t *testing.T) {
+	next := &recordingQueryTracer{}
+	tracer := NewQuerySpanTracer("quickquote", next)
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	if !next.started || !next.ended {
+		t.Errorf("next tracer started = %v, ended = %v", next.started, next.ended)
+	}
+	if !next.sawStartValue {
+		t.Error("next tracer should receive the context it returned from TraceQueryStart")
+	}
+}
+
+func TestQuerySpanTracer_WithoutNext(t *testing.T) {
+	tracer := NewQuerySpanTracer("quickquote", nil)
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT * FROM calls", "SELECT"},
+		{"\n\t  insert into calls (id) values ($1)", "INSERT"},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", "WITH"},
+		{"", "QUERY"},
+	}
+	for _, tt := range tests {
+		if got := queryOperation(tt.sql); got != tt.want {
+			t.Errorf("queryOperation(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAccountingCodeLen bounds item and tax codes in an accounting mapping.
+const MaxAccountingCodeLen = 100
+
+// AccountingConnection is the OAuth grant for the connected accounting
+// company or organisation. There is at most one per provider.
+type AccountingConnection struct {
+	Provider     string     `json:"provider"`
+	TenantID     string     `json:"tenant_id"` // QuickBooks realm ID or Xero tenant ID
+	AccessToken  string     `json:"-"`
+	RefreshToken string     `json:"-"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	ConnectedBy  *uuid.UUID `json:"connected_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// AccountingMapping maps quotes onto the accounting system's items and tax
+// codes.
+type AccountingMapping struct {
+	Provider string `json:"provider"`
+
+	// DefaultItem is the item code used for lines on quotes whose project
+	// type has no item of its own; empty leaves lines without an item.
+	DefaultItem string `json:"default_item"`
+
+	// ProjectTypeItems maps a call's project type to an item code.
+	ProjectTypeItems map[string]string `json:"project_type_items"`
+
+	// TaxCode is applied to lines on quotes with a tax rate, and
+	// ExemptTaxCode to lines on quotes without one.
+	TaxCode       string `json:"tax_code"`
+	ExemptTaxCode string `json:"exempt_tax_code"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the mapping's codes and normalizes project types to lower
+// case.
+func (m *AccountingMapping) Validate() error {
+	m.DefaultItem = strings.TrimSpace(m.DefaultItem)
+	m.TaxCode = strings.TrimSpace(m.TaxCode)
+	m.ExemptTaxCode = strings.TrimSpace(m.ExemptTaxCode)
+	for field, code := range map[string]string{"default_item": m.DefaultItem, "tax_code": m.TaxCode, "exempt_tax_code": m.ExemptTaxCode} {
+		if len(code) > MaxAccountingCodeLen {
+			return NewValidationError(field, fmt.Sprintf("must be at most %d characters", MaxAccountingCodeLen))
+		}
+	}
+
+	items := make(map[string]string, len(m.ProjectTypeItems))
+	for projectType, item := range m.ProjectTypeItems {
+		projectType = strings.ToLower(strings.TrimSpace(projectType))
+		item = strings.TrimSpace(item)
+		if projectType == "" || item == "" {
+			continue
+		}
+		if len(item) > MaxAccountingCodeLen {
+			return NewValidationError("project_type_items", fmt.Sprintf("item for %q must be at most %d characters", projectType, MaxAccountingCodeLen))
+		}
+		items[projectType] = item
+	}
+	m.ProjectTypeItems = items
+	return nil
+}
+
+// ItemFor returns the item code for a project type.
+func (m *AccountingMapping) ItemFor(projectType string) string {
+	if item, ok := m.ProjectTypeItems[strings.ToLower(strings.TrimSpace(projectType))]; ok {
+		return item
+	}
+	return m.DefaultItem
+}
+
+// TaxCodeFor returns the tax code for a quote's tax rate.
+func (m *AccountingMapping) TaxCodeFor(taxRate float64) string {
+	if taxRate > 0 {
+		return m.TaxCode
+	}
+	return m.ExemptTaxCode
+}
+
+// AccountingSyncStatus is the outcome of pushing a quote.
+type AccountingSyncStatus string
+
+// Accounting sync statuses.
+const (
+	AccountingSyncSucceeded AccountingSyncStatus = "succeeded"
+	AccountingSyncFailed    AccountingSyncStatus = "failed"
+)
+
+// AccountingSync is one attempt to push a quote to the accounting system.
+type AccountingSync struct {
+	ID             uuid.UUID            `json:"id"`
+	CallID         uuid.UUID            `json:"call_id"`
+	Provider       string               `json:"provider"`
+	Document       string               `json:"document"` // "estimate" or "invoice"
+	Status         AccountingSyncStatus `json:"status"`
+	ExternalID     string               `json:"external_id,omitempty"`
+	ExternalNumber string               `json:"external_number,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	ActorID        *uuid.UUID           `json:"actor_id,omitempty"` // Nil for automatic syncs
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+// Succeeded reports whether the quote was pushed.
+func (s *AccountingSync) Succeeded() bool {
+	return s.Status == AccountingSyncSucceeded
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAccountingMapping_Validate(t *testing.T) {
+	m := &AccountingMapping{
+		DefaultItem:      " 1 ",
+		ProjectTypeItems: map[string]string{" Roofing ": " 7 ", "fencing": "", "": "9"},
+		TaxCode:          "TAX",
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if m.DefaultItem != "1" || len(m.ProjectTypeItems) != 1 || m.ProjectTypeItems["roofing"] != "7" {
+		t.Errorf("expected trimmed codes and lower-case project types, got %+v", m)
+	}
+
+	if got := m.ItemFor("ROOFING"); got != "7" {
+		t.Errorf("ItemFor(ROOFING) = %q, want 7", got)
+	}
+	if got := m.ItemFor("Decks"); got != "1" {
+		t.Errorf("ItemFor(Decks) = %q, want the default item", got)
+	}
+	if m.TaxCodeFor(8.25) != "TAX" || m.TaxCodeFor(0) != "" {
+		t.Errorf("unexpected tax codes %q, %q", m.TaxCodeFor(8.25), m.TaxCodeFor(0))
+	}
+
+	long := &AccountingMapping{TaxCode: strings.Repeat("x", MaxAccountingCodeLen+1)}
+	if err := long.Validate(); err == nil {
+		t.Error("expected error for an overlong tax code")
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsFilter selects the calls that call analytics are computed over.
+type AnalyticsFilter struct {
+	From     time.Time // Inclusive lower bound on created_at
+	To       time.Time // Exclusive upper bound on created_at
+	Provider string    // Only calls handled by this voice provider; empty for all
+
+	// Only calls tagged with this sentiment or intent; empty for all.
+	Sentiment CallSentiment
+	Intent    CallIntent
+}
+
+// CallKPIs are aggregate call and quote metrics over a time range.
+type CallKPIs struct {
+	TotalCalls     int `json:"total_calls"`
+	CompletedCalls int `json:"completed_calls"`
+	NoAnswerCalls  int `json:"no_answer_calls"`
+	FailedCalls    int `json:"failed_calls"`
+	QuotedCalls    int `json:"quoted_calls"` // Calls with a generated quote
+
+	// AnswerRate is the share of calls that reached a final status that
+	// were answered rather than going unanswered.
+	AnswerRate float64 `json:"answer_rate"`
+	// QuoteConversionRate is the share of completed calls that produced a quote.
+	QuoteConversionRate    float64 `json:"quote_conversion_rate"`
+	AverageDurationSeconds float64 `json:"average_duration_seconds"` // Completed calls only
+
+	// PricedQuotes counts quotes submitted for review with a total; the
+	// average and total quote value are taken over these, converted to
+	// QuoteValueCurrency. Quotes in a currency with no exchange rate are
+	// counted in UnconvertedQuotes instead.
+	PricedQuotes       int              `json:"priced_quotes"`
+	AverageQuoteValue  float64          `json:"average_quote_value"`
+	TotalQuoteValue    float64          `json:"total_quote_value"`
+	QuoteValueCurrency string           `json:"quote_value_currency"`
+	UnconvertedQuotes  int              `json:"unconverted_quotes,omitempty"`
+	QuoteValues        []*CurrencyTotal `json:"quote_values"` // Quote totals in the currency each was priced in
+}
+
+// CalculateRates fills in the rates from the call counts.
+func (k *CallKPIs) CalculateRates() {
+	k.AnswerRate = ratio(k.CompletedCalls, k.CompletedCalls+k.NoAnswerCalls)
+	k.QuoteConversionRate = ratio(k.QuotedCalls, k.CompletedCalls)
+}
+
+// DailyCallVolume counts the calls created on one day (UTC).
+type DailyCallVolume struct {
+	Date      time.Time `json:"date"`
+	Calls     int       `json:"calls"`
+	Completed int       `json:"completed"`
+	Quoted    int       `json:"quoted"`
+}
+
+// PromptCallMetrics summarizes the calls placed with one prompt. Calls
+// without a known prompt are grouped under a nil PromptID.
+type PromptCallMetrics struct {
+	PromptID               *uuid.UUID `json:"prompt_id"`
+	PromptName             string     `json:"prompt_name"`
+	Calls                  int        `json:"calls"`
+	CompletedCalls         int        `json:"completed_calls"`
+	AverageDurationSeconds float64    `json:"average_duration_seconds"` // Completed calls only
+}
+
+// CallTagCounts counts tagged calls by each sentiment, intent and urgency,
+// and calls by disposition whether tagged or not. Every known value is
+// present, with zero for values no call has.
+type CallTagCounts struct {
+	TaggedCalls int                     `json:"tagged_calls"`
+	Sentiment   map[CallSentiment]int   `json:"sentiment"`
+	Intent      map[CallIntent]int      `json:"intent"`
+	Urgency     map[CallUrgency]int     `json:"urgency"`
+	Disposition map[CallDisposition]int `json:"disposition"`
+}
+
+// NewCallTagCounts returns counts of zero for every known tag value.
+func NewCallTagCounts() *CallTagCounts {
+	counts := &CallTagCounts{
+		Sentiment:   make(map[CallSentiment]int, len(CallSentiments)),
+		Intent:      make(map[CallIntent]int, len(CallIntents)),
+		Urgency:     make(map[CallUrgency]int, len(CallUrgencies)),
+		Disposition: make(map[CallDisposition]int, len(CallDispositions)),
+	}
+	for _, v := range CallSentiments {
+		counts.Sentiment[v] = 0
+	}
+	for _, v := range CallIntents {
+		counts.Intent[v] = 0
+	}
+	for _, v := range CallUrgencies {
+		counts.Urgency[v] = 0
+	}
+	for _, v := range CallDispositions {
+		counts.Disposition[v] = 0
+	}
+	return counts
+}
+
+// AnalyticsDimension is what daily analytics rollups break calls down by.
+type AnalyticsDimension string
+
+const (
+	// AnalyticsDimensionPrompt groups calls by the prompt that handled
+	// them, including the prompt of an inbound experiment variant.
+	AnalyticsDimensionPrompt AnalyticsDimension = "prompt"
+	// AnalyticsDimensionNumber groups inbound calls by the number called.
+	AnalyticsDimensionNumber AnalyticsDimension = "number"
+)
+
+// DimensionMetrics compares the calls of one prompt or inbound number over
+// a range of days, totaled from daily rollups.
+type DimensionMetrics struct {
+	Key            string `json:"key"`            // Prompt ID or number called; empty for calls without a known prompt
+	Name           string `json:"name,omitempty"` // Prompt name
+	Calls          int    `json:"calls"`
+	CompletedCalls int    `json:"completed_calls"`
+	NoAnswerCalls  int    `json:"no_answer_calls"`
+	QuotedCalls    int    `json:"quoted_calls"`    // Calls with a generated quote
+	AcceptedQuotes int    `json:"accepted_quotes"` // Quotes the customer accepted
+
+	// CompletedDurationSeconds is the total length of completed calls.
+	CompletedDurationSeconds int64 `json:"-"`
+
+	AnswerRate             float64 `json:"answer_rate"`
+	QuoteRate              float64 `json:"quote_rate"`      // Quoted calls over completed calls
+	AcceptanceRate         float64 `json:"acceptance_rate"` // Accepted quotes over quoted calls
+	AverageDurationSeconds float64 `json:"average_duration_seconds"`
+}
+
+// CalculateRates fills in the rates and average duration from the totals.
+func (m *DimensionMetrics) CalculateRates() {
+	m.AnswerRate = ratio(m.CompletedCalls, m.CompletedCalls+m.NoAnswerCalls)
+	m.QuoteRate = ratio(m.QuotedCalls, m.CompletedCalls)
+	m.AcceptanceRate = ratio(m.AcceptedQuotes, m.QuotedCalls)
+	if m.CompletedCalls > 0 {
+		m.AverageDurationSeconds = float64(m.CompletedDurationSeconds) / float64(m.CompletedCalls)
+	}
+}
+
+// ratio returns n/d, or 0 when d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
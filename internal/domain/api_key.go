@@ -0,0 +1,307 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix is prepended to every generated API key so they are easy to
+// recognize in Authorization headers and secret scanners.
+const APIKeyPrefix = "qq_"
+
+// apiKeySecretBytes is the number of random bytes in an API key secret.
+const apiKeySecretBytes = 32
+
+// apiKeyDisplayPrefixLen is how many characters of the key are kept for display.
+const apiKeyDisplayPrefixLen = 11
+
+// DefaultAPIKeyRateLimit is the default number of requests per minute per key.
+const DefaultAPIKeyRateLimit = 60
+
+// API key scopes. Scopes follow the "<resource>:<access>" convention where
+// access is "read" or "write". A write scope implies read on the same resource.
+const (
+	ScopeAll                 = "*"
+	ScopeCallsRead           = "calls:read"
+	ScopeCallsWrite          = "calls:write"
+	ScopeQuotesRead          = "quotes:read"
+	ScopeQuotesWrite         = "quotes:write"
+	ScopePromptsRead         = "prompts:read"
+	ScopePromptsWrite        = "prompts:write"
+	ScopeBlandRead           = "bland:read"
+	ScopeBlandWrite          = "bland:write"
+	ScopeCustomersRead       = "customers:read"
+	ScopeCustomersWrite      = "customers:write"
+	ScopeQuoteJobsRead       = "quote-jobs:read"
+	ScopeQuoteJobsWrite      = "quote-jobs:write"
+	ScopeUsersRead           = "users:read"
+	ScopeUsersWrite          = "users:write"
+	ScopeExperimentsRead     = "experiments:read"
+	ScopeExperimentsWrite    = "experiments:write"
+	ScopeAnalyticsRead       = "analytics:read"
+	ScopeWebhooksRead        = "webhooks:read"
+	ScopeWebhooksWrite       = "webhooks:write"
+	ScopeEventsRead          = "events:read"
+	ScopeBudgetRead          = "budget:read"
+	ScopeBudgetWrite         = "budget:write"
+	ScopeComplianceRead      = "compliance:read"
+	ScopeComplianceWrite     = "compliance:write"
+	ScopePrivacyWrite        = "privacy:write"
+	ScopeAuditRead           = "audit:read"
+	ScopeTagsRead            = "tags:read"
+	ScopeTagsWrite           = "tags:write"
+	ScopeSavedViewsRead      = "saved-views:read"
+	ScopeSavedViewsWrite     = "saved-views:write"
+	ScopeNotificationsWrite  = "notifications:write"
+	ScopeScheduleRead        = "schedule:read"
+	ScopeScheduleWrite       = "schedule:write"
+	ScopeQuoteTemplatesRead  = "quote-templates:read"
+	ScopeQuoteTemplatesWrite = "quote-templates:write"
+	ScopeRetentionRead       = "retention:read"
+	ScopeRetentionWrite      = "retention:write"
+	ScopeFeatureFlagsRead    = "feature-flags:read"
+	ScopeFeatureFlagsWrite   = "feature-flags:write"
+	ScopeProviderKeysRead    = "provider-keys:read"
+	ScopeProviderKeysWrite   = "provider-keys:write"
+	ScopeSystemRead          = "system:read"
+	ScopeSystemWrite         = "system:write"
+	ScopeTagViewsRead        = "tag-views:read"
+	ScopeTagViewsWrite       = "tag-views:write"
+)
+
+// KnownAPIKeyScopes lists all scopes that may be granted to an API key.
+var KnownAPIKeyScopes = []string{
+	ScopeAll,
+	ScopeCallsRead,
+	ScopeCallsWrite,
+	ScopeQuotesRead,
+	ScopeQuotesWrite,
+	ScopePromptsRead,
+	ScopePromptsWrite,
+	ScopeBlandRead,
+	ScopeBlandWrite,
+	ScopeCustomersRead,
+	ScopeCustomersWrite,
+	ScopeQuoteJobsRead,
+	ScopeQuoteJobsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeExperimentsRead,
+	ScopeExperimentsWrite,
+	ScopeAnalyticsRead,
+	ScopeWebhooksRead,
+	ScopeWebhooksWrite,
+	ScopeEventsRead,
+	ScopeBudgetRead,
+	ScopeBudgetWrite,
+	ScopeComplianceRead,
+	ScopeComplianceWrite,
+	ScopePrivacyWrite,
+	ScopeAuditRead,
+	ScopeTagsRead,
+	ScopeTagsWrite,
+	ScopeSavedViewsRead,
+	ScopeSavedViewsWrite,
+	ScopeNotificationsWrite,
+	ScopeScheduleRead,
+	ScopeScheduleWrite,
+	ScopeQuoteTemplatesRead,
+	ScopeQuoteTemplatesWrite,
+	ScopeRetentionRead,
+	ScopeRetentionWrite,
+	ScopeFeatureFlagsRead,
+	ScopeFeatureFlagsWrite,
+	ScopeProviderKeysRead,
+	ScopeProviderKeysWrite,
+	ScopeSystemRead,
+	ScopeSystemWrite,
+	ScopeTagViewsRead,
+	ScopeTagViewsWrite,
+}
+
+// APIKey represents a bearer credential for machine-to-machine API access.
+type APIKey struct {
+	ID                 uuid.UUID  `json:"id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	Name               string     `json:"name"`
+	Prefix             string     `json:"prefix"`
+	KeyHash            string     `json:"-"` // Never serialize the key hash
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// NewAPIKey creates a new API key for a user and returns it along with the
+// plaintext secret. The plaintext is only available at creation time.
+func NewAPIKey(userID uuid.UUID, name string, scopes []string, rateLimitPerMinute int) (*APIKey, string, error) {
+	plaintext, err := GenerateAPIKeySecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if rateLimitPerMinute <= 0 {
+		rateLimitPerMinute = DefaultAPIKeyRateLimit
+	}
+
+	now := time.Now().UTC()
+	key := &APIKey{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		Name:               name,
+		Prefix:             plaintext[:apiKeyDisplayPrefixLen],
+		KeyHash:            HashAPIKey(plaintext),
+		Scopes:             NormalizeScopes(scopes),
+		RateLimitPerMinute: rateLimitPerMinute,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	return key, plaintext, nil
+}
+
+// GenerateAPIKeySecret generates a new random plaintext API key.
+func GenerateAPIKeySecret() (string, error) {
+	b := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash of a plaintext key.
+// API keys are high-entropy, so a fast hash is sufficient for storage.
+func HashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeAPIKey reports whether a token has the API key format.
+func LooksLikeAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix) && len(token) == len(APIKeyPrefix)+apiKeySecretBytes*2
+}
+
+// Rotate replaces the key secret and returns the new plaintext.
+func (k *APIKey) Rotate() (string, error) {
+	plaintext, err := GenerateAPIKeySecret()
+	if err != nil {
+		return "", err
+	}
+	k.Prefix = plaintext[:apiKeyDisplayPrefixLen]
+	k.KeyHash = HashAPIKey(plaintext)
+	k.UpdatedAt = time.Now().UTC()
+	return plaintext, nil
+}
+
+// Revoke permanently disables the key.
+func (k *APIKey) Revoke() {
+	now := time.Now().UTC()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// IsRevoked returns true if the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired returns true if the key has an expiry in the past.
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().UTC().After(*k.ExpiresAt)
+}
+
+// IsActive returns true if the key may be used to authenticate.
+func (k *APIKey) IsActive() bool {
+	return !k.IsRevoked() && !k.IsExpired()
+}
+
+// HasScope reports whether the key grants the required scope.
+// "*" grants everything, "<resource>:*" grants all access to a resource,
+// and "<resource>:write" implies "<resource>:read".
+func (k *APIKey) HasScope(required string) bool {
+	resource, access, _ := strings.Cut(required, ":")
+	for _, s := range k.Scopes {
+		if s == ScopeAll || s == required {
+			return true
+		}
+		sr, sa, _ := strings.Cut(s, ":")
+		if sr != resource {
+			continue
+		}
+		if sa == "*" || (sa == "write" && access == "read") {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeScopes trims, lowercases, and de-duplicates scopes.
+func NormalizeScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+	return result
+}
+
+// IsValidScope reports whether a scope is known or is a resource wildcard
+// for a known resource.
+func IsValidScope(scope string) bool {
+	for _, known := range KnownAPIKeyScopes {
+		if scope == known {
+			return true
+		}
+	}
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok || access != "*" {
+		return false
+	}
+	for _, known := range KnownAPIKeyScopes {
+		if strings.HasPrefix(known, resource+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// APIDocsResources are the API's own description (the OpenAPI document and
+// Swagger UI), which any key may read.
+var APIDocsResources = []string{"openapi.json", "docs"}
+
+// GraphQLResource is the GraphQL endpoint, /api/graphql. It only reads,
+// and checks the scope of each resource a query reads as it resolves it.
+const GraphQLResource = "graphql"
+
+// RequiredAPIScope derives the scope needed for an API request from its
+// resource (the first path segment under /api/v1) and HTTP method. Reading
+// the API docs or using GraphQL needs no scope, which is reported as "".
+func RequiredAPIScope(resource, method string) string {
+	if resource == GraphQLResource {
+		return ""
+	}
+	access := "write"
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		access = "read"
+		for _, docs := range APIDocsResources {
+			if resource == docs {
+				return ""
+			}
+		}
+	}
+	return resource + ":" + access
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	userID := uuid.New()
+
+	key, plaintext, err := NewAPIKey(userID, "ci", []string{" Calls:Read ", "calls:read", ""}, 0)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	if !strings.HasPrefix(plaintext, APIKeyPrefix) {
+		t.Errorf("expected plaintext to start with %q, got %q", APIKeyPrefix, plaintext)
+	}
+	if !LooksLikeAPIKey(plaintext) {
+		t.Error("expected generated key to look like an API key")
+	}
+	if key.KeyHash != HashAPIKey(plaintext) {
+		t.Error("expected KeyHash to be the hash of the plaintext")
+	}
+	if !strings.HasPrefix(plaintext, key.Prefix) {
+		t.Errorf("expected Prefix %q to be a prefix of the plaintext", key.Prefix)
+	}
+	if key.UserID != userID {
+		t.Errorf("expected UserID %s, got %s", userID, key.UserID)
+	}
+	if key.RateLimitPerMinute != DefaultAPIKeyRateLimit {
+		t.Errorf("expected default rate limit %d, got %d", DefaultAPIKeyRateLimit, key.RateLimitPerMinute)
+	}
+	if len(key.Scopes) != 1 || key.Scopes[0] != ScopeCallsRead {
+		t.Errorf("expected normalized scopes [calls:read], got %v", key.Scopes)
+	}
+}
+
+func TestAPIKey_Rotate(t *testing.T) {
+	key, original, err := NewAPIKey(uuid.New(), "ci", []string{ScopeAll}, 10)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	oldHash := key.KeyHash
+
+	rotated, err := key.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if rotated == original {
+		t.Error("expected a new secret after rotation")
+	}
+	if key.KeyHash == oldHash {
+		t.Error("expected KeyHash to change after rotation")
+	}
+	if key.KeyHash != HashAPIKey(rotated) {
+		t.Error("expected KeyHash to match the rotated secret")
+	}
+}
+
+func TestAPIKey_IsActive(t *testing.T) {
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		key      APIKey
+		expected bool
+	}{
+		{"active", APIKey{}, true},
+		{"future expiry", APIKey{ExpiresAt: &future}, true},
+		{"expired", APIKey{ExpiresAt: &past}, false},
+		{"revoked", APIKey{RevokedAt: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsActive(); got != tt.expected {
+				t.Errorf("IsActive() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		required string
+		expected bool
+	}{
+		{"exact match", []string{ScopeCallsRead}, ScopeCallsRead, true},
+		{"wildcard", []string{ScopeAll}, ScopePromptsWrite, true},
+		{"resource wildcard", []string{"calls:*"}, ScopeCallsWrite, true},
+		{"write implies read", []string{ScopeCallsWrite}, ScopeCallsRead, true},
+		{"read does not imply write", []string{ScopeCallsRead}, ScopeCallsWrite, false},
+		{"other resource", []string{ScopePromptsWrite}, ScopeCallsRead, false},
+		{"no scopes", nil, ScopeCallsRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := &APIKey{Scopes: tt.scopes}
+			if got := key.HasScope(tt.required); got != tt.expected {
+				t.Errorf("HasScope(%q) = %v, expected %v", tt.required, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsValidScope(t *testing.T) {
+	tests := []struct {
+		scope    string
+		expected bool
+	}{
+		{ScopeAll, true},
+		{ScopeCallsRead, true},
+		{"calls:*", true},
+		{"calls:delete", false},
+		{"unknown:*", false},
+		{"calls", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := IsValidScope(tt.scope); got != tt.expected {
+				t.Errorf("IsValidScope(%q) = %v, expected %v", tt.scope, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRequiredAPIScope(t *testing.T) {
+	if got := RequiredAPIScope("calls", http.MethodGet); got != ScopeCallsRead {
+		t.Errorf("expected %q, got %q", ScopeCallsRead, got)
+	}
+	if got := RequiredAPIScope("calls", http.MethodPost); got != ScopeCallsWrite {
+		t.Errorf("expected %q, got %q", ScopeCallsWrite, got)
+	}
+	if got := RequiredAPIScope("openapi.json", http.MethodGet); got != "" {
+		t.Errorf("expected no scope to read the API docs, got %q", got)
+	}
+	if got := RequiredAPIScope(GraphQLResource, http.MethodPost); got != "" {
+		t.Errorf("expected GraphQL to check scopes per field, got %q", got)
+	}
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// nanpZoneAreaCodes lists the US area codes in each time zone. Area codes
+// that straddle a zone line appear under every zone they cover, so callers
+// can require a time to suit all of them. The list is for calling-window
+// checks, so it errs on the side of listing too many zones.
+var nanpZoneAreaCodes = map[string]string{
+	"America/New_York": `
+		201 202 203 207 212 215 216 220 223 229 231 234 239 240 248 252 260 267 269 270 272 276 283
+		301 302 304 305 313 315 317 321 326 330 332 336 339 347 351 352 364 380 386
+		401 404 407 410 412 413 419 423 434 440 443 445 448 463 470 475 478 484
+		502 508 513 516 517 518 540 551 561 567 570 571 574 582 585 586
+		603 606 607 609 610 614 616 617 631 640 646 656 667 678 679 680 681 689
+		703 704 706 716 717 718 724 727 732 734 740 743 754 757 762 765 770 771 772 774 781 786
+		802 803 804 810 812 813 814 826 828 835 838 839 843 845 848 850 854 856 857 859 860 862 863 864 865 878
+		904 906 908 910 912 914 917 919 929 930 934 937 941 943 947 948 954 959 973 978 980 984 989`,
+	"America/Chicago": `
+		205 210 214 217 218 219 224 225 228 251 254 256 262 270 274 281
+		308 309 312 314 316 318 319 320 325 327 331 334 337 346 361 364
+		402 405 409 414 417 430 432 447 464 469 479
+		501 504 507 512 515 531 534 539 557 563 572 573 580
+		601 605 608 612 615 618 620 629 630 636 641 651 659 660 662 682
+		701 708 712 713 715 726 730 731 737 763 769 773 779 785
+		806 812 815 816 817 830 832 847 850 870 872
+		901 903 906 913 918 920 930 931 936 938 940 945 952 956 972 975 979 985`,
+	"America/Denver": `
+		208 303 307 308 385 406 432 435 458 505 541 575 605 620 701 719 720 785 801 915 928 970 983 986`,
+	"America/Phoenix": `
+		480 520 602 623 928`,
+	"America/Los_Angeles": `
+		206 208 209 213 253 279 310 323 341 350 360 408 415 424 425 442 458
+		503 509 510 530 541 559 562 564 619 626 628 650 657 661 669
+		702 707 714 725 747 760 775 805 818 820 840 858 909 916 925 949 951 971 986`,
+	"America/Anchorage":   `907`,
+	"Pacific/Honolulu":    `808`,
+	"America/Puerto_Rico": `787 939`,
+}
+
+// nanpUnknownZones are assumed for North American numbers whose area code
+// is not listed, such as Canadian and new overlay codes.
+var nanpUnknownZones = []string{"America/New_York", "America/Chicago", "America/Denver", "America/Phoenix", "America/Los_Angeles"}
+
+var (
+	areaCodeZonesOnce sync.Once
+	areaCodeZones     map[string][]*time.Location
+	unknownNANPZones  []*time.Location
+)
+
+// LocationsForNumber returns the time zones a North American number may be
+// in, judged by its area code. Unlisted area codes get every continental US
+// zone. Numbers outside the North American Numbering Plan return nil.
+func LocationsForNumber(phoneNumber string) []*time.Location {
+	areaCode, ok := nanpAreaCode(phoneNumber)
+	if !ok {
+		return nil
+	}
+
+	areaCodeZonesOnce.Do(loadAreaCodeZones)
+	if locs, ok := areaCodeZones[areaCode]; ok {
+		return locs
+	}
+	return unknownNANPZones
+}
+
+// nanpAreaCode returns the area code of a +1 number, or of a bare 10-digit
+// number, which is assumed to be North American.
+func nanpAreaCode(phoneNumber string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+
+	switch {
+	case len(digits) == 11 && digits[0] == '1':
+		return digits[1:4], true
+	case len(digits) == 10 && !strings.HasPrefix(strings.TrimSpace(phoneNumber), "+"):
+		return digits[:3], true
+	default:
+		return "", false
+	}
+}
+
+// loadAreaCodeZones resolves the zone names in nanpZoneAreaCodes. Zones the
+// system has no data for are skipped.
+func loadAreaCodeZones() {
+	areaCodeZones = make(map[string][]*time.Location)
+	for zone, codes := range nanpZoneAreaCodes {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			continue
+		}
+		for _, code := range strings.Fields(codes) {
+			areaCodeZones[code] = append(areaCodeZones[code], loc)
+		}
+	}
+	for _, zone := range nanpUnknownZones {
+		if loc, err := time.LoadLocation(zone); err == nil {
+			unknownNANPZones = append(unknownNANPZones, loc)
+		}
+	}
+}
+
+// nanpRegionAreaCodes lists the area codes of each US state and territory,
+// and of each Canadian province, by postal abbreviation.
+var nanpRegionAreaCodes = map[string]string{
+	"AL": `205 251 256 334 659 938`,
+	"AK": `907`,
+	"AZ": `480 520 602 623 928`,
+	"AR": `327 479 501 870`,
+	"CA": `209 213 279 310 323 341 350 369 408 415 424 442 510 530 559 562 619 626 628 650 657 661 669
+		707 714 747 760 805 818 820 831 840 858 909 916 925 949 951`,
+	"CO": `303 719 720 970 983`,
+	"CT": `203 475 860 959`,
+	"DE": `302`,
+	"DC": `202 771`,
+	"FL": `239 305 321 352 386 407 448 561 656 689 727 754 772 786 813 850 863 904 941 954`,
+	"GA": `229 404 470 478 678 706 762 770 912 943`,
+	"HI": `808`,
+	"ID": `208 986`,
+	"IL": `217 224 309 312 331 447 464 618 630 708 730 773 779 815 847 872`,
+	"IN": `219 260 317 463 574 765 812 930`,
+	"IA": `319 515 563 641 712`,
+	"KS": `316 620 785 913`,
+	"KY": `270 364 502 606 859`,
+	"LA": `225 318 337 504 985`,
+	"ME": `207`,
+	"MD": `227 240 301 410 443 667`,
+	"MA": `339 351 413 508 617 774 781 857 978`,
+	"MI": `231 248 269 313 517 586 616 679 734 810 906 947 989`,
+	"MN": `218 320 507 612 651 763 952`,
+	"MS": `228 601 662 769`,
+	"MO": `314 417 557 573 636 660 816 975`,
+	"MT": `406`,
+	"NE": `308 402 531`,
+	"NV": `702 725 775`,
+	"NH": `603`,
+	"NJ": `201 551 609 640 732 848 856 862 908 973`,
+	"NM": `505 575`,
+	"NY": `212 315 332 347 363 516 518 585 607 631 646 680 716 718 838 845 914 917 929 934`,
+	"NC": `252 336 472 704 743 828 910 919 980 984`,
+	"ND": `701`,
+	"OH": `216 220 234 283 326 330 380 419 436 440 513 567 614 740 937`,
+	"OK": `405 539 572 580 918`,
+	"OR": `458 503 541 971`,
+	"PA": `215 223 267 272 412 445 484 570 582 610 717 724 814 835 878`,
+	"RI": `401`,
+	"SC": `803 821 839 843 854 864`,
+	"SD": `605`,
+	"TN": `423 615 629 731 865 901 931`,
+	"TX": `210 214 254 281 325 346 361 409 430 432 469 512 682 713 726 737 806 817 830 832
+		903 915 936 940 945 956 972 979`,
+	"UT": `385 435 801`,
+	"VT": `802`,
+	"VA": `276 434 540 571 686 703 757 804 826 948`,
+	"WA": `206 253 360 425 509 564`,
+	"WV": `304 681`,
+	"WI": `262 274 353 414 534 608 715 920`,
+	"WY": `307`,
+	"PR": `787 939`,
+	"AB": `368 403 587 780 825`,
+	"BC": `236 250 604 672 778`,
+	"MB": `204 431`,
+	"NB": `506`,
+	"NL": `709`,
+	"NS": `782 902`,
+	"ON": `226 249 289 343 365 416 437 519 548 613 647 705 807 905`,
+	"QC": `367 418 438 450 514 579 581 819 873`,
+	"SK": `306 639`,
+}
+
+var (
+	areaCodeRegionsOnce sync.Once
+	areaCodeRegions     map[string]string
+)
+
+// AreaCodeForNumber returns the area code of a North American number.
+func AreaCodeForNumber(phoneNumber string) (string, bool) {
+	return nanpAreaCode(phoneNumber)
+}
+
+// RegionForNumber returns the postal abbreviation of the state, territory or
+// province a North American number's area code belongs to, or "" if it is
+// not known.
+func RegionForNumber(phoneNumber string) string {
+	areaCode, ok := nanpAreaCode(phoneNumber)
+	if !ok {
+		return ""
+	}
+	areaCodeRegionsOnce.Do(loadAreaCodeRegions)
+	return areaCodeRegions[areaCode]
+}
+
+// IsKnownRegion reports whether region is a state, territory or province
+// abbreviation that RegionForNumber can return.
+func IsKnownRegion(region string) bool {
+	_, ok := nanpRegionAreaCodes[region]
+	return ok
+}
+
+func loadAreaCodeRegions() {
+	areaCodeRegions = make(map[string]string)
+	for region, codes := range nanpRegionAreaCodes {
+		for _, code := range strings.Fields(codes) {
+			areaCodeRegions[code] = region
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupStatus represents where a database backup is in being taken.
+type BackupStatus string
+
+const (
+	BackupStatusRunning   BackupStatus = "running"   // pg_dump is writing it
+	BackupStatusCompleted BackupStatus = "completed" // Stored and ready to download
+	BackupStatusFailed    BackupStatus = "failed"    // Nothing was stored
+)
+
+// BackupTrigger records what started a backup.
+type BackupTrigger string
+
+const (
+	BackupTriggerScheduled BackupTrigger = "scheduled"
+	BackupTriggerManual    BackupTrigger = "manual"
+)
+
+// Backup is a logical backup of the database, in pg_dump's custom format,
+// kept in backup storage.
+type Backup struct {
+	ID      uuid.UUID     `json:"id"`
+	Status  BackupStatus  `json:"status"`
+	Trigger BackupTrigger `json:"trigger"`
+
+	// Storage
+	StorageBackend string `json:"storage_backend"`
+	StorageKey     string `json:"storage_key"`
+	SizeBytes      int64  `json:"size_bytes"`
+	SHA256         string `json:"sha256,omitempty"` // Hex digest of the stored file
+
+	Error       *string    `json:"error,omitempty"`
+	RequestedBy *uuid.UUID `json:"requested_by,omitempty"` // Nil for scheduled backups
+
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"` // Nil keeps the backup forever
+}
+
+// NewBackup creates a running backup stored under a key named after its
+// start time. A retention of zero keeps it forever.
+func NewBackup(trigger BackupTrigger, backend string, retention time.Duration, requestedBy *uuid.UUID) *Backup {
+	now := time.Now().UTC()
+	id := uuid.New()
+	b := &Backup{
+		ID:             id,
+		Status:         BackupStatusRunning,
+		Trigger:        trigger,
+		StorageBackend: backend,
+		StorageKey:     "backups/quickquote-" + now.Format("20060102T150405Z") + "-" + id.String()[:8] + ".dump",
+		RequestedBy:    requestedBy,
+		StartedAt:      now,
+	}
+	if retention > 0 {
+		expires := now.Add(retention)
+		b.ExpiresAt = &expires
+	}
+	return b
+}
+
+// IsAvailable reports whether the backup can be downloaded.
+func (b *Backup) IsAvailable() bool {
+	return b.Status == BackupStatusCompleted
+}
+
+// MarkCompleted records the stored file's size and digest.
+func (b *Backup) MarkCompleted(size int64, sha256 string) {
+	now := time.Now().UTC()
+	b.Status = BackupStatusCompleted
+	b.SizeBytes = size
+	b.SHA256 = sha256
+	b.CompletedAt = &now
+}
+
+// MarkFailed records why the backup failed.
+func (b *Backup) MarkFailed(err error) {
+	now := time.Now().UTC()
+	msg := err.Error()
+	b.Status = BackupStatusFailed
+	b.Error = &msg
+	b.CompletedAt = &now
+}
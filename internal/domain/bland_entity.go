@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BlandEntityKind identifies a type of Bland entity kept in the local cache.
+type BlandEntityKind string
+
+// Cached Bland entity kinds.
+const (
+	BlandEntityVoice         BlandEntityKind = "voices"
+	BlandEntityPersona       BlandEntityKind = "personas"
+	BlandEntityPathway       BlandEntityKind = "pathways"
+	BlandEntityKnowledgeBase BlandEntityKind = "knowledge_bases"
+	BlandEntityPhoneNumber   BlandEntityKind = "phone_numbers"
+)
+
+// BlandEntityKinds lists every cached kind in sync order.
+var BlandEntityKinds = []BlandEntityKind{
+	BlandEntityVoice,
+	BlandEntityPersona,
+	BlandEntityPathway,
+	BlandEntityKnowledgeBase,
+	BlandEntityPhoneNumber,
+}
+
+// IsValid reports whether k is a cached kind.
+func (k BlandEntityKind) IsValid() bool {
+	for _, kind := range BlandEntityKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// BlandEntity is a cached copy of one Bland entity. Payload holds the entity
+// as the Bland API returned it.
+type BlandEntity struct {
+	Kind     BlandEntityKind `json:"kind"`
+	BlandID  string          `json:"bland_id"`
+	Position int             `json:"position"`
+	Payload  json.RawMessage `json:"payload"`
+	SyncedAt time.Time       `json:"synced_at"`
+}
+
+// BlandSyncState records how fresh the cache of one kind is.
+type BlandSyncState struct {
+	Kind        BlandEntityKind `json:"kind"`
+	ETag        string          `json:"etag"`
+	ItemCount   int             `json:"item_count"`
+	SyncedAt    *time.Time      `json:"synced_at,omitempty"`
+	AttemptedAt time.Time       `json:"attempted_at"`
+	LastError   string          `json:"last_error,omitempty"`
+}
+
+// HasSynced reports whether a sync of the kind has ever succeeded.
+func (s *BlandSyncState) HasSynced() bool {
+	return s != nil && s.SyncedAt != nil
+}
+
+// IsStale reports whether the cache of the kind may be out of date because
+// the last sync failed.
+func (s *BlandSyncState) IsStale() bool {
+	return s.HasSynced() && s.LastError != ""
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BlandWriteOperation identifies a change to Bland that can be queued while
+// Bland is unreachable.
+type BlandWriteOperation string
+
+// Queueable Bland write operations.
+const (
+	BlandWriteCreateKnowledgeBase BlandWriteOperation = "knowledge_base.create"
+	BlandWriteUpdateKnowledgeBase BlandWriteOperation = "knowledge_base.update"
+	BlandWriteDeleteKnowledgeBase BlandWriteOperation = "knowledge_base.delete"
+	BlandWriteBlockNumber         BlandWriteOperation = "number.block"
+	BlandWriteUnblockNumber       BlandWriteOperation = "number.unblock"
+	BlandWriteConfigureInbound    BlandWriteOperation = "number.configure_inbound"
+	BlandWriteSetUsageLimit       BlandWriteOperation = "usage_limit.set"
+)
+
+// Kind returns the cached entity kind the operation changes, or "" if it
+// changes nothing that is cached.
+func (o BlandWriteOperation) Kind() BlandEntityKind {
+	switch o {
+	case BlandWriteCreateKnowledgeBase, BlandWriteUpdateKnowledgeBase, BlandWriteDeleteKnowledgeBase:
+		return BlandEntityKnowledgeBase
+	case BlandWriteConfigureInbound:
+		return BlandEntityPhoneNumber
+	}
+	return ""
+}
+
+// BlandWriteStatus is the state of a queued write.
+type BlandWriteStatus string
+
+// Queued write statuses.
+const (
+	BlandWriteStatusPending BlandWriteStatus = "pending"
+	BlandWriteStatusApplied BlandWriteStatus = "applied"
+	BlandWriteStatusFailed  BlandWriteStatus = "failed"
+)
+
+// MaxBlandWriteAttempts is how many times Bland may reject a queued write
+// before it is given up on.
+const MaxBlandWriteAttempts = 5
+
+// BlandWrite is a change made while Bland was unreachable, kept until it can
+// be replayed. Payload holds the operation's arguments.
+type BlandWrite struct {
+	ID        uuid.UUID           `json:"id"`
+	Operation BlandWriteOperation `json:"operation"`
+	TargetID  string              `json:"target_id,omitempty"`
+	Payload   json.RawMessage     `json:"payload,omitempty"`
+	Status    BlandWriteStatus    `json:"status"`
+	Attempts  int                 `json:"attempts"`
+	LastError string              `json:"last_error,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	AppliedAt *time.Time          `json:"applied_at,omitempty"`
+}
+
+// NewBlandWrite creates a pending write.
+func NewBlandWrite(op BlandWriteOperation, targetID string, payload json.RawMessage) *BlandWrite {
+	now := time.Now().UTC()
+	return &BlandWrite{
+		ID:        uuid.New(),
+		Operation: op,
+		TargetID:  targetID,
+		Payload:   payload,
+		Status:    BlandWriteStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// MarkApplied records that Bland accepted the write.
+func (w *BlandWrite) MarkApplied() {
+	now := time.Now().UTC()
+	w.Status = BlandWriteStatusApplied
+	w.LastError = ""
+	w.AppliedAt = &now
+	w.UpdatedAt = now
+}
+
+// MarkRejected records that Bland rejected the write, giving up once it has
+// been tried MaxBlandWriteAttempts times.
+func (w *BlandWrite) MarkRejected(errMsg string) {
+	w.Attempts++
+	w.LastError = errMsg
+	if w.Attempts >= MaxBlandWriteAttempts {
+		w.Status = BlandWriteStatusFailed
+	}
+	w.UpdatedAt = time.Now().UTC()
+}
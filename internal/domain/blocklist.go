@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minBlockPrefixDigits keeps prefix blocks from covering whole countries by
+// accident: "+1*" would block every North American number.
+const minBlockPrefixDigits = 3
+
+// BlockDirection is which calls a block applies to.
+type BlockDirection string
+
+const (
+	BlockDirectionInbound  BlockDirection = "inbound"
+	BlockDirectionOutbound BlockDirection = "outbound"
+	BlockDirectionBoth     BlockDirection = "both"
+)
+
+// BlockedNumber is an entry in the local mirror of the blocklist. PhoneNumber
+// is either a single number in E.164 form or a prefix ending in "*", such as
+// "+1900*". Single numbers are also blocked at Bland, which only knows exact
+// numbers; prefixes are enforced here alone.
+type BlockedNumber struct {
+	ID          uuid.UUID      `json:"id"`
+	PhoneNumber string         `json:"phone_number"`
+	Direction   BlockDirection `json:"direction"`
+	Reason      string         `json:"reason,omitempty"`
+	BlandID     string         `json:"bland_id,omitempty"` // Bland's ID for the block, once it has one
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// NewBlockedNumber creates a blocklist entry. An empty direction blocks both
+// directions.
+func NewBlockedNumber(phoneNumber string, direction BlockDirection, reason string) *BlockedNumber {
+	if direction == "" {
+		direction = BlockDirectionBoth
+	}
+	now := time.Now().UTC()
+	return &BlockedNumber{
+		ID:          uuid.New(),
+		PhoneNumber: phoneNumber,
+		Direction:   direction,
+		Reason:      strings.TrimSpace(reason),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ParseBlockPrefix parses a prefix block such as "+1 900*". It returns
+// false if s is not a prefix block, and an error if it is one but is not
+// usable.
+func ParseBlockPrefix(s string) (string, bool, error) {
+	s = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(s))
+	if !strings.HasSuffix(s, "*") {
+		return "", false, nil
+	}
+	digits := strings.TrimPrefix(strings.TrimSuffix(s, "*"), "+")
+	if len(digits) < minBlockPrefixDigits || strings.Trim(digits, "0123456789") != "" {
+		return "", true, NewValidationError("phone_number", "a prefix block must be at least 3 digits followed by *, such as +1900*")
+	}
+	return "+" + digits + "*", true, nil
+}
+
+// Validate validates the entry.
+func (b *BlockedNumber) Validate() error {
+	if b.PhoneNumber == "" {
+		return NewValidationError("phone_number", "phone number is required")
+	}
+	switch b.Direction {
+	case BlockDirectionInbound, BlockDirectionOutbound, BlockDirectionBoth:
+	default:
+		return NewValidationError("direction", "direction must be inbound, outbound or both")
+	}
+	return nil
+}
+
+// IsPrefix reports whether the entry blocks every number with a prefix.
+func (b *BlockedNumber) IsPrefix() bool {
+	return strings.HasSuffix(b.PhoneNumber, "*")
+}
+
+// Blocks reports whether the entry blocks calls with phoneNumber, in E.164
+// form, in the given direction.
+func (b *BlockedNumber) Blocks(phoneNumber string, direction BlockDirection) bool {
+	if b.Direction != BlockDirectionBoth && b.Direction != direction {
+		return false
+	}
+	if b.IsPrefix() {
+		return strings.HasPrefix(phoneNumber, strings.TrimSuffix(b.PhoneNumber, "*"))
+	}
+	return phoneNumber == b.PhoneNumber
+}
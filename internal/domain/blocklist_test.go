@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestParseBlockPrefix(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantPrefix bool
+		wantErr    bool
+	}{
+		{"+1900*", "+1900*", true, false},
+		{"1 (900) *", "+1900*", true, false},
+		{"+15551234567", "", false, false},
+		{"+1*", "", true, true},
+		{"+1-9x0*", "", true, true},
+	}
+	for _, tt := range tests {
+		got, isPrefix, err := ParseBlockPrefix(tt.in)
+		if (err != nil) != tt.wantErr || isPrefix != tt.wantPrefix || (err == nil && got != tt.want) {
+			t.Errorf("ParseBlockPrefix(%q) = %q, %v, %v; want %q, %v, error %v", tt.in, got, isPrefix, err, tt.want, tt.wantPrefix, tt.wantErr)
+		}
+	}
+}
+
+func TestBlockedNumber_Blocks(t *testing.T) {
+	prefix := NewBlockedNumber("+1900*", "", "premium rate")
+	if !prefix.Blocks("+19005551234", BlockDirectionInbound) || !prefix.Blocks("+19005551234", BlockDirectionOutbound) {
+		t.Error("a prefix block should block matching numbers both ways")
+	}
+	if prefix.Blocks("+18005551234", BlockDirectionInbound) {
+		t.Error("a prefix block should not block other numbers")
+	}
+
+	inbound := NewBlockedNumber("+15551234567", BlockDirectionInbound, "")
+	if !inbound.Blocks("+15551234567", BlockDirectionInbound) {
+		t.Error("an inbound block should block the caller")
+	}
+	if inbound.Blocks("+15551234567", BlockDirectionOutbound) {
+		t.Error("an inbound block should not block calls to the number")
+	}
+	if inbound.Blocks("+155512345678", BlockDirectionInbound) {
+		t.Error("a single number should not match longer numbers")
+	}
+}
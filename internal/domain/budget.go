@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetOverride lets calls be placed past the monthly hard cap until it
+// expires or is revoked.
+type BudgetOverride struct {
+	ID        uuid.UUID  `json:"id"`
+	Reason    string     `json:"reason"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewBudgetOverride creates an override lasting until expiresAt.
+func NewBudgetOverride(reason string, expiresAt time.Time, createdBy *uuid.UUID) *BudgetOverride {
+	return &BudgetOverride{
+		ID:        uuid.New(),
+		Reason:    strings.TrimSpace(reason),
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt.UTC(),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Validate checks that the override has a reason and has not already expired.
+func (o *BudgetOverride) Validate(now time.Time) error {
+	if o.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if !o.ExpiresAt.After(now) {
+		return errors.New("until must be in the future")
+	}
+	return nil
+}
+
+// IsActive reports whether the override is in effect at now.
+func (o *BudgetOverride) IsActive(now time.Time) bool {
+	return o.RevokedAt == nil && o.ExpiresAt.After(now)
+}
+
+// BudgetMonthStart returns the start of the UTC calendar month containing t,
+// which is when month-to-date spend resets.
+func BudgetMonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
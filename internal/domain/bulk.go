@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// BulkRecordType is the kind of record a bulk action applies to. A quote's
+// ID is its call's ID.
+type BulkRecordType string
+
+const (
+	BulkRecordCall  BulkRecordType = "call"
+	BulkRecordQuote BulkRecordType = "quote"
+)
+
+// BulkAction is an action applied to many calls or quotes at once.
+type BulkAction string
+
+const (
+	BulkActionArchive    BulkAction = "archive"     // Archive the records
+	BulkActionTag        BulkAction = "tag"         // Apply a tag to the records
+	BulkActionRerunQuote BulkAction = "rerun_quote" // Queue quote generation again
+	BulkActionExport     BulkAction = "export"      // Download the records as CSV or XLSX
+	BulkActionAssign     BulkAction = "assign"      // Assign the records to a user, or unassign them
+)
+
+// BulkActions lists every bulk action.
+var BulkActions = []BulkAction{
+	BulkActionArchive,
+	BulkActionTag,
+	BulkActionRerunQuote,
+	BulkActionExport,
+	BulkActionAssign,
+}
+
+// ParseBulkAction parses a bulk action name.
+func ParseBulkAction(s string) (BulkAction, error) {
+	action := BulkAction(strings.TrimSpace(s))
+	for _, a := range BulkActions {
+		if action == a {
+			return action, nil
+		}
+	}
+	names := make([]string, len(BulkActions))
+	for i, a := range BulkActions {
+		names[i] = string(a)
+	}
+	return "", fmt.Errorf("invalid action %q, expected one of %s", s, strings.Join(names, ", "))
+}
+
+// BulkItemStatus is the outcome of a bulk action for one record.
+type BulkItemStatus string
+
+const (
+	BulkItemApplied  BulkItemStatus = "applied"  // The action was applied
+	BulkItemQueued   BulkItemStatus = "queued"   // The work was queued, as for re-running a quote
+	BulkItemFailed   BulkItemStatus = "failed"   // The action can't be applied to this record
+	BulkItemRejected BulkItemStatus = "rejected" // Not applied because other records failed
+)
+
+// BulkItemResult reports what a bulk action did to one record.
+type BulkItemResult struct {
+	ID     uuid.UUID      `json:"id"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+	JobID  *uuid.UUID     `json:"job_id,omitempty"` // Quote job queued for the record
+}
+
+// BulkResult reports a bulk action record by record. Either every record is
+// checked and the action applied to all of them, or none is changed and
+// Applied is false.
+type BulkResult struct {
+	Action    BulkAction       `json:"action"`
+	Applied   bool             `json:"applied"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+// Fail records that the action can't be applied to the record at index i.
+func (r *BulkResult) Fail(i int, err error) {
+	r.Items[i].Status = BulkItemFailed
+	r.Items[i].Error = err.Error()
+}
+
+// HasFailures reports whether the action can't be applied to some record.
+func (r *BulkResult) HasFailures() bool {
+	for _, item := range r.Items {
+		if item.Status == BulkItemFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Reject marks every record that didn't fail as left unchanged.
+func (r *BulkResult) Reject() {
+	for i := range r.Items {
+		if r.Items[i].Status != BulkItemFailed {
+			r.Items[i].Status = BulkItemRejected
+		}
+	}
+	r.Applied = false
+	r.count()
+}
+
+// Complete marks every record that didn't fail with status.
+func (r *BulkResult) Complete(status BulkItemStatus) {
+	for i := range r.Items {
+		if r.Items[i].Status == "" {
+			r.Items[i].Status = status
+		}
+	}
+	r.Applied = true
+	r.count()
+}
+
+func (r *BulkResult) count() {
+	r.Succeeded, r.Failed = 0, 0
+	for _, item := range r.Items {
+		switch item.Status {
+		case BulkItemApplied, BulkItemQueued:
+			r.Succeeded++
+		case BulkItemFailed:
+			r.Failed++
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseBulkAction(t *testing.T) {
+	for _, action := range BulkActions {
+		if got, err := ParseBulkAction(" " + string(action) + " "); err != nil || got != action {
+			t.Errorf("ParseBulkAction(%q) = %q, %v", action, got, err)
+		}
+	}
+	if _, err := ParseBulkAction("delete"); err == nil {
+		t.Error("ParseBulkAction(\"delete\") succeeded, want an error")
+	}
+}
+
+func TestBulkResult(t *testing.T) {
+	newResult := func() *BulkResult {
+		return &BulkResult{Action: BulkActionArchive, Items: []BulkItemResult{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}}
+	}
+
+	rejected := newResult()
+	rejected.Fail(1, errors.New("call not found"))
+	if !rejected.HasFailures() {
+		t.Fatal("HasFailures() = false after a failure")
+	}
+	rejected.Reject()
+	if rejected.Applied || rejected.Succeeded != 0 || rejected.Failed != 1 {
+		t.Errorf("rejected = %+v, want not applied with one failure", rejected)
+	}
+	if rejected.Items[0].Status != BulkItemRejected || rejected.Items[1].Status != BulkItemFailed {
+		t.Errorf("statuses = %s, %s; want rejected, failed", rejected.Items[0].Status, rejected.Items[1].Status)
+	}
+
+	queued := newResult()
+	queued.Fail(2, errors.New("failed to queue quote generation"))
+	queued.Complete(BulkItemQueued)
+	if !queued.Applied || queued.Succeeded != 2 || queued.Failed != 1 {
+		t.Errorf("queued = %+v, want applied with two queued and one failed", queued)
+	}
+	if queued.Items[0].Status != BulkItemQueued || queued.Items[2].Status != BulkItemFailed {
+		t.Errorf("statuses = %s, %s; want queued, failed", queued.Items[0].Status, queued.Items[2].Status)
+	}
+}
@@ -2,6 +2,8 @@
 package domain
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -24,7 +26,7 @@ const (
 type Call struct {
 	ID                  uuid.UUID              `json:"id"`
 	ProviderCallID      string                 `json:"provider_call_id"` // ID from voice provider (Bland, Vapi, Retell, etc.)
-	Provider            string                 `json:"provider"`         // Provider type: "bland", "vapi", "retell", etc.
+	Provider            string                 `json:"provider"`         // Provider type: "bland", "vapi", "retell", "twilio", etc.
 	PhoneNumber         string                 `json:"phone_number"`     // Number that received the call (to)
 	FromNumber          string                 `json:"from_number"`      // Caller's number
 	CallerName          *string                `json:"caller_name,omitempty"`
@@ -42,9 +44,18 @@ type Call struct {
 	ProviderDisposition *string                `json:"provider_disposition,omitempty"`
 	ProviderMetadata    map[string]interface{} `json:"provider_metadata,omitempty"`
 	QuoteJobID          *uuid.UUID             `json:"quote_job_id,omitempty"`
+	CustomerID          *uuid.UUID             `json:"customer_id,omitempty"`
+	PromptID            *uuid.UUID             `json:"prompt_id,omitempty"` // Prompt an outbound call was placed with
+	Cost                *CallCost              `json:"cost,omitempty"`      // Estimated once the call ends
+	Tags                *CallTags              `json:"tags,omitempty"`      // Classified once the call completes
+	SpamScore           *int                   `json:"spam_score,omitempty"`
+	Disposition         *CallDisposition       `json:"disposition,omitempty"`
+	DispositionSource   *DispositionSource     `json:"disposition_source,omitempty"`
+	AssignedTo          *uuid.UUID             `json:"assigned_to,omitempty"` // User following up the call and its quote
 	CreatedAt           time.Time              `json:"created_at"`
 	UpdatedAt           time.Time              `json:"updated_at"`
 	DeletedAt           *time.Time             `json:"deleted_at,omitempty"`
+	ArchivedAt          *time.Time             `json:"archived_at,omitempty"`
 }
 
 // IsDeleted returns true if the call has been soft-deleted.
@@ -52,6 +63,11 @@ func (c *Call) IsDeleted() bool {
 	return c.DeletedAt != nil
 }
 
+// IsArchived returns true if the call has been archived.
+func (c *Call) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
 // MarkDeleted soft-deletes the call by setting DeletedAt.
 func (c *Call) MarkDeleted() {
 	now := time.Now().UTC()
@@ -60,10 +76,63 @@ func (c *Call) MarkDeleted() {
 }
 
 // TranscriptEntry represents a single message in the call transcript.
+// Timestamp, StartTime and EndTime are seconds from the start of the call;
+// StartTime and EndTime are set when the provider reports them.
 type TranscriptEntry struct {
-	Role      string  `json:"role"`
-	Content   string  `json:"content"`
-	Timestamp float64 `json:"timestamp"`
+	Role      string   `json:"role"`
+	Content   string   `json:"content"`
+	Timestamp float64  `json:"timestamp"`
+	StartTime *float64 `json:"start_time,omitempty"`
+	EndTime   *float64 `json:"end_time,omitempty"`
+}
+
+// TranscriptSegment is one utterance of a transcript with the span of the
+// recording it was spoken in, in seconds from the start of the call.
+type TranscriptSegment struct {
+	Index   int     `json:"index"`
+	Role    string  `json:"role"`
+	Content string  `json:"content"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+}
+
+// TranscriptSegments returns the call's transcript as timed segments for
+// following along with the recording. An utterance without an end time
+// ends where the next one starts, and the last one where the call ended.
+func (c *Call) TranscriptSegments() []TranscriptSegment {
+	segments := make([]TranscriptSegment, 0, len(c.TranscriptJSON))
+	for i, entry := range c.TranscriptJSON {
+		segments = append(segments, TranscriptSegment{
+			Index:   i,
+			Role:    entry.Role,
+			Content: entry.Content,
+			Start:   entry.start(),
+		})
+	}
+
+	for i := range segments {
+		entry := c.TranscriptJSON[i]
+		switch {
+		case entry.EndTime != nil:
+			segments[i].End = *entry.EndTime
+		case i+1 < len(segments):
+			segments[i].End = segments[i+1].Start
+		case c.DurationSeconds != nil:
+			segments[i].End = float64(*c.DurationSeconds)
+		}
+		if segments[i].End < segments[i].Start {
+			segments[i].End = segments[i].Start
+		}
+	}
+	return segments
+}
+
+// start returns when the utterance began.
+func (e TranscriptEntry) start() float64 {
+	if e.StartTime != nil {
+		return *e.StartTime
+	}
+	return e.Timestamp
 }
 
 // ExtractedData holds structured data extracted from the call.
@@ -79,6 +148,18 @@ type ExtractedData struct {
 	Company           string                 `json:"company,omitempty"`
 	AdditionalInfo    string                 `json:"additional_info,omitempty"`
 	Custom            map[string]interface{} `json:"custom,omitempty"`
+
+	// PricingInputs are the quantities and conditions the quote was priced
+	// from, extracted when the quote was generated.
+	PricingInputs *PricingInputs `json:"pricing_inputs,omitempty"`
+
+	// Language is the language the call was held in and the quote is
+	// written in; empty for the generator's default.
+	Language string `json:"language,omitempty"`
+
+	// Currency is the ISO 4217 currency the quote was priced in, recorded
+	// when it was generated.
+	Currency string `json:"currency,omitempty"`
 }
 
 // NewCall creates a new Call with default values.
@@ -101,11 +182,38 @@ func (c *Call) IsComplete() bool {
 	return c.Status == CallStatusCompleted || c.Status == CallStatusFailed || c.Status == CallStatusNoAnswer
 }
 
+// IsInbound returns true if the customer called us: the call has a caller
+// number and was not placed by QuickQuote.
+func (c *Call) IsInbound() bool {
+	return c.FromNumber != "" && c.PromptID == nil && !c.isOutbound()
+}
+
 // HasQuote returns true if a quote has been generated.
 func (c *Call) HasQuote() bool {
 	return c.QuoteSummary != nil && *c.QuoteSummary != ""
 }
 
+// IsSpam returns true if the call was scored as spam when it came in.
+func (c *Call) IsSpam() bool {
+	return c.SpamScore != nil && *c.SpamScore >= SpamFlagScore
+}
+
+// CanSetDisposition returns true if a disposition from source may replace
+// the call's current one: manual dispositions are only replaced manually, and
+// AI inference only fills in or replaces its own.
+func (c *Call) CanSetDisposition(source DispositionSource) bool {
+	if c.DispositionSource == nil || source == DispositionSourceManual {
+		return true
+	}
+	switch *c.DispositionSource {
+	case DispositionSourceManual:
+		return false
+	case DispositionSourceAuto:
+		return source == DispositionSourceAuto
+	}
+	return true
+}
+
 // Duration returns the call duration as a time.Duration.
 func (c *Call) Duration() time.Duration {
 	if c.DurationSeconds == nil {
@@ -130,8 +238,109 @@ func (c *Call) FormattedDuration() string {
 
 // CallListFilter defines optional filters for listing calls.
 type CallListFilter struct {
-	Status *CallStatus
-	Search string
+	Status        *CallStatus
+	Search        string
+	PhoneNumber   string          // Matches either the called or calling number
+	CreatedAfter  *time.Time      // Inclusive lower bound on created_at
+	CreatedBefore *time.Time      // Exclusive upper bound on created_at
+	HasQuote      bool            // Only calls with a generated quote
+	CustomerID    *uuid.UUID      // Only calls linked to this customer
+	Provider      string          // Only calls placed through this voice provider
+	Sentiment     CallSentiment   // Only calls tagged with this sentiment
+	Intent        CallIntent      // Only calls tagged with this intent
+	Urgency       CallUrgency     // Only calls tagged with this urgency
+	Spam          *bool           // Only calls scored as spam (true) or not (false)
+	Disposition   CallDisposition // Only calls with this disposition
+	Archived      bool            // Only archived calls; archived calls are left out otherwise
+	Tags          []string        // Only calls tagged with all of these
+	QuoteTags     []string        // Only calls whose quote is tagged with all of these
+	IDs           []uuid.UUID     // Only these calls, archived or not
+	Sort          CallSort        // Result order; newest first when empty
+
+	// After restricts results to calls that sort after the cursor in the
+	// filter's order. Used to page through large result sets.
+	After *CallCursor
+}
+
+// CallSort is the order calls are listed in.
+type CallSort string
+
+const (
+	CallSortNewest CallSort = "newest"
+	CallSortOldest CallSort = "oldest"
+)
+
+// ParseCallSort parses a sort option, defaulting to newest first.
+func ParseCallSort(s string) (CallSort, error) {
+	switch sort := CallSort(strings.TrimSpace(s)); sort {
+	case "", CallSortNewest:
+		return CallSortNewest, nil
+	case CallSortOldest:
+		return CallSortOldest, nil
+	default:
+		return "", fmt.Errorf("invalid sort %q, expected newest or oldest", s)
+	}
+}
+
+// CallCursor identifies a position in the call ordering.
+type CallCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorFor returns the cursor positioned at the given call.
+func CursorFor(call *Call) *CallCursor {
+	return &CallCursor{CreatedAt: call.CreatedAt, ID: call.ID}
+}
+
+// callCursorPrefix marks cursors of the current format.
+const callCursorPrefix = "v1:"
+
+// Encode returns the opaque form of the cursor handed to API clients.
+func (c *CallCursor) Encode() string {
+	raw := callCursorPrefix + c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCallCursor parses a cursor returned by CallCursor.Encode.
+func DecodeCallCursor(cursor string) (*CallCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), callCursorPrefix) {
+		return nil, errors.New("invalid cursor")
+	}
+	createdAt, id, ok := strings.Cut(strings.TrimPrefix(string(raw), callCursorPrefix), ",")
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &CallCursor{CreatedAt: t, ID: parsedID}, nil
+}
+
+// Markers wrapped around matched terms in a TranscriptMatch headline.
+const (
+	TranscriptHighlightStart = "[[hl]]"
+	TranscriptHighlightStop  = "[[/hl]]"
+)
+
+// TranscriptMatch is a call whose transcript matched a full-text search.
+type TranscriptMatch struct {
+	CallID      uuid.UUID  `json:"call_id"`
+	CallerName  *string    `json:"caller_name,omitempty"`
+	PhoneNumber string     `json:"phone_number"`
+	Status      CallStatus `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Rank        float64    `json:"rank"`
+
+	// Headline is an excerpt of the transcript with matched terms wrapped in
+	// TranscriptHighlightStart and TranscriptHighlightStop.
+	Headline string `json:"-"`
 }
 
 // HasFilters returns true if any filter fields are set.
@@ -139,8 +348,8 @@ func (f *CallListFilter) HasFilters() bool {
 	if f == nil {
 		return false
 	}
-	if f.Status != nil {
+	if f.Status != nil || f.CreatedAfter != nil || f.CreatedBefore != nil || f.HasQuote || f.CustomerID != nil {
 		return true
 	}
-	return strings.TrimSpace(f.Search) != ""
+	return strings.TrimSpace(f.Search) != "" || strings.TrimSpace(f.PhoneNumber) != "" || strings.TrimSpace(f.Provider) != ""
 }
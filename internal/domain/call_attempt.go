@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxCallRetryAttempts bounds how many times a retry policy may dial a number.
+const MaxCallRetryAttempts = 10
+
+// maxCallRetryBackoff bounds the wait before any one retry.
+const maxCallRetryBackoff = 7 * 24 * time.Hour
+
+// CallRetryReason is the outcome of a call that a retry policy re-dials.
+type CallRetryReason string
+
+const (
+	CallRetryReasonVoicemail CallRetryReason = "voicemail"
+	CallRetryReasonNoAnswer  CallRetryReason = "no_answer"
+)
+
+// CallRetryPolicy is how a prompt re-dials numbers whose call went to
+// voicemail or was not answered. Attempt 1 is the original call; the wait
+// before retry n is BackoffMinutes * BackoffMultiplier^(n-1). When windows
+// are set, retries take them in turn, so a number missed in the morning can
+// be tried again in the evening.
+type CallRetryPolicy struct {
+	MaxAttempts       int               `json:"max_attempts"`                 // Dials including the original call
+	BackoffMinutes    int               `json:"backoff_minutes"`              // Wait before the first retry
+	BackoffMultiplier float64           `json:"backoff_multiplier,omitempty"` // Growth of the wait per retry; 1 if unset
+	RetryOn           []CallRetryReason `json:"retry_on,omitempty"`           // Outcomes to retry; all if empty
+	Windows           []CallRetryWindow `json:"windows,omitempty"`            // Times of day to retry in, taken in turn
+	Timezone          string            `json:"timezone,omitempty"`           // IANA zone for windows; UTC if empty
+}
+
+// CallRetryWindow is a time of day, "HH:MM" to "HH:MM", to retry in.
+type CallRetryWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Validate checks that the policy can be followed.
+func (p *CallRetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 || p.MaxAttempts > MaxCallRetryAttempts {
+		return NewValidationError("retry_policy", fmt.Sprintf("retry policy max_attempts must be between 1 and %d", MaxCallRetryAttempts))
+	}
+	if p.BackoffMinutes < 1 || time.Duration(p.BackoffMinutes)*time.Minute > maxCallRetryBackoff {
+		return NewValidationError("retry_policy", "retry policy backoff_minutes must be between 1 minute and 7 days")
+	}
+	if p.BackoffMultiplier != 0 && (p.BackoffMultiplier < 1 || p.BackoffMultiplier > 10) {
+		return NewValidationError("retry_policy", "retry policy backoff_multiplier must be between 1 and 10")
+	}
+	for _, reason := range p.RetryOn {
+		if reason != CallRetryReasonVoicemail && reason != CallRetryReasonNoAnswer {
+			return NewValidationError("retry_policy", fmt.Sprintf("retry policy cannot retry on %q; use voicemail or no_answer", reason))
+		}
+	}
+	for _, w := range p.Windows {
+		start, err := parseClockMinute(w.Start)
+		if err != nil {
+			return NewValidationError("retry_policy", "retry policy window start: "+err.Error())
+		}
+		end, err := parseClockMinute(w.End)
+		if err != nil {
+			return NewValidationError("retry_policy", "retry policy window end: "+err.Error())
+		}
+		if end <= start {
+			return NewValidationError("retry_policy", "retry policy windows must end after they start")
+		}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return NewValidationError("retry_policy", fmt.Sprintf("retry policy timezone %q is not a known time zone", p.Timezone))
+	}
+	return nil
+}
+
+// RetriesOn reports whether the policy re-dials calls with the given outcome.
+func (p *CallRetryPolicy) RetriesOn(reason CallRetryReason) bool {
+	if len(p.RetryOn) == 0 {
+		return true
+	}
+	for _, r := range p.RetryOn {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAttemptAt returns when to place the given attempt (2 for the first
+// retry) after the previous one ended at after. The backoff is waited out
+// first, then the attempt moves to the start of its window if it falls
+// outside it.
+func (p *CallRetryPolicy) NextAttemptAt(attempt int, after time.Time) time.Time {
+	retry := attempt - 1
+	if retry < 1 {
+		retry = 1
+	}
+	multiplier := p.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := time.Duration(float64(p.BackoffMinutes) * math.Pow(multiplier, float64(retry-1)) * float64(time.Minute))
+	if backoff > maxCallRetryBackoff || backoff < 0 {
+		backoff = maxCallRetryBackoff
+	}
+	at := after.Add(backoff)
+	if len(p.Windows) == 0 {
+		return at.UTC()
+	}
+
+	w := p.Windows[(retry-1)%len(p.Windows)]
+	start, err := parseClockMinute(w.Start)
+	if err != nil {
+		return at.UTC()
+	}
+	end, err := parseClockMinute(w.End)
+	if err != nil {
+		return at.UTC()
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := at.In(loc)
+	windowStart := time.Date(local.Year(), local.Month(), local.Day(), start/60, start%60, 0, 0, loc)
+	windowEnd := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	switch {
+	case local.Before(windowStart):
+		return windowStart.UTC()
+	case local.Before(windowEnd):
+		return at.UTC()
+	default:
+		return windowStart.AddDate(0, 0, 1).UTC()
+	}
+}
+
+// CallAttemptStatus represents where a scheduled retry is in being dialed.
+type CallAttemptStatus string
+
+const (
+	CallAttemptStatusScheduled CallAttemptStatus = "scheduled" // Waiting until it is due
+	CallAttemptStatusDialed    CallAttemptStatus = "dialed"    // Call placed
+	CallAttemptStatusCancelled CallAttemptStatus = "cancelled" // Customer was reached or is on the do-not-call list
+	CallAttemptStatusFailed    CallAttemptStatus = "failed"    // The call could not be placed
+)
+
+// CallAttempt is a retry of a call that went to voicemail or was not
+// answered. Attempts chain: each one is scheduled from the call before it
+// and keeps the ID of the first call in the chain.
+type CallAttempt struct {
+	ID             uuid.UUID         `json:"id"`
+	OriginalCallID uuid.UUID         `json:"original_call_id"`
+	PreviousCallID uuid.UUID         `json:"previous_call_id"`
+	CallID         *uuid.UUID        `json:"call_id,omitempty"` // The call placed, once dialed
+	PromptID       uuid.UUID         `json:"prompt_id"`
+	PhoneNumber    string            `json:"phone_number"`
+	Attempt        int               `json:"attempt"` // 2 for the first retry
+	Reason         CallRetryReason   `json:"reason"`
+	Status         CallAttemptStatus `json:"status"`
+	ScheduledAt    time.Time         `json:"scheduled_at"`
+	LastError      *string           `json:"last_error,omitempty"`
+	CancelReason   string            `json:"cancel_reason,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// NewCallAttempt schedules attempt number attempt to follow the previous
+// call in a chain that began with originalCallID.
+func NewCallAttempt(originalCallID uuid.UUID, previous *Call, phoneNumber string, attempt int, reason CallRetryReason, scheduledAt time.Time) *CallAttempt {
+	now := time.Now().UTC()
+	return &CallAttempt{
+		ID:             uuid.New(),
+		OriginalCallID: originalCallID,
+		PreviousCallID: previous.ID,
+		PromptID:       *previous.PromptID,
+		PhoneNumber:    phoneNumber,
+		Attempt:        attempt,
+		Reason:         reason,
+		Status:         CallAttemptStatusScheduled,
+		ScheduledAt:    scheduledAt.UTC(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkDialed records the call the attempt placed.
+func (a *CallAttempt) MarkDialed(callID uuid.UUID) {
+	a.Status = CallAttemptStatusDialed
+	a.CallID = &callID
+	a.LastError = nil
+	a.UpdatedAt = time.Now().UTC()
+}
+
+// MarkFailed records that the call could not be placed.
+func (a *CallAttempt) MarkFailed(err error) {
+	errMsg := err.Error()
+	a.Status = CallAttemptStatusFailed
+	a.LastError = &errMsg
+	a.UpdatedAt = time.Now().UTC()
+}
+
+// Cancel stops the attempt for the given reason.
+func (a *CallAttempt) Cancel(reason string) {
+	a.Status = CallAttemptStatusCancelled
+	a.CancelReason = reason
+	a.UpdatedAt = time.Now().UTC()
+}
+
+// Defer holds the attempt back until the given time.
+func (a *CallAttempt) Defer(until time.Time) {
+	a.ScheduledAt = until.UTC()
+	a.UpdatedAt = time.Now().UTC()
+}
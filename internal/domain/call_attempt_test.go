@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCallRetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CallRetryPolicy
+		wantErr bool
+	}{
+		{"valid", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60}, false},
+		{"with windows", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, Windows: []CallRetryWindow{{"09:00", "12:00"}, {"17:00", "20:00"}}, Timezone: "America/Chicago"}, false},
+		{"too many attempts", CallRetryPolicy{MaxAttempts: MaxCallRetryAttempts + 1, BackoffMinutes: 60}, true},
+		{"no backoff", CallRetryPolicy{MaxAttempts: 3}, true},
+		{"backoff over a week", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 8 * 24 * 60}, true},
+		{"shrinking backoff", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, BackoffMultiplier: 0.5}, true},
+		{"unknown outcome", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, RetryOn: []CallRetryReason{"busy"}}, true},
+		{"bad window", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, Windows: []CallRetryWindow{{"9am", "12:00"}}}, true},
+		{"backwards window", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, Windows: []CallRetryWindow{{"20:00", "17:00"}}}, true},
+		{"unknown timezone", CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, Timezone: "Mars/Olympus"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCallRetryPolicy_NextAttemptAt(t *testing.T) {
+	ended := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC) // Wednesday 10 AM
+
+	backoff := &CallRetryPolicy{MaxAttempts: 4, BackoffMinutes: 30, BackoffMultiplier: 2}
+	for attempt, want := range map[int]time.Duration{2: 30 * time.Minute, 3: time.Hour, 4: 2 * time.Hour} {
+		if got := backoff.NextAttemptAt(attempt, ended); !got.Equal(ended.Add(want)) {
+			t.Errorf("attempt %d at %v, want %v", attempt, got, ended.Add(want))
+		}
+	}
+
+	// Retries alternate between morning and evening
+	windows := &CallRetryPolicy{
+		MaxAttempts:    4,
+		BackoffMinutes: 60,
+		Windows:        []CallRetryWindow{{"09:00", "12:00"}, {"17:00", "20:00"}},
+	}
+	tests := []struct {
+		attempt int
+		after   time.Time
+		want    time.Time
+	}{
+		{2, ended, time.Date(2026, 3, 4, 11, 0, 0, 0, time.UTC)},                                        // Inside the morning window
+		{3, ended, time.Date(2026, 3, 4, 17, 0, 0, 0, time.UTC)},                                        // Waits for the evening window
+		{4, ended.Add(90 * time.Minute), time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},                   // Morning window has passed
+		{2, time.Date(2026, 3, 4, 19, 30, 0, 0, time.UTC), time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)}, // Backoff runs into the night
+	}
+	for _, tt := range tests {
+		if got := windows.NextAttemptAt(tt.attempt, tt.after); !got.Equal(tt.want) {
+			t.Errorf("attempt %d after %v at %v, want %v", tt.attempt, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestCallRetryPolicy_RetriesOn(t *testing.T) {
+	all := &CallRetryPolicy{}
+	if !all.RetriesOn(CallRetryReasonVoicemail) || !all.RetriesOn(CallRetryReasonNoAnswer) {
+		t.Error("a policy without retry_on should retry every outcome")
+	}
+	voicemail := &CallRetryPolicy{RetryOn: []CallRetryReason{CallRetryReasonVoicemail}}
+	if !voicemail.RetriesOn(CallRetryReasonVoicemail) || voicemail.RetriesOn(CallRetryReasonNoAnswer) {
+		t.Error("a voicemail-only policy should retry only voicemail")
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// CallCost is what a finished call is estimated to have cost, priced from
+// the pricing settings in effect when it was processed.
+type CallCost struct {
+	// BilledNumber is our side of the call: the number that was called for
+	// inbound calls and the number we called from for outbound calls.
+	BilledNumber      string  `json:"billed_number"`
+	Outbound          bool    `json:"outbound"`
+	Minutes           float64 `json:"minutes"`
+	RatePerMinute     float64 `json:"rate_per_minute"`
+	CallCost          float64 `json:"call_cost"`
+	TranscriptionCost float64 `json:"transcription_cost"`
+	AnalysisCost      float64 `json:"analysis_cost"`
+	TotalCost         float64 `json:"total_cost"`
+}
+
+// ComputeCost prices the call: its minutes at the inbound or outbound rate,
+// transcription per minute when it has a transcript, and analysis when data
+// was extracted from it.
+func (c *Call) ComputeCost(pricing *PricingSettings) *CallCost {
+	outbound := c.PromptID != nil || c.isOutbound()
+
+	cost := &CallCost{
+		BilledNumber:  c.PhoneNumber,
+		Outbound:      outbound,
+		Minutes:       roundCost(c.Duration().Minutes()),
+		RatePerMinute: pricing.InboundPerMinute,
+	}
+	if outbound {
+		cost.BilledNumber = c.FromNumber
+		cost.RatePerMinute = pricing.OutboundPerMinute
+	}
+
+	cost.CallCost = roundCost(cost.Minutes * cost.RatePerMinute)
+	if c.Transcript != nil && *c.Transcript != "" {
+		cost.TranscriptionCost = roundCost(cost.Minutes * pricing.TranscriptionPerMinute)
+	}
+	if c.ExtractedData != nil {
+		cost.AnalysisCost = pricing.AnalysisPerCall
+	}
+	cost.TotalCost = roundCost(cost.CallCost + cost.TranscriptionCost + cost.AnalysisCost)
+	return cost
+}
+
+// roundCost rounds to the four decimal places costs are stored with.
+func roundCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// CallCostRollup totals the costs of the calls billed to one of our numbers
+// in one calendar month (UTC).
+type CallCostRollup struct {
+	Month             time.Time `json:"month"`
+	PhoneNumber       string    `json:"phone_number"`
+	Calls             int       `json:"calls"`
+	Minutes           float64   `json:"minutes"`
+	CallCost          float64   `json:"call_cost"`
+	TranscriptionCost float64   `json:"transcription_cost"`
+	AnalysisCost      float64   `json:"analysis_cost"`
+	TotalCost         float64   `json:"total_cost"`
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCall_ComputeCost(t *testing.T) {
+	pricing := &PricingSettings{
+		InboundPerMinute:       0.09,
+		OutboundPerMinute:      0.12,
+		TranscriptionPerMinute: 0.02,
+		AnalysisPerCall:        0.05,
+	}
+	duration := 150
+	transcript := "Caller: I need a quote"
+	promptID := uuid.New()
+
+	tests := []struct {
+		name string
+		call *Call
+		want CallCost
+	}{
+		{
+			name: "inbound call billed to the number called",
+			call: &Call{PhoneNumber: "+15550001111", FromNumber: "+15552223333", DurationSeconds: &duration},
+			want: CallCost{BilledNumber: "+15550001111", Minutes: 2.5, RatePerMinute: 0.09, CallCost: 0.225, TotalCost: 0.225},
+		},
+		{
+			name: "outbound call billed to the number called from",
+			call: &Call{PhoneNumber: "+15552223333", FromNumber: "+15550001111", DurationSeconds: &duration, PromptID: &promptID},
+			want: CallCost{BilledNumber: "+15550001111", Outbound: true, Minutes: 2.5, RatePerMinute: 0.12, CallCost: 0.3, TotalCost: 0.3},
+		},
+		{
+			name: "campaign call marked outbound by its metadata",
+			call: &Call{
+				PhoneNumber:      "+15552223333",
+				FromNumber:       "+15550001111",
+				DurationSeconds:  &duration,
+				ProviderMetadata: map[string]interface{}{"metadata": map[string]interface{}{"campaign_contact_id": "c1"}},
+			},
+			want: CallCost{BilledNumber: "+15550001111", Outbound: true, Minutes: 2.5, RatePerMinute: 0.12, CallCost: 0.3, TotalCost: 0.3},
+		},
+		{
+			name: "transcription and analysis",
+			call: &Call{PhoneNumber: "+15550001111", DurationSeconds: &duration, Transcript: &transcript, ExtractedData: &ExtractedData{}},
+			want: CallCost{BilledNumber: "+15550001111", Minutes: 2.5, RatePerMinute: 0.09, CallCost: 0.225, TranscriptionCost: 0.05, AnalysisCost: 0.05, TotalCost: 0.325},
+		},
+		{
+			name: "unanswered call",
+			call: &Call{PhoneNumber: "+15550001111"},
+			want: CallCost{BilledNumber: "+15550001111", RatePerMinute: 0.09},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.call.ComputeCost(pricing)
+			if *got != tt.want {
+				t.Errorf("ComputeCost() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
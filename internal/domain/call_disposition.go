@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CallDisposition is how a call ended for the business, in one local
+// vocabulary whichever voice provider handled the call.
+type CallDisposition string
+
+const (
+	CallDispositionQuoted            CallDisposition = "quoted"
+	CallDispositionCallbackScheduled CallDisposition = "callback_scheduled"
+	CallDispositionNotInterested     CallDisposition = "not_interested"
+	CallDispositionWrongNumber       CallDisposition = "wrong_number"
+	CallDispositionVoicemail         CallDisposition = "voicemail"
+	CallDispositionSpam              CallDisposition = "spam"
+)
+
+// CallDispositions lists the valid dispositions in the order they are shown.
+var CallDispositions = []CallDisposition{
+	CallDispositionQuoted,
+	CallDispositionCallbackScheduled,
+	CallDispositionNotInterested,
+	CallDispositionWrongNumber,
+	CallDispositionVoicemail,
+	CallDispositionSpam,
+}
+
+// DispositionSource is where a call's disposition came from. A manual
+// disposition is never replaced automatically, and one mapped from provider
+// data or quote generation is not replaced by AI inference.
+type DispositionSource string
+
+const (
+	DispositionSourceAuto   DispositionSource = "auto"   // Mapped from provider data, the spam score or a generated quote
+	DispositionSourceAI     DispositionSource = "ai"     // Inferred by AI from the transcript
+	DispositionSourceManual DispositionSource = "manual" // Set or corrected by a user
+)
+
+// ParseCallDisposition parses a disposition, accepting any case and spaces
+// or hyphens for underscores.
+func ParseCallDisposition(s string) (CallDisposition, error) {
+	v := CallDisposition(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s))))
+	for _, known := range CallDispositions {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid disposition %q, expected quoted, callback_scheduled, not_interested, wrong_number, voicemail or spam", s)
+}
+
+// providerDispositions maps words found in providers' free-form
+// dispositions to local dispositions, checked in order.
+var providerDispositions = []struct {
+	keyword     string
+	disposition CallDisposition
+}{
+	{"voicemail", CallDispositionVoicemail},
+	{"answering_machine", CallDispositionVoicemail},
+	{"machine", CallDispositionVoicemail},
+	{"wrong_number", CallDispositionWrongNumber},
+	{"not_interested", CallDispositionNotInterested},
+	{"declined", CallDispositionNotInterested},
+	{"callback", CallDispositionCallbackScheduled},
+	{"call_back", CallDispositionCallbackScheduled},
+	{"spam", CallDispositionSpam},
+	{"robocall", CallDispositionSpam},
+}
+
+// MapProviderDisposition maps a voice provider's disposition, such as
+// "Answering Machine" or "wrong-number", to a local disposition. It returns
+// false when the provider's disposition has no local equivalent.
+func MapProviderDisposition(providerDisposition string) (CallDisposition, bool) {
+	normalized := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(providerDisposition)))
+	if normalized == "" {
+		return "", false
+	}
+	for _, m := range providerDispositions {
+		if strings.Contains(normalized, m.keyword) {
+			return m.disposition, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "testing"
+
+func TestMapProviderDisposition(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     CallDisposition
+		ok       bool
+	}{
+		{"Answering Machine", CallDispositionVoicemail, true},
+		{"voicemail", CallDispositionVoicemail, true},
+		{"wrong-number", CallDispositionWrongNumber, true},
+		{"Not Interested", CallDispositionNotInterested, true},
+		{"customer_declined", CallDispositionNotInterested, true},
+		{"CALLBACK_REQUESTED", CallDispositionCallbackScheduled, true},
+		{"robocall", CallDispositionSpam, true},
+		{"completed", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := MapProviderDisposition(tt.provider)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("MapProviderDisposition(%q) = %q, %v; want %q, %v", tt.provider, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseCallDisposition(t *testing.T) {
+	if got, err := ParseCallDisposition("Callback Scheduled"); err != nil || got != CallDispositionCallbackScheduled {
+		t.Errorf("ParseCallDisposition() = %q, %v", got, err)
+	}
+	if _, err := ParseCallDisposition("none"); err == nil {
+		t.Error("expected an error for an unknown disposition")
+	}
+}
+
+func TestCall_CanSetDisposition(t *testing.T) {
+	source := func(s DispositionSource) *DispositionSource { return &s }
+
+	tests := []struct {
+		name    string
+		current *DispositionSource
+		source  DispositionSource
+		want    bool
+	}{
+		{"unset", nil, DispositionSourceAI, true},
+		{"ai replaces ai", source(DispositionSourceAI), DispositionSourceAI, true},
+		{"auto replaces ai", source(DispositionSourceAI), DispositionSourceAuto, true},
+		{"ai keeps auto", source(DispositionSourceAuto), DispositionSourceAI, false},
+		{"auto keeps manual", source(DispositionSourceManual), DispositionSourceAuto, false},
+		{"manual replaces manual", source(DispositionSourceManual), DispositionSourceManual, true},
+	}
+	for _, tt := range tests {
+		call := &Call{DispositionSource: tt.current}
+		if got := call.CanSetDisposition(tt.source); got != tt.want {
+			t.Errorf("%s: CanSetDisposition() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
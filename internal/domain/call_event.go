@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallEventSource is what reported a call's status.
+type CallEventSource string
+
+const (
+	CallEventSourceWebhook CallEventSource = "webhook" // The voice provider's webhook
+	CallEventSourceManual  CallEventSource = "manual"  // A user, e.g. placing a call from the dashboard
+	CallEventSourceSystem  CallEventSource = "system"  // QuickQuote itself, e.g. a campaign placing a call
+)
+
+// CallEvent records a status a call was reported in. Events are only ever
+// appended; the call's status is derived by replaying them, so a report
+// delivered late can't undo a later one.
+type CallEvent struct {
+	ID      uuid.UUID       `json:"id"`
+	CallID  uuid.UUID       `json:"call_id"`
+	Status  CallStatus      `json:"status"`
+	Source  CallEventSource `json:"source"`
+	Detail  string          `json:"detail,omitempty"`   // e.g. the provider's own status
+	ActorID *uuid.UUID      `json:"actor_id,omitempty"` // User behind a manual event
+
+	// OccurredAt is when the status took effect, as far as the reporter
+	// knows; CreatedAt is when it was recorded.
+	OccurredAt time.Time `json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewCallEvent creates an event recorded now.
+func NewCallEvent(callID uuid.UUID, status CallStatus, source CallEventSource, occurredAt time.Time) *CallEvent {
+	now := time.Now().UTC()
+	if occurredAt.IsZero() {
+		occurredAt = now
+	}
+	return &CallEvent{
+		ID:         uuid.New(),
+		CallID:     callID,
+		Status:     status,
+		Source:     source,
+		OccurredAt: occurredAt,
+		CreatedAt:  now,
+	}
+}
+
+// CallTimelineEntry is a call event as it played out on replay.
+type CallTimelineEntry struct {
+	*CallEvent
+	PreviousStatus CallStatus `json:"previous_status,omitempty"`
+
+	// Applied is false for an event that arrived after the call had
+	// already reached a later stage, such as an in-progress webhook
+	// delivered after the completion webhook.
+	Applied bool `json:"applied"`
+}
+
+// callStage orders statuses along the call lifecycle. Every outcome is the
+// final stage.
+func callStage(status CallStatus) int {
+	switch status {
+	case CallStatusPending:
+		return 0
+	case CallStatusInProgress:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ReplayCallEvents orders events by when they occurred and folds them into
+// the call's status. An event for an earlier stage than the call has
+// reached is kept in the timeline but not applied. The status is empty
+// when there are no events.
+func ReplayCallEvents(events []*CallEvent) (CallStatus, []*CallTimelineEntry) {
+	ordered := make([]*CallEvent, len(events))
+	copy(ordered, events)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].OccurredAt.Equal(ordered[j].OccurredAt) {
+			return ordered[i].OccurredAt.Before(ordered[j].OccurredAt)
+		}
+		return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+	})
+
+	var status CallStatus
+	timeline := make([]*CallTimelineEntry, 0, len(ordered))
+	for _, e := range ordered {
+		entry := &CallTimelineEntry{CallEvent: e, PreviousStatus: status}
+		if status == "" || callStage(e.Status) >= callStage(status) {
+			entry.Applied = true
+			status = e.Status
+		}
+		timeline = append(timeline, entry)
+	}
+	return status, timeline
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestReplayCallEvents(t *testing.T) {
+	callID := uuid.New()
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := func(status CallStatus, occurred, recorded time.Duration) *CallEvent {
+		e := NewCallEvent(callID, status, CallEventSourceWebhook, start.Add(occurred))
+		e.CreatedAt = start.Add(recorded)
+		return e
+	}
+
+	tests := []struct {
+		name        string
+		events      []*CallEvent
+		wantStatus  CallStatus
+		wantApplied []bool
+	}{
+		{"no events", nil, "", []bool{}},
+		{
+			"in order",
+			[]*CallEvent{event(CallStatusPending, 0, 0), event(CallStatusInProgress, time.Second, time.Second), event(CallStatusCompleted, time.Minute, time.Minute)},
+			CallStatusCompleted, []bool{true, true, true},
+		},
+		{
+			"late report of an earlier stage",
+			[]*CallEvent{event(CallStatusCompleted, time.Minute, time.Minute), event(CallStatusInProgress, 2*time.Minute, 2*time.Minute)},
+			CallStatusCompleted, []bool{true, false},
+		},
+		{
+			"recorded late but occurred earlier",
+			[]*CallEvent{event(CallStatusNoAnswer, time.Minute, time.Minute), event(CallStatusInProgress, time.Second, 2*time.Minute)},
+			CallStatusNoAnswer, []bool{true, true},
+		},
+		{
+			"later outcome wins",
+			[]*CallEvent{event(CallStatusFailed, time.Minute, time.Minute), event(CallStatusCompleted, 2*time.Minute, 2*time.Minute)},
+			CallStatusCompleted, []bool{true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, timeline := ReplayCallEvents(tt.events)
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if len(timeline) != len(tt.wantApplied) {
+				t.Fatalf("timeline has %d entries, want %d", len(timeline), len(tt.wantApplied))
+			}
+			for i, entry := range timeline {
+				if entry.Applied != tt.wantApplied[i] {
+					t.Errorf("entry %d (%s) applied = %v, want %v", i, entry.Status, entry.Applied, tt.wantApplied[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CallSentiment is how the caller came across over the call.
+type CallSentiment string
+
+const (
+	CallSentimentPositive CallSentiment = "positive"
+	CallSentimentNeutral  CallSentiment = "neutral"
+	CallSentimentNegative CallSentiment = "negative"
+)
+
+// CallIntent is why the caller called.
+type CallIntent string
+
+const (
+	CallIntentNewProject      CallIntent = "new_project"
+	CallIntentPricingQuestion CallIntent = "pricing_question"
+	CallIntentComplaint       CallIntent = "complaint"
+	CallIntentSpam            CallIntent = "spam"
+	CallIntentOther           CallIntent = "other"
+)
+
+// CallUrgency is how soon the caller needs a response.
+type CallUrgency string
+
+const (
+	CallUrgencyLow    CallUrgency = "low"
+	CallUrgencyMedium CallUrgency = "medium"
+	CallUrgencyHigh   CallUrgency = "high"
+)
+
+// CallSentiments lists the valid sentiments.
+var CallSentiments = []CallSentiment{CallSentimentPositive, CallSentimentNeutral, CallSentimentNegative}
+
+// CallIntents lists the valid intents.
+var CallIntents = []CallIntent{CallIntentNewProject, CallIntentPricingQuestion, CallIntentComplaint, CallIntentSpam, CallIntentOther}
+
+// CallUrgencies lists the valid urgencies.
+var CallUrgencies = []CallUrgency{CallUrgencyLow, CallUrgencyMedium, CallUrgencyHigh}
+
+// CallTags classify a completed call from its transcript.
+type CallTags struct {
+	Sentiment CallSentiment `json:"sentiment"`
+	Intent    CallIntent    `json:"intent"`
+	Urgency   CallUrgency   `json:"urgency"`
+	TaggedAt  time.Time     `json:"tagged_at"`
+
+	// Disposition is inferred with the tags but stored on the call, where
+	// provider data or a user may have set it already. Empty when the
+	// transcript gives no clear disposition.
+	Disposition CallDisposition `json:"-"`
+}
+
+// ParseCallSentiment parses a sentiment, accepting any case.
+func ParseCallSentiment(s string) (CallSentiment, error) {
+	v := CallSentiment(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range CallSentiments {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid sentiment %q, expected positive, neutral or negative", s)
+}
+
+// ParseCallIntent parses an intent, accepting any case and spaces or
+// hyphens for underscores.
+func ParseCallIntent(s string) (CallIntent, error) {
+	v := CallIntent(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(s))))
+	for _, known := range CallIntents {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid intent %q, expected new_project, pricing_question, complaint, spam or other", s)
+}
+
+// ParseCallUrgency parses an urgency, accepting any case.
+func ParseCallUrgency(s string) (CallUrgency, error) {
+	v := CallUrgency(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range CallUrgencies {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("invalid urgency %q, expected low, medium or high", s)
+}
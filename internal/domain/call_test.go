@@ -3,6 +3,8 @@ package domain
 import (
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 func TestNewCall(t *testing.T) {
@@ -137,3 +139,81 @@ func strPtr(s string) *string {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestCallCursor_RoundTrip(t *testing.T) {
+	cursor := &CallCursor{
+		CreatedAt: time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	decoded, err := DecodeCallCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCallCursor() error = %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID {
+		t.Errorf("DecodeCallCursor() = %+v, expected %+v", decoded, cursor)
+	}
+
+	for _, bad := range []string{"", "not-a-cursor", "djE6MjAyNg"} {
+		if _, err := DecodeCallCursor(bad); err == nil {
+			t.Errorf("DecodeCallCursor(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestParseCallSort(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CallSort
+		wantErr bool
+	}{
+		{"", CallSortNewest, false},
+		{"newest", CallSortNewest, false},
+		{"oldest", CallSortOldest, false},
+		{"duration", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCallSort(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCallSort(%q) = %q, %v; expected %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCall_TranscriptSegments(t *testing.T) {
+	ptr := func(f float64) *float64 { return &f }
+	duration := 30
+	call := &Call{
+		DurationSeconds: &duration,
+		TranscriptJSON: []TranscriptEntry{
+			{Role: "assistant", Content: "Hi", Timestamp: 0, StartTime: ptr(0.5), EndTime: ptr(2)},
+			{Role: "user", Content: "I need an app", Timestamp: 4},
+			{Role: "assistant", Content: "Tell me more", Timestamp: 9},
+		},
+	}
+
+	segments := call.TranscriptSegments()
+	expected := []TranscriptSegment{
+		{Index: 0, Role: "assistant", Content: "Hi", Start: 0.5, End: 2},
+		{Index: 1, Role: "user", Content: "I need an app", Start: 4, End: 9},
+		{Index: 2, Role: "assistant", Content: "Tell me more", Start: 9, End: 30},
+	}
+	if len(segments) != len(expected) {
+		t.Fatalf("TranscriptSegments() returned %d segments, expected %d", len(segments), len(expected))
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("segment %d = %+v, expected %+v", i, segments[i], expected[i])
+		}
+	}
+
+	// Without a duration the last segment has no length
+	call.DurationSeconds = nil
+	if last := call.TranscriptSegments()[2]; last.End != last.Start {
+		t.Errorf("last segment without duration = %+v, expected it to end where it starts", last)
+	}
+
+	if segments := (&Call{}).TranscriptSegments(); segments == nil || len(segments) != 0 {
+		t.Errorf("TranscriptSegments() without a transcript = %v, expected an empty list", segments)
+	}
+}
@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CallbackStatus represents where a callback is in being put on the calendar.
+type CallbackStatus string
+
+const (
+	CallbackStatusPending   CallbackStatus = "pending"   // Waiting for a calendar event
+	CallbackStatusScheduled CallbackStatus = "scheduled" // Calendar event created
+	CallbackStatusFailed    CallbackStatus = "failed"    // Gave up creating the event
+)
+
+// callbackMaxAttempts is how many times creating a calendar event is tried.
+const callbackMaxAttempts = 5
+
+// Callback is a time a caller asked to be called back, captured by the
+// schedule_callback tool during a call.
+type Callback struct {
+	ID             uuid.UUID      `json:"id"`
+	CallID         *uuid.UUID     `json:"call_id,omitempty"`
+	ProviderCallID string         `json:"provider_call_id"`
+	PhoneNumber    string         `json:"phone_number,omitempty"`
+	CallerName     string         `json:"caller_name,omitempty"`
+	Reason         string         `json:"reason,omitempty"`
+	RequestedDate  string         `json:"requested_date"` // As the caller said it, e.g. "Monday"
+	RequestedTime  string         `json:"requested_time"` // As the caller said it, e.g. "afternoon"
+	ScheduledAt    time.Time      `json:"scheduled_at"`
+	Status         CallbackStatus `json:"status"`
+
+	// Calendar sync
+	CalendarProvider string    `json:"calendar_provider,omitempty"`
+	CalendarEventID  string    `json:"calendar_event_id,omitempty"`
+	Attempts         int       `json:"attempts"`
+	LastError        *string   `json:"last_error,omitempty"`
+	NextAttemptAt    time.Time `json:"next_attempt_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewCallback creates a pending callback for a call.
+func NewCallback(providerCallID string, scheduledAt time.Time) *Callback {
+	now := time.Now().UTC()
+	return &Callback{
+		ID:             uuid.New(),
+		ProviderCallID: providerCallID,
+		ScheduledAt:    scheduledAt,
+		Status:         CallbackStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// DisplayName returns the caller's name, or their phone number if the name
+// is unknown.
+func (c *Callback) DisplayName() string {
+	if c.CallerName != "" {
+		return c.CallerName
+	}
+	if c.PhoneNumber != "" {
+		return c.PhoneNumber
+	}
+	return "Unknown caller"
+}
+
+// MarkScheduled records the calendar event created for the callback.
+func (c *Callback) MarkScheduled(provider, eventID string) {
+	c.Status = CallbackStatusScheduled
+	c.CalendarProvider = provider
+	c.CalendarEventID = eventID
+	c.Attempts++
+	c.LastError = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// MarkFailed records a failed attempt to create the calendar event, retrying
+// with exponential backoff (1m, 2m, 4m, ...) until attempts run out.
+func (c *Callback) MarkFailed(err error) {
+	now := time.Now().UTC()
+	c.Attempts++
+	errMsg := err.Error()
+	c.LastError = &errMsg
+	c.UpdatedAt = now
+
+	if c.Attempts >= callbackMaxAttempts {
+		c.Status = CallbackStatusFailed
+		return
+	}
+	c.NextAttemptAt = now.Add(time.Minute << (c.Attempts - 1))
+}
+
+// Default clock times for the parts of the day callers tend to give.
+var callbackDayParts = map[string][2]int{
+	"morning":   {9, 0},
+	"noon":      {12, 0},
+	"midday":    {12, 0},
+	"lunchtime": {12, 0},
+	"afternoon": {14, 0},
+	"evening":   {17, 0},
+}
+
+var (
+	callbackClockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	callbackDatePattern    = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})$`)
+	callbackOrdinalPattern = regexp.MustCompile(`(\d)(st|nd|rd|th)\b`)
+)
+
+// ResolveCallbackTime turns the date and time a caller gave, such as
+// "tomorrow" and "2pm" or "Monday" and "afternoon", into a time after now in
+// now's location. Weekdays mean the next such day after today.
+func ResolveCallbackTime(date, clock string, now time.Time) (time.Time, error) {
+	day, err := resolveCallbackDate(date, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	hour, minute, err := resolveCallbackClock(clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	at := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("%s %s has already passed", date, clock)
+	}
+	return at, nil
+}
+
+// resolveCallbackDate returns midnight on the day a caller described.
+func resolveCallbackDate(date string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(date), " "))
+	s = strings.TrimPrefix(s, "on ")
+	s = strings.TrimPrefix(s, "this ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "day after tomorrow", "the day after tomorrow":
+		return today.AddDate(0, 0, 2), nil
+	case "next week":
+		return nextWeekday(today, time.Monday), nil
+	}
+
+	if weekday, ok := parseWeekday(strings.TrimPrefix(s, "next ")); ok {
+		return nextWeekday(today, weekday), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	// Dates without a year are the next such date.
+	undecorated := callbackOrdinalPattern.ReplaceAllString(s, "$1")
+	for _, layout := range []string{"January 2", "Jan 2", "2 January", "2 Jan"} {
+		if t, err := time.ParseInLocation(layout, undecorated, now.Location()); err == nil {
+			return nextAnnual(today, t.Month(), t.Day()), nil
+		}
+	}
+	if m := callbackDatePattern.FindStringSubmatch(s); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		if month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+			return nextAnnual(today, time.Month(month), day), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("could not understand the date %q", date)
+}
+
+// resolveCallbackClock returns the hour and minute a caller described.
+func resolveCallbackClock(clock string) (int, int, error) {
+	s := strings.ToLower(strings.TrimSpace(clock))
+	s = strings.TrimPrefix(s, "in the ")
+	s = strings.TrimPrefix(s, "at ")
+	if hm, ok := callbackDayParts[s]; ok {
+		return hm[0], hm[1], nil
+	}
+
+	s = strings.NewReplacer(" ", "", ".", "", "o'clock", "").Replace(s)
+	m := callbackClockPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("could not understand the time %q", clock)
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("could not understand the time %q", clock)
+		}
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+	default:
+		// Without am/pm, 1 to 7 o'clock almost always means the afternoon.
+		if hour >= 1 && hour <= 7 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("could not understand the time %q", clock)
+	}
+	return hour, minute, nil
+}
+
+// parseWeekday recognizes full and abbreviated English weekday names.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the first day after today that falls on weekday.
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// nextAnnual returns the next occurrence of a month and day, today included.
+func nextAnnual(today time.Time, month time.Month, day int) time.Time {
+	t := time.Date(today.Year(), month, day, 0, 0, 0, 0, today.Location())
+	if t.Before(today) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t
+}
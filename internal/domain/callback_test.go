@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolveCallbackTime(t *testing.T) {
+	// Wednesday, March 4, 2026, 10:30 AM
+	now := time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		date  string
+		clock string
+		want  time.Time
+	}{
+		{"today", "3pm", time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)},
+		{"Tomorrow", "2:30 p.m.", time.Date(2026, 3, 5, 14, 30, 0, 0, time.UTC)},
+		{"day after tomorrow", "morning", time.Date(2026, 3, 6, 9, 0, 0, 0, time.UTC)},
+		{"Friday", "afternoon", time.Date(2026, 3, 6, 14, 0, 0, 0, time.UTC)},
+		{"next Wednesday", "noon", time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)},
+		{"on mon", "9am", time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"next week", "in the evening", time.Date(2026, 3, 9, 17, 0, 0, 0, time.UTC)},
+		{"2026-03-20", "15:45", time.Date(2026, 3, 20, 15, 45, 0, 0, time.UTC)},
+		{"March 10th", "3", time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)},
+		{"2 Feb", "10am", time.Date(2027, 2, 2, 10, 0, 0, 0, time.UTC)},
+		{"3/12", "at 11 o'clock", time.Date(2026, 3, 12, 11, 0, 0, 0, time.UTC)},
+		{"today", "12am", time.Time{}}, // already passed
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date+" "+tt.clock, func(t *testing.T) {
+			got, err := ResolveCallbackTime(tt.date, tt.clock, now)
+			if tt.want.IsZero() {
+				if err == nil {
+					t.Errorf("ResolveCallbackTime() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveCallbackTime() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ResolveCallbackTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCallbackTime_Invalid(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		date  string
+		clock string
+	}{
+		{"someday", "3pm"},
+		{"tomorrow", "whenever"},
+		{"tomorrow", "13pm"},
+		{"tomorrow", "25:00"},
+		{"13/40", "3pm"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got, err := ResolveCallbackTime(tt.date, tt.clock, now); err == nil {
+			t.Errorf("ResolveCallbackTime(%q, %q) = %v, want error", tt.date, tt.clock, got)
+		}
+	}
+}
+
+func TestResolveCallbackTime_UsesLocation(t *testing.T) {
+	loc := time.FixedZone("CST", -6*60*60)
+	now := time.Date(2026, time.March, 4, 10, 0, 0, 0, loc)
+
+	got, err := ResolveCallbackTime("tomorrow", "9am", now)
+	if err != nil {
+		t.Fatalf("ResolveCallbackTime() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ResolveCallbackTime() = %v, want %v", got.UTC(), want)
+	}
+}
+
+func TestCallback_MarkFailed(t *testing.T) {
+	cb := NewCallback("call-1", time.Now().Add(time.Hour))
+
+	cb.MarkFailed(errors.New("boom"))
+	if cb.Status != CallbackStatusPending {
+		t.Errorf("Status = %q after first failure, want pending", cb.Status)
+	}
+	if cb.LastError == nil || *cb.LastError != "boom" {
+		t.Errorf("LastError = %v, want boom", cb.LastError)
+	}
+	if delay := time.Until(cb.NextAttemptAt); delay < 50*time.Second || delay > time.Minute {
+		t.Errorf("first retry in %v, want about 1m", delay)
+	}
+
+	cb.MarkFailed(errors.New("boom"))
+	if delay := time.Until(cb.NextAttemptAt); delay < 110*time.Second || delay > 2*time.Minute {
+		t.Errorf("second retry in %v, want about 2m", delay)
+	}
+
+	for cb.Status == CallbackStatusPending {
+		cb.MarkFailed(errors.New("boom"))
+	}
+	if cb.Attempts != callbackMaxAttempts || cb.Status != CallbackStatusFailed {
+		t.Errorf("Attempts = %d, Status = %q, want failed after %d", cb.Attempts, cb.Status, callbackMaxAttempts)
+	}
+
+	cb.MarkScheduled("google", "evt")
+	if cb.LastError != nil || cb.Status != CallbackStatusScheduled {
+		t.Errorf("MarkScheduled left Status = %q, LastError = %v", cb.Status, cb.LastError)
+	}
+}
+
+func TestCallback_DisplayName(t *testing.T) {
+	cb := &Callback{}
+	if got := cb.DisplayName(); got != "Unknown caller" {
+		t.Errorf("DisplayName() = %q", got)
+	}
+	cb.PhoneNumber = "+15550001111"
+	if got := cb.DisplayName(); got != "+15550001111" {
+		t.Errorf("DisplayName() = %q", got)
+	}
+	cb.CallerName = "Dana"
+	if got := cb.DisplayName(); got != "Dana" {
+		t.Errorf("DisplayName() = %q", got)
+	}
+}
@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignStatus represents the lifecycle state of an outbound call campaign.
+type CampaignStatus string
+
+const (
+	CampaignStatusActive    CampaignStatus = "active"    // Dialing whenever inside the call window
+	CampaignStatusPaused    CampaignStatus = "paused"    // Stopped by an admin, can be resumed
+	CampaignStatusCompleted CampaignStatus = "completed" // Every contact has been dialed or the end date passed
+	CampaignStatusCancelled CampaignStatus = "cancelled" // Stopped permanently by an admin
+)
+
+// CampaignContactStatus represents the dialing state of a single campaign contact.
+type CampaignContactStatus string
+
+const (
+	CampaignContactStatusPending CampaignContactStatus = "pending"
+	CampaignContactStatusDialing CampaignContactStatus = "dialing"
+	CampaignContactStatusCalled  CampaignContactStatus = "called"
+	CampaignContactStatusFailed  CampaignContactStatus = "failed"
+	CampaignContactStatusSkipped CampaignContactStatus = "skipped"
+)
+
+// Campaign pacing bounds.
+const (
+	DefaultCampaignCallsPerHour = 30
+	MaxCampaignCallsPerHour     = 600
+)
+
+// ErrInvalidCampaignTransition is returned when a campaign cannot move to the requested state.
+var ErrInvalidCampaignTransition = errors.New("invalid campaign status transition")
+
+// Campaign is a locally scheduled list of outbound calls that QuickQuote
+// dials out one at a time inside a daily call window.
+type Campaign struct {
+	ID       uuid.UUID      `json:"id"`
+	Name     string         `json:"name"`
+	PromptID *uuid.UUID     `json:"prompt_id,omitempty"`
+	Status   CampaignStatus `json:"status"`
+
+	// Schedule. The daily window is expressed in minutes after local midnight
+	// in Timezone; calls are only placed while the window is open.
+	StartsAt          time.Time  `json:"starts_at"`
+	EndsAt            *time.Time `json:"ends_at,omitempty"`
+	WindowStartMinute int        `json:"window_start_minute"`
+	WindowEndMinute   int        `json:"window_end_minute"`
+	Timezone          string     `json:"timezone"`
+
+	// Pacing
+	CallsPerHour int        `json:"calls_per_hour"`
+	LastDialedAt *time.Time `json:"last_dialed_at,omitempty"`
+
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// NewCampaign creates an active campaign with default pacing and an all-day UTC window.
+func NewCampaign(name string, promptID *uuid.UUID, startsAt time.Time) *Campaign {
+	now := time.Now().UTC()
+	if startsAt.IsZero() {
+		startsAt = now
+	}
+	return &Campaign{
+		ID:                uuid.New(),
+		Name:              name,
+		PromptID:          promptID,
+		Status:            CampaignStatusActive,
+		StartsAt:          startsAt,
+		WindowStartMinute: 0,
+		WindowEndMinute:   24 * 60,
+		Timezone:          "UTC",
+		CallsPerHour:      DefaultCampaignCallsPerHour,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+// Validate checks that the schedule and pacing are usable.
+func (c *Campaign) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.WindowStartMinute < 0 || c.WindowStartMinute >= 24*60 {
+		return errors.New("window start must be between 00:00 and 23:59")
+	}
+	if c.WindowEndMinute <= c.WindowStartMinute || c.WindowEndMinute > 24*60 {
+		return errors.New("window end must be after window start")
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", c.Timezone)
+	}
+	if c.CallsPerHour < 1 || c.CallsPerHour > MaxCampaignCallsPerHour {
+		return fmt.Errorf("calls per hour must be between 1 and %d", MaxCampaignCallsPerHour)
+	}
+	if c.EndsAt != nil && !c.EndsAt.After(c.StartsAt) {
+		return errors.New("end date must be after start date")
+	}
+	return nil
+}
+
+// IsTerminal returns true if the campaign will never dial again.
+func (c *Campaign) IsTerminal() bool {
+	return c.Status == CampaignStatusCompleted || c.Status == CampaignStatusCancelled
+}
+
+// HasEnded returns true if the campaign's end date has passed.
+func (c *Campaign) HasEnded(now time.Time) bool {
+	return c.EndsAt != nil && !now.Before(*c.EndsAt)
+}
+
+// InWindow returns true if now falls on or after the start date, before the
+// end date, and inside the daily call window.
+func (c *Campaign) InWindow(now time.Time) bool {
+	if now.Before(c.StartsAt) || c.HasEnded(now) {
+		return false
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return false
+	}
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= c.WindowStartMinute && minute < c.WindowEndMinute
+}
+
+// DialInterval returns the minimum time between two calls.
+func (c *Campaign) DialInterval() time.Duration {
+	perHour := c.CallsPerHour
+	if perHour < 1 {
+		perHour = 1
+	}
+	return time.Hour / time.Duration(perHour)
+}
+
+// NextDialAt returns the earliest time the pacing allows another call.
+func (c *Campaign) NextDialAt() time.Time {
+	if c.LastDialedAt == nil {
+		return c.StartsAt
+	}
+	return c.LastDialedAt.Add(c.DialInterval())
+}
+
+// ReadyToDial returns true if the campaign is active, inside its window and paced for another call.
+func (c *Campaign) ReadyToDial(now time.Time) bool {
+	return c.Status == CampaignStatusActive && c.InWindow(now) && !now.Before(c.NextDialAt())
+}
+
+// MarkDialed records that a call was just placed.
+func (c *Campaign) MarkDialed(now time.Time) {
+	c.LastDialedAt = &now
+	c.UpdatedAt = now
+}
+
+// Pause stops an active campaign.
+func (c *Campaign) Pause() error {
+	if c.Status != CampaignStatusActive {
+		return ErrInvalidCampaignTransition
+	}
+	c.Status = CampaignStatusPaused
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Resume restarts a paused campaign.
+func (c *Campaign) Resume() error {
+	if c.Status != CampaignStatusPaused {
+		return ErrInvalidCampaignTransition
+	}
+	c.Status = CampaignStatusActive
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Cancel permanently stops a campaign.
+func (c *Campaign) Cancel() error {
+	if c.IsTerminal() {
+		return ErrInvalidCampaignTransition
+	}
+	now := time.Now().UTC()
+	c.Status = CampaignStatusCancelled
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// Complete marks the campaign as finished.
+func (c *Campaign) Complete() {
+	now := time.Now().UTC()
+	c.Status = CampaignStatusCompleted
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// FormatWindowMinute renders minutes after midnight as HH:MM.
+func FormatWindowMinute(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// ParseWindowMinute parses an HH:MM time of day into minutes after midnight.
+// "24:00" is accepted as the end of the day.
+func ParseWindowMinute(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// CampaignContact is a single number to be dialed as part of a campaign.
+type CampaignContact struct {
+	ID          uuid.UUID             `json:"id"`
+	CampaignID  uuid.UUID             `json:"campaign_id"`
+	PhoneNumber string                `json:"phone_number"`
+	Name        string                `json:"name,omitempty"`
+	Status      CampaignContactStatus `json:"status"`
+	CallID      *uuid.UUID            `json:"call_id,omitempty"`
+	Attempts    int                   `json:"attempts"`
+	LastError   *string               `json:"last_error,omitempty"`
+	DialedAt    *time.Time            `json:"dialed_at,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// NewCampaignContact creates a pending contact for a campaign.
+func NewCampaignContact(campaignID uuid.UUID, phoneNumber, name string) *CampaignContact {
+	now := time.Now().UTC()
+	return &CampaignContact{
+		ID:          uuid.New(),
+		CampaignID:  campaignID,
+		PhoneNumber: phoneNumber,
+		Name:        name,
+		Status:      CampaignContactStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// MarkDialing marks the contact as being dialed.
+func (c *CampaignContact) MarkDialing() {
+	now := time.Now().UTC()
+	c.Status = CampaignContactStatusDialing
+	c.Attempts++
+	c.DialedAt = &now
+	c.UpdatedAt = now
+}
+
+// MarkCalled records the call placed for this contact.
+func (c *CampaignContact) MarkCalled(callID uuid.UUID) {
+	c.Status = CampaignContactStatusCalled
+	c.CallID = &callID
+	c.LastError = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// MarkFailed records a failed dial attempt.
+func (c *CampaignContact) MarkFailed(err error) {
+	msg := err.Error()
+	c.Status = CampaignContactStatusFailed
+	c.LastError = &msg
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// CampaignStats summarizes contact progress for a campaign.
+type CampaignStats struct {
+	Total   int `json:"total"`
+	Pending int `json:"pending"`
+	Dialing int `json:"dialing"`
+	Called  int `json:"called"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// NewCampaignStats builds stats from per-status contact counts.
+func NewCampaignStats(counts map[CampaignContactStatus]int) CampaignStats {
+	s := CampaignStats{
+		Pending: counts[CampaignContactStatusPending],
+		Dialing: counts[CampaignContactStatusDialing],
+		Called:  counts[CampaignContactStatusCalled],
+		Failed:  counts[CampaignContactStatusFailed],
+		Skipped: counts[CampaignContactStatusSkipped],
+	}
+	s.Total = s.Pending + s.Dialing + s.Called + s.Failed + s.Skipped
+	return s
+}
+
+// PercentComplete returns the share of contacts that have been dialed.
+func (s CampaignStats) PercentComplete() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return (s.Called + s.Failed + s.Skipped) * 100 / s.Total
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCampaign_Defaults(t *testing.T) {
+	c := NewCampaign("Spring promo", nil, time.Time{})
+
+	if c.Status != CampaignStatusActive {
+		t.Errorf("expected status %s, got %s", CampaignStatusActive, c.Status)
+	}
+	if c.StartsAt.IsZero() {
+		t.Error("expected StartsAt to default to now")
+	}
+	if c.CallsPerHour != DefaultCampaignCallsPerHour {
+		t.Errorf("expected %d calls per hour, got %d", DefaultCampaignCallsPerHour, c.CallsPerHour)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected default campaign to be valid, got %v", err)
+	}
+}
+
+func TestCampaign_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		modify func(*Campaign)
+	}{
+		{"missing name", func(c *Campaign) { c.Name = "" }},
+		{"window end before start", func(c *Campaign) { c.WindowStartMinute, c.WindowEndMinute = 600, 540 }},
+		{"window past midnight", func(c *Campaign) { c.WindowEndMinute = 24*60 + 1 }},
+		{"unknown timezone", func(c *Campaign) { c.Timezone = "Mars/Olympus" }},
+		{"zero pacing", func(c *Campaign) { c.CallsPerHour = 0 }},
+		{"pacing too high", func(c *Campaign) { c.CallsPerHour = MaxCampaignCallsPerHour + 1 }},
+		{"ends before start", func(c *Campaign) { c.EndsAt = &before }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCampaign("Test", nil, start)
+			tt.modify(c)
+			if err := c.Validate(); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestCampaign_InWindow(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	c := NewCampaign("Test", nil, start)
+	c.EndsAt = &end
+	c.Timezone = "America/New_York"
+	c.WindowStartMinute = 9 * 60
+	c.WindowEndMinute = 17 * 60
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"before start date", time.Date(2026, 2, 28, 15, 0, 0, 0, time.UTC), false},
+		{"before local window", time.Date(2026, 3, 2, 13, 59, 0, 0, time.UTC), false}, // 08:59 EST
+		{"window opens", time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC), true},          // 09:00 EST
+		{"window closes", time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC), false},        // 17:00 EST
+		{"after end date", time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.InWindow(tt.now); got != tt.want {
+				t.Errorf("InWindow(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCampaign_ReadyToDial_Pacing(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCampaign("Test", nil, start)
+	c.CallsPerHour = 12 // one call every five minutes
+
+	now := start.Add(time.Hour)
+	if !c.ReadyToDial(now) {
+		t.Fatal("expected campaign to be ready before any calls")
+	}
+
+	c.MarkDialed(now)
+	if c.ReadyToDial(now.Add(4 * time.Minute)) {
+		t.Error("expected pacing to hold the next call")
+	}
+	if !c.ReadyToDial(now.Add(5 * time.Minute)) {
+		t.Error("expected campaign to be ready once the interval elapsed")
+	}
+
+	if err := c.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if c.ReadyToDial(now.Add(time.Hour)) {
+		t.Error("expected paused campaign not to dial")
+	}
+}
+
+func TestCampaign_Transitions(t *testing.T) {
+	c := NewCampaign("Test", nil, time.Now())
+
+	if err := c.Resume(); err != ErrInvalidCampaignTransition {
+		t.Errorf("expected resuming an active campaign to fail, got %v", err)
+	}
+	if err := c.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := c.Pause(); err != ErrInvalidCampaignTransition {
+		t.Errorf("expected pausing a paused campaign to fail, got %v", err)
+	}
+	if err := c.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if err := c.Cancel(); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if !c.IsTerminal() || c.CompletedAt == nil {
+		t.Error("expected cancelled campaign to be terminal with CompletedAt set")
+	}
+	if err := c.Cancel(); err != ErrInvalidCampaignTransition {
+		t.Errorf("expected cancelling a cancelled campaign to fail, got %v", err)
+	}
+}
+
+func TestParseWindowMinute(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"00:00", 0, false},
+		{"09:30", 570, false},
+		{"24:00", 1440, false},
+		{"9am", 0, true},
+		{"25:00", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWindowMinute(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWindowMinute(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWindowMinute(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+		if !tt.wantErr && FormatWindowMinute(got) != tt.in {
+			t.Errorf("FormatWindowMinute(%d) = %q, want %q", got, FormatWindowMinute(got), tt.in)
+		}
+	}
+}
+
+func TestCampaignStats_PercentComplete(t *testing.T) {
+	stats := NewCampaignStats(map[CampaignContactStatus]int{
+		CampaignContactStatusPending: 5,
+		CampaignContactStatusCalled:  4,
+		CampaignContactStatusFailed:  1,
+	})
+
+	if stats.Total != 10 {
+		t.Errorf("expected total 10, got %d", stats.Total)
+	}
+	if got := stats.PercentComplete(); got != 50 {
+		t.Errorf("expected 50%% complete, got %d", got)
+	}
+	if (CampaignStats{}).PercentComplete() != 0 {
+		t.Error("expected empty campaign to be 0% complete")
+	}
+}
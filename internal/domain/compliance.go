@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DNCSource records how a number came to be on the do-not-call list.
+type DNCSource string
+
+const (
+	DNCSourceManual    DNCSource = "manual"      // Added by staff
+	DNCSourceSMSOptOut DNCSource = "sms_opt_out" // The customer texted STOP
+	DNCSourceNational  DNCSource = "national"    // Imported from the national registry
+)
+
+// DNCEntry is a phone number that must not be called.
+type DNCEntry struct {
+	PhoneNumber string     `json:"phone_number"`
+	Source      DNCSource  `json:"source"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// DNCListFilter narrows a listing of the do-not-call list.
+type DNCListFilter struct {
+	Search string    // Matches part of the phone number
+	Source DNCSource // Empty matches every source
+}
+
+// smsOptOutKeywords and smsOptInKeywords are the carrier-standard keywords a
+// customer texts to stop and restart messages. They only count when they
+// are the whole message.
+var (
+	smsOptOutKeywords = map[string]bool{"STOP": true, "STOPALL": true, "UNSUBSCRIBE": true, "CANCEL": true, "END": true, "QUIT": true}
+	smsOptInKeywords  = map[string]bool{"START": true, "UNSTOP": true}
+)
+
+// IsSMSOptOut reports whether an inbound text asks us to stop contacting
+// the sender.
+func IsSMSOptOut(body string) bool {
+	return smsOptOutKeywords[smsKeyword(body)]
+}
+
+// IsSMSOptIn reports whether an inbound text withdraws an earlier opt-out.
+func IsSMSOptIn(body string) bool {
+	return smsOptInKeywords[smsKeyword(body)]
+}
+
+func smsKeyword(body string) string {
+	return strings.ToUpper(strings.Trim(strings.TrimSpace(body), ".!"))
+}
+
+// ComplianceReason is why a call was blocked.
+type ComplianceReason string
+
+const (
+	ComplianceReasonDoNotCall     ComplianceReason = "do_not_call"
+	ComplianceReasonCallingWindow ComplianceReason = "calling_window"
+)
+
+// ComplianceError is returned when a call may not be placed to a number.
+type ComplianceError struct {
+	Reason      ComplianceReason `json:"reason"`
+	PhoneNumber string           `json:"phone_number"`
+	// NextAllowed is when a call blocked by the calling window may be
+	// placed. Nil for numbers on the do-not-call list, or if no time is
+	// inside the window in every time zone the number may be in.
+	NextAllowed *time.Time `json:"next_allowed,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *ComplianceError) Error() string {
+	switch {
+	case e.Reason == ComplianceReasonDoNotCall:
+		return fmt.Sprintf("%s is on the do-not-call list", e.PhoneNumber)
+	case e.NextAllowed != nil:
+		return fmt.Sprintf("%s is outside the calling window until %s", e.PhoneNumber, e.NextAllowed.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("%s cannot be called inside the calling window in every time zone it may be in", e.PhoneNumber)
+	}
+}
+
+// CallingWindow is the part of the day, in the called party's local time,
+// in which calls may be placed, in minutes after midnight. Equal start and
+// end means calls are allowed at any time.
+type CallingWindow struct {
+	StartMinute int
+	EndMinute   int
+	// DefaultLocation is used for numbers outside the North American
+	// Numbering Plan, whose time zone cannot be told from the number.
+	DefaultLocation *time.Location
+}
+
+// ParseCallingWindow builds a calling window from "HH:MM" start and end
+// times. Empty times allow calls at any time.
+func ParseCallingWindow(start, end string, defaultLoc *time.Location) (CallingWindow, error) {
+	w := CallingWindow{DefaultLocation: defaultLoc}
+	if start == "" && end == "" {
+		return w, nil
+	}
+	var err error
+	if w.StartMinute, err = parseClockMinute(start); err != nil {
+		return w, fmt.Errorf("calling window start: %w", err)
+	}
+	if w.EndMinute, err = parseClockMinute(end); err != nil {
+		return w, fmt.Errorf("calling window end: %w", err)
+	}
+	if w.StartMinute >= w.EndMinute {
+		return w, fmt.Errorf("calling window must start before it ends")
+	}
+	return w, nil
+}
+
+// callingWindowMaxRounds bounds the search for a time inside the window in
+// every zone; zones only ever push the candidate later, so a few rounds
+// settle unless the zones never overlap.
+const callingWindowMaxRounds = 8
+
+// NextAllowed returns t if phoneNumber may be called at t, otherwise the
+// earliest later time it may be called. Numbers whose area code spans time
+// zones must be inside the window in all of them. The zero time is
+// returned if the window never holds in all of them at once.
+func (w CallingWindow) NextAllowed(phoneNumber string, t time.Time) time.Time {
+	if w.StartMinute == w.EndMinute {
+		return t
+	}
+
+	locs := LocationsForNumber(phoneNumber)
+	if len(locs) == 0 {
+		locs = []*time.Location{w.defaultLocation()}
+	}
+
+	candidate := t
+	for round := 0; round < callingWindowMaxRounds; round++ {
+		moved := false
+		for _, loc := range locs {
+			if next := w.nextStartIn(candidate, loc); next.After(candidate) {
+				candidate = next
+				moved = true
+			}
+		}
+		if !moved {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// nextStartIn returns t if it is inside the window in loc, otherwise the
+// next time the window opens there.
+func (w CallingWindow) nextStartIn(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if minute >= w.StartMinute && minute < w.EndMinute {
+		return t
+	}
+	start := time.Date(local.Year(), local.Month(), local.Day(), w.StartMinute/60, w.StartMinute%60, 0, 0, loc)
+	if minute >= w.EndMinute {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start
+}
+
+func (w CallingWindow) defaultLocation() *time.Location {
+	if w.DefaultLocation == nil {
+		return time.UTC
+	}
+	return w.DefaultLocation
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSMSKeywords(t *testing.T) {
+	for _, body := range []string{"STOP", " stop ", "Stop.", "unsubscribe", "QUIT"} {
+		if !IsSMSOptOut(body) {
+			t.Errorf("IsSMSOptOut(%q) = false, want true", body)
+		}
+	}
+	for _, body := range []string{"please stop calling me at work", "stopped", "YES", ""} {
+		if IsSMSOptOut(body) {
+			t.Errorf("IsSMSOptOut(%q) = true, want false", body)
+		}
+	}
+	if !IsSMSOptIn("start") || !IsSMSOptIn("UNSTOP") || IsSMSOptIn("STOP") {
+		t.Error("expected START and UNSTOP, and not STOP, to opt back in")
+	}
+}
+
+func TestLocationsForNumber(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		phone string
+		want  []string
+	}{
+		{"+12125550100", []string{"America/New_York"}},
+		{"(415) 555-0100", []string{"America/Los_Angeles"}},
+		{"+18505550100", []string{"America/Chicago", "America/New_York"}},
+		{"+14165550100", nanpUnknownZones},
+		{"+442071234567", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, loc := range LocationsForNumber(tt.phone) {
+			got = append(got, loc.String())
+		}
+		if !sameZones(got, tt.want) {
+			t.Errorf("LocationsForNumber(%q) = %v, want %v", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func sameZones(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool)
+	for _, z := range got {
+		seen[z] = true
+	}
+	for _, z := range want {
+		if !seen[z] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCallingWindow_NextAllowed(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	w, err := ParseCallingWindow("08:00", "21:00", london)
+	if err != nil {
+		t.Fatalf("ParseCallingWindow() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		phone string
+		at    time.Time
+		want  time.Time
+	}{
+		{"New York at noon", "+12125550100", time.Date(2026, 3, 4, 12, 0, 0, 0, ny), time.Date(2026, 3, 4, 12, 0, 0, 0, ny)},
+		{"New York before 8 AM", "+12125550100", time.Date(2026, 3, 4, 7, 0, 0, 0, ny), time.Date(2026, 3, 4, 8, 0, 0, 0, ny)},
+		{"New York at 9 PM", "+12125550100", time.Date(2026, 3, 4, 21, 0, 0, 0, ny), time.Date(2026, 3, 5, 8, 0, 0, 0, ny)},
+		// 8 AM in New York is 6 AM in Denver and 5 AM in Los Angeles
+		{"unknown area code waits for the Pacific", "+14165550100", time.Date(2026, 3, 4, 8, 0, 0, 0, ny), time.Date(2026, 3, 4, 11, 0, 0, 0, ny)},
+		// At 8:30 PM Central it is already 9:30 PM Eastern, and the next
+		// morning Central opens an hour after Eastern
+		{"split area code must suit both zones", "+18505550100", time.Date(2026, 3, 4, 21, 30, 0, 0, ny), time.Date(2026, 3, 5, 9, 0, 0, 0, ny)},
+		{"international numbers use the default zone", "+442071234567", time.Date(2026, 3, 4, 22, 0, 0, 0, london), time.Date(2026, 3, 5, 8, 0, 0, 0, london)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.NextAllowed(tt.phone, tt.at); !got.Equal(tt.want) {
+				t.Errorf("NextAllowed() = %v, want %v", got.In(ny), tt.want.In(ny))
+			}
+		})
+	}
+
+	anyTime, err := ParseCallingWindow("", "", nil)
+	if err != nil {
+		t.Fatalf("ParseCallingWindow() error = %v", err)
+	}
+	at := time.Date(2026, 3, 4, 3, 0, 0, 0, ny)
+	if got := anyTime.NextAllowed("+12125550100", at); !got.Equal(at) {
+		t.Errorf("empty window NextAllowed() = %v, want %v", got, at)
+	}
+
+	for _, bounds := range [][2]string{{"21:00", "08:00"}, {"8am", "21:00"}, {"08:00", ""}} {
+		if _, err := ParseCallingWindow(bounds[0], bounds[1], nil); err == nil {
+			t.Errorf("ParseCallingWindow(%q, %q) error = nil, want error", bounds[0], bounds[1])
+		}
+	}
+}
+
+func TestComplianceError(t *testing.T) {
+	dnc := &ComplianceError{Reason: ComplianceReasonDoNotCall, PhoneNumber: "+12125550100"}
+	if got := dnc.Error(); got != "+12125550100 is on the do-not-call list" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	next := time.Date(2026, 3, 5, 13, 0, 0, 0, time.UTC)
+	window := &ComplianceError{Reason: ComplianceReasonCallingWindow, PhoneNumber: "+12125550100", NextAllowed: &next}
+	if got := window.Error(); got != "+12125550100 is outside the calling window until 2026-03-05T13:00:00Z" {
+		t.Errorf("Error() = %q", got)
+	}
+}
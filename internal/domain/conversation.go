@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageChannel is how messages reach a customer's phone.
+type MessageChannel string
+
+const (
+	MessageChannelSMS      MessageChannel = "sms"
+	MessageChannelWhatsApp MessageChannel = "whatsapp"
+)
+
+// ParseMessageChannel parses a channel name. Empty is SMS.
+func ParseMessageChannel(s string) (MessageChannel, error) {
+	switch c := MessageChannel(strings.ToLower(strings.TrimSpace(s))); c {
+	case "":
+		return MessageChannelSMS, nil
+	case MessageChannelSMS, MessageChannelWhatsApp:
+		return c, nil
+	default:
+		return "", fmt.Errorf("channel must be sms or whatsapp, got %q", s)
+	}
+}
+
+// MessageDirection is whether a message was sent to or received from the
+// customer.
+type MessageDirection string
+
+const (
+	MessageDirectionInbound  MessageDirection = "inbound"
+	MessageDirectionOutbound MessageDirection = "outbound"
+)
+
+// ConversationMessage is a text or WhatsApp message exchanged with a
+// customer. Messages are kept by the customer's phone number, so a
+// conversation outlives changes to the customer record.
+type ConversationMessage struct {
+	ID                uuid.UUID        `json:"id"`
+	PhoneNumber       string           `json:"phone_number"`
+	Channel           MessageChannel   `json:"channel"`
+	Direction         MessageDirection `json:"direction"`
+	Body              string           `json:"body"`
+	ProviderMessageID string           `json:"provider_message_id,omitempty"`
+	CallID            *uuid.UUID       `json:"call_id,omitempty"` // Quote the message was about
+	CreatedAt         time.Time        `json:"created_at"`
+}
+
+// NewConversationMessage creates a message with an E.164 phone number.
+func NewConversationMessage(phoneNumber string, channel MessageChannel, direction MessageDirection, body string) *ConversationMessage {
+	return &ConversationMessage{
+		ID:          uuid.New(),
+		PhoneNumber: phoneNumber,
+		Channel:     channel,
+		Direction:   direction,
+		Body:        body,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// IsInbound returns true for a message from the customer.
+func (m *ConversationMessage) IsInbound() bool {
+	return m.Direction == MessageDirectionInbound
+}
+
+// WhatsApp opt-in sources.
+const (
+	WhatsAppOptInSourceStaff    = "staff"    // Recorded by staff on the customer page
+	WhatsAppOptInSourceWhatsApp = "whatsapp" // The customer messaged an opt-in keyword
+)
+
+// WhatsAppOptIn records a customer's agreement to receive WhatsApp
+// messages, which WhatsApp requires before a business messages them first.
+type WhatsAppOptIn struct {
+	PhoneNumber string     `json:"phone_number"`
+	Source      string     `json:"source"`
+	OptedInAt   time.Time  `json:"opted_in_at"`
+	OptedOutAt  *time.Time `json:"opted_out_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NewWhatsAppOptIn records an opt-in for an E.164 phone number.
+func NewWhatsAppOptIn(phoneNumber, source string) *WhatsAppOptIn {
+	now := time.Now().UTC()
+	return &WhatsAppOptIn{
+		PhoneNumber: phoneNumber,
+		Source:      source,
+		OptedInAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsActive returns true if the customer is opted in.
+func (o *WhatsAppOptIn) IsActive() bool {
+	return o != nil && o.OptedOutAt == nil
+}
+
+// OptOut records that the customer withdrew their opt-in.
+func (o *WhatsAppOptIn) OptOut() {
+	if o.OptedOutAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	o.OptedOutAt = &now
+	o.UpdatedAt = now
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CRM mapping source fields: the call and quote values that can be copied
+// to CRM fields.
+const (
+	CRMFieldCallerName        = "caller_name"
+	CRMFieldEmail             = "email"
+	CRMFieldPhone             = "phone"
+	CRMFieldCompany           = "company"
+	CRMFieldProjectType       = "project_type"
+	CRMFieldRequirements      = "requirements"
+	CRMFieldTimeline          = "timeline"
+	CRMFieldBudgetRange       = "budget_range"
+	CRMFieldContactPreference = "contact_preference"
+	CRMFieldAdditionalInfo    = "additional_info"
+	CRMFieldCallSummary       = "call_summary"
+	CRMFieldCallID            = "call_id"
+	CRMFieldQuoteNumber       = "quote_number"
+	CRMFieldQuoteStatus       = "quote_status"
+	CRMFieldQuoteTotal        = "quote_total"
+	CRMFieldQuoteCurrency     = "quote_currency"
+)
+
+// CRMSourceFields lists the fields a mapping may copy from, in the order
+// they are offered.
+var CRMSourceFields = []string{
+	CRMFieldCallerName,
+	CRMFieldEmail,
+	CRMFieldPhone,
+	CRMFieldCompany,
+	CRMFieldProjectType,
+	CRMFieldRequirements,
+	CRMFieldTimeline,
+	CRMFieldBudgetRange,
+	CRMFieldContactPreference,
+	CRMFieldAdditionalInfo,
+	CRMFieldCallSummary,
+	CRMFieldCallID,
+	CRMFieldQuoteNumber,
+	CRMFieldQuoteStatus,
+	CRMFieldQuoteTotal,
+	CRMFieldQuoteCurrency,
+}
+
+// MaxCRMFieldLen bounds CRM field names and deal stages in a mapping.
+const MaxCRMFieldLen = 100
+
+// crmFieldPattern matches CRM field API names, such as HubSpot internal
+// property names and Salesforce custom fields ending in __c.
+var crmFieldPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// CRMFieldMapping says which call and quote values are copied to which CRM
+// fields, on top of the name, email, phone, company, amount and stage every
+// sync sets, and which deal stage each quote status maps to. It is stored
+// as JSON in the crm_field_mapping setting.
+type CRMFieldMapping struct {
+	// Contact and Deal map a source field to the CRM field it is copied to.
+	Contact map[string]string `json:"contact,omitempty"`
+	Deal    map[string]string `json:"deal,omitempty"`
+
+	// Stages maps a quote status to a deal stage; statuses without one use
+	// the CRM's standard pipeline.
+	Stages map[QuoteStatus]string `json:"stages,omitempty"`
+}
+
+// ParseCRMFieldMapping reads a mapping stored as JSON. An empty value is an
+// empty mapping.
+func ParseCRMFieldMapping(value string) (*CRMFieldMapping, error) {
+	m := &CRMFieldMapping{}
+	if strings.TrimSpace(value) == "" {
+		return m, nil
+	}
+	if err := json.Unmarshal([]byte(value), m); err != nil {
+		return nil, fmt.Errorf("invalid crm field mapping: %w", err)
+	}
+	return m, nil
+}
+
+// Validate checks the mapping's source fields, CRM field names and quote
+// statuses, dropping entries without a CRM field or stage.
+func (m *CRMFieldMapping) Validate() error {
+	for name, fields := range map[string]*map[string]string{"contact": &m.Contact, "deal": &m.Deal} {
+		cleaned := make(map[string]string, len(*fields))
+		for source, field := range *fields {
+			source, field = strings.TrimSpace(source), strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if !isCRMSourceField(source) {
+				return NewValidationError(name, fmt.Sprintf("unknown source field %q", source))
+			}
+			if len(field) > MaxCRMFieldLen || !crmFieldPattern.MatchString(field) {
+				return NewValidationError(name, fmt.Sprintf("%q is not a valid CRM field name", field))
+			}
+			cleaned[source] = field
+		}
+		*fields = cleaned
+	}
+
+	stages := make(map[QuoteStatus]string, len(m.Stages))
+	for status, stage := range m.Stages {
+		stage = strings.TrimSpace(stage)
+		if stage == "" {
+			continue
+		}
+		if !status.IsValid() {
+			return NewValidationError("stages", fmt.Sprintf("unknown quote status %q", status))
+		}
+		if len(stage) > MaxCRMFieldLen {
+			return NewValidationError("stages", fmt.Sprintf("stage for %s must be at most %d characters", status, MaxCRMFieldLen))
+		}
+		stages[status] = stage
+	}
+	m.Stages = stages
+	return nil
+}
+
+func isCRMSourceField(field string) bool {
+	for _, f := range CRMSourceFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CRMSyncStatus is where a call is in being synced to the CRM.
+type CRMSyncStatus string
+
+const (
+	CRMSyncPending   CRMSyncStatus = "pending"   // Waiting for an attempt
+	CRMSyncSucceeded CRMSyncStatus = "succeeded" // The CRM has the latest call and quote
+	CRMSyncFailed    CRMSyncStatus = "failed"    // Gave up after retries; shown in the sync error queue
+)
+
+// CRM sync retry backoff bounds.
+const (
+	crmSyncBaseDelay = time.Minute
+	crmSyncMaxDelay  = time.Hour
+)
+
+// CRMSyncMaxAttempts is how many times a sync is tried before it is moved
+// to the error queue. With the backoff this spans about an hour.
+const CRMSyncMaxAttempts = 6
+
+// CRMSync tracks a call's contact and deal in the CRM. There is one per
+// call; each change to the call or its quote queues it again.
+type CRMSync struct {
+	Provider      string        `json:"provider"`
+	CallID        uuid.UUID     `json:"call_id"`
+	Status        CRMSyncStatus `json:"status"`
+	Attempts      int           `json:"attempts"`
+	ContactID     string        `json:"contact_id,omitempty"`
+	DealID        string        `json:"deal_id,omitempty"`
+	LastError     *string       `json:"last_error,omitempty"`
+	RequestedAt   time.Time     `json:"requested_at"` // When the sync was last queued
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+	SyncedAt      *time.Time    `json:"synced_at,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// MarkSucceeded records a successful sync and the CRM record IDs.
+func (s *CRMSync) MarkSucceeded(contactID, dealID string) {
+	now := time.Now().UTC()
+	s.Status = CRMSyncSucceeded
+	s.Attempts++
+	s.ContactID = contactID
+	if dealID != "" {
+		s.DealID = dealID
+	}
+	s.LastError = nil
+	s.SyncedAt = &now
+	s.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt, retrying with exponential backoff
+// (1m, 2m, 4m, ... capped at one hour) until attempts run out.
+func (s *CRMSync) MarkFailed(err error) {
+	now := time.Now().UTC()
+	s.Attempts++
+	errMsg := err.Error()
+	s.LastError = &errMsg
+	s.UpdatedAt = now
+
+	if s.Attempts >= CRMSyncMaxAttempts {
+		s.Status = CRMSyncFailed
+		return
+	}
+	backoff := crmSyncBaseDelay
+	for i := 1; i < s.Attempts && backoff < crmSyncMaxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > crmSyncMaxDelay {
+		backoff = crmSyncMaxDelay
+	}
+	s.Status = CRMSyncPending
+	s.NextAttemptAt = now.Add(backoff)
+}
+
+// Retry queues a failed sync for another round of attempts.
+func (s *CRMSync) Retry() {
+	now := time.Now().UTC()
+	s.Status = CRMSyncPending
+	s.Attempts = 0
+	s.NextAttemptAt = now
+	s.UpdatedAt = now
+}
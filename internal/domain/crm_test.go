@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCRMFieldMapping_Validate(t *testing.T) {
+	m := &CRMFieldMapping{
+		Contact: map[string]string{" project_type ": " Project_Type__c ", "timeline": ""},
+		Deal:    map[string]string{"quote_number": "quote_number"},
+		Stages:  map[QuoteStatus]string{QuoteStatusSent: " qualifiedtobuy ", QuoteStatusDraft: " "},
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(m.Contact) != 1 || m.Contact["project_type"] != "Project_Type__c" {
+		t.Errorf("expected trimmed contact fields without empty entries, got %v", m.Contact)
+	}
+	if len(m.Stages) != 1 || m.Stages[QuoteStatusSent] != "qualifiedtobuy" {
+		t.Errorf("expected trimmed stages without empty entries, got %v", m.Stages)
+	}
+
+	invalid := []*CRMFieldMapping{
+		{Contact: map[string]string{"favourite_colour": "colour"}},
+		{Deal: map[string]string{"quote_total": "amount; drop"}},
+		{Stages: map[QuoteStatus]string{"archived": "closedlost"}},
+	}
+	for _, m := range invalid {
+		if err := m.Validate(); err == nil {
+			t.Errorf("expected error for %+v", m)
+		}
+	}
+}
+
+func TestParseCRMFieldMapping(t *testing.T) {
+	m, err := ParseCRMFieldMapping(`{"contact":{"timeline":"timeline"},"stages":{"accepted":"won"}}`)
+	if err != nil {
+		t.Fatalf("ParseCRMFieldMapping() error = %v", err)
+	}
+	if m.Contact["timeline"] != "timeline" || m.Stages[QuoteStatusAccepted] != "won" {
+		t.Errorf("unexpected mapping %+v", m)
+	}
+
+	if m, err := ParseCRMFieldMapping(""); err != nil || len(m.Contact) != 0 {
+		t.Errorf("expected empty mapping, got %+v, %v", m, err)
+	}
+	if _, err := ParseCRMFieldMapping("{"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestCRMSync_MarkFailed(t *testing.T) {
+	s := &CRMSync{Status: CRMSyncPending}
+	before := time.Now()
+	s.MarkFailed(errors.New("status 502"))
+	if s.Status != CRMSyncPending || s.Attempts != 1 {
+		t.Fatalf("expected pending after first failure, got %s (%d attempts)", s.Status, s.Attempts)
+	}
+	if delay := s.NextAttemptAt.Sub(before); delay < time.Minute || delay > time.Minute+time.Second {
+		t.Errorf("expected a one minute backoff, got %v", delay)
+	}
+
+	for s.Attempts < CRMSyncMaxAttempts {
+		s.MarkFailed(errors.New("status 502"))
+	}
+	if s.Status != CRMSyncFailed || s.LastError == nil {
+		t.Fatalf("expected failed with an error after %d attempts, got %s", CRMSyncMaxAttempts, s.Status)
+	}
+
+	s.Retry()
+	if s.Status != CRMSyncPending || s.Attempts != 0 {
+		t.Errorf("expected retry to reset the sync, got %s (%d attempts)", s.Status, s.Attempts)
+	}
+
+	s.MarkSucceeded("101", "")
+	s.DealID = "202"
+	s.MarkSucceeded("101", "")
+	if s.Status != CRMSyncSucceeded || s.LastError != nil || s.SyncedAt == nil || s.DealID != "202" {
+		t.Errorf("unexpected sync after success: %+v", s)
+	}
+}
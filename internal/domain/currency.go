@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+)
+
+// DefaultCurrency is the currency quotes are priced in when neither the
+// settings nor the configuration name one.
+const DefaultCurrency = "USD"
+
+// ErrInvalidCurrency is returned for a currency that is not an ISO 4217 code.
+var ErrInvalidCurrency = errors.New("currency must be a three-letter ISO 4217 code")
+
+// currencyPattern matches canonical ISO 4217 codes such as "USD".
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NormalizeCurrency returns a currency code in canonical form, e.g. "EUR"
+// for " eur ".
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCurrency returns true if code is a canonical ISO 4217 code.
+func IsValidCurrency(code string) bool {
+	return currencyPattern.MatchString(code)
+}
+
+// CurrencyScale returns the number of decimal places of the currency's minor
+// unit under ISO 4217: 2 for USD, 0 for JPY and 3 for KWD. Codes it doesn't
+// know are taken to have 2.
+func CurrencyScale(code string) int {
+	unit, err := currency.ParseISO(NormalizeCurrency(code))
+	if err != nil {
+		return 2
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	return scale
+}
+
+// ToMinorUnits converts an amount to the currency's minor unit, such as
+// cents, as payment processors expect it.
+func ToMinorUnits(amount float64, code string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyScale(code))))
+}
+
+// FromMinorUnits converts an amount in the currency's minor unit back to
+// whole units.
+func FromMinorUnits(amount int64, code string) float64 {
+	return float64(amount) / math.Pow10(CurrencyScale(code))
+}
+
+// RoundToMinorUnit rounds an amount to the currency's minor unit.
+func RoundToMinorUnit(amount float64, code string) float64 {
+	return FromMinorUnits(ToMinorUnits(amount, code), code)
+}
+
+// Currency returns the currency the call's quote was priced in, as recorded
+// when it was generated. It returns "" for quotes generated before
+// currencies were recorded.
+func (c *Call) Currency() string {
+	if c.ExtractedData == nil {
+		return ""
+	}
+	return NormalizeCurrency(c.ExtractedData.Currency)
+}
+
+// ExchangeRates are the units of each currency one unit of Base buys.
+type ExchangeRates struct {
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// ToBase converts an amount in currency to the base currency. It returns
+// false when there is no rate for the currency.
+func (r *ExchangeRates) ToBase(amount float64, currency string) (float64, bool) {
+	currency = NormalizeCurrency(currency)
+	if currency == r.Base {
+		return amount, true
+	}
+	rate, ok := r.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+	return amount / rate, true
+}
+
+// CurrencyTotal is the number and total value of quotes in one currency.
+type CurrencyTotal struct {
+	Currency string  `json:"currency"`
+	Count    int     `json:"count"`
+	Total    float64 `json:"total"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		expected int64
+	}{
+		{1200, "USD", 120000},
+		{19.99, "usd", 1999},
+		{1200, "JPY", 1200},
+		{12.3456, "KWD", 12346},
+		{10, "XYZ", 1000},
+	}
+
+	for _, tt := range tests {
+		got := ToMinorUnits(tt.amount, tt.currency)
+		if got != tt.expected {
+			t.Errorf("ToMinorUnits(%v, %q) = %d, expected %d", tt.amount, tt.currency, got, tt.expected)
+		}
+		if back := FromMinorUnits(got, tt.currency); back != RoundToMinorUnit(tt.amount, tt.currency) {
+			t.Errorf("FromMinorUnits(%d, %q) = %v, expected %v", got, tt.currency, back, RoundToMinorUnit(tt.amount, tt.currency))
+		}
+	}
+}
+
+func TestDepositAmount(t *testing.T) {
+	if got := DepositAmount(1001, 25, "USD"); got != 250.25 {
+		t.Errorf("expected a 250.25 USD deposit, got %v", got)
+	}
+	if got := DepositAmount(1001, 25, "JPY"); got != 250 {
+		t.Errorf("expected a 250 JPY deposit, got %v", got)
+	}
+}
+
+func TestQuotePayment_MinorUnits(t *testing.T) {
+	payment := &QuotePayment{Amount: 1200, Currency: "JPY"}
+	if got := payment.AmountMinorUnits(); got != 1200 {
+		t.Errorf("expected 1200 yen requested, got %d", got)
+	}
+
+	payment.MarkPaid("pi_1", 1200, time.Now())
+	if payment.AmountPaid != 1200 {
+		t.Errorf("expected 1200 yen paid, got %v", payment.AmountPaid)
+	}
+}
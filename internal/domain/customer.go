@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CustomerListFilter selects customers to list.
+type CustomerListFilter struct {
+	Search string   // Matches name, email, company or phone number
+	Tags   []string // Only customers tagged with all of these
+}
+
+// Customer is a person or business QuickQuote has spoken with, identified by
+// their E.164 phone number. Every call with that number is linked to the
+// customer, and through those calls, every quote.
+type Customer struct {
+	ID          uuid.UUID `json:"id"`
+	PhoneNumber string    `json:"phone_number"`
+	Name        string    `json:"name,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	Address     string    `json:"address,omitempty"`
+	Notes       string    `json:"notes,omitempty"`
+
+	// OptedOutAt is when the customer asked not to be contacted. Opted-out
+	// customers get no follow-up texts or calls.
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+
+	// PreferredChannel is how the customer is messaged about their quotes.
+	// WhatsApp is only used while they are opted in to it.
+	PreferredChannel MessageChannel `json:"preferred_channel"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewCustomer creates a customer for an E.164 phone number.
+func NewCustomer(phoneNumber string) *Customer {
+	now := time.Now().UTC()
+	return &Customer{
+		ID:               uuid.New(),
+		PhoneNumber:      phoneNumber,
+		PreferredChannel: MessageChannelSMS,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// DisplayName returns the customer's name, falling back to the company and
+// then the phone number.
+func (c *Customer) DisplayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	if c.Company != "" {
+		return c.Company
+	}
+	return c.PhoneNumber
+}
+
+// IsOptedOut returns true if the customer asked not to be contacted.
+func (c *Customer) IsOptedOut() bool {
+	return c.OptedOutAt != nil
+}
+
+// SetOptedOut records or clears the customer's request not to be contacted.
+func (c *Customer) SetOptedOut(optedOut bool) {
+	if optedOut == c.IsOptedOut() {
+		return
+	}
+	now := time.Now().UTC()
+	if optedOut {
+		c.OptedOutAt = &now
+	} else {
+		c.OptedOutAt = nil
+	}
+	c.UpdatedAt = now
+}
+
+// ApplyCallDetails fills in contact details captured on a call. Details the
+// customer already has are kept, so edits made by staff are never overwritten
+// by later calls. It reports whether anything changed.
+func (c *Customer) ApplyCallDetails(call *Call) bool {
+	var name, email, company, address string
+	if call.CallerName != nil {
+		name = *call.CallerName
+	}
+	if d := call.ExtractedData; d != nil {
+		if name == "" {
+			name = d.CallerName
+		}
+		email = d.Email
+		company = d.Company
+		if email == "" {
+			email = customString(d.Custom, "email")
+		}
+		if company == "" {
+			company = customString(d.Custom, "company")
+		}
+		address = customString(d.Custom, "address")
+	}
+
+	changed := false
+	fill := func(field *string, value string) {
+		value = strings.TrimSpace(value)
+		if *field == "" && value != "" {
+			*field = value
+			changed = true
+		}
+	}
+	fill(&c.Name, name)
+	fill(&c.Email, email)
+	fill(&c.Company, company)
+	fill(&c.Address, address)
+
+	if changed {
+		c.UpdatedAt = time.Now().UTC()
+	}
+	return changed
+}
+
+// customString returns a string value from provider-specific extracted fields.
+func customString(custom map[string]interface{}, key string) string {
+	v, ok := custom[key]
+	if !ok || v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// CustomerNumber returns the phone number of the customer on the other end of
+// the call: the caller for inbound calls, or the number dialed for outbound
+// calls. Calls we place are recorded before the provider reports a from
+// number, so a call without one is treated as outbound.
+func (c *Call) CustomerNumber() string {
+	if c.FromNumber == "" || c.isOutbound() {
+		return c.PhoneNumber
+	}
+	return c.FromNumber
+}
+
+// isOutbound reports whether the provider metadata marks the call as one we
+// placed: Twilio and Retell report a direction and Vapi a call type, and
+// calls QuickQuote initiates carry our own metadata back.
+func (c *Call) isOutbound() bool {
+	if dir, ok := c.ProviderMetadata["Direction"].(string); ok && strings.HasPrefix(dir, "outbound") {
+		return true
+	}
+	if call, ok := c.ProviderMetadata["call"].(map[string]interface{}); ok {
+		if call["direction"] == "outbound" || call["call_type"] == "outbound" {
+			return true
+		}
+		if hasPlacedCallMetadata(call["metadata"]) {
+			return true
+		}
+	}
+	if message, ok := c.ProviderMetadata["message"].(map[string]interface{}); ok {
+		if call, ok := message["call"].(map[string]interface{}); ok && call["type"] == "outboundPhoneCall" {
+			return true
+		}
+	}
+	return hasPlacedCallMetadata(c.ProviderMetadata["metadata"])
+}
+
+// hasPlacedCallMetadata reports whether metadata holds any of the keys
+// QuickQuote sets on the calls it places.
+func hasPlacedCallMetadata(metadata interface{}) bool {
+	meta, ok := metadata.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, key := range []string{"campaign_contact_id", "quote_id", "quote_request_call_id"} {
+		if _, ok := meta[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package domain
+
+import "testing"
+
+func TestCustomer_ApplyCallDetails(t *testing.T) {
+	name := "Dana Smith"
+	call := NewCall("p1", "bland", "+15550001111", "+15550002222")
+	call.CallerName = &name
+	call.ExtractedData = &ExtractedData{
+		Email:  "dana@example.com",
+		Custom: map[string]interface{}{"address": "12 Main St", "company": "Acme"},
+	}
+
+	c := NewCustomer("+15550002222")
+	if !c.ApplyCallDetails(call) {
+		t.Fatal("expected details to change")
+	}
+	if c.Name != "Dana Smith" || c.Email != "dana@example.com" || c.Company != "Acme" || c.Address != "12 Main St" {
+		t.Errorf("unexpected customer details %+v", c)
+	}
+
+	// Details already on file are never overwritten.
+	other := "Someone Else"
+	call.CallerName = &other
+	call.ExtractedData.Email = "other@example.com"
+	if c.ApplyCallDetails(call) {
+		t.Error("expected no change when all details are already set")
+	}
+	if c.Name != "Dana Smith" || c.Email != "dana@example.com" {
+		t.Errorf("existing details were overwritten: %+v", c)
+	}
+}
+
+func TestCustomer_DisplayName(t *testing.T) {
+	c := NewCustomer("+15550002222")
+	if c.DisplayName() != "+15550002222" {
+		t.Errorf("expected phone number, got %q", c.DisplayName())
+	}
+	c.Company = "Acme"
+	if c.DisplayName() != "Acme" {
+		t.Errorf("expected company, got %q", c.DisplayName())
+	}
+	c.Name = "Dana"
+	if c.DisplayName() != "Dana" {
+		t.Errorf("expected name, got %q", c.DisplayName())
+	}
+}
+
+func TestCall_CustomerNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		metadata map[string]interface{}
+		expected string
+	}{
+		{"inbound", "+15550002222", nil, "+15550002222"},
+		{"placed, no from number yet", "", nil, "+15550001111"},
+		{"twilio outbound", "+15550002222", map[string]interface{}{"Direction": "outbound-api"}, "+15550001111"},
+		{"twilio inbound", "+15550002222", map[string]interface{}{"Direction": "inbound"}, "+15550002222"},
+		{"campaign call", "+15550002222", map[string]interface{}{
+			"metadata": map[string]interface{}{"campaign_contact_id": "abc"},
+		}, "+15550001111"},
+		{"retell outbound", "+15550002222", map[string]interface{}{
+			"call": map[string]interface{}{"direction": "outbound"},
+		}, "+15550001111"},
+		{"retell campaign call", "+15550002222", map[string]interface{}{
+			"call": map[string]interface{}{"metadata": map[string]interface{}{"campaign_contact_id": "abc"}},
+		}, "+15550001111"},
+		{"vapi outbound", "+15550002222", map[string]interface{}{
+			"message": map[string]interface{}{"call": map[string]interface{}{"type": "outboundPhoneCall"}},
+		}, "+15550001111"},
+		{"vapi inbound", "+15550002222", map[string]interface{}{
+			"message": map[string]interface{}{"call": map[string]interface{}{"type": "inboundPhoneCall"}},
+		}, "+15550002222"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call := NewCall("p1", "bland", "+15550001111", tt.from)
+			call.ProviderMetadata = tt.metadata
+			if got := call.CustomerNumber(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
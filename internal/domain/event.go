@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventFeedVersion is the version of the events feed format. Fields may be
+// added to events within a version but are never removed or changed.
+const EventFeedVersion = "1"
+
+// eventCursorPrefix marks cursors of the current feed version.
+const eventCursorPrefix = "v1:"
+
+// Event is a change to a call or quote recorded in the events feed. Events
+// are the same envelopes sent to outgoing webhook subscribers.
+type Event struct {
+	Sequence  int64            `json:"-"` // Position in the feed, increasing
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CallID    *uuid.UUID       `json:"call_id,omitempty"`
+	Data      json.RawMessage  `json:"data"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// Cursor returns the cursor that continues the feed after this event.
+func (e *Event) Cursor() string {
+	return EncodeEventCursor(e.Sequence)
+}
+
+// EventFilter selects a page of the events feed.
+type EventFilter struct {
+	After int64              // Only events after this sequence
+	Types []WebhookEventType // Only these event types; all when empty
+	Limit int
+}
+
+// EncodeEventCursor returns the opaque cursor for a feed position.
+func EncodeEventCursor(sequence int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(eventCursorPrefix + strconv.FormatInt(sequence, 10)))
+}
+
+// DecodeEventCursor returns the feed position of a cursor.
+func DecodeEventCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), eventCursorPrefix) {
+		return 0, errors.New("invalid cursor")
+	}
+	sequence, err := strconv.ParseInt(strings.TrimPrefix(string(raw), eventCursorPrefix), 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return sequence, nil
+}
@@ -0,0 +1,500 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExperimentDirection is the kind of call an experiment splits.
+type ExperimentDirection string
+
+const (
+	ExperimentDirectionInbound  ExperimentDirection = "inbound"  // Calls received on one phone number
+	ExperimentDirectionOutbound ExperimentDirection = "outbound" // Calls placed with one of the variant prompts
+)
+
+// ExperimentStatus represents the lifecycle state of an experiment.
+type ExperimentStatus string
+
+const (
+	ExperimentStatusDraft   ExperimentStatus = "draft"   // Created, not yet splitting calls
+	ExperimentStatusRunning ExperimentStatus = "running" // Splitting calls between its variants
+	ExperimentStatusStopped ExperimentStatus = "stopped" // Finished; results are kept
+)
+
+// Call metadata keys that tag a call with the experiment variant it was
+// given. They are sent to the provider with the call and come back on its
+// webhooks.
+const (
+	ExperimentMetadataKey        = "experiment_id"
+	ExperimentVariantMetadataKey = "experiment_variant_id"
+)
+
+// ExperimentSignificanceLevel is the p-value below which a variant's
+// difference from the control is reported as significant.
+const ExperimentSignificanceLevel = 0.05
+
+// ErrInvalidExperimentTransition is returned when an experiment cannot move to the requested state.
+var ErrInvalidExperimentTransition = errors.New("invalid experiment status transition")
+
+// Experiment splits inbound or outbound calls between two or more prompts
+// by percentage so their outcomes can be compared. The first variant is the
+// control the others are measured against.
+type Experiment struct {
+	ID          uuid.UUID           `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Direction   ExperimentDirection `json:"direction"`
+	Status      ExperimentStatus    `json:"status"`
+
+	// PhoneNumber is the inbound number whose agent is rotated between the
+	// variants. Outbound experiments have none.
+	PhoneNumber string `json:"phone_number,omitempty"`
+
+	Variants []ExperimentVariant `json:"variants"`
+
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ExperimentVariant is one prompt under test and the percentage of calls it receives.
+type ExperimentVariant struct {
+	ID           uuid.UUID `json:"id"`
+	ExperimentID uuid.UUID `json:"experiment_id"`
+	Name         string    `json:"name"`
+	PromptID     uuid.UUID `json:"prompt_id"`
+	Weight       int       `json:"weight"` // Percentage of calls, 1-100
+	Position     int       `json:"position"`
+}
+
+// NewExperiment creates a draft experiment. Variants are numbered in the
+// order given, so the first is the control.
+func NewExperiment(name string, direction ExperimentDirection, variants []ExperimentVariant) *Experiment {
+	now := time.Now().UTC()
+	e := &Experiment{
+		ID:        uuid.New(),
+		Name:      name,
+		Direction: direction,
+		Status:    ExperimentStatusDraft,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for i, v := range variants {
+		v.ID = uuid.New()
+		v.ExperimentID = e.ID
+		v.Position = i
+		if v.Name == "" {
+			v.Name = fmt.Sprintf("Variant %c", 'A'+i)
+		}
+		e.Variants = append(e.Variants, v)
+	}
+	return e
+}
+
+// Validate checks that the experiment has a usable split.
+func (e *Experiment) Validate() error {
+	if e.Name == "" {
+		return errors.New("name is required")
+	}
+	switch e.Direction {
+	case ExperimentDirectionInbound:
+		if e.PhoneNumber == "" {
+			return errors.New("inbound experiments need a phone number")
+		}
+	case ExperimentDirectionOutbound:
+	default:
+		return fmt.Errorf("direction must be %q or %q", ExperimentDirectionInbound, ExperimentDirectionOutbound)
+	}
+	if len(e.Variants) < 2 {
+		return errors.New("at least two variants are required")
+	}
+
+	total := 0
+	prompts := make(map[uuid.UUID]bool)
+	for _, v := range e.Variants {
+		if v.PromptID == uuid.Nil {
+			return fmt.Errorf("variant %q needs a prompt", v.Name)
+		}
+		if prompts[v.PromptID] {
+			return errors.New("each variant must use a different prompt")
+		}
+		prompts[v.PromptID] = true
+		if v.Weight < 1 || v.Weight > 100 {
+			return fmt.Errorf("variant %q weight must be between 1 and 100", v.Name)
+		}
+		total += v.Weight
+	}
+	if total != 100 {
+		return fmt.Errorf("variant weights must add up to 100, got %d", total)
+	}
+	return nil
+}
+
+// IsRunning returns true if the experiment is splitting calls.
+func (e *Experiment) IsRunning() bool {
+	return e.Status == ExperimentStatusRunning
+}
+
+// Start begins splitting calls.
+func (e *Experiment) Start() error {
+	if e.Status != ExperimentStatusDraft {
+		return ErrInvalidExperimentTransition
+	}
+	now := time.Now().UTC()
+	e.Status = ExperimentStatusRunning
+	e.StartedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// Stop ends a running experiment.
+func (e *Experiment) Stop() error {
+	if e.Status != ExperimentStatusRunning {
+		return ErrInvalidExperimentTransition
+	}
+	now := time.Now().UTC()
+	e.Status = ExperimentStatusStopped
+	e.StoppedAt = &now
+	e.UpdatedAt = now
+	return nil
+}
+
+// Control returns the variant the others are compared against.
+func (e *Experiment) Control() *ExperimentVariant {
+	if len(e.Variants) == 0 {
+		return nil
+	}
+	return &e.Variants[0]
+}
+
+// Variant returns the variant with the given ID, or nil.
+func (e *Experiment) Variant(id uuid.UUID) *ExperimentVariant {
+	for i := range e.Variants {
+		if e.Variants[i].ID == id {
+			return &e.Variants[i]
+		}
+	}
+	return nil
+}
+
+// HasPrompt returns true if one of the variants uses the prompt.
+func (e *Experiment) HasPrompt(promptID uuid.UUID) bool {
+	for _, v := range e.Variants {
+		if v.PromptID == promptID {
+			return true
+		}
+	}
+	return false
+}
+
+// PickVariant chooses a variant by weight. r is a uniform random number
+// in [0, 1).
+func (e *Experiment) PickVariant(r float64) *ExperimentVariant {
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	target := r * float64(total)
+	cumulative := 0
+	for i := range e.Variants {
+		cumulative += e.Variants[i].Weight
+		if target < float64(cumulative) {
+			return &e.Variants[i]
+		}
+	}
+	return &e.Variants[len(e.Variants)-1]
+}
+
+// ExperimentTagFromMetadata reads the experiment and variant a call was
+// tagged with from its provider metadata. Providers echo the metadata sent
+// with a call either at the top level or under "metadata".
+func ExperimentTagFromMetadata(metadata map[string]interface{}) (experimentID, variantID uuid.UUID, ok bool) {
+	if nested, isMap := metadata["metadata"].(map[string]interface{}); isMap {
+		if experimentID, variantID, ok = ExperimentTagFromMetadata(nested); ok {
+			return experimentID, variantID, true
+		}
+	}
+
+	e, _ := metadata[ExperimentMetadataKey].(string)
+	v, _ := metadata[ExperimentVariantMetadataKey].(string)
+	experimentID, err := uuid.Parse(e)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	variantID, err = uuid.Parse(v)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return experimentID, variantID, true
+}
+
+// ExperimentAssignment records the variant a call was given.
+type ExperimentAssignment struct {
+	ID           uuid.UUID `json:"id"`
+	ExperimentID uuid.UUID `json:"experiment_id"`
+	VariantID    uuid.UUID `json:"variant_id"`
+	CallID       uuid.UUID `json:"call_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewExperimentAssignment creates an assignment of a call to a variant.
+func NewExperimentAssignment(variant *ExperimentVariant, callID uuid.UUID) *ExperimentAssignment {
+	return &ExperimentAssignment{
+		ID:           uuid.New(),
+		ExperimentID: variant.ExperimentID,
+		VariantID:    variant.ID,
+		CallID:       callID,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// ExperimentVariantOutcome is the raw outcome totals for one variant's calls.
+type ExperimentVariantOutcome struct {
+	VariantID uuid.UUID
+	Calls     int
+	Completed int
+	Quoted    int
+
+	// Duration statistics over the calls that reported a duration.
+	DurationCount  int
+	DurationMean   float64
+	DurationStdDev float64
+
+	Dispositions map[string]int
+}
+
+// ExperimentResults compares the outcomes of an experiment's variants.
+type ExperimentResults struct {
+	Experiment *Experiment                `json:"experiment"`
+	Variants   []*ExperimentVariantResult `json:"variants"`
+}
+
+// ExperimentVariantResult holds one variant's metrics and, for every
+// variant but the control, how it compares to the control.
+type ExperimentVariantResult struct {
+	Variant            ExperimentVariant `json:"variant"`
+	Control            bool              `json:"control"`
+	Calls              int               `json:"calls"`
+	Completed          int               `json:"completed"`
+	Quoted             int               `json:"quoted"`
+	QuoteRate          float64           `json:"quote_rate"`
+	AvgDurationSeconds float64           `json:"avg_duration_seconds"`
+	Dispositions       map[string]int    `json:"dispositions"`
+
+	// QuoteRateTest compares the share of calls that produced a quote
+	// with the control's (two-proportion z-test).
+	QuoteRateTest *SignificanceTest `json:"quote_rate_test,omitempty"`
+	// DurationTest compares mean call duration with the control's
+	// (Welch's t-test).
+	DurationTest *SignificanceTest `json:"duration_test,omitempty"`
+}
+
+// SignificanceTest is the outcome of comparing a variant with the control.
+type SignificanceTest struct {
+	Difference  float64 `json:"difference"` // Variant minus control
+	Statistic   float64 `json:"statistic"`  // z or t
+	PValue      float64 `json:"p_value"`    // Two-tailed
+	Significant bool    `json:"significant"`
+}
+
+// NewExperimentResults builds results from the outcome totals of each
+// variant. Variants without calls get zero metrics and no tests.
+func NewExperimentResults(e *Experiment, outcomes []*ExperimentVariantOutcome) *ExperimentResults {
+	byVariant := make(map[uuid.UUID]*ExperimentVariantOutcome, len(outcomes))
+	for _, o := range outcomes {
+		byVariant[o.VariantID] = o
+	}
+
+	results := &ExperimentResults{Experiment: e}
+	var control *ExperimentVariantOutcome
+	for i, v := range e.Variants {
+		o := byVariant[v.ID]
+		if o == nil {
+			o = &ExperimentVariantOutcome{VariantID: v.ID}
+		}
+
+		r := &ExperimentVariantResult{
+			Variant:            v,
+			Control:            i == 0,
+			Calls:              o.Calls,
+			Completed:          o.Completed,
+			Quoted:             o.Quoted,
+			AvgDurationSeconds: o.DurationMean,
+			Dispositions:       o.Dispositions,
+		}
+		if r.Dispositions == nil {
+			r.Dispositions = map[string]int{}
+		}
+		if o.Calls > 0 {
+			r.QuoteRate = float64(o.Quoted) / float64(o.Calls)
+		}
+
+		if i == 0 {
+			control = o
+		} else {
+			r.QuoteRateTest = twoProportionZTest(control.Quoted, control.Calls, o.Quoted, o.Calls)
+			r.DurationTest = welchTTest(control, o)
+		}
+		results.Variants = append(results.Variants, r)
+	}
+	return results
+}
+
+// DispositionNames returns every disposition seen across the variants, sorted.
+func (r *ExperimentResults) DispositionNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, v := range r.Variants {
+		for name := range v.Dispositions {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// twoProportionZTest compares the success rates x1/n1 (control) and x2/n2
+// using the pooled two-proportion z-test. It returns nil when either side
+// has no calls or the pooled rate is 0 or 1.
+func twoProportionZTest(x1, n1, x2, n2 int) *SignificanceTest {
+	if n1 == 0 || n2 == 0 {
+		return nil
+	}
+	p1 := float64(x1) / float64(n1)
+	p2 := float64(x2) / float64(n2)
+	pooled := float64(x1+x2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		return nil
+	}
+
+	z := (p2 - p1) / se
+	p := math.Erfc(math.Abs(z) / math.Sqrt2)
+	return &SignificanceTest{
+		Difference:  p2 - p1,
+		Statistic:   z,
+		PValue:      p,
+		Significant: p < ExperimentSignificanceLevel,
+	}
+}
+
+// welchTTest compares the mean call durations of the control and a variant
+// with Welch's unequal-variances t-test. It returns nil when either side
+// has fewer than two durations or both have no variance.
+func welchTTest(control, variant *ExperimentVariantOutcome) *SignificanceTest {
+	n1, n2 := float64(control.DurationCount), float64(variant.DurationCount)
+	if n1 < 2 || n2 < 2 {
+		return nil
+	}
+	v1 := control.DurationStdDev * control.DurationStdDev / n1
+	v2 := variant.DurationStdDev * variant.DurationStdDev / n2
+	se := math.Sqrt(v1 + v2)
+	if se == 0 {
+		return nil
+	}
+
+	t := (variant.DurationMean - control.DurationMean) / se
+	df := (v1 + v2) * (v1 + v2) / (v1*v1/(n1-1) + v2*v2/(n2-1))
+	p := studentTPValue(t, df)
+	return &SignificanceTest{
+		Difference:  variant.DurationMean - control.DurationMean,
+		Statistic:   t,
+		PValue:      p,
+		Significant: p < ExperimentSignificanceLevel,
+	}
+}
+
+// studentTPValue returns the two-tailed p-value of t under Student's t
+// distribution with df degrees of freedom.
+func studentTPValue(t, df float64) float64 {
+	x := df / (df + t*t)
+	return regularizedIncompleteBeta(x, df/2, 0.5)
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) with the continued fraction
+// from Numerical Recipes (betacf).
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgab, _ := math.Lgamma(a + b)
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly for x below (a+1)/(a+b+2);
+	// use the symmetry I_x(a,b) = 1 - I_(1-x)(b,a) above it.
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(1-x, b, a)/b
+	}
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 1e-12
+		tiny          = 1e-300
+	)
+
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestExperiment(weights ...int) *Experiment {
+	variants := make([]ExperimentVariant, 0, len(weights))
+	for _, w := range weights {
+		variants = append(variants, ExperimentVariant{PromptID: uuid.New(), Weight: w})
+	}
+	return NewExperiment("Greeting", ExperimentDirectionOutbound, variants)
+}
+
+func TestNewExperiment(t *testing.T) {
+	e := newTestExperiment(50, 50)
+
+	if e.Status != ExperimentStatusDraft {
+		t.Errorf("Status = %s, want draft", e.Status)
+	}
+	for i, v := range e.Variants {
+		if v.ExperimentID != e.ID || v.Position != i || v.ID == uuid.Nil {
+			t.Errorf("variant %d = %+v", i, v)
+		}
+	}
+	if e.Variants[0].Name != "Variant A" || e.Variants[1].Name != "Variant B" {
+		t.Errorf("default names = %q, %q", e.Variants[0].Name, e.Variants[1].Name)
+	}
+}
+
+func TestExperiment_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(e *Experiment)
+		wantErr bool
+	}{
+		{"valid", func(e *Experiment) {}, false},
+		{"missing name", func(e *Experiment) { e.Name = "" }, true},
+		{"unknown direction", func(e *Experiment) { e.Direction = "sideways" }, true},
+		{"inbound without number", func(e *Experiment) { e.Direction = ExperimentDirectionInbound }, true},
+		{"inbound with number", func(e *Experiment) {
+			e.Direction = ExperimentDirectionInbound
+			e.PhoneNumber = "+15551234567"
+		}, false},
+		{"single variant", func(e *Experiment) { e.Variants = e.Variants[:1]; e.Variants[0].Weight = 100 }, true},
+		{"weights not 100", func(e *Experiment) { e.Variants[0].Weight = 40 }, true},
+		{"zero weight", func(e *Experiment) { e.Variants[0].Weight = 0; e.Variants[1].Weight = 100 }, true},
+		{"same prompt twice", func(e *Experiment) { e.Variants[1].PromptID = e.Variants[0].PromptID }, true},
+		{"missing prompt", func(e *Experiment) { e.Variants[1].PromptID = uuid.Nil }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestExperiment(50, 50)
+			tt.modify(e)
+			if err := e.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExperiment_Transitions(t *testing.T) {
+	e := newTestExperiment(50, 50)
+
+	if err := e.Stop(); err != ErrInvalidExperimentTransition {
+		t.Errorf("Stop() on a draft = %v, want ErrInvalidExperimentTransition", err)
+	}
+	if err := e.Start(); err != nil || !e.IsRunning() || e.StartedAt == nil {
+		t.Fatalf("Start() = %v, status %s", err, e.Status)
+	}
+	if err := e.Start(); err != ErrInvalidExperimentTransition {
+		t.Errorf("Start() twice = %v, want ErrInvalidExperimentTransition", err)
+	}
+	if err := e.Stop(); err != nil || e.Status != ExperimentStatusStopped || e.StoppedAt == nil {
+		t.Errorf("Stop() = %v, status %s", err, e.Status)
+	}
+}
+
+func TestExperiment_PickVariant(t *testing.T) {
+	e := newTestExperiment(20, 30, 50)
+
+	tests := []struct {
+		r    float64
+		want int
+	}{
+		{0, 0},
+		{0.19, 0},
+		{0.2, 1},
+		{0.49, 1},
+		{0.5, 2},
+		{0.999, 2},
+	}
+	for _, tt := range tests {
+		if got := e.PickVariant(tt.r); got.ID != e.Variants[tt.want].ID {
+			t.Errorf("PickVariant(%v) = %s, want %s", tt.r, got.Name, e.Variants[tt.want].Name)
+		}
+	}
+}
+
+func TestExperimentTagFromMetadata(t *testing.T) {
+	experimentID, variantID := uuid.New(), uuid.New()
+	tag := map[string]interface{}{
+		ExperimentMetadataKey:        experimentID.String(),
+		ExperimentVariantMetadataKey: variantID.String(),
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		wantOK   bool
+	}{
+		{"top level", tag, true},
+		{"nested in webhook payload", map[string]interface{}{"call_id": "abc", "metadata": tag}, true},
+		{"untagged", map[string]interface{}{"call_id": "abc"}, false},
+		{"nil", nil, false},
+		{"invalid id", map[string]interface{}{ExperimentMetadataKey: "x", ExperimentVariantMetadataKey: variantID.String()}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, v, ok := ExperimentTagFromMetadata(tt.metadata)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (e != experimentID || v != variantID) {
+				t.Errorf("tag = %s/%s, want %s/%s", e, v, experimentID, variantID)
+			}
+		})
+	}
+}
+
+func TestNewExperimentResults(t *testing.T) {
+	e := newTestExperiment(50, 50)
+	control, variant := e.Variants[0], e.Variants[1]
+
+	results := NewExperimentResults(e, []*ExperimentVariantOutcome{
+		{VariantID: control.ID, Calls: 200, Completed: 190, Quoted: 40,
+			DurationCount: 190, DurationMean: 180, DurationStdDev: 60,
+			Dispositions: map[string]int{"qualified": 40}},
+		{VariantID: variant.ID, Calls: 200, Completed: 195, Quoted: 70,
+			DurationCount: 195, DurationMean: 185, DurationStdDev: 60,
+			Dispositions: map[string]int{"qualified": 70, "not_interested": 5}},
+	})
+
+	if len(results.Variants) != 2 {
+		t.Fatalf("got %d variant results", len(results.Variants))
+	}
+	c, v := results.Variants[0], results.Variants[1]
+	if !c.Control || c.QuoteRateTest != nil || c.DurationTest != nil {
+		t.Errorf("control result = %+v", c)
+	}
+	if c.QuoteRate != 0.2 || v.QuoteRate != 0.35 {
+		t.Errorf("quote rates = %v, %v", c.QuoteRate, v.QuoteRate)
+	}
+
+	// 20% vs 35% over 200 calls each: z ≈ 3.36, p ≈ 0.0008
+	if v.QuoteRateTest == nil || !v.QuoteRateTest.Significant {
+		t.Fatalf("quote rate test = %+v, want significant", v.QuoteRateTest)
+	}
+	if math.Abs(v.QuoteRateTest.Statistic-3.36) > 0.01 || math.Abs(v.QuoteRateTest.PValue-0.00078) > 0.0001 {
+		t.Errorf("quote rate z = %v, p = %v", v.QuoteRateTest.Statistic, v.QuoteRateTest.PValue)
+	}
+
+	// 180s vs 185s with sd 60s: t ≈ 0.83, p ≈ 0.41
+	if v.DurationTest == nil || v.DurationTest.Significant {
+		t.Fatalf("duration test = %+v, want not significant", v.DurationTest)
+	}
+	if math.Abs(v.DurationTest.PValue-0.41) > 0.01 {
+		t.Errorf("duration p = %v, want about 0.41", v.DurationTest.PValue)
+	}
+
+	if names := results.DispositionNames(); len(names) != 2 || names[0] != "not_interested" || names[1] != "qualified" {
+		t.Errorf("DispositionNames() = %v", names)
+	}
+}
+
+func TestNewExperimentResults_NoCalls(t *testing.T) {
+	e := newTestExperiment(50, 50)
+
+	results := NewExperimentResults(e, nil)
+	for _, r := range results.Variants {
+		if r.Calls != 0 || r.QuoteRateTest != nil || r.DurationTest != nil || r.Dispositions == nil {
+			t.Errorf("result without calls = %+v", r)
+		}
+	}
+}
+
+func TestStudentTPValue(t *testing.T) {
+	tests := []struct {
+		t, df, want float64
+	}{
+		{0, 10, 1},
+		{2.228, 10, 0.05},  // Two-tailed 5% critical value
+		{2.042, 30, 0.05},  // Two-tailed 5% critical value
+		{3.169, 10, 0.01},  // Two-tailed 1% critical value
+		{-2.228, 10, 0.05}, // Symmetric
+	}
+	for _, tt := range tests {
+		if got := studentTPValue(tt.t, tt.df); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("studentTPValue(%v, %v) = %v, want %v", tt.t, tt.df, got, tt.want)
+		}
+	}
+}
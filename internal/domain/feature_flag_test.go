@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFeatureFlag_Validate(t *testing.T) {
+	user := uuid.New()
+	flag := NewFeatureFlag(" pricing.new_engine ")
+	flag.Description = " New pricing engine "
+	flag.UserIDs = []uuid.UUID{user, user}
+	if err := flag.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if flag.Key != "pricing.new_engine" || flag.Description != "New pricing engine" || len(flag.UserIDs) != 1 {
+		t.Errorf("expected the flag in canonical form, got %+v", flag)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(f *FeatureFlag)
+	}{
+		{"empty key", func(f *FeatureFlag) { f.Key = "" }},
+		{"uppercase key", func(f *FeatureFlag) { f.Key = "NewPricing" }},
+		{"key with spaces", func(f *FeatureFlag) { f.Key = "new pricing" }},
+		{"negative rollout", func(f *FeatureFlag) { f.RolloutPercent = -1 }},
+		{"rollout over 100", func(f *FeatureFlag) { f.RolloutPercent = 101 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFeatureFlag("pricing.new_engine")
+			tt.modify(f)
+			if err := f.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestFeatureFlag_EnabledFor(t *testing.T) {
+	listed := uuid.New()
+
+	flag := NewFeatureFlag("provider.vapi")
+	flag.UserIDs = []uuid.UUID{listed}
+	if flag.EnabledFor(&listed) {
+		t.Error("disabled flag is on for a listed user")
+	}
+
+	flag.Enabled = true
+	if !flag.EnabledFor(&listed) {
+		t.Error("enabled flag is off for a listed user")
+	}
+	if flag.EnabledFor(nil) {
+		t.Error("flag at 0% is on without a user")
+	}
+
+	// Roughly the rollout percentage of users get the flag, and the same
+	// users keep it as the percentage grows.
+	flag.RolloutPercent = 30
+	users := make([]uuid.UUID, 2000)
+	var on []uuid.UUID
+	for i := range users {
+		users[i] = uuid.New()
+		if flag.EnabledFor(&users[i]) {
+			on = append(on, users[i])
+		}
+	}
+	if len(on) < 500 || len(on) > 700 {
+		t.Errorf("flag at 30%% is on for %d of %d users", len(on), len(users))
+	}
+	flag.RolloutPercent = 60
+	for _, id := range on {
+		if !flag.EnabledFor(&id) {
+			t.Fatal("user lost the flag when the rollout grew")
+		}
+	}
+
+	flag.RolloutPercent = 100
+	if !flag.EnabledFor(nil) {
+		t.Error("flag at 100% is off without a user")
+	}
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FollowUpChannel is how a follow-up step reaches the customer.
+type FollowUpChannel string
+
+const (
+	FollowUpChannelSMS  FollowUpChannel = "sms"
+	FollowUpChannelCall FollowUpChannel = "call"
+)
+
+// FollowUpStepStatus represents where a follow-up step is in being carried out.
+type FollowUpStepStatus string
+
+const (
+	FollowUpStepStatusPending   FollowUpStepStatus = "pending"   // Waiting until it is due
+	FollowUpStepStatusSent      FollowUpStepStatus = "sent"      // SMS sent or call placed
+	FollowUpStepStatusCancelled FollowUpStepStatus = "cancelled" // Customer responded, opted out, or staff stopped the sequence
+	FollowUpStepStatusFailed    FollowUpStepStatus = "failed"    // Gave up after repeated errors
+)
+
+// followUpMaxAttempts is how many times sending a step is tried.
+const followUpMaxAttempts = 3
+
+// FollowUpSequenceStep is one step of a follow-up sequence: a channel and
+// how long after the quote was sent to use it.
+type FollowUpSequenceStep struct {
+	Channel FollowUpChannel `json:"channel"`
+	Delay   time.Duration   `json:"delay"`
+}
+
+// ParseFollowUpSequence parses a sequence such as "sms:1h,call:24h,sms:72h".
+// An empty string is an empty sequence.
+func ParseFollowUpSequence(s string) ([]FollowUpSequenceStep, error) {
+	var steps []FollowUpSequenceStep
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		channel, delay, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("follow-up step %q must be channel:delay", part)
+		}
+		step := FollowUpSequenceStep{Channel: FollowUpChannel(strings.ToLower(strings.TrimSpace(channel)))}
+		if step.Channel != FollowUpChannelSMS && step.Channel != FollowUpChannelCall {
+			return nil, fmt.Errorf("follow-up step %q: channel must be sms or call", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(delay))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("follow-up step %q: delay must be a positive duration such as 24h", part)
+		}
+		if len(steps) > 0 && d <= steps[len(steps)-1].Delay {
+			return nil, fmt.Errorf("follow-up step %q must come after the step before it", part)
+		}
+		step.Delay = d
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// FollowUpStep is one scheduled follow-up with a customer about a sent
+// quote. Steps are keyed by the quote's call ID and their position in the
+// sequence.
+type FollowUpStep struct {
+	ID          uuid.UUID          `json:"id"`
+	CallID      uuid.UUID          `json:"call_id"`
+	Position    int                `json:"position"`
+	Channel     FollowUpChannel    `json:"channel"`
+	PhoneNumber string             `json:"phone_number"`
+	DueAt       time.Time          `json:"due_at"`
+	Status      FollowUpStepStatus `json:"status"`
+
+	// Delivery
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	ProviderRef   string     `json:"provider_ref,omitempty"` // SMS message or call ID
+	CancelReason  string     `json:"cancel_reason,omitempty"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewFollowUpSteps schedules the steps of a sequence for a quote sent at sentAt.
+func NewFollowUpSteps(callID uuid.UUID, phoneNumber string, sentAt time.Time, sequence []FollowUpSequenceStep) []*FollowUpStep {
+	now := time.Now().UTC()
+	steps := make([]*FollowUpStep, 0, len(sequence))
+	for i, s := range sequence {
+		due := sentAt.Add(s.Delay).UTC()
+		steps = append(steps, &FollowUpStep{
+			ID:            uuid.New(),
+			CallID:        callID,
+			Position:      i + 1,
+			Channel:       s.Channel,
+			PhoneNumber:   phoneNumber,
+			DueAt:         due,
+			Status:        FollowUpStepStatusPending,
+			NextAttemptAt: due,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+	return steps
+}
+
+// IsDone returns true once the step will not be attempted again.
+func (s *FollowUpStep) IsDone() bool {
+	return s.Status != FollowUpStepStatusPending
+}
+
+// MarkSent records that the SMS was sent or the call placed.
+func (s *FollowUpStep) MarkSent(providerRef string) {
+	now := time.Now().UTC()
+	s.Status = FollowUpStepStatusSent
+	s.ProviderRef = providerRef
+	s.Attempts++
+	s.LastError = nil
+	s.CompletedAt = &now
+	s.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt, retrying with backoff (5m, 10m, ...)
+// until attempts run out.
+func (s *FollowUpStep) MarkFailed(err error) {
+	now := time.Now().UTC()
+	s.Attempts++
+	errMsg := err.Error()
+	s.LastError = &errMsg
+	s.UpdatedAt = now
+
+	if s.Attempts >= followUpMaxAttempts {
+		s.Status = FollowUpStepStatusFailed
+		s.CompletedAt = &now
+		return
+	}
+	s.NextAttemptAt = now.Add(5 * time.Minute << (s.Attempts - 1))
+}
+
+// Cancel stops the step for the given reason.
+func (s *FollowUpStep) Cancel(reason string) {
+	now := time.Now().UTC()
+	s.Status = FollowUpStepStatusCancelled
+	s.CancelReason = reason
+	s.CompletedAt = &now
+	s.UpdatedAt = now
+}
+
+// Defer holds the step back until the given time without counting an attempt.
+func (s *FollowUpStep) Defer(until time.Time) {
+	s.NextAttemptAt = until.UTC()
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// QuietHours is a daily period in which customers are not contacted,
+// expressed in minutes after local midnight in Location. The period may
+// span midnight, e.g. 21:00 to 09:00. Equal start and end means no quiet hours.
+type QuietHours struct {
+	StartMinute int
+	EndMinute   int
+	Location    *time.Location
+}
+
+// ParseQuietHours builds quiet hours from "HH:MM" start and end times.
+// Empty times disable quiet hours.
+func ParseQuietHours(start, end string, loc *time.Location) (QuietHours, error) {
+	q := QuietHours{Location: loc}
+	if start == "" && end == "" {
+		return q, nil
+	}
+	var err error
+	if q.StartMinute, err = parseClockMinute(start); err != nil {
+		return q, fmt.Errorf("quiet hours start: %w", err)
+	}
+	if q.EndMinute, err = parseClockMinute(end); err != nil {
+		return q, fmt.Errorf("quiet hours end: %w", err)
+	}
+	return q, nil
+}
+
+// parseClockMinute parses "HH:MM" into minutes after midnight.
+func parseClockMinute(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains reports whether t falls inside quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	if q.StartMinute == q.EndMinute {
+		return false
+	}
+	minute := q.minuteOf(t)
+	if q.StartMinute < q.EndMinute {
+		return minute >= q.StartMinute && minute < q.EndMinute
+	}
+	return minute >= q.StartMinute || minute < q.EndMinute
+}
+
+// NextAllowed returns t if it is outside quiet hours, otherwise the time
+// quiet hours end.
+func (q QuietHours) NextAllowed(t time.Time) time.Time {
+	if !q.Contains(t) {
+		return t
+	}
+	local := t.In(q.location())
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.EndMinute/60, q.EndMinute%60, 0, 0, local.Location())
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+func (q QuietHours) minuteOf(t time.Time) int {
+	local := t.In(q.location())
+	return local.Hour()*60 + local.Minute()
+}
+
+func (q QuietHours) location() *time.Location {
+	if q.Location == nil {
+		return time.UTC
+	}
+	return q.Location
+}
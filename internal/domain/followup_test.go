@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseFollowUpSequence(t *testing.T) {
+	steps, err := ParseFollowUpSequence(" sms:1h, CALL:24h ,sms:72h")
+	if err != nil {
+		t.Fatalf("ParseFollowUpSequence() error = %v", err)
+	}
+	want := []FollowUpSequenceStep{
+		{FollowUpChannelSMS, time.Hour},
+		{FollowUpChannelCall, 24 * time.Hour},
+		{FollowUpChannelSMS, 72 * time.Hour},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(want))
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+
+	if steps, err := ParseFollowUpSequence(""); err != nil || len(steps) != 0 {
+		t.Errorf("empty sequence = %v, %v; want no steps", steps, err)
+	}
+
+	for _, s := range []string{"sms", "email:1h", "sms:soon", "sms:-1h", "sms:24h,call:1h", "sms:1h,call:1h"} {
+		if _, err := ParseFollowUpSequence(s); err == nil {
+			t.Errorf("ParseFollowUpSequence(%q) error = nil, want error", s)
+		}
+	}
+}
+
+func TestNewFollowUpSteps(t *testing.T) {
+	callID := uuid.New()
+	sentAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	steps := NewFollowUpSteps(callID, "+15550001111", sentAt, []FollowUpSequenceStep{
+		{FollowUpChannelSMS, time.Hour},
+		{FollowUpChannelCall, 24 * time.Hour},
+	})
+
+	if len(steps) != 2 {
+		t.Fatalf("got %d steps, want 2", len(steps))
+	}
+	second := steps[1]
+	if second.Position != 2 || second.Channel != FollowUpChannelCall || second.CallID != callID {
+		t.Errorf("second step = %+v", second)
+	}
+	if want := sentAt.Add(24 * time.Hour); !second.DueAt.Equal(want) || !second.NextAttemptAt.Equal(want) {
+		t.Errorf("second step due %v, next attempt %v; want %v", second.DueAt, second.NextAttemptAt, want)
+	}
+	if second.Status != FollowUpStepStatusPending {
+		t.Errorf("status = %s, want pending", second.Status)
+	}
+}
+
+func TestFollowUpStep_MarkFailed(t *testing.T) {
+	step := NewFollowUpSteps(uuid.New(), "+15550001111", time.Now(), []FollowUpSequenceStep{{FollowUpChannelSMS, time.Hour}})[0]
+
+	step.MarkFailed(errors.New("carrier rejected"))
+	if step.IsDone() || step.Attempts != 1 || step.LastError == nil {
+		t.Fatalf("after one failure step = %+v, want pending with the error recorded", step)
+	}
+	if wait := time.Until(step.NextAttemptAt); wait < 4*time.Minute || wait > 5*time.Minute {
+		t.Errorf("next attempt in %v, want about 5m", wait)
+	}
+
+	step.MarkFailed(errors.New("carrier rejected"))
+	step.MarkFailed(errors.New("carrier rejected"))
+	if step.Status != FollowUpStepStatusFailed || step.CompletedAt == nil {
+		t.Errorf("after %d failures status = %s, want failed", step.Attempts, step.Status)
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	q, err := ParseQuietHours("21:00", "09:00", ny)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want time.Time
+	}{
+		// 8:59 PM local: allowed
+		{time.Date(2026, 3, 4, 20, 59, 0, 0, ny), time.Date(2026, 3, 4, 20, 59, 0, 0, ny)},
+		// 10 PM local: wait until 9 AM the next day
+		{time.Date(2026, 3, 4, 22, 0, 0, 0, ny), time.Date(2026, 3, 5, 9, 0, 0, 0, ny)},
+		// 3 AM local: wait until 9 AM the same day
+		{time.Date(2026, 3, 5, 3, 0, 0, 0, ny), time.Date(2026, 3, 5, 9, 0, 0, 0, ny)},
+		// 9 AM local: allowed
+		{time.Date(2026, 3, 5, 9, 0, 0, 0, ny), time.Date(2026, 3, 5, 9, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		if got := q.NextAllowed(tt.at.UTC()); !got.Equal(tt.want) {
+			t.Errorf("NextAllowed(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	daytime, err := ParseQuietHours("12:00", "13:00", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	if !daytime.Contains(time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC)) || daytime.Contains(time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)) {
+		t.Error("expected 12:00-13:00 quiet hours to contain 12:30 and not 13:00")
+	}
+
+	none, err := ParseQuietHours("", "", nil)
+	if err != nil || none.Contains(time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("empty quiet hours = %+v, %v; want none", none, err)
+	}
+	if _, err := ParseQuietHours("9pm", "09:00", nil); err == nil {
+		t.Error("expected an error for a start time that is not HH:MM")
+	}
+}
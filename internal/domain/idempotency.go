@@ -0,0 +1,16 @@
+package domain
+
+// IdempotentRequest is a mutating API request sent with an Idempotency-Key
+// header, kept so that retries with the same key get the first response
+// instead of repeating the change.
+type IdempotentRequest struct {
+	Key         string // Client key, scoped to the user who sent it
+	RequestHash string // Hash of the method, path and body the key was first used with
+
+	// The stored response; unset while the first request is still running
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Location    string
+	Body        []byte
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LanguageMetadataKey is the call metadata key holding the language a call
+// was placed in, so the quote can be written in the same language.
+const LanguageMetadataKey = "language"
+
+// PromptLocalization is a prompt's spoken text in one language. Empty fields
+// fall back to the prompt's own.
+type PromptLocalization struct {
+	Task             string `json:"task,omitempty"`
+	FirstSentence    string `json:"first_sentence,omitempty"`
+	VoicemailMessage string `json:"voicemail_message,omitempty"`
+	SummaryPrompt    string `json:"summary_prompt,omitempty"`
+	Voice            string `json:"voice,omitempty"` // Voice suited to the language
+}
+
+// IsEmpty returns true if the localization overrides nothing.
+func (l *PromptLocalization) IsEmpty() bool {
+	return l == nil || (l.Task == "" && l.FirstSentence == "" && l.VoicemailMessage == "" && l.SummaryPrompt == "" && l.Voice == "")
+}
+
+// languageTagPattern matches canonical language tags such as "es" or "es-MX".
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// NormalizeLanguage returns a language tag in canonical form, e.g. "es-MX"
+// for "ES_mx".
+func NormalizeLanguage(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	lang, region, hasRegion := strings.Cut(tag, "-")
+	lang = strings.ToLower(lang)
+	if !hasRegion {
+		return lang
+	}
+	return lang + "-" + strings.ToUpper(region)
+}
+
+// BaseLanguage returns a tag's language without its region, e.g. "es" for
+// "es-MX".
+func BaseLanguage(tag string) string {
+	lang, _, _ := strings.Cut(NormalizeLanguage(tag), "-")
+	return lang
+}
+
+// IsValidLanguage returns true if tag is a canonical language tag.
+func IsValidLanguage(tag string) bool {
+	return languageTagPattern.MatchString(tag)
+}
+
+// NormalizeLocalizations returns localizations keyed by canonical language
+// tags, or nil when there are none.
+func NormalizeLocalizations(localizations map[string]*PromptLocalization) map[string]*PromptLocalization {
+	if len(localizations) == 0 {
+		return nil
+	}
+	result := make(map[string]*PromptLocalization, len(localizations))
+	for language, l := range localizations {
+		result[NormalizeLanguage(language)] = l
+	}
+	return result
+}
+
+// ResolveLanguage returns the localization to use for a call in language:
+// the exact language, then the language without its region, then another
+// region of the same language. It returns "" when the prompt's own text
+// should be used, either because it is already in that language or because
+// there is no localization for it.
+func (p *Prompt) ResolveLanguage(language string) string {
+	language = NormalizeLanguage(language)
+	if language == "" || len(p.Localizations) == 0 {
+		return ""
+	}
+	if _, ok := p.Localizations[language]; ok {
+		return language
+	}
+	if NormalizeLanguage(p.Language) == language {
+		return ""
+	}
+
+	base := BaseLanguage(language)
+	if _, ok := p.Localizations[base]; ok {
+		return base
+	}
+	if BaseLanguage(p.Language) == base {
+		return ""
+	}
+
+	var regions []string
+	for tag := range p.Localizations {
+		if BaseLanguage(tag) == base {
+			regions = append(regions, tag)
+		}
+	}
+	if len(regions) == 0 {
+		return ""
+	}
+	sort.Strings(regions)
+	return regions[0]
+}
+
+// Localize returns the prompt as spoken in language, with the localization
+// picked by ResolveLanguage applied over the prompt's own text. The prompt is
+// returned unchanged when no localization applies.
+func (p *Prompt) Localize(language string) *Prompt {
+	resolved := p.ResolveLanguage(language)
+	if resolved == "" {
+		return p
+	}
+	l := p.Localizations[resolved]
+
+	localized := *p
+	localized.Language = NormalizeLanguage(language)
+	if l.Task != "" {
+		localized.Task = l.Task
+	}
+	if l.FirstSentence != "" {
+		localized.FirstSentence = l.FirstSentence
+	}
+	if l.VoicemailMessage != "" {
+		localized.VoicemailMessage = l.VoicemailMessage
+	}
+	if l.SummaryPrompt != "" {
+		localized.SummaryPrompt = l.SummaryPrompt
+	}
+	if l.Voice != "" {
+		localized.Voice = l.Voice
+	}
+	return &localized
+}
+
+// countryCodeLanguages maps country calling codes to the language most
+// callers there speak. Countries with no clear majority language are left
+// out so their calls keep the prompt's default.
+var countryCodeLanguages = map[string]string{
+	"7":   "ru-RU",
+	"20":  "ar-EG",
+	"27":  "en-ZA",
+	"30":  "el-GR",
+	"31":  "nl-NL",
+	"33":  "fr-FR",
+	"34":  "es-ES",
+	"36":  "hu-HU",
+	"39":  "it-IT",
+	"40":  "ro-RO",
+	"43":  "de-AT",
+	"44":  "en-GB",
+	"45":  "da-DK",
+	"46":  "sv-SE",
+	"47":  "no-NO",
+	"48":  "pl-PL",
+	"49":  "de-DE",
+	"51":  "es-PE",
+	"52":  "es-MX",
+	"53":  "es-CU",
+	"54":  "es-AR",
+	"55":  "pt-BR",
+	"56":  "es-CL",
+	"57":  "es-CO",
+	"58":  "es-VE",
+	"60":  "ms-MY",
+	"61":  "en-AU",
+	"62":  "id-ID",
+	"63":  "en-PH",
+	"64":  "en-NZ",
+	"65":  "en-SG",
+	"66":  "th-TH",
+	"81":  "ja-JP",
+	"82":  "ko-KR",
+	"84":  "vi-VN",
+	"86":  "zh-CN",
+	"90":  "tr-TR",
+	"91":  "en-IN",
+	"351": "pt-PT",
+	"353": "en-IE",
+	"358": "fi-FI",
+	"380": "uk-UA",
+	"420": "cs-CZ",
+	"502": "es-GT",
+	"503": "es-SV",
+	"504": "es-HN",
+	"505": "es-NI",
+	"506": "es-CR",
+	"507": "es-PA",
+	"591": "es-BO",
+	"593": "es-EC",
+	"595": "es-PY",
+	"598": "es-UY",
+	"972": "he-IL",
+}
+
+// quebecAreaCodes are the North American area codes where most callers
+// speak French.
+var quebecAreaCodes = map[string]bool{
+	"263": true, "354": true, "367": true, "418": true, "438": true, "450": true,
+	"468": true, "514": true, "579": true, "581": true, "819": true, "873": true,
+}
+
+// LanguageForNumber returns the language callers from a phone number's
+// country most likely speak, judged by its country calling code and, in
+// North America, its area code. It returns "" for numbers that are not in
+// international format or whose country has no single main language.
+func LanguageForNumber(phoneNumber string) string {
+	if areaCode, ok := nanpAreaCode(phoneNumber); ok {
+		if quebecAreaCodes[areaCode] {
+			return "fr-CA"
+		}
+		return "en-US"
+	}
+	if !strings.HasPrefix(strings.TrimSpace(phoneNumber), "+") {
+		return ""
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+	for n := 3; n >= 1; n-- {
+		if len(digits) <= n {
+			continue
+		}
+		if language, ok := countryCodeLanguages[digits[:n]]; ok {
+			return language
+		}
+	}
+	return ""
+}
+
+// Language returns the language the call was held in, as tagged in the
+// metadata QuickQuote sent with it or else as reported by the provider. It
+// returns "" when neither says.
+func (c *Call) Language() string {
+	if nested, ok := c.ProviderMetadata["metadata"].(map[string]interface{}); ok {
+		if language, ok := nested[LanguageMetadataKey].(string); ok && language != "" {
+			return NormalizeLanguage(language)
+		}
+	}
+	language, _ := c.ProviderMetadata[LanguageMetadataKey].(string)
+	return NormalizeLanguage(language)
+}
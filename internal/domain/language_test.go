@@ -0,0 +1,88 @@
+package domain
+
+import "testing"
+
+func TestPrompt_ResolveLanguage(t *testing.T) {
+	prompt := NewPrompt("Intake", "Collect project details.")
+	prompt.Localizations = map[string]*PromptLocalization{
+		"es":    {Task: "Recopila los detalles."},
+		"pt-BR": {Task: "Colete os detalhes."},
+		"en-GB": {FirstSentence: "Hello, thanks for ringing!"},
+	}
+
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{"es-MX", "es"},    // base language
+		{"pt-PT", "pt-BR"}, // another region
+		{"en-GB", "en-GB"}, // exact
+		{"en-AU", ""},      // the prompt's own language
+		{"en-US", ""},      // the prompt's own language
+		{"de-DE", ""},      // not localized
+		{"", ""},           // unknown
+		{"ES_mx", "es"},    // normalized
+	}
+	for _, tt := range tests {
+		if got := prompt.ResolveLanguage(tt.language); got != tt.expected {
+			t.Errorf("ResolveLanguage(%q) = %q, expected %q", tt.language, got, tt.expected)
+		}
+	}
+
+	localized := prompt.Localize("es-MX")
+	if localized.Task != "Recopila los detalles." || localized.Language != "es-MX" || localized.Voice != prompt.Voice {
+		t.Errorf("unexpected localized prompt: %+v", localized)
+	}
+	if prompt.Task != "Collect project details." {
+		t.Error("Localize modified the prompt")
+	}
+	if prompt.Localize("de-DE") != prompt {
+		t.Error("expected the prompt unchanged without a localization")
+	}
+}
+
+func TestPrompt_ValidateLocalizations(t *testing.T) {
+	prompt := NewPrompt("Intake", "Collect project details.")
+	prompt.Localizations = map[string]*PromptLocalization{"Spanish": {Task: "Recopila los detalles."}}
+	if err := prompt.Validate(); err != ErrPromptLocalizationLanguageInvalid {
+		t.Errorf("expected invalid language error, got %v", err)
+	}
+	prompt.Localizations = map[string]*PromptLocalization{"es": {}}
+	if err := prompt.Validate(); err != ErrPromptLocalizationEmpty {
+		t.Errorf("expected empty localization error, got %v", err)
+	}
+}
+
+func TestLanguageForNumber(t *testing.T) {
+	tests := []struct {
+		number   string
+		expected string
+	}{
+		{"+14155550100", "en-US"},
+		{"+15145550100", "fr-CA"},
+		{"(514) 555-0100", "fr-CA"},
+		{"+525512345678", "es-MX"},
+		{"+351912345678", "pt-PT"},
+		{"+442071234567", "en-GB"},
+		{"+41441234567", ""}, // Switzerland has no single language
+		{"12345", ""},
+	}
+	for _, tt := range tests {
+		if got := LanguageForNumber(tt.number); got != tt.expected {
+			t.Errorf("LanguageForNumber(%q) = %q, expected %q", tt.number, got, tt.expected)
+		}
+	}
+}
+
+func TestCall_Language(t *testing.T) {
+	call := &Call{ProviderMetadata: map[string]interface{}{
+		"language": "en",
+		"metadata": map[string]interface{}{LanguageMetadataKey: "es-mx"},
+	}}
+	if got := call.Language(); got != "es-MX" {
+		t.Errorf("Language() = %q, expected the tagged language", got)
+	}
+	if got := (&Call{}).Language(); got != "" {
+		t.Errorf("Language() = %q for an untagged call", got)
+	}
+}
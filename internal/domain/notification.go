@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageAlert describes a usage limit that has been reached or is close to it.
+type UsageAlert struct {
+	Resource string        `json:"resource"` // e.g. "quote_generation"
+	Reason   string        `json:"reason"`   // e.g. "day limit"
+	Used     int           `json:"used"`
+	Limit    int           `json:"limit"`
+	ResetIn  time.Duration `json:"reset_in"`
+	Detail   string        `json:"detail,omitempty"` // What is being done about it, when not deferring requests
+}
+
+// Key identifies the alert for de-duplication.
+func (a UsageAlert) Key() string {
+	return a.Resource + ":" + a.Reason
+}
+
+// NotificationChannel is a way a user can be sent notifications.
+type NotificationChannel string
+
+// Notification channels.
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	NotificationChannelSlack NotificationChannel = "slack" // A personal Slack or Teams incoming webhook
+)
+
+// NotificationChannels lists the channels in the order they are shown.
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelEmail,
+	NotificationChannelSMS,
+	NotificationChannelSlack,
+}
+
+// IsValid returns true if the channel is known.
+func (c NotificationChannel) IsValid() bool {
+	for _, known := range NotificationChannels {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationEvent names an event users can choose to be notified about.
+type NotificationEvent string
+
+const (
+	NotificationEventCallCompleted  NotificationEvent = "call_completed"  // An answered call ended
+	NotificationEventCallFailed     NotificationEvent = "call_failed"     // A call ended without completing
+	NotificationEventQuoteReady     NotificationEvent = "quote_ready"     // A quote was generated for a call
+	NotificationEventQuoteResponded NotificationEvent = "quote_responded" // A customer accepted or declined a quote
+	NotificationEventUsageAlert     NotificationEvent = "usage_alert"     // A usage limit was reached
+	NotificationEventJobFailed      NotificationEvent = "job_failed"      // A quote job ran out of retries
+)
+
+// NotificationEvents lists the events in the order they are shown.
+var NotificationEvents = []NotificationEvent{
+	NotificationEventCallCompleted,
+	NotificationEventCallFailed,
+	NotificationEventQuoteReady,
+	NotificationEventQuoteResponded,
+	NotificationEventUsageAlert,
+	NotificationEventJobFailed,
+}
+
+// notificationChannelEvents lists the events each channel can deliver.
+var notificationChannelEvents = map[NotificationChannel][]NotificationEvent{
+	NotificationChannelEmail: {
+		NotificationEventCallFailed,
+		NotificationEventQuoteReady,
+		NotificationEventQuoteResponded,
+		NotificationEventUsageAlert,
+	},
+	NotificationChannelSMS: {
+		NotificationEventCallCompleted,
+		NotificationEventCallFailed,
+		NotificationEventQuoteReady,
+		NotificationEventUsageAlert,
+		NotificationEventJobFailed,
+	},
+	NotificationChannelSlack: {
+		NotificationEventCallCompleted,
+		NotificationEventQuoteReady,
+		NotificationEventUsageAlert,
+		NotificationEventJobFailed,
+	},
+}
+
+// IsValid returns true if the event is known.
+func (e NotificationEvent) IsValid() bool {
+	for _, known := range NotificationEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports reports whether the channel can deliver the event.
+func (c NotificationChannel) Supports(event NotificationEvent) bool {
+	for _, e := range notificationChannelEvents[c] {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationPhonePattern matches an E.164 phone number.
+var notificationPhonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// NotificationPreferences are the events a user is notified about on each
+// channel, and where. Users without preferences get no personal
+// notifications; the configured staff recipients and chat channels are
+// notified regardless.
+type NotificationPreferences struct {
+	UserID          uuid.UUID           `json:"user_id"`
+	Email           string              `json:"email"` // The user's address; read from the user, not stored
+	EmailEvents     []NotificationEvent `json:"email_events"`
+	SMSEvents       []NotificationEvent `json:"sms_events"`
+	SlackEvents     []NotificationEvent `json:"slack_events"`
+	Phone           string              `json:"phone,omitempty"`             // E.164 number SMS is sent to
+	SlackWebhookURL string              `json:"slack_webhook_url,omitempty"` // Personal incoming webhook; a secret
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+// Events returns the events chosen for a channel.
+func (p *NotificationPreferences) Events(channel NotificationChannel) []NotificationEvent {
+	switch channel {
+	case NotificationChannelEmail:
+		return p.EmailEvents
+	case NotificationChannelSMS:
+		return p.SMSEvents
+	case NotificationChannelSlack:
+		return p.SlackEvents
+	}
+	return nil
+}
+
+// SetEvents replaces the events chosen for a channel.
+func (p *NotificationPreferences) SetEvents(channel NotificationChannel, events []NotificationEvent) {
+	switch channel {
+	case NotificationChannelEmail:
+		p.EmailEvents = events
+	case NotificationChannelSMS:
+		p.SMSEvents = events
+	case NotificationChannelSlack:
+		p.SlackEvents = events
+	}
+}
+
+// Wants reports whether the user chose to be notified of the event on the channel.
+func (p *NotificationPreferences) Wants(channel NotificationChannel, event NotificationEvent) bool {
+	for _, e := range p.Events(channel) {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Address returns where the channel's notifications are sent: the user's
+// email address, phone number or webhook URL.
+func (p *NotificationPreferences) Address(channel NotificationChannel) string {
+	switch channel {
+	case NotificationChannelEmail:
+		return p.Email
+	case NotificationChannelSMS:
+		return p.Phone
+	case NotificationChannelSlack:
+		return p.SlackWebhookURL
+	}
+	return ""
+}
+
+// Validate checks the chosen events and that the channels they are sent
+// on have somewhere to send them.
+func (p *NotificationPreferences) Validate() error {
+	for _, channel := range NotificationChannels {
+		seen := make(map[NotificationEvent]bool)
+		for _, e := range p.Events(channel) {
+			if !e.IsValid() {
+				return fmt.Errorf("unknown event %q", e)
+			}
+			if !channel.Supports(e) {
+				return fmt.Errorf("%s notifications are not available by %s", e, channel)
+			}
+			if seen[e] {
+				return fmt.Errorf("event %q is listed twice", e)
+			}
+			seen[e] = true
+		}
+	}
+
+	if p.Phone != "" && !notificationPhonePattern.MatchString(p.Phone) {
+		return errors.New("phone must be in international format, e.g. +15551234567")
+	}
+	if len(p.SMSEvents) > 0 && p.Phone == "" {
+		return errors.New("a phone number is required for SMS notifications")
+	}
+	if p.SlackWebhookURL != "" {
+		u, err := url.Parse(p.SlackWebhookURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("webhook URL must be an https URL")
+		}
+	}
+	if len(p.SlackEvents) > 0 && p.SlackWebhookURL == "" {
+		return errors.New("a webhook URL is required for Slack notifications")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Health score thresholds. Scores run from 0 to 100, higher being healthier.
+const (
+	NumberHealthDegradedBelow = 70
+	NumberHealthFlaggedBelow  = 40
+)
+
+// NumberShortCallSeconds is how short an answered call is to count as hung
+// up on at once.
+const NumberShortCallSeconds = 10
+
+// Delivery scoring. Outbound answer rates around 30% are normal for cold
+// calls; numbers labeled spam likely see answer rates fall sharply and more
+// of the calls that are answered hung up on at once.
+const (
+	healthyAnswerRate       = 0.3
+	shortCallPenaltyPercent = 50
+)
+
+// NumberHealthStatus summarizes a caller ID's reputation.
+type NumberHealthStatus string
+
+const (
+	NumberHealthHealthy  NumberHealthStatus = "healthy"
+	NumberHealthDegraded NumberHealthStatus = "degraded"
+	NumberHealthFlagged  NumberHealthStatus = "flagged" // Taken out of dialing pools
+)
+
+// NumberHealthStatusFor returns the status for a score.
+func NumberHealthStatusFor(score int) NumberHealthStatus {
+	switch {
+	case score < NumberHealthFlaggedBelow:
+		return NumberHealthFlagged
+	case score < NumberHealthDegradedBelow:
+		return NumberHealthDegraded
+	default:
+		return NumberHealthHealthy
+	}
+}
+
+// Rank orders statuses from healthy (0) to flagged (2).
+func (s NumberHealthStatus) Rank() int {
+	switch s {
+	case NumberHealthFlagged:
+		return 2
+	case NumberHealthDegraded:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// NumberHealth is the last health check of one of our numbers.
+type NumberHealth struct {
+	PhoneNumber string             `json:"phone_number"`
+	Score       int                `json:"score"`
+	Status      NumberHealthStatus `json:"status"`
+	SpamLikely  bool               `json:"spam_likely"`     // A reputation service labels it spam
+	Label       string             `json:"label,omitempty"` // The reputation service's label, e.g. "Spam Likely"
+
+	// Outbound calls from the number over the lookback window
+	Calls         int     `json:"calls"`
+	AnswerRate    float64 `json:"answer_rate"`
+	ShortCallRate float64 `json:"short_call_rate"` // Share of answered calls hung up within 10 seconds
+
+	// RotatedPools maps the dialing pools the number was taken out of to its
+	// weight there, so it can be put back when it recovers.
+	RotatedPools map[string]int `json:"rotated_pools,omitempty"`
+
+	CheckedAt       time.Time `json:"checked_at"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+}
+
+// NewNumberHealth creates a healthy record for a number not checked before.
+func NewNumberHealth(phoneNumber string) *NumberHealth {
+	now := time.Now().UTC()
+	return &NumberHealth{
+		PhoneNumber:     phoneNumber,
+		Score:           100,
+		Status:          NumberHealthHealthy,
+		CheckedAt:       now,
+		StatusChangedAt: now,
+	}
+}
+
+// SetScore records a new score and the status it implies. A spam label flags
+// the number whatever its score.
+func (h *NumberHealth) SetScore(score int, spamLikely bool, now time.Time) {
+	h.Score = clampScore(score)
+	h.SpamLikely = spamLikely
+	status := NumberHealthStatusFor(h.Score)
+	if spamLikely {
+		status = NumberHealthFlagged
+	}
+	if status != h.Status {
+		h.Status = status
+		h.StatusChangedAt = now
+	}
+	h.CheckedAt = now
+}
+
+// NumberDeliveryStats counts outbound calls from one of our numbers.
+type NumberDeliveryStats struct {
+	PhoneNumber string `json:"phone_number"`
+	Calls       int    `json:"calls"`
+	Answered    int    `json:"answered"`
+	ShortCalls  int    `json:"short_calls"` // Answered calls shorter than 10 seconds
+}
+
+// AnswerRate is the share of calls answered.
+func (s *NumberDeliveryStats) AnswerRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Answered) / float64(s.Calls)
+}
+
+// ShortCallRate is the share of answered calls hung up on at once.
+func (s *NumberDeliveryStats) ShortCallRate() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+	return float64(s.ShortCalls) / float64(s.Answered)
+}
+
+// Score rates delivery from 0 to 100: the answer rate as a share of a
+// healthy 30%, less up to half for calls hung up on at once. ok is false
+// when there are fewer than minCalls calls to judge by.
+func (s *NumberDeliveryStats) Score(minCalls int) (score int, ok bool) {
+	if s.Calls == 0 || s.Calls < minCalls {
+		return 0, false
+	}
+	answered := math.Min(1, s.AnswerRate()/healthyAnswerRate)
+	penalty := s.ShortCallRate() * shortCallPenaltyPercent / 100
+	return clampScore(int(math.Round(100 * answered * (1 - penalty)))), true
+}
+
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNumberDeliveryStats_Score(t *testing.T) {
+	tests := []struct {
+		name   string
+		stats  NumberDeliveryStats
+		want   int
+		wantOK bool
+	}{
+		{"healthy answer rate", NumberDeliveryStats{Calls: 100, Answered: 35}, 100, true},
+		{"half the healthy rate", NumberDeliveryStats{Calls: 100, Answered: 15}, 50, true},
+		{"answered calls hung up on", NumberDeliveryStats{Calls: 100, Answered: 30, ShortCalls: 15}, 75, true},
+		{"nobody answers", NumberDeliveryStats{Calls: 100}, 0, true},
+		{"too few calls", NumberDeliveryStats{Calls: 5, Answered: 0}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.stats.Score(20)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Score() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNumberHealth_SetScore(t *testing.T) {
+	h := NewNumberHealth("+15550000001")
+	changed := h.StatusChangedAt
+	now := changed.Add(time.Hour)
+
+	h.SetScore(85, false, now)
+	if h.Status != NumberHealthHealthy || !h.StatusChangedAt.Equal(changed) {
+		t.Errorf("status = %s changed at %v, want healthy and unchanged", h.Status, h.StatusChangedAt)
+	}
+
+	h.SetScore(55, false, now)
+	if h.Status != NumberHealthDegraded || !h.StatusChangedAt.Equal(now) {
+		t.Errorf("status = %s changed at %v, want degraded now", h.Status, h.StatusChangedAt)
+	}
+
+	h.SetScore(90, true, now)
+	if h.Status != NumberHealthFlagged {
+		t.Errorf("status = %s, want a spam label to flag the number", h.Status)
+	}
+}
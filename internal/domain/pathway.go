@@ -104,11 +104,14 @@ type PathwayFilter struct {
 	Offset      int
 }
 
-// PathwayVersion represents a version history entry.
+// PathwayVersion represents a version history entry. Snapshots of Bland
+// pathways keep their nodes and edges exactly as Bland returned them.
 type PathwayVersion struct {
 	ID          uuid.UUID `json:"id" db:"id"`
 	PathwayID   uuid.UUID `json:"pathway_id" db:"pathway_id"`
 	Version     int       `json:"version" db:"version"`
+	Name        string    `json:"name,omitempty" db:"name"`
+	Description string    `json:"description,omitempty" db:"description"`
 	NodesJSON   string    `json:"-" db:"nodes"`
 	EdgesJSON   string    `json:"-" db:"edges"`
 	ChangeNotes string    `json:"change_notes,omitempty" db:"change_notes"`
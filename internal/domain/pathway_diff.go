@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Pathway diff change kinds.
+const (
+	PathwayChangeAdded    = "added"
+	PathwayChangeRemoved  = "removed"
+	PathwayChangeModified = "modified"
+)
+
+// PathwayDiff lists what changed between two pathway versions. A version of
+// 0 is the live pathway in Bland.
+type PathwayDiff struct {
+	FromVersion int             `json:"from_version"`
+	ToVersion   int             `json:"to_version"`
+	Changes     []PathwayChange `json:"changes"`
+}
+
+// PathwayChange is one difference between two pathway versions. Element is
+// "name", "description", "node" or "edge"; Before and After hold the element
+// as stored, and are omitted when it was added or removed.
+type PathwayChange struct {
+	Element string          `json:"element"`
+	ID      string          `json:"id,omitempty"`
+	Change  string          `json:"change"`
+	Before  json.RawMessage `json:"before,omitempty"`
+	After   json.RawMessage `json:"after,omitempty"`
+}
+
+// HasChanges reports whether the versions differ.
+func (d *PathwayDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// DiffPathwayVersions compares two versions. Nodes and edges are matched by
+// ID (edges without one by source and target) and reported in the order they
+// appear, removals first.
+func DiffPathwayVersions(from, to *PathwayVersion) (*PathwayDiff, error) {
+	diff := &PathwayDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes:     []PathwayChange{},
+	}
+
+	if from.Name != to.Name {
+		diff.Changes = append(diff.Changes, textChange("name", from.Name, to.Name))
+	}
+	if from.Description != to.Description {
+		diff.Changes = append(diff.Changes, textChange("description", from.Description, to.Description))
+	}
+
+	for _, part := range []struct {
+		element  string
+		from, to string
+	}{
+		{"node", from.NodesJSON, to.NodesJSON},
+		{"edge", from.EdgesJSON, to.EdgesJSON},
+	} {
+		changes, err := diffPathwayElements(part.element, part.from, part.to)
+		if err != nil {
+			return nil, err
+		}
+		diff.Changes = append(diff.Changes, changes...)
+	}
+	return diff, nil
+}
+
+// textChange reports a changed name or description.
+func textChange(element, before, after string) PathwayChange {
+	b, _ := json.Marshal(before)
+	a, _ := json.Marshal(after)
+	return PathwayChange{Element: element, Change: PathwayChangeModified, Before: b, After: a}
+}
+
+// pathwayElement is a node or edge keyed for diffing.
+type pathwayElement struct {
+	key       string
+	raw       json.RawMessage
+	canonical []byte
+}
+
+// diffPathwayElements compares two JSON arrays of nodes or edges.
+func diffPathwayElements(element, fromJSON, toJSON string) ([]PathwayChange, error) {
+	before, err := decodePathwayElements(element, fromJSON)
+	if err != nil {
+		return nil, err
+	}
+	after, err := decodePathwayElements(element, toJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	afterByKey := make(map[string]pathwayElement, len(after))
+	for _, el := range after {
+		afterByKey[el.key] = el
+	}
+	beforeByKey := make(map[string]pathwayElement, len(before))
+	for _, el := range before {
+		beforeByKey[el.key] = el
+	}
+
+	var changes []PathwayChange
+	for _, el := range before {
+		if _, ok := afterByKey[el.key]; !ok {
+			changes = append(changes, PathwayChange{Element: element, ID: el.key, Change: PathwayChangeRemoved, Before: el.raw})
+		}
+	}
+	for _, el := range after {
+		old, ok := beforeByKey[el.key]
+		switch {
+		case !ok:
+			changes = append(changes, PathwayChange{Element: element, ID: el.key, Change: PathwayChangeAdded, After: el.raw})
+		case !bytes.Equal(old.canonical, el.canonical):
+			changes = append(changes, PathwayChange{Element: element, ID: el.key, Change: PathwayChangeModified, Before: old.raw, After: el.raw})
+		}
+	}
+	return changes, nil
+}
+
+// decodePathwayElements decodes a JSON array of nodes or edges, keying each
+// by its ID. Key order and whitespace don't count as changes.
+func decodePathwayElements(element, data string) ([]pathwayElement, error) {
+	if data == "" || data == "null" {
+		return nil, nil
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &raws); err != nil {
+		return nil, fmt.Errorf("invalid pathway %ss: %w", element, err)
+	}
+
+	elements := make([]pathwayElement, 0, len(raws))
+	seen := make(map[string]int)
+	for i, raw := range raws {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("invalid pathway %s %d: %w", element, i, err)
+		}
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pathway %s %d: %w", element, i, err)
+		}
+
+		var ids struct {
+			ID     string `json:"id"`
+			Source string `json:"source"`
+			Target string `json:"target"`
+		}
+		_ = json.Unmarshal(raw, &ids)
+		key := ids.ID
+		if key == "" && (ids.Source != "" || ids.Target != "") {
+			key = ids.Source + "->" + ids.Target
+		}
+		if key == "" {
+			key = fmt.Sprintf("#%d", i)
+		}
+		// Repeated keys are told apart by occurrence.
+		if n := seen[key]; n > 0 {
+			seen[key] = n + 1
+			key = fmt.Sprintf("%s#%d", key, n+1)
+		} else {
+			seen[key] = 1
+		}
+
+		elements = append(elements, pathwayElement{key: key, raw: raw, canonical: canonical})
+	}
+	return elements, nil
+}
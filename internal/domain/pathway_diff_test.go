@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestDiffPathwayVersions(t *testing.T) {
+	from := &PathwayVersion{
+		Version:     1,
+		Name:        "Intake",
+		Description: "Collects project details",
+		NodesJSON:   `[{"id":"start","data":{"prompt":"Hi"}},{"id":"quote","data":{"prompt":"Price?"}},{"id":"old","data":{}}]`,
+		EdgesJSON:   `[{"id":"e1","source":"start","target":"quote"},{"source":"quote","target":"old"}]`,
+	}
+	to := &PathwayVersion{
+		Version:     2,
+		Name:        "Intake v2",
+		Description: "Collects project details",
+		// Reordered keys and whitespace on "start" are not a change.
+		NodesJSON: `[{"data": {"prompt": "Hi"}, "id": "start"},{"id":"quote","data":{"prompt":"Budget?"}},{"id":"new","data":{}}]`,
+		EdgesJSON: `[{"id":"e1","source":"start","target":"quote"},{"source":"quote","target":"new"}]`,
+	}
+
+	diff, err := DiffPathwayVersions(from, to)
+	if err != nil {
+		t.Fatalf("DiffPathwayVersions() error = %v", err)
+	}
+	if diff.FromVersion != 1 || diff.ToVersion != 2 {
+		t.Errorf("versions = %d..%d, want 1..2", diff.FromVersion, diff.ToVersion)
+	}
+
+	want := []struct{ element, id, change string }{
+		{"name", "", PathwayChangeModified},
+		{"node", "old", PathwayChangeRemoved},
+		{"node", "quote", PathwayChangeModified},
+		{"node", "new", PathwayChangeAdded},
+		{"edge", "quote->old", PathwayChangeRemoved},
+		{"edge", "quote->new", PathwayChangeAdded},
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(diff.Changes), len(want), diff.Changes)
+	}
+	for i, w := range want {
+		c := diff.Changes[i]
+		if c.Element != w.element || c.ID != w.id || c.Change != w.change {
+			t.Errorf("change %d = %s %q %s, want %s %q %s", i, c.Element, c.ID, c.Change, w.element, w.id, w.change)
+		}
+	}
+
+	if got := string(diff.Changes[0].After); got != `"Intake v2"` {
+		t.Errorf("name after = %s", got)
+	}
+	if got := string(diff.Changes[2].Before); got != `{"id":"quote","data":{"prompt":"Price?"}}` {
+		t.Errorf("node before = %s", got)
+	}
+	if diff.Changes[1].After != nil || diff.Changes[3].Before != nil {
+		t.Error("added and removed elements should only carry one side")
+	}
+}
+
+func TestDiffPathwayVersions_NoChanges(t *testing.T) {
+	v := &PathwayVersion{Version: 3, Name: "Intake", NodesJSON: `[{"id":"a"}]`, EdgesJSON: `[]`}
+
+	diff, err := DiffPathwayVersions(v, v)
+	if err != nil {
+		t.Fatalf("DiffPathwayVersions() error = %v", err)
+	}
+	if diff.HasChanges() {
+		t.Errorf("expected no changes, got %+v", diff.Changes)
+	}
+	if diff.Changes == nil {
+		t.Error("Changes should be an empty list, not nil")
+	}
+}
+
+func TestDiffPathwayVersions_InvalidJSON(t *testing.T) {
+	from := &PathwayVersion{NodesJSON: `[]`}
+	to := &PathwayVersion{NodesJSON: `{"id":"a"}`}
+
+	if _, err := DiffPathwayVersions(from, to); err == nil {
+		t.Error("expected an error for nodes that are not a JSON array")
+	}
+}
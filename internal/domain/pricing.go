@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPricingProjectType is the project type of the rule used when no
+// rule matches a call's project type.
+const DefaultPricingProjectType = "default"
+
+// pricingKeyPattern restricts unit and multiplier keys to identifiers the AI
+// can reliably echo back.
+var pricingKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// PricingRule prices one project type: a base rate, rates for each unit of
+// work, and multipliers for conditions such as rush delivery.
+type PricingRule struct {
+	ID          uuid.UUID           `json:"id"`
+	ProjectType string              `json:"project_type"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	BaseRate    float64             `json:"base_rate"`
+	UnitRates   []PricingUnitRate   `json:"unit_rates"`
+	Multipliers []PricingMultiplier `json:"multipliers"`
+	IsActive    bool                `json:"is_active"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// PricingUnitRate is the price of one unit of work, such as a page or an
+// integration.
+type PricingUnitRate struct {
+	Key   string  `json:"key"`
+	Label string  `json:"label"`
+	Rate  float64 `json:"rate"`
+}
+
+// PricingMultiplier scales the whole price when its condition applies, e.g.
+// 1.25 for rush delivery or 0.9 for a non-profit discount.
+type PricingMultiplier struct {
+	Key    string  `json:"key"`
+	Label  string  `json:"label"`
+	Factor float64 `json:"factor"`
+}
+
+// PricingInputs are the facts about a call that a pricing rule needs: how
+// many of each unit the project involves and which multipliers apply. They
+// are extracted from the call by AI; pricing itself is deterministic.
+type PricingInputs struct {
+	Quantities  map[string]float64 `json:"quantities,omitempty"`
+	Multipliers []string           `json:"multipliers,omitempty"`
+}
+
+// PriceLine is one priced line of an estimate.
+type PriceLine struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// PriceEstimate is the result of applying a pricing rule to a call.
+type PriceEstimate struct {
+	RuleID      uuid.UUID           `json:"rule_id"`
+	RuleName    string              `json:"rule_name"`
+	Lines       []PriceLine         `json:"lines"`
+	Multipliers []PricingMultiplier `json:"multipliers,omitempty"` // Multipliers applied
+	Total       float64             `json:"total"`
+	Currency    string              `json:"currency,omitempty"` // ISO 4217 code; empty for USD
+}
+
+// NewPricingRule creates an active pricing rule.
+func NewPricingRule(projectType, name string, baseRate float64) *PricingRule {
+	now := time.Now().UTC()
+	return &PricingRule{
+		ID:          uuid.New(),
+		ProjectType: NormalizeProjectType(projectType),
+		Name:        name,
+		BaseRate:    baseRate,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// NormalizeProjectType returns the form of a project type used to match
+// calls to rules: lowercase with single spaces.
+func NormalizeProjectType(projectType string) string {
+	return strings.ToLower(strings.Join(strings.Fields(projectType), " "))
+}
+
+// Validate checks that the rule can price a project.
+func (r *PricingRule) Validate() error {
+	if r.ProjectType == "" {
+		return fmt.Errorf("project type is required")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.BaseRate < 0 || math.IsNaN(r.BaseRate) || math.IsInf(r.BaseRate, 0) {
+		return fmt.Errorf("base rate must be zero or more")
+	}
+
+	keys := make(map[string]bool)
+	for _, u := range r.UnitRates {
+		if !pricingKeyPattern.MatchString(u.Key) {
+			return fmt.Errorf("unit key %q must be lowercase letters, digits and underscores", u.Key)
+		}
+		if keys[u.Key] {
+			return fmt.Errorf("duplicate key %q", u.Key)
+		}
+		keys[u.Key] = true
+		if strings.TrimSpace(u.Label) == "" {
+			return fmt.Errorf("unit %q needs a label", u.Key)
+		}
+		if u.Rate < 0 || math.IsNaN(u.Rate) || math.IsInf(u.Rate, 0) {
+			return fmt.Errorf("unit %q rate must be zero or more", u.Key)
+		}
+	}
+	for _, m := range r.Multipliers {
+		if !pricingKeyPattern.MatchString(m.Key) {
+			return fmt.Errorf("multiplier key %q must be lowercase letters, digits and underscores", m.Key)
+		}
+		if keys[m.Key] {
+			return fmt.Errorf("duplicate key %q", m.Key)
+		}
+		keys[m.Key] = true
+		if strings.TrimSpace(m.Label) == "" {
+			return fmt.Errorf("multiplier %q needs a label", m.Key)
+		}
+		if m.Factor <= 0 || math.IsNaN(m.Factor) || math.IsInf(m.Factor, 0) {
+			return fmt.Errorf("multiplier %q factor must be greater than zero", m.Key)
+		}
+	}
+	return nil
+}
+
+// Price applies the rule to the inputs. Unknown unit and multiplier keys and
+// non-positive quantities are ignored. Multipliers scale every line, so the
+// lines always add up to the total.
+func (r *PricingRule) Price(inputs PricingInputs) *PriceEstimate {
+	estimate := &PriceEstimate{RuleID: r.ID, RuleName: r.Name}
+
+	factor := 1.0
+	applied := make(map[string]bool)
+	for _, key := range inputs.Multipliers {
+		applied[key] = true
+	}
+	for _, m := range r.Multipliers {
+		if applied[m.Key] {
+			factor *= m.Factor
+			estimate.Multipliers = append(estimate.Multipliers, m)
+		}
+	}
+
+	addLine := func(description string, quantity, rate float64) {
+		unitPrice := roundCents(rate * factor)
+		line := PriceLine{
+			Description: description,
+			Quantity:    quantity,
+			UnitPrice:   unitPrice,
+			Amount:      roundCents(quantity * unitPrice),
+		}
+		estimate.Lines = append(estimate.Lines, line)
+		estimate.Total += line.Amount
+	}
+
+	if r.BaseRate > 0 {
+		addLine(r.Name, 1, r.BaseRate)
+	}
+	for _, u := range r.UnitRates {
+		qty := inputs.Quantities[u.Key]
+		if qty <= 0 || math.IsNaN(qty) || math.IsInf(qty, 0) || u.Rate == 0 {
+			continue
+		}
+		addLine(u.Label, qty, u.Rate)
+	}
+
+	estimate.Total = roundCents(estimate.Total)
+	return estimate
+}
+
+// roundCents rounds a money amount to the nearest cent.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func testPricingRule() *PricingRule {
+	rule := NewPricingRule(" Website  Development ", "Project setup", 1000)
+	rule.UnitRates = []PricingUnitRate{
+		{Key: "page", Label: "Pages", Rate: 250},
+		{Key: "integration", Label: "Integrations", Rate: 1500},
+	}
+	rule.Multipliers = []PricingMultiplier{
+		{Key: "rush", Label: "Rush delivery", Factor: 1.5},
+		{Key: "nonprofit", Label: "Non-profit discount", Factor: 0.9},
+	}
+	return rule
+}
+
+func TestNewPricingRule_NormalizesProjectType(t *testing.T) {
+	rule := testPricingRule()
+	if rule.ProjectType != "website development" {
+		t.Errorf("ProjectType = %q, want %q", rule.ProjectType, "website development")
+	}
+	if !rule.IsActive {
+		t.Error("new rules should be active")
+	}
+}
+
+func TestPricingRule_Price(t *testing.T) {
+	rule := testPricingRule()
+
+	estimate := rule.Price(PricingInputs{
+		Quantities: map[string]float64{"page": 4, "integration": 0, "unknown": 10},
+	})
+	if len(estimate.Lines) != 2 {
+		t.Fatalf("expected base and page lines, got %+v", estimate.Lines)
+	}
+	if estimate.Lines[0].Description != "Project setup" || estimate.Lines[0].Amount != 1000 {
+		t.Errorf("unexpected base line %+v", estimate.Lines[0])
+	}
+	if estimate.Lines[1].Quantity != 4 || estimate.Lines[1].Amount != 1000 {
+		t.Errorf("unexpected page line %+v", estimate.Lines[1])
+	}
+	if estimate.Total != 2000 {
+		t.Errorf("Total = %v, want 2000", estimate.Total)
+	}
+}
+
+func TestPricingRule_PriceAppliesMultipliersToEveryLine(t *testing.T) {
+	rule := testPricingRule()
+
+	estimate := rule.Price(PricingInputs{
+		Quantities:  map[string]float64{"page": 3},
+		Multipliers: []string{"rush", "nonprofit", "unknown"},
+	})
+	if len(estimate.Multipliers) != 2 {
+		t.Fatalf("expected 2 applied multipliers, got %+v", estimate.Multipliers)
+	}
+	// factor 1.35: base 1350, pages 3 x 337.50
+	if estimate.Lines[0].Amount != 1350 || estimate.Lines[1].UnitPrice != 337.5 {
+		t.Errorf("unexpected lines %+v", estimate.Lines)
+	}
+	var sum float64
+	for _, line := range estimate.Lines {
+		sum += line.Amount
+	}
+	if sum != estimate.Total || estimate.Total != 2362.5 {
+		t.Errorf("Total = %v, lines sum to %v", estimate.Total, sum)
+	}
+}
+
+func TestPricingRule_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PricingRule)
+		errMsg string
+	}{
+		{"valid", func(r *PricingRule) {}, ""},
+		{"missing name", func(r *PricingRule) { r.Name = " " }, "name is required"},
+		{"negative base rate", func(r *PricingRule) { r.BaseRate = -1 }, "base rate"},
+		{"bad key", func(r *PricingRule) { r.UnitRates[0].Key = "Page Count" }, "lowercase"},
+		{"duplicate key", func(r *PricingRule) { r.Multipliers[0].Key = "page" }, "duplicate"},
+		{"zero factor", func(r *PricingRule) { r.Multipliers[1].Factor = 0 }, "greater than zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := testPricingRule()
+			tt.modify(rule)
+			err := rule.Validate()
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
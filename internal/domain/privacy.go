@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PrivacySubject identifies the person a data subject request is about: a
+// customer record, the phone numbers they call from or are called on, and
+// the calls made with them.
+type PrivacySubject struct {
+	CustomerID   *uuid.UUID  `json:"customer_id,omitempty"`
+	PhoneNumbers []string    `json:"phone_numbers"`
+	CallIDs      []uuid.UUID `json:"call_ids,omitempty"`
+}
+
+// PrivacyRecords is everything stored locally about a data subject.
+type PrivacyRecords struct {
+	Customer         *Customer          `json:"customer,omitempty"`
+	Calls            []*Call            `json:"calls"`
+	Quotes           []*Quote           `json:"quotes"`
+	QuoteTransitions []*QuoteTransition `json:"quote_transitions"`
+	Recordings       []*Recording       `json:"recordings"`
+	FollowUpSteps    []*FollowUpStep    `json:"followup_steps"`
+	Callbacks        []*Callback        `json:"callbacks"`
+	CampaignContacts []*CampaignContact `json:"campaign_contacts"`
+	DoNotCall        []*DNCEntry        `json:"do_not_call"`
+}
+
+// PrivacyActionStatus reports how a deletion went in one system.
+type PrivacyActionStatus string
+
+const (
+	PrivacyActionDeleted  PrivacyActionStatus = "deleted"  // Data was removed
+	PrivacyActionRetained PrivacyActionStatus = "retained" // Data was kept on purpose
+	PrivacyActionFailed   PrivacyActionStatus = "failed"   // Removal was attempted and failed
+	PrivacyActionSkipped  PrivacyActionStatus = "skipped"  // The system cannot remove the data
+)
+
+// PrivacyAction is one step taken while deleting a data subject's data.
+type PrivacyAction struct {
+	System string              `json:"system"`
+	Target string              `json:"target,omitempty"`
+	Status PrivacyActionStatus `json:"status"`
+	Count  int                 `json:"count,omitempty"`
+	Detail string              `json:"detail,omitempty"`
+}
+
+// DeletionReport records what a data subject deletion removed and kept. It
+// is signed so it can be handed to the subject or an auditor and checked
+// later without trusting whoever holds it.
+type DeletionReport struct {
+	ID          uuid.UUID       `json:"id"`
+	Subject     PrivacySubject  `json:"subject"`
+	RequestedBy *uuid.UUID      `json:"requested_by,omitempty"`
+	StartedAt   time.Time       `json:"started_at"`
+	CompletedAt time.Time       `json:"completed_at"`
+	Complete    bool            `json:"complete"` // False if any action failed
+	Actions     []PrivacyAction `json:"actions"`
+	Signature   string          `json:"signature,omitempty"`
+}
+
+// Sign sets the report's signature: the hex HMAC-SHA256 of the report's JSON
+// encoding without a signature.
+func (r *DeletionReport) Sign(key []byte) error {
+	sig, err := r.signature(key)
+	if err != nil {
+		return err
+	}
+	r.Signature = sig
+	return nil
+}
+
+// Verify reports whether the report's signature matches its contents.
+func (r *DeletionReport) Verify(key []byte) bool {
+	want, err := r.signature(key)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(r.Signature), []byte(want))
+}
+
+func (r *DeletionReport) signature(key []byte) (string, error) {
+	unsigned := *r
+	unsigned.Signature = ""
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
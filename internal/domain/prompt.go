@@ -21,6 +21,10 @@ type Prompt struct {
 	Voice    string `json:"voice,omitempty"`    // Voice ID or preset name
 	Language string `json:"language,omitempty"` // Language code (en-US, es, etc.)
 
+	// Localizations hold the prompt's text in other languages, keyed by
+	// language tag (es, es-MX, etc.)
+	Localizations map[string]*PromptLocalization `json:"localizations,omitempty"`
+
 	// Model and behavior settings
 	Model                 string   `json:"model,omitempty"`       // "base" or "turbo"
 	Temperature           *float64 `json:"temperature,omitempty"` // 0-1, controls creativity
@@ -39,6 +43,10 @@ type Prompt struct {
 	VoicemailAction  string `json:"voicemail_action,omitempty"` // hangup, leave_message, ignore
 	VoicemailMessage string `json:"voicemail_message,omitempty"`
 
+	// RetryPolicy re-dials numbers whose call went to voicemail or was not
+	// answered. Nil means no retries.
+	RetryPolicy *CallRetryPolicy `json:"retry_policy,omitempty"`
+
 	// Recording and audio
 	Record            bool    `json:"record,omitempty"`
 	BackgroundTrack   *string `json:"background_track,omitempty"`
@@ -97,6 +105,19 @@ func (p *Prompt) Validate() error {
 	if p.MaxDuration != nil && *p.MaxDuration < 1 {
 		return ErrPromptMaxDurationInvalid
 	}
+	if p.RetryPolicy != nil {
+		if err := p.RetryPolicy.Validate(); err != nil {
+			return err
+		}
+	}
+	for language, l := range p.Localizations {
+		if !IsValidLanguage(language) {
+			return ErrPromptLocalizationLanguageInvalid
+		}
+		if l.IsEmpty() {
+			return ErrPromptLocalizationEmpty
+		}
+	}
 	return nil
 }
 
@@ -120,6 +141,9 @@ var (
 	ErrPromptTemperatureInvalid = NewValidationError("temperature", "temperature must be between 0 and 1")
 	ErrPromptMaxDurationInvalid = NewValidationError("max_duration", "max duration must be at least 1 minute")
 	ErrPromptNotFound           = NewNotFoundError("prompt", "prompt not found")
+
+	ErrPromptLocalizationLanguageInvalid = NewValidationError("localizations", "localization languages must be tags such as es or es-MX")
+	ErrPromptLocalizationEmpty           = NewValidationError("localizations", "localizations must override at least one field")
 )
 
 // ValidationError represents a validation error.
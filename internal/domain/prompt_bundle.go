@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PromptBundleVersion is the bundle format written by exports. Imports
+// refuse bundles from a newer format.
+const PromptBundleVersion = 1
+
+// PromptBundle is prompt configuration exported as one document, for
+// keeping in version control and importing into another environment.
+// Prompts hold their versioned fields only; IDs, flags and timestamps
+// belong to the environment.
+type PromptBundle struct {
+	Version         int               `json:"version"`
+	ExportedAt      time.Time         `json:"exported_at"`
+	Prompts         []json.RawMessage `json:"prompts"`
+	Settings        map[string]string `json:"settings,omitempty"`
+	CitationSchemas []json.RawMessage `json:"citation_schemas,omitempty"`
+}
+
+// BundleConflict is what an import does with an item whose name is taken.
+type BundleConflict string
+
+const (
+	BundleConflictSkip      BundleConflict = "skip"      // Keep the existing item
+	BundleConflictOverwrite BundleConflict = "overwrite" // Replace the existing item's content
+	BundleConflictRename    BundleConflict = "rename"    // Import alongside under a new name
+)
+
+// ParseBundleConflict parses a conflict resolution, defaulting to skip.
+func ParseBundleConflict(s string) (BundleConflict, error) {
+	switch c := BundleConflict(s); c {
+	case "":
+		return BundleConflictSkip, nil
+	case BundleConflictSkip, BundleConflictOverwrite, BundleConflictRename:
+		return c, nil
+	default:
+		return "", NewValidationError("conflict", fmt.Sprintf("invalid conflict resolution %q: use skip, overwrite or rename", s))
+	}
+}
+
+// Bundle import actions.
+const (
+	BundleActionCreate    = "create"
+	BundleActionUpdate    = "update"
+	BundleActionRename    = "rename"
+	BundleActionSkip      = "skip"
+	BundleActionUnchanged = "unchanged"
+)
+
+// BundleItemResult is what an import did, or would do, with one item.
+type BundleItemResult struct {
+	Name    string            `json:"name"`
+	Action  string            `json:"action"`
+	NewName string            `json:"new_name,omitempty"` // Name a renamed item was imported under
+	ID      string            `json:"id,omitempty"`       // ID of the created or updated item; empty on dry runs
+	Changes []PromptFieldDiff `json:"changes,omitempty"`  // Fields that differ from the existing item
+}
+
+// PromptBundleImportResult reports an import item by item.
+type PromptBundleImportResult struct {
+	DryRun          bool               `json:"dry_run"`
+	Conflict        BundleConflict     `json:"conflict"`
+	Prompts         []BundleItemResult `json:"prompts"`
+	Settings        []BundleItemResult `json:"settings,omitempty"`
+	CitationSchemas []BundleItemResult `json:"citation_schemas,omitempty"`
+}
+
+// PromptContent returns the versioned fields of a prompt as a JSON object
+// in PromptVersionFields order, leaving out unset ones.
+func PromptContent(p *Prompt) (json.RawMessage, error) {
+	fields, err := promptFields(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, field := range PromptVersionFields {
+		value := fields[field]
+		if bytes.Equal(value, []byte("null")) {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// DecodePromptContent decodes bundled prompt content into a new, valid
+// prompt. Fields left out get NewPrompt's defaults; fields other than the
+// versioned ones are refused.
+func DecodePromptContent(content json.RawMessage) (*Prompt, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, NewValidationError("prompt", "prompt must be an object")
+	}
+	for field := range fields {
+		if !isPromptVersionField(field) {
+			return nil, NewValidationError(field, fmt.Sprintf("unknown prompt field %q", field))
+		}
+	}
+
+	p := NewPrompt("", "")
+	if err := json.Unmarshal(content, p); err != nil {
+		return nil, NewValidationError("prompt", fmt.Sprintf("invalid prompt: %v", err))
+	}
+	p.Localizations = NormalizeLocalizations(p.Localizations)
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func isPromptVersionField(field string) bool {
+	for _, f := range PromptVersionFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PromptVersionFields are the prompt fields a version keeps, by JSON name,
+// in the order they are compared. The ID, default and active flags and
+// timestamps belong to the prompt rather than its content, so restoring a
+// version leaves them alone.
+var PromptVersionFields = []string{
+	"name",
+	"description",
+	"task",
+	"voice",
+	"language",
+	"model",
+	"temperature",
+	"interruption_threshold",
+	"max_duration",
+	"first_sentence",
+	"wait_for_greeting",
+	"transfer_phone_number",
+	"transfer_list",
+	"voicemail_action",
+	"voicemail_message",
+	"record",
+	"background_track",
+	"noise_cancellation",
+	"knowledge_base_ids",
+	"custom_tool_ids",
+	"summary_prompt",
+	"dispositions",
+	"analysis_schema",
+	"keywords",
+	"retry_policy",
+	"localizations",
+}
+
+// PromptVersion is the content a prompt had before an update replaced it.
+// Version n holds the prompt as it was before its nth change; ChangedBy and
+// ChangedFields describe that change.
+type PromptVersion struct {
+	ID            uuid.UUID       `json:"id"`
+	PromptID      uuid.UUID       `json:"prompt_id"`
+	Version       int             `json:"version"`
+	Content       json.RawMessage `json:"content"`
+	ChangedFields []string        `json:"changed_fields"`
+	ChangedBy     string          `json:"changed_by,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// PromptFieldDiff compares one prompt field between two versions, side by
+// side. A null value means the field is unset.
+type PromptFieldDiff struct {
+	Field   string          `json:"field"`
+	Before  json.RawMessage `json:"before"`
+	After   json.RawMessage `json:"after"`
+	Changed bool            `json:"changed"`
+}
+
+// NewPromptVersion captures a prompt's content before it is replaced by
+// next. It returns nil when next changes none of the versioned fields.
+func NewPromptVersion(previous, next *Prompt, changedBy string) (*PromptVersion, error) {
+	diff, err := DiffPrompts(previous, next)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, field := range diff {
+		if field.Changed {
+			changed = append(changed, field.Field)
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	content, err := json.Marshal(previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode prompt: %w", err)
+	}
+
+	return &PromptVersion{
+		ID:            uuid.New(),
+		PromptID:      previous.ID,
+		Content:       content,
+		ChangedFields: changed,
+		ChangedBy:     changedBy,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// Prompt decodes the content the version kept.
+func (v *PromptVersion) Prompt() (*Prompt, error) {
+	var p Prompt
+	if err := json.Unmarshal(v.Content, &p); err != nil {
+		return nil, fmt.Errorf("invalid prompt version content: %w", err)
+	}
+	return &p, nil
+}
+
+// RestoreOnto returns current with its versioned fields set from the
+// version. The prompt's ID, flags and creation time are kept.
+func (v *PromptVersion) RestoreOnto(current *Prompt) (*Prompt, error) {
+	restored, err := v.Prompt()
+	if err != nil {
+		return nil, err
+	}
+	restored.ID = current.ID
+	restored.IsDefault = current.IsDefault
+	restored.IsActive = current.IsActive
+	restored.CreatedAt = current.CreatedAt
+	restored.UpdatedAt = time.Now()
+	restored.DeletedAt = current.DeletedAt
+	return restored, nil
+}
+
+// DiffPrompts compares the versioned fields of two prompts, returning every
+// field in PromptVersionFields order with changed ones marked.
+func DiffPrompts(before, after *Prompt) ([]PromptFieldDiff, error) {
+	beforeFields, err := promptFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := promptFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := make([]PromptFieldDiff, 0, len(PromptVersionFields))
+	for _, field := range PromptVersionFields {
+		b, a := beforeFields[field], afterFields[field]
+		diff = append(diff, PromptFieldDiff{
+			Field:   field,
+			Before:  b,
+			After:   a,
+			Changed: !bytes.Equal(b, a),
+		})
+	}
+	return diff, nil
+}
+
+// promptFields encodes each field of a prompt. Unset fields are null.
+func promptFields(p *Prompt) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode prompt: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode prompt: %w", err)
+	}
+
+	null := json.RawMessage("null")
+	for _, field := range PromptVersionFields {
+		if _, ok := fields[field]; !ok {
+			fields[field] = null
+		}
+	}
+	return fields, nil
+}
+
+// PromptVersionRepository defines the interface for prompt version
+// persistence.
+type PromptVersionRepository interface {
+	// Create saves a version, numbering it after the prompt's latest.
+	Create(ctx context.Context, version *PromptVersion) error
+	Get(ctx context.Context, promptID uuid.UUID, version int) (*PromptVersion, error)
+	// List returns a prompt's versions, newest first.
+	List(ctx context.Context, promptID uuid.UUID) ([]*PromptVersion, error)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPromptVersionFields_CoverPrompt(t *testing.T) {
+	unversioned := map[string]bool{
+		"id": true, "is_default": true, "is_active": true,
+		"created_at": true, "updated_at": true, "deleted_at": true,
+	}
+	versioned := make(map[string]bool)
+	for _, field := range PromptVersionFields {
+		versioned[field] = true
+	}
+
+	typ := reflect.TypeOf(Prompt{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if !versioned[name] && !unversioned[name] {
+			t.Errorf("prompt field %q is neither versioned nor listed as unversioned", name)
+		}
+	}
+}
+
+func TestNewPromptVersion(t *testing.T) {
+	previous := NewPrompt("Intake", "Ask about the project.")
+	next := *previous
+	next.Task = "Ask about the project and budget."
+	next.Voice = "mason"
+	next.IsDefault = true
+
+	v, err := NewPromptVersion(previous, &next, "admin@example.com")
+	if err != nil {
+		t.Fatalf("NewPromptVersion() error = %v", err)
+	}
+	if v == nil {
+		t.Fatal("expected a version")
+	}
+	if want := []string{"task", "voice"}; !reflect.DeepEqual(v.ChangedFields, want) {
+		t.Errorf("ChangedFields = %v, want %v", v.ChangedFields, want)
+	}
+	if v.PromptID != previous.ID || v.ChangedBy != "admin@example.com" {
+		t.Errorf("unexpected version %+v", v)
+	}
+
+	kept, err := v.Prompt()
+	if err != nil {
+		t.Fatalf("Prompt() error = %v", err)
+	}
+	if kept.Task != "Ask about the project." || kept.Voice != "maya" {
+		t.Errorf("version kept task %q voice %q, want the previous content", kept.Task, kept.Voice)
+	}
+
+	// Flags alone are not a content change.
+	flagged := *previous
+	flagged.IsActive = false
+	if v, err := NewPromptVersion(previous, &flagged, ""); err != nil || v != nil {
+		t.Errorf("NewPromptVersion() = %v, %v; want nil for an unchanged prompt", v, err)
+	}
+}
+
+func TestPromptVersion_RestoreOnto(t *testing.T) {
+	old := NewPrompt("Intake", "Old task.")
+	temp := 0.2
+	old.Temperature = &temp
+	content, _ := json.Marshal(old)
+	v := &PromptVersion{PromptID: old.ID, Content: content}
+
+	current := NewPrompt("Intake v2", "New task.")
+	current.ID = old.ID
+	current.IsDefault = true
+	current.IsActive = false
+
+	restored, err := v.RestoreOnto(current)
+	if err != nil {
+		t.Fatalf("RestoreOnto() error = %v", err)
+	}
+	if restored.Name != "Intake" || restored.Task != "Old task." || *restored.Temperature != 0.2 {
+		t.Errorf("restored content = %+v", restored)
+	}
+	if !restored.IsDefault || restored.IsActive {
+		t.Error("restoring should keep the prompt's default and active flags")
+	}
+}
+
+func TestDiffPrompts(t *testing.T) {
+	before := NewPrompt("Intake", "Task.")
+	after := *before
+	after.Description = "Collects details"
+
+	diff, err := DiffPrompts(before, &after)
+	if err != nil {
+		t.Fatalf("DiffPrompts() error = %v", err)
+	}
+	if len(diff) != len(PromptVersionFields) {
+		t.Fatalf("got %d fields, want %d", len(diff), len(PromptVersionFields))
+	}
+	for _, field := range diff {
+		if field.Changed != (field.Field == "description") {
+			t.Errorf("field %s changed = %v", field.Field, field.Changed)
+		}
+		if field.Field == "description" && (string(field.Before) != "null" || string(field.After) != `"Collects details"`) {
+			t.Errorf("description = %s -> %s", field.Before, field.After)
+		}
+	}
+}
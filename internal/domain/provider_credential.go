@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CredentialProvider names a provider whose API key can be set at runtime.
+type CredentialProvider string
+
+// Providers whose API keys can be stored.
+const (
+	CredentialBland     CredentialProvider = "bland"
+	CredentialVapi      CredentialProvider = "vapi"
+	CredentialRetell    CredentialProvider = "retell"
+	CredentialAnthropic CredentialProvider = "anthropic"
+)
+
+// CredentialProviders lists the providers whose API keys can be stored.
+var CredentialProviders = []CredentialProvider{
+	CredentialBland,
+	CredentialVapi,
+	CredentialRetell,
+	CredentialAnthropic,
+}
+
+// IsValid reports whether p is a provider whose API key can be stored.
+func (p CredentialProvider) IsValid() bool {
+	for _, provider := range CredentialProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// Limits on a stored API key.
+const (
+	MaxProviderAPIKeyLen = 500
+	ProviderKeyHintLen   = 4
+)
+
+// ProviderCredential is a provider API key, encrypted under the server's
+// master key. It is never serialized with its ciphertext.
+type ProviderCredential struct {
+	Provider   CredentialProvider `json:"provider"`
+	Ciphertext []byte             `json:"-"`
+	KeyID      string             `json:"-"` // Master key the ciphertext was sealed under
+	Hint       string             `json:"hint"`
+
+	UpdatedBy *uuid.UUID `json:"updated_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// KeyHint returns the last characters of an API key, enough to tell keys
+// apart. Short keys get no hint, so the hint never gives most of a key away.
+func KeyHint(key string) string {
+	if len(key) < 4*ProviderKeyHintLen {
+		return ""
+	}
+	return key[len(key)-ProviderKeyHintLen:]
+}
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteStatus represents a generated quote's position in the review workflow.
+type QuoteStatus string
+
+const (
+	QuoteStatusDraft         QuoteStatus = "draft"          // Generated, not yet submitted for review
+	QuoteStatusPendingReview QuoteStatus = "pending_review" // Waiting for an approver
+	QuoteStatusApproved      QuoteStatus = "approved"       // Cleared to send to the customer
+	QuoteStatusSent          QuoteStatus = "sent"           // Delivered to the customer
+	QuoteStatusAccepted      QuoteStatus = "accepted"       // Customer accepted
+	QuoteStatusDeclined      QuoteStatus = "declined"       // Customer declined
+)
+
+// QuoteStatuses lists the quote statuses in workflow order.
+var QuoteStatuses = []QuoteStatus{
+	QuoteStatusDraft,
+	QuoteStatusPendingReview,
+	QuoteStatusApproved,
+	QuoteStatusSent,
+	QuoteStatusAccepted,
+	QuoteStatusDeclined,
+}
+
+// IsValid reports whether s is a known quote status.
+func (s QuoteStatus) IsValid() bool {
+	for _, status := range QuoteStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidQuoteTransition is returned when a quote cannot move to the requested state.
+var ErrInvalidQuoteTransition = errors.New("invalid quote status transition")
+
+// ErrQuoteNotEditable is returned when a quote is edited after it leaves draft.
+var ErrQuoteNotEditable = errors.New("only draft quotes can be edited")
+
+// quoteTransitions lists the states each status may move to.
+var quoteTransitions = map[QuoteStatus][]QuoteStatus{
+	QuoteStatusDraft:         {QuoteStatusPendingReview},
+	QuoteStatusPendingReview: {QuoteStatusApproved, QuoteStatusDraft},
+	QuoteStatusApproved:      {QuoteStatusSent, QuoteStatusDraft},
+	QuoteStatusSent:          {QuoteStatusAccepted, QuoteStatusDeclined},
+}
+
+// Quote tracks the review state of the quote generated for a call.
+// A call has at most one quote, so the quote is keyed by the call ID.
+type Quote struct {
+	CallID uuid.UUID   `json:"call_id"`
+	Status QuoteStatus `json:"status"`
+
+	// TotalAmount is the priced total captured when the quote was submitted
+	// for review. Approval applies to this amount.
+	TotalAmount      float64 `json:"total_amount"`
+	RequiresApproval bool    `json:"requires_approval"`
+
+	// Currency is the ISO 4217 currency the quote's amounts are in.
+	Currency string `json:"currency"`
+
+	// LineItems, TaxRate and Notes are set once staff edit the quote. Until
+	// then the quote is priced from the line items in the generated text.
+	LineItems []QuoteLineItem `json:"line_items"`
+	TaxRate   float64         `json:"tax_rate"` // Percent applied to the subtotal
+	Notes     string          `json:"notes,omitempty"`
+
+	// Signature is set when the customer signs to accept the quote.
+	Signature *QuoteSignature `json:"signature,omitempty"`
+
+	SubmittedBy *uuid.UUID `json:"submitted_by,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	ApprovedBy  *uuid.UUID `json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time `json:"approved_at,omitempty"`
+	SentAt      *time.Time `json:"sent_at,omitempty"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+
+	// ArchivedAt is set while the quote is archived. Archived quotes can't be
+	// edited or moved through the workflow.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewQuote creates a draft quote for a call.
+func NewQuote(callID uuid.UUID, totalAmount float64) *Quote {
+	now := time.Now().UTC()
+	return &Quote{
+		CallID:      callID,
+		Status:      QuoteStatusDraft,
+		TotalAmount: totalAmount,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// CanTransition reports whether the quote may move to the given status.
+func (q *Quote) CanTransition(to QuoteStatus) bool {
+	for _, s := range quoteTransitions[q.Status] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal returns true once the customer has responded.
+func (q *Quote) IsFinal() bool {
+	return q.Status == QuoteStatusAccepted || q.Status == QuoteStatusDeclined
+}
+
+// IsArchived returns true if the quote has been archived.
+func (q *Quote) IsArchived() bool {
+	return q.ArchivedAt != nil
+}
+
+// Transition moves the quote to a new status on behalf of actorID and
+// returns the audit record for the change.
+func (q *Quote) Transition(to QuoteStatus, actorID *uuid.UUID, note string) (*QuoteTransition, error) {
+	if !q.CanTransition(to) {
+		return nil, ErrInvalidQuoteTransition
+	}
+
+	now := time.Now().UTC()
+	from := q.Status
+
+	switch to {
+	case QuoteStatusPendingReview:
+		q.SubmittedBy = actorID
+		q.SubmittedAt = &now
+	case QuoteStatusApproved:
+		q.ApprovedBy = actorID
+		q.ApprovedAt = &now
+	case QuoteStatusSent:
+		q.SentAt = &now
+	case QuoteStatusAccepted, QuoteStatusDeclined:
+		q.RespondedAt = &now
+	case QuoteStatusDraft:
+		// Sent back for changes: any earlier approval no longer applies.
+		q.ApprovedBy = nil
+		q.ApprovedAt = nil
+	}
+
+	q.Status = to
+	q.UpdatedAt = now
+
+	return &QuoteTransition{
+		ID:         uuid.New(),
+		CallID:     q.CallID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actorID,
+		Note:       note,
+		CreatedAt:  now,
+	}, nil
+}
+
+// Limits on an edited quote.
+const (
+	MaxQuoteLineItems      = 100
+	MaxQuoteDescriptionLen = 500
+	MaxQuoteNotesLen       = 5000
+)
+
+// QuoteLineItem is a single priced entry on a quote.
+type QuoteLineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"` // Quantity times unit price, to the cent
+}
+
+// SetCurrency changes the currency the quote's amounts are in. Only drafts
+// may change currency; amounts are not converted.
+func (q *Quote) SetCurrency(currency string) error {
+	currency = NormalizeCurrency(currency)
+	if currency == q.Currency {
+		return nil
+	}
+	if q.Status != QuoteStatusDraft {
+		return ErrQuoteNotEditable
+	}
+	if !IsValidCurrency(currency) {
+		return ErrInvalidCurrency
+	}
+	q.Currency = currency
+	q.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// IsEdited reports whether staff have itemized the quote.
+func (q *Quote) IsEdited() bool {
+	return len(q.LineItems) > 0
+}
+
+// Edit replaces the quote's line items, tax rate and notes, recalculating
+// each line's amount. Only drafts may be edited.
+func (q *Quote) Edit(items []QuoteLineItem, taxRate float64, notes string) error {
+	if q.Status != QuoteStatusDraft {
+		return ErrQuoteNotEditable
+	}
+
+	notes = strings.TrimSpace(notes)
+	if err := validateQuoteContent(items, taxRate, notes); err != nil {
+		return err
+	}
+
+	edited := make([]QuoteLineItem, len(items))
+	for i, li := range items {
+		li.Description = strings.TrimSpace(li.Description)
+		li.Amount = roundCents(li.Quantity * li.UnitPrice)
+		edited[i] = li
+	}
+
+	q.LineItems = edited
+	q.TaxRate = taxRate
+	q.Notes = notes
+	q.TotalAmount = q.Total()
+	q.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func validateQuoteContent(items []QuoteLineItem, taxRate float64, notes string) error {
+	if len(items) == 0 {
+		return NewValidationError("line_items", "at least one line item is required")
+	}
+	if len(items) > MaxQuoteLineItems {
+		return NewValidationError("line_items", fmt.Sprintf("a quote can have at most %d line items", MaxQuoteLineItems))
+	}
+	for i, li := range items {
+		field := fmt.Sprintf("line_items[%d]", i)
+		desc := strings.TrimSpace(li.Description)
+		if desc == "" {
+			return NewValidationError(field+".description", fmt.Sprintf("line %d needs a description", i+1))
+		}
+		if len(desc) > MaxQuoteDescriptionLen {
+			return NewValidationError(field+".description", fmt.Sprintf("line %d description must be at most %d characters", i+1, MaxQuoteDescriptionLen))
+		}
+		if !(li.Quantity > 0) || math.IsInf(li.Quantity, 0) {
+			return NewValidationError(field+".quantity", fmt.Sprintf("line %d quantity must be greater than zero", i+1))
+		}
+		if !(li.UnitPrice >= 0) || math.IsInf(li.UnitPrice, 0) {
+			return NewValidationError(field+".unit_price", fmt.Sprintf("line %d unit price must be zero or more", i+1))
+		}
+	}
+	if !(taxRate >= 0 && taxRate <= 100) {
+		return NewValidationError("tax_rate", "tax rate must be between 0 and 100 percent")
+	}
+	if len(notes) > MaxQuoteNotesLen {
+		return NewValidationError("notes", fmt.Sprintf("notes must be at most %d characters", MaxQuoteNotesLen))
+	}
+	return nil
+}
+
+// Subtotal returns the sum of the line item amounts.
+func (q *Quote) Subtotal() float64 {
+	var total float64
+	for _, li := range q.LineItems {
+		total += li.Amount
+	}
+	return roundCents(total)
+}
+
+// Tax returns the tax on the subtotal.
+func (q *Quote) Tax() float64 {
+	return roundCents(q.Subtotal() * q.TaxRate / 100)
+}
+
+// Total returns the amount due.
+func (q *Quote) Total() float64 {
+	return roundCents(q.Subtotal() + q.Tax())
+}
+
+// QuoteTransition is an audit trail entry for a quote status change.
+type QuoteTransition struct {
+	ID         uuid.UUID   `json:"id"`
+	CallID     uuid.UUID   `json:"call_id"`
+	FromStatus QuoteStatus `json:"from_status"`
+	ToStatus   QuoteStatus `json:"to_status"`
+	ActorID    *uuid.UUID  `json:"actor_id,omitempty"`
+	ActorEmail string      `json:"actor_email,omitempty"`
+	Note       string      `json:"note,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
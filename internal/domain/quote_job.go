@@ -13,9 +13,60 @@ const (
 	QuoteJobStatusPending    QuoteJobStatus = "pending"
 	QuoteJobStatusProcessing QuoteJobStatus = "processing"
 	QuoteJobStatusCompleted  QuoteJobStatus = "completed"
-	QuoteJobStatusFailed     QuoteJobStatus = "failed"
+	QuoteJobStatusDead       QuoteJobStatus = "dead" // Out of retries; waits in the dead-letter queue for a manual retry
 )
 
+// QuoteJobStatuses lists all quote job statuses.
+var QuoteJobStatuses = []QuoteJobStatus{
+	QuoteJobStatusPending,
+	QuoteJobStatusProcessing,
+	QuoteJobStatusCompleted,
+	QuoteJobStatusDead,
+}
+
+// IsValid returns true if the status is a known quote job status.
+func (s QuoteJobStatus) IsValid() bool {
+	for _, status := range QuoteJobStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// QuoteJobPhase is a step of quote generation reported to progress listeners.
+// A job moves queued → extracting → pricing → complete; a failed attempt that
+// will be retried returns to queued, and one that will not ends in failed.
+type QuoteJobPhase string
+
+const (
+	QuoteJobPhaseQueued     QuoteJobPhase = "queued"     // Waiting for a worker or a retry
+	QuoteJobPhaseExtracting QuoteJobPhase = "extracting" // Loading the transcript and extracted call data
+	QuoteJobPhasePricing    QuoteJobPhase = "pricing"    // Claude is writing the quote
+	QuoteJobPhaseComplete   QuoteJobPhase = "complete"   // Quote saved to the call
+	QuoteJobPhaseFailed     QuoteJobPhase = "failed"     // Out of retries; the job is dead
+)
+
+// IsFinal returns true if no further events follow the phase.
+func (p QuoteJobPhase) IsFinal() bool {
+	return p == QuoteJobPhaseComplete || p == QuoteJobPhaseFailed
+}
+
+// QuoteJobEvent is a progress update for a quote job. Phase events carry a
+// Phase; token events carry the next chunk of quote Text.
+type QuoteJobEvent struct {
+	JobID   uuid.UUID     `json:"job_id"`
+	Phase   QuoteJobPhase `json:"phase,omitempty"`
+	Text    string        `json:"text,omitempty"`
+	Attempt int           `json:"attempt,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// IsToken returns true if the event carries quote text rather than a phase change.
+func (e QuoteJobEvent) IsToken() bool {
+	return e.Phase == ""
+}
+
 // QuoteJob represents an async quote generation job with retry support.
 type QuoteJob struct {
 	ID          uuid.UUID      `json:"id"`
@@ -35,6 +86,11 @@ type QuoteJob struct {
 	LastError  *string `json:"last_error,omitempty"`
 	ErrorCount int     `json:"error_count"`
 
+	// Leasing: the worker that claimed the job owns it until the lease
+	// expires. A processing job whose lease expired was orphaned.
+	LockedBy       *string    `json:"locked_by,omitempty"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+
 	// Metadata for extensibility
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -63,7 +119,7 @@ func (j *QuoteJob) CanRetry() bool {
 
 // IsTerminal returns true if the job is in a final state.
 func (j *QuoteJob) IsTerminal() bool {
-	return j.Status == QuoteJobStatusCompleted || j.Status == QuoteJobStatusFailed
+	return j.Status == QuoteJobStatusCompleted || j.Status == QuoteJobStatusDead
 }
 
 // MarkProcessing marks the job as currently being processed.
@@ -81,14 +137,17 @@ func (j *QuoteJob) MarkCompleted() {
 	j.Status = QuoteJobStatusCompleted
 	j.CompletedAt = &now
 	j.UpdatedAt = now
+	j.LeaseExpiresAt = nil
 }
 
 // MarkFailed marks the job as failed with an error message.
-// If retries are available, schedules for retry with exponential backoff.
+// If retries are available, schedules for retry with exponential backoff;
+// otherwise the job is dead.
 func (j *QuoteJob) MarkFailed(err error) {
 	now := time.Now()
 	j.UpdatedAt = now
 	j.ErrorCount++
+	j.LeaseExpiresAt = nil
 
 	errMsg := err.Error()
 	j.LastError = &errMsg
@@ -99,12 +158,24 @@ func (j *QuoteJob) MarkFailed(err error) {
 		j.ScheduledAt = now.Add(backoff)
 		j.Status = QuoteJobStatusPending
 	} else {
-		// No more retries - mark as permanently failed
-		j.Status = QuoteJobStatusFailed
+		// No more retries - move to the dead-letter queue
+		j.Status = QuoteJobStatusDead
 		j.CompletedAt = &now
 	}
 }
 
+// Retry takes a dead job out of the dead-letter queue with a fresh set of
+// attempts. The last error is kept until the next attempt.
+func (j *QuoteJob) Retry() {
+	now := time.Now()
+	j.Status = QuoteJobStatusPending
+	j.Attempts = 0
+	j.ScheduledAt = now
+	j.StartedAt = nil
+	j.CompletedAt = nil
+	j.UpdatedAt = now
+}
+
 // calculateBackoff returns the backoff duration for the next retry attempt.
 // Uses exponential backoff: 5s, 15s, 60s
 func (j *QuoteJob) calculateBackoff() time.Duration {
@@ -138,6 +209,28 @@ func (j *QuoteJob) TimeUntilRetry() time.Duration {
 	return remaining
 }
 
+// Phase returns the progress phase implied by the job's stored status. A job
+// being processed is reported as extracting since the stored status does not
+// say how far it has got.
+func (j *QuoteJob) Phase() QuoteJobPhase {
+	switch j.Status {
+	case QuoteJobStatusProcessing:
+		return QuoteJobPhaseExtracting
+	case QuoteJobStatusCompleted:
+		return QuoteJobPhaseComplete
+	case QuoteJobStatusDead:
+		return QuoteJobPhaseFailed
+	default:
+		return QuoteJobPhaseQueued
+	}
+}
+
+// IsLeaseExpired returns true if the job is processing but its worker stopped
+// renewing the lease.
+func (j *QuoteJob) IsLeaseExpired(now time.Time) bool {
+	return j.Status == QuoteJobStatusProcessing && j.LeaseExpiresAt != nil && j.LeaseExpiresAt.Before(now)
+}
+
 // IsReadyToProcess returns true if the job is ready to be processed.
 func (j *QuoteJob) IsReadyToProcess() bool {
 	return j.Status == QuoteJobStatusPending && time.Now().After(j.ScheduledAt)
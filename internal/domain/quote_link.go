@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultQuoteLinkTTL is how long a customer's quote link works by default.
+const DefaultQuoteLinkTTL = 30 * 24 * time.Hour
+
+// MaxQuoteCommentLen bounds the comment a customer leaves with their decision.
+const MaxQuoteCommentLen = 2000
+
+// QuoteLink is the link a customer follows to view, accept or decline their
+// quote without signing in. A quote has at most one link; only a hash of the
+// token in it is stored.
+type QuoteLink struct {
+	ID        uuid.UUID  `json:"id"`
+	CallID    uuid.UUID  `json:"call_id"`
+	TokenHash string     `json:"-"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	ViewedAt  *time.Time `json:"viewed_at,omitempty"` // First time the customer opened it
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewQuoteLink creates a link to a quote and returns it with the plaintext
+// token, which is only available at creation time.
+func NewQuoteLink(callID uuid.UUID, createdBy *uuid.UUID, ttl time.Duration) (*QuoteLink, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	plaintext := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now().UTC()
+	return &QuoteLink{
+		ID:        uuid.New(),
+		CallID:    callID,
+		TokenHash: HashUserToken(plaintext),
+		CreatedBy: createdBy,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, plaintext, nil
+}
+
+// IsExpired returns true if the link can no longer be used at now.
+func (l *QuoteLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewQuoteLink(t *testing.T) {
+	link, token, err := NewQuoteLink(uuid.New(), nil, time.Hour)
+	if err != nil {
+		t.Fatalf("NewQuoteLink() error = %v", err)
+	}
+	if token == "" || link.TokenHash != HashUserToken(token) {
+		t.Error("expected the stored hash to match the returned token")
+	}
+	if link.IsExpired(link.CreatedAt.Add(59 * time.Minute)) {
+		t.Error("expected link to work before its TTL")
+	}
+	if !link.IsExpired(link.CreatedAt.Add(time.Hour)) {
+		t.Error("expected link to expire at its TTL")
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotePaymentStatus is where a quote's payment stands.
+type QuotePaymentStatus string
+
+// Quote payment statuses.
+const (
+	QuotePaymentPending QuotePaymentStatus = "pending" // Link sent, not yet paid
+	QuotePaymentPaid    QuotePaymentStatus = "paid"
+)
+
+// QuotePayment is the payment collected for an accepted quote through a
+// payment processor's hosted link. A quote has at most one.
+type QuotePayment struct {
+	ID             uuid.UUID          `json:"id"`
+	CallID         uuid.UUID          `json:"call_id"`
+	Provider       string             `json:"provider"`
+	ProviderLinkID string             `json:"provider_link_id"`
+	URL            string             `json:"url"`
+	Amount         float64            `json:"amount"`
+	DepositPercent float64            `json:"deposit_percent"` // Share of the quote total requested; 100 for the full amount
+	Currency       string             `json:"currency"`
+	Status         QuotePaymentStatus `json:"status"`
+	PaymentID      string             `json:"payment_id,omitempty"` // The processor's ID for the payment
+	AmountPaid     float64            `json:"amount_paid,omitempty"`
+	PaidAt         *time.Time         `json:"paid_at,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// ValidateDepositPercent checks a deposit percentage.
+func ValidateDepositPercent(percent float64) error {
+	if percent <= 0 || percent > 100 {
+		return NewValidationError("deposit_percent", fmt.Sprintf("deposit percent must be above 0 and at most 100, got %g", percent))
+	}
+	return nil
+}
+
+// DepositAmount returns the share of total requested as a deposit, rounded
+// to the currency's minor unit.
+func DepositAmount(total, percent float64, currency string) float64 {
+	return RoundToMinorUnit(total*percent/100, currency)
+}
+
+// AmountMinorUnits returns the requested amount in the currency's minor
+// unit, such as cents for USD or yen for JPY.
+func (p *QuotePayment) AmountMinorUnits() int64 {
+	return ToMinorUnits(p.Amount, p.Currency)
+}
+
+// IsDeposit reports whether the payment is for part of the quote total.
+func (p *QuotePayment) IsDeposit() bool {
+	return p.DepositPercent < 100
+}
+
+// MarkPaid records that the payment was completed. amountPaid is in the
+// currency's minor unit, as processors report it.
+func (p *QuotePayment) MarkPaid(paymentID string, amountPaid int64, at time.Time) {
+	p.Status = QuotePaymentPaid
+	p.PaymentID = paymentID
+	p.AmountPaid = FromMinorUnits(amountPaid, p.Currency)
+	p.PaidAt = &at
+	p.UpdatedAt = at
+}
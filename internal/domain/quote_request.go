@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteRequestProvider is the provider of calls created from quote requests
+// submitted through the website form. They have no audio; the form is their
+// transcript.
+const QuoteRequestProvider = "web_form"
+
+// QuoteRequest is a request for a quote submitted through the form
+// contractors embed on their websites.
+type QuoteRequest struct {
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Company     string `json:"company,omitempty"`
+	ProjectType string `json:"project_type,omitempty"`
+	Description string `json:"description"`
+	Timeline    string `json:"timeline,omitempty"`
+	Budget      string `json:"budget,omitempty"`
+	Callback    bool   `json:"callback,omitempty"` // The submitter asked for a call back
+}
+
+// NewCall creates the completed call a quote request is quoted from. The
+// submitted fields are its extracted data, and a transcript is written from
+// them for the quote generator.
+func (q *QuoteRequest) NewCall() *Call {
+	call := NewCall("form_"+uuid.NewString(), QuoteRequestProvider, "", q.Phone)
+	now := call.CreatedAt
+	call.Status = CallStatusCompleted
+	call.StartedAt = &now
+	call.EndedAt = &now
+
+	transcript := q.transcript()
+	call.Transcript = &transcript
+	if q.Name != "" {
+		name := q.Name
+		call.CallerName = &name
+	}
+
+	contact := "email"
+	if q.Callback || q.Email == "" {
+		contact = "phone"
+	}
+	call.ExtractedData = &ExtractedData{
+		ProjectType:       q.ProjectType,
+		Requirements:      q.Description,
+		Timeline:          q.Timeline,
+		BudgetRange:       q.Budget,
+		ContactPreference: contact,
+		CallerName:        q.Name,
+		Email:             q.Email,
+		Phone:             q.Phone,
+		Company:           q.Company,
+	}
+	return call
+}
+
+// transcript lays out the form as the text quotes are generated from.
+func (q *QuoteRequest) transcript() string {
+	var b strings.Builder
+	b.WriteString("Quote request submitted through the website form on ")
+	b.WriteString(time.Now().UTC().Format("January 2, 2006"))
+	b.WriteString(".\n\n")
+	for _, field := range []struct{ label, value string }{
+		{"Name", q.Name},
+		{"Company", q.Company},
+		{"Email", q.Email},
+		{"Phone", q.Phone},
+		{"Project type", q.ProjectType},
+		{"Timeline", q.Timeline},
+		{"Budget", q.Budget},
+	} {
+		if field.value != "" {
+			b.WriteString(field.label + ": " + field.value + "\n")
+		}
+	}
+	b.WriteString("\nProject description:\n")
+	b.WriteString(q.Description)
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Signature limits.
+const (
+	MaxSignerNameLen       = 200
+	MaxSignatureImageBytes = 64 * 1024 // Fits in a form post once base64 encoded
+)
+
+// pngMagic starts every PNG file.
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+// QuoteSignature is the customer's signature on an accepted quote. The
+// customer always types their name; the image is set when they also draw a
+// signature.
+type QuoteSignature struct {
+	SignerName string    `json:"signer_name"`
+	Image      []byte    `json:"-"` // PNG of the drawn signature, if any
+	IP         string    `json:"ip,omitempty"`
+	SignedAt   time.Time `json:"signed_at"`
+}
+
+// NewQuoteSignature validates and creates a signature made at now.
+func NewQuoteSignature(signerName string, image []byte, ip string, now time.Time) (*QuoteSignature, error) {
+	signerName = strings.TrimSpace(signerName)
+	if signerName == "" {
+		return nil, NewValidationError("signer_name", "type your full name to sign")
+	}
+	if len(signerName) > MaxSignerNameLen {
+		return nil, NewValidationError("signer_name", fmt.Sprintf("signer name must be at most %d characters", MaxSignerNameLen))
+	}
+	if len(image) > 0 {
+		if len(image) > MaxSignatureImageBytes {
+			return nil, NewValidationError("signature", fmt.Sprintf("signature image must be at most %d KB", MaxSignatureImageBytes/1024))
+		}
+		if !bytes.HasPrefix(image, pngMagic) {
+			return nil, NewValidationError("signature", "signature image must be a PNG")
+		}
+	}
+	return &QuoteSignature{
+		SignerName: signerName,
+		Image:      image,
+		IP:         ip,
+		SignedAt:   now.UTC(),
+	}, nil
+}
+
+// Drawn reports whether the customer drew their signature rather than only
+// typing their name.
+func (s *QuoteSignature) Drawn() bool {
+	return len(s.Image) > 0
+}
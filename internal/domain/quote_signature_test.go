@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewQuoteSignature(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n....")
+	now := time.Date(2025, 3, 2, 10, 0, 0, 0, time.FixedZone("EST", -5*3600))
+
+	tests := []struct {
+		name    string
+		signer  string
+		image   []byte
+		wantErr bool
+	}{
+		{name: "typed", signer: " Jane Doe "},
+		{name: "drawn", signer: "Jane Doe", image: png},
+		{name: "missing name", signer: "  ", wantErr: true},
+		{name: "name too long", signer: strings.Repeat("a", MaxSignerNameLen+1), wantErr: true},
+		{name: "not a png", signer: "Jane Doe", image: []byte("GIF89a"), wantErr: true},
+		{name: "image too large", signer: "Jane Doe", image: append(png, make([]byte, MaxSignatureImageBytes)...), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := NewQuoteSignature(tt.signer, tt.image, "203.0.113.7", now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewQuoteSignature() error = %v", err)
+			}
+			if sig.SignerName != "Jane Doe" || sig.IP != "203.0.113.7" || !sig.SignedAt.Equal(now) || sig.SignedAt.Location() != time.UTC {
+				t.Errorf("unexpected signature %+v", sig)
+			}
+			if sig.Drawn() != (tt.image != nil) {
+				t.Errorf("Drawn() = %v", sig.Drawn())
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteTemplate structures the quotes for one project type: fixed sections
+// printed on every quote, line items every such project carries, and the
+// assumptions the price rests on. Like pricing rules, the template for
+// project type DefaultPricingProjectType is used when no other matches.
+type QuoteTemplate struct {
+	ID               uuid.UUID               `json:"id"`
+	ProjectType      string                  `json:"project_type"`
+	Name             string                  `json:"name"`
+	Sections         []QuoteTemplateSection  `json:"sections"`
+	DefaultLineItems []QuoteTemplateLineItem `json:"default_line_items"`
+	Assumptions      string                  `json:"assumptions,omitempty"`
+	IsActive         bool                    `json:"is_active"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+// QuoteTemplateSection is a headed block of text printed on quotes, such as
+// payment terms or a warranty.
+type QuoteTemplateSection struct {
+	Heading string `json:"heading"`
+	Body    string `json:"body"`
+}
+
+// QuoteTemplateLineItem is a line added to every priced quote for the
+// project type, such as a permit fee.
+type QuoteTemplateLineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+}
+
+// NewQuoteTemplate creates an active quote template.
+func NewQuoteTemplate(projectType, name string) *QuoteTemplate {
+	now := time.Now().UTC()
+	return &QuoteTemplate{
+		ID:          uuid.New(),
+		ProjectType: NormalizeProjectType(projectType),
+		Name:        name,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks that the template can be applied to quotes.
+func (t *QuoteTemplate) Validate() error {
+	if t.ProjectType == "" {
+		return fmt.Errorf("project type is required")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, s := range t.Sections {
+		if strings.TrimSpace(s.Heading) == "" {
+			return fmt.Errorf("every section needs a heading")
+		}
+		if strings.TrimSpace(s.Body) == "" {
+			return fmt.Errorf("section %q needs text", s.Heading)
+		}
+	}
+	for _, li := range t.DefaultLineItems {
+		if strings.TrimSpace(li.Description) == "" {
+			return fmt.Errorf("every line item needs a description")
+		}
+		if li.Quantity <= 0 || math.IsNaN(li.Quantity) || math.IsInf(li.Quantity, 0) {
+			return fmt.Errorf("line item %q quantity must be greater than zero", li.Description)
+		}
+		if li.UnitPrice < 0 || math.IsNaN(li.UnitPrice) || math.IsInf(li.UnitPrice, 0) {
+			return fmt.Errorf("line item %q unit price must be zero or more", li.Description)
+		}
+	}
+	return nil
+}
+
+// AddDefaultLines adds the template's default line items to an estimate at
+// their listed prices; pricing rule multipliers do not apply to them.
+func (t *QuoteTemplate) AddDefaultLines(estimate *PriceEstimate) {
+	for _, li := range t.DefaultLineItems {
+		line := PriceLine{
+			Description: li.Description,
+			Quantity:    li.Quantity,
+			UnitPrice:   roundCents(li.UnitPrice),
+			Amount:      roundCents(li.Quantity * li.UnitPrice),
+		}
+		estimate.Lines = append(estimate.Lines, line)
+		estimate.Total += line.Amount
+	}
+	estimate.Total = roundCents(estimate.Total)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestQuoteTemplate_Validate(t *testing.T) {
+	template := NewQuoteTemplate("Roofing", "Roof quote")
+	if err := template.Validate(); err != nil {
+		t.Fatalf("empty template should be valid: %v", err)
+	}
+
+	template.Sections = []QuoteTemplateSection{{Heading: "Warranty"}}
+	if err := template.Validate(); err == nil {
+		t.Error("expected error for a section without text")
+	}
+
+	template.Sections = nil
+	template.DefaultLineItems = []QuoteTemplateLineItem{{Description: "Skip hire", Quantity: 1, UnitPrice: -5}}
+	if err := template.Validate(); err == nil {
+		t.Error("expected error for a negative unit price")
+	}
+}
+
+func TestQuoteTemplate_AddDefaultLines(t *testing.T) {
+	estimate := testPricingRule().Price(PricingInputs{Multipliers: []string{"rush"}})
+
+	template := NewQuoteTemplate("website development", "Website quote")
+	template.DefaultLineItems = []QuoteTemplateLineItem{{Description: "Domain registration", Quantity: 2, UnitPrice: 12.995}}
+	template.AddDefaultLines(estimate)
+
+	last := estimate.Lines[len(estimate.Lines)-1]
+	if last.Description != "Domain registration" || last.Amount != 25.99 {
+		t.Errorf("unexpected default line %+v", last)
+	}
+	// 1000 x 1.5 rush, plus the unmultiplied default line
+	if estimate.Total != 1525.99 {
+		t.Errorf("Total = %v, want 1525.99", estimate.Total)
+	}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestQuote_Lifecycle(t *testing.T) {
+	callID := uuid.New()
+	submitter := uuid.New()
+	approver := uuid.New()
+
+	q := NewQuote(callID, 1200)
+	if q.Status != QuoteStatusDraft {
+		t.Fatalf("expected draft, got %s", q.Status)
+	}
+
+	tr, err := q.Transition(QuoteStatusPendingReview, &submitter, "ready")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if tr.FromStatus != QuoteStatusDraft || tr.ToStatus != QuoteStatusPendingReview || tr.CallID != callID {
+		t.Errorf("unexpected transition record %+v", tr)
+	}
+	if q.SubmittedBy == nil || *q.SubmittedBy != submitter || q.SubmittedAt == nil {
+		t.Error("expected submitter to be recorded")
+	}
+
+	if _, err := q.Transition(QuoteStatusApproved, &approver, ""); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if q.ApprovedBy == nil || *q.ApprovedBy != approver {
+		t.Error("expected approver to be recorded")
+	}
+
+	if _, err := q.Transition(QuoteStatusSent, nil, ""); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if _, err := q.Transition(QuoteStatusAccepted, nil, ""); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if !q.IsFinal() || q.RespondedAt == nil {
+		t.Error("expected accepted quote to be final")
+	}
+}
+
+func TestQuote_InvalidTransitions(t *testing.T) {
+	tests := []struct {
+		from QuoteStatus
+		to   QuoteStatus
+	}{
+		{QuoteStatusDraft, QuoteStatusApproved},
+		{QuoteStatusDraft, QuoteStatusSent},
+		{QuoteStatusPendingReview, QuoteStatusSent},
+		{QuoteStatusApproved, QuoteStatusAccepted},
+		{QuoteStatusSent, QuoteStatusDraft},
+		{QuoteStatusAccepted, QuoteStatusDeclined},
+		{QuoteStatusDeclined, QuoteStatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			q := NewQuote(uuid.New(), 100)
+			q.Status = tt.from
+			if _, err := q.Transition(tt.to, nil, ""); !errors.Is(err, ErrInvalidQuoteTransition) {
+				t.Errorf("expected ErrInvalidQuoteTransition, got %v", err)
+			}
+			if q.Status != tt.from {
+				t.Errorf("status changed to %s on invalid transition", q.Status)
+			}
+		})
+	}
+}
+
+func TestQuote_RequestChangesClearsApproval(t *testing.T) {
+	approver := uuid.New()
+	q := NewQuote(uuid.New(), 100)
+	q.Status = QuoteStatusPendingReview
+
+	if _, err := q.Transition(QuoteStatusApproved, &approver, ""); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := q.Transition(QuoteStatusDraft, nil, "price update"); err != nil {
+		t.Fatalf("request changes: %v", err)
+	}
+	if q.ApprovedBy != nil || q.ApprovedAt != nil {
+		t.Error("expected approval to be cleared when returned to draft")
+	}
+}
+
+func TestQuote_EditRecalculatesTotals(t *testing.T) {
+	q := NewQuote(uuid.New(), 0)
+	items := []QuoteLineItem{
+		{Description: "  Design  ", Quantity: 3, UnitPrice: 125.5, Amount: 1},
+		{Description: "Hosting", Quantity: 12, UnitPrice: 19.99},
+	}
+
+	if err := q.Edit(items, 8.25, " Net 30 "); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if q.LineItems[0].Description != "Design" || q.LineItems[0].Amount != 376.5 {
+		t.Errorf("unexpected first line %+v", q.LineItems[0])
+	}
+	if q.Subtotal() != 616.38 {
+		t.Errorf("expected subtotal 616.38, got %v", q.Subtotal())
+	}
+	if q.Tax() != 50.85 {
+		t.Errorf("expected tax 50.85, got %v", q.Tax())
+	}
+	if q.Total() != 667.23 || q.TotalAmount != 667.23 {
+		t.Errorf("expected total 667.23, got %v (stored %v)", q.Total(), q.TotalAmount)
+	}
+	if q.Notes != "Net 30" {
+		t.Errorf("expected trimmed notes, got %q", q.Notes)
+	}
+}
+
+func TestQuote_EditValidation(t *testing.T) {
+	valid := QuoteLineItem{Description: "Build", Quantity: 1, UnitPrice: 100}
+
+	tests := []struct {
+		name    string
+		items   []QuoteLineItem
+		taxRate float64
+		field   string
+	}{
+		{"no items", nil, 0, "line_items"},
+		{"blank description", []QuoteLineItem{valid, {Description: " ", Quantity: 1}}, 0, "line_items[1].description"},
+		{"zero quantity", []QuoteLineItem{{Description: "Build", UnitPrice: 10}}, 0, "line_items[0].quantity"},
+		{"negative price", []QuoteLineItem{{Description: "Build", Quantity: 1, UnitPrice: -5}}, 0, "line_items[0].unit_price"},
+		{"tax over 100", []QuoteLineItem{valid}, 101, "tax_rate"},
+		{"negative tax", []QuoteLineItem{valid}, -1, "tax_rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQuote(uuid.New(), 0)
+			err := q.Edit(tt.items, tt.taxRate, "")
+			var verr *ValidationError
+			if !errors.As(err, &verr) || verr.Field != tt.field {
+				t.Fatalf("expected validation error on %s, got %v", tt.field, err)
+			}
+			if q.IsEdited() {
+				t.Error("expected invalid edit not to change the quote")
+			}
+		})
+	}
+}
+
+func TestQuote_EditRequiresDraft(t *testing.T) {
+	q := NewQuote(uuid.New(), 100)
+	q.Status = QuoteStatusPendingReview
+
+	err := q.Edit([]QuoteLineItem{{Description: "Build", Quantity: 1, UnitPrice: 100}}, 0, "")
+	if !errors.Is(err, ErrQuoteNotEditable) {
+		t.Errorf("expected ErrQuoteNotEditable, got %v", err)
+	}
+}
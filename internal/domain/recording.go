@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecordingStatus represents where a call recording is in being archived.
+type RecordingStatus string
+
+const (
+	RecordingStatusPending RecordingStatus = "pending" // Waiting to be downloaded
+	RecordingStatusStored  RecordingStatus = "stored"  // Downloaded to our storage
+	RecordingStatusFailed  RecordingStatus = "failed"  // Gave up downloading
+	RecordingStatusDeleted RecordingStatus = "deleted" // Removed by the retention policy
+)
+
+// recordingMaxAttempts is how many times downloading a recording is tried.
+const recordingMaxAttempts = 5
+
+// Recording is a copy of a call's recording, downloaded from the voice
+// provider before its recording URL expires.
+type Recording struct {
+	ID        uuid.UUID       `json:"id"`
+	CallID    uuid.UUID       `json:"call_id"`
+	SourceURL string          `json:"source_url"`
+	Status    RecordingStatus `json:"status"`
+
+	// Storage
+	StorageBackend string `json:"storage_backend,omitempty"`
+	StorageKey     string `json:"storage_key,omitempty"`
+	ContentType    string `json:"content_type,omitempty"`
+	SizeBytes      int64  `json:"size_bytes"`
+
+	// Download attempts
+	Attempts      int       `json:"attempts"`
+	LastError     *string   `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// Retention
+	StoredAt  *time.Time `json:"stored_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Nil keeps the recording forever
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewRecording creates a pending recording for a call.
+func NewRecording(callID uuid.UUID, sourceURL string) *Recording {
+	now := time.Now().UTC()
+	return &Recording{
+		ID:            uuid.New(),
+		CallID:        callID,
+		SourceURL:     sourceURL,
+		Status:        RecordingStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// IsAvailable reports whether the recording can be played from our storage.
+func (r *Recording) IsAvailable() bool {
+	return r.Status == RecordingStatusStored
+}
+
+// MarkStored records where the recording was stored. A retention of zero
+// keeps it forever.
+func (r *Recording) MarkStored(backend, key, contentType string, size int64, retention time.Duration) {
+	now := time.Now().UTC()
+	r.Status = RecordingStatusStored
+	r.StorageBackend = backend
+	r.StorageKey = key
+	r.ContentType = contentType
+	r.SizeBytes = size
+	r.Attempts++
+	r.LastError = nil
+	r.StoredAt = &now
+	r.ExpiresAt = nil
+	if retention > 0 {
+		expires := now.Add(retention)
+		r.ExpiresAt = &expires
+	}
+	r.UpdatedAt = now
+}
+
+// MarkFailed records a failed download, retrying with exponential backoff
+// (1m, 2m, 4m, ...) until attempts run out.
+func (r *Recording) MarkFailed(err error) {
+	now := time.Now().UTC()
+	r.Attempts++
+	errMsg := err.Error()
+	r.LastError = &errMsg
+	r.UpdatedAt = now
+
+	if r.Attempts >= recordingMaxAttempts {
+		r.Status = RecordingStatusFailed
+		return
+	}
+	r.NextAttemptAt = now.Add(time.Minute << (r.Attempts - 1))
+}
+
+// MarkDeleted records that the stored copy was removed.
+func (r *Recording) MarkDeleted() {
+	now := time.Now().UTC()
+	r.Status = RecordingStatusDeleted
+	r.DeletedAt = &now
+	r.UpdatedAt = now
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRecording_MarkStored(t *testing.T) {
+	r := NewRecording(uuid.New(), "https://provider.example.com/rec.mp3")
+	r.MarkFailed(errors.New("timeout"))
+
+	r.MarkStored("s3", "recordings/2026/03/x.mp3", "audio/mpeg", 1234, 48*time.Hour)
+
+	if !r.IsAvailable() || r.LastError != nil {
+		t.Errorf("Status = %q, LastError = %v; want stored without error", r.Status, r.LastError)
+	}
+	if r.StoredAt == nil || r.ExpiresAt == nil || r.ExpiresAt.Sub(*r.StoredAt) != 48*time.Hour {
+		t.Errorf("StoredAt = %v, ExpiresAt = %v; want 48h retention", r.StoredAt, r.ExpiresAt)
+	}
+
+	r.MarkStored("s3", "recordings/2026/03/x.mp3", "audio/mpeg", 1234, 0)
+	if r.ExpiresAt != nil {
+		t.Errorf("ExpiresAt = %v, want nil for unlimited retention", r.ExpiresAt)
+	}
+
+	r.MarkDeleted()
+	if r.IsAvailable() || r.DeletedAt == nil {
+		t.Errorf("Status = %q, DeletedAt = %v; want deleted", r.Status, r.DeletedAt)
+	}
+}
+
+func TestRecording_MarkFailed(t *testing.T) {
+	r := NewRecording(uuid.New(), "https://provider.example.com/rec.mp3")
+
+	r.MarkFailed(errors.New("403"))
+	if r.Status != RecordingStatusPending || !r.NextAttemptAt.After(time.Now()) {
+		t.Errorf("Status = %q, NextAttemptAt = %v; want a later retry", r.Status, r.NextAttemptAt)
+	}
+
+	for r.Status == RecordingStatusPending {
+		r.MarkFailed(errors.New("403"))
+	}
+	if r.Attempts != recordingMaxAttempts || r.Status != RecordingStatusFailed {
+		t.Errorf("Attempts = %d, Status = %q; want failed after %d", r.Attempts, r.Status, recordingMaxAttempts)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// RecoveryCodeCount is how many recovery codes are issued at a time.
+const RecoveryCodeCount = 10
+
+// recoveryCodeAlphabet leaves out characters that are easily misread.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// recoveryCodeLength is the number of characters in a recovery code,
+// written as two groups of five.
+const recoveryCodeLength = 10
+
+// GenerateRecoveryCodes returns a new set of plaintext recovery codes, such
+// as "k7m2p-xq9ta". The plaintext is only available at creation time.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	b := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		var code strings.Builder
+		for j, v := range b {
+			if j == recoveryCodeLength/2 {
+				code.WriteByte('-')
+			}
+			// 256 is not a multiple of the alphabet size; the bias is negligible
+			// for ten characters of a single-use code.
+			code.WriteByte(recoveryCodeAlphabet[int(v)%len(recoveryCodeAlphabet)])
+		}
+		codes[i] = code.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hex-encoded SHA-256 hash of a recovery code,
+// ignoring case, spaces and dashes. Codes are random, so a fast hash is
+// sufficient for storage.
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer(" ", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
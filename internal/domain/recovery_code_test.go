@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", RecoveryCodeCount, len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[recoveryCodeLength/2] != '-' {
+			t.Errorf("code %q is not two groups of five", code)
+		}
+		if strings.Trim(strings.Replace(code, "-", "", 1), recoveryCodeAlphabet) != "" {
+			t.Errorf("code %q uses characters outside the alphabet", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("k7m2p-xq9ta")
+	for _, input := range []string{"K7M2P-XQ9TA", "k7m2pxq9ta", " k7m2p xq9ta "} {
+		if got := HashRecoveryCode(input); got != want {
+			t.Errorf("HashRecoveryCode(%q) differs from the canonical form", input)
+		}
+	}
+	if HashRecoveryCode("k7m2p-xq9tb") == want {
+		t.Error("different codes should not hash the same")
+	}
+}
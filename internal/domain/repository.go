@@ -29,6 +29,93 @@ type CallRepository interface {
 
 	// SetQuoteJobID associates the latest quote job ID with the call.
 	SetQuoteJobID(ctx context.Context, callID uuid.UUID, jobID *uuid.UUID) error
+
+	// SetTags stores the sentiment, intent and urgency classified for a call.
+	SetTags(ctx context.Context, callID uuid.UUID, tags *CallTags) error
+
+	// SetSpamScore stores the spam score an inbound call was given.
+	SetSpamScore(ctx context.Context, callID uuid.UUID, score int) error
+
+	// SetDisposition stores a call's disposition and where it came from.
+	SetDisposition(ctx context.Context, callID uuid.UUID, disposition CallDisposition, source DispositionSource) error
+}
+
+// CallArchiveRepository defines archiving and soft-deleting calls. A call's
+// quote is archived, deleted and restored with it.
+type CallArchiveRepository interface {
+	// Archive archives a call and its quote. Archiving an archived call
+	// keeps its original archive time.
+	Archive(ctx context.Context, id uuid.UUID) error
+
+	// Unarchive returns an archived call and its quote to everyday lists.
+	Unarchive(ctx context.Context, id uuid.UUID) error
+
+	// Delete soft-deletes a call and its quote.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Restore brings back a soft-deleted call and its quote.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// ArchiveCreatedBefore archives up to limit finished calls created
+	// before cutoff, oldest first, and returns how many it archived.
+	ArchiveCreatedBefore(ctx context.Context, cutoff time.Time, limit int) (int, error)
+
+	// ArchiveMany archives calls and their quotes in one transaction.
+	// Returns a not-found error, changing nothing, if a call is missing.
+	ArchiveMany(ctx context.Context, ids []uuid.UUID) error
+}
+
+// CallAssignmentRepository defines assigning calls, and the quotes on
+// them, to the user following them up.
+type CallAssignmentRepository interface {
+	// Assign assigns calls to a user, or unassigns them when userID is nil,
+	// in one transaction. Returns a not-found error, changing nothing, if a
+	// call is missing.
+	Assign(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) error
+}
+
+// RetentionRepository finds and removes records past their retention and
+// manages the legal holds that exempt calls from it. Every count and purge
+// skips calls on legal hold and calls of customers on legal hold.
+type RetentionRepository interface {
+	// SetLegalHold places a call or customer on legal hold with a reason,
+	// or releases the hold when reason is nil.
+	SetLegalHold(ctx context.Context, target LegalHoldTarget, id uuid.UUID, reason *string) error
+
+	// ListLegalHolds retrieves the calls and customers on legal hold, most
+	// recently placed first.
+	ListLegalHolds(ctx context.Context) ([]*LegalHold, error)
+
+	// CountTranscriptsBefore counts transcripts of calls created before cutoff.
+	CountTranscriptsBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// PurgeTranscriptsBefore removes up to limit transcripts of calls created
+	// before cutoff, oldest first, and returns how many it removed.
+	PurgeTranscriptsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error)
+
+	// CountAuditEventsBefore counts audit events that occurred before cutoff.
+	CountAuditEventsBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// PurgeAuditEventsBefore deletes up to limit audit events that occurred
+	// before cutoff, oldest first, and returns how many it deleted.
+	PurgeAuditEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error)
+}
+
+// CallSearchRepository defines full-text search over call transcripts.
+type CallSearchRepository interface {
+	// SearchTranscripts returns calls whose transcript matches query, best match first.
+	SearchTranscripts(ctx context.Context, query string, limit, offset int) ([]*TranscriptMatch, error)
+
+	// CountTranscriptMatches returns the number of calls whose transcript matches query.
+	CountTranscriptMatches(ctx context.Context, query string) (int, error)
+}
+
+// CallCostRepository aggregates the costs attributed to calls.
+type CallCostRepository interface {
+	// ListCostRollups totals call costs by month and billed number for calls
+	// that ended at or after since, newest month first and costliest number
+	// first within a month.
+	ListCostRollups(ctx context.Context, since time.Time) ([]*CallCostRollup, error)
 }
 
 // UserRepository defines the interface for user data persistence.
@@ -42,11 +129,29 @@ type UserRepository interface {
 	// GetByEmail retrieves a user by email address.
 	GetByEmail(ctx context.Context, email string) (*User, error)
 
+	// GetBySSOIdentity retrieves the user linked to an identity provider's
+	// issuer and subject.
+	GetBySSOIdentity(ctx context.Context, issuer, subject string) (*User, error)
+
 	// Update updates an existing user.
 	Update(ctx context.Context, user *User) error
 
+	// AdvanceTOTPCounter records counter as the time step of a user's last
+	// accepted TOTP code, returning not found unless it is newer than the
+	// one recorded.
+	AdvanceTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) error
+
+	// Delete soft-deletes a user.
+	Delete(ctx context.Context, id uuid.UUID) error
+
 	// Count returns the total number of users.
 	Count(ctx context.Context) (int64, error)
+
+	// List returns all users, ordered by email.
+	List(ctx context.Context) ([]*User, error)
+
+	// CountActiveAdmins returns the number of admins who are not disabled.
+	CountActiveAdmins(ctx context.Context) (int64, error)
 }
 
 // SessionRepository defines the interface for session data persistence.
@@ -70,6 +175,42 @@ type SessionRepository interface {
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 }
 
+// RecoveryCodeRepository defines the interface for two-factor recovery code persistence.
+type RecoveryCodeRepository interface {
+	// Replace deletes a user's recovery codes and stores new code hashes.
+	Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// Use marks an unused code as used. It returns a not-found error when the
+	// user has no unused code with the hash.
+	Use(ctx context.Context, userID uuid.UUID, codeHash string) error
+
+	// CountUnused returns how many of a user's codes are still unused.
+	CountUnused(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// DeleteByUserID removes all of a user's codes.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// UserTokenRepository defines the interface for password reset and email
+// verification token persistence.
+type UserTokenRepository interface {
+	// Create inserts a new token.
+	Create(ctx context.Context, token *UserToken) error
+
+	// GetByHash retrieves a token by purpose and hash.
+	GetByHash(ctx context.Context, purpose TokenPurpose, tokenHash string) (*UserToken, error)
+
+	// MarkUsed marks an unused token as used. It returns a not-found error
+	// when the token was already used.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUserID removes a user's tokens for a purpose.
+	DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose TokenPurpose) error
+
+	// DeleteExpired removes expired tokens.
+	DeleteExpired(ctx context.Context) error
+}
+
 // QuoteJobRepository defines the interface for quote job persistence.
 type QuoteJobRepository interface {
 	// Create inserts a new quote job.
@@ -81,17 +222,912 @@ type QuoteJobRepository interface {
 	// GetByCallID retrieves the job for a specific call.
 	GetByCallID(ctx context.Context, callID uuid.UUID) (*QuoteJob, error)
 
-	// Update updates an existing job.
+	// Update updates an existing job. It fails with a conflict error if
+	// another worker has claimed the job since it was read. The lease is kept
+	// only while the job is processing.
 	Update(ctx context.Context, job *QuoteJob) error
 
-	// GetPendingJobs retrieves jobs ready to be processed.
-	// Returns jobs where status='pending' and scheduled_at <= now.
-	GetPendingJobs(ctx context.Context, limit int) ([]*QuoteJob, error)
+	// ClaimPendingJobs leases up to limit jobs that are ready to be processed
+	// and not leased by a live worker, skipping jobs other workers are
+	// claiming concurrently.
+	ClaimPendingJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*QuoteJob, error)
+
+	// ClaimExpiredJobs leases up to limit processing jobs whose lease expired,
+	// so the claiming worker can recover them.
+	ClaimExpiredJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*QuoteJob, error)
+
+	// ExtendLease renews a worker's lease on a job. It fails with a conflict
+	// error if the worker no longer holds the job.
+	ExtendLease(ctx context.Context, id uuid.UUID, workerID string, lease time.Duration) error
 
-	// GetProcessingJobs retrieves jobs currently being processed.
-	// Useful for detecting stuck jobs on startup.
-	GetProcessingJobs(ctx context.Context, olderThan time.Duration) ([]*QuoteJob, error)
+	// ReleaseLease gives up a worker's lease on a job it has not started.
+	ReleaseLease(ctx context.Context, id uuid.UUID, workerID string) error
+
+	// ListByStatus retrieves jobs with the given status, most recently
+	// updated first. An empty status lists jobs of every status.
+	ListByStatus(ctx context.Context, status QuoteJobStatus, limit, offset int) ([]*QuoteJob, error)
 
 	// CountByStatus returns counts of jobs by status.
 	CountByStatus(ctx context.Context) (map[QuoteJobStatus]int, error)
 }
+
+// WebhookEventRepository defines the interface for webhook event persistence.
+type WebhookEventRepository interface {
+	// Create inserts a new webhook event.
+	Create(ctx context.Context, event *WebhookEvent) error
+
+	// GetByID retrieves a webhook event by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookEvent, error)
+
+	// Update updates an existing webhook event.
+	Update(ctx context.Context, event *WebhookEvent) error
+
+	// GetPendingEvents retrieves events due for a retry.
+	// Returns events where status='pending' and scheduled_at <= now.
+	GetPendingEvents(ctx context.Context, limit int) ([]*WebhookEvent, error)
+
+	// GetProcessingEvents retrieves events that have been processing longer than olderThan.
+	// Useful for detecting events interrupted by a restart.
+	GetProcessingEvents(ctx context.Context, olderThan time.Duration) ([]*WebhookEvent, error)
+
+	// CountByStatus returns counts of events by status.
+	CountByStatus(ctx context.Context) (map[WebhookEventStatus]int, error)
+}
+
+// ProcessedWebhookRepository remembers the provider webhooks already
+// processed, so redeliveries can be skipped.
+type ProcessedWebhookRepository interface {
+	// Claim records the webhook keyed by provider and eventKey as processed
+	// until expiresAt. It returns false if the webhook was already claimed
+	// and has not expired.
+	Claim(ctx context.Context, provider, eventKey string, expiresAt time.Time) (bool, error)
+
+	// Release forgets a claimed webhook, so a redelivery is processed.
+	Release(ctx context.Context, provider, eventKey string) error
+
+	// CleanupExpired removes expired webhooks.
+	CleanupExpired(ctx context.Context) error
+}
+
+// WebhookArchiveRepository defines the interface for raw webhook archive persistence.
+type WebhookArchiveRepository interface {
+	// Create stores an archived webhook.
+	Create(ctx context.Context, webhook *ArchivedWebhook) error
+
+	// GetByID retrieves an archived webhook by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*ArchivedWebhook, error)
+
+	// List returns archived webhooks matching filter, newest first.
+	List(ctx context.Context, filter WebhookArchiveFilter) ([]*ArchivedWebhook, error)
+
+	// DeleteBefore removes webhooks received before the given time and
+	// returns how many were removed.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// APIKeyRepository defines the interface for API key persistence.
+type APIKeyRepository interface {
+	// Create inserts a new API key.
+	Create(ctx context.Context, key *APIKey) error
+
+	// GetByID retrieves an API key by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+
+	// GetByHash retrieves an API key by the SHA-256 hash of its secret.
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+
+	// List retrieves all API keys, newest first.
+	List(ctx context.Context) ([]*APIKey, error)
+
+	// Update updates an existing API key (rotation, revocation, scopes).
+	Update(ctx context.Context, key *APIKey) error
+
+	// TouchLastUsed records that a key was just used.
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// CampaignRepository defines the interface for call campaign persistence.
+type CampaignRepository interface {
+	// Create inserts a campaign together with its contacts.
+	Create(ctx context.Context, campaign *Campaign, contacts []*CampaignContact) error
+
+	// GetByID retrieves a campaign by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*Campaign, error)
+
+	// Update updates a campaign's status, schedule and pacing state.
+	Update(ctx context.Context, campaign *Campaign) error
+
+	// List retrieves campaigns, newest first.
+	List(ctx context.Context, limit, offset int) ([]*Campaign, error)
+
+	// ListActive retrieves active campaigns whose start time has passed.
+	ListActive(ctx context.Context, now time.Time) ([]*Campaign, error)
+
+	// NextPendingContact retrieves the oldest pending contact of a campaign,
+	// or nil if none remain.
+	NextPendingContact(ctx context.Context, campaignID uuid.UUID) (*CampaignContact, error)
+
+	// UpdateContact updates a contact's dialing state.
+	UpdateContact(ctx context.Context, contact *CampaignContact) error
+
+	// ListContacts retrieves a campaign's contacts in upload order.
+	ListContacts(ctx context.Context, campaignID uuid.UUID, limit, offset int) ([]*CampaignContact, error)
+
+	// CountContactsByStatus returns counts of a campaign's contacts by status.
+	CountContactsByStatus(ctx context.Context, campaignID uuid.UUID) (map[CampaignContactStatus]int, error)
+
+	// FailStaleDialingContacts marks contacts left dialing longer than olderThan
+	// as failed, returning how many were updated. Used after a restart.
+	FailStaleDialingContacts(ctx context.Context, olderThan time.Duration) (int, error)
+}
+
+// QuoteRepository defines the interface for quote workflow persistence.
+type QuoteRepository interface {
+	// GetByCallID retrieves the quote for a call.
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*Quote, error)
+
+	// SaveTransition upserts the quote and records the transition atomically.
+	SaveTransition(ctx context.Context, quote *Quote, transition *QuoteTransition) error
+
+	// SaveContent upserts the quote with its edited line items, tax rate and notes.
+	SaveContent(ctx context.Context, quote *Quote) error
+
+	// SaveArchived upserts the quote with its archive time.
+	SaveArchived(ctx context.Context, quote *Quote) error
+
+	// SaveArchivedMany upserts quotes with their archive times in one
+	// transaction.
+	SaveArchivedMany(ctx context.Context, quotes []*Quote) error
+
+	// ListTransitions retrieves a quote's status history, oldest first.
+	ListTransitions(ctx context.Context, callID uuid.UUID) ([]*QuoteTransition, error)
+}
+
+// QuoteLinkRepository defines the interface for customer quote link persistence.
+type QuoteLinkRepository interface {
+	// Replace stores a link, removing any earlier link to the same quote.
+	Replace(ctx context.Context, link *QuoteLink) error
+
+	// GetByHash retrieves a link by the hash of its token.
+	GetByHash(ctx context.Context, tokenHash string) (*QuoteLink, error)
+
+	// MarkViewed records the first time a link was opened.
+	MarkViewed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// QuotePaymentRepository defines the interface for quote payment persistence.
+type QuotePaymentRepository interface {
+	// Replace stores a payment, removing any earlier payment for the same quote.
+	Replace(ctx context.Context, payment *QuotePayment) error
+
+	// GetByCallID retrieves the payment for a quote.
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*QuotePayment, error)
+
+	// GetByProviderLinkID retrieves a payment by the processor's link ID.
+	GetByProviderLinkID(ctx context.Context, provider, linkID string) (*QuotePayment, error)
+
+	// Update saves a payment's status.
+	Update(ctx context.Context, payment *QuotePayment) error
+}
+
+// AccountingRepository defines the interface for accounting connection,
+// mapping and sync log persistence.
+type AccountingRepository interface {
+	// GetConnection retrieves the connection for a provider.
+	GetConnection(ctx context.Context, provider string) (*AccountingConnection, error)
+
+	// SaveConnection creates or replaces the connection for its provider.
+	SaveConnection(ctx context.Context, conn *AccountingConnection) error
+
+	// DeleteConnection removes the connection for a provider.
+	DeleteConnection(ctx context.Context, provider string) error
+
+	// GetMapping retrieves the mapping for a provider.
+	GetMapping(ctx context.Context, provider string) (*AccountingMapping, error)
+
+	// SaveMapping creates or replaces the mapping for its provider.
+	SaveMapping(ctx context.Context, mapping *AccountingMapping) error
+
+	// CreateSync records a sync attempt.
+	CreateSync(ctx context.Context, sync *AccountingSync) error
+
+	// ListSyncsByCallID lists a quote's sync attempts, newest first.
+	ListSyncsByCallID(ctx context.Context, callID uuid.UUID) ([]*AccountingSync, error)
+
+	// ListRecentSyncs lists the latest sync attempts across quotes, newest first.
+	ListRecentSyncs(ctx context.Context, limit int) ([]*AccountingSync, error)
+}
+
+// CRMSyncRepository defines the interface for the CRM sync queue.
+type CRMSyncRepository interface {
+	// Enqueue queues a call's sync for an immediate attempt, creating it if
+	// needed and keeping the CRM record IDs of an existing one.
+	Enqueue(ctx context.Context, provider string, callID uuid.UUID, now time.Time) error
+
+	// Get retrieves a call's sync.
+	Get(ctx context.Context, provider string, callID uuid.UUID) (*CRMSync, error)
+
+	// Update records the outcome of an attempt. A sync queued again since
+	// it was read stays pending for another attempt.
+	Update(ctx context.Context, sync *CRMSync) error
+
+	// ListDue retrieves pending syncs whose next attempt is due, oldest first.
+	ListDue(ctx context.Context, provider string, now time.Time, limit int) ([]*CRMSync, error)
+
+	// List retrieves syncs with a status, or all syncs when status is empty,
+	// most recently updated first.
+	List(ctx context.Context, provider string, status CRMSyncStatus, limit int) ([]*CRMSync, error)
+}
+
+// NotificationPreferenceRepository defines the interface for per-user
+// notification preference persistence.
+type NotificationPreferenceRepository interface {
+	// Get retrieves a user's preferences.
+	Get(ctx context.Context, userID uuid.UUID) (*NotificationPreferences, error)
+
+	// Save creates or replaces a user's preferences.
+	Save(ctx context.Context, prefs *NotificationPreferences) error
+
+	// ListForEvent retrieves the preferences of active users who chose to be
+	// notified of the event on the channel.
+	ListForEvent(ctx context.Context, channel NotificationChannel, event NotificationEvent) ([]*NotificationPreferences, error)
+}
+
+// CustomerRepository defines the interface for customer persistence.
+type CustomerRepository interface {
+	// Create inserts a new customer. Returns a conflict error if the phone
+	// number already belongs to another customer.
+	Create(ctx context.Context, customer *Customer) error
+
+	// GetByID retrieves a customer by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*Customer, error)
+
+	// GetOrCreateByPhoneNumber returns the customer with the given E.164
+	// number, inserting customer if there is none.
+	GetOrCreateByPhoneNumber(ctx context.Context, customer *Customer) (*Customer, error)
+
+	// Update updates a customer's details.
+	Update(ctx context.Context, customer *Customer) error
+
+	// Delete removes a customer. Their calls are kept and unlinked.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves customers matching filter, most recently updated
+	// first. A nil filter matches every customer.
+	List(ctx context.Context, filter *CustomerListFilter, limit, offset int) ([]*Customer, error)
+
+	// Count returns the number of customers matching filter.
+	Count(ctx context.Context, filter *CustomerListFilter) (int, error)
+}
+
+// ConversationMessageRepository defines the interface for storing the
+// messages exchanged with customers.
+type ConversationMessageRepository interface {
+	// Create inserts a message. A message whose provider message ID was
+	// already stored for the channel is skipped, so redelivered webhooks are
+	// recorded once.
+	Create(ctx context.Context, message *ConversationMessage) error
+
+	// ListByPhoneNumber retrieves the most recent messages with an E.164
+	// number, newest first.
+	ListByPhoneNumber(ctx context.Context, phoneNumber string, limit int) ([]*ConversationMessage, error)
+
+	// ListLatest retrieves the newest message of each conversation, most
+	// recent conversation first.
+	ListLatest(ctx context.Context, limit int) ([]*ConversationMessage, error)
+}
+
+// WhatsAppOptInRepository defines the interface for WhatsApp opt-in
+// persistence.
+type WhatsAppOptInRepository interface {
+	// Get retrieves the opt-in for an E.164 number.
+	Get(ctx context.Context, phoneNumber string) (*WhatsAppOptIn, error)
+
+	// Save creates or replaces the opt-in for its phone number.
+	Save(ctx context.Context, optIn *WhatsAppOptIn) error
+}
+
+// PricingRuleRepository defines the interface for pricing rule persistence.
+type PricingRuleRepository interface {
+	// Create inserts a new rule. Returns a conflict error if another rule
+	// already prices the project type.
+	Create(ctx context.Context, rule *PricingRule) error
+
+	// GetByID retrieves a rule by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*PricingRule, error)
+
+	// GetActiveByProjectType retrieves the active rule for a normalized
+	// project type.
+	GetActiveByProjectType(ctx context.Context, projectType string) (*PricingRule, error)
+
+	// Update updates a rule.
+	Update(ctx context.Context, rule *PricingRule) error
+
+	// Delete removes a rule.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all rules ordered by project type.
+	List(ctx context.Context) ([]*PricingRule, error)
+}
+
+// QuoteTemplateRepository defines the interface for quote template
+// persistence.
+type QuoteTemplateRepository interface {
+	// Create inserts a new template. Returns a conflict error if another
+	// template already covers the project type.
+	Create(ctx context.Context, template *QuoteTemplate) error
+
+	// GetByID retrieves a template by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*QuoteTemplate, error)
+
+	// GetActiveByProjectType retrieves the active template for a normalized
+	// project type.
+	GetActiveByProjectType(ctx context.Context, projectType string) (*QuoteTemplate, error)
+
+	// Update updates a template.
+	Update(ctx context.Context, template *QuoteTemplate) error
+
+	// Delete removes a template.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all templates ordered by project type.
+	List(ctx context.Context) ([]*QuoteTemplate, error)
+}
+
+// RoutingRuleRepository defines the interface for inbound routing rule
+// persistence.
+type RoutingRuleRepository interface {
+	// Create inserts a new rule.
+	Create(ctx context.Context, rule *RoutingRule) error
+
+	// GetByID retrieves a rule by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*RoutingRule, error)
+
+	// Update updates a rule.
+	Update(ctx context.Context, rule *RoutingRule) error
+
+	// Delete removes a rule.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all rules in the order they are checked: by priority,
+	// then name.
+	List(ctx context.Context) ([]*RoutingRule, error)
+}
+
+// FeatureFlagRepository defines the interface for feature flag persistence.
+type FeatureFlagRepository interface {
+	// Create inserts a new flag. It returns a conflict error when a flag
+	// with the key exists.
+	Create(ctx context.Context, flag *FeatureFlag) error
+
+	// GetByKey retrieves a flag by key.
+	GetByKey(ctx context.Context, key string) (*FeatureFlag, error)
+
+	// Update updates a flag.
+	Update(ctx context.Context, flag *FeatureFlag) error
+
+	// Delete removes a flag.
+	Delete(ctx context.Context, key string) error
+
+	// List retrieves all flags ordered by key.
+	List(ctx context.Context) ([]*FeatureFlag, error)
+}
+
+// ProviderCredentialRepository defines the interface for encrypted provider
+// API key persistence.
+type ProviderCredentialRepository interface {
+	// Upsert stores a provider's key, replacing any key stored before.
+	Upsert(ctx context.Context, cred *ProviderCredential) error
+
+	// Get retrieves a provider's stored key.
+	Get(ctx context.Context, provider CredentialProvider) (*ProviderCredential, error)
+
+	// List retrieves every stored key ordered by provider.
+	List(ctx context.Context) ([]*ProviderCredential, error)
+
+	// Delete removes a provider's stored key.
+	Delete(ctx context.Context, provider CredentialProvider) error
+}
+
+// BackupRepository defines the interface for database backup records.
+type BackupRepository interface {
+	// Create inserts a backup.
+	Create(ctx context.Context, backup *Backup) error
+
+	// Update updates a backup's status, size, digest and error.
+	Update(ctx context.Context, backup *Backup) error
+
+	// Get retrieves a backup by ID.
+	Get(ctx context.Context, id uuid.UUID) (*Backup, error)
+
+	// List retrieves the most recent backups, newest first.
+	List(ctx context.Context, limit int) ([]*Backup, error)
+
+	// ListExpired retrieves up to limit finished backups whose retention
+	// ended before now.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*Backup, error)
+
+	// Delete removes a backup record.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TagRepository defines the interface for tag, applied tag and saved tag
+// view persistence.
+type TagRepository interface {
+	// Create inserts a tag. Returns an already-exists error if the name is
+	// taken.
+	Create(ctx context.Context, tag *Tag) error
+
+	// GetOrCreate returns the tag with tag's name, inserting tag if there
+	// is none.
+	GetOrCreate(ctx context.Context, tag *Tag) (*Tag, error)
+
+	// GetByName retrieves a tag by name.
+	GetByName(ctx context.Context, name string) (*Tag, error)
+
+	// Update updates a tag's description, color and whether it is managed.
+	Update(ctx context.Context, tag *Tag) error
+
+	// Delete removes a tag and takes it off every record.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves every tag with its usage count, ordered by name.
+	List(ctx context.Context) ([]*Tag, error)
+
+	// Apply tags a record. Applying a tag a record already has does nothing.
+	Apply(ctx context.Context, tagID uuid.UUID, entityType TagEntityType, entityID uuid.UUID, createdBy *uuid.UUID) error
+
+	// ApplyMany tags records of one type in one statement. Records that
+	// already carry the tag keep it.
+	ApplyMany(ctx context.Context, tagID uuid.UUID, entityType TagEntityType, entityIDs []uuid.UUID, createdBy *uuid.UUID) error
+
+	// Remove takes a tag off a record. Returns a not-found error if the
+	// record doesn't have it.
+	Remove(ctx context.Context, tagID uuid.UUID, entityType TagEntityType, entityID uuid.UUID) error
+
+	// ListFor retrieves the tags on a record, ordered by name.
+	ListFor(ctx context.Context, entityType TagEntityType, entityID uuid.UUID) ([]*Tag, error)
+
+	// CreateView inserts a saved view.
+	CreateView(ctx context.Context, view *TagView) error
+
+	// GetView retrieves a saved view by ID.
+	GetView(ctx context.Context, id uuid.UUID) (*TagView, error)
+
+	// ListViews retrieves the shared views and the user's own, shared
+	// first, then by name.
+	ListViews(ctx context.Context, userID uuid.UUID) ([]*TagView, error)
+
+	// DeleteView removes a saved view.
+	DeleteView(ctx context.Context, id uuid.UUID) error
+}
+
+// SavedViewRepository defines the interface for saved calls list view
+// persistence.
+type SavedViewRepository interface {
+	// Create inserts a view. Returns an already-exists error if the user
+	// has a view with the same name.
+	Create(ctx context.Context, view *SavedView) error
+
+	// GetByID retrieves a view by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*SavedView, error)
+
+	// Update updates a view's name, filters, sort and columns.
+	Update(ctx context.Context, view *SavedView) error
+
+	// Delete removes a view.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListByUser retrieves a user's views, ordered by name.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*SavedView, error)
+
+	// CountByUser counts a user's views.
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// GetDefault retrieves the user's default view.
+	GetDefault(ctx context.Context, userID uuid.UUID) (*SavedView, error)
+
+	// SetDefault makes a view the user's default, replacing any other. A
+	// nil id leaves the user without one.
+	SetDefault(ctx context.Context, userID uuid.UUID, id *uuid.UUID) error
+}
+
+// FollowUpRepository defines the interface for quote follow-up step persistence.
+type FollowUpRepository interface {
+	// CreateSteps inserts a quote's follow-up steps, skipping any position
+	// the quote already has.
+	CreateSteps(ctx context.Context, steps []*FollowUpStep) error
+
+	// ClaimDue returns up to limit pending steps whose next attempt is due,
+	// pushing their next attempt back by lease so other workers skip them.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*FollowUpStep, error)
+
+	// Update updates a step.
+	Update(ctx context.Context, step *FollowUpStep) error
+
+	// CancelPending cancels a quote's pending steps and returns how many
+	// were cancelled.
+	CancelPending(ctx context.Context, callID uuid.UUID, reason string) (int, error)
+
+	// ListByCallID retrieves a quote's steps in sequence order.
+	ListByCallID(ctx context.Context, callID uuid.UUID) ([]*FollowUpStep, error)
+}
+
+// CallAttemptRepository defines the interface for call retry persistence.
+type CallAttemptRepository interface {
+	// Create inserts an attempt. It returns false without inserting if the
+	// previous call already has a retry scheduled.
+	Create(ctx context.Context, attempt *CallAttempt) (bool, error)
+
+	// GetByCallID retrieves the attempt that placed a call.
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*CallAttempt, error)
+
+	// ClaimDue returns up to limit scheduled attempts that are due, pushing
+	// them back by lease so other workers skip them.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*CallAttempt, error)
+
+	// Update updates an attempt.
+	Update(ctx context.Context, attempt *CallAttempt) error
+
+	// CancelScheduled cancels the scheduled attempts to a phone number and
+	// returns how many were cancelled.
+	CancelScheduled(ctx context.Context, phoneNumber, reason string) (int, error)
+
+	// ListByOriginalCallID retrieves the retries of a call in attempt order.
+	ListByOriginalCallID(ctx context.Context, callID uuid.UUID) ([]*CallAttempt, error)
+}
+
+// BlocklistRepository defines the interface for the local blocklist mirror.
+type BlocklistRepository interface {
+	// Upsert inserts an entry, or updates the direction, reason and Bland ID
+	// of the entry for the same number or prefix. The stored entry is
+	// written back to entry.
+	Upsert(ctx context.Context, entry *BlockedNumber) error
+
+	// GetByID retrieves an entry by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*BlockedNumber, error)
+
+	// GetByBlandID retrieves an entry by Bland's ID for the block.
+	GetByBlandID(ctx context.Context, blandID string) (*BlockedNumber, error)
+
+	// Delete removes an entry.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all entries ordered by number.
+	List(ctx context.Context) ([]*BlockedNumber, error)
+
+	// Match returns the most specific entry that blocks phoneNumber in the
+	// given direction.
+	Match(ctx context.Context, phoneNumber string, direction BlockDirection) (*BlockedNumber, error)
+}
+
+// NumberHealthRepository defines the interface for caller ID health.
+type NumberHealthRepository interface {
+	// Upsert inserts or replaces the health of a number.
+	Upsert(ctx context.Context, health *NumberHealth) error
+
+	// Get retrieves the health of a number.
+	Get(ctx context.Context, phoneNumber string) (*NumberHealth, error)
+
+	// List retrieves the health of every number checked, least healthy first.
+	List(ctx context.Context) ([]*NumberHealth, error)
+
+	// DeliveryStats counts the calls made from each of phoneNumbers since the
+	// given time. Numbers with no calls are left out.
+	DeliveryStats(ctx context.Context, phoneNumbers []string, since time.Time) ([]*NumberDeliveryStats, error)
+}
+
+// CallbackRepository defines the interface for callback persistence.
+type CallbackRepository interface {
+	// Create inserts a new callback.
+	Create(ctx context.Context, callback *Callback) error
+
+	// GetByID retrieves a callback by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*Callback, error)
+
+	// Update updates a callback.
+	Update(ctx context.Context, callback *Callback) error
+
+	// ListDue retrieves pending callbacks whose next calendar attempt is due,
+	// oldest first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*Callback, error)
+
+	// ListUpcoming retrieves callbacks scheduled at or after from, soonest first.
+	ListUpcoming(ctx context.Context, from time.Time, limit int) ([]*Callback, error)
+}
+
+// RecordingRepository defines the interface for call recording persistence.
+type RecordingRepository interface {
+	// Create inserts a new recording. A call has at most one recording.
+	Create(ctx context.Context, recording *Recording) error
+
+	// GetByCallID retrieves the recording for a call.
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*Recording, error)
+
+	// Update updates a recording.
+	Update(ctx context.Context, recording *Recording) error
+
+	// ListDue retrieves pending recordings whose next download attempt is
+	// due, oldest first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*Recording, error)
+
+	// ListExpired retrieves stored recordings whose retention ended before now,
+	// leaving out recordings of calls on legal hold.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*Recording, error)
+
+	// CountStoredBefore counts recordings stored before cutoff, leaving out
+	// recordings of calls on legal hold.
+	CountStoredBefore(ctx context.Context, cutoff time.Time) (int, error)
+
+	// ListStoredBefore retrieves recordings stored before cutoff, oldest
+	// first.
+	ListStoredBefore(ctx context.Context, cutoff time.Time, limit int) ([]*Recording, error)
+}
+
+// BlandEntityRepository defines the interface for the local cache of Bland
+// entities.
+type BlandEntityRepository interface {
+	// Replace swaps the cached entities of a kind for entities and records a
+	// successful sync with the given ETag.
+	Replace(ctx context.Context, kind BlandEntityKind, entities []*BlandEntity, etag string, syncedAt time.Time) error
+
+	// List retrieves the cached entities of a kind in Bland's order.
+	List(ctx context.Context, kind BlandEntityKind) ([]*BlandEntity, error)
+
+	// GetSyncState retrieves the sync state of a kind. It returns a
+	// not-found error if the kind has never been synced.
+	GetSyncState(ctx context.Context, kind BlandEntityKind) (*BlandSyncState, error)
+
+	// ListSyncStates retrieves the sync state of every kind synced so far.
+	ListSyncStates(ctx context.Context) ([]*BlandSyncState, error)
+
+	// RecordSyncError records a failed sync, keeping the cached entities.
+	RecordSyncError(ctx context.Context, kind BlandEntityKind, message string, at time.Time) error
+}
+
+// BlandWriteRepository defines the interface for the queue of Bland writes
+// made while Bland was unreachable.
+type BlandWriteRepository interface {
+	// Create queues a write.
+	Create(ctx context.Context, write *BlandWrite) error
+
+	// ListPending retrieves up to limit pending writes, oldest first.
+	ListPending(ctx context.Context, limit int) ([]*BlandWrite, error)
+
+	// Update saves the status, attempts and error of a write.
+	Update(ctx context.Context, write *BlandWrite) error
+
+	// CountPending returns the number of pending writes.
+	CountPending(ctx context.Context) (int, error)
+}
+
+// ExperimentRepository defines the interface for experiment persistence.
+type ExperimentRepository interface {
+	// Create inserts an experiment together with its variants.
+	Create(ctx context.Context, experiment *Experiment) error
+
+	// GetByID retrieves an experiment and its variants.
+	GetByID(ctx context.Context, id uuid.UUID) (*Experiment, error)
+
+	// Update updates an experiment's name, description and status.
+	Update(ctx context.Context, experiment *Experiment) error
+
+	// Delete removes an experiment, its variants and its assignments.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves experiments, newest first.
+	List(ctx context.Context, limit, offset int) ([]*Experiment, error)
+
+	// ListRunning retrieves the experiments currently splitting calls.
+	ListRunning(ctx context.Context) ([]*Experiment, error)
+
+	// RecordAssignment saves the variant a call was given. It returns false
+	// if the call already had an assignment.
+	RecordAssignment(ctx context.Context, assignment *ExperimentAssignment) (bool, error)
+
+	// VariantOutcomes returns outcome totals for each variant with calls.
+	VariantOutcomes(ctx context.Context, experimentID uuid.UUID) ([]*ExperimentVariantOutcome, error)
+}
+
+// AnalyticsRepository defines aggregate queries over calls and quotes.
+type AnalyticsRepository interface {
+	// CallKPIs returns call counts and average duration. Rates and quote
+	// values are left for the caller to fill in.
+	CallKPIs(ctx context.Context, filter *AnalyticsFilter) (*CallKPIs, error)
+
+	// QuoteValues returns the number and total of priced quotes in each
+	// currency, largest total first.
+	QuoteValues(ctx context.Context, filter *AnalyticsFilter) ([]*CurrencyTotal, error)
+
+	// CallsPerDay returns call counts for each UTC day with calls, oldest first.
+	CallsPerDay(ctx context.Context, filter *AnalyticsFilter) ([]*DailyCallVolume, error)
+
+	// PromptMetrics returns call counts and average duration for each prompt
+	// with calls, busiest first.
+	PromptMetrics(ctx context.Context, filter *AnalyticsFilter) ([]*PromptCallMetrics, error)
+
+	// TagCounts returns the number of tagged calls with each sentiment,
+	// intent and urgency.
+	TagCounts(ctx context.Context, filter *AnalyticsFilter) (*CallTagCounts, error)
+}
+
+// AnalyticsRollupRepository stores daily call metrics by prompt and by
+// inbound number, so comparisons over long ranges read a few rows per day
+// instead of every call.
+type AnalyticsRollupRepository interface {
+	// RollUpDay recomputes the rollups for the UTC day starting at day from
+	// the calls created that day and their quotes, replacing earlier ones.
+	RollUpDay(ctx context.Context, day time.Time) error
+
+	// Breakdown totals the rollups for the days from filter.From up to
+	// filter.To by dimension key, busiest first. Rates are left for the
+	// caller to fill in.
+	Breakdown(ctx context.Context, dimension AnalyticsDimension, filter *AnalyticsFilter) ([]*DimensionMetrics, error)
+}
+
+// WebhookSubscriptionRepository defines the interface for outgoing webhook
+// subscription persistence.
+type WebhookSubscriptionRepository interface {
+	// Create inserts a new subscription.
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+
+	// GetByID retrieves a subscription by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookSubscription, error)
+
+	// Update updates a subscription, including its secret.
+	Update(ctx context.Context, subscription *WebhookSubscription) error
+
+	// Delete removes a subscription and its deliveries.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves all subscriptions, oldest first.
+	List(ctx context.Context) ([]*WebhookSubscription, error)
+
+	// ListForEvent retrieves the active subscriptions that receive an event.
+	ListForEvent(ctx context.Context, event WebhookEventType) ([]*WebhookSubscription, error)
+}
+
+// WebhookDeliveryRepository defines the interface for the outgoing webhook
+// delivery queue and log.
+type WebhookDeliveryRepository interface {
+	// Create queues a delivery.
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+
+	// GetByID retrieves a delivery by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+
+	// Update records the outcome of a delivery attempt.
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+
+	// ListDue retrieves pending deliveries whose next attempt is due, oldest first.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+
+	// ListBySubscription retrieves a subscription's deliveries, newest first.
+	ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*WebhookDelivery, error)
+}
+
+// EventRepository defines the interface for the events feed.
+type EventRepository interface {
+	// Create appends an event to the feed, setting its sequence.
+	Create(ctx context.Context, event *Event) error
+
+	// List retrieves events after filter.After in feed order, skipping those
+	// created after settledBefore.
+	List(ctx context.Context, filter *EventFilter, settledBefore time.Time) ([]*Event, error)
+}
+
+// CallEventRepository defines the interface for the append-only log of call
+// status events.
+type CallEventRepository interface {
+	// Append records an event.
+	Append(ctx context.Context, event *CallEvent) error
+
+	// ListByCall retrieves a call's events in the order they were recorded.
+	ListByCall(ctx context.Context, callID uuid.UUID) ([]*CallEvent, error)
+}
+
+// BudgetRepository defines the interface for budget guard state.
+type BudgetRepository interface {
+	// CreateOverride inserts an override.
+	CreateOverride(ctx context.Context, override *BudgetOverride) error
+
+	// GetActiveOverride retrieves the override in effect at now with the
+	// latest expiry, or nil if there is none.
+	GetActiveOverride(ctx context.Context, now time.Time) (*BudgetOverride, error)
+
+	// RevokeOverrides revokes every override in effect at now, returning how
+	// many were revoked.
+	RevokeOverrides(ctx context.Context, now time.Time) (int, error)
+
+	// AddPausedBatch records a batch paused by the budget guard.
+	AddPausedBatch(ctx context.Context, batchID string) error
+
+	// ListPausedBatches retrieves the batches paused by the budget guard.
+	ListPausedBatches(ctx context.Context) ([]string, error)
+
+	// RemovePausedBatch forgets a batch paused by the budget guard.
+	RemovePausedBatch(ctx context.Context, batchID string) error
+
+	// CallMinutesSince sums the duration of calls that ended at or after
+	// since, including deleted calls.
+	CallMinutesSince(ctx context.Context, since time.Time) (float64, error)
+}
+
+// DNCRepository defines the interface for the do-not-call list.
+type DNCRepository interface {
+	// Add puts a number on the list. A number already on it keeps its
+	// existing entry.
+	Add(ctx context.Context, entry *DNCEntry) error
+
+	// Get retrieves the entry for a number.
+	Get(ctx context.Context, phoneNumber string) (*DNCEntry, error)
+
+	// Remove takes a number off the list. An empty source removes the entry
+	// whatever its source; otherwise only an entry from that source is
+	// removed.
+	Remove(ctx context.Context, phoneNumber string, source DNCSource) error
+
+	// List retrieves entries matching the filter, newest first.
+	List(ctx context.Context, filter *DNCListFilter, limit, offset int) ([]*DNCEntry, error)
+
+	// Count returns the number of entries matching the filter.
+	Count(ctx context.Context, filter *DNCListFilter) (int, error)
+
+	// ImportNational adds numbers from the national registry, marking them
+	// as listed by the import at importedAt. Numbers already on the list
+	// from another source keep their entry.
+	ImportNational(ctx context.Context, phoneNumbers []string, importedAt time.Time) error
+
+	// PruneNational removes national entries not listed by an import at or
+	// after before, returning how many were removed.
+	PruneNational(ctx context.Context, before time.Time) (int, error)
+}
+
+// SpamCallerRepository persists the review queue of callers flagged as spam.
+type SpamCallerRepository interface {
+	// RecordFlag adds a flagged call to the caller's entry, creating it if
+	// the number has none, and returns the stored entry.
+	RecordFlag(ctx context.Context, phoneNumber string, score int, reasons []string, at time.Time) (*SpamCaller, error)
+
+	// GetByID retrieves an entry by ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*SpamCaller, error)
+
+	// GetByPhoneNumber retrieves the entry for an E.164 number.
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*SpamCaller, error)
+
+	// List retrieves entries with the given status, most recently flagged
+	// first.
+	List(ctx context.Context, status SpamCallerStatus, limit int) ([]*SpamCaller, error)
+
+	// Review sets an entry's status. reviewedBy is nil when the change was
+	// made automatically.
+	Review(ctx context.Context, id uuid.UUID, status SpamCallerStatus, reviewedBy *uuid.UUID, at time.Time) error
+
+	// CountCallsFrom counts calls from a number created at or after since.
+	CountCallsFrom(ctx context.Context, phoneNumber string, since time.Time) (int, error)
+}
+
+// PrivacyRepository finds and erases the records held about a data subject
+// across tables, for data subject access and deletion requests.
+type PrivacyRepository interface {
+	// GetCustomerByPhoneNumber retrieves the customer with the given E.164
+	// phone number.
+	GetCustomerByPhoneNumber(ctx context.Context, phoneNumber string) (*Customer, error)
+
+	// ListCalls retrieves calls linked to the customer or made from or to any
+	// of the phone numbers, including soft-deleted calls, oldest first.
+	ListCalls(ctx context.Context, customerID *uuid.UUID, phoneNumbers []string) ([]*Call, error)
+
+	// ListCallbacks retrieves callbacks requested on the subject's calls or
+	// from their phone numbers.
+	ListCallbacks(ctx context.Context, subject *PrivacySubject) ([]*Callback, error)
+
+	// ListCampaignContacts retrieves campaign contacts for the subject's calls
+	// or phone numbers.
+	ListCampaignContacts(ctx context.Context, subject *PrivacySubject) ([]*CampaignContact, error)
+
+	// Erase deletes everything held about the subject in one transaction and
+	// returns the number of rows deleted per table. Do-not-call entries are
+	// kept so the subject is not called again.
+	Erase(ctx context.Context, subject *PrivacySubject) (map[string]int, error)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionEntity names a kind of record the retention rules remove once it
+// is old enough.
+type RetentionEntity string
+
+const (
+	RetentionTranscripts RetentionEntity = "transcripts" // Call transcripts, by call creation time
+	RetentionRecordings  RetentionEntity = "recordings"  // Stored recordings, by storage time
+	RetentionAuditEvents RetentionEntity = "audit_events"
+)
+
+// RetentionEntities lists the entities retention rules can be set for.
+var RetentionEntities = []RetentionEntity{
+	RetentionTranscripts,
+	RetentionRecordings,
+	RetentionAuditEvents,
+}
+
+// LegalHoldTarget names what a legal hold is placed on.
+type LegalHoldTarget string
+
+const (
+	LegalHoldCall     LegalHoldTarget = "call"
+	LegalHoldCustomer LegalHoldTarget = "customer" // Holds every call of the customer
+)
+
+// LegalHold exempts a call, or all of a customer's calls, from the retention
+// rules until it is released.
+type LegalHold struct {
+	Target   LegalHoldTarget `json:"target"`
+	ID       uuid.UUID       `json:"id"`
+	Reason   string          `json:"reason"`
+	PlacedAt time.Time       `json:"placed_at"`
+}
+
+// Limits on a legal hold.
+const MaxLegalHoldReasonLen = 500
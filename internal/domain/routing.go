@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// areaCodePattern matches a three-digit North American area code.
+var areaCodePattern = regexp.MustCompile(`^[2-9][0-9]{2}$`)
+
+// RoutingRule routes inbound callers from some area codes or states, either
+// to a regional prompt whose instructions the agent follows for the call or
+// to a transfer number such as regional staff. Rules are checked in priority
+// order and the first active one that matches the caller applies.
+type RoutingRule struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Priority int       `json:"priority"` // Lower numbers are checked first
+
+	// The caller matches when their area code is listed, or their area
+	// code belongs to a listed state, territory or province.
+	AreaCodes []string `json:"area_codes,omitempty"`
+	Regions   []string `json:"regions,omitempty"` // Postal abbreviations such as "TX" or "ON"
+
+	PromptID       *uuid.UUID `json:"prompt_id,omitempty"`       // Prompt whose instructions are given to the agent
+	TransferNumber string     `json:"transfer_number,omitempty"` // E.164 number the call is transferred to
+
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewRoutingRule creates an active routing rule.
+func NewRoutingRule(name string) *RoutingRule {
+	now := time.Now().UTC()
+	return &RoutingRule{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(name),
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the rule and puts its area codes and regions in
+// canonical form: trimmed, uppercase for regions, sorted and without
+// duplicates.
+func (r *RoutingRule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return NewValidationError("name", "name is required")
+	}
+
+	r.AreaCodes = uniqueSorted(r.AreaCodes, strings.TrimSpace)
+	for _, code := range r.AreaCodes {
+		if !areaCodePattern.MatchString(code) {
+			return NewValidationError("area_codes", fmt.Sprintf("%q is not a three-digit area code", code))
+		}
+	}
+	r.Regions = uniqueSorted(r.Regions, func(s string) string {
+		return strings.ToUpper(strings.TrimSpace(s))
+	})
+	for _, region := range r.Regions {
+		if !IsKnownRegion(region) {
+			return NewValidationError("regions", fmt.Sprintf("%q is not a US state or territory or Canadian province abbreviation", region))
+		}
+	}
+	if len(r.AreaCodes) == 0 && len(r.Regions) == 0 {
+		return NewValidationError("area_codes", "a rule needs at least one area code or state")
+	}
+
+	r.TransferNumber = strings.TrimSpace(r.TransferNumber)
+	if r.TransferNumber != "" && !scheduleNumberPattern.MatchString(r.TransferNumber) {
+		return NewValidationError("transfer_number", fmt.Sprintf("%q is not an E.164 phone number", r.TransferNumber))
+	}
+	if (r.PromptID == nil) == (r.TransferNumber == "") {
+		return NewValidationError("prompt_id", "a rule needs either a prompt or a transfer number")
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to calls from phoneNumber.
+func (r *RoutingRule) Matches(phoneNumber string) bool {
+	areaCode, ok := AreaCodeForNumber(phoneNumber)
+	if !ok {
+		return false
+	}
+	for _, code := range r.AreaCodes {
+		if code == areaCode {
+			return true
+		}
+	}
+	if region := RegionForNumber(phoneNumber); region != "" {
+		for _, listed := range r.Regions {
+			if listed == region {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// uniqueSorted normalizes values, dropping blanks and duplicates, and sorts
+// them.
+func uniqueSorted(values []string, normalize func(string) string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = normalize(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRegionForNumber(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"+12125551234", "NY"},
+		{"(512) 555-1234", "TX"},
+		{"+14165551234", "ON"},
+		{"+19995551234", ""},
+		{"+442071234567", ""},
+	}
+	for _, tt := range tests {
+		if got := RegionForNumber(tt.phone); got != tt.want {
+			t.Errorf("RegionForNumber(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
+
+func TestRoutingRule_Validate(t *testing.T) {
+	promptID := uuid.New()
+
+	rule := NewRoutingRule(" Texas ")
+	rule.AreaCodes = []string{" 212", "212", ""}
+	rule.Regions = []string{"tx", "ok "}
+	rule.PromptID = &promptID
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if rule.Name != "Texas" || len(rule.AreaCodes) != 1 || rule.Regions[0] != "OK" || rule.Regions[1] != "TX" {
+		t.Errorf("expected the rule in canonical form, got %+v", rule)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *RoutingRule)
+	}{
+		{"no callers", func(r *RoutingRule) { r.AreaCodes, r.Regions = nil, nil }},
+		{"bad area code", func(r *RoutingRule) { r.AreaCodes = []string{"12"} }},
+		{"unknown state", func(r *RoutingRule) { r.Regions = []string{"ZZ"} }},
+		{"no action", func(r *RoutingRule) { r.PromptID = nil }},
+		{"both actions", func(r *RoutingRule) { r.TransferNumber = "+15551234567" }},
+		{"bad transfer number", func(r *RoutingRule) { r.PromptID, r.TransferNumber = nil, "555-1234" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoutingRule("Texas")
+			r.Regions = []string{"TX"}
+			r.PromptID = &promptID
+			tt.modify(r)
+			if err := r.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestRoutingRule_Matches(t *testing.T) {
+	rule := &RoutingRule{AreaCodes: []string{"646"}, Regions: []string{"TX"}}
+	for phone, want := range map[string]bool{
+		"+16465551234":  true,  // listed area code
+		"+12145551234":  true,  // Dallas, in a listed state
+		"+12125551234":  false, // New York, not listed
+		"+442071234567": false,
+	} {
+		if got := rule.Matches(phone); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", phone, got, want)
+		}
+	}
+}
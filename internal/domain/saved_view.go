@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Limits on saved views.
+const (
+	MaxSavedViewNameLen   = 100
+	MaxSavedViewFilterLen = 200
+)
+
+// SavedViewFilterKeys are the calls list filters a saved view can hold,
+// named by their query parameters.
+var SavedViewFilterKeys = []string{"status", "q", "intent", "sentiment", "spam", "tag", "quote_tag"}
+
+// CallListColumns are the columns of the calls list, in the order shown.
+var CallListColumns = []string{"caller", "phone", "status", "intent", "duration", "cost", "quote", "date"}
+
+// SavedView is a user's saved configuration of the calls list: its
+// filters, sort order and the columns shown. Each user may mark one view as
+// their default, which the calls list opens with.
+type SavedView struct {
+	ID        uuid.UUID         `json:"id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Name      string            `json:"name"`
+	Filters   map[string]string `json:"filters"`           // Keyed by SavedViewFilterKeys
+	Sort      CallSort          `json:"sort"`              // Newest first when empty
+	Columns   []string          `json:"columns,omitempty"` // All columns when empty
+	IsDefault bool              `json:"is_default"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// NewSavedView creates an empty view of the calls list for a user.
+func NewSavedView(userID uuid.UUID, name string) *SavedView {
+	now := time.Now().UTC()
+	return &SavedView{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Filters:   map[string]string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the view and puts it in canonical form: filters without
+// a value are dropped, tag filters are normalized and columns are put in
+// the list's order.
+func (v *SavedView) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" || len(v.Name) > MaxSavedViewNameLen {
+		return NewValidationError("name", fmt.Sprintf("view name is required, at most %d characters", MaxSavedViewNameLen))
+	}
+
+	filters := make(map[string]string, len(v.Filters))
+	for key, value := range v.Filters {
+		if !containsString(SavedViewFilterKeys, key) {
+			return NewValidationError("filters", fmt.Sprintf("unknown filter %q; use %s", key, strings.Join(SavedViewFilterKeys, ", ")))
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if len(value) > MaxSavedViewFilterLen {
+			return NewValidationError("filters", fmt.Sprintf("filter %q must be at most %d characters", key, MaxSavedViewFilterLen))
+		}
+		normalized, err := normalizeSavedViewFilter(key, value)
+		if err != nil {
+			return NewValidationError("filters", err.Error())
+		}
+		filters[key] = normalized
+	}
+	v.Filters = filters
+
+	sort, err := ParseCallSort(string(v.Sort))
+	if err != nil {
+		return NewValidationError("sort", err.Error())
+	}
+	v.Sort = sort
+
+	var columns []string
+	for _, column := range v.Columns {
+		if !containsString(CallListColumns, column) {
+			return NewValidationError("columns", fmt.Sprintf("unknown column %q; use %s", column, strings.Join(CallListColumns, ", ")))
+		}
+	}
+	for _, column := range CallListColumns {
+		if containsString(v.Columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	v.Columns = columns
+	return nil
+}
+
+// normalizeSavedViewFilter checks a filter's value as the calls list reads
+// it.
+func normalizeSavedViewFilter(key, value string) (string, error) {
+	switch key {
+	case "status":
+		switch CallStatus(value) {
+		case CallStatusPending, CallStatusInProgress, CallStatusCompleted, CallStatusFailed, CallStatusNoAnswer:
+			return value, nil
+		}
+		return "", fmt.Errorf("invalid status %q", value)
+	case "intent":
+		intent, err := ParseCallIntent(value)
+		return string(intent), err
+	case "sentiment":
+		sentiment, err := ParseCallSentiment(value)
+		return string(sentiment), err
+	case "spam":
+		spam, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid spam %q: use true or false", value)
+		}
+		return strconv.FormatBool(spam), nil
+	case "tag", "quote_tag":
+		names, err := ParseTagNames([]string{value})
+		return strings.Join(names, ","), err
+	}
+	return value, nil
+}
+
+// Query returns the calls list query parameters that open the view.
+func (v *SavedView) Query() url.Values {
+	query := url.Values{}
+	for key, value := range v.Filters {
+		query.Set(key, value)
+	}
+	if v.Sort == CallSortOldest {
+		query.Set("sort", string(v.Sort))
+	}
+	if len(v.Columns) > 0 {
+		query.Set("columns", strings.Join(v.Columns, ","))
+	}
+	query.Set("view", v.ID.String())
+	return query
+}
+
+// Path returns the calls list opened with the view.
+func (v *SavedView) Path() string {
+	return "/calls?" + v.Query().Encode()
+}
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSavedView_Validate(t *testing.T) {
+	view := NewSavedView(uuid.New(), "  Open leads ")
+	view.Filters = map[string]string{
+		"status":    "completed",
+		"intent":    " new_project ",
+		"spam":      "0",
+		"tag":       "Hot Lead, vip",
+		"sentiment": "",
+	}
+	view.Columns = []string{"date", "caller", "quote"}
+
+	if err := view.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if view.Name != "Open leads" {
+		t.Errorf("Name = %q, want trimmed", view.Name)
+	}
+	if view.Sort != CallSortNewest {
+		t.Errorf("Sort = %q, want newest", view.Sort)
+	}
+	want := map[string]string{"status": "completed", "intent": "new_project", "spam": "false", "tag": "hot-lead,vip"}
+	if len(view.Filters) != len(want) {
+		t.Errorf("Filters = %v, want %v", view.Filters, want)
+	}
+	for key, value := range want {
+		if view.Filters[key] != value {
+			t.Errorf("Filters[%q] = %q, want %q", key, view.Filters[key], value)
+		}
+	}
+	if got := strings.Join(view.Columns, ","); got != "caller,quote,date" {
+		t.Errorf("Columns = %s, want the list's order", got)
+	}
+}
+
+func TestSavedView_ValidateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*SavedView)
+	}{
+		{"empty name", func(v *SavedView) { v.Name = " " }},
+		{"long name", func(v *SavedView) { v.Name = strings.Repeat("a", MaxSavedViewNameLen+1) }},
+		{"unknown filter", func(v *SavedView) { v.Filters = map[string]string{"page": "2"} }},
+		{"invalid status", func(v *SavedView) { v.Filters = map[string]string{"status": "done"} }},
+		{"invalid spam", func(v *SavedView) { v.Filters = map[string]string{"spam": "maybe"} }},
+		{"invalid tag", func(v *SavedView) { v.Filters = map[string]string{"tag": "hot!"} }},
+		{"long filter", func(v *SavedView) { v.Filters = map[string]string{"q": strings.Repeat("a", MaxSavedViewFilterLen+1)} }},
+		{"invalid sort", func(v *SavedView) { v.Sort = "cost" }},
+		{"unknown column", func(v *SavedView) { v.Columns = []string{"caller", "notes"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view := NewSavedView(uuid.New(), "View")
+			tt.modify(view)
+			if err := view.Validate(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSavedView_Path(t *testing.T) {
+	view := NewSavedView(uuid.New(), "Oldest spam")
+	view.Filters = map[string]string{"spam": "true"}
+	view.Sort = CallSortOldest
+	view.Columns = []string{"caller", "date"}
+
+	want := "/calls?columns=caller%2Cdate&sort=oldest&spam=true&view=" + view.ID.String()
+	if got := view.Path(); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+
+	// Newest first and all columns are the list's defaults
+	view = NewSavedView(uuid.New(), "Everything")
+	view.Sort = CallSortNewest
+	if got := view.Path(); got != "/calls?view="+view.ID.String() {
+		t.Errorf("Path() = %q, want only the view", got)
+	}
+}
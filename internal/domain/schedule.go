@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AfterHoursMode says how inbound numbers answer outside business hours.
+type AfterHoursMode string
+
+const (
+	// AfterHoursVoicemail answers with an agent that only takes a message.
+	AfterHoursVoicemail AfterHoursMode = "voicemail"
+	// AfterHoursPrompt answers with another prompt, or the usual agent with
+	// another first sentence.
+	AfterHoursPrompt AfterHoursMode = "prompt"
+)
+
+// Holiday date layouts: a single date, or a date that recurs every year.
+const (
+	holidayDateLayout   = "2006-01-02"
+	holidayAnnualLayout = "01-02"
+)
+
+// scheduleNumberPattern matches E.164 phone numbers.
+var scheduleNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// BusinessSchedule is when the business answers its inbound numbers and
+// how they answer the rest of the time. It is stored as JSON in the
+// business_schedule setting.
+type BusinessSchedule struct {
+	Enabled  bool   `json:"enabled"`
+	Timezone string `json:"timezone,omitempty"` // IANA time zone the hours are in; empty for UTC
+
+	// Hours maps a lowercase weekday name to that day's opening hours;
+	// days without hours are closed.
+	Hours    map[string]OpeningHours `json:"hours,omitempty"`
+	Holidays []Holiday               `json:"holidays,omitempty"`
+
+	// PhoneNumbers are the inbound numbers the schedule switches.
+	PhoneNumbers []string `json:"phone_numbers,omitempty"`
+
+	// PromptID answers the numbers in business hours; nil for the agent
+	// built from the call settings.
+	PromptID   *uuid.UUID `json:"prompt_id,omitempty"`
+	AfterHours AfterHours `json:"after_hours"`
+}
+
+// OpeningHours are the hours of one day, as "HH:MM" in the schedule's time
+// zone. Close is exclusive.
+type OpeningHours struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Holiday is a day the business is closed all day. Date is "YYYY-MM-DD",
+// or "MM-DD" for a holiday on the same date every year.
+type Holiday struct {
+	Date string `json:"date"`
+	Name string `json:"name,omitempty"`
+}
+
+// AfterHours is how inbound numbers answer outside business hours.
+type AfterHours struct {
+	Mode AfterHoursMode `json:"mode"`
+
+	// PromptID answers in prompt mode; nil for the business hours agent.
+	PromptID *uuid.UUID `json:"prompt_id,omitempty"`
+
+	// FirstSentence replaces the agent's greeting; in voicemail mode it
+	// defaults to a message saying the business is closed.
+	FirstSentence string `json:"first_sentence,omitempty"`
+}
+
+// ParseBusinessSchedule reads a schedule stored as JSON. An empty value is a
+// disabled schedule.
+func ParseBusinessSchedule(value string) (*BusinessSchedule, error) {
+	s := &BusinessSchedule{}
+	if strings.TrimSpace(value) == "" {
+		return s, nil
+	}
+	if err := json.Unmarshal([]byte(value), s); err != nil {
+		return nil, fmt.Errorf("invalid business schedule: %w", err)
+	}
+	return s, nil
+}
+
+// Validate checks the schedule and puts it in canonical form: days are
+// keyed by full name, days without hours and blank holidays and numbers are
+// dropped, and the after hours mode defaults to voicemail.
+func (s *BusinessSchedule) Validate() error {
+	s.Timezone = strings.TrimSpace(s.Timezone)
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return NewValidationError("timezone", fmt.Sprintf("%q is not a known time zone", s.Timezone))
+	}
+
+	hours := make(map[string]OpeningHours, len(s.Hours))
+	for day, h := range s.Hours {
+		day = strings.ToLower(strings.TrimSpace(day))
+		h.Open, h.Close = strings.TrimSpace(h.Open), strings.TrimSpace(h.Close)
+		if h.Open == "" && h.Close == "" {
+			continue
+		}
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return NewValidationError("hours", fmt.Sprintf("%q is not a day of the week", day))
+		}
+		day = strings.ToLower(weekday.String())
+		open, err := parseClockMinute(h.Open)
+		if err != nil {
+			return NewValidationError("hours", day+" opening time: "+err.Error())
+		}
+		closing, err := parseClockMinute(h.Close)
+		if err != nil {
+			return NewValidationError("hours", day+" closing time: "+err.Error())
+		}
+		if closing <= open {
+			return NewValidationError("hours", day+" must close after it opens")
+		}
+		hours[day] = h
+	}
+	s.Hours = hours
+
+	holidays := make([]Holiday, 0, len(s.Holidays))
+	for _, h := range s.Holidays {
+		h.Date, h.Name = strings.TrimSpace(h.Date), strings.TrimSpace(h.Name)
+		if h.Date == "" {
+			continue
+		}
+		if _, err := time.Parse(holidayDateLayout, h.Date); err != nil {
+			if _, err := time.Parse(holidayAnnualLayout, h.Date); err != nil {
+				return NewValidationError("holidays", fmt.Sprintf("%q is not a YYYY-MM-DD or MM-DD date", h.Date))
+			}
+		}
+		holidays = append(holidays, h)
+	}
+	s.Holidays = holidays
+
+	numbers := make([]string, 0, len(s.PhoneNumbers))
+	seen := make(map[string]bool, len(s.PhoneNumbers))
+	for _, number := range s.PhoneNumbers {
+		number = strings.TrimSpace(number)
+		if number == "" || seen[number] {
+			continue
+		}
+		if !scheduleNumberPattern.MatchString(number) {
+			return NewValidationError("phone_numbers", fmt.Sprintf("%q is not an E.164 phone number", number))
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	s.PhoneNumbers = numbers
+	if s.Enabled && len(s.PhoneNumbers) == 0 {
+		return NewValidationError("phone_numbers", "an enabled schedule needs at least one phone number")
+	}
+
+	s.AfterHours.FirstSentence = strings.TrimSpace(s.AfterHours.FirstSentence)
+	switch s.AfterHours.Mode {
+	case "":
+		s.AfterHours.Mode = AfterHoursVoicemail
+	case AfterHoursVoicemail:
+	case AfterHoursPrompt:
+		if s.AfterHours.PromptID == nil && s.AfterHours.FirstSentence == "" {
+			return NewValidationError("after_hours", "after hours prompt mode needs a prompt or a first sentence")
+		}
+	default:
+		return NewValidationError("after_hours", fmt.Sprintf("unknown after hours mode %q; use voicemail or prompt", s.AfterHours.Mode))
+	}
+	if s.AfterHours.Mode == AfterHoursVoicemail {
+		s.AfterHours.PromptID = nil
+	}
+	return nil
+}
+
+// Location returns the schedule's time zone, or UTC if it has none or it is
+// not known.
+func (s *BusinessSchedule) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// HolidayOn returns the holiday on t's date in the schedule's time zone, or
+// nil if there is none.
+func (s *BusinessSchedule) HolidayOn(t time.Time) *Holiday {
+	local := t.In(s.Location())
+	date, annual := local.Format(holidayDateLayout), local.Format(holidayAnnualLayout)
+	for i := range s.Holidays {
+		if s.Holidays[i].Date == date || s.Holidays[i].Date == annual {
+			return &s.Holidays[i]
+		}
+	}
+	return nil
+}
+
+// IsOpen reports whether t falls in business hours. A disabled schedule is
+// always open.
+func (s *BusinessSchedule) IsOpen(t time.Time) bool {
+	if !s.Enabled {
+		return true
+	}
+	if s.HolidayOn(t) != nil {
+		return false
+	}
+	local := t.In(s.Location())
+	h, ok := s.Hours[strings.ToLower(local.Weekday().String())]
+	if !ok {
+		return false
+	}
+	open, err := parseClockMinute(h.Open)
+	if err != nil {
+		return false
+	}
+	closing, err := parseClockMinute(h.Close)
+	if err != nil {
+		return false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= open && minute < closing
+}
@@ -58,6 +58,7 @@ const (
 	SettingKeyRecordCalls         = "record_calls"
 	SettingKeyQualityPreset       = "quality_preset"
 	SettingKeyCustomGreeting      = "custom_greeting"
+	SettingKeyCurrency            = "currency"
 
 	// Pricing keys (fallback values when API unavailable)
 	SettingKeyPricingInboundPerMin      = "pricing_inbound_per_minute"
@@ -66,6 +67,12 @@ const (
 	SettingKeyPricingAnalysisPerCall    = "pricing_analysis_per_call"
 	SettingKeyPricingPhoneNumberPerMonth = "pricing_phone_number_per_month"
 	SettingKeyPricingEnhancedModelPremium = "pricing_enhanced_model_premium"
+
+	// CRM keys
+	SettingKeyCRMFieldMapping = "crm_field_mapping"
+
+	// Schedule keys
+	SettingKeyBusinessSchedule = "business_schedule"
 )
 
 // SettingsRepository defines the interface for settings persistence.
@@ -99,6 +106,7 @@ type CallSettings struct {
 	RecordCalls           bool
 	QualityPreset         string
 	CustomGreeting        string
+	Currency              string // ISO 4217 code new quotes are priced in; empty for the configured default
 }
 
 // NewCallSettingsFromMap creates CallSettings from a map of setting key -> value.
@@ -190,6 +198,9 @@ func NewCallSettingsFromMap(settings map[string]string) *CallSettings {
 	if v, ok := settings[SettingKeyCustomGreeting]; ok {
 		cs.CustomGreeting = v
 	}
+	if v, ok := settings[SettingKeyCurrency]; ok {
+		cs.Currency = NormalizeCurrency(v)
+	}
 
 	return cs
 }
@@ -215,6 +226,7 @@ func (cs *CallSettings) ToMap() map[string]string {
 		SettingKeyRecordCalls:        strconv.FormatBool(cs.RecordCalls),
 		SettingKeyQualityPreset:      cs.QualityPreset,
 		SettingKeyCustomGreeting:     cs.CustomGreeting,
+		SettingKeyCurrency:           cs.Currency,
 	}
 }
 
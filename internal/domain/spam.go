@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpamFlagScore is the spam score from which an inbound call is flagged as
+// spam. Scores run from 0 to 100, higher being more likely spam.
+const SpamFlagScore = 50
+
+// SpamCallerStatus is where a flagged caller is in the review queue.
+type SpamCallerStatus string
+
+const (
+	SpamCallerPending   SpamCallerStatus = "pending"   // Waiting for review
+	SpamCallerBlocked   SpamCallerStatus = "blocked"   // Confirmed spam and blocked
+	SpamCallerDismissed SpamCallerStatus = "dismissed" // Reviewed as not spam; not flagged again
+)
+
+// SpamCallerStatuses lists the review queue statuses in the order they are
+// shown.
+var SpamCallerStatuses = []SpamCallerStatus{SpamCallerPending, SpamCallerBlocked, SpamCallerDismissed}
+
+// IsValid returns true if the status is known.
+func (s SpamCallerStatus) IsValid() bool {
+	switch s {
+	case SpamCallerPending, SpamCallerBlocked, SpamCallerDismissed:
+		return true
+	}
+	return false
+}
+
+// SpamCaller is a number whose inbound calls were flagged as spam, queued
+// for review.
+type SpamCaller struct {
+	ID             uuid.UUID        `json:"id"`
+	PhoneNumber    string           `json:"phone_number"`
+	Score          int              `json:"score"`   // Score of the latest flagged call
+	Reasons        []string         `json:"reasons"` // Why the latest flagged call was scored as spam
+	FlaggedCalls   int              `json:"flagged_calls"`
+	Status         SpamCallerStatus `json:"status"`
+	AutoBlocked    bool             `json:"auto_blocked"` // Blocked for repeat offenses rather than by a reviewer
+	FirstFlaggedAt time.Time        `json:"first_flagged_at"`
+	LastFlaggedAt  time.Time        `json:"last_flagged_at"`
+	ReviewedAt     *time.Time       `json:"reviewed_at,omitempty"`
+	ReviewedBy     *uuid.UUID       `json:"reviewed_by,omitempty"`
+}
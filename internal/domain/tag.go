@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TagEntityType is the kind of record a tag is applied to.
+type TagEntityType string
+
+const (
+	TagEntityCall     TagEntityType = "call"
+	TagEntityQuote    TagEntityType = "quote" // Keyed by the call ID, like the quote itself
+	TagEntityCustomer TagEntityType = "customer"
+)
+
+// ParseTagEntityType parses a tag entity type.
+func ParseTagEntityType(s string) (TagEntityType, error) {
+	switch t := TagEntityType(strings.ToLower(strings.TrimSpace(s))); t {
+	case TagEntityCall, TagEntityQuote, TagEntityCustomer:
+		return t, nil
+	}
+	return "", fmt.Errorf("invalid tag entity type %q: use call, quote or customer", s)
+}
+
+// tagNamePattern matches a normalized tag name: lowercase words joined by
+// dashes, such as "hot-lead".
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// tagColorPattern matches a hex color such as "#e11d48".
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Limits on tags and saved views.
+const (
+	MaxTagNameLen        = 50
+	MaxTagDescriptionLen = 200
+	MaxTagViewNameLen    = 100
+	MaxTagViewTags       = 10
+	MaxTagFilterTags     = 10
+)
+
+// Tag labels calls, quotes and customers, such as "hot-lead" or
+// "follow-up-needed". Managed tags are curated by admins, with a
+// description and color; free-form tags are created the first time someone
+// applies a name that isn't a tag yet.
+type Tag struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Color       string     `json:"color,omitempty"`
+	Managed     bool       `json:"managed"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// UsageCount is how many records carry the tag, set when listing tags.
+	UsageCount int `json:"usage_count"`
+}
+
+// NewTag creates a tag with a normalized name.
+func NewTag(name string, managed bool, createdBy *uuid.UUID) (*Tag, error) {
+	normalized, err := NormalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Tag{
+		ID:        uuid.New(),
+		Name:      normalized,
+		Managed:   managed,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Validate checks the tag's description and color.
+func (t *Tag) Validate() error {
+	t.Description = strings.TrimSpace(t.Description)
+	if len(t.Description) > MaxTagDescriptionLen {
+		return NewValidationError("description", fmt.Sprintf("description must be at most %d characters", MaxTagDescriptionLen))
+	}
+	t.Color = strings.ToLower(strings.TrimSpace(t.Color))
+	if t.Color != "" && !tagColorPattern.MatchString(t.Color) {
+		return NewValidationError("color", "color must be a hex color such as #e11d48")
+	}
+	return nil
+}
+
+// NormalizeTagName lowercases a tag name and joins its words with dashes,
+// so "Hot Lead" and "hot_lead" are the same tag, "hot-lead".
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '\t'
+	}), "-")
+	if name == "" {
+		return "", NewValidationError("name", "tag name is required")
+	}
+	if len(name) > MaxTagNameLen || !tagNamePattern.MatchString(name) {
+		return "", NewValidationError("name", fmt.Sprintf("tag name must be letters, digits and dashes, at most %d characters", MaxTagNameLen))
+	}
+	return name, nil
+}
+
+// ParseTagNames normalizes tag names given as repeated or comma-separated
+// values, dropping duplicates. Records must carry all of them to match.
+func ParseTagNames(values []string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			name, err := NormalizeTagName(part)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) > MaxTagFilterTags {
+		return nil, NewValidationError("tag", fmt.Sprintf("filter by at most %d tags", MaxTagFilterTags))
+	}
+	return names, nil
+}
+
+// TagView is a saved list of the calls, quotes or customers carrying all of
+// its tags, shown on the dashboard. A view without an owner is shared with
+// everyone.
+type TagView struct {
+	ID         uuid.UUID     `json:"id"`
+	Name       string        `json:"name"`
+	EntityType TagEntityType `json:"entity_type"`
+	Tags       []string      `json:"tags"`
+	UserID     *uuid.UUID    `json:"user_id,omitempty"` // Owner; nil for shared views
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// NewTagView creates a saved view. userID is nil for a shared view.
+func NewTagView(name string, entityType TagEntityType, tags []string, userID *uuid.UUID) (*TagView, error) {
+	view := &TagView{
+		ID:         uuid.New(),
+		Name:       strings.TrimSpace(name),
+		EntityType: entityType,
+		UserID:     userID,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if view.Name == "" || len(view.Name) > MaxTagViewNameLen {
+		return nil, NewValidationError("name", fmt.Sprintf("view name is required, at most %d characters", MaxTagViewNameLen))
+	}
+	if _, err := ParseTagEntityType(string(entityType)); err != nil {
+		return nil, NewValidationError("entity_type", err.Error())
+	}
+
+	normalized, err := ParseTagNames(tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 || len(normalized) > MaxTagViewTags {
+		return nil, NewValidationError("tags", fmt.Sprintf("a view needs between 1 and %d tags", MaxTagViewTags))
+	}
+	view.Tags = normalized
+	return view, nil
+}
+
+// IsShared reports whether everyone sees the view.
+func (v *TagView) IsShared() bool {
+	return v.UserID == nil
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeTagName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"hot-lead", "hot-lead", false},
+		{" Hot Lead ", "hot-lead", false},
+		{"follow_up__needed", "follow-up-needed", false},
+		{"-vip-", "vip", false},
+		{"Q3", "q3", false},
+		{"", "", true},
+		{" - ", "", true},
+		{"hot!", "", true},
+		{"café", "", true},
+		{strings.Repeat("a", MaxTagNameLen+1), "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeTagName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeTagName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeTagName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseTagNames(t *testing.T) {
+	names, err := ParseTagNames([]string{"Hot Lead,follow-up-needed", "hot-lead", " , "})
+	if err != nil {
+		t.Fatalf("ParseTagNames() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "hot-lead" || names[1] != "follow-up-needed" {
+		t.Errorf("ParseTagNames() = %v, want [hot-lead follow-up-needed]", names)
+	}
+
+	if names, err := ParseTagNames(nil); err != nil || len(names) != 0 {
+		t.Errorf("ParseTagNames(nil) = %v, %v, want no tags", names, err)
+	}
+	if _, err := ParseTagNames([]string{"ok,bad!"}); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+	tooMany := make([]string, MaxTagFilterTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag-" + strings.Repeat("x", i+1)
+	}
+	if _, err := ParseTagNames(tooMany); err == nil {
+		t.Error("expected an error for too many tags")
+	}
+}
+
+func TestTag_Validate(t *testing.T) {
+	tag, err := NewTag("VIP", true, nil)
+	if err != nil {
+		t.Fatalf("NewTag() error = %v", err)
+	}
+	tag.Color = " #E11D48 "
+	if err := tag.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if tag.Name != "vip" || tag.Color != "#e11d48" {
+		t.Errorf("expected the tag in canonical form, got %+v", tag)
+	}
+
+	tag.Color = "red"
+	if err := tag.Validate(); err == nil {
+		t.Error("expected an error for a color that isn't hex")
+	}
+	tag.Color = ""
+	tag.Description = strings.Repeat("a", MaxTagDescriptionLen+1)
+	if err := tag.Validate(); err == nil {
+		t.Error("expected an error for a long description")
+	}
+}
+
+func TestNewTagView(t *testing.T) {
+	owner := uuid.New()
+	view, err := NewTagView(" Hot leads ", TagEntityCall, []string{"Hot Lead", "hot-lead"}, &owner)
+	if err != nil {
+		t.Fatalf("NewTagView() error = %v", err)
+	}
+	if view.Name != "Hot leads" || len(view.Tags) != 1 || view.Tags[0] != "hot-lead" {
+		t.Errorf("expected the view in canonical form, got %+v", view)
+	}
+	if view.IsShared() {
+		t.Error("a view with an owner is shared")
+	}
+
+	tests := []struct {
+		name       string
+		viewName   string
+		entityType TagEntityType
+		tags       []string
+	}{
+		{"no name", " ", TagEntityCall, []string{"vip"}},
+		{"bad entity type", "VIP", TagEntityType("invoice"), []string{"vip"}},
+		{"no tags", "VIP", TagEntityQuote, nil},
+		{"invalid tag", "VIP", TagEntityCustomer, []string{"vip!"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTagView(tt.viewName, tt.entityType, tt.tags, nil); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
@@ -7,14 +7,44 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// MinPasswordLength is the shortest password accepted when one is chosen.
+const MinPasswordLength = 8
+
+// UserRole controls what a user may do. Admins manage users and settings;
+// members work with calls, customers, quotes and campaigns.
+type UserRole string
+
+// User roles.
+const (
+	UserRoleAdmin  UserRole = "admin"
+	UserRoleMember UserRole = "member"
+)
+
+// IsValid returns true if the role is known.
+func (r UserRole) IsValid() bool {
+	return r == UserRoleAdmin || r == UserRoleMember
+}
+
 // User represents a dashboard user.
 type User struct {
 	ID           uuid.UUID  `json:"id"`
 	Email        string     `json:"email"`
 	PasswordHash string     `json:"-"` // Never serialize password hash
+	Role         UserRole   `json:"role"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty"` // Disabled users can't log in or use API keys
+	// EmailVerifiedAt is nil until the user follows the link emailed to them.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// Two-factor authentication
+	TOTPSecret      *string    `json:"-"`                         // Set during enrollment, in use once enabled
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at,omitempty"` // When two-factor authentication was turned on
+	TOTPLastCounter int64      `json:"-"`                         // Time step of the last accepted code
+	// Single sign-on: the identity provider and its subject for the user,
+	// empty until the user first signs in with it
+	SSOIssuer  string `json:"-"`
+	SSOSubject string `json:"-"`
 }
 
 // IsDeleted returns true if the user has been soft-deleted.
@@ -29,6 +59,98 @@ func (u *User) MarkDeleted() {
 	u.UpdatedAt = now
 }
 
+// IsAdmin returns true if the user has the admin role.
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
+// IsDisabled returns true if the user has been disabled.
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// Disable blocks the user from logging in.
+func (u *User) Disable() {
+	if u.DisabledAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	u.DisabledAt = &now
+	u.UpdatedAt = now
+}
+
+// Enable lets a disabled user log in again.
+func (u *User) Enable() {
+	u.DisabledAt = nil
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// EmailVerified returns true if the user has confirmed their email address.
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// MarkEmailVerified records that the user receives mail at their address.
+func (u *User) MarkEmailVerified() {
+	if u.EmailVerifiedAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// SSOLinked returns true if the user has signed in with single sign-on.
+func (u *User) SSOLinked() bool {
+	return u.SSOSubject != ""
+}
+
+// LinkSSO records the identity provider subject the user signs in as.
+func (u *User) LinkSSO(issuer, subject string) {
+	u.SSOIssuer = issuer
+	u.SSOSubject = subject
+	u.UpdatedAt = time.Now().UTC()
+}
+
+// SetPassword replaces the user's password hash.
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = string(hash)
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// TwoFactorEnabled returns true if the user must enter a TOTP code to log in.
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != nil
+}
+
+// TwoFactorPending returns true if the user has started two-factor
+// enrollment but not yet confirmed a code.
+func (u *User) TwoFactorPending() bool {
+	return u.TOTPEnabledAt == nil && u.TOTPSecret != nil
+}
+
+// EnableTwoFactor turns on two-factor authentication with the enrolled
+// secret, recording the time step of the code that confirmed it.
+func (u *User) EnableTwoFactor(counter int64) {
+	now := time.Now().UTC()
+	u.TOTPEnabledAt = &now
+	u.TOTPLastCounter = counter
+	u.UpdatedAt = now
+}
+
+// DisableTwoFactor turns off two-factor authentication and forgets the secret.
+func (u *User) DisableTwoFactor() {
+	u.TOTPSecret = nil
+	u.TOTPEnabledAt = nil
+	u.TOTPLastCounter = 0
+	u.UpdatedAt = time.Now().UTC()
+}
+
 // NewUser creates a new user with a hashed password.
 func NewUser(email, password string) (*User, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -41,6 +163,7 @@ func NewUser(email, password string) (*User, error) {
 		ID:           uuid.New(),
 		Email:        email,
 		PasswordHash: string(hash),
+		Role:         UserRoleMember,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
@@ -65,6 +188,9 @@ type Session struct {
 	// Token rotation tracking
 	PreviousToken *string    `json:"-"` // Previous token (for grace period)
 	RotatedAt     *time.Time `json:"-"` // When the token was last rotated
+	// TwoFactorVerified is set once the user has entered a TOTP or recovery
+	// code; sessions of two-factor users are unusable until then.
+	TwoFactorVerified bool `json:"two_factor_verified"`
 }
 
 // NewSession creates a new session for a user.
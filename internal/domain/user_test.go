@@ -63,6 +63,42 @@ func TestUser_CheckPassword(t *testing.T) {
 	}
 }
 
+func TestUser_Roles(t *testing.T) {
+	user, err := NewUser("test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if user.Role != UserRoleMember || user.IsAdmin() {
+		t.Errorf("new user role = %q, want member", user.Role)
+	}
+
+	if !UserRoleAdmin.IsValid() || !UserRoleMember.IsValid() || UserRole("owner").IsValid() {
+		t.Error("unexpected role validity")
+	}
+}
+
+func TestUser_DisableEnable(t *testing.T) {
+	user, _ := NewUser("test@example.com", "password123")
+	if user.IsDisabled() {
+		t.Fatal("new user should not be disabled")
+	}
+
+	user.Disable()
+	if !user.IsDisabled() {
+		t.Fatal("expected user to be disabled")
+	}
+	disabledAt := *user.DisabledAt
+	user.Disable()
+	if !user.DisabledAt.Equal(disabledAt) {
+		t.Error("disabling twice should keep the original time")
+	}
+
+	user.Enable()
+	if user.IsDisabled() {
+		t.Error("expected user to be enabled")
+	}
+}
+
 func TestNewSession(t *testing.T) {
 	user, _ := NewUser("test@example.com", "password")
 	token := "test-token-12345"
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewUserToken(t *testing.T) {
+	userID := uuid.New()
+	token, plaintext, err := NewUserToken(userID, TokenPurposePasswordReset, PasswordResetTokenTTL)
+	if err != nil {
+		t.Fatalf("NewUserToken() error = %v", err)
+	}
+
+	if token.UserID != userID || token.Purpose != TokenPurposePasswordReset {
+		t.Errorf("unexpected token %+v", token)
+	}
+	if plaintext == "" || token.TokenHash == plaintext {
+		t.Error("expected only the hash of the plaintext token to be stored")
+	}
+	if token.TokenHash != HashUserToken(plaintext) {
+		t.Error("expected TokenHash to match the plaintext token")
+	}
+	if token.IsExpired() || token.IsUsed() {
+		t.Error("new token should be usable")
+	}
+
+	_, other, _ := NewUserToken(userID, TokenPurposePasswordReset, PasswordResetTokenTTL)
+	if other == plaintext {
+		t.Error("expected tokens to be random")
+	}
+}
+
+func TestUserToken_IsExpired(t *testing.T) {
+	token, _, _ := NewUserToken(uuid.New(), TokenPurposeEmailVerification, time.Hour)
+	token.ExpiresAt = time.Now().Add(-time.Second)
+	if !token.IsExpired() {
+		t.Error("expected token past ExpiresAt to be expired")
+	}
+}
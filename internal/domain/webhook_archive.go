@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivedWebhook is the raw body of a provider webhook as it was received,
+// kept to debug provider integrations.
+type ArchivedWebhook struct {
+	ID             uuid.UUID `json:"id"`
+	Provider       string    `json:"provider"`
+	Path           string    `json:"path"`
+	ContentType    string    `json:"content_type,omitempty"`
+	ProviderCallID string    `json:"provider_call_id,omitempty"` // Empty if the payload could not be parsed
+	Body           []byte    `json:"body"`                       // Gzip-compressed when stored
+	Size           int       `json:"size"`                       // Uncompressed size in bytes
+	ReceivedAt     time.Time `json:"received_at"`
+}
+
+// NewArchivedWebhook creates a new archived webhook for an uncompressed body.
+func NewArchivedWebhook(provider, path, contentType, providerCallID string, body []byte) *ArchivedWebhook {
+	return &ArchivedWebhook{
+		ID:             uuid.New(),
+		Provider:       provider,
+		Path:           path,
+		ContentType:    contentType,
+		ProviderCallID: providerCallID,
+		Body:           body,
+		Size:           len(body),
+		ReceivedAt:     time.Now(),
+	}
+}
+
+// WebhookArchiveFilter narrows a search of the webhook archive.
+type WebhookArchiveFilter struct {
+	Provider       string
+	ProviderCallID string
+	Since          *time.Time
+	Until          *time.Time
+	Limit          int
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventStatus represents the processing state of a received webhook.
+type WebhookEventStatus string
+
+const (
+	WebhookEventStatusPending    WebhookEventStatus = "pending"
+	WebhookEventStatusProcessing WebhookEventStatus = "processing"
+	WebhookEventStatusCompleted  WebhookEventStatus = "completed"
+	WebhookEventStatusFailed     WebhookEventStatus = "failed"
+)
+
+// Webhook retry backoff bounds.
+const (
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = time.Hour
+)
+
+// DefaultWebhookEventMaxAttempts is how many times an event is processed
+// before it is given up on. With the default backoff this spans about two hours.
+const DefaultWebhookEventMaxAttempts = 8
+
+// WebhookEvent is a persisted voice provider webhook. Every incoming event is
+// recorded before processing so failures can be retried.
+type WebhookEvent struct {
+	ID             uuid.UUID          `json:"id"`
+	Provider       string             `json:"provider"`
+	ProviderCallID string             `json:"provider_call_id"`
+	EventStatus    string             `json:"event_status,omitempty"`
+	Payload        json.RawMessage    `json:"payload,omitempty"` // Raw request body
+	Event          json.RawMessage    `json:"event"`             // Normalized call event
+	Status         WebhookEventStatus `json:"status"`
+	Attempts       int                `json:"attempts"`
+	MaxAttempts    int                `json:"max_attempts"`
+	CallID         *uuid.UUID         `json:"call_id,omitempty"`
+
+	// Timing
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ScheduledAt time.Time  `json:"scheduled_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// Error tracking
+	LastError *string `json:"last_error,omitempty"`
+}
+
+// NewWebhookEvent creates a new webhook event record. payload is dropped
+// if it is not valid JSON; the normalized event is always kept.
+func NewWebhookEvent(provider, providerCallID, eventStatus string, payload, event []byte) *WebhookEvent {
+	now := time.Now()
+	if !json.Valid(payload) {
+		payload = nil
+	}
+	return &WebhookEvent{
+		ID:             uuid.New(),
+		Provider:       provider,
+		ProviderCallID: providerCallID,
+		EventStatus:    eventStatus,
+		Payload:        payload,
+		Event:          event,
+		Status:         WebhookEventStatusPending,
+		MaxAttempts:    DefaultWebhookEventMaxAttempts,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ScheduledAt:    now,
+	}
+}
+
+// CanRetry returns true if the event can be retried.
+func (e *WebhookEvent) CanRetry() bool {
+	return e.Attempts < e.MaxAttempts && e.Status != WebhookEventStatusCompleted
+}
+
+// IsTerminal returns true if the event is in a final state.
+func (e *WebhookEvent) IsTerminal() bool {
+	return e.Status == WebhookEventStatusCompleted || e.Status == WebhookEventStatusFailed
+}
+
+// MarkProcessing marks the event as currently being processed.
+func (e *WebhookEvent) MarkProcessing() {
+	now := time.Now()
+	e.Status = WebhookEventStatusProcessing
+	e.Attempts++
+	e.StartedAt = &now
+	e.UpdatedAt = now
+}
+
+// MarkCompleted marks the event as successfully processed.
+func (e *WebhookEvent) MarkCompleted(callID uuid.UUID) {
+	now := time.Now()
+	e.Status = WebhookEventStatusCompleted
+	e.CallID = &callID
+	e.CompletedAt = &now
+	e.UpdatedAt = now
+	e.LastError = nil
+}
+
+// MarkFailed records a processing failure. If retries are available the
+// event is rescheduled with exponential backoff, otherwise it is failed permanently.
+func (e *WebhookEvent) MarkFailed(err error) {
+	now := time.Now()
+	e.UpdatedAt = now
+
+	errMsg := err.Error()
+	e.LastError = &errMsg
+
+	if e.CanRetry() {
+		e.ScheduledAt = now.Add(e.calculateBackoff())
+		e.Status = WebhookEventStatusPending
+	} else {
+		e.Status = WebhookEventStatusFailed
+		e.CompletedAt = &now
+	}
+}
+
+// calculateBackoff returns the delay before the next attempt:
+// 30s, 1m, 2m, 4m, ... capped at one hour.
+func (e *WebhookEvent) calculateBackoff() time.Duration {
+	backoff := webhookRetryBaseDelay
+	for i := 1; i < e.Attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewWebhookEvent_DropsInvalidPayload(t *testing.T) {
+	event := NewWebhookEvent("bland", "call-1", "completed", []byte("not json"), []byte(`{}`))
+	if event.Payload != nil {
+		t.Errorf("expected invalid payload to be dropped, got %s", event.Payload)
+	}
+	if event.Status != WebhookEventStatusPending {
+		t.Errorf("expected status pending, got %s", event.Status)
+	}
+}
+
+func TestWebhookEvent_MarkFailedBackoff(t *testing.T) {
+	event := NewWebhookEvent("bland", "call-1", "completed", nil, []byte(`{}`))
+
+	want := []time.Duration{
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		16 * time.Minute,
+		32 * time.Minute,
+	}
+	for i, backoff := range want {
+		event.MarkProcessing()
+		before := time.Now()
+		event.MarkFailed(errors.New("boom"))
+
+		if event.Status != WebhookEventStatusPending {
+			t.Fatalf("attempt %d: expected pending, got %s", i+1, event.Status)
+		}
+		delay := event.ScheduledAt.Sub(before)
+		if delay < backoff-time.Second || delay > backoff+time.Second {
+			t.Errorf("attempt %d: expected backoff ~%s, got %s", i+1, backoff, delay)
+		}
+	}
+
+	// Final attempt exhausts retries
+	event.MarkProcessing()
+	event.MarkFailed(errors.New("boom"))
+	if event.Status != WebhookEventStatusFailed {
+		t.Errorf("expected failed after %d attempts, got %s", event.Attempts, event.Status)
+	}
+	if !event.IsTerminal() || event.CompletedAt == nil {
+		t.Error("expected terminal event with completed_at set")
+	}
+}
+
+func TestWebhookEvent_BackoffCapped(t *testing.T) {
+	event := NewWebhookEvent("bland", "call-1", "", nil, []byte(`{}`))
+	event.MaxAttempts = 20
+	event.Attempts = 15
+	if got := event.calculateBackoff(); got != time.Hour {
+		t.Errorf("expected backoff capped at 1h, got %s", got)
+	}
+}
+
+func TestWebhookEvent_MarkCompleted(t *testing.T) {
+	event := NewWebhookEvent("bland", "call-1", "", nil, []byte(`{}`))
+	event.MarkProcessing()
+	event.MarkFailed(errors.New("boom"))
+	event.MarkProcessing()
+
+	callID := uuid.New()
+	event.MarkCompleted(callID)
+
+	if event.Status != WebhookEventStatusCompleted {
+		t.Errorf("expected completed, got %s", event.Status)
+	}
+	if event.CallID == nil || *event.CallID != callID {
+		t.Error("expected call ID to be recorded")
+	}
+	if event.LastError != nil {
+		t.Error("expected last error to be cleared")
+	}
+	if event.CanRetry() {
+		t.Error("completed event should not be retryable")
+	}
+}
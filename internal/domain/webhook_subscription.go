@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType names an event delivered to outgoing webhook subscribers
+// and recorded in the events feed.
+type WebhookEventType string
+
+const (
+	WebhookEventCallCreated        WebhookEventType = "call.created"         // A call was placed or first reported
+	WebhookEventCallCompleted      WebhookEventType = "call.completed"       // A call ended after being answered
+	WebhookEventQuoteCreated       WebhookEventType = "quote.created"        // A quote was generated for a call
+	WebhookEventQuoteApproved      WebhookEventType = "quote.approved"       // A quote was approved to send
+	WebhookEventQuoteStatusChanged WebhookEventType = "quote.status_changed" // A quote moved through the review workflow
+)
+
+// WebhookEventTypes lists the events a subscription may receive.
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventCallCreated,
+	WebhookEventCallCompleted,
+	WebhookEventQuoteCreated,
+	WebhookEventQuoteApproved,
+	WebhookEventQuoteStatusChanged,
+}
+
+// IsValid returns true if the event type is known.
+func (t WebhookEventType) IsValid() bool {
+	for _, known := range WebhookEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSecretPrefix marks outgoing webhook signing secrets.
+const WebhookSecretPrefix = "whsec_"
+
+// webhookSecretBytes is the number of random bytes in a signing secret.
+const webhookSecretBytes = 32
+
+// Outgoing webhook request headers.
+const (
+	WebhookSignatureHeader = "X-QuickQuote-Signature"
+	WebhookEventHeader     = "X-QuickQuote-Event"
+	WebhookDeliveryHeader  = "X-QuickQuote-Delivery"
+)
+
+// WebhookSubscription is an external endpoint that is sent signed JSON
+// payloads when the events it subscribes to happen.
+type WebhookSubscription struct {
+	ID        uuid.UUID          `json:"id"`
+	Name      string             `json:"name"`
+	URL       string             `json:"url"`
+	Secret    string             `json:"-"` // Only shown when created or rotated
+	Events    []WebhookEventType `json:"events"`
+	Active    bool               `json:"active"`
+	CreatedBy *uuid.UUID         `json:"created_by,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// NewWebhookSubscription creates an active subscription with a new signing secret.
+func NewWebhookSubscription(name, endpoint string, events []WebhookEventType) (*WebhookSubscription, error) {
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &WebhookSubscription{
+		ID:        uuid.New(),
+		Name:      name,
+		URL:       endpoint,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// GenerateWebhookSecret generates a new random signing secret.
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return WebhookSecretPrefix + hex.EncodeToString(b), nil
+}
+
+// Validate checks the subscription's name, URL and events.
+func (s *WebhookSubscription) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("name is required")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+	if len(s.Events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	seen := make(map[WebhookEventType]bool, len(s.Events))
+	for _, e := range s.Events {
+		if !e.IsValid() {
+			return fmt.Errorf("unknown event %q", e)
+		}
+		if seen[e] {
+			return fmt.Errorf("event %q is listed twice", e)
+		}
+		seen[e] = true
+	}
+	return nil
+}
+
+// Subscribes reports whether the subscription is active and receives the event.
+func (s *WebhookSubscription) Subscribes(event WebhookEventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// RotateSecret replaces the signing secret.
+func (s *WebhookSubscription) RotateSecret() error {
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return err
+	}
+	s.Secret = secret
+	s.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SignWebhookPayload returns the signature header value for a payload sent
+// at timestamp: "t=<unix seconds>,v1=<hex HMAC-SHA256>". The HMAC covers
+// "<unix seconds>.<payload>", so receivers can reject replayed requests by
+// checking the timestamp.
+func SignWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	t := timestamp.Unix()
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", t)
+	mac.Write(payload)
+	return fmt.Sprintf("t=%d,v1=%s", t, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// WebhookPayload is the JSON body sent to subscribers. The event ID is the
+// same for every subscriber, so receivers can deduplicate retries.
+type WebhookPayload struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// WebhookDeliveryStatus represents where a delivery is in being sent.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"   // Waiting for an attempt
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered" // Subscriber answered 2xx
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"    // Gave up after retries
+)
+
+// Delivery retry backoff bounds.
+const (
+	webhookDeliveryBaseDelay = 30 * time.Second
+	webhookDeliveryMaxDelay  = time.Hour
+)
+
+// WebhookDeliveryMaxAttempts is how many times a delivery is tried before it
+// is given up on. With the backoff this spans about two hours.
+const WebhookDeliveryMaxAttempts = 8
+
+// WebhookDelivery is one event payload queued for, or sent to, one subscription.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	EventID        uuid.UUID             `json:"event_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	ResponseStatus *int                  `json:"response_status,omitempty"` // HTTP status of the last attempt
+	LastError      *string               `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// NewWebhookDelivery queues a payload for a subscription.
+func NewWebhookDelivery(subscriptionID uuid.UUID, payload *WebhookPayload, body []byte) *WebhookDelivery {
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventID:        payload.ID,
+		EventType:      payload.Type,
+		Payload:        body,
+		Status:         WebhookDeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkDelivered records a successful attempt.
+func (d *WebhookDelivery) MarkDelivered(statusCode int) {
+	now := time.Now().UTC()
+	d.Status = WebhookDeliveryStatusDelivered
+	d.Attempts++
+	d.ResponseStatus = &statusCode
+	d.LastError = nil
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt, retrying with exponential backoff
+// (30s, 1m, 2m, ... capped at one hour) until attempts run out. statusCode
+// is 0 when no response was received.
+func (d *WebhookDelivery) MarkFailed(statusCode int, err error) {
+	now := time.Now().UTC()
+	d.Attempts++
+	d.ResponseStatus = nil
+	if statusCode != 0 {
+		d.ResponseStatus = &statusCode
+	}
+	errMsg := err.Error()
+	d.LastError = &errMsg
+	d.UpdatedAt = now
+
+	if d.Attempts >= WebhookDeliveryMaxAttempts {
+		d.Status = WebhookDeliveryStatusFailed
+		return
+	}
+	backoff := webhookDeliveryBaseDelay
+	for i := 1; i < d.Attempts && backoff < webhookDeliveryMaxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookDeliveryMaxDelay {
+		backoff = webhookDeliveryMaxDelay
+	}
+	d.NextAttemptAt = now.Add(backoff)
+}
+
+// Retry queues a failed or delivered payload to be sent again now, with a
+// fresh set of attempts.
+func (d *WebhookDelivery) Retry() {
+	now := time.Now().UTC()
+	d.Status = WebhookDeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+}
+
+// Postpone puts off a pending delivery's next attempt without using one up,
+// for example while its subscriber's circuit breaker is open.
+func (d *WebhookDelivery) Postpone(until time.Time, reason string) {
+	d.NextAttemptAt = until
+	d.LastError = &reason
+	d.UpdatedAt = time.Now().UTC()
+}
+
+// Abandon gives up on a pending delivery without another attempt, for
+// example because its subscription was disabled.
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryStatusFailed
+	d.LastError = &reason
+	d.UpdatedAt = time.Now().UTC()
+}
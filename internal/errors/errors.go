@@ -33,12 +33,14 @@ const (
 	CodeAlreadyExists Code = "ALREADY_EXISTS"
 
 	// External service errors
-	CodeExternalService   Code = "EXTERNAL_SERVICE_ERROR"
-	CodeCircuitOpen       Code = "CIRCUIT_OPEN"
-	CodeRateLimited       Code = "RATE_LIMITED"
-	CodeTimeout           Code = "TIMEOUT"
-	CodeWebhookInvalid    Code = "WEBHOOK_INVALID"
-	CodeProviderError     Code = "PROVIDER_ERROR"
+	CodeExternalService     Code = "EXTERNAL_SERVICE_ERROR"
+	CodeCircuitOpen         Code = "CIRCUIT_OPEN"
+	CodeRateLimited         Code = "RATE_LIMITED"
+	CodeTimeout             Code = "TIMEOUT"
+	CodeWebhookInvalid      Code = "WEBHOOK_INVALID"
+	CodeProviderError       Code = "PROVIDER_ERROR"
+	CodeProviderUnavailable Code = "PROVIDER_UNAVAILABLE"
+	CodeServiceUnavailable  Code = "SERVICE_UNAVAILABLE"
 
 	// Internal errors
 	CodeInternal   Code = "INTERNAL_ERROR"
@@ -49,6 +51,8 @@ const (
 	CodeQuoteGenerationFailed Code = "QUOTE_GENERATION_FAILED"
 	CodeCallNotReady          Code = "CALL_NOT_READY"
 	CodeTranscriptMissing     Code = "TRANSCRIPT_MISSING"
+	CodeBudgetExceeded        Code = "BUDGET_EXCEEDED"
+	CodeComplianceBlocked     Code = "COMPLIANCE_BLOCKED"
 )
 
 // Kind represents the kind of error for classification.
@@ -122,8 +126,14 @@ func (e *Error) HTTPStatus() int {
 		return http.StatusConflict
 	case CodeRateLimited:
 		return http.StatusTooManyRequests
+	case CodeBudgetExceeded:
+		return http.StatusPaymentRequired
+	case CodeComplianceBlocked:
+		return http.StatusUnavailableForLegalReasons
 	case CodeTimeout:
 		return http.StatusGatewayTimeout
+	case CodeProviderUnavailable, CodeServiceUnavailable:
+		return http.StatusServiceUnavailable
 	case CodeExternalService, CodeCircuitOpen, CodeProviderError, CodeWebhookInvalid:
 		return http.StatusBadGateway
 	default:
@@ -212,9 +222,9 @@ func kindForCode(code Code) Kind {
 		return KindUser
 	case CodeValidation, CodeInvalidInput, CodeMissingField, CodeInvalidFormat, CodeConstraintFailed:
 		return KindUser
-	case CodeNotFound, CodeConflict, CodeAlreadyExists:
+	case CodeNotFound, CodeConflict, CodeAlreadyExists, CodeBudgetExceeded, CodeComplianceBlocked:
 		return KindUser
-	case CodeRateLimited, CodeTimeout, CodeCircuitOpen:
+	case CodeRateLimited, CodeTimeout, CodeCircuitOpen, CodeProviderUnavailable:
 		return KindTransient
 	case CodeExternalService, CodeProviderError:
 		return KindTransient
@@ -323,6 +333,17 @@ func ProviderError(provider string, err error) *Error {
 	}
 }
 
+// ProviderUnavailable creates an error for a voice provider that can't be
+// reached or is refusing requests for now.
+func ProviderUnavailable(provider string, err error) *Error {
+	return &Error{
+		Code:    CodeProviderUnavailable,
+		Message: fmt.Sprintf("voice provider %s is unavailable", provider),
+		Kind:    KindTransient,
+		Err:     err,
+	}
+}
+
 // WebhookError creates a webhook validation error.
 func WebhookError(message string) *Error {
 	return &Error{
@@ -363,6 +384,41 @@ func GetCode(err error) Code {
 	return CodeInternal
 }
 
+// CodeForStatus returns the code of an error response written with only an
+// HTTP status.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusPaymentRequired:
+		return CodeBudgetExceeded
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound, http.StatusGone:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusMethodNotAllowed:
+		return CodeInvalidInput
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusUnavailableForLegalReasons:
+		return CodeComplianceBlocked
+	case http.StatusBadGateway:
+		return CodeProviderError
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= 400 && status < 500 {
+		return CodeInvalidInput
+	}
+	return CodeInternal
+}
+
 // GetHTTPStatus extracts the HTTP status from an error, returning 500 for non-app errors.
 func GetHTTPStatus(err error) int {
 	var e *Error
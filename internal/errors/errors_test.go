@@ -96,9 +96,13 @@ func TestError_HTTPStatus(t *testing.T) {
 		{CodeConflict, http.StatusConflict},
 		{CodeAlreadyExists, http.StatusConflict},
 		{CodeRateLimited, http.StatusTooManyRequests},
+		{CodeBudgetExceeded, http.StatusPaymentRequired},
+		{CodeComplianceBlocked, http.StatusUnavailableForLegalReasons},
 		{CodeTimeout, http.StatusGatewayTimeout},
 		{CodeExternalService, http.StatusBadGateway},
 		{CodeCircuitOpen, http.StatusBadGateway},
+		{CodeProviderUnavailable, http.StatusServiceUnavailable},
+		{CodeServiceUnavailable, http.StatusServiceUnavailable},
 		{CodeInternal, http.StatusInternalServerError},
 		{CodeDatabase, http.StatusInternalServerError},
 	}
@@ -149,6 +153,8 @@ func TestError_IsUserError(t *testing.T) {
 		{CodeUnauthorized, true},
 		{CodeForbidden, true},
 		{CodeNotFound, true},
+		{CodeBudgetExceeded, true},
+		{CodeComplianceBlocked, true},
 		{CodeInternal, false},
 		{CodeDatabase, false},
 		{CodeRateLimited, false}, // Transient, not user
@@ -276,6 +282,43 @@ func TestExternalServiceError(t *testing.T) {
 	}
 }
 
+func TestProviderUnavailable(t *testing.T) {
+	err := ProviderUnavailable("bland", errors.New("circuit breaker is open"))
+
+	if err.Code != CodeProviderUnavailable || err.HTTPStatus() != http.StatusServiceUnavailable {
+		t.Errorf("Code = %q, status = %d", err.Code, err.HTTPStatus())
+	}
+	if !err.IsRetriable() {
+		t.Error("expected a provider outage to be retriable")
+	}
+}
+
+func TestCodeForStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected Code
+	}{
+		{http.StatusBadRequest, CodeValidation},
+		{http.StatusUnauthorized, CodeUnauthorized},
+		{http.StatusForbidden, CodeForbidden},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusRequestEntityTooLarge, CodeInvalidInput},
+		{http.StatusTooManyRequests, CodeRateLimited},
+		{http.StatusUnavailableForLegalReasons, CodeComplianceBlocked},
+		{http.StatusTeapot, CodeInvalidInput},
+		{http.StatusInternalServerError, CodeInternal},
+		{http.StatusBadGateway, CodeProviderError},
+		{http.StatusServiceUnavailable, CodeServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		if got := CodeForStatus(tt.status); got != tt.expected {
+			t.Errorf("CodeForStatus(%d) = %q, expected %q", tt.status, got, tt.expected)
+		}
+	}
+}
+
 func TestQuoteGenerationError(t *testing.T) {
 	underlying := errors.New("API timeout")
 	err := QuoteGenerationError(underlying)
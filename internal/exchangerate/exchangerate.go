@@ -0,0 +1,144 @@
+// Package exchangerate fetches currency exchange rates so quotes priced in
+// different currencies can be totaled in one for reporting.
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// DefaultRefreshInterval is how long fetched rates are used unless
+// configured otherwise.
+const DefaultRefreshInterval = 12 * time.Hour
+
+// Client fetches exchange rates from an API answering
+// GET {url}/latest/{BASE} in the format of open.er-api.com and
+// exchangerate-api.com:
+//
+//	{"result": "success", "base_code": "USD", "time_last_update_unix": 1767225600, "rates": {"EUR": 0.92}}
+//
+// Rates are cached per base currency for the refresh interval.
+type Client struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+	clock   clock.Clock
+
+	mu      sync.Mutex
+	fetched map[string]*cachedRates
+}
+
+// cachedRates are the rates for one base currency and when they were fetched.
+type cachedRates struct {
+	rates     *domain.ExchangeRates
+	fetchedAt time.Time
+}
+
+// New creates an exchange rate client. A refresh interval of zero or less
+// uses DefaultRefreshInterval, and a client with a 30 second timeout is used
+// when client is nil.
+func New(apiURL string, refresh time.Duration, client *http.Client, clk clock.Clock) *Client {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if refresh <= 0 {
+		refresh = DefaultRefreshInterval
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{
+		url:     strings.TrimRight(apiURL, "/"),
+		refresh: refresh,
+		client:  client,
+		clock:   clk,
+		fetched: make(map[string]*cachedRates),
+	}
+}
+
+// Rates returns the units of each currency one unit of base buys. Rates are
+// fetched at most once per refresh interval; when a refresh fails the rates
+// fetched earlier are returned instead, so check UpdatedAt for their age.
+func (c *Client) Rates(ctx context.Context, base string) (*domain.ExchangeRates, error) {
+	base = domain.NormalizeCurrency(base)
+	if !domain.IsValidCurrency(base) {
+		return nil, domain.ErrInvalidCurrency
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := c.fetched[base]
+	if cached != nil && c.clock.Now().Sub(cached.fetchedAt) < c.refresh {
+		return cached.rates, nil
+	}
+
+	rates, err := c.fetch(ctx, base)
+	if err != nil {
+		if cached != nil {
+			return cached.rates, nil
+		}
+		return nil, err
+	}
+	c.fetched[base] = &cachedRates{rates: rates, fetchedAt: c.clock.Now()}
+	return rates, nil
+}
+
+// ratesResponse is the API's answer.
+type ratesResponse struct {
+	Result         string             `json:"result"`
+	ErrorType      string             `json:"error-type"`
+	BaseCode       string             `json:"base_code"`
+	LastUpdateUnix int64              `json:"time_last_update_unix"`
+	Rates          map[string]float64 `json:"rates"`
+}
+
+// fetch asks the API for the latest rates for base.
+func (c *Client) fetch(ctx context.Context, base string) (*domain.ExchangeRates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/latest/"+base, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exchange rate request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rate request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result ratesResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse exchange rate response: %w", err)
+	}
+	if result.Result != "success" {
+		return nil, fmt.Errorf("exchange rate service returned %s: %s", result.Result, result.ErrorType)
+	}
+	if domain.NormalizeCurrency(result.BaseCode) != base || len(result.Rates) == 0 {
+		return nil, fmt.Errorf("exchange rate service returned no rates for %s", base)
+	}
+
+	rates := &domain.ExchangeRates{
+		Base:      base,
+		Rates:     make(map[string]float64, len(result.Rates)),
+		UpdatedAt: time.Unix(result.LastUpdateUnix, 0).UTC(),
+	}
+	for currency, rate := range result.Rates {
+		rates.Rates[domain.NormalizeCurrency(currency)] = rate
+	}
+	return rates, nil
+}
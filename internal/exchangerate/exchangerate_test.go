@@ -0,0 +1,84 @@
+package exchangerate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+func TestClient_RatesCachesAndServesStale(t *testing.T) {
+	var requests int32
+	fail := atomic.Bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/v6/latest/USD" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"result": "success", "base_code": "USD", "time_last_update_unix": 1767225600, "rates": {"USD": 1, "EUR": 0.8, "gbp": 0.75}}`))
+	}))
+	defer srv.Close()
+
+	clk := clock.NewMock(time.Now())
+	c := New(srv.URL+"/v6/", time.Hour, nil, clk)
+
+	rates, err := c.Rates(context.Background(), "usd")
+	if err != nil {
+		t.Fatalf("Rates() error = %v", err)
+	}
+	if rates.Base != "USD" || rates.Rates["EUR"] != 0.8 || rates.Rates["GBP"] != 0.75 {
+		t.Errorf("Rates() = %+v", rates)
+	}
+	if got, ok := rates.ToBase(100, "EUR"); !ok || got != 125 {
+		t.Errorf("ToBase(100, EUR) = %v, %v, want 125", got, ok)
+	}
+
+	if _, err := c.Rates(context.Background(), "USD"); err != nil || atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("expected cached rates, got %d requests, %v", requests, err)
+	}
+
+	clk.Advance(2 * time.Hour)
+	fail.Store(true)
+	stale, err := c.Rates(context.Background(), "USD")
+	if err != nil || stale.Rates["EUR"] != 0.8 || atomic.LoadInt32(&requests) != 2 {
+		t.Errorf("expected stale rates after a failed refresh, got %+v, %v (%d requests)", stale, err, requests)
+	}
+}
+
+func TestClient_RatesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"server error", http.StatusBadGateway, "upstream down"},
+		{"not json", http.StatusOK, "<html>"},
+		{"api error", http.StatusOK, `{"result": "error", "error-type": "unsupported-code"}`},
+		{"no rates", http.StatusOK, `{"result": "success", "base_code": "USD", "rates": {}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			if _, err := New(srv.URL, 0, nil, nil).Rates(context.Background(), "USD"); err == nil {
+				t.Error("Rates() succeeded, want an error")
+			}
+		})
+	}
+
+	if _, err := New("http://127.0.0.1:0", 0, nil, nil).Rates(context.Background(), "dollars"); err == nil {
+		t.Error("Rates() succeeded for an invalid currency, want an error")
+	}
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter writes rows as RFC 4180 CSV.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// WriteRow writes a single row.
+func (c *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		s := formatValue(v)
+		if _, ok := v.(string); ok {
+			s = neutralizeFormula(s)
+		}
+		record[i] = s
+	}
+	return c.w.Write(record)
+}
+
+// Close flushes buffered output.
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// neutralizeFormula prefixes text that a spreadsheet would evaluate as a
+// formula with a single quote. Phone numbers such as "+15551234567" and
+// negative numbers are left alone.
+func neutralizeFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '@', '\t', '\r':
+		return "'" + s
+	case '+', '-':
+		if len(s) == 1 || !isDigit(s[1]) {
+			return "'" + s
+		}
+	}
+	return s
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
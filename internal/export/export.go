@@ -0,0 +1,114 @@
+// Package export writes tabular data as CSV or XLSX, one row at a time, so
+// large result sets can be streamed straight to an HTTP response.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat parses a format name. An empty name defaults to CSV.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX, "excel":
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", s)
+	}
+}
+
+// ContentType returns the MIME type for the format.
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension returns the file extension for the format, without a dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Writer writes rows of an export. Supported cell values are string, int,
+// int64, float64, bool, time.Time, *time.Time and nil.
+type Writer interface {
+	// WriteRow writes a single row.
+	WriteRow(values ...interface{}) error
+
+	// Close flushes buffered output and finalizes the file.
+	// It does not close the underlying io.Writer.
+	Close() error
+}
+
+// NewWriter creates a Writer for the format. The header row is written immediately.
+func NewWriter(format Format, w io.Writer, header []string) (Writer, error) {
+	var ew Writer
+	switch format {
+	case FormatCSV:
+		ew = newCSVWriter(w)
+	case FormatXLSX:
+		xw, err := newXLSXWriter(w, "Export")
+		if err != nil {
+			return nil, err
+		}
+		ew = xw
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+
+	values := make([]interface{}, len(header))
+	for i, h := range header {
+		values[i] = h
+	}
+	if err := ew.WriteRow(values...); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// TimeLayout is the layout used for timestamps in CSV output.
+const TimeLayout = time.RFC3339
+
+// formatValue renders a cell value as text.
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.UTC().Format(TimeLayout)
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return formatValue(*val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{"", FormatCSV, false},
+		{"csv", FormatCSV, false},
+		{"XLSX", FormatXLSX, false},
+		{"excel", FormatXLSX, false},
+		{"pdf", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf, []string{"Name", "Phone", "Count", "When"})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := w.WriteRow("=HYPERLINK(\"x\")", "+15551234567", 3, when); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	row := records[1]
+	if row[0] != "'=HYPERLINK(\"x\")" {
+		t.Errorf("expected formula to be neutralized, got %q", row[0])
+	}
+	if row[1] != "+15551234567" {
+		t.Errorf("expected phone number unchanged, got %q", row[1])
+	}
+	if row[3] != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected timestamp %q", row[3])
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf, []string{"Name", "When"})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.WriteRow("A & B <co>", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a zip archive: %v", err)
+	}
+
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open worksheet: %v", err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet = string(data)
+	}
+
+	if sheet == "" {
+		t.Fatal("worksheet part missing")
+	}
+	if !strings.Contains(sheet, "A &amp; B &lt;co&gt;") {
+		t.Error("expected escaped text in worksheet")
+	}
+	// 2026-01-01 12:00 is serial day 46023.5.
+	if !strings.Contains(sheet, "<v>46023.500000</v>") {
+		t.Error("expected date serial in worksheet")
+	}
+	if !strings.HasSuffix(sheet, "</sheetData></worksheet>") {
+		t.Error("expected worksheet to be closed")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, expected := range tests {
+		if got := columnName(i); got != expected {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, expected)
+		}
+	}
+}
+
+// pagedCalls serves a fixed newest-first call list, honoring the cursor.
+type pagedCalls struct {
+	calls   []*domain.Call
+	filters []domain.CallListFilter
+}
+
+func (p *pagedCalls) List(ctx context.Context, filter *domain.CallListFilter, limit, offset int) ([]*domain.Call, error) {
+	p.filters = append(p.filters, *filter)
+	var out []*domain.Call
+	for _, c := range p.calls {
+		if filter.After != nil && !c.CreatedAt.Before(filter.After.CreatedAt) {
+			continue
+		}
+		if filter.HasQuote && !c.HasQuote() {
+			continue
+		}
+		out = append(out, c)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func newPagedCalls(n int) *pagedCalls {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	p := &pagedCalls{}
+	for i := 0; i < n; i++ {
+		c := domain.NewCall("p", "bland", "+15550001111", "+15550002222")
+		c.CreatedAt = base.Add(-time.Duration(i) * time.Minute)
+		if i%2 == 0 {
+			quote := "quote"
+			c.QuoteSummary = &quote
+		}
+		p.calls = append(p.calls, c)
+	}
+	return p
+}
+
+func TestService_WriteCalls_PagesThroughAllCalls(t *testing.T) {
+	source := newPagedCalls(7)
+	svc := NewService(source, 3, zap.NewNop())
+
+	var buf bytes.Buffer
+	rows, err := svc.WriteCalls(context.Background(), &buf, FormatCSV, nil)
+	if err != nil {
+		t.Fatalf("WriteCalls() error = %v", err)
+	}
+	if rows != 7 {
+		t.Errorf("expected 7 rows, got %d", rows)
+	}
+	if len(source.filters) != 3 {
+		t.Errorf("expected 3 batches, got %d", len(source.filters))
+	}
+	if source.filters[0].After != nil {
+		t.Error("expected first batch to start without a cursor")
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if len(records) != 8 {
+		t.Errorf("expected header plus 7 rows, got %d records", len(records))
+	}
+}
+
+func TestService_WriteQuotes_OnlyQuotedCalls(t *testing.T) {
+	source := newPagedCalls(5)
+	svc := NewService(source, 10, zap.NewNop())
+
+	status := domain.CallStatusPending
+	filter := &domain.CallListFilter{Status: &status}
+
+	rows, err := svc.WriteQuotes(context.Background(), io.Discard, FormatXLSX, filter)
+	if err != nil {
+		t.Fatalf("WriteQuotes() error = %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("expected 3 quotes, got %d", rows)
+	}
+	if !source.filters[0].HasQuote {
+		t.Error("expected HasQuote filter to be applied")
+	}
+	if filter.HasQuote {
+		t.Error("expected caller's filter to be left unchanged")
+	}
+}
@@ -0,0 +1,181 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// DefaultBatchSize is the number of calls loaded per database round trip.
+const DefaultBatchSize = 500
+
+// CallLister lists calls matching a filter.
+type CallLister interface {
+	List(ctx context.Context, filter *domain.CallListFilter, limit, offset int) ([]*domain.Call, error)
+}
+
+// Service streams call history and quotes as export files.
+type Service struct {
+	calls     CallLister
+	batchSize int
+	logger    *zap.Logger
+}
+
+// NewService creates a new export service. A batchSize of zero uses DefaultBatchSize.
+func NewService(calls CallLister, batchSize int, logger *zap.Logger) *Service {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Service{
+		calls:     calls,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+var callHeader = []string{
+	"ID", "Provider", "Provider Call ID", "Status", "To Number", "From Number",
+	"Caller Name", "Started At", "Ended At", "Duration (s)", "Has Quote",
+	"Disposition", "Created At", "Billed Number", "Billed Minutes", "Call Cost",
+	"Transcription Cost", "Analysis Cost", "Total Cost",
+}
+
+var quoteHeader = []string{
+	"Quote ID", "Caller Name", "From Number", "Email", "Company", "Project Type",
+	"Requirements", "Timeline", "Budget Range", "Quote", "Call Date",
+}
+
+// WriteCalls streams all calls matching filter to w and returns the number of rows written.
+func (s *Service) WriteCalls(ctx context.Context, w io.Writer, format Format, filter *domain.CallListFilter) (int, error) {
+	return s.write(ctx, w, format, callHeader, filter, callRow)
+}
+
+// WriteQuotes streams all calls with a generated quote matching filter to w
+// and returns the number of rows written. The quote ID is the call ID.
+func (s *Service) WriteQuotes(ctx context.Context, w io.Writer, format Format, filter *domain.CallListFilter) (int, error) {
+	f := domain.CallListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.HasQuote = true
+	return s.write(ctx, w, format, quoteHeader, &f, quoteRow)
+}
+
+// write pages through calls with a keyset cursor so only one batch is held
+// in memory at a time.
+func (s *Service) write(ctx context.Context, w io.Writer, format Format, header []string, filter *domain.CallListFilter, row func(*domain.Call) []interface{}) (int, error) {
+	ew, err := NewWriter(format, w, header)
+	if err != nil {
+		return 0, err
+	}
+
+	f := domain.CallListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.After = nil
+
+	written := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		calls, err := s.calls.List(ctx, &f, s.batchSize, 0)
+		if err != nil {
+			return written, fmt.Errorf("failed to list calls: %w", err)
+		}
+
+		for _, call := range calls {
+			if err := ew.WriteRow(row(call)...); err != nil {
+				return written, fmt.Errorf("failed to write row: %w", err)
+			}
+			written++
+		}
+
+		if len(calls) < s.batchSize {
+			break
+		}
+		f.After = domain.CursorFor(calls[len(calls)-1])
+	}
+
+	if err := ew.Close(); err != nil {
+		return written, fmt.Errorf("failed to finalize export: %w", err)
+	}
+
+	s.logger.Debug("export written", zap.String("format", string(format)), zap.Int("rows", written))
+	return written, nil
+}
+
+func callRow(c *domain.Call) []interface{} {
+	var duration interface{}
+	if c.DurationSeconds != nil {
+		duration = *c.DurationSeconds
+	}
+	// Calls not yet priced leave the cost columns blank
+	var billedNumber, minutes, callCost, transcriptionCost, analysisCost, totalCost interface{}
+	if c.Cost != nil {
+		billedNumber = c.Cost.BilledNumber
+		minutes = c.Cost.Minutes
+		callCost = c.Cost.CallCost
+		transcriptionCost = c.Cost.TranscriptionCost
+		analysisCost = c.Cost.AnalysisCost
+		totalCost = c.Cost.TotalCost
+	}
+	return []interface{}{
+		c.ID.String(),
+		c.Provider,
+		c.ProviderCallID,
+		string(c.Status),
+		c.PhoneNumber,
+		c.FromNumber,
+		deref(c.CallerName),
+		c.StartedAt,
+		c.EndedAt,
+		duration,
+		c.HasQuote(),
+		deref(c.ProviderDisposition),
+		c.CreatedAt,
+		billedNumber,
+		minutes,
+		callCost,
+		transcriptionCost,
+		analysisCost,
+		totalCost,
+	}
+}
+
+func quoteRow(c *domain.Call) []interface{} {
+	data := c.ExtractedData
+	if data == nil {
+		data = &domain.ExtractedData{}
+	}
+	name := deref(c.CallerName)
+	if name == "" {
+		name = data.CallerName
+	}
+	return []interface{}{
+		c.ID.String(),
+		name,
+		c.FromNumber,
+		data.Email,
+		data.Company,
+		data.ProjectType,
+		data.Requirements,
+		data.Timeline,
+		data.BudgetRange,
+		deref(c.QuoteSummary),
+		c.CreatedAt,
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
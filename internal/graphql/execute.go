@@ -0,0 +1,308 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Error is an error in a GraphQL result.
+type Error struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Location is a position in a query document, counting from 1.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// ExtendedError is implemented by resolver errors that add extensions, such
+// as a machine-readable code, to the error in the result.
+type ExtendedError = gqlerrors.ExtendedError
+
+// Request is a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    *RequestExtensions     `json:"extensions,omitempty"`
+}
+
+// RequestExtensions are the extensions of a request the server reads.
+type RequestExtensions struct {
+	PersistedQuery *PersistedQueryExtension `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQueryExtension names a persisted query by the SHA-256 of its
+// text, in the format Apollo clients send.
+type PersistedQueryExtension struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash"`
+}
+
+// Result is the result of a request. Data is nil when the request failed
+// before execution, and is then left out of the JSON.
+type Result struct {
+	Data   interface{}
+	Errors []*Error
+}
+
+// MarshalJSON writes data, once the request was executed, and any errors.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Data   interface{} `json:"data,omitempty"`
+		Errors []*Error    `json:"errors,omitempty"`
+	}{Errors: r.Errors}
+	if r.Data != nil {
+		out.Data = r.Data
+	}
+	return json.Marshal(out)
+}
+
+// null is the data of an executed request whose root resolved to null.
+type null struct{}
+
+func (null) MarshalJSON() ([]byte, error) { return []byte("null"), nil }
+
+// Parse parses a query document.
+func Parse(query string) (*ast.Document, error) {
+	return parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query), Name: "GraphQL request"})})
+}
+
+// Execute parses, validates and executes a query request.
+func (s *Schema) Execute(ctx context.Context, req Request) *Result {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return &Result{Errors: convertErrors([]gqlerrors.FormattedError{gqlerrors.FormatError(err)})}
+	}
+	return s.ExecuteDocument(ctx, doc, req.OperationName, req.Variables)
+}
+
+// ExecuteDocument validates and executes an operation of a parsed document.
+func (s *Schema) ExecuteDocument(ctx context.Context, doc *ast.Document, operationName string, variables map[string]interface{}) *Result {
+	// Fragment cycles are refused first: graphql-go's other rules recurse
+	// through fragment spreads without checking for them.
+	if v := gql.ValidateDocument(&s.schema, doc, fragmentRules); !v.IsValid {
+		return &Result{Errors: convertErrors(v.Errors)}
+	}
+	op := operation(doc, operationName)
+	if op != nil {
+		if op.Operation != ast.OperationTypeQuery {
+			return &Result{Errors: convertErrors([]gqlerrors.FormattedError{gqlerrors.FormatError(gqlerrors.NewError(
+				fmt.Sprintf("Only queries are supported, not %ss.", op.Operation), []ast.Node{op}, "", nil, nil, nil,
+			))})}
+		}
+		if err := s.checkDepth(doc, op); err != nil {
+			return &Result{Errors: convertErrors([]gqlerrors.FormattedError{gqlerrors.FormatError(err)})}
+		}
+	}
+	if v := gql.ValidateDocument(&s.schema, doc, nil); !v.IsValid {
+		return &Result{Errors: convertErrors(v.Errors)}
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: operationName,
+		Args:          variables,
+		Context:       ctx,
+	})
+	out := &Result{Data: result.Data, Errors: convertErrors(result.Errors)}
+	switch {
+	case out.Data != nil && op != nil:
+		out.Data = ordered(out.Data, []*ast.SelectionSet{op.SelectionSet}, fragments(doc))
+	case len(out.Errors) > 0 && len(out.Errors[0].Path) > 0:
+		// Errors while resolving carry the path of the field that failed.
+		// Data is nil after one of those only because null reached the root.
+		out.Data = null{}
+	}
+	return out
+}
+
+// fragmentRules are the validation rules that make fragment spreads safe
+// to follow.
+var fragmentRules = []gql.ValidationRuleFn{gql.KnownFragmentNamesRule, gql.NoFragmentCyclesRule}
+
+// fragments returns a document's fragments by name.
+func fragments(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	out := make(map[string]*ast.FragmentDefinition)
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok {
+			out[frag.Name.Value] = frag
+		}
+	}
+	return out
+}
+
+// ordered returns data with its objects' keys in selection order, which
+// graphql-go's maps lose. sets are the selection sets that selected value,
+// more than one when a field was selected twice.
+func ordered(value interface{}, sets []*ast.SelectionSet, frags map[string]*ast.FragmentDefinition) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = ordered(item, sets, frags)
+		}
+		return out
+	case map[string]interface{}:
+		var keys []string
+		subsets := make(map[string][]*ast.SelectionSet)
+		var collect func(set *ast.SelectionSet)
+		collect = func(set *ast.SelectionSet) {
+			if set == nil {
+				return
+			}
+			for _, sel := range set.Selections {
+				switch sel := sel.(type) {
+				case *ast.Field:
+					key := sel.Name.Value
+					if sel.Alias != nil {
+						key = sel.Alias.Value
+					}
+					if _, ok := v[key]; !ok {
+						continue // Skipped by a directive
+					}
+					if _, seen := subsets[key]; !seen {
+						keys = append(keys, key)
+					}
+					subsets[key] = append(subsets[key], sel.SelectionSet)
+				case *ast.InlineFragment:
+					collect(sel.SelectionSet)
+				case *ast.FragmentSpread:
+					if frag, ok := frags[sel.Name.Value]; ok {
+						collect(frag.SelectionSet)
+					}
+				}
+			}
+		}
+		for _, set := range sets {
+			collect(set)
+		}
+
+		obj := &object{keys: keys, values: make([]interface{}, len(keys))}
+		for i, key := range keys {
+			obj.values[i] = ordered(v[key], subsets[key], frags)
+		}
+		return obj
+	}
+	return value
+}
+
+// object is a response object, which keeps its keys in selection order.
+type object struct {
+	keys   []string
+	values []interface{}
+}
+
+// MarshalJSON writes the object's keys in order.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// operation returns the operation a request runs, or nil when graphql-go
+// will report it as missing or ambiguous.
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+		} else if op.Name != nil && op.Name.Value == name {
+			found = op
+		}
+	}
+	return found
+}
+
+// checkDepth refuses an operation that nests selections deeper than
+// MaxDepth. Its fragments have been checked to exist and not to spread
+// themselves.
+func (s *Schema) checkDepth(doc *ast.Document, op *ast.OperationDefinition) error {
+	if s.MaxDepth <= 0 {
+		return nil
+	}
+	frags := fragments(doc)
+
+	var walk func(set *ast.SelectionSet, depth int) error
+	walk = func(set *ast.SelectionSet, depth int) error {
+		if set == nil || len(set.Selections) == 0 {
+			return nil
+		}
+		if depth > s.MaxDepth {
+			return gqlerrors.NewError(fmt.Sprintf("Query is nested deeper than the limit of %d.", s.MaxDepth),
+				[]ast.Node{set}, "", nil, nil, nil)
+		}
+		for _, sel := range set.Selections {
+			var err error
+			switch sel := sel.(type) {
+			case *ast.Field:
+				err = walk(sel.SelectionSet, depth+1)
+			case *ast.InlineFragment:
+				err = walk(sel.SelectionSet, depth)
+			case *ast.FragmentSpread:
+				if frag, ok := frags[sel.Name.Value]; ok {
+					err = walk(frag.SelectionSet, depth)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(op.SelectionSet, 1)
+}
+
+// convertErrors converts graphql-go's errors to the result's.
+func convertErrors(errs []gqlerrors.FormattedError) []*Error {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make([]*Error, len(errs))
+	for i, e := range errs {
+		out[i] = &Error{Message: e.Message, Path: e.Path, Extensions: e.Extensions}
+		for _, loc := range e.Locations {
+			out[i].Locations = append(out[i].Locations, Location{Line: loc.Line, Column: loc.Column})
+		}
+	}
+	return out
+}
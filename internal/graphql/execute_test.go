@@ -0,0 +1,297 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+type testCustomer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testBase struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testCall struct {
+	testBase
+	ID          string        `json:"id"`
+	PhoneNumber string        `json:"phone_number"`
+	Duration    *int          `json:"duration_seconds,omitempty"`
+	Tags        []string      `json:"tags"`
+	Customer    *testCustomer `json:"-"`
+}
+
+type codedError struct{}
+
+func (codedError) Error() string { return "not allowed" }
+
+func (codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "FORBIDDEN"}
+}
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	customer := gql.NewObject(gql.ObjectConfig{Name: "Customer", Fields: gql.Fields{
+		"id":   {Type: gql.NewNonNull(gql.ID)},
+		"name": {Type: gql.String},
+	}})
+	call := gql.NewObject(gql.ObjectConfig{Name: "Call", Fields: gql.Fields{
+		"id":              {Type: gql.NewNonNull(gql.ID)},
+		"phoneNumber":     {Type: gql.NewNonNull(gql.String)},
+		"durationSeconds": {Type: gql.Int},
+		"tags":            {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+		"createdAt":       {Type: gql.NewNonNull(gql.String)},
+		"customer": {Type: customer, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return p.Source.(*testCall).Customer, nil
+		}},
+		"secret": {Type: gql.String, Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return nil, codedError{}
+		}},
+		"required": {Type: gql.NewNonNull(gql.String), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return nil, nil
+		}},
+	}})
+
+	seconds := 42
+	calls := []*testCall{
+		{testBase: testBase{CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}, ID: "c1", PhoneNumber: "+15551230001", Duration: &seconds, Tags: []string{"vip"},
+			Customer: &testCustomer{ID: "u1", Name: "Ada"}},
+		{ID: "c2", PhoneNumber: "+15551230002"},
+	}
+
+	query := gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: gql.Fields{
+		"calls": {
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(call))),
+			Args: gql.FieldConfigArgument{
+				"first": {Type: gql.Int, DefaultValue: 10},
+				"ids":   {Type: gql.NewList(gql.NewNonNull(gql.ID))},
+			},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				out := calls
+				if ids, ok := p.Args["ids"].([]interface{}); ok {
+					out = nil
+					for _, c := range calls {
+						for _, id := range ids {
+							if c.ID == id {
+								out = append(out, c)
+							}
+						}
+					}
+				}
+				if n := p.Args["first"].(int); n < len(out) {
+					out = out[:n]
+				}
+				return out, nil
+			},
+		},
+		"call": {
+			Type: call,
+			Args: gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				for _, c := range calls {
+					if c.ID == p.Args["id"] {
+						return c, nil
+					}
+				}
+				return nil, errors.New("call not found")
+			},
+		},
+		"stats": {Type: gql.NewNonNull(gql.NewObject(gql.ObjectConfig{Name: "Stats", Fields: gql.Fields{
+			"total": {Type: gql.NewNonNull(gql.Int)},
+		}})), Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			return map[string]interface{}{"total": len(calls)}, nil
+		}},
+	}})
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatalf("NewSchema() error = %v", err)
+	}
+	schema.MaxDepth = 3
+	return schema
+}
+
+func execute(t *testing.T, schema *Schema, query string, vars map[string]interface{}) string {
+	t.Helper()
+	result := schema.Execute(context.Background(), Request{Query: query, Variables: vars})
+	out, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(out)
+}
+
+func TestExecute(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]interface{}
+		want  string
+	}{
+		{
+			"field selection in order",
+			`{ calls { phoneNumber id } }`, nil,
+			`{"data":{"calls":[{"phoneNumber":"+15551230001","id":"c1"},{"phoneNumber":"+15551230002","id":"c2"}]}}`,
+		},
+		{
+			"default resolver reads embedded and pointer fields",
+			`{ call(id: "c1") { createdAt durationSeconds tags } }`, nil,
+			`{"data":{"call":{"createdAt":"2026-03-01T09:00:00Z","durationSeconds":42,"tags":["vip"]}}}`,
+		},
+		{
+			"nil slices and pointers",
+			`{ call(id: "c2") { durationSeconds tags customer { name } } }`, nil,
+			`{"data":{"call":{"durationSeconds":null,"tags":[],"customer":null}}}`,
+		},
+		{
+			"aliases, fragments and typename",
+			`query { first: calls(first: 1) { ...F } stats { __typename total } } fragment F on Call { id ... on Call { customer { name } } }`, nil,
+			`{"data":{"first":[{"id":"c1","customer":{"name":"Ada"}}],"stats":{"__typename":"Stats","total":2}}}`,
+		},
+		{
+			"merged selections",
+			`{ call(id: "c1") { id } call(id: "c1") { phoneNumber } }`, nil,
+			`{"data":{"call":{"id":"c1","phoneNumber":"+15551230001"}}}`,
+		},
+		{
+			"variables, list coercion and directives",
+			`query ($ids: [ID!], $full: Boolean!) { calls(ids: $ids) { id phoneNumber @include(if: $full) tags @skip(if: true) } }`,
+			map[string]interface{}{"ids": "c2", "full": false},
+			`{"data":{"calls":[{"id":"c2"}]}}`,
+		},
+		{
+			"absent variable uses the argument default",
+			`query ($first: Int) { calls(first: $first) { id } }`, nil,
+			`{"data":{"calls":[{"id":"c1"},{"id":"c2"}]}}`,
+		},
+		{
+			"resolver error nulls the field",
+			`{ call(id: "zz") { id } stats { total } }`, nil,
+			`{"data":{"call":null,"stats":{"total":2}},"errors":[{"message":"call not found","locations":[{"line":1,"column":3}],"path":["call"]}]}`,
+		},
+		{
+			"error extensions",
+			`{ call(id: "c1") { secret } }`, nil,
+			`{"data":{"call":{"secret":null}},"errors":[{"message":"not allowed","locations":[{"line":1,"column":20}],"path":["call","secret"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			"null in a non-null field nulls the parent",
+			`{ call(id: "c1") { id required } }`, nil,
+			`{"data":{"call":null},"errors":[{"message":"Cannot return null for non-nullable field Call.required.","locations":[{"line":1,"column":23}],"path":["call","required"]}]}`,
+		},
+		{
+			"null propagates through non-null lists to the root",
+			`{ calls { required } }`, nil,
+			`{"data":null,"errors":[{"message":"Cannot return null for non-nullable field Call.required.","locations":[{"line":1,"column":11}],"path":["calls",0,"required"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execute(t, schema, tt.query, tt.vars); got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_ValidationErrors(t *testing.T) {
+	schema := testSchema(t)
+
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]interface{}
+		want  string
+	}{
+		{"unknown field", `{ calls { id cost } }`, nil, `Cannot query field \"cost\" on type \"Call\".`},
+		{"unknown argument", `{ calls(last: 1) { id } }`, nil, `Unknown argument \"last\"`},
+		{"missing argument", `{ call { id } }`, nil, `argument \"id\" of type \"ID!\" is required`},
+		{"bad literal", `{ calls(first: "ten") { id } }`, nil, `Argument \"first\" has invalid value \"ten\"`},
+		{"selection on a leaf", `{ calls { id { x } } }`, nil, `must not have a sub selection`},
+		{"missing selection", `{ calls }`, nil, `must have a sub selection`},
+		{"undefined variable", `{ calls(first: $n) { id } }`, nil, `Variable \"$n\" is not defined.`},
+		{"missing variable", `query ($n: Int!) { calls(first: $n) { id } }`, nil, `was not provided`},
+		{"bad variable", `query ($n: Int) { calls(first: $n) { id } }`, map[string]interface{}{"n": "ten"}, `got invalid value`},
+		{"unknown fragment", `{ calls { ...Missing } }`, nil, `Unknown fragment \"Missing\".`},
+		{"fragment on another type", `{ calls { ...F } } fragment F on Stats { total }`, nil, `can never be of type \"Stats\"`},
+		{"fragment cycle", `{ calls { ...A } } fragment A on Call { ...B } fragment B on Call { ...A }`, nil, `within itself`},
+		{"mutation", `mutation { calls { id } }`, nil, `Only queries are supported`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := execute(t, schema, tt.query, tt.vars)
+			if strings.Contains(got, `"data"`) || !strings.Contains(got, tt.want) {
+				t.Errorf("got %s, want errors containing %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_DepthLimit(t *testing.T) {
+	schema := testSchema(t)
+	schema.MaxDepth = 2
+
+	if got := execute(t, schema, `{ call(id: "c1") { customer { name } } }`, nil); !strings.Contains(got, "nested deeper than the limit of 2") {
+		t.Errorf("got %s", got)
+	}
+	if got := execute(t, schema, `{ call(id: "c1") { id } }`, nil); strings.Contains(got, "errors") {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestExecute_OperationName(t *testing.T) {
+	schema := testSchema(t)
+	query := `query A { stats { total } } query B { calls(first: 1) { id } }`
+
+	result := schema.Execute(context.Background(), Request{Query: query, OperationName: "B"})
+	if out, _ := json.Marshal(result); string(out) != `{"data":{"calls":[{"id":"c1"}]}}` {
+		t.Errorf("got %s", out)
+	}
+
+	result = schema.Execute(context.Background(), Request{Query: query})
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "Must provide operation name") {
+		t.Errorf("got %+v", result.Errors)
+	}
+
+	result = schema.Execute(context.Background(), Request{Query: query, OperationName: "C"})
+	if len(result.Errors) != 1 || result.Data != nil {
+		t.Errorf("got %+v", result)
+	}
+}
+
+func TestSchema_SDL(t *testing.T) {
+	sdl := testSchema(t).SDL()
+	for _, want := range []string{
+		"schema {\n  query: Query\n}",
+		"type Call {",
+		"  calls(first: Int = 10, ids: [ID!]): [Call!]!",
+		"  call(id: ID!): Call\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("SDL missing %q:\n%s", want, sdl)
+		}
+	}
+}
+
+func TestNewSchema_RejectsObjectArguments(t *testing.T) {
+	inner := gql.NewObject(gql.ObjectConfig{Name: "Inner", Fields: gql.Fields{"x": {Type: gql.Int}}})
+	_, err := NewSchema(gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: gql.Fields{
+		"f": {Type: gql.Int, Args: gql.FieldConfigArgument{"in": {Type: inner}}},
+	}}))
+	if err == nil {
+		t.Error("expected an error for an object argument")
+	}
+}
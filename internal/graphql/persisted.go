@@ -0,0 +1,99 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// PersistedQueries are known query documents, looked up by the hex SHA-256
+// of their text. They let clients send a hash instead of the query, and
+// act as an allowlist when ad hoc queries are refused.
+type PersistedQueries struct {
+	docs map[string]*persistedQuery
+}
+
+type persistedQuery struct {
+	query string
+	doc   *ast.Document
+}
+
+// NewPersistedQueries parses the given queries.
+func NewPersistedQueries(queries ...string) (*PersistedQueries, error) {
+	p := &PersistedQueries{docs: make(map[string]*persistedQuery, len(queries))}
+	for _, q := range queries {
+		doc, err := Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		p.docs[QueryHash(q)] = &persistedQuery{query: q, doc: doc}
+	}
+	return p, nil
+}
+
+// LoadPersistedQueries reads every .graphql file in dir, one document per
+// file.
+func LoadPersistedQueries(dir string) (*PersistedQueries, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	queries := make([]string, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, string(data))
+	}
+
+	p, err := NewPersistedQueries(queries...)
+	if err != nil {
+		return nil, fmt.Errorf("persisted queries in %s: %w", dir, err)
+	}
+	return p, nil
+}
+
+// Get returns the query with the given hash.
+func (p *PersistedQueries) Get(hash string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	q, ok := p.docs[hash]
+	if !ok {
+		return "", false
+	}
+	return q.query, true
+}
+
+// Len returns the number of persisted queries.
+func (p *PersistedQueries) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.docs)
+}
+
+func (p *PersistedQueries) document(hash string) (*ast.Document, bool) {
+	if p == nil {
+		return nil, false
+	}
+	q, ok := p.docs[hash]
+	if !ok {
+		return nil, false
+	}
+	return q.doc, true
+}
+
+// QueryHash returns the hex SHA-256 of a query's text.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,231 @@
+// Package graphql serves read-only GraphQL queries over HTTP with
+// graphql-go: batching, persisted query allowlisting, a depth limit and
+// the schema in the schema definition language. Schemas are built with
+// graphql-go's types; fields without a resolver read the struct field whose
+// JSON name is the field name in snake case.
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// Schema is the types a query can read, starting from its query root.
+type Schema struct {
+	schema gql.Schema
+
+	// MaxDepth, when positive, refuses queries that nest object fields
+	// deeper than it.
+	MaxDepth int
+}
+
+// NewSchema returns the schema rooted at query. Fields of its objects
+// without a resolver get one that reads them from the source.
+func NewSchema(query *gql.Object) (*Schema, error) {
+	schema, err := gql.NewSchema(gql.SchemaConfig{Query: query})
+	if err != nil {
+		return nil, fmt.Errorf("graphql: %w", err)
+	}
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*gql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, f := range obj.Fields() {
+			for _, arg := range f.Args {
+				if _, ok := gql.GetNamed(arg.Type).(*gql.Object); ok {
+					return nil, fmt.Errorf("graphql: argument %s of %s.%s must not be an object", arg.Name(), name, fieldName)
+				}
+			}
+			if f.Resolve == nil {
+				fieldName := fieldName
+				f.Resolve = func(p gql.ResolveParams) (interface{}, error) {
+					return defaultResolve(p.Source, fieldName)
+				}
+			}
+		}
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// structFields caches the index of the struct field read for each type and
+// GraphQL field name.
+var structFields sync.Map
+
+// defaultResolve reads a field from its source: a map entry named like the
+// field, or a struct field, possibly embedded, whose JSON name is the field
+// name in snake case.
+func defaultResolve(source interface{}, name string) (interface{}, error) {
+	if m, ok := source.(map[string]interface{}); ok {
+		return leafValue(m[name]), nil
+	}
+
+	rv := reflect.ValueOf(source)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot read field %q from %T", name, source)
+	}
+
+	key := rv.Type().String() + "." + name
+	index, ok := structFields.Load(key)
+	if !ok {
+		sf, found := rv.Type().FieldByNameFunc(func(field string) bool {
+			f, _ := rv.Type().FieldByName(field)
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			return tag == snakeCase(name)
+		})
+		if !found {
+			return nil, fmt.Errorf("%s has no field for %q", rv.Type(), name)
+		}
+		index = sf.Index
+		structFields.Store(key, index)
+	}
+
+	fv, err := rv.FieldByIndexErr(index.([]int))
+	if err != nil {
+		return nil, nil // Through a nil embedded pointer
+	}
+	return leafValue(fv.Interface()), nil
+}
+
+// leafValue converts a value read from a source to one graphql-go
+// serializes as this API always has: pointers are dereferenced, named
+// strings and numbers become their underlying kind, times become RFC 3339
+// strings and nil slices become empty lists.
+func leafValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+		}
+	case reflect.Array:
+		if s, ok := rv.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	case reflect.Struct:
+		if t, ok := rv.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339Nano)
+		}
+	}
+	return rv.Interface()
+}
+
+// snakeCase converts a camel case field name to the snake case of JSON tags.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// builtinScalars are left out of the SDL.
+var builtinScalars = map[string]bool{"String": true, "ID": true, "Int": true, "Float": true, "Boolean": true}
+
+// SDL describes the schema in the GraphQL schema definition language.
+func (s *Schema) SDL() string {
+	types := s.schema.TypeMap()
+	names := make([]string, 0, len(types))
+	for name := range types {
+		if !strings.HasPrefix(name, "__") && !builtinScalars[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + s.schema.QueryType().Name() + "\n}\n")
+	for _, name := range names {
+		switch t := types[name].(type) {
+		case *gql.Scalar:
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description())
+			b.WriteString("scalar " + t.Name() + "\n")
+		case *gql.Object:
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description())
+			b.WriteString("type " + t.Name() + " {\n")
+			fieldMap := t.Fields()
+			fields := make([]string, 0, len(fieldMap))
+			for name := range fieldMap {
+				fields = append(fields, name)
+			}
+			sort.Strings(fields)
+			for _, name := range fields {
+				f := fieldMap[name]
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + name + writeArgs(f.Args) + ": " + f.Type.String() + "\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+	return b.String()
+}
+
+func writeArgs(args []*gql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*gql.Argument(nil), args...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+	parts := make([]string, len(sorted))
+	for i, arg := range sorted {
+		parts[i] = arg.Name() + ": " + arg.Type.String()
+		if d := arg.DefaultValue; d != nil {
+			parts[i] += " = " + literal(d)
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func literal(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	b.WriteString(indent + strconv.Quote(description) + "\n")
+}
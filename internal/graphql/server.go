@@ -0,0 +1,170 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Error codes the server puts in error extensions.
+const (
+	CodePersistedQueryNotFound   = "PERSISTED_QUERY_NOT_FOUND"
+	CodePersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+	CodeBadRequest               = "BAD_REQUEST"
+)
+
+// Config configures a Handler.
+type Config struct {
+	// MaxBatchSize limits the requests in a batch. Zero allows one.
+	MaxBatchSize int
+
+	// Persisted are the queries clients may send by hash.
+	Persisted *PersistedQueries
+
+	// AllowlistOnly refuses queries that are not in Persisted.
+	AllowlistOnly bool
+
+	// MaxDepth, when positive, sets the schema's MaxDepth.
+	MaxDepth int
+}
+
+// Handler serves GraphQL over HTTP. GET takes the request in query
+// parameters; POST takes a JSON request, or a JSON array of requests that
+// run in order and answer with an array of results.
+type Handler struct {
+	schema *Schema
+	cfg    Config
+}
+
+// NewHandler returns a handler executing requests against schema.
+func NewHandler(schema *Schema, cfg Config) *Handler {
+	if cfg.MaxBatchSize < 1 {
+		cfg.MaxBatchSize = 1
+	}
+	if cfg.MaxDepth > 0 {
+		schema.MaxDepth = cfg.MaxDepth
+	}
+	return &Handler{schema: schema, cfg: cfg}
+}
+
+// ServeHTTP executes one request or a batch.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		req, err := requestFromQuery(r)
+		if err != nil {
+			writeResult(w, http.StatusBadRequest, badRequest(err.Error()))
+			return
+		}
+		writeResult(w, http.StatusOK, h.execute(r, req))
+	case http.MethodPost:
+		h.servePost(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeResult(w, http.StatusMethodNotAllowed, badRequest("GraphQL only supports GET and POST requests."))
+	}
+}
+
+func (h *Handler) servePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeResult(w, http.StatusBadRequest, badRequest("Could not read the request body."))
+		return
+	}
+	body = bytes.TrimSpace(body)
+
+	if len(body) == 0 || body[0] != '[' {
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeResult(w, http.StatusBadRequest, badRequest("The request body is not a GraphQL request."))
+			return
+		}
+		writeResult(w, http.StatusOK, h.execute(r, req))
+		return
+	}
+
+	var batch []Request
+	if err := json.Unmarshal(body, &batch); err != nil {
+		writeResult(w, http.StatusBadRequest, badRequest("The request body is not a batch of GraphQL requests."))
+		return
+	}
+	if len(batch) == 0 || len(batch) > h.cfg.MaxBatchSize {
+		writeResult(w, http.StatusBadRequest, badRequest(fmt.Sprintf("A batch must hold between 1 and %d requests.", h.cfg.MaxBatchSize)))
+		return
+	}
+	results := make([]*Result, len(batch))
+	for i, req := range batch {
+		results[i] = h.execute(r, req)
+	}
+	writeResult(w, http.StatusOK, results)
+}
+
+// ServeSDL writes the schema in the schema definition language.
+func (h *Handler) ServeSDL(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, h.schema.SDL())
+}
+
+// execute resolves a persisted query, if the request names one, and runs it.
+func (h *Handler) execute(r *http.Request, req Request) *Result {
+	var hash string
+	if ext := req.Extensions; ext != nil && ext.PersistedQuery != nil {
+		hash = ext.PersistedQuery.SHA256Hash
+		if req.Query != "" && QueryHash(req.Query) != hash {
+			return badRequest("The persisted query hash does not match the query.")
+		}
+	} else if req.Query != "" {
+		hash = QueryHash(req.Query)
+	}
+	if hash == "" {
+		return badRequest("Must provide a query string.")
+	}
+
+	if doc, ok := h.cfg.Persisted.document(hash); ok {
+		return h.schema.ExecuteDocument(r.Context(), doc, req.OperationName, req.Variables)
+	}
+	if req.Query == "" {
+		return &Result{Errors: []*Error{{
+			Message:    "PersistedQueryNotFound",
+			Extensions: map[string]interface{}{"code": CodePersistedQueryNotFound},
+		}}}
+	}
+	if h.cfg.AllowlistOnly {
+		return &Result{Errors: []*Error{{
+			Message:    "Only persisted queries are allowed.",
+			Extensions: map[string]interface{}{"code": CodePersistedQueryNotAllowed},
+		}}}
+	}
+	return h.schema.Execute(r.Context(), req)
+}
+
+func requestFromQuery(r *http.Request) (Request, error) {
+	q := r.URL.Query()
+	req := Request{Query: q.Get("query"), OperationName: q.Get("operationName")}
+	if v := q.Get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			return req, fmt.Errorf("The variables parameter is not a JSON object.")
+		}
+	}
+	if v := q.Get("extensions"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Extensions); err != nil {
+			return req, fmt.Errorf("The extensions parameter is not a JSON object.")
+		}
+	}
+	return req, nil
+}
+
+func badRequest(message string) *Result {
+	return &Result{Errors: []*Error{{
+		Message:    message,
+		Extensions: map[string]interface{}{"code": CodeBadRequest},
+	}}}
+}
+
+func writeResult(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const statsQuery = `{ stats { total } }`
+
+func serve(h *Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(testSchema(t), Config{MaxBatchSize: 2})
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			"post", http.MethodPost, "/", `{"query":"query ($n: Int) { calls(first: $n) { id } }","variables":{"n":1}}`,
+			http.StatusOK, `{"data":{"calls":[{"id":"c1"}]}}`,
+		},
+		{
+			"get", http.MethodGet, "/?query=" + url.QueryEscape(statsQuery), ``,
+			http.StatusOK, `{"data":{"stats":{"total":2}}}`,
+		},
+		{
+			"batch", http.MethodPost, "/", `[{"query":"{ stats { total } }"},{"query":"{ nope }"}]`,
+			http.StatusOK, `[{"data":{"stats":{"total":2}}},{"errors":[{"message":"Cannot query field \"nope\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}]`,
+		},
+		{
+			"batch too large", http.MethodPost, "/", `[{"query":"{ stats { total } }"},{"query":"{ stats { total } }"},{"query":"{ stats { total } }"}]`,
+			http.StatusBadRequest, `{"errors":[{"message":"A batch must hold between 1 and 2 requests.","extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+		{
+			"malformed body", http.MethodPost, "/", `{"query":`,
+			http.StatusBadRequest, `{"errors":[{"message":"The request body is not a GraphQL request.","extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+		{
+			"missing query", http.MethodPost, "/", `{}`,
+			http.StatusOK, `{"errors":[{"message":"Must provide a query string.","extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+		{
+			"method", http.MethodDelete, "/", ``,
+			http.StatusMethodNotAllowed, `{"errors":[{"message":"GraphQL only supports GET and POST requests.","extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(h, tt.method, tt.target, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.wantBody {
+				t.Errorf("body = %s\nwant   %s", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandler_PersistedQueries(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stats.graphql"), []byte(statsQuery), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	persisted, err := LoadPersistedQueries(dir)
+	if err != nil {
+		t.Fatalf("LoadPersistedQueries() error = %v", err)
+	}
+	if persisted.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", persisted.Len())
+	}
+	hash := QueryHash(statsQuery)
+	byHash := `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}}`
+
+	tests := []struct {
+		name      string
+		allowlist bool
+		body      string
+		want      string
+	}{
+		{"by hash", false, byHash, `{"data":{"stats":{"total":2}}}`},
+		{"unknown hash", false, `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"abc"}}}`, CodePersistedQueryNotFound},
+		{"hash mismatch", false, `{"query":"{ calls { id } }","extensions":{"persistedQuery":{"version":1,"sha256Hash":"` + hash + `"}}}`, "does not match"},
+		{"ad hoc query", false, `{"query":"{ calls { id } }"}`, `{"data":{"calls":[{"id":"c1"},{"id":"c2"}]}}`},
+		{"allowlist by hash", true, byHash, `{"data":{"stats":{"total":2}}}`},
+		{"allowlist by text", true, `{"query":"{ stats { total } }"}`, `{"data":{"stats":{"total":2}}}`},
+		{"allowlist refuses ad hoc queries", true, `{"query":"{ calls { id } }"}`, CodePersistedQueryNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(testSchema(t), Config{Persisted: persisted, AllowlistOnly: tt.allowlist})
+			rec := serve(h, http.MethodPost, "/", tt.body)
+			if got := rec.Body.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("body = %s, want it to contain %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPersistedQueries_SyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.graphql"), []byte(`{ stats {`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPersistedQueries(dir); err == nil {
+		t.Error("expected an error for a query that does not parse")
+	}
+}
+
+func TestHandler_ServeSDL(t *testing.T) {
+	h := NewHandler(testSchema(t), Config{})
+	rec := httptest.NewRecorder()
+	h.ServeSDL(rec, httptest.NewRequest(http.MethodGet, "/schema.graphql", nil))
+	if !strings.Contains(rec.Body.String(), "type Query {") {
+		t.Errorf("body = %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,180 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/grpcapi/quickquotev1"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+var callStatuses = map[domain.CallStatus]quickquotev1.CallStatus{
+	domain.CallStatusPending:    quickquotev1.CallStatus_CALL_STATUS_PENDING,
+	domain.CallStatusInProgress: quickquotev1.CallStatus_CALL_STATUS_IN_PROGRESS,
+	domain.CallStatusCompleted:  quickquotev1.CallStatus_CALL_STATUS_COMPLETED,
+	domain.CallStatusFailed:     quickquotev1.CallStatus_CALL_STATUS_FAILED,
+	domain.CallStatusNoAnswer:   quickquotev1.CallStatus_CALL_STATUS_NO_ANSWER,
+}
+
+var quoteStatuses = map[domain.QuoteStatus]quickquotev1.QuoteStatus{
+	domain.QuoteStatusDraft:         quickquotev1.QuoteStatus_QUOTE_STATUS_DRAFT,
+	domain.QuoteStatusPendingReview: quickquotev1.QuoteStatus_QUOTE_STATUS_PENDING_REVIEW,
+	domain.QuoteStatusApproved:      quickquotev1.QuoteStatus_QUOTE_STATUS_APPROVED,
+	domain.QuoteStatusSent:          quickquotev1.QuoteStatus_QUOTE_STATUS_SENT,
+	domain.QuoteStatusAccepted:      quickquotev1.QuoteStatus_QUOTE_STATUS_ACCEPTED,
+	domain.QuoteStatusDeclined:      quickquotev1.QuoteStatus_QUOTE_STATUS_DECLINED,
+}
+
+func callStatusFromProto(status quickquotev1.CallStatus) domain.CallStatus {
+	for s, p := range callStatuses {
+		if p == status {
+			return s
+		}
+	}
+	return ""
+}
+
+func callToProto(c *domain.Call) *quickquotev1.Call {
+	pb := &quickquotev1.Call{
+		Id:             c.ID.String(),
+		ProviderCallId: c.ProviderCallID,
+		Provider:       c.Provider,
+		PhoneNumber:    c.PhoneNumber,
+		FromNumber:     c.FromNumber,
+		CallerName:     c.CallerName,
+		Status:         callStatuses[c.Status],
+		StartedAt:      timestamp(c.StartedAt),
+		EndedAt:        timestamp(c.EndedAt),
+		Transcript:     c.Transcript,
+		RecordingUrl:   c.RecordingURL,
+		QuoteSummary:   c.QuoteSummary,
+		ErrorMessage:   c.ErrorMessage,
+		CustomerId:     optionalID(c.CustomerID),
+		PromptId:       optionalID(c.PromptID),
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+	}
+	if c.DurationSeconds != nil {
+		pb.DurationSeconds = proto.Int32(int32(*c.DurationSeconds))
+	}
+	if c.SpamScore != nil {
+		pb.SpamScore = proto.Int32(int32(*c.SpamScore))
+	}
+	if c.Disposition != nil {
+		pb.Disposition = string(*c.Disposition)
+	}
+	for _, e := range c.TranscriptJSON {
+		pb.TranscriptEntries = append(pb.TranscriptEntries, &quickquotev1.TranscriptEntry{
+			Role:      e.Role,
+			Content:   e.Content,
+			Timestamp: e.Timestamp,
+		})
+	}
+	if t := c.Tags; t != nil {
+		pb.Tags = &quickquotev1.CallTags{
+			Sentiment: string(t.Sentiment),
+			Intent:    string(t.Intent),
+			Urgency:   string(t.Urgency),
+			TaggedAt:  timestamppb.New(t.TaggedAt),
+		}
+	}
+	if cost := c.Cost; cost != nil {
+		pb.Cost = &quickquotev1.CallCost{
+			BilledNumber:      cost.BilledNumber,
+			Outbound:          cost.Outbound,
+			Minutes:           cost.Minutes,
+			RatePerMinute:     cost.RatePerMinute,
+			CallCost:          cost.CallCost,
+			TranscriptionCost: cost.TranscriptionCost,
+			AnalysisCost:      cost.AnalysisCost,
+			TotalCost:         cost.TotalCost,
+		}
+	}
+	return pb
+}
+
+func quoteToProto(d *service.QuoteDetail) *quickquotev1.Quote {
+	pb := &quickquotev1.Quote{
+		CallId:           d.CallID.String(),
+		QuoteNumber:      d.QuoteNumber,
+		Status:           quoteStatuses[d.Status],
+		Currency:         d.Currency,
+		TaxRate:          d.TaxRate,
+		Subtotal:         d.Subtotal,
+		Tax:              d.Tax,
+		Total:            d.Total,
+		RequiresApproval: d.RequiresApproval,
+		Edited:           d.Edited,
+		Notes:            d.Notes,
+		SubmittedAt:      timestamp(d.SubmittedAt),
+		ApprovedAt:       timestamp(d.ApprovedAt),
+		SentAt:           timestamp(d.SentAt),
+		RespondedAt:      timestamp(d.RespondedAt),
+		CreatedAt:        timestamppb.New(d.CreatedAt),
+		UpdatedAt:        timestamppb.New(d.UpdatedAt),
+	}
+	for _, item := range d.LineItems {
+		pb.LineItems = append(pb.LineItems, &quickquotev1.QuoteLineItem{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+		})
+	}
+	return pb
+}
+
+func promptToProto(p *domain.Prompt) *quickquotev1.Prompt {
+	pb := &quickquotev1.Prompt{
+		Id:                  p.ID.String(),
+		Name:                p.Name,
+		Description:         p.Description,
+		Task:                p.Task,
+		Voice:               p.Voice,
+		Language:            p.Language,
+		Model:               p.Model,
+		Temperature:         p.Temperature,
+		FirstSentence:       p.FirstSentence,
+		WaitForGreeting:     p.WaitForGreeting,
+		TransferPhoneNumber: p.TransferPhoneNumber,
+		TransferList:        p.TransferList,
+		VoicemailAction:     p.VoicemailAction,
+		VoicemailMessage:    p.VoicemailMessage,
+		Record:              p.Record,
+		KnowledgeBaseIds:    p.KnowledgeBaseIDs,
+		CustomToolIds:       p.CustomToolIDs,
+		SummaryPrompt:       p.SummaryPrompt,
+		Dispositions:        p.Dispositions,
+		Keywords:            p.Keywords,
+		IsDefault:           p.IsDefault,
+		IsActive:            p.IsActive,
+		CreatedAt:           timestamppb.New(p.CreatedAt),
+		UpdatedAt:           timestamppb.New(p.UpdatedAt),
+	}
+	if p.InterruptionThreshold != nil {
+		pb.InterruptionThreshold = proto.Int32(int32(*p.InterruptionThreshold))
+	}
+	if p.MaxDuration != nil {
+		pb.MaxDurationMinutes = proto.Int32(int32(*p.MaxDuration))
+	}
+	return pb
+}
+
+// timestamp converts an optional time, leaving the field unset when nil.
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	return proto.String(id.String())
+}
@@ -0,0 +1,910 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: quickquote/v1/call.proto
+
+package quickquotev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CallStatus int32
+
+const (
+	CallStatus_CALL_STATUS_UNSPECIFIED CallStatus = 0
+	CallStatus_CALL_STATUS_PENDING     CallStatus = 1
+	CallStatus_CALL_STATUS_IN_PROGRESS CallStatus = 2
+	CallStatus_CALL_STATUS_COMPLETED   CallStatus = 3
+	CallStatus_CALL_STATUS_FAILED      CallStatus = 4
+	CallStatus_CALL_STATUS_NO_ANSWER   CallStatus = 5
+)
+
+// Enum value maps for CallStatus.
+var (
+	CallStatus_name = map[int32]string{
+		0: "CALL_STATUS_UNSPECIFIED",
+		1: "CALL_STATUS_PENDING",
+		2: "CALL_STATUS_IN_PROGRESS",
+		3: "CALL_STATUS_COMPLETED",
+		4: "CALL_STATUS_FAILED",
+		5: "CALL_STATUS_NO_ANSWER",
+	}
+	CallStatus_value = map[string]int32{
+		"CALL_STATUS_UNSPECIFIED": 0,
+		"CALL_STATUS_PENDING":     1,
+		"CALL_STATUS_IN_PROGRESS": 2,
+		"CALL_STATUS_COMPLETED":   3,
+		"CALL_STATUS_FAILED":      4,
+		"CALL_STATUS_NO_ANSWER":   5,
+	}
+)
+
+func (x CallStatus) Enum() *CallStatus {
+	p := new(CallStatus)
+	*p = x
+	return p
+}
+
+func (x CallStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_quickquote_v1_call_proto_enumTypes[0].Descriptor()
+}
+
+func (CallStatus) Type() protoreflect.EnumType {
+	return &file_quickquote_v1_call_proto_enumTypes[0]
+}
+
+func (x CallStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallStatus.Descriptor instead.
+func (CallStatus) EnumDescriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{0}
+}
+
+// Call is a phone call handled by a voice provider.
+type Call struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProviderCallId    string                 `protobuf:"bytes,2,opt,name=provider_call_id,json=providerCallId,proto3" json:"provider_call_id,omitempty"`
+	Provider          string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	PhoneNumber       string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // Number that received the call
+	FromNumber        string                 `protobuf:"bytes,5,opt,name=from_number,json=fromNumber,proto3" json:"from_number,omitempty"`    // Caller's number
+	CallerName        *string                `protobuf:"bytes,6,opt,name=caller_name,json=callerName,proto3,oneof" json:"caller_name,omitempty"`
+	Status            CallStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=quickquote.v1.CallStatus" json:"status,omitempty"`
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	DurationSeconds   *int32                 `protobuf:"varint,10,opt,name=duration_seconds,json=durationSeconds,proto3,oneof" json:"duration_seconds,omitempty"`
+	Transcript        *string                `protobuf:"bytes,11,opt,name=transcript,proto3,oneof" json:"transcript,omitempty"`
+	TranscriptEntries []*TranscriptEntry     `protobuf:"bytes,12,rep,name=transcript_entries,json=transcriptEntries,proto3" json:"transcript_entries,omitempty"`
+	RecordingUrl      *string                `protobuf:"bytes,13,opt,name=recording_url,json=recordingUrl,proto3,oneof" json:"recording_url,omitempty"`
+	QuoteSummary      *string                `protobuf:"bytes,14,opt,name=quote_summary,json=quoteSummary,proto3,oneof" json:"quote_summary,omitempty"`
+	ErrorMessage      *string                `protobuf:"bytes,15,opt,name=error_message,json=errorMessage,proto3,oneof" json:"error_message,omitempty"`
+	CustomerId        *string                `protobuf:"bytes,16,opt,name=customer_id,json=customerId,proto3,oneof" json:"customer_id,omitempty"`
+	PromptId          *string                `protobuf:"bytes,17,opt,name=prompt_id,json=promptId,proto3,oneof" json:"prompt_id,omitempty"` // Prompt an outbound call was placed with
+	Tags              *CallTags              `protobuf:"bytes,18,opt,name=tags,proto3" json:"tags,omitempty"`                               // Set once the call is classified
+	SpamScore         *int32                 `protobuf:"varint,19,opt,name=spam_score,json=spamScore,proto3,oneof" json:"spam_score,omitempty"`
+	Disposition       string                 `protobuf:"bytes,20,opt,name=disposition,proto3" json:"disposition,omitempty"`
+	Cost              *CallCost              `protobuf:"bytes,21,opt,name=cost,proto3" json:"cost,omitempty"` // Set once the call ends
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Call) Reset() {
+	*x = Call{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Call) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Call) ProtoMessage() {}
+
+func (x *Call) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Call.ProtoReflect.Descriptor instead.
+func (*Call) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Call) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Call) GetProviderCallId() string {
+	if x != nil {
+		return x.ProviderCallId
+	}
+	return ""
+}
+
+func (x *Call) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Call) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *Call) GetFromNumber() string {
+	if x != nil {
+		return x.FromNumber
+	}
+	return ""
+}
+
+func (x *Call) GetCallerName() string {
+	if x != nil && x.CallerName != nil {
+		return *x.CallerName
+	}
+	return ""
+}
+
+func (x *Call) GetStatus() CallStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CallStatus_CALL_STATUS_UNSPECIFIED
+}
+
+func (x *Call) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Call) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Call) GetDurationSeconds() int32 {
+	if x != nil && x.DurationSeconds != nil {
+		return *x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Call) GetTranscript() string {
+	if x != nil && x.Transcript != nil {
+		return *x.Transcript
+	}
+	return ""
+}
+
+func (x *Call) GetTranscriptEntries() []*TranscriptEntry {
+	if x != nil {
+		return x.TranscriptEntries
+	}
+	return nil
+}
+
+func (x *Call) GetRecordingUrl() string {
+	if x != nil && x.RecordingUrl != nil {
+		return *x.RecordingUrl
+	}
+	return ""
+}
+
+func (x *Call) GetQuoteSummary() string {
+	if x != nil && x.QuoteSummary != nil {
+		return *x.QuoteSummary
+	}
+	return ""
+}
+
+func (x *Call) GetErrorMessage() string {
+	if x != nil && x.ErrorMessage != nil {
+		return *x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Call) GetCustomerId() string {
+	if x != nil && x.CustomerId != nil {
+		return *x.CustomerId
+	}
+	return ""
+}
+
+func (x *Call) GetPromptId() string {
+	if x != nil && x.PromptId != nil {
+		return *x.PromptId
+	}
+	return ""
+}
+
+func (x *Call) GetTags() *CallTags {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Call) GetSpamScore() int32 {
+	if x != nil && x.SpamScore != nil {
+		return *x.SpamScore
+	}
+	return 0
+}
+
+func (x *Call) GetDisposition() string {
+	if x != nil {
+		return x.Disposition
+	}
+	return ""
+}
+
+func (x *Call) GetCost() *CallCost {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *Call) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Call) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// TranscriptEntry is one turn of the conversation.
+type TranscriptEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Timestamp     float64                `protobuf:"fixed64,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Seconds from the start of the call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptEntry) Reset() {
+	*x = TranscriptEntry{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptEntry) ProtoMessage() {}
+
+func (x *TranscriptEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptEntry.ProtoReflect.Descriptor instead.
+func (*TranscriptEntry) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TranscriptEntry) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *TranscriptEntry) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *TranscriptEntry) GetTimestamp() float64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// CallTags classify a completed call.
+type CallTags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sentiment     string                 `protobuf:"bytes,1,opt,name=sentiment,proto3" json:"sentiment,omitempty"`
+	Intent        string                 `protobuf:"bytes,2,opt,name=intent,proto3" json:"intent,omitempty"`
+	Urgency       string                 `protobuf:"bytes,3,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	TaggedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=tagged_at,json=taggedAt,proto3" json:"tagged_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallTags) Reset() {
+	*x = CallTags{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallTags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallTags) ProtoMessage() {}
+
+func (x *CallTags) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallTags.ProtoReflect.Descriptor instead.
+func (*CallTags) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CallTags) GetSentiment() string {
+	if x != nil {
+		return x.Sentiment
+	}
+	return ""
+}
+
+func (x *CallTags) GetIntent() string {
+	if x != nil {
+		return x.Intent
+	}
+	return ""
+}
+
+func (x *CallTags) GetUrgency() string {
+	if x != nil {
+		return x.Urgency
+	}
+	return ""
+}
+
+func (x *CallTags) GetTaggedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TaggedAt
+	}
+	return nil
+}
+
+// CallCost is the estimated cost of a call.
+type CallCost struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BilledNumber      string                 `protobuf:"bytes,1,opt,name=billed_number,json=billedNumber,proto3" json:"billed_number,omitempty"`
+	Outbound          bool                   `protobuf:"varint,2,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	Minutes           float64                `protobuf:"fixed64,3,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	RatePerMinute     float64                `protobuf:"fixed64,4,opt,name=rate_per_minute,json=ratePerMinute,proto3" json:"rate_per_minute,omitempty"`
+	CallCost          float64                `protobuf:"fixed64,5,opt,name=call_cost,json=callCost,proto3" json:"call_cost,omitempty"`
+	TranscriptionCost float64                `protobuf:"fixed64,6,opt,name=transcription_cost,json=transcriptionCost,proto3" json:"transcription_cost,omitempty"`
+	AnalysisCost      float64                `protobuf:"fixed64,7,opt,name=analysis_cost,json=analysisCost,proto3" json:"analysis_cost,omitempty"`
+	TotalCost         float64                `protobuf:"fixed64,8,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CallCost) Reset() {
+	*x = CallCost{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallCost) ProtoMessage() {}
+
+func (x *CallCost) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallCost.ProtoReflect.Descriptor instead.
+func (*CallCost) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CallCost) GetBilledNumber() string {
+	if x != nil {
+		return x.BilledNumber
+	}
+	return ""
+}
+
+func (x *CallCost) GetOutbound() bool {
+	if x != nil {
+		return x.Outbound
+	}
+	return false
+}
+
+func (x *CallCost) GetMinutes() float64 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *CallCost) GetRatePerMinute() float64 {
+	if x != nil {
+		return x.RatePerMinute
+	}
+	return 0
+}
+
+func (x *CallCost) GetCallCost() float64 {
+	if x != nil {
+		return x.CallCost
+	}
+	return 0
+}
+
+func (x *CallCost) GetTranscriptionCost() float64 {
+	if x != nil {
+		return x.TranscriptionCost
+	}
+	return 0
+}
+
+func (x *CallCost) GetAnalysisCost() float64 {
+	if x != nil {
+		return x.AnalysisCost
+	}
+	return 0
+}
+
+func (x *CallCost) GetTotalCost() float64 {
+	if x != nil {
+		return x.TotalCost
+	}
+	return 0
+}
+
+type GetCallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCallRequest) Reset() {
+	*x = GetCallRequest{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallRequest) ProtoMessage() {}
+
+func (x *GetCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallRequest.ProtoReflect.Descriptor instead.
+func (*GetCallRequest) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCallRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCallsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 1-100; 20 when unset
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from the previous page
+	Status        CallStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=quickquote.v1.CallStatus" json:"status,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,4,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"` // Matches either the called or calling number
+	Provider      string                 `protobuf:"bytes,5,opt,name=provider,proto3" json:"provider,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,6,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	HasQuote      bool                   `protobuf:"varint,7,opt,name=has_quote,json=hasQuote,proto3" json:"has_quote,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`    // Inclusive
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"` // Exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCallsRequest) Reset() {
+	*x = ListCallsRequest{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCallsRequest) ProtoMessage() {}
+
+func (x *ListCallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCallsRequest.ProtoReflect.Descriptor instead.
+func (*ListCallsRequest) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCallsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCallsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCallsRequest) GetStatus() CallStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CallStatus_CALL_STATUS_UNSPECIFIED
+}
+
+func (x *ListCallsRequest) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+func (x *ListCallsRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ListCallsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ListCallsRequest) GetHasQuote() bool {
+	if x != nil {
+		return x.HasQuote
+	}
+	return false
+}
+
+func (x *ListCallsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListCallsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+type ListCallsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calls         []*Call                `protobuf:"bytes,1,rep,name=calls,proto3" json:"calls,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCallsResponse) Reset() {
+	*x = ListCallsResponse{}
+	mi := &file_quickquote_v1_call_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCallsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCallsResponse) ProtoMessage() {}
+
+func (x *ListCallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_call_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCallsResponse.ProtoReflect.Descriptor instead.
+func (*ListCallsResponse) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_call_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCallsResponse) GetCalls() []*Call {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *ListCallsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_quickquote_v1_call_proto protoreflect.FileDescriptor
+
+const file_quickquote_v1_call_proto_rawDesc = "" +
+	"\n" +
+	"\x18quickquote/v1/call.proto\x12\rquickquote.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\t\n" +
+	"\x04Call\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10provider_call_id\x18\x02 \x01(\tR\x0eproviderCallId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x1f\n" +
+	"\vfrom_number\x18\x05 \x01(\tR\n" +
+	"fromNumber\x12$\n" +
+	"\vcaller_name\x18\x06 \x01(\tH\x00R\n" +
+	"callerName\x88\x01\x01\x121\n" +
+	"\x06status\x18\a \x01(\x0e2\x19.quickquote.v1.CallStatusR\x06status\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12.\n" +
+	"\x10duration_seconds\x18\n" +
+	" \x01(\x05H\x01R\x0fdurationSeconds\x88\x01\x01\x12#\n" +
+	"\n" +
+	"transcript\x18\v \x01(\tH\x02R\n" +
+	"transcript\x88\x01\x01\x12M\n" +
+	"\x12transcript_entries\x18\f \x03(\v2\x1e.quickquote.v1.TranscriptEntryR\x11transcriptEntries\x12(\n" +
+	"\rrecording_url\x18\r \x01(\tH\x03R\frecordingUrl\x88\x01\x01\x12(\n" +
+	"\rquote_summary\x18\x0e \x01(\tH\x04R\fquoteSummary\x88\x01\x01\x12(\n" +
+	"\rerror_message\x18\x0f \x01(\tH\x05R\ferrorMessage\x88\x01\x01\x12$\n" +
+	"\vcustomer_id\x18\x10 \x01(\tH\x06R\n" +
+	"customerId\x88\x01\x01\x12 \n" +
+	"\tprompt_id\x18\x11 \x01(\tH\aR\bpromptId\x88\x01\x01\x12+\n" +
+	"\x04tags\x18\x12 \x01(\v2\x17.quickquote.v1.CallTagsR\x04tags\x12\"\n" +
+	"\n" +
+	"spam_score\x18\x13 \x01(\x05H\bR\tspamScore\x88\x01\x01\x12 \n" +
+	"\vdisposition\x18\x14 \x01(\tR\vdisposition\x12+\n" +
+	"\x04cost\x18\x15 \x01(\v2\x17.quickquote.v1.CallCostR\x04cost\x129\n" +
+	"\n" +
+	"created_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_caller_nameB\x13\n" +
+	"\x11_duration_secondsB\r\n" +
+	"\v_transcriptB\x10\n" +
+	"\x0e_recording_urlB\x10\n" +
+	"\x0e_quote_summaryB\x10\n" +
+	"\x0e_error_messageB\x0e\n" +
+	"\f_customer_idB\f\n" +
+	"\n" +
+	"_prompt_idB\r\n" +
+	"\v_spam_score\"]\n" +
+	"\x0fTranscriptEntry\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x01R\ttimestamp\"\x93\x01\n" +
+	"\bCallTags\x12\x1c\n" +
+	"\tsentiment\x18\x01 \x01(\tR\tsentiment\x12\x16\n" +
+	"\x06intent\x18\x02 \x01(\tR\x06intent\x12\x18\n" +
+	"\aurgency\x18\x03 \x01(\tR\aurgency\x127\n" +
+	"\ttagged_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\btaggedAt\"\x9d\x02\n" +
+	"\bCallCost\x12#\n" +
+	"\rbilled_number\x18\x01 \x01(\tR\fbilledNumber\x12\x1a\n" +
+	"\boutbound\x18\x02 \x01(\bR\boutbound\x12\x18\n" +
+	"\aminutes\x18\x03 \x01(\x01R\aminutes\x12&\n" +
+	"\x0frate_per_minute\x18\x04 \x01(\x01R\rratePerMinute\x12\x1b\n" +
+	"\tcall_cost\x18\x05 \x01(\x01R\bcallCost\x12-\n" +
+	"\x12transcription_cost\x18\x06 \x01(\x01R\x11transcriptionCost\x12#\n" +
+	"\ranalysis_cost\x18\a \x01(\x01R\fanalysisCost\x12\x1d\n" +
+	"\n" +
+	"total_cost\x18\b \x01(\x01R\ttotalCost\" \n" +
+	"\x0eGetCallRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x82\x03\n" +
+	"\x10ListCallsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.quickquote.v1.CallStatusR\x06status\x12!\n" +
+	"\fphone_number\x18\x04 \x01(\tR\vphoneNumber\x12\x1a\n" +
+	"\bprovider\x18\x05 \x01(\tR\bprovider\x12\x1f\n" +
+	"\vcustomer_id\x18\x06 \x01(\tR\n" +
+	"customerId\x12\x1b\n" +
+	"\thas_quote\x18\a \x01(\bR\bhasQuote\x12?\n" +
+	"\rcreated_after\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\"f\n" +
+	"\x11ListCallsResponse\x12)\n" +
+	"\x05calls\x18\x01 \x03(\v2\x13.quickquote.v1.CallR\x05calls\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xad\x01\n" +
+	"\n" +
+	"CallStatus\x12\x1b\n" +
+	"\x17CALL_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CALL_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17CALL_STATUS_IN_PROGRESS\x10\x02\x12\x19\n" +
+	"\x15CALL_STATUS_COMPLETED\x10\x03\x12\x16\n" +
+	"\x12CALL_STATUS_FAILED\x10\x04\x12\x19\n" +
+	"\x15CALL_STATUS_NO_ANSWER\x10\x052\x9c\x01\n" +
+	"\vCallService\x12=\n" +
+	"\aGetCall\x12\x1d.quickquote.v1.GetCallRequest\x1a\x13.quickquote.v1.Call\x12N\n" +
+	"\tListCalls\x12\x1f.quickquote.v1.ListCallsRequest\x1a .quickquote.v1.ListCallsResponseBKZIgithub.com/jkindrix/quickquote/internal/grpcapi/quickquotev1;quickquotev1b\x06proto3"
+
+var (
+	file_quickquote_v1_call_proto_rawDescOnce sync.Once
+	file_quickquote_v1_call_proto_rawDescData []byte
+)
+
+func file_quickquote_v1_call_proto_rawDescGZIP() []byte {
+	file_quickquote_v1_call_proto_rawDescOnce.Do(func() {
+		file_quickquote_v1_call_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quickquote_v1_call_proto_rawDesc), len(file_quickquote_v1_call_proto_rawDesc)))
+	})
+	return file_quickquote_v1_call_proto_rawDescData
+}
+
+var file_quickquote_v1_call_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_quickquote_v1_call_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_quickquote_v1_call_proto_goTypes = []any{
+	(CallStatus)(0),               // 0: quickquote.v1.CallStatus
+	(*Call)(nil),                  // 1: quickquote.v1.Call
+	(*TranscriptEntry)(nil),       // 2: quickquote.v1.TranscriptEntry
+	(*CallTags)(nil),              // 3: quickquote.v1.CallTags
+	(*CallCost)(nil),              // 4: quickquote.v1.CallCost
+	(*GetCallRequest)(nil),        // 5: quickquote.v1.GetCallRequest
+	(*ListCallsRequest)(nil),      // 6: quickquote.v1.ListCallsRequest
+	(*ListCallsResponse)(nil),     // 7: quickquote.v1.ListCallsResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_quickquote_v1_call_proto_depIdxs = []int32{
+	0,  // 0: quickquote.v1.Call.status:type_name -> quickquote.v1.CallStatus
+	8,  // 1: quickquote.v1.Call.started_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: quickquote.v1.Call.ended_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: quickquote.v1.Call.transcript_entries:type_name -> quickquote.v1.TranscriptEntry
+	3,  // 4: quickquote.v1.Call.tags:type_name -> quickquote.v1.CallTags
+	4,  // 5: quickquote.v1.Call.cost:type_name -> quickquote.v1.CallCost
+	8,  // 6: quickquote.v1.Call.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: quickquote.v1.Call.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: quickquote.v1.CallTags.tagged_at:type_name -> google.protobuf.Timestamp
+	0,  // 9: quickquote.v1.ListCallsRequest.status:type_name -> quickquote.v1.CallStatus
+	8,  // 10: quickquote.v1.ListCallsRequest.created_after:type_name -> google.protobuf.Timestamp
+	8,  // 11: quickquote.v1.ListCallsRequest.created_before:type_name -> google.protobuf.Timestamp
+	1,  // 12: quickquote.v1.ListCallsResponse.calls:type_name -> quickquote.v1.Call
+	5,  // 13: quickquote.v1.CallService.GetCall:input_type -> quickquote.v1.GetCallRequest
+	6,  // 14: quickquote.v1.CallService.ListCalls:input_type -> quickquote.v1.ListCallsRequest
+	1,  // 15: quickquote.v1.CallService.GetCall:output_type -> quickquote.v1.Call
+	7,  // 16: quickquote.v1.CallService.ListCalls:output_type -> quickquote.v1.ListCallsResponse
+	15, // [15:17] is the sub-list for method output_type
+	13, // [13:15] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_quickquote_v1_call_proto_init() }
+func file_quickquote_v1_call_proto_init() {
+	if File_quickquote_v1_call_proto != nil {
+		return
+	}
+	file_quickquote_v1_call_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quickquote_v1_call_proto_rawDesc), len(file_quickquote_v1_call_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_quickquote_v1_call_proto_goTypes,
+		DependencyIndexes: file_quickquote_v1_call_proto_depIdxs,
+		EnumInfos:         file_quickquote_v1_call_proto_enumTypes,
+		MessageInfos:      file_quickquote_v1_call_proto_msgTypes,
+	}.Build()
+	File_quickquote_v1_call_proto = out.File
+	file_quickquote_v1_call_proto_goTypes = nil
+	file_quickquote_v1_call_proto_depIdxs = nil
+}
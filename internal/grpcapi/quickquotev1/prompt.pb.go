@@ -0,0 +1,542 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: quickquote/v1/prompt.proto
+
+package quickquotev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Prompt configures how the voice agent conducts a call.
+type Prompt struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description           string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Task                  string                 `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	Voice                 string                 `protobuf:"bytes,5,opt,name=voice,proto3" json:"voice,omitempty"`
+	Language              string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Model                 string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	Temperature           *float64               `protobuf:"fixed64,8,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	InterruptionThreshold *int32                 `protobuf:"varint,9,opt,name=interruption_threshold,json=interruptionThreshold,proto3,oneof" json:"interruption_threshold,omitempty"`
+	MaxDurationMinutes    *int32                 `protobuf:"varint,10,opt,name=max_duration_minutes,json=maxDurationMinutes,proto3,oneof" json:"max_duration_minutes,omitempty"`
+	FirstSentence         string                 `protobuf:"bytes,11,opt,name=first_sentence,json=firstSentence,proto3" json:"first_sentence,omitempty"`
+	WaitForGreeting       bool                   `protobuf:"varint,12,opt,name=wait_for_greeting,json=waitForGreeting,proto3" json:"wait_for_greeting,omitempty"`
+	TransferPhoneNumber   string                 `protobuf:"bytes,13,opt,name=transfer_phone_number,json=transferPhoneNumber,proto3" json:"transfer_phone_number,omitempty"`
+	TransferList          map[string]string      `protobuf:"bytes,14,rep,name=transfer_list,json=transferList,proto3" json:"transfer_list,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	VoicemailAction       string                 `protobuf:"bytes,15,opt,name=voicemail_action,json=voicemailAction,proto3" json:"voicemail_action,omitempty"`
+	VoicemailMessage      string                 `protobuf:"bytes,16,opt,name=voicemail_message,json=voicemailMessage,proto3" json:"voicemail_message,omitempty"`
+	Record                bool                   `protobuf:"varint,17,opt,name=record,proto3" json:"record,omitempty"`
+	KnowledgeBaseIds      []string               `protobuf:"bytes,18,rep,name=knowledge_base_ids,json=knowledgeBaseIds,proto3" json:"knowledge_base_ids,omitempty"`
+	CustomToolIds         []string               `protobuf:"bytes,19,rep,name=custom_tool_ids,json=customToolIds,proto3" json:"custom_tool_ids,omitempty"`
+	SummaryPrompt         string                 `protobuf:"bytes,20,opt,name=summary_prompt,json=summaryPrompt,proto3" json:"summary_prompt,omitempty"`
+	Dispositions          []string               `protobuf:"bytes,21,rep,name=dispositions,proto3" json:"dispositions,omitempty"`
+	Keywords              []string               `protobuf:"bytes,22,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	IsDefault             bool                   `protobuf:"varint,23,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	IsActive              bool                   `protobuf:"varint,24,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Prompt) Reset() {
+	*x = Prompt{}
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prompt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prompt) ProtoMessage() {}
+
+func (x *Prompt) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prompt.ProtoReflect.Descriptor instead.
+func (*Prompt) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_prompt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Prompt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Prompt) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Prompt) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Prompt) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *Prompt) GetVoice() string {
+	if x != nil {
+		return x.Voice
+	}
+	return ""
+}
+
+func (x *Prompt) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Prompt) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Prompt) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *Prompt) GetInterruptionThreshold() int32 {
+	if x != nil && x.InterruptionThreshold != nil {
+		return *x.InterruptionThreshold
+	}
+	return 0
+}
+
+func (x *Prompt) GetMaxDurationMinutes() int32 {
+	if x != nil && x.MaxDurationMinutes != nil {
+		return *x.MaxDurationMinutes
+	}
+	return 0
+}
+
+func (x *Prompt) GetFirstSentence() string {
+	if x != nil {
+		return x.FirstSentence
+	}
+	return ""
+}
+
+func (x *Prompt) GetWaitForGreeting() bool {
+	if x != nil {
+		return x.WaitForGreeting
+	}
+	return false
+}
+
+func (x *Prompt) GetTransferPhoneNumber() string {
+	if x != nil {
+		return x.TransferPhoneNumber
+	}
+	return ""
+}
+
+func (x *Prompt) GetTransferList() map[string]string {
+	if x != nil {
+		return x.TransferList
+	}
+	return nil
+}
+
+func (x *Prompt) GetVoicemailAction() string {
+	if x != nil {
+		return x.VoicemailAction
+	}
+	return ""
+}
+
+func (x *Prompt) GetVoicemailMessage() string {
+	if x != nil {
+		return x.VoicemailMessage
+	}
+	return ""
+}
+
+func (x *Prompt) GetRecord() bool {
+	if x != nil {
+		return x.Record
+	}
+	return false
+}
+
+func (x *Prompt) GetKnowledgeBaseIds() []string {
+	if x != nil {
+		return x.KnowledgeBaseIds
+	}
+	return nil
+}
+
+func (x *Prompt) GetCustomToolIds() []string {
+	if x != nil {
+		return x.CustomToolIds
+	}
+	return nil
+}
+
+func (x *Prompt) GetSummaryPrompt() string {
+	if x != nil {
+		return x.SummaryPrompt
+	}
+	return ""
+}
+
+func (x *Prompt) GetDispositions() []string {
+	if x != nil {
+		return x.Dispositions
+	}
+	return nil
+}
+
+func (x *Prompt) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *Prompt) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *Prompt) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Prompt) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Prompt) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetPromptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromptRequest) Reset() {
+	*x = GetPromptRequest{}
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromptRequest) ProtoMessage() {}
+
+func (x *GetPromptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromptRequest.ProtoReflect.Descriptor instead.
+func (*GetPromptRequest) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_prompt_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPromptRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListPromptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                         // 1-based; 1 when unset
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 20 when unset
+	ActiveOnly    bool                   `protobuf:"varint,3,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptsRequest) Reset() {
+	*x = ListPromptsRequest{}
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptsRequest) ProtoMessage() {}
+
+func (x *ListPromptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromptsRequest) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_prompt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPromptsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPromptsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPromptsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListPromptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prompts       []*Prompt              `protobuf:"bytes,1,rep,name=prompts,proto3" json:"prompts,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptsResponse) Reset() {
+	*x = ListPromptsResponse{}
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptsResponse) ProtoMessage() {}
+
+func (x *ListPromptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_prompt_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromptsResponse) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_prompt_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPromptsResponse) GetPrompts() []*Prompt {
+	if x != nil {
+		return x.Prompts
+	}
+	return nil
+}
+
+func (x *ListPromptsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_quickquote_v1_prompt_proto protoreflect.FileDescriptor
+
+const file_quickquote_v1_prompt_proto_rawDesc = "" +
+	"\n" +
+	"\x1aquickquote/v1/prompt.proto\x12\rquickquote.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\b\n" +
+	"\x06Prompt\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04task\x18\x04 \x01(\tR\x04task\x12\x14\n" +
+	"\x05voice\x18\x05 \x01(\tR\x05voice\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12%\n" +
+	"\vtemperature\x18\b \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12:\n" +
+	"\x16interruption_threshold\x18\t \x01(\x05H\x01R\x15interruptionThreshold\x88\x01\x01\x125\n" +
+	"\x14max_duration_minutes\x18\n" +
+	" \x01(\x05H\x02R\x12maxDurationMinutes\x88\x01\x01\x12%\n" +
+	"\x0efirst_sentence\x18\v \x01(\tR\rfirstSentence\x12*\n" +
+	"\x11wait_for_greeting\x18\f \x01(\bR\x0fwaitForGreeting\x122\n" +
+	"\x15transfer_phone_number\x18\r \x01(\tR\x13transferPhoneNumber\x12L\n" +
+	"\rtransfer_list\x18\x0e \x03(\v2'.quickquote.v1.Prompt.TransferListEntryR\ftransferList\x12)\n" +
+	"\x10voicemail_action\x18\x0f \x01(\tR\x0fvoicemailAction\x12+\n" +
+	"\x11voicemail_message\x18\x10 \x01(\tR\x10voicemailMessage\x12\x16\n" +
+	"\x06record\x18\x11 \x01(\bR\x06record\x12,\n" +
+	"\x12knowledge_base_ids\x18\x12 \x03(\tR\x10knowledgeBaseIds\x12&\n" +
+	"\x0fcustom_tool_ids\x18\x13 \x03(\tR\rcustomToolIds\x12%\n" +
+	"\x0esummary_prompt\x18\x14 \x01(\tR\rsummaryPrompt\x12\"\n" +
+	"\fdispositions\x18\x15 \x03(\tR\fdispositions\x12\x1a\n" +
+	"\bkeywords\x18\x16 \x03(\tR\bkeywords\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x17 \x01(\bR\tisDefault\x12\x1b\n" +
+	"\tis_active\x18\x18 \x01(\bR\bisActive\x129\n" +
+	"\n" +
+	"created_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x1a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a?\n" +
+	"\x11TransferListEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0e\n" +
+	"\f_temperatureB\x19\n" +
+	"\x17_interruption_thresholdB\x17\n" +
+	"\x15_max_duration_minutes\"\"\n" +
+	"\x10GetPromptRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"f\n" +
+	"\x12ListPromptsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vactive_only\x18\x03 \x01(\bR\n" +
+	"activeOnly\"\\\n" +
+	"\x13ListPromptsResponse\x12/\n" +
+	"\aprompts\x18\x01 \x03(\v2\x15.quickquote.v1.PromptR\aprompts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total2\xaa\x01\n" +
+	"\rPromptService\x12C\n" +
+	"\tGetPrompt\x12\x1f.quickquote.v1.GetPromptRequest\x1a\x15.quickquote.v1.Prompt\x12T\n" +
+	"\vListPrompts\x12!.quickquote.v1.ListPromptsRequest\x1a\".quickquote.v1.ListPromptsResponseBKZIgithub.com/jkindrix/quickquote/internal/grpcapi/quickquotev1;quickquotev1b\x06proto3"
+
+var (
+	file_quickquote_v1_prompt_proto_rawDescOnce sync.Once
+	file_quickquote_v1_prompt_proto_rawDescData []byte
+)
+
+func file_quickquote_v1_prompt_proto_rawDescGZIP() []byte {
+	file_quickquote_v1_prompt_proto_rawDescOnce.Do(func() {
+		file_quickquote_v1_prompt_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quickquote_v1_prompt_proto_rawDesc), len(file_quickquote_v1_prompt_proto_rawDesc)))
+	})
+	return file_quickquote_v1_prompt_proto_rawDescData
+}
+
+var file_quickquote_v1_prompt_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_quickquote_v1_prompt_proto_goTypes = []any{
+	(*Prompt)(nil),                // 0: quickquote.v1.Prompt
+	(*GetPromptRequest)(nil),      // 1: quickquote.v1.GetPromptRequest
+	(*ListPromptsRequest)(nil),    // 2: quickquote.v1.ListPromptsRequest
+	(*ListPromptsResponse)(nil),   // 3: quickquote.v1.ListPromptsResponse
+	nil,                           // 4: quickquote.v1.Prompt.TransferListEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_quickquote_v1_prompt_proto_depIdxs = []int32{
+	4, // 0: quickquote.v1.Prompt.transfer_list:type_name -> quickquote.v1.Prompt.TransferListEntry
+	5, // 1: quickquote.v1.Prompt.created_at:type_name -> google.protobuf.Timestamp
+	5, // 2: quickquote.v1.Prompt.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 3: quickquote.v1.ListPromptsResponse.prompts:type_name -> quickquote.v1.Prompt
+	1, // 4: quickquote.v1.PromptService.GetPrompt:input_type -> quickquote.v1.GetPromptRequest
+	2, // 5: quickquote.v1.PromptService.ListPrompts:input_type -> quickquote.v1.ListPromptsRequest
+	0, // 6: quickquote.v1.PromptService.GetPrompt:output_type -> quickquote.v1.Prompt
+	3, // 7: quickquote.v1.PromptService.ListPrompts:output_type -> quickquote.v1.ListPromptsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_quickquote_v1_prompt_proto_init() }
+func file_quickquote_v1_prompt_proto_init() {
+	if File_quickquote_v1_prompt_proto != nil {
+		return
+	}
+	file_quickquote_v1_prompt_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quickquote_v1_prompt_proto_rawDesc), len(file_quickquote_v1_prompt_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_quickquote_v1_prompt_proto_goTypes,
+		DependencyIndexes: file_quickquote_v1_prompt_proto_depIdxs,
+		MessageInfos:      file_quickquote_v1_prompt_proto_msgTypes,
+	}.Build()
+	File_quickquote_v1_prompt_proto = out.File
+	file_quickquote_v1_prompt_proto_goTypes = nil
+	file_quickquote_v1_prompt_proto_depIdxs = nil
+}
@@ -0,0 +1,489 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: quickquote/v1/quote.proto
+
+package quickquotev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type QuoteStatus int32
+
+const (
+	QuoteStatus_QUOTE_STATUS_UNSPECIFIED    QuoteStatus = 0
+	QuoteStatus_QUOTE_STATUS_DRAFT          QuoteStatus = 1
+	QuoteStatus_QUOTE_STATUS_PENDING_REVIEW QuoteStatus = 2
+	QuoteStatus_QUOTE_STATUS_APPROVED       QuoteStatus = 3
+	QuoteStatus_QUOTE_STATUS_SENT           QuoteStatus = 4
+	QuoteStatus_QUOTE_STATUS_ACCEPTED       QuoteStatus = 5
+	QuoteStatus_QUOTE_STATUS_DECLINED       QuoteStatus = 6
+)
+
+// Enum value maps for QuoteStatus.
+var (
+	QuoteStatus_name = map[int32]string{
+		0: "QUOTE_STATUS_UNSPECIFIED",
+		1: "QUOTE_STATUS_DRAFT",
+		2: "QUOTE_STATUS_PENDING_REVIEW",
+		3: "QUOTE_STATUS_APPROVED",
+		4: "QUOTE_STATUS_SENT",
+		5: "QUOTE_STATUS_ACCEPTED",
+		6: "QUOTE_STATUS_DECLINED",
+	}
+	QuoteStatus_value = map[string]int32{
+		"QUOTE_STATUS_UNSPECIFIED":    0,
+		"QUOTE_STATUS_DRAFT":          1,
+		"QUOTE_STATUS_PENDING_REVIEW": 2,
+		"QUOTE_STATUS_APPROVED":       3,
+		"QUOTE_STATUS_SENT":           4,
+		"QUOTE_STATUS_ACCEPTED":       5,
+		"QUOTE_STATUS_DECLINED":       6,
+	}
+)
+
+func (x QuoteStatus) Enum() *QuoteStatus {
+	p := new(QuoteStatus)
+	*p = x
+	return p
+}
+
+func (x QuoteStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuoteStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_quickquote_v1_quote_proto_enumTypes[0].Descriptor()
+}
+
+func (QuoteStatus) Type() protoreflect.EnumType {
+	return &file_quickquote_v1_quote_proto_enumTypes[0]
+}
+
+func (x QuoteStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuoteStatus.Descriptor instead.
+func (QuoteStatus) EnumDescriptor() ([]byte, []int) {
+	return file_quickquote_v1_quote_proto_rawDescGZIP(), []int{0}
+}
+
+// Quote is the software project quote generated from a call.
+type Quote struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CallId           string                 `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	QuoteNumber      string                 `protobuf:"bytes,2,opt,name=quote_number,json=quoteNumber,proto3" json:"quote_number,omitempty"`
+	Status           QuoteStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=quickquote.v1.QuoteStatus" json:"status,omitempty"`
+	Currency         string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	LineItems        []*QuoteLineItem       `protobuf:"bytes,5,rep,name=line_items,json=lineItems,proto3" json:"line_items,omitempty"`
+	TaxRate          float64                `protobuf:"fixed64,6,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"` // Percent applied to the subtotal
+	Subtotal         float64                `protobuf:"fixed64,7,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Tax              float64                `protobuf:"fixed64,8,opt,name=tax,proto3" json:"tax,omitempty"`
+	Total            float64                `protobuf:"fixed64,9,opt,name=total,proto3" json:"total,omitempty"`
+	RequiresApproval bool                   `protobuf:"varint,10,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	Edited           bool                   `protobuf:"varint,11,opt,name=edited,proto3" json:"edited,omitempty"` // Line items were changed after generation
+	Notes            string                 `protobuf:"bytes,12,opt,name=notes,proto3" json:"notes,omitempty"`
+	SubmittedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	ApprovedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
+	SentAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	RespondedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=responded_at,json=respondedAt,proto3" json:"responded_at,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_quickquote_v1_quote_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_quote_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_quote_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quote) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+func (x *Quote) GetQuoteNumber() string {
+	if x != nil {
+		return x.QuoteNumber
+	}
+	return ""
+}
+
+func (x *Quote) GetStatus() QuoteStatus {
+	if x != nil {
+		return x.Status
+	}
+	return QuoteStatus_QUOTE_STATUS_UNSPECIFIED
+}
+
+func (x *Quote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Quote) GetLineItems() []*QuoteLineItem {
+	if x != nil {
+		return x.LineItems
+	}
+	return nil
+}
+
+func (x *Quote) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *Quote) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *Quote) GetTax() float64 {
+	if x != nil {
+		return x.Tax
+	}
+	return 0
+}
+
+func (x *Quote) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Quote) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+func (x *Quote) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *Quote) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Quote) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetApprovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ApprovedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+func (x *Quote) GetRespondedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Quote) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type QuoteLineItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteLineItem) Reset() {
+	*x = QuoteLineItem{}
+	mi := &file_quickquote_v1_quote_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteLineItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteLineItem) ProtoMessage() {}
+
+func (x *QuoteLineItem) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_quote_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteLineItem.ProtoReflect.Descriptor instead.
+func (*QuoteLineItem) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_quote_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuoteLineItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *QuoteLineItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *QuoteLineItem) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *QuoteLineItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CallId        string                 `protobuf:"bytes,1,opt,name=call_id,json=callId,proto3" json:"call_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_quickquote_v1_quote_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quickquote_v1_quote_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_quickquote_v1_quote_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetQuoteRequest) GetCallId() string {
+	if x != nil {
+		return x.CallId
+	}
+	return ""
+}
+
+var File_quickquote_v1_quote_proto protoreflect.FileDescriptor
+
+const file_quickquote_v1_quote_proto_rawDesc = "" +
+	"\n" +
+	"\x19quickquote/v1/quote.proto\x12\rquickquote.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf0\x05\n" +
+	"\x05Quote\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId\x12!\n" +
+	"\fquote_number\x18\x02 \x01(\tR\vquoteNumber\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.quickquote.v1.QuoteStatusR\x06status\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12;\n" +
+	"\n" +
+	"line_items\x18\x05 \x03(\v2\x1c.quickquote.v1.QuoteLineItemR\tlineItems\x12\x19\n" +
+	"\btax_rate\x18\x06 \x01(\x01R\ataxRate\x12\x1a\n" +
+	"\bsubtotal\x18\a \x01(\x01R\bsubtotal\x12\x10\n" +
+	"\x03tax\x18\b \x01(\x01R\x03tax\x12\x14\n" +
+	"\x05total\x18\t \x01(\x01R\x05total\x12+\n" +
+	"\x11requires_approval\x18\n" +
+	" \x01(\bR\x10requiresApproval\x12\x16\n" +
+	"\x06edited\x18\v \x01(\bR\x06edited\x12\x14\n" +
+	"\x05notes\x18\f \x01(\tR\x05notes\x12=\n" +
+	"\fsubmitted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x12;\n" +
+	"\vapproved_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"approvedAt\x123\n" +
+	"\asent_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\x12=\n" +
+	"\fresponded_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vrespondedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x84\x01\n" +
+	"\rQuoteLineItem\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\"*\n" +
+	"\x0fGetQuoteRequest\x12\x17\n" +
+	"\acall_id\x18\x01 \x01(\tR\x06callId*\xcc\x01\n" +
+	"\vQuoteStatus\x12\x1c\n" +
+	"\x18QUOTE_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12QUOTE_STATUS_DRAFT\x10\x01\x12\x1f\n" +
+	"\x1bQUOTE_STATUS_PENDING_REVIEW\x10\x02\x12\x19\n" +
+	"\x15QUOTE_STATUS_APPROVED\x10\x03\x12\x15\n" +
+	"\x11QUOTE_STATUS_SENT\x10\x04\x12\x19\n" +
+	"\x15QUOTE_STATUS_ACCEPTED\x10\x05\x12\x19\n" +
+	"\x15QUOTE_STATUS_DECLINED\x10\x062P\n" +
+	"\fQuoteService\x12@\n" +
+	"\bGetQuote\x12\x1e.quickquote.v1.GetQuoteRequest\x1a\x14.quickquote.v1.QuoteBKZIgithub.com/jkindrix/quickquote/internal/grpcapi/quickquotev1;quickquotev1b\x06proto3"
+
+var (
+	file_quickquote_v1_quote_proto_rawDescOnce sync.Once
+	file_quickquote_v1_quote_proto_rawDescData []byte
+)
+
+func file_quickquote_v1_quote_proto_rawDescGZIP() []byte {
+	file_quickquote_v1_quote_proto_rawDescOnce.Do(func() {
+		file_quickquote_v1_quote_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_quickquote_v1_quote_proto_rawDesc), len(file_quickquote_v1_quote_proto_rawDesc)))
+	})
+	return file_quickquote_v1_quote_proto_rawDescData
+}
+
+var file_quickquote_v1_quote_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_quickquote_v1_quote_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_quickquote_v1_quote_proto_goTypes = []any{
+	(QuoteStatus)(0),              // 0: quickquote.v1.QuoteStatus
+	(*Quote)(nil),                 // 1: quickquote.v1.Quote
+	(*QuoteLineItem)(nil),         // 2: quickquote.v1.QuoteLineItem
+	(*GetQuoteRequest)(nil),       // 3: quickquote.v1.GetQuoteRequest
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_quickquote_v1_quote_proto_depIdxs = []int32{
+	0, // 0: quickquote.v1.Quote.status:type_name -> quickquote.v1.QuoteStatus
+	2, // 1: quickquote.v1.Quote.line_items:type_name -> quickquote.v1.QuoteLineItem
+	4, // 2: quickquote.v1.Quote.submitted_at:type_name -> google.protobuf.Timestamp
+	4, // 3: quickquote.v1.Quote.approved_at:type_name -> google.protobuf.Timestamp
+	4, // 4: quickquote.v1.Quote.sent_at:type_name -> google.protobuf.Timestamp
+	4, // 5: quickquote.v1.Quote.responded_at:type_name -> google.protobuf.Timestamp
+	4, // 6: quickquote.v1.Quote.created_at:type_name -> google.protobuf.Timestamp
+	4, // 7: quickquote.v1.Quote.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 8: quickquote.v1.QuoteService.GetQuote:input_type -> quickquote.v1.GetQuoteRequest
+	1, // 9: quickquote.v1.QuoteService.GetQuote:output_type -> quickquote.v1.Quote
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_quickquote_v1_quote_proto_init() }
+func file_quickquote_v1_quote_proto_init() {
+	if File_quickquote_v1_quote_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_quickquote_v1_quote_proto_rawDesc), len(file_quickquote_v1_quote_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_quickquote_v1_quote_proto_goTypes,
+		DependencyIndexes: file_quickquote_v1_quote_proto_depIdxs,
+		EnumInfos:         file_quickquote_v1_quote_proto_enumTypes,
+		MessageInfos:      file_quickquote_v1_quote_proto_msgTypes,
+	}.Build()
+	File_quickquote_v1_quote_proto = out.File
+	file_quickquote_v1_quote_proto_goTypes = nil
+	file_quickquote_v1_quote_proto_depIdxs = nil
+}
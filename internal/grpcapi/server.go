@@ -0,0 +1,215 @@
+// Package grpcapi serves calls, quotes and prompts over gRPC for internal
+// integrations. The services and messages are generated from the
+// definitions in proto/quickquote/v1 into quickquotev1; run "make proto"
+// after changing them.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/grpcapi/quickquotev1"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// Authenticator resolves a presented API key to the key and its owner.
+type Authenticator interface {
+	Authenticate(ctx context.Context, plaintext string) (*domain.APIKey, *domain.User, error)
+}
+
+// Config configures a Server.
+type Config struct {
+	Calls   CallReader
+	Quotes  QuoteReader
+	Prompts PromptReader
+
+	// Auth checks the API key every quickquote.v1 call must present.
+	Auth Authenticator
+
+	// Limiter enforces each key's per-minute limit, shared with the JSON
+	// API. Nil disables rate limiting.
+	Limiter *ratelimit.KeyRateLimiter
+
+	// Creds secures connections. Nil serves plaintext.
+	Creds credentials.TransportCredentials
+
+	Logger *zap.Logger
+}
+
+// Server is a gRPC server exposing the call, quote and prompt services
+// alongside the standard health and reflection services.
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+// serviceScopes maps each service to the API key scope its methods need.
+// Services not listed (health, reflection) need no API key.
+var serviceScopes = map[string]string{
+	quickquotev1.CallService_ServiceDesc.ServiceName:   domain.ScopeCallsRead,
+	quickquotev1.QuoteService_ServiceDesc.ServiceName:  domain.ScopeQuotesRead,
+	quickquotev1.PromptService_ServiceDesc.ServiceName: domain.ScopePromptsRead,
+}
+
+// NewServer returns a server with every service registered.
+func NewServer(cfg Config) *Server {
+	auth := &authorizer{auth: cfg.Auth, limiter: cfg.Limiter, logger: cfg.Logger}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(auth.unary)}
+	if cfg.Creds != nil {
+		opts = append(opts, grpc.Creds(cfg.Creds))
+	}
+	s := &Server{
+		grpc:   grpc.NewServer(opts...),
+		health: health.NewServer(),
+	}
+
+	quickquotev1.RegisterCallServiceServer(s.grpc, &callServer{calls: cfg.Calls, logger: cfg.Logger})
+	quickquotev1.RegisterQuoteServiceServer(s.grpc, &quoteServer{quotes: cfg.Quotes, logger: cfg.Logger})
+	quickquotev1.RegisterPromptServiceServer(s.grpc, &promptServer{prompts: cfg.Prompts, logger: cfg.Logger})
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	return s
+}
+
+// Serve accepts connections on lis until the server stops.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown reports the server as not serving to health checks, stops
+// accepting connections and waits for in-flight calls to finish. Calls
+// still running when ctx is done are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// authorizer authenticates calls with the same API keys, scopes and
+// per-key rate limits as the JSON API.
+type authorizer struct {
+	auth    Authenticator
+	limiter *ratelimit.KeyRateLimiter
+	logger  *zap.Logger
+}
+
+func (a *authorizer) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authorize checks the API key in the call's "authorization" metadata
+// against the scope the method's service needs.
+func (a *authorizer) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	serviceName, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	scope, ok := serviceScopes[serviceName]
+	if !ok {
+		return ctx, nil
+	}
+
+	token, ok := bearerToken(ctx)
+	if !ok || a.auth == nil {
+		return nil, status.Error(codes.Unauthenticated, "missing API key")
+	}
+	key, user, err := a.auth.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		a.logger.Error("failed to authenticate API key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "authentication failed")
+	}
+
+	if a.limiter != nil {
+		if result := a.limiter.Allow(key.ID, key.RateLimitPerMinute); !result.Allowed {
+			a.logger.Warn("API key rate limit exceeded",
+				zap.String("key_id", key.ID.String()),
+				zap.Int("limit", result.Limit),
+			)
+			return nil, status.Error(codes.ResourceExhausted, "API key rate limit exceeded")
+		}
+	}
+
+	if !key.HasScope(scope) {
+		a.logger.Debug("API key missing scope",
+			zap.String("key_id", key.ID.String()),
+			zap.String("required_scope", scope),
+		)
+		return nil, status.Error(codes.PermissionDenied, "API key lacks required scope: "+scope)
+	}
+	return middleware.WithUserID(ctx, user.ID), nil
+}
+
+// bearerToken extracts an API key from the "authorization" metadata.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, auth := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(auth, "Bearer ")
+		if ok && strings.HasPrefix(token, domain.APIKeyPrefix) {
+			return strings.TrimSpace(token), true
+		}
+	}
+	return "", false
+}
+
+// statusError converts a service error to a gRPC status. Errors the caller
+// caused keep their message; anything else is logged and reported without
+// details.
+func statusError(logger *zap.Logger, op string, err error) error {
+	if !apperrors.IsUserError(err) {
+		logger.Error("grpc request failed", zap.String("op", op), zap.Error(err))
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code := codes.InvalidArgument
+	switch apperrors.GetCode(err) {
+	case apperrors.CodeNotFound:
+		code = codes.NotFound
+	case apperrors.CodeConflict:
+		code = codes.Aborted
+	case apperrors.CodeAlreadyExists:
+		code = codes.AlreadyExists
+	case apperrors.CodeUnauthorized, apperrors.CodeInvalidCredentials, apperrors.CodeSessionExpired:
+		code = codes.Unauthenticated
+	case apperrors.CodeForbidden:
+		code = codes.PermissionDenied
+	case apperrors.CodeRateLimited, apperrors.CodeBudgetExceeded:
+		code = codes.ResourceExhausted
+	case apperrors.CodeCallNotReady, apperrors.CodeTranscriptMissing, apperrors.CodeComplianceBlocked:
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,192 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/grpcapi/quickquotev1"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+const (
+	testKey        = "qq_calls"
+	testLimitedKey = "qq_limited"
+)
+
+type fakeAuth struct{}
+
+func (fakeAuth) Authenticate(ctx context.Context, plaintext string) (*domain.APIKey, *domain.User, error) {
+	user := &domain.User{ID: uuid.New()}
+	switch plaintext {
+	case testKey:
+		return &domain.APIKey{ID: uuid.New(), Scopes: []string{domain.ScopeCallsRead}, RateLimitPerMinute: 100}, user, nil
+	case testLimitedKey:
+		return &domain.APIKey{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Scopes: []string{domain.ScopeCallsRead}, RateLimitPerMinute: 1}, user, nil
+	}
+	return nil, nil, service.ErrInvalidAPIKey
+}
+
+type fakeCalls struct {
+	call   *domain.Call
+	err    error
+	filter *domain.CallListFilter
+}
+
+func (f *fakeCalls) GetCall(ctx context.Context, id uuid.UUID) (*domain.Call, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if id != f.call.ID {
+		return nil, apperrors.NotFound("call")
+	}
+	return f.call, nil
+}
+
+func (f *fakeCalls) ListCallPage(ctx context.Context, filter *domain.CallListFilter, cursor string, limit int) (*service.CallPage, error) {
+	f.filter = filter
+	return &service.CallPage{Calls: []*domain.Call{f.call}, NextCursor: "next"}, nil
+}
+
+func newTestClient(t *testing.T, calls *fakeCalls) *grpc.ClientConn {
+	t.Helper()
+	limiter := ratelimit.NewKeyRateLimiter()
+	t.Cleanup(limiter.Stop)
+
+	srv := NewServer(Config{Calls: calls, Auth: fakeAuth{}, Limiter: limiter, Logger: zap.NewNop()})
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+}
+
+func TestServer_GetCall(t *testing.T) {
+	duration := 42
+	call := &domain.Call{ID: uuid.New(), Status: domain.CallStatusCompleted, DurationSeconds: &duration, CreatedAt: time.Now()}
+	client := quickquotev1.NewCallServiceClient(newTestClient(t, &fakeCalls{call: call}))
+
+	got, err := client.GetCall(withKey(testKey), &quickquotev1.GetCallRequest{Id: call.ID.String()})
+	if err != nil {
+		t.Fatalf("GetCall() error = %v", err)
+	}
+	if got.GetId() != call.ID.String() || got.GetStatus() != quickquotev1.CallStatus_CALL_STATUS_COMPLETED || got.GetDurationSeconds() != 42 {
+		t.Errorf("GetCall() = %v", got)
+	}
+	if got.CustomerId != nil || got.EndedAt != nil {
+		t.Errorf("expected unset optional fields, got %v", got)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	call := &domain.Call{ID: uuid.New()}
+	conn := newTestClient(t, &fakeCalls{call: call})
+	client := quickquotev1.NewCallServiceClient(conn)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		id       string
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{"no API key", context.Background(), call.ID.String(), codes.Unauthenticated, "missing API key"},
+		{"unknown API key", withKey("qq_unknown"), call.ID.String(), codes.Unauthenticated, "invalid API key"},
+		{"invalid ID", withKey(testKey), "nope", codes.InvalidArgument, "invalid id"},
+		{"not found", withKey(testKey), uuid.NewString(), codes.NotFound, "call not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetCall(tt.ctx, &quickquotev1.GetCallRequest{Id: tt.id})
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMsg {
+				t.Errorf("GetCall() error = %v, want %s: %s", err, tt.wantCode, tt.wantMsg)
+			}
+		})
+	}
+
+	t.Run("missing scope", func(t *testing.T) {
+		_, err := quickquotev1.NewPromptServiceClient(conn).ListPrompts(withKey(testKey), &quickquotev1.ListPromptsRequest{})
+		if st := status.Convert(err); st.Code() != codes.PermissionDenied || st.Message() != "API key lacks required scope: prompts:read" {
+			t.Errorf("ListPrompts() error = %v", err)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		client.GetCall(withKey(testLimitedKey), &quickquotev1.GetCallRequest{Id: call.ID.String()})
+		_, err := client.GetCall(withKey(testLimitedKey), &quickquotev1.GetCallRequest{Id: call.ID.String()})
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("GetCall() error = %v, want ResourceExhausted", err)
+		}
+	})
+}
+
+func TestServer_InternalErrorsHideDetails(t *testing.T) {
+	client := quickquotev1.NewCallServiceClient(newTestClient(t, &fakeCalls{err: errors.New("connection refused")}))
+
+	_, err := client.GetCall(withKey(testKey), &quickquotev1.GetCallRequest{Id: uuid.NewString()})
+	if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Errorf("GetCall() error = %v, want a generic internal error", err)
+	}
+}
+
+func TestServer_ListCallsFilters(t *testing.T) {
+	calls := &fakeCalls{call: &domain.Call{ID: uuid.New()}}
+	client := quickquotev1.NewCallServiceClient(newTestClient(t, calls))
+	customerID := uuid.New()
+
+	resp, err := client.ListCalls(withKey(testKey), &quickquotev1.ListCallsRequest{
+		Status:     quickquotev1.CallStatus_CALL_STATUS_FAILED,
+		CustomerId: customerID.String(),
+		HasQuote:   true,
+	})
+	if err != nil {
+		t.Fatalf("ListCalls() error = %v", err)
+	}
+	if len(resp.GetCalls()) != 1 || resp.GetNextPageToken() != "next" {
+		t.Errorf("ListCalls() = %v", resp)
+	}
+	f := calls.filter
+	if f.Status == nil || *f.Status != domain.CallStatusFailed || f.CustomerID == nil || *f.CustomerID != customerID || !f.HasQuote {
+		t.Errorf("filter = %+v", f)
+	}
+}
+
+func TestServer_HealthNeedsNoKey(t *testing.T) {
+	client := healthpb.NewHealthClient(newTestClient(t, &fakeCalls{}))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status = %v, want SERVING", resp.GetStatus())
+	}
+}
@@ -0,0 +1,142 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/grpcapi/quickquotev1"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// CallReader is the part of the call service the API reads from.
+type CallReader interface {
+	GetCall(ctx context.Context, id uuid.UUID) (*domain.Call, error)
+	ListCallPage(ctx context.Context, filter *domain.CallListFilter, cursor string, limit int) (*service.CallPage, error)
+}
+
+// QuoteReader is the part of the quote service the API reads from.
+type QuoteReader interface {
+	GetQuote(ctx context.Context, callID uuid.UUID) (*service.QuoteDetail, error)
+}
+
+// PromptReader is the part of the prompt service the API reads from.
+type PromptReader interface {
+	GetPrompt(ctx context.Context, id uuid.UUID) (*domain.Prompt, error)
+	ListPrompts(ctx context.Context, page, pageSize int, activeOnly bool) ([]*domain.Prompt, int, error)
+}
+
+type callServer struct {
+	quickquotev1.UnimplementedCallServiceServer
+	calls  CallReader
+	logger *zap.Logger
+}
+
+func (s *callServer) GetCall(ctx context.Context, req *quickquotev1.GetCallRequest) (*quickquotev1.Call, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, statusError(s.logger, "GetCall", err)
+	}
+	call, err := s.calls.GetCall(ctx, id)
+	if err != nil {
+		return nil, statusError(s.logger, "GetCall", err)
+	}
+	return callToProto(call), nil
+}
+
+func (s *callServer) ListCalls(ctx context.Context, req *quickquotev1.ListCallsRequest) (*quickquotev1.ListCallsResponse, error) {
+	filter := &domain.CallListFilter{
+		PhoneNumber: req.GetPhoneNumber(),
+		Provider:    req.GetProvider(),
+		HasQuote:    req.GetHasQuote(),
+	}
+	if req.GetStatus() != quickquotev1.CallStatus_CALL_STATUS_UNSPECIFIED {
+		status := callStatusFromProto(req.GetStatus())
+		filter.Status = &status
+	}
+	if req.GetCustomerId() != "" {
+		id, err := parseID("customer_id", req.GetCustomerId())
+		if err != nil {
+			return nil, statusError(s.logger, "ListCalls", err)
+		}
+		filter.CustomerID = &id
+	}
+	if req.CreatedAfter != nil {
+		t := req.GetCreatedAfter().AsTime()
+		filter.CreatedAfter = &t
+	}
+	if req.CreatedBefore != nil {
+		t := req.GetCreatedBefore().AsTime()
+		filter.CreatedBefore = &t
+	}
+
+	page, err := s.calls.ListCallPage(ctx, filter, req.GetPageToken(), int(req.GetPageSize()))
+	if err != nil {
+		return nil, statusError(s.logger, "ListCalls", err)
+	}
+	resp := &quickquotev1.ListCallsResponse{NextPageToken: page.NextCursor}
+	for _, call := range page.Calls {
+		resp.Calls = append(resp.Calls, callToProto(call))
+	}
+	return resp, nil
+}
+
+type quoteServer struct {
+	quickquotev1.UnimplementedQuoteServiceServer
+	quotes QuoteReader
+	logger *zap.Logger
+}
+
+func (s *quoteServer) GetQuote(ctx context.Context, req *quickquotev1.GetQuoteRequest) (*quickquotev1.Quote, error) {
+	callID, err := parseID("call_id", req.GetCallId())
+	if err != nil {
+		return nil, statusError(s.logger, "GetQuote", err)
+	}
+	detail, err := s.quotes.GetQuote(ctx, callID)
+	if err != nil {
+		return nil, statusError(s.logger, "GetQuote", err)
+	}
+	return quoteToProto(detail), nil
+}
+
+type promptServer struct {
+	quickquotev1.UnimplementedPromptServiceServer
+	prompts PromptReader
+	logger  *zap.Logger
+}
+
+func (s *promptServer) GetPrompt(ctx context.Context, req *quickquotev1.GetPromptRequest) (*quickquotev1.Prompt, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, statusError(s.logger, "GetPrompt", err)
+	}
+	prompt, err := s.prompts.GetPrompt(ctx, id)
+	if err != nil {
+		return nil, statusError(s.logger, "GetPrompt", err)
+	}
+	return promptToProto(prompt), nil
+}
+
+func (s *promptServer) ListPrompts(ctx context.Context, req *quickquotev1.ListPromptsRequest) (*quickquotev1.ListPromptsResponse, error) {
+	prompts, total, err := s.prompts.ListPrompts(ctx, int(req.GetPage()), int(req.GetPageSize()), req.GetActiveOnly())
+	if err != nil {
+		return nil, statusError(s.logger, "ListPrompts", err)
+	}
+	resp := &quickquotev1.ListPromptsResponse{Total: int32(total)}
+	for _, prompt := range prompts {
+		resp.Prompts = append(resp.Prompts, promptToProto(prompt))
+	}
+	return resp, nil
+}
+
+// parseID parses a UUID field of a request.
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, apperrors.ValidationFailed("invalid " + field)
+	}
+	return id, nil
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/accounting"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+const (
+	// accountingStateCookie holds the OAuth state between the connect
+	// redirect and the provider's callback.
+	accountingStateCookie = "accounting_oauth_state"
+
+	// accountingStateMaxAge is how long an admin has to grant access.
+	accountingStateMaxAge = 600
+
+	// accountingBlankRows is the number of empty project type rows offered
+	// on the item mapping form.
+	accountingBlankRows = 3
+
+	// accountingRecentSyncs is the number of syncs shown in the sync log.
+	accountingRecentSyncs = 50
+)
+
+// AccountingHandler serves the accounting connection and mapping admin page.
+type AccountingHandler struct {
+	*BaseHandler
+	accountingService *service.AccountingService
+	projectTypes      []string
+}
+
+// AccountingHandlerConfig holds configuration for AccountingHandler.
+type AccountingHandlerConfig struct {
+	Base              BaseHandlerConfig
+	AccountingService *service.AccountingService // Optional; nil shows how to configure a provider
+	ProjectTypes      []string                   // Configured project types offered for mapping
+}
+
+// accountingItemRow is one project type row on the item mapping form.
+type accountingItemRow struct {
+	ProjectType string
+	Item        string
+}
+
+// NewAccountingHandler creates a new AccountingHandler with all required dependencies.
+func NewAccountingHandler(cfg AccountingHandlerConfig) *AccountingHandler {
+	return &AccountingHandler{
+		BaseHandler:       NewBaseHandler(cfg.Base),
+		accountingService: cfg.AccountingService,
+		projectTypes:      cfg.ProjectTypes,
+	}
+}
+
+// RegisterRoutes registers accounting routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *AccountingHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/accounting", h.HandleAccountingPage)
+	r.With(h.requireService).Post("/accounting/connect", h.HandleConnect)
+	r.With(h.requireService).Get("/accounting/callback", h.HandleCallback)
+	r.With(h.requireService).Post("/accounting/disconnect", h.HandleDisconnect)
+	r.With(h.requireService).Post("/accounting/mapping", h.HandleSaveMapping)
+}
+
+// requireService rejects requests when no accounting provider is configured.
+func (h *AccountingHandler) requireService(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.accountingService == nil {
+			http.Error(w, "Accounting sync is not configured", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleAccountingPage shows the connection status, item mapping and
+// recent syncs.
+func (h *AccountingHandler) HandleAccountingPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("connected") == "1":
+		successMsg = "Accounting system connected."
+	case r.URL.Query().Get("disconnected") == "1":
+		successMsg = "Accounting system disconnected."
+	case r.URL.Query().Get("saved") == "1":
+		successMsg = "Mapping saved."
+	}
+	if h.accountingService == nil {
+		h.RenderTemplate(w, r, "accounting", map[string]interface{}{
+			"Title":     "Accounting",
+			"ActiveNav": "accounting",
+			"User":      user,
+		})
+		return
+	}
+	h.renderAccountingPage(w, r, nil, successMsg, "")
+}
+
+// HandleConnect redirects to the provider to grant access.
+func (h *AccountingHandler) HandleConnect(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		h.logger.Error("failed to generate oauth state", zap.Error(err))
+		h.renderAccountingPage(w, r, nil, "", "Failed to start connecting.")
+		return
+	}
+	state := hex.EncodeToString(b)
+	setAccountingStateCookie(w, r, state, accountingStateMaxAge)
+
+	http.Redirect(w, r, h.accountingService.AuthURL(state), http.StatusSeeOther)
+}
+
+// HandleCallback completes the connection when the provider redirects back.
+func (h *AccountingHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	cookie, err := r.Cookie(accountingStateCookie)
+	state := r.URL.Query().Get("state")
+	setAccountingStateCookie(w, r, "", -1)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		h.renderAccountingPage(w, r, nil, "", "The connection request expired or did not come from this browser. Please connect again.")
+		return
+	}
+
+	if _, err := h.accountingService.Connect(r.Context(), r.URL.Query(), user); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderAccountingPage(w, r, nil, "", "Failed to connect: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to connect accounting system", zap.Error(err))
+		h.renderAccountingPage(w, r, nil, "", "Failed to connect the accounting system.")
+		return
+	}
+
+	http.Redirect(w, r, "/accounting?connected=1", http.StatusSeeOther)
+}
+
+// HandleDisconnect forgets the stored connection.
+func (h *AccountingHandler) HandleDisconnect(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.accountingService.Disconnect(r.Context()); err != nil {
+		h.logger.Error("failed to disconnect accounting system", zap.Error(err))
+		h.renderAccountingPage(w, r, nil, "", "Failed to disconnect.")
+		return
+	}
+
+	http.Redirect(w, r, "/accounting?disconnected=1", http.StatusSeeOther)
+}
+
+// HandleSaveMapping handles POST to save the item and tax code mapping.
+func (h *AccountingHandler) HandleSaveMapping(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderAccountingPage(w, r, nil, "", "Invalid form submission.")
+		return
+	}
+
+	mapping := &domain.AccountingMapping{
+		DefaultItem:      r.FormValue("default_item"),
+		TaxCode:          r.FormValue("tax_code"),
+		ExemptTaxCode:    r.FormValue("exempt_tax_code"),
+		ProjectTypeItems: make(map[string]string),
+	}
+	types, items := r.Form["project_type"], r.Form["project_item"]
+	for i := range types {
+		if strings.TrimSpace(types[i]) != "" {
+			mapping.ProjectTypeItems[types[i]] = formIndex(items, i)
+		}
+	}
+
+	if err := h.accountingService.SaveMapping(r.Context(), mapping); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderAccountingPage(w, r, mapping, "", "Failed to save mapping: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to save accounting mapping", zap.Error(err))
+		h.renderAccountingPage(w, r, mapping, "", "Failed to save mapping.")
+		return
+	}
+
+	http.Redirect(w, r, "/accounting?saved=1", http.StatusSeeOther)
+}
+
+// renderAccountingPage renders the accounting page. mapping is the submitted
+// mapping to redisplay, or nil to show the stored one.
+func (h *AccountingHandler) renderAccountingPage(w http.ResponseWriter, r *http.Request, mapping *domain.AccountingMapping, successMsg, errMsg string) {
+	ctx := r.Context()
+	var loadErr string
+
+	connection, err := h.accountingService.Connection(ctx)
+	if err != nil {
+		h.logger.Error("failed to get accounting connection", zap.Error(err))
+		loadErr = "Failed to load the accounting connection"
+	}
+	if mapping == nil {
+		mapping, err = h.accountingService.Mapping(ctx)
+		if err != nil {
+			h.logger.Error("failed to get accounting mapping", zap.Error(err))
+			loadErr = "Failed to load the accounting mapping"
+			mapping = &domain.AccountingMapping{}
+		}
+	}
+	syncs, err := h.accountingService.RecentSyncs(ctx, accountingRecentSyncs)
+	if err != nil {
+		h.logger.Error("failed to list accounting syncs", zap.Error(err))
+		loadErr = "Failed to load recent syncs"
+	}
+	if errMsg == "" {
+		errMsg = loadErr
+	}
+
+	h.RenderTemplate(w, r, "accounting", map[string]interface{}{
+		"Title":      "Accounting",
+		"ActiveNav":  "accounting",
+		"User":       GetUserFromContext(ctx),
+		"Enabled":    true,
+		"Provider":   accounting.DisplayName(h.accountingService.Provider()),
+		"Document":   string(h.accountingService.Document()),
+		"Connection": connection,
+		"Mapping":    mapping,
+		"ItemRows":   h.itemRows(mapping),
+		"Syncs":      syncs,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	})
+}
+
+// itemRows lists the mapped project types, then configured project types
+// without an item, then blank rows to fill in.
+func (h *AccountingHandler) itemRows(mapping *domain.AccountingMapping) []accountingItemRow {
+	rows := make([]accountingItemRow, 0, len(mapping.ProjectTypeItems)+len(h.projectTypes)+accountingBlankRows)
+	for projectType, item := range mapping.ProjectTypeItems {
+		rows = append(rows, accountingItemRow{ProjectType: projectType, Item: item})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ProjectType < rows[j].ProjectType })
+
+	for _, projectType := range h.projectTypes {
+		projectType = strings.ToLower(strings.TrimSpace(projectType))
+		if _, ok := mapping.ProjectTypeItems[projectType]; projectType != "" && !ok {
+			rows = append(rows, accountingItemRow{ProjectType: projectType})
+		}
+	}
+	for i := 0; i < accountingBlankRows; i++ {
+		rows = append(rows, accountingItemRow{})
+	}
+	return rows
+}
+
+// setAccountingStateCookie stores the OAuth state for the callback to check.
+func setAccountingStateCookie(w http.ResponseWriter, r *http.Request, state string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     accountingStateCookie,
+		Value:    state,
+		Path:     "/accounting",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   isProduction() || r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
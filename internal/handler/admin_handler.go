@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -18,18 +22,26 @@ import (
 type AdminHandler struct {
 	*BaseHandler
 	blandService    *service.BlandService
+	syncService     *service.BlandSyncService
 	promptService   *service.PromptService
 	settingsService *service.SettingsService
 	quoteJobRepo    domain.QuoteJobRepository
+	callCosts       *service.CallCostService
+	blocklist       *service.BlocklistService
+	numberHealth    *service.NumberHealthService
 }
 
 // AdminHandlerConfig holds configuration for AdminHandler.
 type AdminHandlerConfig struct {
 	Base            BaseHandlerConfig
 	BlandService    *service.BlandService
+	SyncService     *service.BlandSyncService // Optional; pages read Bland lists from its cache
 	PromptService   *service.PromptService
 	SettingsService *service.SettingsService
 	QuoteJobRepo    domain.QuoteJobRepository
+	CallCosts       *service.CallCostService     // Optional; usage shows monthly costs per number
+	Blocklist       *service.BlocklistService    // Optional; adds prefix blocks and a local blocklist
+	NumberHealth    *service.NumberHealthService // Optional; phone numbers show caller ID health
 }
 
 // NewAdminHandler creates a new AdminHandler with all required dependencies.
@@ -37,9 +49,13 @@ func NewAdminHandler(cfg AdminHandlerConfig) *AdminHandler {
 	return &AdminHandler{
 		BaseHandler:     NewBaseHandler(cfg.Base),
 		blandService:    cfg.BlandService,
+		syncService:     cfg.SyncService,
 		promptService:   cfg.PromptService,
 		settingsService: cfg.SettingsService,
 		quoteJobRepo:    cfg.QuoteJobRepo,
+		callCosts:       cfg.CallCosts,
+		blocklist:       cfg.Blocklist,
+		numberHealth:    cfg.NumberHealth,
 	}
 }
 
@@ -78,6 +94,7 @@ func (h *AdminHandler) RegisterRoutes(r chi.Router) {
 	r.Post("/presets/{id}/update", h.HandlePresetUpdate)
 	r.Post("/presets/{id}/delete", h.HandlePresetDelete)
 	r.Post("/presets/{id}/default", h.HandlePresetSetDefault)
+	r.Post("/presets/{id}/versions/{version}/restore", h.HandlePresetRestore)
 	r.Post("/presets/apply", h.HandlePresetApply)
 }
 
@@ -146,6 +163,7 @@ func (h *AdminHandler) HandleSettingsUpdate(w http.ResponseWriter, r *http.Reque
 		BackgroundTrack:   r.FormValue("background_track"),
 		CustomGreeting:    r.FormValue("custom_greeting"),
 		ProjectTypes:      r.FormValue("project_types"),
+		Currency:          domain.NormalizeCurrency(r.FormValue("currency")),
 		WaitForGreeting:   r.FormValue("wait_for_greeting") == "on",
 		NoiseCancellation: r.FormValue("noise_cancellation") == "on",
 		RecordCalls:       r.FormValue("record_calls") == "on",
@@ -168,6 +186,17 @@ func (h *AdminHandler) HandleSettingsUpdate(w http.ResponseWriter, r *http.Reque
 		settings.MaxDurationMinutes = v
 	}
 
+	if settings.Currency != "" && !domain.IsValidCurrency(settings.Currency) {
+		h.RenderTemplate(w, r, "settings", map[string]interface{}{
+			"Title":     "Settings",
+			"ActiveNav": "settings",
+			"User":      user,
+			"Error":     "Currency must be a three-letter ISO 4217 code, such as USD or EUR",
+			"Settings":  settings,
+		})
+		return
+	}
+
 	if h.settingsService != nil {
 		callSettings := settingsDataToCallSettings(settings)
 		if err := h.settingsService.SaveCallSettings(ctx, callSettings); err != nil {
@@ -212,18 +241,23 @@ func (h *AdminHandler) HandlePhoneNumbersPage(w http.ResponseWriter, r *http.Req
 
 	ctx := r.Context()
 	var phoneNumbers []bland.PhoneNumber
-	var blockedNumbers []bland.BlockedNumber
+	var blockedNumbers interface{}
+	var stale *time.Time
 	var errMsg string
 
 	if h.blandService != nil {
 		var err error
-		phoneNumbers, err = h.blandService.ListPhoneNumbers(ctx, &bland.ListPhoneNumbersRequest{})
+		phoneNumbers, stale, err = h.listPhoneNumbers(ctx, wantsRefresh(r))
 		if err != nil {
 			h.logger.Error("failed to list phone numbers", zap.Error(err))
 			errMsg = "Failed to load phone numbers"
 		}
 
-		blockedNumbers, err = h.blandService.ListBlockedNumbers(ctx)
+		if h.blocklist != nil {
+			blockedNumbers, err = h.blocklist.List(ctx)
+		} else {
+			blockedNumbers, err = h.blandService.ListBlockedNumbers(ctx)
+		}
 		if err != nil {
 			h.logger.Error("failed to list blocked numbers", zap.Error(err))
 			if errMsg == "" {
@@ -232,12 +266,27 @@ func (h *AdminHandler) HandlePhoneNumbersPage(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Caller ID health by number; a failure here shouldn't hide the page
+	health := map[string]*domain.NumberHealth{}
+	if h.numberHealth != nil {
+		healths, err := h.numberHealth.List(ctx)
+		if err != nil {
+			h.logger.Warn("failed to list number health", zap.Error(err))
+		}
+		for _, nh := range healths {
+			health[nh.PhoneNumber] = nh
+		}
+	}
+
 	h.RenderTemplate(w, r, "phone_numbers", map[string]interface{}{
 		"Title":          "Phone Numbers",
 		"ActiveNav":      "phone-numbers",
 		"User":           user,
 		"PhoneNumbers":   phoneNumbers,
 		"BlockedNumbers": blockedNumbers,
+		"NumberHealth":   health,
+		"StaleSince":     stale,
+		"Queued":         r.URL.Query().Get("queued") == "1",
 		"Error":          errMsg,
 	})
 }
@@ -253,17 +302,36 @@ func (h *AdminHandler) HandleBlockNumber(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	phoneNumber := r.FormValue("phone_number")
 	reason := r.FormValue("reason")
+	direction := r.FormValue("direction")
 
 	h.logger.Info("blocking phone number",
 		zap.String("phone_number", phoneNumber),
 		zap.String("reason", reason),
 	)
 
-	if h.blandService != nil && phoneNumber != "" {
+	if h.blocklist != nil && phoneNumber != "" {
+		_, err := h.blocklist.Block(ctx, &service.BlockNumberRequest{
+			PhoneNumber: phoneNumber,
+			Reason:      reason,
+			Direction:   domain.BlockDirection(direction),
+		})
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/phone-numbers?queued=1", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to block number", zap.Error(err))
+		}
+	} else if h.blandService != nil && phoneNumber != "" {
 		_, err := h.blandService.BlockNumber(ctx, &bland.BlockNumberRequest{
 			PhoneNumber: phoneNumber,
 			Reason:      reason,
+			Direction:   direction,
 		})
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/phone-numbers?queued=1", http.StatusSeeOther)
+			return
+		}
 		if err != nil {
 			h.logger.Error("failed to block number", zap.Error(err))
 		}
@@ -285,8 +353,18 @@ func (h *AdminHandler) HandleUnblockNumber(w http.ResponseWriter, r *http.Reques
 
 	h.logger.Info("unblocking phone number", zap.String("blocked_id", blockedID))
 
-	if h.blandService != nil && blockedID != "" {
-		if err := h.blandService.UnblockNumber(ctx, blockedID); err != nil {
+	if blockedID != "" && (h.blocklist != nil || h.blandService != nil) {
+		var err error
+		if h.blocklist != nil {
+			err = h.blocklist.Unblock(ctx, blockedID)
+		} else {
+			err = h.blandService.UnblockNumber(ctx, blockedID)
+		}
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/phone-numbers?queued=1", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
 			h.logger.Error("failed to unblock number", zap.Error(err))
 		}
 	}
@@ -308,6 +386,7 @@ func (h *AdminHandler) HandleVoicesPage(w http.ResponseWriter, r *http.Request)
 
 	ctx := r.Context()
 	var voices []bland.Voice
+	var stale *time.Time
 	var errMsg string
 	currentVoice := "maya"
 	voiceSettings := VoiceSettingsData{
@@ -332,7 +411,7 @@ func (h *AdminHandler) HandleVoicesPage(w http.ResponseWriter, r *http.Request)
 
 	if h.blandService != nil {
 		var err error
-		voices, err = h.blandService.ListVoices(ctx)
+		voices, stale, err = h.listVoices(ctx, wantsRefresh(r))
 		if err != nil {
 			h.logger.Error("failed to list voices", zap.Error(err))
 			errMsg = "Failed to load voices"
@@ -348,6 +427,7 @@ func (h *AdminHandler) HandleVoicesPage(w http.ResponseWriter, r *http.Request)
 		"Voices":        voices,
 		"CurrentVoice":  currentVoice,
 		"VoiceSettings": voiceSettings,
+		"StaleSince":    stale,
 		"Error":         errMsg,
 		"Success":       success,
 	})
@@ -540,7 +620,7 @@ func (h *AdminHandler) HandleUsagePage(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		numbers, err := h.blandService.ListPhoneNumbers(ctx, &bland.ListPhoneNumbersRequest{})
+		numbers, _, err := h.listPhoneNumbers(ctx, wantsRefresh(r))
 		if err != nil {
 			h.logger.Warn("failed to get phone numbers for count", zap.Error(err))
 		} else {
@@ -559,6 +639,16 @@ func (h *AdminHandler) HandleUsagePage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	var callCosts *service.CallCostRollups
+	if h.callCosts != nil {
+		rollups, err := h.callCosts.MonthlyRollups(ctx, service.DefaultCostRollupMonths)
+		if err != nil {
+			h.logger.Warn("failed to load call cost rollups", zap.Error(err))
+		} else {
+			callCosts = rollups
+		}
+	}
+
 	h.RenderTemplate(w, r, "usage", map[string]interface{}{
 		"Title":      "Usage",
 		"ActiveNav":  "usage",
@@ -567,8 +657,10 @@ func (h *AdminHandler) HandleUsagePage(w http.ResponseWriter, r *http.Request) {
 		"Pricing":    pricing,
 		"DailyUsage": dailyUsage,
 		"Alerts":     alerts,
+		"Queued":     r.URL.Query().Get("queued") == "1",
 		"Error":      errMsg,
 		"QuoteJobs":  jobStats,
+		"CallCosts":  callCosts,
 	})
 }
 
@@ -589,19 +681,30 @@ func (h *AdminHandler) HandleUsageLimitsUpdate(w http.ResponseWriter, r *http.Re
 		zap.Float64("minute_limit", minuteLimit),
 	)
 
+	queued := false
 	if h.blandService != nil {
 		if costLimit > 0 {
-			if err := h.blandService.SetUsageLimit(ctx, "monthly_cost", costLimit); err != nil {
+			err := h.blandService.SetUsageLimit(ctx, "monthly_cost", costLimit)
+			if h.writeQueued(err) {
+				queued = true
+			} else if err != nil {
 				h.logger.Error("failed to set cost limit", zap.Error(err))
 			}
 		}
 		if minuteLimit > 0 {
-			if err := h.blandService.SetUsageLimit(ctx, "monthly_minutes", minuteLimit); err != nil {
+			err := h.blandService.SetUsageLimit(ctx, "monthly_minutes", minuteLimit)
+			if h.writeQueued(err) {
+				queued = true
+			} else if err != nil {
 				h.logger.Error("failed to set minute limit", zap.Error(err))
 			}
 		}
 	}
 
+	if queued {
+		http.Redirect(w, r, "/usage?queued=1", http.StatusSeeOther)
+		return
+	}
 	http.Redirect(w, r, "/usage", http.StatusSeeOther)
 }
 
@@ -619,11 +722,12 @@ func (h *AdminHandler) HandleKnowledgeBasesPage(w http.ResponseWriter, r *http.R
 
 	ctx := r.Context()
 	var knowledgeBases []bland.KnowledgeBase
+	var stale *time.Time
 	var errMsg string
 
 	if h.blandService != nil {
 		var err error
-		knowledgeBases, err = h.blandService.ListKnowledgeBases(ctx)
+		knowledgeBases, stale, err = h.listKnowledgeBases(ctx, wantsRefresh(r))
 		if err != nil {
 			h.logger.Error("failed to list knowledge bases", zap.Error(err))
 			errMsg = "Failed to load knowledge bases"
@@ -637,6 +741,8 @@ func (h *AdminHandler) HandleKnowledgeBasesPage(w http.ResponseWriter, r *http.R
 		"ActiveNav":      "knowledge-bases",
 		"User":           user,
 		"KnowledgeBases": knowledgeBases,
+		"StaleSince":     stale,
+		"Queued":         r.URL.Query().Get("queued") == "1",
 		"Error":          errMsg,
 		"Success":        success,
 	})
@@ -676,6 +782,10 @@ func (h *AdminHandler) HandleKnowledgeBaseCreate(w http.ResponseWriter, r *http.
 			Description: description,
 			Text:        text,
 		})
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/knowledge-bases?queued=1", http.StatusSeeOther)
+			return
+		}
 		if err != nil {
 			h.logger.Error("failed to create knowledge base", zap.Error(err))
 			h.RenderTemplate(w, r, "knowledge_bases", map[string]interface{}{
@@ -686,6 +796,7 @@ func (h *AdminHandler) HandleKnowledgeBaseCreate(w http.ResponseWriter, r *http.
 			})
 			return
 		}
+		h.refreshCache(ctx, domain.BlandEntityKnowledgeBase)
 	}
 
 	http.Redirect(w, r, "/knowledge-bases?success=1", http.StatusSeeOther)
@@ -727,8 +838,15 @@ func (h *AdminHandler) HandleKnowledgeBaseUpdate(w http.ResponseWriter, r *http.
 			req.Text = &text
 		}
 
-		if err := h.blandService.UpdateKnowledgeBase(ctx, vectorID, req); err != nil {
+		err := h.blandService.UpdateKnowledgeBase(ctx, vectorID, req)
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/knowledge-bases?queued=1", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
 			h.logger.Error("failed to update knowledge base", zap.Error(err))
+		} else {
+			h.refreshCache(ctx, domain.BlandEntityKnowledgeBase)
 		}
 	}
 
@@ -749,8 +867,15 @@ func (h *AdminHandler) HandleKnowledgeBaseDelete(w http.ResponseWriter, r *http.
 	h.logger.Info("deleting knowledge base", zap.String("vector_id", vectorID))
 
 	if h.blandService != nil && vectorID != "" {
-		if err := h.blandService.DeleteKnowledgeBase(ctx, vectorID); err != nil {
+		err := h.blandService.DeleteKnowledgeBase(ctx, vectorID)
+		if h.writeQueued(err) {
+			http.Redirect(w, r, "/knowledge-bases?queued=1", http.StatusSeeOther)
+			return
+		}
+		if err != nil {
 			h.logger.Error("failed to delete knowledge base", zap.Error(err))
+		} else {
+			h.refreshCache(ctx, domain.BlandEntityKnowledgeBase)
 		}
 	}
 
@@ -832,7 +957,7 @@ func (h *AdminHandler) HandlePresetsPage(w http.ResponseWriter, r *http.Request)
 
 	if h.blandService != nil {
 		var err error
-		phoneNumbers, err = h.blandService.ListPhoneNumbers(ctx, &bland.ListPhoneNumbersRequest{})
+		phoneNumbers, _, err = h.listPhoneNumbers(ctx, wantsRefresh(r))
 		if err != nil {
 			h.logger.Warn("failed to list phone numbers", zap.Error(err))
 		}
@@ -845,6 +970,7 @@ func (h *AdminHandler) HandlePresetsPage(w http.ResponseWriter, r *http.Request)
 		"Presets":        presets,
 		"TotalPresets":   totalPresets,
 		"PhoneNumbers":   phoneNumbers,
+		"Queued":         r.URL.Query().Get("queued") == "1",
 		"Error":          errMsg,
 		"Success":        successMsg != "",
 		"SuccessMessage": successMsg,
@@ -935,39 +1061,69 @@ func (h *AdminHandler) HandlePresetEditPage(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	preset := &PresetData{
-		ID:                prompt.ID.String(),
-		Name:              prompt.Name,
-		Description:       prompt.Description,
-		Task:              prompt.Task,
-		Voice:             prompt.Voice,
-		Language:          prompt.Language,
-		Model:             prompt.Model,
-		FirstSentence:     prompt.FirstSentence,
-		WaitForGreeting:   prompt.WaitForGreeting,
-		NoiseCancellation: prompt.NoiseCancellation,
-		Record:            prompt.Record,
-		IsDefault:         prompt.IsDefault,
-		IsActive:          prompt.IsActive,
-	}
-	if prompt.Temperature != nil {
-		preset.Temperature = *prompt.Temperature
+	var errMsg, successMsg string
+	if restored := r.URL.Query().Get("restored"); restored != "" {
+		successMsg = "Restored version " + restored + ". The content it replaced was kept as a new version."
 	}
-	if prompt.InterruptionThreshold != nil {
-		preset.InterruptionThreshold = *prompt.InterruptionThreshold
+
+	versions, err := h.promptService.ListPromptVersions(ctx, id)
+	if err != nil {
+		h.logger.Error("failed to list preset versions", zap.Error(err))
+		errMsg = "Failed to load the preset's history"
 	}
-	if prompt.MaxDuration != nil {
-		preset.MaxDuration = *prompt.MaxDuration
+
+	var compare *PresetComparison
+	if n, err := strconv.Atoi(r.URL.Query().Get("compare")); err == nil {
+		v, fields, err := h.promptService.GetPromptVersion(ctx, id, n)
+		if err != nil {
+			h.logger.Warn("failed to compare preset version", zap.Int("version", n), zap.Error(err))
+			errMsg = "Version " + strconv.Itoa(n) + " could not be loaded"
+		} else {
+			compare = newPresetComparison(v, fields)
+		}
 	}
 
 	h.RenderTemplate(w, r, "preset_edit", map[string]interface{}{
 		"Title":     "Edit Preset",
 		"ActiveNav": "presets",
 		"User":      user,
-		"Preset":    preset,
+		"Preset":    promptToPresetData(prompt),
+		"Versions":  versions,
+		"Compare":   compare,
+		"Error":     errMsg,
+		"Success":   successMsg,
 	})
 }
 
+// HandlePresetRestore handles POST to restore an earlier version of a preset.
+func (h *AdminHandler) HandlePresetRestore(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	presetID := chi.URLParam(r, "id")
+	id, err := uuid.Parse(presetID)
+	if err != nil || h.promptService == nil {
+		http.Redirect(w, r, "/presets", http.StatusSeeOther)
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		http.Redirect(w, r, "/presets/"+presetID+"/edit", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := h.promptService.RestorePromptVersion(r.Context(), id, version, user.Email); err != nil {
+		h.logger.Error("failed to restore preset version", zap.Int("version", version), zap.Error(err))
+		http.Redirect(w, r, "/presets/"+presetID+"/edit?compare="+strconv.Itoa(version), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/presets/"+presetID+"/edit?restored="+strconv.Itoa(version), http.StatusSeeOther)
+}
+
 // HandlePresetUpdate handles POST to update a preset.
 func (h *AdminHandler) HandlePresetUpdate(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r.Context())
@@ -1011,6 +1167,7 @@ func (h *AdminHandler) HandlePresetUpdate(w http.ResponseWriter, r *http.Request
 		WaitForGreeting:   &waitForGreeting,
 		NoiseCancellation: &noiseCancellation,
 		Record:            &record,
+		ChangedBy:         user.Email,
 	}
 
 	if temp, err := strconv.ParseFloat(r.FormValue("temperature"), 64); err == nil {
@@ -1142,6 +1299,10 @@ func (h *AdminHandler) HandlePresetApply(w http.ResponseWriter, r *http.Request)
 	}
 
 	_, err = h.blandService.ConfigureInboundAgent(ctx, phoneNumber, config)
+	if h.writeQueued(err) {
+		http.Redirect(w, r, "/presets?queued=1", http.StatusSeeOther)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to apply preset to phone number",
 			zap.Error(err),
@@ -1157,6 +1318,8 @@ func (h *AdminHandler) HandlePresetApply(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.refreshCache(ctx, domain.BlandEntityPhoneNumber)
+
 	h.logger.Info("preset applied to phone number",
 		zap.String("preset_name", prompt.Name),
 		zap.String("phone_number", phoneNumber),
@@ -1165,6 +1328,71 @@ func (h *AdminHandler) HandlePresetApply(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, "/presets?applied=1", http.StatusSeeOther)
 }
 
+// ===============================================
+// Bland Lists
+// ===============================================
+
+// listPhoneNumbers lists phone numbers from the local cache when it is
+// enabled, or from Bland. refresh syncs the cache first; pages pass it
+// through from ?refresh=1. The returned time is set when Bland couldn't be
+// reached and the list is the cache as of that time.
+func (h *AdminHandler) listPhoneNumbers(ctx context.Context, refresh bool) ([]bland.PhoneNumber, *time.Time, error) {
+	if h.syncService != nil {
+		numbers, state, err := h.syncService.ListPhoneNumbers(ctx, refresh)
+		return numbers, staleSince(state), err
+	}
+	numbers, err := h.blandService.ListPhoneNumbers(ctx, &bland.ListPhoneNumbersRequest{})
+	return numbers, nil, err
+}
+
+// listVoices lists voices from the local cache when it is enabled, or from
+// Bland.
+func (h *AdminHandler) listVoices(ctx context.Context, refresh bool) ([]bland.Voice, *time.Time, error) {
+	if h.syncService != nil {
+		voices, state, err := h.syncService.ListVoices(ctx, refresh)
+		return voices, staleSince(state), err
+	}
+	voices, err := h.blandService.ListVoices(ctx)
+	return voices, nil, err
+}
+
+// listKnowledgeBases lists knowledge bases from the local cache when it is
+// enabled, or from Bland.
+func (h *AdminHandler) listKnowledgeBases(ctx context.Context, refresh bool) ([]bland.KnowledgeBase, *time.Time, error) {
+	if h.syncService != nil {
+		kbs, state, err := h.syncService.ListKnowledgeBases(ctx, refresh)
+		return kbs, staleSince(state), err
+	}
+	kbs, err := h.blandService.ListKnowledgeBases(ctx)
+	return kbs, nil, err
+}
+
+// staleSince returns when a stale cache was last synced, or nil if the
+// cache is current.
+func staleSince(state *domain.BlandSyncState) *time.Time {
+	if !state.IsStale() {
+		return nil
+	}
+	return state.SyncedAt
+}
+
+// writeQueued reports whether err means a change was queued because Bland
+// is unreachable, logging it for the record.
+func (h *AdminHandler) writeQueued(err error) bool {
+	if !errors.Is(err, service.ErrBlandWriteQueued) {
+		return false
+	}
+	h.logger.Warn("bland unreachable, change queued", zap.Error(err))
+	return true
+}
+
+// refreshCache resyncs a kind after a change made through Bland.
+func (h *AdminHandler) refreshCache(ctx context.Context, kind domain.BlandEntityKind) {
+	if h.syncService != nil {
+		h.syncService.Refresh(ctx, kind)
+	}
+}
+
 // ===============================================
 // Helper Types and Functions
 // ===============================================
@@ -1189,6 +1417,7 @@ type SettingsData struct {
 	QualityPreset         string
 	CustomGreeting        string
 	ProjectTypes          string
+	Currency              string
 }
 
 // UsageData holds data for the usage page.
@@ -1258,6 +1487,47 @@ type PresetData struct {
 	IsActive              bool
 }
 
+// PresetComparison shows an earlier preset version beside the current one.
+type PresetComparison struct {
+	Version   int
+	CreatedAt time.Time
+	Fields    []PresetFieldComparison
+}
+
+// PresetFieldComparison is one row of a PresetComparison.
+type PresetFieldComparison struct {
+	Field   string
+	Before  string
+	After   string
+	Changed bool
+}
+
+// newPresetComparison formats a prompt version diff for display. JSON
+// strings are shown unquoted and unset values as empty.
+func newPresetComparison(v *domain.PromptVersion, fields []domain.PromptFieldDiff) *PresetComparison {
+	display := func(raw json.RawMessage) string {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+		if string(raw) == "null" {
+			return ""
+		}
+		return string(raw)
+	}
+
+	c := &PresetComparison{Version: v.Version, CreatedAt: v.CreatedAt}
+	for _, f := range fields {
+		c.Fields = append(c.Fields, PresetFieldComparison{
+			Field:   f.Field,
+			Before:  display(f.Before),
+			After:   display(f.After),
+			Changed: f.Changed,
+		})
+	}
+	return c
+}
+
 // defaultSettingsData returns default settings data.
 func defaultSettingsData() *SettingsData {
 	return &SettingsData{
@@ -1302,6 +1572,7 @@ func callSettingsToSettingsData(cs *domain.CallSettings) *SettingsData {
 		QualityPreset:         cs.QualityPreset,
 		CustomGreeting:        cs.CustomGreeting,
 		ProjectTypes:          strings.Join(cs.ProjectTypes, ","),
+		Currency:              cs.Currency,
 	}
 }
 
@@ -1336,6 +1607,7 @@ func settingsDataToCallSettings(sd *SettingsData) *domain.CallSettings {
 		RecordCalls:           sd.RecordCalls,
 		QualityPreset:         sd.QualityPreset,
 		CustomGreeting:        sd.CustomGreeting,
+		Currency:              sd.Currency,
 	}
 }
 
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// AnalyticsAPIHandler serves aggregated call and quote metrics for dashboard charts.
+type AnalyticsAPIHandler struct {
+	analyticsService *service.AnalyticsService
+	logger           *zap.Logger
+}
+
+// NewAnalyticsAPIHandler creates a new AnalyticsAPIHandler.
+func NewAnalyticsAPIHandler(analyticsService *service.AnalyticsService, logger *zap.Logger) *AnalyticsAPIHandler {
+	return &AnalyticsAPIHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// RegisterRoutes registers analytics API routes.
+func (h *AnalyticsAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/analytics", func(r chi.Router) {
+		r.Get("/", h.GetAnalytics)
+		r.Get("/kpis", h.GetKPIs)
+		r.Get("/calls-per-day", h.GetCallsPerDay)
+		r.Get("/prompts", h.GetPromptMetrics)
+		r.Get("/tags", h.GetTagCounts)
+		r.Get("/by-prompt", h.GetPromptBreakdown)
+		r.Get("/by-number", h.GetNumberBreakdown)
+	})
+}
+
+// GetAnalytics handles GET /api/v1/analytics
+// @Summary Get dashboard analytics
+// @Description Returns call and quote KPIs, calls per day and average duration by prompt. The range defaults to the last 30 days and may be at most 366 days.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} service.CallAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics [get]
+func (h *AnalyticsAPIHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsService.GetAnalytics(r.Context(), filter)
+	if err != nil {
+		h.respondAnalyticsError(w, "failed to get analytics", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, analytics)
+}
+
+// GetKPIs handles GET /api/v1/analytics/kpis
+// @Summary Get call and quote KPIs
+// @Description Returns call counts, answer rate, quote conversion rate, average call duration and average quote value
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} domain.CallKPIs
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/kpis [get]
+func (h *AnalyticsAPIHandler) GetKPIs(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	kpis, err := h.analyticsService.GetKPIs(r.Context(), filter)
+	if err != nil {
+		h.respondAnalyticsError(w, "failed to get call KPIs", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, kpis)
+}
+
+// GetCallsPerDay handles GET /api/v1/analytics/calls-per-day
+// @Summary Get calls per day
+// @Description Returns total, completed and quoted calls for every UTC day in the range, including days without calls
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/calls-per-day [get]
+func (h *AnalyticsAPIHandler) GetCallsPerDay(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	days, err := h.analyticsService.GetCallsPerDay(r.Context(), filter)
+	if err != nil {
+		h.respondAnalyticsError(w, "failed to get calls per day", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"from": filter.From,
+		"to":   filter.To,
+		"days": days,
+	})
+}
+
+// GetPromptMetrics handles GET /api/v1/analytics/prompts
+// @Summary Get call metrics by prompt
+// @Description Returns call counts and average completed call duration for each prompt, busiest first. Calls without a known prompt are grouped under a null prompt_id.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/prompts [get]
+func (h *AnalyticsAPIHandler) GetPromptMetrics(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	prompts, err := h.analyticsService.GetPromptMetrics(r.Context(), filter)
+	if err != nil {
+		h.respondAnalyticsError(w, "failed to get prompt metrics", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"from":    filter.From,
+		"to":      filter.To,
+		"prompts": prompts,
+	})
+}
+
+// GetTagCounts handles GET /api/v1/analytics/tags
+// @Summary Get call tag counts
+// @Description Returns how many calls were tagged with each sentiment, intent and urgency, and how many calls have each disposition. Calls not yet tagged are left out of the tag counts.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Param sentiment query string false "Only calls tagged with this sentiment"
+// @Param intent query string false "Only calls tagged with this intent"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/tags [get]
+func (h *AnalyticsAPIHandler) GetTagCounts(w http.ResponseWriter, r *http.Request) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	tags, err := h.analyticsService.GetTagCounts(r.Context(), filter)
+	if err != nil {
+		h.respondAnalyticsError(w, "failed to get tag counts", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"from": filter.From,
+		"to":   filter.To,
+		"tags": tags,
+	})
+}
+
+// GetPromptBreakdown handles GET /api/v1/analytics/by-prompt
+// @Summary Compare prompts
+// @Description Returns answer rate, quote rate, quote acceptance rate and average call length for each prompt, busiest first, from the nightly rollups. The range is widened to whole UTC days; today is not rolled up yet.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/by-prompt [get]
+func (h *AnalyticsAPIHandler) GetPromptBreakdown(w http.ResponseWriter, r *http.Request) {
+	h.breakdown(w, r, domain.AnalyticsDimensionPrompt, "prompts")
+}
+
+// GetNumberBreakdown handles GET /api/v1/analytics/by-number
+// @Summary Compare inbound numbers
+// @Description Returns answer rate, quote rate, quote acceptance rate and average call length for each number callers dialed, busiest first, from the nightly rollups. The range is widened to whole UTC days; today is not rolled up yet.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param provider query string false "Only calls handled by this voice provider"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/analytics/by-number [get]
+func (h *AnalyticsAPIHandler) GetNumberBreakdown(w http.ResponseWriter, r *http.Request) {
+	h.breakdown(w, r, domain.AnalyticsDimensionNumber, "numbers")
+}
+
+func (h *AnalyticsAPIHandler) breakdown(w http.ResponseWriter, r *http.Request, dimension domain.AnalyticsDimension, key string) {
+	filter, ok := h.filter(w, r)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.analyticsService.GetBreakdown(r.Context(), dimension, filter)
+	if err != nil {
+		h.respondAnalyticsError(w, fmt.Sprintf("failed to get %s breakdown", dimension), err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"from": filter.From,
+		"to":   filter.To,
+		key:    metrics,
+	})
+}
+
+func (h *AnalyticsAPIHandler) filter(w http.ResponseWriter, r *http.Request) (*domain.AnalyticsFilter, bool) {
+	filter, err := parseAnalyticsFilter(r.URL.Query())
+	if err != nil {
+		APIError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return filter, true
+}
+
+func (h *AnalyticsAPIHandler) respondAnalyticsError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
+
+// parseAnalyticsFilter builds an analytics filter from query parameters.
+// Dates are parsed like export dates; a plain "to" date is inclusive.
+func parseAnalyticsFilter(query url.Values) (*domain.AnalyticsFilter, error) {
+	filter := &domain.AnalyticsFilter{
+		Provider: strings.TrimSpace(query.Get("provider")),
+	}
+
+	if sentiment := strings.TrimSpace(query.Get("sentiment")); sentiment != "" {
+		s, err := domain.ParseCallSentiment(sentiment)
+		if err != nil {
+			return nil, err
+		}
+		filter.Sentiment = s
+	}
+	if intent := strings.TrimSpace(query.Get("intent")); intent != "" {
+		i, err := domain.ParseCallIntent(intent)
+		if err != nil {
+			return nil, err
+		}
+		filter.Intent = i
+	}
+
+	if from := strings.TrimSpace(query.Get("from")); from != "" {
+		t, _, err := parseExportDate(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = t
+	}
+
+	if to := strings.TrimSpace(query.Get("to")); to != "" {
+		t, dateOnly, err := parseExportDate(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.To = t
+	}
+
+	return filter, nil
+}
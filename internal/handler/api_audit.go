@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+)
+
+// AuditAPIHandler serves the stored audit log. All of its endpoints are
+// admin only.
+type AuditAPIHandler struct {
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewAuditAPIHandler creates a new AuditAPIHandler.
+func NewAuditAPIHandler(auditLogger *audit.Logger, logger *zap.Logger) *AuditAPIHandler {
+	return &AuditAPIHandler{
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// ListAuditEventsResponse is a page of audit events.
+type ListAuditEventsResponse struct {
+	Events   []*audit.Event `json:"events"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// RegisterRoutes registers audit API routes.
+func (h *AuditAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/audit", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.ListEvents)
+	})
+}
+
+// ListEvents handles GET /api/v1/audit
+// @Summary List audit events
+// @Description Searches the audit log, newest first. Change events carry the resource's state before and after. Admin only.
+// @Tags audit
+// @Produce json
+// @Param type query string false "Event type, or a prefix ending in '.' such as 'admin.'"
+// @Param actor_id query string false "Only events by this actor"
+// @Param resource_type query string false "Only events on this kind of resource"
+// @Param resource_id query string false "Only events on this resource"
+// @Param request_id query string false "Only events from this request (correlation ID)"
+// @Param outcome query string false "Only events with this outcome (success, failure)"
+// @Param since query string false "Events at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param until query string false "Events before this time (RFC 3339, or YYYY-MM-DD inclusive)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(50)
+// @Success 200 {object} ListAuditEventsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/audit [get]
+func (h *AuditAPIHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseAuditFilter(r.URL.Query())
+	if err != nil {
+		APIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	events, total, err := h.auditLogger.Search(r.Context(), filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.logger.Error("failed to list audit events", zap.Error(err))
+		APIServiceError(w, err, "failed to list audit events")
+		return
+	}
+	if events == nil {
+		events = []*audit.Event{}
+	}
+
+	JSON(w, http.StatusOK, ListAuditEventsResponse{
+		Events:   events,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// parseAuditFilter builds an audit event filter from query parameters. Dates
+// accept RFC 3339 timestamps or YYYY-MM-DD; a plain "until" date is inclusive.
+func parseAuditFilter(query url.Values) (*audit.Filter, error) {
+	filter := &audit.Filter{
+		Type:         audit.EventType(strings.TrimSpace(query.Get("type"))),
+		ActorID:      strings.TrimSpace(query.Get("actor_id")),
+		ResourceType: strings.TrimSpace(query.Get("resource_type")),
+		ResourceID:   strings.TrimSpace(query.Get("resource_id")),
+		RequestID:    strings.TrimSpace(query.Get("request_id")),
+		Outcome:      strings.TrimSpace(query.Get("outcome")),
+	}
+
+	if since := strings.TrimSpace(query.Get("since")); since != "" {
+		t, _, err := parseExportDate(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		filter.Since = &t
+	}
+
+	if until := strings.TrimSpace(query.Get("until")); until != "" {
+		t, dateOnly, err := parseExportDate(until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.Until = &t
+	}
+
+	if filter.Since != nil && filter.Until != nil && !filter.Until.After(*filter.Since) {
+		return nil, fmt.Errorf("until must be after since")
+	}
+
+	return filter, nil
+}
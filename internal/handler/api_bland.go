@@ -2,21 +2,32 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/service"
 	"github.com/jkindrix/quickquote/internal/validation"
 )
 
 // BlandAPIHandler handles Bland AI management API endpoints.
 type BlandAPIHandler struct {
-	blandService *service.BlandService
-	logger       *zap.Logger
+	blandService   *service.BlandService
+	syncService    *service.BlandSyncService
+	versionService *service.PathwayVersionService
+	provisioning   *service.NumberProvisioningService
+	blocklist      *service.BlocklistService
+	numberHealth   *service.NumberHealthService
+	logger         *zap.Logger
 }
 
 // NewBlandAPIHandler creates a new BlandAPIHandler.
@@ -27,6 +38,35 @@ func NewBlandAPIHandler(blandService *service.BlandService, logger *zap.Logger)
 	}
 }
 
+// SetSyncService serves voice, persona, pathway, knowledge base and phone
+// number lists from the local cache, and refreshes the cache after changes
+// made through this API.
+func (h *BlandAPIHandler) SetSyncService(syncService *service.BlandSyncService) {
+	h.syncService = syncService
+}
+
+// SetPathwayVersionService enables pathway version history, diffs and
+// rollback, and snapshots pathways before they are published.
+func (h *BlandAPIHandler) SetPathwayVersionService(versionService *service.PathwayVersionService) {
+	h.versionService = versionService
+}
+
+// SetNumberProvisioningService enables buying and setting up numbers in bulk.
+func (h *BlandAPIHandler) SetNumberProvisioningService(provisioning *service.NumberProvisioningService) {
+	h.provisioning = provisioning
+}
+
+// SetBlocklistService serves blocked numbers from the local blocklist,
+// which adds prefix blocks and CSV import and export.
+func (h *BlandAPIHandler) SetBlocklistService(blocklist *service.BlocklistService) {
+	h.blocklist = blocklist
+}
+
+// SetNumberHealthService enables caller ID health scores.
+func (h *BlandAPIHandler) SetNumberHealthService(numberHealth *service.NumberHealthService) {
+	h.numberHealth = numberHealth
+}
+
 // RegisterRoutes registers all Bland API routes.
 func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/bland", func(r chi.Router) {
@@ -65,6 +105,11 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 			r.Patch("/{pathwayID}", h.UpdatePathway)
 			r.Delete("/{pathwayID}", h.DeletePathway)
 			r.Post("/{pathwayID}/publish", h.PublishPathway)
+			r.Get("/{pathwayID}/versions", h.ListPathwayVersions)
+			r.Post("/{pathwayID}/versions", h.CreatePathwayVersion)
+			r.Get("/{pathwayID}/versions/{version}", h.GetPathwayVersion)
+			r.Post("/{pathwayID}/versions/{version}/rollback", h.RollbackPathway)
+			r.Get("/{pathwayID}/diff", h.DiffPathwayVersions)
 		})
 
 		// Memory
@@ -97,6 +142,7 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 		r.Route("/tools", func(r chi.Router) {
 			r.Get("/", h.ListTools)
 			r.Post("/", h.CreateTool)
+			r.Post("/schedule-callback", h.SetupScheduleCallbackTool)
 			r.Get("/{toolID}", h.GetTool)
 			r.Patch("/{toolID}", h.UpdateTool)
 			r.Delete("/{toolID}", h.DeleteTool)
@@ -108,6 +154,9 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 			r.Get("/", h.ListPhoneNumbers)
 			r.Get("/available", h.SearchAvailableNumbers)
 			r.Post("/purchase", h.PurchaseNumber)
+			r.Post("/bulk-purchase", h.BulkPurchaseNumbers)
+			r.Get("/health", h.ListNumberHealth)
+			r.Post("/health/check", h.CheckNumberHealth)
 			r.Get("/{numberID}", h.GetPhoneNumber)
 			r.Patch("/{numberID}", h.UpdatePhoneNumber)
 			r.Delete("/{numberID}", h.ReleasePhoneNumber)
@@ -115,6 +164,8 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 			// Blocked numbers
 			r.Get("/blocked", h.ListBlockedNumbers)
 			r.Post("/blocked", h.BlockNumber)
+			r.Get("/blocked/export", h.ExportBlockedNumbers)
+			r.Post("/blocked/import", h.ImportBlockedNumbers)
 			r.Delete("/blocked/{blockedID}", h.UnblockNumber)
 		})
 
@@ -195,6 +246,10 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 			r.Patch("/members/{memberID}", h.UpdateMemberRole)
 		})
 
+		// Local cache
+		r.Get("/sync", h.GetSyncStatus)
+		r.Post("/sync", h.SyncEntities)
+
 		// Circuit breaker stats
 		r.Get("/health", h.GetCircuitBreakerStats)
 	})
@@ -206,10 +261,21 @@ func (h *BlandAPIHandler) RegisterRoutes(r chi.Router) {
 
 // ListVoices handles GET /api/v1/bland/voices
 func (h *BlandAPIHandler) ListVoices(w http.ResponseWriter, r *http.Request) {
+	if h.syncService != nil {
+		voices, state, err := h.syncService.ListVoices(r.Context(), wantsRefresh(r))
+		if err != nil {
+			h.logger.Error("failed to list voices", zap.Error(err))
+			APIServiceError(w, err, "failed to list voices")
+			return
+		}
+		h.respondCached(w, r, state, voices)
+		return
+	}
+
 	voices, err := h.blandService.ListVoices(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list voices", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list voices")
+		APIServiceError(w, err, "failed to list voices")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, voices)
@@ -230,17 +296,17 @@ func (h *BlandAPIHandler) GetVoice(w http.ResponseWriter, r *http.Request) {
 // CloneVoice handles POST /api/v1/bland/voices/clone
 func (h *BlandAPIHandler) CloneVoice(w http.ResponseWriter, r *http.Request) {
 	var req bland.CloneVoiceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.CloneVoice(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to clone voice", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to clone voice: "+err.Error())
+		APIServiceError(w, err, "failed to clone voice: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityVoice)
 	h.respondJSON(w, http.StatusCreated, result)
 }
 
@@ -248,15 +314,14 @@ func (h *BlandAPIHandler) CloneVoice(w http.ResponseWriter, r *http.Request) {
 func (h *BlandAPIHandler) GenerateVoiceSample(w http.ResponseWriter, r *http.Request) {
 	voiceID := chi.URLParam(r, "voiceID")
 	var req bland.GenerateSampleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.GenerateVoiceSample(r.Context(), voiceID, &req)
 	if err != nil {
 		h.logger.Error("failed to generate sample", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to generate sample: "+err.Error())
+		APIServiceError(w, err, "failed to generate sample: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, result)
@@ -267,9 +332,10 @@ func (h *BlandAPIHandler) DeleteVoice(w http.ResponseWriter, r *http.Request) {
 	voiceID := chi.URLParam(r, "voiceID")
 	if err := h.blandService.DeleteVoice(r.Context(), voiceID); err != nil {
 		h.logger.Error("failed to delete voice", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete voice")
+		APIServiceError(w, err, "failed to delete voice")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityVoice)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -279,10 +345,21 @@ func (h *BlandAPIHandler) DeleteVoice(w http.ResponseWriter, r *http.Request) {
 
 // ListPersonas handles GET /api/v1/bland/personas
 func (h *BlandAPIHandler) ListPersonas(w http.ResponseWriter, r *http.Request) {
+	if h.syncService != nil {
+		personas, state, err := h.syncService.ListPersonas(r.Context(), wantsRefresh(r))
+		if err != nil {
+			h.logger.Error("failed to list personas", zap.Error(err))
+			APIServiceError(w, err, "failed to list personas")
+			return
+		}
+		h.respondCached(w, r, state, personas)
+		return
+	}
+
 	personas, err := h.blandService.ListPersonas(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list personas", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list personas")
+		APIServiceError(w, err, "failed to list personas")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, personas)
@@ -303,17 +380,17 @@ func (h *BlandAPIHandler) GetPersona(w http.ResponseWriter, r *http.Request) {
 // CreatePersona handles POST /api/v1/bland/personas
 func (h *BlandAPIHandler) CreatePersona(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreatePersonaRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	persona, err := h.blandService.CreatePersona(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create persona", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create persona: "+err.Error())
+		APIServiceError(w, err, "failed to create persona: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPersona)
 	h.respondJSON(w, http.StatusCreated, persona)
 }
 
@@ -321,17 +398,17 @@ func (h *BlandAPIHandler) CreatePersona(w http.ResponseWriter, r *http.Request)
 func (h *BlandAPIHandler) UpdatePersona(w http.ResponseWriter, r *http.Request) {
 	personaID := chi.URLParam(r, "personaID")
 	var req bland.UpdatePersonaRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	persona, err := h.blandService.UpdatePersona(r.Context(), personaID, &req)
 	if err != nil {
 		h.logger.Error("failed to update persona", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update persona: "+err.Error())
+		APIServiceError(w, err, "failed to update persona: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPersona)
 	h.respondJSON(w, http.StatusOK, persona)
 }
 
@@ -340,9 +417,10 @@ func (h *BlandAPIHandler) DeletePersona(w http.ResponseWriter, r *http.Request)
 	personaID := chi.URLParam(r, "personaID")
 	if err := h.blandService.DeletePersona(r.Context(), personaID); err != nil {
 		h.logger.Error("failed to delete persona", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete persona")
+		APIServiceError(w, err, "failed to delete persona")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPersona)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -352,10 +430,21 @@ func (h *BlandAPIHandler) DeletePersona(w http.ResponseWriter, r *http.Request)
 
 // ListKnowledgeBases handles GET /api/v1/bland/knowledge-bases
 func (h *BlandAPIHandler) ListKnowledgeBases(w http.ResponseWriter, r *http.Request) {
+	if h.syncService != nil {
+		kbs, state, err := h.syncService.ListKnowledgeBases(r.Context(), wantsRefresh(r))
+		if err != nil {
+			h.logger.Error("failed to list knowledge bases", zap.Error(err))
+			APIServiceError(w, err, "failed to list knowledge bases")
+			return
+		}
+		h.respondCached(w, r, state, kbs)
+		return
+	}
+
 	kbs, err := h.blandService.ListKnowledgeBases(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list knowledge bases", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list knowledge bases")
+		APIServiceError(w, err, "failed to list knowledge bases")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, kbs)
@@ -376,17 +465,20 @@ func (h *BlandAPIHandler) GetKnowledgeBase(w http.ResponseWriter, r *http.Reques
 // CreateKnowledgeBase handles POST /api/v1/bland/knowledge-bases
 func (h *BlandAPIHandler) CreateKnowledgeBase(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateKnowledgeBaseRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.CreateKnowledgeBase(r.Context(), &req)
 	if err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to create knowledge base", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create knowledge base: "+err.Error())
+		APIServiceError(w, err, "failed to create knowledge base: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityKnowledgeBase)
 	h.respondJSON(w, http.StatusCreated, result)
 }
 
@@ -394,16 +486,19 @@ func (h *BlandAPIHandler) CreateKnowledgeBase(w http.ResponseWriter, r *http.Req
 func (h *BlandAPIHandler) UpdateKnowledgeBase(w http.ResponseWriter, r *http.Request) {
 	vectorID := chi.URLParam(r, "vectorID")
 	var req bland.UpdateKnowledgeBaseRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.UpdateKnowledgeBase(r.Context(), vectorID, &req); err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to update knowledge base", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update knowledge base: "+err.Error())
+		APIServiceError(w, err, "failed to update knowledge base: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityKnowledgeBase)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -411,10 +506,14 @@ func (h *BlandAPIHandler) UpdateKnowledgeBase(w http.ResponseWriter, r *http.Req
 func (h *BlandAPIHandler) DeleteKnowledgeBase(w http.ResponseWriter, r *http.Request) {
 	vectorID := chi.URLParam(r, "vectorID")
 	if err := h.blandService.DeleteKnowledgeBase(r.Context(), vectorID); err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to delete knowledge base", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete knowledge base")
+		APIServiceError(w, err, "failed to delete knowledge base")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityKnowledgeBase)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -424,10 +523,21 @@ func (h *BlandAPIHandler) DeleteKnowledgeBase(w http.ResponseWriter, r *http.Req
 
 // ListPathways handles GET /api/v1/bland/pathways
 func (h *BlandAPIHandler) ListPathways(w http.ResponseWriter, r *http.Request) {
+	if h.syncService != nil {
+		pathways, state, err := h.syncService.ListPathways(r.Context(), wantsRefresh(r))
+		if err != nil {
+			h.logger.Error("failed to list pathways", zap.Error(err))
+			APIServiceError(w, err, "failed to list pathways")
+			return
+		}
+		h.respondCached(w, r, state, pathways)
+		return
+	}
+
 	pathways, err := h.blandService.ListPathways(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list pathways", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list pathways")
+		APIServiceError(w, err, "failed to list pathways")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, pathways)
@@ -448,17 +558,17 @@ func (h *BlandAPIHandler) GetPathway(w http.ResponseWriter, r *http.Request) {
 // CreatePathway handles POST /api/v1/bland/pathways
 func (h *BlandAPIHandler) CreatePathway(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreatePathwayRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	pathway, err := h.blandService.CreatePathway(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create pathway", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create pathway: "+err.Error())
+		APIServiceError(w, err, "failed to create pathway: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPathway)
 	h.respondJSON(w, http.StatusCreated, pathway)
 }
 
@@ -466,17 +576,17 @@ func (h *BlandAPIHandler) CreatePathway(w http.ResponseWriter, r *http.Request)
 func (h *BlandAPIHandler) UpdatePathway(w http.ResponseWriter, r *http.Request) {
 	pathwayID := chi.URLParam(r, "pathwayID")
 	var req bland.UpdatePathwayRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	pathway, err := h.blandService.UpdatePathway(r.Context(), pathwayID, &req)
 	if err != nil {
 		h.logger.Error("failed to update pathway", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update pathway: "+err.Error())
+		APIServiceError(w, err, "failed to update pathway: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPathway)
 	h.respondJSON(w, http.StatusOK, pathway)
 }
 
@@ -485,23 +595,202 @@ func (h *BlandAPIHandler) DeletePathway(w http.ResponseWriter, r *http.Request)
 	pathwayID := chi.URLParam(r, "pathwayID")
 	if err := h.blandService.DeletePathway(r.Context(), pathwayID); err != nil {
 		h.logger.Error("failed to delete pathway", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete pathway")
+		APIServiceError(w, err, "failed to delete pathway")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPathway)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
 // PublishPathway handles POST /api/v1/bland/pathways/{pathwayID}/publish
 func (h *BlandAPIHandler) PublishPathway(w http.ResponseWriter, r *http.Request) {
 	pathwayID := chi.URLParam(r, "pathwayID")
+
+	// Keep what is about to go live so it can be rolled back to.
+	if h.versionService != nil {
+		if _, err := h.versionService.Snapshot(r.Context(), pathwayID, "Before publish", requestActor(r)); err != nil {
+			h.logger.Error("failed to snapshot pathway before publish", zap.String("pathway_id", pathwayID), zap.Error(err))
+			APIServiceError(w, err, "failed to snapshot pathway; it was not published")
+			return
+		}
+	}
+
 	if err := h.blandService.PublishPathway(r.Context(), pathwayID); err != nil {
 		h.logger.Error("failed to publish pathway", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to publish pathway")
+		APIServiceError(w, err, "failed to publish pathway")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPathway)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
+// PathwayVersionResponse is a saved pathway version with its nodes and edges.
+type PathwayVersionResponse struct {
+	*domain.PathwayVersion
+	Nodes json.RawMessage `json:"nodes"`
+	Edges json.RawMessage `json:"edges"`
+}
+
+// CreatePathwayVersionRequest is the body of a manual snapshot.
+type CreatePathwayVersionRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// ListPathwayVersions handles GET /api/v1/bland/pathways/{pathwayID}/versions
+func (h *BlandAPIHandler) ListPathwayVersions(w http.ResponseWriter, r *http.Request) {
+	if !h.requireVersioning(w) {
+		return
+	}
+
+	versions, err := h.versionService.ListVersions(r.Context(), chi.URLParam(r, "pathwayID"))
+	if err != nil {
+		h.logger.Error("failed to list pathway versions", zap.Error(err))
+		APIServiceError(w, err, "failed to list pathway versions")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, versions)
+}
+
+// CreatePathwayVersion handles POST /api/v1/bland/pathways/{pathwayID}/versions
+// Snapshots the pathway as it currently is in Bland.
+func (h *BlandAPIHandler) CreatePathwayVersion(w http.ResponseWriter, r *http.Request) {
+	if !h.requireVersioning(w) {
+		return
+	}
+
+	var req CreatePathwayVersionRequest
+	if r.ContentLength != 0 {
+		if !decodeAndValidate(w, r, &req) {
+			return
+		}
+	}
+
+	version, err := h.versionService.Snapshot(r.Context(), chi.URLParam(r, "pathwayID"), req.Notes, requestActor(r))
+	if err != nil {
+		h.logger.Error("failed to snapshot pathway", zap.Error(err))
+		APIServiceError(w, err, "failed to snapshot pathway")
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, newPathwayVersionResponse(version))
+}
+
+// GetPathwayVersion handles GET /api/v1/bland/pathways/{pathwayID}/versions/{version}
+func (h *BlandAPIHandler) GetPathwayVersion(w http.ResponseWriter, r *http.Request) {
+	if !h.requireVersioning(w) {
+		return
+	}
+
+	number, ok := h.versionParam(w, r)
+	if !ok {
+		return
+	}
+
+	version, err := h.versionService.GetVersion(r.Context(), chi.URLParam(r, "pathwayID"), number)
+	if err != nil {
+		h.respondVersionError(w, "failed to get pathway version", err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, newPathwayVersionResponse(version))
+}
+
+// DiffPathwayVersions handles GET /api/v1/bland/pathways/{pathwayID}/diff?from=1&to=2
+// A missing or zero version means the live pathway in Bland.
+func (h *BlandAPIHandler) DiffPathwayVersions(w http.ResponseWriter, r *http.Request) {
+	if !h.requireVersioning(w) {
+		return
+	}
+
+	from, errFrom := parseVersionQuery(r.URL.Query().Get("from"))
+	to, errTo := parseVersionQuery(r.URL.Query().Get("to"))
+	if errFrom != nil || errTo != nil {
+		h.respondError(w, http.StatusBadRequest, "from and to must be version numbers")
+		return
+	}
+
+	diff, err := h.versionService.Diff(r.Context(), chi.URLParam(r, "pathwayID"), from, to)
+	if err != nil {
+		h.respondVersionError(w, "failed to diff pathway versions", err)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, diff)
+}
+
+// RollbackPathway handles POST /api/v1/bland/pathways/{pathwayID}/versions/{version}/rollback
+// Restores the pathway in Bland to a saved version after snapshotting its
+// current state.
+func (h *BlandAPIHandler) RollbackPathway(w http.ResponseWriter, r *http.Request) {
+	if !h.requireVersioning(w) {
+		return
+	}
+
+	number, ok := h.versionParam(w, r)
+	if !ok {
+		return
+	}
+
+	pathway, err := h.versionService.Rollback(r.Context(), chi.URLParam(r, "pathwayID"), number, requestActor(r))
+	if err != nil {
+		h.respondVersionError(w, "failed to roll back pathway", err)
+		return
+	}
+	h.refreshCache(r, domain.BlandEntityPathway)
+	h.respondJSON(w, http.StatusOK, pathway)
+}
+
+// requireVersioning answers 404 when pathway versioning isn't enabled.
+func (h *BlandAPIHandler) requireVersioning(w http.ResponseWriter) bool {
+	if h.versionService == nil {
+		h.respondError(w, http.StatusNotFound, "pathway versioning is not enabled")
+		return false
+	}
+	return true
+}
+
+// versionParam parses the version URL parameter.
+func (h *BlandAPIHandler) versionParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	number, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || number < 1 {
+		h.respondError(w, http.StatusBadRequest, "invalid version")
+		return 0, false
+	}
+	return number, true
+}
+
+// respondVersionError maps pathway version errors to responses.
+func (h *BlandAPIHandler) respondVersionError(w http.ResponseWriter, message string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(message, zap.Error(err))
+	APIServiceError(w, err, message)
+}
+
+// parseVersionQuery parses an optional version query parameter.
+func parseVersionQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// newPathwayVersionResponse exposes a version's stored nodes and edges.
+func newPathwayVersionResponse(v *domain.PathwayVersion) *PathwayVersionResponse {
+	return &PathwayVersionResponse{
+		PathwayVersion: v,
+		Nodes:          json.RawMessage(v.NodesJSON),
+		Edges:          json.RawMessage(v.EdgesJSON),
+	}
+}
+
+// requestActor identifies who made a request, for version history.
+func requestActor(r *http.Request) string {
+	if user := GetUserFromContext(r.Context()); user != nil {
+		return user.Email
+	}
+	return ""
+}
+
 // ===============================================
 // Memory Handlers
 // ===============================================
@@ -517,7 +806,7 @@ func (h *BlandAPIHandler) GetCustomerMemory(w http.ResponseWriter, r *http.Reque
 	memory, err := h.blandService.GetCustomerMemory(r.Context(), phoneNumber)
 	if err != nil {
 		h.logger.Error("failed to get customer memory", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get customer memory")
+		APIServiceError(w, err, "failed to get customer memory")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, memory)
@@ -525,26 +814,20 @@ func (h *BlandAPIHandler) GetCustomerMemory(w http.ResponseWriter, r *http.Reque
 
 // StoreCustomerMemoryRequest is the request body for storing memory.
 type StoreCustomerMemoryRequest struct {
-	PhoneNumber string                 `json:"phone_number"`
+	PhoneNumber string                 `json:"phone_number" validate:"required,e164"`
 	Data        map[string]interface{} `json:"data"`
 }
 
 // StoreCustomerMemory handles POST /api/v1/bland/memory
 func (h *BlandAPIHandler) StoreCustomerMemory(w http.ResponseWriter, r *http.Request) {
 	var req StoreCustomerMemoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-
-	if req.PhoneNumber == "" {
-		h.respondError(w, http.StatusBadRequest, "phone_number is required")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.StoreCustomerMemory(r.Context(), req.PhoneNumber, req.Data); err != nil {
 		h.logger.Error("failed to store customer memory", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to store customer memory")
+		APIServiceError(w, err, "failed to store customer memory")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -560,7 +843,7 @@ func (h *BlandAPIHandler) ClearCustomerMemory(w http.ResponseWriter, r *http.Req
 
 	if err := h.blandService.ClearCustomerMemory(r.Context(), phoneNumber); err != nil {
 		h.logger.Error("failed to clear customer memory", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to clear customer memory")
+		APIServiceError(w, err, "failed to clear customer memory")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -585,7 +868,7 @@ func (h *BlandAPIHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
 	batches, err := h.blandService.ListBatches(r.Context(), params.Limit, params.Offset)
 	if err != nil {
 		h.logger.Error("failed to list batches", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list batches")
+		APIServiceError(w, err, "failed to list batches")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, batches)
@@ -594,15 +877,18 @@ func (h *BlandAPIHandler) ListBatches(w http.ResponseWriter, r *http.Request) {
 // CreateBatch handles POST /api/v1/bland/batches
 func (h *BlandAPIHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateBatchRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.CreateBatch(r.Context(), &req)
+	if code := apperrors.GetCode(err); code == apperrors.CodeBudgetExceeded || code == apperrors.CodeComplianceBlocked {
+		APIAppError(w, err)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to create batch", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create batch: "+err.Error())
+		APIServiceError(w, err, "failed to create batch: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, result)
@@ -625,7 +911,7 @@ func (h *BlandAPIHandler) PauseBatch(w http.ResponseWriter, r *http.Request) {
 	batchID := chi.URLParam(r, "batchID")
 	if err := h.blandService.PauseBatch(r.Context(), batchID); err != nil {
 		h.logger.Error("failed to pause batch", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to pause batch")
+		APIServiceError(w, err, "failed to pause batch")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -636,7 +922,7 @@ func (h *BlandAPIHandler) ResumeBatch(w http.ResponseWriter, r *http.Request) {
 	batchID := chi.URLParam(r, "batchID")
 	if err := h.blandService.ResumeBatch(r.Context(), batchID); err != nil {
 		h.logger.Error("failed to resume batch", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to resume batch")
+		APIServiceError(w, err, "failed to resume batch")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -647,7 +933,7 @@ func (h *BlandAPIHandler) CancelBatch(w http.ResponseWriter, r *http.Request) {
 	batchID := chi.URLParam(r, "batchID")
 	if err := h.blandService.CancelBatch(r.Context(), batchID); err != nil {
 		h.logger.Error("failed to cancel batch", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to cancel batch")
+		APIServiceError(w, err, "failed to cancel batch")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -659,7 +945,7 @@ func (h *BlandAPIHandler) GetBatchAnalytics(w http.ResponseWriter, r *http.Reque
 	analytics, err := h.blandService.GetBatchAnalytics(r.Context(), batchID)
 	if err != nil {
 		h.logger.Error("failed to get batch analytics", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get batch analytics")
+		APIServiceError(w, err, "failed to get batch analytics")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, analytics)
@@ -672,15 +958,14 @@ func (h *BlandAPIHandler) GetBatchAnalytics(w http.ResponseWriter, r *http.Reque
 // SendSMS handles POST /api/v1/bland/sms
 func (h *BlandAPIHandler) SendSMS(w http.ResponseWriter, r *http.Request) {
 	var req bland.SendSMSRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.SendSMS(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to send SMS", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to send SMS: "+err.Error())
+		APIServiceError(w, err, "failed to send SMS: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, result)
@@ -689,15 +974,14 @@ func (h *BlandAPIHandler) SendSMS(w http.ResponseWriter, r *http.Request) {
 // StartSMSConversation handles POST /api/v1/bland/sms/conversation
 func (h *BlandAPIHandler) StartSMSConversation(w http.ResponseWriter, r *http.Request) {
 	var req bland.StartSMSConversationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.StartSMSConversation(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to start SMS conversation", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to start SMS conversation: "+err.Error())
+		APIServiceError(w, err, "failed to start SMS conversation: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, result)
@@ -720,7 +1004,7 @@ func (h *BlandAPIHandler) EndSMSConversation(w http.ResponseWriter, r *http.Requ
 	conversationID := chi.URLParam(r, "conversationID")
 	if err := h.blandService.EndSMSConversation(r.Context(), conversationID); err != nil {
 		h.logger.Error("failed to end SMS conversation", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to end conversation")
+		APIServiceError(w, err, "failed to end conversation")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -735,7 +1019,7 @@ func (h *BlandAPIHandler) ListTools(w http.ResponseWriter, r *http.Request) {
 	tools, err := h.blandService.ListTools(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list tools", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list tools")
+		APIServiceError(w, err, "failed to list tools")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, tools)
@@ -756,15 +1040,26 @@ func (h *BlandAPIHandler) GetTool(w http.ResponseWriter, r *http.Request) {
 // CreateTool handles POST /api/v1/bland/tools
 func (h *BlandAPIHandler) CreateTool(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateToolRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	tool, err := h.blandService.CreateTool(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create tool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create tool: "+err.Error())
+		APIServiceError(w, err, "failed to create tool: "+err.Error())
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, tool)
+}
+
+// SetupScheduleCallbackTool handles POST /api/v1/bland/tools/schedule-callback,
+// creating the tool agents use to put callbacks on the calendar.
+func (h *BlandAPIHandler) SetupScheduleCallbackTool(w http.ResponseWriter, r *http.Request) {
+	tool, err := h.blandService.SetupScheduleCallbackTool(r.Context())
+	if err != nil {
+		h.logger.Error("failed to create schedule callback tool", zap.Error(err))
+		APIServiceError(w, err, "failed to create tool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, tool)
@@ -774,15 +1069,14 @@ func (h *BlandAPIHandler) CreateTool(w http.ResponseWriter, r *http.Request) {
 func (h *BlandAPIHandler) UpdateTool(w http.ResponseWriter, r *http.Request) {
 	toolID := chi.URLParam(r, "toolID")
 	var req bland.UpdateToolRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	tool, err := h.blandService.UpdateTool(r.Context(), toolID, &req)
 	if err != nil {
 		h.logger.Error("failed to update tool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update tool: "+err.Error())
+		APIServiceError(w, err, "failed to update tool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, tool)
@@ -793,7 +1087,7 @@ func (h *BlandAPIHandler) DeleteTool(w http.ResponseWriter, r *http.Request) {
 	toolID := chi.URLParam(r, "toolID")
 	if err := h.blandService.DeleteTool(r.Context(), toolID); err != nil {
 		h.logger.Error("failed to delete tool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete tool")
+		APIServiceError(w, err, "failed to delete tool")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -808,15 +1102,14 @@ type TestToolRequest struct {
 func (h *BlandAPIHandler) TestTool(w http.ResponseWriter, r *http.Request) {
 	toolID := chi.URLParam(r, "toolID")
 	var req TestToolRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.TestTool(r.Context(), toolID, req.Input)
 	if err != nil {
 		h.logger.Error("failed to test tool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to test tool: "+err.Error())
+		APIServiceError(w, err, "failed to test tool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, result)
@@ -838,6 +1131,127 @@ func (h *BlandAPIHandler) GetCircuitBreakerStats(w http.ResponseWriter, r *http.
 // Helper Methods
 // ===============================================
 
+// ===============================================
+// Cache Handlers
+// ===============================================
+
+// GetSyncStatus handles GET /api/v1/bland/sync
+func (h *BlandAPIHandler) GetSyncStatus(w http.ResponseWriter, r *http.Request) {
+	if h.syncService == nil {
+		h.respondError(w, http.StatusNotFound, "bland cache is not enabled")
+		return
+	}
+	states, err := h.syncService.SyncStates(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get bland sync status", zap.Error(err))
+		APIServiceError(w, err, "failed to get sync status")
+		return
+	}
+	if states == nil {
+		states = []*domain.BlandSyncState{}
+	}
+	pendingWrites, err := h.blandService.PendingWrites(r.Context())
+	if err != nil {
+		h.logger.Warn("failed to count queued bland writes", zap.Error(err))
+	}
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"kinds":          states,
+		"degraded":       h.blandService.Degraded(),
+		"pending_writes": pendingWrites,
+	})
+}
+
+// SyncEntities handles POST /api/v1/bland/sync
+// Syncs every kind, or only the one named by the kind query parameter.
+func (h *BlandAPIHandler) SyncEntities(w http.ResponseWriter, r *http.Request) {
+	if h.syncService == nil {
+		h.respondError(w, http.StatusNotFound, "bland cache is not enabled")
+		return
+	}
+
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		state, err := h.syncService.Sync(r.Context(), domain.BlandEntityKind(kind))
+		if err != nil {
+			if apperrors.IsUserError(err) {
+				h.respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			h.logger.Error("failed to sync bland entities", zap.String("kind", kind), zap.Error(err))
+			h.respondError(w, http.StatusBadGateway, "failed to sync "+kind)
+			return
+		}
+		h.respondJSON(w, http.StatusOK, state)
+		return
+	}
+
+	if err := h.syncService.SyncAll(r.Context()); err != nil {
+		h.logger.Error("failed to sync bland entities", zap.Error(err))
+		h.respondError(w, http.StatusBadGateway, "failed to sync some entities; see sync status")
+		return
+	}
+	h.GetSyncStatus(w, r)
+}
+
+// respondCached writes a list served from the local cache. The ETag changes
+// only when a sync sees different data, so clients can poll with
+// If-None-Match and get 304 Not Modified until something changes. When the
+// last sync failed the list may be out of date, which a Warning header says.
+func (h *BlandAPIHandler) respondCached(w http.ResponseWriter, r *http.Request, state *domain.BlandSyncState, data interface{}) {
+	etag := `"` + state.ETag + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if state.SyncedAt != nil {
+		w.Header().Set("Last-Modified", state.SyncedAt.UTC().Format(http.TimeFormat))
+	}
+	if state.IsStale() {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.respondJSON(w, http.StatusOK, data)
+}
+
+// respondQueued answers 202 Accepted for a write queued because Bland is
+// unreachable. It writes nothing and returns false for any other error.
+func (h *BlandAPIHandler) respondQueued(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, service.ErrBlandWriteQueued) {
+		return false
+	}
+	h.respondJSON(w, http.StatusAccepted, map[string]string{
+		"status":  "queued",
+		"message": err.Error(),
+	})
+	return true
+}
+
+// refreshCache resyncs a kind after a change made through Bland, so the next
+// list shows it.
+func (h *BlandAPIHandler) refreshCache(r *http.Request, kind domain.BlandEntityKind) {
+	if h.syncService != nil {
+		h.syncService.Refresh(r.Context(), kind)
+	}
+}
+
+// wantsRefresh reports whether the request asks for the cache to be
+// refreshed from Bland first.
+func wantsRefresh(r *http.Request) bool {
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	return refresh
+}
+
+// etagMatches reports whether an If-None-Match header matches etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *BlandAPIHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	JSON(w, status, data)
 }
@@ -852,10 +1266,21 @@ func (h *BlandAPIHandler) respondError(w http.ResponseWriter, status int, messag
 
 // ListPhoneNumbers handles GET /api/v1/bland/numbers
 func (h *BlandAPIHandler) ListPhoneNumbers(w http.ResponseWriter, r *http.Request) {
+	if h.syncService != nil {
+		numbers, state, err := h.syncService.ListPhoneNumbers(r.Context(), wantsRefresh(r))
+		if err != nil {
+			h.logger.Error("failed to list phone numbers", zap.Error(err))
+			APIServiceError(w, err, "failed to list phone numbers")
+			return
+		}
+		h.respondCached(w, r, state, numbers)
+		return
+	}
+
 	numbers, err := h.blandService.ListPhoneNumbers(r.Context(), nil)
 	if err != nil {
 		h.logger.Error("failed to list phone numbers", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list phone numbers")
+		APIServiceError(w, err, "failed to list phone numbers")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, numbers)
@@ -901,7 +1326,7 @@ func (h *BlandAPIHandler) SearchAvailableNumbers(w http.ResponseWriter, r *http.
 	numbers, err := h.blandService.SearchAvailableNumbers(r.Context(), req)
 	if err != nil {
 		h.logger.Error("failed to search available numbers", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to search available numbers")
+		APIServiceError(w, err, "failed to search available numbers")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, numbers)
@@ -910,35 +1335,72 @@ func (h *BlandAPIHandler) SearchAvailableNumbers(w http.ResponseWriter, r *http.
 // PurchaseNumber handles POST /api/v1/bland/numbers/purchase
 func (h *BlandAPIHandler) PurchaseNumber(w http.ResponseWriter, r *http.Request) {
 	var req bland.PurchaseNumberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	number, err := h.blandService.PurchaseNumber(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to purchase number", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to purchase number: "+err.Error())
+		APIServiceError(w, err, "failed to purchase number: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPhoneNumber)
 	h.respondJSON(w, http.StatusCreated, number)
 }
 
+// BulkPurchaseNumbers handles POST /api/v1/bland/numbers/bulk-purchase
+// @Summary Buy and set up phone numbers in bulk
+// @Description Buys up to count numbers matching the search criteria, applies the preset as each number's inbound agent and adds it to the dialing pool. A number that fails after being bought is released. With all_or_nothing, every number is released unless all of them could be set up.
+// @Tags bland
+// @Accept json
+// @Produce json
+// @Param request body service.BulkPurchaseRequest true "Numbers to buy and how to set them up"
+// @Success 200 {object} service.BulkPurchaseResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/bland/numbers/bulk-purchase [post]
+func (h *BlandAPIHandler) BulkPurchaseNumbers(w http.ResponseWriter, r *http.Request) {
+	if h.provisioning == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "bulk purchase not configured")
+		return
+	}
+
+	var req service.BulkPurchaseRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	result, err := h.provisioning.BulkPurchase(r.Context(), &req)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to bulk purchase numbers", zap.Error(err))
+		APIServiceError(w, err, "failed to bulk purchase numbers: "+err.Error())
+		return
+	}
+	h.refreshCache(r, domain.BlandEntityPhoneNumber)
+	h.respondJSON(w, http.StatusOK, result)
+}
+
 // UpdatePhoneNumber handles PATCH /api/v1/bland/numbers/{numberID}
 func (h *BlandAPIHandler) UpdatePhoneNumber(w http.ResponseWriter, r *http.Request) {
 	numberID := chi.URLParam(r, "numberID")
 	var req bland.UpdatePhoneNumberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	number, err := h.blandService.UpdatePhoneNumber(r.Context(), numberID, &req)
 	if err != nil {
 		h.logger.Error("failed to update phone number", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update phone number: "+err.Error())
+		APIServiceError(w, err, "failed to update phone number: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPhoneNumber)
 	h.respondJSON(w, http.StatusOK, number)
 }
 
@@ -947,9 +1409,10 @@ func (h *BlandAPIHandler) ReleasePhoneNumber(w http.ResponseWriter, r *http.Requ
 	numberID := chi.URLParam(r, "numberID")
 	if err := h.blandService.ReleasePhoneNumber(r.Context(), numberID); err != nil {
 		h.logger.Error("failed to release phone number", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to release phone number")
+		APIServiceError(w, err, "failed to release phone number")
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPhoneNumber)
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -964,52 +1427,189 @@ func (h *BlandAPIHandler) ConfigureInboundAgent(w http.ResponseWriter, r *http.R
 
 	number, err := h.blandService.ConfigureInboundAgent(r.Context(), numberID, &config)
 	if err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to configure inbound agent", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to configure inbound agent: "+err.Error())
+		APIServiceError(w, err, "failed to configure inbound agent: "+err.Error())
 		return
 	}
+	h.refreshCache(r, domain.BlandEntityPhoneNumber)
 	h.respondJSON(w, http.StatusOK, number)
 }
 
+// ListNumberHealth handles GET /api/v1/bland/numbers/health
+func (h *BlandAPIHandler) ListNumberHealth(w http.ResponseWriter, r *http.Request) {
+	if h.numberHealth == nil {
+		h.respondError(w, http.StatusNotFound, "number health is not enabled")
+		return
+	}
+
+	healths, err := h.numberHealth.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list number health", zap.Error(err))
+		APIServiceError(w, err, "failed to list number health")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, healths)
+}
+
+// CheckNumberHealth handles POST /api/v1/bland/numbers/health/check
+// Checks every number now instead of waiting for the worker.
+func (h *BlandAPIHandler) CheckNumberHealth(w http.ResponseWriter, r *http.Request) {
+	if h.numberHealth == nil {
+		h.respondError(w, http.StatusNotFound, "number health is not enabled")
+		return
+	}
+
+	healths, err := h.numberHealth.CheckAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to check number health", zap.Error(err))
+		h.respondError(w, http.StatusBadGateway, "failed to check number health")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, healths)
+}
+
 // ListBlockedNumbers handles GET /api/v1/bland/numbers/blocked
+// With the blocklist enabled, refresh=true syncs it from Bland first.
 func (h *BlandAPIHandler) ListBlockedNumbers(w http.ResponseWriter, r *http.Request) {
+	if h.blocklist != nil {
+		if wantsRefresh(r) {
+			if err := h.blocklist.Sync(r.Context()); err != nil {
+				h.logger.Warn("failed to sync blocklist from bland", zap.Error(err))
+			}
+		}
+		entries, err := h.blocklist.List(r.Context())
+		if err != nil {
+			h.logger.Error("failed to list blocked numbers", zap.Error(err))
+			APIServiceError(w, err, "failed to list blocked numbers")
+			return
+		}
+		h.respondJSON(w, http.StatusOK, entries)
+		return
+	}
+
 	numbers, err := h.blandService.ListBlockedNumbers(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list blocked numbers", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list blocked numbers")
+		APIServiceError(w, err, "failed to list blocked numbers")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, numbers)
 }
 
 // BlockNumber handles POST /api/v1/bland/numbers/blocked
+// With the blocklist enabled, phone_number may be a prefix such as +1900*
+// and direction may be inbound, outbound or both.
 func (h *BlandAPIHandler) BlockNumber(w http.ResponseWriter, r *http.Request) {
+	if h.blocklist != nil {
+		var req service.BlockNumberRequest
+		if !decodeAndValidate(w, r, &req) {
+			return
+		}
+
+		entry, err := h.blocklist.Block(r.Context(), &req)
+		if err != nil {
+			if h.respondQueued(w, err) {
+				return
+			}
+			if apperrors.IsUserError(err) {
+				APIAppError(w, err)
+				return
+			}
+			h.logger.Error("failed to block number", zap.Error(err))
+			APIServiceError(w, err, "failed to block number")
+			return
+		}
+		h.respondJSON(w, http.StatusCreated, entry)
+		return
+	}
+
 	var req bland.BlockNumberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	blocked, err := h.blandService.BlockNumber(r.Context(), &req)
 	if err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to block number", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to block number: "+err.Error())
+		APIServiceError(w, err, "failed to block number: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, blocked)
 }
 
 // UnblockNumber handles DELETE /api/v1/bland/numbers/blocked/{blockedID}
+// The ID is either the blocklist entry's ID or Bland's ID for the block.
 func (h *BlandAPIHandler) UnblockNumber(w http.ResponseWriter, r *http.Request) {
 	blockedID := chi.URLParam(r, "blockedID")
-	if err := h.blandService.UnblockNumber(r.Context(), blockedID); err != nil {
+
+	var err error
+	if h.blocklist != nil {
+		err = h.blocklist.Unblock(r.Context(), blockedID)
+	} else {
+		err = h.blandService.UnblockNumber(r.Context(), blockedID)
+	}
+	if err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
+		if apperrors.IsNotFound(err) {
+			h.respondError(w, http.StatusNotFound, "blocked number not found")
+			return
+		}
 		h.logger.Error("failed to unblock number", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to unblock number")
+		APIServiceError(w, err, "failed to unblock number")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
+// ExportBlockedNumbers handles GET /api/v1/bland/numbers/blocked/export
+// Streams the blocklist as CSV in the format ImportBlockedNumbers accepts.
+func (h *BlandAPIHandler) ExportBlockedNumbers(w http.ResponseWriter, r *http.Request) {
+	if h.blocklist == nil {
+		h.respondError(w, http.StatusNotFound, "blocklist is not enabled")
+		return
+	}
+
+	filename := fmt.Sprintf("blocked-numbers-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if err := h.blocklist.Export(r.Context(), w); err != nil {
+		// Headers are already sent; all that is left is to log it
+		h.logger.Error("failed to export blocked numbers", zap.Error(err))
+	}
+}
+
+// ImportBlockedNumbers handles POST /api/v1/bland/numbers/blocked/import
+// The body is CSV with phone_number, reason and direction columns and an
+// optional header row. Rows that cannot be blocked are reported, not fatal.
+func (h *BlandAPIHandler) ImportBlockedNumbers(w http.ResponseWriter, r *http.Request) {
+	if h.blocklist == nil {
+		h.respondError(w, http.StatusNotFound, "blocklist is not enabled")
+		return
+	}
+
+	result, err := h.blocklist.Import(r.Context(), r.Body)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to import blocked numbers", zap.Error(err))
+		APIServiceError(w, err, "failed to import blocked numbers")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, result)
+}
+
 // ===============================================
 // Citation Handlers
 // ===============================================
@@ -1019,7 +1619,7 @@ func (h *BlandAPIHandler) ListCitationSchemas(w http.ResponseWriter, r *http.Req
 	schemas, err := h.blandService.ListCitationSchemas(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list citation schemas", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list citation schemas")
+		APIServiceError(w, err, "failed to list citation schemas")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, schemas)
@@ -1040,15 +1640,14 @@ func (h *BlandAPIHandler) GetCitationSchema(w http.ResponseWriter, r *http.Reque
 // CreateCitationSchema handles POST /api/v1/bland/citations/schemas
 func (h *BlandAPIHandler) CreateCitationSchema(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateCitationSchemaRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	schema, err := h.blandService.CreateCitationSchema(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create citation schema", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create citation schema: "+err.Error())
+		APIServiceError(w, err, "failed to create citation schema: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, schema)
@@ -1058,15 +1657,14 @@ func (h *BlandAPIHandler) CreateCitationSchema(w http.ResponseWriter, r *http.Re
 func (h *BlandAPIHandler) UpdateCitationSchema(w http.ResponseWriter, r *http.Request) {
 	schemaID := chi.URLParam(r, "schemaID")
 	var req bland.UpdateCitationSchemaRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	schema, err := h.blandService.UpdateCitationSchema(r.Context(), schemaID, &req)
 	if err != nil {
 		h.logger.Error("failed to update citation schema", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update citation schema: "+err.Error())
+		APIServiceError(w, err, "failed to update citation schema: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, schema)
@@ -1077,7 +1675,7 @@ func (h *BlandAPIHandler) DeleteCitationSchema(w http.ResponseWriter, r *http.Re
 	schemaID := chi.URLParam(r, "schemaID")
 	if err := h.blandService.DeleteCitationSchema(r.Context(), schemaID); err != nil {
 		h.logger.Error("failed to delete citation schema", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete citation schema")
+		APIServiceError(w, err, "failed to delete citation schema")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1089,7 +1687,7 @@ func (h *BlandAPIHandler) GetCallCitations(w http.ResponseWriter, r *http.Reques
 	citations, err := h.blandService.GetCallCitations(r.Context(), callID)
 	if err != nil {
 		h.logger.Error("failed to get call citations", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get call citations")
+		APIServiceError(w, err, "failed to get call citations")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, citations)
@@ -1097,22 +1695,21 @@ func (h *BlandAPIHandler) GetCallCitations(w http.ResponseWriter, r *http.Reques
 
 // ExtractCitationsRequest is the request body for extracting citations.
 type ExtractCitationsRequest struct {
-	SchemaIDs []string `json:"schema_ids"`
+	SchemaIDs []string `json:"schema_ids" validate:"required"`
 }
 
 // ExtractCitations handles POST /api/v1/bland/citations/calls/{callID}/extract
 func (h *BlandAPIHandler) ExtractCitations(w http.ResponseWriter, r *http.Request) {
 	callID := chi.URLParam(r, "callID")
 	var req ExtractCitationsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	citations, err := h.blandService.ExtractCitations(r.Context(), callID, req.SchemaIDs)
 	if err != nil {
 		h.logger.Error("failed to extract citations", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to extract citations: "+err.Error())
+		APIServiceError(w, err, "failed to extract citations: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, citations)
@@ -1127,7 +1724,7 @@ func (h *BlandAPIHandler) ListDynamicDataSources(w http.ResponseWriter, r *http.
 	sources, err := h.blandService.ListDynamicDataSources(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list dynamic data sources", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list dynamic data sources")
+		APIServiceError(w, err, "failed to list dynamic data sources")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, sources)
@@ -1148,15 +1745,14 @@ func (h *BlandAPIHandler) GetDynamicDataSource(w http.ResponseWriter, r *http.Re
 // CreateDynamicDataSource handles POST /api/v1/bland/dynamic-data
 func (h *BlandAPIHandler) CreateDynamicDataSource(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateDynamicDataSourceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	source, err := h.blandService.CreateDynamicDataSource(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create dynamic data source", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create dynamic data source: "+err.Error())
+		APIServiceError(w, err, "failed to create dynamic data source: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, source)
@@ -1166,15 +1762,14 @@ func (h *BlandAPIHandler) CreateDynamicDataSource(w http.ResponseWriter, r *http
 func (h *BlandAPIHandler) UpdateDynamicDataSource(w http.ResponseWriter, r *http.Request) {
 	sourceID := chi.URLParam(r, "sourceID")
 	var req bland.UpdateDynamicDataSourceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	source, err := h.blandService.UpdateDynamicDataSource(r.Context(), sourceID, &req)
 	if err != nil {
 		h.logger.Error("failed to update dynamic data source", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update dynamic data source: "+err.Error())
+		APIServiceError(w, err, "failed to update dynamic data source: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, source)
@@ -1185,7 +1780,7 @@ func (h *BlandAPIHandler) DeleteDynamicDataSource(w http.ResponseWriter, r *http
 	sourceID := chi.URLParam(r, "sourceID")
 	if err := h.blandService.DeleteDynamicDataSource(r.Context(), sourceID); err != nil {
 		h.logger.Error("failed to delete dynamic data source", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete dynamic data source")
+		APIServiceError(w, err, "failed to delete dynamic data source")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1200,15 +1795,14 @@ type TestDynamicDataRequest struct {
 func (h *BlandAPIHandler) TestDynamicDataSource(w http.ResponseWriter, r *http.Request) {
 	sourceID := chi.URLParam(r, "sourceID")
 	var req TestDynamicDataRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	result, err := h.blandService.TestDynamicDataSource(r.Context(), sourceID, req.Params)
 	if err != nil {
 		h.logger.Error("failed to test dynamic data source", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to test dynamic data source: "+err.Error())
+		APIServiceError(w, err, "failed to test dynamic data source: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, result)
@@ -1219,7 +1813,7 @@ func (h *BlandAPIHandler) RefreshDynamicDataSource(w http.ResponseWriter, r *htt
 	sourceID := chi.URLParam(r, "sourceID")
 	if err := h.blandService.RefreshDynamicDataSource(r.Context(), sourceID); err != nil {
 		h.logger.Error("failed to refresh dynamic data source", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to refresh dynamic data source")
+		APIServiceError(w, err, "failed to refresh dynamic data source")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1234,7 +1828,7 @@ func (h *BlandAPIHandler) ListTwilioAccounts(w http.ResponseWriter, r *http.Requ
 	accounts, err := h.blandService.ListTwilioAccounts(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list Twilio accounts", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list Twilio accounts")
+		APIServiceError(w, err, "failed to list Twilio accounts")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, accounts)
@@ -1255,15 +1849,14 @@ func (h *BlandAPIHandler) GetTwilioAccount(w http.ResponseWriter, r *http.Reques
 // CreateTwilioAccount handles POST /api/v1/bland/enterprise/twilio
 func (h *BlandAPIHandler) CreateTwilioAccount(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateTwilioAccountRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	account, err := h.blandService.CreateTwilioAccount(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create Twilio account", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create Twilio account: "+err.Error())
+		APIServiceError(w, err, "failed to create Twilio account: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, account)
@@ -1273,15 +1866,14 @@ func (h *BlandAPIHandler) CreateTwilioAccount(w http.ResponseWriter, r *http.Req
 func (h *BlandAPIHandler) UpdateTwilioAccount(w http.ResponseWriter, r *http.Request) {
 	accountID := chi.URLParam(r, "accountID")
 	var req bland.UpdateTwilioAccountRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	account, err := h.blandService.UpdateTwilioAccount(r.Context(), accountID, &req)
 	if err != nil {
 		h.logger.Error("failed to update Twilio account", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update Twilio account: "+err.Error())
+		APIServiceError(w, err, "failed to update Twilio account: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, account)
@@ -1292,7 +1884,7 @@ func (h *BlandAPIHandler) DeleteTwilioAccount(w http.ResponseWriter, r *http.Req
 	accountID := chi.URLParam(r, "accountID")
 	if err := h.blandService.DeleteTwilioAccount(r.Context(), accountID); err != nil {
 		h.logger.Error("failed to delete Twilio account", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete Twilio account")
+		APIServiceError(w, err, "failed to delete Twilio account")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1304,7 +1896,7 @@ func (h *BlandAPIHandler) VerifyTwilioAccount(w http.ResponseWriter, r *http.Req
 	verified, err := h.blandService.VerifyTwilioAccount(r.Context(), accountID)
 	if err != nil {
 		h.logger.Error("failed to verify Twilio account", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to verify Twilio account")
+		APIServiceError(w, err, "failed to verify Twilio account")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]bool{"verified": verified})
@@ -1319,7 +1911,7 @@ func (h *BlandAPIHandler) ListSIPTrunks(w http.ResponseWriter, r *http.Request)
 	trunks, err := h.blandService.ListSIPTrunks(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list SIP trunks", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list SIP trunks")
+		APIServiceError(w, err, "failed to list SIP trunks")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, trunks)
@@ -1340,15 +1932,14 @@ func (h *BlandAPIHandler) GetSIPTrunk(w http.ResponseWriter, r *http.Request) {
 // CreateSIPTrunk handles POST /api/v1/bland/enterprise/sip
 func (h *BlandAPIHandler) CreateSIPTrunk(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateSIPTrunkRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	trunk, err := h.blandService.CreateSIPTrunk(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create SIP trunk", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create SIP trunk: "+err.Error())
+		APIServiceError(w, err, "failed to create SIP trunk: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, trunk)
@@ -1358,15 +1949,14 @@ func (h *BlandAPIHandler) CreateSIPTrunk(w http.ResponseWriter, r *http.Request)
 func (h *BlandAPIHandler) UpdateSIPTrunk(w http.ResponseWriter, r *http.Request) {
 	trunkID := chi.URLParam(r, "trunkID")
 	var req bland.UpdateSIPTrunkRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	trunk, err := h.blandService.UpdateSIPTrunk(r.Context(), trunkID, &req)
 	if err != nil {
 		h.logger.Error("failed to update SIP trunk", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update SIP trunk: "+err.Error())
+		APIServiceError(w, err, "failed to update SIP trunk: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, trunk)
@@ -1377,7 +1967,7 @@ func (h *BlandAPIHandler) DeleteSIPTrunk(w http.ResponseWriter, r *http.Request)
 	trunkID := chi.URLParam(r, "trunkID")
 	if err := h.blandService.DeleteSIPTrunk(r.Context(), trunkID); err != nil {
 		h.logger.Error("failed to delete SIP trunk", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete SIP trunk")
+		APIServiceError(w, err, "failed to delete SIP trunk")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1389,7 +1979,7 @@ func (h *BlandAPIHandler) TestSIPTrunk(w http.ResponseWriter, r *http.Request) {
 	connected, err := h.blandService.TestSIPTrunk(r.Context(), trunkID)
 	if err != nil {
 		h.logger.Error("failed to test SIP trunk", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to test SIP trunk")
+		APIServiceError(w, err, "failed to test SIP trunk")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]bool{"connected": connected})
@@ -1402,7 +1992,7 @@ func (h *BlandAPIHandler) GetSIPTrunkStats(w http.ResponseWriter, r *http.Reques
 	stats, err := h.blandService.GetSIPTrunkStats(r.Context(), trunkID, period)
 	if err != nil {
 		h.logger.Error("failed to get SIP trunk stats", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get SIP trunk stats")
+		APIServiceError(w, err, "failed to get SIP trunk stats")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, stats)
@@ -1417,7 +2007,7 @@ func (h *BlandAPIHandler) ListDialingPools(w http.ResponseWriter, r *http.Reques
 	pools, err := h.blandService.ListDialingPools(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list dialing pools", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list dialing pools")
+		APIServiceError(w, err, "failed to list dialing pools")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, pools)
@@ -1438,15 +2028,14 @@ func (h *BlandAPIHandler) GetDialingPool(w http.ResponseWriter, r *http.Request)
 // CreateDialingPool handles POST /api/v1/bland/enterprise/dialing-pools
 func (h *BlandAPIHandler) CreateDialingPool(w http.ResponseWriter, r *http.Request) {
 	var req bland.CreateDialingPoolRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	pool, err := h.blandService.CreateDialingPool(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create dialing pool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create dialing pool: "+err.Error())
+		APIServiceError(w, err, "failed to create dialing pool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, pool)
@@ -1456,15 +2045,14 @@ func (h *BlandAPIHandler) CreateDialingPool(w http.ResponseWriter, r *http.Reque
 func (h *BlandAPIHandler) UpdateDialingPool(w http.ResponseWriter, r *http.Request) {
 	poolID := chi.URLParam(r, "poolID")
 	var req bland.UpdateDialingPoolRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	pool, err := h.blandService.UpdateDialingPool(r.Context(), poolID, &req)
 	if err != nil {
 		h.logger.Error("failed to update dialing pool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update dialing pool: "+err.Error())
+		APIServiceError(w, err, "failed to update dialing pool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusOK, pool)
@@ -1475,7 +2063,7 @@ func (h *BlandAPIHandler) DeleteDialingPool(w http.ResponseWriter, r *http.Reque
 	poolID := chi.URLParam(r, "poolID")
 	if err := h.blandService.DeleteDialingPool(r.Context(), poolID); err != nil {
 		h.logger.Error("failed to delete dialing pool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete dialing pool")
+		APIServiceError(w, err, "failed to delete dialing pool")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1492,7 +2080,7 @@ func (h *BlandAPIHandler) AddNumberToPool(w http.ResponseWriter, r *http.Request
 
 	if err := h.blandService.AddNumberToPool(r.Context(), poolID, &number); err != nil {
 		h.logger.Error("failed to add number to pool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to add number to pool: "+err.Error())
+		APIServiceError(w, err, "failed to add number to pool: "+err.Error())
 		return
 	}
 	h.respondJSON(w, http.StatusCreated, map[string]string{"status": "success"})
@@ -1504,7 +2092,7 @@ func (h *BlandAPIHandler) RemoveNumberFromPool(w http.ResponseWriter, r *http.Re
 	phoneNumber := chi.URLParam(r, "phoneNumber")
 	if err := h.blandService.RemoveNumberFromPool(r.Context(), poolID, phoneNumber); err != nil {
 		h.logger.Error("failed to remove number from pool", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to remove number from pool")
+		APIServiceError(w, err, "failed to remove number from pool")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1516,7 +2104,7 @@ func (h *BlandAPIHandler) GetDialingPoolStats(w http.ResponseWriter, r *http.Req
 	stats, err := h.blandService.GetDialingPoolStats(r.Context(), poolID)
 	if err != nil {
 		h.logger.Error("failed to get dialing pool stats", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get dialing pool stats")
+		APIServiceError(w, err, "failed to get dialing pool stats")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, stats)
@@ -1536,7 +2124,7 @@ func (h *BlandAPIHandler) GetUsageSummary(w http.ResponseWriter, r *http.Request
 	summary, err := h.blandService.GetUsageSummary(r.Context(), req)
 	if err != nil {
 		h.logger.Error("failed to get usage summary", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get usage summary")
+		APIServiceError(w, err, "failed to get usage summary")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, summary)
@@ -1548,7 +2136,7 @@ func (h *BlandAPIHandler) GetDailyUsage(w http.ResponseWriter, r *http.Request)
 	usage, err := h.blandService.GetDailyUsage(r.Context(), 30)
 	if err != nil {
 		h.logger.Error("failed to get daily usage", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get daily usage")
+		APIServiceError(w, err, "failed to get daily usage")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, usage)
@@ -1559,7 +2147,7 @@ func (h *BlandAPIHandler) GetUsageLimits(w http.ResponseWriter, r *http.Request)
 	limits, err := h.blandService.GetUsageLimits(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get usage limits", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get usage limits")
+		APIServiceError(w, err, "failed to get usage limits")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, limits)
@@ -1567,21 +2155,23 @@ func (h *BlandAPIHandler) GetUsageLimits(w http.ResponseWriter, r *http.Request)
 
 // SetUsageLimitRequest is the request body for setting usage limits.
 type SetUsageLimitRequest struct {
-	Type  string  `json:"type"`
-	Value float64 `json:"value"`
+	Type  string  `json:"type" validate:"required"`
+	Value float64 `json:"value" validate:"min=0"`
 }
 
 // SetUsageLimit handles POST /api/v1/bland/usage/limits
 func (h *BlandAPIHandler) SetUsageLimit(w http.ResponseWriter, r *http.Request) {
 	var req SetUsageLimitRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.SetUsageLimit(r.Context(), req.Type, req.Value); err != nil {
+		if h.respondQueued(w, err) {
+			return
+		}
 		h.logger.Error("failed to set usage limit", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to set usage limit")
+		APIServiceError(w, err, "failed to set usage limit")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1592,7 +2182,7 @@ func (h *BlandAPIHandler) GetPricing(w http.ResponseWriter, r *http.Request) {
 	pricing, err := h.blandService.GetPricing(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get pricing", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get pricing")
+		APIServiceError(w, err, "failed to get pricing")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, pricing)
@@ -1603,7 +2193,7 @@ func (h *BlandAPIHandler) GetUsageAlerts(w http.ResponseWriter, r *http.Request)
 	alerts, err := h.blandService.GetUsageAlerts(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get usage alerts", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get usage alerts")
+		APIServiceError(w, err, "failed to get usage alerts")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, alerts)
@@ -1611,22 +2201,21 @@ func (h *BlandAPIHandler) GetUsageAlerts(w http.ResponseWriter, r *http.Request)
 
 // SetAlertThresholdRequest is the request body for setting alert thresholds.
 type SetAlertThresholdRequest struct {
-	Type          string  `json:"type"`
-	Threshold     float64 `json:"threshold"`
+	Type          string  `json:"type" validate:"required"`
+	Threshold     float64 `json:"threshold" validate:"min=0"`
 	ThresholdType string  `json:"threshold_type"`
 }
 
 // SetAlertThreshold handles POST /api/v1/bland/usage/alerts
 func (h *BlandAPIHandler) SetAlertThreshold(w http.ResponseWriter, r *http.Request) {
 	var req SetAlertThresholdRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.SetAlertThreshold(r.Context(), req.Type, req.Threshold, req.ThresholdType); err != nil {
 		h.logger.Error("failed to set alert threshold", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to set alert threshold")
+		APIServiceError(w, err, "failed to set alert threshold")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1637,7 +2226,7 @@ func (h *BlandAPIHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Reques
 	alertID := chi.URLParam(r, "alertID")
 	if err := h.blandService.AcknowledgeAlert(r.Context(), alertID); err != nil {
 		h.logger.Error("failed to acknowledge alert", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to acknowledge alert")
+		APIServiceError(w, err, "failed to acknowledge alert")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1645,7 +2234,7 @@ func (h *BlandAPIHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Reques
 
 // EstimateCallCostRequest is the request body for estimating call cost.
 type EstimateCallCostRequest struct {
-	DurationMinutes      float64 `json:"duration_minutes"`
+	DurationMinutes      float64 `json:"duration_minutes" validate:"required,duration"`
 	Direction            string  `json:"direction"`
 	NumberType           string  `json:"number_type"`
 	IncludeTranscription bool    `json:"include_transcription"`
@@ -1655,8 +2244,7 @@ type EstimateCallCostRequest struct {
 // EstimateCallCost handles POST /api/v1/bland/usage/estimate
 func (h *BlandAPIHandler) EstimateCallCost(w http.ResponseWriter, r *http.Request) {
 	var req EstimateCallCostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -1664,7 +2252,7 @@ func (h *BlandAPIHandler) EstimateCallCost(w http.ResponseWriter, r *http.Reques
 		req.NumberType, req.IncludeTranscription, req.IncludeAnalysis)
 	if err != nil {
 		h.logger.Error("failed to estimate call cost", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to estimate call cost")
+		APIServiceError(w, err, "failed to estimate call cost")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]float64{"estimated_cost": cost})
@@ -1679,7 +2267,7 @@ func (h *BlandAPIHandler) GetOrganization(w http.ResponseWriter, r *http.Request
 	org, err := h.blandService.GetOrganization(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get organization", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get organization")
+		APIServiceError(w, err, "failed to get organization")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, org)
@@ -1690,7 +2278,7 @@ func (h *BlandAPIHandler) ListOrganizationMembers(w http.ResponseWriter, r *http
 	members, err := h.blandService.ListOrganizationMembers(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list organization members", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list organization members")
+		APIServiceError(w, err, "failed to list organization members")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, members)
@@ -1698,21 +2286,20 @@ func (h *BlandAPIHandler) ListOrganizationMembers(w http.ResponseWriter, r *http
 
 // InviteMemberRequest is the request body for inviting members.
 type InviteMemberRequest struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	Email string `json:"email" validate:"required"`
+	Role  string `json:"role" validate:"required"`
 }
 
 // InviteOrganizationMember handles POST /api/v1/bland/organization/members/invite
 func (h *BlandAPIHandler) InviteOrganizationMember(w http.ResponseWriter, r *http.Request) {
 	var req InviteMemberRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.InviteOrganizationMember(r.Context(), req.Email, req.Role); err != nil {
 		h.logger.Error("failed to invite organization member", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to invite organization member")
+		APIServiceError(w, err, "failed to invite organization member")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1723,7 +2310,7 @@ func (h *BlandAPIHandler) RemoveOrganizationMember(w http.ResponseWriter, r *htt
 	memberID := chi.URLParam(r, "memberID")
 	if err := h.blandService.RemoveOrganizationMember(r.Context(), memberID); err != nil {
 		h.logger.Error("failed to remove organization member", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to remove organization member")
+		APIServiceError(w, err, "failed to remove organization member")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
@@ -1731,21 +2318,20 @@ func (h *BlandAPIHandler) RemoveOrganizationMember(w http.ResponseWriter, r *htt
 
 // UpdateMemberRoleRequest is the request body for updating member role.
 type UpdateMemberRoleRequest struct {
-	Role string `json:"role"`
+	Role string `json:"role" validate:"required"`
 }
 
 // UpdateMemberRole handles PATCH /api/v1/bland/organization/members/{memberID}
 func (h *BlandAPIHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
 	memberID := chi.URLParam(r, "memberID")
 	var req UpdateMemberRoleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.blandService.UpdateMemberRole(r.Context(), memberID, req.Role); err != nil {
 		h.logger.Error("failed to update member role", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update member role")
+		APIServiceError(w, err, "failed to update member role")
 		return
 	}
 	h.respondJSON(w, http.StatusOK, map[string]string{"status": "success"})
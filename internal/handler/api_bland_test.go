@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+func TestBlandAPIHandler_RespondCached(t *testing.T) {
+	h := NewBlandAPIHandler(nil, zap.NewNop())
+	syncedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	state := &domain.BlandSyncState{ETag: "abc123", SyncedAt: &syncedAt}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"no validator", "", http.StatusOK},
+		{"matching", `"abc123"`, http.StatusNotModified},
+		{"weak match", `W/"abc123"`, http.StatusNotModified},
+		{"one of several", `"old", "abc123"`, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"stale", `"old"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/bland/voices", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			h.respondCached(rec, req, state, []string{"maya"})
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("ETag"); got != `"abc123"` {
+				t.Errorf("ETag = %q", got)
+			}
+			if got := rec.Header().Get("Last-Modified"); got != "Fri, 02 Jan 2026 03:04:05 GMT" {
+				t.Errorf("Last-Modified = %q", got)
+			}
+			if tt.wantStatus == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 response has a body: %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWantsRefresh(t *testing.T) {
+	for query, want := range map[string]bool{
+		"":               false,
+		"?refresh=1":     true,
+		"?refresh=true":  true,
+		"?refresh=0":     false,
+		"?refresh=maybe": false,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/bland/voices"+query, nil)
+		if got := wantsRefresh(req); got != want {
+			t.Errorf("wantsRefresh(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestBlandAPIHandler_RespondCachedWarnsWhenStale(t *testing.T) {
+	h := NewBlandAPIHandler(nil, zap.NewNop())
+	syncedAt := time.Now()
+
+	for _, tt := range []struct {
+		name  string
+		state *domain.BlandSyncState
+		want  string
+	}{
+		{"fresh", &domain.BlandSyncState{ETag: "abc", SyncedAt: &syncedAt}, ""},
+		{"stale", &domain.BlandSyncState{ETag: "abc", SyncedAt: &syncedAt, LastError: "circuit breaker is open"}, `110 - "Response is Stale"`},
+	} {
+		rec := httptest.NewRecorder()
+		h.respondCached(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bland/voices", nil), tt.state, []string{})
+		if got := rec.Header().Get("Warning"); got != tt.want {
+			t.Errorf("%s: Warning = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBlandAPIHandler_RespondQueued(t *testing.T) {
+	h := NewBlandAPIHandler(nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	if !h.respondQueued(rec, service.ErrBlandWriteQueued) {
+		t.Fatal("respondQueued(ErrBlandWriteQueued) = false")
+	}
+	if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"status":"queued"`) {
+		t.Errorf("response = %d %s, want 202 queued", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	if h.respondQueued(rec, errors.New("bland API error: not found")) {
+		t.Error("respondQueued(other error) = true")
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("respondQueued wrote %q for another error", rec.Body.String())
+	}
+}
+
+func TestBlandAPIHandler_PathwayVersionRoutes(t *testing.T) {
+	h := NewBlandAPIHandler(nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/bland/pathways/pw-1/versions", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("versions without a version service = %d, want 404", rec.Code)
+	}
+
+	h.SetPathwayVersionService(service.NewPathwayVersionService(nil, nil, zap.NewNop()))
+	for _, tt := range []struct{ method, path string }{
+		{http.MethodGet, "/bland/pathways/pw-1/versions/abc"},
+		{http.MethodPost, "/bland/pathways/pw-1/versions/0/rollback"},
+		{http.MethodGet, "/bland/pathways/pw-1/diff?from=x"},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s %s = %d, want 400", tt.method, tt.path, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+)
+
+// CircuitBreakerAPIHandler reports the circuit breakers guarding outbound
+// dependencies and lets operators trip and reset them. All of its
+// endpoints are admin only.
+type CircuitBreakerAPIHandler struct {
+	breakers    *circuitbreaker.Registry
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewCircuitBreakerAPIHandler creates a new CircuitBreakerAPIHandler.
+// auditLogger may be nil.
+func NewCircuitBreakerAPIHandler(breakers *circuitbreaker.Registry, auditLogger *audit.Logger, logger *zap.Logger) *CircuitBreakerAPIHandler {
+	return &CircuitBreakerAPIHandler{
+		breakers:    breakers,
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// CircuitBreakerListResponse lists the circuit breakers by name.
+type CircuitBreakerListResponse struct {
+	Breakers []circuitbreaker.Stats `json:"breakers"`
+}
+
+// RegisterRoutes registers circuit breaker API routes.
+func (h *CircuitBreakerAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/system/breakers", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.List)
+		r.Post("/{name}/trip", h.Trip)
+		r.Post("/{name}/reset", h.Reset)
+	})
+}
+
+// List handles GET /api/v1/system/breakers
+// @Summary List circuit breakers
+// @Description Reports the state and counters of the circuit breaker guarding each outbound dependency: the Bland, Vapi, Retell and Claude APIs, email, and each outgoing webhook subscriber. Admin only.
+// @Tags system
+// @Produce json
+// @Success 200 {object} CircuitBreakerListResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/system/breakers [get]
+func (h *CircuitBreakerAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	JSON(w, http.StatusOK, CircuitBreakerListResponse{Breakers: h.breakers.Stats()})
+}
+
+// Trip handles POST /api/v1/system/breakers/{name}/trip
+// @Summary Trip a circuit breaker
+// @Description Forces a circuit open, so requests to the dependency fail fast until it is reset, for example during a provider outage. Admin only.
+// @Tags system
+// @Produce json
+// @Param name path string true "Breaker name"
+// @Success 200 {object} circuitbreaker.Stats
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/system/breakers/{name}/trip [post]
+func (h *CircuitBreakerAPIHandler) Trip(w http.ResponseWriter, r *http.Request) {
+	cb, ok := h.breakers.Get(chi.URLParam(r, "name"))
+	if !ok {
+		APIError(w, http.StatusNotFound, "circuit breaker not found")
+		return
+	}
+
+	cb.Trip()
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.CircuitBreakerTripped(r.Context(), userID, userName, cb.Name(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	JSON(w, http.StatusOK, cb.Stats())
+}
+
+// Reset handles POST /api/v1/system/breakers/{name}/reset
+// @Summary Reset a circuit breaker
+// @Description Closes a circuit, letting requests through to the dependency again, whether it opened after failures or was tripped. Admin only.
+// @Tags system
+// @Produce json
+// @Param name path string true "Breaker name"
+// @Success 200 {object} circuitbreaker.Stats
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/system/breakers/{name}/reset [post]
+func (h *CircuitBreakerAPIHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	cb, ok := h.breakers.Get(chi.URLParam(r, "name"))
+	if !ok {
+		APIError(w, http.StatusNotFound, "circuit breaker not found")
+		return
+	}
+
+	cb.Reset()
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.CircuitBreakerReset(r.Context(), userID, userName, cb.Name(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	JSON(w, http.StatusOK, cb.Stats())
+}
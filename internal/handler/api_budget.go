@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// BudgetAPIHandler handles the monthly budget endpoints. Anyone may read the
+// budget; only admins may override the hard cap.
+type BudgetAPIHandler struct {
+	budgetGuard *service.BudgetGuard
+	logger      *zap.Logger
+}
+
+// NewBudgetAPIHandler creates a new BudgetAPIHandler.
+func NewBudgetAPIHandler(budgetGuard *service.BudgetGuard, logger *zap.Logger) *BudgetAPIHandler {
+	return &BudgetAPIHandler{
+		budgetGuard: budgetGuard,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers budget API routes.
+func (h *BudgetAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/budget", func(r chi.Router) {
+		r.Get("/", h.GetBudget)
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAPIAdmin)
+			r.Post("/override", h.CreateOverride)
+			r.Delete("/override", h.DeleteOverride)
+		})
+	})
+}
+
+// GetBudget handles GET /api/v1/budget
+// @Summary Get the monthly budget
+// @Description Returns month-to-date spend (Bland usage plus estimates of calls Bland has not billed yet) against the hard cap, and whether calling is blocked.
+// @Tags budget
+// @Produce json
+// @Success 200 {object} service.BudgetStatus
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/budget [get]
+func (h *BudgetAPIHandler) GetBudget(w http.ResponseWriter, r *http.Request) {
+	status, err := h.budgetGuard.Status(r.Context())
+	if err != nil {
+		h.respondBudgetError(w, "failed to get budget", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
+
+// CreateOverride handles POST /api/v1/budget/override
+// @Summary Override the budget hard cap
+// @Description Allows calls and batches past the hard cap until the given time (default: the end of the month) and resumes batches paused over budget. Admin only.
+// @Tags budget
+// @Accept json
+// @Produce json
+// @Param request body service.BudgetOverrideRequest true "Override"
+// @Success 200 {object} service.BudgetStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "No hard cap is configured"
+// @Router /api/v1/budget/override [post]
+func (h *BudgetAPIHandler) CreateOverride(w http.ResponseWriter, r *http.Request) {
+	var req service.BudgetOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.CreatedBy = &user.ID
+	}
+
+	status, err := h.budgetGuard.Override(r.Context(), &req)
+	if err != nil {
+		h.respondBudgetError(w, "failed to override budget", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
+
+// DeleteOverride handles DELETE /api/v1/budget/override
+// @Summary Revoke the budget override
+// @Description Revokes the override in effect. If spend is over the hard cap, calling is blocked again and running batches are paused. Admin only.
+// @Tags budget
+// @Produce json
+// @Success 200 {object} service.BudgetStatus
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/budget/override [delete]
+func (h *BudgetAPIHandler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	status, err := h.budgetGuard.ClearOverride(r.Context())
+	if err != nil {
+		h.respondBudgetError(w, "failed to revoke budget override", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
+
+func (h *BudgetAPIHandler) respondBudgetError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/export"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// BulkBody is the body of a request to apply one action to many calls or
+// quotes.
+type BulkBody struct {
+	Action string      `json:"action" validate:"required,oneof=archive tag rerun_quote export assign"`
+	IDs    []uuid.UUID `json:"ids"`
+	Tag    string      `json:"tag,omitempty"`                              // Tag to apply, for tag
+	UserID string      `json:"user_id,omitempty" validate:"uuid"`          // User to assign to, for assign; empty unassigns
+	Format string      `json:"format,omitempty" validate:"oneof=csv xlsx"` // File format, for export; csv by default
+}
+
+// request converts the body to a service request.
+func (b *BulkBody) request() *service.BulkRequest {
+	req := &service.BulkRequest{
+		Action: domain.BulkAction(b.Action),
+		IDs:    b.IDs,
+		Tag:    b.Tag,
+	}
+	if id, err := uuid.Parse(b.UserID); err == nil {
+		req.UserID = &id
+	}
+	return req
+}
+
+// serveBulk applies a bulk action to the records named in the body. The
+// per-record report is returned with 200 when the action was applied and
+// 422 when it wasn't; exports stream the file instead once every record
+// checks out.
+func serveBulk(w http.ResponseWriter, r *http.Request, recordType domain.BulkRecordType, bulk *service.BulkService, exports *export.Service, write exportFunc, logger *zap.Logger) {
+	var body BulkBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+	req := body.request()
+
+	if req.Action == domain.BulkActionExport {
+		if exports == nil {
+			APIError(w, http.StatusServiceUnavailable, "export not configured")
+			return
+		}
+		format, err := export.ParseFormat(body.Format)
+		if err != nil {
+			APIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		result, filter, err := bulk.ExportFilter(r.Context(), recordType, req.IDs)
+		if err != nil {
+			respondBulkError(w, err, logger)
+			return
+		}
+		if !result.Applied {
+			JSON(w, http.StatusUnprocessableEntity, result)
+			return
+		}
+		streamExport(w, r, string(recordType)+"s", format, filter, write, logger)
+		return
+	}
+
+	result, err := bulk.Run(r.Context(), userActor(r), recordType, req)
+	if err != nil {
+		respondBulkError(w, err, logger)
+		return
+	}
+	if !result.Applied {
+		JSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+	JSON(w, http.StatusOK, result)
+}
+
+func respondBulkError(w http.ResponseWriter, err error, logger *zap.Logger) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	logger.Error("bulk action failed", zap.Error(err))
+	APIServiceError(w, err, "bulk action failed")
+}
@@ -1,22 +1,40 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/export"
 	"github.com/jkindrix/quickquote/internal/service"
 )
 
 // CallAPIHandler handles call-related API endpoints.
 type CallAPIHandler struct {
-	blandService *service.BlandService
-	auditLogger  *audit.Logger
-	logger       *zap.Logger
+	blandService  *service.BlandService
+	callService   *service.CallService
+	exportService *export.Service
+	searchService *service.SearchService
+	recordings    *service.RecordingService
+	costService   *service.CallCostService
+	control       *service.CallControlService
+	retries       *service.CallRetryService
+	archive       *service.CallArchiveService
+	bulk          *service.BulkService
+	auditLogger   *audit.Logger
+	logger        *zap.Logger
 }
 
 // NewCallAPIHandler creates a new CallAPIHandler.
@@ -28,22 +46,84 @@ func NewCallAPIHandler(blandService *service.BlandService, auditLogger *audit.Lo
 	}
 }
 
+// SetCallService sets the service used to list call history.
+func (h *CallAPIHandler) SetCallService(cs *service.CallService) {
+	h.callService = cs
+}
+
+// SetExportService sets the service used to export call history.
+func (h *CallAPIHandler) SetExportService(es *export.Service) {
+	h.exportService = es
+}
+
+// SetSearchService sets the service used for transcript search.
+func (h *CallAPIHandler) SetSearchService(ss *service.SearchService) {
+	h.searchService = ss
+}
+
+// SetRecordingService sets the service that serves archived call recordings.
+func (h *CallAPIHandler) SetRecordingService(rs *service.RecordingService) {
+	h.recordings = rs
+}
+
+// SetCostService sets the service that reports call costs.
+func (h *CallAPIHandler) SetCostService(cs *service.CallCostService) {
+	h.costService = cs
+}
+
+// SetCallControlService sets the service that ends, transfers and sends
+// messages to calls in progress.
+func (h *CallAPIHandler) SetCallControlService(cs *service.CallControlService) {
+	h.control = cs
+}
+
+// SetCallRetryService sets the service that re-dials calls that went to
+// voicemail or were not answered.
+func (h *CallAPIHandler) SetCallRetryService(rs *service.CallRetryService) {
+	h.retries = rs
+}
+
+// SetCallArchiveService sets the service that archives, deletes and
+// restores calls.
+func (h *CallAPIHandler) SetCallArchiveService(as *service.CallArchiveService) {
+	h.archive = as
+}
+
+// SetBulkService sets the service that applies one action to many calls.
+func (h *CallAPIHandler) SetBulkService(bs *service.BulkService) {
+	h.bulk = bs
+}
+
 // RegisterRoutes registers call API routes.
 func (h *CallAPIHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/calls", func(r chi.Router) {
+		r.Get("/", h.ListCalls)
 		r.Post("/", h.InitiateCall)
 		r.Get("/active", h.GetActiveCalls)
+		r.Get("/export", h.ExportCalls)
+		r.Post("/bulk", h.BulkCalls)
+		r.Get("/search", h.SearchCalls)
+		r.Get("/costs", h.GetCallCosts)
 		r.Get("/{callID}", h.GetCallStatus)
-		r.Post("/{callID}/end", h.EndCall)
+		r.With(RequireAPIAdmin).Delete("/{callID}", h.DeleteCall)
+		r.With(RequireAPIAdmin).Post("/{callID}/restore", h.RestoreCall)
+		r.Post("/{callID}/archive", h.ArchiveCall)
+		r.Post("/{callID}/unarchive", h.UnarchiveCall)
+		r.With(RequireAPIAdmin).Post("/{callID}/end", h.EndCall)
+		r.With(RequireAPIAdmin).Post("/{callID}/transfer", h.TransferCall)
+		r.With(RequireAPIAdmin).Post("/{callID}/message", h.SendCallMessage)
 		r.Get("/{callID}/transcript", h.GetCallTranscript)
+		r.Get("/{callID}/recording", h.GetCallRecording)
+		r.Get("/{callID}/attempts", h.ListCallAttempts)
+		r.Get("/{callID}/timeline", h.GetCallTimeline)
 		r.Post("/{callID}/analyze", h.AnalyzeCall)
 	})
 }
 
 // InitiateCallRequest is the API request body for initiating a call.
 type InitiateCallRequest struct {
-	PhoneNumber   string                 `json:"phone_number"`
-	PromptID      string                 `json:"prompt_id,omitempty"`
+	PhoneNumber   string                 `json:"phone_number" validate:"required,e164"`
+	PromptID      string                 `json:"prompt_id,omitempty" validate:"uuid"`
 	Task          string                 `json:"task,omitempty"`
 	Voice         string                 `json:"voice,omitempty"`
 	FirstSentence string                 `json:"first_sentence,omitempty"`
@@ -51,7 +131,7 @@ type InitiateCallRequest struct {
 	Metadata      map[string]interface{} `json:"metadata,omitempty"`
 	PathwayID     string                 `json:"pathway_id,omitempty"`
 	PersonaID     string                 `json:"persona_id,omitempty"`
-	MaxDuration   *int                   `json:"max_duration,omitempty"`
+	MaxDuration   *int                   `json:"max_duration,omitempty" validate:"duration"`
 	Record        *bool                  `json:"record,omitempty"`
 	ScheduledTime string                 `json:"scheduled_time,omitempty"`
 }
@@ -65,18 +145,14 @@ type InitiateCallRequest struct {
 // @Param request body InitiateCallRequest true "Call initiation request"
 // @Success 201 {object} service.InitiateCallResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse "Monthly budget hard cap reached"
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Failure 451 {object} ErrorResponse "Number is on the do-not-call list or outside the calling window"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/calls [post]
 func (h *CallAPIHandler) InitiateCall(w http.ResponseWriter, r *http.Request) {
 	var req InitiateCallRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-
-	// Validate required fields
-	if req.PhoneNumber == "" {
-		h.respondError(w, http.StatusBadRequest, "phone_number is required")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -94,6 +170,9 @@ func (h *CallAPIHandler) InitiateCall(w http.ResponseWriter, r *http.Request) {
 		Record:        req.Record,
 		ScheduledTime: req.ScheduledTime,
 	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		svcReq.PlacedBy = &user.ID
+	}
 
 	// Parse prompt ID if provided
 	if req.PromptID != "" {
@@ -107,9 +186,13 @@ func (h *CallAPIHandler) InitiateCall(w http.ResponseWriter, r *http.Request) {
 
 	// Initiate the call
 	resp, err := h.blandService.InitiateCall(r.Context(), svcReq)
+	if code := apperrors.GetCode(err); code == apperrors.CodeBudgetExceeded || code == apperrors.CodeComplianceBlocked {
+		APIAppError(w, err)
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to initiate call", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to initiate call: "+err.Error())
+		APIServiceError(w, err, "failed to initiate call: "+err.Error())
 		return
 	}
 
@@ -127,6 +210,136 @@ func (h *CallAPIHandler) InitiateCall(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, http.StatusCreated, resp)
 }
 
+// ListCalls handles GET /api/v1/calls
+// @Summary List calls
+// @Description Lists calls matching the filters, newest first unless sorted otherwise. Pass next_cursor back as cursor for the next page; it is empty on the last page.
+// @Tags calls
+// @Produce json
+// @Param cursor query string false "Cursor from a previous page; omit to start at the beginning"
+// @Param limit query int false "Maximum calls to return (default 20, max 100)"
+// @Param sort query string false "newest (default) or oldest"
+// @Param status query string false "Call status"
+// @Param provider query string false "Voice provider, e.g. bland"
+// @Param phone_number query string false "Matches the called or calling number"
+// @Param tag query string false "Only calls tagged with all of these comma-separated tags"
+// @Param quote_tag query string false "Only calls whose quote is tagged with all of these comma-separated tags"
+// @Param from query string false "Created on or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339) or on (YYYY-MM-DD)"
+// @Param has_quote query bool false "Only calls with a generated quote"
+// @Param sentiment query string false "Only calls tagged positive, neutral or negative"
+// @Param intent query string false "Only calls tagged new_project, pricing_question, complaint, spam or other"
+// @Param urgency query string false "Only calls tagged low, medium or high urgency"
+// @Param spam query bool false "Only calls scored as spam (true) or only calls that were not (false)"
+// @Param disposition query string false "Only calls with this disposition: quoted, callback_scheduled, not_interested, wrong_number, voicemail or spam"
+// @Param archived query bool false "List archived calls instead of current ones. Admins only."
+// @Success 200 {object} service.CallPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls [get]
+func (h *CallAPIHandler) ListCalls(w http.ResponseWriter, r *http.Request) {
+	if h.callService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call listing not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := parseCallListFilter(query)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Archived {
+		if user := GetUserFromContext(r.Context()); user == nil || !user.IsAdmin() {
+			h.respondError(w, http.StatusForbidden, "admin access required")
+			return
+		}
+	}
+
+	limit := 0
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			h.respondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.callService.ListCallPage(r.Context(), filter, query.Get("cursor"), limit)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to list calls", zap.Error(err))
+		APIServiceError(w, err, "failed to list calls")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, page)
+}
+
+// parseCallListFilter builds a call filter from list query parameters: the
+// export filters, which include tags, plus provider, has_quote, sentiment,
+// intent, urgency, spam, disposition, archived and sort.
+func parseCallListFilter(query url.Values) (*domain.CallListFilter, error) {
+	filter, err := parseExportFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Provider = strings.ToLower(strings.TrimSpace(query.Get("provider")))
+
+	if hasQuote := strings.TrimSpace(query.Get("has_quote")); hasQuote != "" {
+		filter.HasQuote, err = strconv.ParseBool(hasQuote)
+		if err != nil {
+			return nil, fmt.Errorf("invalid has_quote %q", hasQuote)
+		}
+	}
+
+	if sentiment := strings.TrimSpace(query.Get("sentiment")); sentiment != "" {
+		if filter.Sentiment, err = domain.ParseCallSentiment(sentiment); err != nil {
+			return nil, err
+		}
+	}
+	if intent := strings.TrimSpace(query.Get("intent")); intent != "" {
+		if filter.Intent, err = domain.ParseCallIntent(intent); err != nil {
+			return nil, err
+		}
+	}
+	if urgency := strings.TrimSpace(query.Get("urgency")); urgency != "" {
+		if filter.Urgency, err = domain.ParseCallUrgency(urgency); err != nil {
+			return nil, err
+		}
+	}
+	if spam := strings.TrimSpace(query.Get("spam")); spam != "" {
+		isSpam, err := strconv.ParseBool(spam)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spam %q", spam)
+		}
+		filter.Spam = &isSpam
+	}
+	if disposition := strings.TrimSpace(query.Get("disposition")); disposition != "" {
+		if filter.Disposition, err = domain.ParseCallDisposition(disposition); err != nil {
+			return nil, err
+		}
+	}
+	if archived := strings.TrimSpace(query.Get("archived")); archived != "" {
+		filter.Archived, err = strconv.ParseBool(archived)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archived %q", archived)
+		}
+	}
+
+	filter.Sort, err = domain.ParseCallSort(query.Get("sort"))
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
 // GetCallStatus handles GET /api/v1/calls/{callID}
 // @Summary Get call status
 // @Description Retrieves the current status of a call
@@ -147,7 +360,7 @@ func (h *CallAPIHandler) GetCallStatus(w http.ResponseWriter, r *http.Request) {
 	details, err := h.blandService.GetCallStatus(r.Context(), callID)
 	if err != nil {
 		h.logger.Error("failed to get call status", zap.String("call_id", callID), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get call status")
+		APIServiceError(w, err, "failed to get call status")
 		return
 	}
 
@@ -156,12 +369,16 @@ func (h *CallAPIHandler) GetCallStatus(w http.ResponseWriter, r *http.Request) {
 
 // EndCall handles POST /api/v1/calls/{callID}/end
 // @Summary End an active call
-// @Description Terminates an ongoing call
+// @Description Hangs up a call in progress. The call is identified by its ID or its Bland call ID. Admins only.
 // @Tags calls
 // @Produce json
-// @Param callID path string true "Bland Call ID"
+// @Param callID path string true "Call ID or Bland call ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/calls/{callID}/end [post]
 func (h *CallAPIHandler) EndCall(w http.ResponseWriter, r *http.Request) {
@@ -171,20 +388,22 @@ func (h *CallAPIHandler) EndCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.blandService.EndCall(r.Context(), callID); err != nil {
+	if h.control != nil {
+		call, err := h.control.EndCall(r.Context(), callID)
+		if err != nil {
+			h.respondCallControlError(w, "failed to end call", callID, err)
+			return
+		}
+		callID = call.ID.String()
+	} else if err := h.blandService.EndCall(r.Context(), callID); err != nil {
 		h.logger.Error("failed to end call", zap.String("call_id", callID), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to end call")
+		APIServiceError(w, err, "failed to end call")
 		return
 	}
 
 	// Audit log the call termination
 	if h.auditLogger != nil {
-		user := GetUserFromContext(r.Context())
-		userID, userName := "", ""
-		if user != nil {
-			userID = user.ID.String()
-			userName = user.Email
-		}
+		userID, userName := auditActor(r)
 		h.auditLogger.CallEnded(r.Context(), userID, userName, callID, getClientIP(r), GetRequestIDFromContext(r.Context()))
 	}
 
@@ -194,17 +413,159 @@ func (h *CallAPIHandler) EndCall(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// TransferCallRequest is the API request body for transferring a call.
+type TransferCallRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+}
+
+// TransferCall handles POST /api/v1/calls/{callID}/transfer
+// @Summary Transfer an active call
+// @Description Hands a call in progress over to a phone number, such as a person who can take over from the agent. Admins only.
+// @Tags calls
+// @Accept json
+// @Produce json
+// @Param callID path string true "Call ID or Bland call ID"
+// @Param request body TransferCallRequest true "Number to transfer to"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/transfer [post]
+func (h *CallAPIHandler) TransferCall(w http.ResponseWriter, r *http.Request) {
+	if h.control == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call control is not configured")
+		return
+	}
+
+	var req TransferCallRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	callID := chi.URLParam(r, "callID")
+	call, err := h.control.TransferCall(r.Context(), callID, req.PhoneNumber)
+	if err != nil {
+		h.respondCallControlError(w, "failed to transfer call", callID, err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.CallTransferred(r.Context(), userID, userName, call.ID.String(), req.PhoneNumber, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "call transferred",
+	})
+}
+
+// CallMessageRequest is the API request body for sending a message into a call.
+type CallMessageRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+// SendCallMessage handles POST /api/v1/calls/{callID}/message
+// @Summary Send a message into an active call
+// @Description Injects a message into a call in progress for the agent to act on, for example to say something or change course. Admins only.
+// @Tags calls
+// @Accept json
+// @Produce json
+// @Param callID path string true "Call ID or Bland call ID"
+// @Param request body CallMessageRequest true "Message for the agent"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Failure 502 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/message [post]
+func (h *CallAPIHandler) SendCallMessage(w http.ResponseWriter, r *http.Request) {
+	if h.control == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call control is not configured")
+		return
+	}
+
+	var req CallMessageRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	callID := chi.URLParam(r, "callID")
+	call, err := h.control.SendMessage(r.Context(), callID, req.Message)
+	if err != nil {
+		h.respondCallControlError(w, "failed to send message to call", callID, err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.CallMessageSent(r.Context(), userID, userName, call.ID.String(), req.Message, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "message sent",
+	})
+}
+
+// respondCallControlError reports a failure to act on a call in progress.
+func (h *CallAPIHandler) respondCallControlError(w http.ResponseWriter, msg, callID string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.String("call_id", callID), zap.Error(err))
+	h.respondError(w, http.StatusBadGateway, msg)
+}
+
+// auditActor returns the ID and email of the user making the request.
+func auditActor(r *http.Request) (string, string) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		return "", ""
+	}
+	return user.ID.String(), user.Email
+}
+
+// CallTranscriptSegmentsResponse is a call's transcript as timed segments,
+// with where to play the recording they follow.
+type CallTranscriptSegmentsResponse struct {
+	CallID          uuid.UUID                  `json:"call_id"`
+	DurationSeconds *int                       `json:"duration_seconds,omitempty"`
+	RecordingURL    string                     `json:"recording_url,omitempty"`
+	Segments        []domain.TranscriptSegment `json:"segments"`
+}
+
 // GetCallTranscript handles GET /api/v1/calls/{callID}/transcript
 // @Summary Get call transcript
-// @Description Retrieves the transcript for a completed call
+// @Description Retrieves the transcript for a completed call. With format=segments, callID is our call ID and the stored transcript is returned as timed segments, with the recording URL, for following along with playback; otherwise callID is the Bland call ID and Bland's transcript is returned.
 // @Tags calls
 // @Produce json
-// @Param callID path string true "Bland Call ID"
-// @Success 200 {object} bland.TranscriptResponse
+// @Param callID path string true "Bland Call ID, or Call ID with format=segments"
+// @Param format query string false "segments for timed segments"
+// @Success 200 {object} bland.TranscriptResponse "Bland's transcript; a CallTranscriptSegmentsResponse with format=segments"
 // @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/calls/{callID}/transcript [get]
 func (h *CallAPIHandler) GetCallTranscript(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Query().Get("format") {
+	case "":
+	case "segments":
+		h.getCallTranscriptSegments(w, r)
+		return
+	default:
+		h.respondError(w, http.StatusBadRequest, "format must be segments")
+		return
+	}
+
 	callID := chi.URLParam(r, "callID")
 	if callID == "" {
 		h.respondError(w, http.StatusBadRequest, "call_id is required")
@@ -214,13 +575,64 @@ func (h *CallAPIHandler) GetCallTranscript(w http.ResponseWriter, r *http.Reques
 	transcript, err := h.blandService.GetCallTranscript(r.Context(), callID)
 	if err != nil {
 		h.logger.Error("failed to get transcript", zap.String("call_id", callID), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get transcript")
+		APIServiceError(w, err, "failed to get transcript")
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, transcript)
 }
 
+// getCallTranscriptSegments responds with a call's stored transcript as
+// timed segments.
+func (h *CallAPIHandler) getCallTranscriptSegments(w http.ResponseWriter, r *http.Request) {
+	if h.callService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call listing not configured")
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid call ID")
+		return
+	}
+
+	call, err := h.callService.GetCall(r.Context(), callID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			h.respondError(w, http.StatusNotFound, "call not found")
+			return
+		}
+		h.logger.Error("failed to get call", zap.String("call_id", callID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to get transcript")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, CallTranscriptSegmentsResponse{
+		CallID:          call.ID,
+		DurationSeconds: call.DurationSeconds,
+		RecordingURL:    h.recordingURL(r.Context(), call),
+		Segments:        call.TranscriptSegments(),
+	})
+}
+
+// recordingURL returns where to play a call's recording: our archived copy
+// when it has been stored, otherwise the provider's URL, if any.
+func (h *CallAPIHandler) recordingURL(ctx context.Context, call *domain.Call) string {
+	if h.recordings != nil {
+		recording, err := h.recordings.Get(ctx, call.ID)
+		if err == nil && recording.IsAvailable() {
+			return "/api/v1/calls/" + call.ID.String() + "/recording"
+		}
+		if err != nil && !apperrors.IsNotFound(err) {
+			h.logger.Warn("failed to get recording", zap.String("call_id", call.ID.String()), zap.Error(err))
+		}
+	}
+	if call.RecordingURL != nil {
+		return *call.RecordingURL
+	}
+	return ""
+}
+
 // AnalyzeCallRequest is the request body for analyzing a call.
 type AnalyzeCallRequest struct {
 	Goal      string   `json:"goal,omitempty"`
@@ -247,15 +659,14 @@ func (h *CallAPIHandler) AnalyzeCall(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req AnalyzeCallRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	analysis, err := h.blandService.AnalyzeCall(r.Context(), callID, req.Goal, req.Questions)
 	if err != nil {
 		h.logger.Error("failed to analyze call", zap.String("call_id", callID), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to analyze call")
+		APIServiceError(w, err, "failed to analyze call")
 		return
 	}
 
@@ -285,17 +696,371 @@ func (h *CallAPIHandler) GetActiveCalls(w http.ResponseWriter, r *http.Request)
 	active, err := h.blandService.GetActiveCalls(r.Context())
 	if err != nil {
 		h.logger.Error("failed to get active calls", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to get active calls")
+		APIServiceError(w, err, "failed to get active calls")
 		return
 	}
 
 	h.respondJSON(w, http.StatusOK, active)
 }
 
-// ErrorResponse represents an API error response.
+// ExportCalls handles GET /api/v1/calls/export
+// @Summary Export call history
+// @Description Streams call history matching the filters as CSV or XLSX
+// @Tags calls
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default) or xlsx"
+// @Param from query string false "Created on or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339) or on (YYYY-MM-DD)"
+// @Param status query string false "Call status"
+// @Param phone_number query string false "Matches the called or calling number"
+// @Param tag query string false "Only calls tagged with all of these comma-separated tags"
+// @Param quote_tag query string false "Only calls whose quote is tagged with all of these comma-separated tags"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/export [get]
+func (h *CallAPIHandler) ExportCalls(w http.ResponseWriter, r *http.Request) {
+	if h.exportService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "export not configured")
+		return
+	}
+	serveExport(w, r, "calls", h.exportService.WriteCalls, h.logger)
+}
+
+// BulkCalls handles POST /api/v1/calls/bulk
+// @Summary Apply an action to many calls
+// @Description Archives, tags, re-runs quotes for, exports or assigns the listed calls. Every call is checked first; if the action can't be applied to one of them, none is changed and the report is returned with 422, marking the calls that failed and why and the others rejected. Archiving, tagging and assigning change every call in one transaction. rerun_quote queues a quote job per call and reports each job. export streams the calls as CSV or XLSX (format). assign takes user_id, or unassigns without it. tag takes the tag name and creates the tag if it is new.
+// @Tags calls
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param request body BulkBody true "Bulk action"
+// @Success 200 {object} domain.BulkResult "Applied, or the export file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} domain.BulkResult "Not applied; see the failed items"
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/bulk [post]
+func (h *CallAPIHandler) BulkCalls(w http.ResponseWriter, r *http.Request) {
+	if h.bulk == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "bulk actions not configured")
+		return
+	}
+	var write exportFunc
+	if h.exportService != nil {
+		write = h.exportService.WriteCalls
+	}
+	serveBulk(w, r, domain.BulkRecordCall, h.bulk, h.exportService, write, h.logger)
+}
+
+// SearchCalls handles GET /api/v1/calls/search
+// @Summary Search call transcripts
+// @Description Full-text search across call transcripts, ranked by relevance with highlighted snippets
+// @Tags calls
+// @Produce json
+// @Param q query string true "Search query (supports quoted phrases, OR and -exclusions)"
+// @Param limit query int false "Maximum results (default 20, max 100)"
+// @Param offset query int false "Results to skip"
+// @Success 200 {object} service.TranscriptSearchResults
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/search [get]
+func (h *CallAPIHandler) SearchCalls(w http.ResponseWriter, r *http.Request) {
+	if h.searchService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "search not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	results, err := h.searchService.SearchTranscripts(r.Context(), query.Get("q"), limit, offset)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.logger.Error("failed to search transcripts", zap.Error(err))
+		APIServiceError(w, err, "failed to search transcripts")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, results)
+}
+
+// GetCallCosts handles GET /api/v1/calls/costs
+// @Summary Get monthly call costs per number
+// @Description Totals the estimated costs of finished calls by month and the number they were billed to, newest month first
+// @Tags calls
+// @Produce json
+// @Param months query int false "Months to include, counting the current one (default 3, max 24)"
+// @Success 200 {object} service.CallCostRollups
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/costs [get]
+func (h *CallAPIHandler) GetCallCosts(w http.ResponseWriter, r *http.Request) {
+	if h.costService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call costs not configured")
+		return
+	}
+
+	months, _ := strconv.Atoi(r.URL.Query().Get("months"))
+
+	rollups, err := h.costService.MonthlyRollups(r.Context(), months)
+	if err != nil {
+		h.logger.Error("failed to get call costs", zap.Error(err))
+		APIServiceError(w, err, "failed to get call costs")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, rollups)
+}
+
+// GetCallRecording handles GET /api/v1/calls/{callID}/recording
+// @Summary Stream a call recording
+// @Description Streams the archived copy of a call's recording
+// @Tags calls
+// @Produce audio/mpeg
+// @Param callID path string true "Call ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/recording [get]
+func (h *CallAPIHandler) GetCallRecording(w http.ResponseWriter, r *http.Request) {
+	if h.recordings == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "recording storage not configured")
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid call ID")
+		return
+	}
+
+	recording, obj, err := h.recordings.Open(r.Context(), callID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			msg := "recording not found"
+			if recording != nil {
+				msg = recordingUnavailableMessage(recording.Status)
+			}
+			h.respondError(w, http.StatusNotFound, msg)
+			return
+		}
+		h.logger.Error("failed to open recording", zap.String("call_id", callID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to open recording")
+		return
+	}
+	defer obj.Body.Close()
+
+	w.Header().Set("Content-Type", obj.ContentType)
+	if obj.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", path.Base(recording.StorageKey)))
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, obj.Body); err != nil {
+		h.logger.Debug("recording stream interrupted", zap.String("call_id", callID.String()), zap.Error(err))
+	}
+}
+
+// ListCallAttempts handles GET /api/v1/calls/{callID}/attempts
+// @Summary List a call's retries
+// @Description Returns the retries scheduled after the call went to voicemail or was not answered, in attempt order.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID of the original call"
+// @Success 200 {array} domain.CallAttempt
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/attempts [get]
+func (h *CallAPIHandler) ListCallAttempts(w http.ResponseWriter, r *http.Request) {
+	if h.retries == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call retries not configured")
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid call ID")
+		return
+	}
+
+	attempts, err := h.retries.ListAttempts(r.Context(), callID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			h.respondError(w, http.StatusNotFound, "call not found")
+			return
+		}
+		h.logger.Error("failed to list call attempts", zap.String("call_id", callID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to list call attempts")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, attempts)
+}
+
+// GetCallTimeline handles GET /api/v1/calls/{callID}/timeline
+// @Summary Get a call's status history
+// @Description Returns every status the call was reported in, in the order the statuses took effect, and the status replayed from them. Events that arrived after the call reached a later stage, such as a late in-progress webhook, are listed with applied set to false.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} service.CallTimeline
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/timeline [get]
+func (h *CallAPIHandler) GetCallTimeline(w http.ResponseWriter, r *http.Request) {
+	if h.callService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call listing not configured")
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid call ID")
+		return
+	}
+
+	timeline, err := h.callService.GetCallTimeline(r.Context(), callID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			h.respondError(w, http.StatusNotFound, "call not found")
+			return
+		}
+		h.logger.Error("failed to get call timeline", zap.String("call_id", callID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to get call timeline")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, timeline)
+}
+
+// ArchiveCall handles POST /api/v1/calls/{callID}/archive
+// @Summary Archive a call
+// @Description Archives a call and its quote. Archived calls are left out of call lists unless archived=true is passed, and archived quotes can't be edited or moved through the workflow.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/archive [post]
+func (h *CallAPIHandler) ArchiveCall(w http.ResponseWriter, r *http.Request) {
+	h.changeCall(w, r, "archive", "call archived", h.archive.Archive, (*audit.Logger).CallArchived)
+}
+
+// UnarchiveCall handles POST /api/v1/calls/{callID}/unarchive
+// @Summary Unarchive a call
+// @Description Returns an archived call and its quote to call lists and the quote workflow.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/unarchive [post]
+func (h *CallAPIHandler) UnarchiveCall(w http.ResponseWriter, r *http.Request) {
+	h.changeCall(w, r, "unarchive", "call unarchived", h.archive.Unarchive, (*audit.Logger).CallUnarchived)
+}
+
+// DeleteCall handles DELETE /api/v1/calls/{callID}
+// @Summary Delete a call
+// @Description Soft-deletes a call and its quote. Deleted calls are hidden everywhere but can be restored. Admins only.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID} [delete]
+func (h *CallAPIHandler) DeleteCall(w http.ResponseWriter, r *http.Request) {
+	h.changeCall(w, r, "delete", "call deleted", h.archive.Delete, (*audit.Logger).CallDeleted)
+}
+
+// RestoreCall handles POST /api/v1/calls/{callID}/restore
+// @Summary Restore a deleted call
+// @Description Brings back a soft-deleted call and its quote. Admins only.
+// @Tags calls
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "No deleted call with this ID"
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/restore [post]
+func (h *CallAPIHandler) RestoreCall(w http.ResponseWriter, r *http.Request) {
+	h.changeCall(w, r, "restore", "call restored", h.archive.Restore, (*audit.Logger).CallRestored)
+}
+
+// callAuditFunc records a change to a call in the audit log.
+type callAuditFunc func(l *audit.Logger, ctx context.Context, userID, userName, callID, ip, requestID string)
+
+// changeCall archives, unarchives, deletes or restores the call in the URL.
+func (h *CallAPIHandler) changeCall(w http.ResponseWriter, r *http.Request, verb, message string, apply func(context.Context, uuid.UUID) error, record callAuditFunc) {
+	if h.archive == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "call archiving not configured")
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid call ID")
+		return
+	}
+
+	if err := apply(r.Context(), callID); err != nil {
+		if apperrors.IsNotFound(err) {
+			h.respondError(w, http.StatusNotFound, "call not found")
+			return
+		}
+		h.logger.Error("failed to "+verb+" call", zap.String("call_id", callID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to "+verb+" call")
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		record(h.auditLogger, r.Context(), userID, userName, callID.String(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": message,
+	})
+}
+
+// recordingUnavailableMessage explains why a call's recording can't be played.
+func recordingUnavailableMessage(status domain.RecordingStatus) string {
+	switch status {
+	case domain.RecordingStatusPending:
+		return "recording has not been downloaded yet"
+	case domain.RecordingStatusFailed:
+		return "recording could not be downloaded"
+	case domain.RecordingStatusDeleted:
+		return "recording was deleted by the retention policy"
+	default:
+		return "recording not found"
+	}
+}
+
+// ErrorResponse represents an API error response. Code is stable, so
+// clients can branch on it; Message is for people.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error   string         `json:"error"`
+	Code    apperrors.Code `json:"code"`
+	Message string         `json:"message,omitempty"`
 }
 
 func (h *CallAPIHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
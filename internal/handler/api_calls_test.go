@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -13,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
 	"github.com/jkindrix/quickquote/internal/service"
 )
 
@@ -647,3 +649,211 @@ func TestErrorResponse_JSONSerialization(t *testing.T) {
 		t.Errorf("message mismatch: expected %q, got %q", resp.Message, decoded.Message)
 	}
 }
+
+// stubTranscriptSearch is a fixed-result domain.CallSearchRepository.
+type stubTranscriptSearch struct {
+	matches []*domain.TranscriptMatch
+}
+
+func (s *stubTranscriptSearch) SearchTranscripts(ctx context.Context, query string, limit, offset int) ([]*domain.TranscriptMatch, error) {
+	return s.matches, nil
+}
+
+func (s *stubTranscriptSearch) CountTranscriptMatches(ctx context.Context, query string) (int, error) {
+	return len(s.matches), nil
+}
+
+func TestCallAPIHandler_SearchCalls(t *testing.T) {
+	callID := uuid.New()
+	handler := NewCallAPIHandler(nil, nil, zap.NewNop())
+
+	r := chi.NewRouter()
+	handler.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodGet, "/calls/search?q=budget", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without search service, got %d", rr.Code)
+	}
+
+	handler.SetSearchService(service.NewSearchService(&stubTranscriptSearch{
+		matches: []*domain.TranscriptMatch{{
+			CallID:   callID,
+			Status:   domain.CallStatusCompleted,
+			Headline: "our [[hl]]budget[[/hl]] is flexible",
+		}},
+	}, zap.NewNop()))
+
+	req = httptest.NewRequest(http.MethodGet, "/calls/search?q=", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for empty query, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/calls/search?q=budget&limit=5", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp struct {
+		Query   string `json:"query"`
+		Total   int    `json:"total"`
+		Limit   int    `json:"limit"`
+		Results []struct {
+			CallID  uuid.UUID `json:"call_id"`
+			Snippet string    `json:"snippet"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Total != 1 || resp.Limit != 5 || len(resp.Results) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if resp.Results[0].CallID != callID || resp.Results[0].Snippet != "our <mark>budget</mark> is flexible" {
+		t.Errorf("unexpected result %+v", resp.Results[0])
+	}
+}
+
+func TestParseCallListFilter(t *testing.T) {
+	values, _ := url.ParseQuery("status=completed&provider=Vapi&has_quote=true&sort=oldest&from=2026-01-01&intent=Pricing-Question&sentiment=negative&spam=false")
+	f, err := parseCallListFilter(values)
+	if err != nil {
+		t.Fatalf("parseCallListFilter() error = %v", err)
+	}
+	if f.Status == nil || *f.Status != domain.CallStatusCompleted {
+		t.Errorf("unexpected status: %v", f.Status)
+	}
+	if f.Provider != "vapi" || !f.HasQuote || f.Sort != domain.CallSortOldest || f.CreatedAfter == nil {
+		t.Errorf("unexpected filter: %+v", f)
+	}
+	if f.Intent != domain.CallIntentPricingQuestion || f.Sentiment != domain.CallSentimentNegative || f.Urgency != "" {
+		t.Errorf("unexpected tag filters: %+v", f)
+	}
+	if f.Spam == nil || *f.Spam {
+		t.Errorf("unexpected spam filter: %v", f.Spam)
+	}
+
+	values, _ = url.ParseQuery("archived=true")
+	if f, err := parseCallListFilter(values); err != nil || !f.Archived {
+		t.Errorf("parseCallListFilter(archived=true) = %+v, %v", f, err)
+	}
+
+	for _, query := range []string{"has_quote=maybe", "sort=longest", "status=bogus", "intent=shopping", "urgency=asap", "spam=maybe", "archived=maybe"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := parseCallListFilter(values); err == nil {
+			t.Errorf("parseCallListFilter(%q) succeeded", query)
+		}
+	}
+}
+
+func TestCallAPIHandler_ListCalls_NotConfigured(t *testing.T) {
+	h := NewCallAPIHandler(nil, nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/calls", http.NoBody))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestCallAPIHandler_GetCallTranscript_Segments(t *testing.T) {
+	h := NewCallAPIHandler(nil, nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"unknown format", "/calls/" + uuid.NewString() + "/transcript?format=srt", http.StatusBadRequest},
+		{"not configured", "/calls/" + uuid.NewString() + "/transcript?format=segments", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+		if rr.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.name, tt.status, rr.Code)
+		}
+	}
+
+	h.SetCallService(&service.CallService{})
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/calls/bland-123/transcript?format=segments", http.NoBody))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Bland call ID: expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestCallAPIHandler_CallControls_RequireAdmin(t *testing.T) {
+	h := NewCallAPIHandler(nil, nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	member := &domain.User{ID: uuid.New(), Email: "member@example.com", Role: domain.UserRoleMember}
+	admin := &domain.User{ID: uuid.New(), Email: "admin@example.com", Role: domain.UserRoleAdmin}
+	body := `{"phone_number":"+15551234567","message":"hi"}`
+
+	for _, action := range []string{"end", "transfer", "message"} {
+		req := httptest.NewRequest(http.MethodPost, "/calls/bland-123/"+action, bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, member))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("%s by a member: expected status %d, got %d", action, http.StatusForbidden, rr.Code)
+		}
+	}
+
+	// Admins get through to handlers that report call control isn't set up
+	for _, action := range []string{"transfer", "message"} {
+		req := httptest.NewRequest(http.MethodPost, "/calls/bland-123/"+action, bytes.NewBufferString(body))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, admin))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s by an admin: expected status %d, got %d", action, http.StatusServiceUnavailable, rr.Code)
+		}
+	}
+}
+
+func TestCallAPIHandler_ArchiveAndDelete(t *testing.T) {
+	h := NewCallAPIHandler(nil, nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	member := &domain.User{ID: uuid.New(), Email: "member@example.com", Role: domain.UserRoleMember}
+	admin := &domain.User{ID: uuid.New(), Email: "admin@example.com", Role: domain.UserRoleAdmin}
+	callID := uuid.NewString()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		user   *domain.User
+		want   int
+	}{
+		{"member deletes", http.MethodDelete, "/calls/" + callID, member, http.StatusForbidden},
+		{"member restores", http.MethodPost, "/calls/" + callID + "/restore", member, http.StatusForbidden},
+		{"admin deletes", http.MethodDelete, "/calls/" + callID, admin, http.StatusServiceUnavailable},
+		{"member archives", http.MethodPost, "/calls/" + callID + "/archive", member, http.StatusServiceUnavailable},
+		{"member unarchives", http.MethodPost, "/calls/" + callID + "/unarchive", member, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			req = req.WithContext(context.WithValue(req.Context(), userContextKey, tt.user))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
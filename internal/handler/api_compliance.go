@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ComplianceAPIHandler handles the do-not-call list endpoints. Anyone may
+// read the list and add numbers to it; only admins may remove numbers or
+// import the national registry.
+type ComplianceAPIHandler struct {
+	complianceService *service.ComplianceService
+	logger            *zap.Logger
+}
+
+// NewComplianceAPIHandler creates a new ComplianceAPIHandler.
+func NewComplianceAPIHandler(complianceService *service.ComplianceService, logger *zap.Logger) *ComplianceAPIHandler {
+	return &ComplianceAPIHandler{
+		complianceService: complianceService,
+		logger:            logger,
+	}
+}
+
+// ListDNCResponse is a page of the do-not-call list.
+type ListDNCResponse struct {
+	Entries  []*domain.DNCEntry `json:"entries"`
+	Total    int                `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
+
+// ComplianceCheckResponse reports whether a number may be called now.
+type ComplianceCheckResponse struct {
+	PhoneNumber string                  `json:"phone_number"`
+	Allowed     bool                    `json:"allowed"`
+	Reason      domain.ComplianceReason `json:"reason,omitempty"`
+	NextAllowed *time.Time              `json:"next_allowed,omitempty"`
+}
+
+// RegisterRoutes registers compliance API routes.
+func (h *ComplianceAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/compliance", func(r chi.Router) {
+		r.Get("/check", h.CheckNumber)
+		r.Get("/dnc", h.ListNumbers)
+		r.Post("/dnc", h.AddNumber)
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAPIAdmin)
+			r.Delete("/dnc/{phoneNumber}", h.RemoveNumber)
+			r.Post("/dnc/import", h.ImportNational)
+		})
+	})
+}
+
+// CheckNumber handles GET /api/v1/compliance/check
+// @Summary Check whether a number may be called
+// @Description Reports whether a call to the number would be placed now, or why not: the number is on the do-not-call list, or the calling window is closed in a time zone its area code covers.
+// @Tags compliance
+// @Produce json
+// @Param phone_number query string true "Phone number"
+// @Success 200 {object} ComplianceCheckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/compliance/check [get]
+func (h *ComplianceAPIHandler) CheckNumber(w http.ResponseWriter, r *http.Request) {
+	phone := r.URL.Query().Get("phone_number")
+	if phone == "" {
+		APIError(w, http.StatusBadRequest, "phone_number is required")
+		return
+	}
+
+	resp := ComplianceCheckResponse{PhoneNumber: phone, Allowed: true}
+	err := h.complianceService.CheckCall(r.Context(), phone)
+	if cerr, ok := service.AsComplianceError(err); ok {
+		resp.Allowed = false
+		resp.Reason = cerr.Reason
+		resp.NextAllowed = cerr.NextAllowed
+	} else if err != nil {
+		h.logger.Error("failed to check number", zap.Error(err))
+		APIServiceError(w, err, "failed to check number")
+		return
+	}
+
+	JSON(w, http.StatusOK, resp)
+}
+
+// ListNumbers handles GET /api/v1/compliance/dnc
+// @Summary List the do-not-call list
+// @Description Retrieves numbers on the do-not-call list, newest first
+// @Tags compliance
+// @Produce json
+// @Param q query string false "Search phone numbers"
+// @Param source query string false "Only entries from this source (manual, sms_opt_out, national)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(20)
+// @Success 200 {object} ListDNCResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/compliance/dnc [get]
+func (h *ComplianceAPIHandler) ListNumbers(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filter := &domain.DNCListFilter{
+		Search: r.URL.Query().Get("q"),
+		Source: domain.DNCSource(r.URL.Query().Get("source")),
+	}
+	list, err := h.complianceService.ListNumbers(r.Context(), filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		h.logger.Error("failed to list do-not-call numbers", zap.Error(err))
+		APIServiceError(w, err, "failed to list do-not-call numbers")
+		return
+	}
+
+	JSON(w, http.StatusOK, ListDNCResponse{
+		Entries:  list.Entries,
+		Total:    list.Total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
+// AddNumber handles POST /api/v1/compliance/dnc
+// @Summary Add a number to the do-not-call list
+// @Description Blocks calls to the number. A number already on the list keeps its existing entry.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Param request body service.AddDNCRequest true "Number"
+// @Success 201 {object} domain.DNCEntry
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/compliance/dnc [post]
+func (h *ComplianceAPIHandler) AddNumber(w http.ResponseWriter, r *http.Request) {
+	var req service.AddDNCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.CreatedBy = &user.ID
+	}
+
+	entry, err := h.complianceService.AddNumber(r.Context(), &req)
+	if err != nil {
+		h.respondComplianceError(w, "failed to add do-not-call number", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, entry)
+}
+
+// RemoveNumber handles DELETE /api/v1/compliance/dnc/{phoneNumber}
+// @Summary Remove a number from the do-not-call list
+// @Description Allows calls to the number again, whatever put it on the list. Admin only.
+// @Tags compliance
+// @Param phoneNumber path string true "Phone number"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/compliance/dnc/{phoneNumber} [delete]
+func (h *ComplianceAPIHandler) RemoveNumber(w http.ResponseWriter, r *http.Request) {
+	if err := h.complianceService.RemoveNumber(r.Context(), chi.URLParam(r, "phoneNumber")); err != nil {
+		h.respondComplianceError(w, "failed to remove do-not-call number", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ImportNational handles POST /api/v1/compliance/dnc/import
+// @Summary Import national do-not-call numbers
+// @Description Adds the numbers in a national registry download, one per line, as a single number or "area code,number". With replace=true, national entries the file no longer lists are removed. Numbers added by staff or by SMS opt-out are left alone. Admin only.
+// @Tags compliance
+// @Accept plain
+// @Produce json
+// @Param replace query bool false "Remove national entries the file does not list"
+// @Success 200 {object} service.DNCImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/compliance/dnc/import [post]
+func (h *ComplianceAPIHandler) ImportNational(w http.ResponseWriter, r *http.Request) {
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+
+	result, err := h.complianceService.ImportNational(r.Context(), r.Body, replace)
+	if err != nil {
+		h.respondComplianceError(w, "failed to import do-not-call numbers", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, result)
+}
+
+func (h *ComplianceAPIHandler) respondComplianceError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// CustomerAPIHandler handles customer API endpoints.
+type CustomerAPIHandler struct {
+	customerService *service.CustomerService
+	conversations   *service.ConversationService
+	logger          *zap.Logger
+}
+
+// NewCustomerAPIHandler creates a new CustomerAPIHandler.
+func NewCustomerAPIHandler(customerService *service.CustomerService, logger *zap.Logger) *CustomerAPIHandler {
+	return &CustomerAPIHandler{
+		customerService: customerService,
+		logger:          logger,
+	}
+}
+
+// SetConversations enables a customer's message history and WhatsApp
+// opt-in. Call it before RegisterRoutes.
+func (h *CustomerAPIHandler) SetConversations(conversations *service.ConversationService) {
+	h.conversations = conversations
+}
+
+// RegisterRoutes registers customer API routes.
+func (h *CustomerAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/customers", func(r chi.Router) {
+		r.Get("/", h.ListCustomers)
+		r.Post("/", h.CreateCustomer)
+		r.Get("/{customerID}", h.GetCustomer)
+		r.Put("/{customerID}", h.UpdateCustomer)
+		r.Delete("/{customerID}", h.DeleteCustomer)
+		r.Get("/{customerID}/history", h.GetCustomerHistory)
+		if h.conversations != nil {
+			r.Get("/{customerID}/messages", h.ListCustomerMessages)
+			r.Put("/{customerID}/whatsapp-opt-in", h.UpdateWhatsAppOptIn)
+		}
+	})
+}
+
+// ListCustomersResponse is the response for listing customers.
+type ListCustomersResponse struct {
+	Customers interface{} `json:"customers"`
+	Total     int         `json:"total"`
+	Page      int         `json:"page"`
+	PageSize  int         `json:"page_size"`
+}
+
+// ListCustomers handles GET /api/v1/customers
+// @Summary List customers
+// @Description Retrieves customers, most recently updated first
+// @Tags customers
+// @Produce json
+// @Param q query string false "Search name, email, company or phone number"
+// @Param tag query string false "Only customers tagged with all of these comma-separated tags"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(20)
+// @Success 200 {object} ListCustomersResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/customers [get]
+func (h *CustomerAPIHandler) ListCustomers(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	tags, err := domain.ParseTagNames(r.URL.Query()["tag"])
+	if err != nil {
+		APIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter := &domain.CustomerListFilter{Search: r.URL.Query().Get("q"), Tags: tags}
+	customers, total, err := h.customerService.ListCustomers(r.Context(), filter, page, pageSize)
+	if err != nil {
+		h.logger.Error("failed to list customers", zap.Error(err))
+		APIServiceError(w, err, "failed to list customers")
+		return
+	}
+
+	JSON(w, http.StatusOK, ListCustomersResponse{
+		Customers: customers,
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+	})
+}
+
+// CreateCustomer handles POST /api/v1/customers
+// @Summary Create a customer
+// @Description Creates a customer. The phone number is normalized to E.164 and must be unique.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param request body service.CreateCustomerRequest true "Customer details"
+// @Success 201 {object} domain.Customer
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/customers [post]
+func (h *CustomerAPIHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req service.CreateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	customer, err := h.customerService.CreateCustomer(r.Context(), &req)
+	if err != nil {
+		h.respondCustomerError(w, "failed to create customer", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, customer)
+}
+
+// GetCustomer handles GET /api/v1/customers/{customerID}
+// @Summary Get a customer
+// @Tags customers
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Success 200 {object} domain.Customer
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID} [get]
+func (h *CustomerAPIHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(r.Context(), customerID)
+	if err != nil {
+		h.respondCustomerError(w, "failed to get customer", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, customer)
+}
+
+// GetCustomerHistory handles GET /api/v1/customers/{customerID}/history
+// @Summary Get a customer's call and quote history
+// @Description Returns the customer with their most recent calls and the quotes generated from them
+// @Tags customers
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Success 200 {object} service.CustomerHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID}/history [get]
+func (h *CustomerAPIHandler) GetCustomerHistory(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	history, err := h.customerService.GetHistory(r.Context(), customerID)
+	if err != nil {
+		h.respondCustomerError(w, "failed to get customer history", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, history)
+}
+
+// ListCustomerMessages handles GET /api/v1/customers/{customerID}/messages
+// @Summary List a customer's messages
+// @Description Returns the text and WhatsApp messages exchanged with the customer, oldest first
+// @Tags customers
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Success 200 {array} domain.ConversationMessage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID}/messages [get]
+func (h *CustomerAPIHandler) ListCustomerMessages(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(r.Context(), customerID)
+	if err != nil {
+		h.respondCustomerError(w, "failed to get customer", err)
+		return
+	}
+
+	messages, err := h.conversations.ListConversation(r.Context(), customer.PhoneNumber)
+	if err != nil {
+		h.respondCustomerError(w, "failed to list messages", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, messages)
+}
+
+// UpdateWhatsAppOptInRequest is the request body for recording a customer's
+// WhatsApp opt-in.
+type UpdateWhatsAppOptInRequest struct {
+	OptedIn bool `json:"opted_in"`
+}
+
+// WhatsAppOptInResponse is a customer's WhatsApp opt-in. OptIn is null for a
+// customer who never opted in.
+type WhatsAppOptInResponse struct {
+	OptedIn bool                  `json:"opted_in"`
+	OptIn   *domain.WhatsAppOptIn `json:"opt_in"`
+}
+
+// UpdateWhatsAppOptIn handles PUT /api/v1/customers/{customerID}/whatsapp-opt-in
+// @Summary Record a customer's WhatsApp opt-in
+// @Description Records that the customer agreed to, or withdrew from, WhatsApp messages
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Param request body UpdateWhatsAppOptInRequest true "Opt-in"
+// @Success 200 {object} WhatsAppOptInResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID}/whatsapp-opt-in [put]
+func (h *CustomerAPIHandler) UpdateWhatsAppOptIn(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	var req UpdateWhatsAppOptInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(r.Context(), customerID)
+	if err != nil {
+		h.respondCustomerError(w, "failed to get customer", err)
+		return
+	}
+
+	if err := h.conversations.SetWhatsAppOptIn(r.Context(), customer.PhoneNumber, req.OptedIn, domain.WhatsAppOptInSourceStaff); err != nil {
+		h.respondCustomerError(w, "failed to update WhatsApp opt-in", err)
+		return
+	}
+	optIn, err := h.conversations.GetWhatsAppOptIn(r.Context(), customer.PhoneNumber)
+	if err != nil {
+		h.respondCustomerError(w, "failed to get WhatsApp opt-in", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, WhatsAppOptInResponse{OptedIn: optIn.IsActive(), OptIn: optIn})
+}
+
+// UpdateCustomer handles PUT /api/v1/customers/{customerID}
+// @Summary Update a customer
+// @Description Updates the fields present in the request body
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Param request body service.UpdateCustomerRequest true "Fields to update"
+// @Success 200 {object} domain.Customer
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID} [put]
+func (h *CustomerAPIHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	var req service.UpdateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(r.Context(), customerID, &req)
+	if err != nil {
+		h.respondCustomerError(w, "failed to update customer", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, customer)
+}
+
+// DeleteCustomer handles DELETE /api/v1/customers/{customerID}
+// @Summary Delete a customer
+// @Description Deletes a customer. Their calls and quotes are kept but no longer linked.
+// @Tags customers
+// @Param customerID path string true "Customer ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID} [delete]
+func (h *CustomerAPIHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request) {
+	customerID, err := uuid.Parse(chi.URLParam(r, "customerID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid customer_id")
+		return
+	}
+
+	if err := h.customerService.DeleteCustomer(r.Context(), customerID); err != nil {
+		h.respondCustomerError(w, "failed to delete customer", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "customer deleted",
+	})
+}
+
+func (h *CustomerAPIHandler) respondCustomerError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, "customer not found")
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// EventAPIHandler serves the events feed that integrations poll to sync
+// calls and quotes.
+type EventAPIHandler struct {
+	eventService *service.EventService
+	logger       *zap.Logger
+}
+
+// NewEventAPIHandler creates a new EventAPIHandler.
+func NewEventAPIHandler(eventService *service.EventService, logger *zap.Logger) *EventAPIHandler {
+	return &EventAPIHandler{
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers events API routes.
+func (h *EventAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/events", h.ListEvents)
+}
+
+// ListEvents handles GET /api/v1/events
+// @Summary List events
+// @Description Returns changes to calls and quotes, oldest first. Pass next_cursor back as cursor to continue; it is unchanged when there are no new events, so it can be polled. Events are the same envelopes sent to outgoing webhooks.
+// @Tags events
+// @Produce json
+// @Param cursor query string false "Cursor from a previous page; omit to start at the beginning"
+// @Param type query string false "Only events of these types, comma separated (call.created, call.completed, quote.created, quote.approved, quote.status_changed)"
+// @Param limit query int false "Maximum events to return (default 100, max 500)"
+// @Success 200 {object} service.EventPage
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/events [get]
+func (h *EventAPIHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit := 0
+	if l := query.Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			APIError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = parsed
+	}
+
+	var types []string
+	for _, t := range query["type"] {
+		for _, part := range strings.Split(t, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				types = append(types, part)
+			}
+		}
+	}
+
+	page, err := h.eventService.ListEvents(r.Context(), query.Get("cursor"), types, limit)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to list events", zap.Error(err))
+		APIServiceError(w, err, "failed to list events")
+		return
+	}
+
+	JSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ExperimentAPIHandler handles prompt experiment API endpoints.
+type ExperimentAPIHandler struct {
+	experimentService *service.ExperimentService
+	logger            *zap.Logger
+}
+
+// NewExperimentAPIHandler creates a new ExperimentAPIHandler.
+func NewExperimentAPIHandler(experimentService *service.ExperimentService, logger *zap.Logger) *ExperimentAPIHandler {
+	return &ExperimentAPIHandler{
+		experimentService: experimentService,
+		logger:            logger,
+	}
+}
+
+// RegisterRoutes registers experiment API routes.
+func (h *ExperimentAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/experiments", func(r chi.Router) {
+		r.Get("/", h.ListExperiments)
+		r.Post("/", h.CreateExperiment)
+		r.Get("/{experimentID}", h.GetExperiment)
+		r.Delete("/{experimentID}", h.DeleteExperiment)
+		r.Post("/{experimentID}/start", h.StartExperiment)
+		r.Post("/{experimentID}/stop", h.StopExperiment)
+		r.Get("/{experimentID}/results", h.GetResults)
+	})
+}
+
+// ListExperiments handles GET /api/v1/experiments
+// @Summary List experiments
+// @Description Retrieves prompt experiments, newest first
+// @Tags experiments
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/experiments [get]
+func (h *ExperimentAPIHandler) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.experimentService.ListExperiments(r.Context(), 100)
+	if err != nil {
+		h.logger.Error("failed to list experiments", zap.Error(err))
+		APIServiceError(w, err, "failed to list experiments")
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"experiments": experiments,
+	})
+}
+
+// CreateExperiment handles POST /api/v1/experiments
+// @Summary Create an experiment
+// @Description Creates a draft experiment splitting inbound or outbound calls between two or more prompts. Variant weights are percentages and must add up to 100; the first variant is the control.
+// @Tags experiments
+// @Accept json
+// @Produce json
+// @Param request body service.CreateExperimentRequest true "Experiment details"
+// @Success 201 {object} domain.Experiment
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/experiments [post]
+func (h *ExperimentAPIHandler) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	var req service.CreateExperimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.CreatedBy = &user.ID
+	}
+
+	experiment, err := h.experimentService.CreateExperiment(r.Context(), &req)
+	if err != nil {
+		h.respondExperimentError(w, "failed to create experiment", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, experiment)
+}
+
+// GetExperiment handles GET /api/v1/experiments/{experimentID}
+// @Summary Get an experiment
+// @Tags experiments
+// @Produce json
+// @Param experimentID path string true "Experiment ID"
+// @Success 200 {object} domain.Experiment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/experiments/{experimentID} [get]
+func (h *ExperimentAPIHandler) GetExperiment(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.GetExperiment(r.Context(), experimentID)
+	if err != nil {
+		h.respondExperimentError(w, "failed to get experiment", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, experiment)
+}
+
+// DeleteExperiment handles DELETE /api/v1/experiments/{experimentID}
+// @Summary Delete an experiment
+// @Description Deletes an experiment and its results. Running experiments must be stopped first.
+// @Tags experiments
+// @Param experimentID path string true "Experiment ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/experiments/{experimentID} [delete]
+func (h *ExperimentAPIHandler) DeleteExperiment(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.experimentService.DeleteExperiment(r.Context(), experimentID); err != nil {
+		h.respondExperimentError(w, "failed to delete experiment", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "experiment deleted",
+	})
+}
+
+// StartExperiment handles POST /api/v1/experiments/{experimentID}/start
+// @Summary Start an experiment
+// @Description Starts splitting calls between the variants of a draft experiment
+// @Tags experiments
+// @Produce json
+// @Param experimentID path string true "Experiment ID"
+// @Success 200 {object} domain.Experiment
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/experiments/{experimentID}/start [post]
+func (h *ExperimentAPIHandler) StartExperiment(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.StartExperiment(r.Context(), experimentID)
+	if err != nil {
+		h.respondExperimentError(w, "failed to start experiment", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, experiment)
+}
+
+// StopExperiment handles POST /api/v1/experiments/{experimentID}/stop
+// @Summary Stop an experiment
+// @Description Stops splitting calls. Inbound numbers are put back on the control prompt.
+// @Tags experiments
+// @Produce json
+// @Param experimentID path string true "Experiment ID"
+// @Success 200 {object} domain.Experiment
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/experiments/{experimentID}/stop [post]
+func (h *ExperimentAPIHandler) StopExperiment(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	experiment, err := h.experimentService.StopExperiment(r.Context(), experimentID)
+	if err != nil {
+		h.respondExperimentError(w, "failed to stop experiment", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, experiment)
+}
+
+// GetResults handles GET /api/v1/experiments/{experimentID}/results
+// @Summary Get experiment results
+// @Description Returns call count, average duration, quote rate and dispositions for each variant, with significance tests of the quote rate (two-proportion z-test) and duration (Welch's t-test) against the control
+// @Tags experiments
+// @Produce json
+// @Param experimentID path string true "Experiment ID"
+// @Success 200 {object} domain.ExperimentResults
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/experiments/{experimentID}/results [get]
+func (h *ExperimentAPIHandler) GetResults(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.experimentService.GetResults(r.Context(), experimentID)
+	if err != nil {
+		h.respondExperimentError(w, "failed to get experiment results", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, results)
+}
+
+func (h *ExperimentAPIHandler) experimentID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	experimentID, err := uuid.Parse(chi.URLParam(r, "experimentID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid experiment_id")
+		return uuid.Nil, false
+	}
+	return experimentID, true
+}
+
+func (h *ExperimentAPIHandler) respondExperimentError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, "experiment not found")
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
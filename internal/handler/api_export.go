@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/export"
+)
+
+// exportFunc writes an export in the given format and returns the number of rows written.
+type exportFunc func(ctx context.Context, w io.Writer, format export.Format, filter *domain.CallListFilter) (int, error)
+
+// serveExport parses export parameters and streams the file to the client.
+func serveExport(w http.ResponseWriter, r *http.Request, name string, write exportFunc, logger *zap.Logger) {
+	query := r.URL.Query()
+
+	format, err := export.ParseFormat(query.Get("format"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := parseExportFilter(query)
+	if err != nil {
+		APIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	streamExport(w, r, name, format, filter, write, logger)
+}
+
+// streamExport streams the calls matching filter to the client as a file.
+// Once the first byte is written the status can no longer change, so errors
+// after that point are only logged and the truncated download is left to fail.
+func streamExport(w http.ResponseWriter, r *http.Request, name string, format export.Format, filter *domain.CallListFilter, write exportFunc, logger *zap.Logger) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102-150405"), format.Extension())
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	// Large exports can outlast the server's write timeout.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	rows, err := write(r.Context(), w, format, filter)
+	if err != nil {
+		logger.Error("export failed",
+			zap.String("export", name),
+			zap.Int("rows_written", rows),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Info("export completed",
+		zap.String("export", name),
+		zap.String("format", string(format)),
+		zap.Int("rows", rows),
+	)
+}
+
+// parseExportFilter builds a call filter from export query parameters.
+// Dates accept RFC 3339 timestamps or YYYY-MM-DD; a plain "to" date is inclusive.
+func parseExportFilter(query url.Values) (*domain.CallListFilter, error) {
+	filter := &domain.CallListFilter{
+		PhoneNumber: strings.TrimSpace(query.Get("phone_number")),
+	}
+
+	if status := strings.TrimSpace(query.Get("status")); status != "" {
+		switch s := domain.CallStatus(status); s {
+		case domain.CallStatusPending,
+			domain.CallStatusInProgress,
+			domain.CallStatusCompleted,
+			domain.CallStatusFailed,
+			domain.CallStatusNoAnswer:
+			filter.Status = &s
+		default:
+			return nil, fmt.Errorf("invalid status %q", status)
+		}
+	}
+
+	if from := strings.TrimSpace(query.Get("from")); from != "" {
+		t, _, err := parseExportDate(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.CreatedAfter = &t
+	}
+
+	if to := strings.TrimSpace(query.Get("to")); to != "" {
+		t, dateOnly, err := parseExportDate(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %w", err)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.CreatedBefore = &t
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedBefore.After(*filter.CreatedAfter) {
+		return nil, fmt.Errorf("to must be after from")
+	}
+
+	var err error
+	if filter.Tags, err = domain.ParseTagNames(query["tag"]); err != nil {
+		return nil, err
+	}
+	if filter.QuoteTags, err = domain.ParseTagNames(query["quote_tag"]); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// parseExportDate parses an RFC 3339 timestamp or a YYYY-MM-DD date,
+// reporting whether the value was a plain date.
+func parseExportDate(s string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), false, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected RFC 3339 or YYYY-MM-DD, got %q", s)
+	}
+	return t, true, nil
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// FeatureFlagMiddleware evaluates the feature flags for the signed-in user
+// and stores them in the request context, where handlers and services read
+// them with service.FeatureEnabled. It must run after authentication.
+func FeatureFlagMiddleware(flagService *service.FeatureFlagService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var userID *uuid.UUID
+			if user := GetUserFromContext(r.Context()); user != nil {
+				userID = &user.ID
+			}
+			flags := flagService.Evaluate(r.Context(), userID)
+			next.ServeHTTP(w, r.WithContext(service.WithFeatureFlags(r.Context(), flags)))
+		})
+	}
+}
+
+// FeatureFlagAPIHandler handles the feature flag endpoints. Any user can
+// read the flags evaluated for them; managing flags is admin only.
+type FeatureFlagAPIHandler struct {
+	flagService *service.FeatureFlagService
+	logger      *zap.Logger
+}
+
+// NewFeatureFlagAPIHandler creates a new FeatureFlagAPIHandler.
+func NewFeatureFlagAPIHandler(flagService *service.FeatureFlagService, logger *zap.Logger) *FeatureFlagAPIHandler {
+	return &FeatureFlagAPIHandler{
+		flagService: flagService,
+		logger:      logger,
+	}
+}
+
+// FeatureFlagListResponse lists the feature flags.
+type FeatureFlagListResponse struct {
+	Flags []*domain.FeatureFlag `json:"flags"`
+}
+
+// EvaluatedFeatureFlagsResponse holds whether each flag is on for the
+// current user.
+type EvaluatedFeatureFlagsResponse struct {
+	Flags domain.FeatureFlags `json:"flags"`
+}
+
+// FeatureFlagBody is the body of a request to create or replace a feature
+// flag. The key is only read when creating.
+type FeatureFlagBody struct {
+	Key            string      `json:"key,omitempty" validate:"max=100"`
+	Description    string      `json:"description" validate:"max=500"`
+	Enabled        bool        `json:"enabled"`
+	RolloutPercent int         `json:"rollout_percent" validate:"min=0,max=100"`
+	UserIDs        []uuid.UUID `json:"user_ids" validate:"max=1000"`
+}
+
+func (b *FeatureFlagBody) request() *service.FeatureFlagRequest {
+	return &service.FeatureFlagRequest{
+		Key:            b.Key,
+		Description:    b.Description,
+		Enabled:        b.Enabled,
+		RolloutPercent: b.RolloutPercent,
+		UserIDs:        b.UserIDs,
+	}
+}
+
+// RegisterRoutes registers feature flag API routes.
+func (h *FeatureFlagAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/feature-flags", func(r chi.Router) {
+		r.Get("/evaluate", h.Evaluate)
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAPIAdmin)
+			r.Get("/", h.List)
+			r.Post("/", h.Create)
+			r.Put("/{key}", h.Update)
+			r.Delete("/{key}", h.Delete)
+		})
+	})
+}
+
+// Evaluate handles GET /api/v1/feature-flags/evaluate
+// @Summary Evaluate feature flags
+// @Description Returns whether each feature flag is on for the current user. Flags missing from the response are off.
+// @Tags feature-flags
+// @Produce json
+// @Success 200 {object} EvaluatedFeatureFlagsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/feature-flags/evaluate [get]
+func (h *FeatureFlagAPIHandler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	JSON(w, http.StatusOK, EvaluatedFeatureFlagsResponse{Flags: service.FeatureFlagsFromContext(r.Context())})
+}
+
+// List handles GET /api/v1/feature-flags
+// @Summary List feature flags
+// @Description Lists every feature flag with its rollout, ordered by key. Admin only.
+// @Tags feature-flags
+// @Produce json
+// @Success 200 {object} FeatureFlagListResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/feature-flags [get]
+func (h *FeatureFlagAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.flagService.ListFlags(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list feature flags", zap.Error(err))
+		APIServiceError(w, err, "failed to list feature flags")
+		return
+	}
+	if flags == nil {
+		flags = []*domain.FeatureFlag{}
+	}
+	JSON(w, http.StatusOK, FeatureFlagListResponse{Flags: flags})
+}
+
+// Create handles POST /api/v1/feature-flags
+// @Summary Create a feature flag
+// @Description Creates a feature flag. An enabled flag is on for the listed users and for rollout_percent of all other users; a flag at 100% is also on for work done without a user. Admin only.
+// @Tags feature-flags
+// @Accept json
+// @Produce json
+// @Param request body FeatureFlagBody true "Feature flag"
+// @Success 201 {object} domain.FeatureFlag
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/feature-flags [post]
+func (h *FeatureFlagAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body FeatureFlagBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	flag, err := h.flagService.CreateFlag(r.Context(), userActor(r), body.request())
+	if err != nil {
+		h.respondFeatureFlagError(w, "failed to create feature flag", err)
+		return
+	}
+	JSON(w, http.StatusCreated, flag)
+}
+
+// Update handles PUT /api/v1/feature-flags/{key}
+// @Summary Update a feature flag
+// @Description Replaces a feature flag's description, switch, rollout and users. The change takes effect on other instances once their flag cache expires. Admin only.
+// @Tags feature-flags
+// @Accept json
+// @Produce json
+// @Param key path string true "Flag key"
+// @Param request body FeatureFlagBody true "Feature flag"
+// @Success 200 {object} domain.FeatureFlag
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/feature-flags/{key} [put]
+func (h *FeatureFlagAPIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var body FeatureFlagBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	flag, err := h.flagService.UpdateFlag(r.Context(), userActor(r), chi.URLParam(r, "key"), body.request())
+	if err != nil {
+		h.respondFeatureFlagError(w, "failed to update feature flag", err)
+		return
+	}
+	JSON(w, http.StatusOK, flag)
+}
+
+// Delete handles DELETE /api/v1/feature-flags/{key}
+// @Summary Delete a feature flag
+// @Description Deletes a feature flag. Code still checking it sees it off. Admin only.
+// @Tags feature-flags
+// @Param key path string true "Flag key"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/feature-flags/{key} [delete]
+func (h *FeatureFlagAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.flagService.DeleteFlag(r.Context(), userActor(r), chi.URLParam(r, "key")); err != nil {
+		h.respondFeatureFlagError(w, "failed to delete feature flag", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *FeatureFlagAPIHandler) respondFeatureFlagError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,508 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	gql "github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/graphql"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// GraphQLAPIHandler serves read-only GraphQL queries over calls, quotes,
+// customers and analytics, so dashboards can fetch exactly the fields they
+// show in one round trip.
+type GraphQLAPIHandler struct {
+	callService      *service.CallService
+	customerService  *service.CustomerService
+	quoteService     *service.QuoteService
+	analyticsService *service.AnalyticsService
+	server           *graphql.Handler
+	logger           *zap.Logger
+}
+
+// NewGraphQLAPIHandler creates a new GraphQLAPIHandler.
+func NewGraphQLAPIHandler(
+	callService *service.CallService,
+	customerService *service.CustomerService,
+	quoteService *service.QuoteService,
+	analyticsService *service.AnalyticsService,
+	cfg graphql.Config,
+	logger *zap.Logger,
+) (*GraphQLAPIHandler, error) {
+	h := &GraphQLAPIHandler{
+		callService:      callService,
+		customerService:  customerService,
+		quoteService:     quoteService,
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+
+	schema, err := graphql.NewSchema(h.queryType())
+	if err != nil {
+		return nil, err
+	}
+	h.server = graphql.NewHandler(schema, cfg)
+	return h, nil
+}
+
+// RegisterRoutes registers the GraphQL endpoint and its schema.
+func (h *GraphQLAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/", h.Query)
+	r.Post("/", h.Query)
+	r.Get("/schema.graphql", h.GetSchema)
+}
+
+// Query handles GET and POST /api/graphql. A POST body may be a JSON array
+// to run several queries in one request; lookups are shared between them.
+func (h *GraphQLAPIHandler) Query(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), graphQLLoaderContextKey, &graphQLLoader{})
+	h.server.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSchema handles GET /api/graphql/schema.graphql
+func (h *GraphQLAPIHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeSDL(w, r)
+}
+
+// graphQLLoader caches the customers and quotes loaded for one HTTP
+// request, so calls sharing a customer, or a batch asking for the same
+// quote twice, load it once.
+type graphQLLoader struct {
+	mu      sync.Mutex
+	results map[string]*graphQLLoad
+}
+
+type graphQLLoad struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+func (l *graphQLLoader) load(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	l.mu.Lock()
+	if l.results == nil {
+		l.results = make(map[string]*graphQLLoad)
+	}
+	result, ok := l.results[key]
+	if !ok {
+		result = &graphQLLoad{}
+		l.results[key] = result
+	}
+	l.mu.Unlock()
+
+	result.once.Do(func() { result.value, result.err = fetch() })
+	return result.value, result.err
+}
+
+// load fetches through the request's loader, or directly outside a request.
+func (h *GraphQLAPIHandler) load(ctx context.Context, key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if l, ok := ctx.Value(graphQLLoaderContextKey).(*graphQLLoader); ok {
+		return l.load(key, fetch)
+	}
+	return fetch()
+}
+
+// graphQLError is a resolver error with its machine-readable code, which
+// is returned in the error's extensions.
+type graphQLError struct {
+	code    apperrors.Code
+	message string
+}
+
+func (e *graphQLError) Error() string { return e.message }
+
+func (e *graphQLError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// resolver wraps a resolver with a scope check for API keys and converts
+// its errors. Errors the client caused keep their message; others are
+// logged and reported as internal errors.
+func (h *GraphQLAPIHandler) resolver(scope string, fn gql.FieldResolveFn) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		if key := GetAPIKeyFromContext(p.Context); key != nil && !key.HasScope(scope) {
+			return nil, &graphQLError{code: apperrors.CodeForbidden, message: "API key lacks required scope: " + scope}
+		}
+
+		value, err := fn(p)
+		if err == nil {
+			return value, nil
+		}
+		if apperrors.IsUserError(err) {
+			return nil, &graphQLError{code: apperrors.GetCode(err), message: err.Error()}
+		}
+		h.logger.Error("graphql resolver failed", zap.String("scope", scope), zap.Error(err))
+		return nil, &graphQLError{code: apperrors.CodeInternal, message: "internal error"}
+	}
+}
+
+// graphQLID parses a UUID argument.
+func graphQLID(p gql.ResolveParams, name string) (uuid.UUID, error) {
+	s, _ := p.Args[name].(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, apperrors.ValidationFailed("invalid " + name)
+	}
+	return id, nil
+}
+
+// graphQLQuery converts arguments to the query parameters the REST
+// endpoints parse, so both APIs filter the same way.
+func graphQLQuery(args map[string]interface{}, names map[string]string) url.Values {
+	query := url.Values{}
+	for arg, param := range names {
+		switch v := args[arg].(type) {
+		case string:
+			query.Set(param, v)
+		case bool:
+			query.Set(param, strconv.FormatBool(v))
+		case int:
+			query.Set(param, strconv.Itoa(v))
+		}
+	}
+	return query
+}
+
+// nullIfNotFound turns a missing record into a null field.
+func nullIfNotFound(value interface{}, err error) (interface{}, error) {
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// callListArgs are the arguments of call lists, and the list query
+// parameters they map to.
+var callListArgs = map[string]string{
+	"after":       "cursor",
+	"status":      "status",
+	"phoneNumber": "phone_number",
+	"from":        "from",
+	"to":          "to",
+	"provider":    "provider",
+	"hasQuote":    "has_quote",
+	"sentiment":   "sentiment",
+	"intent":      "intent",
+	"urgency":     "urgency",
+	"spam":        "spam",
+	"disposition": "disposition",
+	"sort":        "sort",
+}
+
+// analyticsArgs are the arguments of analytics, and the query parameters
+// they map to.
+var analyticsArgs = map[string]string{
+	"from":      "from",
+	"to":        "to",
+	"provider":  "provider",
+	"sentiment": "sentiment",
+	"intent":    "intent",
+}
+
+func (h *GraphQLAPIHandler) listCalls(ctx context.Context, args map[string]interface{}, customerID *uuid.UUID) (interface{}, error) {
+	query := graphQLQuery(args, callListArgs)
+	filter, err := parseCallListFilter(query)
+	if err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	filter.CustomerID = customerID
+	first, _ := args["first"].(int)
+	return h.callService.ListCallPage(ctx, filter, query.Get("cursor"), first)
+}
+
+func (h *GraphQLAPIHandler) loadCustomer(ctx context.Context, id uuid.UUID) (interface{}, error) {
+	return h.load(ctx, "customer:"+id.String(), func() (interface{}, error) {
+		return nullIfNotFound(h.customerService.GetCustomer(ctx, id))
+	})
+}
+
+func (h *GraphQLAPIHandler) loadQuote(ctx context.Context, callID uuid.UUID) (interface{}, error) {
+	return h.load(ctx, "quote:"+callID.String(), func() (interface{}, error) {
+		return nullIfNotFound(h.quoteService.GetQuote(ctx, callID))
+	})
+}
+
+// queryType builds the schema's types and their resolvers.
+func (h *GraphQLAPIHandler) queryType() *gql.Object {
+	str := gql.String
+	nonNull := gql.NewNonNull
+	list := func(t gql.Type) gql.Type { return nonNull(gql.NewList(nonNull(t))) }
+
+	customer := gql.NewObject(gql.ObjectConfig{Name: "Customer", Fields: gql.Fields{
+		"id":               {Type: nonNull(gql.ID)},
+		"phoneNumber":      {Type: nonNull(str), Description: "E.164 phone number"},
+		"name":             {Type: str},
+		"email":            {Type: str},
+		"company":          {Type: str},
+		"preferredChannel": {Type: nonNull(str), Description: "How the customer is messaged about quotes"},
+		"createdAt":        {Type: nonNull(str)},
+		"updatedAt":        {Type: nonNull(str)},
+	}})
+
+	lineItem := gql.NewObject(gql.ObjectConfig{Name: "QuoteLineItem", Fields: gql.Fields{
+		"description": {Type: nonNull(str)},
+		"quantity":    {Type: nonNull(gql.Float)},
+		"unitPrice":   {Type: nonNull(gql.Float)},
+		"amount":      {Type: nonNull(gql.Float)},
+	}})
+	quote := gql.NewObject(gql.ObjectConfig{Name: "Quote", Fields: gql.Fields{
+		"callId":           {Type: nonNull(gql.ID)},
+		"quoteNumber":      {Type: nonNull(str)},
+		"status":           {Type: nonNull(str), Description: "draft, pending_review, approved, sent, accepted or declined"},
+		"currency":         {Type: nonNull(str), Description: "ISO 4217 currency of the amounts"},
+		"lineItems":        {Type: list(lineItem)},
+		"taxRate":          {Type: nonNull(gql.Float), Description: "Percent applied to the subtotal"},
+		"subtotal":         {Type: nonNull(gql.Float)},
+		"tax":              {Type: nonNull(gql.Float)},
+		"total":            {Type: nonNull(gql.Float)},
+		"edited":           {Type: nonNull(gql.Boolean), Description: "Whether staff edited the generated line items"},
+		"requiresApproval": {Type: nonNull(gql.Boolean)},
+		"notes":            {Type: str},
+		"submittedAt":      {Type: str},
+		"approvedAt":       {Type: str},
+		"sentAt":           {Type: str},
+		"respondedAt":      {Type: str},
+		"createdAt":        {Type: nonNull(str)},
+		"updatedAt":        {Type: nonNull(str)},
+	}})
+
+	tags := gql.NewObject(gql.ObjectConfig{Name: "CallTags", Fields: gql.Fields{
+		"sentiment": {Type: nonNull(str)},
+		"intent":    {Type: nonNull(str)},
+		"urgency":   {Type: nonNull(str)},
+		"taggedAt":  {Type: nonNull(str)},
+	}})
+	cost := gql.NewObject(gql.ObjectConfig{Name: "CallCost", Fields: gql.Fields{
+		"billedNumber":      {Type: nonNull(str)},
+		"outbound":          {Type: nonNull(gql.Boolean)},
+		"minutes":           {Type: nonNull(gql.Float)},
+		"ratePerMinute":     {Type: nonNull(gql.Float)},
+		"callCost":          {Type: nonNull(gql.Float)},
+		"transcriptionCost": {Type: nonNull(gql.Float)},
+		"analysisCost":      {Type: nonNull(gql.Float)},
+		"totalCost":         {Type: nonNull(gql.Float)},
+	}})
+
+	call := gql.NewObject(gql.ObjectConfig{Name: "Call", Fields: gql.Fields{
+		"id":              {Type: nonNull(gql.ID)},
+		"providerCallId":  {Type: nonNull(str)},
+		"provider":        {Type: nonNull(str)},
+		"phoneNumber":     {Type: nonNull(str), Description: "Number that received the call"},
+		"fromNumber":      {Type: nonNull(str), Description: "Caller's number"},
+		"callerName":      {Type: str},
+		"status":          {Type: nonNull(str)},
+		"startedAt":       {Type: str},
+		"endedAt":         {Type: str},
+		"durationSeconds": {Type: gql.Int},
+		"transcript":      {Type: str},
+		"recordingUrl":    {Type: str},
+		"quoteSummary":    {Type: str, Description: "Generated quote text"},
+		"errorMessage":    {Type: str},
+		"spamScore":       {Type: gql.Int},
+		"disposition":     {Type: str},
+		"tags":            {Type: tags, Description: "Set once a completed call is classified"},
+		"cost":            {Type: cost, Description: "Estimated once the call ends"},
+		"createdAt":       {Type: nonNull(str)},
+		"updatedAt":       {Type: nonNull(str)},
+		"customer": {
+			Type:        customer,
+			Description: "Needs the customers:read scope",
+			Resolve: h.resolver(domain.ScopeCustomersRead, func(p gql.ResolveParams) (interface{}, error) {
+				c := p.Source.(*domain.Call)
+				if c.CustomerID == nil {
+					return nil, nil
+				}
+				return h.loadCustomer(p.Context, *c.CustomerID)
+			}),
+		},
+		"quote": {
+			Type:        quote,
+			Description: "Needs the quotes:read scope",
+			Resolve: h.resolver(domain.ScopeQuotesRead, func(p gql.ResolveParams) (interface{}, error) {
+				c := p.Source.(*domain.Call)
+				if c.QuoteSummary == nil {
+					return nil, nil
+				}
+				return h.loadQuote(p.Context, c.ID)
+			}),
+		},
+	}})
+
+	callListFilters := gql.FieldConfigArgument{
+		"first":       {Type: gql.Int, DefaultValue: 20, Description: "Maximum calls to return, at most 100"},
+		"after":       {Type: str, Description: "nextCursor of the previous page"},
+		"status":      {Type: str},
+		"phoneNumber": {Type: str, Description: "Matches the called or calling number"},
+		"from":        {Type: str, Description: "Created on or after (RFC 3339 or YYYY-MM-DD)"},
+		"to":          {Type: str, Description: "Created before (RFC 3339) or on (YYYY-MM-DD)"},
+		"provider":    {Type: str},
+		"hasQuote":    {Type: gql.Boolean},
+		"sentiment":   {Type: str},
+		"intent":      {Type: str},
+		"urgency":     {Type: str},
+		"spam":        {Type: gql.Boolean},
+		"disposition": {Type: str},
+		"sort":        {Type: str, Description: "newest (default) or oldest"},
+	}
+	callPage := gql.NewObject(gql.ObjectConfig{Name: "CallPage", Fields: gql.Fields{
+		"calls":      {Type: list(call)},
+		"nextCursor": {Type: str, Description: "Pass as after for the next page; empty on the last page"},
+		"hasMore":    {Type: nonNull(gql.Boolean)},
+	}})
+	customer.AddFieldConfig("calls", &gql.Field{
+		Type:        nonNull(callPage),
+		Description: "The customer's calls. Needs the calls:read scope",
+		Args:        callListFilters,
+		Resolve: h.resolver(domain.ScopeCallsRead, func(p gql.ResolveParams) (interface{}, error) {
+			id := p.Source.(*domain.Customer).ID
+			return h.listCalls(p.Context, p.Args, &id)
+		}),
+	})
+
+	customerPage := gql.NewObject(gql.ObjectConfig{Name: "CustomerPage", Fields: gql.Fields{
+		"customers": {Type: list(customer)},
+		"total":     {Type: nonNull(gql.Int)},
+		"page":      {Type: nonNull(gql.Int)},
+		"pageSize":  {Type: nonNull(gql.Int)},
+	}})
+
+	analytics := gql.NewObject(gql.ObjectConfig{Name: "Analytics", Fields: gql.Fields{
+		"kpis": {
+			Type: nonNull(gql.NewObject(gql.ObjectConfig{Name: "CallKPIs", Fields: gql.Fields{
+				"totalCalls":             {Type: nonNull(gql.Int)},
+				"completedCalls":         {Type: nonNull(gql.Int)},
+				"noAnswerCalls":          {Type: nonNull(gql.Int)},
+				"failedCalls":            {Type: nonNull(gql.Int)},
+				"quotedCalls":            {Type: nonNull(gql.Int)},
+				"answerRate":             {Type: nonNull(gql.Float)},
+				"quoteConversionRate":    {Type: nonNull(gql.Float)},
+				"averageDurationSeconds": {Type: nonNull(gql.Float), Description: "Completed calls only"},
+				"pricedQuotes":           {Type: nonNull(gql.Int)},
+				"averageQuoteValue":      {Type: nonNull(gql.Float)},
+				"totalQuoteValue":        {Type: nonNull(gql.Float)},
+				"quoteValueCurrency":     {Type: nonNull(str)},
+			}})),
+			Resolve: h.resolver(domain.ScopeAnalyticsRead, func(p gql.ResolveParams) (interface{}, error) {
+				return h.analyticsService.GetKPIs(p.Context, p.Source.(*domain.AnalyticsFilter))
+			}),
+		},
+		"callsPerDay": {
+			Type: list(gql.NewObject(gql.ObjectConfig{Name: "DailyCallVolume", Fields: gql.Fields{
+				"date":      {Type: nonNull(str)},
+				"calls":     {Type: nonNull(gql.Int)},
+				"completed": {Type: nonNull(gql.Int)},
+				"quoted":    {Type: nonNull(gql.Int)},
+			}})),
+			Resolve: h.resolver(domain.ScopeAnalyticsRead, func(p gql.ResolveParams) (interface{}, error) {
+				return h.analyticsService.GetCallsPerDay(p.Context, p.Source.(*domain.AnalyticsFilter))
+			}),
+		},
+		"prompts": {
+			Type: list(gql.NewObject(gql.ObjectConfig{Name: "PromptCallMetrics", Fields: gql.Fields{
+				"promptId":               {Type: gql.ID, Description: "Null for calls without a known prompt"},
+				"promptName":             {Type: nonNull(str)},
+				"calls":                  {Type: nonNull(gql.Int)},
+				"completedCalls":         {Type: nonNull(gql.Int)},
+				"averageDurationSeconds": {Type: nonNull(gql.Float)},
+			}})),
+			Resolve: h.resolver(domain.ScopeAnalyticsRead, func(p gql.ResolveParams) (interface{}, error) {
+				return h.analyticsService.GetPromptMetrics(p.Context, p.Source.(*domain.AnalyticsFilter))
+			}),
+		},
+	}})
+
+	return gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: gql.Fields{
+		"call": {
+			Type: call,
+			Args: gql.FieldConfigArgument{"id": {Type: nonNull(gql.ID)}},
+			Resolve: h.resolver(domain.ScopeCallsRead, func(p gql.ResolveParams) (interface{}, error) {
+				id, err := graphQLID(p, "id")
+				if err != nil {
+					return nil, err
+				}
+				return nullIfNotFound(h.callService.GetCall(p.Context, id))
+			}),
+		},
+		"calls": {
+			Type: nonNull(callPage),
+			Args: callListFilters,
+			Resolve: h.resolver(domain.ScopeCallsRead, func(p gql.ResolveParams) (interface{}, error) {
+				return h.listCalls(p.Context, p.Args, nil)
+			}),
+		},
+		"customer": {
+			Type: customer,
+			Args: gql.FieldConfigArgument{"id": {Type: nonNull(gql.ID)}},
+			Resolve: h.resolver(domain.ScopeCustomersRead, func(p gql.ResolveParams) (interface{}, error) {
+				id, err := graphQLID(p, "id")
+				if err != nil {
+					return nil, err
+				}
+				return h.loadCustomer(p.Context, id)
+			}),
+		},
+		"customers": {
+			Type: nonNull(customerPage),
+			Args: gql.FieldConfigArgument{
+				"search":   {Type: str, Description: "Text to search customers for"},
+				"page":     {Type: gql.Int, DefaultValue: 1},
+				"pageSize": {Type: gql.Int, DefaultValue: 20, Description: "At most 100"},
+			},
+			Resolve: h.resolver(domain.ScopeCustomersRead, func(p gql.ResolveParams) (interface{}, error) {
+				search, _ := p.Args["search"].(string)
+				page, _ := p.Args["page"].(int)
+				pageSize, _ := p.Args["pageSize"].(int)
+				if page < 1 {
+					page = 1
+				}
+				if pageSize < 1 || pageSize > 100 {
+					pageSize = 20
+				}
+				customers, total, err := h.customerService.ListCustomers(p.Context, &domain.CustomerListFilter{Search: search}, page, pageSize)
+				if err != nil {
+					return nil, err
+				}
+				return map[string]interface{}{"customers": customers, "total": total, "page": page, "pageSize": pageSize}, nil
+			}),
+		},
+		"quote": {
+			Type: quote,
+			Args: gql.FieldConfigArgument{"callId": {Type: nonNull(gql.ID)}},
+			Resolve: h.resolver(domain.ScopeQuotesRead, func(p gql.ResolveParams) (interface{}, error) {
+				id, err := graphQLID(p, "callId")
+				if err != nil {
+					return nil, err
+				}
+				return h.loadQuote(p.Context, id)
+			}),
+		},
+		"analytics": {
+			Type:        nonNull(analytics),
+			Description: "Call and quote metrics over a range, by default the last 30 days",
+			Args: gql.FieldConfigArgument{
+				"from":      {Type: str, Description: "Start of the range (RFC 3339 or YYYY-MM-DD)"},
+				"to":        {Type: str, Description: "End of the range (RFC 3339, or YYYY-MM-DD inclusive)"},
+				"provider":  {Type: str},
+				"sentiment": {Type: str},
+				"intent":    {Type: str},
+			},
+			Resolve: h.resolver(domain.ScopeAnalyticsRead, func(p gql.ResolveParams) (interface{}, error) {
+				filter, err := parseAnalyticsFilter(graphQLQuery(p.Args, analyticsArgs))
+				if err != nil {
+					return nil, apperrors.ValidationFailed(err.Error())
+				}
+				return filter, nil
+			}),
+		},
+	}})
+}
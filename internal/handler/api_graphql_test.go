@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/graphql"
+)
+
+func newTestGraphQLAPIHandler(t *testing.T) *GraphQLAPIHandler {
+	t.Helper()
+	h, err := NewGraphQLAPIHandler(nil, nil, nil, nil, graphql.Config{MaxBatchSize: 5, MaxDepth: 5}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewGraphQLAPIHandler() error = %v", err)
+	}
+	return h
+}
+
+func TestGraphQLAPIHandler_Schema(t *testing.T) {
+	h := newTestGraphQLAPIHandler(t)
+
+	rr := httptest.NewRecorder()
+	h.GetSchema(rr, httptest.NewRequest(http.MethodGet, "/api/graphql/schema.graphql", nil))
+
+	for _, want := range []string{"type Query {", "type Call {", "type Quote {", "type CustomerPage {", "type CallKPIs {"} {
+		if !strings.Contains(rr.Body.String(), want) {
+			t.Errorf("schema missing %q", want)
+		}
+	}
+}
+
+func TestGraphQLAPIHandler_ScopesPerField(t *testing.T) {
+	auth, key := newTestAPIKeyAuth(t, []string{domain.ScopeCallsRead}, 0)
+	h := newTestGraphQLAPIHandler(t)
+	srv := auth.APIKeyAuthMiddleware(http.HandlerFunc(h.Query))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{"query":"{ customers { total } }"}`))
+	req.Header.Set("Authorization", "Bearer "+key)
+	rr := httptest.NewRecorder()
+	srv.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+	want := `{"data":null,"errors":[{"message":"API key lacks required scope: customers:read","locations":[{"line":1,"column":3}],"path":["customers"],"extensions":{"code":"FORBIDDEN"}}]}`
+	if got := strings.TrimSpace(rr.Body.String()); got != want {
+		t.Errorf("body = %s\nwant   %s", got, want)
+	}
+}
+
+func TestGraphQLLoader(t *testing.T) {
+	ctx := context.WithValue(context.Background(), graphQLLoaderContextKey, &graphQLLoader{})
+	h := newTestGraphQLAPIHandler(t)
+
+	fetches := 0
+	fetch := func() (interface{}, error) {
+		fetches++
+		return fetches, nil
+	}
+	for i := 0; i < 3; i++ {
+		if v, _ := h.load(ctx, "customer:1", fetch); v != 1 {
+			t.Errorf("load() = %v, want the first result", v)
+		}
+	}
+	h.load(ctx, "customer:2", fetch)
+	if fetches != 2 {
+		t.Errorf("fetched %d times, want once per key", fetches)
+	}
+
+	h.load(context.Background(), "customer:1", fetch)
+	if fetches != 3 {
+		t.Error("expected loads outside a request to fetch every time")
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// APIKeyHandler handles API key management endpoints.
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+	auditLogger   *audit.Logger
+	logger        *zap.Logger
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler.
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService, auditLogger *audit.Logger, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		auditLogger:   auditLogger,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers API key management routes.
+func (h *APIKeyHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/admin/api-keys", func(r chi.Router) {
+		r.Get("/", h.ListAPIKeys)
+		r.Post("/", h.CreateAPIKey)
+		r.Post("/{keyID}/rotate", h.RotateAPIKey)
+		r.Delete("/{keyID}", h.RevokeAPIKey)
+	})
+}
+
+// CreateAPIKeyRequest is the request body for creating an API key.
+type CreateAPIKeyRequest struct {
+	Name               string     `json:"name"`
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeySecretResponse is returned when a key is created or rotated.
+// The plaintext key is only ever shown in this response.
+type APIKeySecretResponse struct {
+	APIKey *domain.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+// ListAPIKeys handles GET /admin/api-keys
+// @Summary List API keys
+// @Description Lists all API keys. Secrets are never returned.
+// @Tags api-keys
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyService.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list API keys", zap.Error(err))
+		APIServiceError(w, err, "failed to list API keys")
+		return
+	}
+	if keys == nil {
+		keys = []*domain.APIKey{}
+	}
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"api_keys": keys,
+		"scopes":   domain.KnownAPIKeyScopes,
+	})
+}
+
+// CreateAPIKey handles POST /admin/api-keys
+// @Summary Create an API key
+// @Description Generates a new API key. The key is only returned once.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param request body CreateAPIKeyRequest true "API key configuration"
+// @Success 201 {object} APIKeySecretResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		h.respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	key, plaintext, err := h.apiKeyService.Create(r.Context(), user.ID, service.CreateAPIKeyRequest{
+		Name:               req.Name,
+		Scopes:             req.Scopes,
+		RateLimitPerMinute: req.RateLimitPerMinute,
+		ExpiresAt:          req.ExpiresAt,
+	})
+	if err != nil {
+		h.handleServiceError(w, "failed to create API key", err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		h.auditLogger.APIKeyCreated(r.Context(), user.ID.String(), user.Email, key.ID.String(), key.Prefix, key.Scopes, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusCreated, APIKeySecretResponse{APIKey: key, Key: plaintext})
+}
+
+// RotateAPIKey handles POST /admin/api-keys/{keyID}/rotate
+// @Summary Rotate an API key
+// @Description Replaces the key secret. The previous secret stops working immediately.
+// @Tags api-keys
+// @Produce json
+// @Param keyID path string true "API key ID"
+// @Success 200 {object} APIKeySecretResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/api-keys/{keyID}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid key_id")
+		return
+	}
+
+	key, plaintext, err := h.apiKeyService.Rotate(r.Context(), keyID)
+	if err != nil {
+		h.handleServiceError(w, "failed to rotate API key", err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := h.actor(r)
+		h.auditLogger.APIKeyRotated(r.Context(), userID, userName, key.ID.String(), key.Prefix, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusOK, APIKeySecretResponse{APIKey: key, Key: plaintext})
+}
+
+// RevokeAPIKey handles DELETE /admin/api-keys/{keyID}
+// @Summary Revoke an API key
+// @Description Permanently disables an API key
+// @Tags api-keys
+// @Produce json
+// @Param keyID path string true "API key ID"
+// @Success 200 {object} domain.APIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/api-keys/{keyID} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyID"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid key_id")
+		return
+	}
+
+	key, err := h.apiKeyService.Revoke(r.Context(), keyID)
+	if err != nil {
+		h.handleServiceError(w, "failed to revoke API key", err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := h.actor(r)
+		h.auditLogger.APIKeyRevoked(r.Context(), userID, userName, key.ID.String(), key.Prefix, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	h.respondJSON(w, http.StatusOK, key)
+}
+
+// actor returns the ID and email of the authenticated user for audit logging.
+func (h *APIKeyHandler) actor(r *http.Request) (string, string) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		return "", ""
+	}
+	return user.ID.String(), user.Email
+}
+
+// handleServiceError maps service errors to HTTP responses.
+func (h *APIKeyHandler) handleServiceError(w http.ResponseWriter, msg string, err error) {
+	status := apperrors.GetHTTPStatus(err)
+	if status >= http.StatusInternalServerError {
+		h.logger.Error(msg, zap.Error(err))
+		h.respondError(w, status, msg)
+		return
+	}
+	h.respondError(w, status, err.Error())
+}
+
+func (h *APIKeyHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+	JSON(w, status, data)
+}
+
+func (h *APIKeyHandler) respondError(w http.ResponseWriter, status int, message string) {
+	APIError(w, status, message)
+}
@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// stubAPIKeyRepo is an in-memory domain.APIKeyRepository for handler tests.
+type stubAPIKeyRepo struct {
+	keys map[uuid.UUID]*domain.APIKey
+}
+
+func (s *stubAPIKeyRepo) Create(ctx context.Context, key *domain.APIKey) error {
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *stubAPIKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	if key, ok := s.keys[id]; ok {
+		return key, nil
+	}
+	return nil, apperrors.NotFound("api key")
+}
+
+func (s *stubAPIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	for _, key := range s.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, apperrors.NotFound("api key")
+}
+
+func (s *stubAPIKeyRepo) List(ctx context.Context) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *stubAPIKeyRepo) Update(ctx context.Context, key *domain.APIKey) error {
+	s.keys[key.ID] = key
+	return nil
+}
+
+func (s *stubAPIKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return nil
+}
+
+// stubUserRepo is an in-memory domain.UserRepository for handler tests.
+type stubUserRepo struct {
+	users map[uuid.UUID]*domain.User
+}
+
+func (s *stubUserRepo) Create(ctx context.Context, user *domain.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *stubUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if user, ok := s.users[id]; ok {
+		return user, nil
+	}
+	return nil, apperrors.NotFound("user")
+}
+
+func (s *stubUserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return nil, apperrors.NotFound("user")
+}
+
+func (s *stubUserRepo) GetBySSOIdentity(ctx context.Context, issuer, subject string) (*domain.User, error) {
+	return nil, apperrors.NotFound("user")
+}
+
+func (s *stubUserRepo) Update(ctx context.Context, user *domain.User) error {
+	return nil
+}
+
+func (s *stubUserRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(s.users, id)
+	return nil
+}
+
+func (s *stubUserRepo) Count(ctx context.Context) (int64, error) {
+	return int64(len(s.users)), nil
+}
+
+func (s *stubUserRepo) List(ctx context.Context) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (s *stubUserRepo) AdvanceTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) error {
+	return nil
+}
+
+func (s *stubUserRepo) CountActiveAdmins(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func newTestAPIKeyAuth(t *testing.T, scopes []string, rateLimit int) (*AuthHandler, string) {
+	t.Helper()
+	logger := zap.NewNop()
+
+	userRepo := &stubUserRepo{users: make(map[uuid.UUID]*domain.User)}
+	user, _ := domain.NewUser("bot@example.com", "password")
+	userRepo.Create(context.Background(), user)
+
+	keyRepo := &stubAPIKeyRepo{keys: make(map[uuid.UUID]*domain.APIKey)}
+	apiKeyService := service.NewAPIKeyService(keyRepo, userRepo, logger)
+	_, plaintext, err := apiKeyService.Create(context.Background(), user.ID, service.CreateAPIKeyRequest{
+		Name:               "test",
+		Scopes:             scopes,
+		RateLimitPerMinute: rateLimit,
+	})
+	if err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+
+	limiter := ratelimit.NewKeyRateLimiter()
+	t.Cleanup(limiter.Stop)
+
+	h := NewAuthHandler(AuthHandlerConfig{
+		Base:          BaseHandlerConfig{Logger: logger},
+		AuthService:   service.NewAuthService(userRepo, nil, time.Hour, logger, nil),
+		APIKeyService: apiKeyService,
+		APIKeyLimiter: limiter,
+	})
+	return h, plaintext
+}
+
+func TestAPIKeyAuthMiddleware(t *testing.T) {
+	h, key := newTestAPIKeyAuth(t, []string{domain.ScopeCallsRead}, 10)
+
+	var gotKey *domain.APIKey
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = GetAPIKeyFromContext(r.Context())
+		if GetUserFromContext(r.Context()) == nil {
+			t.Error("expected user in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mw := h.APIKeyAuthMiddleware(next)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		auth     string
+		expected int
+	}{
+		{"valid key with scope", http.MethodGet, "/api/v1/calls/active", "Bearer " + key, http.StatusOK},
+		{"missing write scope", http.MethodPost, "/api/v1/calls", "Bearer " + key, http.StatusForbidden},
+		{"other resource", http.MethodGet, "/api/v1/prompts", "Bearer " + key, http.StatusForbidden},
+		{"unknown key", http.MethodGet, "/api/v1/calls/active", "Bearer qq_0000000000000000000000000000000000000000000000000000000000000000", http.StatusUnauthorized},
+		{"no credentials", http.MethodGet, "/api/v1/calls/active", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotKey = nil
+			req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rr := httptest.NewRecorder()
+
+			mw.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+			if tt.expected == http.StatusOK && gotKey == nil {
+				t.Error("expected API key in context")
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuthMiddleware_RateLimit(t *testing.T) {
+	h, key := newTestAPIKeyAuth(t, []string{domain.ScopeAll}, 2)
+
+	mw := h.APIKeyAuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := make([]int, 3)
+	for i := range codes {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/calls/active", http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+key)
+		rr := httptest.NewRecorder()
+		mw.ServeHTTP(rr, req)
+		codes[i] = rr.Code
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK {
+		t.Errorf("expected first two requests to succeed, got %v", codes)
+	}
+	if codes[2] != http.StatusTooManyRequests {
+		t.Errorf("expected third request to be rate limited, got %d", codes[2])
+	}
+}
+
+func TestAPIResource(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/calls", "calls"},
+		{"/api/v1/calls/123/end", "calls"},
+		{"/api/v1/bland/voices", "bland"},
+		{"/api/v1/", ""},
+		{"/api/graphql", "graphql"},
+		{"/api/graphql/schema.graphql", "graphql"},
+	}
+
+	for _, tt := range tests {
+		if got := apiResource(tt.path); got != tt.expected {
+			t.Errorf("apiResource(%q) = %q, expected %q", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestRequireAPIAdmin(t *testing.T) {
+	mw := RequireAPIAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	admin, _ := domain.NewUser("admin@example.com", "password")
+	admin.Role = domain.UserRoleAdmin
+	enrolled, _ := domain.NewUser("enrolled@example.com", "password")
+	enrolled.Role = domain.UserRoleAdmin
+	secret := "JBSWY3DPEHPK3PXP"
+	enrolled.TOTPSecret = &secret
+	enrolled.EnableTwoFactor(1)
+	member, _ := domain.NewUser("member@example.com", "password")
+
+	tests := []struct {
+		name              string
+		user              *domain.User
+		twoFactorRequired bool
+		expected          int
+	}{
+		{"admin", admin, false, http.StatusOK},
+		{"admin without two-factor when required", admin, true, http.StatusForbidden},
+		{"admin with two-factor when required", enrolled, true, http.StatusOK},
+		{"member", member, false, http.StatusForbidden},
+		{"no user", nil, false, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/provider-keys/bland/rotate", http.NoBody)
+			ctx := context.WithValue(req.Context(), twoFactorRequiredContextKey, tt.twoFactorRequired)
+			if tt.user != nil {
+				ctx = context.WithValue(ctx, userContextKey, tt.user)
+			}
+			rr := httptest.NewRecorder()
+
+			mw.ServeHTTP(rr, req.WithContext(ctx))
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	h, _ := newTestAPIKeyAuth(t, []string{domain.ScopeAll}, 0)
+	mw := h.RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	admin, _ := domain.NewUser("admin@example.com", "password")
+	admin.Role = domain.UserRoleAdmin
+	member, _ := domain.NewUser("member@example.com", "password")
+
+	tests := []struct {
+		name     string
+		user     *domain.User
+		expected int
+	}{
+		{"admin", admin, http.StatusOK},
+		{"member", member, http.StatusForbidden},
+		{"no user", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", http.NoBody)
+			if tt.user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), userContextKey, tt.user))
+			}
+			rr := httptest.NewRecorder()
+
+			mw.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+		})
+	}
+}
+
+// TestAPIRoutesHaveKnownScopes fails when an API resource is added without
+// the scopes an API key needs to reach it.
+func TestAPIRoutesHaveKnownScopes(t *testing.T) {
+	r := chi.NewRouter()
+	for _, h := range []interface{ RegisterRoutes(chi.Router) }{
+		&CallAPIHandler{}, &PromptAPIHandler{}, &BlandAPIHandler{}, &QuoteAPIHandler{},
+		&CustomerAPIHandler{}, &QuoteJobAPIHandler{}, &UserAPIHandler{}, &ExperimentAPIHandler{},
+		&ScheduleAPIHandler{}, &AnalyticsAPIHandler{}, &WebhookAPIHandler{}, &QuoteTemplateAPIHandler{},
+		&EventAPIHandler{}, &BudgetAPIHandler{}, &NotificationAPIHandler{}, &ComplianceAPIHandler{},
+		&PrivacyAPIHandler{}, &RetentionAPIHandler{}, &FeatureFlagAPIHandler{}, &TagAPIHandler{},
+		&SavedViewAPIHandler{}, &ProviderKeyAPIHandler{}, &CircuitBreakerAPIHandler{}, &AuditAPIHandler{},
+	} {
+		h.RegisterRoutes(r)
+	}
+
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		resource, _, _ := strings.Cut(strings.TrimPrefix(route, "/"), "/")
+		if scope := domain.RequiredAPIScope(resource, method); scope != "" && !domain.IsValidScope(scope) {
+			t.Errorf("%s %s needs scope %q, which is not in KnownAPIKeyScopes", method, route, scope)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+}
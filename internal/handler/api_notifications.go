@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/notification/chatops"
+)
+
+// NotificationAPIHandler handles the chat notification endpoints. Admin only.
+type NotificationAPIHandler struct {
+	notifier *chatops.Notifier
+	logger   *zap.Logger
+}
+
+// NewNotificationAPIHandler creates a new NotificationAPIHandler. notifier
+// may be nil.
+func NewNotificationAPIHandler(notifier *chatops.Notifier, logger *zap.Logger) *NotificationAPIHandler {
+	return &NotificationAPIHandler{
+		notifier: notifier,
+		logger:   logger,
+	}
+}
+
+// NotificationTestRequest is the body of a test notification request.
+type NotificationTestRequest struct {
+	// Event to test; empty tests every configured event.
+	Event string `json:"event,omitempty" example:"quote_ready"`
+}
+
+// NotificationTestResult is the outcome of one test notification.
+type NotificationTestResult struct {
+	Event string `json:"event"`
+	Sent  bool   `json:"sent"`
+	Error string `json:"error,omitempty"`
+}
+
+// NotificationTestResponse lists the outcome of each test notification.
+type NotificationTestResponse struct {
+	Results []NotificationTestResult `json:"results"`
+}
+
+// RegisterRoutes registers notification API routes.
+func (h *NotificationAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/notifications", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Post("/test", h.SendTest)
+	})
+}
+
+// SendTest handles POST /api/v1/notifications/test
+// @Summary Send a test chat notification
+// @Description Posts a sample message for an event to its Slack or Teams webhook and reports whether it was accepted. With no event, every configured event is tested. Admin only.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body NotificationTestRequest false "Event to test"
+// @Success 200 {object} NotificationTestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse "No chat webhook is configured"
+// @Router /api/v1/notifications/test [post]
+func (h *NotificationAPIHandler) SendTest(w http.ResponseWriter, r *http.Request) {
+	if h.notifier == nil || len(h.notifier.Configured()) == 0 {
+		APIError(w, http.StatusServiceUnavailable, "chat notifications are not configured")
+		return
+	}
+
+	var req NotificationTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	events := h.notifier.Configured()
+	if req.Event != "" {
+		event := chatops.Event(req.Event)
+		if !event.IsValid() {
+			APIError(w, http.StatusBadRequest, "unknown event: "+req.Event)
+			return
+		}
+		events = []chatops.Event{event}
+	}
+
+	resp := NotificationTestResponse{Results: make([]NotificationTestResult, 0, len(events))}
+	for _, event := range events {
+		result := NotificationTestResult{Event: string(event), Sent: true}
+		if err := h.notifier.Test(r.Context(), event); err != nil {
+			h.logger.Warn("test chat notification failed", zap.String("event", string(event)), zap.Error(err))
+			result.Sent = false
+			result.Error = err.Error()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	JSON(w, http.StatusOK, resp)
+}
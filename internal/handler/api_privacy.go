@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// PrivacyAPIHandler handles data subject export and deletion requests. All
+// of its endpoints are admin only.
+type PrivacyAPIHandler struct {
+	privacyService *service.PrivacyService
+	logger         *zap.Logger
+}
+
+// NewPrivacyAPIHandler creates a new PrivacyAPIHandler.
+func NewPrivacyAPIHandler(privacyService *service.PrivacyService, logger *zap.Logger) *PrivacyAPIHandler {
+	return &PrivacyAPIHandler{
+		privacyService: privacyService,
+		logger:         logger,
+	}
+}
+
+// VerifyDeletionReportResponse reports whether a deletion report is genuine.
+type VerifyDeletionReportResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// RegisterRoutes registers privacy API routes.
+func (h *PrivacyAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/privacy", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Post("/export", h.Export)
+		r.Post("/delete", h.Delete)
+		r.Post("/verify", h.Verify)
+	})
+}
+
+// Export handles POST /api/v1/privacy/export
+// @Summary Export a data subject's data
+// @Description Collects everything held about a customer, named by phone number or customer ID: their customer record, calls with transcripts, quotes, recordings, follow-ups, callbacks, campaign contacts, do-not-call entry, and the memory and SMS history Bland holds for their number. Admin only.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param request body service.PrivacyRequest true "Data subject"
+// @Success 200 {object} service.PrivacyExport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/privacy/export [post]
+func (h *PrivacyAPIHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var req service.PrivacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	export, err := h.privacyService.Export(r.Context(), &req)
+	if err != nil {
+		h.respondPrivacyError(w, "failed to export data subject", err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="privacy-export.json"`)
+	JSON(w, http.StatusOK, export)
+}
+
+// Delete handles POST /api/v1/privacy/delete
+// @Summary Delete a data subject's data
+// @Description Deletes everything held about a customer, named by phone number or customer ID, including stored recordings and Bland memory, and returns a signed report of what was deleted, kept or could not be deleted. Do-not-call entries are kept so the customer is not called again. Admin only.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param request body service.PrivacyRequest true "Data subject"
+// @Success 200 {object} domain.DeletionReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/privacy/delete [post]
+func (h *PrivacyAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var req service.PrivacyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.RequestedBy = &user.ID
+	}
+
+	report, err := h.privacyService.Delete(r.Context(), &req)
+	if err != nil {
+		h.respondPrivacyError(w, "failed to delete data subject", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, report)
+}
+
+// Verify handles POST /api/v1/privacy/verify
+// @Summary Verify a deletion report
+// @Description Checks that a deletion report was signed by this server and has not been changed. Admin only.
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param report body domain.DeletionReport true "Deletion report"
+// @Success 200 {object} VerifyDeletionReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/privacy/verify [post]
+func (h *PrivacyAPIHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var report domain.DeletionReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	JSON(w, http.StatusOK, VerifyDeletionReportResponse{Valid: h.privacyService.VerifyReport(&report)})
+}
+
+func (h *PrivacyAPIHandler) respondPrivacyError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
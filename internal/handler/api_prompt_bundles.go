@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// SetBundleService enables exporting and importing prompt bundles.
+func (h *PromptAPIHandler) SetBundleService(bs *service.PromptBundleService) {
+	h.bundleService = bs
+}
+
+// ExportPromptBundle handles GET /api/v1/prompts/bundle
+// @Summary Export prompts as a bundle
+// @Description Exports every active prompt, and optionally settings and citation schemas, as one JSON or YAML document for version control. IDs, flags and timestamps are left out.
+// @Tags prompts
+// @Produce json
+// @Produce application/yaml
+// @Param format query string false "json (default) or yaml"
+// @Param include query string false "Comma-separated extras: settings, citation_schemas"
+// @Success 200 {object} domain.PromptBundle
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/prompts/bundle [get]
+func (h *PromptAPIHandler) ExportPromptBundle(w http.ResponseWriter, r *http.Request) {
+	if h.bundleService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "prompt bundles not configured")
+		return
+	}
+
+	format, ok := h.bundleFormat(w, r)
+	if !ok {
+		return
+	}
+
+	var opts service.PromptBundleExportOptions
+	for _, include := range strings.Split(r.URL.Query().Get("include"), ",") {
+		switch strings.TrimSpace(include) {
+		case "":
+		case "settings":
+			opts.Settings = true
+		case "citation_schemas":
+			opts.CitationSchemas = true
+		default:
+			h.respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid include %q: use settings or citation_schemas", include))
+			return
+		}
+	}
+
+	bundle, err := h.bundleService.Export(r.Context(), opts)
+	if err != nil {
+		h.respondServiceError(w, "failed to export prompt bundle", "", err)
+		return
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err == nil && format == "yaml" {
+		data, err = jsonToYAML(data)
+	}
+	if err != nil {
+		h.logger.Error("failed to encode prompt bundle", zap.Error(err))
+		APIServiceError(w, err, "failed to export prompt bundle")
+		return
+	}
+
+	contentType := "application/json"
+	if format == "yaml" {
+		contentType = "application/yaml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="prompts.%s"`, format))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// ImportPromptBundle handles POST /api/v1/prompts/bundle/import
+// @Summary Import a prompt bundle
+// @Description Validates a bundle, then creates its prompts, settings and citation schemas, matching existing ones by name (settings by key). Items whose content differs from an existing one are skipped, overwritten or imported under a new name. With dry_run the changes are reported, field by field, without being made. Admin only.
+// @Tags prompts
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param format query string false "json (default) or yaml; a YAML Content-Type also selects yaml"
+// @Param conflict query string false "skip (default), overwrite or rename"
+// @Param dry_run query bool false "Report what would change without changing it"
+// @Param request body domain.PromptBundle true "Prompt bundle"
+// @Success 200 {object} domain.PromptBundleImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/prompts/bundle/import [post]
+func (h *PromptAPIHandler) ImportPromptBundle(w http.ResponseWriter, r *http.Request) {
+	if h.bundleService == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "prompt bundles not configured")
+		return
+	}
+	user := GetUserFromContext(r.Context())
+	if user == nil || !user.IsAdmin() {
+		h.respondError(w, http.StatusForbidden, "admin access required")
+		return
+	}
+
+	format, ok := h.bundleFormat(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	conflict, err := domain.ParseBundleConflict(query.Get("conflict"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dryRun := false
+	if v := query.Get("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid dry_run")
+			return
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+	if format == "yaml" {
+		if body, err = yamlToJSON(body); err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid YAML: "+err.Error())
+			return
+		}
+	}
+	var bundle domain.PromptBundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid prompt bundle: "+err.Error())
+		return
+	}
+
+	result, err := h.bundleService.Import(r.Context(), &bundle, service.PromptBundleImportOptions{
+		Conflict:  conflict,
+		DryRun:    dryRun,
+		ChangedBy: user.Email,
+	})
+	if err != nil {
+		h.respondServiceError(w, "failed to import prompt bundle", "", err)
+		return
+	}
+
+	if h.auditLogger != nil && !dryRun {
+		for _, item := range result.Prompts {
+			switch item.Action {
+			case domain.BundleActionCreate, domain.BundleActionRename:
+				name := item.Name
+				if item.NewName != "" {
+					name = item.NewName
+				}
+				h.auditLogger.PromptCreated(r.Context(), user.ID.String(), user.Email, item.ID, name, getClientIP(r), GetRequestIDFromContext(r.Context()))
+			case domain.BundleActionUpdate:
+				changes := map[string]interface{}{"imported_fields": len(item.Changes)}
+				h.auditLogger.PromptUpdated(r.Context(), user.ID.String(), user.Email, item.ID, item.Name, getClientIP(r), GetRequestIDFromContext(r.Context()), changes)
+			}
+		}
+	}
+
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// bundleFormat returns the format a bundle is exchanged in: the format
+// query parameter, or yaml for a YAML request body.
+func (h *PromptAPIHandler) bundleFormat(w http.ResponseWriter, r *http.Request) (string, bool) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "json"
+		if strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+			format = "yaml"
+		}
+	}
+	if format != "json" && format != "yaml" {
+		h.respondError(w, http.StatusBadRequest, "invalid format: use json or yaml")
+		return "", false
+	}
+	return format, true
+}
+
+// jsonToYAML converts a JSON document to YAML, keeping the order of object
+// keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := jsonNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(node)
+}
+
+// jsonNode decodes the next JSON value as a YAML node.
+func jsonNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		kind, end := yaml.SequenceNode, json.Delim(']')
+		if v == '{' {
+			kind, end = yaml.MappingNode, '}'
+		}
+		node := &yaml.Node{Kind: kind}
+		for dec.More() {
+			if kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := jsonNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if tok, err := dec.Token(); err != nil || tok != end {
+			return nil, fmt.Errorf("malformed JSON")
+		}
+		return node, nil
+	case string:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+		if strings.Contains(v, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
+
+// yamlToJSON converts a YAML document to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
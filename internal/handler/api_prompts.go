@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"os"
 	"strconv"
@@ -11,7 +10,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/audit"
-	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/service"
 )
 
@@ -19,6 +19,7 @@ import (
 type PromptAPIHandler struct {
 	promptService *service.PromptService
 	blandService  *service.BlandService
+	bundleService *service.PromptBundleService
 	auditLogger   *audit.Logger
 	logger        *zap.Logger
 }
@@ -43,12 +44,17 @@ func (h *PromptAPIHandler) RegisterRoutes(r chi.Router) {
 		r.Get("/", h.ListPrompts)
 		r.Post("/", h.CreatePrompt)
 		r.Get("/default", h.GetDefaultPrompt)
+		r.Get("/bundle", h.ExportPromptBundle)
+		r.Post("/bundle/import", h.ImportPromptBundle)
 		r.Get("/{promptID}", h.GetPrompt)
 		r.Put("/{promptID}", h.UpdatePrompt)
 		r.Delete("/{promptID}", h.DeletePrompt)
 		r.Post("/{promptID}/default", h.SetDefaultPrompt)
 		r.Post("/{promptID}/duplicate", h.DuplicatePrompt)
 		r.Post("/{promptID}/apply-inbound", h.ApplyToInbound)
+		r.Get("/{promptID}/versions", h.ListPromptVersions)
+		r.Get("/{promptID}/versions/{version}", h.GetPromptVersion)
+		r.Post("/{promptID}/versions/{version}/restore", h.RestorePromptVersion)
 	})
 }
 
@@ -82,7 +88,7 @@ func (h *PromptAPIHandler) ListPrompts(w http.ResponseWriter, r *http.Request) {
 	prompts, total, err := h.promptService.ListPrompts(r.Context(), page, pageSize, activeOnly)
 	if err != nil {
 		h.logger.Error("failed to list prompts", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to list prompts")
+		APIServiceError(w, err, "failed to list prompts")
 		return
 	}
 
@@ -111,29 +117,19 @@ type ListPromptsResponse struct {
 // @Param request body service.CreatePromptRequest true "Prompt configuration"
 // @Success 201 {object} domain.Prompt
 // @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/prompts [post]
 func (h *PromptAPIHandler) CreatePrompt(w http.ResponseWriter, r *http.Request) {
 	var req service.CreatePromptRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-
-	// Validate required fields
-	if req.Name == "" {
-		h.respondError(w, http.StatusBadRequest, "name is required")
-		return
-	}
-	if req.Task == "" {
-		h.respondError(w, http.StatusBadRequest, "task is required")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	prompt, err := h.promptService.CreatePrompt(r.Context(), &req)
 	if err != nil {
 		h.logger.Error("failed to create prompt", zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to create prompt: "+err.Error())
+		APIServiceError(w, err, "failed to create prompt: "+err.Error())
 		return
 	}
 
@@ -209,6 +205,7 @@ func (h *PromptAPIHandler) GetDefaultPrompt(w http.ResponseWriter, r *http.Reque
 // @Success 200 {object} domain.Prompt
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/prompts/{promptID} [put]
 func (h *PromptAPIHandler) UpdatePrompt(w http.ResponseWriter, r *http.Request) {
@@ -220,15 +217,17 @@ func (h *PromptAPIHandler) UpdatePrompt(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req service.UpdatePromptRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.ChangedBy = user.Email
+	}
 
 	prompt, err := h.promptService.UpdatePrompt(r.Context(), promptID, &req)
 	if err != nil {
 		h.logger.Error("failed to update prompt", zap.String("id", promptIDStr), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to update prompt: "+err.Error())
+		APIServiceError(w, err, "failed to update prompt: "+err.Error())
 		return
 	}
 
@@ -280,7 +279,7 @@ func (h *PromptAPIHandler) DeletePrompt(w http.ResponseWriter, r *http.Request)
 
 	if err := h.promptService.DeletePrompt(r.Context(), promptID); err != nil {
 		h.logger.Error("failed to delete prompt", zap.String("id", promptIDStr), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to delete prompt")
+		APIServiceError(w, err, "failed to delete prompt")
 		return
 	}
 
@@ -321,7 +320,7 @@ func (h *PromptAPIHandler) SetDefaultPrompt(w http.ResponseWriter, r *http.Reque
 
 	if err := h.promptService.SetDefaultPrompt(r.Context(), promptID); err != nil {
 		h.logger.Error("failed to set default prompt", zap.String("id", promptIDStr), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to set default prompt")
+		APIServiceError(w, err, "failed to set default prompt")
 		return
 	}
 
@@ -333,7 +332,7 @@ func (h *PromptAPIHandler) SetDefaultPrompt(w http.ResponseWriter, r *http.Reque
 
 // DuplicatePromptRequest is the request body for duplicating a prompt.
 type DuplicatePromptRequest struct {
-	Name string `json:"name"`
+	Name string `json:"name" validate:"required"`
 }
 
 // DuplicatePrompt handles POST /api/v1/prompts/{promptID}/duplicate
@@ -347,6 +346,7 @@ type DuplicatePromptRequest struct {
 // @Success 201 {object} domain.Prompt
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
 // @Router /api/v1/prompts/{promptID}/duplicate [post]
 func (h *PromptAPIHandler) DuplicatePrompt(w http.ResponseWriter, r *http.Request) {
 	promptIDStr := chi.URLParam(r, "promptID")
@@ -357,26 +357,147 @@ func (h *PromptAPIHandler) DuplicatePrompt(w http.ResponseWriter, r *http.Reques
 	}
 
 	var req DuplicatePromptRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid request body")
-		return
-	}
-
-	if req.Name == "" {
-		h.respondError(w, http.StatusBadRequest, "name is required")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	prompt, err := h.promptService.DuplicatePrompt(r.Context(), promptID, req.Name)
 	if err != nil {
 		h.logger.Error("failed to duplicate prompt", zap.String("id", promptIDStr), zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to duplicate prompt: "+err.Error())
+		APIServiceError(w, err, "failed to duplicate prompt: "+err.Error())
 		return
 	}
 
 	h.respondJSON(w, http.StatusCreated, prompt)
 }
 
+// PromptVersionResponse is an earlier version of a prompt compared side by
+// side with the prompt as it is now.
+type PromptVersionResponse struct {
+	*domain.PromptVersion
+	Fields []domain.PromptFieldDiff `json:"fields"`
+}
+
+// ListPromptVersions handles GET /api/v1/prompts/{promptID}/versions
+// @Summary List prompt versions
+// @Description Lists the content a prompt had before each update, newest first
+// @Tags prompts
+// @Produce json
+// @Param promptID path string true "Prompt ID"
+// @Success 200 {array} domain.PromptVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/prompts/{promptID}/versions [get]
+func (h *PromptAPIHandler) ListPromptVersions(w http.ResponseWriter, r *http.Request) {
+	promptIDStr := chi.URLParam(r, "promptID")
+	promptID, err := uuid.Parse(promptIDStr)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid prompt_id")
+		return
+	}
+
+	versions, err := h.promptService.ListPromptVersions(r.Context(), promptID)
+	if err != nil {
+		h.respondServiceError(w, "failed to list prompt versions", promptIDStr, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, versions)
+}
+
+// GetPromptVersion handles GET /api/v1/prompts/{promptID}/versions/{version}
+// @Summary Get a prompt version
+// @Description Retrieves an earlier version of a prompt with each field compared to the current prompt
+// @Tags prompts
+// @Produce json
+// @Param promptID path string true "Prompt ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} PromptVersionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/prompts/{promptID}/versions/{version} [get]
+func (h *PromptAPIHandler) GetPromptVersion(w http.ResponseWriter, r *http.Request) {
+	promptIDStr := chi.URLParam(r, "promptID")
+	promptID, err := uuid.Parse(promptIDStr)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid prompt_id")
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		h.respondError(w, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	v, fields, err := h.promptService.GetPromptVersion(r.Context(), promptID, version)
+	if err != nil {
+		h.respondServiceError(w, "failed to get prompt version", promptIDStr, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, &PromptVersionResponse{PromptVersion: v, Fields: fields})
+}
+
+// RestorePromptVersion handles POST /api/v1/prompts/{promptID}/versions/{version}/restore
+// @Summary Restore a prompt version
+// @Description Sets a prompt's content back to an earlier version, keeping the replaced content as a new version
+// @Tags prompts
+// @Produce json
+// @Param promptID path string true "Prompt ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} domain.Prompt
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/prompts/{promptID}/versions/{version}/restore [post]
+func (h *PromptAPIHandler) RestorePromptVersion(w http.ResponseWriter, r *http.Request) {
+	promptIDStr := chi.URLParam(r, "promptID")
+	promptID, err := uuid.Parse(promptIDStr)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid prompt_id")
+		return
+	}
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		h.respondError(w, http.StatusBadRequest, "invalid version")
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	userID, userName := "", ""
+	if user != nil {
+		userID = user.ID.String()
+		userName = user.Email
+	}
+
+	prompt, err := h.promptService.RestorePromptVersion(r.Context(), promptID, version, userName)
+	if err != nil {
+		h.respondServiceError(w, "failed to restore prompt version", promptIDStr, err)
+		return
+	}
+
+	if h.auditLogger != nil {
+		changes := map[string]interface{}{"restored_version": version}
+		h.auditLogger.PromptUpdated(r.Context(), userID, userName, prompt.ID.String(), prompt.Name, getClientIP(r), GetRequestIDFromContext(r.Context()), changes)
+	}
+
+	h.respondJSON(w, http.StatusOK, prompt)
+}
+
+// respondServiceError answers with the status of a user-facing service
+// error, or 500 for anything else.
+func (h *PromptAPIHandler) respondServiceError(w http.ResponseWriter, message, promptID string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	if verr, ok := err.(*domain.ValidationError); ok {
+		h.respondError(w, http.StatusBadRequest, verr.Error())
+		return
+	}
+	h.logger.Error(message, zap.String("id", promptID), zap.Error(err))
+	APIServiceError(w, err, message)
+}
+
 func (h *PromptAPIHandler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	JSON(w, status, data)
 }
@@ -387,7 +508,7 @@ func (h *PromptAPIHandler) respondError(w http.ResponseWriter, status int, messa
 
 // ApplyToInboundRequest contains optional phone number override.
 type ApplyToInboundRequest struct {
-	PhoneNumber string `json:"phone_number,omitempty"` // Optional - defaults to BLAND_INBOUND_NUMBER env var
+	PhoneNumber string `json:"phone_number,omitempty" validate:"e164"` // Optional - defaults to BLAND_INBOUND_NUMBER env var
 }
 
 // ApplyToInbound handles POST /api/v1/prompts/{promptID}/apply-inbound
@@ -401,6 +522,7 @@ type ApplyToInboundRequest struct {
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/prompts/{promptID}/apply-inbound [post]
 func (h *PromptAPIHandler) ApplyToInbound(w http.ResponseWriter, r *http.Request) {
@@ -419,8 +541,7 @@ func (h *PromptAPIHandler) ApplyToInbound(w http.ResponseWriter, r *http.Request
 	// Parse optional request body
 	var req ApplyToInboundRequest
 	if r.Body != nil && r.ContentLength > 0 {
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			h.respondError(w, http.StatusBadRequest, "invalid request body")
+		if !decodeAndValidate(w, r, &req) {
 			return
 		}
 	}
@@ -444,35 +565,7 @@ func (h *PromptAPIHandler) ApplyToInbound(w http.ResponseWriter, r *http.Request
 	}
 
 	// Build inbound config from prompt
-	config := &bland.InboundConfig{
-		Task:          prompt.Task,
-		Voice:         prompt.Voice,
-		Language:      prompt.Language,
-		Model:         prompt.Model,
-		FirstSentence: prompt.FirstSentence,
-		WaitForGreeting: prompt.WaitForGreeting,
-		Record:        prompt.Record,
-		SummaryPrompt: prompt.SummaryPrompt,
-		AnalysisSchema: prompt.AnalysisSchema,
-		Keywords:      prompt.Keywords,
-		KnowledgeBases: prompt.KnowledgeBaseIDs,
-		Tools:         prompt.CustomToolIDs,
-	}
-
-	// Set optional numeric fields
-	if prompt.Temperature != nil {
-		config.Temperature = *prompt.Temperature
-	}
-	if prompt.InterruptionThreshold != nil {
-		config.InterruptionThreshold = *prompt.InterruptionThreshold
-	}
-	if prompt.MaxDuration != nil {
-		config.MaxDuration = *prompt.MaxDuration
-	}
-	if prompt.BackgroundTrack != nil {
-		config.BackgroundTrack = *prompt.BackgroundTrack
-	}
-	config.NoiseCancellation = prompt.NoiseCancellation
+	config := service.InboundConfigFromPrompt(prompt)
 
 	// Apply to Bland inbound number
 	result, err := h.blandService.ConfigureInboundAgent(r.Context(), phoneNumber, config)
@@ -481,7 +574,7 @@ func (h *PromptAPIHandler) ApplyToInbound(w http.ResponseWriter, r *http.Request
 			zap.String("prompt_id", promptIDStr),
 			zap.String("phone_number", phoneNumber),
 			zap.Error(err))
-		h.respondError(w, http.StatusInternalServerError, "failed to apply prompt: "+err.Error())
+		APIServiceError(w, err, "failed to apply prompt: "+err.Error())
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -807,3 +808,26 @@ func TestDuplicatePromptRequest_JSONParsing(t *testing.T) {
 		t.Errorf("expected name 'Copy of Original', got %q", req.Name)
 	}
 }
+
+func TestPromptBundleYAMLRoundTrip(t *testing.T) {
+	original := []byte(`{"version":1,"prompts":[{"name":"Web App Intake","task":"Ask about the app.\nGet a budget.","temperature":0.7,"max_duration":15}]}`)
+
+	data, err := jsonToYAML(original)
+	if err != nil {
+		t.Fatalf("jsonToYAML() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("version: 1\nprompts:\n    - name: Web App Intake\n      task: |-\n")) {
+		t.Errorf("expected keys in order and the task as a block, got:\n%s", data)
+	}
+
+	back, err := yamlToJSON(data)
+	if err != nil {
+		t.Fatalf("yamlToJSON() error = %v", err)
+	}
+	var want, got interface{}
+	_ = json.Unmarshal(original, &want)
+	_ = json.Unmarshal(back, &got)
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("round trip = %s, want %s", back, original)
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ProviderKeyAPIHandler handles setting and rotating provider API keys at
+// runtime. All of its endpoints are admin only, and keys are never returned.
+type ProviderKeyAPIHandler struct {
+	secretsService *service.SecretsService
+	logger         *zap.Logger
+}
+
+// NewProviderKeyAPIHandler creates a new ProviderKeyAPIHandler.
+func NewProviderKeyAPIHandler(secretsService *service.SecretsService, logger *zap.Logger) *ProviderKeyAPIHandler {
+	return &ProviderKeyAPIHandler{
+		secretsService: secretsService,
+		logger:         logger,
+	}
+}
+
+// ProviderKeyListResponse describes the API key in use for each provider.
+type ProviderKeyListResponse struct {
+	StorageEnabled bool                        `json:"storage_enabled"` // Whether keys can be stored, which needs a master key
+	Keys           []service.ProviderKeyStatus `json:"keys"`
+}
+
+// SetProviderKeyRequest is the body of a request to set a provider's API key.
+type SetProviderKeyRequest struct {
+	Key string `json:"key" validate:"required,max=500"`
+}
+
+// RegisterRoutes registers provider key API routes.
+func (h *ProviderKeyAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/provider-keys", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.List)
+		r.Put("/{provider}", h.Set)
+		r.Delete("/{provider}", h.Delete)
+	})
+}
+
+// List handles GET /api/v1/provider-keys
+// @Summary List provider keys
+// @Description Reports, for Bland, Vapi, Retell and Anthropic, whether the API key in use is stored in the database or comes from the environment, with the last characters of a stored key. Keys themselves are never returned. Admin only.
+// @Tags provider-keys
+// @Produce json
+// @Success 200 {object} ProviderKeyListResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/provider-keys [get]
+func (h *ProviderKeyAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.secretsService.Status(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list provider keys", zap.Error(err))
+		APIServiceError(w, err, "failed to list provider keys")
+		return
+	}
+	JSON(w, http.StatusOK, ProviderKeyListResponse{
+		StorageEnabled: h.secretsService.Enabled(),
+		Keys:           statuses,
+	})
+}
+
+// Set handles PUT /api/v1/provider-keys/{provider}
+// @Summary Set a provider key
+// @Description Encrypts and stores a provider's API key, replacing the stored or environment key. Clients switch to the new key at once, without a restart. Needs SECRETS_MASTER_KEY. Admin only.
+// @Tags provider-keys
+// @Accept json
+// @Produce json
+// @Param provider path string true "bland, vapi, retell or anthropic"
+// @Param request body SetProviderKeyRequest true "API key"
+// @Success 200 {object} service.ProviderKeyStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Failure 503 {object} ErrorResponse "No master key configured"
+// @Router /api/v1/provider-keys/{provider} [put]
+func (h *ProviderKeyAPIHandler) Set(w http.ResponseWriter, r *http.Request) {
+	var req SetProviderKeyRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	provider := domain.CredentialProvider(chi.URLParam(r, "provider"))
+	status, err := h.secretsService.SetKey(r.Context(), userActor(r), provider, req.Key)
+	if err != nil {
+		h.respondProviderKeyError(w, "failed to set provider key", err)
+		return
+	}
+	JSON(w, http.StatusOK, status)
+}
+
+// Delete handles DELETE /api/v1/provider-keys/{provider}
+// @Summary Remove a stored provider key
+// @Description Removes a provider's stored API key. Clients go back to the key in the environment at once. Admin only.
+// @Tags provider-keys
+// @Param provider path string true "bland, vapi, retell or anthropic"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/provider-keys/{provider} [delete]
+func (h *ProviderKeyAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	provider := domain.CredentialProvider(chi.URLParam(r, "provider"))
+	if err := h.secretsService.DeleteKey(r.Context(), userActor(r), provider); err != nil {
+		h.respondProviderKeyError(w, "failed to remove provider key", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// respondProviderKeyError responds with err, passing on user errors and the
+// missing master key as they are.
+func (h *ProviderKeyAPIHandler) respondProviderKeyError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) || apperrors.GetCode(err) == apperrors.CodeServiceUnavailable {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
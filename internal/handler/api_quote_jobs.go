@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// quoteJobStreamPollInterval is how often an open progress stream re-reads the
+// stored job status and sends a keep-alive. The stored status catches jobs
+// finished by another instance, whose events this process never sees.
+const quoteJobStreamPollInterval = 5 * time.Second
+
+// QuoteJobManager retrieves quote jobs and retries dead ones.
+type QuoteJobManager interface {
+	GetJobStatus(ctx context.Context, jobID uuid.UUID) (*domain.QuoteJob, error)
+	ListJobs(ctx context.Context, status domain.QuoteJobStatus, limit, offset int) ([]*domain.QuoteJob, error)
+	RetryJob(ctx context.Context, jobID uuid.UUID) (*domain.QuoteJob, error)
+}
+
+// QuoteJobAPIHandler handles quote job API endpoints.
+type QuoteJobAPIHandler struct {
+	jobs         QuoteJobManager
+	events       *service.QuoteJobEvents
+	pollInterval time.Duration
+	logger       *zap.Logger
+}
+
+// NewQuoteJobAPIHandler creates a new QuoteJobAPIHandler.
+func NewQuoteJobAPIHandler(jobs QuoteJobManager, events *service.QuoteJobEvents, logger *zap.Logger) *QuoteJobAPIHandler {
+	return &QuoteJobAPIHandler{
+		jobs:         jobs,
+		events:       events,
+		pollInterval: quoteJobStreamPollInterval,
+		logger:       logger,
+	}
+}
+
+// RegisterRoutes registers quote job API routes.
+func (h *QuoteJobAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/quote-jobs", func(r chi.Router) {
+		r.Get("/", h.ListQuoteJobs)
+		r.Post("/{jobID}/retry", h.RetryQuoteJob)
+		r.Get("/{jobID}/stream", h.StreamQuoteJob)
+	})
+}
+
+// ListQuoteJobs handles GET /api/v1/quote-jobs
+// @Summary List quote jobs
+// @Description Returns quote jobs, most recently updated first, with attempts and the last error. Pass status=dead for the
+// @Description dead-letter queue: jobs that ran out of retries and wait for a manual retry.
+// @Tags quote-jobs
+// @Produce json
+// @Param status query string false "Only jobs with this status (pending, processing, completed, dead)"
+// @Param limit query int false "Maximum jobs to return (default 50, max 100)"
+// @Param offset query int false "Jobs to skip"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/quote-jobs [get]
+func (h *QuoteJobAPIHandler) ListQuoteJobs(w http.ResponseWriter, r *http.Request) {
+	if h.jobs == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote jobs are not configured")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	jobs, err := h.jobs.ListJobs(r.Context(), domain.QuoteJobStatus(query.Get("status")), limit, offset)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to list quote jobs", zap.Error(err))
+		APIServiceError(w, err, "failed to list quote jobs")
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"jobs": jobs,
+	})
+}
+
+// RetryQuoteJob handles POST /api/v1/quote-jobs/{jobID}/retry
+// @Summary Retry a dead quote job
+// @Description Takes a job out of the dead-letter queue and schedules it with a fresh set of attempts.
+// @Tags quote-jobs
+// @Produce json
+// @Param jobID path string true "Quote job ID"
+// @Success 200 {object} domain.QuoteJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/quote-jobs/{jobID}/retry [post]
+func (h *QuoteJobAPIHandler) RetryQuoteJob(w http.ResponseWriter, r *http.Request) {
+	if h.jobs == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote jobs are not configured")
+		return
+	}
+
+	jobID, err := uuid.Parse(chi.URLParam(r, "jobID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid job_id")
+		return
+	}
+
+	job, err := h.jobs.RetryJob(r.Context(), jobID)
+	if err != nil {
+		switch {
+		case apperrors.IsNotFound(err):
+			APIError(w, http.StatusNotFound, "quote job not found")
+		case apperrors.IsUserError(err):
+			APIAppError(w, err)
+		default:
+			h.logger.Error("failed to retry quote job", zap.Error(err), zap.String("job_id", jobID.String()))
+			APIServiceError(w, err, "failed to retry quote job")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, job)
+}
+
+// StreamQuoteJob handles GET /api/v1/quote-jobs/{jobID}/stream
+// @Summary Stream quote generation progress
+// @Description Server-Sent Events stream of a quote job. "phase" events report queued, extracting, pricing, complete or failed;
+// @Description "token" events carry the quote text as Claude writes it. A job already in progress starts with its current phase
+// @Description and the text so far. A "queued" phase after tokens means the attempt failed and will be retried, so discard the text.
+// @Description The stream ends after "complete" or "failed".
+// @Tags quote-jobs
+// @Produce text/event-stream
+// @Param jobID path string true "Quote job ID"
+// @Success 200 {object} domain.QuoteJobEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/quote-jobs/{jobID}/stream [get]
+func (h *QuoteJobAPIHandler) StreamQuoteJob(w http.ResponseWriter, r *http.Request) {
+	if h.jobs == nil || h.events == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote job streaming is not configured")
+		return
+	}
+
+	jobID, err := uuid.Parse(chi.URLParam(r, "jobID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid job_id")
+		return
+	}
+
+	// Subscribe before reading the job so no event between the two is missed.
+	catchUp, events, unsubscribe := h.events.Subscribe(jobID)
+	defer unsubscribe()
+
+	job, err := h.jobs.GetJobStatus(r.Context(), jobID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			APIError(w, http.StatusNotFound, "quote job not found")
+			return
+		}
+		h.logger.Error("failed to get quote job", zap.Error(err), zap.String("job_id", jobID.String()))
+		APIServiceError(w, err, "failed to get quote job")
+		return
+	}
+	if len(catchUp) == 0 {
+		catchUp = []domain.QuoteJobEvent{storedJobEvent(job)}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	// The stream lasts as long as the job, well past the server's write timeout.
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range catchUp {
+		if err := writeQuoteJobEvent(w, event); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil || catchUp[0].Phase.IsFinal() {
+		return
+	}
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				// Either the final phase was delivered or this listener fell
+				// behind; a reconnecting client catches up from the start.
+				return
+			}
+			if err := writeQuoteJobEvent(w, event); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil || event.Phase.IsFinal() {
+				return
+			}
+
+		case <-ticker.C:
+			job, err := h.jobs.GetJobStatus(r.Context(), jobID)
+			if err == nil && job.IsTerminal() {
+				_ = writeQuoteJobEvent(w, storedJobEvent(job))
+				_ = rc.Flush()
+				return
+			}
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// storedJobEvent describes a job's progress from its stored status.
+func storedJobEvent(job *domain.QuoteJob) domain.QuoteJobEvent {
+	event := domain.QuoteJobEvent{JobID: job.ID, Phase: job.Phase(), Attempt: job.Attempts}
+	if job.Status == domain.QuoteJobStatusDead && job.LastError != nil {
+		event.Error = *job.LastError
+	}
+	return event
+}
+
+// writeQuoteJobEvent writes an event in Server-Sent Events format.
+func writeQuoteJobEvent(w http.ResponseWriter, event domain.QuoteJobEvent) error {
+	name := "phase"
+	if event.IsToken() {
+		name = "token"
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
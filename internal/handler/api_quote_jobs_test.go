@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+type stubQuoteJobs struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*domain.QuoteJob
+	read chan struct{} // closed on the first lookup, if set
+}
+
+func (s *stubQuoteJobs) GetJobStatus(ctx context.Context, id uuid.UUID) (*domain.QuoteJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.read != nil {
+		close(s.read)
+		s.read = nil
+	}
+	if job, ok := s.jobs[id]; ok {
+		cp := *job
+		return &cp, nil
+	}
+	return nil, apperrors.NotFound("quote job")
+}
+
+func (s *stubQuoteJobs) ListJobs(ctx context.Context, status domain.QuoteJobStatus, limit, offset int) ([]*domain.QuoteJob, error) {
+	if status != "" && !status.IsValid() {
+		return nil, apperrors.ValidationFailed("unknown quote job status")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := []*domain.QuoteJob{}
+	for _, job := range s.jobs {
+		if status == "" || job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (s *stubQuoteJobs) RetryJob(ctx context.Context, id uuid.UUID) (*domain.QuoteJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, apperrors.NotFound("quote job")
+	}
+	if job.Status != domain.QuoteJobStatusDead {
+		return nil, apperrors.New(apperrors.CodeConflict, "only dead jobs can be retried")
+	}
+	job.Retry()
+	return job, nil
+}
+
+func newQuoteJobStreamRouter(jobs *stubQuoteJobs, events *service.QuoteJobEvents) chi.Router {
+	h := NewQuoteJobAPIHandler(jobs, events, zap.NewNop())
+	h.pollInterval = 10 * time.Millisecond
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+	return r
+}
+
+func TestQuoteJobAPIHandler_StreamFinishedJob(t *testing.T) {
+	job := domain.NewQuoteJob(uuid.New())
+	job.MarkProcessing()
+	job.MarkCompleted()
+	r := newQuoteJobStreamRouter(&stubQuoteJobs{jobs: map[uuid.UUID]*domain.QuoteJob{job.ID: job}}, service.NewQuoteJobEvents())
+
+	req := httptest.NewRequest(http.MethodGet, "/quote-jobs/"+job.ID.String()+"/stream", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %s", ct)
+	}
+	if !strings.Contains(rr.Body.String(), "event: phase\ndata: ") || !strings.Contains(rr.Body.String(), `"phase":"complete"`) {
+		t.Errorf("expected a single complete phase event, got %q", rr.Body.String())
+	}
+}
+
+func TestQuoteJobAPIHandler_StreamLiveJob(t *testing.T) {
+	job := domain.NewQuoteJob(uuid.New())
+	events := service.NewQuoteJobEvents()
+	read := make(chan struct{})
+	r := newQuoteJobStreamRouter(&stubQuoteJobs{jobs: map[uuid.UUID]*domain.QuoteJob{job.ID: job}, read: read}, events)
+
+	go func() {
+		// The handler subscribes before it first looks the job up.
+		<-read
+		events.PublishPhase(job.ID, domain.QuoteJobPhaseExtracting, 1, "")
+		events.PublishPhase(job.ID, domain.QuoteJobPhasePricing, 1, "")
+		events.PublishText(job.ID, "Project Overview")
+		events.PublishPhase(job.ID, domain.QuoteJobPhaseComplete, 1, "")
+	}()
+
+	req := httptest.NewRequest(http.MethodGet, "/quote-jobs/"+job.ID.String()+"/stream", nil)
+	rr := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		r.ServeHTTP(rr, req)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not end after the job completed")
+	}
+
+	body := rr.Body.String()
+	for _, want := range []string{`"phase":"queued"`, "event: token\n", `"text":"Project Overview"`, `"phase":"complete"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in stream, got %q", want, body)
+		}
+	}
+}
+
+func TestQuoteJobAPIHandler_StreamErrors(t *testing.T) {
+	r := newQuoteJobStreamRouter(&stubQuoteJobs{}, service.NewQuoteJobEvents())
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/quote-jobs/not-a-uuid/stream", http.StatusBadRequest},
+		{"/quote-jobs/" + uuid.New().String() + "/stream", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rr.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.status, rr.Code)
+		}
+	}
+
+	h := NewQuoteJobAPIHandler(nil, nil, zap.NewNop())
+	rr := httptest.NewRecorder()
+	h.StreamQuoteJob(rr, httptest.NewRequest(http.MethodGet, "/quote-jobs/x/stream", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d when not configured, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+}
+
+func TestQuoteJobAPIHandler_DeadLetterQueue(t *testing.T) {
+	dead := domain.NewQuoteJob(uuid.New())
+	dead.Attempts = dead.MaxAttempts
+	dead.MarkFailed(errors.New("invalid prompt"))
+	pending := domain.NewQuoteJob(uuid.New())
+	jobs := &stubQuoteJobs{jobs: map[uuid.UUID]*domain.QuoteJob{dead.ID: dead, pending.ID: pending}}
+	r := newQuoteJobStreamRouter(jobs, service.NewQuoteJobEvents())
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/quote-jobs?status=dead", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("list: expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var list struct {
+		Jobs []*domain.QuoteJob `json:"jobs"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil || len(list.Jobs) != 1 || list.Jobs[0].ID != dead.ID {
+		t.Fatalf("list = %s, %v; want the dead job", rr.Body.String(), err)
+	}
+	if *list.Jobs[0].LastError != "invalid prompt" {
+		t.Errorf("last_error = %q, want the failure reason", *list.Jobs[0].LastError)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/quote-jobs?status=unknown", http.StatusBadRequest},
+		{http.MethodPost, "/quote-jobs/" + dead.ID.String() + "/retry", http.StatusOK},
+		{http.MethodPost, "/quote-jobs/" + dead.ID.String() + "/retry", http.StatusConflict},
+		{http.MethodPost, "/quote-jobs/" + uuid.New().String() + "/retry", http.StatusNotFound},
+		{http.MethodPost, "/quote-jobs/not-a-uuid/retry", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+		if rr.Code != tt.status {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.status, rr.Code)
+		}
+	}
+	if dead.Status != domain.QuoteJobStatusPending {
+		t.Errorf("retried job status = %s, want pending", dead.Status)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// QuoteRequestAPIHandler serves the public quote request endpoints used by
+// the form widget contractors embed on their websites. No sign-in is
+// needed; the caller should apply CORS and rate limiting.
+type QuoteRequestAPIHandler struct {
+	requestService *service.QuoteRequestService
+	config         QuoteRequestAPIConfig
+	logger         *zap.Logger
+}
+
+// QuoteRequestAPIConfig holds the settings the widget is rendered with.
+type QuoteRequestAPIConfig struct {
+	CaptchaProvider string
+	CaptchaSiteKey  string
+	ProjectTypes    []string
+}
+
+// NewQuoteRequestAPIHandler creates a new QuoteRequestAPIHandler.
+func NewQuoteRequestAPIHandler(requestService *service.QuoteRequestService, cfg QuoteRequestAPIConfig, logger *zap.Logger) *QuoteRequestAPIHandler {
+	return &QuoteRequestAPIHandler{
+		requestService: requestService,
+		config:         cfg,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the public quote request routes.
+func (h *QuoteRequestAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/quote-requests/config", h.GetConfig)
+	r.With(middleware.BodySizeLimiterForm()).Post("/quote-requests", h.SubmitRequest)
+}
+
+// quoteRequestBody is a quote request as the widget posts it.
+type quoteRequestBody struct {
+	domain.QuoteRequest
+	CaptchaToken string `json:"captcha_token,omitempty"`
+}
+
+// GetConfig handles GET /api/public/quote-requests/config
+// @Summary Get the quote form settings
+// @Description Returns what the embeddable form needs to render: the captcha provider and site key, project types to choose from, and whether callbacks are offered. No authentication required.
+// @Tags public
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/public/quote-requests/config [get]
+func (h *QuoteRequestAPIHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	projectTypes := h.config.ProjectTypes
+	if projectTypes == nil {
+		projectTypes = []string{}
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"captcha_provider": h.config.CaptchaProvider,
+		"captcha_site_key": h.config.CaptchaSiteKey,
+		"project_types":    projectTypes,
+		"callbacks":        h.requestService.CallbacksEnabled(),
+	})
+}
+
+// SubmitRequest handles POST /api/public/quote-requests
+// @Summary Submit a quote request
+// @Description Records a quote request from a website form and queues its quote. Needs a name, a description and an email address or phone number; callback asks for an AI call to the phone number. No authentication required.
+// @Tags public
+// @Accept json
+// @Produce json
+// @Param request body quoteRequestBody true "Quote request"
+// @Success 201 {object} service.QuoteRequestResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse "Captcha verification failed"
+// @Failure 429 {object} ErrorResponse
+// @Router /api/public/quote-requests [post]
+func (h *QuoteRequestAPIHandler) SubmitRequest(w http.ResponseWriter, r *http.Request) {
+	var body quoteRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.requestService.Submit(r.Context(), &body.QuoteRequest, body.CaptchaToken, getClientIP(r))
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to submit quote request", zap.Error(err))
+		APIServiceError(w, err, "failed to submit quote request")
+		return
+	}
+
+	JSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// QuoteTemplateAPIHandler handles quote template endpoints. Only admins may
+// change templates, since they appear on every quote for a project type.
+type QuoteTemplateAPIHandler struct {
+	templateService *service.QuoteTemplateService
+	logger          *zap.Logger
+}
+
+// NewQuoteTemplateAPIHandler creates a new QuoteTemplateAPIHandler.
+func NewQuoteTemplateAPIHandler(templateService *service.QuoteTemplateService, logger *zap.Logger) *QuoteTemplateAPIHandler {
+	return &QuoteTemplateAPIHandler{
+		templateService: templateService,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers quote template API routes.
+func (h *QuoteTemplateAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/quote-templates", func(r chi.Router) {
+		r.Get("/", h.ListTemplates)
+		r.Get("/{templateID}", h.GetTemplate)
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAPIAdmin)
+			r.Post("/", h.CreateTemplate)
+			r.Put("/{templateID}", h.UpdateTemplate)
+			r.Delete("/{templateID}", h.DeleteTemplate)
+		})
+	})
+}
+
+// ListTemplates handles GET /api/v1/quote-templates
+// @Summary List quote templates
+// @Description Lists quote templates ordered by project type.
+// @Tags quote-templates
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quote-templates [get]
+func (h *QuoteTemplateAPIHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.ListTemplates(r.Context())
+	if err != nil {
+		h.respondTemplateError(w, "failed to list quote templates", err)
+		return
+	}
+	if templates == nil {
+		templates = []*domain.QuoteTemplate{}
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"templates": templates,
+	})
+}
+
+// CreateTemplate handles POST /api/v1/quote-templates
+// @Summary Create a quote template
+// @Description Creates the template for a project type. Use project type "default" for the template used when no other matches. Admin only.
+// @Tags quote-templates
+// @Accept json
+// @Produce json
+// @Param request body service.QuoteTemplateRequest true "Template details"
+// @Success 201 {object} domain.QuoteTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The project type already has a template"
+// @Router /api/v1/quote-templates [post]
+func (h *QuoteTemplateAPIHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req service.QuoteTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(r.Context(), &req)
+	if err != nil {
+		h.respondTemplateError(w, "failed to create quote template", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, template)
+}
+
+// GetTemplate handles GET /api/v1/quote-templates/{templateID}
+// @Summary Get a quote template
+// @Tags quote-templates
+// @Produce json
+// @Param templateID path string true "Template ID"
+// @Success 200 {object} domain.QuoteTemplate
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quote-templates/{templateID} [get]
+func (h *QuoteTemplateAPIHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := h.templateID(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(r.Context(), templateID)
+	if err != nil {
+		h.respondTemplateError(w, "failed to get quote template", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, template)
+}
+
+// UpdateTemplate handles PUT /api/v1/quote-templates/{templateID}
+// @Summary Replace a quote template
+// @Description Replaces every field of a template. Admin only.
+// @Tags quote-templates
+// @Accept json
+// @Produce json
+// @Param templateID path string true "Template ID"
+// @Param request body service.QuoteTemplateRequest true "Template details"
+// @Success 200 {object} domain.QuoteTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "The project type already has a template"
+// @Router /api/v1/quote-templates/{templateID} [put]
+func (h *QuoteTemplateAPIHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := h.templateID(w, r)
+	if !ok {
+		return
+	}
+
+	var req service.QuoteTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(r.Context(), templateID, &req)
+	if err != nil {
+		h.respondTemplateError(w, "failed to update quote template", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /api/v1/quote-templates/{templateID}
+// @Summary Delete a quote template
+// @Description Admin only.
+// @Tags quote-templates
+// @Produce json
+// @Param templateID path string true "Template ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quote-templates/{templateID} [delete]
+func (h *QuoteTemplateAPIHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	templateID, ok := h.templateID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(r.Context(), templateID); err != nil {
+		h.respondTemplateError(w, "failed to delete quote template", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "quote template deleted",
+	})
+}
+
+func (h *QuoteTemplateAPIHandler) templateID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	templateID, err := uuid.Parse(chi.URLParam(r, "templateID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid template_id")
+		return uuid.Nil, false
+	}
+	return templateID, true
+}
+
+func (h *QuoteTemplateAPIHandler) respondTemplateError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
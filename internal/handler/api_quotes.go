@@ -0,0 +1,701 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/export"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// QuoteAPIHandler handles quote-related API endpoints.
+type QuoteAPIHandler struct {
+	pdfService    *quotepdf.Service
+	exportService *export.Service
+	quoteService  *service.QuoteService
+	followUps     *service.FollowUpService
+	portal        *service.QuotePortalService
+	payments      *service.PaymentService
+	accounting    *service.AccountingService
+	bulk          *service.BulkService
+	logger        *zap.Logger
+}
+
+// NewQuoteAPIHandler creates a new QuoteAPIHandler.
+func NewQuoteAPIHandler(pdfService *quotepdf.Service, logger *zap.Logger) *QuoteAPIHandler {
+	return &QuoteAPIHandler{
+		pdfService: pdfService,
+		logger:     logger,
+	}
+}
+
+// SetExportService sets the service used to export quotes.
+func (h *QuoteAPIHandler) SetExportService(es *export.Service) {
+	h.exportService = es
+}
+
+// SetQuoteService sets the service used for the quote review workflow.
+func (h *QuoteAPIHandler) SetQuoteService(qs *service.QuoteService) {
+	h.quoteService = qs
+}
+
+// SetFollowUpService sets the service that follows up on sent quotes.
+func (h *QuoteAPIHandler) SetFollowUpService(fs *service.FollowUpService) {
+	h.followUps = fs
+}
+
+// SetQuotePortalService sets the service that creates customer quote links.
+func (h *QuoteAPIHandler) SetQuotePortalService(ps *service.QuotePortalService) {
+	h.portal = ps
+}
+
+// SetPaymentService sets the service that collects payment for accepted quotes.
+func (h *QuoteAPIHandler) SetPaymentService(ps *service.PaymentService) {
+	h.payments = ps
+}
+
+// SetAccountingService sets the service that pushes accepted quotes to the
+// accounting system.
+func (h *QuoteAPIHandler) SetAccountingService(as *service.AccountingService) {
+	h.accounting = as
+}
+
+// SetBulkService sets the service that applies one action to many quotes.
+func (h *QuoteAPIHandler) SetBulkService(bs *service.BulkService) {
+	h.bulk = bs
+}
+
+// RegisterRoutes registers quote API routes.
+func (h *QuoteAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/quotes", func(r chi.Router) {
+		r.Get("/export", h.ExportQuotes)
+		r.Post("/bulk", h.BulkQuotes)
+		r.Get("/{quoteID}", h.GetQuote)
+		r.Put("/{quoteID}", h.UpdateQuote)
+		r.Get("/{quoteID}/pdf", h.GetQuotePDF)
+		r.Post("/{quoteID}/submit", h.SubmitQuote)
+		r.Post("/{quoteID}/approve", h.ApproveQuote)
+		r.Post("/{quoteID}/request-changes", h.RequestQuoteChanges)
+		r.Post("/{quoteID}/send", h.SendQuote)
+		r.Post("/{quoteID}/accept", h.AcceptQuote)
+		r.Post("/{quoteID}/decline", h.DeclineQuote)
+		r.Post("/{quoteID}/archive", h.ArchiveQuote)
+		r.Post("/{quoteID}/unarchive", h.UnarchiveQuote)
+		r.Post("/{quoteID}/link", h.CreateQuoteLink)
+		r.Post("/{quoteID}/payment-link", h.CreatePaymentLink)
+		r.Post("/{quoteID}/accounting-sync", h.SyncQuoteToAccounting)
+		r.Get("/{quoteID}/accounting-syncs", h.ListAccountingSyncs)
+		r.Get("/{quoteID}/follow-ups", h.ListQuoteFollowUps)
+		r.Delete("/{quoteID}/follow-ups", h.CancelQuoteFollowUps)
+	})
+}
+
+// GetQuotePDF handles GET /api/v1/quotes/{quoteID}/pdf
+// @Summary Download a quote PDF
+// @Description Renders the generated quote for a call as a branded PDF document. The quote ID is the call ID.
+// @Tags quotes
+// @Produce application/pdf
+// @Param quoteID path string true "Quote (call) ID"
+// @Param download query bool false "Serve as an attachment instead of inline"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/pdf [get]
+func (h *QuoteAPIHandler) GetQuotePDF(w http.ResponseWriter, r *http.Request) {
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	pdf, err := h.pdfService.Render(r.Context(), quoteID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			APIError(w, http.StatusNotFound, "quote not found")
+			return
+		}
+		h.logger.Error("failed to render quote PDF", zap.String("quote_id", quoteID.String()), zap.Error(err))
+		APIServiceError(w, err, "failed to render quote PDF")
+		return
+	}
+
+	disposition := "inline"
+	if r.URL.Query().Get("download") == "true" {
+		disposition = "attachment"
+	}
+
+	w.Header().Set("Content-Type", pdf.ContentType)
+	w.Header().Set("Content-Disposition", disposition+`; filename="`+pdf.Filename+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf.Data)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(pdf.Data); err != nil {
+		h.logger.Warn("failed to write quote PDF", zap.Error(err))
+	}
+}
+
+// ExportQuotes handles GET /api/v1/quotes/export
+// @Summary Export quotes
+// @Description Streams generated quotes and the customer details they were built from as CSV or XLSX
+// @Tags quotes
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default) or xlsx"
+// @Param from query string false "Created on or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339) or on (YYYY-MM-DD)"
+// @Param status query string false "Call status"
+// @Param phone_number query string false "Matches the called or calling number"
+// @Param tag query string false "Only calls tagged with all of these comma-separated tags"
+// @Param quote_tag query string false "Only calls whose quote is tagged with all of these comma-separated tags"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/quotes/export [get]
+func (h *QuoteAPIHandler) ExportQuotes(w http.ResponseWriter, r *http.Request) {
+	if h.exportService == nil {
+		APIError(w, http.StatusServiceUnavailable, "export not configured")
+		return
+	}
+	serveExport(w, r, "quotes", h.exportService.WriteQuotes, h.logger)
+}
+
+// BulkQuotes handles POST /api/v1/quotes/bulk
+// @Summary Apply an action to many quotes
+// @Description Archives, tags, re-runs, exports or assigns the listed quotes; a quote ID is its call ID. Every quote is checked first; if the action can't be applied to one of them, none is changed and the report is returned with 422, marking the quotes that failed and why and the others rejected. Archiving, tagging and assigning change every quote in one transaction; assigning a quote assigns its call. rerun_quote queues a quote job per call and reports each job. export streams the quotes as CSV or XLSX (format).
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param request body BulkBody true "Bulk action"
+// @Success 200 {object} domain.BulkResult "Applied, or the export file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} domain.BulkResult "Not applied; see the failed items"
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v1/quotes/bulk [post]
+func (h *QuoteAPIHandler) BulkQuotes(w http.ResponseWriter, r *http.Request) {
+	if h.bulk == nil {
+		APIError(w, http.StatusServiceUnavailable, "bulk actions not configured")
+		return
+	}
+	var write exportFunc
+	if h.exportService != nil {
+		write = h.exportService.WriteQuotes
+	}
+	serveBulk(w, r, domain.BulkRecordQuote, h.bulk, h.exportService, write, h.logger)
+}
+
+// QuoteTransitionRequest is the optional API request body for a quote status change.
+type QuoteTransitionRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// GetQuote handles GET /api/v1/quotes/{quoteID}
+// @Summary Get quote review status
+// @Description Returns the quote's workflow status, approval requirement and status history. The quote ID is the call ID.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {object} service.QuoteDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID} [get]
+func (h *QuoteAPIHandler) GetQuote(w http.ResponseWriter, r *http.Request) {
+	if h.quoteService == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote workflow not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	detail, err := h.quoteService.GetQuote(r.Context(), quoteID)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, detail)
+}
+
+// QuoteLineItemRequest is a line item in an UpdateQuoteRequest.
+type QuoteLineItemRequest struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+}
+
+// UpdateQuoteRequest is the API request body for editing a draft quote.
+type UpdateQuoteRequest struct {
+	LineItems []QuoteLineItemRequest `json:"line_items"`
+	TaxRate   float64                `json:"tax_rate"` // Percent, 0 to 100
+	Notes     string                 `json:"notes,omitempty"`
+	Currency  string                 `json:"currency,omitempty"` // ISO 4217 code; omit to keep the quote's currency
+}
+
+// UpdateQuote handles PUT /api/v1/quotes/{quoteID}
+// @Summary Edit a draft quote
+// @Description Replaces the quote's line items, tax rate and notes and recalculates its totals. Line amounts are computed from quantity and unit price. The currency may be changed; amounts are not converted. Only draft quotes can be edited.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body UpdateQuoteRequest true "Quote content"
+// @Success 200 {object} service.QuoteDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID} [put]
+func (h *QuoteAPIHandler) UpdateQuote(w http.ResponseWriter, r *http.Request) {
+	if h.quoteService == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote workflow not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	var req UpdateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	items := make([]domain.QuoteLineItem, len(req.LineItems))
+	for i, li := range req.LineItems {
+		items[i] = domain.QuoteLineItem{Description: li.Description, Quantity: li.Quantity, UnitPrice: li.UnitPrice}
+	}
+
+	actor := service.QuoteActor{
+		User:      GetUserFromContext(r.Context()),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+
+	detail, err := h.quoteService.UpdateQuote(r.Context(), quoteID, actor, items, req.TaxRate, req.Notes, req.Currency)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, detail)
+}
+
+// SubmitQuote handles POST /api/v1/quotes/{quoteID}/submit
+// @Summary Submit a draft quote for review
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/submit [post]
+func (h *QuoteAPIHandler) SubmitQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.Submit)
+}
+
+// ApproveQuote handles POST /api/v1/quotes/{quoteID}/approve
+// @Summary Approve a quote under review
+// @Description Quotes above the approval threshold must be approved by a designated approver other than the submitter.
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/approve [post]
+func (h *QuoteAPIHandler) ApproveQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.Approve)
+}
+
+// RequestQuoteChanges handles POST /api/v1/quotes/{quoteID}/request-changes
+// @Summary Return a quote to draft
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/request-changes [post]
+func (h *QuoteAPIHandler) RequestQuoteChanges(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.RequestChanges)
+}
+
+// SendQuote handles POST /api/v1/quotes/{quoteID}/send
+// @Summary Mark an approved quote as sent to the customer
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/send [post]
+func (h *QuoteAPIHandler) SendQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.MarkSent)
+}
+
+// AcceptQuote handles POST /api/v1/quotes/{quoteID}/accept
+// @Summary Record that the customer accepted a quote
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/accept [post]
+func (h *QuoteAPIHandler) AcceptQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.MarkAccepted)
+}
+
+// DeclineQuote handles POST /api/v1/quotes/{quoteID}/decline
+// @Summary Record that the customer declined a quote
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Param request body QuoteTransitionRequest false "Optional note"
+// @Success 200 {object} domain.Quote
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/decline [post]
+func (h *QuoteAPIHandler) DeclineQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.quoteService.MarkDeclined)
+}
+
+// CreateQuoteLink handles POST /api/v1/quotes/{quoteID}/link
+// @Summary Create a customer link to a sent quote
+// @Description Creates a link the customer can open without signing in to view, accept or decline the quote. Any earlier link to the quote stops working.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 201 {object} service.QuotePortalLink
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/link [post]
+func (h *QuoteAPIHandler) CreateQuoteLink(w http.ResponseWriter, r *http.Request) {
+	if h.portal == nil {
+		APIError(w, http.StatusServiceUnavailable, "customer quote links not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	actor := service.QuoteActor{
+		User:      GetUserFromContext(r.Context()),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+	link, err := h.portal.CreateLink(r.Context(), quoteID, actor)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, link)
+}
+
+// CreatePaymentLink handles POST /api/v1/quotes/{quoteID}/payment-link
+// @Summary Create a payment link for an accepted quote
+// @Description Creates a payment processor link for the configured deposit share of the quote total. Any earlier unpaid link to the quote is replaced.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 201 {object} domain.QuotePayment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/payment-link [post]
+func (h *QuoteAPIHandler) CreatePaymentLink(w http.ResponseWriter, r *http.Request) {
+	if h.payments == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote payments not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	payment, err := h.payments.CreatePaymentLink(r.Context(), quoteID)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, payment)
+}
+
+// SyncQuoteToAccounting handles POST /api/v1/quotes/{quoteID}/accounting-sync
+// @Summary Push an accepted quote to the accounting system
+// @Description Creates the quote as an estimate or invoice in QuickBooks Online or Xero, creating the customer if needed. The attempt is recorded in the quote's sync log; a push the accounting system rejects returns 502 with the failed sync.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 201 {object} domain.AccountingSync
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 502 {object} domain.AccountingSync
+// @Router /api/v1/quotes/{quoteID}/accounting-sync [post]
+func (h *QuoteAPIHandler) SyncQuoteToAccounting(w http.ResponseWriter, r *http.Request) {
+	if h.accounting == nil {
+		APIError(w, http.StatusServiceUnavailable, "accounting sync not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	attempt, err := h.accounting.SyncQuote(r.Context(), quoteID, GetUserFromContext(r.Context()))
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+	if !attempt.Succeeded() {
+		JSON(w, http.StatusBadGateway, attempt)
+		return
+	}
+
+	JSON(w, http.StatusCreated, attempt)
+}
+
+// ListAccountingSyncs handles GET /api/v1/quotes/{quoteID}/accounting-syncs
+// @Summary List a quote's accounting syncs
+// @Description Returns each attempt to push the quote to the accounting system, newest first.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {array} domain.AccountingSync
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/accounting-syncs [get]
+func (h *QuoteAPIHandler) ListAccountingSyncs(w http.ResponseWriter, r *http.Request) {
+	if h.accounting == nil {
+		APIError(w, http.StatusServiceUnavailable, "accounting sync not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	syncs, err := h.accounting.ListSyncs(r.Context(), quoteID)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+	if syncs == nil {
+		syncs = []*domain.AccountingSync{}
+	}
+
+	JSON(w, http.StatusOK, syncs)
+}
+
+// ListQuoteFollowUps handles GET /api/v1/quotes/{quoteID}/follow-ups
+// @Summary List a quote's follow-ups
+// @Description Returns the follow-up texts and calls scheduled after the quote was sent, in sequence order.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {array} domain.FollowUpStep
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/follow-ups [get]
+func (h *QuoteAPIHandler) ListQuoteFollowUps(w http.ResponseWriter, r *http.Request) {
+	if h.followUps == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote follow-ups not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	steps, err := h.followUps.ListSteps(r.Context(), quoteID)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, steps)
+}
+
+// CancelQuoteFollowUps handles DELETE /api/v1/quotes/{quoteID}/follow-ups
+// @Summary Stop a quote's follow-ups
+// @Description Cancels the quote's pending follow-up texts and calls.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {object} map[string]int
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/follow-ups [delete]
+func (h *QuoteAPIHandler) CancelQuoteFollowUps(w http.ResponseWriter, r *http.Request) {
+	if h.followUps == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote follow-ups not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	reason := "stopped by staff"
+	if user := GetUserFromContext(r.Context()); user != nil {
+		reason = "stopped by " + user.Email
+	}
+
+	cancelled, err := h.followUps.CancelQuote(r.Context(), quoteID, reason)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]int{"cancelled": cancelled})
+}
+
+// ArchiveQuote handles POST /api/v1/quotes/{quoteID}/archive
+// @Summary Archive a quote
+// @Description Archives a quote. Archived quotes can't be edited or moved through the workflow until they are unarchived. Archiving a call archives its quote too.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {object} service.QuoteDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/archive [post]
+func (h *QuoteAPIHandler) ArchiveQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleArchive(w, r, h.quoteService.ArchiveQuote)
+}
+
+// UnarchiveQuote handles POST /api/v1/quotes/{quoteID}/unarchive
+// @Summary Unarchive a quote
+// @Description Returns an archived quote to the workflow.
+// @Tags quotes
+// @Produce json
+// @Param quoteID path string true "Quote (call) ID"
+// @Success 200 {object} service.QuoteDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/unarchive [post]
+func (h *QuoteAPIHandler) UnarchiveQuote(w http.ResponseWriter, r *http.Request) {
+	h.handleArchive(w, r, h.quoteService.UnarchiveQuote)
+}
+
+func (h *QuoteAPIHandler) handleArchive(w http.ResponseWriter, r *http.Request, apply func(context.Context, uuid.UUID, service.QuoteActor) (*service.QuoteDetail, error)) {
+	if h.quoteService == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote workflow not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	actor := service.QuoteActor{
+		User:      GetUserFromContext(r.Context()),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+
+	detail, err := apply(r.Context(), quoteID, actor)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, detail)
+}
+
+// quoteTransitionFunc applies one quote workflow step.
+type quoteTransitionFunc func(ctx context.Context, callID uuid.UUID, actor service.QuoteActor, note string) (*domain.Quote, error)
+
+func (h *QuoteAPIHandler) handleTransition(w http.ResponseWriter, r *http.Request, apply quoteTransitionFunc) {
+	if h.quoteService == nil {
+		APIError(w, http.StatusServiceUnavailable, "quote workflow not configured")
+		return
+	}
+
+	quoteID, err := uuid.Parse(chi.URLParam(r, "quoteID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid quote_id")
+		return
+	}
+
+	var req QuoteTransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	actor := service.QuoteActor{
+		User:      GetUserFromContext(r.Context()),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+
+	quote, err := apply(r.Context(), quoteID, actor, req.Note)
+	if err != nil {
+		h.respondQuoteError(w, quoteID, err)
+		return
+	}
+
+	JSON(w, http.StatusOK, quote)
+}
+
+func (h *QuoteAPIHandler) respondQuoteError(w http.ResponseWriter, quoteID uuid.UUID, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, "quote not found")
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error("quote workflow request failed", zap.String("quote_id", quoteID.String()), zap.Error(err))
+	APIServiceError(w, err, "failed to update quote")
+}
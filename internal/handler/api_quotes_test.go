@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/export"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+type stubQuoteCalls map[uuid.UUID]*domain.Call
+
+func (s stubQuoteCalls) GetByID(ctx context.Context, id uuid.UUID) (*domain.Call, error) {
+	if call, ok := s[id]; ok {
+		return call, nil
+	}
+	return nil, apperrors.NotFound("call")
+}
+
+func TestQuoteAPIHandler_GetQuotePDF(t *testing.T) {
+	withQuote := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	quote := "- Build: $1,000"
+	withQuote.QuoteSummary = &quote
+	withoutQuote := domain.NewCall("p2", "bland", "+15550001111", "+15550002222")
+
+	svc := quotepdf.NewService(stubQuoteCalls{withQuote.ID: withQuote, withoutQuote.ID: withoutQuote}, nil, quotepdf.DefaultConfig(), zap.NewNop())
+	h := NewQuoteAPIHandler(svc, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	tests := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		{"quote available", "/quotes/" + withQuote.ID.String() + "/pdf", http.StatusOK},
+		{"no quote yet", "/quotes/" + withoutQuote.ID.String() + "/pdf", http.StatusNotFound},
+		{"unknown call", "/quotes/" + uuid.New().String() + "/pdf", http.StatusNotFound},
+		{"invalid id", "/quotes/not-a-uuid/pdf", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Fatalf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+			if tt.expected == http.StatusOK {
+				if ct := rr.Header().Get("Content-Type"); ct != quotepdf.ContentType {
+					t.Errorf("expected Content-Type %q, got %q", quotepdf.ContentType, ct)
+				}
+				if !bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF")) {
+					t.Error("expected PDF body")
+				}
+			}
+		})
+	}
+}
+
+func TestParseExportFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+		check   func(t *testing.T, f *domain.CallListFilter)
+	}{
+		{
+			name:  "empty",
+			query: "",
+			check: func(t *testing.T, f *domain.CallListFilter) {
+				if f.HasFilters() {
+					t.Error("expected no filters")
+				}
+			},
+		},
+		{
+			name:  "date range is inclusive of the end date",
+			query: "from=2026-01-01&to=2026-01-31&status=completed&phone_number=555",
+			check: func(t *testing.T, f *domain.CallListFilter) {
+				if f.CreatedAfter == nil || !f.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("unexpected from: %v", f.CreatedAfter)
+				}
+				if f.CreatedBefore == nil || !f.CreatedBefore.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("unexpected to: %v", f.CreatedBefore)
+				}
+				if f.Status == nil || *f.Status != domain.CallStatusCompleted {
+					t.Errorf("unexpected status: %v", f.Status)
+				}
+				if f.PhoneNumber != "555" {
+					t.Errorf("unexpected phone number: %q", f.PhoneNumber)
+				}
+			},
+		},
+		{
+			name:  "timestamps",
+			query: "to=2026-01-31T12:00:00Z",
+			check: func(t *testing.T, f *domain.CallListFilter) {
+				if f.CreatedBefore == nil || !f.CreatedBefore.Equal(time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)) {
+					t.Errorf("unexpected to: %v", f.CreatedBefore)
+				}
+			},
+		},
+		{name: "invalid status", query: "status=bogus", wantErr: true},
+		{name: "invalid date", query: "from=yesterday", wantErr: true},
+		{name: "reversed range", query: "from=2026-02-01&to=2026-01-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			f, err := parseExportFilter(values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExportFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, f)
+			}
+		})
+	}
+}
+
+func TestQuoteAPIHandler_ExportQuotes(t *testing.T) {
+	h := NewQuoteAPIHandler(nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	req := httptest.NewRequest(http.MethodGet, "/quotes/export", http.NoBody)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d without export service, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	quote := "- Build: $1,000"
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	call.QuoteSummary = &quote
+	h.SetExportService(export.NewService(stubExportCalls{call}, 0, zap.NewNop()))
+
+	req = httptest.NewRequest(http.MethodGet, "/quotes/export?format=csv", http.NoBody)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != export.FormatCSV.ContentType() {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	if !strings.Contains(rr.Body.String(), call.ID.String()) {
+		t.Error("expected quote row in export")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/quotes/export?format=pdf", http.NoBody)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d for unknown format, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+type stubExportCalls []*domain.Call
+
+func (s stubExportCalls) List(ctx context.Context, filter *domain.CallListFilter, limit, offset int) ([]*domain.Call, error) {
+	if filter.After != nil {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func TestQuoteAPIHandler_WorkflowNotConfigured(t *testing.T) {
+	h := NewQuoteAPIHandler(nil, zap.NewNop())
+	r := chi.NewRouter()
+	h.RegisterRoutes(r)
+
+	for _, path := range []string{"/quotes/" + uuid.New().String(), "/quotes/" + uuid.New().String() + "/submit", "/quotes/" + uuid.New().String() + "/link", "/quotes/" + uuid.New().String() + "/payment-link", "/quotes/" + uuid.New().String() + "/accounting-sync", "/quotes/" + uuid.New().String() + "/accounting-syncs"} {
+		method := http.MethodGet
+		if strings.HasSuffix(path, "/submit") || strings.HasSuffix(path, "link") || strings.HasSuffix(path, "-sync") {
+			method = http.MethodPost
+		}
+		req := httptest.NewRequest(method, path, http.NoBody)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s %s: expected status %d, got %d", method, path, http.StatusServiceUnavailable, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// RetentionAPIHandler handles the retention policy and legal hold
+// endpoints. All of its endpoints are admin only.
+type RetentionAPIHandler struct {
+	retentionService *service.RetentionService
+	auditLogger      *audit.Logger
+	logger           *zap.Logger
+}
+
+// NewRetentionAPIHandler creates a new RetentionAPIHandler. auditLogger may be nil.
+func NewRetentionAPIHandler(retentionService *service.RetentionService, auditLogger *audit.Logger, logger *zap.Logger) *RetentionAPIHandler {
+	return &RetentionAPIHandler{
+		retentionService: retentionService,
+		auditLogger:      auditLogger,
+		logger:           logger,
+	}
+}
+
+// RetentionRule is how long one kind of record is kept.
+type RetentionRule struct {
+	Entity      domain.RetentionEntity `json:"entity"`
+	KeepForDays int                    `json:"keep_for_days"` // 0 keeps the records forever
+}
+
+// RetentionPolicyResponse describes the retention rules in force and the
+// outcome of their last scheduled run.
+type RetentionPolicyResponse struct {
+	DryRun     bool                     `json:"dry_run"`
+	Rules      []RetentionRule          `json:"rules"`
+	LastReport *service.RetentionReport `json:"last_report,omitempty"`
+}
+
+// LegalHoldListResponse lists the calls and customers on legal hold.
+type LegalHoldListResponse struct {
+	Holds []*domain.LegalHold `json:"holds"`
+}
+
+// PlaceLegalHoldRequest is the body of a request to place a legal hold.
+type PlaceLegalHoldRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// RegisterRoutes registers retention API routes.
+func (h *RetentionAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/retention", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.GetPolicy)
+		r.Post("/preview", h.Preview)
+		r.Get("/legal-holds", h.ListLegalHolds)
+		r.Put("/legal-holds/{target}/{id}", h.PlaceLegalHold)
+		r.Delete("/legal-holds/{target}/{id}", h.ReleaseLegalHold)
+	})
+}
+
+// GetPolicy handles GET /api/v1/retention
+// @Summary Get the retention policy
+// @Description Lists how long transcripts, recordings and audit events are kept, whether the nightly run only reports, and the report of its last run. Admin only.
+// @Tags retention
+// @Produce json
+// @Success 200 {object} RetentionPolicyResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/retention [get]
+func (h *RetentionAPIHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := h.retentionService.Policy()
+	resp := RetentionPolicyResponse{
+		DryRun:     policy.DryRun,
+		LastReport: h.retentionService.LastReport(),
+	}
+	for _, entity := range domain.RetentionEntities {
+		resp.Rules = append(resp.Rules, RetentionRule{
+			Entity:      entity,
+			KeepForDays: int(policy.Age(entity) / (24 * time.Hour)),
+		})
+	}
+	JSON(w, http.StatusOK, resp)
+}
+
+// Preview handles POST /api/v1/retention/preview
+// @Summary Preview the retention rules
+// @Description Counts the transcripts, recordings and audit events each retention rule would remove now, leaving out anything on legal hold. Nothing is removed. Admin only.
+// @Tags retention
+// @Produce json
+// @Success 200 {object} service.RetentionReport
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/retention/preview [post]
+func (h *RetentionAPIHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	report, err := h.retentionService.Preview(r.Context())
+	if err != nil {
+		h.logger.Error("failed to preview retention", zap.Error(err))
+		APIServiceError(w, err, "failed to preview retention")
+		return
+	}
+	JSON(w, http.StatusOK, report)
+}
+
+// ListLegalHolds handles GET /api/v1/retention/legal-holds
+// @Summary List legal holds
+// @Description Lists the calls and customers on legal hold, most recently placed first. Admin only.
+// @Tags retention
+// @Produce json
+// @Success 200 {object} LegalHoldListResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/retention/legal-holds [get]
+func (h *RetentionAPIHandler) ListLegalHolds(w http.ResponseWriter, r *http.Request) {
+	holds, err := h.retentionService.ListLegalHolds(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list legal holds", zap.Error(err))
+		APIServiceError(w, err, "failed to list legal holds")
+		return
+	}
+	JSON(w, http.StatusOK, LegalHoldListResponse{Holds: holds})
+}
+
+// PlaceLegalHold handles PUT /api/v1/retention/legal-holds/{target}/{id}
+// @Summary Place a legal hold
+// @Description Exempts a call, or every call of a customer, from the retention rules until the hold is released. Placing a hold on a record already held updates the reason. Admin only.
+// @Tags retention
+// @Accept json
+// @Param target path string true "call or customer"
+// @Param id path string true "Call or customer ID"
+// @Param request body PlaceLegalHoldRequest true "Why the record is held"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/retention/legal-holds/{target}/{id} [put]
+func (h *RetentionAPIHandler) PlaceLegalHold(w http.ResponseWriter, r *http.Request) {
+	target, id, ok := parseLegalHoldTarget(w, r)
+	if !ok {
+		return
+	}
+	var req PlaceLegalHoldRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.retentionService.PlaceLegalHold(r.Context(), target, id, req.Reason); err != nil {
+		h.respondRetentionError(w, "failed to place legal hold", err)
+		return
+	}
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.LegalHoldPlaced(r.Context(), userID, userName, string(target), id.String(), req.Reason, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReleaseLegalHold handles DELETE /api/v1/retention/legal-holds/{target}/{id}
+// @Summary Release a legal hold
+// @Description Returns a call or customer to the retention rules. Records already past their retention are removed on the next run. Admin only.
+// @Tags retention
+// @Param target path string true "call or customer"
+// @Param id path string true "Call or customer ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/retention/legal-holds/{target}/{id} [delete]
+func (h *RetentionAPIHandler) ReleaseLegalHold(w http.ResponseWriter, r *http.Request) {
+	target, id, ok := parseLegalHoldTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.retentionService.ReleaseLegalHold(r.Context(), target, id); err != nil {
+		h.respondRetentionError(w, "failed to release legal hold", err)
+		return
+	}
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.LegalHoldReleased(r.Context(), userID, userName, string(target), id.String(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseLegalHoldTarget reads the target and ID of a legal hold from the
+// path, responding with 400 when either is invalid.
+func parseLegalHoldTarget(w http.ResponseWriter, r *http.Request) (domain.LegalHoldTarget, uuid.UUID, bool) {
+	target := domain.LegalHoldTarget(chi.URLParam(r, "target"))
+	if target != domain.LegalHoldCall && target != domain.LegalHoldCustomer {
+		APIError(w, http.StatusBadRequest, "legal hold target must be call or customer")
+		return "", uuid.Nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid "+string(target)+" ID")
+		return "", uuid.Nil, false
+	}
+	return target, id, true
+}
+
+func (h *RetentionAPIHandler) respondRetentionError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
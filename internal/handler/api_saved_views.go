@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// SavedViewAPIHandler handles the endpoints for the current user's saved
+// calls list views.
+type SavedViewAPIHandler struct {
+	savedViews *service.SavedViewService
+	logger     *zap.Logger
+}
+
+// NewSavedViewAPIHandler creates a new SavedViewAPIHandler.
+func NewSavedViewAPIHandler(savedViews *service.SavedViewService, logger *zap.Logger) *SavedViewAPIHandler {
+	return &SavedViewAPIHandler{
+		savedViews: savedViews,
+		logger:     logger,
+	}
+}
+
+// SavedViewListResponse lists the current user's saved views.
+type SavedViewListResponse struct {
+	Views []*domain.SavedView `json:"views"`
+}
+
+// SavedViewBody is the body of a request to save or replace a view.
+type SavedViewBody struct {
+	Name      string            `json:"name" validate:"required,max=100"`
+	Filters   map[string]string `json:"filters"`
+	Sort      string            `json:"sort" validate:"oneof=newest oldest"`
+	Columns   []string          `json:"columns" validate:"max=20"`
+	IsDefault bool              `json:"is_default"`
+}
+
+func (b *SavedViewBody) request() *service.SavedViewRequest {
+	return &service.SavedViewRequest{
+		Name:      b.Name,
+		Filters:   b.Filters,
+		Sort:      domain.CallSort(b.Sort),
+		Columns:   b.Columns,
+		IsDefault: b.IsDefault,
+	}
+}
+
+// RegisterRoutes registers saved view API routes.
+func (h *SavedViewAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/saved-views", func(r chi.Router) {
+		r.Use(h.requireUser)
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{viewID}", h.Get)
+		r.Put("/{viewID}", h.Update)
+		r.Delete("/{viewID}", h.Delete)
+		r.Post("/{viewID}/default", h.SetDefault)
+		r.Delete("/{viewID}/default", h.ClearDefault)
+	})
+}
+
+// List handles GET /api/v1/saved-views
+// @Summary List saved views
+// @Description Lists the current user's saved calls list views, ordered by name.
+// @Tags saved-views
+// @Produce json
+// @Success 200 {object} SavedViewListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/saved-views [get]
+func (h *SavedViewAPIHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	views, err := h.savedViews.List(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list saved views", zap.Error(err))
+		APIServiceError(w, err, "failed to list saved views")
+		return
+	}
+	if views == nil {
+		views = []*domain.SavedView{}
+	}
+	JSON(w, http.StatusOK, SavedViewListResponse{Views: views})
+}
+
+// Create handles POST /api/v1/saved-views
+// @Summary Save a view
+// @Description Saves a calls list view: filters keyed by the calls list's query parameters (status, q, intent, sentiment, spam, tag, quote_tag), a sort order and the columns shown (caller, phone, status, intent, duration, cost, quote, date; all when empty). With is_default the calls list opens with it.
+// @Tags saved-views
+// @Accept json
+// @Produce json
+// @Param request body SavedViewBody true "Saved view"
+// @Success 201 {object} domain.SavedView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/saved-views [post]
+func (h *SavedViewAPIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var body SavedViewBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	view, err := h.savedViews.Create(r.Context(), user.ID, body.request())
+	if err != nil {
+		h.respondSavedViewError(w, "failed to save view", err)
+		return
+	}
+	JSON(w, http.StatusCreated, view)
+}
+
+// Get handles GET /api/v1/saved-views/{viewID}
+// @Summary Get a saved view
+// @Description Returns one of the current user's saved views.
+// @Tags saved-views
+// @Produce json
+// @Param viewID path string true "View ID"
+// @Success 200 {object} domain.SavedView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/saved-views/{viewID} [get]
+func (h *SavedViewAPIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	viewID, ok := parseSavedViewID(w, r)
+	if !ok {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	view, err := h.savedViews.Get(r.Context(), user.ID, viewID)
+	if err != nil {
+		h.respondSavedViewError(w, "failed to get saved view", err)
+		return
+	}
+	JSON(w, http.StatusOK, view)
+}
+
+// Update handles PUT /api/v1/saved-views/{viewID}
+// @Summary Replace a saved view
+// @Description Replaces the name, filters, sort and columns of one of the current user's views. is_default is ignored; use the default endpoints to change it.
+// @Tags saved-views
+// @Accept json
+// @Produce json
+// @Param viewID path string true "View ID"
+// @Param request body SavedViewBody true "Saved view"
+// @Success 200 {object} domain.SavedView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/saved-views/{viewID} [put]
+func (h *SavedViewAPIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	viewID, ok := parseSavedViewID(w, r)
+	if !ok {
+		return
+	}
+	var body SavedViewBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	view, err := h.savedViews.Update(r.Context(), user.ID, viewID, body.request())
+	if err != nil {
+		h.respondSavedViewError(w, "failed to update saved view", err)
+		return
+	}
+	JSON(w, http.StatusOK, view)
+}
+
+// Delete handles DELETE /api/v1/saved-views/{viewID}
+// @Summary Delete a saved view
+// @Description Deletes one of the current user's views. Deleting the default view leaves the calls list opening unfiltered.
+// @Tags saved-views
+// @Param viewID path string true "View ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/saved-views/{viewID} [delete]
+func (h *SavedViewAPIHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	viewID, ok := parseSavedViewID(w, r)
+	if !ok {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	if err := h.savedViews.Delete(r.Context(), user.ID, viewID); err != nil {
+		h.respondSavedViewError(w, "failed to delete saved view", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetDefault handles POST /api/v1/saved-views/{viewID}/default
+// @Summary Make a saved view the default
+// @Description Makes one of the current user's views the one the calls list opens with, replacing any other default.
+// @Tags saved-views
+// @Produce json
+// @Param viewID path string true "View ID"
+// @Success 200 {object} domain.SavedView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/saved-views/{viewID}/default [post]
+func (h *SavedViewAPIHandler) SetDefault(w http.ResponseWriter, r *http.Request) {
+	viewID, ok := parseSavedViewID(w, r)
+	if !ok {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	view, err := h.savedViews.SetDefault(r.Context(), user.ID, viewID)
+	if err != nil {
+		h.respondSavedViewError(w, "failed to set default view", err)
+		return
+	}
+	JSON(w, http.StatusOK, view)
+}
+
+// ClearDefault handles DELETE /api/v1/saved-views/{viewID}/default
+// @Summary Stop a saved view being the default
+// @Description Stops one of the current user's views being their default, so the calls list opens unfiltered. Does nothing if the view isn't the default.
+// @Tags saved-views
+// @Produce json
+// @Param viewID path string true "View ID"
+// @Success 200 {object} domain.SavedView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/saved-views/{viewID}/default [delete]
+func (h *SavedViewAPIHandler) ClearDefault(w http.ResponseWriter, r *http.Request) {
+	viewID, ok := parseSavedViewID(w, r)
+	if !ok {
+		return
+	}
+
+	user := GetUserFromContext(r.Context())
+	view, err := h.savedViews.ClearDefault(r.Context(), user.ID, viewID)
+	if err != nil {
+		h.respondSavedViewError(w, "failed to clear default view", err)
+		return
+	}
+	JSON(w, http.StatusOK, view)
+}
+
+func parseSavedViewID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "viewID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid view_id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// requireUser rejects requests without a user, since views belong to one.
+func (h *SavedViewAPIHandler) requireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetUserFromContext(r.Context()) == nil {
+			APIError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *SavedViewAPIHandler) respondSavedViewError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
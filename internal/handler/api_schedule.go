@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ScheduleAPIHandler handles the business hours endpoints. Anyone may read
+// the schedule; only admins may change it.
+type ScheduleAPIHandler struct {
+	scheduleService *service.ScheduleService
+	logger          *zap.Logger
+}
+
+// NewScheduleAPIHandler creates a new ScheduleAPIHandler.
+func NewScheduleAPIHandler(scheduleService *service.ScheduleService, logger *zap.Logger) *ScheduleAPIHandler {
+	return &ScheduleAPIHandler{
+		scheduleService: scheduleService,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers business hours API routes.
+func (h *ScheduleAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/schedule", func(r chi.Router) {
+		r.Get("/", h.GetSchedule)
+		r.With(RequireAPIAdmin).Put("/", h.UpdateSchedule)
+	})
+}
+
+// GetSchedule handles GET /api/v1/schedule
+// @Summary Get the business hours schedule
+// @Description Returns the business hours, holidays, the inbound numbers they switch and their after hours agent, and whether the business is open now.
+// @Tags schedule
+// @Produce json
+// @Success 200 {object} service.BusinessScheduleStatus
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/schedule [get]
+func (h *ScheduleAPIHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	status, err := h.scheduleService.Status(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get business schedule", zap.Error(err))
+		APIServiceError(w, err, "failed to get business schedule")
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
+
+// UpdateSchedule handles PUT /api/v1/schedule
+// @Summary Replace the business hours schedule
+// @Description Replaces the schedule and switches its numbers to the agent for the current time. Hours are keyed by weekday name; holidays are YYYY-MM-DD, or MM-DD for every year. Admin only.
+// @Tags schedule
+// @Accept json
+// @Produce json
+// @Param request body domain.BusinessSchedule true "Schedule"
+// @Success 200 {object} service.BusinessScheduleStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/schedule [put]
+func (h *ScheduleAPIHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule domain.BusinessSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	status, err := h.scheduleService.SaveSchedule(r.Context(), &schedule)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			APIAppError(w, err)
+			return
+		}
+		h.logger.Error("failed to save business schedule", zap.Error(err))
+		APIServiceError(w, err, "failed to save business schedule")
+		return
+	}
+
+	JSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,488 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// TagAPIHandler handles the tag endpoints: managing tags, tagging calls,
+// quotes and customers, and the saved tag views shown on the dashboard.
+// Any user can tag records; managing tags is admin only.
+type TagAPIHandler struct {
+	tagService *service.TagService
+	logger     *zap.Logger
+}
+
+// NewTagAPIHandler creates a new TagAPIHandler.
+func NewTagAPIHandler(tagService *service.TagService, logger *zap.Logger) *TagAPIHandler {
+	return &TagAPIHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// TagListResponse lists tags.
+type TagListResponse struct {
+	Tags []*domain.Tag `json:"tags"`
+}
+
+// TagViewListResponse lists the saved tag views with how many records each
+// matches.
+type TagViewListResponse struct {
+	Views []*service.TagViewCount `json:"views"`
+}
+
+// TagBody is the body of a request to create or update a managed tag. The
+// name is only read when creating.
+type TagBody struct {
+	Name        string `json:"name,omitempty" validate:"max=50"`
+	Description string `json:"description" validate:"max=200"`
+	Color       string `json:"color" validate:"max=7"`
+}
+
+// AddTagBody is the body of a request to tag a record.
+type AddTagBody struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// TagViewBody is the body of a request to save a tag view.
+type TagViewBody struct {
+	Name       string   `json:"name" validate:"required,max=100"`
+	EntityType string   `json:"entity_type" validate:"required,oneof=call quote customer"`
+	Tags       []string `json:"tags" validate:"required,min=1,max=10"`
+	Shared     bool     `json:"shared"`
+}
+
+// RegisterRoutes registers tag API routes.
+func (h *TagAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/tags", func(r chi.Router) {
+		r.Get("/", h.ListTags)
+
+		r.Group(func(r chi.Router) {
+			r.Use(RequireAPIAdmin)
+			r.Post("/", h.CreateTag)
+			r.Put("/{name}", h.UpdateTag)
+			r.Delete("/{name}", h.DeleteTag)
+		})
+	})
+
+	r.Route("/tag-views", func(r chi.Router) {
+		r.Get("/", h.ListTagViews)
+		r.Post("/", h.CreateTagView)
+		r.Delete("/{viewID}", h.DeleteTagView)
+	})
+
+	// These sit beside the /calls, /quotes and /customers routes of the
+	// other handlers
+	r.Get("/calls/{callID}/tags", h.ListCallTags)
+	r.Post("/calls/{callID}/tags", h.AddCallTag)
+	r.Delete("/calls/{callID}/tags/{name}", h.RemoveCallTag)
+	r.Get("/quotes/{quoteID}/tags", h.ListQuoteTags)
+	r.Post("/quotes/{quoteID}/tags", h.AddQuoteTag)
+	r.Delete("/quotes/{quoteID}/tags/{name}", h.RemoveQuoteTag)
+	r.Get("/customers/{customerID}/tags", h.ListCustomerTags)
+	r.Post("/customers/{customerID}/tags", h.AddCustomerTag)
+	r.Delete("/customers/{customerID}/tags/{name}", h.RemoveCustomerTag)
+}
+
+// ListTags handles GET /api/v1/tags
+// @Summary List tags
+// @Description Lists every tag, managed and free-form, with how many records carry it, ordered by name.
+// @Tags tags
+// @Produce json
+// @Success 200 {object} TagListResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tags [get]
+func (h *TagAPIHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagService.ListTags(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list tags", zap.Error(err))
+		APIServiceError(w, err, "failed to list tags")
+		return
+	}
+	if tags == nil {
+		tags = []*domain.Tag{}
+	}
+	JSON(w, http.StatusOK, TagListResponse{Tags: tags})
+}
+
+// CreateTag handles POST /api/v1/tags
+// @Summary Create a managed tag
+// @Description Creates a managed tag with a description and color. Names are lowercased and their words joined with dashes, so "Hot Lead" becomes "hot-lead". Admin only.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body TagBody true "Tag"
+// @Success 201 {object} domain.Tag
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/tags [post]
+func (h *TagAPIHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var body TagBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	tag, err := h.tagService.CreateTag(r.Context(), userActor(r), body.request())
+	if err != nil {
+		h.respondTagError(w, "failed to create tag", err)
+		return
+	}
+	JSON(w, http.StatusCreated, tag)
+}
+
+// UpdateTag handles PUT /api/v1/tags/{name}
+// @Summary Update a tag
+// @Description Sets a tag's description and color. A free-form tag becomes managed. Admin only.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param name path string true "Tag name"
+// @Param request body TagBody true "Tag"
+// @Success 200 {object} domain.Tag
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/tags/{name} [put]
+func (h *TagAPIHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	var body TagBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	tag, err := h.tagService.UpdateTag(r.Context(), userActor(r), chi.URLParam(r, "name"), body.request())
+	if err != nil {
+		h.respondTagError(w, "failed to update tag", err)
+		return
+	}
+	JSON(w, http.StatusOK, tag)
+}
+
+// DeleteTag handles DELETE /api/v1/tags/{name}
+// @Summary Delete a tag
+// @Description Deletes a tag and takes it off every record. Saved views naming it match nothing until it is applied again. Admin only.
+// @Tags tags
+// @Param name path string true "Tag name"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/tags/{name} [delete]
+func (h *TagAPIHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	if err := h.tagService.DeleteTag(r.Context(), userActor(r), chi.URLParam(r, "name")); err != nil {
+		h.respondTagError(w, "failed to delete tag", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTagViews handles GET /api/v1/tag-views
+// @Summary List saved tag views
+// @Description Lists the shared tag views and the current user's own, with how many records each matches. A view matches the records carrying all of its tags.
+// @Tags tags
+// @Produce json
+// @Success 200 {object} TagViewListResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/tag-views [get]
+func (h *TagAPIHandler) ListTagViews(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		APIError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	views, err := h.tagService.ListViews(r.Context(), user.ID)
+	if err != nil {
+		h.logger.Error("failed to list tag views", zap.Error(err))
+		APIServiceError(w, err, "failed to list tag views")
+		return
+	}
+	JSON(w, http.StatusOK, TagViewListResponse{Views: views})
+}
+
+// CreateTagView handles POST /api/v1/tag-views
+// @Summary Save a tag view
+// @Description Saves a view of the calls, quotes or customers carrying all of the given tags, shown on the dashboard. Views are private to their owner unless shared; only admins can share views.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param request body TagViewBody true "Tag view"
+// @Success 201 {object} domain.TagView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/tag-views [post]
+func (h *TagAPIHandler) CreateTagView(w http.ResponseWriter, r *http.Request) {
+	var body TagViewBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	view, err := h.tagService.CreateView(r.Context(), userActor(r), &service.TagViewRequest{
+		Name:       body.Name,
+		EntityType: domain.TagEntityType(body.EntityType),
+		Tags:       body.Tags,
+		Shared:     body.Shared,
+	})
+	if err != nil {
+		h.respondTagError(w, "failed to save tag view", err)
+		return
+	}
+	JSON(w, http.StatusCreated, view)
+}
+
+// DeleteTagView handles DELETE /api/v1/tag-views/{viewID}
+// @Summary Delete a saved tag view
+// @Description Deletes one of the current user's views. Shared views can only be deleted by admins.
+// @Tags tags
+// @Param viewID path string true "View ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/tag-views/{viewID} [delete]
+func (h *TagAPIHandler) DeleteTagView(w http.ResponseWriter, r *http.Request) {
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid view_id")
+		return
+	}
+
+	if err := h.tagService.DeleteView(r.Context(), userActor(r), viewID); err != nil {
+		h.respondTagError(w, "failed to delete tag view", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListCallTags handles GET /api/v1/calls/{callID}/tags
+// @Summary List a call's tags
+// @Description Lists the tags on a call, ordered by name.
+// @Tags tags
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/tags [get]
+func (h *TagAPIHandler) ListCallTags(w http.ResponseWriter, r *http.Request) {
+	h.listTags(w, r, domain.TagEntityCall, "callID")
+}
+
+// AddCallTag handles POST /api/v1/calls/{callID}/tags
+// @Summary Tag a call
+// @Description Applies a tag to a call and returns the call's tags. A name that isn't a tag yet creates a free-form tag, unless free-form tags are turned off.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Param request body AddTagBody true "Tag"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/calls/{callID}/tags [post]
+func (h *TagAPIHandler) AddCallTag(w http.ResponseWriter, r *http.Request) {
+	h.addTag(w, r, domain.TagEntityCall, "callID")
+}
+
+// RemoveCallTag handles DELETE /api/v1/calls/{callID}/tags/{name}
+// @Summary Untag a call
+// @Description Takes a tag off a call and returns the call's tags.
+// @Tags tags
+// @Produce json
+// @Param callID path string true "Call ID"
+// @Param name path string true "Tag name"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/calls/{callID}/tags/{name} [delete]
+func (h *TagAPIHandler) RemoveCallTag(w http.ResponseWriter, r *http.Request) {
+	h.removeTag(w, r, domain.TagEntityCall, "callID")
+}
+
+// ListQuoteTags handles GET /api/v1/quotes/{quoteID}/tags
+// @Summary List a quote's tags
+// @Description Lists the tags on a quote, ordered by name. Quotes are tagged separately from their calls.
+// @Tags tags
+// @Produce json
+// @Param quoteID path string true "Quote ID (the call ID)"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/tags [get]
+func (h *TagAPIHandler) ListQuoteTags(w http.ResponseWriter, r *http.Request) {
+	h.listTags(w, r, domain.TagEntityQuote, "quoteID")
+}
+
+// AddQuoteTag handles POST /api/v1/quotes/{quoteID}/tags
+// @Summary Tag a quote
+// @Description Applies a tag to a quote and returns the quote's tags. A name that isn't a tag yet creates a free-form tag, unless free-form tags are turned off.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param quoteID path string true "Quote ID (the call ID)"
+// @Param request body AddTagBody true "Tag"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/quotes/{quoteID}/tags [post]
+func (h *TagAPIHandler) AddQuoteTag(w http.ResponseWriter, r *http.Request) {
+	h.addTag(w, r, domain.TagEntityQuote, "quoteID")
+}
+
+// RemoveQuoteTag handles DELETE /api/v1/quotes/{quoteID}/tags/{name}
+// @Summary Untag a quote
+// @Description Takes a tag off a quote and returns the quote's tags.
+// @Tags tags
+// @Produce json
+// @Param quoteID path string true "Quote ID (the call ID)"
+// @Param name path string true "Tag name"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/quotes/{quoteID}/tags/{name} [delete]
+func (h *TagAPIHandler) RemoveQuoteTag(w http.ResponseWriter, r *http.Request) {
+	h.removeTag(w, r, domain.TagEntityQuote, "quoteID")
+}
+
+// ListCustomerTags handles GET /api/v1/customers/{customerID}/tags
+// @Summary List a customer's tags
+// @Description Lists the tags on a customer, ordered by name.
+// @Tags tags
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID}/tags [get]
+func (h *TagAPIHandler) ListCustomerTags(w http.ResponseWriter, r *http.Request) {
+	h.listTags(w, r, domain.TagEntityCustomer, "customerID")
+}
+
+// AddCustomerTag handles POST /api/v1/customers/{customerID}/tags
+// @Summary Tag a customer
+// @Description Applies a tag to a customer and returns the customer's tags. A name that isn't a tag yet creates a free-form tag, unless free-form tags are turned off.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Param request body AddTagBody true "Tag"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ValidationErrorResponse "Invalid fields"
+// @Router /api/v1/customers/{customerID}/tags [post]
+func (h *TagAPIHandler) AddCustomerTag(w http.ResponseWriter, r *http.Request) {
+	h.addTag(w, r, domain.TagEntityCustomer, "customerID")
+}
+
+// RemoveCustomerTag handles DELETE /api/v1/customers/{customerID}/tags/{name}
+// @Summary Untag a customer
+// @Description Takes a tag off a customer and returns the customer's tags.
+// @Tags tags
+// @Produce json
+// @Param customerID path string true "Customer ID"
+// @Param name path string true "Tag name"
+// @Success 200 {object} TagListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/customers/{customerID}/tags/{name} [delete]
+func (h *TagAPIHandler) RemoveCustomerTag(w http.ResponseWriter, r *http.Request) {
+	h.removeTag(w, r, domain.TagEntityCustomer, "customerID")
+}
+
+func (h *TagAPIHandler) listTags(w http.ResponseWriter, r *http.Request, entityType domain.TagEntityType, param string) {
+	entityID, ok := parseTagEntityID(w, r, entityType, param)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.TagsFor(r.Context(), entityType, entityID)
+	if err != nil {
+		h.respondTagError(w, "failed to list tags", err)
+		return
+	}
+	h.respondTags(w, tags)
+}
+
+func (h *TagAPIHandler) addTag(w http.ResponseWriter, r *http.Request, entityType domain.TagEntityType, param string) {
+	entityID, ok := parseTagEntityID(w, r, entityType, param)
+	if !ok {
+		return
+	}
+	var body AddTagBody
+	if !decodeAndValidate(w, r, &body) {
+		return
+	}
+
+	tags, err := h.tagService.AddTag(r.Context(), userActor(r), entityType, entityID, body.Name)
+	if err != nil {
+		h.respondTagError(w, "failed to add tag", err)
+		return
+	}
+	h.respondTags(w, tags)
+}
+
+func (h *TagAPIHandler) removeTag(w http.ResponseWriter, r *http.Request, entityType domain.TagEntityType, param string) {
+	entityID, ok := parseTagEntityID(w, r, entityType, param)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.RemoveTag(r.Context(), entityType, entityID, chi.URLParam(r, "name"))
+	if err != nil {
+		h.respondTagError(w, "failed to remove tag", err)
+		return
+	}
+	h.respondTags(w, tags)
+}
+
+func (h *TagAPIHandler) respondTags(w http.ResponseWriter, tags []*domain.Tag) {
+	if tags == nil {
+		tags = []*domain.Tag{}
+	}
+	JSON(w, http.StatusOK, TagListResponse{Tags: tags})
+}
+
+// parseTagEntityID parses the record ID from the URL, responding with a bad
+// request if it is invalid.
+func parseTagEntityID(w http.ResponseWriter, r *http.Request, entityType domain.TagEntityType, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid "+string(entityType)+"_id")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (b *TagBody) request() *service.TagRequest {
+	return &service.TagRequest{
+		Name:        b.Name,
+		Description: b.Description,
+		Color:       b.Color,
+	}
+}
+
+func (h *TagAPIHandler) respondTagError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// UserAPIHandler handles user management API endpoints. Only admins may
+// use them; the service enforces this and audit logs every change.
+type UserAPIHandler struct {
+	userService *service.UserService
+	logger      *zap.Logger
+}
+
+// NewUserAPIHandler creates a new UserAPIHandler.
+func NewUserAPIHandler(userService *service.UserService, logger *zap.Logger) *UserAPIHandler {
+	return &UserAPIHandler{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers user API routes.
+func (h *UserAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/users", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.ListUsers)
+		r.Post("/", h.InviteUser)
+		r.Get("/{userID}", h.GetUser)
+		r.Patch("/{userID}", h.UpdateUser)
+		r.Delete("/{userID}", h.DeleteUser)
+		r.Post("/{userID}/disable", h.DisableUser)
+		r.Post("/{userID}/enable", h.EnableUser)
+		r.Post("/{userID}/invitation", h.ResendInvitation)
+	})
+}
+
+// InviteUserRequest is the request body for inviting a user.
+type InviteUserRequest struct {
+	Email string          `json:"email"`
+	Role  domain.UserRole `json:"role,omitempty"`
+}
+
+// UpdateUserRequest is the request body for changing a user's role.
+type UpdateUserRequest struct {
+	Role domain.UserRole `json:"role"`
+}
+
+// InvitationResponse is returned when an invitation is issued. The link is
+// also emailed to the user when email is configured.
+type InvitationResponse struct {
+	User      *domain.User `json:"user"`
+	InviteURL string       `json:"invite_url"`
+}
+
+// ListUsers handles GET /api/v1/users
+// @Summary List users
+// @Description Lists dashboard users. Admin only.
+// @Tags users
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/users [get]
+func (h *UserAPIHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.userService.List(r.Context(), userActor(r))
+	if err != nil {
+		h.respondUserError(w, "failed to list users", err)
+		return
+	}
+	if users == nil {
+		users = []*domain.User{}
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"users": users,
+	})
+}
+
+// InviteUser handles POST /api/v1/users
+// @Summary Invite a user
+// @Description Creates a user and issues a link for choosing their password. Admin only.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body InviteUserRequest true "User to invite"
+// @Success 201 {object} InvitationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/users [post]
+func (h *UserAPIHandler) InviteUser(w http.ResponseWriter, r *http.Request) {
+	var req InviteUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, inviteURL, err := h.userService.Invite(r.Context(), userActor(r), service.InviteUserRequest{
+		Email: req.Email,
+		Role:  req.Role,
+	})
+	if err != nil {
+		h.respondUserError(w, "failed to invite user", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, InvitationResponse{User: user, InviteURL: inviteURL})
+}
+
+// GetUser handles GET /api/v1/users/{userID}
+// @Summary Get a user
+// @Tags users
+// @Produce json
+// @Param userID path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{userID} [get]
+func (h *UserAPIHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.Get(r.Context(), userActor(r), userID)
+	if err != nil {
+		h.respondUserError(w, "failed to get user", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, user)
+}
+
+// UpdateUser handles PATCH /api/v1/users/{userID}
+// @Summary Change a user's role
+// @Description Admins can't change their own role or demote the last admin.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param userID path string true "User ID"
+// @Param request body UpdateUserRequest true "New role"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/users/{userID} [patch]
+func (h *UserAPIHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, err := h.userService.SetRole(r.Context(), userActor(r), userID, req.Role)
+	if err != nil {
+		h.respondUserError(w, "failed to update user", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, user)
+}
+
+// DisableUser handles POST /api/v1/users/{userID}/disable
+// @Summary Disable a user
+// @Description Blocks login, signs out the user's sessions and stops their API keys working.
+// @Tags users
+// @Produce json
+// @Param userID path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/users/{userID}/disable [post]
+func (h *UserAPIHandler) DisableUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.Disable(r.Context(), userActor(r), userID)
+	if err != nil {
+		h.respondUserError(w, "failed to disable user", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, user)
+}
+
+// EnableUser handles POST /api/v1/users/{userID}/enable
+// @Summary Enable a disabled user
+// @Tags users
+// @Produce json
+// @Param userID path string true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/users/{userID}/enable [post]
+func (h *UserAPIHandler) EnableUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.Enable(r.Context(), userActor(r), userID)
+	if err != nil {
+		h.respondUserError(w, "failed to enable user", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, user)
+}
+
+// ResendInvitation handles POST /api/v1/users/{userID}/invitation
+// @Summary Resend an invitation
+// @Description Issues a new invitation link for a user who hasn't accepted theirs. Earlier links stop working.
+// @Tags users
+// @Produce json
+// @Param userID path string true "User ID"
+// @Success 200 {object} InvitationResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/users/{userID}/invitation [post]
+func (h *UserAPIHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, inviteURL, err := h.userService.ResendInvitation(r.Context(), userActor(r), userID)
+	if err != nil {
+		h.respondUserError(w, "failed to resend invitation", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, InvitationResponse{User: user, InviteURL: inviteURL})
+}
+
+// DeleteUser handles DELETE /api/v1/users/{userID}
+// @Summary Delete a user
+// @Description Deletes a user and signs out their sessions. Their address can be invited again.
+// @Tags users
+// @Param userID path string true "User ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/users/{userID} [delete]
+func (h *UserAPIHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.userService.Delete(r.Context(), userActor(r), userID); err != nil {
+		h.respondUserError(w, "failed to delete user", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "user deleted",
+	})
+}
+
+func (h *UserAPIHandler) respondUserError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
+
+// parseUserID parses the userID path parameter, writing a 400 response if
+// it is invalid.
+func parseUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid user_id")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// userActor identifies the authenticated user for user management.
+func userActor(r *http.Request) service.UserActor {
+	return service.UserActor{
+		User:      GetUserFromContext(r.Context()),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+}
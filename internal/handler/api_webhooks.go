@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// WebhookAPIHandler handles outgoing webhook subscription endpoints. Only
+// admins may use them, since subscribers receive call and quote details.
+type WebhookAPIHandler struct {
+	webhookService *service.OutgoingWebhookService
+	logger         *zap.Logger
+}
+
+// NewWebhookAPIHandler creates a new WebhookAPIHandler.
+func NewWebhookAPIHandler(webhookService *service.OutgoingWebhookService, logger *zap.Logger) *WebhookAPIHandler {
+	return &WebhookAPIHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers webhook subscription API routes.
+func (h *WebhookAPIHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Use(RequireAPIAdmin)
+		r.Get("/", h.ListWebhooks)
+		r.Post("/", h.CreateWebhook)
+		r.Get("/{webhookID}", h.GetWebhook)
+		r.Patch("/{webhookID}", h.UpdateWebhook)
+		r.Delete("/{webhookID}", h.DeleteWebhook)
+		r.Post("/{webhookID}/rotate-secret", h.RotateSecret)
+		r.Get("/{webhookID}/deliveries", h.ListDeliveries)
+		r.Post("/{webhookID}/deliveries/{deliveryID}/retry", h.RetryDelivery)
+	})
+}
+
+// WebhookSecretResponse is a subscription with its signing secret. It is
+// returned only when the subscription is created or its secret is rotated.
+type WebhookSecretResponse struct {
+	*domain.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// ListWebhooks handles GET /api/v1/webhooks
+// @Summary List webhook subscriptions
+// @Description Lists outgoing webhook subscriptions, oldest first. Admin only.
+// @Tags webhooks
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/webhooks [get]
+func (h *WebhookAPIHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		h.respondWebhookError(w, "failed to list webhooks", err)
+		return
+	}
+	if subscriptions == nil {
+		subscriptions = []*domain.WebhookSubscription{}
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"webhooks": subscriptions,
+		"events":   domain.WebhookEventTypes,
+	})
+}
+
+// CreateWebhook handles POST /api/v1/webhooks
+// @Summary Create a webhook subscription
+// @Description Subscribes a URL to call.completed, quote.created and/or quote.approved events. The signing secret is returned only in this response. Admin only.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body service.CreateWebhookRequest true "Subscription details"
+// @Success 201 {object} WebhookSecretResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/webhooks [post]
+func (h *WebhookAPIHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req service.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if user := GetUserFromContext(r.Context()); user != nil {
+		req.CreatedBy = &user.ID
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(r.Context(), &req)
+	if err != nil {
+		h.respondWebhookError(w, "failed to create webhook", err)
+		return
+	}
+
+	JSON(w, http.StatusCreated, WebhookSecretResponse{
+		WebhookSubscription: subscription,
+		Secret:              subscription.Secret,
+	})
+}
+
+// GetWebhook handles GET /api/v1/webhooks/{webhookID}
+// @Summary Get a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Param webhookID path string true "Subscription ID"
+// @Success 200 {object} domain.WebhookSubscription
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID} [get]
+func (h *WebhookAPIHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(r.Context(), webhookID)
+	if err != nil {
+		h.respondWebhookError(w, "failed to get webhook", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, subscription)
+}
+
+// UpdateWebhook handles PATCH /api/v1/webhooks/{webhookID}
+// @Summary Update a webhook subscription
+// @Description Changes the name, URL, events or active flag of a subscription. Omitted fields are unchanged. Admin only.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhookID path string true "Subscription ID"
+// @Param request body service.UpdateWebhookRequest true "Changes"
+// @Success 200 {object} domain.WebhookSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID} [patch]
+func (h *WebhookAPIHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var req service.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(r.Context(), webhookID, &req)
+	if err != nil {
+		h.respondWebhookError(w, "failed to update webhook", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, subscription)
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/{webhookID}
+// @Summary Delete a webhook subscription
+// @Description Deletes a subscription and its delivery log. Admin only.
+// @Tags webhooks
+// @Param webhookID path string true "Subscription ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID} [delete]
+func (h *WebhookAPIHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), webhookID); err != nil {
+		h.respondWebhookError(w, "failed to delete webhook", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]string{
+		"status":  "success",
+		"message": "webhook deleted",
+	})
+}
+
+// RotateSecret handles POST /api/v1/webhooks/{webhookID}/rotate-secret
+// @Summary Rotate a webhook signing secret
+// @Description Replaces the signing secret. Deliveries are signed with the new secret from the next attempt on. The new secret is returned only in this response. Admin only.
+// @Tags webhooks
+// @Produce json
+// @Param webhookID path string true "Subscription ID"
+// @Success 200 {object} WebhookSecretResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID}/rotate-secret [post]
+func (h *WebhookAPIHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.RotateSecret(r.Context(), webhookID)
+	if err != nil {
+		h.respondWebhookError(w, "failed to rotate webhook secret", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, WebhookSecretResponse{
+		WebhookSubscription: subscription,
+		Secret:              subscription.Secret,
+	})
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/{webhookID}/deliveries
+// @Summary List webhook deliveries
+// @Description Returns the delivery log of a subscription, newest first, with the payload, attempts, last response status and error. Admin only.
+// @Tags webhooks
+// @Produce json
+// @Param webhookID path string true "Subscription ID"
+// @Param limit query int false "Maximum deliveries to return (default 50, max 100)"
+// @Param offset query int false "Deliveries to skip"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID}/deliveries [get]
+func (h *WebhookAPIHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), webhookID, limit, offset)
+	if err != nil {
+		h.respondWebhookError(w, "failed to list webhook deliveries", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+	})
+}
+
+// RetryDelivery handles POST /api/v1/webhooks/{webhookID}/deliveries/{deliveryID}/retry
+// @Summary Retry a webhook delivery
+// @Description Sends a delivered or failed delivery again with a fresh set of attempts. Admin only.
+// @Tags webhooks
+// @Produce json
+// @Param webhookID path string true "Subscription ID"
+// @Param deliveryID path string true "Delivery ID"
+// @Success 200 {object} domain.WebhookDelivery
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/webhooks/{webhookID}/deliveries/{deliveryID}/retry [post]
+func (h *WebhookAPIHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid delivery_id")
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		h.respondWebhookError(w, "failed to retry webhook delivery", err)
+		return
+	}
+
+	JSON(w, http.StatusOK, delivery)
+}
+
+func (h *WebhookAPIHandler) webhookID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhookID"))
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "invalid webhook_id")
+		return uuid.Nil, false
+	}
+	return webhookID, true
+}
+
+func (h *WebhookAPIHandler) respondWebhookError(w http.ResponseWriter, msg string, err error) {
+	if apperrors.IsNotFound(err) {
+		APIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if apperrors.IsUserError(err) {
+		APIAppError(w, err)
+		return
+	}
+	h.logger.Error(msg, zap.Error(err))
+	APIServiceError(w, err, msg)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// archivedCallsPageSize is the number of archived calls per page.
+const archivedCallsPageSize = 50
+
+// ArchivedCallsHandler serves the admin page listing archived calls, where
+// they can be returned to the calls list.
+type ArchivedCallsHandler struct {
+	*BaseHandler
+	callService *service.CallService
+	archive     *service.CallArchiveService
+	auditLogger *audit.Logger
+}
+
+// ArchivedCallsHandlerConfig holds configuration for ArchivedCallsHandler.
+type ArchivedCallsHandlerConfig struct {
+	Base        BaseHandlerConfig
+	CallService *service.CallService
+	Archive     *service.CallArchiveService
+	AuditLogger *audit.Logger // Optional
+}
+
+// NewArchivedCallsHandler creates a new ArchivedCallsHandler with all required dependencies.
+func NewArchivedCallsHandler(cfg ArchivedCallsHandlerConfig) *ArchivedCallsHandler {
+	if cfg.CallService == nil {
+		panic("callService is required")
+	}
+	if cfg.Archive == nil {
+		panic("archive is required")
+	}
+	return &ArchivedCallsHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		callService: cfg.CallService,
+		archive:     cfg.Archive,
+		auditLogger: cfg.AuditLogger,
+	}
+}
+
+// RegisterRoutes registers archived call routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *ArchivedCallsHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/archived", h.HandleArchivedPage)
+	r.Post("/admin/archived/{id}/unarchive", h.HandleUnarchive)
+}
+
+// HandleArchivedPage lists archived calls, most recently created first.
+func (h *ArchivedCallsHandler) HandleArchivedPage(w http.ResponseWriter, r *http.Request) {
+	var successMsg string
+	if r.URL.Query().Get("done") == "unarchived" {
+		successMsg = "Call unarchived. It is back in the calls list."
+	}
+	h.renderArchivedPage(w, r, successMsg, "")
+}
+
+// HandleUnarchive handles POST to return an archived call and its quote to
+// everyday lists.
+func (h *ArchivedCallsHandler) HandleUnarchive(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid call ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.archive.Unarchive(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Call not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to unarchive call", zap.Error(err), zap.String("call_id", id.String()))
+		h.renderArchivedPage(w, r, "", "Failed to unarchive call.")
+		return
+	}
+
+	if h.auditLogger != nil {
+		h.auditLogger.CallUnarchived(r.Context(), user.ID.String(), user.Email, id.String(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	http.Redirect(w, r, "/admin/archived?done=unarchived", http.StatusSeeOther)
+}
+
+func (h *ArchivedCallsHandler) renderArchivedPage(w http.ResponseWriter, r *http.Request, successMsg, errMsg string) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 1 {
+		page = p
+	}
+
+	calls, total, err := h.callService.ListCalls(r.Context(), page, archivedCallsPageSize, &domain.CallListFilter{Archived: true})
+	if err != nil {
+		h.logger.Error("failed to list archived calls", zap.Error(err))
+		errMsg = "Failed to load archived calls"
+	}
+
+	h.RenderTemplate(w, r, "archived_calls", map[string]interface{}{
+		"Title":      "Archived Calls",
+		"ActiveNav":  "calls",
+		"User":       user,
+		"Calls":      calls,
+		"Total":      total,
+		"Page":       page,
+		"TotalPages": (total + archivedCallsPageSize - 1) / archivedCallsPageSize,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+)
+
+// auditPageSize is the number of audit events shown per page.
+const auditPageSize = 50
+
+// AuditHandler serves the audit log admin page.
+type AuditHandler struct {
+	*BaseHandler
+	auditLogger *audit.Logger
+}
+
+// AuditHandlerConfig holds configuration for AuditHandler.
+type AuditHandlerConfig struct {
+	Base        BaseHandlerConfig
+	AuditLogger *audit.Logger
+}
+
+// NewAuditHandler creates a new AuditHandler with all required dependencies.
+func NewAuditHandler(cfg AuditHandlerConfig) *AuditHandler {
+	if cfg.AuditLogger == nil {
+		panic("auditLogger is required")
+	}
+	return &AuditHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		auditLogger: cfg.AuditLogger,
+	}
+}
+
+// auditEventView is an audit event with its change rendered for display.
+type auditEventView struct {
+	*audit.Event
+	BeforeJSON string
+	AfterJSON  string
+}
+
+// RegisterRoutes registers audit log routes on the router.
+// Note: These routes require authentication and admin middleware to be
+// applied by the caller.
+func (h *AuditHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/audit", h.HandleAuditPage)
+}
+
+// HandleAuditPage lists audit events, newest first, with filters.
+func (h *AuditHandler) HandleAuditPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	query := r.URL.Query()
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 1 {
+		page = p
+	}
+
+	var errMsg string
+	var views []auditEventView
+	var total int
+	filter, err := parseAuditFilter(query)
+	if err != nil {
+		errMsg = "Invalid filter: " + err.Error()
+	} else {
+		events, n, err := h.auditLogger.Search(r.Context(), filter, auditPageSize, (page-1)*auditPageSize)
+		if err != nil {
+			h.logger.Error("failed to list audit events", zap.Error(err))
+			errMsg = "Failed to load the audit log"
+		}
+		total = n
+		for _, event := range events {
+			views = append(views, auditEventView{
+				Event:      event,
+				BeforeJSON: auditJSON(event.Before),
+				AfterJSON:  auditJSON(event.After),
+			})
+		}
+	}
+
+	totalPages := (total + auditPageSize - 1) / auditPageSize
+	var prevURL, nextURL string
+	if page > 1 {
+		prevURL = auditPageURL(query, page-1)
+	}
+	if page < totalPages {
+		nextURL = auditPageURL(query, page+1)
+	}
+
+	h.RenderTemplate(w, r, "audit", map[string]interface{}{
+		"Title":      "Audit Log",
+		"ActiveNav":  "audit",
+		"User":       user,
+		"Events":     views,
+		"Total":      total,
+		"Filter":     query,
+		"Filtered":   len(query) > 0 && !(len(query) == 1 && query.Has("page")),
+		"Page":       page,
+		"TotalPages": totalPages,
+		"PrevURL":    prevURL,
+		"NextURL":    nextURL,
+		"Error":      errMsg,
+	})
+}
+
+// auditPageURL returns the audit page URL for page, keeping the filters.
+func auditPageURL(query url.Values, page int) string {
+	q := url.Values{}
+	for key, values := range query {
+		if key != "page" && len(values) > 0 && values[0] != "" {
+			q.Set(key, values[0])
+		}
+	}
+	q.Set("page", strconv.Itoa(page))
+	return "/admin/audit?" + q.Encode()
+}
+
+// auditJSON renders a before or after state for display.
+func auditJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
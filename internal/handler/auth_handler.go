@@ -2,27 +2,47 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/metrics"
 	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/oidc"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
 	"github.com/jkindrix/quickquote/internal/service"
 )
 
+const (
+	// ssoCookie holds a single sign-on attempt between the redirect to the
+	// identity provider and its callback.
+	ssoCookie = "sso_login"
+
+	// ssoCookieMaxAge is how long a user has to sign in at the provider.
+	ssoCookieMaxAge = 600
+)
+
 // AuthHandler handles authentication-related HTTP requests.
 type AuthHandler struct {
 	*BaseHandler
 	authService      *service.AuthService
 	loginRateLimiter *middleware.LoginRateLimiter
+	apiKeyService    *service.APIKeyService
+	apiKeyLimiter    *ratelimit.KeyRateLimiter
+	requireTwoFactor bool
+	sso              *oidc.Provider
+	ssoName          string
 	metrics          *metrics.Metrics
 }
 
@@ -31,6 +51,11 @@ type AuthHandlerConfig struct {
 	Base             BaseHandlerConfig
 	AuthService      *service.AuthService
 	LoginRateLimiter *middleware.LoginRateLimiter
+	APIKeyService    *service.APIKeyService    // Optional: enables bearer API key auth
+	APIKeyLimiter    *ratelimit.KeyRateLimiter // Optional: enforces per-key rate limits
+	RequireTwoFactor bool                      // Send users without two-factor authentication to set it up before admin pages and the admin API
+	SSO              *oidc.Provider            // Optional: enables single sign-on with an OIDC identity provider
+	SSOName          string                    // Identity provider name on the sign-in button
 	Metrics          *metrics.Metrics
 }
 
@@ -43,6 +68,11 @@ func NewAuthHandler(cfg AuthHandlerConfig) *AuthHandler {
 		BaseHandler:      NewBaseHandler(cfg.Base),
 		authService:      cfg.AuthService,
 		loginRateLimiter: cfg.LoginRateLimiter,
+		apiKeyService:    cfg.APIKeyService,
+		apiKeyLimiter:    cfg.APIKeyLimiter,
+		requireTwoFactor: cfg.RequireTwoFactor,
+		sso:              cfg.SSO,
+		ssoName:          cfg.SSOName,
 		metrics:          cfg.Metrics,
 	}
 }
@@ -52,6 +82,17 @@ func (h *AuthHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/", h.HandleIndex)
 	r.Get("/login", h.HandleLoginPage)
 	r.With(middleware.BodySizeLimiterForm()).Post("/login", h.HandleLogin)
+	r.Get("/auth/sso", h.HandleSSOStart)
+	r.Get("/auth/sso/callback", h.HandleSSOCallback)
+	r.Get("/login/2fa", h.HandleTwoFactorPage)
+	r.With(middleware.BodySizeLimiterForm()).Post("/login/2fa", h.HandleTwoFactor)
+	r.Get("/auth/forgot-password", h.HandleForgotPasswordPage)
+	r.With(middleware.BodySizeLimiterForm()).Post("/auth/forgot-password", h.HandleForgotPassword)
+	r.Get("/auth/reset-password", h.HandleResetPasswordPage)
+	r.With(middleware.BodySizeLimiterForm()).Post("/auth/reset-password", h.HandleResetPassword)
+	r.Get("/auth/verify-email", h.HandleVerifyEmail)
+	r.Get("/auth/accept-invite", h.HandleAcceptInvitePage)
+	r.With(middleware.BodySizeLimiterForm()).Post("/auth/accept-invite", h.HandleAcceptInvite)
 	r.Get("/logout", h.HandleLogout)
 }
 
@@ -82,10 +123,16 @@ func (h *AuthHandler) Middleware(next http.Handler) http.Handler {
 
 		// If token was rotated, set the new cookie
 		if result.NewToken != "" {
-			h.setSessionCookie(w, r, result.NewToken, 86400*7)
+			setSessionCookie(w, r, result.NewToken, 86400*7)
 			h.logger.Debug("session token rotated for user")
 		}
 
+		// Login isn't complete until the second factor is verified
+		if result.TwoFactorPending {
+			http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), userContextKey, result.User)
 		if result.User != nil {
 			ctx = middleware.WithUserID(ctx, result.User.ID)
@@ -111,10 +158,16 @@ func (h *AuthHandler) APIAuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		if result.NewToken != "" {
-			h.setSessionCookie(w, r, result.NewToken, 86400*7)
+			setSessionCookie(w, r, result.NewToken, 86400*7)
+		}
+
+		if result.TwoFactorPending {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
 		}
 
 		ctx := context.WithValue(r.Context(), userContextKey, result.User)
+		ctx = context.WithValue(ctx, twoFactorRequiredContextKey, h.requireTwoFactor)
 		if result.User != nil {
 			ctx = middleware.WithUserID(ctx, result.User.ID)
 		}
@@ -122,6 +175,145 @@ func (h *AuthHandler) APIAuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// RequireTwoFactor guards sensitive admin pages. When two-factor
+// authentication is required, users who have not enabled it are sent to the
+// security page to set it up. Sessions awaiting a code never get this far;
+// Middleware sends them to /login/2fa.
+func (h *AuthHandler) RequireTwoFactor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if h.requireTwoFactor && user != nil && !user.TwoFactorEnabled() {
+			h.logger.Debug("two-factor authentication required",
+				zap.String("user_id", user.ID.String()),
+				zap.String("path", r.URL.Path),
+			)
+			http.Redirect(w, r, "/account/security?required=1", http.StatusSeeOther)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAdmin restricts routes to users with the admin role.
+func (h *AuthHandler) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil || !user.IsAdmin() {
+			if user != nil {
+				h.logger.Debug("admin role required",
+					zap.String("user_id", user.ID.String()),
+					zap.String("path", r.URL.Path),
+				)
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAPIAdmin restricts JSON API routes to users with the admin role,
+// answering others with a JSON error rather than RequireAdmin's plain text.
+// Like RequireTwoFactor on admin pages, when two-factor authentication is
+// required it also refuses admins who have not enabled it, whether they call
+// with a session or with an API key acting for them.
+func RequireAPIAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := GetUserFromContext(r.Context())
+		if user == nil || !user.IsAdmin() {
+			APIError(w, http.StatusForbidden, "admin access required")
+			return
+		}
+		if required, _ := r.Context().Value(twoFactorRequiredContextKey).(bool); required && !user.TwoFactorEnabled() {
+			APIError(w, http.StatusForbidden, "two-factor authentication required; enable it at /account/security")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// APIKeyAuthMiddleware authenticates JSON API requests with an
+// "Authorization: Bearer qq_..." API key, enforcing the key's scopes and
+// per-key rate limit. Requests without an API key fall back to session
+// authentication via APIAuthMiddleware.
+func (h *AuthHandler) APIKeyAuthMiddleware(next http.Handler) http.Handler {
+	sessionAuth := h.APIAuthMiddleware(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok || h.apiKeyService == nil {
+			sessionAuth.ServeHTTP(w, r)
+			return
+		}
+
+		key, user, err := h.apiKeyService.Authenticate(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAPIKey) {
+				APIError(w, http.StatusUnauthorized, "invalid API key")
+				return
+			}
+			h.logger.Error("failed to authenticate API key", zap.Error(err))
+			APIError(w, http.StatusInternalServerError, "authentication failed")
+			return
+		}
+
+		if h.apiKeyLimiter != nil {
+			result := h.apiKeyLimiter.Allow(key.ID, key.RateLimitPerMinute)
+			w.Header().Set("X-RateLimit-Limit-Key", strconv.Itoa(result.Limit))
+			w.Header().Set("X-RateLimit-Remaining-Key", strconv.Itoa(result.Remaining))
+			if !result.Allowed {
+				h.logger.Warn("API key rate limit exceeded",
+					zap.String("key_id", key.ID.String()),
+					zap.Int("limit", result.Limit),
+				)
+				w.Header().Set("Retry-After", strconv.Itoa(int(result.ResetIn.Seconds())+1))
+				APIError(w, http.StatusTooManyRequests, "API key rate limit exceeded")
+				return
+			}
+		}
+
+		scope := domain.RequiredAPIScope(apiResource(r.URL.Path), r.Method)
+		if scope != "" && !key.HasScope(scope) {
+			h.logger.Debug("API key missing scope",
+				zap.String("key_id", key.ID.String()),
+				zap.String("required_scope", scope),
+			)
+			APIError(w, http.StatusForbidden, "API key lacks required scope: "+scope)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userContextKey, user)
+		ctx = context.WithValue(ctx, apiKeyContextKey, key)
+		ctx = context.WithValue(ctx, twoFactorRequiredContextKey, h.requireTwoFactor)
+		ctx = middleware.WithUserID(ctx, user.ID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// bearerToken extracts an API key from the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok || !strings.HasPrefix(token, domain.APIKeyPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// apiResource returns the first path segment after /api/v1, which names the
+// resource used for scope checks (e.g. "calls" for /api/v1/calls/123), or
+// after /api for endpoints outside the versioned API ("graphql").
+func apiResource(path string) string {
+	if rest, ok := strings.CutPrefix(path, "/api/v1"); ok {
+		path = rest
+	} else {
+		path = strings.TrimPrefix(path, "/api")
+	}
+	path = strings.TrimPrefix(path, "/")
+	resource, _, _ := strings.Cut(path, "/")
+	return resource
+}
+
 // HandleIndex redirects to dashboard or login based on auth status.
 func (h *AuthHandler) HandleIndex(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie("session_token")
@@ -145,8 +337,19 @@ func (h *AuthHandler) HandleLoginPage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	h.Render(w, r, "login", &LoginPageData{
-		Title: "Login",
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("reset") == "1":
+		successMsg = "Your password has been changed. Sign in with your new password."
+	case r.URL.Query().Get("verified") == "1":
+		successMsg = "Your email address is verified. You can sign in now."
+	case r.URL.Query().Get("invited") == "1":
+		successMsg = "Your account is ready. Sign in with your new password."
+	}
+
+	h.renderLogin(w, r, &LoginPageData{
+		Title:   "Login",
+		Success: successMsg,
 	})
 }
 
@@ -154,7 +357,7 @@ func (h *AuthHandler) HandleLoginPage(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		h.logger.Error("failed to parse form", zap.Error(err))
-		h.Render(w, r, "login", &LoginPageData{
+		h.renderLogin(w, r, &LoginPageData{
 			Title: "Login",
 			Error: "Invalid request",
 		})
@@ -174,7 +377,7 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		if h.metrics != nil {
 			h.metrics.RecordAuthRateLimited()
 		}
-		h.Render(w, r, "login", &LoginPageData{
+		h.renderLogin(w, r, &LoginPageData{
 			Title: "Login",
 			Error: "Too many login attempts. Please try again in 30 minutes.",
 			Email: email,
@@ -183,7 +386,7 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if email == "" || password == "" {
-		h.Render(w, r, "login", &LoginPageData{
+		h.renderLogin(w, r, &LoginPageData{
 			Title: "Login",
 			Error: "Email and password are required",
 			Email: email,
@@ -209,7 +412,11 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 
 		errorMsg := "Invalid email or password"
 		var authErr *service.AuthError
-		if !errors.As(err, &authErr) {
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			errorMsg = "Please verify your email address first. We've emailed you a new link."
+		} else if errors.Is(err, service.ErrAccountDisabled) {
+			errorMsg = "This account has been disabled. Contact an administrator."
+		} else if !errors.As(err, &authErr) {
 			errorMsg = "An error occurred. Please try again."
 		}
 
@@ -222,7 +429,7 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 			errorMsg = fmt.Sprintf("%s %d attempts remaining.", errorMsg, remaining)
 		}
 
-		h.Render(w, r, "login", &LoginPageData{
+		h.renderLogin(w, r, &LoginPageData{
 			Title: "Login",
 			Error: errorMsg,
 			Email: email,
@@ -230,23 +437,430 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.metrics != nil {
+		h.metrics.RecordAuthAttempt(true)
+		h.metrics.RecordSessionCreated()
+	}
+
+	// Set session cookie
+	setSessionCookie(w, r, session.Token, int(time.Until(session.ExpiresAt).Seconds()))
+
+	// The rate limit keeps counting until the second factor is verified, so
+	// a known password doesn't reset the budget for guessing codes.
+	if !session.TwoFactorVerified {
+		h.logger.Info("password accepted, awaiting two-factor code", zap.String("email", email))
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+
 	// Record successful login to reset rate limit
 	if h.loginRateLimiter != nil {
 		h.loginRateLimiter.RecordSuccess(ip, email)
 	}
 
+	h.logger.Info("user logged in successfully", zap.String("email", email))
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// renderLogin renders the login page with the password reset link and
+// single sign-on button when they are available.
+func (h *AuthHandler) renderLogin(w http.ResponseWriter, r *http.Request, data *LoginPageData) {
+	data.PasswordReset = h.authService.AccountEmailAvailable()
+	if h.sso != nil {
+		data.SSOName = h.ssoName
+		if data.SSOName == "" {
+			data.SSOName = "SSO"
+		}
+	}
+	h.Render(w, r, "login", data)
+}
+
+// HandleSSOStart redirects to the identity provider to sign in.
+func (h *AuthHandler) HandleSSOStart(w http.ResponseWriter, r *http.Request) {
+	if h.sso == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	login, err := oidc.NewLogin()
+	if err != nil {
+		h.logger.Error("failed to start single sign-on", zap.Error(err))
+		h.renderLogin(w, r, &LoginPageData{Title: "Login", Error: "An error occurred. Please try again."})
+		return
+	}
+	authURL, err := h.sso.AuthURL(r.Context(), login)
+	if err != nil {
+		h.logger.Error("failed to build single sign-on URL", zap.Error(err))
+		h.renderLogin(w, r, &LoginPageData{Title: "Login", Error: "Single sign-on is unavailable right now. Please try again."})
+		return
+	}
+	setSSOCookie(w, r, strings.Join([]string{login.State, login.Nonce, login.CodeVerifier}, "."), ssoCookieMaxAge)
+
+	http.Redirect(w, r, authURL, http.StatusSeeOther)
+}
+
+// HandleSSOCallback completes single sign-on when the identity provider
+// redirects back, starting a session for the signed-in user.
+func (h *AuthHandler) HandleSSOCallback(w http.ResponseWriter, r *http.Request) {
+	if h.sso == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	cookie, err := r.Cookie(ssoCookie)
+	setSSOCookie(w, r, "", -1)
+	var login *oidc.Login
+	if err == nil {
+		if parts := strings.Split(cookie.Value, "."); len(parts) == 3 {
+			login = &oidc.Login{State: parts[0], Nonce: parts[1], CodeVerifier: parts[2]}
+		}
+	}
+	state := r.URL.Query().Get("state")
+	if login == nil || state == "" || subtle.ConstantTimeCompare([]byte(login.State), []byte(state)) != 1 {
+		h.renderLogin(w, r, &LoginPageData{Title: "Login", Error: "The sign-in request expired or did not come from this browser. Please try again."})
+		return
+	}
+
+	identity, err := h.sso.Exchange(r.Context(), r.URL.Query(), login)
+	if err != nil {
+		h.logger.Warn("single sign-on failed", zap.Error(err))
+		if h.metrics != nil {
+			h.metrics.RecordAuthAttempt(false)
+		}
+		errorMsg := "Single sign-on failed. Please try again."
+		if errors.Is(err, oidc.ErrLoginFailed) {
+			errorMsg = "Sign-in was cancelled or refused by the identity provider."
+		}
+		h.renderLogin(w, r, &LoginPageData{Title: "Login", Error: errorMsg})
+		return
+	}
+
+	session, err := h.authService.LoginWithSSO(r.Context(), service.SSOIdentity{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   identity.Email,
+	}, &service.LoginContext{
+		IPAddress: getClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		h.logger.Warn("single sign-on login refused",
+			zap.String("email", identity.Email),
+			zap.Error(err),
+		)
+		if h.metrics != nil {
+			h.metrics.RecordAuthAttempt(false)
+		}
+		errorMsg := "An error occurred. Please try again."
+		switch {
+		case errors.Is(err, service.ErrDomainNotAllowed):
+			errorMsg = identity.Email + " is not allowed to sign in. Use your company account."
+		case errors.Is(err, service.ErrAccountDisabled):
+			errorMsg = "This account has been disabled. Contact an administrator."
+		case errors.Is(err, service.ErrSSONoAccount):
+			errorMsg = "There is no account for " + identity.Email + ". Ask an administrator for an invitation."
+		case errors.Is(err, service.ErrSSOAccountLinked):
+			errorMsg = "The account for " + identity.Email + " signs in as another user. Contact an administrator."
+		}
+		h.renderLogin(w, r, &LoginPageData{Title: "Login", Error: errorMsg})
+		return
+	}
+
 	if h.metrics != nil {
 		h.metrics.RecordAuthAttempt(true)
 		h.metrics.RecordSessionCreated()
 	}
+	setSessionCookie(w, r, session.Token, int(time.Until(session.ExpiresAt).Seconds()))
 
-	// Set session cookie
-	h.setSessionCookie(w, r, session.Token, int(time.Until(session.ExpiresAt).Seconds()))
+	if !session.TwoFactorVerified {
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// HandleTwoFactorPage renders the code entry step of login.
+func (h *AuthHandler) HandleTwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.pendingTwoFactor(w, r)
+	if !ok {
+		return
+	}
+
+	h.Render(w, r, "login_2fa", &LoginPageData{
+		Title: "Two-Factor Authentication",
+		Email: result.User.Email,
+	})
+}
+
+// HandleTwoFactor checks the code entered at the second step of login.
+func (h *AuthHandler) HandleTwoFactor(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.pendingTwoFactor(w, r)
+	if !ok {
+		return
+	}
+	email := result.User.Email
+	ip := getClientIP(r)
+
+	if err := r.ParseForm(); err != nil {
+		h.Render(w, r, "login_2fa", &LoginPageData{Title: "Two-Factor Authentication", Email: email, Error: "Invalid request"})
+		return
+	}
+
+	if h.loginRateLimiter != nil && !h.loginRateLimiter.Check(ip, email) {
+		h.logger.Warn("two-factor verification rate limited",
+			zap.String("email", email),
+			zap.String("ip", ip),
+		)
+		if h.metrics != nil {
+			h.metrics.RecordAuthRateLimited()
+		}
+		h.Render(w, r, "login_2fa", &LoginPageData{
+			Title: "Two-Factor Authentication",
+			Email: email,
+			Error: "Too many attempts. Please try again in 30 minutes.",
+		})
+		return
+	}
+
+	cookie, _ := r.Cookie("session_token")
+	session, err := h.authService.VerifyTwoFactor(r.Context(), cookie.Value, r.FormValue("code"))
+	if err != nil {
+		if errors.Is(err, service.ErrSessionExpired) {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		h.logger.Warn("two-factor verification failed",
+			zap.String("email", email),
+			zap.Error(err),
+		)
+		if h.metrics != nil {
+			h.metrics.RecordAuthAttempt(false)
+		}
+
+		errorMsg := "Invalid authentication code"
+		var authErr *service.AuthError
+		if !errors.As(err, &authErr) {
+			errorMsg = "An error occurred. Please try again."
+		}
+		h.Render(w, r, "login_2fa", &LoginPageData{
+			Title: "Two-Factor Authentication",
+			Email: email,
+			Error: errorMsg,
+		})
+		return
+	}
+
+	if h.loginRateLimiter != nil {
+		h.loginRateLimiter.RecordSuccess(ip, email)
+	}
+
+	setSessionCookie(w, r, session.Token, int(time.Until(session.ExpiresAt).Seconds()))
 
 	h.logger.Info("user logged in successfully", zap.String("email", email))
 	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
 }
 
+// pendingTwoFactor returns the session awaiting a second factor. It
+// redirects and returns false when there is none: to /login without a
+// valid session, to /dashboard when no code is needed.
+func (h *AuthHandler) pendingTwoFactor(w http.ResponseWriter, r *http.Request) (*service.SessionValidationResult, bool) {
+	cookie, err := r.Cookie("session_token")
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, false
+	}
+	result, err := h.authService.ValidateAndRefreshSession(r.Context(), cookie.Value)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, false
+	}
+	if !result.TwoFactorPending {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		return nil, false
+	}
+	return result, true
+}
+
+// HandleForgotPasswordPage renders the form for requesting a password reset link.
+func (h *AuthHandler) HandleForgotPasswordPage(w http.ResponseWriter, r *http.Request) {
+	h.renderForgotPassword(w, r, "", "", "")
+}
+
+// HandleForgotPassword emails a password reset link. The response is the
+// same whether or not the address has an account.
+func (h *AuthHandler) HandleForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.renderForgotPassword(w, r, "", "", "Invalid request")
+		return
+	}
+
+	email := strings.TrimSpace(r.FormValue("email"))
+	if email == "" {
+		h.renderForgotPassword(w, r, email, "", "Email is required")
+		return
+	}
+
+	// Reset requests are limited separately from logins, so asking for a
+	// link doesn't use up login attempts.
+	ip := getClientIP(r)
+	if h.loginRateLimiter != nil && !h.loginRateLimiter.Check(ip, "reset:"+email) {
+		h.logger.Warn("password reset rate limited",
+			zap.String("email", email),
+			zap.String("ip", ip),
+		)
+		h.renderForgotPassword(w, r, email, "", "Too many requests. Please try again in 30 minutes.")
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(r.Context(), email); err != nil {
+		h.logger.Error("failed to request password reset", zap.Error(err))
+		h.renderForgotPassword(w, r, email, "", "Password reset is unavailable. Please contact an administrator.")
+		return
+	}
+
+	h.renderForgotPassword(w, r, "", "If an account exists for "+email+", we've emailed it a link to reset the password.", "")
+}
+
+// renderForgotPassword renders the forgot password page.
+func (h *AuthHandler) renderForgotPassword(w http.ResponseWriter, r *http.Request, email, successMsg, errMsg string) {
+	if errMsg == "" && successMsg == "" && !h.authService.AccountEmailAvailable() {
+		errMsg = "Password reset by email is not configured. Please contact an administrator."
+	}
+	h.RenderTemplate(w, r, "forgot_password", map[string]interface{}{
+		"Title":   "Forgot Password",
+		"Email":   email,
+		"Success": successMsg,
+		"Error":   errMsg,
+	})
+}
+
+// HandleResetPasswordPage renders the form for choosing a new password.
+func (h *AuthHandler) HandleResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if err := h.authService.CheckPasswordResetToken(r.Context(), token); err != nil {
+		if !errors.Is(err, service.ErrInvalidToken) {
+			h.logger.Error("failed to check password reset token", zap.Error(err))
+		}
+		h.renderResetPassword(w, r, "", "This password reset link is invalid or has expired.")
+		return
+	}
+	h.renderResetPassword(w, r, token, "")
+}
+
+// HandleResetPassword sets a new password from the reset form.
+func (h *AuthHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.renderResetPassword(w, r, "", "Invalid request")
+		return
+	}
+
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	if password != r.FormValue("confirm_password") {
+		h.renderResetPassword(w, r, token, "Passwords do not match")
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), token, password); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidToken):
+			h.renderResetPassword(w, r, "", "This password reset link is invalid or has expired.")
+		case apperrors.IsUserError(err):
+			h.renderResetPassword(w, r, token, err.Error())
+		default:
+			h.logger.Error("failed to reset password", zap.Error(err))
+			h.renderResetPassword(w, r, token, "An error occurred. Please try again.")
+		}
+		return
+	}
+
+	http.Redirect(w, r, "/login?reset=1", http.StatusSeeOther)
+}
+
+// renderResetPassword renders the reset password page. Without a token the
+// page offers to send a new link instead of the form.
+func (h *AuthHandler) renderResetPassword(w http.ResponseWriter, r *http.Request, token, errMsg string) {
+	h.RenderTemplate(w, r, "reset_password", map[string]interface{}{
+		"Title":             "Reset Password",
+		"Token":             token,
+		"MinPasswordLength": domain.MinPasswordLength,
+		"Error":             errMsg,
+	})
+}
+
+// HandleAcceptInvitePage renders the form for an invited user to choose
+// their password.
+func (h *AuthHandler) HandleAcceptInvitePage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if err := h.authService.CheckInvitationToken(r.Context(), token); err != nil {
+		if !errors.Is(err, service.ErrInvalidToken) {
+			h.logger.Error("failed to check invitation token", zap.Error(err))
+		}
+		h.renderAcceptInvite(w, r, "", "This invitation link is invalid or has expired. Ask your administrator for a new one.")
+		return
+	}
+	h.renderAcceptInvite(w, r, token, "")
+}
+
+// HandleAcceptInvite sets the invited user's password.
+func (h *AuthHandler) HandleAcceptInvite(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.renderAcceptInvite(w, r, "", "Invalid request")
+		return
+	}
+
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	if password != r.FormValue("confirm_password") {
+		h.renderAcceptInvite(w, r, token, "Passwords do not match")
+		return
+	}
+
+	if _, err := h.authService.AcceptInvitation(r.Context(), token, password); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidToken), errors.Is(err, service.ErrAccountDisabled):
+			h.renderAcceptInvite(w, r, "", "This invitation link is invalid or has expired. Ask your administrator for a new one.")
+		case apperrors.IsUserError(err):
+			h.renderAcceptInvite(w, r, token, err.Error())
+		default:
+			h.logger.Error("failed to accept invitation", zap.Error(err))
+			h.renderAcceptInvite(w, r, token, "An error occurred. Please try again.")
+		}
+		return
+	}
+
+	http.Redirect(w, r, "/login?invited=1", http.StatusSeeOther)
+}
+
+// renderAcceptInvite renders the accept invitation page. Without a token
+// only the error is shown.
+func (h *AuthHandler) renderAcceptInvite(w http.ResponseWriter, r *http.Request, token, errMsg string) {
+	h.RenderTemplate(w, r, "accept_invite", map[string]interface{}{
+		"Title":             "Accept Invitation",
+		"Token":             token,
+		"MinPasswordLength": domain.MinPasswordLength,
+		"Error":             errMsg,
+	})
+}
+
+// HandleVerifyEmail confirms a user's email address from an emailed link.
+func (h *AuthHandler) HandleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.authService.VerifyEmail(r.Context(), r.URL.Query().Get("token")); err != nil {
+		errMsg := "This verification link is invalid or has expired. Sign in to get a new one."
+		if !errors.Is(err, service.ErrInvalidToken) {
+			h.logger.Error("failed to verify email", zap.Error(err))
+			errMsg = "An error occurred. Please try again."
+		}
+		h.renderLogin(w, r, &LoginPageData{
+			Title: "Login",
+			Error: errMsg,
+		})
+		return
+	}
+
+	http.Redirect(w, r, "/login?verified=1", http.StatusSeeOther)
+}
+
 // HandleLogout logs the user out.
 func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie("session_token")
@@ -270,7 +884,7 @@ func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 }
 
 // setSessionCookie sets the session cookie with proper security flags.
-func (h *AuthHandler) setSessionCookie(w http.ResponseWriter, r *http.Request, token string, maxAge int) {
+func setSessionCookie(w http.ResponseWriter, r *http.Request, token string, maxAge int) {
 	// Always use Secure in production
 	secure := isProduction() || r.TLS != nil
 
@@ -285,6 +899,20 @@ func (h *AuthHandler) setSessionCookie(w http.ResponseWriter, r *http.Request, t
 	})
 }
 
+// setSSOCookie stores a single sign-on attempt's state, nonce and PKCE
+// verifier for the callback.
+func setSSOCookie(w http.ResponseWriter, r *http.Request, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoCookie,
+		Value:    value,
+		Path:     "/auth/sso",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   isProduction() || r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
 // isProduction checks if running in production environment.
 func isProduction() bool {
 	env := os.Getenv("APP_ENV")
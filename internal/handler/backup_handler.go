@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/storage"
+)
+
+// backupListLimit is how many backups the list shows.
+const backupListLimit = 100
+
+// BackupManager takes database backups and opens them for download.
+// service.BackupService implements it.
+type BackupManager interface {
+	List(ctx context.Context, limit int) ([]*domain.Backup, error)
+	StartBackup(ctx context.Context, requestedBy *uuid.UUID) (*domain.Backup, error)
+	Open(ctx context.Context, id uuid.UUID) (*domain.Backup, *storage.Object, error)
+}
+
+// BackupHandler lists database backups, takes one on request and serves
+// them for download.
+type BackupHandler struct {
+	backups     BackupManager
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewBackupHandler creates a handler for database backups. backups is nil
+// when no backup storage is configured; auditLogger may be nil.
+func NewBackupHandler(backups BackupManager, auditLogger *audit.Logger, logger *zap.Logger) *BackupHandler {
+	return &BackupHandler{
+		backups:     backups,
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// BackupsResponse lists the most recent backups, newest first.
+type BackupsResponse struct {
+	Backups []*domain.Backup `json:"backups"`
+}
+
+// RegisterRoutes registers the backup routes on the router.
+// Note: These routes require authentication and admin middleware to be
+// applied by the caller.
+func (h *BackupHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/backups", h.HandleList)
+	r.Post("/admin/backups", h.HandleCreate)
+	r.Get("/admin/backups/{id}/download", h.HandleDownload)
+}
+
+// HandleList lists the most recent backups and how each went.
+func (h *BackupHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if h.backups == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "backup storage not configured")
+		return
+	}
+
+	backups, err := h.backups.List(r.Context(), backupListLimit)
+	if err != nil {
+		h.logger.Error("failed to list backups", zap.Error(err))
+		h.writeError(w, http.StatusInternalServerError, "failed to list backups")
+		return
+	}
+	if backups == nil {
+		backups = []*domain.Backup{}
+	}
+	json.NewEncoder(w).Encode(BackupsResponse{Backups: backups})
+}
+
+// HandleCreate starts a backup. It is taken in the background; its outcome
+// shows in the list once it finishes.
+func (h *BackupHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if h.backups == nil {
+		h.writeError(w, http.StatusServiceUnavailable, "backup storage not configured")
+		return
+	}
+
+	var requestedBy *uuid.UUID
+	if user := GetUserFromContext(r.Context()); user != nil {
+		requestedBy = &user.ID
+	}
+	backup, err := h.backups.StartBackup(r.Context(), requestedBy)
+	if err != nil {
+		if errors.Is(err, service.ErrBackupInProgress) {
+			h.writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		h.logger.Error("failed to start backup", zap.Error(err))
+		h.writeError(w, http.StatusInternalServerError, "failed to start backup")
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.BackupStarted(r.Context(), userID, userName, backup.ID.String(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(backup)
+}
+
+// HandleDownload streams a completed backup's dump file.
+func (h *BackupHandler) HandleDownload(w http.ResponseWriter, r *http.Request) {
+	if h.backups == nil {
+		w.Header().Set("Content-Type", "application/json")
+		h.writeError(w, http.StatusServiceUnavailable, "backup storage not configured")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		h.writeError(w, http.StatusBadRequest, "invalid backup ID")
+		return
+	}
+
+	backup, obj, err := h.backups.Open(r.Context(), id)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		if apperrors.IsNotFound(err) {
+			msg := "backup not found"
+			if backup != nil {
+				msg = fmt.Sprintf("backup is %s and has no file to download", backup.Status)
+			}
+			h.writeError(w, http.StatusNotFound, msg)
+			return
+		}
+		h.logger.Error("failed to open backup", zap.String("backup_id", id.String()), zap.Error(err))
+		h.writeError(w, http.StatusInternalServerError, "failed to open backup")
+		return
+	}
+	defer obj.Body.Close()
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.BackupDownloaded(r.Context(), userID, userName, backup.ID.String(), getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if obj.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(backup.StorageKey)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, obj.Body); err != nil {
+		h.logger.Debug("backup download interrupted", zap.String("backup_id", id.String()), zap.Error(err))
+	}
+}
+
+func (h *BackupHandler) writeError(w http.ResponseWriter, status int, msg string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": msg,
+	})
+}
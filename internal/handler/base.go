@@ -4,14 +4,20 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/validation"
 )
 
 // Context key for user
@@ -20,6 +26,14 @@ type contextKey string
 const (
 	userContextKey      contextKey = "user"
 	requestIDContextKey contextKey = "request_id"
+	apiKeyContextKey    contextKey = "api_key"
+
+	// twoFactorRequiredContextKey marks API requests whose admins must have
+	// two-factor authentication enabled
+	twoFactorRequiredContextKey contextKey = "two_factor_required"
+
+	// graphQLLoaderContextKey holds the lookups shared by one GraphQL request
+	graphQLLoaderContextKey contextKey = "graphql_loader"
 )
 
 // GetUserFromContext retrieves the authenticated user from the context.
@@ -31,6 +45,16 @@ func GetUserFromContext(ctx context.Context) *domain.User {
 	return user
 }
 
+// GetAPIKeyFromContext retrieves the API key used to authenticate the request,
+// or nil if the request was authenticated with a session.
+func GetAPIKeyFromContext(ctx context.Context) *domain.APIKey {
+	key, ok := ctx.Value(apiKeyContextKey).(*domain.APIKey)
+	if !ok {
+		return nil
+	}
+	return key
+}
+
 // GetRequestIDFromContext retrieves the request ID from the context.
 func GetRequestIDFromContext(ctx context.Context) string {
 	id, ok := ctx.Value(requestIDContextKey).(string)
@@ -93,6 +117,11 @@ func (b *BaseHandler) RenderTemplate(w http.ResponseWriter, r *http.Request, nam
 		data["RequestID"] = reqID
 	}
 
+	// Add the feature flags evaluated for the user
+	if _, ok := data["Flags"]; !ok {
+		data["Flags"] = service.FeatureFlagsFromContext(r.Context())
+	}
+
 	if _, ok := data["AssetVersion"]; !ok && b.assetVersion != "" {
 		data["AssetVersion"] = b.assetVersion
 	}
@@ -145,6 +174,7 @@ func (b *BaseHandler) WriteJSON(w http.ResponseWriter, r *http.Request, status i
 func (b *BaseHandler) WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
 	b.WriteJSON(w, r, status, map[string]interface{}{
 		"error":      message,
+		"code":       apperrors.CodeForStatus(status),
 		"status":     status,
 		"request_id": GetRequestIDFromContext(r.Context()),
 	})
@@ -178,20 +208,79 @@ func JSONWithRequest(w http.ResponseWriter, r *http.Request, status int, data in
 	}
 }
 
-// APIError writes an API error response in a consistent format.
+// APIError writes an API error response in a consistent format, with the
+// error code conventionally used for status.
 // This is a package-level helper for handlers that don't embed BaseHandler.
 func APIError(w http.ResponseWriter, status int, message string) {
+	APIErrorCode(w, status, apperrors.CodeForStatus(status), message)
+}
+
+// APIErrorCode writes an API error response with a specific error code.
+func APIErrorCode(w http.ResponseWriter, status int, code apperrors.Code, message string) {
 	JSON(w, status, ErrorResponse{
 		Error:   http.StatusText(status),
+		Code:    code,
 		Message: message,
 	})
 }
 
+// APIAppError writes an application error with its own status, code and
+// message. Use it for errors the client caused (apperrors.IsUserError).
+func APIAppError(w http.ResponseWriter, err error) {
+	APIErrorCode(w, apperrors.GetHTTPStatus(err), apperrors.GetCode(err), err.Error())
+}
+
+// APIServiceError writes an error returned by a service: errors the client
+// caused with their own status and code, voice provider failures as
+// PROVIDER_UNAVAILABLE or PROVIDER_ERROR, and anything else as an internal
+// error. Only client errors expose err's text; the others are described by
+// message.
+func APIServiceError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case apperrors.IsUserError(err):
+		APIAppError(w, err)
+	case isProviderUnavailable(err):
+		APIErrorCode(w, http.StatusServiceUnavailable, apperrors.CodeProviderUnavailable, message)
+	case isProviderError(err):
+		APIErrorCode(w, http.StatusBadGateway, apperrors.CodeProviderError, message)
+	default:
+		APIErrorCode(w, http.StatusInternalServerError, apperrors.CodeInternal, message)
+	}
+}
+
+// isProviderUnavailable reports whether err means a voice provider can't be
+// reached or is being given a rest by its circuit breaker.
+func isProviderUnavailable(err error) bool {
+	if errors.Is(err, circuitbreaker.ErrCircuitOpen) || errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+		return true
+	}
+	switch apperrors.GetCode(err) {
+	case apperrors.CodeProviderUnavailable, apperrors.CodeCircuitOpen:
+		return true
+	}
+	return false
+}
+
+// isProviderError reports whether err is a voice provider refusing or
+// failing a request.
+func isProviderError(err error) bool {
+	var blandErr *bland.APIError
+	if errors.As(err, &blandErr) {
+		return true
+	}
+	switch apperrors.GetCode(err) {
+	case apperrors.CodeProviderError, apperrors.CodeExternalService:
+		return true
+	}
+	return false
+}
+
 // APIErrorWithRequest writes an API error response, including request context.
 // This is the preferred method when the request is available.
 func APIErrorWithRequest(w http.ResponseWriter, r *http.Request, status int, message string) {
 	JSONWithRequest(w, r, status, map[string]interface{}{
 		"error":      http.StatusText(status),
+		"code":       apperrors.CodeForStatus(status),
 		"message":    message,
 		"status":     status,
 		"request_id": GetRequestIDFromContext(r.Context()),
@@ -208,6 +297,7 @@ type ValidationFieldError struct {
 // ValidationErrorResponse represents a structured validation error response.
 type ValidationErrorResponse struct {
 	Error   string                 `json:"error"`
+	Code    apperrors.Code         `json:"code"`
 	Message string                 `json:"message"`
 	Status  int                    `json:"status"`
 	Errors  []ValidationFieldError `json:"errors"`
@@ -217,6 +307,7 @@ type ValidationErrorResponse struct {
 func APIValidationError(w http.ResponseWriter, errors []ValidationFieldError) {
 	resp := ValidationErrorResponse{
 		Error:   "Bad Request",
+		Code:    apperrors.CodeValidation,
 		Message: "Validation failed",
 		Status:  http.StatusBadRequest,
 		Errors:  errors,
@@ -228,6 +319,7 @@ func APIValidationError(w http.ResponseWriter, errors []ValidationFieldError) {
 func APIValidationErrorWithRequest(w http.ResponseWriter, r *http.Request, errors []ValidationFieldError) {
 	resp := map[string]interface{}{
 		"error":      "Bad Request",
+		"code":       apperrors.CodeValidation,
 		"message":    "Validation failed",
 		"status":     http.StatusBadRequest,
 		"errors":     errors,
@@ -236,6 +328,38 @@ func APIValidationErrorWithRequest(w http.ResponseWriter, r *http.Request, error
 	JSONWithRequest(w, r, http.StatusBadRequest, resp)
 }
 
+// APIFieldErrors writes a 422 listing the fields of a request body that
+// failed validation.
+func APIFieldErrors(w http.ResponseWriter, errs validation.ValidationErrors) {
+	fields := make([]ValidationFieldError, len(errs))
+	for i, e := range errs {
+		fields[i] = ValidationFieldError(e)
+	}
+	resp := ValidationErrorResponse{
+		Error:   "Unprocessable Entity",
+		Code:    apperrors.CodeValidation,
+		Message: "Validation failed",
+		Status:  http.StatusUnprocessableEntity,
+		Errors:  fields,
+	}
+	JSON(w, http.StatusUnprocessableEntity, resp)
+}
+
+// decodeAndValidate decodes a JSON request body into dst and checks it
+// against dst's validate tags. It answers 400 for a malformed body and 422
+// for invalid fields, and reports whether the handler can go on.
+func decodeAndValidate(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		APIError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	if errs := validation.Struct(dst); errs.HasErrors() {
+		APIFieldErrors(w, errs)
+		return false
+	}
+	return true
+}
+
 // NewValidationError creates a single field validation error.
 func NewValidationError(field, message, code string) ValidationFieldError {
 	return ValidationFieldError{
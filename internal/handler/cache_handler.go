@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jkindrix/quickquote/internal/cache"
+)
+
+// CacheStatsProvider reports on the caches a service keeps.
+// SettingsService and PromptService implement it.
+type CacheStatsProvider interface {
+	CacheStats() []cache.Stats
+}
+
+// CacheHandler shows how the in-process caches are doing.
+type CacheHandler struct {
+	providers []CacheStatsProvider
+}
+
+// NewCacheHandler creates a handler for cache statistics.
+func NewCacheHandler(providers ...CacheStatsProvider) *CacheHandler {
+	return &CacheHandler{providers: providers}
+}
+
+// CacheResponse lists the statistics of each cache.
+type CacheResponse struct {
+	Caches []cache.Stats `json:"caches"`
+}
+
+// ServeHTTP implements http.Handler for the cache statistics endpoint.
+func (h *CacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "method not allowed",
+		})
+		return
+	}
+
+	resp := CacheResponse{Caches: []cache.Stats{}}
+	for _, p := range h.providers {
+		resp.Caches = append(resp.Caches, p.CacheStats()...)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
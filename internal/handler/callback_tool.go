@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// CallbackToolHandler serves the schedule_callback tool that the voice agent
+// calls mid-call when a caller asks to be called back.
+type CallbackToolHandler struct {
+	callbackService *service.CallbackService
+	secret          string
+	logger          *zap.Logger
+}
+
+// NewCallbackToolHandler creates a new CallbackToolHandler. When secret is
+// set, requests must carry it in the bland.ToolSecretHeader header.
+func NewCallbackToolHandler(callbackService *service.CallbackService, secret string, logger *zap.Logger) *CallbackToolHandler {
+	return &CallbackToolHandler{
+		callbackService: callbackService,
+		secret:          secret,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers the tool route. It needs no session; requests
+// are authenticated by the tool secret.
+func (h *CallbackToolHandler) RegisterRoutes(r chi.Router) {
+	r.With(middleware.BodySizeLimiterWebhook()).Post(bland.ScheduleCallbackToolPath, h.HandleScheduleCallback)
+}
+
+// ScheduleCallbackResponse is the tool response the agent reads back to the caller.
+type ScheduleCallbackResponse struct {
+	Success    bool   `json:"success"`
+	CallbackID string `json:"callback_id,omitempty"`
+	Date       string `json:"date,omitempty"` // e.g. "Monday, October 19"
+	Time       string `json:"time,omitempty"` // e.g. "2:00 PM"
+	Error      string `json:"error,omitempty"`
+}
+
+// HandleScheduleCallback handles POST /webhook/bland/tools/schedule-callback
+func (h *CallbackToolHandler) HandleScheduleCallback(w http.ResponseWriter, r *http.Request) {
+	if h.secret != "" {
+		got := r.Header.Get(bland.ToolSecretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(h.secret)) != 1 {
+			h.logger.Warn("schedule callback tool request with invalid secret")
+			APIError(w, http.StatusUnauthorized, "invalid tool secret")
+			return
+		}
+	}
+
+	var req service.ScheduleCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSON(w, http.StatusBadRequest, ScheduleCallbackResponse{Error: "invalid request body"})
+		return
+	}
+	if id := strings.TrimSpace(r.Header.Get(bland.ToolCallIDHeader)); id != "" {
+		req.ProviderCallID = id
+	}
+
+	callback, err := h.callbackService.ScheduleCallback(r.Context(), &req)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			JSON(w, http.StatusUnprocessableEntity, ScheduleCallbackResponse{Error: err.Error()})
+			return
+		}
+		h.logger.Error("failed to schedule callback",
+			zap.Error(err),
+			zap.String("provider_call_id", req.ProviderCallID),
+		)
+		JSON(w, http.StatusInternalServerError, ScheduleCallbackResponse{Error: "failed to schedule callback"})
+		return
+	}
+
+	at := callback.ScheduledAt.In(h.callbackService.Location())
+	JSON(w, http.StatusOK, ScheduleCallbackResponse{
+		Success:    true,
+		CallbackID: callback.ID.String(),
+		Date:       at.Format("Monday, January 2"),
+		Time:       at.Format("3:04 PM"),
+	})
+}
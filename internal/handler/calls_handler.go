@@ -3,7 +3,10 @@ package handler
 import (
 	"fmt"
 	"html"
+	"html/template"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -11,6 +14,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/export"
 	"github.com/jkindrix/quickquote/internal/middleware"
 	"github.com/jkindrix/quickquote/internal/service"
 )
@@ -18,13 +23,25 @@ import (
 // CallsHandler handles call-related HTTP requests including dashboard.
 type CallsHandler struct {
 	*BaseHandler
-	callService *service.CallService
+	callService     *service.CallService
+	searchService   *service.SearchService
+	callbackService *service.CallbackService
+	tagService      *service.TagService
+	savedViews      *service.SavedViewService
+	bulk            *service.BulkService
+	exports         *export.Service
 }
 
 // CallsHandlerConfig holds configuration for CallsHandler.
 type CallsHandlerConfig struct {
-	Base        BaseHandlerConfig
-	CallService *service.CallService
+	Base            BaseHandlerConfig
+	CallService     *service.CallService
+	SearchService   *service.SearchService    // Optional: enables transcript search
+	CallbackService *service.CallbackService  // Optional: shows upcoming callbacks on the dashboard
+	TagService      *service.TagService       // Optional: shows saved tag views on the dashboard
+	SavedViews      *service.SavedViewService // Optional: saved calls list views and the default view
+	Bulk            *service.BulkService      // Optional: multi-select bulk actions on the calls list
+	Exports         *export.Service           // Optional: lets bulk actions export the selected calls
 }
 
 // NewCallsHandler creates a new CallsHandler with all required dependencies.
@@ -33,8 +50,14 @@ func NewCallsHandler(cfg CallsHandlerConfig) *CallsHandler {
 		panic("callService is required")
 	}
 	return &CallsHandler{
-		BaseHandler: NewBaseHandler(cfg.Base),
-		callService: cfg.CallService,
+		BaseHandler:     NewBaseHandler(cfg.Base),
+		callService:     cfg.CallService,
+		searchService:   cfg.SearchService,
+		callbackService: cfg.CallbackService,
+		tagService:      cfg.TagService,
+		savedViews:      cfg.SavedViews,
+		bulk:            cfg.Bulk,
+		exports:         cfg.Exports,
 	}
 }
 
@@ -45,8 +68,20 @@ func (h *CallsHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/calls", h.HandleCallsList)
 	r.Get("/calls/{id}", h.HandleCallDetail)
 	r.Post("/calls/{id}/regenerate-quote", h.HandleRegenerateQuote)
+	r.Post("/calls/{id}/disposition", h.HandleSetDisposition)
+	if h.savedViews != nil {
+		r.Post("/calls/saved-views", h.HandleSaveView)
+		r.Post("/calls/saved-views/{viewID}/default", h.HandleSetDefaultView)
+		r.Post("/calls/saved-views/{viewID}/delete", h.HandleDeleteView)
+	}
+	if h.bulk != nil {
+		r.Post("/calls/bulk", h.HandleBulk)
+	}
 }
 
+// dashboardCallbackLimit is the number of upcoming callbacks on the dashboard.
+const dashboardCallbackLimit = 5
+
 // HandleDashboard serves the main dashboard.
 func (h *CallsHandler) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 	user := GetUserFromContext(r.Context())
@@ -62,7 +97,7 @@ func (h *CallsHandler) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.Render(w, r, "dashboard", &DashboardPageData{
+	data := &DashboardPageData{
 		BasePageData: BasePageData{
 			Title:     "Dashboard",
 			ActiveNav: "dashboard",
@@ -71,7 +106,51 @@ func (h *CallsHandler) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 		Calls:         calls,
 		TotalCalls:    total,
 		PendingQuotes: countPendingQuotes(calls),
-	})
+	}
+
+	if h.callbackService != nil {
+		data.ShowCallbacks = true
+		callbacks, err := h.callbackService.Upcoming(r.Context(), dashboardCallbackLimit)
+		if err != nil {
+			h.logger.Error("failed to list upcoming callbacks", zap.Error(err))
+		}
+		// Show callback times in the business timezone.
+		for _, cb := range callbacks {
+			cb.ScheduledAt = cb.ScheduledAt.In(h.callbackService.Location())
+		}
+		data.Callbacks = callbacks
+	}
+
+	if h.tagService != nil {
+		views, err := h.tagService.ListViews(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("failed to list tag views", zap.Error(err))
+		}
+		for _, view := range views {
+			data.TagViews = append(data.TagViews, TagViewLink{
+				Name:   view.Name,
+				Tags:   view.Tags,
+				Count:  view.Count,
+				Path:   tagViewPath(view.TagView),
+				Shared: view.IsShared(),
+			})
+		}
+	}
+
+	h.Render(w, r, "dashboard", data)
+}
+
+// tagViewPath returns the list page filtered by a saved view's tags.
+func tagViewPath(view *domain.TagView) string {
+	tags := url.QueryEscape(strings.Join(view.Tags, ","))
+	switch view.EntityType {
+	case domain.TagEntityCustomer:
+		return "/customers?tag=" + tags
+	case domain.TagEntityQuote:
+		return "/calls?quote_tag=" + tags
+	default:
+		return "/calls?tag=" + tags
+	}
 }
 
 // HandleCallsList serves the calls list page.
@@ -90,10 +169,44 @@ func (h *CallsHandler) HandleCallsList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := r.URL.Query()
+
+	// The list opens with the user's default view
+	if len(query) == 0 && h.savedViews != nil {
+		view, err := h.savedViews.Default(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("failed to get default view", zap.Error(err))
+		} else if view != nil {
+			http.Redirect(w, r, view.Path(), http.StatusSeeOther)
+			return
+		}
+	}
+
 	statusParam := strings.TrimSpace(query.Get("status"))
 	searchParam := strings.TrimSpace(query.Get("q"))
+	transcriptParam := strings.TrimSpace(query.Get("transcript"))
+
+	if transcriptParam != "" && h.searchService != nil {
+		h.renderTranscriptSearch(w, r, user, transcriptParam, page)
+		return
+	}
 
-	filter := buildCallListFilter(statusParam, searchParam)
+	view := CallListFilterView{
+		Status:    statusParam,
+		Query:     searchParam,
+		Sentiment: strings.TrimSpace(query.Get("sentiment")),
+		Intent:    strings.TrimSpace(query.Get("intent")),
+		Spam:      strings.TrimSpace(query.Get("spam")),
+		Tag:       strings.TrimSpace(query.Get("tag")),
+		QuoteTag:  strings.TrimSpace(query.Get("quote_tag")),
+		Columns:   parseCallListColumns(query["columns"]),
+	}
+	if viewID, err := uuid.Parse(query.Get("view")); err == nil {
+		view.View = viewID.String()
+	}
+	if sort, err := domain.ParseCallSort(query.Get("sort")); err == nil && sort == domain.CallSortOldest {
+		view.Sort = string(sort)
+	}
+	filter := buildCallListFilter(view)
 
 	calls, total, err := h.callService.ListCalls(r.Context(), page, 20, filter)
 	if err != nil {
@@ -105,7 +218,7 @@ func (h *CallsHandler) HandleCallsList(w http.ResponseWriter, r *http.Request) {
 	pageSize := 20
 	totalPages := (total + pageSize - 1) / pageSize
 
-	h.Render(w, r, "calls", &CallsPageData{
+	data := &CallsPageData{
 		BasePageData: BasePageData{
 			Title:     "Calls",
 			ActiveNav: "calls",
@@ -116,11 +229,257 @@ func (h *CallsHandler) HandleCallsList(w http.ResponseWriter, r *http.Request) {
 		Page:       page,
 		PageSize:   pageSize,
 		TotalPages: totalPages,
-		Filter: CallListFilterView{
-			Status: statusParam,
-			Query:  searchParam,
-		},
+		Filter:     view,
+		Columns:    callListColumnViews(view),
+	}
+
+	if h.savedViews != nil {
+		data.ShowSavedViews = true
+		views, err := h.savedViews.List(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("failed to list saved views", zap.Error(err))
+		}
+		data.SavedViews = views
+		for _, v := range views {
+			if v.ID.String() == view.View {
+				data.ActiveView = v
+			}
+		}
+	}
+
+	if h.bulk != nil {
+		data.ShowBulk = true
+		assignees, err := h.bulk.Assignees(r.Context())
+		if err != nil {
+			h.logger.Error("failed to list assignees", zap.Error(err))
+		}
+		data.Assignees = assignees
+	}
+
+	h.Render(w, r, "calls", data)
+}
+
+// HandleBulk applies the action chosen on the calls list to the selected
+// calls and returns to the list, or downloads them for an export. If the
+// action can't be applied to some call nothing is changed and the calls
+// that failed are listed.
+func (h *CallsHandler) HandleBulk(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	action, err := domain.ParseBulkAction(r.PostForm.Get("action"))
+	if err != nil {
+		http.Error(w, "Choose an action", http.StatusBadRequest)
+		return
+	}
+	var ids []uuid.UUID
+	for _, value := range r.PostForm["ids"] {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, "Invalid call ID", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		http.Error(w, "Select at least one call", http.StatusBadRequest)
+		return
+	}
+
+	var result *domain.BulkResult
+	if action == domain.BulkActionExport {
+		format, err := export.ParseFormat(r.PostForm.Get("format"))
+		if err != nil || h.exports == nil {
+			http.Error(w, "Export is not available", http.StatusBadRequest)
+			return
+		}
+		var filter *domain.CallListFilter
+		result, filter, err = h.bulk.ExportFilter(r.Context(), domain.BulkRecordCall, ids)
+		if err != nil {
+			h.respondBulkError(w, err)
+			return
+		}
+		if result.Applied {
+			streamExport(w, r, "calls", format, filter, h.exports.WriteCalls, h.logger)
+			return
+		}
+	} else {
+		req := &service.BulkRequest{Action: action, IDs: ids, Tag: r.PostForm.Get("tag")}
+		if assignee := r.PostForm.Get("user_id"); assignee != "" {
+			id, err := uuid.Parse(assignee)
+			if err != nil {
+				http.Error(w, "Invalid user", http.StatusBadRequest)
+				return
+			}
+			req.UserID = &id
+		}
+		result, err = h.bulk.Run(r.Context(), userActor(r), domain.BulkRecordCall, req)
+		if err != nil {
+			h.respondBulkError(w, err)
+			return
+		}
+	}
+
+	if !result.Applied {
+		var failed []string
+		for _, item := range result.Items {
+			if item.Status == domain.BulkItemFailed {
+				failed = append(failed, fmt.Sprintf("%s: %s", item.ID, item.Error))
+			}
+		}
+		http.Error(w, "No calls were changed:\n"+strings.Join(failed, "\n"), http.StatusUnprocessableEntity)
+		return
+	}
+
+	returnTo := r.PostForm.Get("return_to")
+	if !strings.HasPrefix(returnTo, "/calls") {
+		returnTo = "/calls"
+	}
+	http.Redirect(w, r, returnTo, http.StatusSeeOther)
+}
+
+func (h *CallsHandler) respondBulkError(w http.ResponseWriter, err error) {
+	if apperrors.IsUserError(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.logger.Error("bulk action failed", zap.Error(err))
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// HandleSaveView saves the calls list's current filters, sort and columns
+// as a view for the user and opens it.
+func (h *CallsHandler) HandleSaveView(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	filters := make(map[string]string)
+	for _, key := range domain.SavedViewFilterKeys {
+		filters[key] = r.PostForm.Get(key)
+	}
+	view, err := h.savedViews.Create(r.Context(), user.ID, &service.SavedViewRequest{
+		Name:      r.PostForm.Get("name"),
+		Filters:   filters,
+		Sort:      domain.CallSort(r.PostForm.Get("sort")),
+		Columns:   parseCallListColumns(r.PostForm["columns"]),
+		IsDefault: r.PostForm.Get("default") == "on",
 	})
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to save view", zap.Error(err))
+		http.Error(w, "Failed to save view", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, view.Path(), http.StatusSeeOther)
+}
+
+// HandleSetDefaultView makes one of the user's views the one the calls list
+// opens with.
+func (h *CallsHandler) HandleSetDefaultView(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewID"))
+	if err != nil {
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	view, err := h.savedViews.SetDefault(r.Context(), user.ID, viewID)
+	if err != nil {
+		h.respondSavedViewError(w, "failed to set default view", err)
+		return
+	}
+	http.Redirect(w, r, view.Path(), http.StatusSeeOther)
+}
+
+// HandleDeleteView deletes one of the user's views.
+func (h *CallsHandler) HandleDeleteView(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewID"))
+	if err != nil {
+		http.Error(w, "Invalid view ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.savedViews.Delete(r.Context(), user.ID, viewID); err != nil {
+		h.respondSavedViewError(w, "failed to delete view", err)
+		return
+	}
+	http.Redirect(w, r, "/calls?view=all", http.StatusSeeOther)
+}
+
+func (h *CallsHandler) respondSavedViewError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case apperrors.IsNotFound(err):
+		http.Error(w, "View not found", http.StatusNotFound)
+	case apperrors.IsUserError(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
+}
+
+// renderTranscriptSearch renders the calls page with ranked transcript matches.
+func (h *CallsHandler) renderTranscriptSearch(w http.ResponseWriter, r *http.Request, user *domain.User, query string, page int) {
+	const pageSize = 20
+
+	data := &CallsPageData{
+		BasePageData: BasePageData{
+			Title:     "Calls",
+			ActiveNav: "calls",
+			User:      user,
+		},
+		Page:     page,
+		PageSize: pageSize,
+		Filter:   CallListFilterView{Transcript: query},
+	}
+
+	results, err := h.searchService.SearchTranscripts(r.Context(), query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		if !apperrors.IsUserError(err) {
+			h.logger.Error("failed to search transcripts", zap.Error(err))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		data.SearchError = err.Error()
+	} else {
+		data.TotalCalls = results.Total
+		data.TotalPages = (results.Total + pageSize - 1) / pageSize
+		for _, res := range results.Results {
+			data.TranscriptMatches = append(data.TranscriptMatches, TranscriptMatchView{
+				TranscriptSearchResult: res,
+				SnippetHTML:            template.HTML(res.Snippet), // escaped by SearchService
+			})
+		}
+	}
+
+	h.Render(w, r, "calls", data)
 }
 
 // HandleCallDetail serves a single call detail page.
@@ -145,14 +504,55 @@ func (h *CallsHandler) HandleCallDetail(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	h.Render(w, r, "call_detail", &CallDetailPageData{
+	data := &CallDetailPageData{
 		BasePageData: BasePageData{
 			Title:     "Call Details",
 			ActiveNav: "calls",
 			User:      user,
 		},
-		Call: call,
-	})
+		Call:         call,
+		Dispositions: domain.CallDispositions,
+	}
+	if call.Disposition != nil {
+		data.Disposition = string(*call.Disposition)
+	}
+	if call.DispositionSource != nil {
+		data.DispositionSource = string(*call.DispositionSource)
+	}
+	h.Render(w, r, "call_detail", data)
+}
+
+// HandleSetDisposition corrects a call's disposition from the call detail
+// page.
+func (h *CallsHandler) HandleSetDisposition(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		http.Error(w, "Invalid call ID", http.StatusBadRequest)
+		return
+	}
+
+	disposition := domain.CallDisposition(r.FormValue("disposition"))
+	if _, err := h.callService.SetDisposition(r.Context(), id, disposition); err != nil {
+		switch {
+		case apperrors.IsNotFound(err):
+			http.Error(w, "Call not found", http.StatusNotFound)
+		case apperrors.IsUserError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error("failed to set call disposition", zap.Error(err), zap.String("id", idStr))
+			http.Error(w, "Failed to set disposition", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/calls/%s", id), http.StatusSeeOther)
 }
 
 // HandleRegenerateQuote regenerates the quote for a call.
@@ -190,8 +590,11 @@ func (h *CallsHandler) HandleRegenerateQuote(w http.ResponseWriter, r *http.Requ
 // renderQuoteSection renders just the quote section for htmx updates.
 func (h *CallsHandler) renderQuoteSection(w http.ResponseWriter, r *http.Request, call *domain.Call) {
 	quote := "No quote generated yet"
+	pdfLink := ""
 	if call.QuoteSummary != nil {
 		quote = *call.QuoteSummary
+		pdfLink = fmt.Sprintf(`<a href="/quotes/%s" class="btn btn-secondary">Edit Quote</a>
+				<a href="/api/v1/quotes/%s/pdf?download=true" class="btn btn-secondary">Download PDF</a>`, call.ID, call.ID)
 	}
 
 	csrfToken := h.GetCSRFToken(r)
@@ -212,9 +615,10 @@ func (h *CallsHandler) renderQuoteSection(w http.ResponseWriter, r *http.Request
 					Regenerate Quote
 				</button>
 				<span id="quote-loading" class="htmx-indicator">Generating...</span>
+				%s
 			</form>
 		</div>
-	`, html.EscapeString(quote), call.ID, html.EscapeString(csrfToken))
+	`, html.EscapeString(quote), call.ID, html.EscapeString(csrfToken), pdfLink)
 }
 
 // countPendingQuotes counts calls that are completed but don't have quotes.
@@ -230,15 +634,128 @@ func countPendingQuotes(calls []*domain.Call) int {
 
 // CallListFilterView holds the UI filter state.
 type CallListFilterView struct {
-	Status string
-	Query  string
+	Status     string
+	Query      string
+	Sentiment  string
+	Intent     string
+	Spam       string // "true" for spam only, "false" to hide spam
+	Tag        string // Comma-separated call tags
+	QuoteTag   string // Comma-separated quote tags
+	Transcript string
+	Sort       string   // "oldest", or empty for newest first
+	Columns    []string // Columns shown, in the list's order; all when empty
+	View       string   // ID of the saved view opened, if any
+}
+
+// callListColumnLabels are the headings of the calls list's columns.
+var callListColumnLabels = map[string]string{
+	"caller":   "Caller",
+	"phone":    "Phone",
+	"status":   "Status",
+	"intent":   "Intent",
+	"duration": "Duration",
+	"cost":     "Cost",
+	"quote":    "Quote",
+	"date":     "Date",
+}
+
+// CallListColumnView is a column of the calls list and whether it is shown.
+type CallListColumnView struct {
+	Key   string
+	Label string
+	Shown bool
+}
+
+// Shows reports whether the list shows a column.
+func (v CallListFilterView) Shows(column string) bool {
+	if len(v.Columns) == 0 {
+		return true
+	}
+	for _, c := range v.Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
 }
 
-// buildCallListFilter creates a domain filter from UI inputs.
-func buildCallListFilter(status, search string) *domain.CallListFilter {
+// ColumnCount returns how many columns the list shows, counting actions.
+func (v CallListFilterView) ColumnCount() int {
+	if len(v.Columns) == 0 {
+		return len(domain.CallListColumns) + 1
+	}
+	return len(v.Columns) + 1
+}
+
+// Params returns the list's query parameters other than the page, for
+// links to other pages of the same list.
+func (v CallListFilterView) Params() template.URL {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"status":    v.Status,
+		"q":         v.Query,
+		"intent":    v.Intent,
+		"sentiment": v.Sentiment,
+		"spam":      v.Spam,
+		"tag":       v.Tag,
+		"quote_tag": v.QuoteTag,
+		"sort":      v.Sort,
+		"view":      v.View,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if len(v.Columns) > 0 {
+		query.Set("columns", strings.Join(v.Columns, ","))
+	}
+	return template.URL(query.Encode())
+}
+
+// parseCallListColumns reads the columns to show from repeated or
+// comma-separated values, ignoring unknown ones.
+func parseCallListColumns(values []string) []string {
+	selected := make(map[string]bool)
+	for _, value := range values {
+		for _, column := range strings.Split(value, ",") {
+			selected[strings.TrimSpace(column)] = true
+		}
+	}
+	var columns []string
+	for _, column := range domain.CallListColumns {
+		if selected[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// callListColumnViews lists the columns the list can show for choosing
+// which are shown.
+func callListColumnViews(view CallListFilterView) []CallListColumnView {
+	columns := make([]CallListColumnView, 0, len(domain.CallListColumns))
+	for _, column := range domain.CallListColumns {
+		columns = append(columns, CallListColumnView{
+			Key:   column,
+			Label: callListColumnLabels[column],
+			Shown: view.Shows(column),
+		})
+	}
+	return columns
+}
+
+// TranscriptMatchView is a transcript search result prepared for rendering.
+type TranscriptMatchView struct {
+	*service.TranscriptSearchResult
+	SnippetHTML template.HTML
+}
+
+// buildCallListFilter creates a domain filter from UI inputs. Unknown
+// values are ignored.
+func buildCallListFilter(view CallListFilterView) *domain.CallListFilter {
 	var filter domain.CallListFilter
 
-	if status != "" {
+	if status := view.Status; status != "" {
 		switch domain.CallStatus(status) {
 		case domain.CallStatusPending,
 			domain.CallStatusInProgress,
@@ -250,11 +767,26 @@ func buildCallListFilter(status, search string) *domain.CallListFilter {
 		}
 	}
 
-	if strings.TrimSpace(search) != "" {
-		filter.Search = search
+	if strings.TrimSpace(view.Query) != "" {
+		filter.Search = view.Query
+	}
+	if view.Sentiment != "" {
+		filter.Sentiment, _ = domain.ParseCallSentiment(view.Sentiment)
+	}
+	if view.Intent != "" {
+		filter.Intent, _ = domain.ParseCallIntent(view.Intent)
+	}
+	if spam, err := strconv.ParseBool(view.Spam); err == nil {
+		filter.Spam = &spam
+	}
+	filter.Tags, _ = domain.ParseTagNames([]string{view.Tag})
+	filter.QuoteTags, _ = domain.ParseTagNames([]string{view.QuoteTag})
+	if view.Sort == string(domain.CallSortOldest) {
+		filter.Sort = domain.CallSortOldest
 	}
 
-	if filter.Status == nil && strings.TrimSpace(filter.Search) == "" {
+	if filter.Status == nil && strings.TrimSpace(filter.Search) == "" && filter.Sentiment == "" && filter.Intent == "" && filter.Spam == nil &&
+		len(filter.Tags) == 0 && len(filter.QuoteTags) == 0 && filter.Sort == "" {
 		return nil
 	}
 	return &filter
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// maxContactListSize is the largest contact list upload accepted.
+const maxContactListSize = 5 << 20 // 5MB
+
+// campaignContactsPageSize is the number of contacts shown on the campaign detail page.
+const campaignContactsPageSize = 100
+
+// CampaignHandler serves the outbound call campaign pages.
+type CampaignHandler struct {
+	*BaseHandler
+	campaignService *service.CampaignService
+	promptService   *service.PromptService
+}
+
+// CampaignHandlerConfig holds configuration for CampaignHandler.
+type CampaignHandlerConfig struct {
+	Base            BaseHandlerConfig
+	CampaignService *service.CampaignService
+	PromptService   *service.PromptService
+}
+
+// NewCampaignHandler creates a new CampaignHandler with all required dependencies.
+func NewCampaignHandler(cfg CampaignHandlerConfig) *CampaignHandler {
+	if cfg.CampaignService == nil {
+		panic("campaignService is required")
+	}
+	return &CampaignHandler{
+		BaseHandler:     NewBaseHandler(cfg.Base),
+		campaignService: cfg.CampaignService,
+		promptService:   cfg.PromptService,
+	}
+}
+
+// RegisterRoutes registers campaign routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *CampaignHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/campaigns", h.HandleCampaignsPage)
+	r.Post("/campaigns", h.HandleCampaignCreate)
+	r.Get("/campaigns/{id}", h.HandleCampaignDetail)
+	r.Post("/campaigns/{id}/pause", h.HandleCampaignPause)
+	r.Post("/campaigns/{id}/resume", h.HandleCampaignResume)
+	r.Post("/campaigns/{id}/cancel", h.HandleCampaignCancel)
+}
+
+// HandleCampaignsPage lists campaigns and shows the create form.
+func (h *CampaignHandler) HandleCampaignsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	h.renderCampaigns(w, r, user, "", "")
+}
+
+// HandleCampaignCreate handles POST to create a campaign from an uploaded contact list.
+func (h *CampaignHandler) HandleCampaignCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxContactListSize)
+	if err := r.ParseMultipartForm(maxContactListSize); err != nil {
+		h.logger.Warn("failed to parse campaign form", zap.Error(err))
+		h.renderCampaigns(w, r, user, "", "Contact list is too large or the form is invalid.")
+		return
+	}
+
+	file, _, err := r.FormFile("contacts")
+	if err != nil {
+		h.renderCampaigns(w, r, user, "", "Please choose a CSV contact list to upload.")
+		return
+	}
+	defer file.Close()
+
+	req := service.CreateCampaignRequest{
+		Name:        r.FormValue("name"),
+		WindowStart: r.FormValue("window_start"),
+		WindowEnd:   r.FormValue("window_end"),
+		Timezone:    strings.TrimSpace(r.FormValue("timezone")),
+		Contacts:    file,
+		CreatedBy:   &user.ID,
+	}
+
+	if id := r.FormValue("prompt_id"); id != "" {
+		promptID, err := uuid.Parse(id)
+		if err != nil {
+			h.renderCampaigns(w, r, user, "", "Invalid preset.")
+			return
+		}
+		req.PromptID = &promptID
+	}
+	if v := r.FormValue("calls_per_hour"); v != "" {
+		perHour, err := strconv.Atoi(v)
+		if err != nil {
+			h.renderCampaigns(w, r, user, "", "Calls per hour must be a number.")
+			return
+		}
+		req.CallsPerHour = perHour
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		if l, err := time.LoadLocation(req.Timezone); err == nil {
+			loc = l
+		}
+	}
+	if v := r.FormValue("starts_at"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02T15:04", v, loc)
+		if err != nil {
+			h.renderCampaigns(w, r, user, "", "Invalid start date.")
+			return
+		}
+		req.StartsAt = t
+	}
+	if v := r.FormValue("ends_at"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02T15:04", v, loc)
+		if err != nil {
+			h.renderCampaigns(w, r, user, "", "Invalid end date.")
+			return
+		}
+		req.EndsAt = &t
+	}
+
+	campaign, skipped, err := h.campaignService.CreateCampaign(r.Context(), req)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderCampaigns(w, r, user, "", "Failed to create campaign: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create campaign", zap.Error(err))
+		h.renderCampaigns(w, r, user, "", "Failed to create campaign.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%s?created=1&skipped=%d", campaign.ID, skipped), http.StatusSeeOther)
+}
+
+// HandleCampaignDetail shows a campaign's progress and contacts.
+func (h *CampaignHandler) HandleCampaignDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid campaign ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	campaign, err := h.campaignService.GetCampaign(ctx, id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Campaign not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get campaign", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 1 {
+		page = p
+	}
+	contacts, err := h.campaignService.ListContacts(ctx, id, campaignContactsPageSize, (page-1)*campaignContactsPageSize)
+	if err != nil {
+		h.logger.Error("failed to list campaign contacts", zap.Error(err), zap.String("id", id.String()))
+	}
+
+	var successMsg, errMsg string
+	switch {
+	case r.URL.Query().Get("created") == "1":
+		successMsg = "Campaign created."
+		if skipped, _ := strconv.Atoi(r.URL.Query().Get("skipped")); skipped > 0 {
+			successMsg = fmt.Sprintf("Campaign created. %d invalid or duplicate row(s) were skipped.", skipped)
+		}
+	case r.URL.Query().Get("updated") == "1":
+		successMsg = "Campaign updated."
+	case r.URL.Query().Get("error") == "1":
+		errMsg = "That action is not available for the campaign's current status."
+	}
+
+	var promptName string
+	if campaign.PromptID != nil && h.promptService != nil {
+		if prompt, err := h.promptService.GetPrompt(ctx, *campaign.PromptID); err == nil {
+			promptName = prompt.Name
+		}
+	}
+
+	totalPages := (campaign.Stats.Total + campaignContactsPageSize - 1) / campaignContactsPageSize
+	var prevPage, nextPage int
+	if page > 1 {
+		prevPage = page - 1
+	}
+	if page < totalPages {
+		nextPage = page + 1
+	}
+
+	h.RenderTemplate(w, r, "campaign_detail", map[string]interface{}{
+		"Title":       "Campaign: " + campaign.Name,
+		"ActiveNav":   "campaigns",
+		"User":        user,
+		"Campaign":    campaign,
+		"PromptName":  promptName,
+		"WindowStart": domain.FormatWindowMinute(campaign.WindowStartMinute),
+		"WindowEnd":   domain.FormatWindowMinute(campaign.WindowEndMinute),
+		"Contacts":    contacts,
+		"Page":        page,
+		"TotalPages":  totalPages,
+		"PrevPage":    prevPage,
+		"NextPage":    nextPage,
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// HandleCampaignPause handles POST to pause a campaign.
+func (h *CampaignHandler) HandleCampaignPause(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.campaignService.PauseCampaign)
+}
+
+// HandleCampaignResume handles POST to resume a paused campaign.
+func (h *CampaignHandler) HandleCampaignResume(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.campaignService.ResumeCampaign)
+}
+
+// HandleCampaignCancel handles POST to cancel a campaign.
+func (h *CampaignHandler) HandleCampaignCancel(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.campaignService.CancelCampaign)
+}
+
+func (h *CampaignHandler) handleTransition(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, id uuid.UUID) (*domain.Campaign, error)) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid campaign ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := apply(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Campaign not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Warn("failed to update campaign", zap.String("id", id.String()), zap.Error(err))
+		http.Redirect(w, r, fmt.Sprintf("/campaigns/%s?error=1", id), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// renderCampaigns renders the campaign list page.
+func (h *CampaignHandler) renderCampaigns(w http.ResponseWriter, r *http.Request, user *domain.User, successMsg, errMsg string) {
+	ctx := r.Context()
+
+	campaigns, err := h.campaignService.ListCampaigns(ctx, 50)
+	if err != nil {
+		h.logger.Error("failed to list campaigns", zap.Error(err))
+		if errMsg == "" {
+			errMsg = "Failed to load campaigns"
+		}
+	}
+
+	var prompts []*domain.Prompt
+	if h.promptService != nil {
+		prompts, _, err = h.promptService.ListPrompts(ctx, 1, 100, true)
+		if err != nil {
+			h.logger.Warn("failed to list presets", zap.Error(err))
+		}
+	}
+
+	h.RenderTemplate(w, r, "campaigns", map[string]interface{}{
+		"Title":     "Campaigns",
+		"ActiveNav": "campaigns",
+		"User":      user,
+		"Campaigns": campaigns,
+		"Prompts":   prompts,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
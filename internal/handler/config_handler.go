@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/config"
+)
+
+// ConfigHandler shows the configuration the server is running with.
+type ConfigHandler struct {
+	reloader *config.Reloader
+}
+
+// NewConfigHandler creates a handler for configuration introspection.
+func NewConfigHandler(reloader *config.Reloader) *ConfigHandler {
+	return &ConfigHandler{reloader: reloader}
+}
+
+// ConfigResponse is the effective configuration with secrets masked.
+type ConfigResponse struct {
+	Settings   map[string]interface{} `json:"settings"`
+	Reloadable []string               `json:"reloadable"`
+	LoadedAt   time.Time              `json:"loaded_at"`
+	ReloadedAt *time.Time             `json:"reloaded_at,omitempty"`
+}
+
+// ServeHTTP implements http.Handler for the configuration endpoint.
+func (h *ConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "method not allowed",
+		})
+		return
+	}
+
+	loadedAt, reloadedAt := h.reloader.LoadedAt()
+	json.NewEncoder(w).Encode(ConfigResponse{
+		Settings:   h.reloader.Current().Redacted(),
+		Reloadable: config.ReloadableSettings,
+		LoadedAt:   loadedAt,
+		ReloadedAt: reloadedAt,
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ConversationHandler serves the inbox of customer text and WhatsApp
+// conversations.
+type ConversationHandler struct {
+	*BaseHandler
+	conversations *service.ConversationService
+}
+
+// ConversationHandlerConfig holds configuration for ConversationHandler.
+type ConversationHandlerConfig struct {
+	Base          BaseHandlerConfig
+	Conversations *service.ConversationService
+}
+
+// NewConversationHandler creates a new ConversationHandler with all required dependencies.
+func NewConversationHandler(cfg ConversationHandlerConfig) *ConversationHandler {
+	if cfg.Conversations == nil {
+		panic("conversationService is required")
+	}
+	return &ConversationHandler{
+		BaseHandler:   NewBaseHandler(cfg.Base),
+		conversations: cfg.Conversations,
+	}
+}
+
+// RegisterRoutes registers inbox routes on the router.
+func (h *ConversationHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/inbox", h.HandleInboxPage)
+}
+
+// HandleInboxPage lists the latest message of each conversation, newest
+// first.
+func (h *ConversationHandler) HandleInboxPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var errMsg string
+	messages, err := h.conversations.ListInbox(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list conversations", zap.Error(err))
+		errMsg = "Failed to load conversations"
+	}
+
+	h.RenderTemplate(w, r, "inbox", map[string]interface{}{
+		"Title":     "Inbox",
+		"ActiveNav": "inbox",
+		"User":      user,
+		"Messages":  messages,
+		"WhatsApp":  h.conversations.WhatsAppEnabled(),
+		"Error":     errMsg,
+	})
+}
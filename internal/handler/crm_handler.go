@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/crm"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+const (
+	// crmBlankRows is the number of empty field rows offered for contacts
+	// and deals on the mapping form.
+	crmBlankRows = 3
+
+	// crmListLimit is the number of syncs shown in each list.
+	crmListLimit = 50
+)
+
+// CRMHandler serves the CRM field mapping and sync error queue admin page.
+type CRMHandler struct {
+	*BaseHandler
+	crmService *service.CRMService
+}
+
+// CRMHandlerConfig holds configuration for CRMHandler.
+type CRMHandlerConfig struct {
+	Base       BaseHandlerConfig
+	CRMService *service.CRMService // Optional; nil shows how to configure a CRM
+}
+
+// crmFieldRow is one source field to CRM field row on the mapping form.
+type crmFieldRow struct {
+	Source string
+	Field  string
+}
+
+// crmStageRow is the deal stage for one quote status on the mapping form.
+type crmStageRow struct {
+	Status  string
+	Stage   string
+	Default string
+}
+
+// NewCRMHandler creates a new CRMHandler with all required dependencies.
+func NewCRMHandler(cfg CRMHandlerConfig) *CRMHandler {
+	return &CRMHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		crmService:  cfg.CRMService,
+	}
+}
+
+// RegisterRoutes registers CRM routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *CRMHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/crm", h.HandleCRMPage)
+	r.With(h.requireService).Post("/crm/mapping", h.HandleSaveMapping)
+	r.With(h.requireService).Post("/crm/syncs/{callID}/retry", h.HandleRetrySync)
+}
+
+// requireService rejects requests when no CRM is configured.
+func (h *CRMHandler) requireService(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.crmService == nil {
+			http.Error(w, "CRM sync is not configured", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleCRMPage shows the field mapping, the sync error queue and recent
+// syncs.
+func (h *CRMHandler) HandleCRMPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("saved") == "1":
+		successMsg = "Mapping saved."
+	case r.URL.Query().Get("retried") == "1":
+		successMsg = "Sync queued for retry."
+	}
+	if h.crmService == nil {
+		h.RenderTemplate(w, r, "crm", map[string]interface{}{
+			"Title":     "CRM",
+			"ActiveNav": "crm",
+			"User":      user,
+		})
+		return
+	}
+	h.renderCRMPage(w, r, nil, successMsg, "")
+}
+
+// HandleSaveMapping handles POST to save the field mapping and deal stages.
+func (h *CRMHandler) HandleSaveMapping(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderCRMPage(w, r, nil, "", "Invalid form submission.")
+		return
+	}
+
+	mapping := &domain.CRMFieldMapping{
+		Contact: crmFormFields(r.Form["contact_source"], r.Form["contact_field"]),
+		Deal:    crmFormFields(r.Form["deal_source"], r.Form["deal_field"]),
+		Stages:  make(map[domain.QuoteStatus]string),
+	}
+	for _, status := range domain.QuoteStatuses {
+		mapping.Stages[status] = r.FormValue("stage_" + string(status))
+	}
+
+	if err := h.crmService.SaveMapping(r.Context(), mapping); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderCRMPage(w, r, mapping, "", "Failed to save mapping: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to save crm field mapping", zap.Error(err))
+		h.renderCRMPage(w, r, mapping, "", "Failed to save mapping.")
+		return
+	}
+
+	http.Redirect(w, r, "/crm?saved=1", http.StatusSeeOther)
+}
+
+// HandleRetrySync queues a sync from the error queue for another round of
+// attempts.
+func (h *CRMHandler) HandleRetrySync(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	callID, err := uuid.Parse(chi.URLParam(r, "callID"))
+	if err != nil {
+		h.renderCRMPage(w, r, nil, "", "Invalid call ID.")
+		return
+	}
+
+	if _, err := h.crmService.RetrySync(r.Context(), callID); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderCRMPage(w, r, nil, "", "Failed to retry: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to retry crm sync", zap.String("call_id", callID.String()), zap.Error(err))
+		h.renderCRMPage(w, r, nil, "", "Failed to retry the sync.")
+		return
+	}
+
+	http.Redirect(w, r, "/crm?retried=1", http.StatusSeeOther)
+}
+
+// renderCRMPage renders the CRM page. mapping is the submitted mapping to
+// redisplay, or nil to show the stored one.
+func (h *CRMHandler) renderCRMPage(w http.ResponseWriter, r *http.Request, mapping *domain.CRMFieldMapping, successMsg, errMsg string) {
+	ctx := r.Context()
+	var loadErr string
+
+	if mapping == nil {
+		var err error
+		mapping, err = h.crmService.Mapping(ctx)
+		if err != nil {
+			h.logger.Error("failed to get crm field mapping", zap.Error(err))
+			loadErr = "Failed to load the field mapping"
+			mapping = &domain.CRMFieldMapping{}
+		}
+	}
+	failed, err := h.crmService.ListSyncs(ctx, domain.CRMSyncFailed, crmListLimit)
+	if err != nil {
+		h.logger.Error("failed to list failed crm syncs", zap.Error(err))
+		loadErr = "Failed to load the sync error queue"
+	}
+	recent, err := h.crmService.ListSyncs(ctx, "", crmListLimit)
+	if err != nil {
+		h.logger.Error("failed to list crm syncs", zap.Error(err))
+		loadErr = "Failed to load recent syncs"
+	}
+	if errMsg == "" {
+		errMsg = loadErr
+	}
+
+	defaults := crm.DefaultDealStages(h.crmService.Provider())
+	stages := make([]crmStageRow, 0, len(domain.QuoteStatuses))
+	for _, status := range domain.QuoteStatuses {
+		stages = append(stages, crmStageRow{
+			Status:  string(status),
+			Stage:   mapping.Stages[status],
+			Default: defaults[string(status)],
+		})
+	}
+
+	h.RenderTemplate(w, r, "crm", map[string]interface{}{
+		"Title":       "CRM",
+		"ActiveNav":   "crm",
+		"User":        GetUserFromContext(ctx),
+		"Enabled":     true,
+		"Provider":    crm.DisplayName(h.crmService.Provider()),
+		"Sources":     domain.CRMSourceFields,
+		"ContactRows": crmFieldRows(mapping.Contact),
+		"DealRows":    crmFieldRows(mapping.Deal),
+		"StageRows":   stages,
+		"Failed":      failed,
+		"Syncs":       recent,
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// crmFormFields reads parallel source and CRM field inputs, skipping rows
+// without a CRM field.
+func crmFormFields(sources, fields []string) map[string]string {
+	mapped := make(map[string]string)
+	for i := range sources {
+		if field := formIndex(fields, i); strings.TrimSpace(field) != "" {
+			mapped[sources[i]] = field
+		}
+	}
+	return mapped
+}
+
+// crmFieldRows lists the mapped fields in source field order, then blank
+// rows to fill in.
+func crmFieldRows(fields map[string]string) []crmFieldRow {
+	rows := make([]crmFieldRow, 0, len(fields)+crmBlankRows)
+	for source, field := range fields {
+		rows = append(rows, crmFieldRow{Source: source, Field: field})
+	}
+	order := make(map[string]int, len(domain.CRMSourceFields))
+	for i, source := range domain.CRMSourceFields {
+		order[source] = i
+	}
+	sort.Slice(rows, func(i, j int) bool { return order[rows[i].Source] < order[rows[j].Source] })
+
+	for i := 0; i < crmBlankRows; i++ {
+		rows = append(rows, crmFieldRow{})
+	}
+	return rows
+}
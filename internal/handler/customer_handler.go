@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// customersPageSize is the number of customers shown per page.
+const customersPageSize = 25
+
+// CustomerHandler serves the customer pages.
+type CustomerHandler struct {
+	*BaseHandler
+	customerService *service.CustomerService
+	conversations   *service.ConversationService
+}
+
+// CustomerHandlerConfig holds configuration for CustomerHandler.
+type CustomerHandlerConfig struct {
+	Base            BaseHandlerConfig
+	CustomerService *service.CustomerService
+	Conversations   *service.ConversationService // Optional: shows messages and WhatsApp settings
+}
+
+// NewCustomerHandler creates a new CustomerHandler with all required dependencies.
+func NewCustomerHandler(cfg CustomerHandlerConfig) *CustomerHandler {
+	if cfg.CustomerService == nil {
+		panic("customerService is required")
+	}
+	return &CustomerHandler{
+		BaseHandler:     NewBaseHandler(cfg.Base),
+		customerService: cfg.CustomerService,
+		conversations:   cfg.Conversations,
+	}
+}
+
+// RegisterRoutes registers customer routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *CustomerHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/customers", h.HandleCustomersPage)
+	r.Get("/customers/{id}", h.HandleCustomerDetail)
+	r.Post("/customers/{id}", h.HandleCustomerUpdate)
+	r.Post("/customers/{id}/delete", h.HandleCustomerDelete)
+}
+
+// HandleCustomersPage lists customers with an optional search.
+func (h *CustomerHandler) HandleCustomersPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 1 {
+		page = p
+	}
+	search := strings.TrimSpace(r.URL.Query().Get("q"))
+	tag := strings.TrimSpace(r.URL.Query().Get("tag"))
+
+	var errMsg string
+	var customers []*domain.Customer
+	var total int
+	tags, err := domain.ParseTagNames([]string{tag})
+	if err != nil {
+		errMsg = err.Error()
+	} else {
+		customers, total, err = h.customerService.ListCustomers(r.Context(), &domain.CustomerListFilter{Search: search, Tags: tags}, page, customersPageSize)
+		if err != nil {
+			h.logger.Error("failed to list customers", zap.Error(err))
+			errMsg = "Failed to load customers"
+		}
+	}
+
+	totalPages := (total + customersPageSize - 1) / customersPageSize
+	var prevPage, nextPage int
+	if page > 1 {
+		prevPage = page - 1
+	}
+	if page < totalPages {
+		nextPage = page + 1
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("deleted") == "1" {
+		successMsg = "Customer deleted."
+	}
+
+	h.RenderTemplate(w, r, "customers", map[string]interface{}{
+		"Title":      "Customers",
+		"ActiveNav":  "customers",
+		"User":       user,
+		"Customers":  customers,
+		"Total":      total,
+		"Query":      search,
+		"Tag":        tag,
+		"Page":       page,
+		"TotalPages": totalPages,
+		"PrevPage":   prevPage,
+		"NextPage":   nextPage,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	})
+}
+
+// HandleCustomerDetail shows a customer with their call and quote history.
+func (h *CustomerHandler) HandleCustomerDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("updated") == "1" {
+		successMsg = "Customer updated."
+	}
+	h.renderCustomerDetail(w, r, id, successMsg, "")
+}
+
+// HandleCustomerUpdate handles POST to edit a customer's details.
+func (h *CustomerHandler) HandleCustomerUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderCustomerDetail(w, r, id, "", "Invalid form submission.")
+		return
+	}
+
+	field := func(name string) *string {
+		v := r.FormValue(name)
+		return &v
+	}
+	optedOut := r.FormValue("opted_out") == "on"
+	req := &service.UpdateCustomerRequest{
+		PhoneNumber: field("phone_number"),
+		Name:        field("name"),
+		Email:       field("email"),
+		Company:     field("company"),
+		Address:     field("address"),
+		Notes:       field("notes"),
+		OptedOut:    &optedOut,
+	}
+	if r.Form.Has("preferred_channel") {
+		req.PreferredChannel = field("preferred_channel")
+	}
+
+	customer, err := h.customerService.UpdateCustomer(r.Context(), id, req)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Customer not found", http.StatusNotFound)
+			return
+		}
+		if apperrors.IsUserError(err) {
+			h.renderCustomerDetail(w, r, id, "", "Failed to update customer: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to update customer", zap.Error(err), zap.String("id", id.String()))
+		h.renderCustomerDetail(w, r, id, "", "Failed to update customer.")
+		return
+	}
+
+	if h.conversations != nil && h.conversations.WhatsAppEnabled() {
+		optedIn := r.FormValue("whatsapp_opt_in") == "on"
+		if err := h.conversations.SetWhatsAppOptIn(r.Context(), customer.PhoneNumber, optedIn, domain.WhatsAppOptInSourceStaff); err != nil {
+			h.logger.Error("failed to update WhatsApp opt-in", zap.Error(err), zap.String("id", id.String()))
+			h.renderCustomerDetail(w, r, id, "", "Customer updated, but the WhatsApp opt-in could not be saved.")
+			return
+		}
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/customers/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandleCustomerDelete handles POST to delete a customer.
+func (h *CustomerHandler) HandleCustomerDelete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.customerService.DeleteCustomer(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Customer not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to delete customer", zap.Error(err), zap.String("id", id.String()))
+		h.renderCustomerDetail(w, r, id, "", "Failed to delete customer.")
+		return
+	}
+
+	http.Redirect(w, r, "/customers?deleted=1", http.StatusSeeOther)
+}
+
+// renderCustomerDetail renders the customer detail page.
+func (h *CustomerHandler) renderCustomerDetail(w http.ResponseWriter, r *http.Request, id uuid.UUID, successMsg, errMsg string) {
+	history, err := h.customerService.GetHistory(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Customer not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get customer history", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":      "Customer: " + history.Customer.DisplayName(),
+		"ActiveNav":  "customers",
+		"User":       GetUserFromContext(r.Context()),
+		"Customer":   history.Customer,
+		"Calls":      history.Calls,
+		"TotalCalls": history.TotalCalls,
+		"Quotes":     history.Quotes,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	}
+	if h.conversations != nil {
+		phone := history.Customer.PhoneNumber
+		messages, err := h.conversations.ListConversation(r.Context(), phone)
+		if err != nil {
+			h.logger.Error("failed to list customer messages", zap.Error(err), zap.String("id", id.String()))
+		}
+		data["Conversations"] = true
+		data["Messages"] = messages
+		if h.conversations.WhatsAppEnabled() {
+			optIn, err := h.conversations.GetWhatsAppOptIn(r.Context(), phone)
+			if err != nil {
+				h.logger.Error("failed to get WhatsApp opt-in", zap.Error(err), zap.String("id", id.String()))
+			}
+			data["WhatsApp"] = true
+			data["WhatsAppOptIn"] = optIn
+			data["WhatsAppOptedIn"] = optIn.IsActive()
+		}
+	}
+
+	h.RenderTemplate(w, r, "customer_detail", data)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Drainer stops the server taking on new work ahead of shutdown.
+// shutdown.Coordinator implements it.
+type Drainer interface {
+	Drain(ctx context.Context) error
+	DrainedAt() *time.Time
+}
+
+// DrainHandler lets an orchestrator drain the server before stopping it.
+type DrainHandler struct {
+	drainer Drainer
+	logger  *zap.Logger
+}
+
+// NewDrainHandler creates a handler for draining the server.
+func NewDrainHandler(drainer Drainer, logger *zap.Logger) *DrainHandler {
+	return &DrainHandler{
+		drainer: drainer,
+		logger:  logger,
+	}
+}
+
+// DrainResponse reports whether the server is draining.
+type DrainResponse struct {
+	Draining  bool       `json:"draining"`
+	DrainedAt *time.Time `json:"drained_at,omitempty"`
+}
+
+// ServeHTTP implements http.Handler for the drain endpoint. GET reports the
+// state; POST starts draining, and is safe to repeat.
+func (h *DrainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		fields := []zap.Field{zap.String("remote_addr", r.RemoteAddr)}
+		if user := GetUserFromContext(r.Context()); user != nil {
+			fields = append(fields, zap.String("user", user.Email))
+		}
+		h.logger.Warn("drain requested", fields...)
+
+		if err := h.drainer.Drain(r.Context()); err != nil {
+			// Draining has started regardless; report the step that failed
+			h.logger.Error("drain step failed", zap.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": "draining started, but a step failed: " + err.Error(),
+			})
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "method not allowed",
+		})
+		return
+	}
+
+	drainedAt := h.drainer.DrainedAt()
+	json.NewEncoder(w).Encode(DrainResponse{
+		Draining:  drainedAt != nil,
+		DrainedAt: drainedAt,
+	})
+}
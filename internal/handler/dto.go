@@ -17,9 +17,12 @@ type BasePageData struct {
 
 // LoginPageData contains data for the login page template.
 type LoginPageData struct {
-	Title string
-	Error string
-	Email string
+	Title         string
+	Error         string
+	Success       string
+	Email         string
+	PasswordReset bool   // Show the forgot password link
+	SSOName       string // Identity provider on the single sign-on button; empty hides it
 }
 
 // DashboardPageData contains data for the dashboard template.
@@ -28,6 +31,19 @@ type DashboardPageData struct {
 	Calls         []*domain.Call
 	TotalCalls    int
 	PendingQuotes int
+	ShowCallbacks bool
+	Callbacks     []*domain.Callback // Upcoming callbacks, soonest first
+	TagViews      []TagViewLink      // Saved tag views, shared first
+}
+
+// TagViewLink is a saved tag view on the dashboard, linking to the list it
+// filters.
+type TagViewLink struct {
+	Name   string
+	Tags   []string
+	Count  int
+	Path   string
+	Shared bool
 }
 
 // CallsPageData contains data for the calls list template.
@@ -39,12 +55,29 @@ type CallsPageData struct {
 	PageSize   int
 	TotalPages int
 	Filter     CallListFilterView
+	Columns    []CallListColumnView
+
+	// Saved views of the list, and the one opened if any
+	ShowSavedViews bool
+	SavedViews     []*domain.SavedView
+	ActiveView     *domain.SavedView
+
+	// Multi-select bulk actions, and the users calls can be assigned to
+	ShowBulk  bool
+	Assignees []*domain.User
+
+	// Transcript search results, set instead of Calls when searching transcripts.
+	TranscriptMatches []TranscriptMatchView
+	SearchError       string
 }
 
 // CallDetailPageData contains data for the call detail template.
 type CallDetailPageData struct {
 	BasePageData
-	Call *domain.Call
+	Call              *domain.Call
+	Disposition       string                   // The call's disposition; empty if none
+	DispositionSource string                   // Where the disposition came from
+	Dispositions      []domain.CallDisposition // Choices for correcting the disposition
 }
 
 // SettingsPageData contains data for the settings template.
@@ -146,9 +179,13 @@ func (d *LoginPageData) ToMap() map[string]interface{} {
 	if d.Error != "" {
 		m["Error"] = d.Error
 	}
+	if d.Success != "" {
+		m["Success"] = d.Success
+	}
 	if d.Email != "" {
 		m["Email"] = d.Email
 	}
+	m["PasswordReset"] = d.PasswordReset
 	return m
 }
 
@@ -158,6 +195,9 @@ func (d *DashboardPageData) ToMap() map[string]interface{} {
 	m["Calls"] = d.Calls
 	m["TotalCalls"] = d.TotalCalls
 	m["PendingQuotes"] = d.PendingQuotes
+	m["ShowCallbacks"] = d.ShowCallbacks
+	m["Callbacks"] = d.Callbacks
+	m["TagViews"] = d.TagViews
 	return m
 }
 
@@ -170,6 +210,14 @@ func (d *CallsPageData) ToMap() map[string]interface{} {
 	m["PageSize"] = d.PageSize
 	m["TotalPages"] = d.TotalPages
 	m["Filter"] = d.Filter
+	m["Columns"] = d.Columns
+	m["ShowSavedViews"] = d.ShowSavedViews
+	m["SavedViews"] = d.SavedViews
+	m["ActiveView"] = d.ActiveView
+	m["ShowBulk"] = d.ShowBulk
+	m["Assignees"] = d.Assignees
+	m["TranscriptMatches"] = d.TranscriptMatches
+	m["SearchError"] = d.SearchError
 	return m
 }
 
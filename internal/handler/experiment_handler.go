@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// experimentFormVariants is the number of variant rows on the create form.
+const experimentFormVariants = 4
+
+// ExperimentHandler serves the prompt experiment admin pages.
+type ExperimentHandler struct {
+	*BaseHandler
+	experimentService *service.ExperimentService
+	promptService     *service.PromptService
+}
+
+// ExperimentHandlerConfig holds configuration for ExperimentHandler.
+type ExperimentHandlerConfig struct {
+	Base              BaseHandlerConfig
+	ExperimentService *service.ExperimentService
+	PromptService     *service.PromptService
+}
+
+// NewExperimentHandler creates a new ExperimentHandler with all required dependencies.
+func NewExperimentHandler(cfg ExperimentHandlerConfig) *ExperimentHandler {
+	if cfg.ExperimentService == nil {
+		panic("experimentService is required")
+	}
+	return &ExperimentHandler{
+		BaseHandler:       NewBaseHandler(cfg.Base),
+		experimentService: cfg.ExperimentService,
+		promptService:     cfg.PromptService,
+	}
+}
+
+// RegisterRoutes registers experiment routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *ExperimentHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/experiments", h.HandleExperimentsPage)
+	r.Post("/experiments", h.HandleExperimentCreate)
+	r.Get("/experiments/{id}", h.HandleExperimentDetail)
+	r.Post("/experiments/{id}/start", h.HandleExperimentStart)
+	r.Post("/experiments/{id}/stop", h.HandleExperimentStop)
+	r.Post("/experiments/{id}/delete", h.HandleExperimentDelete)
+}
+
+// HandleExperimentsPage lists experiments and shows the create form.
+func (h *ExperimentHandler) HandleExperimentsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("deleted") == "1" {
+		successMsg = "Experiment deleted."
+	}
+	h.renderExperiments(w, r, user, successMsg, "")
+}
+
+// HandleExperimentCreate handles POST to create a draft experiment.
+func (h *ExperimentHandler) HandleExperimentCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderExperiments(w, r, user, "", "Invalid form submission.")
+		return
+	}
+
+	req := &service.CreateExperimentRequest{
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+		Direction:   domain.ExperimentDirection(r.FormValue("direction")),
+		PhoneNumber: r.FormValue("phone_number"),
+		CreatedBy:   &user.ID,
+	}
+
+	// Variant rows left without a preset are ignored
+	names := r.Form["variant_name"]
+	weights := r.Form["variant_weight"]
+	for i, id := range r.Form["variant_prompt_id"] {
+		if id == "" {
+			continue
+		}
+		promptID, err := uuid.Parse(id)
+		if err != nil {
+			h.renderExperiments(w, r, user, "", "Invalid preset.")
+			return
+		}
+		variant := service.ExperimentVariantRequest{PromptID: promptID}
+		if i < len(names) {
+			variant.Name = names[i]
+		}
+		if i < len(weights) {
+			weight, err := strconv.Atoi(strings.TrimSpace(weights[i]))
+			if err != nil {
+				h.renderExperiments(w, r, user, "", "Variant weights must be whole percentages.")
+				return
+			}
+			variant.Weight = weight
+		}
+		req.Variants = append(req.Variants, variant)
+	}
+
+	experiment, err := h.experimentService.CreateExperiment(r.Context(), req)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderExperiments(w, r, user, "", "Failed to create experiment: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create experiment", zap.Error(err))
+		h.renderExperiments(w, r, user, "", "Failed to create experiment.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/experiments/%s?created=1", experiment.ID), http.StatusSeeOther)
+}
+
+// HandleExperimentDetail shows an experiment's variants and results.
+func (h *ExperimentHandler) HandleExperimentDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid experiment ID", http.StatusBadRequest)
+		return
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("created") == "1":
+		successMsg = "Experiment created. Start it to begin splitting calls."
+	case r.URL.Query().Get("updated") == "1":
+		successMsg = "Experiment updated."
+	}
+	h.renderExperimentDetail(w, r, user, id, successMsg, "")
+}
+
+// HandleExperimentStart handles POST to start a draft experiment.
+func (h *ExperimentHandler) HandleExperimentStart(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.experimentService.StartExperiment)
+}
+
+// HandleExperimentStop handles POST to stop a running experiment.
+func (h *ExperimentHandler) HandleExperimentStop(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, h.experimentService.StopExperiment)
+}
+
+// HandleExperimentDelete handles POST to delete an experiment.
+func (h *ExperimentHandler) HandleExperimentDelete(w http.ResponseWriter, r *http.Request) {
+	h.handleTransition(w, r, func(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+		return nil, h.experimentService.DeleteExperiment(ctx, id)
+	})
+}
+
+func (h *ExperimentHandler) handleTransition(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, id uuid.UUID) (*domain.Experiment, error)) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid experiment ID", http.StatusBadRequest)
+		return
+	}
+
+	experiment, err := apply(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Experiment not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Warn("failed to update experiment", zap.String("id", id.String()), zap.Error(err))
+		errMsg := "The experiment could not be updated."
+		if apperrors.IsUserError(err) {
+			errMsg = err.Error()
+		}
+		h.renderExperimentDetail(w, r, user, id, "", errMsg)
+		return
+	}
+
+	if experiment == nil {
+		http.Redirect(w, r, "/experiments?deleted=1", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/experiments/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// renderExperimentDetail renders an experiment's variants and results.
+func (h *ExperimentHandler) renderExperimentDetail(w http.ResponseWriter, r *http.Request, user *domain.User, id uuid.UUID, successMsg, errMsg string) {
+	ctx := r.Context()
+	results, err := h.experimentService.GetResults(ctx, id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Experiment not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get experiment results", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.RenderTemplate(w, r, "experiment_detail", map[string]interface{}{
+		"Title":             "Experiment: " + results.Experiment.Name,
+		"ActiveNav":         "experiments",
+		"User":              user,
+		"Experiment":        results.Experiment,
+		"Results":           results,
+		"Dispositions":      results.DispositionNames(),
+		"PromptNames":       h.promptNames(ctx),
+		"SignificanceLevel": domain.ExperimentSignificanceLevel,
+		"Success":           successMsg,
+		"Error":             errMsg,
+	})
+}
+
+// renderExperiments renders the experiment list page.
+func (h *ExperimentHandler) renderExperiments(w http.ResponseWriter, r *http.Request, user *domain.User, successMsg, errMsg string) {
+	ctx := r.Context()
+
+	experiments, err := h.experimentService.ListExperiments(ctx, 50)
+	if err != nil {
+		h.logger.Error("failed to list experiments", zap.Error(err))
+		if errMsg == "" {
+			errMsg = "Failed to load experiments"
+		}
+	}
+
+	var prompts []*domain.Prompt
+	if h.promptService != nil {
+		prompts, _, err = h.promptService.ListPrompts(ctx, 1, 100, true)
+		if err != nil {
+			h.logger.Warn("failed to list presets", zap.Error(err))
+		}
+	}
+
+	h.RenderTemplate(w, r, "experiments", map[string]interface{}{
+		"Title":        "Experiments",
+		"ActiveNav":    "experiments",
+		"User":         user,
+		"Experiments":  experiments,
+		"Prompts":      prompts,
+		"VariantSlots": make([]struct{}, experimentFormVariants),
+		"Success":      successMsg,
+		"Error":        errMsg,
+	})
+}
+
+// promptNames maps prompt IDs to names for display.
+func (h *ExperimentHandler) promptNames(ctx context.Context) map[string]string {
+	names := make(map[string]string)
+	if h.promptService == nil {
+		return names
+	}
+	prompts, _, err := h.promptService.ListPrompts(ctx, 1, 100, false)
+	if err != nil {
+		h.logger.Warn("failed to list presets", zap.Error(err))
+		return names
+	}
+	for _, p := range prompts {
+		names[p.ID.String()] = p.Name
+	}
+	return names
+}
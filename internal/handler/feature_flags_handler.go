@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// FeatureFlagHandler serves the feature flag admin page.
+type FeatureFlagHandler struct {
+	*BaseHandler
+	flagService *service.FeatureFlagService
+	userService *service.UserService
+}
+
+// FeatureFlagHandlerConfig holds configuration for FeatureFlagHandler.
+type FeatureFlagHandlerConfig struct {
+	Base        BaseHandlerConfig
+	FlagService *service.FeatureFlagService
+	UserService *service.UserService
+}
+
+// NewFeatureFlagHandler creates a new FeatureFlagHandler with all required dependencies.
+func NewFeatureFlagHandler(cfg FeatureFlagHandlerConfig) *FeatureFlagHandler {
+	if cfg.FlagService == nil {
+		panic("flagService is required")
+	}
+	if cfg.UserService == nil {
+		panic("userService is required")
+	}
+	return &FeatureFlagHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		flagService: cfg.FlagService,
+		userService: cfg.UserService,
+	}
+}
+
+// RegisterRoutes registers feature flag routes on the router.
+// Note: These routes require authentication and admin middleware to be
+// applied by the caller.
+func (h *FeatureFlagHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/flags", h.HandleFlagsPage)
+	r.Post("/admin/flags", h.HandleFlagCreate)
+	r.Post("/admin/flags/{key}", h.HandleFlagUpdate)
+	r.Post("/admin/flags/{key}/toggle", h.HandleFlagToggle)
+	r.Post("/admin/flags/{key}/delete", h.HandleFlagDelete)
+}
+
+// HandleFlagsPage lists feature flags with the form to add one.
+func (h *FeatureFlagHandler) HandleFlagsPage(w http.ResponseWriter, r *http.Request) {
+	var successMsg string
+	switch r.URL.Query().Get("done") {
+	case "created":
+		successMsg = "Flag created."
+	case "updated":
+		successMsg = "Flag updated."
+	case "enabled":
+		successMsg = "Flag enabled."
+	case "disabled":
+		successMsg = "Flag disabled."
+	case "deleted":
+		successMsg = "Flag deleted."
+	}
+
+	h.renderFlagsPage(w, r, successMsg, "")
+}
+
+// HandleFlagCreate creates a feature flag.
+func (h *FeatureFlagHandler) HandleFlagCreate(w http.ResponseWriter, r *http.Request) {
+	req, errMsg := parseFeatureFlagForm(r)
+	if errMsg != "" {
+		h.renderFlagsPage(w, r, "", errMsg)
+		return
+	}
+	req.Key = r.FormValue("key")
+
+	if _, err := h.flagService.CreateFlag(r.Context(), userActor(r), req); err != nil {
+		h.renderFlagsPage(w, r, "", h.flagErrorMessage("create flag", err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/flags?done=created", http.StatusSeeOther)
+}
+
+// HandleFlagUpdate replaces a feature flag's settings.
+func (h *FeatureFlagHandler) HandleFlagUpdate(w http.ResponseWriter, r *http.Request) {
+	req, errMsg := parseFeatureFlagForm(r)
+	if errMsg != "" {
+		h.renderFlagsPage(w, r, "", errMsg)
+		return
+	}
+
+	if _, err := h.flagService.UpdateFlag(r.Context(), userActor(r), chi.URLParam(r, "key"), req); err != nil {
+		h.renderFlagsPage(w, r, "", h.flagErrorMessage("update flag", err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/flags?done=updated", http.StatusSeeOther)
+}
+
+// HandleFlagToggle switches a feature flag on or off, keeping its rollout.
+func (h *FeatureFlagHandler) HandleFlagToggle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.renderFlagsPage(w, r, "", "Invalid form submission.")
+		return
+	}
+	enabled := r.FormValue("enabled") == "true"
+
+	if _, err := h.flagService.SetEnabled(r.Context(), userActor(r), chi.URLParam(r, "key"), enabled); err != nil {
+		h.renderFlagsPage(w, r, "", h.flagErrorMessage("toggle flag", err))
+		return
+	}
+
+	done := "disabled"
+	if enabled {
+		done = "enabled"
+	}
+	http.Redirect(w, r, "/admin/flags?done="+done, http.StatusSeeOther)
+}
+
+// HandleFlagDelete deletes a feature flag.
+func (h *FeatureFlagHandler) HandleFlagDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.flagService.DeleteFlag(r.Context(), userActor(r), chi.URLParam(r, "key")); err != nil {
+		h.renderFlagsPage(w, r, "", h.flagErrorMessage("delete flag", err))
+		return
+	}
+
+	http.Redirect(w, r, "/admin/flags?done=deleted", http.StatusSeeOther)
+}
+
+// parseFeatureFlagForm reads a flag's settings from a submitted form,
+// returning a message for the admin when the form is invalid.
+func parseFeatureFlagForm(r *http.Request) (*service.FeatureFlagRequest, string) {
+	if err := r.ParseForm(); err != nil {
+		return nil, "Invalid form submission."
+	}
+
+	req := &service.FeatureFlagRequest{
+		Description: r.FormValue("description"),
+		Enabled:     r.FormValue("enabled") == "on",
+	}
+	if v := r.FormValue("rollout_percent"); v != "" {
+		percent, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, "Rollout must be a whole number between 0 and 100."
+		}
+		req.RolloutPercent = percent
+	}
+	for _, v := range r.Form["user_ids"] {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, "Invalid user selected."
+		}
+		req.UserIDs = append(req.UserIDs, id)
+	}
+	return req, ""
+}
+
+// renderFlagsPage renders the flag list with the users a flag can target.
+func (h *FeatureFlagHandler) renderFlagsPage(w http.ResponseWriter, r *http.Request, successMsg, errMsg string) {
+	flags, err := h.flagService.ListFlags(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list feature flags", zap.Error(err))
+		if errMsg == "" {
+			errMsg = "Failed to load flags."
+		}
+	}
+
+	users, err := h.userService.List(r.Context(), userActor(r))
+	if err != nil {
+		h.logger.Error("failed to list users", zap.Error(err))
+	}
+	emails := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+
+	h.RenderTemplate(w, r, "feature_flags", map[string]interface{}{
+		"Title":        "Feature Flags",
+		"ActiveNav":    "flags",
+		"User":         GetUserFromContext(r.Context()),
+		"FeatureFlags": flags,
+		"Users":        users,
+		"UserEmails":   emails,
+		"Success":      successMsg,
+		"Error":        errMsg,
+	})
+}
+
+// flagErrorMessage returns a message for the admin describing err.
+func (h *FeatureFlagHandler) flagErrorMessage(action string, err error) string {
+	if apperrors.IsUserError(err) {
+		return "Failed to " + action + ": " + err.Error()
+	}
+	h.logger.Error("failed to "+action, zap.Error(err))
+	return "Failed to " + action + "."
+}
@@ -4,11 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/cache"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/health"
+	"github.com/jkindrix/quickquote/internal/jobs"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/storage"
 )
 
 // mockHealthChecker implements HealthChecker for testing
@@ -360,4 +376,390 @@ func TestBaseHandler_WriteError(t *testing.T) {
 	if resp["status"] != float64(http.StatusBadRequest) {
 		t.Errorf("expected status=%d, got %v", http.StatusBadRequest, resp["status"])
 	}
+	if resp["code"] != string(apperrors.CodeValidation) {
+		t.Errorf("expected code=%s, got %v", apperrors.CodeValidation, resp["code"])
+	}
+}
+
+func TestAPIServiceError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    apperrors.Code
+		message string
+	}{
+		{"client error", apperrors.NotFound("quote"), http.StatusNotFound, apperrors.CodeNotFound, "quote not found"},
+		{"compliance", apperrors.New(apperrors.CodeComplianceBlocked, "number is on the do-not-call list"), http.StatusUnavailableForLegalReasons, apperrors.CodeComplianceBlocked, "number is on the do-not-call list"},
+		{"circuit open", fmt.Errorf("list voices: %w", circuitbreaker.ErrCircuitOpen), http.StatusServiceUnavailable, apperrors.CodeProviderUnavailable, "failed to list voices"},
+		{"provider refusal", &bland.APIError{Message: "invalid voice"}, http.StatusBadGateway, apperrors.CodeProviderError, "failed to list voices"},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, apperrors.CodeInternal, "failed to list voices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			APIServiceError(rr, tt.err, "failed to list voices")
+
+			var resp ErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if rr.Code != tt.status || resp.Code != tt.code || resp.Message != tt.message {
+				t.Errorf("got %d %s %q, expected %d %s %q", rr.Code, resp.Code, resp.Message, tt.status, tt.code, tt.message)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	r := chi.NewRouter()
+	NewCallAPIHandler(nil, nil, zap.NewNop()).RegisterRoutes(r)
+	NewPromptAPIHandler(nil, nil, zap.NewNop()).RegisterRoutes(r)
+	NewBlandAPIHandler(nil, zap.NewNop()).RegisterRoutes(r)
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		fields []string
+	}{
+		{"malformed", "/calls", `{"phone_number":`, http.StatusBadRequest, nil},
+		{"call", "/calls", `{"phone_number":"call me","max_duration":0,"prompt_id":"abc"}`, http.StatusUnprocessableEntity, []string{"phone_number", "prompt_id", "max_duration"}},
+		{"prompt", "/prompts", `{"task":"Collect project scope","temperature":2}`, http.StatusUnprocessableEntity, []string{"name", "temperature"}},
+		{"batch", "/bland/batches", `{"calls":[{"phone_number":"+15551234567"},{}]}`, http.StatusUnprocessableEntity, []string{"calls[1].phone_number"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if rr.Code != tt.status || resp.Code != apperrors.CodeValidation {
+				t.Fatalf("got %d %s, expected %d %s", rr.Code, resp.Code, tt.status, apperrors.CodeValidation)
+			}
+			var fields []string
+			for _, fe := range resp.Errors {
+				fields = append(fields, fe.Field)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tt.fields) {
+				t.Errorf("invalid fields = %v, expected %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestHealthHandler_Dependencies(t *testing.T) {
+	monitor := health.NewMonitor(nil, zap.NewNop(), nil)
+	var dbErr error
+	monitor.Register(health.Dependency{Name: "database", Critical: true, Check: func(ctx context.Context) error { return dbErr }})
+	monitor.Register(health.Dependency{Name: "smtp", Check: func(ctx context.Context) error { return errors.New("unreachable") }})
+	monitor.CheckAll(context.Background())
+
+	h := NewHealthHandler(HealthHandlerConfig{
+		Monitor: monitor,
+		Logger:  zap.NewNop(),
+	})
+
+	rr := httptest.NewRecorder()
+	h.HandleHealth(rr, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+
+	var resp HealthResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || resp.Status != "degraded" {
+		t.Errorf("got %d %q, expected a non-critical failure to degrade", rr.Code, resp.Status)
+	}
+	if len(resp.Dependencies) != 2 || resp.Dependencies[1].Status != health.StatusUnhealthy {
+		t.Errorf("dependencies = %+v, expected smtp unhealthy", resp.Dependencies)
+	}
+
+	rr = httptest.NewRecorder()
+	h.HandleReadiness(rr, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+	if rr.Code != http.StatusOK {
+		t.Errorf("readiness = %d, expected ready despite smtp", rr.Code)
+	}
+
+	dbErr = errors.New("connection refused")
+	rr = httptest.NewRecorder()
+	h.HandleReadiness(rr, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("readiness = %d, expected not ready without the database", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	h.HandleHealth(rr, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("health = %d, expected unhealthy after the database failed", rr.Code)
+	}
+}
+
+// mockReadiness implements ReadinessState for testing
+type mockReadiness struct {
+	ready bool
+}
+
+func (m *mockReadiness) IsReady() bool {
+	return m.ready
+}
+
+func TestHealthHandler_HandleReadiness_Draining(t *testing.T) {
+	readiness := &mockReadiness{ready: true}
+	h := NewHealthHandler(HealthHandlerConfig{
+		HealthChecker: &mockHealthChecker{},
+		Readiness:     readiness,
+		Logger:        zap.NewNop(),
+	})
+
+	rr := httptest.NewRecorder()
+	h.HandleReadiness(rr, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	readiness.ready = false
+	rr = httptest.NewRecorder()
+	h.HandleReadiness(rr, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d while draining, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	// Draining doesn't make the server unhealthy
+	rr = httptest.NewRecorder()
+	h.HandleHealth(rr, httptest.NewRequest(http.MethodGet, "/health", http.NoBody))
+	var resp HealthResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || !resp.Draining {
+		t.Errorf("got %d with draining=%v, expected 200 and draining", rr.Code, resp.Draining)
+	}
+}
+
+// mockDrainer implements Drainer for testing
+type mockDrainer struct {
+	drains    int
+	drainedAt *time.Time
+}
+
+func (m *mockDrainer) Drain(ctx context.Context) error {
+	m.drains++
+	if m.drainedAt == nil {
+		now := time.Now()
+		m.drainedAt = &now
+	}
+	return nil
+}
+
+func (m *mockDrainer) DrainedAt() *time.Time {
+	return m.drainedAt
+}
+
+func TestDrainHandler(t *testing.T) {
+	drainer := &mockDrainer{}
+	h := NewDrainHandler(drainer, zap.NewNop())
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/drain", http.NoBody))
+	var resp DrainResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Draining || drainer.drains != 0 {
+		t.Errorf("GET drained the server: %+v", resp)
+	}
+
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/drain", http.NoBody))
+	resp = DrainResponse{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || !resp.Draining || resp.DrainedAt == nil || drainer.drains != 1 {
+		t.Errorf("POST got %d %+v, expected the server drained", rr.Code, resp)
+	}
+
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/admin/drain", http.NoBody))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+type mockCacheStatsProvider []cache.Stats
+
+func (m mockCacheStatsProvider) CacheStats() []cache.Stats {
+	return m
+}
+
+func TestCacheHandler(t *testing.T) {
+	h := NewCacheHandler(
+		mockCacheStatsProvider{{Name: "settings", Hits: 3}},
+		mockCacheStatsProvider{{Name: "prompts_by_id"}, {Name: "default_prompt"}},
+	)
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/cache", http.NoBody))
+	var resp CacheResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Caches) != 3 || resp.Caches[0].Name != "settings" || resp.Caches[0].Hits != 3 {
+		t.Errorf("unexpected caches %+v", resp.Caches)
+	}
+
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/cache", http.NoBody))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+// mockJobRunner implements JobRunner for testing
+type mockJobRunner struct {
+	statuses []jobs.Status
+	runs     []string
+	err      error
+}
+
+func (m *mockJobRunner) Status() []jobs.Status {
+	return m.statuses
+}
+
+func (m *mockJobRunner) RunNow(name string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.runs = append(m.runs, name)
+	return nil
+}
+
+func TestJobsHandler(t *testing.T) {
+	runner := &mockJobRunner{statuses: []jobs.Status{{Name: "session-cleanup", Schedule: "every 1h0m0s"}}}
+	r := chi.NewRouter()
+	NewJobsHandler(runner, nil, zap.NewNop()).RegisterRoutes(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/jobs", http.NoBody))
+	var resp JobsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || len(resp.Jobs) != 1 || resp.Jobs[0].Name != "session-cleanup" {
+		t.Errorf("GET got %d %+v", rr.Code, resp)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/jobs/session-cleanup/run", http.NoBody))
+	if rr.Code != http.StatusAccepted || len(runner.runs) != 1 || runner.runs[0] != "session-cleanup" {
+		t.Errorf("POST got %d with runs %v, expected the job started", rr.Code, runner.runs)
+	}
+
+	for _, tt := range []struct {
+		err      error
+		expected int
+	}{
+		{jobs.ErrNotFound, http.StatusNotFound},
+		{jobs.ErrRunning, http.StatusConflict},
+		{jobs.ErrStopped, http.StatusServiceUnavailable},
+	} {
+		runner.err = tt.err
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/jobs/session-cleanup/run", http.NoBody))
+		if rr.Code != tt.expected {
+			t.Errorf("RunNow error %v: expected status %d, got %d", tt.err, tt.expected, rr.Code)
+		}
+	}
+}
+
+// mockBackupManager implements BackupManager for testing
+type mockBackupManager struct {
+	backups  []*domain.Backup
+	startErr error
+	started  []*uuid.UUID
+}
+
+func (m *mockBackupManager) List(ctx context.Context, limit int) ([]*domain.Backup, error) {
+	return m.backups, nil
+}
+
+func (m *mockBackupManager) StartBackup(ctx context.Context, requestedBy *uuid.UUID) (*domain.Backup, error) {
+	if m.startErr != nil {
+		return nil, m.startErr
+	}
+	m.started = append(m.started, requestedBy)
+	return domain.NewBackup(domain.BackupTriggerManual, "local", 0, requestedBy), nil
+}
+
+func (m *mockBackupManager) Open(ctx context.Context, id uuid.UUID) (*domain.Backup, *storage.Object, error) {
+	for _, b := range m.backups {
+		if b.ID != id {
+			continue
+		}
+		if !b.IsAvailable() {
+			return b, nil, apperrors.NotFound("backup file")
+		}
+		return b, &storage.Object{Body: io.NopCloser(strings.NewReader("PGDMP")), Size: 5}, nil
+	}
+	return nil, nil, apperrors.NotFound("backup")
+}
+
+func TestBackupHandler(t *testing.T) {
+	completed := domain.NewBackup(domain.BackupTriggerScheduled, "local", 0, nil)
+	completed.MarkCompleted(5, "abc")
+	failed := domain.NewBackup(domain.BackupTriggerScheduled, "local", 0, nil)
+	failed.MarkFailed(errors.New("pg_dump failed"))
+	manager := &mockBackupManager{backups: []*domain.Backup{completed, failed}}
+	r := chi.NewRouter()
+	NewBackupHandler(manager, nil, zap.NewNop()).RegisterRoutes(r)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/backups", http.NoBody))
+	var resp BackupsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusOK || len(resp.Backups) != 2 {
+		t.Errorf("GET got %d %+v", rr.Code, resp)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/backups", http.NoBody))
+	if rr.Code != http.StatusAccepted || len(manager.started) != 1 {
+		t.Errorf("POST got %d, expected a backup started", rr.Code)
+	}
+	manager.startErr = service.ErrBackupInProgress
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/backups", http.NoBody))
+	if rr.Code != http.StatusConflict {
+		t.Errorf("POST during a backup got %d, expected 409", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/backups/"+completed.ID.String()+"/download", http.NoBody))
+	if rr.Code != http.StatusOK || rr.Body.String() != "PGDMP" || !strings.Contains(rr.Header().Get("Content-Disposition"), "attachment") {
+		t.Errorf("download got %d %q", rr.Code, rr.Body.String())
+	}
+	for _, id := range []string{failed.ID.String(), uuid.NewString()} {
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/backups/"+id+"/download", http.NoBody))
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("download of %s got %d, expected 404", id, rr.Code)
+		}
+	}
+
+	// Without backup storage the endpoints say so
+	r = chi.NewRouter()
+	NewBackupHandler(nil, nil, zap.NewNop()).RegisterRoutes(r)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/backups", http.NoBody))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("POST without storage got %d, expected 503", rr.Code)
+	}
 }
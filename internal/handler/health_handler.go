@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/health"
 	"github.com/jkindrix/quickquote/internal/voiceprovider"
 )
 
@@ -22,11 +23,19 @@ type AIHealthChecker interface {
 	IsCircuitOpen() bool
 }
 
+// ReadinessState reports whether the server should receive traffic.
+// shutdown.ReadinessProbe implements it.
+type ReadinessState interface {
+	IsReady() bool
+}
+
 // HealthHandler handles health check HTTP requests.
 type HealthHandler struct {
 	healthChecker    HealthChecker
 	aiHealthChecker  AIHealthChecker
 	providerRegistry *voiceprovider.Registry
+	monitor          *health.Monitor
+	readiness        ReadinessState
 	logger           *zap.Logger
 }
 
@@ -35,6 +44,8 @@ type HealthHandlerConfig struct {
 	HealthChecker    HealthChecker
 	AIHealthChecker  AIHealthChecker
 	ProviderRegistry *voiceprovider.Registry
+	Monitor          *health.Monitor // Optional; adds the dependency report and readiness gate
+	Readiness        ReadinessState  // Optional; /ready fails once the server is draining
 	Logger           *zap.Logger
 }
 
@@ -47,6 +58,8 @@ func NewHealthHandler(cfg HealthHandlerConfig) *HealthHandler {
 		healthChecker:    cfg.HealthChecker,
 		aiHealthChecker:  cfg.AIHealthChecker,
 		providerRegistry: cfg.ProviderRegistry,
+		monitor:          cfg.Monitor,
+		readiness:        cfg.Readiness,
 		logger:           cfg.Logger,
 	}
 }
@@ -64,6 +77,8 @@ type HealthResponse struct {
 	Version        string                     `json:"version,omitempty"`
 	Checks         map[string]ComponentHealth `json:"checks,omitempty"`
 	VoiceProviders []VoiceProviderHealth      `json:"voice_providers,omitempty"`
+	Dependencies   []health.DependencyStatus  `json:"dependencies,omitempty"`
+	Draining       bool                       `json:"draining,omitempty"`
 }
 
 // ComponentHealth represents the health of a single component.
@@ -74,10 +89,11 @@ type ComponentHealth struct {
 
 // VoiceProviderHealth represents the health of a voice provider.
 type VoiceProviderHealth struct {
-	Name      string `json:"name"`
-	Status    string `json:"status"`
-	IsPrimary bool   `json:"is_primary"`
-	Message   string `json:"message,omitempty"`
+	Name         string                     `json:"name"`
+	Status       string                     `json:"status"`
+	IsPrimary    bool                       `json:"is_primary"`
+	Message      string                     `json:"message,omitempty"`
+	Capabilities voiceprovider.Capabilities `json:"capabilities"`
 }
 
 // HandleHealth returns a health check response including all service dependencies.
@@ -132,10 +148,11 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 		response.VoiceProviders = make([]VoiceProviderHealth, len(statuses))
 		for i, status := range statuses {
 			response.VoiceProviders[i] = VoiceProviderHealth{
-				Name:      string(status.Name),
-				Status:    "available",
-				IsPrimary: status.IsPrimary,
-				Message:   status.Message,
+				Name:         string(status.Name),
+				Status:       "available",
+				IsPrimary:    status.IsPrimary,
+				Message:      status.Message,
+				Capabilities: status.Capabilities,
 			}
 			if !status.Available {
 				response.VoiceProviders[i].Status = "unavailable"
@@ -157,6 +174,23 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Report the latest background checks of external dependencies
+	if h.monitor != nil {
+		response.Dependencies = h.monitor.Statuses()
+		for _, dep := range response.Dependencies {
+			switch {
+			case dep.Status == health.StatusUnhealthy && dep.Critical:
+				hasCriticalFailure = true
+			case dep.Status == health.StatusUnhealthy || dep.Status == health.StatusDegraded:
+				hasDegradation = true
+			}
+		}
+	}
+
+	if h.readiness != nil && !h.readiness.IsReady() {
+		response.Draining = true
+	}
+
 	// Determine overall status
 	if hasCriticalFailure {
 		response.Status = "unhealthy"
@@ -182,13 +216,26 @@ func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// HandleReadiness returns a simple readiness probe response.
+// HandleReadiness returns a simple readiness probe response. Only critical
+// dependencies are checked, so an unreachable voice provider or mail server
+// doesn't take the server out of rotation.
 func (h *HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	if h.readiness != nil && !h.readiness.IsReady() {
+		http.Error(w, "draining", http.StatusServiceUnavailable)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
 	defer cancel()
 
-	// Only check database - the critical dependency
-	if h.healthChecker != nil {
+	if h.monitor != nil {
+		if err := h.monitor.Ready(ctx); err != nil {
+			h.logger.Error("readiness check failed", zap.Error(err))
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+	} else if h.healthChecker != nil {
+		// Only check database - the critical dependency
 		if err := h.healthChecker.Ping(ctx); err != nil {
 			h.logger.Error("readiness check failed", zap.Error(err))
 			http.Error(w, "not ready", http.StatusServiceUnavailable)
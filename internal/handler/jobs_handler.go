@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/jobs"
+)
+
+// JobRunner reports on background jobs and runs them on request.
+// jobs.Scheduler implements it.
+type JobRunner interface {
+	Status() []jobs.Status
+	RunNow(name string) error
+}
+
+// JobsHandler shows the background jobs and lets admins run one now.
+type JobsHandler struct {
+	runner      JobRunner
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewJobsHandler creates a handler for the background jobs. auditLogger
+// may be nil.
+func NewJobsHandler(runner JobRunner, auditLogger *audit.Logger, logger *zap.Logger) *JobsHandler {
+	return &JobsHandler{
+		runner:      runner,
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// JobsResponse lists the status of each background job.
+type JobsResponse struct {
+	Jobs []jobs.Status `json:"jobs"`
+}
+
+// RegisterRoutes registers the job routes on the router.
+// Note: These routes require authentication and admin middleware to be
+// applied by the caller.
+func (h *JobsHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/jobs", h.HandleList)
+	r.Post("/admin/jobs/{name}/run", h.HandleRun)
+}
+
+// HandleList reports each job's schedule and last run.
+func (h *JobsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(JobsResponse{Jobs: h.runner.Status()})
+}
+
+// HandleRun starts a job outside its schedule. The run happens in the
+// background; its outcome shows in the job list once it finishes.
+func (h *JobsHandler) HandleRun(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	name := chi.URLParam(r, "name")
+
+	if err := h.runner.RunNow(name); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, jobs.ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, jobs.ErrRunning):
+			status = http.StatusConflict
+		case errors.Is(err, jobs.ErrStopped):
+			status = http.StatusServiceUnavailable
+		default:
+			h.logger.Error("failed to run job", zap.String("job", name), zap.Error(err))
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if h.auditLogger != nil {
+		userID, userName := auditActor(r)
+		h.auditLogger.JobRun(r.Context(), userID, userName, name, getClientIP(r), GetRequestIDFromContext(r.Context()))
+	}
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status": "started",
+		"job":    name,
+	})
+}
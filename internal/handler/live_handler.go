@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/realtime"
+)
+
+// livePingInterval is how often an idle live calls connection is pinged so
+// proxies don't close it.
+const livePingInterval = 30 * time.Second
+
+// LiveHandler serves the live calls page and the WebSocket that feeds it.
+type LiveHandler struct {
+	*BaseHandler
+	hub *realtime.Hub
+}
+
+// LiveHandlerConfig holds configuration for LiveHandler.
+type LiveHandlerConfig struct {
+	Base BaseHandlerConfig
+	Hub  *realtime.Hub
+}
+
+// NewLiveHandler creates a new LiveHandler with all required dependencies.
+func NewLiveHandler(cfg LiveHandlerConfig) *LiveHandler {
+	if cfg.Hub == nil {
+		panic("hub is required")
+	}
+	return &LiveHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		hub:         cfg.Hub,
+	}
+}
+
+// RegisterRoutes registers live call routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *LiveHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/calls/live", h.HandleLivePage)
+	r.Get("/calls/live/ws", h.HandleLiveSocket)
+}
+
+// HandleLivePage renders the live calls page. The calls themselves arrive
+// over the WebSocket.
+func (h *LiveHandler) HandleLivePage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	h.RenderTemplate(w, r, "live", map[string]interface{}{
+		"Title":     "Live Calls",
+		"ActiveNav": "live",
+		"User":      user,
+	})
+}
+
+// HandleLiveSocket upgrades to a WebSocket and streams live call events: a
+// snapshot of the calls in progress, then call, transcript and call_ended
+// events as provider webhooks arrive. A client that falls behind is
+// disconnected and should reconnect for a fresh snapshot.
+func (h *LiveHandler) HandleLiveSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := realtime.Upgrade(w, r)
+	if err != nil {
+		if !errors.Is(err, realtime.ErrBadHandshake) {
+			h.logger.Warn("failed to open live calls websocket", zap.Error(err))
+		}
+		return
+	}
+
+	snapshot, events, unsubscribe := h.hub.Subscribe()
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		_ = conn.ReadLoop()
+	}()
+
+	if err := conn.WriteJSON(snapshot); err != nil {
+		conn.Close(realtime.CloseGoingAway)
+		return
+	}
+
+	ticker := time.NewTicker(livePingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				conn.Close(realtime.CloseGoingAway)
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				conn.Close(realtime.CloseGoingAway)
+				return
+			}
+
+		case <-ticker.C:
+			if err := conn.Ping(); err != nil {
+				conn.Close(realtime.CloseGoingAway)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// NotificationPreferenceHandler serves the notification settings page, where
+// users choose the events they are notified about on each channel.
+type NotificationPreferenceHandler struct {
+	*BaseHandler
+	preferences *service.NotificationPreferenceService
+}
+
+// NotificationPreferenceHandlerConfig holds configuration for NotificationPreferenceHandler.
+type NotificationPreferenceHandlerConfig struct {
+	Base        BaseHandlerConfig
+	Preferences *service.NotificationPreferenceService
+}
+
+// NewNotificationPreferenceHandler creates a new NotificationPreferenceHandler
+// with all required dependencies.
+func NewNotificationPreferenceHandler(cfg NotificationPreferenceHandlerConfig) *NotificationPreferenceHandler {
+	if cfg.Preferences == nil {
+		panic("preferences is required")
+	}
+	return &NotificationPreferenceHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		preferences: cfg.Preferences,
+	}
+}
+
+// notificationChannelLabels are the settings page's column headings.
+var notificationChannelLabels = map[domain.NotificationChannel]string{
+	domain.NotificationChannelEmail: "Email",
+	domain.NotificationChannelSMS:   "SMS",
+	domain.NotificationChannelSlack: "Slack",
+}
+
+// notificationCell is one event and channel checkbox on the settings page.
+type notificationCell struct {
+	Channel   domain.NotificationChannel
+	Supported bool
+	Checked   bool
+}
+
+// notificationRow is one event's checkboxes on the settings page.
+type notificationRow struct {
+	Event domain.NotificationEvent
+	Cells []notificationCell
+}
+
+// RegisterRoutes registers notification settings routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *NotificationPreferenceHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/account/notifications", h.HandleNotificationsPage)
+	r.Post("/account/notifications", h.HandleSaveNotifications)
+}
+
+// HandleNotificationsPage shows the user's notification preferences.
+func (h *NotificationPreferenceHandler) HandleNotificationsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	prefs, err := h.preferences.Get(r.Context(), user)
+	if err != nil {
+		h.logger.Error("failed to load notification preferences", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("saved") == "1" {
+		successMsg = "Notification preferences saved."
+	}
+	h.renderNotificationsPage(w, r, user, prefs, successMsg, "")
+}
+
+// HandleSaveNotifications saves the user's notification preferences.
+func (h *NotificationPreferenceHandler) HandleSaveNotifications(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	prefs := &domain.NotificationPreferences{UserID: user.ID, Email: user.Email}
+	if err := r.ParseForm(); err != nil {
+		h.renderNotificationsPage(w, r, user, prefs, "", "Invalid form submission.")
+		return
+	}
+
+	prefs.Phone = r.FormValue("phone")
+	prefs.SlackWebhookURL = r.FormValue("slack_webhook_url")
+	for _, channel := range h.preferences.Channels() {
+		var events []domain.NotificationEvent
+		for _, e := range r.Form[string(channel)] {
+			events = append(events, domain.NotificationEvent(e))
+		}
+		prefs.SetEvents(channel, events)
+	}
+
+	if err := h.preferences.Update(r.Context(), user, prefs); err != nil {
+		errMsg := "Failed to save notification preferences."
+		if apperrors.IsUserError(err) {
+			errMsg = "Failed to save notification preferences: " + err.Error()
+		} else {
+			h.logger.Error("failed to save notification preferences", zap.Error(err))
+		}
+		h.renderNotificationsPage(w, r, user, prefs, "", errMsg)
+		return
+	}
+
+	http.Redirect(w, r, "/account/notifications?saved=1", http.StatusSeeOther)
+}
+
+// renderNotificationsPage renders the settings page with a checkbox for each
+// event on each channel this server can deliver on.
+func (h *NotificationPreferenceHandler) renderNotificationsPage(w http.ResponseWriter, r *http.Request, user *domain.User, prefs *domain.NotificationPreferences, successMsg, errMsg string) {
+	channels := h.preferences.Channels()
+	labels := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		labels = append(labels, notificationChannelLabels[channel])
+	}
+	rows := make([]notificationRow, 0, len(domain.NotificationEvents))
+	for _, event := range domain.NotificationEvents {
+		row := notificationRow{Event: event}
+		for _, channel := range channels {
+			row.Cells = append(row.Cells, notificationCell{
+				Channel:   channel,
+				Supported: channel.Supports(event),
+				Checked:   prefs.Wants(channel, event),
+			})
+		}
+		rows = append(rows, row)
+	}
+
+	h.RenderTemplate(w, r, "notifications", map[string]interface{}{
+		"Title":       "Notifications",
+		"ActiveNav":   "notifications",
+		"User":        user,
+		"Channels":    labels,
+		"Rows":        rows,
+		"Preferences": prefs,
+		"SMS":         h.preferences.Available(domain.NotificationChannelSMS),
+		"Slack":       h.preferences.Available(domain.NotificationChannelSlack),
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/payments"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// PaymentWebhookPath is where the payment processor sends payment events.
+const PaymentWebhookPath = "/webhook/stripe"
+
+// PaymentWebhookHandler receives payment processor webhooks and marks the
+// quotes they pay for as paid.
+type PaymentWebhookHandler struct {
+	paymentService *service.PaymentService
+	logger         *zap.Logger
+}
+
+// NewPaymentWebhookHandler creates a new PaymentWebhookHandler.
+func NewPaymentWebhookHandler(paymentService *service.PaymentService, logger *zap.Logger) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{
+		paymentService: paymentService,
+		logger:         logger,
+	}
+}
+
+// RegisterRoutes registers the webhook route. It needs no session; requests
+// are authenticated by the processor's signature.
+func (h *PaymentWebhookHandler) RegisterRoutes(r chi.Router) {
+	r.With(middleware.BodySizeLimiterWebhook()).Post(PaymentWebhookPath, h.HandleWebhook)
+}
+
+// HandleWebhook handles POST /webhook/stripe
+func (h *PaymentWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	// The signature covers the exact bytes sent, so read the raw body.
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	if err := h.paymentService.HandleWebhook(r.Context(), payload, r.Header); err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			h.logger.Warn("payment webhook with invalid signature")
+			APIError(w, http.StatusUnauthorized, "invalid signature")
+			return
+		}
+		// A 5xx makes the processor retry the delivery.
+		h.logger.Error("failed to handle payment webhook", zap.Error(err))
+		APIError(w, http.StatusInternalServerError, "failed to handle webhook")
+		return
+	}
+
+	JSON(w, http.StatusOK, map[string]bool{"received": true})
+}
@@ -0,0 +1,344 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// pricingBlankRows is the number of empty unit and multiplier rows offered
+// on the pricing rule form.
+const pricingBlankRows = 3
+
+// PricingHandler serves the pricing rule admin pages.
+type PricingHandler struct {
+	*BaseHandler
+	pricingService *service.PricingService
+}
+
+// PricingHandlerConfig holds configuration for PricingHandler.
+type PricingHandlerConfig struct {
+	Base           BaseHandlerConfig
+	PricingService *service.PricingService
+}
+
+// NewPricingHandler creates a new PricingHandler with all required dependencies.
+func NewPricingHandler(cfg PricingHandlerConfig) *PricingHandler {
+	if cfg.PricingService == nil {
+		panic("pricingService is required")
+	}
+	return &PricingHandler{
+		BaseHandler:    NewBaseHandler(cfg.Base),
+		pricingService: cfg.PricingService,
+	}
+}
+
+// RegisterRoutes registers pricing routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *PricingHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/pricing", h.HandlePricingPage)
+	r.Get("/pricing/new", h.HandlePricingRuleNew)
+	r.Post("/pricing", h.HandlePricingRuleCreate)
+	r.Get("/pricing/{id}", h.HandlePricingRuleDetail)
+	r.Post("/pricing/{id}", h.HandlePricingRuleUpdate)
+	r.Post("/pricing/{id}/delete", h.HandlePricingRuleDelete)
+}
+
+// HandlePricingPage lists the pricing rules.
+func (h *PricingHandler) HandlePricingPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var errMsg string
+	rules, err := h.pricingService.ListRules(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list pricing rules", zap.Error(err))
+		errMsg = "Failed to load pricing rules"
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("created") == "1":
+		successMsg = "Pricing rule created."
+	case r.URL.Query().Get("deleted") == "1":
+		successMsg = "Pricing rule deleted."
+	}
+
+	h.RenderTemplate(w, r, "pricing", map[string]interface{}{
+		"Title":       "Pricing",
+		"ActiveNav":   "pricing",
+		"User":        user,
+		"Rules":       rules,
+		"DefaultType": domain.DefaultPricingProjectType,
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// HandlePricingRuleNew shows the form for a new pricing rule.
+func (h *PricingHandler) HandlePricingRuleNew(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	h.renderPricingRuleForm(w, r, &domain.PricingRule{IsActive: true}, true, "", "")
+}
+
+// HandlePricingRuleCreate handles POST to create a pricing rule.
+func (h *PricingHandler) HandlePricingRuleCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderPricingRuleForm(w, r, &domain.PricingRule{IsActive: true}, true, "", "Invalid form submission.")
+		return
+	}
+
+	req, err := parsePricingRuleForm(r)
+	if err != nil {
+		h.renderPricingRuleForm(w, r, pricingRuleFromRequest(req), true, "", err.Error())
+		return
+	}
+
+	if _, err := h.pricingService.CreateRule(r.Context(), req); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderPricingRuleForm(w, r, pricingRuleFromRequest(req), true, "", "Failed to create pricing rule: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create pricing rule", zap.Error(err))
+		h.renderPricingRuleForm(w, r, pricingRuleFromRequest(req), true, "", "Failed to create pricing rule.")
+		return
+	}
+
+	http.Redirect(w, r, "/pricing?created=1", http.StatusSeeOther)
+}
+
+// HandlePricingRuleDetail shows the edit form for a pricing rule.
+func (h *PricingHandler) HandlePricingRuleDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid pricing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.pricingService.GetRule(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Pricing rule not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get pricing rule", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("updated") == "1" {
+		successMsg = "Pricing rule updated."
+	}
+	h.renderPricingRuleForm(w, r, rule, false, successMsg, "")
+}
+
+// HandlePricingRuleUpdate handles POST to edit a pricing rule.
+func (h *PricingHandler) HandlePricingRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid pricing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	req, err := parsePricingRuleForm(r)
+	failed := func(msg string) {
+		rule := pricingRuleFromRequest(req)
+		rule.ID = id
+		h.renderPricingRuleForm(w, r, rule, false, "", msg)
+	}
+	if err != nil {
+		failed(err.Error())
+		return
+	}
+
+	if _, err := h.pricingService.UpdateRule(r.Context(), id, req); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Pricing rule not found", http.StatusNotFound)
+			return
+		}
+		if apperrors.IsUserError(err) {
+			failed("Failed to update pricing rule: " + err.Error())
+			return
+		}
+		h.logger.Error("failed to update pricing rule", zap.Error(err), zap.String("id", id.String()))
+		failed("Failed to update pricing rule.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/pricing/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandlePricingRuleDelete handles POST to delete a pricing rule.
+func (h *PricingHandler) HandlePricingRuleDelete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid pricing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.pricingService.DeleteRule(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Pricing rule not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to delete pricing rule", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Failed to delete pricing rule", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/pricing?deleted=1", http.StatusSeeOther)
+}
+
+// renderPricingRuleForm renders the create or edit form for a pricing rule,
+// padding its units and multipliers with blank rows to fill in.
+func (h *PricingHandler) renderPricingRuleForm(w http.ResponseWriter, r *http.Request, rule *domain.PricingRule, isNew bool, successMsg, errMsg string) {
+	units := append([]domain.PricingUnitRate(nil), rule.UnitRates...)
+	multipliers := append([]domain.PricingMultiplier(nil), rule.Multipliers...)
+	for i := 0; i < pricingBlankRows; i++ {
+		units = append(units, domain.PricingUnitRate{})
+		multipliers = append(multipliers, domain.PricingMultiplier{})
+	}
+
+	title := "New Pricing Rule"
+	if !isNew {
+		title = "Pricing: " + rule.Name
+	}
+
+	h.RenderTemplate(w, r, "pricing_rule", map[string]interface{}{
+		"Title":       title,
+		"ActiveNav":   "pricing",
+		"User":        GetUserFromContext(r.Context()),
+		"Rule":        rule,
+		"IsNew":       isNew,
+		"Units":       units,
+		"Multipliers": multipliers,
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// parsePricingRuleForm reads a pricing rule from a submitted form. Unit and
+// multiplier rows are submitted as parallel fields; blank rows are skipped.
+// The request is returned even on error so the form can be redisplayed.
+func parsePricingRuleForm(r *http.Request) (*service.PricingRuleRequest, error) {
+	req := &service.PricingRuleRequest{
+		ProjectType: r.FormValue("project_type"),
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+		IsActive:    r.FormValue("is_active") != "",
+	}
+
+	var errs []string
+	parseNumber := func(field, value string) float64 {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", ""), 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %q is not a number", field, value))
+		}
+		return n
+	}
+
+	req.BaseRate = parseNumber("base rate", r.FormValue("base_rate"))
+
+	keys, labels, rates := r.Form["unit_key"], r.Form["unit_label"], r.Form["unit_rate"]
+	for i := range keys {
+		label, rate := formIndex(labels, i), formIndex(rates, i)
+		if strings.TrimSpace(keys[i]+label+rate) == "" {
+			continue
+		}
+		req.UnitRates = append(req.UnitRates, domain.PricingUnitRate{
+			Key:   keys[i],
+			Label: label,
+			Rate:  parseNumber("unit rate", rate),
+		})
+	}
+
+	keys, labels, factors := r.Form["multiplier_key"], r.Form["multiplier_label"], r.Form["multiplier_factor"]
+	for i := range keys {
+		label, factor := formIndex(labels, i), formIndex(factors, i)
+		if strings.TrimSpace(keys[i]+label+factor) == "" {
+			continue
+		}
+		req.Multipliers = append(req.Multipliers, domain.PricingMultiplier{
+			Key:    keys[i],
+			Label:  label,
+			Factor: parseNumber("multiplier factor", factor),
+		})
+	}
+
+	if len(errs) > 0 {
+		return req, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return req, nil
+}
+
+// formIndex returns values[i], or "" when the form sent fewer values.
+func formIndex(values []string, i int) string {
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
+
+// pricingRuleFromRequest builds an unsaved rule for redisplaying a form.
+func pricingRuleFromRequest(req *service.PricingRuleRequest) *domain.PricingRule {
+	return &domain.PricingRule{
+		ProjectType: req.ProjectType,
+		Name:        req.Name,
+		Description: req.Description,
+		BaseRate:    req.BaseRate,
+		UnitRates:   req.UnitRates,
+		Multipliers: req.Multipliers,
+		IsActive:    req.IsActive,
+	}
+}
@@ -0,0 +1,389 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/accounting"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// quoteBlankRows is the number of empty line item rows offered on the quote
+// editor.
+const quoteBlankRows = 3
+
+// QuoteHandler serves the quote editor, where staff adjust a draft quote's
+// line items before it is sent.
+type QuoteHandler struct {
+	*BaseHandler
+	quoteService      *service.QuoteService
+	portalService     *service.QuotePortalService
+	paymentService    *service.PaymentService
+	accountingService *service.AccountingService
+}
+
+// QuoteHandlerConfig holds configuration for QuoteHandler.
+type QuoteHandlerConfig struct {
+	Base              BaseHandlerConfig
+	QuoteService      *service.QuoteService
+	PortalService     *service.QuotePortalService // Optional; enables customer links
+	PaymentService    *service.PaymentService     // Optional; enables payment links
+	AccountingService *service.AccountingService  // Optional; enables accounting sync
+}
+
+// NewQuoteHandler creates a new QuoteHandler with all required dependencies.
+func NewQuoteHandler(cfg QuoteHandlerConfig) *QuoteHandler {
+	if cfg.QuoteService == nil {
+		panic("quoteService is required")
+	}
+	return &QuoteHandler{
+		BaseHandler:       NewBaseHandler(cfg.Base),
+		quoteService:      cfg.QuoteService,
+		portalService:     cfg.PortalService,
+		paymentService:    cfg.PaymentService,
+		accountingService: cfg.AccountingService,
+	}
+}
+
+// RegisterRoutes registers quote routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *QuoteHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/quotes/{id}", h.HandleQuoteEditor)
+	r.Post("/quotes/{id}", h.HandleQuoteUpdate)
+	r.Post("/quotes/{id}/link", h.HandleCreateLink)
+	r.Post("/quotes/{id}/payment-link", h.HandleCreatePaymentLink)
+	r.Post("/quotes/{id}/accounting-sync", h.HandleAccountingSync)
+}
+
+// HandleQuoteEditor shows a quote's line items, totals and status.
+func (h *QuoteHandler) HandleQuoteEditor(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.quoteService.GetQuote(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("updated") == "1" {
+		successMsg = "Quote updated."
+	}
+	h.renderQuoteEditor(w, r, detail, successMsg, "")
+}
+
+// HandleQuoteUpdate handles POST to save a draft quote's line items.
+func (h *QuoteHandler) HandleQuoteUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	items, taxRate, notes, formErr := parseQuoteForm(r)
+	currency := domain.NormalizeCurrency(r.FormValue("currency"))
+
+	// failed redisplays the form with what was submitted.
+	failed := func(msg string) {
+		detail, err := h.quoteService.GetQuote(r.Context(), id)
+		if err != nil {
+			if apperrors.IsNotFound(err) {
+				http.Error(w, "Quote not found", http.StatusNotFound)
+				return
+			}
+			h.logger.Error("failed to get quote", zap.Error(err), zap.String("id", id.String()))
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		for i := range items {
+			items[i].Amount = items[i].Quantity * items[i].UnitPrice
+		}
+		detail.LineItems = items
+		detail.TaxRate = taxRate
+		detail.Notes = notes
+		if currency != "" {
+			detail.Currency = currency
+		}
+		h.renderQuoteEditor(w, r, detail, "", msg)
+	}
+	if formErr != nil {
+		failed(formErr.Error())
+		return
+	}
+
+	actor := service.QuoteActor{
+		User:      user,
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+	if _, err := h.quoteService.UpdateQuote(r.Context(), id, actor, items, taxRate, notes, currency); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote not found", http.StatusNotFound)
+			return
+		}
+		if apperrors.IsUserError(err) {
+			failed("Failed to save quote: " + err.Error())
+			return
+		}
+		h.logger.Error("failed to update quote", zap.Error(err), zap.String("id", id.String()))
+		failed("Failed to save quote.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/quotes/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandleCreateLink creates a customer link to a sent quote and shows it once.
+func (h *QuoteHandler) HandleCreateLink(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+	if h.portalService == nil {
+		http.Error(w, "Customer quote links are not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	detail, err := h.quoteService.GetQuote(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	actor := service.QuoteActor{
+		User:      user,
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+	link, err := h.portalService.CreateLink(r.Context(), id, actor)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderQuoteEditor(w, r, detail, "", "Failed to create link: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create quote link", zap.Error(err), zap.String("id", id.String()))
+		h.renderQuoteEditor(w, r, detail, "", "Failed to create link.")
+		return
+	}
+
+	h.renderQuoteEditor(w, r, detail, "Customer link created. Copy it now; it won't be shown again: "+link.URL, "")
+}
+
+// HandleCreatePaymentLink creates a payment link for an accepted quote.
+func (h *QuoteHandler) HandleCreatePaymentLink(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+	if h.paymentService == nil {
+		http.Error(w, "Quote payments are not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	_, err = h.paymentService.CreatePaymentLink(r.Context(), id)
+	// Reload so the page shows the new payment.
+	detail, getErr := h.quoteService.GetQuote(r.Context(), id)
+	if getErr != nil {
+		if apperrors.IsNotFound(getErr) {
+			http.Error(w, "Quote not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote", zap.Error(getErr), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderQuoteEditor(w, r, detail, "", "Failed to create payment link: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create payment link", zap.Error(err), zap.String("id", id.String()))
+		h.renderQuoteEditor(w, r, detail, "", "Failed to create payment link.")
+		return
+	}
+
+	h.renderQuoteEditor(w, r, detail, "Payment link created.", "")
+}
+
+// HandleAccountingSync pushes an accepted quote to the accounting system.
+func (h *QuoteHandler) HandleAccountingSync(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote ID", http.StatusBadRequest)
+		return
+	}
+	if h.accountingService == nil {
+		http.Error(w, "Accounting sync is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	attempt, err := h.accountingService.SyncQuote(r.Context(), id, user)
+	// Reload so the page shows the new sync.
+	detail, getErr := h.quoteService.GetQuote(r.Context(), id)
+	if getErr != nil {
+		if apperrors.IsNotFound(getErr) {
+			http.Error(w, "Quote not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote", zap.Error(getErr), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderQuoteEditor(w, r, detail, "", "Failed to sync quote: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to sync quote", zap.Error(err), zap.String("id", id.String()))
+		h.renderQuoteEditor(w, r, detail, "", "Failed to sync quote.")
+		return
+	}
+	if !attempt.Succeeded() {
+		h.renderQuoteEditor(w, r, detail, "", "The accounting system rejected the quote: "+attempt.Error)
+		return
+	}
+
+	h.renderQuoteEditor(w, r, detail, "Quote pushed to accounting.", "")
+}
+
+// renderQuoteEditor renders the quote editor, padding the line items with
+// blank rows to fill in.
+func (h *QuoteHandler) renderQuoteEditor(w http.ResponseWriter, r *http.Request, detail *service.QuoteDetail, successMsg, errMsg string) {
+	items := append([]domain.QuoteLineItem(nil), detail.LineItems...)
+	for i := 0; i < quoteBlankRows; i++ {
+		items = append(items, domain.QuoteLineItem{})
+	}
+
+	var syncs []*domain.AccountingSync
+	var provider string
+	if h.accountingService != nil {
+		provider = accounting.DisplayName(h.accountingService.Provider())
+		var err error
+		syncs, err = h.accountingService.ListSyncs(r.Context(), detail.CallID)
+		if err != nil {
+			h.logger.Error("failed to list accounting syncs", zap.Error(err), zap.String("id", detail.CallID.String()))
+		}
+	}
+	synced := false
+	for _, s := range syncs {
+		if s.Succeeded() {
+			synced = true
+			break
+		}
+	}
+
+	h.RenderTemplate(w, r, "quote_edit", map[string]interface{}{
+		"Title":              "Quote " + detail.QuoteNumber,
+		"ActiveNav":          "calls",
+		"User":               GetUserFromContext(r.Context()),
+		"Quote":              detail,
+		"LineItems":          items,
+		"Editable":           detail.Status == domain.QuoteStatusDraft,
+		"Shareable":          h.portalService != nil && detail.Status == domain.QuoteStatusSent,
+		"Payable":            h.paymentService != nil && detail.Status == domain.QuoteStatusAccepted,
+		"Syncable":           h.accountingService != nil && detail.Status == domain.QuoteStatusAccepted && !synced,
+		"AccountingProvider": provider,
+		"AccountingSyncs":    syncs,
+		"Success":            successMsg,
+		"Error":              errMsg,
+	})
+}
+
+// parseQuoteForm reads line items, tax rate and notes from a submitted form.
+// Line items are submitted as parallel fields; blank rows are skipped. The
+// values are returned even on error so the form can be redisplayed.
+func parseQuoteForm(r *http.Request) ([]domain.QuoteLineItem, float64, string, error) {
+	var errs []string
+	parseNumber := func(field, value string) float64 {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(value, "$"), "%"), ",", ""), 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %q is not a number", field, value))
+		}
+		return n
+	}
+
+	var items []domain.QuoteLineItem
+	descriptions, quantities, prices := r.Form["item_description"], r.Form["item_quantity"], r.Form["item_unit_price"]
+	for i := range descriptions {
+		quantity, price := formIndex(quantities, i), formIndex(prices, i)
+		if strings.TrimSpace(descriptions[i]+quantity+price) == "" {
+			continue
+		}
+		items = append(items, domain.QuoteLineItem{
+			Description: descriptions[i],
+			Quantity:    parseNumber("quantity", quantity),
+			UnitPrice:   parseNumber("unit price", price),
+		})
+	}
+
+	taxRate := parseNumber("tax rate", r.FormValue("tax_rate"))
+	notes := r.FormValue("notes")
+
+	if len(errs) > 0 {
+		return items, taxRate, notes, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return items, taxRate, notes, nil
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// quoteJobPageSize is the number of jobs shown on the quote jobs page.
+const quoteJobPageSize = 100
+
+// QuoteJobHandler serves the quote job admin pages, where dead jobs are
+// inspected and retried.
+type QuoteJobHandler struct {
+	*BaseHandler
+	jobProcessor *service.QuoteJobProcessor
+	callService  *service.CallService
+}
+
+// QuoteJobHandlerConfig holds configuration for QuoteJobHandler.
+type QuoteJobHandlerConfig struct {
+	Base         BaseHandlerConfig
+	JobProcessor *service.QuoteJobProcessor
+	CallService  *service.CallService
+}
+
+// NewQuoteJobHandler creates a new QuoteJobHandler with all required dependencies.
+func NewQuoteJobHandler(cfg QuoteJobHandlerConfig) *QuoteJobHandler {
+	if cfg.JobProcessor == nil {
+		panic("jobProcessor is required")
+	}
+	if cfg.CallService == nil {
+		panic("callService is required")
+	}
+	return &QuoteJobHandler{
+		BaseHandler:  NewBaseHandler(cfg.Base),
+		jobProcessor: cfg.JobProcessor,
+		callService:  cfg.CallService,
+	}
+}
+
+// RegisterRoutes registers quote job routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *QuoteJobHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/quote-jobs", h.HandleQuoteJobsPage)
+	r.Get("/quote-jobs/{id}", h.HandleQuoteJobDetail)
+	r.Post("/quote-jobs/{id}/retry", h.HandleQuoteJobRetry)
+}
+
+// HandleQuoteJobsPage lists quote jobs, dead ones unless another status is
+// chosen.
+func (h *QuoteJobHandler) HandleQuoteJobsPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	status := domain.QuoteJobStatus(r.URL.Query().Get("status"))
+	if !r.URL.Query().Has("status") {
+		status = domain.QuoteJobStatusDead
+	}
+
+	var errMsg string
+	jobs, err := h.jobProcessor.ListJobs(r.Context(), status, quoteJobPageSize, 0)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list quote jobs", zap.Error(err))
+		errMsg = "Failed to load quote jobs"
+	}
+
+	h.RenderTemplate(w, r, "quote_jobs", map[string]interface{}{
+		"Title":     "Quote Jobs",
+		"ActiveNav": "usage",
+		"User":      user,
+		"Jobs":      jobs,
+		"Status":    string(status),
+		"Statuses":  domain.QuoteJobStatuses,
+		"Error":     errMsg,
+	})
+}
+
+// HandleQuoteJobDetail shows a job's failure and the call data it was
+// generating a quote from.
+func (h *QuoteJobHandler) HandleQuoteJobDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.jobID(w, r)
+	if !ok {
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("retried") == "1" {
+		successMsg = "Job queued to run again."
+	}
+	h.renderQuoteJobDetail(w, r, user, id, successMsg, "")
+}
+
+// HandleQuoteJobRetry handles POST to take a dead job out of the dead-letter queue.
+func (h *QuoteJobHandler) HandleQuoteJobRetry(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.jobID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.jobProcessor.RetryJob(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote job not found", http.StatusNotFound)
+			return
+		}
+		errMsg := "Failed to retry job."
+		if apperrors.IsUserError(err) {
+			errMsg = "Failed to retry job: " + err.Error()
+		} else {
+			h.logger.Error("failed to retry quote job", zap.Error(err), zap.String("id", id.String()))
+		}
+		h.renderQuoteJobDetail(w, r, user, id, "", errMsg)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/quote-jobs/%s?retried=1", id), http.StatusSeeOther)
+}
+
+// renderQuoteJobDetail renders a job with its call's transcript and
+// extracted data.
+func (h *QuoteJobHandler) renderQuoteJobDetail(w http.ResponseWriter, r *http.Request, user *domain.User, id uuid.UUID, successMsg, errMsg string) {
+	ctx := r.Context()
+	job, err := h.jobProcessor.GetJobStatus(ctx, id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote job not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote job", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	call, err := h.callService.GetCall(ctx, job.CallID)
+	if err != nil {
+		h.logger.Error("failed to get call for quote job", zap.Error(err), zap.String("id", id.String()))
+		if errMsg == "" {
+			errMsg = "Failed to load the call"
+		}
+	}
+
+	var extracted string
+	if call != nil && call.ExtractedData != nil {
+		if data, err := json.MarshalIndent(call.ExtractedData, "", "  "); err == nil {
+			extracted = string(data)
+		}
+	}
+
+	h.RenderTemplate(w, r, "quote_job_detail", map[string]interface{}{
+		"Title":         "Quote Job",
+		"ActiveNav":     "usage",
+		"User":          user,
+		"Job":           job,
+		"Call":          call,
+		"ExtractedData": extracted,
+		"Success":       successMsg,
+		"Error":         errMsg,
+	})
+}
+
+func (h *QuoteJobHandler) jobID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote job ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// invalidQuoteLinkMessage is shown for links that do not exist or have expired.
+const invalidQuoteLinkMessage = "This quote link is invalid or has expired. Please contact us for a new one."
+
+// QuotePortalHandler serves the pages customers reach from their quote link.
+// No sign-in is needed; the token in the link identifies the quote.
+type QuotePortalHandler struct {
+	*BaseHandler
+	portalService *service.QuotePortalService
+	pdfService    *quotepdf.Service
+}
+
+// QuotePortalHandlerConfig holds configuration for QuotePortalHandler.
+type QuotePortalHandlerConfig struct {
+	Base          BaseHandlerConfig
+	PortalService *service.QuotePortalService
+	PDFService    *quotepdf.Service
+}
+
+// NewQuotePortalHandler creates a new QuotePortalHandler with all required dependencies.
+func NewQuotePortalHandler(cfg QuotePortalHandlerConfig) *QuotePortalHandler {
+	if cfg.PortalService == nil {
+		panic("portalService is required")
+	}
+	if cfg.PDFService == nil {
+		panic("pdfService is required")
+	}
+	return &QuotePortalHandler{
+		BaseHandler:   NewBaseHandler(cfg.Base),
+		portalService: cfg.PortalService,
+		pdfService:    cfg.PDFService,
+	}
+}
+
+// RegisterRoutes registers the customer quote routes on the router.
+// Note: These routes are public; the caller should apply rate limiting.
+func (h *QuotePortalHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/q/{token}", h.HandleQuotePage)
+	r.Get("/q/{token}/pdf", h.HandleQuotePDF)
+	r.With(middleware.BodySizeLimiterForm()).Post("/q/{token}/accept", h.HandleAccept)
+	r.With(middleware.BodySizeLimiterForm()).Post("/q/{token}/decline", h.HandleDecline)
+}
+
+// HandleQuotePage shows the customer their quote.
+func (h *QuotePortalHandler) HandleQuotePage(w http.ResponseWriter, r *http.Request) {
+	view, err := h.portalService.View(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+	h.renderQuotePage(w, r, view, "")
+}
+
+// HandleQuotePDF serves the quote behind a link as a PDF.
+func (h *QuotePortalHandler) HandleQuotePDF(w http.ResponseWriter, r *http.Request) {
+	link, err := h.portalService.Resolve(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	pdf, err := h.pdfService.Render(r.Context(), link.CallID)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", pdf.ContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+pdf.Filename+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(pdf.Data)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(pdf.Data); err != nil {
+		h.logger.Warn("failed to write quote PDF", zap.Error(err))
+	}
+}
+
+// HandleAccept records that the customer accepted their quote.
+func (h *QuotePortalHandler) HandleAccept(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, true)
+}
+
+// HandleDecline records that the customer declined their quote.
+func (h *QuotePortalHandler) HandleDecline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, false)
+}
+
+func (h *QuotePortalHandler) respond(w http.ResponseWriter, r *http.Request, accept bool) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+	resp := service.QuoteResponse{
+		Accept:    accept,
+		Comment:   r.FormValue("comment"),
+		IP:        getClientIP(r),
+		RequestID: GetRequestIDFromContext(r.Context()),
+	}
+	var err error
+	if accept {
+		resp.SignerName = r.FormValue("signer_name")
+		resp.SignatureImage, err = parseSignatureDataURL(r.FormValue("signature"))
+	}
+
+	var view *service.QuotePortalView
+	if err == nil {
+		view, err = h.portalService.Respond(r.Context(), token, resp)
+	}
+	if err != nil {
+		if !apperrors.IsUserError(err) {
+			h.renderError(w, r, err)
+			return
+		}
+		// Show the quote again with what went wrong.
+		view, viewErr := h.portalService.View(r.Context(), token)
+		if viewErr != nil {
+			h.renderError(w, r, viewErr)
+			return
+		}
+		h.renderQuotePage(w, r, view, err.Error())
+		return
+	}
+
+	h.renderQuotePage(w, r, view, "")
+}
+
+// parseSignatureDataURL decodes the drawn signature the page submits as a
+// PNG data URL. An empty value means the customer only typed their name.
+func parseSignatureDataURL(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	const prefix = "data:image/png;base64,"
+	if !strings.HasPrefix(value, prefix) {
+		return nil, apperrors.ValidationFailed("signature image must be a PNG")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, prefix))
+	if err != nil {
+		return nil, apperrors.ValidationFailed("signature image is not valid")
+	}
+	return data, nil
+}
+
+// renderQuotePage renders the customer's quote.
+func (h *QuotePortalHandler) renderQuotePage(w http.ResponseWriter, r *http.Request, view *service.QuotePortalView, errMsg string) {
+	h.RenderTemplate(w, r, "quote_portal", map[string]interface{}{
+		"Title":        "Quote " + view.QuoteNumber,
+		"BusinessName": h.pdfService.BusinessName(r.Context()),
+		"Token":        chi.URLParam(r, "token"),
+		"Quote":        view,
+		"Accepted":     view.Status == domain.QuoteStatusAccepted,
+		"Declined":     view.Status == domain.QuoteStatusDeclined,
+		"Error":        errMsg,
+	})
+}
+
+// renderError renders the page shown when a link can't be used. Unknown,
+// expired and removed quotes look the same to the customer.
+func (h *QuotePortalHandler) renderError(w http.ResponseWriter, r *http.Request, err error) {
+	status, msg := http.StatusNotFound, invalidQuoteLinkMessage
+	if !errors.Is(err, service.ErrQuoteLinkInvalid) && !apperrors.IsNotFound(err) {
+		h.logger.Error("customer quote request failed", zap.Error(err))
+		status, msg = http.StatusInternalServerError, "Something went wrong. Please try again later."
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	h.RenderTemplate(w, r, "quote_portal", map[string]interface{}{
+		"Title":        "Quote",
+		"BusinessName": h.pdfService.BusinessName(r.Context()),
+		"Error":        msg,
+	})
+}
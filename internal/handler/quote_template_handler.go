@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// quoteTemplateBlankRows is the number of empty section and line item rows
+// offered on the quote template form.
+const quoteTemplateBlankRows = 3
+
+// QuoteTemplateHandler serves the quote template admin pages.
+type QuoteTemplateHandler struct {
+	*BaseHandler
+	templateService *service.QuoteTemplateService
+}
+
+// QuoteTemplateHandlerConfig holds configuration for QuoteTemplateHandler.
+type QuoteTemplateHandlerConfig struct {
+	Base            BaseHandlerConfig
+	TemplateService *service.QuoteTemplateService
+}
+
+// NewQuoteTemplateHandler creates a new QuoteTemplateHandler with all required dependencies.
+func NewQuoteTemplateHandler(cfg QuoteTemplateHandlerConfig) *QuoteTemplateHandler {
+	if cfg.TemplateService == nil {
+		panic("templateService is required")
+	}
+	return &QuoteTemplateHandler{
+		BaseHandler:     NewBaseHandler(cfg.Base),
+		templateService: cfg.TemplateService,
+	}
+}
+
+// RegisterRoutes registers quote template routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *QuoteTemplateHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/quote-templates", h.HandleTemplatesPage)
+	r.Get("/quote-templates/new", h.HandleTemplateNew)
+	r.Post("/quote-templates", h.HandleTemplateCreate)
+	r.Get("/quote-templates/{id}", h.HandleTemplateDetail)
+	r.Post("/quote-templates/{id}", h.HandleTemplateUpdate)
+	r.Post("/quote-templates/{id}/delete", h.HandleTemplateDelete)
+}
+
+// HandleTemplatesPage lists the quote templates.
+func (h *QuoteTemplateHandler) HandleTemplatesPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var errMsg string
+	templates, err := h.templateService.ListTemplates(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list quote templates", zap.Error(err))
+		errMsg = "Failed to load quote templates"
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("created") == "1":
+		successMsg = "Quote template created."
+	case r.URL.Query().Get("deleted") == "1":
+		successMsg = "Quote template deleted."
+	}
+
+	h.RenderTemplate(w, r, "quote_templates", map[string]interface{}{
+		"Title":       "Quote Templates",
+		"ActiveNav":   "quote-templates",
+		"User":        user,
+		"Templates":   templates,
+		"DefaultType": domain.DefaultPricingProjectType,
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// HandleTemplateNew shows the form for a new quote template.
+func (h *QuoteTemplateHandler) HandleTemplateNew(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	h.renderTemplateForm(w, r, &domain.QuoteTemplate{IsActive: true}, true, "", "")
+}
+
+// HandleTemplateCreate handles POST to create a quote template.
+func (h *QuoteTemplateHandler) HandleTemplateCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderTemplateForm(w, r, &domain.QuoteTemplate{IsActive: true}, true, "", "Invalid form submission.")
+		return
+	}
+
+	req, err := parseQuoteTemplateForm(r)
+	if err != nil {
+		h.renderTemplateForm(w, r, quoteTemplateFromRequest(req), true, "", err.Error())
+		return
+	}
+
+	if _, err := h.templateService.CreateTemplate(r.Context(), req); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderTemplateForm(w, r, quoteTemplateFromRequest(req), true, "", "Failed to create quote template: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create quote template", zap.Error(err))
+		h.renderTemplateForm(w, r, quoteTemplateFromRequest(req), true, "", "Failed to create quote template.")
+		return
+	}
+
+	http.Redirect(w, r, "/quote-templates?created=1", http.StatusSeeOther)
+}
+
+// HandleTemplateDetail shows the edit form for a quote template.
+func (h *QuoteTemplateHandler) HandleTemplateDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote template ID", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.templateService.GetTemplate(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote template not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get quote template", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("updated") == "1" {
+		successMsg = "Quote template updated."
+	}
+	h.renderTemplateForm(w, r, template, false, successMsg, "")
+}
+
+// HandleTemplateUpdate handles POST to edit a quote template.
+func (h *QuoteTemplateHandler) HandleTemplateUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote template ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	req, err := parseQuoteTemplateForm(r)
+	failed := func(msg string) {
+		template := quoteTemplateFromRequest(req)
+		template.ID = id
+		h.renderTemplateForm(w, r, template, false, "", msg)
+	}
+	if err != nil {
+		failed(err.Error())
+		return
+	}
+
+	if _, err := h.templateService.UpdateTemplate(r.Context(), id, req); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote template not found", http.StatusNotFound)
+			return
+		}
+		if apperrors.IsUserError(err) {
+			failed("Failed to update quote template: " + err.Error())
+			return
+		}
+		h.logger.Error("failed to update quote template", zap.Error(err), zap.String("id", id.String()))
+		failed("Failed to update quote template.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/quote-templates/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandleTemplateDelete handles POST to delete a quote template.
+func (h *QuoteTemplateHandler) HandleTemplateDelete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid quote template ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.templateService.DeleteTemplate(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Quote template not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to delete quote template", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Failed to delete quote template", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/quote-templates?deleted=1", http.StatusSeeOther)
+}
+
+// renderTemplateForm renders the create or edit form for a quote template,
+// padding its sections and line items with blank rows to fill in.
+func (h *QuoteTemplateHandler) renderTemplateForm(w http.ResponseWriter, r *http.Request, template *domain.QuoteTemplate, isNew bool, successMsg, errMsg string) {
+	sections := append([]domain.QuoteTemplateSection(nil), template.Sections...)
+	lineItems := append([]domain.QuoteTemplateLineItem(nil), template.DefaultLineItems...)
+	for i := 0; i < quoteTemplateBlankRows; i++ {
+		sections = append(sections, domain.QuoteTemplateSection{})
+		lineItems = append(lineItems, domain.QuoteTemplateLineItem{})
+	}
+
+	title := "New Quote Template"
+	if !isNew {
+		title = "Quote Template: " + template.Name
+	}
+
+	h.RenderTemplate(w, r, "quote_template", map[string]interface{}{
+		"Title":     title,
+		"ActiveNav": "quote-templates",
+		"User":      GetUserFromContext(r.Context()),
+		"Template":  template,
+		"IsNew":     isNew,
+		"Sections":  sections,
+		"LineItems": lineItems,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
+
+// parseQuoteTemplateForm reads a quote template from a submitted form.
+// Section and line item rows are submitted as parallel fields; blank rows
+// are skipped. The request is returned even on error so the form can be
+// redisplayed.
+func parseQuoteTemplateForm(r *http.Request) (*service.QuoteTemplateRequest, error) {
+	req := &service.QuoteTemplateRequest{
+		ProjectType: r.FormValue("project_type"),
+		Name:        r.FormValue("name"),
+		Assumptions: r.FormValue("assumptions"),
+		IsActive:    r.FormValue("is_active") != "",
+	}
+
+	var errs []string
+	parseNumber := func(field, value string) float64 {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", ""), 64)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %q is not a number", field, value))
+		}
+		return n
+	}
+
+	headings, bodies := r.Form["section_heading"], r.Form["section_body"]
+	for i := range headings {
+		body := formIndex(bodies, i)
+		if strings.TrimSpace(headings[i]+body) == "" {
+			continue
+		}
+		req.Sections = append(req.Sections, domain.QuoteTemplateSection{
+			Heading: headings[i],
+			Body:    body,
+		})
+	}
+
+	descriptions, quantities, prices := r.Form["item_description"], r.Form["item_quantity"], r.Form["item_unit_price"]
+	for i := range descriptions {
+		quantity, price := formIndex(quantities, i), formIndex(prices, i)
+		if strings.TrimSpace(descriptions[i]+quantity+price) == "" {
+			continue
+		}
+		item := domain.QuoteTemplateLineItem{
+			Description: descriptions[i],
+			Quantity:    1,
+			UnitPrice:   parseNumber("unit price", price),
+		}
+		if strings.TrimSpace(quantity) != "" {
+			item.Quantity = parseNumber("quantity", quantity)
+		}
+		req.DefaultLineItems = append(req.DefaultLineItems, item)
+	}
+
+	if len(errs) > 0 {
+		return req, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return req, nil
+}
+
+// quoteTemplateFromRequest builds an unsaved template for redisplaying a form.
+func quoteTemplateFromRequest(req *service.QuoteTemplateRequest) *domain.QuoteTemplate {
+	return &domain.QuoteTemplate{
+		ProjectType:      req.ProjectType,
+		Name:             req.Name,
+		Sections:         req.Sections,
+		DefaultLineItems: req.DefaultLineItems,
+		Assumptions:      req.Assumptions,
+		IsActive:         req.IsActive,
+	}
+}
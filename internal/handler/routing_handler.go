@@ -0,0 +1,324 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// RoutingHandler serves the inbound routing rule admin pages.
+type RoutingHandler struct {
+	*BaseHandler
+	routingService *service.RoutingService
+	promptService  *service.PromptService
+}
+
+// RoutingHandlerConfig holds configuration for RoutingHandler.
+type RoutingHandlerConfig struct {
+	Base           BaseHandlerConfig
+	RoutingService *service.RoutingService
+	PromptService  *service.PromptService
+}
+
+// NewRoutingHandler creates a new RoutingHandler with all required dependencies.
+func NewRoutingHandler(cfg RoutingHandlerConfig) *RoutingHandler {
+	if cfg.RoutingService == nil {
+		panic("routingService is required")
+	}
+	return &RoutingHandler{
+		BaseHandler:    NewBaseHandler(cfg.Base),
+		routingService: cfg.RoutingService,
+		promptService:  cfg.PromptService,
+	}
+}
+
+// RegisterRoutes registers routing rule routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *RoutingHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/routing", h.HandleRoutingPage)
+	r.Get("/routing/new", h.HandleRoutingRuleNew)
+	r.Post("/routing", h.HandleRoutingRuleCreate)
+	r.Get("/routing/{id}", h.HandleRoutingRuleDetail)
+	r.Post("/routing/{id}", h.HandleRoutingRuleUpdate)
+	r.Post("/routing/{id}/delete", h.HandleRoutingRuleDelete)
+}
+
+// HandleRoutingPage lists the routing rules in the order they are checked.
+func (h *RoutingHandler) HandleRoutingPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var errMsg string
+	rules, err := h.routingService.ListRules(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list routing rules", zap.Error(err))
+		errMsg = "Failed to load routing rules"
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("created") == "1":
+		successMsg = "Routing rule created."
+	case r.URL.Query().Get("deleted") == "1":
+		successMsg = "Routing rule deleted."
+	}
+
+	h.RenderTemplate(w, r, "routing", map[string]interface{}{
+		"Title":       "Routing",
+		"ActiveNav":   "routing",
+		"User":        user,
+		"Rules":       rules,
+		"PromptNames": h.promptNames(r),
+		"Success":     successMsg,
+		"Error":       errMsg,
+	})
+}
+
+// HandleRoutingRuleNew shows the form for a new routing rule.
+func (h *RoutingHandler) HandleRoutingRuleNew(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	h.renderRoutingRuleForm(w, r, &domain.RoutingRule{IsActive: true}, true, "", "")
+}
+
+// HandleRoutingRuleCreate handles POST to create a routing rule.
+func (h *RoutingHandler) HandleRoutingRuleCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderRoutingRuleForm(w, r, &domain.RoutingRule{IsActive: true}, true, "", "Invalid form submission.")
+		return
+	}
+
+	req, err := parseRoutingRuleForm(r)
+	if err != nil {
+		h.renderRoutingRuleForm(w, r, routingRuleFromRequest(req), true, "", err.Error())
+		return
+	}
+
+	if _, err := h.routingService.CreateRule(r.Context(), req); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderRoutingRuleForm(w, r, routingRuleFromRequest(req), true, "", "Failed to create routing rule: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to create routing rule", zap.Error(err))
+		h.renderRoutingRuleForm(w, r, routingRuleFromRequest(req), true, "", "Failed to create routing rule.")
+		return
+	}
+
+	http.Redirect(w, r, "/routing?created=1", http.StatusSeeOther)
+}
+
+// HandleRoutingRuleDetail shows the edit form for a routing rule.
+func (h *RoutingHandler) HandleRoutingRuleDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid routing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := h.routingService.GetRule(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Routing rule not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get routing rule", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("updated") == "1" {
+		successMsg = "Routing rule updated."
+	}
+	h.renderRoutingRuleForm(w, r, rule, false, successMsg, "")
+}
+
+// HandleRoutingRuleUpdate handles POST to edit a routing rule.
+func (h *RoutingHandler) HandleRoutingRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid routing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+
+	req, err := parseRoutingRuleForm(r)
+	failed := func(msg string) {
+		rule := routingRuleFromRequest(req)
+		rule.ID = id
+		h.renderRoutingRuleForm(w, r, rule, false, "", msg)
+	}
+	if err != nil {
+		failed(err.Error())
+		return
+	}
+
+	if _, err := h.routingService.UpdateRule(r.Context(), id, req); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Routing rule not found", http.StatusNotFound)
+			return
+		}
+		if apperrors.IsUserError(err) {
+			failed("Failed to update routing rule: " + err.Error())
+			return
+		}
+		h.logger.Error("failed to update routing rule", zap.Error(err), zap.String("id", id.String()))
+		failed("Failed to update routing rule.")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/routing/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandleRoutingRuleDelete handles POST to delete a routing rule.
+func (h *RoutingHandler) HandleRoutingRuleDelete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid routing rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.routingService.DeleteRule(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Routing rule not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to delete routing rule", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Failed to delete routing rule", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/routing?deleted=1", http.StatusSeeOther)
+}
+
+// renderRoutingRuleForm renders the create or edit form for a routing rule.
+func (h *RoutingHandler) renderRoutingRuleForm(w http.ResponseWriter, r *http.Request, rule *domain.RoutingRule, isNew bool, successMsg, errMsg string) {
+	var prompts []*domain.Prompt
+	if h.promptService != nil {
+		var err error
+		prompts, _, err = h.promptService.ListPrompts(r.Context(), 1, 100, true)
+		if err != nil {
+			h.logger.Warn("failed to list presets", zap.Error(err))
+		}
+	}
+
+	promptID := ""
+	if rule.PromptID != nil {
+		promptID = rule.PromptID.String()
+	}
+
+	title := "New Routing Rule"
+	if !isNew {
+		title = "Routing: " + rule.Name
+	}
+
+	h.RenderTemplate(w, r, "routing_rule", map[string]interface{}{
+		"Title":     title,
+		"ActiveNav": "routing",
+		"User":      GetUserFromContext(r.Context()),
+		"Rule":      rule,
+		"IsNew":     isNew,
+		"AreaCodes": strings.Join(rule.AreaCodes, ", "),
+		"Regions":   strings.Join(rule.Regions, ", "),
+		"PromptID":  promptID,
+		"Prompts":   prompts,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
+
+// promptNames maps prompt IDs to names for the rule list.
+func (h *RoutingHandler) promptNames(r *http.Request) map[string]string {
+	names := make(map[string]string)
+	if h.promptService == nil {
+		return names
+	}
+	prompts, _, err := h.promptService.ListPrompts(r.Context(), 1, 100, true)
+	if err != nil {
+		h.logger.Warn("failed to list presets", zap.Error(err))
+		return names
+	}
+	for _, p := range prompts {
+		names[p.ID.String()] = p.Name
+	}
+	return names
+}
+
+// parseRoutingRuleForm reads a routing rule from a submitted form. The
+// request is returned even on error so the form can be redisplayed.
+func parseRoutingRuleForm(r *http.Request) (*service.RoutingRuleRequest, error) {
+	req := &service.RoutingRuleRequest{
+		Name:           r.FormValue("name"),
+		AreaCodes:      strings.FieldsFunc(r.FormValue("area_codes"), isListSeparator),
+		Regions:        strings.FieldsFunc(r.FormValue("regions"), isListSeparator),
+		PromptID:       formPromptID(r.FormValue("prompt_id")),
+		TransferNumber: r.FormValue("transfer_number"),
+		IsActive:       r.FormValue("is_active") != "",
+	}
+
+	if value := strings.TrimSpace(r.FormValue("priority")); value != "" {
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return req, fmt.Errorf("priority %q is not a whole number", value)
+		}
+		req.Priority = priority
+	}
+	return req, nil
+}
+
+// routingRuleFromRequest builds an unsaved rule for redisplaying a form.
+func routingRuleFromRequest(req *service.RoutingRuleRequest) *domain.RoutingRule {
+	return &domain.RoutingRule{
+		Name:           req.Name,
+		Priority:       req.Priority,
+		AreaCodes:      req.AreaCodes,
+		Regions:        req.Regions,
+		PromptID:       req.PromptID,
+		TransferNumber: req.TransferNumber,
+		IsActive:       req.IsActive,
+	}
+}
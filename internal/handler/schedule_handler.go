@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ScheduleHandler serves the business hours admin page.
+type ScheduleHandler struct {
+	*BaseHandler
+	scheduleService *service.ScheduleService
+	promptService   *service.PromptService
+}
+
+// ScheduleHandlerConfig holds configuration for ScheduleHandler.
+type ScheduleHandlerConfig struct {
+	Base            BaseHandlerConfig
+	ScheduleService *service.ScheduleService
+	PromptService   *service.PromptService
+}
+
+// scheduleDayRow is one day's opening hours on the schedule form.
+type scheduleDayRow struct {
+	Day   string
+	Open  string
+	Close string
+}
+
+// NewScheduleHandler creates a new ScheduleHandler with all required dependencies.
+func NewScheduleHandler(cfg ScheduleHandlerConfig) *ScheduleHandler {
+	if cfg.ScheduleService == nil {
+		panic("scheduleService is required")
+	}
+	return &ScheduleHandler{
+		BaseHandler:     NewBaseHandler(cfg.Base),
+		scheduleService: cfg.ScheduleService,
+		promptService:   cfg.PromptService,
+	}
+}
+
+// RegisterRoutes registers business hours routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *ScheduleHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/schedule", h.HandleSchedulePage)
+	r.Post("/schedule", h.HandleScheduleSave)
+}
+
+// HandleSchedulePage shows the business hours, holidays and after hours
+// agent.
+func (h *ScheduleHandler) HandleSchedulePage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("saved") == "1" {
+		successMsg = "Schedule saved."
+	}
+
+	status, err := h.scheduleService.Status(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get business schedule", zap.Error(err))
+		h.renderSchedulePage(w, r, &domain.BusinessSchedule{}, nil, "", "Failed to load the schedule")
+		return
+	}
+	h.renderSchedulePage(w, r, status.BusinessSchedule, status, successMsg, "")
+}
+
+// HandleScheduleSave handles POST to save the schedule.
+func (h *ScheduleHandler) HandleScheduleSave(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderSchedulePage(w, r, &domain.BusinessSchedule{}, nil, "", "Invalid form submission.")
+		return
+	}
+
+	schedule := &domain.BusinessSchedule{
+		Enabled:      r.FormValue("enabled") == "on",
+		Timezone:     r.FormValue("timezone"),
+		Hours:        make(map[string]domain.OpeningHours),
+		Holidays:     parseHolidayLines(r.FormValue("holidays")),
+		PhoneNumbers: strings.FieldsFunc(r.FormValue("phone_numbers"), isListSeparator),
+		PromptID:     formPromptID(r.FormValue("prompt_id")),
+		AfterHours: domain.AfterHours{
+			Mode:          domain.AfterHoursMode(r.FormValue("after_hours_mode")),
+			PromptID:      formPromptID(r.FormValue("after_hours_prompt_id")),
+			FirstSentence: r.FormValue("after_hours_first_sentence"),
+		},
+	}
+	for _, day := range scheduleWeekdays() {
+		schedule.Hours[day] = domain.OpeningHours{
+			Open:  r.FormValue("open_" + day),
+			Close: r.FormValue("close_" + day),
+		}
+	}
+
+	if _, err := h.scheduleService.SaveSchedule(r.Context(), schedule); err != nil {
+		if apperrors.IsUserError(err) {
+			h.renderSchedulePage(w, r, schedule, nil, "", "Failed to save schedule: "+err.Error())
+			return
+		}
+		h.logger.Error("failed to save business schedule", zap.Error(err))
+		h.renderSchedulePage(w, r, schedule, nil, "", "Failed to save schedule.")
+		return
+	}
+
+	http.Redirect(w, r, "/schedule?saved=1", http.StatusSeeOther)
+}
+
+// renderSchedulePage renders the schedule form for schedule; status is
+// shown when the schedule is the stored one.
+func (h *ScheduleHandler) renderSchedulePage(w http.ResponseWriter, r *http.Request, schedule *domain.BusinessSchedule, status *service.BusinessScheduleStatus, successMsg, errMsg string) {
+	ctx := r.Context()
+
+	var prompts []*domain.Prompt
+	if h.promptService != nil {
+		var err error
+		prompts, _, err = h.promptService.ListPrompts(ctx, 1, 100, true)
+		if err != nil {
+			h.logger.Warn("failed to list presets", zap.Error(err))
+		}
+	}
+
+	days := make([]scheduleDayRow, 0, 7)
+	for _, day := range scheduleWeekdays() {
+		hours := schedule.Hours[day]
+		days = append(days, scheduleDayRow{Day: day, Open: hours.Open, Close: hours.Close})
+	}
+	holidays := make([]string, 0, len(schedule.Holidays))
+	for _, holiday := range schedule.Holidays {
+		holidays = append(holidays, strings.TrimSpace(holiday.Date+" "+holiday.Name))
+	}
+	promptID, afterHoursPromptID := "", ""
+	if schedule.PromptID != nil {
+		promptID = schedule.PromptID.String()
+	}
+	if schedule.AfterHours.PromptID != nil {
+		afterHoursPromptID = schedule.AfterHours.PromptID.String()
+	}
+
+	h.RenderTemplate(w, r, "schedule", map[string]interface{}{
+		"Title":              "Business Hours",
+		"ActiveNav":          "schedule",
+		"User":               GetUserFromContext(ctx),
+		"Schedule":           schedule,
+		"Status":             status,
+		"Days":               days,
+		"Holidays":           strings.Join(holidays, "\n"),
+		"PhoneNumbers":       strings.Join(schedule.PhoneNumbers, "\n"),
+		"PromptID":           promptID,
+		"AfterHoursMode":     string(schedule.AfterHours.Mode),
+		"AfterHoursPromptID": afterHoursPromptID,
+		"Prompts":            prompts,
+		"Success":            successMsg,
+		"Error":              errMsg,
+	})
+}
+
+// scheduleWeekdays lists the lowercase weekday names from Monday.
+func scheduleWeekdays() []string {
+	days := make([]string, 0, 7)
+	for i := 1; i <= 7; i++ {
+		days = append(days, strings.ToLower(time.Weekday(i%7).String()))
+	}
+	return days
+}
+
+// parseHolidayLines reads one holiday per line: a date, then an optional
+// name, such as "2026-12-25 Christmas Day".
+func parseHolidayLines(text string) []domain.Holiday {
+	var holidays []domain.Holiday
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		holidays = append(holidays, domain.Holiday{
+			Date: fields[0],
+			Name: strings.Join(fields[1:], " "),
+		})
+	}
+	return holidays
+}
+
+// isListSeparator splits lists typed one per line or separated by commas.
+func isListSeparator(r rune) bool {
+	return r == '\n' || r == '\r' || r == ',' || r == ' '
+}
+
+// formPromptID parses an optional prompt selection.
+func formPromptID(value string) *uuid.UUID {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
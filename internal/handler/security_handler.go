@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/qrcode"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// SecurityHandler serves the account security page, where users manage
+// two-factor authentication.
+type SecurityHandler struct {
+	*BaseHandler
+	authService *service.AuthService
+}
+
+// SecurityHandlerConfig holds configuration for SecurityHandler.
+type SecurityHandlerConfig struct {
+	Base        BaseHandlerConfig
+	AuthService *service.AuthService
+}
+
+// NewSecurityHandler creates a new SecurityHandler with all required dependencies.
+func NewSecurityHandler(cfg SecurityHandlerConfig) *SecurityHandler {
+	if cfg.AuthService == nil {
+		panic("authService is required")
+	}
+	return &SecurityHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		authService: cfg.AuthService,
+	}
+}
+
+// RegisterRoutes registers account security routes on the router.
+// Note: These routes require authentication middleware to be applied by the caller.
+func (h *SecurityHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/account/security", h.HandleSecurityPage)
+	r.Post("/account/security/2fa/setup", h.HandleTwoFactorSetup)
+	r.Post("/account/security/2fa/enable", h.HandleTwoFactorEnable)
+	r.Post("/account/security/2fa/disable", h.HandleTwoFactorDisable)
+	r.Post("/account/security/2fa/recovery-codes", h.HandleRecoveryCodes)
+}
+
+// HandleSecurityPage shows the user's two-factor authentication status.
+func (h *SecurityHandler) HandleSecurityPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg, errMsg string
+	switch {
+	case r.URL.Query().Get("disabled") == "1":
+		successMsg = "Two-factor authentication disabled."
+	case r.URL.Query().Get("required") == "1":
+		errMsg = "Enable two-factor authentication to continue to that page."
+	}
+
+	h.renderSecurityPage(w, r, user, nil, successMsg, errMsg)
+}
+
+// HandleTwoFactorSetup generates a new secret and shows its QR code.
+func (h *SecurityHandler) HandleTwoFactorSetup(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := h.authService.BeginTwoFactorSetup(r.Context(), user.ID); err != nil {
+		h.logger.Error("failed to begin two-factor setup", zap.Error(err))
+		h.renderSecurityPage(w, r, user, nil, "", securityErrorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/account/security", http.StatusSeeOther)
+}
+
+// HandleTwoFactorEnable confirms setup with a code from the authenticator
+// app and shows the recovery codes, which are never shown again.
+func (h *SecurityHandler) HandleTwoFactorEnable(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderSecurityPage(w, r, user, nil, "", "Invalid form submission.")
+		return
+	}
+
+	loginCtx := &service.LoginContext{
+		IPAddress: getClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+	codes, session, err := h.authService.EnableTwoFactor(r.Context(), user.ID, r.FormValue("code"), loginCtx)
+	if err != nil {
+		h.logger.Warn("failed to enable two-factor authentication", zap.Error(err))
+		h.renderSecurityPage(w, r, user, nil, "", securityErrorMessage(err))
+		return
+	}
+
+	// Other sessions, including the one this request came in on, were
+	// signed out.
+	setSessionCookie(w, r, session.Token, int(time.Until(session.ExpiresAt).Seconds()))
+
+	h.reloadAndRender(w, r, user, codes, "Two-factor authentication enabled.")
+}
+
+// HandleTwoFactorDisable turns off two-factor authentication.
+func (h *SecurityHandler) HandleTwoFactorDisable(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderSecurityPage(w, r, user, nil, "", "Invalid form submission.")
+		return
+	}
+
+	err := h.authService.DisableTwoFactor(r.Context(), user.ID, r.FormValue("password"), r.FormValue("code"))
+	if err != nil {
+		h.logger.Warn("failed to disable two-factor authentication", zap.Error(err))
+		h.renderSecurityPage(w, r, user, nil, "", securityErrorMessage(err))
+		return
+	}
+
+	http.Redirect(w, r, "/account/security?disabled=1", http.StatusSeeOther)
+}
+
+// HandleRecoveryCodes replaces the user's recovery codes.
+func (h *SecurityHandler) HandleRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderSecurityPage(w, r, user, nil, "", "Invalid form submission.")
+		return
+	}
+
+	codes, err := h.authService.RegenerateRecoveryCodes(r.Context(), user.ID, r.FormValue("code"))
+	if err != nil {
+		h.logger.Warn("failed to regenerate recovery codes", zap.Error(err))
+		h.renderSecurityPage(w, r, user, nil, "", securityErrorMessage(err))
+		return
+	}
+
+	h.reloadAndRender(w, r, user, codes, "New recovery codes generated. The old codes no longer work.")
+}
+
+// reloadAndRender renders the page with freshly loaded user state, since the
+// user in the request context predates the change just made.
+func (h *SecurityHandler) reloadAndRender(w http.ResponseWriter, r *http.Request, user *domain.User, codes []string, successMsg string) {
+	if fresh, err := h.authService.GetUser(r.Context(), user.ID); err == nil {
+		user = fresh
+	}
+	h.renderSecurityPage(w, r, user, codes, successMsg, "")
+}
+
+// renderSecurityPage renders the security page. Recovery codes are passed
+// only right after they are generated.
+func (h *SecurityHandler) renderSecurityPage(w http.ResponseWriter, r *http.Request, user *domain.User, codes []string, successMsg, errMsg string) {
+	data := map[string]interface{}{
+		"Title":         "Account Security",
+		"ActiveNav":     "security",
+		"User":          user,
+		"Available":     h.authService.TwoFactorAvailable(),
+		"Enabled":       user.TwoFactorEnabled(),
+		"RecoveryCodes": codes,
+		"Success":       successMsg,
+		"Error":         errMsg,
+	}
+
+	if user.TwoFactorEnabled() {
+		remaining, err := h.authService.RecoveryCodesRemaining(r.Context(), user.ID)
+		if err != nil {
+			h.logger.Error("failed to count recovery codes", zap.Error(err))
+		}
+		data["RecoveryCodesRemaining"] = remaining
+	}
+
+	if setup := h.authService.PendingTwoFactorSetup(user); setup != nil {
+		data["Secret"] = setup.Secret
+		svg, err := qrcode.SVG(setup.URI)
+		if err != nil {
+			h.logger.Error("failed to render two-factor QR code", zap.Error(err))
+		} else {
+			// Generated markup containing only path data, safe to embed.
+			data["QRCode"] = template.HTML(svg)
+		}
+	}
+
+	h.RenderTemplate(w, r, "security", data)
+}
+
+// securityErrorMessage returns a message for the user describing err.
+func securityErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactor):
+		return "Invalid authentication code."
+	case errors.Is(err, service.ErrInvalidCredentials):
+		return "Incorrect password."
+	case apperrors.IsUserError(err):
+		return err.Error()
+	default:
+		return "Something went wrong. Please try again."
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// SpamHandler serves the spam review queue admin page.
+type SpamHandler struct {
+	*BaseHandler
+	spamService *service.SpamService
+}
+
+// SpamHandlerConfig holds configuration for SpamHandler.
+type SpamHandlerConfig struct {
+	Base        BaseHandlerConfig
+	SpamService *service.SpamService
+}
+
+// NewSpamHandler creates a new SpamHandler with all required dependencies.
+func NewSpamHandler(cfg SpamHandlerConfig) *SpamHandler {
+	if cfg.SpamService == nil {
+		panic("spamService is required")
+	}
+	return &SpamHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		spamService: cfg.SpamService,
+	}
+}
+
+// RegisterRoutes registers spam review routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *SpamHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/spam", h.HandleSpamPage)
+	r.Post("/spam/{id}/block", h.HandleSpamBlock)
+	r.Post("/spam/{id}/dismiss", h.HandleSpamDismiss)
+}
+
+// HandleSpamPage lists flagged callers, those waiting for review unless
+// another status is asked for.
+func (h *SpamHandler) HandleSpamPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	status := domain.SpamCallerStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = domain.SpamCallerPending
+	}
+
+	var errMsg string
+	callers, err := h.spamService.ListQueue(r.Context(), status)
+	if err != nil {
+		if apperrors.IsUserError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.logger.Error("failed to list spam callers", zap.Error(err))
+		errMsg = "Failed to load flagged callers"
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("blocked") == "1":
+		successMsg = "Caller blocked."
+	case r.URL.Query().Get("dismissed") == "1":
+		successMsg = "Caller marked as not spam."
+	}
+
+	h.RenderTemplate(w, r, "spam", map[string]interface{}{
+		"Title":     "Spam",
+		"ActiveNav": "spam",
+		"User":      user,
+		"Callers":   callers,
+		"Status":    string(status),
+		"Statuses":  domain.SpamCallerStatuses,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
+
+// HandleSpamBlock confirms a flagged caller as spam and blocks them.
+func (h *SpamHandler) HandleSpamBlock(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, "blocked", h.spamService.Block)
+}
+
+// HandleSpamDismiss marks a flagged caller as not spam.
+func (h *SpamHandler) HandleSpamDismiss(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, "dismissed", h.spamService.Dismiss)
+}
+
+func (h *SpamHandler) review(w http.ResponseWriter, r *http.Request, done string, action func(ctx context.Context, id, reviewer uuid.UUID) error) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid spam caller ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := action(r.Context(), id, user.ID); err != nil {
+		switch {
+		case apperrors.IsNotFound(err):
+			http.Error(w, "Spam caller not found", http.StatusNotFound)
+		case apperrors.IsUserError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error("failed to review spam caller", zap.String("id", id.String()), zap.Error(err))
+			http.Error(w, "Failed to review caller", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, "/spam?"+done+"=1", http.StatusSeeOther)
+}
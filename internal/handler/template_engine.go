@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/quotepdf"
 )
 
 // TemplateEngine handles parsing and rendering of HTML templates.
@@ -51,16 +53,17 @@ func NewTemplateEngine(templatesDir string, logger *zap.Logger) (*TemplateEngine
 			}
 			return a / b
 		},
-		"gt": func(a, b float64) bool {
-			return a > b
+		"gt": func(a, b interface{}) bool {
+			return toFloat(a) > toFloat(b)
 		},
-		"lt": func(a, b float64) bool {
-			return a < b
+		"lt": func(a, b interface{}) bool {
+			return toFloat(a) < toFloat(b)
 		},
 		"eq": func(a, b interface{}) bool {
 			return a == b
 		},
 		"printf": fmt.Sprintf,
+		"money":  quotepdf.FormatMoney,
 		"deref": func(s *string) string {
 			if s == nil {
 				return ""
@@ -179,3 +182,22 @@ func (te *TemplateEngine) HasTemplate(name string) bool {
 	_, ok := te.templates[name]
 	return ok
 }
+
+// toFloat converts a numeric template value to float64 so comparisons work
+// across int and float fields.
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case float64:
+		return n
+	case float32:
+		return float64(n)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// UserHandler serves the user management admin pages.
+type UserHandler struct {
+	*BaseHandler
+	userService *service.UserService
+}
+
+// UserHandlerConfig holds configuration for UserHandler.
+type UserHandlerConfig struct {
+	Base        BaseHandlerConfig
+	UserService *service.UserService
+}
+
+// NewUserHandler creates a new UserHandler with all required dependencies.
+func NewUserHandler(cfg UserHandlerConfig) *UserHandler {
+	if cfg.UserService == nil {
+		panic("userService is required")
+	}
+	return &UserHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		userService: cfg.UserService,
+	}
+}
+
+// RegisterRoutes registers user management routes on the router.
+// Note: These routes require authentication and admin middleware to be
+// applied by the caller.
+func (h *UserHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/users", h.HandleUsersPage)
+	r.Post("/admin/users", h.HandleUserInvite)
+	r.Post("/admin/users/{id}/role", h.HandleUserRole)
+	r.Post("/admin/users/{id}/disable", h.HandleUserDisable)
+	r.Post("/admin/users/{id}/enable", h.HandleUserEnable)
+	r.Post("/admin/users/{id}/invitation", h.HandleUserReinvite)
+	r.Post("/admin/users/{id}/delete", h.HandleUserDelete)
+}
+
+// HandleUsersPage lists users with the invite form.
+func (h *UserHandler) HandleUsersPage(w http.ResponseWriter, r *http.Request) {
+	var successMsg string
+	switch r.URL.Query().Get("done") {
+	case "role":
+		successMsg = "Role updated."
+	case "disabled":
+		successMsg = "User disabled and signed out."
+	case "enabled":
+		successMsg = "User enabled."
+	case "deleted":
+		successMsg = "User deleted."
+	}
+
+	h.renderUsersPage(w, r, successMsg, "", "")
+}
+
+// HandleUserInvite creates a user and shows their invitation link.
+func (h *UserHandler) HandleUserInvite(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.renderUsersPage(w, r, "", "Invalid form submission.", "")
+		return
+	}
+
+	user, inviteURL, err := h.userService.Invite(r.Context(), userActor(r), service.InviteUserRequest{
+		Email: r.FormValue("email"),
+		Role:  domain.UserRole(r.FormValue("role")),
+	})
+	if err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("invite user", err), "")
+		return
+	}
+
+	h.renderUsersPage(w, r, "Invited "+user.Email+".", "", inviteURL)
+}
+
+// HandleUserReinvite issues a new invitation link.
+func (h *UserHandler) HandleUserReinvite(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	user, inviteURL, err := h.userService.ResendInvitation(r.Context(), userActor(r), id)
+	if err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("resend invitation", err), "")
+		return
+	}
+
+	h.renderUsersPage(w, r, "New invitation issued for "+user.Email+". Earlier links no longer work.", "", inviteURL)
+}
+
+// HandleUserRole changes a user's role.
+func (h *UserHandler) HandleUserRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.renderUsersPage(w, r, "", "Invalid form submission.", "")
+		return
+	}
+
+	if _, err := h.userService.SetRole(r.Context(), userActor(r), id, domain.UserRole(r.FormValue("role"))); err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("change role", err), "")
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users?done=role", http.StatusSeeOther)
+}
+
+// HandleUserDisable disables a user.
+func (h *UserHandler) HandleUserDisable(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.userService.Disable(r.Context(), userActor(r), id); err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("disable user", err), "")
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users?done=disabled", http.StatusSeeOther)
+}
+
+// HandleUserEnable re-enables a disabled user.
+func (h *UserHandler) HandleUserEnable(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.userService.Enable(r.Context(), userActor(r), id); err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("enable user", err), "")
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users?done=enabled", http.StatusSeeOther)
+}
+
+// HandleUserDelete deletes a user.
+func (h *UserHandler) HandleUserDelete(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.userService.Delete(r.Context(), userActor(r), id); err != nil {
+		h.renderUsersPage(w, r, "", h.userErrorMessage("delete user", err), "")
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users?done=deleted", http.StatusSeeOther)
+}
+
+// parseID parses the id path parameter, showing an error if it is invalid.
+func (h *UserHandler) parseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderUsersPage(w, r, "", "Invalid user ID.", "")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// renderUsersPage renders the user list. inviteURL is shown right after an
+// invitation is issued, so the admin can pass it on.
+func (h *UserHandler) renderUsersPage(w http.ResponseWriter, r *http.Request, successMsg, errMsg, inviteURL string) {
+	user := GetUserFromContext(r.Context())
+
+	users, err := h.userService.List(r.Context(), userActor(r))
+	if err != nil {
+		h.logger.Error("failed to list users", zap.Error(err))
+		if errMsg == "" {
+			errMsg = "Failed to load users."
+		}
+	}
+
+	h.RenderTemplate(w, r, "users", map[string]interface{}{
+		"Title":     "Users",
+		"ActiveNav": "users",
+		"User":      user,
+		"Users":     users,
+		"Roles":     []domain.UserRole{domain.UserRoleMember, domain.UserRoleAdmin},
+		"InviteURL": inviteURL,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
+
+// userErrorMessage returns a message for the admin describing err.
+func (h *UserHandler) userErrorMessage(action string, err error) string {
+	if apperrors.IsUserError(err) {
+		return "Failed to " + action + ": " + err.Error()
+	}
+	h.logger.Error("failed to "+action, zap.Error(err))
+	return "Failed to " + action + "."
+}
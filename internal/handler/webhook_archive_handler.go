@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// WebhookArchiveHandler serves the raw webhook archive admin pages, where
+// provider payloads are inspected, parsed again and re-dispatched.
+type WebhookArchiveHandler struct {
+	*BaseHandler
+	archive *service.WebhookArchiveService
+}
+
+// WebhookArchiveHandlerConfig holds configuration for WebhookArchiveHandler.
+type WebhookArchiveHandlerConfig struct {
+	Base    BaseHandlerConfig
+	Archive *service.WebhookArchiveService
+}
+
+// NewWebhookArchiveHandler creates a new WebhookArchiveHandler with all required dependencies.
+func NewWebhookArchiveHandler(cfg WebhookArchiveHandlerConfig) *WebhookArchiveHandler {
+	if cfg.Archive == nil {
+		panic("archive is required")
+	}
+	return &WebhookArchiveHandler{
+		BaseHandler: NewBaseHandler(cfg.Base),
+		archive:     cfg.Archive,
+	}
+}
+
+// RegisterRoutes registers webhook archive routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *WebhookArchiveHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/admin/webhook-archive", h.HandleArchivePage)
+	r.Get("/admin/webhook-archive/{id}", h.HandleArchiveDetail)
+	r.Post("/admin/webhook-archive/{id}/redispatch", h.HandleArchiveRedispatch)
+}
+
+// HandleArchivePage lists archived webhooks, newest first, with filters.
+func (h *WebhookArchiveHandler) HandleArchivePage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	query := r.URL.Query()
+	var errMsg string
+	var webhooks []*domain.ArchivedWebhook
+	filter, err := parseWebhookArchiveFilter(query)
+	if err != nil {
+		errMsg = "Invalid filter: " + err.Error()
+	} else if webhooks, err = h.archive.List(r.Context(), *filter); err != nil {
+		h.logger.Error("failed to list archived webhooks", zap.Error(err))
+		errMsg = "Failed to load the webhook archive"
+	}
+
+	h.RenderTemplate(w, r, "webhook_archive", map[string]interface{}{
+		"Title":     "Webhook Archive",
+		"ActiveNav": "webhooks",
+		"User":      user,
+		"Webhooks":  webhooks,
+		"Providers": []voiceprovider.ProviderType{voiceprovider.ProviderBland, voiceprovider.ProviderVapi, voiceprovider.ProviderRetell, voiceprovider.ProviderTwilio},
+		"Filter":    query,
+		"Filtered":  len(query) > 0,
+		"Error":     errMsg,
+	})
+}
+
+// HandleArchiveDetail shows an archived webhook's body and what its
+// provider's parser makes of it now.
+func (h *WebhookArchiveHandler) HandleArchiveDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var successMsg string
+	if callID := r.URL.Query().Get("redispatched"); callID != "" {
+		successMsg = "Webhook re-dispatched to call " + callID + "."
+	}
+	h.renderArchiveDetail(w, r, user, id, successMsg, "")
+}
+
+// HandleArchiveRedispatch handles POST to parse an archived webhook again
+// and apply it to its call.
+func (h *WebhookArchiveHandler) HandleArchiveRedispatch(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	call, err := h.archive.Redispatch(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Archived webhook not found", http.StatusNotFound)
+			return
+		}
+		errMsg := "Failed to re-dispatch webhook."
+		if apperrors.IsUserError(err) {
+			errMsg = "Failed to re-dispatch webhook: " + err.Error()
+		} else {
+			h.logger.Error("failed to re-dispatch archived webhook", zap.Error(err), zap.String("id", id.String()))
+		}
+		h.renderArchiveDetail(w, r, user, id, "", errMsg)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/webhook-archive/%s?redispatched=%s", id, call.ID), http.StatusSeeOther)
+}
+
+// renderArchiveDetail renders an archived webhook with its body formatted
+// and parsed.
+func (h *WebhookArchiveHandler) renderArchiveDetail(w http.ResponseWriter, r *http.Request, user *domain.User, id uuid.UUID, successMsg, errMsg string) {
+	webhook, err := h.archive.Get(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Archived webhook not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get archived webhook", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	body := string(webhook.Body)
+	var indented bytes.Buffer
+	if json.Indent(&indented, webhook.Body, "", "  ") == nil {
+		body = indented.String()
+	}
+
+	var parsed, parseErr string
+	event, err := h.archive.Parse(r.Context(), webhook)
+	if err != nil {
+		parseErr = err.Error()
+	} else if data, err := json.MarshalIndent(event, "", "  "); err == nil {
+		parsed = string(data)
+	}
+
+	h.RenderTemplate(w, r, "webhook_archive_detail", map[string]interface{}{
+		"Title":      "Archived Webhook",
+		"ActiveNav":  "webhooks",
+		"User":       user,
+		"Webhook":    webhook,
+		"Body":       body,
+		"Parsed":     parsed,
+		"ParseError": parseErr,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	})
+}
+
+func (h *WebhookArchiveHandler) webhookID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid archived webhook ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseWebhookArchiveFilter builds an archive search from query parameters.
+// Dates accept RFC 3339 timestamps or YYYY-MM-DD; a plain "until" date is
+// inclusive.
+func parseWebhookArchiveFilter(query url.Values) (*domain.WebhookArchiveFilter, error) {
+	filter := &domain.WebhookArchiveFilter{
+		Provider:       strings.TrimSpace(query.Get("provider")),
+		ProviderCallID: strings.TrimSpace(query.Get("call_id")),
+	}
+
+	if since := strings.TrimSpace(query.Get("since")); since != "" {
+		t, _, err := parseExportDate(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		filter.Since = &t
+	}
+
+	if until := strings.TrimSpace(query.Get("until")); until != "" {
+		t, dateOnly, err := parseExportDate(until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.Until = &t
+	}
+
+	if filter.Since != nil && filter.Until != nil && !filter.Until.After(*filter.Since) {
+		return nil, fmt.Errorf("until must be after since")
+	}
+
+	return filter, nil
+}
@@ -1,15 +1,21 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
 	"github.com/jkindrix/quickquote/internal/metrics"
 	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/realtime"
 	"github.com/jkindrix/quickquote/internal/service"
 	"github.com/jkindrix/quickquote/internal/voiceprovider"
 )
@@ -17,7 +23,20 @@ import (
 // WebhookHandler handles incoming webhooks from voice providers.
 type WebhookHandler struct {
 	callService      *service.CallService
+	webhookEvents    *service.WebhookEventProcessor
+	dedup            *service.WebhookDeduplicator
+	archive          *service.WebhookArchiveService
 	providerRegistry *voiceprovider.Registry
+	notifier         service.Notifier
+	transcription    *service.TranscriptionService
+	compliance       *service.ComplianceService
+	conversations    *service.ConversationService
+	live             *realtime.Hub
+	blocklist        *service.BlocklistService
+	overflow         *service.OverflowService
+	routing          *service.RoutingService
+	spam             *service.SpamService
+	blandSignature   func(http.Handler) http.Handler
 	logger           *zap.Logger
 	metrics          *metrics.Metrics
 }
@@ -25,7 +44,20 @@ type WebhookHandler struct {
 // WebhookHandlerConfig holds configuration for WebhookHandler.
 type WebhookHandlerConfig struct {
 	CallService      *service.CallService
+	WebhookEvents    *service.WebhookEventProcessor // Optional: persists events and retries failures
+	Dedup            *service.WebhookDeduplicator   // Optional: skips redelivered webhooks
+	Archive          *service.WebhookArchiveService // Optional: keeps the raw bodies of verified webhooks
 	ProviderRegistry *voiceprovider.Registry
+	Notifier         service.Notifier                // Optional: notified when calls fail
+	Transcription    *service.TranscriptionService   // Optional: transcribes recordings when the provider sends no transcript
+	Compliance       *service.ComplianceService      // Optional: applies STOP and START texts to the do-not-call list
+	Conversations    *service.ConversationService    // Optional: records inbound texts in the conversation inbox
+	Live             *realtime.Hub                   // Optional: pushes call activity to the live calls page
+	Blocklist        *service.BlocklistService       // Optional: turns away blocked callers
+	Overflow         *service.OverflowService        // Optional: handles inbound calls over the concurrency threshold
+	Routing          *service.RoutingService         // Optional: routes inbound callers by area code
+	Spam             *service.SpamService            // Optional: scores inbound callers for spam
+	BlandSignature   func(http.Handler) http.Handler // Optional: verifies Bland call and SMS webhooks before they are handled
 	Logger           *zap.Logger
 	Metrics          *metrics.Metrics
 }
@@ -37,7 +69,20 @@ func NewWebhookHandler(cfg WebhookHandlerConfig) *WebhookHandler {
 	}
 	return &WebhookHandler{
 		callService:      cfg.CallService,
+		webhookEvents:    cfg.WebhookEvents,
+		dedup:            cfg.Dedup,
+		archive:          cfg.Archive,
 		providerRegistry: cfg.ProviderRegistry,
+		notifier:         cfg.Notifier,
+		transcription:    cfg.Transcription,
+		compliance:       cfg.Compliance,
+		conversations:    cfg.Conversations,
+		live:             cfg.Live,
+		blocklist:        cfg.Blocklist,
+		overflow:         cfg.Overflow,
+		routing:          cfg.Routing,
+		spam:             cfg.Spam,
+		blandSignature:   cfg.BlandSignature,
 		logger:           cfg.Logger,
 		metrics:          cfg.Metrics,
 	}
@@ -45,14 +90,26 @@ func NewWebhookHandler(cfg WebhookHandlerConfig) *WebhookHandler {
 
 // RegisterRoutes registers webhook routes on the router.
 func (h *WebhookHandler) RegisterRoutes(r chi.Router) {
+	bland := r.With(middleware.BodySizeLimiterWebhook())
+	if h.blandSignature != nil {
+		bland = bland.With(h.blandSignature)
+	}
+
 	if h.providerRegistry != nil {
 		for _, path := range h.providerRegistry.GetWebhookPaths() {
 			h.logger.Info("registering webhook route", zap.String("path", path))
+			if path == "/webhook/bland" {
+				bland.Post(path, h.HandleVoiceWebhook)
+				continue
+			}
 			r.With(middleware.BodySizeLimiterWebhook()).Post(path, h.HandleVoiceWebhook)
 		}
 	} else {
 		// Fallback to legacy Bland-only route
-		r.With(middleware.BodySizeLimiterWebhook()).Post("/webhook/bland", h.HandleBlandWebhook)
+		bland.Post("/webhook/bland", h.HandleBlandWebhook)
+	}
+	if h.compliance != nil || h.conversations != nil {
+		bland.Post("/webhook/bland/sms", h.HandleSMSWebhook)
 	}
 }
 
@@ -83,6 +140,16 @@ func (h *WebhookHandler) HandleVoiceWebhook(w http.ResponseWriter, r *http.Reque
 		zap.String("content_type", r.Header.Get("Content-Type")),
 	)
 
+	// Buffer the body so the raw payload can be persisted after parsing
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error("failed to read webhook body", zap.Error(err))
+		h.recordWebhookMetrics(string(provider.GetName()), "read_error", start)
+		http.Error(w, "Invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
 	// Validate webhook authenticity
 	if !provider.ValidateWebhook(r) {
 		h.logger.Warn("webhook validation failed",
@@ -95,6 +162,7 @@ func (h *WebhookHandler) HandleVoiceWebhook(w http.ResponseWriter, r *http.Reque
 
 	// Parse webhook into normalized CallEvent
 	event, err := provider.ParseWebhook(r)
+	h.archiveWebhook(r, provider, event, body)
 	if err != nil {
 		h.logger.Error("failed to parse webhook",
 			zap.String("provider", string(provider.GetName())),
@@ -111,13 +179,80 @@ func (h *WebhookHandler) HandleVoiceWebhook(w http.ResponseWriter, r *http.Reque
 		zap.String("status", string(event.Status)),
 	)
 
-	// Process the normalized event
-	call, err := h.callService.ProcessCallEvent(r.Context(), event)
+	eventKey := service.WebhookEventKey("", body)
+	if h.dedup != nil && h.dedup.Claim(r.Context(), string(event.Provider), eventKey) {
+		h.recordWebhookMetrics(string(event.Provider), "duplicate", start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":   true,
+			"duplicate": true,
+			"provider":  string(event.Provider),
+		}); err != nil {
+			h.logger.Debug("failed to write webhook response", zap.Error(err))
+		}
+		return
+	}
+
+	if h.blocklist != nil {
+		blocked, err := h.blocklist.ScreenInbound(r.Context(), event)
+		if err != nil {
+			// Let the call through rather than drop it over a lookup failure
+			h.logger.Warn("failed to check blocklist", zap.Error(err))
+		} else if blocked != nil {
+			h.logger.Info("blocked caller turned away",
+				zap.String("provider_call_id", event.ProviderCallID),
+				zap.String("blocked_by", blocked.PhoneNumber),
+			)
+			h.recordWebhookMetrics(string(event.Provider), "blocked", start)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(map[string]interface{}{
+				"success":  true,
+				"blocked":  true,
+				"provider": string(event.Provider),
+			}); err != nil {
+				h.logger.Debug("failed to write webhook response", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	// Process the normalized event, persisting it for retry when configured
+	var call *domain.Call
+	if h.webhookEvents != nil {
+		call, err = h.webhookEvents.Handle(r.Context(), event, body)
+	} else {
+		call, err = h.callService.ProcessCallEvent(r.Context(), event)
+	}
+	if errors.Is(err, service.ErrWebhookEventQueued) {
+		// The event is stored and will be retried; acknowledge it so the
+		// provider does not redeliver it as well.
+		h.logger.Warn("webhook processing failed, queued for retry",
+			zap.Error(err),
+			zap.String("provider_call_id", event.ProviderCallID),
+		)
+		h.recordWebhookMetrics(string(event.Provider), "queued", start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":  false,
+			"queued":   true,
+			"provider": string(event.Provider),
+		}); err != nil {
+			h.logger.Debug("failed to write webhook response", zap.Error(err))
+		}
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to process webhook",
 			zap.Error(err),
 			zap.String("provider_call_id", event.ProviderCallID),
 		)
+		if h.dedup != nil {
+			// The provider redelivers the webhook, which must not be skipped
+			h.dedup.Release(r.Context(), string(event.Provider), eventKey)
+		}
 		h.recordWebhookMetrics(string(event.Provider), "processing_error", start)
 		http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
 		return
@@ -127,6 +262,43 @@ func (h *WebhookHandler) HandleVoiceWebhook(w http.ResponseWriter, r *http.Reque
 		h.metrics.RecordProviderCall(string(event.Provider), string(event.Status))
 	}
 
+	if h.notifier != nil && call.Status == domain.CallStatusFailed {
+		h.notifier.CallFailed(r.Context(), call)
+	}
+
+	if h.spam != nil {
+		if _, err := h.spam.Screen(r.Context(), call); err != nil {
+			h.logger.Warn("failed to screen call for spam",
+				zap.String("provider_call_id", event.ProviderCallID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if h.live != nil {
+		h.live.PublishCall(call)
+	}
+
+	var routed *domain.RoutingRule
+	if h.routing != nil {
+		routed, err = h.routing.Route(r.Context(), call)
+		if err != nil {
+			h.logger.Warn("failed to route inbound call",
+				zap.String("provider_call_id", event.ProviderCallID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	// Calls transferred by a routing rule are no longer on the agent
+	if h.overflow != nil && (routed == nil || routed.TransferNumber == "") {
+		h.overflow.Track(r.Context(), call)
+	}
+
+	if h.transcription != nil && needsTranscription(call) {
+		h.transcription.Enqueue(call.ID)
+	}
+
 	h.logger.Info("webhook processed successfully",
 		zap.String("provider", string(event.Provider)),
 		zap.String("provider_call_id", event.ProviderCallID),
@@ -158,6 +330,101 @@ func (h *WebhookHandler) HandleBlandWebhook(w http.ResponseWriter, r *http.Reque
 	h.HandleVoiceWebhook(w, r)
 }
 
+// HandleSMSWebhook processes inbound texts from Bland. Texts are recorded in
+// the conversation inbox, and those that opt out of or back in to contact
+// update the do-not-call list.
+func (h *WebhookHandler) HandleSMSWebhook(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	provider := string(voiceprovider.ProviderBland)
+
+	if h.providerRegistry != nil {
+		if p, err := h.providerRegistry.Get(voiceprovider.ProviderBland); err == nil && !p.ValidateWebhook(r) {
+			h.logger.Warn("SMS webhook validation failed")
+			h.recordWebhookMetrics(provider, "invalid_signature", start)
+			http.Error(w, "Invalid webhook signature", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Error("failed to read SMS webhook body", zap.Error(err))
+		h.recordWebhookMetrics(provider, "read_error", start)
+		http.Error(w, "Invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	var sms bland.SMS
+	if err := json.Unmarshal(body, &sms); err != nil {
+		h.logger.Error("failed to parse SMS webhook", zap.Error(err))
+		h.recordWebhookMetrics(provider, "parse_error", start)
+		http.Error(w, "Invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	eventKey := service.WebhookEventKey(sms.ID, body)
+	if h.dedup != nil && h.dedup.Claim(r.Context(), provider, eventKey) {
+		h.recordWebhookMetrics(provider, "duplicate", start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "duplicate": true}); err != nil {
+			h.logger.Debug("failed to write webhook response", zap.Error(err))
+		}
+		return
+	}
+
+	if sms.Direction == "" || sms.Direction == "inbound" {
+		if h.conversations != nil {
+			err = h.conversations.ReceiveMessage(r.Context(), domain.MessageChannelSMS, sms.From, sms.Body, sms.ID)
+		}
+		if err == nil && h.compliance != nil {
+			err = h.compliance.HandleInboundSMS(r.Context(), sms.From, sms.Body)
+		}
+		if err != nil {
+			h.logger.Error("failed to process inbound SMS", zap.Error(err), zap.String("message_id", sms.ID))
+			if h.dedup != nil {
+				h.dedup.Release(r.Context(), provider, eventKey)
+			}
+			h.recordWebhookMetrics(provider, "processing_error", start)
+			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"success": true}); err != nil {
+		h.logger.Debug("failed to write webhook response", zap.Error(err))
+	}
+	h.recordWebhookMetrics(provider, "sms", start)
+}
+
+// archiveWebhook keeps the raw body of a verified webhook. event is nil if
+// the body could not be parsed.
+func (h *WebhookHandler) archiveWebhook(r *http.Request, provider voiceprovider.Provider, event *voiceprovider.CallEvent, body []byte) {
+	if h.archive == nil {
+		return
+	}
+	var providerCallID string
+	if event != nil {
+		providerCallID = event.ProviderCallID
+	}
+	if err := h.archive.Archive(r.Context(), string(provider.GetName()), r.URL.Path, r.Header.Get("Content-Type"), providerCallID, body); err != nil {
+		h.logger.Warn("failed to archive webhook",
+			zap.String("provider", string(provider.GetName())),
+			zap.Error(err),
+		)
+	}
+}
+
+// needsTranscription reports whether a completed call has a recording but
+// no transcript to generate a quote from.
+func needsTranscription(call *domain.Call) bool {
+	return call.Status == domain.CallStatusCompleted &&
+		(call.Transcript == nil || *call.Transcript == "") &&
+		call.RecordingURL != nil && *call.RecordingURL != ""
+}
+
 func (h *WebhookHandler) recordWebhookMetrics(provider, status string, started time.Time) {
 	if h.metrics == nil {
 		return
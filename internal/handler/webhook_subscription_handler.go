@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// webhookDeliveryPageSize is the number of deliveries shown on a subscription page.
+const webhookDeliveryPageSize = 50
+
+// WebhookSubscriptionHandler serves the outgoing webhook admin pages.
+type WebhookSubscriptionHandler struct {
+	*BaseHandler
+	webhookService *service.OutgoingWebhookService
+}
+
+// WebhookSubscriptionHandlerConfig holds configuration for WebhookSubscriptionHandler.
+type WebhookSubscriptionHandlerConfig struct {
+	Base           BaseHandlerConfig
+	WebhookService *service.OutgoingWebhookService
+}
+
+// NewWebhookSubscriptionHandler creates a new WebhookSubscriptionHandler with all required dependencies.
+func NewWebhookSubscriptionHandler(cfg WebhookSubscriptionHandlerConfig) *WebhookSubscriptionHandler {
+	if cfg.WebhookService == nil {
+		panic("webhookService is required")
+	}
+	return &WebhookSubscriptionHandler{
+		BaseHandler:    NewBaseHandler(cfg.Base),
+		webhookService: cfg.WebhookService,
+	}
+}
+
+// RegisterRoutes registers webhook subscription routes on the router.
+// Note: These routes require admin middleware to be applied by the caller.
+func (h *WebhookSubscriptionHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/webhooks", h.HandleWebhooksPage)
+	r.Post("/webhooks", h.HandleWebhookCreate)
+	r.Get("/webhooks/{id}", h.HandleWebhookDetail)
+	r.Post("/webhooks/{id}", h.HandleWebhookUpdate)
+	r.Post("/webhooks/{id}/rotate", h.HandleWebhookRotate)
+	r.Post("/webhooks/{id}/delete", h.HandleWebhookDelete)
+	r.Post("/webhooks/{id}/deliveries/{deliveryID}/retry", h.HandleDeliveryRetry)
+}
+
+// HandleWebhooksPage lists subscriptions and shows the create form.
+func (h *WebhookSubscriptionHandler) HandleWebhooksPage(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	var successMsg string
+	if r.URL.Query().Get("deleted") == "1" {
+		successMsg = "Webhook deleted."
+	}
+	h.renderWebhooks(w, r, user, successMsg, "")
+}
+
+// HandleWebhookCreate handles POST to create a subscription. The new
+// subscription's page is rendered directly so its secret can be shown once.
+func (h *WebhookSubscriptionHandler) HandleWebhookCreate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderWebhooks(w, r, user, "", "Invalid form submission.")
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(r.Context(), &service.CreateWebhookRequest{
+		Name:      r.FormValue("name"),
+		URL:       r.FormValue("url"),
+		Events:    formWebhookEvents(r),
+		CreatedBy: &user.ID,
+	})
+	if err != nil {
+		h.renderWebhooks(w, r, user, "", h.webhookErrorMessage("create webhook", err))
+		return
+	}
+
+	h.renderWebhookDetail(w, r, user, subscription.ID, subscription.Secret, "Webhook created.", "")
+}
+
+// HandleWebhookDetail shows a subscription and its delivery log.
+func (h *WebhookSubscriptionHandler) HandleWebhookDetail(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.subscriptionID(w, r)
+	if !ok {
+		return
+	}
+
+	var successMsg string
+	switch {
+	case r.URL.Query().Get("updated") == "1":
+		successMsg = "Webhook updated."
+	case r.URL.Query().Get("retried") == "1":
+		successMsg = "Delivery queued to be sent again."
+	}
+	h.renderWebhookDetail(w, r, user, id, "", successMsg, "")
+}
+
+// HandleWebhookUpdate handles POST to change a subscription's settings.
+func (h *WebhookSubscriptionHandler) HandleWebhookUpdate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.subscriptionID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.renderWebhookDetail(w, r, user, id, "", "", "Invalid form submission.")
+		return
+	}
+
+	name := r.FormValue("name")
+	endpoint := r.FormValue("url")
+	active := r.FormValue("active") == "on"
+	events := formWebhookEvents(r)
+	if events == nil {
+		events = []domain.WebhookEventType{}
+	}
+
+	_, err := h.webhookService.UpdateSubscription(r.Context(), id, &service.UpdateWebhookRequest{
+		Name:   &name,
+		URL:    &endpoint,
+		Events: events,
+		Active: &active,
+	})
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		h.renderWebhookDetail(w, r, user, id, "", "", h.webhookErrorMessage("update webhook", err))
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/webhooks/%s?updated=1", id), http.StatusSeeOther)
+}
+
+// HandleWebhookRotate handles POST to replace a subscription's signing secret.
+func (h *WebhookSubscriptionHandler) HandleWebhookRotate(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.subscriptionID(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.RotateSecret(r.Context(), id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		h.renderWebhookDetail(w, r, user, id, "", "", h.webhookErrorMessage("rotate secret", err))
+		return
+	}
+
+	h.renderWebhookDetail(w, r, user, id, subscription.Secret, "Signing secret rotated.", "")
+}
+
+// HandleWebhookDelete handles POST to delete a subscription.
+func (h *WebhookSubscriptionHandler) HandleWebhookDelete(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.subscriptionID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), id); err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		h.renderWebhookDetail(w, r, user, id, "", "", h.webhookErrorMessage("delete webhook", err))
+		return
+	}
+
+	http.Redirect(w, r, "/webhooks?deleted=1", http.StatusSeeOther)
+}
+
+// HandleDeliveryRetry handles POST to send a delivery again.
+func (h *WebhookSubscriptionHandler) HandleDeliveryRetry(w http.ResponseWriter, r *http.Request) {
+	user := GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	id, ok := h.subscriptionID(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "deliveryID"))
+	if err != nil {
+		http.Error(w, "Invalid delivery ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.webhookService.RetryDelivery(r.Context(), id, deliveryID); err != nil {
+		h.renderWebhookDetail(w, r, user, id, "", "", h.webhookErrorMessage("retry delivery", err))
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/webhooks/%s?retried=1", id), http.StatusSeeOther)
+}
+
+// renderWebhookDetail renders a subscription's settings and delivery log.
+// secret is shown only right after the subscription is created or rotated.
+func (h *WebhookSubscriptionHandler) renderWebhookDetail(w http.ResponseWriter, r *http.Request, user *domain.User, id uuid.UUID, secret, successMsg, errMsg string) {
+	ctx := r.Context()
+	subscription, err := h.webhookService.GetSubscription(ctx, id)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get webhook subscription", zap.Error(err), zap.String("id", id.String()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(ctx, id, webhookDeliveryPageSize, 0)
+	if err != nil {
+		h.logger.Error("failed to list webhook deliveries", zap.Error(err), zap.String("id", id.String()))
+		if errMsg == "" {
+			errMsg = "Failed to load deliveries"
+		}
+	}
+
+	h.RenderTemplate(w, r, "webhook_detail", map[string]interface{}{
+		"Title":      "Webhook: " + subscription.Name,
+		"ActiveNav":  "webhooks",
+		"User":       user,
+		"Webhook":    subscription,
+		"Subscribed": subscribedEvents(subscription),
+		"Events":     domain.WebhookEventTypes,
+		"Deliveries": deliveries,
+		"Secret":     secret,
+		"Success":    successMsg,
+		"Error":      errMsg,
+	})
+}
+
+// renderWebhooks renders the subscription list page.
+func (h *WebhookSubscriptionHandler) renderWebhooks(w http.ResponseWriter, r *http.Request, user *domain.User, successMsg, errMsg string) {
+	subscriptions, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list webhook subscriptions", zap.Error(err))
+		if errMsg == "" {
+			errMsg = "Failed to load webhooks"
+		}
+	}
+
+	h.RenderTemplate(w, r, "webhooks", map[string]interface{}{
+		"Title":     "Webhooks",
+		"ActiveNav": "webhooks",
+		"User":      user,
+		"Webhooks":  subscriptions,
+		"Events":    domain.WebhookEventTypes,
+		"Success":   successMsg,
+		"Error":     errMsg,
+	})
+}
+
+func (h *WebhookSubscriptionHandler) subscriptionID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// webhookErrorMessage returns a message for the admin describing err.
+func (h *WebhookSubscriptionHandler) webhookErrorMessage(action string, err error) string {
+	if apperrors.IsUserError(err) {
+		return "Failed to " + action + ": " + err.Error()
+	}
+	h.logger.Error("failed to "+action, zap.Error(err))
+	return "Failed to " + action + "."
+}
+
+// formWebhookEvents returns the events checked on a subscription form.
+func formWebhookEvents(r *http.Request) []domain.WebhookEventType {
+	var events []domain.WebhookEventType
+	for _, e := range r.Form["events"] {
+		events = append(events, domain.WebhookEventType(e))
+	}
+	return events
+}
+
+// subscribedEvents returns the set of events a subscription receives, for
+// checking boxes on the edit form.
+func subscribedEvents(s *domain.WebhookSubscription) map[domain.WebhookEventType]bool {
+	subscribed := make(map[domain.WebhookEventType]bool, len(s.Events))
+	for _, e := range s.Events {
+		subscribed[e] = true
+	}
+	return subscribed
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/middleware"
+	"github.com/jkindrix/quickquote/internal/service"
+	"github.com/jkindrix/quickquote/internal/whatsapp"
+)
+
+// WhatsAppWebhookPath is where the WhatsApp Cloud API sends messages from
+// customers.
+const WhatsAppWebhookPath = "/webhook/whatsapp"
+
+// WhatsAppWebhookHandler receives WhatsApp webhooks and records customer
+// messages in the conversation inbox.
+type WhatsAppWebhookHandler struct {
+	client        *whatsapp.Client
+	conversations *service.ConversationService
+	logger        *zap.Logger
+}
+
+// NewWhatsAppWebhookHandler creates a new WhatsAppWebhookHandler.
+func NewWhatsAppWebhookHandler(client *whatsapp.Client, conversations *service.ConversationService, logger *zap.Logger) *WhatsAppWebhookHandler {
+	return &WhatsAppWebhookHandler{
+		client:        client,
+		conversations: conversations,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers the webhook routes. They need no session;
+// subscriptions are checked against the verify token and deliveries
+// against the app secret signature.
+func (h *WhatsAppWebhookHandler) RegisterRoutes(r chi.Router) {
+	r.Get(WhatsAppWebhookPath, h.VerifySubscription)
+	r.With(middleware.BodySizeLimiterWebhook()).Post(WhatsAppWebhookPath, h.HandleWebhook)
+}
+
+// VerifySubscription handles GET /webhook/whatsapp, echoing the challenge
+// when Meta verifies the webhook subscription.
+func (h *WhatsAppWebhookHandler) VerifySubscription(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if !h.client.VerifySubscription(q.Get("hub.mode"), q.Get("hub.verify_token")) {
+		h.logger.Warn("WhatsApp webhook subscription with wrong verify token")
+		APIError(w, http.StatusForbidden, "invalid verify token")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, q.Get("hub.challenge")); err != nil {
+		h.logger.Debug("failed to write webhook response", zap.Error(err))
+	}
+}
+
+// HandleWebhook handles POST /webhook/whatsapp
+func (h *WhatsAppWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	// The signature covers the exact bytes sent, so read the raw body.
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		APIError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	if err := h.client.VerifySignature(payload, r.Header.Get(whatsapp.SignatureHeader)); err != nil {
+		if errors.Is(err, whatsapp.ErrInvalidSignature) {
+			h.logger.Warn("WhatsApp webhook with invalid signature")
+		}
+		APIError(w, http.StatusUnauthorized, "invalid signature")
+		return
+	}
+
+	messages, err := whatsapp.ParseWebhook(payload)
+	if err != nil {
+		h.logger.Error("failed to parse WhatsApp webhook", zap.Error(err))
+		APIError(w, http.StatusBadRequest, "invalid webhook payload")
+		return
+	}
+
+	for _, m := range messages {
+		if err := h.conversations.ReceiveMessage(r.Context(), domain.MessageChannelWhatsApp, m.From, m.Body, m.ID); err != nil {
+			// A 5xx makes WhatsApp retry the delivery; stored messages are skipped.
+			h.logger.Error("failed to record WhatsApp message", zap.String("message_id", m.ID), zap.Error(err))
+			APIError(w, http.StatusInternalServerError, "failed to handle webhook")
+			return
+		}
+	}
+
+	JSON(w, http.StatusOK, map[string]bool{"received": true})
+}
@@ -0,0 +1,399 @@
+// Package health tracks whether the services QuickQuote depends on are
+// reachable: when each last answered, how quickly, and the state of any
+// circuit breaker guarding it.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+// Dependency statuses.
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"  // Reachable, but its circuit breaker is not closed
+	StatusUnhealthy = "unhealthy" // The last check failed
+	StatusUnknown   = "unknown"   // Not checked yet
+)
+
+// latencySamples is how many recent check latencies percentiles are taken
+// over.
+const latencySamples = 100
+
+// CheckFunc returns an error if a dependency can't be reached.
+type CheckFunc func(ctx context.Context) error
+
+// Breaker reports the state of the circuit breaker guarding a dependency.
+type Breaker interface {
+	CircuitBreakerStats() circuitbreaker.Stats
+}
+
+// Dependency is a service to check.
+type Dependency struct {
+	Name     string
+	Critical bool // The server is not ready while a critical dependency is unhealthy
+	Check    CheckFunc
+	Breaker  Breaker // Optional
+}
+
+// DependencyStatus is the latest known health of a dependency.
+type DependencyStatus struct {
+	Name                string     `json:"name"`
+	Critical            bool       `json:"critical"`
+	Status              string     `json:"status"`
+	Message             string     `json:"message,omitempty"`
+	LatencyMs           float64    `json:"latency_ms"` // Last check
+	LatencyP50Ms        float64    `json:"latency_p50_ms"`
+	LatencyP95Ms        float64    `json:"latency_p95_ms"`
+	LatencyP99Ms        float64    `json:"latency_p99_ms"`
+	LastChecked         *time.Time `json:"last_checked,omitempty"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	CircuitBreaker      string     `json:"circuit_breaker,omitempty"`
+}
+
+// dependencyState is what the monitor has learned about a dependency.
+type dependencyState struct {
+	dep                 Dependency
+	lastErr             error
+	lastLatency         time.Duration
+	latencies           []time.Duration // Ring buffer of recent latencies
+	next                int
+	lastChecked         *time.Time
+	lastSuccess         *time.Time
+	lastFailure         *time.Time
+	consecutiveFailures int
+}
+
+// Config holds monitor settings.
+type Config struct {
+	Interval time.Duration // How often every dependency is checked
+	Timeout  time.Duration // How long one check may take
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Interval: 30 * time.Second,
+		Timeout:  5 * time.Second,
+	}
+}
+
+// Monitor checks dependencies in the background and keeps their latest
+// results, so health requests never wait on slow or distant services.
+type Monitor struct {
+	config *Config
+	logger *zap.Logger
+	clock  clock.Clock
+
+	mu   sync.RWMutex
+	deps []*dependencyState
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	runMu   sync.Mutex
+	running bool
+}
+
+// NewMonitor creates a new Monitor.
+func NewMonitor(config *Config, logger *zap.Logger, clk clock.Clock) *Monitor {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Monitor{
+		config: config,
+		logger: logger,
+		clock:  clk,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// Register adds a dependency to check.
+func (m *Monitor) Register(dep Dependency) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deps = append(m.deps, &dependencyState{dep: dep})
+}
+
+// Start begins checking dependencies periodically.
+func (m *Monitor) Start(ctx context.Context) error {
+	m.runMu.Lock()
+	if m.running {
+		m.runMu.Unlock()
+		return errors.New("health monitor already running")
+	}
+	m.running = true
+	m.runMu.Unlock()
+
+	m.logger.Info("starting health monitor", zap.Duration("interval", m.config.Interval))
+
+	m.wg.Add(1)
+	go m.loop()
+	return nil
+}
+
+// Stop stops the background checks.
+func (m *Monitor) Stop(ctx context.Context) error {
+	m.runMu.Lock()
+	if !m.running {
+		m.runMu.Unlock()
+		return nil
+	}
+	m.running = false
+	m.runMu.Unlock()
+
+	close(m.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Monitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-m.stopCh
+		cancel()
+	}()
+
+	m.CheckAll(ctx)
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.CheckAll(ctx)
+		}
+	}
+}
+
+// CheckAll checks every dependency concurrently.
+func (m *Monitor) CheckAll(ctx context.Context) {
+	m.check(ctx, m.states(false))
+}
+
+// Ready checks the critical dependencies now and returns an error naming
+// the first one that is unhealthy.
+func (m *Monitor) Ready(ctx context.Context) error {
+	states := m.states(true)
+	m.check(ctx, states)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range states {
+		if s.lastErr != nil {
+			return fmt.Errorf("%s: %w", s.dep.Name, s.lastErr)
+		}
+	}
+	return nil
+}
+
+// Statuses returns the latest health of every dependency, in the order
+// they were registered.
+func (m *Monitor) Statuses() []DependencyStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]DependencyStatus, len(m.deps))
+	for i, s := range m.deps {
+		statuses[i] = s.status()
+	}
+	return statuses
+}
+
+func (m *Monitor) states(criticalOnly bool) []*dependencyState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var states []*dependencyState
+	for _, s := range m.deps {
+		if !criticalOnly || s.dep.Critical {
+			states = append(states, s)
+		}
+	}
+	return states
+}
+
+func (m *Monitor) check(ctx context.Context, states []*dependencyState) {
+	var wg sync.WaitGroup
+	for _, s := range states {
+		wg.Add(1)
+		go func(s *dependencyState) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+			defer cancel()
+
+			start := m.clock.Now()
+			err := s.dep.Check(checkCtx)
+			latency := m.clock.Now().Sub(start)
+			if err != nil && ctx.Err() != nil {
+				return // Caller gave up; the check didn't get a fair chance
+			}
+
+			m.mu.Lock()
+			wasFailing := s.consecutiveFailures > 0
+			s.record(m.clock.Now(), latency, err)
+			m.mu.Unlock()
+
+			if err != nil && !wasFailing {
+				m.logger.Warn("dependency health check failed", zap.String("dependency", s.dep.Name), zap.Error(err))
+			} else if err == nil && wasFailing {
+				m.logger.Info("dependency recovered", zap.String("dependency", s.dep.Name))
+			}
+		}(s)
+	}
+	wg.Wait()
+}
+
+func (s *dependencyState) record(now time.Time, latency time.Duration, err error) {
+	s.lastErr = err
+	s.lastLatency = latency
+	s.lastChecked = &now
+	if len(s.latencies) < latencySamples {
+		s.latencies = append(s.latencies, latency)
+	} else {
+		s.latencies[s.next] = latency
+		s.next = (s.next + 1) % latencySamples
+	}
+	if err != nil {
+		s.lastFailure = &now
+		s.consecutiveFailures++
+	} else {
+		s.lastSuccess = &now
+		s.consecutiveFailures = 0
+	}
+}
+
+func (s *dependencyState) status() DependencyStatus {
+	status := DependencyStatus{
+		Name:                s.dep.Name,
+		Critical:            s.dep.Critical,
+		Status:              StatusUnknown,
+		LastChecked:         s.lastChecked,
+		LastSuccess:         s.lastSuccess,
+		LastFailure:         s.lastFailure,
+		ConsecutiveFailures: s.consecutiveFailures,
+	}
+	if s.lastChecked != nil {
+		status.Status = StatusHealthy
+		status.LatencyMs = milliseconds(s.lastLatency)
+		status.LatencyP50Ms, status.LatencyP95Ms, status.LatencyP99Ms = percentiles(s.latencies)
+	}
+	if s.dep.Breaker != nil {
+		stats := s.dep.Breaker.CircuitBreakerStats()
+		status.CircuitBreaker = stats.State
+		if stats.State != circuitbreaker.StateClosed.String() && status.Status == StatusHealthy {
+			status.Status = StatusDegraded
+			status.Message = "circuit breaker " + stats.State
+			if stats.LastError != "" {
+				status.Message += ": " + stats.LastError
+			}
+		}
+	}
+	if s.lastErr != nil {
+		status.Status = StatusUnhealthy
+		status.Message = s.lastErr.Error()
+	}
+	return status
+}
+
+// percentiles returns the nearest-rank 50th, 95th and 99th percentile
+// latencies in milliseconds.
+func percentiles(latencies []time.Duration) (p50, p95, p99 float64) {
+	if len(latencies) == 0 {
+		return 0, 0, 0
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p int) float64 {
+		i := (len(sorted)*p+99)/100 - 1
+		return milliseconds(sorted[i])
+	}
+	return rank(50), rank(95), rank(99)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// HTTPCheck returns a check that a service answers HTTP requests to url.
+// Any response below 500 counts, since an unauthenticated request to an API
+// is usually refused but still shows the API is up.
+func HTTPCheck(client *http.Client, url string) CheckFunc {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("%s returned %s", url, resp.Status)
+		}
+		return nil
+	}
+}
+
+// SMTPCheck returns a check that an SMTP server at addr (host:port) accepts
+// connections and greets.
+func SMTPCheck(addr string) CheckFunc {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		host, _, _ := net.SplitHostPort(addr)
+		client, err := smtp.NewClient(conn, host)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		return client.Quit()
+	}
+}
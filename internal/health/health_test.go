@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+)
+
+type stubBreaker struct {
+	state string
+}
+
+func (b stubBreaker) CircuitBreakerStats() circuitbreaker.Stats {
+	return circuitbreaker.Stats{State: b.state, LastError: "timeout"}
+}
+
+func TestMonitor_Statuses(t *testing.T) {
+	m := NewMonitor(nil, zap.NewNop(), nil)
+	dbErr := errors.New("connection refused")
+	m.Register(Dependency{Name: "database", Critical: true, Check: func(ctx context.Context) error { return dbErr }})
+	m.Register(Dependency{Name: "anthropic", Check: func(ctx context.Context) error { return nil }, Breaker: stubBreaker{state: "open"}})
+	m.Register(Dependency{Name: "smtp", Check: func(ctx context.Context) error { return nil }})
+
+	statuses := m.Statuses()
+	if statuses[0].Status != StatusUnknown {
+		t.Errorf("status before any check = %q, expected unknown", statuses[0].Status)
+	}
+
+	m.CheckAll(context.Background())
+	dbErr = nil
+	m.CheckAll(context.Background())
+	dbErr = errors.New("connection refused")
+	m.CheckAll(context.Background())
+
+	statuses = m.Statuses()
+	if len(statuses) != 3 {
+		t.Fatalf("got %d statuses, expected 3", len(statuses))
+	}
+
+	db := statuses[0]
+	if db.Name != "database" || db.Status != StatusUnhealthy || !db.Critical {
+		t.Errorf("database = %+v, expected critical and unhealthy", db)
+	}
+	if db.LastSuccess == nil || db.LastFailure == nil || db.ConsecutiveFailures != 1 {
+		t.Errorf("database = %+v, expected a success, a failure and one consecutive failure", db)
+	}
+
+	ai := statuses[1]
+	if ai.Status != StatusDegraded || ai.CircuitBreaker != "open" || ai.Message != "circuit breaker open: timeout" {
+		t.Errorf("anthropic = %+v, expected degraded by its open breaker", ai)
+	}
+
+	if statuses[2].Status != StatusHealthy {
+		t.Errorf("smtp = %+v, expected healthy", statuses[2])
+	}
+}
+
+func TestMonitor_Ready(t *testing.T) {
+	m := NewMonitor(nil, zap.NewNop(), nil)
+	var dbErr error
+	smtpChecks := 0
+	m.Register(Dependency{Name: "database", Critical: true, Check: func(ctx context.Context) error { return dbErr }})
+	m.Register(Dependency{Name: "smtp", Check: func(ctx context.Context) error {
+		smtpChecks++
+		return errors.New("unreachable")
+	}})
+
+	if err := m.Ready(context.Background()); err != nil {
+		t.Errorf("Ready() = %v, expected a non-critical failure to be ignored", err)
+	}
+	if smtpChecks != 0 {
+		t.Error("Ready() checked a non-critical dependency")
+	}
+
+	dbErr = errors.New("connection refused")
+	if err := m.Ready(context.Background()); err == nil || err.Error() != "database: connection refused" {
+		t.Errorf("Ready() = %v, expected the database failure", err)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	p50, p95, p99 := percentiles(latencies)
+	if p50 != 50 || p95 != 95 || p99 != 99 {
+		t.Errorf("percentiles = %v, %v, %v, expected 50, 95, 99", p50, p95, p99)
+	}
+
+	if p50, p95, p99 := percentiles(nil); p50 != 0 || p95 != 0 || p99 != 0 {
+		t.Error("percentiles of no samples should be zero")
+	}
+}
+
+func TestDependencyState_LatencyWindow(t *testing.T) {
+	s := &dependencyState{}
+	for i := 0; i < latencySamples+10; i++ {
+		s.record(time.Now(), time.Duration(i)*time.Millisecond, nil)
+	}
+	if len(s.latencies) != latencySamples {
+		t.Fatalf("kept %d samples, expected %d", len(s.latencies), latencySamples)
+	}
+	for _, l := range s.latencies {
+		if l < 10*time.Millisecond {
+			t.Fatalf("oldest samples were not replaced: found %v", l)
+		}
+	}
+}
+
+func TestHTTPCheck(t *testing.T) {
+	status := http.StatusUnauthorized
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	check := HTTPCheck(srv.Client(), srv.URL)
+	if err := check(context.Background()); err != nil {
+		t.Errorf("check() = %v, expected a refused request to count as reachable", err)
+	}
+
+	status = http.StatusBadGateway
+	if err := check(context.Background()); err == nil {
+		t.Error("check() succeeded on a server error")
+	}
+}
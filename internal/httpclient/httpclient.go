@@ -0,0 +1,298 @@
+// Package httpclient builds the HTTP clients used to call provider APIs,
+// with configurable timeouts, proxying, connection pooling and retries.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Defaults used for zero Config fields.
+const (
+	DefaultTimeout             = 30 * time.Second
+	DefaultDialTimeout         = 10 * time.Second
+	DefaultRetryBaseDelay      = 200 * time.Millisecond
+	DefaultRetryMaxDelay       = 5 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// retryReserve is how many retries the budget holds when full, so a client
+// that has sent few requests can still retry.
+const retryReserve = 10
+
+// Config holds the policy for one provider's client.
+type Config struct {
+	Timeout             time.Duration // Whole request, including reading the body
+	DialTimeout         time.Duration // Connecting and the TLS handshake
+	MaxRetries          int           // Retries per request; zero disables retries
+	RetryBaseDelay      time.Duration // Upper bound of the first retry's jittered delay; doubles per retry
+	RetryMaxDelay       time.Duration // Longest wait before a retry, including Retry-After
+	RetryBudget         float64       // Retries allowed per request sent, across the client
+	ProxyURL            string        // http, https or socks5 proxy; empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int // Zero is unlimited
+	IdleConnTimeout     time.Duration
+}
+
+// New builds a client for a provider. name identifies it in logs.
+func New(name string, cfg Config, logger *zap.Logger) (*http.Client, error) {
+	proxy, err := proxyFunc(cfg.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	dialTimeout := orDefault(cfg.DialTimeout, DefaultDialTimeout)
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   dialTimeout,
+		ExpectContinueTimeout: time.Second,
+		MaxIdleConns:          orDefault(cfg.MaxIdleConns, DefaultMaxIdleConns),
+		MaxIdleConnsPerHost:   orDefault(cfg.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       orDefault(cfg.IdleConnTimeout, DefaultIdleConnTimeout),
+	}
+
+	var rt http.RoundTripper = transport
+	if cfg.MaxRetries > 0 {
+		rt = &retryTransport{
+			name:       name,
+			next:       transport,
+			maxRetries: cfg.MaxRetries,
+			baseDelay:  orDefault(cfg.RetryBaseDelay, DefaultRetryBaseDelay),
+			maxDelay:   orDefault(cfg.RetryMaxDelay, DefaultRetryMaxDelay),
+			budget:     newRetryBudget(cfg.RetryBudget),
+			logger:     logger,
+			sleep:      sleepContext,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   orDefault(cfg.Timeout, DefaultTimeout),
+		Transport: rt,
+	}, nil
+}
+
+// ParseProxyURL checks a proxy URL, which must use the http, https,
+// socks5 or socks5h scheme and name a host.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("proxy URL scheme must be http, https, socks5 or socks5h, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", raw)
+	}
+	return u, nil
+}
+
+// proxyFunc returns the proxy selector for a configured proxy URL.
+func proxyFunc(raw string) (func(*http.Request) (*url.URL, error), error) {
+	if raw == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	u, err := ParseProxyURL(raw)
+	if err != nil {
+		return nil, err
+	}
+	return http.ProxyURL(u), nil
+}
+
+func orDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
+// retryTransport retries requests that failed in a way that is safe to
+// retry, waiting a jittered, growing delay between attempts.
+type retryTransport struct {
+	name       string
+	next       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	budget     *retryBudget
+	logger     *zap.Logger
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip sends the request, retrying it as the policy allows.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.budget.deposit()
+	// A request body can only be sent again if it can be rewound
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !rewindable || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(t.baseDelay, t.maxDelay, attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > t.maxDelay {
+					// The server wants longer than we'll wait; pass on its answer
+					return resp, err
+				}
+				delay = after
+			}
+		}
+		if !t.budget.withdraw() {
+			t.logger.Debug("retry budget exhausted", zap.String("client", t.name))
+			return resp, err
+		}
+
+		if resp != nil {
+			drain(resp)
+		}
+		t.logger.Debug("retrying request",
+			zap.String("client", t.name),
+			zap.String("method", req.Method),
+			zap.String("host", req.URL.Host),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether a failed attempt may be sent again. Responses
+// saying the request wasn't processed (429, 503 and Anthropic's 529) are
+// retried for any method; other gateway errors and network failures only
+// for methods that are safe to repeat.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(req)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, 529:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req)
+	}
+	return false
+}
+
+// idempotent reports whether sending req twice has the effect of sending
+// it once.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns a random delay of up to base doubled per attempt, capped
+// at max ("full jitter"), so clients retrying together spread out.
+func backoff(base, max time.Duration, attempt int) time.Duration {
+	ceiling := base << uint(attempt)
+	if ceiling <= 0 || ceiling > max {
+		ceiling = max
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// retryAfter returns the wait a Retry-After header asks for.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// drain discards the rest of a response so its connection can be reused.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryBudget caps retries at a share of requests, so a provider that is
+// down isn't sent several times its usual traffic. Each request adds ratio
+// to the budget, up to retryReserve, and each retry spends one.
+type retryBudget struct {
+	mu     sync.Mutex
+	ratio  float64
+	tokens float64
+}
+
+func newRetryBudget(ratio float64) *retryBudget {
+	return &retryBudget{ratio: ratio, tokens: retryReserve}
+}
+
+func (b *retryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += b.ratio
+	if b.tokens > retryReserve {
+		b.tokens = retryReserve
+	}
+}
+
+func (b *retryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,423 @@
+// Package jobs runs the server's periodic background jobs, such as cleaning
+// up expired sessions and enforcing retention rules, recording how each run
+// went so operators can see and trigger them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// Run outcomes reported in Status and metrics.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomePanic   = "panic"
+)
+
+var (
+	// ErrNotFound is returned for a job name that isn't registered.
+	ErrNotFound = errors.New("job not found")
+	// ErrRunning is returned when a job is asked to run while it already is.
+	ErrRunning = errors.New("job is already running")
+	// ErrStopped is returned when a job is asked to run while the scheduler
+	// isn't running.
+	ErrStopped = errors.New("job scheduler is not running")
+)
+
+// Schedule decides when a job runs next.
+type Schedule interface {
+	// Next returns the time of the first run after t.
+	Next(t time.Time) time.Time
+	// String describes the schedule for operators.
+	String() string
+}
+
+// Every returns a schedule that runs a job at a fixed interval.
+func Every(interval time.Duration) Schedule {
+	return every(interval)
+}
+
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time { return t.Add(time.Duration(e)) }
+func (e every) String() string             { return "every " + time.Duration(e).String() }
+
+// Job is a unit of background work.
+type Job struct {
+	Name        string
+	Description string
+	Schedule    Schedule
+	// Timeout bounds each run; zero leaves runs unbounded.
+	Timeout time.Duration
+	// Jitter delays each scheduled run by a random amount up to this, so
+	// instances started together don't all run a job at once.
+	Jitter time.Duration
+	// RunAtStart runs the job as soon as the scheduler starts, as well as
+	// on its schedule.
+	RunAtStart bool
+	// Singleton jobs run only on the instance the scheduler's Elector
+	// chooses, when instances share a database.
+	Singleton bool
+	Run       func(ctx context.Context) error
+}
+
+// Elector decides whether this instance runs singleton jobs.
+// database.LeaderElector implements it.
+type Elector interface {
+	IsLeader() bool
+}
+
+// Status reports a job's schedule and its most recent run.
+type Status struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description,omitempty"`
+	Schedule     string     `json:"schedule"`
+	Singleton    bool       `json:"singleton,omitempty"`
+	Running      bool       `json:"running"`
+	NextRun      *time.Time `json:"next_run,omitempty"`
+	LastStarted  *time.Time `json:"last_started,omitempty"`
+	LastFinished *time.Time `json:"last_finished,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastOutcome  string     `json:"last_outcome,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	LastSkipped  *time.Time `json:"last_skipped,omitempty"`
+	Runs         int64      `json:"runs"`
+	Failures     int64      `json:"failures"`
+}
+
+// entry is a registered job and the state of its runs.
+type entry struct {
+	job Job
+
+	mu           sync.Mutex
+	running      bool
+	nextRun      time.Time
+	lastStarted  time.Time
+	lastFinished time.Time
+	lastDuration time.Duration
+	lastOutcome  string
+	lastError    string
+	lastSkipped  time.Time
+	runs         int64
+	failures     int64
+}
+
+// Scheduler runs registered jobs on their schedules. A job never runs
+// concurrently with itself: a run that comes due while the previous one is
+// still going is skipped.
+type Scheduler struct {
+	logger  *zap.Logger
+	metrics *metrics.Metrics
+	elector Elector
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running bool
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler with no jobs.
+func NewScheduler(logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+		jobs:   make(map[string]*entry),
+	}
+}
+
+// SetMetrics sets the metrics collector for recording job runs.
+func (s *Scheduler) SetMetrics(m *metrics.Metrics) {
+	s.metrics = m
+}
+
+// SetElector sets what decides whether this instance runs singleton jobs.
+// Without one, they run on every instance. Set it before Start.
+func (s *Scheduler) SetElector(e Elector) {
+	s.elector = e
+}
+
+// Register adds a job. Jobs registered after Start are scheduled at once.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return errors.New("job name is required")
+	}
+	if job.Schedule == nil {
+		return fmt.Errorf("job %s has no schedule", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("job %s has no run function", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name)
+	}
+	e := &entry{job: job}
+	s.jobs[job.Name] = e
+	if s.running {
+		s.startLocked(e)
+	}
+	return nil
+}
+
+// Start begins running jobs on their schedules until ctx is done or Stop
+// is called.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return errors.New("job scheduler already running")
+	}
+	s.running = true
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	s.logger.Info("starting job scheduler", zap.Int("jobs", len(s.jobs)))
+	for _, e := range s.jobs {
+		s.startLocked(e)
+	}
+	return nil
+}
+
+// Stop stops scheduling runs and cancels those in flight, waiting for them
+// to return or for ctx to be done.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.cancel()
+	s.mu.Unlock()
+
+	s.logger.Info("stopping job scheduler")
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunNow starts a run of the named job in the background, outside its
+// schedule. The job must not already be running here; a singleton job runs
+// even if another instance is the leader.
+func (s *Scheduler) RunNow(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[name]
+	if !ok {
+		return ErrNotFound
+	}
+	if !s.running {
+		return ErrStopped
+	}
+	if !e.claim() {
+		return ErrRunning
+	}
+
+	s.logger.Info("running job on request", zap.String("job", name))
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.ctx, e)
+	}()
+	return nil
+}
+
+// Status reports every job, ordered by name.
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	entries := make([]*entry, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		entries = append(entries, e)
+	}
+	s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(entries))
+	for _, e := range entries {
+		statuses = append(statuses, e.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// startLocked starts the loop running e on its schedule. s.mu must be held.
+func (s *Scheduler) startLocked(e *entry) {
+	s.wg.Add(1)
+	go s.loop(s.ctx, e)
+}
+
+// loop runs a job each time it comes due until ctx is done.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+
+	if e.job.RunAtStart && s.elected(e) && e.claim() {
+		s.run(ctx, e)
+	}
+
+	for {
+		next := e.job.Schedule.Next(time.Now())
+		if e.job.Jitter > 0 {
+			next = next.Add(time.Duration(rand.Int63n(int64(e.job.Jitter))))
+		}
+		e.mu.Lock()
+		e.nextRun = next
+		e.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !s.elected(e) {
+			continue
+		}
+		if !e.claim() {
+			s.logger.Debug("skipping job run, previous run still going", zap.String("job", e.job.Name))
+			continue
+		}
+		s.run(ctx, e)
+	}
+}
+
+// elected reports whether this instance should run e, recording the
+// skipped run if not.
+func (s *Scheduler) elected(e *entry) bool {
+	if !e.job.Singleton || s.elector == nil || s.elector.IsLeader() {
+		return true
+	}
+	s.logger.Debug("skipping singleton job, another instance is the leader", zap.String("job", e.job.Name))
+	e.mu.Lock()
+	e.lastSkipped = time.Now()
+	e.mu.Unlock()
+	return false
+}
+
+// run runs a claimed job once, recovering from a panic, and records how it
+// went.
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	if e.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.job.Timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	e.mu.Lock()
+	e.lastStarted = started
+	e.mu.Unlock()
+
+	outcome, err := s.invoke(ctx, e)
+	duration := time.Since(started)
+
+	e.mu.Lock()
+	e.running = false
+	e.lastFinished = time.Now()
+	e.lastDuration = duration
+	e.lastOutcome = outcome
+	e.lastError = ""
+	e.runs++
+	if err != nil {
+		e.lastError = err.Error()
+		e.failures++
+	}
+	e.mu.Unlock()
+
+	if s.metrics != nil {
+		s.metrics.RecordJobRun(e.job.Name, outcome, duration)
+	}
+	if err != nil {
+		s.logger.Warn("job failed",
+			zap.String("job", e.job.Name),
+			zap.String("outcome", outcome),
+			zap.Duration("duration", duration),
+			zap.Error(err),
+		)
+		return
+	}
+	s.logger.Debug("job finished", zap.String("job", e.job.Name), zap.Duration("duration", duration))
+}
+
+// invoke calls the job's function, turning a panic into an error.
+func (s *Scheduler) invoke(ctx context.Context, e *entry) (outcome string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("job panicked",
+				zap.String("job", e.job.Name),
+				zap.Any("panic", r),
+				zap.ByteString("stack", debug.Stack()),
+			)
+			outcome, err = OutcomePanic, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if err := e.job.Run(ctx); err != nil {
+		return OutcomeFailure, err
+	}
+	return OutcomeSuccess, nil
+}
+
+// claim marks the job running, reporting false if it already was.
+func (e *entry) claim() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return false
+	}
+	e.running = true
+	return true
+}
+
+func (e *entry) status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	st := Status{
+		Name:        e.job.Name,
+		Description: e.job.Description,
+		Schedule:    e.job.Schedule.String(),
+		Singleton:   e.job.Singleton,
+		Running:     e.running,
+		NextRun:     timePtr(e.nextRun),
+		LastStarted: timePtr(e.lastStarted),
+		LastOutcome: e.lastOutcome,
+		LastError:   e.lastError,
+		LastSkipped: timePtr(e.lastSkipped),
+		Runs:        e.runs,
+		Failures:    e.failures,
+	}
+	if !e.lastFinished.IsZero() {
+		st.LastFinished = timePtr(e.lastFinished)
+		st.LastDuration = e.lastDuration.String()
+	}
+	return st
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,254 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// startScheduler starts a scheduler with the given jobs, stopping it when
+// the test ends.
+func startScheduler(t *testing.T, jobs ...Job) *Scheduler {
+	t.Helper()
+	s := NewScheduler(zap.NewNop())
+	for _, job := range jobs {
+		if err := s.Register(job); err != nil {
+			t.Fatalf("Register(%s) error = %v", job.Name, err)
+		}
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := s.Stop(ctx); err != nil {
+			t.Errorf("Stop() error = %v", err)
+		}
+	})
+	return s
+}
+
+// waitFor polls until cond holds or a second passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func statusOf(t *testing.T, s *Scheduler, name string) Status {
+	t.Helper()
+	for _, st := range s.Status() {
+		if st.Name == name {
+			return st
+		}
+	}
+	t.Fatalf("no status for job %s", name)
+	return Status{}
+}
+
+func TestScheduler_RunsOnSchedule(t *testing.T) {
+	var runs int32
+	s := startScheduler(t, Job{
+		Name:     "cleanup",
+		Schedule: Every(10 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+
+	waitFor(t, "three runs", func() bool { return atomic.LoadInt32(&runs) >= 3 })
+
+	st := statusOf(t, s, "cleanup")
+	if st.LastOutcome != OutcomeSuccess || st.Failures != 0 {
+		t.Errorf("status = %+v, expected successful runs", st)
+	}
+	if st.Schedule != "every 10ms" {
+		t.Errorf("Schedule = %q", st.Schedule)
+	}
+}
+
+func TestScheduler_RecoversFromPanics(t *testing.T) {
+	var runs int32
+	s := startScheduler(t, Job{
+		Name:     "flaky",
+		Schedule: Every(time.Hour),
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&runs, 1) == 1 {
+				panic("boom")
+			}
+			return nil
+		},
+	})
+
+	if err := s.RunNow("flaky"); err != nil {
+		t.Fatalf("RunNow() error = %v", err)
+	}
+	waitFor(t, "the panicking run", func() bool { return statusOf(t, s, "flaky").Runs == 1 })
+
+	st := statusOf(t, s, "flaky")
+	if st.LastOutcome != OutcomePanic || !strings.Contains(st.LastError, "boom") || st.Failures != 1 {
+		t.Errorf("status = %+v, expected the panic recorded", st)
+	}
+
+	// The job still runs after panicking
+	if err := s.RunNow("flaky"); err != nil {
+		t.Fatalf("RunNow() error = %v", err)
+	}
+	waitFor(t, "the next run", func() bool { return statusOf(t, s, "flaky").Runs == 2 })
+	if st := statusOf(t, s, "flaky"); st.LastOutcome != OutcomeSuccess || st.LastError != "" {
+		t.Errorf("status = %+v, expected a successful run", st)
+	}
+}
+
+func TestScheduler_RunNowRefusesOverlappingRuns(t *testing.T) {
+	release := make(chan struct{})
+	s := startScheduler(t, Job{
+		Name:     "retention",
+		Schedule: Every(time.Hour),
+		Run: func(ctx context.Context) error {
+			<-release
+			return errors.New("rules failed")
+		},
+	})
+
+	if err := s.RunNow("retention"); err != nil {
+		t.Fatalf("RunNow() error = %v", err)
+	}
+	if err := s.RunNow("retention"); !errors.Is(err, ErrRunning) {
+		t.Errorf("second RunNow() error = %v, expected ErrRunning", err)
+	}
+	if !statusOf(t, s, "retention").Running {
+		t.Error("expected the job reported as running")
+	}
+
+	close(release)
+	waitFor(t, "the run to finish", func() bool { return !statusOf(t, s, "retention").Running })
+	if st := statusOf(t, s, "retention"); st.LastOutcome != OutcomeFailure || st.LastError != "rules failed" {
+		t.Errorf("status = %+v, expected the failure recorded", st)
+	}
+
+	if err := s.RunNow("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RunNow(missing) error = %v, expected ErrNotFound", err)
+	}
+}
+
+func TestScheduler_RunAtStartAndMetrics(t *testing.T) {
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	s := NewScheduler(zap.NewNop())
+	s.SetMetrics(m)
+	done := make(chan struct{})
+	if err := s.Register(Job{
+		Name:       "blocklist-sync",
+		Schedule:   Every(time.Hour),
+		RunAtStart: true,
+		Run: func(ctx context.Context) error {
+			close(done)
+			return nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop(context.Background())
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the job didn't run at start")
+	}
+	waitFor(t, "the run to be recorded", func() bool {
+		return testutil.ToFloat64(m.JobRunsTotal.WithLabelValues("blocklist-sync", OutcomeSuccess)) == 1
+	})
+	if st := statusOf(t, s, "blocklist-sync"); st.NextRun == nil {
+		t.Error("expected the next scheduled run reported")
+	}
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s := NewScheduler(zap.NewNop())
+	job := Job{Name: "sessions", Schedule: Every(time.Hour), Run: func(context.Context) error { return nil }}
+	if err := s.Register(job); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := s.Register(job); err == nil {
+		t.Error("expected an error registering the same name twice")
+	}
+	if err := s.Register(Job{Name: "no-schedule", Run: job.Run}); err == nil {
+		t.Error("expected an error for a job without a schedule")
+	}
+	if err := s.RunNow("sessions"); !errors.Is(err, ErrStopped) {
+		t.Errorf("RunNow() before Start error = %v, expected ErrStopped", err)
+	}
+}
+
+// fakeElector implements Elector for testing
+type fakeElector struct {
+	leader atomic.Bool
+}
+
+func (f *fakeElector) IsLeader() bool {
+	return f.leader.Load()
+}
+
+func TestScheduler_SingletonJobsRunOnlyOnTheLeader(t *testing.T) {
+	elector := &fakeElector{}
+	var singletonRuns, localRuns int32
+	s := NewScheduler(zap.NewNop())
+	s.SetElector(elector)
+	for _, job := range []Job{
+		{
+			Name:      "retention",
+			Schedule:  Every(10 * time.Millisecond),
+			Singleton: true,
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&singletonRuns, 1)
+				return nil
+			},
+		},
+		{
+			Name:     "db-metrics",
+			Schedule: Every(10 * time.Millisecond),
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&localRuns, 1)
+				return nil
+			},
+		},
+	} {
+		if err := s.Register(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop(context.Background())
+
+	waitFor(t, "the local job to run", func() bool { return atomic.LoadInt32(&localRuns) >= 3 })
+	if n := atomic.LoadInt32(&singletonRuns); n != 0 {
+		t.Fatalf("the singleton job ran %d times without leadership", n)
+	}
+	if st := statusOf(t, s, "retention"); st.LastSkipped == nil || !st.Singleton {
+		t.Errorf("status = %+v, expected the skipped run recorded", st)
+	}
+
+	elector.leader.Store(true)
+	waitFor(t, "the singleton job to run once leader", func() bool { return atomic.LoadInt32(&singletonRuns) >= 1 })
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 )
 
 // Outcome/status label values for metrics.
@@ -39,7 +41,12 @@ type Metrics struct {
 	// Voice provider metrics
 	WebhooksReceivedTotal   *prometheus.CounterVec
 	WebhookProcessDuration  *prometheus.HistogramVec
+	WebhookAuthFailures     *prometheus.CounterVec
+	WebhookDuplicatesTotal  *prometheus.CounterVec
 	ProviderCallsTotal      *prometheus.CounterVec
+	ProviderAPICallDuration *prometheus.HistogramVec
+	ProviderAPICallFailures *prometheus.CounterVec
+	ProviderFailoversTotal  *prometheus.CounterVec
 
 	// External service metrics
 	ClaudeAPICallsTotal     *prometheus.CounterVec
@@ -47,6 +54,12 @@ type Metrics struct {
 	CircuitBreakerState     *prometheus.GaugeVec
 	CircuitBreakerTrips     prometheus.Counter
 
+	// AI usage metrics
+	ClaudeTokensTotal    *prometheus.CounterVec
+	ClaudeCostTotal      prometheus.Counter
+	QuoteJobTokens       *prometheus.HistogramVec
+	QuoteJobCost         prometheus.Histogram
+
 	// Database metrics
 	DBConnectionsOpen   prometheus.Gauge
 	DBConnectionsInUse  prometheus.Gauge
@@ -57,6 +70,11 @@ type Metrics struct {
 	RateLimitHitsTotal  *prometheus.CounterVec
 	RateLimitCurrent    *prometheus.GaugeVec
 
+	// Background job metrics
+	JobRunsTotal   *prometheus.CounterVec
+	JobRunDuration *prometheus.HistogramVec
+	JobLastSuccess *prometheus.GaugeVec
+
 	// Registry used for this metrics instance (nil means default registry)
 	registry prometheus.Gatherer
 }
@@ -174,6 +192,20 @@ func newMetricsWithRegistry(registerer prometheus.Registerer) *Metrics {
 			},
 			[]string{"provider"},
 		),
+		WebhookAuthFailures: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_webhook_signature_failures_total",
+				Help: "Total number of webhooks that failed signature verification by provider, reason and enforcement mode",
+			},
+			[]string{"provider", "reason", "mode"}, // mode: "reject", "log"
+		),
+		WebhookDuplicatesTotal: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_webhook_duplicates_total",
+				Help: "Total number of redelivered webhooks skipped by provider",
+			},
+			[]string{"provider"},
+		),
 		ProviderCallsTotal: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "quickquote_provider_calls_total",
@@ -181,6 +213,28 @@ func newMetricsWithRegistry(registerer prometheus.Registerer) *Metrics {
 			},
 			[]string{"provider", "call_status"},
 		),
+		ProviderAPICallDuration: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "quickquote_provider_api_call_duration_seconds",
+				Help:    "Duration of voice provider API calls by provider and operation",
+				Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"provider", "operation"},
+		),
+		ProviderAPICallFailures: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_provider_api_call_failures_total",
+				Help: "Total number of failed voice provider API calls by provider and operation",
+			},
+			[]string{"provider", "operation"},
+		),
+		ProviderFailoversTotal: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_provider_failovers_total",
+				Help: "Total number of outbound calls failed over from one voice provider to another",
+			},
+			[]string{"from", "to"},
+		),
 
 		// External service metrics
 		ClaudeAPICallsTotal: factory.NewCounterVec(
@@ -211,6 +265,36 @@ func newMetricsWithRegistry(registerer prometheus.Registerer) *Metrics {
 			},
 		),
 
+		// AI usage metrics
+		ClaudeTokensTotal: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_claude_tokens_total",
+				Help: "Total number of Claude tokens used by type",
+			},
+			[]string{"type"}, // "input", "output"
+		),
+		ClaudeCostTotal: factory.NewCounter(
+			prometheus.CounterOpts{
+				Name: "quickquote_claude_cost_dollars_total",
+				Help: "Estimated total cost of Claude API usage in US dollars",
+			},
+		),
+		QuoteJobTokens: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "quickquote_quote_job_tokens",
+				Help:    "Claude tokens used per quote job attempt by type",
+				Buckets: prometheus.ExponentialBuckets(250, 2, 8), // 250 to 32000
+			},
+			[]string{"type"},
+		),
+		QuoteJobCost: factory.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "quickquote_quote_job_cost_dollars",
+				Help:    "Estimated Claude cost per quote job attempt in US dollars",
+				Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25},
+			},
+		),
+
 		// Database metrics
 		DBConnectionsOpen: factory.NewGauge(
 			prometheus.GaugeOpts{
@@ -255,6 +339,30 @@ func newMetricsWithRegistry(registerer prometheus.Registerer) *Metrics {
 			},
 			[]string{"limiter", "window"}, // window: "minute", "hour", "day"
 		),
+
+		// Background job metrics
+		JobRunsTotal: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "quickquote_job_runs_total",
+				Help: "Total number of background job runs by job and outcome",
+			},
+			[]string{"job", "outcome"}, // "success", "failure", "panic"
+		),
+		JobRunDuration: factory.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "quickquote_job_run_duration_seconds",
+				Help:    "Duration of background job runs",
+				Buckets: []float64{.01, .1, .5, 1, 5, 15, 60, 300, 1800},
+			},
+			[]string{"job"},
+		),
+		JobLastSuccess: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "quickquote_job_last_success_timestamp_seconds",
+				Help: "Unix time of each background job's last successful run",
+			},
+			[]string{"job"},
+		),
 	}
 }
 
@@ -317,6 +425,12 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // normalizePath normalizes URL paths to prevent high cardinality labels.
 func normalizePath(path string) string {
 	// Map specific paths
@@ -386,11 +500,36 @@ func (m *Metrics) RecordWebhook(provider, status string, duration time.Duration)
 	m.WebhookProcessDuration.WithLabelValues(provider).Observe(duration.Seconds())
 }
 
+// RecordWebhookSignatureFailure records a webhook that failed signature
+// verification.
+func (m *Metrics) RecordWebhookSignatureFailure(provider, reason, mode string) {
+	m.WebhookAuthFailures.WithLabelValues(provider, reason, mode).Inc()
+}
+
+// RecordWebhookDuplicate records a redelivered webhook that was skipped.
+func (m *Metrics) RecordWebhookDuplicate(provider string) {
+	m.WebhookDuplicatesTotal.WithLabelValues(provider).Inc()
+}
+
 // RecordProviderCall records a call from a voice provider.
 func (m *Metrics) RecordProviderCall(provider, callStatus string) {
 	m.ProviderCallsTotal.WithLabelValues(provider, callStatus).Inc()
 }
 
+// RecordProviderAPICall records a call to a voice provider's API.
+func (m *Metrics) RecordProviderAPICall(provider, operation string, duration time.Duration, err error) {
+	m.ProviderAPICallDuration.WithLabelValues(provider, operation).Observe(duration.Seconds())
+	if err != nil {
+		m.ProviderAPICallFailures.WithLabelValues(provider, operation).Inc()
+	}
+}
+
+// RecordProviderFailover records an outbound call failing over from one
+// voice provider to another.
+func (m *Metrics) RecordProviderFailover(from, to string) {
+	m.ProviderFailoversTotal.WithLabelValues(from, to).Inc()
+}
+
 // RecordClaudeAPICall records a Claude API call.
 func (m *Metrics) RecordClaudeAPICall(success bool, duration time.Duration) {
 	status := outcomeFailure
@@ -401,6 +540,21 @@ func (m *Metrics) RecordClaudeAPICall(success bool, duration time.Duration) {
 	m.ClaudeAPICallDuration.Observe(duration.Seconds())
 }
 
+// RecordClaudeUsage records the tokens used by a Claude API call and their
+// estimated cost.
+func (m *Metrics) RecordClaudeUsage(inputTokens, outputTokens int, cost float64) {
+	m.ClaudeTokensTotal.WithLabelValues("input").Add(float64(inputTokens))
+	m.ClaudeTokensTotal.WithLabelValues("output").Add(float64(outputTokens))
+	m.ClaudeCostTotal.Add(cost)
+}
+
+// RecordQuoteJobUsage records the Claude usage of one quote job attempt.
+func (m *Metrics) RecordQuoteJobUsage(usage TokenUsageSnapshot) {
+	m.QuoteJobTokens.WithLabelValues("input").Observe(float64(usage.InputTokens))
+	m.QuoteJobTokens.WithLabelValues("output").Observe(float64(usage.OutputTokens))
+	m.QuoteJobCost.Observe(usage.Cost)
+}
+
 // RecordCircuitOpen records a circuit breaker opening.
 func (m *Metrics) RecordCircuitOpen() {
 	m.ClaudeAPICallsTotal.WithLabelValues("circuit_open").Inc()
@@ -413,6 +567,22 @@ func (m *Metrics) SetCircuitBreakerState(service string, state int) {
 	m.CircuitBreakerState.WithLabelValues(service).Set(float64(state))
 }
 
+// CircuitBreakerStateChanged records a circuit breaker's new state, counting
+// a trip when it opens. It is a circuitbreaker.StateChangeFunc.
+func (m *Metrics) CircuitBreakerStateChanged(name string, from, to circuitbreaker.State) {
+	switch to {
+	case circuitbreaker.StateClosed:
+		m.SetCircuitBreakerState(name, 0)
+	case circuitbreaker.StateHalfOpen:
+		m.SetCircuitBreakerState(name, 1)
+	case circuitbreaker.StateOpen:
+		m.SetCircuitBreakerState(name, 2)
+		if from != circuitbreaker.StateOpen {
+			m.CircuitBreakerTrips.Inc()
+		}
+	}
+}
+
 // UpdateDBConnections updates database connection metrics.
 func (m *Metrics) UpdateDBConnections(open, inUse int) {
 	m.DBConnectionsOpen.Set(float64(open))
@@ -447,6 +617,15 @@ func (m *Metrics) RecordQuoteJobProcessed(status string) {
 	m.QuoteJobsProcessed.WithLabelValues(status).Inc()
 }
 
+// RecordJobRun records a background job run and its outcome.
+func (m *Metrics) RecordJobRun(job, outcome string, duration time.Duration) {
+	m.JobRunsTotal.WithLabelValues(job, outcome).Inc()
+	m.JobRunDuration.WithLabelValues(job).Observe(duration.Seconds())
+	if outcome == outcomeSuccess {
+		m.JobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
 // SetActiveSessions sets the number of active sessions.
 func (m *Metrics) SetActiveSessions(count int) {
 	m.SessionsActive.Set(float64(count))
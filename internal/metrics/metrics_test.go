@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 )
 
 func TestNewMetrics(t *testing.T) {
@@ -148,6 +151,24 @@ func TestMetrics_SetCircuitBreakerState(t *testing.T) {
 	}
 }
 
+func TestMetrics_CircuitBreakerStateChanged(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetricsWithRegistry(reg)
+
+	m.CircuitBreakerStateChanged("vapi-api", circuitbreaker.StateClosed, circuitbreaker.StateClosed)
+	m.CircuitBreakerStateChanged("vapi-api", circuitbreaker.StateClosed, circuitbreaker.StateOpen)
+	if state := testutil.ToFloat64(m.CircuitBreakerState.WithLabelValues("vapi-api")); state != 2 {
+		t.Errorf("state = %f, expected 2 (open)", state)
+	}
+	m.CircuitBreakerStateChanged("vapi-api", circuitbreaker.StateOpen, circuitbreaker.StateHalfOpen)
+	if state := testutil.ToFloat64(m.CircuitBreakerState.WithLabelValues("vapi-api")); state != 1 {
+		t.Errorf("state = %f, expected 1 (half-open)", state)
+	}
+	if trips := testutil.ToFloat64(m.CircuitBreakerTrips); trips != 1 {
+		t.Errorf("trip count = %f, expected 1", trips)
+	}
+}
+
 func TestMetrics_UpdateDBConnections(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	m := NewMetricsWithRegistry(reg)
@@ -406,3 +427,63 @@ func TestMetrics_Handler(t *testing.T) {
 		t.Errorf("status = %d, expected %d", rr.Code, http.StatusOK)
 	}
 }
+
+func TestMetrics_RecordProviderAPICall(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetricsWithRegistry(reg)
+
+	m.RecordProviderAPICall("bland", "POST /calls", 200*time.Millisecond, nil)
+	m.RecordProviderAPICall("bland", "POST /calls", 2*time.Second, errors.New("timeout"))
+
+	if n := testutil.CollectAndCount(m.ProviderAPICallDuration); n != 1 {
+		t.Errorf("expected 1 duration series, got %d", n)
+	}
+	failures := testutil.ToFloat64(m.ProviderAPICallFailures.WithLabelValues("bland", "POST /calls"))
+	if failures != 1 {
+		t.Errorf("failure count = %f, expected 1", failures)
+	}
+}
+
+func TestMetrics_RecordProviderFailover(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetricsWithRegistry(reg)
+
+	m.RecordProviderFailover("bland", "retell")
+	m.RecordProviderFailover("bland", "retell")
+
+	if n := testutil.ToFloat64(m.ProviderFailoversTotal.WithLabelValues("bland", "retell")); n != 2 {
+		t.Errorf("failover count = %f, expected 2", n)
+	}
+}
+
+func TestMetrics_RecordClaudeUsage(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetricsWithRegistry(reg)
+
+	m.RecordClaudeUsage(1000, 500, 0.0105)
+	m.RecordClaudeUsage(2000, 100, 0.0075)
+
+	if v := testutil.ToFloat64(m.ClaudeTokensTotal.WithLabelValues("input")); v != 3000 {
+		t.Errorf("input tokens = %f, expected 3000", v)
+	}
+	if v := testutil.ToFloat64(m.ClaudeTokensTotal.WithLabelValues("output")); v != 600 {
+		t.Errorf("output tokens = %f, expected 600", v)
+	}
+	if v := testutil.ToFloat64(m.ClaudeCostTotal); v < 0.0179 || v > 0.0181 {
+		t.Errorf("cost = %f, expected 0.018", v)
+	}
+}
+
+func TestMetrics_RecordQuoteJobUsage(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetricsWithRegistry(reg)
+
+	m.RecordQuoteJobUsage(TokenUsageSnapshot{InputTokens: 1200, OutputTokens: 800, Cost: 0.0156})
+
+	if n := testutil.CollectAndCount(m.QuoteJobTokens); n != 2 {
+		t.Errorf("expected input and output token series, got %d", n)
+	}
+	if n := testutil.CollectAndCount(m.QuoteJobCost); n != 1 {
+		t.Errorf("expected 1 cost series, got %d", n)
+	}
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+)
+
+// ModelPricing is the price of a model's tokens in US dollars per million.
+type ModelPricing struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// Cost returns the estimated cost of a request in US dollars.
+func (p ModelPricing) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.InputPerMTok + float64(outputTokens)*p.OutputPerMTok) / 1e6
+}
+
+// TokenUsage accumulates the AI tokens used by a unit of work, such as a quote
+// job attempt, across every API call made on its behalf. It is safe for
+// concurrent use.
+type TokenUsage struct {
+	mu    sync.Mutex
+	usage TokenUsageSnapshot
+}
+
+// TokenUsageSnapshot is the usage accumulated at a point in time.
+type TokenUsageSnapshot struct {
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+}
+
+// Add adds the usage of one API call.
+func (u *TokenUsage) Add(inputTokens, outputTokens int, cost float64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.usage.InputTokens += inputTokens
+	u.usage.OutputTokens += outputTokens
+	u.usage.Cost += cost
+}
+
+// Snapshot returns the usage accumulated so far.
+func (u *TokenUsage) Snapshot() TokenUsageSnapshot {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.usage
+}
+
+type tokenUsageKey struct{}
+
+// WithTokenUsage returns a context that accumulates the usage reported by
+// AddTokenUsage into the returned TokenUsage.
+func WithTokenUsage(ctx context.Context) (context.Context, *TokenUsage) {
+	usage := &TokenUsage{}
+	return context.WithValue(ctx, tokenUsageKey{}, usage), usage
+}
+
+// AddTokenUsage adds the usage of one API call to the TokenUsage carried by
+// ctx, if any.
+func AddTokenUsage(ctx context.Context, inputTokens, outputTokens int, cost float64) {
+	if usage, ok := ctx.Value(tokenUsageKey{}).(*TokenUsage); ok {
+		usage.Add(inputTokens, outputTokens, cost)
+	}
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+)
+
+func TestModelPricing_Cost(t *testing.T) {
+	p := ModelPricing{InputPerMTok: 3, OutputPerMTok: 15}
+
+	got := p.Cost(1_000_000, 100_000)
+	if got != 4.5 {
+		t.Errorf("Cost = %f, expected 4.5", got)
+	}
+	if (ModelPricing{}).Cost(1000, 1000) != 0 {
+		t.Error("expected zero cost without pricing")
+	}
+}
+
+func TestTokenUsage_Context(t *testing.T) {
+	// Usage reported without an accumulator is ignored.
+	AddTokenUsage(context.Background(), 10, 10, 1)
+
+	ctx, usage := WithTokenUsage(context.Background())
+	AddTokenUsage(ctx, 100, 50, 0.001)
+	AddTokenUsage(ctx, 200, 25, 0.002)
+
+	got := usage.Snapshot()
+	if got.InputTokens != 300 || got.OutputTokens != 75 {
+		t.Errorf("unexpected tokens %+v", got)
+	}
+	if got.Cost < 0.00299 || got.Cost > 0.00301 {
+		t.Errorf("cost = %f, expected 0.003", got.Cost)
+	}
+}
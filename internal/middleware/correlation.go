@@ -2,13 +2,19 @@
 package middleware
 
 import (
+	"bufio"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"net"
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/tracing"
 )
 
 // Correlation ID constants.
@@ -77,6 +83,18 @@ func (rc *RequestCorrelation) Middleware(next http.Handler) http.Handler {
 		// Always generate a new span ID
 		spanID := generateID()[:16] // Shorter span ID
 
+		// When the request is traced, use the span's IDs so logs match the
+		// exported trace, and record the correlation IDs on the span.
+		if span := trace.SpanFromContext(ctx); tracing.Enabled() && span.SpanContext().IsValid() {
+			sc := span.SpanContext()
+			traceID = sc.TraceID().String()
+			spanID = sc.SpanID().String()
+			span.SetAttributes(
+				attribute.String("correlation.id", correlationID),
+				attribute.String("request.id", requestID),
+			)
+		}
+
 		// Add to context
 		ctx = context.WithValue(ctx, correlationIDKey{}, correlationID)
 		ctx = context.WithValue(ctx, requestIDKey{}, requestID)
@@ -133,6 +151,18 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return rw.ResponseWriter.Write(b)
 }
 
+// Unwrap returns the underlying ResponseWriter so http.ResponseController
+// can reach optional interfaces such as http.Flusher.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack lets WebSocket handlers take over the connection, including through
+// chi's Compress middleware, which looks for http.Hijacker directly.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
 // GetCorrelationID retrieves the correlation ID from context.
 func GetCorrelationID(ctx context.Context) string {
 	if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
@@ -226,4 +256,5 @@ func PropagateHeaders(ctx context.Context, req *http.Request) {
 	}
 	// Create new span ID for outgoing call
 	req.Header.Set(SpanIDHeader, generateID()[:16])
+	tracing.Inject(ctx, req.Header)
 }
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAge is how long browsers may cache a preflight response.
+const corsMaxAge = 24 * 60 * 60
+
+// CORS lets pages on other sites call the wrapped routes from the browser.
+// allowedOrigins lists origins such as "https://example.com"; an empty list
+// allows any origin. Preflight requests are answered here. Requests from
+// an origin that is not allowed are refused, so the routes can't be used
+// from other sites' pages even by clients that ignore CORS headers.
+// Cookies are never allowed, so the routes must not rely on a session.
+func CORS(allowedOrigins []string, methods ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.TrimRight(origin, "/")] = true
+	}
+	allowMethods := strings.Join(append(methods, http.MethodOptions), ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				// Not a cross-origin browser request
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			if len(allowed) > 0 && !allowed[origin] {
+				http.Error(w, "Origin not allowed", http.StatusForbidden)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -259,6 +259,13 @@ func (c *CSRFProtection) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// API key requests authenticate with an explicit Authorization header
+		// rather than ambient cookies, so they cannot be forged cross-site.
+		if isAPIKeyRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// For state-changing methods, validate CSRF token
 		cookieToken := c.getTokenFromCookie(r)
 		if cookieToken == "" {
@@ -379,6 +386,13 @@ func (c *CSRFProtection) GetToken(r *http.Request) string {
 	return cookie.Value
 }
 
+// isAPIKeyRequest reports whether the request carries an API key bearer token
+// for the JSON API.
+func isAPIKeyRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/api/") &&
+		strings.HasPrefix(r.Header.Get("Authorization"), "Bearer qq_")
+}
+
 // SkipPath wraps the middleware to skip CSRF checks for specific paths.
 // Paths ending with "/" are treated as prefixes (e.g., "/api/" matches "/api/v1/foo").
 func (c *CSRFProtection) SkipPath(paths ...string) func(http.Handler) http.Handler {
@@ -123,6 +123,40 @@ func TestCSRFProtection_Middleware_UnsafeMethodsBlocked(t *testing.T) {
 	}
 }
 
+func TestCSRFProtection_Middleware_APIKeyBypass(t *testing.T) {
+	logger := zap.NewNop()
+	csrf := NewCSRFProtection(logger)
+
+	handler := csrf.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		path     string
+		auth     string
+		expected int
+	}{
+		{"api key on api path", "/api/v1/calls", "Bearer qq_abc", http.StatusOK},
+		{"api key on non-api path", "/settings", "Bearer qq_abc", http.StatusForbidden},
+		{"other bearer token", "/api/v1/calls", "Bearer xyz", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("Authorization", tt.auth)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+		})
+	}
+}
+
 func TestCSRFProtection_Middleware_ValidToken(t *testing.T) {
 	logger := zap.NewNop()
 	csrf := NewCSRFProtection(logger)
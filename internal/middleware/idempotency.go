@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client's
+// idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks a response replayed from an earlier request.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+const (
+	// IdempotencyKeyTTL is how long a stored response is replayed.
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// idempotencyLockTimeout is how long a request holds its key before a
+	// retry may assume it died and run the request again.
+	idempotencyLockTimeout = 5 * time.Minute
+
+	// maxIdempotencyKeyLength bounds the keys clients may send.
+	maxIdempotencyKeyLength = 255
+
+	// maxIdempotentBodySize is the largest response body stored for replay.
+	maxIdempotentBodySize = 1 << 20
+)
+
+// IdempotencyStore keeps idempotent requests and their responses.
+// repository.IdempotencyRepository implements it.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, key, requestHash string, expiresAt, staleBefore time.Time) (*domain.IdempotentRequest, error)
+	Complete(ctx context.Context, req *domain.IdempotentRequest) error
+	Release(ctx context.Context, key string) error
+}
+
+// Idempotency returns HTTP middleware that makes POST, PUT and PATCH
+// requests sent with an Idempotency-Key header safe to retry. The first
+// request with a key runs and its response is stored; retries with the same
+// key get that response back instead of repeating the change. Keys are
+// scoped to the authenticated user. Reusing a key for a different request
+// is refused with 422, and a retry that arrives while the first request is
+// still running gets 409. Server errors aren't stored, so those requests
+// can be retried; nor are streamed responses or bodies too large to keep,
+// so retrying those runs the request again.
+func Idempotency(store IdempotencyStore, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientKey := r.Header.Get(IdempotencyKeyHeader)
+			if clientKey == "" || !isIdempotentMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(clientKey) > maxIdempotencyKeyLength {
+				apiError(w, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				apiError(w, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key := idempotencyScope(r) + ":" + clientKey
+			hash := requestHash(r, body)
+			now := time.Now()
+
+			existing, err := store.Reserve(r.Context(), key, hash, now.Add(IdempotencyKeyTTL), now.Add(-idempotencyLockTimeout))
+			if err != nil {
+				logger.Error("failed to reserve idempotency key", zap.String("path", r.URL.Path), zap.Error(err))
+				apiError(w, http.StatusInternalServerError, "failed to check idempotency key")
+				return
+			}
+			if existing != nil {
+				switch {
+				case existing.RequestHash != hash:
+					apiError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+				case !existing.Completed:
+					apiError(w, http.StatusConflict, "a request with this Idempotency-Key is still being processed")
+				default:
+					replayIdempotentResponse(w, existing)
+				}
+				return
+			}
+
+			rec := &idempotencyRecorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				// Store the outcome even if the client has gone away
+				ctx := context.WithoutCancel(r.Context())
+
+				if p := recover(); p != nil {
+					if err := store.Release(ctx, key); err != nil {
+						logger.Warn("failed to release idempotency key", zap.Error(err))
+					}
+					panic(p)
+				}
+
+				if rec.status >= http.StatusInternalServerError || rec.streamed || rec.tooLarge {
+					if err := store.Release(ctx, key); err != nil {
+						logger.Warn("failed to release idempotency key", zap.Error(err))
+					}
+					return
+				}
+
+				err := store.Complete(ctx, &domain.IdempotentRequest{
+					Key:         key,
+					RequestHash: hash,
+					Completed:   true,
+					StatusCode:  rec.status,
+					ContentType: rec.Header().Get("Content-Type"),
+					Location:    rec.Header().Get("Location"),
+					Body:        rec.body.Bytes(),
+				})
+				if err != nil {
+					logger.Warn("failed to store idempotent response",
+						zap.String("path", r.URL.Path),
+						zap.Error(err),
+					)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// isIdempotentMethod reports whether requests with method are made
+// idempotent by key.
+func isIdempotentMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// idempotencyScope is the namespace of the request's keys: the
+// authenticated user, so clients can't collide with each other's keys.
+func idempotencyScope(r *http.Request) string {
+	if userID, ok := UserIDFromContext(r.Context()); ok {
+		return userID.String()
+	}
+	return "anonymous"
+}
+
+// requestHash identifies a request by method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayIdempotentResponse writes a stored response.
+func replayIdempotentResponse(w http.ResponseWriter, req *domain.IdempotentRequest) {
+	if req.ContentType != "" {
+		w.Header().Set("Content-Type", req.ContentType)
+	}
+	if req.Location != "" {
+		w.Header().Set("Location", req.Location)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(req.StatusCode)
+	w.Write(req.Body)
+}
+
+// apiError writes an API error response.
+func apiError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   http.StatusText(status),
+		"code":    string(apperrors.CodeForStatus(status)),
+		"message": message,
+	})
+}
+
+// idempotencyRecorder passes a response through while keeping a copy, up
+// to maxIdempotentBodySize. A handler that flushes or lifts its write
+// deadline is streaming, and its response isn't kept.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	streamed    bool
+	tooLarge    bool
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	if !r.streamed && !r.tooLarge {
+		if r.body.Len()+len(b) > maxIdempotentBodySize {
+			r.tooLarge = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *idempotencyRecorder) Flush() {
+	r.streamed = true
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *idempotencyRecorder) SetWriteDeadline(deadline time.Time) error {
+	r.streamed = true
+	return http.NewResponseController(r.ResponseWriter).SetWriteDeadline(deadline)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (r *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore.
+type memoryIdempotencyStore struct {
+	mu       sync.Mutex
+	requests map[string]*domain.IdempotentRequest
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{requests: make(map[string]*domain.IdempotentRequest)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, key, requestHash string, expiresAt, staleBefore time.Time) (*domain.IdempotentRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.requests[key]; ok {
+		cp := *existing
+		return &cp, nil
+	}
+	s.requests[key] = &domain.IdempotentRequest{Key: key, RequestHash: requestHash}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, req *domain.IdempotentRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *req
+	s.requests[req.Key] = &cp
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.requests, key)
+	return nil
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyStore(), zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"call_id":"abc"}`))
+	}))
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/calls", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req = req.WithContext(WithUserID(req.Context(), uuid.MustParse("11111111-1111-1111-1111-111111111111")))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := send(`{"phone_number":"+15551234567"}`)
+	if first.Code != http.StatusCreated || first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Fatalf("first request got %d, replayed %q", first.Code, first.Header().Get(IdempotentReplayedHeader))
+	}
+
+	retry := send(`{"phone_number":"+15551234567"}`)
+	if calls != 1 {
+		t.Errorf("handler ran %d times, expected once", calls)
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != `{"call_id":"abc"}` {
+		t.Errorf("retry got %d %q, expected the stored response", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get(IdempotentReplayedHeader) != "true" || retry.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected retry headers %v", retry.Header())
+	}
+
+	reused := send(`{"phone_number":"+15559999999"}`)
+	if reused.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Errorf("key reused for a different body got %d, expected %d", reused.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	store.requests["anonymous:key-1"] = &domain.IdempotentRequest{
+		Key:         "anonymous:key-1",
+		RequestHash: requestHash(httptest.NewRequest(http.MethodPost, "/x", nil), []byte("{}")),
+	}
+	handler := Idempotency(store, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler ran while the first request was still running")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader("{}"))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rec.Code)
+	}
+}
+
+func TestIdempotency_ReleasesOnServerError(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	status := http.StatusInternalServerError
+	calls := 0
+	handler := Idempotency(store, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/x", strings.NewReader("{}"))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	send()
+	status = http.StatusOK
+	if code := send(); code != http.StatusOK || calls != 2 {
+		t.Errorf("retry after a server error got %d after %d calls, expected it to run again", code, calls)
+	}
+}
+
+func TestIdempotency_PassesThrough(t *testing.T) {
+	calls := 0
+	handler := Idempotency(newMemoryIdempotencyStore(), zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	// No key, and a method that isn't covered
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/x", strings.NewReader("{}")))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/x", strings.NewReader("{}")))
+	req := httptest.NewRequest(http.MethodDelete, "/x", http.NoBody)
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if calls != 4 {
+		t.Errorf("handler ran %d times, expected 4", calls)
+	}
+}
+
+func TestIdempotency_SkipsStreamedAndLargeResponses(t *testing.T) {
+	tests := []struct {
+		name  string
+		serve func(w http.ResponseWriter)
+	}{
+		{"lifted write deadline", func(w http.ResponseWriter) {
+			_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+			w.Write([]byte("id,phone\n"))
+		}},
+		{"flushed", func(w http.ResponseWriter) {
+			w.Write([]byte("id,phone\n"))
+			_ = http.NewResponseController(w).Flush()
+		}},
+		{"too large", func(w http.ResponseWriter) {
+			w.Write([]byte(strings.Repeat("x", maxIdempotentBodySize)))
+			w.Write([]byte("x"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryIdempotencyStore()
+			handler := Idempotency(store, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tt.serve(w)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/calls/bulk", strings.NewReader("{}"))
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+				t.Fatalf("got %d with %d bytes, expected the response passed through", rec.Code, rec.Body.Len())
+			}
+			if len(store.requests) != 0 {
+				t.Errorf("stored %d responses, expected the key released", len(store.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ParseIPNets parses a comma-separated list of CIDR ranges and single IP
+// addresses, such as "10.0.0.0/8, 203.0.113.7". An empty list parses to nil.
+func ParseIPNets(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// containsIP reports whether any of nets contains ip.
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustedProxies decides whose forwarding headers are believed when
+// working out a request's client IP.
+type TrustedProxies struct {
+	all  bool
+	nets []*net.IPNet
+}
+
+// ParseTrustedProxies parses the trusted proxy setting: "*" trusts the
+// forwarding headers of every peer, "none" of no peer, and anything else is
+// a list of CIDR ranges and addresses for ParseIPNets.
+func ParseTrustedProxies(spec string) (*TrustedProxies, error) {
+	switch strings.TrimSpace(spec) {
+	case "*":
+		return &TrustedProxies{all: true}, nil
+	case "", "none":
+		return &TrustedProxies{}, nil
+	}
+	nets, err := ParseIPNets(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedProxies{nets: nets}, nil
+}
+
+// trusts reports whether the proxy at ip is trusted.
+func (t *TrustedProxies) trusts(ip net.IP) bool {
+	return t.all || containsIP(t.nets, ip)
+}
+
+// RealIP sets each request's RemoteAddr to the client IP. Forwarding
+// headers are only believed from a trusted proxy: X-Forwarded-For is read
+// from the right, skipping trusted proxies, and the first address that
+// isn't one is the client. Unless every peer is trusted, the headers are
+// then replaced with the client IP alone, so code reading them directly
+// can't be fooled by a spoofed X-Forwarded-For.
+func RealIP(trusted *TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			peer := remoteIP(r)
+			if peer == nil || !trusted.trusts(peer) {
+				if !trusted.all {
+					normalizeForwardedFor(r, hostOnly(r.RemoteAddr))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := forwardedClient(r, trusted)
+			if client != "" {
+				r.RemoteAddr = client
+				if !trusted.all {
+					normalizeForwardedFor(r, client)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedClient returns the client IP given by a trusted proxy's
+// forwarding headers, or "" if they don't give one.
+func forwardedClient(r *http.Request, trusted *TrustedProxies) string {
+	if trusted.all {
+		// Every peer is trusted, so the headers are taken as they come
+		for _, header := range []string{"True-Client-IP", "X-Real-IP"} {
+			if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(header))); ip != nil {
+				return ip.String()
+			}
+		}
+		if hops := forwardedHops(r); len(hops) > 0 {
+			return hops[0]
+		}
+		return ""
+	}
+
+	hops := forwardedHops(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(hops[i])
+		if ip == nil {
+			// Garbage in the chain: nothing to its left can be believed
+			return ""
+		}
+		if !trusted.trusts(ip) {
+			return ip.String()
+		}
+	}
+	if len(hops) > 0 {
+		// Every hop is a trusted proxy; the leftmost is the closest to the client
+		return hops[0]
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+// forwardedHops returns the addresses in every X-Forwarded-For header, in
+// order.
+func forwardedHops(r *http.Request) []string {
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
+
+// normalizeForwardedFor replaces the forwarding headers with client alone.
+func normalizeForwardedFor(r *http.Request, client string) {
+	r.Header.Del("True-Client-IP")
+	r.Header.Del("X-Forwarded-For")
+	r.Header.Set("X-Real-IP", client)
+}
+
+// remoteIP returns the IP of the request's peer, or nil if RemoteAddr
+// doesn't hold one.
+func remoteIP(r *http.Request) net.IP {
+	return net.ParseIP(hostOnly(r.RemoteAddr))
+}
+
+// hostOnly strips the port from an address, if it has one.
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// IPAllowlist refuses requests from clients outside allowed with 403. It
+// must run after RealIP. An empty allowlist lets every client through.
+// name identifies the routes in logs.
+func IPAllowlist(name string, allowed []*net.IPNet, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(allowed) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := remoteIP(r)
+			if ip == nil || !containsIP(allowed, ip) {
+				logger.Warn("request from outside the IP allowlist refused",
+					zap.String("routes", name),
+					zap.String("ip", r.RemoteAddr),
+					zap.String("path", r.URL.Path),
+				)
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireClientCert refuses requests that didn't present a client
+// certificate the server verified, with 403. It is for routes that need
+// mutual TLS on a server that only asks for client certificates. name
+// identifies the routes in logs.
+func RequireClientCert(name string, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				logger.Warn("request without a verified client certificate refused",
+					zap.String("routes", name),
+					zap.String("ip", r.RemoteAddr),
+					zap.String("path", r.URL.Path),
+				)
+				http.Error(w, "Client certificate required", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// realIPRecorder runs a request through RealIP and returns what the next
+// handler saw.
+func realIPRecorder(t *testing.T, spec string, req *http.Request) *http.Request {
+	t.Helper()
+	trusted, err := ParseTrustedProxies(spec)
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies(%q) error = %v", spec, err)
+	}
+	var seen *http.Request
+	RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+	})).ServeHTTP(httptest.NewRecorder(), req)
+	return seen
+}
+
+func TestParseIPNets(t *testing.T) {
+	nets, err := ParseIPNets(" 10.0.0.0/8, 192.0.2.10 ,, 2001:db8::1")
+	if err != nil {
+		t.Fatalf("ParseIPNets() error = %v", err)
+	}
+	if len(nets) != 3 {
+		t.Fatalf("expected 3 networks, got %d", len(nets))
+	}
+	if !nets[1].Contains([]byte{192, 0, 2, 10}) || nets[1].Contains([]byte{192, 0, 2, 11}) {
+		t.Errorf("a single address should match only itself, got %v", nets[1])
+	}
+
+	for _, bad := range []string{"10.0.0.0/33", "example.com"} {
+		if _, err := ParseIPNets(bad); err == nil {
+			t.Errorf("ParseIPNets(%q) expected an error", bad)
+		}
+	}
+}
+
+func TestRealIP_TrustsEveryPeerByDefault(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.1:4000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.2")
+
+	seen := realIPRecorder(t, "*", req)
+
+	if seen.RemoteAddr != "203.0.113.5" {
+		t.Errorf("RemoteAddr = %q, expected the leftmost forwarded address", seen.RemoteAddr)
+	}
+	if seen.Header.Get("X-Forwarded-For") == "" {
+		t.Error("X-Forwarded-For should be left alone when every peer is trusted")
+	}
+}
+
+func TestRealIP_IgnoresUntrustedPeers(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.1:4000"
+	req.Header.Set("X-Forwarded-For", "10.1.2.3")
+	req.Header.Set("X-Real-IP", "10.1.2.3")
+
+	seen := realIPRecorder(t, "10.0.0.0/8", req)
+
+	if seen.RemoteAddr != "198.51.100.1:4000" {
+		t.Errorf("RemoteAddr = %q, expected the peer address", seen.RemoteAddr)
+	}
+	if got := seen.Header.Get("X-Forwarded-For"); got != "" {
+		t.Errorf("X-Forwarded-For should be removed, got %q", got)
+	}
+	if got := seen.Header.Get("X-Real-IP"); got != "198.51.100.1" {
+		t.Errorf("X-Real-IP = %q, expected the peer address", got)
+	}
+}
+
+func TestRealIP_SkipsTrustedHops(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:4000"
+	// The client spoofed the first entry; the trusted proxies appended the rest
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 203.0.113.5, 10.0.0.7")
+
+	seen := realIPRecorder(t, "10.0.0.0/8", req)
+
+	if seen.RemoteAddr != "203.0.113.5" {
+		t.Errorf("RemoteAddr = %q, expected the first untrusted hop from the right", seen.RemoteAddr)
+	}
+	if got := seen.Header.Get("X-Real-IP"); got != "203.0.113.5" {
+		t.Errorf("X-Real-IP = %q", got)
+	}
+	if got := seen.Header.Get("X-Forwarded-For"); got != "" {
+		t.Errorf("X-Forwarded-For should be removed, got %q", got)
+	}
+}
+
+func TestRealIP_NoneTrustsNoOne(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:4000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.5")
+
+	seen := realIPRecorder(t, "none", req)
+
+	if seen.RemoteAddr != "10.0.0.1:4000" {
+		t.Errorf("RemoteAddr = %q, expected the peer address", seen.RemoteAddr)
+	}
+}
+
+func TestIPAllowlist(t *testing.T) {
+	nets, err := ParseIPNets("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := IPAllowlist("metrics", nets, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		remoteAddr string
+		expected   int
+	}{
+		{"10.2.3.4:5000", http.StatusOK},
+		{"10.2.3.4", http.StatusOK},
+		{"198.51.100.1:5000", http.StatusForbidden},
+		{"garbage", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.RemoteAddr = tt.remoteAddr
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestIPAllowlist_EmptyAllowsEveryone(t *testing.T) {
+	handler := IPAllowlist("webhooks", nil, zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/webhook/bland", nil)
+	req.RemoteAddr = "198.51.100.1:5000"
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestRequireClientCert(t *testing.T) {
+	handler := RequireClientCert("api", zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		tls      *tls.ConnectionState
+		expected int
+	}{
+		{"plaintext", nil, http.StatusForbidden},
+		{"no certificate", &tls.ConnectionState{}, http.StatusForbidden},
+		{"verified certificate", &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/calls", nil)
+			req.TLS = tt.tls
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -43,6 +43,15 @@ func NewRateLimiter(rate int, window time.Duration, logger *zap.Logger) *RateLim
 	return rl
 }
 
+// SetLimit changes the number of requests allowed per window. Visitors keep
+// their remaining requests until their current window ends.
+func (rl *RateLimiter) SetLimit(rate int, window time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.rate = rate
+	rl.window = window
+}
+
 // cleanup removes stale visitors periodically.
 func (rl *RateLimiter) cleanup() {
 	ticker := time.NewTicker(rl.window * 2)
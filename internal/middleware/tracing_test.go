@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+	"github.com/jkindrix/quickquote/internal/tracing"
+)
+
+// enableTracing installs a tracing provider for the duration of a test.
+func enableTracing(t *testing.T) {
+	t.Helper()
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	provider, err := tracing.NewProvider(&config.TracingConfig{Endpoint: collector.URL, SampleRatio: 1}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	tracing.SetProvider(provider)
+	t.Cleanup(func() {
+		tracing.SetProvider(nil)
+		_ = provider.Shutdown(context.Background())
+		collector.Close()
+	})
+}
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	enableTracing(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	var spanCtx trace.SpanContext
+	var loggedTraceID string
+
+	r := chi.NewRouter()
+	r.Use(Tracing())
+	r.Use(NewRequestCorrelation(zap.NewNop()).Middleware)
+	r.Get("/calls/{id}", func(w http.ResponseWriter, r *http.Request) {
+		spanCtx = trace.SpanContextFromContext(r.Context())
+		loggedTraceID = GetTraceID(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/calls/123", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if spanCtx.TraceID().String() != traceID {
+		t.Errorf("span trace ID = %s, want %s", spanCtx.TraceID(), traceID)
+	}
+	if spanCtx.IsRemote() {
+		t.Error("handler should see the server span, not the remote parent")
+	}
+	if loggedTraceID != traceID {
+		t.Errorf("correlation trace ID = %q, want %q", loggedTraceID, traceID)
+	}
+	if got := rec.Header().Get(TraceIDHeader); got != traceID {
+		t.Errorf("%s header = %q, want %q", TraceIDHeader, got, traceID)
+	}
+	if got := rec.Header().Get(SpanIDHeader); got != spanCtx.SpanID().String() {
+		t.Errorf("%s header = %q, want %q", SpanIDHeader, got, spanCtx.SpanID())
+	}
+}
+
+func TestTracing_DisabledPassesThrough(t *testing.T) {
+	called := false
+	handler := Tracing()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if trace.SpanFromContext(r.Context()).SpanContext().IsValid() {
+			t.Error("no span should be started without a provider")
+		}
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !called || rec.Code != http.StatusTeapot {
+		t.Errorf("handler called = %v, status = %d", called, rec.Code)
+	}
+}
+
+func TestPropagateHeaders_InjectsTraceparent(t *testing.T) {
+	enableTracing(t)
+
+	ctx, span := tracing.Start(context.Background(), "outgoing")
+	defer span.End()
+
+	req := httptest.NewRequest(http.MethodGet, "/downstream", nil)
+	PropagateHeaders(ctx, req)
+
+	want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	if got := req.Header.Get(tracing.TraceparentHeader); got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+}
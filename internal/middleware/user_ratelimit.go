@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -65,7 +66,11 @@ func UserRateLimit(limiter *ratelimit.UserRateLimiter, logger *zap.Logger, metri
 				w.Header().Set("X-RateLimit-Remaining-Day", strconv.Itoa(stats.DayRemaining))
 				w.Header().Set("Retry-After", "60")
 
-				http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+				if strings.HasPrefix(r.URL.Path, "/api/") {
+					apiError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				} else {
+					http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+				}
 				return
 			}
 
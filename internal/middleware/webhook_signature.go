@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// Webhook signature enforcement modes.
+const (
+	// WebhookSignatureReject turns away webhooks that fail verification.
+	WebhookSignatureReject = "reject"
+	// WebhookSignatureLogOnly logs and counts webhooks that fail
+	// verification but still handles them, for rolling out signing.
+	WebhookSignatureLogOnly = "log"
+)
+
+const (
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of
+	// "<timestamp>.<body>", or of the body alone from senders that don't
+	// send a timestamp, keyed with the webhook secret.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	// WebhookTimestampHeader carries the Unix time the webhook was signed.
+	// It is optional; without it the signature covers the body alone.
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+
+	// DefaultWebhookTolerance is how far a webhook's timestamp may be from
+	// now, to stop captured webhooks from being replayed.
+	DefaultWebhookTolerance = 5 * time.Minute
+)
+
+// Reasons a webhook fails signature verification.
+const (
+	signatureMissing   = "missing_signature"
+	signatureInvalid   = "invalid_signature"
+	timestampInvalid   = "invalid_timestamp"
+	timestampOutOfDate = "stale_timestamp"
+)
+
+// WebhookSignatureConfig configures WebhookSignature.
+type WebhookSignatureConfig struct {
+	Provider  string        // Names the provider in logs and metrics
+	Secret    string        // Webhook secret the HMAC is keyed with
+	Mode      string        // WebhookSignatureReject (default) or WebhookSignatureLogOnly
+	Tolerance time.Duration // Defaults to DefaultWebhookTolerance
+	Headers   []string      // Signature headers, checked in order; defaults to WebhookSignatureHeader
+	Logger    *zap.Logger
+	Metrics   *metrics.Metrics // Optional: counts webhooks that fail verification
+	Clock     clock.Clock      // Defaults to the real clock
+}
+
+// WebhookSignature verifies the HMAC signature of webhook deliveries, and
+// their timestamp when they carry one. Webhooks that fail are rejected with
+// 401, or only logged in log-only mode. The body is buffered and restored for
+// the next handler.
+func WebhookSignature(cfg WebhookSignatureConfig) func(http.Handler) http.Handler {
+	if cfg.Tolerance <= 0 {
+		cfg.Tolerance = DefaultWebhookTolerance
+	}
+	if len(cfg.Headers) == 0 {
+		cfg.Headers = []string{WebhookSignatureHeader}
+	}
+	if cfg.Mode != WebhookSignatureLogOnly {
+		cfg.Mode = WebhookSignatureReject
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "Invalid webhook payload", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			reason := cfg.verify(r.Header, body)
+			if reason == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cfg.Logger != nil {
+				cfg.Logger.Warn("webhook failed signature verification",
+					zap.String("provider", cfg.Provider),
+					zap.String("reason", reason),
+					zap.String("mode", cfg.Mode),
+					zap.String("remote_addr", r.RemoteAddr),
+				)
+			}
+			if cfg.Metrics != nil {
+				cfg.Metrics.RecordWebhookSignatureFailure(cfg.Provider, reason, cfg.Mode)
+			}
+			if cfg.Mode == WebhookSignatureLogOnly {
+				next.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, "Invalid webhook signature", http.StatusUnauthorized)
+		})
+	}
+}
+
+// verify returns why the webhook fails verification, or "" if it passes.
+func (cfg *WebhookSignatureConfig) verify(header http.Header, body []byte) string {
+	var signature string
+	for _, name := range cfg.Headers {
+		if signature = header.Get(name); signature != "" {
+			break
+		}
+	}
+	if signature == "" {
+		return signatureMissing
+	}
+
+	// Senders that predate signed timestamps sign the body alone
+	timestamp := header.Get(WebhookTimestampHeader)
+	if timestamp != "" {
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return timestampInvalid
+		}
+		if age := cfg.Clock.Now().Sub(time.Unix(unix, 0)); age > cfg.Tolerance || age < -cfg.Tolerance {
+			return timestampOutOfDate
+		}
+	}
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, SignWebhook(cfg.Secret, timestamp, body)) {
+		return signatureInvalid
+	}
+	return ""
+}
+
+// SignWebhook returns the HMAC-SHA256 of "<timestamp>.<body>", or of the
+// body alone when timestamp is empty, keyed with secret, as carried
+// hex-encoded in WebhookSignatureHeader.
+func SignWebhook(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	if timestamp != "" {
+		mac.Write([]byte(timestamp))
+		mac.Write([]byte("."))
+	}
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+const testWebhookBody = `{"call_id":"call-1","status":"completed"}`
+
+var testWebhookNow = time.Unix(1700000000, 0)
+
+func newSignedWebhook(secret string, signedAt time.Time) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhook/bland", strings.NewReader(testWebhookBody))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(SignWebhook(secret, timestamp, []byte(testWebhookBody))))
+	return req
+}
+
+func newWebhookSignatureTest(mode string) (http.Handler, *metrics.Metrics, *bool) {
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	handled := false
+	handler := WebhookSignature(WebhookSignatureConfig{
+		Provider: "bland",
+		Secret:   "whsec",
+		Mode:     mode,
+		Metrics:  m,
+		Clock:    clock.NewMock(testWebhookNow),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != testWebhookBody {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	return handler, m, &handled
+}
+
+func TestWebhookSignature_Valid(t *testing.T) {
+	handler, _, handled := newWebhookSignatureTest(WebhookSignatureReject)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedWebhook("whsec", testWebhookNow.Add(-time.Minute)))
+
+	if rec.Code != http.StatusOK || !*handled {
+		t.Errorf("expected a signed webhook to be handled with its body intact, got %d", rec.Code)
+	}
+}
+
+func TestWebhookSignature_BodyOnly(t *testing.T) {
+	handler, _, handled := newWebhookSignatureTest(WebhookSignatureReject)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/bland", strings.NewReader(testWebhookBody))
+	req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(SignWebhook("whsec", "", []byte(testWebhookBody))))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !*handled {
+		t.Errorf("expected a webhook signed without a timestamp to be handled, got %d", rec.Code)
+	}
+}
+
+func TestWebhookSignature_Rejects(t *testing.T) {
+	unsigned := httptest.NewRequest(http.MethodPost, "/webhook/bland", strings.NewReader(testWebhookBody))
+	timestampDropped := newSignedWebhook("whsec", testWebhookNow)
+	timestampDropped.Header.Del(WebhookTimestampHeader)
+	badTimestamp := newSignedWebhook("whsec", testWebhookNow)
+	badTimestamp.Header.Set(WebhookTimestampHeader, "yesterday")
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		reason string
+	}{
+		{"unsigned", unsigned, signatureMissing},
+		{"timestamp dropped", timestampDropped, signatureInvalid},
+		{"unparseable timestamp", badTimestamp, timestampInvalid},
+		{"replayed", newSignedWebhook("whsec", testWebhookNow.Add(-10*time.Minute)), timestampOutOfDate},
+		{"wrong secret", newSignedWebhook("other", testWebhookNow), signatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, m, handled := newWebhookSignatureTest(WebhookSignatureReject)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req)
+
+			if rec.Code != http.StatusUnauthorized || *handled {
+				t.Errorf("expected 401 without handling, got %d", rec.Code)
+			}
+			if n := testutil.ToFloat64(m.WebhookAuthFailures.WithLabelValues("bland", tt.reason, WebhookSignatureReject)); n != 1 {
+				t.Errorf("expected one %s failure counted, got %f", tt.reason, n)
+			}
+		})
+	}
+}
+
+func TestWebhookSignature_LogOnly(t *testing.T) {
+	handler, m, handled := newWebhookSignatureTest(WebhookSignatureLogOnly)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newSignedWebhook("other", testWebhookNow))
+
+	if rec.Code != http.StatusOK || !*handled {
+		t.Errorf("expected the webhook handled in log-only mode, got %d", rec.Code)
+	}
+	if n := testutil.ToFloat64(m.WebhookAuthFailures.WithLabelValues("bland", signatureInvalid, WebhookSignatureLogOnly)); n != 1 {
+		t.Errorf("expected the failure counted, got %f", n)
+	}
+}
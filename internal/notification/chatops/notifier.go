@@ -0,0 +1,432 @@
+// Package chatops posts call, quote, usage and job failure notices to Slack
+// and Microsoft Teams channels through incoming webhooks.
+package chatops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// ErrNotConfigured is returned when an event has no webhook URL.
+var ErrNotConfigured = errors.New("no webhook URL is configured for this event")
+
+// RecipientResolver returns the personal webhook URLs of the users who
+// chose to be sent an event.
+type RecipientResolver interface {
+	Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string
+}
+
+// Config holds notifier settings.
+type Config struct {
+	// URLs are the incoming webhook URLs by event. Events without one are
+	// not posted. Teams URLs are recognized by host; any other URL is sent
+	// Slack's format, which Mattermost and Discord also accept.
+	URLs map[Event]string
+	// QuoteReadyMinTotal only posts quotes with at least this total, in
+	// the quote's own currency.
+	QuoteReadyMinTotal float64
+	// Templates override the default message templates by event.
+	Templates map[Event]string
+	// BusinessName is available to templates.
+	BusinessName string
+	// PublicURL is used to link to call and quote pages.
+	PublicURL string
+	// SendTimeout bounds each delivery attempt.
+	SendTimeout time.Duration
+	// AlertInterval suppresses repeats of the same usage alert.
+	AlertInterval time.Duration
+	// Currency is used for quotes generated before currencies were recorded.
+	Currency string
+}
+
+// Enabled reports whether any event has a webhook URL.
+func (c Config) Enabled() bool {
+	for _, u := range c.URLs {
+		if u != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier posts notices to chat channels. Messages are delivered in the
+// background so callers are never blocked.
+type Notifier struct {
+	config     Config
+	templates  map[Event]*template.Template
+	client     *http.Client
+	recipients RecipientResolver
+	logger     *zap.Logger
+
+	wg sync.WaitGroup
+
+	mu         sync.Mutex
+	lastAlerts map[string]time.Time
+	clock      clock.Clock
+}
+
+// NewNotifier creates a new chat notifier. It fails when a webhook URL or
+// message template is invalid.
+func NewNotifier(cfg Config, logger *zap.Logger, clk clock.Clock) (*Notifier, error) {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 10 * time.Second
+	}
+	if cfg.AlertInterval <= 0 {
+		cfg.AlertInterval = time.Hour
+	}
+	if cfg.BusinessName == "" {
+		cfg.BusinessName = "QuickQuote"
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	for event, raw := range cfg.URLs {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("unknown chatops event %q", event)
+		}
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("%s webhook URL must be an https URL", event)
+		}
+	}
+	templates, err := parseTemplates(cfg.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		config:     cfg,
+		templates:  templates,
+		client:     &http.Client{Timeout: cfg.SendTimeout},
+		logger:     logger,
+		lastAlerts: make(map[string]time.Time),
+		clock:      clk,
+	}, nil
+}
+
+// SetRecipients also posts each event to the personal webhooks of the users
+// who chose to be sent it.
+func (n *Notifier) SetRecipients(recipients RecipientResolver) {
+	n.recipients = recipients
+}
+
+// CallCompleted posts an answered call that ended.
+func (n *Notifier) CallCompleted(ctx context.Context, call *domain.Call) {
+	if call == nil {
+		return
+	}
+
+	data := CallCompletedData{
+		BusinessName: n.config.BusinessName,
+		CallerNumber: call.CustomerNumber(),
+		CallURL:      n.link("/calls/", call.ID),
+	}
+	if call.DurationSeconds != nil {
+		data.Duration = call.Duration().String()
+	}
+	data.CallerName, data.ProjectType = callerDetails(call)
+
+	n.post(ctx, EventCallCompleted, data)
+}
+
+// QuoteReady posts a generated quote whose total is at least the
+// configured minimum.
+func (n *Notifier) QuoteReady(ctx context.Context, call *domain.Call) {
+	if call == nil || call.QuoteSummary == nil {
+		return
+	}
+	total := service.QuoteTotal(*call.QuoteSummary)
+	if total < n.config.QuoteReadyMinTotal {
+		return
+	}
+
+	data := QuoteReadyData{
+		BusinessName:  n.config.BusinessName,
+		CustomerPhone: call.CustomerNumber(),
+		QuoteNumber:   quotepdf.QuoteNumber(call.ID),
+		Total:         total,
+		Currency:      call.Currency(),
+		QuoteURL:      n.link("/quotes/", call.ID),
+	}
+	if data.Currency == "" {
+		data.Currency = n.config.Currency
+	}
+	data.TotalText = quotepdf.FormatMoney(total, data.Currency)
+	data.CustomerName, data.ProjectType = callerDetails(call)
+
+	n.post(ctx, EventQuoteReady, data)
+}
+
+// CallFailed does nothing; staff hear about failed calls by email.
+func (n *Notifier) CallFailed(ctx context.Context, call *domain.Call) {}
+
+// UsageAlert posts a reached usage limit. Repeats of the same alert are
+// suppressed for the configured alert interval.
+func (n *Notifier) UsageAlert(ctx context.Context, alert domain.UsageAlert) {
+	if n.config.URLs[EventUsageAlert] == "" && n.recipients == nil {
+		return
+	}
+	now := n.clock.Now()
+	n.mu.Lock()
+	if last, ok := n.lastAlerts[alert.Key()]; ok && now.Sub(last) < n.config.AlertInterval {
+		n.mu.Unlock()
+		return
+	}
+	n.lastAlerts[alert.Key()] = now
+	n.mu.Unlock()
+
+	data := UsageAlertData{
+		BusinessName: n.config.BusinessName,
+		Resource:     alert.Resource,
+		Reason:       alert.Reason,
+		Used:         alert.Used,
+		Limit:        alert.Limit,
+		Detail:       alert.Detail,
+	}
+	if alert.ResetIn > 0 {
+		data.ResetIn = alert.ResetIn.Round(time.Minute).String()
+	}
+
+	n.post(ctx, EventUsageAlert, data)
+}
+
+// QuoteJobFailed posts a quote job that ran out of retries.
+func (n *Notifier) QuoteJobFailed(ctx context.Context, job *domain.QuoteJob) {
+	if job == nil {
+		return
+	}
+
+	data := JobFailedData{
+		BusinessName: n.config.BusinessName,
+		JobID:        job.ID.String(),
+		CallID:       job.CallID.String(),
+		Attempts:     job.Attempts,
+		CallURL:      n.link("/calls/", job.CallID),
+	}
+	if job.LastError != nil {
+		data.Error = *job.LastError
+	}
+
+	n.post(ctx, EventJobFailed, data)
+}
+
+// Test posts a sample message for an event and waits for the result.
+func (n *Notifier) Test(ctx context.Context, event Event) error {
+	if !event.IsValid() {
+		return fmt.Errorf("unknown chatops event %q", event)
+	}
+	endpoint := n.config.URLs[event]
+	if endpoint == "" {
+		return ErrNotConfigured
+	}
+	text, err := n.render(event, sampleData[event])
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, n.config.SendTimeout)
+	defer cancel()
+	return n.send(ctx, endpoint, "[Test] "+text)
+}
+
+// Configured lists the events that have a webhook URL.
+func (n *Notifier) Configured() []Event {
+	var events []Event
+	for _, event := range Events {
+		if n.config.URLs[event] != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Close waits for in-flight messages to be delivered or ctx to expire.
+func (n *Notifier) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// post renders an event's message and sends it to the event's channel and
+// the personal webhooks of users who chose it, in the background and
+// detached from the caller's cancellation so request-scoped contexts do not
+// abort delivery.
+func (n *Notifier) post(ctx context.Context, event Event, data interface{}) {
+	channel := n.config.URLs[event]
+	if channel == "" && n.recipients == nil {
+		return
+	}
+	text, err := n.render(event, data)
+	if err != nil {
+		n.logger.Error("failed to render chat notification", zap.String("event", string(event)), zap.Error(err))
+		return
+	}
+
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.config.SendTimeout)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer cancel()
+
+		var endpoints []string
+		if channel != "" {
+			endpoints = append(endpoints, channel)
+		}
+		if n.recipients != nil {
+			for _, endpoint := range n.recipients.Recipients(sendCtx, domain.NotificationChannelSlack, domain.NotificationEvent(event)) {
+				if endpoint != channel {
+					endpoints = append(endpoints, endpoint)
+				}
+			}
+		}
+
+		for _, endpoint := range endpoints {
+			if err := n.send(sendCtx, endpoint, text); err != nil {
+				n.logger.Error("failed to send chat notification",
+					zap.String("event", string(event)),
+					zap.Bool("personal", endpoint != channel),
+					zap.Error(err),
+				)
+				continue
+			}
+			n.logger.Debug("chat notification sent", zap.String("event", string(event)), zap.Bool("personal", endpoint != channel))
+		}
+	}()
+}
+
+func (n *Notifier) render(event Event, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := n.templates[event].Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s message: %w", event, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// send posts a message in the format the webhook's service expects.
+func (n *Notifier) send(ctx context.Context, endpoint, text string) error {
+	body, err := json.Marshal(payload(endpoint, text))
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// Don't log the URL; incoming webhook URLs are secrets.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// payload builds the message body: an Adaptive Card for Teams, or Slack's
+// text message for everything else.
+func payload(endpoint, text string) interface{} {
+	if isTeams(endpoint) {
+		return map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{{
+						"type": "TextBlock",
+						"text": text,
+						"wrap": true,
+					}},
+				},
+			}},
+		}
+	}
+	return map[string]string{"text": slackEscape(text)}
+}
+
+// isTeams reports whether a webhook URL belongs to Microsoft Teams, either
+// an Office 365 connector or a Power Automate workflow.
+func isTeams(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, suffix := range []string{".webhook.office.com", ".logic.azure.com", ".powerplatform.com"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// slackEscape escapes the characters Slack treats as markup.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// callerDetails returns the caller's name and project type.
+func callerDetails(call *domain.Call) (name, projectType string) {
+	if call.CallerName != nil {
+		name = strings.TrimSpace(*call.CallerName)
+	}
+	if data := call.ExtractedData; data != nil {
+		if name == "" {
+			name = strings.TrimSpace(data.CallerName)
+		}
+		projectType = data.ProjectType
+	}
+	return name, projectType
+}
+
+// link returns the admin URL for a call's page, or empty when no public URL
+// is set.
+func (n *Notifier) link(prefix string, id uuid.UUID) string {
+	if n.config.PublicURL == "" {
+		return ""
+	}
+	return n.config.PublicURL + prefix + id.String()
+}
@@ -0,0 +1,276 @@
+package chatops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// fakeWebhook records the messages posted to it.
+type fakeWebhook struct {
+	server *httptest.Server
+	status int
+
+	mu     sync.Mutex
+	bodies []map[string]interface{}
+}
+
+func newFakeWebhook(t *testing.T) *fakeWebhook {
+	t.Helper()
+	f := &fakeWebhook{status: http.StatusOK}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid message body: %v", err)
+		}
+		f.mu.Lock()
+		f.bodies = append(f.bodies, body)
+		status := f.status
+		f.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeWebhook) texts() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var texts []string
+	for _, b := range f.bodies {
+		text, _ := b["text"].(string)
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+func newTestNotifier(t *testing.T, webhook *fakeWebhook, cfg Config) *Notifier {
+	t.Helper()
+	if cfg.URLs == nil {
+		cfg.URLs = make(map[Event]string)
+		for _, event := range Events {
+			cfg.URLs[event] = webhook.server.URL
+		}
+	}
+	cfg.BusinessName = "Acme"
+	cfg.PublicURL = "https://qq.example.com/"
+	n, err := NewNotifier(cfg, zap.NewNop(), nil)
+	if err != nil {
+		t.Fatalf("NewNotifier() error = %v", err)
+	}
+	n.client = webhook.server.Client()
+	return n
+}
+
+func closeNotifier(t *testing.T, n *Notifier) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := n.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func quotedCall(summary string) *domain.Call {
+	name := "Jane Doe"
+	call := domain.NewCall("provider-123", "bland", "+15550100", "+15550199")
+	call.CallerName = &name
+	call.QuoteSummary = &summary
+	call.ExtractedData = &domain.ExtractedData{ProjectType: "Roofing"}
+	return call
+}
+
+func TestNotifier_QuoteReadyMinTotal(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{QuoteReadyMinTotal: 1000})
+
+	n.QuoteReady(context.Background(), quotedCall("- Patch: $400"))
+	large := quotedCall("- Tear-off: $3,000\n- Shingles: $1,250")
+	n.QuoteReady(context.Background(), large)
+	closeNotifier(t, n)
+
+	texts := webhook.texts()
+	if len(texts) != 1 {
+		t.Fatalf("expected only the quote over the minimum posted, got %d messages", len(texts))
+	}
+	for _, want := range []string{"Jane Doe", "$4,250.00", "(Roofing)", "https://qq.example.com/quotes/" + large.ID.String()} {
+		if !strings.Contains(texts[0], want) {
+			t.Errorf("expected %q in message %q", want, texts[0])
+		}
+	}
+}
+
+func TestNotifier_QuoteReadyCurrency(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{Currency: "GBP"})
+
+	legacy := quotedCall("- Tear-off: $3,000")
+	n.QuoteReady(context.Background(), legacy)
+	euro := quotedCall("- Dach: 3.000 €")
+	euro.ExtractedData.Currency = "EUR"
+	n.QuoteReady(context.Background(), euro)
+	closeNotifier(t, n)
+
+	texts := strings.Join(webhook.texts(), "\n")
+	for _, want := range []string{"£3,000.00", "€3,000.00"} {
+		if !strings.Contains(texts, want) {
+			t.Errorf("expected %q in messages %q", want, texts)
+		}
+	}
+}
+
+func TestNotifier_UsageAlertSuppressesRepeats(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{})
+	clk := clock.NewMock(time.Now())
+	n.clock = clk
+
+	alert := domain.UsageAlert{Resource: "quote_generation", Reason: "day limit", Used: 500, Limit: 500}
+	n.UsageAlert(context.Background(), alert)
+	n.UsageAlert(context.Background(), alert)
+	clk.Advance(2 * time.Hour)
+	n.UsageAlert(context.Background(), alert)
+	closeNotifier(t, n)
+
+	if got := len(webhook.texts()); got != 2 {
+		t.Errorf("expected the repeat within the interval suppressed, got %d messages", got)
+	}
+}
+
+func TestNotifier_QuoteJobFailed(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{
+		Templates: map[Event]string{EventJobFailed: "<!channel> job {{.JobID}} failed: {{.Error}}"},
+	})
+
+	lastError := "anthropic: overloaded"
+	job := &domain.QuoteJob{ID: uuid.New(), CallID: uuid.New(), Attempts: 3, LastError: &lastError}
+	n.QuoteJobFailed(context.Background(), job)
+	closeNotifier(t, n)
+
+	texts := webhook.texts()
+	want := "&lt;!channel&gt; job " + job.ID.String() + " failed: anthropic: overloaded"
+	if len(texts) != 1 || texts[0] != want {
+		t.Errorf("expected templated, escaped message %q, got %v", want, texts)
+	}
+}
+
+func TestNotifier_SkipsEventsWithoutURL(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{URLs: map[Event]string{EventQuoteReady: webhook.server.URL}})
+
+	n.CallCompleted(context.Background(), quotedCall("- Patch: $400"))
+	closeNotifier(t, n)
+
+	if got := len(webhook.texts()); got != 0 {
+		t.Errorf("expected no messages, got %d", got)
+	}
+	if err := n.Test(context.Background(), EventCallCompleted); err != ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+// fakeResolver returns the same personal webhooks for every event.
+type fakeResolver []string
+
+func (f fakeResolver) Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string {
+	if channel != domain.NotificationChannelSlack || event != domain.NotificationEventJobFailed {
+		return nil
+	}
+	return f
+}
+
+func TestNotifier_PersonalWebhooks(t *testing.T) {
+	channel := newFakeWebhook(t)
+	personal := newFakeWebhook(t)
+	n := newTestNotifier(t, channel, Config{URLs: map[Event]string{EventJobFailed: channel.server.URL}})
+	// The test servers share a certificate, so one client reaches both.
+	n.SetRecipients(fakeResolver{personal.server.URL, channel.server.URL})
+
+	n.QuoteJobFailed(context.Background(), &domain.QuoteJob{ID: uuid.New(), CallID: uuid.New()})
+	n.UsageAlert(context.Background(), domain.UsageAlert{Resource: "quote_generation", Reason: "day limit"})
+	closeNotifier(t, n)
+
+	if got := len(channel.texts()); got != 1 {
+		t.Errorf("expected one message in the channel, got %d", got)
+	}
+	if got := len(personal.texts()); got != 1 {
+		t.Errorf("expected one message to the personal webhook, got %d", got)
+	}
+}
+
+func TestNotifier_Test(t *testing.T) {
+	webhook := newFakeWebhook(t)
+	n := newTestNotifier(t, webhook, Config{})
+
+	if err := n.Test(context.Background(), EventQuoteReady); err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if texts := webhook.texts(); len(texts) != 1 || !strings.HasPrefix(texts[0], "[Test] New quote Q-TEST") {
+		t.Errorf("unexpected test message %v", texts)
+	}
+
+	webhook.mu.Lock()
+	webhook.status = http.StatusNotFound
+	webhook.mu.Unlock()
+	if err := n.Test(context.Background(), EventQuoteReady); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected the webhook's status in the error, got %v", err)
+	}
+}
+
+func TestNewNotifier_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"http URL", Config{URLs: map[Event]string{EventQuoteReady: "http://hooks.slack.com/services/x"}}},
+		{"unknown event", Config{URLs: map[Event]string{"call_started": "https://hooks.slack.com/services/x"}}},
+		{"unparseable template", Config{Templates: map[Event]string{EventQuoteReady: "{{.Total"}}},
+		{"unknown field", Config{Templates: map[Event]string{EventQuoteReady: "{{.Amount}}"}}},
+		{"unknown template event", Config{Templates: map[Event]string{"call_started": "hi"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNotifier(tt.cfg, zap.NewNop(), nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPayload(t *testing.T) {
+	teams, _ := json.Marshal(payload("https://acme.webhook.office.com/webhookb2/x", "Quote <ready> & sent"))
+	var card struct {
+		Attachments []struct {
+			Content struct {
+				Type string `json:"type"`
+				Body []struct {
+					Text string `json:"text"`
+				} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(teams, &card); err != nil || len(card.Attachments) != 1 {
+		t.Fatalf("expected one attachment for Teams, got %s", teams)
+	}
+	content := card.Attachments[0].Content
+	if content.Type != "AdaptiveCard" || content.Body[0].Text != "Quote <ready> & sent" {
+		t.Errorf("expected an unescaped adaptive card for Teams, got %s", teams)
+	}
+
+	slack, ok := payload("https://hooks.slack.com/services/x", "Quote <ready> & sent").(map[string]string)
+	if !ok || slack["text"] != "Quote &lt;ready&gt; &amp; sent" {
+		t.Errorf("expected an escaped Slack message, got %v", slack)
+	}
+}
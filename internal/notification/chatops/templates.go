@@ -0,0 +1,130 @@
+package chatops
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// Event names a kind of message that can be posted to a channel.
+type Event string
+
+const (
+	EventCallCompleted Event = "call_completed" // An answered call ended
+	EventQuoteReady    Event = "quote_ready"    // A quote was generated, at or above the minimum total
+	EventUsageAlert    Event = "usage_alert"    // A usage limit was reached
+	EventJobFailed     Event = "job_failed"     // A quote job ran out of retries
+)
+
+// Events lists the events in the order they are documented.
+var Events = []Event{EventCallCompleted, EventQuoteReady, EventUsageAlert, EventJobFailed}
+
+// IsValid reports whether e is a known event.
+func (e Event) IsValid() bool {
+	_, ok := defaultTemplates[e]
+	return ok
+}
+
+// CallCompletedData is the data for call completed messages.
+type CallCompletedData struct {
+	BusinessName string
+	CallerName   string
+	CallerNumber string
+	Duration     string
+	ProjectType  string
+	CallURL      string
+}
+
+// QuoteReadyData is the data for quote ready messages.
+type QuoteReadyData struct {
+	BusinessName  string
+	CustomerName  string
+	CustomerPhone string
+	QuoteNumber   string
+	Total         float64
+	Currency      string // ISO 4217 code of Total
+	TotalText     string // Total formatted in its currency, e.g. "€4,250.00"
+	ProjectType   string
+	QuoteURL      string
+}
+
+// UsageAlertData is the data for usage alert messages.
+type UsageAlertData struct {
+	BusinessName string
+	Resource     string
+	Reason       string
+	Used         int
+	Limit        int
+	ResetIn      string
+	Detail       string
+}
+
+// JobFailedData is the data for quote job failure messages.
+type JobFailedData struct {
+	BusinessName string
+	JobID        string
+	CallID       string
+	Attempts     int
+	Error        string
+	CallURL      string
+}
+
+// defaultTemplates are the message templates used unless overridden. They
+// are plain text so they read the same in Slack and Teams.
+var defaultTemplates = map[Event]string{
+	EventCallCompleted: `Call completed: {{if .CallerName}}{{.CallerName}} ({{.CallerNumber}}){{else}}{{.CallerNumber}}{{end}}` +
+		`{{if .Duration}}, {{.Duration}}{{end}}{{if .ProjectType}} - {{.ProjectType}}{{end}}` +
+		`{{if .CallURL}}
+{{.CallURL}}{{end}}`,
+	EventQuoteReady: `New quote {{.QuoteNumber}} for {{if .CustomerName}}{{.CustomerName}}{{else}}{{.CustomerPhone}}{{end}}: {{.TotalText}}` +
+		`{{if .ProjectType}} ({{.ProjectType}}){{end}}` +
+		`{{if .QuoteURL}}
+{{.QuoteURL}}{{end}}`,
+	EventUsageAlert: `Usage alert: {{.Resource}} reached its {{.Reason}} ({{.Used}}/{{.Limit}})` +
+		`{{if .ResetIn}}, resets in {{.ResetIn}}{{end}}.{{if .Detail}} {{.Detail}}{{end}}`,
+	EventJobFailed: `Quote generation failed after {{.Attempts}} attempts for call {{.CallID}}: {{.Error}}` +
+		`{{if .CallURL}}
+{{.CallURL}}{{end}}`,
+}
+
+// sampleData is the data rendered for test notifications.
+var sampleData = map[Event]interface{}{
+	EventCallCompleted: CallCompletedData{
+		CallerName: "Jane Doe", CallerNumber: "+15550100", Duration: "4m12s", ProjectType: "Roofing",
+	},
+	EventQuoteReady: QuoteReadyData{
+		CustomerName: "Jane Doe", CustomerPhone: "+15550100", QuoteNumber: "Q-TEST", Total: 4250, Currency: "USD", TotalText: "$4,250.00", ProjectType: "Roofing",
+	},
+	EventUsageAlert: UsageAlertData{
+		Resource: "quote_generation", Reason: "day limit", Used: 500, Limit: 500, ResetIn: "3h0m0s",
+	},
+	EventJobFailed: JobFailedData{
+		JobID: "00000000-0000-0000-0000-000000000000", CallID: "00000000-0000-0000-0000-000000000000", Attempts: 3, Error: "test error",
+	},
+}
+
+// parseTemplates parses the default templates with overrides applied.
+func parseTemplates(overrides map[Event]string) (map[Event]*template.Template, error) {
+	templates := make(map[Event]*template.Template, len(defaultTemplates))
+	for event, text := range defaultTemplates {
+		if override, ok := overrides[event]; ok && override != "" {
+			text = override
+		}
+		tmpl, err := template.New(string(event)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", event, err)
+		}
+		// Catch references to fields the event does not have at startup
+		// rather than on the first real message.
+		if err := tmpl.Execute(&bytes.Buffer{}, sampleData[event]); err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", event, err)
+		}
+		templates[event] = tmpl
+	}
+	for event := range overrides {
+		if !event.IsValid() {
+			return nil, fmt.Errorf("unknown chatops event %q", event)
+		}
+	}
+	return templates, nil
+}
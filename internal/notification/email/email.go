@@ -0,0 +1,178 @@
+// Package email sends transactional email through SMTP or SendGrid.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone     = ""
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+)
+
+// ErrNoRecipients is returned when a message has no recipients.
+var ErrNoRecipients = errors.New("email has no recipients")
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is an outgoing email.
+type Message struct {
+	To          []string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Attachments []Attachment
+}
+
+// Validate checks that the message can be sent.
+func (m *Message) Validate() error {
+	if len(m.To) == 0 {
+		return ErrNoRecipients
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+	}
+	if strings.TrimSpace(m.Subject) == "" {
+		return errors.New("email subject is required")
+	}
+	if m.TextBody == "" && m.HTMLBody == "" {
+		return errors.New("email body is required")
+	}
+	return nil
+}
+
+// Sender delivers email messages.
+type Sender interface {
+	// Send delivers a message.
+	Send(ctx context.Context, msg *Message) error
+
+	// Name returns the backend name for logging.
+	Name() string
+}
+
+// Config holds email backend settings.
+type Config struct {
+	Provider string // "smtp", "sendgrid", or empty to disable
+	From     string
+	FromName string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	SendGridAPIKey string
+	SendGridAPIURL string
+}
+
+// Enabled reports whether an email backend is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != ProviderNone
+}
+
+// fromHeader formats the From address with the optional display name.
+func (c Config) fromHeader() string {
+	if c.FromName == "" {
+		return c.From
+	}
+	return (&mail.Address{Name: c.FromName, Address: c.From}).String()
+}
+
+// NewSender creates the sender for the configured provider.
+func NewSender(cfg Config, logger *zap.Logger) (Sender, error) {
+	if cfg.Enabled() && cfg.From == "" {
+		return nil, errors.New("email from address is required")
+	}
+
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderNone:
+		return NewNoopSender(logger), nil
+	case ProviderSMTP:
+		if cfg.SMTPHost == "" {
+			return nil, errors.New("smtp host is required")
+		}
+		return NewSMTPSender(cfg), nil
+	case ProviderSendGrid:
+		if cfg.SendGridAPIKey == "" {
+			return nil, errors.New("sendgrid api key is required")
+		}
+		return NewSendGridSender(cfg, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", cfg.Provider)
+	}
+}
+
+// NoopSender discards messages. It is used when email is not configured.
+type NoopSender struct {
+	logger *zap.Logger
+}
+
+// NewNoopSender creates a sender that only logs.
+func NewNoopSender(logger *zap.Logger) *NoopSender {
+	return &NoopSender{logger: logger}
+}
+
+// Send logs and discards the message.
+func (s *NoopSender) Send(ctx context.Context, msg *Message) error {
+	s.logger.Debug("email disabled, dropping message",
+		zap.Strings("to", msg.To),
+		zap.String("subject", msg.Subject),
+	)
+	return nil
+}
+
+// Name returns the backend name.
+func (s *NoopSender) Name() string {
+	return "noop"
+}
+
+// BreakerSender sends through another sender behind a circuit breaker, so
+// mail fails fast while the backend is down instead of every notification
+// waiting on it.
+type BreakerSender struct {
+	next    Sender
+	breaker *circuitbreaker.CircuitBreaker
+}
+
+// NewBreakerSender wraps next with breaker.
+func NewBreakerSender(next Sender, breaker *circuitbreaker.CircuitBreaker) *BreakerSender {
+	return &BreakerSender{next: next, breaker: breaker}
+}
+
+// Send delivers a message unless the circuit is open. Invalid messages are
+// refused without counting against the backend.
+func (s *BreakerSender) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	return s.breaker.Execute(ctx, func(ctx context.Context) error {
+		return s.next.Send(ctx, msg)
+	})
+}
+
+// Name returns the wrapped backend's name.
+func (s *BreakerSender) Name() string {
+	return s.next.Name()
+}
+
+// CircuitBreaker returns the breaker guarding the backend.
+func (s *BreakerSender) CircuitBreaker() *circuitbreaker.CircuitBreaker {
+	return s.breaker
+}
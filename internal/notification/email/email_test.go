@@ -0,0 +1,232 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+)
+
+func testMessage() *Message {
+	return &Message{
+		To:       []string{"customer@example.com"},
+		Subject:  "Your quote",
+		TextBody: "Hello",
+		HTMLBody: "<p>Hello</p>",
+		Attachments: []Attachment{
+			{Filename: "quote.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")},
+		},
+	}
+}
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(m *Message)
+		wantErr bool
+	}{
+		{"valid", func(m *Message) {}, false},
+		{"no recipients", func(m *Message) { m.To = nil }, true},
+		{"bad recipient", func(m *Message) { m.To = []string{"not-an-address"} }, true},
+		{"no subject", func(m *Message) { m.Subject = " " }, true},
+		{"no body", func(m *Message) { m.TextBody, m.HTMLBody = "", "" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMessage()
+			tt.mutate(m)
+			if err := m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	logger := zap.NewNop()
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantName string
+		wantErr  bool
+	}{
+		{"disabled", Config{}, "noop", false},
+		{"smtp", Config{Provider: "smtp", From: "a@example.com", SMTPHost: "mail.example.com"}, ProviderSMTP, false},
+		{"smtp missing host", Config{Provider: "smtp", From: "a@example.com"}, "", true},
+		{"sendgrid", Config{Provider: "SendGrid", From: "a@example.com", SendGridAPIKey: "key"}, ProviderSendGrid, false},
+		{"sendgrid missing key", Config{Provider: "sendgrid", From: "a@example.com"}, "", true},
+		{"missing from", Config{Provider: "smtp", SMTPHost: "mail.example.com"}, "", true},
+		{"unknown", Config{Provider: "pigeon", From: "a@example.com"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSender(tt.cfg, logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && s.Name() != tt.wantName {
+				t.Errorf("Name() = %q, want %q", s.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	s := NewSMTPSender(Config{
+		From:         "quotes@example.com",
+		FromName:     "Acme",
+		SMTPHost:     "mail.example.com",
+		SMTPUsername: "user",
+		SMTPPassword: "pass",
+	})
+
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotBody []byte
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotBody = addr, from, to, msg
+		return nil
+	}
+
+	if err := s.Send(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if gotAddr != "mail.example.com:587" {
+		t.Errorf("addr = %q, want default port 587", gotAddr)
+	}
+	if gotFrom != "quotes@example.com" {
+		t.Errorf("envelope from = %q", gotFrom)
+	}
+	if len(gotTo) != 1 || gotTo[0] != "customer@example.com" {
+		t.Errorf("to = %v", gotTo)
+	}
+
+	body := string(gotBody)
+	for _, want := range []string{
+		`From: "Acme" <quotes@example.com>`,
+		"Subject: Your quote",
+		"multipart/mixed",
+		"multipart/alternative",
+		"text/plain; charset=utf-8",
+		"text/html; charset=utf-8",
+		`Content-Disposition: attachment; filename="quote.pdf"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("MIME body missing %q", want)
+		}
+	}
+}
+
+func TestBreakerSender_FailsFastWhenOpen(t *testing.T) {
+	s := NewSMTPSender(Config{From: "quotes@example.com", SMTPHost: "mail.example.com"})
+	var sends int
+	s.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		sends++
+		return errors.New("connection refused")
+	}
+	breaker := circuitbreaker.New("email", &circuitbreaker.Config{
+		FailureThreshold:    2,
+		SuccessThreshold:    1,
+		OpenTimeout:         time.Hour,
+		HalfOpenMaxRequests: 1,
+	}, zap.NewNop())
+	sender := NewBreakerSender(s, breaker)
+
+	// Invalid messages don't count against the relay
+	if err := sender.Send(context.Background(), &Message{Subject: "No one"}); !errors.Is(err, ErrNoRecipients) {
+		t.Fatalf("expected ErrNoRecipients, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := sender.Send(context.Background(), testMessage()); err == nil {
+			t.Fatal("expected the relay's error")
+		}
+	}
+	if err := sender.Send(context.Background(), testMessage()); !errors.Is(err, circuitbreaker.ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if sends != 2 {
+		t.Errorf("expected 2 sends to reach the relay, got %d", sends)
+	}
+	if sender.Name() != ProviderSMTP {
+		t.Errorf("Name() = %q", sender.Name())
+	}
+}
+
+func TestBuildMIME_WrapsBase64(t *testing.T) {
+	msg := testMessage()
+	msg.Attachments[0].Data = make([]byte, 1000)
+
+	out, err := buildMIME("a@example.com", msg, time.Now())
+	if err != nil {
+		t.Fatalf("buildMIME() error = %v", err)
+	}
+	for _, line := range strings.Split(string(out), "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("line exceeds RFC 5322 limit: %d chars", len(line))
+		}
+	}
+}
+
+func TestSendGridSender_Send(t *testing.T) {
+	var got sendGridRequest
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s := NewSendGridSender(Config{
+		From:           "quotes@example.com",
+		FromName:       "Acme",
+		SendGridAPIKey: "sg-key",
+		SendGridAPIURL: srv.URL,
+	}, srv.Client())
+
+	if err := s.Send(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if auth != "Bearer sg-key" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if got.From.Email != "quotes@example.com" || got.From.Name != "Acme" {
+		t.Errorf("from = %+v", got.From)
+	}
+	if len(got.Personalizations) != 1 || got.Personalizations[0].To[0].Email != "customer@example.com" {
+		t.Errorf("personalizations = %+v", got.Personalizations)
+	}
+	if len(got.Content) != 2 || got.Content[0].Type != "text/plain" {
+		t.Errorf("content = %+v, want text/plain first", got.Content)
+	}
+	if len(got.Attachments) != 1 || got.Attachments[0].Filename != "quote.pdf" {
+		t.Errorf("attachments = %+v", got.Attachments)
+	}
+}
+
+func TestSendGridSender_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":[{"message":"bad key"}]}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	s := NewSendGridSender(Config{From: "a@example.com", SendGridAPIKey: "x", SendGridAPIURL: srv.URL}, srv.Client())
+	err := s.Send(context.Background(), testMessage())
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("Send() error = %v, want status 401", err)
+	}
+}
@@ -0,0 +1,366 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// QuoteRenderer renders quote PDFs for attachment.
+type QuoteRenderer interface {
+	Render(ctx context.Context, callID uuid.UUID) (*quotepdf.Attachment, error)
+	AttachToFollowUps() bool
+}
+
+// RecipientResolver returns the addresses of the users who chose to be
+// emailed about an event.
+type RecipientResolver interface {
+	Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string
+}
+
+// NotifierConfig holds notifier settings.
+type NotifierConfig struct {
+	// BusinessName is shown in message bodies.
+	BusinessName string
+	// StaffRecipients receive call failure, usage and new quote notices.
+	StaffRecipients []string
+	// PublicURL is used to link to call detail pages.
+	PublicURL string
+	// SendTimeout bounds each delivery attempt.
+	SendTimeout time.Duration
+	// AlertInterval suppresses repeats of the same usage alert.
+	AlertInterval time.Duration
+}
+
+// Notifier sends email for call and quote lifecycle events. Messages
+// are delivered in the background so callers are never blocked.
+type Notifier struct {
+	sender     Sender
+	quotes     QuoteRenderer
+	recipients RecipientResolver
+	config     NotifierConfig
+	logger     *zap.Logger
+
+	wg sync.WaitGroup
+
+	mu         sync.Mutex
+	lastAlerts map[string]time.Time
+	clock      clock.Clock
+}
+
+// NewNotifier creates a new email notifier. quotes may be nil.
+func NewNotifier(sender Sender, quotes QuoteRenderer, cfg NotifierConfig, logger *zap.Logger, clk clock.Clock) *Notifier {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 30 * time.Second
+	}
+	if cfg.AlertInterval <= 0 {
+		cfg.AlertInterval = time.Hour
+	}
+	if cfg.BusinessName == "" {
+		cfg.BusinessName = "QuickQuote"
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	return &Notifier{
+		sender:     sender,
+		quotes:     quotes,
+		config:     cfg,
+		logger:     logger,
+		lastAlerts: make(map[string]time.Time),
+		clock:      clk,
+	}
+}
+
+// SetRecipients adds the users who chose to be emailed about an event to the
+// staff recipients of its notices.
+func (n *Notifier) SetRecipients(recipients RecipientResolver) {
+	n.recipients = recipients
+}
+
+// QuoteReady emails the quote to the customer, if an address was captured,
+// and a notice to staff.
+func (n *Notifier) QuoteReady(ctx context.Context, call *domain.Call) {
+	if call == nil || call.QuoteSummary == nil {
+		return
+	}
+
+	data := QuoteReadyData{
+		BusinessName:  n.config.BusinessName,
+		CustomerPhone: call.FromNumber,
+		QuoteNumber:   quotepdf.QuoteNumber(call.ID),
+		Summary:       *call.QuoteSummary,
+		CallURL:       n.callURL(call.ID),
+	}
+	if call.CallerName != nil {
+		data.CustomerName = *call.CallerName
+	}
+	if call.ExtractedData != nil {
+		if data.CustomerName == "" {
+			data.CustomerName = call.ExtractedData.CallerName
+		}
+		data.CustomerEmail = strings.TrimSpace(call.ExtractedData.Email)
+	}
+
+	callID := call.ID
+	n.dispatch(ctx, TemplateQuoteReady+"_customer", func(ctx context.Context) error {
+		if data.CustomerEmail == "" {
+			return nil
+		}
+		customer := data
+		var attachments []Attachment
+		if n.quotes != nil && n.quotes.AttachToFollowUps() {
+			pdf, err := n.quotes.Render(ctx, callID)
+			if err != nil {
+				n.logger.Warn("failed to render quote PDF for email, sending without attachment",
+					zap.String("call_id", callID.String()),
+					zap.Error(err),
+				)
+			} else {
+				customer.HasAttachment = true
+				attachments = append(attachments, Attachment{
+					Filename:    pdf.Filename,
+					ContentType: pdf.ContentType,
+					Data:        pdf.Data,
+				})
+			}
+		}
+		// The customer copy never links to the admin UI.
+		customer.CallURL = ""
+
+		msg, err := Render(TemplateQuoteReady, customer)
+		if err != nil {
+			return err
+		}
+		msg.To = []string{customer.CustomerEmail}
+		msg.Attachments = attachments
+		return n.sender.Send(ctx, msg)
+	})
+
+	n.sendStaff(ctx, TemplateQuoteReadyStaff, domain.NotificationEventQuoteReady, data)
+}
+
+// QuoteResponded emails staff when a customer accepts or declines their quote.
+func (n *Notifier) QuoteResponded(ctx context.Context, call *domain.Call, quote *domain.Quote, comment string) {
+	if call == nil || quote == nil {
+		return
+	}
+
+	data := QuoteRespondedData{
+		BusinessName:  n.config.BusinessName,
+		CustomerPhone: call.FromNumber,
+		QuoteNumber:   quotepdf.QuoteNumber(call.ID),
+		Decision:      string(quote.Status),
+		Total:         quote.TotalAmount,
+		TotalText:     quotepdf.FormatMoney(quote.TotalAmount, quote.Currency),
+		Comment:       comment,
+	}
+	if quote.Signature != nil {
+		data.SignedBy = quote.Signature.SignerName
+	}
+	if n.config.PublicURL != "" {
+		data.QuoteURL = n.config.PublicURL + "/quotes/" + call.ID.String()
+	}
+	if call.CallerName != nil {
+		data.CustomerName = *call.CallerName
+	}
+	if data.CustomerName == "" && call.ExtractedData != nil {
+		data.CustomerName = call.ExtractedData.CallerName
+	}
+
+	n.sendStaff(ctx, TemplateQuoteResponded, domain.NotificationEventQuoteResponded, data)
+}
+
+// CallFailed emails staff about a call that did not complete.
+func (n *Notifier) CallFailed(ctx context.Context, call *domain.Call) {
+	if call == nil {
+		return
+	}
+
+	data := CallFailedData{
+		BusinessName: n.config.BusinessName,
+		CallerNumber: call.FromNumber,
+		Provider:     call.Provider,
+		CallURL:      n.callURL(call.ID),
+	}
+	if call.CallerName != nil {
+		data.CallerName = *call.CallerName
+	}
+	if call.ErrorMessage != nil {
+		data.Reason = *call.ErrorMessage
+	}
+
+	n.sendStaff(ctx, TemplateCallFailed, domain.NotificationEventCallFailed, data)
+}
+
+// UsageAlert emails staff when a usage limit is reached. Repeats of the
+// same alert are suppressed for the configured alert interval.
+func (n *Notifier) UsageAlert(ctx context.Context, alert domain.UsageAlert) {
+	now := n.clock.Now()
+	n.mu.Lock()
+	if last, ok := n.lastAlerts[alert.Key()]; ok && now.Sub(last) < n.config.AlertInterval {
+		n.mu.Unlock()
+		return
+	}
+	n.lastAlerts[alert.Key()] = now
+	n.mu.Unlock()
+
+	data := UsageAlertData{
+		BusinessName: n.config.BusinessName,
+		Resource:     alert.Resource,
+		Reason:       alert.Reason,
+		Used:         alert.Used,
+		Limit:        alert.Limit,
+		Detail:       alert.Detail,
+	}
+	if alert.ResetIn > 0 {
+		data.ResetIn = alert.ResetIn.Round(time.Minute).String()
+	}
+
+	n.sendStaff(ctx, TemplateUsageAlert, domain.NotificationEventUsageAlert, data)
+}
+
+// PasswordReset emails a user a link for choosing a new password.
+func (n *Notifier) PasswordReset(ctx context.Context, user *domain.User, resetURL string) {
+	n.sendAccountLink(ctx, TemplatePasswordReset, user, resetURL, domain.PasswordResetTokenTTL)
+}
+
+// EmailVerification emails a user a link that confirms their address.
+func (n *Notifier) EmailVerification(ctx context.Context, user *domain.User, verifyURL string) {
+	n.sendAccountLink(ctx, TemplateVerifyEmail, user, verifyURL, domain.EmailVerificationTokenTTL)
+}
+
+// Invitation emails a new user a link for accepting their invitation.
+func (n *Notifier) Invitation(ctx context.Context, user *domain.User, inviteURL string) {
+	n.sendAccountLink(ctx, TemplateInvitation, user, inviteURL, domain.InvitationTokenTTL)
+}
+
+// sendAccountLink sends an account email containing a link to the user.
+func (n *Notifier) sendAccountLink(ctx context.Context, template string, user *domain.User, link string, ttl time.Duration) {
+	data := AccountLinkData{
+		BusinessName: n.config.BusinessName,
+		Email:        user.Email,
+		URL:          link,
+		ExpiresIn:    formatTTL(ttl),
+	}
+	n.dispatch(ctx, template, func(ctx context.Context) error {
+		msg, err := Render(template, data)
+		if err != nil {
+			return err
+		}
+		msg.To = []string{user.Email}
+		return n.sender.Send(ctx, msg)
+	})
+}
+
+// formatTTL describes a link lifetime in whole hours or days.
+func formatTTL(ttl time.Duration) string {
+	hours := int(ttl.Hours())
+	switch {
+	case hours == 1:
+		return "1 hour"
+	case hours%24 == 0 && hours > 24:
+		return fmt.Sprintf("%d days", hours/24)
+	default:
+		return fmt.Sprintf("%d hours", hours)
+	}
+}
+
+// Close waits for in-flight messages to be delivered or ctx to expire.
+func (n *Notifier) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendStaff renders a template and sends it to the staff recipients and the
+// users who chose to be emailed about the event.
+func (n *Notifier) sendStaff(ctx context.Context, template string, event domain.NotificationEvent, data interface{}) {
+	if len(n.config.StaffRecipients) == 0 && n.recipients == nil {
+		return
+	}
+	n.dispatch(ctx, template, func(ctx context.Context) error {
+		to := n.config.StaffRecipients
+		if n.recipients != nil {
+			to = mergeRecipients(to, n.recipients.Recipients(ctx, domain.NotificationChannelEmail, event))
+		}
+		if len(to) == 0 {
+			return nil
+		}
+		msg, err := Render(template, data)
+		if err != nil {
+			return err
+		}
+		msg.To = to
+		return n.sender.Send(ctx, msg)
+	})
+}
+
+// mergeRecipients appends the addresses in extra not already in to,
+// ignoring case.
+func mergeRecipients(to, extra []string) []string {
+	seen := make(map[string]bool, len(to)+len(extra))
+	merged := make([]string, 0, len(to)+len(extra))
+	for _, address := range append(append([]string(nil), to...), extra...) {
+		key := strings.ToLower(address)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, address)
+	}
+	return merged
+}
+
+// dispatch runs send in the background, detached from the caller's
+// cancellation so request-scoped contexts do not abort delivery.
+func (n *Notifier) dispatch(ctx context.Context, name string, send func(ctx context.Context) error) {
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.config.SendTimeout)
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer cancel()
+
+		if err := send(sendCtx); err != nil {
+			n.logger.Error("failed to send email notification",
+				zap.String("template", name),
+				zap.String("backend", n.sender.Name()),
+				zap.Error(err),
+			)
+			return
+		}
+		n.logger.Debug("email notification sent",
+			zap.String("template", name),
+			zap.String("backend", n.sender.Name()),
+		)
+	}()
+}
+
+// callURL returns the admin URL for a call, or empty when no public URL is set.
+func (n *Notifier) callURL(id uuid.UUID) string {
+	if n.config.PublicURL == "" {
+		return ""
+	}
+	return n.config.PublicURL + "/calls/" + id.String()
+}
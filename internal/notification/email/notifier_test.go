@@ -0,0 +1,348 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []*Message
+}
+
+func (f *fakeSender) Send(ctx context.Context, msg *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeSender) Name() string { return "fake" }
+
+func (f *fakeSender) messages() []*Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*Message(nil), f.sent...)
+}
+
+type fakeRenderer struct {
+	attach bool
+	err    error
+}
+
+func (f *fakeRenderer) Render(ctx context.Context, callID uuid.UUID) (*quotepdf.Attachment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &quotepdf.Attachment{Filename: "quote.pdf", ContentType: quotepdf.ContentType, Data: []byte("%PDF")}, nil
+}
+
+func (f *fakeRenderer) AttachToFollowUps() bool { return f.attach }
+
+func newTestNotifier(sender Sender, quotes QuoteRenderer) *Notifier {
+	return NewNotifier(sender, quotes, NotifierConfig{
+		BusinessName:    "Acme",
+		StaffRecipients: []string{"staff@example.com"},
+		PublicURL:       "https://qq.example.com/",
+	}, zap.NewNop(), nil)
+}
+
+func closeNotifier(t *testing.T, n *Notifier) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := n.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func findMessage(msgs []*Message, to string) *Message {
+	for _, m := range msgs {
+		if m.To[0] == to {
+			return m
+		}
+	}
+	return nil
+}
+
+func quotedCall() *domain.Call {
+	summary := "Website redesign: $4,500"
+	name := "Jane Doe"
+	return &domain.Call{
+		ID:           uuid.New(),
+		FromNumber:   "+15551234567",
+		CallerName:   &name,
+		QuoteSummary: &summary,
+		ExtractedData: &domain.ExtractedData{
+			Email: "jane@example.com",
+		},
+	}
+}
+
+func TestNotifier_QuoteReady(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, &fakeRenderer{attach: true})
+	call := quotedCall()
+
+	n.QuoteReady(context.Background(), call)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(msgs))
+	}
+
+	customer := findMessage(msgs, "jane@example.com")
+	if customer == nil {
+		t.Fatal("no customer message sent")
+	}
+	if len(customer.Attachments) != 1 {
+		t.Errorf("customer attachments = %d, want 1", len(customer.Attachments))
+	}
+	if strings.Contains(customer.TextBody, "qq.example.com") {
+		t.Error("customer message should not link to the admin UI")
+	}
+	if !strings.Contains(customer.Subject, quotepdf.QuoteNumber(call.ID)) {
+		t.Errorf("customer subject = %q", customer.Subject)
+	}
+
+	staff := findMessage(msgs, "staff@example.com")
+	if staff == nil {
+		t.Fatal("no staff message sent")
+	}
+	if !strings.Contains(staff.TextBody, "https://qq.example.com/calls/"+call.ID.String()) {
+		t.Errorf("staff message missing call link:\n%s", staff.TextBody)
+	}
+}
+
+func TestNotifier_QuoteReady_RenderFailureStillSends(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, &fakeRenderer{attach: true, err: errors.New("boom")})
+
+	n.QuoteReady(context.Background(), quotedCall())
+	closeNotifier(t, n)
+
+	customer := findMessage(sender.messages(), "jane@example.com")
+	if customer == nil {
+		t.Fatal("no customer message sent")
+	}
+	if len(customer.Attachments) != 0 {
+		t.Errorf("attachments = %d, want 0", len(customer.Attachments))
+	}
+}
+
+func TestNotifier_QuoteReady_NoCustomerEmail(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	call := quotedCall()
+	call.ExtractedData = nil
+
+	n.QuoteReady(context.Background(), call)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 || msgs[0].To[0] != "staff@example.com" {
+		t.Fatalf("messages = %d, want only the staff notice", len(msgs))
+	}
+}
+
+func TestNotifier_CallFailed(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	reason := "caller hung up"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.CallFailed(ctx, &domain.Call{ID: uuid.New(), FromNumber: "+15550000000", Provider: "bland", ErrorMessage: &reason})
+	// Cancelling the request context must not abort delivery.
+	cancel()
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	if !strings.Contains(msgs[0].TextBody, reason) {
+		t.Errorf("body missing reason:\n%s", msgs[0].TextBody)
+	}
+}
+
+func TestNotifier_QuoteResponded(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	call := quotedCall()
+
+	quote := &domain.Quote{CallID: call.ID, Status: domain.QuoteStatusAccepted, TotalAmount: 4500, Currency: "EUR", Signature: &domain.QuoteSignature{SignerName: "J. Doe"}}
+	n.QuoteResponded(context.Background(), call, quote, "Can you start Monday?")
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 || msgs[0].To[0] != "staff@example.com" {
+		t.Fatalf("expected one staff message, got %d", len(msgs))
+	}
+	for _, want := range []string{"accepted", "Jane Doe", "Total:    €4,500.00", "Signed:   J. Doe", "Can you start Monday?", "https://qq.example.com/quotes/" + call.ID.String()} {
+		if !strings.Contains(msgs[0].TextBody, want) {
+			t.Errorf("body missing %q:\n%s", want, msgs[0].TextBody)
+		}
+	}
+}
+
+func TestNotifier_UsageAlertThrottled(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	clk := clock.NewMock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	n.clock = clk
+
+	alert := domain.UsageAlert{Resource: "quote_generation", Reason: "day limit", Used: 100, Limit: 100, ResetIn: 3 * time.Hour}
+	n.UsageAlert(context.Background(), alert)
+	n.UsageAlert(context.Background(), alert)
+	clk.Advance(2 * time.Hour)
+	n.UsageAlert(context.Background(), alert)
+	closeNotifier(t, n)
+
+	if got := len(sender.messages()); got != 2 {
+		t.Fatalf("sent %d alerts, want 2", got)
+	}
+}
+
+func TestNotifier_NoStaffRecipients(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewNotifier(sender, nil, NotifierConfig{}, zap.NewNop(), nil)
+
+	n.CallFailed(context.Background(), &domain.Call{ID: uuid.New()})
+	closeNotifier(t, n)
+
+	if got := len(sender.messages()); got != 0 {
+		t.Fatalf("sent %d messages, want 0", got)
+	}
+}
+
+// fakeResolver returns fixed recipients for each event.
+type fakeResolver map[domain.NotificationEvent][]string
+
+func (f fakeResolver) Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string {
+	if channel != domain.NotificationChannelEmail {
+		return nil
+	}
+	return f[event]
+}
+
+func TestNotifier_PreferenceRecipients(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	n.SetRecipients(fakeResolver{
+		domain.NotificationEventCallFailed: {"STAFF@example.com", "owner@example.com"},
+	})
+
+	n.CallFailed(context.Background(), &domain.Call{ID: uuid.New()})
+	n.UsageAlert(context.Background(), domain.UsageAlert{Resource: "quote_generation", Reason: "day limit"})
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(msgs))
+	}
+	for _, m := range msgs {
+		want := "staff@example.com"
+		if strings.HasSuffix(m.Subject, "failed") {
+			want += ",owner@example.com"
+		}
+		if got := strings.Join(m.To, ","); got != want {
+			t.Errorf("%q sent to %s, want %s", m.Subject, got, want)
+		}
+	}
+
+	// Opted-in users are emailed even without staff recipients.
+	sender = &fakeSender{}
+	n = NewNotifier(sender, nil, NotifierConfig{}, zap.NewNop(), nil)
+	n.SetRecipients(fakeResolver{domain.NotificationEventCallFailed: {"owner@example.com"}})
+	n.CallFailed(context.Background(), &domain.Call{ID: uuid.New()})
+	closeNotifier(t, n)
+	if msgs := sender.messages(); len(msgs) != 1 || msgs[0].To[0] != "owner@example.com" {
+		t.Errorf("expected one message to the opted-in user, got %d", len(msgs))
+	}
+}
+
+func TestNotifier_PasswordReset(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	link := "https://qq.example.com/auth/reset-password?token=abc"
+
+	n.PasswordReset(context.Background(), &domain.User{Email: "user@example.com"}, link)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	if msgs[0].To[0] != "user@example.com" {
+		t.Errorf("sent to %v, want the user only", msgs[0].To)
+	}
+	if !strings.Contains(msgs[0].TextBody, link) || !strings.Contains(msgs[0].TextBody, "1 hour") {
+		t.Errorf("body missing link or expiry:\n%s", msgs[0].TextBody)
+	}
+}
+
+func TestNotifier_Invitation(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, nil)
+	link := "https://qq.example.com/auth/accept-invite?token=abc"
+
+	n.Invitation(context.Background(), &domain.User{Email: "new@example.com"}, link)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(msgs))
+	}
+	if !strings.Contains(msgs[0].TextBody, link) || !strings.Contains(msgs[0].TextBody, "7 days") {
+		t.Errorf("body missing link or expiry:\n%s", msgs[0].TextBody)
+	}
+}
+
+func TestFormatTTL(t *testing.T) {
+	tests := map[time.Duration]string{
+		time.Hour:      "1 hour",
+		2 * time.Hour:  "2 hours",
+		24 * time.Hour: "24 hours",
+		72 * time.Hour: "3 days",
+	}
+	for ttl, want := range tests {
+		if got := formatTTL(ttl); got != want {
+			t.Errorf("formatTTL(%v) = %q, want %q", ttl, got, want)
+		}
+	}
+}
+
+func TestRender_UnknownTemplate(t *testing.T) {
+	if _, err := Render("missing", nil); err == nil {
+		t.Fatal("expected error for unknown template")
+	}
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	msg, err := Render(TemplateQuoteReady, QuoteReadyData{
+		BusinessName: "Acme",
+		QuoteNumber:  "Q-1",
+		Summary:      "<script>alert(1)</script>",
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(msg.HTMLBody, "<script>") {
+		t.Error("HTML body should escape summary")
+	}
+	if !strings.Contains(msg.TextBody, "<script>") {
+		t.Error("text body should contain raw summary")
+	}
+}
@@ -0,0 +1,132 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultSendGridAPIURL is the SendGrid v3 mail send endpoint.
+const defaultSendGridAPIURL = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridSender delivers email through the SendGrid v3 API.
+type SendGridSender struct {
+	apiKey   string
+	apiURL   string
+	from     string
+	fromName string
+	client   *http.Client
+}
+
+// NewSendGridSender creates a new SendGrid sender. A default HTTP client
+// is used when client is nil.
+func NewSendGridSender(cfg Config, client *http.Client) *SendGridSender {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	apiURL := cfg.SendGridAPIURL
+	if apiURL == "" {
+		apiURL = defaultSendGridAPIURL
+	}
+	return &SendGridSender{
+		apiKey:   cfg.SendGridAPIKey,
+		apiURL:   apiURL,
+		from:     cfg.From,
+		fromName: cfg.FromName,
+		client:   client,
+	}
+}
+
+// Name returns the backend name.
+func (s *SendGridSender) Name() string {
+	return ProviderSendGrid
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From        sendGridAddress      `json:"from"`
+	Subject     string               `json:"subject"`
+	Content     []sendGridContent    `json:"content"`
+	Attachments []sendGridAttachment `json:"attachments,omitempty"`
+}
+
+// Send delivers a message.
+func (s *SendGridSender) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	req := sendGridRequest{
+		From:    sendGridAddress{Email: s.from, Name: s.fromName},
+		Subject: msg.Subject,
+	}
+	req.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	for _, to := range msg.To {
+		req.Personalizations[0].To = append(req.Personalizations[0].To, sendGridAddress{Email: to})
+	}
+	// SendGrid requires text/plain before text/html.
+	if msg.TextBody != "" {
+		req.Content = append(req.Content, sendGridContent{Type: "text/plain", Value: msg.TextBody})
+	}
+	if msg.HTMLBody != "" {
+		req.Content = append(req.Content, sendGridContent{Type: "text/html", Value: msg.HTMLBody})
+	}
+	for _, att := range msg.Attachments {
+		req.Attachments = append(req.Attachments, sendGridAttachment{
+			Content:     base64.StdEncoding.EncodeToString(att.Data),
+			Type:        att.ContentType,
+			Filename:    att.Filename,
+			Disposition: "attachment",
+		})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sendgrid request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create sendgrid request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("sendgrid request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("sendgrid returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSender delivers email through an SMTP relay.
+type SMTPSender struct {
+	addr     string
+	host     string
+	auth     smtp.Auth
+	from     string
+	fromAddr string
+
+	// sendMail is swapped out in tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPSender creates a new SMTP sender. Authentication is used when a
+// username is configured; net/smtp upgrades to STARTTLS when the server offers it.
+func NewSMTPSender(cfg Config) *SMTPSender {
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = 587
+	}
+
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &SMTPSender{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(port)),
+		host:     cfg.SMTPHost,
+		auth:     auth,
+		from:     cfg.fromHeader(),
+		fromAddr: cfg.From,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name returns the backend name.
+func (s *SMTPSender) Name() string {
+	return ProviderSMTP
+}
+
+// Send delivers a message. net/smtp does not support contexts, so the
+// context is only checked before sending.
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := buildMIME(s.from, msg, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	if err := s.sendMail(s.addr, s.auth, s.fromAddr, msg.To, body); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+	return nil
+}
+
+// buildMIME renders a message as a MIME document with text and HTML
+// alternatives and optional attachments.
+func buildMIME(from string, msg *Message, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeHeader := func(k, v string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, v)
+	}
+	writeHeader("From", from)
+	writeHeader("To", strings.Join(msg.To, ", "))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+
+	mixed, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+	alt, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	writeHeader("Content-Type", `multipart/mixed; boundary="`+mixed+`"`)
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "--%s\r\n", mixed)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", alt)
+	if msg.TextBody != "" {
+		writePart(&buf, alt, "text/plain; charset=utf-8", []byte(msg.TextBody))
+	}
+	if msg.HTMLBody != "" {
+		writePart(&buf, alt, "text/html; charset=utf-8", []byte(msg.HTMLBody))
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", alt)
+
+	for _, att := range msg.Attachments {
+		fmt.Fprintf(&buf, "--%s\r\n", mixed)
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n", att.ContentType)
+		fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n", att.Filename)
+		buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+		writeBase64Lines(&buf, att.Data)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", mixed)
+
+	return buf.Bytes(), nil
+}
+
+// writePart writes a base64-encoded body part.
+func writePart(buf *bytes.Buffer, boundary, contentType string, data []byte) {
+	fmt.Fprintf(buf, "--%s\r\n", boundary)
+	fmt.Fprintf(buf, "Content-Type: %s\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	writeBase64Lines(buf, data)
+}
+
+// writeBase64Lines writes data as base64 wrapped at 76 characters per RFC 2045.
+func writeBase64Lines(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+	buf.WriteString("\r\n")
+}
+
+// newBoundary generates a random MIME boundary.
+func newBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "qq-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,269 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// Template names.
+const (
+	TemplateQuoteReady      = "quote_ready"
+	TemplateQuoteReadyStaff = "quote_ready_staff"
+	TemplateQuoteResponded  = "quote_responded"
+	TemplateCallFailed      = "call_failed"
+	TemplateUsageAlert      = "usage_alert"
+	TemplatePasswordReset   = "password_reset"
+	TemplateVerifyEmail     = "verify_email"
+	TemplateInvitation      = "invitation"
+)
+
+// QuoteReadyData is the data for quote ready messages.
+type QuoteReadyData struct {
+	BusinessName  string
+	CustomerName  string
+	CustomerPhone string
+	CustomerEmail string
+	QuoteNumber   string
+	Summary       string
+	CallURL       string
+	HasAttachment bool
+}
+
+// QuoteRespondedData is the data for messages about a customer's decision on a quote.
+type QuoteRespondedData struct {
+	BusinessName  string
+	CustomerName  string
+	CustomerPhone string
+	QuoteNumber   string
+	Decision      string // "accepted" or "declined"
+	Total         float64
+	TotalText     string // Total formatted in the quote's currency, e.g. "€4,500.00"
+	Comment       string
+	SignedBy      string // Name the customer signed with, when accepted
+	QuoteURL      string
+}
+
+// CallFailedData is the data for call failed messages.
+type CallFailedData struct {
+	BusinessName string
+	CallerNumber string
+	CallerName   string
+	Provider     string
+	Reason       string
+	CallURL      string
+}
+
+// UsageAlertData is the data for usage alert messages.
+type UsageAlertData struct {
+	BusinessName string
+	Resource     string
+	Reason       string
+	Used         int
+	Limit        int
+	ResetIn      string
+	Detail       string
+}
+
+// AccountLinkData is the data for password reset and email verification
+// messages.
+type AccountLinkData struct {
+	BusinessName string
+	Email        string
+	URL          string
+	ExpiresIn    string
+}
+
+type messageTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+var templates = map[string]messageTemplate{
+	TemplateQuoteReady: mustTemplate(
+		`Your quote {{.QuoteNumber}} from {{.BusinessName}}`,
+		`Hi{{if .CustomerName}} {{.CustomerName}}{{end}},
+
+Thanks for calling {{.BusinessName}}. Your quote {{.QuoteNumber}} is ready.
+{{if .HasAttachment}}
+The full quote is attached as a PDF.
+{{end}}
+{{.Summary}}
+
+— {{.BusinessName}}
+`,
+		`<p>Hi{{if .CustomerName}} {{.CustomerName}}{{end}},</p>
+<p>Thanks for calling {{.BusinessName}}. Your quote <strong>{{.QuoteNumber}}</strong> is ready.</p>
+{{if .HasAttachment}}<p>The full quote is attached as a PDF.</p>{{end}}
+<pre style="font-family:inherit;white-space:pre-wrap">{{.Summary}}</pre>
+<p>— {{.BusinessName}}</p>
+`),
+
+	TemplateQuoteReadyStaff: mustTemplate(
+		`Quote {{.QuoteNumber}} generated for {{if .CustomerName}}{{.CustomerName}}{{else}}{{.CustomerPhone}}{{end}}`,
+		`A new quote was generated.
+
+Quote:    {{.QuoteNumber}}
+Customer: {{if .CustomerName}}{{.CustomerName}}{{else}}unknown{{end}}
+Phone:    {{.CustomerPhone}}
+Email:    {{if .CustomerEmail}}{{.CustomerEmail}}{{else}}not provided{{end}}
+{{if .CallURL}}
+View call: {{.CallURL}}
+{{end}}
+{{.Summary}}
+`,
+		`<p>A new quote was generated.</p>
+<table>
+<tr><td>Quote</td><td>{{.QuoteNumber}}</td></tr>
+<tr><td>Customer</td><td>{{if .CustomerName}}{{.CustomerName}}{{else}}unknown{{end}}</td></tr>
+<tr><td>Phone</td><td>{{.CustomerPhone}}</td></tr>
+<tr><td>Email</td><td>{{if .CustomerEmail}}{{.CustomerEmail}}{{else}}not provided{{end}}</td></tr>
+</table>
+{{if .CallURL}}<p><a href="{{.CallURL}}">View call</a></p>{{end}}
+<pre style="font-family:inherit;white-space:pre-wrap">{{.Summary}}</pre>
+`),
+
+	TemplateQuoteResponded: mustTemplate(
+		`Quote {{.QuoteNumber}} {{.Decision}} by {{if .CustomerName}}{{.CustomerName}}{{else}}{{.CustomerPhone}}{{end}}`,
+		`A customer {{.Decision}} their quote.
+
+Quote:    {{.QuoteNumber}}
+Customer: {{if .CustomerName}}{{.CustomerName}}{{else}}unknown{{end}}
+Phone:    {{.CustomerPhone}}
+Total:    {{.TotalText}}
+{{if .SignedBy}}Signed:   {{.SignedBy}}
+{{end}}{{if .Comment}}
+Comment:
+{{.Comment}}
+{{end}}{{if .QuoteURL}}
+View quote: {{.QuoteURL}}
+{{end}}`,
+		`<p>A customer {{.Decision}} their quote.</p>
+<table>
+<tr><td>Quote</td><td>{{.QuoteNumber}}</td></tr>
+<tr><td>Customer</td><td>{{if .CustomerName}}{{.CustomerName}}{{else}}unknown{{end}}</td></tr>
+<tr><td>Phone</td><td>{{.CustomerPhone}}</td></tr>
+<tr><td>Total</td><td>{{.TotalText}}</td></tr>
+{{if .SignedBy}}<tr><td>Signed</td><td>{{.SignedBy}}</td></tr>{{end}}
+</table>
+{{if .Comment}}<p>Comment:</p>
+<pre style="font-family:inherit;white-space:pre-wrap">{{.Comment}}</pre>{{end}}
+{{if .QuoteURL}}<p><a href="{{.QuoteURL}}">View quote</a></p>{{end}}
+`),
+
+	TemplateCallFailed: mustTemplate(
+		`Call from {{.CallerNumber}} failed`,
+		`A call did not complete.
+
+Caller:   {{if .CallerName}}{{.CallerName}} {{end}}{{.CallerNumber}}
+Provider: {{.Provider}}
+Reason:   {{if .Reason}}{{.Reason}}{{else}}unknown{{end}}
+{{if .CallURL}}
+View call: {{.CallURL}}
+{{end}}`,
+		`<p>A call did not complete.</p>
+<table>
+<tr><td>Caller</td><td>{{if .CallerName}}{{.CallerName}} {{end}}{{.CallerNumber}}</td></tr>
+<tr><td>Provider</td><td>{{.Provider}}</td></tr>
+<tr><td>Reason</td><td>{{if .Reason}}{{.Reason}}{{else}}unknown{{end}}</td></tr>
+</table>
+{{if .CallURL}}<p><a href="{{.CallURL}}">View call</a></p>{{end}}
+`),
+
+	TemplateUsageAlert: mustTemplate(
+		`Usage alert: {{.Resource}} {{.Reason}} reached`,
+		`{{.BusinessName}} has reached a usage limit.
+
+Resource: {{.Resource}}
+Limit:    {{.Reason}} ({{.Used}} of {{.Limit}})
+{{if .ResetIn}}Resets in: {{.ResetIn}}
+{{end}}
+{{if .Detail}}{{.Detail}}{{else}}Requests over the limit are being deferred until it resets.{{end}}
+`,
+		`<p>{{.BusinessName}} has reached a usage limit.</p>
+<table>
+<tr><td>Resource</td><td>{{.Resource}}</td></tr>
+<tr><td>Limit</td><td>{{.Reason}} ({{.Used}} of {{.Limit}})</td></tr>
+{{if .ResetIn}}<tr><td>Resets in</td><td>{{.ResetIn}}</td></tr>{{end}}
+</table>
+<p>{{if .Detail}}{{.Detail}}{{else}}Requests over the limit are being deferred until it resets.{{end}}</p>
+`),
+
+	TemplatePasswordReset: mustTemplate(
+		`Reset your {{.BusinessName}} password`,
+		`Someone asked to reset the password for {{.Email}}.
+
+Choose a new password here:
+{{.URL}}
+
+The link works once and expires in {{.ExpiresIn}}. If you didn't ask for this, ignore this email; your password hasn't changed.
+`,
+		`<p>Someone asked to reset the password for {{.Email}}.</p>
+<p><a href="{{.URL}}">Choose a new password</a></p>
+<p>The link works once and expires in {{.ExpiresIn}}. If you didn't ask for this, ignore this email; your password hasn't changed.</p>
+`),
+
+	TemplateVerifyEmail: mustTemplate(
+		`Verify your email for {{.BusinessName}}`,
+		`An account on {{.BusinessName}} was created for {{.Email}}.
+
+Confirm this is your address to finish setting it up:
+{{.URL}}
+
+The link expires in {{.ExpiresIn}}. Signing in with your password sends a new one.
+`,
+		`<p>An account on {{.BusinessName}} was created for {{.Email}}.</p>
+<p><a href="{{.URL}}">Confirm your email address</a> to finish setting it up.</p>
+<p>The link expires in {{.ExpiresIn}}. Signing in with your password sends a new one.</p>
+`),
+
+	TemplateInvitation: mustTemplate(
+		`You're invited to {{.BusinessName}}`,
+		`You've been invited to the {{.BusinessName}} dashboard as {{.Email}}.
+
+Choose a password to accept the invitation:
+{{.URL}}
+
+The link works once and expires in {{.ExpiresIn}}. Ask your administrator for a new one if it runs out.
+`,
+		`<p>You've been invited to the {{.BusinessName}} dashboard as {{.Email}}.</p>
+<p><a href="{{.URL}}">Choose a password</a> to accept the invitation.</p>
+<p>The link works once and expires in {{.ExpiresIn}}. Ask your administrator for a new one if it runs out.</p>
+`),
+}
+
+func mustTemplate(subject, text, html string) messageTemplate {
+	return messageTemplate{
+		subject: texttemplate.Must(texttemplate.New("subject").Parse(subject)),
+		text:    texttemplate.Must(texttemplate.New("text").Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New("html").Parse(html)),
+	}
+}
+
+// Render builds a message from a named template. Recipients and
+// attachments are left for the caller to set.
+func Render(name string, data interface{}) (*Message, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text body: %w", name, err)
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html body: %w", name, err)
+	}
+
+	return &Message{
+		Subject:  subject.String(),
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
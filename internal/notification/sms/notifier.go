@@ -0,0 +1,224 @@
+// Package sms texts call, quote, usage and job failure notices to the staff
+// who chose to receive them by SMS.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+	"github.com/jkindrix/quickquote/internal/service"
+)
+
+// Sender sends a text message.
+type Sender interface {
+	SendSMS(ctx context.Context, req *bland.SendSMSRequest) (*bland.SendSMSResponse, error)
+}
+
+// RecipientResolver returns the phone numbers of the users who chose to be
+// texted about an event.
+type RecipientResolver interface {
+	Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string
+}
+
+// Config holds notifier settings.
+type Config struct {
+	// From is the number messages are sent from; empty uses the provider's
+	// default number.
+	From string
+	// BusinessName prefixes every message.
+	BusinessName string
+	// PublicURL is used to link to call and quote pages.
+	PublicURL string
+	// SendTimeout bounds each delivery attempt.
+	SendTimeout time.Duration
+	// AlertInterval suppresses repeats of the same usage alert.
+	AlertInterval time.Duration
+	// Currency is used for quotes generated before currencies were recorded.
+	Currency string
+}
+
+// Notifier texts notices to users. Messages are delivered in the background
+// so callers are never blocked.
+type Notifier struct {
+	sender     Sender
+	recipients RecipientResolver
+	config     Config
+	logger     *zap.Logger
+
+	wg sync.WaitGroup
+
+	mu         sync.Mutex
+	lastAlerts map[string]time.Time
+	clock      clock.Clock
+}
+
+// NewNotifier creates a new SMS notifier.
+func NewNotifier(sender Sender, recipients RecipientResolver, cfg Config, logger *zap.Logger, clk clock.Clock) *Notifier {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 30 * time.Second
+	}
+	if cfg.AlertInterval <= 0 {
+		cfg.AlertInterval = time.Hour
+	}
+	if cfg.BusinessName == "" {
+		cfg.BusinessName = "QuickQuote"
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+
+	return &Notifier{
+		sender:     sender,
+		recipients: recipients,
+		config:     cfg,
+		logger:     logger,
+		lastAlerts: make(map[string]time.Time),
+		clock:      clk,
+	}
+}
+
+// CallCompleted texts an answered call that ended.
+func (n *Notifier) CallCompleted(ctx context.Context, call *domain.Call) {
+	if call == nil {
+		return
+	}
+	text := "Call completed: " + caller(call)
+	if call.DurationSeconds != nil {
+		text += ", " + call.Duration().String()
+	}
+	n.send(ctx, domain.NotificationEventCallCompleted, text, n.link("/calls/", call.ID))
+}
+
+// QuoteReady texts a generated quote.
+func (n *Notifier) QuoteReady(ctx context.Context, call *domain.Call) {
+	if call == nil || call.QuoteSummary == nil {
+		return
+	}
+	currency := call.Currency()
+	if currency == "" {
+		currency = n.config.Currency
+	}
+	text := fmt.Sprintf("New quote %s for %s: %s",
+		quotepdf.QuoteNumber(call.ID), caller(call), quotepdf.FormatMoney(service.QuoteTotal(*call.QuoteSummary), currency))
+	n.send(ctx, domain.NotificationEventQuoteReady, text, n.link("/quotes/", call.ID))
+}
+
+// CallFailed texts a call that did not complete.
+func (n *Notifier) CallFailed(ctx context.Context, call *domain.Call) {
+	if call == nil {
+		return
+	}
+	text := "Call failed: " + caller(call)
+	if call.ErrorMessage != nil && *call.ErrorMessage != "" {
+		text += " - " + *call.ErrorMessage
+	}
+	n.send(ctx, domain.NotificationEventCallFailed, text, n.link("/calls/", call.ID))
+}
+
+// UsageAlert texts a reached usage limit. Repeats of the same alert are
+// suppressed for the configured alert interval.
+func (n *Notifier) UsageAlert(ctx context.Context, alert domain.UsageAlert) {
+	now := n.clock.Now()
+	n.mu.Lock()
+	if last, ok := n.lastAlerts[alert.Key()]; ok && now.Sub(last) < n.config.AlertInterval {
+		n.mu.Unlock()
+		return
+	}
+	n.lastAlerts[alert.Key()] = now
+	n.mu.Unlock()
+
+	text := fmt.Sprintf("Usage limit reached: %s %s (%d of %d)", alert.Resource, alert.Reason, alert.Used, alert.Limit)
+	if alert.ResetIn > 0 {
+		text += ", resets in " + alert.ResetIn.Round(time.Minute).String()
+	}
+	n.send(ctx, domain.NotificationEventUsageAlert, text, "")
+}
+
+// QuoteJobFailed texts a quote job that ran out of retries.
+func (n *Notifier) QuoteJobFailed(ctx context.Context, job *domain.QuoteJob) {
+	if job == nil {
+		return
+	}
+	text := fmt.Sprintf("Quote generation failed after %d attempts", job.Attempts)
+	if job.LastError != nil && *job.LastError != "" {
+		text += ": " + *job.LastError
+	}
+	n.send(ctx, domain.NotificationEventJobFailed, text, n.link("/calls/", job.CallID))
+}
+
+// Close waits for in-flight messages to be delivered or ctx to expire.
+func (n *Notifier) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// send texts a message to the users who chose the event, in the background
+// and detached from the caller's cancellation so request-scoped contexts do
+// not abort delivery.
+func (n *Notifier) send(ctx context.Context, event domain.NotificationEvent, text, link string) {
+	body := n.config.BusinessName + ": " + text
+	if link != "" {
+		body += "\n" + link
+	}
+
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.config.SendTimeout)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		defer cancel()
+
+		for _, phone := range n.recipients.Recipients(sendCtx, domain.NotificationChannelSMS, event) {
+			_, err := n.sender.SendSMS(sendCtx, &bland.SendSMSRequest{To: phone, From: n.config.From, Body: body})
+			if err != nil {
+				n.logger.Error("failed to send SMS notification", zap.String("event", string(event)), zap.Error(err))
+				continue
+			}
+			n.logger.Debug("SMS notification sent", zap.String("event", string(event)))
+		}
+	}()
+}
+
+// caller returns the caller's name and number, or just the number.
+func caller(call *domain.Call) string {
+	var name string
+	if call.CallerName != nil {
+		name = strings.TrimSpace(*call.CallerName)
+	}
+	if name == "" && call.ExtractedData != nil {
+		name = strings.TrimSpace(call.ExtractedData.CallerName)
+	}
+	if name == "" {
+		return call.CustomerNumber()
+	}
+	return name + " (" + call.CustomerNumber() + ")"
+}
+
+// link returns the admin URL for a call or quote page, or empty when no
+// public URL is set.
+func (n *Notifier) link(prefix string, id uuid.UUID) string {
+	if n.config.PublicURL == "" {
+		return ""
+	}
+	return n.config.PublicURL + prefix + id.String()
+}
@@ -0,0 +1,129 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []*bland.SendSMSRequest
+	err  error
+}
+
+func (f *fakeSender) SendSMS(ctx context.Context, req *bland.SendSMSRequest) (*bland.SendSMSResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &bland.SendSMSResponse{Status: "sent"}, nil
+}
+
+func (f *fakeSender) messages() []*bland.SendSMSRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*bland.SendSMSRequest(nil), f.sent...)
+}
+
+// fakeResolver returns fixed phone numbers for each event.
+type fakeResolver map[domain.NotificationEvent][]string
+
+func (f fakeResolver) Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string {
+	if channel != domain.NotificationChannelSMS {
+		return nil
+	}
+	return f[event]
+}
+
+func newTestNotifier(sender Sender, recipients RecipientResolver) *Notifier {
+	return NewNotifier(sender, recipients, Config{
+		From:         "+15550000000",
+		BusinessName: "Acme",
+		PublicURL:    "https://qq.example.com/",
+	}, zap.NewNop(), nil)
+}
+
+func closeNotifier(t *testing.T, n *Notifier) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := n.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestNotifier_QuoteReady(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, fakeResolver{
+		domain.NotificationEventQuoteReady: {"+15551110001", "+15551110002"},
+	})
+
+	name := "Jane Doe"
+	summary := "- Tear-off: $3,000\n- Shingles: $1,250"
+	call := &domain.Call{ID: uuid.New(), FromNumber: "+15550100", CallerName: &name, QuoteSummary: &summary}
+	n.QuoteReady(context.Background(), call)
+	n.CallFailed(context.Background(), call)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(msgs))
+	}
+	for _, msg := range msgs {
+		if msg.From != "+15550000000" {
+			t.Errorf("sent from %q", msg.From)
+		}
+		for _, want := range []string{"Acme: New quote", "Jane Doe (+15550100)", "$4,250.00", "https://qq.example.com/quotes/" + call.ID.String()} {
+			if !strings.Contains(msg.Body, want) {
+				t.Errorf("expected %q in message %q", want, msg.Body)
+			}
+		}
+	}
+}
+
+func TestNotifier_QuoteReadyCurrency(t *testing.T) {
+	sender := &fakeSender{}
+	n := newTestNotifier(sender, fakeResolver{domain.NotificationEventQuoteReady: {"+15551110001"}})
+
+	summary := "- Dach: 3.000 €\n- Ziegel: 1.250 €"
+	call := &domain.Call{ID: uuid.New(), FromNumber: "+4930123456", QuoteSummary: &summary,
+		ExtractedData: &domain.ExtractedData{Currency: "EUR"}}
+	n.QuoteReady(context.Background(), call)
+	closeNotifier(t, n)
+
+	msgs := sender.messages()
+	if len(msgs) != 1 || !strings.Contains(msgs[0].Body, "€4,250.00") {
+		t.Errorf("expected the total in euros, got %+v", msgs)
+	}
+}
+
+func TestNotifier_UsageAlertSuppressesRepeats(t *testing.T) {
+	sender := &fakeSender{err: errors.New("bland: invalid number")}
+	n := newTestNotifier(sender, fakeResolver{domain.NotificationEventUsageAlert: {"+15551110001"}})
+	clk := clock.NewMock(time.Now())
+	n.clock = clk
+
+	alert := domain.UsageAlert{Resource: "quote_generation", Reason: "day limit", Used: 500, Limit: 500}
+	n.UsageAlert(context.Background(), alert)
+	n.UsageAlert(context.Background(), alert)
+	clk.Advance(2 * time.Hour)
+	n.UsageAlert(context.Background(), alert)
+	closeNotifier(t, n)
+
+	if got := len(sender.messages()); got != 2 {
+		t.Errorf("expected the repeat within the interval suppressed, got %d messages", got)
+	}
+}
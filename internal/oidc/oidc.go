@@ -0,0 +1,238 @@
+// Package oidc signs users in with an OpenID Connect identity provider such
+// as Google Workspace, Microsoft Entra ID (Azure AD) or Okta, using the
+// authorization code flow with PKCE. Discovery, signing keys and ID token
+// verification are handled by go-oidc.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// ErrLoginFailed wraps errors the identity provider reports on the callback,
+// such as the user declining consent.
+var ErrLoginFailed = errors.New("identity provider login failed")
+
+// Config configures the identity provider.
+type Config struct {
+	// IssuerURL is the provider's issuer, e.g. https://accounts.google.com
+	// or https://login.microsoftonline.com/<tenant>/v2.0. The discovery
+	// document is read from <IssuerURL>/.well-known/openid-configuration.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is this server's callback URL registered with the provider.
+	RedirectURL string
+	// Scopes are requested in addition to openid.
+	Scopes []string
+}
+
+// Identity is the user the provider signed in. Issuer and Subject identify
+// the user for good; Email is verified by the provider but may change.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Name    string
+}
+
+// Login holds the per-attempt secrets that must survive the redirect to the
+// provider and back: the state checked against the callback, the nonce
+// checked against the ID token, and the PKCE code verifier.
+type Login struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// NewLogin generates the secrets for a login attempt.
+func NewLogin() (*Login, error) {
+	values := make([]string, 3)
+	for i := range values {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return &Login{State: values[0], Nonce: values[1], CodeVerifier: values[2]}, nil
+}
+
+// Provider signs users in with an OpenID Connect provider. The discovery
+// document is fetched on first use, so the server starts even when the
+// provider is unreachable; go-oidc caches the signing keys and refetches
+// them when the provider rotates its keys.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	provider *gooidc.Provider
+}
+
+// New creates a provider. It returns nil, nil when no issuer is configured.
+func New(cfg Config) (*Provider, error) {
+	if cfg.IssuerURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(cfg.IssuerURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Hostname() != "localhost") {
+		return nil, fmt.Errorf("OIDC issuer URL must be an https URL")
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("OIDC client ID and client secret are required")
+	}
+	if cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("OIDC redirect URL is required; set APP_PUBLIC_URL")
+	}
+	return &Provider{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// AuthURL returns the provider's sign-in page URL for a login attempt.
+func (p *Provider) AuthURL(ctx context.Context, login *Login) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(provider).AuthCodeURL(login.State,
+		gooidc.Nonce(login.Nonce),
+		oauth2.S256ChallengeOption(login.CodeVerifier),
+	), nil
+}
+
+// Exchange completes a login from the callback's query parameters. The
+// caller must already have checked the state parameter against login.State.
+// It trades the code for tokens and returns the identity in the verified ID
+// token, which must carry an email address the provider has verified.
+func (p *Provider) Exchange(ctx context.Context, query url.Values, login *Login) (*Identity, error) {
+	if e := query.Get("error"); e != "" {
+		if desc := query.Get("error_description"); desc != "" {
+			e += ": " + desc
+		}
+		return nil, fmt.Errorf("%w: %s", ErrLoginFailed, e)
+	}
+	code := query.Get("code")
+	if code == "" {
+		return nil, fmt.Errorf("authorization code is missing")
+	}
+
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = gooidc.ClientContext(ctx, p.client)
+	token, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(login.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("token response has no ID token")
+	}
+
+	idToken, err := provider.Verifier(&gooidc.Config{ClientID: p.config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(login.Nonce)) != 1 {
+		return nil, fmt.Errorf("ID token nonce does not match")
+	}
+
+	var claims struct {
+		Email         string          `json:"email"`
+		EmailVerified json.RawMessage `json:"email_verified"`
+		Name          string          `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %w", err)
+	}
+	switch {
+	case idToken.Subject == "":
+		return nil, fmt.Errorf("ID token has no subject")
+	case claims.Email == "":
+		return nil, fmt.Errorf("ID token has no email claim; request the email scope or add the email optional claim")
+	case !emailVerified(claims.EmailVerified):
+		return nil, fmt.Errorf("email address %s is not verified by the identity provider", claims.Email)
+	}
+
+	return &Identity{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Email:   strings.TrimSpace(claims.Email),
+		Name:    claims.Name,
+	}, nil
+}
+
+// discover returns the provider from its discovery document, fetching it
+// on first use and again after a failed attempt.
+func (p *Provider) discover(ctx context.Context) (*gooidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	// The key set keeps the context it is created with for refetching keys,
+	// so it must outlive this request
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(context.WithoutCancel(ctx), p.client), p.config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+func (p *Provider) oauth2Config(provider *gooidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       dedupe(append([]string{gooidc.ScopeOpenID, "email", "profile"}, p.config.Scopes...)),
+	}
+}
+
+// emailVerified reports whether the email_verified claim is true. Some
+// providers send it as a string. A missing claim counts as unverified:
+// providers that omit it, such as Entra ID, may let users set an address
+// they don't own.
+func emailVerified(raw json.RawMessage) bool {
+	var verified bool
+	if err := json.Unmarshal(raw, &verified); err == nil {
+		return verified
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s == "true"
+	}
+	return false
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0]
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
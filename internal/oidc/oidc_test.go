@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIdP is an identity provider that issues ID tokens signed with an RSA
+// key, or an ECDSA key for ES256.
+type fakeIdP struct {
+	server *httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+
+	mu         sync.Mutex
+	claims     map[string]interface{}
+	alg        string
+	kid        string
+	verifier   string
+	keyFetches int
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeIdP{rsaKey: rsaKey, ecKey: ecKey, alg: "RS256", kid: "rsa-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                f.server.URL,
+			"authorization_endpoint":                f.server.URL + "/authorize",
+			"token_endpoint":                        f.server.URL + "/token",
+			"jwks_uri":                              f.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256", "ES256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.keyFetches++
+		f.mu.Unlock()
+		enc := base64.RawURLEncoding
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": enc.EncodeToString(rsaKey.N.Bytes()), "e": enc.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": enc.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))), "y": enc.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32)))},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		id, secret, _ := r.BasicAuth()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if id != "client-1" || secret != "secret" || r.PostForm.Get("code") != "code-1" ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != f.verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access-1", "token_type": "Bearer", "id_token": f.sign(t)})
+	})
+	f.server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// sign returns an ID token with the current claims. The caller holds f.mu.
+func (f *fakeIdP) sign(t *testing.T) string {
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": f.alg, "kid": f.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(f.claims)
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	if f.alg == "ES256" {
+		r, s, err := ecdsa.Sign(rand.Reader, f.ecKey, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	} else {
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, f.rsaKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + enc.EncodeToString(sig)
+}
+
+// login starts a login at the fake provider and returns the attempt and the
+// callback query it would redirect back with.
+func (f *fakeIdP) login(t *testing.T, p *Provider, claims map[string]interface{}) (*Login, url.Values) {
+	t.Helper()
+	login, err := NewLogin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthURL(context.Background(), login)
+	if err != nil {
+		t.Fatalf("AuthURL() error = %v", err)
+	}
+	u, _ := url.Parse(authURL)
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("state") != login.State || q.Get("redirect_uri") != "https://qq.example.com/auth/sso/callback" {
+		t.Fatalf("unexpected authorization URL %s", authURL)
+	}
+	if !strings.Contains(q.Get("scope"), "openid") || !strings.Contains(q.Get("scope"), "groups") {
+		t.Errorf("unexpected scope %q", q.Get("scope"))
+	}
+
+	base := map[string]interface{}{
+		"iss":            f.server.URL,
+		"sub":            "user-1",
+		"aud":            "client-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          q.Get("nonce"),
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"name":           "Jane Doe",
+	}
+	for k, v := range claims {
+		if v == nil {
+			delete(base, k)
+		} else {
+			base[k] = v
+		}
+	}
+	f.mu.Lock()
+	f.claims = base
+	f.verifier = q.Get("code_challenge")
+	f.mu.Unlock()
+
+	return login, url.Values{"code": {"code-1"}, "state": {login.State}}
+}
+
+func newTestProvider(t *testing.T, f *fakeIdP) *Provider {
+	t.Helper()
+	p, err := New(Config{
+		IssuerURL:    f.server.URL,
+		ClientID:     "client-1",
+		ClientSecret: "secret",
+		RedirectURL:  "https://qq.example.com/auth/sso/callback",
+		Scopes:       []string{"groups", "email"},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	p.client = f.server.Client()
+	return p
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	f := newFakeIdP(t)
+	p := newTestProvider(t, f)
+
+	login, query := f.login(t, p, nil)
+	identity, err := p.Exchange(context.Background(), query, login)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if identity.Issuer != f.server.URL || identity.Subject != "user-1" || identity.Email != "jane@example.com" || identity.Name != "Jane Doe" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+
+	f.mu.Lock()
+	f.alg, f.kid = "ES256", "ec-1"
+	f.mu.Unlock()
+	login, query = f.login(t, p, map[string]interface{}{"aud": []string{"client-1", "other"}, "azp": "client-1"})
+	if _, err := p.Exchange(context.Background(), query, login); err != nil {
+		t.Errorf("Exchange() with ES256 error = %v", err)
+	}
+	if f.keyFetches != 1 {
+		t.Errorf("expected the signing keys fetched once, got %d", f.keyFetches)
+	}
+}
+
+func TestProvider_Exchange_RejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		nonce  string
+	}{
+		{"wrong issuer", map[string]interface{}{"iss": "https://evil.example.com"}, ""},
+		{"wrong audience", map[string]interface{}{"aud": "client-2"}, ""},
+		{"expired", map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}, ""},
+		{"wrong nonce", nil, "other-nonce"},
+		{"no email", map[string]interface{}{"email": nil}, ""},
+		{"unverified email", map[string]interface{}{"email_verified": false}, ""},
+		{"unverified email string", map[string]interface{}{"email_verified": "false"}, ""},
+		{"email not known to be verified", map[string]interface{}{"email_verified": nil}, ""},
+	}
+	f := newFakeIdP(t)
+	p := newTestProvider(t, f)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login, query := f.login(t, p, tt.claims)
+			if tt.nonce != "" {
+				login.Nonce = tt.nonce
+			}
+			if _, err := p.Exchange(context.Background(), query, login); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestProvider_Exchange_RejectsWrongVerifierAndSignature(t *testing.T) {
+	f := newFakeIdP(t)
+	p := newTestProvider(t, f)
+
+	login, query := f.login(t, p, nil)
+	login.CodeVerifier = "stolen-code-without-verifier"
+	if _, err := p.Exchange(context.Background(), query, login); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("expected the token request refused, got %v", err)
+	}
+
+	// A token signed with the EC key but claiming the RSA key's ID
+	f.mu.Lock()
+	f.alg = "ES256"
+	f.mu.Unlock()
+	login, query = f.login(t, p, nil)
+	if _, err := p.Exchange(context.Background(), query, login); err == nil {
+		t.Error("expected a signature mismatch to be rejected")
+	}
+}
+
+func TestProvider_Exchange_ProviderError(t *testing.T) {
+	f := newFakeIdP(t)
+	p := newTestProvider(t, f)
+
+	query := url.Values{"error": {"access_denied"}, "error_description": {"The user declined"}}
+	if _, err := p.Exchange(context.Background(), query, &Login{}); !errors.Is(err, ErrLoginFailed) {
+		t.Errorf("Exchange() error = %v, want %v", err, ErrLoginFailed)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if p, err := New(Config{}); p != nil || err != nil {
+		t.Errorf("expected nil, nil without an issuer, got %v, %v", p, err)
+	}
+	if _, err := New(Config{IssuerURL: "http://idp.example.com", ClientID: "a", ClientSecret: "b", RedirectURL: "c"}); err == nil {
+		t.Error("expected an http issuer to be rejected")
+	}
+	if _, err := New(Config{IssuerURL: "https://idp.example.com", ClientID: "a", ClientSecret: "b"}); err == nil {
+		t.Error("expected a missing redirect URL to be rejected")
+	}
+}
@@ -0,0 +1,668 @@
+package openapi
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// handlerDir is where the annotated handlers live, relative to the module.
+const handlerDir = "internal/handler"
+
+// Security scheme names.
+const (
+	SchemeAPIKey  = "apiKey"
+	SchemeSession = "session"
+)
+
+// externalTypes are the schemas of types from outside the module that
+// appear in requests and responses.
+var externalTypes = map[string]*Schema{
+	"time.Time":                       {Type: "string", Format: "date-time"},
+	"time.Duration":                   {Type: "integer", Format: "int64", Description: "Nanoseconds"},
+	"github.com/google/uuid.UUID":     {Type: "string", Format: "uuid"},
+	"encoding/json.RawMessage":        {},
+	"database/sql.NullString":         {Type: "string"},
+	"database/sql.NullTime":           {Type: "string", Format: "date-time"},
+	"github.com/google/uuid.NullUUID": {Type: "string", Format: "uuid"},
+}
+
+// contentTypes expands the short content types annotations may use.
+var contentTypes = map[string]string{
+	"json":         "application/json",
+	"plain":        "text/plain",
+	"html":         "text/html",
+	"octet-stream": "application/octet-stream",
+	"event-stream": "text/event-stream",
+}
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(\S+)\s+(\S+)\s+(true|false)\s+"([^"]*)"\s*(.*)$`)
+	responsePattern = regexp.MustCompile(`^(\d+)(?:\s+\{(\w+)\}\s+(\S+))?(?:\s+"([^"]*)")?\s*$`)
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(\w+)\]$`)
+	attrPattern     = regexp.MustCompile(`(\w+)\(([^)]*)\)`)
+)
+
+// Generate builds the API's document from the swag-style annotations
+// (@Summary, @Param, @Success, @Router and so on) on the handlers of the
+// module at root, resolving the types they name from the module's source.
+func Generate(root string) (*Document, error) {
+	module, err := modulePath(root)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		module:  module,
+		types:   make(map[string]*typeDecl),
+		enums:   make(map[string][]interface{}),
+		schemas: make(map[string]*Schema),
+	}
+	if err := g.load(filepath.Join(root, "internal")); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "QuickQuote API",
+			Description: "Calls, quotes, prompts and the rest of QuickQuote. Authenticate with an API key (`Authorization: Bearer qq_...`) or a dashboard session.",
+			Version:     "v1",
+		},
+		Servers:  []Server{{URL: "/"}},
+		Security: []map[string][]string{{SchemeAPIKey: {}}, {SchemeSession: {}}},
+		Paths:    make(map[string]PathItem),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				SchemeAPIKey:  {Type: "http", Scheme: "bearer", BearerFormat: "qq_...", Description: "API key created at /admin/api-keys; each key is limited to its scopes"},
+				SchemeSession: {Type: "apiKey", In: "cookie", Name: "session_token", Description: "Dashboard session; requests other than GET also need the X-CSRF-Token header"},
+			},
+		},
+	}
+
+	handlerPath := module + "/" + handlerDir
+	tags := make(map[string]bool)
+	operationIDs := make(map[string]bool)
+	for _, file := range g.files[handlerPath] {
+		for _, decl := range file.ast.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil || !strings.Contains(fn.Doc.Text(), "@Router") {
+				continue
+			}
+			path, method, op, err := g.operation(file, fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", filepath.Base(g.fset.Position(fn.Pos()).Filename), fn.Name.Name, err)
+			}
+
+			op.OperationID = fn.Name.Name
+			if operationIDs[op.OperationID] {
+				op.OperationID = receiverName(fn) + fn.Name.Name
+			}
+			operationIDs[op.OperationID] = true
+			for _, tag := range op.Tags {
+				tags[tag] = true
+			}
+
+			item := doc.Paths[path]
+			if item == nil {
+				item = make(PathItem)
+				doc.Paths[path] = item
+			}
+			if item[method] != nil {
+				return nil, fmt.Errorf("%s %s is annotated twice", strings.ToUpper(method), path)
+			}
+			item[method] = op
+		}
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return doc, nil
+}
+
+// modulePath reads the module path from root/go.mod.
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(module), nil
+		}
+	}
+	return "", fmt.Errorf("no module path in %s", f.Name())
+}
+
+// sourceFile is a parsed file with its imports.
+type sourceFile struct {
+	ast     *ast.File
+	pkgPath string
+	imports map[string]string // Local name to import path
+}
+
+// typeDecl is a type declared in the module.
+type typeDecl struct {
+	name string // Component name, such as domain.Prompt
+	spec *ast.TypeSpec
+	doc  string
+	file *sourceFile
+}
+
+type generator struct {
+	module  string
+	fset    *token.FileSet
+	files   map[string][]*sourceFile // By package path
+	types   map[string]*typeDecl     // By package path and type name
+	enums   map[string][]interface{} // String constants by package path and type name
+	schemas map[string]*Schema       // Components, by name
+}
+
+// load parses every non-test file under dir.
+func (g *generator) load(dir string) error {
+	g.fset = token.NewFileSet()
+	g.files = make(map[string][]*sourceFile)
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(g.fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.Dir(dir), filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		file := &sourceFile{ast: f, pkgPath: g.module + "/" + filepath.ToSlash(rel), imports: make(map[string]string)}
+		for _, imp := range f.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			name := importPath[strings.LastIndex(importPath, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			file.imports[name] = importPath
+		}
+		g.files[file.pkgPath] = append(g.files[file.pkgPath], file)
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					doc := spec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					g.types[file.pkgPath+"."+spec.Name.Name] = &typeDecl{
+						name: f.Name.Name + "." + spec.Name.Name,
+						spec: spec,
+						doc:  commentText(doc),
+						file: file,
+					}
+				case *ast.ValueSpec:
+					g.collectEnum(file, gen.Tok, spec)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// collectEnum records string constants declared with a named type, so the
+// type's schema can list its values.
+func (g *generator) collectEnum(file *sourceFile, tok token.Token, spec *ast.ValueSpec) {
+	ident, ok := spec.Type.(*ast.Ident)
+	if tok != token.CONST || !ok {
+		return
+	}
+	for _, value := range spec.Values {
+		lit, ok := value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			continue
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			continue
+		}
+		key := file.pkgPath + "." + ident.Name
+		g.enums[key] = append(g.enums[key], s)
+	}
+}
+
+// operation builds an operation from a handler's annotations.
+func (g *generator) operation(file *sourceFile, fn *ast.FuncDecl) (string, string, *Operation, error) {
+	op := &Operation{Responses: make(map[string]Response)}
+	var path, method string
+	var accept, produce []string
+	var body *Parameter
+	var bodyType string
+
+	type response struct {
+		code, kind, typ, description string
+	}
+	var responses []response
+
+	for _, line := range fn.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(line.Text, "//"))
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+		key, value, _ := strings.Cut(text, " ")
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "@Summary":
+			op.Summary = value
+		case "@Description":
+			op.Description = strings.TrimSpace(op.Description + " " + value)
+		case "@Tags":
+			for _, tag := range strings.Split(value, ",") {
+				op.Tags = append(op.Tags, strings.TrimSpace(tag))
+			}
+		case "@Accept":
+			accept = append(accept, contentType(value))
+		case "@Produce":
+			produce = append(produce, contentType(value))
+		case "@Param":
+			m := paramPattern.FindStringSubmatch(value)
+			if m == nil {
+				return "", "", nil, fmt.Errorf("malformed @Param %q", value)
+			}
+			param := Parameter{Name: m[1], In: m[2], Required: m[4] == "true" || m[2] == "path", Description: m[5]}
+			if param.In == "body" {
+				body, bodyType = &param, m[3]
+				continue
+			}
+			param.Schema = paramSchema(m[3], m[6])
+			op.Parameters = append(op.Parameters, param)
+		case "@Success", "@Failure":
+			m := responsePattern.FindStringSubmatch(value)
+			if m == nil {
+				return "", "", nil, fmt.Errorf("malformed %s %q", key, value)
+			}
+			responses = append(responses, response{code: m[1], kind: m[2], typ: m[3], description: m[4]})
+		case "@Router":
+			m := routerPattern.FindStringSubmatch(value)
+			if m == nil {
+				return "", "", nil, fmt.Errorf("malformed @Router %q", value)
+			}
+			path, method = m[1], strings.ToLower(m[2])
+		}
+	}
+
+	if path == "" {
+		return "", "", nil, fmt.Errorf("missing @Router")
+	}
+	if strings.HasPrefix(path, "/api/public/") {
+		op.Security = &[]map[string][]string{}
+	}
+	if len(accept) == 0 {
+		accept = []string{"application/json"}
+	}
+	if len(produce) == 0 {
+		produce = []string{"application/json"}
+	}
+
+	if body != nil {
+		schema, err := g.typeSchema(file, bodyType)
+		if err != nil {
+			return "", "", nil, err
+		}
+		op.RequestBody = &RequestBody{Description: body.Description, Required: body.Required, Content: make(map[string]MediaType)}
+		for _, ct := range accept {
+			op.RequestBody.Content[ct] = MediaType{Schema: schema}
+		}
+	}
+
+	for _, r := range responses {
+		res := Response{Description: r.description}
+		if res.Description == "" {
+			code, _ := strconv.Atoi(r.code)
+			res.Description = http.StatusText(code)
+		}
+
+		var schema *Schema
+		switch r.kind {
+		case "":
+		case "file":
+			schema = &Schema{Type: "string", Format: "binary"}
+		case "object", "array":
+			var err error
+			if schema, err = g.typeSchema(file, r.typ); err != nil {
+				return "", "", nil, err
+			}
+			if r.kind == "array" {
+				schema = &Schema{Type: "array", Items: schema}
+			}
+		default:
+			return "", "", nil, fmt.Errorf("unknown response kind {%s}", r.kind)
+		}
+
+		if schema != nil {
+			res.Content = make(map[string]MediaType)
+			if r.code[0] == '2' {
+				for _, ct := range produce {
+					res.Content[ct] = MediaType{Schema: schema}
+				}
+			} else {
+				res.Content["application/json"] = MediaType{Schema: schema}
+			}
+		}
+		op.Responses[r.code] = res
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["default"] = Response{Description: "Response"}
+	}
+
+	return path, method, op, nil
+}
+
+// typeSchema returns the schema of a type named in an annotation, such as
+// domain.Prompt or map[string]string, resolved in file's package.
+func (g *generator) typeSchema(file *sourceFile, typ string) (*Schema, error) {
+	expr, err := parser.ParseExpr(typ)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q: %w", typ, err)
+	}
+	schema := g.exprSchema(file, expr)
+	if schema == nil {
+		return nil, fmt.Errorf("type %q can't be described", typ)
+	}
+	if schema.Ref == "" && schema.Type == "" && isNamed(expr) {
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return schema, nil
+}
+
+func isNamed(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.SelectorExpr:
+		return true
+	case *ast.Ident:
+		return expr.Name != "any"
+	}
+	return false
+}
+
+// exprSchema returns the schema of a type expression in file, or nil for
+// types JSON can't carry.
+func (g *generator) exprSchema(file *sourceFile, expr ast.Expr) *Schema {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if schema := basicSchema(expr.Name); schema != nil {
+			return schema
+		}
+		return g.namedSchema(file.pkgPath, expr.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return &Schema{}
+		}
+		importPath, ok := file.imports[pkg.Name]
+		if !ok {
+			// Annotations may name packages their file doesn't import
+			importPath = g.module + "/internal/" + pkg.Name
+		}
+		if strings.HasPrefix(importPath, g.module+"/") {
+			return g.namedSchema(importPath, expr.Sel.Name)
+		}
+		if schema, ok := externalTypes[importPath+"."+expr.Sel.Name]; ok {
+			cp := *schema
+			return &cp
+		}
+		return &Schema{}
+	case *ast.StarExpr:
+		return g.exprSchema(file, expr.X)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		items := g.exprSchema(file, expr.Elt)
+		if items == nil {
+			return nil
+		}
+		return &Schema{Type: "array", Items: items}
+	case *ast.MapType:
+		values := g.exprSchema(file, expr.Value)
+		if values == nil {
+			return nil
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}
+	case *ast.StructType:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		g.addFields(file, expr, schema)
+		return schema
+	case *ast.InterfaceType:
+		return &Schema{}
+	case *ast.FuncType, *ast.ChanType:
+		return nil
+	default:
+		return &Schema{}
+	}
+}
+
+// basicSchema returns the schema of a predeclared type.
+func basicSchema(name string) *Schema {
+	switch name {
+	case "string", "error":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32", "byte", "rune":
+		return &Schema{Type: "integer"}
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	case "any":
+		return &Schema{}
+	}
+	return nil
+}
+
+// namedSchema returns the schema of a type declared in the module. Structs
+// become components referred to by $ref; other types are described inline,
+// with their string constants as the allowed values.
+func (g *generator) namedSchema(pkgPath, name string) *Schema {
+	key := pkgPath + "." + name
+	decl, ok := g.types[key]
+	if !ok {
+		return &Schema{}
+	}
+
+	st, ok := decl.spec.Type.(*ast.StructType)
+	if !ok || decl.spec.TypeParams != nil {
+		schema := g.exprSchema(decl.file, decl.spec.Type)
+		if schema != nil && schema.Ref == "" && decl.spec.TypeParams == nil {
+			schema.Description = decl.doc
+			if values := g.enums[key]; len(values) > 0 && schema.Type == "string" {
+				schema.Enum = values
+			}
+		}
+		return schema
+	}
+
+	ref := &Schema{Ref: "#/components/schemas/" + decl.name}
+	if _, ok := g.schemas[decl.name]; ok {
+		return ref
+	}
+	schema := &Schema{Type: "object", Description: decl.doc, Properties: make(map[string]*Schema)}
+	g.schemas[decl.name] = schema
+	g.addFields(decl.file, st, schema)
+	return ref
+}
+
+// addFields adds a struct's JSON fields to schema, flattening embedded
+// structs as encoding/json does.
+func (g *generator) addFields(file *sourceFile, st *ast.StructType, schema *Schema) {
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+		jsonTag := reflect.StructTag(tag).Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(jsonTag, ",")
+
+		if len(field.Names) == 0 {
+			if jsonName == "" {
+				g.addEmbedded(file, field.Type, schema)
+				continue
+			}
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(field.Type)}
+		}
+		for _, name := range names {
+			if name == nil || !name.IsExported() {
+				continue
+			}
+			prop := g.exprSchema(file, field.Type)
+			if prop == nil {
+				continue
+			}
+			doc := field.Doc
+			if doc == nil {
+				doc = field.Comment
+			}
+			if description := commentText(doc); description != "" && prop.Ref == "" {
+				prop.Description = description
+			}
+			propName := jsonName
+			if propName == "" {
+				propName = name.Name
+			}
+			schema.Properties[propName] = prop
+		}
+	}
+}
+
+// addEmbedded flattens an embedded struct into schema.
+func (g *generator) addEmbedded(file *sourceFile, expr ast.Expr, schema *Schema) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	var key string
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		key = file.pkgPath + "." + expr.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := expr.X.(*ast.Ident); ok {
+			key = file.imports[pkg.Name] + "." + expr.Sel.Name
+		}
+	}
+	decl, ok := g.types[key]
+	if !ok {
+		return
+	}
+	if st, ok := decl.spec.Type.(*ast.StructType); ok {
+		g.addFields(decl.file, st, schema)
+	}
+}
+
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.Ident:
+		return expr
+	case *ast.SelectorExpr:
+		return expr.Sel
+	}
+	return nil
+}
+
+// paramSchema returns the schema of a path or query parameter, with the
+// default(...) and Enums(...) attributes annotations may add.
+func paramSchema(typ, attrs string) *Schema {
+	schema := basicSchema(typ)
+	switch {
+	case schema != nil:
+	case typ == "integer" || typ == "number" || typ == "boolean":
+		schema = &Schema{Type: typ}
+	default:
+		schema = &Schema{Type: "string"}
+	}
+
+	for _, m := range attrPattern.FindAllStringSubmatch(attrs, -1) {
+		switch strings.ToLower(m[1]) {
+		case "default":
+			schema.Default = paramValue(schema.Type, m[2])
+		case "enums":
+			for _, v := range strings.Split(m[2], ",") {
+				schema.Enum = append(schema.Enum, paramValue(schema.Type, strings.TrimSpace(v)))
+			}
+		}
+	}
+	return schema
+}
+
+func paramValue(typ, s string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+func contentType(s string) string {
+	if ct, ok := contentTypes[s]; ok {
+		return ct
+	}
+	return s
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	if ident := embeddedName(fn.Recv.List[0].Type); ident != nil {
+		return strings.TrimSuffix(ident.Name, "Handler")
+	}
+	return ""
+}
+
+// commentText returns a doc comment as one line.
+func commentText(group *ast.CommentGroup) string {
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
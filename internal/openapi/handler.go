@@ -0,0 +1,170 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// generated is the document written by cmd/openapi-gen.
+//
+//go:embed openapi.json
+var generated []byte
+
+//go:embed docs.html
+var docsHTML string
+
+var docsTemplate = template.Must(template.New("docs").Parse(docsHTML))
+
+var routeParamPattern = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// Marshal encodes a document the way cmd/openapi-gen writes it.
+func Marshal(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Generated returns the document generated from the handler annotations.
+func Generated() (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(generated, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode the generated OpenAPI document: %w", err)
+	}
+	return &doc, nil
+}
+
+// AddRoutes adds the routes of a router mounted at prefix that the
+// document doesn't describe, so every route is listed even before its
+// handler is annotated. Their parameters come from the route pattern.
+func AddRoutes(doc *Document, routes chi.Routes, prefix string) (int, error) {
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[method+" "+routeKey(path)] = true
+		}
+	}
+
+	added := 0
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		method = strings.ToLower(method)
+		path := strings.TrimSuffix(prefix+strings.TrimSuffix(route, "/*"), "/")
+		if strings.HasSuffix(path, "*") || documented[method+" "+routeKey(path)] {
+			return nil
+		}
+		documented[method+" "+routeKey(path)] = true
+
+		op := &Operation{
+			Description: "This route has no annotations yet.",
+			Responses:   map[string]Response{"default": {Description: "Response"}},
+		}
+		if resource, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/"), "/"); resource != "" {
+			op.Tags = []string{resource}
+		}
+		for _, m := range routeParamPattern.FindAllStringSubmatch(path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+
+		path = routeParamPattern.ReplaceAllString(path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][method] = op
+		added++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	tags := make(map[string]bool)
+	for _, item := range doc.Paths {
+		for _, op := range item {
+			for _, tag := range op.Tags {
+				tags[tag] = true
+			}
+		}
+	}
+	doc.Tags = doc.Tags[:0]
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return added, nil
+}
+
+// routeKey normalizes a path so routes match whatever their parameters are
+// called.
+func routeKey(path string) string {
+	return routeParamPattern.ReplaceAllString(path, "{}")
+}
+
+// Handler serves the API's OpenAPI document and Swagger UI.
+type Handler struct {
+	spec []byte
+	docs []byte
+}
+
+// NewHandler returns a handler for the generated document completed with
+// the routes of api, mounted at prefix. Swagger UI's assets are loaded from
+// swaggerUIURL.
+func NewHandler(api chi.Routes, prefix, swaggerUIURL string, logger *zap.Logger) (*Handler, error) {
+	doc, err := Generated()
+	if err != nil {
+		return nil, err
+	}
+	added, err := AddRoutes(doc, api, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API routes: %w", err)
+	}
+	spec, err := Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs bytes.Buffer
+	err = docsTemplate.Execute(&docs, map[string]string{
+		"AssetsURL": strings.TrimSuffix(swaggerUIURL, "/"),
+		"SpecURL":   prefix + "/openapi.json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if added > 0 {
+		logger.Debug("API routes without OpenAPI annotations", zap.Int("count", added))
+	}
+	return &Handler{spec: spec, docs: docs.Bytes()}, nil
+}
+
+// RegisterRoutes registers /openapi.json and /docs.
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/openapi.json", h.ServeSpec)
+	r.Get("/docs", h.ServeDocs)
+}
+
+// ServeSpec serves the OpenAPI document.
+func (h *Handler) ServeSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(h.spec)
+}
+
+// ServeDocs serves Swagger UI for the document.
+func (h *Handler) ServeDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(h.docs)
+}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+func TestGenerated_UpToDate(t *testing.T) {
+	doc, err := Generate("../..")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	data, err := Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, generated) {
+		t.Fatal("openapi.json is out of date; run make openapi")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generated()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := doc.Paths["/api/v1/prompts/bundle/import"]["post"]
+	if op == nil {
+		t.Fatal("expected the prompt bundle import operation")
+	}
+	if op.OperationID != "ImportPromptBundle" || op.Tags[0] != "prompts" {
+		t.Errorf("unexpected operation %+v", op)
+	}
+	if schema := op.RequestBody.Content["application/yaml"].Schema; schema == nil || schema.Ref != "#/components/schemas/domain.PromptBundle" {
+		t.Errorf("expected a YAML request body of domain.PromptBundle, got %+v", op.RequestBody)
+	}
+	if res := op.Responses["403"]; res.Content["application/json"].Schema.Ref != "#/components/schemas/handler.ErrorResponse" {
+		t.Errorf("expected errors described as ErrorResponse, got %+v", res)
+	}
+
+	var conflict *Parameter
+	for i, p := range op.Parameters {
+		if p.Name == "conflict" {
+			conflict = &op.Parameters[i]
+		}
+	}
+	if conflict == nil || conflict.In != "query" || conflict.Schema.Type != "string" {
+		t.Errorf("expected the conflict query parameter, got %+v", op.Parameters)
+	}
+
+	prompt := doc.Components.Schemas["domain.Prompt"]
+	if prompt == nil || prompt.Properties["name"].Type != "string" || prompt.Properties["id"].Format != "uuid" {
+		t.Fatalf("unexpected domain.Prompt schema %+v", prompt)
+	}
+	if _, ok := prompt.Properties["ID"]; ok {
+		t.Error("expected properties named by their JSON tags")
+	}
+
+	if public := doc.Paths["/api/public/quote-requests"]["post"]; public == nil || public.Security == nil || len(*public.Security) != 0 {
+		t.Error("expected public routes to need no authentication")
+	}
+}
+
+func TestAddRoutes(t *testing.T) {
+	doc := &Document{Paths: map[string]PathItem{
+		"/api/v1/calls/{callID}": {"get": {OperationID: "GetCall", Tags: []string{"calls"}}},
+	}}
+
+	r := chi.NewRouter()
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	r.Route("/calls", func(r chi.Router) {
+		r.Get("/{id}", noop)
+		r.Post("/{id:[0-9a-f-]+}/end", noop)
+	})
+
+	added, err := AddRoutes(doc, r, "/api/v1")
+	if err != nil {
+		t.Fatalf("AddRoutes() error = %v", err)
+	}
+	if added != 1 {
+		t.Fatalf("expected one route added, got %d", added)
+	}
+	if doc.Paths["/api/v1/calls/{callID}"]["get"].OperationID != "GetCall" {
+		t.Error("expected the annotated operation kept")
+	}
+	op := doc.Paths["/api/v1/calls/{id}/end"]["post"]
+	if op == nil || len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || !op.Parameters[0].Required {
+		t.Fatalf("expected the undocumented route with its path parameter, got %+v", op)
+	}
+	if len(doc.Tags) != 1 || doc.Tags[0].Name != "calls" {
+		t.Errorf("tags = %+v", doc.Tags)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := chi.NewRouter()
+	h, err := NewHandler(r, "/api/v1", "https://assets.example.com/swagger-ui/", zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHandler() error = %v", err)
+	}
+	h.RegisterRoutes(r)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var doc Document
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &doc) != nil || doc.OpenAPI != Version {
+		t.Fatalf("expected the document, got %d %s", rec.Code, rec.Body.String()[:min(rec.Body.Len(), 200)])
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, `src="https://assets.example.com/swagger-ui/swagger-ui-bundle.js"`) || !strings.Contains(body, `url: "\/api\/v1\/openapi.json"`) {
+		t.Errorf("unexpected docs page:\n%s", body)
+	}
+}
@@ -0,0 +1,109 @@
+// Package payments collects payment for accepted quotes through a payment
+// processor.
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone   = ""
+	ProviderStripe = "stripe"
+)
+
+// ErrInvalidSignature is returned for webhooks that were not signed by the
+// processor.
+var ErrInvalidSignature = errors.New("invalid payment webhook signature")
+
+// LinkRequest describes a payment to collect.
+type LinkRequest struct {
+	// Reference identifies what is being paid for and comes back on the
+	// payment's webhook.
+	Reference   string
+	Description string
+	Amount      int64  // In the currency's smallest unit, such as cents
+	Currency    string // ISO 4217 code, such as "USD"
+}
+
+// Validate checks that the request can be sent to a processor.
+func (r *LinkRequest) Validate() error {
+	if r.Reference == "" {
+		return errors.New("payment reference is required")
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		return errors.New("payment description is required")
+	}
+	if r.Amount <= 0 {
+		return errors.New("payment amount must be positive")
+	}
+	if len(r.Currency) != 3 {
+		return errors.New("payment currency must be a 3-letter code")
+	}
+	return nil
+}
+
+// Link is a hosted page where the customer pays.
+type Link struct {
+	ID  string // The processor's ID for the link
+	URL string
+}
+
+// Event is a completed payment reported by a processor webhook.
+type Event struct {
+	LinkID     string // The link that was paid
+	Reference  string // LinkRequest.Reference, when the processor returns it
+	PaymentID  string // The processor's ID for the payment
+	AmountPaid int64  // In the currency's smallest unit
+}
+
+// Processor creates payment links and reads the processor's webhooks.
+type Processor interface {
+	// CreateLink creates a payment link for the request.
+	CreateLink(ctx context.Context, req *LinkRequest) (*Link, error)
+
+	// ParseWebhook verifies a webhook and returns the completed payment it
+	// reports, or nil for events that do not complete a payment. It returns
+	// ErrInvalidSignature when the webhook is not authentic.
+	ParseWebhook(payload []byte, header http.Header) (*Event, error)
+
+	// Name returns the backend name for logging.
+	Name() string
+}
+
+// Config holds payment processor settings.
+type Config struct {
+	Provider string // "stripe", or empty to disable
+
+	StripeSecretKey     string
+	StripeWebhookSecret string // Signing secret of the webhook endpoint
+	StripeAPIURL        string // Overrides the Stripe API URL, for tests
+}
+
+// Enabled reports whether a payment processor is configured.
+func (c Config) Enabled() bool {
+	return c.Provider != ProviderNone
+}
+
+// New creates the processor for the configured provider. It returns nil when
+// no provider is configured.
+func New(cfg Config) (Processor, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderNone:
+		return nil, nil
+	case ProviderStripe:
+		if cfg.StripeSecretKey == "" {
+			return nil, errors.New("stripe secret key is required")
+		}
+		if cfg.StripeWebhookSecret == "" {
+			return nil, errors.New("stripe webhook secret is required")
+		}
+		return NewStripe(cfg, nil, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Provider)
+	}
+}
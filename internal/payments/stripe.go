@@ -0,0 +1,242 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+const (
+	// defaultStripeAPIURL is the Stripe API.
+	defaultStripeAPIURL = "https://api.stripe.com/v1"
+
+	// stripeSignatureTolerance is how old a signed webhook may be, to stop
+	// captured webhooks from being replayed.
+	stripeSignatureTolerance = 5 * time.Minute
+)
+
+// Stripe creates Stripe Payment Links and reads Stripe webhooks.
+type Stripe struct {
+	secretKey     string
+	webhookSecret string
+	apiURL        string
+	client        *http.Client
+
+	clock clock.Clock
+}
+
+// NewStripe creates a Stripe processor. A client with a 30 second timeout is
+// used when client is nil.
+func NewStripe(cfg Config, client *http.Client, clk clock.Clock) *Stripe {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	apiURL := cfg.StripeAPIURL
+	if apiURL == "" {
+		apiURL = defaultStripeAPIURL
+	}
+	return &Stripe{
+		secretKey:     cfg.StripeSecretKey,
+		webhookSecret: cfg.StripeWebhookSecret,
+		apiURL:        strings.TrimRight(apiURL, "/"),
+		client:        client,
+		clock:         clk,
+	}
+}
+
+// Name returns the backend name.
+func (s *Stripe) Name() string {
+	return ProviderStripe
+}
+
+// CreateLink creates a one-off price for the request and a payment link that
+// sells it once.
+func (s *Stripe) CreateLink(ctx context.Context, req *LinkRequest) (*Link, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var price struct {
+		ID string `json:"id"`
+	}
+	err := s.post(ctx, "/prices", url.Values{
+		"currency":           {strings.ToLower(req.Currency)},
+		"unit_amount":        {strconv.FormatInt(req.Amount, 10)},
+		"product_data[name]": {req.Description},
+	}, req.Reference+"-price", &price)
+	if err != nil {
+		return nil, err
+	}
+
+	var link struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	err = s.post(ctx, "/payment_links", url.Values{
+		"line_items[0][price]":    {price.ID},
+		"line_items[0][quantity]": {"1"},
+		"metadata[reference]":     {req.Reference},
+		// Stop the link from being paid twice.
+		"restrictions[completed_sessions][limit]": {"1"},
+	}, req.Reference+"-link", &link)
+	if err != nil {
+		return nil, err
+	}
+	if link.ID == "" || link.URL == "" {
+		return nil, fmt.Errorf("stripe returned no payment link")
+	}
+	return &Link{ID: link.ID, URL: link.URL}, nil
+}
+
+// post sends a form-encoded request to the Stripe API and decodes the JSON
+// response into out. The idempotency key makes a retried request return the
+// original result instead of creating a duplicate.
+func (s *Stripe) post(ctx context.Context, path string, form url.Values, idempotencyKey string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.apiURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create stripe request: %w", err)
+	}
+	req.SetBasicAuth(s.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("stripe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read stripe response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return fmt.Errorf("stripe returned %d: %s", resp.StatusCode, apiErr.Error.Message)
+		}
+		return fmt.Errorf("stripe returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode stripe response: %w", err)
+	}
+	return nil
+}
+
+// stripeEvent is the part of a Stripe webhook event used here.
+type stripeEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID            string            `json:"id"`
+			PaymentLink   string            `json:"payment_link"`
+			PaymentStatus string            `json:"payment_status"`
+			PaymentIntent string            `json:"payment_intent"`
+			AmountTotal   int64             `json:"amount_total"`
+			Metadata      map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// ParseWebhook verifies the Stripe-Signature header and returns the payment
+// completed by a checkout.session.completed or
+// checkout.session.async_payment_succeeded event for a payment link.
+func (s *Stripe) ParseWebhook(payload []byte, header http.Header) (*Event, error) {
+	if err := s.verifySignature(payload, header.Get("Stripe-Signature")); err != nil {
+		return nil, err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode stripe event: %w", err)
+	}
+
+	session := event.Data.Object
+	switch event.Type {
+	case "checkout.session.completed":
+		// Delayed payment methods complete the session before the money
+		// arrives; async_payment_succeeded follows once it does.
+		if session.PaymentStatus != "paid" {
+			return nil, nil
+		}
+	case "checkout.session.async_payment_succeeded":
+	default:
+		return nil, nil
+	}
+	if session.PaymentLink == "" {
+		return nil, nil
+	}
+
+	paymentID := session.PaymentIntent
+	if paymentID == "" {
+		paymentID = session.ID
+	}
+	return &Event{
+		LinkID:     session.PaymentLink,
+		Reference:  session.Metadata["reference"],
+		PaymentID:  paymentID,
+		AmountPaid: session.AmountTotal,
+	}, nil
+}
+
+// verifySignature checks a Stripe-Signature header of the form
+// "t=<unix time>,v1=<hex hmac>[,v1=...]", where each v1 is the HMAC-SHA256 of
+// "<t>.<payload>" keyed with the webhook secret.
+func (s *Stripe) verifySignature(payload []byte, header string) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := s.clock.Now().Sub(time.Unix(unix, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	for _, sig := range signatures {
+		decoded, err := hex.DecodeString(sig)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,163 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+)
+
+func TestStripe_CreateLink(t *testing.T) {
+	var linkForm map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prices", func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "sk_test_1" {
+			t.Errorf("basic auth user = %q", user)
+		}
+		if r.Header.Get("Idempotency-Key") != "quote-1-price" {
+			t.Errorf("Idempotency-Key = %q", r.Header.Get("Idempotency-Key"))
+		}
+		_ = r.ParseForm()
+		if r.Form.Get("unit_amount") != "125050" || r.Form.Get("currency") != "usd" || r.Form.Get("product_data[name]") != "Deposit for quote Q-1" {
+			t.Errorf("unexpected price form %v", r.Form)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "price_1"})
+	})
+	mux.HandleFunc("/payment_links", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		linkForm = map[string]string{}
+		for k := range r.Form {
+			linkForm[k] = r.Form.Get(k)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "plink_1", "url": "https://buy.stripe.com/test_1"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	s := NewStripe(Config{StripeSecretKey: "sk_test_1", StripeWebhookSecret: "whsec_1", StripeAPIURL: server.URL}, server.Client(), nil)
+	link, err := s.CreateLink(context.Background(), &LinkRequest{
+		Reference:   "quote-1",
+		Description: "Deposit for quote Q-1",
+		Amount:      125050,
+		Currency:    "USD",
+	})
+	if err != nil {
+		t.Fatalf("CreateLink() error = %v", err)
+	}
+	if link.ID != "plink_1" || link.URL != "https://buy.stripe.com/test_1" {
+		t.Errorf("unexpected link %+v", link)
+	}
+	if linkForm["line_items[0][price]"] != "price_1" || linkForm["metadata[reference]"] != "quote-1" {
+		t.Errorf("unexpected payment link form %v", linkForm)
+	}
+}
+
+func TestStripe_CreateLinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Invalid currency: xyz"}}`))
+	}))
+	defer server.Close()
+
+	s := NewStripe(Config{StripeSecretKey: "sk_test_1", StripeAPIURL: server.URL}, server.Client(), nil)
+	_, err := s.CreateLink(context.Background(), &LinkRequest{Reference: "quote-1", Description: "Quote", Amount: 100, Currency: "XYZ"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid currency") {
+		t.Errorf("expected Stripe's error message, got %v", err)
+	}
+
+	if _, err := s.CreateLink(context.Background(), &LinkRequest{Reference: "quote-1", Description: "Quote", Currency: "USD"}); err == nil {
+		t.Error("expected error for a zero amount")
+	}
+}
+
+func signStripe(secret string, at time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.%s", at.Unix(), payload)
+	return fmt.Sprintf("t=%d,v1=%s", at.Unix(), hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestStripe_ParseWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewStripe(Config{StripeSecretKey: "sk_test_1", StripeWebhookSecret: "whsec_1"}, nil, clock.NewMock(now))
+
+	session := func(eventType, status string) []byte {
+		return []byte(fmt.Sprintf(`{"type":%q,"data":{"object":{"id":"cs_1","payment_link":"plink_1","payment_status":%q,"payment_intent":"pi_1","amount_total":125050,"metadata":{"reference":"quote-1"}}}}`, eventType, status))
+	}
+	header := func(sig string) http.Header {
+		h := http.Header{}
+		h.Set("Stripe-Signature", sig)
+		return h
+	}
+
+	paid := session("checkout.session.completed", "paid")
+	event, err := s.ParseWebhook(paid, header(signStripe("whsec_1", now, paid)))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event == nil || event.LinkID != "plink_1" || event.Reference != "quote-1" || event.PaymentID != "pi_1" || event.AmountPaid != 125050 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// Sessions still waiting on a delayed payment complete nothing yet.
+	unpaid := session("checkout.session.completed", "unpaid")
+	if event, err := s.ParseWebhook(unpaid, header(signStripe("whsec_1", now, unpaid))); err != nil || event != nil {
+		t.Errorf("expected no event for an unpaid session, got %+v, %v", event, err)
+	}
+	async := session("checkout.session.async_payment_succeeded", "paid")
+	if event, err := s.ParseWebhook(async, header(signStripe("whsec_1", now, async))); err != nil || event == nil {
+		t.Errorf("expected an event once a delayed payment succeeds, got %+v, %v", event, err)
+	}
+
+	other := []byte(`{"type":"customer.created","data":{"object":{"id":"cus_1"}}}`)
+	if event, err := s.ParseWebhook(other, header(signStripe("whsec_1", now, other))); err != nil || event != nil {
+		t.Errorf("expected other events to be ignored, got %+v, %v", event, err)
+	}
+}
+
+func TestStripe_ParseWebhookSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewStripe(Config{StripeSecretKey: "sk_test_1", StripeWebhookSecret: "whsec_1"}, nil, clock.NewMock(now))
+	payload := []byte(`{"type":"checkout.session.completed"}`)
+
+	tests := map[string]string{
+		"missing":      "",
+		"wrong secret": signStripe("whsec_other", now, payload),
+		"too old":      signStripe("whsec_1", now.Add(-10*time.Minute), payload),
+		"malformed":    "t=abc,v1=zz",
+	}
+	for name, sig := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("Stripe-Signature", sig)
+			if _, err := s.ParseWebhook(payload, h); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if p, err := New(Config{}); p != nil || err != nil {
+		t.Errorf("expected no processor when disabled, got %v, %v", p, err)
+	}
+	if _, err := New(Config{Provider: ProviderStripe, StripeSecretKey: "sk"}); err == nil {
+		t.Error("expected error without a webhook secret")
+	}
+	if _, err := New(Config{Provider: "paypal"}); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	p, err := New(Config{Provider: "Stripe", StripeSecretKey: "sk", StripeWebhookSecret: "whsec"})
+	if err != nil || p.Name() != ProviderStripe {
+		t.Errorf("expected stripe processor, got %v, %v", p, err)
+	}
+}
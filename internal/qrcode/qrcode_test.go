@@ -0,0 +1,229 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// "HELLO WORLD" as a 1-M code, from the ISO/IEC 18004 worked example.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("rsRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	tests := map[int]int{
+		0: 0b101010000010010,
+		1: 0b101000100100101,
+		5: 0b100000011001110,
+		4: 0b100010111111001,
+		7: 0b100101010100000,
+	}
+	for mask, want := range tests {
+		if got := formatBits(mask); got != want {
+			t.Errorf("formatBits(%d) = %015b, want %015b", mask, got, want)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	if got, want := versionBits(7), 0b000111110010010100; got != want {
+		t.Errorf("versionBits(7) = %018b, want %018b", got, want)
+	}
+	if got, want := versionBits(10), 0b001010010011010011; got != want {
+		t.Errorf("versionBits(10) = %018b, want %018b", got, want)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	want := []int{1: 14, 2: 26, 3: 42, 4: 62, 5: 84, 6: 106, 7: 122, 8: 152, 9: 180, 10: 213}
+	for v := 1; v < len(versions); v++ {
+		info := versions[v]
+		total := dataCodewords(v) + info.ecPerBlock*len(info.blocks)
+		size := 17 + 4*v
+		if got := capacity(v); got != want[v] {
+			t.Errorf("capacity(%d) = %d, want %d", v, got, want[v])
+		}
+		// Every data module must be used except the remainder bits.
+		code := encode(v, nil)
+		free := 0
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				if !code.function[y][x] {
+					free++
+				}
+			}
+		}
+		if rem := free - total*8; rem < 0 || rem > 7 {
+			t.Errorf("version %d has %d data modules for %d codewords", v, free, total)
+		}
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	tests := []string{
+		"",
+		"hello",
+		"otpauth://totp/QuickQuote:admin%40example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=QuickQuote",
+		strings.Repeat("x", 213),
+	}
+	for _, text := range tests {
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) error = %v", len(text), err)
+		}
+		if got := decode(t, code); got != text {
+			t.Errorf("decode() = %q, want %q", got, text)
+		}
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("x", 214)); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Encode() error = %v, want ErrTooLong", err)
+	}
+}
+
+func TestEncode_FunctionPatterns(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.Version != 1 || code.Size != 21 {
+		t.Fatalf("version %d size %d, want 1 and 21", code.Version, code.Size)
+	}
+	// Finder pattern rows: dark ring, light ring, 3x3 center, then separator.
+	rows := []string{"#######.", "#.....#.", "#.###.#."}
+	for y, row := range rows {
+		for x, want := range row {
+			if code.Dark(x, y) != (want == '#') {
+				t.Errorf("module (%d,%d) dark = %v", x, y, code.Dark(x, y))
+			}
+		}
+	}
+	if !code.Dark(8, code.Size-8) {
+		t.Error("dark module is light")
+	}
+}
+
+func TestSVG(t *testing.T) {
+	svg, err := SVG("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 29 29"`) {
+		t.Errorf("SVG() = %.80s", svg)
+	}
+	if !strings.Contains(svg, "M4 4h1v1h-1z") {
+		t.Error("SVG() is missing the top-left finder module")
+	}
+}
+
+// decode reads a code back: it finds the mask from the format bits, reads
+// the codewords, checks each block's error correction and parses the data.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+
+	var format int
+	for i := 0; i <= 5; i++ {
+		format |= b2i(c.Dark(8, i)) << i
+	}
+	format |= b2i(c.Dark(8, 7))<<6 | b2i(c.Dark(8, 8))<<7 | b2i(c.Dark(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		format |= b2i(c.Dark(14-i, 8)) << i
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("invalid format bits %015b", format)
+	}
+
+	plain := &Code{Version: c.Version, Size: c.Size, modules: make([][]bool, c.Size), function: c.function}
+	for y := range plain.modules {
+		plain.modules[y] = append([]bool(nil), c.modules[y]...)
+	}
+	plain.applyMask(mask)
+
+	var bits bitBuffer
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.Size; vert++ {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				if x := right - j; !c.function[y][x] {
+					bits = append(bits, plain.modules[y][x])
+				}
+			}
+		}
+	}
+	codewords := bits[:len(bits)/8*8].bytes()
+
+	info := versions[c.Version]
+	blocks := make([][]byte, len(info.blocks))
+	pos := 0
+	for i := 0; pos < dataCodewords(c.Version); i++ {
+		for b, n := range info.blocks {
+			if i < n {
+				blocks[b] = append(blocks[b], codewords[pos])
+				pos++
+			}
+		}
+	}
+	divisor := rsDivisor(info.ecPerBlock)
+	var data []byte
+	for b, block := range blocks {
+		var ec []byte
+		for i := 0; i < info.ecPerBlock; i++ {
+			ec = append(ec, codewords[pos+i*len(blocks)+b])
+		}
+		if want := rsRemainder(block, divisor); !bytes.Equal(ec, want) {
+			t.Fatalf("block %d error correction = %v, want %v", b, ec, want)
+		}
+		data = append(data, block...)
+	}
+
+	var stream bitBuffer
+	stream.append(0, 0)
+	for _, d := range data {
+		stream.append(int(d), 8)
+	}
+	read := func(n int) int {
+		v := 0
+		for _, bit := range stream[:n] {
+			v = v<<1 | b2i(bit)
+		}
+		stream = stream[n:]
+		return v
+	}
+	if mode := read(4); mode != 0b0100 {
+		t.Fatalf("mode = %04b, want byte mode", mode)
+	}
+	out := make([]byte, read(countBits(c.Version)))
+	for i := range out {
+		out[i] = byte(read(8))
+	}
+	return string(out)
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,436 @@
+package quotepdf
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// LineItem is a single priced entry on a quote.
+type LineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+}
+
+// Amount returns the line total.
+func (li LineItem) Amount() float64 {
+	return li.Quantity * li.UnitPrice
+}
+
+// Signature is the customer's acceptance of the quote.
+type Signature struct {
+	SignerName string
+	Image      []byte // PNG of the drawn signature; empty if only typed
+	IP         string
+	SignedAt   time.Time
+}
+
+// Section is a headed block of fixed text from the quote template, such as
+// payment terms.
+type Section struct {
+	Heading string
+	Body    string
+}
+
+// Document holds everything needed to render a quote PDF.
+type Document struct {
+	BusinessName  string
+	QuoteNumber   string
+	IssuedAt      time.Time
+	ValidUntil    time.Time
+	Currency      string
+	CustomerName  string
+	CustomerPhone string
+	CustomerEmail string
+	Company       string
+	ProjectType   string
+	Timeline      string
+	LineItems     []LineItem
+	TaxRate       float64 // Percent applied to the subtotal
+	Notes         string
+	Summary       string
+	Sections      []Section  // From the quote template for the project type
+	Assumptions   string     // What the price assumes, from the quote template
+	Signature     *Signature // Set once the customer has signed
+}
+
+// Subtotal returns the sum of all line item amounts.
+func (d *Document) Subtotal() float64 {
+	var total float64
+	for _, li := range d.LineItems {
+		total += li.Amount()
+	}
+	return total
+}
+
+// Tax returns the tax on the subtotal, rounded to the cent.
+func (d *Document) Tax() float64 {
+	return math.Round(d.Subtotal()*d.TaxRate) / 100
+}
+
+// Total returns the amount due.
+func (d *Document) Total() float64 {
+	return math.Round((d.Subtotal()+d.Tax())*100) / 100
+}
+
+// lineItemPattern matches bullet lines that end in a single amount with a
+// currency symbol or code before or after it, e.g. "- Backend API
+// development: $12,500", "* Design - 3.000,00 €" or "1. Hosting: CHF 1'200".
+var lineItemPattern = regexp.MustCompile(`^\s*(?:[-*•]|\d+[.)])\s+(.+?)[\s:–—-]+(?:(` + currencyPattern + `)\s?)?(` + amountPattern + `)(?:\s?(` + currencyPattern + `))?\s*$`)
+
+// currencyPattern matches a currency symbol or a common currency code.
+const currencyPattern = `[$€£¥₹]|R\$|kr|zł|USD|EUR|GBP|CAD|AUD|NZD|CHF|JPY|CNY|INR|MXN|BRL|ARS|COP|CLP|SEK|NOK|DKK|PLN|CZK|HUF|ZAR|SGD|HKD|ILS|TRY`
+
+// amountPattern matches an amount with its thousands grouped by ",", ".",
+// spaces or apostrophes, or ungrouped, and up to two decimals after "." or ",".
+const amountPattern = `\d{1,3}(?:[,. '’\x{00A0}\x{202F}]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?`
+
+// rangeEndPattern matches a description that ends in an amount, left over
+// when a line gives a price range such as "€100 - €200".
+var rangeEndPattern = regexp.MustCompile(`(?:(?:` + currencyPattern + `)\s?(?:` + amountPattern + `)|(?:` + amountPattern + `)\s?(?:` + currencyPattern + `))$`)
+
+// ParseLineItems extracts priced line items from a generated quote summary.
+// Amounts may use either "." or "," as the decimal separator, so quotes
+// written for any locale are read. Lines with price ranges or without an
+// amount are ignored.
+func ParseLineItems(summary string) []LineItem {
+	var items []LineItem
+	for _, line := range strings.Split(summary, "\n") {
+		m := lineItemPattern.FindStringSubmatch(line)
+		if m == nil || (m[2] == "" && m[4] == "") {
+			continue
+		}
+		price, ok := parseAmount(m[3])
+		if !ok {
+			continue
+		}
+		desc := strings.TrimSpace(stripMarkdown(m[1]))
+		desc = strings.TrimRight(desc, ":–—- ")
+		if desc == "" || strings.Contains(desc, "$") || rangeEndPattern.MatchString(desc) {
+			continue
+		}
+		items = append(items, LineItem{Description: desc, Quantity: 1, UnitPrice: price})
+	}
+	return items
+}
+
+// parseAmount parses an amount matched by amountPattern. A lone separator
+// followed by three digits groups thousands, so "12,500" and "12.500" are
+// both twelve thousand five hundred.
+func parseAmount(s string) (float64, bool) {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '\'' || r == '’' {
+			return -1
+		}
+		return r
+	}, s)
+
+	decimal := -1
+	lastDot, lastComma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = max(lastDot, lastComma)
+	case lastDot >= 0 || lastComma >= 0:
+		i := max(lastDot, lastComma)
+		if strings.Count(s, s[i:i+1]) == 1 && len(s)-i-1 != 3 {
+			decimal = i
+		}
+	}
+
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case i == decimal:
+			b.WriteByte('.')
+		}
+	}
+	amount, err := strconv.ParseFloat(b.String(), 64)
+	return amount, err == nil
+}
+
+// stripMarkdown removes the inline markdown the quote generator emits.
+func stripMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "**", "")
+	s = strings.ReplaceAll(s, "__", "")
+	s = strings.TrimLeft(s, "# ")
+	return s
+}
+
+// FormatMoney formats an amount with thousands separators and a currency
+// symbol, to the number of decimal places of the currency's minor unit.
+func FormatMoney(amount float64, currency string) string {
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	// Round as payment links do, halves away from zero
+	amount = domain.RoundToMinorUnit(amount, currency)
+	s := strconv.FormatFloat(amount, 'f', domain.CurrencyScale(currency), 64)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+	return sign + currencySymbol(currency) + b.String()
+}
+
+// symbolPrinter renders currency symbols as English speakers know them,
+// e.g. "CA$" for Canadian dollars.
+var symbolPrinter = message.NewPrinter(language.English)
+
+// currencySymbol returns the display prefix for a currency code: its symbol,
+// or the code and a space when it has none the PDF fonts can show.
+func currencySymbol(code string) string {
+	code = domain.NormalizeCurrency(code)
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return code + " "
+	}
+	symbol := symbolPrinter.Sprint(currency.Symbol(unit))
+	if symbol == code {
+		return code + " "
+	}
+	for _, r := range symbol {
+		if r > 0xff && r != '€' {
+			return code + " "
+		}
+	}
+	return symbol
+}
+
+// Render produces the PDF bytes for the document.
+func Render(doc *Document) []byte {
+	w := newPDFWriter()
+
+	// Header: business name and quote metadata.
+	w.space(20)
+	w.text(pageMargin, w.y, fontBold, 20, doc.BusinessName)
+	w.textRight(pageWidth-pageMargin, w.y, fontBold, 20, "QUOTE")
+	w.space(18)
+	w.textRight(pageWidth-pageMargin, w.y, fontRegular, 10, "Quote #"+doc.QuoteNumber)
+	w.space(14)
+	w.textRight(pageWidth-pageMargin, w.y, fontRegular, 10, "Issued "+doc.IssuedAt.Format("January 2, 2006"))
+	w.space(14)
+	w.textRight(pageWidth-pageMargin, w.y, fontRegular, 10, "Valid until "+doc.ValidUntil.Format("January 2, 2006"))
+	w.space(12)
+	w.line(w.y, 0.6)
+	w.space(10)
+
+	// Customer and project details.
+	w.paragraph(fontBold, 11, "Prepared for")
+	for _, v := range []string{doc.CustomerName, doc.Company, doc.CustomerPhone, doc.CustomerEmail} {
+		if v != "" {
+			w.paragraph(fontRegular, 10, v)
+		}
+	}
+	if doc.ProjectType != "" || doc.Timeline != "" {
+		w.space(8)
+		w.paragraph(fontBold, 11, "Project")
+		if doc.ProjectType != "" {
+			w.paragraph(fontRegular, 10, "Type: "+doc.ProjectType)
+		}
+		if doc.Timeline != "" {
+			w.paragraph(fontRegular, 10, "Timeline: "+doc.Timeline)
+		}
+	}
+	w.space(14)
+
+	renderLineItems(w, doc)
+	renderNotes(w, doc.Notes)
+	renderSummary(w, doc.Summary)
+	for _, section := range doc.Sections {
+		renderTextBlock(w, section.Heading, section.Body)
+	}
+	renderTextBlock(w, "Assumptions", doc.Assumptions)
+	renderSignature(w, doc.Signature)
+
+	// Validity footer.
+	w.space(16)
+	w.ensureSpace(30)
+	w.line(w.y, 0.8)
+	w.paragraph(fontRegular, 9, fmt.Sprintf(
+		"This quote is valid until %s. Estimates are based on the requirements discussed and may change if the scope changes.",
+		doc.ValidUntil.Format("January 2, 2006"),
+	))
+
+	return w.bytes()
+}
+
+// renderLineItems draws the itemized table and totals.
+func renderLineItems(w *pdfWriter, doc *Document) {
+	const (
+		qtyRight    = pageWidth - pageMargin - 170
+		priceRight  = pageWidth - pageMargin - 85
+		amountRight = pageWidth - pageMargin
+		descWidth   = qtyRight - pageMargin - 40
+	)
+
+	w.ensureSpace(40)
+	w.space(14)
+	w.text(pageMargin, w.y, fontBold, 10, "Description")
+	w.textRight(qtyRight, w.y, fontBold, 10, "Qty")
+	w.textRight(priceRight, w.y, fontBold, 10, "Unit price")
+	w.textRight(amountRight, w.y, fontBold, 10, "Amount")
+	w.space(6)
+	w.line(w.y, 0.6)
+
+	if len(doc.LineItems) == 0 {
+		w.paragraph(fontRegular, 10, "See the project summary below for estimate details.")
+		w.space(6)
+		return
+	}
+
+	for _, li := range doc.LineItems {
+		lines := wrapText(li.Description, fontRegular, 10, descWidth)
+		w.ensureSpace(float64(len(lines))*14 + 4)
+		w.space(14)
+		w.text(pageMargin, w.y, fontRegular, 10, lines[0])
+		w.textRight(qtyRight, w.y, fontRegular, 10, strconv.FormatFloat(li.Quantity, 'f', -1, 64))
+		w.textRight(priceRight, w.y, fontRegular, 10, FormatMoney(li.UnitPrice, doc.Currency))
+		w.textRight(amountRight, w.y, fontRegular, 10, FormatMoney(li.Amount(), doc.Currency))
+		for _, extra := range lines[1:] {
+			w.space(14)
+			w.text(pageMargin, w.y, fontRegular, 10, extra)
+		}
+	}
+
+	w.space(8)
+	w.line(w.y, 0.6)
+	w.ensureSpace(40)
+	w.space(16)
+	w.textRight(priceRight, w.y, fontRegular, 10, "Subtotal")
+	w.textRight(amountRight, w.y, fontRegular, 10, FormatMoney(doc.Subtotal(), doc.Currency))
+	if doc.TaxRate > 0 {
+		w.space(16)
+		w.textRight(priceRight, w.y, fontRegular, 10, "Tax ("+strconv.FormatFloat(doc.TaxRate, 'f', -1, 64)+"%)")
+		w.textRight(amountRight, w.y, fontRegular, 10, FormatMoney(doc.Tax(), doc.Currency))
+	}
+	w.space(16)
+	w.textRight(priceRight, w.y, fontBold, 11, "Total")
+	w.textRight(amountRight, w.y, fontBold, 11, FormatMoney(doc.Total(), doc.Currency))
+	w.space(6)
+}
+
+// renderNotes writes the notes staff added to the quote.
+func renderNotes(w *pdfWriter, notes string) {
+	renderTextBlock(w, "Notes", notes)
+}
+
+// renderTextBlock writes a heading and its text, keeping the text's line
+// breaks. Empty text writes nothing.
+func renderTextBlock(w *pdfWriter, heading, text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	w.space(16)
+	w.paragraph(fontBold, 12, heading)
+	w.space(4)
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			w.space(6)
+			continue
+		}
+		w.paragraph(fontRegular, 10, line)
+	}
+}
+
+// renderSignature writes the customer's acceptance: their drawn signature,
+// or their typed name when they didn't draw one, above who signed, when and
+// from where.
+func renderSignature(w *pdfWriter, sig *Signature) {
+	if sig == nil {
+		return
+	}
+	const (
+		maxWidth  = 200.0
+		maxHeight = 60.0
+	)
+
+	w.ensureSpace(maxHeight + 90)
+	w.space(20)
+	w.paragraph(fontBold, 12, "Acceptance")
+	w.space(8)
+
+	drawn := false
+	if len(sig.Image) > 0 {
+		if img, err := png.Decode(bytes.NewReader(sig.Image)); err == nil && img.Bounds().Dx() > 0 && img.Bounds().Dy() > 0 {
+			width, height := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+			scale := math.Min(maxWidth/width, maxHeight/height)
+			w.space(height * scale)
+			w.image(pageMargin, w.y, width*scale, height*scale, newPDFImage(img))
+			drawn = true
+		}
+	}
+	if !drawn {
+		w.paragraph(fontBold, 16, sig.SignerName)
+	}
+
+	w.space(4)
+	w.lineBetween(pageMargin, pageMargin+maxWidth, w.y, 0)
+	w.paragraph(fontRegular, 9, "Signed by "+sig.SignerName+" on "+sig.SignedAt.UTC().Format("January 2, 2006 at 15:04 MST"))
+	if sig.IP != "" {
+		w.paragraph(fontRegular, 9, "From IP address "+sig.IP)
+	}
+}
+
+// renderSummary writes the generated quote narrative, turning markdown
+// headings into bold lines and bullets into indented paragraphs.
+func renderSummary(w *pdfWriter, summary string) {
+	if strings.TrimSpace(summary) == "" {
+		return
+	}
+
+	w.space(16)
+	w.paragraph(fontBold, 12, "Project summary")
+	w.space(4)
+
+	for _, raw := range strings.Split(summary, "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			w.space(6)
+		case strings.HasPrefix(line, "#"):
+			w.space(4)
+			w.paragraph(fontBold, 11, stripMarkdown(line))
+		case strings.HasPrefix(line, "**") && strings.HasSuffix(line, "**"):
+			w.space(4)
+			w.paragraph(fontBold, 11, stripMarkdown(line))
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			w.ensureSpace(14)
+			w.text(pageMargin+8, w.y-14, fontRegular, 10, "•")
+			w.paragraphAt(pageMargin+20, contentWidth-20, fontRegular, 10, stripMarkdown(line[2:]))
+		default:
+			w.paragraph(fontRegular, 10, stripMarkdown(line))
+		}
+	}
+}
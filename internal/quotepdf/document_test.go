@@ -0,0 +1,205 @@
+package quotepdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLineItems(t *testing.T) {
+	summary := `**Project Overview**
+A customer portal.
+
+**Budget Considerations**
+- **Discovery & design**: $3,000
+- Backend API development - $12,500.50
+* Mobile app – $8,000
+1. QA and launch: $1,200
+- Hosting estimate: $100 - $200 per month
+- Ongoing support to be discussed`
+
+	items := ParseLineItems(summary)
+	if len(items) != 4 {
+		t.Fatalf("expected 4 line items, got %d: %+v", len(items), items)
+	}
+
+	expected := []LineItem{
+		{Description: "Discovery & design", Quantity: 1, UnitPrice: 3000},
+		{Description: "Backend API development", Quantity: 1, UnitPrice: 12500.50},
+		{Description: "Mobile app", Quantity: 1, UnitPrice: 8000},
+		{Description: "QA and launch", Quantity: 1, UnitPrice: 1200},
+	}
+	for i, want := range expected {
+		if items[i] != want {
+			t.Errorf("item %d: expected %+v, got %+v", i, want, items[i])
+		}
+	}
+}
+
+func TestParseLineItems_Locales(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected float64
+	}{
+		{"- Diseño web: 3.000 €", 3000},
+		{"- Développement : 12 500,50 €", 12500.50},
+		{"- Entwicklung – 1.234,56 EUR", 1234.56},
+		{"- Hosting: CHF 1'200", 1200},
+		{"- Implantação: R$ 8.000,00", 8000},
+		{"- Design: £2,500.75", 2500.75},
+		{"- Phase 2: 3000 kr", 3000},
+		{"- Support: 49,9 €", 49.9},
+	}
+	for _, tt := range tests {
+		items := ParseLineItems(tt.line)
+		if len(items) != 1 || items[0].UnitPrice != tt.expected {
+			t.Errorf("ParseLineItems(%q) = %+v, expected a price of %v", tt.line, items, tt.expected)
+		}
+	}
+
+	for _, line := range []string{
+		"- Hébergement : 100 € - 200 €",
+		"- Deliver the MVP 2",
+		"- Phase 2 starts in 3 weeks",
+	} {
+		if items := ParseLineItems(line); len(items) != 0 {
+			t.Errorf("ParseLineItems(%q) = %+v, expected no line items", line, items)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		expected string
+	}{
+		{0, "USD", "$0.00"},
+		{999.5, "USD", "$999.50"},
+		{1234567.891, "USD", "$1,234,567.89"},
+		{-1500, "USD", "-$1,500.00"},
+		{250, "GBP", "£250.00"},
+		{1234.5, "JPY", "¥1,235"},
+		{1500, "CAD", "CA$1,500.00"},
+		{99.5, "AUD", "A$99.50"},
+		{12.3456, "KWD", "KWD 12.346"},
+		{10, "INR", "INR 10.00"},
+		{10, "", "$10.00"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatMoney(tt.amount, tt.currency); got != tt.expected {
+			t.Errorf("FormatMoney(%v, %q) = %q, expected %q", tt.amount, tt.currency, got, tt.expected)
+		}
+	}
+}
+
+func TestDocument_Totals(t *testing.T) {
+	doc := &Document{LineItems: []LineItem{
+		{Description: "a", Quantity: 2, UnitPrice: 100},
+		{Description: "b", Quantity: 1, UnitPrice: 50.25},
+	}}
+
+	if got := doc.Subtotal(); got != 250.25 {
+		t.Errorf("expected subtotal 250.25, got %v", got)
+	}
+	if got := doc.Total(); got != 250.25 {
+		t.Errorf("expected total 250.25, got %v", got)
+	}
+
+	doc.TaxRate = 8
+	if got := doc.Tax(); got != 20.02 {
+		t.Errorf("expected tax 20.02, got %v", got)
+	}
+	if got := doc.Total(); got != 270.27 {
+		t.Errorf("expected total with tax 270.27, got %v", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	issued := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	doc := &Document{
+		BusinessName: "Acme (Software)",
+		QuoteNumber:  "Q-ABCDEF12",
+		IssuedAt:     issued,
+		ValidUntil:   issued.AddDate(0, 0, 30),
+		Currency:     "USD",
+		CustomerName: "Jane Doe",
+		LineItems:    []LineItem{{Description: "Build", Quantity: 1, UnitPrice: 5000}},
+		Summary:      strings.Repeat("- Requirement line that is long enough to wrap across the page width\n", 80),
+	}
+
+	data := Render(doc)
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) {
+		t.Error("expected PDF header")
+	}
+	if !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Error("expected PDF trailer")
+	}
+	if !bytes.Contains(data, []byte(`(Acme \(Software\))`)) {
+		t.Error("expected escaped business name in content stream")
+	}
+	if !bytes.Contains(data, []byte("Valid until March 31, 2025")) {
+		t.Error("expected validity date")
+	}
+	if !bytes.Contains(data, []byte("$5,000.00")) {
+		t.Error("expected formatted line item amount")
+	}
+	if !bytes.Contains(data, []byte("/Count 3")) && !bytes.Contains(data, []byte("/Count 2")) {
+		t.Error("expected long summary to span multiple pages")
+	}
+}
+
+func TestRender_Signature(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 10))
+	img.Set(5, 5, color.Black)
+	var drawn bytes.Buffer
+	if err := png.Encode(&drawn, img); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	signedAt := time.Date(2025, 3, 2, 15, 4, 0, 0, time.UTC)
+	doc := &Document{
+		BusinessName: "Acme",
+		QuoteNumber:  "Q-ABCDEF12",
+		IssuedAt:     signedAt,
+		ValidUntil:   signedAt.AddDate(0, 0, 30),
+		Currency:     "USD",
+	}
+
+	doc.Signature = &Signature{SignerName: "Jane Doe", IP: "203.0.113.7", SignedAt: signedAt}
+	typed := Render(doc)
+	if !bytes.Contains(typed, []byte("Signed by Jane Doe on March 2, 2025 at 15:04 UTC")) || !bytes.Contains(typed, []byte("203.0.113.7")) {
+		t.Error("expected signer, time and IP")
+	}
+	if bytes.Contains(typed, []byte("/XObject")) {
+		t.Error("expected no image for a typed signature")
+	}
+
+	doc.Signature.Image = drawn.Bytes()
+	data := Render(doc)
+	for _, want := range []string{"/XObject << /Im1", "/Subtype /Image /Width 40 /Height 10", "/Im1 Do"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("expected %q in drawn signature PDF", want)
+		}
+	}
+	if !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Error("expected PDF trailer")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("the quick brown fox jumps over the lazy dog", fontRegular, 10, 60)
+	if len(lines) < 2 {
+		t.Fatalf("expected text to wrap, got %v", lines)
+	}
+	for _, line := range lines {
+		if textWidth(line, fontRegular, 10) > 60 && strings.Contains(line, " ") {
+			t.Errorf("line %q exceeds max width", line)
+		}
+	}
+}
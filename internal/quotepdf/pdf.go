@@ -0,0 +1,270 @@
+// Package quotepdf renders generated quotes into branded PDF documents.
+package quotepdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Page geometry in PDF points (US Letter).
+const (
+	pageWidth    = 612.0
+	pageHeight   = 792.0
+	pageMargin   = 50.0
+	contentWidth = pageWidth - 2*pageMargin
+)
+
+// Font resource names used in content streams.
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// helveticaWidths holds glyph widths (per 1000 units of font size) for the
+// printable ASCII range of the standard Helvetica font, starting at space.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space-/
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0-?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @-O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P-_
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // `-o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p-~
+}
+
+// textWidth estimates the rendered width of s in points. Bold text is
+// approximated as slightly wider than regular.
+func textWidth(s string, font string, size float64) float64 {
+	total := 0
+	for _, r := range s {
+		if r >= 32 && int(r-32) < len(helveticaWidths) {
+			total += helveticaWidths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	w := float64(total) * size / 1000
+	if font == fontBold {
+		w *= 1.05
+	}
+	return w
+}
+
+// wrapText splits s into lines no wider than maxWidth.
+func wrapText(s string, font string, size, maxWidth float64) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		candidate := line + " " + word
+		if textWidth(candidate, font, size) > maxWidth {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line = candidate
+	}
+	return append(lines, line)
+}
+
+// escapeText encodes s as a PDF literal string body using WinAnsi encoding.
+// Characters outside Latin-1 are replaced with '?'.
+func escapeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '–' || r == '—':
+			b.WriteByte('-')
+		case r == '‘' || r == '’':
+			b.WriteByte('\'')
+		case r == '“' || r == '”':
+			b.WriteByte('"')
+		case r == '•':
+			b.WriteString("\\225")
+		case r == '€':
+			b.WriteString("\\200")
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfImage is an image XObject: 8-bit RGB samples, Flate compressed.
+type pdfImage struct {
+	width, height int
+	data          []byte
+}
+
+// newPDFImage converts img to RGB, flattening any transparency onto white.
+func newPDFImage(img image.Image) *pdfImage {
+	bounds := img.Bounds()
+	var raw bytes.Buffer
+	zw := zlib.NewWriter(&raw)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Colors are alpha-premultiplied, so adding the uncovered
+			// share of white composites onto a white page.
+			white := 0xffff - a
+			row = append(row, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+		_, _ = zw.Write(row)
+	}
+	_ = zw.Close()
+	return &pdfImage{width: bounds.Dx(), height: bounds.Dy(), data: raw.Bytes()}
+}
+
+// pdfWriter lays out text onto pages and serializes a minimal PDF 1.4 file
+// using the built-in Helvetica fonts, so no font embedding is required.
+type pdfWriter struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	y       float64
+	images  []*pdfImage
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+// newPage starts a new page and resets the cursor to the top margin.
+func (w *pdfWriter) newPage() {
+	w.current = &bytes.Buffer{}
+	w.pages = append(w.pages, w.current)
+	w.y = pageHeight - pageMargin
+}
+
+// ensureSpace starts a new page if fewer than height points remain.
+func (w *pdfWriter) ensureSpace(height float64) {
+	if w.y-height < pageMargin {
+		w.newPage()
+	}
+}
+
+// text draws s with its baseline at (x, y).
+func (w *pdfWriter) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(w.current, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapeText(s))
+}
+
+// textRight draws s right-aligned so that it ends at x.
+func (w *pdfWriter) textRight(x, y float64, font string, size float64, s string) {
+	w.text(x-textWidth(s, font, size), y, font, size, s)
+}
+
+// line draws a horizontal rule at y across the content area.
+func (w *pdfWriter) line(y float64, gray float64) {
+	w.lineBetween(pageMargin, pageWidth-pageMargin, y, gray)
+}
+
+// lineBetween draws a horizontal rule at y from x1 to x2.
+func (w *pdfWriter) lineBetween(x1, x2, y float64, gray float64) {
+	fmt.Fprintf(w.current, "%.2f G 0.75 w %.2f %.2f m %.2f %.2f l S 0 G\n",
+		gray, x1, y, x2, y)
+}
+
+// image draws img scaled to width by height points with its lower left
+// corner at (x, y).
+func (w *pdfWriter) image(x, y, width, height float64, img *pdfImage) {
+	w.images = append(w.images, img)
+	fmt.Fprintf(w.current, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, x, y, len(w.images))
+}
+
+// paragraph writes wrapped text at the cursor and advances it.
+func (w *pdfWriter) paragraph(font string, size float64, s string) {
+	w.paragraphAt(pageMargin, contentWidth, font, size, s)
+}
+
+// paragraphAt writes wrapped text starting at x and advances the cursor.
+func (w *pdfWriter) paragraphAt(x, width float64, font string, size float64, s string) {
+	leading := size * 1.4
+	for _, line := range wrapText(s, font, size, width) {
+		w.ensureSpace(leading)
+		w.y -= leading
+		w.text(x, w.y, font, size, line)
+	}
+}
+
+// space advances the cursor by h points.
+func (w *pdfWriter) space(h float64) {
+	w.y -= h
+}
+
+// bytes serializes the document.
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Object layout: 1 catalog, 2 page tree, 3-4 fonts, then a page and a
+	// content stream object per page, then the images.
+	const firstPageObj = 5
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+	firstImageObj := firstPageObj + 2*len(w.pages)
+	var xobjects string
+	if len(w.images) > 0 {
+		refs := make([]string, len(w.images))
+		for i := range w.images {
+			refs[i] = fmt.Sprintf("/Im%d %d 0 R", i+1, firstImageObj+i)
+		}
+		xobjects = " /XObject << " + strings.Join(refs, " ") + " >>"
+	}
+
+	writeObj("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range w.pages {
+		contentObj := firstPageObj + 2*i + 1
+		writeObj(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >>%s >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, xobjects, contentObj,
+		))
+		writeObj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.String()))
+	}
+
+	for _, img := range w.images {
+		writeObj(fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			img.width, img.height, len(img.data), img.data,
+		))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
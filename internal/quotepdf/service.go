@@ -0,0 +1,267 @@
+package quotepdf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// ContentType is the MIME type of rendered quotes.
+const ContentType = "application/pdf"
+
+// CallReader loads the call a quote was generated for.
+type CallReader interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Call, error)
+}
+
+// SettingsReader provides the business identity shown on quotes.
+type SettingsReader interface {
+	GetCallSettings(ctx context.Context) (*domain.CallSettings, error)
+}
+
+// QuoteReader loads the quote staff edited for a call.
+type QuoteReader interface {
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Quote, error)
+}
+
+// TemplateReader finds the quote template for a project type.
+type TemplateReader interface {
+	TemplateFor(ctx context.Context, projectType string) (*domain.QuoteTemplate, error)
+}
+
+// Config holds quote document settings.
+type Config struct {
+	// ValidityDays is how long a quote remains valid after it is issued.
+	ValidityDays int
+	// Currency is the ISO currency code used for amounts of quotes with no
+	// recorded currency when none is set in settings.
+	Currency string
+	// AttachToFollowUps controls whether follow-up emails include the PDF.
+	AttachToFollowUps bool
+	// FallbackBusinessName is used when no business name is configured in settings.
+	FallbackBusinessName string
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() Config {
+	return Config{
+		ValidityDays:      30,
+		Currency:          "USD",
+		AttachToFollowUps: true,
+	}
+}
+
+// Attachment is a rendered quote ready to be downloaded or attached to a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Service builds and renders quote PDFs.
+type Service struct {
+	calls     CallReader
+	settings  SettingsReader
+	quotes    QuoteReader
+	templates TemplateReader
+	config    Config
+	logger    *zap.Logger
+}
+
+// NewService creates a new quote PDF service. settings may be nil.
+func NewService(calls CallReader, settings SettingsReader, cfg Config, logger *zap.Logger) *Service {
+	if cfg.ValidityDays <= 0 {
+		cfg.ValidityDays = DefaultConfig().ValidityDays
+	}
+	if cfg.Currency == "" {
+		cfg.Currency = DefaultConfig().Currency
+	}
+	return &Service{
+		calls:    calls,
+		settings: settings,
+		config:   cfg,
+		logger:   logger,
+	}
+}
+
+// SetQuoteReader enables rendering the line items, tax and notes staff
+// edited in place of those parsed from the generated quote text.
+func (s *Service) SetQuoteReader(quotes QuoteReader) {
+	s.quotes = quotes
+}
+
+// SetTemplates enables printing the sections and assumptions of the quote
+// template for each quote's project type.
+func (s *Service) SetTemplates(templates TemplateReader) {
+	s.templates = templates
+}
+
+// AttachToFollowUps reports whether follow-up messages should include the PDF.
+func (s *Service) AttachToFollowUps() bool {
+	return s.config.AttachToFollowUps
+}
+
+// BuildDocument assembles the quote document for a call.
+func (s *Service) BuildDocument(ctx context.Context, callID uuid.UUID) (*Document, error) {
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if call.QuoteSummary == nil || strings.TrimSpace(*call.QuoteSummary) == "" {
+		return nil, apperrors.NotFound("quote")
+	}
+
+	issued := call.UpdatedAt
+	if issued.IsZero() {
+		issued = time.Now().UTC()
+	}
+
+	doc := &Document{
+		BusinessName:  s.BusinessName(ctx),
+		QuoteNumber:   QuoteNumber(call.ID),
+		IssuedAt:      issued,
+		ValidUntil:    issued.AddDate(0, 0, s.config.ValidityDays),
+		Currency:      call.Currency(),
+		CustomerPhone: call.FromNumber,
+		LineItems:     ParseLineItems(*call.QuoteSummary),
+		Summary:       *call.QuoteSummary,
+	}
+	if doc.Currency == "" {
+		doc.Currency = s.defaultCurrency(ctx)
+	}
+	s.applyEdits(ctx, doc, callID)
+	if call.CallerName != nil {
+		doc.CustomerName = *call.CallerName
+	}
+	if data := call.ExtractedData; data != nil {
+		if doc.CustomerName == "" {
+			doc.CustomerName = data.CallerName
+		}
+		if data.Phone != "" {
+			doc.CustomerPhone = data.Phone
+		}
+		doc.CustomerEmail = data.Email
+		doc.Company = data.Company
+		doc.ProjectType = data.ProjectType
+		doc.Timeline = data.Timeline
+	}
+	s.applyTemplate(ctx, doc, callID)
+
+	return doc, nil
+}
+
+// Render builds and renders the quote PDF for a call.
+func (s *Service) Render(ctx context.Context, callID uuid.UUID) (*Attachment, error) {
+	doc, err := s.BuildDocument(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := Render(doc)
+	s.logger.Debug("rendered quote PDF",
+		zap.String("call_id", callID.String()),
+		zap.String("quote_number", doc.QuoteNumber),
+		zap.Int("line_items", len(doc.LineItems)),
+		zap.Int("bytes", len(data)),
+	)
+
+	return &Attachment{
+		Filename:    fmt.Sprintf("quote-%s.pdf", doc.QuoteNumber),
+		ContentType: ContentType,
+		Data:        data,
+	}, nil
+}
+
+// applyEdits replaces the parsed line items with those staff edited, if any,
+// uses the quote's currency and adds the customer's signature once they have
+// signed.
+func (s *Service) applyEdits(ctx context.Context, doc *Document, callID uuid.UUID) {
+	if s.quotes == nil {
+		return
+	}
+	quote, err := s.quotes.GetByCallID(ctx, callID)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to load edited quote for PDF", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+		return
+	}
+	if sig := quote.Signature; sig != nil {
+		doc.Signature = &Signature{SignerName: sig.SignerName, Image: sig.Image, IP: sig.IP, SignedAt: sig.SignedAt}
+	}
+	if quote.Currency != "" {
+		doc.Currency = quote.Currency
+	}
+	if !quote.IsEdited() {
+		return
+	}
+
+	doc.LineItems = make([]LineItem, len(quote.LineItems))
+	for i, li := range quote.LineItems {
+		doc.LineItems[i] = LineItem{Description: li.Description, Quantity: li.Quantity, UnitPrice: li.UnitPrice}
+	}
+	doc.TaxRate = quote.TaxRate
+	doc.Notes = quote.Notes
+}
+
+// applyTemplate adds the sections and assumptions of the template for the
+// quote's project type. Quotes are rendered without them when the template
+// can't be loaded.
+func (s *Service) applyTemplate(ctx context.Context, doc *Document, callID uuid.UUID) {
+	if s.templates == nil {
+		return
+	}
+	template, err := s.templates.TemplateFor(ctx, doc.ProjectType)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to load quote template for PDF", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+		return
+	}
+	for _, section := range template.Sections {
+		doc.Sections = append(doc.Sections, Section{Heading: section.Heading, Body: section.Body})
+	}
+	doc.Assumptions = template.Assumptions
+}
+
+// BusinessName returns the configured business name shown on quotes.
+func (s *Service) BusinessName(ctx context.Context) string {
+	if s.settings != nil {
+		cs, err := s.settings.GetCallSettings(ctx)
+		if err != nil {
+			s.logger.Warn("failed to load call settings for quote PDF", zap.Error(err))
+		} else if cs != nil && cs.BusinessName != "" {
+			return cs.BusinessName
+		}
+	}
+	if s.config.FallbackBusinessName != "" {
+		return s.config.FallbackBusinessName
+	}
+	return "QuickQuote"
+}
+
+// defaultCurrency returns the currency of quotes generated before currencies
+// were recorded: the currency setting, else the configured currency.
+func (s *Service) defaultCurrency(ctx context.Context) string {
+	if s.settings != nil {
+		cs, err := s.settings.GetCallSettings(ctx)
+		if err != nil {
+			s.logger.Warn("failed to load call settings for quote PDF", zap.Error(err))
+		} else if cs != nil && domain.IsValidCurrency(cs.Currency) {
+			return cs.Currency
+		}
+	}
+	return s.config.Currency
+}
+
+// QuoteNumber derives a short, human-friendly quote number from a call ID.
+func QuoteNumber(callID uuid.UUID) string {
+	return "Q-" + strings.ToUpper(strings.ReplaceAll(callID.String(), "-", "")[:8])
+}
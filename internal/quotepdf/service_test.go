@@ -0,0 +1,207 @@
+package quotepdf
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubCalls map[uuid.UUID]*domain.Call
+
+func (s stubCalls) GetByID(ctx context.Context, id uuid.UUID) (*domain.Call, error) {
+	if call, ok := s[id]; ok {
+		return call, nil
+	}
+	return nil, apperrors.NotFound("call")
+}
+
+type stubSettings struct{ name, currency string }
+
+func (s stubSettings) GetCallSettings(ctx context.Context) (*domain.CallSettings, error) {
+	return &domain.CallSettings{BusinessName: s.name, Currency: s.currency}, nil
+}
+
+func TestService_Render(t *testing.T) {
+	call := domain.NewCall("prov-1", "bland", "+15550001111", "+15550002222")
+	quote := "- Web app: $10,000"
+	name := "Jane"
+	call.QuoteSummary = &quote
+	call.CallerName = &name
+	call.ExtractedData = &domain.ExtractedData{Email: "jane@example.com", ProjectType: "web app"}
+
+	svc := NewService(stubCalls{call.ID: call}, stubSettings{name: "Acme Dev"}, DefaultConfig(), zap.NewNop())
+
+	doc, err := svc.BuildDocument(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if doc.BusinessName != "Acme Dev" {
+		t.Errorf("expected business name from settings, got %q", doc.BusinessName)
+	}
+	if doc.CustomerEmail != "jane@example.com" {
+		t.Errorf("expected customer email, got %q", doc.CustomerEmail)
+	}
+	if doc.Total() != 10000 {
+		t.Errorf("expected total 10000, got %v", doc.Total())
+	}
+	if got := doc.ValidUntil.Sub(doc.IssuedAt).Hours(); got != 30*24 {
+		t.Errorf("expected 30 day validity, got %v hours", got)
+	}
+
+	att, err := svc.Render(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if att.ContentType != ContentType {
+		t.Errorf("expected content type %q, got %q", ContentType, att.ContentType)
+	}
+	if att.Filename != "quote-"+QuoteNumber(call.ID)+".pdf" {
+		t.Errorf("unexpected filename %q", att.Filename)
+	}
+	if !bytes.HasPrefix(att.Data, []byte("%PDF")) {
+		t.Error("expected PDF data")
+	}
+}
+
+type stubQuotes map[uuid.UUID]*domain.Quote
+
+func (s stubQuotes) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Quote, error) {
+	if q, ok := s[callID]; ok {
+		return q, nil
+	}
+	return nil, apperrors.NotFound("quote")
+}
+
+func TestService_BuildDocument_EditedQuote(t *testing.T) {
+	call := domain.NewCall("prov-1", "bland", "+15550001111", "+15550002222")
+	summary := "- Web app: $10,000"
+	call.QuoteSummary = &summary
+
+	edited := domain.NewQuote(call.ID, 0)
+	items := []domain.QuoteLineItem{
+		{Description: "Web app", Quantity: 1, UnitPrice: 9000},
+		{Description: "Support", Quantity: 6, UnitPrice: 150},
+	}
+	if err := edited.Edit(items, 10, "Net 30"); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+
+	svc := NewService(stubCalls{call.ID: call}, nil, DefaultConfig(), zap.NewNop())
+	svc.SetQuoteReader(stubQuotes{call.ID: edited})
+
+	doc, err := svc.BuildDocument(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if len(doc.LineItems) != 2 || doc.Notes != "Net 30" {
+		t.Fatalf("expected edited line items and notes, got %+v", doc)
+	}
+	if doc.Total() != edited.Total() || doc.Total() != 10890 {
+		t.Errorf("expected total 10890, got %v", doc.Total())
+	}
+	if doc.Signature != nil {
+		t.Error("expected no signature on an unsigned quote")
+	}
+
+	edited.Signature = &domain.QuoteSignature{SignerName: "Jane Doe", SignedAt: time.Now()}
+	if doc, err = svc.BuildDocument(context.Background(), call.ID); err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if doc.Signature == nil || doc.Signature.SignerName != "Jane Doe" {
+		t.Errorf("expected the customer's signature, got %+v", doc.Signature)
+	}
+
+	// Quotes staff have not edited are still priced from the generated text.
+	svc.SetQuoteReader(stubQuotes{})
+	if doc, err = svc.BuildDocument(context.Background(), call.ID); err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if doc.Total() != 10000 {
+		t.Errorf("expected parsed total 10000, got %v", doc.Total())
+	}
+}
+
+func TestService_Render_NoQuote(t *testing.T) {
+	call := domain.NewCall("prov-1", "bland", "+15550001111", "+15550002222")
+	svc := NewService(stubCalls{call.ID: call}, nil, DefaultConfig(), zap.NewNop())
+
+	if _, err := svc.Render(context.Background(), call.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("expected not found for call without quote, got %v", err)
+	}
+	if _, err := svc.Render(context.Background(), uuid.New()); !apperrors.IsNotFound(err) {
+		t.Errorf("expected not found for unknown call, got %v", err)
+	}
+}
+
+func TestService_BuildDocument_Currency(t *testing.T) {
+	call := domain.NewCall("prov-1", "bland", "+15550001111", "+15550002222")
+	summary := "- Web app: 10.000 €"
+	call.QuoteSummary = &summary
+
+	svc := NewService(stubCalls{call.ID: call}, stubSettings{currency: "CAD"}, DefaultConfig(), zap.NewNop())
+	doc, err := svc.BuildDocument(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if doc.Currency != "CAD" {
+		t.Errorf("expected the currency setting for a quote without one, got %q", doc.Currency)
+	}
+
+	call.ExtractedData = &domain.ExtractedData{Currency: "EUR"}
+	if doc, _ = svc.BuildDocument(context.Background(), call.ID); doc.Currency != "EUR" {
+		t.Errorf("expected the currency the quote was generated in, got %q", doc.Currency)
+	}
+
+	quote := domain.NewQuote(call.ID, 0)
+	quote.Currency = "GBP"
+	svc.SetQuoteReader(stubQuotes{call.ID: quote})
+	if doc, _ = svc.BuildDocument(context.Background(), call.ID); doc.Currency != "GBP" {
+		t.Errorf("expected the quote's currency, got %q", doc.Currency)
+	}
+}
+
+type stubTemplates map[string]*domain.QuoteTemplate
+
+func (s stubTemplates) TemplateFor(ctx context.Context, projectType string) (*domain.QuoteTemplate, error) {
+	if template, ok := s[projectType]; ok {
+		return template, nil
+	}
+	return nil, apperrors.NotFound("quote template")
+}
+
+func TestService_BuildDocument_Template(t *testing.T) {
+	call := domain.NewCall("prov-1", "bland", "+15550001111", "+15550002222")
+	quote := "- Web app: $10,000"
+	call.QuoteSummary = &quote
+	call.ExtractedData = &domain.ExtractedData{ProjectType: "web app"}
+
+	template := domain.NewQuoteTemplate("web app", "Web quote")
+	template.Sections = []domain.QuoteTemplateSection{{Heading: "Payment terms", Body: "50% up front."}}
+	template.Assumptions = "Content is supplied by the customer."
+
+	svc := NewService(stubCalls{call.ID: call}, stubSettings{}, DefaultConfig(), zap.NewNop())
+	svc.SetTemplates(stubTemplates{"web app": template})
+
+	doc, err := svc.BuildDocument(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("BuildDocument() error = %v", err)
+	}
+	if len(doc.Sections) != 1 || doc.Sections[0].Heading != "Payment terms" {
+		t.Errorf("expected the template's sections, got %+v", doc.Sections)
+	}
+	if doc.Assumptions != template.Assumptions {
+		t.Errorf("expected the template's assumptions, got %q", doc.Assumptions)
+	}
+
+	svc.SetTemplates(stubTemplates{})
+	if doc, _ = svc.BuildDocument(context.Background(), call.ID); len(doc.Sections) != 0 || doc.Assumptions != "" {
+		t.Errorf("expected no template content without a template, got %+v", doc)
+	}
+}
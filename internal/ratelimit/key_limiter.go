@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KeyRateLimiter enforces per-minute request limits for API keys.
+// Each key carries its own limit, so buckets are sized on first use and
+// resized if the key's configured limit changes.
+type KeyRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[uuid.UUID]*keyBucket
+	stop    chan struct{}
+}
+
+type keyBucket struct {
+	bucket     *tokenBucket
+	lastAccess time.Time
+}
+
+// KeyRateLimitResult describes the outcome of a rate limit check.
+type KeyRateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetIn   time.Duration
+}
+
+// NewKeyRateLimiter creates a new per-key rate limiter.
+func NewKeyRateLimiter() *KeyRateLimiter {
+	rl := &KeyRateLimiter{
+		buckets: make(map[uuid.UUID]*keyBucket),
+		stop:    make(chan struct{}),
+	}
+	go rl.cleanup()
+	return rl
+}
+
+// Allow consumes one request from the key's per-minute budget.
+func (rl *KeyRateLimiter) Allow(keyID uuid.UUID, limitPerMinute int) KeyRateLimitResult {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	kb, exists := rl.buckets[keyID]
+	if !exists || kb.bucket.max != limitPerMinute {
+		kb = &keyBucket{bucket: newTokenBucket(limitPerMinute, time.Minute, now)}
+		rl.buckets[keyID] = kb
+	}
+	kb.lastAccess = now
+
+	allowed := kb.bucket.tryAcquire(now)
+	return KeyRateLimitResult{
+		Allowed:   allowed,
+		Limit:     limitPerMinute,
+		Remaining: kb.bucket.remaining(),
+		ResetIn:   kb.bucket.resetIn(now),
+	}
+}
+
+// Stop stops the background cleanup goroutine.
+func (rl *KeyRateLimiter) Stop() {
+	close(rl.stop)
+}
+
+// cleanup removes buckets for keys that have not been used recently.
+func (rl *KeyRateLimiter) cleanup() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rl.stop:
+			return
+		case now := <-ticker.C:
+			rl.mu.Lock()
+			for id, kb := range rl.buckets {
+				if now.Sub(kb.lastAccess) > 10*time.Minute {
+					delete(rl.buckets, id)
+				}
+			}
+			rl.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestKeyRateLimiter_Allow(t *testing.T) {
+	rl := NewKeyRateLimiter()
+	defer rl.Stop()
+
+	keyID := uuid.New()
+	for i := 0; i < 3; i++ {
+		if res := rl.Allow(keyID, 3); !res.Allowed {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+
+	res := rl.Allow(keyID, 3)
+	if res.Allowed {
+		t.Error("request over the limit should be rejected")
+	}
+	if res.Remaining != 0 {
+		t.Errorf("expected 0 remaining, got %d", res.Remaining)
+	}
+	if res.Limit != 3 {
+		t.Errorf("expected limit 3, got %d", res.Limit)
+	}
+}
+
+func TestKeyRateLimiter_KeysAreIndependent(t *testing.T) {
+	rl := NewKeyRateLimiter()
+	defer rl.Stop()
+
+	a, b := uuid.New(), uuid.New()
+	rl.Allow(a, 1)
+	if rl.Allow(a, 1).Allowed {
+		t.Error("key a should be exhausted")
+	}
+	if !rl.Allow(b, 1).Allowed {
+		t.Error("key b should have its own budget")
+	}
+}
+
+func TestKeyRateLimiter_LimitChangeResetsBucket(t *testing.T) {
+	rl := NewKeyRateLimiter()
+	defer rl.Stop()
+
+	keyID := uuid.New()
+	rl.Allow(keyID, 1)
+	if rl.Allow(keyID, 1).Allowed {
+		t.Fatal("key should be exhausted at limit 1")
+	}
+	if !rl.Allow(keyID, 5).Allowed {
+		t.Error("raising the limit should allow further requests")
+	}
+}
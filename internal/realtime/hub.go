@@ -0,0 +1,210 @@
+// Package realtime pushes live call activity to operators' browsers.
+//
+// A Hub keeps the state of the calls in progress on this server, fed by
+// provider webhooks, and fans every change out to its subscribers. The live
+// calls page subscribes over a WebSocket (see Upgrade) so operators can
+// watch calls without polling. Hubs are per process: a server only sees the
+// webhooks it received itself.
+package realtime
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+const (
+	// subscriberBuffer is how many events a slow subscriber may fall behind
+	// before it is dropped.
+	subscriberBuffer = 64
+
+	// staleCallAge is how long a call may go without a webhook before it is
+	// assumed to have ended without the provider telling us.
+	staleCallAge = 2 * time.Hour
+)
+
+// Event types sent to subscribers.
+const (
+	EventSnapshot   = "snapshot"   // Every call in progress, sent first
+	EventCall       = "call"       // A call started or changed status
+	EventTranscript = "transcript" // A call in progress has more transcript
+	EventCallEnded  = "call_ended" // A call finished; it is no longer live
+)
+
+// LiveCall is the state of a call in progress.
+type LiveCall struct {
+	ID                uuid.UUID                `json:"id"`
+	ProviderCallID    string                   `json:"provider_call_id"`
+	Provider          string                   `json:"provider"`
+	PhoneNumber       string                   `json:"phone_number"`
+	FromNumber        string                   `json:"from_number"`
+	CallerName        string                   `json:"caller_name,omitempty"`
+	Status            domain.CallStatus        `json:"status"`
+	StartedAt         *time.Time               `json:"started_at,omitempty"`
+	EndedAt           *time.Time               `json:"ended_at,omitempty"`
+	Transcript        string                   `json:"transcript,omitempty"`
+	TranscriptEntries []domain.TranscriptEntry `json:"transcript_entries,omitempty"`
+	UpdatedAt         time.Time                `json:"updated_at"`
+}
+
+// Event is a change pushed to subscribers.
+type Event struct {
+	Type  string      `json:"type"`
+	Call  *LiveCall   `json:"call,omitempty"`
+	Calls []*LiveCall `json:"calls,omitempty"` // Snapshot only
+}
+
+// Hub tracks calls in progress and fans out their changes.
+type Hub struct {
+	mu    sync.Mutex
+	clock clock.Clock
+	calls map[uuid.UUID]*LiveCall
+	subs  map[chan Event]struct{}
+}
+
+// NewHub creates an empty Hub. A nil clock uses real time.
+func NewHub(clk clock.Clock) *Hub {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Hub{
+		clock: clk,
+		calls: make(map[uuid.UUID]*LiveCall),
+		subs:  make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers a listener. It returns a snapshot of the calls in
+// progress, a channel of further events, and a function to unsubscribe. The
+// channel is closed if the listener falls too far behind.
+func (h *Hub) Subscribe() (Event, <-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pruneStale()
+	ch := make(chan Event, subscriberBuffer)
+	h.subs[ch] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+	return Event{Type: EventSnapshot, Calls: h.snapshot()}, ch, unsubscribe
+}
+
+// ActiveCalls returns the calls in progress, oldest first.
+func (h *Hub) ActiveCalls() []*LiveCall {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneStale()
+	return h.snapshot()
+}
+
+// PublishCall records the latest state of a call from a provider webhook.
+// A call that has finished is announced as ended and forgotten; otherwise a
+// change of status is announced as a call event and new transcript as a
+// transcript event.
+func (h *Hub) PublishCall(call *domain.Call) {
+	if call == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	live := newLiveCall(call, h.clock.Now())
+	previous, known := h.calls[call.ID]
+
+	if call.IsComplete() {
+		delete(h.calls, call.ID)
+		h.send(Event{Type: EventCallEnded, Call: live})
+		return
+	}
+
+	h.calls[call.ID] = live
+	if !known || previous.Status != live.Status {
+		h.send(Event{Type: EventCall, Call: live})
+		return
+	}
+	if previous.Transcript != live.Transcript || len(previous.TranscriptEntries) != len(live.TranscriptEntries) {
+		h.send(Event{Type: EventTranscript, Call: live})
+	}
+}
+
+// newLiveCall copies the live state of a call.
+func newLiveCall(call *domain.Call, now time.Time) *LiveCall {
+	live := &LiveCall{
+		ID:                call.ID,
+		ProviderCallID:    call.ProviderCallID,
+		Provider:          call.Provider,
+		PhoneNumber:       call.PhoneNumber,
+		FromNumber:        call.FromNumber,
+		Status:            call.Status,
+		StartedAt:         call.StartedAt,
+		EndedAt:           call.EndedAt,
+		TranscriptEntries: call.TranscriptJSON,
+		UpdatedAt:         now,
+	}
+	if call.CallerName != nil {
+		live.CallerName = *call.CallerName
+	}
+	if call.Transcript != nil {
+		live.Transcript = *call.Transcript
+	}
+	return live
+}
+
+// snapshot lists the calls in progress, oldest first. Callers must hold mu.
+func (h *Hub) snapshot() []*LiveCall {
+	calls := make([]*LiveCall, 0, len(h.calls))
+	for _, call := range h.calls {
+		calls = append(calls, call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		return startedOrUpdated(calls[i]).Before(startedOrUpdated(calls[j]))
+	})
+	return calls
+}
+
+// startedOrUpdated is when a call started, or when it was last heard from if
+// the provider didn't say.
+func startedOrUpdated(call *LiveCall) time.Time {
+	if call.StartedAt != nil {
+		return *call.StartedAt
+	}
+	return call.UpdatedAt
+}
+
+// pruneStale forgets calls not heard from in staleCallAge, announcing them as
+// ended. Callers must hold mu.
+func (h *Hub) pruneStale() {
+	cutoff := h.clock.Now().Add(-staleCallAge)
+	for id, call := range h.calls {
+		if call.UpdatedAt.Before(cutoff) {
+			delete(h.calls, id)
+			h.send(Event{Type: EventCallEnded, Call: call})
+		}
+	}
+}
+
+// send delivers an event to every subscriber, dropping any that are full so
+// a stalled browser cannot hold up webhook processing. Callers must hold mu.
+func (h *Hub) send(event Event) {
+	for ch := range h.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// drainEvents reads events until the channel is closed or empty.
+func drainEvents(ch <-chan Event) (events []Event, closed bool) {
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return events, true
+			}
+			events = append(events, e)
+		default:
+			return events, false
+		}
+	}
+}
+
+func newTestCall(status domain.CallStatus, transcript string) *domain.Call {
+	call := domain.NewCall("bland-1", "bland", "+15550000001", "+15550000002")
+	call.ID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	call.Status = status
+	if transcript != "" {
+		call.Transcript = &transcript
+	}
+	return call
+}
+
+func TestHub_PublishesCallLifecycle(t *testing.T) {
+	hub := NewHub(nil)
+	snapshot, ch, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	if snapshot.Type != EventSnapshot || len(snapshot.Calls) != 0 {
+		t.Fatalf("expected an empty snapshot, got %+v", snapshot)
+	}
+
+	hub.PublishCall(newTestCall(domain.CallStatusInProgress, ""))
+	hub.PublishCall(newTestCall(domain.CallStatusInProgress, "Caller: Hi"))
+	hub.PublishCall(newTestCall(domain.CallStatusInProgress, "Caller: Hi")) // Nothing new
+	hub.PublishCall(newTestCall(domain.CallStatusCompleted, "Caller: Hi"))
+
+	events, _ := drainEvents(ch)
+	want := []string{EventCall, EventTranscript, EventCallEnded}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, typ := range want {
+		if events[i].Type != typ {
+			t.Errorf("events[%d] = %s, expected %s", i, events[i].Type, typ)
+		}
+	}
+	if events[1].Call.Transcript != "Caller: Hi" {
+		t.Errorf("expected transcript event to carry the transcript, got %q", events[1].Call.Transcript)
+	}
+	if calls := hub.ActiveCalls(); len(calls) != 0 {
+		t.Errorf("expected ended call to be forgotten, %d remain", len(calls))
+	}
+}
+
+func TestHub_SnapshotAndStaleCalls(t *testing.T) {
+	clk := clock.NewMock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	hub := NewHub(clk)
+	hub.PublishCall(newTestCall(domain.CallStatusInProgress, ""))
+
+	snapshot, _, unsubscribe := hub.Subscribe()
+	if len(snapshot.Calls) != 1 || snapshot.Calls[0].Status != domain.CallStatusInProgress {
+		t.Fatalf("expected the call in progress in the snapshot, got %+v", snapshot.Calls)
+	}
+	unsubscribe()
+
+	clk.Advance(staleCallAge + time.Minute)
+	if calls := hub.ActiveCalls(); len(calls) != 0 {
+		t.Errorf("expected a call not heard from in %s to be dropped, got %+v", staleCallAge, calls)
+	}
+}
+
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewHub(nil)
+	_, ch, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		call := newTestCall(domain.CallStatusInProgress, "")
+		call.ID = uuid.New()
+		hub.PublishCall(call)
+	}
+
+	events, closed := drainEvents(ch)
+	if !closed || len(events) != subscriberBuffer {
+		t.Errorf("expected %d events then a closed channel, got %d (closed %v)", subscriberBuffer, len(events), closed)
+	}
+}
@@ -0,0 +1,94 @@
+package realtime
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeTimeout bounds each message written to a client.
+	writeTimeout = 10 * time.Second
+
+	// maxReadPayload bounds messages read from a client. The server only
+	// pushes, so clients have nothing large to send.
+	maxReadPayload = 64 * 1024
+)
+
+// Close status codes.
+const (
+	CloseNormal    = websocket.CloseNormalClosure
+	CloseGoingAway = websocket.CloseGoingAway
+)
+
+// ErrBadHandshake is returned by Upgrade when the request is not a valid
+// WebSocket handshake. Upgrade has already written the error response.
+var ErrBadHandshake = errors.New("realtime: bad websocket handshake")
+
+// upgrader leaves CheckOrigin unset, so cross-origin requests are refused:
+// browsers send session cookies with them.
+var upgrader = websocket.Upgrader{}
+
+// Conn is a server-side WebSocket connection. Writes may be made from any
+// goroutine; reads belong to ReadLoop.
+type Conn struct {
+	ws *websocket.Conn
+
+	writeMu sync.Mutex
+}
+
+// Upgrade completes a WebSocket handshake and takes over the connection.
+// Cross-origin requests are refused. On failure it writes the error
+// response and returns an error.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		var handshakeErr websocket.HandshakeError
+		if errors.As(err, &handshakeErr) {
+			return nil, ErrBadHandshake
+		}
+		return nil, fmt.Errorf("failed to upgrade connection: %w", err)
+	}
+	ws.SetReadLimit(maxReadPayload)
+	return &Conn{ws: ws}, nil
+}
+
+// WriteJSON sends v as a text message.
+func (c *Conn) WriteJSON(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_ = c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return c.ws.WriteJSON(v)
+}
+
+// Ping sends a ping, which keeps proxies from closing an idle connection.
+func (c *Conn) Ping() error {
+	return c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+}
+
+// Close sends a close message with code and closes the connection.
+func (c *Conn) Close(code int) error {
+	_ = c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(writeTimeout))
+	return c.ws.Close()
+}
+
+// ReadLoop reads from the client until the connection closes, answering
+// pings and close messages. Messages from the client are discarded. It
+// returns io.EOF once the client closes the connection cleanly.
+func (c *Conn) ReadLoop() error {
+	for {
+		if _, _, err := c.ws.ReadMessage(); err != nil {
+			c.ws.Close()
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				return io.EOF
+			}
+			return err
+		}
+	}
+}
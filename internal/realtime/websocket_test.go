@@ -0,0 +1,110 @@
+package realtime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestUpgrade_RejectsBadHandshakes(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"not an upgrade", map[string]string{}, http.StatusBadRequest},
+		{"old version", map[string]string{"Sec-WebSocket-Version": "8"}, http.StatusBadRequest},
+		{"cross origin", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://quickquote.example/calls/live/ws", nil)
+			if tt.name != "not an upgrade" {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+				req.Header.Set("Sec-WebSocket-Version", "13")
+				req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			if _, err := Upgrade(rec, req); err != ErrBadHandshake {
+				t.Errorf("expected ErrBadHandshake, got %v", err)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}
+
+// serve starts a server that upgrades, runs handle and reports the result.
+func serve(t *testing.T, handle func(*Conn) error) (*websocket.Conn, <-chan error) {
+	t.Helper()
+	done := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r)
+		if err != nil {
+			done <- err
+			return
+		}
+		done <- handle(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return client, done
+}
+
+func TestUpgrade_SendsMessagesAndCloses(t *testing.T) {
+	client, done := serve(t, func(conn *Conn) error {
+		if err := conn.WriteJSON(Event{Type: EventSnapshot}); err != nil {
+			return err
+		}
+		return conn.ReadLoop()
+	})
+
+	_, payload, err := client.ReadMessage()
+	if err != nil || string(payload) != `{"type":"snapshot"}`+"\n" {
+		t.Errorf("got payload %q, err %v", payload, err)
+	}
+
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := client.WriteMessage(websocket.CloseMessage, msg); err != nil {
+		t.Fatalf("write close: %v", err)
+	}
+	if _, _, err := client.ReadMessage(); !websocket.IsCloseError(err, CloseNormal) {
+		t.Errorf("expected close reply, got %v", err)
+	}
+	if err := <-done; err != io.EOF {
+		t.Errorf("ReadLoop() = %v, expected io.EOF", err)
+	}
+}
+
+func TestReadLoop_RejectsLargeMessages(t *testing.T) {
+	client, done := serve(t, func(conn *Conn) error {
+		return conn.ReadLoop()
+	})
+
+	if err := client.WriteMessage(websocket.TextMessage, make([]byte, maxReadPayload+1)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, _, err := client.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+		t.Errorf("expected close with %d, got %v", websocket.CloseMessageTooBig, err)
+	}
+	if err := <-done; !errors.Is(err, websocket.ErrReadLimit) {
+		t.Errorf("ReadLoop() = %v, expected ErrReadLimit", err)
+	}
+}
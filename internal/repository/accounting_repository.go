@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const accountingSyncColumns = `id, call_id, provider, document, status, external_id,
+	external_number, error, actor_id, created_at`
+
+// AccountingRepository implements domain.AccountingRepository using PostgreSQL.
+type AccountingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAccountingRepository creates a new AccountingRepository.
+func NewAccountingRepository(pool *pgxpool.Pool) *AccountingRepository {
+	return &AccountingRepository{pool: pool}
+}
+
+// GetConnection retrieves the connection for a provider.
+func (r *AccountingRepository) GetConnection(ctx context.Context, provider string) (*domain.AccountingConnection, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT provider, tenant_id, access_token, refresh_token, expires_at,
+			connected_by, created_at, updated_at
+		FROM accounting_connections
+		WHERE provider = $1`
+
+	c := &domain.AccountingConnection{}
+	err := r.pool.QueryRow(ctx, query, provider).Scan(
+		&c.Provider,
+		&c.TenantID,
+		&c.AccessToken,
+		&c.RefreshToken,
+		&c.ExpiresAt,
+		&c.ConnectedBy,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("accounting connection")
+		}
+		return nil, apperrors.DatabaseError("AccountingRepository.GetConnection", err)
+	}
+	return c, nil
+}
+
+// SaveConnection creates or replaces the connection for its provider.
+func (r *AccountingRepository) SaveConnection(ctx context.Context, conn *domain.AccountingConnection) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO accounting_connections (provider, tenant_id, access_token, refresh_token,
+			expires_at, connected_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (provider) DO UPDATE SET
+			tenant_id = EXCLUDED.tenant_id,
+			access_token = EXCLUDED.access_token,
+			refresh_token = EXCLUDED.refresh_token,
+			expires_at = EXCLUDED.expires_at,
+			connected_by = EXCLUDED.connected_by,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		conn.Provider,
+		conn.TenantID,
+		conn.AccessToken,
+		conn.RefreshToken,
+		conn.ExpiresAt,
+		conn.ConnectedBy,
+		conn.CreatedAt,
+		conn.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("AccountingRepository.SaveConnection", err)
+	}
+	return nil
+}
+
+// DeleteConnection removes the connection for a provider.
+func (r *AccountingRepository) DeleteConnection(ctx context.Context, provider string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM accounting_connections WHERE provider = $1`, provider); err != nil {
+		return apperrors.DatabaseError("AccountingRepository.DeleteConnection", err)
+	}
+	return nil
+}
+
+// GetMapping retrieves the mapping for a provider.
+func (r *AccountingRepository) GetMapping(ctx context.Context, provider string) (*domain.AccountingMapping, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT provider, default_item, project_type_items, tax_code, exempt_tax_code, updated_at
+		FROM accounting_mappings
+		WHERE provider = $1`
+
+	m := &domain.AccountingMapping{}
+	var items []byte
+	err := r.pool.QueryRow(ctx, query, provider).Scan(
+		&m.Provider,
+		&m.DefaultItem,
+		&items,
+		&m.TaxCode,
+		&m.ExemptTaxCode,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("accounting mapping")
+		}
+		return nil, apperrors.DatabaseError("AccountingRepository.GetMapping", err)
+	}
+	if err := json.Unmarshal(items, &m.ProjectTypeItems); err != nil {
+		return nil, fmt.Errorf("failed to decode project type items: %w", err)
+	}
+	return m, nil
+}
+
+// SaveMapping creates or replaces the mapping for its provider.
+func (r *AccountingRepository) SaveMapping(ctx context.Context, mapping *domain.AccountingMapping) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	items := mapping.ProjectTypeItems
+	if items == nil {
+		items = map[string]string{}
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to encode project type items: %w", err)
+	}
+
+	query := `
+		INSERT INTO accounting_mappings (provider, default_item, project_type_items,
+			tax_code, exempt_tax_code, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (provider) DO UPDATE SET
+			default_item = EXCLUDED.default_item,
+			project_type_items = EXCLUDED.project_type_items,
+			tax_code = EXCLUDED.tax_code,
+			exempt_tax_code = EXCLUDED.exempt_tax_code,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = r.pool.Exec(ctx, query,
+		mapping.Provider,
+		mapping.DefaultItem,
+		itemsJSON,
+		mapping.TaxCode,
+		mapping.ExemptTaxCode,
+		mapping.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("AccountingRepository.SaveMapping", err)
+	}
+	return nil
+}
+
+// CreateSync records a sync attempt.
+func (r *AccountingRepository) CreateSync(ctx context.Context, sync *domain.AccountingSync) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO accounting_syncs (` + accountingSyncColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.pool.Exec(ctx, query,
+		sync.ID,
+		sync.CallID,
+		sync.Provider,
+		sync.Document,
+		sync.Status,
+		nullableString(sync.ExternalID),
+		nullableString(sync.ExternalNumber),
+		nullableString(sync.Error),
+		sync.ActorID,
+		sync.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("AccountingRepository.CreateSync", err)
+	}
+	return nil
+}
+
+// ListSyncsByCallID lists a quote's sync attempts, newest first.
+func (r *AccountingRepository) ListSyncsByCallID(ctx context.Context, callID uuid.UUID) ([]*domain.AccountingSync, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + accountingSyncColumns + ` FROM accounting_syncs
+		WHERE call_id = $1
+		ORDER BY created_at DESC`
+	return r.listSyncs(ctx, query, callID)
+}
+
+// ListRecentSyncs lists the latest sync attempts across quotes, newest first.
+func (r *AccountingRepository) ListRecentSyncs(ctx context.Context, limit int) ([]*domain.AccountingSync, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + accountingSyncColumns + ` FROM accounting_syncs
+		ORDER BY created_at DESC
+		LIMIT $1`
+	return r.listSyncs(ctx, query, limit)
+}
+
+func (r *AccountingRepository) listSyncs(ctx context.Context, query string, args ...interface{}) ([]*domain.AccountingSync, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AccountingRepository.listSyncs", err)
+	}
+	defer rows.Close()
+
+	var syncs []*domain.AccountingSync
+	for rows.Next() {
+		s := &domain.AccountingSync{}
+		var externalID, externalNumber, syncErr *string
+		if err := rows.Scan(
+			&s.ID,
+			&s.CallID,
+			&s.Provider,
+			&s.Document,
+			&s.Status,
+			&externalID,
+			&externalNumber,
+			&syncErr,
+			&s.ActorID,
+			&s.CreatedAt,
+		); err != nil {
+			return nil, apperrors.DatabaseError("AccountingRepository.listSyncs", err)
+		}
+		s.ExternalID = stringValue(externalID)
+		s.ExternalNumber = stringValue(externalNumber)
+		s.Error = stringValue(syncErr)
+		syncs = append(syncs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AccountingRepository.listSyncs", err)
+	}
+	return syncs, nil
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// analyticsCallFilter restricts calls to the filter's time range, provider,
+// sentiment and intent ($1 to $5, as returned by analyticsArgs). Deleted
+// calls are left out.
+const analyticsCallFilter = `
+	c.deleted_at IS NULL
+	AND c.created_at >= $1 AND c.created_at < $2
+	AND ($3 = '' OR c.provider = $3)
+	AND ($4 = '' OR c.sentiment = $4)
+	AND ($5 = '' OR c.intent = $5)`
+
+// analyticsArgs returns the arguments for analyticsCallFilter.
+func analyticsArgs(filter *domain.AnalyticsFilter) []interface{} {
+	return []interface{}{filter.From, filter.To, filter.Provider, string(filter.Sentiment), string(filter.Intent)}
+}
+
+// AnalyticsRepository implements domain.AnalyticsRepository using PostgreSQL.
+// Its queries are read-only and read from pools.Reader.
+type AnalyticsRepository struct {
+	reader *pgxpool.Pool
+}
+
+// NewAnalyticsRepository creates a new AnalyticsRepository.
+func NewAnalyticsRepository(pools Pools) *AnalyticsRepository {
+	return &AnalyticsRepository{reader: pools.reader()}
+}
+
+// CallKPIs returns call counts and average duration.
+func (r *AnalyticsRepository) CallKPIs(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallKPIs, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE c.status = 'completed'),
+			COUNT(*) FILTER (WHERE c.status = 'no_answer'),
+			COUNT(*) FILTER (WHERE c.status = 'failed'),
+			COUNT(*) FILTER (WHERE c.quote_summary IS NOT NULL AND c.quote_summary <> ''),
+			COALESCE(AVG(c.duration_seconds) FILTER (WHERE c.status = 'completed'), 0)::float8
+		FROM calls c
+		WHERE` + analyticsCallFilter
+
+	kpis := &domain.CallKPIs{}
+	err := r.reader.QueryRow(ctx, query, analyticsArgs(filter)...).Scan(
+		&kpis.TotalCalls,
+		&kpis.CompletedCalls,
+		&kpis.NoAnswerCalls,
+		&kpis.FailedCalls,
+		&kpis.QuotedCalls,
+		&kpis.AverageDurationSeconds,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.CallKPIs", err)
+	}
+	return kpis, nil
+}
+
+// QuoteValues returns the number and total of priced quotes in each
+// currency, largest total first.
+func (r *AnalyticsRepository) QuoteValues(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.CurrencyTotal, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT q.currency, COUNT(*), SUM(q.total_amount)::float8
+		FROM calls c
+		JOIN quotes q ON q.call_id = c.id
+		WHERE` + analyticsCallFilter + `
+		AND q.total_amount > 0
+		GROUP BY q.currency
+		ORDER BY SUM(q.total_amount) DESC, q.currency`
+
+	rows, err := r.reader.Query(ctx, query, analyticsArgs(filter)...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.QuoteValues", err)
+	}
+	defer rows.Close()
+
+	var totals []*domain.CurrencyTotal
+	for rows.Next() {
+		t := &domain.CurrencyTotal{}
+		if err := rows.Scan(&t.Currency, &t.Count, &t.Total); err != nil {
+			return nil, apperrors.DatabaseError("AnalyticsRepository.QuoteValues", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.QuoteValues", err)
+	}
+	return totals, nil
+}
+
+// CallsPerDay returns call counts for each UTC day with calls, oldest first.
+func (r *AnalyticsRepository) CallsPerDay(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.DailyCallVolume, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			date_trunc('day', c.created_at AT TIME ZONE 'UTC') AS day,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE c.status = 'completed'),
+			COUNT(*) FILTER (WHERE c.quote_summary IS NOT NULL AND c.quote_summary <> '')
+		FROM calls c
+		WHERE` + analyticsCallFilter + `
+		GROUP BY day
+		ORDER BY day`
+
+	rows, err := r.reader.Query(ctx, query, analyticsArgs(filter)...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.CallsPerDay", err)
+	}
+	defer rows.Close()
+
+	var days []*domain.DailyCallVolume
+	for rows.Next() {
+		d := &domain.DailyCallVolume{}
+		if err := rows.Scan(&d.Date, &d.Calls, &d.Completed, &d.Quoted); err != nil {
+			return nil, apperrors.DatabaseError("AnalyticsRepository.CallsPerDay", err)
+		}
+		d.Date = d.Date.UTC()
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.CallsPerDay", err)
+	}
+	return days, nil
+}
+
+// PromptMetrics returns call counts and average duration for each prompt
+// with calls, busiest first. Calls answered by an inbound experiment are
+// attributed to the prompt of their variant.
+func (r *AnalyticsRepository) PromptMetrics(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.PromptCallMetrics, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			COALESCE(c.prompt_id, v.prompt_id) AS prompt,
+			p.name,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE c.status = 'completed'),
+			COALESCE(AVG(c.duration_seconds) FILTER (WHERE c.status = 'completed'), 0)::float8
+		FROM calls c
+		LEFT JOIN experiment_assignments a ON a.call_id = c.id
+		LEFT JOIN experiment_variants v ON v.id = a.variant_id
+		LEFT JOIN prompts p ON p.id = COALESCE(c.prompt_id, v.prompt_id)
+		WHERE` + analyticsCallFilter + `
+		GROUP BY prompt, p.name
+		ORDER BY COUNT(*) DESC, p.name`
+
+	rows, err := r.reader.Query(ctx, query, analyticsArgs(filter)...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.PromptMetrics", err)
+	}
+	defer rows.Close()
+
+	var metrics []*domain.PromptCallMetrics
+	for rows.Next() {
+		m := &domain.PromptCallMetrics{}
+		var name *string
+		if err := rows.Scan(&m.PromptID, &name, &m.Calls, &m.CompletedCalls, &m.AverageDurationSeconds); err != nil {
+			return nil, apperrors.DatabaseError("AnalyticsRepository.PromptMetrics", err)
+		}
+		if name != nil {
+			m.PromptName = *name
+		}
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.PromptMetrics", err)
+	}
+	return metrics, nil
+}
+
+// TagCounts returns the number of tagged calls with each sentiment, intent
+// and urgency, and the number of calls with each disposition.
+func (r *AnalyticsRepository) TagCounts(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallTagCounts, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT 'sentiment', c.sentiment, COUNT(*)
+		FROM calls c
+		WHERE` + analyticsCallFilter + ` AND c.sentiment IS NOT NULL
+		GROUP BY c.sentiment
+		UNION ALL
+		SELECT 'intent', c.intent, COUNT(*)
+		FROM calls c
+		WHERE` + analyticsCallFilter + ` AND c.intent IS NOT NULL
+		GROUP BY c.intent
+		UNION ALL
+		SELECT 'urgency', c.urgency, COUNT(*)
+		FROM calls c
+		WHERE` + analyticsCallFilter + ` AND c.urgency IS NOT NULL
+		GROUP BY c.urgency
+		UNION ALL
+		SELECT 'disposition', c.disposition, COUNT(*)
+		FROM calls c
+		WHERE` + analyticsCallFilter + ` AND c.disposition IS NOT NULL
+		GROUP BY c.disposition`
+
+	rows, err := r.reader.Query(ctx, query, analyticsArgs(filter)...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.TagCounts", err)
+	}
+	defer rows.Close()
+
+	counts := domain.NewCallTagCounts()
+	for rows.Next() {
+		var tag, value string
+		var n int
+		if err := rows.Scan(&tag, &value, &n); err != nil {
+			return nil, apperrors.DatabaseError("AnalyticsRepository.TagCounts", err)
+		}
+		switch tag {
+		case "sentiment":
+			counts.Sentiment[domain.CallSentiment(value)] = n
+			counts.TaggedCalls += n
+		case "intent":
+			counts.Intent[domain.CallIntent(value)] = n
+		case "urgency":
+			counts.Urgency[domain.CallUrgency(value)] = n
+		case "disposition":
+			counts.Disposition[domain.CallDisposition(value)] = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRepository.TagCounts", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// analyticsRollupSelect computes the day $1's rollup rows for dimension $4
+// from the calls created between $2 and $3, keyed by the expression
+// formatted in as the third column. Calls answered by an inbound experiment
+// are attributed to the prompt of their variant.
+const analyticsRollupSelect = `
+	SELECT
+		$1::date,
+		$4,
+		%s AS key,
+		c.provider,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE c.status = 'completed'),
+		COUNT(*) FILTER (WHERE c.status = 'no_answer'),
+		COUNT(*) FILTER (WHERE c.quote_summary IS NOT NULL AND c.quote_summary <> ''),
+		COUNT(*) FILTER (WHERE q.status = 'accepted'),
+		COALESCE(SUM(c.duration_seconds) FILTER (WHERE c.status = 'completed'), 0)
+	FROM calls c
+	LEFT JOIN experiment_assignments a ON a.call_id = c.id
+	LEFT JOIN experiment_variants v ON v.id = a.variant_id
+	LEFT JOIN quotes q ON q.call_id = c.id
+	WHERE c.deleted_at IS NULL
+	AND c.created_at >= $2 AND c.created_at < $3`
+
+// analyticsInboundCall matches calls we received rather than placed, like
+// domain.Call.IsInbound.
+const analyticsInboundCall = `
+	AND c.prompt_id IS NULL
+	AND COALESCE(c.from_number, '') <> ''
+	AND COALESCE(c.provider_metadata->>'Direction', '') NOT LIKE 'outbound%'
+	AND COALESCE(c.provider_metadata->'call'->>'direction', '') <> 'outbound'
+	AND COALESCE(c.provider_metadata->'message'->'call'->>'type', '') <> 'outboundPhoneCall'
+	AND NOT (COALESCE(c.provider_metadata->'metadata', '{}'::jsonb) ?| ARRAY['campaign_contact_id', 'quote_id'])
+	AND NOT (COALESCE(c.provider_metadata->'call'->'metadata', '{}'::jsonb) ?| ARRAY['campaign_contact_id', 'quote_id'])`
+
+// AnalyticsRollupRepository implements domain.AnalyticsRollupRepository
+// using PostgreSQL. Rollups are computed on the primary; breakdowns read
+// from pools.Reader.
+type AnalyticsRollupRepository struct {
+	writer *pgxpool.Pool
+	reader *pgxpool.Pool
+}
+
+// NewAnalyticsRollupRepository creates a new AnalyticsRollupRepository.
+func NewAnalyticsRollupRepository(pools Pools) *AnalyticsRollupRepository {
+	return &AnalyticsRollupRepository{writer: pools.Writer, reader: pools.reader()}
+}
+
+// RollUpDay replaces the rollups for the UTC day starting at day.
+func (r *AnalyticsRollupRepository) RollUpDay(ctx context.Context, day time.Time) error {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.writer.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("AnalyticsRollupRepository.RollUpDay", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM analytics_daily WHERE day = $1::date`, day); err != nil {
+		return apperrors.DatabaseError("AnalyticsRollupRepository.RollUpDay", err)
+	}
+
+	insert := `
+		INSERT INTO analytics_daily (
+			day, dimension, dimension_key, provider, calls, completed_calls,
+			no_answer_calls, quoted_calls, accepted_quotes, completed_duration_seconds
+		)`
+	byPrompt := insert + fmt.Sprintf(analyticsRollupSelect, `COALESCE(COALESCE(c.prompt_id, v.prompt_id)::text, '')`) + `
+		GROUP BY key, c.provider`
+	byNumber := insert + fmt.Sprintf(analyticsRollupSelect, `c.phone_number`) + analyticsInboundCall + `
+		GROUP BY key, c.provider`
+
+	end := day.AddDate(0, 0, 1)
+	if _, err := tx.Exec(ctx, byPrompt, day, day, end, string(domain.AnalyticsDimensionPrompt)); err != nil {
+		return apperrors.DatabaseError("AnalyticsRollupRepository.RollUpDay", err)
+	}
+	if _, err := tx.Exec(ctx, byNumber, day, day, end, string(domain.AnalyticsDimensionNumber)); err != nil {
+		return apperrors.DatabaseError("AnalyticsRollupRepository.RollUpDay", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("AnalyticsRollupRepository.RollUpDay", err)
+	}
+	return nil
+}
+
+// Breakdown totals the rollups for the filter's days by key, busiest first.
+func (r *AnalyticsRollupRepository) Breakdown(ctx context.Context, dimension domain.AnalyticsDimension, filter *domain.AnalyticsFilter) ([]*domain.DimensionMetrics, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			d.dimension_key,
+			p.name,
+			SUM(d.calls),
+			SUM(d.completed_calls),
+			SUM(d.no_answer_calls),
+			SUM(d.quoted_calls),
+			SUM(d.accepted_quotes),
+			SUM(d.completed_duration_seconds)::bigint
+		FROM analytics_daily d
+		LEFT JOIN prompts p ON d.dimension = 'prompt' AND p.id::text = d.dimension_key
+		WHERE d.dimension = $1
+		AND d.day >= $2::date AND d.day < $3::date
+		AND ($4 = '' OR d.provider = $4)
+		GROUP BY d.dimension_key, p.name
+		ORDER BY SUM(d.calls) DESC, d.dimension_key`
+
+	rows, err := r.reader.Query(ctx, query, string(dimension), filter.From, filter.To, filter.Provider)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRollupRepository.Breakdown", err)
+	}
+	defer rows.Close()
+
+	var metrics []*domain.DimensionMetrics
+	for rows.Next() {
+		m := &domain.DimensionMetrics{}
+		var name *string
+		err := rows.Scan(
+			&m.Key,
+			&name,
+			&m.Calls,
+			&m.CompletedCalls,
+			&m.NoAnswerCalls,
+			&m.QuotedCalls,
+			&m.AcceptedQuotes,
+			&m.CompletedDurationSeconds,
+		)
+		if err != nil {
+			return nil, apperrors.DatabaseError("AnalyticsRollupRepository.Breakdown", err)
+		}
+		m.Name = stringValue(name)
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AnalyticsRollupRepository.Breakdown", err)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// APIKeyRepository implements domain.APIKeyRepository using PostgreSQL.
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository.
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, rate_limit_per_minute,
+	last_used_at, expires_at, revoked_at, created_at, updated_at`
+
+// Create inserts a new API key.
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	_, err := r.pool.Exec(ctx, query,
+		key.ID,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.RateLimitPerMinute,
+		key.LastUsedAt,
+		key.ExpiresAt,
+		key.RevokedAt,
+		key.CreatedAt,
+		key.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("APIKeyRepository.Create", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an API key by ID.
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("api key")
+		}
+		return nil, apperrors.DatabaseError("APIKeyRepository.GetByID", err)
+	}
+
+	return key, nil
+}
+
+// GetByHash retrieves an API key by the hash of its secret.
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.pool.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("api key")
+		}
+		return nil, apperrors.DatabaseError("APIKeyRepository.GetByHash", err)
+	}
+
+	return key, nil
+}
+
+// List retrieves all API keys, newest first.
+func (r *APIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("APIKeyRepository.List", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError("APIKeyRepository.List", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("APIKeyRepository.List", err)
+	}
+
+	return keys, nil
+}
+
+// Update updates an existing API key.
+func (r *APIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE api_keys
+		SET name = $2, prefix = $3, key_hash = $4, scopes = $5, rate_limit_per_minute = $6,
+			expires_at = $7, revoked_at = $8, updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		key.RateLimitPerMinute,
+		key.ExpiresAt,
+		key.RevokedAt,
+		key.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("APIKeyRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("api key")
+	}
+
+	return nil
+}
+
+// TouchLastUsed records the last time a key was used.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, id, at)
+	if err != nil {
+		return apperrors.DatabaseError("APIKeyRepository.TouchLastUsed", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a row into an APIKey.
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.RateLimitPerMinute,
+		&key.LastUsedAt,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const auditEventColumns = `
+	id, occurred_at, type, severity, actor_id, actor_type, actor_name,
+	source_ip, user_agent, request_id, session_id, resource_type, resource_id,
+	action, outcome, reason, before, after, metadata`
+
+// AuditRepository implements audit.Store using PostgreSQL.
+// Searching the log reads from pools.Reader.
+type AuditRepository struct {
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(pools Pools) *AuditRepository {
+	return &AuditRepository{pool: pools.Writer, reader: pools.reader()}
+}
+
+// Save inserts an audit event.
+func (r *AuditRepository) Save(ctx context.Context, event *audit.Event) error {
+	id, err := uuid.Parse(event.ID)
+	if err != nil {
+		return apperrors.ValidationFailed("audit event ID must be a UUID")
+	}
+	before, err := marshalAuditJSON(event.Before)
+	if err != nil {
+		return apperrors.Wrap(err, "AuditRepository.Save", apperrors.CodeInternal, "failed to encode before state")
+	}
+	after, err := marshalAuditJSON(event.After)
+	if err != nil {
+		return apperrors.Wrap(err, "AuditRepository.Save", apperrors.CodeInternal, "failed to encode after state")
+	}
+	var metadata interface{}
+	if len(event.Metadata) > 0 {
+		if metadata, err = marshalAuditJSON(event.Metadata); err != nil {
+			return apperrors.Wrap(err, "AuditRepository.Save", apperrors.CodeInternal, "failed to encode metadata")
+		}
+	}
+
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO audit_events (` + auditEventColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
+		)`
+
+	_, err = r.pool.Exec(ctx, query,
+		id,
+		event.Timestamp,
+		string(event.Type),
+		string(event.Severity),
+		nullableString(event.ActorID),
+		nullableString(event.ActorType),
+		nullableString(event.ActorName),
+		nullableString(event.SourceIP),
+		nullableString(event.UserAgent),
+		nullableString(event.RequestID),
+		nullableString(event.SessionID),
+		nullableString(event.ResourceType),
+		nullableString(event.ResourceID),
+		nullableString(event.Action),
+		nullableString(event.Outcome),
+		nullableString(event.Reason),
+		before,
+		after,
+		metadata,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("AuditRepository.Save", err)
+	}
+	return nil
+}
+
+// List retrieves events matching the filter, newest first.
+func (r *AuditRepository) List(ctx context.Context, filter *audit.Filter, limit, offset int) ([]*audit.Event, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildAuditFilter(filter)
+	query := fmt.Sprintf(`SELECT %s FROM audit_events %s
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, auditEventColumns, whereClause, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.reader.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AuditRepository.List", err)
+	}
+	defer rows.Close()
+
+	var events []*audit.Event
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("AuditRepository.List", err)
+	}
+	return events, nil
+}
+
+// Count returns the number of events matching the filter.
+func (r *AuditRepository) Count(ctx context.Context, filter *audit.Filter) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildAuditFilter(filter)
+
+	var count int
+	if err := r.reader.QueryRow(ctx, `SELECT COUNT(*) FROM audit_events `+whereClause, args...).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("AuditRepository.Count", err)
+	}
+	return count, nil
+}
+
+// buildAuditFilter builds the WHERE clause for an audit event search. A type
+// ending in "." matches every type with that prefix.
+func buildAuditFilter(filter *audit.Filter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	equal := func(column, value string) {
+		if value != "" {
+			args = append(args, value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+	}
+
+	if t := string(filter.Type); strings.HasSuffix(t, ".") {
+		args = append(args, t)
+		conditions = append(conditions, fmt.Sprintf("left(type, length($%d)) = $%d", len(args), len(args)))
+	} else {
+		equal("type", t)
+	}
+	equal("actor_id", filter.ActorID)
+	equal("resource_type", filter.ResourceType)
+	equal("resource_id", filter.ResourceID)
+	equal("request_id", filter.RequestID)
+	equal("outcome", filter.Outcome)
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions = append(conditions, fmt.Sprintf("occurred_at < $%d", len(args)))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// marshalAuditJSON encodes a before, after or metadata value for a JSONB
+// column, or nil for NULL.
+func marshalAuditJSON(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func scanAuditEvent(row pgx.Row) (*audit.Event, error) {
+	event := &audit.Event{}
+	var id uuid.UUID
+	var eventType, severity string
+	var actorID, actorType, actorName, sourceIP, userAgent, requestID, sessionID *string
+	var resourceType, resourceID, action, outcome, reason *string
+	var before, after, metadata []byte
+	err := row.Scan(
+		&id,
+		&event.Timestamp,
+		&eventType,
+		&severity,
+		&actorID,
+		&actorType,
+		&actorName,
+		&sourceIP,
+		&userAgent,
+		&requestID,
+		&sessionID,
+		&resourceType,
+		&resourceID,
+		&action,
+		&outcome,
+		&reason,
+		&before,
+		&after,
+		&metadata,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError("AuditRepository.scan", err)
+	}
+
+	event.ID = id.String()
+	event.Type = audit.EventType(eventType)
+	event.Severity = audit.Severity(severity)
+	event.ActorID = stringValue(actorID)
+	event.ActorType = stringValue(actorType)
+	event.ActorName = stringValue(actorName)
+	event.SourceIP = stringValue(sourceIP)
+	event.UserAgent = stringValue(userAgent)
+	event.RequestID = stringValue(requestID)
+	event.SessionID = stringValue(sessionID)
+	event.ResourceType = stringValue(resourceType)
+	event.ResourceID = stringValue(resourceID)
+	event.Action = stringValue(action)
+	event.Outcome = stringValue(outcome)
+	event.Reason = stringValue(reason)
+	if len(before) > 0 {
+		event.Before = json.RawMessage(before)
+	}
+	if len(after) > 0 {
+		event.After = json.RawMessage(after)
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &event.Metadata); err != nil {
+			return nil, apperrors.DatabaseError("AuditRepository.scan", err)
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const backupColumns = `
+	id, status, triggered_by, storage_backend, storage_key, size_bytes, sha256,
+	error, requested_by, started_at, completed_at, expires_at`
+
+// BackupRepository implements domain.BackupRepository using PostgreSQL.
+type BackupRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackupRepository creates a new BackupRepository.
+func NewBackupRepository(pool *pgxpool.Pool) *BackupRepository {
+	return &BackupRepository{pool: pool}
+}
+
+// Create inserts a backup.
+func (r *BackupRepository) Create(ctx context.Context, backup *domain.Backup) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO backups (` + backupColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)`
+
+	_, err := r.pool.Exec(ctx, query,
+		backup.ID,
+		backup.Status,
+		backup.Trigger,
+		backup.StorageBackend,
+		backup.StorageKey,
+		backup.SizeBytes,
+		backup.SHA256,
+		backup.Error,
+		backup.RequestedBy,
+		backup.StartedAt,
+		backup.CompletedAt,
+		backup.ExpiresAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BackupRepository.Create", err)
+	}
+	return nil
+}
+
+// Update updates a backup's status, size, digest and error.
+func (r *BackupRepository) Update(ctx context.Context, backup *domain.Backup) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE backups SET
+			status = $2,
+			size_bytes = $3,
+			sha256 = $4,
+			error = $5,
+			completed_at = $6,
+			expires_at = $7
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		backup.ID,
+		backup.Status,
+		backup.SizeBytes,
+		backup.SHA256,
+		backup.Error,
+		backup.CompletedAt,
+		backup.ExpiresAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BackupRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("backup")
+	}
+	return nil
+}
+
+// Get retrieves a backup by ID.
+func (r *BackupRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Backup, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + backupColumns + ` FROM backups WHERE id = $1`
+	return scanBackup(r.pool.QueryRow(ctx, query, id))
+}
+
+// List retrieves the most recent backups, newest first.
+func (r *BackupRepository) List(ctx context.Context, limit int) ([]*domain.Backup, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + backupColumns + `
+		FROM backups
+		ORDER BY started_at DESC
+		LIMIT $1`
+	return r.list(ctx, "BackupRepository.List", query, limit)
+}
+
+// ListExpired retrieves up to limit finished backups whose retention ended
+// before now.
+func (r *BackupRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Backup, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + backupColumns + `
+		FROM backups
+		WHERE status <> 'running' AND expires_at IS NOT NULL AND expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT $2`
+	return r.list(ctx, "BackupRepository.ListExpired", query, now, limit)
+}
+
+// Delete removes a backup record.
+func (r *BackupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM backups WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("BackupRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("backup")
+	}
+	return nil
+}
+
+func (r *BackupRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Backup, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var backups []*domain.Backup
+	for rows.Next() {
+		backup, err := scanBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return backups, nil
+}
+
+func scanBackup(row pgx.Row) (*domain.Backup, error) {
+	backup := &domain.Backup{}
+	err := row.Scan(
+		&backup.ID,
+		&backup.Status,
+		&backup.Trigger,
+		&backup.StorageBackend,
+		&backup.StorageKey,
+		&backup.SizeBytes,
+		&backup.SHA256,
+		&backup.Error,
+		&backup.RequestedBy,
+		&backup.StartedAt,
+		&backup.CompletedAt,
+		&backup.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("backup")
+		}
+		return nil, apperrors.DatabaseError("BackupRepository.scan", err)
+	}
+	return backup, nil
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const blandSyncStateColumns = `kind, etag, item_count, synced_at, attempted_at, last_error`
+
+// BlandEntityRepository implements domain.BlandEntityRepository using PostgreSQL.
+type BlandEntityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBlandEntityRepository creates a new BlandEntityRepository.
+func NewBlandEntityRepository(pool *pgxpool.Pool) *BlandEntityRepository {
+	return &BlandEntityRepository{pool: pool}
+}
+
+// Replace swaps the cached entities of a kind and records the sync in a
+// single transaction, so readers never see a partial list.
+func (r *BlandEntityRepository) Replace(ctx context.Context, kind domain.BlandEntityKind, entities []*domain.BlandEntity, etag string, syncedAt time.Time) error {
+	ctx, cancel := WithTransactionTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("BlandEntityRepository.Replace", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM bland_entities WHERE kind = $1`, kind); err != nil {
+		return apperrors.DatabaseError("BlandEntityRepository.Replace", err)
+	}
+
+	for _, entity := range entities {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO bland_entities (kind, bland_id, position, payload, synced_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			kind, entity.BlandID, entity.Position, []byte(entity.Payload), syncedAt,
+		)
+		if err != nil {
+			return apperrors.DatabaseError("BlandEntityRepository.Replace", err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO bland_sync_state (kind, etag, item_count, synced_at, attempted_at, last_error)
+		VALUES ($1, $2, $3, $4, $4, '')
+		ON CONFLICT (kind) DO UPDATE SET
+			etag = EXCLUDED.etag,
+			item_count = EXCLUDED.item_count,
+			synced_at = EXCLUDED.synced_at,
+			attempted_at = EXCLUDED.attempted_at,
+			last_error = ''`,
+		kind, etag, len(entities), syncedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BlandEntityRepository.Replace", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("BlandEntityRepository.Replace", err)
+	}
+	return nil
+}
+
+// List retrieves the cached entities of a kind in Bland's order.
+func (r *BlandEntityRepository) List(ctx context.Context, kind domain.BlandEntityKind) ([]*domain.BlandEntity, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT kind, bland_id, position, payload, synced_at
+		FROM bland_entities
+		WHERE kind = $1
+		ORDER BY position ASC`,
+		kind,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError("BlandEntityRepository.List", err)
+	}
+	defer rows.Close()
+
+	var entities []*domain.BlandEntity
+	for rows.Next() {
+		entity := &domain.BlandEntity{}
+		var payload []byte
+		if err := rows.Scan(&entity.Kind, &entity.BlandID, &entity.Position, &payload, &entity.SyncedAt); err != nil {
+			return nil, apperrors.DatabaseError("BlandEntityRepository.List", err)
+		}
+		entity.Payload = payload
+		entities = append(entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("BlandEntityRepository.List", err)
+	}
+	return entities, nil
+}
+
+// GetSyncState retrieves the sync state of a kind.
+func (r *BlandEntityRepository) GetSyncState(ctx context.Context, kind domain.BlandEntityKind) (*domain.BlandSyncState, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + blandSyncStateColumns + ` FROM bland_sync_state WHERE kind = $1`
+	return scanBlandSyncState(r.pool.QueryRow(ctx, query, kind))
+}
+
+// ListSyncStates retrieves the sync state of every kind synced so far.
+func (r *BlandEntityRepository) ListSyncStates(ctx context.Context) ([]*domain.BlandSyncState, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+blandSyncStateColumns+` FROM bland_sync_state ORDER BY kind`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("BlandEntityRepository.ListSyncStates", err)
+	}
+	defer rows.Close()
+
+	var states []*domain.BlandSyncState
+	for rows.Next() {
+		state, err := scanBlandSyncState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("BlandEntityRepository.ListSyncStates", err)
+	}
+	return states, nil
+}
+
+// RecordSyncError records a failed sync, keeping the cached entities and the
+// time of the last successful sync.
+func (r *BlandEntityRepository) RecordSyncError(ctx context.Context, kind domain.BlandEntityKind, message string, at time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO bland_sync_state (kind, attempted_at, last_error)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (kind) DO UPDATE SET
+			attempted_at = EXCLUDED.attempted_at,
+			last_error = EXCLUDED.last_error`,
+		kind, at, message,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BlandEntityRepository.RecordSyncError", err)
+	}
+	return nil
+}
+
+func scanBlandSyncState(row pgx.Row) (*domain.BlandSyncState, error) {
+	state := &domain.BlandSyncState{}
+	err := row.Scan(
+		&state.Kind,
+		&state.ETag,
+		&state.ItemCount,
+		&state.SyncedAt,
+		&state.AttemptedAt,
+		&state.LastError,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("bland sync state")
+		}
+		return nil, apperrors.DatabaseError("BlandEntityRepository.scan", err)
+	}
+	return state, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// BlandWriteRepository implements domain.BlandWriteRepository using PostgreSQL.
+type BlandWriteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBlandWriteRepository creates a new BlandWriteRepository.
+func NewBlandWriteRepository(pool *pgxpool.Pool) *BlandWriteRepository {
+	return &BlandWriteRepository{pool: pool}
+}
+
+// Create queues a write.
+func (r *BlandWriteRepository) Create(ctx context.Context, write *domain.BlandWrite) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO bland_writes (id, operation, target_id, payload, status, attempts, last_error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		write.ID, write.Operation, write.TargetID, []byte(write.Payload), write.Status,
+		write.Attempts, write.LastError, write.CreatedAt, write.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BlandWriteRepository.Create", err)
+	}
+	return nil
+}
+
+// ListPending retrieves up to limit pending writes, oldest first.
+func (r *BlandWriteRepository) ListPending(ctx context.Context, limit int) ([]*domain.BlandWrite, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, operation, target_id, payload, status, attempts, last_error, created_at, updated_at, applied_at
+		FROM bland_writes
+		WHERE status = $1
+		ORDER BY created_at ASC
+		LIMIT $2`,
+		domain.BlandWriteStatusPending, limit,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError("BlandWriteRepository.ListPending", err)
+	}
+	defer rows.Close()
+
+	var writes []*domain.BlandWrite
+	for rows.Next() {
+		write := &domain.BlandWrite{}
+		var payload []byte
+		err := rows.Scan(
+			&write.ID,
+			&write.Operation,
+			&write.TargetID,
+			&payload,
+			&write.Status,
+			&write.Attempts,
+			&write.LastError,
+			&write.CreatedAt,
+			&write.UpdatedAt,
+			&write.AppliedAt,
+		)
+		if err != nil {
+			return nil, apperrors.DatabaseError("BlandWriteRepository.ListPending", err)
+		}
+		write.Payload = payload
+		writes = append(writes, write)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("BlandWriteRepository.ListPending", err)
+	}
+	return writes, nil
+}
+
+// Update saves the status, attempts and error of a write.
+func (r *BlandWriteRepository) Update(ctx context.Context, write *domain.BlandWrite) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE bland_writes
+		SET status = $2, attempts = $3, last_error = $4, updated_at = $5, applied_at = $6
+		WHERE id = $1`,
+		write.ID, write.Status, write.Attempts, write.LastError, write.UpdatedAt, write.AppliedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("BlandWriteRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("bland write")
+	}
+	return nil
+}
+
+// CountPending returns the number of pending writes.
+func (r *BlandWriteRepository) CountPending(ctx context.Context) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM bland_writes WHERE status = $1`, domain.BlandWriteStatusPending).Scan(&count)
+	if err != nil {
+		return 0, apperrors.DatabaseError("BlandWriteRepository.CountPending", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const blockedNumberColumns = `id, phone_number, direction, reason, bland_id, created_at, updated_at`
+
+// BlocklistRepository implements domain.BlocklistRepository using PostgreSQL.
+type BlocklistRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBlocklistRepository creates a new BlocklistRepository.
+func NewBlocklistRepository(pool *pgxpool.Pool) *BlocklistRepository {
+	return &BlocklistRepository{pool: pool}
+}
+
+// Upsert inserts an entry or updates the entry for the same number or
+// prefix. A Bland ID already stored is kept if the entry has none.
+func (r *BlocklistRepository) Upsert(ctx context.Context, entry *domain.BlockedNumber) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO blocked_numbers (` + blockedNumberColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (phone_number) DO UPDATE SET
+			direction = EXCLUDED.direction,
+			reason = EXCLUDED.reason,
+			bland_id = COALESCE(EXCLUDED.bland_id, blocked_numbers.bland_id),
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + blockedNumberColumns
+
+	stored, err := scanBlockedNumber(r.pool.QueryRow(ctx, query,
+		entry.ID,
+		entry.PhoneNumber,
+		entry.Direction,
+		nullableString(entry.Reason),
+		nullableString(entry.BlandID),
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	))
+	if err != nil {
+		return err
+	}
+	*entry = *stored
+	return nil
+}
+
+// GetByID retrieves an entry by ID.
+func (r *BlocklistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.BlockedNumber, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + blockedNumberColumns + ` FROM blocked_numbers WHERE id = $1`
+	return scanBlockedNumber(r.pool.QueryRow(ctx, query, id))
+}
+
+// GetByBlandID retrieves an entry by Bland's ID for the block.
+func (r *BlocklistRepository) GetByBlandID(ctx context.Context, blandID string) (*domain.BlockedNumber, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + blockedNumberColumns + ` FROM blocked_numbers WHERE bland_id = $1`
+	return scanBlockedNumber(r.pool.QueryRow(ctx, query, blandID))
+}
+
+// Delete removes an entry.
+func (r *BlocklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM blocked_numbers WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("BlocklistRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("blocked number")
+	}
+	return nil
+}
+
+// List retrieves all entries ordered by number.
+func (r *BlocklistRepository) List(ctx context.Context) ([]*domain.BlockedNumber, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + blockedNumberColumns + ` FROM blocked_numbers ORDER BY phone_number ASC`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("BlocklistRepository.List", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.BlockedNumber
+	for rows.Next() {
+		entry, err := scanBlockedNumber(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("BlocklistRepository.List", err)
+	}
+	return entries, nil
+}
+
+// Match returns the most specific entry that blocks phoneNumber in the
+// given direction: the number itself, or else its longest blocked prefix.
+func (r *BlocklistRepository) Match(ctx context.Context, phoneNumber string, direction domain.BlockDirection) (*domain.BlockedNumber, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + blockedNumberColumns + `
+		FROM blocked_numbers
+		WHERE direction IN ($2, 'both')
+			AND (
+				phone_number = $1
+				OR (phone_number LIKE '%*' AND starts_with($1, rtrim(phone_number, '*')))
+			)
+		ORDER BY length(phone_number) DESC
+		LIMIT 1`
+	return scanBlockedNumber(r.pool.QueryRow(ctx, query, phoneNumber, direction))
+}
+
+func scanBlockedNumber(row pgx.Row) (*domain.BlockedNumber, error) {
+	entry := &domain.BlockedNumber{}
+	var reason, blandID *string
+	err := row.Scan(
+		&entry.ID,
+		&entry.PhoneNumber,
+		&entry.Direction,
+		&reason,
+		&blandID,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("blocked number")
+		}
+		return nil, apperrors.DatabaseError("BlocklistRepository.scan", err)
+	}
+	entry.Reason = stringValue(reason)
+	entry.BlandID = stringValue(blandID)
+	return entry, nil
+}
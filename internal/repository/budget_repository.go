@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// BudgetRepository implements domain.BudgetRepository using PostgreSQL.
+type BudgetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBudgetRepository creates a new BudgetRepository.
+func NewBudgetRepository(pool *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{pool: pool}
+}
+
+// CreateOverride inserts an override.
+func (r *BudgetRepository) CreateOverride(ctx context.Context, o *domain.BudgetOverride) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO budget_overrides (id, reason, created_by, expires_at, revoked_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.pool.Exec(ctx, query, o.ID, o.Reason, o.CreatedBy, o.ExpiresAt, o.RevokedAt, o.CreatedAt)
+	if err != nil {
+		return apperrors.DatabaseError("BudgetRepository.CreateOverride", err)
+	}
+	return nil
+}
+
+// GetActiveOverride retrieves the override in effect at now with the latest
+// expiry, or nil if there is none.
+func (r *BudgetRepository) GetActiveOverride(ctx context.Context, now time.Time) (*domain.BudgetOverride, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, reason, created_by, expires_at, revoked_at, created_at
+		FROM budget_overrides
+		WHERE revoked_at IS NULL AND expires_at > $1
+		ORDER BY expires_at DESC
+		LIMIT 1`
+
+	o := &domain.BudgetOverride{}
+	err := r.pool.QueryRow(ctx, query, now).Scan(
+		&o.ID,
+		&o.Reason,
+		&o.CreatedBy,
+		&o.ExpiresAt,
+		&o.RevokedAt,
+		&o.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.DatabaseError("BudgetRepository.GetActiveOverride", err)
+	}
+	return o, nil
+}
+
+// RevokeOverrides revokes every override in effect at now, returning how
+// many were revoked.
+func (r *BudgetRepository) RevokeOverrides(ctx context.Context, now time.Time) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE budget_overrides
+		SET revoked_at = $1
+		WHERE revoked_at IS NULL AND expires_at > $1`
+
+	result, err := r.pool.Exec(ctx, query, now)
+	if err != nil {
+		return 0, apperrors.DatabaseError("BudgetRepository.RevokeOverrides", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// AddPausedBatch records a batch paused by the budget guard.
+func (r *BudgetRepository) AddPausedBatch(ctx context.Context, batchID string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO budget_paused_batches (batch_id)
+		VALUES ($1)
+		ON CONFLICT (batch_id) DO NOTHING`
+
+	if _, err := r.pool.Exec(ctx, query, batchID); err != nil {
+		return apperrors.DatabaseError("BudgetRepository.AddPausedBatch", err)
+	}
+	return nil
+}
+
+// ListPausedBatches retrieves the batches paused by the budget guard, in the
+// order they were paused.
+func (r *BudgetRepository) ListPausedBatches(ctx context.Context) ([]string, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT batch_id FROM budget_paused_batches ORDER BY paused_at, batch_id`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("BudgetRepository.ListPausedBatches", err)
+	}
+	defer rows.Close()
+
+	var batchIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, apperrors.DatabaseError("BudgetRepository.ListPausedBatches", err)
+		}
+		batchIDs = append(batchIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("BudgetRepository.ListPausedBatches", err)
+	}
+	return batchIDs, nil
+}
+
+// RemovePausedBatch forgets a batch paused by the budget guard.
+func (r *BudgetRepository) RemovePausedBatch(ctx context.Context, batchID string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM budget_paused_batches WHERE batch_id = $1`, batchID); err != nil {
+		return apperrors.DatabaseError("BudgetRepository.RemovePausedBatch", err)
+	}
+	return nil
+}
+
+// CallMinutesSince sums the duration of calls that ended at or after since,
+// including deleted calls, which were billed all the same.
+func (r *BudgetRepository) CallMinutesSince(ctx context.Context, since time.Time) (float64, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT (COALESCE(SUM(duration_seconds), 0) / 60.0)::float8
+		FROM calls
+		WHERE ended_at IS NOT NULL AND ended_at >= $1`
+
+	var minutes float64
+	if err := r.pool.QueryRow(ctx, query, since).Scan(&minutes); err != nil {
+		return 0, apperrors.DatabaseError("BudgetRepository.CallMinutesSince", err)
+	}
+	return minutes, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const callAttemptColumns = `
+	id, original_call_id, previous_call_id, call_id, prompt_id, phone_number,
+	attempt, reason, status, scheduled_at, last_error, cancel_reason,
+	created_at, updated_at`
+
+// CallAttemptRepository implements domain.CallAttemptRepository using PostgreSQL.
+type CallAttemptRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCallAttemptRepository creates a new CallAttemptRepository.
+func NewCallAttemptRepository(pool *pgxpool.Pool) *CallAttemptRepository {
+	return &CallAttemptRepository{pool: pool}
+}
+
+// Create inserts an attempt. A call gets at most one retry, so a repeated
+// webhook for the same unanswered call inserts nothing and returns false.
+func (r *CallAttemptRepository) Create(ctx context.Context, attempt *domain.CallAttempt) (bool, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO call_attempts (` + callAttemptColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+		)
+		ON CONFLICT (previous_call_id) DO NOTHING`
+
+	result, err := r.pool.Exec(ctx, query,
+		attempt.ID,
+		attempt.OriginalCallID,
+		attempt.PreviousCallID,
+		attempt.CallID,
+		attempt.PromptID,
+		attempt.PhoneNumber,
+		attempt.Attempt,
+		attempt.Reason,
+		attempt.Status,
+		attempt.ScheduledAt,
+		attempt.LastError,
+		nullableString(attempt.CancelReason),
+		attempt.CreatedAt,
+		attempt.UpdatedAt,
+	)
+	if err != nil {
+		return false, apperrors.DatabaseError("CallAttemptRepository.Create", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// GetByCallID retrieves the attempt that placed a call.
+func (r *CallAttemptRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.CallAttempt, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + callAttemptColumns + `
+		FROM call_attempts
+		WHERE call_id = $1`
+	return scanCallAttempt(r.pool.QueryRow(ctx, query, callID))
+}
+
+// ClaimDue returns up to limit scheduled attempts that are due and pushes
+// them back by lease. Rows locked by a concurrent claim are skipped, so each
+// attempt goes to one worker; an attempt whose worker dies is retried once
+// the lease runs out.
+func (r *CallAttemptRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.CallAttempt, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE call_attempts SET scheduled_at = $1::timestamptz + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM call_attempts
+			WHERE status = 'scheduled' AND scheduled_at <= $1
+			ORDER BY scheduled_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + callAttemptColumns
+
+	return r.list(ctx, "CallAttemptRepository.ClaimDue", query, now, lease.Seconds(), limit)
+}
+
+// Update updates an attempt.
+func (r *CallAttemptRepository) Update(ctx context.Context, attempt *domain.CallAttempt) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE call_attempts SET
+			call_id = $2,
+			status = $3,
+			scheduled_at = $4,
+			last_error = $5,
+			cancel_reason = $6,
+			updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		attempt.ID,
+		attempt.CallID,
+		attempt.Status,
+		attempt.ScheduledAt,
+		attempt.LastError,
+		nullableString(attempt.CancelReason),
+		attempt.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CallAttemptRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("call attempt")
+	}
+	return nil
+}
+
+// CancelScheduled cancels the scheduled attempts to a phone number.
+func (r *CallAttemptRepository) CancelScheduled(ctx context.Context, phoneNumber, reason string) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE call_attempts SET
+			status = 'cancelled',
+			cancel_reason = $2,
+			updated_at = NOW()
+		WHERE phone_number = $1 AND status = 'scheduled'`
+
+	result, err := r.pool.Exec(ctx, query, phoneNumber, reason)
+	if err != nil {
+		return 0, apperrors.DatabaseError("CallAttemptRepository.CancelScheduled", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ListByOriginalCallID retrieves the retries of a call in attempt order.
+func (r *CallAttemptRepository) ListByOriginalCallID(ctx context.Context, callID uuid.UUID) ([]*domain.CallAttempt, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + callAttemptColumns + `
+		FROM call_attempts
+		WHERE original_call_id = $1
+		ORDER BY attempt ASC`
+	return r.list(ctx, "CallAttemptRepository.ListByOriginalCallID", query, callID)
+}
+
+func (r *CallAttemptRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.CallAttempt, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var attempts []*domain.CallAttempt
+	for rows.Next() {
+		attempt, err := scanCallAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return attempts, nil
+}
+
+func scanCallAttempt(row pgx.Row) (*domain.CallAttempt, error) {
+	attempt := &domain.CallAttempt{}
+	var cancelReason *string
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.OriginalCallID,
+		&attempt.PreviousCallID,
+		&attempt.CallID,
+		&attempt.PromptID,
+		&attempt.PhoneNumber,
+		&attempt.Attempt,
+		&attempt.Reason,
+		&attempt.Status,
+		&attempt.ScheduledAt,
+		&attempt.LastError,
+		&cancelReason,
+		&attempt.CreatedAt,
+		&attempt.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("call attempt")
+		}
+		return nil, apperrors.DatabaseError("CallAttemptRepository.scan", err)
+	}
+	attempt.CancelReason = stringValue(cancelReason)
+	return attempt, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const callEventColumns = `
+	id, call_id, status, source, detail, actor_id, occurred_at, created_at`
+
+// CallEventRepository implements domain.CallEventRepository using PostgreSQL.
+type CallEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCallEventRepository creates a new CallEventRepository.
+func NewCallEventRepository(pool *pgxpool.Pool) *CallEventRepository {
+	return &CallEventRepository{pool: pool}
+}
+
+// Append records an event.
+func (r *CallEventRepository) Append(ctx context.Context, e *domain.CallEvent) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO call_events (` + callEventColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	var detail *string
+	if e.Detail != "" {
+		detail = &e.Detail
+	}
+	_, err := r.pool.Exec(ctx, query,
+		e.ID,
+		e.CallID,
+		e.Status,
+		e.Source,
+		detail,
+		e.ActorID,
+		e.OccurredAt,
+		e.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CallEventRepository.Append", err)
+	}
+	return nil
+}
+
+// ListByCall retrieves a call's events in the order they were recorded.
+func (r *CallEventRepository) ListByCall(ctx context.Context, callID uuid.UUID) ([]*domain.CallEvent, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + callEventColumns + ` FROM call_events WHERE call_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.pool.Query(ctx, query, callID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CallEventRepository.ListByCall", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.CallEvent
+	for rows.Next() {
+		e := &domain.CallEvent{}
+		var detail *string
+		if err := rows.Scan(&e.ID, &e.CallID, &e.Status, &e.Source, &detail, &e.ActorID, &e.OccurredAt, &e.CreatedAt); err != nil {
+			return nil, apperrors.DatabaseError("CallEventRepository.ListByCall", err)
+		}
+		if detail != nil {
+			e.Detail = *detail
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CallEventRepository.ListByCall", err)
+	}
+
+	return events, nil
+}
@@ -18,13 +18,15 @@ import (
 )
 
 // CallRepository implements domain.CallRepository using PostgreSQL.
+// Listing, counting and searching calls read from pools.Reader.
 type CallRepository struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool
 }
 
 // NewCallRepository creates a new CallRepository.
-func NewCallRepository(pool *pgxpool.Pool) *CallRepository {
-	return &CallRepository{pool: pool}
+func NewCallRepository(pools Pools) *CallRepository {
+	return &CallRepository{pool: pools.Writer, reader: pools.reader()}
 }
 
 // Create inserts a new call record.
@@ -53,13 +55,16 @@ func (r *CallRepository) Create(ctx context.Context, call *domain.Call) error {
 			status, started_at, ended_at, duration_seconds, transcript,
 			transcript_json, recording_url, quote_summary, extracted_data,
 			error_message, provider_summary, provider_disposition, provider_metadata,
-			quote_job_id, created_at, updated_at
+			quote_job_id, customer_id, prompt_id, created_at, updated_at,
+			billed_number, billed_outbound, billed_minutes, rate_per_minute,
+			call_cost, transcription_cost, analysis_cost, total_cost
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16,
-			$17, $18, $19, $20, $21
+			$17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30,
+			$31, $32
 		)`
 
-	_, err = r.pool.Exec(ctx, query,
+	args := []interface{}{
 		call.ID,
 		call.ProviderCallID,
 		call.Provider,
@@ -80,9 +85,14 @@ func (r *CallRepository) Create(ctx context.Context, call *domain.Call) error {
 		call.ProviderDisposition,
 		providerMetadataJSON,
 		call.QuoteJobID,
+		call.CustomerID,
+		call.PromptID,
 		call.CreatedAt,
 		call.UpdatedAt,
-	)
+	}
+	args = append(args, callCostArgs(call.Cost)...)
+
+	_, err = r.pool.Exec(ctx, query, args...)
 	if err != nil {
 		return apperrors.DatabaseError("CallRepository.Create", err)
 	}
@@ -101,7 +111,10 @@ func (r *CallRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Cal
 			status, started_at, ended_at, duration_seconds, transcript,
 			transcript_json, recording_url, quote_summary, extracted_data,
 			error_message, provider_summary, provider_disposition, provider_metadata,
-			quote_job_id, created_at, updated_at, deleted_at
+			quote_job_id, customer_id, prompt_id, billed_number, billed_outbound,
+			billed_minutes, rate_per_minute, call_cost, transcription_cost, analysis_cost,
+			total_cost, sentiment, intent, urgency, tagged_at, spam_score, disposition,
+			disposition_source, assigned_to, created_at, updated_at, deleted_at, archived_at
 		FROM calls
 		WHERE id = $1 AND deleted_at IS NULL`
 
@@ -119,7 +132,10 @@ func (r *CallRepository) GetByProviderCallID(ctx context.Context, providerCallID
 			status, started_at, ended_at, duration_seconds, transcript,
 			transcript_json, recording_url, quote_summary, extracted_data,
 			error_message, provider_summary, provider_disposition, provider_metadata,
-			quote_job_id, created_at, updated_at, deleted_at
+			quote_job_id, customer_id, prompt_id, billed_number, billed_outbound,
+			billed_minutes, rate_per_minute, call_cost, transcription_cost, analysis_cost,
+			total_cost, sentiment, intent, urgency, tagged_at, spam_score, disposition,
+			disposition_source, assigned_to, created_at, updated_at, deleted_at, archived_at
 		FROM calls
 		WHERE provider_call_id = $1 AND deleted_at IS NULL`
 
@@ -168,11 +184,20 @@ func (r *CallRepository) Update(ctx context.Context, call *domain.Call) error {
 			provider_disposition = $17,
 			provider_metadata = $18,
 			quote_job_id = $19,
-			updated_at = $20,
-			deleted_at = $21
+			customer_id = $20,
+			updated_at = $21,
+			deleted_at = $22,
+			billed_number = $23,
+			billed_outbound = $24,
+			billed_minutes = $25,
+			rate_per_minute = $26,
+			call_cost = $27,
+			transcription_cost = $28,
+			analysis_cost = $29,
+			total_cost = $30
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.pool.Exec(ctx, query,
+	args := []interface{}{
 		call.ID,
 		call.Provider,
 		call.PhoneNumber,
@@ -192,9 +217,13 @@ func (r *CallRepository) Update(ctx context.Context, call *domain.Call) error {
 		call.ProviderDisposition,
 		providerMetadataJSON,
 		call.QuoteJobID,
+		call.CustomerID,
 		call.UpdatedAt,
 		call.DeletedAt,
-	)
+	}
+	args = append(args, callCostArgs(call.Cost)...)
+
+	result, err := r.pool.Exec(ctx, query, args...)
 	if err != nil {
 		return apperrors.DatabaseError("CallRepository.Update", err)
 	}
@@ -206,27 +235,185 @@ func (r *CallRepository) Update(ctx context.Context, call *domain.Call) error {
 	return nil
 }
 
-// Delete soft-deletes a call by setting deleted_at.
+// Delete soft-deletes a call and its quote by setting deleted_at.
 func (r *CallRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ctx, cancel := WithWriteTimeout(ctx)
 	defer cancel()
 
-	now := time.Now().UTC()
 	query := `
-		UPDATE calls SET
-			deleted_at = $2,
-			updated_at = $2
-		WHERE id = $1 AND deleted_at IS NULL`
+		WITH deleted AS (
+			UPDATE calls SET
+				deleted_at = $2,
+				updated_at = $2
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET deleted_at = $2
+			WHERE call_id IN (SELECT id FROM deleted)
+		)
+		SELECT COUNT(*) FROM deleted`
+
+	return r.execCallChange(ctx, "CallRepository.Delete", query, id, time.Now().UTC())
+}
+
+// Restore clears deleted_at on a soft-deleted call and its quote.
+func (r *CallRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH restored AS (
+			UPDATE calls SET
+				deleted_at = NULL,
+				updated_at = $2
+			WHERE id = $1 AND deleted_at IS NOT NULL
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET deleted_at = NULL
+			WHERE call_id IN (SELECT id FROM restored)
+		)
+		SELECT COUNT(*) FROM restored`
+
+	return r.execCallChange(ctx, "CallRepository.Restore", query, id, time.Now().UTC())
+}
+
+// Archive sets archived_at on a call and its quote, keeping an earlier
+// archive time.
+func (r *CallRepository) Archive(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH archived AS (
+			UPDATE calls SET archived_at = COALESCE(archived_at, $2)
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET archived_at = COALESCE(archived_at, $2)
+			WHERE call_id IN (SELECT id FROM archived)
+		)
+		SELECT COUNT(*) FROM archived`
+
+	return r.execCallChange(ctx, "CallRepository.Archive", query, id, time.Now().UTC())
+}
 
-	result, err := r.pool.Exec(ctx, query, id, now)
+// Unarchive clears archived_at on a call and its quote.
+func (r *CallRepository) Unarchive(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH unarchived AS (
+			UPDATE calls SET archived_at = NULL
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET archived_at = NULL
+			WHERE call_id IN (SELECT id FROM unarchived)
+		)
+		SELECT COUNT(*) FROM unarchived`
+
+	return r.execCallChange(ctx, "CallRepository.Unarchive", query, id)
+}
+
+// ArchiveCreatedBefore archives up to limit finished calls created before
+// cutoff, oldest first, along with their quotes. Calls still pending or in
+// progress are left alone.
+func (r *CallRepository) ArchiveCreatedBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		WITH batch AS (
+			SELECT id FROM calls
+			WHERE deleted_at IS NULL AND archived_at IS NULL
+				AND created_at < $1 AND status NOT IN ('pending', 'in_progress')
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), archived AS (
+			UPDATE calls SET archived_at = $2
+			WHERE id IN (SELECT id FROM batch)
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET archived_at = COALESCE(archived_at, $2)
+			WHERE call_id IN (SELECT id FROM archived)
+		)
+		SELECT COUNT(*) FROM archived`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, cutoff, time.Now().UTC(), limit).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("CallRepository.ArchiveCreatedBefore", err)
+	}
+	return count, nil
+}
+
+// ArchiveMany sets archived_at on calls and their quotes, keeping earlier
+// archive times.
+func (r *CallRepository) ArchiveMany(ctx context.Context, ids []uuid.UUID) error {
+	query := `
+		WITH archived AS (
+			UPDATE calls SET archived_at = COALESCE(archived_at, $2)
+			WHERE id = ANY($1) AND deleted_at IS NULL
+			RETURNING id
+		), quote AS (
+			UPDATE quotes SET archived_at = COALESCE(archived_at, $2)
+			WHERE call_id IN (SELECT id FROM archived)
+		)
+		SELECT COUNT(*) FROM archived`
+
+	return r.changeAll(ctx, "CallRepository.ArchiveMany", len(ids), query, ids, time.Now().UTC())
+}
+
+// Assign sets assigned_to on calls.
+func (r *CallRepository) Assign(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) error {
+	query := `
+		WITH assigned AS (
+			UPDATE calls SET assigned_to = $2, updated_at = $3
+			WHERE id = ANY($1) AND deleted_at IS NULL
+			RETURNING id
+		)
+		SELECT COUNT(*) FROM assigned`
+
+	return r.changeAll(ctx, "CallRepository.Assign", len(ids), query, ids, userID, time.Now().UTC())
+}
+
+// changeAll runs a statement that changes calls and returns how many it
+// changed, rolling it back unless it changed all want of them.
+func (r *CallRepository) changeAll(ctx context.Context, op string, want int, query string, args ...interface{}) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return apperrors.DatabaseError("CallRepository.Delete", err)
+		return apperrors.DatabaseError(op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	if result.RowsAffected() == 0 {
+	var count int
+	if err := tx.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return apperrors.DatabaseError(op, err)
+	}
+	if count != want {
 		return apperrors.NotFound("call")
 	}
 
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError(op, err)
+	}
+	return nil
+}
+
+// execCallChange runs a statement that changes one call and returns the
+// number of calls it changed, reporting a missing call as not found.
+func (r *CallRepository) execCallChange(ctx context.Context, op, query string, args ...interface{}) error {
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return apperrors.DatabaseError(op, err)
+	}
+	if count == 0 {
+		return apperrors.NotFound("call")
+	}
 	return nil
 }
 
@@ -247,7 +434,62 @@ func (r *CallRepository) SetQuoteJobID(ctx context.Context, callID uuid.UUID, jo
 	return nil
 }
 
-// List retrieves calls with pagination, ordered by creation time descending (excludes soft-deleted).
+// SetTags stores the tags classified for a call.
+func (r *CallRepository) SetTags(ctx context.Context, callID uuid.UUID, tags *domain.CallTags) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE calls
+		SET sentiment = $2,
+		    intent = $3,
+		    urgency = $4,
+		    tagged_at = $5
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, callID, string(tags.Sentiment), string(tags.Intent), string(tags.Urgency), tags.TaggedAt)
+	if err != nil {
+		return apperrors.DatabaseError("CallRepository.SetTags", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("call")
+	}
+	return nil
+}
+
+// SetSpamScore stores the spam score an inbound call was given.
+func (r *CallRepository) SetSpamScore(ctx context.Context, callID uuid.UUID, score int) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `UPDATE calls SET spam_score = $2 WHERE id = $1 AND deleted_at IS NULL`, callID, score)
+	if err != nil {
+		return apperrors.DatabaseError("CallRepository.SetSpamScore", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("call")
+	}
+	return nil
+}
+
+// SetDisposition stores a call's disposition and where it came from.
+func (r *CallRepository) SetDisposition(ctx context.Context, callID uuid.UUID, disposition domain.CallDisposition, source domain.DispositionSource) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE calls SET disposition = $2, disposition_source = $3 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.pool.Exec(ctx, query, callID, string(disposition), string(source))
+	if err != nil {
+		return apperrors.DatabaseError("CallRepository.SetDisposition", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("call")
+	}
+	return nil
+}
+
+// List retrieves calls with pagination in the filter's order, newest first
+// by default (excludes soft-deleted).
 func (r *CallRepository) List(ctx context.Context, filter *domain.CallListFilter, limit, offset int) ([]*domain.Call, error) {
 	ctx, cancel := WithListQueryTimeout(ctx)
 	defer cancel()
@@ -258,22 +500,30 @@ func (r *CallRepository) List(ctx context.Context, filter *domain.CallListFilter
 			status, started_at, ended_at, duration_seconds, transcript,
 			transcript_json, recording_url, quote_summary, extracted_data,
 			error_message, provider_summary, provider_disposition, provider_metadata,
-			quote_job_id, created_at, updated_at, deleted_at
+			quote_job_id, customer_id, prompt_id, billed_number, billed_outbound,
+			billed_minutes, rate_per_minute, call_cost, transcription_cost, analysis_cost,
+			total_cost, sentiment, intent, urgency, tagged_at, spam_score, disposition,
+			disposition_source, assigned_to, created_at, updated_at, deleted_at, archived_at
 		FROM calls`
 
 	whereClause, args := buildCallFilter(filter)
 	paramIndex := len(args) + 1
 
+	direction := "DESC"
+	if filter != nil && filter.Sort == domain.CallSortOldest {
+		direction = "ASC"
+	}
+
 	query := fmt.Sprintf(`%s %s
-		ORDER BY created_at DESC
-		LIMIT $%d OFFSET $%d`, baseQuery, whereClause, paramIndex, paramIndex+1)
+		ORDER BY created_at %s, id %s
+		LIMIT $%d OFFSET $%d`, baseQuery, whereClause, direction, direction, paramIndex, paramIndex+1)
 
 	args = append(args, limit, offset)
 
 	return r.scanCalls(ctx, query, args...)
 }
 
-// Count returns the total number of active (non-deleted) calls.
+// Count returns the number of non-deleted calls matching the filter.
 func (r *CallRepository) Count(ctx context.Context, filter *domain.CallListFilter) (int, error) {
 	ctx, cancel := WithQueryTimeout(ctx)
 	defer cancel()
@@ -283,17 +533,135 @@ func (r *CallRepository) Count(ctx context.Context, filter *domain.CallListFilte
 	query := fmt.Sprintf(`SELECT COUNT(*) FROM calls %s`, whereClause)
 
 	var count int
-	err := r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := r.reader.QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, apperrors.DatabaseError("CallRepository.Count", err)
 	}
 	return count, nil
 }
 
+// transcriptHeadlineOptions controls the ts_headline excerpt returned with search results.
+var transcriptHeadlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "`,
+	domain.TranscriptHighlightStart, domain.TranscriptHighlightStop,
+)
+
+// SearchTranscripts returns calls whose transcript matches a web-search style
+// query (quoted phrases, OR, -exclusions), ranked by relevance.
+func (r *CallRepository) SearchTranscripts(ctx context.Context, query string, limit, offset int) ([]*domain.TranscriptMatch, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		SELECT id, caller_name, phone_number, status, created_at, rank,
+			ts_headline('english', COALESCE(transcript, ''), q, $2)
+		FROM (
+			SELECT c.id, c.caller_name, c.phone_number, c.status, c.created_at, c.transcript, q,
+				ts_rank_cd(c.transcript_tsv, q) AS rank
+			FROM calls c, websearch_to_tsquery('english', $1) q
+			WHERE c.deleted_at IS NULL AND c.transcript_tsv @@ q
+			ORDER BY rank DESC, c.created_at DESC
+			LIMIT $3 OFFSET $4
+		) ranked
+		ORDER BY rank DESC, created_at DESC`
+
+	rows, err := r.reader.Query(ctx, sql, query, transcriptHeadlineOptions, limit, offset)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CallRepository.SearchTranscripts", err)
+	}
+	defer rows.Close()
+
+	var matches []*domain.TranscriptMatch
+	for rows.Next() {
+		m := &domain.TranscriptMatch{}
+		if err := rows.Scan(&m.CallID, &m.CallerName, &m.PhoneNumber, &m.Status, &m.CreatedAt, &m.Rank, &m.Headline); err != nil {
+			return nil, apperrors.DatabaseError("CallRepository.SearchTranscripts", err)
+		}
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CallRepository.SearchTranscripts", err)
+	}
+
+	return matches, nil
+}
+
+// CountTranscriptMatches returns the number of calls whose transcript matches query.
+func (r *CallRepository) CountTranscriptMatches(ctx context.Context, query string) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		SELECT COUNT(*)
+		FROM calls
+		WHERE deleted_at IS NULL AND transcript_tsv @@ websearch_to_tsquery('english', $1)`
+
+	var count int
+	if err := r.reader.QueryRow(ctx, sql, query).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("CallRepository.CountTranscriptMatches", err)
+	}
+	return count, nil
+}
+
+// ListCostRollups totals the costs of priced calls by UTC month and billed
+// number for calls that ended at or after since. Deleted calls are included;
+// they were billed all the same.
+func (r *CallRepository) ListCostRollups(ctx context.Context, since time.Time) ([]*domain.CallCostRollup, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			date_trunc('month', COALESCE(ended_at, created_at), 'UTC') AS month,
+			COALESCE(billed_number, ''),
+			COUNT(*),
+			COALESCE(SUM(billed_minutes), 0)::float8,
+			COALESCE(SUM(call_cost), 0)::float8,
+			COALESCE(SUM(transcription_cost), 0)::float8,
+			COALESCE(SUM(analysis_cost), 0)::float8,
+			SUM(total_cost)::float8 AS total
+		FROM calls
+		WHERE total_cost IS NOT NULL AND COALESCE(ended_at, created_at) >= $1
+		GROUP BY 1, 2
+		ORDER BY month DESC, total DESC, 2`
+
+	rows, err := r.reader.Query(ctx, query, since)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CallRepository.ListCostRollups", err)
+	}
+	defer rows.Close()
+
+	var rollups []*domain.CallCostRollup
+	for rows.Next() {
+		rollup := &domain.CallCostRollup{}
+		if err := rows.Scan(
+			&rollup.Month,
+			&rollup.PhoneNumber,
+			&rollup.Calls,
+			&rollup.Minutes,
+			&rollup.CallCost,
+			&rollup.TranscriptionCost,
+			&rollup.AnalysisCost,
+			&rollup.TotalCost,
+		); err != nil {
+			return nil, apperrors.DatabaseError("CallRepository.ListCostRollups", err)
+		}
+		rollup.Month = rollup.Month.UTC()
+		rollups = append(rollups, rollup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CallRepository.ListCostRollups", err)
+	}
+
+	return rollups, nil
+}
+
 // scanCall scans a single call from a query.
 func (r *CallRepository) scanCall(ctx context.Context, query string, args ...interface{}) (*domain.Call, error) {
 	call := &domain.Call{}
 	var transcriptJSON, extractedDataJSON, providerMetadataJSON []byte
+	var cost callCostColumns
+	var tags callTagColumns
 
 	err := r.pool.QueryRow(ctx, query, args...).Scan(
 		&call.ID,
@@ -316,9 +684,28 @@ func (r *CallRepository) scanCall(ctx context.Context, query string, args ...int
 		&call.ProviderDisposition,
 		&providerMetadataJSON,
 		&call.QuoteJobID,
+		&call.CustomerID,
+		&call.PromptID,
+		&cost.billedNumber,
+		&cost.outbound,
+		&cost.minutes,
+		&cost.rate,
+		&cost.base,
+		&cost.transcription,
+		&cost.analysis,
+		&cost.total,
+		&tags.sentiment,
+		&tags.intent,
+		&tags.urgency,
+		&tags.taggedAt,
+		&call.SpamScore,
+		&call.Disposition,
+		&call.DispositionSource,
+		&call.AssignedTo,
 		&call.CreatedAt,
 		&call.UpdatedAt,
 		&call.DeletedAt,
+		&call.ArchivedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -347,13 +734,15 @@ func (r *CallRepository) scanCall(ctx context.Context, query string, args ...int
 		}
 		call.ProviderMetadata = metadata
 	}
+	call.Cost = cost.callCost()
+	call.Tags = tags.callTags()
 
 	return call, nil
 }
 
 // scanCalls scans multiple calls from a query.
 func (r *CallRepository) scanCalls(ctx context.Context, query string, args ...interface{}) ([]*domain.Call, error) {
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.reader.Query(ctx, query, args...)
 	if err != nil {
 		return nil, apperrors.DatabaseError("CallRepository.scanCalls", err)
 	}
@@ -363,6 +752,8 @@ func (r *CallRepository) scanCalls(ctx context.Context, query string, args ...in
 	for rows.Next() {
 		call := &domain.Call{}
 		var transcriptJSON, extractedDataJSON, providerMetadataJSON []byte
+		var cost callCostColumns
+		var tags callTagColumns
 
 		err := rows.Scan(
 			&call.ID,
@@ -385,9 +776,28 @@ func (r *CallRepository) scanCalls(ctx context.Context, query string, args ...in
 			&call.ProviderDisposition,
 			&providerMetadataJSON,
 			&call.QuoteJobID,
+			&call.CustomerID,
+			&call.PromptID,
+			&cost.billedNumber,
+			&cost.outbound,
+			&cost.minutes,
+			&cost.rate,
+			&cost.base,
+			&cost.transcription,
+			&cost.analysis,
+			&cost.total,
+			&tags.sentiment,
+			&tags.intent,
+			&tags.urgency,
+			&tags.taggedAt,
+			&call.SpamScore,
+			&call.Disposition,
+			&call.DispositionSource,
+			&call.AssignedTo,
 			&call.CreatedAt,
 			&call.UpdatedAt,
 			&call.DeletedAt,
+			&call.ArchivedAt,
 		)
 		if err != nil {
 			return nil, apperrors.DatabaseError("CallRepository.scanCalls", err)
@@ -413,6 +823,8 @@ func (r *CallRepository) scanCalls(ctx context.Context, query string, args ...in
 			}
 			call.ProviderMetadata = metadata
 		}
+		call.Cost = cost.callCost()
+		call.Tags = tags.callTags()
 
 		calls = append(calls, call)
 	}
@@ -430,6 +842,15 @@ func buildCallFilter(filter *domain.CallListFilter) (string, []interface{}) {
 	args := make([]interface{}, 0, 2)
 	paramIndex := 1
 
+	switch {
+	case filter != nil && len(filter.IDs) > 0:
+		// Calls picked by ID are listed whether archived or not
+	case filter != nil && filter.Archived:
+		conditions = append(conditions, "archived_at IS NOT NULL")
+	default:
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+
 	if filter != nil {
 		if filter.Status != nil {
 			conditions = append(conditions, fmt.Sprintf("status = $%d", paramIndex))
@@ -441,7 +862,169 @@ func buildCallFilter(filter *domain.CallListFilter) (string, []interface{}) {
 			args = append(args, "%"+search+"%")
 			paramIndex++
 		}
+		if phone := strings.TrimSpace(filter.PhoneNumber); phone != "" {
+			conditions = append(conditions, fmt.Sprintf("(phone_number ILIKE $%d OR from_number ILIKE $%d)", paramIndex, paramIndex))
+			args = append(args, "%"+phone+"%")
+			paramIndex++
+		}
+		if filter.CreatedAfter != nil {
+			conditions = append(conditions, fmt.Sprintf("created_at >= $%d", paramIndex))
+			args = append(args, *filter.CreatedAfter)
+			paramIndex++
+		}
+		if filter.CreatedBefore != nil {
+			conditions = append(conditions, fmt.Sprintf("created_at < $%d", paramIndex))
+			args = append(args, *filter.CreatedBefore)
+			paramIndex++
+		}
+		if filter.HasQuote {
+			conditions = append(conditions, "quote_summary IS NOT NULL AND quote_summary <> ''")
+		}
+		if filter.CustomerID != nil {
+			conditions = append(conditions, fmt.Sprintf("customer_id = $%d", paramIndex))
+			args = append(args, *filter.CustomerID)
+			paramIndex++
+		}
+		if provider := strings.TrimSpace(filter.Provider); provider != "" {
+			conditions = append(conditions, fmt.Sprintf("provider = $%d", paramIndex))
+			args = append(args, provider)
+			paramIndex++
+		}
+		if filter.Sentiment != "" {
+			conditions = append(conditions, fmt.Sprintf("sentiment = $%d", paramIndex))
+			args = append(args, string(filter.Sentiment))
+			paramIndex++
+		}
+		if filter.Intent != "" {
+			conditions = append(conditions, fmt.Sprintf("intent = $%d", paramIndex))
+			args = append(args, string(filter.Intent))
+			paramIndex++
+		}
+		if filter.Urgency != "" {
+			conditions = append(conditions, fmt.Sprintf("urgency = $%d", paramIndex))
+			args = append(args, string(filter.Urgency))
+			paramIndex++
+		}
+		if filter.Spam != nil {
+			if *filter.Spam {
+				conditions = append(conditions, fmt.Sprintf("spam_score >= %d", domain.SpamFlagScore))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("(spam_score IS NULL OR spam_score < %d)", domain.SpamFlagScore))
+			}
+		}
+		if filter.Disposition != "" {
+			conditions = append(conditions, fmt.Sprintf("disposition = $%d", paramIndex))
+			args = append(args, string(filter.Disposition))
+			paramIndex++
+		}
+		if len(filter.Tags) > 0 {
+			conditions = append(conditions, taggedCondition(domain.TagEntityCall, paramIndex))
+			args = append(args, filter.Tags)
+			paramIndex++
+		}
+		if len(filter.QuoteTags) > 0 {
+			conditions = append(conditions, taggedCondition(domain.TagEntityQuote, paramIndex))
+			args = append(args, filter.QuoteTags)
+			paramIndex++
+		}
+		if len(filter.IDs) > 0 {
+			conditions = append(conditions, fmt.Sprintf("id = ANY($%d)", paramIndex))
+			args = append(args, filter.IDs)
+			paramIndex++
+		}
+		if filter.After != nil {
+			op := "<"
+			if filter.Sort == domain.CallSortOldest {
+				op = ">"
+			}
+			conditions = append(conditions, fmt.Sprintf("(created_at, id) %s ($%d, $%d)", op, paramIndex, paramIndex+1))
+			args = append(args, filter.After.CreatedAt, filter.After.ID)
+			paramIndex += 2
+		}
 	}
 
 	return "WHERE " + strings.Join(conditions, " AND "), args
 }
+
+// callCostColumns receives the cost columns of a call, which are NULL until
+// the call has been priced.
+type callCostColumns struct {
+	billedNumber  *string
+	outbound      *bool
+	minutes       *float64
+	rate          *float64
+	base          *float64
+	transcription *float64
+	analysis      *float64
+	total         *float64
+}
+
+// callCost returns the scanned cost, or nil if the call has not been priced.
+func (c *callCostColumns) callCost() *domain.CallCost {
+	if c.total == nil {
+		return nil
+	}
+	cost := &domain.CallCost{TotalCost: *c.total}
+	if c.billedNumber != nil {
+		cost.BilledNumber = *c.billedNumber
+	}
+	if c.outbound != nil {
+		cost.Outbound = *c.outbound
+	}
+	if c.minutes != nil {
+		cost.Minutes = *c.minutes
+	}
+	if c.rate != nil {
+		cost.RatePerMinute = *c.rate
+	}
+	if c.base != nil {
+		cost.CallCost = *c.base
+	}
+	if c.transcription != nil {
+		cost.TranscriptionCost = *c.transcription
+	}
+	if c.analysis != nil {
+		cost.AnalysisCost = *c.analysis
+	}
+	return cost
+}
+
+// callCostArgs returns the values of the cost columns of a call, in column
+// order, all NULL if the call has not been priced.
+func callCostArgs(cost *domain.CallCost) []interface{} {
+	if cost == nil {
+		return []interface{}{nil, nil, nil, nil, nil, nil, nil, nil}
+	}
+	return []interface{}{
+		cost.BilledNumber,
+		cost.Outbound,
+		cost.Minutes,
+		cost.RatePerMinute,
+		cost.CallCost,
+		cost.TranscriptionCost,
+		cost.AnalysisCost,
+		cost.TotalCost,
+	}
+}
+
+// callTagColumns receives the tag columns of a call, which are NULL until
+// the call has been tagged.
+type callTagColumns struct {
+	sentiment *string
+	intent    *string
+	urgency   *string
+	taggedAt  *time.Time
+}
+
+// callTags returns the scanned tags, or nil if the call has not been tagged.
+func (c *callTagColumns) callTags() *domain.CallTags {
+	if c.taggedAt == nil {
+		return nil
+	}
+	return &domain.CallTags{
+		Sentiment: domain.CallSentiment(stringValue(c.sentiment)),
+		Intent:    domain.CallIntent(stringValue(c.intent)),
+		Urgency:   domain.CallUrgency(stringValue(c.urgency)),
+		TaggedAt:  *c.taggedAt,
+	}
+}
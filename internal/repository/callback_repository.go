@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const callbackColumns = `
+	id, call_id, provider_call_id, phone_number, caller_name, reason,
+	requested_date, requested_time, scheduled_at, status,
+	calendar_provider, calendar_event_id, attempts, last_error, next_attempt_at,
+	created_at, updated_at`
+
+// CallbackRepository implements domain.CallbackRepository using PostgreSQL.
+type CallbackRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCallbackRepository creates a new CallbackRepository.
+func NewCallbackRepository(pool *pgxpool.Pool) *CallbackRepository {
+	return &CallbackRepository{pool: pool}
+}
+
+// Create inserts a new callback.
+func (r *CallbackRepository) Create(ctx context.Context, callback *domain.Callback) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO callbacks (` + callbackColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+		)`
+
+	_, err := r.pool.Exec(ctx, query,
+		callback.ID,
+		callback.CallID,
+		callback.ProviderCallID,
+		nullableString(callback.PhoneNumber),
+		nullableString(callback.CallerName),
+		nullableString(callback.Reason),
+		callback.RequestedDate,
+		callback.RequestedTime,
+		callback.ScheduledAt,
+		callback.Status,
+		nullableString(callback.CalendarProvider),
+		nullableString(callback.CalendarEventID),
+		callback.Attempts,
+		callback.LastError,
+		callback.NextAttemptAt,
+		callback.CreatedAt,
+		callback.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CallbackRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a callback by ID.
+func (r *CallbackRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Callback, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + callbackColumns + ` FROM callbacks WHERE id = $1`
+	return scanCallback(r.pool.QueryRow(ctx, query, id))
+}
+
+// Update updates a callback.
+func (r *CallbackRepository) Update(ctx context.Context, callback *domain.Callback) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE callbacks SET
+			call_id = $2,
+			phone_number = $3,
+			caller_name = $4,
+			scheduled_at = $5,
+			status = $6,
+			calendar_provider = $7,
+			calendar_event_id = $8,
+			attempts = $9,
+			last_error = $10,
+			next_attempt_at = $11,
+			updated_at = $12
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		callback.ID,
+		callback.CallID,
+		nullableString(callback.PhoneNumber),
+		nullableString(callback.CallerName),
+		callback.ScheduledAt,
+		callback.Status,
+		nullableString(callback.CalendarProvider),
+		nullableString(callback.CalendarEventID),
+		callback.Attempts,
+		callback.LastError,
+		callback.NextAttemptAt,
+		callback.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CallbackRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("callback")
+	}
+	return nil
+}
+
+// ListDue retrieves pending callbacks whose next calendar attempt is due.
+func (r *CallbackRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Callback, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + callbackColumns + `
+		FROM callbacks
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`
+	return r.list(ctx, "CallbackRepository.ListDue", query, now, limit)
+}
+
+// ListUpcoming retrieves callbacks scheduled at or after from, soonest first.
+func (r *CallbackRepository) ListUpcoming(ctx context.Context, from time.Time, limit int) ([]*domain.Callback, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + callbackColumns + `
+		FROM callbacks
+		WHERE scheduled_at >= $1
+		ORDER BY scheduled_at ASC
+		LIMIT $2`
+	return r.list(ctx, "CallbackRepository.ListUpcoming", query, from, limit)
+}
+
+func (r *CallbackRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Callback, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var callbacks []*domain.Callback
+	for rows.Next() {
+		callback, err := scanCallback(rows)
+		if err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, callback)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return callbacks, nil
+}
+
+func scanCallback(row pgx.Row) (*domain.Callback, error) {
+	callback := &domain.Callback{}
+	var phoneNumber, callerName, reason, calendarProvider, calendarEventID *string
+	err := row.Scan(
+		&callback.ID,
+		&callback.CallID,
+		&callback.ProviderCallID,
+		&phoneNumber,
+		&callerName,
+		&reason,
+		&callback.RequestedDate,
+		&callback.RequestedTime,
+		&callback.ScheduledAt,
+		&callback.Status,
+		&calendarProvider,
+		&calendarEventID,
+		&callback.Attempts,
+		&callback.LastError,
+		&callback.NextAttemptAt,
+		&callback.CreatedAt,
+		&callback.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("callback")
+		}
+		return nil, apperrors.DatabaseError("CallbackRepository.scan", err)
+	}
+	callback.PhoneNumber = stringValue(phoneNumber)
+	callback.CallerName = stringValue(callerName)
+	callback.Reason = stringValue(reason)
+	callback.CalendarProvider = stringValue(calendarProvider)
+	callback.CalendarEventID = stringValue(calendarEventID)
+	return callback, nil
+}
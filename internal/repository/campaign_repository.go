@@ -0,0 +1,418 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const campaignColumns = `
+	id, name, prompt_id, status, starts_at, ends_at,
+	window_start_minute, window_end_minute, timezone,
+	calls_per_hour, last_dialed_at, created_by,
+	created_at, updated_at, completed_at`
+
+const campaignContactColumns = `
+	id, campaign_id, phone_number, name, status, call_id,
+	attempts, last_error, dialed_at, created_at, updated_at`
+
+// CampaignRepository implements domain.CampaignRepository using PostgreSQL.
+type CampaignRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCampaignRepository creates a new CampaignRepository.
+func NewCampaignRepository(pool *pgxpool.Pool) *CampaignRepository {
+	return &CampaignRepository{pool: pool}
+}
+
+// Create inserts a campaign and its contacts in a single transaction.
+func (r *CampaignRepository) Create(ctx context.Context, campaign *domain.Campaign, contacts []*domain.CampaignContact) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("CampaignRepository.Create", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO campaigns (` + campaignColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+		)`
+
+	_, err = tx.Exec(ctx, query,
+		campaign.ID,
+		campaign.Name,
+		campaign.PromptID,
+		campaign.Status,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		campaign.WindowStartMinute,
+		campaign.WindowEndMinute,
+		campaign.Timezone,
+		campaign.CallsPerHour,
+		campaign.LastDialedAt,
+		campaign.CreatedBy,
+		campaign.CreatedAt,
+		campaign.UpdatedAt,
+		campaign.CompletedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CampaignRepository.Create", err)
+	}
+
+	rows := make([][]interface{}, 0, len(contacts))
+	for _, c := range contacts {
+		rows = append(rows, []interface{}{
+			c.ID, c.CampaignID, c.PhoneNumber, nullableString(c.Name), c.Status, c.CallID,
+			c.Attempts, c.LastError, c.DialedAt, c.CreatedAt, c.UpdatedAt,
+		})
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"campaign_contacts"},
+		[]string{"id", "campaign_id", "phone_number", "name", "status", "call_id",
+			"attempts", "last_error", "dialed_at", "created_at", "updated_at"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CampaignRepository.Create", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("CampaignRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a campaign by ID.
+func (r *CampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE id = $1`
+
+	campaign, err := scanCampaign(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("campaign")
+		}
+		return nil, apperrors.DatabaseError("CampaignRepository.GetByID", err)
+	}
+	return campaign, nil
+}
+
+// Update updates a campaign's status, schedule and pacing state.
+func (r *CampaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE campaigns SET
+			name = $2,
+			prompt_id = $3,
+			status = $4,
+			starts_at = $5,
+			ends_at = $6,
+			window_start_minute = $7,
+			window_end_minute = $8,
+			timezone = $9,
+			calls_per_hour = $10,
+			last_dialed_at = $11,
+			updated_at = $12,
+			completed_at = $13
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		campaign.ID,
+		campaign.Name,
+		campaign.PromptID,
+		campaign.Status,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		campaign.WindowStartMinute,
+		campaign.WindowEndMinute,
+		campaign.Timezone,
+		campaign.CallsPerHour,
+		campaign.LastDialedAt,
+		campaign.UpdatedAt,
+		campaign.CompletedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CampaignRepository.Update", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("campaign")
+	}
+
+	return nil
+}
+
+// List retrieves campaigns, newest first.
+func (r *CampaignRepository) List(ctx context.Context, limit, offset int) ([]*domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	return r.queryCampaigns(ctx, "CampaignRepository.List", query, limit, offset)
+}
+
+// ListActive retrieves active campaigns whose start time has passed.
+func (r *CampaignRepository) ListActive(ctx context.Context, now time.Time) ([]*domain.Campaign, error) {
+	query := `
+		SELECT ` + campaignColumns + `
+		FROM campaigns
+		WHERE status = 'active' AND starts_at <= $1
+		ORDER BY starts_at ASC`
+
+	return r.queryCampaigns(ctx, "CampaignRepository.ListActive", query, now)
+}
+
+// NextPendingContact retrieves the oldest pending contact of a campaign.
+func (r *CampaignRepository) NextPendingContact(ctx context.Context, campaignID uuid.UUID) (*domain.CampaignContact, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + campaignContactColumns + `
+		FROM campaign_contacts
+		WHERE campaign_id = $1 AND status = 'pending'
+		ORDER BY created_at ASC, id ASC
+		LIMIT 1`
+
+	contact, err := scanCampaignContact(r.pool.QueryRow(ctx, query, campaignID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.DatabaseError("CampaignRepository.NextPendingContact", err)
+	}
+	return contact, nil
+}
+
+// UpdateContact updates a contact's dialing state.
+func (r *CampaignRepository) UpdateContact(ctx context.Context, contact *domain.CampaignContact) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE campaign_contacts SET
+			status = $2,
+			call_id = $3,
+			attempts = $4,
+			last_error = $5,
+			dialed_at = $6,
+			updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		contact.ID,
+		contact.Status,
+		contact.CallID,
+		contact.Attempts,
+		contact.LastError,
+		contact.DialedAt,
+		contact.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CampaignRepository.UpdateContact", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("campaign_contact")
+	}
+
+	return nil
+}
+
+// ListContacts retrieves a campaign's contacts in upload order.
+func (r *CampaignRepository) ListContacts(ctx context.Context, campaignID uuid.UUID, limit, offset int) ([]*domain.CampaignContact, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + campaignContactColumns + `
+		FROM campaign_contacts
+		WHERE campaign_id = $1
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.pool.Query(ctx, query, campaignID, limit, offset)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CampaignRepository.ListContacts", err)
+	}
+	defer rows.Close()
+
+	var contacts []*domain.CampaignContact
+	for rows.Next() {
+		contact, err := scanCampaignContact(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError("CampaignRepository.ListContacts", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CampaignRepository.ListContacts", err)
+	}
+
+	return contacts, nil
+}
+
+// CountContactsByStatus returns counts of a campaign's contacts by status.
+func (r *CampaignRepository) CountContactsByStatus(ctx context.Context, campaignID uuid.UUID) (map[domain.CampaignContactStatus]int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT status, COUNT(*) FROM campaign_contacts WHERE campaign_id = $1 GROUP BY status`,
+		campaignID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CampaignRepository.CountContactsByStatus", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.CampaignContactStatus]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, apperrors.DatabaseError("CampaignRepository.CountContactsByStatus", err)
+		}
+		counts[domain.CampaignContactStatus(status)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CampaignRepository.CountContactsByStatus", err)
+	}
+
+	return counts, nil
+}
+
+// FailStaleDialingContacts marks contacts left dialing longer than olderThan as failed.
+func (r *CampaignRepository) FailStaleDialingContacts(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	cutoff := time.Now().Add(-olderThan)
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE campaign_contacts SET
+			status = 'failed',
+			last_error = 'dialing interrupted - process restarted',
+			updated_at = NOW()
+		WHERE status = 'dialing' AND dialed_at < $1`, cutoff)
+	if err != nil {
+		return 0, apperrors.DatabaseError("CampaignRepository.FailStaleDialingContacts", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// queryCampaigns runs a query returning multiple campaigns.
+func (r *CampaignRepository) queryCampaigns(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Campaign, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var campaigns []*domain.Campaign
+	for rows.Next() {
+		campaign, err := scanCampaign(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError(op, err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+
+	return campaigns, nil
+}
+
+// scanCampaign scans a campaign row.
+func scanCampaign(row pgx.Row) (*domain.Campaign, error) {
+	c := &domain.Campaign{}
+	var status string
+
+	err := row.Scan(
+		&c.ID,
+		&c.Name,
+		&c.PromptID,
+		&status,
+		&c.StartsAt,
+		&c.EndsAt,
+		&c.WindowStartMinute,
+		&c.WindowEndMinute,
+		&c.Timezone,
+		&c.CallsPerHour,
+		&c.LastDialedAt,
+		&c.CreatedBy,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Status = domain.CampaignStatus(status)
+	return c, nil
+}
+
+// scanCampaignContact scans a campaign contact row.
+func scanCampaignContact(row pgx.Row) (*domain.CampaignContact, error) {
+	c := &domain.CampaignContact{}
+	var name *string
+	var status string
+
+	err := row.Scan(
+		&c.ID,
+		&c.CampaignID,
+		&c.PhoneNumber,
+		&name,
+		&status,
+		&c.CallID,
+		&c.Attempts,
+		&c.LastError,
+		&c.DialedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		c.Name = *name
+	}
+	c.Status = domain.CampaignContactStatus(status)
+	return c, nil
+}
+
+// nullableString returns nil for empty strings so they are stored as NULL.
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -195,6 +195,8 @@ var PathwayVersionColumns = TableColumns{
 		"id",
 		"pathway_id",
 		"version",
+		"name",
+		"description",
 		"nodes",
 		"edges",
 		"change_notes",
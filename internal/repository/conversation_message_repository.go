@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const conversationMessageColumns = `
+	id, phone_number, channel, direction, body, provider_message_id, call_id, created_at`
+
+// ConversationMessageRepository implements domain.ConversationMessageRepository
+// using PostgreSQL.
+type ConversationMessageRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewConversationMessageRepository creates a new ConversationMessageRepository.
+func NewConversationMessageRepository(pool *pgxpool.Pool) *ConversationMessageRepository {
+	return &ConversationMessageRepository{pool: pool}
+}
+
+// Create inserts a message, skipping one already stored under its provider
+// message ID.
+func (r *ConversationMessageRepository) Create(ctx context.Context, message *domain.ConversationMessage) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO conversation_messages (` + conversationMessageColumns + `
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (channel, provider_message_id) WHERE provider_message_id IS NOT NULL DO NOTHING`
+
+	_, err := r.pool.Exec(ctx, query,
+		message.ID,
+		message.PhoneNumber,
+		message.Channel,
+		message.Direction,
+		message.Body,
+		nullableString(message.ProviderMessageID),
+		message.CallID,
+		message.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("ConversationMessageRepository.Create", err)
+	}
+	return nil
+}
+
+// ListByPhoneNumber retrieves the most recent messages with a number, newest
+// first.
+func (r *ConversationMessageRepository) ListByPhoneNumber(ctx context.Context, phoneNumber string, limit int) ([]*domain.ConversationMessage, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + conversationMessageColumns + `
+		FROM conversation_messages
+		WHERE phone_number = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+
+	return r.list(ctx, "ListByPhoneNumber", query, phoneNumber, limit)
+}
+
+// ListLatest retrieves the newest message of each conversation, most recent
+// conversation first.
+func (r *ConversationMessageRepository) ListLatest(ctx context.Context, limit int) ([]*domain.ConversationMessage, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + conversationMessageColumns + `
+		FROM (
+			SELECT DISTINCT ON (phone_number) ` + conversationMessageColumns + `
+			FROM conversation_messages
+			ORDER BY phone_number, created_at DESC, id DESC
+		) latest
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1`
+
+	return r.list(ctx, "ListLatest", query, limit)
+}
+
+func (r *ConversationMessageRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.ConversationMessage, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("ConversationMessageRepository."+op, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.ConversationMessage
+	for rows.Next() {
+		message, err := scanConversationMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("ConversationMessageRepository."+op, err)
+	}
+	return messages, nil
+}
+
+func scanConversationMessage(row pgx.Row) (*domain.ConversationMessage, error) {
+	message := &domain.ConversationMessage{}
+	var providerMessageID *string
+	err := row.Scan(
+		&message.ID,
+		&message.PhoneNumber,
+		&message.Channel,
+		&message.Direction,
+		&message.Body,
+		&providerMessageID,
+		&message.CallID,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("conversation message")
+		}
+		return nil, apperrors.DatabaseError("ConversationMessageRepository.scan", err)
+	}
+	message.ProviderMessageID = stringValue(providerMessageID)
+	return message, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const crmSyncColumns = `provider, call_id, status, attempts, contact_id, deal_id,
+	last_error, requested_at, next_attempt_at, synced_at, created_at, updated_at`
+
+// CRMSyncRepository implements domain.CRMSyncRepository using PostgreSQL.
+type CRMSyncRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCRMSyncRepository creates a new CRMSyncRepository.
+func NewCRMSyncRepository(pool *pgxpool.Pool) *CRMSyncRepository {
+	return &CRMSyncRepository{pool: pool}
+}
+
+// Enqueue queues a call's sync for an immediate attempt.
+func (r *CRMSyncRepository) Enqueue(ctx context.Context, provider string, callID uuid.UUID, now time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO crm_syncs (provider, call_id, status, attempts, requested_at,
+			next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, 'pending', 0, $3, $3, $3, $3)
+		ON CONFLICT (provider, call_id) DO UPDATE SET
+			status = 'pending',
+			attempts = 0,
+			requested_at = EXCLUDED.requested_at,
+			next_attempt_at = EXCLUDED.next_attempt_at,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.pool.Exec(ctx, query, provider, callID, now); err != nil {
+		return apperrors.DatabaseError("CRMSyncRepository.Enqueue", err)
+	}
+	return nil
+}
+
+// Get retrieves a call's sync.
+func (r *CRMSyncRepository) Get(ctx context.Context, provider string, callID uuid.UUID) (*domain.CRMSync, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + crmSyncColumns + ` FROM crm_syncs WHERE provider = $1 AND call_id = $2`
+	return scanCRMSync(r.pool.QueryRow(ctx, query, provider, callID))
+}
+
+// Update records the outcome of an attempt. When the sync was queued again
+// after it was read, the call or quote changed mid-attempt, so the new
+// record IDs are kept but the sync stays pending.
+func (r *CRMSyncRepository) Update(ctx context.Context, s *domain.CRMSync) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE crm_syncs SET
+			status = CASE WHEN requested_at = $3 THEN $4 ELSE 'pending' END,
+			attempts = CASE WHEN requested_at = $3 THEN $5 ELSE 0 END,
+			next_attempt_at = CASE WHEN requested_at = $3 THEN $6 ELSE next_attempt_at END,
+			contact_id = $7,
+			deal_id = $8,
+			last_error = $9,
+			synced_at = $10,
+			updated_at = $11
+		WHERE provider = $1 AND call_id = $2`
+
+	result, err := r.pool.Exec(ctx, query,
+		s.Provider,
+		s.CallID,
+		s.RequestedAt,
+		s.Status,
+		s.Attempts,
+		s.NextAttemptAt,
+		s.ContactID,
+		s.DealID,
+		s.LastError,
+		s.SyncedAt,
+		s.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("CRMSyncRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("crm sync")
+	}
+	return nil
+}
+
+// ListDue retrieves pending syncs whose next attempt is due, oldest first.
+func (r *CRMSyncRepository) ListDue(ctx context.Context, provider string, now time.Time, limit int) ([]*domain.CRMSync, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + crmSyncColumns + `
+		FROM crm_syncs
+		WHERE provider = $1 AND status = 'pending' AND next_attempt_at <= $2
+		ORDER BY next_attempt_at ASC
+		LIMIT $3`
+	return r.list(ctx, "CRMSyncRepository.ListDue", query, provider, now, limit)
+}
+
+// List retrieves syncs with a status, or all syncs when status is empty,
+// most recently updated first.
+func (r *CRMSyncRepository) List(ctx context.Context, provider string, status domain.CRMSyncStatus, limit int) ([]*domain.CRMSync, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + crmSyncColumns + `
+		FROM crm_syncs
+		WHERE provider = $1 AND ($2 = '' OR status = $2)
+		ORDER BY updated_at DESC, call_id
+		LIMIT $3`
+	return r.list(ctx, "CRMSyncRepository.List", query, provider, string(status), limit)
+}
+
+func (r *CRMSyncRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.CRMSync, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var syncs []*domain.CRMSync
+	for rows.Next() {
+		s, err := scanCRMSync(rows)
+		if err != nil {
+			return nil, err
+		}
+		syncs = append(syncs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return syncs, nil
+}
+
+func scanCRMSync(row pgx.Row) (*domain.CRMSync, error) {
+	s := &domain.CRMSync{}
+	err := row.Scan(
+		&s.Provider,
+		&s.CallID,
+		&s.Status,
+		&s.Attempts,
+		&s.ContactID,
+		&s.DealID,
+		&s.LastError,
+		&s.RequestedAt,
+		&s.NextAttemptAt,
+		&s.SyncedAt,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("crm sync")
+		}
+		return nil, apperrors.DatabaseError("CRMSyncRepository.scan", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const customerColumns = `
+	id, phone_number, name, email, company, address, notes, opted_out_at, preferred_channel, created_at, updated_at`
+
+// pgUniqueViolation is the PostgreSQL error code for a unique constraint violation.
+const pgUniqueViolation = "23505"
+
+// CustomerRepository implements domain.CustomerRepository using PostgreSQL.
+// Listing and counting customers read from pools.Reader.
+type CustomerRepository struct {
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool
+}
+
+// NewCustomerRepository creates a new CustomerRepository.
+func NewCustomerRepository(pools Pools) *CustomerRepository {
+	return &CustomerRepository{pool: pools.Writer, reader: pools.reader()}
+}
+
+// Create inserts a new customer.
+func (r *CustomerRepository) Create(ctx context.Context, customer *domain.Customer) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO customers (` + customerColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		)
+		ON CONFLICT (phone_number) DO NOTHING`
+
+	result, err := r.pool.Exec(ctx, query, customerArgs(customer)...)
+	if err != nil {
+		return apperrors.DatabaseError("CustomerRepository.Create", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errCustomerPhoneTaken()
+	}
+	return nil
+}
+
+// GetByID retrieves a customer by ID.
+func (r *CustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE id = $1`
+	return scanCustomer(r.pool.QueryRow(ctx, query, id))
+}
+
+// GetOrCreateByPhoneNumber returns the customer with customer's phone number,
+// inserting customer if there is none. Concurrent callers for the same number
+// all receive the same row.
+func (r *CustomerRepository) GetOrCreateByPhoneNumber(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	// The no-op update makes RETURNING yield the existing row on conflict.
+	query := `
+		INSERT INTO customers (` + customerColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		)
+		ON CONFLICT (phone_number) DO UPDATE SET phone_number = EXCLUDED.phone_number
+		RETURNING ` + customerColumns
+
+	return scanCustomer(r.pool.QueryRow(ctx, query, customerArgs(customer)...))
+}
+
+// Update updates a customer's details.
+func (r *CustomerRepository) Update(ctx context.Context, customer *domain.Customer) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE customers SET
+			phone_number = $2,
+			name = $3,
+			email = $4,
+			company = $5,
+			address = $6,
+			notes = $7,
+			opted_out_at = $8,
+			preferred_channel = $9,
+			updated_at = $10
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		customer.ID,
+		customer.PhoneNumber,
+		nullableString(customer.Name),
+		nullableString(customer.Email),
+		nullableString(customer.Company),
+		nullableString(customer.Address),
+		nullableString(customer.Notes),
+		customer.OptedOutAt,
+		customerChannel(customer.PreferredChannel),
+		customer.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return errCustomerPhoneTaken()
+		}
+		return apperrors.DatabaseError("CustomerRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("customer")
+	}
+	return nil
+}
+
+// Delete removes a customer. The foreign key unlinks their calls.
+func (r *CustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM customers WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("CustomerRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("customer")
+	}
+	return nil
+}
+
+// List retrieves customers matching filter, most recently updated first.
+func (r *CustomerRepository) List(ctx context.Context, filter *domain.CustomerListFilter, limit, offset int) ([]*domain.Customer, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildCustomerFilter(filter)
+	query := fmt.Sprintf(`SELECT %s FROM customers %s
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $%d OFFSET $%d`, customerColumns, whereClause, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.reader.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("CustomerRepository.List", err)
+	}
+	defer rows.Close()
+
+	var customers []*domain.Customer
+	for rows.Next() {
+		c, err := scanCustomer(rows)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("CustomerRepository.List", err)
+	}
+	return customers, nil
+}
+
+// Count returns the number of customers matching filter.
+func (r *CustomerRepository) Count(ctx context.Context, filter *domain.CustomerListFilter) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildCustomerFilter(filter)
+
+	var count int
+	if err := r.reader.QueryRow(ctx, `SELECT COUNT(*) FROM customers `+whereClause, args...).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("CustomerRepository.Count", err)
+	}
+	return count, nil
+}
+
+// buildCustomerFilter builds the WHERE clause for a customer filter.
+func buildCustomerFilter(filter *domain.CustomerListFilter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+search+"%")
+		conditions = append(conditions, fmt.Sprintf(`(COALESCE(name, '') ILIKE $%d OR COALESCE(email, '') ILIKE $%d
+		OR COALESCE(company, '') ILIKE $%d OR phone_number ILIKE $%d)`, len(args), len(args), len(args), len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		conditions = append(conditions, taggedCondition(domain.TagEntityCustomer, len(args)))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func customerArgs(c *domain.Customer) []interface{} {
+	return []interface{}{
+		c.ID,
+		c.PhoneNumber,
+		nullableString(c.Name),
+		nullableString(c.Email),
+		nullableString(c.Company),
+		nullableString(c.Address),
+		nullableString(c.Notes),
+		c.OptedOutAt,
+		customerChannel(c.PreferredChannel),
+		c.CreatedAt,
+		c.UpdatedAt,
+	}
+}
+
+func scanCustomer(row pgx.Row) (*domain.Customer, error) {
+	c := &domain.Customer{}
+	var name, email, company, address, notes *string
+	err := row.Scan(
+		&c.ID,
+		&c.PhoneNumber,
+		&name,
+		&email,
+		&company,
+		&address,
+		&notes,
+		&c.OptedOutAt,
+		&c.PreferredChannel,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("customer")
+		}
+		return nil, apperrors.DatabaseError("CustomerRepository.scan", err)
+	}
+	c.Name = stringValue(name)
+	c.Email = stringValue(email)
+	c.Company = stringValue(company)
+	c.Address = stringValue(address)
+	c.Notes = stringValue(notes)
+	return c, nil
+}
+
+// customerChannel returns the channel to store, defaulting to SMS.
+func customerChannel(channel domain.MessageChannel) domain.MessageChannel {
+	if channel == "" {
+		return domain.MessageChannelSMS
+	}
+	return channel
+}
+
+// stringValue returns the value of a nullable text column, or "" for NULL.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func errCustomerPhoneTaken() error {
+	return apperrors.New(apperrors.CodeAlreadyExists, "a customer with this phone number already exists")
+}
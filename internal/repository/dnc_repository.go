@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const dncColumns = `phone_number, source, reason, created_by, created_at`
+
+// DNCRepository implements domain.DNCRepository using PostgreSQL.
+type DNCRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDNCRepository creates a new DNCRepository.
+func NewDNCRepository(pool *pgxpool.Pool) *DNCRepository {
+	return &DNCRepository{pool: pool}
+}
+
+// Add puts a number on the list. A number already on it keeps its existing
+// entry.
+func (r *DNCRepository) Add(ctx context.Context, entry *domain.DNCEntry) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO dnc_numbers (` + dncColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (phone_number) DO NOTHING`
+
+	_, err := r.pool.Exec(ctx, query,
+		entry.PhoneNumber,
+		entry.Source,
+		nullableString(entry.Reason),
+		entry.CreatedBy,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("DNCRepository.Add", err)
+	}
+	return nil
+}
+
+// Get retrieves the entry for a number.
+func (r *DNCRepository) Get(ctx context.Context, phoneNumber string) (*domain.DNCEntry, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + dncColumns + ` FROM dnc_numbers WHERE phone_number = $1`
+	return scanDNCEntry(r.pool.QueryRow(ctx, query, phoneNumber))
+}
+
+// Remove takes a number off the list, optionally only if it came from source.
+func (r *DNCRepository) Remove(ctx context.Context, phoneNumber string, source domain.DNCSource) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM dnc_numbers WHERE phone_number = $1 AND ($2::text = '' OR source = $2::text)`
+	result, err := r.pool.Exec(ctx, query, phoneNumber, string(source))
+	if err != nil {
+		return apperrors.DatabaseError("DNCRepository.Remove", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("do-not-call entry")
+	}
+	return nil
+}
+
+// List retrieves entries matching the filter, newest first.
+func (r *DNCRepository) List(ctx context.Context, filter *domain.DNCListFilter, limit, offset int) ([]*domain.DNCEntry, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildDNCFilter(filter)
+	query := fmt.Sprintf(`SELECT %s FROM dnc_numbers %s
+		ORDER BY created_at DESC, phone_number ASC
+		LIMIT $%d OFFSET $%d`, dncColumns, whereClause, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("DNCRepository.List", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.DNCEntry
+	for rows.Next() {
+		entry, err := scanDNCEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("DNCRepository.List", err)
+	}
+	return entries, nil
+}
+
+// Count returns the number of entries matching the filter.
+func (r *DNCRepository) Count(ctx context.Context, filter *domain.DNCListFilter) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	whereClause, args := buildDNCFilter(filter)
+
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM dnc_numbers `+whereClause, args...).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("DNCRepository.Count", err)
+	}
+	return count, nil
+}
+
+// ImportNational adds numbers from the national registry. National entries
+// already on the list are marked as listed by this import; entries from
+// other sources are left alone.
+func (r *DNCRepository) ImportNational(ctx context.Context, phoneNumbers []string, importedAt time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO dnc_numbers (phone_number, source, imported_at, created_at)
+		SELECT DISTINCT number, $2::text, $3::timestamptz, $3::timestamptz
+		FROM unnest($1::text[]) AS number
+		ON CONFLICT (phone_number) DO UPDATE
+		SET imported_at = EXCLUDED.imported_at
+		WHERE dnc_numbers.source = $2::text`
+
+	if _, err := r.pool.Exec(ctx, query, phoneNumbers, domain.DNCSourceNational, importedAt); err != nil {
+		return apperrors.DatabaseError("DNCRepository.ImportNational", err)
+	}
+	return nil
+}
+
+// PruneNational removes national entries not listed by an import at or after
+// before.
+func (r *DNCRepository) PruneNational(ctx context.Context, before time.Time) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM dnc_numbers WHERE source = $1 AND imported_at < $2`
+	result, err := r.pool.Exec(ctx, query, domain.DNCSourceNational, before)
+	if err != nil {
+		return 0, apperrors.DatabaseError("DNCRepository.PruneNational", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// buildDNCFilter builds the WHERE clause for a do-not-call listing.
+func buildDNCFilter(filter *domain.DNCListFilter) (string, []interface{}) {
+	if filter == nil {
+		return "", nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		args = append(args, "%"+search+"%")
+		conditions = append(conditions, fmt.Sprintf("phone_number LIKE $%d", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanDNCEntry(row pgx.Row) (*domain.DNCEntry, error) {
+	entry := &domain.DNCEntry{}
+	var reason *string
+	err := row.Scan(
+		&entry.PhoneNumber,
+		&entry.Source,
+		&reason,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("do-not-call entry")
+		}
+		return nil, apperrors.DatabaseError("DNCRepository.scan", err)
+	}
+	entry.Reason = stringValue(reason)
+	return entry, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// EventRepository implements domain.EventRepository using PostgreSQL.
+type EventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewEventRepository creates a new EventRepository.
+func NewEventRepository(pool *pgxpool.Pool) *EventRepository {
+	return &EventRepository{pool: pool}
+}
+
+// Create appends an event to the feed, setting its sequence.
+func (r *EventRepository) Create(ctx context.Context, e *domain.Event) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO events (id, type, call_id, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING sequence`
+
+	err := r.pool.QueryRow(ctx, query,
+		e.ID,
+		e.Type,
+		e.CallID,
+		[]byte(e.Data),
+		e.CreatedAt,
+	).Scan(&e.Sequence)
+	if err != nil {
+		return apperrors.DatabaseError("EventRepository.Create", err)
+	}
+	return nil
+}
+
+// List retrieves events after filter.After in feed order, skipping those
+// created after settledBefore.
+func (r *EventRepository) List(ctx context.Context, filter *domain.EventFilter, settledBefore time.Time) ([]*domain.Event, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	types := make([]string, len(filter.Types))
+	for i, t := range filter.Types {
+		types[i] = string(t)
+	}
+
+	query := `
+		SELECT sequence, id, type, call_id, data, created_at
+		FROM events
+		WHERE sequence > $1
+			AND created_at <= $2
+			AND (cardinality($3::text[]) = 0 OR type = ANY($3))
+		ORDER BY sequence
+		LIMIT $4`
+
+	rows, err := r.pool.Query(ctx, query, filter.After, settledBefore, types, filter.Limit)
+	if err != nil {
+		return nil, apperrors.DatabaseError("EventRepository.List", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+	for rows.Next() {
+		e := &domain.Event{}
+		var data []byte
+		if err := rows.Scan(&e.Sequence, &e.ID, &e.Type, &e.CallID, &data, &e.CreatedAt); err != nil {
+			return nil, apperrors.DatabaseError("EventRepository.List", err)
+		}
+		e.Data = data
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("EventRepository.List", err)
+	}
+	return events, nil
+}
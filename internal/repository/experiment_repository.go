@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const experimentColumns = `
+	id, name, description, direction, status, phone_number,
+	created_by, started_at, stopped_at, created_at, updated_at`
+
+const experimentVariantColumns = `id, experiment_id, name, prompt_id, weight, position`
+
+// ExperimentRepository implements domain.ExperimentRepository using PostgreSQL.
+type ExperimentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewExperimentRepository creates a new ExperimentRepository.
+func NewExperimentRepository(pool *pgxpool.Pool) *ExperimentRepository {
+	return &ExperimentRepository{pool: pool}
+}
+
+// Create inserts an experiment and its variants in a single transaction.
+func (r *ExperimentRepository) Create(ctx context.Context, experiment *domain.Experiment) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("ExperimentRepository.Create", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO experiments (`+experimentColumns+`
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		)`,
+		experiment.ID,
+		experiment.Name,
+		nullableString(experiment.Description),
+		experiment.Direction,
+		experiment.Status,
+		nullableString(experiment.PhoneNumber),
+		experiment.CreatedBy,
+		experiment.StartedAt,
+		experiment.StoppedAt,
+		experiment.CreatedAt,
+		experiment.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("ExperimentRepository.Create", err)
+	}
+
+	for _, v := range experiment.Variants {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO experiment_variants (`+experimentVariantColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			v.ID, experiment.ID, v.Name, v.PromptID, v.Weight, v.Position,
+		)
+		if err != nil {
+			return apperrors.DatabaseError("ExperimentRepository.Create", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("ExperimentRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an experiment and its variants.
+func (r *ExperimentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + experimentColumns + ` FROM experiments WHERE id = $1`
+
+	experiment, err := scanExperiment(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("experiment")
+		}
+		return nil, apperrors.DatabaseError("ExperimentRepository.GetByID", err)
+	}
+
+	if err := r.loadVariants(ctx, []*domain.Experiment{experiment}); err != nil {
+		return nil, apperrors.DatabaseError("ExperimentRepository.GetByID", err)
+	}
+	return experiment, nil
+}
+
+// Update updates an experiment's name, description and status. Variants
+// are fixed once the experiment is created.
+func (r *ExperimentRepository) Update(ctx context.Context, experiment *domain.Experiment) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `
+		UPDATE experiments SET
+			name = $2,
+			description = $3,
+			status = $4,
+			started_at = $5,
+			stopped_at = $6,
+			updated_at = $7
+		WHERE id = $1`,
+		experiment.ID,
+		experiment.Name,
+		nullableString(experiment.Description),
+		experiment.Status,
+		experiment.StartedAt,
+		experiment.StoppedAt,
+		experiment.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("ExperimentRepository.Update", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("experiment")
+	}
+	return nil
+}
+
+// Delete removes an experiment. Its variants and assignments are removed
+// by cascade.
+func (r *ExperimentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM experiments WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("ExperimentRepository.Delete", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("experiment")
+	}
+	return nil
+}
+
+// List retrieves experiments, newest first.
+func (r *ExperimentRepository) List(ctx context.Context, limit, offset int) ([]*domain.Experiment, error) {
+	query := `
+		SELECT ` + experimentColumns + `
+		FROM experiments
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	return r.queryExperiments(ctx, "ExperimentRepository.List", query, limit, offset)
+}
+
+// ListRunning retrieves the experiments currently splitting calls.
+func (r *ExperimentRepository) ListRunning(ctx context.Context) ([]*domain.Experiment, error) {
+	query := `
+		SELECT ` + experimentColumns + `
+		FROM experiments
+		WHERE status = 'running'
+		ORDER BY started_at ASC`
+
+	return r.queryExperiments(ctx, "ExperimentRepository.ListRunning", query)
+}
+
+// RecordAssignment saves the variant a call was given. It returns false if
+// the call already had an assignment.
+func (r *ExperimentRepository) RecordAssignment(ctx context.Context, assignment *domain.ExperimentAssignment) (bool, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `
+		INSERT INTO experiment_assignments (id, experiment_id, variant_id, call_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (call_id) DO NOTHING`,
+		assignment.ID,
+		assignment.ExperimentID,
+		assignment.VariantID,
+		assignment.CallID,
+		assignment.CreatedAt,
+	)
+	if err != nil {
+		return false, apperrors.DatabaseError("ExperimentRepository.RecordAssignment", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// VariantOutcomes returns outcome totals for each variant with calls.
+// Deleted calls are left out.
+func (r *ExperimentRepository) VariantOutcomes(ctx context.Context, experimentID uuid.UUID) ([]*domain.ExperimentVariantOutcome, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+			a.variant_id,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE c.status = 'completed'),
+			COUNT(*) FILTER (WHERE c.quote_summary IS NOT NULL AND c.quote_summary <> ''),
+			COUNT(c.duration_seconds),
+			COALESCE(AVG(c.duration_seconds), 0)::float8,
+			COALESCE(STDDEV_SAMP(c.duration_seconds), 0)::float8
+		FROM experiment_assignments a
+		JOIN calls c ON c.id = a.call_id AND c.deleted_at IS NULL
+		WHERE a.experiment_id = $1
+		GROUP BY a.variant_id`, experimentID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+	}
+	defer rows.Close()
+
+	var outcomes []*domain.ExperimentVariantOutcome
+	byVariant := make(map[uuid.UUID]*domain.ExperimentVariantOutcome)
+	for rows.Next() {
+		o := &domain.ExperimentVariantOutcome{Dispositions: make(map[string]int)}
+		if err := rows.Scan(
+			&o.VariantID,
+			&o.Calls,
+			&o.Completed,
+			&o.Quoted,
+			&o.DurationCount,
+			&o.DurationMean,
+			&o.DurationStdDev,
+		); err != nil {
+			return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+		}
+		outcomes = append(outcomes, o)
+		byVariant[o.VariantID] = o
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+	}
+	rows.Close()
+
+	rows, err = r.pool.Query(ctx, `
+		SELECT a.variant_id, c.provider_disposition, COUNT(*)
+		FROM experiment_assignments a
+		JOIN calls c ON c.id = a.call_id AND c.deleted_at IS NULL
+		WHERE a.experiment_id = $1 AND c.provider_disposition IS NOT NULL AND c.provider_disposition <> ''
+		GROUP BY a.variant_id, c.provider_disposition`, experimentID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variantID uuid.UUID
+		var disposition string
+		var count int
+		if err := rows.Scan(&variantID, &disposition, &count); err != nil {
+			return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+		}
+		if o := byVariant[variantID]; o != nil {
+			o.Dispositions[disposition] = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("ExperimentRepository.VariantOutcomes", err)
+	}
+
+	return outcomes, nil
+}
+
+// queryExperiments runs a query returning multiple experiments and loads
+// their variants.
+func (r *ExperimentRepository) queryExperiments(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Experiment, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var experiments []*domain.Experiment
+	for rows.Next() {
+		experiment, err := scanExperiment(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError(op, err)
+		}
+		experiments = append(experiments, experiment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	rows.Close()
+
+	if err := r.loadVariants(ctx, experiments); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return experiments, nil
+}
+
+// loadVariants fills in the variants of each experiment, in position order.
+func (r *ExperimentRepository) loadVariants(ctx context.Context, experiments []*domain.Experiment) error {
+	if len(experiments) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(experiments))
+	byID := make(map[uuid.UUID]*domain.Experiment, len(experiments))
+	for _, e := range experiments {
+		ids = append(ids, e.ID)
+		byID[e.ID] = e
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+experimentVariantColumns+`
+		FROM experiment_variants
+		WHERE experiment_id = ANY($1)
+		ORDER BY experiment_id, position`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v domain.ExperimentVariant
+		if err := rows.Scan(&v.ID, &v.ExperimentID, &v.Name, &v.PromptID, &v.Weight, &v.Position); err != nil {
+			return err
+		}
+		if e := byID[v.ExperimentID]; e != nil {
+			e.Variants = append(e.Variants, v)
+		}
+	}
+	return rows.Err()
+}
+
+// scanExperiment scans an experiment row without its variants.
+func scanExperiment(row pgx.Row) (*domain.Experiment, error) {
+	e := &domain.Experiment{}
+	var description, phoneNumber *string
+	var direction, status string
+
+	err := row.Scan(
+		&e.ID,
+		&e.Name,
+		&description,
+		&direction,
+		&status,
+		&phoneNumber,
+		&e.CreatedBy,
+		&e.StartedAt,
+		&e.StoppedAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description != nil {
+		e.Description = *description
+	}
+	if phoneNumber != nil {
+		e.PhoneNumber = *phoneNumber
+	}
+	e.Direction = domain.ExperimentDirection(direction)
+	e.Status = domain.ExperimentStatus(status)
+	return e, nil
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const featureFlagColumns = `
+	key, description, enabled, rollout_percent, user_ids, updated_by,
+	created_at, updated_at`
+
+// FeatureFlagRepository implements domain.FeatureFlagRepository using PostgreSQL.
+type FeatureFlagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeatureFlagRepository creates a new FeatureFlagRepository.
+func NewFeatureFlagRepository(pool *pgxpool.Pool) *FeatureFlagRepository {
+	return &FeatureFlagRepository{pool: pool}
+}
+
+// Create inserts a new feature flag.
+func (r *FeatureFlagRepository) Create(ctx context.Context, flag *domain.FeatureFlag) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO feature_flags (` + featureFlagColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)`
+
+	if _, err := r.pool.Exec(ctx, query, featureFlagArgs(flag)...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return apperrors.New(apperrors.CodeAlreadyExists, "a feature flag with this key already exists")
+		}
+		return apperrors.DatabaseError("FeatureFlagRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByKey retrieves a feature flag by key.
+func (r *FeatureFlagRepository) GetByKey(ctx context.Context, key string) (*domain.FeatureFlag, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + featureFlagColumns + ` FROM feature_flags WHERE key = $1`
+	return scanFeatureFlag(r.pool.QueryRow(ctx, query, key))
+}
+
+// Update updates a feature flag.
+func (r *FeatureFlagRepository) Update(ctx context.Context, flag *domain.FeatureFlag) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE feature_flags SET
+			description = $2,
+			enabled = $3,
+			rollout_percent = $4,
+			user_ids = $5,
+			updated_by = $6,
+			updated_at = $8
+		WHERE key = $1`
+
+	result, err := r.pool.Exec(ctx, query, featureFlagArgs(flag)...)
+	if err != nil {
+		return apperrors.DatabaseError("FeatureFlagRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("feature flag")
+	}
+	return nil
+}
+
+// Delete removes a feature flag.
+func (r *FeatureFlagRepository) Delete(ctx context.Context, key string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return apperrors.DatabaseError("FeatureFlagRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("feature flag")
+	}
+	return nil
+}
+
+// List retrieves all feature flags ordered by key.
+func (r *FeatureFlagRepository) List(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+featureFlagColumns+` FROM feature_flags ORDER BY key`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("FeatureFlagRepository.List", err)
+	}
+	defer rows.Close()
+
+	var flags []*domain.FeatureFlag
+	for rows.Next() {
+		flag, err := scanFeatureFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("FeatureFlagRepository.List", err)
+	}
+	return flags, nil
+}
+
+func featureFlagArgs(flag *domain.FeatureFlag) []interface{} {
+	userIDs := flag.UserIDs
+	if userIDs == nil {
+		userIDs = []uuid.UUID{}
+	}
+	return []interface{}{
+		flag.Key,
+		flag.Description,
+		flag.Enabled,
+		flag.RolloutPercent,
+		userIDs,
+		flag.UpdatedBy,
+		flag.CreatedAt,
+		flag.UpdatedAt,
+	}
+}
+
+func scanFeatureFlag(row pgx.Row) (*domain.FeatureFlag, error) {
+	flag := &domain.FeatureFlag{}
+	err := row.Scan(
+		&flag.Key,
+		&flag.Description,
+		&flag.Enabled,
+		&flag.RolloutPercent,
+		&flag.UserIDs,
+		&flag.UpdatedBy,
+		&flag.CreatedAt,
+		&flag.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("feature flag")
+		}
+		return nil, apperrors.DatabaseError("FeatureFlagRepository.scan", err)
+	}
+	return flag, nil
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const followUpColumns = `
+	id, call_id, position, channel, phone_number, due_at, status,
+	attempts, last_error, next_attempt_at, provider_ref, cancel_reason,
+	completed_at, created_at, updated_at`
+
+// FollowUpRepository implements domain.FollowUpRepository using PostgreSQL.
+type FollowUpRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFollowUpRepository creates a new FollowUpRepository.
+func NewFollowUpRepository(pool *pgxpool.Pool) *FollowUpRepository {
+	return &FollowUpRepository{pool: pool}
+}
+
+// CreateSteps inserts a quote's follow-up steps. Positions the quote already
+// has are left alone, so re-sending a quote does not restart its sequence.
+func (r *FollowUpRepository) CreateSteps(ctx context.Context, steps []*domain.FollowUpStep) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO followup_steps (` + followUpColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+		)
+		ON CONFLICT (call_id, position) DO NOTHING`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("FollowUpRepository.CreateSteps", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, step := range steps {
+		_, err := tx.Exec(ctx, query,
+			step.ID,
+			step.CallID,
+			step.Position,
+			step.Channel,
+			step.PhoneNumber,
+			step.DueAt,
+			step.Status,
+			step.Attempts,
+			step.LastError,
+			step.NextAttemptAt,
+			nullableString(step.ProviderRef),
+			nullableString(step.CancelReason),
+			step.CompletedAt,
+			step.CreatedAt,
+			step.UpdatedAt,
+		)
+		if err != nil {
+			return apperrors.DatabaseError("FollowUpRepository.CreateSteps", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("FollowUpRepository.CreateSteps", err)
+	}
+	return nil
+}
+
+// ClaimDue returns up to limit pending steps whose next attempt is due and
+// pushes their next attempt back by lease. Rows locked by a concurrent claim
+// are skipped, so each step goes to one worker; a step whose worker dies is
+// retried once the lease runs out.
+func (r *FollowUpRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.FollowUpStep, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE followup_steps SET next_attempt_at = $1::timestamptz + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM followup_steps
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + followUpColumns
+
+	return r.list(ctx, "FollowUpRepository.ClaimDue", query, now, lease.Seconds(), limit)
+}
+
+// Update updates a step.
+func (r *FollowUpRepository) Update(ctx context.Context, step *domain.FollowUpStep) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE followup_steps SET
+			status = $2,
+			attempts = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			provider_ref = $6,
+			cancel_reason = $7,
+			completed_at = $8,
+			updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		step.ID,
+		step.Status,
+		step.Attempts,
+		step.LastError,
+		step.NextAttemptAt,
+		nullableString(step.ProviderRef),
+		nullableString(step.CancelReason),
+		step.CompletedAt,
+		step.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("FollowUpRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("follow-up step")
+	}
+	return nil
+}
+
+// CancelPending cancels a quote's pending steps.
+func (r *FollowUpRepository) CancelPending(ctx context.Context, callID uuid.UUID, reason string) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE followup_steps SET
+			status = 'cancelled',
+			cancel_reason = $2,
+			completed_at = NOW(),
+			updated_at = NOW()
+		WHERE call_id = $1 AND status = 'pending'`
+
+	result, err := r.pool.Exec(ctx, query, callID, reason)
+	if err != nil {
+		return 0, apperrors.DatabaseError("FollowUpRepository.CancelPending", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ListByCallID retrieves a quote's steps in sequence order.
+func (r *FollowUpRepository) ListByCallID(ctx context.Context, callID uuid.UUID) ([]*domain.FollowUpStep, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + followUpColumns + `
+		FROM followup_steps
+		WHERE call_id = $1
+		ORDER BY position ASC`
+	return r.list(ctx, "FollowUpRepository.ListByCallID", query, callID)
+}
+
+func (r *FollowUpRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.FollowUpStep, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var steps []*domain.FollowUpStep
+	for rows.Next() {
+		step, err := scanFollowUpStep(rows)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return steps, nil
+}
+
+func scanFollowUpStep(row pgx.Row) (*domain.FollowUpStep, error) {
+	step := &domain.FollowUpStep{}
+	var providerRef, cancelReason *string
+	err := row.Scan(
+		&step.ID,
+		&step.CallID,
+		&step.Position,
+		&step.Channel,
+		&step.PhoneNumber,
+		&step.DueAt,
+		&step.Status,
+		&step.Attempts,
+		&step.LastError,
+		&step.NextAttemptAt,
+		&providerRef,
+		&cancelReason,
+		&step.CompletedAt,
+		&step.CreatedAt,
+		&step.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("follow-up step")
+		}
+		return nil, apperrors.DatabaseError("FollowUpRepository.scan", err)
+	}
+	step.ProviderRef = stringValue(providerRef)
+	step.CancelReason = stringValue(cancelReason)
+	return step, nil
+}
@@ -303,7 +303,7 @@ func (g *Guard) ValidateCallStatus(status string) error {
 
 // ValidateProviderType validates a voice provider type.
 func (g *Guard) ValidateProviderType(provider string) error {
-	validProviders := []string{"bland", "vapi", "retell"}
+	validProviders := []string{"bland", "vapi", "retell", "twilio"}
 	return g.RequireEnum(provider, validProviders, "provider")
 }
 
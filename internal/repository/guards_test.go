@@ -407,7 +407,7 @@ func TestGuard_ValidateCallStatus(t *testing.T) {
 func TestGuard_ValidateProviderType(t *testing.T) {
 	g := NewGuard()
 
-	validProviders := []string{"bland", "vapi", "retell"}
+	validProviders := []string{"bland", "vapi", "retell", "twilio"}
 	for _, provider := range validProviders {
 		if err := g.ValidateProviderType(provider); err != nil {
 			t.Errorf("ValidateProviderType(%q) should be valid", provider)
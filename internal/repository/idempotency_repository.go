@@ -8,10 +8,12 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/domain"
 	apperrors "github.com/jkindrix/quickquote/internal/errors"
 )
 
-// IdempotencyRepository persists outbound call idempotency responses.
+// IdempotencyRepository persists outbound call idempotency responses and
+// the stored responses of mutating API requests.
 type IdempotencyRepository struct {
 	db     *pgxpool.Pool
 	logger *zap.Logger
@@ -74,5 +76,101 @@ func (r *IdempotencyRepository) CleanupExpired(ctx context.Context) error {
 	if _, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`); err != nil {
 		return apperrors.DatabaseError("IdempotencyRepository.CleanupExpired", err)
 	}
+	if _, err := r.db.Exec(ctx, `DELETE FROM api_idempotency_keys WHERE expires_at <= NOW()`); err != nil {
+		return apperrors.DatabaseError("IdempotencyRepository.CleanupExpired", err)
+	}
+	return nil
+}
+
+// Reserve claims key for a request with the given hash. It returns nil if
+// the caller now holds the key and should run the request, or the existing
+// request otherwise: completed, or still running elsewhere. A key whose
+// request has held it since before staleBefore without completing is taken
+// over, as is an expired one.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, key, requestHash string, expiresAt, staleBefore time.Time) (*domain.IdempotentRequest, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	reserve := `
+		INSERT INTO api_idempotency_keys (key, request_hash, locked_at, created_at, expires_at)
+		VALUES ($1, $2, NOW(), NOW(), $3)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+		    status_code = NULL,
+		    content_type = NULL,
+		    location = NULL,
+		    body = NULL,
+		    locked_at = NOW(),
+		    completed_at = NULL,
+		    created_at = NOW(),
+		    expires_at = EXCLUDED.expires_at
+		WHERE api_idempotency_keys.expires_at <= NOW()
+		   OR (api_idempotency_keys.completed_at IS NULL AND api_idempotency_keys.locked_at < $4)
+		RETURNING key`
+
+	existing := `
+		SELECT key, request_hash, completed_at IS NOT NULL,
+		       COALESCE(status_code, 0), COALESCE(content_type, ''), COALESCE(location, ''), body
+		FROM api_idempotency_keys
+		WHERE key = $1`
+
+	// The existing row can be released between the two statements; the
+	// second attempt then reserves it.
+	for attempt := 0; attempt < 2; attempt++ {
+		var reserved string
+		err := r.db.QueryRow(ctx, reserve, key, requestHash, expiresAt, staleBefore).Scan(&reserved)
+		if err == nil {
+			return nil, nil
+		}
+		if err != pgx.ErrNoRows {
+			return nil, apperrors.DatabaseError("IdempotencyRepository.Reserve", err)
+		}
+
+		req := &domain.IdempotentRequest{}
+		err = r.db.QueryRow(ctx, existing, key).Scan(
+			&req.Key,
+			&req.RequestHash,
+			&req.Completed,
+			&req.StatusCode,
+			&req.ContentType,
+			&req.Location,
+			&req.Body,
+		)
+		if err == nil {
+			return req, nil
+		}
+		if err != pgx.ErrNoRows {
+			return nil, apperrors.DatabaseError("IdempotencyRepository.Reserve", err)
+		}
+	}
+	return nil, apperrors.New(apperrors.CodeConflict, "idempotency key is changing, try again")
+}
+
+// Complete stores the response to the request holding key.
+func (r *IdempotencyRepository) Complete(ctx context.Context, req *domain.IdempotentRequest) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE api_idempotency_keys
+		SET status_code = $2, content_type = $3, location = $4, body = $5, completed_at = NOW()
+		WHERE key = $1 AND request_hash = $6`
+
+	_, err := r.db.Exec(ctx, query, req.Key, req.StatusCode, req.ContentType, req.Location, req.Body, req.RequestHash)
+	if err != nil {
+		return apperrors.DatabaseError("IdempotencyRepository.Complete", err)
+	}
+	return nil
+}
+
+// Release gives up key without storing a response, so the request can be
+// retried.
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM api_idempotency_keys WHERE key = $1 AND completed_at IS NULL`, key); err != nil {
+		return apperrors.DatabaseError("IdempotencyRepository.Release", err)
+	}
 	return nil
 }
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const notificationPreferenceColumns = `p.user_id, u.email, p.email_events, p.sms_events, p.slack_events,
+	p.phone, p.slack_webhook_url, p.updated_at`
+
+// notificationEventColumns maps each channel to the column holding its events.
+var notificationEventColumns = map[domain.NotificationChannel]string{
+	domain.NotificationChannelEmail: "email_events",
+	domain.NotificationChannelSMS:   "sms_events",
+	domain.NotificationChannelSlack: "slack_events",
+}
+
+// NotificationPreferenceRepository implements domain.NotificationPreferenceRepository using PostgreSQL.
+type NotificationPreferenceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewNotificationPreferenceRepository creates a new NotificationPreferenceRepository.
+func NewNotificationPreferenceRepository(pool *pgxpool.Pool) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{pool: pool}
+}
+
+// Get retrieves a user's preferences.
+func (r *NotificationPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + notificationPreferenceColumns + `
+		FROM notification_preferences p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.user_id = $1`
+	return scanNotificationPreferences(r.pool.QueryRow(ctx, query, userID))
+}
+
+// Save creates or replaces a user's preferences.
+func (r *NotificationPreferenceRepository) Save(ctx context.Context, p *domain.NotificationPreferences) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO notification_preferences (user_id, email_events, sms_events, slack_events,
+			phone, slack_webhook_url, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_events = EXCLUDED.email_events,
+			sms_events = EXCLUDED.sms_events,
+			slack_events = EXCLUDED.slack_events,
+			phone = EXCLUDED.phone,
+			slack_webhook_url = EXCLUDED.slack_webhook_url,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		p.UserID,
+		notificationEventStrings(p.EmailEvents),
+		notificationEventStrings(p.SMSEvents),
+		notificationEventStrings(p.SlackEvents),
+		p.Phone,
+		p.SlackWebhookURL,
+		p.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("NotificationPreferenceRepository.Save", err)
+	}
+	return nil
+}
+
+// ListForEvent retrieves the preferences of active users who chose to be
+// notified of the event on the channel.
+func (r *NotificationPreferenceRepository) ListForEvent(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) ([]*domain.NotificationPreferences, error) {
+	column, ok := notificationEventColumns[channel]
+	if !ok {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("unknown notification channel %q", channel))
+	}
+
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + notificationPreferenceColumns + `
+		FROM notification_preferences p
+		JOIN users u ON u.id = p.user_id
+		WHERE $1 = ANY(p.` + column + `)
+			AND u.deleted_at IS NULL AND u.disabled_at IS NULL
+		ORDER BY u.email`
+
+	rows, err := r.pool.Query(ctx, query, string(event))
+	if err != nil {
+		return nil, apperrors.DatabaseError("NotificationPreferenceRepository.ListForEvent", err)
+	}
+	defer rows.Close()
+
+	var prefs []*domain.NotificationPreferences
+	for rows.Next() {
+		p, err := scanNotificationPreferences(rows)
+		if err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("NotificationPreferenceRepository.ListForEvent", err)
+	}
+	return prefs, nil
+}
+
+func scanNotificationPreferences(row pgx.Row) (*domain.NotificationPreferences, error) {
+	p := &domain.NotificationPreferences{}
+	var emailEvents, smsEvents, slackEvents []string
+	err := row.Scan(
+		&p.UserID,
+		&p.Email,
+		&emailEvents,
+		&smsEvents,
+		&slackEvents,
+		&p.Phone,
+		&p.SlackWebhookURL,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("notification preferences")
+		}
+		return nil, apperrors.DatabaseError("NotificationPreferenceRepository.scan", err)
+	}
+	p.EmailEvents = notificationEvents(emailEvents)
+	p.SMSEvents = notificationEvents(smsEvents)
+	p.SlackEvents = notificationEvents(slackEvents)
+	return p, nil
+}
+
+func notificationEvents(values []string) []domain.NotificationEvent {
+	var events []domain.NotificationEvent
+	for _, v := range values {
+		events = append(events, domain.NotificationEvent(v))
+	}
+	return events
+}
+
+func notificationEventStrings(events []domain.NotificationEvent) []string {
+	result := make([]string, len(events))
+	for i, e := range events {
+		result[i] = string(e)
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const numberHealthColumns = `phone_number, score, status, spam_likely, label, calls, answer_rate,
+	short_call_rate, rotated_pools, checked_at, status_changed_at`
+
+// NumberHealthRepository implements domain.NumberHealthRepository using PostgreSQL.
+type NumberHealthRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewNumberHealthRepository creates a new NumberHealthRepository.
+func NewNumberHealthRepository(pool *pgxpool.Pool) *NumberHealthRepository {
+	return &NumberHealthRepository{pool: pool}
+}
+
+// Upsert inserts or replaces the health of a number.
+func (r *NumberHealthRepository) Upsert(ctx context.Context, health *domain.NumberHealth) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	var rotated map[string]int
+	if len(health.RotatedPools) > 0 {
+		rotated = health.RotatedPools
+	}
+
+	query := `
+		INSERT INTO number_health (` + numberHealthColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (phone_number) DO UPDATE SET
+			score = EXCLUDED.score,
+			status = EXCLUDED.status,
+			spam_likely = EXCLUDED.spam_likely,
+			label = EXCLUDED.label,
+			calls = EXCLUDED.calls,
+			answer_rate = EXCLUDED.answer_rate,
+			short_call_rate = EXCLUDED.short_call_rate,
+			rotated_pools = EXCLUDED.rotated_pools,
+			checked_at = EXCLUDED.checked_at,
+			status_changed_at = EXCLUDED.status_changed_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		health.PhoneNumber,
+		health.Score,
+		health.Status,
+		health.SpamLikely,
+		nullableString(health.Label),
+		health.Calls,
+		health.AnswerRate,
+		health.ShortCallRate,
+		rotated,
+		health.CheckedAt,
+		health.StatusChangedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("NumberHealthRepository.Upsert", err)
+	}
+	return nil
+}
+
+// Get retrieves the health of a number.
+func (r *NumberHealthRepository) Get(ctx context.Context, phoneNumber string) (*domain.NumberHealth, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + numberHealthColumns + ` FROM number_health WHERE phone_number = $1`
+	return scanNumberHealth(r.pool.QueryRow(ctx, query, phoneNumber))
+}
+
+// List retrieves the health of every number checked, least healthy first.
+func (r *NumberHealthRepository) List(ctx context.Context) ([]*domain.NumberHealth, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + numberHealthColumns + ` FROM number_health ORDER BY score ASC, phone_number ASC`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("NumberHealthRepository.List", err)
+	}
+	defer rows.Close()
+
+	var healths []*domain.NumberHealth
+	for rows.Next() {
+		health, err := scanNumberHealth(rows)
+		if err != nil {
+			return nil, err
+		}
+		healths = append(healths, health)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("NumberHealthRepository.List", err)
+	}
+	return healths, nil
+}
+
+// DeliveryStats counts the finished calls made from each of phoneNumbers
+// since the given time. A completed call counts as answered; one shorter
+// than domain.NumberShortCallSeconds also counts as hung up on at once.
+func (r *NumberHealthRepository) DeliveryStats(ctx context.Context, phoneNumbers []string, since time.Time) ([]*domain.NumberDeliveryStats, error) {
+	if len(phoneNumbers) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			from_number,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'completed'),
+			COUNT(*) FILTER (WHERE status = 'completed' AND COALESCE(duration_seconds, 0) < $3)
+		FROM calls
+		WHERE from_number = ANY($1)
+			AND created_at >= $2
+			AND deleted_at IS NULL
+			AND status IN ('completed', 'no_answer', 'failed')
+		GROUP BY from_number`
+
+	rows, err := r.pool.Query(ctx, query, phoneNumbers, since, domain.NumberShortCallSeconds)
+	if err != nil {
+		return nil, apperrors.DatabaseError("NumberHealthRepository.DeliveryStats", err)
+	}
+	defer rows.Close()
+
+	var stats []*domain.NumberDeliveryStats
+	for rows.Next() {
+		s := &domain.NumberDeliveryStats{}
+		if err := rows.Scan(&s.PhoneNumber, &s.Calls, &s.Answered, &s.ShortCalls); err != nil {
+			return nil, apperrors.DatabaseError("NumberHealthRepository.DeliveryStats", err)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("NumberHealthRepository.DeliveryStats", err)
+	}
+	return stats, nil
+}
+
+func scanNumberHealth(row pgx.Row) (*domain.NumberHealth, error) {
+	health := &domain.NumberHealth{}
+	var label *string
+	err := row.Scan(
+		&health.PhoneNumber,
+		&health.Score,
+		&health.Status,
+		&health.SpamLikely,
+		&label,
+		&health.Calls,
+		&health.AnswerRate,
+		&health.ShortCallRate,
+		&health.RotatedPools,
+		&health.CheckedAt,
+		&health.StatusChangedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("number health")
+		}
+		return nil, apperrors.DatabaseError("NumberHealthRepository.scan", err)
+	}
+	health.Label = stringValue(label)
+	return health, nil
+}
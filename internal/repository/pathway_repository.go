@@ -175,13 +175,14 @@ func (r *PathwayRepository) Delete(ctx context.Context, id uuid.UUID) error {
 // SaveVersion saves a version of a pathway.
 func (r *PathwayRepository) SaveVersion(ctx context.Context, version *domain.PathwayVersion) error {
 	query := `
-		INSERT INTO pathway_versions (id, pathway_id, version, nodes, edges, change_notes,
-			created_at, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO pathway_versions (id, pathway_id, version, name, description, nodes,
+			edges, change_notes, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 	_, err := r.pool.Exec(ctx, query,
-		version.ID, version.PathwayID, version.Version, version.NodesJSON,
-		version.EdgesJSON, version.ChangeNotes, version.CreatedAt, version.CreatedBy,
+		version.ID, version.PathwayID, version.Version, version.Name, version.Description,
+		version.NodesJSON, version.EdgesJSON, version.ChangeNotes, version.CreatedAt,
+		version.CreatedBy,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save pathway version: %w", err)
@@ -192,15 +193,16 @@ func (r *PathwayRepository) SaveVersion(ctx context.Context, version *domain.Pat
 // GetVersion retrieves a specific version of a pathway.
 func (r *PathwayRepository) GetVersion(ctx context.Context, pathwayID uuid.UUID, version int) (*domain.PathwayVersion, error) {
 	query := `
-		SELECT id, pathway_id, version, nodes, edges, change_notes, created_at, created_by
+		SELECT id, pathway_id, version, name, description, nodes, edges,
+			COALESCE(change_notes, ''), created_at, COALESCE(created_by, '')
 		FROM pathway_versions
 		WHERE pathway_id = $1 AND version = $2
 	`
 	row := r.pool.QueryRow(ctx, query, pathwayID, version)
 	v := &domain.PathwayVersion{}
 	err := row.Scan(
-		&v.ID, &v.PathwayID, &v.Version, &v.NodesJSON, &v.EdgesJSON,
-		&v.ChangeNotes, &v.CreatedAt, &v.CreatedBy,
+		&v.ID, &v.PathwayID, &v.Version, &v.Name, &v.Description, &v.NodesJSON,
+		&v.EdgesJSON, &v.ChangeNotes, &v.CreatedAt, &v.CreatedBy,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -214,7 +216,8 @@ func (r *PathwayRepository) GetVersion(ctx context.Context, pathwayID uuid.UUID,
 // ListVersions lists all versions of a pathway.
 func (r *PathwayRepository) ListVersions(ctx context.Context, pathwayID uuid.UUID) ([]*domain.PathwayVersion, error) {
 	query := `
-		SELECT id, pathway_id, version, nodes, edges, change_notes, created_at, created_by
+		SELECT id, pathway_id, version, name, description, nodes, edges,
+			COALESCE(change_notes, ''), created_at, COALESCE(created_by, '')
 		FROM pathway_versions
 		WHERE pathway_id = $1
 		ORDER BY version DESC
@@ -229,8 +232,8 @@ func (r *PathwayRepository) ListVersions(ctx context.Context, pathwayID uuid.UUI
 	for rows.Next() {
 		v := &domain.PathwayVersion{}
 		err := rows.Scan(
-			&v.ID, &v.PathwayID, &v.Version, &v.NodesJSON, &v.EdgesJSON,
-			&v.ChangeNotes, &v.CreatedAt, &v.CreatedBy,
+			&v.ID, &v.PathwayID, &v.Version, &v.Name, &v.Description, &v.NodesJSON,
+			&v.EdgesJSON, &v.ChangeNotes, &v.CreatedAt, &v.CreatedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -265,6 +268,8 @@ func (r *PathwayRepository) RestoreVersion(ctx context.Context, pathwayID uuid.U
 		ID:          uuid.New(),
 		PathwayID:   pathwayID,
 		Version:     pathway.Version,
+		Name:        pathway.Name,
+		Description: pathway.Description,
 		NodesJSON:   pathway.NodesJSON,
 		EdgesJSON:   pathway.EdgesJSON,
 		ChangeNotes: fmt.Sprintf("Auto-saved before restoring to version %d", version),
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/database"
+)
+
+// Pools is the pair of connection pools a repository queries. Writer is the
+// primary, used for writes and for reads that must see them. Reader serves
+// heavy list, search and analytics queries; it may be a read replica, whose
+// results can lag the primary slightly.
+type Pools struct {
+	Writer *pgxpool.Pool
+	Reader *pgxpool.Pool // nil reads from Writer
+}
+
+// NewPools pairs the primary with the pool used for heavy reads.
+func NewPools(writer, reader *pgxpool.Pool) Pools {
+	return Pools{Writer: writer, Reader: reader}
+}
+
+// reader returns the pool for heavy reads.
+func (p Pools) reader() *pgxpool.Pool {
+	if p.Reader != nil {
+		return p.Reader
+	}
+	return p.Writer
+}
+
+// querier returns the transaction a caller started in ctx with
+// database.TxManager.WithTransactionContext, if any, otherwise pool, so the
+// query takes part in the caller's transaction.
+func querier(ctx context.Context, pool *pgxpool.Pool) database.Querier {
+	if tx := database.TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return pool
+}
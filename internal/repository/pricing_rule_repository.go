@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const pricingRuleColumns = `
+	id, project_type, name, description, base_rate, unit_rates, multipliers,
+	is_active, created_at, updated_at`
+
+// PricingRuleRepository implements domain.PricingRuleRepository using PostgreSQL.
+type PricingRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPricingRuleRepository creates a new PricingRuleRepository.
+func NewPricingRuleRepository(pool *pgxpool.Pool) *PricingRuleRepository {
+	return &PricingRuleRepository{pool: pool}
+}
+
+// Create inserts a new pricing rule.
+func (r *PricingRuleRepository) Create(ctx context.Context, rule *domain.PricingRule) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	args, err := pricingRuleArgs(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO pricing_rules (` + pricingRuleColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)
+		ON CONFLICT (project_type) DO NOTHING`
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.DatabaseError("PricingRuleRepository.Create", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errPricingProjectTypeTaken()
+	}
+	return nil
+}
+
+// GetByID retrieves a pricing rule by ID.
+func (r *PricingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PricingRule, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + pricingRuleColumns + ` FROM pricing_rules WHERE id = $1`
+	return scanPricingRule(r.pool.QueryRow(ctx, query, id))
+}
+
+// GetActiveByProjectType retrieves the active rule for a normalized project type.
+func (r *PricingRuleRepository) GetActiveByProjectType(ctx context.Context, projectType string) (*domain.PricingRule, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + pricingRuleColumns + ` FROM pricing_rules WHERE project_type = $1 AND is_active`
+	return scanPricingRule(r.pool.QueryRow(ctx, query, projectType))
+}
+
+// Update updates a pricing rule.
+func (r *PricingRuleRepository) Update(ctx context.Context, rule *domain.PricingRule) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	args, err := pricingRuleArgs(rule)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE pricing_rules SET
+			project_type = $2,
+			name = $3,
+			description = $4,
+			base_rate = $5,
+			unit_rates = $6,
+			multipliers = $7,
+			is_active = $8,
+			updated_at = $10
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return errPricingProjectTypeTaken()
+		}
+		return apperrors.DatabaseError("PricingRuleRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("pricing rule")
+	}
+	return nil
+}
+
+// Delete removes a pricing rule.
+func (r *PricingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM pricing_rules WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("PricingRuleRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("pricing rule")
+	}
+	return nil
+}
+
+// List retrieves all pricing rules ordered by project type.
+func (r *PricingRuleRepository) List(ctx context.Context) ([]*domain.PricingRule, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+pricingRuleColumns+` FROM pricing_rules ORDER BY project_type`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PricingRuleRepository.List", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.PricingRule
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("PricingRuleRepository.List", err)
+	}
+	return rules, nil
+}
+
+func pricingRuleArgs(rule *domain.PricingRule) ([]interface{}, error) {
+	unitRates := rule.UnitRates
+	if unitRates == nil {
+		unitRates = []domain.PricingUnitRate{}
+	}
+	multipliers := rule.Multipliers
+	if multipliers == nil {
+		multipliers = []domain.PricingMultiplier{}
+	}
+	unitRatesJSON, err := json.Marshal(unitRates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal unit rates: %w", err)
+	}
+	multipliersJSON, err := json.Marshal(multipliers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal multipliers: %w", err)
+	}
+
+	return []interface{}{
+		rule.ID,
+		rule.ProjectType,
+		rule.Name,
+		nullableString(rule.Description),
+		rule.BaseRate,
+		unitRatesJSON,
+		multipliersJSON,
+		rule.IsActive,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	}, nil
+}
+
+func scanPricingRule(row pgx.Row) (*domain.PricingRule, error) {
+	rule := &domain.PricingRule{}
+	var description *string
+	var unitRatesJSON, multipliersJSON []byte
+	err := row.Scan(
+		&rule.ID,
+		&rule.ProjectType,
+		&rule.Name,
+		&description,
+		&rule.BaseRate,
+		&unitRatesJSON,
+		&multipliersJSON,
+		&rule.IsActive,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("pricing rule")
+		}
+		return nil, apperrors.DatabaseError("PricingRuleRepository.scan", err)
+	}
+	rule.Description = stringValue(description)
+	if err := json.Unmarshal(unitRatesJSON, &rule.UnitRates); err != nil {
+		return nil, apperrors.DatabaseError("PricingRuleRepository.scan", err)
+	}
+	if err := json.Unmarshal(multipliersJSON, &rule.Multipliers); err != nil {
+		return nil, apperrors.DatabaseError("PricingRuleRepository.scan", err)
+	}
+	return rule, nil
+}
+
+func errPricingProjectTypeTaken() error {
+	return apperrors.New(apperrors.CodeAlreadyExists, "a pricing rule for this project type already exists")
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// privacyErasures are the deletes run to erase a data subject, in order.
+// Rows referencing calls are deleted before the calls so each table's count
+// is reported; quote jobs, experiment assignments and the other tables whose
+// rows cascade go with their calls. Archived webhooks have no foreign key
+// and are matched by the calls' provider IDs.
+var privacyErasures = []struct {
+	table string
+	by    privacyKey
+	query string
+}{
+	{"webhook_deliveries", byCallIDs, `DELETE FROM webhook_deliveries WHERE event_id IN (SELECT id FROM events WHERE call_id = ANY($1))`},
+	{"events", byCallIDs, `DELETE FROM events WHERE call_id = ANY($1)`},
+	{"webhook_events", byCallIDs, `DELETE FROM webhook_events WHERE call_id = ANY($1)`},
+	{"batch_calls", byCallIDsOrPhoneNumbers, `DELETE FROM batch_calls WHERE call_id = ANY($1) OR phone_number = ANY($2)`},
+	{"campaign_contacts", byCallIDsOrPhoneNumbers, `DELETE FROM campaign_contacts WHERE call_id = ANY($1) OR phone_number = ANY($2)`},
+	{"callbacks", byCallIDsOrPhoneNumbers, `DELETE FROM callbacks WHERE call_id = ANY($1) OR phone_number = ANY($2)`},
+	{"conversation_messages", byCallIDsOrPhoneNumbers, `DELETE FROM conversation_messages WHERE call_id = ANY($1) OR phone_number = ANY($2)`},
+	{"followup_steps", byCallIDsOrPhoneNumbers, `DELETE FROM followup_steps WHERE call_id = ANY($1) OR phone_number = ANY($2)`},
+	{"quote_transitions", byCallIDs, `DELETE FROM quote_transitions WHERE call_id = ANY($1)`},
+	{"quotes", byCallIDs, `DELETE FROM quotes WHERE call_id = ANY($1)`},
+	{"recordings", byCallIDs, `DELETE FROM recordings WHERE call_id = ANY($1)`},
+	{"webhook_archive", byCallIDs, `DELETE FROM webhook_archive WHERE provider_call_id IN (SELECT provider_call_id FROM calls WHERE id = ANY($1))`},
+	{"entity_tags", byCallIDs, `DELETE FROM entity_tags WHERE entity_type IN ('call', 'quote') AND entity_id = ANY($1)`},
+	{"calls", byCallIDs, `DELETE FROM calls WHERE id = ANY($1)`},
+	{"memory_stores", byPhoneNumbers, `DELETE FROM memory_stores WHERE phone_number = ANY($1)`},
+	{"spam_callers", byPhoneNumbers, `DELETE FROM spam_callers WHERE phone_number = ANY($1)`},
+	{"whatsapp_opt_ins", byPhoneNumbers, `DELETE FROM whatsapp_opt_ins WHERE phone_number = ANY($1)`},
+	{"entity_tags", byCustomerID, `DELETE FROM entity_tags WHERE entity_type = 'customer' AND entity_id = $1`},
+	{"customers", byCustomerID, `DELETE FROM customers WHERE id = $1`},
+}
+
+// privacyKey says which of a subject's identifiers an erasure is keyed by.
+type privacyKey int
+
+const (
+	byCallIDs privacyKey = iota
+	byCallIDsOrPhoneNumbers
+	byPhoneNumbers
+	byCustomerID
+)
+
+// PrivacyRepository implements domain.PrivacyRepository using PostgreSQL.
+type PrivacyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPrivacyRepository creates a new PrivacyRepository.
+func NewPrivacyRepository(pool *pgxpool.Pool) *PrivacyRepository {
+	return &PrivacyRepository{pool: pool}
+}
+
+// GetCustomerByPhoneNumber retrieves the customer with the given phone number.
+func (r *PrivacyRepository) GetCustomerByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Customer, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE phone_number = $1`
+	return scanCustomer(r.pool.QueryRow(ctx, query, phoneNumber))
+}
+
+// ListCalls retrieves the calls linked to the customer or made from or to
+// any of the phone numbers, including soft-deleted calls, oldest first.
+func (r *PrivacyRepository) ListCalls(ctx context.Context, customerID *uuid.UUID, phoneNumbers []string) ([]*domain.Call, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			id, provider_call_id, provider, phone_number, from_number, caller_name,
+			status, started_at, ended_at, duration_seconds, transcript,
+			transcript_json, recording_url, quote_summary, extracted_data,
+			error_message, provider_summary, provider_disposition, provider_metadata,
+			quote_job_id, customer_id, prompt_id, billed_number, billed_outbound,
+			billed_minutes, rate_per_minute, call_cost, transcription_cost, analysis_cost,
+			total_cost, sentiment, intent, urgency, tagged_at, spam_score, disposition,
+			disposition_source, created_at, updated_at, deleted_at
+		FROM calls
+		WHERE customer_id = $1 OR from_number = ANY($2) OR phone_number = ANY($2)
+		ORDER BY created_at ASC, id ASC`
+
+	// Read from the primary so an export includes calls made moments ago
+	calls := &CallRepository{pool: r.pool, reader: r.pool}
+	return calls.scanCalls(ctx, query, customerID, phoneNumbers)
+}
+
+// ListCallbacks retrieves callbacks requested on the subject's calls or from
+// their phone numbers, oldest first.
+func (r *PrivacyRepository) ListCallbacks(ctx context.Context, subject *domain.PrivacySubject) ([]*domain.Callback, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + callbackColumns + `
+		FROM callbacks
+		WHERE call_id = ANY($1) OR phone_number = ANY($2)
+		ORDER BY created_at ASC`
+
+	rows, err := r.pool.Query(ctx, query, subject.CallIDs, subject.PhoneNumbers)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.ListCallbacks", err)
+	}
+	defer rows.Close()
+
+	var callbacks []*domain.Callback
+	for rows.Next() {
+		callback, err := scanCallback(rows)
+		if err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, callback)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.ListCallbacks", err)
+	}
+	return callbacks, nil
+}
+
+// ListCampaignContacts retrieves campaign contacts for the subject's calls
+// or phone numbers, oldest first.
+func (r *PrivacyRepository) ListCampaignContacts(ctx context.Context, subject *domain.PrivacySubject) ([]*domain.CampaignContact, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + campaignContactColumns + `
+		FROM campaign_contacts
+		WHERE call_id = ANY($1) OR phone_number = ANY($2)
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.pool.Query(ctx, query, subject.CallIDs, subject.PhoneNumbers)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.ListCampaignContacts", err)
+	}
+	defer rows.Close()
+
+	var contacts []*domain.CampaignContact
+	for rows.Next() {
+		contact, err := scanCampaignContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.ListCampaignContacts", err)
+	}
+	return contacts, nil
+}
+
+// Erase deletes everything held about the subject in a single transaction
+// and returns the rows deleted per table. Tables with nothing to delete are
+// left out. Do-not-call entries are kept.
+func (r *PrivacyRepository) Erase(ctx context.Context, subject *domain.PrivacySubject) (map[string]int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.Erase", err)
+	}
+	defer tx.Rollback(ctx)
+
+	callIDs := subject.CallIDs
+	if callIDs == nil {
+		callIDs = []uuid.UUID{}
+	}
+	phoneNumbers := subject.PhoneNumbers
+	if phoneNumbers == nil {
+		phoneNumbers = []string{}
+	}
+
+	deleted := make(map[string]int)
+	for _, erasure := range privacyErasures {
+		var args []interface{}
+		switch erasure.by {
+		case byCallIDs:
+			args = []interface{}{callIDs}
+		case byCallIDsOrPhoneNumbers:
+			args = []interface{}{callIDs, phoneNumbers}
+		case byPhoneNumbers:
+			args = []interface{}{phoneNumbers}
+		case byCustomerID:
+			if subject.CustomerID == nil {
+				continue
+			}
+			args = []interface{}{*subject.CustomerID}
+		}
+
+		result, err := tx.Exec(ctx, erasure.query, args...)
+		if err != nil {
+			return nil, apperrors.DatabaseError("PrivacyRepository.Erase "+erasure.table, err)
+		}
+		if n := int(result.RowsAffected()); n > 0 {
+			deleted[erasure.table] += n
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, apperrors.DatabaseError("PrivacyRepository.Erase", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// privacySubjectColumns are columns that tie a row to a data subject: their
+// calls, their customer record or their phone number.
+var privacySubjectColumns = []string{
+	"call_id", "customer_id", "entity_id", "phone_number", "from_number", "provider_call_id",
+}
+
+// privacyKeptTables are tables with a subject column that erasure leaves to
+// cascades or keeps on purpose.
+var privacyKeptTables = map[string]string{
+	"accounting_syncs":       "cascades with quotes",
+	"call_attempts":          "cascades with the original call",
+	"call_events":            "cascades with calls",
+	"crm_syncs":              "cascades with calls",
+	"experiment_assignments": "cascades with calls",
+	"quote_jobs":             "cascades with calls",
+	"quote_links":            "cascades with quotes",
+	"quote_payments":         "cascades with quotes",
+	"blocked_numbers":        "kept so the number stays blocked",
+	"dnc_numbers":            "kept so the number is never called again",
+	"experiments":            "our own phone numbers",
+	"number_health":          "our own phone numbers",
+}
+
+var (
+	createTableRe = regexp.MustCompile(`(?is)CREATE TABLE (?:IF NOT EXISTS )?(\w+)\s*\((.*?)\n\);`)
+	addColumnRe   = regexp.MustCompile(`(?is)ALTER TABLE (?:IF EXISTS )?(\w+)([^;]*);`)
+	columnRe      = regexp.MustCompile(`(?i)ADD COLUMN (?:IF NOT EXISTS )?(\w+)`)
+	dropTableRe   = regexp.MustCompile(`(?i)DROP TABLE (?:IF EXISTS )?(\w+)`)
+	sqlCommentRe  = regexp.MustCompile(`--[^\n]*`)
+)
+
+// TestPrivacyErasuresCoverSubjectTables fails when a migration adds a table
+// holding a data subject's rows that Erase neither deletes nor lists in
+// privacyKeptTables.
+func TestPrivacyErasuresCoverSubjectTables(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "migrations", "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+
+	tables := make(map[string]map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", file, err)
+		}
+		sql := sqlCommentRe.ReplaceAllString(string(data), "")
+		for _, m := range createTableRe.FindAllStringSubmatch(sql, -1) {
+			columns := make(map[string]bool)
+			for _, line := range strings.Split(m[2], "\n") {
+				if fields := strings.Fields(line); len(fields) > 0 {
+					columns[strings.ToLower(strings.Trim(fields[0], `",`))] = true
+				}
+			}
+			tables[m[1]] = columns
+		}
+		for _, m := range addColumnRe.FindAllStringSubmatch(sql, -1) {
+			for _, c := range columnRe.FindAllStringSubmatch(m[2], -1) {
+				if tables[m[1]] == nil {
+					tables[m[1]] = make(map[string]bool)
+				}
+				tables[m[1]][strings.ToLower(c[1])] = true
+			}
+		}
+		for _, m := range dropTableRe.FindAllStringSubmatch(sql, -1) {
+			delete(tables, m[1])
+		}
+	}
+
+	erased := make(map[string]bool)
+	for _, erasure := range privacyErasures {
+		erased[erasure.table] = true
+		if tables[erasure.table] == nil {
+			t.Errorf("privacyErasures deletes from %s, which no migration creates", erasure.table)
+		}
+	}
+	for table := range privacyKeptTables {
+		if tables[table] == nil {
+			t.Errorf("privacyKeptTables lists %s, which no migration creates", table)
+		}
+	}
+
+	for table, columns := range tables {
+		if erased[table] || privacyKeptTables[table] != "" {
+			continue
+		}
+		for _, column := range privacySubjectColumns {
+			if columns[column] {
+				t.Errorf("table %s has %s but is not erased; add it to privacyErasures or privacyKeptTables", table, column)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// ProcessedWebhookRepository remembers processed provider webhooks in
+// PostgreSQL.
+type ProcessedWebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProcessedWebhookRepository creates a new ProcessedWebhookRepository.
+func NewProcessedWebhookRepository(db *pgxpool.Pool) *ProcessedWebhookRepository {
+	return &ProcessedWebhookRepository{db: db}
+}
+
+// Claim records a webhook as processed until expiresAt. An expired claim is
+// taken over.
+func (r *ProcessedWebhookRepository) Claim(ctx context.Context, provider, eventKey string, expiresAt time.Time) (bool, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO processed_webhooks (provider, event_key, processed_at, expires_at)
+		VALUES ($1, $2, NOW(), $3)
+		ON CONFLICT (provider, event_key) DO UPDATE
+		SET processed_at = NOW(),
+		    expires_at = EXCLUDED.expires_at
+		WHERE processed_webhooks.expires_at <= NOW()
+		RETURNING event_key`
+
+	var claimed string
+	err := r.db.QueryRow(ctx, query, provider, eventKey, expiresAt).Scan(&claimed)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, apperrors.DatabaseError("ProcessedWebhookRepository.Claim", err)
+	}
+	return true, nil
+}
+
+// Release forgets a claimed webhook.
+func (r *ProcessedWebhookRepository) Release(ctx context.Context, provider, eventKey string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM processed_webhooks WHERE provider = $1 AND event_key = $2`
+	if _, err := r.db.Exec(ctx, query, provider, eventKey); err != nil {
+		return apperrors.DatabaseError("ProcessedWebhookRepository.Release", err)
+	}
+	return nil
+}
+
+// CleanupExpired removes expired webhooks. It's safe to call periodically.
+func (r *ProcessedWebhookRepository) CleanupExpired(ctx context.Context) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM processed_webhooks WHERE expires_at <= NOW()`); err != nil {
+		return apperrors.DatabaseError("ProcessedWebhookRepository.CleanupExpired", err)
+	}
+	return nil
+}
@@ -34,7 +34,7 @@ func (r *PromptRepository) Create(ctx context.Context, prompt *domain.Prompt) er
 			voicemail_action, voicemail_message,
 			record, background_track, noise_cancellation,
 			knowledge_base_ids, custom_tool_ids,
-			summary_prompt, dispositions, analysis_schema, keywords,
+			summary_prompt, dispositions, analysis_schema, keywords, retry_policy, localizations,
 			is_default, is_active, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7,
@@ -44,11 +44,11 @@ func (r *PromptRepository) Create(ctx context.Context, prompt *domain.Prompt) er
 			$15, $16,
 			$17, $18, $19,
 			$20, $21,
-			$22, $23, $24, $25,
-			$26, $27, $28, $29
+			$22, $23, $24, $25, $26, $27,
+			$28, $29, $30, $31
 		)`
 
-	_, err := r.pool.Exec(ctx, query,
+	_, err := querier(ctx, r.pool).Exec(ctx, query,
 		prompt.ID,
 		prompt.Name,
 		prompt.Description,
@@ -74,6 +74,8 @@ func (r *PromptRepository) Create(ctx context.Context, prompt *domain.Prompt) er
 		prompt.Dispositions,
 		prompt.AnalysisSchema,
 		prompt.Keywords,
+		prompt.RetryPolicy,
+		prompt.Localizations,
 		prompt.IsDefault,
 		prompt.IsActive,
 		prompt.CreatedAt,
@@ -96,12 +98,12 @@ func (r *PromptRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.P
 			voicemail_action, voicemail_message,
 			record, background_track, noise_cancellation,
 			knowledge_base_ids, custom_tool_ids,
-			summary_prompt, dispositions, analysis_schema, keywords,
+			summary_prompt, dispositions, analysis_schema, keywords, retry_policy, localizations,
 			is_default, is_active, created_at, updated_at, deleted_at
 		FROM prompts
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	return r.scanPrompt(r.pool.QueryRow(ctx, query, id))
+	return r.scanPrompt(querier(ctx, r.pool).QueryRow(ctx, query, id))
 }
 
 // GetByName retrieves a prompt by its name.
@@ -114,7 +116,7 @@ func (r *PromptRepository) GetByName(ctx context.Context, name string) (*domain.
 			voicemail_action, voicemail_message,
 			record, background_track, noise_cancellation,
 			knowledge_base_ids, custom_tool_ids,
-			summary_prompt, dispositions, analysis_schema, keywords,
+			summary_prompt, dispositions, analysis_schema, keywords, retry_policy, localizations,
 			is_default, is_active, created_at, updated_at, deleted_at
 		FROM prompts
 		WHERE name = $1 AND deleted_at IS NULL`
@@ -132,7 +134,7 @@ func (r *PromptRepository) GetDefault(ctx context.Context) (*domain.Prompt, erro
 			voicemail_action, voicemail_message,
 			record, background_track, noise_cancellation,
 			knowledge_base_ids, custom_tool_ids,
-			summary_prompt, dispositions, analysis_schema, keywords,
+			summary_prompt, dispositions, analysis_schema, keywords, retry_policy, localizations,
 			is_default, is_active, created_at, updated_at, deleted_at
 		FROM prompts
 		WHERE is_default = true AND is_active = true AND deleted_at IS NULL
@@ -151,7 +153,7 @@ func (r *PromptRepository) List(ctx context.Context, limit, offset int, activeOn
 			voicemail_action, voicemail_message,
 			record, background_track, noise_cancellation,
 			knowledge_base_ids, custom_tool_ids,
-			summary_prompt, dispositions, analysis_schema, keywords,
+			summary_prompt, dispositions, analysis_schema, keywords, retry_policy, localizations,
 			is_default, is_active, created_at, updated_at, deleted_at
 		FROM prompts
 		WHERE deleted_at IS NULL`
@@ -162,7 +164,7 @@ func (r *PromptRepository) List(ctx context.Context, limit, offset int, activeOn
 
 	query += " ORDER BY created_at DESC LIMIT $1 OFFSET $2"
 
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := querier(ctx, r.pool).Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, apperrors.DatabaseError("PromptRepository.List", err)
 	}
@@ -229,12 +231,14 @@ func (r *PromptRepository) Update(ctx context.Context, prompt *domain.Prompt) er
 			dispositions = $23,
 			analysis_schema = $24,
 			keywords = $25,
-			is_default = $26,
-			is_active = $27,
-			updated_at = $28
+			retry_policy = $26,
+			localizations = $27,
+			is_default = $28,
+			is_active = $29,
+			updated_at = $30
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	result, err := r.pool.Exec(ctx, query,
+	result, err := querier(ctx, r.pool).Exec(ctx, query,
 		prompt.ID,
 		prompt.Name,
 		prompt.Description,
@@ -260,6 +264,8 @@ func (r *PromptRepository) Update(ctx context.Context, prompt *domain.Prompt) er
 		prompt.Dispositions,
 		prompt.AnalysisSchema,
 		prompt.Keywords,
+		prompt.RetryPolicy,
+		prompt.Localizations,
 		prompt.IsDefault,
 		prompt.IsActive,
 		prompt.UpdatedAt,
@@ -358,6 +364,8 @@ func (r *PromptRepository) scanPrompt(row pgx.Row) (*domain.Prompt, error) {
 		&p.Dispositions,
 		&p.AnalysisSchema,
 		&p.Keywords,
+		&p.RetryPolicy,
+		&p.Localizations,
 		&p.IsDefault,
 		&p.IsActive,
 		&p.CreatedAt,
@@ -404,6 +412,8 @@ func (r *PromptRepository) scanPromptFromRows(rows pgx.Rows) (*domain.Prompt, er
 		&p.Dispositions,
 		&p.AnalysisSchema,
 		&p.Keywords,
+		&p.RetryPolicy,
+		&p.Localizations,
 		&p.IsDefault,
 		&p.IsActive,
 		&p.CreatedAt,
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const promptVersionColumns = `id, prompt_id, version, content, changed_fields, changed_by, created_at`
+
+// PromptVersionRepository implements domain.PromptVersionRepository using
+// PostgreSQL.
+type PromptVersionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPromptVersionRepository creates a new PromptVersionRepository.
+func NewPromptVersionRepository(pool *pgxpool.Pool) *PromptVersionRepository {
+	return &PromptVersionRepository{pool: pool}
+}
+
+// Create saves a version numbered after the prompt's latest, setting
+// version.Version.
+func (r *PromptVersionRepository) Create(ctx context.Context, version *domain.PromptVersion) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	changed := version.ChangedFields
+	if changed == nil {
+		changed = []string{}
+	}
+
+	err := querier(ctx, r.pool).QueryRow(ctx, `
+		INSERT INTO prompt_versions (`+promptVersionColumns+`)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6
+		FROM prompt_versions
+		WHERE prompt_id = $2
+		RETURNING version`,
+		version.ID, version.PromptID, []byte(version.Content), changed, version.ChangedBy, version.CreatedAt,
+	).Scan(&version.Version)
+	if err != nil {
+		return apperrors.DatabaseError("PromptVersionRepository.Create", err)
+	}
+	return nil
+}
+
+// Get retrieves one version of a prompt.
+func (r *PromptVersionRepository) Get(ctx context.Context, promptID uuid.UUID, version int) (*domain.PromptVersion, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	row := r.pool.QueryRow(ctx, `
+		SELECT `+promptVersionColumns+`
+		FROM prompt_versions
+		WHERE prompt_id = $1 AND version = $2`,
+		promptID, version,
+	)
+	v, err := scanPromptVersion(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.NotFound("prompt version")
+	}
+	if err != nil {
+		return nil, apperrors.DatabaseError("PromptVersionRepository.Get", err)
+	}
+	return v, nil
+}
+
+// List retrieves a prompt's versions, newest first.
+func (r *PromptVersionRepository) List(ctx context.Context, promptID uuid.UUID) ([]*domain.PromptVersion, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+promptVersionColumns+`
+		FROM prompt_versions
+		WHERE prompt_id = $1
+		ORDER BY version DESC`,
+		promptID,
+	)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PromptVersionRepository.List", err)
+	}
+	defer rows.Close()
+
+	versions := []*domain.PromptVersion{}
+	for rows.Next() {
+		v, err := scanPromptVersion(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError("PromptVersionRepository.List", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("PromptVersionRepository.List", err)
+	}
+	return versions, nil
+}
+
+// scanPromptVersion scans a prompt version row.
+func scanPromptVersion(row pgx.Row) (*domain.PromptVersion, error) {
+	v := &domain.PromptVersion{}
+	var content []byte
+	err := row.Scan(
+		&v.ID,
+		&v.PromptID,
+		&v.Version,
+		&content,
+		&v.ChangedFields,
+		&v.ChangedBy,
+		&v.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	v.Content = content
+	return v, nil
+}
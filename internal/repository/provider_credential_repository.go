@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const providerCredentialColumns = `
+	provider, ciphertext, key_id, hint, updated_by, created_at, updated_at`
+
+// ProviderCredentialRepository implements domain.ProviderCredentialRepository using PostgreSQL.
+type ProviderCredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewProviderCredentialRepository creates a new ProviderCredentialRepository.
+func NewProviderCredentialRepository(pool *pgxpool.Pool) *ProviderCredentialRepository {
+	return &ProviderCredentialRepository{pool: pool}
+}
+
+// Upsert stores a provider's key, replacing any key stored before. The
+// row's creation time is kept on replacement.
+func (r *ProviderCredentialRepository) Upsert(ctx context.Context, cred *domain.ProviderCredential) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO provider_credentials (` + providerCredentialColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		ON CONFLICT (provider) DO UPDATE SET
+			ciphertext = EXCLUDED.ciphertext,
+			key_id = EXCLUDED.key_id,
+			hint = EXCLUDED.hint,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		cred.Provider,
+		cred.Ciphertext,
+		cred.KeyID,
+		cred.Hint,
+		cred.UpdatedBy,
+		cred.CreatedAt,
+		cred.UpdatedAt,
+	).Scan(&cred.CreatedAt)
+	if err != nil {
+		return apperrors.DatabaseError("ProviderCredentialRepository.Upsert", err)
+	}
+	return nil
+}
+
+// Get retrieves a provider's stored key.
+func (r *ProviderCredentialRepository) Get(ctx context.Context, provider domain.CredentialProvider) (*domain.ProviderCredential, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + providerCredentialColumns + ` FROM provider_credentials WHERE provider = $1`
+	return scanProviderCredential(r.pool.QueryRow(ctx, query, provider))
+}
+
+// List retrieves every stored key ordered by provider.
+func (r *ProviderCredentialRepository) List(ctx context.Context) ([]*domain.ProviderCredential, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+providerCredentialColumns+` FROM provider_credentials ORDER BY provider`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("ProviderCredentialRepository.List", err)
+	}
+	defer rows.Close()
+
+	var creds []*domain.ProviderCredential
+	for rows.Next() {
+		cred, err := scanProviderCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, cred)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("ProviderCredentialRepository.List", err)
+	}
+	return creds, nil
+}
+
+// Delete removes a provider's stored key.
+func (r *ProviderCredentialRepository) Delete(ctx context.Context, provider domain.CredentialProvider) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM provider_credentials WHERE provider = $1`, provider)
+	if err != nil {
+		return apperrors.DatabaseError("ProviderCredentialRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("provider credential")
+	}
+	return nil
+}
+
+func scanProviderCredential(row pgx.Row) (*domain.ProviderCredential, error) {
+	cred := &domain.ProviderCredential{}
+	err := row.Scan(
+		&cred.Provider,
+		&cred.Ciphertext,
+		&cred.KeyID,
+		&cred.Hint,
+		&cred.UpdatedBy,
+		&cred.CreatedAt,
+		&cred.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("provider credential")
+		}
+		return nil, apperrors.DatabaseError("ProviderCredentialRepository.scan", err)
+	}
+	return cred, nil
+}
@@ -14,6 +14,12 @@ import (
 	apperrors "github.com/jkindrix/quickquote/internal/errors"
 )
 
+// quoteJobColumns lists the columns read by scanJob and scanJobs.
+const quoteJobColumns = `
+			id, call_id, status, attempts, max_attempts,
+			created_at, updated_at, scheduled_at, started_at, completed_at,
+			last_error, error_count, metadata, locked_by, lease_expires_at`
+
 // QuoteJobRepository implements domain.QuoteJobRepository using PostgreSQL.
 type QuoteJobRepository struct {
 	pool *pgxpool.Pool
@@ -65,10 +71,7 @@ func (r *QuoteJobRepository) Create(ctx context.Context, job *domain.QuoteJob) e
 // GetByID retrieves a job by ID.
 func (r *QuoteJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.QuoteJob, error) {
 	query := `
-		SELECT
-			id, call_id, status, attempts, max_attempts,
-			created_at, updated_at, scheduled_at, started_at, completed_at,
-			last_error, error_count, metadata
+		SELECT ` + quoteJobColumns + `
 		FROM quote_jobs
 		WHERE id = $1`
 
@@ -78,10 +81,7 @@ func (r *QuoteJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 // GetByCallID retrieves the job for a specific call.
 func (r *QuoteJobRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.QuoteJob, error) {
 	query := `
-		SELECT
-			id, call_id, status, attempts, max_attempts,
-			created_at, updated_at, scheduled_at, started_at, completed_at,
-			last_error, error_count, metadata
+		SELECT ` + quoteJobColumns + `
 		FROM quote_jobs
 		WHERE call_id = $1
 		ORDER BY created_at DESC
@@ -90,7 +90,8 @@ func (r *QuoteJobRepository) GetByCallID(ctx context.Context, callID uuid.UUID)
 	return r.scanJob(ctx, query, callID)
 }
 
-// Update updates an existing job.
+// Update updates an existing job, provided no other worker has claimed it
+// since it was read. The lease is kept only while the job is processing.
 func (r *QuoteJobRepository) Update(ctx context.Context, job *domain.QuoteJob) error {
 	metadataJSON, err := json.Marshal(job.Metadata)
 	if err != nil {
@@ -108,8 +109,9 @@ func (r *QuoteJobRepository) Update(ctx context.Context, job *domain.QuoteJob) e
 			completed_at = $8,
 			last_error = $9,
 			error_count = $10,
-			metadata = $11
-		WHERE id = $1`
+			metadata = $11,
+			lease_expires_at = CASE WHEN $2 = 'processing' THEN lease_expires_at END
+		WHERE id = $1 AND locked_by IS NOT DISTINCT FROM $12`
 
 	result, err := r.pool.Exec(ctx, query,
 		job.ID,
@@ -123,49 +125,108 @@ func (r *QuoteJobRepository) Update(ctx context.Context, job *domain.QuoteJob) e
 		job.LastError,
 		job.ErrorCount,
 		metadataJSON,
+		job.LockedBy,
 	)
 	if err != nil {
 		return apperrors.DatabaseError("QuoteJobRepository.Update", err)
 	}
 
 	if result.RowsAffected() == 0 {
+		var exists bool
+		if err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM quote_jobs WHERE id = $1)`, job.ID).Scan(&exists); err != nil {
+			return apperrors.DatabaseError("QuoteJobRepository.Update", err)
+		}
+		if exists {
+			return errLeaseLost
+		}
 		return apperrors.NotFound("quote_job")
 	}
-
 	return nil
 }
 
-// GetPendingJobs retrieves jobs ready to be processed.
-// Returns jobs where status='pending' and scheduled_at <= now.
-func (r *QuoteJobRepository) GetPendingJobs(ctx context.Context, limit int) ([]*domain.QuoteJob, error) {
+// errLeaseLost is returned when a job was claimed by another worker.
+var errLeaseLost = apperrors.New(apperrors.CodeConflict, "quote job is leased by another worker")
+
+// ClaimPendingJobs leases up to limit jobs where status='pending',
+// scheduled_at <= now and no live lease is held. Rows locked by a concurrent
+// claim are skipped, so each job goes to one worker.
+func (r *QuoteJobRepository) ClaimPendingJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*domain.QuoteJob, error) {
 	query := `
-		SELECT
-			id, call_id, status, attempts, max_attempts,
-			created_at, updated_at, scheduled_at, started_at, completed_at,
-			last_error, error_count, metadata
-		FROM quote_jobs
-		WHERE status = 'pending' AND scheduled_at <= NOW()
-		ORDER BY scheduled_at ASC
-		LIMIT $1`
+		UPDATE quote_jobs SET
+			locked_by = $1,
+			lease_expires_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM quote_jobs
+			WHERE status = 'pending' AND scheduled_at <= NOW()
+				AND (lease_expires_at IS NULL OR lease_expires_at < NOW())
+			ORDER BY scheduled_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + quoteJobColumns
 
-	return r.scanJobs(ctx, query, limit)
+	return r.scanJobs(ctx, query, workerID, lease.Seconds(), limit)
 }
 
-// GetProcessingJobs retrieves jobs currently being processed.
-// Useful for detecting stuck jobs on startup.
-func (r *QuoteJobRepository) GetProcessingJobs(ctx context.Context, olderThan time.Duration) ([]*domain.QuoteJob, error) {
-	cutoff := time.Now().Add(-olderThan)
+// ClaimExpiredJobs leases up to limit processing jobs whose lease expired
+// because their worker stopped renewing it.
+func (r *QuoteJobRepository) ClaimExpiredJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*domain.QuoteJob, error) {
+	query := `
+		UPDATE quote_jobs SET
+			locked_by = $1,
+			lease_expires_at = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM quote_jobs
+			WHERE status = 'processing' AND lease_expires_at < NOW()
+			ORDER BY lease_expires_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + quoteJobColumns
+
+	return r.scanJobs(ctx, query, workerID, lease.Seconds(), limit)
+}
 
+// ExtendLease renews a worker's lease on a job it still holds.
+func (r *QuoteJobRepository) ExtendLease(ctx context.Context, id uuid.UUID, workerID string, lease time.Duration) error {
 	query := `
-		SELECT
-			id, call_id, status, attempts, max_attempts,
-			created_at, updated_at, scheduled_at, started_at, completed_at,
-			last_error, error_count, metadata
+		UPDATE quote_jobs SET lease_expires_at = NOW() + make_interval(secs => $3)
+		WHERE id = $1 AND locked_by = $2 AND lease_expires_at IS NOT NULL`
+
+	result, err := r.pool.Exec(ctx, query, id, workerID, lease.Seconds())
+	if err != nil {
+		return apperrors.DatabaseError("QuoteJobRepository.ExtendLease", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errLeaseLost
+	}
+	return nil
+}
+
+// ReleaseLease gives up a worker's lease on a job it has not started, so any
+// worker can claim it again.
+func (r *QuoteJobRepository) ReleaseLease(ctx context.Context, id uuid.UUID, workerID string) error {
+	query := `
+		UPDATE quote_jobs SET lease_expires_at = NULL
+		WHERE id = $1 AND locked_by = $2 AND status = 'pending'`
+
+	if _, err := r.pool.Exec(ctx, query, id, workerID); err != nil {
+		return apperrors.DatabaseError("QuoteJobRepository.ReleaseLease", err)
+	}
+	return nil
+}
+
+// ListByStatus retrieves jobs with the given status, most recently updated
+// first. An empty status lists jobs of every status.
+func (r *QuoteJobRepository) ListByStatus(ctx context.Context, status domain.QuoteJobStatus, limit, offset int) ([]*domain.QuoteJob, error) {
+	query := `
+		SELECT ` + quoteJobColumns + `
 		FROM quote_jobs
-		WHERE status = 'processing' AND started_at < $1
-		ORDER BY started_at ASC`
+		WHERE $1 = '' OR status = $1
+		ORDER BY updated_at DESC
+		LIMIT $2 OFFSET $3`
 
-	return r.scanJobs(ctx, query, cutoff)
+	return r.scanJobs(ctx, query, string(status), limit, offset)
 }
 
 // CountByStatus returns counts of jobs by status.
@@ -217,6 +278,8 @@ func (r *QuoteJobRepository) scanJob(ctx context.Context, query string, args ...
 		&job.LastError,
 		&job.ErrorCount,
 		&metadataJSON,
+		&job.LockedBy,
+		&job.LeaseExpiresAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -262,6 +325,8 @@ func (r *QuoteJobRepository) scanJobs(ctx context.Context, query string, args ..
 			&job.LastError,
 			&job.ErrorCount,
 			&metadataJSON,
+			&job.LockedBy,
+			&job.LeaseExpiresAt,
 		)
 		if err != nil {
 			return nil, apperrors.DatabaseError("QuoteJobRepository.scanJobs", err)
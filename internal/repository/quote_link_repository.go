@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const quoteLinkColumns = `id, call_id, token_hash, created_by, expires_at, viewed_at, created_at`
+
+// QuoteLinkRepository implements domain.QuoteLinkRepository using PostgreSQL.
+type QuoteLinkRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuoteLinkRepository creates a new QuoteLinkRepository.
+func NewQuoteLinkRepository(pool *pgxpool.Pool) *QuoteLinkRepository {
+	return &QuoteLinkRepository{pool: pool}
+}
+
+// Replace stores a link, removing any earlier link to the same quote.
+func (r *QuoteLinkRepository) Replace(ctx context.Context, link *domain.QuoteLink) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO quote_links (` + quoteLinkColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (call_id) DO UPDATE SET
+			id = EXCLUDED.id,
+			token_hash = EXCLUDED.token_hash,
+			created_by = EXCLUDED.created_by,
+			expires_at = EXCLUDED.expires_at,
+			viewed_at = EXCLUDED.viewed_at,
+			created_at = EXCLUDED.created_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		link.ID,
+		link.CallID,
+		link.TokenHash,
+		link.CreatedBy,
+		link.ExpiresAt,
+		link.ViewedAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteLinkRepository.Replace", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a link by the hash of its token.
+func (r *QuoteLinkRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.QuoteLink, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quoteLinkColumns + ` FROM quote_links WHERE token_hash = $1`
+
+	link := &domain.QuoteLink{}
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&link.ID,
+		&link.CallID,
+		&link.TokenHash,
+		&link.CreatedBy,
+		&link.ExpiresAt,
+		&link.ViewedAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("quote link")
+		}
+		return nil, apperrors.DatabaseError("QuoteLinkRepository.GetByHash", err)
+	}
+	return link, nil
+}
+
+// MarkViewed records the first time a link was opened. Later views leave
+// the time unchanged.
+func (r *QuoteLinkRepository) MarkViewed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx, `UPDATE quote_links SET viewed_at = $2 WHERE id = $1 AND viewed_at IS NULL`, id, at)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteLinkRepository.MarkViewed", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const quotePaymentColumns = `id, call_id, provider, provider_link_id, url, amount, deposit_percent,
+	currency, status, payment_id, amount_paid, paid_at, created_at, updated_at`
+
+// QuotePaymentRepository implements domain.QuotePaymentRepository using PostgreSQL.
+type QuotePaymentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuotePaymentRepository creates a new QuotePaymentRepository.
+func NewQuotePaymentRepository(pool *pgxpool.Pool) *QuotePaymentRepository {
+	return &QuotePaymentRepository{pool: pool}
+}
+
+// Replace stores a payment, removing any earlier payment for the same quote.
+func (r *QuotePaymentRepository) Replace(ctx context.Context, payment *domain.QuotePayment) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO quote_payments (` + quotePaymentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (call_id) DO UPDATE SET
+			id = EXCLUDED.id,
+			provider = EXCLUDED.provider,
+			provider_link_id = EXCLUDED.provider_link_id,
+			url = EXCLUDED.url,
+			amount = EXCLUDED.amount,
+			deposit_percent = EXCLUDED.deposit_percent,
+			currency = EXCLUDED.currency,
+			status = EXCLUDED.status,
+			payment_id = EXCLUDED.payment_id,
+			amount_paid = EXCLUDED.amount_paid,
+			paid_at = EXCLUDED.paid_at,
+			created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.pool.Exec(ctx, query, quotePaymentArgs(payment)...); err != nil {
+		return apperrors.DatabaseError("QuotePaymentRepository.Replace", err)
+	}
+	return nil
+}
+
+// GetByCallID retrieves the payment for a quote.
+func (r *QuotePaymentRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.QuotePayment, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quotePaymentColumns + ` FROM quote_payments WHERE call_id = $1`
+	return scanQuotePayment(r.pool.QueryRow(ctx, query, callID))
+}
+
+// GetByProviderLinkID retrieves a payment by the processor's link ID.
+func (r *QuotePaymentRepository) GetByProviderLinkID(ctx context.Context, provider, linkID string) (*domain.QuotePayment, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quotePaymentColumns + ` FROM quote_payments WHERE provider = $1 AND provider_link_id = $2`
+	return scanQuotePayment(r.pool.QueryRow(ctx, query, provider, linkID))
+}
+
+// Update saves a payment's status.
+func (r *QuotePaymentRepository) Update(ctx context.Context, payment *domain.QuotePayment) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE quote_payments
+		SET status = $2, payment_id = $3, amount_paid = $4, paid_at = $5, updated_at = $6
+		WHERE id = $1`
+
+	tag, err := r.pool.Exec(ctx, query,
+		payment.ID,
+		payment.Status,
+		nullableString(payment.PaymentID),
+		payment.AmountPaid,
+		payment.PaidAt,
+		payment.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("QuotePaymentRepository.Update", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.NotFound("quote payment")
+	}
+	return nil
+}
+
+func quotePaymentArgs(p *domain.QuotePayment) []interface{} {
+	return []interface{}{
+		p.ID,
+		p.CallID,
+		p.Provider,
+		p.ProviderLinkID,
+		p.URL,
+		p.Amount,
+		p.DepositPercent,
+		p.Currency,
+		p.Status,
+		nullableString(p.PaymentID),
+		p.AmountPaid,
+		p.PaidAt,
+		p.CreatedAt,
+		p.UpdatedAt,
+	}
+}
+
+func scanQuotePayment(row pgx.Row) (*domain.QuotePayment, error) {
+	p := &domain.QuotePayment{}
+	var paymentID *string
+	var amountPaid *float64
+	err := row.Scan(
+		&p.ID,
+		&p.CallID,
+		&p.Provider,
+		&p.ProviderLinkID,
+		&p.URL,
+		&p.Amount,
+		&p.DepositPercent,
+		&p.Currency,
+		&p.Status,
+		&paymentID,
+		&amountPaid,
+		&p.PaidAt,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("quote payment")
+		}
+		return nil, apperrors.DatabaseError("QuotePaymentRepository.scan", err)
+	}
+	p.PaymentID = stringValue(paymentID)
+	if amountPaid != nil {
+		p.AmountPaid = *amountPaid
+	}
+	return p, nil
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const quoteColumns = `
+	call_id, status, total_amount, requires_approval,
+	submitted_by, submitted_at, approved_by, approved_at,
+	sent_at, responded_at, line_items, tax_rate, notes,
+	signer_name, signature_image, signed_ip, signed_at,
+	currency, archived_at, created_at, updated_at`
+
+// saveArchivedQuery upserts a quote, changing only its archive time.
+const saveArchivedQuery = `
+	INSERT INTO quotes (` + quoteColumns + `
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+	)
+	ON CONFLICT (call_id) DO UPDATE SET
+		archived_at = EXCLUDED.archived_at,
+		updated_at = EXCLUDED.updated_at`
+
+const quoteTransitionColumns = `
+	id, call_id, from_status, to_status, actor_id, actor_email, note, created_at`
+
+// QuoteRepository implements domain.QuoteRepository using PostgreSQL.
+type QuoteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuoteRepository creates a new QuoteRepository.
+func NewQuoteRepository(pool *pgxpool.Pool) *QuoteRepository {
+	return &QuoteRepository{pool: pool}
+}
+
+// GetByCallID retrieves the quote for a call.
+func (r *QuoteRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Quote, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quoteColumns + ` FROM quotes WHERE call_id = $1 AND deleted_at IS NULL`
+
+	q := &domain.Quote{}
+	var notes, signerName, signedIP *string
+	var signatureImage []byte
+	var signedAt *time.Time
+	err := r.pool.QueryRow(ctx, query, callID).Scan(
+		&q.CallID,
+		&q.Status,
+		&q.TotalAmount,
+		&q.RequiresApproval,
+		&q.SubmittedBy,
+		&q.SubmittedAt,
+		&q.ApprovedBy,
+		&q.ApprovedAt,
+		&q.SentAt,
+		&q.RespondedAt,
+		&q.LineItems,
+		&q.TaxRate,
+		&notes,
+		&signerName,
+		&signatureImage,
+		&signedIP,
+		&signedAt,
+		&q.Currency,
+		&q.ArchivedAt,
+		&q.CreatedAt,
+		&q.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("quote")
+		}
+		return nil, apperrors.DatabaseError("QuoteRepository.GetByCallID", err)
+	}
+	q.Notes = stringValue(notes)
+	if signerName != nil && signedAt != nil {
+		q.Signature = &domain.QuoteSignature{
+			SignerName: *signerName,
+			Image:      signatureImage,
+			IP:         stringValue(signedIP),
+			SignedAt:   *signedAt,
+		}
+	}
+	return q, nil
+}
+
+// SaveContent upserts the quote with its edited line items, tax rate, notes
+// and currency.
+func (r *QuoteRepository) SaveContent(ctx context.Context, quote *domain.Quote) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	upsert := `
+		INSERT INTO quotes (` + quoteColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+		)
+		ON CONFLICT (call_id) DO UPDATE SET
+			total_amount = EXCLUDED.total_amount,
+			currency = EXCLUDED.currency,
+			line_items = EXCLUDED.line_items,
+			tax_rate = EXCLUDED.tax_rate,
+			notes = EXCLUDED.notes,
+			updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.pool.Exec(ctx, upsert, quoteArgs(quote)...); err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveContent", err)
+	}
+	return nil
+}
+
+// SaveArchived upserts the quote with its archive time.
+func (r *QuoteRepository) SaveArchived(ctx context.Context, quote *domain.Quote) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, saveArchivedQuery, quoteArgs(quote)...); err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveArchived", err)
+	}
+	return nil
+}
+
+// SaveArchivedMany upserts quotes with their archive times in a single
+// transaction.
+func (r *QuoteRepository) SaveArchivedMany(ctx context.Context, quotes []*domain.Quote) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveArchivedMany", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, quote := range quotes {
+		if _, err := tx.Exec(ctx, saveArchivedQuery, quoteArgs(quote)...); err != nil {
+			return apperrors.DatabaseError("QuoteRepository.SaveArchivedMany", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveArchivedMany", err)
+	}
+	return nil
+}
+
+// SaveTransition upserts the quote and records the transition in a single transaction.
+func (r *QuoteRepository) SaveTransition(ctx context.Context, quote *domain.Quote, transition *domain.QuoteTransition) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveTransition", err)
+	}
+	defer tx.Rollback(ctx)
+
+	upsert := `
+		INSERT INTO quotes (` + quoteColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+		)
+		ON CONFLICT (call_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			total_amount = EXCLUDED.total_amount,
+			requires_approval = EXCLUDED.requires_approval,
+			submitted_by = EXCLUDED.submitted_by,
+			submitted_at = EXCLUDED.submitted_at,
+			approved_by = EXCLUDED.approved_by,
+			approved_at = EXCLUDED.approved_at,
+			sent_at = EXCLUDED.sent_at,
+			responded_at = EXCLUDED.responded_at,
+			signer_name = EXCLUDED.signer_name,
+			signature_image = EXCLUDED.signature_image,
+			signed_ip = EXCLUDED.signed_ip,
+			signed_at = EXCLUDED.signed_at,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = tx.Exec(ctx, upsert, quoteArgs(quote)...)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveTransition", err)
+	}
+
+	insert := `INSERT INTO quote_transitions (` + quoteTransitionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = tx.Exec(ctx, insert,
+		transition.ID,
+		transition.CallID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.ActorID,
+		nullableString(transition.ActorEmail),
+		nullableString(transition.Note),
+		transition.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveTransition", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("QuoteRepository.SaveTransition", err)
+	}
+	return nil
+}
+
+// ListTransitions retrieves a quote's status history, oldest first.
+func (r *QuoteRepository) ListTransitions(ctx context.Context, callID uuid.UUID) ([]*domain.QuoteTransition, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quoteTransitionColumns + ` FROM quote_transitions WHERE call_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.pool.Query(ctx, query, callID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("QuoteRepository.ListTransitions", err)
+	}
+	defer rows.Close()
+
+	var transitions []*domain.QuoteTransition
+	for rows.Next() {
+		t := &domain.QuoteTransition{}
+		var actorEmail, note *string
+		if err := rows.Scan(&t.ID, &t.CallID, &t.FromStatus, &t.ToStatus, &t.ActorID, &actorEmail, &note, &t.CreatedAt); err != nil {
+			return nil, apperrors.DatabaseError("QuoteRepository.ListTransitions", err)
+		}
+		if actorEmail != nil {
+			t.ActorEmail = *actorEmail
+		}
+		if note != nil {
+			t.Note = *note
+		}
+		transitions = append(transitions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("QuoteRepository.ListTransitions", err)
+	}
+
+	return transitions, nil
+}
+
+// quoteArgs returns the values for quoteColumns. Line items are stored only
+// once the quote has been edited.
+func quoteArgs(quote *domain.Quote) []interface{} {
+	var lineItems []domain.QuoteLineItem
+	if quote.IsEdited() {
+		lineItems = quote.LineItems
+	}
+	var signerName, signedIP interface{}
+	var signatureImage []byte
+	var signedAt *time.Time
+	if sig := quote.Signature; sig != nil {
+		signerName = sig.SignerName
+		signatureImage = sig.Image
+		signedIP = nullableString(sig.IP)
+		signedAt = &sig.SignedAt
+	}
+	return []interface{}{
+		quote.CallID,
+		quote.Status,
+		quote.TotalAmount,
+		quote.RequiresApproval,
+		quote.SubmittedBy,
+		quote.SubmittedAt,
+		quote.ApprovedBy,
+		quote.ApprovedAt,
+		quote.SentAt,
+		quote.RespondedAt,
+		lineItems,
+		quote.TaxRate,
+		nullableString(quote.Notes),
+		signerName,
+		signatureImage,
+		signedIP,
+		signedAt,
+		quote.Currency,
+		quote.ArchivedAt,
+		quote.CreatedAt,
+		quote.UpdatedAt,
+	}
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const quoteTemplateColumns = `
+	id, project_type, name, sections, default_line_items, assumptions,
+	is_active, created_at, updated_at`
+
+// QuoteTemplateRepository implements domain.QuoteTemplateRepository using PostgreSQL.
+type QuoteTemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuoteTemplateRepository creates a new QuoteTemplateRepository.
+func NewQuoteTemplateRepository(pool *pgxpool.Pool) *QuoteTemplateRepository {
+	return &QuoteTemplateRepository{pool: pool}
+}
+
+// Create inserts a new quote template.
+func (r *QuoteTemplateRepository) Create(ctx context.Context, template *domain.QuoteTemplate) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	args, err := quoteTemplateArgs(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO quote_templates (` + quoteTemplateColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+		ON CONFLICT (project_type) DO NOTHING`
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteTemplateRepository.Create", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errQuoteTemplateProjectTypeTaken()
+	}
+	return nil
+}
+
+// GetByID retrieves a quote template by ID.
+func (r *QuoteTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.QuoteTemplate, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quoteTemplateColumns + ` FROM quote_templates WHERE id = $1`
+	return scanQuoteTemplate(r.pool.QueryRow(ctx, query, id))
+}
+
+// GetActiveByProjectType retrieves the active template for a normalized project type.
+func (r *QuoteTemplateRepository) GetActiveByProjectType(ctx context.Context, projectType string) (*domain.QuoteTemplate, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + quoteTemplateColumns + ` FROM quote_templates WHERE project_type = $1 AND is_active`
+	return scanQuoteTemplate(r.pool.QueryRow(ctx, query, projectType))
+}
+
+// Update updates a quote template.
+func (r *QuoteTemplateRepository) Update(ctx context.Context, template *domain.QuoteTemplate) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	args, err := quoteTemplateArgs(template)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE quote_templates SET
+			project_type = $2,
+			name = $3,
+			sections = $4,
+			default_line_items = $5,
+			assumptions = $6,
+			is_active = $7,
+			updated_at = $9
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return errQuoteTemplateProjectTypeTaken()
+		}
+		return apperrors.DatabaseError("QuoteTemplateRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("quote template")
+	}
+	return nil
+}
+
+// Delete removes a quote template.
+func (r *QuoteTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM quote_templates WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("QuoteTemplateRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("quote template")
+	}
+	return nil
+}
+
+// List retrieves all quote templates ordered by project type.
+func (r *QuoteTemplateRepository) List(ctx context.Context) ([]*domain.QuoteTemplate, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+quoteTemplateColumns+` FROM quote_templates ORDER BY project_type`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("QuoteTemplateRepository.List", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.QuoteTemplate
+	for rows.Next() {
+		template, err := scanQuoteTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("QuoteTemplateRepository.List", err)
+	}
+	return templates, nil
+}
+
+func quoteTemplateArgs(template *domain.QuoteTemplate) ([]interface{}, error) {
+	sections := template.Sections
+	if sections == nil {
+		sections = []domain.QuoteTemplateSection{}
+	}
+	lineItems := template.DefaultLineItems
+	if lineItems == nil {
+		lineItems = []domain.QuoteTemplateLineItem{}
+	}
+	sectionsJSON, err := json.Marshal(sections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sections: %w", err)
+	}
+	lineItemsJSON, err := json.Marshal(lineItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal default line items: %w", err)
+	}
+
+	return []interface{}{
+		template.ID,
+		template.ProjectType,
+		template.Name,
+		sectionsJSON,
+		lineItemsJSON,
+		nullableString(template.Assumptions),
+		template.IsActive,
+		template.CreatedAt,
+		template.UpdatedAt,
+	}, nil
+}
+
+func scanQuoteTemplate(row pgx.Row) (*domain.QuoteTemplate, error) {
+	template := &domain.QuoteTemplate{}
+	var assumptions *string
+	var sectionsJSON, lineItemsJSON []byte
+	err := row.Scan(
+		&template.ID,
+		&template.ProjectType,
+		&template.Name,
+		&sectionsJSON,
+		&lineItemsJSON,
+		&assumptions,
+		&template.IsActive,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("quote template")
+		}
+		return nil, apperrors.DatabaseError("QuoteTemplateRepository.scan", err)
+	}
+	template.Assumptions = stringValue(assumptions)
+	if err := json.Unmarshal(sectionsJSON, &template.Sections); err != nil {
+		return nil, apperrors.DatabaseError("QuoteTemplateRepository.scan", err)
+	}
+	if err := json.Unmarshal(lineItemsJSON, &template.DefaultLineItems); err != nil {
+		return nil, apperrors.DatabaseError("QuoteTemplateRepository.scan", err)
+	}
+	return template, nil
+}
+
+func errQuoteTemplateProjectTypeTaken() error {
+	return apperrors.New(apperrors.CodeAlreadyExists, "a quote template for this project type already exists")
+}
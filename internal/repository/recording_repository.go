@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const recordingColumns = `
+	id, call_id, source_url, status, storage_backend, storage_key,
+	content_type, size_bytes, attempts, last_error, next_attempt_at,
+	stored_at, expires_at, deleted_at, created_at, updated_at`
+
+// RecordingRepository implements domain.RecordingRepository using PostgreSQL.
+type RecordingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRecordingRepository creates a new RecordingRepository.
+func NewRecordingRepository(pool *pgxpool.Pool) *RecordingRepository {
+	return &RecordingRepository{pool: pool}
+}
+
+// Create inserts a new recording.
+func (r *RecordingRepository) Create(ctx context.Context, recording *domain.Recording) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO recordings (` + recordingColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+		)`
+
+	_, err := r.pool.Exec(ctx, query,
+		recording.ID,
+		recording.CallID,
+		recording.SourceURL,
+		recording.Status,
+		nullableString(recording.StorageBackend),
+		nullableString(recording.StorageKey),
+		nullableString(recording.ContentType),
+		recording.SizeBytes,
+		recording.Attempts,
+		recording.LastError,
+		recording.NextAttemptAt,
+		recording.StoredAt,
+		recording.ExpiresAt,
+		recording.DeletedAt,
+		recording.CreatedAt,
+		recording.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return apperrors.New(apperrors.CodeAlreadyExists, "the call already has a recording")
+		}
+		return apperrors.DatabaseError("RecordingRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByCallID retrieves the recording for a call.
+func (r *RecordingRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Recording, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + recordingColumns + ` FROM recordings WHERE call_id = $1`
+	return scanRecording(r.pool.QueryRow(ctx, query, callID))
+}
+
+// Update updates a recording.
+func (r *RecordingRepository) Update(ctx context.Context, recording *domain.Recording) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE recordings SET
+			source_url = $2,
+			status = $3,
+			storage_backend = $4,
+			storage_key = $5,
+			content_type = $6,
+			size_bytes = $7,
+			attempts = $8,
+			last_error = $9,
+			next_attempt_at = $10,
+			stored_at = $11,
+			expires_at = $12,
+			deleted_at = $13,
+			updated_at = $14
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		recording.ID,
+		recording.SourceURL,
+		recording.Status,
+		nullableString(recording.StorageBackend),
+		nullableString(recording.StorageKey),
+		nullableString(recording.ContentType),
+		recording.SizeBytes,
+		recording.Attempts,
+		recording.LastError,
+		recording.NextAttemptAt,
+		recording.StoredAt,
+		recording.ExpiresAt,
+		recording.DeletedAt,
+		recording.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("RecordingRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("recording")
+	}
+	return nil
+}
+
+// ListDue retrieves pending recordings whose next download attempt is due.
+func (r *RecordingRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Recording, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + recordingColumns + `
+		FROM recordings
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`
+	return r.list(ctx, "RecordingRepository.ListDue", query, now, limit)
+}
+
+// ListExpired retrieves stored recordings whose retention ended before now,
+// leaving out recordings of calls on legal hold.
+func (r *RecordingRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Recording, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + recordingColumns + `
+		FROM recordings
+		WHERE status = 'stored' AND expires_at IS NOT NULL AND expires_at <= $1
+		  AND ` + callNotOnLegalHold("recordings.call_id") + `
+		ORDER BY expires_at ASC
+		LIMIT $2`
+	return r.list(ctx, "RecordingRepository.ListExpired", query, now, limit)
+}
+
+// CountStoredBefore counts recordings stored before cutoff whose calls are
+// not on legal hold.
+func (r *RecordingRepository) CountStoredBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT COUNT(*)
+		FROM recordings
+		WHERE status = 'stored' AND stored_at < $1
+		  AND ` + callNotOnLegalHold("recordings.call_id")
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, cutoff).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("RecordingRepository.CountStoredBefore", err)
+	}
+	return count, nil
+}
+
+// ListStoredBefore retrieves recordings stored before cutoff whose calls are
+// not on legal hold, oldest first.
+func (r *RecordingRepository) ListStoredBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Recording, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + recordingColumns + `
+		FROM recordings
+		WHERE status = 'stored' AND stored_at < $1
+		  AND ` + callNotOnLegalHold("recordings.call_id") + `
+		ORDER BY stored_at ASC
+		LIMIT $2`
+	return r.list(ctx, "RecordingRepository.ListStoredBefore", query, cutoff, limit)
+}
+
+func (r *RecordingRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.Recording, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var recordings []*domain.Recording
+	for rows.Next() {
+		recording, err := scanRecording(rows)
+		if err != nil {
+			return nil, err
+		}
+		recordings = append(recordings, recording)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return recordings, nil
+}
+
+func scanRecording(row pgx.Row) (*domain.Recording, error) {
+	recording := &domain.Recording{}
+	var storageBackend, storageKey, contentType *string
+	err := row.Scan(
+		&recording.ID,
+		&recording.CallID,
+		&recording.SourceURL,
+		&recording.Status,
+		&storageBackend,
+		&storageKey,
+		&contentType,
+		&recording.SizeBytes,
+		&recording.Attempts,
+		&recording.LastError,
+		&recording.NextAttemptAt,
+		&recording.StoredAt,
+		&recording.ExpiresAt,
+		&recording.DeletedAt,
+		&recording.CreatedAt,
+		&recording.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("recording")
+		}
+		return nil, apperrors.DatabaseError("RecordingRepository.scan", err)
+	}
+	recording.StorageBackend = stringValue(storageBackend)
+	recording.StorageKey = stringValue(storageKey)
+	recording.ContentType = stringValue(contentType)
+	return recording, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// RecoveryCodeRepository implements domain.RecoveryCodeRepository using PostgreSQL.
+type RecoveryCodeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRecoveryCodeRepository creates a new RecoveryCodeRepository.
+func NewRecoveryCodeRepository(pool *pgxpool.Pool) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{pool: pool}
+}
+
+// Replace deletes a user's recovery codes and stores new code hashes in a
+// single transaction.
+func (r *RecoveryCodeRepository) Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("RecoveryCodeRepository.Replace", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return apperrors.DatabaseError("RecoveryCodeRepository.Replace", err)
+	}
+
+	now := time.Now().UTC()
+	for _, hash := range codeHashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO user_recovery_codes (id, user_id, code_hash, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			uuid.New(), userID, hash, now,
+		)
+		if err != nil {
+			return apperrors.DatabaseError("RecoveryCodeRepository.Replace", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("RecoveryCodeRepository.Replace", err)
+	}
+	return nil
+}
+
+// Use marks an unused code as used. The update is conditional, so a code
+// submitted twice concurrently is only accepted once.
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE user_recovery_codes SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, userID, codeHash, time.Now().UTC())
+	if err != nil {
+		return apperrors.DatabaseError("RecoveryCodeRepository.Use", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("recovery code")
+	}
+	return nil
+}
+
+// CountUnused returns how many of a user's codes are still unused.
+func (r *RecoveryCodeRepository) CountUnused(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`,
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperrors.DatabaseError("RecoveryCodeRepository.CountUnused", err)
+	}
+	return count, nil
+}
+
+// DeleteByUserID removes all of a user's codes.
+func (r *RecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return apperrors.DatabaseError("RecoveryCodeRepository.DeleteByUserID", err)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"errors"
 	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func TestErrNotFound(t *testing.T) {
@@ -34,7 +36,7 @@ func TestErrNotFound_ErrorsIs(t *testing.T) {
 func TestNewCallRepository(t *testing.T) {
 	// Test that NewCallRepository creates a repository with nil pool
 	// (just testing the constructor, not database operations)
-	repo := NewCallRepository(nil)
+	repo := NewCallRepository(Pools{})
 
 	if repo == nil {
 		t.Fatal("expected non-nil repository")
@@ -45,6 +47,21 @@ func TestNewCallRepository(t *testing.T) {
 	}
 }
 
+func TestPools_Reader(t *testing.T) {
+	primary := &pgxpool.Pool{}
+	replica := &pgxpool.Pool{}
+
+	repo := NewCallRepository(NewPools(primary, nil))
+	if repo.pool != primary || repo.reader != primary {
+		t.Error("expected reads and writes on the primary without a replica")
+	}
+
+	repo = NewCallRepository(NewPools(primary, replica))
+	if repo.pool != primary || repo.reader != replica {
+		t.Error("expected writes on the primary and heavy reads on the replica")
+	}
+}
+
 func TestNewUserRepository(t *testing.T) {
 	// Test that NewUserRepository creates a repository
 	repo := NewUserRepository(nil)
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// heldCalls selects the IDs of calls on legal hold, directly or through
+// their customer.
+const heldCalls = `
+	SELECT held.id FROM calls held
+	LEFT JOIN customers held_customer ON held_customer.id = held.customer_id
+	WHERE held.legal_hold_at IS NOT NULL OR held_customer.legal_hold_at IS NOT NULL`
+
+// callNotOnLegalHold returns a condition that is true when the call named by
+// callID is not on legal hold, directly or through its customer.
+func callNotOnLegalHold(callID string) string {
+	return callID + ` NOT IN (` + heldCalls + `)`
+}
+
+// RetentionRepository implements domain.RetentionRepository using PostgreSQL.
+type RetentionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRetentionRepository creates a new RetentionRepository.
+func NewRetentionRepository(pool *pgxpool.Pool) *RetentionRepository {
+	return &RetentionRepository{pool: pool}
+}
+
+// SetLegalHold places a call or customer on legal hold, or releases the hold
+// when reason is nil. Changing the reason of a hold keeps when it was placed.
+func (r *RetentionRepository) SetLegalHold(ctx context.Context, target domain.LegalHoldTarget, id uuid.UUID, reason *string) error {
+	var table string
+	switch target {
+	case domain.LegalHoldCall:
+		table = "calls"
+	case domain.LegalHoldCustomer:
+		table = "customers"
+	default:
+		return apperrors.ValidationFailed(fmt.Sprintf("unknown legal hold target %q", target))
+	}
+
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE ` + table + ` SET
+			legal_hold_at = CASE WHEN $2::text IS NULL THEN NULL ELSE COALESCE(legal_hold_at, $3) END,
+			legal_hold_reason = $2
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id, reason, time.Now().UTC())
+	if err != nil {
+		return apperrors.DatabaseError("RetentionRepository.SetLegalHold", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound(string(target))
+	}
+	return nil
+}
+
+// ListLegalHolds retrieves the calls and customers on legal hold, most
+// recently placed first.
+func (r *RetentionRepository) ListLegalHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT 'call', id, COALESCE(legal_hold_reason, ''), legal_hold_at
+		FROM calls WHERE legal_hold_at IS NOT NULL
+		UNION ALL
+		SELECT 'customer', id, COALESCE(legal_hold_reason, ''), legal_hold_at
+		FROM customers WHERE legal_hold_at IS NOT NULL
+		ORDER BY 4 DESC`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("RetentionRepository.ListLegalHolds", err)
+	}
+	defer rows.Close()
+
+	var holds []*domain.LegalHold
+	for rows.Next() {
+		hold := &domain.LegalHold{}
+		var target string
+		if err := rows.Scan(&target, &hold.ID, &hold.Reason, &hold.PlacedAt); err != nil {
+			return nil, apperrors.DatabaseError("RetentionRepository.ListLegalHolds", err)
+		}
+		hold.Target = domain.LegalHoldTarget(target)
+		holds = append(holds, hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("RetentionRepository.ListLegalHolds", err)
+	}
+	return holds, nil
+}
+
+// transcriptsBefore selects calls created before $1 that still hold a
+// transcript and are not on legal hold.
+var transcriptsBefore = `
+	FROM calls
+	WHERE created_at < $1 AND transcript_purged_at IS NULL
+		AND (transcript IS NOT NULL OR transcript_json IS NOT NULL)
+		AND ` + callNotOnLegalHold("calls.id")
+
+// CountTranscriptsBefore counts transcripts of calls created before cutoff.
+func (r *RetentionRepository) CountTranscriptsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return r.count(ctx, "RetentionRepository.CountTranscriptsBefore", `SELECT COUNT(*) `+transcriptsBefore, cutoff)
+}
+
+// PurgeTranscriptsBefore removes up to limit transcripts of calls created
+// before cutoff, oldest first. The calls themselves are kept.
+func (r *RetentionRepository) PurgeTranscriptsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	query := `
+		WITH batch AS (
+			SELECT id ` + transcriptsBefore + `
+			ORDER BY created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), purged AS (
+			UPDATE calls SET transcript = NULL, transcript_json = NULL, transcript_purged_at = $2
+			WHERE id IN (SELECT id FROM batch)
+			RETURNING id
+		)
+		SELECT COUNT(*) FROM purged`
+	return r.purge(ctx, "RetentionRepository.PurgeTranscriptsBefore", query, cutoff, time.Now().UTC(), limit)
+}
+
+// auditEventsBefore selects audit events that occurred before $1, leaving
+// out events about held calls, their quotes and held customers.
+var auditEventsBefore = `
+	FROM audit_events a
+	WHERE a.occurred_at < $1
+		AND NOT EXISTS (
+			SELECT 1 FROM (` + heldCalls + `) h
+			WHERE a.resource_type IN ('call', 'quote') AND a.resource_id = h.id::text
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM customers c
+			WHERE c.legal_hold_at IS NOT NULL
+				AND a.resource_type = 'customer' AND a.resource_id = c.id::text
+		)`
+
+// CountAuditEventsBefore counts audit events that occurred before cutoff.
+func (r *RetentionRepository) CountAuditEventsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return r.count(ctx, "RetentionRepository.CountAuditEventsBefore", `SELECT COUNT(*) `+auditEventsBefore, cutoff)
+}
+
+// PurgeAuditEventsBefore deletes up to limit audit events that occurred
+// before cutoff, oldest first.
+func (r *RetentionRepository) PurgeAuditEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	query := `
+		WITH batch AS (
+			SELECT a.id ` + auditEventsBefore + `
+			ORDER BY a.occurred_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		), purged AS (
+			DELETE FROM audit_events WHERE id IN (SELECT id FROM batch)
+			RETURNING id
+		)
+		SELECT COUNT(*) FROM purged`
+	return r.purge(ctx, "RetentionRepository.PurgeAuditEventsBefore", query, cutoff, limit)
+}
+
+func (r *RetentionRepository) count(ctx context.Context, op, query string, args ...interface{}) (int, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError(op, err)
+	}
+	return count, nil
+}
+
+func (r *RetentionRepository) purge(ctx context.Context, op, query string, args ...interface{}) (int, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError(op, err)
+	}
+	return count, nil
+}
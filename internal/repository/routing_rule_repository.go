@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const routingRuleColumns = `
+	id, name, priority, area_codes, regions, prompt_id, transfer_number,
+	is_active, created_at, updated_at`
+
+// RoutingRuleRepository implements domain.RoutingRuleRepository using PostgreSQL.
+type RoutingRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRoutingRuleRepository creates a new RoutingRuleRepository.
+func NewRoutingRuleRepository(pool *pgxpool.Pool) *RoutingRuleRepository {
+	return &RoutingRuleRepository{pool: pool}
+}
+
+// Create inserts a new routing rule.
+func (r *RoutingRuleRepository) Create(ctx context.Context, rule *domain.RoutingRule) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO routing_rules (` + routingRuleColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)`
+
+	if _, err := r.pool.Exec(ctx, query, routingRuleArgs(rule)...); err != nil {
+		return apperrors.DatabaseError("RoutingRuleRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a routing rule by ID.
+func (r *RoutingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RoutingRule, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + routingRuleColumns + ` FROM routing_rules WHERE id = $1`
+	return scanRoutingRule(r.pool.QueryRow(ctx, query, id))
+}
+
+// Update updates a routing rule.
+func (r *RoutingRuleRepository) Update(ctx context.Context, rule *domain.RoutingRule) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE routing_rules SET
+			name = $2,
+			priority = $3,
+			area_codes = $4,
+			regions = $5,
+			prompt_id = $6,
+			transfer_number = $7,
+			is_active = $8,
+			updated_at = $10
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, routingRuleArgs(rule)...)
+	if err != nil {
+		return apperrors.DatabaseError("RoutingRuleRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("routing rule")
+	}
+	return nil
+}
+
+// Delete removes a routing rule.
+func (r *RoutingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM routing_rules WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("RoutingRuleRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("routing rule")
+	}
+	return nil
+}
+
+// List retrieves all routing rules by priority, then name.
+func (r *RoutingRuleRepository) List(ctx context.Context) ([]*domain.RoutingRule, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT `+routingRuleColumns+` FROM routing_rules ORDER BY priority, name`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("RoutingRuleRepository.List", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.RoutingRule
+	for rows.Next() {
+		rule, err := scanRoutingRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("RoutingRuleRepository.List", err)
+	}
+	return rules, nil
+}
+
+func routingRuleArgs(rule *domain.RoutingRule) []interface{} {
+	areaCodes := rule.AreaCodes
+	if areaCodes == nil {
+		areaCodes = []string{}
+	}
+	regions := rule.Regions
+	if regions == nil {
+		regions = []string{}
+	}
+	return []interface{}{
+		rule.ID,
+		rule.Name,
+		rule.Priority,
+		areaCodes,
+		regions,
+		rule.PromptID,
+		nullableString(rule.TransferNumber),
+		rule.IsActive,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	}
+}
+
+func scanRoutingRule(row pgx.Row) (*domain.RoutingRule, error) {
+	rule := &domain.RoutingRule{}
+	var transferNumber *string
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Priority,
+		&rule.AreaCodes,
+		&rule.Regions,
+		&rule.PromptID,
+		&transferNumber,
+		&rule.IsActive,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("routing rule")
+		}
+		return nil, apperrors.DatabaseError("RoutingRuleRepository.scan", err)
+	}
+	rule.TransferNumber = stringValue(transferNumber)
+	return rule, nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const savedViewColumns = `
+	id, user_id, name, filters, sort, columns, is_default, created_at, updated_at`
+
+// SavedViewRepository implements domain.SavedViewRepository using PostgreSQL.
+type SavedViewRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSavedViewRepository creates a new SavedViewRepository.
+func NewSavedViewRepository(pool *pgxpool.Pool) *SavedViewRepository {
+	return &SavedViewRepository{pool: pool}
+}
+
+// Create inserts a view.
+func (r *SavedViewRepository) Create(ctx context.Context, view *domain.SavedView) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	filtersJSON, err := json.Marshal(view.Filters)
+	if err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.Create", err)
+	}
+
+	query := `
+		INSERT INTO saved_views (` + savedViewColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = r.pool.Exec(ctx, query,
+		view.ID, view.UserID, view.Name, filtersJSON, view.Sort, savedViewColumnsArg(view.Columns),
+		view.IsDefault, view.CreatedAt, view.UpdatedAt)
+	if err != nil {
+		return savedViewWriteError("SavedViewRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a view by ID.
+func (r *SavedViewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE id = $1`
+	return scanSavedView(r.pool.QueryRow(ctx, query, id))
+}
+
+// Update updates a view's name, filters, sort and columns.
+func (r *SavedViewRepository) Update(ctx context.Context, view *domain.SavedView) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	filtersJSON, err := json.Marshal(view.Filters)
+	if err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.Update", err)
+	}
+
+	query := `
+		UPDATE saved_views SET
+			name = $2,
+			filters = $3,
+			sort = $4,
+			columns = $5,
+			updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		view.ID, view.Name, filtersJSON, view.Sort, savedViewColumnsArg(view.Columns), view.UpdatedAt)
+	if err != nil {
+		return savedViewWriteError("SavedViewRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("saved view")
+	}
+	return nil
+}
+
+// Delete removes a view.
+func (r *SavedViewRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM saved_views WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("saved view")
+	}
+	return nil
+}
+
+// ListByUser retrieves a user's views, ordered by name.
+func (r *SavedViewRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.SavedView, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE user_id = $1 ORDER BY name`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("SavedViewRepository.ListByUser", err)
+	}
+	defer rows.Close()
+
+	var views []*domain.SavedView
+	for rows.Next() {
+		view, err := scanSavedView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("SavedViewRepository.ListByUser", err)
+	}
+	return views, nil
+}
+
+// CountByUser counts a user's views.
+func (r *SavedViewRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM saved_views WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("SavedViewRepository.CountByUser", err)
+	}
+	return count, nil
+}
+
+// GetDefault retrieves the user's default view.
+func (r *SavedViewRepository) GetDefault(ctx context.Context, userID uuid.UUID) (*domain.SavedView, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + savedViewColumns + ` FROM saved_views WHERE user_id = $1 AND is_default`
+	return scanSavedView(r.pool.QueryRow(ctx, query, userID))
+}
+
+// SetDefault makes a view the user's default in one transaction, so the
+// user never has two.
+func (r *SavedViewRepository) SetDefault(ctx context.Context, userID uuid.UUID, id *uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.SetDefault", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE saved_views SET is_default = false WHERE user_id = $1 AND is_default`, userID); err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.SetDefault", err)
+	}
+	if id != nil {
+		result, err := tx.Exec(ctx,
+			`UPDATE saved_views SET is_default = true WHERE id = $1 AND user_id = $2`, *id, userID)
+		if err != nil {
+			return apperrors.DatabaseError("SavedViewRepository.SetDefault", err)
+		}
+		if result.RowsAffected() == 0 {
+			return apperrors.NotFound("saved view")
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return apperrors.DatabaseError("SavedViewRepository.SetDefault", err)
+	}
+	return nil
+}
+
+// savedViewColumnsArg stores no columns as an empty array, for all columns.
+func savedViewColumnsArg(columns []string) []string {
+	if columns == nil {
+		return []string{}
+	}
+	return columns
+}
+
+func savedViewWriteError(op string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return apperrors.New(apperrors.CodeAlreadyExists, "you already have a view with this name")
+	}
+	return apperrors.DatabaseError(op, err)
+}
+
+func scanSavedView(row pgx.Row) (*domain.SavedView, error) {
+	view := &domain.SavedView{}
+	var filtersJSON []byte
+	err := row.Scan(
+		&view.ID,
+		&view.UserID,
+		&view.Name,
+		&filtersJSON,
+		&view.Sort,
+		&view.Columns,
+		&view.IsDefault,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("saved view")
+		}
+		return nil, apperrors.DatabaseError("SavedViewRepository.scan", err)
+	}
+	if err := json.Unmarshal(filtersJSON, &view.Filters); err != nil {
+		return nil, apperrors.DatabaseError("SavedViewRepository.scan", err)
+	}
+	if len(view.Columns) == 0 {
+		view.Columns = nil
+	}
+	return view, nil
+}
@@ -85,7 +85,7 @@ func (r *SettingsRepository) GetAll(ctx context.Context) ([]*domain.Setting, err
 		ORDER BY category, key
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := querier(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, apperrors.DatabaseError("SettingsRepository.GetAll", err)
 	}
@@ -117,7 +117,7 @@ func (r *SettingsRepository) Set(ctx context.Context, key, value string) error {
 		WHERE key = $1
 	`
 
-	result, err := r.db.Exec(ctx, query, key, value)
+	result, err := querier(ctx, r.db).Exec(ctx, query, key, value)
 	if err != nil {
 		return apperrors.DatabaseError("SettingsRepository.Set", err)
 	}
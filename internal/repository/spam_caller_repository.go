@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const spamCallerColumns = `
+	id, phone_number, score, reasons, flagged_calls, status, auto_blocked,
+	first_flagged_at, last_flagged_at, reviewed_at, reviewed_by`
+
+// SpamCallerRepository implements domain.SpamCallerRepository using PostgreSQL.
+type SpamCallerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewSpamCallerRepository creates a new SpamCallerRepository.
+func NewSpamCallerRepository(pool *pgxpool.Pool) *SpamCallerRepository {
+	return &SpamCallerRepository{pool: pool}
+}
+
+// RecordFlag adds a flagged call to the caller's entry, creating it if the
+// number has none. The entry's score and reasons become the call's.
+func (r *SpamCallerRepository) RecordFlag(ctx context.Context, phoneNumber string, score int, reasons []string, at time.Time) (*domain.SpamCaller, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	query := `
+		INSERT INTO spam_callers (
+			id, phone_number, score, reasons, flagged_calls, status,
+			first_flagged_at, last_flagged_at
+		) VALUES ($1, $2, $3, $4, 1, $5, $6, $6)
+		ON CONFLICT (phone_number) DO UPDATE SET
+			score = EXCLUDED.score,
+			reasons = EXCLUDED.reasons,
+			flagged_calls = spam_callers.flagged_calls + 1,
+			last_flagged_at = EXCLUDED.last_flagged_at
+		RETURNING ` + spamCallerColumns
+
+	return scanSpamCaller(r.pool.QueryRow(ctx, query,
+		uuid.New(),
+		phoneNumber,
+		score,
+		reasons,
+		domain.SpamCallerPending,
+		at,
+	))
+}
+
+// GetByID retrieves an entry by ID.
+func (r *SpamCallerRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SpamCaller, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + spamCallerColumns + ` FROM spam_callers WHERE id = $1`
+	return scanSpamCaller(r.pool.QueryRow(ctx, query, id))
+}
+
+// GetByPhoneNumber retrieves the entry for an E.164 number.
+func (r *SpamCallerRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.SpamCaller, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + spamCallerColumns + ` FROM spam_callers WHERE phone_number = $1`
+	return scanSpamCaller(r.pool.QueryRow(ctx, query, phoneNumber))
+}
+
+// List retrieves entries with the given status, most recently flagged first.
+func (r *SpamCallerRepository) List(ctx context.Context, status domain.SpamCallerStatus, limit int) ([]*domain.SpamCaller, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + spamCallerColumns + `
+		FROM spam_callers
+		WHERE status = $1
+		ORDER BY last_flagged_at DESC
+		LIMIT $2`
+
+	rows, err := r.pool.Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, apperrors.DatabaseError("SpamCallerRepository.List", err)
+	}
+	defer rows.Close()
+
+	var callers []*domain.SpamCaller
+	for rows.Next() {
+		caller, err := scanSpamCaller(rows)
+		if err != nil {
+			return nil, err
+		}
+		callers = append(callers, caller)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("SpamCallerRepository.List", err)
+	}
+	return callers, nil
+}
+
+// Review sets an entry's status. An entry blocked with no reviewer is
+// marked auto-blocked.
+func (r *SpamCallerRepository) Review(ctx context.Context, id uuid.UUID, status domain.SpamCallerStatus, reviewedBy *uuid.UUID, at time.Time) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE spam_callers SET
+			status = $2,
+			auto_blocked = ($2 = 'blocked' AND $3::uuid IS NULL),
+			reviewed_at = $4,
+			reviewed_by = $3
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, id, status, reviewedBy, at)
+	if err != nil {
+		return apperrors.DatabaseError("SpamCallerRepository.Review", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("spam caller")
+	}
+	return nil
+}
+
+// CountCallsFrom counts calls from a number created at or after since.
+func (r *SpamCallerRepository) CountCallsFrom(ctx context.Context, phoneNumber string, since time.Time) (int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	query := `SELECT COUNT(*) FROM calls WHERE from_number = $1 AND created_at >= $2`
+	if err := r.pool.QueryRow(ctx, query, phoneNumber, since).Scan(&count); err != nil {
+		return 0, apperrors.DatabaseError("SpamCallerRepository.CountCallsFrom", err)
+	}
+	return count, nil
+}
+
+func scanSpamCaller(row pgx.Row) (*domain.SpamCaller, error) {
+	caller := &domain.SpamCaller{}
+	err := row.Scan(
+		&caller.ID,
+		&caller.PhoneNumber,
+		&caller.Score,
+		&caller.Reasons,
+		&caller.FlaggedCalls,
+		&caller.Status,
+		&caller.AutoBlocked,
+		&caller.FirstFlaggedAt,
+		&caller.LastFlaggedAt,
+		&caller.ReviewedAt,
+		&caller.ReviewedBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("spam caller")
+		}
+		return nil, apperrors.DatabaseError("SpamCallerRepository.scan", err)
+	}
+	return caller, nil
+}
@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const tagColumns = `
+	t.id, t.name, t.description, t.color, t.managed, t.created_by,
+	t.created_at, t.updated_at`
+
+const tagViewColumns = `id, name, entity_type, tags, user_id, created_at`
+
+// TagRepository implements domain.TagRepository using PostgreSQL.
+type TagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTagRepository creates a new TagRepository.
+func NewTagRepository(pool *pgxpool.Pool) *TagRepository {
+	return &TagRepository{pool: pool}
+}
+
+// taggedCondition returns a condition matching the rows whose id carries
+// every tag named in the text[] parameter at paramIndex.
+func taggedCondition(entityType domain.TagEntityType, paramIndex int) string {
+	return fmt.Sprintf(`id IN (
+			SELECT et.entity_id FROM entity_tags et JOIN tags tg ON tg.id = et.tag_id
+			WHERE et.entity_type = '%s' AND tg.name = ANY($%d::text[])
+			GROUP BY et.entity_id
+			HAVING COUNT(*) = cardinality($%d::text[]))`, entityType, paramIndex, paramIndex)
+}
+
+// Create inserts a tag.
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO tags (id, name, description, color, managed, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.pool.Exec(ctx, query,
+		tag.ID, tag.Name, tag.Description, tag.Color, tag.Managed, tag.CreatedBy, tag.CreatedAt, tag.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return apperrors.New(apperrors.CodeAlreadyExists, "a tag with this name already exists")
+		}
+		return apperrors.DatabaseError("TagRepository.Create", err)
+	}
+	return nil
+}
+
+// GetOrCreate returns the tag with tag's name, inserting tag if there is
+// none.
+func (r *TagRepository) GetOrCreate(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	// The no-op update returns the existing row when the name is taken
+	query := `
+		INSERT INTO tags AS t (id, name, description, color, managed, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING ` + tagColumns
+
+	return scanTag(r.pool.QueryRow(ctx, query,
+		tag.ID, tag.Name, tag.Description, tag.Color, tag.Managed, tag.CreatedBy, tag.CreatedAt, tag.UpdatedAt))
+}
+
+// GetByName retrieves a tag by name.
+func (r *TagRepository) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + tagColumns + ` FROM tags t WHERE t.name = $1`
+	return scanTag(r.pool.QueryRow(ctx, query, name))
+}
+
+// Update updates a tag's description, color and whether it is managed.
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE tags SET
+			description = $2,
+			color = $3,
+			managed = $4,
+			updated_at = $5
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query, tag.ID, tag.Description, tag.Color, tag.Managed, tag.UpdatedAt)
+	if err != nil {
+		return apperrors.DatabaseError("TagRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("tag")
+	}
+	return nil
+}
+
+// Delete removes a tag. Its applications are deleted with it.
+func (r *TagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("TagRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("tag")
+	}
+	return nil
+}
+
+// List retrieves every tag with its usage count, ordered by name.
+func (r *TagRepository) List(ctx context.Context) ([]*domain.Tag, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + tagColumns + `, COUNT(et.tag_id)
+		FROM tags t
+		LEFT JOIN entity_tags et ON et.tag_id = t.id
+		GROUP BY t.id
+		ORDER BY t.name`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.List", err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		tag := &domain.Tag{}
+		if err := rows.Scan(
+			&tag.ID, &tag.Name, &tag.Description, &tag.Color, &tag.Managed, &tag.CreatedBy,
+			&tag.CreatedAt, &tag.UpdatedAt, &tag.UsageCount,
+		); err != nil {
+			return nil, apperrors.DatabaseError("TagRepository.List", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.List", err)
+	}
+	return tags, nil
+}
+
+// Apply tags a record.
+func (r *TagRepository) Apply(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityID uuid.UUID, createdBy *uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO entity_tags (tag_id, entity_type, entity_id, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+
+	if _, err := r.pool.Exec(ctx, query, tagID, entityType, entityID, createdBy); err != nil {
+		return apperrors.DatabaseError("TagRepository.Apply", err)
+	}
+	return nil
+}
+
+// ApplyMany tags records of one type.
+func (r *TagRepository) ApplyMany(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityIDs []uuid.UUID, createdBy *uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO entity_tags (tag_id, entity_type, entity_id, created_by)
+		SELECT $1, $2, entity_id, $4 FROM UNNEST($3::uuid[]) AS entity_id
+		ON CONFLICT DO NOTHING`
+
+	if _, err := r.pool.Exec(ctx, query, tagID, entityType, entityIDs, createdBy); err != nil {
+		return apperrors.DatabaseError("TagRepository.ApplyMany", err)
+	}
+	return nil
+}
+
+// Remove takes a tag off a record.
+func (r *TagRepository) Remove(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityID uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx,
+		`DELETE FROM entity_tags WHERE tag_id = $1 AND entity_type = $2 AND entity_id = $3`,
+		tagID, entityType, entityID)
+	if err != nil {
+		return apperrors.DatabaseError("TagRepository.Remove", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("tag")
+	}
+	return nil
+}
+
+// ListFor retrieves the tags on a record, ordered by name.
+func (r *TagRepository) ListFor(ctx context.Context, entityType domain.TagEntityType, entityID uuid.UUID) ([]*domain.Tag, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + tagColumns + `
+		FROM tags t
+		JOIN entity_tags et ON et.tag_id = t.id
+		WHERE et.entity_type = $1 AND et.entity_id = $2
+		ORDER BY t.name`
+
+	rows, err := r.pool.Query(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.ListFor", err)
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.ListFor", err)
+	}
+	return tags, nil
+}
+
+// CreateView inserts a saved view.
+func (r *TagRepository) CreateView(ctx context.Context, view *domain.TagView) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO tag_views (` + tagViewColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := r.pool.Exec(ctx, query,
+		view.ID, view.Name, view.EntityType, view.Tags, view.UserID, view.CreatedAt); err != nil {
+		return apperrors.DatabaseError("TagRepository.CreateView", err)
+	}
+	return nil
+}
+
+// GetView retrieves a saved view by ID.
+func (r *TagRepository) GetView(ctx context.Context, id uuid.UUID) (*domain.TagView, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + tagViewColumns + ` FROM tag_views WHERE id = $1`
+	return scanTagView(r.pool.QueryRow(ctx, query, id))
+}
+
+// ListViews retrieves the shared views and the user's own.
+func (r *TagRepository) ListViews(ctx context.Context, userID uuid.UUID) ([]*domain.TagView, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + tagViewColumns + `
+		FROM tag_views
+		WHERE user_id IS NULL OR user_id = $1
+		ORDER BY user_id IS NOT NULL, name`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.ListViews", err)
+	}
+	defer rows.Close()
+
+	var views []*domain.TagView
+	for rows.Next() {
+		view, err := scanTagView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("TagRepository.ListViews", err)
+	}
+	return views, nil
+}
+
+// DeleteView removes a saved view.
+func (r *TagRepository) DeleteView(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM tag_views WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("TagRepository.DeleteView", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("tag view")
+	}
+	return nil
+}
+
+func scanTag(row pgx.Row) (*domain.Tag, error) {
+	tag := &domain.Tag{}
+	err := row.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Description,
+		&tag.Color,
+		&tag.Managed,
+		&tag.CreatedBy,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("tag")
+		}
+		return nil, apperrors.DatabaseError("TagRepository.scan", err)
+	}
+	return tag, nil
+}
+
+func scanTagView(row pgx.Row) (*domain.TagView, error) {
+	view := &domain.TagView{}
+	err := row.Scan(
+		&view.ID,
+		&view.Name,
+		&view.EntityType,
+		&view.Tags,
+		&view.UserID,
+		&view.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("tag view")
+		}
+		return nil, apperrors.DatabaseError("TagRepository.scanView", err)
+	}
+	return view, nil
+}
@@ -13,6 +13,35 @@ import (
 	apperrors "github.com/jkindrix/quickquote/internal/errors"
 )
 
+// userColumns lists the columns scanned by scanUser, in order.
+const userColumns = `id, email, password_hash, role, created_at, updated_at, deleted_at, disabled_at,
+		       totp_secret, totp_enabled_at, totp_last_counter, email_verified_at, sso_issuer, sso_subject`
+
+// scanUser scans a row selected with userColumns.
+func scanUser(row pgx.Row) (*domain.User, error) {
+	user := &domain.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+		&user.DisabledAt,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+		&user.TOTPLastCounter,
+		&user.EmailVerifiedAt,
+		&user.SSOIssuer,
+		&user.SSOSubject,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 // UserRepository implements domain.UserRepository using PostgreSQL.
 type UserRepository struct {
 	pool *pgxpool.Pool
@@ -29,8 +58,8 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	defer cancel()
 
 	query := `
-		INSERT INTO users (id, email, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO users (id, email, password_hash, created_at, updated_at, totp_secret, totp_enabled_at, totp_last_counter, email_verified_at, role, disabled_at, sso_issuer, sso_subject)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 
 	_, err := r.pool.Exec(ctx, query,
 		user.ID,
@@ -38,6 +67,14 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		user.PasswordHash,
 		user.CreatedAt,
 		user.UpdatedAt,
+		user.TOTPSecret,
+		user.TOTPEnabledAt,
+		user.TOTPLastCounter,
+		user.EmailVerifiedAt,
+		user.Role,
+		user.DisabledAt,
+		user.SSOIssuer,
+		user.SSOSubject,
 	)
 	if err != nil {
 		return apperrors.DatabaseError("UserRepository.Create", err)
@@ -52,19 +89,11 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	defer cancel()
 
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at, deleted_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-		&user.DeletedAt,
-	)
+	user, err := scanUser(r.pool.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.NotFound("user")
@@ -81,19 +110,11 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	defer cancel()
 
 	query := `
-		SELECT id, email, password_hash, created_at, updated_at, deleted_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1 AND deleted_at IS NULL`
 
-	user := &domain.User{}
-	err := r.pool.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-		&user.DeletedAt,
-	)
+	user, err := scanUser(r.pool.QueryRow(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.NotFound("user")
@@ -104,6 +125,28 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return user, nil
 }
 
+// GetBySSOIdentity retrieves the user linked to an identity provider's
+// issuer and subject (excludes soft-deleted users).
+func (r *UserRepository) GetBySSOIdentity(ctx context.Context, issuer, subject string) (*domain.User, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE sso_issuer = $1 AND sso_subject = $2 AND sso_subject <> '' AND deleted_at IS NULL`
+
+	user, err := scanUser(r.pool.QueryRow(ctx, query, issuer, subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("user")
+		}
+		return nil, apperrors.DatabaseError("UserRepository.GetBySSOIdentity", err)
+	}
+
+	return user, nil
+}
+
 // Update updates an existing user (excludes soft-deleted users).
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	ctx, cancel := WithWriteTimeout(ctx)
@@ -116,7 +159,15 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 			email = $2,
 			password_hash = $3,
 			updated_at = $4,
-			deleted_at = $5
+			deleted_at = $5,
+			totp_secret = $6,
+			totp_enabled_at = $7,
+			totp_last_counter = $8,
+			email_verified_at = $9,
+			role = $10,
+			disabled_at = $11,
+			sso_issuer = $12,
+			sso_subject = $13
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.pool.Exec(ctx, query,
@@ -125,6 +176,14 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		user.PasswordHash,
 		user.UpdatedAt,
 		user.DeletedAt,
+		user.TOTPSecret,
+		user.TOTPEnabledAt,
+		user.TOTPLastCounter,
+		user.EmailVerifiedAt,
+		user.Role,
+		user.DisabledAt,
+		user.SSOIssuer,
+		user.SSOSubject,
 	)
 	if err != nil {
 		return apperrors.DatabaseError("UserRepository.Update", err)
@@ -137,6 +196,27 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// AdvanceTOTPCounter records the time step of a user's last accepted TOTP
+// code. The update is conditional, so a code submitted twice concurrently is
+// only accepted once.
+func (r *UserRepository) AdvanceTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE users SET totp_last_counter = $2, updated_at = $3
+		WHERE id = $1 AND totp_last_counter < $2 AND deleted_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id, counter, time.Now().UTC())
+	if err != nil {
+		return apperrors.DatabaseError("UserRepository.AdvanceTOTPCounter", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("user")
+	}
+	return nil
+}
+
 // Delete soft-deletes a user by setting deleted_at.
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ctx, cancel := WithWriteTimeout(ctx)
@@ -174,6 +254,55 @@ func (r *UserRepository) Count(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+// List returns all active (non-deleted) users, ordered by email.
+func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE deleted_at IS NULL
+		ORDER BY email`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, apperrors.DatabaseError("UserRepository.List", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError("UserRepository.List", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("UserRepository.List", err)
+	}
+
+	return users, nil
+}
+
+// CountActiveAdmins returns the number of admins who are neither deleted
+// nor disabled.
+func (r *UserRepository) CountActiveAdmins(ctx context.Context) (int64, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM users
+		WHERE role = 'admin' AND deleted_at IS NULL AND disabled_at IS NULL`,
+	).Scan(&count)
+	if err != nil {
+		return 0, apperrors.DatabaseError("UserRepository.CountActiveAdmins", err)
+	}
+	return count, nil
+}
+
 // SessionRepository implements domain.SessionRepository using PostgreSQL.
 type SessionRepository struct {
 	pool *pgxpool.Pool
@@ -190,8 +319,8 @@ func (r *SessionRepository) Create(ctx context.Context, session *domain.Session)
 	defer cancel()
 
 	query := `
-		INSERT INTO sessions (id, user_id, token, expires_at, created_at, last_active_at, ip_address, user_agent, previous_token, rotated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		INSERT INTO sessions (id, user_id, token, expires_at, created_at, last_active_at, ip_address, user_agent, previous_token, rotated_at, two_factor_verified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	_, err := r.pool.Exec(ctx, query,
 		session.ID,
@@ -204,6 +333,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *domain.Session)
 		session.UserAgent,
 		session.PreviousToken,
 		session.RotatedAt,
+		session.TwoFactorVerified,
 	)
 	if err != nil {
 		return apperrors.DatabaseError("SessionRepository.Create", err)
@@ -224,7 +354,8 @@ func (r *SessionRepository) GetByToken(ctx context.Context, token string) (*doma
 		       COALESCE(ip_address, '') as ip_address,
 		       COALESCE(user_agent, '') as user_agent,
 		       previous_token,
-		       rotated_at
+		       rotated_at,
+		       two_factor_verified
 		FROM sessions
 		WHERE expires_at > NOW() AND (
 			token = $1 OR
@@ -243,6 +374,7 @@ func (r *SessionRepository) GetByToken(ctx context.Context, token string) (*doma
 		&session.UserAgent,
 		&session.PreviousToken,
 		&session.RotatedAt,
+		&session.TwoFactorVerified,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -267,7 +399,8 @@ func (r *SessionRepository) Update(ctx context.Context, session *domain.Session)
 			ip_address = $5,
 			user_agent = $6,
 			previous_token = $7,
-			rotated_at = $8
+			rotated_at = $8,
+			two_factor_verified = $9
 		WHERE id = $1`
 
 	result, err := r.pool.Exec(ctx, query,
@@ -279,6 +412,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *domain.Session)
 		session.UserAgent,
 		session.PreviousToken,
 		session.RotatedAt,
+		session.TwoFactorVerified,
 	)
 	if err != nil {
 		return apperrors.DatabaseError("SessionRepository.Update", err)
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// UserTokenRepository implements domain.UserTokenRepository using PostgreSQL.
+type UserTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserTokenRepository creates a new UserTokenRepository.
+func NewUserTokenRepository(pool *pgxpool.Pool) *UserTokenRepository {
+	return &UserTokenRepository{pool: pool}
+}
+
+// Create inserts a new token.
+func (r *UserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.pool.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Purpose,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.UsedAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("UserTokenRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a token by purpose and hash.
+func (r *UserTokenRepository) GetByHash(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens
+		WHERE purpose = $1 AND token_hash = $2`
+
+	token := &domain.UserToken{}
+	err := r.pool.QueryRow(ctx, query, purpose, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("token")
+		}
+		return nil, apperrors.DatabaseError("UserTokenRepository.GetByHash", err)
+	}
+	return token, nil
+}
+
+// MarkUsed marks an unused token as used. The update is conditional, so a
+// link followed twice concurrently is only accepted once.
+func (r *UserTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx,
+		`UPDATE user_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`,
+		id, time.Now().UTC(),
+	)
+	if err != nil {
+		return apperrors.DatabaseError("UserTokenRepository.MarkUsed", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("token")
+	}
+	return nil
+}
+
+// DeleteByUserID removes a user's tokens for a purpose.
+func (r *UserTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pool.Exec(ctx,
+		`DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2`,
+		userID, purpose,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("UserTokenRepository.DeleteByUserID", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes expired tokens.
+func (r *UserTokenRepository) DeleteExpired(ctx context.Context) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.pool.Exec(ctx, `DELETE FROM user_tokens WHERE expires_at < NOW()`); err != nil {
+		return apperrors.DatabaseError("UserTokenRepository.DeleteExpired", err)
+	}
+	return nil
+}
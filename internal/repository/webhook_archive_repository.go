@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// webhookArchiveListColumns are the columns listed, leaving out the body.
+const webhookArchiveListColumns = `
+	id, provider, path, COALESCE(content_type, ''), COALESCE(provider_call_id, ''), size, received_at`
+
+// WebhookArchiveRepository implements domain.WebhookArchiveRepository using PostgreSQL.
+type WebhookArchiveRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookArchiveRepository creates a new WebhookArchiveRepository.
+func NewWebhookArchiveRepository(pool *pgxpool.Pool) *WebhookArchiveRepository {
+	return &WebhookArchiveRepository{pool: pool}
+}
+
+// Create stores an archived webhook.
+func (r *WebhookArchiveRepository) Create(ctx context.Context, webhook *domain.ArchivedWebhook) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_archive (id, provider, path, content_type, provider_call_id, body, size, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.pool.Exec(ctx, query,
+		webhook.ID,
+		webhook.Provider,
+		webhook.Path,
+		nullableString(webhook.ContentType),
+		nullableString(webhook.ProviderCallID),
+		webhook.Body,
+		webhook.Size,
+		webhook.ReceivedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookArchiveRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an archived webhook with its body.
+func (r *WebhookArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ArchivedWebhook, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + webhookArchiveListColumns + `, body FROM webhook_archive WHERE id = $1`
+
+	webhook := &domain.ArchivedWebhook{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&webhook.ID,
+		&webhook.Provider,
+		&webhook.Path,
+		&webhook.ContentType,
+		&webhook.ProviderCallID,
+		&webhook.Size,
+		&webhook.ReceivedAt,
+		&webhook.Body,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("archived webhook")
+		}
+		return nil, apperrors.DatabaseError("WebhookArchiveRepository.GetByID", err)
+	}
+	return webhook, nil
+}
+
+// List returns archived webhooks matching filter, newest first, without
+// their bodies.
+func (r *WebhookArchiveRepository) List(ctx context.Context, filter domain.WebhookArchiveFilter) ([]*domain.ArchivedWebhook, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	var conditions []string
+	var args []interface{}
+	if filter.Provider != "" {
+		args = append(args, filter.Provider)
+		conditions = append(conditions, fmt.Sprintf("provider = $%d", len(args)))
+	}
+	if filter.ProviderCallID != "" {
+		args = append(args, filter.ProviderCallID)
+		conditions = append(conditions, fmt.Sprintf("provider_call_id = $%d", len(args)))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions = append(conditions, fmt.Sprintf("received_at >= $%d", len(args)))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions = append(conditions, fmt.Sprintf("received_at < $%d", len(args)))
+	}
+	var where string
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`SELECT %s FROM webhook_archive %s
+		ORDER BY received_at DESC
+		LIMIT $%d`, webhookArchiveListColumns, where, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError("WebhookArchiveRepository.List", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*domain.ArchivedWebhook
+	for rows.Next() {
+		webhook := &domain.ArchivedWebhook{}
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.Provider,
+			&webhook.Path,
+			&webhook.ContentType,
+			&webhook.ProviderCallID,
+			&webhook.Size,
+			&webhook.ReceivedAt,
+		); err != nil {
+			return nil, apperrors.DatabaseError("WebhookArchiveRepository.List", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("WebhookArchiveRepository.List", err)
+	}
+	return webhooks, nil
+}
+
+// DeleteBefore removes webhooks received before the given time.
+func (r *WebhookArchiveRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM webhook_archive WHERE received_at < $1`, before)
+	if err != nil {
+		return 0, apperrors.DatabaseError("WebhookArchiveRepository.DeleteBefore", err)
+	}
+	return result.RowsAffected(), nil
+}
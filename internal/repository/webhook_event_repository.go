@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const webhookEventColumns = `
+	id, provider, provider_call_id, event_status, payload, event,
+	status, attempts, max_attempts, call_id,
+	created_at, updated_at, scheduled_at, started_at, completed_at, last_error`
+
+// WebhookEventRepository implements domain.WebhookEventRepository using PostgreSQL.
+type WebhookEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookEventRepository creates a new WebhookEventRepository.
+func NewWebhookEventRepository(pool *pgxpool.Pool) *WebhookEventRepository {
+	return &WebhookEventRepository{pool: pool}
+}
+
+// Create inserts a new webhook event.
+func (r *WebhookEventRepository) Create(ctx context.Context, event *domain.WebhookEvent) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	var eventStatus *string
+	if event.EventStatus != "" {
+		eventStatus = &event.EventStatus
+	}
+
+	query := `
+		INSERT INTO webhook_events (` + webhookEventColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+		)`
+
+	_, err := r.pool.Exec(ctx, query,
+		event.ID,
+		event.Provider,
+		event.ProviderCallID,
+		eventStatus,
+		nullableJSON(event.Payload),
+		[]byte(event.Event),
+		event.Status,
+		event.Attempts,
+		event.MaxAttempts,
+		event.CallID,
+		event.CreatedAt,
+		event.UpdatedAt,
+		event.ScheduledAt,
+		event.StartedAt,
+		event.CompletedAt,
+		event.LastError,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookEventRepository.Create", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a webhook event by ID.
+func (r *WebhookEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEvent, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + webhookEventColumns + ` FROM webhook_events WHERE id = $1`
+
+	event, err := scanWebhookEvent(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("webhook_event")
+		}
+		return nil, apperrors.DatabaseError("WebhookEventRepository.GetByID", err)
+	}
+	return event, nil
+}
+
+// Update updates an existing webhook event's processing state.
+func (r *WebhookEventRepository) Update(ctx context.Context, event *domain.WebhookEvent) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_events SET
+			status = $2,
+			attempts = $3,
+			max_attempts = $4,
+			call_id = $5,
+			updated_at = $6,
+			scheduled_at = $7,
+			started_at = $8,
+			completed_at = $9,
+			last_error = $10
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		event.ID,
+		event.Status,
+		event.Attempts,
+		event.MaxAttempts,
+		event.CallID,
+		event.UpdatedAt,
+		event.ScheduledAt,
+		event.StartedAt,
+		event.CompletedAt,
+		event.LastError,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookEventRepository.Update", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("webhook_event")
+	}
+
+	return nil
+}
+
+// GetPendingEvents retrieves events due for a retry.
+func (r *WebhookEventRepository) GetPendingEvents(ctx context.Context, limit int) ([]*domain.WebhookEvent, error) {
+	query := `
+		SELECT ` + webhookEventColumns + `
+		FROM webhook_events
+		WHERE status = 'pending' AND scheduled_at <= NOW()
+		ORDER BY scheduled_at ASC
+		LIMIT $1`
+
+	return r.queryEvents(ctx, "WebhookEventRepository.GetPendingEvents", query, limit)
+}
+
+// GetProcessingEvents retrieves events that have been processing longer than olderThan.
+func (r *WebhookEventRepository) GetProcessingEvents(ctx context.Context, olderThan time.Duration) ([]*domain.WebhookEvent, error) {
+	cutoff := time.Now().Add(-olderThan)
+
+	query := `
+		SELECT ` + webhookEventColumns + `
+		FROM webhook_events
+		WHERE status = 'processing' AND started_at < $1
+		ORDER BY started_at ASC`
+
+	return r.queryEvents(ctx, "WebhookEventRepository.GetProcessingEvents", query, cutoff)
+}
+
+// CountByStatus returns counts of events by status.
+func (r *WebhookEventRepository) CountByStatus(ctx context.Context) (map[domain.WebhookEventStatus]int, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, `SELECT status, COUNT(*) FROM webhook_events GROUP BY status`)
+	if err != nil {
+		return nil, apperrors.DatabaseError("WebhookEventRepository.CountByStatus", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.WebhookEventStatus]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, apperrors.DatabaseError("WebhookEventRepository.CountByStatus", err)
+		}
+		counts[domain.WebhookEventStatus(status)] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError("WebhookEventRepository.CountByStatus", err)
+	}
+
+	return counts, nil
+}
+
+// queryEvents runs a query returning multiple webhook events.
+func (r *WebhookEventRepository) queryEvents(ctx context.Context, op, query string, args ...interface{}) ([]*domain.WebhookEvent, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.WebhookEvent
+	for rows.Next() {
+		event, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, apperrors.DatabaseError(op, err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+
+	return events, nil
+}
+
+// scanWebhookEvent scans a webhook event row.
+func scanWebhookEvent(row pgx.Row) (*domain.WebhookEvent, error) {
+	event := &domain.WebhookEvent{}
+	var eventStatus *string
+	var payload, normalized []byte
+
+	err := row.Scan(
+		&event.ID,
+		&event.Provider,
+		&event.ProviderCallID,
+		&eventStatus,
+		&payload,
+		&normalized,
+		&event.Status,
+		&event.Attempts,
+		&event.MaxAttempts,
+		&event.CallID,
+		&event.CreatedAt,
+		&event.UpdatedAt,
+		&event.ScheduledAt,
+		&event.StartedAt,
+		&event.CompletedAt,
+		&event.LastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventStatus != nil {
+		event.EventStatus = *eventStatus
+	}
+	event.Payload = payload
+	event.Event = normalized
+
+	return event, nil
+}
+
+// nullableJSON returns nil for empty JSON so it is stored as NULL.
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
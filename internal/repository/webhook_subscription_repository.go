@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+const webhookSubscriptionColumns = `id, name, url, secret, events, active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `
+	id, subscription_id, event_id, event_type, payload, status, attempts,
+	response_status, last_error, next_attempt_at, delivered_at, created_at, updated_at`
+
+// WebhookSubscriptionRepository implements domain.WebhookSubscriptionRepository using PostgreSQL.
+type WebhookSubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookSubscriptionRepository creates a new WebhookSubscriptionRepository.
+func NewWebhookSubscriptionRepository(pool *pgxpool.Pool) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{pool: pool}
+}
+
+// Create inserts a new subscription.
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, s *domain.WebhookSubscription) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.pool.Exec(ctx, query,
+		s.ID,
+		s.Name,
+		s.URL,
+		s.Secret,
+		webhookEventStrings(s.Events),
+		s.Active,
+		s.CreatedBy,
+		s.CreatedAt,
+		s.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookSubscriptionRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a subscription by ID.
+func (r *WebhookSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+	return scanWebhookSubscription(r.pool.QueryRow(ctx, query, id))
+}
+
+// Update updates a subscription, including its secret.
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, s *domain.WebhookSubscription) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET name = $2, url = $3, secret = $4, events = $5, active = $6, updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		s.ID,
+		s.Name,
+		s.URL,
+		s.Secret,
+		webhookEventStrings(s.Events),
+		s.Active,
+		s.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookSubscriptionRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("webhook subscription")
+	}
+	return nil
+}
+
+// Delete removes a subscription; its deliveries cascade.
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookSubscriptionRepository.Delete", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("webhook subscription")
+	}
+	return nil
+}
+
+// List retrieves all subscriptions, oldest first.
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY created_at, id`
+	return r.list(ctx, "WebhookSubscriptionRepository.List", query)
+}
+
+// ListForEvent retrieves the active subscriptions that receive an event.
+func (r *WebhookSubscriptionRepository) ListForEvent(ctx context.Context, event domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE active AND $1 = ANY(events)
+		ORDER BY created_at, id`
+	return r.list(ctx, "WebhookSubscriptionRepository.ListForEvent", query, string(event))
+}
+
+func (r *WebhookSubscriptionRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.WebhookSubscription
+	for rows.Next() {
+		s, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return subscriptions, nil
+}
+
+func scanWebhookSubscription(row pgx.Row) (*domain.WebhookSubscription, error) {
+	s := &domain.WebhookSubscription{}
+	var events []string
+	err := row.Scan(
+		&s.ID,
+		&s.Name,
+		&s.URL,
+		&s.Secret,
+		&events,
+		&s.Active,
+		&s.CreatedBy,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("webhook subscription")
+		}
+		return nil, apperrors.DatabaseError("WebhookSubscriptionRepository.scan", err)
+	}
+	for _, e := range events {
+		s.Events = append(s.Events, domain.WebhookEventType(e))
+	}
+	return s, nil
+}
+
+func webhookEventStrings(events []domain.WebhookEventType) []string {
+	result := make([]string, len(events))
+	for i, e := range events {
+		result[i] = string(e)
+	}
+	return result
+}
+
+// WebhookDeliveryRepository implements domain.WebhookDeliveryRepository using PostgreSQL.
+type WebhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookDeliveryRepository creates a new WebhookDeliveryRepository.
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{pool: pool}
+}
+
+// Create queues a delivery.
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+		)`
+
+	_, err := r.pool.Exec(ctx, query,
+		d.ID,
+		d.SubscriptionID,
+		d.EventID,
+		d.EventType,
+		[]byte(d.Payload),
+		d.Status,
+		d.Attempts,
+		d.ResponseStatus,
+		d.LastError,
+		d.NextAttemptAt,
+		d.DeliveredAt,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookDeliveryRepository.Create", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a delivery by ID.
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	return scanWebhookDelivery(r.pool.QueryRow(ctx, query, id))
+}
+
+// Update records the outcome of a delivery attempt.
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		UPDATE webhook_deliveries SET
+			status = $2,
+			attempts = $3,
+			response_status = $4,
+			last_error = $5,
+			next_attempt_at = $6,
+			delivered_at = $7,
+			updated_at = $8
+		WHERE id = $1`
+
+	result, err := r.pool.Exec(ctx, query,
+		d.ID,
+		d.Status,
+		d.Attempts,
+		d.ResponseStatus,
+		d.LastError,
+		d.NextAttemptAt,
+		d.DeliveredAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WebhookDeliveryRepository.Update", err)
+	}
+	if result.RowsAffected() == 0 {
+		return apperrors.NotFound("webhook delivery")
+	}
+	return nil
+}
+
+// ListDue retrieves pending deliveries whose next attempt is due, oldest first.
+func (r *WebhookDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC
+		LIMIT $2`
+	return r.list(ctx, "WebhookDeliveryRepository.ListDue", query, now, limit)
+}
+
+// ListBySubscription retrieves a subscription's deliveries, newest first.
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	ctx, cancel := WithListQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+	return r.list(ctx, "WebhookDeliveryRepository.ListBySubscription", query, subscriptionID, limit, offset)
+}
+
+func (r *WebhookDeliveryRepository) list(ctx context.Context, op, query string, args ...interface{}) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperrors.DatabaseError(op, err)
+	}
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	d := &domain.WebhookDelivery{}
+	var payload []byte
+	err := row.Scan(
+		&d.ID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&payload,
+		&d.Status,
+		&d.Attempts,
+		&d.ResponseStatus,
+		&d.LastError,
+		&d.NextAttemptAt,
+		&d.DeliveredAt,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("webhook delivery")
+		}
+		return nil, apperrors.DatabaseError("WebhookDeliveryRepository.scan", err)
+	}
+	d.Payload = payload
+	return d, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// WhatsAppOptInRepository implements domain.WhatsAppOptInRepository using
+// PostgreSQL.
+type WhatsAppOptInRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWhatsAppOptInRepository creates a new WhatsAppOptInRepository.
+func NewWhatsAppOptInRepository(pool *pgxpool.Pool) *WhatsAppOptInRepository {
+	return &WhatsAppOptInRepository{pool: pool}
+}
+
+// Get retrieves the opt-in for a number.
+func (r *WhatsAppOptInRepository) Get(ctx context.Context, phoneNumber string) (*domain.WhatsAppOptIn, error) {
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT phone_number, source, opted_in_at, opted_out_at, updated_at
+		FROM whatsapp_opt_ins
+		WHERE phone_number = $1`
+
+	optIn := &domain.WhatsAppOptIn{}
+	err := r.pool.QueryRow(ctx, query, phoneNumber).Scan(
+		&optIn.PhoneNumber,
+		&optIn.Source,
+		&optIn.OptedInAt,
+		&optIn.OptedOutAt,
+		&optIn.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.NotFound("whatsapp opt-in")
+		}
+		return nil, apperrors.DatabaseError("WhatsAppOptInRepository.Get", err)
+	}
+	return optIn, nil
+}
+
+// Save creates or replaces the opt-in for its phone number.
+func (r *WhatsAppOptInRepository) Save(ctx context.Context, optIn *domain.WhatsAppOptIn) error {
+	ctx, cancel := WithWriteTimeout(ctx)
+	defer cancel()
+
+	query := `
+		INSERT INTO whatsapp_opt_ins (phone_number, source, opted_in_at, opted_out_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (phone_number) DO UPDATE SET
+			source = EXCLUDED.source,
+			opted_in_at = EXCLUDED.opted_in_at,
+			opted_out_at = EXCLUDED.opted_out_at,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.pool.Exec(ctx, query,
+		optIn.PhoneNumber,
+		optIn.Source,
+		optIn.OptedInAt,
+		optIn.OptedOutAt,
+		optIn.UpdatedAt,
+	)
+	if err != nil {
+		return apperrors.DatabaseError("WhatsAppOptInRepository.Save", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package reputation looks up how carriers and call-blocking apps label our
+// caller IDs, through a reputation API.
+package reputation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Result is a reputation service's view of a number.
+type Result struct {
+	Score      int    `json:"score"`       // 0 to 100, higher is cleaner
+	SpamLikely bool   `json:"spam_likely"` // Shown to called parties as spam or scam
+	Label      string `json:"label"`       // e.g. "Spam Likely", "Telemarketer"
+}
+
+// Client checks numbers with a reputation API. The API is called as
+// GET {url}?phone_number=+15551234567 with the key as a bearer token, and
+// answers with a Result as JSON. Adapters for commercial services can be
+// put behind any URL that speaks this.
+type Client struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// New creates a reputation client. A client with a 30 second timeout is
+// used when client is nil.
+func New(apiURL, apiKey string, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{
+		url:    strings.TrimRight(apiURL, "/"),
+		apiKey: apiKey,
+		client: client,
+	}
+}
+
+// Check looks up a number in E.164 form.
+func (c *Client) Check(ctx context.Context, phoneNumber string) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"?phone_number="+url.QueryEscape(phoneNumber), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reputation request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("reputation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reputation service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result Result
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse reputation response: %w", err)
+	}
+	if result.Score < 0 || result.Score > 100 {
+		return nil, fmt.Errorf("reputation score %d is out of range", result.Score)
+	}
+	return &result, nil
+}
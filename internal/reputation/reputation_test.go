@@ -0,0 +1,55 @@
+package reputation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Check(t *testing.T) {
+	var gotNumber, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotNumber = r.URL.Query().Get("phone_number")
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"score": 22, "spam_likely": true, "label": "Spam Likely"}`))
+	}))
+	defer srv.Close()
+
+	result, err := New(srv.URL, "rep-key", nil).Check(context.Background(), "+15551234567")
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if gotNumber != "+15551234567" || gotAuth != "Bearer rep-key" {
+		t.Errorf("request had phone_number %q and Authorization %q", gotNumber, gotAuth)
+	}
+	if result.Score != 22 || !result.SpamLikely || result.Label != "Spam Likely" {
+		t.Errorf("Check() = %+v", result)
+	}
+}
+
+func TestClient_CheckErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"server error", http.StatusBadGateway, "upstream down"},
+		{"not json", http.StatusOK, "<html>"},
+		{"score out of range", http.StatusOK, `{"score": 250}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			if _, err := New(srv.URL, "", nil).Check(context.Background(), "+15551234567"); err == nil {
+				t.Error("Check() succeeded, want an error")
+			}
+		})
+	}
+}
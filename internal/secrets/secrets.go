@@ -0,0 +1,134 @@
+// Package secrets encrypts credentials kept in the database with AES-256-GCM
+// under a master key that only the server holds. Each ciphertext records
+// which master key sealed it, so the master key can be replaced while rows
+// sealed under the old one are still read.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the length of a master key: 32 bytes, for AES-256.
+const KeySize = 32
+
+var (
+	// ErrUnknownKey is returned when a ciphertext was sealed under a master
+	// key the keyring doesn't hold.
+	ErrUnknownKey = errors.New("secrets: sealed under an unknown master key")
+
+	// ErrDecrypt is returned when a ciphertext fails authentication: it was
+	// altered, or sealed for a different purpose.
+	ErrDecrypt = errors.New("secrets: ciphertext could not be decrypted")
+)
+
+// ParseKey decodes a base64-encoded master key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("secrets: master key is not valid base64: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("secrets: master key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// GenerateKey returns a new random base64-encoded master key.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// masterKey is one master key and its identifier.
+type masterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// Keyring seals secrets under the current master key and opens them under
+// the current or any previous one.
+type Keyring struct {
+	current *masterKey
+	keys    map[string]*masterKey
+}
+
+// NewKeyring creates a Keyring that seals under current and can still open
+// secrets sealed under any of previous.
+func NewKeyring(current []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]*masterKey, 1+len(previous))}
+	for i, raw := range append([][]byte{current}, previous...) {
+		mk, err := newMasterKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			k.current = mk
+		}
+		if _, ok := k.keys[mk.id]; !ok {
+			k.keys[mk.id] = mk
+		}
+	}
+	return k, nil
+}
+
+func newMasterKey(raw []byte) (*masterKey, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("secrets: master key must be %d bytes, got %d", KeySize, len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// The ID is a fingerprint of the key, never the key itself
+	sum := sha256.Sum256(raw)
+	return &masterKey{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+// KeyID returns the identifier of the current master key.
+func (k *Keyring) KeyID() string {
+	return k.current.id
+}
+
+// Seal encrypts plaintext under the current master key, returning the key's
+// identifier and the nonce-prefixed ciphertext. additionalData, such as the
+// name the secret is stored under, must be given again to open it, so a
+// ciphertext can't be moved to another row.
+func (k *Keyring) Seal(plaintext, additionalData []byte) (string, []byte, error) {
+	nonce := make([]byte, k.current.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return k.current.id, k.current.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts a ciphertext sealed under the master key keyID.
+func (k *Keyring) Open(keyID string, ciphertext, additionalData []byte) ([]byte, error) {
+	mk, ok := k.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	size := mk.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := mk.aead.Open(nil, ciphertext[:size], ciphertext[size:], additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	encoded, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	key, err := ParseKey(encoded)
+	if err != nil {
+		t.Fatalf("ParseKey() error = %v", err)
+	}
+	return key
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	keyring, err := NewKeyring(testKey(t))
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+
+	keyID, sealed, err := keyring.Seal([]byte("sk-test-123"), []byte("anthropic"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if keyID != keyring.KeyID() {
+		t.Errorf("Seal() key ID = %q, want %q", keyID, keyring.KeyID())
+	}
+	if bytes.Contains(sealed, []byte("sk-test-123")) {
+		t.Error("ciphertext contains the plaintext")
+	}
+
+	opened, err := keyring.Open(keyID, sealed, []byte("anthropic"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if string(opened) != "sk-test-123" {
+		t.Errorf("Open() = %q, want the sealed secret", opened)
+	}
+
+	// A ciphertext only opens for the row it was sealed for
+	if _, err := keyring.Open(keyID, sealed, []byte("bland")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("Open() with other additional data error = %v, want ErrDecrypt", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := keyring.Open(keyID, sealed, []byte("anthropic")); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("Open() of an altered ciphertext error = %v, want ErrDecrypt", err)
+	}
+}
+
+func TestKeyring_PreviousKeys(t *testing.T) {
+	oldKey, newKey := testKey(t), testKey(t)
+	old, err := NewKeyring(oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	keyID, sealed, err := old.Seal([]byte("secret"), nil)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	rotated, err := NewKeyring(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	if rotated.KeyID() == keyID {
+		t.Error("rotated keyring seals under the previous key")
+	}
+	if opened, err := rotated.Open(keyID, sealed, nil); err != nil || string(opened) != "secret" {
+		t.Errorf("Open() under a previous key = %q, %v", opened, err)
+	}
+
+	fresh, err := NewKeyring(newKey)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	if _, err := fresh.Open(keyID, sealed, nil); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Open() without the old key error = %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	for _, encoded := range []string{"", "not base64!", "c2hvcnQ="} {
+		if _, err := ParseKey(encoded); err == nil {
+			t.Errorf("ParseKey(%q) error = nil", encoded)
+		}
+	}
+}
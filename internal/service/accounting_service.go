@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/accounting"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// accountingSyncTimeout bounds an automatic push after a quote is accepted.
+const accountingSyncTimeout = 2 * time.Minute
+
+// AccountingConfig holds accounting sync settings.
+type AccountingConfig struct {
+	// Document is what quotes are pushed as.
+	Document accounting.DocumentKind
+	// AutoSync pushes quotes as soon as they are accepted.
+	AutoSync bool
+	// RedirectURL is the OAuth callback URL registered with the provider.
+	RedirectURL string
+}
+
+// AccountingService pushes accepted quotes to QuickBooks Online or Xero and
+// keeps a log of each attempt.
+type AccountingService struct {
+	repo      domain.AccountingRepository
+	quotes    *QuoteService
+	calls     domain.CallRepository
+	connector accounting.Connector
+	config    AccountingConfig
+	logger    *zap.Logger
+
+	// tokenMu serializes token refreshes, since providers may rotate the
+	// refresh token on each use.
+	tokenMu sync.Mutex
+
+	// wg tracks automatic syncs in flight.
+	wg sync.WaitGroup
+
+	clock clock.Clock
+}
+
+// NewAccountingService creates a new AccountingService.
+func NewAccountingService(repo domain.AccountingRepository, quotes *QuoteService, calls domain.CallRepository, connector accounting.Connector, config AccountingConfig, logger *zap.Logger, clk clock.Clock) *AccountingService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config.Document == "" {
+		config.Document = accounting.DocumentInvoice
+	}
+	return &AccountingService{
+		repo:      repo,
+		quotes:    quotes,
+		calls:     calls,
+		connector: connector,
+		config:    config,
+		logger:    logger,
+		clock:     clk,
+	}
+}
+
+// Provider returns the connected provider's name.
+func (s *AccountingService) Provider() string {
+	return s.connector.Name()
+}
+
+// Document returns what quotes are pushed as.
+func (s *AccountingService) Document() accounting.DocumentKind {
+	return s.config.Document
+}
+
+// AuthURL returns the provider page where an admin grants access. state is
+// returned to the callback and must be checked there.
+func (s *AccountingService) AuthURL(state string) string {
+	return s.connector.AuthURL(state, s.config.RedirectURL)
+}
+
+// Connect completes the OAuth flow with the callback's query parameters and
+// stores the grant, replacing any earlier connection.
+func (s *AccountingService) Connect(ctx context.Context, params url.Values, user *domain.User) (*domain.AccountingConnection, error) {
+	if e := params.Get("error"); e != "" {
+		return nil, apperrors.ValidationFailed("authorization was not granted: " + e)
+	}
+
+	token, err := s.connector.Exchange(ctx, params, s.config.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect %s: %w", s.Provider(), err)
+	}
+
+	now := s.clock.Now().UTC()
+	conn := &domain.AccountingConnection{
+		Provider:     s.Provider(),
+		TenantID:     token.TenantID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		ExpiresAt:    token.ExpiresAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if user != nil {
+		id := user.ID
+		conn.ConnectedBy = &id
+	}
+	if err := s.repo.SaveConnection(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save accounting connection: %w", err)
+	}
+
+	s.logger.Info("accounting connected",
+		zap.String("provider", conn.Provider),
+		zap.String("tenant_id", conn.TenantID),
+	)
+	return conn, nil
+}
+
+// Disconnect forgets the stored grant. Access should also be revoked in the
+// provider's app settings.
+func (s *AccountingService) Disconnect(ctx context.Context) error {
+	if err := s.repo.DeleteConnection(ctx, s.Provider()); err != nil {
+		return fmt.Errorf("failed to delete accounting connection: %w", err)
+	}
+	s.logger.Info("accounting disconnected", zap.String("provider", s.Provider()))
+	return nil
+}
+
+// Connection returns the stored grant, or nil when not connected.
+func (s *AccountingService) Connection(ctx context.Context) (*domain.AccountingConnection, error) {
+	conn, err := s.repo.GetConnection(ctx, s.Provider())
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Mapping returns the item and tax code mapping, empty if none is saved.
+func (s *AccountingService) Mapping(ctx context.Context) (*domain.AccountingMapping, error) {
+	mapping, err := s.repo.GetMapping(ctx, s.Provider())
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return &domain.AccountingMapping{Provider: s.Provider(), ProjectTypeItems: map[string]string{}}, nil
+		}
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// SaveMapping validates and stores the item and tax code mapping.
+func (s *AccountingService) SaveMapping(ctx context.Context, mapping *domain.AccountingMapping) error {
+	if err := mapping.Validate(); err != nil {
+		return apperrors.ValidationFailed(err.Error())
+	}
+	mapping.Provider = s.Provider()
+	mapping.UpdatedAt = s.clock.Now().UTC()
+	if err := s.repo.SaveMapping(ctx, mapping); err != nil {
+		return fmt.Errorf("failed to save accounting mapping: %w", err)
+	}
+	return nil
+}
+
+// ListSyncs lists a quote's sync attempts, newest first.
+func (s *AccountingService) ListSyncs(ctx context.Context, callID uuid.UUID) ([]*domain.AccountingSync, error) {
+	return s.repo.ListSyncsByCallID(ctx, callID)
+}
+
+// RecentSyncs lists the latest sync attempts across quotes, newest first.
+func (s *AccountingService) RecentSyncs(ctx context.Context, limit int) ([]*domain.AccountingSync, error) {
+	return s.repo.ListRecentSyncs(ctx, limit)
+}
+
+// SyncQuote pushes an accepted quote to the accounting system and records
+// the attempt. A push the provider rejects is recorded and returned as a
+// failed sync rather than an error; errors mean nothing was attempted. user
+// is nil for automatic syncs.
+func (s *AccountingService) SyncQuote(ctx context.Context, callID uuid.UUID, user *domain.User) (*domain.AccountingSync, error) {
+	detail, err := s.quotes.GetQuote(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Status != domain.QuoteStatusAccepted {
+		return nil, apperrors.New(apperrors.CodeConflict,
+			fmt.Sprintf("quote is %s; only accepted quotes can be pushed to accounting", detail.Status))
+	}
+	syncs, err := s.repo.ListSyncsByCallID(ctx, callID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounting syncs: %w", err)
+	}
+	for _, prev := range syncs {
+		if prev.Succeeded() {
+			return nil, apperrors.New(apperrors.CodeConflict,
+				fmt.Sprintf("quote was already pushed as %s %s", prev.Document, prev.ExternalNumber))
+		}
+	}
+
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := s.Mapping(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounting mapping: %w", err)
+	}
+	doc, err := s.document(detail, call, mapping)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := &domain.AccountingSync{
+		ID:        uuid.New(),
+		CallID:    callID,
+		Provider:  s.Provider(),
+		Document:  string(doc.Kind),
+		CreatedAt: s.clock.Now().UTC(),
+	}
+	if user != nil {
+		id := user.ID
+		attempt.ActorID = &id
+	}
+
+	result, pushErr := s.connector.Push(ctx, token, doc)
+	if pushErr != nil {
+		attempt.Status = domain.AccountingSyncFailed
+		attempt.Error = pushErr.Error()
+		s.logger.Warn("failed to push quote to accounting",
+			zap.String("call_id", callID.String()),
+			zap.String("provider", attempt.Provider),
+			zap.Error(pushErr),
+		)
+	} else {
+		attempt.Status = domain.AccountingSyncSucceeded
+		attempt.ExternalID = result.ID
+		attempt.ExternalNumber = result.Number
+		s.logger.Info("quote pushed to accounting",
+			zap.String("call_id", callID.String()),
+			zap.String("provider", attempt.Provider),
+			zap.String("document", attempt.Document),
+			zap.String("external_id", result.ID),
+		)
+	}
+
+	if err := s.repo.CreateSync(ctx, attempt); err != nil {
+		return nil, fmt.Errorf("failed to record accounting sync: %w", err)
+	}
+	return attempt, nil
+}
+
+// QuoteAccepted pushes a newly accepted quote in the background when
+// automatic sync is on and an accounting system is connected. Failures are
+// logged in the sync log for staff to retry.
+func (s *AccountingService) QuoteAccepted(ctx context.Context, callID uuid.UUID) {
+	if !s.config.AutoSync {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), accountingSyncTimeout)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		conn, err := s.Connection(ctx)
+		if err != nil || conn == nil {
+			if err != nil {
+				s.logger.Warn("failed to check accounting connection", zap.Error(err))
+			}
+			return
+		}
+		if _, err := s.SyncQuote(ctx, callID, nil); err != nil {
+			s.logger.Warn("automatic accounting sync failed", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+	}()
+}
+
+// Stop waits for automatic syncs in flight to finish.
+func (s *AccountingService) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("accounting sync stop timed out")
+		return ctx.Err()
+	}
+}
+
+// token returns a current access token, refreshing and storing it when it
+// is about to expire.
+func (s *AccountingService) token(ctx context.Context) (*accounting.Token, error) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	conn, err := s.Connection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounting connection: %w", err)
+	}
+	if conn == nil {
+		return nil, apperrors.New(apperrors.CodeConflict, s.Provider()+" is not connected")
+	}
+
+	token := &accounting.Token{
+		AccessToken:  conn.AccessToken,
+		RefreshToken: conn.RefreshToken,
+		ExpiresAt:    conn.ExpiresAt,
+		TenantID:     conn.TenantID,
+	}
+	if !token.Expired(s.clock.Now()) {
+		return token, nil
+	}
+
+	refreshed, err := s.connector.Refresh(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh %s access: %w", s.Provider(), err)
+	}
+	conn.AccessToken = refreshed.AccessToken
+	if refreshed.RefreshToken != "" {
+		conn.RefreshToken = refreshed.RefreshToken
+	}
+	conn.ExpiresAt = refreshed.ExpiresAt
+	conn.UpdatedAt = s.clock.Now().UTC()
+	if err := s.repo.SaveConnection(ctx, conn); err != nil {
+		return nil, fmt.Errorf("failed to save refreshed accounting token: %w", err)
+	}
+	refreshed.RefreshToken = conn.RefreshToken
+	return refreshed, nil
+}
+
+// document builds the estimate or invoice for a quote. Lines use the item
+// mapped to the call's project type; quotes read from generated text with
+// no priced lines are pushed as a single line for the total.
+func (s *AccountingService) document(detail *QuoteDetail, call *domain.Call, mapping *domain.AccountingMapping) (*accounting.Document, error) {
+	doc := &accounting.Document{
+		Kind:          s.config.Document,
+		Number:        detail.QuoteNumber,
+		Date:          s.clock.Now().UTC(),
+		CustomerPhone: call.CustomerNumber(),
+		TaxCode:       mapping.TaxCodeFor(detail.TaxRate),
+		Currency:      detail.Currency,
+		Notes:         detail.Notes,
+	}
+
+	var projectType string
+	if call.CallerName != nil {
+		doc.CustomerName = strings.TrimSpace(*call.CallerName)
+	}
+	if data := call.ExtractedData; data != nil {
+		if doc.CustomerName == "" {
+			doc.CustomerName = strings.TrimSpace(data.CallerName)
+		}
+		if doc.CustomerName == "" {
+			doc.CustomerName = strings.TrimSpace(data.Company)
+		}
+		if data.Phone != "" {
+			doc.CustomerPhone = data.Phone
+		}
+		doc.CustomerEmail = data.Email
+		projectType = data.ProjectType
+	}
+	if doc.CustomerName == "" {
+		doc.CustomerName = doc.CustomerPhone
+	}
+
+	item := mapping.ItemFor(projectType)
+	for _, li := range detail.LineItems {
+		if strings.TrimSpace(li.Description) == "" {
+			continue
+		}
+		doc.Lines = append(doc.Lines, accounting.Line{
+			Description: li.Description,
+			Quantity:    li.Quantity,
+			UnitPrice:   li.UnitPrice,
+			ItemCode:    item,
+		})
+	}
+	if len(doc.Lines) == 0 && detail.Total > 0 {
+		doc.Lines = []accounting.Line{{
+			Description: "Quote " + detail.QuoteNumber,
+			Quantity:    1,
+			UnitPrice:   detail.Total,
+			ItemCode:    item,
+		}}
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed("quote can't be pushed to accounting: " + err.Error())
+	}
+	return doc, nil
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/accounting"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// mockAccountingRepository is an in-memory AccountingRepository.
+type mockAccountingRepository struct {
+	mu          sync.Mutex
+	connections map[string]*domain.AccountingConnection
+	mappings    map[string]*domain.AccountingMapping
+	syncs       []*domain.AccountingSync
+}
+
+func newMockAccountingRepository() *mockAccountingRepository {
+	return &mockAccountingRepository{
+		connections: make(map[string]*domain.AccountingConnection),
+		mappings:    make(map[string]*domain.AccountingMapping),
+	}
+}
+
+func (m *mockAccountingRepository) GetConnection(ctx context.Context, provider string) (*domain.AccountingConnection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.connections[provider]; ok {
+		cp := *c
+		return &cp, nil
+	}
+	return nil, apperrors.NotFound("accounting connection")
+}
+
+func (m *mockAccountingRepository) SaveConnection(ctx context.Context, conn *domain.AccountingConnection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *conn
+	m.connections[conn.Provider] = &cp
+	return nil
+}
+
+func (m *mockAccountingRepository) DeleteConnection(ctx context.Context, provider string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.connections, provider)
+	return nil
+}
+
+func (m *mockAccountingRepository) GetMapping(ctx context.Context, provider string) (*domain.AccountingMapping, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mapping, ok := m.mappings[provider]; ok {
+		cp := *mapping
+		return &cp, nil
+	}
+	return nil, apperrors.NotFound("accounting mapping")
+}
+
+func (m *mockAccountingRepository) SaveMapping(ctx context.Context, mapping *domain.AccountingMapping) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *mapping
+	m.mappings[mapping.Provider] = &cp
+	return nil
+}
+
+func (m *mockAccountingRepository) CreateSync(ctx context.Context, sync *domain.AccountingSync) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *sync
+	m.syncs = append([]*domain.AccountingSync{&cp}, m.syncs...)
+	return nil
+}
+
+func (m *mockAccountingRepository) ListSyncsByCallID(ctx context.Context, callID uuid.UUID) ([]*domain.AccountingSync, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*domain.AccountingSync
+	for _, s := range m.syncs {
+		if s.CallID == callID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockAccountingRepository) ListRecentSyncs(ctx context.Context, limit int) ([]*domain.AccountingSync, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.syncs) > limit {
+		return m.syncs[:limit], nil
+	}
+	return m.syncs, nil
+}
+
+// fakeAccountingConnector records pushed documents.
+type fakeAccountingConnector struct {
+	mu        sync.Mutex
+	docs      []*accounting.Document
+	tokens    []*accounting.Token
+	pushErr   error
+	refreshed int
+}
+
+func (f *fakeAccountingConnector) AuthURL(state, redirectURL string) string {
+	return "https://auth.example.com/?state=" + state
+}
+
+func (f *fakeAccountingConnector) Exchange(ctx context.Context, params url.Values, redirectURL string) (*accounting.Token, error) {
+	return &accounting.Token{AccessToken: "at-" + params.Get("code"), RefreshToken: "rt-1", ExpiresAt: time.Now().Add(time.Hour), TenantID: "realm-1"}, nil
+}
+
+func (f *fakeAccountingConnector) Refresh(ctx context.Context, token *accounting.Token) (*accounting.Token, error) {
+	f.refreshed++
+	return &accounting.Token{AccessToken: "at-refreshed", RefreshToken: "rt-2", ExpiresAt: time.Now().Add(time.Hour), TenantID: token.TenantID}, nil
+}
+
+func (f *fakeAccountingConnector) Push(ctx context.Context, token *accounting.Token, doc *accounting.Document) (*accounting.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.docs = append(f.docs, doc)
+	f.tokens = append(f.tokens, token)
+	if f.pushErr != nil {
+		return nil, f.pushErr
+	}
+	return &accounting.Result{ID: "901", Number: "1042"}, nil
+}
+
+func (f *fakeAccountingConnector) Name() string {
+	return accounting.ProviderQuickBooks
+}
+
+// newAccountingTestService returns an accounting service connected to a
+// fake QuickBooks, over a $1,200 roofing quote. The quote is accepted when
+// accept is set.
+func newAccountingTestService(t *testing.T, accept bool) (*AccountingService, *QuoteService, *mockAccountingRepository, *fakeAccountingConnector, uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	quotes, _, calls, callID := newQuoteTestService(t, "- Build: $1,200", QuoteApprovalConfig{})
+	call, _ := calls.GetByID(ctx, callID)
+	call.ExtractedData = &domain.ExtractedData{CallerName: "Jane Doe", Email: "jane@example.com", ProjectType: "Roofing"}
+
+	repo := newMockAccountingRepository()
+	connector := &fakeAccountingConnector{}
+	svc := NewAccountingService(repo, quotes, calls, connector, AccountingConfig{}, zap.NewNop(), nil)
+	if _, err := svc.Connect(ctx, url.Values{"code": {"1"}}, nil); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	if accept {
+		staff := quoteActor("staff@example.com")
+		for _, step := range []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){
+			quotes.Submit, quotes.Approve, quotes.MarkSent, quotes.MarkAccepted,
+		} {
+			if _, err := step(ctx, callID, staff, ""); err != nil {
+				t.Fatalf("prepare quote: %v", err)
+			}
+		}
+	}
+	return svc, quotes, repo, connector, callID
+}
+
+func TestAccountingService_SyncQuote(t *testing.T) {
+	svc, _, repo, connector, callID := newAccountingTestService(t, true)
+	ctx := context.Background()
+	if err := svc.SaveMapping(ctx, &domain.AccountingMapping{
+		DefaultItem:      "1",
+		ProjectTypeItems: map[string]string{" ROOFING ": "7"},
+		TaxCode:          "TAX",
+		ExemptTaxCode:    "NON",
+	}); err != nil {
+		t.Fatalf("save mapping: %v", err)
+	}
+
+	user := &domain.User{ID: uuid.New()}
+	sync, err := svc.SyncQuote(ctx, callID, user)
+	if err != nil {
+		t.Fatalf("sync quote: %v", err)
+	}
+	if !sync.Succeeded() || sync.ExternalNumber != "1042" || sync.Document != "invoice" || sync.ActorID == nil || *sync.ActorID != user.ID {
+		t.Errorf("unexpected sync %+v", sync)
+	}
+
+	doc := connector.docs[0]
+	if doc.CustomerName != "Jane Doe" || doc.CustomerEmail != "jane@example.com" || doc.TaxCode != "NON" {
+		t.Errorf("unexpected document %+v", doc)
+	}
+	if len(doc.Lines) != 1 || doc.Lines[0].ItemCode != "7" || doc.Lines[0].UnitPrice != 1200 {
+		t.Errorf("expected the roofing item on each line, got %+v", doc.Lines)
+	}
+
+	if _, err := svc.SyncQuote(ctx, callID, user); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict pushing a quote twice, got %v", err)
+	}
+	if len(repo.syncs) != 1 {
+		t.Errorf("expected one logged sync, got %d", len(repo.syncs))
+	}
+}
+
+func TestAccountingService_SyncQuoteFailureIsLogged(t *testing.T) {
+	svc, _, repo, connector, callID := newAccountingTestService(t, true)
+	ctx := context.Background()
+	connector.pushErr = errors.New("quickbooks invoice: status 400: Invalid Reference Id")
+
+	sync, err := svc.SyncQuote(ctx, callID, nil)
+	if err != nil {
+		t.Fatalf("sync quote: %v", err)
+	}
+	if sync.Succeeded() || sync.Error != connector.pushErr.Error() {
+		t.Errorf("expected a failed sync with the provider's error, got %+v", sync)
+	}
+
+	// A failed push may be retried.
+	connector.pushErr = nil
+	if sync, err := svc.SyncQuote(ctx, callID, nil); err != nil || !sync.Succeeded() {
+		t.Errorf("expected the retry to succeed, got %+v, %v", sync, err)
+	}
+	if syncs, _ := svc.ListSyncs(ctx, callID); len(syncs) != 2 || !syncs[0].Succeeded() {
+		t.Errorf("expected both attempts newest first, got %d", len(repo.syncs))
+	}
+}
+
+func TestAccountingService_SyncQuotePreconditions(t *testing.T) {
+	svc, _, _, connector, callID := newAccountingTestService(t, false)
+	ctx := context.Background()
+
+	if _, err := svc.SyncQuote(ctx, callID, nil); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict for a draft quote, got %v", err)
+	}
+
+	svc2, _, _, _, acceptedID := newAccountingTestService(t, true)
+	if err := svc2.Disconnect(ctx); err != nil {
+		t.Fatalf("disconnect: %v", err)
+	}
+	if _, err := svc2.SyncQuote(ctx, acceptedID, nil); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict when not connected, got %v", err)
+	}
+	if len(connector.docs) != 0 {
+		t.Errorf("expected nothing pushed, got %d", len(connector.docs))
+	}
+}
+
+func TestAccountingService_RefreshesExpiredToken(t *testing.T) {
+	svc, _, repo, connector, callID := newAccountingTestService(t, true)
+	ctx := context.Background()
+	conn := repo.connections[accounting.ProviderQuickBooks]
+	conn.ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := svc.SyncQuote(ctx, callID, nil); err != nil {
+		t.Fatalf("sync quote: %v", err)
+	}
+	if connector.refreshed != 1 || connector.tokens[0].AccessToken != "at-refreshed" {
+		t.Errorf("expected the push to use a refreshed token, got %+v", connector.tokens[0])
+	}
+	if stored := repo.connections[accounting.ProviderQuickBooks]; stored.AccessToken != "at-refreshed" || stored.RefreshToken != "rt-2" {
+		t.Errorf("expected the refreshed token to be stored, got %+v", stored)
+	}
+}
+
+func TestAccountingService_AutoSyncOnAcceptance(t *testing.T) {
+	svc, quotes, _, connector, callID := newAccountingTestService(t, false)
+	svc.config.AutoSync = true
+	quotes.SetAccounting(svc)
+	ctx := context.Background()
+
+	staff := quoteActor("staff@example.com")
+	for _, step := range []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){
+		quotes.Submit, quotes.Approve, quotes.MarkSent,
+	} {
+		if _, err := step(ctx, callID, staff, ""); err != nil {
+			t.Fatalf("prepare quote: %v", err)
+		}
+	}
+	if err := svc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if len(connector.docs) != 0 {
+		t.Fatalf("expected no push before acceptance, got %d", len(connector.docs))
+	}
+
+	if _, err := quotes.MarkAccepted(ctx, callID, staff, ""); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if err := svc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if syncs, _ := svc.ListSyncs(ctx, callID); len(syncs) != 1 || !syncs[0].Succeeded() || syncs[0].ActorID != nil {
+		t.Errorf("expected one automatic sync, got %+v", syncs)
+	}
+}
+
+func TestAccountingService_ConnectDenied(t *testing.T) {
+	svc, _, _, _, _ := newAccountingTestService(t, false)
+	_, err := svc.Connect(context.Background(), url.Values{"error": {"access_denied"}}, nil)
+	if apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
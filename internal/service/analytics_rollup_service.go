@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// analyticsRollupCheckInterval is how often the worker checks whether the
+// nightly rollup is due.
+const analyticsRollupCheckInterval = 15 * time.Minute
+
+// AnalyticsRollupService rolls up each day's call metrics by prompt and by
+// inbound number every night. Quotes are accepted, and late webhooks finish
+// calls, days after the calls were made, so every night the last few days
+// are rolled up again.
+type AnalyticsRollupService struct {
+	repo   domain.AnalyticsRollupRepository
+	logger *zap.Logger
+
+	// Configuration
+	hour         int
+	lookbackDays int
+
+	clock clock.Clock
+
+	// lastRun is the UTC day of the last nightly rollup; only the worker
+	// goroutine touches it.
+	lastRun time.Time
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// AnalyticsRollupServiceConfig holds configuration for the analytics rollup
+// worker.
+type AnalyticsRollupServiceConfig struct {
+	Hour         int // UTC hour the nightly rollup runs at
+	LookbackDays int // Days before today rolled up each night
+}
+
+// DefaultAnalyticsRollupServiceConfig returns sensible defaults.
+func DefaultAnalyticsRollupServiceConfig() *AnalyticsRollupServiceConfig {
+	return &AnalyticsRollupServiceConfig{
+		Hour:         2,
+		LookbackDays: 7,
+	}
+}
+
+// NewAnalyticsRollupService creates a new AnalyticsRollupService.
+func NewAnalyticsRollupService(repo domain.AnalyticsRollupRepository, logger *zap.Logger, config *AnalyticsRollupServiceConfig, clk clock.Clock) *AnalyticsRollupService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	defaults := DefaultAnalyticsRollupServiceConfig()
+	if config == nil {
+		config = defaults
+	}
+	hour := config.Hour
+	if hour < 0 || hour > 23 {
+		hour = defaults.Hour
+	}
+	lookbackDays := config.LookbackDays
+	if lookbackDays <= 0 {
+		lookbackDays = defaults.LookbackDays
+	}
+
+	return &AnalyticsRollupService{
+		repo:         repo,
+		logger:       logger,
+		hour:         hour,
+		lookbackDays: lookbackDays,
+		clock:        clk,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// RollUp recomputes the rollups for the lookback days before today and
+// returns how many days were rolled up. It stops at the first failure.
+func (s *AnalyticsRollupService) RollUp(ctx context.Context) (int, error) {
+	today := truncateDay(s.clock.Now())
+	rolled := 0
+	for i := s.lookbackDays; i >= 1; i-- {
+		day := today.AddDate(0, 0, -i)
+		if err := s.repo.RollUpDay(ctx, day); err != nil {
+			return rolled, fmt.Errorf("failed to roll up %s: %w", day.Format("2006-01-02"), err)
+		}
+		rolled++
+	}
+	return rolled, nil
+}
+
+// due reports whether tonight's rollup has yet to run.
+func (s *AnalyticsRollupService) due() bool {
+	now := s.clock.Now().UTC()
+	return now.Hour() >= s.hour && truncateDay(now).After(s.lastRun)
+}
+
+// Start begins rolling up analytics every night, after first rolling up
+// the lookback days at once.
+func (s *AnalyticsRollupService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("analytics rollup worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting analytics rollup worker",
+		zap.Int("hour_utc", s.hour),
+		zap.Int("lookback_days", s.lookbackDays),
+	)
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for an in-flight rollup to finish.
+func (s *AnalyticsRollupService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping analytics rollup worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("analytics rollup worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("analytics rollup worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+func (s *AnalyticsRollupService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(analyticsRollupCheckInterval)
+	defer ticker.Stop()
+
+	s.runRollup()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if s.due() {
+				s.runRollup()
+			}
+		}
+	}
+}
+
+func (s *AnalyticsRollupService) runRollup() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	s.lastRun = truncateDay(s.clock.Now())
+	rolled, err := s.RollUp(ctx)
+	if err != nil {
+		s.logger.Error("analytics rollup failed", zap.Int("days_rolled_up", rolled), zap.Error(err))
+		return
+	}
+	s.logger.Info("analytics rolled up", zap.Int("days", rolled))
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// MockAnalyticsRollupRepository records the days rolled up and returns
+// canned breakdowns.
+type MockAnalyticsRollupRepository struct {
+	rolled    []time.Time
+	failOn    time.Time
+	metrics   []*domain.DimensionMetrics
+	dimension domain.AnalyticsDimension
+	filter    *domain.AnalyticsFilter
+}
+
+func (m *MockAnalyticsRollupRepository) RollUpDay(ctx context.Context, day time.Time) error {
+	if day.Equal(m.failOn) {
+		return errors.New("database unavailable")
+	}
+	m.rolled = append(m.rolled, day)
+	return nil
+}
+
+func (m *MockAnalyticsRollupRepository) Breakdown(ctx context.Context, dimension domain.AnalyticsDimension, filter *domain.AnalyticsFilter) ([]*domain.DimensionMetrics, error) {
+	m.dimension = dimension
+	m.filter = filter
+	return m.metrics, nil
+}
+
+func TestAnalyticsRollupService_RollUp(t *testing.T) {
+	now := time.Date(2025, 6, 15, 3, 0, 0, 0, time.UTC)
+	repo := &MockAnalyticsRollupRepository{}
+	svc := NewAnalyticsRollupService(repo, zap.NewNop(), &AnalyticsRollupServiceConfig{Hour: 2, LookbackDays: 3}, clock.NewMock(now))
+
+	rolled, err := svc.RollUp(context.Background())
+	if err != nil {
+		t.Fatalf("RollUp() error = %v", err)
+	}
+	want := []time.Time{
+		time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC),
+	}
+	if rolled != len(want) || len(repo.rolled) != len(want) {
+		t.Fatalf("rolled up %v, want %v", repo.rolled, want)
+	}
+	for i := range want {
+		if !repo.rolled[i].Equal(want[i]) {
+			t.Errorf("day %d = %s, want %s", i, repo.rolled[i], want[i])
+		}
+	}
+
+	repo.rolled = nil
+	repo.failOn = want[1]
+	rolled, err = svc.RollUp(context.Background())
+	if err == nil || rolled != 1 {
+		t.Errorf("RollUp() = %d, %v; want to stop after one day with an error", rolled, err)
+	}
+}
+
+func TestAnalyticsRollupService_Due(t *testing.T) {
+	clk := clock.NewMock(time.Date(2025, 6, 15, 1, 0, 0, 0, time.UTC))
+	svc := NewAnalyticsRollupService(&MockAnalyticsRollupRepository{}, zap.NewNop(), &AnalyticsRollupServiceConfig{Hour: 2}, clk)
+	svc.lastRun = time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	if svc.due() {
+		t.Error("expected the rollup not due before its hour")
+	}
+	clk.Advance(time.Hour)
+	if !svc.due() {
+		t.Error("expected the rollup due at its hour")
+	}
+	svc.lastRun = truncateDay(clk.Now())
+	if svc.due() {
+		t.Error("expected the rollup not due again the same night")
+	}
+}
+
+func TestAnalyticsService_GetBreakdown(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	svc := newTestAnalyticsService(&MockAnalyticsRepository{}, now)
+
+	metrics, err := svc.GetBreakdown(context.Background(), domain.AnalyticsDimensionNumber, &domain.AnalyticsFilter{})
+	if err != nil || len(metrics) != 0 {
+		t.Fatalf("GetBreakdown() without rollups = %v, %v; want empty", metrics, err)
+	}
+
+	rollups := &MockAnalyticsRollupRepository{metrics: []*domain.DimensionMetrics{{
+		Key:                      "+15550001111",
+		Calls:                    10,
+		CompletedCalls:           6,
+		NoAnswerCalls:            2,
+		QuotedCalls:              4,
+		AcceptedQuotes:           1,
+		CompletedDurationSeconds: 720,
+	}}}
+	svc.SetRollups(rollups)
+
+	filter := &domain.AnalyticsFilter{
+		From: time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+		To:   time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+	}
+	metrics, err = svc.GetBreakdown(context.Background(), domain.AnalyticsDimensionNumber, filter)
+	if err != nil {
+		t.Fatalf("GetBreakdown() error = %v", err)
+	}
+	if rollups.dimension != domain.AnalyticsDimensionNumber {
+		t.Errorf("dimension = %q, want number", rollups.dimension)
+	}
+	if !filter.From.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)) || !filter.To.Equal(time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("range = %s to %s, want whole days June 1 to 9", filter.From, filter.To)
+	}
+
+	m := metrics[0]
+	if m.AnswerRate != 0.75 || m.QuoteRate != 4.0/6 || m.AcceptanceRate != 0.25 || m.AverageDurationSeconds != 120 {
+		t.Errorf("rates = %+v", m)
+	}
+}
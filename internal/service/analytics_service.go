@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// Analytics time ranges.
+const (
+	DefaultAnalyticsRange = 30 * 24 * time.Hour
+	MaxAnalyticsRange     = 366 * 24 * time.Hour
+)
+
+// ExchangeRateSource provides exchange rates for totaling quotes priced in
+// different currencies.
+type ExchangeRateSource interface {
+	Rates(ctx context.Context, base string) (*domain.ExchangeRates, error)
+}
+
+// AnalyticsService computes dashboard KPIs from aggregate call and quote queries.
+type AnalyticsService struct {
+	repo       domain.AnalyticsRepository
+	rates      ExchangeRateSource
+	currencies CurrencySource
+	rollups    domain.AnalyticsRollupRepository
+	clock      clock.Clock
+	logger     *zap.Logger
+}
+
+// NewAnalyticsService creates a new AnalyticsService.
+func NewAnalyticsService(repo domain.AnalyticsRepository, logger *zap.Logger, clk clock.Clock) *AnalyticsService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &AnalyticsService{
+		repo:   repo,
+		clock:  clk,
+		logger: logger,
+	}
+}
+
+// SetExchangeRates sets the source of exchange rates used to total quotes
+// in the currency from currencies. Without rates, quotes in other
+// currencies are left out of quote value totals; without currencies, totals
+// are in USD.
+func (s *AnalyticsService) SetExchangeRates(rates ExchangeRateSource, currencies CurrencySource) {
+	s.rates = rates
+	s.currencies = currencies
+}
+
+// SetRollups enables breakdowns by prompt and by inbound number, read from
+// the daily rollups. Without them, breakdowns are empty.
+func (s *AnalyticsService) SetRollups(rollups domain.AnalyticsRollupRepository) {
+	s.rollups = rollups
+}
+
+// CallAnalytics holds every dashboard metric for one time range.
+type CallAnalytics struct {
+	From        time.Time                   `json:"from"`
+	To          time.Time                   `json:"to"`
+	Provider    string                      `json:"provider,omitempty"`
+	KPIs        *domain.CallKPIs            `json:"kpis"`
+	CallsPerDay []*domain.DailyCallVolume   `json:"calls_per_day"`
+	Prompts     []*domain.PromptCallMetrics `json:"prompts"`
+	Tags        *domain.CallTagCounts       `json:"tags"`
+}
+
+// GetAnalytics returns the KPIs, daily call volume, per-prompt metrics and
+// tag counts for the filter's time range.
+func (s *AnalyticsService) GetAnalytics(ctx context.Context, filter *domain.AnalyticsFilter) (*CallAnalytics, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+
+	kpis, err := s.callKPIs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.callsPerDay(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := s.promptMetrics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.tagCounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CallAnalytics{
+		From:        filter.From,
+		To:          filter.To,
+		Provider:    filter.Provider,
+		KPIs:        kpis,
+		CallsPerDay: days,
+		Prompts:     prompts,
+		Tags:        tags,
+	}, nil
+}
+
+// GetKPIs returns call and quote KPIs for the filter's time range.
+func (s *AnalyticsService) GetKPIs(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallKPIs, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.callKPIs(ctx, filter)
+}
+
+// GetCallsPerDay returns call counts for every UTC day in the filter's time
+// range, including days without calls.
+func (s *AnalyticsService) GetCallsPerDay(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.DailyCallVolume, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.callsPerDay(ctx, filter)
+}
+
+// GetPromptMetrics returns call counts and average duration by prompt for
+// the filter's time range.
+func (s *AnalyticsService) GetPromptMetrics(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.PromptCallMetrics, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.promptMetrics(ctx, filter)
+}
+
+// GetTagCounts returns how many calls in the filter's time range were
+// tagged with each sentiment, intent and urgency, and given each disposition.
+func (s *AnalyticsService) GetTagCounts(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallTagCounts, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.tagCounts(ctx, filter)
+}
+
+// GetBreakdown compares answer, quote and acceptance rates and average
+// call length across prompts or inbound numbers, busiest first. Rollups
+// cover whole UTC days, so the filter's range is widened to the days it
+// touches; days not yet rolled up count no calls.
+func (s *AnalyticsService) GetBreakdown(ctx context.Context, dimension domain.AnalyticsDimension, filter *domain.AnalyticsFilter) ([]*domain.DimensionMetrics, error) {
+	if err := s.normalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter.Sentiment != "" || filter.Intent != "" {
+		return nil, apperrors.ValidationFailed("breakdowns cannot be filtered by sentiment or intent")
+	}
+	filter.From = truncateDay(filter.From)
+	filter.To = truncateDay(filter.To.Add(-time.Nanosecond)).AddDate(0, 0, 1)
+
+	metrics := []*domain.DimensionMetrics{}
+	if s.rollups == nil {
+		return metrics, nil
+	}
+	rows, err := s.rollups.Breakdown(ctx, dimension, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s breakdown: %w", dimension, err)
+	}
+	for _, m := range rows {
+		m.CalculateRates()
+		metrics = append(metrics, m)
+	}
+	return metrics, nil
+}
+
+func (s *AnalyticsService) callKPIs(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallKPIs, error) {
+	kpis, err := s.repo.CallKPIs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get call KPIs: %w", err)
+	}
+	kpis.CalculateRates()
+	if err := s.addQuoteValues(ctx, filter, kpis); err != nil {
+		return nil, err
+	}
+	return kpis, nil
+}
+
+// addQuoteValues totals priced quotes in the reporting currency, converting
+// those priced in other currencies at the latest exchange rates.
+func (s *AnalyticsService) addQuoteValues(ctx context.Context, filter *domain.AnalyticsFilter, kpis *domain.CallKPIs) error {
+	values, err := s.repo.QuoteValues(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get quote values: %w", err)
+	}
+	if values == nil {
+		values = []*domain.CurrencyTotal{}
+	}
+	currency := defaultCurrency(ctx, s.currencies)
+	kpis.QuoteValues = values
+	kpis.QuoteValueCurrency = currency
+
+	var rates *domain.ExchangeRates
+	for _, v := range values {
+		if v.Currency != currency && s.rates != nil {
+			rates, err = s.rates.Rates(ctx, currency)
+			if err != nil {
+				s.logger.Warn("failed to get exchange rates; quotes in other currencies left out of totals",
+					zap.String("currency", currency), zap.Error(err))
+			}
+			break
+		}
+	}
+
+	var total float64
+	for _, v := range values {
+		amount, ok := v.Total, v.Currency == currency
+		if !ok && rates != nil {
+			amount, ok = rates.ToBase(v.Total, v.Currency)
+		}
+		if !ok {
+			kpis.UnconvertedQuotes += v.Count
+			continue
+		}
+		kpis.PricedQuotes += v.Count
+		total += amount
+	}
+	kpis.TotalQuoteValue = math.Round(total*100) / 100
+	if kpis.PricedQuotes > 0 {
+		kpis.AverageQuoteValue = math.Round(total/float64(kpis.PricedQuotes)*100) / 100
+	}
+	return nil
+}
+
+func (s *AnalyticsService) callsPerDay(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.DailyCallVolume, error) {
+	counted, err := s.repo.CallsPerDay(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calls per day: %w", err)
+	}
+
+	byDay := make(map[time.Time]*domain.DailyCallVolume, len(counted))
+	for _, d := range counted {
+		byDay[d.Date] = d
+	}
+
+	// Charts need a point for every day, so days without calls are zero
+	var days []*domain.DailyCallVolume
+	last := filter.To.Add(-time.Nanosecond)
+	for day := truncateDay(filter.From); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if d, ok := byDay[day]; ok {
+			days = append(days, d)
+		} else {
+			days = append(days, &domain.DailyCallVolume{Date: day})
+		}
+	}
+	return days, nil
+}
+
+func (s *AnalyticsService) promptMetrics(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.PromptCallMetrics, error) {
+	metrics, err := s.repo.PromptMetrics(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt metrics: %w", err)
+	}
+	if metrics == nil {
+		metrics = []*domain.PromptCallMetrics{}
+	}
+	return metrics, nil
+}
+
+func (s *AnalyticsService) tagCounts(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallTagCounts, error) {
+	counts, err := s.repo.TagCounts(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag counts: %w", err)
+	}
+	return counts, nil
+}
+
+// normalizeFilter fills in a missing time range, ending now and starting
+// DefaultAnalyticsRange before the end, and rejects ranges that are empty
+// or longer than MaxAnalyticsRange.
+func (s *AnalyticsService) normalizeFilter(filter *domain.AnalyticsFilter) error {
+	if filter.To.IsZero() {
+		filter.To = s.clock.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-DefaultAnalyticsRange)
+	}
+	filter.From = filter.From.UTC()
+	filter.To = filter.To.UTC()
+	filter.Provider = strings.ToLower(strings.TrimSpace(filter.Provider))
+
+	if !filter.To.After(filter.From) {
+		return apperrors.ValidationFailed("to must be after from")
+	}
+	if filter.To.Sub(filter.From) > MaxAnalyticsRange {
+		return apperrors.ValidationFailed(fmt.Sprintf("time range must be at most %d days", int(MaxAnalyticsRange.Hours()/24)))
+	}
+	return nil
+}
+
+// truncateDay returns the start of t's UTC day.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockAnalyticsRepository returns canned aggregates and records the filter it was given.
+type MockAnalyticsRepository struct {
+	kpis    *domain.CallKPIs
+	values  []*domain.CurrencyTotal
+	days    []*domain.DailyCallVolume
+	prompts []*domain.PromptCallMetrics
+	filter  *domain.AnalyticsFilter
+}
+
+func (m *MockAnalyticsRepository) CallKPIs(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallKPIs, error) {
+	m.filter = filter
+	cp := *m.kpis
+	return &cp, nil
+}
+
+func (m *MockAnalyticsRepository) QuoteValues(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.CurrencyTotal, error) {
+	m.filter = filter
+	return m.values, nil
+}
+
+func (m *MockAnalyticsRepository) CallsPerDay(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.DailyCallVolume, error) {
+	m.filter = filter
+	return m.days, nil
+}
+
+func (m *MockAnalyticsRepository) PromptMetrics(ctx context.Context, filter *domain.AnalyticsFilter) ([]*domain.PromptCallMetrics, error) {
+	m.filter = filter
+	return m.prompts, nil
+}
+
+func (m *MockAnalyticsRepository) TagCounts(ctx context.Context, filter *domain.AnalyticsFilter) (*domain.CallTagCounts, error) {
+	m.filter = filter
+	return domain.NewCallTagCounts(), nil
+}
+
+func newTestAnalyticsService(repo *MockAnalyticsRepository, now time.Time) *AnalyticsService {
+	svc := NewAnalyticsService(repo, zap.NewNop(), clock.NewMock(now))
+	return svc
+}
+
+func TestAnalyticsService_GetKPIs_Rates(t *testing.T) {
+	repo := &MockAnalyticsRepository{kpis: &domain.CallKPIs{
+		TotalCalls:     20,
+		CompletedCalls: 12,
+		NoAnswerCalls:  4,
+		FailedCalls:    2,
+		QuotedCalls:    9,
+	}}
+	svc := newTestAnalyticsService(repo, time.Now())
+
+	kpis, err := svc.GetKPIs(context.Background(), &domain.AnalyticsFilter{})
+	if err != nil {
+		t.Fatalf("GetKPIs() error = %v", err)
+	}
+	if kpis.AnswerRate != 0.75 {
+		t.Errorf("AnswerRate = %v, want 0.75", kpis.AnswerRate)
+	}
+	if kpis.QuoteConversionRate != 0.75 {
+		t.Errorf("QuoteConversionRate = %v, want 0.75", kpis.QuoteConversionRate)
+	}
+
+	repo.kpis = &domain.CallKPIs{}
+	kpis, err = svc.GetKPIs(context.Background(), &domain.AnalyticsFilter{})
+	if err != nil || kpis.AnswerRate != 0 || kpis.QuoteConversionRate != 0 {
+		t.Errorf("GetKPIs() without calls = %+v, %v", kpis, err)
+	}
+}
+
+// staticRates is an ExchangeRateSource with fixed rates.
+type staticRates struct {
+	rates *domain.ExchangeRates
+	err   error
+}
+
+func (s staticRates) Rates(ctx context.Context, base string) (*domain.ExchangeRates, error) {
+	return s.rates, s.err
+}
+
+func TestAnalyticsService_GetKPIs_QuoteValues(t *testing.T) {
+	repo := &MockAnalyticsRepository{
+		kpis: &domain.CallKPIs{},
+		values: []*domain.CurrencyTotal{
+			{Currency: "EUR", Count: 2, Total: 8000},
+			{Currency: "USD", Count: 1, Total: 2000},
+			{Currency: "JPY", Count: 1, Total: 500000},
+		},
+	}
+	svc := newTestAnalyticsService(repo, time.Now())
+
+	kpis, err := svc.GetKPIs(context.Background(), &domain.AnalyticsFilter{})
+	if err != nil {
+		t.Fatalf("GetKPIs() error = %v", err)
+	}
+	if kpis.QuoteValueCurrency != "USD" || kpis.PricedQuotes != 1 || kpis.TotalQuoteValue != 2000 || kpis.UnconvertedQuotes != 3 {
+		t.Errorf("expected only USD quotes totaled without rates, got %+v", kpis)
+	}
+
+	svc.SetExchangeRates(staticRates{rates: &domain.ExchangeRates{
+		Base:  "GBP",
+		Rates: map[string]float64{"EUR": 1.25, "USD": 1.6},
+	}}, staticCurrency("GBP"))
+	kpis, err = svc.GetKPIs(context.Background(), &domain.AnalyticsFilter{})
+	if err != nil {
+		t.Fatalf("GetKPIs() error = %v", err)
+	}
+	if kpis.QuoteValueCurrency != "GBP" || kpis.PricedQuotes != 3 || kpis.UnconvertedQuotes != 1 {
+		t.Errorf("expected EUR and USD quotes converted to GBP, got %+v", kpis)
+	}
+	if kpis.TotalQuoteValue != 7650 || kpis.AverageQuoteValue != 2550 {
+		t.Errorf("TotalQuoteValue = %v, AverageQuoteValue = %v, want 7650 and 2550", kpis.TotalQuoteValue, kpis.AverageQuoteValue)
+	}
+	if len(kpis.QuoteValues) != 3 {
+		t.Errorf("expected the totals in each currency, got %+v", kpis.QuoteValues)
+	}
+}
+
+func TestAnalyticsService_NormalizeFilter(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	repo := &MockAnalyticsRepository{kpis: &domain.CallKPIs{}}
+	svc := newTestAnalyticsService(repo, now)
+
+	if _, err := svc.GetKPIs(context.Background(), &domain.AnalyticsFilter{Provider: " Bland "}); err != nil {
+		t.Fatalf("GetKPIs() error = %v", err)
+	}
+	if !repo.filter.To.Equal(now) || !repo.filter.From.Equal(now.Add(-DefaultAnalyticsRange)) {
+		t.Errorf("default range = %s to %s", repo.filter.From, repo.filter.To)
+	}
+	if repo.filter.Provider != "bland" {
+		t.Errorf("Provider = %q, want bland", repo.filter.Provider)
+	}
+
+	tests := []struct {
+		name   string
+		filter *domain.AnalyticsFilter
+	}{
+		{"to before from", &domain.AnalyticsFilter{From: now, To: now.Add(-time.Hour)}},
+		{"empty range", &domain.AnalyticsFilter{From: now, To: now}},
+		{"range too long", &domain.AnalyticsFilter{From: now.AddDate(-2, 0, 0), To: now}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetKPIs(context.Background(), tt.filter)
+			if !apperrors.IsUserError(err) {
+				t.Errorf("GetKPIs() error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_GetCallsPerDay_FillsEmptyDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC) }
+	repo := &MockAnalyticsRepository{days: []*domain.DailyCallVolume{
+		{Date: day(2), Calls: 3, Completed: 2, Quoted: 1},
+		{Date: day(4), Calls: 1},
+	}}
+	svc := newTestAnalyticsService(repo, time.Now())
+
+	days, err := svc.GetCallsPerDay(context.Background(), &domain.AnalyticsFilter{
+		From: day(1).Add(9 * time.Hour),
+		To:   day(5),
+	})
+	if err != nil {
+		t.Fatalf("GetCallsPerDay() error = %v", err)
+	}
+
+	wantCalls := []int{0, 3, 0, 1}
+	if len(days) != len(wantCalls) {
+		t.Fatalf("got %d days, want %d", len(days), len(wantCalls))
+	}
+	for i, d := range days {
+		if !d.Date.Equal(day(i+1)) || d.Calls != wantCalls[i] {
+			t.Errorf("day %d = %s with %d calls, want %s with %d", i, d.Date, d.Calls, day(i+1), wantCalls[i])
+		}
+	}
+}
+
+func TestAnalyticsService_GetAnalytics(t *testing.T) {
+	repo := &MockAnalyticsRepository{kpis: &domain.CallKPIs{TotalCalls: 1}}
+	svc := newTestAnalyticsService(repo, time.Now())
+
+	analytics, err := svc.GetAnalytics(context.Background(), &domain.AnalyticsFilter{Provider: "vapi"})
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+	if analytics.KPIs.TotalCalls != 1 || analytics.Provider != "vapi" {
+		t.Errorf("analytics = %+v", analytics)
+	}
+	if len(analytics.CallsPerDay) < 30 || analytics.Prompts == nil {
+		t.Errorf("got %d days and prompts %v", len(analytics.CallsPerDay), analytics.Prompts)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// ErrInvalidAPIKey is returned when a presented API key is unknown, revoked, or expired.
+var ErrInvalidAPIKey = &AuthError{Message: "invalid API key"}
+
+// APIKeyService manages API keys for machine-to-machine access.
+type APIKeyService struct {
+	repo     domain.APIKeyRepository
+	userRepo domain.UserRepository
+	logger   *zap.Logger
+}
+
+// NewAPIKeyService creates a new APIKeyService.
+func NewAPIKeyService(repo domain.APIKeyRepository, userRepo domain.UserRepository, logger *zap.Logger) *APIKeyService {
+	return &APIKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// CreateAPIKeyRequest holds the parameters for creating an API key.
+type CreateAPIKeyRequest struct {
+	Name               string
+	Scopes             []string
+	RateLimitPerMinute int
+	ExpiresAt          *time.Time
+}
+
+// Create generates a new API key owned by userID. The returned plaintext is
+// the only time the full key is available.
+func (s *APIKeyService) Create(ctx context.Context, userID uuid.UUID, req CreateAPIKeyRequest) (*domain.APIKey, string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, "", apperrors.MissingField("name")
+	}
+	scopes := domain.NormalizeScopes(req.Scopes)
+	if len(scopes) == 0 {
+		return nil, "", apperrors.MissingField("scopes")
+	}
+	for _, scope := range scopes {
+		if !domain.IsValidScope(scope) {
+			return nil, "", apperrors.InvalidFormat("scopes", "known scope such as calls:read")
+		}
+	}
+	if req.RateLimitPerMinute < 0 {
+		return nil, "", apperrors.ValidationFailed("rate_limit_per_minute must not be negative")
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return nil, "", apperrors.ValidationFailed("expires_at must be in the future")
+	}
+
+	key, plaintext, err := domain.NewAPIKey(userID, name, scopes, req.RateLimitPerMinute)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key.ExpiresAt = req.ExpiresAt
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	s.logger.Info("API key created",
+		zap.String("key_id", key.ID.String()),
+		zap.String("prefix", key.Prefix),
+		zap.String("user_id", userID.String()),
+	)
+
+	return key, plaintext, nil
+}
+
+// List returns all API keys.
+func (s *APIKeyService) List(ctx context.Context) ([]*domain.APIKey, error) {
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Rotate replaces the secret of an active key and returns the new plaintext.
+// The previous secret stops working immediately.
+func (s *APIKeyService) Rotate(ctx context.Context, id uuid.UUID) (*domain.APIKey, string, error) {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if key.IsRevoked() {
+		return nil, "", apperrors.New(apperrors.CodeConflict, "cannot rotate a revoked API key")
+	}
+
+	plaintext, err := key.Rotate()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to rotate API key: %w", err)
+	}
+
+	s.logger.Info("API key rotated",
+		zap.String("key_id", key.ID.String()),
+		zap.String("prefix", key.Prefix),
+	)
+
+	return key, plaintext, nil
+}
+
+// Revoke permanently disables a key. Revoking an already revoked key is a no-op.
+func (s *APIKeyService) Revoke(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsRevoked() {
+		return key, nil
+	}
+
+	key.Revoke()
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	s.logger.Info("API key revoked",
+		zap.String("key_id", key.ID.String()),
+		zap.String("prefix", key.Prefix),
+	)
+
+	return key, nil
+}
+
+// Authenticate resolves a plaintext key to the key record and its owner.
+func (s *APIKeyService) Authenticate(ctx context.Context, plaintext string) (*domain.APIKey, *domain.User, error) {
+	if !domain.LooksLikeAPIKey(plaintext) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(ctx, domain.HashAPIKey(plaintext))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	if !key.IsActive() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil, ErrInvalidAPIKey
+		}
+		return nil, nil, fmt.Errorf("failed to get API key owner: %w", err)
+	}
+	// Keys act as their owner, so a disabled owner's keys stop working.
+	if user.IsDisabled() {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+		// Usage tracking is best-effort and must not block the request.
+		s.logger.Warn("failed to record API key usage", zap.String("key_id", key.ID.String()), zap.Error(err))
+	} else {
+		key.LastUsedAt = &now
+	}
+
+	return key, user, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+func newTestAPIKeyService() (*APIKeyService, *MockAPIKeyRepository, *domain.User) {
+	keyRepo := NewMockAPIKeyRepository()
+	userRepo := NewMockUserRepository()
+	user, _ := domain.NewUser("admin@example.com", "password")
+	userRepo.Create(context.Background(), user)
+	return NewAPIKeyService(keyRepo, userRepo, zap.NewNop()), keyRepo, user
+}
+
+func TestAPIKeyService_CreateAndAuthenticate(t *testing.T) {
+	svc, keyRepo, user := newTestAPIKeyService()
+	ctx := context.Background()
+
+	key, plaintext, err := svc.Create(ctx, user.ID, CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{domain.ScopeCallsRead},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if keyRepo.CreateCalls != 1 {
+		t.Errorf("expected 1 Create call, got %d", keyRepo.CreateCalls)
+	}
+
+	gotKey, gotUser, err := svc.Authenticate(ctx, plaintext)
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if gotKey.ID != key.ID {
+		t.Errorf("expected key %s, got %s", key.ID, gotKey.ID)
+	}
+	if gotUser.ID != user.ID {
+		t.Errorf("expected user %s, got %s", user.ID, gotUser.ID)
+	}
+	if gotKey.LastUsedAt == nil {
+		t.Error("expected LastUsedAt to be recorded")
+	}
+}
+
+func TestAPIKeyService_Create_Validation(t *testing.T) {
+	svc, _, user := newTestAPIKeyService()
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  CreateAPIKeyRequest
+	}{
+		{"missing name", CreateAPIKeyRequest{Scopes: []string{domain.ScopeAll}}},
+		{"missing scopes", CreateAPIKeyRequest{Name: "ci"}},
+		{"unknown scope", CreateAPIKeyRequest{Name: "ci", Scopes: []string{"admin:write"}}},
+		{"negative rate limit", CreateAPIKeyRequest{Name: "ci", Scopes: []string{domain.ScopeAll}, RateLimitPerMinute: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.Create(ctx, user.ID, tt.req)
+			if apperrors.GetHTTPStatus(err) != http.StatusBadRequest {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Rotate(t *testing.T) {
+	svc, _, user := newTestAPIKeyService()
+	ctx := context.Background()
+
+	key, original, _ := svc.Create(ctx, user.ID, CreateAPIKeyRequest{Name: "ci", Scopes: []string{domain.ScopeAll}})
+
+	_, rotated, err := svc.Rotate(ctx, key.ID)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if _, _, err := svc.Authenticate(ctx, original); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("expected old key to be rejected, got %v", err)
+	}
+	if _, _, err := svc.Authenticate(ctx, rotated); err != nil {
+		t.Errorf("expected rotated key to authenticate, got %v", err)
+	}
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	svc, _, user := newTestAPIKeyService()
+	ctx := context.Background()
+
+	key, plaintext, _ := svc.Create(ctx, user.ID, CreateAPIKeyRequest{Name: "ci", Scopes: []string{domain.ScopeAll}})
+
+	if _, err := svc.Revoke(ctx, key.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, _, err := svc.Authenticate(ctx, plaintext); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("expected revoked key to be rejected, got %v", err)
+	}
+	if _, _, err := svc.Rotate(ctx, key.ID); err == nil {
+		t.Error("expected rotating a revoked key to fail")
+	}
+}
+
+func TestAPIKeyService_Authenticate_Unknown(t *testing.T) {
+	svc, _, _ := newTestAPIKeyService()
+	ctx := context.Background()
+
+	for _, token := range []string{"", "not-a-key", "qq_short"} {
+		if _, _, err := svc.Authenticate(ctx, token); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("Authenticate(%q) expected ErrInvalidAPIKey, got %v", token, err)
+		}
+	}
+
+	unknown, _ := domain.GenerateAPIKeySecret()
+	if _, _, err := svc.Authenticate(ctx, unknown); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Errorf("expected ErrInvalidAPIKey for unknown key, got %v", err)
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
 	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/totp"
 )
 
 // tokenLength is the length of session tokens in bytes.
@@ -24,6 +28,18 @@ type AuthService struct {
 	sessionDuration time.Duration
 	logger          *zap.Logger
 	metrics         *metrics.Metrics
+
+	// Two-factor authentication (optional)
+	recoveryCodes domain.RecoveryCodeRepository
+	totpIssuer    string
+
+	// Password reset, email verification and invitations (optional)
+	tokens    domain.UserTokenRepository
+	mailer    AccountMailer
+	publicURL string
+
+	// Single sign-on (optional)
+	sso *SSOConfig
 }
 
 // AuthError represents an authentication error.
@@ -40,6 +56,13 @@ var (
 	ErrInvalidCredentials = &AuthError{Message: "invalid email or password"}
 	ErrSessionExpired     = &AuthError{Message: "session expired"}
 	ErrUserNotFound       = &AuthError{Message: "user not found"}
+	ErrInvalidTwoFactor   = &AuthError{Message: "invalid authentication code"}
+	ErrEmailNotVerified   = &AuthError{Message: "email address not verified"}
+	ErrInvalidToken       = &AuthError{Message: "invalid or expired link"}
+	ErrAccountDisabled    = &AuthError{Message: "account disabled"}
+	ErrDomainNotAllowed   = &AuthError{Message: "email domain not allowed"}
+	ErrSSONoAccount       = &AuthError{Message: "no account for this single sign-on user"}
+	ErrSSOAccountLinked   = &AuthError{Message: "account is linked to another single sign-on user"}
 )
 
 // NewAuthService creates a new AuthService.
@@ -59,6 +82,180 @@ func NewAuthService(
 	}
 }
 
+// SetTwoFactor enables TOTP two-factor authentication. issuer is the name
+// authenticator apps show for the account.
+func (s *AuthService) SetTwoFactor(recoveryCodes domain.RecoveryCodeRepository, issuer string) {
+	s.recoveryCodes = recoveryCodes
+	s.totpIssuer = issuer
+}
+
+// SetAccountEmail enables password reset and email verification. Links in
+// the emails point at publicURL. mailer may be nil, in which case only
+// invitations are available and their links are shared by the inviting
+// admin.
+func (s *AuthService) SetAccountEmail(tokens domain.UserTokenRepository, mailer AccountMailer, publicURL string) {
+	s.tokens = tokens
+	s.mailer = mailer
+	s.publicURL = strings.TrimRight(publicURL, "/")
+}
+
+// SSOConfig controls who may sign in with single sign-on.
+type SSOConfig struct {
+	// AllowedDomains are the email domains that may sign in; empty allows
+	// any address the identity provider vouches for.
+	AllowedDomains []string
+	// AutoProvision creates users on their first sign-in. Without it only
+	// existing and invited users may sign in.
+	AutoProvision bool
+	// DefaultRole is given to users created on their first sign-in.
+	DefaultRole domain.UserRole
+}
+
+// SSOIdentity is a user the identity provider signed in. Issuer and
+// Subject identify them for good; Email is an address the provider has
+// verified.
+type SSOIdentity struct {
+	Issuer  string
+	Subject string
+	Email   string
+}
+
+// SetSSO enables single sign-on logins.
+func (s *AuthService) SetSSO(cfg SSOConfig) {
+	if !cfg.DefaultRole.IsValid() {
+		cfg.DefaultRole = domain.UserRoleMember
+	}
+	domains := make([]string, 0, len(cfg.AllowedDomains))
+	for _, d := range cfg.AllowedDomains {
+		if d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@")); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	cfg.AllowedDomains = domains
+	s.sso = &cfg
+}
+
+// SSOAvailable reports whether single sign-on is enabled.
+func (s *AuthService) SSOAvailable() bool {
+	return s.sso != nil
+}
+
+// LoginWithSSO creates a session for a user the identity provider signed
+// in. Users are matched by the provider's issuer and subject. On their first
+// single sign-on an existing or invited user with the same verified address
+// is linked to that subject, and a user is created with the default role
+// when auto-provisioning is on. Users with two-factor authentication still
+// enter a code.
+func (s *AuthService) LoginWithSSO(ctx context.Context, identity SSOIdentity, loginCtx *LoginContext) (*domain.Session, error) {
+	if s.sso == nil {
+		return nil, fmt.Errorf("single sign-on is not enabled")
+	}
+	email := identity.Email
+	if identity.Issuer == "" || identity.Subject == "" {
+		return nil, fmt.Errorf("single sign-on identity has no issuer or subject")
+	}
+	if !s.ssoDomainAllowed(email) {
+		s.logger.Warn("single sign-on from a domain that is not allowed", zap.String("email", email))
+		return nil, ErrDomainNotAllowed
+	}
+
+	user, err := s.userRepo.GetBySSOIdentity(ctx, identity.Issuer, identity.Subject)
+	switch {
+	case apperrors.IsNotFound(err):
+		if user, err = s.linkSSOUser(ctx, identity); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsDisabled() {
+		s.logger.Warn("single sign-on for disabled user", zap.String("email", email))
+		return nil, ErrAccountDisabled
+	}
+
+	session, err := s.createSession(ctx, user, loginCtx, !user.TwoFactorEnabled())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user logged in with single sign-on",
+		zap.String("user_id", user.ID.String()),
+		zap.String("email", email),
+	)
+
+	return session, nil
+}
+
+// linkSSOUser links a provider subject signing in for the first time to the
+// user with its address, creating the user when auto-provisioning is on.
+func (s *AuthService) linkSSOUser(ctx context.Context, identity SSOIdentity) (*domain.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	switch {
+	case apperrors.IsNotFound(err):
+		if !s.sso.AutoProvision {
+			s.logger.Warn("single sign-on for an unknown user", zap.String("email", identity.Email))
+			return nil, ErrSSONoAccount
+		}
+		// The user never has a usable password; they can set one through
+		// a password reset if they need one.
+		password, err := generateToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate password: %w", err)
+		}
+		user, err = domain.NewUser(identity.Email, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		user.Role = s.sso.DefaultRole
+		user.MarkEmailVerified()
+		user.LinkSSO(identity.Issuer, identity.Subject)
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to save user: %w", err)
+		}
+		s.logger.Info("user provisioned by single sign-on",
+			zap.String("user_id", user.ID.String()),
+			zap.String("role", string(user.Role)),
+		)
+		return user, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	case user.SSOLinked():
+		// The address moved to another subject, as happens when a
+		// provider lets users change their email
+		s.logger.Warn("single sign-on address belongs to a user linked to another subject",
+			zap.String("user_id", user.ID.String()),
+			zap.String("email", identity.Email),
+		)
+		return nil, ErrSSOAccountLinked
+	}
+
+	user.LinkSSO(identity.Issuer, identity.Subject)
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.logger.Info("user linked to single sign-on", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// ssoDomainAllowed reports whether an address is in an allowed domain.
+func (s *AuthService) ssoDomainAllowed(email string) bool {
+	if len(s.sso.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	host := strings.ToLower(email[at+1:])
+	for _, allowed := range s.sso.AllowedDomains {
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 // LoginContext holds contextual information for login.
 type LoginContext struct {
 	IPAddress string
@@ -86,7 +283,39 @@ func (s *AuthService) LoginWithContext(ctx context.Context, email, password stri
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate session token
+	if user.IsDisabled() {
+		s.logger.Warn("login attempt for disabled user", zap.String("email", email))
+		return nil, ErrAccountDisabled
+	}
+
+	// Verification is only enforced while links can be sent; otherwise an
+	// unverified user would have no way in.
+	if !user.EmailVerified() && s.AccountEmailAvailable() {
+		s.logger.Warn("login attempt before email verification", zap.String("email", email))
+		// The password was right, so a fresh link goes to the address's owner.
+		if err := s.SendEmailVerification(ctx, user); err != nil {
+			s.logger.Error("failed to resend verification email", zap.Error(err))
+		}
+		return nil, ErrEmailNotVerified
+	}
+
+	// Two-factor users must verify a code before the session is usable.
+	session, err := s.createSession(ctx, user, loginCtx, !user.TwoFactorEnabled())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("user logged in",
+		zap.String("user_id", user.ID.String()),
+		zap.String("email", email),
+	)
+
+	return session, nil
+}
+
+// createSession starts a session for a user. twoFactorVerified records
+// whether the second factor has been checked.
+func (s *AuthService) createSession(ctx context.Context, user *domain.User, loginCtx *LoginContext, twoFactorVerified bool) (*domain.Session, error) {
 	token, err := generateToken()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session token: %w", err)
@@ -98,16 +327,11 @@ func (s *AuthService) LoginWithContext(ctx context.Context, email, password stri
 	} else {
 		session = domain.NewSession(user.ID, token, s.sessionDuration)
 	}
+	session.TwoFactorVerified = twoFactorVerified
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
-
-	s.logger.Info("user logged in",
-		zap.String("user_id", user.ID.String()),
-		zap.String("email", email),
-	)
-
 	return session, nil
 }
 
@@ -125,6 +349,9 @@ func (s *AuthService) Logout(ctx context.Context, token string) error {
 type SessionValidationResult struct {
 	User     *domain.User
 	NewToken string // Set if token was rotated
+	// TwoFactorPending is set when the user has two-factor authentication
+	// enabled and has not yet verified a code in this session.
+	TwoFactorPending bool
 }
 
 // ValidateSession validates a session token and returns the associated user.
@@ -166,7 +393,17 @@ func (s *AuthService) ValidateAndRefreshSession(ctx context.Context, token strin
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	result := &SessionValidationResult{User: user}
+	// Sessions are signed out when a user is disabled; this catches any
+	// that slipped through.
+	if user.IsDisabled() {
+		_ = s.sessionRepo.Delete(ctx, token)
+		return nil, ErrAccountDisabled
+	}
+
+	result := &SessionValidationResult{
+		User:             user,
+		TwoFactorPending: user.TwoFactorEnabled() && !session.TwoFactorVerified,
+	}
 
 	// If using old token during grace period, return current token for client to update
 	if usingOldToken {
@@ -213,8 +450,31 @@ func (s *AuthService) ValidateAndRefreshSession(ctx context.Context, token strin
 	return result, nil
 }
 
-// CreateUser creates a new user account.
+// CreateUser creates a new user account. When account email is configured
+// the user must follow an emailed link before logging in; otherwise the
+// address is trusted as given.
 func (s *AuthService) CreateUser(ctx context.Context, email, password string) (*domain.User, error) {
+	verified := !s.AccountEmailAvailable()
+	user, err := s.createUser(ctx, email, password, domain.UserRoleMember, verified)
+	if err != nil {
+		return nil, err
+	}
+
+	if !verified {
+		if err := s.SendEmailVerification(ctx, user); err != nil {
+			s.logger.Error("failed to send verification email",
+				zap.String("user_id", user.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return user, nil
+}
+
+// createUser saves a new user with the given role, optionally with the
+// email already verified.
+func (s *AuthService) createUser(ctx context.Context, email, password string, role domain.UserRole, verified bool) (*domain.User, error) {
 	// Check if user already exists
 	existing, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil && !apperrors.IsNotFound(err) {
@@ -228,6 +488,10 @@ func (s *AuthService) CreateUser(ctx context.Context, email, password string) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	user.Role = role
+	if verified {
+		user.MarkEmailVerified()
+	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to save user: %w", err)
@@ -267,8 +531,8 @@ func (s *AuthService) EnsureAdminUser(ctx context.Context, email, password strin
 		return false, apperrors.ValidationFailed("admin email and password required for initial setup")
 	}
 
-	// Create admin user
-	user, err := s.CreateUser(ctx, email, password)
+	// Create admin user; the address comes from the operator, so it is trusted
+	user, err := s.createUser(ctx, email, password, domain.UserRoleAdmin, true)
 	if err != nil {
 		return false, fmt.Errorf("failed to create admin user: %w", err)
 	}
@@ -281,6 +545,487 @@ func (s *AuthService) EnsureAdminUser(ctx context.Context, email, password strin
 	return true, nil
 }
 
+// CleanupExpiredTokens removes expired password reset, email verification
+// and invitation tokens.
+func (s *AuthService) CleanupExpiredTokens(ctx context.Context) error {
+	if s.tokens == nil {
+		return nil
+	}
+	return s.tokens.DeleteExpired(ctx)
+}
+
+// AccountEmailAvailable reports whether password reset and email
+// verification links can be sent.
+func (s *AuthService) AccountEmailAvailable() bool {
+	return s.tokens != nil && s.mailer != nil && s.publicURL != ""
+}
+
+// RequestPasswordReset emails a password reset link to the user with the
+// given address. Unknown addresses are ignored without an error, so the
+// response doesn't reveal which addresses have accounts.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	if !s.AccountEmailAvailable() {
+		return apperrors.New(apperrors.CodeConfig, "password reset is not configured")
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			s.logger.Info("password reset requested for unknown email", zap.String("email", email))
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.IsDisabled() {
+		s.logger.Info("password reset requested for disabled user", zap.String("user_id", user.ID.String()))
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, domain.TokenPurposePasswordReset, domain.PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	s.mailer.PasswordReset(ctx, user, s.accountURL("/auth/reset-password", token))
+
+	s.logger.Info("password reset requested", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// CheckPasswordResetToken returns ErrInvalidToken unless token is a
+// usable password reset token.
+func (s *AuthService) CheckPasswordResetToken(ctx context.Context, token string) error {
+	_, err := s.getToken(ctx, domain.TokenPurposePasswordReset, token)
+	return err
+}
+
+// ResetPassword sets a new password using a password reset token. The
+// user's sessions are signed out. Following the emailed link proves the
+// user receives mail at their address, so it is marked verified as well.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password string) error {
+	user, err := s.setPasswordWithToken(ctx, domain.TokenPurposePasswordReset, token, password)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("password reset", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// SendInvitation issues an invitation link for a user created by an admin
+// and returns it, so the admin can pass it on if email isn't configured.
+// The link is emailed to the user when it is.
+func (s *AuthService) SendInvitation(ctx context.Context, user *domain.User) (string, error) {
+	if s.tokens == nil {
+		return "", apperrors.New(apperrors.CodeConfig, "invitations are not configured")
+	}
+
+	token, err := s.issueToken(ctx, user.ID, domain.TokenPurposeInvitation, domain.InvitationTokenTTL)
+	if err != nil {
+		return "", err
+	}
+	inviteURL := s.accountURL("/auth/accept-invite", token)
+	if s.AccountEmailAvailable() {
+		s.mailer.Invitation(ctx, user, inviteURL)
+	}
+
+	s.logger.Info("invitation issued", zap.String("user_id", user.ID.String()))
+	return inviteURL, nil
+}
+
+// CheckInvitationToken returns ErrInvalidToken unless token is a usable
+// invitation token.
+func (s *AuthService) CheckInvitationToken(ctx context.Context, token string) error {
+	_, err := s.getToken(ctx, domain.TokenPurposeInvitation, token)
+	return err
+}
+
+// AcceptInvitation sets the invited user's first password. The invitation
+// reached them at their address, so it is marked verified.
+func (s *AuthService) AcceptInvitation(ctx context.Context, token, password string) (*domain.User, error) {
+	user, err := s.setPasswordWithToken(ctx, domain.TokenPurposeInvitation, token, password)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("invitation accepted", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// setPasswordWithToken spends a password reset or invitation token and
+// sets the user's password, signing out their sessions and marking their
+// address verified.
+func (s *AuthService) setPasswordWithToken(ctx context.Context, purpose domain.TokenPurpose, token, password string) (*domain.User, error) {
+	t, err := s.getToken(ctx, purpose, token)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < domain.MinPasswordLength {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("password must be at least %d characters", domain.MinPasswordLength))
+	}
+
+	user, err := s.GetUser(ctx, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return nil, ErrAccountDisabled
+	}
+	if err := s.useToken(ctx, t); err != nil {
+		return nil, err
+	}
+
+	if err := user.SetPassword(password); err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save password: %w", err)
+	}
+
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to sign out sessions: %w", err)
+	}
+	if err := s.tokens.DeleteByUserID(ctx, user.ID, purpose); err != nil {
+		s.logger.Warn("failed to delete used tokens", zap.String("purpose", string(purpose)), zap.Error(err))
+	}
+
+	return user, nil
+}
+
+// SendEmailVerification emails a user a link that confirms their address,
+// replacing any earlier link.
+func (s *AuthService) SendEmailVerification(ctx context.Context, user *domain.User) error {
+	if !s.AccountEmailAvailable() {
+		return apperrors.New(apperrors.CodeConfig, "email verification is not configured")
+	}
+
+	token, err := s.issueToken(ctx, user.ID, domain.TokenPurposeEmailVerification, domain.EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	s.mailer.EmailVerification(ctx, user, s.accountURL("/auth/verify-email", token))
+
+	s.logger.Info("verification email sent", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// VerifyEmail marks the address of the token's user as verified.
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	t, err := s.getToken(ctx, domain.TokenPurposeEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.GetUser(ctx, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.useToken(ctx, t); err != nil {
+		return nil, err
+	}
+
+	user.MarkEmailVerified()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	s.logger.Info("email verified", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// issueToken replaces a user's tokens for a purpose with a new one and
+// returns the plaintext token.
+func (s *AuthService) issueToken(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose, ttl time.Duration) (string, error) {
+	if err := s.tokens.DeleteByUserID(ctx, userID, purpose); err != nil {
+		return "", fmt.Errorf("failed to delete old tokens: %w", err)
+	}
+	t, plaintext, err := domain.NewUserToken(userID, purpose, ttl)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	if err := s.tokens.Create(ctx, t); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+	return plaintext, nil
+}
+
+// getToken looks up an unused, unexpired token.
+func (s *AuthService) getToken(ctx context.Context, purpose domain.TokenPurpose, token string) (*domain.UserToken, error) {
+	if s.tokens == nil || token == "" {
+		return nil, ErrInvalidToken
+	}
+	t, err := s.tokens.GetByHash(ctx, purpose, domain.HashUserToken(token))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	if t.IsUsed() || t.IsExpired() {
+		return nil, ErrInvalidToken
+	}
+	return t, nil
+}
+
+// useToken spends a token, failing if it was used concurrently.
+func (s *AuthService) useToken(ctx context.Context, t *domain.UserToken) error {
+	if err := s.tokens.MarkUsed(ctx, t.ID); err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrInvalidToken
+		}
+		return fmt.Errorf("failed to use token: %w", err)
+	}
+	return nil
+}
+
+// accountURL builds a link to an account page carrying a token. Without a
+// public URL the link is relative to the dashboard.
+func (s *AuthService) accountURL(path, token string) string {
+	return s.publicURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// TwoFactorSetup holds what a user needs to add their account to an
+// authenticator app.
+type TwoFactorSetup struct {
+	Secret string // Base32 secret, for typing in when the QR code can't be scanned
+	URI    string // otpauth:// provisioning URI, shown as a QR code
+}
+
+// TwoFactorAvailable reports whether two-factor authentication is configured.
+func (s *AuthService) TwoFactorAvailable() bool {
+	return s.recoveryCodes != nil
+}
+
+// BeginTwoFactorSetup generates a new TOTP secret for a user. Two-factor
+// authentication is not enabled until EnableTwoFactor confirms a code from it.
+func (s *AuthService) BeginTwoFactorSetup(ctx context.Context, userID uuid.UUID) (*TwoFactorSetup, error) {
+	if !s.TwoFactorAvailable() {
+		return nil, apperrors.New(apperrors.CodeConfig, "two-factor authentication is not configured")
+	}
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled() {
+		return nil, apperrors.ValidationFailed("two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+	user.TOTPSecret = &secret
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save totp secret: %w", err)
+	}
+
+	return s.PendingTwoFactorSetup(user), nil
+}
+
+// PendingTwoFactorSetup returns the setup details for a user who has begun
+// but not finished enrollment, or nil.
+func (s *AuthService) PendingTwoFactorSetup(user *domain.User) *TwoFactorSetup {
+	if !user.TwoFactorPending() {
+		return nil
+	}
+	return &TwoFactorSetup{
+		Secret: *user.TOTPSecret,
+		URI:    totp.URI(s.totpIssuer, user.Email, *user.TOTPSecret),
+	}
+}
+
+// EnableTwoFactor confirms enrollment with a code from the authenticator app
+// and returns new recovery codes. The user's other sessions are signed out;
+// the returned session replaces the caller's.
+func (s *AuthService) EnableTwoFactor(ctx context.Context, userID uuid.UUID, code string, loginCtx *LoginContext) ([]string, *domain.Session, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.TwoFactorPending() {
+		return nil, nil, apperrors.ValidationFailed("two-factor setup has not been started")
+	}
+
+	counter, ok := totp.Validate(*user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, nil, ErrInvalidTwoFactor
+	}
+	user.EnableTwoFactor(counter)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Existing sessions were created without a second factor.
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return nil, nil, fmt.Errorf("failed to sign out other sessions: %w", err)
+	}
+	session, err := s.createSession(ctx, user, loginCtx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.Info("two-factor authentication enabled", zap.String("user_id", user.ID.String()))
+	return codes, session, nil
+}
+
+// VerifyTwoFactor completes login for a session awaiting its second factor,
+// accepting a TOTP code or an unused recovery code. The session token is
+// replaced, so the returned session's token must be sent to the client.
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, token, code string) (*domain.Session, error) {
+	session, err := s.sessionRepo.GetByToken(ctx, token)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, ErrSessionExpired
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session.IsExpired() {
+		return nil, ErrSessionExpired
+	}
+	user, err := s.GetUser(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled() || session.TwoFactorVerified {
+		return session, nil
+	}
+
+	if err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	// A new token keeps a token seen before verification from being used after.
+	newToken, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	session.Token = newToken
+	session.InvalidatePreviousToken()
+	session.TwoFactorVerified = true
+	session.Touch()
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.logger.Info("two-factor verification succeeded", zap.String("user_id", user.ID.String()))
+	return session, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication after checking the
+// user's password and a current code or recovery code.
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID uuid.UUID, password, code string) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled() {
+		return apperrors.ValidationFailed("two-factor authentication is not enabled")
+	}
+	if !user.CheckPassword(password) {
+		return ErrInvalidCredentials
+	}
+	if err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.DisableTwoFactor()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+	if err := s.recoveryCodes.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	s.logger.Info("two-factor authentication disabled", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces a user's recovery codes after checking a
+// current code, invalidating the old ones.
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled() {
+		return nil, apperrors.ValidationFailed("two-factor authentication is not enabled")
+	}
+	if err := s.checkSecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, user.ID)
+}
+
+// RecoveryCodesRemaining returns how many unused recovery codes a user has.
+func (s *AuthService) RecoveryCodesRemaining(ctx context.Context, userID uuid.UUID) (int, error) {
+	if !s.TwoFactorAvailable() {
+		return 0, nil
+	}
+	return s.recoveryCodes.CountUnused(ctx, userID)
+}
+
+// checkSecondFactor accepts a TOTP code newer than the last one used, or an
+// unused recovery code, which is then spent. The TOTP counter only moves
+// forward in the database, so of two requests with the same code only one
+// is accepted.
+func (s *AuthService) checkSecondFactor(ctx context.Context, user *domain.User, code string) error {
+	if counter, ok := totp.Validate(*user.TOTPSecret, code, time.Now()); ok {
+		if err := s.userRepo.AdvanceTOTPCounter(ctx, user.ID, counter); err != nil {
+			if apperrors.IsNotFound(err) {
+				s.logger.Warn("rejected reused totp code", zap.String("user_id", user.ID.String()))
+				return ErrInvalidTwoFactor
+			}
+			return fmt.Errorf("failed to record totp use: %w", err)
+		}
+		user.TOTPLastCounter = counter
+		return nil
+	}
+
+	if s.recoveryCodes == nil {
+		return ErrInvalidTwoFactor
+	}
+	if err := s.recoveryCodes.Use(ctx, user.ID, domain.HashRecoveryCode(code)); err != nil {
+		if apperrors.IsNotFound(err) {
+			return ErrInvalidTwoFactor
+		}
+		return fmt.Errorf("failed to check recovery code: %w", err)
+	}
+	s.logger.Info("recovery code used", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// replaceRecoveryCodes issues a new set of recovery codes for a user.
+func (s *AuthService) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes, err := domain.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = domain.HashRecoveryCode(code)
+	}
+	if err := s.recoveryCodes.Replace(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// GetUser loads a user, mapping a missing user to ErrUserNotFound.
+func (s *AuthService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
 // generateToken generates a cryptographically secure random token.
 func generateToken() (string, error) {
 	bytes := make([]byte, tokenLength)
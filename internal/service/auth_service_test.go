@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/totp"
 )
 
 func newTestAuthService() (*AuthService, *MockUserRepository, *MockSessionRepository) {
@@ -232,3 +236,528 @@ func TestGenerateToken(t *testing.T) {
 		t.Error("expected different tokens, got same")
 	}
 }
+
+// enrollTwoFactor creates a user with two-factor authentication enabled and
+// returns it with its recovery codes.
+func enrollTwoFactor(t *testing.T, service *AuthService, userRepo *MockUserRepository, password string) (*domain.User, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("2fa@example.com", password)
+	userRepo.Create(ctx, user)
+
+	setup, err := service.BeginTwoFactorSetup(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginTwoFactorSetup() error = %v", err)
+	}
+	code, _ := totp.Code(setup.Secret, totp.Counter(time.Now()))
+	codes, _, err := service.EnableTwoFactor(ctx, user.ID, code, nil)
+	if err != nil {
+		t.Fatalf("EnableTwoFactor() error = %v", err)
+	}
+	return user, codes
+}
+
+func newTestTwoFactorAuthService() (*AuthService, *MockUserRepository, *MockSessionRepository) {
+	service, userRepo, sessionRepo := newTestAuthService()
+	service.SetTwoFactor(NewMockRecoveryCodeRepository(), "QuickQuote")
+	return service, userRepo, sessionRepo
+}
+
+func TestAuthService_EnableTwoFactor(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("2fa@example.com", "securepassword123")
+	userRepo.Create(ctx, user)
+
+	setup, err := service.BeginTwoFactorSetup(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginTwoFactorSetup() error = %v", err)
+	}
+	if !strings.HasPrefix(setup.URI, "otpauth://totp/QuickQuote:") {
+		t.Errorf("unexpected provisioning URI %q", setup.URI)
+	}
+	if user.TwoFactorEnabled() {
+		t.Fatal("two-factor authentication should not be enabled before a code is confirmed")
+	}
+
+	if _, _, err := service.EnableTwoFactor(ctx, user.ID, "not-a-code", nil); !errors.Is(err, ErrInvalidTwoFactor) {
+		t.Errorf("EnableTwoFactor() with wrong code error = %v, want %v", err, ErrInvalidTwoFactor)
+	}
+
+	code, _ := totp.Code(setup.Secret, totp.Counter(time.Now()))
+	codes, session, err := service.EnableTwoFactor(ctx, user.ID, code, nil)
+	if err != nil {
+		t.Fatalf("EnableTwoFactor() error = %v", err)
+	}
+	if !user.TwoFactorEnabled() {
+		t.Error("expected two-factor authentication to be enabled")
+	}
+	if len(codes) != domain.RecoveryCodeCount {
+		t.Errorf("expected %d recovery codes, got %d", domain.RecoveryCodeCount, len(codes))
+	}
+	if !session.TwoFactorVerified {
+		t.Error("expected the replacement session to be verified")
+	}
+}
+
+func TestAuthService_Login_TwoFactorPending(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	password := "securepassword123"
+	user, _ := enrollTwoFactor(t, service, userRepo, password)
+
+	session, err := service.Login(ctx, user.Email, password)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if session.TwoFactorVerified {
+		t.Fatal("expected session to await two-factor verification")
+	}
+
+	result, err := service.ValidateAndRefreshSession(ctx, session.Token)
+	if err != nil {
+		t.Fatalf("ValidateAndRefreshSession() error = %v", err)
+	}
+	if !result.TwoFactorPending {
+		t.Error("expected TwoFactorPending for unverified session")
+	}
+
+	oldToken := session.Token
+	code, _ := totp.Code(*user.TOTPSecret, user.TOTPLastCounter+1)
+	verified, err := service.VerifyTwoFactor(ctx, session.Token, code)
+	if err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+	if !verified.TwoFactorVerified {
+		t.Error("expected session to be verified")
+	}
+	if verified.Token == oldToken {
+		t.Error("expected session token to be rotated on verification")
+	}
+
+	result, err = service.ValidateAndRefreshSession(ctx, verified.Token)
+	if err != nil {
+		t.Fatalf("ValidateAndRefreshSession() error = %v", err)
+	}
+	if result.TwoFactorPending {
+		t.Error("expected verified session not to be pending")
+	}
+}
+
+func TestAuthService_VerifyTwoFactor_RejectsReusedCode(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	password := "securepassword123"
+	user, _ := enrollTwoFactor(t, service, userRepo, password)
+
+	// The code used to enable two-factor authentication
+	code, _ := totp.Code(*user.TOTPSecret, user.TOTPLastCounter)
+
+	session, _ := service.Login(ctx, user.Email, password)
+	if _, err := service.VerifyTwoFactor(ctx, session.Token, code); !errors.Is(err, ErrInvalidTwoFactor) {
+		t.Errorf("VerifyTwoFactor() with reused code error = %v, want %v", err, ErrInvalidTwoFactor)
+	}
+}
+
+func TestAuthService_CheckSecondFactor_ConcurrentCodeAcceptedOnce(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	user, _ := enrollTwoFactor(t, service, userRepo, "securepassword123")
+
+	// Two requests that each read the user before either recorded the code
+	first, second := *user, *user
+	code, _ := totp.Code(*user.TOTPSecret, user.TOTPLastCounter+1)
+	if err := service.checkSecondFactor(ctx, &first, code); err != nil {
+		t.Fatalf("checkSecondFactor() error = %v", err)
+	}
+	if err := service.checkSecondFactor(ctx, &second, code); !errors.Is(err, ErrInvalidTwoFactor) {
+		t.Errorf("checkSecondFactor() with the same code error = %v, want %v", err, ErrInvalidTwoFactor)
+	}
+}
+
+func TestAuthService_VerifyTwoFactor_RecoveryCodeSingleUse(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	password := "securepassword123"
+	user, codes := enrollTwoFactor(t, service, userRepo, password)
+
+	session, _ := service.Login(ctx, user.Email, password)
+	if _, err := service.VerifyTwoFactor(ctx, session.Token, strings.ToUpper(codes[0])); err != nil {
+		t.Fatalf("VerifyTwoFactor() with recovery code error = %v", err)
+	}
+
+	remaining, _ := service.RecoveryCodesRemaining(ctx, user.ID)
+	if remaining != domain.RecoveryCodeCount-1 {
+		t.Errorf("expected %d recovery codes remaining, got %d", domain.RecoveryCodeCount-1, remaining)
+	}
+
+	session, _ = service.Login(ctx, user.Email, password)
+	if _, err := service.VerifyTwoFactor(ctx, session.Token, codes[0]); !errors.Is(err, ErrInvalidTwoFactor) {
+		t.Errorf("VerifyTwoFactor() with spent recovery code error = %v, want %v", err, ErrInvalidTwoFactor)
+	}
+}
+
+func TestAuthService_DisableTwoFactor(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	password := "securepassword123"
+	user, codes := enrollTwoFactor(t, service, userRepo, password)
+
+	if err := service.DisableTwoFactor(ctx, user.ID, "wrongpassword", codes[0]); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("DisableTwoFactor() with wrong password error = %v, want %v", err, ErrInvalidCredentials)
+	}
+
+	if err := service.DisableTwoFactor(ctx, user.ID, password, codes[0]); err != nil {
+		t.Fatalf("DisableTwoFactor() error = %v", err)
+	}
+	if user.TwoFactorEnabled() || user.TOTPSecret != nil {
+		t.Error("expected two-factor authentication to be disabled and the secret cleared")
+	}
+
+	session, _ := service.Login(ctx, user.Email, password)
+	if !session.TwoFactorVerified {
+		t.Error("expected login without two-factor authentication to be complete")
+	}
+}
+
+func newTestAccountEmailAuthService() (*AuthService, *MockUserRepository, *MockSessionRepository, *MockAccountMailer) {
+	service, userRepo, sessionRepo := newTestAuthService()
+	mailer := &MockAccountMailer{}
+	service.SetAccountEmail(NewMockUserTokenRepository(), mailer, "https://qq.example.com/")
+	return service, userRepo, sessionRepo, mailer
+}
+
+// linkToken returns the token query parameter of an emailed link.
+func linkToken(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid link %q: %v", link, err)
+	}
+	return u.Query().Get("token")
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	service, userRepo, sessionRepo, mailer := newTestAccountEmailAuthService()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("test@example.com", "oldpassword123")
+	user.MarkEmailVerified()
+	userRepo.Create(ctx, user)
+	service.Login(ctx, user.Email, "oldpassword123")
+
+	if err := service.RequestPasswordReset(ctx, user.Email); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+	if len(mailer.ResetURLs) != 1 {
+		t.Fatalf("expected 1 reset email, got %d", len(mailer.ResetURLs))
+	}
+	link := mailer.ResetURLs[0]
+	if !strings.HasPrefix(link, "https://qq.example.com/auth/reset-password?token=") {
+		t.Errorf("unexpected reset link %q", link)
+	}
+	token := linkToken(t, link)
+
+	if err := service.ResetPassword(ctx, token, "short"); !apperrors.IsUserError(err) {
+		t.Errorf("ResetPassword() with short password error = %v, want validation error", err)
+	}
+
+	if err := service.ResetPassword(ctx, token, "newpassword123"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	if !user.CheckPassword("newpassword123") {
+		t.Error("expected new password to be set")
+	}
+	if sessionRepo.DeleteByUserIDCalls != 1 {
+		t.Errorf("expected sessions to be signed out, got %d DeleteByUserID calls", sessionRepo.DeleteByUserIDCalls)
+	}
+
+	if err := service.ResetPassword(ctx, token, "anotherpassword123"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ResetPassword() with used token error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestAuthService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	service, _, _, mailer := newTestAccountEmailAuthService()
+
+	if err := service.RequestPasswordReset(context.Background(), "nobody@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset() error = %v", err)
+	}
+	if len(mailer.ResetURLs) != 0 {
+		t.Errorf("expected no email for an unknown address, got %d", len(mailer.ResetURLs))
+	}
+}
+
+func TestAuthService_RequestPasswordReset_ReplacesEarlierLink(t *testing.T) {
+	service, userRepo, _, mailer := newTestAccountEmailAuthService()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("test@example.com", "oldpassword123")
+	userRepo.Create(ctx, user)
+
+	service.RequestPasswordReset(ctx, user.Email)
+	service.RequestPasswordReset(ctx, user.Email)
+
+	if err := service.CheckPasswordResetToken(ctx, linkToken(t, mailer.ResetURLs[0])); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("CheckPasswordResetToken() for replaced link error = %v, want %v", err, ErrInvalidToken)
+	}
+	if err := service.CheckPasswordResetToken(ctx, linkToken(t, mailer.ResetURLs[1])); err != nil {
+		t.Errorf("CheckPasswordResetToken() for latest link error = %v", err)
+	}
+}
+
+func TestAuthService_EmailVerification(t *testing.T) {
+	service, _, _, mailer := newTestAccountEmailAuthService()
+	ctx := context.Background()
+	password := "securepassword123"
+
+	user, err := service.CreateUser(ctx, "new@example.com", password)
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if user.EmailVerified() {
+		t.Fatal("expected new user to be unverified")
+	}
+	if len(mailer.VerificationURLs) != 1 {
+		t.Fatalf("expected 1 verification email, got %d", len(mailer.VerificationURLs))
+	}
+
+	// Logging in before verifying fails and sends a fresh link.
+	if _, err := service.Login(ctx, user.Email, password); !errors.Is(err, ErrEmailNotVerified) {
+		t.Errorf("Login() before verification error = %v, want %v", err, ErrEmailNotVerified)
+	}
+	if len(mailer.VerificationURLs) != 2 {
+		t.Fatalf("expected a new verification email on login, got %d", len(mailer.VerificationURLs))
+	}
+
+	if _, err := service.VerifyEmail(ctx, linkToken(t, mailer.VerificationURLs[1])); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !user.EmailVerified() {
+		t.Error("expected user to be verified")
+	}
+	if _, err := service.Login(ctx, user.Email, password); err != nil {
+		t.Errorf("Login() after verification error = %v", err)
+	}
+}
+
+func TestAuthService_CreateUser_VerifiedWithoutAccountEmail(t *testing.T) {
+	service, _, _ := newTestAuthService()
+
+	user, err := service.CreateUser(context.Background(), "new@example.com", "securepassword123")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	if !user.EmailVerified() {
+		t.Error("expected user to be verified when account email is not configured")
+	}
+}
+
+func TestAuthService_DisabledUser(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	ctx := context.Background()
+
+	password := "securepassword123"
+	user, _ := domain.NewUser("test@example.com", password)
+	userRepo.Create(ctx, user)
+	session, err := service.Login(ctx, user.Email, password)
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	user.Disable()
+
+	if _, err := service.Login(ctx, user.Email, password); !errors.Is(err, ErrAccountDisabled) {
+		t.Errorf("Login() error = %v, want %v", err, ErrAccountDisabled)
+	}
+	if _, err := service.ValidateSession(ctx, session.Token); !errors.Is(err, ErrAccountDisabled) {
+		t.Errorf("ValidateSession() error = %v, want %v", err, ErrAccountDisabled)
+	}
+}
+
+func TestAuthService_EnsureAdminUser_CreatesAdmin(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	ctx := context.Background()
+
+	created, err := service.EnsureAdminUser(ctx, "admin@example.com", "securepassword123")
+	if err != nil || !created {
+		t.Fatalf("EnsureAdminUser() = %v, %v", created, err)
+	}
+	user, _ := userRepo.GetByEmail(ctx, "admin@example.com")
+	if !user.IsAdmin() {
+		t.Errorf("seeded user role = %q, want admin", user.Role)
+	}
+}
+
+func TestAuthService_AcceptInvitation(t *testing.T) {
+	service, userRepo, _, mailer := newTestAccountEmailAuthService()
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("invited@example.com", "unknownpassword123")
+	userRepo.Create(ctx, user)
+
+	inviteURL, err := service.SendInvitation(ctx, user)
+	if err != nil {
+		t.Fatalf("SendInvitation() error = %v", err)
+	}
+	if len(mailer.InvitationURLs) != 1 || mailer.InvitationURLs[0] != inviteURL {
+		t.Fatalf("expected the invitation link to be emailed, got %v", mailer.InvitationURLs)
+	}
+	if !strings.HasPrefix(inviteURL, "https://qq.example.com/auth/accept-invite?token=") {
+		t.Errorf("unexpected invitation link %q", inviteURL)
+	}
+
+	token := linkToken(t, inviteURL)
+	if err := service.CheckPasswordResetToken(ctx, token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("invitation token accepted as a reset token: %v", err)
+	}
+	if _, err := service.AcceptInvitation(ctx, token, "short"); err == nil {
+		t.Error("expected short password to be rejected")
+	}
+
+	if _, err := service.AcceptInvitation(ctx, token, "chosenpassword123"); err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+	if !user.EmailVerified() {
+		t.Error("expected invited user to be verified")
+	}
+	if _, err := service.Login(ctx, user.Email, "chosenpassword123"); err != nil {
+		t.Errorf("Login() after accepting error = %v", err)
+	}
+	if _, err := service.AcceptInvitation(ctx, token, "anotherpassword123"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("second AcceptInvitation() error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestAuthService_SendInvitation_WithoutMailer(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	service.SetAccountEmail(NewMockUserTokenRepository(), nil, "")
+	ctx := context.Background()
+
+	user, _ := domain.NewUser("invited@example.com", "unknownpassword123")
+	userRepo.Create(ctx, user)
+
+	inviteURL, err := service.SendInvitation(ctx, user)
+	if err != nil {
+		t.Fatalf("SendInvitation() error = %v", err)
+	}
+	if !strings.HasPrefix(inviteURL, "/auth/accept-invite?token=") {
+		t.Errorf("expected a relative link to share, got %q", inviteURL)
+	}
+	if service.AccountEmailAvailable() {
+		t.Error("account email should not be available without a mailer")
+	}
+}
+
+func ssoIdentity(subject, email string) SSOIdentity {
+	return SSOIdentity{Issuer: "https://idp.example.com", Subject: subject, Email: email}
+}
+
+func TestAuthService_LoginWithSSO(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	ctx := context.Background()
+	service.SetSSO(SSOConfig{AllowedDomains: []string{"@Example.com"}, AutoProvision: true})
+
+	session, err := service.LoginWithSSO(ctx, ssoIdentity("sub-1", "new@example.com"), nil)
+	if err != nil {
+		t.Fatalf("LoginWithSSO() error = %v", err)
+	}
+	user, err := userRepo.GetByEmail(ctx, "new@example.com")
+	if err != nil {
+		t.Fatalf("expected the user to be provisioned: %v", err)
+	}
+	if user.Role != domain.UserRoleMember || !user.EmailVerified() || session.UserID != user.ID || !session.TwoFactorVerified {
+		t.Errorf("unexpected provisioned user %+v or session %+v", user, session)
+	}
+	if user.SSOIssuer != "https://idp.example.com" || user.SSOSubject != "sub-1" {
+		t.Errorf("expected the user linked to the subject, got %q %q", user.SSOIssuer, user.SSOSubject)
+	}
+
+	session, err = service.LoginWithSSO(ctx, ssoIdentity("sub-1", "new@example.com"), nil)
+	if err != nil || session.UserID != user.ID {
+		t.Errorf("expected the existing user signed in again, got %v", err)
+	}
+
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-2", "someone@gmail.com"), nil); !errors.Is(err, ErrDomainNotAllowed) {
+		t.Errorf("LoginWithSSO() error = %v, want %v", err, ErrDomainNotAllowed)
+	}
+	if _, err := userRepo.GetByEmail(ctx, "someone@gmail.com"); !apperrors.IsNotFound(err) {
+		t.Error("expected no user created for a domain that is not allowed")
+	}
+
+	user.Disable()
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-1", "new@example.com"), nil); !errors.Is(err, ErrAccountDisabled) {
+		t.Errorf("LoginWithSSO() error = %v, want %v", err, ErrAccountDisabled)
+	}
+}
+
+func TestAuthService_LoginWithSSO_MatchesSubject(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	ctx := context.Background()
+	service.SetSSO(SSOConfig{AllowedDomains: []string{"example.com"}, AutoProvision: true})
+
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-1", "jane@example.com"), nil); err != nil {
+		t.Fatalf("LoginWithSSO() error = %v", err)
+	}
+	user, _ := userRepo.GetByEmail(ctx, "jane@example.com")
+
+	// The provider changed the user's address; the subject still matches
+	session, err := service.LoginWithSSO(ctx, ssoIdentity("sub-1", "jane.doe@example.com"), nil)
+	if err != nil || session.UserID != user.ID {
+		t.Errorf("expected the subject's user signed in, got %v", err)
+	}
+
+	// Another subject claiming the address can't take the account over
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-2", "jane@example.com"), nil); !errors.Is(err, ErrSSOAccountLinked) {
+		t.Errorf("LoginWithSSO() error = %v, want %v", err, ErrSSOAccountLinked)
+	}
+}
+
+func TestAuthService_LoginWithSSO_WithoutAutoProvision(t *testing.T) {
+	service, userRepo, _ := newTestAuthService()
+	ctx := context.Background()
+	service.SetSSO(SSOConfig{})
+
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-1", "stranger@example.com"), nil); !errors.Is(err, ErrSSONoAccount) {
+		t.Errorf("LoginWithSSO() error = %v, want %v", err, ErrSSONoAccount)
+	}
+	if _, err := userRepo.GetByEmail(ctx, "stranger@example.com"); !apperrors.IsNotFound(err) {
+		t.Error("expected no user created without auto-provisioning")
+	}
+
+	existing, err := service.CreateUser(ctx, "member@example.com", "securepassword123")
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+	session, err := service.LoginWithSSO(ctx, ssoIdentity("sub-2", "member@example.com"), nil)
+	if err != nil || session.UserID != existing.ID {
+		t.Fatalf("expected the existing user signed in, got %v", err)
+	}
+	if !existing.SSOLinked() || !existing.EmailVerified() {
+		t.Error("expected the existing user linked to the subject and verified")
+	}
+}
+
+func TestAuthService_LoginWithSSO_DefaultRoleAndTwoFactor(t *testing.T) {
+	service, userRepo, _ := newTestTwoFactorAuthService()
+	ctx := context.Background()
+	service.SetSSO(SSOConfig{AutoProvision: true, DefaultRole: domain.UserRoleAdmin})
+
+	if _, err := service.LoginWithSSO(ctx, ssoIdentity("sub-boss", "boss@example.org"), nil); err != nil {
+		t.Fatalf("LoginWithSSO() error = %v", err)
+	}
+	if user, _ := userRepo.GetByEmail(ctx, "boss@example.org"); !user.IsAdmin() {
+		t.Errorf("provisioned role = %q, want admin", user.Role)
+	}
+
+	user, _ := enrollTwoFactor(t, service, userRepo, "securepassword123")
+	session, err := service.LoginWithSSO(ctx, ssoIdentity("sub-2fa", user.Email), nil)
+	if err != nil {
+		t.Fatalf("LoginWithSSO() error = %v", err)
+	}
+	if session.TwoFactorVerified {
+		t.Error("expected single sign-on to still require the second factor")
+	}
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/storage"
+)
+
+// ErrBackupInProgress is returned when a backup is requested while this
+// instance is already taking one.
+var ErrBackupInProgress = errors.New("a backup is already in progress")
+
+// DatabaseDumper writes a logical backup of the database.
+// backup.Dumper implements it with pg_dump.
+type DatabaseDumper interface {
+	Dump(ctx context.Context, w io.Writer) error
+}
+
+// backupContentType is the content type dump files are stored with.
+const backupContentType = "application/octet-stream"
+
+// BackupService takes logical backups of the database, keeps them in
+// backup storage and deletes them when their retention ends.
+type BackupService struct {
+	repo   domain.BackupRepository
+	store  storage.Store
+	dumper DatabaseDumper
+	logger *zap.Logger
+
+	// Configuration
+	retention time.Duration
+	timeout   time.Duration
+	batchSize int
+
+	// Lifecycle
+	mu      sync.Mutex
+	running bool // A backup is being taken
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// BackupServiceConfig holds configuration for the backup service.
+type BackupServiceConfig struct {
+	Retention time.Duration // How long backups are kept; 0 keeps them forever
+	Timeout   time.Duration // Longest a backup may take
+	BatchSize int           // Most expired backups deleted per cleanup
+}
+
+// DefaultBackupServiceConfig returns sensible defaults.
+func DefaultBackupServiceConfig() *BackupServiceConfig {
+	return &BackupServiceConfig{
+		Retention: 14 * 24 * time.Hour,
+		Timeout:   time.Hour,
+		BatchSize: 50,
+	}
+}
+
+// NewBackupService creates a new BackupService.
+func NewBackupService(
+	repo domain.BackupRepository,
+	store storage.Store,
+	dumper DatabaseDumper,
+	logger *zap.Logger,
+	config *BackupServiceConfig,
+) *BackupService {
+	if config == nil {
+		config = DefaultBackupServiceConfig()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackupService{
+		repo:      repo,
+		store:     store,
+		dumper:    dumper,
+		logger:    logger,
+		retention: config.Retention,
+		timeout:   config.Timeout,
+		batchSize: config.BatchSize,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Backup takes a backup and waits for it to be stored. A failed backup is
+// recorded as failed as well as returning the error.
+func (s *BackupService) Backup(ctx context.Context, trigger domain.BackupTrigger) (*domain.Backup, error) {
+	backup, err := s.begin(ctx, trigger, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer s.finish()
+	return backup, s.take(ctx, backup)
+}
+
+// StartBackup starts a backup requested by a user and returns it while it
+// is still running. Its outcome is recorded on the backup when it finishes.
+func (s *BackupService) StartBackup(ctx context.Context, requestedBy *uuid.UUID) (*domain.Backup, error) {
+	backup, err := s.begin(ctx, domain.BackupTriggerManual, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// The backup outlives the request that started it
+	started := *backup
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.finish()
+		if err := s.take(s.ctx, backup); err != nil {
+			s.logger.Error("requested backup failed", zap.String("backup_id", backup.ID.String()), zap.Error(err))
+		}
+	}()
+	return &started, nil
+}
+
+// Stop cancels a backup started by StartBackup and waits for it to be
+// recorded as failed, or for ctx to be done.
+func (s *BackupService) Stop(ctx context.Context) error {
+	s.cancel()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// List returns the most recent backups, newest first.
+func (s *BackupService) List(ctx context.Context, limit int) ([]*domain.Backup, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// Get returns a backup.
+func (s *BackupService) Get(ctx context.Context, id uuid.UUID) (*domain.Backup, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// Open returns a completed backup's file for downloading. It returns a
+// not-found error when the backup didn't complete or its file is gone.
+func (s *BackupService) Open(ctx context.Context, id uuid.UUID) (*domain.Backup, *storage.Object, error) {
+	backup, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !backup.IsAvailable() {
+		return backup, nil, apperrors.NotFound("backup file")
+	}
+
+	obj, err := s.store.Get(ctx, backup.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return backup, nil, apperrors.NotFound("backup file")
+		}
+		return backup, nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	return backup, obj, nil
+}
+
+// DeleteExpired deletes backups whose retention has ended, from storage and
+// from the list, and returns how many it deleted.
+func (s *BackupService) DeleteExpired(ctx context.Context) (int, error) {
+	expired, err := s.repo.ListExpired(ctx, time.Now().UTC(), s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired backups: %w", err)
+	}
+
+	deleted := 0
+	for _, backup := range expired {
+		if backup.Status == domain.BackupStatusCompleted {
+			if err := s.store.Delete(ctx, backup.StorageKey); err != nil {
+				return deleted, fmt.Errorf("failed to delete backup %s: %w", backup.ID, err)
+			}
+		}
+		if err := s.repo.Delete(ctx, backup.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", backup.ID, err)
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		s.logger.Info("deleted expired backups", zap.Int("count", deleted))
+	}
+	return deleted, nil
+}
+
+// begin claims the service for a backup and records it as running.
+func (s *BackupService) begin(ctx context.Context, trigger domain.BackupTrigger, requestedBy *uuid.UUID) (*domain.Backup, error) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return nil, ErrBackupInProgress
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	backup := domain.NewBackup(trigger, s.store.Name(), s.retention, requestedBy)
+	if err := s.repo.Create(ctx, backup); err != nil {
+		s.finish()
+		return nil, fmt.Errorf("failed to record backup: %w", err)
+	}
+	return backup, nil
+}
+
+func (s *BackupService) finish() {
+	s.mu.Lock()
+	s.running = false
+	s.mu.Unlock()
+}
+
+// take dumps the database to a temporary file, stores it and records the
+// outcome on backup.
+func (s *BackupService) take(ctx context.Context, backup *domain.Backup) error {
+	s.logger.Info("starting database backup",
+		zap.String("backup_id", backup.ID.String()),
+		zap.String("trigger", string(backup.Trigger)),
+	)
+	started := time.Now()
+
+	size, digest, err := s.dump(ctx, backup.StorageKey)
+	if err != nil {
+		backup.MarkFailed(err)
+	} else {
+		backup.MarkCompleted(size, digest)
+	}
+
+	// Record the outcome even if ctx was cancelled during the backup
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if updateErr := s.repo.Update(recordCtx, backup); updateErr != nil {
+		s.logger.Error("failed to record backup outcome", zap.String("backup_id", backup.ID.String()), zap.Error(updateErr))
+		if err == nil {
+			err = fmt.Errorf("failed to record backup: %w", updateErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("database backup completed",
+		zap.String("backup_id", backup.ID.String()),
+		zap.String("key", backup.StorageKey),
+		zap.Int64("size_bytes", size),
+		zap.Duration("duration", time.Since(started)),
+	)
+	return nil
+}
+
+// dump writes a backup to a temporary file, so its size is known before
+// it is stored, and stores it under key.
+func (s *BackupService) dump(ctx context.Context, key string) (int64, string, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	tmp, err := os.CreateTemp("", "backup-*")
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	hash := sha256.New()
+	if err := s.dumper.Dump(ctx, io.MultiWriter(tmp, hash)); err != nil {
+		return 0, "", err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+
+	if err := s.store.Put(ctx, key, tmp, size, backupContentType); err != nil {
+		return 0, "", fmt.Errorf("failed to store backup: %w", err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/storage"
+)
+
+// MockBackupRepository is an in-memory BackupRepository.
+type MockBackupRepository struct {
+	mu      sync.Mutex
+	backups map[uuid.UUID]*domain.Backup
+}
+
+func NewMockBackupRepository() *MockBackupRepository {
+	return &MockBackupRepository{backups: make(map[uuid.UUID]*domain.Backup)}
+}
+
+func (m *MockBackupRepository) Create(ctx context.Context, backup *domain.Backup) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *backup
+	m.backups[backup.ID] = &cp
+	return nil
+}
+
+func (m *MockBackupRepository) Update(ctx context.Context, backup *domain.Backup) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.backups[backup.ID]; !ok {
+		return apperrors.NotFound("backup")
+	}
+	cp := *backup
+	m.backups[backup.ID] = &cp
+	return nil
+}
+
+func (m *MockBackupRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Backup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.backups[id]
+	if !ok {
+		return nil, apperrors.NotFound("backup")
+	}
+	cp := *b
+	return &cp, nil
+}
+
+func (m *MockBackupRepository) List(ctx context.Context, limit int) ([]*domain.Backup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var backups []*domain.Backup
+	for _, b := range m.backups {
+		cp := *b
+		backups = append(backups, &cp)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].StartedAt.After(backups[j].StartedAt) })
+	if len(backups) > limit {
+		backups = backups[:limit]
+	}
+	return backups, nil
+}
+
+func (m *MockBackupRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Backup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var backups []*domain.Backup
+	for _, b := range m.backups {
+		if b.Status != domain.BackupStatusRunning && b.ExpiresAt != nil && !b.ExpiresAt.After(now) {
+			cp := *b
+			backups = append(backups, &cp)
+		}
+	}
+	return backups, nil
+}
+
+func (m *MockBackupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.backups[id]; !ok {
+		return apperrors.NotFound("backup")
+	}
+	delete(m.backups, id)
+	return nil
+}
+
+// fakeDumper writes a fixed dump, or fails, optionally waiting for release
+// first.
+type fakeDumper struct {
+	data    string
+	err     error
+	release chan struct{}
+}
+
+func (d *fakeDumper) Dump(ctx context.Context, w io.Writer) error {
+	if d.release != nil {
+		select {
+		case <-d.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+	_, err := io.WriteString(w, d.data)
+	return err
+}
+
+func newTestBackupService(t *testing.T, dumper DatabaseDumper) (*BackupService, *MockBackupRepository, storage.Store) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := NewMockBackupRepository()
+	return NewBackupService(repo, store, dumper, zap.NewNop(), nil), repo, store
+}
+
+func TestBackupService_BackupStoresTheDump(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestBackupService(t, &fakeDumper{data: "PGDMP dump"})
+
+	backup, err := svc.Backup(ctx, domain.BackupTriggerScheduled)
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	sum := sha256.Sum256([]byte("PGDMP dump"))
+	stored, _ := repo.Get(ctx, backup.ID)
+	if stored.Status != domain.BackupStatusCompleted || stored.SizeBytes != 10 || stored.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("stored backup = %+v, expected it completed with its size and digest", stored)
+	}
+	if stored.ExpiresAt == nil {
+		t.Error("expected the default retention applied")
+	}
+
+	_, obj, err := svc.Open(ctx, backup.ID)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer obj.Body.Close()
+	if data, _ := io.ReadAll(obj.Body); string(data) != "PGDMP dump" {
+		t.Errorf("downloaded %q", data)
+	}
+}
+
+func TestBackupService_RecordsFailedBackups(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestBackupService(t, &fakeDumper{err: errors.New("pg_dump failed: connection refused")})
+
+	backup, err := svc.Backup(ctx, domain.BackupTriggerScheduled)
+	if err == nil {
+		t.Fatal("expected the dump error returned")
+	}
+	stored, _ := repo.Get(ctx, backup.ID)
+	if stored.Status != domain.BackupStatusFailed || stored.Error == nil || *stored.Error != err.Error() {
+		t.Errorf("stored backup = %+v, expected the failure recorded", stored)
+	}
+	if _, _, err := svc.Open(ctx, backup.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("Open() error = %v, expected not found for a failed backup", err)
+	}
+}
+
+func TestBackupService_StartBackupRunsInTheBackground(t *testing.T) {
+	ctx := context.Background()
+	dumper := &fakeDumper{data: "dump", release: make(chan struct{})}
+	svc, repo, _ := newTestBackupService(t, dumper)
+	userID := uuid.New()
+
+	backup, err := svc.StartBackup(ctx, &userID)
+	if err != nil {
+		t.Fatalf("StartBackup() error = %v", err)
+	}
+	if backup.Status != domain.BackupStatusRunning || backup.Trigger != domain.BackupTriggerManual {
+		t.Errorf("StartBackup() = %+v, expected a running manual backup", backup)
+	}
+	if _, err := svc.Backup(ctx, domain.BackupTriggerScheduled); !errors.Is(err, ErrBackupInProgress) {
+		t.Errorf("Backup() during another error = %v, expected ErrBackupInProgress", err)
+	}
+
+	close(dumper.release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if stored, _ := repo.Get(ctx, backup.ID); stored.Status != domain.BackupStatusRunning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the backup to finish")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := svc.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	stored, _ := repo.Get(ctx, backup.ID)
+	if stored.Status != domain.BackupStatusCompleted || stored.RequestedBy == nil || *stored.RequestedBy != userID {
+		t.Errorf("stored backup = %+v, expected it completed and attributed", stored)
+	}
+}
+
+func TestBackupService_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, store := newTestBackupService(t, &fakeDumper{data: "dump"})
+
+	old, err := svc.Backup(ctx, domain.BackupTriggerScheduled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	old.ExpiresAt = &past
+	repo.Update(ctx, old)
+
+	time.Sleep(time.Millisecond)
+	current, err := svc.Backup(ctx, domain.BackupTriggerScheduled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := svc.DeleteExpired(ctx)
+	if err != nil || deleted != 1 {
+		t.Fatalf("DeleteExpired() = %d, %v, expected 1 deleted", deleted, err)
+	}
+	if _, err := store.Get(ctx, old.StorageKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected the expired file deleted, got %v", err)
+	}
+	if _, err := repo.Get(ctx, old.ID); !apperrors.IsNotFound(err) {
+		t.Error("expected the expired backup removed from the list")
+	}
+	if _, err := repo.Get(ctx, current.ID); err != nil {
+		t.Errorf("expected the current backup kept, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/jkindrix/quickquote/internal/bland"
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/repository"
 )
 
@@ -152,11 +154,22 @@ type BlandService struct {
 	promptRepo      domain.PromptRepository
 	settingsService *SettingsService
 	webhookURL      string
+	toolSecret      string
+	customers       CustomerLinker
+	experiments     ExperimentAssigner
+	publisher       EventPublisher
+	budget          CallBudget
+	compliance      CallCompliance
+	blocklist       OutboundBlocklist
+	callEvents      domain.CallEventRepository
 	logger          *zap.Logger
 
 	// Idempotency cache for preventing duplicate calls
 	idempotencyCache *idempotencyCache
 	idempotencyRepo  *repository.IdempotencyRepository
+
+	// writeQueue holds writes made while Bland is unreachable
+	writeQueue domain.BlandWriteRepository
 }
 
 // IdempotencyKeyTTL is the duration for which idempotency keys are cached.
@@ -184,6 +197,67 @@ func NewBlandService(
 	}
 }
 
+// SetToolSecret sets the secret Bland sends with requests from tools
+// QuickQuote serves, such as schedule_callback.
+func (s *BlandService) SetToolSecret(secret string) {
+	s.toolSecret = secret
+}
+
+// SetCustomerLinker enables linking initiated calls to the customer dialed.
+func (s *BlandService) SetCustomerLinker(linker CustomerLinker) {
+	s.customers = linker
+}
+
+// SetEventPublisher enables announcing initiated calls to the events feed
+// and outgoing webhook subscribers.
+func (s *BlandService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
+// SetCallEvents starts the status event log of each call placed.
+func (s *BlandService) SetCallEvents(events domain.CallEventRepository) {
+	s.callEvents = events
+}
+
+// ExperimentAssigner splits outbound calls between the prompts of a running
+// experiment.
+type ExperimentAssigner interface {
+	// AssignOutbound picks a variant for a call placed with promptID, or
+	// returns nil if no running experiment is testing it.
+	AssignOutbound(ctx context.Context, promptID uuid.UUID) (*domain.ExperimentVariant, error)
+	RecordAssignment(ctx context.Context, variant *domain.ExperimentVariant, callID uuid.UUID) error
+}
+
+// SetExperimentAssigner enables splitting outbound calls between the
+// variants of running prompt experiments.
+func (s *BlandService) SetExperimentAssigner(assigner ExperimentAssigner) {
+	s.experiments = assigner
+}
+
+// SetBudget rejects new calls and batches once the monthly budget's hard cap
+// is reached.
+func (s *BlandService) SetBudget(budget CallBudget) {
+	s.budget = budget
+}
+
+// SetCompliance rejects calls and batches to numbers on the do-not-call list
+// or outside the calling window.
+func (s *BlandService) SetCompliance(compliance CallCompliance) {
+	s.compliance = compliance
+}
+
+// OutboundBlocklist decides whether a number is blocked from being called.
+// BlocklistService implements it.
+type OutboundBlocklist interface {
+	CheckOutbound(ctx context.Context, phoneNumber string) error
+}
+
+// SetBlocklist rejects calls to numbers and prefixes blocked for outbound
+// calls.
+func (s *BlandService) SetBlocklist(blocklist OutboundBlocklist) {
+	s.blocklist = blocklist
+}
+
 // InitiateCallRequest contains parameters for initiating a call.
 type InitiateCallRequest struct {
 	// Required: Phone number to call (E.164 format)
@@ -206,12 +280,19 @@ type InitiateCallRequest struct {
 	// FirstSentence: Override prompt's opening
 	FirstSentence string `json:"first_sentence,omitempty"`
 
+	// Language: The caller's language (es, es-MX, etc.); defaults to the
+	// language of the country dialed. Picks the prompt's localization for it.
+	Language string `json:"language,omitempty"`
+
 	// RequestData: Custom variables for the call (accessible as {{variable}})
 	RequestData map[string]interface{} `json:"request_data,omitempty"`
 
 	// Metadata: Custom tracking data
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 
+	// FromNumber: Caller ID to call from (must be owned with the provider)
+	FromNumber string `json:"from_number,omitempty"`
+
 	// PathwayID: Use a conversation pathway instead of task
 	PathwayID string `json:"pathway_id,omitempty"`
 
@@ -226,6 +307,10 @@ type InitiateCallRequest struct {
 
 	// ScheduledTime: Schedule call for later (RFC3339 format)
 	ScheduledTime string `json:"scheduled_time,omitempty"`
+
+	// PlacedBy: The user placing the call; nil when QuickQuote places it
+	// (campaigns, retries, quote requests)
+	PlacedBy *uuid.UUID `json:"-"`
 }
 
 // InitiateCallResponse contains the result of initiating a call.
@@ -256,8 +341,24 @@ func (s *BlandService) InitiateCall(ctx context.Context, req *InitiateCallReques
 		}
 	}
 
+	if s.budget != nil {
+		if err := s.budget.CheckBudget(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if s.compliance != nil {
+		if err := s.compliance.CheckCall(ctx, req.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+	if s.blocklist != nil {
+		if err := s.blocklist.CheckOutbound(ctx, req.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build the Bland API request
-	blandReq, prompt, err := s.buildBlandRequest(ctx, req)
+	blandReq, prompt, variant, err := s.buildBlandRequest(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
@@ -296,6 +397,16 @@ func (s *BlandService) InitiateCall(ctx context.Context, req *InitiateCallReques
 	if prompt != nil {
 		promptID = &prompt.ID
 		promptName = prompt.Name
+		call.PromptID = promptID
+	}
+
+	if s.customers != nil {
+		if err := s.customers.LinkCall(ctx, call); err != nil {
+			s.logger.Warn("failed to link call to customer",
+				zap.String("bland_call_id", blandResp.CallID),
+				zap.Error(err),
+			)
+		}
 	}
 
 	// Create the call record
@@ -305,6 +416,20 @@ func (s *BlandService) InitiateCall(ctx context.Context, req *InitiateCallReques
 			zap.Error(err),
 		)
 		// Don't fail - the call was already initiated
+	} else {
+		recordCallPlaced(ctx, s.callEvents, call, req.PlacedBy, s.logger)
+		if variant != nil {
+			if err := s.experiments.RecordAssignment(ctx, variant, call.ID); err != nil {
+				// The webhook carries the variant too and records it then
+				s.logger.Warn("failed to record experiment assignment",
+					zap.String("call_id", call.ID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+		if s.publisher != nil {
+			s.publisher.Publish(ctx, domain.WebhookEventCallCreated, NewWebhookCallData(call))
+		}
 	}
 
 	// Store the full parameters in call metadata for debugging
@@ -328,12 +453,15 @@ func (s *BlandService) InitiateCall(ctx context.Context, req *InitiateCallReques
 	return response, nil
 }
 
-// buildBlandRequest constructs the Bland API request from our request.
-func (s *BlandService) buildBlandRequest(ctx context.Context, req *InitiateCallRequest) (*bland.SendCallRequest, *domain.Prompt, error) {
+// buildBlandRequest constructs the Bland API request from our request. When
+// the call's prompt is under test in a running experiment, the prompt of the
+// variant picked for the call is used instead and returned with it.
+func (s *BlandService) buildBlandRequest(ctx context.Context, req *InitiateCallRequest) (*bland.SendCallRequest, *domain.Prompt, *domain.ExperimentVariant, error) {
 	blandReq := &bland.SendCallRequest{
 		PhoneNumber: req.PhoneNumber,
 		RequestData: req.RequestData,
 		Metadata:    req.Metadata,
+		From:        req.FromNumber,
 	}
 
 	var prompt *domain.Prompt
@@ -343,11 +471,8 @@ func (s *BlandService) buildBlandRequest(ctx context.Context, req *InitiateCallR
 		var err error
 		prompt, err = s.promptRepo.GetByID(ctx, *req.PromptID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("prompt not found: %w", err)
+			return nil, nil, nil, fmt.Errorf("prompt not found: %w", err)
 		}
-
-		// Apply prompt settings
-		s.applyPromptToRequest(blandReq, prompt)
 	}
 
 	// Use default prompt if no task, pathway, or persona specified
@@ -355,8 +480,27 @@ func (s *BlandService) buildBlandRequest(ctx context.Context, req *InitiateCallR
 		var err error
 		prompt, err = s.promptRepo.GetDefault(ctx)
 		if err != nil {
-			return nil, nil, fmt.Errorf("no default prompt configured and no task provided: %w", err)
+			return nil, nil, nil, fmt.Errorf("no default prompt configured and no task provided: %w", err)
 		}
+	}
+
+	// Calls that replace the prompt's task aren't testing the prompt
+	var variant *domain.ExperimentVariant
+	if prompt != nil && req.Task == "" && req.PathwayID == "" && req.PersonaID == "" {
+		prompt, variant = s.assignExperimentVariant(ctx, prompt)
+	}
+
+	// Speak the caller's language when the prompt has been localized for it
+	language := domain.NormalizeLanguage(req.Language)
+	if language == "" {
+		language = domain.LanguageForNumber(req.PhoneNumber)
+	}
+	if prompt != nil && req.Task == "" {
+		prompt = prompt.Localize(language)
+	}
+
+	// Apply prompt settings
+	if prompt != nil {
 		s.applyPromptToRequest(blandReq, prompt)
 	}
 
@@ -385,8 +529,61 @@ func (s *BlandService) buildBlandRequest(ctx context.Context, req *InitiateCallR
 	if req.ScheduledTime != "" {
 		blandReq.StartTime = req.ScheduledTime
 	}
+	if req.Language != "" {
+		blandReq.Language = domain.NormalizeLanguage(req.Language)
+	}
+
+	// Tag the call so its outcome is credited to the variant and its quote
+	// is written in the language spoken
+	if variant != nil || blandReq.Language != "" {
+		metadata := make(map[string]interface{}, len(req.Metadata)+3)
+		for k, v := range req.Metadata {
+			metadata[k] = v
+		}
+		if variant != nil {
+			metadata[domain.ExperimentMetadataKey] = variant.ExperimentID.String()
+			metadata[domain.ExperimentVariantMetadataKey] = variant.ID.String()
+		}
+		if blandReq.Language != "" {
+			metadata[domain.LanguageMetadataKey] = blandReq.Language
+		}
+		blandReq.Metadata = metadata
+	}
+
+	return blandReq, prompt, variant, nil
+}
+
+// assignExperimentVariant returns the prompt of the experiment variant
+// picked for a call placed with prompt, and the variant. The prompt is
+// returned unchanged with no variant when it isn't under test or the
+// assignment fails.
+func (s *BlandService) assignExperimentVariant(ctx context.Context, prompt *domain.Prompt) (*domain.Prompt, *domain.ExperimentVariant) {
+	if s.experiments == nil {
+		return prompt, nil
+	}
 
-	return blandReq, prompt, nil
+	variant, err := s.experiments.AssignOutbound(ctx, prompt.ID)
+	if err != nil {
+		// Place the call with the requested prompt rather than fail it
+		s.logger.Warn("failed to assign experiment variant",
+			zap.String("prompt_id", prompt.ID.String()),
+			zap.Error(err),
+		)
+		return prompt, nil
+	}
+	if variant == nil || variant.PromptID == prompt.ID {
+		return prompt, variant
+	}
+
+	variantPrompt, err := s.promptRepo.GetByID(ctx, variant.PromptID)
+	if err != nil {
+		s.logger.Warn("failed to load experiment variant prompt",
+			zap.String("variant_id", variant.ID.String()),
+			zap.Error(err),
+		)
+		return prompt, nil
+	}
+	return variantPrompt, variant
 }
 
 // applyPromptToRequest applies a prompt's settings to a Bland request.
@@ -525,17 +722,23 @@ func (s *BlandService) GetKnowledgeBase(ctx context.Context, vectorID string) (*
 
 // CreateKnowledgeBase creates a new knowledge base from text.
 func (s *BlandService) CreateKnowledgeBase(ctx context.Context, req *bland.CreateKnowledgeBaseRequest) (*bland.CreateKnowledgeBaseResponse, error) {
-	return s.blandClient.CreateKnowledgeBase(ctx, req)
+	resp, err := s.blandClient.CreateKnowledgeBase(ctx, req)
+	if err != nil {
+		return nil, s.queueWrite(ctx, domain.BlandWriteCreateKnowledgeBase, "", req, err)
+	}
+	return resp, nil
 }
 
 // UpdateKnowledgeBase updates an existing knowledge base.
 func (s *BlandService) UpdateKnowledgeBase(ctx context.Context, vectorID string, req *bland.UpdateKnowledgeBaseRequest) error {
-	return s.blandClient.UpdateKnowledgeBase(ctx, vectorID, req)
+	err := s.blandClient.UpdateKnowledgeBase(ctx, vectorID, req)
+	return s.queueWrite(ctx, domain.BlandWriteUpdateKnowledgeBase, vectorID, req, err)
 }
 
 // DeleteKnowledgeBase removes a knowledge base.
 func (s *BlandService) DeleteKnowledgeBase(ctx context.Context, vectorID string) error {
-	return s.blandClient.DeleteKnowledgeBase(ctx, vectorID)
+	err := s.blandClient.DeleteKnowledgeBase(ctx, vectorID)
+	return s.queueWrite(ctx, domain.BlandWriteDeleteKnowledgeBase, vectorID, nil, err)
 }
 
 // ===============================================
@@ -602,6 +805,15 @@ func (s *BlandService) StoreQuoteContext(ctx context.Context, phoneNumber string
 
 // CreateBatch creates a batch of calls.
 func (s *BlandService) CreateBatch(ctx context.Context, req *bland.CreateBatchRequest) (*bland.CreateBatchResponse, error) {
+	if s.budget != nil {
+		if err := s.budget.CheckBudget(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkBatchCompliance(ctx, req.Calls); err != nil {
+		return nil, err
+	}
+
 	// Add webhook URL if not specified
 	if req.WebhookURL == "" {
 		req.WebhookURL = s.webhookURL
@@ -609,6 +821,31 @@ func (s *BlandService) CreateBatch(ctx context.Context, req *bland.CreateBatchRe
 	return s.blandClient.CreateBatch(ctx, req)
 }
 
+// checkBatchCompliance rejects a batch if any of its numbers may not be
+// called, listing every blocked number so they can be removed together.
+func (s *BlandService) checkBatchCompliance(ctx context.Context, calls []bland.BatchCallTarget) error {
+	if s.compliance == nil {
+		return nil
+	}
+
+	var blocked []string
+	for _, target := range calls {
+		err := s.compliance.CheckCall(ctx, target.PhoneNumber)
+		if cerr, ok := AsComplianceError(err); ok {
+			blocked = append(blocked, cerr.Error())
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(blocked) > 0 {
+		return apperrors.New(apperrors.CodeComplianceBlocked,
+			fmt.Sprintf("batch blocked: %d of %d calls may not be placed: %s", len(blocked), len(calls), strings.Join(blocked, "; ")))
+	}
+	return nil
+}
+
 // GetBatch retrieves batch details.
 func (s *BlandService) GetBatch(ctx context.Context, batchID string) (*bland.Batch, error) {
 	return s.blandClient.GetBatch(ctx, batchID)
@@ -648,6 +885,12 @@ func (s *BlandService) SendSMS(ctx context.Context, req *bland.SendSMSRequest) (
 	return s.blandClient.SendSMS(ctx, req)
 }
 
+// GetSMSHistory retrieves the most recent SMS messages exchanged with a
+// phone number.
+func (s *BlandService) GetSMSHistory(ctx context.Context, phoneNumber string) ([]bland.SMS, error) {
+	return s.blandClient.GetConversationHistory(ctx, phoneNumber)
+}
+
 // StartSMSConversation starts an AI-powered SMS conversation.
 func (s *BlandService) StartSMSConversation(ctx context.Context, req *bland.StartSMSConversationRequest) (*bland.StartSMSConversationResponse, error) {
 	// Add webhook URL if not specified
@@ -672,6 +915,16 @@ func (s *BlandService) SendQuoteReadySMS(ctx context.Context, phoneNumber, quote
 	return s.blandClient.SendQuoteReadySMS(ctx, phoneNumber, quoteID, amount)
 }
 
+// SendQuoteAcceptedSMS confirms to a customer that their quote acceptance was received.
+func (s *BlandService) SendQuoteAcceptedSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error) {
+	return s.blandClient.SendQuoteAcceptedSMS(ctx, phoneNumber, customerName, quoteID)
+}
+
+// SendQuoteFollowUpSMS texts a customer a reminder about their quote.
+func (s *BlandService) SendQuoteFollowUpSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error) {
+	return s.blandClient.SendQuoteFollowUp(ctx, phoneNumber, customerName, quoteID)
+}
+
 // ===============================================
 // Custom Tools Management
 // ===============================================
@@ -735,7 +988,8 @@ func (s *BlandService) SetupQuoteLookupTool(ctx context.Context) (*bland.Tool, e
 
 // SetupScheduleCallbackTool creates the schedule callback tool in Bland.
 func (s *BlandService) SetupScheduleCallbackTool(ctx context.Context) (*bland.Tool, error) {
-	toolReq := bland.NewScheduleCallbackTool(s.webhookURL)
+	serverURL := strings.TrimSuffix(s.webhookURL, "/webhook/bland")
+	toolReq := bland.NewScheduleCallbackTool(serverURL, s.toolSecret)
 	return s.blandClient.CreateTool(ctx, toolReq)
 }
 
@@ -775,7 +1029,11 @@ func (s *BlandService) ReleasePhoneNumber(ctx context.Context, numberID string)
 
 // ConfigureInboundAgent configures an inbound agent for a phone number.
 func (s *BlandService) ConfigureInboundAgent(ctx context.Context, phoneNumberID string, config *bland.InboundConfig) (*bland.PhoneNumber, error) {
-	return s.blandClient.ConfigureInboundAgent(ctx, phoneNumberID, config)
+	number, err := s.blandClient.ConfigureInboundAgent(ctx, phoneNumberID, config)
+	if err != nil {
+		return nil, s.queueWrite(ctx, domain.BlandWriteConfigureInbound, phoneNumberID, config, err)
+	}
+	return number, nil
 }
 
 // ListBlockedNumbers returns all blocked numbers.
@@ -785,12 +1043,17 @@ func (s *BlandService) ListBlockedNumbers(ctx context.Context) ([]bland.BlockedN
 
 // BlockNumber blocks a phone number.
 func (s *BlandService) BlockNumber(ctx context.Context, req *bland.BlockNumberRequest) (*bland.BlockedNumber, error) {
-	return s.blandClient.BlockNumber(ctx, req)
+	blocked, err := s.blandClient.BlockNumber(ctx, req)
+	if err != nil {
+		return nil, s.queueWrite(ctx, domain.BlandWriteBlockNumber, "", req, err)
+	}
+	return blocked, nil
 }
 
 // UnblockNumber unblocks a phone number.
 func (s *BlandService) UnblockNumber(ctx context.Context, blockedID string) error {
-	return s.blandClient.UnblockNumber(ctx, blockedID)
+	err := s.blandClient.UnblockNumber(ctx, blockedID)
+	return s.queueWrite(ctx, domain.BlandWriteUnblockNumber, blockedID, nil, err)
 }
 
 // ===============================================
@@ -1011,7 +1274,8 @@ func (s *BlandService) GetUsageLimits(ctx context.Context) (*bland.UsageLimits,
 
 // SetUsageLimit sets a usage limit.
 func (s *BlandService) SetUsageLimit(ctx context.Context, limitType string, value float64) error {
-	return s.blandClient.SetUsageLimit(ctx, limitType, value)
+	err := s.blandClient.SetUsageLimit(ctx, limitType, value)
+	return s.queueWrite(ctx, domain.BlandWriteSetUsageLimit, "", usageLimitArgs{Type: limitType, Value: value}, err)
 }
 
 // GetPricing retrieves pricing information.
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+func TestBlandService_BuildRequestLocalizesPrompt(t *testing.T) {
+	prompt := domain.NewPrompt("Intake", "Collect the caller's project details.")
+	prompt.FirstSentence = "Hi, thanks for calling!"
+	prompt.Localizations = map[string]*domain.PromptLocalization{
+		"es": {Task: "Recopila los detalles del proyecto.", FirstSentence: "¡Hola, gracias por llamar!"},
+		"fr": {Task: "Recueillez les détails du projet."},
+	}
+	svc := NewBlandService(nil, nil, NewMockPromptRepository(prompt), nil, "", nil, zap.NewNop())
+
+	tests := []struct {
+		name         string
+		req          *InitiateCallRequest
+		wantLanguage string
+		wantTask     string
+		wantGreeting string
+		wantTagged   string
+	}{
+		{
+			name:         "country of the number dialed",
+			req:          &InitiateCallRequest{PhoneNumber: "+525512345678", PromptID: &prompt.ID},
+			wantLanguage: "es-MX",
+			wantTask:     "Recopila los detalles del proyecto.",
+			wantGreeting: "¡Hola, gracias por llamar!",
+			wantTagged:   "es-MX",
+		},
+		{
+			name:         "caller language overrides the country",
+			req:          &InitiateCallRequest{PhoneNumber: "+14155550100", PromptID: &prompt.ID, Language: "fr_CA"},
+			wantLanguage: "fr-CA",
+			wantTask:     "Recueillez les détails du projet.",
+			wantGreeting: "Hi, thanks for calling!",
+			wantTagged:   "fr-CA",
+		},
+		{
+			name:         "no localization falls back to the prompt",
+			req:          &InitiateCallRequest{PhoneNumber: "+4930123456", PromptID: &prompt.ID},
+			wantLanguage: "en-US",
+			wantTask:     "Collect the caller's project details.",
+			wantGreeting: "Hi, thanks for calling!",
+			wantTagged:   "en-US",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _, _, err := svc.buildBlandRequest(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("buildBlandRequest() error = %v", err)
+			}
+			if req.Language != tt.wantLanguage || req.Task != tt.wantTask || req.FirstSentence != tt.wantGreeting {
+				t.Errorf("got language %q, task %q, greeting %q", req.Language, req.Task, req.FirstSentence)
+			}
+			if got := req.Metadata[domain.LanguageMetadataKey]; got != tt.wantTagged {
+				t.Errorf("call tagged with language %v, want %q", got, tt.wantTagged)
+			}
+		})
+	}
+}
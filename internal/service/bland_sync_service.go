@@ -0,0 +1,659 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// blandMissingError is the sync error given to local records whose Bland
+// entity no longer exists.
+const blandMissingError = "no longer exists in Bland"
+
+// BlandEntitySource lists the Bland entities kept in the local cache.
+// BlandService implements it.
+type BlandEntitySource interface {
+	ListVoices(ctx context.Context) ([]bland.Voice, error)
+	ListPersonas(ctx context.Context) ([]bland.Persona, error)
+	ListPathways(ctx context.Context) ([]bland.Pathway, error)
+	ListKnowledgeBases(ctx context.Context) ([]bland.KnowledgeBase, error)
+	ListPhoneNumbers(ctx context.Context, req *bland.ListPhoneNumbersRequest) ([]bland.PhoneNumber, error)
+}
+
+// BlandWriteReplayer replays Bland writes queued while Bland was
+// unreachable. BlandService implements it.
+type BlandWriteReplayer interface {
+	ReplayQueuedWrites(ctx context.Context) ([]domain.BlandEntityKind, error)
+}
+
+// BlandSyncService keeps a local copy of Bland voices, personas, pathways,
+// knowledge bases and phone numbers. List endpoints and admin pages read the
+// copy instead of calling Bland on every request; a worker refreshes it
+// periodically and callers can force a refresh.
+type BlandSyncService struct {
+	source      BlandEntitySource
+	repo        domain.BlandEntityRepository
+	kbRepo      domain.KnowledgeBaseRepository
+	pathwayRepo domain.PathwayRepository
+	personaRepo domain.PersonaRepository
+	replayer    BlandWriteReplayer
+	logger      *zap.Logger
+
+	interval       time.Duration
+	replayInterval time.Duration
+
+	// syncMu serializes syncs so a forced refresh doesn't race the worker.
+	syncMu sync.Mutex
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// BlandSyncServiceConfig holds configuration for the Bland sync service.
+type BlandSyncServiceConfig struct {
+	Interval       time.Duration // How often every kind is synced from Bland
+	ReplayInterval time.Duration // How often queued writes are retried
+}
+
+// DefaultBlandSyncServiceConfig returns sensible defaults.
+func DefaultBlandSyncServiceConfig() *BlandSyncServiceConfig {
+	return &BlandSyncServiceConfig{
+		Interval:       15 * time.Minute,
+		ReplayInterval: 30 * time.Second,
+	}
+}
+
+// NewBlandSyncService creates a new BlandSyncService.
+func NewBlandSyncService(
+	source BlandEntitySource,
+	repo domain.BlandEntityRepository,
+	logger *zap.Logger,
+	config *BlandSyncServiceConfig,
+) *BlandSyncService {
+	if config == nil {
+		config = DefaultBlandSyncServiceConfig()
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = DefaultBlandSyncServiceConfig().Interval
+	}
+	replayInterval := config.ReplayInterval
+	if replayInterval <= 0 {
+		replayInterval = DefaultBlandSyncServiceConfig().ReplayInterval
+	}
+
+	return &BlandSyncService{
+		source:         source,
+		repo:           repo,
+		logger:         logger,
+		interval:       interval,
+		replayInterval: replayInterval,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// SetLocalRepositories mirrors synced knowledge bases, pathways and personas
+// into their local tables, linked by Bland ID. Any of the repositories may be
+// nil.
+func (s *BlandSyncService) SetLocalRepositories(kbRepo domain.KnowledgeBaseRepository, pathwayRepo domain.PathwayRepository, personaRepo domain.PersonaRepository) {
+	s.kbRepo = kbRepo
+	s.pathwayRepo = pathwayRepo
+	s.personaRepo = personaRepo
+}
+
+// SetWriteReplayer makes the worker replay queued writes, resyncing the
+// kinds they change.
+func (s *BlandSyncService) SetWriteReplayer(replayer BlandWriteReplayer) {
+	s.replayer = replayer
+}
+
+// Start begins syncing every kind periodically.
+func (s *BlandSyncService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("bland sync worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting bland sync worker", zap.Duration("interval", s.interval))
+
+	s.wg.Add(1)
+	go s.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for an in-flight sync to finish.
+func (s *BlandSyncService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping bland sync worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("bland sync worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("bland sync worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// loop syncs every kind until stopped.
+func (s *BlandSyncService) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	replayTicker := time.NewTicker(s.replayInterval)
+	defer replayTicker.Stop()
+
+	s.untilStopped(s.replayAndSyncAll)
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.untilStopped(s.replayAndSyncAll)
+		case <-replayTicker.C:
+			s.untilStopped(s.replay)
+		}
+	}
+}
+
+// untilStopped runs fn, aborting it when the worker stops.
+func (s *BlandSyncService) untilStopped(fn func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	fn(ctx)
+}
+
+// replayAndSyncAll replays queued writes before syncing every kind, so the
+// sync picks up their changes.
+func (s *BlandSyncService) replayAndSyncAll(ctx context.Context) {
+	if s.replayer != nil {
+		if _, err := s.replayer.ReplayQueuedWrites(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Warn("failed to replay queued bland writes", zap.Error(err))
+		}
+	}
+	if err := s.SyncAll(ctx); err != nil && ctx.Err() == nil {
+		s.logger.Warn("bland sync incomplete", zap.Error(err))
+	}
+}
+
+// replay replays queued writes and resyncs the kinds they changed.
+func (s *BlandSyncService) replay(ctx context.Context) {
+	if s.replayer == nil {
+		return
+	}
+	kinds, err := s.replayer.ReplayQueuedWrites(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Warn("failed to replay queued bland writes", zap.Error(err))
+	}
+	for _, kind := range kinds {
+		if ctx.Err() != nil {
+			return
+		}
+		s.Refresh(ctx, kind)
+	}
+}
+
+// SyncAll syncs every kind. A failure of one kind doesn't stop the others;
+// the returned error joins every failure.
+func (s *BlandSyncService) SyncAll(ctx context.Context) error {
+	var errs []error
+	for _, kind := range domain.BlandEntityKinds {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := s.Sync(ctx, kind); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sync replaces the cached entities of a kind with Bland's current list.
+// When Bland can't be reached the cache is kept and the error is recorded in
+// the kind's sync state.
+func (s *BlandSyncService) Sync(ctx context.Context, kind domain.BlandEntityKind) (*domain.BlandSyncState, error) {
+	if !kind.IsValid() {
+		return nil, apperrors.InvalidFormat("kind", "voices, personas, pathways, knowledge_bases or phone_numbers")
+	}
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	items, mirror, err := s.fetch(ctx, kind)
+	now := time.Now().UTC()
+	if err != nil {
+		if recordErr := s.repo.RecordSyncError(ctx, kind, err.Error(), now); recordErr != nil {
+			s.logger.Warn("failed to record bland sync error", zap.String("kind", string(kind)), zap.Error(recordErr))
+		}
+		return nil, fmt.Errorf("failed to list %s from Bland: %w", kind, err)
+	}
+
+	entities, etag, err := encodeBlandEntities(kind, items, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Replace(ctx, kind, entities, etag, now); err != nil {
+		return nil, fmt.Errorf("failed to cache %s: %w", kind, err)
+	}
+	if mirror != nil {
+		mirror(ctx, now)
+	}
+
+	s.logger.Debug("synced bland entities",
+		zap.String("kind", string(kind)),
+		zap.Int("count", len(entities)),
+	)
+	return &domain.BlandSyncState{
+		Kind:        kind,
+		ETag:        etag,
+		ItemCount:   len(entities),
+		SyncedAt:    &now,
+		AttemptedAt: now,
+	}, nil
+}
+
+// SyncStates returns the sync state of every kind synced so far.
+func (s *BlandSyncService) SyncStates(ctx context.Context) ([]*domain.BlandSyncState, error) {
+	return s.repo.ListSyncStates(ctx)
+}
+
+// ListVoices returns the cached voices, syncing first when refresh is set or
+// the cache has never been filled. If that sync fails but an earlier one
+// succeeded, the cached voices are returned with a stale state (see
+// BlandSyncState.IsStale) rather than an error.
+func (s *BlandSyncService) ListVoices(ctx context.Context, refresh bool) ([]bland.Voice, *domain.BlandSyncState, error) {
+	return listCached[bland.Voice](ctx, s, domain.BlandEntityVoice, refresh)
+}
+
+// ListPersonas returns the cached personas.
+func (s *BlandSyncService) ListPersonas(ctx context.Context, refresh bool) ([]bland.Persona, *domain.BlandSyncState, error) {
+	return listCached[bland.Persona](ctx, s, domain.BlandEntityPersona, refresh)
+}
+
+// ListPathways returns the cached pathways.
+func (s *BlandSyncService) ListPathways(ctx context.Context, refresh bool) ([]bland.Pathway, *domain.BlandSyncState, error) {
+	return listCached[bland.Pathway](ctx, s, domain.BlandEntityPathway, refresh)
+}
+
+// ListKnowledgeBases returns the cached knowledge bases.
+func (s *BlandSyncService) ListKnowledgeBases(ctx context.Context, refresh bool) ([]bland.KnowledgeBase, *domain.BlandSyncState, error) {
+	return listCached[bland.KnowledgeBase](ctx, s, domain.BlandEntityKnowledgeBase, refresh)
+}
+
+// ListPhoneNumbers returns the cached phone numbers.
+func (s *BlandSyncService) ListPhoneNumbers(ctx context.Context, refresh bool) ([]bland.PhoneNumber, *domain.BlandSyncState, error) {
+	return listCached[bland.PhoneNumber](ctx, s, domain.BlandEntityPhoneNumber, refresh)
+}
+
+// Refresh resyncs a kind after it was changed through Bland, logging rather
+// than returning failures since the change itself succeeded.
+func (s *BlandSyncService) Refresh(ctx context.Context, kind domain.BlandEntityKind) {
+	if _, err := s.Sync(ctx, kind); err != nil {
+		s.logger.Warn("failed to refresh bland cache", zap.String("kind", string(kind)), zap.Error(err))
+	}
+}
+
+// listCached decodes the cached entities of a kind.
+func listCached[T any](ctx context.Context, s *BlandSyncService, kind domain.BlandEntityKind, refresh bool) ([]T, *domain.BlandSyncState, error) {
+	state, err := s.repo.GetSyncState(ctx, kind)
+	if err != nil && !apperrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if refresh || !state.HasSynced() {
+		synced, err := s.Sync(ctx, kind)
+		switch {
+		case err == nil:
+			state = synced
+		case state.HasSynced():
+			s.logger.Warn("bland unreachable, serving cached entities",
+				zap.String("kind", string(kind)),
+				zap.Error(err),
+			)
+			state.LastError = err.Error()
+		default:
+			return nil, nil, err
+		}
+	}
+
+	entities, err := s.repo.List(ctx, kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]T, 0, len(entities))
+	for _, entity := range entities {
+		var item T
+		if err := json.Unmarshal(entity.Payload, &item); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode cached %s %s: %w", kind, entity.BlandID, err)
+		}
+		items = append(items, item)
+	}
+	return items, state, nil
+}
+
+// blandItem is one entity fetched from Bland.
+type blandItem struct {
+	id    string
+	value interface{}
+}
+
+// fetch lists a kind from Bland. The returned mirror, when not nil, copies
+// the entities into their local table.
+func (s *BlandSyncService) fetch(ctx context.Context, kind domain.BlandEntityKind) ([]blandItem, func(context.Context, time.Time), error) {
+	var items []blandItem
+	switch kind {
+	case domain.BlandEntityVoice:
+		voices, err := s.source.ListVoices(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, v := range voices {
+			id := v.ID
+			if id == "" && v.VoiceID != 0 {
+				id = strconv.Itoa(v.VoiceID)
+			}
+			items = append(items, blandItem{id: id, value: v})
+		}
+		return items, nil, nil
+
+	case domain.BlandEntityPersona:
+		personas, err := s.source.ListPersonas(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range personas {
+			items = append(items, blandItem{id: p.ID, value: p})
+		}
+		return items, func(ctx context.Context, now time.Time) { s.mirrorPersonas(ctx, personas, now) }, nil
+
+	case domain.BlandEntityPathway:
+		pathways, err := s.source.ListPathways(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, p := range pathways {
+			items = append(items, blandItem{id: p.ID, value: p})
+		}
+		return items, func(ctx context.Context, now time.Time) { s.mirrorPathways(ctx, pathways, now) }, nil
+
+	case domain.BlandEntityKnowledgeBase:
+		kbs, err := s.source.ListKnowledgeBases(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, kb := range kbs {
+			items = append(items, blandItem{id: kb.VectorID, value: kb})
+		}
+		return items, func(ctx context.Context, now time.Time) { s.mirrorKnowledgeBases(ctx, kbs, now) }, nil
+
+	case domain.BlandEntityPhoneNumber:
+		numbers, err := s.source.ListPhoneNumbers(ctx, &bland.ListPhoneNumbersRequest{})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, n := range numbers {
+			id := n.ID
+			if id == "" {
+				id = n.PhoneNumber
+			}
+			items = append(items, blandItem{id: id, value: n})
+		}
+		return items, nil, nil
+	}
+	return nil, nil, fmt.Errorf("unknown bland entity kind %q", kind)
+}
+
+// encodeBlandEntities encodes fetched items for the cache and computes their
+// ETag. Items without an ID are keyed by position; repeated IDs keep the
+// first occurrence.
+func encodeBlandEntities(kind domain.BlandEntityKind, items []blandItem, now time.Time) ([]*domain.BlandEntity, string, error) {
+	hash := sha256.New()
+	entities := make([]*domain.BlandEntity, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		id := item.id
+		if id == "" {
+			id = "#" + strconv.Itoa(i)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		payload, err := json.Marshal(item.value)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode %s %s: %w", kind, id, err)
+		}
+		hash.Write(payload)
+		hash.Write([]byte{'\n'})
+
+		entities = append(entities, &domain.BlandEntity{
+			Kind:     kind,
+			BlandID:  id,
+			Position: len(entities),
+			Payload:  payload,
+			SyncedAt: now,
+		})
+	}
+	return entities, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// mirrorKnowledgeBases copies synced knowledge bases into the local table.
+// Failures are logged; the cache is already up to date.
+func (s *BlandSyncService) mirrorKnowledgeBases(ctx context.Context, kbs []bland.KnowledgeBase, now time.Time) {
+	if s.kbRepo == nil {
+		return
+	}
+
+	seen := make(map[string]bool, len(kbs))
+	for _, remote := range kbs {
+		if remote.VectorID == "" {
+			continue
+		}
+		seen[remote.VectorID] = true
+
+		local, err := s.kbRepo.GetByBlandID(ctx, remote.VectorID)
+		if err != nil {
+			s.logger.Warn("failed to load local knowledge base", zap.String("bland_id", remote.VectorID), zap.Error(err))
+			continue
+		}
+		isNew := local == nil
+		if isNew {
+			local = domain.NewKnowledgeBase(remote.Name, remote.Description)
+			local.BlandID = remote.VectorID
+			local.VectorDBID = remote.VectorID
+		}
+		local.Name = remote.Name
+		local.Description = remote.Description
+		local.Status = domain.KnowledgeBaseStatusActive
+		local.SyncError = ""
+		local.LastSyncedAt = &now
+
+		if isNew {
+			err = s.kbRepo.Create(ctx, local)
+		} else {
+			err = s.kbRepo.Update(ctx, local)
+		}
+		if err != nil {
+			s.logger.Warn("failed to save local knowledge base", zap.String("bland_id", remote.VectorID), zap.Error(err))
+		}
+	}
+
+	locals, err := s.kbRepo.List(ctx, nil)
+	if err != nil {
+		s.logger.Warn("failed to list local knowledge bases", zap.Error(err))
+		return
+	}
+	for _, local := range locals {
+		if local.BlandID == "" || seen[local.BlandID] || local.SyncError == blandMissingError {
+			continue
+		}
+		if err := s.kbRepo.MarkSyncError(ctx, local.ID, blandMissingError); err != nil {
+			s.logger.Warn("failed to mark knowledge base missing", zap.String("id", local.ID.String()), zap.Error(err))
+		}
+	}
+}
+
+// mirrorPathways copies synced pathways into the local table. Nodes and
+// edges are left alone since they are edited locally.
+func (s *BlandSyncService) mirrorPathways(ctx context.Context, pathways []bland.Pathway, now time.Time) {
+	if s.pathwayRepo == nil {
+		return
+	}
+
+	seen := make(map[string]bool, len(pathways))
+	for _, remote := range pathways {
+		if remote.ID == "" {
+			continue
+		}
+		seen[remote.ID] = true
+
+		local, err := s.pathwayRepo.GetByBlandID(ctx, remote.ID)
+		if err != nil {
+			s.logger.Warn("failed to load local pathway", zap.String("bland_id", remote.ID), zap.Error(err))
+			continue
+		}
+		isNew := local == nil
+		if isNew {
+			local = domain.NewPathway(remote.Name, remote.Description)
+			local.BlandID = remote.ID
+		}
+		local.Name = remote.Name
+		local.Description = remote.Description
+		if remote.Version > 0 {
+			local.Version = remote.Version
+		}
+		local.IsPublished = remote.IsProduction
+		local.Status = domain.PathwayStatusActive
+		local.SyncError = ""
+		local.LastSyncedAt = &now
+
+		if isNew {
+			err = s.pathwayRepo.Create(ctx, local)
+		} else {
+			err = s.pathwayRepo.Update(ctx, local)
+		}
+		if err != nil {
+			s.logger.Warn("failed to save local pathway", zap.String("bland_id", remote.ID), zap.Error(err))
+		}
+	}
+
+	locals, err := s.pathwayRepo.List(ctx, nil)
+	if err != nil {
+		s.logger.Warn("failed to list local pathways", zap.Error(err))
+		return
+	}
+	for _, local := range locals {
+		if local.BlandID == "" || seen[local.BlandID] || local.SyncError == blandMissingError {
+			continue
+		}
+		if err := s.pathwayRepo.MarkSyncError(ctx, local.ID, blandMissingError); err != nil {
+			s.logger.Warn("failed to mark pathway missing", zap.String("id", local.ID.String()), zap.Error(err))
+		}
+	}
+}
+
+// mirrorPersonas copies synced personas into the local table.
+func (s *BlandSyncService) mirrorPersonas(ctx context.Context, personas []bland.Persona, now time.Time) {
+	if s.personaRepo == nil {
+		return
+	}
+
+	seen := make(map[string]bool, len(personas))
+	for _, remote := range personas {
+		if remote.ID == "" {
+			continue
+		}
+		seen[remote.ID] = true
+
+		local, err := s.personaRepo.GetByBlandID(ctx, remote.ID)
+		if err != nil {
+			s.logger.Warn("failed to load local persona", zap.String("bland_id", remote.ID), zap.Error(err))
+			continue
+		}
+		isNew := local == nil
+		if isNew {
+			local = domain.NewPersona(remote.Name, remote.Description)
+			local.BlandID = remote.ID
+		}
+		local.Name = remote.Name
+		local.Description = remote.Description
+		if remote.Voice != "" {
+			local.Voice = remote.Voice
+		}
+		if remote.Language != "" {
+			local.Language = remote.Language
+		}
+		local.SystemPrompt = remote.Prompt
+		local.KnowledgeBases = remote.KnowledgeBaseIDs
+		local.Tools = remote.Tools
+		local.Status = domain.PersonaStatusActive
+		local.SyncError = ""
+		local.LastSyncedAt = &now
+
+		if isNew {
+			err = s.personaRepo.Create(ctx, local)
+		} else {
+			err = s.personaRepo.Update(ctx, local)
+		}
+		if err != nil {
+			s.logger.Warn("failed to save local persona", zap.String("bland_id", remote.ID), zap.Error(err))
+		}
+	}
+
+	locals, err := s.personaRepo.List(ctx, nil)
+	if err != nil {
+		s.logger.Warn("failed to list local personas", zap.Error(err))
+		return
+	}
+	for _, local := range locals {
+		if local.BlandID == "" || seen[local.BlandID] || local.SyncError == blandMissingError {
+			continue
+		}
+		if err := s.personaRepo.MarkSyncError(ctx, local.ID, blandMissingError); err != nil {
+			s.logger.Warn("failed to mark persona missing", zap.String("id", local.ID.String()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockBlandEntityRepository is an in-memory BlandEntityRepository.
+type MockBlandEntityRepository struct {
+	mu       sync.Mutex
+	entities map[domain.BlandEntityKind][]*domain.BlandEntity
+	states   map[domain.BlandEntityKind]*domain.BlandSyncState
+}
+
+func NewMockBlandEntityRepository() *MockBlandEntityRepository {
+	return &MockBlandEntityRepository{
+		entities: make(map[domain.BlandEntityKind][]*domain.BlandEntity),
+		states:   make(map[domain.BlandEntityKind]*domain.BlandSyncState),
+	}
+}
+
+func (m *MockBlandEntityRepository) Replace(ctx context.Context, kind domain.BlandEntityKind, entities []*domain.BlandEntity, etag string, syncedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entities[kind] = entities
+	m.states[kind] = &domain.BlandSyncState{
+		Kind:        kind,
+		ETag:        etag,
+		ItemCount:   len(entities),
+		SyncedAt:    &syncedAt,
+		AttemptedAt: syncedAt,
+	}
+	return nil
+}
+
+func (m *MockBlandEntityRepository) List(ctx context.Context, kind domain.BlandEntityKind) ([]*domain.BlandEntity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entities[kind], nil
+}
+
+func (m *MockBlandEntityRepository) GetSyncState(ctx context.Context, kind domain.BlandEntityKind) (*domain.BlandSyncState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[kind]
+	if !ok {
+		return nil, apperrors.NotFound("bland sync state")
+	}
+	cp := *state
+	return &cp, nil
+}
+
+func (m *MockBlandEntityRepository) ListSyncStates(ctx context.Context) ([]*domain.BlandSyncState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var states []*domain.BlandSyncState
+	for _, kind := range domain.BlandEntityKinds {
+		if state, ok := m.states[kind]; ok {
+			cp := *state
+			states = append(states, &cp)
+		}
+	}
+	return states, nil
+}
+
+func (m *MockBlandEntityRepository) RecordSyncError(ctx context.Context, kind domain.BlandEntityKind, message string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[kind]
+	if !ok {
+		state = &domain.BlandSyncState{Kind: kind}
+		m.states[kind] = state
+	}
+	state.AttemptedAt = at
+	state.LastError = message
+	return nil
+}
+
+// fakeBlandSource returns fixed lists and counts calls.
+type fakeBlandSource struct {
+	mu     sync.Mutex
+	voices []bland.Voice
+	kbs    []bland.KnowledgeBase
+	err    error
+	calls  int
+}
+
+func (f *fakeBlandSource) call() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.err
+}
+
+func (f *fakeBlandSource) ListVoices(ctx context.Context) ([]bland.Voice, error) {
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	return f.voices, nil
+}
+
+func (f *fakeBlandSource) ListPersonas(ctx context.Context) ([]bland.Persona, error) {
+	return nil, f.call()
+}
+
+func (f *fakeBlandSource) ListPathways(ctx context.Context) ([]bland.Pathway, error) {
+	return nil, f.call()
+}
+
+func (f *fakeBlandSource) ListKnowledgeBases(ctx context.Context) ([]bland.KnowledgeBase, error) {
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	return f.kbs, nil
+}
+
+func (f *fakeBlandSource) ListPhoneNumbers(ctx context.Context, req *bland.ListPhoneNumbersRequest) ([]bland.PhoneNumber, error) {
+	return nil, f.call()
+}
+
+func (f *fakeBlandSource) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// fakeKnowledgeBaseRepo stores knowledge bases in memory. Methods the sync
+// doesn't use panic through the embedded nil interface.
+type fakeKnowledgeBaseRepo struct {
+	domain.KnowledgeBaseRepository
+	kbs map[uuid.UUID]*domain.KnowledgeBase
+}
+
+func (f *fakeKnowledgeBaseRepo) Create(ctx context.Context, kb *domain.KnowledgeBase) error {
+	cp := *kb
+	f.kbs[kb.ID] = &cp
+	return nil
+}
+
+func (f *fakeKnowledgeBaseRepo) Update(ctx context.Context, kb *domain.KnowledgeBase) error {
+	cp := *kb
+	f.kbs[kb.ID] = &cp
+	return nil
+}
+
+func (f *fakeKnowledgeBaseRepo) GetByBlandID(ctx context.Context, blandID string) (*domain.KnowledgeBase, error) {
+	for _, kb := range f.kbs {
+		if kb.BlandID == blandID {
+			cp := *kb
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeKnowledgeBaseRepo) List(ctx context.Context, filter *domain.KnowledgeBaseFilter) ([]*domain.KnowledgeBase, error) {
+	var kbs []*domain.KnowledgeBase
+	for _, kb := range f.kbs {
+		cp := *kb
+		kbs = append(kbs, &cp)
+	}
+	return kbs, nil
+}
+
+func (f *fakeKnowledgeBaseRepo) MarkSyncError(ctx context.Context, id uuid.UUID, errMsg string) error {
+	f.kbs[id].Status = domain.KnowledgeBaseStatusError
+	f.kbs[id].SyncError = errMsg
+	return nil
+}
+
+func newTestBlandSyncService(source BlandEntitySource) (*BlandSyncService, *MockBlandEntityRepository) {
+	repo := NewMockBlandEntityRepository()
+	return NewBlandSyncService(source, repo, zap.NewNop(), nil), repo
+}
+
+func TestBlandSyncService_ListFillsCacheOnce(t *testing.T) {
+	source := &fakeBlandSource{voices: []bland.Voice{
+		{ID: "v1", Name: "Maya"},
+		{ID: "v2", Name: "Josh"},
+	}}
+	svc, _ := newTestBlandSyncService(source)
+	ctx := context.Background()
+
+	voices, state, err := svc.ListVoices(ctx, false)
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+	if len(voices) != 2 || voices[0].Name != "Maya" || voices[1].Name != "Josh" {
+		t.Errorf("voices = %+v, want Maya then Josh", voices)
+	}
+	if state.ETag == "" || state.ItemCount != 2 || !state.HasSynced() {
+		t.Errorf("state = %+v, want a synced state with an ETag", state)
+	}
+
+	// Later lists are served from the cache.
+	again, againState, err := svc.ListVoices(ctx, false)
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+	if len(again) != 2 {
+		t.Errorf("cached voices = %d, want 2", len(again))
+	}
+	if againState.ETag != state.ETag {
+		t.Errorf("ETag changed without a sync: %q -> %q", state.ETag, againState.ETag)
+	}
+	if source.callCount() != 1 {
+		t.Errorf("Bland calls = %d, want 1", source.callCount())
+	}
+}
+
+func TestBlandSyncService_RefreshChangesETagOnlyWhenDataChanges(t *testing.T) {
+	source := &fakeBlandSource{voices: []bland.Voice{{ID: "v1", Name: "Maya"}}}
+	svc, _ := newTestBlandSyncService(source)
+	ctx := context.Background()
+
+	_, first, err := svc.ListVoices(ctx, false)
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+
+	_, same, err := svc.ListVoices(ctx, true)
+	if err != nil {
+		t.Fatalf("ListVoices(refresh) error = %v", err)
+	}
+	if same.ETag != first.ETag {
+		t.Errorf("ETag changed for identical data")
+	}
+
+	source.voices = append(source.voices, bland.Voice{ID: "v2", Name: "Josh"})
+	voices, changed, err := svc.ListVoices(ctx, true)
+	if err != nil {
+		t.Fatalf("ListVoices(refresh) error = %v", err)
+	}
+	if changed.ETag == first.ETag {
+		t.Errorf("ETag unchanged after data changed")
+	}
+	if len(voices) != 2 {
+		t.Errorf("voices = %d, want 2", len(voices))
+	}
+	if source.callCount() != 3 {
+		t.Errorf("Bland calls = %d, want 3", source.callCount())
+	}
+}
+
+func TestBlandSyncService_SyncErrorKeepsCache(t *testing.T) {
+	source := &fakeBlandSource{voices: []bland.Voice{{ID: "v1", Name: "Maya"}}}
+	svc, repo := newTestBlandSyncService(source)
+	ctx := context.Background()
+
+	if _, err := svc.Sync(ctx, domain.BlandEntityVoice); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	source.err = errors.New("bland unavailable")
+	if _, err := svc.Sync(ctx, domain.BlandEntityVoice); err == nil {
+		t.Fatal("Sync() error = nil, want an error")
+	}
+
+	state, err := repo.GetSyncState(ctx, domain.BlandEntityVoice)
+	if err != nil {
+		t.Fatalf("GetSyncState() error = %v", err)
+	}
+	if state.LastError == "" {
+		t.Error("LastError not recorded")
+	}
+
+	voices, _, err := svc.ListVoices(ctx, false)
+	if err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+	if len(voices) != 1 {
+		t.Errorf("voices = %d, want the cached voice", len(voices))
+	}
+}
+
+func TestBlandSyncService_ListWithoutCacheReturnsSyncError(t *testing.T) {
+	source := &fakeBlandSource{err: errors.New("bland unavailable")}
+	svc, _ := newTestBlandSyncService(source)
+
+	if _, _, err := svc.ListVoices(context.Background(), false); err == nil {
+		t.Fatal("ListVoices() error = nil, want the sync error")
+	}
+}
+
+func TestBlandSyncService_SyncDeduplicatesIDs(t *testing.T) {
+	source := &fakeBlandSource{voices: []bland.Voice{
+		{ID: "v1", Name: "Maya"},
+		{ID: "v1", Name: "Maya again"},
+		{Name: "No ID"},
+	}}
+	svc, _ := newTestBlandSyncService(source)
+
+	state, err := svc.Sync(context.Background(), domain.BlandEntityVoice)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if state.ItemCount != 2 {
+		t.Errorf("ItemCount = %d, want 2", state.ItemCount)
+	}
+}
+
+func TestBlandSyncService_SyncRejectsUnknownKind(t *testing.T) {
+	svc, _ := newTestBlandSyncService(&fakeBlandSource{})
+
+	_, err := svc.Sync(context.Background(), domain.BlandEntityKind("tools"))
+	if !apperrors.IsUserError(err) {
+		t.Errorf("Sync() error = %v, want a validation error", err)
+	}
+}
+
+func TestBlandSyncService_SyncAllContinuesPastFailures(t *testing.T) {
+	source := &fakeBlandSource{err: errors.New("bland unavailable")}
+	svc, repo := newTestBlandSyncService(source)
+	ctx := context.Background()
+
+	if err := svc.SyncAll(ctx); err == nil {
+		t.Fatal("SyncAll() error = nil, want an error")
+	}
+	if source.callCount() != len(domain.BlandEntityKinds) {
+		t.Errorf("Bland calls = %d, want one per kind", source.callCount())
+	}
+	states, _ := repo.ListSyncStates(ctx)
+	if len(states) != len(domain.BlandEntityKinds) {
+		t.Errorf("states = %d, want one per kind", len(states))
+	}
+}
+
+func TestBlandSyncService_MirrorsKnowledgeBases(t *testing.T) {
+	source := &fakeBlandSource{kbs: []bland.KnowledgeBase{
+		{VectorID: "kb-1", Name: "Pricing", Description: "Price list"},
+	}}
+	svc, _ := newTestBlandSyncService(source)
+	kbRepo := &fakeKnowledgeBaseRepo{kbs: make(map[uuid.UUID]*domain.KnowledgeBase)}
+	svc.SetLocalRepositories(kbRepo, nil, nil)
+	ctx := context.Background()
+
+	// A local record whose Bland knowledge base was deleted.
+	gone := domain.NewKnowledgeBase("Old", "")
+	gone.BlandID = "kb-gone"
+	gone.Status = domain.KnowledgeBaseStatusActive
+	kbRepo.kbs[gone.ID] = gone
+
+	if _, err := svc.Sync(ctx, domain.BlandEntityKnowledgeBase); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	local, _ := kbRepo.GetByBlandID(ctx, "kb-1")
+	if local == nil {
+		t.Fatal("knowledge base not mirrored")
+	}
+	if local.Name != "Pricing" || local.Status != domain.KnowledgeBaseStatusActive || local.LastSyncedAt == nil {
+		t.Errorf("mirrored knowledge base = %+v", local)
+	}
+	if kbRepo.kbs[gone.ID].SyncError != blandMissingError {
+		t.Errorf("missing knowledge base SyncError = %q, want %q", kbRepo.kbs[gone.ID].SyncError, blandMissingError)
+	}
+
+	// A rename in Bland updates the same local record.
+	source.kbs[0].Name = "Prices"
+	if _, err := svc.Sync(ctx, domain.BlandEntityKnowledgeBase); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(kbRepo.kbs) != 2 {
+		t.Errorf("local knowledge bases = %d, want 2", len(kbRepo.kbs))
+	}
+	if renamed, _ := kbRepo.GetByBlandID(ctx, "kb-1"); renamed.Name != "Prices" || renamed.ID != local.ID {
+		t.Errorf("renamed knowledge base = %+v", renamed)
+	}
+}
+
+func TestBlandSyncService_RefreshFailureServesStaleCache(t *testing.T) {
+	source := &fakeBlandSource{voices: []bland.Voice{{ID: "v1", Name: "Maya"}}}
+	svc, _ := newTestBlandSyncService(source)
+	ctx := context.Background()
+
+	if _, _, err := svc.ListVoices(ctx, false); err != nil {
+		t.Fatalf("ListVoices() error = %v", err)
+	}
+
+	source.err = errors.New("bland unavailable")
+	voices, state, err := svc.ListVoices(ctx, true)
+	if err != nil {
+		t.Fatalf("ListVoices(refresh) error = %v, want the cached voices", err)
+	}
+	if len(voices) != 1 {
+		t.Errorf("voices = %d, want the cached voice", len(voices))
+	}
+	if !state.IsStale() {
+		t.Errorf("state = %+v, want stale", state)
+	}
+
+	// The worker's next list still reports the failed sync.
+	if _, state, _ := svc.ListVoices(ctx, false); !state.IsStale() {
+		t.Errorf("state = %+v, want stale until a sync succeeds", state)
+	}
+
+	source.err = nil
+	if _, state, _ := svc.ListVoices(ctx, true); state.IsStale() {
+		t.Errorf("state = %+v, want fresh after a successful sync", state)
+	}
+}
+
+// fakeWriteReplayer reports fixed kinds as changed by replayed writes.
+type fakeWriteReplayer struct {
+	kinds []domain.BlandEntityKind
+}
+
+func (f *fakeWriteReplayer) ReplayQueuedWrites(ctx context.Context) ([]domain.BlandEntityKind, error) {
+	return f.kinds, nil
+}
+
+func TestBlandSyncService_ReplayResyncsChangedKinds(t *testing.T) {
+	source := &fakeBlandSource{kbs: []bland.KnowledgeBase{{VectorID: "kb-1", Name: "FAQ"}}}
+	svc, repo := newTestBlandSyncService(source)
+	svc.SetWriteReplayer(&fakeWriteReplayer{kinds: []domain.BlandEntityKind{domain.BlandEntityKnowledgeBase}})
+
+	svc.replay(context.Background())
+
+	if source.callCount() != 1 {
+		t.Errorf("Bland calls = %d, want one resync", source.callCount())
+	}
+	if state, err := repo.GetSyncState(context.Background(), domain.BlandEntityKnowledgeBase); err != nil || state.ItemCount != 1 {
+		t.Errorf("knowledge base state = %+v, %v; want the resynced list", state, err)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// ErrBlandWriteQueued is returned by BlandService writes made while Bland is
+// unreachable and a write queue is set. The write is replayed once Bland
+// recovers.
+var ErrBlandWriteQueued = errors.New("bland is unreachable; the change was queued and will be applied when it recovers")
+
+// blandReplayBatchSize caps the writes replayed in one run.
+const blandReplayBatchSize = 50
+
+// usageLimitArgs are the arguments of a queued SetUsageLimit.
+type usageLimitArgs struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+}
+
+// SetWriteQueue enables queueing knowledge base, blocked number, inbound
+// agent and usage limit changes while Bland's circuit breaker is open,
+// instead of failing them.
+func (s *BlandService) SetWriteQueue(repo domain.BlandWriteRepository) {
+	s.writeQueue = repo
+}
+
+// Degraded reports whether Bland is currently considered unreachable.
+func (s *BlandService) Degraded() bool {
+	return s.blandClient != nil && s.blandClient.IsCircuitOpen()
+}
+
+// PendingWrites returns the number of writes waiting for Bland to recover.
+func (s *BlandService) PendingWrites(ctx context.Context) (int, error) {
+	if s.writeQueue == nil {
+		return 0, nil
+	}
+	return s.writeQueue.CountPending(ctx)
+}
+
+// isBlandUnavailable reports whether the circuit breaker refused a call.
+func isBlandUnavailable(err error) bool {
+	return errors.Is(err, circuitbreaker.ErrCircuitOpen) || errors.Is(err, circuitbreaker.ErrTooManyRequests)
+}
+
+// queueWrite queues a write that failed because Bland is unreachable,
+// returning ErrBlandWriteQueued. Other errors, and all errors when no queue
+// is set, are returned unchanged.
+func (s *BlandService) queueWrite(ctx context.Context, op domain.BlandWriteOperation, targetID string, args interface{}, err error) error {
+	if err == nil || s.writeQueue == nil || !isBlandUnavailable(err) {
+		return err
+	}
+
+	var payload json.RawMessage
+	if args != nil {
+		encoded, encodeErr := json.Marshal(args)
+		if encodeErr != nil {
+			s.logger.Error("failed to encode bland write", zap.String("operation", string(op)), zap.Error(encodeErr))
+			return err
+		}
+		payload = encoded
+	}
+
+	write := domain.NewBlandWrite(op, targetID, payload)
+	if queueErr := s.writeQueue.Create(ctx, write); queueErr != nil {
+		s.logger.Error("failed to queue bland write", zap.String("operation", string(op)), zap.Error(queueErr))
+		return err
+	}
+
+	s.logger.Warn("bland unreachable, queued write for replay",
+		zap.String("write_id", write.ID.String()),
+		zap.String("operation", string(op)),
+		zap.String("target_id", targetID),
+	)
+	return ErrBlandWriteQueued
+}
+
+// ReplayQueuedWrites applies queued writes in the order they were made and
+// returns the cached kinds they changed. Replay stops at the first write
+// that doesn't apply, so later writes never overtake it: a write refused
+// because Bland is still unreachable stays queued untouched, and one Bland
+// rejects counts an attempt and fails after MaxBlandWriteAttempts.
+func (s *BlandService) ReplayQueuedWrites(ctx context.Context) ([]domain.BlandEntityKind, error) {
+	if s.writeQueue == nil {
+		return nil, nil
+	}
+
+	writes, err := s.writeQueue.ListPending(ctx, blandReplayBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued bland writes: %w", err)
+	}
+
+	var kinds []domain.BlandEntityKind
+	seen := make(map[domain.BlandEntityKind]bool)
+	for _, write := range writes {
+		if ctx.Err() != nil {
+			return kinds, ctx.Err()
+		}
+
+		applyErr := s.applyWrite(ctx, write)
+		if isBlandUnavailable(applyErr) || (applyErr != nil && ctx.Err() != nil) {
+			return kinds, nil
+		}
+
+		if applyErr != nil {
+			write.MarkRejected(applyErr.Error())
+			s.logger.Warn("queued bland write rejected",
+				zap.String("write_id", write.ID.String()),
+				zap.String("operation", string(write.Operation)),
+				zap.Int("attempts", write.Attempts),
+				zap.Error(applyErr),
+			)
+		} else {
+			write.MarkApplied()
+			s.logger.Info("replayed queued bland write",
+				zap.String("write_id", write.ID.String()),
+				zap.String("operation", string(write.Operation)),
+			)
+			if kind := write.Operation.Kind(); kind != "" && !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+
+		if err := s.writeQueue.Update(ctx, write); err != nil {
+			return kinds, fmt.Errorf("failed to save queued bland write %s: %w", write.ID, err)
+		}
+		if applyErr != nil && write.Status == domain.BlandWriteStatusPending {
+			return kinds, nil
+		}
+	}
+	return kinds, nil
+}
+
+// applyWrite sends a queued write to Bland.
+func (s *BlandService) applyWrite(ctx context.Context, write *domain.BlandWrite) error {
+	switch write.Operation {
+	case domain.BlandWriteCreateKnowledgeBase:
+		var req bland.CreateKnowledgeBaseRequest
+		if err := json.Unmarshal(write.Payload, &req); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+		_, err := s.blandClient.CreateKnowledgeBase(ctx, &req)
+		return err
+
+	case domain.BlandWriteUpdateKnowledgeBase:
+		var req bland.UpdateKnowledgeBaseRequest
+		if err := json.Unmarshal(write.Payload, &req); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+		return s.blandClient.UpdateKnowledgeBase(ctx, write.TargetID, &req)
+
+	case domain.BlandWriteDeleteKnowledgeBase:
+		return s.blandClient.DeleteKnowledgeBase(ctx, write.TargetID)
+
+	case domain.BlandWriteBlockNumber:
+		var req bland.BlockNumberRequest
+		if err := json.Unmarshal(write.Payload, &req); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+		_, err := s.blandClient.BlockNumber(ctx, &req)
+		return err
+
+	case domain.BlandWriteUnblockNumber:
+		return s.blandClient.UnblockNumber(ctx, write.TargetID)
+
+	case domain.BlandWriteConfigureInbound:
+		var config bland.InboundConfig
+		if err := json.Unmarshal(write.Payload, &config); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+		_, err := s.blandClient.ConfigureInboundAgent(ctx, write.TargetID, &config)
+		return err
+
+	case domain.BlandWriteSetUsageLimit:
+		var args usageLimitArgs
+		if err := json.Unmarshal(write.Payload, &args); err != nil {
+			return fmt.Errorf("invalid payload: %w", err)
+		}
+		return s.blandClient.SetUsageLimit(ctx, args.Type, args.Value)
+	}
+	return fmt.Errorf("unknown operation %q", write.Operation)
+}
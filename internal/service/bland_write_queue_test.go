@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// MockBlandWriteRepository is an in-memory BlandWriteRepository.
+type MockBlandWriteRepository struct {
+	mu     sync.Mutex
+	writes []*domain.BlandWrite
+}
+
+func (m *MockBlandWriteRepository) Create(ctx context.Context, write *domain.BlandWrite) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *write
+	m.writes = append(m.writes, &cp)
+	return nil
+}
+
+func (m *MockBlandWriteRepository) ListPending(ctx context.Context, limit int) ([]*domain.BlandWrite, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []*domain.BlandWrite
+	for _, w := range m.writes {
+		if w.Status == domain.BlandWriteStatusPending {
+			cp := *w
+			pending = append(pending, &cp)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (m *MockBlandWriteRepository) Update(ctx context.Context, write *domain.BlandWrite) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, w := range m.writes {
+		if w.ID == write.ID {
+			cp := *write
+			m.writes[i] = &cp
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func (m *MockBlandWriteRepository) CountPending(ctx context.Context) (int, error) {
+	pending, _ := m.ListPending(ctx, len(m.writes)+1)
+	return len(pending), nil
+}
+
+// fakeBlandAPI answers Bland requests with a configurable status and records
+// the requests it served.
+type fakeBlandAPI struct {
+	mu       sync.Mutex
+	status   int
+	requests []string
+}
+
+func (f *fakeBlandAPI) setStatus(status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+}
+
+func (f *fakeBlandAPI) served() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+func (f *fakeBlandAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	status := f.status
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path+" "+string(body))
+	f.mu.Unlock()
+
+	w.WriteHeader(status)
+	if status >= 400 {
+		io.WriteString(w, `{"status":"error","message":"unavailable"}`)
+		return
+	}
+	io.WriteString(w, `{}`)
+}
+
+func newTestBlandWriteQueue(t *testing.T) (*BlandService, *bland.Client, *fakeBlandAPI, *MockBlandWriteRepository) {
+	t.Helper()
+	api := &fakeBlandAPI{status: http.StatusOK}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	client := bland.New(&bland.Config{APIKey: "test", BaseURL: srv.URL}, zap.NewNop())
+	queue := &MockBlandWriteRepository{}
+	svc := NewBlandService(client, nil, nil, nil, "", nil, zap.NewNop())
+	svc.SetWriteQueue(queue)
+	return svc, client, api, queue
+}
+
+// openCircuit fails enough Bland calls to open the client's circuit breaker.
+func openCircuit(t *testing.T, client *bland.Client, api *fakeBlandAPI) {
+	t.Helper()
+	api.setStatus(http.StatusServiceUnavailable)
+	for i := 0; i < 5; i++ {
+		_ = client.DeleteKnowledgeBase(context.Background(), "warmup")
+	}
+	if !client.IsCircuitOpen() {
+		t.Fatal("circuit breaker did not open")
+	}
+}
+
+func TestBlandService_QueuesWritesWhileCircuitOpen(t *testing.T) {
+	svc, client, api, queue := newTestBlandWriteQueue(t)
+	ctx := context.Background()
+	openCircuit(t, client, api)
+
+	name := "FAQ v2"
+	err := svc.UpdateKnowledgeBase(ctx, "kb-1", &bland.UpdateKnowledgeBaseRequest{Name: &name})
+	if !errors.Is(err, ErrBlandWriteQueued) {
+		t.Fatalf("UpdateKnowledgeBase() error = %v, want ErrBlandWriteQueued", err)
+	}
+	if err := svc.UnblockNumber(ctx, "blk-1"); !errors.Is(err, ErrBlandWriteQueued) {
+		t.Fatalf("UnblockNumber() error = %v, want ErrBlandWriteQueued", err)
+	}
+	if !svc.Degraded() {
+		t.Error("Degraded() = false while the circuit is open")
+	}
+	if n, _ := svc.PendingWrites(ctx); n != 2 {
+		t.Fatalf("PendingWrites() = %d, want 2", n)
+	}
+
+	// Bland recovers.
+	client.ResetCircuitBreaker()
+	api.setStatus(http.StatusOK)
+	before := len(api.served())
+
+	kinds, err := svc.ReplayQueuedWrites(ctx)
+	if err != nil {
+		t.Fatalf("ReplayQueuedWrites() error = %v", err)
+	}
+	if len(kinds) != 1 || kinds[0] != domain.BlandEntityKnowledgeBase {
+		t.Errorf("kinds = %v, want [knowledge_bases]", kinds)
+	}
+
+	replayed := api.served()[before:]
+	want := []string{
+		`PATCH /knowledgebases/kb-1 {"name":"FAQ v2"}`,
+		"DELETE /numbers/blocked/blk-1 ",
+	}
+	if len(replayed) != len(want) {
+		t.Fatalf("replayed requests = %q, want %q", replayed, want)
+	}
+	for i := range want {
+		if replayed[i] != want[i] {
+			t.Errorf("replayed request %d = %q, want %q", i, replayed[i], want[i])
+		}
+	}
+	for _, w := range queue.writes {
+		if w.Status != domain.BlandWriteStatusApplied || w.AppliedAt == nil {
+			t.Errorf("write %s status = %q, want applied", w.Operation, w.Status)
+		}
+	}
+}
+
+func TestBlandService_ReplayStopsWhileBlandUnreachable(t *testing.T) {
+	svc, client, api, queue := newTestBlandWriteQueue(t)
+	ctx := context.Background()
+	openCircuit(t, client, api)
+
+	if err := svc.DeleteKnowledgeBase(ctx, "kb-1"); !errors.Is(err, ErrBlandWriteQueued) {
+		t.Fatalf("DeleteKnowledgeBase() error = %v, want ErrBlandWriteQueued", err)
+	}
+
+	kinds, err := svc.ReplayQueuedWrites(ctx)
+	if err != nil || len(kinds) != 0 {
+		t.Fatalf("ReplayQueuedWrites() = %v, %v; want nothing applied", kinds, err)
+	}
+	if w := queue.writes[0]; w.Status != domain.BlandWriteStatusPending || w.Attempts != 0 {
+		t.Errorf("write = %+v, want pending with no attempts", w)
+	}
+}
+
+func TestBlandService_ReplayRejectedWriteBlocksLaterWrites(t *testing.T) {
+	svc, client, api, queue := newTestBlandWriteQueue(t)
+	ctx := context.Background()
+	openCircuit(t, client, api)
+
+	_ = svc.DeleteKnowledgeBase(ctx, "kb-1")
+	_ = svc.DeleteKnowledgeBase(ctx, "kb-2")
+
+	client.ResetCircuitBreaker()
+	api.setStatus(http.StatusBadRequest)
+
+	for attempt := 1; attempt <= domain.MaxBlandWriteAttempts; attempt++ {
+		if _, err := svc.ReplayQueuedWrites(ctx); err != nil {
+			t.Fatalf("ReplayQueuedWrites() error = %v", err)
+		}
+		if got := queue.writes[0].Attempts; got != attempt {
+			t.Fatalf("attempts = %d, want %d", got, attempt)
+		}
+		if queue.writes[1].Attempts != 0 {
+			t.Fatal("later write replayed before the earlier one was resolved")
+		}
+	}
+	if queue.writes[0].Status != domain.BlandWriteStatusFailed {
+		t.Errorf("status = %q, want failed after %d attempts", queue.writes[0].Status, domain.MaxBlandWriteAttempts)
+	}
+}
+
+func TestBlandService_WritesFailWithoutQueue(t *testing.T) {
+	svc, client, api, _ := newTestBlandWriteQueue(t)
+	svc.SetWriteQueue(nil)
+	openCircuit(t, client, api)
+
+	err := svc.DeleteKnowledgeBase(context.Background(), "kb-1")
+	if err == nil || errors.Is(err, ErrBlandWriteQueued) {
+		t.Errorf("DeleteKnowledgeBase() error = %v, want the circuit breaker error", err)
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// maxBlocklistImportErrors bounds the row errors an import reports.
+const maxBlocklistImportErrors = 50
+
+// BlocklistProvider blocks single numbers at the voice provider.
+// BlandService implements it.
+type BlocklistProvider interface {
+	ListBlockedNumbers(ctx context.Context) ([]bland.BlockedNumber, error)
+	BlockNumber(ctx context.Context, req *bland.BlockNumberRequest) (*bland.BlockedNumber, error)
+	UnblockNumber(ctx context.Context, blockedID string) error
+}
+
+// CallHangup ends calls in progress. bland.Client implements it.
+type CallHangup interface {
+	EndCall(ctx context.Context, callID string) error
+}
+
+// BlocklistService keeps a local mirror of the blocklist alongside Bland's.
+// Single numbers are blocked at Bland as well; prefix blocks such as +1900*
+// exist only here. Webhooks check the mirror, so blocked callers are turned
+// away even when Bland is slow to answer.
+type BlocklistService struct {
+	repo     domain.BlocklistRepository
+	provider BlocklistProvider
+	hangup   CallHangup
+	logger   *zap.Logger
+}
+
+// NewBlocklistService creates a new BlocklistService.
+func NewBlocklistService(repo domain.BlocklistRepository, provider BlocklistProvider, logger *zap.Logger) *BlocklistService {
+	return &BlocklistService{
+		repo:     repo,
+		provider: provider,
+		logger:   logger,
+	}
+}
+
+// SetHangup enables hanging up on blocked callers whose call is still in
+// progress when its webhook arrives.
+func (s *BlocklistService) SetHangup(hangup CallHangup) {
+	s.hangup = hangup
+}
+
+// BlockNumberRequest blocks a number or a prefix ending in "*".
+type BlockNumberRequest struct {
+	PhoneNumber string                `json:"phone_number"`
+	Reason      string                `json:"reason,omitempty"`
+	Direction   domain.BlockDirection `json:"direction,omitempty"` // inbound, outbound or both (default)
+}
+
+// BlocklistImportResult summarizes a blocklist import.
+type BlocklistImportResult struct {
+	Blocked int      `json:"blocked"` // Rows blocked or updated
+	Skipped int      `json:"skipped"` // Rows that could not be blocked
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// Block adds a number or prefix to the blocklist, or updates its direction
+// and reason. Single numbers are blocked at Bland too; if Bland is
+// unreachable the block is saved locally and ErrBlandWriteQueued is
+// returned with it while Bland's copy waits for replay.
+func (s *BlocklistService) Block(ctx context.Context, req *BlockNumberRequest) (*domain.BlockedNumber, error) {
+	pattern, err := blockPattern(req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	entry := domain.NewBlockedNumber(pattern, req.Direction, req.Reason)
+	if err := entry.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	var queued error
+	if !entry.IsPrefix() {
+		blocked, err := s.provider.BlockNumber(ctx, &bland.BlockNumberRequest{
+			PhoneNumber: entry.PhoneNumber,
+			Reason:      entry.Reason,
+			Direction:   string(entry.Direction),
+		})
+		switch {
+		case errors.Is(err, ErrBlandWriteQueued):
+			queued = err
+		case err != nil:
+			return nil, fmt.Errorf("failed to block number at bland: %w", err)
+		case blocked != nil:
+			entry.BlandID = blocked.ID
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, entry); err != nil {
+		return nil, err
+	}
+	s.logger.Info("number blocked",
+		zap.String("phone_number", entry.PhoneNumber),
+		zap.String("direction", string(entry.Direction)),
+	)
+	return entry, queued
+}
+
+// Unblock removes an entry, by its ID or Bland's ID for the block. A Bland
+// ID with no local entry is unblocked at Bland alone.
+func (s *BlocklistService) Unblock(ctx context.Context, id string) error {
+	entry, err := s.find(ctx, id)
+	if apperrors.IsNotFound(err) && id != "" {
+		return s.provider.UnblockNumber(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	var queued error
+	if entry.BlandID != "" {
+		err := s.provider.UnblockNumber(ctx, entry.BlandID)
+		switch {
+		case errors.Is(err, ErrBlandWriteQueued):
+			queued = err
+		case err != nil:
+			return fmt.Errorf("failed to unblock number at bland: %w", err)
+		}
+	}
+
+	if err := s.repo.Delete(ctx, entry.ID); err != nil {
+		return err
+	}
+	s.logger.Info("number unblocked", zap.String("phone_number", entry.PhoneNumber))
+	return queued
+}
+
+func (s *BlocklistService) find(ctx context.Context, id string) (*domain.BlockedNumber, error) {
+	if entryID, err := uuid.Parse(id); err == nil {
+		entry, err := s.repo.GetByID(ctx, entryID)
+		if !apperrors.IsNotFound(err) {
+			return entry, err
+		}
+	}
+	return s.repo.GetByBlandID(ctx, id)
+}
+
+// List returns the blocklist ordered by number.
+func (s *BlocklistService) List(ctx context.Context) ([]*domain.BlockedNumber, error) {
+	entries, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*domain.BlockedNumber{}
+	}
+	return entries, nil
+}
+
+// Import blocks the numbers and prefixes in a CSV file with phone_number,
+// reason and direction columns. The header row is optional; without one
+// the columns are taken in that order. Rows that can't be blocked are
+// skipped and reported.
+func (s *BlocklistService) Import(ctx context.Context, r io.Reader) (*BlocklistImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	result := &BlocklistImportResult{}
+	columns := map[string]int{"phone_number": 0, "reason": 1, "direction": 2}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperrors.ValidationFailed("failed to read blocklist CSV: " + err.Error())
+		}
+		if line == 1 && isBlocklistHeader(record) {
+			columns = blocklistColumns(record)
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if field("phone_number") == "" {
+			continue
+		}
+
+		_, err = s.Block(ctx, &BlockNumberRequest{
+			PhoneNumber: field("phone_number"),
+			Reason:      field("reason"),
+			Direction:   domain.BlockDirection(strings.ToLower(field("direction"))),
+		})
+		if err != nil && !errors.Is(err, ErrBlandWriteQueued) {
+			result.Skipped++
+			if len(result.Errors) < maxBlocklistImportErrors {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: %v", line, err))
+			}
+			continue
+		}
+		result.Blocked++
+	}
+
+	s.logger.Info("blocklist imported",
+		zap.Int("blocked", result.Blocked),
+		zap.Int("skipped", result.Skipped),
+	)
+	return result, nil
+}
+
+// isBlocklistHeader reports whether a CSV row names its columns.
+func isBlocklistHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+	first := strings.ToLower(strings.TrimSpace(record[0]))
+	return strings.Contains(first, "phone") || strings.Contains(first, "number") || first == "pattern"
+}
+
+// blocklistColumns maps a header row to column positions.
+func blocklistColumns(header []string) map[string]int {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case strings.Contains(name, "phone") || strings.Contains(name, "number") || name == "pattern":
+			columns["phone_number"] = i
+		case name == "reason":
+			columns["reason"] = i
+		case name == "direction":
+			columns["direction"] = i
+		}
+	}
+	return columns
+}
+
+// Export writes the blocklist as CSV in the format Import reads.
+func (s *BlocklistService) Export(ctx context.Context, w io.Writer) error {
+	entries, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"phone_number", "reason", "direction", "created_at"}); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := writer.Write([]string{
+			entry.PhoneNumber,
+			entry.Reason,
+			string(entry.Direction),
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Sync mirrors Bland's blocklist: numbers blocked at Bland are added
+// locally, and local numbers Bland no longer blocks are removed. Prefix
+// blocks and blocks still waiting to reach Bland are kept.
+func (s *BlocklistService) Sync(ctx context.Context) error {
+	remote, err := s.provider.ListBlockedNumbers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list bland blocked numbers: %w", err)
+	}
+
+	seen := make(map[string]bool, len(remote))
+	for _, b := range remote {
+		phone := normalizePhoneNumber(b.PhoneNumber)
+		if phone == "" {
+			continue
+		}
+		entry := domain.NewBlockedNumber(phone, domain.BlockDirection(b.Direction), b.Reason)
+		entry.BlandID = b.ID
+		if entry.Validate() != nil {
+			entry.Direction = domain.BlockDirectionBoth
+		}
+		if err := s.repo.Upsert(ctx, entry); err != nil {
+			return err
+		}
+		seen[b.ID] = true
+	}
+
+	local, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for _, entry := range local {
+		if entry.BlandID != "" && !seen[entry.BlandID] {
+			if err := s.repo.Delete(ctx, entry.ID); err != nil && !apperrors.IsNotFound(err) {
+				return err
+			}
+			removed++
+		}
+	}
+
+	s.logger.Info("blocklist synced from bland",
+		zap.Int("bland_numbers", len(remote)),
+		zap.Int("removed", removed),
+	)
+	return nil
+}
+
+// Check returns the entry that blocks calls with phoneNumber in the given
+// direction, or nil if none does.
+func (s *BlocklistService) Check(ctx context.Context, phoneNumber string, direction domain.BlockDirection) (*domain.BlockedNumber, error) {
+	phone := normalizePhoneNumber(phoneNumber)
+	if phone == "" {
+		return nil, nil
+	}
+	entry, err := s.repo.Match(ctx, phone, direction)
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check blocklist: %w", err)
+	}
+	return entry, nil
+}
+
+// CheckOutbound returns an error if calls to phoneNumber are blocked.
+func (s *BlocklistService) CheckOutbound(ctx context.Context, phoneNumber string) error {
+	entry, err := s.Check(ctx, phoneNumber, domain.BlockDirectionOutbound)
+	if err != nil || entry == nil {
+		return err
+	}
+	return apperrors.New(apperrors.CodeComplianceBlocked,
+		fmt.Sprintf("%s is blocked by %s", phoneNumber, entry.PhoneNumber))
+}
+
+// ScreenInbound returns the entry that blocks the caller on a call event, or
+// nil if the caller is not blocked. A blocked Bland call still in progress
+// is hung up.
+func (s *BlocklistService) ScreenInbound(ctx context.Context, event *voiceprovider.CallEvent) (*domain.BlockedNumber, error) {
+	entry, err := s.Check(ctx, event.FromNumber, domain.BlockDirectionInbound)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	inProgress := event.Status == voiceprovider.CallStatusPending || event.Status == voiceprovider.CallStatusInProgress
+	if s.hangup != nil && inProgress && event.Provider == voiceprovider.ProviderBland {
+		if err := s.hangup.EndCall(ctx, event.ProviderCallID); err != nil {
+			s.logger.Warn("failed to hang up on blocked caller",
+				zap.String("provider_call_id", event.ProviderCallID),
+				zap.Error(err),
+			)
+		}
+	}
+	return entry, nil
+}
+
+// blockPattern normalizes a number, or a prefix ending in "*".
+func blockPattern(s string) (string, error) {
+	prefix, isPrefix, err := domain.ParseBlockPrefix(s)
+	if err != nil {
+		return "", apperrors.ValidationFailed(err.Error())
+	}
+	if isPrefix {
+		return prefix, nil
+	}
+	phone := normalizePhoneNumber(s)
+	if phone == "" {
+		return "", apperrors.ValidationFailed("phone_number must be a phone number or a prefix ending in *, such as +1900*")
+	}
+	return phone, nil
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// MockBlocklistRepository is an in-memory BlocklistRepository.
+type MockBlocklistRepository struct {
+	mu      sync.Mutex
+	entries map[string]*domain.BlockedNumber // by phone number or prefix
+}
+
+func NewMockBlocklistRepository() *MockBlocklistRepository {
+	return &MockBlocklistRepository{entries: make(map[string]*domain.BlockedNumber)}
+}
+
+func (m *MockBlocklistRepository) Upsert(ctx context.Context, entry *domain.BlockedNumber) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.entries[entry.PhoneNumber]; ok {
+		existing.Direction = entry.Direction
+		existing.Reason = entry.Reason
+		if entry.BlandID != "" {
+			existing.BlandID = entry.BlandID
+		}
+		*entry = *existing
+		return nil
+	}
+	cp := *entry
+	m.entries[entry.PhoneNumber] = &cp
+	return nil
+}
+
+func (m *MockBlocklistRepository) find(match func(*domain.BlockedNumber) bool) (*domain.BlockedNumber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.entries {
+		if match(e) {
+			cp := *e
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("blocked number")
+}
+
+func (m *MockBlocklistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.BlockedNumber, error) {
+	return m.find(func(e *domain.BlockedNumber) bool { return e.ID == id })
+}
+
+func (m *MockBlocklistRepository) GetByBlandID(ctx context.Context, blandID string) (*domain.BlockedNumber, error) {
+	return m.find(func(e *domain.BlockedNumber) bool { return e.BlandID == blandID })
+}
+
+func (m *MockBlocklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for phone, e := range m.entries {
+		if e.ID == id {
+			delete(m.entries, phone)
+			return nil
+		}
+	}
+	return apperrors.NotFound("blocked number")
+}
+
+func (m *MockBlocklistRepository) List(ctx context.Context) ([]*domain.BlockedNumber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*domain.BlockedNumber
+	for _, e := range m.entries {
+		cp := *e
+		entries = append(entries, &cp)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].PhoneNumber < entries[j].PhoneNumber })
+	return entries, nil
+}
+
+func (m *MockBlocklistRepository) Match(ctx context.Context, phoneNumber string, direction domain.BlockDirection) (*domain.BlockedNumber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var best *domain.BlockedNumber
+	for _, e := range m.entries {
+		if e.Blocks(phoneNumber, direction) && (best == nil || len(e.PhoneNumber) > len(best.PhoneNumber)) {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil, apperrors.NotFound("blocked number")
+	}
+	cp := *best
+	return &cp, nil
+}
+
+// fakeBlocklistProvider stands in for Bland's blocklist.
+type fakeBlocklistProvider struct {
+	blocked map[string]bland.BlockedNumber // by ID
+	err     error
+	nextID  int
+}
+
+func newFakeBlocklistProvider() *fakeBlocklistProvider {
+	return &fakeBlocklistProvider{blocked: map[string]bland.BlockedNumber{}}
+}
+
+func (f *fakeBlocklistProvider) ListBlockedNumbers(ctx context.Context) ([]bland.BlockedNumber, error) {
+	var numbers []bland.BlockedNumber
+	for _, b := range f.blocked {
+		numbers = append(numbers, b)
+	}
+	return numbers, nil
+}
+
+func (f *fakeBlocklistProvider) BlockNumber(ctx context.Context, req *bland.BlockNumberRequest) (*bland.BlockedNumber, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.nextID++
+	b := bland.BlockedNumber{ID: fmt.Sprintf("blk_%d", f.nextID), PhoneNumber: req.PhoneNumber, Reason: req.Reason, Direction: req.Direction}
+	f.blocked[b.ID] = b
+	return &b, nil
+}
+
+func (f *fakeBlocklistProvider) UnblockNumber(ctx context.Context, blockedID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.blocked, blockedID)
+	return nil
+}
+
+func newTestBlocklistService() (*BlocklistService, *MockBlocklistRepository, *fakeBlocklistProvider) {
+	repo := NewMockBlocklistRepository()
+	provider := newFakeBlocklistProvider()
+	return NewBlocklistService(repo, provider, zap.NewNop()), repo, provider
+}
+
+func TestBlocklistService_Block(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, provider := newTestBlocklistService()
+
+	entry, err := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "(555) 123-4567", Reason: "spam"})
+	if err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if entry.PhoneNumber != "+15551234567" || entry.BlandID == "" || entry.Direction != domain.BlockDirectionBoth {
+		t.Errorf("entry = %+v, want a normalized number blocked at bland both ways", entry)
+	}
+
+	// Prefixes never reach Bland
+	prefix, err := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+1900*", Direction: domain.BlockDirectionInbound})
+	if err != nil {
+		t.Fatalf("Block(prefix) error = %v", err)
+	}
+	if prefix.BlandID != "" || len(provider.blocked) != 1 {
+		t.Errorf("prefix block reached bland: %+v", prefix)
+	}
+
+	if _, err := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+1*"}); !apperrors.IsUserError(err) {
+		t.Errorf("Block(+1*) error = %v, want a validation error", err)
+	}
+
+	// Bland being down still blocks locally
+	provider.err = ErrBlandWriteQueued
+	if _, err := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+15557654321"}); !errors.Is(err, ErrBlandWriteQueued) {
+		t.Errorf("Block() error = %v, want ErrBlandWriteQueued", err)
+	}
+	if entries, _ := repo.List(ctx); len(entries) != 3 {
+		t.Errorf("local blocklist has %d entries, want 3", len(entries))
+	}
+}
+
+func TestBlocklistService_Unblock(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, provider := newTestBlocklistService()
+
+	entry, _ := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+15551234567"})
+	if err := svc.Unblock(ctx, entry.BlandID); err != nil {
+		t.Fatalf("Unblock() error = %v", err)
+	}
+	if len(provider.blocked) != 0 {
+		t.Error("number is still blocked at bland")
+	}
+	if _, err := repo.GetByID(ctx, entry.ID); !apperrors.IsNotFound(err) {
+		t.Error("number is still blocked locally")
+	}
+}
+
+func TestBlocklistService_ImportExport(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newTestBlocklistService()
+
+	csv := "Phone Number,Reason,Direction\n" +
+		"+15551234567,spam,inbound\n" +
+		"+1900*,premium rate,\n" +
+		"not a number,,\n" +
+		"+15557654321,,sideways\n"
+	result, err := svc.Import(ctx, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Blocked != 2 || result.Skipped != 2 || len(result.Errors) != 2 {
+		t.Errorf("result = %+v, want 2 blocked and 2 skipped", result)
+	}
+
+	var buf bytes.Buffer
+	if err := svc.Export(ctx, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "phone_number,reason,direction,created_at" {
+		t.Fatalf("export = %q", buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "+15551234567,spam,inbound,") || !strings.HasPrefix(lines[2], "+1900*,premium rate,both,") {
+		t.Errorf("export rows = %q", lines[1:])
+	}
+
+	// The export reads back in as-is
+	other, _, _ := newTestBlocklistService()
+	if result, err := other.Import(ctx, &buf); err != nil || result.Blocked != 2 {
+		t.Errorf("re-import = %+v, %v", result, err)
+	}
+}
+
+func TestBlocklistService_Sync(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, provider := newTestBlocklistService()
+
+	stale, _ := svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+15551234567"})
+	svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+1900*"})
+	delete(provider.blocked, stale.BlandID)
+	provider.blocked["blk_remote"] = bland.BlockedNumber{ID: "blk_remote", PhoneNumber: "+15559990000", Direction: "inbound"}
+
+	if err := svc.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	entries, _ := repo.List(ctx)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.PhoneNumber)
+	}
+	if strings.Join(got, " ") != "+15559990000 +1900*" {
+		t.Errorf("blocklist after sync = %v, want the prefix and bland's number", got)
+	}
+}
+
+func TestBlocklistService_Screening(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _ := newTestBlocklistService()
+	hangup := newFakeCallController()
+	svc.SetHangup(hangup)
+
+	svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+1900*", Direction: domain.BlockDirectionInbound})
+	svc.Block(ctx, &BlockNumberRequest{PhoneNumber: "+15551234567", Direction: domain.BlockDirectionOutbound})
+
+	event := &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "call-1",
+		FromNumber:     "+19005550000",
+		Status:         voiceprovider.CallStatusInProgress,
+	}
+	entry, err := svc.ScreenInbound(ctx, event)
+	if err != nil || entry == nil || entry.PhoneNumber != "+1900*" {
+		t.Fatalf("ScreenInbound() = %+v, %v; want blocked by +1900*", entry, err)
+	}
+	if len(hangup.ended) != 1 || hangup.ended[0] != "call-1" {
+		t.Errorf("ended calls = %v, want the blocked call hung up", hangup.ended)
+	}
+
+	event.FromNumber = "+15551234567"
+	if entry, _ := svc.ScreenInbound(ctx, event); entry != nil {
+		t.Errorf("an outbound-only block turned away a caller: %+v", entry)
+	}
+
+	if err := svc.CheckOutbound(ctx, "+15551234567"); apperrors.GetCode(err) != apperrors.CodeComplianceBlocked {
+		t.Errorf("CheckOutbound() error = %v, want compliance blocked", err)
+	}
+	if err := svc.CheckOutbound(ctx, "+19005550000"); err != nil {
+		t.Errorf("CheckOutbound() error = %v for an inbound-only block", err)
+	}
+}
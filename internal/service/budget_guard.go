@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// providerUsageLag is how far behind Bland's usage summary may run. Calls
+// that ended this long before a sync are estimated locally as well, so a cap
+// errs on the side of stopping early rather than overspending.
+const providerUsageLag = 5 * time.Minute
+
+// budgetBatchPageSize is how many batches are listed per request when
+// looking for running batches to pause.
+const budgetBatchPageSize = 100
+
+// BudgetProvider reports month-to-date spend and pauses and resumes batches.
+// BlandService implements it.
+type BudgetProvider interface {
+	GetUsageSummary(ctx context.Context, req *bland.GetUsageSummaryRequest) (*bland.UsageSummary, error)
+	ListBatches(ctx context.Context, limit, offset int) (*bland.ListBatchesResponse, error)
+	PauseBatch(ctx context.Context, batchID string) error
+	ResumeBatch(ctx context.Context, batchID string) error
+}
+
+// CallBudget decides whether new calls may be placed. BudgetGuard implements it.
+type CallBudget interface {
+	CheckBudget(ctx context.Context) error
+}
+
+// BudgetStatus is the month-to-date spend measured against the hard cap.
+type BudgetStatus struct {
+	Enabled        bool                   `json:"enabled"`
+	HardCap        float64                `json:"hard_cap"`
+	ProviderSpend  float64                `json:"provider_spend"`  // Reported by Bland's usage API
+	EstimatedSpend float64                `json:"estimated_spend"` // Calls that ended since the usage was synced, at the estimated rate
+	Spend          float64                `json:"spend"`
+	Remaining      float64                `json:"remaining"`
+	Exceeded       bool                   `json:"exceeded"`
+	Blocked        bool                   `json:"blocked"` // Exceeded without an override; new calls and batches are rejected
+	Override       *domain.BudgetOverride `json:"override,omitempty"`
+	PausedBatches  []string               `json:"paused_batches"` // Batches paused by the guard, resumed once calling is allowed again
+	SyncedAt       *time.Time             `json:"synced_at,omitempty"`
+	CheckedAt      time.Time              `json:"checked_at"`
+}
+
+// BudgetOverrideRequest grants an override of the hard cap.
+type BudgetOverrideRequest struct {
+	Reason    string     `json:"reason"`
+	Until     *time.Time `json:"until,omitempty"` // Defaults to the end of the month
+	CreatedBy *uuid.UUID `json:"-"`
+}
+
+// BudgetGuard tracks month-to-date spend from Bland's usage API plus local
+// estimates of calls Bland has not billed yet. Once spend reaches the hard
+// cap it rejects new calls and batches and pauses running batches, until the
+// month rolls over or an admin grants an override.
+type BudgetGuard struct {
+	provider BudgetProvider
+	repo     domain.BudgetRepository
+	logger   *zap.Logger
+	clock    clock.Clock
+
+	// Configuration
+	limitsMu        sync.RWMutex
+	hardCap         float64
+	costPerMinute   float64
+	refreshInterval time.Duration
+
+	// refreshMu serializes refreshes so the worker and API requests don't
+	// pause or resume the same batches twice.
+	refreshMu sync.Mutex
+	statusMu  sync.RWMutex
+	status    *BudgetStatus
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// BudgetGuardConfig holds configuration for the budget guard.
+type BudgetGuardConfig struct {
+	HardCap         float64       // Month-to-date spend at which calling stops; 0 disables the guard
+	CostPerMinute   float64       // Estimated cost of a call minute Bland has not billed yet
+	RefreshInterval time.Duration // How often spend is recomputed
+}
+
+// DefaultBudgetGuardConfig returns sensible defaults.
+func DefaultBudgetGuardConfig() *BudgetGuardConfig {
+	return &BudgetGuardConfig{
+		CostPerMinute:   0.14,
+		RefreshInterval: time.Minute,
+	}
+}
+
+// NewBudgetGuard creates a new budget guard.
+func NewBudgetGuard(
+	provider BudgetProvider,
+	repo domain.BudgetRepository,
+	logger *zap.Logger,
+	config *BudgetGuardConfig,
+	clk clock.Clock,
+) *BudgetGuard {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = DefaultBudgetGuardConfig()
+	}
+	refreshInterval := config.RefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultBudgetGuardConfig().RefreshInterval
+	}
+
+	return &BudgetGuard{
+		provider:        provider,
+		repo:            repo,
+		logger:          logger,
+		clock:           clk,
+		hardCap:         config.HardCap,
+		costPerMinute:   config.CostPerMinute,
+		refreshInterval: refreshInterval,
+		stopCh:          make(chan struct{}),
+	}
+}
+
+// Enabled reports whether a hard cap is configured.
+func (g *BudgetGuard) Enabled() bool {
+	hardCap, _ := g.limits()
+	return hardCap > 0
+}
+
+// SetLimits changes the hard cap and the estimated cost per minute, then
+// recomputes spend against them. Removing the cap resumes the batches the
+// guard paused.
+func (g *BudgetGuard) SetLimits(ctx context.Context, hardCap, costPerMinute float64) error {
+	g.limitsMu.Lock()
+	g.hardCap = hardCap
+	g.costPerMinute = costPerMinute
+	g.limitsMu.Unlock()
+
+	g.logger.Info("budget limits changed",
+		zap.Float64("hard_cap", hardCap),
+		zap.Float64("cost_per_minute", costPerMinute),
+	)
+
+	_, err := g.Refresh(ctx)
+	return err
+}
+
+func (g *BudgetGuard) limits() (hardCap, costPerMinute float64) {
+	g.limitsMu.RLock()
+	defer g.limitsMu.RUnlock()
+	return g.hardCap, g.costPerMinute
+}
+
+// Start begins recomputing spend periodically.
+func (g *BudgetGuard) Start(ctx context.Context) error {
+	g.mu.Lock()
+	if g.running {
+		g.mu.Unlock()
+		return errors.New("budget guard already running")
+	}
+	g.running = true
+	g.mu.Unlock()
+
+	hardCap, _ := g.limits()
+	g.logger.Info("starting budget guard",
+		zap.Float64("hard_cap", hardCap),
+		zap.Duration("interval", g.refreshInterval),
+	)
+
+	g.wg.Add(1)
+	go g.loop()
+
+	return nil
+}
+
+// Stop gracefully stops the guard's worker.
+func (g *BudgetGuard) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	if !g.running {
+		g.mu.Unlock()
+		return nil
+	}
+	g.running = false
+	g.mu.Unlock()
+
+	g.logger.Info("stopping budget guard")
+	close(g.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		g.logger.Info("budget guard stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		g.logger.Warn("budget guard stop timed out")
+		return ctx.Err()
+	}
+}
+
+// loop recomputes spend until stopped, while a hard cap is configured.
+func (g *BudgetGuard) loop() {
+	defer g.wg.Done()
+
+	ticker := time.NewTicker(g.refreshInterval)
+	defer ticker.Stop()
+
+	if g.Enabled() {
+		g.refreshUntilStopped()
+	}
+	for {
+		select {
+		case <-g.stopCh:
+			return
+		case <-ticker.C:
+			if g.Enabled() {
+				g.refreshUntilStopped()
+			}
+		}
+	}
+}
+
+// refreshUntilStopped refreshes, aborting when the worker stops.
+func (g *BudgetGuard) refreshUntilStopped() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-g.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if _, err := g.Refresh(ctx); err != nil && ctx.Err() == nil {
+		g.logger.Warn("failed to refresh budget", zap.Error(err))
+	}
+}
+
+// CheckBudget returns a budget exceeded error when spend has reached the hard
+// cap and no override is in effect. It reads the last refreshed spend, so it
+// is cheap enough to call before every call.
+func (g *BudgetGuard) CheckBudget(ctx context.Context) error {
+	if !g.Enabled() {
+		return nil
+	}
+
+	status := g.snapshot()
+	if status == nil {
+		var err error
+		if status, err = g.Refresh(ctx); err != nil {
+			// Without a spend figure there is nothing to enforce; the
+			// worker keeps retrying
+			g.logger.Warn("budget unknown, allowing call", zap.Error(err))
+			return nil
+		}
+	}
+	if status.Blocked {
+		return apperrors.New(apperrors.CodeBudgetExceeded, "monthly budget hard cap reached; calling is paused until an admin grants an override")
+	}
+	return nil
+}
+
+// Status returns the last refreshed spend, refreshing first if spend has not
+// been computed yet.
+func (g *BudgetGuard) Status(ctx context.Context) (*BudgetStatus, error) {
+	if !g.Enabled() {
+		return &BudgetStatus{PausedBatches: []string{}, CheckedAt: g.clock.Now().UTC()}, nil
+	}
+	if status := g.snapshot(); status != nil {
+		return status, nil
+	}
+	return g.Refresh(ctx)
+}
+
+// Refresh recomputes month-to-date spend. When calling is blocked it pauses
+// running batches; when calling is allowed again it resumes the batches it
+// paused.
+func (g *BudgetGuard) Refresh(ctx context.Context) (*BudgetStatus, error) {
+	g.refreshMu.Lock()
+	defer g.refreshMu.Unlock()
+
+	now := g.clock.Now().UTC()
+	monthStart := domain.BudgetMonthStart(now)
+	hardCap, costPerMinute := g.limits()
+
+	status := &BudgetStatus{
+		Enabled:   hardCap > 0,
+		HardCap:   hardCap,
+		CheckedAt: now,
+	}
+	prev := g.snapshot()
+	if prev != nil && prev.SyncedAt != nil && !prev.SyncedAt.Before(monthStart) {
+		status.ProviderSpend = prev.ProviderSpend
+		status.SyncedAt = prev.SyncedAt
+	}
+
+	summary, err := g.provider.GetUsageSummary(ctx, &bland.GetUsageSummaryRequest{Period: "monthly"})
+	if err != nil {
+		g.logger.Warn("failed to get provider usage, estimating spend locally", zap.Error(err))
+	} else if summary != nil {
+		status.ProviderSpend = summary.TotalCost
+		status.SyncedAt = &now
+	}
+
+	since := monthStart
+	if status.SyncedAt != nil {
+		since = status.SyncedAt.Add(-providerUsageLag)
+		if since.Before(monthStart) {
+			since = monthStart
+		}
+	}
+	minutes, err := g.repo.CallMinutesSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	status.EstimatedSpend = minutes * costPerMinute
+	status.Spend = status.ProviderSpend + status.EstimatedSpend
+	status.Remaining = hardCap - status.Spend
+	if status.Remaining < 0 {
+		status.Remaining = 0
+	}
+	status.Exceeded = status.Enabled && status.Spend >= hardCap
+
+	if status.Override, err = g.repo.GetActiveOverride(ctx, now); err != nil {
+		return nil, err
+	}
+	status.Blocked = status.Exceeded && status.Override == nil
+
+	if status.Blocked {
+		if prev == nil || !prev.Blocked {
+			g.logger.Warn("monthly budget hard cap reached, pausing calls",
+				zap.Float64("spend", status.Spend),
+				zap.Float64("hard_cap", hardCap),
+			)
+		}
+		g.pauseRunningBatches(ctx)
+	} else {
+		g.resumePausedBatches(ctx)
+	}
+
+	if status.PausedBatches, err = g.repo.ListPausedBatches(ctx); err != nil {
+		return nil, err
+	}
+	if status.PausedBatches == nil {
+		status.PausedBatches = []string{}
+	}
+
+	g.statusMu.Lock()
+	g.status = status
+	g.statusMu.Unlock()
+
+	return status, nil
+}
+
+// Override lets calls be placed past the hard cap until the request's Until,
+// or the end of the month, and resumes the batches the guard paused.
+func (g *BudgetGuard) Override(ctx context.Context, req *BudgetOverrideRequest) (*BudgetStatus, error) {
+	if !g.Enabled() {
+		return nil, apperrors.New(apperrors.CodeConflict, "no budget hard cap is configured")
+	}
+
+	now := g.clock.Now().UTC()
+	until := domain.BudgetMonthStart(now).AddDate(0, 1, 0)
+	if req.Until != nil {
+		until = *req.Until
+	}
+
+	override := domain.NewBudgetOverride(req.Reason, until, req.CreatedBy)
+	if err := override.Validate(now); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	if err := g.repo.CreateOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	g.logger.Info("budget override granted",
+		zap.String("override_id", override.ID.String()),
+		zap.String("reason", override.Reason),
+		zap.Time("expires_at", override.ExpiresAt),
+	)
+
+	return g.Refresh(ctx)
+}
+
+// ClearOverride revokes the overrides in effect. If spend is still over the
+// hard cap, calling is blocked again and running batches are paused.
+func (g *BudgetGuard) ClearOverride(ctx context.Context) (*BudgetStatus, error) {
+	revoked, err := g.repo.RevokeOverrides(ctx, g.clock.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if revoked == 0 {
+		return nil, apperrors.NotFound("budget override")
+	}
+
+	g.logger.Info("budget override revoked", zap.Int("revoked", revoked))
+
+	return g.Refresh(ctx)
+}
+
+// snapshot returns the last refreshed status with Blocked brought up to date,
+// since an override may have expired since the refresh.
+func (g *BudgetGuard) snapshot() *BudgetStatus {
+	g.statusMu.RLock()
+	defer g.statusMu.RUnlock()
+	if g.status == nil {
+		return nil
+	}
+
+	status := *g.status
+	if status.Override != nil && !status.Override.IsActive(g.clock.Now()) {
+		status.Override = nil
+	}
+	status.Blocked = status.Exceeded && status.Override == nil
+	return &status
+}
+
+// pauseRunningBatches pauses every running batch, recording it so it can be
+// resumed. Failures are logged and retried on the next refresh.
+func (g *BudgetGuard) pauseRunningBatches(ctx context.Context) {
+	for offset := 0; ; offset += budgetBatchPageSize {
+		resp, err := g.provider.ListBatches(ctx, budgetBatchPageSize, offset)
+		if err != nil {
+			g.logger.Warn("failed to list batches to pause", zap.Error(err))
+			return
+		}
+
+		for _, batch := range resp.Batches {
+			if batch.Status != "in_progress" {
+				continue
+			}
+			logger := g.logger.With(zap.String("batch_id", batch.ID))
+			if err := g.provider.PauseBatch(ctx, batch.ID); err != nil {
+				logger.Warn("failed to pause batch over budget", zap.Error(err))
+				continue
+			}
+			if err := g.repo.AddPausedBatch(ctx, batch.ID); err != nil {
+				logger.Error("failed to record batch paused over budget", zap.Error(err))
+				continue
+			}
+			logger.Info("paused batch over budget")
+		}
+
+		if len(resp.Batches) < budgetBatchPageSize {
+			return
+		}
+	}
+}
+
+// resumePausedBatches resumes the batches the guard paused. Failures are
+// logged and retried on the next refresh.
+func (g *BudgetGuard) resumePausedBatches(ctx context.Context) {
+	batchIDs, err := g.repo.ListPausedBatches(ctx)
+	if err != nil {
+		g.logger.Warn("failed to list batches paused over budget", zap.Error(err))
+		return
+	}
+
+	for _, batchID := range batchIDs {
+		logger := g.logger.With(zap.String("batch_id", batchID))
+		if err := g.provider.ResumeBatch(ctx, batchID); err != nil {
+			logger.Warn("failed to resume batch paused over budget", zap.Error(err))
+			continue
+		}
+		if err := g.repo.RemovePausedBatch(ctx, batchID); err != nil {
+			logger.Error("failed to forget resumed batch", zap.Error(err))
+			continue
+		}
+		logger.Info("resumed batch paused over budget")
+	}
+}
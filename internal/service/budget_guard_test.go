@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockBudgetRepository is an in-memory BudgetRepository.
+type MockBudgetRepository struct {
+	mu          sync.Mutex
+	overrides   []*domain.BudgetOverride
+	paused      []string
+	callMinutes float64
+	since       time.Time
+}
+
+func (m *MockBudgetRepository) CreateOverride(ctx context.Context, o *domain.BudgetOverride) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *o
+	m.overrides = append(m.overrides, &cp)
+	return nil
+}
+
+func (m *MockBudgetRepository) GetActiveOverride(ctx context.Context, now time.Time) (*domain.BudgetOverride, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var active *domain.BudgetOverride
+	for _, o := range m.overrides {
+		if o.IsActive(now) && (active == nil || o.ExpiresAt.After(active.ExpiresAt)) {
+			active = o
+		}
+	}
+	if active == nil {
+		return nil, nil
+	}
+	cp := *active
+	return &cp, nil
+}
+
+func (m *MockBudgetRepository) RevokeOverrides(ctx context.Context, now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	revoked := 0
+	for _, o := range m.overrides {
+		if o.IsActive(now) {
+			o.RevokedAt = &now
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (m *MockBudgetRepository) AddPausedBatch(ctx context.Context, batchID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range m.paused {
+		if id == batchID {
+			return nil
+		}
+	}
+	m.paused = append(m.paused, batchID)
+	return nil
+}
+
+func (m *MockBudgetRepository) ListPausedBatches(ctx context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.paused...), nil
+}
+
+func (m *MockBudgetRepository) RemovePausedBatch(ctx context.Context, batchID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, id := range m.paused {
+		if id == batchID {
+			m.paused = append(m.paused[:i], m.paused[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockBudgetRepository) CallMinutesSince(ctx context.Context, since time.Time) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since = since
+	return m.callMinutes, nil
+}
+
+// fakeBudgetProvider reports a fixed spend and tracks batch state.
+type fakeBudgetProvider struct {
+	spend    float64
+	usageErr error
+	batches  map[string]string // batch ID -> status
+}
+
+func (f *fakeBudgetProvider) GetUsageSummary(ctx context.Context, req *bland.GetUsageSummaryRequest) (*bland.UsageSummary, error) {
+	if f.usageErr != nil {
+		return nil, f.usageErr
+	}
+	return &bland.UsageSummary{TotalCost: f.spend}, nil
+}
+
+func (f *fakeBudgetProvider) ListBatches(ctx context.Context, limit, offset int) (*bland.ListBatchesResponse, error) {
+	resp := &bland.ListBatchesResponse{}
+	if offset > 0 {
+		return resp, nil
+	}
+	for id, status := range f.batches {
+		resp.Batches = append(resp.Batches, bland.Batch{ID: id, Status: status})
+	}
+	return resp, nil
+}
+
+func (f *fakeBudgetProvider) PauseBatch(ctx context.Context, batchID string) error {
+	f.batches[batchID] = "paused"
+	return nil
+}
+
+func (f *fakeBudgetProvider) ResumeBatch(ctx context.Context, batchID string) error {
+	f.batches[batchID] = "in_progress"
+	return nil
+}
+
+func newTestBudgetGuard(now time.Time, provider *fakeBudgetProvider) (*BudgetGuard, *MockBudgetRepository) {
+	repo := &MockBudgetRepository{}
+	guard := NewBudgetGuard(provider, repo, zap.NewNop(), &BudgetGuardConfig{
+		HardCap:       100,
+		CostPerMinute: 0.10,
+	}, clock.NewMock(now))
+	return guard, repo
+}
+
+func TestBudgetGuard_BlocksAndPausesBatchesAtHardCap(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	provider := &fakeBudgetProvider{
+		spend:   95,
+		batches: map[string]string{"running": "in_progress", "done": "completed"},
+	}
+	guard, repo := newTestBudgetGuard(now, provider)
+	repo.callMinutes = 60 // $6 Bland has not billed yet
+	ctx := context.Background()
+
+	status, err := guard.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if status.Spend != 101 || status.EstimatedSpend != 6 || !status.Blocked {
+		t.Fatalf("status = %+v, want $101 spend and blocked", status)
+	}
+	if want := now.Add(-providerUsageLag); !repo.since.Equal(want) {
+		t.Errorf("estimated calls since %v, want %v", repo.since, want)
+	}
+	if provider.batches["running"] != "paused" || provider.batches["done"] != "completed" {
+		t.Errorf("batches = %v, want only the running batch paused", provider.batches)
+	}
+	if len(status.PausedBatches) != 1 || status.PausedBatches[0] != "running" {
+		t.Errorf("PausedBatches = %v, want [running]", status.PausedBatches)
+	}
+
+	err = guard.CheckBudget(ctx)
+	if apperrors.GetCode(err) != apperrors.CodeBudgetExceeded {
+		t.Errorf("CheckBudget() error = %v, want budget exceeded", err)
+	}
+}
+
+func TestBudgetGuard_OverrideResumesBatches(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	provider := &fakeBudgetProvider{spend: 150, batches: map[string]string{"running": "in_progress"}}
+	guard, repo := newTestBudgetGuard(now, provider)
+	ctx := context.Background()
+
+	if _, err := guard.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if _, err := guard.Override(ctx, &BudgetOverrideRequest{}); !apperrors.IsUserError(err) {
+		t.Errorf("Override() without a reason error = %v, want validation error", err)
+	}
+
+	status, err := guard.Override(ctx, &BudgetOverrideRequest{Reason: "end of quarter push"})
+	if err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+	if status.Blocked || !status.Exceeded || status.Override == nil {
+		t.Fatalf("status = %+v, want exceeded but not blocked", status)
+	}
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !status.Override.ExpiresAt.Equal(want) {
+		t.Errorf("override expires %v, want the end of the month", status.Override.ExpiresAt)
+	}
+	if provider.batches["running"] != "in_progress" || len(repo.paused) != 0 {
+		t.Errorf("batches = %v, paused = %v; want the batch resumed", provider.batches, repo.paused)
+	}
+	if err := guard.CheckBudget(ctx); err != nil {
+		t.Errorf("CheckBudget() with an override error = %v", err)
+	}
+
+	// Revoking the override blocks calling and pauses the batch again
+	status, err = guard.ClearOverride(ctx)
+	if err != nil {
+		t.Fatalf("ClearOverride() error = %v", err)
+	}
+	if !status.Blocked || provider.batches["running"] != "paused" {
+		t.Errorf("status blocked = %v, batches = %v; want blocked and paused", status.Blocked, provider.batches)
+	}
+	if _, err := guard.ClearOverride(ctx); !apperrors.IsNotFound(err) {
+		t.Errorf("ClearOverride() without an override error = %v, want not found", err)
+	}
+}
+
+func TestBudgetGuard_ExpiredOverrideBlocksWithoutRefresh(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	provider := &fakeBudgetProvider{spend: 150, batches: map[string]string{}}
+	guard, _ := newTestBudgetGuard(now, provider)
+	ctx := context.Background()
+
+	until := now.Add(time.Hour)
+	if _, err := guard.Override(ctx, &BudgetOverrideRequest{Reason: "demo", Until: &until}); err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+	if err := guard.CheckBudget(ctx); err != nil {
+		t.Fatalf("CheckBudget() error = %v", err)
+	}
+
+	guard.clock = clock.NewMock(until.Add(time.Second))
+	if err := guard.CheckBudget(ctx); apperrors.GetCode(err) != apperrors.CodeBudgetExceeded {
+		t.Errorf("CheckBudget() after the override expired error = %v, want budget exceeded", err)
+	}
+}
+
+func TestBudgetGuard_EstimatesMonthLocallyWithoutProviderUsage(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	provider := &fakeBudgetProvider{usageErr: errors.New("bland unavailable"), batches: map[string]string{}}
+	guard, repo := newTestBudgetGuard(now, provider)
+	repo.callMinutes = 500
+
+	status, err := guard.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if !repo.since.Equal(domain.BudgetMonthStart(now)) {
+		t.Errorf("estimated calls since %v, want the start of the month", repo.since)
+	}
+	if status.Spend != 50 || status.Blocked || status.SyncedAt != nil {
+		t.Errorf("status = %+v, want $50 estimated and not blocked", status)
+	}
+}
+
+func TestBudgetGuard_DisabledAllowsCalls(t *testing.T) {
+	guard := NewBudgetGuard(&fakeBudgetProvider{spend: 1e6}, &MockBudgetRepository{}, zap.NewNop(), nil, nil)
+
+	if err := guard.CheckBudget(context.Background()); err != nil {
+		t.Errorf("CheckBudget() error = %v", err)
+	}
+	if _, err := guard.Override(context.Background(), &BudgetOverrideRequest{Reason: "x"}); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("Override() error = %v, want conflict", err)
+	}
+}
+
+func TestBudgetGuard_SetLimits(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	provider := &fakeBudgetProvider{spend: 150, batches: map[string]string{"running": "in_progress"}}
+	guard, _ := newTestBudgetGuard(now, provider)
+	ctx := context.Background()
+
+	if _, err := guard.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	// Raising the cap lets calls through again at once
+	if err := guard.SetLimits(ctx, 200, 0.10); err != nil {
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	if err := guard.CheckBudget(ctx); err != nil {
+		t.Errorf("CheckBudget() under the raised cap error = %v", err)
+	}
+	if provider.batches["running"] != "in_progress" {
+		t.Errorf("batch = %s, want resumed under the raised cap", provider.batches["running"])
+	}
+
+	// Lowering it pauses batches; removing it disables the guard
+	if err := guard.SetLimits(ctx, 100, 0.10); err != nil {
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	if provider.batches["running"] != "paused" {
+		t.Fatalf("batch = %s, want paused over the lowered cap", provider.batches["running"])
+	}
+	if err := guard.SetLimits(ctx, 0, 0.10); err != nil {
+		t.Fatalf("SetLimits() error = %v", err)
+	}
+	if guard.Enabled() || guard.CheckBudget(ctx) != nil || provider.batches["running"] != "in_progress" {
+		t.Errorf("enabled = %v, batch = %s; want the guard off and the batch resumed", guard.Enabled(), provider.batches["running"])
+	}
+}
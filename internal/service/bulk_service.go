@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// BulkService applies one action to many calls or quotes. Every record is
+// checked before anything changes; if the action can't be applied to one
+// of them, none is changed. Archiving, tagging and assigning then change
+// every record in one transaction. Re-running quotes queues a job per call,
+// and exports are written by the caller from ExportFilter.
+type BulkService struct {
+	calls       domain.CallRepository
+	assignments domain.CallAssignmentRepository
+	users       domain.UserRepository
+	archive     *CallArchiveService
+	quotes      *QuoteService
+	tags        *TagService
+	jobs        QuoteJobEnqueuer
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+
+	// Configuration
+	maxItems int
+}
+
+// BulkServiceConfig holds configuration for the bulk service.
+type BulkServiceConfig struct {
+	MaxItems int // Most records one action can name; 0 is unlimited
+}
+
+// DefaultBulkServiceConfig returns sensible defaults.
+func DefaultBulkServiceConfig() *BulkServiceConfig {
+	return &BulkServiceConfig{
+		MaxItems: 200,
+	}
+}
+
+// NewBulkService creates a new BulkService. auditLogger may be nil.
+func NewBulkService(
+	calls domain.CallRepository,
+	assignments domain.CallAssignmentRepository,
+	users domain.UserRepository,
+	archive *CallArchiveService,
+	quotes *QuoteService,
+	tags *TagService,
+	jobs QuoteJobEnqueuer,
+	auditLogger *audit.Logger,
+	logger *zap.Logger,
+	config *BulkServiceConfig,
+) *BulkService {
+	if config == nil {
+		config = DefaultBulkServiceConfig()
+	}
+	return &BulkService{
+		calls:       calls,
+		assignments: assignments,
+		users:       users,
+		archive:     archive,
+		quotes:      quotes,
+		tags:        tags,
+		jobs:        jobs,
+		auditLogger: auditLogger,
+		logger:      logger,
+		maxItems:    config.MaxItems,
+	}
+}
+
+// BulkRequest holds a bulk action and the records it applies to.
+type BulkRequest struct {
+	Action domain.BulkAction `json:"action"`
+	IDs    []uuid.UUID       `json:"ids"`
+	Tag    string            `json:"tag,omitempty"`     // Tag to apply, for tag
+	UserID *uuid.UUID        `json:"user_id,omitempty"` // User to assign to, for assign; nil unassigns
+}
+
+// Run applies a bulk action other than export and reports what it did to
+// each record. When some record can't take the action the result is not
+// applied and nothing is changed.
+func (s *BulkService) Run(ctx context.Context, actor UserActor, recordType domain.BulkRecordType, req *BulkRequest) (*domain.BulkResult, error) {
+	if _, err := domain.ParseBulkAction(string(req.Action)); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	if req.Action == domain.BulkActionExport {
+		return nil, apperrors.ValidationFailed("exports are downloaded, not run")
+	}
+
+	var assignee *domain.User
+	switch req.Action {
+	case domain.BulkActionTag:
+		if strings.TrimSpace(req.Tag) == "" {
+			return nil, apperrors.ValidationFailed("tag is required to tag records")
+		}
+	case domain.BulkActionAssign:
+		if req.UserID != nil {
+			user, err := s.users.GetByID(ctx, *req.UserID)
+			if err != nil && !apperrors.IsNotFound(err) {
+				return nil, err
+			}
+			if user == nil || user.IsDeleted() || user.IsDisabled() {
+				return nil, apperrors.ValidationFailed("user_id must be an active user")
+			}
+			assignee = user
+		}
+	}
+
+	result, calls, err := s.check(ctx, recordType, req.Action, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+	if result.HasFailures() {
+		result.Reject()
+		return result, nil
+	}
+
+	ids := make([]uuid.UUID, len(calls))
+	for i, call := range calls {
+		ids[i] = call.ID
+	}
+
+	switch req.Action {
+	case domain.BulkActionArchive:
+		err = s.archiveAll(ctx, actor, recordType, ids)
+	case domain.BulkActionTag:
+		var failures map[uuid.UUID]error
+		failures, err = s.tags.AddTagToMany(ctx, actor, bulkTagEntity(recordType), ids, req.Tag)
+		if len(failures) > 0 {
+			for i, id := range ids {
+				if failure, ok := failures[id]; ok {
+					result.Fail(i, failure)
+				}
+			}
+			result.Reject()
+			return result, nil
+		}
+	case domain.BulkActionAssign:
+		err = s.assignAll(ctx, actor, ids, assignee)
+	case domain.BulkActionRerunQuote:
+		s.rerunQuotes(ctx, result, calls)
+		result.Complete(domain.BulkItemQueued)
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Complete(domain.BulkItemApplied)
+	s.logger.Info("bulk action applied",
+		zap.String("action", string(req.Action)),
+		zap.String("record_type", string(recordType)),
+		zap.Int("count", len(ids)),
+	)
+	return result, nil
+}
+
+// Assignees lists the users records can be assigned to.
+func (s *BulkService) Assignees(ctx context.Context) ([]*domain.User, error) {
+	users, err := s.users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := users[:0]
+	for _, user := range users {
+		if !user.IsDeleted() && !user.IsDisabled() {
+			active = append(active, user)
+		}
+	}
+	return active, nil
+}
+
+// ExportFilter checks that every record can be exported and returns the
+// filter that lists them. When some record can't be, the result is not
+// applied and the filter is nil.
+func (s *BulkService) ExportFilter(ctx context.Context, recordType domain.BulkRecordType, ids []uuid.UUID) (*domain.BulkResult, *domain.CallListFilter, error) {
+	result, calls, err := s.check(ctx, recordType, domain.BulkActionExport, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.HasFailures() {
+		result.Reject()
+		return result, nil, nil
+	}
+
+	filter := &domain.CallListFilter{IDs: make([]uuid.UUID, len(calls))}
+	for i, call := range calls {
+		filter.IDs[i] = call.ID
+	}
+	result.Complete(domain.BulkItemApplied)
+	return result, filter, nil
+}
+
+// check loads every record, in the order named and without repeats, and
+// fails those the action can't be applied to.
+func (s *BulkService) check(ctx context.Context, recordType domain.BulkRecordType, action domain.BulkAction, ids []uuid.UUID) (*domain.BulkResult, []*domain.Call, error) {
+	if recordType != domain.BulkRecordCall && recordType != domain.BulkRecordQuote {
+		return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("invalid record type %q", recordType))
+	}
+	if len(ids) == 0 {
+		return nil, nil, apperrors.ValidationFailed("ids must name at least one record")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if s.maxItems > 0 && len(unique) > s.maxItems {
+		return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("at most %d records can be changed at once", s.maxItems))
+	}
+
+	result := &domain.BulkResult{Action: action, Items: make([]domain.BulkItemResult, len(unique))}
+	calls := make([]*domain.Call, len(unique))
+	for i, id := range unique {
+		result.Items[i].ID = id
+
+		call, err := s.calls.GetByID(ctx, id)
+		if err != nil {
+			if !apperrors.IsNotFound(err) {
+				return nil, nil, err
+			}
+			result.Fail(i, apperrors.NotFound(string(recordType)))
+			continue
+		}
+		calls[i] = call
+
+		switch {
+		case call.IsDeleted():
+			result.Fail(i, apperrors.NotFound(string(recordType)))
+		case recordType == domain.BulkRecordQuote && !call.HasQuote():
+			result.Fail(i, apperrors.NotFound("quote"))
+		case action == domain.BulkActionRerunQuote && (call.Transcript == nil || *call.Transcript == ""):
+			result.Fail(i, errors.New("call has no transcript"))
+		}
+	}
+	return result, calls, nil
+}
+
+// archiveAll archives calls with their quotes, or only the quotes.
+func (s *BulkService) archiveAll(ctx context.Context, actor UserActor, recordType domain.BulkRecordType, ids []uuid.UUID) error {
+	if recordType == domain.BulkRecordQuote {
+		return s.quotes.ArchiveQuotes(ctx, ids, QuoteActor(actor))
+	}
+	if err := s.archive.ArchiveMany(ctx, ids); err != nil {
+		return err
+	}
+	if s.auditLogger != nil {
+		for _, id := range ids {
+			s.auditLogger.CallArchived(ctx, actor.id(), actor.email(), id.String(), actor.IP, actor.RequestID)
+		}
+	}
+	return nil
+}
+
+// assignAll assigns calls, and the quotes on them, to a user, or unassigns
+// them when user is nil.
+func (s *BulkService) assignAll(ctx context.Context, actor UserActor, ids []uuid.UUID, user *domain.User) error {
+	var userID *uuid.UUID
+	var assigneeID string
+	if user != nil {
+		userID = &user.ID
+		assigneeID = user.ID.String()
+	}
+	if err := s.assignments.Assign(ctx, ids, userID); err != nil {
+		return err
+	}
+	if s.auditLogger != nil {
+		for _, id := range ids {
+			s.auditLogger.CallAssigned(ctx, actor.id(), actor.email(), id.String(), assigneeID, actor.IP, actor.RequestID)
+		}
+	}
+	return nil
+}
+
+// rerunQuotes queues quote generation for each call. A job already queued
+// for a call is reused. Calls whose job can't be queued are failed; the
+// others are still queued.
+func (s *BulkService) rerunQuotes(ctx context.Context, result *domain.BulkResult, calls []*domain.Call) {
+	for i, call := range calls {
+		job, err := s.jobs.EnqueueJob(ctx, call.ID)
+		if err != nil {
+			s.logger.Error("failed to queue quote job",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+			result.Fail(i, errors.New("failed to queue quote generation"))
+			continue
+		}
+		jobID := job.ID
+		result.Items[i].JobID = &jobID
+		if err := s.calls.SetQuoteJobID(ctx, call.ID, &jobID); err != nil && !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to set quote job id",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// bulkTagEntity returns the tag entity type of a bulk record type.
+func bulkTagEntity(recordType domain.BulkRecordType) domain.TagEntityType {
+	if recordType == domain.BulkRecordQuote {
+		return domain.TagEntityQuote
+	}
+	return domain.TagEntityCall
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubCallAssignmentRepo struct {
+	assigned map[uuid.UUID]*uuid.UUID
+}
+
+func (r *stubCallAssignmentRepo) Assign(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) error {
+	for _, id := range ids {
+		r.assigned[id] = userID
+	}
+	return nil
+}
+
+type bulkTestEnv struct {
+	svc         *BulkService
+	calls       *MockCallRepository
+	users       *MockUserRepository
+	archive     *stubCallArchiveRepo
+	assignments *stubCallAssignmentRepo
+	tags        *stubTagRepo
+	jobs        *fakeQuoteJobEnqueuer
+}
+
+func newBulkTestEnv(maxItems int) *bulkTestEnv {
+	env := &bulkTestEnv{
+		calls:       NewMockCallRepository(),
+		users:       NewMockUserRepository(),
+		archive:     &stubCallArchiveRepo{archived: map[uuid.UUID]bool{}},
+		assignments: &stubCallAssignmentRepo{assigned: map[uuid.UUID]*uuid.UUID{}},
+		tags:        newStubTagRepo(),
+		jobs:        &fakeQuoteJobEnqueuer{},
+	}
+	archive := NewCallArchiveService(env.archive, CallArchivePolicy{}, zap.NewNop(), nil)
+	tags := NewTagService(env.tags, env.calls, NewMockCustomerRepository(), nil, zap.NewNop(), &TagServiceConfig{FreeForm: true})
+	env.svc = NewBulkService(env.calls, env.assignments, env.users, archive, nil, tags, env.jobs, nil, zap.NewNop(), &BulkServiceConfig{MaxItems: maxItems})
+	return env
+}
+
+func (env *bulkTestEnv) addCall(transcript string) uuid.UUID {
+	call := domain.NewCall("prov-"+uuid.NewString(), "bland", "+15551234567", "+15557654321")
+	if transcript != "" {
+		call.Transcript = &transcript
+	}
+	env.calls.calls[call.ID] = call
+	return call.ID
+}
+
+func bulkTestActor() UserActor {
+	return UserActor{User: &domain.User{ID: uuid.New(), Email: "rep@example.com", Role: domain.UserRoleMember}}
+}
+
+func TestBulkService_Archive(t *testing.T) {
+	env := newBulkTestEnv(10)
+	first, second := env.addCall(""), env.addCall("")
+
+	result, err := env.svc.Run(context.Background(), bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionArchive,
+		IDs:    []uuid.UUID{first, second, first},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.Applied || result.Succeeded != 2 || result.Failed != 0 || len(result.Items) != 2 {
+		t.Fatalf("result = %+v, want both calls applied once", result)
+	}
+	if !env.archive.archived[first] || !env.archive.archived[second] {
+		t.Errorf("archived = %v, want both calls", env.archive.archived)
+	}
+}
+
+func TestBulkService_RejectsAllWhenOneFails(t *testing.T) {
+	env := newBulkTestEnv(10)
+	existing, missing := env.addCall(""), uuid.New()
+
+	result, err := env.svc.Run(context.Background(), bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionArchive,
+		IDs:    []uuid.UUID{existing, missing},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Applied {
+		t.Fatal("result applied despite a missing call")
+	}
+	if result.Items[0].Status != domain.BulkItemRejected || result.Items[1].Status != domain.BulkItemFailed {
+		t.Errorf("statuses = %s, %s; want rejected, failed", result.Items[0].Status, result.Items[1].Status)
+	}
+	if result.Items[1].Error == "" {
+		t.Error("failed item has no error")
+	}
+	if len(env.archive.archived) != 0 {
+		t.Errorf("archived %d calls, want none", len(env.archive.archived))
+	}
+}
+
+func TestBulkService_Tag(t *testing.T) {
+	env := newBulkTestEnv(10)
+	first, second := env.addCall(""), env.addCall("")
+	ctx := context.Background()
+
+	if _, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionTag,
+		IDs:    []uuid.UUID{first},
+	}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("tagging without a tag: error = %v, want validation failure", err)
+	}
+
+	result, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionTag,
+		IDs:    []uuid.UUID{first, second},
+		Tag:    "Hot Lead",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.Applied || result.Succeeded != 2 {
+		t.Fatalf("result = %+v, want both calls tagged", result)
+	}
+	for _, id := range []uuid.UUID{first, second} {
+		if len(env.tags.applied[tagRecordKey(domain.TagEntityCall, id)]) != 1 {
+			t.Errorf("call %s not tagged", id)
+		}
+	}
+}
+
+func TestBulkService_Assign(t *testing.T) {
+	env := newBulkTestEnv(10)
+	callID := env.addCall("")
+	ctx := context.Background()
+
+	assignee := &domain.User{ID: uuid.New(), Email: "owner@example.com", Role: domain.UserRoleMember}
+	disabled := &domain.User{ID: uuid.New(), Email: "gone@example.com", Role: domain.UserRoleMember}
+	disabled.Disable()
+	env.users.users[assignee.ID] = assignee
+	env.users.users[disabled.ID] = disabled
+
+	if _, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionAssign,
+		IDs:    []uuid.UUID{callID},
+		UserID: &disabled.ID,
+	}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("assigning to a disabled user: error = %v, want validation failure", err)
+	}
+
+	result, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionAssign,
+		IDs:    []uuid.UUID{callID},
+		UserID: &assignee.ID,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.Applied {
+		t.Fatalf("result = %+v, want applied", result)
+	}
+	if got := env.assignments.assigned[callID]; got == nil || *got != assignee.ID {
+		t.Errorf("assigned to %v, want %s", got, assignee.ID)
+	}
+
+	if _, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionAssign,
+		IDs:    []uuid.UUID{callID},
+	}); err != nil {
+		t.Fatalf("unassign: Run() error = %v", err)
+	}
+	if got := env.assignments.assigned[callID]; got != nil {
+		t.Errorf("assigned to %s after unassigning", got)
+	}
+}
+
+func TestBulkService_RerunQuote(t *testing.T) {
+	env := newBulkTestEnv(10)
+	withTranscript, withoutTranscript := env.addCall("I need a mobile app"), env.addCall("")
+	ctx := context.Background()
+
+	result, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionRerunQuote,
+		IDs:    []uuid.UUID{withTranscript, withoutTranscript},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Applied || len(env.jobs.callIDs) != 0 {
+		t.Fatalf("queued %d jobs despite a call with no transcript", len(env.jobs.callIDs))
+	}
+
+	result, err = env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, &BulkRequest{
+		Action: domain.BulkActionRerunQuote,
+		IDs:    []uuid.UUID{withTranscript},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.Applied || result.Items[0].Status != domain.BulkItemQueued || result.Items[0].JobID == nil {
+		t.Fatalf("result = %+v, want the call queued with a job", result)
+	}
+	if len(env.jobs.callIDs) != 1 || env.jobs.callIDs[0] != withTranscript {
+		t.Errorf("queued jobs for %v, want %s", env.jobs.callIDs, withTranscript)
+	}
+}
+
+func TestBulkService_Limits(t *testing.T) {
+	env := newBulkTestEnv(1)
+	first, second := env.addCall(""), env.addCall("")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  *BulkRequest
+	}{
+		{"no ids", &BulkRequest{Action: domain.BulkActionArchive}},
+		{"too many ids", &BulkRequest{Action: domain.BulkActionArchive, IDs: []uuid.UUID{first, second}}},
+		{"unknown action", &BulkRequest{Action: "delete", IDs: []uuid.UUID{first}}},
+		{"export", &BulkRequest{Action: domain.BulkActionExport, IDs: []uuid.UUID{first}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.svc.Run(ctx, bulkTestActor(), domain.BulkRecordCall, tt.req); apperrors.GetCode(err) != apperrors.CodeValidation {
+				t.Errorf("Run() error = %v, want validation failure", err)
+			}
+		})
+	}
+}
+
+func TestBulkService_ExportFilter(t *testing.T) {
+	env := newBulkTestEnv(0)
+	withQuote, withoutQuote := env.addCall(""), env.addCall("")
+	summary := "Mobile app, 12 weeks"
+	env.calls.calls[withQuote].QuoteSummary = &summary
+	ctx := context.Background()
+
+	result, filter, err := env.svc.ExportFilter(ctx, domain.BulkRecordQuote, []uuid.UUID{withQuote, withoutQuote})
+	if err != nil {
+		t.Fatalf("ExportFilter() error = %v", err)
+	}
+	if result.Applied || filter != nil {
+		t.Errorf("exporting a call without a quote as a quote: result = %+v, filter = %v", result, filter)
+	}
+
+	result, filter, err = env.svc.ExportFilter(ctx, domain.BulkRecordQuote, []uuid.UUID{withQuote})
+	if err != nil {
+		t.Fatalf("ExportFilter() error = %v", err)
+	}
+	if !result.Applied || filter == nil || len(filter.IDs) != 1 || filter.IDs[0] != withQuote {
+		t.Errorf("result = %+v, filter = %+v; want the quote's call", result, filter)
+	}
+}
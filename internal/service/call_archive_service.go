@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// CallArchivePolicy is when finished calls are archived automatically.
+type CallArchivePolicy struct {
+	CallsAfter time.Duration // Age, from creation, at which a call is archived
+	BatchSize  int           // Most calls archived in one statement
+}
+
+// CallArchiveService archives, soft-deletes and restores calls along with
+// their quotes, and applies the archival policy that keeps everyday lists
+// to recent calls.
+type CallArchiveService struct {
+	repo   domain.CallArchiveRepository
+	policy CallArchivePolicy
+	logger *zap.Logger
+	clock  clock.Clock
+}
+
+// NewCallArchiveService creates a new CallArchiveService.
+func NewCallArchiveService(repo domain.CallArchiveRepository, policy CallArchivePolicy, logger *zap.Logger, clk clock.Clock) *CallArchiveService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &CallArchiveService{
+		repo:   repo,
+		policy: policy,
+		logger: logger,
+		clock:  clk,
+	}
+}
+
+// Archive archives a call and its quote.
+func (s *CallArchiveService) Archive(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Archive(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("call archived", zap.String("call_id", id.String()))
+	return nil
+}
+
+// ArchiveMany archives calls and their quotes together: either every call
+// is archived or, if one is missing, none is.
+func (s *CallArchiveService) ArchiveMany(ctx context.Context, ids []uuid.UUID) error {
+	if err := s.repo.ArchiveMany(ctx, ids); err != nil {
+		return err
+	}
+	s.logger.Info("calls archived", zap.Int("count", len(ids)))
+	return nil
+}
+
+// Unarchive returns an archived call and its quote to everyday lists.
+func (s *CallArchiveService) Unarchive(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Unarchive(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("call unarchived", zap.String("call_id", id.String()))
+	return nil
+}
+
+// Delete soft-deletes a call and its quote. Deleted calls can be restored.
+func (s *CallArchiveService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("call deleted", zap.String("call_id", id.String()))
+	return nil
+}
+
+// Restore brings back a soft-deleted call and its quote.
+func (s *CallArchiveService) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("call restored", zap.String("call_id", id.String()))
+	return nil
+}
+
+// ApplyPolicy archives every finished call older than the policy's age, a
+// batch at a time, and returns how many it archived.
+func (s *CallArchiveService) ApplyPolicy(ctx context.Context) (int, error) {
+	if s.policy.CallsAfter <= 0 || s.policy.BatchSize <= 0 {
+		return 0, nil
+	}
+
+	cutoff := s.clock.Now().UTC().Add(-s.policy.CallsAfter)
+	total := 0
+	for {
+		n, err := s.repo.ArchiveCreatedBefore(ctx, cutoff, s.policy.BatchSize)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("failed to archive calls: %w", err)
+		}
+		if n < s.policy.BatchSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+
+	if total > 0 {
+		s.logger.Info("archived old calls",
+			zap.Int("count", total),
+			zap.Time("created_before", cutoff),
+		)
+	}
+	return total, nil
+}
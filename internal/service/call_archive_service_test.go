@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubCallArchiveRepo struct {
+	old      int // Calls left for the policy to archive
+	cutoffs  []time.Time
+	archived map[uuid.UUID]bool
+}
+
+func (r *stubCallArchiveRepo) Archive(ctx context.Context, id uuid.UUID) error {
+	r.archived[id] = true
+	return nil
+}
+
+func (r *stubCallArchiveRepo) Unarchive(ctx context.Context, id uuid.UUID) error {
+	if !r.archived[id] {
+		return apperrors.NotFound("call")
+	}
+	delete(r.archived, id)
+	return nil
+}
+
+func (r *stubCallArchiveRepo) Delete(ctx context.Context, id uuid.UUID) error  { return nil }
+func (r *stubCallArchiveRepo) Restore(ctx context.Context, id uuid.UUID) error { return nil }
+
+func (r *stubCallArchiveRepo) ArchiveMany(ctx context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		r.archived[id] = true
+	}
+	return nil
+}
+
+func (r *stubCallArchiveRepo) ArchiveCreatedBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	r.cutoffs = append(r.cutoffs, cutoff)
+	n := min(limit, r.old)
+	r.old -= n
+	return n, nil
+}
+
+func TestCallArchiveService_ApplyPolicy(t *testing.T) {
+	repo := &stubCallArchiveRepo{old: 25}
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewCallArchiveService(repo, CallArchivePolicy{CallsAfter: 365 * 24 * time.Hour, BatchSize: 10}, zap.NewNop(), clock.NewMock(now))
+
+	archived, err := svc.ApplyPolicy(context.Background())
+	if err != nil {
+		t.Fatalf("ApplyPolicy() error = %v", err)
+	}
+	if archived != 25 {
+		t.Errorf("archived = %d, want 25", archived)
+	}
+	if len(repo.cutoffs) != 3 {
+		t.Fatalf("ran %d batches, want 3", len(repo.cutoffs))
+	}
+	if want := now.Add(-365 * 24 * time.Hour); !repo.cutoffs[0].Equal(want) {
+		t.Errorf("cutoff = %v, want %v", repo.cutoffs[0], want)
+	}
+}
+
+func TestCallArchiveService_ApplyPolicyDisabled(t *testing.T) {
+	repo := &stubCallArchiveRepo{old: 5}
+	svc := NewCallArchiveService(repo, CallArchivePolicy{}, zap.NewNop(), nil)
+
+	if archived, err := svc.ApplyPolicy(context.Background()); err != nil || archived != 0 {
+		t.Errorf("ApplyPolicy() = %d, %v; want nothing archived", archived, err)
+	}
+	if len(repo.cutoffs) != 0 {
+		t.Errorf("ran %d batches without a policy", len(repo.cutoffs))
+	}
+}
+
+func TestCallArchiveService_UnarchiveNotArchived(t *testing.T) {
+	repo := &stubCallArchiveRepo{archived: map[uuid.UUID]bool{}}
+	svc := NewCallArchiveService(repo, CallArchivePolicy{}, zap.NewNop(), nil)
+	id := uuid.New()
+
+	if err := svc.Archive(context.Background(), id); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if err := svc.Unarchive(context.Background(), id); err != nil {
+		t.Fatalf("Unarchive() error = %v", err)
+	}
+	if err := svc.Unarchive(context.Background(), id); !apperrors.IsNotFound(err) {
+		t.Errorf("Unarchive() error = %v, want not found", err)
+	}
+}
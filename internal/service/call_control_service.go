@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// MaxCallMessageLength bounds messages injected into a call.
+const MaxCallMessageLength = 1000
+
+// CallController acts on calls in progress at the voice provider.
+// bland.Client implements it.
+type CallController interface {
+	EndCall(ctx context.Context, callID string) error
+	TransferCall(ctx context.Context, callID, phoneNumber string) error
+	SendCallMessage(ctx context.Context, callID, message string) error
+}
+
+// CallControlService lets operators step into calls in progress: ending
+// them, transferring them to a person, or telling the agent something.
+type CallControlService struct {
+	callRepo   domain.CallRepository
+	controller CallController
+	logger     *zap.Logger
+}
+
+// NewCallControlService creates a new CallControlService.
+func NewCallControlService(callRepo domain.CallRepository, controller CallController, logger *zap.Logger) *CallControlService {
+	return &CallControlService{
+		callRepo:   callRepo,
+		controller: controller,
+		logger:     logger,
+	}
+}
+
+// EndCall hangs up a call in progress. The call is identified by its ID or
+// its provider call ID.
+func (s *CallControlService) EndCall(ctx context.Context, id string) (*domain.Call, error) {
+	call, err := s.activeCall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.controller.EndCall(ctx, call.ProviderCallID); err != nil {
+		return nil, fmt.Errorf("failed to end call: %w", err)
+	}
+
+	s.logger.Info("call ended by operator", zap.String("call_id", call.ID.String()))
+	return call, nil
+}
+
+// TransferCall hands a call in progress over to a phone number, such as a
+// person who can take over from the agent.
+func (s *CallControlService) TransferCall(ctx context.Context, id, phoneNumber string) (*domain.Call, error) {
+	to := normalizePhoneNumber(phoneNumber)
+	if to == "" {
+		return nil, apperrors.ValidationFailed("phone_number must be a valid phone number")
+	}
+
+	call, err := s.activeCall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if to == call.FromNumber || to == call.PhoneNumber {
+		return nil, apperrors.ValidationFailed("phone_number must not be a number already on the call")
+	}
+	if err := s.controller.TransferCall(ctx, call.ProviderCallID, to); err != nil {
+		return nil, fmt.Errorf("failed to transfer call: %w", err)
+	}
+
+	s.logger.Info("call transferred by operator",
+		zap.String("call_id", call.ID.String()),
+		zap.String("to", to),
+	)
+	return call, nil
+}
+
+// SendMessage injects a message into a call in progress for the agent to
+// act on.
+func (s *CallControlService) SendMessage(ctx context.Context, id, message string) (*domain.Call, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, apperrors.ValidationFailed("message is required")
+	}
+	if len(message) > MaxCallMessageLength {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("message must be at most %d characters", MaxCallMessageLength))
+	}
+
+	call, err := s.activeCall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.controller.SendCallMessage(ctx, call.ProviderCallID, message); err != nil {
+		return nil, fmt.Errorf("failed to send message to call: %w", err)
+	}
+
+	s.logger.Info("message sent to call by operator", zap.String("call_id", call.ID.String()))
+	return call, nil
+}
+
+// activeCall finds a call by ID or provider call ID and checks that it can
+// still be acted on.
+func (s *CallControlService) activeCall(ctx context.Context, id string) (*domain.Call, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, apperrors.ValidationFailed("call_id is required")
+	}
+
+	var call *domain.Call
+	var err error
+	if callID, parseErr := uuid.Parse(id); parseErr == nil {
+		call, err = s.callRepo.GetByID(ctx, callID)
+	}
+	if call == nil && (err == nil || apperrors.IsNotFound(err)) {
+		// Provider call IDs may be UUIDs too
+		call, err = s.callRepo.GetByProviderCallID(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Provider != "" && call.Provider != string(voiceprovider.ProviderBland) {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("calls through %s can't be controlled", call.Provider))
+	}
+	if call.IsComplete() {
+		return nil, apperrors.New(apperrors.CodeConflict, "call has already ended")
+	}
+	return call, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// fakeCallController records the actions taken on calls.
+type fakeCallController struct {
+	ended       []string
+	transferred map[string]string
+	messages    map[string]string
+	err         error
+}
+
+func newFakeCallController() *fakeCallController {
+	return &fakeCallController{transferred: map[string]string{}, messages: map[string]string{}}
+}
+
+func (f *fakeCallController) EndCall(ctx context.Context, callID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.ended = append(f.ended, callID)
+	return nil
+}
+
+func (f *fakeCallController) TransferCall(ctx context.Context, callID, phoneNumber string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.transferred[callID] = phoneNumber
+	return nil
+}
+
+func (f *fakeCallController) SendCallMessage(ctx context.Context, callID, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.messages[callID] = message
+	return nil
+}
+
+func newTestCallControlService(t *testing.T, status domain.CallStatus) (*CallControlService, *fakeCallController, *domain.Call) {
+	t.Helper()
+	repo := NewMockCallRepository()
+	call := domain.NewCall("bland-abc", "bland", "+15550000001", "+15550000002")
+	call.Status = status
+	if err := repo.Create(context.Background(), call); err != nil {
+		t.Fatal(err)
+	}
+	controller := newFakeCallController()
+	return NewCallControlService(repo, controller, zap.NewNop()), controller, call
+}
+
+func TestCallControlService_ActsOnActiveCall(t *testing.T) {
+	svc, controller, call := newTestCallControlService(t, domain.CallStatusInProgress)
+	ctx := context.Background()
+
+	if _, err := svc.TransferCall(ctx, call.ID.String(), "(555) 123-4567"); err != nil {
+		t.Fatalf("TransferCall() error = %v", err)
+	}
+	if got := controller.transferred["bland-abc"]; got != "+15551234567" {
+		t.Errorf("transferred to %q, expected the normalized number", got)
+	}
+
+	// Calls can also be addressed by their provider call ID
+	if _, err := svc.SendMessage(ctx, "bland-abc", "  Offer a 10% discount  "); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got := controller.messages["bland-abc"]; got != "Offer a 10% discount" {
+		t.Errorf("sent %q", got)
+	}
+
+	if _, err := svc.EndCall(ctx, call.ID.String()); err != nil {
+		t.Fatalf("EndCall() error = %v", err)
+	}
+	if len(controller.ended) != 1 || controller.ended[0] != "bland-abc" {
+		t.Errorf("ended %v, expected the provider call ID", controller.ended)
+	}
+}
+
+func TestCallControlService_Rejections(t *testing.T) {
+	ctx := context.Background()
+
+	svc, _, call := newTestCallControlService(t, domain.CallStatusCompleted)
+	if _, err := svc.EndCall(ctx, call.ID.String()); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("ending a finished call: expected conflict, got %v", err)
+	}
+	if _, err := svc.EndCall(ctx, "unknown"); !apperrors.IsNotFound(err) {
+		t.Errorf("ending an unknown call: expected not found, got %v", err)
+	}
+
+	svc, controller, call := newTestCallControlService(t, domain.CallStatusInProgress)
+	if _, err := svc.TransferCall(ctx, call.ID.String(), "not a number"); !apperrors.IsUserError(err) {
+		t.Errorf("invalid transfer number: expected a validation error, got %v", err)
+	}
+	if _, err := svc.TransferCall(ctx, call.ID.String(), call.FromNumber); !apperrors.IsUserError(err) {
+		t.Errorf("transfer to the caller: expected a validation error, got %v", err)
+	}
+	if _, err := svc.SendMessage(ctx, call.ID.String(), "   "); !apperrors.IsUserError(err) {
+		t.Errorf("empty message: expected a validation error, got %v", err)
+	}
+
+	controller.err = errors.New("provider unavailable")
+	if _, err := svc.EndCall(ctx, call.ID.String()); err == nil || apperrors.IsUserError(err) {
+		t.Errorf("provider failure: expected an internal error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// Cost rollup limits, in months.
+const (
+	DefaultCostRollupMonths = 3
+	MaxCostRollupMonths     = 24
+)
+
+// CallPricingSource provides the rates calls are priced at.
+type CallPricingSource interface {
+	GetPricingSettings(ctx context.Context) (*domain.PricingSettings, error)
+}
+
+// CallCostService attributes estimated costs to finished calls and totals
+// them by month and billed number.
+type CallCostService struct {
+	repo    domain.CallCostRepository
+	pricing CallPricingSource
+	logger  *zap.Logger
+
+	clock clock.Clock
+}
+
+// NewCallCostService creates a new CallCostService.
+func NewCallCostService(repo domain.CallCostRepository, pricing CallPricingSource, logger *zap.Logger, clk clock.Clock) *CallCostService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &CallCostService{
+		repo:    repo,
+		pricing: pricing,
+		logger:  logger,
+		clock:   clk,
+	}
+}
+
+// CallCostRollups is the monthly cost per billed number over recent months.
+type CallCostRollups struct {
+	Since     time.Time                `json:"since"`
+	Months    int                      `json:"months"`
+	TotalCost float64                  `json:"total_cost"`
+	Rollups   []*domain.CallCostRollup `json:"rollups"`
+}
+
+// PriceCall sets the estimated cost of a finished call from the current
+// pricing settings. Calls that have not ended are left unpriced.
+func (s *CallCostService) PriceCall(ctx context.Context, call *domain.Call) error {
+	if !call.IsComplete() {
+		return nil
+	}
+
+	pricing, err := s.pricing.GetPricingSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load pricing settings: %w", err)
+	}
+
+	call.Cost = call.ComputeCost(pricing)
+	return nil
+}
+
+// MonthlyRollups totals call costs per billed number for the current month
+// and the months before it, newest first. Out of range month counts fall
+// back to DefaultCostRollupMonths.
+func (s *CallCostService) MonthlyRollups(ctx context.Context, months int) (*CallCostRollups, error) {
+	if months < 1 || months > MaxCostRollupMonths {
+		months = DefaultCostRollupMonths
+	}
+	since := domain.BudgetMonthStart(s.clock.Now()).AddDate(0, 1-months, 0)
+
+	rollups, err := s.repo.ListCostRollups(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list call cost rollups: %w", err)
+	}
+
+	result := &CallCostRollups{
+		Since:   since,
+		Months:  months,
+		Rollups: []*domain.CallCostRollup{},
+	}
+	for _, rollup := range rollups {
+		result.TotalCost += rollup.TotalCost
+		result.Rollups = append(result.Rollups, rollup)
+	}
+	return result, nil
+}
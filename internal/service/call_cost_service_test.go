@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// fakeCallPricing returns fixed pricing settings.
+type fakeCallPricing struct {
+	settings *domain.PricingSettings
+	err      error
+}
+
+func (f *fakeCallPricing) GetPricingSettings(ctx context.Context) (*domain.PricingSettings, error) {
+	return f.settings, f.err
+}
+
+// fakeCallCostRepository returns fixed rollups and records the window asked for.
+type fakeCallCostRepository struct {
+	rollups []*domain.CallCostRollup
+	since   time.Time
+}
+
+func (f *fakeCallCostRepository) ListCostRollups(ctx context.Context, since time.Time) ([]*domain.CallCostRollup, error) {
+	f.since = since
+	return f.rollups, nil
+}
+
+func TestCallService_ProcessCallEvent_PricesFinishedCalls(t *testing.T) {
+	callService, _, _ := newTestCallService()
+	pricing := &fakeCallPricing{settings: domain.NewPricingSettingsFromMap(nil)}
+	callService.SetCallCoster(NewCallCostService(&fakeCallCostRepository{}, pricing, zap.NewNop(), nil))
+	ctx := context.Background()
+
+	event := &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "provider-call-cost",
+		ToNumber:       "+15550001111",
+		FromNumber:     "+15552223333",
+		Status:         voiceprovider.CallStatusInProgress,
+	}
+	call, err := callService.ProcessCallEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	if call.Cost != nil {
+		t.Errorf("in progress call Cost = %+v, want unpriced", call.Cost)
+	}
+
+	event.Status = voiceprovider.CallStatusCompleted
+	event.DurationSecs = 120
+	call, err = callService.ProcessCallEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	if call.Cost == nil || call.Cost.BilledNumber != "+15550001111" || call.Cost.TotalCost != 0.18 {
+		t.Errorf("Cost = %+v, want 2 inbound minutes at $0.09 billed to +15550001111", call.Cost)
+	}
+
+	// A pricing failure leaves the call unpriced but still processed
+	pricing.err = errors.New("settings unavailable")
+	event.ProviderCallID = "provider-call-cost-2"
+	call, err = callService.ProcessCallEvent(ctx, event)
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() with pricing unavailable error = %v", err)
+	}
+	if call.Cost != nil {
+		t.Errorf("Cost = %+v, want unpriced", call.Cost)
+	}
+}
+
+func TestCallCostService_MonthlyRollups(t *testing.T) {
+	repo := &fakeCallCostRepository{rollups: []*domain.CallCostRollup{
+		{PhoneNumber: "+15550001111", TotalCost: 12.5},
+		{PhoneNumber: "+15550002222", TotalCost: 7.25},
+	}}
+	costs := NewCallCostService(repo, &fakeCallPricing{}, zap.NewNop(), clock.NewMock(time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)))
+
+	result, err := costs.MonthlyRollups(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("MonthlyRollups() error = %v", err)
+	}
+	if want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); !repo.since.Equal(want) || result.Months != DefaultCostRollupMonths {
+		t.Errorf("rolled up %d months since %v, want 3 since %v", result.Months, repo.since, want)
+	}
+	if result.TotalCost != 19.75 || len(result.Rollups) != 2 {
+		t.Errorf("result = %+v, want both rollups totalling $19.75", result)
+	}
+
+	if _, err := costs.MonthlyRollups(context.Background(), 1); err != nil {
+		t.Fatalf("MonthlyRollups() error = %v", err)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !repo.since.Equal(want) {
+		t.Errorf("current month rolled up since %v, want %v", repo.since, want)
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// setDisposition stores a disposition for the call unless the call's
+// current disposition takes precedence over one from source, and updates
+// the call to match. Unchanged dispositions are not written again.
+func setDisposition(ctx context.Context, calls domain.CallRepository, call *domain.Call, disposition domain.CallDisposition, source domain.DispositionSource) error {
+	if !call.CanSetDisposition(source) {
+		return nil
+	}
+	if call.Disposition != nil && *call.Disposition == disposition &&
+		call.DispositionSource != nil && *call.DispositionSource == source {
+		return nil
+	}
+	if err := calls.SetDisposition(ctx, call.ID, disposition, source); err != nil {
+		return err
+	}
+	call.Disposition = &disposition
+	call.DispositionSource = &source
+	return nil
+}
+
+// eventDisposition maps a provider's call event to a local disposition.
+// Calls that reached voicemail are voicemail whatever the provider's
+// disposition says.
+func eventDisposition(event *voiceprovider.CallEvent) (domain.CallDisposition, bool) {
+	if event.Status == voiceprovider.CallStatusVoicemail {
+		return domain.CallDispositionVoicemail, true
+	}
+	return domain.MapProviderDisposition(event.Disposition)
+}
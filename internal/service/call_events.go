@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// CallTimeline is a call's status history.
+type CallTimeline struct {
+	CallID uuid.UUID                   `json:"call_id"`
+	Status domain.CallStatus           `json:"status"` // Replayed from the events
+	Events []*domain.CallTimelineEntry `json:"events"` // In the order they took effect
+}
+
+// GetCallTimeline replays a call's status events. A call with no events,
+// or when the event log is not enabled, has an empty timeline and its
+// stored status.
+func (s *CallService) GetCallTimeline(ctx context.Context, callID uuid.UUID) (*CallTimeline, error) {
+	call, err := s.callRepo.GetByID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &CallTimeline{CallID: call.ID, Status: call.Status, Events: []*domain.CallTimelineEntry{}}
+	if s.callEvents == nil {
+		return timeline, nil
+	}
+	events, err := s.callEvents.ListByCall(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if status, entries := domain.ReplayCallEvents(events); status != "" {
+		timeline.Status = status
+		timeline.Events = entries
+	}
+	return timeline, nil
+}
+
+// recordCallPlaced starts the event log of an outbound call: a manual event
+// when a user placed it, otherwise a system one. Failures are logged; the
+// provider's webhooks fill in the rest of the log.
+func recordCallPlaced(ctx context.Context, events domain.CallEventRepository, call *domain.Call, placedBy *uuid.UUID, logger *zap.Logger) {
+	if events == nil {
+		return
+	}
+
+	source := domain.CallEventSourceSystem
+	if placedBy != nil {
+		source = domain.CallEventSourceManual
+	}
+	e := domain.NewCallEvent(call.ID, call.Status, source, call.CreatedAt)
+	e.ActorID = placedBy
+	e.Detail = "placed with " + call.Provider
+	if err := events.Append(ctx, e); err != nil {
+		logger.Warn("failed to record call event",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
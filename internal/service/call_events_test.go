@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+type stubCallEventRepo struct {
+	events []*domain.CallEvent
+}
+
+func (r *stubCallEventRepo) Append(ctx context.Context, e *domain.CallEvent) error {
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *stubCallEventRepo) ListByCall(ctx context.Context, callID uuid.UUID) ([]*domain.CallEvent, error) {
+	var events []*domain.CallEvent
+	for _, e := range r.events {
+		if e.CallID == callID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func TestCallService_ProcessCallEvent_OutOfOrderStatus(t *testing.T) {
+	svc, _, _ := newTestCallService()
+	events := &stubCallEventRepo{}
+	svc.SetCallEvents(events)
+	ctx := context.Background()
+
+	event := func(status voiceprovider.CallStatus) *voiceprovider.CallEvent {
+		return &voiceprovider.CallEvent{
+			Provider:       voiceprovider.ProviderBland,
+			ProviderCallID: "provider-call-123",
+			ToNumber:       "+1234567890",
+			Status:         status,
+		}
+	}
+
+	if _, err := svc.ProcessCallEvent(ctx, event(voiceprovider.CallStatusCompleted)); err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	call, err := svc.ProcessCallEvent(ctx, event(voiceprovider.CallStatusInProgress))
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	if call.Status != domain.CallStatusCompleted {
+		t.Errorf("status = %s, want the completed status kept", call.Status)
+	}
+
+	timeline, err := svc.GetCallTimeline(ctx, call.ID)
+	if err != nil {
+		t.Fatalf("GetCallTimeline() error = %v", err)
+	}
+	if timeline.Status != domain.CallStatusCompleted || len(timeline.Events) != 2 {
+		t.Fatalf("timeline = %+v", timeline)
+	}
+	late := timeline.Events[1]
+	if late.Status != domain.CallStatusInProgress || late.Applied || late.Source != domain.CallEventSourceWebhook {
+		t.Errorf("late event = %+v, want an unapplied in-progress webhook event", late)
+	}
+}
+
+func TestCallService_ProcessCallEvent_RecordsOnlyStatusChanges(t *testing.T) {
+	svc, _, _ := newTestCallService()
+	events := &stubCallEventRepo{}
+	svc.SetCallEvents(events)
+
+	for i := 0; i < 3; i++ {
+		svc.ProcessCallEvent(context.Background(), &voiceprovider.CallEvent{
+			Provider:       voiceprovider.ProviderBland,
+			ProviderCallID: "provider-call-123",
+			Status:         voiceprovider.CallStatusInProgress,
+		})
+	}
+	if len(events.events) != 1 {
+		t.Errorf("recorded %d events, want 1", len(events.events))
+	}
+}
+
+func TestCallService_GetCallTimeline_NotFound(t *testing.T) {
+	svc, _, _ := newTestCallService()
+	svc.SetCallEvents(&stubCallEventRepo{})
+
+	if _, err := svc.GetCallTimeline(context.Background(), uuid.New()); !apperrors.IsNotFound(err) {
+		t.Errorf("GetCallTimeline() error = %v, want not found", err)
+	}
+}
+
+func TestRecordCallPlaced(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name     string
+		placedBy *uuid.UUID
+		want     domain.CallEventSource
+	}{
+		{"by a user", &userID, domain.CallEventSourceManual},
+		{"by the system", nil, domain.CallEventSourceSystem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &stubCallEventRepo{}
+			call := domain.NewCall("provider-call-123", "vapi", "+1234567890", "")
+			recordCallPlaced(context.Background(), events, call, tt.placedBy, zap.NewNop())
+
+			if len(events.events) != 1 {
+				t.Fatalf("recorded %d events, want 1", len(events.events))
+			}
+			e := events.events[0]
+			if e.Source != tt.want || e.Status != domain.CallStatusPending || e.ActorID != tt.placedBy {
+				t.Errorf("event = %+v", e)
+			}
+		})
+	}
+}
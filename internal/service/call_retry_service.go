@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// CallRetryPrompts looks up the prompt a call was placed with.
+// PromptService implements it.
+type CallRetryPrompts interface {
+	GetPrompt(ctx context.Context, id uuid.UUID) (*domain.Prompt, error)
+}
+
+// CallRetryService re-dials numbers whose call went to voicemail or was not
+// answered, following the retry policy of the prompt the call was placed
+// with. Retries are scheduled when the call ends and dialed in the
+// background; reaching the customer cancels the retries still scheduled.
+type CallRetryService struct {
+	repo    domain.CallAttemptRepository
+	calls   domain.CallRepository
+	prompts CallRetryPrompts
+	dialer  CampaignDialer
+	logger  *zap.Logger
+
+	// Configuration
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+
+	clock clock.Clock
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// CallRetryServiceConfig holds configuration for the call retry service.
+type CallRetryServiceConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration // How long a worker holds an attempt before another may dial it
+}
+
+// DefaultCallRetryServiceConfig returns sensible defaults.
+func DefaultCallRetryServiceConfig() *CallRetryServiceConfig {
+	return &CallRetryServiceConfig{
+		PollInterval: 30 * time.Second,
+		BatchSize:    20,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// NewCallRetryService creates a new CallRetryService.
+func NewCallRetryService(
+	repo domain.CallAttemptRepository,
+	calls domain.CallRepository,
+	prompts CallRetryPrompts,
+	dialer CampaignDialer,
+	logger *zap.Logger,
+	config *CallRetryServiceConfig,
+	clk clock.Clock,
+) *CallRetryService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = DefaultCallRetryServiceConfig()
+	}
+
+	return &CallRetryService{
+		repo:         repo,
+		calls:        calls,
+		prompts:      prompts,
+		dialer:       dialer,
+		logger:       logger,
+		pollInterval: config.PollInterval,
+		batchSize:    config.BatchSize,
+		lease:        config.Lease,
+		clock:        clk,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// CallEnded schedules the next attempt for an outbound call that went to
+// voicemail or was not answered, if its prompt has a retry policy that
+// allows one. A completed call cancels the retries scheduled to the
+// customer's number.
+func (s *CallRetryService) CallEnded(ctx context.Context, call *domain.Call, voicemail bool) error {
+	phone := call.CustomerNumber()
+	if call.PromptID != nil {
+		// Calls placed with a prompt are ours whatever the provider reports
+		phone = call.PhoneNumber
+	}
+	if phone == "" {
+		return nil
+	}
+
+	if call.Status == domain.CallStatusCompleted {
+		cancelled, err := s.repo.CancelScheduled(ctx, phone, "customer reached")
+		if err != nil {
+			return fmt.Errorf("failed to cancel call retries: %w", err)
+		}
+		if cancelled > 0 {
+			s.logger.Info("call retries cancelled",
+				zap.String("call_id", call.ID.String()),
+				zap.Int("attempts", cancelled),
+			)
+		}
+		return nil
+	}
+
+	reason := domain.CallRetryReasonNoAnswer
+	switch {
+	case voicemail:
+		reason = domain.CallRetryReasonVoicemail
+	case call.Status != domain.CallStatusNoAnswer:
+		return nil
+	}
+	if call.PromptID == nil || call.IsInbound() {
+		return nil
+	}
+
+	prompt, err := s.prompts.GetPrompt(ctx, *call.PromptID)
+	if apperrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+	policy := prompt.RetryPolicy
+	if policy == nil || !policy.RetriesOn(reason) {
+		return nil
+	}
+
+	// The call is attempt 1 unless a retry placed it
+	originalCallID, attempt := call.ID, 1
+	previous, err := s.repo.GetByCallID(ctx, call.ID)
+	if err != nil && !apperrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get call attempt: %w", err)
+	}
+	if previous != nil {
+		originalCallID, attempt = previous.OriginalCallID, previous.Attempt
+	}
+	if attempt >= policy.MaxAttempts {
+		s.logger.Info("call retries exhausted",
+			zap.String("call_id", call.ID.String()),
+			zap.String("original_call_id", originalCallID.String()),
+			zap.Int("attempts", attempt),
+		)
+		return nil
+	}
+
+	next := domain.NewCallAttempt(originalCallID, call, phone, attempt+1, reason, policy.NextAttemptAt(attempt+1, s.clock.Now()))
+	created, err := s.repo.Create(ctx, next)
+	if err != nil {
+		return fmt.Errorf("failed to schedule call retry: %w", err)
+	}
+	if created {
+		s.logger.Info("call retry scheduled",
+			zap.String("call_id", call.ID.String()),
+			zap.String("reason", string(reason)),
+			zap.Int("attempt", next.Attempt),
+			zap.Time("scheduled_at", next.ScheduledAt),
+		)
+	}
+	return nil
+}
+
+// ListAttempts returns the retries of a call in attempt order.
+func (s *CallRetryService) ListAttempts(ctx context.Context, callID uuid.UUID) ([]*domain.CallAttempt, error) {
+	if _, err := s.calls.GetByID(ctx, callID); err != nil {
+		return nil, err
+	}
+	attempts, err := s.repo.ListByOriginalCallID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if attempts == nil {
+		attempts = []*domain.CallAttempt{}
+	}
+	return attempts, nil
+}
+
+// Start begins dialing due retries.
+func (s *CallRetryService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("call retry worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting call retry worker", zap.Duration("poll_interval", s.pollInterval))
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight dials to finish.
+func (s *CallRetryService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping call retry worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("call retry worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("call retry worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *CallRetryService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.runDue()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.runDue()
+		}
+	}
+}
+
+// runDue dials the retries that are due.
+func (s *CallRetryService) runDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	attempts, err := s.repo.ClaimDue(ctx, s.clock.Now(), s.lease, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to claim due call retries", zap.Error(err))
+		return
+	}
+
+	for _, attempt := range attempts {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		s.dial(ctx, attempt)
+	}
+}
+
+// dial places one retry and records the outcome. Retries blocked by the
+// calling window wait for it to open; numbers on the do-not-call list are
+// not retried.
+func (s *CallRetryService) dial(ctx context.Context, attempt *domain.CallAttempt) {
+	logger := s.logger.With(
+		zap.String("attempt_id", attempt.ID.String()),
+		zap.String("original_call_id", attempt.OriginalCallID.String()),
+		zap.Int("attempt", attempt.Attempt),
+	)
+
+	promptID := attempt.PromptID
+	resp, err := s.dialer.InitiateCall(ctx, &InitiateCallRequest{
+		PhoneNumber:    attempt.PhoneNumber,
+		PromptID:       &promptID,
+		IdempotencyKey: "call-retry-" + attempt.ID.String(),
+		Metadata: map[string]interface{}{
+			"type":             "call_retry",
+			"original_call_id": attempt.OriginalCallID.String(),
+			"attempt":          attempt.Attempt,
+		},
+	})
+	if cerr, ok := AsComplianceError(err); ok {
+		switch {
+		case cerr.Reason == domain.ComplianceReasonDoNotCall:
+			attempt.Cancel("number on do-not-call list")
+			logger.Info("call retry cancelled for do-not-call list")
+		case cerr.NextAllowed != nil:
+			attempt.Defer(*cerr.NextAllowed)
+			logger.Debug("call retry deferred for the calling window", zap.Time("scheduled_at", attempt.ScheduledAt))
+		default:
+			attempt.MarkFailed(cerr)
+			logger.Warn("call retry blocked", zap.Error(cerr))
+		}
+	} else if err != nil {
+		attempt.MarkFailed(err)
+		logger.Error("failed to place call retry", zap.Error(err))
+	} else {
+		attempt.MarkDialed(resp.CallID)
+		logger.Info("call retry placed", zap.String("call_id", resp.CallID.String()))
+	}
+
+	if err := s.repo.Update(ctx, attempt); err != nil {
+		logger.Error("failed to update call attempt", zap.Error(err))
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockCallAttemptRepository is an in-memory CallAttemptRepository.
+type MockCallAttemptRepository struct {
+	mu       sync.Mutex
+	attempts []*domain.CallAttempt
+}
+
+func (m *MockCallAttemptRepository) Create(ctx context.Context, attempt *domain.CallAttempt) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.attempts {
+		if a.PreviousCallID == attempt.PreviousCallID {
+			return false, nil
+		}
+	}
+	cp := *attempt
+	m.attempts = append(m.attempts, &cp)
+	return true, nil
+}
+
+func (m *MockCallAttemptRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.CallAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.attempts {
+		if a.CallID != nil && *a.CallID == callID {
+			cp := *a
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("call attempt")
+}
+
+func (m *MockCallAttemptRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.CallAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*domain.CallAttempt
+	for _, a := range m.attempts {
+		if a.Status == domain.CallAttemptStatusScheduled && !a.ScheduledAt.After(now) && len(due) < limit {
+			a.ScheduledAt = now.Add(lease)
+			cp := *a
+			due = append(due, &cp)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockCallAttemptRepository) Update(ctx context.Context, attempt *domain.CallAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, a := range m.attempts {
+		if a.ID == attempt.ID {
+			cp := *attempt
+			m.attempts[i] = &cp
+			return nil
+		}
+	}
+	return apperrors.NotFound("call attempt")
+}
+
+func (m *MockCallAttemptRepository) CancelScheduled(ctx context.Context, phoneNumber, reason string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cancelled := 0
+	for _, a := range m.attempts {
+		if a.PhoneNumber == phoneNumber && a.Status == domain.CallAttemptStatusScheduled {
+			a.Cancel(reason)
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
+
+func (m *MockCallAttemptRepository) ListByOriginalCallID(ctx context.Context, callID uuid.UUID) ([]*domain.CallAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var attempts []*domain.CallAttempt
+	for _, a := range m.attempts {
+		if a.OriginalCallID == callID {
+			cp := *a
+			attempts = append(attempts, &cp)
+		}
+	}
+	sort.Slice(attempts, func(i, j int) bool { return attempts[i].Attempt < attempts[j].Attempt })
+	return attempts, nil
+}
+
+// fakeRetryPrompts serves prompts from a map.
+type fakeRetryPrompts map[uuid.UUID]*domain.Prompt
+
+func (f fakeRetryPrompts) GetPrompt(ctx context.Context, id uuid.UUID) (*domain.Prompt, error) {
+	if p, ok := f[id]; ok {
+		return p, nil
+	}
+	return nil, apperrors.NotFound("prompt")
+}
+
+// fakeRetryDialer records the calls it was asked to place and records each
+// as a new call placed with the request's prompt.
+type fakeRetryDialer struct {
+	calls *MockCallRepository
+	reqs  []*InitiateCallRequest
+	err   error // Returned instead of placing calls when set
+}
+
+func (f *fakeRetryDialer) InitiateCall(ctx context.Context, req *InitiateCallRequest) (*InitiateCallResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.reqs = append(f.reqs, req)
+	call := domain.NewCall(uuid.NewString(), "bland", req.PhoneNumber, "")
+	call.PromptID = req.PromptID
+	if err := f.calls.Create(ctx, call); err != nil {
+		return nil, err
+	}
+	return &InitiateCallResponse{CallID: call.ID}, nil
+}
+
+type callRetryTest struct {
+	svc    *CallRetryService
+	repo   *MockCallAttemptRepository
+	calls  *MockCallRepository
+	dialer *fakeRetryDialer
+	prompt *domain.Prompt
+	start  time.Time
+}
+
+// newCallRetryTest sets up a prompt that retries up to 3 dials, an hour
+// and then two hours apart.
+func newCallRetryTest(t *testing.T) *callRetryTest {
+	t.Helper()
+	ct := &callRetryTest{
+		repo:  &MockCallAttemptRepository{},
+		calls: NewMockCallRepository(),
+		start: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+	ct.dialer = &fakeRetryDialer{calls: ct.calls}
+	ct.prompt = domain.NewPrompt("Outreach", "Call about the estimate")
+	ct.prompt.RetryPolicy = &domain.CallRetryPolicy{MaxAttempts: 3, BackoffMinutes: 60, BackoffMultiplier: 2}
+	ct.svc = NewCallRetryService(ct.repo, ct.calls, fakeRetryPrompts{ct.prompt.ID: ct.prompt}, ct.dialer, zap.NewNop(), nil, clock.NewMock(ct.start))
+	return ct
+}
+
+// placedCall records an outbound call placed with the test prompt.
+func (ct *callRetryTest) placedCall(t *testing.T) *domain.Call {
+	t.Helper()
+	call := domain.NewCall("p1", "bland", "+15550002222", "+15550001111")
+	call.PromptID = &ct.prompt.ID
+	if err := ct.calls.Create(context.Background(), call); err != nil {
+		t.Fatalf("create call: %v", err)
+	}
+	return call
+}
+
+// end reports the call ending with the given status.
+func (ct *callRetryTest) end(t *testing.T, call *domain.Call, status domain.CallStatus, voicemail bool) {
+	t.Helper()
+	call.Status = status
+	if err := ct.svc.CallEnded(context.Background(), call, voicemail); err != nil {
+		t.Fatalf("CallEnded() error = %v", err)
+	}
+}
+
+// runAt runs the worker once at the given time.
+func (ct *callRetryTest) runAt(now time.Time) {
+	ct.svc.clock = clock.NewMock(now)
+	ct.svc.runDue()
+}
+
+func (ct *callRetryTest) attempts(t *testing.T, callID uuid.UUID) []*domain.CallAttempt {
+	t.Helper()
+	attempts, err := ct.svc.ListAttempts(context.Background(), callID)
+	if err != nil {
+		t.Fatalf("ListAttempts() error = %v", err)
+	}
+	return attempts
+}
+
+func TestCallRetryService_RedialsUntilAttemptsRunOut(t *testing.T) {
+	ct := newCallRetryTest(t)
+	original := ct.placedCall(t)
+	ct.end(t, original, domain.CallStatusNoAnswer, true)
+	ct.end(t, original, domain.CallStatusNoAnswer, true) // Repeated webhook
+
+	attempts := ct.attempts(t, original.ID)
+	if len(attempts) != 1 || attempts[0].Attempt != 2 || attempts[0].Reason != domain.CallRetryReasonVoicemail {
+		t.Fatalf("attempts = %+v, want attempt 2 after voicemail", attempts)
+	}
+	if want := ct.start.Add(time.Hour); !attempts[0].ScheduledAt.Equal(want) {
+		t.Errorf("ScheduledAt = %v, want %v", attempts[0].ScheduledAt, want)
+	}
+
+	ct.runAt(ct.start.Add(30 * time.Minute))
+	if len(ct.dialer.reqs) != 0 {
+		t.Fatalf("dialed %d retries before the first was due", len(ct.dialer.reqs))
+	}
+	ct.runAt(ct.start.Add(time.Hour))
+	if len(ct.dialer.reqs) != 1 {
+		t.Fatalf("dialed %d retries, want 1", len(ct.dialer.reqs))
+	}
+	req := ct.dialer.reqs[0]
+	if req.PhoneNumber != "+15550002222" || req.PromptID == nil || *req.PromptID != ct.prompt.ID {
+		t.Errorf("retry request = %+v, want the customer dialed with the prompt", req)
+	}
+
+	// The retry isn't answered either; the wait doubles
+	attempts = ct.attempts(t, original.ID)
+	if attempts[0].Status != domain.CallAttemptStatusDialed || attempts[0].CallID == nil {
+		t.Fatalf("attempt 2 = %+v, want dialed", attempts[0])
+	}
+	retry, _ := ct.calls.GetByID(context.Background(), *attempts[0].CallID)
+	ct.svc.clock = clock.NewMock(ct.start.Add(time.Hour))
+	ct.end(t, retry, domain.CallStatusNoAnswer, false)
+
+	attempts = ct.attempts(t, original.ID)
+	if len(attempts) != 2 || attempts[1].Attempt != 3 || attempts[1].Reason != domain.CallRetryReasonNoAnswer {
+		t.Fatalf("attempts = %+v, want attempt 3 after no answer", attempts)
+	}
+	if want := ct.start.Add(3 * time.Hour); !attempts[1].ScheduledAt.Equal(want) {
+		t.Errorf("ScheduledAt = %v, want %v", attempts[1].ScheduledAt, want)
+	}
+
+	// The third dial is the last
+	ct.runAt(ct.start.Add(3 * time.Hour))
+	attempts = ct.attempts(t, original.ID)
+	last, _ := ct.calls.GetByID(context.Background(), *attempts[1].CallID)
+	ct.end(t, last, domain.CallStatusNoAnswer, false)
+	if attempts := ct.attempts(t, original.ID); len(attempts) != 2 {
+		t.Errorf("got %d attempts, want no more than max_attempts", len(attempts)+1)
+	}
+}
+
+func TestCallRetryService_CompletedCallCancelsRetries(t *testing.T) {
+	ct := newCallRetryTest(t)
+	original := ct.placedCall(t)
+	ct.end(t, original, domain.CallStatusNoAnswer, false)
+
+	// The customer calls back in the meantime
+	inbound := domain.NewCall("p2", "bland", "+15550001111", "+15550002222")
+	ct.end(t, inbound, domain.CallStatusCompleted, false)
+
+	attempts := ct.attempts(t, original.ID)
+	if len(attempts) != 1 || attempts[0].Status != domain.CallAttemptStatusCancelled {
+		t.Fatalf("attempts = %+v, want the retry cancelled", attempts)
+	}
+	ct.runAt(ct.start.Add(2 * time.Hour))
+	if len(ct.dialer.reqs) != 0 {
+		t.Errorf("dialed a cancelled retry")
+	}
+}
+
+func TestCallRetryService_FollowsPolicy(t *testing.T) {
+	t.Run("outcome not retried", func(t *testing.T) {
+		ct := newCallRetryTest(t)
+		ct.prompt.RetryPolicy.RetryOn = []domain.CallRetryReason{domain.CallRetryReasonVoicemail}
+		call := ct.placedCall(t)
+		ct.end(t, call, domain.CallStatusNoAnswer, false)
+		if attempts := ct.attempts(t, call.ID); len(attempts) != 0 {
+			t.Errorf("attempts = %+v, want none for no_answer", attempts)
+		}
+	})
+
+	t.Run("no policy", func(t *testing.T) {
+		ct := newCallRetryTest(t)
+		ct.prompt.RetryPolicy = nil
+		call := ct.placedCall(t)
+		ct.end(t, call, domain.CallStatusNoAnswer, true)
+		if attempts := ct.attempts(t, call.ID); len(attempts) != 0 {
+			t.Errorf("attempts = %+v, want none", attempts)
+		}
+	})
+
+	t.Run("failed call", func(t *testing.T) {
+		ct := newCallRetryTest(t)
+		call := ct.placedCall(t)
+		ct.end(t, call, domain.CallStatusFailed, false)
+		if attempts := ct.attempts(t, call.ID); len(attempts) != 0 {
+			t.Errorf("attempts = %+v, want none", attempts)
+		}
+	})
+}
+
+func TestCallRetryService_Compliance(t *testing.T) {
+	ct := newCallRetryTest(t)
+	call := ct.placedCall(t)
+	ct.end(t, call, domain.CallStatusNoAnswer, false)
+
+	opens := ct.start.Add(20 * time.Hour)
+	ct.dialer.err = &domain.ComplianceError{Reason: domain.ComplianceReasonCallingWindow, PhoneNumber: "+15550002222", NextAllowed: &opens}
+	ct.runAt(ct.start.Add(time.Hour))
+	attempts := ct.attempts(t, call.ID)
+	if attempts[0].Status != domain.CallAttemptStatusScheduled || !attempts[0].ScheduledAt.Equal(opens) {
+		t.Fatalf("attempt = %+v, want it deferred until %v", attempts[0], opens)
+	}
+
+	ct.dialer.err = &domain.ComplianceError{Reason: domain.ComplianceReasonDoNotCall, PhoneNumber: "+15550002222"}
+	ct.runAt(opens)
+	attempts = ct.attempts(t, call.ID)
+	if attempts[0].Status != domain.CallAttemptStatusCancelled {
+		t.Errorf("attempt status = %s, want cancelled for a do-not-call number", attempts[0].Status)
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
 	apperrors "github.com/jkindrix/quickquote/internal/errors"
 	"github.com/jkindrix/quickquote/internal/metrics"
 	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/tracing"
 	"github.com/jkindrix/quickquote/internal/voiceprovider"
 )
 
@@ -21,8 +25,19 @@ import (
 type CallService struct {
 	callRepo     domain.CallRepository
 	quoteGen     QuoteGenerator
+	pricing      *PricingService
+	currencies   CurrencySource
 	jobProcessor *QuoteJobProcessor
 	quoteLimiter *ratelimit.QuoteLimiter
+	customers    CustomerLinker
+	recordings   RecordingArchiver
+	experiments  ExperimentRecorder
+	publisher    EventPublisher
+	costs        CallCoster
+	retrier      CallRetrier
+	notifier     CallCompletedNotifier
+	tagger       CallTagger
+	callEvents   domain.CallEventRepository
 	logger       *zap.Logger
 	metrics      *metrics.Metrics
 }
@@ -32,6 +47,39 @@ type QuoteGenerator interface {
 	GenerateQuote(ctx context.Context, transcript string, extractedData *domain.ExtractedData) (string, error)
 }
 
+// CustomerLinker attaches calls to the customer they were made by or to.
+type CustomerLinker interface {
+	LinkCall(ctx context.Context, call *domain.Call) error
+}
+
+// RecordingArchiver keeps copies of call recordings before provider URLs expire.
+type RecordingArchiver interface {
+	Archive(ctx context.Context, call *domain.Call) error
+}
+
+// ExperimentRecorder attributes calls to the prompt experiment variant they
+// were given.
+type ExperimentRecorder interface {
+	RecordCall(ctx context.Context, call *domain.Call) error
+}
+
+// CallCoster attributes estimated costs to finished calls.
+type CallCoster interface {
+	PriceCall(ctx context.Context, call *domain.Call) error
+}
+
+// CallRetrier re-dials calls that went to voicemail or were not answered.
+// CallRetryService implements it.
+type CallRetrier interface {
+	CallEnded(ctx context.Context, call *domain.Call, voicemail bool) error
+}
+
+// CallTagger classifies completed calls from their transcripts.
+// CallTaggingService implements it.
+type CallTagger interface {
+	Enqueue(callID uuid.UUID)
+}
+
 // NewCallService creates a new CallService.
 func NewCallService(
 	callRepo domain.CallRepository,
@@ -51,9 +99,85 @@ func NewCallService(
 	}
 }
 
+// SetCustomerLinker enables linking processed calls to customers.
+func (s *CallService) SetCustomerLinker(linker CustomerLinker) {
+	s.customers = linker
+}
+
+// SetRecordingArchiver enables archiving the recordings of processed calls.
+func (s *CallService) SetRecordingArchiver(archiver RecordingArchiver) {
+	s.recordings = archiver
+}
+
+// SetExperimentRecorder enables attributing processed calls to prompt
+// experiment variants.
+func (s *CallService) SetExperimentRecorder(recorder ExperimentRecorder) {
+	s.experiments = recorder
+}
+
+// SetEventPublisher enables announcing new and completed calls and generated
+// quotes to the events feed and outgoing webhook subscribers.
+func (s *CallService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
+// SetCallCoster enables attributing estimated costs to processed calls.
+func (s *CallService) SetCallCoster(coster CallCoster) {
+	s.costs = coster
+}
+
+// SetCallRetrier enables re-dialing calls that went to voicemail or were
+// not answered.
+func (s *CallService) SetCallRetrier(retrier CallRetrier) {
+	s.retrier = retrier
+}
+
+// SetNotifier sets the notifier told about answered calls that ended.
+func (s *CallService) SetNotifier(n CallCompletedNotifier) {
+	s.notifier = n
+}
+
+// SetCallTagger enables tagging completed calls with their sentiment,
+// intent and urgency.
+func (s *CallService) SetCallTagger(tagger CallTagger) {
+	s.tagger = tagger
+}
+
+// SetPricing sets the service that prices manually generated quotes.
+func (s *CallService) SetPricing(pricing *PricingService) {
+	s.pricing = pricing
+}
+
+// SetCallEvents enables the call status event log. Webhook statuses are
+// appended to it and the call's status replayed from it, so webhooks
+// delivered out of order can't move a call back to an earlier stage.
+func (s *CallService) SetCallEvents(events domain.CallEventRepository) {
+	s.callEvents = events
+}
+
+// SetCurrencies sets the source of the currency quotes are generated in.
+// Without one, quotes are priced in USD.
+func (s *CallService) SetCurrencies(currencies CurrencySource) {
+	s.currencies = currencies
+}
+
 // ProcessCallEvent processes a normalized call event from any voice provider.
 // This is the provider-agnostic entry point for call processing.
 func (s *CallService) ProcessCallEvent(ctx context.Context, event *voiceprovider.CallEvent) (*domain.Call, error) {
+	ctx, span := tracing.Start(ctx, "CallService.ProcessCallEvent", trace.WithAttributes(
+		attribute.String("voice.provider", string(event.Provider)),
+		attribute.String("voice.provider_call_id", event.ProviderCallID),
+		attribute.String("voice.call_status", string(event.Status)),
+	))
+	defer span.End()
+
+	call, err := s.processCallEvent(ctx, event)
+	tracing.RecordError(span, err)
+	return call, err
+}
+
+// processCallEvent creates or updates the call described by an event.
+func (s *CallService) processCallEvent(ctx context.Context, event *voiceprovider.CallEvent) (*domain.Call, error) {
 	s.logger.Info("processing call event",
 		zap.String("provider", string(event.Provider)),
 		zap.String("provider_call_id", event.ProviderCallID),
@@ -66,7 +190,8 @@ func (s *CallService) ProcessCallEvent(ctx context.Context, event *voiceprovider
 		return nil, fmt.Errorf("failed to check existing call: %w", err)
 	}
 
-	if call == nil {
+	created := call == nil
+	if created {
 		// Create new call record
 		call = domain.NewCall(
 			event.ProviderCallID,
@@ -81,7 +206,32 @@ func (s *CallService) ProcessCallEvent(ctx context.Context, event *voiceprovider
 	}
 
 	// Update call with event data
+	previousStatus := call.Status
 	s.updateCallFromEvent(call, event)
+	if created || call.Status != previousStatus {
+		s.recordStatus(ctx, call, event)
+	}
+
+	// Link to the customer before saving so the link is stored with the update.
+	if s.customers != nil {
+		if err := s.customers.LinkCall(ctx, call); err != nil {
+			// Don't fail the webhook over CRM bookkeeping
+			s.logger.Warn("failed to link call to customer",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if s.costs != nil {
+		if err := s.costs.PriceCall(ctx, call); err != nil {
+			// Cost attribution is bookkeeping; don't fail the webhook
+			s.logger.Warn("failed to price call",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
 
 	if err := s.callRepo.Update(ctx, call); err != nil {
 		return nil, fmt.Errorf("failed to update call: %w", err)
@@ -92,36 +242,182 @@ func (s *CallService) ProcessCallEvent(ctx context.Context, event *voiceprovider
 		zap.String("status", string(call.Status)),
 	)
 
-	// Enqueue quote generation job if call completed successfully with transcript
-	if call.Status == domain.CallStatusCompleted && call.Transcript != nil && *call.Transcript != "" {
-		if s.jobProcessor != nil {
-			job, err := s.jobProcessor.EnqueueJob(ctx, call.ID)
-			if err != nil {
-				s.logger.Error("failed to enqueue quote job",
-					zap.String("call_id", call.ID.String()),
-					zap.Error(err),
-				)
-				// Don't fail the whole request, quote will need manual retry
-			} else if job != nil {
-				jobID := job.ID
-				if err := s.callRepo.SetQuoteJobID(ctx, call.ID, &jobID); err != nil && !apperrors.IsNotFound(err) {
-					s.logger.Warn("failed to set quote job id",
-						zap.String("call_id", call.ID.String()),
-						zap.Error(err),
-					)
-				}
-			}
-		} else {
-			// Log warning - job processor should always be configured in production
-			s.logger.Warn("job processor not configured, quote generation skipped",
+	if disposition, ok := eventDisposition(event); ok {
+		if err := setDisposition(ctx, s.callRepo, call, disposition, domain.DispositionSourceAuto); err != nil {
+			s.logger.Warn("failed to set call disposition",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if s.experiments != nil {
+		if err := s.experiments.RecordCall(ctx, call); err != nil {
+			// Experiment results are bookkeeping; don't fail the webhook
+			s.logger.Warn("failed to record experiment call",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if s.recordings != nil && call.RecordingURL != nil {
+		if err := s.recordings.Archive(ctx, call); err != nil {
+			// The provider URL still works for now; don't fail the webhook
+			s.logger.Warn("failed to queue recording download",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if s.publisher != nil {
+		if created {
+			s.publisher.Publish(ctx, domain.WebhookEventCallCreated, NewWebhookCallData(call))
+		}
+		if call.Status == domain.CallStatusCompleted && previousStatus != domain.CallStatusCompleted {
+			s.publisher.Publish(ctx, domain.WebhookEventCallCompleted, NewWebhookCallData(call))
+		}
+	}
+	if s.notifier != nil && call.Status == domain.CallStatusCompleted && previousStatus != domain.CallStatusCompleted {
+		s.notifier.CallCompleted(ctx, call)
+	}
+
+	if s.retrier != nil && call.IsComplete() && call.Status != previousStatus {
+		voicemail := event.Status == voiceprovider.CallStatusVoicemail
+		if err := s.retrier.CallEnded(ctx, call, voicemail); err != nil {
+			// The call itself is recorded; don't fail the webhook over a retry
+			s.logger.Warn("failed to handle call retry",
 				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
 			)
 		}
 	}
 
+	// Enqueue quote generation job if call completed successfully with transcript
+	if call.Status == domain.CallStatusCompleted && call.Transcript != nil && *call.Transcript != "" {
+		s.enqueueQuoteJob(ctx, call)
+		s.enqueueTagging(call)
+	}
+
+	return call, nil
+}
+
+// recordStatus appends the status a webhook reported to the call's event
+// log, then sets the call's status by replaying the log.
+func (s *CallService) recordStatus(ctx context.Context, call *domain.Call, event *voiceprovider.CallEvent) {
+	if s.callEvents == nil {
+		return
+	}
+
+	e := domain.NewCallEvent(call.ID, call.Status, domain.CallEventSourceWebhook, statusTime(call, event))
+	e.Detail = string(event.Status)
+	if err := s.callEvents.Append(ctx, e); err != nil {
+		// The call record still gets the reported status
+		s.logger.Warn("failed to record call event",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	events, err := s.callEvents.ListByCall(ctx, call.ID)
+	if err != nil {
+		s.logger.Warn("failed to replay call events",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if status, _ := domain.ReplayCallEvents(events); status != "" && status != call.Status {
+		s.logger.Info("ignoring out-of-order call status",
+			zap.String("call_id", call.ID.String()),
+			zap.String("reported", string(call.Status)),
+			zap.String("status", string(status)),
+		)
+		call.Status = status
+	}
+}
+
+// statusTime is when a webhook's status took effect: the end of a call that
+// has ended, the start of one in progress, otherwise now.
+func statusTime(call *domain.Call, event *voiceprovider.CallEvent) time.Time {
+	switch {
+	case call.IsComplete() && event.EndedAt != nil:
+		return *event.EndedAt
+	case call.Status == domain.CallStatusInProgress && event.StartedAt != nil:
+		return *event.StartedAt
+	}
+	return time.Now().UTC()
+}
+
+// ApplyTranscript saves a transcript produced for a call whose provider sent
+// none, then queues quote generation for it. A call that already has a
+// transcript is returned unchanged.
+func (s *CallService) ApplyTranscript(ctx context.Context, callID uuid.UUID, transcript string) (*domain.Call, error) {
+	call, err := s.callRepo.GetByID(ctx, callID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get call: %w", err)
+	}
+	if call.Transcript != nil && *call.Transcript != "" {
+		return call, nil
+	}
+
+	call.Transcript = &transcript
+	call.UpdatedAt = time.Now().UTC()
+	if err := s.callRepo.Update(ctx, call); err != nil {
+		return nil, fmt.Errorf("failed to update call: %w", err)
+	}
+	s.logger.Info("call transcript saved",
+		zap.String("call_id", call.ID.String()),
+		zap.Int("length", len(transcript)),
+	)
+
+	if call.Status == domain.CallStatusCompleted {
+		s.enqueueQuoteJob(ctx, call)
+		s.enqueueTagging(call)
+	}
 	return call, nil
 }
 
+// enqueueTagging queues a completed call that has not been tagged for
+// classification.
+func (s *CallService) enqueueTagging(call *domain.Call) {
+	if s.tagger != nil && call.Tags == nil {
+		s.tagger.Enqueue(call.ID)
+	}
+}
+
+// enqueueQuoteJob queues quote generation for a completed call. Failures are
+// logged; the quote can still be generated manually.
+func (s *CallService) enqueueQuoteJob(ctx context.Context, call *domain.Call) {
+	if s.jobProcessor == nil {
+		// Log warning - job processor should always be configured in production
+		s.logger.Warn("job processor not configured, quote generation skipped",
+			zap.String("call_id", call.ID.String()),
+		)
+		return
+	}
+
+	job, err := s.jobProcessor.EnqueueJob(ctx, call.ID)
+	if err != nil {
+		s.logger.Error("failed to enqueue quote job",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if job != nil {
+		jobID := job.ID
+		if err := s.callRepo.SetQuoteJobID(ctx, call.ID, &jobID); err != nil && !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to set quote job id",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
 // updateCallFromEvent updates a call record with data from a normalized CallEvent.
 func (s *CallService) updateCallFromEvent(call *domain.Call, event *voiceprovider.CallEvent) {
 	// Update phone numbers if provided
@@ -163,6 +459,8 @@ func (s *CallService) updateCallFromEvent(call *domain.Call, event *voiceprovide
 				Role:      t.Role,
 				Content:   t.Content,
 				Timestamp: t.Timestamp,
+				StartTime: t.StartTime,
+				EndTime:   t.EndTime,
 			}
 		}
 	}
@@ -256,19 +554,40 @@ func (s *CallService) GenerateQuote(ctx context.Context, callID uuid.UUID) (*dom
 	s.logger.Info("generating quote", zap.String("call_id", callID.String()))
 
 	start := time.Now()
-	quote, err := s.quoteGen.GenerateQuote(ctx, *call.Transcript, call.ExtractedData)
+	recordCurrency(ctx, s.currencies, call)
+	var estimate *domain.PriceEstimate
+	if s.pricing != nil {
+		estimate, err = s.pricing.Estimate(ctx, call)
+		if err != nil {
+			if s.metrics != nil {
+				s.metrics.RecordQuoteGeneration(false, time.Since(start))
+			}
+			return nil, fmt.Errorf("failed to price quote: %w", err)
+		}
+	}
+
+	quote, err := s.quoteGen.GenerateQuote(ctx, *call.Transcript, quoteDetails(call))
 	if err != nil {
 		if s.metrics != nil {
 			s.metrics.RecordQuoteGeneration(false, time.Since(start))
 		}
 		return nil, fmt.Errorf("failed to generate quote: %w", err)
 	}
+	if s.pricing != nil {
+		quote = appendPricingSection(quote, estimate)
+	}
 
 	call.QuoteSummary = &quote
 
 	if err := s.callRepo.Update(ctx, call); err != nil {
 		return nil, fmt.Errorf("failed to update call with quote: %w", err)
 	}
+	if err := setDisposition(ctx, s.callRepo, call, domain.CallDispositionQuoted, domain.DispositionSourceAuto); err != nil {
+		s.logger.Warn("failed to set call disposition",
+			zap.String("call_id", callID.String()),
+			zap.Error(err),
+		)
+	}
 
 	if s.metrics != nil {
 		s.metrics.RecordQuoteGeneration(true, time.Since(start))
@@ -283,6 +602,47 @@ func (s *CallService) GenerateQuote(ctx context.Context, callID uuid.UUID) (*dom
 
 	s.logger.Info("quote generated successfully", zap.String("call_id", callID.String()))
 
+	if s.publisher != nil {
+		s.publisher.Publish(ctx, domain.WebhookEventQuoteCreated, NewWebhookQuoteCreatedData(call))
+	}
+
+	return call, nil
+}
+
+// quoteDetails returns the details a quote is generated from: the call's
+// extracted data and the language the call was held in.
+func quoteDetails(call *domain.Call) *domain.ExtractedData {
+	language := call.Language()
+	if language == "" {
+		return call.ExtractedData
+	}
+
+	var details domain.ExtractedData
+	if call.ExtractedData != nil {
+		details = *call.ExtractedData
+	}
+	details.Language = language
+	return &details
+}
+
+// SetDisposition sets a call's disposition by hand, correcting the one
+// mapped from provider data or inferred by AI. It is not replaced
+// automatically afterwards.
+func (s *CallService) SetDisposition(ctx context.Context, callID uuid.UUID, disposition domain.CallDisposition) (*domain.Call, error) {
+	if _, err := domain.ParseCallDisposition(string(disposition)); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	call, err := s.callRepo.GetByID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if err := setDisposition(ctx, s.callRepo, call, disposition, domain.DispositionSourceManual); err != nil {
+		return nil, fmt.Errorf("failed to set call disposition: %w", err)
+	}
+	s.logger.Info("call disposition set",
+		zap.String("call_id", callID.String()),
+		zap.String("disposition", string(disposition)),
+	)
 	return call, nil
 }
 
@@ -314,3 +674,49 @@ func (s *CallService) ListCalls(ctx context.Context, page, pageSize int, filter
 
 	return calls, total, nil
 }
+
+// CallPage is a page of calls listed with a cursor.
+type CallPage struct {
+	Calls      []*domain.Call `json:"calls"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Pass as cursor for the next page; empty on the last page
+	HasMore    bool           `json:"has_more"`
+}
+
+// ListCallPage returns the page of calls matching filter that follows
+// cursor. Unlike ListCalls it doesn't count the matches, and pages stay
+// consistent as new calls arrive, so it suits large call histories.
+func (s *CallService) ListCallPage(ctx context.Context, filter *domain.CallListFilter, cursor string, limit int) (*CallPage, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	f := domain.CallListFilter{}
+	if filter != nil {
+		f = *filter
+	}
+	f.After = nil
+	if cursor = strings.TrimSpace(cursor); cursor != "" {
+		after, err := domain.DecodeCallCursor(cursor)
+		if err != nil {
+			return nil, apperrors.ValidationFailed(err.Error())
+		}
+		f.After = after
+	}
+
+	// Fetch one extra call to learn whether there are more
+	calls, err := s.callRepo.List(ctx, &f, limit+1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CallPage{Calls: []*domain.Call{}}
+	if len(calls) > limit {
+		calls = calls[:limit]
+		page.HasMore = true
+	}
+	page.Calls = append(page.Calls, calls...)
+	if page.HasMore {
+		page.NextCursor = domain.CursorFor(calls[len(calls)-1]).Encode()
+	}
+	return page, nil
+}
@@ -464,3 +464,77 @@ func TestCallService_ListCalls_InvalidPageSize(t *testing.T) {
 		t.Errorf("expected 1 call, got %d", len(calls))
 	}
 }
+
+func TestCallService_ListCallPage(t *testing.T) {
+	service, mockRepo, _ := newTestCallService()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		call := domain.NewCall("provider-"+string(rune('a'+i)), "bland", "+12345678901", "+19876543210")
+		mockRepo.Create(ctx, call)
+	}
+
+	page, err := service.ListCallPage(ctx, nil, "", 2)
+	if err != nil {
+		t.Fatalf("ListCallPage() error = %v", err)
+	}
+	if len(page.Calls) != 2 || !page.HasMore || page.NextCursor == "" {
+		t.Errorf("expected 2 calls and a next cursor, got %d calls, has_more %v, cursor %q", len(page.Calls), page.HasMore, page.NextCursor)
+	}
+	if _, err := domain.DecodeCallCursor(page.NextCursor); err != nil {
+		t.Errorf("next cursor doesn't decode: %v", err)
+	}
+
+	page, err = service.ListCallPage(ctx, nil, "", 5)
+	if err != nil {
+		t.Fatalf("ListCallPage() error = %v", err)
+	}
+	if len(page.Calls) != 3 || page.HasMore || page.NextCursor != "" {
+		t.Errorf("expected the last page, got %d calls, has_more %v, cursor %q", len(page.Calls), page.HasMore, page.NextCursor)
+	}
+
+	if _, err := service.ListCallPage(ctx, nil, "bogus", 5); !apperrors.IsUserError(err) {
+		t.Errorf("expected a validation error for a bad cursor, got %v", err)
+	}
+}
+
+func TestCallService_ProcessCallEvent_Disposition(t *testing.T) {
+	service, mockRepo, _ := newTestCallService()
+	ctx := context.Background()
+
+	call, err := service.ProcessCallEvent(ctx, &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "provider-call-vm",
+		ToNumber:       "+1234567890",
+		FromNumber:     "+19876543210",
+		Status:         voiceprovider.CallStatusVoicemail,
+	})
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	if call.Disposition == nil || *call.Disposition != domain.CallDispositionVoicemail || *call.DispositionSource != domain.DispositionSourceAuto {
+		t.Fatalf("disposition = %v, want voicemail from provider data", call.Disposition)
+	}
+
+	if _, err := service.SetDisposition(ctx, call.ID, domain.CallDispositionCallbackScheduled); err != nil {
+		t.Fatalf("SetDisposition() error = %v", err)
+	}
+
+	// A later webhook does not undo the correction
+	if _, err := service.ProcessCallEvent(ctx, &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "provider-call-vm",
+		Status:         voiceprovider.CallStatusVoicemail,
+		Disposition:    "Answering Machine",
+	}); err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+	stored, _ := mockRepo.GetByID(ctx, call.ID)
+	if *stored.Disposition != domain.CallDispositionCallbackScheduled || *stored.DispositionSource != domain.DispositionSourceManual {
+		t.Errorf("disposition = %s from %s, want the manual correction", *stored.Disposition, *stored.DispositionSource)
+	}
+
+	if _, err := service.SetDisposition(ctx, call.ID, "maybe"); !apperrors.IsUserError(err) {
+		t.Errorf("SetDisposition() with an unknown disposition = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// CallClassifier classifies a call from its transcript. ai.ClaudeClient
+// implements it.
+type CallClassifier interface {
+	ClassifyCall(ctx context.Context, transcript string) (*domain.CallTags, error)
+}
+
+// CallTaggingService tags completed calls with the caller's sentiment,
+// intent and urgency, classified by AI from the transcript, so calls can be
+// filtered and counted by them. The disposition inferred alongside is stored
+// for calls without one from provider data.
+type CallTaggingService struct {
+	classifier CallClassifier
+	calls      domain.CallRepository
+	logger     *zap.Logger
+
+	// Configuration
+	workers int
+	timeout time.Duration
+
+	// Lifecycle
+	queue   chan uuid.UUID
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// CallTaggingServiceConfig holds configuration for the call tagging service.
+type CallTaggingServiceConfig struct {
+	Workers   int           // Concurrent classifications
+	QueueSize int           // Calls waiting for a worker before new ones are dropped
+	Timeout   time.Duration // Limit for classifying one call
+}
+
+// DefaultCallTaggingServiceConfig returns sensible defaults.
+func DefaultCallTaggingServiceConfig() *CallTaggingServiceConfig {
+	return &CallTaggingServiceConfig{
+		Workers:   2,
+		QueueSize: 500,
+		Timeout:   2 * time.Minute,
+	}
+}
+
+// NewCallTaggingService creates a new CallTaggingService.
+func NewCallTaggingService(
+	classifier CallClassifier,
+	calls domain.CallRepository,
+	logger *zap.Logger,
+	config *CallTaggingServiceConfig,
+) *CallTaggingService {
+	defaults := DefaultCallTaggingServiceConfig()
+	if config == nil {
+		config = defaults
+	}
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaults.QueueSize
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaults.Timeout
+	}
+
+	return &CallTaggingService{
+		classifier: classifier,
+		calls:      calls,
+		logger:     logger,
+		workers:    workers,
+		timeout:    timeout,
+		queue:      make(chan uuid.UUID, queueSize),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Enqueue queues a call for tagging without blocking. The call is dropped
+// with a warning when the queue is full.
+func (s *CallTaggingService) Enqueue(callID uuid.UUID) {
+	select {
+	case s.queue <- callID:
+		s.logger.Debug("call queued for tagging", zap.String("call_id", callID.String()))
+	default:
+		s.logger.Warn("call tagging queue full, call dropped", zap.String("call_id", callID.String()))
+	}
+}
+
+// Tag classifies a call's transcript and stores the tags, replacing any
+// earlier ones.
+func (s *CallTaggingService) Tag(ctx context.Context, callID uuid.UUID) (*domain.CallTags, error) {
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if call.Transcript == nil || *call.Transcript == "" {
+		return nil, errors.New("call has no transcript")
+	}
+
+	tags, err := s.classifier.ClassifyCall(ctx, *call.Transcript)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.calls.SetTags(ctx, callID, tags); err != nil {
+		return nil, fmt.Errorf("failed to save call tags: %w", err)
+	}
+
+	if tags.Disposition != "" {
+		// Dispositions from provider data or set by hand take precedence
+		if err := setDisposition(ctx, s.calls, call, tags.Disposition, domain.DispositionSourceAI); err != nil {
+			s.logger.Warn("failed to set inferred call disposition",
+				zap.String("call_id", callID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.logger.Info("call tagged",
+		zap.String("call_id", callID.String()),
+		zap.String("sentiment", string(tags.Sentiment)),
+		zap.String("intent", string(tags.Intent)),
+		zap.String("urgency", string(tags.Urgency)),
+		zap.String("disposition", string(tags.Disposition)),
+	)
+	return tags, nil
+}
+
+// Start begins tagging queued calls.
+func (s *CallTaggingService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("call tagging worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting call tagging worker", zap.Int("workers", s.workers))
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight classifications to finish.
+func (s *CallTaggingService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping call tagging worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("call tagging worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("call tagging worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// worker tags queued calls until stopped.
+func (s *CallTaggingService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case callID := <-s.queue:
+			ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+			if _, err := s.Tag(ctx, callID); err != nil {
+				s.logger.Error("failed to tag call",
+					zap.String("call_id", callID.String()),
+					zap.Error(err),
+				)
+			}
+			cancel()
+		}
+	}
+}
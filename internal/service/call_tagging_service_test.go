@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// fakeCallClassifier returns fixed tags and records the transcripts it saw.
+type fakeCallClassifier struct {
+	tags        *domain.CallTags
+	transcripts []string
+}
+
+func (f *fakeCallClassifier) ClassifyCall(ctx context.Context, transcript string) (*domain.CallTags, error) {
+	f.transcripts = append(f.transcripts, transcript)
+	tags := *f.tags
+	return &tags, nil
+}
+
+func TestCallTaggingService_Tag(t *testing.T) {
+	calls := NewMockCallRepository()
+	transcript := "I need a quote for a new deck."
+	call := &domain.Call{ID: uuid.New(), Status: domain.CallStatusCompleted, Transcript: &transcript}
+	calls.calls[call.ID] = call
+
+	classifier := &fakeCallClassifier{tags: &domain.CallTags{
+		Sentiment: domain.CallSentimentPositive,
+		Intent:    domain.CallIntentNewProject,
+		Urgency:   domain.CallUrgencyLow,
+		TaggedAt:  time.Now().UTC(),
+	}}
+	svc := NewCallTaggingService(classifier, calls, zap.NewNop(), nil)
+
+	tags, err := svc.Tag(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	if len(classifier.transcripts) != 1 || classifier.transcripts[0] != transcript {
+		t.Errorf("classified %v, want the call transcript", classifier.transcripts)
+	}
+	if call.Tags == nil || call.Tags.Intent != domain.CallIntentNewProject || tags.Sentiment != domain.CallSentimentPositive {
+		t.Errorf("stored tags = %+v", call.Tags)
+	}
+}
+
+func TestCallTaggingService_TagWithoutTranscript(t *testing.T) {
+	calls := NewMockCallRepository()
+	call := &domain.Call{ID: uuid.New(), Status: domain.CallStatusCompleted}
+	calls.calls[call.ID] = call
+
+	classifier := &fakeCallClassifier{tags: &domain.CallTags{}}
+	svc := NewCallTaggingService(classifier, calls, zap.NewNop(), nil)
+
+	if _, err := svc.Tag(context.Background(), call.ID); err == nil {
+		t.Error("expected an error for a call without a transcript")
+	}
+	if len(classifier.transcripts) != 0 || call.Tags != nil {
+		t.Error("expected the call not to be classified")
+	}
+}
+
+func TestCallTaggingService_EnqueueDropsWhenFull(t *testing.T) {
+	svc := NewCallTaggingService(&fakeCallClassifier{}, NewMockCallRepository(), zap.NewNop(), &CallTaggingServiceConfig{QueueSize: 1})
+
+	svc.Enqueue(uuid.New())
+	svc.Enqueue(uuid.New())
+
+	if len(svc.queue) != 1 {
+		t.Errorf("queue length = %d, want 1", len(svc.queue))
+	}
+}
+
+func TestAnalyticsService_GetBreakdownRejectsTagFilters(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	svc := newTestAnalyticsService(&MockAnalyticsRepository{}, now)
+	svc.SetRollups(&MockAnalyticsRollupRepository{})
+
+	_, err := svc.GetBreakdown(context.Background(), domain.AnalyticsDimensionPrompt, &domain.AnalyticsFilter{Intent: domain.CallIntentSpam})
+	if err == nil {
+		t.Error("expected breakdowns filtered by intent to be rejected")
+	}
+}
+
+func TestCallTaggingService_TagInfersDisposition(t *testing.T) {
+	calls := NewMockCallRepository()
+	transcript := "Call me back Thursday about the deck."
+	untagged := &domain.Call{ID: uuid.New(), Status: domain.CallStatusCompleted, Transcript: &transcript}
+	auto := domain.DispositionSourceAuto
+	voicemail := domain.CallDispositionVoicemail
+	mapped := &domain.Call{ID: uuid.New(), Status: domain.CallStatusCompleted, Transcript: &transcript, Disposition: &voicemail, DispositionSource: &auto}
+	calls.calls[untagged.ID] = untagged
+	calls.calls[mapped.ID] = mapped
+
+	classifier := &fakeCallClassifier{tags: &domain.CallTags{
+		Sentiment:   domain.CallSentimentNeutral,
+		Intent:      domain.CallIntentNewProject,
+		Urgency:     domain.CallUrgencyLow,
+		Disposition: domain.CallDispositionCallbackScheduled,
+	}}
+	svc := NewCallTaggingService(classifier, calls, zap.NewNop(), nil)
+
+	for _, call := range []*domain.Call{untagged, mapped} {
+		if _, err := svc.Tag(context.Background(), call.ID); err != nil {
+			t.Fatalf("Tag() error = %v", err)
+		}
+	}
+	if untagged.Disposition == nil || *untagged.Disposition != domain.CallDispositionCallbackScheduled || *untagged.DispositionSource != domain.DispositionSourceAI {
+		t.Errorf("inferred disposition = %v, want callback_scheduled from ai", untagged.Disposition)
+	}
+	if *mapped.Disposition != domain.CallDispositionVoicemail {
+		t.Errorf("disposition = %s, want the provider's voicemail kept", *mapped.Disposition)
+	}
+}
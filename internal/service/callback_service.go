@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/calendar"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// CallbackService records callbacks callers schedule during calls and puts
+// them on the business calendar in the background.
+type CallbackService struct {
+	repo     domain.CallbackRepository
+	calls    domain.CallRepository
+	calendar calendar.Calendar
+	location *time.Location
+	logger   *zap.Logger
+	elector  Elector
+
+	// Configuration
+	pollInterval  time.Duration
+	batchSize     int
+	eventDuration time.Duration
+	publicURL     string
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// CallbackServiceConfig holds configuration for the callback service.
+type CallbackServiceConfig struct {
+	Location      *time.Location // Timezone callers' requested times are read in
+	EventDuration time.Duration
+	PublicURL     string // Base URL for links to calls in event descriptions
+	PollInterval  time.Duration
+	BatchSize     int
+}
+
+// DefaultCallbackServiceConfig returns sensible defaults.
+func DefaultCallbackServiceConfig() *CallbackServiceConfig {
+	return &CallbackServiceConfig{
+		Location:      time.UTC,
+		EventDuration: 30 * time.Minute,
+		PollInterval:  10 * time.Second,
+		BatchSize:     20,
+	}
+}
+
+// NewCallbackService creates a new CallbackService. cal may be nil, in which
+// case callbacks are recorded but never put on a calendar.
+func NewCallbackService(
+	repo domain.CallbackRepository,
+	calls domain.CallRepository,
+	cal calendar.Calendar,
+	logger *zap.Logger,
+	config *CallbackServiceConfig,
+) *CallbackService {
+	if config == nil {
+		config = DefaultCallbackServiceConfig()
+	}
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &CallbackService{
+		repo:          repo,
+		calls:         calls,
+		calendar:      cal,
+		location:      location,
+		logger:        logger,
+		pollInterval:  config.PollInterval,
+		batchSize:     config.BatchSize,
+		eventDuration: config.EventDuration,
+		publicURL:     strings.TrimRight(config.PublicURL, "/"),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// ScheduleCallbackRequest is a callback request from the schedule_callback
+// tool, with the date and time as the caller said them.
+type ScheduleCallbackRequest struct {
+	ProviderCallID string `json:"call_id"`
+	PhoneNumber    string `json:"phone_number,omitempty"`
+	PreferredDate  string `json:"preferred_date"`
+	PreferredTime  string `json:"preferred_time"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// ScheduleCallback records a callback. It returns a validation error when
+// the requested time cannot be understood or has passed, so the agent can
+// ask the caller for another time.
+func (s *CallbackService) ScheduleCallback(ctx context.Context, req *ScheduleCallbackRequest) (*domain.Callback, error) {
+	providerCallID := strings.TrimSpace(req.ProviderCallID)
+	if providerCallID == "" {
+		return nil, apperrors.ValidationFailed("call_id is required")
+	}
+
+	at, err := domain.ResolveCallbackTime(req.PreferredDate, req.PreferredTime, time.Now().In(s.location))
+	if err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	callback := domain.NewCallback(providerCallID, at)
+	callback.RequestedDate = strings.TrimSpace(req.PreferredDate)
+	callback.RequestedTime = strings.TrimSpace(req.PreferredTime)
+	callback.Reason = strings.TrimSpace(req.Reason)
+	callback.PhoneNumber = strings.TrimSpace(req.PhoneNumber)
+	s.linkCall(ctx, callback)
+
+	if err := s.repo.Create(ctx, callback); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("callback scheduled",
+		zap.String("callback_id", callback.ID.String()),
+		zap.String("provider_call_id", providerCallID),
+		zap.Time("scheduled_at", callback.ScheduledAt),
+	)
+	return callback, nil
+}
+
+// Upcoming returns callbacks from now on, soonest first.
+func (s *CallbackService) Upcoming(ctx context.Context, limit int) ([]*domain.Callback, error) {
+	return s.repo.ListUpcoming(ctx, time.Now(), limit)
+}
+
+// Location returns the timezone callbacks are scheduled in.
+func (s *CallbackService) Location() *time.Location {
+	return s.location
+}
+
+// SetElector limits creating calendar events to the instance elector chooses.
+func (s *CallbackService) SetElector(elector Elector) {
+	s.elector = elector
+}
+
+// Start begins creating calendar events for pending callbacks. It does
+// nothing when no calendar is configured.
+func (s *CallbackService) Start(ctx context.Context) error {
+	if s.calendar == nil {
+		s.logger.Info("no calendar configured, callbacks will not be synced")
+		return nil
+	}
+
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("callback worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting callback calendar worker",
+		zap.String("calendar", s.calendar.Name()),
+		zap.Duration("poll_interval", s.pollInterval),
+	)
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight events to finish.
+func (s *CallbackService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping callback calendar worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("callback calendar worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("callback calendar worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *CallbackService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.syncDue(time.Now())
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.syncDue(time.Now())
+		}
+	}
+}
+
+// syncDue creates calendar events for callbacks that are due an attempt.
+func (s *CallbackService) syncDue(now time.Time) {
+	if !isLeader(s.elector) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	callbacks, err := s.repo.ListDue(ctx, now, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to list due callbacks", zap.Error(err))
+		return
+	}
+
+	for _, callback := range callbacks {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		s.sync(ctx, callback)
+	}
+}
+
+// sync creates the calendar event for one callback and records the outcome.
+func (s *CallbackService) sync(ctx context.Context, callback *domain.Callback) {
+	logger := s.logger.With(zap.String("callback_id", callback.ID.String()))
+
+	// The call is usually stored after the caller hangs up, so link it now
+	// for the name and number on the event.
+	if callback.CallID == nil {
+		s.linkCall(ctx, callback)
+	}
+
+	eventID, err := s.calendar.CreateEvent(ctx, s.event(callback))
+	if err != nil {
+		callback.MarkFailed(err)
+		if callback.Status == domain.CallbackStatusFailed {
+			logger.Error("giving up on callback calendar event", zap.Error(err), zap.Int("attempts", callback.Attempts))
+		} else {
+			logger.Warn("failed to create callback calendar event", zap.Error(err), zap.Time("next_attempt", callback.NextAttemptAt))
+		}
+	} else {
+		callback.MarkScheduled(s.calendar.Name(), eventID)
+		logger.Info("callback added to calendar", zap.String("event_id", eventID))
+	}
+
+	if err := s.repo.Update(ctx, callback); err != nil {
+		logger.Error("failed to update callback", zap.Error(err))
+	}
+}
+
+// event builds the calendar event for a callback.
+func (s *CallbackService) event(callback *domain.Callback) *calendar.Event {
+	var desc strings.Builder
+	if callback.PhoneNumber != "" {
+		fmt.Fprintf(&desc, "Phone: %s\n", callback.PhoneNumber)
+	}
+	fmt.Fprintf(&desc, "Requested: %s, %s\n", callback.RequestedDate, callback.RequestedTime)
+	if callback.Reason != "" {
+		fmt.Fprintf(&desc, "Reason: %s\n", callback.Reason)
+	}
+	if callback.CallID != nil && s.publicURL != "" {
+		fmt.Fprintf(&desc, "Call: %s/calls/%s\n", s.publicURL, callback.CallID)
+	}
+
+	return &calendar.Event{
+		UID:         strings.ReplaceAll(callback.ID.String(), "-", ""),
+		Summary:     "Call back " + callback.DisplayName(),
+		Description: strings.TrimSpace(desc.String()),
+		Start:       callback.ScheduledAt,
+		End:         callback.ScheduledAt.Add(s.eventDuration),
+	}
+}
+
+// linkCall fills in the callback's call, caller name and number from the
+// stored call, if there is one yet.
+func (s *CallbackService) linkCall(ctx context.Context, callback *domain.Callback) {
+	call, err := s.calls.GetByProviderCallID(ctx, callback.ProviderCallID)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to look up call for callback",
+				zap.String("provider_call_id", callback.ProviderCallID),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+
+	callback.CallID = &call.ID
+	if callback.PhoneNumber == "" {
+		callback.PhoneNumber = call.CustomerNumber()
+	}
+	if callback.CallerName == "" && call.CallerName != nil {
+		callback.CallerName = *call.CallerName
+	}
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/calendar"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockCallbackRepository is an in-memory CallbackRepository.
+type MockCallbackRepository struct {
+	mu        sync.Mutex
+	callbacks map[uuid.UUID]*domain.Callback
+}
+
+func NewMockCallbackRepository() *MockCallbackRepository {
+	return &MockCallbackRepository{callbacks: make(map[uuid.UUID]*domain.Callback)}
+}
+
+func (m *MockCallbackRepository) Create(ctx context.Context, callback *domain.Callback) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *callback
+	m.callbacks[callback.ID] = &cp
+	return nil
+}
+
+func (m *MockCallbackRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Callback, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cb, ok := m.callbacks[id]
+	if !ok {
+		return nil, apperrors.NotFound("callback")
+	}
+	cp := *cb
+	return &cp, nil
+}
+
+func (m *MockCallbackRepository) Update(ctx context.Context, callback *domain.Callback) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.callbacks[callback.ID]; !ok {
+		return apperrors.NotFound("callback")
+	}
+	cp := *callback
+	m.callbacks[callback.ID] = &cp
+	return nil
+}
+
+func (m *MockCallbackRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Callback, error) {
+	return m.list(limit, func(cb *domain.Callback) bool {
+		return cb.Status == domain.CallbackStatusPending && !cb.NextAttemptAt.After(now)
+	})
+}
+
+func (m *MockCallbackRepository) ListUpcoming(ctx context.Context, from time.Time, limit int) ([]*domain.Callback, error) {
+	return m.list(limit, func(cb *domain.Callback) bool {
+		return !cb.ScheduledAt.Before(from)
+	})
+}
+
+func (m *MockCallbackRepository) list(limit int, match func(*domain.Callback) bool) ([]*domain.Callback, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.Callback
+	for _, cb := range m.callbacks {
+		if match(cb) {
+			cp := *cb
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ScheduledAt.Before(result[j].ScheduledAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// fakeCalendar records the events it is asked to create.
+type fakeCalendar struct {
+	mu     sync.Mutex
+	events []*calendar.Event
+	err    error
+}
+
+func (f *fakeCalendar) CreateEvent(ctx context.Context, event *calendar.Event) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	f.events = append(f.events, event)
+	return "evt-" + event.UID, nil
+}
+
+func (f *fakeCalendar) Name() string {
+	return "fake"
+}
+
+func newTestCallbackService(cal calendar.Calendar) (*CallbackService, *MockCallbackRepository, *MockCallRepository) {
+	repo := NewMockCallbackRepository()
+	calls := NewMockCallRepository()
+	svc := NewCallbackService(repo, calls, cal, zap.NewNop(), &CallbackServiceConfig{
+		Location:      time.UTC,
+		EventDuration: 15 * time.Minute,
+		PublicURL:     "https://quotes.example.com/",
+		PollInterval:  time.Hour,
+		BatchSize:     10,
+	})
+	return svc, repo, calls
+}
+
+func TestCallbackService_ScheduleCallback(t *testing.T) {
+	svc, repo, calls := newTestCallbackService(nil)
+	call := domain.NewCall("bland-123", "bland", "+15550001111", "+15552223333")
+	name := "Dana"
+	call.CallerName = &name
+	calls.Create(context.Background(), call)
+
+	callback, err := svc.ScheduleCallback(context.Background(), &ScheduleCallbackRequest{
+		ProviderCallID: "bland-123",
+		PreferredDate:  "tomorrow",
+		PreferredTime:  "2pm",
+		Reason:         " discuss the quote ",
+	})
+	if err != nil {
+		t.Fatalf("ScheduleCallback() error = %v", err)
+	}
+
+	if callback.CallID == nil || *callback.CallID != call.ID {
+		t.Errorf("CallID = %v, want %s", callback.CallID, call.ID)
+	}
+	if callback.PhoneNumber != "+15552223333" {
+		t.Errorf("PhoneNumber = %q, want the caller's number", callback.PhoneNumber)
+	}
+	if callback.CallerName != "Dana" {
+		t.Errorf("CallerName = %q, want Dana", callback.CallerName)
+	}
+	if callback.Reason != "discuss the quote" {
+		t.Errorf("Reason = %q", callback.Reason)
+	}
+	if callback.ScheduledAt.Hour() != 14 || !callback.ScheduledAt.After(time.Now()) {
+		t.Errorf("ScheduledAt = %v, want 2pm tomorrow", callback.ScheduledAt)
+	}
+	if callback.Status != domain.CallbackStatusPending {
+		t.Errorf("Status = %q, want pending", callback.Status)
+	}
+
+	if _, err := repo.GetByID(context.Background(), callback.ID); err != nil {
+		t.Errorf("callback not stored: %v", err)
+	}
+}
+
+func TestCallbackService_ScheduleCallback_BeforeCallIsStored(t *testing.T) {
+	svc, _, _ := newTestCallbackService(nil)
+
+	callback, err := svc.ScheduleCallback(context.Background(), &ScheduleCallbackRequest{
+		ProviderCallID: "bland-456",
+		PhoneNumber:    "+15550009999",
+		PreferredDate:  "next monday",
+		PreferredTime:  "morning",
+	})
+	if err != nil {
+		t.Fatalf("ScheduleCallback() error = %v", err)
+	}
+	if callback.CallID != nil {
+		t.Errorf("CallID = %v, want nil until the call is stored", callback.CallID)
+	}
+	if callback.PhoneNumber != "+15550009999" {
+		t.Errorf("PhoneNumber = %q", callback.PhoneNumber)
+	}
+}
+
+func TestCallbackService_ScheduleCallback_Invalid(t *testing.T) {
+	svc, _, _ := newTestCallbackService(nil)
+
+	tests := []struct {
+		name string
+		req  *ScheduleCallbackRequest
+	}{
+		{"missing call id", &ScheduleCallbackRequest{PreferredDate: "tomorrow", PreferredTime: "2pm"}},
+		{"unknown date", &ScheduleCallbackRequest{ProviderCallID: "c1", PreferredDate: "someday", PreferredTime: "2pm"}},
+		{"unknown time", &ScheduleCallbackRequest{ProviderCallID: "c1", PreferredDate: "tomorrow", PreferredTime: "whenever"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ScheduleCallback(context.Background(), tt.req)
+			if !apperrors.IsUserError(err) {
+				t.Errorf("ScheduleCallback() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
+
+func TestCallbackService_SyncDue(t *testing.T) {
+	cal := &fakeCalendar{}
+	svc, repo, calls := newTestCallbackService(cal)
+
+	callback := domain.NewCallback("bland-789", time.Now().Add(24*time.Hour))
+	callback.RequestedDate = "tomorrow"
+	callback.RequestedTime = "afternoon"
+	repo.Create(context.Background(), callback)
+
+	// The call arrives after the callback was scheduled.
+	call := domain.NewCall("bland-789", "bland", "+15550001111", "+15552223333")
+	calls.Create(context.Background(), call)
+
+	svc.syncDue(time.Now())
+
+	if len(cal.events) != 1 {
+		t.Fatalf("created %d events, want 1", len(cal.events))
+	}
+	event := cal.events[0]
+	if event.Summary != "Call back +15552223333" {
+		t.Errorf("Summary = %q", event.Summary)
+	}
+	if event.End.Sub(event.Start) != 15*time.Minute {
+		t.Errorf("event lasts %v, want 15m", event.End.Sub(event.Start))
+	}
+	if !strings.Contains(event.Description, "https://quotes.example.com/calls/"+call.ID.String()) {
+		t.Errorf("Description = %q, want a link to the call", event.Description)
+	}
+
+	stored, _ := repo.GetByID(context.Background(), callback.ID)
+	if stored.Status != domain.CallbackStatusScheduled {
+		t.Errorf("Status = %q, want scheduled", stored.Status)
+	}
+	if stored.CalendarProvider != "fake" || stored.CalendarEventID != "evt-"+event.UID {
+		t.Errorf("calendar = %q/%q", stored.CalendarProvider, stored.CalendarEventID)
+	}
+	if stored.CallID == nil || *stored.CallID != call.ID {
+		t.Errorf("CallID = %v, want %s", stored.CallID, call.ID)
+	}
+
+	// Scheduled callbacks are not synced again.
+	svc.syncDue(time.Now())
+	if len(cal.events) != 1 {
+		t.Errorf("created %d events after second sync, want 1", len(cal.events))
+	}
+}
+
+func TestCallbackService_SyncDue_Failure(t *testing.T) {
+	cal := &fakeCalendar{err: errors.New("calendar unavailable")}
+	svc, repo, _ := newTestCallbackService(cal)
+
+	callback := domain.NewCallback("bland-000", time.Now().Add(time.Hour))
+	repo.Create(context.Background(), callback)
+
+	svc.syncDue(time.Now())
+
+	stored, _ := repo.GetByID(context.Background(), callback.ID)
+	if stored.Status != domain.CallbackStatusPending {
+		t.Errorf("Status = %q, want pending for a retry", stored.Status)
+	}
+	if stored.Attempts != 1 || stored.LastError == nil || *stored.LastError != "calendar unavailable" {
+		t.Errorf("Attempts = %d, LastError = %v", stored.Attempts, stored.LastError)
+	}
+	if !stored.NextAttemptAt.After(time.Now()) {
+		t.Errorf("NextAttemptAt = %v, want a later retry", stored.NextAttemptAt)
+	}
+
+	// Not due again until the backoff passes.
+	svc.syncDue(time.Now())
+	stored, _ = repo.GetByID(context.Background(), callback.ID)
+	if stored.Attempts != 1 {
+		t.Errorf("Attempts = %d after early sync, want 1", stored.Attempts)
+	}
+}
+
+func TestCallbackService_StartWithoutCalendar(t *testing.T) {
+	svc, _, _ := newTestCallbackService(nil)
+
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := svc.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+}
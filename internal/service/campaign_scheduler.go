@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+)
+
+// CampaignDialer places outbound calls for campaign contacts.
+type CampaignDialer interface {
+	InitiateCall(ctx context.Context, req *InitiateCallRequest) (*InitiateCallResponse, error)
+}
+
+// QuoteCapacity reports remaining quote generation capacity.
+type QuoteCapacity interface {
+	Stats() ratelimit.QuoteLimiterStats
+}
+
+// CampaignScheduler drips out calls for active campaigns, one contact at a
+// time per campaign, honoring each campaign's window and pacing. Dialing is
+// held back whenever the quote rate limiter is out of capacity so campaigns
+// never produce more calls than can be quoted, and while the monthly budget's
+// hard cap is reached.
+type CampaignScheduler struct {
+	repo     domain.CampaignRepository
+	dialer   CampaignDialer
+	capacity QuoteCapacity
+	budget   CallBudget
+	elector  Elector
+	logger   *zap.Logger
+
+	// Configuration
+	pollInterval        time.Duration
+	dialTimeout         time.Duration
+	staleDialingTimeout time.Duration
+
+	// Lifecycle
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	running  bool
+	draining atomic.Bool // Set by Drain; no new calls are placed
+}
+
+// CampaignSchedulerConfig holds configuration for the scheduler.
+type CampaignSchedulerConfig struct {
+	PollInterval        time.Duration
+	DialTimeout         time.Duration
+	StaleDialingTimeout time.Duration
+}
+
+// DefaultCampaignSchedulerConfig returns sensible defaults.
+func DefaultCampaignSchedulerConfig() *CampaignSchedulerConfig {
+	return &CampaignSchedulerConfig{
+		PollInterval:        5 * time.Second,
+		DialTimeout:         30 * time.Second,
+		StaleDialingTimeout: 5 * time.Minute,
+	}
+}
+
+// NewCampaignScheduler creates a new campaign scheduler. capacity may be nil
+// to dial without regard to the quote rate limiter.
+func NewCampaignScheduler(
+	repo domain.CampaignRepository,
+	dialer CampaignDialer,
+	capacity QuoteCapacity,
+	logger *zap.Logger,
+	config *CampaignSchedulerConfig,
+) *CampaignScheduler {
+	if config == nil {
+		config = DefaultCampaignSchedulerConfig()
+	}
+
+	return &CampaignScheduler{
+		repo:                repo,
+		dialer:              dialer,
+		capacity:            capacity,
+		logger:              logger,
+		pollInterval:        config.PollInterval,
+		dialTimeout:         config.DialTimeout,
+		staleDialingTimeout: config.StaleDialingTimeout,
+		stopCh:              make(chan struct{}),
+	}
+}
+
+// SetBudget holds campaign calls back while the monthly budget's hard cap is
+// reached, rather than failing each contact.
+func (s *CampaignScheduler) SetBudget(budget CallBudget) {
+	s.budget = budget
+}
+
+// SetElector limits campaign dialing to the instance elector chooses.
+func (s *CampaignScheduler) SetElector(elector Elector) {
+	s.elector = elector
+}
+
+// Start begins the scheduling loop.
+func (s *CampaignScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("scheduler already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting campaign scheduler", zap.Duration("poll_interval", s.pollInterval))
+
+	// A contact left dialing by a crash may or may not have been called;
+	// fail it rather than risk calling the same person twice.
+	if n, err := s.repo.FailStaleDialingContacts(ctx, s.staleDialingTimeout); err != nil {
+		s.logger.Error("failed to recover stale campaign contacts", zap.Error(err))
+	} else if n > 0 {
+		s.logger.Warn("marked interrupted campaign contacts as failed", zap.Int("count", n))
+	}
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the scheduling loop, waiting for an in-flight dial to finish.
+func (s *CampaignScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping campaign scheduler")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("campaign scheduler stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("campaign scheduler stop timed out")
+		return ctx.Err()
+	}
+}
+
+// Drain stops the scheduler placing new campaign calls ahead of shutdown,
+// pausing campaign dialing on this replica. Campaigns stay active, so other
+// replicas, or this one after a restart, carry on where it stopped.
+func (s *CampaignScheduler) Drain() {
+	if !s.draining.Swap(true) {
+		s.logger.Info("campaign scheduler draining; no new calls will be placed")
+	}
+}
+
+// runLoop is the main scheduling loop.
+func (s *CampaignScheduler) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.tick(time.Now())
+		}
+	}
+}
+
+// tick dials at most one contact for each campaign that is ready.
+func (s *CampaignScheduler) tick(now time.Time) {
+	if s.draining.Load() || !isLeader(s.elector) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	campaigns, err := s.repo.ListActive(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to list active campaigns", zap.Error(err))
+		return
+	}
+
+	for _, campaign := range campaigns {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		if campaign.HasEnded(now) {
+			s.complete(ctx, campaign, "end date reached")
+			continue
+		}
+		if !campaign.ReadyToDial(now) {
+			continue
+		}
+		if !s.hasQuoteCapacity() {
+			s.logger.Debug("quote capacity exhausted, holding campaign calls")
+			return
+		}
+		if s.budget != nil && s.budget.CheckBudget(ctx) != nil {
+			s.logger.Debug("budget hard cap reached, holding campaign calls")
+			return
+		}
+
+		s.dialNext(campaign, now)
+	}
+}
+
+// dialNext places a call to the campaign's next pending contact.
+func (s *CampaignScheduler) dialNext(campaign *domain.Campaign, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.dialTimeout)
+	defer cancel()
+
+	logger := s.logger.With(zap.String("campaign_id", campaign.ID.String()))
+
+	contact, err := s.repo.NextPendingContact(ctx, campaign.ID)
+	if err != nil {
+		logger.Error("failed to get next campaign contact", zap.Error(err))
+		return
+	}
+	if contact == nil {
+		s.completeIfDrained(ctx, campaign)
+		return
+	}
+
+	logger = logger.With(zap.String("contact_id", contact.ID.String()))
+
+	contact.MarkDialing()
+	if err := s.repo.UpdateContact(ctx, contact); err != nil {
+		logger.Error("failed to mark campaign contact dialing", zap.Error(err))
+		return
+	}
+
+	// Record the dial before placing the call so pacing holds even if the
+	// provider request is slow or the process stops mid-call.
+	campaign.MarkDialed(now)
+	if err := s.repo.Update(ctx, campaign); err != nil {
+		logger.Error("failed to record campaign dial", zap.Error(err))
+	}
+
+	req := &InitiateCallRequest{
+		PhoneNumber:    contact.PhoneNumber,
+		PromptID:       campaign.PromptID,
+		IdempotencyKey: "campaign-contact-" + contact.ID.String(),
+		Metadata: map[string]interface{}{
+			"campaign_id":         campaign.ID.String(),
+			"campaign_contact_id": contact.ID.String(),
+		},
+	}
+	if contact.Name != "" {
+		req.RequestData = map[string]interface{}{"name": contact.Name}
+	}
+
+	resp, err := s.dialer.InitiateCall(ctx, req)
+	if err != nil {
+		contact.MarkFailed(err)
+		logger.Warn("campaign call failed", zap.Error(err))
+	} else {
+		contact.MarkCalled(resp.CallID)
+		logger.Info("campaign call placed", zap.String("call_id", resp.CallID.String()))
+	}
+
+	if err := s.repo.UpdateContact(ctx, contact); err != nil {
+		logger.Error("failed to update campaign contact", zap.Error(err))
+	}
+}
+
+// completeIfDrained completes a campaign once no contacts are pending or dialing.
+func (s *CampaignScheduler) completeIfDrained(ctx context.Context, campaign *domain.Campaign) {
+	counts, err := s.repo.CountContactsByStatus(ctx, campaign.ID)
+	if err != nil {
+		s.logger.Error("failed to count campaign contacts",
+			zap.String("campaign_id", campaign.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if counts[domain.CampaignContactStatusPending] > 0 || counts[domain.CampaignContactStatusDialing] > 0 {
+		return
+	}
+	s.complete(ctx, campaign, "all contacts dialed")
+}
+
+// complete marks a campaign as finished.
+func (s *CampaignScheduler) complete(ctx context.Context, campaign *domain.Campaign, reason string) {
+	campaign.Complete()
+	if err := s.repo.Update(ctx, campaign); err != nil {
+		s.logger.Error("failed to complete campaign",
+			zap.String("campaign_id", campaign.ID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	s.logger.Info("campaign completed",
+		zap.String("campaign_id", campaign.ID.String()),
+		zap.String("reason", reason),
+	)
+}
+
+// hasQuoteCapacity reports whether the quote limiter can absorb another call.
+func (s *CampaignScheduler) hasQuoteCapacity() bool {
+	if s.capacity == nil {
+		return true
+	}
+	stats := s.capacity.Stats()
+	return stats.MinuteRemaining > 0 && stats.HourRemaining > 0 && stats.DayRemaining > 0
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/validation"
+)
+
+// MaxCampaignContacts is the largest contact list accepted for a single campaign.
+const MaxCampaignContacts = 10000
+
+// CampaignService manages outbound call campaigns.
+type CampaignService struct {
+	repo   domain.CampaignRepository
+	logger *zap.Logger
+}
+
+// NewCampaignService creates a new CampaignService.
+func NewCampaignService(repo domain.CampaignRepository, logger *zap.Logger) *CampaignService {
+	return &CampaignService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateCampaignRequest holds the parameters for creating a campaign.
+type CreateCampaignRequest struct {
+	Name         string
+	PromptID     *uuid.UUID
+	StartsAt     time.Time
+	EndsAt       *time.Time
+	WindowStart  string // HH:MM in Timezone
+	WindowEnd    string // HH:MM in Timezone
+	Timezone     string
+	CallsPerHour int
+	Contacts     io.Reader // CSV contact list
+	CreatedBy    *uuid.UUID
+}
+
+// CampaignWithStats pairs a campaign with its contact progress.
+type CampaignWithStats struct {
+	*domain.Campaign
+	Stats domain.CampaignStats `json:"stats"`
+}
+
+// CreateCampaign validates the schedule, parses the contact list and stores the campaign.
+// It returns the number of contact list rows that were skipped as invalid or duplicate.
+func (s *CampaignService) CreateCampaign(ctx context.Context, req CreateCampaignRequest) (*domain.Campaign, int, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, 0, apperrors.MissingField("name")
+	}
+	if req.Contacts == nil {
+		return nil, 0, apperrors.MissingField("contacts")
+	}
+
+	campaign := domain.NewCampaign(name, req.PromptID, req.StartsAt)
+	campaign.EndsAt = req.EndsAt
+	campaign.CreatedBy = req.CreatedBy
+	if req.Timezone != "" {
+		campaign.Timezone = req.Timezone
+	}
+	if req.CallsPerHour != 0 {
+		campaign.CallsPerHour = req.CallsPerHour
+	}
+
+	var err error
+	if req.WindowStart != "" {
+		if campaign.WindowStartMinute, err = domain.ParseWindowMinute(req.WindowStart); err != nil {
+			return nil, 0, apperrors.ValidationFailed(err.Error())
+		}
+	}
+	if req.WindowEnd != "" {
+		if campaign.WindowEndMinute, err = domain.ParseWindowMinute(req.WindowEnd); err != nil {
+			return nil, 0, apperrors.ValidationFailed(err.Error())
+		}
+	}
+	if err := campaign.Validate(); err != nil {
+		return nil, 0, apperrors.ValidationFailed(err.Error())
+	}
+
+	entries, skipped, err := ParseContactList(req.Contacts)
+	if err != nil {
+		return nil, 0, apperrors.ValidationFailed(err.Error())
+	}
+
+	contacts := make([]*domain.CampaignContact, 0, len(entries))
+	for _, e := range entries {
+		contacts = append(contacts, domain.NewCampaignContact(campaign.ID, e.PhoneNumber, e.Name))
+	}
+
+	if err := s.repo.Create(ctx, campaign, contacts); err != nil {
+		return nil, 0, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	s.logger.Info("campaign created",
+		zap.String("campaign_id", campaign.ID.String()),
+		zap.String("name", campaign.Name),
+		zap.Int("contacts", len(contacts)),
+		zap.Int("skipped", skipped),
+	)
+
+	return campaign, skipped, nil
+}
+
+// GetCampaign returns a campaign with its contact progress.
+func (s *CampaignService) GetCampaign(ctx context.Context, id uuid.UUID) (*CampaignWithStats, error) {
+	campaign, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withStats(ctx, campaign)
+}
+
+// ListCampaigns returns the most recent campaigns with their contact progress.
+func (s *CampaignService) ListCampaigns(ctx context.Context, limit int) ([]*CampaignWithStats, error) {
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	campaigns, err := s.repo.List(ctx, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	result := make([]*CampaignWithStats, 0, len(campaigns))
+	for _, c := range campaigns {
+		cs, err := s.withStats(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cs)
+	}
+	return result, nil
+}
+
+// ListContacts returns a page of a campaign's contacts.
+func (s *CampaignService) ListContacts(ctx context.Context, campaignID uuid.UUID, limit, offset int) ([]*domain.CampaignContact, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListContacts(ctx, campaignID, limit, offset)
+}
+
+// PauseCampaign stops dialing for an active campaign.
+func (s *CampaignService) PauseCampaign(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	return s.transition(ctx, id, "paused", (*domain.Campaign).Pause)
+}
+
+// ResumeCampaign restarts dialing for a paused campaign.
+func (s *CampaignService) ResumeCampaign(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	return s.transition(ctx, id, "resumed", (*domain.Campaign).Resume)
+}
+
+// CancelCampaign permanently stops a campaign. Contacts not yet dialed stay pending.
+func (s *CampaignService) CancelCampaign(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	return s.transition(ctx, id, "cancelled", (*domain.Campaign).Cancel)
+}
+
+// transition loads a campaign, applies a status change and saves it.
+func (s *CampaignService) transition(ctx context.Context, id uuid.UUID, action string, apply func(*domain.Campaign) error) (*domain.Campaign, error) {
+	campaign, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(campaign); err != nil {
+		if errors.Is(err, domain.ErrInvalidCampaignTransition) {
+			return nil, apperrors.New(apperrors.CodeConflict, fmt.Sprintf("campaign cannot be %s while %s", action, campaign.Status))
+		}
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	s.logger.Info("campaign "+action, zap.String("campaign_id", campaign.ID.String()))
+	return campaign, nil
+}
+
+func (s *CampaignService) withStats(ctx context.Context, campaign *domain.Campaign) (*CampaignWithStats, error) {
+	counts, err := s.repo.CountContactsByStatus(ctx, campaign.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count campaign contacts: %w", err)
+	}
+	return &CampaignWithStats{Campaign: campaign, Stats: domain.NewCampaignStats(counts)}, nil
+}
+
+// ContactEntry is a single parsed row of an uploaded contact list.
+type ContactEntry struct {
+	PhoneNumber string
+	Name        string
+}
+
+// ParseContactList reads a CSV contact list. The first row may be a header
+// naming a "phone" (or "phone_number", "number") column and an optional
+// "name" column; without a header the first column is the phone number and
+// the second, if present, the name. Invalid and duplicate numbers are
+// skipped and counted.
+func ParseContactList(r io.Reader) ([]ContactEntry, int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	phoneCol, nameCol := 0, 1
+	seen := make(map[string]bool)
+	var entries []ContactEntry
+	skipped := 0
+
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid contact list: %w", err)
+		}
+
+		if line == 0 {
+			if p, n, ok := contactListHeader(record); ok {
+				phoneCol, nameCol = p, n
+				continue
+			}
+		}
+
+		if phoneCol >= len(record) {
+			skipped++
+			continue
+		}
+
+		phone := normalizePhoneNumber(record[phoneCol])
+		if phone == "" || seen[phone] {
+			skipped++
+			continue
+		}
+		seen[phone] = true
+
+		entry := ContactEntry{PhoneNumber: phone}
+		if nameCol >= 0 && nameCol < len(record) {
+			entry.Name = strings.TrimSpace(record[nameCol])
+		}
+		entries = append(entries, entry)
+
+		if len(entries) > MaxCampaignContacts {
+			return nil, 0, fmt.Errorf("contact list exceeds %d contacts", MaxCampaignContacts)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, 0, errors.New("contact list has no valid phone numbers")
+	}
+	return entries, skipped, nil
+}
+
+// contactListHeader reports the phone and name column indexes if record is a header row.
+func contactListHeader(record []string) (phoneCol, nameCol int, ok bool) {
+	phoneCol, nameCol = -1, -1
+	for i, field := range record {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "phone", "phone_number", "phone number", "number":
+			phoneCol = i
+		case "name", "caller_name", "contact":
+			nameCol = i
+		}
+	}
+	return phoneCol, nameCol, phoneCol >= 0
+}
+
+// normalizePhoneNumber strips formatting and returns an E.164-style number,
+// or "" if the value is not a valid phone number.
+func normalizePhoneNumber(s string) string {
+	cleaned := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(s))
+	if cleaned == "" {
+		return ""
+	}
+	v := validation.New()
+	if !v.PhoneNumber("phone_number", cleaned) {
+		return ""
+	}
+	if !strings.HasPrefix(cleaned, "+") {
+		// Bare 10-digit numbers are assumed to be North American.
+		if len(cleaned) == 10 {
+			cleaned = "1" + cleaned
+		}
+		cleaned = "+" + cleaned
+	}
+	return cleaned
+}
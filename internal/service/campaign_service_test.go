@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/ratelimit"
+)
+
+// MockCampaignRepository is a mock implementation of domain.CampaignRepository.
+type MockCampaignRepository struct {
+	mu        sync.RWMutex
+	campaigns map[uuid.UUID]*domain.Campaign
+	contacts  []*domain.CampaignContact // upload order
+}
+
+func NewMockCampaignRepository() *MockCampaignRepository {
+	return &MockCampaignRepository{
+		campaigns: make(map[uuid.UUID]*domain.Campaign),
+	}
+}
+
+func (m *MockCampaignRepository) Create(ctx context.Context, campaign *domain.Campaign, contacts []*domain.CampaignContact) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.campaigns[campaign.ID] = campaign
+	m.contacts = append(m.contacts, contacts...)
+	return nil
+}
+
+func (m *MockCampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if c, ok := m.campaigns[id]; ok {
+		return c, nil
+	}
+	return nil, apperrors.NotFound("campaign")
+}
+
+func (m *MockCampaignRepository) Update(ctx context.Context, campaign *domain.Campaign) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.campaigns[campaign.ID]; !ok {
+		return apperrors.NotFound("campaign")
+	}
+	m.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (m *MockCampaignRepository) List(ctx context.Context, limit, offset int) ([]*domain.Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.Campaign
+	for _, c := range m.campaigns {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+func (m *MockCampaignRepository) ListActive(ctx context.Context, now time.Time) ([]*domain.Campaign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.Campaign
+	for _, c := range m.campaigns {
+		if c.Status == domain.CampaignStatusActive && !c.StartsAt.After(now) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockCampaignRepository) NextPendingContact(ctx context.Context, campaignID uuid.UUID) (*domain.CampaignContact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, c := range m.contacts {
+		if c.CampaignID == campaignID && c.Status == domain.CampaignContactStatusPending {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockCampaignRepository) UpdateContact(ctx context.Context, contact *domain.CampaignContact) error {
+	return nil
+}
+
+func (m *MockCampaignRepository) ListContacts(ctx context.Context, campaignID uuid.UUID, limit, offset int) ([]*domain.CampaignContact, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.CampaignContact
+	for _, c := range m.contacts {
+		if c.CampaignID == campaignID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockCampaignRepository) CountContactsByStatus(ctx context.Context, campaignID uuid.UUID) (map[domain.CampaignContactStatus]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[domain.CampaignContactStatus]int)
+	for _, c := range m.contacts {
+		if c.CampaignID == campaignID {
+			counts[c.Status]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *MockCampaignRepository) FailStaleDialingContacts(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, c := range m.contacts {
+		if c.Status == domain.CampaignContactStatusDialing {
+			c.MarkFailed(errors.New("interrupted"))
+			n++
+		}
+	}
+	return n, nil
+}
+
+// fakeCampaignDialer records calls and fails numbers listed in failNumbers.
+type fakeCampaignDialer struct {
+	mu          sync.Mutex
+	requests    []*InitiateCallRequest
+	failNumbers map[string]bool
+}
+
+func (d *fakeCampaignDialer) InitiateCall(ctx context.Context, req *InitiateCallRequest) (*InitiateCallResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, req)
+	if d.failNumbers[req.PhoneNumber] {
+		return nil, errors.New("provider rejected number")
+	}
+	return &InitiateCallResponse{CallID: uuid.New(), PhoneNumber: req.PhoneNumber}, nil
+}
+
+type fakeQuoteCapacity struct {
+	stats ratelimit.QuoteLimiterStats
+}
+
+func (f *fakeQuoteCapacity) Stats() ratelimit.QuoteLimiterStats {
+	return f.stats
+}
+
+func plentyOfCapacity() *fakeQuoteCapacity {
+	return &fakeQuoteCapacity{stats: ratelimit.QuoteLimiterStats{
+		MinuteRemaining: 10,
+		HourRemaining:   100,
+		DayRemaining:    1000,
+	}}
+}
+
+func TestParseContactList(t *testing.T) {
+	t.Run("with header", func(t *testing.T) {
+		csv := "Name,Phone Number\nAlice,(555) 123-4567\nBob,+44 20 7946 0958\nDup,555-123-4567\nNobody,not-a-number\n"
+		entries, skipped, err := ParseContactList(strings.NewReader(csv))
+		if err != nil {
+			t.Fatalf("ParseContactList() error = %v", err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(entries))
+		}
+		if entries[0].PhoneNumber != "+15551234567" || entries[0].Name != "Alice" {
+			t.Errorf("unexpected first entry %+v", entries[0])
+		}
+		if entries[1].PhoneNumber != "+442079460958" {
+			t.Errorf("unexpected second number %q", entries[1].PhoneNumber)
+		}
+		if skipped != 2 {
+			t.Errorf("expected 2 skipped rows, got %d", skipped)
+		}
+	})
+
+	t.Run("without header", func(t *testing.T) {
+		entries, skipped, err := ParseContactList(strings.NewReader("+15550000001,Carol\n+15550000002\n"))
+		if err != nil {
+			t.Fatalf("ParseContactList() error = %v", err)
+		}
+		if len(entries) != 2 || skipped != 0 {
+			t.Fatalf("expected 2 entries and 0 skipped, got %d and %d", len(entries), skipped)
+		}
+		if entries[0].Name != "Carol" || entries[1].Name != "" {
+			t.Errorf("unexpected names %q, %q", entries[0].Name, entries[1].Name)
+		}
+	})
+
+	t.Run("no valid numbers", func(t *testing.T) {
+		if _, _, err := ParseContactList(strings.NewReader("phone\nabc\n")); err == nil {
+			t.Error("expected error for list with no valid numbers")
+		}
+	})
+}
+
+func TestCampaignService_CreateCampaign(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	svc := NewCampaignService(repo, zap.NewNop())
+
+	campaign, skipped, err := svc.CreateCampaign(context.Background(), CreateCampaignRequest{
+		Name:         "  Spring promo  ",
+		WindowStart:  "09:00",
+		WindowEnd:    "17:30",
+		Timezone:     "America/Chicago",
+		CallsPerHour: 60,
+		Contacts:     strings.NewReader("phone\n+15550000001\n+15550000001\n"),
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign() error = %v", err)
+	}
+	if campaign.Name != "Spring promo" {
+		t.Errorf("expected trimmed name, got %q", campaign.Name)
+	}
+	if campaign.WindowStartMinute != 540 || campaign.WindowEndMinute != 1050 {
+		t.Errorf("unexpected window %d-%d", campaign.WindowStartMinute, campaign.WindowEndMinute)
+	}
+	if skipped != 1 {
+		t.Errorf("expected 1 skipped duplicate, got %d", skipped)
+	}
+
+	got, err := svc.GetCampaign(context.Background(), campaign.ID)
+	if err != nil {
+		t.Fatalf("GetCampaign() error = %v", err)
+	}
+	if got.Stats.Pending != 1 {
+		t.Errorf("expected 1 pending contact, got %d", got.Stats.Pending)
+	}
+}
+
+func TestCampaignService_CreateCampaign_Invalid(t *testing.T) {
+	svc := NewCampaignService(NewMockCampaignRepository(), zap.NewNop())
+
+	tests := []struct {
+		name string
+		req  CreateCampaignRequest
+	}{
+		{"missing name", CreateCampaignRequest{Contacts: strings.NewReader("+15550000001")}},
+		{"missing contacts", CreateCampaignRequest{Name: "Test"}},
+		{"bad window", CreateCampaignRequest{Name: "Test", WindowStart: "9am", Contacts: strings.NewReader("+15550000001")}},
+		{"bad timezone", CreateCampaignRequest{Name: "Test", Timezone: "Nowhere", Contacts: strings.NewReader("+15550000001")}},
+		{"empty list", CreateCampaignRequest{Name: "Test", Contacts: strings.NewReader("phone\n")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.CreateCampaign(context.Background(), tt.req)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !apperrors.IsUserError(err) {
+				t.Errorf("expected user-facing error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCampaignService_Transitions(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	svc := NewCampaignService(repo, zap.NewNop())
+	ctx := context.Background()
+
+	campaign := domain.NewCampaign("Test", nil, time.Now())
+	_ = repo.Create(ctx, campaign, nil)
+
+	if _, err := svc.ResumeCampaign(ctx, campaign.ID); err == nil {
+		t.Error("expected resuming an active campaign to fail")
+	}
+	if _, err := svc.PauseCampaign(ctx, campaign.ID); err != nil {
+		t.Fatalf("PauseCampaign() error = %v", err)
+	}
+	if _, err := svc.CancelCampaign(ctx, campaign.ID); err != nil {
+		t.Fatalf("CancelCampaign() error = %v", err)
+	}
+	if _, err := svc.PauseCampaign(ctx, uuid.New()); !apperrors.IsNotFound(err) {
+		t.Errorf("expected not found for unknown campaign, got %v", err)
+	}
+}
+
+func newTestScheduler(repo *MockCampaignRepository, dialer *fakeCampaignDialer, capacity QuoteCapacity) *CampaignScheduler {
+	return NewCampaignScheduler(repo, dialer, capacity, zap.NewNop(), &CampaignSchedulerConfig{
+		PollInterval:        10 * time.Millisecond,
+		DialTimeout:         time.Second,
+		StaleDialingTimeout: time.Minute,
+	})
+}
+
+func TestCampaignScheduler_TickDialsAndPaces(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	dialer := &fakeCampaignDialer{failNumbers: map[string]bool{"+15550000002": true}}
+	scheduler := newTestScheduler(repo, dialer, plentyOfCapacity())
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	campaign := domain.NewCampaign("Test", nil, start)
+	campaign.CallsPerHour = 60
+	contacts := []*domain.CampaignContact{
+		domain.NewCampaignContact(campaign.ID, "+15550000001", "Alice"),
+		domain.NewCampaignContact(campaign.ID, "+15550000002", ""),
+	}
+	_ = repo.Create(context.Background(), campaign, contacts)
+
+	now := start.Add(time.Hour)
+	scheduler.tick(now)
+	scheduler.tick(now.Add(30 * time.Second)) // held back by pacing
+
+	if len(dialer.requests) != 1 {
+		t.Fatalf("expected 1 call after paced ticks, got %d", len(dialer.requests))
+	}
+	req := dialer.requests[0]
+	if req.PhoneNumber != "+15550000001" || req.RequestData["name"] != "Alice" {
+		t.Errorf("unexpected call request %+v", req)
+	}
+	if contacts[0].Status != domain.CampaignContactStatusCalled || contacts[0].CallID == nil {
+		t.Errorf("expected first contact called, got %s", contacts[0].Status)
+	}
+
+	scheduler.tick(now.Add(time.Minute))
+	if contacts[1].Status != domain.CampaignContactStatusFailed || contacts[1].LastError == nil {
+		t.Errorf("expected second contact failed, got %s", contacts[1].Status)
+	}
+
+	scheduler.tick(now.Add(2 * time.Minute))
+	if campaign.Status != domain.CampaignStatusCompleted {
+		t.Errorf("expected drained campaign to complete, got %s", campaign.Status)
+	}
+}
+
+func TestCampaignScheduler_TickRespectsQuoteCapacity(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	dialer := &fakeCampaignDialer{}
+	capacity := plentyOfCapacity()
+	capacity.stats.HourRemaining = 0
+	scheduler := newTestScheduler(repo, dialer, capacity)
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	campaign := domain.NewCampaign("Test", nil, start)
+	_ = repo.Create(context.Background(), campaign, []*domain.CampaignContact{
+		domain.NewCampaignContact(campaign.ID, "+15550000001", ""),
+	})
+
+	scheduler.tick(start.Add(time.Hour))
+	if len(dialer.requests) != 0 {
+		t.Fatalf("expected no calls while quote capacity is exhausted, got %d", len(dialer.requests))
+	}
+
+	capacity.stats.HourRemaining = 5
+	scheduler.tick(start.Add(time.Hour))
+	if len(dialer.requests) != 1 {
+		t.Errorf("expected call once capacity returned, got %d", len(dialer.requests))
+	}
+}
+
+func TestCampaignScheduler_TickCompletesEndedCampaign(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	dialer := &fakeCampaignDialer{}
+	scheduler := newTestScheduler(repo, dialer, nil)
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	campaign := domain.NewCampaign("Test", nil, start)
+	campaign.EndsAt = &end
+	_ = repo.Create(context.Background(), campaign, []*domain.CampaignContact{
+		domain.NewCampaignContact(campaign.ID, "+15550000001", ""),
+	})
+
+	scheduler.tick(end.Add(time.Minute))
+	if len(dialer.requests) != 0 {
+		t.Errorf("expected no calls after end date, got %d", len(dialer.requests))
+	}
+	if campaign.Status != domain.CampaignStatusCompleted {
+		t.Errorf("expected campaign completed, got %s", campaign.Status)
+	}
+}
+
+func TestCampaignScheduler_StartRecoversStaleContacts(t *testing.T) {
+	repo := NewMockCampaignRepository()
+	scheduler := newTestScheduler(repo, &fakeCampaignDialer{}, nil)
+
+	campaign := domain.NewCampaign("Test", nil, time.Now())
+	contact := domain.NewCampaignContact(campaign.ID, "+15550000001", "")
+	contact.MarkDialing()
+	_ = repo.Create(context.Background(), campaign, []*domain.CampaignContact{contact})
+
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := scheduler.Start(context.Background()); err == nil {
+		t.Error("expected second Start to fail")
+	}
+	if err := scheduler.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if contact.Status != domain.CampaignContactStatusFailed {
+		t.Errorf("expected interrupted contact to be failed, got %s", contact.Status)
+	}
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// dncImportChunkSize is how many numbers are written per statement when
+// importing the national registry.
+const dncImportChunkSize = 5000
+
+// CallCompliance decides whether a number may be called now.
+// ComplianceService implements it.
+type CallCompliance interface {
+	CheckCall(ctx context.Context, phoneNumber string) error
+}
+
+// ComplianceService keeps the do-not-call list and blocks calls to numbers
+// on it or outside the calling window in the called party's time zone.
+type ComplianceService struct {
+	repo   domain.DNCRepository
+	window domain.CallingWindow
+	logger *zap.Logger
+
+	clock clock.Clock
+}
+
+// ComplianceServiceConfig holds configuration for the compliance service.
+type ComplianceServiceConfig struct {
+	CallingWindow domain.CallingWindow // Zero allows calls at any time
+}
+
+// NewComplianceService creates a new ComplianceService.
+func NewComplianceService(repo domain.DNCRepository, logger *zap.Logger, config *ComplianceServiceConfig, clk clock.Clock) *ComplianceService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = &ComplianceServiceConfig{}
+	}
+
+	return &ComplianceService{
+		repo:   repo,
+		window: config.CallingWindow,
+		logger: logger,
+		clock:  clk,
+	}
+}
+
+// DNCList is a page of the do-not-call list.
+type DNCList struct {
+	Entries []*domain.DNCEntry `json:"entries"`
+	Total   int                `json:"total"`
+}
+
+// AddDNCRequest puts a number on the do-not-call list.
+type AddDNCRequest struct {
+	PhoneNumber string     `json:"phone_number"`
+	Reason      string     `json:"reason,omitempty"`
+	CreatedBy   *uuid.UUID `json:"-"`
+}
+
+// DNCImportResult summarizes a national registry import.
+type DNCImportResult struct {
+	Imported int `json:"imported"` // Numbers read from the file
+	Skipped  int `json:"skipped"`  // Lines that were not phone numbers
+	Pruned   int `json:"pruned"`   // National entries removed because the file no longer lists them
+}
+
+// CheckCall returns a compliance error if phoneNumber is on the do-not-call
+// list or the calling window is closed where it is.
+func (s *ComplianceService) CheckCall(ctx context.Context, phoneNumber string) error {
+	if err := s.CheckDoNotCall(ctx, phoneNumber); err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+	next := s.window.NextAllowed(phoneNumber, now)
+	if next.Equal(now) {
+		return nil
+	}
+	cerr := &domain.ComplianceError{Reason: domain.ComplianceReasonCallingWindow, PhoneNumber: phoneNumber}
+	if !next.IsZero() {
+		cerr.NextAllowed = &next
+	}
+	return complianceBlocked(cerr)
+}
+
+// CheckDoNotCall returns a compliance error if phoneNumber is on the
+// do-not-call list.
+func (s *ComplianceService) CheckDoNotCall(ctx context.Context, phoneNumber string) error {
+	_, err := s.repo.Get(ctx, dncKey(phoneNumber))
+	if apperrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check do-not-call list: %w", err)
+	}
+	return complianceBlocked(&domain.ComplianceError{Reason: domain.ComplianceReasonDoNotCall, PhoneNumber: phoneNumber})
+}
+
+// AddNumber puts a number on the do-not-call list. A number already on the
+// list keeps its existing entry, which is returned.
+func (s *ComplianceService) AddNumber(ctx context.Context, req *AddDNCRequest) (*domain.DNCEntry, error) {
+	phone := normalizePhoneNumber(req.PhoneNumber)
+	if phone == "" {
+		return nil, apperrors.ValidationFailed("phone_number must be a valid phone number")
+	}
+
+	entry := &domain.DNCEntry{
+		PhoneNumber: phone,
+		Source:      domain.DNCSourceManual,
+		Reason:      strings.TrimSpace(req.Reason),
+		CreatedBy:   req.CreatedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.repo.Add(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to add number to do-not-call list: %w", err)
+	}
+
+	s.logger.Info("number added to do-not-call list", zap.String("phone_number", phone))
+	return s.repo.Get(ctx, phone)
+}
+
+// RemoveNumber takes a number off the do-not-call list.
+func (s *ComplianceService) RemoveNumber(ctx context.Context, phoneNumber string) error {
+	phone := dncKey(phoneNumber)
+	if err := s.repo.Remove(ctx, phone, ""); err != nil {
+		return err
+	}
+
+	s.logger.Info("number removed from do-not-call list", zap.String("phone_number", phone))
+	return nil
+}
+
+// ListNumbers returns a page of the do-not-call list.
+func (s *ComplianceService) ListNumbers(ctx context.Context, filter *domain.DNCListFilter, limit, offset int) (*DNCList, error) {
+	entries, err := s.repo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*domain.DNCEntry{}
+	}
+	return &DNCList{Entries: entries, Total: total}, nil
+}
+
+// ImportNational adds the numbers in a national registry file, one number
+// per line, either as a single field or split as "area code,number". When
+// replace is true, national entries the file no longer lists are removed.
+// Entries staff added or customers opted into are never changed.
+func (s *ComplianceService) ImportNational(ctx context.Context, r io.Reader, replace bool) (*DNCImportResult, error) {
+	// Postgres keeps microseconds; truncating keeps this import's rows from
+	// comparing older than importedAt when pruning.
+	importedAt := time.Now().UTC().Truncate(time.Microsecond)
+	result := &DNCImportResult{}
+
+	chunk := make([]string, 0, dncImportChunkSize)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := s.repo.ImportNational(ctx, chunk, importedAt); err != nil {
+			return fmt.Errorf("failed to import do-not-call numbers: %w", err)
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		phone := normalizePhoneNumber(strings.ReplaceAll(line, ",", ""))
+		if phone == "" {
+			result.Skipped++
+			continue
+		}
+		chunk = append(chunk, phone)
+		result.Imported++
+		if len(chunk) == dncImportChunkSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperrors.ValidationFailed("failed to read do-not-call file: " + err.Error())
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if replace && result.Imported > 0 {
+		pruned, err := s.repo.PruneNational(ctx, importedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prune do-not-call numbers: %w", err)
+		}
+		result.Pruned = pruned
+	}
+
+	s.logger.Info("national do-not-call list imported",
+		zap.Int("imported", result.Imported),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("pruned", result.Pruned),
+	)
+	return result, nil
+}
+
+// HandleInboundSMS applies opt-out and opt-in keywords texted to us. A
+// customer who texts STOP is put on the do-not-call list; texting START
+// takes them off again unless they were put on it another way. Other
+// messages are ignored.
+func (s *ComplianceService) HandleInboundSMS(ctx context.Context, from, body string) error {
+	phone := normalizePhoneNumber(from)
+	if phone == "" {
+		return nil
+	}
+
+	switch {
+	case domain.IsSMSOptOut(body):
+		entry := &domain.DNCEntry{
+			PhoneNumber: phone,
+			Source:      domain.DNCSourceSMSOptOut,
+			Reason:      "texted " + strings.ToUpper(strings.TrimSpace(body)),
+			CreatedAt:   time.Now().UTC(),
+		}
+		if err := s.repo.Add(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record SMS opt-out: %w", err)
+		}
+		s.logger.Info("customer opted out by SMS", zap.String("phone_number", phone))
+	case domain.IsSMSOptIn(body):
+		err := s.repo.Remove(ctx, phone, domain.DNCSourceSMSOptOut)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return fmt.Errorf("failed to record SMS opt-in: %w", err)
+		}
+		if err == nil {
+			s.logger.Info("customer opted back in by SMS", zap.String("phone_number", phone))
+		}
+	}
+	return nil
+}
+
+// AsComplianceError returns the compliance error in err's chain, if any.
+func AsComplianceError(err error) (*domain.ComplianceError, bool) {
+	var cerr *domain.ComplianceError
+	if errors.As(err, &cerr) {
+		return cerr, true
+	}
+	return nil, false
+}
+
+func complianceBlocked(cerr *domain.ComplianceError) error {
+	return apperrors.Wrap(cerr, "", apperrors.CodeComplianceBlocked, "call blocked")
+}
+
+// dncKey returns the form numbers are stored in on the do-not-call list.
+func dncKey(phoneNumber string) string {
+	if phone := normalizePhoneNumber(phoneNumber); phone != "" {
+		return phone
+	}
+	return strings.TrimSpace(phoneNumber)
+}
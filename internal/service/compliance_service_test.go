@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockDNCRepository is an in-memory DNCRepository.
+type MockDNCRepository struct {
+	mu         sync.Mutex
+	entries    map[string]*domain.DNCEntry
+	importedAt map[string]time.Time
+}
+
+func NewMockDNCRepository() *MockDNCRepository {
+	return &MockDNCRepository{
+		entries:    make(map[string]*domain.DNCEntry),
+		importedAt: make(map[string]time.Time),
+	}
+}
+
+func (m *MockDNCRepository) Add(ctx context.Context, entry *domain.DNCEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[entry.PhoneNumber]; !ok {
+		cp := *entry
+		m.entries[entry.PhoneNumber] = &cp
+	}
+	return nil
+}
+
+func (m *MockDNCRepository) Get(ctx context.Context, phoneNumber string) (*domain.DNCEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[phoneNumber]
+	if !ok {
+		return nil, apperrors.NotFound("do-not-call entry")
+	}
+	cp := *entry
+	return &cp, nil
+}
+
+func (m *MockDNCRepository) Remove(ctx context.Context, phoneNumber string, source domain.DNCSource) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[phoneNumber]
+	if !ok || (source != "" && entry.Source != source) {
+		return apperrors.NotFound("do-not-call entry")
+	}
+	delete(m.entries, phoneNumber)
+	return nil
+}
+
+func (m *MockDNCRepository) List(ctx context.Context, filter *domain.DNCListFilter, limit, offset int) ([]*domain.DNCEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []*domain.DNCEntry
+	for _, e := range m.entries {
+		if filter != nil && (!strings.Contains(e.PhoneNumber, filter.Search) || (filter.Source != "" && e.Source != filter.Source)) {
+			continue
+		}
+		cp := *e
+		entries = append(entries, &cp)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].PhoneNumber < entries[j].PhoneNumber })
+	if offset >= len(entries) {
+		return nil, nil
+	}
+	entries = entries[offset:]
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (m *MockDNCRepository) Count(ctx context.Context, filter *domain.DNCListFilter) (int, error) {
+	entries, err := m.List(ctx, filter, len(m.entries), 0)
+	return len(entries), err
+}
+
+func (m *MockDNCRepository) ImportNational(ctx context.Context, phoneNumbers []string, importedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, phone := range phoneNumbers {
+		if entry, ok := m.entries[phone]; ok && entry.Source != domain.DNCSourceNational {
+			continue
+		}
+		if _, ok := m.entries[phone]; !ok {
+			m.entries[phone] = &domain.DNCEntry{PhoneNumber: phone, Source: domain.DNCSourceNational, CreatedAt: importedAt}
+		}
+		m.importedAt[phone] = importedAt
+	}
+	return nil
+}
+
+func (m *MockDNCRepository) PruneNational(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pruned := 0
+	for phone, entry := range m.entries {
+		if entry.Source == domain.DNCSourceNational && m.importedAt[phone].Before(before) {
+			delete(m.entries, phone)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// newComplianceTestService returns a service with an 8 AM to 9 PM calling
+// window whose clock reads noon in New York.
+func newComplianceTestService(t *testing.T) (*ComplianceService, *MockDNCRepository) {
+	t.Helper()
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	window, err := domain.ParseCallingWindow("08:00", "21:00", ny)
+	if err != nil {
+		t.Fatalf("ParseCallingWindow() error = %v", err)
+	}
+	repo := NewMockDNCRepository()
+	svc := NewComplianceService(repo, zap.NewNop(), &ComplianceServiceConfig{CallingWindow: window}, clock.NewMock(time.Date(2026, 3, 4, 12, 0, 0, 0, ny)))
+	return svc, repo
+}
+
+func TestComplianceService_CheckCall(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newComplianceTestService(t)
+
+	if err := svc.CheckCall(ctx, "+12125550100"); err != nil {
+		t.Fatalf("CheckCall() at noon = %v, want nil", err)
+	}
+
+	if _, err := svc.AddNumber(ctx, &AddDNCRequest{PhoneNumber: "(212) 555-0100", Reason: "asked on the phone"}); err != nil {
+		t.Fatalf("AddNumber() error = %v", err)
+	}
+	err := svc.CheckCall(ctx, "+12125550100")
+	if apperrors.GetCode(err) != apperrors.CodeComplianceBlocked {
+		t.Fatalf("CheckCall() code = %s, want %s", apperrors.GetCode(err), apperrors.CodeComplianceBlocked)
+	}
+	if cerr, ok := AsComplianceError(err); !ok || cerr.Reason != domain.ComplianceReasonDoNotCall {
+		t.Errorf("CheckCall() = %v, want a do-not-call error", err)
+	}
+
+	// 9 AM Eastern is 6 AM Pacific
+	svc.clock = clock.NewMock(time.Date(2026, 3, 4, 14, 0, 0, 0, time.UTC))
+	err = svc.CheckCall(ctx, "+14155550100")
+	cerr, ok := AsComplianceError(err)
+	if !ok || cerr.Reason != domain.ComplianceReasonCallingWindow {
+		t.Fatalf("CheckCall() = %v, want a calling window error", err)
+	}
+	if want := time.Date(2026, 3, 4, 16, 0, 0, 0, time.UTC); cerr.NextAllowed == nil || !cerr.NextAllowed.Equal(want) {
+		t.Errorf("NextAllowed = %v, want %v", cerr.NextAllowed, want)
+	}
+
+	if _, err := svc.AddNumber(ctx, &AddDNCRequest{PhoneNumber: "not a number"}); !apperrors.IsUserError(err) {
+		t.Errorf("AddNumber() with a bad number error = %v, want a validation error", err)
+	}
+}
+
+func TestComplianceService_HandleInboundSMS(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newComplianceTestService(t)
+
+	if err := svc.HandleInboundSMS(ctx, "+12125550100", "Stop"); err != nil {
+		t.Fatalf("HandleInboundSMS() error = %v", err)
+	}
+	entry, err := repo.Get(ctx, "+12125550100")
+	if err != nil || entry.Source != domain.DNCSourceSMSOptOut {
+		t.Fatalf("entry = %+v, %v; want an SMS opt-out", entry, err)
+	}
+
+	if err := svc.HandleInboundSMS(ctx, "+12125550100", "START"); err != nil {
+		t.Fatalf("HandleInboundSMS() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "+12125550100"); !apperrors.IsNotFound(err) {
+		t.Errorf("Get() after START error = %v, want not found", err)
+	}
+
+	// START does not undo a number staff put on the list
+	if _, err := svc.AddNumber(ctx, &AddDNCRequest{PhoneNumber: "+12125550101"}); err != nil {
+		t.Fatalf("AddNumber() error = %v", err)
+	}
+	if err := svc.HandleInboundSMS(ctx, "+12125550101", "start"); err != nil {
+		t.Fatalf("HandleInboundSMS() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "+12125550101"); err != nil {
+		t.Errorf("Get() after START error = %v, want the manual entry kept", err)
+	}
+
+	if err := svc.HandleInboundSMS(ctx, "+12125550102", "When can you come out?"); err != nil {
+		t.Fatalf("HandleInboundSMS() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "+12125550102"); !apperrors.IsNotFound(err) {
+		t.Errorf("Get() after an ordinary text error = %v, want not found", err)
+	}
+}
+
+func TestComplianceService_ImportNational(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newComplianceTestService(t)
+
+	if _, err := svc.AddNumber(ctx, &AddDNCRequest{PhoneNumber: "+12125550100"}); err != nil {
+		t.Fatalf("AddNumber() error = %v", err)
+	}
+
+	result, err := svc.ImportNational(ctx, strings.NewReader("Area Code,Phone Number\n212,5550100\n212,5550101\n\n2125550102\n"), false)
+	if err != nil {
+		t.Fatalf("ImportNational() error = %v", err)
+	}
+	if result.Imported != 3 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want 3 imported and the header skipped", result)
+	}
+	if entry, _ := repo.Get(ctx, "+12125550100"); entry.Source != domain.DNCSourceManual {
+		t.Errorf("source = %s, want the manual entry kept", entry.Source)
+	}
+
+	result, err = svc.ImportNational(ctx, strings.NewReader("212,5550101\n"), true)
+	if err != nil {
+		t.Fatalf("ImportNational() error = %v", err)
+	}
+	if result.Pruned != 1 {
+		t.Errorf("pruned = %d, want 1", result.Pruned)
+	}
+	if _, err := repo.Get(ctx, "+12125550102"); !apperrors.IsNotFound(err) {
+		t.Errorf("Get() error = %v, want the unlisted national entry pruned", err)
+	}
+	for _, phone := range []string{"+12125550100", "+12125550101"} {
+		if _, err := repo.Get(ctx, phone); err != nil {
+			t.Errorf("Get(%s) error = %v, want it kept", phone, err)
+		}
+	}
+}
+
+func TestFollowUpService_ComplianceBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("do-not-call list cancels the sequence", func(t *testing.T) {
+		ft := newFollowUpTest(t)
+		compliance, _ := newComplianceTestService(t)
+		if err := compliance.HandleInboundSMS(ctx, "+15550002222", "STOP"); err != nil {
+			t.Fatalf("HandleInboundSMS() error = %v", err)
+		}
+		ft.svc.SetDoNotCall(compliance)
+
+		ft.runAt(ft.sentAt.Add(time.Hour))
+		if len(ft.sender.texts) != 0 {
+			t.Errorf("texts = %v, want none", ft.sender.texts)
+		}
+		for i, status := range ft.statuses(t) {
+			if status != domain.FollowUpStepStatusCancelled {
+				t.Errorf("step %d status = %s, want cancelled", i+1, status)
+			}
+		}
+	})
+
+	t.Run("calling window defers the call", func(t *testing.T) {
+		ft := newFollowUpTest(t)
+		next := ft.sentAt.Add(30 * time.Hour)
+		ft.sender.callErr = complianceBlocked(&domain.ComplianceError{
+			Reason:      domain.ComplianceReasonCallingWindow,
+			PhoneNumber: "+15550002222",
+			NextAllowed: &next,
+		})
+
+		ft.runAt(ft.sentAt.Add(24 * time.Hour))
+		steps, _ := ft.repo.ListByCallID(ctx, ft.call.ID)
+		call := steps[1]
+		if call.Status != domain.FollowUpStepStatusPending || call.Attempts != 0 || !call.NextAttemptAt.Equal(next) {
+			t.Errorf("call step = %+v, want pending until %v without an attempt", call, next)
+		}
+	})
+}
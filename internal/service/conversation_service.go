@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// Limits on the conversations listed.
+const (
+	conversationHistoryLimit = 100
+	conversationInboxLimit   = 50
+)
+
+// ConversationSMSSender texts customers. BlandService implements it.
+type ConversationSMSSender interface {
+	SendSMS(ctx context.Context, req *bland.SendSMSRequest) (*bland.SendSMSResponse, error)
+}
+
+// WhatsAppSender sends WhatsApp messages. whatsapp.Client implements it.
+type WhatsAppSender interface {
+	SendText(ctx context.Context, to, body string) (string, error)
+	SendTemplate(ctx context.Context, to, name, language string, params []string) (string, error)
+}
+
+// QuoteLinkCreator creates customer links to sent quotes.
+// QuotePortalService implements it.
+type QuoteLinkCreator interface {
+	CreateLink(ctx context.Context, callID uuid.UUID, actor QuoteActor) (*QuotePortalLink, error)
+}
+
+// ConversationServiceConfig holds configuration for the conversation service.
+type ConversationServiceConfig struct {
+	BusinessName string // Signs quote-ready messages
+	SMSFrom      string // Number texts are sent from; empty uses the provider's default
+	Currency     string // For quotes generated before currencies were recorded
+
+	// WhatsAppTemplate is the approved template quote-ready messages are
+	// sent with, filled with the customer's name, the quote number and its
+	// total, then its link when quote links are enabled. Without one they
+	// are sent as text, which WhatsApp only delivers within 24 hours of the
+	// customer's last message.
+	WhatsAppTemplate string
+	WhatsAppLanguage string
+}
+
+// ConversationService messages customers over SMS or WhatsApp, as each
+// customer prefers, and keeps the messages sent and received as the
+// conversation inbox. WhatsApp is only used for customers who opted in.
+type ConversationService struct {
+	messages  domain.ConversationMessageRepository
+	optIns    domain.WhatsAppOptInRepository
+	customers domain.CustomerRepository
+	calls     domain.CallRepository
+	quotes    domain.QuoteRepository
+	sms       ConversationSMSSender
+	whatsapp  WhatsAppSender
+	links     QuoteLinkCreator
+	doNotCall FollowUpDoNotCall
+	config    ConversationServiceConfig
+	logger    *zap.Logger
+}
+
+// NewConversationService creates a new ConversationService.
+func NewConversationService(
+	messages domain.ConversationMessageRepository,
+	optIns domain.WhatsAppOptInRepository,
+	customers domain.CustomerRepository,
+	calls domain.CallRepository,
+	quotes domain.QuoteRepository,
+	logger *zap.Logger,
+	cfg *ConversationServiceConfig,
+) *ConversationService {
+	if cfg == nil {
+		cfg = &ConversationServiceConfig{}
+	}
+	config := *cfg
+	if config.BusinessName == "" {
+		config.BusinessName = "QuickQuote"
+	}
+	if config.WhatsAppLanguage == "" {
+		config.WhatsAppLanguage = "en_US"
+	}
+	return &ConversationService{
+		messages:  messages,
+		optIns:    optIns,
+		customers: customers,
+		calls:     calls,
+		quotes:    quotes,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// SetSMS enables texting customers.
+func (s *ConversationService) SetSMS(sender ConversationSMSSender) {
+	s.sms = sender
+}
+
+// SetWhatsApp enables messaging customers who prefer WhatsApp.
+func (s *ConversationService) SetWhatsApp(sender WhatsAppSender) {
+	s.whatsapp = sender
+}
+
+// SetQuoteLinks adds the customer's quote link to quote-ready messages.
+func (s *ConversationService) SetQuoteLinks(links QuoteLinkCreator) {
+	s.links = links
+}
+
+// SetDoNotCall skips quote-ready messages to numbers on the do-not-call
+// list, including customers who texted STOP.
+func (s *ConversationService) SetDoNotCall(doNotCall FollowUpDoNotCall) {
+	s.doNotCall = doNotCall
+}
+
+// WhatsAppEnabled reports whether customers can be messaged on WhatsApp.
+func (s *ConversationService) WhatsAppEnabled() bool {
+	return s.whatsapp != nil
+}
+
+// SendQuoteReady tells the customer on a sent quote that it is ready, on
+// their preferred channel. Customers who opted out or are on the
+// do-not-call list are skipped.
+func (s *ConversationService) SendQuoteReady(ctx context.Context, callID uuid.UUID) error {
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return err
+	}
+	logger := s.logger.With(zap.String("call_id", callID.String()))
+
+	phone := normalizePhoneNumber(call.CustomerNumber())
+	if phone == "" {
+		logger.Warn("quote has no customer number to message")
+		return nil
+	}
+
+	var customer *domain.Customer
+	if call.CustomerID != nil {
+		customer, err = s.customers.GetByID(ctx, *call.CustomerID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return err
+		}
+		if customer != nil && customer.IsOptedOut() {
+			logger.Info("skipping quote-ready message to opted-out customer")
+			return nil
+		}
+	}
+	if s.doNotCall != nil {
+		if err := s.doNotCall.CheckDoNotCall(ctx, phone); err != nil {
+			if _, ok := AsComplianceError(err); ok {
+				logger.Info("skipping quote-ready message", zap.Error(err))
+				return nil
+			}
+			return err
+		}
+	}
+
+	channel, err := s.channelFor(ctx, customer, phone)
+	if err != nil {
+		return err
+	}
+
+	name := "there"
+	if call.CallerName != nil && strings.TrimSpace(*call.CallerName) != "" {
+		name = strings.TrimSpace(*call.CallerName)
+	}
+	quoteNumber := quotepdf.QuoteNumber(callID)
+	total, err := s.quoteTotal(ctx, call)
+	if err != nil {
+		return err
+	}
+	link := s.quoteLink(ctx, logger, callID)
+
+	body := fmt.Sprintf("Hi %s, your quote %s from %s is ready: %s.", name, quoteNumber, s.config.BusinessName, total)
+	if link != "" {
+		body += " View it here: " + link
+	}
+	body += " Reply to this message with any questions."
+
+	message := domain.NewConversationMessage(phone, channel, domain.MessageDirectionOutbound, body)
+	message.CallID = &callID
+	switch {
+	case channel == domain.MessageChannelWhatsApp && s.config.WhatsAppTemplate != "":
+		params := []string{name, quoteNumber, total}
+		if link != "" {
+			params = append(params, link)
+		}
+		message.ProviderMessageID, err = s.whatsapp.SendTemplate(ctx, phone, s.config.WhatsAppTemplate, s.config.WhatsAppLanguage, params)
+	case channel == domain.MessageChannelWhatsApp:
+		message.ProviderMessageID, err = s.whatsapp.SendText(ctx, phone, body)
+	default:
+		var resp *bland.SendSMSResponse
+		resp, err = s.sms.SendSMS(ctx, &bland.SendSMSRequest{To: phone, From: s.config.SMSFrom, Body: body})
+		if resp != nil {
+			message.ProviderMessageID = resp.MessageID
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send quote-ready %s message: %w", channel, err)
+	}
+
+	if err := s.messages.Create(ctx, message); err != nil {
+		logger.Warn("failed to record quote-ready message", zap.Error(err))
+	}
+	logger.Info("quote-ready message sent", zap.String("channel", string(channel)))
+	return nil
+}
+
+// channelFor returns the channel to message a customer on: WhatsApp if they
+// prefer it and are opted in, otherwise SMS.
+func (s *ConversationService) channelFor(ctx context.Context, customer *domain.Customer, phone string) (domain.MessageChannel, error) {
+	if s.whatsapp != nil && customer != nil && customer.PreferredChannel == domain.MessageChannelWhatsApp {
+		optIn, err := s.GetWhatsAppOptIn(ctx, phone)
+		if err != nil {
+			return "", err
+		}
+		if optIn.IsActive() {
+			return domain.MessageChannelWhatsApp, nil
+		}
+	}
+	if s.sms == nil {
+		return "", errors.New("no channel configured to message the customer on")
+	}
+	return domain.MessageChannelSMS, nil
+}
+
+// quoteTotal returns the formatted total of a call's quote.
+func (s *ConversationService) quoteTotal(ctx context.Context, call *domain.Call) (string, error) {
+	currency := call.Currency()
+	var total float64
+	if call.QuoteSummary != nil {
+		total = QuoteTotal(*call.QuoteSummary)
+	}
+	if s.quotes != nil {
+		quote, err := s.quotes.GetByCallID(ctx, call.ID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return "", err
+		}
+		if quote != nil {
+			total = quote.TotalAmount
+			if quote.Currency != "" {
+				currency = quote.Currency
+			}
+		}
+	}
+	if currency == "" {
+		currency = s.config.Currency
+	}
+	return quotepdf.FormatMoney(total, currency), nil
+}
+
+// quoteLink creates the customer's link to the quote, or returns empty if
+// links are not enabled or the link can't be created.
+func (s *ConversationService) quoteLink(ctx context.Context, logger *zap.Logger, callID uuid.UUID) string {
+	if s.links == nil {
+		return ""
+	}
+	link, err := s.links.CreateLink(ctx, callID, QuoteActor{})
+	if err != nil {
+		logger.Warn("failed to create quote link for quote-ready message", zap.Error(err))
+		return ""
+	}
+	return link.URL
+}
+
+// ReceiveMessage records a message from a customer in the inbox. On
+// WhatsApp, opt-out and opt-in keywords such as STOP and START withdraw and
+// record the customer's WhatsApp opt-in.
+func (s *ConversationService) ReceiveMessage(ctx context.Context, channel domain.MessageChannel, from, body, providerMessageID string) error {
+	phone := normalizePhoneNumber(from)
+	if phone == "" {
+		return nil
+	}
+
+	message := domain.NewConversationMessage(phone, channel, domain.MessageDirectionInbound, body)
+	message.ProviderMessageID = providerMessageID
+	if err := s.messages.Create(ctx, message); err != nil {
+		return fmt.Errorf("failed to record message: %w", err)
+	}
+
+	if channel == domain.MessageChannelWhatsApp {
+		switch {
+		case domain.IsSMSOptOut(body):
+			return s.SetWhatsAppOptIn(ctx, phone, false, domain.WhatsAppOptInSourceWhatsApp)
+		case domain.IsSMSOptIn(body):
+			return s.SetWhatsAppOptIn(ctx, phone, true, domain.WhatsAppOptInSourceWhatsApp)
+		}
+	}
+	return nil
+}
+
+// GetWhatsAppOptIn returns the WhatsApp opt-in for a number, or nil if it
+// has none.
+func (s *ConversationService) GetWhatsAppOptIn(ctx context.Context, phoneNumber string) (*domain.WhatsAppOptIn, error) {
+	optIn, err := s.optIns.Get(ctx, phoneNumber)
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return optIn, err
+}
+
+// SetWhatsAppOptIn records or withdraws a number's WhatsApp opt-in.
+func (s *ConversationService) SetWhatsAppOptIn(ctx context.Context, phoneNumber string, optedIn bool, source string) error {
+	optIn, err := s.GetWhatsAppOptIn(ctx, phoneNumber)
+	if err != nil {
+		return err
+	}
+	if optedIn == optIn.IsActive() {
+		return nil
+	}
+
+	if optedIn {
+		optIn = domain.NewWhatsAppOptIn(phoneNumber, source)
+	} else {
+		optIn.OptOut()
+	}
+	if err := s.optIns.Save(ctx, optIn); err != nil {
+		return fmt.Errorf("failed to save WhatsApp opt-in: %w", err)
+	}
+	s.logger.Info("WhatsApp opt-in changed",
+		zap.String("phone_number", phoneNumber),
+		zap.Bool("opted_in", optedIn),
+		zap.String("source", source),
+	)
+	return nil
+}
+
+// ListConversation returns the most recent messages with a number, oldest
+// first.
+func (s *ConversationService) ListConversation(ctx context.Context, phoneNumber string) ([]*domain.ConversationMessage, error) {
+	messages, err := s.messages.ListByPhoneNumber(ctx, phoneNumber, conversationHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	if messages == nil {
+		messages = []*domain.ConversationMessage{}
+	}
+	return messages, nil
+}
+
+// ListInbox returns the latest message of each recent conversation, most
+// recent first.
+func (s *ConversationService) ListInbox(ctx context.Context) ([]*domain.ConversationMessage, error) {
+	messages, err := s.messages.ListLatest(ctx, conversationInboxLimit)
+	if err != nil {
+		return nil, err
+	}
+	if messages == nil {
+		messages = []*domain.ConversationMessage{}
+	}
+	return messages, nil
+}
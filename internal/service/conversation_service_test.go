@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockConversationMessageRepository is an in-memory ConversationMessageRepository.
+type MockConversationMessageRepository struct {
+	mu       sync.Mutex
+	messages []*domain.ConversationMessage
+}
+
+func (m *MockConversationMessageRepository) Create(ctx context.Context, message *domain.ConversationMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.messages {
+		if message.ProviderMessageID != "" && existing.Channel == message.Channel && existing.ProviderMessageID == message.ProviderMessageID {
+			return nil
+		}
+	}
+	cp := *message
+	m.messages = append(m.messages, &cp)
+	return nil
+}
+
+func (m *MockConversationMessageRepository) ListByPhoneNumber(ctx context.Context, phoneNumber string, limit int) ([]*domain.ConversationMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var messages []*domain.ConversationMessage
+	for i := len(m.messages) - 1; i >= 0 && len(messages) < limit; i-- {
+		if m.messages[i].PhoneNumber == phoneNumber {
+			messages = append(messages, m.messages[i])
+		}
+	}
+	return messages, nil
+}
+
+func (m *MockConversationMessageRepository) ListLatest(ctx context.Context, limit int) ([]*domain.ConversationMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seen := make(map[string]bool)
+	var messages []*domain.ConversationMessage
+	for i := len(m.messages) - 1; i >= 0 && len(messages) < limit; i-- {
+		if !seen[m.messages[i].PhoneNumber] {
+			seen[m.messages[i].PhoneNumber] = true
+			messages = append(messages, m.messages[i])
+		}
+	}
+	return messages, nil
+}
+
+// MockWhatsAppOptInRepository is an in-memory WhatsAppOptInRepository.
+type MockWhatsAppOptInRepository struct {
+	optIns map[string]*domain.WhatsAppOptIn
+}
+
+func (m *MockWhatsAppOptInRepository) Get(ctx context.Context, phoneNumber string) (*domain.WhatsAppOptIn, error) {
+	optIn, ok := m.optIns[phoneNumber]
+	if !ok {
+		return nil, apperrors.NotFound("whatsapp opt-in")
+	}
+	cp := *optIn
+	return &cp, nil
+}
+
+func (m *MockWhatsAppOptInRepository) Save(ctx context.Context, optIn *domain.WhatsAppOptIn) error {
+	cp := *optIn
+	m.optIns[optIn.PhoneNumber] = &cp
+	return nil
+}
+
+// recordingWhatsApp records the WhatsApp messages sent.
+type recordingWhatsApp struct {
+	texts     []string // bodies
+	templates [][]string
+}
+
+func (r *recordingWhatsApp) SendText(ctx context.Context, to, body string) (string, error) {
+	r.texts = append(r.texts, body)
+	return "wamid.out", nil
+}
+
+func (r *recordingWhatsApp) SendTemplate(ctx context.Context, to, name, language string, params []string) (string, error) {
+	r.templates = append(r.templates, params)
+	return "wamid.out", nil
+}
+
+type conversationTest struct {
+	svc       *ConversationService
+	messages  *MockConversationMessageRepository
+	customers *MockCustomerRepository
+	sms       *recordingSMS
+	whatsapp  *recordingWhatsApp
+	customer  *domain.Customer
+	call      *domain.Call
+}
+
+// newConversationTest sets up a sent $500 quote for Jane at +15550002222,
+// with both SMS and WhatsApp configured.
+func newConversationTest(t *testing.T, cfg *ConversationServiceConfig) *conversationTest {
+	t.Helper()
+	ctx := context.Background()
+	ct := &conversationTest{
+		messages:  &MockConversationMessageRepository{},
+		customers: NewMockCustomerRepository(),
+		sms:       &recordingSMS{},
+		whatsapp:  &recordingWhatsApp{},
+	}
+
+	ct.customer = domain.NewCustomer("+15550002222")
+	if err := ct.customers.Create(ctx, ct.customer); err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	calls := NewMockCallRepository()
+	summary := "- Build: $500"
+	name := "Jane"
+	ct.call = domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	ct.call.QuoteSummary = &summary
+	ct.call.CallerName = &name
+	ct.call.CustomerID = &ct.customer.ID
+	if err := calls.Create(ctx, ct.call); err != nil {
+		t.Fatalf("create call: %v", err)
+	}
+
+	optIns := &MockWhatsAppOptInRepository{optIns: make(map[string]*domain.WhatsAppOptIn)}
+	ct.svc = NewConversationService(ct.messages, optIns, ct.customers, calls, nil, zap.NewNop(), cfg)
+	ct.svc.SetSMS(ct.sms)
+	ct.svc.SetWhatsApp(ct.whatsapp)
+	return ct
+}
+
+func (ct *conversationTest) preferWhatsApp(t *testing.T) {
+	t.Helper()
+	ct.customer.PreferredChannel = domain.MessageChannelWhatsApp
+	if err := ct.customers.Update(context.Background(), ct.customer); err != nil {
+		t.Fatalf("update customer: %v", err)
+	}
+}
+
+func TestConversationService_SendQuoteReadyBySMS(t *testing.T) {
+	ct := newConversationTest(t, nil)
+	ctx := context.Background()
+
+	if err := ct.svc.SendQuoteReady(ctx, ct.call.ID); err != nil {
+		t.Fatalf("SendQuoteReady() error = %v", err)
+	}
+	if len(ct.sms.sent) != 1 || len(ct.whatsapp.texts) != 0 {
+		t.Fatalf("expected one text, got %d texts and %d WhatsApp messages", len(ct.sms.sent), len(ct.whatsapp.texts))
+	}
+	body := ct.sms.sent[0].Body
+	if ct.sms.sent[0].To != "+15550002222" || !strings.Contains(body, "Hi Jane") || !strings.Contains(body, "$500.00") {
+		t.Errorf("unexpected text to %s: %q", ct.sms.sent[0].To, body)
+	}
+
+	messages, err := ct.svc.ListConversation(ctx, "+15550002222")
+	if err != nil {
+		t.Fatalf("ListConversation() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].Channel != domain.MessageChannelSMS || messages[0].IsInbound() || messages[0].CallID == nil {
+		t.Errorf("expected the outbound text recorded, got %+v", messages)
+	}
+}
+
+func TestConversationService_SendQuoteReadyByWhatsApp(t *testing.T) {
+	ct := newConversationTest(t, nil)
+	ctx := context.Background()
+	ct.preferWhatsApp(t)
+
+	// Without an opt-in, WhatsApp customers are texted
+	if err := ct.svc.SendQuoteReady(ctx, ct.call.ID); err != nil {
+		t.Fatalf("SendQuoteReady() error = %v", err)
+	}
+	if len(ct.sms.sent) != 1 || len(ct.whatsapp.texts) != 0 {
+		t.Fatalf("expected a text without an opt-in, got %d texts and %d WhatsApp messages", len(ct.sms.sent), len(ct.whatsapp.texts))
+	}
+
+	if err := ct.svc.SetWhatsAppOptIn(ctx, "+15550002222", true, domain.WhatsAppOptInSourceStaff); err != nil {
+		t.Fatalf("SetWhatsAppOptIn() error = %v", err)
+	}
+	if err := ct.svc.SendQuoteReady(ctx, ct.call.ID); err != nil {
+		t.Fatalf("SendQuoteReady() error = %v", err)
+	}
+	if len(ct.sms.sent) != 1 || len(ct.whatsapp.texts) != 1 {
+		t.Fatalf("expected a WhatsApp message once opted in, got %d texts and %d WhatsApp messages", len(ct.sms.sent), len(ct.whatsapp.texts))
+	}
+}
+
+func TestConversationService_SendQuoteReadyTemplate(t *testing.T) {
+	ct := newConversationTest(t, &ConversationServiceConfig{WhatsAppTemplate: "quote_ready"})
+	ctx := context.Background()
+	ct.preferWhatsApp(t)
+	if err := ct.svc.SetWhatsAppOptIn(ctx, "+15550002222", true, domain.WhatsAppOptInSourceStaff); err != nil {
+		t.Fatalf("SetWhatsAppOptIn() error = %v", err)
+	}
+
+	if err := ct.svc.SendQuoteReady(ctx, ct.call.ID); err != nil {
+		t.Fatalf("SendQuoteReady() error = %v", err)
+	}
+	if len(ct.whatsapp.templates) != 1 {
+		t.Fatalf("expected a template message, got %d", len(ct.whatsapp.templates))
+	}
+	params := ct.whatsapp.templates[0]
+	if len(params) != 3 || params[0] != "Jane" || params[2] != "$500.00" {
+		t.Errorf("unexpected template parameters %v", params)
+	}
+}
+
+func TestConversationService_SendQuoteReadySkipsOptedOut(t *testing.T) {
+	ct := newConversationTest(t, nil)
+	ct.customer.SetOptedOut(true)
+	if err := ct.customers.Update(context.Background(), ct.customer); err != nil {
+		t.Fatalf("update customer: %v", err)
+	}
+
+	if err := ct.svc.SendQuoteReady(context.Background(), ct.call.ID); err != nil {
+		t.Fatalf("SendQuoteReady() error = %v", err)
+	}
+	if len(ct.sms.sent) != 0 {
+		t.Errorf("expected no text to an opted-out customer, got %d", len(ct.sms.sent))
+	}
+}
+
+func TestConversationService_ReceiveMessage(t *testing.T) {
+	ct := newConversationTest(t, nil)
+	ctx := context.Background()
+
+	if err := ct.svc.ReceiveMessage(ctx, domain.MessageChannelWhatsApp, "+15550002222", "START", "wamid.1"); err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	}
+	optIn, err := ct.svc.GetWhatsAppOptIn(ctx, "+15550002222")
+	if err != nil || !optIn.IsActive() || optIn.Source != domain.WhatsAppOptInSourceWhatsApp {
+		t.Fatalf("expected an opt-in from WhatsApp, got %+v, %v", optIn, err)
+	}
+
+	// Redelivered messages are recorded once
+	if err := ct.svc.ReceiveMessage(ctx, domain.MessageChannelWhatsApp, "+15550002222", "START", "wamid.1"); err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	}
+	if err := ct.svc.ReceiveMessage(ctx, domain.MessageChannelWhatsApp, "+15550002222", "STOP", "wamid.2"); err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	}
+	if optIn, _ := ct.svc.GetWhatsAppOptIn(ctx, "+15550002222"); optIn.IsActive() {
+		t.Error("expected STOP to withdraw the opt-in")
+	}
+
+	// Keywords by text do not change the WhatsApp opt-in
+	if err := ct.svc.ReceiveMessage(ctx, domain.MessageChannelSMS, "+15550002222", "START", "sms-1"); err != nil {
+		t.Fatalf("ReceiveMessage() error = %v", err)
+	}
+	if optIn, _ := ct.svc.GetWhatsAppOptIn(ctx, "+15550002222"); optIn.IsActive() {
+		t.Error("expected a texted START to leave the WhatsApp opt-in alone")
+	}
+
+	inbox, err := ct.svc.ListInbox(ctx)
+	if err != nil {
+		t.Fatalf("ListInbox() error = %v", err)
+	}
+	if len(inbox) != 1 || inbox[0].Body != "START" || inbox[0].Channel != domain.MessageChannelSMS {
+		t.Errorf("expected the latest message in the inbox, got %+v", inbox)
+	}
+	messages, _ := ct.svc.ListConversation(ctx, "+15550002222")
+	if len(messages) != 3 || messages[0].ProviderMessageID != "wamid.1" {
+		t.Errorf("expected 3 messages oldest first, got %d", len(messages))
+	}
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/crm"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// crmOpenDealCloseAfter is the expected close date of a deal whose quote
+// the customer has not answered yet, counted from the sync.
+const crmOpenDealCloseAfter = 30 * 24 * time.Hour
+
+// CRMMappingStore loads and saves the CRM field mapping.
+// SettingsService implements it.
+type CRMMappingStore interface {
+	GetCRMFieldMapping(ctx context.Context) (*domain.CRMFieldMapping, error)
+	SaveCRMFieldMapping(ctx context.Context, mapping *domain.CRMFieldMapping) error
+}
+
+// CRMService keeps a contact for each caller and a deal for each quote in
+// HubSpot or Salesforce. Completed calls and quote changes queue a sync,
+// and a background worker upserts the records, retrying failures with
+// backoff before moving them to the sync error queue.
+type CRMService struct {
+	syncs    domain.CRMSyncRepository
+	calls    domain.CallRepository
+	quotes   *QuoteService
+	mappings CRMMappingStore
+	client   crm.Client
+	logger   *zap.Logger
+	elector  Elector
+
+	// Configuration
+	pollInterval time.Duration
+	batchSize    int
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+
+	clock clock.Clock
+}
+
+// CRMConfig holds configuration for the CRM sync worker.
+type CRMConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DefaultCRMConfig returns sensible defaults.
+func DefaultCRMConfig() *CRMConfig {
+	return &CRMConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+	}
+}
+
+// NewCRMService creates a new CRMService.
+func NewCRMService(
+	syncs domain.CRMSyncRepository,
+	calls domain.CallRepository,
+	quotes *QuoteService,
+	mappings CRMMappingStore,
+	client crm.Client,
+	logger *zap.Logger,
+	config *CRMConfig,
+	clk clock.Clock,
+) *CRMService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = DefaultCRMConfig()
+	}
+	return &CRMService{
+		syncs:        syncs,
+		calls:        calls,
+		quotes:       quotes,
+		mappings:     mappings,
+		client:       client,
+		logger:       logger,
+		pollInterval: config.PollInterval,
+		batchSize:    config.BatchSize,
+		stopCh:       make(chan struct{}),
+		clock:        clk,
+	}
+}
+
+// Provider returns the CRM's name.
+func (s *CRMService) Provider() string {
+	return s.client.Name()
+}
+
+// EventPublished queues a sync when a call completes or its quote changes.
+// Failures are logged; the caller's work never fails over the CRM.
+func (s *CRMService) EventPublished(ctx context.Context, eventType domain.WebhookEventType, callID uuid.UUID) {
+	switch eventType {
+	case domain.WebhookEventCallCompleted, domain.WebhookEventQuoteCreated,
+		domain.WebhookEventQuoteApproved, domain.WebhookEventQuoteStatusChanged:
+	default:
+		return
+	}
+	if err := s.syncs.Enqueue(ctx, s.Provider(), callID, s.clock.Now().UTC()); err != nil {
+		s.logger.Error("failed to queue crm sync",
+			zap.String("call_id", callID.String()),
+			zap.String("event", string(eventType)),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListSyncs lists syncs with a status, or all syncs when status is empty,
+// most recently updated first.
+func (s *CRMService) ListSyncs(ctx context.Context, status domain.CRMSyncStatus, limit int) ([]*domain.CRMSync, error) {
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	syncs, err := s.syncs.List(ctx, s.Provider(), status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list crm syncs: %w", err)
+	}
+	return syncs, nil
+}
+
+// RetrySync queues a failed sync for another round of attempts.
+func (s *CRMService) RetrySync(ctx context.Context, callID uuid.UUID) (*domain.CRMSync, error) {
+	crmSync, err := s.syncs.Get(ctx, s.Provider(), callID)
+	if err != nil {
+		return nil, err
+	}
+	if crmSync.Status == domain.CRMSyncPending {
+		return nil, apperrors.New(apperrors.CodeConflict, "sync is already waiting to run")
+	}
+
+	crmSync.Retry()
+	if err := s.syncs.Update(ctx, crmSync); err != nil {
+		return nil, fmt.Errorf("failed to update crm sync: %w", err)
+	}
+	s.logger.Info("crm sync queued for retry", zap.String("call_id", callID.String()))
+	return crmSync, nil
+}
+
+// Mapping returns the field mapping.
+func (s *CRMService) Mapping(ctx context.Context) (*domain.CRMFieldMapping, error) {
+	mapping, err := s.mappings.GetCRMFieldMapping(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crm field mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// SaveMapping validates and stores the field mapping.
+func (s *CRMService) SaveMapping(ctx context.Context, mapping *domain.CRMFieldMapping) error {
+	if err := mapping.Validate(); err != nil {
+		return apperrors.ValidationFailed(err.Error())
+	}
+	if err := s.mappings.SaveCRMFieldMapping(ctx, mapping); err != nil {
+		return fmt.Errorf("failed to save crm field mapping: %w", err)
+	}
+	return nil
+}
+
+// DealStage returns the deal stage for a quote status: the mapped stage,
+// or the CRM's standard pipeline stage.
+func (s *CRMService) DealStage(mapping *domain.CRMFieldMapping, status domain.QuoteStatus) string {
+	if stage := mapping.Stages[status]; stage != "" {
+		return stage
+	}
+	return crm.DefaultDealStages(s.Provider())[string(status)]
+}
+
+// SetElector limits syncing to the instance elector chooses.
+func (s *CRMService) SetElector(elector Elector) {
+	s.elector = elector
+}
+
+// Start begins syncing queued calls in the background.
+func (s *CRMService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("crm sync worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting crm sync worker",
+		zap.String("provider", s.Provider()),
+		zap.Duration("poll_interval", s.pollInterval),
+	)
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight syncs to finish.
+func (s *CRMService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping crm sync worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("crm sync worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("crm sync worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *CRMService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.syncDue(s.clock.Now())
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.syncDue(s.clock.Now())
+		}
+	}
+}
+
+// syncDue attempts the syncs that are due.
+func (s *CRMService) syncDue(now time.Time) {
+	if !isLeader(s.elector) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	syncs, err := s.syncs.ListDue(ctx, s.Provider(), now, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to list due crm syncs", zap.Error(err))
+		return
+	}
+	if len(syncs) == 0 {
+		return
+	}
+
+	mapping, err := s.Mapping(ctx)
+	if err != nil {
+		s.logger.Error("failed to load crm field mapping", zap.Error(err))
+		return
+	}
+	for _, crmSync := range syncs {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		s.attempt(ctx, crmSync, mapping)
+	}
+}
+
+// attempt makes one sync attempt and records the outcome.
+func (s *CRMService) attempt(ctx context.Context, crmSync *domain.CRMSync, mapping *domain.CRMFieldMapping) {
+	logger := s.logger.With(
+		zap.String("call_id", crmSync.CallID.String()),
+		zap.String("provider", crmSync.Provider),
+	)
+
+	if contactID, dealID, err := s.sync(ctx, crmSync, mapping); err != nil {
+		// Keep the contact created before a failed deal upsert, so the
+		// retry updates it instead of searching again.
+		if contactID != "" {
+			crmSync.ContactID = contactID
+		}
+		crmSync.MarkFailed(err)
+		if crmSync.Status == domain.CRMSyncFailed {
+			logger.Error("giving up on crm sync", zap.Error(err), zap.Int("attempts", crmSync.Attempts))
+		} else {
+			logger.Warn("crm sync failed", zap.Error(err), zap.Time("next_attempt", crmSync.NextAttemptAt))
+		}
+	} else {
+		crmSync.MarkSucceeded(contactID, dealID)
+		logger.Debug("crm sync succeeded", zap.String("contact_id", contactID), zap.String("deal_id", dealID))
+	}
+
+	if err := s.syncs.Update(ctx, crmSync); err != nil {
+		logger.Error("failed to update crm sync", zap.Error(err))
+	}
+}
+
+// sync upserts the caller's contact and, once the call has a quote, the
+// quote's deal. It returns the contact ID even when the deal fails.
+func (s *CRMService) sync(ctx context.Context, crmSync *domain.CRMSync, mapping *domain.CRMFieldMapping) (string, string, error) {
+	call, err := s.calls.GetByID(ctx, crmSync.CallID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get call: %w", err)
+	}
+	detail, err := s.quotes.GetQuote(ctx, crmSync.CallID)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			return "", "", fmt.Errorf("failed to get quote: %w", err)
+		}
+		detail = nil
+	}
+	values := crmSourceValues(call, detail)
+
+	contact := &crm.Contact{
+		Email:      values[domain.CRMFieldEmail],
+		Phone:      values[domain.CRMFieldPhone],
+		Company:    values[domain.CRMFieldCompany],
+		Properties: crmProperties(mapping.Contact, values),
+	}
+	contact.FirstName, contact.LastName = crm.SplitName(values[domain.CRMFieldCallerName])
+	contactID, err := s.client.UpsertContact(ctx, crmSync.ContactID, contact)
+	if err != nil {
+		return "", "", err
+	}
+	if detail == nil {
+		return contactID, "", nil
+	}
+
+	deal := &crm.Deal{
+		Name:       "Quote " + detail.QuoteNumber,
+		Amount:     detail.Total,
+		Stage:      s.DealStage(mapping, detail.Status),
+		CloseDate:  s.clock.Now().UTC().Add(crmOpenDealCloseAfter),
+		Properties: crmProperties(mapping.Deal, values),
+	}
+	if name := values[domain.CRMFieldCallerName]; name != "" {
+		deal.Name += " - " + name
+	}
+	if detail.IsFinal() && detail.RespondedAt != nil {
+		deal.CloseDate = *detail.RespondedAt
+	}
+	dealID, err := s.client.UpsertDeal(ctx, crmSync.DealID, contactID, deal)
+	if err != nil {
+		return contactID, "", err
+	}
+	return contactID, dealID, nil
+}
+
+// crmSourceValues collects the values a field mapping can copy from.
+// detail is nil before the call has a quote.
+func crmSourceValues(call *domain.Call, detail *QuoteDetail) map[string]string {
+	values := map[string]string{
+		domain.CRMFieldCallID: call.ID.String(),
+		domain.CRMFieldPhone:  call.CustomerNumber(),
+	}
+	if call.CallerName != nil {
+		values[domain.CRMFieldCallerName] = strings.TrimSpace(*call.CallerName)
+	}
+	if call.ProviderSummary != nil {
+		values[domain.CRMFieldCallSummary] = strings.TrimSpace(*call.ProviderSummary)
+	}
+	if data := call.ExtractedData; data != nil {
+		if values[domain.CRMFieldCallerName] == "" {
+			values[domain.CRMFieldCallerName] = strings.TrimSpace(data.CallerName)
+		}
+		if data.Phone != "" {
+			values[domain.CRMFieldPhone] = data.Phone
+		}
+		values[domain.CRMFieldEmail] = data.Email
+		values[domain.CRMFieldCompany] = data.Company
+		values[domain.CRMFieldProjectType] = data.ProjectType
+		values[domain.CRMFieldRequirements] = data.Requirements
+		values[domain.CRMFieldTimeline] = data.Timeline
+		values[domain.CRMFieldBudgetRange] = data.BudgetRange
+		values[domain.CRMFieldContactPreference] = data.ContactPreference
+		values[domain.CRMFieldAdditionalInfo] = data.AdditionalInfo
+	}
+	if detail != nil {
+		values[domain.CRMFieldQuoteNumber] = detail.QuoteNumber
+		values[domain.CRMFieldQuoteStatus] = string(detail.Status)
+		values[domain.CRMFieldQuoteTotal] = strconv.FormatFloat(detail.Total, 'f', 2, 64)
+		values[domain.CRMFieldQuoteCurrency] = detail.Currency
+	}
+	return values
+}
+
+// crmProperties maps source values onto CRM fields, skipping empty values
+// so they do not clear fields already set in the CRM.
+func crmProperties(fields map[string]string, values map[string]string) map[string]string {
+	props := make(map[string]string, len(fields))
+	for source, field := range fields {
+		if v := values[source]; v != "" {
+			props[field] = v
+		}
+	}
+	return props
+}
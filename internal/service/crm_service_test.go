@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/crm"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// mockCRMSyncRepository is an in-memory CRM sync queue.
+type mockCRMSyncRepository struct {
+	mu    sync.Mutex
+	syncs map[uuid.UUID]*domain.CRMSync
+}
+
+func newMockCRMSyncRepository() *mockCRMSyncRepository {
+	return &mockCRMSyncRepository{syncs: make(map[uuid.UUID]*domain.CRMSync)}
+}
+
+func (m *mockCRMSyncRepository) Enqueue(ctx context.Context, provider string, callID uuid.UUID, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.syncs[callID]
+	if !ok {
+		s = &domain.CRMSync{Provider: provider, CallID: callID, CreatedAt: now}
+		m.syncs[callID] = s
+	}
+	s.Status = domain.CRMSyncPending
+	s.Attempts = 0
+	s.RequestedAt = now
+	s.NextAttemptAt = now
+	s.UpdatedAt = now
+	return nil
+}
+
+func (m *mockCRMSyncRepository) Get(ctx context.Context, provider string, callID uuid.UUID) (*domain.CRMSync, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.syncs[callID]
+	if !ok {
+		return nil, apperrors.NotFound("crm sync")
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *mockCRMSyncRepository) Update(ctx context.Context, s *domain.CRMSync) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.syncs[s.CallID]
+	if !ok {
+		return apperrors.NotFound("crm sync")
+	}
+	cp := *s
+	if !stored.RequestedAt.Equal(s.RequestedAt) {
+		cp.Status = domain.CRMSyncPending
+		cp.Attempts = 0
+		cp.RequestedAt = stored.RequestedAt
+		cp.NextAttemptAt = stored.NextAttemptAt
+	}
+	m.syncs[s.CallID] = &cp
+	return nil
+}
+
+func (m *mockCRMSyncRepository) ListDue(ctx context.Context, provider string, now time.Time, limit int) ([]*domain.CRMSync, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.CRMSync
+	for _, s := range m.syncs {
+		if s.Status == domain.CRMSyncPending && !s.NextAttemptAt.After(now) && len(result) < limit {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCRMSyncRepository) List(ctx context.Context, provider string, status domain.CRMSyncStatus, limit int) ([]*domain.CRMSync, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.CRMSync
+	for _, s := range m.syncs {
+		if (status == "" || s.Status == status) && len(result) < limit {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+// fakeCRMClient records upserted contacts and deals.
+type fakeCRMClient struct {
+	mu         sync.Mutex
+	contacts   []*crm.Contact
+	contactIDs []string
+	deals      []*crm.Deal
+	dealIDs    []string
+	contactErr error
+	dealErr    error
+}
+
+func (f *fakeCRMClient) UpsertContact(ctx context.Context, id string, contact *crm.Contact) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.contacts = append(f.contacts, contact)
+	f.contactIDs = append(f.contactIDs, id)
+	if f.contactErr != nil {
+		return "", f.contactErr
+	}
+	return "c-1", nil
+}
+
+func (f *fakeCRMClient) UpsertDeal(ctx context.Context, id, contactID string, deal *crm.Deal) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deals = append(f.deals, deal)
+	f.dealIDs = append(f.dealIDs, id)
+	if f.dealErr != nil {
+		return "", f.dealErr
+	}
+	return "d-1", nil
+}
+
+func (f *fakeCRMClient) Name() string {
+	return crm.ProviderHubSpot
+}
+
+// memoryCRMMappingStore holds the field mapping in memory.
+type memoryCRMMappingStore struct {
+	mapping *domain.CRMFieldMapping
+}
+
+func (m *memoryCRMMappingStore) GetCRMFieldMapping(ctx context.Context) (*domain.CRMFieldMapping, error) {
+	if m.mapping == nil {
+		return &domain.CRMFieldMapping{}, nil
+	}
+	cp := *m.mapping
+	return &cp, nil
+}
+
+func (m *memoryCRMMappingStore) SaveCRMFieldMapping(ctx context.Context, mapping *domain.CRMFieldMapping) error {
+	cp := *mapping
+	m.mapping = &cp
+	return nil
+}
+
+// newCRMTestService returns a CRM service over a fake HubSpot and a call
+// from Jane Doe with a $1,200 roofing quote.
+func newCRMTestService(t *testing.T) (*CRMService, *QuoteService, *mockCRMSyncRepository, *fakeCRMClient, uuid.UUID) {
+	t.Helper()
+	quotes, _, calls, callID := newQuoteTestService(t, "- Build: $1,200", QuoteApprovalConfig{})
+	call, _ := calls.GetByID(context.Background(), callID)
+	call.ExtractedData = &domain.ExtractedData{CallerName: "Jane Doe", Email: "jane@example.com", ProjectType: "Roofing", Timeline: "June"}
+
+	repo := newMockCRMSyncRepository()
+	client := &fakeCRMClient{}
+	svc := NewCRMService(repo, calls, quotes, &memoryCRMMappingStore{}, client, zap.NewNop(), nil, nil)
+	return svc, quotes, repo, client, callID
+}
+
+func TestCRMService_SyncsContactAndDeal(t *testing.T) {
+	svc, quotes, repo, client, callID := newCRMTestService(t)
+	ctx := context.Background()
+	if err := svc.SaveMapping(ctx, &domain.CRMFieldMapping{
+		Contact: map[string]string{domain.CRMFieldProjectType: "project_type"},
+		Deal:    map[string]string{domain.CRMFieldTimeline: "timeline", domain.CRMFieldBudgetRange: "budget"},
+		Stages:  map[domain.QuoteStatus]string{domain.QuoteStatusPendingReview: "qualifiedtobuy"},
+	}); err != nil {
+		t.Fatalf("save mapping: %v", err)
+	}
+
+	svc.EventPublished(ctx, domain.WebhookEventCallCreated, callID)
+	if len(repo.syncs) != 0 {
+		t.Fatal("expected call.created not to queue a sync")
+	}
+	svc.EventPublished(ctx, domain.WebhookEventCallCompleted, callID)
+	svc.syncDue(time.Now())
+
+	crmSync := repo.syncs[callID]
+	if crmSync.Status != domain.CRMSyncSucceeded || crmSync.ContactID != "c-1" || crmSync.DealID != "d-1" {
+		t.Fatalf("unexpected sync %+v", crmSync)
+	}
+	contact := client.contacts[0]
+	if contact.FirstName != "Jane" || contact.LastName != "Doe" || contact.Email != "jane@example.com" || contact.Phone == "" {
+		t.Errorf("unexpected contact %+v", contact)
+	}
+	if contact.Properties["project_type"] != "Roofing" {
+		t.Errorf("expected mapped project type, got %v", contact.Properties)
+	}
+	deal := client.deals[0]
+	if deal.Amount != 1200 || deal.Stage != "appointmentscheduled" || deal.Properties["timeline"] != "June" {
+		t.Errorf("unexpected deal %+v", deal)
+	}
+	if _, ok := deal.Properties["budget"]; ok {
+		t.Error("expected empty source values to be skipped")
+	}
+
+	if _, err := quotes.Submit(ctx, callID, quoteActor("staff@example.com"), ""); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	svc.EventPublished(ctx, domain.WebhookEventQuoteStatusChanged, callID)
+	svc.syncDue(time.Now())
+	if len(client.deals) != 2 || client.deals[1].Stage != "qualifiedtobuy" {
+		t.Fatalf("expected the deal moved to the mapped stage, got %d deals", len(client.deals))
+	}
+	if client.contactIDs[1] != "c-1" || client.dealIDs[1] != "d-1" {
+		t.Errorf("expected the second sync to update the same records, got %v, %v", client.contactIDs, client.dealIDs)
+	}
+}
+
+func TestCRMService_FailedSyncsRetry(t *testing.T) {
+	svc, _, repo, client, callID := newCRMTestService(t)
+	ctx := context.Background()
+	client.dealErr = errors.New("status 400: Property values were not valid")
+
+	svc.EventPublished(ctx, domain.WebhookEventQuoteCreated, callID)
+	svc.syncDue(time.Now())
+
+	crmSync := repo.syncs[callID]
+	if crmSync.Status != domain.CRMSyncPending || crmSync.Attempts != 1 || crmSync.LastError == nil {
+		t.Fatalf("expected a pending retry, got %+v", crmSync)
+	}
+	if crmSync.ContactID != "c-1" {
+		t.Errorf("expected the contact kept after the deal failed, got %q", crmSync.ContactID)
+	}
+
+	for i := 1; i < domain.CRMSyncMaxAttempts; i++ {
+		svc.syncDue(time.Now().Add(2 * time.Hour))
+	}
+	failed, err := svc.ListSyncs(ctx, domain.CRMSyncFailed, 0)
+	if err != nil || len(failed) != 1 {
+		t.Fatalf("expected one sync in the error queue, got %d, %v", len(failed), err)
+	}
+
+	client.dealErr = nil
+	if _, err := svc.RetrySync(ctx, callID); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if _, err := svc.RetrySync(ctx, callID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict retrying a pending sync, got %v", err)
+	}
+	svc.syncDue(time.Now())
+	if repo.syncs[callID].Status != domain.CRMSyncSucceeded {
+		t.Errorf("expected the retried sync to succeed, got %s", repo.syncs[callID].Status)
+	}
+}
+
+func TestCRMService_RequeuedMidAttemptStaysPending(t *testing.T) {
+	svc, _, repo, _, callID := newCRMTestService(t)
+	ctx := context.Background()
+
+	svc.EventPublished(ctx, domain.WebhookEventCallCompleted, callID)
+	crmSync, _ := repo.Get(ctx, crm.ProviderHubSpot, callID)
+	svc.clock = clock.NewMock(time.Now().Add(time.Second))
+	svc.EventPublished(ctx, domain.WebhookEventQuoteApproved, callID)
+
+	mapping, _ := svc.Mapping(ctx)
+	svc.attempt(ctx, crmSync, mapping)
+	if stored := repo.syncs[callID]; stored.Status != domain.CRMSyncPending || stored.ContactID != "c-1" {
+		t.Errorf("expected a sync queued mid-attempt to stay pending with its contact, got %+v", stored)
+	}
+}
+
+func TestEventService_PublishQueuesCRMSync(t *testing.T) {
+	events, _ := newTestEventService(time.Now())
+	svc, _, repo, _, callID := newCRMTestService(t)
+	events.SetCRM(svc)
+
+	events.Publish(context.Background(), domain.WebhookEventQuoteApproved, &WebhookQuoteData{CallID: callID, Status: domain.QuoteStatusApproved})
+	if s := repo.syncs[callID]; s == nil || s.Status != domain.CRMSyncPending {
+		t.Errorf("expected a queued crm sync, got %+v", s)
+	}
+}
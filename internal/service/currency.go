@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// CurrencySource provides the currency new quotes are priced in.
+type CurrencySource interface {
+	DefaultCurrency(ctx context.Context) string
+}
+
+// callCurrency returns the currency a call's quote is priced in: the one
+// recorded when it was generated, or else the default.
+func callCurrency(ctx context.Context, currencies CurrencySource, call *domain.Call) string {
+	if currency := call.Currency(); currency != "" {
+		return currency
+	}
+	return defaultCurrency(ctx, currencies)
+}
+
+// defaultCurrency returns the currency new quotes are priced in.
+func defaultCurrency(ctx context.Context, currencies CurrencySource) string {
+	if currencies != nil {
+		return currencies.DefaultCurrency(ctx)
+	}
+	return domain.DefaultCurrency
+}
+
+// recordCurrency records the currency a call's quote is about to be
+// generated in, so later edits, documents and payments use the same one. A
+// regenerated quote is priced in the current default.
+func recordCurrency(ctx context.Context, currencies CurrencySource, call *domain.Call) {
+	if call.ExtractedData == nil {
+		call.ExtractedData = &domain.ExtractedData{}
+	}
+	call.ExtractedData.Currency = defaultCurrency(ctx, currencies)
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// customerHistoryLimit caps the number of calls shown in a customer's history.
+const customerHistoryLimit = 50
+
+// CustomerService manages customers and links calls to them.
+type CustomerService struct {
+	repo   domain.CustomerRepository
+	calls  domain.CallRepository
+	quotes domain.QuoteRepository
+	logger *zap.Logger
+}
+
+// NewCustomerService creates a new CustomerService. quotes may be nil, in
+// which case every quote in a customer's history is reported as a draft.
+func NewCustomerService(repo domain.CustomerRepository, calls domain.CallRepository, quotes domain.QuoteRepository, logger *zap.Logger) *CustomerService {
+	return &CustomerService{
+		repo:   repo,
+		calls:  calls,
+		quotes: quotes,
+		logger: logger,
+	}
+}
+
+// CreateCustomerRequest holds the fields for creating a customer.
+type CreateCustomerRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	Name        string `json:"name,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Company     string `json:"company,omitempty"`
+	Address     string `json:"address,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+
+	// PreferredChannel is "sms" or "whatsapp"; empty is SMS.
+	PreferredChannel string `json:"preferred_channel,omitempty"`
+}
+
+// UpdateCustomerRequest holds the fields to change on a customer. Nil fields
+// are left unchanged.
+type UpdateCustomerRequest struct {
+	PhoneNumber *string `json:"phone_number,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Company     *string `json:"company,omitempty"`
+	Address     *string `json:"address,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	OptedOut    *bool   `json:"opted_out,omitempty"`
+
+	PreferredChannel *string `json:"preferred_channel,omitempty"`
+}
+
+// CustomerQuote summarizes a quote in a customer's history.
+type CustomerQuote struct {
+	CallID      uuid.UUID          `json:"call_id"`
+	QuoteNumber string             `json:"quote_number"`
+	Status      domain.QuoteStatus `json:"status"`
+	TotalAmount float64            `json:"total_amount"`
+	Currency    string             `json:"currency"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// CustomerHistory is a customer with their most recent calls and quotes.
+type CustomerHistory struct {
+	Customer   *domain.Customer `json:"customer"`
+	Calls      []*domain.Call   `json:"calls"`
+	TotalCalls int              `json:"total_calls"`
+	Quotes     []*CustomerQuote `json:"quotes"`
+}
+
+// CreateCustomer validates and stores a new customer.
+func (s *CustomerService) CreateCustomer(ctx context.Context, req *CreateCustomerRequest) (*domain.Customer, error) {
+	phone, err := customerPhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := domain.NewCustomer(phone)
+	customer.Name = strings.TrimSpace(req.Name)
+	customer.Email = strings.TrimSpace(req.Email)
+	customer.Company = strings.TrimSpace(req.Company)
+	customer.Address = strings.TrimSpace(req.Address)
+	customer.Notes = strings.TrimSpace(req.Notes)
+	if err := validateCustomerEmail(customer.Email); err != nil {
+		return nil, err
+	}
+	if customer.PreferredChannel, err = customerChannel(req.PreferredChannel); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("customer created", zap.String("customer_id", customer.ID.String()))
+	return customer, nil
+}
+
+// GetCustomer retrieves a customer by ID.
+func (s *CustomerService) GetCustomer(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdateCustomer applies changes to a customer.
+func (s *CustomerService) UpdateCustomer(ctx context.Context, id uuid.UUID, req *UpdateCustomerRequest) (*domain.Customer, error) {
+	customer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PhoneNumber != nil {
+		phone, err := customerPhoneNumber(*req.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		customer.PhoneNumber = phone
+	}
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = strings.TrimSpace(*value)
+		}
+	}
+	set(&customer.Name, req.Name)
+	set(&customer.Email, req.Email)
+	set(&customer.Company, req.Company)
+	set(&customer.Address, req.Address)
+	set(&customer.Notes, req.Notes)
+	if err := validateCustomerEmail(customer.Email); err != nil {
+		return nil, err
+	}
+	if req.OptedOut != nil {
+		customer.SetOptedOut(*req.OptedOut)
+	}
+	if req.PreferredChannel != nil {
+		if customer.PreferredChannel, err = customerChannel(*req.PreferredChannel); err != nil {
+			return nil, err
+		}
+	}
+
+	customer.UpdatedAt = time.Now().UTC()
+	if err := s.repo.Update(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("customer updated", zap.String("customer_id", customer.ID.String()))
+	return customer, nil
+}
+
+// DeleteCustomer removes a customer. Their calls and quotes are kept.
+func (s *CustomerService) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("customer deleted", zap.String("customer_id", id.String()))
+	return nil
+}
+
+// ListCustomers retrieves customers matching filter with pagination.
+func (s *CustomerService) ListCustomers(ctx context.Context, filter *domain.CustomerListFilter, page, pageSize int) ([]*domain.Customer, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	customers, err := s.repo.List(ctx, filter, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list customers: %w", err)
+	}
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count customers: %w", err)
+	}
+	return customers, total, nil
+}
+
+// GetHistory returns a customer with their most recent calls and the quotes
+// generated from them.
+func (s *CustomerService) GetHistory(ctx context.Context, id uuid.UUID) (*CustomerHistory, error) {
+	customer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &domain.CallListFilter{CustomerID: &customer.ID}
+	calls, err := s.calls.List(ctx, filter, customerHistoryLimit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer calls: %w", err)
+	}
+	total, err := s.calls.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count customer calls: %w", err)
+	}
+
+	history := &CustomerHistory{
+		Customer:   customer,
+		Calls:      calls,
+		TotalCalls: total,
+		Quotes:     []*CustomerQuote{},
+	}
+	if history.Calls == nil {
+		history.Calls = []*domain.Call{}
+	}
+
+	for _, call := range calls {
+		if !call.HasQuote() {
+			continue
+		}
+		q := &CustomerQuote{
+			CallID:      call.ID,
+			QuoteNumber: quotepdf.QuoteNumber(call.ID),
+			Status:      domain.QuoteStatusDraft,
+			TotalAmount: QuoteTotal(*call.QuoteSummary),
+			Currency:    call.Currency(),
+			CreatedAt:   call.CreatedAt,
+		}
+		if s.quotes != nil {
+			quote, err := s.quotes.GetByCallID(ctx, call.ID)
+			if err != nil && !apperrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get quote status: %w", err)
+			}
+			if quote != nil {
+				q.Status = quote.Status
+				q.Currency = quote.Currency
+				if quote.Status != domain.QuoteStatusDraft {
+					q.TotalAmount = quote.TotalAmount
+				}
+			}
+		}
+		if q.Currency == "" {
+			q.Currency = domain.DefaultCurrency
+		}
+		history.Quotes = append(history.Quotes, q)
+	}
+
+	return history, nil
+}
+
+// LinkCall attaches a call to the customer with the call's phone number,
+// creating the customer on first contact, and fills in any contact details
+// the call captured. Calls without a usable number are left unlinked.
+func (s *CustomerService) LinkCall(ctx context.Context, call *domain.Call) error {
+	var customer *domain.Customer
+	if call.CustomerID != nil {
+		c, err := s.repo.GetByID(ctx, *call.CustomerID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return err
+		}
+		customer = c
+	}
+
+	if customer == nil {
+		phone := normalizePhoneNumber(call.CustomerNumber())
+		if phone == "" {
+			return nil
+		}
+		c, err := s.repo.GetOrCreateByPhoneNumber(ctx, domain.NewCustomer(phone))
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		customer = c
+	}
+
+	if customer.ApplyCallDetails(call) {
+		if err := s.repo.Update(ctx, customer); err != nil {
+			return fmt.Errorf("failed to update customer: %w", err)
+		}
+	}
+
+	call.CustomerID = &customer.ID
+	return nil
+}
+
+// customerPhoneNumber normalizes a phone number to E.164 or returns a validation error.
+func customerPhoneNumber(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", apperrors.MissingField("phone_number")
+	}
+	phone := normalizePhoneNumber(s)
+	if phone == "" {
+		return "", apperrors.ValidationFailed("phone_number must be a valid phone number")
+	}
+	return phone, nil
+}
+
+// customerChannel parses a preferred channel or returns a validation error.
+func customerChannel(s string) (domain.MessageChannel, error) {
+	channel, err := domain.ParseMessageChannel(s)
+	if err != nil {
+		return "", apperrors.ValidationFailed("preferred_channel must be sms or whatsapp")
+	}
+	return channel, nil
+}
+
+func validateCustomerEmail(email string) error {
+	if email == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return apperrors.ValidationFailed("email must be a valid email address")
+	}
+	return nil
+}
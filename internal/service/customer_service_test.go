@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockCustomerRepository is an in-memory CustomerRepository.
+type MockCustomerRepository struct {
+	mu        sync.Mutex
+	customers map[uuid.UUID]*domain.Customer
+}
+
+func NewMockCustomerRepository() *MockCustomerRepository {
+	return &MockCustomerRepository{customers: make(map[uuid.UUID]*domain.Customer)}
+}
+
+func (m *MockCustomerRepository) byPhone(phone string) *domain.Customer {
+	for _, c := range m.customers {
+		if c.PhoneNumber == phone {
+			return c
+		}
+	}
+	return nil
+}
+
+func (m *MockCustomerRepository) Create(ctx context.Context, customer *domain.Customer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.byPhone(customer.PhoneNumber) != nil {
+		return apperrors.New(apperrors.CodeAlreadyExists, "a customer with this phone number already exists")
+	}
+	cp := *customer
+	m.customers[customer.ID] = &cp
+	return nil
+}
+
+func (m *MockCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.customers[id]
+	if !ok {
+		return nil, apperrors.NotFound("customer")
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (m *MockCustomerRepository) GetOrCreateByPhoneNumber(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c := m.byPhone(customer.PhoneNumber); c != nil {
+		cp := *c
+		return &cp, nil
+	}
+	cp := *customer
+	m.customers[customer.ID] = &cp
+	result := cp
+	return &result, nil
+}
+
+func (m *MockCustomerRepository) Update(ctx context.Context, customer *domain.Customer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.customers[customer.ID]; !ok {
+		return apperrors.NotFound("customer")
+	}
+	if c := m.byPhone(customer.PhoneNumber); c != nil && c.ID != customer.ID {
+		return apperrors.New(apperrors.CodeAlreadyExists, "a customer with this phone number already exists")
+	}
+	cp := *customer
+	m.customers[customer.ID] = &cp
+	return nil
+}
+
+func (m *MockCustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.customers[id]; !ok {
+		return apperrors.NotFound("customer")
+	}
+	delete(m.customers, id)
+	return nil
+}
+
+func (m *MockCustomerRepository) List(ctx context.Context, filter *domain.CustomerListFilter, limit, offset int) ([]*domain.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	search := ""
+	if filter != nil {
+		search = filter.Search
+	}
+	var result []*domain.Customer
+	for _, c := range m.customers {
+		if search == "" || strings.Contains(c.Name, search) || strings.Contains(c.PhoneNumber, search) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockCustomerRepository) Count(ctx context.Context, filter *domain.CustomerListFilter) (int, error) {
+	customers, _ := m.List(ctx, filter, 0, 0)
+	return len(customers), nil
+}
+
+func newCustomerTestService() (*CustomerService, *MockCustomerRepository, *MockCallRepository, *MockQuoteRepository) {
+	repo := NewMockCustomerRepository()
+	calls := NewMockCallRepository()
+	quotes := NewMockQuoteRepository()
+	return NewCustomerService(repo, calls, quotes, zap.NewNop()), repo, calls, quotes
+}
+
+func TestCustomerService_CreateCustomer(t *testing.T) {
+	svc, _, _, _ := newCustomerTestService()
+	ctx := context.Background()
+
+	c, err := svc.CreateCustomer(ctx, &CreateCustomerRequest{PhoneNumber: "(555) 000-1111", Name: " Dana "})
+	if err != nil {
+		t.Fatalf("CreateCustomer: %v", err)
+	}
+	if c.PhoneNumber != "+15550001111" {
+		t.Errorf("expected normalized phone +15550001111, got %s", c.PhoneNumber)
+	}
+	if c.Name != "Dana" {
+		t.Errorf("expected trimmed name, got %q", c.Name)
+	}
+
+	_, err = svc.CreateCustomer(ctx, &CreateCustomerRequest{PhoneNumber: "+15550001111"})
+	if apperrors.GetCode(err) != apperrors.CodeAlreadyExists {
+		t.Errorf("expected already exists for duplicate phone, got %v", err)
+	}
+
+	invalid := []*CreateCustomerRequest{
+		{},
+		{PhoneNumber: "not a number"},
+		{PhoneNumber: "+15550003333", Email: "nope"},
+	}
+	for _, req := range invalid {
+		if _, err := svc.CreateCustomer(ctx, req); !apperrors.IsUserError(err) {
+			t.Errorf("expected validation error for %+v, got %v", req, err)
+		}
+	}
+}
+
+func TestCustomerService_LinkCall_DedupesByPhoneNumber(t *testing.T) {
+	svc, repo, _, _ := newCustomerTestService()
+	ctx := context.Background()
+
+	name := "Dana"
+	first := domain.NewCall("p1", "bland", "+15550001111", "555-000-2222")
+	first.CallerName = &name
+	second := domain.NewCall("p2", "bland", "+15550001111", "+15550002222")
+
+	if err := svc.LinkCall(ctx, first); err != nil {
+		t.Fatalf("LinkCall: %v", err)
+	}
+	if err := svc.LinkCall(ctx, second); err != nil {
+		t.Fatalf("LinkCall: %v", err)
+	}
+
+	if first.CustomerID == nil || second.CustomerID == nil {
+		t.Fatal("expected both calls to be linked")
+	}
+	if *first.CustomerID != *second.CustomerID {
+		t.Error("expected calls from the same number to share a customer")
+	}
+	if len(repo.customers) != 1 {
+		t.Fatalf("expected 1 customer, got %d", len(repo.customers))
+	}
+
+	c, _ := repo.GetByID(ctx, *first.CustomerID)
+	if c.PhoneNumber != "+15550002222" || c.Name != "Dana" {
+		t.Errorf("unexpected customer %+v", c)
+	}
+}
+
+func TestCustomerService_LinkCall_KeepsExistingLink(t *testing.T) {
+	svc, repo, _, _ := newCustomerTestService()
+	ctx := context.Background()
+
+	existing, err := svc.CreateCustomer(ctx, &CreateCustomerRequest{PhoneNumber: "+15550009999"})
+	if err != nil {
+		t.Fatalf("CreateCustomer: %v", err)
+	}
+
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	call.CustomerID = &existing.ID
+	if err := svc.LinkCall(ctx, call); err != nil {
+		t.Fatalf("LinkCall: %v", err)
+	}
+	if *call.CustomerID != existing.ID {
+		t.Error("expected call to stay linked to its customer")
+	}
+	if len(repo.customers) != 1 {
+		t.Errorf("expected no new customer, got %d", len(repo.customers))
+	}
+}
+
+func TestCustomerService_LinkCall_NoNumber(t *testing.T) {
+	svc, repo, _, _ := newCustomerTestService()
+
+	call := domain.NewCall("p1", "bland", "", "")
+	if err := svc.LinkCall(context.Background(), call); err != nil {
+		t.Fatalf("LinkCall: %v", err)
+	}
+	if call.CustomerID != nil {
+		t.Error("expected call without a number to stay unlinked")
+	}
+	if len(repo.customers) != 0 {
+		t.Errorf("expected no customers, got %d", len(repo.customers))
+	}
+}
+
+func TestCustomerService_GetHistory(t *testing.T) {
+	svc, _, calls, quotes := newCustomerTestService()
+	ctx := context.Background()
+
+	customer, err := svc.CreateCustomer(ctx, &CreateCustomerRequest{PhoneNumber: "+15550002222"})
+	if err != nil {
+		t.Fatalf("CreateCustomer: %v", err)
+	}
+
+	summary := "Item A: $100\nTotal: $100"
+	quoted := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	quoted.CustomerID = &customer.ID
+	quoted.QuoteSummary = &summary
+	plain := domain.NewCall("p2", "bland", "+15550001111", "+15550002222")
+	plain.CustomerID = &customer.ID
+	other := domain.NewCall("p3", "bland", "+15550001111", "+15550003333")
+	for _, c := range []*domain.Call{quoted, plain, other} {
+		if err := calls.Create(ctx, c); err != nil {
+			t.Fatalf("create call: %v", err)
+		}
+	}
+
+	q := domain.NewQuote(quoted.ID, 100)
+	q.Status = domain.QuoteStatusSent
+	quotes.quotes[quoted.ID] = q
+
+	history, err := svc.GetHistory(ctx, customer.ID)
+	if err != nil {
+		t.Fatalf("GetHistory: %v", err)
+	}
+	if history.TotalCalls != 2 || len(history.Calls) != 2 {
+		t.Errorf("expected 2 calls, got %d (%d total)", len(history.Calls), history.TotalCalls)
+	}
+	if len(history.Quotes) != 1 {
+		t.Fatalf("expected 1 quote, got %d", len(history.Quotes))
+	}
+	if history.Quotes[0].CallID != quoted.ID || history.Quotes[0].Status != domain.QuoteStatusSent {
+		t.Errorf("unexpected quote %+v", history.Quotes[0])
+	}
+
+	if _, err := svc.GetHistory(ctx, uuid.New()); !apperrors.IsNotFound(err) {
+		t.Errorf("expected not found for unknown customer, got %v", err)
+	}
+}
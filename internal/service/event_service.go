@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// Events feed page sizes.
+const (
+	DefaultEventPageSize = 100
+	MaxEventPageSize     = 500
+)
+
+// eventSettleDelay holds back the newest events from the feed, so an event
+// whose sequence was assigned before a concurrent one's but committed after
+// it is not skipped by clients that already moved past it.
+const eventSettleDelay = 5 * time.Second
+
+// EventService records changes to calls and quotes in the events feed and
+// passes them on to outgoing webhook subscribers and the CRM.
+type EventService struct {
+	events   domain.EventRepository
+	webhooks *OutgoingWebhookService
+	crm      EventSubscriber
+	logger   *zap.Logger
+	clock    clock.Clock
+}
+
+// NewEventService creates a new EventService.
+func NewEventService(events domain.EventRepository, logger *zap.Logger, clk clock.Clock) *EventService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &EventService{
+		events: events,
+		logger: logger,
+		clock:  clk,
+	}
+}
+
+// SetWebhooks enables delivering published events to outgoing webhook subscribers.
+func (s *EventService) SetWebhooks(webhooks *OutgoingWebhookService) {
+	s.webhooks = webhooks
+}
+
+// EventSubscriber is told about each published event that belongs to a
+// call. CRMService implements it.
+type EventSubscriber interface {
+	EventPublished(ctx context.Context, eventType domain.WebhookEventType, callID uuid.UUID)
+}
+
+// SetCRM enables queuing CRM syncs for published call and quote events.
+func (s *EventService) SetCRM(crm EventSubscriber) {
+	s.crm = crm
+}
+
+// eventCallData is implemented by event data that belongs to a call.
+type eventCallData interface {
+	eventCallID() uuid.UUID
+}
+
+func (d *WebhookCallData) eventCallID() uuid.UUID  { return d.CallID }
+func (d *WebhookQuoteData) eventCallID() uuid.UUID { return d.CallID }
+
+// Publish records an event in the feed and queues it for webhook
+// subscribers. Failures are logged; the caller's work never fails over an event.
+func (s *EventService) Publish(ctx context.Context, eventType domain.WebhookEventType, data interface{}) {
+	logger := s.logger.With(zap.String("event", string(eventType)))
+
+	payload := &domain.WebhookPayload{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: s.clock.Now().UTC(),
+		Data:      data,
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		logger.Error("failed to marshal event data", zap.Error(err))
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed to marshal event payload", zap.Error(err))
+		return
+	}
+
+	event := &domain.Event{
+		ID:        payload.ID,
+		Type:      eventType,
+		Data:      dataJSON,
+		CreatedAt: payload.CreatedAt,
+	}
+	if d, ok := data.(eventCallData); ok {
+		callID := d.eventCallID()
+		event.CallID = &callID
+	}
+	if err := s.events.Create(ctx, event); err != nil {
+		logger.Error("failed to record event", zap.Error(err))
+	}
+
+	if s.webhooks != nil {
+		s.webhooks.Enqueue(ctx, payload, body)
+	}
+	if s.crm != nil && event.CallID != nil {
+		s.crm.EventPublished(ctx, eventType, *event.CallID)
+	}
+}
+
+// FeedEvent is an event as returned by the events feed, with the cursor
+// that continues the feed after it.
+type FeedEvent struct {
+	*domain.Event
+	Cursor string `json:"cursor"`
+}
+
+// EventPage is a page of the events feed.
+type EventPage struct {
+	Version    string       `json:"version"`
+	Events     []*FeedEvent `json:"events"`
+	NextCursor string       `json:"next_cursor"` // Pass as cursor to continue; unchanged when there are no new events
+	HasMore    bool         `json:"has_more"`
+}
+
+// ListEvents returns the events after cursor, oldest first. An empty cursor
+// starts at the beginning of the feed.
+func (s *EventService) ListEvents(ctx context.Context, cursor string, types []string, limit int) (*EventPage, error) {
+	filter := &domain.EventFilter{Limit: limit}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultEventPageSize
+	}
+	if filter.Limit > MaxEventPageSize {
+		filter.Limit = MaxEventPageSize
+	}
+
+	cursor = strings.TrimSpace(cursor)
+	if cursor != "" {
+		after, err := domain.DecodeEventCursor(cursor)
+		if err != nil {
+			return nil, apperrors.ValidationFailed(err.Error())
+		}
+		filter.After = after
+	}
+
+	for _, t := range types {
+		eventType := domain.WebhookEventType(strings.TrimSpace(t))
+		if !eventType.IsValid() {
+			return nil, apperrors.ValidationFailed(fmt.Sprintf("unknown event type %q", t))
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+
+	// Fetch one extra event to learn whether there are more
+	page := *filter
+	page.Limit++
+	events, err := s.events.List(ctx, &page, s.clock.Now().Add(-eventSettleDelay))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	result := &EventPage{
+		Version:    domain.EventFeedVersion,
+		Events:     []*FeedEvent{},
+		NextCursor: domain.EncodeEventCursor(filter.After),
+	}
+	if len(events) > filter.Limit {
+		events = events[:filter.Limit]
+		result.HasMore = true
+	}
+	for _, e := range events {
+		result.Events = append(result.Events, &FeedEvent{Event: e, Cursor: e.Cursor()})
+	}
+	if len(events) > 0 {
+		result.NextCursor = events[len(events)-1].Cursor()
+	}
+	return result, nil
+}
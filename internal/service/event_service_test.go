@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockEventRepository is an in-memory events feed.
+type MockEventRepository struct {
+	mu     sync.Mutex
+	events []*domain.Event
+}
+
+func (m *MockEventRepository) Create(ctx context.Context, event *domain.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	event.Sequence = int64(len(m.events) + 1)
+	cp := *event
+	m.events = append(m.events, &cp)
+	return nil
+}
+
+func (m *MockEventRepository) List(ctx context.Context, filter *domain.EventFilter, settledBefore time.Time) ([]*domain.Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.Event
+	for _, e := range m.events {
+		if e.Sequence <= filter.After || e.CreatedAt.After(settledBefore) {
+			continue
+		}
+		if len(filter.Types) > 0 {
+			match := false
+			for _, t := range filter.Types {
+				match = match || t == e.Type
+			}
+			if !match {
+				continue
+			}
+		}
+		cp := *e
+		result = append(result, &cp)
+		if len(result) == filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func newTestEventService(now time.Time) (*EventService, *MockEventRepository) {
+	repo := &MockEventRepository{}
+	svc := NewEventService(repo, zap.NewNop(), clock.NewMock(now))
+	return svc, repo
+}
+
+func TestEventService_PublishRecordsAndDeliversEvent(t *testing.T) {
+	svc, repo := newTestEventService(time.Now())
+	webhooks, webhookRepo := newTestOutgoingWebhookService()
+	svc.SetWebhooks(webhooks)
+
+	ctx := context.Background()
+	if _, err := webhooks.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "CRM", URL: "https://crm.example.com", Events: []domain.WebhookEventType{domain.WebhookEventQuoteApproved},
+	}); err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+
+	callID := uuid.New()
+	svc.Publish(ctx, domain.WebhookEventQuoteApproved, &WebhookQuoteData{CallID: callID, Status: domain.QuoteStatusApproved})
+
+	if len(repo.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(repo.events))
+	}
+	event := repo.events[0]
+	if event.CallID == nil || *event.CallID != callID {
+		t.Errorf("CallID = %v, want %s", event.CallID, callID)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(event.Data, &data); err != nil || data["status"] != "approved" {
+		t.Errorf("Data = %s, %v", event.Data, err)
+	}
+
+	if len(webhookRepo.deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(webhookRepo.deliveries))
+	}
+	for _, d := range webhookRepo.deliveries {
+		if d.EventID != event.ID {
+			t.Errorf("delivery event ID = %s, want the feed event's %s", d.EventID, event.ID)
+		}
+	}
+}
+
+func TestEventService_ListEventsPaginates(t *testing.T) {
+	now := time.Now()
+	svc, repo := newTestEventService(now.Add(-time.Minute))
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		svc.Publish(ctx, domain.WebhookEventCallCreated, &WebhookCallData{CallID: uuid.New()})
+	}
+	svc.Publish(ctx, domain.WebhookEventQuoteCreated, &WebhookQuoteData{CallID: uuid.New()})
+	svc.clock = clock.NewMock(now)
+
+	page, err := svc.ListEvents(ctx, "", nil, 2)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(page.Events) != 2 || !page.HasMore || page.Version != domain.EventFeedVersion {
+		t.Fatalf("first page = %d events, has_more %v", len(page.Events), page.HasMore)
+	}
+	if page.NextCursor != page.Events[1].Cursor {
+		t.Errorf("NextCursor = %q, want the last event's cursor", page.NextCursor)
+	}
+
+	page, err = svc.ListEvents(ctx, page.NextCursor, nil, 2)
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	if len(page.Events) != 2 || page.HasMore || page.Events[1].Type != domain.WebhookEventQuoteCreated {
+		t.Fatalf("second page = %d events, has_more %v", len(page.Events), page.HasMore)
+	}
+
+	last := page.NextCursor
+	page, err = svc.ListEvents(ctx, last, nil, 2)
+	if err != nil || len(page.Events) != 0 || page.NextCursor != last {
+		t.Errorf("caught up page = %+v, %v; want no events and the same cursor", page, err)
+	}
+
+	page, err = svc.ListEvents(ctx, "", []string{"quote.created"}, 0)
+	if err != nil || len(page.Events) != 1 {
+		t.Errorf("filtered page = %+v, %v", page, err)
+	}
+
+	// Events newer than the settle delay are held back
+	svc.Publish(ctx, domain.WebhookEventCallCreated, &WebhookCallData{CallID: uuid.New()})
+	page, err = svc.ListEvents(ctx, last, nil, 0)
+	if err != nil || len(page.Events) != 0 {
+		t.Errorf("unsettled page = %d events, %v; want none", len(page.Events), err)
+	}
+	if len(repo.events) != 5 {
+		t.Errorf("got %d events, want 5", len(repo.events))
+	}
+}
+
+func TestEventService_ListEventsValidates(t *testing.T) {
+	svc, _ := newTestEventService(time.Now())
+	ctx := context.Background()
+
+	if _, err := svc.ListEvents(ctx, "not-a-cursor", nil, 0); !apperrors.IsUserError(err) {
+		t.Errorf("ListEvents() with a bad cursor error = %v, want validation error", err)
+	}
+	if _, err := svc.ListEvents(ctx, "", []string{"call.deleted"}, 0); !apperrors.IsUserError(err) {
+		t.Errorf("ListEvents() with an unknown type error = %v, want validation error", err)
+	}
+
+	sequence, err := domain.DecodeEventCursor(domain.EncodeEventCursor(42))
+	if err != nil || sequence != 42 {
+		t.Errorf("cursor round trip = %d, %v", sequence, err)
+	}
+}
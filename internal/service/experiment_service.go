@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// InboundAgentConfigurer sets the agent that answers an inbound number.
+type InboundAgentConfigurer interface {
+	ConfigureInboundAgent(ctx context.Context, phoneNumberID string, config *bland.InboundConfig) (*bland.PhoneNumber, error)
+}
+
+// ExperimentService manages prompt A/B experiments. Outbound calls placed
+// with a prompt under test are given a variant when they are initiated;
+// inbound numbers are switched to a newly picked variant after every call
+// they receive. Calls carry their variant in provider metadata so the
+// webhook that reports their outcome can attribute it.
+type ExperimentService struct {
+	repo    domain.ExperimentRepository
+	prompts domain.PromptRepository
+	inbound InboundAgentConfigurer
+	random  func() float64
+	logger  *zap.Logger
+}
+
+// NewExperimentService creates a new ExperimentService.
+func NewExperimentService(repo domain.ExperimentRepository, prompts domain.PromptRepository, logger *zap.Logger) *ExperimentService {
+	return &ExperimentService{
+		repo:    repo,
+		prompts: prompts,
+		random:  rand.Float64,
+		logger:  logger,
+	}
+}
+
+// SetInboundConfigurer enables inbound experiments, which switch the agent
+// on their phone number between variants.
+func (s *ExperimentService) SetInboundConfigurer(configurer InboundAgentConfigurer) {
+	s.inbound = configurer
+}
+
+// CreateExperimentRequest holds the parameters for creating an experiment.
+type CreateExperimentRequest struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description,omitempty"`
+	Direction   domain.ExperimentDirection `json:"direction"`
+	PhoneNumber string                     `json:"phone_number,omitempty"`
+	// Variants are the prompts under test; the first is the control.
+	Variants  []ExperimentVariantRequest `json:"variants"`
+	CreatedBy *uuid.UUID                 `json:"-"`
+}
+
+// ExperimentVariantRequest describes one variant of a new experiment.
+type ExperimentVariantRequest struct {
+	Name     string    `json:"name,omitempty"`
+	PromptID uuid.UUID `json:"prompt_id"`
+	Weight   int       `json:"weight"` // Percentage of calls; the weights add up to 100
+}
+
+// CreateExperiment validates the split and stores a draft experiment.
+func (s *ExperimentService) CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*domain.Experiment, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.MissingField("name")
+	}
+
+	variants := make([]domain.ExperimentVariant, 0, len(req.Variants))
+	for _, v := range req.Variants {
+		variants = append(variants, domain.ExperimentVariant{
+			Name:     strings.TrimSpace(v.Name),
+			PromptID: v.PromptID,
+			Weight:   v.Weight,
+		})
+	}
+
+	experiment := domain.NewExperiment(name, req.Direction, variants)
+	experiment.Description = strings.TrimSpace(req.Description)
+	experiment.PhoneNumber = strings.TrimSpace(req.PhoneNumber)
+	experiment.CreatedBy = req.CreatedBy
+	if experiment.Direction == domain.ExperimentDirectionOutbound {
+		experiment.PhoneNumber = ""
+	}
+	if err := experiment.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	if experiment.Direction == domain.ExperimentDirectionInbound && s.inbound == nil {
+		return nil, apperrors.ValidationFailed("inbound experiments are not available without a voice provider")
+	}
+
+	for _, v := range experiment.Variants {
+		if _, err := s.prompts.GetByID(ctx, v.PromptID); err != nil {
+			if apperrors.IsNotFound(err) {
+				return nil, apperrors.ValidationFailed(fmt.Sprintf("prompt %s for variant %q not found", v.PromptID, v.Name))
+			}
+			return nil, fmt.Errorf("failed to get prompt: %w", err)
+		}
+	}
+
+	if err := s.repo.Create(ctx, experiment); err != nil {
+		return nil, fmt.Errorf("failed to create experiment: %w", err)
+	}
+
+	s.logger.Info("experiment created",
+		zap.String("experiment_id", experiment.ID.String()),
+		zap.String("name", experiment.Name),
+		zap.String("direction", string(experiment.Direction)),
+		zap.Int("variants", len(experiment.Variants)),
+	)
+	return experiment, nil
+}
+
+// GetExperiment retrieves an experiment by ID.
+func (s *ExperimentService) GetExperiment(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListExperiments returns the most recent experiments.
+func (s *ExperimentService) ListExperiments(ctx context.Context, limit int) ([]*domain.Experiment, error) {
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	experiments, err := s.repo.List(ctx, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list experiments: %w", err)
+	}
+	return experiments, nil
+}
+
+// DeleteExperiment removes an experiment and its results. A running
+// experiment must be stopped first.
+func (s *ExperimentService) DeleteExperiment(ctx context.Context, id uuid.UUID) error {
+	experiment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if experiment.IsRunning() {
+		return apperrors.New(apperrors.CodeConflict, "stop the experiment before deleting it")
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete experiment: %w", err)
+	}
+	s.logger.Info("experiment deleted", zap.String("experiment_id", id.String()))
+	return nil
+}
+
+// StartExperiment begins splitting calls. Only one running experiment may
+// test a given prompt on outbound calls, and only one may run on a given
+// inbound number. Starting an inbound experiment puts a variant on the
+// number straight away.
+func (s *ExperimentService) StartExperiment(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+	experiment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := experiment.Start(); err != nil {
+		return nil, s.transitionError(experiment, "started", err)
+	}
+
+	running, err := s.repo.ListRunning(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running experiments: %w", err)
+	}
+	for _, other := range running {
+		if conflict := experimentsOverlap(experiment, other); conflict != "" {
+			return nil, apperrors.New(apperrors.CodeConflict,
+				fmt.Sprintf("experiment %q is already running %s", other.Name, conflict))
+		}
+	}
+
+	if experiment.Direction == domain.ExperimentDirectionInbound {
+		if err := s.applyInboundVariant(ctx, experiment, experiment.PickVariant(s.random())); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, experiment); err != nil {
+		return nil, fmt.Errorf("failed to update experiment: %w", err)
+	}
+
+	s.logger.Info("experiment started", zap.String("experiment_id", experiment.ID.String()))
+	return experiment, nil
+}
+
+// StopExperiment stops splitting calls. An inbound number is put back on
+// the control prompt.
+func (s *ExperimentService) StopExperiment(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+	experiment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := experiment.Stop(); err != nil {
+		return nil, s.transitionError(experiment, "stopped", err)
+	}
+
+	if err := s.repo.Update(ctx, experiment); err != nil {
+		return nil, fmt.Errorf("failed to update experiment: %w", err)
+	}
+
+	if experiment.Direction == domain.ExperimentDirectionInbound {
+		if err := s.applyInboundVariant(ctx, experiment, experiment.Control()); err != nil {
+			// The experiment is stopped either way; the number keeps its
+			// last variant until the preset is applied again.
+			s.logger.Warn("failed to restore control prompt on inbound number",
+				zap.String("experiment_id", experiment.ID.String()),
+				zap.String("phone_number", experiment.PhoneNumber),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.logger.Info("experiment stopped", zap.String("experiment_id", experiment.ID.String()))
+	return experiment, nil
+}
+
+// GetResults returns the outcome metrics of each variant and how each
+// compares to the control.
+func (s *ExperimentService) GetResults(ctx context.Context, id uuid.UUID) (*domain.ExperimentResults, error) {
+	experiment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	outcomes, err := s.repo.VariantOutcomes(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experiment outcomes: %w", err)
+	}
+	return domain.NewExperimentResults(experiment, outcomes), nil
+}
+
+// AssignOutbound picks a variant for an outbound call placed with promptID.
+// It returns nil when no running experiment is testing that prompt.
+func (s *ExperimentService) AssignOutbound(ctx context.Context, promptID uuid.UUID) (*domain.ExperimentVariant, error) {
+	running, err := s.repo.ListRunning(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running experiments: %w", err)
+	}
+	for _, experiment := range running {
+		if experiment.Direction == domain.ExperimentDirectionOutbound && experiment.HasPrompt(promptID) {
+			return experiment.PickVariant(s.random()), nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordAssignment saves the variant an outbound call was given.
+func (s *ExperimentService) RecordAssignment(ctx context.Context, variant *domain.ExperimentVariant, callID uuid.UUID) error {
+	_, err := s.repo.RecordAssignment(ctx, domain.NewExperimentAssignment(variant, callID))
+	return err
+}
+
+// RecordCall attributes a call to the variant named in its provider
+// metadata. The first time a call on a running inbound experiment is
+// recorded, the number is switched to a newly picked variant for the next
+// caller.
+func (s *ExperimentService) RecordCall(ctx context.Context, call *domain.Call) error {
+	experimentID, variantID, ok := domain.ExperimentTagFromMetadata(call.ProviderMetadata)
+	if !ok {
+		return nil
+	}
+
+	experiment, err := s.repo.GetByID(ctx, experimentID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	variant := experiment.Variant(variantID)
+	if variant == nil {
+		return nil
+	}
+
+	recorded, err := s.repo.RecordAssignment(ctx, domain.NewExperimentAssignment(variant, call.ID))
+	if err != nil {
+		return err
+	}
+	if recorded && experiment.Direction == domain.ExperimentDirectionInbound && experiment.IsRunning() {
+		return s.applyInboundVariant(ctx, experiment, experiment.PickVariant(s.random()))
+	}
+	return nil
+}
+
+// applyInboundVariant configures an inbound experiment's number with a
+// variant's prompt, tagging the calls it answers with the variant.
+func (s *ExperimentService) applyInboundVariant(ctx context.Context, experiment *domain.Experiment, variant *domain.ExperimentVariant) error {
+	if s.inbound == nil {
+		return apperrors.New(apperrors.CodeConfig, "inbound experiments are not available without a voice provider")
+	}
+
+	prompt, err := s.prompts.GetByID(ctx, variant.PromptID)
+	if err != nil {
+		return fmt.Errorf("failed to get variant prompt: %w", err)
+	}
+
+	// Answer in the language of the number's country when the prompt has
+	// been localized for it
+	prompt = prompt.Localize(domain.LanguageForNumber(experiment.PhoneNumber))
+
+	config := inboundConfigFromPrompt(prompt)
+	config.Metadata = map[string]interface{}{
+		domain.ExperimentMetadataKey:        experiment.ID.String(),
+		domain.ExperimentVariantMetadataKey: variant.ID.String(),
+	}
+	if prompt.Language != "" {
+		config.Metadata[domain.LanguageMetadataKey] = prompt.Language
+	}
+
+	_, err = s.inbound.ConfigureInboundAgent(ctx, experiment.PhoneNumber, config)
+	if err != nil && !errors.Is(err, ErrBlandWriteQueued) {
+		return fmt.Errorf("failed to configure inbound number: %w", err)
+	}
+
+	s.logger.Debug("inbound experiment variant applied",
+		zap.String("experiment_id", experiment.ID.String()),
+		zap.String("variant", variant.Name),
+		zap.String("phone_number", experiment.PhoneNumber),
+	)
+	return nil
+}
+
+func (s *ExperimentService) transitionError(experiment *domain.Experiment, action string, err error) error {
+	if errors.Is(err, domain.ErrInvalidExperimentTransition) {
+		return apperrors.New(apperrors.CodeConflict, fmt.Sprintf("experiment cannot be %s while %s", action, experiment.Status))
+	}
+	return err
+}
+
+// experimentsOverlap describes why two experiments cannot run together, or
+// returns "" if they can.
+func experimentsOverlap(a, b *domain.Experiment) string {
+	if a.ID == b.ID || a.Direction != b.Direction {
+		return ""
+	}
+	if a.Direction == domain.ExperimentDirectionInbound {
+		if a.PhoneNumber == b.PhoneNumber {
+			return "on " + a.PhoneNumber
+		}
+		return ""
+	}
+	for _, v := range a.Variants {
+		if b.HasPrompt(v.PromptID) {
+			return "with one of these prompts"
+		}
+	}
+	return ""
+}
+
+// inboundConfigFromPrompt builds the inbound agent settings for a prompt.
+func inboundConfigFromPrompt(prompt *domain.Prompt) *bland.InboundConfig {
+	config := &bland.InboundConfig{
+		Task:              prompt.Task,
+		Voice:             prompt.Voice,
+		Language:          prompt.Language,
+		Model:             prompt.Model,
+		FirstSentence:     prompt.FirstSentence,
+		WaitForGreeting:   prompt.WaitForGreeting,
+		Record:            prompt.Record,
+		SummaryPrompt:     prompt.SummaryPrompt,
+		AnalysisSchema:    prompt.AnalysisSchema,
+		Keywords:          prompt.Keywords,
+		KnowledgeBases:    prompt.KnowledgeBaseIDs,
+		Tools:             prompt.CustomToolIDs,
+		NoiseCancellation: prompt.NoiseCancellation,
+	}
+	if prompt.Temperature != nil {
+		config.Temperature = *prompt.Temperature
+	}
+	if prompt.InterruptionThreshold != nil {
+		config.InterruptionThreshold = *prompt.InterruptionThreshold
+	}
+	if prompt.MaxDuration != nil {
+		config.MaxDuration = *prompt.MaxDuration
+	}
+	if prompt.BackgroundTrack != nil {
+		config.BackgroundTrack = *prompt.BackgroundTrack
+	}
+	return config
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockExperimentRepository is an in-memory ExperimentRepository.
+type MockExperimentRepository struct {
+	domain.ExperimentRepository
+	experiments map[uuid.UUID]*domain.Experiment
+	assignments map[uuid.UUID]*domain.ExperimentAssignment
+}
+
+func NewMockExperimentRepository() *MockExperimentRepository {
+	return &MockExperimentRepository{
+		experiments: make(map[uuid.UUID]*domain.Experiment),
+		assignments: make(map[uuid.UUID]*domain.ExperimentAssignment),
+	}
+}
+
+func (m *MockExperimentRepository) Create(ctx context.Context, e *domain.Experiment) error {
+	cp := *e
+	m.experiments[e.ID] = &cp
+	return nil
+}
+
+func (m *MockExperimentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Experiment, error) {
+	e, ok := m.experiments[id]
+	if !ok {
+		return nil, apperrors.NotFound("experiment")
+	}
+	cp := *e
+	return &cp, nil
+}
+
+func (m *MockExperimentRepository) Update(ctx context.Context, e *domain.Experiment) error {
+	cp := *e
+	m.experiments[e.ID] = &cp
+	return nil
+}
+
+func (m *MockExperimentRepository) ListRunning(ctx context.Context) ([]*domain.Experiment, error) {
+	var running []*domain.Experiment
+	for _, e := range m.experiments {
+		if e.IsRunning() {
+			cp := *e
+			running = append(running, &cp)
+		}
+	}
+	return running, nil
+}
+
+func (m *MockExperimentRepository) RecordAssignment(ctx context.Context, a *domain.ExperimentAssignment) (bool, error) {
+	if _, ok := m.assignments[a.CallID]; ok {
+		return false, nil
+	}
+	m.assignments[a.CallID] = a
+	return true, nil
+}
+
+type fakeInboundConfigurer struct {
+	configs []*bland.InboundConfig
+}
+
+func (f *fakeInboundConfigurer) ConfigureInboundAgent(ctx context.Context, phoneNumberID string, config *bland.InboundConfig) (*bland.PhoneNumber, error) {
+	f.configs = append(f.configs, config)
+	return &bland.PhoneNumber{}, nil
+}
+
+func newTestExperimentService() (*ExperimentService, *MockExperimentRepository, *fakeInboundConfigurer, []*domain.Prompt) {
+	prompts := []*domain.Prompt{
+		domain.NewPrompt("Control", "Ask about the project."),
+		domain.NewPrompt("Challenger", "Ask about the budget first."),
+		domain.NewPrompt("Other", "Something else."),
+	}
+	repo := NewMockExperimentRepository()
+	inbound := &fakeInboundConfigurer{}
+	svc := NewExperimentService(repo, NewMockPromptRepository(prompts...), zap.NewNop())
+	svc.SetInboundConfigurer(inbound)
+	return svc, repo, inbound, prompts
+}
+
+func newTestExperimentRequest(direction domain.ExperimentDirection, prompts ...*domain.Prompt) *CreateExperimentRequest {
+	req := &CreateExperimentRequest{Name: "Opening line", Direction: direction}
+	if direction == domain.ExperimentDirectionInbound {
+		req.PhoneNumber = "+15551234567"
+	}
+	for _, p := range prompts {
+		req.Variants = append(req.Variants, ExperimentVariantRequest{PromptID: p.ID, Weight: 100 / len(prompts)})
+	}
+	return req
+}
+
+func TestExperimentService_CreateExperiment_UnknownPrompt(t *testing.T) {
+	svc, _, _, prompts := newTestExperimentService()
+	missing := domain.NewPrompt("Missing", "Not stored.")
+
+	_, err := svc.CreateExperiment(context.Background(), newTestExperimentRequest(domain.ExperimentDirectionOutbound, prompts[0], missing))
+	if !apperrors.IsUserError(err) {
+		t.Errorf("CreateExperiment() error = %v, want validation error", err)
+	}
+}
+
+func TestExperimentService_AssignOutbound(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _, prompts := newTestExperimentService()
+
+	experiment, err := svc.CreateExperiment(ctx, newTestExperimentRequest(domain.ExperimentDirectionOutbound, prompts[0], prompts[1]))
+	if err != nil {
+		t.Fatalf("CreateExperiment() error = %v", err)
+	}
+
+	// Drafts do not split calls
+	if v, _ := svc.AssignOutbound(ctx, prompts[0].ID); v != nil {
+		t.Errorf("AssignOutbound() on a draft = %v, want nil", v)
+	}
+
+	if _, err := svc.StartExperiment(ctx, experiment.ID); err != nil {
+		t.Fatalf("StartExperiment() error = %v", err)
+	}
+
+	svc.random = func() float64 { return 0.75 }
+	v, err := svc.AssignOutbound(ctx, prompts[0].ID)
+	if err != nil || v == nil || v.PromptID != prompts[1].ID {
+		t.Errorf("AssignOutbound() = %v, %v, want the second variant", v, err)
+	}
+
+	if v, _ := svc.AssignOutbound(ctx, prompts[2].ID); v != nil {
+		t.Errorf("AssignOutbound() for an untested prompt = %v, want nil", v)
+	}
+}
+
+func TestExperimentService_StartExperiment_Overlap(t *testing.T) {
+	ctx := context.Background()
+	svc, _, _, prompts := newTestExperimentService()
+
+	first, _ := svc.CreateExperiment(ctx, newTestExperimentRequest(domain.ExperimentDirectionOutbound, prompts[0], prompts[1]))
+	second, _ := svc.CreateExperiment(ctx, newTestExperimentRequest(domain.ExperimentDirectionOutbound, prompts[1], prompts[2]))
+
+	if _, err := svc.StartExperiment(ctx, first.ID); err != nil {
+		t.Fatalf("StartExperiment() error = %v", err)
+	}
+	_, err := svc.StartExperiment(ctx, second.ID)
+	if apperrors.GetHTTPStatus(err) != 409 {
+		t.Errorf("StartExperiment() sharing a prompt error = %v, want conflict", err)
+	}
+
+	if err := svc.DeleteExperiment(ctx, first.ID); apperrors.GetHTTPStatus(err) != 409 {
+		t.Errorf("DeleteExperiment() while running error = %v, want conflict", err)
+	}
+}
+
+func TestExperimentService_RecordCall_Inbound(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, inbound, prompts := newTestExperimentService()
+
+	experiment, err := svc.CreateExperiment(ctx, newTestExperimentRequest(domain.ExperimentDirectionInbound, prompts[0], prompts[1]))
+	if err != nil {
+		t.Fatalf("CreateExperiment() error = %v", err)
+	}
+	svc.random = func() float64 { return 0 }
+	if _, err := svc.StartExperiment(ctx, experiment.ID); err != nil {
+		t.Fatalf("StartExperiment() error = %v", err)
+	}
+	if len(inbound.configs) != 1 || inbound.configs[0].Task != prompts[0].Task {
+		t.Fatalf("number not configured with the first variant on start: %+v", inbound.configs)
+	}
+	tag := inbound.configs[0].Metadata
+
+	call := &domain.Call{
+		ID:               uuid.New(),
+		ProviderMetadata: map[string]interface{}{"call_id": "abc", "metadata": tag},
+	}
+
+	svc.random = func() float64 { return 0.99 }
+	if err := svc.RecordCall(ctx, call); err != nil {
+		t.Fatalf("RecordCall() error = %v", err)
+	}
+	a := repo.assignments[call.ID]
+	if a == nil || a.VariantID != experiment.Variants[0].ID {
+		t.Errorf("assignment = %+v, want first variant", a)
+	}
+	if len(inbound.configs) != 2 || inbound.configs[1].Task != prompts[1].Task {
+		t.Errorf("number not switched to the next variant: %d configs", len(inbound.configs))
+	}
+
+	// Later webhooks for the same call do not switch the number again
+	if err := svc.RecordCall(ctx, call); err != nil {
+		t.Fatalf("RecordCall() error = %v", err)
+	}
+	if len(inbound.configs) != 2 {
+		t.Errorf("number switched again on a repeated webhook: %d configs", len(inbound.configs))
+	}
+
+	// Stopping puts the control back on the number
+	if _, err := svc.StopExperiment(ctx, experiment.ID); err != nil {
+		t.Fatalf("StopExperiment() error = %v", err)
+	}
+	if last := inbound.configs[len(inbound.configs)-1]; last.Task != prompts[0].Task {
+		t.Errorf("control not restored on stop, task = %q", last.Task)
+	}
+}
+
+func TestExperimentService_RecordCall_Untagged(t *testing.T) {
+	svc, repo, _, _ := newTestExperimentService()
+
+	call := &domain.Call{ID: uuid.New(), ProviderMetadata: map[string]interface{}{"call_id": "abc"}}
+	if err := svc.RecordCall(context.Background(), call); err != nil {
+		t.Fatalf("RecordCall() error = %v", err)
+	}
+	if len(repo.assignments) != 0 {
+		t.Errorf("untagged call was assigned")
+	}
+}
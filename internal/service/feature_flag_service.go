@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/cache"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// featureFlagsCacheKey is the single cache entry holding every flag.
+const featureFlagsCacheKey = "all"
+
+// featureFlagsContextKey is the context key for the flags evaluated for a
+// request.
+type featureFlagsContextKey struct{}
+
+// WithFeatureFlags returns a context carrying the flags evaluated for the
+// request's user.
+func WithFeatureFlags(ctx context.Context, flags domain.FeatureFlags) context.Context {
+	return context.WithValue(ctx, featureFlagsContextKey{}, flags)
+}
+
+// FeatureFlagsFromContext returns the flags evaluated for the request, or an
+// empty set when none were.
+func FeatureFlagsFromContext(ctx context.Context) domain.FeatureFlags {
+	flags, _ := ctx.Value(featureFlagsContextKey{}).(domain.FeatureFlags)
+	if flags == nil {
+		return domain.FeatureFlags{}
+	}
+	return flags
+}
+
+// FeatureEnabled reports whether a flag is on for the request's user.
+func FeatureEnabled(ctx context.Context, key string) bool {
+	return FeatureFlagsFromContext(ctx).Enabled(key)
+}
+
+// FeatureFlagService manages feature flags and evaluates them for users.
+// Flags are cached, so a change made on another instance takes effect
+// there once the cache expires.
+type FeatureFlagService struct {
+	repo        domain.FeatureFlagRepository
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+	cache       *cache.Cache[string, []*domain.FeatureFlag]
+}
+
+// NewFeatureFlagService creates a new FeatureFlagService. auditLogger may be nil.
+func NewFeatureFlagService(repo domain.FeatureFlagRepository, auditLogger *audit.Logger, logger *zap.Logger) *FeatureFlagService {
+	return &FeatureFlagService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		logger:      logger,
+		cache:       cache.New[string, []*domain.FeatureFlag]("feature_flags", DefaultCacheTTL, nil),
+	}
+}
+
+// SetCacheTTL sets how long flags are cached. Zero disables the cache.
+func (s *FeatureFlagService) SetCacheTTL(ttl time.Duration) {
+	s.cache = cache.New[string, []*domain.FeatureFlag]("feature_flags", ttl, nil)
+}
+
+// FeatureFlagRequest holds the fields for creating or replacing a feature
+// flag. The key is only read when creating.
+type FeatureFlagRequest struct {
+	Key            string      `json:"key,omitempty"`
+	Description    string      `json:"description"`
+	Enabled        bool        `json:"enabled"`
+	RolloutPercent int         `json:"rollout_percent"`
+	UserIDs        []uuid.UUID `json:"user_ids"`
+}
+
+// ListFlags returns all feature flags ordered by key.
+func (s *FeatureFlagService) ListFlags(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	return s.repo.List(ctx)
+}
+
+// GetFlag retrieves a feature flag by key.
+func (s *FeatureFlagService) GetFlag(ctx context.Context, key string) (*domain.FeatureFlag, error) {
+	return s.repo.GetByKey(ctx, key)
+}
+
+// CreateFlag validates and stores a new feature flag.
+func (s *FeatureFlagService) CreateFlag(ctx context.Context, actor UserActor, req *FeatureFlagRequest) (*domain.FeatureFlag, error) {
+	flag := domain.NewFeatureFlag(req.Key)
+	if err := applyFeatureFlagRequest(flag, req, actor); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.cache.Clear()
+
+	s.logger.Info("feature flag created",
+		zap.String("key", flag.Key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Int("rollout_percent", flag.RolloutPercent),
+	)
+	if s.auditLogger != nil {
+		s.auditLogger.FeatureFlagChanged(ctx, actor.id(), actor.email(), flag.Key, actor.IP, actor.RequestID, nil, flag)
+	}
+	return flag, nil
+}
+
+// UpdateFlag replaces the settings of a feature flag.
+func (s *FeatureFlagService) UpdateFlag(ctx context.Context, actor UserActor, key string, req *FeatureFlagRequest) (*domain.FeatureFlag, error) {
+	flag, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	before := *flag
+
+	if err := applyFeatureFlagRequest(flag, req, actor); err != nil {
+		return nil, err
+	}
+	flag.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, flag); err != nil {
+		return nil, err
+	}
+	s.cache.Clear()
+
+	s.logger.Info("feature flag updated",
+		zap.String("key", flag.Key),
+		zap.Bool("enabled", flag.Enabled),
+		zap.Int("rollout_percent", flag.RolloutPercent),
+	)
+	if s.auditLogger != nil {
+		s.auditLogger.FeatureFlagChanged(ctx, actor.id(), actor.email(), flag.Key, actor.IP, actor.RequestID, &before, flag)
+	}
+	return flag, nil
+}
+
+// SetEnabled flips a feature flag's master switch, keeping its rollout.
+func (s *FeatureFlagService) SetEnabled(ctx context.Context, actor UserActor, key string, enabled bool) (*domain.FeatureFlag, error) {
+	flag, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdateFlag(ctx, actor, key, &FeatureFlagRequest{
+		Description:    flag.Description,
+		Enabled:        enabled,
+		RolloutPercent: flag.RolloutPercent,
+		UserIDs:        flag.UserIDs,
+	})
+}
+
+// DeleteFlag removes a feature flag. Code still checking it sees it off.
+func (s *FeatureFlagService) DeleteFlag(ctx context.Context, actor UserActor, key string) error {
+	if err := s.repo.Delete(ctx, key); err != nil {
+		return err
+	}
+	s.cache.Clear()
+
+	s.logger.Info("feature flag deleted", zap.String("key", key))
+	if s.auditLogger != nil {
+		s.auditLogger.FeatureFlagDeleted(ctx, actor.id(), actor.email(), key, actor.IP, actor.RequestID)
+	}
+	return nil
+}
+
+// Evaluate returns every flag's state for a user; userID is nil for work
+// done without one. Flags fail closed: if they can't be loaded, all are off.
+func (s *FeatureFlagService) Evaluate(ctx context.Context, userID *uuid.UUID) domain.FeatureFlags {
+	flags, err := s.flags(ctx)
+	if err != nil {
+		s.logger.Warn("failed to load feature flags; treating all as off", zap.Error(err))
+		return domain.FeatureFlags{}
+	}
+
+	result := make(domain.FeatureFlags, len(flags))
+	for _, flag := range flags {
+		result[flag.Key] = flag.EnabledFor(userID)
+	}
+	return result
+}
+
+// IsEnabled reports whether one flag is on for a user.
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, key string, userID *uuid.UUID) bool {
+	return s.Evaluate(ctx, userID).Enabled(key)
+}
+
+// flags returns every flag, from the cache when it is fresh.
+func (s *FeatureFlagService) flags(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	if flags, ok := s.cache.Get(featureFlagsCacheKey); ok {
+		return flags, nil
+	}
+	flags, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Set(featureFlagsCacheKey, flags)
+	return flags, nil
+}
+
+// applyFeatureFlagRequest copies a request onto a flag and validates it.
+func applyFeatureFlagRequest(flag *domain.FeatureFlag, req *FeatureFlagRequest, actor UserActor) error {
+	flag.Description = req.Description
+	flag.Enabled = req.Enabled
+	flag.RolloutPercent = req.RolloutPercent
+	flag.UserIDs = append([]uuid.UUID(nil), req.UserIDs...)
+	flag.UpdatedBy = nil
+	if actor.User != nil {
+		id := actor.User.ID
+		flag.UpdatedBy = &id
+	}
+	if err := flag.Validate(); err != nil {
+		return apperrors.ValidationFailed(err.Error())
+	}
+	return nil
+}
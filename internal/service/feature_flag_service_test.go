@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubFeatureFlagRepo struct {
+	flags   map[string]*domain.FeatureFlag
+	lists   int // Calls to List
+	listErr error
+}
+
+func newStubFeatureFlagRepo() *stubFeatureFlagRepo {
+	return &stubFeatureFlagRepo{flags: map[string]*domain.FeatureFlag{}}
+}
+
+func (r *stubFeatureFlagRepo) Create(ctx context.Context, flag *domain.FeatureFlag) error {
+	if _, ok := r.flags[flag.Key]; ok {
+		return apperrors.New(apperrors.CodeAlreadyExists, "a feature flag with this key already exists")
+	}
+	stored := *flag
+	r.flags[flag.Key] = &stored
+	return nil
+}
+
+func (r *stubFeatureFlagRepo) GetByKey(ctx context.Context, key string) (*domain.FeatureFlag, error) {
+	flag, ok := r.flags[key]
+	if !ok {
+		return nil, apperrors.NotFound("feature flag")
+	}
+	found := *flag
+	return &found, nil
+}
+
+func (r *stubFeatureFlagRepo) Update(ctx context.Context, flag *domain.FeatureFlag) error {
+	if _, ok := r.flags[flag.Key]; !ok {
+		return apperrors.NotFound("feature flag")
+	}
+	stored := *flag
+	r.flags[flag.Key] = &stored
+	return nil
+}
+
+func (r *stubFeatureFlagRepo) Delete(ctx context.Context, key string) error {
+	if _, ok := r.flags[key]; !ok {
+		return apperrors.NotFound("feature flag")
+	}
+	delete(r.flags, key)
+	return nil
+}
+
+func (r *stubFeatureFlagRepo) List(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	r.lists++
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	var flags []*domain.FeatureFlag
+	for _, flag := range r.flags {
+		listed := *flag
+		flags = append(flags, &listed)
+	}
+	return flags, nil
+}
+
+func TestFeatureFlagService_CreateAndToggle(t *testing.T) {
+	repo := newStubFeatureFlagRepo()
+	svc := NewFeatureFlagService(repo, nil, zap.NewNop())
+	ctx := context.Background()
+	admin := &domain.User{ID: uuid.New(), Email: "admin@example.com", Role: domain.UserRoleAdmin}
+	actor := UserActor{User: admin}
+	user := uuid.New()
+
+	if _, err := svc.CreateFlag(ctx, actor, &FeatureFlagRequest{Key: "Bad Key"}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("CreateFlag() with a bad key error = %v, want validation failure", err)
+	}
+	flag, err := svc.CreateFlag(ctx, actor, &FeatureFlagRequest{Key: "pricing.new_engine", UserIDs: []uuid.UUID{user}})
+	if err != nil {
+		t.Fatalf("CreateFlag() error = %v", err)
+	}
+	if flag.UpdatedBy == nil || *flag.UpdatedBy != admin.ID {
+		t.Errorf("UpdatedBy = %v, want the admin", flag.UpdatedBy)
+	}
+	if _, err := svc.CreateFlag(ctx, actor, &FeatureFlagRequest{Key: "pricing.new_engine"}); apperrors.GetCode(err) != apperrors.CodeAlreadyExists {
+		t.Errorf("CreateFlag() duplicate error = %v, want already exists", err)
+	}
+
+	if svc.IsEnabled(ctx, "pricing.new_engine", &user) {
+		t.Error("disabled flag is on")
+	}
+	if _, err := svc.SetEnabled(ctx, actor, "pricing.new_engine", true); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	// The toggle clears the cache, so it takes effect at once.
+	if !svc.IsEnabled(ctx, "pricing.new_engine", &user) {
+		t.Error("flag is off for a listed user after being enabled")
+	}
+	if got := repo.flags["pricing.new_engine"].UserIDs; len(got) != 1 || got[0] != user {
+		t.Errorf("UserIDs = %v, want the listed user kept", got)
+	}
+
+	if err := svc.DeleteFlag(ctx, actor, "pricing.new_engine"); err != nil {
+		t.Fatalf("DeleteFlag() error = %v", err)
+	}
+	if svc.IsEnabled(ctx, "pricing.new_engine", &user) {
+		t.Error("deleted flag is on")
+	}
+}
+
+func TestFeatureFlagService_Evaluate(t *testing.T) {
+	repo := newStubFeatureFlagRepo()
+	svc := NewFeatureFlagService(repo, nil, zap.NewNop())
+	ctx := context.Background()
+	repo.flags["everyone"] = &domain.FeatureFlag{Key: "everyone", Enabled: true, RolloutPercent: 100}
+	repo.flags["nobody"] = &domain.FeatureFlag{Key: "nobody", RolloutPercent: 100}
+
+	flags := svc.Evaluate(ctx, nil)
+	if !flags.Enabled("everyone") || flags.Enabled("nobody") || flags.Enabled("missing") {
+		t.Errorf("Evaluate() = %v", flags)
+	}
+	svc.Evaluate(ctx, nil)
+	if repo.lists != 1 {
+		t.Errorf("List called %d times, want the flags cached after the first", repo.lists)
+	}
+
+	ctx = WithFeatureFlags(ctx, flags)
+	if !FeatureEnabled(ctx, "everyone") {
+		t.Error("FeatureEnabled() = false for a flag set in the context")
+	}
+	if FeatureEnabled(context.Background(), "everyone") {
+		t.Error("FeatureEnabled() = true without flags in the context")
+	}
+
+	// Flags fail closed when they can't be loaded.
+	repo.listErr = errors.New("connection refused")
+	svc.SetCacheTTL(0)
+	if flags := svc.Evaluate(context.Background(), nil); len(flags) != 0 {
+		t.Errorf("Evaluate() = %v after a load failure, want no flags on", flags)
+	}
+}
@@ -0,0 +1,439 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// FollowUpSender reaches customers about their quotes.
+type FollowUpSender interface {
+	SendQuoteFollowUpSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error)
+	InitiateQuoteFollowUp(ctx context.Context, phoneNumber, quoteID string, quoteSummary string) (*InitiateCallResponse, error)
+}
+
+// FollowUpDoNotCall reports whether a number is on the do-not-call list.
+// ComplianceService implements it.
+type FollowUpDoNotCall interface {
+	CheckDoNotCall(ctx context.Context, phoneNumber string) error
+}
+
+// FollowUpService schedules a sequence of follow-up texts and calls when a
+// quote is sent and carries them out in the background. A sequence stops
+// when the quote is accepted or declined, the customer opts out, or the
+// customer calls back. Steps due in quiet hours wait until quiet hours end.
+type FollowUpService struct {
+	repo      domain.FollowUpRepository
+	quotes    domain.QuoteRepository
+	calls     domain.CallRepository
+	customers domain.CustomerRepository
+	sender    FollowUpSender
+	doNotCall FollowUpDoNotCall
+	logger    *zap.Logger
+
+	// Configuration
+	sequence     []domain.FollowUpSequenceStep
+	quietHours   domain.QuietHours
+	pollInterval time.Duration
+	batchSize    int
+	lease        time.Duration
+
+	clock clock.Clock
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// FollowUpServiceConfig holds configuration for the follow-up service.
+type FollowUpServiceConfig struct {
+	Sequence     []domain.FollowUpSequenceStep // Empty disables scheduling new follow-ups
+	QuietHours   domain.QuietHours
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration // How long a worker holds a step before another may retry it
+}
+
+// DefaultFollowUpServiceConfig returns sensible defaults.
+func DefaultFollowUpServiceConfig() *FollowUpServiceConfig {
+	return &FollowUpServiceConfig{
+		PollInterval: 30 * time.Second,
+		BatchSize:    20,
+		Lease:        5 * time.Minute,
+	}
+}
+
+// NewFollowUpService creates a new FollowUpService.
+func NewFollowUpService(
+	repo domain.FollowUpRepository,
+	quotes domain.QuoteRepository,
+	calls domain.CallRepository,
+	customers domain.CustomerRepository,
+	sender FollowUpSender,
+	logger *zap.Logger,
+	config *FollowUpServiceConfig,
+	clk clock.Clock,
+) *FollowUpService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config == nil {
+		config = DefaultFollowUpServiceConfig()
+	}
+
+	return &FollowUpService{
+		repo:         repo,
+		quotes:       quotes,
+		calls:        calls,
+		customers:    customers,
+		sender:       sender,
+		logger:       logger,
+		sequence:     config.Sequence,
+		quietHours:   config.QuietHours,
+		pollInterval: config.PollInterval,
+		batchSize:    config.BatchSize,
+		lease:        config.Lease,
+		clock:        clk,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// SetDoNotCall stops sequences to numbers on the do-not-call list, including
+// customers who texted STOP.
+func (s *FollowUpService) SetDoNotCall(doNotCall FollowUpDoNotCall) {
+	s.doNotCall = doNotCall
+}
+
+// ScheduleQuote schedules the follow-up sequence for a quote sent at sentAt
+// to the customer on the quote's call.
+func (s *FollowUpService) ScheduleQuote(ctx context.Context, callID uuid.UUID, sentAt time.Time) error {
+	if len(s.sequence) == 0 {
+		return nil
+	}
+
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return err
+	}
+	phone := call.CustomerNumber()
+	if phone == "" {
+		s.logger.Warn("quote has no customer number to follow up with", zap.String("call_id", callID.String()))
+		return nil
+	}
+
+	steps := domain.NewFollowUpSteps(callID, phone, sentAt, s.sequence)
+	if err := s.repo.CreateSteps(ctx, steps); err != nil {
+		return fmt.Errorf("failed to schedule follow-ups: %w", err)
+	}
+
+	s.logger.Info("quote follow-ups scheduled",
+		zap.String("call_id", callID.String()),
+		zap.Int("steps", len(steps)),
+	)
+	return nil
+}
+
+// CancelQuote stops a quote's pending follow-ups and returns how many were
+// cancelled.
+func (s *FollowUpService) CancelQuote(ctx context.Context, callID uuid.UUID, reason string) (int, error) {
+	cancelled, err := s.repo.CancelPending(ctx, callID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel follow-ups: %w", err)
+	}
+	if cancelled > 0 {
+		s.logger.Info("quote follow-ups cancelled",
+			zap.String("call_id", callID.String()),
+			zap.Int("steps", cancelled),
+			zap.String("reason", reason),
+		)
+	}
+	return cancelled, nil
+}
+
+// ListSteps returns a quote's follow-up steps in sequence order.
+func (s *FollowUpService) ListSteps(ctx context.Context, callID uuid.UUID) ([]*domain.FollowUpStep, error) {
+	if _, err := s.calls.GetByID(ctx, callID); err != nil {
+		return nil, err
+	}
+	steps, err := s.repo.ListByCallID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if steps == nil {
+		steps = []*domain.FollowUpStep{}
+	}
+	return steps, nil
+}
+
+// Start begins carrying out due follow-up steps.
+func (s *FollowUpService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("follow-up worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting quote follow-up worker",
+		zap.Int("sequence_steps", len(s.sequence)),
+		zap.Duration("poll_interval", s.pollInterval),
+	)
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight steps to finish.
+func (s *FollowUpService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping quote follow-up worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("quote follow-up worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("quote follow-up worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *FollowUpService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.runDue()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.runDue()
+		}
+	}
+}
+
+// runDue carries out the follow-up steps that are due.
+func (s *FollowUpService) runDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	steps, err := s.repo.ClaimDue(ctx, s.clock.Now(), s.lease, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to claim due follow-up steps", zap.Error(err))
+		return
+	}
+
+	for _, step := range steps {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		s.execute(ctx, step)
+	}
+}
+
+// execute carries out one step, or cancels the rest of the sequence if the
+// customer no longer needs following up, and records the outcome.
+func (s *FollowUpService) execute(ctx context.Context, step *domain.FollowUpStep) {
+	logger := s.logger.With(
+		zap.String("step_id", step.ID.String()),
+		zap.String("call_id", step.CallID.String()),
+		zap.String("channel", string(step.Channel)),
+	)
+
+	call, reason, err := s.checkStillWanted(ctx, step)
+	if err != nil {
+		step.MarkFailed(err)
+		logger.Warn("failed to check follow-up step", zap.Error(err))
+		s.update(ctx, logger, step)
+		return
+	}
+	if reason != "" {
+		if _, err := s.CancelQuote(ctx, step.CallID, reason); err != nil {
+			logger.Error("failed to cancel follow-ups", zap.Error(err))
+		}
+		return
+	}
+
+	now := s.clock.Now()
+	if s.quietHours.Contains(now) {
+		step.Defer(s.quietHours.NextAllowed(now))
+		logger.Debug("follow-up step deferred for quiet hours", zap.Time("next_attempt", step.NextAttemptAt))
+		s.update(ctx, logger, step)
+		return
+	}
+
+	ref, err := s.send(ctx, step, call)
+	if cerr, ok := AsComplianceError(err); ok {
+		s.holdForCompliance(ctx, logger, step, cerr)
+		return
+	}
+	if err != nil {
+		step.MarkFailed(err)
+		if step.Status == domain.FollowUpStepStatusFailed {
+			logger.Error("giving up on follow-up step", zap.Error(err), zap.Int("attempts", step.Attempts))
+		} else {
+			logger.Warn("failed to send follow-up step", zap.Error(err), zap.Time("next_attempt", step.NextAttemptAt))
+		}
+	} else {
+		step.MarkSent(ref)
+		logger.Info("follow-up step sent", zap.String("provider_ref", ref))
+	}
+	s.update(ctx, logger, step)
+}
+
+// checkStillWanted loads the step's call and returns a reason to stop
+// following up, if there is one.
+func (s *FollowUpService) checkStillWanted(ctx context.Context, step *domain.FollowUpStep) (*domain.Call, string, error) {
+	quote, err := s.quotes.GetByCallID(ctx, step.CallID)
+	if err != nil {
+		return nil, "", err
+	}
+	if quote.Status != domain.QuoteStatusSent {
+		return nil, "quote " + string(quote.Status), nil
+	}
+
+	call, err := s.calls.GetByID(ctx, step.CallID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if call.CustomerID != nil {
+		customer, err := s.customers.GetByID(ctx, *call.CustomerID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, "", err
+		}
+		if customer != nil && customer.IsOptedOut() {
+			return call, "customer opted out", nil
+		}
+	}
+
+	if s.doNotCall != nil {
+		err := s.doNotCall.CheckDoNotCall(ctx, step.PhoneNumber)
+		if _, ok := AsComplianceError(err); ok {
+			return call, "number on do-not-call list", nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	responded, err := s.customerCalledBack(ctx, step, quote.SentAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if responded {
+		return call, "customer called back", nil
+	}
+	return call, "", nil
+}
+
+// customerCalledBack reports whether the customer has called us since the
+// quote was sent.
+func (s *FollowUpService) customerCalledBack(ctx context.Context, step *domain.FollowUpStep, sentAt *time.Time) (bool, error) {
+	if sentAt == nil {
+		return false, nil
+	}
+	calls, err := s.calls.List(ctx, &domain.CallListFilter{PhoneNumber: step.PhoneNumber, CreatedAfter: sentAt}, 50, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range calls {
+		if c.ID != step.CallID && c.IsInbound() && c.FromNumber == step.PhoneNumber && !c.CreatedAt.Before(*sentAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// send texts or calls the customer and returns the message or call ID.
+func (s *FollowUpService) send(ctx context.Context, step *domain.FollowUpStep, call *domain.Call) (string, error) {
+	quoteNumber := quotepdf.QuoteNumber(step.CallID)
+
+	switch step.Channel {
+	case domain.FollowUpChannelSMS:
+		name := "there"
+		if call.CallerName != nil && strings.TrimSpace(*call.CallerName) != "" {
+			name = strings.TrimSpace(*call.CallerName)
+		}
+		resp, err := s.sender.SendQuoteFollowUpSMS(ctx, step.PhoneNumber, name, quoteNumber)
+		if err != nil {
+			return "", err
+		}
+		return resp.MessageID, nil
+	case domain.FollowUpChannelCall:
+		summary := ""
+		if call.QuoteSummary != nil {
+			summary = *call.QuoteSummary
+		}
+		resp, err := s.sender.InitiateQuoteFollowUp(ctx, step.PhoneNumber, quoteNumber, summary)
+		if err != nil {
+			return "", err
+		}
+		return resp.CallID.String(), nil
+	default:
+		return "", fmt.Errorf("unknown follow-up channel %q", step.Channel)
+	}
+}
+
+// holdForCompliance waits for the calling window to open for a follow-up
+// call that was blocked by it, and stops the sequence for a number on the
+// do-not-call list.
+func (s *FollowUpService) holdForCompliance(ctx context.Context, logger *zap.Logger, step *domain.FollowUpStep, cerr *domain.ComplianceError) {
+	switch {
+	case cerr.Reason == domain.ComplianceReasonDoNotCall:
+		if _, err := s.CancelQuote(ctx, step.CallID, "number on do-not-call list"); err != nil {
+			logger.Error("failed to cancel follow-ups", zap.Error(err))
+		}
+		return
+	case cerr.NextAllowed != nil:
+		step.Defer(*cerr.NextAllowed)
+		logger.Debug("follow-up call deferred for the calling window", zap.Time("next_attempt", step.NextAttemptAt))
+	default:
+		step.MarkFailed(cerr)
+		logger.Warn("follow-up call blocked", zap.Error(cerr))
+	}
+	s.update(ctx, logger, step)
+}
+
+func (s *FollowUpService) update(ctx context.Context, logger *zap.Logger, step *domain.FollowUpStep) {
+	if err := s.repo.Update(ctx, step); err != nil {
+		logger.Error("failed to update follow-up step", zap.Error(err))
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockFollowUpRepository is an in-memory FollowUpRepository.
+type MockFollowUpRepository struct {
+	mu    sync.Mutex
+	steps []*domain.FollowUpStep
+}
+
+func (m *MockFollowUpRepository) CreateSteps(ctx context.Context, steps []*domain.FollowUpStep) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, step := range steps {
+		exists := false
+		for _, s := range m.steps {
+			if s.CallID == step.CallID && s.Position == step.Position {
+				exists = true
+			}
+		}
+		if !exists {
+			cp := *step
+			m.steps = append(m.steps, &cp)
+		}
+	}
+	return nil
+}
+
+func (m *MockFollowUpRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.FollowUpStep, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []*domain.FollowUpStep
+	for _, s := range m.steps {
+		if s.Status == domain.FollowUpStepStatusPending && !s.NextAttemptAt.After(now) && len(due) < limit {
+			s.NextAttemptAt = now.Add(lease)
+			cp := *s
+			due = append(due, &cp)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockFollowUpRepository) Update(ctx context.Context, step *domain.FollowUpStep) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, s := range m.steps {
+		if s.ID == step.ID {
+			cp := *step
+			m.steps[i] = &cp
+			return nil
+		}
+	}
+	return apperrors.NotFound("follow-up step")
+}
+
+func (m *MockFollowUpRepository) CancelPending(ctx context.Context, callID uuid.UUID, reason string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cancelled := 0
+	for _, s := range m.steps {
+		if s.CallID == callID && s.Status == domain.FollowUpStepStatusPending {
+			s.Cancel(reason)
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
+
+func (m *MockFollowUpRepository) ListByCallID(ctx context.Context, callID uuid.UUID) ([]*domain.FollowUpStep, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var steps []*domain.FollowUpStep
+	for _, s := range m.steps {
+		if s.CallID == callID {
+			cp := *s
+			steps = append(steps, &cp)
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Position < steps[j].Position })
+	return steps, nil
+}
+
+// fakeFollowUpSender records the texts and calls it was asked to make.
+type fakeFollowUpSender struct {
+	texts   []string // phone numbers
+	calls   []string
+	callErr error // Returned instead of placing calls when set
+}
+
+func (f *fakeFollowUpSender) SendQuoteFollowUpSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error) {
+	f.texts = append(f.texts, phoneNumber)
+	return &bland.SendSMSResponse{MessageID: "msg-1"}, nil
+}
+
+func (f *fakeFollowUpSender) InitiateQuoteFollowUp(ctx context.Context, phoneNumber, quoteID string, quoteSummary string) (*InitiateCallResponse, error) {
+	if f.callErr != nil {
+		return nil, f.callErr
+	}
+	f.calls = append(f.calls, phoneNumber)
+	return &InitiateCallResponse{CallID: uuid.New()}, nil
+}
+
+type followUpTest struct {
+	svc       *FollowUpService
+	repo      *MockFollowUpRepository
+	calls     *MockCallRepository
+	customers *MockCustomerRepository
+	sender    *fakeFollowUpSender
+	call      *domain.Call
+	sentAt    time.Time
+}
+
+// newFollowUpTest sets up a quote from an inbound call by +15550002222 that
+// was sent at 10 AM UTC, with an SMS after an hour and a call after a day,
+// and quiet hours from 9 PM to 9 AM UTC.
+func newFollowUpTest(t *testing.T) *followUpTest {
+	t.Helper()
+	ctx := context.Background()
+	ft := &followUpTest{
+		repo:      &MockFollowUpRepository{},
+		calls:     NewMockCallRepository(),
+		customers: NewMockCustomerRepository(),
+		sender:    &fakeFollowUpSender{},
+		sentAt:    time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	customer := domain.NewCustomer("+15550002222")
+	if err := ft.customers.Create(ctx, customer); err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	summary := "- Build: $500"
+	ft.call = domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	ft.call.QuoteSummary = &summary
+	ft.call.CustomerID = &customer.ID
+	if err := ft.calls.Create(ctx, ft.call); err != nil {
+		t.Fatalf("create call: %v", err)
+	}
+
+	quotes := NewMockQuoteRepository()
+	quote := domain.NewQuote(ft.call.ID, 500)
+	quote.Status = domain.QuoteStatusSent
+	quote.SentAt = &ft.sentAt
+	quotes.quotes[ft.call.ID] = quote
+
+	sequence, _ := domain.ParseFollowUpSequence("sms:1h,call:24h")
+	quietHours, _ := domain.ParseQuietHours("21:00", "09:00", time.UTC)
+	cfg := DefaultFollowUpServiceConfig()
+	cfg.Sequence = sequence
+	cfg.QuietHours = quietHours
+	ft.svc = NewFollowUpService(ft.repo, quotes, ft.calls, ft.customers, ft.sender, zap.NewNop(), cfg, nil)
+
+	if err := ft.svc.ScheduleQuote(ctx, ft.call.ID, ft.sentAt); err != nil {
+		t.Fatalf("ScheduleQuote() error = %v", err)
+	}
+	return ft
+}
+
+// runAt runs the worker once at the given time.
+func (ft *followUpTest) runAt(now time.Time) {
+	ft.svc.clock = clock.NewMock(now)
+	ft.svc.runDue()
+}
+
+func (ft *followUpTest) statuses(t *testing.T) []domain.FollowUpStepStatus {
+	t.Helper()
+	steps, err := ft.svc.ListSteps(context.Background(), ft.call.ID)
+	if err != nil {
+		t.Fatalf("ListSteps() error = %v", err)
+	}
+	var statuses []domain.FollowUpStepStatus
+	for _, s := range steps {
+		statuses = append(statuses, s.Status)
+	}
+	return statuses
+}
+
+func TestFollowUpService_SendsStepsWhenDue(t *testing.T) {
+	ft := newFollowUpTest(t)
+
+	ft.runAt(ft.sentAt.Add(30 * time.Minute))
+	if len(ft.sender.texts) != 0 {
+		t.Fatalf("texted %v before the first step was due", ft.sender.texts)
+	}
+
+	ft.runAt(ft.sentAt.Add(time.Hour))
+	if len(ft.sender.texts) != 1 || ft.sender.texts[0] != "+15550002222" {
+		t.Fatalf("texts = %v, want one to the customer", ft.sender.texts)
+	}
+
+	ft.runAt(ft.sentAt.Add(24 * time.Hour))
+	if len(ft.sender.calls) != 1 {
+		t.Fatalf("calls = %v, want one follow-up call", ft.sender.calls)
+	}
+	if got := ft.statuses(t); got[0] != domain.FollowUpStepStatusSent || got[1] != domain.FollowUpStepStatusSent {
+		t.Errorf("statuses = %v, want both sent", got)
+	}
+}
+
+func TestFollowUpService_DefersStepsInQuietHours(t *testing.T) {
+	ft := newFollowUpTest(t)
+
+	// The first step came due at 11 AM but the worker only ran at 11 PM
+	late := time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)
+	ft.runAt(late)
+	if len(ft.sender.texts) != 0 {
+		t.Fatalf("texted %v during quiet hours", ft.sender.texts)
+	}
+	steps, _ := ft.repo.ListByCallID(context.Background(), ft.call.ID)
+	if want := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC); !steps[0].NextAttemptAt.Equal(want) {
+		t.Errorf("next attempt %v, want %v", steps[0].NextAttemptAt, want)
+	}
+	if steps[0].Attempts != 0 {
+		t.Errorf("attempts = %d, want deferral not to count as an attempt", steps[0].Attempts)
+	}
+
+	ft.runAt(time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC))
+	if len(ft.sender.texts) != 1 {
+		t.Errorf("texts = %v, want the deferred text sent when quiet hours end", ft.sender.texts)
+	}
+}
+
+func TestFollowUpService_StopsWhenCustomerResponds(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(t *testing.T, ft *followUpTest)
+	}{
+		{"opted out", func(t *testing.T, ft *followUpTest) {
+			customer, _ := ft.customers.GetByID(context.Background(), *ft.call.CustomerID)
+			customer.SetOptedOut(true)
+			if err := ft.customers.Update(context.Background(), customer); err != nil {
+				t.Fatalf("update customer: %v", err)
+			}
+		}},
+		{"called back", func(t *testing.T, ft *followUpTest) {
+			callBack := domain.NewCall("p2", "bland", "+15550001111", "+15550002222")
+			callBack.CreatedAt = ft.sentAt.Add(30 * time.Minute)
+			if err := ft.calls.Create(context.Background(), callBack); err != nil {
+				t.Fatalf("create call: %v", err)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := newFollowUpTest(t)
+			tt.respond(t, ft)
+
+			ft.runAt(ft.sentAt.Add(time.Hour))
+			if len(ft.sender.texts) != 0 {
+				t.Errorf("texts = %v, want none", ft.sender.texts)
+			}
+			for i, status := range ft.statuses(t) {
+				if status != domain.FollowUpStepStatusCancelled {
+					t.Errorf("step %d status = %s, want cancelled", i+1, status)
+				}
+			}
+		})
+	}
+}
+
+func TestQuoteService_FollowUpsStartWhenSentAndStopWhenDecided(t *testing.T) {
+	ctx := context.Background()
+	svc, _, calls, callID := newQuoteTestService(t, "- Build: $500", QuoteApprovalConfig{})
+	repo := &MockFollowUpRepository{}
+	followUps := NewFollowUpService(repo, NewMockQuoteRepository(), calls, NewMockCustomerRepository(), &fakeFollowUpSender{}, zap.NewNop(), &FollowUpServiceConfig{
+		Sequence: []domain.FollowUpSequenceStep{{Channel: domain.FollowUpChannelSMS, Delay: time.Hour}},
+	}, nil)
+	svc.SetFollowUps(followUps)
+	staff := quoteActor("staff@example.com")
+
+	for _, step := range []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){svc.Submit, svc.Approve, svc.MarkSent} {
+		if _, err := step(ctx, callID, staff, ""); err != nil {
+			t.Fatalf("transition: %v", err)
+		}
+	}
+	steps, _ := repo.ListByCallID(ctx, callID)
+	if len(steps) != 1 || steps[0].Status != domain.FollowUpStepStatusPending {
+		t.Fatalf("steps = %+v, want one pending step once sent", steps)
+	}
+
+	if _, err := svc.MarkDeclined(ctx, callID, staff, ""); err != nil {
+		t.Fatalf("decline: %v", err)
+	}
+	steps, _ = repo.ListByCallID(ctx, callID)
+	if steps[0].Status != domain.FollowUpStepStatusCancelled || steps[0].CancelReason != "quote declined" {
+		t.Errorf("step = %+v, want cancelled because the quote was declined", steps[0])
+	}
+}
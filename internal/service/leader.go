@@ -0,0 +1,18 @@
+package service
+
+// Elector decides whether this instance runs the workers that pick up
+// shared work with plain reads, which would be done twice if every replica
+// ran them. database.LeaderElector implements it.
+//
+// Services with such a worker take one through SetElector, before Start.
+// Without one, the instance is assumed to be the only one and always does
+// the work.
+type Elector interface {
+	IsLeader() bool
+}
+
+// isLeader reports whether elector lets this instance do the work. Without
+// an elector the instance is assumed to be the only one.
+func isLeader(elector Elector) bool {
+	return elector == nil || elector.IsLeader()
+}
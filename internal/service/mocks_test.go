@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -108,6 +110,9 @@ func (m *MockCallRepository) List(ctx context.Context, filter *domain.CallListFi
 		if filter != nil && filter.Status != nil && call.Status != *filter.Status {
 			continue
 		}
+		if filter != nil && filter.CustomerID != nil && (call.CustomerID == nil || *call.CustomerID != *filter.CustomerID) {
+			continue
+		}
 		if filter != nil && strings.TrimSpace(filter.Search) != "" {
 			search := strings.ToLower(strings.TrimSpace(filter.Search))
 			target := strings.ToLower(call.PhoneNumber + call.FromNumber + call.ProviderCallID)
@@ -144,6 +149,9 @@ func (m *MockCallRepository) Count(ctx context.Context, filter *domain.CallListF
 		if filter != nil && filter.Status != nil && call.Status != *filter.Status {
 			continue
 		}
+		if filter != nil && filter.CustomerID != nil && (call.CustomerID == nil || *call.CustomerID != *filter.CustomerID) {
+			continue
+		}
 		if filter != nil && strings.TrimSpace(filter.Search) != "" {
 			search := strings.ToLower(strings.TrimSpace(filter.Search))
 			target := strings.ToLower(call.PhoneNumber + call.FromNumber + call.ProviderCallID)
@@ -174,6 +182,37 @@ func (m *MockCallRepository) SetQuoteJobID(ctx context.Context, callID uuid.UUID
 	return apperrors.NotFound("call")
 }
 
+func (m *MockCallRepository) SetTags(ctx context.Context, callID uuid.UUID, tags *domain.CallTags) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if call, ok := m.calls[callID]; ok {
+		call.Tags = tags
+		return nil
+	}
+	return apperrors.NotFound("call")
+}
+
+func (m *MockCallRepository) SetSpamScore(ctx context.Context, callID uuid.UUID, score int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if call, ok := m.calls[callID]; ok {
+		call.SpamScore = &score
+		return nil
+	}
+	return apperrors.NotFound("call")
+}
+
+func (m *MockCallRepository) SetDisposition(ctx context.Context, callID uuid.UUID, disposition domain.CallDisposition, source domain.DispositionSource) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if call, ok := m.calls[callID]; ok {
+		call.Disposition = &disposition
+		call.DispositionSource = &source
+		return nil
+	}
+	return apperrors.NotFound("call")
+}
+
 // MockQuoteGenerator is a mock implementation of QuoteGenerator for testing.
 type MockQuoteGenerator struct {
 	GenerateQuoteCalls int
@@ -257,6 +296,17 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return nil, apperrors.NotFound("user")
 }
 
+func (m *MockUserRepository) GetBySSOIdentity(ctx context.Context, issuer, subject string) (*domain.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, user := range m.users {
+		if user.SSOLinked() && user.SSOIssuer == issuer && user.SSOSubject == subject {
+			return user, nil
+		}
+	}
+	return nil, apperrors.NotFound("user")
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -272,12 +322,58 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[id]
+	if !ok {
+		return apperrors.NotFound("user")
+	}
+	delete(m.users, id)
+	delete(m.byEmail, user.Email)
+	return nil
+}
+
 func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	return int64(len(m.users)), nil
 }
 
+func (m *MockUserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	users := make([]*domain.User, 0, len(m.users))
+	for _, user := range m.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+	return users, nil
+}
+
+func (m *MockUserRepository) AdvanceTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[id]
+	if !ok || counter <= user.TOTPLastCounter {
+		return apperrors.NotFound("user")
+	}
+	user.TOTPLastCounter = counter
+	return nil
+}
+
+func (m *MockUserRepository) CountActiveAdmins(ctx context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var count int64
+	for _, user := range m.users {
+		if user.IsAdmin() && !user.IsDisabled() {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // MockSessionRepository is a mock implementation of domain.SessionRepository for testing.
 type MockSessionRepository struct {
 	mu       sync.RWMutex
@@ -385,3 +481,231 @@ func (m *MockSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.
 	delete(m.byUserID, userID)
 	return nil
 }
+
+// MockRecoveryCodeRepository is a mock implementation of domain.RecoveryCodeRepository for testing.
+type MockRecoveryCodeRepository struct {
+	mu    sync.Mutex
+	codes map[uuid.UUID]map[string]bool // code hash -> used
+}
+
+func NewMockRecoveryCodeRepository() *MockRecoveryCodeRepository {
+	return &MockRecoveryCodeRepository{
+		codes: make(map[uuid.UUID]map[string]bool),
+	}
+}
+
+func (m *MockRecoveryCodeRepository) Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	codes := make(map[string]bool, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes[hash] = false
+	}
+	m.codes[userID] = codes
+	return nil
+}
+
+func (m *MockRecoveryCodeRepository) Use(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	used, ok := m.codes[userID][codeHash]
+	if !ok || used {
+		return apperrors.NotFound("recovery code")
+	}
+	m.codes[userID][codeHash] = true
+	return nil
+}
+
+func (m *MockRecoveryCodeRepository) CountUnused(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, used := range m.codes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockRecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.codes, userID)
+	return nil
+}
+
+// MockUserTokenRepository is a mock implementation of domain.UserTokenRepository for testing.
+type MockUserTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uuid.UUID]*domain.UserToken
+}
+
+func NewMockUserTokenRepository() *MockUserTokenRepository {
+	return &MockUserTokenRepository{
+		tokens: make(map[uuid.UUID]*domain.UserToken),
+	}
+}
+
+func (m *MockUserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *MockUserTokenRepository) GetByHash(ctx context.Context, purpose domain.TokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.tokens {
+		if t.Purpose == purpose && t.TokenHash == tokenHash {
+			copied := *t
+			return &copied, nil
+		}
+	}
+	return nil, apperrors.NotFound("token")
+}
+
+func (m *MockUserTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return apperrors.NotFound("token")
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return nil
+}
+
+func (m *MockUserTokenRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, t := range m.tokens {
+		if t.UserID == userID && t.Purpose == purpose {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+func (m *MockUserTokenRepository) DeleteExpired(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, t := range m.tokens {
+		if t.IsExpired() {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+// MockAccountMailer is a mock implementation of AccountMailer that records links.
+type MockAccountMailer struct {
+	mu               sync.Mutex
+	ResetURLs        []string
+	VerificationURLs []string
+	InvitationURLs   []string
+}
+
+func (m *MockAccountMailer) PasswordReset(ctx context.Context, user *domain.User, resetURL string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ResetURLs = append(m.ResetURLs, resetURL)
+}
+
+func (m *MockAccountMailer) EmailVerification(ctx context.Context, user *domain.User, verifyURL string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.VerificationURLs = append(m.VerificationURLs, verifyURL)
+}
+
+func (m *MockAccountMailer) Invitation(ctx context.Context, user *domain.User, inviteURL string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.InvitationURLs = append(m.InvitationURLs, inviteURL)
+}
+
+// MockAPIKeyRepository is a mock implementation of domain.APIKeyRepository for testing.
+type MockAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]*domain.APIKey
+
+	CreateCalls        int
+	UpdateCalls        int
+	TouchLastUsedCalls int
+
+	CreateError error
+	UpdateError error
+}
+
+func NewMockAPIKeyRepository() *MockAPIKeyRepository {
+	return &MockAPIKeyRepository{
+		keys: make(map[uuid.UUID]*domain.APIKey),
+	}
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.CreateCalls++
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if key, ok := m.keys[id]; ok {
+		return key, nil
+	}
+	return nil, apperrors.NotFound("api key")
+}
+
+func (m *MockAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, apperrors.NotFound("api key")
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]*domain.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]*domain.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		result = append(result, key)
+	}
+	return result, nil
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, key *domain.APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.UpdateCalls++
+	if m.UpdateError != nil {
+		return m.UpdateError
+	}
+	if _, ok := m.keys[key.ID]; !ok {
+		return apperrors.NotFound("api key")
+	}
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.TouchLastUsedCalls++
+	if key, ok := m.keys[id]; ok {
+		key.LastUsedAt = &at
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// NotificationPreferenceService manages the events each user is notified
+// about by email, SMS and Slack, and tells the notifiers who to send each
+// event to.
+type NotificationPreferenceService struct {
+	repo     domain.NotificationPreferenceRepository
+	channels []domain.NotificationChannel
+	logger   *zap.Logger
+
+	clock clock.Clock
+}
+
+// NewNotificationPreferenceService creates a new NotificationPreferenceService.
+// channels are the channels this server can deliver on.
+func NewNotificationPreferenceService(repo domain.NotificationPreferenceRepository, channels []domain.NotificationChannel, logger *zap.Logger, clk clock.Clock) *NotificationPreferenceService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &NotificationPreferenceService{
+		repo:     repo,
+		channels: channels,
+		logger:   logger,
+		clock:    clk,
+	}
+}
+
+// Channels returns the channels users can choose.
+func (s *NotificationPreferenceService) Channels() []domain.NotificationChannel {
+	return s.channels
+}
+
+// Available reports whether the channel can be chosen.
+func (s *NotificationPreferenceService) Available(channel domain.NotificationChannel) bool {
+	for _, c := range s.channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns a user's preferences. Users who never saved any get empty
+// preferences.
+func (s *NotificationPreferenceService) Get(ctx context.Context, user *domain.User) (*domain.NotificationPreferences, error) {
+	prefs, err := s.repo.Get(ctx, user.ID)
+	if apperrors.IsNotFound(err) {
+		return &domain.NotificationPreferences{UserID: user.ID, Email: user.Email}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// Update validates and saves a user's preferences.
+func (s *NotificationPreferenceService) Update(ctx context.Context, user *domain.User, prefs *domain.NotificationPreferences) error {
+	prefs.UserID = user.ID
+	prefs.Email = user.Email
+	prefs.Phone = strings.TrimSpace(prefs.Phone)
+	prefs.SlackWebhookURL = strings.TrimSpace(prefs.SlackWebhookURL)
+
+	for _, channel := range domain.NotificationChannels {
+		if len(prefs.Events(channel)) > 0 && !s.Available(channel) {
+			return apperrors.ValidationFailed(fmt.Sprintf("%s notifications are not available on this server", channel))
+		}
+	}
+	if err := prefs.Validate(); err != nil {
+		return apperrors.ValidationFailed(err.Error())
+	}
+
+	prefs.UpdatedAt = s.clock.Now().UTC()
+	return s.repo.Save(ctx, prefs)
+}
+
+// Recipients returns the addresses of the users who chose to be notified of
+// the event on the channel: email addresses, phone numbers or webhook URLs.
+// Lookup failures are logged, and no one is added.
+func (s *NotificationPreferenceService) Recipients(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) []string {
+	if !s.Available(channel) {
+		return nil
+	}
+	prefs, err := s.repo.ListForEvent(ctx, channel, event)
+	if err != nil {
+		s.logger.Error("failed to load notification preferences",
+			zap.String("channel", string(channel)),
+			zap.String("event", string(event)),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(prefs))
+	var recipients []string
+	for _, p := range prefs {
+		address := p.Address(channel)
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		recipients = append(recipients, address)
+	}
+	return recipients
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockNotificationPreferenceRepository keeps preferences in memory.
+type MockNotificationPreferenceRepository struct {
+	prefs map[uuid.UUID]*domain.NotificationPreferences
+}
+
+func (m *MockNotificationPreferenceRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.NotificationPreferences, error) {
+	p, ok := m.prefs[userID]
+	if !ok {
+		return nil, apperrors.NotFound("notification preferences")
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (m *MockNotificationPreferenceRepository) Save(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	cp := *prefs
+	m.prefs[prefs.UserID] = &cp
+	return nil
+}
+
+func (m *MockNotificationPreferenceRepository) ListForEvent(ctx context.Context, channel domain.NotificationChannel, event domain.NotificationEvent) ([]*domain.NotificationPreferences, error) {
+	var result []*domain.NotificationPreferences
+	for _, p := range m.prefs {
+		if p.Wants(channel, event) {
+			cp := *p
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+func newTestNotificationPreferenceService(channels ...domain.NotificationChannel) *NotificationPreferenceService {
+	repo := &MockNotificationPreferenceRepository{prefs: make(map[uuid.UUID]*domain.NotificationPreferences)}
+	return NewNotificationPreferenceService(repo, channels, zap.NewNop(), nil)
+}
+
+func TestNotificationPreferenceService_UpdateAndRecipients(t *testing.T) {
+	svc := newTestNotificationPreferenceService(domain.NotificationChannelEmail, domain.NotificationChannelSMS)
+	ctx := context.Background()
+	owner := &domain.User{ID: uuid.New(), Email: "owner@example.com"}
+	estimator := &domain.User{ID: uuid.New(), Email: "estimator@example.com"}
+
+	prefs, err := svc.Get(ctx, owner)
+	if err != nil || len(prefs.EmailEvents) != 0 || prefs.Email != "owner@example.com" {
+		t.Fatalf("expected empty preferences for a new user, got %+v, %v", prefs, err)
+	}
+
+	if err := svc.Update(ctx, owner, &domain.NotificationPreferences{
+		EmailEvents: []domain.NotificationEvent{domain.NotificationEventQuoteReady, domain.NotificationEventUsageAlert},
+		SMSEvents:   []domain.NotificationEvent{domain.NotificationEventUsageAlert},
+		Phone:       " +15551230001 ",
+	}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := svc.Update(ctx, estimator, &domain.NotificationPreferences{
+		EmailEvents: []domain.NotificationEvent{domain.NotificationEventQuoteReady},
+	}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if got := svc.Recipients(ctx, domain.NotificationChannelEmail, domain.NotificationEventQuoteReady); len(got) != 2 {
+		t.Errorf("expected both users emailed about quotes, got %v", got)
+	}
+	if got := svc.Recipients(ctx, domain.NotificationChannelSMS, domain.NotificationEventUsageAlert); len(got) != 1 || got[0] != "+15551230001" {
+		t.Errorf("expected the owner texted about usage alerts, got %v", got)
+	}
+	if got := svc.Recipients(ctx, domain.NotificationChannelSlack, domain.NotificationEventQuoteReady); got != nil {
+		t.Errorf("expected no recipients on an unavailable channel, got %v", got)
+	}
+}
+
+func TestNotificationPreferenceService_UpdateRejectsInvalid(t *testing.T) {
+	svc := newTestNotificationPreferenceService(domain.NotificationChannelEmail, domain.NotificationChannelSMS)
+	user := &domain.User{ID: uuid.New(), Email: "owner@example.com"}
+
+	tests := []struct {
+		name  string
+		prefs *domain.NotificationPreferences
+	}{
+		{"unavailable channel", &domain.NotificationPreferences{SlackEvents: []domain.NotificationEvent{domain.NotificationEventQuoteReady}, SlackWebhookURL: "https://hooks.slack.com/services/x"}},
+		{"unsupported event", &domain.NotificationPreferences{EmailEvents: []domain.NotificationEvent{domain.NotificationEventJobFailed}}},
+		{"sms without phone", &domain.NotificationPreferences{SMSEvents: []domain.NotificationEvent{domain.NotificationEventCallFailed}}},
+		{"invalid phone", &domain.NotificationPreferences{Phone: "555-0100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Update(context.Background(), user, tt.prefs)
+			if apperrors.GetCode(err) != apperrors.CodeValidation {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// Notifier delivers notifications about call and quote lifecycle events.
+// Implementations must not block the caller on delivery.
+type Notifier interface {
+	// QuoteReady is called after a quote has been generated for a call.
+	QuoteReady(ctx context.Context, call *domain.Call)
+
+	// CallFailed is called when a call ends without completing.
+	CallFailed(ctx context.Context, call *domain.Call)
+
+	// UsageAlert is called when a usage limit is reached.
+	UsageAlert(ctx context.Context, alert domain.UsageAlert)
+}
+
+// AccountMailer emails users links for managing their account.
+// Implementations must not block the caller on delivery.
+type AccountMailer interface {
+	// PasswordReset sends a link for choosing a new password.
+	PasswordReset(ctx context.Context, user *domain.User, resetURL string)
+
+	// EmailVerification sends a link that confirms the user's address.
+	EmailVerification(ctx context.Context, user *domain.User, verifyURL string)
+
+	// Invitation sends a new user a link for accepting their invitation.
+	Invitation(ctx context.Context, user *domain.User, inviteURL string)
+}
+
+// EventPublisher announces QuickQuote events to external systems.
+// Implementations must not block the caller on delivery.
+type EventPublisher interface {
+	// Publish announces an event; data is serialized as the event's JSON data.
+	Publish(ctx context.Context, event domain.WebhookEventType, data interface{})
+}
+
+// CallCompletedNotifier is notified when an answered call ends.
+// Implementations must not block the caller on delivery.
+type CallCompletedNotifier interface {
+	CallCompleted(ctx context.Context, call *domain.Call)
+}
+
+// JobFailureNotifier is notified when a quote job runs out of retries.
+// Notifiers passed to QuoteJobProcessor.SetNotifier that implement it are
+// told about dead-lettered jobs.
+type JobFailureNotifier interface {
+	QuoteJobFailed(ctx context.Context, job *domain.QuoteJob)
+}
+
+// MultiNotifier delivers each notification to every notifier in the list,
+// including the optional CallCompletedNotifier and JobFailureNotifier
+// notifications of those that implement them.
+type MultiNotifier []Notifier
+
+// QuoteReady notifies every notifier that a quote was generated.
+func (m MultiNotifier) QuoteReady(ctx context.Context, call *domain.Call) {
+	for _, n := range m {
+		n.QuoteReady(ctx, call)
+	}
+}
+
+// CallFailed notifies every notifier that a call failed.
+func (m MultiNotifier) CallFailed(ctx context.Context, call *domain.Call) {
+	for _, n := range m {
+		n.CallFailed(ctx, call)
+	}
+}
+
+// UsageAlert notifies every notifier that a usage limit was reached.
+func (m MultiNotifier) UsageAlert(ctx context.Context, alert domain.UsageAlert) {
+	for _, n := range m {
+		n.UsageAlert(ctx, alert)
+	}
+}
+
+// CallCompleted notifies the notifiers that implement CallCompletedNotifier.
+func (m MultiNotifier) CallCompleted(ctx context.Context, call *domain.Call) {
+	for _, n := range m {
+		if c, ok := n.(CallCompletedNotifier); ok {
+			c.CallCompleted(ctx, call)
+		}
+	}
+}
+
+// QuoteJobFailed notifies the notifiers that implement JobFailureNotifier.
+func (m MultiNotifier) QuoteJobFailed(ctx context.Context, job *domain.QuoteJob) {
+	for _, n := range m {
+		if f, ok := n.(JobFailureNotifier); ok {
+			f.QuoteJobFailed(ctx, job)
+		}
+	}
+}
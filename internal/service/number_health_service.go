@@ -0,0 +1,447 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/reputation"
+)
+
+// NumberHealthNumbers lists the numbers we own. BlandService implements it.
+type NumberHealthNumbers interface {
+	ListPhoneNumbers(ctx context.Context, req *bland.ListPhoneNumbersRequest) ([]bland.PhoneNumber, error)
+}
+
+// DialingPools moves numbers in and out of dialing pools. BlandService
+// implements it.
+type DialingPools interface {
+	ListDialingPools(ctx context.Context) ([]bland.DialingPool, error)
+	AddNumberToPool(ctx context.Context, poolID string, number *bland.PoolNumber) error
+	RemoveNumberFromPool(ctx context.Context, poolID string, phoneNumber string) error
+}
+
+// NumberReputationChecker looks up how a number is labeled to the people it
+// calls. reputation.Client implements it.
+type NumberReputationChecker interface {
+	Check(ctx context.Context, phoneNumber string) (*reputation.Result, error)
+}
+
+// NumberHealthService watches the reputation of our caller IDs. It scores
+// each number from its outbound answer rate and, when configured, a
+// reputation API; takes flagged numbers out of the dialing pools they are in
+// so calls go out from healthier ones; puts them back once they recover; and
+// alerts staff when a number gets worse.
+type NumberHealthService struct {
+	repo       domain.NumberHealthRepository
+	numbers    NumberHealthNumbers
+	pools      DialingPools
+	reputation NumberReputationChecker
+	notifier   Notifier
+	logger     *zap.Logger
+
+	// Configuration
+	interval time.Duration
+	lookback time.Duration
+	minCalls int
+
+	clock clock.Clock
+
+	// checkMu serializes checks so a check requested through the API
+	// doesn't race the worker.
+	checkMu sync.Mutex
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// NumberHealthServiceConfig holds configuration for the number health service.
+type NumberHealthServiceConfig struct {
+	Interval time.Duration // How often every number is checked
+	Lookback time.Duration // How far back calls are counted
+	MinCalls int           // Fewest calls in the lookback window to score delivery by
+}
+
+// DefaultNumberHealthServiceConfig returns sensible defaults.
+func DefaultNumberHealthServiceConfig() *NumberHealthServiceConfig {
+	return &NumberHealthServiceConfig{
+		Interval: 6 * time.Hour,
+		Lookback: 7 * 24 * time.Hour,
+		MinCalls: 20,
+	}
+}
+
+// NewNumberHealthService creates a new NumberHealthService.
+func NewNumberHealthService(
+	repo domain.NumberHealthRepository,
+	numbers NumberHealthNumbers,
+	logger *zap.Logger,
+	config *NumberHealthServiceConfig,
+	clk clock.Clock,
+) *NumberHealthService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	defaults := DefaultNumberHealthServiceConfig()
+	if config == nil {
+		config = defaults
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaults.Interval
+	}
+	lookback := config.Lookback
+	if lookback <= 0 {
+		lookback = defaults.Lookback
+	}
+	minCalls := config.MinCalls
+	if minCalls <= 0 {
+		minCalls = defaults.MinCalls
+	}
+
+	return &NumberHealthService{
+		repo:     repo,
+		numbers:  numbers,
+		logger:   logger,
+		interval: interval,
+		lookback: lookback,
+		minCalls: minCalls,
+		clock:    clk,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// SetDialingPools enables taking flagged numbers out of dialing pools.
+func (s *NumberHealthService) SetDialingPools(pools DialingPools) {
+	s.pools = pools
+}
+
+// SetReputationChecker scores numbers by a reputation API as well as by
+// their answer rate.
+func (s *NumberHealthService) SetReputationChecker(checker NumberReputationChecker) {
+	s.reputation = checker
+}
+
+// SetNotifier enables usage alerts when a number gets worse.
+func (s *NumberHealthService) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// List returns the health of every number checked, least healthy first.
+func (s *NumberHealthService) List(ctx context.Context) ([]*domain.NumberHealth, error) {
+	healths, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if healths == nil {
+		healths = []*domain.NumberHealth{}
+	}
+	return healths, nil
+}
+
+// CheckAll checks every number we own, rotates dialing pools and returns the
+// new health of each number.
+func (s *NumberHealthService) CheckAll(ctx context.Context) ([]*domain.NumberHealth, error) {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	owned, err := s.numbers.ListPhoneNumbers(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list phone numbers: %w", err)
+	}
+	var phones []string
+	for _, n := range owned {
+		if phone := normalizePhoneNumber(n.PhoneNumber); phone != "" {
+			phones = append(phones, phone)
+		}
+	}
+
+	now := s.clock.Now().UTC()
+	delivery, err := s.repo.DeliveryStats(ctx, phones, now.Add(-s.lookback))
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]*domain.NumberDeliveryStats, len(delivery))
+	for _, st := range delivery {
+		stats[st.PhoneNumber] = st
+	}
+
+	healths := make([]*domain.NumberHealth, 0, len(phones))
+	for _, phone := range phones {
+		health, err := s.repo.Get(ctx, phone)
+		if apperrors.IsNotFound(err) {
+			health = domain.NewNumberHealth(phone)
+		} else if err != nil {
+			return nil, err
+		}
+
+		previous := health.Status
+		s.score(ctx, health, stats[phone], now)
+		if health.Status.Rank() > previous.Rank() {
+			s.alert(ctx, health)
+		}
+		healths = append(healths, health)
+	}
+
+	s.rotate(ctx, healths)
+
+	for _, health := range healths {
+		if err := s.repo.Upsert(ctx, health); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("number health checked", zap.Int("numbers", len(healths)))
+	return healths, nil
+}
+
+// score updates a number's health from its delivery and reputation. The
+// lower score wins. A number with nothing to judge by keeps its status,
+// except that one judged unhealthy is given another chance once a lookback
+// window has passed; a flagged number makes no calls to be judged by.
+func (s *NumberHealthService) score(ctx context.Context, health *domain.NumberHealth, stats *domain.NumberDeliveryStats, now time.Time) {
+	health.Calls, health.AnswerRate, health.ShortCallRate = 0, 0, 0
+
+	score, judged := 100, false
+	if stats != nil {
+		health.Calls = stats.Calls
+		health.AnswerRate = stats.AnswerRate()
+		health.ShortCallRate = stats.ShortCallRate()
+		if delivery, ok := stats.Score(s.minCalls); ok {
+			score, judged = delivery, true
+		}
+	}
+
+	spamLikely := false
+	if s.reputation != nil {
+		result, err := s.reputation.Check(ctx, health.PhoneNumber)
+		if err != nil {
+			s.logger.Warn("failed to check number reputation",
+				zap.String("phone_number", health.PhoneNumber),
+				zap.Error(err),
+			)
+		} else {
+			score = min(score, result.Score)
+			spamLikely = result.SpamLikely
+			health.Label = result.Label
+			judged = true
+		}
+	}
+
+	switch {
+	case judged:
+		health.SetScore(score, spamLikely, now)
+	case health.Status != domain.NumberHealthHealthy && now.Sub(health.StatusChangedAt) >= s.lookback:
+		health.SetScore(domain.NumberHealthDegradedBelow, false, now)
+	default:
+		health.CheckedAt = now
+	}
+}
+
+// rotate takes flagged numbers out of their dialing pools, leaving at least
+// one number in each pool, and puts healthy numbers back into the pools they
+// were taken out of.
+func (s *NumberHealthService) rotate(ctx context.Context, healths []*domain.NumberHealth) {
+	if s.pools == nil {
+		return
+	}
+	pools, err := s.pools.ListDialingPools(ctx)
+	if err != nil {
+		s.logger.Warn("failed to list dialing pools", zap.Error(err))
+		return
+	}
+
+	byPhone := make(map[string]*domain.NumberHealth, len(healths))
+	for _, health := range healths {
+		byPhone[health.PhoneNumber] = health
+	}
+
+	exists := make(map[string]bool, len(pools))
+	for _, pool := range pools {
+		exists[pool.ID] = true
+		remaining := len(pool.PhoneNumbers)
+		for _, n := range pool.PhoneNumbers {
+			health := byPhone[normalizePhoneNumber(n.PhoneNumber)]
+			if health == nil || health.Status != domain.NumberHealthFlagged {
+				continue
+			}
+			if remaining <= 1 {
+				s.logger.Warn("leaving flagged number in dialing pool with no other numbers",
+					zap.String("pool_id", pool.ID),
+					zap.String("phone_number", health.PhoneNumber),
+				)
+				continue
+			}
+			if err := s.pools.RemoveNumberFromPool(ctx, pool.ID, n.PhoneNumber); err != nil {
+				s.logger.Warn("failed to take flagged number out of dialing pool",
+					zap.String("pool_id", pool.ID),
+					zap.String("phone_number", health.PhoneNumber),
+					zap.Error(err),
+				)
+				continue
+			}
+			if health.RotatedPools == nil {
+				health.RotatedPools = make(map[string]int)
+			}
+			health.RotatedPools[pool.ID] = n.Weight
+			remaining--
+			s.logger.Info("flagged number taken out of dialing pool",
+				zap.String("pool_id", pool.ID),
+				zap.String("phone_number", health.PhoneNumber),
+			)
+		}
+	}
+
+	for _, health := range healths {
+		if health.Status != domain.NumberHealthHealthy {
+			continue
+		}
+		for poolID, weight := range health.RotatedPools {
+			if !exists[poolID] {
+				delete(health.RotatedPools, poolID)
+				continue
+			}
+			err := s.pools.AddNumberToPool(ctx, poolID, &bland.PoolNumber{
+				PhoneNumber: health.PhoneNumber,
+				Weight:      weight,
+				IsActive:    true,
+			})
+			if err != nil {
+				s.logger.Warn("failed to put recovered number back in dialing pool",
+					zap.String("pool_id", poolID),
+					zap.String("phone_number", health.PhoneNumber),
+					zap.Error(err),
+				)
+				continue
+			}
+			delete(health.RotatedPools, poolID)
+			s.logger.Info("recovered number put back in dialing pool",
+				zap.String("pool_id", poolID),
+				zap.String("phone_number", health.PhoneNumber),
+			)
+		}
+	}
+}
+
+// alert tells staff a number has got worse.
+func (s *NumberHealthService) alert(ctx context.Context, health *domain.NumberHealth) {
+	s.logger.Warn("number health degraded",
+		zap.String("phone_number", health.PhoneNumber),
+		zap.String("status", string(health.Status)),
+		zap.Int("score", health.Score),
+	)
+	if s.notifier == nil {
+		return
+	}
+
+	detail := fmt.Sprintf("%s now scores %d of 100.", health.PhoneNumber, health.Score)
+	if health.Label != "" {
+		detail += fmt.Sprintf(" It is labeled %q.", health.Label)
+	}
+	if health.Status == domain.NumberHealthFlagged {
+		detail += " It is being taken out of its dialing pools until it recovers."
+	} else {
+		detail += fmt.Sprintf(" It will be taken out of its dialing pools if it falls below %d.", domain.NumberHealthFlaggedBelow)
+	}
+
+	s.notifier.UsageAlert(ctx, domain.UsageAlert{
+		Resource: "caller ID",
+		Reason:   health.PhoneNumber + " " + string(health.Status),
+		Used:     health.Score,
+		Limit:    100,
+		Detail:   detail,
+	})
+}
+
+// Start begins checking every number periodically.
+func (s *NumberHealthService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("number health worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting number health worker", zap.Duration("interval", s.interval))
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for an in-flight check to finish.
+func (s *NumberHealthService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping number health worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("number health worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("number health worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *NumberHealthService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.runCheck()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.runCheck()
+		}
+	}
+}
+
+// runCheck checks every number, giving up if the worker stops.
+func (s *NumberHealthService) runCheck() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if _, err := s.CheckAll(ctx); err != nil {
+		s.logger.Error("failed to check number health", zap.Error(err))
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/reputation"
+)
+
+// MockNumberHealthRepository is an in-memory NumberHealthRepository.
+type MockNumberHealthRepository struct {
+	mu      sync.Mutex
+	healths map[string]*domain.NumberHealth
+	stats   map[string]*domain.NumberDeliveryStats
+}
+
+func NewMockNumberHealthRepository() *MockNumberHealthRepository {
+	return &MockNumberHealthRepository{
+		healths: make(map[string]*domain.NumberHealth),
+		stats:   make(map[string]*domain.NumberDeliveryStats),
+	}
+}
+
+func (m *MockNumberHealthRepository) Upsert(ctx context.Context, health *domain.NumberHealth) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *health
+	cp.RotatedPools = make(map[string]int)
+	for k, v := range health.RotatedPools {
+		cp.RotatedPools[k] = v
+	}
+	m.healths[health.PhoneNumber] = &cp
+	return nil
+}
+
+func (m *MockNumberHealthRepository) Get(ctx context.Context, phoneNumber string) (*domain.NumberHealth, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	health, ok := m.healths[phoneNumber]
+	if !ok {
+		return nil, apperrors.NotFound("number health")
+	}
+	cp := *health
+	return &cp, nil
+}
+
+func (m *MockNumberHealthRepository) List(ctx context.Context) ([]*domain.NumberHealth, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var healths []*domain.NumberHealth
+	for _, h := range m.healths {
+		cp := *h
+		healths = append(healths, &cp)
+	}
+	sort.Slice(healths, func(i, j int) bool { return healths[i].Score < healths[j].Score })
+	return healths, nil
+}
+
+func (m *MockNumberHealthRepository) DeliveryStats(ctx context.Context, phoneNumbers []string, since time.Time) ([]*domain.NumberDeliveryStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var stats []*domain.NumberDeliveryStats
+	for _, phone := range phoneNumbers {
+		if st, ok := m.stats[phone]; ok {
+			stats = append(stats, st)
+		}
+	}
+	return stats, nil
+}
+
+// fakeNumberHealthBland stands in for Bland's numbers and dialing pools.
+type fakeNumberHealthBland struct {
+	numbers []bland.PhoneNumber
+	pools   map[string][]bland.PoolNumber
+}
+
+func (f *fakeNumberHealthBland) ListPhoneNumbers(ctx context.Context, req *bland.ListPhoneNumbersRequest) ([]bland.PhoneNumber, error) {
+	return f.numbers, nil
+}
+
+func (f *fakeNumberHealthBland) ListDialingPools(ctx context.Context) ([]bland.DialingPool, error) {
+	var pools []bland.DialingPool
+	for id, numbers := range f.pools {
+		pools = append(pools, bland.DialingPool{ID: id, PhoneNumbers: append([]bland.PoolNumber(nil), numbers...)})
+	}
+	return pools, nil
+}
+
+func (f *fakeNumberHealthBland) AddNumberToPool(ctx context.Context, poolID string, number *bland.PoolNumber) error {
+	f.pools[poolID] = append(f.pools[poolID], *number)
+	return nil
+}
+
+func (f *fakeNumberHealthBland) RemoveNumberFromPool(ctx context.Context, poolID string, phoneNumber string) error {
+	numbers := f.pools[poolID][:0]
+	for _, n := range f.pools[poolID] {
+		if n.PhoneNumber != phoneNumber {
+			numbers = append(numbers, n)
+		}
+	}
+	f.pools[poolID] = numbers
+	return nil
+}
+
+func (f *fakeNumberHealthBland) inPool(poolID, phoneNumber string) bool {
+	for _, n := range f.pools[poolID] {
+		if n.PhoneNumber == phoneNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeReputation answers reputation checks from a map.
+type fakeReputation map[string]*reputation.Result
+
+func (f fakeReputation) Check(ctx context.Context, phoneNumber string) (*reputation.Result, error) {
+	if result, ok := f[phoneNumber]; ok {
+		return result, nil
+	}
+	return nil, errors.New("reputation service unavailable")
+}
+
+type numberHealthTest struct {
+	svc      *NumberHealthService
+	repo     *MockNumberHealthRepository
+	bland    *fakeNumberHealthBland
+	notifier *recordingNotifier
+	clock    *clock.Mock
+}
+
+func newNumberHealthTest(t *testing.T) *numberHealthTest {
+	t.Helper()
+	tt := &numberHealthTest{
+		repo: NewMockNumberHealthRepository(),
+		bland: &fakeNumberHealthBland{
+			numbers: []bland.PhoneNumber{{PhoneNumber: "+15550000001"}, {PhoneNumber: "+15550000002"}},
+			pools: map[string][]bland.PoolNumber{
+				"pool-a": {{PhoneNumber: "+15550000001", Weight: 3, IsActive: true}, {PhoneNumber: "+15550000002", Weight: 1, IsActive: true}},
+				"pool-b": {{PhoneNumber: "+15550000001", Weight: 1, IsActive: true}},
+			},
+		},
+		notifier: &recordingNotifier{},
+		clock:    clock.NewMock(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)),
+	}
+	tt.svc = NewNumberHealthService(tt.repo, tt.bland, zap.NewNop(), &NumberHealthServiceConfig{MinCalls: 10, Lookback: 7 * 24 * time.Hour}, tt.clock)
+	tt.svc.SetDialingPools(tt.bland)
+	tt.svc.SetNotifier(tt.notifier)
+	return tt
+}
+
+func (tt *numberHealthTest) health(t *testing.T, phone string) *domain.NumberHealth {
+	t.Helper()
+	health, err := tt.repo.Get(context.Background(), phone)
+	if err != nil {
+		t.Fatalf("no health for %s: %v", phone, err)
+	}
+	return health
+}
+
+func TestNumberHealthService_RotatesFlaggedNumbers(t *testing.T) {
+	ctx := context.Background()
+	tt := newNumberHealthTest(t)
+
+	// The first number's calls have stopped being answered
+	tt.repo.stats["+15550000001"] = &domain.NumberDeliveryStats{PhoneNumber: "+15550000001", Calls: 50, Answered: 2, ShortCalls: 1}
+	tt.repo.stats["+15550000002"] = &domain.NumberDeliveryStats{PhoneNumber: "+15550000002", Calls: 50, Answered: 15}
+
+	if _, err := tt.svc.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	flagged := tt.health(t, "+15550000001")
+	if flagged.Status != domain.NumberHealthFlagged {
+		t.Fatalf("status = %s (score %d), want flagged", flagged.Status, flagged.Score)
+	}
+	if tt.bland.inPool("pool-a", "+15550000001") {
+		t.Error("flagged number is still in pool-a")
+	}
+	if !tt.bland.inPool("pool-b", "+15550000001") {
+		t.Error("flagged number was taken out of pool-b, leaving it empty")
+	}
+	if flagged.RotatedPools["pool-a"] != 3 {
+		t.Errorf("rotated pools = %v, want pool-a's weight remembered", flagged.RotatedPools)
+	}
+	if healthy := tt.health(t, "+15550000002"); healthy.Status != domain.NumberHealthHealthy || healthy.Score != 100 {
+		t.Errorf("second number = %s (score %d), want healthy at 100", healthy.Status, healthy.Score)
+	}
+	if len(tt.notifier.alerts) != 1 || tt.notifier.alerts[0].Reason != "+15550000001 flagged" {
+		t.Errorf("alerts = %+v, want one for the flagged number", tt.notifier.alerts)
+	}
+
+	// Checking again raises no new alert
+	if _, err := tt.svc.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+	if len(tt.notifier.alerts) != 1 {
+		t.Errorf("alerts = %d after an unchanged check, want 1", len(tt.notifier.alerts))
+	}
+
+	// Out of the pool it makes no calls; after a lookback window it gets
+	// another chance
+	delete(tt.repo.stats, "+15550000001")
+	tt.clock.Advance(8 * 24 * time.Hour)
+	if _, err := tt.svc.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+	recovered := tt.health(t, "+15550000001")
+	if recovered.Status != domain.NumberHealthHealthy || len(recovered.RotatedPools) != 0 {
+		t.Errorf("recovered = %+v, want healthy with no rotated pools", recovered)
+	}
+	for _, n := range tt.bland.pools["pool-a"] {
+		if n.PhoneNumber == "+15550000001" && n.Weight != 3 {
+			t.Errorf("number put back with weight %d, want 3", n.Weight)
+		}
+	}
+	if !tt.bland.inPool("pool-a", "+15550000001") {
+		t.Error("recovered number was not put back in pool-a")
+	}
+}
+
+func TestNumberHealthService_Reputation(t *testing.T) {
+	ctx := context.Background()
+	tt := newNumberHealthTest(t)
+	tt.svc.SetReputationChecker(fakeReputation{
+		"+15550000001": {Score: 80, SpamLikely: true, Label: "Spam Likely"},
+		"+15550000002": {Score: 55},
+	})
+
+	if _, err := tt.svc.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+
+	if spam := tt.health(t, "+15550000001"); spam.Status != domain.NumberHealthFlagged || spam.Label != "Spam Likely" {
+		t.Errorf("spam-labeled number = %s %q, want flagged whatever its score", spam.Status, spam.Label)
+	}
+	if degraded := tt.health(t, "+15550000002"); degraded.Status != domain.NumberHealthDegraded || degraded.Score != 55 {
+		t.Errorf("second number = %s (score %d), want degraded at 55", degraded.Status, degraded.Score)
+	}
+	if len(tt.notifier.alerts) != 2 {
+		t.Errorf("alerts = %d, want one per number that got worse", len(tt.notifier.alerts))
+	}
+}
+
+func TestNumberHealthService_TooFewCallsKeepsStatus(t *testing.T) {
+	ctx := context.Background()
+	tt := newNumberHealthTest(t)
+	tt.repo.stats["+15550000001"] = &domain.NumberDeliveryStats{PhoneNumber: "+15550000001", Calls: 5}
+
+	if _, err := tt.svc.CheckAll(ctx); err != nil {
+		t.Fatalf("CheckAll() error = %v", err)
+	}
+	health := tt.health(t, "+15550000001")
+	if health.Status != domain.NumberHealthHealthy || health.Calls != 5 {
+		t.Errorf("health = %+v, want healthy with its 5 calls counted", health)
+	}
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MaxBulkPurchase is the most numbers one bulk purchase may buy.
+const MaxBulkPurchase = 50
+
+// NumberProvisioner buys, configures and releases phone numbers.
+// bland.Client implements it.
+type NumberProvisioner interface {
+	SearchAvailableNumbers(ctx context.Context, req *bland.SearchAvailableNumbersRequest) ([]bland.AvailablePhoneNumber, error)
+	PurchaseNumber(ctx context.Context, req *bland.PurchaseNumberRequest) (*bland.PhoneNumber, error)
+	ConfigureInboundAgent(ctx context.Context, phoneNumber string, config *bland.InboundConfig) (*bland.PhoneNumber, error)
+	AddNumberToPool(ctx context.Context, poolID string, number *bland.PoolNumber) error
+	RemoveNumberFromPool(ctx context.Context, poolID string, phoneNumber string) error
+	ReleasePhoneNumber(ctx context.Context, phoneNumberID string) error
+}
+
+// NumberProvisioningService buys phone numbers in bulk and sets each one up
+// to take inbound calls and dial out from a pool.
+type NumberProvisioningService struct {
+	numbers NumberProvisioner
+	prompts *PromptService
+	logger  *zap.Logger
+}
+
+// NewNumberProvisioningService creates a new NumberProvisioningService.
+func NewNumberProvisioningService(numbers NumberProvisioner, prompts *PromptService, logger *zap.Logger) *NumberProvisioningService {
+	return &NumberProvisioningService{
+		numbers: numbers,
+		prompts: prompts,
+		logger:  logger,
+	}
+}
+
+// BulkPurchaseRequest describes the numbers to buy and how to set them up.
+type BulkPurchaseRequest struct {
+	Count       int    `json:"count"`
+	CountryCode string `json:"country_code"`
+	AreaCode    string `json:"area_code,omitempty"`
+	Type        string `json:"type,omitempty"`     // local, toll-free
+	Contains    string `json:"contains,omitempty"` // Digits the number must contain
+
+	PromptID   uuid.UUID `json:"prompt_id"`             // Preset applied as each number's inbound agent
+	PoolID     string    `json:"pool_id,omitempty"`     // Dialing pool the numbers join, if any
+	PoolWeight int       `json:"pool_weight,omitempty"` // Weight of each number in the pool
+
+	// AllOrNothing releases every number bought if fewer than Count could
+	// be set up. Otherwise the numbers that were set up are kept.
+	AllOrNothing bool `json:"all_or_nothing,omitempty"`
+}
+
+// Bulk purchase steps, reported where a number failed.
+const (
+	BulkStepPurchase         = "purchase"
+	BulkStepConfigureInbound = "configure_inbound"
+	BulkStepAddToPool        = "add_to_pool"
+)
+
+// Outcomes of a number in a bulk purchase.
+const (
+	BulkNumberProvisioned = "provisioned" // Bought and fully set up
+	BulkNumberFailed      = "failed"      // Not bought, or set up failed and releasing it failed too
+	BulkNumberRolledBack  = "rolled_back" // Bought, then released
+)
+
+// BulkPurchaseNumber is what happened to one number.
+type BulkPurchaseNumber struct {
+	PhoneNumber  string `json:"phone_number"`
+	ID           string `json:"id,omitempty"`
+	Status       string `json:"status"`
+	FailedStep   string `json:"failed_step,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ReleaseError string `json:"release_error,omitempty"` // Releasing failed; the number is still owned and billed
+}
+
+// BulkPurchaseResult reports a bulk purchase number by number.
+type BulkPurchaseResult struct {
+	Requested   int                   `json:"requested"`
+	Provisioned int                   `json:"provisioned"`
+	RolledBack  bool                  `json:"rolled_back"` // All numbers were released under AllOrNothing
+	Numbers     []*BulkPurchaseNumber `json:"numbers"`
+}
+
+// BulkPurchase finds numbers matching the request, buys them until Count
+// are set up or the matches run out, configures each with the preset as its
+// inbound agent and adds it to the dialing pool. A number that fails after
+// being bought is released, so no half-configured numbers are kept.
+func (s *NumberProvisioningService) BulkPurchase(ctx context.Context, req *BulkPurchaseRequest) (*BulkPurchaseResult, error) {
+	if req.Count < 1 || req.Count > MaxBulkPurchase {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("count must be between 1 and %d", MaxBulkPurchase))
+	}
+	if strings.TrimSpace(req.CountryCode) == "" {
+		return nil, apperrors.ValidationFailed("country_code is required")
+	}
+	if req.PromptID == uuid.Nil {
+		return nil, apperrors.ValidationFailed("prompt_id is required")
+	}
+
+	prompt, err := s.prompts.GetPrompt(ctx, req.PromptID)
+	if err != nil {
+		return nil, err
+	}
+	config := InboundConfigFromPrompt(prompt)
+
+	// Search for spares, since numbers can be taken between search and purchase
+	candidates, err := s.numbers.SearchAvailableNumbers(ctx, &bland.SearchAvailableNumbersRequest{
+		CountryCode: req.CountryCode,
+		AreaCode:    req.AreaCode,
+		Type:        req.Type,
+		Contains:    req.Contains,
+		Limit:       req.Count * 2,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search available numbers: %w", err)
+	}
+
+	result := &BulkPurchaseResult{Requested: req.Count, Numbers: []*BulkPurchaseNumber{}}
+	var provisioned []*BulkPurchaseNumber
+	for _, candidate := range candidates {
+		if len(provisioned) == req.Count || ctx.Err() != nil {
+			break
+		}
+		number := s.provision(ctx, req, candidate.PhoneNumber, config)
+		result.Numbers = append(result.Numbers, number)
+		if number.Status == BulkNumberProvisioned {
+			provisioned = append(provisioned, number)
+		}
+	}
+
+	if req.AllOrNothing && len(provisioned) < req.Count {
+		reason := fmt.Sprintf("only %d of %d numbers could be set up", len(provisioned), req.Count)
+		for _, number := range provisioned {
+			s.rollback(ctx, req, number, reason)
+		}
+		result.RolledBack = true
+		provisioned = nil
+	}
+	result.Provisioned = len(provisioned)
+
+	s.logger.Info("bulk number purchase finished",
+		zap.Int("requested", req.Count),
+		zap.Int("provisioned", result.Provisioned),
+		zap.Int("attempted", len(result.Numbers)),
+		zap.Bool("rolled_back", result.RolledBack),
+	)
+	return result, nil
+}
+
+// provision buys one number and sets it up, releasing it if set up fails.
+func (s *NumberProvisioningService) provision(ctx context.Context, req *BulkPurchaseRequest, phoneNumber string, config *bland.InboundConfig) *BulkPurchaseNumber {
+	number := &BulkPurchaseNumber{PhoneNumber: phoneNumber}
+
+	purchased, err := s.numbers.PurchaseNumber(ctx, &bland.PurchaseNumberRequest{PhoneNumber: phoneNumber})
+	if err != nil {
+		number.Status = BulkNumberFailed
+		number.FailedStep = BulkStepPurchase
+		number.Error = err.Error()
+		return number
+	}
+	number.ID = purchased.ID
+	if purchased.PhoneNumber != "" {
+		number.PhoneNumber = purchased.PhoneNumber
+	}
+
+	if _, err := s.numbers.ConfigureInboundAgent(ctx, number.PhoneNumber, config); err != nil {
+		number.FailedStep = BulkStepConfigureInbound
+		s.rollback(ctx, req, number, err.Error())
+		return number
+	}
+
+	if req.PoolID != "" {
+		err := s.numbers.AddNumberToPool(ctx, req.PoolID, &bland.PoolNumber{
+			PhoneNumber: number.PhoneNumber,
+			Weight:      req.PoolWeight,
+			AreaCode:    purchased.AreaCode,
+			IsActive:    true,
+		})
+		if err != nil {
+			number.FailedStep = BulkStepAddToPool
+			s.rollback(ctx, req, number, err.Error())
+			return number
+		}
+	}
+
+	number.Status = BulkNumberProvisioned
+	return number
+}
+
+// rollback releases a bought number, taking it out of the pool first if it
+// was added. Rolling back continues even if the request has been cancelled.
+func (s *NumberProvisioningService) rollback(ctx context.Context, req *BulkPurchaseRequest, number *BulkPurchaseNumber, reason string) {
+	ctx = context.WithoutCancel(ctx)
+	number.Error = reason
+
+	if req.PoolID != "" && number.Status == BulkNumberProvisioned {
+		if err := s.numbers.RemoveNumberFromPool(ctx, req.PoolID, number.PhoneNumber); err != nil {
+			s.logger.Warn("failed to remove number from pool during rollback",
+				zap.String("phone_number", number.PhoneNumber),
+				zap.String("pool_id", req.PoolID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	id := number.ID
+	if id == "" {
+		id = number.PhoneNumber
+	}
+	if err := s.numbers.ReleasePhoneNumber(ctx, id); err != nil {
+		s.logger.Error("failed to release number during rollback",
+			zap.String("phone_number", number.PhoneNumber),
+			zap.Error(err),
+		)
+		number.Status = BulkNumberFailed
+		number.ReleaseError = err.Error()
+		return
+	}
+	number.Status = BulkNumberRolledBack
+}
+
+// InboundConfigFromPrompt builds the inbound agent configuration for a
+// preset.
+func InboundConfigFromPrompt(prompt *domain.Prompt) *bland.InboundConfig {
+	config := &bland.InboundConfig{
+		Task:              prompt.Task,
+		Voice:             prompt.Voice,
+		Language:          prompt.Language,
+		Model:             prompt.Model,
+		FirstSentence:     prompt.FirstSentence,
+		WaitForGreeting:   prompt.WaitForGreeting,
+		Record:            prompt.Record,
+		SummaryPrompt:     prompt.SummaryPrompt,
+		AnalysisSchema:    prompt.AnalysisSchema,
+		Keywords:          prompt.Keywords,
+		KnowledgeBases:    prompt.KnowledgeBaseIDs,
+		Tools:             prompt.CustomToolIDs,
+		NoiseCancellation: prompt.NoiseCancellation,
+	}
+
+	// Set optional numeric fields
+	if prompt.Temperature != nil {
+		config.Temperature = *prompt.Temperature
+	}
+	if prompt.InterruptionThreshold != nil {
+		config.InterruptionThreshold = *prompt.InterruptionThreshold
+	}
+	if prompt.MaxDuration != nil {
+		config.MaxDuration = *prompt.MaxDuration
+	}
+	if prompt.BackgroundTrack != nil {
+		config.BackgroundTrack = *prompt.BackgroundTrack
+	}
+	return config
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// fakeNumberProvisioner records what was bought, configured and released.
+type fakeNumberProvisioner struct {
+	available     []string
+	failPurchase  map[string]bool
+	failConfigure map[string]bool
+	failPool      map[string]bool
+
+	purchased  []string
+	configured []string
+	pooled     []string
+	unpooled   []string
+	released   []string
+}
+
+func (f *fakeNumberProvisioner) SearchAvailableNumbers(ctx context.Context, req *bland.SearchAvailableNumbersRequest) ([]bland.AvailablePhoneNumber, error) {
+	var numbers []bland.AvailablePhoneNumber
+	for _, n := range f.available {
+		if len(numbers) == req.Limit {
+			break
+		}
+		numbers = append(numbers, bland.AvailablePhoneNumber{PhoneNumber: n})
+	}
+	return numbers, nil
+}
+
+func (f *fakeNumberProvisioner) PurchaseNumber(ctx context.Context, req *bland.PurchaseNumberRequest) (*bland.PhoneNumber, error) {
+	if f.failPurchase[req.PhoneNumber] {
+		return nil, errors.New("number no longer available")
+	}
+	f.purchased = append(f.purchased, req.PhoneNumber)
+	return &bland.PhoneNumber{ID: "id-" + req.PhoneNumber, PhoneNumber: req.PhoneNumber}, nil
+}
+
+func (f *fakeNumberProvisioner) ConfigureInboundAgent(ctx context.Context, phoneNumber string, config *bland.InboundConfig) (*bland.PhoneNumber, error) {
+	if f.failConfigure[phoneNumber] {
+		return nil, errors.New("configure failed")
+	}
+	f.configured = append(f.configured, phoneNumber)
+	return &bland.PhoneNumber{PhoneNumber: phoneNumber}, nil
+}
+
+func (f *fakeNumberProvisioner) AddNumberToPool(ctx context.Context, poolID string, number *bland.PoolNumber) error {
+	if f.failPool[number.PhoneNumber] {
+		return errors.New("pool full")
+	}
+	f.pooled = append(f.pooled, number.PhoneNumber)
+	return nil
+}
+
+func (f *fakeNumberProvisioner) RemoveNumberFromPool(ctx context.Context, poolID string, phoneNumber string) error {
+	f.unpooled = append(f.unpooled, phoneNumber)
+	return nil
+}
+
+func (f *fakeNumberProvisioner) ReleasePhoneNumber(ctx context.Context, phoneNumberID string) error {
+	f.released = append(f.released, phoneNumberID)
+	return nil
+}
+
+func newTestNumberProvisioningService(numbers *fakeNumberProvisioner) (*NumberProvisioningService, *domain.Prompt) {
+	prompt := domain.NewPrompt("Inbound", "Answer questions about the business.")
+	prompts := NewPromptService(NewMockPromptRepository(prompt), zap.NewNop())
+	return NewNumberProvisioningService(numbers, prompts, zap.NewNop()), prompt
+}
+
+func TestNumberProvisioningService_BulkPurchase(t *testing.T) {
+	numbers := &fakeNumberProvisioner{
+		available:     []string{"+15550000001", "+15550000002", "+15550000003", "+15550000004"},
+		failPurchase:  map[string]bool{"+15550000001": true},
+		failConfigure: map[string]bool{"+15550000002": true},
+	}
+	svc, prompt := newTestNumberProvisioningService(numbers)
+
+	result, err := svc.BulkPurchase(context.Background(), &BulkPurchaseRequest{
+		Count:       2,
+		CountryCode: "US",
+		PromptID:    prompt.ID,
+		PoolID:      "pool-1",
+	})
+	if err != nil {
+		t.Fatalf("BulkPurchase() error = %v", err)
+	}
+
+	if result.Provisioned != 2 || len(result.Numbers) != 4 {
+		t.Fatalf("expected 2 provisioned of 4 attempted, got %+v", result)
+	}
+	want := []struct{ status, step string }{
+		{BulkNumberFailed, BulkStepPurchase},
+		{BulkNumberRolledBack, BulkStepConfigureInbound},
+		{BulkNumberProvisioned, ""},
+		{BulkNumberProvisioned, ""},
+	}
+	for i, w := range want {
+		if n := result.Numbers[i]; n.Status != w.status || n.FailedStep != w.step {
+			t.Errorf("numbers[%d] = %+v, expected status %s at step %q", i, n, w.status, w.step)
+		}
+	}
+	if len(numbers.released) != 1 || numbers.released[0] != "id-+15550000002" {
+		t.Errorf("released %v, expected only the number that failed to configure", numbers.released)
+	}
+	if len(numbers.pooled) != 2 {
+		t.Errorf("pooled %v, expected the 2 provisioned numbers", numbers.pooled)
+	}
+}
+
+func TestNumberProvisioningService_BulkPurchaseAllOrNothing(t *testing.T) {
+	numbers := &fakeNumberProvisioner{
+		available: []string{"+15550000001", "+15550000002"},
+		failPool:  map[string]bool{"+15550000002": true},
+	}
+	svc, prompt := newTestNumberProvisioningService(numbers)
+
+	result, err := svc.BulkPurchase(context.Background(), &BulkPurchaseRequest{
+		Count:        2,
+		CountryCode:  "US",
+		PromptID:     prompt.ID,
+		PoolID:       "pool-1",
+		AllOrNothing: true,
+	})
+	if err != nil {
+		t.Fatalf("BulkPurchase() error = %v", err)
+	}
+
+	if !result.RolledBack || result.Provisioned != 0 {
+		t.Errorf("expected everything rolled back, got %+v", result)
+	}
+	for _, n := range result.Numbers {
+		if n.Status != BulkNumberRolledBack {
+			t.Errorf("number %s has status %s, expected rolled_back", n.PhoneNumber, n.Status)
+		}
+	}
+	if len(numbers.released) != 2 {
+		t.Errorf("released %v, expected both numbers", numbers.released)
+	}
+	if len(numbers.unpooled) != 1 || numbers.unpooled[0] != "+15550000001" {
+		t.Errorf("removed %v from the pool, expected the number that was added", numbers.unpooled)
+	}
+}
+
+func TestNumberProvisioningService_BulkPurchaseValidation(t *testing.T) {
+	svc, prompt := newTestNumberProvisioningService(&fakeNumberProvisioner{})
+
+	tests := []BulkPurchaseRequest{
+		{Count: 0, CountryCode: "US", PromptID: prompt.ID},
+		{Count: MaxBulkPurchase + 1, CountryCode: "US", PromptID: prompt.ID},
+		{Count: 1, PromptID: prompt.ID},
+		{Count: 1, CountryCode: "US"},
+	}
+	for _, req := range tests {
+		if _, err := svc.BulkPurchase(context.Background(), &req); !apperrors.IsUserError(err) {
+			t.Errorf("BulkPurchase(%+v) error = %v, expected a validation error", req, err)
+		}
+	}
+}
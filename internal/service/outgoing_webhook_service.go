@@ -0,0 +1,605 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// webhookUserAgent identifies outgoing webhook requests.
+const webhookUserAgent = "QuickQuote-Webhooks/1.0"
+
+// webhookBreakerConfig is the circuit breaker each subscriber gets, so one
+// that is down is left alone for a while instead of failing every delivery
+// queued for it.
+var webhookBreakerConfig = &circuitbreaker.Config{
+	FailureThreshold:    5,
+	SuccessThreshold:    1,
+	OpenTimeout:         5 * time.Minute,
+	HalfOpenMaxRequests: 1,
+}
+
+// OutgoingWebhookService manages outgoing webhook subscriptions. Published
+// events are queued as one delivery per subscriber, and a background worker
+// POSTs them with an HMAC signature, retrying failures with backoff.
+type OutgoingWebhookService struct {
+	subscriptions domain.WebhookSubscriptionRepository
+	deliveries    domain.WebhookDeliveryRepository
+	client        *http.Client
+	logger        *zap.Logger
+	elector       Elector
+
+	// Circuit breakers, one per subscriber, created on first delivery
+	breakerMu sync.Mutex
+	breakers  map[uuid.UUID]*circuitbreaker.CircuitBreaker
+	registry  *circuitbreaker.Registry
+
+	// Configuration
+	pollInterval time.Duration
+	batchSize    int
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// OutgoingWebhookConfig holds configuration for the outgoing webhook service.
+type OutgoingWebhookConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Timeout      time.Duration // Per delivery attempt
+}
+
+// DefaultOutgoingWebhookConfig returns sensible defaults.
+func DefaultOutgoingWebhookConfig() *OutgoingWebhookConfig {
+	return &OutgoingWebhookConfig{
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+		Timeout:      10 * time.Second,
+	}
+}
+
+// NewOutgoingWebhookService creates a new OutgoingWebhookService.
+func NewOutgoingWebhookService(
+	subscriptions domain.WebhookSubscriptionRepository,
+	deliveries domain.WebhookDeliveryRepository,
+	logger *zap.Logger,
+	config *OutgoingWebhookConfig,
+) *OutgoingWebhookService {
+	if config == nil {
+		config = DefaultOutgoingWebhookConfig()
+	}
+	return &OutgoingWebhookService{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			// Subscribers must answer at the URL they registered
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger:       logger,
+		breakers:     make(map[uuid.UUID]*circuitbreaker.CircuitBreaker),
+		pollInterval: config.PollInterval,
+		batchSize:    config.BatchSize,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// SetBreakerRegistry registers subscribers' circuit breakers with r, so
+// operators can see and trip them. Call it before Start.
+func (s *OutgoingWebhookService) SetBreakerRegistry(r *circuitbreaker.Registry) {
+	s.registry = r
+}
+
+// SetElector limits delivery retries to the instance elector chooses.
+func (s *OutgoingWebhookService) SetElector(elector Elector) {
+	s.elector = elector
+}
+
+// CreateWebhookRequest holds the parameters for creating a subscription.
+type CreateWebhookRequest struct {
+	Name      string                    `json:"name"`
+	URL       string                    `json:"url"`
+	Events    []domain.WebhookEventType `json:"events"`
+	CreatedBy *uuid.UUID                `json:"-"`
+}
+
+// UpdateWebhookRequest holds the changes to a subscription. Nil fields are
+// left unchanged.
+type UpdateWebhookRequest struct {
+	Name   *string                   `json:"name,omitempty"`
+	URL    *string                   `json:"url,omitempty"`
+	Events []domain.WebhookEventType `json:"events,omitempty"`
+	Active *bool                     `json:"active,omitempty"`
+}
+
+// CreateSubscription validates and stores a new subscription. The returned
+// subscription carries its signing secret.
+func (s *OutgoingWebhookService) CreateSubscription(ctx context.Context, req *CreateWebhookRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := domain.NewWebhookSubscription(strings.TrimSpace(req.Name), strings.TrimSpace(req.URL), req.Events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook secret: %w", err)
+	}
+	subscription.CreatedBy = req.CreatedBy
+	if err := subscription.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	s.logger.Info("webhook subscription created",
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("url", subscription.URL),
+	)
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription by ID.
+func (s *OutgoingWebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	return s.subscriptions.GetByID(ctx, id)
+}
+
+// ListSubscriptions returns all subscriptions, oldest first.
+func (s *OutgoingWebhookService) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subscriptions, err := s.subscriptions.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription changes a subscription's name, URL, events or whether
+// it is active.
+func (s *OutgoingWebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *UpdateWebhookRequest) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		subscription.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Events != nil {
+		subscription.Events = req.Events
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	subscription.UpdatedAt = time.Now().UTC()
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	s.logger.Info("webhook subscription updated", zap.String("subscription_id", id.String()))
+	return subscription, nil
+}
+
+// RotateSecret gives a subscription a new signing secret. The returned
+// subscription carries the new secret.
+func (s *OutgoingWebhookService) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := subscription.RotateSecret(); err != nil {
+		return nil, fmt.Errorf("failed to create webhook secret: %w", err)
+	}
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	s.logger.Info("webhook secret rotated", zap.String("subscription_id", id.String()))
+	return subscription, nil
+}
+
+// DeleteSubscription removes a subscription and its delivery log.
+func (s *OutgoingWebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.dropBreaker(id)
+	s.logger.Info("webhook subscription deleted", zap.String("subscription_id", id.String()))
+	return nil
+}
+
+// ListDeliveries returns a subscription's delivery log, newest first.
+func (s *OutgoingWebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	if _, err := s.subscriptions.GetByID(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	deliveries, err := s.deliveries.ListBySubscription(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	if deliveries == nil {
+		deliveries = []*domain.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery sends a finished delivery again with a fresh set of attempts.
+func (s *OutgoingWebhookService) RetryDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := s.deliveries.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != subscriptionID {
+		return nil, apperrors.NotFound("webhook delivery")
+	}
+	if delivery.Status == domain.WebhookDeliveryStatusPending {
+		return nil, apperrors.New(apperrors.CodeConflict, "delivery is already waiting to be sent")
+	}
+
+	delivery.Retry()
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	s.logger.Info("webhook delivery queued for retry",
+		zap.String("subscription_id", subscriptionID.String()),
+		zap.String("delivery_id", deliveryID.String()),
+	)
+	return delivery, nil
+}
+
+// Publish queues an event for every active subscription that receives it.
+// Failures are logged; the caller's work never fails over a webhook.
+func (s *OutgoingWebhookService) Publish(ctx context.Context, event domain.WebhookEventType, data interface{}) {
+	payload := &domain.WebhookPayload{
+		ID:        uuid.New(),
+		Type:      event,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("failed to marshal webhook payload", zap.String("event", string(event)), zap.Error(err))
+		return
+	}
+	s.Enqueue(ctx, payload, body)
+}
+
+// Enqueue queues an already serialized event for every active subscription
+// that receives it. Failures are logged.
+func (s *OutgoingWebhookService) Enqueue(ctx context.Context, payload *domain.WebhookPayload, body []byte) {
+	logger := s.logger.With(zap.String("event", string(payload.Type)))
+
+	subscriptions, err := s.subscriptions.ListForEvent(ctx, payload.Type)
+	if err != nil {
+		logger.Error("failed to list webhook subscriptions", zap.Error(err))
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		delivery := domain.NewWebhookDelivery(subscription.ID, payload, body)
+		if err := s.deliveries.Create(ctx, delivery); err != nil {
+			logger.Error("failed to queue webhook delivery",
+				zap.String("subscription_id", subscription.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+	}
+	logger.Debug("webhook event queued",
+		zap.String("event_id", payload.ID.String()),
+		zap.Int("subscriptions", len(subscriptions)),
+	)
+}
+
+// Start begins delivering queued webhooks in the background.
+func (s *OutgoingWebhookService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("webhook delivery worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting webhook delivery worker", zap.Duration("poll_interval", s.pollInterval))
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight deliveries to finish.
+func (s *OutgoingWebhookService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping webhook delivery worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("webhook delivery worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("webhook delivery worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *OutgoingWebhookService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.deliverDue(time.Now())
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.deliverDue(time.Now())
+		}
+	}
+}
+
+// deliverDue attempts the deliveries that are due.
+func (s *OutgoingWebhookService) deliverDue(now time.Time) {
+	if !isLeader(s.elector) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	deliveries, err := s.deliveries.ListDue(ctx, now, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to list due webhook deliveries", zap.Error(err))
+		return
+	}
+
+	subscriptions := make(map[uuid.UUID]*domain.WebhookSubscription)
+	for _, delivery := range deliveries {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				s.logger.Error("failed to get webhook subscription",
+					zap.String("subscription_id", delivery.SubscriptionID.String()),
+					zap.Error(err),
+				)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		s.deliver(ctx, subscription, delivery)
+	}
+}
+
+// deliver makes one delivery attempt and records the outcome.
+func (s *OutgoingWebhookService) deliver(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) {
+	logger := s.logger.With(
+		zap.String("subscription_id", subscription.ID.String()),
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("event", string(delivery.EventType)),
+	)
+
+	if !subscription.Active {
+		delivery.Abandon("subscription is disabled")
+		logger.Info("dropping webhook delivery for disabled subscription")
+	} else {
+		var status int
+		err := s.breakerFor(subscription.ID).Execute(ctx, func(ctx context.Context) error {
+			var sendErr error
+			status, sendErr = s.send(ctx, subscription, delivery)
+			return sendErr
+		})
+		switch {
+		case errors.Is(err, circuitbreaker.ErrCircuitOpen) || errors.Is(err, circuitbreaker.ErrTooManyRequests):
+			delivery.Postpone(time.Now().UTC().Add(webhookBreakerConfig.OpenTimeout), "subscriber's circuit breaker is open")
+			logger.Debug("postponing webhook delivery while the subscriber's circuit is open", zap.Time("next_attempt", delivery.NextAttemptAt))
+		case err != nil:
+			delivery.MarkFailed(status, err)
+			if delivery.Status == domain.WebhookDeliveryStatusFailed {
+				logger.Error("giving up on webhook delivery", zap.Error(err), zap.Int("attempts", delivery.Attempts))
+			} else {
+				logger.Warn("webhook delivery failed", zap.Error(err), zap.Time("next_attempt", delivery.NextAttemptAt))
+			}
+		default:
+			delivery.MarkDelivered(status)
+			logger.Debug("webhook delivered", zap.Int("status", status))
+		}
+	}
+
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		logger.Error("failed to update webhook delivery", zap.Error(err))
+	}
+}
+
+// breakerFor returns the circuit breaker for a subscriber, creating it on
+// first use.
+func (s *OutgoingWebhookService) breakerFor(subscriptionID uuid.UUID) *circuitbreaker.CircuitBreaker {
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+
+	if cb, ok := s.breakers[subscriptionID]; ok {
+		return cb
+	}
+	cb := circuitbreaker.New("webhook-"+subscriptionID.String(), webhookBreakerConfig, s.logger)
+	s.breakers[subscriptionID] = cb
+	if s.registry != nil {
+		s.registry.Register(cb)
+	}
+	return cb
+}
+
+// dropBreaker forgets a deleted subscriber's circuit breaker.
+func (s *OutgoingWebhookService) dropBreaker(subscriptionID uuid.UUID) {
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+
+	if cb, ok := s.breakers[subscriptionID]; ok {
+		delete(s.breakers, subscriptionID)
+		if s.registry != nil {
+			s.registry.Unregister(cb.Name())
+		}
+	}
+}
+
+// send POSTs a delivery's payload to its subscriber, returning the response
+// status. Any status other than 2xx is an error.
+func (s *OutgoingWebhookService) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(domain.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(domain.WebhookSignatureHeader, domain.SignWebhookPayload(subscription.Secret, time.Now(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		msg := strings.TrimSpace(string(body))
+		if msg == "" {
+			return resp.StatusCode, fmt.Errorf("subscriber returned %s", resp.Status)
+		}
+		return resp.StatusCode, fmt.Errorf("subscriber returned %s: %s", resp.Status, msg)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// WebhookCallData is the data of call.completed events.
+type WebhookCallData struct {
+	CallID          uuid.UUID             `json:"call_id"`
+	Provider        string                `json:"provider"`
+	ProviderCallID  string                `json:"provider_call_id"`
+	PhoneNumber     string                `json:"phone_number"`
+	FromNumber      string                `json:"from_number"`
+	CallerName      *string               `json:"caller_name,omitempty"`
+	Status          domain.CallStatus     `json:"status"`
+	StartedAt       *time.Time            `json:"started_at,omitempty"`
+	EndedAt         *time.Time            `json:"ended_at,omitempty"`
+	DurationSeconds *int                  `json:"duration_seconds,omitempty"`
+	Disposition     *string               `json:"disposition,omitempty"`
+	Summary         *string               `json:"summary,omitempty"`
+	CustomerID      *uuid.UUID            `json:"customer_id,omitempty"`
+	ExtractedData   *domain.ExtractedData `json:"extracted_data,omitempty"`
+}
+
+// NewWebhookCallData builds the call.completed data for a call.
+func NewWebhookCallData(call *domain.Call) *WebhookCallData {
+	return &WebhookCallData{
+		CallID:          call.ID,
+		Provider:        call.Provider,
+		ProviderCallID:  call.ProviderCallID,
+		PhoneNumber:     call.PhoneNumber,
+		FromNumber:      call.FromNumber,
+		CallerName:      call.CallerName,
+		Status:          call.Status,
+		StartedAt:       call.StartedAt,
+		EndedAt:         call.EndedAt,
+		DurationSeconds: call.DurationSeconds,
+		Disposition:     call.ProviderDisposition,
+		Summary:         call.ProviderSummary,
+		CustomerID:      call.CustomerID,
+		ExtractedData:   call.ExtractedData,
+	}
+}
+
+// WebhookQuoteData is the data of quote events.
+type WebhookQuoteData struct {
+	CallID         uuid.UUID          `json:"call_id"`
+	QuoteNumber    string             `json:"quote_number"`
+	Status         domain.QuoteStatus `json:"status"`
+	PreviousStatus domain.QuoteStatus `json:"previous_status,omitempty"` // On quote.status_changed
+	TotalAmount    float64            `json:"total_amount"`
+	Currency       string             `json:"currency,omitempty"` // ISO 4217 code of total_amount
+	Quote          string             `json:"quote,omitempty"`    // Quote text, on quote.created
+	ApprovedBy     *uuid.UUID         `json:"approved_by,omitempty"`
+	ApprovedAt     *time.Time         `json:"approved_at,omitempty"`
+}
+
+// NewWebhookQuoteCreatedData builds the quote.created data for a call whose
+// quote was just generated.
+func NewWebhookQuoteCreatedData(call *domain.Call) *WebhookQuoteData {
+	data := &WebhookQuoteData{
+		CallID:      call.ID,
+		QuoteNumber: quotepdf.QuoteNumber(call.ID),
+		Status:      domain.QuoteStatusDraft,
+		Currency:    call.Currency(),
+	}
+	if call.QuoteSummary != nil {
+		data.Quote = *call.QuoteSummary
+		data.TotalAmount = QuoteTotal(*call.QuoteSummary)
+	}
+	return data
+}
+
+// NewWebhookQuoteData builds the data of a quote workflow event.
+func NewWebhookQuoteData(quote *domain.Quote) *WebhookQuoteData {
+	return &WebhookQuoteData{
+		CallID:      quote.CallID,
+		QuoteNumber: quotepdf.QuoteNumber(quote.CallID),
+		Status:      quote.Status,
+		TotalAmount: quote.TotalAmount,
+		Currency:    quote.Currency,
+		ApprovedBy:  quote.ApprovedBy,
+		ApprovedAt:  quote.ApprovedAt,
+	}
+}
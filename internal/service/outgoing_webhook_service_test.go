@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockWebhookRepository is an in-memory webhook subscription and delivery repository.
+type MockWebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*domain.WebhookSubscription
+	deliveries    map[uuid.UUID]*domain.WebhookDelivery
+}
+
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		subscriptions: make(map[uuid.UUID]*domain.WebhookSubscription),
+		deliveries:    make(map[uuid.UUID]*domain.WebhookDelivery),
+	}
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, s *domain.WebhookSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *s
+	m.subscriptions[s.ID] = &cp
+	return nil
+}
+
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.subscriptions[id]
+	if !ok {
+		return nil, apperrors.NotFound("webhook subscription")
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, s *domain.WebhookSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subscriptions[s.ID]; !ok {
+		return apperrors.NotFound("webhook subscription")
+	}
+	cp := *s
+	m.subscriptions[s.ID] = &cp
+	return nil
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subscriptions, id)
+	return nil
+}
+
+func (m *MockWebhookRepository) List(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.WebhookSubscription
+	for _, s := range m.subscriptions {
+		cp := *s
+		result = append(result, &cp)
+	}
+	return result, nil
+}
+
+func (m *MockWebhookRepository) ListForEvent(ctx context.Context, event domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.WebhookSubscription
+	for _, s := range m.subscriptions {
+		if s.Subscribes(event) {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+	return result, nil
+}
+
+// MockWebhookDeliveryRepository stores deliveries in the shared MockWebhookRepository.
+type MockWebhookDeliveryRepository struct {
+	*MockWebhookRepository
+}
+
+func (m MockWebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *d
+	m.deliveries[d.ID] = &cp
+	return nil
+}
+
+func (m MockWebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deliveries[id]
+	if !ok {
+		return nil, apperrors.NotFound("webhook delivery")
+	}
+	cp := *d
+	return &cp, nil
+}
+
+func (m MockWebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *d
+	m.deliveries[d.ID] = &cp
+	return nil
+}
+
+func (m MockWebhookDeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return m.list(func(d *domain.WebhookDelivery) bool {
+		return d.Status == domain.WebhookDeliveryStatusPending && !d.NextAttemptAt.After(now)
+	}), nil
+}
+
+func (m MockWebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	return m.list(func(d *domain.WebhookDelivery) bool { return d.SubscriptionID == subscriptionID }), nil
+}
+
+func (m MockWebhookDeliveryRepository) list(match func(*domain.WebhookDelivery) bool) []*domain.WebhookDelivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.WebhookDelivery
+	for _, d := range m.deliveries {
+		if match(d) {
+			cp := *d
+			result = append(result, &cp)
+		}
+	}
+	return result
+}
+
+func newTestOutgoingWebhookService() (*OutgoingWebhookService, *MockWebhookRepository) {
+	repo := NewMockWebhookRepository()
+	svc := NewOutgoingWebhookService(repo, MockWebhookDeliveryRepository{repo}, zap.NewNop(), nil)
+	return svc, repo
+}
+
+func TestOutgoingWebhookService_PublishFansOutToSubscribers(t *testing.T) {
+	svc, repo := newTestOutgoingWebhookService()
+	ctx := context.Background()
+
+	quotes, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "Quotes", URL: "https://a.example.com", Events: []domain.WebhookEventType{domain.WebhookEventQuoteCreated},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	if _, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "Calls", URL: "https://b.example.com", Events: []domain.WebhookEventType{domain.WebhookEventCallCompleted},
+	}); err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	disabled, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "Old", URL: "https://c.example.com", Events: []domain.WebhookEventType{domain.WebhookEventQuoteCreated},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	inactive := false
+	if _, err := svc.UpdateSubscription(ctx, disabled.ID, &UpdateWebhookRequest{Active: &inactive}); err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+
+	svc.Publish(ctx, domain.WebhookEventQuoteCreated, map[string]string{"quote_number": "Q-1"})
+
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(repo.deliveries))
+	}
+	for _, d := range repo.deliveries {
+		if d.SubscriptionID != quotes.ID || d.EventType != domain.WebhookEventQuoteCreated {
+			t.Errorf("delivery = %+v", d)
+		}
+		var payload map[string]interface{}
+		if err := json.Unmarshal(d.Payload, &payload); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		if payload["id"] != d.EventID.String() || payload["type"] != "quote.created" {
+			t.Errorf("payload = %v", payload)
+		}
+	}
+}
+
+func TestOutgoingWebhookService_DeliverSignsAndRetries(t *testing.T) {
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("try later"))
+	}))
+	defer server.Close()
+
+	svc, repo := newTestOutgoingWebhookService()
+	ctx := context.Background()
+	subscription, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "CRM", URL: server.URL, Events: []domain.WebhookEventType{domain.WebhookEventCallCompleted},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	svc.Publish(ctx, domain.WebhookEventCallCompleted, map[string]string{"call_id": "1"})
+
+	svc.deliverDue(time.Now())
+
+	deliveries, _ := svc.ListDeliveries(ctx, subscription.ID, 10, 0)
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.Status != domain.WebhookDeliveryStatusPending || d.Attempts != 1 || d.ResponseStatus == nil || *d.ResponseStatus != 500 {
+		t.Fatalf("after failed attempt delivery = %+v", d)
+	}
+	if d.LastError == nil || !strings.Contains(*d.LastError, "try later") {
+		t.Errorf("LastError = %v, want the response body", d.LastError)
+	}
+
+	req, body := requests[0], bodies[0]
+	if req.Header.Get(domain.WebhookEventHeader) != "call.completed" || req.Header.Get(domain.WebhookDeliveryHeader) != d.ID.String() {
+		t.Errorf("headers = %v", req.Header)
+	}
+	sig := req.Header.Get(domain.WebhookSignatureHeader)
+	var ts int64
+	for _, part := range strings.Split(sig, ",") {
+		if strings.HasPrefix(part, "t=") {
+			ts, _ = strconv.ParseInt(strings.TrimPrefix(part, "t="), 10, 64)
+		}
+	}
+	if want := domain.SignWebhookPayload(subscription.Secret, time.Unix(ts, 0), body); sig != want {
+		t.Errorf("signature = %q, want %q", sig, want)
+	}
+
+	// Not due yet
+	svc.deliverDue(time.Now())
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests before the retry was due", len(requests))
+	}
+
+	status = http.StatusNoContent
+	svc.deliverDue(d.NextAttemptAt)
+	delivered := repo.deliveries[d.ID]
+	if delivered.Status != domain.WebhookDeliveryStatusDelivered || delivered.Attempts != 2 || delivered.LastError != nil {
+		t.Errorf("after retry delivery = %+v", delivered)
+	}
+
+	if _, err := svc.RetryDelivery(ctx, uuid.New(), d.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("RetryDelivery() for another subscription error = %v, want not found", err)
+	}
+	retried, err := svc.RetryDelivery(ctx, subscription.ID, d.ID)
+	if err != nil || retried.Status != domain.WebhookDeliveryStatusPending || retried.Attempts != 0 {
+		t.Fatalf("RetryDelivery() = %+v, %v", retried, err)
+	}
+	if _, err := svc.RetryDelivery(ctx, subscription.ID, d.ID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("RetryDelivery() of a pending delivery error = %v, want conflict", err)
+	}
+}
+
+func TestOutgoingWebhookService_PostponesWhileCircuitOpen(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	svc, repo := newTestOutgoingWebhookService()
+	registry := circuitbreaker.NewRegistry()
+	svc.SetBreakerRegistry(registry)
+	ctx := context.Background()
+	subscription, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "CRM", URL: server.URL, Events: []domain.WebhookEventType{domain.WebhookEventCallCompleted},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	for i := 0; i < webhookBreakerConfig.FailureThreshold+2; i++ {
+		svc.Publish(ctx, domain.WebhookEventCallCompleted, map[string]int{"n": i})
+	}
+
+	svc.deliverDue(time.Now())
+
+	if got := atomic.LoadInt32(&requests); got != int32(webhookBreakerConfig.FailureThreshold) {
+		t.Errorf("subscriber got %d requests, want %d before the circuit opened", got, webhookBreakerConfig.FailureThreshold)
+	}
+	var postponed int
+	for _, d := range repo.deliveries {
+		if d.Attempts == 0 {
+			postponed++
+			if d.Status != domain.WebhookDeliveryStatusPending || d.NextAttemptAt.Before(time.Now().Add(time.Minute)) {
+				t.Errorf("postponed delivery = %+v", d)
+			}
+		}
+	}
+	if postponed != 2 {
+		t.Errorf("got %d postponed deliveries, want 2", postponed)
+	}
+
+	name := "webhook-" + subscription.ID.String()
+	if cb, ok := registry.Get(name); !ok || !cb.IsOpen() {
+		t.Fatalf("expected the subscriber's open breaker registered as %s", name)
+	}
+	if err := svc.DeleteSubscription(ctx, subscription.ID); err != nil {
+		t.Fatalf("DeleteSubscription() error = %v", err)
+	}
+	if _, ok := registry.Get(name); ok {
+		t.Error("expected the breaker unregistered with its subscription")
+	}
+}
+
+type fakeElector struct{ leader bool }
+
+func (e *fakeElector) IsLeader() bool { return e.leader }
+
+func TestOutgoingWebhookService_DeliversOnlyWhenLeader(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	svc, _ := newTestOutgoingWebhookService()
+	elector := &fakeElector{}
+	svc.SetElector(elector)
+	ctx := context.Background()
+	if _, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "CRM", URL: server.URL, Events: []domain.WebhookEventType{domain.WebhookEventCallCompleted},
+	}); err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	svc.Publish(ctx, domain.WebhookEventCallCompleted, nil)
+
+	svc.deliverDue(time.Now())
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("got %d requests from an instance that isn't the leader", n)
+	}
+
+	elector.leader = true
+	svc.deliverDue(time.Now())
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d requests from the leader, want 1", n)
+	}
+}
+
+func TestOutgoingWebhookService_DropsDeliveriesOfDisabledSubscriptions(t *testing.T) {
+	svc, repo := newTestOutgoingWebhookService()
+	ctx := context.Background()
+	subscription, err := svc.CreateSubscription(ctx, &CreateWebhookRequest{
+		Name: "CRM", URL: "http://127.0.0.1:1", Events: []domain.WebhookEventType{domain.WebhookEventQuoteApproved},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	svc.Publish(ctx, domain.WebhookEventQuoteApproved, nil)
+
+	inactive := false
+	if _, err := svc.UpdateSubscription(ctx, subscription.ID, &UpdateWebhookRequest{Active: &inactive}); err != nil {
+		t.Fatalf("UpdateSubscription() error = %v", err)
+	}
+	svc.deliverDue(time.Now())
+
+	for _, d := range repo.deliveries {
+		if d.Status != domain.WebhookDeliveryStatusFailed || d.Attempts != 0 {
+			t.Errorf("delivery = %+v, want failed without an attempt", d)
+		}
+	}
+}
+
+func TestOutgoingWebhookService_CreateSubscriptionValidates(t *testing.T) {
+	svc, _ := newTestOutgoingWebhookService()
+	_, err := svc.CreateSubscription(context.Background(), &CreateWebhookRequest{
+		Name: "CRM", URL: "not a url", Events: []domain.WebhookEventType{domain.WebhookEventQuoteCreated},
+	})
+	if !apperrors.IsUserError(err) {
+		t.Errorf("CreateSubscription() error = %v, want validation error", err)
+	}
+}
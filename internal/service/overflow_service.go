@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// overflowStaleAfter is how long an inbound call is counted as active
+// without a webhook before it is assumed to have ended.
+const overflowStaleAfter = 2 * time.Hour
+
+// overflowSMSCallMessage tells the agent on an overflow call that the
+// caller has been texted.
+const overflowSMSCallMessage = "We are handling an unusually high number of calls. Let the caller know we have just texted them so they can arrange a callback, answer anything quick, and then politely wrap up the call."
+
+// defaultOverflowSMSMessage is texted to overflow callers when no message
+// is configured.
+const defaultOverflowSMSMessage = "Thanks for calling! We're very busy right now. Reply with a good time and we'll call you back."
+
+// OverflowPolicy is what happens to inbound calls over the threshold.
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyPrompt answers new calls to a busy number with another
+	// prompt until the spike passes.
+	OverflowPolicyPrompt OverflowPolicy = "prompt"
+	// OverflowPolicySMS texts the caller an offer of a callback and asks the
+	// agent to wrap up.
+	OverflowPolicySMS OverflowPolicy = "sms"
+	// OverflowPolicyTransfer transfers the call to a person.
+	OverflowPolicyTransfer OverflowPolicy = "transfer"
+)
+
+// OverflowServiceConfig holds inbound overflow settings.
+type OverflowServiceConfig struct {
+	// Threshold is the most inbound calls handled normally at once; 0
+	// disables overflow handling.
+	Threshold      int
+	Policy         OverflowPolicy
+	PromptID       *uuid.UUID // Prompt busy numbers answer with under the prompt policy
+	TransferNumber string     // Where calls are transferred under the transfer policy
+	SMSMessage     string     // Text sent to callers under the sms policy
+}
+
+// Validate checks that the policy has what it needs.
+func (c *OverflowServiceConfig) Validate() error {
+	if c.Threshold <= 0 {
+		return nil
+	}
+	switch c.Policy {
+	case OverflowPolicyPrompt:
+		if c.PromptID == nil {
+			return errors.New("the prompt overflow policy needs a prompt ID")
+		}
+	case OverflowPolicySMS:
+		if strings.TrimSpace(c.SMSMessage) == "" {
+			c.SMSMessage = defaultOverflowSMSMessage
+		}
+	case OverflowPolicyTransfer:
+		if normalizePhoneNumber(c.TransferNumber) == "" {
+			return errors.New("the transfer overflow policy needs a valid transfer number")
+		}
+		c.TransferNumber = normalizePhoneNumber(c.TransferNumber)
+	default:
+		return fmt.Errorf("unknown overflow policy %q; use prompt, sms or transfer", c.Policy)
+	}
+	return nil
+}
+
+// InboundAgentSource builds the agent an inbound number normally answers
+// with. ScheduleService implements it.
+type InboundAgentSource interface {
+	InboundAgent(ctx context.Context, phoneNumber string) (*bland.InboundConfig, error)
+}
+
+// OverflowSMSSender texts callers. BlandService implements it.
+type OverflowSMSSender interface {
+	SendSMS(ctx context.Context, req *bland.SendSMSRequest) (*bland.SendSMSResponse, error)
+}
+
+// OverflowService counts the inbound calls in progress from provider
+// webhooks and applies an overflow policy to calls over the threshold, so
+// a spike doesn't put every caller through the same agent. Counts are
+// kept per process.
+type OverflowService struct {
+	config     OverflowServiceConfig
+	controller CallController
+	inbound    InboundAgentConfigurer
+	agents     InboundAgentSource
+	prompts    domain.PromptRepository
+	sms        OverflowSMSSender
+	doNotCall  FollowUpDoNotCall
+	logger     *zap.Logger
+
+	clock clock.Clock
+
+	// mu guards active, which maps each inbound call in progress to when its
+	// last webhook arrived, and overflowing, the numbers switched to the
+	// overflow prompt.
+	mu          sync.Mutex
+	active      map[string]time.Time
+	overflowing map[string]bool
+}
+
+// NewOverflowService creates a new OverflowService. The config must have
+// been validated.
+func NewOverflowService(
+	config OverflowServiceConfig,
+	controller CallController,
+	inbound InboundAgentConfigurer,
+	agents InboundAgentSource,
+	prompts domain.PromptRepository,
+	sms OverflowSMSSender,
+	logger *zap.Logger,
+	clk clock.Clock,
+) *OverflowService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &OverflowService{
+		config:      config,
+		controller:  controller,
+		inbound:     inbound,
+		agents:      agents,
+		prompts:     prompts,
+		sms:         sms,
+		logger:      logger,
+		clock:       clk,
+		active:      make(map[string]time.Time),
+		overflowing: make(map[string]bool),
+	}
+}
+
+// SetDoNotCall stops overflow texts to numbers on the do-not-call list.
+func (s *OverflowService) SetDoNotCall(doNotCall FollowUpDoNotCall) {
+	s.doNotCall = doNotCall
+}
+
+// Active returns the number of inbound calls in progress.
+func (s *OverflowService) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	return len(s.active)
+}
+
+// Track updates the inbound call count from a call's webhook. A call that
+// starts while more calls than the threshold are in progress is handled by
+// the overflow policy; under the prompt policy, busy numbers go back to
+// their usual agent once the count drops to the threshold.
+func (s *OverflowService) Track(ctx context.Context, call *domain.Call) {
+	if s.config.Threshold <= 0 || !call.IsInbound() || call.Provider != string(voiceprovider.ProviderBland) {
+		return
+	}
+
+	s.mu.Lock()
+	s.prune()
+	_, seen := s.active[call.ProviderCallID]
+	if call.IsComplete() {
+		delete(s.active, call.ProviderCallID)
+	} else {
+		s.active[call.ProviderCallID] = s.clock.Now()
+	}
+	count := len(s.active)
+	overflow := !seen && !call.IsComplete() && count > s.config.Threshold
+	var restore []string
+	if count <= s.config.Threshold {
+		for number := range s.overflowing {
+			restore = append(restore, number)
+		}
+	}
+	s.mu.Unlock()
+
+	if overflow {
+		s.logger.Info("inbound call over overflow threshold",
+			zap.String("provider_call_id", call.ProviderCallID),
+			zap.Int("active_calls", count),
+			zap.String("policy", string(s.config.Policy)),
+		)
+		if err := s.overflow(ctx, call); err != nil {
+			s.logger.Error("failed to apply overflow policy",
+				zap.String("provider_call_id", call.ProviderCallID),
+				zap.String("policy", string(s.config.Policy)),
+				zap.Error(err),
+			)
+		}
+	}
+	for _, number := range restore {
+		s.restore(ctx, number)
+	}
+}
+
+// prune drops calls without a webhook for too long, whose ending webhook
+// was missed. The caller must hold mu.
+func (s *OverflowService) prune() {
+	cutoff := s.clock.Now().Add(-overflowStaleAfter)
+	for id, seen := range s.active {
+		if seen.Before(cutoff) {
+			delete(s.active, id)
+		}
+	}
+}
+
+// overflow applies the policy to a call over the threshold.
+func (s *OverflowService) overflow(ctx context.Context, call *domain.Call) error {
+	switch s.config.Policy {
+	case OverflowPolicyPrompt:
+		return s.switchToOverflowPrompt(ctx, call.PhoneNumber)
+	case OverflowPolicySMS:
+		return s.offerCallback(ctx, call)
+	case OverflowPolicyTransfer:
+		if err := s.controller.TransferCall(ctx, call.ProviderCallID, s.config.TransferNumber); err != nil {
+			return fmt.Errorf("failed to transfer call: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// switchToOverflowPrompt answers new calls to a number with the overflow
+// prompt. The call that crossed the threshold keeps its agent.
+func (s *OverflowService) switchToOverflowPrompt(ctx context.Context, phoneNumber string) error {
+	if phoneNumber == "" {
+		return nil
+	}
+	s.mu.Lock()
+	switched := s.overflowing[phoneNumber]
+	s.mu.Unlock()
+	if switched {
+		return nil
+	}
+
+	prompt, err := s.prompts.GetByID(ctx, *s.config.PromptID)
+	if err != nil {
+		return fmt.Errorf("failed to get overflow prompt: %w", err)
+	}
+	prompt = prompt.Localize(domain.LanguageForNumber(phoneNumber))
+	if err := s.configure(ctx, phoneNumber, inboundConfigFromPrompt(prompt)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.overflowing[phoneNumber] = true
+	s.mu.Unlock()
+	s.logger.Info("inbound number switched to overflow prompt", zap.String("phone_number", phoneNumber))
+	return nil
+}
+
+// restore puts a number back on its usual agent. A number that fails stays
+// marked and is tried again on the next webhook.
+func (s *OverflowService) restore(ctx context.Context, phoneNumber string) {
+	config, err := s.agents.InboundAgent(ctx, phoneNumber)
+	if err == nil {
+		err = s.configure(ctx, phoneNumber, config)
+	}
+	if err != nil {
+		s.logger.Error("failed to restore inbound number after overflow",
+			zap.String("phone_number", phoneNumber),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.mu.Lock()
+	delete(s.overflowing, phoneNumber)
+	s.mu.Unlock()
+	s.logger.Info("inbound number restored after overflow", zap.String("phone_number", phoneNumber))
+}
+
+// configure sets a number's inbound agent.
+func (s *OverflowService) configure(ctx context.Context, phoneNumber string, config *bland.InboundConfig) error {
+	_, err := s.inbound.ConfigureInboundAgent(ctx, phoneNumber, config)
+	if err != nil && !errors.Is(err, ErrBlandWriteQueued) {
+		return fmt.Errorf("failed to configure inbound number: %w", err)
+	}
+	return nil
+}
+
+// offerCallback texts the caller an offer of a callback from the number
+// they called, then asks the agent to tell them and wrap up.
+func (s *OverflowService) offerCallback(ctx context.Context, call *domain.Call) error {
+	if s.doNotCall != nil {
+		if err := s.doNotCall.CheckDoNotCall(ctx, call.FromNumber); err != nil {
+			s.logger.Info("overflow text skipped for do-not-call number",
+				zap.String("provider_call_id", call.ProviderCallID),
+			)
+			return nil
+		}
+	}
+
+	if _, err := s.sms.SendSMS(ctx, &bland.SendSMSRequest{
+		To:   call.FromNumber,
+		From: call.PhoneNumber,
+		Body: s.config.SMSMessage,
+	}); err != nil {
+		return fmt.Errorf("failed to text caller: %w", err)
+	}
+	if err := s.controller.SendCallMessage(ctx, call.ProviderCallID, overflowSMSCallMessage); err != nil {
+		return fmt.Errorf("failed to message agent: %w", err)
+	}
+	return nil
+}
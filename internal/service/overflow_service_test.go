@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// recordingSMS records the texts sent.
+type recordingSMS struct {
+	sent []*bland.SendSMSRequest
+}
+
+func (r *recordingSMS) SendSMS(ctx context.Context, req *bland.SendSMSRequest) (*bland.SendSMSResponse, error) {
+	r.sent = append(r.sent, req)
+	return &bland.SendSMSResponse{}, nil
+}
+
+func newTestOverflowService(t *testing.T, config OverflowServiceConfig, prompts ...*domain.Prompt) (*OverflowService, *fakeCallController, *numberConfigurer, *recordingSMS) {
+	t.Helper()
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	controller := newFakeCallController()
+	inbound := &numberConfigurer{configs: make(map[string]*bland.InboundConfig)}
+	schedule, _ := newTestScheduleService(nil, prompts...)
+	sms := &recordingSMS{}
+	svc := NewOverflowService(config, controller, inbound, schedule, NewMockPromptRepository(prompts...), sms, zap.NewNop(), nil)
+	return svc, controller, inbound, sms
+}
+
+func inboundCall(n int, status domain.CallStatus) *domain.Call {
+	call := domain.NewCall(fmt.Sprintf("call-%d", n), "bland", "+15550000000", fmt.Sprintf("+1555100000%d", n))
+	call.Status = status
+	return call
+}
+
+func TestOverflowService_TransferOverThreshold(t *testing.T) {
+	svc, controller, _, _ := newTestOverflowService(t, OverflowServiceConfig{
+		Threshold:      2,
+		Policy:         OverflowPolicyTransfer,
+		TransferNumber: "(555) 222-3333",
+	})
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		svc.Track(ctx, inboundCall(i, domain.CallStatusInProgress))
+	}
+	// A second webhook for the same call does not count it twice
+	svc.Track(ctx, inboundCall(3, domain.CallStatusInProgress))
+
+	if svc.Active() != 3 {
+		t.Errorf("Active() = %d, want 3", svc.Active())
+	}
+	if len(controller.transferred) != 1 || controller.transferred["call-3"] != "+15552223333" {
+		t.Errorf("expected only the third call transferred, got %v", controller.transferred)
+	}
+
+	svc.Track(ctx, inboundCall(1, domain.CallStatusCompleted))
+	svc.Track(ctx, inboundCall(2, domain.CallStatusCompleted))
+	svc.Track(ctx, inboundCall(4, domain.CallStatusInProgress))
+	if _, ok := controller.transferred["call-4"]; ok {
+		t.Error("expected a call within the threshold to be left alone")
+	}
+
+	outbound := inboundCall(5, domain.CallStatusInProgress)
+	outbound.FromNumber = ""
+	svc.Track(ctx, outbound)
+	if svc.Active() != 2 {
+		t.Errorf("expected outbound calls not to be counted, Active() = %d", svc.Active())
+	}
+}
+
+func TestOverflowService_SMSOffer(t *testing.T) {
+	svc, controller, _, sms := newTestOverflowService(t, OverflowServiceConfig{
+		Threshold: 1,
+		Policy:    OverflowPolicySMS,
+	})
+	ctx := context.Background()
+
+	svc.Track(ctx, inboundCall(1, domain.CallStatusInProgress))
+	svc.Track(ctx, inboundCall(2, domain.CallStatusInProgress))
+
+	if len(sms.sent) != 1 || sms.sent[0].To != "+15551000002" || sms.sent[0].From != "+15550000000" {
+		t.Fatalf("expected the second caller texted from the number they called, got %+v", sms.sent)
+	}
+	if sms.sent[0].Body != defaultOverflowSMSMessage {
+		t.Errorf("expected the default message, got %q", sms.sent[0].Body)
+	}
+	if controller.messages["call-2"] == "" {
+		t.Error("expected the agent to be told about the text")
+	}
+}
+
+func TestOverflowService_PromptRestoresAfterSpike(t *testing.T) {
+	overflowPrompt := domain.NewPrompt("Overflow", "Take a message; we are busy.")
+	svc, _, inbound, _ := newTestOverflowService(t, OverflowServiceConfig{
+		Threshold: 1,
+		Policy:    OverflowPolicyPrompt,
+		PromptID:  &overflowPrompt.ID,
+	}, overflowPrompt)
+	ctx := context.Background()
+
+	svc.Track(ctx, inboundCall(1, domain.CallStatusInProgress))
+	svc.Track(ctx, inboundCall(2, domain.CallStatusInProgress))
+	if got := inbound.configs["+15550000000"]; got == nil || got.Task != overflowPrompt.Task {
+		t.Fatalf("expected the number switched to the overflow prompt, got %+v", got)
+	}
+
+	svc.Track(ctx, inboundCall(3, domain.CallStatusInProgress))
+	if inbound.calls != 1 {
+		t.Errorf("expected a busy number to be switched once, got %d", inbound.calls)
+	}
+
+	svc.Track(ctx, inboundCall(2, domain.CallStatusCompleted))
+	svc.Track(ctx, inboundCall(3, domain.CallStatusCompleted))
+	if got := inbound.configs["+15550000000"]; got.Task != "Collect project details." {
+		t.Errorf("expected the usual agent back once the spike passed, got %+v", got)
+	}
+}
+
+func TestOverflowServiceConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  OverflowServiceConfig
+		wantErr bool
+	}{
+		{"disabled", OverflowServiceConfig{Policy: "bogus"}, false},
+		{"prompt without prompt", OverflowServiceConfig{Threshold: 5, Policy: OverflowPolicyPrompt}, true},
+		{"transfer without number", OverflowServiceConfig{Threshold: 5, Policy: OverflowPolicyTransfer}, true},
+		{"unknown policy", OverflowServiceConfig{Threshold: 5, Policy: "queue"}, true},
+		{"sms", OverflowServiceConfig{Threshold: 5, Policy: OverflowPolicySMS}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// PathwayVersionSource reads and writes pathways in Bland. BlandService
+// implements it.
+type PathwayVersionSource interface {
+	GetPathway(ctx context.Context, pathwayID string) (*bland.Pathway, error)
+	UpdatePathway(ctx context.Context, pathwayID string, req *bland.UpdatePathwayRequest) (*bland.Pathway, error)
+}
+
+// PathwayVersionService keeps a local version history of Bland pathways.
+// Snapshots record a pathway as Bland holds it, so versions can be listed,
+// diffed against each other or the live pathway, and rolled back to.
+type PathwayVersionService struct {
+	source      PathwayVersionSource
+	pathwayRepo domain.PathwayRepository
+	logger      *zap.Logger
+}
+
+// NewPathwayVersionService creates a new PathwayVersionService.
+func NewPathwayVersionService(source PathwayVersionSource, pathwayRepo domain.PathwayRepository, logger *zap.Logger) *PathwayVersionService {
+	return &PathwayVersionService{
+		source:      source,
+		pathwayRepo: pathwayRepo,
+		logger:      logger,
+	}
+}
+
+// Snapshot saves the current state of a Bland pathway as a new version.
+func (s *PathwayVersionService) Snapshot(ctx context.Context, blandID, notes, createdBy string) (*domain.PathwayVersion, error) {
+	remote, err := s.source.GetPathway(ctx, blandID)
+	if err != nil {
+		return nil, err
+	}
+
+	local, err := s.localPathway(ctx, blandID, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.pathwayRepo.ListVersions(ctx, local.ID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.Snapshot", err)
+	}
+	next := 1
+	if len(versions) > 0 {
+		next = versions[0].Version + 1
+	}
+
+	version, err := versionFromPathway(remote)
+	if err != nil {
+		return nil, err
+	}
+	version.ID = uuid.New()
+	version.PathwayID = local.ID
+	version.Version = next
+	version.ChangeNotes = notes
+	version.CreatedBy = createdBy
+	version.CreatedAt = time.Now().UTC()
+
+	if err := s.pathwayRepo.SaveVersion(ctx, version); err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.Snapshot", err)
+	}
+
+	s.logger.Info("pathway version saved",
+		zap.String("pathway_id", blandID),
+		zap.Int("version", version.Version),
+	)
+	return version, nil
+}
+
+// ListVersions returns the saved versions of a Bland pathway, newest first.
+func (s *PathwayVersionService) ListVersions(ctx context.Context, blandID string) ([]*domain.PathwayVersion, error) {
+	local, err := s.pathwayRepo.GetByBlandID(ctx, blandID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.ListVersions", err)
+	}
+	if local == nil {
+		return []*domain.PathwayVersion{}, nil
+	}
+
+	versions, err := s.pathwayRepo.ListVersions(ctx, local.ID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.ListVersions", err)
+	}
+	if versions == nil {
+		versions = []*domain.PathwayVersion{}
+	}
+	return versions, nil
+}
+
+// GetVersion returns one saved version of a Bland pathway.
+func (s *PathwayVersionService) GetVersion(ctx context.Context, blandID string, version int) (*domain.PathwayVersion, error) {
+	local, err := s.pathwayRepo.GetByBlandID(ctx, blandID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.GetVersion", err)
+	}
+	if local == nil {
+		return nil, apperrors.NotFound("pathway version")
+	}
+
+	v, err := s.pathwayRepo.GetVersion(ctx, local.ID, version)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.GetVersion", err)
+	}
+	if v == nil {
+		return nil, apperrors.NotFound("pathway version")
+	}
+	return v, nil
+}
+
+// Diff compares two saved versions of a Bland pathway. A version of 0 is
+// the pathway as it currently is in Bland.
+func (s *PathwayVersionService) Diff(ctx context.Context, blandID string, from, to int) (*domain.PathwayDiff, error) {
+	if from < 0 || to < 0 {
+		return nil, apperrors.ValidationFailed("versions must not be negative")
+	}
+
+	fromVersion, err := s.diffSide(ctx, blandID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.diffSide(ctx, blandID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := domain.DiffPathwayVersions(fromVersion, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff pathway versions: %w", err)
+	}
+	return diff, nil
+}
+
+// Rollback restores a Bland pathway to a saved version. The current state is
+// snapshotted first so the rollback itself can be undone.
+func (s *PathwayVersionService) Rollback(ctx context.Context, blandID string, version int, createdBy string) (*bland.Pathway, error) {
+	target, err := s.GetVersion(ctx, blandID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &bland.UpdatePathwayRequest{
+		Name:        &target.Name,
+		Description: &target.Description,
+	}
+	if err := json.Unmarshal([]byte(target.NodesJSON), &req.Nodes); err != nil {
+		return nil, fmt.Errorf("invalid nodes in pathway version %d: %w", version, err)
+	}
+	if err := json.Unmarshal([]byte(target.EdgesJSON), &req.Edges); err != nil {
+		return nil, fmt.Errorf("invalid edges in pathway version %d: %w", version, err)
+	}
+
+	notes := fmt.Sprintf("Before rollback to version %d", version)
+	if _, err := s.Snapshot(ctx, blandID, notes, createdBy); err != nil {
+		return nil, fmt.Errorf("failed to snapshot pathway before rollback: %w", err)
+	}
+
+	pathway, err := s.source.UpdatePathway(ctx, blandID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("pathway rolled back",
+		zap.String("pathway_id", blandID),
+		zap.Int("version", version),
+	)
+	return pathway, nil
+}
+
+// diffSide loads one side of a diff: a saved version, or the live pathway
+// for version 0.
+func (s *PathwayVersionService) diffSide(ctx context.Context, blandID string, version int) (*domain.PathwayVersion, error) {
+	if version > 0 {
+		return s.GetVersion(ctx, blandID, version)
+	}
+
+	remote, err := s.source.GetPathway(ctx, blandID)
+	if err != nil {
+		return nil, err
+	}
+	return versionFromPathway(remote)
+}
+
+// localPathway returns the local record of a Bland pathway, creating it if
+// the pathway hasn't been synced yet.
+func (s *PathwayVersionService) localPathway(ctx context.Context, blandID string, remote *bland.Pathway) (*domain.Pathway, error) {
+	local, err := s.pathwayRepo.GetByBlandID(ctx, blandID)
+	if err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.localPathway", err)
+	}
+	if local != nil {
+		return local, nil
+	}
+
+	now := time.Now().UTC()
+	local = domain.NewPathway(remote.Name, remote.Description)
+	local.BlandID = blandID
+	if remote.Version > 0 {
+		local.Version = remote.Version
+	}
+	local.IsPublished = remote.IsProduction
+	local.Status = domain.PathwayStatusActive
+	local.LastSyncedAt = &now
+	if err := s.pathwayRepo.Create(ctx, local); err != nil {
+		return nil, apperrors.DatabaseError("PathwayVersionService.localPathway", err)
+	}
+	return local, nil
+}
+
+// versionFromPathway captures a Bland pathway's content as an unsaved
+// version.
+func versionFromPathway(p *bland.Pathway) (*domain.PathwayVersion, error) {
+	nodes := p.Nodes
+	if nodes == nil {
+		nodes = []bland.PathwayNode{}
+	}
+	edges := p.Edges
+	if edges == nil {
+		edges = []bland.PathwayEdge{}
+	}
+
+	nodesJSON, err := json.Marshal(nodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pathway nodes: %w", err)
+	}
+	edgesJSON, err := json.Marshal(edges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pathway edges: %w", err)
+	}
+
+	return &domain.PathwayVersion{
+		Name:        p.Name,
+		Description: p.Description,
+		NodesJSON:   string(nodesJSON),
+		EdgesJSON:   string(edgesJSON),
+	}, nil
+}
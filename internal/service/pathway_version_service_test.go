@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockPathwayRepository is an in-memory PathwayRepository covering the
+// lookups and versioning used by PathwayVersionService.
+type MockPathwayRepository struct {
+	domain.PathwayRepository
+	pathways []*domain.Pathway
+	versions []*domain.PathwayVersion
+}
+
+func (m *MockPathwayRepository) Create(ctx context.Context, pathway *domain.Pathway) error {
+	m.pathways = append(m.pathways, pathway)
+	return nil
+}
+
+func (m *MockPathwayRepository) GetByBlandID(ctx context.Context, blandID string) (*domain.Pathway, error) {
+	for _, p := range m.pathways {
+		if p.BlandID == blandID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPathwayRepository) SaveVersion(ctx context.Context, version *domain.PathwayVersion) error {
+	m.versions = append(m.versions, version)
+	return nil
+}
+
+func (m *MockPathwayRepository) GetVersion(ctx context.Context, pathwayID uuid.UUID, version int) (*domain.PathwayVersion, error) {
+	for _, v := range m.versions {
+		if v.PathwayID == pathwayID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockPathwayRepository) ListVersions(ctx context.Context, pathwayID uuid.UUID) ([]*domain.PathwayVersion, error) {
+	var versions []*domain.PathwayVersion
+	for _, v := range m.versions {
+		if v.PathwayID == pathwayID {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+	return versions, nil
+}
+
+// fakePathwaySource holds one Bland pathway in memory.
+type fakePathwaySource struct {
+	pathway bland.Pathway
+	updates []*bland.UpdatePathwayRequest
+}
+
+func (f *fakePathwaySource) GetPathway(ctx context.Context, pathwayID string) (*bland.Pathway, error) {
+	if pathwayID != f.pathway.ID {
+		return nil, apperrors.NotFound("pathway")
+	}
+	p := f.pathway
+	return &p, nil
+}
+
+func (f *fakePathwaySource) UpdatePathway(ctx context.Context, pathwayID string, req *bland.UpdatePathwayRequest) (*bland.Pathway, error) {
+	f.updates = append(f.updates, req)
+	if req.Name != nil {
+		f.pathway.Name = *req.Name
+	}
+	if req.Description != nil {
+		f.pathway.Description = *req.Description
+	}
+	f.pathway.Nodes = req.Nodes
+	f.pathway.Edges = req.Edges
+	p := f.pathway
+	return &p, nil
+}
+
+func newTestPathwayVersionService() (*PathwayVersionService, *fakePathwaySource, *MockPathwayRepository) {
+	source := &fakePathwaySource{pathway: bland.Pathway{
+		ID:   "pw-1",
+		Name: "Intake",
+		Nodes: []bland.PathwayNode{
+			{ID: "start", Name: "Start", Type: "default", Data: &bland.NodeData{Prompt: "Hi"}},
+		},
+		Edges: []bland.PathwayEdge{},
+	}}
+	repo := &MockPathwayRepository{}
+	return NewPathwayVersionService(source, repo, zap.NewNop()), source, repo
+}
+
+func TestPathwayVersionService_Snapshot(t *testing.T) {
+	svc, _, repo := newTestPathwayVersionService()
+	ctx := context.Background()
+
+	v1, err := svc.Snapshot(ctx, "pw-1", "first", "admin@example.com")
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	v2, err := svc.Snapshot(ctx, "pw-1", "", "")
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	if v1.Version != 1 || v2.Version != 2 {
+		t.Errorf("versions = %d, %d; want 1, 2", v1.Version, v2.Version)
+	}
+	if len(repo.pathways) != 1 || repo.pathways[0].BlandID != "pw-1" {
+		t.Fatalf("expected one local pathway for pw-1, got %+v", repo.pathways)
+	}
+	if v1.PathwayID != repo.pathways[0].ID || v1.Name != "Intake" || v1.CreatedBy != "admin@example.com" {
+		t.Errorf("unexpected snapshot %+v", v1)
+	}
+	if !strings.Contains(v1.NodesJSON, `"prompt":"Hi"`) || v1.EdgesJSON != "[]" {
+		t.Errorf("snapshot content = %s / %s", v1.NodesJSON, v1.EdgesJSON)
+	}
+}
+
+func TestPathwayVersionService_DiffAgainstLive(t *testing.T) {
+	svc, source, _ := newTestPathwayVersionService()
+	ctx := context.Background()
+
+	if _, err := svc.Snapshot(ctx, "pw-1", "", ""); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	source.pathway.Nodes = append(source.pathway.Nodes, bland.PathwayNode{ID: "end", Type: "end_call"})
+
+	diff, err := svc.Diff(ctx, "pw-1", 1, 0)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0].ID != "end" || diff.Changes[0].Change != domain.PathwayChangeAdded {
+		t.Errorf("changes = %+v, want the added end node", diff.Changes)
+	}
+
+	if _, err := svc.Diff(ctx, "pw-1", 1, 7); !apperrors.IsNotFound(err) {
+		t.Errorf("Diff() with a missing version error = %v, want not found", err)
+	}
+}
+
+func TestPathwayVersionService_Rollback(t *testing.T) {
+	svc, source, repo := newTestPathwayVersionService()
+	ctx := context.Background()
+
+	if _, err := svc.Snapshot(ctx, "pw-1", "", ""); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	source.pathway.Name = "Intake (broken)"
+	source.pathway.Nodes = nil
+
+	pathway, err := svc.Rollback(ctx, "pw-1", 1, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if pathway.Name != "Intake" || len(pathway.Nodes) != 1 || pathway.Nodes[0].Data.Prompt != "Hi" {
+		t.Errorf("pathway after rollback = %+v", pathway)
+	}
+
+	if len(repo.versions) != 2 {
+		t.Fatalf("expected the pre-rollback state to be saved, got %d versions", len(repo.versions))
+	}
+	saved := repo.versions[1]
+	if saved.Version != 2 || saved.Name != "Intake (broken)" || saved.ChangeNotes != "Before rollback to version 1" {
+		t.Errorf("pre-rollback snapshot = %+v", saved)
+	}
+
+	if _, err := svc.Rollback(ctx, "pw-1", 9, ""); !apperrors.IsNotFound(err) {
+		t.Errorf("Rollback() to a missing version error = %v, want not found", err)
+	}
+	if len(source.updates) != 1 {
+		t.Errorf("expected one Bland update, got %d", len(source.updates))
+	}
+}
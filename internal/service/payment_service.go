@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/payments"
+)
+
+// PaymentConfig holds quote payment settings.
+type PaymentConfig struct {
+	// DepositPercent is the share of the quote total requested; 100 asks
+	// for the full amount.
+	DepositPercent float64
+	// Currency is the ISO 4217 code of quotes with no currency recorded.
+	Currency string
+}
+
+// PaymentService collects payment for accepted quotes through a payment
+// processor's hosted links.
+type PaymentService struct {
+	repo      domain.QuotePaymentRepository
+	quotes    *QuoteService
+	processor payments.Processor
+	config    PaymentConfig
+	logger    *zap.Logger
+
+	clock clock.Clock
+}
+
+// NewPaymentService creates a new PaymentService.
+func NewPaymentService(repo domain.QuotePaymentRepository, quotes *QuoteService, processor payments.Processor, config PaymentConfig, logger *zap.Logger, clk clock.Clock) (*PaymentService, error) {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config.DepositPercent == 0 {
+		config.DepositPercent = 100
+	}
+	if err := domain.ValidateDepositPercent(config.DepositPercent); err != nil {
+		return nil, err
+	}
+	config.Currency = strings.ToUpper(strings.TrimSpace(config.Currency))
+	if config.Currency == "" {
+		config.Currency = "USD"
+	}
+	return &PaymentService{
+		repo:      repo,
+		quotes:    quotes,
+		processor: processor,
+		config:    config,
+		logger:    logger,
+		clock:     clk,
+	}, nil
+}
+
+// CreatePaymentLink creates a payment link for the deposit on an accepted
+// quote, replacing any unpaid link made earlier.
+func (s *PaymentService) CreatePaymentLink(ctx context.Context, callID uuid.UUID) (*domain.QuotePayment, error) {
+	detail, err := s.quotes.GetQuote(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Status != domain.QuoteStatusAccepted {
+		return nil, apperrors.New(apperrors.CodeConflict,
+			fmt.Sprintf("quote is %s; only accepted quotes can be paid", detail.Status))
+	}
+	if detail.Payment != nil && detail.Payment.Status == domain.QuotePaymentPaid {
+		return nil, apperrors.New(apperrors.CodeConflict, "quote has already been paid")
+	}
+
+	currency := detail.Currency
+	if currency == "" {
+		currency = s.config.Currency
+	}
+	now := s.clock.Now().UTC()
+	payment := &domain.QuotePayment{
+		ID:             uuid.New(),
+		CallID:         callID,
+		Provider:       s.processor.Name(),
+		Amount:         domain.DepositAmount(detail.Total, s.config.DepositPercent, currency),
+		DepositPercent: s.config.DepositPercent,
+		Currency:       currency,
+		Status:         domain.QuotePaymentPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if payment.Amount <= 0 {
+		return nil, apperrors.ValidationFailed("quote has no total to collect")
+	}
+
+	description := "Quote " + detail.QuoteNumber
+	if payment.IsDeposit() {
+		description = fmt.Sprintf("%g%% deposit for quote %s", payment.DepositPercent, detail.QuoteNumber)
+	}
+	link, err := s.processor.CreateLink(ctx, &payments.LinkRequest{
+		// The payment ID keeps a retried request from reusing an earlier
+		// link for a different amount.
+		Reference:   payment.ID.String(),
+		Description: description,
+		Amount:      payment.AmountMinorUnits(),
+		Currency:    payment.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment link: %w", err)
+	}
+	payment.ProviderLinkID = link.ID
+	payment.URL = link.URL
+
+	if err := s.repo.Replace(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to save quote payment: %w", err)
+	}
+
+	s.logger.Info("quote payment link created",
+		zap.String("call_id", callID.String()),
+		zap.String("provider", payment.Provider),
+		zap.Float64("amount", payment.Amount),
+	)
+	return payment, nil
+}
+
+// GetPayment returns the payment for a quote.
+func (s *PaymentService) GetPayment(ctx context.Context, callID uuid.UUID) (*domain.QuotePayment, error) {
+	return s.repo.GetByCallID(ctx, callID)
+}
+
+// HandleWebhook verifies a processor webhook and marks the quote it pays
+// for as paid. Events that pay nothing we know of are ignored, and repeated
+// deliveries are harmless. It returns payments.ErrInvalidSignature for
+// webhooks that were not signed by the processor.
+func (s *PaymentService) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := s.processor.ParseWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return nil
+	}
+
+	payment, err := s.repo.GetByProviderLinkID(ctx, s.processor.Name(), event.LinkID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			// Replaced links, or links made outside this app.
+			s.logger.Info("ignoring payment for unknown link", zap.String("link_id", event.LinkID))
+			return nil
+		}
+		return fmt.Errorf("failed to get quote payment: %w", err)
+	}
+	if payment.Status == domain.QuotePaymentPaid {
+		return nil
+	}
+
+	payment.MarkPaid(event.PaymentID, event.AmountPaid, s.clock.Now().UTC())
+	if err := s.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to save quote payment: %w", err)
+	}
+
+	s.logger.Info("quote paid",
+		zap.String("call_id", payment.CallID.String()),
+		zap.String("payment_id", payment.PaymentID),
+		zap.Float64("amount_paid", payment.AmountPaid),
+	)
+	return nil
+}
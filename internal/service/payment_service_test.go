@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/payments"
+)
+
+// mockQuotePaymentRepository is an in-memory QuotePaymentRepository.
+type mockQuotePaymentRepository struct {
+	mu       sync.Mutex
+	payments map[uuid.UUID]*domain.QuotePayment // by call ID
+}
+
+func newMockQuotePaymentRepository() *mockQuotePaymentRepository {
+	return &mockQuotePaymentRepository{payments: make(map[uuid.UUID]*domain.QuotePayment)}
+}
+
+func (m *mockQuotePaymentRepository) Replace(ctx context.Context, payment *domain.QuotePayment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *payment
+	m.payments[payment.CallID] = &cp
+	return nil
+}
+
+func (m *mockQuotePaymentRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.QuotePayment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.payments[callID]; ok {
+		cp := *p
+		return &cp, nil
+	}
+	return nil, apperrors.NotFound("quote payment")
+}
+
+func (m *mockQuotePaymentRepository) GetByProviderLinkID(ctx context.Context, provider, linkID string) (*domain.QuotePayment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.payments {
+		if p.Provider == provider && p.ProviderLinkID == linkID {
+			cp := *p
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("quote payment")
+}
+
+func (m *mockQuotePaymentRepository) Update(ctx context.Context, payment *domain.QuotePayment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.payments[payment.CallID]; !ok {
+		return apperrors.NotFound("quote payment")
+	}
+	cp := *payment
+	m.payments[payment.CallID] = &cp
+	return nil
+}
+
+// fakePaymentProcessor records link requests and returns a queued event
+// for any webhook.
+type fakePaymentProcessor struct {
+	requests []*payments.LinkRequest
+	event    *payments.Event
+	err      error
+}
+
+func (f *fakePaymentProcessor) CreateLink(ctx context.Context, req *payments.LinkRequest) (*payments.Link, error) {
+	f.requests = append(f.requests, req)
+	id := fmt.Sprintf("plink_%d", len(f.requests))
+	return &payments.Link{ID: id, URL: "https://pay.example.com/" + id}, nil
+}
+
+func (f *fakePaymentProcessor) ParseWebhook(payload []byte, header http.Header) (*payments.Event, error) {
+	return f.event, f.err
+}
+
+func (f *fakePaymentProcessor) Name() string {
+	return "fake"
+}
+
+// newPaymentTestService returns a payment service over a $1,200 quote.
+// The quote is accepted when accept is set.
+func newPaymentTestService(t *testing.T, depositPercent float64, accept bool) (*PaymentService, *QuoteService, *fakePaymentProcessor, uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	quotes, _, _, callID := newQuoteTestService(t, "- Build: $1,200", QuoteApprovalConfig{})
+	if accept {
+		staff := quoteActor("staff@example.com")
+		for _, step := range []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){
+			quotes.Submit, quotes.Approve, quotes.MarkSent, quotes.MarkAccepted,
+		} {
+			if _, err := step(ctx, callID, staff, ""); err != nil {
+				t.Fatalf("prepare quote: %v", err)
+			}
+		}
+	}
+
+	repo := newMockQuotePaymentRepository()
+	quotes.SetPayments(repo)
+	processor := &fakePaymentProcessor{}
+	svc, err := NewPaymentService(repo, quotes, processor, PaymentConfig{DepositPercent: depositPercent, Currency: "usd"}, zap.NewNop(), nil)
+	if err != nil {
+		t.Fatalf("new payment service: %v", err)
+	}
+	return svc, quotes, processor, callID
+}
+
+func TestNewPaymentService_InvalidDeposit(t *testing.T) {
+	if _, err := NewPaymentService(newMockQuotePaymentRepository(), nil, &fakePaymentProcessor{}, PaymentConfig{DepositPercent: 150}, zap.NewNop(), nil); err == nil {
+		t.Error("expected error for a deposit above 100%")
+	}
+}
+
+func TestPaymentService_CreatePaymentLink(t *testing.T) {
+	svc, quotes, processor, callID := newPaymentTestService(t, 25, true)
+	ctx := context.Background()
+
+	payment, err := svc.CreatePaymentLink(ctx, callID)
+	if err != nil {
+		t.Fatalf("create payment link: %v", err)
+	}
+	if payment.Amount != 300 || payment.Currency != "USD" || payment.Status != domain.QuotePaymentPending || !payment.IsDeposit() {
+		t.Errorf("expected a pending $300 deposit, got %+v", payment)
+	}
+	if len(processor.requests) != 1 || processor.requests[0].Amount != 30000 || processor.requests[0].Reference != payment.ID.String() {
+		t.Errorf("unexpected link request %+v", processor.requests)
+	}
+
+	detail, err := quotes.GetQuote(ctx, callID)
+	if err != nil {
+		t.Fatalf("get quote: %v", err)
+	}
+	if detail.Payment == nil || detail.Payment.URL != payment.URL {
+		t.Errorf("expected the quote to show its payment, got %+v", detail.Payment)
+	}
+
+	// A new link replaces the unpaid one.
+	again, err := svc.CreatePaymentLink(ctx, callID)
+	if err != nil {
+		t.Fatalf("create second payment link: %v", err)
+	}
+	if current, _ := svc.GetPayment(ctx, callID); current.ProviderLinkID != again.ProviderLinkID || again.ProviderLinkID == payment.ProviderLinkID {
+		t.Errorf("expected the new link to replace the old one, got %+v", current)
+	}
+}
+
+func TestPaymentService_CreatePaymentLinkRequiresAcceptedQuote(t *testing.T) {
+	svc, _, processor, callID := newPaymentTestService(t, 100, false)
+
+	if _, err := svc.CreatePaymentLink(context.Background(), callID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict for a draft quote, got %v", err)
+	}
+	if len(processor.requests) != 0 {
+		t.Errorf("expected no link to be created, got %d", len(processor.requests))
+	}
+}
+
+func TestPaymentService_HandleWebhook(t *testing.T) {
+	svc, _, processor, callID := newPaymentTestService(t, 100, true)
+	ctx := context.Background()
+	paidAt := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	svc.clock = clock.NewMock(paidAt)
+
+	payment, err := svc.CreatePaymentLink(ctx, callID)
+	if err != nil {
+		t.Fatalf("create payment link: %v", err)
+	}
+
+	processor.event = &payments.Event{LinkID: payment.ProviderLinkID, PaymentID: "pi_1", AmountPaid: 120000}
+	if err := svc.HandleWebhook(ctx, nil, nil); err != nil {
+		t.Fatalf("handle webhook: %v", err)
+	}
+	paid, _ := svc.GetPayment(ctx, callID)
+	if paid.Status != domain.QuotePaymentPaid || paid.PaymentID != "pi_1" || paid.AmountPaid != 1200 || paid.PaidAt == nil || !paid.PaidAt.Equal(paidAt) {
+		t.Errorf("expected the quote to be marked paid, got %+v", paid)
+	}
+
+	// Redelivery keeps the first payment.
+	processor.event = &payments.Event{LinkID: payment.ProviderLinkID, PaymentID: "pi_2", AmountPaid: 120000}
+	if err := svc.HandleWebhook(ctx, nil, nil); err != nil {
+		t.Fatalf("handle repeated webhook: %v", err)
+	}
+	if again, _ := svc.GetPayment(ctx, callID); again.PaymentID != "pi_1" {
+		t.Errorf("expected the first payment to be kept, got %q", again.PaymentID)
+	}
+
+	if _, err := svc.CreatePaymentLink(ctx, callID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict for a paid quote, got %v", err)
+	}
+}
+
+func TestPaymentService_HandleWebhookIgnored(t *testing.T) {
+	svc, _, processor, _ := newPaymentTestService(t, 100, true)
+	ctx := context.Background()
+
+	// Events that complete no payment, and payments for unknown links.
+	if err := svc.HandleWebhook(ctx, nil, nil); err != nil {
+		t.Errorf("expected no error for an ignored event, got %v", err)
+	}
+	processor.event = &payments.Event{LinkID: "plink_other"}
+	if err := svc.HandleWebhook(ctx, nil, nil); err != nil {
+		t.Errorf("expected no error for an unknown link, got %v", err)
+	}
+
+	processor.err = payments.ErrInvalidSignature
+	if err := svc.HandleWebhook(ctx, nil, nil); !errors.Is(err, payments.ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// PricingExtractor reads the facts a pricing rule needs from a call.
+type PricingExtractor interface {
+	ExtractPricingInputs(ctx context.Context, transcript string, extractedData *domain.ExtractedData, rule *domain.PricingRule) (*domain.PricingInputs, error)
+}
+
+// QuoteTemplateSource finds the quote template for a project type.
+// QuoteTemplateService implements it.
+type QuoteTemplateSource interface {
+	TemplateFor(ctx context.Context, projectType string) (*domain.QuoteTemplate, error)
+}
+
+// PricingService manages pricing rules and prices calls with them. AI output
+// is only used to extract pricing inputs; amounts always come from the rules.
+type PricingService struct {
+	rules     domain.PricingRuleRepository
+	extractor PricingExtractor
+	templates QuoteTemplateSource
+	logger    *zap.Logger
+}
+
+// NewPricingService creates a new PricingService.
+func NewPricingService(rules domain.PricingRuleRepository, extractor PricingExtractor, logger *zap.Logger) *PricingService {
+	return &PricingService{
+		rules:     rules,
+		extractor: extractor,
+		logger:    logger,
+	}
+}
+
+// SetTemplates adds the default line items of the project type's quote
+// template to each estimate.
+func (s *PricingService) SetTemplates(templates QuoteTemplateSource) {
+	s.templates = templates
+}
+
+// PricingRuleRequest holds the fields for creating or replacing a pricing rule.
+type PricingRuleRequest struct {
+	ProjectType string                     `json:"project_type"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description,omitempty"`
+	BaseRate    float64                    `json:"base_rate"`
+	UnitRates   []domain.PricingUnitRate   `json:"unit_rates"`
+	Multipliers []domain.PricingMultiplier `json:"multipliers"`
+	IsActive    bool                       `json:"is_active"`
+}
+
+// ListRules returns all pricing rules.
+func (s *PricingService) ListRules(ctx context.Context) ([]*domain.PricingRule, error) {
+	return s.rules.List(ctx)
+}
+
+// GetRule retrieves a pricing rule by ID.
+func (s *PricingService) GetRule(ctx context.Context, id uuid.UUID) (*domain.PricingRule, error) {
+	return s.rules.GetByID(ctx, id)
+}
+
+// CreateRule validates and stores a new pricing rule.
+func (s *PricingService) CreateRule(ctx context.Context, req *PricingRuleRequest) (*domain.PricingRule, error) {
+	rule := domain.NewPricingRule(req.ProjectType, "", 0)
+	applyPricingRuleRequest(rule, req)
+	if err := rule.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if err := s.rules.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("pricing rule created",
+		zap.String("rule_id", rule.ID.String()),
+		zap.String("project_type", rule.ProjectType),
+	)
+	return rule, nil
+}
+
+// UpdateRule replaces the fields of a pricing rule.
+func (s *PricingService) UpdateRule(ctx context.Context, id uuid.UUID, req *PricingRuleRequest) (*domain.PricingRule, error) {
+	rule, err := s.rules.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyPricingRuleRequest(rule, req)
+	if err := rule.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	rule.UpdatedAt = time.Now().UTC()
+
+	if err := s.rules.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("pricing rule updated", zap.String("rule_id", rule.ID.String()))
+	return rule, nil
+}
+
+// DeleteRule removes a pricing rule.
+func (s *PricingService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if err := s.rules.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("pricing rule deleted", zap.String("rule_id", id.String()))
+	return nil
+}
+
+// RuleFor returns the active rule for a project type, falling back to the
+// default rule. It returns a not-found error when neither exists.
+func (s *PricingService) RuleFor(ctx context.Context, projectType string) (*domain.PricingRule, error) {
+	if normalized := domain.NormalizeProjectType(projectType); normalized != "" && normalized != domain.DefaultPricingProjectType {
+		rule, err := s.rules.GetActiveByProjectType(ctx, normalized)
+		if err == nil {
+			return rule, nil
+		}
+		if !apperrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return s.rules.GetActiveByProjectType(ctx, domain.DefaultPricingProjectType)
+}
+
+// Estimate prices a call in the currency recorded on it. Pricing inputs are
+// extracted from the transcript and recorded on the call's extracted data so
+// the price can be reproduced.
+// It returns nil without error when no pricing rule applies.
+func (s *PricingService) Estimate(ctx context.Context, call *domain.Call) (*domain.PriceEstimate, error) {
+	if call.ExtractedData == nil {
+		call.ExtractedData = &domain.ExtractedData{}
+	}
+
+	rule, err := s.RuleFor(ctx, call.ExtractedData.ProjectType)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find pricing rule: %w", err)
+	}
+
+	transcript := ""
+	if call.Transcript != nil {
+		transcript = *call.Transcript
+	}
+	inputs, err := s.extractor.ExtractPricingInputs(ctx, transcript, call.ExtractedData, rule)
+	if err != nil {
+		return nil, err
+	}
+	call.ExtractedData.PricingInputs = inputs
+
+	estimate := rule.Price(*inputs)
+	estimate.Currency = call.Currency()
+	s.addTemplateLines(ctx, call, estimate)
+	s.logger.Debug("call priced",
+		zap.String("call_id", call.ID.String()),
+		zap.String("rule_id", rule.ID.String()),
+		zap.Float64("total", estimate.Total),
+	)
+	return estimate, nil
+}
+
+// addTemplateLines adds the default line items of the call's quote
+// template. A template that can't be loaded leaves the estimate as priced
+// by the rule rather than failing the quote.
+func (s *PricingService) addTemplateLines(ctx context.Context, call *domain.Call, estimate *domain.PriceEstimate) {
+	if s.templates == nil {
+		return
+	}
+	template, err := s.templates.TemplateFor(ctx, call.ExtractedData.ProjectType)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			s.logger.Warn("failed to load quote template",
+				zap.String("call_id", call.ID.String()),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+	template.AddDefaultLines(estimate)
+}
+
+// PricingSection renders an estimate as the pricing section appended to a
+// quote summary. Priced lines are bullets ending in their amount so they are
+// picked up as quote line items; a nil estimate renders a placeholder.
+func PricingSection(estimate *domain.PriceEstimate) string {
+	var b strings.Builder
+	b.WriteString("## Pricing\n\n")
+	if estimate == nil || len(estimate.Lines) == 0 {
+		b.WriteString("Pricing for this project will be confirmed by our team.\n")
+		return b.String()
+	}
+
+	for _, line := range estimate.Lines {
+		desc := line.Description
+		if line.Quantity != 1 {
+			desc += " (×" + strconv.FormatFloat(line.Quantity, 'f', -1, 64) + ")"
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", desc, quotepdf.FormatMoney(line.Amount, estimate.Currency))
+	}
+	if len(estimate.Multipliers) > 0 {
+		labels := make([]string, len(estimate.Multipliers))
+		for i, m := range estimate.Multipliers {
+			labels[i] = m.Label + " ×" + strconv.FormatFloat(m.Factor, 'f', -1, 64)
+		}
+		fmt.Fprintf(&b, "\nIncludes adjustments: %s.\n", strings.Join(labels, ", "))
+	}
+	fmt.Fprintf(&b, "\n**Total: %s**\n", quotepdf.FormatMoney(estimate.Total, estimate.Currency))
+	return b.String()
+}
+
+// appendPricingSection joins a generated quote and its pricing section.
+func appendPricingSection(quote string, estimate *domain.PriceEstimate) string {
+	return strings.TrimRight(quote, "\n") + "\n\n" + PricingSection(estimate)
+}
+
+// applyPricingRuleRequest copies a request onto a rule, trimming text fields.
+func applyPricingRuleRequest(rule *domain.PricingRule, req *PricingRuleRequest) {
+	rule.ProjectType = domain.NormalizeProjectType(req.ProjectType)
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Description = strings.TrimSpace(req.Description)
+	rule.BaseRate = req.BaseRate
+	rule.IsActive = req.IsActive
+
+	rule.UnitRates = make([]domain.PricingUnitRate, 0, len(req.UnitRates))
+	for _, u := range req.UnitRates {
+		u.Key = strings.TrimSpace(u.Key)
+		u.Label = strings.TrimSpace(u.Label)
+		rule.UnitRates = append(rule.UnitRates, u)
+	}
+	rule.Multipliers = make([]domain.PricingMultiplier, 0, len(req.Multipliers))
+	for _, m := range req.Multipliers {
+		m.Key = strings.TrimSpace(m.Key)
+		m.Label = strings.TrimSpace(m.Label)
+		rule.Multipliers = append(rule.Multipliers, m)
+	}
+}
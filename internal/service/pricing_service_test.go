@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// MockPricingRuleRepository is an in-memory PricingRuleRepository.
+type MockPricingRuleRepository struct {
+	mu    sync.Mutex
+	rules map[uuid.UUID]*domain.PricingRule
+}
+
+func NewMockPricingRuleRepository() *MockPricingRuleRepository {
+	return &MockPricingRuleRepository{rules: make(map[uuid.UUID]*domain.PricingRule)}
+}
+
+func (m *MockPricingRuleRepository) Create(ctx context.Context, rule *domain.PricingRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.rules {
+		if r.ProjectType == rule.ProjectType {
+			return apperrors.New(apperrors.CodeAlreadyExists, "a pricing rule for this project type already exists")
+		}
+	}
+	cp := *rule
+	m.rules[rule.ID] = &cp
+	return nil
+}
+
+func (m *MockPricingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PricingRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.rules[id]
+	if !ok {
+		return nil, apperrors.NotFound("pricing rule")
+	}
+	cp := *r
+	return &cp, nil
+}
+
+func (m *MockPricingRuleRepository) GetActiveByProjectType(ctx context.Context, projectType string) (*domain.PricingRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.rules {
+		if r.ProjectType == projectType && r.IsActive {
+			cp := *r
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("pricing rule")
+}
+
+func (m *MockPricingRuleRepository) Update(ctx context.Context, rule *domain.PricingRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.rules[rule.ID]; !ok {
+		return apperrors.NotFound("pricing rule")
+	}
+	cp := *rule
+	m.rules[rule.ID] = &cp
+	return nil
+}
+
+func (m *MockPricingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.rules[id]; !ok {
+		return apperrors.NotFound("pricing rule")
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *MockPricingRuleRepository) List(ctx context.Context) ([]*domain.PricingRule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var rules []*domain.PricingRule
+	for _, r := range m.rules {
+		cp := *r
+		rules = append(rules, &cp)
+	}
+	return rules, nil
+}
+
+// mockPricingExtractor returns fixed pricing inputs.
+type mockPricingExtractor struct {
+	inputs *domain.PricingInputs
+	err    error
+	rule   *domain.PricingRule // Rule passed on the last call
+}
+
+func (m *mockPricingExtractor) ExtractPricingInputs(ctx context.Context, transcript string, extractedData *domain.ExtractedData, rule *domain.PricingRule) (*domain.PricingInputs, error) {
+	m.rule = rule
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.inputs, nil
+}
+
+func newTestPricingService(t *testing.T) (*PricingService, *mockPricingExtractor) {
+	t.Helper()
+	extractor := &mockPricingExtractor{inputs: &domain.PricingInputs{
+		Quantities:  map[string]float64{"page": 3},
+		Multipliers: []string{"rush"},
+	}}
+	svc := NewPricingService(NewMockPricingRuleRepository(), extractor, zap.NewNop())
+
+	_, err := svc.CreateRule(context.Background(), &PricingRuleRequest{
+		ProjectType: domain.DefaultPricingProjectType,
+		Name:        "Project setup",
+		BaseRate:    2000,
+		UnitRates:   []domain.PricingUnitRate{{Key: "page", Label: "Pages", Rate: 500}},
+		Multipliers: []domain.PricingMultiplier{{Key: "rush", Label: "Rush delivery", Factor: 1.5}},
+		IsActive:    true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create default rule: %v", err)
+	}
+	return svc, extractor
+}
+
+func TestPricingService_CreateRuleValidates(t *testing.T) {
+	svc, _ := newTestPricingService(t)
+
+	_, err := svc.CreateRule(context.Background(), &PricingRuleRequest{
+		ProjectType: "Mobile App",
+		Name:        "App build",
+		UnitRates:   []domain.PricingUnitRate{{Key: "Screen", Label: "Screens", Rate: 100}},
+	})
+	if !apperrors.IsUserError(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestPricingService_RuleFor(t *testing.T) {
+	svc, _ := newTestPricingService(t)
+	ctx := context.Background()
+
+	app, err := svc.CreateRule(ctx, &PricingRuleRequest{
+		ProjectType: "  Mobile   App ",
+		Name:        "App build",
+		BaseRate:    8000,
+		IsActive:    true,
+	})
+	if err != nil {
+		t.Fatalf("CreateRule failed: %v", err)
+	}
+
+	rule, err := svc.RuleFor(ctx, "mobile app")
+	if err != nil || rule.ID != app.ID {
+		t.Errorf("expected the mobile app rule, got %+v, %v", rule, err)
+	}
+
+	rule, err = svc.RuleFor(ctx, "Landscaping")
+	if err != nil || rule.ProjectType != domain.DefaultPricingProjectType {
+		t.Errorf("expected the default rule, got %+v, %v", rule, err)
+	}
+}
+
+func TestPricingService_Estimate(t *testing.T) {
+	svc, extractor := newTestPricingService(t)
+
+	transcript := "Three pages, and I need it next week."
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	call.Transcript = &transcript
+
+	estimate, err := svc.Estimate(context.Background(), call)
+	if err != nil {
+		t.Fatalf("Estimate failed: %v", err)
+	}
+	if extractor.rule == nil || extractor.rule.ProjectType != domain.DefaultPricingProjectType {
+		t.Errorf("extractor should be given the default rule, got %+v", extractor.rule)
+	}
+	// (2000 + 3 x 500) x 1.5
+	if estimate.Total != 5250 {
+		t.Errorf("Total = %v, want 5250", estimate.Total)
+	}
+	if call.ExtractedData == nil || call.ExtractedData.PricingInputs != extractor.inputs {
+		t.Error("pricing inputs should be recorded on the call")
+	}
+}
+
+func TestPricingService_EstimateWithoutRules(t *testing.T) {
+	svc := NewPricingService(NewMockPricingRuleRepository(), &mockPricingExtractor{}, zap.NewNop())
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+
+	estimate, err := svc.Estimate(context.Background(), call)
+	if err != nil || estimate != nil {
+		t.Errorf("expected no estimate and no error, got %+v, %v", estimate, err)
+	}
+}
+
+func TestPricingSection(t *testing.T) {
+	estimate := &domain.PriceEstimate{
+		Lines: []domain.PriceLine{
+			{Description: "Project setup", Quantity: 1, UnitPrice: 3000, Amount: 3000},
+			{Description: "Pages", Quantity: 3, UnitPrice: 750, Amount: 2250},
+		},
+		Multipliers: []domain.PricingMultiplier{{Key: "rush", Label: "Rush delivery", Factor: 1.5}},
+		Total:       5250,
+	}
+
+	section := PricingSection(estimate)
+	if !strings.Contains(section, "**Total: $5,250.00**") || !strings.Contains(section, "Rush delivery ×1.5") {
+		t.Errorf("unexpected section:\n%s", section)
+	}
+
+	// The priced lines must be read back as the quote's line items.
+	items := quotepdf.ParseLineItems(section)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 line items, got %+v", items)
+	}
+	if items[1].Description != "Pages (×3)" || items[1].UnitPrice != 2250 {
+		t.Errorf("unexpected line item %+v", items[1])
+	}
+
+	if !strings.Contains(PricingSection(nil), "confirmed by our team") {
+		t.Error("expected a placeholder without an estimate")
+	}
+
+	estimate.Currency = "EUR"
+	section = PricingSection(estimate)
+	if !strings.Contains(section, "**Total: €5,250.00**") {
+		t.Errorf("expected the total in euros:\n%s", section)
+	}
+	if got := quotepdf.ParseLineItems(section); len(got) != 2 {
+		t.Errorf("expected euro line items read back, got %+v", got)
+	}
+}
+
+func TestQuoteJobProcessor_ProcessJob_AppendsPricing(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	svc, _ := newTestPricingService(t)
+	processor.SetPricing(svc)
+	ctx := context.Background()
+
+	transcript := "Three pages, and I need it next week."
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	callRepo.Create(ctx, call)
+	job := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, job)
+
+	processor.processJob(ctx, job)
+
+	updatedCall, _ := callRepo.GetByID(ctx, call.ID)
+	if updatedCall.QuoteSummary == nil || !strings.HasPrefix(*updatedCall.QuoteSummary, quoteGen.GeneratedQuote) {
+		t.Fatalf("expected the generated quote, got %v", updatedCall.QuoteSummary)
+	}
+	if !strings.Contains(*updatedCall.QuoteSummary, "**Total: $5,250.00**") {
+		t.Errorf("expected the pricing section, got:\n%s", *updatedCall.QuoteSummary)
+	}
+	if updatedCall.ExtractedData == nil || updatedCall.ExtractedData.PricingInputs == nil {
+		t.Error("expected pricing inputs to be saved with the call")
+	}
+}
+
+func TestQuoteJobProcessor_ProcessJob_PricingFailureRetries(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	svc, extractor := newTestPricingService(t)
+	extractor.err = errors.New("AI unavailable")
+	processor.SetPricing(svc)
+	ctx := context.Background()
+
+	transcript := "Three pages."
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	callRepo.Create(ctx, call)
+	job := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, job)
+
+	processor.processJob(ctx, job)
+
+	updatedJob, _ := jobRepo.GetByID(ctx, job.ID)
+	if updatedJob.Status != domain.QuoteJobStatusPending {
+		t.Errorf("expected job to be scheduled for retry, got %s", updatedJob.Status)
+	}
+	if quoteGen.GenerateQuoteCalls != 0 {
+		t.Error("quote should not be generated when pricing fails")
+	}
+}
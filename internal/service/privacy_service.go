@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// PrivacyProvider is what the voice provider remembers about phone numbers.
+// BlandService implements it.
+type PrivacyProvider interface {
+	GetCustomerMemory(ctx context.Context, phoneNumber string) (map[string]interface{}, error)
+	ClearCustomerMemory(ctx context.Context, phoneNumber string) error
+	GetSMSHistory(ctx context.Context, phoneNumber string) ([]bland.SMS, error)
+}
+
+// RecordingPurger deletes stored call recordings. RecordingService
+// implements it.
+type RecordingPurger interface {
+	Purge(ctx context.Context, callID uuid.UUID) (bool, error)
+}
+
+// PrivacyService answers data subject requests: exporting everything held
+// about a customer, or deleting it and producing a signed report of what was
+// removed.
+type PrivacyService struct {
+	repo       domain.PrivacyRepository
+	customers  domain.CustomerRepository
+	quotes     domain.QuoteRepository
+	recordings domain.RecordingRepository
+	followUps  domain.FollowUpRepository
+	dnc        domain.DNCRepository
+	signingKey []byte
+	logger     *zap.Logger
+
+	provider PrivacyProvider
+	purger   RecordingPurger
+
+	clock clock.Clock
+}
+
+// NewPrivacyService creates a new PrivacyService. Deletion reports are
+// signed with signingKey.
+func NewPrivacyService(
+	repo domain.PrivacyRepository,
+	customers domain.CustomerRepository,
+	quotes domain.QuoteRepository,
+	recordings domain.RecordingRepository,
+	followUps domain.FollowUpRepository,
+	dnc domain.DNCRepository,
+	signingKey []byte,
+	logger *zap.Logger,
+	clk clock.Clock,
+) *PrivacyService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &PrivacyService{
+		repo:       repo,
+		customers:  customers,
+		quotes:     quotes,
+		recordings: recordings,
+		followUps:  followUps,
+		dnc:        dnc,
+		signingKey: signingKey,
+		logger:     logger,
+		clock:      clk,
+	}
+}
+
+// SetProvider sets the voice provider whose memory and SMS history are
+// included in exports and cleared on deletion.
+func (s *PrivacyService) SetProvider(provider PrivacyProvider) {
+	s.provider = provider
+}
+
+// SetRecordingPurger sets where stored recordings are deleted from. Without
+// one, deletions only remove recording rows.
+func (s *PrivacyService) SetRecordingPurger(purger RecordingPurger) {
+	s.purger = purger
+}
+
+// PrivacyRequest names the data subject of a request by phone number or
+// customer ID.
+type PrivacyRequest struct {
+	PhoneNumber string     `json:"phone_number,omitempty"`
+	CustomerID  *uuid.UUID `json:"customer_id,omitempty"`
+	RequestedBy *uuid.UUID `json:"-"`
+}
+
+// PrivacyExport is everything held about a data subject.
+type PrivacyExport struct {
+	Subject        domain.PrivacySubject             `json:"subject"`
+	ExportedAt     time.Time                         `json:"exported_at"`
+	Records        *domain.PrivacyRecords            `json:"records"`
+	ProviderMemory map[string]map[string]interface{} `json:"provider_memory,omitempty"` // By phone number
+	SMS            []bland.SMS                       `json:"sms,omitempty"`
+	Incomplete     []string                          `json:"incomplete,omitempty"` // Sources that could not be read
+}
+
+// Export collects everything held about the subject, locally and by the
+// voice provider. Provider failures are listed in the export rather than
+// failing it.
+func (s *PrivacyService) Export(ctx context.Context, req *PrivacyRequest) (*PrivacyExport, error) {
+	subject, customer, calls, err := s.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	records := &domain.PrivacyRecords{
+		Customer:         customer,
+		Calls:            calls,
+		Quotes:           []*domain.Quote{},
+		QuoteTransitions: []*domain.QuoteTransition{},
+		Recordings:       []*domain.Recording{},
+		FollowUpSteps:    []*domain.FollowUpStep{},
+		Callbacks:        []*domain.Callback{},
+		CampaignContacts: []*domain.CampaignContact{},
+		DoNotCall:        []*domain.DNCEntry{},
+	}
+	for _, call := range calls {
+		quote, err := s.quotes.GetByCallID(ctx, call.ID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+		if quote != nil {
+			records.Quotes = append(records.Quotes, quote)
+			transitions, err := s.quotes.ListTransitions(ctx, call.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list quote transitions: %w", err)
+			}
+			records.QuoteTransitions = append(records.QuoteTransitions, transitions...)
+		}
+
+		recording, err := s.recordings.GetByCallID(ctx, call.ID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get recording: %w", err)
+		}
+		if recording != nil {
+			records.Recordings = append(records.Recordings, recording)
+		}
+
+		steps, err := s.followUps.ListByCallID(ctx, call.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list follow-up steps: %w", err)
+		}
+		records.FollowUpSteps = append(records.FollowUpSteps, steps...)
+	}
+
+	callbacks, err := s.repo.ListCallbacks(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list callbacks: %w", err)
+	}
+	records.Callbacks = append(records.Callbacks, callbacks...)
+
+	contacts, err := s.repo.ListCampaignContacts(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign contacts: %w", err)
+	}
+	records.CampaignContacts = append(records.CampaignContacts, contacts...)
+
+	for _, phone := range subject.PhoneNumbers {
+		entry, err := s.dnc.Get(ctx, phone)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check do-not-call list: %w", err)
+		}
+		if entry != nil {
+			records.DoNotCall = append(records.DoNotCall, entry)
+		}
+	}
+
+	export := &PrivacyExport{
+		Subject:    *subject,
+		ExportedAt: s.clock.Now().UTC(),
+		Records:    records,
+	}
+	if s.provider != nil {
+		for _, phone := range subject.PhoneNumbers {
+			memory, err := s.provider.GetCustomerMemory(ctx, phone)
+			if err != nil {
+				s.logger.Warn("failed to export provider memory", zap.Error(err))
+				export.Incomplete = append(export.Incomplete, "provider memory: "+err.Error())
+			} else if len(memory) > 0 {
+				if export.ProviderMemory == nil {
+					export.ProviderMemory = make(map[string]map[string]interface{})
+				}
+				export.ProviderMemory[phone] = memory
+			}
+
+			messages, err := s.provider.GetSMSHistory(ctx, phone)
+			if err != nil {
+				s.logger.Warn("failed to export SMS history", zap.Error(err))
+				export.Incomplete = append(export.Incomplete, "SMS history: "+err.Error())
+				continue
+			}
+			export.SMS = append(export.SMS, messages...)
+		}
+	}
+
+	s.logger.Info("privacy export produced",
+		zap.Int("calls", len(calls)),
+		zap.Bool("customer", customer != nil),
+	)
+	return export, nil
+}
+
+// Delete removes everything held about the subject and returns a signed
+// report of what was removed, kept, or could not be removed. Stored
+// recordings are deleted first; if any cannot be, the database is left
+// alone so a retry can find them again. Do-not-call entries are kept so the
+// subject is not called again.
+func (s *PrivacyService) Delete(ctx context.Context, req *PrivacyRequest) (*domain.DeletionReport, error) {
+	subject, _, _, err := s.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.DeletionReport{
+		ID:          uuid.New(),
+		Subject:     *subject,
+		RequestedBy: req.RequestedBy,
+		StartedAt:   s.clock.Now().UTC(),
+		Actions:     []domain.PrivacyAction{},
+	}
+
+	recordingsFailed := false
+	if s.purger != nil {
+		purged := 0
+		for _, callID := range subject.CallIDs {
+			ok, err := s.purger.Purge(ctx, callID)
+			if err != nil {
+				recordingsFailed = true
+				s.logger.Error("failed to purge recording", zap.String("call_id", callID.String()), zap.Error(err))
+				report.Actions = append(report.Actions, domain.PrivacyAction{
+					System: "recording_storage",
+					Target: callID.String(),
+					Status: domain.PrivacyActionFailed,
+					Detail: err.Error(),
+				})
+				continue
+			}
+			if ok {
+				purged++
+			}
+		}
+		if purged > 0 {
+			report.Actions = append(report.Actions, domain.PrivacyAction{
+				System: "recording_storage",
+				Status: domain.PrivacyActionDeleted,
+				Count:  purged,
+			})
+		}
+	}
+
+	if recordingsFailed {
+		report.Actions = append(report.Actions, domain.PrivacyAction{
+			System: "database",
+			Status: domain.PrivacyActionSkipped,
+			Detail: "kept until every stored recording is deleted; retry the request",
+		})
+	} else {
+		deleted, err := s.repo.Erase(ctx, subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to erase subject data: %w", err)
+		}
+		tables := make([]string, 0, len(deleted))
+		for table := range deleted {
+			tables = append(tables, table)
+		}
+		sort.Strings(tables)
+		for _, table := range tables {
+			report.Actions = append(report.Actions, domain.PrivacyAction{
+				System: "database",
+				Target: table,
+				Status: domain.PrivacyActionDeleted,
+				Count:  deleted[table],
+			})
+		}
+	}
+
+	for _, phone := range subject.PhoneNumbers {
+		if _, err := s.dnc.Get(ctx, phone); err == nil {
+			report.Actions = append(report.Actions, domain.PrivacyAction{
+				System: "database",
+				Target: "dnc_numbers",
+				Status: domain.PrivacyActionRetained,
+				Count:  1,
+				Detail: "kept so the number is not called again",
+			})
+		} else if !apperrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to check do-not-call list: %w", err)
+		}
+	}
+
+	if s.provider != nil {
+		for _, phone := range subject.PhoneNumbers {
+			action := domain.PrivacyAction{System: "bland", Target: "memory", Status: domain.PrivacyActionDeleted}
+			if err := s.provider.ClearCustomerMemory(ctx, phone); err != nil {
+				s.logger.Error("failed to clear provider memory", zap.Error(err))
+				action.Status = domain.PrivacyActionFailed
+				action.Detail = err.Error()
+			}
+			report.Actions = append(report.Actions, action)
+
+			action = domain.PrivacyAction{
+				System: "bland",
+				Target: "sms",
+				Status: domain.PrivacyActionSkipped,
+				Detail: "Bland has no API for deleting SMS messages; ask Bland support to delete them",
+			}
+			if messages, err := s.provider.GetSMSHistory(ctx, phone); err == nil {
+				action.Count = len(messages)
+			}
+			report.Actions = append(report.Actions, action)
+		}
+	}
+
+	report.Complete = true
+	for _, action := range report.Actions {
+		if action.Status == domain.PrivacyActionFailed || (action.System == "database" && action.Status == domain.PrivacyActionSkipped) {
+			report.Complete = false
+		}
+	}
+	report.CompletedAt = s.clock.Now().UTC()
+	if err := report.Sign(s.signingKey); err != nil {
+		return nil, fmt.Errorf("failed to sign deletion report: %w", err)
+	}
+
+	s.logger.Info("data subject deleted",
+		zap.String("report_id", report.ID.String()),
+		zap.Int("calls", len(subject.CallIDs)),
+		zap.Bool("complete", report.Complete),
+	)
+	return report, nil
+}
+
+// VerifyReport reports whether a deletion report was signed by this
+// service and has not been changed since.
+func (s *PrivacyService) VerifyReport(report *domain.DeletionReport) bool {
+	return report.Signature != "" && report.Verify(s.signingKey)
+}
+
+// resolve finds the subject a request names: their customer record, phone
+// numbers and calls. Calls are matched on the customer's end of the line,
+// so naming our own number does not sweep up every inbound call.
+func (s *PrivacyService) resolve(ctx context.Context, req *PrivacyRequest) (*domain.PrivacySubject, *domain.Customer, []*domain.Call, error) {
+	hasPhone := strings.TrimSpace(req.PhoneNumber) != ""
+	if hasPhone == (req.CustomerID != nil) {
+		return nil, nil, nil, apperrors.ValidationFailed("exactly one of phone_number or customer_id is required")
+	}
+
+	var customer *domain.Customer
+	var phone string
+	if req.CustomerID != nil {
+		c, err := s.customers.GetByID(ctx, *req.CustomerID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		customer = c
+		phone = c.PhoneNumber
+	} else {
+		phone = normalizePhoneNumber(req.PhoneNumber)
+		if phone == "" {
+			return nil, nil, nil, apperrors.ValidationFailed("phone_number must be a valid phone number")
+		}
+		c, err := s.repo.GetCustomerByPhoneNumber(ctx, phone)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, nil, nil, fmt.Errorf("failed to get customer: %w", err)
+		}
+		customer = c
+	}
+
+	subject := &domain.PrivacySubject{PhoneNumbers: []string{phone}}
+	if customer != nil {
+		subject.CustomerID = &customer.ID
+	}
+
+	candidates, err := s.repo.ListCalls(ctx, subject.CustomerID, subject.PhoneNumbers)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list calls: %w", err)
+	}
+	calls := []*domain.Call{}
+	for _, call := range candidates {
+		linked := subject.CustomerID != nil && call.CustomerID != nil && *call.CustomerID == *subject.CustomerID
+		if linked || normalizePhoneNumber(call.CustomerNumber()) == phone {
+			calls = append(calls, call)
+			subject.CallIDs = append(subject.CallIDs, call.ID)
+		}
+	}
+
+	return subject, customer, calls, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockPrivacyRepository is an in-memory PrivacyRepository over a fixed set
+// of customers and calls.
+type MockPrivacyRepository struct {
+	mu        sync.Mutex
+	customers []*domain.Customer
+	calls     []*domain.Call
+	erased    *domain.PrivacySubject
+}
+
+func (m *MockPrivacyRepository) GetCustomerByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Customer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.customers {
+		if c.PhoneNumber == phoneNumber {
+			cp := *c
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("customer")
+}
+
+func (m *MockPrivacyRepository) ListCalls(ctx context.Context, customerID *uuid.UUID, phoneNumbers []string) ([]*domain.Call, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []*domain.Call
+	for _, call := range m.calls {
+		match := customerID != nil && call.CustomerID != nil && *call.CustomerID == *customerID
+		for _, phone := range phoneNumbers {
+			match = match || call.FromNumber == phone || call.PhoneNumber == phone
+		}
+		if match {
+			cp := *call
+			calls = append(calls, &cp)
+		}
+	}
+	return calls, nil
+}
+
+func (m *MockPrivacyRepository) ListCallbacks(ctx context.Context, subject *domain.PrivacySubject) ([]*domain.Callback, error) {
+	return nil, nil
+}
+
+func (m *MockPrivacyRepository) ListCampaignContacts(ctx context.Context, subject *domain.PrivacySubject) ([]*domain.CampaignContact, error) {
+	return nil, nil
+}
+
+func (m *MockPrivacyRepository) Erase(ctx context.Context, subject *domain.PrivacySubject) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.erased = subject
+	return map[string]int{"calls": len(subject.CallIDs), "customers": 1}, nil
+}
+
+// fakePrivacyProvider records cleared memory.
+type fakePrivacyProvider struct {
+	memory  map[string]map[string]interface{}
+	sms     []bland.SMS
+	cleared []string
+}
+
+func (f *fakePrivacyProvider) GetCustomerMemory(ctx context.Context, phoneNumber string) (map[string]interface{}, error) {
+	return f.memory[phoneNumber], nil
+}
+
+func (f *fakePrivacyProvider) ClearCustomerMemory(ctx context.Context, phoneNumber string) error {
+	f.cleared = append(f.cleared, phoneNumber)
+	return nil
+}
+
+func (f *fakePrivacyProvider) GetSMSHistory(ctx context.Context, phoneNumber string) ([]bland.SMS, error) {
+	return f.sms, nil
+}
+
+// failingPurger fails every recording deletion.
+type failingPurger struct{}
+
+func (failingPurger) Purge(ctx context.Context, callID uuid.UUID) (bool, error) {
+	return false, errors.New("storage unavailable")
+}
+
+type privacyTest struct {
+	svc        *PrivacyService
+	repo       *MockPrivacyRepository
+	recordings *MockRecordingRepository
+	provider   *fakePrivacyProvider
+	customer   *domain.Customer
+	inbound    *domain.Call // From the customer
+	outbound   *domain.Call // To the customer
+	other      *domain.Call // From someone else
+}
+
+// newPrivacyTest sets up a customer with an inbound call that has a stored
+// recording and a quote, an outbound call, and another caller's call to the
+// same business number.
+func newPrivacyTest(t *testing.T) *privacyTest {
+	t.Helper()
+	ctx := context.Background()
+
+	customers := NewMockCustomerRepository()
+	customer := domain.NewCustomer("+15550002222")
+	if err := customers.Create(ctx, customer); err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+
+	pt := &privacyTest{
+		customer: customer,
+		inbound:  domain.NewCall("p1", "bland", "+15550001111", "+15550002222"),
+		outbound: domain.NewCall("p2", "bland", "+15550002222", ""),
+		other:    domain.NewCall("p3", "bland", "+15550001111", "+15550003333"),
+		provider: &fakePrivacyProvider{
+			memory: map[string]map[string]interface{}{"+15550002222": {"name": "Pat"}},
+			sms:    []bland.SMS{{ID: "sms-1", Body: "Is the quote ready?"}},
+		},
+	}
+	pt.inbound.CustomerID = &customer.ID
+	pt.repo = &MockPrivacyRepository{
+		customers: []*domain.Customer{customer},
+		calls:     []*domain.Call{pt.inbound, pt.outbound, pt.other},
+	}
+
+	quotes := NewMockQuoteRepository()
+	quotes.quotes[pt.inbound.ID] = domain.NewQuote(pt.inbound.ID, 500)
+
+	recordingSvc, recordings, store := newTestRecordingService(t, 1<<20)
+	pt.recordings = recordings
+	recording := domain.NewRecording(pt.inbound.ID, "https://example.com/r.mp3")
+	recording.MarkStored(store.Name(), "recordings/p1.mp3", "audio/mpeg", 5, 0)
+	if err := recordings.Create(ctx, recording); err != nil {
+		t.Fatalf("create recording: %v", err)
+	}
+	if err := store.Put(ctx, recording.StorageKey, strings.NewReader("audio"), 5, "audio/mpeg"); err != nil {
+		t.Fatalf("store recording: %v", err)
+	}
+
+	dnc := NewMockDNCRepository()
+	dnc.entries["+15550002222"] = &domain.DNCEntry{PhoneNumber: "+15550002222", Source: domain.DNCSourceSMSOptOut}
+
+	pt.svc = NewPrivacyService(pt.repo, customers, quotes, recordings, &MockFollowUpRepository{}, dnc, []byte("test-key"), zap.NewNop(), nil)
+	pt.svc.SetProvider(pt.provider)
+	pt.svc.SetRecordingPurger(recordingSvc)
+	return pt
+}
+
+func TestPrivacyService_Export(t *testing.T) {
+	ctx := context.Background()
+	pt := newPrivacyTest(t)
+
+	export, err := pt.svc.Export(ctx, &PrivacyRequest{PhoneNumber: "(555) 000-2222"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if export.Subject.CustomerID == nil || *export.Subject.CustomerID != pt.customer.ID {
+		t.Errorf("subject customer = %v, want %s", export.Subject.CustomerID, pt.customer.ID)
+	}
+	records := export.Records
+	if len(records.Calls) != 2 || records.Calls[0].ID != pt.inbound.ID || records.Calls[1].ID != pt.outbound.ID {
+		t.Errorf("calls = %d, want the inbound and outbound calls", len(records.Calls))
+	}
+	if len(records.Quotes) != 1 || len(records.Recordings) != 1 || len(records.DoNotCall) != 1 {
+		t.Errorf("quotes = %d, recordings = %d, do-not-call = %d; want 1 each",
+			len(records.Quotes), len(records.Recordings), len(records.DoNotCall))
+	}
+	if export.ProviderMemory["+15550002222"]["name"] != "Pat" || len(export.SMS) != 1 {
+		t.Errorf("provider data = %v, %v; want the customer's memory and SMS", export.ProviderMemory, export.SMS)
+	}
+
+	// Our own number matches every inbound call but is nobody's customer number
+	export, err = pt.svc.Export(ctx, &PrivacyRequest{PhoneNumber: "+15550001111"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(export.Records.Calls) != 0 {
+		t.Errorf("calls for the business number = %d, want 0", len(export.Records.Calls))
+	}
+
+	for _, req := range []*PrivacyRequest{{}, {PhoneNumber: "+15550002222", CustomerID: &pt.customer.ID}, {PhoneNumber: "nope"}} {
+		if _, err := pt.svc.Export(ctx, req); !apperrors.IsUserError(err) {
+			t.Errorf("Export(%+v) error = %v, want a validation error", req, err)
+		}
+	}
+}
+
+func TestPrivacyService_Delete(t *testing.T) {
+	ctx := context.Background()
+	pt := newPrivacyTest(t)
+	requester := uuid.New()
+
+	report, err := pt.svc.Delete(ctx, &PrivacyRequest{CustomerID: &pt.customer.ID, RequestedBy: &requester})
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !report.Complete {
+		t.Errorf("report = %+v, want complete", report)
+	}
+
+	erased := pt.repo.erased
+	if erased == nil || len(erased.CallIDs) != 2 {
+		t.Fatalf("erased subject = %+v, want the customer's two calls", erased)
+	}
+	for _, id := range erased.CallIDs {
+		if id == pt.other.ID {
+			t.Error("erased another caller's call")
+		}
+	}
+	if rec, _ := pt.recordings.GetByCallID(ctx, pt.inbound.ID); rec.Status != domain.RecordingStatusDeleted {
+		t.Errorf("recording status = %s, want deleted from storage", rec.Status)
+	}
+	if len(pt.provider.cleared) != 1 || pt.provider.cleared[0] != "+15550002222" {
+		t.Errorf("cleared memory = %v, want the customer's number", pt.provider.cleared)
+	}
+
+	statuses := make(map[string]domain.PrivacyActionStatus)
+	for _, action := range report.Actions {
+		statuses[action.System+"/"+action.Target] = action.Status
+	}
+	want := map[string]domain.PrivacyActionStatus{
+		"recording_storage/":   domain.PrivacyActionDeleted,
+		"database/calls":       domain.PrivacyActionDeleted,
+		"database/customers":   domain.PrivacyActionDeleted,
+		"database/dnc_numbers": domain.PrivacyActionRetained,
+		"bland/memory":         domain.PrivacyActionDeleted,
+		"bland/sms":            domain.PrivacyActionSkipped,
+	}
+	for key, status := range want {
+		if statuses[key] != status {
+			t.Errorf("action %s = %q, want %q", key, statuses[key], status)
+		}
+	}
+
+	if !pt.svc.VerifyReport(report) {
+		t.Error("VerifyReport() = false for the report just signed")
+	}
+	report.Actions[0].Count++
+	if pt.svc.VerifyReport(report) {
+		t.Error("VerifyReport() = true for a changed report")
+	}
+}
+
+func TestPrivacyService_DeleteKeepsDataWhenRecordingsRemain(t *testing.T) {
+	pt := newPrivacyTest(t)
+	pt.svc.SetRecordingPurger(failingPurger{})
+
+	report, err := pt.svc.Delete(context.Background(), &PrivacyRequest{PhoneNumber: "+15550002222"})
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if report.Complete {
+		t.Error("report complete, want incomplete")
+	}
+	if pt.repo.erased != nil {
+		t.Error("database erased while recordings remain in storage")
+	}
+}
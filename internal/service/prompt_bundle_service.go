@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// BundleSettingsStore reads and writes the settings a bundle carries.
+type BundleSettingsStore interface {
+	GetAllSettings(ctx context.Context) ([]*domain.Setting, error)
+	Set(ctx context.Context, key, value string) error
+}
+
+// CitationSchemaStore reads and writes the citation schemas a bundle
+// carries.
+type CitationSchemaStore interface {
+	ListCitationSchemas(ctx context.Context) ([]bland.CitationSchema, error)
+	CreateCitationSchema(ctx context.Context, req *bland.CreateCitationSchemaRequest) (*bland.CitationSchema, error)
+	UpdateCitationSchema(ctx context.Context, schemaID string, req *bland.UpdateCitationSchemaRequest) (*bland.CitationSchema, error)
+}
+
+// BundleTransactor runs fn in a database transaction that the stores'
+// queries made with the ctx it is given take part in, committing it when fn
+// returns nil and rolling it back otherwise.
+type BundleTransactor interface {
+	WithTransactionContext(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// PromptBundleExportOptions chooses what an export carries besides prompts.
+type PromptBundleExportOptions struct {
+	Settings        bool
+	CitationSchemas bool
+}
+
+// PromptBundleImportOptions controls an import.
+type PromptBundleImportOptions struct {
+	Conflict  domain.BundleConflict
+	DryRun    bool   // Report what would change without changing it
+	ChangedBy string // Recorded in the history of overwritten prompts
+}
+
+// bundledCitationSchema is a citation schema's content in a bundle.
+type bundledCitationSchema struct {
+	Name        string                       `json:"name"`
+	Description string                       `json:"description,omitempty"`
+	Schema      map[string]bland.SchemaField `json:"schema"`
+}
+
+// PromptBundleService exports prompts, and optionally settings and
+// citation schemas, as one bundle, and imports bundles with validation,
+// dry runs and a choice of what to do with items whose name is taken.
+type PromptBundleService struct {
+	prompts  *PromptService
+	settings BundleSettingsStore
+	schemas  CitationSchemaStore
+	tx       BundleTransactor
+	logger   *zap.Logger
+}
+
+// NewPromptBundleService creates a new PromptBundleService.
+func NewPromptBundleService(prompts *PromptService, logger *zap.Logger) *PromptBundleService {
+	return &PromptBundleService{
+		prompts: prompts,
+		logger:  logger,
+	}
+}
+
+// SetSettingsStore lets bundles carry settings.
+func (s *PromptBundleService) SetSettingsStore(settings BundleSettingsStore) {
+	s.settings = settings
+}
+
+// SetCitationSchemaStore lets bundles carry citation schemas.
+func (s *PromptBundleService) SetCitationSchemaStore(schemas CitationSchemaStore) {
+	s.schemas = schemas
+}
+
+// SetTransactor makes imports all or nothing: the prompts and settings of a
+// bundle are written in one transaction.
+func (s *PromptBundleService) SetTransactor(tx BundleTransactor) {
+	s.tx = tx
+}
+
+// Export returns the active prompts, sorted by name, and whatever else opts
+// asks for as a bundle.
+func (s *PromptBundleService) Export(ctx context.Context, opts PromptBundleExportOptions) (*domain.PromptBundle, error) {
+	prompts, err := s.prompts.ListAllPrompts(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(prompts, func(i, j int) bool { return prompts[i].Name < prompts[j].Name })
+
+	bundle := &domain.PromptBundle{
+		Version:    domain.PromptBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Prompts:    make([]json.RawMessage, 0, len(prompts)),
+	}
+	for _, prompt := range prompts {
+		content, err := domain.PromptContent(prompt)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Prompts = append(bundle.Prompts, content)
+	}
+
+	if opts.Settings {
+		if s.settings == nil {
+			return nil, apperrors.ValidationFailed("settings can't be exported from this server")
+		}
+		settings, err := s.settings.GetAllSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Settings = make(map[string]string, len(settings))
+		for _, setting := range settings {
+			bundle.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	if opts.CitationSchemas {
+		if s.schemas == nil {
+			return nil, apperrors.ValidationFailed("citation schemas can't be exported from this server")
+		}
+		schemas, err := s.schemas.ListCitationSchemas(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+		for _, schema := range schemas {
+			content, err := json.Marshal(bundledCitationSchema{Name: schema.Name, Description: schema.Description, Schema: schema.Schema})
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode citation schema %q: %w", schema.Name, err)
+			}
+			bundle.CitationSchemas = append(bundle.CitationSchemas, content)
+		}
+	}
+
+	return bundle, nil
+}
+
+// Import applies a bundle. Prompts and citation schemas are matched to
+// existing ones by name and settings by key; opts.Conflict decides what
+// happens to matches whose content differs. The whole bundle is validated
+// before anything is written. With a transactor, prompts and settings are
+// written in one transaction, rolled back if any item fails. Citation
+// schemas live in Bland, so they are written last, before the commit; a
+// failure there still rolls back the prompts and settings, but not schemas
+// already created.
+func (s *PromptBundleService) Import(ctx context.Context, bundle *domain.PromptBundle, opts PromptBundleImportOptions) (*domain.PromptBundleImportResult, error) {
+	if opts.Conflict == "" {
+		opts.Conflict = domain.BundleConflictSkip
+	}
+
+	prompts, schemas, err := s.validate(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PromptBundleImportResult{DryRun: opts.DryRun, Conflict: opts.Conflict}
+	apply := func(ctx context.Context) error {
+		var err error
+		if result.Prompts, err = s.importPrompts(ctx, prompts, opts); err != nil {
+			return err
+		}
+		if len(bundle.Settings) > 0 {
+			if result.Settings, err = s.importSettings(ctx, bundle.Settings, opts); err != nil {
+				return err
+			}
+		}
+		if len(schemas) > 0 {
+			if result.CitationSchemas, err = s.importCitationSchemas(ctx, schemas, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if s.tx != nil && !opts.DryRun {
+		err = s.tx.WithTransactionContext(ctx, apply)
+		// Prompts and settings cached while the transaction was open may
+		// be stale
+		s.prompts.invalidateCache()
+		if settings, ok := s.settings.(interface{ invalidateCache() }); ok {
+			settings.invalidateCache()
+		}
+	} else {
+		err = apply(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.DryRun {
+		s.logger.Info("prompt bundle imported",
+			zap.Int("prompts", len(prompts)),
+			zap.Int("settings", len(bundle.Settings)),
+			zap.Int("citation_schemas", len(schemas)),
+			zap.String("conflict", string(opts.Conflict)),
+		)
+	}
+	return result, nil
+}
+
+// validate decodes every item of a bundle, refusing the bundle if any is
+// invalid or named twice.
+func (s *PromptBundleService) validate(bundle *domain.PromptBundle) ([]*domain.Prompt, []bundledCitationSchema, error) {
+	if bundle.Version < 1 || bundle.Version > domain.PromptBundleVersion {
+		return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("unsupported bundle version %d", bundle.Version))
+	}
+	if len(bundle.Settings) > 0 && s.settings == nil {
+		return nil, nil, apperrors.ValidationFailed("settings can't be imported on this server")
+	}
+	if len(bundle.CitationSchemas) > 0 && s.schemas == nil {
+		return nil, nil, apperrors.ValidationFailed("citation schemas can't be imported on this server")
+	}
+
+	prompts := make([]*domain.Prompt, len(bundle.Prompts))
+	names := make(map[string]bool, len(bundle.Prompts))
+	for i, content := range bundle.Prompts {
+		prompt, err := domain.DecodePromptContent(content)
+		if err != nil {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("prompts[%d]: %v", i, err))
+		}
+		if names[prompt.Name] {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("prompts[%d]: prompt %q appears more than once", i, prompt.Name))
+		}
+		names[prompt.Name] = true
+		prompts[i] = prompt
+	}
+
+	schemas := make([]bundledCitationSchema, len(bundle.CitationSchemas))
+	names = make(map[string]bool, len(bundle.CitationSchemas))
+	for i, content := range bundle.CitationSchemas {
+		var schema bundledCitationSchema
+		if err := json.Unmarshal(content, &schema); err != nil {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("citation_schemas[%d]: invalid citation schema: %v", i, err))
+		}
+		if strings.TrimSpace(schema.Name) == "" {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("citation_schemas[%d]: name is required", i))
+		}
+		if len(schema.Schema) == 0 {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("citation_schemas[%d]: schema is required", i))
+		}
+		if names[schema.Name] {
+			return nil, nil, apperrors.ValidationFailed(fmt.Sprintf("citation_schemas[%d]: citation schema %q appears more than once", i, schema.Name))
+		}
+		names[schema.Name] = true
+		schemas[i] = schema
+	}
+
+	for key := range bundle.Settings {
+		if strings.TrimSpace(key) == "" {
+			return nil, nil, apperrors.ValidationFailed("settings: keys can't be empty")
+		}
+	}
+
+	return prompts, schemas, nil
+}
+
+func (s *PromptBundleService) importPrompts(ctx context.Context, prompts []*domain.Prompt, opts PromptBundleImportOptions) ([]domain.BundleItemResult, error) {
+	existing, err := s.prompts.ListAllPrompts(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Prompt, len(existing))
+	for _, prompt := range existing {
+		byName[prompt.Name] = prompt
+	}
+
+	results := make([]domain.BundleItemResult, 0, len(prompts))
+	for _, prompt := range prompts {
+		item := domain.BundleItemResult{Name: prompt.Name, Action: domain.BundleActionCreate}
+
+		if current, ok := byName[prompt.Name]; ok {
+			item.ID = current.ID.String()
+			diff, err := domain.DiffPrompts(current, prompt)
+			if err != nil {
+				return nil, err
+			}
+			item.Changes = changedFields(diff)
+			item.Action = conflictAction(item.Changes, opts.Conflict)
+		}
+
+		switch item.Action {
+		case domain.BundleActionRename:
+			item.NewName = uniqueName(prompt.Name, func(name string) bool { return byName[name] != nil })
+			prompt.Name = item.NewName
+			item.ID = ""
+			fallthrough
+		case domain.BundleActionCreate:
+			byName[prompt.Name] = prompt
+			if !opts.DryRun {
+				if err := s.prompts.ImportPrompt(ctx, prompt); err != nil {
+					return nil, err
+				}
+				item.ID = prompt.ID.String()
+			}
+		case domain.BundleActionUpdate:
+			if !opts.DryRun {
+				if _, err := s.prompts.ReplacePromptContent(ctx, byName[prompt.Name].ID, prompt, opts.ChangedBy); err != nil {
+					return nil, err
+				}
+			}
+		}
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+func (s *PromptBundleService) importSettings(ctx context.Context, settings map[string]string, opts PromptBundleImportOptions) ([]domain.BundleItemResult, error) {
+	existing, err := s.settings.GetAllSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]string, len(existing))
+	for _, setting := range existing {
+		current[setting.Key] = setting.Value
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Settings are identified by key, so they can't be renamed
+	conflict := opts.Conflict
+	if conflict == domain.BundleConflictRename {
+		conflict = domain.BundleConflictSkip
+	}
+
+	results := make([]domain.BundleItemResult, 0, len(keys))
+	for _, key := range keys {
+		item := domain.BundleItemResult{Name: key, Action: domain.BundleActionCreate}
+		if value, ok := current[key]; ok {
+			item.Changes = changedFields([]domain.PromptFieldDiff{fieldDiff("value", value, settings[key])})
+			item.Action = conflictAction(item.Changes, conflict)
+		}
+
+		if (item.Action == domain.BundleActionCreate || item.Action == domain.BundleActionUpdate) && !opts.DryRun {
+			if err := s.settings.Set(ctx, key, settings[key]); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+func (s *PromptBundleService) importCitationSchemas(ctx context.Context, schemas []bundledCitationSchema, opts PromptBundleImportOptions) ([]domain.BundleItemResult, error) {
+	existing, err := s.schemas.ListCitationSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]bland.CitationSchema, len(existing))
+	for _, schema := range existing {
+		byName[schema.Name] = schema
+	}
+
+	results := make([]domain.BundleItemResult, 0, len(schemas))
+	for _, schema := range schemas {
+		item := domain.BundleItemResult{Name: schema.Name, Action: domain.BundleActionCreate}
+
+		if current, ok := byName[schema.Name]; ok {
+			item.ID = current.ID
+			item.Changes = changedFields([]domain.PromptFieldDiff{
+				fieldDiff("description", current.Description, schema.Description),
+				fieldDiff("schema", current.Schema, schema.Schema),
+			})
+			item.Action = conflictAction(item.Changes, opts.Conflict)
+		}
+
+		switch item.Action {
+		case domain.BundleActionRename:
+			item.NewName = uniqueName(schema.Name, func(name string) bool { _, ok := byName[name]; return ok })
+			schema.Name = item.NewName
+			item.ID = ""
+			fallthrough
+		case domain.BundleActionCreate:
+			byName[schema.Name] = bland.CitationSchema{Name: schema.Name}
+			if !opts.DryRun {
+				created, err := s.schemas.CreateCitationSchema(ctx, &bland.CreateCitationSchemaRequest{
+					Name:        schema.Name,
+					Description: schema.Description,
+					Schema:      schema.Schema,
+				})
+				if err != nil {
+					return nil, err
+				}
+				item.ID = created.ID
+			}
+		case domain.BundleActionUpdate:
+			if !opts.DryRun {
+				if _, err := s.schemas.UpdateCitationSchema(ctx, item.ID, &bland.UpdateCitationSchemaRequest{
+					Description: &schema.Description,
+					Schema:      schema.Schema,
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+		results = append(results, item)
+	}
+	return results, nil
+}
+
+// conflictAction returns what happens to an item whose name is taken,
+// given the fields that differ.
+func conflictAction(changes []domain.PromptFieldDiff, conflict domain.BundleConflict) string {
+	if len(changes) == 0 {
+		return domain.BundleActionUnchanged
+	}
+	switch conflict {
+	case domain.BundleConflictOverwrite:
+		return domain.BundleActionUpdate
+	case domain.BundleConflictRename:
+		return domain.BundleActionRename
+	default:
+		return domain.BundleActionSkip
+	}
+}
+
+// changedFields returns the fields of a diff that changed.
+func changedFields(diff []domain.PromptFieldDiff) []domain.PromptFieldDiff {
+	var changed []domain.PromptFieldDiff
+	for _, field := range diff {
+		if field.Changed {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// fieldDiff compares one field's values by their JSON encoding.
+func fieldDiff(field string, before, after interface{}) domain.PromptFieldDiff {
+	b, _ := json.Marshal(before)
+	a, _ := json.Marshal(after)
+	return domain.PromptFieldDiff{Field: field, Before: b, After: a, Changed: string(a) != string(b)}
+}
+
+// uniqueName returns the first of "name (imported)", "name (imported 2)",
+// ... that isn't taken.
+func uniqueName(name string, taken func(string) bool) string {
+	candidate := name + " (imported)"
+	for n := 2; taken(candidate); n++ {
+		candidate = fmt.Sprintf("%s (imported %d)", name, n)
+	}
+	return candidate
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// mockBundleSettings is an in-memory BundleSettingsStore.
+type mockBundleSettings map[string]string
+
+func (m mockBundleSettings) GetAllSettings(ctx context.Context) ([]*domain.Setting, error) {
+	var settings []*domain.Setting
+	for key, value := range m {
+		settings = append(settings, &domain.Setting{Key: key, Value: value})
+	}
+	return settings, nil
+}
+
+func (m mockBundleSettings) Set(ctx context.Context, key, value string) error {
+	m[key] = value
+	return nil
+}
+
+// stubBundleTransactor runs fn directly and then fails the commit with err.
+type stubBundleTransactor struct {
+	calls int
+	err   error
+}
+
+func (s *stubBundleTransactor) WithTransactionContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.calls++
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	return s.err
+}
+
+func newTestBundleService(prompts ...*domain.Prompt) (*PromptBundleService, *MockPromptRepository, *MockPromptVersionRepository) {
+	repo := NewMockPromptRepository(prompts...)
+	versions := &MockPromptVersionRepository{}
+	promptService := NewPromptService(repo, zap.NewNop())
+	promptService.SetVersionRepository(versions)
+	return NewPromptBundleService(promptService, zap.NewNop()), repo, versions
+}
+
+func promptsByName(repo *MockPromptRepository) map[string]*domain.Prompt {
+	byName := make(map[string]*domain.Prompt)
+	for _, p := range repo.prompts {
+		byName[p.Name] = p
+	}
+	return byName
+}
+
+func TestPromptBundleService_ExportImportRoundTrip(t *testing.T) {
+	web := domain.NewPrompt("Web App Intake", "Ask about the web app.")
+	mobile := domain.NewPrompt("Mobile App Intake", "Ask about the app.")
+	inactive := domain.NewPrompt("Retired", "Old script.")
+	inactive.IsActive = false
+	svc, _, _ := newTestBundleService(web, mobile, inactive)
+	svc.SetSettingsStore(mockBundleSettings{"pricing.hourly_rate": "150"})
+	ctx := context.Background()
+
+	bundle, err := svc.Export(ctx, PromptBundleExportOptions{Settings: true})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(bundle.Prompts) != 2 || !strings.Contains(string(bundle.Prompts[0]), `"name":"Mobile App Intake"`) {
+		t.Fatalf("expected the active prompts sorted by name, got %s", bundle.Prompts)
+	}
+	if strings.Contains(string(bundle.Prompts[0]), `"id"`) {
+		t.Errorf("expected IDs left out, got %s", bundle.Prompts[0])
+	}
+
+	// The bundle imports into an empty environment as is
+	data, _ := json.Marshal(bundle)
+	var decoded domain.PromptBundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	target, repo, _ := newTestBundleService()
+	settings := mockBundleSettings{}
+	target.SetSettingsStore(settings)
+	result, err := target.Import(ctx, &decoded, PromptBundleImportOptions{})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(result.Prompts) != 2 || result.Prompts[0].Action != domain.BundleActionCreate || result.Prompts[0].ID == "" {
+		t.Errorf("expected both prompts created, got %+v", result.Prompts)
+	}
+	if got := promptsByName(repo)["Web App Intake"]; got == nil || got.Task != "Ask about the web app." || got.IsDefault {
+		t.Errorf("imported prompt = %+v", got)
+	}
+	if settings["pricing.hourly_rate"] != "150" {
+		t.Errorf("expected the setting imported, got %v", settings)
+	}
+}
+
+func TestPromptBundleService_ImportConflicts(t *testing.T) {
+	bundle := &domain.PromptBundle{
+		Version: domain.PromptBundleVersion,
+		Prompts: []json.RawMessage{
+			json.RawMessage(`{"name":"Web App Intake","task":"Ask about the web app and its users."}`),
+			json.RawMessage(`{"name":"Mobile App Intake","task":"Ask about the app."}`),
+		},
+	}
+
+	tests := []struct {
+		conflict   domain.BundleConflict
+		dryRun     bool
+		wantAction string
+		wantTask   string
+		wantCount  int
+	}{
+		{domain.BundleConflictSkip, false, domain.BundleActionSkip, "Ask about the web app.", 2},
+		{domain.BundleConflictOverwrite, true, domain.BundleActionUpdate, "Ask about the web app.", 2},
+		{domain.BundleConflictOverwrite, false, domain.BundleActionUpdate, "Ask about the web app and its users.", 2},
+		{domain.BundleConflictRename, false, domain.BundleActionRename, "Ask about the web app.", 3},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.conflict), func(t *testing.T) {
+			web := domain.NewPrompt("Web App Intake", "Ask about the web app.")
+			mobile := domain.NewPrompt("Mobile App Intake", "Ask about the app.")
+			svc, repo, versions := newTestBundleService(web, mobile)
+
+			result, err := svc.Import(context.Background(), bundle, PromptBundleImportOptions{Conflict: tt.conflict, DryRun: tt.dryRun, ChangedBy: "admin@example.com"})
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+
+			item := result.Prompts[0]
+			if item.Action != tt.wantAction || len(item.Changes) != 1 || item.Changes[0].Field != "task" {
+				t.Errorf("web prompt result = %+v", item)
+			}
+			if result.Prompts[1].Action != domain.BundleActionUnchanged {
+				t.Errorf("expected the identical prompt unchanged, got %q", result.Prompts[1].Action)
+			}
+			if got := repo.prompts[web.ID].Task; got != tt.wantTask {
+				t.Errorf("existing prompt task = %q, want %q", got, tt.wantTask)
+			}
+			if len(repo.prompts) != tt.wantCount {
+				t.Errorf("expected %d prompts, got %d", tt.wantCount, len(repo.prompts))
+			}
+
+			switch {
+			case tt.conflict == domain.BundleConflictOverwrite && !tt.dryRun:
+				if len(versions.versions) != 1 || versions.versions[0].ChangedBy != "admin@example.com" {
+					t.Errorf("expected the overwritten content kept as a version, got %+v", versions.versions)
+				}
+			case tt.conflict == domain.BundleConflictRename:
+				renamed := promptsByName(repo)["Web App Intake (imported)"]
+				if item.NewName != "Web App Intake (imported)" || renamed == nil || renamed.Task != "Ask about the web app and its users." {
+					t.Errorf("expected the prompt imported under a new name, got %+v", item)
+				}
+			}
+		})
+	}
+}
+
+func TestPromptBundleService_ImportValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  domain.PromptBundle
+		wantErr string
+	}{
+		{"newer version", domain.PromptBundle{Version: domain.PromptBundleVersion + 1}, "unsupported bundle version"},
+		{"missing task", domain.PromptBundle{Version: 1, Prompts: []json.RawMessage{json.RawMessage(`{"name":"A"}`)}}, "prompts[0]"},
+		{"unknown field", domain.PromptBundle{Version: 1, Prompts: []json.RawMessage{json.RawMessage(`{"name":"A","task":"T","is_default":true}`)}}, "is_default"},
+		{"duplicate name", domain.PromptBundle{Version: 1, Prompts: []json.RawMessage{
+			json.RawMessage(`{"name":"A","task":"T"}`),
+			json.RawMessage(`{"name":"A","task":"U"}`),
+		}}, "appears more than once"},
+		{"settings without a store", domain.PromptBundle{Version: 1, Settings: map[string]string{"a": "b"}}, "settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := domain.NewPrompt("Existing", "Ask about the project.")
+			svc, repo, _ := newTestBundleService(existing)
+			bundle := tt.bundle
+			bundle.Prompts = append(bundle.Prompts, json.RawMessage(`{"name":"Valid","task":"T"}`))
+
+			_, err := svc.Import(context.Background(), &bundle, PromptBundleImportOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Import() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+			if len(repo.prompts) != 1 {
+				t.Errorf("expected nothing imported from an invalid bundle, got %d prompts", len(repo.prompts))
+			}
+		})
+	}
+}
+
+func TestPromptBundleService_ImportInTransaction(t *testing.T) {
+	bundle := &domain.PromptBundle{
+		Version: domain.PromptBundleVersion,
+		Prompts: []json.RawMessage{json.RawMessage(`{"name":"Web App Intake","task":"Ask about the web app."}`)},
+	}
+	svc, _, _ := newTestBundleService()
+	tx := &stubBundleTransactor{}
+	svc.SetTransactor(tx)
+	ctx := context.Background()
+
+	if _, err := svc.Import(ctx, bundle, PromptBundleImportOptions{DryRun: true}); err != nil {
+		t.Fatalf("Import() dry run error = %v", err)
+	}
+	if tx.calls != 0 {
+		t.Errorf("dry run opened %d transactions, want none", tx.calls)
+	}
+
+	if _, err := svc.Import(ctx, bundle, PromptBundleImportOptions{}); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if tx.calls != 1 {
+		t.Errorf("import opened %d transactions, want 1", tx.calls)
+	}
+
+	tx.err = errors.New("commit failed")
+	if _, err := svc.Import(ctx, bundle, PromptBundleImportOptions{Conflict: domain.BundleConflictRename}); !errors.Is(err, tx.err) {
+		t.Errorf("Import() with a failed commit error = %v, want %v", err, tx.err)
+	}
+}
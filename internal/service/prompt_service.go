@@ -3,32 +3,69 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/cache"
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
 )
 
 // PromptService handles prompt management business logic.
 type PromptService struct {
-	promptRepo domain.PromptRepository
-	logger     *zap.Logger
+	promptRepo  domain.PromptRepository
+	versionRepo domain.PromptVersionRepository
+	logger      *zap.Logger
+
+	// Caches for the lookups made when calls start
+	byID          *cache.Cache[uuid.UUID, domain.Prompt]
+	byName        *cache.Cache[string, domain.Prompt]
+	defaultPrompt *cache.Cache[struct{}, domain.Prompt]
 }
 
 // NewPromptService creates a new PromptService.
 func NewPromptService(promptRepo domain.PromptRepository, logger *zap.Logger) *PromptService {
-	return &PromptService{
+	s := &PromptService{
 		promptRepo: promptRepo,
 		logger:     logger,
 	}
+	s.SetCacheTTL(DefaultCacheTTL)
+	return s
+}
+
+// SetCacheTTL sets how long prompts are cached. Zero disables the cache.
+func (s *PromptService) SetCacheTTL(ttl time.Duration) {
+	s.byID = cache.New[uuid.UUID, domain.Prompt]("prompts_by_id", ttl, nil)
+	s.byName = cache.New[string, domain.Prompt]("prompts_by_name", ttl, nil)
+	s.defaultPrompt = cache.New[struct{}, domain.Prompt]("default_prompt", ttl, nil)
+}
+
+// CacheStats reports how the prompt caches are doing.
+func (s *PromptService) CacheStats() []cache.Stats {
+	return []cache.Stats{s.byID.Stats(), s.byName.Stats(), s.defaultPrompt.Stats()}
+}
+
+// invalidateCache clears the prompt caches. A change to one prompt can
+// change another's default status, so everything goes.
+func (s *PromptService) invalidateCache() {
+	s.byID.Clear()
+	s.byName.Clear()
+	s.defaultPrompt.Clear()
+}
+
+// SetVersionRepository keeps the content each update replaces, so prompts
+// can be compared with and restored to earlier versions.
+func (s *PromptService) SetVersionRepository(versionRepo domain.PromptVersionRepository) {
+	s.versionRepo = versionRepo
 }
 
 // CreatePromptRequest contains parameters for creating a prompt.
 type CreatePromptRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" validate:"required,max=255"`
 	Description string `json:"description,omitempty"`
-	Task        string `json:"task"`
+	Task        string `json:"task" validate:"required"`
 
 	// Voice settings
 	Voice    string `json:"voice,omitempty"`
@@ -36,22 +73,29 @@ type CreatePromptRequest struct {
 
 	// Model settings
 	Model                 string   `json:"model,omitempty"`
-	Temperature           *float64 `json:"temperature,omitempty"`
-	InterruptionThreshold *int     `json:"interruption_threshold,omitempty"`
-	MaxDuration           *int     `json:"max_duration,omitempty"`
+	Temperature           *float64 `json:"temperature,omitempty" validate:"temperature"`
+	InterruptionThreshold *int     `json:"interruption_threshold,omitempty" validate:"min=0"`
+	MaxDuration           *int     `json:"max_duration,omitempty" validate:"duration"`
 
 	// Opening behavior
 	FirstSentence   string `json:"first_sentence,omitempty"`
 	WaitForGreeting bool   `json:"wait_for_greeting,omitempty"`
 
 	// Transfer settings
-	TransferPhoneNumber string            `json:"transfer_phone_number,omitempty"`
+	TransferPhoneNumber string            `json:"transfer_phone_number,omitempty" validate:"e164"`
 	TransferList        map[string]string `json:"transfer_list,omitempty"`
 
 	// Voicemail
-	VoicemailAction  string `json:"voicemail_action,omitempty"`
+	VoicemailAction  string `json:"voicemail_action,omitempty" validate:"oneof=hangup leave_message ignore"`
 	VoicemailMessage string `json:"voicemail_message,omitempty"`
 
+	// RetryPolicy re-dials numbers whose call went to voicemail or was not
+	// answered.
+	RetryPolicy *domain.CallRetryPolicy `json:"retry_policy,omitempty"`
+
+	// Localizations hold the prompt's text in other languages.
+	Localizations map[string]*domain.PromptLocalization `json:"localizations,omitempty"`
+
 	// Recording
 	Record            bool    `json:"record,omitempty"`
 	BackgroundTrack   *string `json:"background_track,omitempty"`
@@ -71,7 +115,7 @@ type CreatePromptRequest struct {
 
 // UpdatePromptRequest contains parameters for updating a prompt.
 type UpdatePromptRequest struct {
-	Name        *string `json:"name,omitempty"`
+	Name        *string `json:"name,omitempty" validate:"max=255"`
 	Description *string `json:"description,omitempty"`
 	Task        *string `json:"task,omitempty"`
 
@@ -79,19 +123,27 @@ type UpdatePromptRequest struct {
 	Language *string `json:"language,omitempty"`
 
 	Model                 *string  `json:"model,omitempty"`
-	Temperature           *float64 `json:"temperature,omitempty"`
-	InterruptionThreshold *int     `json:"interruption_threshold,omitempty"`
-	MaxDuration           *int     `json:"max_duration,omitempty"`
+	Temperature           *float64 `json:"temperature,omitempty" validate:"temperature"`
+	InterruptionThreshold *int     `json:"interruption_threshold,omitempty" validate:"min=0"`
+	MaxDuration           *int     `json:"max_duration,omitempty" validate:"duration"`
 
 	FirstSentence   *string `json:"first_sentence,omitempty"`
 	WaitForGreeting *bool   `json:"wait_for_greeting,omitempty"`
 
-	TransferPhoneNumber *string            `json:"transfer_phone_number,omitempty"`
+	TransferPhoneNumber *string            `json:"transfer_phone_number,omitempty" validate:"e164"`
 	TransferList        map[string]string  `json:"transfer_list,omitempty"`
 
-	VoicemailAction  *string `json:"voicemail_action,omitempty"`
+	VoicemailAction  *string `json:"voicemail_action,omitempty" validate:"oneof=hangup leave_message ignore"`
 	VoicemailMessage *string `json:"voicemail_message,omitempty"`
 
+	// RetryPolicy replaces the prompt's retry policy; max_attempts of 0
+	// removes it.
+	RetryPolicy *domain.CallRetryPolicy `json:"retry_policy,omitempty"`
+
+	// Localizations replace the prompt's localizations; an empty object
+	// removes them.
+	Localizations map[string]*domain.PromptLocalization `json:"localizations,omitempty"`
+
 	Record            *bool   `json:"record,omitempty"`
 	BackgroundTrack   *string `json:"background_track,omitempty"`
 	NoiseCancellation *bool   `json:"noise_cancellation,omitempty"`
@@ -104,6 +156,9 @@ type UpdatePromptRequest struct {
 
 	IsDefault *bool `json:"is_default,omitempty"`
 	IsActive  *bool `json:"is_active,omitempty"`
+
+	// ChangedBy identifies who made the update in the prompt's history.
+	ChangedBy string `json:"-"`
 }
 
 // CreatePrompt creates a new prompt.
@@ -148,6 +203,8 @@ func (s *PromptService) CreatePrompt(ctx context.Context, req *CreatePromptReque
 	if req.VoicemailMessage != "" {
 		prompt.VoicemailMessage = req.VoicemailMessage
 	}
+	prompt.RetryPolicy = req.RetryPolicy
+	prompt.Localizations = domain.NormalizeLocalizations(req.Localizations)
 	prompt.Record = req.Record
 	if req.BackgroundTrack != nil {
 		prompt.BackgroundTrack = req.BackgroundTrack
@@ -176,6 +233,7 @@ func (s *PromptService) CreatePrompt(ctx context.Context, req *CreatePromptReque
 	if err := s.promptRepo.Create(ctx, prompt); err != nil {
 		return nil, fmt.Errorf("failed to create prompt: %w", err)
 	}
+	defer s.invalidateCache()
 
 	// If this is set as default, update default status
 	if prompt.IsDefault {
@@ -194,17 +252,44 @@ func (s *PromptService) CreatePrompt(ctx context.Context, req *CreatePromptReque
 
 // GetPrompt retrieves a prompt by ID.
 func (s *PromptService) GetPrompt(ctx context.Context, id uuid.UUID) (*domain.Prompt, error) {
-	return s.promptRepo.GetByID(ctx, id)
+	if prompt, ok := s.byID.Get(id); ok {
+		return &prompt, nil
+	}
+
+	prompt, err := s.promptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.byID.Set(id, *prompt)
+	return prompt, nil
 }
 
 // GetPromptByName retrieves a prompt by name.
 func (s *PromptService) GetPromptByName(ctx context.Context, name string) (*domain.Prompt, error) {
-	return s.promptRepo.GetByName(ctx, name)
+	if prompt, ok := s.byName.Get(name); ok {
+		return &prompt, nil
+	}
+
+	prompt, err := s.promptRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	s.byName.Set(name, *prompt)
+	return prompt, nil
 }
 
 // GetDefaultPrompt retrieves the default prompt.
 func (s *PromptService) GetDefaultPrompt(ctx context.Context) (*domain.Prompt, error) {
-	return s.promptRepo.GetDefault(ctx)
+	if prompt, ok := s.defaultPrompt.Get(struct{}{}); ok {
+		return &prompt, nil
+	}
+
+	prompt, err := s.promptRepo.GetDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.defaultPrompt.Set(struct{}{}, *prompt)
+	return prompt, nil
 }
 
 // ListPrompts retrieves prompts with pagination.
@@ -231,12 +316,83 @@ func (s *PromptService) ListPrompts(ctx context.Context, page, pageSize int, act
 	return prompts, total, nil
 }
 
+// ListAllPrompts returns every prompt, or every active one, without paging.
+func (s *PromptService) ListAllPrompts(ctx context.Context, activeOnly bool) ([]*domain.Prompt, error) {
+	const pageSize = 100
+	var prompts []*domain.Prompt
+	for offset := 0; ; offset += pageSize {
+		page, err := s.promptRepo.List(ctx, pageSize, offset, activeOnly)
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, page...)
+		if len(page) < pageSize {
+			return prompts, nil
+		}
+	}
+}
+
+// ImportPrompt saves a complete prompt built elsewhere, such as from a
+// bundle. It never becomes the default.
+func (s *PromptService) ImportPrompt(ctx context.Context, prompt *domain.Prompt) error {
+	prompt.IsDefault = false
+	if err := prompt.Validate(); err != nil {
+		return err
+	}
+	if err := s.promptRepo.Create(ctx, prompt); err != nil {
+		return fmt.Errorf("failed to import prompt: %w", err)
+	}
+	s.invalidateCache()
+
+	s.logger.Info("prompt imported",
+		zap.String("id", prompt.ID.String()),
+		zap.String("name", prompt.Name),
+	)
+	return nil
+}
+
+// ReplacePromptContent sets a prompt's versioned fields to those of content,
+// keeping its ID, flags and creation time. The content it replaces is kept
+// as a version.
+func (s *PromptService) ReplacePromptContent(ctx context.Context, id uuid.UUID, content *domain.Prompt, changedBy string) (*domain.Prompt, error) {
+	current, err := s.promptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := *content
+	replaced.ID = current.ID
+	replaced.IsDefault = current.IsDefault
+	replaced.IsActive = current.IsActive
+	replaced.CreatedAt = current.CreatedAt
+	replaced.UpdatedAt = time.Now()
+	replaced.DeletedAt = current.DeletedAt
+	if err := replaced.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveVersion(ctx, current, &replaced, changedBy); err != nil {
+		return nil, err
+	}
+	if err := s.promptRepo.Update(ctx, &replaced); err != nil {
+		return nil, fmt.Errorf("failed to update prompt: %w", err)
+	}
+	s.invalidateCache()
+
+	s.logger.Info("prompt content replaced",
+		zap.String("id", id.String()),
+		zap.String("name", replaced.Name),
+	)
+	return &replaced, nil
+}
+
 // UpdatePrompt updates an existing prompt.
 func (s *PromptService) UpdatePrompt(ctx context.Context, id uuid.UUID, req *UpdatePromptRequest) (*domain.Prompt, error) {
 	prompt, err := s.promptRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	previous := *prompt
 
 	// Apply updates
 	if req.Name != nil {
@@ -284,6 +440,15 @@ func (s *PromptService) UpdatePrompt(ctx context.Context, id uuid.UUID, req *Upd
 	if req.VoicemailMessage != nil {
 		prompt.VoicemailMessage = *req.VoicemailMessage
 	}
+	if req.RetryPolicy != nil {
+		prompt.RetryPolicy = req.RetryPolicy
+		if req.RetryPolicy.MaxAttempts == 0 {
+			prompt.RetryPolicy = nil
+		}
+	}
+	if req.Localizations != nil {
+		prompt.Localizations = domain.NormalizeLocalizations(req.Localizations)
+	}
 	if req.Record != nil {
 		prompt.Record = *req.Record
 	}
@@ -314,6 +479,7 @@ func (s *PromptService) UpdatePrompt(ctx context.Context, id uuid.UUID, req *Upd
 		if err := s.promptRepo.SetDefault(ctx, prompt.ID); err != nil {
 			return nil, fmt.Errorf("failed to set as default: %w", err)
 		}
+		defer s.invalidateCache()
 		prompt.IsDefault = true
 	}
 
@@ -322,10 +488,16 @@ func (s *PromptService) UpdatePrompt(ctx context.Context, id uuid.UUID, req *Upd
 		return nil, err
 	}
 
+	// Keep the content being replaced
+	if err := s.saveVersion(ctx, &previous, prompt, req.ChangedBy); err != nil {
+		return nil, err
+	}
+
 	// Update in database
 	if err := s.promptRepo.Update(ctx, prompt); err != nil {
 		return nil, fmt.Errorf("failed to update prompt: %w", err)
 	}
+	s.invalidateCache()
 
 	s.logger.Info("prompt updated",
 		zap.String("id", prompt.ID.String()),
@@ -340,6 +512,7 @@ func (s *PromptService) DeletePrompt(ctx context.Context, id uuid.UUID) error {
 	if err := s.promptRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete prompt: %w", err)
 	}
+	s.invalidateCache()
 
 	s.logger.Info("prompt deleted", zap.String("id", id.String()))
 	return nil
@@ -350,6 +523,7 @@ func (s *PromptService) SetDefaultPrompt(ctx context.Context, id uuid.UUID) erro
 	if err := s.promptRepo.SetDefault(ctx, id); err != nil {
 		return fmt.Errorf("failed to set default prompt: %w", err)
 	}
+	s.invalidateCache()
 
 	s.logger.Info("default prompt set", zap.String("id", id.String()))
 	return nil
@@ -372,6 +546,7 @@ func (s *PromptService) DuplicatePrompt(ctx context.Context, id uuid.UUID, newNa
 	if err := s.promptRepo.Create(ctx, &copy); err != nil {
 		return nil, fmt.Errorf("failed to duplicate prompt: %w", err)
 	}
+	s.invalidateCache()
 
 	s.logger.Info("prompt duplicated",
 		zap.String("original_id", id.String()),
@@ -381,3 +556,99 @@ func (s *PromptService) DuplicatePrompt(ctx context.Context, id uuid.UUID, newNa
 
 	return &copy, nil
 }
+
+// ListPromptVersions returns a prompt's earlier versions, newest first.
+func (s *PromptService) ListPromptVersions(ctx context.Context, id uuid.UUID) ([]*domain.PromptVersion, error) {
+	if s.versionRepo == nil {
+		return []*domain.PromptVersion{}, nil
+	}
+	if _, err := s.promptRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.versionRepo.List(ctx, id)
+}
+
+// GetPromptVersion returns an earlier version of a prompt, compared field
+// by field with the prompt as it is now.
+func (s *PromptService) GetPromptVersion(ctx context.Context, id uuid.UUID, version int) (*domain.PromptVersion, []domain.PromptFieldDiff, error) {
+	if s.versionRepo == nil {
+		return nil, nil, apperrors.NotFound("prompt version")
+	}
+
+	current, err := s.promptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	v, err := s.versionRepo.Get(ctx, id, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	old, err := v.Prompt()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff, err := domain.DiffPrompts(old, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, diff, nil
+}
+
+// RestorePromptVersion sets a prompt's content back to an earlier version.
+// The content it replaces is kept as a new version, so a restore can be
+// undone the same way.
+func (s *PromptService) RestorePromptVersion(ctx context.Context, id uuid.UUID, version int, changedBy string) (*domain.Prompt, error) {
+	if s.versionRepo == nil {
+		return nil, apperrors.NotFound("prompt version")
+	}
+
+	current, err := s.promptRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.versionRepo.Get(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := v.RestoreOnto(current)
+	if err != nil {
+		return nil, err
+	}
+	if err := restored.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveVersion(ctx, current, restored, changedBy); err != nil {
+		return nil, err
+	}
+	if err := s.promptRepo.Update(ctx, restored); err != nil {
+		return nil, fmt.Errorf("failed to restore prompt: %w", err)
+	}
+	s.invalidateCache()
+
+	s.logger.Info("prompt version restored",
+		zap.String("id", id.String()),
+		zap.Int("version", version),
+	)
+	return restored, nil
+}
+
+// saveVersion keeps the content of previous before next replaces it. It
+// does nothing without a version repository or when the content is
+// unchanged.
+func (s *PromptService) saveVersion(ctx context.Context, previous, next *domain.Prompt, changedBy string) error {
+	if s.versionRepo == nil {
+		return nil
+	}
+
+	v, err := domain.NewPromptVersion(previous, next, changedBy)
+	if err != nil || v == nil {
+		return err
+	}
+	if err := s.versionRepo.Create(ctx, v); err != nil {
+		return fmt.Errorf("failed to save prompt version: %w", err)
+	}
+	return nil
+}
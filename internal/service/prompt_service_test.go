@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockPromptRepository is an in-memory PromptRepository.
+type MockPromptRepository struct {
+	domain.PromptRepository
+	prompts map[uuid.UUID]*domain.Prompt
+}
+
+func NewMockPromptRepository(prompts ...*domain.Prompt) *MockPromptRepository {
+	m := &MockPromptRepository{prompts: make(map[uuid.UUID]*domain.Prompt)}
+	for _, p := range prompts {
+		m.prompts[p.ID] = p
+	}
+	return m
+}
+
+func (m *MockPromptRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Prompt, error) {
+	p, ok := m.prompts[id]
+	if !ok {
+		return nil, apperrors.NotFound("prompt")
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (m *MockPromptRepository) Update(ctx context.Context, prompt *domain.Prompt) error {
+	cp := *prompt
+	m.prompts[prompt.ID] = &cp
+	return nil
+}
+
+func (m *MockPromptRepository) Create(ctx context.Context, prompt *domain.Prompt) error {
+	cp := *prompt
+	m.prompts[prompt.ID] = &cp
+	return nil
+}
+
+func (m *MockPromptRepository) List(ctx context.Context, limit, offset int, activeOnly bool) ([]*domain.Prompt, error) {
+	var prompts []*domain.Prompt
+	for _, p := range m.prompts {
+		if activeOnly && !p.IsActive {
+			continue
+		}
+		cp := *p
+		prompts = append(prompts, &cp)
+	}
+	if offset >= len(prompts) {
+		return nil, nil
+	}
+	prompts = prompts[offset:]
+	if len(prompts) > limit {
+		prompts = prompts[:limit]
+	}
+	return prompts, nil
+}
+
+// MockPromptVersionRepository is an in-memory PromptVersionRepository.
+type MockPromptVersionRepository struct {
+	versions []*domain.PromptVersion
+}
+
+func (m *MockPromptVersionRepository) Create(ctx context.Context, version *domain.PromptVersion) error {
+	version.Version = 1
+	for _, v := range m.versions {
+		if v.PromptID == version.PromptID && v.Version >= version.Version {
+			version.Version = v.Version + 1
+		}
+	}
+	m.versions = append(m.versions, version)
+	return nil
+}
+
+func (m *MockPromptVersionRepository) Get(ctx context.Context, promptID uuid.UUID, version int) (*domain.PromptVersion, error) {
+	for _, v := range m.versions {
+		if v.PromptID == promptID && v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, apperrors.NotFound("prompt version")
+}
+
+func (m *MockPromptVersionRepository) List(ctx context.Context, promptID uuid.UUID) ([]*domain.PromptVersion, error) {
+	versions := []*domain.PromptVersion{}
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		if m.versions[i].PromptID == promptID {
+			versions = append(versions, m.versions[i])
+		}
+	}
+	return versions, nil
+}
+
+func newTestPromptService() (*PromptService, *domain.Prompt, *MockPromptVersionRepository) {
+	prompt := domain.NewPrompt("Intake", "Ask about the project.")
+	versions := &MockPromptVersionRepository{}
+	svc := NewPromptService(NewMockPromptRepository(prompt), zap.NewNop())
+	svc.SetVersionRepository(versions)
+	return svc, prompt, versions
+}
+
+func TestPromptService_UpdatePromptSavesVersion(t *testing.T) {
+	svc, prompt, versions := newTestPromptService()
+	ctx := context.Background()
+
+	task := "Ask about the project and budget."
+	if _, err := svc.UpdatePrompt(ctx, prompt.ID, &UpdatePromptRequest{Task: &task, ChangedBy: "admin@example.com"}); err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+
+	if len(versions.versions) != 1 {
+		t.Fatalf("expected 1 version, got %d", len(versions.versions))
+	}
+	v := versions.versions[0]
+	if v.Version != 1 || v.ChangedBy != "admin@example.com" || !reflect.DeepEqual(v.ChangedFields, []string{"task"}) {
+		t.Errorf("unexpected version %+v", v)
+	}
+	kept, _ := v.Prompt()
+	if kept.Task != "Ask about the project." {
+		t.Errorf("version kept task %q, want the previous task", kept.Task)
+	}
+
+	// Saving the same content again adds no version.
+	if _, err := svc.UpdatePrompt(ctx, prompt.ID, &UpdatePromptRequest{Task: &task}); err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+	if len(versions.versions) != 1 {
+		t.Errorf("an unchanged update saved a version")
+	}
+}
+
+func TestPromptService_RestorePromptVersion(t *testing.T) {
+	svc, prompt, versions := newTestPromptService()
+	ctx := context.Background()
+
+	name, task := "Intake v2", "New task."
+	if _, err := svc.UpdatePrompt(ctx, prompt.ID, &UpdatePromptRequest{Name: &name, Task: &task}); err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+
+	_, diff, err := svc.GetPromptVersion(ctx, prompt.ID, 1)
+	if err != nil {
+		t.Fatalf("GetPromptVersion() error = %v", err)
+	}
+	var changed []string
+	for _, field := range diff {
+		if field.Changed {
+			changed = append(changed, field.Field)
+		}
+	}
+	if !reflect.DeepEqual(changed, []string{"name", "task"}) {
+		t.Errorf("changed fields = %v, want [name task]", changed)
+	}
+
+	restored, err := svc.RestorePromptVersion(ctx, prompt.ID, 1, "admin@example.com")
+	if err != nil {
+		t.Fatalf("RestorePromptVersion() error = %v", err)
+	}
+	if restored.Name != "Intake" || restored.Task != "Ask about the project." || restored.ID != prompt.ID {
+		t.Errorf("restored prompt = %+v", restored)
+	}
+
+	if len(versions.versions) != 2 {
+		t.Fatalf("expected the replaced content to be kept, got %d versions", len(versions.versions))
+	}
+	if kept, _ := versions.versions[1].Prompt(); kept.Task != "New task." {
+		t.Errorf("version 2 kept task %q, want the content the restore replaced", kept.Task)
+	}
+
+	if _, err := svc.RestorePromptVersion(ctx, prompt.ID, 9, ""); !apperrors.IsNotFound(err) {
+		t.Errorf("RestorePromptVersion() to a missing version error = %v, want not found", err)
+	}
+}
+
+func TestPromptService_CachesUntilWrite(t *testing.T) {
+	svc, prompt, _ := newTestPromptService()
+	repo := svc.promptRepo.(*MockPromptRepository)
+	ctx := context.Background()
+
+	if _, err := svc.GetPrompt(ctx, prompt.ID); err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+
+	// A change behind the service's back isn't seen until the entry expires
+	repo.prompts[prompt.ID].Task = "Changed elsewhere."
+	got, err := svc.GetPrompt(ctx, prompt.ID)
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if got.Task != "Ask about the project." {
+		t.Errorf("GetPrompt() task = %q, expected the cached task", got.Task)
+	}
+
+	// A write through the service is seen straight away
+	task := "Ask about the timeline."
+	if _, err := svc.UpdatePrompt(ctx, prompt.ID, &UpdatePromptRequest{Task: &task}); err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+	got, err = svc.GetPrompt(ctx, prompt.ID)
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if got.Task != task {
+		t.Errorf("GetPrompt() task = %q after update, expected %q", got.Task, task)
+	}
+
+	stats := svc.CacheStats()[0]
+	if stats.Name != "prompts_by_id" || stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// ProviderDialer places outbound calls through whichever voice provider is
+// primary, so campaigns run on it. Calls go through Bland when it is the
+// primary provider. Other providers call with the agent configured at the
+// provider: the request's prompt is not sent, and its request data is
+// passed to the agent as variables.
+//
+// When a provider fails to place a call, the call fails over to the next
+// provider the registry offers, calling from the number mapped to the
+// caller ID. Calls refused for budget or compliance reasons don't fail over.
+type ProviderDialer struct {
+	registry   *voiceprovider.Registry
+	bland      CampaignDialer
+	callRepo   domain.CallRepository
+	customers  CustomerLinker
+	publisher  EventPublisher
+	budget     CallBudget
+	compliance CallCompliance
+	blocklist  OutboundBlocklist
+	callEvents domain.CallEventRepository
+	metrics    *metrics.Metrics
+	logger     *zap.Logger
+}
+
+// NewProviderDialer creates a new ProviderDialer. bland places calls through
+// Bland and may be nil when Bland is not configured.
+func NewProviderDialer(registry *voiceprovider.Registry, bland CampaignDialer, callRepo domain.CallRepository, logger *zap.Logger) *ProviderDialer {
+	return &ProviderDialer{
+		registry: registry,
+		bland:    bland,
+		callRepo: callRepo,
+		logger:   logger,
+	}
+}
+
+// SetMetrics sets the collector for failover events.
+func (d *ProviderDialer) SetMetrics(m *metrics.Metrics) {
+	d.metrics = m
+}
+
+// SetCustomerLinker links the calls placed to customers.
+func (d *ProviderDialer) SetCustomerLinker(linker CustomerLinker) {
+	d.customers = linker
+}
+
+// SetEventPublisher announces the calls placed.
+func (d *ProviderDialer) SetEventPublisher(publisher EventPublisher) {
+	d.publisher = publisher
+}
+
+// SetBudget refuses calls while the monthly budget's hard cap is reached.
+func (d *ProviderDialer) SetBudget(budget CallBudget) {
+	d.budget = budget
+}
+
+// SetCompliance rejects calls to numbers on the do-not-call list or outside
+// the calling window.
+func (d *ProviderDialer) SetCompliance(compliance CallCompliance) {
+	d.compliance = compliance
+}
+
+// SetBlocklist rejects calls to numbers and prefixes blocked for outbound
+// calls.
+func (d *ProviderDialer) SetBlocklist(blocklist OutboundBlocklist) {
+	d.blocklist = blocklist
+}
+
+// SetCallEvents starts the status event log of each call placed.
+func (d *ProviderDialer) SetCallEvents(events domain.CallEventRepository) {
+	d.callEvents = events
+}
+
+// InitiateCall places a call through the first provider that takes it and
+// records it.
+func (d *ProviderDialer) InitiateCall(ctx context.Context, req *InitiateCallRequest) (*InitiateCallResponse, error) {
+	if req.PhoneNumber == "" {
+		return nil, fmt.Errorf("phone_number is required")
+	}
+
+	providers := d.registry.OutboundProviders()
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no voice provider can place outbound calls")
+	}
+
+	var lastErr error
+	for i, provider := range providers {
+		attempt := req
+		if i > 0 {
+			from, to := providers[i-1].GetName(), provider.GetName()
+			d.logger.Warn("failing over outbound call",
+				zap.String("from", string(from)),
+				zap.String("to", string(to)),
+				zap.Error(lastErr),
+			)
+			if d.metrics != nil {
+				d.metrics.RecordProviderFailover(string(from), string(to))
+			}
+			failover := *req
+			failover.FromNumber = d.registry.MapNumber(req.FromNumber)
+			attempt = &failover
+		}
+
+		resp, err := d.dial(ctx, provider, attempt)
+		if err == nil {
+			return resp, nil
+		}
+		if !shouldFailOver(ctx, err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// shouldFailOver reports whether a call that failed may be placed through
+// another provider: not when the call itself was refused or the caller gave
+// up.
+func shouldFailOver(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !apperrors.IsUserError(err)
+}
+
+// dial places a call through one provider.
+func (d *ProviderDialer) dial(ctx context.Context, provider voiceprovider.Provider, req *InitiateCallRequest) (*InitiateCallResponse, error) {
+	if provider.GetName() == voiceprovider.ProviderBland {
+		if d.bland == nil {
+			return nil, fmt.Errorf("bland is not configured for outbound calls")
+		}
+		return d.bland.InitiateCall(ctx, req)
+	}
+	outbound, ok := provider.(voiceprovider.OutboundProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support outbound calls", provider.GetName())
+	}
+
+	if d.budget != nil {
+		if err := d.budget.CheckBudget(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if d.compliance != nil {
+		if err := d.compliance.CheckCall(ctx, req.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+	if d.blocklist != nil {
+		if err := d.blocklist.CheckOutbound(ctx, req.PhoneNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	outboundReq := voiceprovider.OutboundCallRequest{
+		ToNumber:     req.PhoneNumber,
+		FromNumber:   req.FromNumber,
+		Task:         req.Task,
+		PathwayID:    req.PathwayID,
+		Voice:        req.Voice,
+		FirstMessage: req.FirstSentence,
+		Metadata:     req.Metadata,
+	}
+	if len(req.RequestData) > 0 {
+		outboundReq.Variables = make(map[string]string, len(req.RequestData))
+		for k, v := range req.RequestData {
+			outboundReq.Variables[k] = fmt.Sprint(v)
+		}
+	}
+
+	resp, err := outbound.InitiateCall(ctx, outboundReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate call: %w", err)
+	}
+
+	// The webhook fills in the rest; the metadata marks the call as one we
+	// placed until then.
+	call := domain.NewCall(resp.ProviderCallID, string(provider.GetName()), req.PhoneNumber, "")
+	if len(req.Metadata) > 0 {
+		call.ProviderMetadata = map[string]interface{}{"metadata": req.Metadata}
+	}
+	if d.customers != nil {
+		if err := d.customers.LinkCall(ctx, call); err != nil {
+			d.logger.Warn("failed to link call to customer",
+				zap.String("provider_call_id", resp.ProviderCallID),
+				zap.Error(err),
+			)
+		}
+	}
+	if err := d.callRepo.Create(ctx, call); err != nil {
+		// Don't fail - the call was already placed, and its webhook
+		// creates the record if this didn't
+		d.logger.Error("failed to create call record",
+			zap.String("provider_call_id", resp.ProviderCallID),
+			zap.Error(err),
+		)
+	} else {
+		recordCallPlaced(ctx, d.callEvents, call, req.PlacedBy, d.logger)
+		if d.publisher != nil {
+			d.publisher.Publish(ctx, domain.WebhookEventCallCreated, NewWebhookCallData(call))
+		}
+	}
+
+	d.logger.Info("call initiated",
+		zap.String("provider", string(provider.GetName())),
+		zap.String("call_id", call.ID.String()),
+		zap.String("provider_call_id", resp.ProviderCallID),
+	)
+
+	return &InitiateCallResponse{
+		CallID:      call.ID,
+		Status:      resp.Status,
+		PhoneNumber: req.PhoneNumber,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/metrics"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// fakeOutboundProvider records the outbound calls placed through it, and
+// fails them with err when set.
+type fakeOutboundProvider struct {
+	name     voiceprovider.ProviderType
+	err      error
+	requests []voiceprovider.OutboundCallRequest
+}
+
+func (p *fakeOutboundProvider) GetName() voiceprovider.ProviderType { return p.name }
+func (p *fakeOutboundProvider) GetWebhookPath() string              { return "/webhook/" + string(p.name) }
+func (p *fakeOutboundProvider) ValidateWebhook(r *http.Request) bool {
+	return true
+}
+func (p *fakeOutboundProvider) ParseWebhook(r *http.Request) (*voiceprovider.CallEvent, error) {
+	return nil, nil
+}
+func (p *fakeOutboundProvider) GetCallStatus(ctx context.Context, providerCallID string) (*voiceprovider.CallEvent, error) {
+	return nil, nil
+}
+
+func (p *fakeOutboundProvider) InitiateCall(ctx context.Context, req voiceprovider.OutboundCallRequest) (*voiceprovider.OutboundCallResponse, error) {
+	p.requests = append(p.requests, req)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &voiceprovider.OutboundCallResponse{ProviderCallID: "retell-1", Status: "registered"}, nil
+}
+
+func newTestProviderDialer(primary voiceprovider.Provider, bland CampaignDialer) (*ProviderDialer, *MockCallRepository) {
+	registry := voiceprovider.NewRegistry(zap.NewNop())
+	registry.Register(primary)
+	calls := NewMockCallRepository()
+	return NewProviderDialer(registry, bland, calls, zap.NewNop()), calls
+}
+
+func TestProviderDialer_InitiateCall(t *testing.T) {
+	provider := &fakeOutboundProvider{name: voiceprovider.ProviderRetell}
+	bland := &fakeCampaignDialer{}
+	dialer, calls := newTestProviderDialer(provider, bland)
+	ctx := context.Background()
+
+	resp, err := dialer.InitiateCall(ctx, &InitiateCallRequest{
+		PhoneNumber: "+15550002222",
+		RequestData: map[string]interface{}{"name": "Jane"},
+		Metadata:    map[string]interface{}{"campaign_contact_id": "c1"},
+	})
+	if err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+	if len(bland.requests) != 0 || len(provider.requests) != 1 {
+		t.Fatalf("expected the call placed through Retell, got %d Bland and %d Retell calls", len(bland.requests), len(provider.requests))
+	}
+	if provider.requests[0].Variables["name"] != "Jane" {
+		t.Errorf("expected the contact's name as a variable, got %v", provider.requests[0].Variables)
+	}
+
+	call, err := calls.GetByProviderCallID(ctx, "retell-1")
+	if err != nil {
+		t.Fatalf("expected the call recorded: %v", err)
+	}
+	if call.ID != resp.CallID || call.Provider != "retell" {
+		t.Errorf("unexpected call %+v", call)
+	}
+	if call.IsInbound() || call.CustomerNumber() != "+15550002222" {
+		t.Errorf("expected an outbound call to +15550002222, got customer number %s", call.CustomerNumber())
+	}
+}
+
+func TestProviderDialer_InitiateCallThroughBland(t *testing.T) {
+	bland := &fakeCampaignDialer{}
+	dialer, _ := newTestProviderDialer(&fakeOutboundProvider{name: voiceprovider.ProviderBland}, bland)
+
+	if _, err := dialer.InitiateCall(context.Background(), &InitiateCallRequest{PhoneNumber: "+15550002222"}); err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+	if len(bland.requests) != 1 {
+		t.Errorf("expected the call placed through Bland, got %d", len(bland.requests))
+	}
+}
+
+func TestProviderDialer_FailsOver(t *testing.T) {
+	bland := &fakeOutboundProvider{name: voiceprovider.ProviderBland}
+	retell := &fakeOutboundProvider{name: voiceprovider.ProviderRetell}
+	registry := voiceprovider.NewRegistry(zap.NewNop())
+	registry.Register(bland)
+	registry.Register(retell)
+	registry.SetPrimary(voiceprovider.ProviderBland)
+	registry.SetFallbacks([]voiceprovider.ProviderType{voiceprovider.ProviderRetell})
+	registry.SetNumberMap(map[string]string{"+15550001111": "+15550009999"})
+
+	blandDialer := &fakeCampaignDialer{failNumbers: map[string]bool{"+15550002222": true}}
+	dialer := NewProviderDialer(registry, blandDialer, NewMockCallRepository(), zap.NewNop())
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	dialer.SetMetrics(m)
+
+	resp, err := dialer.InitiateCall(context.Background(), &InitiateCallRequest{
+		PhoneNumber: "+15550002222",
+		FromNumber:  "+15550001111",
+	})
+	if err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+	if len(blandDialer.requests) != 1 || len(retell.requests) != 1 {
+		t.Fatalf("expected Bland tried then Retell, got %d Bland and %d Retell calls", len(blandDialer.requests), len(retell.requests))
+	}
+	if retell.requests[0].FromNumber != "+15550009999" {
+		t.Errorf("expected the mapped caller ID, got %q", retell.requests[0].FromNumber)
+	}
+	if resp.Status != "registered" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if n := testutil.ToFloat64(m.ProviderFailoversTotal.WithLabelValues("bland", "retell")); n != 1 {
+		t.Errorf("failover count = %f, expected 1", n)
+	}
+}
+
+func TestProviderDialer_NoFailoverWhenRefused(t *testing.T) {
+	primary := &fakeOutboundProvider{name: voiceprovider.ProviderVapi, err: errors.New("vapi: status 500")}
+	retell := &fakeOutboundProvider{name: voiceprovider.ProviderRetell}
+	registry := voiceprovider.NewRegistry(zap.NewNop())
+	registry.Register(primary)
+	registry.Register(retell)
+	registry.SetPrimary(voiceprovider.ProviderVapi)
+	registry.SetFallbacks([]voiceprovider.ProviderType{voiceprovider.ProviderRetell})
+	dialer := NewProviderDialer(registry, nil, NewMockCallRepository(), zap.NewNop())
+	dialer.SetCompliance(refusingCompliance{})
+
+	_, err := dialer.InitiateCall(context.Background(), &InitiateCallRequest{PhoneNumber: "+15550002222"})
+	if apperrors.GetCode(err) != apperrors.CodeComplianceBlocked {
+		t.Fatalf("expected the compliance error, got %v", err)
+	}
+	if len(primary.requests) != 0 || len(retell.requests) != 0 {
+		t.Errorf("expected no calls placed, got %d and %d", len(primary.requests), len(retell.requests))
+	}
+}
+
+// refusingCompliance blocks every call.
+type refusingCompliance struct{}
+
+func (refusingCompliance) CheckCall(ctx context.Context, phoneNumber string) error {
+	return apperrors.New(apperrors.CodeComplianceBlocked, "outside the calling window")
+}
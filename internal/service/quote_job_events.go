@@ -0,0 +1,135 @@
+package service
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// quoteJobSubscriberBuffer is how many events a slow subscriber may fall
+// behind before it is dropped.
+const quoteJobSubscriberBuffer = 256
+
+// QuoteJobEvents fans out quote job progress to listeners in this process.
+// It remembers the phase and text of jobs in progress so listeners that join
+// part way through can catch up.
+type QuoteJobEvents struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*quoteJobProgress
+}
+
+// quoteJobProgress is the state of one job's progress stream.
+type quoteJobProgress struct {
+	phase   domain.QuoteJobPhase
+	attempt int
+	text    strings.Builder
+	subs    map[chan domain.QuoteJobEvent]struct{}
+}
+
+// NewQuoteJobEvents creates an empty QuoteJobEvents.
+func NewQuoteJobEvents() *QuoteJobEvents {
+	return &QuoteJobEvents{jobs: make(map[uuid.UUID]*quoteJobProgress)}
+}
+
+// Subscribe registers a listener for a job. It returns the events needed to
+// catch up with a job already in progress (its phase, then the text so far),
+// a channel of further events, and a function to unsubscribe. The channel is
+// closed after the job's final phase, or if the listener falls too far behind.
+func (e *QuoteJobEvents) Subscribe(jobID uuid.UUID) ([]domain.QuoteJobEvent, <-chan domain.QuoteJobEvent, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p := e.progress(jobID)
+	ch := make(chan domain.QuoteJobEvent, quoteJobSubscriberBuffer)
+	p.subs[ch] = struct{}{}
+
+	var catchUp []domain.QuoteJobEvent
+	if p.phase != "" {
+		catchUp = append(catchUp, domain.QuoteJobEvent{JobID: jobID, Phase: p.phase, Attempt: p.attempt})
+		if p.text.Len() > 0 {
+			catchUp = append(catchUp, domain.QuoteJobEvent{JobID: jobID, Text: p.text.String()})
+		}
+	}
+
+	unsubscribe := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if p, ok := e.jobs[jobID]; ok {
+			if _, ok := p.subs[ch]; ok {
+				delete(p.subs, ch)
+				close(ch)
+			}
+			e.cleanup(jobID, p)
+		}
+	}
+	return catchUp, ch, unsubscribe
+}
+
+// PublishPhase announces that a job has entered a phase. Returning to queued
+// discards any text from the failed attempt.
+func (e *QuoteJobEvents) PublishPhase(jobID uuid.UUID, phase domain.QuoteJobPhase, attempt int, errMsg string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p := e.progress(jobID)
+	p.phase = phase
+	p.attempt = attempt
+	if phase == domain.QuoteJobPhaseQueued {
+		p.text.Reset()
+	}
+	e.send(p, domain.QuoteJobEvent{JobID: jobID, Phase: phase, Attempt: attempt, Error: errMsg})
+
+	if phase.IsFinal() {
+		for ch := range p.subs {
+			close(ch)
+		}
+		delete(e.jobs, jobID)
+		return
+	}
+	e.cleanup(jobID, p)
+}
+
+// PublishText announces the next chunk of a job's quote text.
+func (e *QuoteJobEvents) PublishText(jobID uuid.UUID, text string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	p := e.progress(jobID)
+	p.text.WriteString(text)
+	e.send(p, domain.QuoteJobEvent{JobID: jobID, Text: text})
+}
+
+// progress returns the state for a job, creating it if needed. Callers must hold mu.
+func (e *QuoteJobEvents) progress(jobID uuid.UUID) *quoteJobProgress {
+	p, ok := e.jobs[jobID]
+	if !ok {
+		p = &quoteJobProgress{subs: make(map[chan domain.QuoteJobEvent]struct{})}
+		e.jobs[jobID] = p
+	}
+	return p
+}
+
+// send delivers an event to every subscriber, dropping any that are full so a
+// stalled listener cannot block quote generation. Callers must hold mu.
+func (e *QuoteJobEvents) send(p *quoteJobProgress, event domain.QuoteJobEvent) {
+	for ch := range p.subs {
+		select {
+		case ch <- event:
+		default:
+			delete(p.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// cleanup forgets a job that is not being worked on and has no listeners.
+// Callers must hold mu.
+func (e *QuoteJobEvents) cleanup(jobID uuid.UUID, p *quoteJobProgress) {
+	working := p.phase == domain.QuoteJobPhaseExtracting || p.phase == domain.QuoteJobPhasePricing
+	if !working && len(p.subs) == 0 {
+		delete(e.jobs, jobID)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// drainQuoteJobEvents reads events until the channel is closed or empty.
+func drainQuoteJobEvents(ch <-chan domain.QuoteJobEvent) (events []domain.QuoteJobEvent, closed bool) {
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return events, true
+			}
+			events = append(events, e)
+		default:
+			return events, false
+		}
+	}
+}
+
+func TestQuoteJobEvents_DeliversAndClosesOnFinalPhase(t *testing.T) {
+	events := NewQuoteJobEvents()
+	jobID := uuid.New()
+
+	catchUp, ch, unsubscribe := events.Subscribe(jobID)
+	defer unsubscribe()
+	if len(catchUp) != 0 {
+		t.Fatalf("expected no catch-up for an idle job, got %v", catchUp)
+	}
+
+	events.PublishPhase(jobID, domain.QuoteJobPhaseExtracting, 1, "")
+	events.PublishPhase(jobID, domain.QuoteJobPhasePricing, 1, "")
+	events.PublishText(jobID, "Hello")
+	events.PublishPhase(jobID, domain.QuoteJobPhaseComplete, 1, "")
+
+	got, closed := drainQuoteJobEvents(ch)
+	if !closed {
+		t.Error("expected channel to close after the final phase")
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(got))
+	}
+	if !got[2].IsToken() || got[2].Text != "Hello" {
+		t.Errorf("expected token event, got %+v", got[2])
+	}
+	if got[3].Phase != domain.QuoteJobPhaseComplete {
+		t.Errorf("expected complete, got %s", got[3].Phase)
+	}
+	if len(events.jobs) != 0 {
+		t.Errorf("expected finished job to be forgotten, %d remain", len(events.jobs))
+	}
+}
+
+func TestQuoteJobEvents_CatchUp(t *testing.T) {
+	events := NewQuoteJobEvents()
+	jobID := uuid.New()
+
+	events.PublishPhase(jobID, domain.QuoteJobPhasePricing, 2, "")
+	events.PublishText(jobID, "Project ")
+	events.PublishText(jobID, "Overview")
+
+	catchUp, _, unsubscribe := events.Subscribe(jobID)
+	defer unsubscribe()
+
+	if len(catchUp) != 2 {
+		t.Fatalf("expected phase and text, got %v", catchUp)
+	}
+	if catchUp[0].Phase != domain.QuoteJobPhasePricing || catchUp[0].Attempt != 2 {
+		t.Errorf("unexpected phase event %+v", catchUp[0])
+	}
+	if catchUp[1].Text != "Project Overview" {
+		t.Errorf("expected text so far, got %q", catchUp[1].Text)
+	}
+}
+
+func TestQuoteJobEvents_RetryDiscardsText(t *testing.T) {
+	events := NewQuoteJobEvents()
+	jobID := uuid.New()
+
+	_, _, unsubscribe := events.Subscribe(jobID)
+	defer unsubscribe()
+
+	events.PublishPhase(jobID, domain.QuoteJobPhasePricing, 1, "")
+	events.PublishText(jobID, "partial")
+	events.PublishPhase(jobID, domain.QuoteJobPhaseQueued, 1, "overloaded")
+
+	catchUp, _, unsubscribeLate := events.Subscribe(jobID)
+	defer unsubscribeLate()
+	if len(catchUp) != 1 || catchUp[0].Phase != domain.QuoteJobPhaseQueued {
+		t.Errorf("expected only the queued phase, got %v", catchUp)
+	}
+}
+
+func TestQuoteJobEvents_DropsSlowSubscriber(t *testing.T) {
+	events := NewQuoteJobEvents()
+	jobID := uuid.New()
+
+	_, ch, unsubscribe := events.Subscribe(jobID)
+	defer unsubscribe()
+
+	events.PublishPhase(jobID, domain.QuoteJobPhasePricing, 1, "")
+	for i := 0; i < quoteJobSubscriberBuffer+1; i++ {
+		events.PublishText(jobID, "x")
+	}
+
+	got, closed := drainQuoteJobEvents(ch)
+	if !closed {
+		t.Error("expected a full subscriber to be dropped")
+	}
+	if len(got) != quoteJobSubscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", quoteJobSubscriberBuffer, len(got))
+	}
+}
+
+func TestQuoteJobEvents_UnsubscribeForgetsIdleJob(t *testing.T) {
+	events := NewQuoteJobEvents()
+
+	_, _, unsubscribe := events.Subscribe(uuid.New())
+	unsubscribe()
+	unsubscribe() // safe to call twice
+
+	if len(events.jobs) != 0 {
+		t.Errorf("expected idle job to be forgotten, %d remain", len(events.jobs))
+	}
+}
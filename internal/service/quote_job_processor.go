@@ -4,57 +4,103 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/metrics"
 	"github.com/jkindrix/quickquote/internal/ratelimit"
+	"github.com/jkindrix/quickquote/internal/tracing"
 )
 
 // QuoteJobProcessor handles async quote generation with retry support.
+// Several processors, one per server replica, can share the job queue: each
+// leases the jobs it claims and renews the lease while it works on them, and
+// jobs whose lease expires are recovered by whichever processor notices first.
 type QuoteJobProcessor struct {
-	jobRepo   domain.QuoteJobRepository
-	callRepo  domain.CallRepository
-	quoteGen  QuoteGenerator
-	limiter   *ratelimit.QuoteLimiter
-	logger    *zap.Logger
+	jobRepo    domain.QuoteJobRepository
+	callRepo   domain.CallRepository
+	quoteGen   QuoteGenerator
+	pricing    *PricingService
+	currencies CurrencySource
+	limiter    *ratelimit.QuoteLimiter
+	notifier   Notifier
+	publisher  EventPublisher
+	events     *QuoteJobEvents
+	metrics    *metrics.Metrics
+	logger     *zap.Logger
 
 	// Configuration
-	pollInterval    time.Duration
-	batchSize       int
-	stuckJobTimeout time.Duration
-	workerCount     int
+	workerID      string
+	pollInterval  time.Duration
+	batchSize     int
+	leaseDuration time.Duration
+	workerCount   int
+	maxAttempts   int
 
 	// Lifecycle
 	stopCh   chan struct{}
 	jobCh    chan *domain.QuoteJob
 	wg       sync.WaitGroup
 	workerWg sync.WaitGroup
+	inFlight atomic.Int32 // Jobs dispatched to workers and not yet finished
+	draining atomic.Bool  // Set by Drain; no new jobs are claimed
 	mu       sync.RWMutex
 	running  bool
 }
 
+// errLeaseLost is the cancellation cause of a job whose lease was taken over
+// by another worker.
+var errLeaseLost = errors.New("quote job lease lost")
+
+// StreamingQuoteGenerator is a QuoteGenerator that can report the quote text
+// as it is written.
+type StreamingQuoteGenerator interface {
+	QuoteGenerator
+	GenerateQuoteStream(ctx context.Context, transcript string, extractedData *domain.ExtractedData, onText func(string)) (string, error)
+}
+
 // QuoteJobProcessorConfig holds configuration for the processor.
 type QuoteJobProcessorConfig struct {
-	PollInterval    time.Duration
-	BatchSize       int
-	StuckJobTimeout time.Duration
-	WorkerCount     int
+	WorkerID      string // Identifies this processor in job leases; unique per replica
+	PollInterval  time.Duration
+	BatchSize     int
+	LeaseDuration time.Duration // How long a claimed job stays leased without a heartbeat
+	WorkerCount   int
+	MaxAttempts   int // Attempts before a job is moved to the dead-letter queue
 }
 
 // DefaultQuoteJobProcessorConfig returns sensible defaults.
 func DefaultQuoteJobProcessorConfig() *QuoteJobProcessorConfig {
 	return &QuoteJobProcessorConfig{
-		PollInterval:    5 * time.Second,
-		BatchSize:       10,
-		StuckJobTimeout: 5 * time.Minute,
-		WorkerCount:     3,
+		WorkerID:      DefaultQuoteJobWorkerID(),
+		PollInterval:  5 * time.Second,
+		BatchSize:     10,
+		LeaseDuration: 2 * time.Minute,
+		WorkerCount:   3,
+		MaxAttempts:   3,
 	}
 }
 
+// DefaultQuoteJobWorkerID returns a worker ID made of the host name and
+// process ID, with a random suffix so a restarted container that reuses both
+// does not inherit its predecessor's leases.
+func DefaultQuoteJobWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "quickquote"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.New().String()[:8])
+}
+
 // NewQuoteJobProcessor creates a new job processor.
 func NewQuoteJobProcessor(
 	jobRepo domain.QuoteJobRepository,
@@ -72,22 +118,71 @@ func NewQuoteJobProcessor(
 	if workerCount < 1 {
 		workerCount = 1
 	}
+	workerID := config.WorkerID
+	if workerID == "" {
+		workerID = DefaultQuoteJobWorkerID()
+	}
+	leaseDuration := config.LeaseDuration
+	if leaseDuration <= 0 {
+		leaseDuration = 2 * time.Minute
+	}
+	maxAttempts := config.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 3
+	}
 
 	return &QuoteJobProcessor{
-		jobRepo:         jobRepo,
-		callRepo:        callRepo,
-		quoteGen:        quoteGen,
-		limiter:         limiter,
-		logger:          logger,
-		pollInterval:    config.PollInterval,
-		batchSize:       config.BatchSize,
-		stuckJobTimeout: config.StuckJobTimeout,
-		workerCount:     workerCount,
-		stopCh:          make(chan struct{}),
-		jobCh:           make(chan *domain.QuoteJob, config.BatchSize),
+		jobRepo:       jobRepo,
+		callRepo:      callRepo,
+		quoteGen:      quoteGen,
+		limiter:       limiter,
+		logger:        logger,
+		workerID:      workerID,
+		pollInterval:  config.PollInterval,
+		batchSize:     config.BatchSize,
+		leaseDuration: leaseDuration,
+		workerCount:   workerCount,
+		maxAttempts:   maxAttempts,
+		stopCh:        make(chan struct{}),
+		jobCh:         make(chan *domain.QuoteJob, workerCount),
 	}
 }
 
+// SetNotifier sets the notifier used to announce completed quotes and usage
+// alerts, and failed jobs when it implements JobFailureNotifier.
+func (p *QuoteJobProcessor) SetNotifier(n Notifier) {
+	p.notifier = n
+}
+
+// SetEventPublisher sets the publisher that announces generated quotes to
+// the events feed and outgoing webhook subscribers.
+func (p *QuoteJobProcessor) SetEventPublisher(publisher EventPublisher) {
+	p.publisher = publisher
+}
+
+// SetEvents sets where job progress is published. When the quote generator
+// supports streaming, the quote text is published as it is written.
+func (p *QuoteJobProcessor) SetEvents(events *QuoteJobEvents) {
+	p.events = events
+}
+
+// SetMetrics sets the collector for job outcomes and per-job AI usage.
+func (p *QuoteJobProcessor) SetMetrics(m *metrics.Metrics) {
+	p.metrics = m
+}
+
+// SetPricing sets the service that prices quotes. When set, the generated
+// quote is followed by a pricing section computed from the pricing rules.
+func (p *QuoteJobProcessor) SetPricing(pricing *PricingService) {
+	p.pricing = pricing
+}
+
+// SetCurrencies sets the source of the currency quotes are generated in.
+// Without one, quotes are priced in USD.
+func (p *QuoteJobProcessor) SetCurrencies(currencies CurrencySource) {
+	p.currencies = currencies
+}
+
 // Start begins the job processing loop.
 func (p *QuoteJobProcessor) Start(ctx context.Context) error {
 	p.mu.Lock()
@@ -99,14 +194,16 @@ func (p *QuoteJobProcessor) Start(ctx context.Context) error {
 	p.mu.Unlock()
 
 	p.logger.Info("starting quote job processor",
+		zap.String("worker", p.workerID),
 		zap.Duration("poll_interval", p.pollInterval),
+		zap.Duration("lease_duration", p.leaseDuration),
 		zap.Int("batch_size", p.batchSize),
 		zap.Int("worker_count", p.workerCount),
 	)
 
-	// Recover any stuck jobs from previous runs
-	if err := p.recoverStuckJobs(ctx); err != nil {
-		p.logger.Error("failed to recover stuck jobs", zap.Error(err))
+	// Recover jobs orphaned by workers that stopped
+	if err := p.recoverExpiredJobs(ctx); err != nil {
+		p.logger.Error("failed to recover orphaned jobs", zap.Error(err))
 	}
 
 	// Start worker pool
@@ -169,6 +266,14 @@ func (p *QuoteJobProcessor) Stop(ctx context.Context) error {
 	}
 }
 
+// Drain stops the processor claiming new jobs ahead of shutdown, leaving
+// them to other replicas. Jobs already claimed run to completion.
+func (p *QuoteJobProcessor) Drain() {
+	if !p.draining.Swap(true) {
+		p.logger.Info("quote job processor draining; no new jobs will be claimed")
+	}
+}
+
 // EnqueueJob creates a new quote generation job for a call.
 func (p *QuoteJobProcessor) EnqueueJob(ctx context.Context, callID uuid.UUID) (*domain.QuoteJob, error) {
 	// Check if job already exists for this call
@@ -182,6 +287,7 @@ func (p *QuoteJobProcessor) EnqueueJob(ctx context.Context, callID uuid.UUID) (*
 	}
 
 	job := domain.NewQuoteJob(callID)
+	job.MaxAttempts = p.maxAttempts
 	if err := p.jobRepo.Create(ctx, job); err != nil {
 		return nil, fmt.Errorf("failed to create job: %w", err)
 	}
@@ -190,6 +296,7 @@ func (p *QuoteJobProcessor) EnqueueJob(ctx context.Context, callID uuid.UUID) (*
 		zap.String("job_id", job.ID.String()),
 		zap.String("call_id", callID.String()),
 	)
+	p.publishPhase(job, domain.QuoteJobPhaseQueued, "")
 
 	return job, nil
 }
@@ -199,6 +306,53 @@ func (p *QuoteJobProcessor) GetJobStatus(ctx context.Context, jobID uuid.UUID) (
 	return p.jobRepo.GetByID(ctx, jobID)
 }
 
+// ListJobs lists jobs with the given status, most recently updated first.
+// An empty status lists jobs of every status.
+func (p *QuoteJobProcessor) ListJobs(ctx context.Context, status domain.QuoteJobStatus, limit, offset int) ([]*domain.QuoteJob, error) {
+	if status != "" && !status.IsValid() {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("unknown quote job status %q", status))
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	jobs, err := p.jobRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quote jobs: %w", err)
+	}
+	if jobs == nil {
+		jobs = []*domain.QuoteJob{}
+	}
+	return jobs, nil
+}
+
+// RetryJob takes a dead job out of the dead-letter queue and schedules it
+// with a fresh set of attempts, for example after fixing the settings that
+// made it fail.
+func (p *QuoteJobProcessor) RetryJob(ctx context.Context, jobID uuid.UUID) (*domain.QuoteJob, error) {
+	job, err := p.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.QuoteJobStatusDead {
+		return nil, apperrors.New(apperrors.CodeConflict, "only dead jobs can be retried")
+	}
+
+	job.Retry()
+	if err := p.jobRepo.Update(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to retry quote job: %w", err)
+	}
+
+	p.logger.Info("dead quote job queued for retry",
+		zap.String("job_id", job.ID.String()),
+		zap.String("call_id", job.CallID.String()),
+	)
+	p.publishPhase(job, domain.QuoteJobPhaseQueued, "")
+	return job, nil
+}
+
 // GetJobByCallID retrieves the job for a specific call.
 func (p *QuoteJobProcessor) GetJobByCallID(ctx context.Context, callID uuid.UUID) (*domain.QuoteJob, error) {
 	return p.jobRepo.GetByCallID(ctx, callID)
@@ -235,14 +389,32 @@ func (p *QuoteJobProcessor) runLoop() {
 	}
 }
 
-// processBatch fetches pending jobs and dispatches them to workers.
+// processBatch recovers orphaned jobs, then claims as many pending jobs as
+// there are idle workers and dispatches them. Claiming no more than can start
+// straight away keeps leased jobs from waiting here while other replicas idle.
 func (p *QuoteJobProcessor) processBatch() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	jobs, err := p.jobRepo.GetPendingJobs(ctx, p.batchSize)
+	if err := p.recoverExpiredJobs(ctx); err != nil {
+		p.logger.Error("failed to recover orphaned jobs", zap.Error(err))
+	}
+
+	if p.draining.Load() {
+		return
+	}
+
+	limit := p.workerCount - int(p.inFlight.Load())
+	if limit > p.batchSize {
+		limit = p.batchSize
+	}
+	if limit <= 0 {
+		return
+	}
+
+	jobs, err := p.jobRepo.ClaimPendingJobs(ctx, p.workerID, p.leaseDuration, limit)
 	if err != nil {
-		p.logger.Error("failed to get pending jobs", zap.Error(err))
+		p.logger.Error("failed to claim pending jobs", zap.Error(err))
 		return
 	}
 
@@ -252,9 +424,20 @@ func (p *QuoteJobProcessor) processBatch() {
 
 	p.logger.Debug("dispatching job batch to workers", zap.Int("count", len(jobs)))
 
-	for _, job := range jobs {
+	for i, job := range jobs {
+		p.inFlight.Add(1)
 		select {
 		case <-p.stopCh:
+			p.inFlight.Add(-1)
+			// Hand back the jobs no worker will start
+			for _, unstarted := range jobs[i:] {
+				if err := p.jobRepo.ReleaseLease(ctx, unstarted.ID, p.workerID); err != nil {
+					p.logger.Warn("failed to release job lease",
+						zap.String("job_id", unstarted.ID.String()),
+						zap.Error(err),
+					)
+				}
+			}
 			return
 		case p.jobCh <- job:
 			// Job dispatched to worker
@@ -270,14 +453,71 @@ func (p *QuoteJobProcessor) worker(id int) {
 	logger.Debug("worker started")
 
 	for job := range p.jobCh {
-		p.processJob(context.Background(), job)
+		p.processLeasedJob(job)
+		p.inFlight.Add(-1)
 	}
 
 	logger.Debug("worker stopped")
 }
 
+// processLeasedJob processes a claimed job, renewing its lease until done.
+// If another worker takes the job over, processing is cancelled.
+func (p *QuoteJobProcessor) processLeasedJob(job *domain.QuoteJob) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		p.heartbeat(ctx, job.ID, cancel)
+	}()
+
+	p.processJob(ctx, job)
+	cancel(nil)
+	<-heartbeatDone
+}
+
+// heartbeat renews a job's lease every third of the lease duration until ctx
+// is done, cancelling the job if the lease was lost.
+func (p *QuoteJobProcessor) heartbeat(ctx context.Context, jobID uuid.UUID, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(p.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := p.jobRepo.ExtendLease(ctx, jobID, p.workerID, p.leaseDuration)
+			if err == nil || ctx.Err() != nil {
+				continue
+			}
+			if apperrors.GetCode(err) == apperrors.CodeConflict {
+				p.logger.Warn("quote job lease lost, abandoning job",
+					zap.String("job_id", jobID.String()),
+					zap.String("worker", p.workerID),
+				)
+				cancel(errLeaseLost)
+				return
+			}
+			// The lease is still ours until it expires; try again next beat
+			p.logger.Error("failed to renew quote job lease",
+				zap.String("job_id", jobID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
 // processJob processes a single job.
 func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob) {
+	ctx, span := tracing.Start(ctx, "QuoteJobProcessor.processJob", trace.WithAttributes(
+		attribute.String("quote_job.id", job.ID.String()),
+		attribute.String("call.id", job.CallID.String()),
+		attribute.Int("quote_job.attempt", job.Attempts+1),
+	))
+	defer span.End()
+
 	logger := p.logger.With(
 		zap.String("job_id", job.ID.String()),
 		zap.String("call_id", job.CallID.String()),
@@ -289,12 +529,25 @@ func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob
 	// Acquire rate limit slot if limiter is configured
 	if p.limiter != nil {
 		if err := p.limiter.Acquire(ctx); err != nil {
-			// Rate limited - don't mark as failed, just skip for now
-			// The job will be picked up in the next batch
+			// Rate limited - don't mark as failed, just hand the job back
+			// so it is picked up in a later batch, here or by another replica
+			if err := p.jobRepo.ReleaseLease(ctx, job.ID, p.workerID); err != nil {
+				logger.Warn("failed to release job lease", zap.Error(err))
+			}
+			stats := p.limiter.Stats()
 			logger.Warn("rate limited, deferring job",
 				zap.Error(err),
-				zap.String("limiter_stats", fmt.Sprintf("%+v", p.limiter.Stats())),
+				zap.String("limiter_stats", fmt.Sprintf("%+v", stats)),
 			)
+			if p.notifier != nil && errors.Is(err, ratelimit.ErrDayLimitExceeded) {
+				p.notifier.UsageAlert(ctx, domain.UsageAlert{
+					Resource: "quote_generation",
+					Reason:   "day limit",
+					Used:     stats.DayMax - stats.DayRemaining,
+					Limit:    stats.DayMax,
+					ResetIn:  stats.DayResetIn,
+				})
+			}
 			return
 		}
 		// Ensure we release the slot when done
@@ -304,9 +557,14 @@ func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob
 	// Mark as processing
 	job.MarkProcessing()
 	if err := p.jobRepo.Update(ctx, job); err != nil {
+		if apperrors.GetCode(err) == apperrors.CodeConflict {
+			logger.Warn("job was claimed by another worker, skipping")
+			return
+		}
 		logger.Error("failed to mark job as processing", zap.Error(err))
 		return
 	}
+	p.publishPhase(job, domain.QuoteJobPhaseExtracting, "")
 
 	// Get the call
 	call, err := p.callRepo.GetByID(ctx, job.CallID)
@@ -323,13 +581,37 @@ func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob
 		return
 	}
 
+	genCtx, usage := metrics.WithTokenUsage(ctx)
+
+	// Extract pricing inputs and price the call
+	recordCurrency(ctx, p.currencies, call)
+	var estimate *domain.PriceEstimate
+	if p.pricing != nil {
+		estimate, err = p.pricing.Estimate(genCtx, call)
+		if err != nil {
+			if p.metrics != nil {
+				p.metrics.RecordQuoteJobUsage(usage.Snapshot())
+			}
+			logger.Error("pricing failed", zap.Error(err))
+			p.failJob(ctx, job, fmt.Errorf("failed to price quote: %w", err))
+			return
+		}
+	}
+
 	// Generate quote
-	quote, err := p.quoteGen.GenerateQuote(ctx, *call.Transcript, call.ExtractedData)
+	p.publishPhase(job, domain.QuoteJobPhasePricing, "")
+	quote, err := p.generateQuote(genCtx, job, call)
+	if p.metrics != nil {
+		p.metrics.RecordQuoteJobUsage(usage.Snapshot())
+	}
 	if err != nil {
 		logger.Error("quote generation failed", zap.Error(err))
 		p.failJob(ctx, job, err)
 		return
 	}
+	if p.pricing != nil {
+		quote = p.appendPricing(job, quote, estimate)
+	}
 
 	// Update call with quote
 	call.QuoteSummary = &quote
@@ -338,6 +620,9 @@ func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob
 		p.failJob(ctx, job, fmt.Errorf("failed to update call: %w", err))
 		return
 	}
+	if err := setDisposition(ctx, p.callRepo, call, domain.CallDispositionQuoted, domain.DispositionSourceAuto); err != nil {
+		logger.Warn("failed to set call disposition", zap.Error(err))
+	}
 
 	// Mark job as completed
 	job.MarkCompleted()
@@ -347,6 +632,17 @@ func (p *QuoteJobProcessor) processJob(ctx context.Context, job *domain.QuoteJob
 	}
 
 	logger.Info("job completed successfully")
+	if p.metrics != nil {
+		p.metrics.RecordQuoteJobProcessed("completed")
+	}
+	p.publishPhase(job, domain.QuoteJobPhaseComplete, "")
+
+	if p.notifier != nil {
+		p.notifier.QuoteReady(ctx, call)
+	}
+	if p.publisher != nil {
+		p.publisher.Publish(ctx, domain.WebhookEventQuoteCreated, NewWebhookQuoteCreatedData(call))
+	}
 }
 
 // failJob handles job failure with retry logic.
@@ -356,7 +652,22 @@ func (p *QuoteJobProcessor) failJob(ctx context.Context, job *domain.QuoteJob, e
 		zap.String("call_id", job.CallID.String()),
 	)
 
+	if errors.Is(context.Cause(ctx), errLeaseLost) {
+		// The job's new worker owns its outcome
+		logger.Warn("job abandoned after losing its lease", zap.Error(err))
+		return
+	}
+
 	job.MarkFailed(err)
+	tracing.RecordError(trace.SpanFromContext(ctx), err)
+
+	if p.metrics != nil {
+		outcome := "failed"
+		if job.Status == domain.QuoteJobStatusPending {
+			outcome = "retried"
+		}
+		p.metrics.RecordQuoteJobProcessed(outcome)
+	}
 
 	if job.Status == domain.QuoteJobStatusPending {
 		// Scheduled for retry
@@ -365,8 +676,8 @@ func (p *QuoteJobProcessor) failJob(ctx context.Context, job *domain.QuoteJob, e
 			zap.Time("next_retry", job.ScheduledAt),
 		)
 	} else {
-		// Permanently failed
-		logger.Warn("job permanently failed",
+		// Out of retries
+		logger.Warn("job moved to the dead-letter queue",
 			zap.Int("attempts", job.Attempts),
 			zap.String("error", *job.LastError),
 		)
@@ -375,34 +686,71 @@ func (p *QuoteJobProcessor) failJob(ctx context.Context, job *domain.QuoteJob, e
 	if updateErr := p.jobRepo.Update(ctx, job); updateErr != nil {
 		logger.Error("failed to update failed job", zap.Error(updateErr))
 	}
+	p.publishPhase(job, job.Phase(), *job.LastError)
+
+	if job.Status == domain.QuoteJobStatusDead {
+		if n, ok := p.notifier.(JobFailureNotifier); ok {
+			n.QuoteJobFailed(ctx, job)
+		}
+	}
+}
+
+// generateQuote writes the quote for a call, streaming its text to job
+// listeners when both the generator and an event stream are available.
+func (p *QuoteJobProcessor) generateQuote(ctx context.Context, job *domain.QuoteJob, call *domain.Call) (string, error) {
+	streamer, ok := p.quoteGen.(StreamingQuoteGenerator)
+	if !ok || p.events == nil {
+		return p.quoteGen.GenerateQuote(ctx, *call.Transcript, quoteDetails(call))
+	}
+	return streamer.GenerateQuoteStream(ctx, *call.Transcript, quoteDetails(call), func(text string) {
+		p.events.PublishText(job.ID, text)
+	})
+}
+
+// appendPricing adds the pricing section to a generated quote, publishing it
+// to job listeners who have already seen the quote text.
+func (p *QuoteJobProcessor) appendPricing(job *domain.QuoteJob, quote string, estimate *domain.PriceEstimate) string {
+	priced := appendPricingSection(quote, estimate)
+	if _, ok := p.quoteGen.(StreamingQuoteGenerator); ok && p.events != nil {
+		p.events.PublishText(job.ID, priced[len(quote):])
+	}
+	return priced
+}
+
+// publishPhase announces a job's phase if an event stream is configured.
+func (p *QuoteJobProcessor) publishPhase(job *domain.QuoteJob, phase domain.QuoteJobPhase, errMsg string) {
+	if p.events != nil {
+		p.events.PublishPhase(job.ID, phase, job.Attempts, errMsg)
+	}
 }
 
-// recoverStuckJobs handles jobs that were processing when the service stopped.
-func (p *QuoteJobProcessor) recoverStuckJobs(ctx context.Context) error {
-	stuckJobs, err := p.jobRepo.GetProcessingJobs(ctx, p.stuckJobTimeout)
+// recoverExpiredJobs claims processing jobs whose worker stopped renewing
+// the lease, such as one on a replica that crashed, and schedules them for retry.
+func (p *QuoteJobProcessor) recoverExpiredJobs(ctx context.Context) error {
+	orphaned, err := p.jobRepo.ClaimExpiredJobs(ctx, p.workerID, p.leaseDuration, p.batchSize)
 	if err != nil {
-		return fmt.Errorf("failed to get stuck jobs: %w", err)
+		return fmt.Errorf("failed to claim orphaned jobs: %w", err)
 	}
 
-	if len(stuckJobs) == 0 {
+	if len(orphaned) == 0 {
 		return nil
 	}
 
-	p.logger.Info("recovering stuck jobs", zap.Int("count", len(stuckJobs)))
+	p.logger.Info("recovering orphaned jobs", zap.Int("count", len(orphaned)))
 
-	for _, job := range stuckJobs {
+	for _, job := range orphaned {
 		// Mark as failed to trigger retry logic
-		job.MarkFailed(errors.New("job interrupted - process restarted"))
+		job.MarkFailed(errors.New("job interrupted - worker lease expired"))
 
 		if err := p.jobRepo.Update(ctx, job); err != nil {
-			p.logger.Error("failed to recover stuck job",
+			p.logger.Error("failed to recover orphaned job",
 				zap.String("job_id", job.ID.String()),
 				zap.Error(err),
 			)
 			continue
 		}
 
-		p.logger.Info("recovered stuck job",
+		p.logger.Info("recovered orphaned job",
 			zap.String("job_id", job.ID.String()),
 			zap.String("status", string(job.Status)),
 		)
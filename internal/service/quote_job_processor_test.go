@@ -3,14 +3,19 @@ package service
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
 
 	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/metrics"
 	"github.com/jkindrix/quickquote/internal/ratelimit"
 	"github.com/jkindrix/quickquote/internal/repository"
 )
@@ -66,40 +71,111 @@ func (m *MockQuoteJobRepository) Update(ctx context.Context, job *domain.QuoteJo
 	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if _, ok := m.jobs[job.ID]; !ok {
+	stored, ok := m.jobs[job.ID]
+	if !ok {
 		return repository.ErrNotFound
 	}
+	if !sameWorker(stored.LockedBy, job.LockedBy) {
+		return apperrors.New(apperrors.CodeConflict, "quote job is leased by another worker")
+	}
+	if job.Status != domain.QuoteJobStatusProcessing {
+		job.LeaseExpiresAt = nil
+	} else {
+		job.LeaseExpiresAt = stored.LeaseExpiresAt
+	}
 	m.jobs[job.ID] = job
 	return nil
 }
 
-func (m *MockQuoteJobRepository) GetPendingJobs(ctx context.Context, limit int) ([]*domain.QuoteJob, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-	var pending []*domain.QuoteJob
+func sameWorker(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// claim leases the jobs matching ready to workerID, returning copies as a
+// database would.
+func (m *MockQuoteJobRepository) claim(workerID string, lease time.Duration, limit int, ready func(*domain.QuoteJob, time.Time) bool) []*domain.QuoteJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	now := time.Now()
+	var claimed []*domain.QuoteJob
 	for _, job := range m.jobs {
-		if job.Status == domain.QuoteJobStatusPending && job.ScheduledAt.Before(now) {
-			pending = append(pending, job)
-			if len(pending) >= limit {
-				break
-			}
+		if len(claimed) >= limit || !ready(job, now) {
+			continue
 		}
+		expires := now.Add(lease)
+		id := workerID
+		job.LockedBy = &id
+		job.LeaseExpiresAt = &expires
+		cp := *job
+		claimed = append(claimed, &cp)
+	}
+	return claimed
+}
+
+func (m *MockQuoteJobRepository) ClaimPendingJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*domain.QuoteJob, error) {
+	return m.claim(workerID, lease, limit, func(job *domain.QuoteJob, now time.Time) bool {
+		return job.Status == domain.QuoteJobStatusPending && !job.ScheduledAt.After(now) &&
+			(job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(now))
+	}), nil
+}
+
+func (m *MockQuoteJobRepository) ClaimExpiredJobs(ctx context.Context, workerID string, lease time.Duration, limit int) ([]*domain.QuoteJob, error) {
+	return m.claim(workerID, lease, limit, func(job *domain.QuoteJob, now time.Time) bool {
+		return job.IsLeaseExpired(now)
+	}), nil
+}
+
+func (m *MockQuoteJobRepository) ExtendLease(ctx context.Context, id uuid.UUID, workerID string, lease time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok || job.LockedBy == nil || *job.LockedBy != workerID || job.LeaseExpiresAt == nil {
+		return apperrors.New(apperrors.CodeConflict, "quote job is leased by another worker")
 	}
-	return pending, nil
+	expires := time.Now().Add(lease)
+	job.LeaseExpiresAt = &expires
+	return nil
+}
+
+func (m *MockQuoteJobRepository) ReleaseLease(ctx context.Context, id uuid.UUID, workerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok && job.LockedBy != nil && *job.LockedBy == workerID && job.Status == domain.QuoteJobStatusPending {
+		job.LeaseExpiresAt = nil
+	}
+	return nil
+}
+
+// takeOver hands a job's lease to another worker, as if this one's expired.
+func (m *MockQuoteJobRepository) takeOver(id uuid.UUID, workerID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expires := time.Now().Add(time.Minute)
+	m.jobs[id].LockedBy = &workerID
+	m.jobs[id].LeaseExpiresAt = &expires
 }
 
-func (m *MockQuoteJobRepository) GetProcessingJobs(ctx context.Context, olderThan time.Duration) ([]*domain.QuoteJob, error) {
+func (m *MockQuoteJobRepository) ListByStatus(ctx context.Context, status domain.QuoteJobStatus, limit, offset int) ([]*domain.QuoteJob, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	cutoff := time.Now().Add(-olderThan)
-	var stuck []*domain.QuoteJob
+	var jobs []*domain.QuoteJob
 	for _, job := range m.jobs {
-		if job.Status == domain.QuoteJobStatusProcessing && job.StartedAt != nil && job.StartedAt.Before(cutoff) {
-			stuck = append(stuck, job)
+		if status == "" || job.Status == status {
+			jobs = append(jobs, job)
 		}
 	}
-	return stuck, nil
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].UpdatedAt.After(jobs[j].UpdatedAt) })
+	if offset >= len(jobs) {
+		return nil, nil
+	}
+	jobs = jobs[offset:]
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
 }
 
 func (m *MockQuoteJobRepository) CountByStatus(ctx context.Context) (map[domain.QuoteJobStatus]int, error) {
@@ -119,9 +195,10 @@ func newTestProcessor() (*QuoteJobProcessor, *MockQuoteJobRepository, *MockCallR
 	quoteGen := NewMockQuoteGenerator()
 
 	config := &QuoteJobProcessorConfig{
-		PollInterval:    100 * time.Millisecond,
-		BatchSize:       10,
-		StuckJobTimeout: 1 * time.Minute,
+		WorkerID:      "test-worker",
+		PollInterval:  100 * time.Millisecond,
+		BatchSize:     10,
+		LeaseDuration: 1 * time.Minute,
 	}
 
 	// Pass nil for limiter - tests don't need rate limiting
@@ -293,14 +370,125 @@ func TestQuoteJobProcessor_ProcessJob_FailsAfterMaxRetries(t *testing.T) {
 
 	// Verify job permanently failed
 	updatedJob, _ := jobRepo.GetByID(ctx, job.ID)
-	if updatedJob.Status != domain.QuoteJobStatusFailed {
-		t.Errorf("expected status %s after max retries, got %s", domain.QuoteJobStatusFailed, updatedJob.Status)
+	if updatedJob.Status != domain.QuoteJobStatusDead {
+		t.Errorf("expected status %s after max retries, got %s", domain.QuoteJobStatusDead, updatedJob.Status)
 	}
 	if updatedJob.Attempts != 3 {
 		t.Errorf("expected 3 attempts, got %d", updatedJob.Attempts)
 	}
 }
 
+// jobFailureRecorder records dead-lettered jobs.
+type jobFailureRecorder struct {
+	recordingNotifier
+	failedJobs []uuid.UUID
+}
+
+func (n *jobFailureRecorder) QuoteJobFailed(ctx context.Context, job *domain.QuoteJob) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failedJobs = append(n.failedJobs, job.ID)
+}
+
+func TestQuoteJobProcessor_ProcessJob_NotifiesDeadLetteredJobs(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	ctx := context.Background()
+	recorder := &jobFailureRecorder{}
+	processor.SetNotifier(MultiNotifier{&recordingNotifier{}, recorder})
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	call.Status = domain.CallStatusCompleted
+	callRepo.Create(ctx, call)
+	quoteGen.GenerateQuoteError = errors.New("persistent failure")
+
+	retried := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, retried)
+	processor.processJob(ctx, retried)
+	if len(recorder.failedJobs) != 0 {
+		t.Fatalf("expected no notification for a job that will be retried, got %d", len(recorder.failedJobs))
+	}
+
+	dead := domain.NewQuoteJob(call.ID)
+	dead.Attempts = 2
+	jobRepo.Create(ctx, dead)
+	processor.processJob(ctx, dead)
+	if len(recorder.failedJobs) != 1 || recorder.failedJobs[0] != dead.ID {
+		t.Errorf("expected the dead-lettered job to be notified, got %v", recorder.failedJobs)
+	}
+}
+
+func TestQuoteJobProcessor_RetryJob(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	ctx := context.Background()
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	call.Status = domain.CallStatusCompleted
+	callRepo.Create(ctx, call)
+
+	// Run the job out of retries
+	job := domain.NewQuoteJob(call.ID)
+	job.Attempts = 2
+	jobRepo.Create(ctx, job)
+	quoteGen.GenerateQuoteError = errors.New("invalid prompt")
+	processor.processJob(ctx, job)
+
+	dead, err := processor.ListJobs(ctx, domain.QuoteJobStatusDead, 0, 0)
+	if err != nil || len(dead) != 1 || dead[0].ID != job.ID {
+		t.Fatalf("ListJobs(dead) = %v, %v; want the job", dead, err)
+	}
+
+	// After fixing the settings, the job runs again with fresh attempts
+	quoteGen.GenerateQuoteError = nil
+	retried, err := processor.RetryJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("RetryJob() error = %v", err)
+	}
+	if retried.Status != domain.QuoteJobStatusPending || retried.Attempts != 0 || retried.CompletedAt != nil {
+		t.Errorf("retried job = %s with %d attempts, want pending with none", retried.Status, retried.Attempts)
+	}
+	processor.processJob(ctx, retried)
+
+	updated, _ := jobRepo.GetByID(ctx, job.ID)
+	if updated.Status != domain.QuoteJobStatusCompleted {
+		t.Errorf("expected status %s after retry, got %s", domain.QuoteJobStatusCompleted, updated.Status)
+	}
+
+	// Only dead jobs can be retried
+	if _, err := processor.RetryJob(ctx, job.ID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("RetryJob() on a completed job error = %v, want conflict", err)
+	}
+}
+
+func TestQuoteJobProcessor_ListJobs_RejectsUnknownStatus(t *testing.T) {
+	processor, _, _, _ := newTestProcessor()
+
+	if _, err := processor.ListJobs(context.Background(), "failed", 0, 0); !apperrors.IsUserError(err) {
+		t.Errorf("ListJobs() error = %v, want validation error", err)
+	}
+	jobs, err := processor.ListJobs(context.Background(), "", 0, 0)
+	if err != nil || jobs == nil {
+		t.Errorf("ListJobs() = %v, %v; want an empty list", jobs, err)
+	}
+}
+
+func TestQuoteJobProcessor_EnqueueJob_UsesMaxAttempts(t *testing.T) {
+	config := DefaultQuoteJobProcessorConfig()
+	config.MaxAttempts = 5
+	processor := NewQuoteJobProcessor(NewMockQuoteJobRepository(), NewMockCallRepository(), NewMockQuoteGenerator(), nil, zap.NewNop(), config)
+
+	job, err := processor.EnqueueJob(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("EnqueueJob() error = %v", err)
+	}
+	if job.MaxAttempts != 5 {
+		t.Errorf("MaxAttempts = %d, want 5", job.MaxAttempts)
+	}
+}
+
 func TestQuoteJobProcessor_ProcessJob_NoTranscript(t *testing.T) {
 	processor, jobRepo, callRepo, _ := newTestProcessor()
 	ctx := context.Background()
@@ -338,7 +526,7 @@ func TestQuoteJobProcessor_GetStats(t *testing.T) {
 	jobRepo.Create(ctx, job2)
 
 	job3 := domain.NewQuoteJob(uuid.New())
-	job3.Status = domain.QuoteJobStatusFailed
+	job3.Status = domain.QuoteJobStatusDead
 	jobRepo.Create(ctx, job3)
 
 	stats, err := processor.GetStats(ctx)
@@ -352,8 +540,8 @@ func TestQuoteJobProcessor_GetStats(t *testing.T) {
 	if stats[domain.QuoteJobStatusCompleted] != 1 {
 		t.Errorf("expected 1 completed, got %d", stats[domain.QuoteJobStatusCompleted])
 	}
-	if stats[domain.QuoteJobStatusFailed] != 1 {
-		t.Errorf("expected 1 failed, got %d", stats[domain.QuoteJobStatusFailed])
+	if stats[domain.QuoteJobStatusDead] != 1 {
+		t.Errorf("expected 1 dead, got %d", stats[domain.QuoteJobStatusDead])
 	}
 }
 
@@ -398,32 +586,165 @@ func TestQuoteJobProcessor_StartStop(t *testing.T) {
 	}
 }
 
-func TestQuoteJobProcessor_RecoverStuckJobs(t *testing.T) {
+func TestQuoteJobProcessor_RecoverExpiredJobs(t *testing.T) {
 	processor, jobRepo, _, _ := newTestProcessor()
 	ctx := context.Background()
 
-	// Create a stuck job (processing for too long)
-	stuckJob := domain.NewQuoteJob(uuid.New())
-	stuckJob.Status = domain.QuoteJobStatusProcessing
-	startedAt := time.Now().Add(-10 * time.Minute) // Started 10 minutes ago
-	stuckJob.StartedAt = &startedAt
-	stuckJob.Attempts = 1
-	jobRepo.Create(ctx, stuckJob)
-
-	// Recover stuck jobs
-	err := processor.recoverStuckJobs(ctx)
-	if err != nil {
-		t.Fatalf("recoverStuckJobs() error = %v", err)
-	}
-
-	// Verify job was rescheduled for retry
-	recovered, _ := jobRepo.GetByID(ctx, stuckJob.ID)
+	// A job whose worker crashed: processing, with a lease that ran out
+	crashed := "crashed-worker"
+	expired := time.Now().Add(-time.Minute)
+	orphanedJob := domain.NewQuoteJob(uuid.New())
+	orphanedJob.Status = domain.QuoteJobStatusProcessing
+	orphanedJob.Attempts = 1
+	orphanedJob.LockedBy = &crashed
+	orphanedJob.LeaseExpiresAt = &expired
+	jobRepo.Create(ctx, orphanedJob)
+
+	// A job another live worker is processing
+	live := "live-worker"
+	renewed := time.Now().Add(time.Minute)
+	liveJob := domain.NewQuoteJob(uuid.New())
+	liveJob.Status = domain.QuoteJobStatusProcessing
+	liveJob.Attempts = 1
+	liveJob.LockedBy = &live
+	liveJob.LeaseExpiresAt = &renewed
+	jobRepo.Create(ctx, liveJob)
+
+	if err := processor.recoverExpiredJobs(ctx); err != nil {
+		t.Fatalf("recoverExpiredJobs() error = %v", err)
+	}
+
+	// Verify the orphaned job was rescheduled for retry
+	recovered, _ := jobRepo.GetByID(ctx, orphanedJob.ID)
 	if recovered.Status != domain.QuoteJobStatusPending {
 		t.Errorf("expected status %s after recovery, got %s", domain.QuoteJobStatusPending, recovered.Status)
 	}
 	if recovered.LastError == nil {
 		t.Error("expected LastError to be set after recovery")
 	}
+	if recovered.LeaseExpiresAt != nil {
+		t.Error("expected the recovered job's lease to be released")
+	}
+
+	// Verify the live job was left alone
+	untouched, _ := jobRepo.GetByID(ctx, liveJob.ID)
+	if untouched.Status != domain.QuoteJobStatusProcessing || *untouched.LockedBy != live {
+		t.Errorf("live job = %s locked by %v, want it still processing on %s", untouched.Status, *untouched.LockedBy, live)
+	}
+}
+
+func TestQuoteJobProcessor_ProcessBatch_ClaimsOnlyForIdleWorkers(t *testing.T) {
+	processor, jobRepo, _, _ := newTestProcessor()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		jobRepo.Create(ctx, domain.NewQuoteJob(uuid.New()))
+	}
+
+	// No workers are running, so dispatched jobs stay in flight
+	processor.processBatch()
+	processor.processBatch()
+
+	if len(processor.jobCh) != processor.workerCount {
+		t.Errorf("dispatched %d jobs, want one per worker (%d)", len(processor.jobCh), processor.workerCount)
+	}
+	leased := 0
+	for _, job := range jobRepo.jobs {
+		if job.LeaseExpiresAt != nil {
+			leased++
+			if *job.LockedBy != "test-worker" {
+				t.Errorf("job locked by %q, want test-worker", *job.LockedBy)
+			}
+		}
+	}
+	if leased != processor.workerCount {
+		t.Errorf("leased %d jobs, want %d; the rest stay free for other replicas", leased, processor.workerCount)
+	}
+}
+
+func TestQuoteJobProcessor_Drain(t *testing.T) {
+	processor, jobRepo, _, _ := newTestProcessor()
+	ctx := context.Background()
+	jobRepo.Create(ctx, domain.NewQuoteJob(uuid.New()))
+
+	processor.Drain()
+	processor.processBatch()
+
+	if len(processor.jobCh) != 0 {
+		t.Errorf("dispatched %d jobs while draining, want none", len(processor.jobCh))
+	}
+	for _, job := range jobRepo.jobs {
+		if job.LockedBy != nil {
+			t.Errorf("job claimed by %q while draining", *job.LockedBy)
+		}
+	}
+}
+
+func TestQuoteJobProcessor_ProcessJob_SkipsJobClaimedByAnotherWorker(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	ctx := context.Background()
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	call.Status = domain.CallStatusCompleted
+	callRepo.Create(ctx, call)
+
+	jobRepo.Create(ctx, domain.NewQuoteJob(call.ID))
+	claimed, _ := jobRepo.ClaimPendingJobs(ctx, "test-worker", time.Minute, 1)
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d jobs, want 1", len(claimed))
+	}
+
+	// This worker stalled long enough for its lease to be taken over
+	jobRepo.takeOver(claimed[0].ID, "other-worker")
+	processor.processJob(ctx, claimed[0])
+
+	if quoteGen.GenerateQuoteCalls != 0 {
+		t.Errorf("generated %d quotes for a job this worker lost", quoteGen.GenerateQuoteCalls)
+	}
+	stored, _ := jobRepo.GetByID(ctx, claimed[0].ID)
+	if stored.Status != domain.QuoteJobStatusPending || *stored.LockedBy != "other-worker" {
+		t.Errorf("job = %s locked by %s, want it left pending for other-worker", stored.Status, *stored.LockedBy)
+	}
+}
+
+func TestQuoteJobProcessor_Heartbeat(t *testing.T) {
+	processor, jobRepo, _, _ := newTestProcessor()
+	processor.leaseDuration = 30 * time.Millisecond
+	ctx := context.Background()
+
+	jobRepo.Create(ctx, domain.NewQuoteJob(uuid.New()))
+	claimed, _ := jobRepo.ClaimPendingJobs(ctx, "test-worker", processor.leaseDuration, 1)
+	job := claimed[0]
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		processor.heartbeat(jobCtx, job.ID, cancel)
+	}()
+
+	// The lease is renewed past its original expiry
+	time.Sleep(2 * processor.leaseDuration)
+	jobRepo.mu.RLock()
+	expires := *jobRepo.jobs[job.ID].LeaseExpiresAt
+	jobRepo.mu.RUnlock()
+	if !expires.After(*job.LeaseExpiresAt) {
+		t.Errorf("lease expires at %v, want it renewed past %v", expires, *job.LeaseExpiresAt)
+	}
+
+	// Losing the lease cancels the job
+	jobRepo.takeOver(job.ID, "other-worker")
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat did not stop after losing the lease")
+	}
+	if !errors.Is(context.Cause(jobCtx), errLeaseLost) {
+		t.Errorf("job context cause = %v, want errLeaseLost", context.Cause(jobCtx))
+	}
 }
 
 func TestQuoteJob_ExponentialBackoff(t *testing.T) {
@@ -437,7 +758,7 @@ func TestQuoteJob_ExponentialBackoff(t *testing.T) {
 	}{
 		{"after first attempt can retry", 1, true},
 		{"after second attempt can retry", 2, true},
-		{"after third attempt fails permanently", 3, false},
+		{"after third attempt is dead", 3, false},
 	}
 
 	for _, tt := range tests {
@@ -455,8 +776,8 @@ func TestQuoteJob_ExponentialBackoff(t *testing.T) {
 					t.Error("expected scheduled_at to be in the future")
 				}
 			} else {
-				if job.Status != domain.QuoteJobStatusFailed {
-					t.Errorf("expected failed status, got %s", job.Status)
+				if job.Status != domain.QuoteJobStatusDead {
+					t.Errorf("expected dead status, got %s", job.Status)
 				}
 			}
 		})
@@ -536,10 +857,10 @@ func TestQuoteJob_IsTerminal(t *testing.T) {
 		t.Error("completed job should be terminal")
 	}
 
-	// Failed is terminal
-	job.Status = domain.QuoteJobStatusFailed
+	// Dead is terminal
+	job.Status = domain.QuoteJobStatusDead
 	if !job.IsTerminal() {
-		t.Error("failed job should be terminal")
+		t.Error("dead job should be terminal")
 	}
 }
 
@@ -559,9 +880,10 @@ func TestQuoteJobProcessor_WithRateLimiter(t *testing.T) {
 	limiter := ratelimit.NewQuoteLimiter(limiterConfig, logger)
 
 	config := &QuoteJobProcessorConfig{
-		PollInterval:    100 * time.Millisecond,
-		BatchSize:       10,
-		StuckJobTimeout: 1 * time.Minute,
+		WorkerID:      "test-worker",
+		PollInterval:  100 * time.Millisecond,
+		BatchSize:     10,
+		LeaseDuration: 1 * time.Minute,
 	}
 
 	processor := NewQuoteJobProcessor(jobRepo, callRepo, quoteGen, limiter, logger, config)
@@ -630,3 +952,144 @@ func TestQuoteJobProcessor_GetRateLimiterStats_NoLimiter(t *testing.T) {
 		t.Error("expected nil stats when no limiter configured")
 	}
 }
+
+// streamingQuoteGenerator is a MockQuoteGenerator that streams its quote in chunks.
+type streamingQuoteGenerator struct {
+	*MockQuoteGenerator
+	chunks []string
+}
+
+func (g *streamingQuoteGenerator) GenerateQuoteStream(ctx context.Context, transcript string, extractedData *domain.ExtractedData, onText func(string)) (string, error) {
+	g.GenerateQuoteCalls++
+	if g.GenerateQuoteError != nil {
+		return "", g.GenerateQuoteError
+	}
+	var quote string
+	for _, chunk := range g.chunks {
+		onText(chunk)
+		quote += chunk
+	}
+	return quote, nil
+}
+
+func TestQuoteJobProcessor_ProcessJob_PublishesProgress(t *testing.T) {
+	jobRepo := NewMockQuoteJobRepository()
+	callRepo := NewMockCallRepository()
+	quoteGen := &streamingQuoteGenerator{MockQuoteGenerator: NewMockQuoteGenerator(), chunks: []string{"Project ", "Overview"}}
+	processor := NewQuoteJobProcessor(jobRepo, callRepo, quoteGen, nil, zap.NewNop(), nil)
+	events := NewQuoteJobEvents()
+	processor.SetEvents(events)
+	ctx := context.Background()
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	callRepo.Create(ctx, call)
+
+	job := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, job)
+
+	_, ch, unsubscribe := events.Subscribe(job.ID)
+	defer unsubscribe()
+
+	processor.processJob(ctx, job)
+
+	got, closed := drainQuoteJobEvents(ch)
+	if !closed {
+		t.Error("expected stream to end after completion")
+	}
+	var phases []domain.QuoteJobPhase
+	var text string
+	for _, e := range got {
+		if e.IsToken() {
+			text += e.Text
+		} else {
+			phases = append(phases, e.Phase)
+		}
+	}
+	want := []domain.QuoteJobPhase{domain.QuoteJobPhaseExtracting, domain.QuoteJobPhasePricing, domain.QuoteJobPhaseComplete}
+	if len(phases) != len(want) {
+		t.Fatalf("expected phases %v, got %v", want, phases)
+	}
+	for i := range want {
+		if phases[i] != want[i] {
+			t.Errorf("expected phases %v, got %v", want, phases)
+			break
+		}
+	}
+	if text != "Project Overview" {
+		t.Errorf("expected streamed text, got %q", text)
+	}
+
+	updatedCall, _ := callRepo.GetByID(ctx, call.ID)
+	if updatedCall.QuoteSummary == nil || *updatedCall.QuoteSummary != "Project Overview" {
+		t.Errorf("expected streamed quote to be saved, got %v", updatedCall.QuoteSummary)
+	}
+}
+
+func TestQuoteJobProcessor_ProcessJob_PublishesRetry(t *testing.T) {
+	processor, jobRepo, callRepo, quoteGen := newTestProcessor()
+	events := NewQuoteJobEvents()
+	processor.SetEvents(events)
+	ctx := context.Background()
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	callRepo.Create(ctx, call)
+
+	job := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, job)
+	quoteGen.GenerateQuoteError = errors.New("AI service unavailable")
+
+	_, ch, unsubscribe := events.Subscribe(job.ID)
+	defer unsubscribe()
+
+	processor.processJob(ctx, job)
+
+	got, closed := drainQuoteJobEvents(ch)
+	if closed {
+		t.Error("expected stream to stay open for the retry")
+	}
+	last := got[len(got)-1]
+	if last.Phase != domain.QuoteJobPhaseQueued || last.Error == "" {
+		t.Errorf("expected queued phase with error, got %+v", last)
+	}
+}
+
+// usageQuoteGenerator reports token usage the way the Claude client does.
+type usageQuoteGenerator struct{}
+
+func (usageQuoteGenerator) GenerateQuote(ctx context.Context, transcript string, extractedData *domain.ExtractedData) (string, error) {
+	metrics.AddTokenUsage(ctx, 1200, 400, 0.0096)
+	return "quote", nil
+}
+
+func TestQuoteJobProcessor_ProcessJob_RecordsMetrics(t *testing.T) {
+	jobRepo := NewMockQuoteJobRepository()
+	callRepo := NewMockCallRepository()
+	processor := NewQuoteJobProcessor(jobRepo, callRepo, usageQuoteGenerator{}, nil, zap.NewNop(), nil)
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	processor.SetMetrics(m)
+	ctx := context.Background()
+
+	transcript := "Test transcript"
+	call := domain.NewCall("provider-123", "bland", "+1234567890", "+19876543210")
+	call.Transcript = &transcript
+	callRepo.Create(ctx, call)
+
+	job := domain.NewQuoteJob(call.ID)
+	jobRepo.Create(ctx, job)
+
+	processor.processJob(ctx, job)
+
+	if v := testutil.ToFloat64(m.QuoteJobsProcessed.WithLabelValues("completed")); v != 1 {
+		t.Errorf("completed jobs = %f, expected 1", v)
+	}
+	if n := testutil.CollectAndCount(m.QuoteJobTokens); n != 2 {
+		t.Errorf("expected input and output token series, got %d", n)
+	}
+	if n := testutil.CollectAndCount(m.QuoteJobCost); n != 1 {
+		t.Errorf("expected 1 cost series, got %d", n)
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// ErrQuoteLinkInvalid is returned for a quote link that does not exist or
+// has expired.
+var ErrQuoteLinkInvalid = errors.New("invalid or expired quote link")
+
+// QuoteResponseNotifier tells staff when a customer accepts or declines a
+// quote. The email notifier implements it.
+type QuoteResponseNotifier interface {
+	QuoteResponded(ctx context.Context, call *domain.Call, quote *domain.Quote, comment string)
+}
+
+// QuoteConfirmationSender texts customers to confirm their acceptance.
+// BlandService implements it.
+type QuoteConfirmationSender interface {
+	SendQuoteAcceptedSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error)
+}
+
+// QuotePortalConfig holds customer quote link settings.
+type QuotePortalConfig struct {
+	// LinkTTL is how long a link works after it is created.
+	LinkTTL time.Duration
+	// PublicURL is prepended to link paths; without it links are relative.
+	PublicURL string
+}
+
+// QuotePortalLink is a newly created customer link.
+type QuotePortalLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// QuotePortalView is what a customer sees when they open their link.
+type QuotePortalView struct {
+	*QuoteDetail
+	Call       *domain.Call
+	ExpiresAt  time.Time
+	CanRespond bool // The quote is waiting on the customer's decision
+}
+
+// QuotePortalService lets customers view, accept or decline a sent quote
+// through a link that needs no sign-in.
+type QuotePortalService struct {
+	links     domain.QuoteLinkRepository
+	quotes    *QuoteService
+	calls     domain.CallRepository
+	notifier  QuoteResponseNotifier
+	sms       QuoteConfirmationSender
+	doNotCall FollowUpDoNotCall
+	config    QuotePortalConfig
+	logger    *zap.Logger
+
+	clock clock.Clock
+}
+
+// NewQuotePortalService creates a new QuotePortalService.
+func NewQuotePortalService(links domain.QuoteLinkRepository, quotes *QuoteService, calls domain.CallRepository, config QuotePortalConfig, logger *zap.Logger, clk clock.Clock) *QuotePortalService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if config.LinkTTL <= 0 {
+		config.LinkTTL = domain.DefaultQuoteLinkTTL
+	}
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+	return &QuotePortalService{
+		links:  links,
+		quotes: quotes,
+		calls:  calls,
+		config: config,
+		logger: logger,
+		clock:  clk,
+	}
+}
+
+// SetNotifier enables telling staff about customer decisions.
+func (s *QuotePortalService) SetNotifier(notifier QuoteResponseNotifier) {
+	s.notifier = notifier
+}
+
+// SetConfirmationSender enables texting customers to confirm their acceptance.
+func (s *QuotePortalService) SetConfirmationSender(sender QuoteConfirmationSender) {
+	s.sms = sender
+}
+
+// SetDoNotCall skips confirmation texts to numbers on the do-not-call list,
+// including customers who texted STOP.
+func (s *QuotePortalService) SetDoNotCall(doNotCall FollowUpDoNotCall) {
+	s.doNotCall = doNotCall
+}
+
+// CreateLink creates the customer link for a sent quote, replacing any
+// earlier link to it.
+func (s *QuotePortalService) CreateLink(ctx context.Context, callID uuid.UUID, actor QuoteActor) (*QuotePortalLink, error) {
+	detail, err := s.quotes.GetQuote(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.Status != domain.QuoteStatusSent {
+		return nil, apperrors.New(apperrors.CodeConflict,
+			fmt.Sprintf("quote is %s; only sent quotes can be shared with the customer", detail.Status))
+	}
+
+	var createdBy *uuid.UUID
+	if actor.User != nil {
+		id := actor.User.ID
+		createdBy = &id
+	}
+	link, token, err := domain.NewQuoteLink(callID, createdBy, s.config.LinkTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quote link: %w", err)
+	}
+	if err := s.links.Replace(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to save quote link: %w", err)
+	}
+
+	s.logger.Info("quote link created",
+		zap.String("call_id", callID.String()),
+		zap.Time("expires_at", link.ExpiresAt),
+	)
+
+	return &QuotePortalLink{
+		URL:       s.config.PublicURL + "/q/" + token,
+		ExpiresAt: link.ExpiresAt,
+	}, nil
+}
+
+// Resolve returns the link for a token, or ErrQuoteLinkInvalid.
+func (s *QuotePortalService) Resolve(ctx context.Context, token string) (*domain.QuoteLink, error) {
+	if token == "" {
+		return nil, ErrQuoteLinkInvalid
+	}
+	link, err := s.links.GetByHash(ctx, domain.HashUserToken(token))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, ErrQuoteLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get quote link: %w", err)
+	}
+	if link.IsExpired(s.clock.Now()) {
+		return nil, ErrQuoteLinkInvalid
+	}
+	return link, nil
+}
+
+// View returns the quote behind a link and records the customer's first view.
+func (s *QuotePortalService) View(ctx context.Context, token string) (*QuotePortalView, error) {
+	link, err := s.Resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.view(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if link.ViewedAt == nil {
+		if err := s.links.MarkViewed(ctx, link.ID, s.clock.Now().UTC()); err != nil {
+			s.logger.Warn("failed to record quote link view", zap.String("call_id", link.CallID.String()), zap.Error(err))
+		}
+	}
+	return view, nil
+}
+
+// QuoteResponse is a customer's decision on their quote.
+type QuoteResponse struct {
+	Accept  bool
+	Comment string
+	// SignerName is the name the customer typed to sign; required to accept.
+	SignerName string
+	// SignatureImage is a PNG of the signature the customer drew, if any.
+	SignatureImage []byte
+	IP             string
+	RequestID      string
+}
+
+// Respond records the customer's decision on the quote behind a link, with
+// their comment as the note on the status change, and tells staff. An
+// acceptance must be signed and is confirmed to the customer by text when a
+// sender is set.
+func (s *QuotePortalService) Respond(ctx context.Context, token string, resp QuoteResponse) (*QuotePortalView, error) {
+	link, err := s.Resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := strings.TrimSpace(resp.Comment)
+	if len(comment) > domain.MaxQuoteCommentLen {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("comment must be at most %d characters", domain.MaxQuoteCommentLen))
+	}
+
+	// The customer is not a user, so the change carries no actor.
+	actor := QuoteActor{IP: resp.IP, RequestID: resp.RequestID}
+	var quote *domain.Quote
+	if resp.Accept {
+		signature, sigErr := domain.NewQuoteSignature(resp.SignerName, resp.SignatureImage, resp.IP, s.clock.Now())
+		if sigErr != nil {
+			return nil, apperrors.ValidationFailed(sigErr.Error())
+		}
+		quote, err = s.quotes.AcceptSigned(ctx, link.CallID, actor, comment, signature)
+	} else {
+		quote, err = s.quotes.MarkDeclined(ctx, link.CallID, actor, comment)
+	}
+	if err != nil {
+		if apperrors.GetCode(err) == apperrors.CodeConflict {
+			return nil, apperrors.New(apperrors.CodeConflict, "this quote is no longer waiting on a decision")
+		}
+		return nil, err
+	}
+
+	view, err := s.view(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("customer responded to quote",
+		zap.String("call_id", link.CallID.String()),
+		zap.String("status", string(quote.Status)),
+	)
+
+	if s.notifier != nil {
+		s.notifier.QuoteResponded(ctx, view.Call, quote, comment)
+	}
+	if resp.Accept {
+		s.sendConfirmation(ctx, view.Call)
+	}
+	return view, nil
+}
+
+// sendConfirmation texts the customer that their acceptance was received.
+// The quote is accepted either way, so failures are only logged.
+func (s *QuotePortalService) sendConfirmation(ctx context.Context, call *domain.Call) {
+	if s.sms == nil {
+		return
+	}
+	phone := call.CustomerNumber()
+	if phone == "" {
+		return
+	}
+	if s.doNotCall != nil {
+		if err := s.doNotCall.CheckDoNotCall(ctx, phone); err != nil {
+			s.logger.Info("skipping quote confirmation text", zap.String("call_id", call.ID.String()), zap.Error(err))
+			return
+		}
+	}
+
+	name := "there"
+	if call.CallerName != nil && strings.TrimSpace(*call.CallerName) != "" {
+		name = strings.TrimSpace(*call.CallerName)
+	}
+	if _, err := s.sms.SendQuoteAcceptedSMS(ctx, phone, name, quotepdf.QuoteNumber(call.ID)); err != nil {
+		s.logger.Warn("failed to send quote confirmation text", zap.String("call_id", call.ID.String()), zap.Error(err))
+	}
+}
+
+func (s *QuotePortalService) view(ctx context.Context, link *domain.QuoteLink) (*QuotePortalView, error) {
+	detail, err := s.quotes.GetQuote(ctx, link.CallID)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, ErrQuoteLinkInvalid
+		}
+		return nil, err
+	}
+	call, err := s.calls.GetByID(ctx, link.CallID)
+	if err != nil {
+		return nil, err
+	}
+	return &QuotePortalView{
+		QuoteDetail: detail,
+		Call:        call,
+		ExpiresAt:   link.ExpiresAt,
+		CanRespond:  detail.Status == domain.QuoteStatusSent,
+	}, nil
+}
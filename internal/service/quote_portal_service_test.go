@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// mockQuoteLinkRepository is an in-memory QuoteLinkRepository.
+type mockQuoteLinkRepository struct {
+	mu    sync.Mutex
+	links map[uuid.UUID]*domain.QuoteLink // by call ID
+}
+
+func newMockQuoteLinkRepository() *mockQuoteLinkRepository {
+	return &mockQuoteLinkRepository{links: make(map[uuid.UUID]*domain.QuoteLink)}
+}
+
+func (m *mockQuoteLinkRepository) Replace(ctx context.Context, link *domain.QuoteLink) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *link
+	m.links[link.CallID] = &cp
+	return nil
+}
+
+func (m *mockQuoteLinkRepository) GetByHash(ctx context.Context, hash string) (*domain.QuoteLink, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, link := range m.links {
+		if link.TokenHash == hash {
+			cp := *link
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("quote link")
+}
+
+func (m *mockQuoteLinkRepository) MarkViewed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, link := range m.links {
+		if link.ID == id && link.ViewedAt == nil {
+			link.ViewedAt = &at
+		}
+	}
+	return nil
+}
+
+type fakeQuoteResponseNotifier struct {
+	statuses []domain.QuoteStatus
+	comments []string
+}
+
+func (f *fakeQuoteResponseNotifier) QuoteResponded(ctx context.Context, call *domain.Call, quote *domain.Quote, comment string) {
+	f.statuses = append(f.statuses, quote.Status)
+	f.comments = append(f.comments, comment)
+}
+
+type fakeQuoteConfirmationSender struct {
+	phones []string
+}
+
+func (f *fakeQuoteConfirmationSender) SendQuoteAcceptedSMS(ctx context.Context, phoneNumber, customerName, quoteID string) (*bland.SendSMSResponse, error) {
+	f.phones = append(f.phones, phoneNumber)
+	return &bland.SendSMSResponse{}, nil
+}
+
+type fakePortalDoNotCall struct {
+	blocked map[string]bool
+}
+
+func (f *fakePortalDoNotCall) CheckDoNotCall(ctx context.Context, phoneNumber string) error {
+	if f.blocked[phoneNumber] {
+		return errors.New("number is on the do-not-call list")
+	}
+	return nil
+}
+
+// newQuotePortalTestService returns a portal service over a quote that has
+// been sent to the customer, with a link to it.
+func newQuotePortalTestService(t *testing.T) (*QuotePortalService, *QuoteService, *mockQuoteLinkRepository, uuid.UUID, string) {
+	t.Helper()
+	ctx := context.Background()
+	quotes, _, calls, callID := newQuoteTestService(t, "- Build: $1,200", QuoteApprovalConfig{})
+	staff := quoteActor("staff@example.com")
+	for _, step := range []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){
+		quotes.Submit, quotes.Approve, quotes.MarkSent,
+	} {
+		if _, err := step(ctx, callID, staff, ""); err != nil {
+			t.Fatalf("prepare quote: %v", err)
+		}
+	}
+
+	links := newMockQuoteLinkRepository()
+	svc := NewQuotePortalService(links, quotes, calls, QuotePortalConfig{PublicURL: "https://qq.example.com/"}, zap.NewNop(), nil)
+	link, err := svc.CreateLink(ctx, callID, staff)
+	if err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+	const prefix = "https://qq.example.com/q/"
+	if len(link.URL) <= len(prefix) || link.URL[:len(prefix)] != prefix {
+		t.Fatalf("unexpected link URL %q", link.URL)
+	}
+	return svc, quotes, links, callID, link.URL[len(prefix):]
+}
+
+func TestQuotePortalService_CreateLinkRequiresSentQuote(t *testing.T) {
+	quotes, _, calls, callID := newQuoteTestService(t, "- Build: $1,200", QuoteApprovalConfig{})
+	svc := NewQuotePortalService(newMockQuoteLinkRepository(), quotes, calls, QuotePortalConfig{}, zap.NewNop(), nil)
+
+	_, err := svc.CreateLink(context.Background(), callID, quoteActor("staff@example.com"))
+	if apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict for a draft quote, got %v", err)
+	}
+}
+
+func TestQuotePortalService_View(t *testing.T) {
+	svc, _, links, callID, token := newQuotePortalTestService(t)
+
+	view, err := svc.View(context.Background(), token)
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if !view.CanRespond || view.Total != 1200 {
+		t.Errorf("expected a $1200 quote awaiting a decision, got %+v", view.QuoteDetail)
+	}
+	if links.links[callID].ViewedAt == nil {
+		t.Error("expected the first view to be recorded")
+	}
+}
+
+func TestQuotePortalService_InvalidTokens(t *testing.T) {
+	svc, _, _, _, token := newQuotePortalTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.View(ctx, "not-a-token"); !errors.Is(err, ErrQuoteLinkInvalid) {
+		t.Errorf("expected ErrQuoteLinkInvalid for an unknown token, got %v", err)
+	}
+
+	svc.clock = clock.NewMock(time.Now().Add(domain.DefaultQuoteLinkTTL + time.Hour))
+	if _, err := svc.View(ctx, token); !errors.Is(err, ErrQuoteLinkInvalid) {
+		t.Errorf("expected ErrQuoteLinkInvalid for an expired link, got %v", err)
+	}
+	if _, err := svc.Respond(ctx, token, QuoteResponse{Accept: true, SignerName: "Jane Doe"}); !errors.Is(err, ErrQuoteLinkInvalid) {
+		t.Errorf("expected ErrQuoteLinkInvalid responding to an expired link, got %v", err)
+	}
+}
+
+func TestQuotePortalService_Accept(t *testing.T) {
+	svc, quotes, _, callID, token := newQuotePortalTestService(t)
+	ctx := context.Background()
+	notifier := &fakeQuoteResponseNotifier{}
+	sms := &fakeQuoteConfirmationSender{}
+	svc.SetNotifier(notifier)
+	svc.SetConfirmationSender(sms)
+
+	view, err := svc.Respond(ctx, token, QuoteResponse{Accept: true, Comment: "  Looks great  ", SignerName: " Jane Doe ", IP: "203.0.113.7", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if view.Status != domain.QuoteStatusAccepted || view.CanRespond {
+		t.Errorf("expected accepted quote no longer awaiting a decision, got %s", view.Status)
+	}
+	history := view.History
+	if last := history[len(history)-1]; last.Note != "Looks great" || last.ActorID != nil {
+		t.Errorf("expected customer comment as an actor-less note, got %+v", last)
+	}
+	if sig := view.Signature; sig == nil || sig.SignerName != "Jane Doe" || sig.IP != "203.0.113.7" || sig.Drawn() {
+		t.Errorf("expected a typed signature from the customer's IP, got %+v", sig)
+	}
+	if len(notifier.statuses) != 1 || notifier.statuses[0] != domain.QuoteStatusAccepted {
+		t.Errorf("expected staff to be told of the acceptance, got %v", notifier.statuses)
+	}
+	if len(sms.phones) != 1 || sms.phones[0] != "+15550002222" {
+		t.Errorf("expected a confirmation text to the customer, got %v", sms.phones)
+	}
+
+	// A second decision is refused.
+	if _, err := svc.Respond(ctx, token, QuoteResponse{}); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict responding twice, got %v", err)
+	}
+	if detail, _ := quotes.GetQuote(ctx, callID); detail.Status != domain.QuoteStatusAccepted {
+		t.Errorf("expected quote to stay accepted, got %s", detail.Status)
+	}
+}
+
+func TestQuotePortalService_AcceptRequiresSignature(t *testing.T) {
+	svc, quotes, _, callID, token := newQuotePortalTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.Respond(ctx, token, QuoteResponse{Accept: true, SignerName: "  "}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error without a signer name, got %v", err)
+	}
+	if _, err := svc.Respond(ctx, token, QuoteResponse{Accept: true, SignerName: "Jane Doe", SignatureImage: []byte("GIF89a")}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error for a non-PNG signature, got %v", err)
+	}
+	if detail, _ := quotes.GetQuote(ctx, callID); detail.Status != domain.QuoteStatusSent || detail.Signature != nil {
+		t.Errorf("expected quote to stay sent and unsigned, got %s", detail.Status)
+	}
+
+	drawn := append([]byte("\x89PNG\r\n\x1a\n"), 0, 0, 0, 0)
+	view, err := svc.Respond(ctx, token, QuoteResponse{Accept: true, SignerName: "Jane Doe", SignatureImage: drawn})
+	if err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if view.Signature == nil || !view.Signature.Drawn() {
+		t.Errorf("expected a drawn signature to be stored, got %+v", view.Signature)
+	}
+}
+
+func TestQuotePortalService_Decline(t *testing.T) {
+	svc, _, _, _, token := newQuotePortalTestService(t)
+	notifier := &fakeQuoteResponseNotifier{}
+	sms := &fakeQuoteConfirmationSender{}
+	svc.SetNotifier(notifier)
+	svc.SetConfirmationSender(sms)
+
+	view, err := svc.Respond(context.Background(), token, QuoteResponse{Comment: "Too expensive"})
+	if err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if view.Status != domain.QuoteStatusDeclined || view.Signature != nil {
+		t.Errorf("expected declined, got %s", view.Status)
+	}
+	if len(notifier.comments) != 1 || notifier.comments[0] != "Too expensive" {
+		t.Errorf("expected staff to get the comment, got %v", notifier.comments)
+	}
+	if len(sms.phones) != 0 {
+		t.Errorf("expected no confirmation text for a decline, got %v", sms.phones)
+	}
+}
+
+func TestQuotePortalService_ConfirmationSkipsDoNotCall(t *testing.T) {
+	svc, _, _, _, token := newQuotePortalTestService(t)
+	sms := &fakeQuoteConfirmationSender{}
+	svc.SetConfirmationSender(sms)
+	svc.SetDoNotCall(&fakePortalDoNotCall{blocked: map[string]bool{"+15550002222": true}})
+
+	if _, err := svc.Respond(context.Background(), token, QuoteResponse{Accept: true, SignerName: "Jane Doe"}); err != nil {
+		t.Fatalf("respond: %v", err)
+	}
+	if len(sms.phones) != 0 {
+		t.Errorf("expected no text to a do-not-call number, got %v", sms.phones)
+	}
+}
+
+func TestQuotePortalService_CommentTooLong(t *testing.T) {
+	svc, _, _, _, token := newQuotePortalTestService(t)
+
+	long := make([]byte, domain.MaxQuoteCommentLen+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	_, err := svc.Respond(context.Background(), token, QuoteResponse{Accept: true, Comment: string(long), SignerName: "Jane Doe"})
+	if apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/captcha"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// Limits on quote request fields, so the form can't be used to store or
+// send the quote generator arbitrarily large text.
+const (
+	quoteRequestMaxField       = 200
+	quoteRequestMaxDescription = 5000
+)
+
+// QuoteJobEnqueuer queues quote generation for a call.
+type QuoteJobEnqueuer interface {
+	EnqueueJob(ctx context.Context, callID uuid.UUID) (*domain.QuoteJob, error)
+}
+
+// CaptchaVerifier checks the captcha token submitted with a form.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// QuoteRequestServiceConfig holds configuration for the quote request service.
+type QuoteRequestServiceConfig struct {
+	Callbacks        bool       // Place an AI call to submitters who ask for one
+	CallbackPromptID *uuid.UUID // Prompt for callback calls; nil uses the default
+}
+
+// QuoteRequestService takes quote requests submitted through the website
+// form. Each request becomes a completed call with the form as its
+// transcript, so it is quoted, listed and followed up like a phone call.
+type QuoteRequestService struct {
+	calls     domain.CallRepository
+	jobs      QuoteJobEnqueuer
+	customers CustomerLinker
+	captcha   CaptchaVerifier
+	dialer    CampaignDialer
+	config    QuoteRequestServiceConfig
+	logger    *zap.Logger
+}
+
+// NewQuoteRequestService creates a new QuoteRequestService.
+func NewQuoteRequestService(calls domain.CallRepository, jobs QuoteJobEnqueuer, logger *zap.Logger, cfg *QuoteRequestServiceConfig) *QuoteRequestService {
+	if cfg == nil {
+		cfg = &QuoteRequestServiceConfig{}
+	}
+	return &QuoteRequestService{
+		calls:  calls,
+		jobs:   jobs,
+		config: *cfg,
+		logger: logger,
+	}
+}
+
+// SetCustomers links submissions to customers by phone number.
+func (s *QuoteRequestService) SetCustomers(customers CustomerLinker) {
+	s.customers = customers
+}
+
+// SetCaptcha requires submissions to pass a captcha. Without one they are
+// accepted unchecked.
+func (s *QuoteRequestService) SetCaptcha(verifier CaptchaVerifier) {
+	s.captcha = verifier
+}
+
+// SetDialer sets how callback calls are placed. Callbacks are not offered
+// without one.
+func (s *QuoteRequestService) SetDialer(dialer CampaignDialer) {
+	s.dialer = dialer
+}
+
+// CallbacksEnabled reports whether submitters can ask for a callback call.
+func (s *QuoteRequestService) CallbacksEnabled() bool {
+	return s.config.Callbacks && s.dialer != nil
+}
+
+// QuoteRequestResult is the outcome of a submission.
+type QuoteRequestResult struct {
+	RequestID uuid.UUID `json:"request_id"` // ID of the call created for the request
+	Callback  bool      `json:"callback"`   // A callback call was placed
+}
+
+// Submit checks and records a quote request and queues its quote. The
+// callback call, when asked for, is best effort: a failure to place it is
+// logged and the request still succeeds.
+func (s *QuoteRequestService) Submit(ctx context.Context, req *domain.QuoteRequest, captchaToken, remoteIP string) (*QuoteRequestResult, error) {
+	if s.captcha != nil {
+		if err := s.captcha.Verify(ctx, captchaToken, remoteIP); err != nil {
+			if errors.Is(err, captcha.ErrFailed) {
+				return nil, apperrors.New(apperrors.CodeForbidden, "captcha verification failed")
+			}
+			return nil, apperrors.ExternalServiceError("captcha", err)
+		}
+	}
+
+	if err := s.normalize(req); err != nil {
+		return nil, err
+	}
+
+	call := req.NewCall()
+	if s.customers != nil {
+		if err := s.customers.LinkCall(ctx, call); err != nil {
+			s.logger.Warn("failed to link quote request to customer", zap.String("call_id", call.ID.String()), zap.Error(err))
+		}
+	}
+	if err := s.calls.Create(ctx, call); err != nil {
+		return nil, err
+	}
+
+	logger := s.logger.With(zap.String("call_id", call.ID.String()))
+	logger.Info("quote request received", zap.String("project_type", req.ProjectType), zap.Bool("callback", req.Callback))
+
+	job, err := s.jobs.EnqueueJob(ctx, call.ID)
+	if err != nil {
+		// The request is stored; its quote can be generated from the call page
+		logger.Error("failed to enqueue quote job for quote request", zap.Error(err))
+	} else if job != nil {
+		jobID := job.ID
+		if err := s.calls.SetQuoteJobID(ctx, call.ID, &jobID); err != nil {
+			logger.Warn("failed to set quote job id", zap.Error(err))
+		}
+	}
+
+	result := &QuoteRequestResult{RequestID: call.ID}
+	if req.Callback && s.CallbacksEnabled() {
+		result.Callback = s.placeCallback(ctx, req, call)
+	}
+	return result, nil
+}
+
+// placeCallback has the AI agent call the submitter about their request.
+func (s *QuoteRequestService) placeCallback(ctx context.Context, req *domain.QuoteRequest, call *domain.Call) bool {
+	data := map[string]interface{}{
+		"name":                req.Name,
+		"project_type":        req.ProjectType,
+		"project_description": req.Description,
+	}
+	resp, err := s.dialer.InitiateCall(ctx, &InitiateCallRequest{
+		PhoneNumber:    req.Phone,
+		PromptID:       s.config.CallbackPromptID,
+		IdempotencyKey: "quote-request-" + call.ID.String(),
+		RequestData:    data,
+		Metadata: map[string]interface{}{
+			"quote_request_call_id": call.ID.String(),
+		},
+	})
+	if err != nil {
+		s.logger.Warn("failed to place quote request callback",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+		return false
+	}
+	s.logger.Info("quote request callback placed",
+		zap.String("call_id", call.ID.String()),
+		zap.String("callback_call_id", resp.CallID.String()),
+	)
+	return true
+}
+
+// normalize trims a request's fields and checks them.
+func (s *QuoteRequestService) normalize(req *domain.QuoteRequest) error {
+	for _, field := range []*string{&req.Name, &req.Email, &req.Phone, &req.Company, &req.ProjectType, &req.Timeline, &req.Budget} {
+		*field = strings.TrimSpace(*field)
+		if len(*field) > quoteRequestMaxField {
+			return apperrors.ValidationFailed("fields must be at most 200 characters")
+		}
+	}
+	req.Description = strings.TrimSpace(req.Description)
+
+	if req.Name == "" {
+		return apperrors.MissingField("name")
+	}
+	if req.Description == "" {
+		return apperrors.MissingField("description")
+	}
+	if len(req.Description) > quoteRequestMaxDescription {
+		return apperrors.ValidationFailed("description must be at most 5000 characters")
+	}
+	if req.Email == "" && req.Phone == "" {
+		return apperrors.ValidationFailed("an email address or phone number is required")
+	}
+	if err := validateCustomerEmail(req.Email); err != nil {
+		return err
+	}
+	if req.Phone != "" {
+		phone := normalizePhoneNumber(req.Phone)
+		if phone == "" {
+			return apperrors.ValidationFailed("phone must be a valid phone number")
+		}
+		req.Phone = phone
+	}
+	if req.Callback && req.Phone == "" {
+		return apperrors.ValidationFailed("a phone number is required for a callback")
+	}
+	return nil
+}
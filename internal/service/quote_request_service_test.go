@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/captcha"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// fakeQuoteJobEnqueuer records the calls quotes were queued for.
+type fakeQuoteJobEnqueuer struct {
+	callIDs []uuid.UUID
+	err     error
+}
+
+func (f *fakeQuoteJobEnqueuer) EnqueueJob(ctx context.Context, callID uuid.UUID) (*domain.QuoteJob, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.callIDs = append(f.callIDs, callID)
+	return domain.NewQuoteJob(callID), nil
+}
+
+// fakeCaptcha accepts the token "ok".
+type fakeCaptcha struct{ err error }
+
+func (f fakeCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if token != "ok" {
+		return captcha.ErrFailed
+	}
+	return nil
+}
+
+func newTestQuoteRequest() *domain.QuoteRequest {
+	return &domain.QuoteRequest{
+		Name:        " Jane Doe ",
+		Email:       "jane@example.com",
+		Phone:       "(555) 123-4567",
+		ProjectType: "Kitchen remodel",
+		Description: "New cabinets and countertops for a 12 by 14 kitchen.",
+	}
+}
+
+func TestQuoteRequestService_Submit(t *testing.T) {
+	calls := NewMockCallRepository()
+	jobs := &fakeQuoteJobEnqueuer{}
+	svc := NewQuoteRequestService(calls, jobs, zap.NewNop(), nil)
+
+	result, err := svc.Submit(context.Background(), newTestQuoteRequest(), "", "")
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if result.Callback {
+		t.Error("no callback should be placed without a dialer")
+	}
+
+	call, err := calls.GetByID(context.Background(), result.RequestID)
+	if err != nil {
+		t.Fatalf("call not stored: %v", err)
+	}
+	if call.Provider != domain.QuoteRequestProvider || call.Status != domain.CallStatusCompleted {
+		t.Errorf("call has provider %q and status %q", call.Provider, call.Status)
+	}
+	if call.FromNumber != "+15551234567" || call.CallerName == nil || *call.CallerName != "Jane Doe" {
+		t.Errorf("call has number %q and caller name %v", call.FromNumber, call.CallerName)
+	}
+	if call.ExtractedData == nil || call.ExtractedData.Email != "jane@example.com" || call.ExtractedData.ProjectType != "Kitchen remodel" {
+		t.Errorf("unexpected extracted data %+v", call.ExtractedData)
+	}
+	if call.Transcript == nil || !strings.Contains(*call.Transcript, "New cabinets and countertops") {
+		t.Errorf("transcript should hold the description, got %v", call.Transcript)
+	}
+	if len(jobs.callIDs) != 1 || jobs.callIDs[0] != call.ID || calls.SetQuoteJobIDCalls != 1 {
+		t.Errorf("expected a quote job for the call, got %v", jobs.callIDs)
+	}
+}
+
+func TestQuoteRequestService_SubmitValidates(t *testing.T) {
+	svc := NewQuoteRequestService(NewMockCallRepository(), &fakeQuoteJobEnqueuer{}, zap.NewNop(), nil)
+
+	tests := []struct {
+		name   string
+		modify func(*domain.QuoteRequest)
+	}{
+		{"no name", func(r *domain.QuoteRequest) { r.Name = " " }},
+		{"no description", func(r *domain.QuoteRequest) { r.Description = "" }},
+		{"no contact", func(r *domain.QuoteRequest) { r.Email, r.Phone = "", "" }},
+		{"bad email", func(r *domain.QuoteRequest) { r.Email = "jane" }},
+		{"bad phone", func(r *domain.QuoteRequest) { r.Phone = "call me" }},
+		{"callback without phone", func(r *domain.QuoteRequest) { r.Phone, r.Callback = "", true }},
+		{"long description", func(r *domain.QuoteRequest) { r.Description = strings.Repeat("x", 5001) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newTestQuoteRequest()
+			tt.modify(req)
+			if _, err := svc.Submit(context.Background(), req, "", ""); !apperrors.IsUserError(err) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQuoteRequestService_SubmitChecksCaptcha(t *testing.T) {
+	calls := NewMockCallRepository()
+	svc := NewQuoteRequestService(calls, &fakeQuoteJobEnqueuer{}, zap.NewNop(), nil)
+	svc.SetCaptcha(fakeCaptcha{})
+
+	_, err := svc.Submit(context.Background(), newTestQuoteRequest(), "bot", "203.0.113.9")
+	if apperrors.GetHTTPStatus(err) != http.StatusForbidden {
+		t.Errorf("expected forbidden for a failed captcha, got %v", err)
+	}
+	if calls.CreateCalls != 0 {
+		t.Error("no call should be stored for a failed captcha")
+	}
+
+	svc.SetCaptcha(fakeCaptcha{err: errors.New("captcha provider down")})
+	if _, err := svc.Submit(context.Background(), newTestQuoteRequest(), "ok", ""); err == nil || apperrors.IsUserError(err) {
+		t.Errorf("expected a service error when the provider is down, got %v", err)
+	}
+
+	svc.SetCaptcha(fakeCaptcha{})
+	if _, err := svc.Submit(context.Background(), newTestQuoteRequest(), "ok", ""); err != nil {
+		t.Errorf("Submit with a good token failed: %v", err)
+	}
+}
+
+func TestQuoteRequestService_SubmitPlacesCallback(t *testing.T) {
+	promptID := uuid.New()
+	dialer := &fakeCampaignDialer{}
+	svc := NewQuoteRequestService(NewMockCallRepository(), &fakeQuoteJobEnqueuer{}, zap.NewNop(), &QuoteRequestServiceConfig{
+		Callbacks:        true,
+		CallbackPromptID: &promptID,
+	})
+	svc.SetDialer(dialer)
+
+	req := newTestQuoteRequest()
+	req.Callback = true
+	result, err := svc.Submit(context.Background(), req, "", "")
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if !result.Callback || len(dialer.requests) != 1 {
+		t.Fatalf("expected a callback call, got %+v and %d requests", result, len(dialer.requests))
+	}
+	callReq := dialer.requests[0]
+	if callReq.PhoneNumber != "+15551234567" || callReq.PromptID != &promptID {
+		t.Errorf("callback dialed %q with prompt %v", callReq.PhoneNumber, callReq.PromptID)
+	}
+	if callReq.Metadata["quote_request_call_id"] != result.RequestID.String() {
+		t.Errorf("callback metadata = %v", callReq.Metadata)
+	}
+
+	// A failed callback doesn't fail the request
+	dialer.failNumbers = map[string]bool{"+15551234567": true}
+	if result, err = svc.Submit(context.Background(), req, "", ""); err != nil || result.Callback {
+		t.Errorf("expected the request to succeed without a callback, got %+v, %v", result, err)
+	}
+
+	// Submitters who don't ask aren't called
+	req.Callback = false
+	dialer.requests = nil
+	if _, err := svc.Submit(context.Background(), req, "", ""); err != nil || len(dialer.requests) != 0 {
+		t.Errorf("expected no callback, got %d requests, %v", len(dialer.requests), err)
+	}
+}
@@ -0,0 +1,621 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/quotepdf"
+)
+
+// QuoteApprovalConfig holds the quote review policy.
+type QuoteApprovalConfig struct {
+	// Threshold is the quote total above which approval must come from a
+	// designated approver other than the submitter. Zero disables the check.
+	Threshold float64
+
+	// Approvers lists the emails allowed to approve quotes above Threshold.
+	// When empty, any user except the submitter may approve.
+	Approvers []string
+}
+
+// QuoteActor identifies who is moving a quote through the workflow.
+type QuoteActor struct {
+	User      *domain.User
+	IP        string
+	RequestID string
+}
+
+// QuoteDetail is a quote with its priced totals and status history. Quotes
+// staff have not edited list the line items found in the generated text.
+type QuoteDetail struct {
+	*domain.Quote
+	QuoteNumber       string                    `json:"quote_number"`
+	Edited            bool                      `json:"edited"`
+	Subtotal          float64                   `json:"subtotal"`
+	Tax               float64                   `json:"tax"`
+	Total             float64                   `json:"total"`
+	ApprovalThreshold float64                   `json:"approval_threshold"`
+	History           []*domain.QuoteTransition `json:"history"`
+	Payment           *domain.QuotePayment      `json:"payment,omitempty"`
+}
+
+// QuoteService manages the quote review workflow.
+type QuoteService struct {
+	quotes      domain.QuoteRepository
+	calls       domain.CallRepository
+	auditLogger *audit.Logger
+	publisher   EventPublisher
+	followUps   QuoteFollowUps
+	messenger   QuoteMessenger
+	payments    QuotePaymentReader
+	accounting  QuoteAccounting
+	currencies  CurrencySource
+	configMu    sync.RWMutex
+	config      QuoteApprovalConfig
+	logger      *zap.Logger
+}
+
+// NewQuoteService creates a new QuoteService. auditLogger may be nil.
+func NewQuoteService(quotes domain.QuoteRepository, calls domain.CallRepository, auditLogger *audit.Logger, config QuoteApprovalConfig, logger *zap.Logger) *QuoteService {
+	return &QuoteService{
+		quotes:      quotes,
+		calls:       calls,
+		auditLogger: auditLogger,
+		config:      normalizeApprovalConfig(config),
+		logger:      logger,
+	}
+}
+
+// SetApprovalConfig replaces the review policy. Quotes already under review
+// keep whether they need approval; the approver check uses the new policy.
+func (s *QuoteService) SetApprovalConfig(config QuoteApprovalConfig) {
+	config = normalizeApprovalConfig(config)
+	s.configMu.Lock()
+	s.config = config
+	s.configMu.Unlock()
+}
+
+func (s *QuoteService) approvalConfig() QuoteApprovalConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config
+}
+
+func normalizeApprovalConfig(config QuoteApprovalConfig) QuoteApprovalConfig {
+	approvers := make([]string, len(config.Approvers))
+	for i, a := range config.Approvers {
+		approvers[i] = strings.ToLower(strings.TrimSpace(a))
+	}
+	config.Approvers = approvers
+	return config
+}
+
+// SetEventPublisher enables announcing quote status changes and approvals
+// to the events feed and outgoing webhook subscribers.
+func (s *QuoteService) SetEventPublisher(publisher EventPublisher) {
+	s.publisher = publisher
+}
+
+// QuoteFollowUps schedules follow-ups for sent quotes and stops them once
+// the customer decides.
+type QuoteFollowUps interface {
+	ScheduleQuote(ctx context.Context, callID uuid.UUID, sentAt time.Time) error
+	CancelQuote(ctx context.Context, callID uuid.UUID, reason string) (int, error)
+}
+
+// SetFollowUps enables following up with customers after their quote is sent.
+func (s *QuoteService) SetFollowUps(followUps QuoteFollowUps) {
+	s.followUps = followUps
+}
+
+// QuoteMessenger tells customers their quote is ready.
+// ConversationService implements it.
+type QuoteMessenger interface {
+	SendQuoteReady(ctx context.Context, callID uuid.UUID) error
+}
+
+// SetMessenger enables messaging customers when their quote is sent.
+func (s *QuoteService) SetMessenger(messenger QuoteMessenger) {
+	s.messenger = messenger
+}
+
+// QuotePaymentReader looks up the payment collected for a quote.
+// QuotePaymentRepository implements it.
+type QuotePaymentReader interface {
+	GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.QuotePayment, error)
+}
+
+// SetPayments enables showing each quote's payment status.
+func (s *QuoteService) SetPayments(payments QuotePaymentReader) {
+	s.payments = payments
+}
+
+// QuoteAccounting pushes accepted quotes to an accounting system.
+// AccountingService implements it.
+type QuoteAccounting interface {
+	QuoteAccepted(ctx context.Context, callID uuid.UUID)
+}
+
+// SetAccounting enables pushing quotes to accounting once accepted.
+func (s *QuoteService) SetAccounting(accounting QuoteAccounting) {
+	s.accounting = accounting
+}
+
+// SetCurrencies sets the source of the currency quotes generated before
+// currencies were recorded are taken to be in. Without one, they are USD.
+func (s *QuoteService) SetCurrencies(currencies CurrencySource) {
+	s.currencies = currencies
+}
+
+// GetQuote returns the quote for a call with its status history. Quotes that
+// have never been submitted are reported as drafts.
+func (s *QuoteService) GetQuote(ctx context.Context, callID uuid.UUID) (*QuoteDetail, error) {
+	quote, summary, err := s.loadWithSummary(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, quote, summary)
+}
+
+// UpdateQuote replaces a draft quote's line items, tax rate and notes and
+// recalculates its total. An empty currency keeps the quote's currency.
+// Quotes under review or later must be returned to draft first.
+func (s *QuoteService) UpdateQuote(ctx context.Context, callID uuid.UUID, actor QuoteActor, items []domain.QuoteLineItem, taxRate float64, notes, currency string) (*QuoteDetail, error) {
+	quote, summary, err := s.loadWithSummary(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.IsArchived() {
+		return nil, apperrors.New(apperrors.CodeConflict, "quote is archived; unarchive it first")
+	}
+
+	before := quote.TotalAmount
+	err = quote.Edit(items, taxRate, notes)
+	if err == nil && currency != "" {
+		err = quote.SetCurrency(currency)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrQuoteNotEditable) {
+			return nil, apperrors.New(apperrors.CodeConflict,
+				fmt.Sprintf("quote is %s; only draft quotes can be edited", quote.Status))
+		}
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if err := s.quotes.SaveContent(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to save quote: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		var userID, email string
+		if actor.User != nil {
+			userID = actor.User.ID.String()
+			email = actor.User.Email
+		}
+		s.auditLogger.QuoteEdited(ctx, userID, email, callID.String(), actor.IP, actor.RequestID,
+			len(quote.LineItems), before, quote.TotalAmount)
+	}
+
+	s.logger.Info("quote edited",
+		zap.String("call_id", callID.String()),
+		zap.Int("line_items", len(quote.LineItems)),
+		zap.Float64("total", quote.TotalAmount),
+		zap.String("currency", quote.Currency),
+	)
+
+	return s.detail(ctx, quote, summary)
+}
+
+// ArchiveQuote archives a quote, taking it out of the workflow until it is
+// unarchived. Archiving an archived quote keeps its original archive time.
+func (s *QuoteService) ArchiveQuote(ctx context.Context, callID uuid.UUID, actor QuoteActor) (*QuoteDetail, error) {
+	return s.setArchived(ctx, callID, actor, true)
+}
+
+// UnarchiveQuote returns an archived quote to the workflow.
+func (s *QuoteService) UnarchiveQuote(ctx context.Context, callID uuid.UUID, actor QuoteActor) (*QuoteDetail, error) {
+	return s.setArchived(ctx, callID, actor, false)
+}
+
+// ArchiveQuotes archives the quotes of calls together: either every quote
+// is archived or none is. Quotes already archived keep their archive time.
+func (s *QuoteService) ArchiveQuotes(ctx context.Context, callIDs []uuid.UUID, actor QuoteActor) error {
+	now := time.Now().UTC()
+	quotes := make([]*domain.Quote, 0, len(callIDs))
+	for _, callID := range callIDs {
+		quote, _, err := s.loadWithSummary(ctx, callID)
+		if err != nil {
+			return err
+		}
+		if quote.IsArchived() {
+			continue
+		}
+		quote.ArchivedAt = &now
+		quote.UpdatedAt = now
+		quotes = append(quotes, quote)
+	}
+	if len(quotes) == 0 {
+		return nil
+	}
+	if err := s.quotes.SaveArchivedMany(ctx, quotes); err != nil {
+		return fmt.Errorf("failed to save quotes: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		var userID, email string
+		if actor.User != nil {
+			userID = actor.User.ID.String()
+			email = actor.User.Email
+		}
+		for _, quote := range quotes {
+			s.auditLogger.QuoteArchived(ctx, userID, email, quote.CallID.String(), actor.IP, actor.RequestID)
+		}
+	}
+
+	s.logger.Info("quotes archived", zap.Int("count", len(quotes)))
+	return nil
+}
+
+func (s *QuoteService) setArchived(ctx context.Context, callID uuid.UUID, actor QuoteActor, archived bool) (*QuoteDetail, error) {
+	quote, summary, err := s.loadWithSummary(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.IsArchived() == archived {
+		return s.detail(ctx, quote, summary)
+	}
+
+	now := time.Now().UTC()
+	quote.ArchivedAt = nil
+	if archived {
+		quote.ArchivedAt = &now
+	}
+	quote.UpdatedAt = now
+	if err := s.quotes.SaveArchived(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to save quote: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		var userID, email string
+		if actor.User != nil {
+			userID = actor.User.ID.String()
+			email = actor.User.Email
+		}
+		if archived {
+			s.auditLogger.QuoteArchived(ctx, userID, email, callID.String(), actor.IP, actor.RequestID)
+		} else {
+			s.auditLogger.QuoteUnarchived(ctx, userID, email, callID.String(), actor.IP, actor.RequestID)
+		}
+	}
+
+	s.logger.Info("quote archive state changed",
+		zap.String("call_id", callID.String()),
+		zap.Bool("archived", archived),
+	)
+
+	return s.detail(ctx, quote, summary)
+}
+
+// detail adds the priced totals and status history to a quote.
+func (s *QuoteService) detail(ctx context.Context, quote *domain.Quote, summary string) (*QuoteDetail, error) {
+	edited := quote.IsEdited()
+	if !edited {
+		quote.LineItems = SummaryLineItems(summary)
+	}
+
+	history, err := s.quotes.ListTransitions(ctx, quote.CallID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quote history: %w", err)
+	}
+	if history == nil {
+		history = []*domain.QuoteTransition{}
+	}
+
+	var payment *domain.QuotePayment
+	if s.payments != nil {
+		payment, err = s.payments.GetByCallID(ctx, quote.CallID)
+		if err != nil && !apperrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get quote payment: %w", err)
+		}
+	}
+
+	return &QuoteDetail{
+		Quote:             quote,
+		QuoteNumber:       quotepdf.QuoteNumber(quote.CallID),
+		Edited:            edited,
+		Subtotal:          quote.Subtotal(),
+		Tax:               quote.Tax(),
+		Total:             quote.Total(),
+		ApprovalThreshold: s.approvalConfig().Threshold,
+		History:           history,
+		Payment:           payment,
+	}, nil
+}
+
+// Submit sends a draft quote for review, capturing its current total.
+func (s *QuoteService) Submit(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	return s.transition(ctx, callID, domain.QuoteStatusPendingReview, actor, note, func(q *domain.Quote, total float64) error {
+		threshold := s.approvalConfig().Threshold
+		q.TotalAmount = total
+		q.RequiresApproval = threshold > 0 && total > threshold
+		return nil
+	})
+}
+
+// Approve clears a quote under review to be sent. Quotes above the approval
+// threshold must be approved by a designated approver other than the submitter.
+func (s *QuoteService) Approve(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	quote, err := s.transition(ctx, callID, domain.QuoteStatusApproved, actor, note, func(q *domain.Quote, total float64) error {
+		if !sameAmount(total, q.TotalAmount) {
+			return apperrors.New(apperrors.CodeConflict, "quote has changed since it was submitted; request changes and resubmit")
+		}
+		if q.RequiresApproval {
+			return s.checkApprover(q, actor)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.publisher != nil {
+		s.publisher.Publish(ctx, domain.WebhookEventQuoteApproved, NewWebhookQuoteData(quote))
+	}
+	return quote, nil
+}
+
+// RequestChanges returns a quote under review, or approved but not yet sent, to draft.
+func (s *QuoteService) RequestChanges(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	return s.transition(ctx, callID, domain.QuoteStatusDraft, actor, note, nil)
+}
+
+// MarkSent records that an approved quote was delivered to the customer,
+// messages them that it is ready and schedules its follow-ups.
+func (s *QuoteService) MarkSent(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	quote, err := s.transition(ctx, callID, domain.QuoteStatusSent, actor, note, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.messenger != nil {
+		if err := s.messenger.SendQuoteReady(ctx, callID); err != nil {
+			s.logger.Warn("failed to send quote-ready message", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+	}
+
+	// The quote has been sent either way; a missed follow-up is not worth failing over
+	if s.followUps != nil && quote.SentAt != nil {
+		if err := s.followUps.ScheduleQuote(ctx, callID, *quote.SentAt); err != nil {
+			s.logger.Warn("failed to schedule quote follow-ups", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+	}
+	return quote, nil
+}
+
+// MarkAccepted records that the customer accepted a sent quote.
+func (s *QuoteService) MarkAccepted(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	return s.finish(ctx, callID, domain.QuoteStatusAccepted, actor, note, nil)
+}
+
+// AcceptSigned records that the customer accepted a sent quote, storing
+// their signature with it.
+func (s *QuoteService) AcceptSigned(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string, signature *domain.QuoteSignature) (*domain.Quote, error) {
+	if signature == nil {
+		return nil, apperrors.ValidationFailed("a signature is required to accept the quote")
+	}
+	return s.finish(ctx, callID, domain.QuoteStatusAccepted, actor, note, func(q *domain.Quote, _ float64) error {
+		q.Signature = signature
+		return nil
+	})
+}
+
+// MarkDeclined records that the customer declined a sent quote.
+func (s *QuoteService) MarkDeclined(ctx context.Context, callID uuid.UUID, actor QuoteActor, note string) (*domain.Quote, error) {
+	return s.finish(ctx, callID, domain.QuoteStatusDeclined, actor, note, nil)
+}
+
+// finish records the customer's decision on a sent quote, stops its
+// follow-ups and hands accepted quotes to accounting. The follow-up worker also checks the quote's status before
+// each step, so a failed cancel only leaves stale pending steps behind.
+func (s *QuoteService) finish(
+	ctx context.Context,
+	callID uuid.UUID,
+	to domain.QuoteStatus,
+	actor QuoteActor,
+	note string,
+	check func(q *domain.Quote, total float64) error,
+) (*domain.Quote, error) {
+	quote, err := s.transition(ctx, callID, to, actor, note, check)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.followUps != nil {
+		if _, err := s.followUps.CancelQuote(ctx, callID, "quote "+string(to)); err != nil {
+			s.logger.Warn("failed to cancel quote follow-ups", zap.String("call_id", callID.String()), zap.Error(err))
+		}
+	}
+	if s.accounting != nil && to == domain.QuoteStatusAccepted {
+		s.accounting.QuoteAccepted(ctx, callID)
+	}
+	return quote, nil
+}
+
+// transition loads a quote, runs check with the quote's current priced total,
+// applies the status change, persists it with its history entry and audits it.
+// check may also set fields to be saved with the change.
+func (s *QuoteService) transition(
+	ctx context.Context,
+	callID uuid.UUID,
+	to domain.QuoteStatus,
+	actor QuoteActor,
+	note string,
+	check func(q *domain.Quote, total float64) error,
+) (*domain.Quote, error) {
+	quote, total, err := s.load(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.IsArchived() {
+		return nil, apperrors.New(apperrors.CodeConflict, "quote is archived; unarchive it first")
+	}
+	if !quote.CanTransition(to) {
+		return nil, apperrors.New(apperrors.CodeConflict,
+			fmt.Sprintf("quote cannot move from %s to %s", quote.Status, to))
+	}
+
+	var actorID *uuid.UUID
+	var actorEmail string
+	if actor.User != nil {
+		id := actor.User.ID
+		actorID = &id
+		actorEmail = actor.User.Email
+	}
+
+	if check != nil {
+		if err := check(quote, total); err != nil {
+			if s.auditLogger != nil && apperrors.GetCode(err) == apperrors.CodeForbidden {
+				userID := ""
+				if actorID != nil {
+					userID = actorID.String()
+				}
+				s.auditLogger.AccessDenied(ctx, userID, "quote", string(to)+" quote "+callID.String(), actor.IP, actor.RequestID, err.Error())
+			}
+			return nil, err
+		}
+	}
+
+	note = strings.TrimSpace(note)
+	t, err := quote.Transition(to, actorID, note)
+	if err != nil {
+		return nil, err
+	}
+	t.ActorEmail = actorEmail
+
+	if err := s.quotes.SaveTransition(ctx, quote, t); err != nil {
+		return nil, fmt.Errorf("failed to save quote transition: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		userID := ""
+		if actorID != nil {
+			userID = actorID.String()
+		}
+		s.auditLogger.QuoteStatusChanged(ctx, userID, actorEmail, callID.String(),
+			string(t.FromStatus), string(t.ToStatus), note, actor.IP, actor.RequestID, quote.TotalAmount)
+	}
+
+	s.logger.Info("quote status changed",
+		zap.String("call_id", callID.String()),
+		zap.String("from", string(t.FromStatus)),
+		zap.String("to", string(t.ToStatus)),
+	)
+
+	if s.publisher != nil {
+		data := NewWebhookQuoteData(quote)
+		data.PreviousStatus = t.FromStatus
+		s.publisher.Publish(ctx, domain.WebhookEventQuoteStatusChanged, data)
+	}
+
+	return quote, nil
+}
+
+// load returns the stored quote for a call, or a new draft if it has never
+// been submitted, along with its current total: that of its edited line
+// items, or else the total priced in the quote text.
+func (s *QuoteService) load(ctx context.Context, callID uuid.UUID) (*domain.Quote, float64, error) {
+	quote, summary, err := s.loadWithSummary(ctx, callID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if quote.IsEdited() {
+		return quote, quote.Total(), nil
+	}
+	return quote, QuoteTotal(summary), nil
+}
+
+// loadWithSummary returns the stored quote for a call, or a new draft if it
+// has never been saved, along with the generated quote text.
+func (s *QuoteService) loadWithSummary(ctx context.Context, callID uuid.UUID) (*domain.Quote, string, error) {
+	call, err := s.calls.GetByID(ctx, callID)
+	if err != nil {
+		return nil, "", err
+	}
+	if call.QuoteSummary == nil || strings.TrimSpace(*call.QuoteSummary) == "" {
+		return nil, "", apperrors.NotFound("quote")
+	}
+	summary := *call.QuoteSummary
+
+	quote, err := s.quotes.GetByCallID(ctx, callID)
+	if err != nil {
+		if !apperrors.IsNotFound(err) {
+			return nil, "", err
+		}
+		quote = domain.NewQuote(callID, QuoteTotal(summary))
+		quote.Currency = callCurrency(ctx, s.currencies, call)
+	}
+	return quote, summary, nil
+}
+
+// checkApprover enforces the approval policy for quotes above the threshold.
+func (s *QuoteService) checkApprover(q *domain.Quote, actor QuoteActor) error {
+	if actor.User == nil {
+		return apperrors.New(apperrors.CodeForbidden, "quotes above the approval threshold must be approved by a signed-in user")
+	}
+	if q.SubmittedBy != nil && *q.SubmittedBy == actor.User.ID {
+		return apperrors.New(apperrors.CodeForbidden, "quotes above the approval threshold cannot be approved by their submitter")
+	}
+	approvers := s.approvalConfig().Approvers
+	if len(approvers) == 0 {
+		return nil
+	}
+	email := strings.ToLower(actor.User.Email)
+	for _, a := range approvers {
+		if a == email {
+			return nil
+		}
+	}
+	return apperrors.New(apperrors.CodeForbidden, "quotes above the approval threshold require a designated approver")
+}
+
+// QuoteTotal returns the sum of the priced line items in a quote summary.
+func QuoteTotal(summary string) float64 {
+	var total float64
+	for _, li := range quotepdf.ParseLineItems(summary) {
+		total += li.Amount()
+	}
+	return math.Round(total*100) / 100
+}
+
+// SummaryLineItems returns the priced line items in a quote summary.
+func SummaryLineItems(summary string) []domain.QuoteLineItem {
+	parsed := quotepdf.ParseLineItems(summary)
+	items := make([]domain.QuoteLineItem, len(parsed))
+	for i, li := range parsed {
+		items[i] = domain.QuoteLineItem{
+			Description: li.Description,
+			Quantity:    li.Quantity,
+			UnitPrice:   li.UnitPrice,
+			Amount:      math.Round(li.Amount()*100) / 100,
+		}
+	}
+	return items
+}
+
+// sameAmount compares two money amounts to the cent.
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockQuoteRepository is an in-memory QuoteRepository.
+type MockQuoteRepository struct {
+	mu          sync.Mutex
+	quotes      map[uuid.UUID]*domain.Quote
+	transitions map[uuid.UUID][]*domain.QuoteTransition
+}
+
+func NewMockQuoteRepository() *MockQuoteRepository {
+	return &MockQuoteRepository{
+		quotes:      make(map[uuid.UUID]*domain.Quote),
+		transitions: make(map[uuid.UUID][]*domain.QuoteTransition),
+	}
+}
+
+func (m *MockQuoteRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Quote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q, ok := m.quotes[callID]
+	if !ok {
+		return nil, apperrors.NotFound("quote")
+	}
+	cp := *q
+	return &cp, nil
+}
+
+func (m *MockQuoteRepository) SaveTransition(ctx context.Context, quote *domain.Quote, t *domain.QuoteTransition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *quote
+	m.quotes[quote.CallID] = &cp
+	m.transitions[quote.CallID] = append(m.transitions[quote.CallID], t)
+	return nil
+}
+
+func (m *MockQuoteRepository) SaveContent(ctx context.Context, quote *domain.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *quote
+	m.quotes[quote.CallID] = &cp
+	return nil
+}
+
+func (m *MockQuoteRepository) SaveArchived(ctx context.Context, quote *domain.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *quote
+	m.quotes[quote.CallID] = &cp
+	return nil
+}
+
+func (m *MockQuoteRepository) SaveArchivedMany(ctx context.Context, quotes []*domain.Quote) error {
+	for _, quote := range quotes {
+		if err := m.SaveArchived(ctx, quote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockQuoteRepository) ListTransitions(ctx context.Context, callID uuid.UUID) ([]*domain.QuoteTransition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.transitions[callID], nil
+}
+
+func newQuoteTestService(t *testing.T, summary string, cfg QuoteApprovalConfig) (*QuoteService, *MockQuoteRepository, *MockCallRepository, uuid.UUID) {
+	t.Helper()
+	calls := NewMockCallRepository()
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	call.QuoteSummary = &summary
+	if err := calls.Create(context.Background(), call); err != nil {
+		t.Fatalf("create call: %v", err)
+	}
+	quotes := NewMockQuoteRepository()
+	return NewQuoteService(quotes, calls, nil, cfg, zap.NewNop()), quotes, calls, call.ID
+}
+
+func quoteActor(email string) QuoteActor {
+	return QuoteActor{User: &domain.User{ID: uuid.New(), Email: email}}
+}
+
+func TestQuoteService_FullLifecycle(t *testing.T) {
+	ctx := context.Background()
+	svc, quotes, _, callID := newQuoteTestService(t, "- Design: $2,000\n- Build: $3,500.50", QuoteApprovalConfig{Threshold: 10000})
+	staff := quoteActor("staff@example.com")
+
+	q, err := svc.Submit(ctx, callID, staff, "first draft")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if q.TotalAmount != 5500.50 {
+		t.Errorf("expected total 5500.50, got %v", q.TotalAmount)
+	}
+	if q.RequiresApproval {
+		t.Error("expected quote under threshold not to require approval")
+	}
+
+	// Under the threshold the submitter may approve their own quote.
+	steps := []func(context.Context, uuid.UUID, QuoteActor, string) (*domain.Quote, error){
+		svc.Approve, svc.MarkSent, svc.MarkAccepted,
+	}
+	for i, step := range steps {
+		if q, err = step(ctx, callID, staff, ""); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+	if q.Status != domain.QuoteStatusAccepted {
+		t.Errorf("expected accepted, got %s", q.Status)
+	}
+
+	detail, err := svc.GetQuote(ctx, callID)
+	if err != nil {
+		t.Fatalf("get quote: %v", err)
+	}
+	if len(detail.History) != 4 {
+		t.Errorf("expected 4 history entries, got %d", len(detail.History))
+	}
+	if got := quotes.transitions[callID][0]; got.ActorEmail != "staff@example.com" || got.Note != "first draft" {
+		t.Errorf("unexpected first history entry %+v", got)
+	}
+}
+
+func TestQuoteService_GetQuote_DefaultsToDraft(t *testing.T) {
+	svc, _, _, callID := newQuoteTestService(t, "- Build: $500", QuoteApprovalConfig{})
+
+	detail, err := svc.GetQuote(context.Background(), callID)
+	if err != nil {
+		t.Fatalf("get quote: %v", err)
+	}
+	if detail.Status != domain.QuoteStatusDraft || detail.TotalAmount != 500 {
+		t.Errorf("expected $500 draft, got %s $%v", detail.Status, detail.TotalAmount)
+	}
+	if detail.History == nil {
+		t.Error("expected empty, non-nil history")
+	}
+}
+
+func TestQuoteService_NoQuoteGenerated(t *testing.T) {
+	svc, _, _, callID := newQuoteTestService(t, "  ", QuoteApprovalConfig{})
+
+	_, err := svc.Submit(context.Background(), callID, quoteActor("staff@example.com"), "")
+	if !apperrors.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestQuoteService_ApprovalThreshold(t *testing.T) {
+	ctx := context.Background()
+	summary := "- Platform build: $25,000"
+
+	t.Run("submitter cannot approve", func(t *testing.T) {
+		svc, _, _, callID := newQuoteTestService(t, summary, QuoteApprovalConfig{Threshold: 10000})
+		staff := quoteActor("staff@example.com")
+
+		q, err := svc.Submit(ctx, callID, staff, "")
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+		if !q.RequiresApproval {
+			t.Fatal("expected quote over threshold to require approval")
+		}
+
+		_, err = svc.Approve(ctx, callID, staff, "")
+		if apperrors.GetCode(err) != apperrors.CodeForbidden {
+			t.Errorf("expected forbidden, got %v", err)
+		}
+		if _, err := svc.Approve(ctx, callID, quoteActor("other@example.com"), ""); err != nil {
+			t.Errorf("expected another user to approve, got %v", err)
+		}
+	})
+
+	t.Run("anonymous cannot approve", func(t *testing.T) {
+		svc, _, _, callID := newQuoteTestService(t, summary, QuoteApprovalConfig{Threshold: 10000})
+		if _, err := svc.Submit(ctx, callID, quoteActor("staff@example.com"), ""); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+
+		_, err := svc.Approve(ctx, callID, QuoteActor{}, "")
+		if apperrors.GetCode(err) != apperrors.CodeForbidden {
+			t.Errorf("expected forbidden, got %v", err)
+		}
+	})
+
+	t.Run("designated approvers only", func(t *testing.T) {
+		cfg := QuoteApprovalConfig{Threshold: 10000, Approvers: []string{" Boss@Example.com "}}
+		svc, _, _, callID := newQuoteTestService(t, summary, cfg)
+		if _, err := svc.Submit(ctx, callID, quoteActor("staff@example.com"), ""); err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+
+		_, err := svc.Approve(ctx, callID, quoteActor("other@example.com"), "")
+		if apperrors.GetCode(err) != apperrors.CodeForbidden {
+			t.Errorf("expected forbidden for non-approver, got %v", err)
+		}
+		if _, err := svc.Approve(ctx, callID, quoteActor("boss@example.com"), ""); err != nil {
+			t.Errorf("expected designated approver to approve, got %v", err)
+		}
+	})
+}
+
+func TestQuoteService_ApproveRejectsChangedQuote(t *testing.T) {
+	ctx := context.Background()
+	svc, _, calls, callID := newQuoteTestService(t, "- Build: $1,000", QuoteApprovalConfig{})
+	staff := quoteActor("staff@example.com")
+
+	if _, err := svc.Submit(ctx, callID, staff, ""); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	call, _ := calls.GetByID(ctx, callID)
+	updated := "- Build: $1,500"
+	call.QuoteSummary = &updated
+
+	_, err := svc.Approve(ctx, callID, staff, "")
+	if apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict, got %v", err)
+	}
+}
+
+func TestQuoteService_InvalidTransition(t *testing.T) {
+	svc, quotes, _, callID := newQuoteTestService(t, "- Build: $1,000", QuoteApprovalConfig{})
+
+	_, err := svc.MarkSent(context.Background(), callID, quoteActor("staff@example.com"), "")
+	if apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict, got %v", err)
+	}
+	if len(quotes.transitions[callID]) != 0 {
+		t.Error("expected no history for a rejected transition")
+	}
+}
+
+func TestQuoteService_ArchivedQuoteIsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	svc, quotes, _, callID := newQuoteTestService(t, "- Build: $1,000", QuoteApprovalConfig{})
+	staff := quoteActor("staff@example.com")
+
+	detail, err := svc.ArchiveQuote(ctx, callID, staff)
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if !detail.IsArchived() {
+		t.Fatal("expected archived quote")
+	}
+
+	if _, err := svc.Submit(ctx, callID, staff, ""); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("submit archived quote: expected conflict, got %v", err)
+	}
+	items := []domain.QuoteLineItem{{Description: "Build", Quantity: 1, UnitPrice: 900}}
+	if _, err := svc.UpdateQuote(ctx, callID, staff, items, 0, "", ""); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("edit archived quote: expected conflict, got %v", err)
+	}
+
+	if detail, err = svc.UnarchiveQuote(ctx, callID, staff); err != nil || detail.IsArchived() {
+		t.Fatalf("unarchive: %v", err)
+	}
+	if _, err := svc.Submit(ctx, callID, staff, ""); err != nil {
+		t.Errorf("submit after unarchive: %v", err)
+	}
+	if quotes.quotes[callID].ArchivedAt != nil {
+		t.Error("expected stored quote to be unarchived")
+	}
+}
+
+func TestQuoteService_UpdateQuote(t *testing.T) {
+	ctx := context.Background()
+	svc, quotes, _, callID := newQuoteTestService(t, "- Design: $2,000\n- Build: $3,000", QuoteApprovalConfig{Threshold: 6000})
+	staff := quoteActor("staff@example.com")
+
+	detail, err := svc.GetQuote(ctx, callID)
+	if err != nil {
+		t.Fatalf("get quote: %v", err)
+	}
+	if detail.Edited || len(detail.LineItems) != 2 || detail.Total != 5000 {
+		t.Fatalf("expected 2 parsed line items totalling 5000, got edited=%v %+v total %v", detail.Edited, detail.LineItems, detail.Total)
+	}
+
+	items := []domain.QuoteLineItem{
+		{Description: "Design", Quantity: 1, UnitPrice: 2000},
+		{Description: "Build", Quantity: 40, UnitPrice: 95},
+	}
+	detail, err = svc.UpdateQuote(ctx, callID, staff, items, 5, "Includes two revision rounds", "")
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !detail.Edited || detail.Subtotal != 5800 || detail.Tax != 290 || detail.Total != 6090 {
+		t.Errorf("unexpected totals: subtotal %v tax %v total %v", detail.Subtotal, detail.Tax, detail.Total)
+	}
+	if stored := quotes.quotes[callID]; stored == nil || len(stored.LineItems) != 2 || stored.Notes != "Includes two revision rounds" {
+		t.Fatalf("expected edited quote to be stored, got %+v", stored)
+	}
+
+	// The edited total, not the generated text, is what goes for approval.
+	q, err := svc.Submit(ctx, callID, staff, "")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if q.TotalAmount != 6090 || !q.RequiresApproval {
+		t.Errorf("expected edited total 6090 to require approval, got %v (requires approval %v)", q.TotalAmount, q.RequiresApproval)
+	}
+
+	_, err = svc.UpdateQuote(ctx, callID, staff, items, 0, "", "")
+	if apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("expected conflict editing a quote under review, got %v", err)
+	}
+}
+
+func TestQuoteService_UpdateQuote_Validation(t *testing.T) {
+	svc, quotes, _, callID := newQuoteTestService(t, "- Build: $1,000", QuoteApprovalConfig{})
+
+	items := []domain.QuoteLineItem{{Description: "Build", Quantity: 0, UnitPrice: 1000}}
+	_, err := svc.UpdateQuote(context.Background(), callID, quoteActor("staff@example.com"), items, 0, "", "")
+	if apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	if _, ok := quotes.quotes[callID]; ok {
+		t.Error("expected invalid edit not to be stored")
+	}
+}
+
+// staticCurrency is a CurrencySource with a fixed default.
+type staticCurrency string
+
+func (c staticCurrency) DefaultCurrency(ctx context.Context) string {
+	return string(c)
+}
+
+func TestQuoteService_Currency(t *testing.T) {
+	ctx := context.Background()
+	svc, quotes, calls, callID := newQuoteTestService(t, "- Build: $1,000", QuoteApprovalConfig{})
+	staff := quoteActor("staff@example.com")
+
+	detail, err := svc.GetQuote(ctx, callID)
+	if err != nil || detail.Currency != "USD" {
+		t.Fatalf("expected a quote without a source to be in USD, got %+v, %v", detail, err)
+	}
+
+	svc.SetCurrencies(staticCurrency("CAD"))
+	if detail, _ = svc.GetQuote(ctx, callID); detail.Currency != "CAD" {
+		t.Errorf("expected the default currency, got %q", detail.Currency)
+	}
+
+	call, _ := calls.GetByID(ctx, callID)
+	call.ExtractedData = &domain.ExtractedData{Currency: "eur"}
+	if detail, _ = svc.GetQuote(ctx, callID); detail.Currency != "EUR" {
+		t.Errorf("expected the currency the quote was generated in, got %q", detail.Currency)
+	}
+
+	items := []domain.QuoteLineItem{{Description: "Build", Quantity: 1, UnitPrice: 1000}}
+	if _, err := svc.UpdateQuote(ctx, callID, staff, items, 0, "", "pounds"); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("expected validation error for an invalid currency, got %v", err)
+	}
+	if _, err := svc.UpdateQuote(ctx, callID, staff, items, 0, "", "gbp"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if stored := quotes.quotes[callID]; stored == nil || stored.Currency != "GBP" {
+		t.Errorf("expected the quote stored in GBP, got %+v", stored)
+	}
+}
+
+func TestQuoteTotal(t *testing.T) {
+	summary := "Project quote\n- Design: $1,250.10\n- Build: $3,000\n- Hosting: $50-$100/month\nTotal: $4,250.10"
+	if got := QuoteTotal(summary); got != 4250.10 {
+		t.Errorf("expected 4250.10, got %v", got)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// QuoteTemplateService manages quote templates, which give the quotes for
+// each project type their fixed sections, default line items and
+// assumptions. The pricing engine adds the line items; the PDF generator
+// prints the sections and assumptions.
+type QuoteTemplateService struct {
+	templates domain.QuoteTemplateRepository
+	logger    *zap.Logger
+}
+
+// NewQuoteTemplateService creates a new QuoteTemplateService.
+func NewQuoteTemplateService(templates domain.QuoteTemplateRepository, logger *zap.Logger) *QuoteTemplateService {
+	return &QuoteTemplateService{
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// QuoteTemplateRequest holds the fields for creating or replacing a quote
+// template.
+type QuoteTemplateRequest struct {
+	ProjectType      string                         `json:"project_type"`
+	Name             string                         `json:"name"`
+	Sections         []domain.QuoteTemplateSection  `json:"sections"`
+	DefaultLineItems []domain.QuoteTemplateLineItem `json:"default_line_items"`
+	Assumptions      string                         `json:"assumptions,omitempty"`
+	IsActive         bool                           `json:"is_active"`
+}
+
+// ListTemplates returns all quote templates.
+func (s *QuoteTemplateService) ListTemplates(ctx context.Context) ([]*domain.QuoteTemplate, error) {
+	return s.templates.List(ctx)
+}
+
+// GetTemplate retrieves a quote template by ID.
+func (s *QuoteTemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*domain.QuoteTemplate, error) {
+	return s.templates.GetByID(ctx, id)
+}
+
+// CreateTemplate validates and stores a new quote template.
+func (s *QuoteTemplateService) CreateTemplate(ctx context.Context, req *QuoteTemplateRequest) (*domain.QuoteTemplate, error) {
+	template := domain.NewQuoteTemplate(req.ProjectType, "")
+	applyQuoteTemplateRequest(template, req)
+	if err := template.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if err := s.templates.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("quote template created",
+		zap.String("template_id", template.ID.String()),
+		zap.String("project_type", template.ProjectType),
+	)
+	return template, nil
+}
+
+// UpdateTemplate replaces the fields of a quote template.
+func (s *QuoteTemplateService) UpdateTemplate(ctx context.Context, id uuid.UUID, req *QuoteTemplateRequest) (*domain.QuoteTemplate, error) {
+	template, err := s.templates.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyQuoteTemplateRequest(template, req)
+	if err := template.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	template.UpdatedAt = time.Now().UTC()
+
+	if err := s.templates.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("quote template updated", zap.String("template_id", template.ID.String()))
+	return template, nil
+}
+
+// DeleteTemplate removes a quote template.
+func (s *QuoteTemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.templates.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("quote template deleted", zap.String("template_id", id.String()))
+	return nil
+}
+
+// TemplateFor returns the active template for a project type, falling back
+// to the default template. It returns a not-found error when neither exists.
+func (s *QuoteTemplateService) TemplateFor(ctx context.Context, projectType string) (*domain.QuoteTemplate, error) {
+	if normalized := domain.NormalizeProjectType(projectType); normalized != "" && normalized != domain.DefaultPricingProjectType {
+		template, err := s.templates.GetActiveByProjectType(ctx, normalized)
+		if err == nil {
+			return template, nil
+		}
+		if !apperrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return s.templates.GetActiveByProjectType(ctx, domain.DefaultPricingProjectType)
+}
+
+// applyQuoteTemplateRequest copies a request onto a template, trimming text
+// fields.
+func applyQuoteTemplateRequest(template *domain.QuoteTemplate, req *QuoteTemplateRequest) {
+	template.ProjectType = domain.NormalizeProjectType(req.ProjectType)
+	template.Name = strings.TrimSpace(req.Name)
+	template.Assumptions = strings.TrimSpace(req.Assumptions)
+	template.IsActive = req.IsActive
+
+	template.Sections = make([]domain.QuoteTemplateSection, 0, len(req.Sections))
+	for _, section := range req.Sections {
+		section.Heading = strings.TrimSpace(section.Heading)
+		section.Body = strings.TrimSpace(section.Body)
+		template.Sections = append(template.Sections, section)
+	}
+	template.DefaultLineItems = make([]domain.QuoteTemplateLineItem, 0, len(req.DefaultLineItems))
+	for _, li := range req.DefaultLineItems {
+		li.Description = strings.TrimSpace(li.Description)
+		template.DefaultLineItems = append(template.DefaultLineItems, li)
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockQuoteTemplateRepository is an in-memory QuoteTemplateRepository.
+type MockQuoteTemplateRepository struct {
+	mu        sync.Mutex
+	templates map[uuid.UUID]*domain.QuoteTemplate
+}
+
+func NewMockQuoteTemplateRepository() *MockQuoteTemplateRepository {
+	return &MockQuoteTemplateRepository{templates: make(map[uuid.UUID]*domain.QuoteTemplate)}
+}
+
+func (m *MockQuoteTemplateRepository) Create(ctx context.Context, template *domain.QuoteTemplate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.templates {
+		if t.ProjectType == template.ProjectType {
+			return apperrors.New(apperrors.CodeAlreadyExists, "a quote template for this project type already exists")
+		}
+	}
+	cp := *template
+	m.templates[template.ID] = &cp
+	return nil
+}
+
+func (m *MockQuoteTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.QuoteTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.templates[id]
+	if !ok {
+		return nil, apperrors.NotFound("quote template")
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (m *MockQuoteTemplateRepository) GetActiveByProjectType(ctx context.Context, projectType string) (*domain.QuoteTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.templates {
+		if t.ProjectType == projectType && t.IsActive {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("quote template")
+}
+
+func (m *MockQuoteTemplateRepository) Update(ctx context.Context, template *domain.QuoteTemplate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.templates[template.ID]; !ok {
+		return apperrors.NotFound("quote template")
+	}
+	cp := *template
+	m.templates[template.ID] = &cp
+	return nil
+}
+
+func (m *MockQuoteTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.templates[id]; !ok {
+		return apperrors.NotFound("quote template")
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *MockQuoteTemplateRepository) List(ctx context.Context) ([]*domain.QuoteTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var templates []*domain.QuoteTemplate
+	for _, t := range m.templates {
+		cp := *t
+		templates = append(templates, &cp)
+	}
+	return templates, nil
+}
+
+func newTestQuoteTemplateService(t *testing.T) *QuoteTemplateService {
+	t.Helper()
+	svc := NewQuoteTemplateService(NewMockQuoteTemplateRepository(), zap.NewNop())
+	_, err := svc.CreateTemplate(context.Background(), &QuoteTemplateRequest{
+		ProjectType:      domain.DefaultPricingProjectType,
+		Name:             "Standard quote",
+		Sections:         []domain.QuoteTemplateSection{{Heading: "Payment terms", Body: "50% deposit."}},
+		DefaultLineItems: []domain.QuoteTemplateLineItem{{Description: "Permit fee", Quantity: 1, UnitPrice: 150}},
+		Assumptions:      "Site access is available on weekdays.",
+		IsActive:         true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create default template: %v", err)
+	}
+	return svc
+}
+
+func TestQuoteTemplateService_CreateTemplateValidates(t *testing.T) {
+	svc := newTestQuoteTemplateService(t)
+	ctx := context.Background()
+
+	_, err := svc.CreateTemplate(ctx, &QuoteTemplateRequest{
+		ProjectType:      "Roofing",
+		Name:             "Roof quote",
+		DefaultLineItems: []domain.QuoteTemplateLineItem{{Description: "Skip hire", Quantity: 0, UnitPrice: 200}},
+	})
+	if !apperrors.IsUserError(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	_, err = svc.CreateTemplate(ctx, &QuoteTemplateRequest{ProjectType: " DEFAULT ", Name: "Another"})
+	if apperrors.GetHTTPStatus(err) != 409 {
+		t.Errorf("expected conflict for a duplicate project type, got %v", err)
+	}
+}
+
+func TestQuoteTemplateService_TemplateFor(t *testing.T) {
+	svc := newTestQuoteTemplateService(t)
+	ctx := context.Background()
+
+	roofing, err := svc.CreateTemplate(ctx, &QuoteTemplateRequest{
+		ProjectType: "  Roofing ",
+		Name:        "Roof quote",
+		IsActive:    true,
+	})
+	if err != nil {
+		t.Fatalf("CreateTemplate failed: %v", err)
+	}
+
+	template, err := svc.TemplateFor(ctx, "roofing")
+	if err != nil || template.ID != roofing.ID {
+		t.Errorf("expected the roofing template, got %+v, %v", template, err)
+	}
+
+	template, err = svc.TemplateFor(ctx, "Landscaping")
+	if err != nil || template.ProjectType != domain.DefaultPricingProjectType {
+		t.Errorf("expected the default template, got %+v, %v", template, err)
+	}
+
+	roofing.IsActive = false
+	if _, err := svc.UpdateTemplate(ctx, roofing.ID, &QuoteTemplateRequest{ProjectType: "roofing", Name: "Roof quote"}); err != nil {
+		t.Fatalf("UpdateTemplate failed: %v", err)
+	}
+	template, err = svc.TemplateFor(ctx, "roofing")
+	if err != nil || template.ProjectType != domain.DefaultPricingProjectType {
+		t.Errorf("inactive template should fall back to the default, got %+v, %v", template, err)
+	}
+}
+
+func TestPricingService_EstimateAddsTemplateLines(t *testing.T) {
+	svc, _ := newTestPricingService(t)
+	svc.SetTemplates(newTestQuoteTemplateService(t))
+
+	call := domain.NewCall("p1", "bland", "+15550001111", "+15550002222")
+	estimate, err := svc.Estimate(context.Background(), call)
+	if err != nil {
+		t.Fatalf("Estimate failed: %v", err)
+	}
+	// (2000 + 3 x 500) x 1.5 + 150 permit fee, which is not multiplied
+	if estimate.Total != 5400 {
+		t.Errorf("Total = %v, want 5400", estimate.Total)
+	}
+	last := estimate.Lines[len(estimate.Lines)-1]
+	if last.Description != "Permit fee" || last.Amount != 150 {
+		t.Errorf("last line = %+v, want the permit fee", last)
+	}
+}
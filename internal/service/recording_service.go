@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/storage"
+)
+
+// RecordingService downloads call recordings from voice providers before
+// their URLs expire and deletes the copies when their retention ends.
+type RecordingService struct {
+	repo    domain.RecordingRepository
+	store   storage.Store
+	client  *http.Client
+	logger  *zap.Logger
+	elector Elector
+
+	// Configuration
+	retention       time.Duration
+	maxSize         int64
+	pollInterval    time.Duration
+	cleanupInterval time.Duration
+	batchSize       int
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// RecordingServiceConfig holds configuration for the recording service.
+type RecordingServiceConfig struct {
+	Retention       time.Duration // How long stored recordings are kept; 0 keeps them forever
+	MaxSize         int64         // Largest recording downloaded, in bytes
+	PollInterval    time.Duration // How often pending downloads are checked
+	CleanupInterval time.Duration // How often expired recordings are deleted
+	BatchSize       int
+	HTTPClient      *http.Client // Client used to download recordings
+}
+
+// DefaultRecordingServiceConfig returns sensible defaults.
+func DefaultRecordingServiceConfig() *RecordingServiceConfig {
+	return &RecordingServiceConfig{
+		Retention:       90 * 24 * time.Hour,
+		MaxSize:         100 << 20,
+		PollInterval:    15 * time.Second,
+		CleanupInterval: time.Hour,
+		BatchSize:       10,
+	}
+}
+
+// NewRecordingService creates a new RecordingService.
+func NewRecordingService(
+	repo domain.RecordingRepository,
+	store storage.Store,
+	logger *zap.Logger,
+	config *RecordingServiceConfig,
+) *RecordingService {
+	if config == nil {
+		config = DefaultRecordingServiceConfig()
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+
+	return &RecordingService{
+		repo:            repo,
+		store:           store,
+		client:          client,
+		logger:          logger,
+		retention:       config.Retention,
+		maxSize:         config.MaxSize,
+		pollInterval:    config.PollInterval,
+		cleanupInterval: config.CleanupInterval,
+		batchSize:       config.BatchSize,
+		stopCh:          make(chan struct{}),
+	}
+}
+
+// Archive queues a call's recording for download. Calls without a recording
+// URL and recordings already stored are left alone.
+func (s *RecordingService) Archive(ctx context.Context, call *domain.Call) error {
+	if call.RecordingURL == nil || *call.RecordingURL == "" {
+		return nil
+	}
+	sourceURL := *call.RecordingURL
+
+	existing, err := s.repo.GetByCallID(ctx, call.ID)
+	if err != nil && !apperrors.IsNotFound(err) {
+		return err
+	}
+	if existing != nil {
+		// A later webhook may carry a fresh URL for a download still waiting.
+		if existing.Status != domain.RecordingStatusPending || existing.SourceURL == sourceURL {
+			return nil
+		}
+		existing.SourceURL = sourceURL
+		existing.NextAttemptAt = time.Now().UTC()
+		existing.UpdatedAt = existing.NextAttemptAt
+		return s.repo.Update(ctx, existing)
+	}
+
+	recording := domain.NewRecording(call.ID, sourceURL)
+	if err := s.repo.Create(ctx, recording); err != nil {
+		return err
+	}
+	s.logger.Info("recording queued for download",
+		zap.String("call_id", call.ID.String()),
+		zap.String("recording_id", recording.ID.String()),
+	)
+	return nil
+}
+
+// Get returns the recording of a call.
+func (s *RecordingService) Get(ctx context.Context, callID uuid.UUID) (*domain.Recording, error) {
+	return s.repo.GetByCallID(ctx, callID)
+}
+
+// Open returns a call's stored recording for streaming. It returns a
+// not-found error when the call has no recording in storage.
+func (s *RecordingService) Open(ctx context.Context, callID uuid.UUID) (*domain.Recording, *storage.Object, error) {
+	recording, err := s.repo.GetByCallID(ctx, callID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !recording.IsAvailable() {
+		return recording, nil, apperrors.NotFound("recording")
+	}
+
+	obj, err := s.store.Get(ctx, recording.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return recording, nil, apperrors.NotFound("recording")
+		}
+		return recording, nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	if obj.ContentType == "" || obj.ContentType == "application/octet-stream" {
+		obj.ContentType = recording.ContentType
+	}
+	return recording, obj, nil
+}
+
+// Purge deletes a call's stored recording from storage ahead of its
+// retention, for data subject deletions, and reports whether there was one.
+// The recording row is left for the caller to remove.
+func (s *RecordingService) Purge(ctx context.Context, callID uuid.UUID) (bool, error) {
+	recording, err := s.repo.GetByCallID(ctx, callID)
+	if apperrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if recording.StorageKey == "" || recording.Status == domain.RecordingStatusDeleted {
+		return false, nil
+	}
+
+	if err := s.store.Delete(ctx, recording.StorageKey); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, fmt.Errorf("failed to delete recording: %w", err)
+	}
+	recording.MarkDeleted()
+	if err := s.repo.Update(ctx, recording); err != nil {
+		return false, err
+	}
+	s.logger.Info("recording purged", zap.String("call_id", callID.String()))
+	return true, nil
+}
+
+// SetElector limits the recording worker to the instance elector chooses.
+func (s *RecordingService) SetElector(elector Elector) {
+	s.elector = elector
+}
+
+// Start begins downloading queued recordings and deleting expired ones.
+func (s *RecordingService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("recording worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting recording worker",
+		zap.String("storage", s.store.Name()),
+		zap.Duration("retention", s.retention),
+		zap.Duration("poll_interval", s.pollInterval),
+	)
+
+	s.wg.Add(2)
+	go s.downloadLoop()
+	go s.cleanupLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight downloads to finish.
+func (s *RecordingService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping recording worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("recording worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("recording worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// downloadLoop downloads due recordings until stopped.
+func (s *RecordingService) downloadLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	s.downloadDue(time.Now())
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.downloadDue(time.Now())
+		}
+	}
+}
+
+// cleanupLoop deletes expired recordings until stopped.
+func (s *RecordingService) cleanupLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cleanupInterval)
+	defer ticker.Stop()
+
+	s.deleteExpired(time.Now())
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.deleteExpired(time.Now())
+		}
+	}
+}
+
+// downloadDue downloads recordings that are due an attempt.
+func (s *RecordingService) downloadDue(now time.Time) {
+	if !isLeader(s.elector) {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Abort an in-flight download when the worker stops.
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	recordings, err := s.repo.ListDue(ctx, now, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to list due recordings", zap.Error(err))
+		return
+	}
+
+	for _, recording := range recordings {
+		if ctx.Err() != nil {
+			return
+		}
+		s.download(ctx, recording)
+	}
+}
+
+// download copies one recording into storage and records the outcome.
+func (s *RecordingService) download(ctx context.Context, recording *domain.Recording) {
+	logger := s.logger.With(
+		zap.String("recording_id", recording.ID.String()),
+		zap.String("call_id", recording.CallID.String()),
+	)
+
+	key, contentType, size, err := s.fetch(ctx, recording)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Stopped mid-download; try again on the next run.
+			return
+		}
+		recording.MarkFailed(err)
+		if recording.Status == domain.RecordingStatusFailed {
+			logger.Error("giving up on recording download", zap.Error(err), zap.Int("attempts", recording.Attempts))
+		} else {
+			logger.Warn("failed to download recording", zap.Error(err), zap.Time("next_attempt", recording.NextAttemptAt))
+		}
+	} else {
+		recording.MarkStored(s.store.Name(), key, contentType, size, s.retention)
+		logger.Info("recording stored", zap.String("key", key), zap.Int64("size_bytes", size))
+	}
+
+	// Record the outcome even if the worker is stopping.
+	if err := s.repo.Update(context.Background(), recording); err != nil {
+		logger.Error("failed to update recording", zap.Error(err))
+	}
+}
+
+// fetch downloads the recording to a temporary file, then stores it. It
+// returns the storage key, content type and size.
+func (s *RecordingService) fetch(ctx context.Context, recording *domain.Recording) (string, string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, recording.SourceURL, nil)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid recording url: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("recording request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", 0, fmt.Errorf("recording url returned %d", resp.StatusCode)
+	}
+	if s.maxSize > 0 && resp.ContentLength > s.maxSize {
+		return "", "", 0, fmt.Errorf("recording is %d bytes, more than the %d byte limit", resp.ContentLength, s.maxSize)
+	}
+
+	tmp, err := os.CreateTemp("", "recording-*")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	body := io.Reader(resp.Body)
+	if s.maxSize > 0 {
+		body = io.LimitReader(resp.Body, s.maxSize+1)
+	}
+	size, err := io.Copy(tmp, body)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to download recording: %w", err)
+	}
+	if s.maxSize > 0 && size > s.maxSize {
+		return "", "", 0, fmt.Errorf("recording is more than the %d byte limit", s.maxSize)
+	}
+	if size == 0 {
+		return "", "", 0, errors.New("recording is empty")
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", "", 0, fmt.Errorf("failed to read downloaded recording: %w", err)
+	}
+
+	contentType := recordingContentType(resp.Header.Get("Content-Type"), recording.SourceURL)
+	key := recordingKey(recording, contentType)
+	if err := s.store.Put(ctx, key, tmp, size, contentType); err != nil {
+		return "", "", 0, fmt.Errorf("failed to store recording: %w", err)
+	}
+	return key, contentType, size, nil
+}
+
+// deleteExpired deletes recordings whose retention has ended.
+func (s *RecordingService) deleteExpired(now time.Time) {
+	if !isLeader(s.elector) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	recordings, err := s.repo.ListExpired(ctx, now, s.batchSize)
+	if err != nil {
+		s.logger.Error("failed to list expired recordings", zap.Error(err))
+		return
+	}
+
+	for _, recording := range recordings {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		if err := s.deleteStored(ctx, recording); err != nil {
+			s.logger.Warn("failed to delete expired recording",
+				zap.String("recording_id", recording.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		s.logger.Info("expired recording deleted",
+			zap.String("recording_id", recording.ID.String()),
+			zap.String("call_id", recording.CallID.String()),
+		)
+	}
+}
+
+// CountStoredBefore counts recordings stored before cutoff that are not on
+// legal hold.
+func (s *RecordingService) CountStoredBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	return s.repo.CountStoredBefore(ctx, cutoff)
+}
+
+// PurgeStoredBefore deletes up to limit recordings stored before cutoff,
+// oldest first, skipping recordings on legal hold, and returns how many it
+// deleted. A recording that fails to delete is left for the next run.
+func (s *RecordingService) PurgeStoredBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	recordings, err := s.repo.ListStoredBefore(ctx, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, recording := range recordings {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		if err := s.deleteStored(ctx, recording); err != nil {
+			s.logger.Warn("failed to delete recording past retention",
+				zap.String("recording_id", recording.ID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// deleteStored removes a stored recording from storage and marks it deleted.
+func (s *RecordingService) deleteStored(ctx context.Context, recording *domain.Recording) error {
+	if err := s.store.Delete(ctx, recording.StorageKey); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	recording.MarkDeleted()
+	return s.repo.Update(ctx, recording)
+}
+
+// recordingExtensions maps the audio types providers serve to file extensions.
+var recordingExtensions = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/wav":   ".wav",
+	"audio/x-wav": ".wav",
+	"audio/wave":  ".wav",
+	"audio/ogg":   ".ogg",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/webm":  ".webm",
+}
+
+// recordingContentType returns the recording's audio type from the response
+// header, falling back to the URL's file extension and then to MP3.
+func recordingContentType(header, sourceURL string) string {
+	if mediaType, _, err := mime.ParseMediaType(header); err == nil && strings.HasPrefix(mediaType, "audio/") {
+		return mediaType
+	}
+	if u, err := url.Parse(sourceURL); err == nil {
+		switch strings.ToLower(path.Ext(u.Path)) {
+		case ".wav":
+			return "audio/wav"
+		case ".ogg":
+			return "audio/ogg"
+		case ".m4a":
+			return "audio/mp4"
+		case ".webm":
+			return "audio/webm"
+		}
+	}
+	return "audio/mpeg"
+}
+
+// recordingKey returns the storage key for a recording, grouped by month.
+func recordingKey(recording *domain.Recording, contentType string) string {
+	ext, ok := recordingExtensions[contentType]
+	if !ok {
+		ext = ".mp3"
+	}
+	return fmt.Sprintf("recordings/%s/%s%s", recording.CreatedAt.UTC().Format("2006/01"), recording.CallID, ext)
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/storage"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// MockRecordingRepository is an in-memory RecordingRepository.
+type MockRecordingRepository struct {
+	mu         sync.Mutex
+	recordings map[uuid.UUID]*domain.Recording // by call ID
+}
+
+func NewMockRecordingRepository() *MockRecordingRepository {
+	return &MockRecordingRepository{recordings: make(map[uuid.UUID]*domain.Recording)}
+}
+
+func (m *MockRecordingRepository) Create(ctx context.Context, recording *domain.Recording) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.recordings[recording.CallID]; ok {
+		return apperrors.New(apperrors.CodeAlreadyExists, "the call already has a recording")
+	}
+	cp := *recording
+	m.recordings[recording.CallID] = &cp
+	return nil
+}
+
+func (m *MockRecordingRepository) GetByCallID(ctx context.Context, callID uuid.UUID) (*domain.Recording, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.recordings[callID]
+	if !ok {
+		return nil, apperrors.NotFound("recording")
+	}
+	cp := *r
+	return &cp, nil
+}
+
+func (m *MockRecordingRepository) Update(ctx context.Context, recording *domain.Recording) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.recordings[recording.CallID]; !ok {
+		return apperrors.NotFound("recording")
+	}
+	cp := *recording
+	m.recordings[recording.CallID] = &cp
+	return nil
+}
+
+func (m *MockRecordingRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Recording, error) {
+	return m.list(limit, func(r *domain.Recording) bool {
+		return r.Status == domain.RecordingStatusPending && !r.NextAttemptAt.After(now)
+	})
+}
+
+func (m *MockRecordingRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Recording, error) {
+	return m.list(limit, func(r *domain.Recording) bool {
+		return r.Status == domain.RecordingStatusStored && r.ExpiresAt != nil && !r.ExpiresAt.After(now)
+	})
+}
+
+func (m *MockRecordingRepository) CountStoredBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	recordings, err := m.ListStoredBefore(ctx, cutoff, 0)
+	return len(recordings), err
+}
+
+func (m *MockRecordingRepository) ListStoredBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Recording, error) {
+	return m.list(limit, func(r *domain.Recording) bool {
+		return r.Status == domain.RecordingStatusStored && r.StoredAt != nil && r.StoredAt.Before(cutoff)
+	})
+}
+
+func (m *MockRecordingRepository) list(limit int, match func(*domain.Recording) bool) ([]*domain.Recording, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*domain.Recording
+	for _, r := range m.recordings {
+		if match(r) {
+			cp := *r
+			result = append(result, &cp)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func newTestRecordingService(t *testing.T, maxSize int64) (*RecordingService, *MockRecordingRepository, *storage.LocalStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	repo := NewMockRecordingRepository()
+	svc := NewRecordingService(repo, store, zap.NewNop(), &RecordingServiceConfig{
+		Retention:       24 * time.Hour,
+		MaxSize:         maxSize,
+		PollInterval:    time.Hour,
+		CleanupInterval: time.Hour,
+		BatchSize:       10,
+	})
+	return svc, repo, store
+}
+
+func newRecordedCall(url string) *domain.Call {
+	call := domain.NewCall("bland-"+uuid.NewString(), "bland", "+15550001111", "+15552223333")
+	call.RecordingURL = &url
+	return call
+}
+
+func TestRecordingService_Archive(t *testing.T) {
+	svc, repo, _ := newTestRecordingService(t, 1<<20)
+	ctx := context.Background()
+
+	if err := svc.Archive(ctx, domain.NewCall("c1", "bland", "+1", "+2")); err != nil {
+		t.Fatalf("Archive() without recording error = %v", err)
+	}
+	if len(repo.recordings) != 0 {
+		t.Errorf("queued %d recordings for a call without one", len(repo.recordings))
+	}
+
+	call := newRecordedCall("https://provider.example.com/rec/1.mp3")
+	if err := svc.Archive(ctx, call); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	// A repeated webhook with the same URL is a no-op.
+	if err := svc.Archive(ctx, call); err != nil {
+		t.Fatalf("second Archive() error = %v", err)
+	}
+	recording, err := repo.GetByCallID(ctx, call.ID)
+	if err != nil {
+		t.Fatalf("recording not queued: %v", err)
+	}
+	if recording.Status != domain.RecordingStatusPending || recording.SourceURL != *call.RecordingURL {
+		t.Errorf("recording = %+v", recording)
+	}
+
+	// A fresh URL replaces the one still waiting to be downloaded.
+	fresh := "https://provider.example.com/rec/1.mp3?token=new"
+	call.RecordingURL = &fresh
+	if err := svc.Archive(ctx, call); err != nil {
+		t.Fatalf("Archive() with new URL error = %v", err)
+	}
+	recording, _ = repo.GetByCallID(ctx, call.ID)
+	if recording.SourceURL != fresh {
+		t.Errorf("SourceURL = %q, want %q", recording.SourceURL, fresh)
+	}
+}
+
+func TestRecordingService_DownloadAndOpen(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		io.WriteString(w, "RIFF recording")
+	}))
+	defer provider.Close()
+
+	svc, repo, _ := newTestRecordingService(t, 1<<20)
+	ctx := context.Background()
+	call := newRecordedCall(provider.URL + "/rec/1")
+	svc.Archive(ctx, call)
+
+	svc.downloadDue(time.Now())
+
+	recording, _ := repo.GetByCallID(ctx, call.ID)
+	if recording.Status != domain.RecordingStatusStored {
+		t.Fatalf("Status = %q, LastError = %v; want stored", recording.Status, recording.LastError)
+	}
+	if recording.StorageBackend != storage.BackendLocal || recording.ContentType != "audio/wav" || recording.SizeBytes != 14 {
+		t.Errorf("recording = %+v", recording)
+	}
+	if !strings.HasSuffix(recording.StorageKey, call.ID.String()+".wav") {
+		t.Errorf("StorageKey = %q", recording.StorageKey)
+	}
+	if recording.ExpiresAt == nil || recording.ExpiresAt.Sub(*recording.StoredAt) != 24*time.Hour {
+		t.Errorf("ExpiresAt = %v, want a day after storing", recording.ExpiresAt)
+	}
+
+	_, obj, err := svc.Open(ctx, call.ID)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if string(data) != "RIFF recording" || obj.ContentType != "audio/wav" {
+		t.Errorf("Open() = %q, %q", data, obj.ContentType)
+	}
+}
+
+func TestRecordingService_DownloadFailure(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/large") {
+			io.WriteString(w, strings.Repeat("x", 2048))
+			return
+		}
+		http.Error(w, "expired", http.StatusForbidden)
+	}))
+	defer provider.Close()
+
+	svc, repo, _ := newTestRecordingService(t, 1024)
+	ctx := context.Background()
+	expired := newRecordedCall(provider.URL + "/rec/expired.mp3")
+	large := newRecordedCall(provider.URL + "/rec/large")
+	svc.Archive(ctx, expired)
+	svc.Archive(ctx, large)
+
+	svc.downloadDue(time.Now())
+
+	for _, call := range []*domain.Call{expired, large} {
+		recording, _ := repo.GetByCallID(ctx, call.ID)
+		if recording.Status != domain.RecordingStatusPending || recording.Attempts != 1 || recording.LastError == nil {
+			t.Errorf("%s: Status = %q, Attempts = %d, LastError = %v; want a retry", *call.RecordingURL, recording.Status, recording.Attempts, recording.LastError)
+		}
+		if _, _, err := svc.Open(ctx, call.ID); !apperrors.IsNotFound(err) {
+			t.Errorf("Open() error = %v, want not found", err)
+		}
+	}
+}
+
+func TestRecordingService_DeleteExpired(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ID3 recording")
+	}))
+	defer provider.Close()
+
+	svc, repo, store := newTestRecordingService(t, 1<<20)
+	ctx := context.Background()
+	call := newRecordedCall(provider.URL + "/rec/1.mp3")
+	svc.Archive(ctx, call)
+	svc.downloadDue(time.Now())
+
+	// Not expired yet.
+	svc.deleteExpired(time.Now())
+	recording, _ := repo.GetByCallID(ctx, call.ID)
+	if recording.Status != domain.RecordingStatusStored {
+		t.Fatalf("Status = %q before retention ended, want stored", recording.Status)
+	}
+
+	svc.deleteExpired(time.Now().Add(25 * time.Hour))
+
+	recording, _ = repo.GetByCallID(ctx, call.ID)
+	if recording.Status != domain.RecordingStatusDeleted || recording.DeletedAt == nil {
+		t.Errorf("Status = %q, DeletedAt = %v; want deleted", recording.Status, recording.DeletedAt)
+	}
+	if _, err := store.Get(ctx, recording.StorageKey); err != storage.ErrNotFound {
+		t.Errorf("stored copy still exists: %v", err)
+	}
+	if recording, _, err := svc.Open(ctx, call.ID); !apperrors.IsNotFound(err) || recording.Status != domain.RecordingStatusDeleted {
+		t.Errorf("Open() = %v, %v; want not found with the deleted recording", recording, err)
+	}
+}
+
+func TestRecordingService_PurgeStoredBefore(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ID3 recording")
+	}))
+	defer provider.Close()
+
+	svc, repo, _ := newTestRecordingService(t, 1<<20)
+	ctx := context.Background()
+	call := newRecordedCall(provider.URL + "/rec/1.mp3")
+	svc.Archive(ctx, call)
+	svc.downloadDue(time.Now())
+
+	if n, err := svc.CountStoredBefore(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("CountStoredBefore() = %d, %v; want 0 for a fresh recording", n, err)
+	}
+
+	cutoff := time.Now().Add(time.Hour)
+	if n, err := svc.CountStoredBefore(ctx, cutoff); err != nil || n != 1 {
+		t.Fatalf("CountStoredBefore() = %d, %v; want 1", n, err)
+	}
+	if n, err := svc.PurgeStoredBefore(ctx, cutoff, 10); err != nil || n != 1 {
+		t.Fatalf("PurgeStoredBefore() = %d, %v; want 1", n, err)
+	}
+	recording, _ := repo.GetByCallID(ctx, call.ID)
+	if recording.Status != domain.RecordingStatusDeleted {
+		t.Errorf("Status = %q, want deleted", recording.Status)
+	}
+}
+
+func TestRecordingService_StartStop(t *testing.T) {
+	svc, _, _ := newTestRecordingService(t, 1<<20)
+
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := svc.Start(context.Background()); err == nil {
+		t.Error("second Start() succeeded, want error")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := svc.Stop(ctx); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+}
+
+func TestRecordingContentType(t *testing.T) {
+	tests := []struct {
+		header string
+		url    string
+		want   string
+	}{
+		{"audio/wav; charset=binary", "https://x/rec", "audio/wav"},
+		{"application/octet-stream", "https://x/rec.wav?sig=abc", "audio/wav"},
+		{"", "https://x/rec.ogg", "audio/ogg"},
+		{"", "https://x/rec", "audio/mpeg"},
+	}
+	for _, tt := range tests {
+		if got := recordingContentType(tt.header, tt.url); got != tt.want {
+			t.Errorf("recordingContentType(%q, %q) = %q, want %q", tt.header, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCallService_ProcessCallEvent_ArchivesRecording(t *testing.T) {
+	callService, _, _ := newTestCallService()
+	recordings, repo, _ := newTestRecordingService(t, 1<<20)
+	callService.SetRecordingArchiver(recordings)
+
+	call, err := callService.ProcessCallEvent(context.Background(), &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "provider-call-rec",
+		Status:         voiceprovider.CallStatusCompleted,
+		RecordingURL:   "https://provider.example.com/rec/1.mp3",
+	})
+	if err != nil {
+		t.Fatalf("ProcessCallEvent() error = %v", err)
+	}
+
+	recording, err := repo.GetByCallID(context.Background(), call.ID)
+	if err != nil {
+		t.Fatalf("recording not queued: %v", err)
+	}
+	if recording.SourceURL != "https://provider.example.com/rec/1.mp3" {
+		t.Errorf("SourceURL = %q", recording.SourceURL)
+	}
+}
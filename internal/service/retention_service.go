@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// RetentionPolicy is how long each kind of record is kept. A zero age keeps
+// that kind of record forever.
+type RetentionPolicy struct {
+	Transcripts time.Duration // Age, from call creation, at which transcripts are removed
+	Recordings  time.Duration // Age, from storage, at which recordings are deleted
+	AuditEvents time.Duration // Age at which audit events are deleted
+	BatchSize   int           // Most records removed in one statement
+	DryRun      bool          // Report what is due without removing anything
+}
+
+// Age returns how long the policy keeps an entity; 0 keeps it forever.
+func (p RetentionPolicy) Age(entity domain.RetentionEntity) time.Duration {
+	switch entity {
+	case domain.RetentionTranscripts:
+		return p.Transcripts
+	case domain.RetentionRecordings:
+		return p.Recordings
+	case domain.RetentionAuditEvents:
+		return p.AuditEvents
+	}
+	return 0
+}
+
+// RecordingRetention deletes stored recordings past their retention. It is
+// implemented by RecordingService.
+type RecordingRetention interface {
+	CountStoredBefore(ctx context.Context, cutoff time.Time) (int, error)
+	PurgeStoredBefore(ctx context.Context, cutoff time.Time, limit int) (int, error)
+}
+
+// RetentionRuleReport is what one retention rule found and removed.
+type RetentionRuleReport struct {
+	Entity      domain.RetentionEntity `json:"entity"`
+	KeepForDays int                    `json:"keep_for_days"`
+	Cutoff      time.Time              `json:"cutoff"`
+	Due         int                    `json:"due"`    // Records past the cutoff and not on legal hold
+	Purged      int                    `json:"purged"` // Records removed; always 0 in a dry run
+	Error       string                 `json:"error,omitempty"`
+}
+
+// RetentionReport is the outcome of one run of the retention rules.
+type RetentionReport struct {
+	DryRun     bool                  `json:"dry_run"`
+	StartedAt  time.Time             `json:"started_at"`
+	FinishedAt time.Time             `json:"finished_at"`
+	Rules      []RetentionRuleReport `json:"rules"`
+}
+
+// RetentionService removes transcripts, recordings and audit events once
+// they are older than their retention rule allows, and manages the legal
+// holds that exempt calls and customers from the rules.
+type RetentionService struct {
+	repo        domain.RetentionRepository
+	recordings  RecordingRetention
+	auditLogger *audit.Logger
+	policy      RetentionPolicy
+	logger      *zap.Logger
+	clock       clock.Clock
+
+	mu         sync.Mutex
+	lastReport *RetentionReport
+}
+
+// NewRetentionService creates a new RetentionService. auditLogger may be nil.
+func NewRetentionService(repo domain.RetentionRepository, auditLogger *audit.Logger, policy RetentionPolicy, logger *zap.Logger, clk clock.Clock) *RetentionService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &RetentionService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		policy:      policy,
+		logger:      logger,
+		clock:       clk,
+	}
+}
+
+// SetRecordings enables the recordings rule.
+func (s *RetentionService) SetRecordings(recordings RecordingRetention) {
+	s.recordings = recordings
+}
+
+// Policy returns the retention policy in force.
+func (s *RetentionService) Policy() RetentionPolicy {
+	return s.policy
+}
+
+// LastReport returns the report of the most recent scheduled run, or nil
+// before the first run.
+func (s *RetentionService) LastReport() *RetentionReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastReport
+}
+
+// Enforce runs every retention rule. In dry-run mode it only counts what is
+// due. A failing rule doesn't stop the others; the report records its error
+// and the first error is returned with the report.
+func (s *RetentionService) Enforce(ctx context.Context) (*RetentionReport, error) {
+	report, err := s.run(ctx, s.policy.DryRun)
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+
+	purged := make(map[string]int)
+	for _, rule := range report.Rules {
+		s.logger.Info("retention rule applied",
+			zap.String("entity", string(rule.Entity)),
+			zap.Bool("dry_run", report.DryRun),
+			zap.Time("cutoff", rule.Cutoff),
+			zap.Int("due", rule.Due),
+			zap.Int("purged", rule.Purged),
+			zap.String("error", rule.Error),
+		)
+		if rule.Purged > 0 {
+			purged[string(rule.Entity)] = rule.Purged
+		}
+	}
+	if len(purged) > 0 && s.auditLogger != nil {
+		s.auditLogger.RetentionEnforced(ctx, purged)
+	}
+	return report, err
+}
+
+// Preview reports what each retention rule would remove now, without
+// removing anything.
+func (s *RetentionService) Preview(ctx context.Context) (*RetentionReport, error) {
+	return s.run(ctx, true)
+}
+
+func (s *RetentionService) run(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	now := s.clock.Now().UTC()
+	report := &RetentionReport{DryRun: dryRun, StartedAt: now}
+
+	var firstErr error
+	for _, entity := range domain.RetentionEntities {
+		age := s.policy.Age(entity)
+		count, purge := s.target(entity)
+		if age <= 0 || count == nil {
+			continue
+		}
+
+		rule := RetentionRuleReport{
+			Entity:      entity,
+			KeepForDays: int(age / (24 * time.Hour)),
+			Cutoff:      now.Add(-age),
+		}
+		err := s.apply(ctx, &rule, dryRun, count, purge)
+		if err != nil {
+			rule.Error = err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to apply %s retention: %w", entity, err)
+			}
+		}
+		report.Rules = append(report.Rules, rule)
+	}
+
+	report.FinishedAt = s.clock.Now().UTC()
+	return report, firstErr
+}
+
+// apply counts the records due under a rule and, unless dryRun, removes
+// them a batch at a time.
+func (s *RetentionService) apply(
+	ctx context.Context,
+	rule *RetentionRuleReport,
+	dryRun bool,
+	count func(context.Context, time.Time) (int, error),
+	purge func(context.Context, time.Time, int) (int, error),
+) error {
+	due, err := count(ctx, rule.Cutoff)
+	if err != nil {
+		return err
+	}
+	rule.Due = due
+	if dryRun || due == 0 || s.policy.BatchSize <= 0 {
+		return nil
+	}
+
+	for {
+		n, err := purge(ctx, rule.Cutoff, s.policy.BatchSize)
+		rule.Purged += n
+		if err != nil {
+			return err
+		}
+		if n < s.policy.BatchSize {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// target returns the count and purge functions for an entity, or nils when
+// the entity's rule can't run.
+func (s *RetentionService) target(entity domain.RetentionEntity) (
+	func(context.Context, time.Time) (int, error),
+	func(context.Context, time.Time, int) (int, error),
+) {
+	switch entity {
+	case domain.RetentionTranscripts:
+		return s.repo.CountTranscriptsBefore, s.repo.PurgeTranscriptsBefore
+	case domain.RetentionRecordings:
+		if s.recordings != nil {
+			return s.recordings.CountStoredBefore, s.recordings.PurgeStoredBefore
+		}
+	case domain.RetentionAuditEvents:
+		return s.repo.CountAuditEventsBefore, s.repo.PurgeAuditEventsBefore
+	}
+	return nil, nil
+}
+
+// ListLegalHolds returns the calls and customers on legal hold.
+func (s *RetentionService) ListLegalHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	holds, err := s.repo.ListLegalHolds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if holds == nil {
+		holds = []*domain.LegalHold{}
+	}
+	return holds, nil
+}
+
+// PlaceLegalHold exempts a call, or every call of a customer, from the
+// retention rules until the hold is released. Placing a hold on a record
+// already held updates the reason.
+func (s *RetentionService) PlaceLegalHold(ctx context.Context, target domain.LegalHoldTarget, id uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return apperrors.ValidationFailed("a legal hold needs a reason")
+	}
+	if len(reason) > domain.MaxLegalHoldReasonLen {
+		return apperrors.ValidationFailed(fmt.Sprintf("reason must be at most %d characters", domain.MaxLegalHoldReasonLen))
+	}
+	if err := s.repo.SetLegalHold(ctx, target, id, &reason); err != nil {
+		return err
+	}
+	s.logger.Info("legal hold placed", zap.String("target", string(target)), zap.String("id", id.String()))
+	return nil
+}
+
+// ReleaseLegalHold releases a legal hold, returning the call or customer to
+// the retention rules.
+func (s *RetentionService) ReleaseLegalHold(ctx context.Context, target domain.LegalHoldTarget, id uuid.UUID) error {
+	if err := s.repo.SetLegalHold(ctx, target, id, nil); err != nil {
+		return err
+	}
+	s.logger.Info("legal hold released", zap.String("target", string(target)), zap.String("id", id.String()))
+	return nil
+}
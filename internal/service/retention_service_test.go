@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubRetentionRepo struct {
+	transcripts int // Transcripts past their retention
+	auditEvents int
+	auditErr    error
+	cutoffs     map[string]time.Time
+	holds       map[uuid.UUID]string
+}
+
+func newStubRetentionRepo() *stubRetentionRepo {
+	return &stubRetentionRepo{cutoffs: map[string]time.Time{}, holds: map[uuid.UUID]string{}}
+}
+
+func (r *stubRetentionRepo) SetLegalHold(ctx context.Context, target domain.LegalHoldTarget, id uuid.UUID, reason *string) error {
+	if reason == nil {
+		delete(r.holds, id)
+		return nil
+	}
+	r.holds[id] = *reason
+	return nil
+}
+
+func (r *stubRetentionRepo) ListLegalHolds(ctx context.Context) ([]*domain.LegalHold, error) {
+	return nil, nil
+}
+
+func (r *stubRetentionRepo) CountTranscriptsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	r.cutoffs["transcripts"] = cutoff
+	return r.transcripts, nil
+}
+
+func (r *stubRetentionRepo) PurgeTranscriptsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	n := min(limit, r.transcripts)
+	r.transcripts -= n
+	return n, nil
+}
+
+func (r *stubRetentionRepo) CountAuditEventsBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	r.cutoffs["audit_events"] = cutoff
+	return r.auditEvents, nil
+}
+
+func (r *stubRetentionRepo) PurgeAuditEventsBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	if r.auditErr != nil {
+		return 0, r.auditErr
+	}
+	n := min(limit, r.auditEvents)
+	r.auditEvents -= n
+	return n, nil
+}
+
+func newTestRetentionService(repo *stubRetentionRepo, dryRun bool) (*RetentionService, time.Time) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	svc := NewRetentionService(repo, nil, RetentionPolicy{
+		Transcripts: 2 * 365 * 24 * time.Hour,
+		AuditEvents: 7 * 365 * 24 * time.Hour,
+		BatchSize:   10,
+		DryRun:      dryRun,
+	}, zap.NewNop(), clock.NewMock(now))
+	return svc, now
+}
+
+func TestRetentionService_Enforce(t *testing.T) {
+	repo := newStubRetentionRepo()
+	repo.transcripts = 25
+	repo.auditEvents = 3
+	svc, now := newTestRetentionService(repo, false)
+
+	report, err := svc.Enforce(context.Background())
+	if err != nil {
+		t.Fatalf("Enforce() error = %v", err)
+	}
+	if report.DryRun {
+		t.Error("DryRun = true, want false")
+	}
+	// The recordings rule is skipped without a recording service.
+	if len(report.Rules) != 2 {
+		t.Fatalf("len(Rules) = %d, want 2", len(report.Rules))
+	}
+	transcripts := report.Rules[0]
+	if transcripts.Entity != domain.RetentionTranscripts || transcripts.Due != 25 || transcripts.Purged != 25 {
+		t.Errorf("transcripts = %+v, want 25 due and purged", transcripts)
+	}
+	if transcripts.KeepForDays != 730 {
+		t.Errorf("KeepForDays = %d, want 730", transcripts.KeepForDays)
+	}
+	if want := now.Add(-2 * 365 * 24 * time.Hour); !repo.cutoffs["transcripts"].Equal(want) {
+		t.Errorf("transcript cutoff = %v, want %v", repo.cutoffs["transcripts"], want)
+	}
+	if repo.transcripts != 0 || repo.auditEvents != 0 {
+		t.Errorf("left %d transcripts and %d audit events", repo.transcripts, repo.auditEvents)
+	}
+	if svc.LastReport() != report {
+		t.Error("LastReport() is not the report of the run")
+	}
+}
+
+func TestRetentionService_EnforceDryRun(t *testing.T) {
+	repo := newStubRetentionRepo()
+	repo.transcripts = 5
+	svc, _ := newTestRetentionService(repo, true)
+
+	report, err := svc.Enforce(context.Background())
+	if err != nil {
+		t.Fatalf("Enforce() error = %v", err)
+	}
+	if !report.DryRun || report.Rules[0].Due != 5 || report.Rules[0].Purged != 0 {
+		t.Errorf("report = %+v, want 5 due and nothing purged", report)
+	}
+	if repo.transcripts != 5 {
+		t.Errorf("dry run removed %d transcripts", 5-repo.transcripts)
+	}
+}
+
+func TestRetentionService_EnforceRuleFailure(t *testing.T) {
+	repo := newStubRetentionRepo()
+	repo.transcripts = 5
+	repo.auditEvents = 5
+	repo.auditErr = errors.New("connection reset")
+	svc, _ := newTestRetentionService(repo, false)
+
+	report, err := svc.Enforce(context.Background())
+	if err == nil {
+		t.Fatal("Enforce() error = nil, want the audit event failure")
+	}
+	if report.Rules[0].Purged != 5 {
+		t.Errorf("transcripts purged = %d, want 5 despite the audit failure", report.Rules[0].Purged)
+	}
+	if report.Rules[1].Error == "" {
+		t.Error("audit event rule has no error")
+	}
+}
+
+func TestRetentionService_PlaceLegalHold(t *testing.T) {
+	repo := newStubRetentionRepo()
+	svc, _ := newTestRetentionService(repo, false)
+	ctx := context.Background()
+	id := uuid.New()
+
+	if err := svc.PlaceLegalHold(ctx, domain.LegalHoldCustomer, id, "  "); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("PlaceLegalHold() without a reason error = %v, want validation failure", err)
+	}
+	if err := svc.PlaceLegalHold(ctx, domain.LegalHoldCustomer, id, " Litigation 2026-114 "); err != nil {
+		t.Fatalf("PlaceLegalHold() error = %v", err)
+	}
+	if repo.holds[id] != "Litigation 2026-114" {
+		t.Errorf("reason = %q, want trimmed", repo.holds[id])
+	}
+	if err := svc.ReleaseLegalHold(ctx, domain.LegalHoldCustomer, id); err != nil {
+		t.Fatalf("ReleaseLegalHold() error = %v", err)
+	}
+	if _, held := repo.holds[id]; held {
+		t.Error("hold still in place after release")
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// routedCallTTL is how long a routed call is remembered, so later webhooks
+// for it don't route it again.
+const routedCallTTL = 2 * time.Hour
+
+// RoutingService manages inbound routing rules and applies them to calls as
+// their webhooks arrive. The agent on a number is set before anyone calls,
+// so a regional prompt's instructions are injected into the call once the
+// caller is known; a transfer hands the call to regional staff.
+type RoutingService struct {
+	rules      domain.RoutingRuleRepository
+	prompts    domain.PromptRepository
+	controller CallController
+	logger     *zap.Logger
+
+	clock clock.Clock
+
+	// mu guards routed, the calls already routed and when.
+	mu     sync.Mutex
+	routed map[string]time.Time
+}
+
+// NewRoutingService creates a new RoutingService.
+func NewRoutingService(rules domain.RoutingRuleRepository, prompts domain.PromptRepository, controller CallController, logger *zap.Logger, clk clock.Clock) *RoutingService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &RoutingService{
+		rules:      rules,
+		prompts:    prompts,
+		controller: controller,
+		logger:     logger,
+		clock:      clk,
+		routed:     make(map[string]time.Time),
+	}
+}
+
+// RoutingRuleRequest holds the fields for creating or replacing a routing
+// rule.
+type RoutingRuleRequest struct {
+	Name           string     `json:"name"`
+	Priority       int        `json:"priority"`
+	AreaCodes      []string   `json:"area_codes"`
+	Regions        []string   `json:"regions"`
+	PromptID       *uuid.UUID `json:"prompt_id,omitempty"`
+	TransferNumber string     `json:"transfer_number,omitempty"`
+	IsActive       bool       `json:"is_active"`
+}
+
+// ListRules returns all routing rules in the order they are checked.
+func (s *RoutingService) ListRules(ctx context.Context) ([]*domain.RoutingRule, error) {
+	return s.rules.List(ctx)
+}
+
+// GetRule retrieves a routing rule by ID.
+func (s *RoutingService) GetRule(ctx context.Context, id uuid.UUID) (*domain.RoutingRule, error) {
+	return s.rules.GetByID(ctx, id)
+}
+
+// CreateRule validates and stores a new routing rule.
+func (s *RoutingService) CreateRule(ctx context.Context, req *RoutingRuleRequest) (*domain.RoutingRule, error) {
+	rule := domain.NewRoutingRule(req.Name)
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.rules.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("routing rule created",
+		zap.String("rule_id", rule.ID.String()),
+		zap.String("name", rule.Name),
+	)
+	return rule, nil
+}
+
+// UpdateRule replaces the fields of a routing rule.
+func (s *RoutingService) UpdateRule(ctx context.Context, id uuid.UUID, req *RoutingRuleRequest) (*domain.RoutingRule, error) {
+	rule, err := s.rules.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = time.Now().UTC()
+
+	if err := s.rules.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("routing rule updated", zap.String("rule_id", rule.ID.String()))
+	return rule, nil
+}
+
+// DeleteRule removes a routing rule.
+func (s *RoutingService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if err := s.rules.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.Info("routing rule deleted", zap.String("rule_id", id.String()))
+	return nil
+}
+
+// applyRequest copies a request onto a rule and validates it.
+func (s *RoutingService) applyRequest(ctx context.Context, rule *domain.RoutingRule, req *RoutingRuleRequest) error {
+	rule.Name = req.Name
+	rule.Priority = req.Priority
+	rule.AreaCodes = req.AreaCodes
+	rule.Regions = req.Regions
+	rule.PromptID = req.PromptID
+	rule.TransferNumber = strings.TrimSpace(req.TransferNumber)
+	rule.IsActive = req.IsActive
+	if rule.TransferNumber != "" {
+		normalized := normalizePhoneNumber(rule.TransferNumber)
+		if normalized == "" {
+			return apperrors.ValidationFailed("transfer_number must be a valid phone number")
+		}
+		rule.TransferNumber = normalized
+	}
+	if err := rule.Validate(); err != nil {
+		return apperrors.ValidationFailed(err.Error())
+	}
+
+	if rule.PromptID != nil {
+		if _, err := s.prompts.GetByID(ctx, *rule.PromptID); err != nil {
+			if apperrors.IsNotFound(err) {
+				return apperrors.ValidationFailed(fmt.Sprintf("prompt %s not found", rule.PromptID))
+			}
+			return fmt.Errorf("failed to get prompt: %w", err)
+		}
+	}
+	return nil
+}
+
+// Match returns the first active rule, by priority, that applies to calls
+// from phoneNumber, or nil if none does.
+func (s *RoutingService) Match(ctx context.Context, phoneNumber string) (*domain.RoutingRule, error) {
+	rules, err := s.rules.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routing rules: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.IsActive && rule.Matches(phoneNumber) {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
+
+// Route applies the matching rule to an inbound Bland call still in
+// progress, once per call. It returns the rule applied, or nil.
+func (s *RoutingService) Route(ctx context.Context, call *domain.Call) (*domain.RoutingRule, error) {
+	if !call.IsInbound() || call.IsComplete() || call.Provider != string(voiceprovider.ProviderBland) {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	cutoff := s.clock.Now().Add(-routedCallTTL)
+	for id, at := range s.routed {
+		if at.Before(cutoff) {
+			delete(s.routed, id)
+		}
+	}
+	_, done := s.routed[call.ProviderCallID]
+	s.routed[call.ProviderCallID] = s.clock.Now()
+	s.mu.Unlock()
+	if done {
+		return nil, nil
+	}
+
+	rule, err := s.Match(ctx, call.FromNumber)
+	if err != nil || rule == nil {
+		return nil, err
+	}
+
+	if rule.TransferNumber != "" {
+		if err := s.controller.TransferCall(ctx, call.ProviderCallID, rule.TransferNumber); err != nil {
+			return nil, fmt.Errorf("failed to transfer call: %w", err)
+		}
+	} else if rule.PromptID != nil {
+		prompt, err := s.prompts.GetByID(ctx, *rule.PromptID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get routing prompt: %w", err)
+		}
+		prompt = prompt.Localize(domain.LanguageForNumber(call.PhoneNumber))
+		message := fmt.Sprintf("This caller is calling from the %s area. For the rest of the call, follow these instructions instead of your own:\n\n%s", rule.Name, prompt.Task)
+		if err := s.controller.SendCallMessage(ctx, call.ProviderCallID, message); err != nil {
+			return nil, fmt.Errorf("failed to send routing instructions: %w", err)
+		}
+	}
+
+	s.logger.Info("inbound call routed",
+		zap.String("provider_call_id", call.ProviderCallID),
+		zap.String("rule_id", rule.ID.String()),
+		zap.String("rule", rule.Name),
+	)
+	return rule, nil
+}
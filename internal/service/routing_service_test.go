@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// MockRoutingRuleRepository is an in-memory RoutingRuleRepository.
+type MockRoutingRuleRepository struct {
+	rules map[uuid.UUID]*domain.RoutingRule
+}
+
+func NewMockRoutingRuleRepository() *MockRoutingRuleRepository {
+	return &MockRoutingRuleRepository{rules: make(map[uuid.UUID]*domain.RoutingRule)}
+}
+
+func (m *MockRoutingRuleRepository) Create(ctx context.Context, rule *domain.RoutingRule) error {
+	cp := *rule
+	m.rules[rule.ID] = &cp
+	return nil
+}
+
+func (m *MockRoutingRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.RoutingRule, error) {
+	rule, ok := m.rules[id]
+	if !ok {
+		return nil, apperrors.NotFound("routing rule")
+	}
+	cp := *rule
+	return &cp, nil
+}
+
+func (m *MockRoutingRuleRepository) Update(ctx context.Context, rule *domain.RoutingRule) error {
+	if _, ok := m.rules[rule.ID]; !ok {
+		return apperrors.NotFound("routing rule")
+	}
+	cp := *rule
+	m.rules[rule.ID] = &cp
+	return nil
+}
+
+func (m *MockRoutingRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := m.rules[id]; !ok {
+		return apperrors.NotFound("routing rule")
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *MockRoutingRuleRepository) List(ctx context.Context) ([]*domain.RoutingRule, error) {
+	var rules []*domain.RoutingRule
+	for _, rule := range m.rules {
+		cp := *rule
+		rules = append(rules, &cp)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].Name < rules[j].Name
+	})
+	return rules, nil
+}
+
+func TestRoutingService_Route(t *testing.T) {
+	texas := domain.NewPrompt("Texas pricing", "Quote Texas rates, which include travel.")
+	controller := newFakeCallController()
+	svc := NewRoutingService(NewMockRoutingRuleRepository(), NewMockPromptRepository(texas), controller, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if _, err := svc.CreateRule(ctx, &RoutingRuleRequest{
+		Name:     "Texas",
+		Priority: 10,
+		Regions:  []string{"tx"},
+		PromptID: &texas.ID,
+		IsActive: true,
+	}); err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+	if _, err := svc.CreateRule(ctx, &RoutingRuleRequest{
+		Name:           "Dallas office",
+		Priority:       1,
+		AreaCodes:      []string{"214"},
+		TransferNumber: "(555) 222-3333",
+		IsActive:       true,
+	}); err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+
+	call := domain.NewCall("call-1", "bland", "+15550000000", "+12145551234")
+	call.Status = domain.CallStatusInProgress
+	rule, err := svc.Route(ctx, call)
+	if err != nil || rule == nil || rule.Name != "Dallas office" {
+		t.Fatalf("expected the higher priority rule, got %+v, %v", rule, err)
+	}
+	if controller.transferred["call-1"] != "+15552223333" {
+		t.Errorf("expected the call transferred, got %v", controller.transferred)
+	}
+	if rule, _ := svc.Route(ctx, call); rule != nil {
+		t.Error("expected a call to be routed only once")
+	}
+
+	call = domain.NewCall("call-2", "bland", "+15550000000", "+15125551234")
+	call.Status = domain.CallStatusInProgress
+	if rule, err := svc.Route(ctx, call); err != nil || rule == nil || rule.Name != "Texas" {
+		t.Fatalf("expected the state rule, got %+v, %v", rule, err)
+	}
+	if controller.messages["call-2"] == "" {
+		t.Error("expected the regional instructions sent to the agent")
+	}
+
+	call = domain.NewCall("call-3", "bland", "+15550000000", "+12125551234")
+	call.Status = domain.CallStatusInProgress
+	if rule, _ := svc.Route(ctx, call); rule != nil {
+		t.Errorf("expected no rule for a New York caller, got %s", rule.Name)
+	}
+}
+
+func TestRoutingService_CreateRuleValidation(t *testing.T) {
+	svc := NewRoutingService(NewMockRoutingRuleRepository(), NewMockPromptRepository(), newFakeCallController(), zap.NewNop(), nil)
+	missing := uuid.New()
+
+	_, err := svc.CreateRule(context.Background(), &RoutingRuleRequest{Name: "West", Regions: []string{"CA"}, PromptID: &missing})
+	if !apperrors.IsUserError(err) {
+		t.Errorf("expected a validation error for a missing prompt, got %v", err)
+	}
+	_, err = svc.CreateRule(context.Background(), &RoutingRuleRequest{Name: "West", Regions: []string{"CA"}, TransferNumber: "nope"})
+	if !apperrors.IsUserError(err) {
+		t.Errorf("expected a validation error for a bad transfer number, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// SavedViewService manages users' saved calls list views and the default
+// view each user's calls list opens with. Users only see their own views.
+type SavedViewService struct {
+	repo   domain.SavedViewRepository
+	logger *zap.Logger
+
+	// Configuration
+	maxPerUser int
+}
+
+// SavedViewServiceConfig holds configuration for the saved view service.
+type SavedViewServiceConfig struct {
+	MaxPerUser int // Most views one user can save; 0 is unlimited
+}
+
+// DefaultSavedViewServiceConfig returns sensible defaults.
+func DefaultSavedViewServiceConfig() *SavedViewServiceConfig {
+	return &SavedViewServiceConfig{
+		MaxPerUser: 50,
+	}
+}
+
+// NewSavedViewService creates a new SavedViewService.
+func NewSavedViewService(repo domain.SavedViewRepository, logger *zap.Logger, config *SavedViewServiceConfig) *SavedViewService {
+	if config == nil {
+		config = DefaultSavedViewServiceConfig()
+	}
+	return &SavedViewService{
+		repo:       repo,
+		logger:     logger,
+		maxPerUser: config.MaxPerUser,
+	}
+}
+
+// SavedViewRequest holds the fields for saving a view.
+type SavedViewRequest struct {
+	Name      string            `json:"name"`
+	Filters   map[string]string `json:"filters"`
+	Sort      domain.CallSort   `json:"sort"`
+	Columns   []string          `json:"columns"`
+	IsDefault bool              `json:"is_default"` // Make it the user's default; only read when creating
+}
+
+// List returns the user's views, ordered by name.
+func (s *SavedViewService) List(ctx context.Context, userID uuid.UUID) ([]*domain.SavedView, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Get returns one of the user's views.
+func (s *SavedViewService) Get(ctx context.Context, userID, id uuid.UUID) (*domain.SavedView, error) {
+	view, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' views are private
+	if view.UserID != userID {
+		return nil, apperrors.NotFound("saved view")
+	}
+	return view, nil
+}
+
+// Default returns the user's default view, or nil if they haven't chosen
+// one.
+func (s *SavedViewService) Default(ctx context.Context, userID uuid.UUID) (*domain.SavedView, error) {
+	view, err := s.repo.GetDefault(ctx, userID)
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return view, err
+}
+
+// Create saves a view for the user, making it their default if asked.
+func (s *SavedViewService) Create(ctx context.Context, userID uuid.UUID, req *SavedViewRequest) (*domain.SavedView, error) {
+	view := domain.NewSavedView(userID, req.Name)
+	applySavedViewRequest(view, req)
+	if err := view.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if s.maxPerUser > 0 {
+		count, err := s.repo.CountByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= s.maxPerUser {
+			return nil, apperrors.ValidationFailed(fmt.Sprintf("you can save at most %d views; delete one first", s.maxPerUser))
+		}
+	}
+
+	if err := s.repo.Create(ctx, view); err != nil {
+		return nil, err
+	}
+	if req.IsDefault {
+		if err := s.repo.SetDefault(ctx, userID, &view.ID); err != nil {
+			return nil, err
+		}
+		view.IsDefault = true
+	}
+
+	s.logger.Debug("saved view created", zap.String("view_id", view.ID.String()), zap.String("user_id", userID.String()))
+	return view, nil
+}
+
+// Update replaces the name, filters, sort and columns of one of the user's
+// views. Whether it is the default is unchanged.
+func (s *SavedViewService) Update(ctx context.Context, userID, id uuid.UUID, req *SavedViewRequest) (*domain.SavedView, error) {
+	view, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	view.Name = req.Name
+	applySavedViewRequest(view, req)
+	if err := view.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	view.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// Delete deletes one of the user's views. Deleting the default leaves the
+// user without one.
+func (s *SavedViewService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// SetDefault makes one of the user's views their default, replacing any
+// other.
+func (s *SavedViewService) SetDefault(ctx context.Context, userID, id uuid.UUID) (*domain.SavedView, error) {
+	view, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetDefault(ctx, userID, &id); err != nil {
+		return nil, err
+	}
+	view.IsDefault = true
+	return view, nil
+}
+
+// ClearDefault stops one of the user's views being their default, so the
+// calls list opens unfiltered.
+func (s *SavedViewService) ClearDefault(ctx context.Context, userID, id uuid.UUID) (*domain.SavedView, error) {
+	view, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if view.IsDefault {
+		if err := s.repo.SetDefault(ctx, userID, nil); err != nil {
+			return nil, err
+		}
+		view.IsDefault = false
+	}
+	return view, nil
+}
+
+func applySavedViewRequest(view *domain.SavedView, req *SavedViewRequest) {
+	view.Filters = req.Filters
+	view.Sort = req.Sort
+	view.Columns = req.Columns
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubSavedViewRepo struct {
+	views map[uuid.UUID]*domain.SavedView
+}
+
+func newStubSavedViewRepo() *stubSavedViewRepo {
+	return &stubSavedViewRepo{views: map[uuid.UUID]*domain.SavedView{}}
+}
+
+func (r *stubSavedViewRepo) Create(ctx context.Context, view *domain.SavedView) error {
+	for _, v := range r.views {
+		if v.UserID == view.UserID && v.Name == view.Name {
+			return apperrors.New(apperrors.CodeAlreadyExists, "you already have a view with this name")
+		}
+	}
+	stored := *view
+	r.views[view.ID] = &stored
+	return nil
+}
+
+func (r *stubSavedViewRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.SavedView, error) {
+	view, ok := r.views[id]
+	if !ok {
+		return nil, apperrors.NotFound("saved view")
+	}
+	found := *view
+	return &found, nil
+}
+
+func (r *stubSavedViewRepo) Update(ctx context.Context, view *domain.SavedView) error {
+	stored, ok := r.views[view.ID]
+	if !ok {
+		return apperrors.NotFound("saved view")
+	}
+	stored.Name, stored.Filters, stored.Sort, stored.Columns = view.Name, view.Filters, view.Sort, view.Columns
+	return nil
+}
+
+func (r *stubSavedViewRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.views[id]; !ok {
+		return apperrors.NotFound("saved view")
+	}
+	delete(r.views, id)
+	return nil
+}
+
+func (r *stubSavedViewRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.SavedView, error) {
+	var views []*domain.SavedView
+	for _, view := range r.views {
+		if view.UserID == userID {
+			listed := *view
+			views = append(views, &listed)
+		}
+	}
+	return views, nil
+}
+
+func (r *stubSavedViewRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	views, _ := r.ListByUser(ctx, userID)
+	return len(views), nil
+}
+
+func (r *stubSavedViewRepo) GetDefault(ctx context.Context, userID uuid.UUID) (*domain.SavedView, error) {
+	for _, view := range r.views {
+		if view.UserID == userID && view.IsDefault {
+			found := *view
+			return &found, nil
+		}
+	}
+	return nil, apperrors.NotFound("saved view")
+}
+
+func (r *stubSavedViewRepo) SetDefault(ctx context.Context, userID uuid.UUID, id *uuid.UUID) error {
+	for _, view := range r.views {
+		if view.UserID == userID {
+			view.IsDefault = id != nil && view.ID == *id
+		}
+	}
+	return nil
+}
+
+func TestSavedViewService_CreateAndDefault(t *testing.T) {
+	repo := newStubSavedViewRepo()
+	svc := NewSavedViewService(repo, zap.NewNop(), &SavedViewServiceConfig{MaxPerUser: 2})
+	ctx := context.Background()
+	userID := uuid.New()
+
+	if view, err := svc.Default(ctx, userID); err != nil || view != nil {
+		t.Errorf("Default() = %v, %v, want none", view, err)
+	}
+
+	open, err := svc.Create(ctx, userID, &SavedViewRequest{
+		Name:      "Open leads",
+		Filters:   map[string]string{"intent": "new_project"},
+		IsDefault: true,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !open.IsDefault || open.Sort != domain.CallSortNewest {
+		t.Errorf("Create() = %+v, want the default, newest first", open)
+	}
+
+	spam, err := svc.Create(ctx, userID, &SavedViewRequest{Name: "Spam", Filters: map[string]string{"spam": "true"}, IsDefault: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if view, _ := svc.Default(ctx, userID); view == nil || view.ID != spam.ID {
+		t.Errorf("Default() = %v, want the latest default", view)
+	}
+	if repo.views[open.ID].IsDefault {
+		t.Error("the earlier view is still the default")
+	}
+
+	if _, err := svc.Create(ctx, userID, &SavedViewRequest{Name: "Third"}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("Create() over the limit error = %v, want validation failure", err)
+	}
+	if _, err := svc.Create(ctx, uuid.New(), &SavedViewRequest{Name: "Bad", Filters: map[string]string{"page": "2"}}); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("Create() with an unknown filter error = %v, want validation failure", err)
+	}
+
+	if _, err := svc.SetDefault(ctx, userID, open.ID); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if view, _ := svc.Default(ctx, userID); view == nil || view.ID != open.ID {
+		t.Errorf("Default() = %v, want the view made default", view)
+	}
+
+	// Clearing a view that isn't the default leaves the default alone
+	if _, err := svc.ClearDefault(ctx, userID, spam.ID); err != nil {
+		t.Fatalf("ClearDefault() error = %v", err)
+	}
+	if view, _ := svc.Default(ctx, userID); view == nil || view.ID != open.ID {
+		t.Errorf("Default() = %v, want it unchanged", view)
+	}
+	if _, err := svc.ClearDefault(ctx, userID, open.ID); err != nil {
+		t.Fatalf("ClearDefault() error = %v", err)
+	}
+	if view, _ := svc.Default(ctx, userID); view != nil {
+		t.Errorf("Default() = %v, want none", view)
+	}
+}
+
+func TestSavedViewService_OtherUsersViews(t *testing.T) {
+	repo := newStubSavedViewRepo()
+	svc := NewSavedViewService(repo, zap.NewNop(), nil)
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	view, err := svc.Create(ctx, owner, &SavedViewRequest{Name: "Mine", Columns: []string{"date", "caller"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := svc.Get(ctx, other, view.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("Get() of another user's view error = %v, want not found", err)
+	}
+	if _, err := svc.Update(ctx, other, view.ID, &SavedViewRequest{Name: "Theirs"}); !apperrors.IsNotFound(err) {
+		t.Errorf("Update() of another user's view error = %v, want not found", err)
+	}
+	if _, err := svc.SetDefault(ctx, other, view.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("SetDefault() of another user's view error = %v, want not found", err)
+	}
+	if err := svc.Delete(ctx, other, view.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("Delete() of another user's view error = %v, want not found", err)
+	}
+
+	updated, err := svc.Update(ctx, owner, view.ID, &SavedViewRequest{Name: "Renamed", Sort: domain.CallSortOldest})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Name != "Renamed" || updated.Sort != domain.CallSortOldest || len(updated.Columns) != 0 {
+		t.Errorf("Update() = %+v, want the view replaced", updated)
+	}
+	if err := svc.Delete(ctx, owner, view.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// scheduleCheckInterval is how often the schedule worker checks whether
+// business hours have started or ended.
+const scheduleCheckInterval = time.Minute
+
+// afterHoursVoicemailTask is the task of the agent that takes messages
+// outside business hours.
+const afterHoursVoicemailTask = `You are answering the phone for a business that is closed right now.
+
+Tell the caller the office is closed and offer to take a message. Ask for their name, the best number to reach them on, and a short description of what they need. Repeat the number back to confirm it.
+
+Do not quote prices, book appointments or answer detailed questions; say someone will call them back during business hours. Once you have the message, thank the caller and end the call.`
+
+// afterHoursVoicemailGreeting is the voicemail agent's first sentence when
+// the schedule doesn't set one.
+const afterHoursVoicemailGreeting = "Thank you for calling. We're closed right now, but I can take a message and have someone call you back when we open."
+
+// BusinessScheduleStore loads and saves the business schedule.
+// SettingsService implements it.
+type BusinessScheduleStore interface {
+	GetBusinessSchedule(ctx context.Context) (*domain.BusinessSchedule, error)
+	SaveBusinessSchedule(ctx context.Context, schedule *domain.BusinessSchedule) error
+}
+
+// InboundSettingsAgent builds the inbound agent from the call settings.
+// BlandService implements it.
+type InboundSettingsAgent interface {
+	GetInboundConfig(ctx context.Context) (*bland.InboundConfig, error)
+}
+
+// ScheduleService switches inbound numbers between their business hours
+// agent and an after hours one: a voicemail-only agent, another prompt, or
+// the same agent with a different first sentence. A worker checks the
+// schedule every minute and reconfigures a number only when its agent
+// should change.
+type ScheduleService struct {
+	store   BusinessScheduleStore
+	agent   InboundSettingsAgent
+	inbound InboundAgentConfigurer
+	prompts domain.PromptRepository
+	logger  *zap.Logger
+
+	clock clock.Clock
+
+	// applyMu serializes passes; applied maps each number switched to the
+	// state it was last configured for.
+	applyMu sync.Mutex
+	applied map[string]string
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// NewScheduleService creates a new ScheduleService.
+func NewScheduleService(
+	store BusinessScheduleStore,
+	agent InboundSettingsAgent,
+	inbound InboundAgentConfigurer,
+	prompts domain.PromptRepository,
+	logger *zap.Logger,
+	clk clock.Clock,
+) *ScheduleService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &ScheduleService{
+		store:   store,
+		agent:   agent,
+		inbound: inbound,
+		prompts: prompts,
+		logger:  logger,
+		clock:   clk,
+		applied: make(map[string]string),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// BusinessScheduleStatus is the schedule and whether the business is open.
+type BusinessScheduleStatus struct {
+	*domain.BusinessSchedule
+	Open    bool            `json:"open"`
+	Holiday *domain.Holiday `json:"holiday,omitempty"` // Today's holiday, if any
+}
+
+// Status returns the schedule and whether the business is open now.
+func (s *ScheduleService) Status(ctx context.Context) (*BusinessScheduleStatus, error) {
+	schedule, err := s.store.GetBusinessSchedule(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business schedule: %w", err)
+	}
+	return s.status(schedule), nil
+}
+
+func (s *ScheduleService) status(schedule *domain.BusinessSchedule) *BusinessScheduleStatus {
+	now := s.clock.Now()
+	return &BusinessScheduleStatus{
+		BusinessSchedule: schedule,
+		Open:             schedule.IsOpen(now),
+		Holiday:          schedule.HolidayOn(now),
+	}
+}
+
+// SaveSchedule validates and stores the schedule, then switches its
+// numbers to the agent for the current time straight away.
+func (s *ScheduleService) SaveSchedule(ctx context.Context, schedule *domain.BusinessSchedule) (*BusinessScheduleStatus, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	if err := s.checkPrompt(ctx, "business hours", schedule.PromptID); err != nil {
+		return nil, err
+	}
+	if err := s.checkPrompt(ctx, "after hours", schedule.AfterHours.PromptID); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveBusinessSchedule(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to save business schedule: %w", err)
+	}
+	s.logger.Info("business schedule saved",
+		zap.Bool("enabled", schedule.Enabled),
+		zap.Int("phone_numbers", len(schedule.PhoneNumbers)),
+		zap.Int("holidays", len(schedule.Holidays)),
+	)
+
+	if err := s.Apply(ctx); err != nil {
+		// The worker tries again on its next check
+		s.logger.Warn("failed to apply business schedule", zap.Error(err))
+	}
+	return s.status(schedule), nil
+}
+
+// checkPrompt checks that a prompt the schedule answers with exists.
+func (s *ScheduleService) checkPrompt(ctx context.Context, name string, id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	if _, err := s.prompts.GetByID(ctx, *id); err != nil {
+		if apperrors.IsNotFound(err) {
+			return apperrors.ValidationFailed(fmt.Sprintf("%s prompt %s not found", name, id))
+		}
+		return fmt.Errorf("failed to get prompt: %w", err)
+	}
+	return nil
+}
+
+// Apply configures every scheduled number whose agent should change: to
+// the after hours agent when business hours end, and back when they start.
+// Numbers taken off the schedule, or on a schedule that is disabled, are
+// put back on their business hours agent. A number that fails is tried
+// again on the next pass.
+func (s *ScheduleService) Apply(ctx context.Context) error {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	schedule, err := s.store.GetBusinessSchedule(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get business schedule: %w", err)
+	}
+	open := schedule.IsOpen(s.clock.Now())
+
+	// targets maps each number to whether it is on the schedule
+	targets := make(map[string]bool)
+	if schedule.Enabled {
+		for _, number := range schedule.PhoneNumbers {
+			targets[number] = true
+		}
+	}
+	for number := range s.applied {
+		if !targets[number] {
+			targets[number] = false
+		}
+	}
+
+	var failed int
+	for number, scheduled := range targets {
+		numberOpen := open || !scheduled
+		state := scheduleState(schedule, numberOpen)
+		if s.applied[number] == state {
+			if !scheduled {
+				delete(s.applied, number)
+			}
+			continue
+		}
+
+		if err := s.configure(ctx, schedule, number, numberOpen); err != nil {
+			failed++
+			s.logger.Error("failed to switch inbound number for business hours",
+				zap.String("phone_number", number),
+				zap.Bool("open", numberOpen),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if scheduled {
+			s.applied[number] = state
+		} else {
+			delete(s.applied, number)
+		}
+		s.logger.Info("inbound number switched for business hours",
+			zap.String("phone_number", number),
+			zap.Bool("open", numberOpen),
+		)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to switch %d inbound numbers", failed)
+	}
+	return nil
+}
+
+// configure puts a number on its business hours or after hours agent.
+func (s *ScheduleService) configure(ctx context.Context, schedule *domain.BusinessSchedule, phoneNumber string, open bool) error {
+	config, err := s.agentConfig(ctx, schedule, phoneNumber, open)
+	if err != nil {
+		return err
+	}
+	_, err = s.inbound.ConfigureInboundAgent(ctx, phoneNumber, config)
+	if err != nil && !errors.Is(err, ErrBlandWriteQueued) {
+		return fmt.Errorf("failed to configure inbound number: %w", err)
+	}
+	return nil
+}
+
+// InboundAgent builds the agent a number should answer with now: its
+// business or after hours agent if it is on the schedule, or the agent
+// from the call settings.
+func (s *ScheduleService) InboundAgent(ctx context.Context, phoneNumber string) (*bland.InboundConfig, error) {
+	schedule, err := s.store.GetBusinessSchedule(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business schedule: %w", err)
+	}
+	if schedule.Enabled {
+		for _, number := range schedule.PhoneNumbers {
+			if number == phoneNumber {
+				return s.agentConfig(ctx, schedule, phoneNumber, schedule.IsOpen(s.clock.Now()))
+			}
+		}
+	}
+	config, err := s.agent.GetInboundConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inbound config: %w", err)
+	}
+	return config, nil
+}
+
+// agentConfig builds the agent a number answers with in or outside
+// business hours.
+func (s *ScheduleService) agentConfig(ctx context.Context, schedule *domain.BusinessSchedule, phoneNumber string, open bool) (*bland.InboundConfig, error) {
+	promptID := schedule.PromptID
+	if !open && schedule.AfterHours.Mode == domain.AfterHoursPrompt && schedule.AfterHours.PromptID != nil {
+		promptID = schedule.AfterHours.PromptID
+	}
+
+	var config *bland.InboundConfig
+	if promptID != nil {
+		prompt, err := s.prompts.GetByID(ctx, *promptID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schedule prompt: %w", err)
+		}
+		// Answer in the language of the number's country when the prompt
+		// has been localized for it
+		prompt = prompt.Localize(domain.LanguageForNumber(phoneNumber))
+		config = inboundConfigFromPrompt(prompt)
+	} else {
+		var err error
+		if config, err = s.agent.GetInboundConfig(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get inbound config: %w", err)
+		}
+	}
+	if open {
+		return config, nil
+	}
+
+	if schedule.AfterHours.FirstSentence != "" {
+		config.FirstSentence = schedule.AfterHours.FirstSentence
+	}
+	if schedule.AfterHours.Mode == domain.AfterHoursVoicemail {
+		config.Task = afterHoursVoicemailTask
+		config.KnowledgeBases = nil
+		config.Tools = nil
+		if schedule.AfterHours.FirstSentence == "" {
+			config.FirstSentence = afterHoursVoicemailGreeting
+		}
+	}
+	return config, nil
+}
+
+// scheduleState identifies the agent a number should have, so a number is
+// only reconfigured when it changes.
+func scheduleState(schedule *domain.BusinessSchedule, open bool) string {
+	if open {
+		data, _ := json.Marshal(schedule.PromptID)
+		return "open:" + string(data)
+	}
+	data, _ := json.Marshal(struct {
+		PromptID   *uuid.UUID
+		AfterHours domain.AfterHours
+	}{schedule.PromptID, schedule.AfterHours})
+	return "closed:" + string(data)
+}
+
+// Start begins checking the schedule every minute.
+func (s *ScheduleService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("schedule worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting schedule worker", zap.Duration("interval", scheduleCheckInterval))
+
+	s.wg.Add(1)
+	go s.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for an in-flight pass to finish.
+func (s *ScheduleService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping schedule worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("schedule worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("schedule worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main worker loop.
+func (s *ScheduleService) runLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	s.runApply()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.runApply()
+		}
+	}
+}
+
+// runApply applies the schedule, giving up if the worker stops.
+func (s *ScheduleService) runApply() {
+	ctx, cancel := context.WithTimeout(context.Background(), scheduleCheckInterval)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := s.Apply(ctx); err != nil {
+		s.logger.Error("failed to apply business schedule", zap.Error(err))
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/bland"
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// memoryScheduleStore keeps the business schedule in memory.
+type memoryScheduleStore struct {
+	schedule *domain.BusinessSchedule
+}
+
+func (m *memoryScheduleStore) GetBusinessSchedule(ctx context.Context) (*domain.BusinessSchedule, error) {
+	if m.schedule == nil {
+		return &domain.BusinessSchedule{}, nil
+	}
+	return m.schedule, nil
+}
+
+func (m *memoryScheduleStore) SaveBusinessSchedule(ctx context.Context, schedule *domain.BusinessSchedule) error {
+	m.schedule = schedule
+	return nil
+}
+
+// settingsAgent is the agent built from the call settings.
+type settingsAgent struct{}
+
+func (settingsAgent) GetInboundConfig(ctx context.Context) (*bland.InboundConfig, error) {
+	return &bland.InboundConfig{Task: "Collect project details.", FirstSentence: "Hello!", Tools: []string{"book_callback"}}, nil
+}
+
+// numberConfigurer records the last agent put on each number.
+type numberConfigurer struct {
+	configs map[string]*bland.InboundConfig
+	calls   int
+}
+
+func (n *numberConfigurer) ConfigureInboundAgent(ctx context.Context, phoneNumberID string, config *bland.InboundConfig) (*bland.PhoneNumber, error) {
+	n.configs[phoneNumberID] = config
+	n.calls++
+	return &bland.PhoneNumber{}, nil
+}
+
+func newTestScheduleService(clk clock.Clock, prompts ...*domain.Prompt) (*ScheduleService, *numberConfigurer) {
+	inbound := &numberConfigurer{configs: make(map[string]*bland.InboundConfig)}
+	svc := NewScheduleService(&memoryScheduleStore{}, settingsAgent{}, inbound, NewMockPromptRepository(prompts...), zap.NewNop(), clk)
+	return svc, inbound
+}
+
+func TestScheduleService_SwitchesAfterHours(t *testing.T) {
+	// Monday 2026-10-12, 10:00 in New York
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+	clk := clock.NewMock(time.Date(2026, 10, 12, 10, 0, 0, 0, ny))
+	svc, inbound := newTestScheduleService(clk)
+	ctx := context.Background()
+
+	schedule := &domain.BusinessSchedule{
+		Enabled:      true,
+		Timezone:     "America/New_York",
+		Hours:        map[string]domain.OpeningHours{"mon": {Open: "09:00", Close: "17:00"}},
+		PhoneNumbers: []string{"+15551234567"},
+	}
+	status, err := svc.SaveSchedule(ctx, schedule)
+	if err != nil {
+		t.Fatalf("SaveSchedule() error = %v", err)
+	}
+	if !status.Open || inbound.configs["+15551234567"].Task != "Collect project details." {
+		t.Errorf("expected the business hours agent while open, got %+v", inbound.configs["+15551234567"])
+	}
+
+	// Nothing changes until business hours end
+	if err := svc.Apply(ctx); err != nil || inbound.calls != 1 {
+		t.Errorf("expected no reconfiguration in the same state, got %d calls, %v", inbound.calls, err)
+	}
+
+	clk.Set(time.Date(2026, 10, 12, 17, 0, 0, 0, ny))
+	if err := svc.Apply(ctx); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	closed := inbound.configs["+15551234567"]
+	if closed.Task != afterHoursVoicemailTask || closed.FirstSentence != afterHoursVoicemailGreeting || closed.Tools != nil {
+		t.Errorf("expected the voicemail agent after hours, got %+v", closed)
+	}
+
+	// Disabling the schedule puts the number back
+	schedule.Enabled = false
+	if _, err := svc.SaveSchedule(ctx, schedule); err != nil {
+		t.Fatalf("SaveSchedule() error = %v", err)
+	}
+	if inbound.configs["+15551234567"].Task != "Collect project details." || inbound.calls != 3 {
+		t.Errorf("expected the business hours agent back, got %+v (%d calls)", inbound.configs["+15551234567"], inbound.calls)
+	}
+	if err := svc.Apply(ctx); err != nil || inbound.calls != 3 {
+		t.Errorf("expected a disabled schedule to leave numbers alone, got %d calls, %v", inbound.calls, err)
+	}
+}
+
+func TestScheduleService_AfterHoursPrompt(t *testing.T) {
+	now := time.Date(2026, 12, 25, 12, 0, 0, 0, time.UTC)
+	afterHours := domain.NewPrompt("After hours", "Book a callback for tomorrow.")
+	svc, inbound := newTestScheduleService(clock.NewMock(now), afterHours)
+
+	status, err := svc.SaveSchedule(context.Background(), &domain.BusinessSchedule{
+		Enabled:      true,
+		Hours:        map[string]domain.OpeningHours{"friday": {Open: "09:00", Close: "17:00"}},
+		Holidays:     []domain.Holiday{{Date: "12-25", Name: "Christmas Day"}},
+		PhoneNumbers: []string{"+15551234567"},
+		AfterHours: domain.AfterHours{
+			Mode:          domain.AfterHoursPrompt,
+			PromptID:      &afterHours.ID,
+			FirstSentence: "Merry Christmas! We're closed today.",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SaveSchedule() error = %v", err)
+	}
+	if status.Open || status.Holiday == nil || status.Holiday.Name != "Christmas Day" {
+		t.Errorf("expected to be closed for the holiday, got %+v", status)
+	}
+	config := inbound.configs["+15551234567"]
+	if config.Task != afterHours.Task || config.FirstSentence != "Merry Christmas! We're closed today." {
+		t.Errorf("expected the after hours prompt with its greeting, got %+v", config)
+	}
+}
+
+func TestScheduleService_SaveScheduleValidation(t *testing.T) {
+	svc, _ := newTestScheduleService(nil)
+	missing := domain.NewPrompt("Missing", "Not stored.")
+
+	tests := []struct {
+		name     string
+		schedule *domain.BusinessSchedule
+	}{
+		{"no numbers", &domain.BusinessSchedule{Enabled: true}},
+		{"bad number", &domain.BusinessSchedule{PhoneNumbers: []string{"555-1234"}}},
+		{"bad timezone", &domain.BusinessSchedule{Timezone: "Mars/Olympus"}},
+		{"closes before opening", &domain.BusinessSchedule{Hours: map[string]domain.OpeningHours{"monday": {Open: "17:00", Close: "09:00"}}}},
+		{"bad day", &domain.BusinessSchedule{Hours: map[string]domain.OpeningHours{"someday": {Open: "09:00", Close: "17:00"}}}},
+		{"bad holiday", &domain.BusinessSchedule{Holidays: []domain.Holiday{{Date: "Christmas"}}}},
+		{"unknown prompt", &domain.BusinessSchedule{PromptID: &missing.ID}},
+		{"empty prompt mode", &domain.BusinessSchedule{AfterHours: domain.AfterHours{Mode: domain.AfterHoursPrompt}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.SaveSchedule(context.Background(), tt.schedule); !apperrors.IsUserError(err) {
+				t.Errorf("SaveSchedule() error = %v, want validation error", err)
+			}
+		})
+	}
+}
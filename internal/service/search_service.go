@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// Search limits.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	MaxSearchQueryLen  = 200
+)
+
+// SearchService provides full-text search across call transcripts.
+type SearchService struct {
+	repo   domain.CallSearchRepository
+	logger *zap.Logger
+}
+
+// NewSearchService creates a new SearchService.
+func NewSearchService(repo domain.CallSearchRepository, logger *zap.Logger) *SearchService {
+	return &SearchService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// TranscriptSearchResult is a ranked transcript match ready for display.
+type TranscriptSearchResult struct {
+	*domain.TranscriptMatch
+
+	// Snippet is an HTML-escaped transcript excerpt with matched terms
+	// wrapped in <mark> tags.
+	Snippet string `json:"snippet"`
+}
+
+// TranscriptSearchResults is a page of transcript search results.
+type TranscriptSearchResults struct {
+	Query   string                    `json:"query"`
+	Total   int                       `json:"total"`
+	Limit   int                       `json:"limit"`
+	Offset  int                       `json:"offset"`
+	Results []*TranscriptSearchResult `json:"results"`
+}
+
+// SearchTranscripts returns calls whose transcripts match query, best match first.
+// The query supports quoted phrases, OR and -exclusions.
+func (s *SearchService) SearchTranscripts(ctx context.Context, query string, limit, offset int) (*TranscriptSearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperrors.MissingField("q")
+	}
+	if len(query) > MaxSearchQueryLen {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("search query must be at most %d characters", MaxSearchQueryLen))
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		limit = DefaultSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	total, err := s.repo.CountTranscriptMatches(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transcript matches: %w", err)
+	}
+
+	results := &TranscriptSearchResults{
+		Query:   query,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		Results: []*TranscriptSearchResult{},
+	}
+	if total == 0 || offset >= total {
+		return results, nil
+	}
+
+	matches, err := s.repo.SearchTranscripts(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transcripts: %w", err)
+	}
+
+	for _, m := range matches {
+		results.Results = append(results.Results, &TranscriptSearchResult{
+			TranscriptMatch: m,
+			Snippet:         highlightSnippet(m.Headline),
+		})
+	}
+
+	s.logger.Debug("transcript search",
+		zap.Int("query_len", len(query)),
+		zap.Int("total", total),
+		zap.Int("returned", len(results.Results)),
+	)
+
+	return results, nil
+}
+
+// highlightSnippet escapes a headline and turns its highlight markers into <mark> tags.
+// The markers contain no HTML metacharacters, so they survive escaping intact.
+func highlightSnippet(headline string) string {
+	escaped := html.EscapeString(strings.TrimSpace(headline))
+	return strings.NewReplacer(
+		domain.TranscriptHighlightStart, "<mark>",
+		domain.TranscriptHighlightStop, "</mark>",
+	).Replace(escaped)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// stubCallSearchRepository returns fixed matches and records the last query.
+type stubCallSearchRepository struct {
+	matches   []*domain.TranscriptMatch
+	lastQuery string
+	lastLimit int
+	searched  bool
+}
+
+func (s *stubCallSearchRepository) SearchTranscripts(ctx context.Context, query string, limit, offset int) ([]*domain.TranscriptMatch, error) {
+	s.searched = true
+	s.lastQuery = query
+	s.lastLimit = limit
+	return s.matches, nil
+}
+
+func (s *stubCallSearchRepository) CountTranscriptMatches(ctx context.Context, query string) (int, error) {
+	return len(s.matches), nil
+}
+
+func TestSearchService_SearchTranscripts(t *testing.T) {
+	repo := &stubCallSearchRepository{
+		matches: []*domain.TranscriptMatch{{
+			CallID:   uuid.New(),
+			Status:   domain.CallStatusCompleted,
+			Rank:     0.5,
+			Headline: "we need a [[hl]]mobile[[/hl]] app <script>alert(1)</script>",
+		}},
+	}
+	svc := NewSearchService(repo, zap.NewNop())
+
+	results, err := svc.SearchTranscripts(context.Background(), "  mobile  ", 500, 0)
+	if err != nil {
+		t.Fatalf("SearchTranscripts() error = %v", err)
+	}
+	if repo.lastQuery != "mobile" {
+		t.Errorf("expected trimmed query, got %q", repo.lastQuery)
+	}
+	if repo.lastLimit != DefaultSearchLimit {
+		t.Errorf("expected out-of-range limit to default to %d, got %d", DefaultSearchLimit, repo.lastLimit)
+	}
+	if results.Total != 1 || len(results.Results) != 1 {
+		t.Fatalf("expected 1 result, got total=%d results=%d", results.Total, len(results.Results))
+	}
+
+	snippet := results.Results[0].Snippet
+	if !strings.Contains(snippet, "<mark>mobile</mark>") {
+		t.Errorf("expected highlighted term in snippet, got %q", snippet)
+	}
+	if strings.Contains(snippet, "<script>") {
+		t.Errorf("expected transcript HTML to be escaped, got %q", snippet)
+	}
+}
+
+func TestSearchService_SearchTranscripts_NoMatchesSkipsSearch(t *testing.T) {
+	repo := &stubCallSearchRepository{}
+	svc := NewSearchService(repo, zap.NewNop())
+
+	results, err := svc.SearchTranscripts(context.Background(), "nothing", 10, 0)
+	if err != nil {
+		t.Fatalf("SearchTranscripts() error = %v", err)
+	}
+	if repo.searched {
+		t.Error("expected ranked search to be skipped when nothing matches")
+	}
+	if results.Results == nil || len(results.Results) != 0 {
+		t.Errorf("expected empty, non-nil results, got %v", results.Results)
+	}
+}
+
+func TestSearchService_SearchTranscripts_InvalidQuery(t *testing.T) {
+	svc := NewSearchService(&stubCallSearchRepository{}, zap.NewNop())
+
+	for _, q := range []string{"", "   ", strings.Repeat("a", MaxSearchQueryLen+1)} {
+		_, err := svc.SearchTranscripts(context.Background(), q, 10, 0)
+		if !apperrors.IsUserError(err) {
+			t.Errorf("SearchTranscripts(%d chars) expected user error, got %v", len(q), err)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/secrets"
+)
+
+// ProviderKeySource is where the API key in use for a provider comes from.
+type ProviderKeySource string
+
+// Provider key sources.
+const (
+	ProviderKeyStored      ProviderKeySource = "database"
+	ProviderKeyEnvironment ProviderKeySource = "environment"
+	ProviderKeyNone        ProviderKeySource = "none"
+)
+
+// ProviderKeyStatus describes the API key in use for a provider, without
+// the key itself.
+type ProviderKeyStatus struct {
+	Provider  domain.CredentialProvider `json:"provider"`
+	Source    ProviderKeySource         `json:"source"`
+	Hint      string                    `json:"hint,omitempty"` // Last characters of a stored key
+	UpdatedBy string                    `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time                `json:"updated_at,omitempty"`
+}
+
+// SecretsService stores provider API keys encrypted in the database. A
+// stored key overrides the one in the environment, and subscribers are told
+// when a key is set or removed so clients switch to it without a restart.
+// Keys set or removed through another instance sharing the database reach
+// this instance's subscribers on the next Refresh.
+type SecretsService struct {
+	repo        domain.ProviderCredentialRepository
+	keyring     *secrets.Keyring
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+
+	mu          sync.RWMutex
+	envKeys     map[domain.CredentialProvider]bool
+	loaded      map[domain.CredentialProvider]time.Time // When each stored key last loaded was set
+	subscribers []func(provider domain.CredentialProvider)
+}
+
+// NewSecretsService creates a new SecretsService. keyring is nil when no
+// master key is configured, in which case keys can't be stored and only
+// environment keys are used. auditLogger may be nil.
+func NewSecretsService(repo domain.ProviderCredentialRepository, keyring *secrets.Keyring, auditLogger *audit.Logger, logger *zap.Logger) *SecretsService {
+	return &SecretsService{
+		repo:        repo,
+		keyring:     keyring,
+		auditLogger: auditLogger,
+		logger:      logger,
+		envKeys:     make(map[domain.CredentialProvider]bool),
+		loaded:      make(map[domain.CredentialProvider]time.Time),
+	}
+}
+
+// SetEnvironmentKeys records which providers have a key in the environment,
+// for reporting where each key in use comes from.
+func (s *SecretsService) SetEnvironmentKeys(providers ...domain.CredentialProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.envKeys = make(map[domain.CredentialProvider]bool, len(providers))
+	for _, provider := range providers {
+		s.envKeys[provider] = true
+	}
+}
+
+// Subscribe registers fn to be called after a provider's stored key is set
+// or removed. fn reads the key in effect with Keys.
+func (s *SecretsService) Subscribe(fn func(provider domain.CredentialProvider)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Enabled reports whether keys can be stored, which needs a master key.
+func (s *SecretsService) Enabled() bool {
+	return s.keyring != nil
+}
+
+// Status describes the key in use for each provider.
+func (s *SecretsService) Status(ctx context.Context) ([]ProviderKeyStatus, error) {
+	creds, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[domain.CredentialProvider]*domain.ProviderCredential, len(creds))
+	for _, cred := range creds {
+		stored[cred.Provider] = cred
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]ProviderKeyStatus, 0, len(domain.CredentialProviders))
+	for _, provider := range domain.CredentialProviders {
+		status := ProviderKeyStatus{Provider: provider, Source: ProviderKeyNone}
+		if cred, ok := stored[provider]; ok && s.keyring != nil {
+			status.Source = ProviderKeyStored
+			status.Hint = cred.Hint
+			status.UpdatedAt = &cred.UpdatedAt
+			if cred.UpdatedBy != nil {
+				status.UpdatedBy = cred.UpdatedBy.String()
+			}
+		} else if s.envKeys[provider] {
+			status.Source = ProviderKeyEnvironment
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SetKey encrypts and stores a provider's API key, replacing any stored
+// before, and tells subscribers to switch to it.
+func (s *SecretsService) SetKey(ctx context.Context, actor UserActor, provider domain.CredentialProvider, key string) (*ProviderKeyStatus, error) {
+	if s.keyring == nil {
+		return nil, apperrors.New(apperrors.CodeServiceUnavailable, "provider keys can't be stored without a master key; set SECRETS_MASTER_KEY")
+	}
+	if !provider.IsValid() {
+		return nil, apperrors.NotFound("provider")
+	}
+	key = strings.TrimSpace(key)
+	if key == "" || len(key) > domain.MaxProviderAPIKeyLen || strings.ContainsAny(key, " \t\r\n") {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("key must be 1 to %d characters without spaces", domain.MaxProviderAPIKeyLen))
+	}
+
+	keyID, ciphertext, err := s.keyring.Seal([]byte(key), []byte(provider))
+	if err != nil {
+		return nil, apperrors.InternalError("failed to encrypt provider key", err)
+	}
+	now := time.Now().UTC()
+	cred := &domain.ProviderCredential{
+		Provider:   provider,
+		Ciphertext: ciphertext,
+		KeyID:      keyID,
+		Hint:       domain.KeyHint(key),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if actor.User != nil {
+		id := actor.User.ID
+		cred.UpdatedBy = &id
+	}
+	if err := s.repo.Upsert(ctx, cred); err != nil {
+		return nil, err
+	}
+	s.setLoaded(provider, &cred.UpdatedAt)
+
+	s.logger.Info("provider key set", zap.String("provider", string(provider)), zap.String("hint", cred.Hint))
+	if s.auditLogger != nil {
+		s.auditLogger.ProviderKeySet(ctx, actor.id(), actor.email(), string(provider), cred.Hint, actor.IP, actor.RequestID)
+	}
+	s.notify(provider)
+
+	return &ProviderKeyStatus{
+		Provider:  provider,
+		Source:    ProviderKeyStored,
+		Hint:      cred.Hint,
+		UpdatedBy: actor.id(),
+		UpdatedAt: &cred.UpdatedAt,
+	}, nil
+}
+
+// DeleteKey removes a provider's stored key, returning it to the key in
+// the environment, and tells subscribers.
+func (s *SecretsService) DeleteKey(ctx context.Context, actor UserActor, provider domain.CredentialProvider) error {
+	if !provider.IsValid() {
+		return apperrors.NotFound("provider")
+	}
+	if err := s.repo.Delete(ctx, provider); err != nil {
+		return err
+	}
+	s.setLoaded(provider, nil)
+
+	s.logger.Info("provider key removed", zap.String("provider", string(provider)))
+	if s.auditLogger != nil {
+		s.auditLogger.ProviderKeyDeleted(ctx, actor.id(), actor.email(), string(provider), actor.IP, actor.RequestID)
+	}
+	s.notify(provider)
+	return nil
+}
+
+// Keys returns the decrypted stored key of each provider that has one. A
+// key that can't be decrypted, such as one sealed under a master key no
+// longer configured, is logged and left out, so the environment key is used.
+func (s *SecretsService) Keys(ctx context.Context) (map[domain.CredentialProvider]string, error) {
+	keys := make(map[domain.CredentialProvider]string)
+	if s.keyring == nil {
+		return keys, nil
+	}
+	creds, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.recordLoaded(creds)
+	for _, cred := range creds {
+		key, err := s.keyring.Open(cred.KeyID, cred.Ciphertext, []byte(cred.Provider))
+		if err != nil {
+			s.logger.Error("failed to decrypt stored provider key; using the environment key",
+				zap.String("provider", string(cred.Provider)),
+				zap.String("key_id", cred.KeyID),
+				zap.Error(err),
+			)
+			continue
+		}
+		keys[cred.Provider] = string(key)
+	}
+	return keys, nil
+}
+
+// Refresh tells subscribers about each provider whose stored key was set or
+// removed since this instance last loaded the keys, such as a key rotated
+// through another instance. It is run periodically on every instance.
+func (s *SecretsService) Refresh(ctx context.Context) error {
+	if s.keyring == nil {
+		return nil
+	}
+	creds, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, provider := range s.recordLoaded(creds) {
+		s.logger.Info("provider key changed on another instance", zap.String("provider", string(provider)))
+		s.notify(provider)
+	}
+	return nil
+}
+
+// Reseal re-encrypts stored keys sealed under a previous master key with the
+// current one, so the previous key can be retired. It returns how many keys
+// were re-encrypted.
+func (s *SecretsService) Reseal(ctx context.Context) (int, error) {
+	if s.keyring == nil {
+		return 0, nil
+	}
+	creds, err := s.repo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resealed := 0
+	for _, cred := range creds {
+		if cred.KeyID == s.keyring.KeyID() {
+			continue
+		}
+		key, err := s.keyring.Open(cred.KeyID, cred.Ciphertext, []byte(cred.Provider))
+		if err != nil {
+			s.logger.Warn("can't re-encrypt stored provider key",
+				zap.String("provider", string(cred.Provider)),
+				zap.String("key_id", cred.KeyID),
+				zap.Error(err),
+			)
+			continue
+		}
+		keyID, ciphertext, err := s.keyring.Seal(key, []byte(cred.Provider))
+		if err != nil {
+			return resealed, apperrors.InternalError("failed to encrypt provider key", err)
+		}
+		cred.KeyID = keyID
+		cred.Ciphertext = ciphertext
+		if err := s.repo.Upsert(ctx, cred); err != nil {
+			return resealed, err
+		}
+		resealed++
+	}
+	return resealed, nil
+}
+
+// recordLoaded records when each of creds was set as the keys in use and
+// returns the providers whose key differs from the one loaded before.
+func (s *SecretsService) recordLoaded(creds []*domain.ProviderCredential) []domain.CredentialProvider {
+	current := make(map[domain.CredentialProvider]time.Time, len(creds))
+	for _, cred := range creds {
+		current[cred.Provider] = cred.UpdatedAt.Truncate(time.Microsecond)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var changed []domain.CredentialProvider
+	for _, provider := range domain.CredentialProviders {
+		before, had := s.loaded[provider]
+		after, has := current[provider]
+		if had != has || !before.Equal(after) {
+			changed = append(changed, provider)
+		}
+	}
+	s.loaded = current
+	return changed
+}
+
+// setLoaded records a key this instance set, at updatedAt, or removed, when
+// updatedAt is nil, so Refresh doesn't report it back.
+func (s *SecretsService) setLoaded(provider domain.CredentialProvider, updatedAt *time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if updatedAt == nil {
+		delete(s.loaded, provider)
+		return
+	}
+	// Stored to the microsecond, as the database keeps it
+	s.loaded[provider] = updatedAt.Truncate(time.Microsecond)
+}
+
+func (s *SecretsService) notify(provider domain.CredentialProvider) {
+	s.mu.RLock()
+	subscribers := append([]func(domain.CredentialProvider){}, s.subscribers...)
+	s.mu.RUnlock()
+	for _, fn := range subscribers {
+		fn(provider)
+	}
+}
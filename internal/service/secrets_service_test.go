@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/secrets"
+)
+
+type stubProviderCredentialRepo struct {
+	creds map[domain.CredentialProvider]*domain.ProviderCredential
+}
+
+func newStubProviderCredentialRepo() *stubProviderCredentialRepo {
+	return &stubProviderCredentialRepo{creds: map[domain.CredentialProvider]*domain.ProviderCredential{}}
+}
+
+func (r *stubProviderCredentialRepo) Upsert(ctx context.Context, cred *domain.ProviderCredential) error {
+	stored := *cred
+	r.creds[cred.Provider] = &stored
+	return nil
+}
+
+func (r *stubProviderCredentialRepo) Get(ctx context.Context, provider domain.CredentialProvider) (*domain.ProviderCredential, error) {
+	cred, ok := r.creds[provider]
+	if !ok {
+		return nil, apperrors.NotFound("provider credential")
+	}
+	found := *cred
+	return &found, nil
+}
+
+func (r *stubProviderCredentialRepo) List(ctx context.Context) ([]*domain.ProviderCredential, error) {
+	var creds []*domain.ProviderCredential
+	for _, cred := range r.creds {
+		listed := *cred
+		creds = append(creds, &listed)
+	}
+	sort.Slice(creds, func(i, j int) bool { return creds[i].Provider < creds[j].Provider })
+	return creds, nil
+}
+
+func (r *stubProviderCredentialRepo) Delete(ctx context.Context, provider domain.CredentialProvider) error {
+	if _, ok := r.creds[provider]; !ok {
+		return apperrors.NotFound("provider credential")
+	}
+	delete(r.creds, provider)
+	return nil
+}
+
+func newTestKeyring(t *testing.T, previous ...[]byte) (*secrets.Keyring, []byte) {
+	t.Helper()
+	encoded, err := secrets.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	key, _ := secrets.ParseKey(encoded)
+	keyring, err := secrets.NewKeyring(key, previous...)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	return keyring, key
+}
+
+func TestSecretsService_SetAndDeleteKey(t *testing.T) {
+	repo := newStubProviderCredentialRepo()
+	keyring, _ := newTestKeyring(t)
+	svc := NewSecretsService(repo, keyring, nil, zap.NewNop())
+	svc.SetEnvironmentKeys(domain.CredentialAnthropic)
+	ctx := context.Background()
+	actor := UserActor{User: &domain.User{ID: uuid.New(), Email: "admin@example.com", Role: domain.UserRoleAdmin}}
+
+	var notified []domain.CredentialProvider
+	svc.Subscribe(func(provider domain.CredentialProvider) { notified = append(notified, provider) })
+
+	if _, err := svc.SetKey(ctx, actor, domain.CredentialVapi, "  "); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("SetKey() with a blank key error = %v, want validation failure", err)
+	}
+	if _, err := svc.SetKey(ctx, actor, "twilio", "abc"); !apperrors.IsNotFound(err) {
+		t.Errorf("SetKey() for an unknown provider error = %v, want not found", err)
+	}
+
+	status, err := svc.SetKey(ctx, actor, domain.CredentialAnthropic, " sk-ant-0123456789abcd ")
+	if err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+	if status.Source != ProviderKeyStored || status.Hint != "abcd" {
+		t.Errorf("SetKey() status = %+v, want stored with hint abcd", status)
+	}
+	if string(repo.creds[domain.CredentialAnthropic].Ciphertext) == "sk-ant-0123456789abcd" {
+		t.Error("key stored in plaintext")
+	}
+	if len(notified) != 1 || notified[0] != domain.CredentialAnthropic {
+		t.Errorf("subscribers told about %v, want anthropic", notified)
+	}
+
+	keys, err := svc.Keys(ctx)
+	if err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+	if keys[domain.CredentialAnthropic] != "sk-ant-0123456789abcd" {
+		t.Errorf("Keys() = %v, want the trimmed key", keys)
+	}
+
+	if err := svc.DeleteKey(ctx, actor, domain.CredentialAnthropic); err != nil {
+		t.Fatalf("DeleteKey() error = %v", err)
+	}
+	statuses, err := svc.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	for _, s := range statuses {
+		want := ProviderKeyNone
+		if s.Provider == domain.CredentialAnthropic {
+			want = ProviderKeyEnvironment
+		}
+		if s.Source != want {
+			t.Errorf("%s source = %s, want %s", s.Provider, s.Source, want)
+		}
+	}
+	if len(notified) != 2 {
+		t.Errorf("subscribers told %d times, want 2", len(notified))
+	}
+}
+
+func TestSecretsService_WithoutMasterKey(t *testing.T) {
+	svc := NewSecretsService(newStubProviderCredentialRepo(), nil, nil, zap.NewNop())
+	_, err := svc.SetKey(context.Background(), UserActor{}, domain.CredentialBland, "key")
+	if apperrors.GetCode(err) != apperrors.CodeServiceUnavailable {
+		t.Errorf("SetKey() error = %v, want service unavailable", err)
+	}
+}
+
+func TestSecretsService_Reseal(t *testing.T) {
+	repo := newStubProviderCredentialRepo()
+	oldKeyring, oldKey := newTestKeyring(t)
+	ctx := context.Background()
+	if _, err := NewSecretsService(repo, oldKeyring, nil, zap.NewNop()).SetKey(ctx, UserActor{}, domain.CredentialBland, "bland-key"); err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+
+	newKeyring, _ := newTestKeyring(t, oldKey)
+	svc := NewSecretsService(repo, newKeyring, nil, zap.NewNop())
+	n, err := svc.Reseal(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("Reseal() = %d, %v, want 1 key re-encrypted", n, err)
+	}
+	if repo.creds[domain.CredentialBland].KeyID != newKeyring.KeyID() {
+		t.Error("key still sealed under the previous master key")
+	}
+	if keys, _ := svc.Keys(ctx); keys[domain.CredentialBland] != "bland-key" {
+		t.Errorf("Keys() after reseal = %v", keys)
+	}
+}
+
+func TestSecretsService_Refresh(t *testing.T) {
+	repo := newStubProviderCredentialRepo()
+	keyring, _ := newTestKeyring(t)
+	ctx := context.Background()
+	// Two instances sharing the database
+	local := NewSecretsService(repo, keyring, nil, zap.NewNop())
+	other := NewSecretsService(repo, keyring, nil, zap.NewNop())
+	if _, err := local.Keys(ctx); err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+
+	var notified []domain.CredentialProvider
+	local.Subscribe(func(provider domain.CredentialProvider) { notified = append(notified, provider) })
+
+	if _, err := local.SetKey(ctx, UserActor{}, domain.CredentialVapi, "vapi-key"); err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+	if _, err := other.SetKey(ctx, UserActor{}, domain.CredentialBland, "bland-key"); err != nil {
+		t.Fatalf("SetKey() error = %v", err)
+	}
+	notified = nil
+	if err := local.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(notified) != 1 || notified[0] != domain.CredentialBland {
+		t.Errorf("Refresh() told subscribers about %v, want only bland", notified)
+	}
+
+	notified = nil
+	if err := local.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(notified) != 0 {
+		t.Errorf("Refresh() with nothing changed told subscribers about %v", notified)
+	}
+
+	if err := other.DeleteKey(ctx, UserActor{}, domain.CredentialBland); err != nil {
+		t.Fatalf("DeleteKey() error = %v", err)
+	}
+	if err := local.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(notified) != 1 || notified[0] != domain.CredentialBland {
+		t.Errorf("Refresh() after removal told subscribers about %v, want bland", notified)
+	}
+}
@@ -2,23 +2,34 @@ package service
 
 import (
 	"context"
-	"sync"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/cache"
 	"github.com/jkindrix/quickquote/internal/domain"
 	"github.com/jkindrix/quickquote/internal/repository"
 )
 
+// DefaultCacheTTL is how long settings and prompts are cached unless
+// configured otherwise.
+const DefaultCacheTTL = time.Minute
+
+// settingsCacheKey is the single entry holding every setting.
+const settingsCacheKey = "all"
+
 // SettingsService manages application settings.
 type SettingsService struct {
 	repo   *repository.SettingsRepository
 	logger *zap.Logger
 
+	// defaultCurrency is used when no currency is set in settings.
+	defaultCurrency string
+
 	// Cache for settings to avoid repeated DB queries
-	cache    map[string]string
-	cacheMu  sync.RWMutex
-	cacheSet bool
+	cache *cache.Cache[string, map[string]string]
 }
 
 // NewSettingsService creates a new settings service.
@@ -26,10 +37,20 @@ func NewSettingsService(repo *repository.SettingsRepository, logger *zap.Logger)
 	return &SettingsService{
 		repo:   repo,
 		logger: logger,
-		cache:  make(map[string]string),
+		cache:  cache.New[string, map[string]string]("settings", DefaultCacheTTL, nil),
 	}
 }
 
+// SetCacheTTL sets how long settings are cached. Zero disables the cache.
+func (s *SettingsService) SetCacheTTL(ttl time.Duration) {
+	s.cache = cache.New[string, map[string]string]("settings", ttl, nil)
+}
+
+// CacheStats reports how the settings cache is doing.
+func (s *SettingsService) CacheStats() []cache.Stats {
+	return []cache.Stats{s.cache.Stats()}
+}
+
 // GetCallSettings retrieves all call-related settings as a typed struct.
 func (s *SettingsService) GetCallSettings(ctx context.Context) (*domain.CallSettings, error) {
 	settingsMap, err := s.getAllAsMap(ctx)
@@ -63,14 +84,11 @@ func (s *SettingsService) SaveCallSettings(ctx context.Context, settings *domain
 // Get retrieves a single setting value.
 func (s *SettingsService) Get(ctx context.Context, key string) (string, error) {
 	// Check cache first
-	s.cacheMu.RLock()
-	if s.cacheSet {
-		if v, ok := s.cache[key]; ok {
-			s.cacheMu.RUnlock()
+	if settings, ok := s.cache.Get(settingsCacheKey); ok {
+		if v, ok := settings[key]; ok {
 			return v, nil
 		}
 	}
-	s.cacheMu.RUnlock()
 
 	// Fetch from DB
 	setting, err := s.repo.Get(ctx, key)
@@ -90,12 +108,8 @@ func (s *SettingsService) Set(ctx context.Context, key, value string) error {
 		return err
 	}
 
-	// Update cache
-	s.cacheMu.Lock()
-	if s.cacheSet {
-		s.cache[key] = value
-	}
-	s.cacheMu.Unlock()
+	// Invalidate cache
+	s.invalidateCache()
 
 	s.logger.Info("setting updated", zap.String("key", key))
 
@@ -109,17 +123,14 @@ func (s *SettingsService) GetAllSettings(ctx context.Context) ([]*domain.Setting
 
 // getAllAsMap retrieves all settings as a map, using cache if available.
 func (s *SettingsService) getAllAsMap(ctx context.Context) (map[string]string, error) {
-	s.cacheMu.RLock()
-	if s.cacheSet {
+	if settings, ok := s.cache.Get(settingsCacheKey); ok {
 		// Return copy of cache
-		result := make(map[string]string, len(s.cache))
-		for k, v := range s.cache {
+		result := make(map[string]string, len(settings))
+		for k, v := range settings {
 			result[k] = v
 		}
-		s.cacheMu.RUnlock()
 		return result, nil
 	}
-	s.cacheMu.RUnlock()
 
 	// Fetch from DB and populate cache
 	settingsMap, err := s.repo.GetAsMap(ctx)
@@ -127,20 +138,18 @@ func (s *SettingsService) getAllAsMap(ctx context.Context) (map[string]string, e
 		return nil, err
 	}
 
-	s.cacheMu.Lock()
-	s.cache = settingsMap
-	s.cacheSet = true
-	s.cacheMu.Unlock()
+	cached := make(map[string]string, len(settingsMap))
+	for k, v := range settingsMap {
+		cached[k] = v
+	}
+	s.cache.Set(settingsCacheKey, cached)
 
 	return settingsMap, nil
 }
 
 // invalidateCache clears the settings cache.
 func (s *SettingsService) invalidateCache() {
-	s.cacheMu.Lock()
-	s.cache = make(map[string]string)
-	s.cacheSet = false
-	s.cacheMu.Unlock()
+	s.cache.Clear()
 }
 
 // RefreshCache forces a reload of the settings cache from the database.
@@ -150,6 +159,26 @@ func (s *SettingsService) RefreshCache(ctx context.Context) error {
 	return err
 }
 
+// SetDefaultCurrency sets the currency used when none is set in settings.
+func (s *SettingsService) SetDefaultCurrency(currency string) {
+	s.defaultCurrency = domain.NormalizeCurrency(currency)
+}
+
+// DefaultCurrency returns the currency new quotes are priced in: the
+// currency setting, else the configured default, else USD.
+func (s *SettingsService) DefaultCurrency(ctx context.Context) string {
+	settingsMap, err := s.getAllAsMap(ctx)
+	if err != nil {
+		s.logger.Warn("failed to load currency setting", zap.Error(err))
+	} else if currency := domain.NormalizeCurrency(settingsMap[domain.SettingKeyCurrency]); domain.IsValidCurrency(currency) {
+		return currency
+	}
+	if domain.IsValidCurrency(s.defaultCurrency) {
+		return s.defaultCurrency
+	}
+	return domain.DefaultCurrency
+}
+
 // GetPricingSettings retrieves pricing fallback settings as a typed struct.
 func (s *SettingsService) GetPricingSettings(ctx context.Context) (*domain.PricingSettings, error) {
 	settingsMap, err := s.getAllAsMap(ctx)
@@ -159,3 +188,39 @@ func (s *SettingsService) GetPricingSettings(ctx context.Context) (*domain.Prici
 
 	return domain.NewPricingSettingsFromMap(settingsMap), nil
 }
+
+// GetCRMFieldMapping retrieves the CRM field mapping.
+func (s *SettingsService) GetCRMFieldMapping(ctx context.Context) (*domain.CRMFieldMapping, error) {
+	value, err := s.Get(ctx, domain.SettingKeyCRMFieldMapping)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ParseCRMFieldMapping(value)
+}
+
+// SaveCRMFieldMapping saves the CRM field mapping.
+func (s *SettingsService) SaveCRMFieldMapping(ctx context.Context, mapping *domain.CRMFieldMapping) error {
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode crm field mapping: %w", err)
+	}
+	return s.Set(ctx, domain.SettingKeyCRMFieldMapping, string(data))
+}
+
+// GetBusinessSchedule retrieves the business hours schedule.
+func (s *SettingsService) GetBusinessSchedule(ctx context.Context) (*domain.BusinessSchedule, error) {
+	value, err := s.Get(ctx, domain.SettingKeyBusinessSchedule)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ParseBusinessSchedule(value)
+}
+
+// SaveBusinessSchedule saves the business hours schedule.
+func (s *SettingsService) SaveBusinessSchedule(ctx context.Context, schedule *domain.BusinessSchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to encode business schedule: %w", err)
+	}
+	return s.Set(ctx, domain.SettingKeyBusinessSchedule, string(data))
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/clock"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// Spam scoring. Each signal adds to a caller's score, capped at 100; calls
+// scoring domain.SpamFlagScore or more are flagged.
+const (
+	spamPrefixScore       = 60 // The number starts with a known spam prefix
+	spamFrequencyScore    = 50 // The number called more than the hourly limit
+	spamRepeatScore       = 20 // The number was flagged before and not yet reviewed
+	spamLookupScore       = 60 // The lookup API labels the number spam
+	spamLowReputation     = 30 // The lookup API gives the number a poor score
+	spamLowReputationUpTo = 40 // Lookup scores below this are poor
+)
+
+// spamQueueLimit bounds the callers listed in the review queue.
+const spamQueueLimit = 200
+
+// SpamBlocker blocks numbers. BlocklistService implements it.
+type SpamBlocker interface {
+	Block(ctx context.Context, req *BlockNumberRequest) (*domain.BlockedNumber, error)
+}
+
+// SpamService scores inbound callers for spam and robocalls when their
+// calls' webhooks arrive. Flagged calls are tagged with their score and
+// their callers queued for review, where they can be blocked or dismissed;
+// repeat offenders can be blocked automatically.
+type SpamService struct {
+	repo    domain.SpamCallerRepository
+	calls   domain.CallRepository
+	lookup  NumberReputationChecker
+	blocker SpamBlocker
+	logger  *zap.Logger
+
+	// Configuration
+	prefixes        []string
+	maxCallsPerHour int
+	autoBlockAfter  int
+
+	clock clock.Clock
+}
+
+// SpamServiceConfig holds configuration for the spam service.
+type SpamServiceConfig struct {
+	Prefixes        []string // Known spam prefixes, e.g. +1900
+	MaxCallsPerHour int      // Calls from one number in an hour before it looks like a robocaller
+	AutoBlockAfter  int      // Flagged calls before a caller is blocked automatically; 0 never blocks
+}
+
+// DefaultSpamServiceConfig returns sensible defaults.
+func DefaultSpamServiceConfig() *SpamServiceConfig {
+	return &SpamServiceConfig{
+		MaxCallsPerHour: 5,
+	}
+}
+
+// NewSpamService creates a new SpamService.
+func NewSpamService(repo domain.SpamCallerRepository, calls domain.CallRepository, logger *zap.Logger, config *SpamServiceConfig, clk clock.Clock) *SpamService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	defaults := DefaultSpamServiceConfig()
+	if config == nil {
+		config = defaults
+	}
+	maxCallsPerHour := config.MaxCallsPerHour
+	if maxCallsPerHour <= 0 {
+		maxCallsPerHour = defaults.MaxCallsPerHour
+	}
+	autoBlockAfter := config.AutoBlockAfter
+	if autoBlockAfter < 0 {
+		autoBlockAfter = 0
+	}
+
+	var prefixes []string
+	for _, prefix := range config.Prefixes {
+		prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "*")
+		if prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return &SpamService{
+		repo:            repo,
+		calls:           calls,
+		logger:          logger,
+		prefixes:        prefixes,
+		maxCallsPerHour: maxCallsPerHour,
+		autoBlockAfter:  autoBlockAfter,
+		clock:           clk,
+	}
+}
+
+// SetLookup scores callers by a lookup API as well as by the local
+// heuristics.
+func (s *SpamService) SetLookup(lookup NumberReputationChecker) {
+	s.lookup = lookup
+}
+
+// SetBlocker enables blocking callers, by a reviewer or automatically.
+func (s *SpamService) SetBlocker(blocker SpamBlocker) {
+	s.blocker = blocker
+}
+
+// Score rates how likely calls from a number are spam, from 0 to 100, and
+// gives the reasons. Callers reviewed as not spam score 0.
+func (s *SpamService) Score(ctx context.Context, phoneNumber string) (int, []string, error) {
+	caller, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
+	if err != nil && !apperrors.IsNotFound(err) {
+		return 0, nil, err
+	}
+	if caller != nil && caller.Status == domain.SpamCallerDismissed {
+		return 0, nil, nil
+	}
+
+	score := 0
+	var reasons []string
+
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(phoneNumber, prefix) {
+			score += spamPrefixScore
+			reasons = append(reasons, fmt.Sprintf("Known spam prefix %s", prefix))
+			break
+		}
+	}
+
+	calls, err := s.repo.CountCallsFrom(ctx, phoneNumber, s.clock.Now().Add(-time.Hour))
+	if err != nil {
+		return 0, nil, err
+	}
+	if calls > s.maxCallsPerHour {
+		score += spamFrequencyScore
+		reasons = append(reasons, fmt.Sprintf("%d calls in the last hour", calls))
+	}
+
+	if caller != nil && caller.Status == domain.SpamCallerPending && caller.FlaggedCalls > 0 {
+		score += spamRepeatScore
+		reasons = append(reasons, fmt.Sprintf("Flagged %d times before", caller.FlaggedCalls))
+	}
+
+	if s.lookup != nil {
+		// Score by the heuristics alone rather than fail over the lookup
+		result, err := s.lookup.Check(ctx, phoneNumber)
+		switch {
+		case err != nil:
+			s.logger.Warn("spam lookup failed", zap.String("phone_number", phoneNumber), zap.Error(err))
+		case result.SpamLikely:
+			score += spamLookupScore
+			label := result.Label
+			if label == "" {
+				label = "spam"
+			}
+			reasons = append(reasons, "Lookup labels it "+label)
+		case result.Score < spamLowReputationUpTo:
+			score += spamLowReputation
+			reasons = append(reasons, fmt.Sprintf("Lookup reputation score %d", result.Score))
+		}
+	}
+
+	if score > 100 {
+		score = 100
+	}
+	return score, reasons, nil
+}
+
+// Screen scores an inbound call the first time one of its webhooks arrives
+// and stores the score on the call. Flagged callers are queued for review
+// and returned, and blocked once they reach the auto-block threshold. Other
+// calls return nil.
+func (s *SpamService) Screen(ctx context.Context, call *domain.Call) (*domain.SpamCaller, error) {
+	if !call.IsInbound() || call.SpamScore != nil {
+		return nil, nil
+	}
+	phone := normalizePhoneNumber(call.FromNumber)
+	if phone == "" {
+		return nil, nil
+	}
+
+	score, reasons, err := s.Score(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.calls.SetSpamScore(ctx, call.ID, score); err != nil {
+		return nil, fmt.Errorf("failed to save spam score: %w", err)
+	}
+	call.SpamScore = &score
+	if score < domain.SpamFlagScore {
+		return nil, nil
+	}
+
+	caller, err := s.repo.RecordFlag(ctx, phone, score, reasons, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("inbound call flagged as spam",
+		zap.String("call_id", call.ID.String()),
+		zap.String("phone_number", phone),
+		zap.Int("score", score),
+		zap.Strings("reasons", reasons),
+	)
+	if err := setDisposition(ctx, s.calls, call, domain.CallDispositionSpam, domain.DispositionSourceAuto); err != nil {
+		s.logger.Warn("failed to set call disposition",
+			zap.String("call_id", call.ID.String()),
+			zap.Error(err),
+		)
+	}
+
+	if s.autoBlockAfter > 0 && caller.Status == domain.SpamCallerPending && caller.FlaggedCalls >= s.autoBlockAfter {
+		reason := fmt.Sprintf("Auto-blocked as spam after %d flagged calls", caller.FlaggedCalls)
+		if err := s.block(ctx, caller, reason, nil); err != nil {
+			s.logger.Error("failed to auto-block spam caller",
+				zap.String("phone_number", phone),
+				zap.Error(err),
+			)
+		}
+	}
+	return caller, nil
+}
+
+// ListQueue returns the callers with the given status, most recently
+// flagged first; pending callers by default.
+func (s *SpamService) ListQueue(ctx context.Context, status domain.SpamCallerStatus) ([]*domain.SpamCaller, error) {
+	if status == "" {
+		status = domain.SpamCallerPending
+	}
+	if !status.IsValid() {
+		return nil, apperrors.ValidationFailed("status must be pending, blocked or dismissed")
+	}
+	callers, err := s.repo.List(ctx, status, spamQueueLimit)
+	if err != nil {
+		return nil, err
+	}
+	if callers == nil {
+		callers = []*domain.SpamCaller{}
+	}
+	return callers, nil
+}
+
+// Block confirms a flagged caller as spam and blocks their inbound calls.
+func (s *SpamService) Block(ctx context.Context, id uuid.UUID, reviewer uuid.UUID) error {
+	caller, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if caller.Status == domain.SpamCallerBlocked {
+		return nil
+	}
+	return s.block(ctx, caller, "Reviewed as spam", &reviewer)
+}
+
+// Dismiss marks a flagged caller as not spam, so their calls are not flagged
+// again. Blocked callers are unblocked on the phone numbers page instead.
+func (s *SpamService) Dismiss(ctx context.Context, id uuid.UUID, reviewer uuid.UUID) error {
+	caller, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if caller.Status == domain.SpamCallerBlocked {
+		return apperrors.ValidationFailed("caller is blocked; unblock the number instead")
+	}
+	if err := s.repo.Review(ctx, id, domain.SpamCallerDismissed, &reviewer, s.clock.Now()); err != nil {
+		return err
+	}
+	s.logger.Info("spam caller dismissed", zap.String("phone_number", caller.PhoneNumber))
+	return nil
+}
+
+// block adds the caller to the blocklist for inbound calls and marks them
+// blocked. reviewer is nil for automatic blocks.
+func (s *SpamService) block(ctx context.Context, caller *domain.SpamCaller, reason string, reviewer *uuid.UUID) error {
+	if s.blocker == nil {
+		return errors.New("blocking is not enabled")
+	}
+	_, err := s.blocker.Block(ctx, &BlockNumberRequest{
+		PhoneNumber: caller.PhoneNumber,
+		Reason:      reason,
+		Direction:   domain.BlockDirectionInbound,
+	})
+	if err != nil && !errors.Is(err, ErrBlandWriteQueued) {
+		return err
+	}
+	if err := s.repo.Review(ctx, caller.ID, domain.SpamCallerBlocked, reviewer, s.clock.Now()); err != nil {
+		return err
+	}
+	caller.Status = domain.SpamCallerBlocked
+	caller.AutoBlocked = reviewer == nil
+
+	s.logger.Info("spam caller blocked",
+		zap.String("phone_number", caller.PhoneNumber),
+		zap.Bool("auto_blocked", caller.AutoBlocked),
+	)
+	return nil
+}
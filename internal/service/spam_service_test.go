@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/reputation"
+)
+
+// MockSpamCallerRepository keeps spam callers in memory and returns a fixed
+// recent call count.
+type MockSpamCallerRepository struct {
+	callers     map[string]*domain.SpamCaller
+	recentCalls int
+}
+
+func NewMockSpamCallerRepository() *MockSpamCallerRepository {
+	return &MockSpamCallerRepository{callers: make(map[string]*domain.SpamCaller)}
+}
+
+func (m *MockSpamCallerRepository) RecordFlag(ctx context.Context, phoneNumber string, score int, reasons []string, at time.Time) (*domain.SpamCaller, error) {
+	caller, ok := m.callers[phoneNumber]
+	if !ok {
+		caller = &domain.SpamCaller{
+			ID:             uuid.New(),
+			PhoneNumber:    phoneNumber,
+			Status:         domain.SpamCallerPending,
+			FirstFlaggedAt: at,
+		}
+		m.callers[phoneNumber] = caller
+	}
+	caller.Score = score
+	caller.Reasons = reasons
+	caller.FlaggedCalls++
+	caller.LastFlaggedAt = at
+	stored := *caller
+	return &stored, nil
+}
+
+func (m *MockSpamCallerRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SpamCaller, error) {
+	for _, caller := range m.callers {
+		if caller.ID == id {
+			stored := *caller
+			return &stored, nil
+		}
+	}
+	return nil, apperrors.NotFound("spam caller")
+}
+
+func (m *MockSpamCallerRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.SpamCaller, error) {
+	caller, ok := m.callers[phoneNumber]
+	if !ok {
+		return nil, apperrors.NotFound("spam caller")
+	}
+	stored := *caller
+	return &stored, nil
+}
+
+func (m *MockSpamCallerRepository) List(ctx context.Context, status domain.SpamCallerStatus, limit int) ([]*domain.SpamCaller, error) {
+	var callers []*domain.SpamCaller
+	for _, caller := range m.callers {
+		if caller.Status == status {
+			callers = append(callers, caller)
+		}
+	}
+	return callers, nil
+}
+
+func (m *MockSpamCallerRepository) Review(ctx context.Context, id uuid.UUID, status domain.SpamCallerStatus, reviewedBy *uuid.UUID, at time.Time) error {
+	for _, caller := range m.callers {
+		if caller.ID == id {
+			caller.Status = status
+			caller.AutoBlocked = status == domain.SpamCallerBlocked && reviewedBy == nil
+			caller.ReviewedAt = &at
+			caller.ReviewedBy = reviewedBy
+			return nil
+		}
+	}
+	return apperrors.NotFound("spam caller")
+}
+
+func (m *MockSpamCallerRepository) CountCallsFrom(ctx context.Context, phoneNumber string, since time.Time) (int, error) {
+	return m.recentCalls, nil
+}
+
+// recordingBlocker records the numbers blocked.
+type recordingBlocker struct {
+	blocked []*BlockNumberRequest
+}
+
+func (b *recordingBlocker) Block(ctx context.Context, req *BlockNumberRequest) (*domain.BlockedNumber, error) {
+	b.blocked = append(b.blocked, req)
+	return domain.NewBlockedNumber(req.PhoneNumber, req.Direction, req.Reason), nil
+}
+
+// fakeSpamLookup answers every lookup with the same result or error.
+type fakeSpamLookup struct {
+	result *reputation.Result
+	err    error
+}
+
+func (f *fakeSpamLookup) Check(ctx context.Context, phoneNumber string) (*reputation.Result, error) {
+	return f.result, f.err
+}
+
+func newInboundCall(calls *MockCallRepository, from string) *domain.Call {
+	call := &domain.Call{ID: uuid.New(), FromNumber: from, PhoneNumber: "+15550001111", Status: domain.CallStatusInProgress}
+	calls.calls[call.ID] = call
+	return call
+}
+
+func TestSpamService_Score(t *testing.T) {
+	repo := NewMockSpamCallerRepository()
+	svc := NewSpamService(repo, NewMockCallRepository(), zap.NewNop(), &SpamServiceConfig{
+		Prefixes:        []string{"+1900*"},
+		MaxCallsPerHour: 3,
+	}, nil)
+
+	score, reasons, err := svc.Score(context.Background(), "+15551234567")
+	if err != nil || score != 0 || len(reasons) != 0 {
+		t.Errorf("Score() of a clean caller = %d, %v, %v; want 0", score, reasons, err)
+	}
+
+	score, _, _ = svc.Score(context.Background(), "+19005551234")
+	if score != spamPrefixScore {
+		t.Errorf("Score() with a spam prefix = %d, want %d", score, spamPrefixScore)
+	}
+
+	repo.recentCalls = 4
+	score, reasons, _ = svc.Score(context.Background(), "+19005551234")
+	if score != 100 || len(reasons) != 2 {
+		t.Errorf("Score() with a spam prefix and frequent calls = %d, %v; want 100 with two reasons", score, reasons)
+	}
+}
+
+func TestSpamService_ScoreLookup(t *testing.T) {
+	lookup := &fakeSpamLookup{result: &reputation.Result{Score: 10, SpamLikely: true, Label: "Telemarketer"}}
+	svc := NewSpamService(NewMockSpamCallerRepository(), NewMockCallRepository(), zap.NewNop(), nil, nil)
+	svc.SetLookup(lookup)
+
+	score, reasons, err := svc.Score(context.Background(), "+15551234567")
+	if err != nil || score != spamLookupScore || reasons[0] != "Lookup labels it Telemarketer" {
+		t.Errorf("Score() = %d, %v, %v", score, reasons, err)
+	}
+
+	lookup.result, lookup.err = nil, errors.New("lookup unavailable")
+	score, _, err = svc.Score(context.Background(), "+15551234567")
+	if err != nil || score != 0 {
+		t.Errorf("Score() with the lookup down = %d, %v; want 0 and no error", score, err)
+	}
+}
+
+func TestSpamService_Screen(t *testing.T) {
+	repo := NewMockSpamCallerRepository()
+	calls := NewMockCallRepository()
+	svc := NewSpamService(repo, calls, zap.NewNop(), &SpamServiceConfig{Prefixes: []string{"+1900"}}, nil)
+
+	clean := newInboundCall(calls, "+15551234567")
+	caller, err := svc.Screen(context.Background(), clean)
+	if err != nil || caller != nil {
+		t.Fatalf("Screen() of a clean call = %v, %v; want nil", caller, err)
+	}
+	if clean.SpamScore == nil || *clean.SpamScore != 0 || clean.IsSpam() {
+		t.Errorf("clean call spam score = %v, want 0", clean.SpamScore)
+	}
+
+	spam := newInboundCall(calls, "+19005551234")
+	caller, err = svc.Screen(context.Background(), spam)
+	if err != nil || caller == nil {
+		t.Fatalf("Screen() of a spam call = %v, %v; want the flagged caller", caller, err)
+	}
+	if !spam.IsSpam() || caller.FlaggedCalls != 1 || caller.Status != domain.SpamCallerPending {
+		t.Errorf("flagged caller = %+v, call score = %v", caller, spam.SpamScore)
+	}
+	if spam.Disposition == nil || *spam.Disposition != domain.CallDispositionSpam {
+		t.Errorf("flagged call disposition = %v, want spam", spam.Disposition)
+	}
+
+	// Later webhooks for the same call are not screened again
+	if caller, _ := svc.Screen(context.Background(), spam); caller != nil {
+		t.Error("expected a scored call not to be screened again")
+	}
+
+	outbound := &domain.Call{ID: uuid.New(), FromNumber: "+19005551234", PromptID: &spam.ID}
+	if caller, _ := svc.Screen(context.Background(), outbound); caller != nil || outbound.SpamScore != nil {
+		t.Error("expected outbound calls not to be screened")
+	}
+}
+
+func TestSpamService_AutoBlock(t *testing.T) {
+	repo := NewMockSpamCallerRepository()
+	calls := NewMockCallRepository()
+	blocker := &recordingBlocker{}
+	svc := NewSpamService(repo, calls, zap.NewNop(), &SpamServiceConfig{Prefixes: []string{"+1900"}, AutoBlockAfter: 2}, nil)
+	svc.SetBlocker(blocker)
+
+	if _, err := svc.Screen(context.Background(), newInboundCall(calls, "+19005551234")); err != nil {
+		t.Fatal(err)
+	}
+	if len(blocker.blocked) != 0 {
+		t.Fatal("expected no block after the first flagged call")
+	}
+
+	caller, err := svc.Screen(context.Background(), newInboundCall(calls, "+19005551234"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocker.blocked) != 1 || blocker.blocked[0].Direction != domain.BlockDirectionInbound {
+		t.Fatalf("blocked %+v, want one inbound block", blocker.blocked)
+	}
+	if caller.Status != domain.SpamCallerBlocked || !caller.AutoBlocked {
+		t.Errorf("caller = %+v, want auto-blocked", caller)
+	}
+}
+
+func TestSpamService_Review(t *testing.T) {
+	repo := NewMockSpamCallerRepository()
+	calls := NewMockCallRepository()
+	blocker := &recordingBlocker{}
+	svc := NewSpamService(repo, calls, zap.NewNop(), &SpamServiceConfig{Prefixes: []string{"+1900"}}, nil)
+	svc.SetBlocker(blocker)
+	reviewer := uuid.New()
+
+	dismissed, _ := svc.Screen(context.Background(), newInboundCall(calls, "+19005550001"))
+	if err := svc.Dismiss(context.Background(), dismissed.ID, reviewer); err != nil {
+		t.Fatalf("Dismiss() error = %v", err)
+	}
+	if caller, _ := svc.Screen(context.Background(), newInboundCall(calls, "+19005550001")); caller != nil {
+		t.Error("expected a dismissed caller not to be flagged again")
+	}
+
+	blocked, _ := svc.Screen(context.Background(), newInboundCall(calls, "+19005550002"))
+	if err := svc.Block(context.Background(), blocked.ID, reviewer); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	stored := repo.callers["+19005550002"]
+	if stored.Status != domain.SpamCallerBlocked || stored.AutoBlocked || *stored.ReviewedBy != reviewer || len(blocker.blocked) != 1 {
+		t.Errorf("blocked caller = %+v", stored)
+	}
+	if err := svc.Dismiss(context.Background(), blocked.ID, reviewer); !apperrors.IsUserError(err) {
+		t.Errorf("Dismiss() of a blocked caller = %v, want a validation error", err)
+	}
+
+	queue, err := svc.ListQueue(context.Background(), "")
+	if err != nil || len(queue) != 0 {
+		t.Errorf("ListQueue() = %v, %v; want no pending callers", queue, err)
+	}
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// TagService manages tags, applies them to calls, quotes and customers,
+// and keeps the saved tag views shown on the dashboard.
+type TagService struct {
+	repo        domain.TagRepository
+	calls       domain.CallRepository
+	customers   domain.CustomerRepository
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+
+	// Configuration
+	freeForm     bool
+	maxPerRecord int
+}
+
+// TagServiceConfig holds configuration for the tag service.
+type TagServiceConfig struct {
+	FreeForm     bool // Applying an unknown name creates the tag
+	MaxPerRecord int  // Most tags one record can carry; 0 is unlimited
+}
+
+// DefaultTagServiceConfig returns sensible defaults.
+func DefaultTagServiceConfig() *TagServiceConfig {
+	return &TagServiceConfig{
+		FreeForm:     true,
+		MaxPerRecord: 20,
+	}
+}
+
+// NewTagService creates a new TagService. auditLogger may be nil.
+func NewTagService(
+	repo domain.TagRepository,
+	calls domain.CallRepository,
+	customers domain.CustomerRepository,
+	auditLogger *audit.Logger,
+	logger *zap.Logger,
+	config *TagServiceConfig,
+) *TagService {
+	if config == nil {
+		config = DefaultTagServiceConfig()
+	}
+	return &TagService{
+		repo:         repo,
+		calls:        calls,
+		customers:    customers,
+		auditLogger:  auditLogger,
+		logger:       logger,
+		freeForm:     config.FreeForm,
+		maxPerRecord: config.MaxPerRecord,
+	}
+}
+
+// TagRequest holds the fields for creating or updating a managed tag. The
+// name is only read when creating.
+type TagRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description"`
+	Color       string `json:"color"`
+}
+
+// TagViewRequest holds the fields for saving a tag view.
+type TagViewRequest struct {
+	Name       string               `json:"name"`
+	EntityType domain.TagEntityType `json:"entity_type"`
+	Tags       []string             `json:"tags"`
+	Shared     bool                 `json:"shared"` // Visible to everyone; admins only
+}
+
+// TagViewCount is a saved view with how many records it matches.
+type TagViewCount struct {
+	*domain.TagView
+	Count int `json:"count"`
+}
+
+// ListTags returns every tag with how many records carry it.
+func (s *TagService) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+	return s.repo.List(ctx)
+}
+
+// CreateTag creates a managed tag.
+func (s *TagService) CreateTag(ctx context.Context, actor UserActor, req *TagRequest) (*domain.Tag, error) {
+	tag, err := domain.NewTag(req.Name, true, actor.userID())
+	if err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	tag.Description = req.Description
+	tag.Color = req.Color
+	if err := tag.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+
+	if err := s.repo.Create(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("tag created", zap.String("tag", tag.Name))
+	if s.auditLogger != nil {
+		s.auditLogger.TagChanged(ctx, actor.id(), actor.email(), tag.Name, "tag created", actor.IP, actor.RequestID)
+	}
+	return tag, nil
+}
+
+// UpdateTag sets a tag's description and color. A free-form tag becomes
+// managed.
+func (s *TagService) UpdateTag(ctx context.Context, actor UserActor, name string, req *TagRequest) (*domain.Tag, error) {
+	tag, err := s.getTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	tag.Description = req.Description
+	tag.Color = req.Color
+	tag.Managed = true
+	if err := tag.Validate(); err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	tag.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("tag updated", zap.String("tag", tag.Name))
+	if s.auditLogger != nil {
+		s.auditLogger.TagChanged(ctx, actor.id(), actor.email(), tag.Name, "tag updated", actor.IP, actor.RequestID)
+	}
+	return tag, nil
+}
+
+// DeleteTag deletes a tag and takes it off every record.
+func (s *TagService) DeleteTag(ctx context.Context, actor UserActor, name string) error {
+	tag, err := s.getTag(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, tag.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("tag deleted", zap.String("tag", tag.Name))
+	if s.auditLogger != nil {
+		s.auditLogger.TagDeleted(ctx, actor.id(), actor.email(), tag.Name, actor.IP, actor.RequestID)
+	}
+	return nil
+}
+
+// TagsFor returns the tags on a record.
+func (s *TagService) TagsFor(ctx context.Context, entityType domain.TagEntityType, entityID uuid.UUID) ([]*domain.Tag, error) {
+	if err := s.checkEntity(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFor(ctx, entityType, entityID)
+}
+
+// AddTag tags a record, creating the tag if free-form tags are allowed and
+// there is none by that name. It returns the record's tags.
+func (s *TagService) AddTag(ctx context.Context, actor UserActor, entityType domain.TagEntityType, entityID uuid.UUID, name string) ([]*domain.Tag, error) {
+	if err := s.checkEntity(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.getTag(ctx, name)
+	if apperrors.IsNotFound(err) && s.freeForm {
+		var created *domain.Tag
+		created, err = domain.NewTag(name, false, actor.userID())
+		if err != nil {
+			return nil, apperrors.ValidationFailed(err.Error())
+		}
+		tag, err = s.repo.GetOrCreate(ctx, created)
+	}
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, apperrors.ValidationFailed(fmt.Sprintf("unknown tag %q; only managed tags can be applied", name))
+		}
+		return nil, err
+	}
+
+	tags, err := s.repo.ListFor(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	if hasTag(tags, tag.ID) {
+		return tags, nil
+	}
+	if s.maxPerRecord > 0 && len(tags) >= s.maxPerRecord {
+		return nil, apperrors.ValidationFailed(fmt.Sprintf("a %s can carry at most %d tags", entityType, s.maxPerRecord))
+	}
+
+	if err := s.repo.Apply(ctx, tag.ID, entityType, entityID, actor.userID()); err != nil {
+		return nil, err
+	}
+	s.logger.Debug("tag applied",
+		zap.String("tag", tag.Name),
+		zap.String("entity_type", string(entityType)),
+		zap.String("entity_id", entityID.String()),
+	)
+	return s.repo.ListFor(ctx, entityType, entityID)
+}
+
+// AddTagToMany tags records of one type together, creating the tag as
+// AddTag does. It first checks every record and returns the error for each
+// one that can't take the tag, tagging none of them if there are any.
+func (s *TagService) AddTagToMany(ctx context.Context, actor UserActor, entityType domain.TagEntityType, entityIDs []uuid.UUID, name string) (map[uuid.UUID]error, error) {
+	tag, err := s.getTag(ctx, name)
+	if apperrors.IsNotFound(err) && s.freeForm {
+		var created *domain.Tag
+		created, err = domain.NewTag(name, false, actor.userID())
+		if err != nil {
+			return nil, apperrors.ValidationFailed(err.Error())
+		}
+		tag, err = s.repo.GetOrCreate(ctx, created)
+	}
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, apperrors.ValidationFailed(fmt.Sprintf("unknown tag %q; only managed tags can be applied", name))
+		}
+		return nil, err
+	}
+
+	failures := make(map[uuid.UUID]error)
+	for _, entityID := range entityIDs {
+		if err := s.checkEntity(ctx, entityType, entityID); err != nil {
+			failures[entityID] = err
+			continue
+		}
+		if s.maxPerRecord <= 0 {
+			continue
+		}
+		tags, err := s.repo.ListFor(ctx, entityType, entityID)
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) >= s.maxPerRecord && !hasTag(tags, tag.ID) {
+			failures[entityID] = apperrors.ValidationFailed(fmt.Sprintf("a %s can carry at most %d tags", entityType, s.maxPerRecord))
+		}
+	}
+	if len(failures) > 0 {
+		return failures, nil
+	}
+
+	if err := s.repo.ApplyMany(ctx, tag.ID, entityType, entityIDs, actor.userID()); err != nil {
+		return nil, err
+	}
+	s.logger.Debug("tag applied to records",
+		zap.String("tag", tag.Name),
+		zap.String("entity_type", string(entityType)),
+		zap.Int("count", len(entityIDs)),
+	)
+	return nil, nil
+}
+
+func hasTag(tags []*domain.Tag, id uuid.UUID) bool {
+	for _, t := range tags {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveTag takes a tag off a record and returns the record's tags.
+func (s *TagService) RemoveTag(ctx context.Context, entityType domain.TagEntityType, entityID uuid.UUID, name string) ([]*domain.Tag, error) {
+	if err := s.checkEntity(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+	tag, err := s.getTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Remove(ctx, tag.ID, entityType, entityID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFor(ctx, entityType, entityID)
+}
+
+// ListViews returns the shared views and the user's own with how many
+// records each matches.
+func (s *TagService) ListViews(ctx context.Context, userID uuid.UUID) ([]*TagViewCount, error) {
+	views, err := s.repo.ListViews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]*TagViewCount, 0, len(views))
+	for _, view := range views {
+		count, err := s.count(ctx, view)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, &TagViewCount{TagView: view, Count: count})
+	}
+	return counts, nil
+}
+
+// CreateView saves a tag view for the actor, or for everyone when shared.
+func (s *TagService) CreateView(ctx context.Context, actor UserActor, req *TagViewRequest) (*domain.TagView, error) {
+	if actor.User == nil {
+		return nil, apperrors.New(apperrors.CodeUnauthorized, "authentication required")
+	}
+	owner := &actor.User.ID
+	if req.Shared {
+		if !actor.User.IsAdmin() {
+			return nil, apperrors.New(apperrors.CodeForbidden, "only admins can share views")
+		}
+		owner = nil
+	}
+
+	view, err := domain.NewTagView(req.Name, req.EntityType, req.Tags, owner)
+	if err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	if err := s.repo.CreateView(ctx, view); err != nil {
+		return nil, err
+	}
+	s.logger.Info("tag view created", zap.String("view_id", view.ID.String()), zap.Bool("shared", view.IsShared()))
+	return view, nil
+}
+
+// DeleteView deletes a saved view. Users delete their own views; shared
+// views are deleted by admins.
+func (s *TagService) DeleteView(ctx context.Context, actor UserActor, id uuid.UUID) error {
+	if actor.User == nil {
+		return apperrors.New(apperrors.CodeUnauthorized, "authentication required")
+	}
+	view, err := s.repo.GetView(ctx, id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case view.IsShared() && !actor.User.IsAdmin():
+		return apperrors.New(apperrors.CodeForbidden, "only admins can delete shared views")
+	case !view.IsShared() && *view.UserID != actor.User.ID:
+		// Other users' views are private
+		return apperrors.NotFound("tag view")
+	}
+	return s.repo.DeleteView(ctx, id)
+}
+
+// count returns how many records a view matches.
+func (s *TagService) count(ctx context.Context, view *domain.TagView) (int, error) {
+	switch view.EntityType {
+	case domain.TagEntityCustomer:
+		return s.customers.Count(ctx, &domain.CustomerListFilter{Tags: view.Tags})
+	case domain.TagEntityQuote:
+		return s.calls.Count(ctx, &domain.CallListFilter{HasQuote: true, QuoteTags: view.Tags})
+	default:
+		return s.calls.Count(ctx, &domain.CallListFilter{Tags: view.Tags})
+	}
+}
+
+// getTag returns the tag with the normalized form of name.
+func (s *TagService) getTag(ctx context.Context, name string) (*domain.Tag, error) {
+	normalized, err := domain.NormalizeTagName(name)
+	if err != nil {
+		return nil, apperrors.ValidationFailed(err.Error())
+	}
+	return s.repo.GetByName(ctx, normalized)
+}
+
+// checkEntity returns a not-found error unless the record exists. A quote
+// exists once its call has one generated.
+func (s *TagService) checkEntity(ctx context.Context, entityType domain.TagEntityType, entityID uuid.UUID) error {
+	switch entityType {
+	case domain.TagEntityCustomer:
+		_, err := s.customers.GetByID(ctx, entityID)
+		return err
+	case domain.TagEntityCall, domain.TagEntityQuote:
+		call, err := s.calls.GetByID(ctx, entityID)
+		if err != nil {
+			return err
+		}
+		if call.DeletedAt != nil {
+			return apperrors.NotFound("call")
+		}
+		if entityType == domain.TagEntityQuote && !call.HasQuote() {
+			return apperrors.NotFound("quote")
+		}
+		return nil
+	}
+	return apperrors.ValidationFailed(fmt.Sprintf("invalid tag entity type %q", entityType))
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+type stubTagRepo struct {
+	tags    map[string]*domain.Tag
+	applied map[string]map[uuid.UUID]bool // Record key to the IDs of its tags
+	views   map[uuid.UUID]*domain.TagView
+}
+
+func newStubTagRepo() *stubTagRepo {
+	return &stubTagRepo{
+		tags:    map[string]*domain.Tag{},
+		applied: map[string]map[uuid.UUID]bool{},
+		views:   map[uuid.UUID]*domain.TagView{},
+	}
+}
+
+func tagRecordKey(entityType domain.TagEntityType, entityID uuid.UUID) string {
+	return string(entityType) + ":" + entityID.String()
+}
+
+func (r *stubTagRepo) Create(ctx context.Context, tag *domain.Tag) error {
+	if _, ok := r.tags[tag.Name]; ok {
+		return apperrors.New(apperrors.CodeAlreadyExists, "a tag with this name already exists")
+	}
+	stored := *tag
+	r.tags[tag.Name] = &stored
+	return nil
+}
+
+func (r *stubTagRepo) GetOrCreate(ctx context.Context, tag *domain.Tag) (*domain.Tag, error) {
+	if _, ok := r.tags[tag.Name]; !ok {
+		stored := *tag
+		r.tags[tag.Name] = &stored
+	}
+	return r.GetByName(ctx, tag.Name)
+}
+
+func (r *stubTagRepo) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	tag, ok := r.tags[name]
+	if !ok {
+		return nil, apperrors.NotFound("tag")
+	}
+	found := *tag
+	return &found, nil
+}
+
+func (r *stubTagRepo) Update(ctx context.Context, tag *domain.Tag) error {
+	if _, ok := r.tags[tag.Name]; !ok {
+		return apperrors.NotFound("tag")
+	}
+	stored := *tag
+	r.tags[tag.Name] = &stored
+	return nil
+}
+
+func (r *stubTagRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	for name, tag := range r.tags {
+		if tag.ID == id {
+			delete(r.tags, name)
+			for _, ids := range r.applied {
+				delete(ids, id)
+			}
+			return nil
+		}
+	}
+	return apperrors.NotFound("tag")
+}
+
+func (r *stubTagRepo) List(ctx context.Context) ([]*domain.Tag, error) {
+	var tags []*domain.Tag
+	for _, tag := range r.tags {
+		listed := *tag
+		tags = append(tags, &listed)
+	}
+	return tags, nil
+}
+
+func (r *stubTagRepo) Apply(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityID uuid.UUID, createdBy *uuid.UUID) error {
+	key := tagRecordKey(entityType, entityID)
+	if r.applied[key] == nil {
+		r.applied[key] = map[uuid.UUID]bool{}
+	}
+	r.applied[key][tagID] = true
+	return nil
+}
+
+func (r *stubTagRepo) ApplyMany(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityIDs []uuid.UUID, createdBy *uuid.UUID) error {
+	for _, entityID := range entityIDs {
+		if err := r.Apply(ctx, tagID, entityType, entityID, createdBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *stubTagRepo) Remove(ctx context.Context, tagID uuid.UUID, entityType domain.TagEntityType, entityID uuid.UUID) error {
+	ids := r.applied[tagRecordKey(entityType, entityID)]
+	if !ids[tagID] {
+		return apperrors.NotFound("tag")
+	}
+	delete(ids, tagID)
+	return nil
+}
+
+func (r *stubTagRepo) ListFor(ctx context.Context, entityType domain.TagEntityType, entityID uuid.UUID) ([]*domain.Tag, error) {
+	ids := r.applied[tagRecordKey(entityType, entityID)]
+	var tags []*domain.Tag
+	for _, tag := range r.tags {
+		if ids[tag.ID] {
+			listed := *tag
+			tags = append(tags, &listed)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *stubTagRepo) CreateView(ctx context.Context, view *domain.TagView) error {
+	stored := *view
+	r.views[view.ID] = &stored
+	return nil
+}
+
+func (r *stubTagRepo) GetView(ctx context.Context, id uuid.UUID) (*domain.TagView, error) {
+	view, ok := r.views[id]
+	if !ok {
+		return nil, apperrors.NotFound("tag view")
+	}
+	found := *view
+	return &found, nil
+}
+
+func (r *stubTagRepo) ListViews(ctx context.Context, userID uuid.UUID) ([]*domain.TagView, error) {
+	var views []*domain.TagView
+	for _, view := range r.views {
+		if view.IsShared() || *view.UserID == userID {
+			listed := *view
+			views = append(views, &listed)
+		}
+	}
+	return views, nil
+}
+
+func (r *stubTagRepo) DeleteView(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.views[id]; !ok {
+		return apperrors.NotFound("tag view")
+	}
+	delete(r.views, id)
+	return nil
+}
+
+func tagNames(tags []*domain.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+func TestTagService_AddAndRemoveTags(t *testing.T) {
+	repo := newStubTagRepo()
+	calls := NewMockCallRepository()
+	svc := NewTagService(repo, calls, NewMockCustomerRepository(), nil, zap.NewNop(), &TagServiceConfig{FreeForm: true, MaxPerRecord: 2})
+	ctx := context.Background()
+	actor := UserActor{User: &domain.User{ID: uuid.New(), Role: domain.UserRoleMember}}
+
+	call := domain.NewCall("prov-1", "bland", "+15551234567", "+15557654321")
+	calls.calls[call.ID] = call
+
+	tags, err := svc.AddTag(ctx, actor, domain.TagEntityCall, call.ID, "Hot Lead")
+	if err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	if got := tagNames(tags); len(got) != 1 || got[0] != "hot-lead" {
+		t.Errorf("tags = %v, want [hot-lead]", got)
+	}
+	if repo.tags["hot-lead"].Managed {
+		t.Error("a tag created by applying it is managed")
+	}
+
+	// Applying a tag twice keeps one
+	if tags, err := svc.AddTag(ctx, actor, domain.TagEntityCall, call.ID, "hot-lead"); err != nil || len(tags) != 1 {
+		t.Errorf("AddTag() again = %v, %v, want the one tag", tagNames(tags), err)
+	}
+	if _, err := svc.AddTag(ctx, actor, domain.TagEntityCall, call.ID, "follow-up-needed"); err != nil {
+		t.Fatalf("AddTag() error = %v", err)
+	}
+	if _, err := svc.AddTag(ctx, actor, domain.TagEntityCall, call.ID, "vip"); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("AddTag() over the limit error = %v, want validation failure", err)
+	}
+
+	// Quotes are tagged separately and only once the call has one
+	if _, err := svc.AddTag(ctx, actor, domain.TagEntityQuote, call.ID, "vip"); !apperrors.IsNotFound(err) {
+		t.Errorf("AddTag() on a call without a quote error = %v, want not found", err)
+	}
+	if _, err := svc.AddTag(ctx, actor, domain.TagEntityCustomer, uuid.New(), "vip"); !apperrors.IsNotFound(err) {
+		t.Errorf("AddTag() on a missing customer error = %v, want not found", err)
+	}
+
+	tags, err = svc.RemoveTag(ctx, domain.TagEntityCall, call.ID, "Hot Lead")
+	if err != nil {
+		t.Fatalf("RemoveTag() error = %v", err)
+	}
+	if got := tagNames(tags); len(got) != 1 || got[0] != "follow-up-needed" {
+		t.Errorf("tags = %v, want [follow-up-needed]", got)
+	}
+}
+
+func TestTagService_ManagedOnly(t *testing.T) {
+	repo := newStubTagRepo()
+	calls := NewMockCallRepository()
+	svc := NewTagService(repo, calls, NewMockCustomerRepository(), nil, zap.NewNop(), &TagServiceConfig{FreeForm: false})
+	ctx := context.Background()
+	admin := UserActor{User: &domain.User{ID: uuid.New(), Role: domain.UserRoleAdmin}}
+
+	call := domain.NewCall("prov-1", "bland", "+15551234567", "+15557654321")
+	calls.calls[call.ID] = call
+
+	if _, err := svc.AddTag(ctx, admin, domain.TagEntityCall, call.ID, "hot-lead"); apperrors.GetCode(err) != apperrors.CodeValidation {
+		t.Errorf("AddTag() with an unknown tag error = %v, want validation failure", err)
+	}
+
+	tag, err := svc.CreateTag(ctx, admin, &TagRequest{Name: "Hot Lead", Color: "#E11D48"})
+	if err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+	if !tag.Managed || tag.Color != "#e11d48" || tag.CreatedBy == nil || *tag.CreatedBy != admin.User.ID {
+		t.Errorf("CreateTag() = %+v, want a managed tag created by the admin", tag)
+	}
+	if _, err := svc.CreateTag(ctx, admin, &TagRequest{Name: "hot-lead"}); apperrors.GetCode(err) != apperrors.CodeAlreadyExists {
+		t.Errorf("CreateTag() duplicate error = %v, want already exists", err)
+	}
+	if _, err := svc.AddTag(ctx, admin, domain.TagEntityCall, call.ID, "hot-lead"); err != nil {
+		t.Fatalf("AddTag() with a managed tag error = %v", err)
+	}
+
+	if err := svc.DeleteTag(ctx, admin, "hot-lead"); err != nil {
+		t.Fatalf("DeleteTag() error = %v", err)
+	}
+	if tags, _ := svc.TagsFor(ctx, domain.TagEntityCall, call.ID); len(tags) != 0 {
+		t.Errorf("tags = %v after deleting the tag, want none", tagNames(tags))
+	}
+}
+
+func TestTagService_Views(t *testing.T) {
+	repo := newStubTagRepo()
+	svc := NewTagService(repo, NewMockCallRepository(), NewMockCustomerRepository(), nil, zap.NewNop(), nil)
+	ctx := context.Background()
+	admin := UserActor{User: &domain.User{ID: uuid.New(), Role: domain.UserRoleAdmin}}
+	user := UserActor{User: &domain.User{ID: uuid.New(), Role: domain.UserRoleMember}}
+	other := UserActor{User: &domain.User{ID: uuid.New(), Role: domain.UserRoleMember}}
+
+	if _, err := svc.CreateView(ctx, user, &TagViewRequest{Name: "Hot", EntityType: domain.TagEntityCall, Tags: []string{"hot-lead"}, Shared: true}); apperrors.GetCode(err) != apperrors.CodeForbidden {
+		t.Errorf("CreateView() shared by a user error = %v, want forbidden", err)
+	}
+	if _, err := svc.CreateView(ctx, UserActor{}, &TagViewRequest{Name: "Hot", EntityType: domain.TagEntityCall, Tags: []string{"hot-lead"}}); apperrors.GetCode(err) != apperrors.CodeUnauthorized {
+		t.Errorf("CreateView() without a user error = %v, want unauthorized", err)
+	}
+
+	shared, err := svc.CreateView(ctx, admin, &TagViewRequest{Name: "Hot leads", EntityType: domain.TagEntityCall, Tags: []string{"hot-lead"}, Shared: true})
+	if err != nil {
+		t.Fatalf("CreateView() error = %v", err)
+	}
+	own, err := svc.CreateView(ctx, user, &TagViewRequest{Name: "My VIPs", EntityType: domain.TagEntityCustomer, Tags: []string{"vip"}})
+	if err != nil {
+		t.Fatalf("CreateView() error = %v", err)
+	}
+
+	views, err := svc.ListViews(ctx, other.User.ID)
+	if err != nil {
+		t.Fatalf("ListViews() error = %v", err)
+	}
+	if len(views) != 1 || views[0].ID != shared.ID {
+		t.Errorf("another user's views = %v, want only the shared one", views)
+	}
+
+	if err := svc.DeleteView(ctx, other, own.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("DeleteView() of another user's view error = %v, want not found", err)
+	}
+	if err := svc.DeleteView(ctx, user, shared.ID); apperrors.GetCode(err) != apperrors.CodeForbidden {
+		t.Errorf("DeleteView() of a shared view by a user error = %v, want forbidden", err)
+	}
+	if err := svc.DeleteView(ctx, user, own.ID); err != nil {
+		t.Errorf("DeleteView() error = %v", err)
+	}
+	if err := svc.DeleteView(ctx, admin, shared.ID); err != nil {
+		t.Errorf("DeleteView() of a shared view by an admin error = %v", err)
+	}
+}
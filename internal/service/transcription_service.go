@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/transcription"
+)
+
+// TranscriptionService transcribes the recordings of calls whose provider
+// sent no transcript, so those calls still get quotes.
+type TranscriptionService struct {
+	transcriber transcription.Transcriber
+	calls       *CallService
+	recordings  *RecordingService // Optional: archived copies are preferred to provider URLs
+	client      *http.Client
+	logger      *zap.Logger
+
+	// Configuration
+	maxSize int64
+	workers int
+	timeout time.Duration
+
+	// Lifecycle
+	queue   chan uuid.UUID
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// TranscriptionServiceConfig holds configuration for the transcription service.
+type TranscriptionServiceConfig struct {
+	MaxSize    int64         // Largest recording transcribed, in bytes
+	Workers    int           // Concurrent transcriptions
+	QueueSize  int           // Calls waiting for a worker before new ones are dropped
+	Timeout    time.Duration // Limit for downloading and transcribing one recording
+	HTTPClient *http.Client  // Client used to download recordings
+}
+
+// DefaultTranscriptionServiceConfig returns sensible defaults. The size limit
+// matches the OpenAI transcription API's upload limit.
+func DefaultTranscriptionServiceConfig() *TranscriptionServiceConfig {
+	return &TranscriptionServiceConfig{
+		MaxSize:   25 << 20,
+		Workers:   2,
+		QueueSize: 100,
+		Timeout:   10 * time.Minute,
+	}
+}
+
+// NewTranscriptionService creates a new TranscriptionService.
+func NewTranscriptionService(
+	transcriber transcription.Transcriber,
+	calls *CallService,
+	logger *zap.Logger,
+	config *TranscriptionServiceConfig,
+) *TranscriptionService {
+	if config == nil {
+		config = DefaultTranscriptionServiceConfig()
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute}
+	}
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &TranscriptionService{
+		transcriber: transcriber,
+		calls:       calls,
+		client:      client,
+		logger:      logger,
+		maxSize:     config.MaxSize,
+		workers:     workers,
+		timeout:     config.Timeout,
+		queue:       make(chan uuid.UUID, config.QueueSize),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// SetRecordings sets the recording service whose archived copies are read
+// instead of downloading from the provider.
+func (s *TranscriptionService) SetRecordings(recordings *RecordingService) {
+	s.recordings = recordings
+}
+
+// Enqueue queues a call for transcription without blocking. The call is
+// dropped with a warning when the queue is full.
+func (s *TranscriptionService) Enqueue(callID uuid.UUID) {
+	select {
+	case s.queue <- callID:
+		s.logger.Info("call queued for transcription", zap.String("call_id", callID.String()))
+	default:
+		s.logger.Warn("transcription queue full, call dropped", zap.String("call_id", callID.String()))
+	}
+}
+
+// Start begins transcribing queued calls.
+func (s *TranscriptionService) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("transcription worker already running")
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.logger.Info("starting transcription worker",
+		zap.String("provider", s.transcriber.Name()),
+		zap.Int("workers", s.workers),
+	)
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+
+	return nil
+}
+
+// Stop gracefully stops the worker, waiting for in-flight transcriptions to finish.
+func (s *TranscriptionService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Info("stopping transcription worker")
+	close(s.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("transcription worker stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("transcription worker stop timed out")
+		return ctx.Err()
+	}
+}
+
+// worker transcribes queued calls until stopped.
+func (s *TranscriptionService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case callID := <-s.queue:
+			ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+			if err := s.transcribe(ctx, callID); err != nil {
+				s.logger.Error("failed to transcribe call",
+					zap.String("call_id", callID.String()),
+					zap.Error(err),
+				)
+			}
+			cancel()
+		}
+	}
+}
+
+// transcribe transcribes a call's recording and saves the transcript.
+func (s *TranscriptionService) transcribe(ctx context.Context, callID uuid.UUID) error {
+	call, err := s.calls.GetCall(ctx, callID)
+	if err != nil {
+		return err
+	}
+	if call.Transcript != nil && *call.Transcript != "" {
+		return nil
+	}
+
+	audio, filename, err := s.openAudio(ctx, call)
+	if err != nil {
+		return err
+	}
+	defer audio.Close()
+
+	start := time.Now()
+	text, err := s.transcriber.Transcribe(ctx, audio, filename)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		return errors.New("transcription is empty")
+	}
+	s.logger.Info("call transcribed",
+		zap.String("call_id", callID.String()),
+		zap.String("provider", s.transcriber.Name()),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	_, err = s.calls.ApplyTranscript(ctx, callID, text)
+	return err
+}
+
+// openAudio returns the call's recording and a filename whose extension
+// names its format. The archived copy is used when one is stored.
+func (s *TranscriptionService) openAudio(ctx context.Context, call *domain.Call) (io.ReadCloser, string, error) {
+	if s.recordings != nil {
+		if _, obj, err := s.recordings.Open(ctx, call.ID); err == nil {
+			return obj.Body, transcriptionFilename(call, obj.ContentType), nil
+		}
+	}
+	if call.RecordingURL == nil || *call.RecordingURL == "" {
+		return nil, "", errors.New("call has no recording")
+	}
+	return s.download(ctx, call)
+}
+
+// download copies the provider's recording to a temporary file that is
+// removed when closed.
+func (s *TranscriptionService) download(ctx context.Context, call *domain.Call) (io.ReadCloser, string, error) {
+	sourceURL := *call.RecordingURL
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid recording url: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("recording request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("recording url returned %d", resp.StatusCode)
+	}
+	if s.maxSize > 0 && resp.ContentLength > s.maxSize {
+		return nil, "", fmt.Errorf("recording is %d bytes, more than the %d byte transcription limit", resp.ContentLength, s.maxSize)
+	}
+
+	tmp, err := os.CreateTemp("", "transcription-*")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	audio := &tempFile{File: tmp}
+
+	body := io.Reader(resp.Body)
+	if s.maxSize > 0 {
+		body = io.LimitReader(resp.Body, s.maxSize+1)
+	}
+	size, err := io.Copy(tmp, body)
+	if err == nil && s.maxSize > 0 && size > s.maxSize {
+		err = fmt.Errorf("recording is more than the %d byte transcription limit", s.maxSize)
+	}
+	if err == nil && size == 0 {
+		err = errors.New("recording is empty")
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		audio.Close()
+		return nil, "", fmt.Errorf("failed to download recording: %w", err)
+	}
+
+	contentType := recordingContentType(resp.Header.Get("Content-Type"), sourceURL)
+	return audio, transcriptionFilename(call, contentType), nil
+}
+
+// tempFile is a temporary file deleted when closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
+
+// transcriptionFilename names the upload; transcription backends detect the
+// audio format from its extension.
+func transcriptionFilename(call *domain.Call, contentType string) string {
+	ext, ok := recordingExtensions[contentType]
+	if !ok {
+		ext = ".mp3"
+	}
+	return call.ID.String() + ext
+}
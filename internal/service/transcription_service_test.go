@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+)
+
+// fakeTranscriber returns a fixed transcript and records what it was sent.
+type fakeTranscriber struct {
+	mu       sync.Mutex
+	text     string
+	err      error
+	audio    []string
+	filename []string
+}
+
+func (f *fakeTranscriber) Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	data, err := io.ReadAll(audio)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.audio = append(f.audio, string(data))
+	f.filename = append(f.filename, filename)
+	return f.text, f.err
+}
+
+func (f *fakeTranscriber) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.audio)
+}
+
+func (f *fakeTranscriber) Name() string {
+	return "fake"
+}
+
+func newTestTranscriptionService(tr *fakeTranscriber, maxSize int64) (*TranscriptionService, *MockCallRepository) {
+	callService, calls, _ := newTestCallService()
+	svc := NewTranscriptionService(tr, callService, zap.NewNop(), &TranscriptionServiceConfig{
+		MaxSize:   maxSize,
+		Workers:   1,
+		QueueSize: 10,
+		Timeout:   time.Minute,
+	})
+	return svc, calls
+}
+
+func newRecordingServer(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newCompletedRecordedCall(calls *MockCallRepository, url string) *domain.Call {
+	call := newRecordedCall(url)
+	call.Status = domain.CallStatusCompleted
+	calls.Create(context.Background(), call)
+	return call
+}
+
+func TestTranscriptionService_Transcribe(t *testing.T) {
+	tr := &fakeTranscriber{text: "I need a quote for a fence."}
+	svc, calls := newTestTranscriptionService(tr, 1<<20)
+	srv := newRecordingServer(t, "audio/wav", "RIFF audio")
+	call := newCompletedRecordedCall(calls, srv.URL+"/rec")
+
+	if err := svc.transcribe(context.Background(), call.ID); err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+
+	if len(tr.audio) != 1 || tr.audio[0] != "RIFF audio" {
+		t.Fatalf("transcriber got %q", tr.audio)
+	}
+	if want := call.ID.String() + ".wav"; tr.filename[0] != want {
+		t.Errorf("filename = %q, want %q", tr.filename[0], want)
+	}
+	stored, _ := calls.GetByID(context.Background(), call.ID)
+	if stored.Transcript == nil || *stored.Transcript != "I need a quote for a fence." {
+		t.Errorf("Transcript = %v", stored.Transcript)
+	}
+}
+
+func TestTranscriptionService_Transcribe_SkipsTranscribedCalls(t *testing.T) {
+	tr := &fakeTranscriber{text: "new"}
+	svc, calls := newTestTranscriptionService(tr, 1<<20)
+	call := newCompletedRecordedCall(calls, "http://127.0.0.1:1/rec")
+	existing := "from the provider"
+	call.Transcript = &existing
+	calls.Update(context.Background(), call)
+
+	if err := svc.transcribe(context.Background(), call.ID); err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if len(tr.audio) != 0 {
+		t.Errorf("transcriber called for a call with a transcript")
+	}
+}
+
+func TestTranscriptionService_Transcribe_TooLarge(t *testing.T) {
+	tr := &fakeTranscriber{text: "unused"}
+	svc, calls := newTestTranscriptionService(tr, 4)
+	srv := newRecordingServer(t, "audio/mpeg", "too much audio")
+	call := newCompletedRecordedCall(calls, srv.URL+"/rec.mp3")
+
+	if err := svc.transcribe(context.Background(), call.ID); err == nil {
+		t.Fatal("transcribe() error = nil, want size limit error")
+	}
+	if len(tr.audio) != 0 {
+		t.Errorf("transcriber called for an oversized recording")
+	}
+	stored, _ := calls.GetByID(context.Background(), call.ID)
+	if stored.Transcript != nil {
+		t.Errorf("Transcript = %q, want none", *stored.Transcript)
+	}
+}
+
+func TestTranscriptionService_Transcribe_Failure(t *testing.T) {
+	tr := &fakeTranscriber{err: errors.New("model unavailable")}
+	svc, calls := newTestTranscriptionService(tr, 1<<20)
+	srv := newRecordingServer(t, "audio/mpeg", "audio")
+	call := newCompletedRecordedCall(calls, srv.URL+"/rec.mp3")
+
+	if err := svc.transcribe(context.Background(), call.ID); err == nil {
+		t.Fatal("transcribe() error = nil, want transcriber error")
+	}
+	stored, _ := calls.GetByID(context.Background(), call.ID)
+	if stored.Transcript != nil {
+		t.Errorf("Transcript = %q, want none", *stored.Transcript)
+	}
+}
+
+func TestTranscriptionService_PrefersArchivedRecording(t *testing.T) {
+	tr := &fakeTranscriber{text: "archived"}
+	svc, calls := newTestTranscriptionService(tr, 1<<20)
+	recordings, _, _ := newTestRecordingService(t, 1<<20)
+	svc.SetRecordings(recordings)
+
+	srv := newRecordingServer(t, "audio/ogg", "archived audio")
+	call := newCompletedRecordedCall(calls, srv.URL+"/rec")
+	if err := recordings.Archive(context.Background(), call); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	recordings.downloadDue(time.Now())
+
+	// The provider URL has expired; only the archived copy remains.
+	srv.Close()
+
+	if err := svc.transcribe(context.Background(), call.ID); err != nil {
+		t.Fatalf("transcribe() error = %v", err)
+	}
+	if len(tr.audio) != 1 || tr.audio[0] != "archived audio" {
+		t.Fatalf("transcriber got %q", tr.audio)
+	}
+	if want := call.ID.String() + ".ogg"; tr.filename[0] != want {
+		t.Errorf("filename = %q, want %q", tr.filename[0], want)
+	}
+}
+
+func TestTranscriptionService_Worker(t *testing.T) {
+	tr := &fakeTranscriber{text: "queued"}
+	svc, calls := newTestTranscriptionService(tr, 1<<20)
+	srv := newRecordingServer(t, "audio/mpeg", "audio")
+	call := newCompletedRecordedCall(calls, srv.URL+"/rec.mp3")
+
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	svc.Enqueue(call.ID)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for tr.calls() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("call was not transcribed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Stop waits for the transcript to be saved.
+	if err := svc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	stored, _ := calls.GetByID(context.Background(), call.ID)
+	if stored.Transcript == nil || *stored.Transcript != "queued" {
+		t.Errorf("Transcript = %v", stored.Transcript)
+	}
+}
+
+func TestCallService_ApplyTranscript(t *testing.T) {
+	callService, calls, _ := newTestCallService()
+	call := newCompletedRecordedCall(calls, "https://provider.example.com/rec.mp3")
+
+	updated, err := callService.ApplyTranscript(context.Background(), call.ID, "hello")
+	if err != nil {
+		t.Fatalf("ApplyTranscript() error = %v", err)
+	}
+	if updated.Transcript == nil || *updated.Transcript != "hello" {
+		t.Errorf("Transcript = %v", updated.Transcript)
+	}
+
+	// An existing transcript is not replaced.
+	updated, err = callService.ApplyTranscript(context.Background(), call.ID, "again")
+	if err != nil {
+		t.Fatalf("ApplyTranscript() error = %v", err)
+	}
+	if *updated.Transcript != "hello" {
+		t.Errorf("Transcript = %q, want the first transcript kept", *updated.Transcript)
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/audit"
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+// UserActor identifies the admin managing users.
+type UserActor struct {
+	User      *domain.User
+	IP        string
+	RequestID string
+}
+
+// id returns the actor's user ID for audit logging.
+func (a UserActor) id() string {
+	if a.User == nil {
+		return ""
+	}
+	return a.User.ID.String()
+}
+
+// userID returns the actor's user ID to record who made a change, or nil
+// for work done without a user.
+func (a UserActor) userID() *uuid.UUID {
+	if a.User == nil {
+		return nil
+	}
+	id := a.User.ID
+	return &id
+}
+
+// email returns the actor's email for audit logging.
+func (a UserActor) email() string {
+	if a.User == nil {
+		return ""
+	}
+	return a.User.Email
+}
+
+// UserService handles dashboard user management. Every operation requires
+// an admin actor and is audit logged.
+type UserService struct {
+	userRepo    domain.UserRepository
+	sessionRepo domain.SessionRepository
+	authService *AuthService
+	auditLogger *audit.Logger
+	logger      *zap.Logger
+}
+
+// NewUserService creates a new UserService. Invitations are sent through
+// authService, which must have user tokens configured. auditLogger may be
+// nil.
+func NewUserService(userRepo domain.UserRepository, sessionRepo domain.SessionRepository, authService *AuthService, auditLogger *audit.Logger, logger *zap.Logger) *UserService {
+	return &UserService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		authService: authService,
+		auditLogger: auditLogger,
+		logger:      logger,
+	}
+}
+
+// InviteUserRequest holds the parameters for inviting a user.
+type InviteUserRequest struct {
+	Email string
+	Role  domain.UserRole
+}
+
+// List returns all users.
+func (s *UserService) List(ctx context.Context, actor UserActor) ([]*domain.User, error) {
+	if err := s.requireAdmin(ctx, actor, "list users"); err != nil {
+		return nil, err
+	}
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// Get returns a user by ID.
+func (s *UserService) Get(ctx context.Context, actor UserActor, id uuid.UUID) (*domain.User, error) {
+	if err := s.requireAdmin(ctx, actor, "view user "+id.String()); err != nil {
+		return nil, err
+	}
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// Invite creates a user who sets their own password by following the
+// returned invitation link. The link is also emailed when account email is
+// configured.
+func (s *UserService) Invite(ctx context.Context, actor UserActor, req InviteUserRequest) (*domain.User, string, error) {
+	if err := s.requireAdmin(ctx, actor, "invite user"); err != nil {
+		return nil, "", err
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return nil, "", apperrors.MissingField("email")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, "", apperrors.ValidationFailed("email must be a valid email address")
+	}
+	role := req.Role
+	if role == "" {
+		role = domain.UserRoleMember
+	}
+	if !role.IsValid() {
+		return nil, "", apperrors.InvalidFormat("role", "admin or member")
+	}
+
+	// Nobody knows this password; the invitation replaces it.
+	password, err := generateToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	user, err := s.authService.createUser(ctx, email, password, role, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	inviteURL, err := s.authService.SendInvitation(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserInvited(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, string(role), actor.IP, actor.RequestID)
+	}
+	return user, inviteURL, nil
+}
+
+// ResendInvitation issues a new invitation link for a user who hasn't
+// accepted theirs yet. Earlier links stop working.
+func (s *UserService) ResendInvitation(ctx context.Context, actor UserActor, id uuid.UUID) (*domain.User, string, error) {
+	if err := s.requireAdmin(ctx, actor, "reinvite user "+id.String()); err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if user.EmailVerified() {
+		return nil, "", apperrors.New(apperrors.CodeConflict, "user has already accepted their invitation")
+	}
+	if user.IsDisabled() {
+		return nil, "", apperrors.New(apperrors.CodeConflict, "cannot invite a disabled user")
+	}
+
+	inviteURL, err := s.authService.SendInvitation(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserReinvited(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, actor.IP, actor.RequestID)
+	}
+	return user, inviteURL, nil
+}
+
+// SetRole changes a user's role.
+func (s *UserService) SetRole(ctx context.Context, actor UserActor, id uuid.UUID, role domain.UserRole) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, apperrors.InvalidFormat("role", "admin or member")
+	}
+	user, err := s.getOther(ctx, actor, id, "change role of user")
+	if err != nil {
+		return nil, err
+	}
+
+	oldRole := user.Role
+	if oldRole == role {
+		return user, nil
+	}
+	if oldRole == domain.UserRoleAdmin {
+		if err := s.ensureOtherAdmin(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Role = role
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserRoleChanged(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, string(oldRole), string(role), actor.IP, actor.RequestID)
+	}
+	s.logger.Info("user role changed",
+		zap.String("user_id", user.ID.String()),
+		zap.String("old_role", string(oldRole)),
+		zap.String("new_role", string(role)),
+	)
+	return user, nil
+}
+
+// Disable blocks a user from logging in and signs out their sessions.
+// Their API keys stop working until they are enabled again.
+func (s *UserService) Disable(ctx context.Context, actor UserActor, id uuid.UUID) (*domain.User, error) {
+	user, err := s.getOther(ctx, actor, id, "disable user")
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled() {
+		return user, nil
+	}
+	if err := s.ensureOtherAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	user.Disable()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to disable user: %w", err)
+	}
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to sign out sessions: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserDisabled(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, actor.IP, actor.RequestID)
+	}
+	s.logger.Info("user disabled", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// Enable lets a disabled user log in again.
+func (s *UserService) Enable(ctx context.Context, actor UserActor, id uuid.UUID) (*domain.User, error) {
+	user, err := s.getOther(ctx, actor, id, "enable user")
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsDisabled() {
+		return user, nil
+	}
+
+	user.Enable()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to enable user: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserEnabled(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, actor.IP, actor.RequestID)
+	}
+	s.logger.Info("user enabled", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// Delete soft-deletes a user and signs out their sessions. Their address
+// can be invited again afterwards.
+func (s *UserService) Delete(ctx context.Context, actor UserActor, id uuid.UUID) (*domain.User, error) {
+	user, err := s.getOther(ctx, actor, id, "delete user")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureOtherAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.Delete(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to sign out sessions: %w", err)
+	}
+
+	if s.auditLogger != nil {
+		s.auditLogger.UserDeleted(ctx, actor.id(), actor.email(), user.ID.String(), user.Email, actor.IP, actor.RequestID)
+	}
+	s.logger.Info("user deleted", zap.String("user_id", user.ID.String()))
+	return user, nil
+}
+
+// requireAdmin fails unless the actor is an admin, auditing the denial.
+func (s *UserService) requireAdmin(ctx context.Context, actor UserActor, action string) error {
+	if actor.User != nil && actor.User.IsAdmin() {
+		return nil
+	}
+	err := apperrors.New(apperrors.CodeForbidden, "only admins can manage users")
+	if s.auditLogger != nil {
+		s.auditLogger.AccessDenied(ctx, actor.id(), "user", action, actor.IP, actor.RequestID, err.Error())
+	}
+	return err
+}
+
+// getOther loads a user for a change by an admin, refusing changes to the
+// admin's own account so they can't lock themselves out.
+func (s *UserService) getOther(ctx context.Context, actor UserActor, id uuid.UUID, action string) (*domain.User, error) {
+	if err := s.requireAdmin(ctx, actor, action+" "+id.String()); err != nil {
+		return nil, err
+	}
+	if actor.User.ID == id {
+		return nil, apperrors.New(apperrors.CodeConflict, "you can't change your own account here")
+	}
+	return s.userRepo.GetByID(ctx, id)
+}
+
+// ensureOtherAdmin fails if user is the only active admin, since removing
+// them would leave nobody able to manage users.
+func (s *UserService) ensureOtherAdmin(ctx context.Context, user *domain.User) error {
+	if !user.IsAdmin() || user.IsDisabled() {
+		return nil
+	}
+	count, err := s.userRepo.CountActiveAdmins(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+	if count <= 1 {
+		return apperrors.New(apperrors.CodeConflict, "cannot remove the last admin")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+)
+
+func newTestUserService(t *testing.T) (*UserService, *MockUserRepository, *MockSessionRepository, *domain.User) {
+	t.Helper()
+	authService, userRepo, sessionRepo := newTestAuthService()
+	authService.SetAccountEmail(NewMockUserTokenRepository(), nil, "")
+
+	admin, _ := domain.NewUser("admin@example.com", "securepassword123")
+	admin.Role = domain.UserRoleAdmin
+	admin.MarkEmailVerified()
+	userRepo.Create(context.Background(), admin)
+
+	return NewUserService(userRepo, sessionRepo, authService, nil, authService.logger), userRepo, sessionRepo, admin
+}
+
+func actorFor(user *domain.User) UserActor {
+	return UserActor{User: user, IP: "10.0.0.1", RequestID: "req-1"}
+}
+
+func TestUserService_Invite(t *testing.T) {
+	svc, userRepo, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	user, inviteURL, err := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: " new@example.com "})
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if user.Email != "new@example.com" || user.Role != domain.UserRoleMember {
+		t.Errorf("invited user = %q (%s), want new@example.com (member)", user.Email, user.Role)
+	}
+	if user.EmailVerified() {
+		t.Error("invited user should not be verified before accepting")
+	}
+	if inviteURL == "" {
+		t.Error("expected an invitation link")
+	}
+	if _, err := userRepo.GetByEmail(ctx, "new@example.com"); err != nil {
+		t.Errorf("invited user not saved: %v", err)
+	}
+
+	if _, _, err := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "new@example.com"}); apperrors.GetCode(err) != apperrors.CodeAlreadyExists {
+		t.Errorf("duplicate Invite() error = %v, want already exists", err)
+	}
+}
+
+func TestUserService_Invite_Validation(t *testing.T) {
+	svc, _, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	tests := []InviteUserRequest{
+		{Email: ""},
+		{Email: "not-an-email"},
+		{Email: "new@example.com", Role: "owner"},
+	}
+	for _, req := range tests {
+		if _, _, err := svc.Invite(ctx, actorFor(admin), req); !apperrors.IsUserError(err) {
+			t.Errorf("Invite(%+v) error = %v, want a validation error", req, err)
+		}
+	}
+}
+
+func TestUserService_ResendInvitation(t *testing.T) {
+	svc, _, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	user, _, _ := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "new@example.com"})
+	if _, _, err := svc.ResendInvitation(ctx, actorFor(admin), user.ID); err != nil {
+		t.Errorf("ResendInvitation() error = %v", err)
+	}
+	if _, _, err := svc.ResendInvitation(ctx, actorFor(admin), admin.ID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("ResendInvitation() for active user error = %v, want conflict", err)
+	}
+}
+
+func TestUserService_SetRole(t *testing.T) {
+	svc, _, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	user, _, _ := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "new@example.com"})
+
+	updated, err := svc.SetRole(ctx, actorFor(admin), user.ID, domain.UserRoleAdmin)
+	if err != nil {
+		t.Fatalf("SetRole() error = %v", err)
+	}
+	if !updated.IsAdmin() {
+		t.Errorf("SetRole() role = %s, want admin", updated.Role)
+	}
+
+	if _, err := svc.SetRole(ctx, actorFor(admin), user.ID, "owner"); !apperrors.IsUserError(err) {
+		t.Errorf("SetRole() with unknown role error = %v, want validation error", err)
+	}
+	if _, err := svc.SetRole(ctx, actorFor(admin), admin.ID, domain.UserRoleMember); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("SetRole() on self error = %v, want conflict", err)
+	}
+}
+
+func TestUserService_KeepsLastAdmin(t *testing.T) {
+	svc, userRepo, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	// A second admin acting on the first, after which the first is the
+	// only active admin left.
+	other, _ := domain.NewUser("other@example.com", "securepassword123")
+	other.Role = domain.UserRoleAdmin
+	userRepo.Create(ctx, other)
+	if _, err := svc.Disable(ctx, actorFor(admin), other.ID); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	if _, err := svc.SetRole(ctx, actorFor(other), admin.ID, domain.UserRoleMember); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("demoting the last admin error = %v, want conflict", err)
+	}
+	if _, err := svc.Disable(ctx, actorFor(other), admin.ID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("disabling the last admin error = %v, want conflict", err)
+	}
+	if _, err := svc.Delete(ctx, actorFor(other), admin.ID); apperrors.GetCode(err) != apperrors.CodeConflict {
+		t.Errorf("deleting the last admin error = %v, want conflict", err)
+	}
+}
+
+func TestUserService_DisableAndEnable(t *testing.T) {
+	svc, _, sessionRepo, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	user, _, _ := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "new@example.com"})
+	sessionRepo.Create(ctx, domain.NewSession(user.ID, "user-token", 0))
+
+	disabled, err := svc.Disable(ctx, actorFor(admin), user.ID)
+	if err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if !disabled.IsDisabled() {
+		t.Error("expected user to be disabled")
+	}
+	if sessionRepo.DeleteByUserIDCalls != 1 {
+		t.Errorf("expected sessions to be signed out, got %d calls", sessionRepo.DeleteByUserIDCalls)
+	}
+
+	enabled, err := svc.Enable(ctx, actorFor(admin), user.ID)
+	if err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if enabled.IsDisabled() {
+		t.Error("expected user to be enabled")
+	}
+}
+
+func TestUserService_Delete(t *testing.T) {
+	svc, userRepo, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	user, _, _ := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "new@example.com"})
+	if _, err := svc.Delete(ctx, actorFor(admin), user.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := userRepo.GetByID(ctx, user.ID); !apperrors.IsNotFound(err) {
+		t.Errorf("GetByID() after delete error = %v, want not found", err)
+	}
+	if _, err := svc.Delete(ctx, actorFor(admin), uuid.New()); !apperrors.IsNotFound(err) {
+		t.Errorf("Delete() of unknown user error = %v, want not found", err)
+	}
+}
+
+func TestUserService_RequiresAdmin(t *testing.T) {
+	svc, _, _, admin := newTestUserService(t)
+	ctx := context.Background()
+
+	member, _, _ := svc.Invite(ctx, actorFor(admin), InviteUserRequest{Email: "member@example.com"})
+
+	if _, err := svc.List(ctx, actorFor(member)); apperrors.GetCode(err) != apperrors.CodeForbidden {
+		t.Errorf("List() by member error = %v, want forbidden", err)
+	}
+	if _, _, err := svc.Invite(ctx, actorFor(member), InviteUserRequest{Email: "other@example.com"}); apperrors.GetCode(err) != apperrors.CodeForbidden {
+		t.Errorf("Invite() by member error = %v, want forbidden", err)
+	}
+	if _, err := svc.Disable(ctx, UserActor{}, admin.ID); apperrors.GetCode(err) != apperrors.CodeForbidden {
+		t.Errorf("Disable() without actor error = %v, want forbidden", err)
+	}
+}
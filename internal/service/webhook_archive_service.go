@@ -0,0 +1,167 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// DefaultWebhookArchiveRetention is how long raw webhook bodies are kept.
+const DefaultWebhookArchiveRetention = 30 * 24 * time.Hour
+
+// webhookArchivePageSize is the number of webhooks listed by default, and
+// maxWebhookArchivePageSize the most listed at once.
+const (
+	webhookArchivePageSize    = 100
+	maxWebhookArchivePageSize = 500
+)
+
+// WebhookArchiveService keeps the raw bodies of provider webhooks, gzipped,
+// for the retention period. Archived webhooks can be parsed again to see
+// what the provider's parser makes of them, and re-dispatched to update
+// their call, for when a provider changes its payloads.
+type WebhookArchiveService struct {
+	repo      domain.WebhookArchiveRepository
+	registry  *voiceprovider.Registry
+	calls     CallEventProcessor
+	retention time.Duration
+	logger    *zap.Logger
+}
+
+// NewWebhookArchiveService creates a new WebhookArchiveService. A retention
+// of zero uses DefaultWebhookArchiveRetention.
+func NewWebhookArchiveService(
+	repo domain.WebhookArchiveRepository,
+	registry *voiceprovider.Registry,
+	calls CallEventProcessor,
+	retention time.Duration,
+	logger *zap.Logger,
+) *WebhookArchiveService {
+	if retention <= 0 {
+		retention = DefaultWebhookArchiveRetention
+	}
+	return &WebhookArchiveService{
+		repo:      repo,
+		registry:  registry,
+		calls:     calls,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+// Archive stores a webhook body as received. providerCallID is empty if the
+// body could not be parsed.
+func (s *WebhookArchiveService) Archive(ctx context.Context, provider, path, contentType, providerCallID string, body []byte) error {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(body); err != nil {
+		return fmt.Errorf("failed to compress webhook body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress webhook body: %w", err)
+	}
+
+	webhook := domain.NewArchivedWebhook(provider, path, contentType, providerCallID, body)
+	webhook.Body = compressed.Bytes()
+	return s.repo.Create(ctx, webhook)
+}
+
+// List returns the archived webhooks matching filter, newest first, without
+// their bodies.
+func (s *WebhookArchiveService) List(ctx context.Context, filter domain.WebhookArchiveFilter) ([]*domain.ArchivedWebhook, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = webhookArchivePageSize
+	}
+	if filter.Limit > maxWebhookArchivePageSize {
+		filter.Limit = maxWebhookArchivePageSize
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Get returns an archived webhook with its body uncompressed.
+func (s *WebhookArchiveService) Get(ctx context.Context, id uuid.UUID) (*domain.ArchivedWebhook, error) {
+	webhook, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(webhook.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress webhook body: %w", err)
+	}
+	defer zr.Close()
+	if webhook.Body, err = io.ReadAll(zr); err != nil {
+		return nil, fmt.Errorf("failed to decompress webhook body: %w", err)
+	}
+	return webhook, nil
+}
+
+// Parse runs an archived webhook through its provider's parser as if it had
+// just been received.
+func (s *WebhookArchiveService) Parse(ctx context.Context, webhook *domain.ArchivedWebhook) (*voiceprovider.CallEvent, error) {
+	provider, err := s.registry.GetByWebhookPath(webhook.Path)
+	if err != nil {
+		return nil, apperrors.WebhookError(fmt.Sprintf("no provider receives webhooks at %s", webhook.Path))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Path, bytes.NewReader(webhook.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	if webhook.ContentType != "" {
+		req.Header.Set("Content-Type", webhook.ContentType)
+	}
+
+	event, err := provider.ParseWebhook(req)
+	if err != nil {
+		return nil, apperrors.WebhookError(fmt.Sprintf("%s could not parse the webhook: %v", provider.GetName(), err))
+	}
+	return event, nil
+}
+
+// Redispatch parses an archived webhook again and applies it to its call.
+func (s *WebhookArchiveService) Redispatch(ctx context.Context, id uuid.UUID) (*domain.Call, error) {
+	webhook, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.Parse(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := s.calls.ProcessCallEvent(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("archived webhook re-dispatched",
+		zap.String("webhook_id", id.String()),
+		zap.String("provider", webhook.Provider),
+		zap.String("call_id", call.ID.String()),
+	)
+	return call, nil
+}
+
+// CleanupExpired removes webhooks older than the retention period.
+func (s *WebhookArchiveService) CleanupExpired(ctx context.Context) error {
+	removed, err := s.repo.DeleteBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		s.logger.Info("removed expired archived webhooks", zap.Int64("count", removed))
+	}
+	return nil
+}
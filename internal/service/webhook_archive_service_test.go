@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	apperrors "github.com/jkindrix/quickquote/internal/errors"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// MockWebhookArchiveRepository is an in-memory WebhookArchiveRepository.
+type MockWebhookArchiveRepository struct {
+	webhooks []*domain.ArchivedWebhook
+}
+
+func (m *MockWebhookArchiveRepository) Create(ctx context.Context, webhook *domain.ArchivedWebhook) error {
+	cp := *webhook
+	m.webhooks = append(m.webhooks, &cp)
+	return nil
+}
+
+func (m *MockWebhookArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ArchivedWebhook, error) {
+	for _, webhook := range m.webhooks {
+		if webhook.ID == id {
+			cp := *webhook
+			return &cp, nil
+		}
+	}
+	return nil, apperrors.NotFound("archived webhook")
+}
+
+func (m *MockWebhookArchiveRepository) List(ctx context.Context, filter domain.WebhookArchiveFilter) ([]*domain.ArchivedWebhook, error) {
+	var webhooks []*domain.ArchivedWebhook
+	for i := len(m.webhooks) - 1; i >= 0 && len(webhooks) < filter.Limit; i-- {
+		if filter.Provider == "" || m.webhooks[i].Provider == filter.Provider {
+			webhooks = append(webhooks, m.webhooks[i])
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockWebhookArchiveRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	var kept []*domain.ArchivedWebhook
+	for _, webhook := range m.webhooks {
+		if !webhook.ReceivedAt.Before(before) {
+			kept = append(kept, webhook)
+		}
+	}
+	removed := int64(len(m.webhooks) - len(kept))
+	m.webhooks = kept
+	return removed, nil
+}
+
+// jsonWebhookProvider parses webhooks of the form {"call_id": ..., "status": ...}.
+type jsonWebhookProvider struct{}
+
+func (jsonWebhookProvider) GetName() voiceprovider.ProviderType  { return voiceprovider.ProviderRetell }
+func (jsonWebhookProvider) GetWebhookPath() string               { return "/webhook/retell" }
+func (jsonWebhookProvider) ValidateWebhook(r *http.Request) bool { return true }
+func (jsonWebhookProvider) ParseWebhook(r *http.Request) (*voiceprovider.CallEvent, error) {
+	var payload struct {
+		CallID string `json:"call_id"`
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	return &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderRetell,
+		ProviderCallID: payload.CallID,
+		Status:         voiceprovider.CallStatus(payload.Status),
+	}, nil
+}
+
+func newTestWebhookArchive() (*WebhookArchiveService, *MockWebhookArchiveRepository, *fakeCallEventProcessor) {
+	registry := voiceprovider.NewRegistry(zap.NewNop())
+	registry.Register(jsonWebhookProvider{})
+	repo := &MockWebhookArchiveRepository{}
+	calls := &fakeCallEventProcessor{}
+	return NewWebhookArchiveService(repo, registry, calls, 0, zap.NewNop()), repo, calls
+}
+
+func TestWebhookArchiveService_ArchiveCompresses(t *testing.T) {
+	svc, repo, _ := newTestWebhookArchive()
+	ctx := context.Background()
+	body := bytes.Repeat([]byte(`{"call_id":"call-1","status":"completed"}`), 50)
+
+	if err := svc.Archive(ctx, "retell", "/webhook/retell", "application/json", "call-1", body); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	stored := repo.webhooks[0]
+	if len(stored.Body) >= len(body) || stored.Size != len(body) {
+		t.Errorf("expected the body stored compressed, got %d bytes for %d", len(stored.Body), stored.Size)
+	}
+
+	webhook, err := svc.Get(ctx, stored.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !bytes.Equal(webhook.Body, body) {
+		t.Error("expected the body back as received")
+	}
+}
+
+func TestWebhookArchiveService_Redispatch(t *testing.T) {
+	svc, repo, calls := newTestWebhookArchive()
+	ctx := context.Background()
+
+	if err := svc.Archive(ctx, "retell", "/webhook/retell", "application/json", "", []byte(`{"call_id":"call-1","status":"completed"}`)); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	call, err := svc.Redispatch(ctx, repo.webhooks[0].ID)
+	if err != nil {
+		t.Fatalf("Redispatch() error = %v", err)
+	}
+	if calls.calls != 1 || call.ProviderCallID != "call-1" {
+		t.Errorf("expected the parsed event applied to call-1, got %d calls and %+v", calls.calls, call)
+	}
+
+	// Payloads the parser can't read are reported, not dispatched
+	if err := svc.Archive(ctx, "retell", "/webhook/retell", "application/json", "", []byte(`not json`)); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if _, err := svc.Redispatch(ctx, repo.webhooks[1].ID); !apperrors.IsUserError(err) || calls.calls != 1 {
+		t.Errorf("expected a parse error without dispatching, got %v", err)
+	}
+}
+
+func TestWebhookArchiveService_CleanupExpired(t *testing.T) {
+	svc, repo, _ := newTestWebhookArchive()
+	ctx := context.Background()
+
+	for _, callID := range []string{"old", "new"} {
+		if err := svc.Archive(ctx, "retell", "/webhook/retell", "", callID, []byte(`{}`)); err != nil {
+			t.Fatalf("Archive() error = %v", err)
+		}
+	}
+	repo.webhooks[0].ReceivedAt = time.Now().Add(-DefaultWebhookArchiveRetention - time.Hour)
+
+	if err := svc.CleanupExpired(ctx); err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+	webhooks, _ := svc.List(ctx, domain.WebhookArchiveFilter{})
+	if len(webhooks) != 1 || webhooks[0].ProviderCallID != "new" {
+		t.Errorf("expected only the recent webhook kept, got %d", len(webhooks))
+	}
+}
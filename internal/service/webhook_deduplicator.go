@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// DefaultWebhookDedupTTL is how long processed webhooks are remembered.
+// Providers stop redelivering well within it.
+const DefaultWebhookDedupTTL = 72 * time.Hour
+
+// WebhookDeduplicator skips provider webhooks that were already processed,
+// so a redelivered webhook does not update its call or queue its quote
+// twice. Webhooks are keyed by the provider's event ID, or by the SHA-256 of
+// the payload when the provider sends none.
+type WebhookDeduplicator struct {
+	repo    domain.ProcessedWebhookRepository
+	ttl     time.Duration
+	metrics *metrics.Metrics
+	logger  *zap.Logger
+}
+
+// NewWebhookDeduplicator creates a new WebhookDeduplicator. A ttl of zero
+// uses DefaultWebhookDedupTTL.
+func NewWebhookDeduplicator(repo domain.ProcessedWebhookRepository, ttl time.Duration, logger *zap.Logger) *WebhookDeduplicator {
+	if ttl <= 0 {
+		ttl = DefaultWebhookDedupTTL
+	}
+	return &WebhookDeduplicator{
+		repo:   repo,
+		ttl:    ttl,
+		logger: logger,
+	}
+}
+
+// SetMetrics sets the collector for skipped duplicates.
+func (d *WebhookDeduplicator) SetMetrics(m *metrics.Metrics) {
+	d.metrics = m
+}
+
+// WebhookEventKey returns the key a webhook is remembered by: eventID, or
+// the SHA-256 of payload when eventID is empty.
+func WebhookEventKey(eventID string, payload []byte) string {
+	if eventID != "" {
+		return eventID
+	}
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Claim marks a webhook as processed and reports whether it was already
+// processed. The webhook is processed if it can't be checked, since a
+// duplicate does less harm than a lost event.
+func (d *WebhookDeduplicator) Claim(ctx context.Context, provider, eventKey string) bool {
+	claimed, err := d.repo.Claim(ctx, provider, eventKey, time.Now().Add(d.ttl))
+	if err != nil {
+		d.logger.Warn("failed to check for duplicate webhook",
+			zap.String("provider", provider),
+			zap.Error(err),
+		)
+		return false
+	}
+	if claimed {
+		return false
+	}
+
+	d.logger.Info("skipping duplicate webhook",
+		zap.String("provider", provider),
+		zap.String("event_key", eventKey),
+	)
+	if d.metrics != nil {
+		d.metrics.RecordWebhookDuplicate(provider)
+	}
+	return true
+}
+
+// Release forgets a webhook that failed to process, so the provider's
+// redelivery is processed.
+func (d *WebhookDeduplicator) Release(ctx context.Context, provider, eventKey string) {
+	// The request context may already be cancelled; release regardless.
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := d.repo.Release(releaseCtx, provider, eventKey); err != nil {
+		d.logger.Warn("failed to release webhook for redelivery",
+			zap.String("provider", provider),
+			zap.String("event_key", eventKey),
+			zap.Error(err),
+		)
+	}
+}
+
+// CleanupExpired forgets webhooks older than the TTL.
+func (d *WebhookDeduplicator) CleanupExpired(ctx context.Context) error {
+	return d.repo.CleanupExpired(ctx)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/metrics"
+)
+
+// MockProcessedWebhookRepository is an in-memory ProcessedWebhookRepository.
+type MockProcessedWebhookRepository struct {
+	expires map[string]time.Time
+	err     error
+}
+
+func NewMockProcessedWebhookRepository() *MockProcessedWebhookRepository {
+	return &MockProcessedWebhookRepository{expires: make(map[string]time.Time)}
+}
+
+func (m *MockProcessedWebhookRepository) Claim(ctx context.Context, provider, eventKey string, expiresAt time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	key := provider + "/" + eventKey
+	if expires, ok := m.expires[key]; ok && expires.After(time.Now()) {
+		return false, nil
+	}
+	m.expires[key] = expiresAt
+	return true, nil
+}
+
+func (m *MockProcessedWebhookRepository) Release(ctx context.Context, provider, eventKey string) error {
+	delete(m.expires, provider+"/"+eventKey)
+	return nil
+}
+
+func (m *MockProcessedWebhookRepository) CleanupExpired(ctx context.Context) error {
+	for key, expires := range m.expires {
+		if !expires.After(time.Now()) {
+			delete(m.expires, key)
+		}
+	}
+	return nil
+}
+
+func TestWebhookEventKey(t *testing.T) {
+	if key := WebhookEventKey("sms-1", []byte(`{}`)); key != "sms-1" {
+		t.Errorf("expected the event ID as key, got %q", key)
+	}
+	a := WebhookEventKey("", []byte(`{"call_id":"c1","status":"completed"}`))
+	b := WebhookEventKey("", []byte(`{"call_id":"c1","status":"in-progress"}`))
+	if a == b || a != WebhookEventKey("", []byte(`{"call_id":"c1","status":"completed"}`)) {
+		t.Errorf("expected payload keys to differ by payload only, got %q and %q", a, b)
+	}
+}
+
+func TestWebhookDeduplicator_Claim(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockProcessedWebhookRepository()
+	m := metrics.NewMetricsWithRegistry(prometheus.NewRegistry())
+	d := NewWebhookDeduplicator(repo, time.Hour, zap.NewNop())
+	d.SetMetrics(m)
+
+	if d.Claim(ctx, "bland", "k1") {
+		t.Fatal("expected the first delivery to be processed")
+	}
+	if !d.Claim(ctx, "bland", "k1") {
+		t.Error("expected the redelivery to be skipped")
+	}
+	if d.Claim(ctx, "retell", "k1") {
+		t.Error("expected the same key from another provider to be processed")
+	}
+	if n := testutil.ToFloat64(m.WebhookDuplicatesTotal.WithLabelValues("bland")); n != 1 {
+		t.Errorf("expected one duplicate counted, got %f", n)
+	}
+
+	// A webhook that failed to process is processed when redelivered
+	d.Release(ctx, "bland", "k1")
+	if d.Claim(ctx, "bland", "k1") {
+		t.Error("expected a released webhook to be processed again")
+	}
+}
+
+func TestWebhookDeduplicator_Expiry(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockProcessedWebhookRepository()
+	d := NewWebhookDeduplicator(repo, time.Hour, zap.NewNop())
+
+	repo.expires["bland/old"] = time.Now().Add(-time.Minute)
+	if d.Claim(ctx, "bland", "old") {
+		t.Error("expected an expired webhook to be processed")
+	}
+
+	repo.expires["bland/stale"] = time.Now().Add(-time.Minute)
+	if err := d.CleanupExpired(ctx); err != nil {
+		t.Fatalf("CleanupExpired() error = %v", err)
+	}
+	if _, ok := repo.expires["bland/stale"]; ok || len(repo.expires) != 1 {
+		t.Errorf("expected only the live webhook kept, got %v", repo.expires)
+	}
+}
+
+func TestWebhookDeduplicator_FailsOpen(t *testing.T) {
+	repo := NewMockProcessedWebhookRepository()
+	repo.err = errors.New("database unavailable")
+	d := NewWebhookDeduplicator(repo, 0, zap.NewNop())
+
+	if d.Claim(context.Background(), "bland", "k1") || d.Claim(context.Background(), "bland", "k1") {
+		t.Error("expected webhooks processed when duplicates can't be checked")
+	}
+}
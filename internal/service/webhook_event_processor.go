@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// ErrWebhookEventQueued is returned by WebhookEventProcessor.Handle when
+// processing failed but the event was persisted and will be retried.
+var ErrWebhookEventQueued = errors.New("webhook event queued for retry")
+
+// CallEventProcessor applies a normalized provider event to the call it belongs to.
+type CallEventProcessor interface {
+	ProcessCallEvent(ctx context.Context, event *voiceprovider.CallEvent) (*domain.Call, error)
+}
+
+// WebhookEventProcessor persists incoming provider webhooks and retries
+// failed ones in the background with exponential backoff.
+type WebhookEventProcessor struct {
+	repo     domain.WebhookEventRepository
+	calls    CallEventProcessor
+	notifier Notifier
+	logger   *zap.Logger
+
+	// Configuration
+	pollInterval      time.Duration
+	batchSize         int
+	stuckEventTimeout time.Duration
+	processTimeout    time.Duration
+
+	// Lifecycle
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+}
+
+// WebhookEventProcessorConfig holds configuration for the processor.
+type WebhookEventProcessorConfig struct {
+	PollInterval      time.Duration
+	BatchSize         int
+	StuckEventTimeout time.Duration
+	ProcessTimeout    time.Duration
+}
+
+// DefaultWebhookEventProcessorConfig returns sensible defaults.
+func DefaultWebhookEventProcessorConfig() *WebhookEventProcessorConfig {
+	return &WebhookEventProcessorConfig{
+		PollInterval:      10 * time.Second,
+		BatchSize:         20,
+		StuckEventTimeout: 5 * time.Minute,
+		ProcessTimeout:    30 * time.Second,
+	}
+}
+
+// NewWebhookEventProcessor creates a new webhook event processor.
+func NewWebhookEventProcessor(
+	repo domain.WebhookEventRepository,
+	calls CallEventProcessor,
+	logger *zap.Logger,
+	config *WebhookEventProcessorConfig,
+) *WebhookEventProcessor {
+	if config == nil {
+		config = DefaultWebhookEventProcessorConfig()
+	}
+
+	return &WebhookEventProcessor{
+		repo:              repo,
+		calls:             calls,
+		logger:            logger,
+		pollInterval:      config.PollInterval,
+		batchSize:         config.BatchSize,
+		stuckEventTimeout: config.StuckEventTimeout,
+		processTimeout:    config.ProcessTimeout,
+		stopCh:            make(chan struct{}),
+	}
+}
+
+// SetNotifier sets the notifier used when a retried event reports a failed call.
+func (p *WebhookEventProcessor) SetNotifier(n Notifier) {
+	p.notifier = n
+}
+
+// Handle persists an incoming event and processes it. If processing fails
+// after the event was persisted, the returned error wraps ErrWebhookEventQueued.
+// If the event cannot be persisted it is still processed, without durability.
+func (p *WebhookEventProcessor) Handle(ctx context.Context, event *voiceprovider.CallEvent, payload []byte) (*domain.Call, error) {
+	normalized, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal call event: %w", err)
+	}
+
+	record := domain.NewWebhookEvent(
+		string(event.Provider),
+		event.ProviderCallID,
+		string(event.Status),
+		payload,
+		normalized,
+	)
+	record.MarkProcessing()
+
+	persisted := true
+	if err := p.repo.Create(ctx, record); err != nil {
+		persisted = false
+		p.logger.Error("failed to persist webhook event, processing without retry",
+			zap.String("provider_call_id", event.ProviderCallID),
+			zap.Error(err),
+		)
+	}
+
+	call, procErr := p.calls.ProcessCallEvent(ctx, event)
+	if !persisted {
+		return call, procErr
+	}
+
+	if procErr != nil {
+		// The request context may already be cancelled; record the failure regardless.
+		updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		p.failEvent(updateCtx, record, procErr)
+		if record.Status == domain.WebhookEventStatusPending {
+			return nil, fmt.Errorf("%w: %v", ErrWebhookEventQueued, procErr)
+		}
+		return nil, procErr
+	}
+
+	record.MarkCompleted(call.ID)
+	if err := p.repo.Update(ctx, record); err != nil {
+		p.logger.Warn("failed to mark webhook event completed",
+			zap.String("webhook_event_id", record.ID.String()),
+			zap.Error(err),
+		)
+	}
+
+	return call, nil
+}
+
+// GetStats returns webhook event counts by status.
+func (p *WebhookEventProcessor) GetStats(ctx context.Context) (map[domain.WebhookEventStatus]int, error) {
+	return p.repo.CountByStatus(ctx)
+}
+
+// Start begins the retry loop.
+func (p *WebhookEventProcessor) Start(ctx context.Context) error {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return errors.New("processor already running")
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	p.logger.Info("starting webhook event processor",
+		zap.Duration("poll_interval", p.pollInterval),
+		zap.Int("batch_size", p.batchSize),
+	)
+
+	if err := p.recoverStuckEvents(ctx); err != nil {
+		p.logger.Error("failed to recover stuck webhook events", zap.Error(err))
+	}
+
+	p.wg.Add(1)
+	go p.runLoop()
+
+	return nil
+}
+
+// Stop gracefully stops the retry loop.
+func (p *WebhookEventProcessor) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return nil
+	}
+	p.running = false
+	p.mu.Unlock()
+
+	p.logger.Info("stopping webhook event processor")
+	close(p.stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("webhook event processor stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		p.logger.Warn("webhook event processor stop timed out")
+		return ctx.Err()
+	}
+}
+
+// runLoop is the main retry loop.
+func (p *WebhookEventProcessor) runLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.retryBatch()
+		}
+	}
+}
+
+// retryBatch fetches events due for a retry and reprocesses them.
+func (p *WebhookEventProcessor) retryBatch() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	events, err := p.repo.GetPendingEvents(ctx, p.batchSize)
+	if err != nil {
+		p.logger.Error("failed to get pending webhook events", zap.Error(err))
+		return
+	}
+
+	for _, record := range events {
+		select {
+		case <-p.stopCh:
+			return
+		default:
+		}
+		p.retryEvent(record)
+	}
+}
+
+// retryEvent reprocesses a single persisted event.
+func (p *WebhookEventProcessor) retryEvent(record *domain.WebhookEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.processTimeout)
+	defer cancel()
+
+	logger := p.logger.With(
+		zap.String("webhook_event_id", record.ID.String()),
+		zap.String("provider_call_id", record.ProviderCallID),
+		zap.Int("attempt", record.Attempts+1),
+	)
+
+	var event voiceprovider.CallEvent
+	if err := json.Unmarshal(record.Event, &event); err != nil {
+		// A corrupt event can never succeed; fail it permanently.
+		record.MaxAttempts = record.Attempts
+		p.failEvent(ctx, record, fmt.Errorf("failed to decode stored event: %w", err))
+		return
+	}
+
+	record.MarkProcessing()
+	if err := p.repo.Update(ctx, record); err != nil {
+		logger.Error("failed to mark webhook event as processing", zap.Error(err))
+		return
+	}
+
+	logger.Info("retrying webhook event")
+
+	call, err := p.calls.ProcessCallEvent(ctx, &event)
+	if err != nil {
+		p.failEvent(ctx, record, err)
+		return
+	}
+
+	record.MarkCompleted(call.ID)
+	if err := p.repo.Update(ctx, record); err != nil {
+		logger.Error("failed to mark webhook event completed", zap.Error(err))
+		return
+	}
+
+	logger.Info("webhook event retry succeeded", zap.String("call_id", call.ID.String()))
+
+	if p.notifier != nil && call.Status == domain.CallStatusFailed {
+		p.notifier.CallFailed(ctx, call)
+	}
+}
+
+// failEvent records a processing failure and schedules a retry if allowed.
+func (p *WebhookEventProcessor) failEvent(ctx context.Context, record *domain.WebhookEvent, err error) {
+	logger := p.logger.With(
+		zap.String("webhook_event_id", record.ID.String()),
+		zap.String("provider_call_id", record.ProviderCallID),
+	)
+
+	record.MarkFailed(err)
+
+	if record.Status == domain.WebhookEventStatusPending {
+		logger.Warn("webhook event processing failed, scheduled for retry",
+			zap.Int("attempts", record.Attempts),
+			zap.Time("next_retry", record.ScheduledAt),
+			zap.Error(err),
+		)
+	} else {
+		logger.Error("webhook event permanently failed",
+			zap.Int("attempts", record.Attempts),
+			zap.Error(err),
+		)
+	}
+
+	if updateErr := p.repo.Update(ctx, record); updateErr != nil {
+		logger.Error("failed to update failed webhook event", zap.Error(updateErr))
+	}
+}
+
+// recoverStuckEvents reschedules events that were processing when the service stopped.
+func (p *WebhookEventProcessor) recoverStuckEvents(ctx context.Context) error {
+	stuck, err := p.repo.GetProcessingEvents(ctx, p.stuckEventTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to get stuck webhook events: %w", err)
+	}
+
+	if len(stuck) == 0 {
+		return nil
+	}
+
+	p.logger.Info("recovering stuck webhook events", zap.Int("count", len(stuck)))
+
+	for _, record := range stuck {
+		p.failEvent(ctx, record, errors.New("processing interrupted - process restarted"))
+	}
+
+	return nil
+}
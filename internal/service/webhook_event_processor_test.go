@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/domain"
+	"github.com/jkindrix/quickquote/internal/repository"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// MockWebhookEventRepository is a mock implementation of domain.WebhookEventRepository.
+type MockWebhookEventRepository struct {
+	mu     sync.RWMutex
+	events map[uuid.UUID]*domain.WebhookEvent
+
+	CreateError error
+}
+
+func NewMockWebhookEventRepository() *MockWebhookEventRepository {
+	return &MockWebhookEventRepository{
+		events: make(map[uuid.UUID]*domain.WebhookEvent),
+	}
+}
+
+func (m *MockWebhookEventRepository) Create(ctx context.Context, event *domain.WebhookEvent) error {
+	if m.CreateError != nil {
+		return m.CreateError
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events[event.ID] = event
+	return nil
+}
+
+func (m *MockWebhookEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if event, ok := m.events[id]; ok {
+		return event, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *MockWebhookEventRepository) Update(ctx context.Context, event *domain.WebhookEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.events[event.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	m.events[event.ID] = event
+	return nil
+}
+
+func (m *MockWebhookEventRepository) GetPendingEvents(ctx context.Context, limit int) ([]*domain.WebhookEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*domain.WebhookEvent
+	for _, event := range m.events {
+		if event.Status == domain.WebhookEventStatusPending && !event.ScheduledAt.After(time.Now()) {
+			result = append(result, event)
+			if len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MockWebhookEventRepository) GetProcessingEvents(ctx context.Context, olderThan time.Duration) ([]*domain.WebhookEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cutoff := time.Now().Add(-olderThan)
+	var result []*domain.WebhookEvent
+	for _, event := range m.events {
+		if event.Status == domain.WebhookEventStatusProcessing && event.StartedAt != nil && event.StartedAt.Before(cutoff) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockWebhookEventRepository) CountByStatus(ctx context.Context) (map[domain.WebhookEventStatus]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	counts := make(map[domain.WebhookEventStatus]int)
+	for _, event := range m.events {
+		counts[event.Status]++
+	}
+	return counts, nil
+}
+
+// fakeCallEventProcessor fails the first failures calls, then succeeds.
+type fakeCallEventProcessor struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	status   domain.CallStatus
+}
+
+func (f *fakeCallEventProcessor) ProcessCallEvent(ctx context.Context, event *voiceprovider.CallEvent) (*domain.Call, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("database unavailable")
+	}
+	call := domain.NewCall(event.ProviderCallID, string(event.Provider), event.ToNumber, event.FromNumber)
+	if f.status != "" {
+		call.Status = f.status
+	}
+	return call, nil
+}
+
+type recordingNotifier struct {
+	mu     sync.Mutex
+	failed int
+	alerts []domain.UsageAlert
+}
+
+func (n *recordingNotifier) QuoteReady(ctx context.Context, call *domain.Call) {}
+
+func (n *recordingNotifier) CallFailed(ctx context.Context, call *domain.Call) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failed++
+}
+
+func (n *recordingNotifier) UsageAlert(ctx context.Context, alert domain.UsageAlert) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, alert)
+}
+
+func testCallEvent() *voiceprovider.CallEvent {
+	return &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderBland,
+		ProviderCallID: "call-123",
+		ToNumber:       "+15550000001",
+		FromNumber:     "+15550000002",
+		Status:         voiceprovider.CallStatusCompleted,
+	}
+}
+
+func onlyEvent(t *testing.T, repo *MockWebhookEventRepository) *domain.WebhookEvent {
+	t.Helper()
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	if len(repo.events) != 1 {
+		t.Fatalf("expected 1 persisted event, got %d", len(repo.events))
+	}
+	for _, event := range repo.events {
+		return event
+	}
+	return nil
+}
+
+func TestWebhookEventProcessor_Handle_Success(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	p := NewWebhookEventProcessor(repo, &fakeCallEventProcessor{}, zap.NewNop(), nil)
+
+	call, err := p.Handle(context.Background(), testCallEvent(), []byte(`{"call_id":"call-123"}`))
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	event := onlyEvent(t, repo)
+	if event.Status != domain.WebhookEventStatusCompleted {
+		t.Errorf("status = %s, want completed", event.Status)
+	}
+	if event.CallID == nil || *event.CallID != call.ID {
+		t.Errorf("call_id not recorded")
+	}
+	if string(event.Payload) != `{"call_id":"call-123"}` {
+		t.Errorf("payload = %s", event.Payload)
+	}
+	if event.Attempts != 1 {
+		t.Errorf("attempts = %d, want 1", event.Attempts)
+	}
+}
+
+func TestWebhookEventProcessor_Handle_FailureQueuesRetry(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	p := NewWebhookEventProcessor(repo, &fakeCallEventProcessor{failures: 1}, zap.NewNop(), nil)
+
+	_, err := p.Handle(context.Background(), testCallEvent(), nil)
+	if !errors.Is(err, ErrWebhookEventQueued) {
+		t.Fatalf("Handle() error = %v, want ErrWebhookEventQueued", err)
+	}
+
+	event := onlyEvent(t, repo)
+	if event.Status != domain.WebhookEventStatusPending {
+		t.Errorf("status = %s, want pending", event.Status)
+	}
+	if event.LastError == nil {
+		t.Error("expected last_error to be recorded")
+	}
+	if !event.ScheduledAt.After(time.Now()) {
+		t.Error("expected retry to be scheduled in the future")
+	}
+}
+
+func TestWebhookEventProcessor_Handle_PersistFailureStillProcesses(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	repo.CreateError = errors.New("db down")
+	calls := &fakeCallEventProcessor{}
+	p := NewWebhookEventProcessor(repo, calls, zap.NewNop(), nil)
+
+	if _, err := p.Handle(context.Background(), testCallEvent(), nil); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if calls.calls != 1 {
+		t.Errorf("ProcessCallEvent called %d times, want 1", calls.calls)
+	}
+
+	calls.failures = 2
+	_, err := p.Handle(context.Background(), testCallEvent(), nil)
+	if err == nil || errors.Is(err, ErrWebhookEventQueued) {
+		t.Fatalf("Handle() error = %v, want unqueued processing error", err)
+	}
+}
+
+func TestWebhookEventProcessor_RetryEvent(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	calls := &fakeCallEventProcessor{failures: 1, status: domain.CallStatusFailed}
+	notifier := &recordingNotifier{}
+	p := NewWebhookEventProcessor(repo, calls, zap.NewNop(), nil)
+	p.SetNotifier(notifier)
+
+	if _, err := p.Handle(context.Background(), testCallEvent(), nil); !errors.Is(err, ErrWebhookEventQueued) {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	event := onlyEvent(t, repo)
+	event.ScheduledAt = time.Now().Add(-time.Second)
+	p.retryBatch()
+
+	if event.Status != domain.WebhookEventStatusCompleted {
+		t.Fatalf("status = %s, want completed", event.Status)
+	}
+	if event.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", event.Attempts)
+	}
+	if notifier.failed != 1 {
+		t.Errorf("CallFailed notifications = %d, want 1", notifier.failed)
+	}
+}
+
+func TestWebhookEventProcessor_RetryCorruptEventFailsPermanently(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	p := NewWebhookEventProcessor(repo, &fakeCallEventProcessor{}, zap.NewNop(), nil)
+
+	event := domain.NewWebhookEvent("bland", "call-1", "completed", nil, []byte(`not json`))
+	event.ScheduledAt = time.Now().Add(-time.Second)
+	_ = repo.Create(context.Background(), event)
+
+	p.retryBatch()
+
+	if event.Status != domain.WebhookEventStatusFailed {
+		t.Errorf("status = %s, want failed", event.Status)
+	}
+}
+
+func TestWebhookEventProcessor_RecoverStuckEvents(t *testing.T) {
+	repo := NewMockWebhookEventRepository()
+	p := NewWebhookEventProcessor(repo, &fakeCallEventProcessor{}, zap.NewNop(), nil)
+
+	event := domain.NewWebhookEvent("bland", "call-1", "completed", nil, []byte(`{}`))
+	event.MarkProcessing()
+	started := time.Now().Add(-time.Hour)
+	event.StartedAt = &started
+	_ = repo.Create(context.Background(), event)
+
+	if err := p.recoverStuckEvents(context.Background()); err != nil {
+		t.Fatalf("recoverStuckEvents() error = %v", err)
+	}
+	if event.Status != domain.WebhookEventStatusPending {
+		t.Errorf("status = %s, want pending", event.Status)
+	}
+}
+
+func TestWebhookEventProcessor_StartStop(t *testing.T) {
+	p := NewWebhookEventProcessor(NewMockWebhookEventRepository(), &fakeCallEventProcessor{}, zap.NewNop(), nil)
+
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := p.Start(context.Background()); err == nil {
+		t.Error("expected error starting twice")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := p.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
@@ -64,6 +64,9 @@ type Coordinator struct {
 	logger   *zap.Logger
 
 	// State
+	drainCh      chan struct{}
+	drainOnce    sync.Once
+	drainedAt    *time.Time
 	shutdownCh   chan struct{}
 	shutdownOnce sync.Once
 	done         chan struct{}
@@ -92,6 +95,7 @@ func NewCoordinator(cfg *Config, logger *zap.Logger) *Coordinator {
 		services:   make(map[Phase][]Service),
 		timeout:    cfg.Timeout,
 		logger:     logger,
+		drainCh:    make(chan struct{}),
 		shutdownCh: make(chan struct{}),
 		done:       make(chan struct{}),
 	}
@@ -114,6 +118,47 @@ func (c *Coordinator) RegisterFunc(phase Phase, name string, fn func(ctx context
 	c.Register(phase, ServiceFunc{ServiceName: name, ShutdownFn: fn})
 }
 
+// Drain stops the server taking on new work ahead of shutdown, so an
+// orchestrator can move traffic away before sending SIGTERM. It runs the
+// pre-drain phase once; in-flight work carries on until Shutdown, which
+// skips the pre-drain phase if it has already run.
+func (c *Coordinator) Drain(ctx context.Context) error {
+	if errs := c.drain(ctx); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// DrainCh returns a channel that's closed when draining starts, either by
+// Drain or by Shutdown.
+func (c *Coordinator) DrainCh() <-chan struct{} {
+	return c.drainCh
+}
+
+// DrainedAt returns when draining started, or nil if it hasn't.
+func (c *Coordinator) DrainedAt() *time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.drainedAt
+}
+
+// drain runs the pre-drain phase the first time it is called.
+func (c *Coordinator) drain(ctx context.Context) []error {
+	var errs []error
+	c.drainOnce.Do(func() {
+		now := time.Now()
+		c.mu.Lock()
+		c.drainedAt = &now
+		services := c.services[PhasePreDrain]
+		c.mu.Unlock()
+		close(c.drainCh)
+
+		c.logger.Info("draining", zap.Int("services", len(services)))
+		errs = c.shutdownPhase(ctx, PhasePreDrain, services)
+	})
+	return errs
+}
+
 // Shutdown initiates graceful shutdown of all registered services.
 // It runs phases sequentially, but services within each phase run concurrently.
 func (c *Coordinator) Shutdown(ctx context.Context) error {
@@ -148,9 +193,10 @@ func (c *Coordinator) runShutdown(_ context.Context) {
 		zap.Duration("timeout", c.timeout),
 	)
 
-	phases := []Phase{PhasePreDrain, PhaseDrain, PhaseShutdown, PhaseCleanup}
-	var errors []error
+	// Stop taking on new work, unless Drain already did
+	errors := c.drain(ctx)
 
+	phases := []Phase{PhaseDrain, PhaseShutdown, PhaseCleanup}
 	for _, phase := range phases {
 		c.mu.Lock()
 		services := c.services[phase]
@@ -276,7 +322,10 @@ func NewReadinessProbe(coordinator *Coordinator) *ReadinessProbe {
 }
 
 func (rp *ReadinessProbe) watchShutdown() {
-	<-rp.coordinator.ShutdownCh()
+	select {
+	case <-rp.coordinator.DrainCh():
+	case <-rp.coordinator.ShutdownCh():
+	}
 	rp.mu.Lock()
 	rp.state = HealthStateDraining
 	rp.mu.Unlock()
@@ -266,6 +266,49 @@ func TestReadinessProbe(t *testing.T) {
 	}
 }
 
+func TestCoordinator_Drain(t *testing.T) {
+	logger := zap.NewNop()
+	coord := NewCoordinator(nil, logger)
+	probe := NewReadinessProbe(coord)
+
+	var preDrains, shutdowns atomic.Int32
+	coord.RegisterFunc(PhasePreDrain, "stop-claiming", func(ctx context.Context) error {
+		preDrains.Add(1)
+		return nil
+	})
+	coord.RegisterFunc(PhaseShutdown, "worker", func(ctx context.Context) error {
+		shutdowns.Add(1)
+		return nil
+	})
+
+	if coord.DrainedAt() != nil {
+		t.Error("DrainedAt should be nil before draining")
+	}
+	if err := coord.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+	if err := coord.Drain(context.Background()); err != nil {
+		t.Fatalf("second Drain() error = %v", err)
+	}
+	if preDrains.Load() != 1 || shutdowns.Load() != 0 {
+		t.Errorf("pre-drain ran %d times and shutdown %d times, expected 1 and 0", preDrains.Load(), shutdowns.Load())
+	}
+	if coord.DrainedAt() == nil {
+		t.Error("DrainedAt not recorded")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if probe.State() != HealthStateDraining {
+		t.Errorf("expected draining state, got %v", probe.State())
+	}
+
+	// Shutdown doesn't run the pre-drain phase again
+	coord.Shutdown(context.Background())
+	if preDrains.Load() != 1 || shutdowns.Load() != 1 {
+		t.Errorf("pre-drain ran %d times and shutdown %d times, expected 1 each", preDrains.Load(), shutdowns.Load())
+	}
+}
+
 func TestPhase_String(t *testing.T) {
 	tests := []struct {
 		phase    Phase
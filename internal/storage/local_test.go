@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	ctx := context.Background()
+	key := "recordings/2026/03/call.wav"
+
+	if err := store.Put(ctx, key, strings.NewReader("RIFF audio"), 10, "audio/wav"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if string(data) != "RIFF audio" || obj.Size != 10 {
+		t.Errorf("Get() = %q (%d bytes)", data, obj.Size)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of missing object error = %v", err)
+	}
+}
+
+func TestLocalStore_PutSizeMismatch(t *testing.T) {
+	store, _ := NewLocalStore(t.TempDir())
+
+	if err := store.Put(context.Background(), "a.mp3", strings.NewReader("short"), 100, "audio/mpeg"); err == nil {
+		t.Fatal("Put() with short body succeeded, want error")
+	}
+	if _, err := store.Get(context.Background(), "a.mp3"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("partial upload was stored: %v", err)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	valid := []string{"a.mp3", "recordings/2026/03/x.mp3"}
+	for _, key := range valid {
+		if err := validateKey(key); err != nil {
+			t.Errorf("validateKey(%q) error = %v", key, err)
+		}
+	}
+
+	invalid := []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b", `a\b`, "a/./b"}
+	for _, key := range invalid {
+		if err := validateKey(key); err == nil {
+			t.Errorf("validateKey(%q) succeeded, want error", key)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	store, err := New(Config{})
+	if err != nil || store != nil {
+		t.Errorf("New() with no backend = %v, %v; want nil, nil", store, err)
+	}
+
+	store, err = New(Config{Backend: "local", LocalPath: t.TempDir()})
+	if err != nil || store.Name() != BackendLocal {
+		t.Errorf("New(local) = %v, %v", store, err)
+	}
+
+	invalid := []Config{
+		{Backend: "local"},
+		{Backend: "s3"},
+		{Backend: "s3", S3Bucket: "b"},
+		{Backend: "gcs"},
+	}
+	for _, cfg := range invalid {
+		if store, err := New(cfg); err == nil || store != nil {
+			t.Errorf("New(%+v) = %v, %v; want an error", cfg, store, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Package storage keeps files such as call recordings on local disk or in an
+// S3-compatible object store.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Backend names accepted in Config.Backend.
+const (
+	BackendNone  = ""
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Object is a stored file being read. Callers must close Body.
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// Store saves and serves objects by key. Keys are slash-separated relative
+// paths such as "recordings/<id>.mp3".
+type Store interface {
+	// Put stores size bytes read from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get opens the object stored under key, or returns ErrNotFound.
+	Get(ctx context.Context, key string) (*Object, error)
+
+	// Delete removes the object stored under key. Deleting a missing object
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// Name returns the backend name.
+	Name() string
+}
+
+// Config holds storage backend settings.
+type Config struct {
+	Backend string // "local", "s3", or empty to disable
+
+	LocalPath string // Directory objects are stored beneath
+
+	S3Endpoint        string // e.g. "https://s3.us-east-1.amazonaws.com" or a MinIO URL
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool // Address the bucket in the path instead of the host name
+}
+
+// New creates the store for the configured backend. It returns nil when no
+// backend is configured.
+func New(cfg Config) (Store, error) {
+	switch strings.ToLower(cfg.Backend) {
+	case BackendNone:
+		return nil, nil
+	case BackendLocal:
+		local, err := NewLocalStore(cfg.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		return local, nil
+	case BackendS3:
+		s3, err := NewS3Store(cfg, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return s3, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// validateKey rejects keys that could escape the store's root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key from the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	// The RFC lists eight-digit codes; six-digit codes are their last six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Counter(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	counter := Counter(now)
+
+	tests := []struct {
+		name   string
+		code   string
+		want   int64
+		wantOK bool
+	}{
+		{"current", "050471", counter, true},
+		{"previous step", "081804", counter - 1, true},
+		{"spaced", "050 471", counter, true},
+		{"wrong", "123456", 0, false},
+		{"too short", "05047", 0, false},
+		{"empty", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Validate(rfcSecret, tt.code, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Validate() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	// Codes from outside the skew window are rejected.
+	old, _ := Code(rfcSecret, counter-2)
+	if _, ok := Validate(rfcSecret, old, now); ok {
+		t.Error("Validate() accepted a code two steps old")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+	b, _ := GenerateSecret()
+	if len(a) != 32 || a == b {
+		t.Errorf("GenerateSecret() = %q, %q", a, b)
+	}
+	code, err := Code(a, Counter(time.Now()))
+	if err != nil || len(code) != Digits {
+		t.Errorf("Code() = %q, %v", code, err)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("Code() accepted an invalid secret")
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("Quick Quote", "admin@example.com", "JBSWY3DPEHPK3PXP")
+	want := "otpauth://totp/Quick%20Quote:admin@example.com?"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("URI() = %s, want prefix %s", got, want)
+	}
+	for _, param := range []string{"secret=JBSWY3DPEHPK3PXP", "issuer=Quick%20Quote", "digits=6", "period=30", "algorithm=SHA1"} {
+		if !strings.Contains(got, param) {
+			t.Errorf("URI() = %s, missing %s", got, param)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceparentHeader is the W3C Trace Context header that carries a span's
+// identity between services.
+const TraceparentHeader = "traceparent"
+
+// traceContext reads and writes traceparent and tracestate headers.
+var traceContext = propagation.TraceContext{}
+
+// Extract returns a context continuing the trace in h's traceparent header,
+// or ctx unchanged if the header is missing or malformed.
+func Extract(ctx context.Context, h http.Header) context.Context {
+	return traceContext.Extract(ctx, propagation.HeaderCarrier(h))
+}
+
+// Inject sets the traceparent header for the current span in ctx, so the
+// receiving service can join the trace.
+func Inject(ctx context.Context, h http.Header) {
+	traceContext.Inject(ctx, propagation.HeaderCarrier(h))
+}
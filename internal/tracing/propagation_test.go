@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantValid   bool
+		wantSampled bool
+	}{
+		{"sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true, false},
+		{"future version with extra field", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, true},
+		{"missing", "", false, false},
+		{"version 00 with extra field", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false, false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false, false},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false, false},
+		{"zero span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false, false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false, false},
+		{"short trace ID", "00-4bf92f35-00f067aa0ba902b7-01", false, false},
+		{"bad flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.header != "" {
+				h.Set(TraceparentHeader, tt.header)
+			}
+			sc := trace.SpanContextFromContext(Extract(context.Background(), h))
+			if sc.IsValid() != tt.wantValid {
+				t.Fatalf("IsValid() = %v, want %v", sc.IsValid(), tt.wantValid)
+			}
+			if !tt.wantValid {
+				return
+			}
+			if sc.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID().String() != "00f067aa0ba902b7" {
+				t.Errorf("IDs = %s/%s", sc.TraceID(), sc.SpanID())
+			}
+			if sc.IsSampled() != tt.wantSampled {
+				t.Errorf("IsSampled() = %v, want %v", sc.IsSampled(), tt.wantSampled)
+			}
+			if !sc.IsRemote() {
+				t.Error("extracted span context should be remote")
+			}
+		})
+	}
+}
+
+func TestInject_RoundTrip(t *testing.T) {
+	want := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), want)
+
+	h := http.Header{}
+	Inject(ctx, h)
+	if got := h.Get(TraceparentHeader); got != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("traceparent = %q", got)
+	}
+
+	sc := trace.SpanContextFromContext(Extract(context.Background(), h))
+	if sc.TraceID() != want.TraceID() || sc.SpanID() != want.SpanID() || !sc.IsSampled() {
+		t.Error("extracted span context does not match injected span")
+	}
+}
+
+func TestInject_WithoutSpan(t *testing.T) {
+	h := http.Header{}
+	Inject(context.Background(), h)
+	if h.Get(TraceparentHeader) != "" {
+		t.Error("traceparent should not be set without a span")
+	}
+}
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/config"
+)
+
+const (
+	// queueSize is how many ended spans may wait for export before new ones
+	// are dropped.
+	queueSize = 2048
+	// maxBatchSize is the most spans sent in one export request.
+	maxBatchSize = 512
+	// batchTimeout is the longest an ended span waits before being exported.
+	batchTimeout = 5 * time.Second
+	// exportTimeout bounds a single export request.
+	exportTimeout = 10 * time.Second
+)
+
+// NewProvider creates a provider that samples new traces at cfg's ratio and
+// exports them in batches to the OTLP/HTTP endpoint in cfg, from a
+// background goroutine so ending a span never waits on the network. It
+// returns nil when no endpoint is configured.
+func NewProvider(cfg *config.TracingConfig, logger *zap.Logger) (*sdktrace.TracerProvider, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(tracesURL(cfg.Endpoint)),
+		otlptracehttp.WithHeaders(parseHeaders(cfg.Headers)),
+		otlptracehttp.WithTimeout(exportTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	// Export failures are logged rather than returned to the code that
+	// ended the span
+	logger = logger.Named("tracing")
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("failed to export spans", zap.Error(err))
+	}))
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithMaxQueueSize(queueSize),
+			sdktrace.WithMaxExportBatchSize(maxBatchSize),
+			sdktrace.WithBatchTimeout(batchTimeout),
+			sdktrace.WithExportTimeout(exportTimeout),
+		),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(sdkresource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	), nil
+}
+
+// SetProvider installs the provider used by Start and the W3C Trace Context
+// propagator. Passing nil disables tracing.
+func SetProvider(p *sdktrace.TracerProvider) {
+	if p == nil {
+		enabled.Store(false)
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return
+	}
+	otel.SetTracerProvider(p)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	enabled.Store(true)
+}
+
+// tracesURL returns the URL spans are posted to: the endpoint, with the
+// OTLP traces path added if it isn't there already.
+func tracesURL(endpoint string) string {
+	url := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	return url
+}
+
+// parseHeaders parses "key=value,key2=value2" into a map, the format of
+// OTEL_EXPORTER_OTLP_HEADERS.
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers
+}
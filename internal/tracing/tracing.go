@@ -0,0 +1,45 @@
+// Package tracing sets up OpenTelemetry distributed tracing, exporting spans
+// to a collector over OTLP/HTTP.
+//
+// Spans are carried in the context: a span started by the HTTP middleware is
+// the parent of the service, database and provider spans started while the
+// request is handled. Code that records spans uses the OpenTelemetry trace
+// API directly; until a provider is installed with SetProvider, its spans
+// are no-ops.
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationScope names this code as the source of its spans.
+const instrumentationScope = "github.com/jkindrix/quickquote"
+
+// enabled is set while a provider is installed.
+var enabled atomic.Bool
+
+// Enabled reports whether spans are being recorded and exported.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Start starts a span as a child of the span in ctx, or of a remote parent
+// extracted from incoming headers, and returns a context carrying it.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationScope).Start(ctx, name, opts...)
+}
+
+// RecordError marks span as failed and records err as an exception event.
+// A nil err is ignored.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
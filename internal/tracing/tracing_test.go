@@ -0,0 +1,221 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jkindrix/quickquote/internal/config"
+)
+
+// newTestProvider installs a provider sampling at ratio that exports to a
+// collector which discards spans, and returns a recorder of ended spans.
+func newTestProvider(t *testing.T, ratio float64) *tracetest.SpanRecorder {
+	t.Helper()
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	p, err := NewProvider(&config.TracingConfig{Endpoint: collector.URL, SampleRatio: ratio}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	p.RegisterSpanProcessor(recorder)
+	SetProvider(p)
+	t.Cleanup(func() {
+		SetProvider(nil)
+		_ = p.Shutdown(context.Background())
+		collector.Close()
+	})
+	return recorder
+}
+
+func TestStart_WithoutProviderIsNoop(t *testing.T) {
+	SetProvider(nil)
+
+	_, span := Start(context.Background(), "op")
+	defer span.End()
+	if Enabled() {
+		t.Error("tracing should be disabled without a provider")
+	}
+	if span.IsRecording() || span.SpanContext().IsValid() {
+		t.Error("span should not be recorded without a provider")
+	}
+}
+
+func TestProvider_ChildSpansShareTrace(t *testing.T) {
+	recorder := newTestProvider(t, 1)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].SpanContext().TraceID() != spans[1].SpanContext().TraceID() {
+		t.Error("child should share its parent's trace ID")
+	}
+	if spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Error("child's parent should be the parent span")
+	}
+}
+
+func TestProvider_UnsampledSpansAreNotRecorded(t *testing.T) {
+	recorder := newTestProvider(t, 0)
+
+	_, span := Start(context.Background(), "op")
+	span.End()
+
+	if span.SpanContext().IsSampled() || len(recorder.Ended()) != 0 {
+		t.Error("spans should not be sampled at ratio 0")
+	}
+}
+
+func TestProvider_RemoteParentKeepsSamplingDecision(t *testing.T) {
+	recorder := newTestProvider(t, 0)
+
+	h := http.Header{}
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, span := Start(Extract(context.Background(), h), "op")
+	span.End()
+
+	if len(recorder.Ended()) != 1 {
+		t.Error("a sampled remote parent should be followed regardless of ratio")
+	}
+}
+
+func TestRecordError(t *testing.T) {
+	recorder := newTestProvider(t, 1)
+
+	_, failed := Start(context.Background(), "failed")
+	RecordError(failed, errors.New("boom"))
+	failed.End()
+	_, ok := Start(context.Background(), "ok")
+	RecordError(ok, nil)
+	ok.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if got := spans[0].Status(); got.Code != codes.Error || got.Description != "boom" {
+		t.Errorf("status = %+v", got)
+	}
+	if events := spans[0].Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("events = %+v", events)
+	}
+	if got := spans[1].Status(); got.Code != codes.Unset || len(spans[1].Events()) != 0 {
+		t.Error("a nil error should not change the span")
+	}
+}
+
+func TestNewProvider_DisabledWithoutEndpoint(t *testing.T) {
+	p, err := NewProvider(&config.TracingConfig{}, zap.NewNop())
+	if err != nil || p != nil {
+		t.Errorf("NewProvider() = %v, %v; want nil provider without an endpoint", p, err)
+	}
+}
+
+func TestNewProvider_ExportsToCollector(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		path    string
+		auth    string
+		payload collectortrace.ExportTraceServiceRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		path = r.URL.Path
+		auth = r.Header.Get("Authorization")
+		if err := proto.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid OTLP request: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	p, err := NewProvider(&config.TracingConfig{
+		Endpoint:    server.URL,
+		Headers:     "Authorization=Bearer token, x-empty",
+		ServiceName: "quickquote-test",
+		SampleRatio: 1,
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	_, span := p.Tracer("test").Start(context.Background(), "op", trace.WithSpanKind(trace.SpanKindClient))
+	span.End()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if path != "/v1/traces" {
+		t.Errorf("path = %q, want /v1/traces", path)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(payload.ResourceSpans) != 1 || len(payload.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("unexpected payload shape: %v", &payload)
+	}
+	serviceName := ""
+	for _, attr := range payload.ResourceSpans[0].Resource.Attributes {
+		if attr.Key == "service.name" {
+			serviceName = attr.Value.GetStringValue()
+		}
+	}
+	if serviceName != "quickquote-test" {
+		t.Errorf("service.name = %q, want quickquote-test", serviceName)
+	}
+	spans := payload.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 || spans[0].Name != "op" {
+		t.Fatalf("spans = %v", spans)
+	}
+}
+
+func TestTracesURL(t *testing.T) {
+	tests := map[string]string{
+		"http://collector:4318":            "http://collector:4318/v1/traces",
+		"http://collector:4318/":           "http://collector:4318/v1/traces",
+		"http://collector:4318/v1/traces":  "http://collector:4318/v1/traces",
+		"http://collector:4318/v1/traces/": "http://collector:4318/v1/traces",
+	}
+	for endpoint, want := range tests {
+		if got := tracesURL(endpoint); got != want {
+			t.Errorf("tracesURL(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	got := parseHeaders(" a = 1 ,b=2=3,,=x,c")
+	want := map[string]string{"a": "1", "b": "2=3"}
+	if len(got) != len(want) {
+		t.Fatalf("parseHeaders() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("header %q = %q, want %q", k, got[k], v)
+		}
+	}
+}
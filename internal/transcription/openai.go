@@ -0,0 +1,56 @@
+package transcription
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultOpenAIAPIURL is the OpenAI API.
+const defaultOpenAIAPIURL = "https://api.openai.com/v1"
+
+// OpenAITranscriber transcribes audio with the OpenAI audio transcription API.
+type OpenAITranscriber struct {
+	apiKey   string
+	model    string
+	apiURL   string
+	language string
+	client   *http.Client
+}
+
+// NewOpenAITranscriber creates an OpenAI transcriber. A default HTTP client
+// is used when client is nil.
+func NewOpenAITranscriber(cfg Config, client *http.Client) *OpenAITranscriber {
+	model := cfg.OpenAIModel
+	if model == "" {
+		model = "whisper-1"
+	}
+	apiURL := cfg.OpenAIAPIURL
+	if apiURL == "" {
+		apiURL = defaultOpenAIAPIURL
+	}
+	return &OpenAITranscriber{
+		apiKey:   cfg.OpenAIAPIKey,
+		model:    model,
+		apiURL:   strings.TrimRight(apiURL, "/"),
+		language: cfg.Language,
+		client:   defaultClient(client),
+	}
+}
+
+// Name returns the backend name.
+func (t *OpenAITranscriber) Name() string {
+	return ProviderOpenAI
+}
+
+// Transcribe uploads the audio to the transcriptions endpoint.
+func (t *OpenAITranscriber) Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+t.apiKey)
+	return postAudio(ctx, t.client, t.apiURL+"/audio/transcriptions", header, map[string]string{
+		"model":           t.model,
+		"language":        t.language,
+		"response_format": "json",
+	}, audio, filename)
+}
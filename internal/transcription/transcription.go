@@ -0,0 +1,137 @@
+// Package transcription turns call recordings into text with OpenAI's Whisper
+// API or a self-hosted whisper.cpp server.
+package transcription
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider names accepted in Config.Provider.
+const (
+	ProviderNone       = ""
+	ProviderOpenAI     = "openai"
+	ProviderWhisperCpp = "whisper_cpp"
+)
+
+// Transcriber transcribes audio.
+type Transcriber interface {
+	// Transcribe returns the text spoken in audio. filename's extension
+	// tells the backend the audio format, e.g. "call.mp3".
+	Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error)
+
+	// Name returns the backend name for logging.
+	Name() string
+}
+
+// Config holds transcription backend settings.
+type Config struct {
+	Provider string // "openai", "whisper_cpp", or empty to disable
+	Language string // ISO-639-1 code such as "en"; empty lets the model detect it
+
+	OpenAIAPIKey string
+	OpenAIModel  string // Defaults to whisper-1
+	OpenAIAPIURL string // Defaults to https://api.openai.com/v1
+
+	WhisperCppURL string // Base URL of a whisper.cpp server, e.g. http://whisper:8080
+}
+
+// New creates the transcriber for the configured provider. It returns nil
+// when no provider is configured.
+func New(cfg Config) (Transcriber, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderNone:
+		return nil, nil
+	case ProviderOpenAI:
+		if cfg.OpenAIAPIKey == "" {
+			return nil, errors.New("openai api key is required for whisper transcription")
+		}
+		return NewOpenAITranscriber(cfg, nil), nil
+	case ProviderWhisperCpp:
+		if cfg.WhisperCppURL == "" {
+			return nil, errors.New("whisper.cpp server url is required")
+		}
+		return NewWhisperCppTranscriber(cfg, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown transcription provider %q", cfg.Provider)
+	}
+}
+
+// defaultClient returns client, or a client with a timeout long enough for
+// transcribing long calls.
+func defaultClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: 10 * time.Minute}
+}
+
+// postAudio uploads audio as the multipart "file" field along with fields
+// and returns the "text" of the JSON response.
+func postAudio(ctx context.Context, client *http.Client, url string, header http.Header, fields map[string]string, audio io.Reader, filename string) (string, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return "", fmt.Errorf("failed to read audio: %w", err)
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := mw.WriteField(name, value); err != nil {
+			return "", fmt.Errorf("failed to build transcription request: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transcription request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("transcription request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		msg := strings.TrimSpace(string(respBody))
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Message != "" {
+			msg = apiErr.Error.Message
+		}
+		return "", fmt.Errorf("transcription returned %d: %s", resp.StatusCode, msg)
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse transcription response: %w", err)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
@@ -0,0 +1,139 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    string
+		wantErr bool
+	}{
+		{"disabled", Config{}, "", false},
+		{"openai", Config{Provider: "openai", OpenAIAPIKey: "sk-test"}, ProviderOpenAI, false},
+		{"openai without key", Config{Provider: "openai"}, "", true},
+		{"whisper.cpp", Config{Provider: "whisper_cpp", WhisperCppURL: "http://whisper:8080"}, ProviderWhisperCpp, false},
+		{"whisper.cpp without url", Config{Provider: "whisper_cpp"}, "", true},
+		{"unknown", Config{Provider: "dictaphone"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("New() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Name() != tt.want {
+				t.Errorf("New() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// upload is what a fake transcription server received.
+type upload struct {
+	path   string
+	auth   string
+	file   string
+	name   string
+	fields map[string]string
+}
+
+func newTranscriptionServer(t *testing.T, status int, body string) (*httptest.Server, *upload) {
+	t.Helper()
+	got := &upload{fields: make(map[string]string)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.path = r.URL.Path
+		got.auth = r.Header.Get("Authorization")
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm() error = %v", err)
+		}
+		for name, values := range r.MultipartForm.Value {
+			got.fields[name] = values[0]
+		}
+		if file, header, err := r.FormFile("file"); err == nil {
+			data, _ := io.ReadAll(file)
+			got.file = string(data)
+			got.name = header.Filename
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+func TestOpenAITranscriber_Transcribe(t *testing.T) {
+	srv, got := newTranscriptionServer(t, http.StatusOK, `{"text":" I need a quote for a deck. "}`)
+	tr := NewOpenAITranscriber(Config{OpenAIAPIKey: "sk-test", OpenAIAPIURL: srv.URL + "/v1/", Language: "en"}, srv.Client())
+
+	text, err := tr.Transcribe(context.Background(), strings.NewReader("audio"), "call.mp3")
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "I need a quote for a deck." {
+		t.Errorf("Transcribe() = %q", text)
+	}
+	if got.path != "/v1/audio/transcriptions" {
+		t.Errorf("path = %q", got.path)
+	}
+	if got.auth != "Bearer sk-test" {
+		t.Errorf("Authorization = %q", got.auth)
+	}
+	if got.file != "audio" || got.name != "call.mp3" {
+		t.Errorf("file = %q named %q", got.file, got.name)
+	}
+	if got.fields["model"] != "whisper-1" || got.fields["language"] != "en" || got.fields["response_format"] != "json" {
+		t.Errorf("fields = %v", got.fields)
+	}
+}
+
+func TestOpenAITranscriber_Error(t *testing.T) {
+	body, _ := json.Marshal(map[string]any{"error": map[string]string{"message": "Invalid file format."}})
+	srv, _ := newTranscriptionServer(t, http.StatusBadRequest, string(body))
+	tr := NewOpenAITranscriber(Config{OpenAIAPIKey: "sk-test", OpenAIAPIURL: srv.URL}, srv.Client())
+
+	_, err := tr.Transcribe(context.Background(), strings.NewReader("audio"), "call.mp3")
+	if err == nil || !strings.Contains(err.Error(), "400: Invalid file format.") {
+		t.Errorf("Transcribe() error = %v, want the API message", err)
+	}
+}
+
+func TestWhisperCppTranscriber_Transcribe(t *testing.T) {
+	srv, got := newTranscriptionServer(t, http.StatusOK, `{"text":"hello there\n"}`)
+	tr := NewWhisperCppTranscriber(Config{WhisperCppURL: srv.URL + "/"}, srv.Client())
+
+	text, err := tr.Transcribe(context.Background(), strings.NewReader("audio"), "call.wav")
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if text != "hello there" {
+		t.Errorf("Transcribe() = %q", text)
+	}
+	if got.path != "/inference" {
+		t.Errorf("path = %q", got.path)
+	}
+	if got.auth != "" {
+		t.Errorf("Authorization = %q, want none", got.auth)
+	}
+	if _, ok := got.fields["language"]; ok {
+		t.Errorf("language sent without being configured: %v", got.fields)
+	}
+	if got.name != "call.wav" {
+		t.Errorf("filename = %q", got.name)
+	}
+}
@@ -0,0 +1,39 @@
+package transcription
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WhisperCppTranscriber transcribes audio with a whisper.cpp server. Start
+// the server with --convert so it accepts MP3 and other formats via ffmpeg.
+type WhisperCppTranscriber struct {
+	url      string
+	language string
+	client   *http.Client
+}
+
+// NewWhisperCppTranscriber creates a whisper.cpp transcriber. A default HTTP
+// client is used when client is nil.
+func NewWhisperCppTranscriber(cfg Config, client *http.Client) *WhisperCppTranscriber {
+	return &WhisperCppTranscriber{
+		url:      strings.TrimRight(cfg.WhisperCppURL, "/"),
+		language: cfg.Language,
+		client:   defaultClient(client),
+	}
+}
+
+// Name returns the backend name.
+func (t *WhisperCppTranscriber) Name() string {
+	return ProviderWhisperCpp
+}
+
+// Transcribe uploads the audio to the server's inference endpoint.
+func (t *WhisperCppTranscriber) Transcribe(ctx context.Context, audio io.Reader, filename string) (string, error) {
+	return postAudio(ctx, t.client, t.url+"/inference", nil, map[string]string{
+		"language":        t.language,
+		"response_format": "json",
+	}, audio, filename)
+}
@@ -0,0 +1,196 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct validates a struct, or a pointer to one, against the validate tags
+// on its fields and returns an error per rule a field breaks. Fields are
+// named by their JSON path, e.g. calls[2].phone_number.
+//
+// A tag lists comma-separated rules:
+//
+//	required     the field must be set; strings must not be blank
+//	e164         a phone number in E.164 format
+//	temperature  a model temperature between 0 and 1
+//	duration     a positive number, or a Go duration string such as "90s"
+//	min=N, max=N bounds on a number, or on the length of a string, slice or map
+//	oneof=a b c  one of the space-separated values
+//	url, uuid    a URL or UUID
+//
+// Rules other than required ignore fields that are unset, so optional fields
+// are only checked when present. Struct fields, and slices and maps of
+// structs, are validated in turn. An unknown rule is a programming error and
+// panics.
+func Struct(s interface{}) ValidationErrors {
+	v := New()
+	v.validateValue("", reflect.ValueOf(s))
+	return v.Errors()
+}
+
+func (v *Validator) validateValue(path string, rv reflect.Value) {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		v.validateStruct(path, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			v.validateValue(fmt.Sprintf("%s[%d]", path, i), rv.Index(i))
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			v.validateValue(fmt.Sprintf("%s.%v", path, iter.Key()), iter.Value())
+		}
+	}
+}
+
+func (v *Validator) validateStruct(path string, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && name == "" {
+			v.validateValue(path, fv)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		if tag := sf.Tag.Get("validate"); tag != "" {
+			v.validateField(name, fv, tag)
+		}
+		v.validateValue(name, fv)
+	}
+}
+
+func (v *Validator) validateField(field string, fv reflect.Value, tag string) {
+	rules := strings.Split(tag, ",")
+	if fv.IsZero() {
+		for _, rule := range rules {
+			if rule == "required" {
+				v.AddError(field, "is required", CodeRequired)
+			}
+		}
+		return
+	}
+	for fv.Kind() == reflect.Ptr {
+		fv = fv.Elem()
+	}
+
+	for _, rule := range rules {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if fv.Kind() == reflect.String {
+				v.Required(field, fv.String())
+			}
+		case "e164":
+			v.PhoneNumber(field, stringValue(field, fv, rule))
+		case "url":
+			v.URL(field, stringValue(field, fv, rule))
+		case "uuid":
+			v.UUID(field, stringValue(field, fv, rule))
+		case "oneof":
+			v.OneOf(field, stringValue(field, fv, rule), strings.Fields(arg))
+		case "temperature":
+			if t := numberValue(field, fv, rule); t < 0 || t > 1 {
+				v.AddError(field, "must be between 0 and 1", CodeInvalidValue)
+			}
+		case "duration":
+			v.duration(field, fv)
+		case "min", "max":
+			v.bound(field, fv, name, arg)
+		default:
+			panic(fmt.Sprintf("validation: unknown rule %q on %s", rule, field))
+		}
+	}
+}
+
+func (v *Validator) duration(field string, fv reflect.Value) {
+	if fv.Kind() == reflect.String {
+		d, err := time.ParseDuration(fv.String())
+		if err != nil {
+			v.AddError(field, "must be a duration such as 90s or 5m", CodeInvalidFormat)
+			return
+		}
+		if d <= 0 {
+			v.AddError(field, "must be positive", CodeInvalidValue)
+		}
+		return
+	}
+	if numberValue(field, fv, "duration") <= 0 {
+		v.AddError(field, "must be positive", CodeInvalidValue)
+	}
+}
+
+func (v *Validator) bound(field string, fv reflect.Value, rule, arg string) {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validation: invalid %s=%s on %s", rule, arg, field))
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		if rule == "min" {
+			v.MinLength(field, fv.String(), int(limit))
+		} else {
+			v.MaxLength(field, fv.String(), int(limit))
+		}
+		return
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if n := fv.Len(); rule == "min" && float64(n) < limit {
+			v.AddError(field, fmt.Sprintf("must have at least %s items", arg), CodeTooShort)
+		} else if rule == "max" && float64(n) > limit {
+			v.AddError(field, fmt.Sprintf("must have at most %s items", arg), CodeTooLong)
+		}
+		return
+	}
+
+	if n := numberValue(field, fv, rule); rule == "min" && n < limit {
+		v.AddError(field, "must be at least "+arg, CodeInvalidValue)
+	} else if rule == "max" && n > limit {
+		v.AddError(field, "must be at most "+arg, CodeInvalidValue)
+	}
+}
+
+func stringValue(field string, fv reflect.Value, rule string) string {
+	if fv.Kind() != reflect.String {
+		panic(fmt.Sprintf("validation: rule %q needs a string, %s is %s", rule, field, fv.Kind()))
+	}
+	return fv.String()
+}
+
+func numberValue(field string, fv reflect.Value, rule string) float64 {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return fv.Float()
+	}
+	panic(fmt.Sprintf("validation: rule %q needs a number, %s is %s", rule, field, fv.Kind()))
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"testing"
+)
+
+type testTarget struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+}
+
+type testCallRequest struct {
+	Name        string                `json:"name" validate:"required,max=10"`
+	Temperature *float64              `json:"temperature,omitempty" validate:"temperature"`
+	MaxDuration *int                  `json:"max_duration,omitempty" validate:"duration"`
+	Timeout     string                `json:"timeout,omitempty" validate:"duration"`
+	Voicemail   string                `json:"voicemail_action,omitempty" validate:"oneof=hangup leave_message"`
+	Webhook     string                `json:"webhook,omitempty" validate:"url"`
+	Retries     int                   `json:"retries,omitempty" validate:"min=1,max=5"`
+	Targets     []testTarget          `json:"targets" validate:"required,max=2"`
+	ByRegion    map[string]testTarget `json:"by_region,omitempty"`
+	Ignored     string                `json:"-" validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	temp := func(f float64) *float64 { return &f }
+	minutes := func(n int) *int { return &n }
+	valid := func() testCallRequest {
+		return testCallRequest{Name: "Intake", Targets: []testTarget{{PhoneNumber: "+15551234567"}}}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*testCallRequest)
+		want   map[string]string // field -> code
+	}{
+		{"valid", func(r *testCallRequest) {}, nil},
+		{"optional fields set", func(r *testCallRequest) {
+			r.Temperature = temp(0)
+			r.MaxDuration = minutes(15)
+			r.Timeout = "90s"
+			r.Voicemail = "hangup"
+			r.Webhook = "https://example.com/hook"
+			r.Retries = 3
+		}, nil},
+		{"missing required", func(r *testCallRequest) { r.Name = "  "; r.Targets = nil }, map[string]string{
+			"name": CodeRequired, "targets": CodeRequired,
+		}},
+		{"too long", func(r *testCallRequest) { r.Name = "Software intake" }, map[string]string{"name": CodeTooLong}},
+		{"temperature out of range", func(r *testCallRequest) { r.Temperature = temp(1.5) }, map[string]string{"temperature": CodeInvalidValue}},
+		{"zero duration", func(r *testCallRequest) { r.MaxDuration = minutes(0) }, map[string]string{"max_duration": CodeInvalidValue}},
+		{"bad duration string", func(r *testCallRequest) { r.Timeout = "soon" }, map[string]string{"timeout": CodeInvalidFormat}},
+		{"not one of", func(r *testCallRequest) { r.Voicemail = "sing" }, map[string]string{"voicemail_action": CodeInvalidValue}},
+		{"bad url", func(r *testCallRequest) { r.Webhook = "example" }, map[string]string{"webhook": CodeInvalidFormat}},
+		{"out of bounds", func(r *testCallRequest) { r.Retries = 9 }, map[string]string{"retries": CodeInvalidValue}},
+		{"too many items", func(r *testCallRequest) {
+			r.Targets = append(r.Targets, r.Targets[0], r.Targets[0])
+		}, map[string]string{"targets": CodeTooLong}},
+		{"nested slice", func(r *testCallRequest) {
+			r.Targets = append(r.Targets, testTarget{PhoneNumber: "call me"})
+		}, map[string]string{"targets[1].phone_number": CodeInvalidFormat}},
+		{"nested map", func(r *testCallRequest) {
+			r.ByRegion = map[string]testTarget{"west": {}}
+		}, map[string]string{"by_region.west.phone_number": CodeRequired}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+
+			errs := Struct(&req)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("Struct() = %v, want errors for %v", errs, tt.want)
+			}
+			for _, err := range errs {
+				if code, ok := tt.want[err.Field]; !ok || code != err.Code {
+					t.Errorf("unexpected error %+v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestStruct_NilPointer(t *testing.T) {
+	var req *testCallRequest
+	if errs := Struct(req); errs.HasErrors() {
+		t.Errorf("Struct(nil) = %v", errs)
+	}
+}
+
+func TestStruct_UnknownRulePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown rule")
+		}
+	}()
+	Struct(struct {
+		Name string `json:"name" validate:"shiny"`
+	}{Name: "x"})
+}
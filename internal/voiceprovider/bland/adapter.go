@@ -52,6 +52,18 @@ func (p *Provider) GetWebhookPath() string {
 	return "/webhook/bland"
 }
 
+// SupportsBatch reports that batches of calls can be placed through Bland.
+// Bland calls, batches and texts go through the Bland API client rather than
+// this adapter.
+func (p *Provider) SupportsBatch() bool {
+	return true
+}
+
+// SupportsSMS reports that texts can be sent through Bland.
+func (p *Provider) SupportsSMS() bool {
+	return true
+}
+
 // ValidateWebhook verifies the webhook signature if a secret is configured.
 func (p *Provider) ValidateWebhook(r *http.Request) bool {
 	// If no webhook secret is configured, skip validation
@@ -62,8 +74,12 @@ func (p *Provider) ValidateWebhook(r *http.Request) bool {
 	}
 
 	// Bland AI uses X-Webhook-Secret header for signature validation
-	// The signature is an HMAC-SHA256 of the request body
-	signature := r.Header.Get("X-Webhook-Secret")
+	// The signature is an HMAC-SHA256 of the request body, prefixed with
+	// the X-Webhook-Timestamp value and a dot when that is sent
+	signature := r.Header.Get("X-Webhook-Signature")
+	if signature == "" {
+		signature = r.Header.Get("X-Webhook-Secret")
+	}
 	if signature == "" {
 		// Also check alternative header names Bland might use
 		signature = r.Header.Get("X-Bland-Signature")
@@ -88,6 +104,9 @@ func (p *Provider) ValidateWebhook(r *http.Request) bool {
 
 	// Compute expected HMAC-SHA256 signature
 	mac := hmac.New(sha256.New, []byte(p.config.WebhookSecret))
+	if timestamp := r.Header.Get("X-Webhook-Timestamp"); timestamp != "" {
+		mac.Write([]byte(timestamp + "."))
+	}
 	mac.Write(body)
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
 
@@ -332,6 +332,30 @@ func TestProvider_ValidateWebhook_AlternativeHeader(t *testing.T) {
 	}
 }
 
+func TestProvider_ValidateWebhook_TimestampedSignature(t *testing.T) {
+	secret := "test-webhook-secret"
+	provider := New(&Config{APIKey: "test-api-key", WebhookSecret: secret}, zap.NewNop())
+
+	payload := `{"call_id":"test-123","status":"completed"}`
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("1700000000." + payload))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook/bland", bytes.NewReader([]byte(payload)))
+	req.Header.Set("X-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Webhook-Timestamp", "1700000000")
+	if !provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should accept a signature over the timestamp and body")
+	}
+
+	// The timestamp is covered by the signature
+	req = httptest.NewRequest(http.MethodPost, "/webhook/bland", bytes.NewReader([]byte(payload)))
+	req.Header.Set("X-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-Webhook-Timestamp", "1700000001")
+	if provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should reject a changed timestamp")
+	}
+}
+
 func TestProvider_New_DefaultAPIURL(t *testing.T) {
 	logger := zap.NewNop()
 	cfg := &Config{
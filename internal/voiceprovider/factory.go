@@ -11,6 +11,9 @@ import (
 type Registry struct {
 	providers map[ProviderType]Provider
 	primary   ProviderType
+	fallbacks []ProviderType
+	numbers   map[string]string
+	circuits  map[ProviderType]CircuitReporter
 	mu        sync.RWMutex
 	logger    *zap.Logger
 }
@@ -19,6 +22,7 @@ type Registry struct {
 func NewRegistry(logger *zap.Logger) *Registry {
 	return &Registry{
 		providers: make(map[ProviderType]Provider),
+		circuits:  make(map[ProviderType]CircuitReporter),
 		logger:    logger,
 	}
 }
@@ -33,6 +37,28 @@ func (r *Registry) Register(provider Provider) {
 	r.logger.Info("registered voice provider", zap.String("provider", string(name)))
 }
 
+// Replace swaps in the providers, primary and failover settings of another
+// registry, so providers can be enabled or disabled while holders of r keep
+// using it. Circuits set on r are kept.
+func (r *Registry) Replace(other *Registry) {
+	other.mu.RLock()
+	providers := make(map[ProviderType]Provider, len(other.providers))
+	for name, provider := range other.providers {
+		providers[name] = provider
+	}
+	primary := other.primary
+	fallbacks := append([]ProviderType(nil), other.fallbacks...)
+	numbers := other.numbers
+	other.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers = providers
+	r.primary = primary
+	r.fallbacks = fallbacks
+	r.numbers = numbers
+}
+
 // SetPrimary sets the primary provider for outbound operations.
 func (r *Registry) SetPrimary(providerType ProviderType) error {
 	r.mu.Lock()
@@ -47,6 +73,95 @@ func (r *Registry) SetPrimary(providerType ProviderType) error {
 	return nil
 }
 
+// SetFallbacks sets the providers outbound calls fail over to, in the order
+// they are tried. Providers that aren't registered are skipped.
+func (r *Registry) SetFallbacks(providerTypes []ProviderType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallbacks = append([]ProviderType(nil), providerTypes...)
+	if len(providerTypes) > 0 {
+		r.logger.Info("set fallback voice providers", zap.Any("providers", providerTypes))
+	}
+}
+
+// SetNumberMap sets the number each caller ID is replaced with when a call
+// fails over to another provider, since a number is owned by one provider.
+func (r *Registry) SetNumberMap(numbers map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.numbers = numbers
+}
+
+// MapNumber returns the number to call from in place of from when a call
+// fails over, or "" to use the fallback provider's own number.
+func (r *Registry) MapNumber(from string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.numbers[from]
+}
+
+// SetCircuit reports a provider's circuit through c, for providers whose
+// API client is held outside the registry.
+func (r *Registry) SetCircuit(providerType ProviderType, c CircuitReporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.circuits[providerType] = c
+}
+
+// IsCircuitOpen reports whether calls to the provider are failing fast.
+func (r *Registry) IsCircuitOpen(providerType ProviderType) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.circuitOpen(providerType, r.providers[providerType])
+}
+
+func (r *Registry) circuitOpen(providerType ProviderType, provider Provider) bool {
+	if c, ok := r.circuits[providerType]; ok {
+		return c.IsCircuitOpen()
+	}
+	if c, ok := provider.(CircuitReporter); ok {
+		return c.IsCircuitOpen()
+	}
+	return false
+}
+
+// OutboundProviders returns the providers to try an outbound call with: the
+// primary, then the fallbacks in order. Providers whose circuit is open are
+// moved to the end, so calls go to a healthy provider first but are still
+// attempted when none is.
+func (r *Registry) OutboundProviders() []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order := make([]ProviderType, 0, len(r.fallbacks)+1)
+	if r.primary != "" {
+		order = append(order, r.primary)
+	} else {
+		for name := range r.providers {
+			order = append(order, name)
+			break
+		}
+	}
+	order = append(order, r.fallbacks...)
+
+	seen := make(map[ProviderType]bool, len(order))
+	var healthy, open []Provider
+	for _, name := range order {
+		provider, ok := r.providers[name]
+		if !ok || seen[name] || !CapabilitiesOf(provider).Outbound {
+			continue
+		}
+		seen[name] = true
+		if r.circuitOpen(name, provider) {
+			open = append(open, provider)
+		} else {
+			healthy = append(healthy, provider)
+		}
+	}
+	return append(healthy, open...)
+}
+
 // Get retrieves a provider by type.
 func (r *Registry) Get(providerType ProviderType) (Provider, error) {
 	r.mu.RLock()
@@ -147,10 +262,11 @@ func (r *Registry) IsEmpty() bool {
 
 // ProviderStatus represents the health status of a voice provider.
 type ProviderStatus struct {
-	Name      ProviderType `json:"name"`
-	Available bool         `json:"available"`
-	IsPrimary bool         `json:"is_primary"`
-	Message   string       `json:"message,omitempty"`
+	Name         ProviderType `json:"name"`
+	Available    bool         `json:"available"`
+	IsPrimary    bool         `json:"is_primary"`
+	Message      string       `json:"message,omitempty"`
+	Capabilities Capabilities `json:"capabilities"`
 }
 
 // HealthStatus returns the health status of all registered providers.
@@ -161,10 +277,15 @@ func (r *Registry) HealthStatus() []ProviderStatus {
 	statuses := make([]ProviderStatus, 0, len(r.providers))
 	for providerType, provider := range r.providers {
 		status := ProviderStatus{
-			Name:      providerType,
-			Available: true, // Provider is available if registered
-			IsPrimary: providerType == r.primary,
-			Message:   fmt.Sprintf("webhook: %s", provider.GetWebhookPath()),
+			Name:         providerType,
+			Available:    true, // Provider is available if registered
+			IsPrimary:    providerType == r.primary,
+			Message:      fmt.Sprintf("webhook: %s", provider.GetWebhookPath()),
+			Capabilities: CapabilitiesOf(provider),
+		}
+		if r.circuitOpen(providerType, provider) {
+			status.Available = false
+			status.Message = "circuit breaker open"
 		}
 		statuses = append(statuses, status)
 	}
@@ -125,6 +125,30 @@ func TestRegistry_SetPrimary(t *testing.T) {
 	}
 }
 
+func TestRegistry_Replace(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	registry.Register(newMockProvider(ProviderBland, "/webhook/bland"))
+	_ = registry.SetPrimary(ProviderBland)
+
+	next := NewRegistry(zap.NewNop())
+	next.Register(newMockProvider(ProviderVapi, "/webhook/vapi"))
+	_ = next.SetPrimary(ProviderVapi)
+	registry.Replace(next)
+
+	if registry.HasProvider(ProviderBland) || !registry.HasProvider(ProviderVapi) {
+		t.Errorf("providers = %v, expected only vapi", registry.List())
+	}
+	if registry.PrimaryProviderName() != ProviderVapi {
+		t.Errorf("primary = %q, expected %q", registry.PrimaryProviderName(), ProviderVapi)
+	}
+
+	// Later changes to the other registry don't leak in
+	next.Register(newMockProvider(ProviderRetell, "/webhook/retell"))
+	if registry.HasProvider(ProviderRetell) {
+		t.Error("provider registered after Replace appeared in the registry")
+	}
+}
+
 func TestRegistry_SetPrimary_NotRegistered(t *testing.T) {
 	logger := zap.NewNop()
 	registry := NewRegistry(logger)
@@ -251,3 +275,76 @@ func TestRegistry_IsEmpty(t *testing.T) {
 		t.Error("expected IsEmpty() to return false after registering provider")
 	}
 }
+
+// fakeCircuit reports a circuit that is open while open is true.
+type fakeCircuit struct {
+	open bool
+}
+
+func (c *fakeCircuit) IsCircuitOpen() bool {
+	return c.open
+}
+
+func TestRegistry_OutboundProviders(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	registry.Register(&batchProvider{newMockProvider(ProviderBland, "/webhook/bland")})
+	registry.Register(&batchProvider{newMockProvider(ProviderRetell, "/webhook/retell")})
+	registry.Register(&batchProvider{newMockProvider(ProviderVapi, "/webhook/vapi")})
+	registry.Register(newMockProvider(ProviderTwilio, "/webhook/twilio"))
+	if err := registry.SetPrimary(ProviderBland); err != nil {
+		t.Fatalf("SetPrimary() error = %v", err)
+	}
+	// Twilio can't place calls and LiveKit isn't registered, so both are skipped
+	registry.SetFallbacks([]ProviderType{ProviderTwilio, ProviderLiveKit, ProviderRetell, ProviderBland, ProviderVapi})
+
+	names := func() []ProviderType {
+		var names []ProviderType
+		for _, p := range registry.OutboundProviders() {
+			names = append(names, p.GetName())
+		}
+		return names
+	}
+	if got := names(); len(got) != 3 || got[0] != ProviderBland || got[1] != ProviderRetell || got[2] != ProviderVapi {
+		t.Errorf("OutboundProviders() = %v, expected [bland retell vapi]", got)
+	}
+
+	// An open circuit moves the primary to the end
+	circuit := &fakeCircuit{open: true}
+	registry.SetCircuit(ProviderBland, circuit)
+	if got := names(); got[0] != ProviderRetell || got[2] != ProviderBland {
+		t.Errorf("OutboundProviders() = %v, expected bland last", got)
+	}
+	for _, status := range registry.HealthStatus() {
+		if status.Name == ProviderBland && status.Available {
+			t.Error("expected bland to be unavailable while its circuit is open")
+		}
+	}
+
+	// Circuits survive a reload
+	reloaded := NewRegistry(zap.NewNop())
+	reloaded.Register(&batchProvider{newMockProvider(ProviderBland, "/webhook/bland")})
+	reloaded.Register(&batchProvider{newMockProvider(ProviderRetell, "/webhook/retell")})
+	reloaded.SetPrimary(ProviderBland)
+	reloaded.SetFallbacks([]ProviderType{ProviderRetell})
+	registry.Replace(reloaded)
+	if got := names(); len(got) != 2 || got[0] != ProviderRetell {
+		t.Errorf("OutboundProviders() after reload = %v, expected [retell bland]", got)
+	}
+
+	circuit.open = false
+	if got := names(); got[0] != ProviderBland {
+		t.Errorf("OutboundProviders() = %v, expected bland first once its circuit closes", got)
+	}
+}
+
+func TestRegistry_MapNumber(t *testing.T) {
+	registry := NewRegistry(zap.NewNop())
+	registry.SetNumberMap(map[string]string{"+15550001111": "+15550009999"})
+
+	if got := registry.MapNumber("+15550001111"); got != "+15550009999" {
+		t.Errorf("MapNumber() = %q, expected the mapped number", got)
+	}
+	if got := registry.MapNumber("+15550002222"); got != "" {
+		t.Errorf("MapNumber() = %q, expected no number for an unmapped caller ID", got)
+	}
+}
@@ -0,0 +1,499 @@
+// Package fake implements a simulated voice provider for development and
+// demos. Its calls are never dialed: the provider fabricates the webhooks a
+// real provider would send, with a scripted transcript and a synthetic
+// recording, so the call → transcript → quote pipeline runs end to end
+// without provider costs.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+const (
+	// WebhookPath is where the provider posts the webhooks it fabricates.
+	WebhookPath = "/webhook/fake"
+
+	// RecordingPath is where the synthetic recordings are served, followed
+	// by "<call ID>.wav".
+	RecordingPath = "/webhook/fake/recordings/"
+
+	// SignatureHeader carries the hex HMAC-SHA256 of the body when a
+	// webhook secret is configured.
+	SignatureHeader = "X-Fake-Signature"
+
+	// NoAnswerSuffix marks numbers whose calls go unanswered.
+	NoAnswerSuffix = "0000"
+)
+
+// Webhook events, in the order they are sent.
+const (
+	EventCallStarted = "call_started"
+	EventCallEnded   = "call_ended"
+)
+
+// Config holds simulated provider configuration.
+type Config struct {
+	WebhookURL      string        // Base URL of this app, that webhooks are posted to
+	WebhookSecret   string        // Signs webhooks when set
+	FromNumber      string        // Number simulated calls are placed from and inbound calls reach
+	RingDelay       time.Duration // Time from placing a call to it being answered
+	CallDuration    time.Duration // Time from answering a call to it ending
+	InboundInterval time.Duration // How often a simulated customer calls in; 0 disables inbound calls
+}
+
+// Provider implements the voiceprovider.Provider interface with simulated
+// calls.
+type Provider struct {
+	config *Config
+	client *http.Client
+	logger *zap.Logger
+
+	mu    sync.Mutex
+	calls map[string]*voiceprovider.CallEvent // Latest state of each call
+	seq   int
+
+	// Lifecycle
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates a new simulated provider.
+func New(cfg *Config, logger *zap.Logger) *Provider {
+	if cfg.FromNumber == "" {
+		cfg.FromNumber = "+15550100000"
+	}
+	if cfg.RingDelay <= 0 {
+		cfg.RingDelay = 2 * time.Second
+	}
+	if cfg.CallDuration <= 0 {
+		cfg.CallDuration = 20 * time.Second
+	}
+	cfg.WebhookURL = strings.TrimSuffix(cfg.WebhookURL, "/")
+	return &Provider{
+		config: cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+		logger: logger,
+		calls:  make(map[string]*voiceprovider.CallEvent),
+		stopCh: make(chan struct{}),
+	}
+}
+
+// GetName returns the provider type identifier.
+func (p *Provider) GetName() voiceprovider.ProviderType {
+	return voiceprovider.ProviderFake
+}
+
+// GetWebhookPath returns the path for simulated webhooks.
+func (p *Provider) GetWebhookPath() string {
+	return WebhookPath
+}
+
+// SupportsBatch reports that batches can't be placed through the simulator.
+func (p *Provider) SupportsBatch() bool {
+	return false
+}
+
+// SupportsSMS reports that texts can't be sent through the simulator.
+func (p *Provider) SupportsSMS() bool {
+	return false
+}
+
+// Start begins simulating inbound calls, if configured.
+func (p *Provider) Start(ctx context.Context) error {
+	if p.config.InboundInterval <= 0 {
+		return nil
+	}
+	p.logger.Info("simulating inbound calls", zap.Duration("interval", p.config.InboundInterval))
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.config.InboundInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stopCh:
+				return
+			case <-ticker.C:
+				p.SimulateInboundCall()
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop ends the calls in progress without sending their remaining webhooks.
+func (p *Provider) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// InitiateCall places a simulated call. It is answered after the ring
+// delay, unless the number ends in NoAnswerSuffix, and ends after the call
+// duration with a scripted conversation.
+func (p *Provider) InitiateCall(ctx context.Context, req voiceprovider.OutboundCallRequest) (*voiceprovider.OutboundCallResponse, error) {
+	if req.ToNumber == "" {
+		return nil, fmt.Errorf("fake: a to number is required")
+	}
+	from := req.FromNumber
+	if from == "" {
+		from = p.config.FromNumber
+	}
+
+	call := &simulatedCall{
+		WebhookCall: WebhookCall{
+			CallID:     "fake-" + uuid.NewString(),
+			Direction:  "outbound",
+			FromNumber: from,
+			ToNumber:   req.ToNumber,
+			Metadata:   req.Metadata,
+		},
+		callerName: req.Variables["name"],
+		noAnswer:   strings.HasSuffix(req.ToNumber, NoAnswerSuffix),
+	}
+	p.simulate(call)
+
+	return &voiceprovider.OutboundCallResponse{
+		ProviderCallID: call.CallID,
+		Status:         "queued",
+	}, nil
+}
+
+// SimulateInboundCall starts a simulated call from a customer to the
+// provider's number and returns its provider call ID.
+func (p *Provider) SimulateInboundCall() string {
+	call := &simulatedCall{
+		WebhookCall: WebhookCall{
+			CallID:     "fake-" + uuid.NewString(),
+			Direction:  "inbound",
+			FromNumber: fmt.Sprintf("+1555010%04d", rand.Intn(10000)),
+			ToNumber:   p.config.FromNumber,
+		},
+	}
+	p.simulate(call)
+	return call.CallID
+}
+
+// GetCallStatus returns the latest state of a simulated call.
+func (p *Provider) GetCallStatus(ctx context.Context, providerCallID string) (*voiceprovider.CallEvent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	event, ok := p.calls[providerCallID]
+	if !ok {
+		return nil, fmt.Errorf("fake: call %s not found", providerCallID)
+	}
+	cp := *event
+	return &cp, nil
+}
+
+// simulatedCall is a call in progress.
+type simulatedCall struct {
+	WebhookCall
+	callerName string
+	noAnswer   bool
+}
+
+// simulate runs a call's lifecycle in the background.
+func (p *Provider) simulate(call *simulatedCall) {
+	p.mu.Lock()
+	p.seq++
+	script := scripts[p.seq%len(scripts)]
+	p.calls[call.CallID] = &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderFake,
+		ProviderCallID: call.CallID,
+		ToNumber:       call.ToNumber,
+		FromNumber:     call.FromNumber,
+		Status:         voiceprovider.CallStatusPending,
+	}
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if !p.wait(p.config.RingDelay) {
+			return
+		}
+
+		if call.noAnswer {
+			ended := time.Now()
+			call.Status = string(voiceprovider.CallStatusNoAnswer)
+			call.EndedAt = &ended
+			p.send(EventCallEnded, call.WebhookCall)
+			return
+		}
+
+		started := time.Now()
+		call.Status = string(voiceprovider.CallStatusInProgress)
+		call.StartedAt = &started
+		p.send(EventCallStarted, call.WebhookCall)
+
+		if !p.wait(p.config.CallDuration) {
+			return
+		}
+
+		ended := time.Now()
+		call.Status = string(voiceprovider.CallStatusCompleted)
+		call.EndedAt = &ended
+		call.DurationSecs = int(ended.Sub(started).Seconds())
+		call.Transcript, call.ExtractedData, call.Summary = script.play(call.callerName)
+		call.CallerName = call.ExtractedData.Name
+		call.RecordingURL = p.config.WebhookURL + RecordingPath + call.CallID + ".wav"
+		p.send(EventCallEnded, call.WebhookCall)
+	}()
+}
+
+// wait waits for d, reporting false if the provider stopped first.
+func (p *Provider) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-p.stopCh:
+		return false
+	}
+}
+
+// send posts a webhook for the call to the app, as a provider would.
+func (p *Provider) send(event string, call WebhookCall) {
+	body, err := json.Marshal(WebhookPayload{Event: event, Call: call})
+	if err != nil {
+		p.logger.Error("failed to encode simulated webhook", zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.WebhookURL+WebhookPath, bytes.NewReader(body))
+	if err != nil {
+		p.logger.Error("failed to build simulated webhook", zap.Error(err))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.config.WebhookSecret != "" {
+		req.Header.Set(SignatureHeader, hex.EncodeToString(sign(p.config.WebhookSecret, body)))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.logger.Warn("failed to send simulated webhook",
+			zap.String("call_id", call.CallID),
+			zap.String("event", event),
+			zap.Error(err),
+		)
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		p.logger.Warn("simulated webhook was not accepted",
+			zap.String("call_id", call.CallID),
+			zap.String("event", event),
+			zap.Int("status", resp.StatusCode),
+		)
+		return
+	}
+	p.logger.Debug("sent simulated webhook",
+		zap.String("call_id", call.CallID),
+		zap.String("event", event),
+	)
+}
+
+// ValidateWebhook verifies the signature of a simulated webhook. Without a
+// webhook secret every webhook is accepted.
+func (p *Provider) ValidateWebhook(r *http.Request) bool {
+	if p.config.WebhookSecret == "" {
+		return true
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		p.logger.Error("failed to read webhook body for validation", zap.Error(err))
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	signature, err := hex.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, sign(p.config.WebhookSecret, body)) {
+		p.logger.Warn("invalid simulated webhook signature", zap.String("remote_addr", r.RemoteAddr))
+		return false
+	}
+	return true
+}
+
+// sign returns the HMAC-SHA256 of body keyed with secret.
+func sign(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// WebhookPayload is a webhook fabricated by the provider.
+type WebhookPayload struct {
+	Event string      `json:"event"`
+	Call  WebhookCall `json:"call"`
+}
+
+// WebhookCall is the call a webhook reports on.
+type WebhookCall struct {
+	CallID        string                          `json:"call_id"`
+	Direction     string                          `json:"direction"` // "inbound" or "outbound"
+	FromNumber    string                          `json:"from_number"`
+	ToNumber      string                          `json:"to_number"`
+	Status        string                          `json:"status"`
+	CallerName    string                          `json:"caller_name,omitempty"`
+	StartedAt     *time.Time                      `json:"started_at,omitempty"`
+	EndedAt       *time.Time                      `json:"ended_at,omitempty"`
+	DurationSecs  int                             `json:"duration_secs,omitempty"`
+	Transcript    []voiceprovider.TranscriptEntry `json:"transcript,omitempty"`
+	ExtractedData *voiceprovider.ExtractedData    `json:"extracted_data,omitempty"`
+	Summary       string                          `json:"summary,omitempty"`
+	RecordingURL  string                          `json:"recording_url,omitempty"`
+	Metadata      map[string]interface{}          `json:"metadata,omitempty"`
+}
+
+// ParseWebhook parses a simulated webhook into a normalized CallEvent.
+func (p *Provider) ParseWebhook(r *http.Request) (*voiceprovider.CallEvent, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	defer r.Body.Close()
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+	call := payload.Call
+	if call.CallID == "" {
+		return nil, errors.New("missing call_id in webhook")
+	}
+
+	event := &voiceprovider.CallEvent{
+		Provider:          voiceprovider.ProviderFake,
+		ProviderCallID:    call.CallID,
+		ToNumber:          call.ToNumber,
+		FromNumber:        call.FromNumber,
+		CallerName:        call.CallerName,
+		Status:            voiceprovider.CallStatus(call.Status),
+		StartedAt:         call.StartedAt,
+		EndedAt:           call.EndedAt,
+		DurationSecs:      call.DurationSecs,
+		TranscriptEntries: call.Transcript,
+		ExtractedData:     call.ExtractedData,
+		RecordingURL:      call.RecordingURL,
+		Summary:           call.Summary,
+	}
+	if event.Status == "" {
+		event.Status = voiceprovider.CallStatusPending
+	}
+	lines := make([]string, len(call.Transcript))
+	for i, entry := range call.Transcript {
+		lines[i] = entry.Role + ": " + entry.Content
+	}
+	event.Transcript = strings.Join(lines, "\n")
+
+	// Keep the raw payload, which marks the calls we placed as outbound
+	var rawMetadata map[string]interface{}
+	if err := json.Unmarshal(body, &rawMetadata); err == nil {
+		event.RawMetadata = rawMetadata
+	}
+
+	p.mu.Lock()
+	cp := *event
+	p.calls[call.CallID] = &cp
+	p.mu.Unlock()
+
+	return event, nil
+}
+
+// Recording format: 8 kHz 16-bit mono PCM, at most maxRecordingSecs long.
+const (
+	recordingSampleRate = 8000
+	maxRecordingSecs    = 30
+)
+
+// ServeRecording serves the synthetic recording of a call: a quiet tone as
+// long as the call, up to 30 seconds.
+func (p *Provider) ServeRecording(w http.ResponseWriter, r *http.Request) {
+	callID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, RecordingPath), ".wav")
+	if callID == "" || strings.Contains(callID, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	secs := 10
+	p.mu.Lock()
+	if event, ok := p.calls[callID]; ok && event.DurationSecs > 0 {
+		secs = event.DurationSecs
+	}
+	p.mu.Unlock()
+	if secs > maxRecordingSecs {
+		secs = maxRecordingSecs
+	}
+
+	w.Header().Set("Content-Type", "audio/wav")
+	if _, err := w.Write(toneWAV(secs)); err != nil {
+		p.logger.Debug("failed to write simulated recording", zap.Error(err))
+	}
+}
+
+// toneWAV returns a WAV file of a quiet 440 Hz tone lasting secs seconds.
+func toneWAV(secs int) []byte {
+	samples := secs * recordingSampleRate
+	dataSize := samples * 2
+
+	var buf bytes.Buffer
+	buf.Grow(44 + dataSize)
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(16))                    // fmt chunk size
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1))                     // PCM
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1))                     // mono
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(recordingSampleRate))   // sample rate
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(recordingSampleRate*2)) // byte rate
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(2))                     // block align
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(16))                    // bits per sample
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+
+	for i := 0; i < samples; i++ {
+		sample := int16(1000 * math.Sin(2*math.Pi*440*float64(i)/recordingSampleRate))
+		_ = binary.Write(&buf, binary.LittleEndian, sample)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,167 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// newTestProvider returns a provider posting its webhooks to a test server,
+// and the events that server parsed from them, in order.
+func newTestProvider(t *testing.T, secret string) (*Provider, <-chan *voiceprovider.CallEvent) {
+	t.Helper()
+	events := make(chan *voiceprovider.CallEvent, 10)
+
+	var provider *Provider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != WebhookPath || !provider.ValidateWebhook(r) {
+			http.Error(w, "rejected", http.StatusUnauthorized)
+			return
+		}
+		event, err := provider.ParseWebhook(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		events <- event
+	}))
+	t.Cleanup(server.Close)
+
+	provider = New(&Config{
+		WebhookURL:    server.URL,
+		WebhookSecret: secret,
+		RingDelay:     10 * time.Millisecond,
+		CallDuration:  10 * time.Millisecond,
+	}, zap.NewNop())
+	t.Cleanup(func() { _ = provider.Stop(context.Background()) })
+	return provider, events
+}
+
+func nextEvent(t *testing.T, events <-chan *voiceprovider.CallEvent) *voiceprovider.CallEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a simulated webhook")
+		return nil
+	}
+}
+
+func TestProvider_InitiateCall(t *testing.T) {
+	provider, events := newTestProvider(t, "test-secret")
+
+	resp, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{
+		ToNumber:  "+15551234567",
+		Variables: map[string]string{"name": "Alex Kim"},
+		Metadata:  map[string]interface{}{"campaign_contact_id": "contact-1"},
+	})
+	if err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+
+	started := nextEvent(t, events)
+	if started.ProviderCallID != resp.ProviderCallID || started.Status != voiceprovider.CallStatusInProgress {
+		t.Errorf("expected %s in progress first, got %s %s", resp.ProviderCallID, started.ProviderCallID, started.Status)
+	}
+
+	ended := nextEvent(t, events)
+	if ended.Status != voiceprovider.CallStatusCompleted {
+		t.Fatalf("Status = %q, expected completed", ended.Status)
+	}
+	if ended.ExtractedData == nil || ended.ExtractedData.Name != "Alex Kim" || ended.ExtractedData.ProjectType == "" {
+		t.Errorf("expected a project extracted for Alex Kim, got %+v", ended.ExtractedData)
+	}
+	if !strings.Contains(ended.Transcript, "user: ") || !strings.Contains(ended.Transcript, "Alex Kim") {
+		t.Errorf("expected a transcript with the customer, got %q", ended.Transcript)
+	}
+	if !strings.HasSuffix(ended.RecordingURL, RecordingPath+resp.ProviderCallID+".wav") {
+		t.Errorf("RecordingURL = %q", ended.RecordingURL)
+	}
+	call, _ := ended.RawMetadata["call"].(map[string]interface{})
+	if call["direction"] != "outbound" {
+		t.Errorf("expected the call marked outbound, got %v", call["direction"])
+	}
+
+	status, err := provider.GetCallStatus(context.Background(), resp.ProviderCallID)
+	if err != nil || status.Status != voiceprovider.CallStatusCompleted {
+		t.Errorf("GetCallStatus() = %+v, %v", status, err)
+	}
+}
+
+func TestProvider_InitiateCall_NoAnswer(t *testing.T) {
+	provider, events := newTestProvider(t, "")
+
+	if _, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{ToNumber: "+1555123" + NoAnswerSuffix}); err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+
+	event := nextEvent(t, events)
+	if event.Status != voiceprovider.CallStatusNoAnswer || event.Transcript != "" {
+		t.Errorf("expected an unanswered call without a transcript, got %s %q", event.Status, event.Transcript)
+	}
+}
+
+func TestProvider_SimulateInboundCall(t *testing.T) {
+	provider, events := newTestProvider(t, "")
+
+	callID := provider.SimulateInboundCall()
+	nextEvent(t, events)
+	ended := nextEvent(t, events)
+
+	if ended.ProviderCallID != callID || ended.ToNumber != provider.config.FromNumber {
+		t.Errorf("expected %s to %s, got %s to %s", callID, provider.config.FromNumber, ended.ProviderCallID, ended.ToNumber)
+	}
+	if ended.ExtractedData == nil || ended.ExtractedData.Requirements == "" {
+		t.Error("expected the scripted customer's requirements")
+	}
+}
+
+func TestProvider_ValidateWebhook(t *testing.T) {
+	provider := New(&Config{WebhookSecret: "test-secret"}, zap.NewNop())
+	body := []byte(`{"event":"call_ended","call":{"call_id":"fake-1"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, WebhookPath, bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, "00")
+	if provider.ValidateWebhook(req) {
+		t.Error("expected a wrong signature rejected")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, WebhookPath, bytes.NewReader(body))
+	req.Header.Set(SignatureHeader, hexSignature("test-secret", body))
+	if !provider.ValidateWebhook(req) {
+		t.Error("expected a signed webhook accepted")
+	}
+	if event, err := provider.ParseWebhook(req); err != nil || event.ProviderCallID != "fake-1" {
+		t.Errorf("expected the body readable after validation, got %+v, %v", event, err)
+	}
+}
+
+func TestProvider_ServeRecording(t *testing.T) {
+	provider := New(&Config{}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	provider.ServeRecording(rec, httptest.NewRequest(http.MethodGet, RecordingPath+"fake-1.wav", nil))
+
+	wav := rec.Body.Bytes()
+	if rec.Code != http.StatusOK || len(wav) < 44 || string(wav[:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		t.Fatalf("expected a WAV file, got %d with %d bytes", rec.Code, len(wav))
+	}
+	if dataSize := binary.LittleEndian.Uint32(wav[40:44]); int(dataSize) != len(wav)-44 {
+		t.Errorf("data size = %d, expected %d", dataSize, len(wav)-44)
+	}
+}
+
+func hexSignature(secret string, body []byte) string {
+	return hex.EncodeToString(sign(secret, body))
+}
@@ -0,0 +1,116 @@
+package fake
+
+import (
+	"strings"
+
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// script is a scripted conversation with a customer about a software
+// project. "{name}" in a line is replaced with the customer's name.
+type script struct {
+	name         string
+	company      string
+	projectType  string
+	requirements string
+	timeline     string
+	budget       string
+	lines        []string // Alternating agent and customer lines, agent first
+}
+
+var scripts = []script{
+	{
+		name:         "Dana Whitfield",
+		company:      "Whitfield Bakery",
+		projectType:  "web_app",
+		requirements: "Online ordering site with a product catalog, Stripe checkout, pickup scheduling and an admin page for daily orders",
+		timeline:     "Live in 3 months",
+		budget:       "$15,000 - $25,000",
+		lines: []string{
+			"Thanks for calling. I can help put together a quote for your software project. Who am I speaking with?",
+			"Hi, this is {name} from Whitfield Bakery.",
+			"Nice to meet you, {name}. What would you like to build?",
+			"We want customers to order cakes and bread online and pick them up in the shop.",
+			"Got it, a web ordering site. What features matter most?",
+			"A catalog with photos, card payments through Stripe, choosing a pickup time, and a page where we see the day's orders.",
+			"When do you need it live, and do you have a budget in mind?",
+			"Ideally within three months, and we were thinking somewhere between fifteen and twenty-five thousand dollars.",
+			"Thanks, {name}. I have everything I need and we'll send your quote shortly.",
+		},
+	},
+	{
+		name:         "Marcus Lee",
+		company:      "FleetTrack Logistics",
+		projectType:  "mobile_app",
+		requirements: "iOS and Android driver app with route lists, proof-of-delivery photos, offline mode and sync to the existing dispatch API",
+		timeline:     "First version in 4 months",
+		budget:       "$40,000 - $60,000",
+		lines: []string{
+			"Thanks for calling. I can help put together a quote for your software project. Who am I speaking with?",
+			"This is {name}, I run operations at FleetTrack Logistics.",
+			"Thanks, {name}. What are you looking to build?",
+			"A mobile app for our drivers, on both iPhone and Android.",
+			"What should drivers be able to do in the app?",
+			"See their route for the day, take a photo as proof of delivery, and keep working when they lose signal. It has to sync with our dispatch API.",
+			"Understood. What timeline and budget are you working with?",
+			"We'd like a first version in about four months, with a budget of forty to sixty thousand.",
+			"Perfect, {name}. We'll prepare a quote and get it over to you.",
+		},
+	},
+	{
+		name:         "Priya Raman",
+		company:      "Northside Clinics",
+		projectType:  "integration",
+		requirements: "Sync appointments between the scheduling system and the billing platform through their REST APIs, with error alerts and a nightly reconciliation report",
+		timeline:     "Within 6 weeks",
+		budget:       "Around $10,000",
+		lines: []string{
+			"Thanks for calling. I can help put together a quote for your software project. Who am I speaking with?",
+			"Hi, I'm {name}, IT lead at Northside Clinics.",
+			"Hi {name}. What can we help you with?",
+			"Our scheduling system and our billing platform don't talk to each other, so staff retype every appointment.",
+			"So an integration between the two. Do both have APIs?",
+			"Yes, both have REST APIs. We'd also want alerts when a sync fails and a nightly report showing anything that doesn't match.",
+			"When do you need it, and is there a budget?",
+			"Within six weeks if possible, and around ten thousand dollars.",
+			"Thank you, {name}. Your quote will be on its way soon.",
+		},
+	},
+}
+
+// play returns the script's conversation with the customer called name, or
+// the script's own customer if name is empty, and what the agent gathered.
+func (s script) play(name string) ([]voiceprovider.TranscriptEntry, *voiceprovider.ExtractedData, string) {
+	if name == "" {
+		name = s.name
+	}
+
+	entries := make([]voiceprovider.TranscriptEntry, len(s.lines))
+	for i, line := range s.lines {
+		role := "assistant"
+		if i%2 == 1 {
+			role = "user"
+		}
+		start, end := float64(i*6), float64((i+1)*6)
+		entries[i] = voiceprovider.TranscriptEntry{
+			Role:      role,
+			Content:   strings.ReplaceAll(line, "{name}", name),
+			Timestamp: start,
+			StartTime: &start,
+			EndTime:   &end,
+		}
+	}
+
+	data := &voiceprovider.ExtractedData{
+		Name:         name,
+		CallerName:   name,
+		Company:      s.company,
+		ProjectType:  s.projectType,
+		Requirements: s.requirements,
+		Timeline:     s.timeline,
+		Budget:       s.budget,
+		BudgetRange:  s.budget,
+	}
+	summary := name + " from " + s.company + " asked for a quote: " + s.requirements + "."
+	return entries, data, summary
+}
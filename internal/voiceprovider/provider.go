@@ -17,8 +17,10 @@ const (
 	ProviderBland    ProviderType = "bland"
 	ProviderVapi     ProviderType = "vapi"
 	ProviderRetell   ProviderType = "retell"
+	ProviderTwilio   ProviderType = "twilio"
 	ProviderLiveKit  ProviderType = "livekit"
 	ProviderCustom   ProviderType = "custom"
+	ProviderFake     ProviderType = "fake"
 )
 
 // CallStatus represents the normalized status of a call across all providers.
@@ -163,6 +165,7 @@ type OutboundCallRequest struct {
 	Voice        string                 `json:"voice,omitempty"`       // Voice ID to use
 	FirstMessage string                 `json:"first_message,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Variables    map[string]string      `json:"variables,omitempty"` // Filled into the agent's prompt
 }
 
 // OutboundCallResponse contains the result of initiating an outbound call.
@@ -172,6 +175,85 @@ type OutboundCallResponse struct {
 	Message        string `json:"message,omitempty"`
 }
 
+// BatchProvider extends OutboundProvider with placing many calls in one
+// request. The provider dials them on its own schedule.
+type BatchProvider interface {
+	OutboundProvider
+
+	// InitiateBatch starts outbound calls to every number in the batch.
+	InitiateBatch(ctx context.Context, req BatchCallRequest) (*BatchCallResponse, error)
+}
+
+// BatchCallRequest contains the calls of a batch. FromNumber applies to
+// calls that don't set their own.
+type BatchCallRequest struct {
+	Name       string                `json:"name,omitempty"`
+	FromNumber string                `json:"from_number,omitempty"`
+	Calls      []OutboundCallRequest `json:"calls"`
+}
+
+// BatchCallResponse contains the result of initiating a batch. Calls holds
+// the calls started, for providers that start them immediately; providers
+// that queue the batch report its ID and size only.
+type BatchCallResponse struct {
+	BatchID string                 `json:"batch_id,omitempty"`
+	Queued  int                    `json:"queued"`
+	Calls   []OutboundCallResponse `json:"calls,omitempty"`
+}
+
+// CapabilityProvider is implemented by providers that report which of
+// QuickQuote's outbound features work with them.
+type CapabilityProvider interface {
+	Provider
+
+	// SupportsBatch reports whether batches of outbound calls can be placed
+	// through the provider.
+	SupportsBatch() bool
+
+	// SupportsSMS reports whether texts can be sent through the provider.
+	SupportsSMS() bool
+}
+
+// CircuitReporter is implemented by providers, and the API clients behind
+// them, that guard their API with a circuit breaker.
+type CircuitReporter interface {
+	// IsCircuitOpen reports whether calls to the API are failing fast.
+	IsCircuitOpen() bool
+}
+
+// Capabilities lists the optional features a provider supports.
+type Capabilities struct {
+	Outbound bool `json:"outbound"`
+	Batch    bool `json:"batch"`
+	SMS      bool `json:"sms"`
+}
+
+// CapabilitiesOf discovers the optional features of a provider. Outbound
+// calls are supported by providers that implement OutboundProvider or that
+// place batches; providers that don't report their capabilities support
+// nothing else.
+func CapabilitiesOf(p Provider) Capabilities {
+	var caps Capabilities
+	_, caps.Outbound = p.(OutboundProvider)
+	if c, ok := p.(CapabilityProvider); ok {
+		caps.Batch = c.SupportsBatch()
+		caps.SMS = c.SupportsSMS()
+	}
+	caps.Outbound = caps.Outbound || caps.Batch
+	return caps
+}
+
+// SupportsBatch reports whether batches of outbound calls can be placed
+// through the provider.
+func SupportsBatch(p Provider) bool {
+	return CapabilitiesOf(p).Batch
+}
+
+// SupportsSMS reports whether texts can be sent through the provider.
+func SupportsSMS(p Provider) bool {
+	return CapabilitiesOf(p).SMS
+}
+
 // ConfigurableProvider extends Provider with runtime configuration capabilities.
 type ConfigurableProvider interface {
 	Provider
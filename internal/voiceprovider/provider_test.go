@@ -1,6 +1,7 @@
 package voiceprovider
 
 import (
+	"context"
 	"testing"
 )
 
@@ -191,3 +192,37 @@ func TestCallEvent_Complete(t *testing.T) {
 		t.Errorf("TranscriptEntries len = %d, expected 2", len(event.TranscriptEntries))
 	}
 }
+
+// batchProvider is a mockProvider that places calls and batches.
+type batchProvider struct {
+	*mockProvider
+}
+
+func (b *batchProvider) InitiateCall(ctx context.Context, req OutboundCallRequest) (*OutboundCallResponse, error) {
+	return &OutboundCallResponse{ProviderCallID: "call-1"}, nil
+}
+
+func (b *batchProvider) GetCallStatus(ctx context.Context, providerCallID string) (*CallEvent, error) {
+	return nil, nil
+}
+
+func (b *batchProvider) InitiateBatch(ctx context.Context, req BatchCallRequest) (*BatchCallResponse, error) {
+	return &BatchCallResponse{Queued: len(req.Calls)}, nil
+}
+
+func (b *batchProvider) SupportsBatch() bool { return true }
+func (b *batchProvider) SupportsSMS() bool   { return false }
+
+func TestCapabilitiesOf(t *testing.T) {
+	if caps := CapabilitiesOf(newMockProvider(ProviderCustom, "/webhook/custom")); caps != (Capabilities{}) {
+		t.Errorf("expected no capabilities for an inbound-only provider, got %+v", caps)
+	}
+
+	p := &batchProvider{newMockProvider(ProviderVapi, "/webhook/vapi")}
+	if caps := CapabilitiesOf(p); caps != (Capabilities{Outbound: true, Batch: true}) {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+	if !SupportsBatch(p) || SupportsSMS(p) {
+		t.Error("expected batch calls but no texts")
+	}
+}
@@ -4,6 +4,7 @@ package retell
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,10 +12,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 	"github.com/jkindrix/quickquote/internal/validation"
 	"github.com/jkindrix/quickquote/internal/voiceprovider"
 )
@@ -24,12 +27,17 @@ type Config struct {
 	APIKey        string
 	WebhookSecret string
 	APIURL        string
+	FromNumber    string       // Retell number outbound calls are placed from
+	AgentID       string       // Overrides the agent bound to FromNumber (optional)
+	HTTPClient    *http.Client // Client for API calls; nil uses one with a 30 second timeout
 }
 
 // Provider implements the voiceprovider.Provider interface for Retell AI.
 type Provider struct {
-	config *Config
-	logger *zap.Logger
+	config  *Config
+	client  *http.Client
+	breaker *circuitbreaker.CircuitBreaker
+	logger  *zap.Logger
 }
 
 // New creates a new Retell AI provider.
@@ -37,9 +45,15 @@ func New(cfg *Config, logger *zap.Logger) *Provider {
 	if cfg.APIURL == "" {
 		cfg.APIURL = "https://api.retellai.com"
 	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
 	return &Provider{
-		config: cfg,
-		logger: logger,
+		config:  cfg,
+		client:  client,
+		breaker: circuitbreaker.New("retell-api", nil, logger),
+		logger:  logger,
 	}
 }
 
@@ -53,6 +67,178 @@ func (p *Provider) GetWebhookPath() string {
 	return "/webhook/retell"
 }
 
+// SupportsBatch reports that Retell places batches of outbound calls.
+func (p *Provider) SupportsBatch() bool {
+	return true
+}
+
+// SupportsSMS reports that texts can't be sent through Retell.
+func (p *Provider) SupportsSMS() bool {
+	return false
+}
+
+// InitiateCall starts an outbound call. Variables are passed to the agent
+// as dynamic variables and metadata is returned on the call's webhooks.
+func (p *Provider) InitiateCall(ctx context.Context, req voiceprovider.OutboundCallRequest) (*voiceprovider.OutboundCallResponse, error) {
+	from := p.fromNumber(req.FromNumber)
+	if from == "" {
+		return nil, fmt.Errorf("retell: a from number is required for outbound calls")
+	}
+	body := map[string]interface{}{
+		"from_number": from,
+		"to_number":   req.ToNumber,
+	}
+	if p.config.AgentID != "" {
+		body["override_agent_id"] = p.config.AgentID
+	}
+	if len(req.Metadata) > 0 {
+		body["metadata"] = req.Metadata
+	}
+	if len(req.Variables) > 0 {
+		body["retell_llm_dynamic_variables"] = req.Variables
+	}
+
+	var call RetellCall
+	if err := p.do(ctx, http.MethodPost, "/v2/create-phone-call", body, &call); err != nil {
+		return nil, err
+	}
+	return &voiceprovider.OutboundCallResponse{
+		ProviderCallID: call.CallID,
+		Status:         call.CallStatus,
+	}, nil
+}
+
+// InitiateBatch queues a batch call. Retell dials the batch itself and
+// reports each call on the webhook, so only the batch ID is returned.
+func (p *Provider) InitiateBatch(ctx context.Context, req voiceprovider.BatchCallRequest) (*voiceprovider.BatchCallResponse, error) {
+	from := p.fromNumber(req.FromNumber)
+	if from == "" {
+		return nil, fmt.Errorf("retell: a from number is required for outbound calls")
+	}
+	if len(req.Calls) == 0 {
+		return nil, fmt.Errorf("retell: batch has no calls")
+	}
+	tasks := make([]map[string]interface{}, len(req.Calls))
+	for i, c := range req.Calls {
+		task := map[string]interface{}{"to_number": c.ToNumber}
+		if len(c.Variables) > 0 {
+			task["retell_llm_dynamic_variables"] = c.Variables
+		}
+		tasks[i] = task
+	}
+	body := map[string]interface{}{
+		"from_number": from,
+		"tasks":       tasks,
+	}
+	if req.Name != "" {
+		body["name"] = req.Name
+	}
+
+	var result struct {
+		BatchCallID    string `json:"batch_call_id"`
+		TotalTaskCount int    `json:"total_task_count"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/create-batch-call", body, &result); err != nil {
+		return nil, err
+	}
+	return &voiceprovider.BatchCallResponse{
+		BatchID: result.BatchCallID,
+		Queued:  result.TotalTaskCount,
+	}, nil
+}
+
+// GetCallStatus retrieves a call's current status.
+func (p *Provider) GetCallStatus(ctx context.Context, providerCallID string) (*voiceprovider.CallEvent, error) {
+	var call RetellCall
+	if err := p.do(ctx, http.MethodGet, "/v2/get-call/"+providerCallID, nil, &call); err != nil {
+		return nil, err
+	}
+	return p.toCallEvent(&RetellWebhookPayload{Call: call})
+}
+
+// fromNumber returns the number to call from: the request's, or the
+// configured default.
+func (p *Provider) fromNumber(from string) string {
+	if from != "" {
+		return from
+	}
+	return p.config.FromNumber
+}
+
+// IsCircuitOpen reports whether calls to the Retell API are failing fast.
+func (p *Provider) IsCircuitOpen() bool {
+	return p.breaker.IsOpen()
+}
+
+// CircuitBreakerStats returns the Retell API circuit breaker statistics.
+func (p *Provider) CircuitBreakerStats() circuitbreaker.Stats {
+	return p.breaker.Stats()
+}
+
+// CircuitBreaker returns the breaker guarding the Retell API.
+func (p *Provider) CircuitBreaker() *circuitbreaker.CircuitBreaker {
+	return p.breaker
+}
+
+// do sends a request to the Retell API with circuit breaker protection and
+// decodes the JSON response into out.
+func (p *Provider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("retell: API key is not configured")
+	}
+	return p.breaker.Execute(ctx, func(ctx context.Context) error {
+		return p.send(ctx, method, path, body, out)
+	})
+}
+
+// send performs the actual HTTP request.
+func (p *Provider) send(ctx context.Context, method, path string, body, out interface{}) error {
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("retell: failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(p.config.APIURL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("retell: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("retell: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("retell: failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+			Error   string `json:"error_message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil {
+			if msg := apiErr.Message + apiErr.Error; msg != "" {
+				return fmt.Errorf("retell: %s (status %d)", msg, resp.StatusCode)
+			}
+		}
+		return fmt.Errorf("retell: status %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("retell: failed to decode response: %w", err)
+	}
+	return nil
+}
+
 // ValidateWebhook verifies the webhook signature.
 // Retell uses HMAC-SHA256 for webhook authentication.
 func (p *Provider) ValidateWebhook(r *http.Request) bool {
@@ -211,10 +397,18 @@ func (p *Provider) toCallEvent(payload *RetellWebhookPayload) (*voiceprovider.Ca
 	if len(call.TranscriptObject) > 0 {
 		event.TranscriptEntries = make([]voiceprovider.TranscriptEntry, len(call.TranscriptObject))
 		for i, t := range call.TranscriptObject {
-			event.TranscriptEntries[i] = voiceprovider.TranscriptEntry{
+			entry := voiceprovider.TranscriptEntry{
 				Role:    t.Role,
 				Content: t.Content,
 			}
+			// Word timings give when the utterance was spoken
+			if len(t.Words) > 0 {
+				start, end := t.Words[0].Start, t.Words[len(t.Words)-1].End
+				entry.Timestamp = start
+				entry.StartTime = &start
+				entry.EndTime = &end
+			}
+			event.TranscriptEntries[i] = entry
 		}
 	}
 
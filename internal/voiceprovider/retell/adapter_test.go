@@ -2,6 +2,7 @@ package retell
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
@@ -76,7 +78,10 @@ func TestProvider_ParseWebhook_Success(t *testing.T) {
 			RecordingURL:   "https://recording.url/call.mp3",
 			TranscriptObject: []RetellTranscriptEntry{
 				{Role: "agent", Content: "Hello, how can I help you?"},
-				{Role: "user", Content: "I need a quote for a website."},
+				{Role: "user", Content: "I need a quote for a website.", Words: []RetellWord{
+					{Word: "I", Start: 2.5, End: 2.6},
+					{Word: "website.", Start: 4.1, End: 4.8},
+				}},
 			},
 			CallAnalysis: &RetellCallAnalysis{
 				CallSummary:   "Customer requested quote for web project",
@@ -132,6 +137,12 @@ func TestProvider_ParseWebhook_Success(t *testing.T) {
 	if event.TranscriptEntries[0].Role != "agent" {
 		t.Errorf("TranscriptEntries[0].Role = %q, expected %q", event.TranscriptEntries[0].Role, "agent")
 	}
+	if e := event.TranscriptEntries[0]; e.StartTime != nil || e.EndTime != nil {
+		t.Errorf("TranscriptEntries[0] without words has times %v-%v", e.StartTime, e.EndTime)
+	}
+	if e := event.TranscriptEntries[1]; e.StartTime == nil || *e.StartTime != 2.5 || e.EndTime == nil || *e.EndTime != 4.8 {
+		t.Errorf("TranscriptEntries[1] times = %v-%v, expected 2.5-4.8", e.StartTime, e.EndTime)
+	}
 	if event.ExtractedData == nil {
 		t.Fatal("ExtractedData is nil")
 	}
@@ -609,3 +620,88 @@ func TestProvider_NormalizeCallStatus(t *testing.T) {
 		})
 	}
 }
+
+func newOutboundTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return New(&Config{
+		APIKey:     "test-api-key",
+		APIURL:     server.URL,
+		FromNumber: "+15550001111",
+		AgentID:    "agent-1",
+	}, zap.NewNop())
+}
+
+func TestProvider_InitiateCall(t *testing.T) {
+	var sent map[string]interface{}
+	provider := newOutboundTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/create-phone-call" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"call_id":"call-1","call_status":"registered"}`))
+	})
+
+	resp, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{
+		ToNumber:  "+15551234567",
+		Variables: map[string]string{"name": "Jane"},
+		Metadata:  map[string]interface{}{"campaign_contact_id": "c1"},
+	})
+	if err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+	if resp.ProviderCallID != "call-1" || resp.Status != "registered" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if sent["from_number"] != "+15550001111" || sent["to_number"] != "+15551234567" || sent["override_agent_id"] != "agent-1" {
+		t.Errorf("unexpected request %v", sent)
+	}
+	if sent["retell_llm_dynamic_variables"].(map[string]interface{})["name"] != "Jane" {
+		t.Errorf("unexpected variables %v", sent["retell_llm_dynamic_variables"])
+	}
+}
+
+func TestProvider_InitiateCall_Error(t *testing.T) {
+	provider := newOutboundTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_message":"to_number is invalid"}`))
+	})
+
+	_, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{ToNumber: "555"})
+	if err == nil || !strings.Contains(err.Error(), "to_number is invalid") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
+
+func TestProvider_InitiateBatch(t *testing.T) {
+	var sent map[string]interface{}
+	provider := newOutboundTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/create-batch-call" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"batch_call_id":"batch-1","total_task_count":2}`))
+	})
+
+	resp, err := provider.InitiateBatch(context.Background(), voiceprovider.BatchCallRequest{
+		Name: "Spring follow-ups",
+		Calls: []voiceprovider.OutboundCallRequest{
+			{ToNumber: "+15551234567", Variables: map[string]string{"name": "Jane"}},
+			{ToNumber: "+15557654321"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InitiateBatch() error = %v", err)
+	}
+	if resp.BatchID != "batch-1" || resp.Queued != 2 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if tasks := sent["tasks"].([]interface{}); len(tasks) != 2 || sent["from_number"] != "+15550001111" {
+		t.Errorf("unexpected request %v", sent)
+	}
+}
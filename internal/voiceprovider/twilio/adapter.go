@@ -0,0 +1,397 @@
+// Package twilio implements the VoiceProvider interface for Twilio Voice.
+// See: https://www.twilio.com/docs/voice/api/call-resource#statuscallback
+package twilio
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/validation"
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+// Config holds Twilio provider configuration.
+type Config struct {
+	AccountSID string
+	AuthToken  string
+	APIURL     string
+	// WebhookBaseURL is the public base URL Twilio calls (e.g. https://quotes.example.com).
+	// Twilio signs the full request URL, so this must match what is configured in
+	// the Twilio console when the service runs behind a proxy. If empty, the URL
+	// is reconstructed from the request.
+	WebhookBaseURL string
+}
+
+// Provider implements the voiceprovider.Provider interface for Twilio Voice.
+type Provider struct {
+	config *Config
+	logger *zap.Logger
+}
+
+// New creates a new Twilio provider.
+func New(cfg *Config, logger *zap.Logger) *Provider {
+	if cfg.APIURL == "" {
+		cfg.APIURL = "https://api.twilio.com/2010-04-01"
+	}
+	cfg.WebhookBaseURL = strings.TrimRight(cfg.WebhookBaseURL, "/")
+	return &Provider{
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// GetName returns the provider type identifier.
+func (p *Provider) GetName() voiceprovider.ProviderType {
+	return voiceprovider.ProviderTwilio
+}
+
+// GetWebhookPath returns the path for Twilio status callbacks.
+func (p *Provider) GetWebhookPath() string {
+	return "/webhook/twilio"
+}
+
+// SupportsBatch reports that batches of calls can't be placed through Twilio.
+func (p *Provider) SupportsBatch() bool {
+	return false
+}
+
+// SupportsSMS reports that texts can't be sent through Twilio.
+func (p *Provider) SupportsSMS() bool {
+	return false
+}
+
+// ValidateWebhook verifies the X-Twilio-Signature header.
+// Twilio signs the full request URL followed by the sorted POST parameters
+// using HMAC-SHA1 keyed with the account auth token.
+func (p *Provider) ValidateWebhook(r *http.Request) bool {
+	// If no auth token is configured, skip validation
+	// NOTE: In production, the auth token should always be configured
+	if p.config.AuthToken == "" {
+		p.logger.Warn("twilio auth token not configured, skipping signature validation")
+		return true
+	}
+
+	signature := r.Header.Get("X-Twilio-Signature")
+	if signature == "" {
+		p.logger.Warn("webhook missing X-Twilio-Signature header",
+			zap.String("provider", "twilio"),
+			zap.String("remote_addr", r.RemoteAddr),
+		)
+		return false
+	}
+
+	// Read body for signature verification
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		p.logger.Error("failed to read webhook body for validation", zap.Error(err))
+		return false
+	}
+
+	// CRITICAL: Restore the body so ParseWebhook can read it
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	params, err := url.ParseQuery(string(body))
+	if err != nil {
+		p.logger.Warn("failed to parse twilio webhook form for validation", zap.Error(err))
+		return false
+	}
+
+	expectedSignature := ComputeSignature(p.config.AuthToken, p.requestURL(r), params)
+
+	// Use constant-time comparison to prevent timing attacks
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		p.logger.Warn("webhook signature mismatch",
+			zap.String("provider", "twilio"),
+			zap.String("remote_addr", r.RemoteAddr),
+		)
+		return false
+	}
+
+	p.logger.Debug("webhook signature validated successfully",
+		zap.String("provider", "twilio"),
+	)
+	return true
+}
+
+// ComputeSignature returns the X-Twilio-Signature value for a request URL and
+// its POST parameters.
+func ComputeSignature(authToken, requestURL string, params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(requestURL)
+	for _, k := range keys {
+		values := append([]string(nil), params[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			b.WriteString(k)
+			b.WriteString(v)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(b.String()))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// requestURL returns the URL Twilio used to reach this request.
+func (p *Provider) requestURL(r *http.Request) string {
+	if p.config.WebhookBaseURL != "" {
+		return p.config.WebhookBaseURL + r.URL.RequestURI()
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// ParseWebhook parses a Twilio status, recording, or transcription callback
+// into a normalized CallEvent.
+func (p *Provider) ParseWebhook(r *http.Request) (*voiceprovider.CallEvent, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	defer r.Body.Close()
+
+	params, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook payload: %w", err)
+	}
+
+	payload := payloadFromValues(params)
+
+	event, err := p.toCallEvent(&payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store raw payload for debugging
+	rawMetadata := make(map[string]interface{}, len(params))
+	for k := range params {
+		rawMetadata[k] = params.Get(k)
+	}
+	event.RawMetadata = rawMetadata
+
+	p.logger.Debug("parsed twilio webhook",
+		zap.String("call_status", payload.CallStatus),
+		zap.String("call_sid", event.ProviderCallID),
+	)
+
+	return event, nil
+}
+
+// validatePayload validates and sanitizes the webhook payload.
+func (p *Provider) validatePayload(payload *TwilioWebhookPayload) error {
+	v := validation.NewCallEventValidator()
+
+	// Validate call SID (required)
+	v.ValidateCallID(payload.CallSid)
+
+	// Validate phone numbers
+	v.ValidatePhoneNumbers(payload.To, payload.From)
+
+	// Validate caller name and transcript content
+	v.ValidateCallerName(payload.CallerName)
+	v.ValidateTranscript(payload.TranscriptionText)
+
+	// Validate recording URL
+	v.ValidateRecordingURL(payload.RecordingURL)
+
+	// Check for validation errors
+	if !v.IsValid() {
+		errs := v.Errors()
+		p.logger.Warn("webhook payload validation failed",
+			zap.String("provider", "twilio"),
+			zap.String("call_sid", payload.CallSid),
+			zap.Int("error_count", len(errs)),
+			zap.String("errors", errs.Error()),
+		)
+		return errs
+	}
+
+	// Sanitize strings that passed validation
+	payload.CallerName = validation.SanitizeString(payload.CallerName)
+	payload.TranscriptionText = validation.SanitizeString(payload.TranscriptionText)
+
+	return nil
+}
+
+// toCallEvent converts a Twilio payload to a normalized CallEvent.
+func (p *Provider) toCallEvent(payload *TwilioWebhookPayload) (*voiceprovider.CallEvent, error) {
+	if payload.CallSid == "" {
+		return nil, fmt.Errorf("missing CallSid in webhook")
+	}
+
+	// Client and SIP identities are not phone numbers; drop them
+	payload.From = phoneOrEmpty(payload.From)
+	payload.To = phoneOrEmpty(payload.To)
+
+	// Validate and sanitize payload
+	if err := p.validatePayload(payload); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	event := &voiceprovider.CallEvent{
+		Provider:       voiceprovider.ProviderTwilio,
+		ProviderCallID: payload.CallSid,
+		ToNumber:       payload.To,
+		FromNumber:     payload.From,
+		CallerName:     payload.CallerName,
+		Status:         p.normalizeStatus(payload),
+		Transcript:     payload.TranscriptionText,
+		RecordingURL:   payload.RecordingURL,
+		Disposition:    payload.CallStatus,
+	}
+
+	// Call duration is reported on terminal status callbacks
+	if payload.CallDuration > 0 {
+		event.DurationSecs = payload.CallDuration
+	} else if payload.RecordingDuration > 0 {
+		event.DurationSecs = payload.RecordingDuration
+	}
+
+	// Timestamp is when the callback fired; for terminal statuses that is the end of the call
+	if payload.Timestamp != nil && event.IsComplete() {
+		ended := *payload.Timestamp
+		event.EndedAt = &ended
+		if event.DurationSecs > 0 {
+			started := ended.Add(-time.Duration(event.DurationSecs) * time.Second)
+			event.StartedAt = &started
+		}
+	} else if payload.Timestamp != nil && event.Status == voiceprovider.CallStatusInProgress {
+		started := *payload.Timestamp
+		event.StartedAt = &started
+	}
+
+	if payload.ErrorCode != "" {
+		event.ErrorCode = payload.ErrorCode
+		event.ErrorMessage = payload.ErrorMessage
+	} else if payload.SipResponseCode != "" && event.Status == voiceprovider.CallStatusFailed {
+		event.ErrorCode = "sip_" + payload.SipResponseCode
+	}
+
+	return event, nil
+}
+
+// normalizeStatus converts Twilio's CallStatus to a normalized CallStatus.
+func (p *Provider) normalizeStatus(payload *TwilioWebhookPayload) voiceprovider.CallStatus {
+	// Recording and transcription callbacks arrive after the call has ended
+	// and do not carry a CallStatus.
+	if payload.CallStatus == "" && (payload.RecordingStatus == "completed" || payload.TranscriptionStatus == "completed") {
+		return voiceprovider.CallStatusCompleted
+	}
+
+	switch payload.CallStatus {
+	case "queued", "initiated", "ringing":
+		return voiceprovider.CallStatusPending
+	case "in-progress":
+		return voiceprovider.CallStatusInProgress
+	case "completed":
+		if strings.HasPrefix(payload.AnsweredBy, "machine") {
+			return voiceprovider.CallStatusVoicemail
+		}
+		return voiceprovider.CallStatusCompleted
+	case "busy", "no-answer":
+		return voiceprovider.CallStatusNoAnswer
+	case "failed", "canceled":
+		return voiceprovider.CallStatusFailed
+	default:
+		return voiceprovider.CallStatusPending
+	}
+}
+
+// phoneOrEmpty returns the value if it looks like a phone number.
+// Twilio uses values such as "client:alice", "sip:..." and "anonymous" for
+// non-PSTN parties.
+func phoneOrEmpty(value string) string {
+	if value == "" || strings.Contains(value, ":") || strings.EqualFold(value, "anonymous") {
+		return ""
+	}
+	return value
+}
+
+// payloadFromValues maps form parameters to a TwilioWebhookPayload.
+func payloadFromValues(v url.Values) TwilioWebhookPayload {
+	payload := TwilioWebhookPayload{
+		CallSid:             v.Get("CallSid"),
+		AccountSid:          v.Get("AccountSid"),
+		From:                v.Get("From"),
+		To:                  v.Get("To"),
+		CallerName:          v.Get("CallerName"),
+		Direction:           v.Get("Direction"),
+		CallStatus:          v.Get("CallStatus"),
+		AnsweredBy:          v.Get("AnsweredBy"),
+		SipResponseCode:     v.Get("SipResponseCode"),
+		ErrorCode:           v.Get("ErrorCode"),
+		ErrorMessage:        v.Get("ErrorMessage"),
+		RecordingSid:        v.Get("RecordingSid"),
+		RecordingURL:        v.Get("RecordingUrl"),
+		RecordingStatus:     v.Get("RecordingStatus"),
+		TranscriptionText:   v.Get("TranscriptionText"),
+		TranscriptionStatus: v.Get("TranscriptionStatus"),
+	}
+
+	if d, err := strconv.Atoi(v.Get("CallDuration")); err == nil {
+		payload.CallDuration = d
+	}
+	if d, err := strconv.Atoi(v.Get("RecordingDuration")); err == nil {
+		payload.RecordingDuration = d
+	}
+	if ts := v.Get("Timestamp"); ts != "" {
+		if t, err := time.Parse(time.RFC1123Z, ts); err == nil {
+			payload.Timestamp = &t
+		}
+	}
+
+	return payload
+}
+
+// TwilioWebhookPayload represents the form parameters sent by Twilio
+// status, recording, and transcription callbacks.
+type TwilioWebhookPayload struct {
+	CallSid         string
+	AccountSid      string
+	From            string
+	To              string
+	CallerName      string
+	Direction       string // "inbound", "outbound-api", "outbound-dial"
+	CallStatus      string // "queued", "ringing", "in-progress", "completed", "busy", "failed", "no-answer", "canceled"
+	AnsweredBy      string // "human", "machine_start", ... when AMD is enabled
+	CallDuration    int    // seconds, on terminal callbacks
+	Timestamp       *time.Time
+	SipResponseCode string
+	ErrorCode       string
+	ErrorMessage    string
+
+	// Recording callbacks
+	RecordingSid      string
+	RecordingURL      string
+	RecordingStatus   string
+	RecordingDuration int
+
+	// Transcription callbacks
+	TranscriptionText   string
+	TranscriptionStatus string
+}
@@ -0,0 +1,284 @@
+package twilio
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/jkindrix/quickquote/internal/voiceprovider"
+)
+
+func newTestProvider() *Provider {
+	logger := zap.NewNop()
+	cfg := &Config{
+		AccountSID: "AC123",
+		AuthToken:  "",
+	}
+	return New(cfg, logger)
+}
+
+func newFormRequest(target string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func statusCallback() url.Values {
+	return url.Values{
+		"CallSid":      {"CA1234567890abcdef"},
+		"AccountSid":   {"AC123"},
+		"From":         {"+19876543210"},
+		"To":           {"+12345678901"},
+		"CallerName":   {"Jane Doe"},
+		"Direction":    {"inbound"},
+		"CallStatus":   {"completed"},
+		"CallDuration": {"125"},
+		"Timestamp":    {"Mon, 13 Nov 2023 22:15:00 +0000"},
+		"RecordingUrl": {"https://api.twilio.com/2010-04-01/Accounts/AC123/Recordings/RE123"},
+	}
+}
+
+func TestProvider_GetName(t *testing.T) {
+	provider := newTestProvider()
+
+	if got := provider.GetName(); got != voiceprovider.ProviderTwilio {
+		t.Errorf("GetName() = %q, expected %q", got, voiceprovider.ProviderTwilio)
+	}
+}
+
+func TestProvider_GetWebhookPath(t *testing.T) {
+	provider := newTestProvider()
+
+	if got := provider.GetWebhookPath(); got != "/webhook/twilio" {
+		t.Errorf("GetWebhookPath() = %q, expected %q", got, "/webhook/twilio")
+	}
+}
+
+func TestProvider_New_DefaultAPIURL(t *testing.T) {
+	provider := New(&Config{AccountSID: "AC123"}, zap.NewNop())
+
+	if provider.config.APIURL != "https://api.twilio.com/2010-04-01" {
+		t.Errorf("APIURL = %q, expected default value", provider.config.APIURL)
+	}
+}
+
+func TestProvider_ParseWebhook_Success(t *testing.T) {
+	provider := newTestProvider()
+
+	event, err := provider.ParseWebhook(newFormRequest("/webhook/twilio", statusCallback()))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+
+	if event.Provider != voiceprovider.ProviderTwilio {
+		t.Errorf("Provider = %q, expected %q", event.Provider, voiceprovider.ProviderTwilio)
+	}
+	if event.ProviderCallID != "CA1234567890abcdef" {
+		t.Errorf("ProviderCallID = %q", event.ProviderCallID)
+	}
+	if event.FromNumber != "+19876543210" || event.ToNumber != "+12345678901" {
+		t.Errorf("numbers = %q -> %q", event.FromNumber, event.ToNumber)
+	}
+	if event.CallerName != "Jane Doe" {
+		t.Errorf("CallerName = %q", event.CallerName)
+	}
+	if event.Status != voiceprovider.CallStatusCompleted {
+		t.Errorf("Status = %q, expected completed", event.Status)
+	}
+	if event.DurationSecs != 125 {
+		t.Errorf("DurationSecs = %d, expected 125", event.DurationSecs)
+	}
+	if event.EndedAt == nil || event.StartedAt == nil {
+		t.Fatal("expected StartedAt and EndedAt to be set")
+	}
+	if got := event.EndedAt.Sub(*event.StartedAt); got != 125*time.Second {
+		t.Errorf("EndedAt - StartedAt = %s, expected 125s", got)
+	}
+	if event.RecordingURL == "" {
+		t.Error("expected RecordingURL to be set")
+	}
+	if event.RawMetadata["CallSid"] != "CA1234567890abcdef" {
+		t.Error("expected raw metadata to include CallSid")
+	}
+}
+
+func TestProvider_ParseWebhook_MissingCallSid(t *testing.T) {
+	provider := newTestProvider()
+
+	form := statusCallback()
+	form.Del("CallSid")
+
+	if _, err := provider.ParseWebhook(newFormRequest("/webhook/twilio", form)); err == nil {
+		t.Error("ParseWebhook() should return error for missing CallSid")
+	}
+}
+
+func TestProvider_ParseWebhook_ClientIdentity(t *testing.T) {
+	provider := newTestProvider()
+
+	form := statusCallback()
+	form.Set("From", "client:alice")
+
+	event, err := provider.ParseWebhook(newFormRequest("/webhook/twilio", form))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event.FromNumber != "" {
+		t.Errorf("FromNumber = %q, expected client identity to be dropped", event.FromNumber)
+	}
+}
+
+func TestProvider_ParseWebhook_Transcription(t *testing.T) {
+	provider := newTestProvider()
+
+	form := url.Values{
+		"CallSid":             {"CA1"},
+		"TranscriptionStatus": {"completed"},
+		"TranscriptionText":   {"I need a quote for a new roof."},
+	}
+
+	event, err := provider.ParseWebhook(newFormRequest("/webhook/twilio", form))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if event.Status != voiceprovider.CallStatusCompleted {
+		t.Errorf("Status = %q, expected completed", event.Status)
+	}
+	if event.Transcript != "I need a quote for a new roof." {
+		t.Errorf("Transcript = %q", event.Transcript)
+	}
+}
+
+func TestProvider_NormalizeStatus(t *testing.T) {
+	provider := newTestProvider()
+
+	tests := []struct {
+		callStatus string
+		answeredBy string
+		expected   voiceprovider.CallStatus
+	}{
+		{"queued", "", voiceprovider.CallStatusPending},
+		{"initiated", "", voiceprovider.CallStatusPending},
+		{"ringing", "", voiceprovider.CallStatusPending},
+		{"in-progress", "", voiceprovider.CallStatusInProgress},
+		{"completed", "", voiceprovider.CallStatusCompleted},
+		{"completed", "human", voiceprovider.CallStatusCompleted},
+		{"completed", "machine_end_beep", voiceprovider.CallStatusVoicemail},
+		{"busy", "", voiceprovider.CallStatusNoAnswer},
+		{"no-answer", "", voiceprovider.CallStatusNoAnswer},
+		{"failed", "", voiceprovider.CallStatusFailed},
+		{"canceled", "", voiceprovider.CallStatusFailed},
+		{"unknown", "", voiceprovider.CallStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.callStatus+"/"+tt.answeredBy, func(t *testing.T) {
+			payload := &TwilioWebhookPayload{CallStatus: tt.callStatus, AnsweredBy: tt.answeredBy}
+			if got := provider.normalizeStatus(payload); got != tt.expected {
+				t.Errorf("normalizeStatus(%q) = %q, expected %q", tt.callStatus, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProvider_ValidateWebhook_NoAuthToken(t *testing.T) {
+	provider := newTestProvider()
+
+	req := newFormRequest("/webhook/twilio", statusCallback())
+
+	if !provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should return true when no auth token is configured")
+	}
+}
+
+func TestProvider_ValidateWebhook_MissingSignature(t *testing.T) {
+	provider := New(&Config{AccountSID: "AC123", AuthToken: "token"}, zap.NewNop())
+
+	req := newFormRequest("/webhook/twilio", statusCallback())
+
+	if provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should return false when signature header is missing")
+	}
+}
+
+func TestProvider_ValidateWebhook_ValidSignature(t *testing.T) {
+	authToken := "test-auth-token"
+	provider := New(&Config{
+		AccountSID:     "AC123",
+		AuthToken:      authToken,
+		WebhookBaseURL: "https://quotes.example.com/",
+	}, zap.NewNop())
+
+	form := statusCallback()
+	req := newFormRequest("/webhook/twilio?source=status", form)
+	req.Header.Set("X-Twilio-Signature",
+		ComputeSignature(authToken, "https://quotes.example.com/webhook/twilio?source=status", form))
+
+	if !provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should return true for valid signature")
+	}
+
+	// Verify body can still be read after validation
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to read body after validation: %v", err)
+	}
+	if string(body) != form.Encode() {
+		t.Errorf("body was not restored correctly after validation")
+	}
+}
+
+func TestProvider_ValidateWebhook_ReconstructsURL(t *testing.T) {
+	authToken := "test-auth-token"
+	provider := New(&Config{AccountSID: "AC123", AuthToken: authToken}, zap.NewNop())
+
+	form := statusCallback()
+	req := newFormRequest("/webhook/twilio", form)
+	req.Host = "quotes.example.com"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Twilio-Signature",
+		ComputeSignature(authToken, "https://quotes.example.com/webhook/twilio", form))
+
+	if !provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should accept signature computed for the forwarded URL")
+	}
+}
+
+func TestProvider_ValidateWebhook_InvalidSignature(t *testing.T) {
+	authToken := "test-auth-token"
+	provider := New(&Config{
+		AccountSID:     "AC123",
+		AuthToken:      authToken,
+		WebhookBaseURL: "https://quotes.example.com",
+	}, zap.NewNop())
+
+	form := statusCallback()
+	signature := ComputeSignature(authToken, "https://quotes.example.com/webhook/twilio", form)
+
+	// Tamper with a parameter after signing
+	form.Set("CallStatus", "failed")
+	req := newFormRequest("/webhook/twilio", form)
+	req.Header.Set("X-Twilio-Signature", signature)
+
+	if provider.ValidateWebhook(req) {
+		t.Error("ValidateWebhook() should return false for tampered payload")
+	}
+}
+
+func TestComputeSignature_ParameterOrder(t *testing.T) {
+	a := url.Values{"b": {"2"}, "a": {"1"}}
+	b := url.Values{"a": {"1"}, "b": {"2"}}
+
+	if ComputeSignature("token", "https://example.com/hook", a) != ComputeSignature("token", "https://example.com/hook", b) {
+		t.Error("signature should not depend on parameter insertion order")
+	}
+	if ComputeSignature("token", "https://example.com/hook", a) == ComputeSignature("other", "https://example.com/hook", a) {
+		t.Error("signature should depend on the auth token")
+	}
+}
@@ -5,6 +5,7 @@ package vapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -12,10 +13,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/jkindrix/quickquote/internal/circuitbreaker"
 	"github.com/jkindrix/quickquote/internal/validation"
 	"github.com/jkindrix/quickquote/internal/voiceprovider"
 )
@@ -25,12 +28,17 @@ type Config struct {
 	APIKey        string
 	WebhookSecret string
 	APIURL        string
+	AssistantID   string       // Assistant that handles outbound calls
+	PhoneNumberID string       // Vapi phone number outbound calls are placed from
+	HTTPClient    *http.Client // Client for API calls; nil uses one with a 30 second timeout
 }
 
 // Provider implements the voiceprovider.Provider interface for Vapi.
 type Provider struct {
-	config *Config
-	logger *zap.Logger
+	config  *Config
+	client  *http.Client
+	breaker *circuitbreaker.CircuitBreaker
+	logger  *zap.Logger
 }
 
 // New creates a new Vapi provider.
@@ -38,9 +46,15 @@ func New(cfg *Config, logger *zap.Logger) *Provider {
 	if cfg.APIURL == "" {
 		cfg.APIURL = "https://api.vapi.ai"
 	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
 	return &Provider{
-		config: cfg,
-		logger: logger,
+		config:  cfg,
+		client:  client,
+		breaker: circuitbreaker.New("vapi-api", nil, logger),
+		logger:  logger,
 	}
 }
 
@@ -54,6 +68,195 @@ func (p *Provider) GetWebhookPath() string {
 	return "/webhook/vapi"
 }
 
+// SupportsBatch reports that Vapi places batches of outbound calls.
+func (p *Provider) SupportsBatch() bool {
+	return true
+}
+
+// SupportsSMS reports that texts can't be sent through Vapi.
+func (p *Provider) SupportsSMS() bool {
+	return false
+}
+
+// InitiateCall starts an outbound call from the configured phone number
+// with the configured assistant. Variables are passed to the assistant as
+// variable values and metadata is returned on the call's webhooks.
+func (p *Provider) InitiateCall(ctx context.Context, req voiceprovider.OutboundCallRequest) (*voiceprovider.OutboundCallResponse, error) {
+	if err := p.checkOutbound(); err != nil {
+		return nil, err
+	}
+	body := p.callBody(req.FirstMessage, req.Variables, req.Metadata)
+	body["customer"] = vapiCustomer(req)
+
+	var call VapiCall
+	if err := p.do(ctx, http.MethodPost, "/call", body, &call); err != nil {
+		return nil, err
+	}
+	return &voiceprovider.OutboundCallResponse{
+		ProviderCallID: call.ID,
+		Status:         call.Status,
+	}, nil
+}
+
+// InitiateBatch starts calls to every number in the batch in one request.
+// Vapi applies one set of assistant overrides to a batch, so the first
+// call's first message, variables and metadata are used for all of them.
+func (p *Provider) InitiateBatch(ctx context.Context, req voiceprovider.BatchCallRequest) (*voiceprovider.BatchCallResponse, error) {
+	if err := p.checkOutbound(); err != nil {
+		return nil, err
+	}
+	if len(req.Calls) == 0 {
+		return nil, fmt.Errorf("vapi: batch has no calls")
+	}
+	first := req.Calls[0]
+	body := p.callBody(first.FirstMessage, first.Variables, first.Metadata)
+	if req.Name != "" {
+		body["name"] = req.Name
+	}
+	customers := make([]VapiCustomer, len(req.Calls))
+	for i, c := range req.Calls {
+		customers[i] = vapiCustomer(c)
+	}
+	body["customers"] = customers
+
+	var result struct {
+		Results []VapiCall `json:"results"`
+		Errors  []struct {
+			Error string `json:"error"`
+		} `json:"errors"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/call", body, &result); err != nil {
+		return nil, err
+	}
+	for _, e := range result.Errors {
+		p.logger.Warn("vapi batch call failed", zap.String("error", e.Error))
+	}
+
+	resp := &voiceprovider.BatchCallResponse{
+		Queued: len(result.Results),
+		Calls:  make([]voiceprovider.OutboundCallResponse, len(result.Results)),
+	}
+	for i, call := range result.Results {
+		resp.Calls[i] = voiceprovider.OutboundCallResponse{ProviderCallID: call.ID, Status: call.Status}
+	}
+	return resp, nil
+}
+
+// GetCallStatus retrieves a call's current status.
+func (p *Provider) GetCallStatus(ctx context.Context, providerCallID string) (*voiceprovider.CallEvent, error) {
+	var call VapiCall
+	if err := p.do(ctx, http.MethodGet, "/call/"+providerCallID, nil, &call); err != nil {
+		return nil, err
+	}
+	return p.parseStatusUpdate(&VapiWebhookPayload{Message: VapiMessage{Call: call, Status: call.Status}})
+}
+
+// checkOutbound returns an error unless outbound calls are configured.
+func (p *Provider) checkOutbound() error {
+	if p.config.AssistantID == "" || p.config.PhoneNumberID == "" {
+		return fmt.Errorf("vapi: assistant ID and phone number ID are required for outbound calls")
+	}
+	return nil
+}
+
+// callBody builds the part of a call request shared by single calls and
+// batches.
+func (p *Provider) callBody(firstMessage string, variables map[string]string, metadata map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{
+		"assistantId":   p.config.AssistantID,
+		"phoneNumberId": p.config.PhoneNumberID,
+	}
+	overrides := map[string]interface{}{}
+	if firstMessage != "" {
+		overrides["firstMessage"] = firstMessage
+	}
+	if len(variables) > 0 {
+		overrides["variableValues"] = variables
+	}
+	if len(metadata) > 0 {
+		overrides["metadata"] = metadata
+	}
+	if len(overrides) > 0 {
+		body["assistantOverrides"] = overrides
+	}
+	return body
+}
+
+func vapiCustomer(req voiceprovider.OutboundCallRequest) VapiCustomer {
+	return VapiCustomer{Number: req.ToNumber, Name: req.Variables["name"]}
+}
+
+// IsCircuitOpen reports whether calls to the Vapi API are failing fast.
+func (p *Provider) IsCircuitOpen() bool {
+	return p.breaker.IsOpen()
+}
+
+// CircuitBreakerStats returns the Vapi API circuit breaker statistics.
+func (p *Provider) CircuitBreakerStats() circuitbreaker.Stats {
+	return p.breaker.Stats()
+}
+
+// CircuitBreaker returns the breaker guarding the Vapi API.
+func (p *Provider) CircuitBreaker() *circuitbreaker.CircuitBreaker {
+	return p.breaker
+}
+
+// do sends a request to the Vapi API with circuit breaker protection and
+// decodes the JSON response into out.
+func (p *Provider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	if p.config.APIKey == "" {
+		return fmt.Errorf("vapi: API key is not configured")
+	}
+	return p.breaker.Execute(ctx, func(ctx context.Context) error {
+		return p.send(ctx, method, path, body, out)
+	})
+}
+
+// send performs the actual HTTP request.
+func (p *Provider) send(ctx context.Context, method, path string, body, out interface{}) error {
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("vapi: failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(p.config.APIURL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("vapi: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vapi: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("vapi: failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message interface{} `json:"message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != nil {
+			return fmt.Errorf("vapi: %v (status %d)", apiErr.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("vapi: status %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("vapi: failed to decode response: %w", err)
+	}
+	return nil
+}
+
 // ValidateWebhook verifies the webhook authenticity.
 // Vapi supports multiple authentication methods - we implement HMAC-SHA256.
 func (p *Provider) ValidateWebhook(r *http.Request) bool {
@@ -263,10 +466,18 @@ func (p *Provider) parseEndOfCallReport(payload *VapiWebhookPayload) (*voiceprov
 	if len(payload.Message.Messages) > 0 {
 		event.TranscriptEntries = make([]voiceprovider.TranscriptEntry, len(payload.Message.Messages))
 		for i, msg := range payload.Message.Messages {
-			event.TranscriptEntries[i] = voiceprovider.TranscriptEntry{
+			entry := voiceprovider.TranscriptEntry{
 				Role:    msg.Role,
 				Content: msg.Content,
 			}
+			// Offsets are only known when the message has an end
+			if msg.EndTime > 0 {
+				start, end := msg.StartTime, msg.EndTime
+				entry.Timestamp = start
+				entry.StartTime = &start
+				entry.EndTime = &end
+			}
+			event.TranscriptEntries[i] = entry
 		}
 	}
 
@@ -2,6 +2,7 @@ package vapi
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -137,6 +138,9 @@ func TestProvider_ParseWebhook_EndOfCallReport_Success(t *testing.T) {
 	if event.TranscriptEntries[0].Role != "assistant" {
 		t.Errorf("TranscriptEntries[0].Role = %q, expected %q", event.TranscriptEntries[0].Role, "assistant")
 	}
+	if e := event.TranscriptEntries[1]; e.StartTime == nil || *e.StartTime != 2.5 || e.EndTime == nil || *e.EndTime != 5.0 {
+		t.Errorf("TranscriptEntries[1] times = %v-%v, expected 2.5-5", e.StartTime, e.EndTime)
+	}
 	if event.ExtractedData == nil {
 		t.Fatal("ExtractedData is nil")
 	}
@@ -780,3 +784,85 @@ func TestVapiPhoneNumber_JSONSerialization(t *testing.T) {
 		t.Errorf("Number = %q, expected %q", decoded.Number, original.Number)
 	}
 }
+
+func newOutboundTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return New(&Config{
+		APIKey:        "test-api-key",
+		APIURL:        server.URL,
+		AssistantID:   "asst-1",
+		PhoneNumberID: "phone-1",
+	}, zap.NewNop())
+}
+
+func TestProvider_InitiateCall(t *testing.T) {
+	var sent map[string]interface{}
+	provider := newOutboundTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/call" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"id":"call-1","status":"queued","type":"outboundPhoneCall"}`))
+	})
+
+	resp, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{
+		ToNumber:  "+15551234567",
+		Variables: map[string]string{"name": "Jane"},
+		Metadata:  map[string]interface{}{"campaign_contact_id": "c1"},
+	})
+	if err != nil {
+		t.Fatalf("InitiateCall() error = %v", err)
+	}
+	if resp.ProviderCallID != "call-1" || resp.Status != "queued" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if sent["assistantId"] != "asst-1" || sent["phoneNumberId"] != "phone-1" {
+		t.Errorf("unexpected request %v", sent)
+	}
+	customer := sent["customer"].(map[string]interface{})
+	if customer["number"] != "+15551234567" || customer["name"] != "Jane" {
+		t.Errorf("unexpected customer %v", customer)
+	}
+	overrides := sent["assistantOverrides"].(map[string]interface{})
+	if overrides["variableValues"].(map[string]interface{})["name"] != "Jane" || overrides["metadata"].(map[string]interface{})["campaign_contact_id"] != "c1" {
+		t.Errorf("unexpected overrides %v", overrides)
+	}
+}
+
+func TestProvider_InitiateCall_NotConfigured(t *testing.T) {
+	provider := newTestProvider()
+
+	if _, err := provider.InitiateCall(context.Background(), voiceprovider.OutboundCallRequest{ToNumber: "+15551234567"}); err == nil {
+		t.Error("expected an error without an assistant and phone number")
+	}
+}
+
+func TestProvider_InitiateBatch(t *testing.T) {
+	var sent map[string]interface{}
+	provider := newOutboundTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"results":[{"id":"call-1","status":"queued"},{"id":"call-2","status":"queued"}],"errors":[]}`))
+	})
+
+	resp, err := provider.InitiateBatch(context.Background(), voiceprovider.BatchCallRequest{
+		Name: "Spring follow-ups",
+		Calls: []voiceprovider.OutboundCallRequest{
+			{ToNumber: "+15551234567"},
+			{ToNumber: "+15557654321"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("InitiateBatch() error = %v", err)
+	}
+	if resp.Queued != 2 || len(resp.Calls) != 2 || resp.Calls[1].ProviderCallID != "call-2" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if customers := sent["customers"].([]interface{}); len(customers) != 2 || sent["name"] != "Spring follow-ups" {
+		t.Errorf("unexpected request %v", sent)
+	}
+}
@@ -0,0 +1,253 @@
+// Package whatsapp sends and receives WhatsApp messages through the
+// WhatsApp Business Cloud API.
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultAPIURL is the Graph API the Cloud API is served from.
+const defaultAPIURL = "https://graph.facebook.com/v19.0"
+
+// SignatureHeader carries the app secret signature of a webhook body.
+const SignatureHeader = "X-Hub-Signature-256"
+
+// ErrInvalidSignature is returned for a webhook whose signature does not
+// match its body.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Config holds Cloud API settings.
+type Config struct {
+	AccessToken   string // System user access token
+	PhoneNumberID string // ID of the business phone number messages are sent from
+	AppSecret     string // Signs webhook deliveries
+	VerifyToken   string // Echoed back when the webhook subscription is verified
+	APIURL        string // Overrides the Graph API URL, for tests
+}
+
+// Client sends messages from a WhatsApp Business phone number and reads the
+// webhooks for it.
+type Client struct {
+	config Config
+	apiURL string
+	client *http.Client
+}
+
+// New creates a client. A client with a 30 second timeout is used when
+// client is nil.
+func New(cfg Config, client *http.Client) (*Client, error) {
+	if cfg.AccessToken == "" || cfg.PhoneNumberID == "" {
+		return nil, errors.New("whatsapp: access token and phone number ID are required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	return &Client{
+		config: cfg,
+		apiURL: strings.TrimRight(apiURL, "/"),
+		client: client,
+	}, nil
+}
+
+// SendText sends a free-form message and returns its message ID. WhatsApp
+// only delivers free-form messages within 24 hours of the customer's last
+// message; outside that window, use SendTemplate.
+func (c *Client) SendText(ctx context.Context, to, body string) (string, error) {
+	return c.send(ctx, map[string]interface{}{
+		"type": "text",
+		"text": map[string]interface{}{"body": body, "preview_url": true},
+	}, to)
+}
+
+// SendTemplate sends an approved message template with its body parameters
+// filled in order, and returns its message ID.
+func (c *Client) SendTemplate(ctx context.Context, to, name, language string, params []string) (string, error) {
+	template := map[string]interface{}{
+		"name":     name,
+		"language": map[string]string{"code": language},
+	}
+	if len(params) > 0 {
+		parameters := make([]map[string]string, len(params))
+		for i, p := range params {
+			parameters[i] = map[string]string{"type": "text", "text": p}
+		}
+		template["components"] = []map[string]interface{}{
+			{"type": "body", "parameters": parameters},
+		}
+	}
+	return c.send(ctx, map[string]interface{}{
+		"type":     "template",
+		"template": template,
+	}, to)
+}
+
+func (c *Client) send(ctx context.Context, message map[string]interface{}, to string) (string, error) {
+	message["messaging_product"] = "whatsapp"
+	message["recipient_type"] = "individual"
+	message["to"] = strings.TrimPrefix(to, "+")
+	body, err := json.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/"+c.config.PhoneNumberID+"/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("whatsapp: failed to read response: %w", err)
+	}
+
+	var result struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+		Error *struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil && resp.StatusCode < 300 {
+		return "", fmt.Errorf("whatsapp: failed to decode response: %w", err)
+	}
+	if resp.StatusCode >= 300 || result.Error != nil {
+		if result.Error != nil {
+			return "", fmt.Errorf("whatsapp: %s (code %d)", result.Error.Message, result.Error.Code)
+		}
+		return "", fmt.Errorf("whatsapp: status %d", resp.StatusCode)
+	}
+	if len(result.Messages) == 0 {
+		return "", errors.New("whatsapp: response has no message ID")
+	}
+	return result.Messages[0].ID, nil
+}
+
+// VerifySubscription reports whether a webhook subscription request
+// carries the configured verify token.
+func (c *Client) VerifySubscription(mode, token string) bool {
+	return mode == "subscribe" && c.config.VerifyToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(c.config.VerifyToken)) == 1
+}
+
+// VerifySignature checks a webhook body against its X-Hub-Signature-256
+// header. Without an app secret, every webhook is accepted.
+func (c *Client) VerifySignature(payload []byte, signature string) error {
+	if c.config.AppSecret == "" {
+		return nil
+	}
+	sig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(c.config.AppSecret))
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Message is a message a customer sent to the business number.
+type Message struct {
+	ID        string
+	From      string // E.164
+	Name      string // The sender's WhatsApp profile name
+	Body      string
+	Timestamp time.Time
+}
+
+// ParseWebhook returns the customer messages in a webhook. Delivery status
+// updates carry none. Media and other non-text messages are returned with
+// their type in brackets as the body, such as "[image]".
+func ParseWebhook(payload []byte) ([]Message, error) {
+	var webhook struct {
+		Entry []struct {
+			Changes []struct {
+				Value struct {
+					Contacts []struct {
+						WaID    string `json:"wa_id"`
+						Profile struct {
+							Name string `json:"name"`
+						} `json:"profile"`
+					} `json:"contacts"`
+					Messages []struct {
+						ID        string `json:"id"`
+						From      string `json:"from"`
+						Timestamp string `json:"timestamp"`
+						Type      string `json:"type"`
+						Text      struct {
+							Body string `json:"body"`
+						} `json:"text"`
+						Button struct {
+							Text string `json:"text"`
+						} `json:"button"`
+					} `json:"messages"`
+				} `json:"value"`
+			} `json:"changes"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return nil, fmt.Errorf("whatsapp: invalid webhook: %w", err)
+	}
+
+	var messages []Message
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			names := make(map[string]string)
+			for _, contact := range change.Value.Contacts {
+				names[contact.WaID] = contact.Profile.Name
+			}
+			for _, m := range change.Value.Messages {
+				msg := Message{
+					ID:   m.ID,
+					From: "+" + strings.TrimPrefix(m.From, "+"),
+					Name: names[m.From],
+				}
+				switch m.Type {
+				case "text":
+					msg.Body = m.Text.Body
+				case "button":
+					msg.Body = m.Button.Text
+				default:
+					msg.Body = "[" + m.Type + "]"
+				}
+				if secs, err := strconv.ParseInt(m.Timestamp, 10, 64); err == nil {
+					msg.Timestamp = time.Unix(secs, 0).UTC()
+				}
+				messages = append(messages, msg)
+			}
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,129 @@
+package whatsapp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := New(Config{
+		AccessToken:   "token_1",
+		PhoneNumberID: "1055",
+		AppSecret:     "app_secret",
+		VerifyToken:   "verify_1",
+		APIURL:        server.URL,
+	}, server.Client())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestClient_SendTemplate(t *testing.T) {
+	var sent map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/1055/messages" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token_1" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"messages": []map[string]string{{"id": "wamid.1"}},
+		})
+	})
+
+	id, err := c.SendTemplate(context.Background(), "+15551234567", "quote_ready", "en_US", []string{"Jane", "Q-1"})
+	if err != nil {
+		t.Fatalf("SendTemplate() error = %v", err)
+	}
+	if id != "wamid.1" {
+		t.Errorf("message ID = %q", id)
+	}
+	if sent["to"] != "15551234567" || sent["type"] != "template" || sent["messaging_product"] != "whatsapp" {
+		t.Errorf("unexpected message %v", sent)
+	}
+	template := sent["template"].(map[string]interface{})
+	params := template["components"].([]interface{})[0].(map[string]interface{})["parameters"].([]interface{})
+	if template["name"] != "quote_ready" || len(params) != 2 || params[1].(map[string]interface{})["text"] != "Q-1" {
+		t.Errorf("unexpected template %v", template)
+	}
+}
+
+func TestClient_SendTextError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Re-engagement message","code":131047}}`))
+	})
+
+	_, err := c.SendText(context.Background(), "+15551234567", "Hello")
+	if err == nil || !strings.Contains(err.Error(), "Re-engagement message") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
+
+func TestClient_Verify(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	if !c.VerifySubscription("subscribe", "verify_1") || c.VerifySubscription("subscribe", "wrong") {
+		t.Error("VerifySubscription should accept only the verify token")
+	}
+
+	payload := []byte(`{"entry":[]}`)
+	mac := hmac.New(sha256.New, []byte("app_secret"))
+	mac.Write(payload)
+	if err := c.VerifySignature(payload, "sha256="+hex.EncodeToString(mac.Sum(nil))); err != nil {
+		t.Errorf("VerifySignature() error = %v", err)
+	}
+	if err := c.VerifySignature(payload, "sha256=00"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	payload := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{"changes": [{"field": "messages", "value": {
+			"contacts": [{"wa_id": "15551234567", "profile": {"name": "Jane"}}],
+			"messages": [
+				{"id": "wamid.1", "from": "15551234567", "timestamp": "1700000000", "type": "text", "text": {"body": "Can you start Monday?"}},
+				{"id": "wamid.2", "from": "15551234567", "timestamp": "1700000060", "type": "image"}
+			]
+		}}]}]
+	}`)
+
+	messages, err := ParseWebhook(payload)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	if messages[0].From != "+15551234567" || messages[0].Name != "Jane" || messages[0].Body != "Can you start Monday?" {
+		t.Errorf("unexpected message %+v", messages[0])
+	}
+	if messages[0].Timestamp.Unix() != 1700000000 {
+		t.Errorf("timestamp = %v", messages[0].Timestamp)
+	}
+	if messages[1].Body != "[image]" {
+		t.Errorf("media message body = %q", messages[1].Body)
+	}
+
+	// Status updates carry no messages
+	messages, err = ParseWebhook([]byte(`{"entry":[{"changes":[{"value":{"statuses":[{"id":"wamid.1","status":"read"}]}}]}]}`))
+	if err != nil || len(messages) != 0 {
+		t.Errorf("expected no messages, got %v, %v", messages, err)
+	}
+}
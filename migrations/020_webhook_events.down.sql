@@ -0,0 +1,6 @@
+-- Rollback webhook event log
+DROP INDEX IF EXISTS idx_webhook_events_status_scheduled;
+DROP INDEX IF EXISTS idx_webhook_events_provider_call_id;
+DROP INDEX IF EXISTS idx_webhook_events_created_at;
+
+DROP TABLE IF EXISTS webhook_events;
@@ -0,0 +1,33 @@
+-- Durable log of incoming voice provider webhooks with retry support
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    provider VARCHAR(50) NOT NULL,
+    provider_call_id VARCHAR(255) NOT NULL,
+    event_status VARCHAR(50),                  -- Call status reported by the provider
+
+    -- Payloads
+    payload JSONB,                             -- Raw request body as received
+    event JSONB NOT NULL,                      -- Normalized CallEvent used for processing
+
+    -- Processing state
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, processing, completed, failed
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 8,
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), -- When to next attempt
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    last_error TEXT,
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_events_status_scheduled ON webhook_events(status, scheduled_at)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX IF NOT EXISTS idx_webhook_events_provider_call_id ON webhook_events(provider_call_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_created_at ON webhook_events(created_at);
+
+COMMENT ON TABLE webhook_events IS 'Every incoming provider webhook, persisted before processing so failures can be retried';
+COMMENT ON COLUMN webhook_events.status IS 'Processing status: pending (awaiting retry), processing, completed, failed (retries exhausted)';
+COMMENT ON COLUMN webhook_events.scheduled_at IS 'When to next attempt processing (for retry backoff)';
@@ -0,0 +1,8 @@
+-- Rollback call campaigns
+DROP INDEX IF EXISTS idx_campaign_contacts_pending;
+DROP INDEX IF EXISTS idx_campaign_contacts_status;
+DROP TABLE IF EXISTS campaign_contacts;
+
+DROP INDEX IF EXISTS idx_campaigns_status;
+DROP INDEX IF EXISTS idx_campaigns_created_at;
+DROP TABLE IF EXISTS campaigns;
@@ -0,0 +1,55 @@
+-- Locally scheduled outbound call campaigns
+CREATE TABLE IF NOT EXISTS campaigns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    prompt_id UUID REFERENCES prompts(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',  -- active, paused, completed, cancelled
+
+    -- Schedule
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    window_start_minute INTEGER NOT NULL DEFAULT 0,
+    window_end_minute INTEGER NOT NULL DEFAULT 1440,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+
+    -- Pacing
+    calls_per_hour INTEGER NOT NULL DEFAULT 30,
+    last_dialed_at TIMESTAMPTZ,
+
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+
+    CONSTRAINT campaigns_window_check CHECK (window_start_minute >= 0 AND window_end_minute <= 1440 AND window_start_minute < window_end_minute),
+    CONSTRAINT campaigns_pacing_check CHECK (calls_per_hour > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaigns_status ON campaigns(status);
+CREATE INDEX IF NOT EXISTS idx_campaigns_created_at ON campaigns(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS campaign_contacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    phone_number VARCHAR(50) NOT NULL,
+    name VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, dialing, called, failed, skipped
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    dialed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT campaign_contacts_unique_number UNIQUE (campaign_id, phone_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_contacts_pending ON campaign_contacts(campaign_id, created_at)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_campaign_contacts_status ON campaign_contacts(campaign_id, status);
+
+COMMENT ON TABLE campaigns IS 'Outbound call campaigns dialed locally by the campaign scheduler';
+COMMENT ON COLUMN campaigns.window_start_minute IS 'Start of the daily call window, in minutes after midnight in the campaign timezone';
+COMMENT ON COLUMN campaigns.window_end_minute IS 'End of the daily call window (exclusive), in minutes after midnight in the campaign timezone';
+COMMENT ON COLUMN campaigns.calls_per_hour IS 'Maximum dialing rate; calls are spaced evenly across the hour';
+COMMENT ON TABLE campaign_contacts IS 'Numbers uploaded for a campaign and the outcome of dialing each one';
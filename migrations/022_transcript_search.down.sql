@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_calls_transcript_tsv;
+ALTER TABLE calls DROP COLUMN IF EXISTS transcript_tsv;
@@ -0,0 +1,7 @@
+-- Full-text search over call transcripts
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS transcript_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(transcript, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_calls_transcript_tsv ON calls USING GIN (transcript_tsv);
+
+COMMENT ON COLUMN calls.transcript_tsv IS 'English tsvector of the transcript, maintained by Postgres for full-text search';
@@ -0,0 +1,6 @@
+-- Rollback quote review workflow
+DROP INDEX IF EXISTS idx_quote_transitions_call_id;
+DROP TABLE IF EXISTS quote_transitions;
+
+DROP INDEX IF EXISTS idx_quotes_status;
+DROP TABLE IF EXISTS quotes;
@@ -0,0 +1,39 @@
+-- Quote review workflow: draft -> pending_review -> approved -> sent -> accepted/declined
+CREATE TABLE IF NOT EXISTS quotes (
+    call_id UUID PRIMARY KEY REFERENCES calls(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    total_amount NUMERIC(12,2) NOT NULL DEFAULT 0,
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+
+    submitted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMPTZ,
+    approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    responded_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT quotes_status_check CHECK (status IN ('draft', 'pending_review', 'approved', 'sent', 'accepted', 'declined'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_quotes_status ON quotes(status);
+
+CREATE TABLE IF NOT EXISTS quote_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL REFERENCES quotes(call_id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    actor_email VARCHAR(255),
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quote_transitions_call_id ON quote_transitions(call_id, created_at);
+
+COMMENT ON TABLE quotes IS 'Review state of the quote generated for each call; calls without a row are drafts';
+COMMENT ON COLUMN quotes.total_amount IS 'Priced total captured when the quote was submitted for review';
+COMMENT ON COLUMN quotes.requires_approval IS 'True when total_amount exceeded the approval threshold at submission';
+COMMENT ON TABLE quote_transitions IS 'Audit trail of quote status changes';
@@ -0,0 +1,6 @@
+-- Rollback customers
+DROP INDEX IF EXISTS idx_calls_customer_id;
+ALTER TABLE calls DROP COLUMN IF EXISTS customer_id;
+
+DROP INDEX IF EXISTS idx_customers_created_at;
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,35 @@
+-- Customers: one record per caller phone number, linked to their calls.
+-- Quotes are keyed by call, so a customer's quotes follow from their calls.
+CREATE TABLE IF NOT EXISTS customers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    phone_number VARCHAR(20) NOT NULL,  -- E.164
+    name VARCHAR(255),
+    email VARCHAR(255),
+    company VARCHAR(255),
+    address TEXT,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT customers_phone_number_key UNIQUE (phone_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_customers_created_at ON customers(created_at DESC);
+
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS customer_id UUID REFERENCES customers(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_customer_id ON calls(customer_id, created_at DESC) WHERE customer_id IS NOT NULL;
+
+-- Backfill customers from existing calls whose caller number is already E.164.
+INSERT INTO customers (phone_number, name, created_at)
+SELECT DISTINCT ON (from_number) from_number, caller_name, created_at
+FROM calls
+WHERE deleted_at IS NULL AND from_number ~ '^\+[1-9][0-9]{6,14}$'
+ORDER BY from_number, created_at DESC
+ON CONFLICT (phone_number) DO NOTHING;
+
+UPDATE calls SET customer_id = customers.id
+FROM customers
+WHERE calls.customer_id IS NULL AND calls.from_number = customers.phone_number;
+
+COMMENT ON TABLE customers IS 'Callers, deduplicated by E.164 phone number';
+COMMENT ON COLUMN calls.customer_id IS 'Customer the call was made by or to';
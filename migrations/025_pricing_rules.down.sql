@@ -0,0 +1,2 @@
+-- Rollback pricing rules
+DROP TABLE IF EXISTS pricing_rules;
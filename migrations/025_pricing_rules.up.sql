@@ -0,0 +1,33 @@
+-- Pricing rules: deterministic quote pricing per project type.
+-- unit_rates is a JSON array of {key, label, rate}; multipliers is a JSON
+-- array of {key, label, factor}. The rule for project type 'default' prices
+-- calls whose project type has no rule of its own.
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_type VARCHAR(100) NOT NULL,  -- lowercase, single-spaced
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    base_rate NUMERIC(12,2) NOT NULL DEFAULT 0 CHECK (base_rate >= 0),
+    unit_rates JSONB NOT NULL DEFAULT '[]',
+    multipliers JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT pricing_rules_project_type_key UNIQUE (project_type)
+);
+
+-- Starter rule so quotes keep carrying prices after upgrading; adjust it
+-- from the Pricing page.
+INSERT INTO pricing_rules (project_type, name, description, base_rate, unit_rates, multipliers) VALUES (
+    'default',
+    'Project setup',
+    'Used when no rule matches the project type',
+    2500,
+    '[{"key": "page", "label": "Pages or screens", "rate": 750},
+      {"key": "integration", "label": "Third-party integrations", "rate": 2000},
+      {"key": "user_role", "label": "User roles", "rate": 1000}]',
+    '[{"key": "rush", "label": "Rush delivery", "factor": 1.25}]'
+) ON CONFLICT (project_type) DO NOTHING;
+
+COMMENT ON TABLE pricing_rules IS 'Rates used to price quotes from the quantities extracted from a call';
@@ -0,0 +1,2 @@
+-- Rollback callbacks
+DROP TABLE IF EXISTS callbacks;
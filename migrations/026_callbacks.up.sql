@@ -0,0 +1,31 @@
+-- Callbacks: times callers asked to be called back, captured by the
+-- schedule_callback tool and put on the business calendar by a worker.
+CREATE TABLE IF NOT EXISTS callbacks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    provider_call_id VARCHAR(255) NOT NULL,
+    phone_number VARCHAR(20),
+    caller_name VARCHAR(255),
+    reason TEXT,
+    requested_date VARCHAR(100) NOT NULL,
+    requested_time VARCHAR(100) NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    calendar_provider VARCHAR(20),
+    calendar_event_id VARCHAR(1024),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT callbacks_status_check CHECK (status IN ('pending', 'scheduled', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_callbacks_due ON callbacks(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_callbacks_scheduled_at ON callbacks(scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_callbacks_call_id ON callbacks(call_id) WHERE call_id IS NOT NULL;
+
+COMMENT ON TABLE callbacks IS 'Callbacks requested by callers, synced to the business calendar';
+COMMENT ON COLUMN callbacks.requested_date IS 'Date as the caller gave it, e.g. Monday';
+COMMENT ON COLUMN callbacks.calendar_event_id IS 'Event ID (Google) or resource URL (CalDAV) of the calendar event';
@@ -0,0 +1,2 @@
+-- Rollback recordings
+DROP TABLE IF EXISTS recordings;
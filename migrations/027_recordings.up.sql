@@ -0,0 +1,31 @@
+-- Recordings: copies of call recordings downloaded from the voice provider
+-- before their URLs expire, deleted again when their retention ends.
+CREATE TABLE IF NOT EXISTS recordings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    source_url TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    storage_backend VARCHAR(20),
+    storage_key VARCHAR(1024),
+    content_type VARCHAR(100),
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    stored_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT recordings_call_id_unique UNIQUE (call_id),
+    CONSTRAINT recordings_status_check CHECK (status IN ('pending', 'stored', 'failed', 'deleted'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_recordings_due ON recordings(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_recordings_expires_at ON recordings(expires_at) WHERE status = 'stored' AND expires_at IS NOT NULL;
+
+COMMENT ON TABLE recordings IS 'Call recordings archived from voice providers, with retention';
+COMMENT ON COLUMN recordings.source_url IS 'Provider recording URL the copy was downloaded from';
+COMMENT ON COLUMN recordings.storage_key IS 'Object key (S3) or relative path (local) of the stored copy';
+COMMENT ON COLUMN recordings.expires_at IS 'When retention ends and the stored copy is deleted; NULL keeps it forever';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+ALTER TABLE sessions DROP COLUMN IF EXISTS two_factor_verified;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_counter;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,23 @@
+-- Optional TOTP two-factor authentication for dashboard users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_counter BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN users.totp_secret IS 'Base32 TOTP secret; set during enrollment, in use once totp_enabled_at is set';
+COMMENT ON COLUMN users.totp_last_counter IS 'Time step of the last accepted code, so a code cannot be used twice';
+
+-- Sessions of two-factor users are unusable until a code is verified
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS two_factor_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_recovery_codes_unused ON user_recovery_codes(user_id) WHERE used_at IS NULL;
+
+COMMENT ON TABLE user_recovery_codes IS 'Single-use two-factor recovery codes; only the SHA-256 hash of each code is stored';
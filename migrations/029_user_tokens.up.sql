@@ -0,0 +1,24 @@
+-- Email verification for dashboard users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+-- Existing users signed in before verification existed
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+COMMENT ON COLUMN users.email_verified_at IS 'When the user proved they receive mail at their address; NULL blocks login while account email is configured';
+
+-- Single-use links emailed to users, such as password resets
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_purpose ON user_tokens(user_id, purpose);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_expires_at ON user_tokens(expires_at);
+
+COMMENT ON TABLE user_tokens IS 'Password reset and email verification tokens; only the SHA-256 hash of each token is stored';
+COMMENT ON COLUMN user_tokens.purpose IS 'password_reset or email_verification';
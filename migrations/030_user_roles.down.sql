@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_email_active_unique;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+COMMENT ON COLUMN user_tokens.purpose IS 'password_reset or email_verification';
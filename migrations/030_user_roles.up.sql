@@ -0,0 +1,18 @@
+-- Roles and disabling for dashboard users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'member'));
+
+-- Users created before roles existed managed everything
+UPDATE users SET role = 'admin';
+
+-- Email only needs to be unique among active users, so a deleted user's
+-- address can be invited again
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active_unique ON users(email) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.role IS 'admin manages users and settings; member works with calls, quotes and campaigns';
+COMMENT ON COLUMN users.disabled_at IS 'When an admin disabled the user; NULL means the user can log in';
+COMMENT ON COLUMN user_tokens.purpose IS 'password_reset, email_verification or invitation';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bland_sync_state;
+DROP TABLE IF EXISTS bland_entities;
@@ -0,0 +1,29 @@
+-- Local copies of Bland voices, personas, pathways, knowledge bases and
+-- phone numbers, refreshed by the sync worker so list endpoints and admin
+-- pages don't call Bland on every request
+CREATE TABLE IF NOT EXISTS bland_entities (
+    kind VARCHAR(32) NOT NULL,
+    bland_id VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    payload JSONB NOT NULL,
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, bland_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bland_entities_kind_position ON bland_entities(kind, position);
+
+CREATE TABLE IF NOT EXISTS bland_sync_state (
+    kind VARCHAR(32) PRIMARY KEY,
+    etag VARCHAR(64) NOT NULL DEFAULT '',
+    item_count INTEGER NOT NULL DEFAULT 0,
+    synced_at TIMESTAMP WITH TIME ZONE,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+COMMENT ON TABLE bland_entities IS 'Cached Bland entities, stored as returned by the Bland API';
+COMMENT ON COLUMN bland_entities.kind IS 'voices, personas, pathways, knowledge_bases or phone_numbers';
+COMMENT ON COLUMN bland_entities.position IS 'Order in the Bland list response';
+COMMENT ON COLUMN bland_sync_state.etag IS 'Hash of the cached payloads; changes only when the data does';
+COMMENT ON COLUMN bland_sync_state.synced_at IS 'Last successful sync; NULL if no sync has succeeded';
+COMMENT ON COLUMN bland_sync_state.last_error IS 'Error from the last failed sync; cleared by a successful one';
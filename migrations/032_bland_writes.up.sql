@@ -0,0 +1,22 @@
+-- Changes to Bland made while its API was unreachable, replayed in order
+-- once it recovers
+CREATE TABLE IF NOT EXISTS bland_writes (
+    id UUID PRIMARY KEY,
+    operation VARCHAR(64) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    payload JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT bland_writes_status_check CHECK (status IN ('pending', 'applied', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bland_writes_pending ON bland_writes(created_at) WHERE status = 'pending';
+
+COMMENT ON TABLE bland_writes IS 'Bland writes queued while the Bland circuit breaker was open';
+COMMENT ON COLUMN bland_writes.operation IS 'Queued operation, e.g. knowledge_base.update or number.block';
+COMMENT ON COLUMN bland_writes.target_id IS 'Bland ID of the entity changed, if any';
+COMMENT ON COLUMN bland_writes.attempts IS 'Times Bland rejected the write; it fails after 5';
@@ -0,0 +1,2 @@
+ALTER TABLE pathway_versions DROP COLUMN IF EXISTS description;
+ALTER TABLE pathway_versions DROP COLUMN IF EXISTS name;
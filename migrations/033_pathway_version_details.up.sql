@@ -0,0 +1,7 @@
+-- Pathway snapshots taken from Bland also record the name and description,
+-- so rolling back restores them along with the nodes and edges
+ALTER TABLE pathway_versions ADD COLUMN IF NOT EXISTS name VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pathway_versions ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN pathway_versions.nodes IS 'Nodes as Bland returned them when the snapshot was taken';
+COMMENT ON COLUMN pathway_versions.edges IS 'Edges as Bland returned them when the snapshot was taken';
@@ -0,0 +1,17 @@
+-- The content prompts had before each update, so edits can be reviewed and
+-- rolled back
+CREATE TABLE IF NOT EXISTS prompt_versions (
+    id UUID PRIMARY KEY,
+    prompt_id UUID NOT NULL REFERENCES prompts(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    content JSONB NOT NULL,
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (prompt_id, version)
+);
+
+COMMENT ON TABLE prompt_versions IS 'Prompt content replaced by updates, newest version last';
+COMMENT ON COLUMN prompt_versions.content IS 'The prompt as it was before the update, as JSON';
+COMMENT ON COLUMN prompt_versions.changed_fields IS 'Fields the update changed';
+COMMENT ON COLUMN prompt_versions.changed_by IS 'Email of the user who made the update';
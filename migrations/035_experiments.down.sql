@@ -0,0 +1,8 @@
+-- Rollback prompt A/B experiments
+DROP INDEX IF EXISTS idx_experiment_assignments_experiment;
+DROP TABLE IF EXISTS experiment_assignments;
+DROP TABLE IF EXISTS experiment_variants;
+
+DROP INDEX IF EXISTS idx_experiments_running;
+DROP INDEX IF EXISTS idx_experiments_created_at;
+DROP TABLE IF EXISTS experiments;
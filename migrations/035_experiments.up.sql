@@ -0,0 +1,50 @@
+-- Prompt A/B experiments: calls split between prompts by percentage
+CREATE TABLE IF NOT EXISTS experiments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    direction VARCHAR(20) NOT NULL,                -- inbound, outbound
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',   -- draft, running, stopped
+    phone_number VARCHAR(50),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ,
+    stopped_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT experiments_direction_check CHECK (direction IN ('inbound', 'outbound')),
+    CONSTRAINT experiments_phone_number_check CHECK (direction = 'outbound' OR phone_number IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiments_running ON experiments(direction) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_experiments_created_at ON experiments(created_at DESC);
+
+CREATE TABLE IF NOT EXISTS experiment_variants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    experiment_id UUID NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    prompt_id UUID NOT NULL REFERENCES prompts(id),
+    weight INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+
+    CONSTRAINT experiment_variants_weight_check CHECK (weight BETWEEN 1 AND 100),
+    CONSTRAINT experiment_variants_unique_position UNIQUE (experiment_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS experiment_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    experiment_id UUID NOT NULL REFERENCES experiments(id) ON DELETE CASCADE,
+    variant_id UUID NOT NULL REFERENCES experiment_variants(id) ON DELETE CASCADE,
+    call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT experiment_assignments_unique_call UNIQUE (call_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiment_assignments_experiment ON experiment_assignments(experiment_id, variant_id);
+
+COMMENT ON TABLE experiments IS 'Prompt A/B experiments splitting inbound or outbound calls between variants';
+COMMENT ON COLUMN experiments.phone_number IS 'Inbound number whose agent is rotated between the variants';
+COMMENT ON COLUMN experiment_variants.weight IS 'Percentage of the experiment''s calls given this variant; the weights add up to 100';
+COMMENT ON COLUMN experiment_variants.position IS 'Variant order; position 0 is the control';
+COMMENT ON TABLE experiment_assignments IS 'The experiment variant each call was given';
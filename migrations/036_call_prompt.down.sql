@@ -0,0 +1,4 @@
+-- Rollback call prompt
+DROP INDEX IF EXISTS idx_calls_provider_created_at;
+DROP INDEX IF EXISTS idx_calls_prompt_id;
+ALTER TABLE calls DROP COLUMN IF EXISTS prompt_id;
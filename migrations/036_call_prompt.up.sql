@@ -0,0 +1,9 @@
+-- Record the prompt (preset) an outbound call was placed with, so call
+-- metrics can be broken down by prompt.
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS prompt_id UUID REFERENCES prompts(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_prompt_id ON calls(prompt_id, created_at DESC) WHERE prompt_id IS NOT NULL;
+
+-- Analytics aggregate calls by creation time, optionally for one provider.
+CREATE INDEX IF NOT EXISTS idx_calls_provider_created_at ON calls(provider, created_at DESC) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN calls.prompt_id IS 'Prompt the call was placed with, if any';
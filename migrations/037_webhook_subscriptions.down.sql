@@ -0,0 +1,7 @@
+-- Rollback outgoing webhooks
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription;
+DROP INDEX IF EXISTS idx_webhook_deliveries_due;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP INDEX IF EXISTS idx_webhook_subscriptions_active;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,4 @@
+-- Rollback events feed
+DROP INDEX IF EXISTS idx_events_created_at;
+DROP INDEX IF EXISTS idx_events_type;
+DROP TABLE IF EXISTS events;
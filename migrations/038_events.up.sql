@@ -0,0 +1,17 @@
+-- Events feed: an append-only log of changes to calls and quotes that
+-- integrations poll with a cursor.
+CREATE TABLE IF NOT EXISTS events (
+    sequence BIGSERIAL PRIMARY KEY,
+    id UUID NOT NULL UNIQUE,
+    type VARCHAR(50) NOT NULL,
+    call_id UUID,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_type ON events(type, sequence);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
+
+COMMENT ON TABLE events IS 'Change feed of calls and quotes served by GET /api/v1/events';
+COMMENT ON COLUMN events.sequence IS 'Feed position; cursors encode the last sequence a client has seen';
+COMMENT ON COLUMN events.id IS 'Event ID, shared with the outgoing webhook deliveries of the event';
@@ -0,0 +1,4 @@
+-- Rollback quote job leases
+DROP INDEX IF EXISTS idx_quote_jobs_lease;
+ALTER TABLE quote_jobs DROP COLUMN IF EXISTS lease_expires_at;
+ALTER TABLE quote_jobs DROP COLUMN IF EXISTS locked_by;
@@ -0,0 +1,14 @@
+-- Quote job leases: a worker owns a job while its lease is live, so several
+-- server replicas can share the queue. Jobs whose lease expires while
+-- processing were orphaned by a worker that stopped and are retried.
+ALTER TABLE quote_jobs ADD COLUMN IF NOT EXISTS locked_by VARCHAR(255);
+ALTER TABLE quote_jobs ADD COLUMN IF NOT EXISTS lease_expires_at TIMESTAMPTZ;
+
+-- Jobs processing before leases existed are recovered straight away
+UPDATE quote_jobs SET lease_expires_at = NOW() WHERE status = 'processing';
+
+CREATE INDEX IF NOT EXISTS idx_quote_jobs_lease ON quote_jobs(lease_expires_at)
+    WHERE status = 'processing';
+
+COMMENT ON COLUMN quote_jobs.locked_by IS 'Worker that claimed the job most recently';
+COMMENT ON COLUMN quote_jobs.lease_expires_at IS 'When the claiming worker loses the job unless it renews the lease';
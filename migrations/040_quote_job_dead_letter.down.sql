@@ -0,0 +1,5 @@
+-- Rollback quote job dead-letter queue
+DROP INDEX IF EXISTS idx_quote_jobs_dead;
+UPDATE quote_jobs SET status = 'failed' WHERE status = 'dead';
+CREATE INDEX IF NOT EXISTS idx_quote_jobs_failed ON quote_jobs(completed_at DESC)
+    WHERE status = 'failed';
@@ -0,0 +1,9 @@
+-- Dead-letter queue for quote jobs: jobs that run out of retries are 'dead'
+-- instead of 'failed' and wait there until an admin retries them.
+UPDATE quote_jobs SET status = 'dead' WHERE status = 'failed';
+
+DROP INDEX IF EXISTS idx_quote_jobs_failed;
+CREATE INDEX IF NOT EXISTS idx_quote_jobs_dead ON quote_jobs(updated_at DESC)
+    WHERE status = 'dead';
+
+COMMENT ON INDEX idx_quote_jobs_dead IS 'Optimizes listing the dead-letter queue';
@@ -0,0 +1,5 @@
+-- Rollback budget guard
+DROP INDEX IF EXISTS idx_calls_ended_at;
+DROP TABLE IF EXISTS budget_paused_batches;
+DROP INDEX IF EXISTS idx_budget_overrides_active;
+DROP TABLE IF EXISTS budget_overrides;
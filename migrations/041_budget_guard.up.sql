@@ -0,0 +1,25 @@
+-- Budget guard: admin overrides of the monthly hard cap, and the Bland
+-- batches the guard paused when the cap was hit so they can be resumed.
+CREATE TABLE IF NOT EXISTS budget_overrides (
+    id UUID PRIMARY KEY,
+    reason TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_budget_overrides_active ON budget_overrides(expires_at DESC)
+    WHERE revoked_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS budget_paused_batches (
+    batch_id VARCHAR(255) PRIMARY KEY,
+    paused_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Local spend estimates sum the calls that ended since the last usage sync,
+-- deleted or not, since deleted calls were still billed
+CREATE INDEX IF NOT EXISTS idx_calls_ended_at ON calls(ended_at) WHERE ended_at IS NOT NULL;
+
+COMMENT ON TABLE budget_overrides IS 'Admin overrides that allow calling past the monthly hard cap until expires_at';
+COMMENT ON TABLE budget_paused_batches IS 'Bland batches paused by the budget guard, resumed when an override is granted';
@@ -0,0 +1,11 @@
+-- Rollback per-call cost attribution
+DROP INDEX IF EXISTS idx_calls_cost_month;
+ALTER TABLE calls
+    DROP COLUMN IF EXISTS total_cost,
+    DROP COLUMN IF EXISTS analysis_cost,
+    DROP COLUMN IF EXISTS transcription_cost,
+    DROP COLUMN IF EXISTS call_cost,
+    DROP COLUMN IF EXISTS rate_per_minute,
+    DROP COLUMN IF EXISTS billed_minutes,
+    DROP COLUMN IF EXISTS billed_outbound,
+    DROP COLUMN IF EXISTS billed_number;
@@ -0,0 +1,17 @@
+-- Per-call cost attribution: what each finished call is estimated to have
+-- cost, and the number it is billed to, for monthly rollups per number
+ALTER TABLE calls
+    ADD COLUMN IF NOT EXISTS billed_number VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS billed_outbound BOOLEAN,
+    ADD COLUMN IF NOT EXISTS billed_minutes DECIMAL(10,4),
+    ADD COLUMN IF NOT EXISTS rate_per_minute DECIMAL(10,4),
+    ADD COLUMN IF NOT EXISTS call_cost DECIMAL(10,4),
+    ADD COLUMN IF NOT EXISTS transcription_cost DECIMAL(10,4),
+    ADD COLUMN IF NOT EXISTS analysis_cost DECIMAL(10,4),
+    ADD COLUMN IF NOT EXISTS total_cost DECIMAL(10,4);
+
+CREATE INDEX IF NOT EXISTS idx_calls_cost_month ON calls((COALESCE(ended_at, created_at)))
+    WHERE total_cost IS NOT NULL;
+
+COMMENT ON COLUMN calls.billed_number IS 'Our side of the call: the number called for inbound calls, the number called from for outbound calls';
+COMMENT ON COLUMN calls.total_cost IS 'Estimated cost of the call from the pricing settings when it ended; NULL until then';
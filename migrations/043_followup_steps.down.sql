@@ -0,0 +1,3 @@
+-- Rollback quote follow-up sequences
+ALTER TABLE customers DROP COLUMN IF EXISTS opted_out_at;
+DROP TABLE IF EXISTS followup_steps;
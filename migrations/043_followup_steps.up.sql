@@ -0,0 +1,28 @@
+-- Quote follow-up sequences: the texts and calls scheduled after a quote is
+-- sent, and customers' requests not to be contacted
+CREATE TABLE IF NOT EXISTS followup_steps (
+    id UUID PRIMARY KEY,
+    call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    phone_number VARCHAR(50) NOT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    provider_ref VARCHAR(255),
+    cancel_reason TEXT,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (call_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_followup_steps_due ON followup_steps(next_attempt_at) WHERE status = 'pending';
+
+ALTER TABLE customers ADD COLUMN IF NOT EXISTS opted_out_at TIMESTAMPTZ;
+
+COMMENT ON TABLE followup_steps IS 'Scheduled follow-up texts and calls for sent quotes, keyed by the quote''s call';
+COMMENT ON COLUMN followup_steps.next_attempt_at IS 'When the worker next tries the step; pushed back for quiet hours, retries, and while a worker holds it';
+COMMENT ON COLUMN customers.opted_out_at IS 'When the customer asked not to be contacted; NULL if they have not';
@@ -0,0 +1,2 @@
+-- Rollback the do-not-call list
+DROP TABLE IF EXISTS dnc_numbers;
@@ -0,0 +1,16 @@
+-- Do-not-call list: numbers staff added, customers who texted STOP, and the
+-- national registry
+CREATE TABLE IF NOT EXISTS dnc_numbers (
+    phone_number VARCHAR(50) PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    reason TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    imported_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dnc_numbers_national ON dnc_numbers(imported_at) WHERE source = 'national';
+
+COMMENT ON TABLE dnc_numbers IS 'Numbers calls may not be placed to';
+COMMENT ON COLUMN dnc_numbers.source IS 'manual, sms_opt_out, or national';
+COMMENT ON COLUMN dnc_numbers.imported_at IS 'For national entries, the import that last listed the number; a replacing import prunes entries older than itself';
@@ -0,0 +1,33 @@
+-- Audit events: the security and admin audit trail, kept so it can be
+-- searched as well as logged
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    type VARCHAR(100) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255),
+    actor_type VARCHAR(50),
+    actor_name VARCHAR(255),
+    source_ip VARCHAR(100),
+    user_agent TEXT,
+    request_id VARCHAR(255),
+    session_id VARCHAR(255),
+    resource_type VARCHAR(100),
+    resource_id VARCHAR(255),
+    action TEXT,
+    outcome VARCHAR(50),
+    reason TEXT,
+    before JSONB,
+    after JSONB,
+    metadata JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events(occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_resource ON audit_events(resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_type ON audit_events(type);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request ON audit_events(request_id);
+
+COMMENT ON TABLE audit_events IS 'Security and admin audit trail';
+COMMENT ON COLUMN audit_events.before IS 'State of the resource before the change, if the event is a change';
+COMMENT ON COLUMN audit_events.after IS 'State of the resource after the change, if the event is a change';
@@ -0,0 +1,11 @@
+-- Rollback call list indexes
+DROP INDEX IF EXISTS idx_calls_from_number_trgm;
+DROP INDEX IF EXISTS idx_calls_phone_number_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_calls_provider_created_at ON calls(provider, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_status_created ON calls(status, created_at DESC);
+
+DROP INDEX IF EXISTS idx_calls_quoted_list;
+DROP INDEX IF EXISTS idx_calls_provider_list;
+DROP INDEX IF EXISTS idx_calls_status_list;
+DROP INDEX IF EXISTS idx_calls_list;
@@ -0,0 +1,22 @@
+-- Indexes for keyset pagination of the call list: each filter that narrows
+-- the list gets an index in (created_at, id) order, so a page is read from
+-- the index in either direction without sorting, even with millions of calls.
+CREATE INDEX IF NOT EXISTS idx_calls_list ON calls(created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_status_list ON calls(status, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_provider_list ON calls(provider, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_quoted_list ON calls(created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND quote_summary IS NOT NULL AND quote_summary <> '';
+
+-- These cover the indexes they replace
+DROP INDEX IF EXISTS idx_calls_status_created;
+DROP INDEX IF EXISTS idx_calls_provider_created_at;
+
+-- The phone number filter matches substrings of either number
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_calls_phone_number_trgm ON calls USING GIN (phone_number gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_calls_from_number_trgm ON calls USING GIN (from_number gin_trgm_ops);
+
+COMMENT ON INDEX idx_calls_list IS 'Keyset pagination of the call list';
+COMMENT ON INDEX idx_calls_status_list IS 'Keyset pagination of the call list filtered by status';
+COMMENT ON INDEX idx_calls_provider_list IS 'Keyset pagination of the call list filtered by provider; also serves analytics';
+COMMENT ON INDEX idx_calls_quoted_list IS 'Keyset pagination of calls with a quote';
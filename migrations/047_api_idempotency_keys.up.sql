@@ -0,0 +1,21 @@
+-- Responses to mutating API requests sent with an Idempotency-Key header,
+-- replayed when a client retries with the same key
+CREATE TABLE IF NOT EXISTS api_idempotency_keys (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER,
+    content_type TEXT,
+    location TEXT,
+    body BYTEA,
+    locked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_idempotency_keys_expires_at
+    ON api_idempotency_keys (expires_at);
+
+COMMENT ON TABLE api_idempotency_keys IS 'Stored responses for API requests sent with an Idempotency-Key header';
+COMMENT ON COLUMN api_idempotency_keys.key IS 'User ID and client key';
+COMMENT ON COLUMN api_idempotency_keys.completed_at IS 'When the response was stored; NULL while the first request is running';
@@ -0,0 +1,3 @@
+-- Rollback voicemail and no-answer retries
+DROP TABLE IF EXISTS call_attempts;
+ALTER TABLE prompts DROP COLUMN IF EXISTS retry_policy;
@@ -0,0 +1,29 @@
+-- Voicemail and no-answer retries: per-prompt retry policies and the
+-- attempts scheduled from them
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS retry_policy JSONB;
+
+CREATE TABLE IF NOT EXISTS call_attempts (
+    id UUID PRIMARY KEY,
+    original_call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    previous_call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    prompt_id UUID NOT NULL REFERENCES prompts(id),
+    phone_number VARCHAR(50) NOT NULL,
+    attempt INTEGER NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    last_error TEXT,
+    cancel_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (previous_call_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_call_attempts_due ON call_attempts(scheduled_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_call_attempts_phone ON call_attempts(phone_number) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_call_attempts_call_id ON call_attempts(call_id);
+
+COMMENT ON COLUMN prompts.retry_policy IS 'How calls placed with the prompt are re-dialed after voicemail or no answer; NULL for no retries';
+COMMENT ON TABLE call_attempts IS 'Retries of calls that went to voicemail or were not answered, one per unanswered call';
+COMMENT ON COLUMN call_attempts.scheduled_at IS 'When the worker next dials the attempt; pushed back for the calling window and while a worker holds it';
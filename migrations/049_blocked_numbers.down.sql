@@ -0,0 +1,2 @@
+-- Rollback local blocklist mirror
+DROP TABLE IF EXISTS blocked_numbers;
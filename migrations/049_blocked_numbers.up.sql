@@ -0,0 +1,17 @@
+-- Local mirror of the blocklist, so blocked callers can be turned away
+-- without asking Bland, and prefix blocks Bland doesn't support
+CREATE TABLE IF NOT EXISTS blocked_numbers (
+    id UUID PRIMARY KEY,
+    phone_number VARCHAR(50) NOT NULL UNIQUE,
+    direction VARCHAR(10) NOT NULL DEFAULT 'both',
+    reason TEXT,
+    bland_id VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocked_numbers_bland_id ON blocked_numbers(bland_id) WHERE bland_id IS NOT NULL;
+
+COMMENT ON TABLE blocked_numbers IS 'Blocked numbers and prefixes; single numbers are also blocked at Bland';
+COMMENT ON COLUMN blocked_numbers.phone_number IS 'E.164 number, or a prefix ending in * such as +1900*';
+COMMENT ON COLUMN blocked_numbers.bland_id IS 'Bland''s ID for the block; NULL for prefixes and blocks Bland has not confirmed';
@@ -0,0 +1,2 @@
+-- Rollback caller ID health
+DROP TABLE IF EXISTS number_health;
@@ -0,0 +1,20 @@
+-- Caller ID health: the last reputation and delivery check of each of our
+-- numbers, and the dialing pools flagged numbers were taken out of
+CREATE TABLE IF NOT EXISTS number_health (
+    phone_number VARCHAR(50) PRIMARY KEY,
+    score INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    spam_likely BOOLEAN NOT NULL DEFAULT FALSE,
+    label TEXT,
+    calls INTEGER NOT NULL DEFAULT 0,
+    answer_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    short_call_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rotated_pools JSONB,
+    checked_at TIMESTAMPTZ NOT NULL,
+    status_changed_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT number_health_status_check CHECK (status IN ('healthy', 'degraded', 'flagged'))
+);
+
+COMMENT ON TABLE number_health IS 'Last health check of each owned number';
+COMMENT ON COLUMN number_health.score IS '0 to 100, higher is healthier; below 40 is flagged';
+COMMENT ON COLUMN number_health.rotated_pools IS 'Dialing pool ID to the number''s weight there, for pools it was taken out of while flagged';
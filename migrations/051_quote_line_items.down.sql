@@ -0,0 +1,7 @@
+-- Rollback structured quote content
+ALTER TABLE quotes DROP CONSTRAINT IF EXISTS quotes_tax_rate_check;
+
+ALTER TABLE quotes
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS line_items;
@@ -0,0 +1,12 @@
+-- Structured quote content edited by staff before sending
+ALTER TABLE quotes
+    ADD COLUMN IF NOT EXISTS line_items JSONB,
+    ADD COLUMN IF NOT EXISTS tax_rate NUMERIC(6,3) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS notes TEXT;
+
+ALTER TABLE quotes
+    ADD CONSTRAINT quotes_tax_rate_check CHECK (tax_rate >= 0 AND tax_rate <= 100);
+
+COMMENT ON COLUMN quotes.line_items IS 'Edited line items (description, quantity, unit_price, amount); NULL until edited, when the generated quote text is priced instead';
+COMMENT ON COLUMN quotes.tax_rate IS 'Tax percent applied to the line item subtotal';
+COMMENT ON COLUMN quotes.notes IS 'Notes shown to the customer on the quote';
@@ -0,0 +1,2 @@
+-- Rollback customer quote links
+DROP TABLE IF EXISTS quote_links;
@@ -0,0 +1,14 @@
+-- Links customers follow to view, accept or decline their quote without signing in
+CREATE TABLE IF NOT EXISTS quote_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL UNIQUE REFERENCES quotes(call_id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE quote_links IS 'Customer quote links; creating a new link for a quote replaces the old one';
+COMMENT ON COLUMN quote_links.token_hash IS 'SHA-256 hash of the token in the link; the token itself is not stored';
+COMMENT ON COLUMN quote_links.viewed_at IS 'First time the customer opened the link';
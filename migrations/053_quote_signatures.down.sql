@@ -0,0 +1,6 @@
+-- Rollback customer quote signatures
+ALTER TABLE quotes
+    DROP COLUMN IF EXISTS signed_at,
+    DROP COLUMN IF EXISTS signed_ip,
+    DROP COLUMN IF EXISTS signature_image,
+    DROP COLUMN IF EXISTS signer_name;
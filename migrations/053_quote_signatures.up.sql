@@ -0,0 +1,11 @@
+-- Customer signatures captured when a quote is accepted
+ALTER TABLE quotes
+    ADD COLUMN IF NOT EXISTS signer_name TEXT,
+    ADD COLUMN IF NOT EXISTS signature_image BYTEA,
+    ADD COLUMN IF NOT EXISTS signed_ip TEXT,
+    ADD COLUMN IF NOT EXISTS signed_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN quotes.signer_name IS 'Name the customer typed to sign the quote';
+COMMENT ON COLUMN quotes.signature_image IS 'PNG of the signature the customer drew, if any';
+COMMENT ON COLUMN quotes.signed_ip IS 'IP address the quote was signed from';
+COMMENT ON COLUMN quotes.signed_at IS 'When the customer signed the quote';
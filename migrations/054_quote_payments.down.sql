@@ -0,0 +1,2 @@
+-- Rollback quote payments
+DROP TABLE IF EXISTS quote_payments;
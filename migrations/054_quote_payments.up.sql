@@ -0,0 +1,23 @@
+-- Payments collected for accepted quotes through a payment processor
+CREATE TABLE IF NOT EXISTS quote_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL UNIQUE REFERENCES quotes(call_id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    provider_link_id VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    amount NUMERIC(12,2) NOT NULL CHECK (amount > 0),
+    deposit_percent NUMERIC(5,2) NOT NULL CHECK (deposit_percent > 0 AND deposit_percent <= 100),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid')),
+    payment_id VARCHAR(255),
+    amount_paid NUMERIC(12,2),
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quote_payments_provider_link ON quote_payments(provider, provider_link_id);
+
+COMMENT ON TABLE quote_payments IS 'Payment links for accepted quotes; creating a new link for a quote replaces the old one';
+COMMENT ON COLUMN quote_payments.deposit_percent IS 'Share of the quote total requested; 100 for the full amount';
+COMMENT ON COLUMN quote_payments.payment_id IS 'The processor''s ID for the completed payment';
@@ -0,0 +1,4 @@
+-- Rollback accounting sync
+DROP TABLE IF EXISTS accounting_syncs;
+DROP TABLE IF EXISTS accounting_mappings;
+DROP TABLE IF EXISTS accounting_connections;
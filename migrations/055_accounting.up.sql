@@ -0,0 +1,42 @@
+-- OAuth grants for the connected accounting company or organisation, one per provider
+CREATE TABLE IF NOT EXISTS accounting_connections (
+    provider VARCHAR(32) PRIMARY KEY,
+    tenant_id VARCHAR(255) NOT NULL,
+    access_token TEXT NOT NULL,
+    refresh_token TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    connected_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- How quotes map onto the accounting system's items and tax codes
+CREATE TABLE IF NOT EXISTS accounting_mappings (
+    provider VARCHAR(32) PRIMARY KEY,
+    default_item VARCHAR(100) NOT NULL DEFAULT '',
+    project_type_items JSONB NOT NULL DEFAULT '{}',
+    tax_code VARCHAR(100) NOT NULL DEFAULT '',
+    exempt_tax_code VARCHAR(100) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Each attempt to push a quote to the accounting system
+CREATE TABLE IF NOT EXISTS accounting_syncs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL REFERENCES quotes(call_id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    document VARCHAR(16) NOT NULL CHECK (document IN ('estimate', 'invoice')),
+    status VARCHAR(16) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    external_id VARCHAR(255),
+    external_number VARCHAR(255),
+    error TEXT,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_accounting_syncs_call_id ON accounting_syncs(call_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_accounting_syncs_created_at ON accounting_syncs(created_at DESC);
+
+COMMENT ON COLUMN accounting_connections.tenant_id IS 'QuickBooks realm ID or Xero tenant ID';
+COMMENT ON COLUMN accounting_mappings.project_type_items IS 'Item code for each lower-case project type';
+COMMENT ON COLUMN accounting_syncs.actor_id IS 'User who pushed the quote; NULL for automatic syncs on acceptance';
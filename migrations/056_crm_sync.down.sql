@@ -0,0 +1,3 @@
+-- Rollback CRM sync
+DELETE FROM settings WHERE key = 'crm_field_mapping';
+DROP TABLE IF EXISTS crm_syncs;
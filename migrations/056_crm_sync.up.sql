@@ -0,0 +1,25 @@
+-- CRM contact and deal sync state, one row per call
+CREATE TABLE IF NOT EXISTS crm_syncs (
+    provider VARCHAR(32) NOT NULL,
+    call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    contact_id VARCHAR(255) NOT NULL DEFAULT '',
+    deal_id VARCHAR(255) NOT NULL DEFAULT '',
+    last_error TEXT,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    synced_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (provider, call_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_crm_syncs_due ON crm_syncs(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_crm_syncs_status ON crm_syncs(provider, status, updated_at DESC);
+
+COMMENT ON COLUMN crm_syncs.requested_at IS 'When the sync was last queued; a sync queued again mid-attempt stays pending';
+
+INSERT INTO settings (key, value, value_type, category, description) VALUES
+    ('crm_field_mapping', '{}', 'json', 'crm', 'Call and quote fields copied to CRM contact and deal fields, and the deal stage for each quote status')
+ON CONFLICT (key) DO NOTHING;
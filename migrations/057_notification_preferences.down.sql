@@ -0,0 +1,2 @@
+-- Rollback per-user notification preferences
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,16 @@
+-- Per-user notification preferences: the events each user is notified about
+-- by email, SMS and personal Slack webhook
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_events TEXT[] NOT NULL DEFAULT '{}',
+    sms_events TEXT[] NOT NULL DEFAULT '{}',
+    slack_events TEXT[] NOT NULL DEFAULT '{}',
+    phone VARCHAR(20) NOT NULL DEFAULT '',
+    slack_webhook_url TEXT NOT NULL DEFAULT '',  -- Incoming webhook URLs are secrets
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE notification_preferences IS 'Events each user is notified about, by channel, in addition to the staff recipients';
+COMMENT ON COLUMN notification_preferences.email_events IS 'Event types emailed to the user, e.g. quote_ready, usage_alert';
+COMMENT ON COLUMN notification_preferences.phone IS 'E.164 number SMS notifications are sent to';
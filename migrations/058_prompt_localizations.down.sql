@@ -0,0 +1,2 @@
+-- Rollback prompt localization
+ALTER TABLE prompts DROP COLUMN IF EXISTS localizations;
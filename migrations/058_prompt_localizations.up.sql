@@ -0,0 +1,5 @@
+-- Prompt localization: the prompt's text in other languages, picked per call
+-- from the caller's language or the country of the number dialed
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS localizations JSONB;
+
+COMMENT ON COLUMN prompts.localizations IS 'Task, greeting, voicemail, summary prompt and voice overrides keyed by language tag (es, es-MX); NULL when the prompt has none';
@@ -0,0 +1,3 @@
+-- Rollback multi-currency quotes
+DELETE FROM settings WHERE key = 'currency';
+ALTER TABLE quotes DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,9 @@
+-- Multi-currency quotes: each quote records the currency its amounts are in,
+-- and the deployment's default currency becomes a setting
+ALTER TABLE quotes ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+COMMENT ON COLUMN quotes.currency IS 'ISO 4217 currency of total_amount and the line items';
+
+INSERT INTO settings (key, value, value_type, category, description) VALUES
+    ('currency', '', 'string', 'business', 'ISO 4217 currency new quotes are priced in; empty for QUOTE_PDF_CURRENCY')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,2 @@
+-- Rollback business schedule
+DELETE FROM settings WHERE key = 'business_schedule';
@@ -0,0 +1,5 @@
+-- Business hours and holidays: inbound numbers are switched to an after
+-- hours agent outside them
+INSERT INTO settings (key, value, value_type, category, description) VALUES
+    ('business_schedule', '{}', 'json', 'schedule', 'Business hours, holidays, the inbound numbers they apply to and how those numbers answer after hours')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,2 @@
+-- Rollback inbound routing rules
+DROP TABLE IF EXISTS routing_rules;
@@ -0,0 +1,21 @@
+-- Inbound routing rules: callers from some area codes or states get a
+-- regional prompt or are transferred to regional staff
+CREATE TABLE IF NOT EXISTS routing_rules (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    area_codes TEXT[] NOT NULL DEFAULT '{}',
+    regions TEXT[] NOT NULL DEFAULT '{}',
+    prompt_id UUID REFERENCES prompts(id) ON DELETE SET NULL,
+    transfer_number VARCHAR(50),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_routing_rules_priority ON routing_rules(priority, name);
+
+COMMENT ON TABLE routing_rules IS 'Rules routing inbound callers by area code or state; the first active match by priority applies';
+COMMENT ON COLUMN routing_rules.regions IS 'US state and territory or Canadian province postal abbreviations';
+COMMENT ON COLUMN routing_rules.prompt_id IS 'Prompt whose instructions the agent is given for matching callers';
+COMMENT ON COLUMN routing_rules.transfer_number IS 'E.164 number matching callers are transferred to';
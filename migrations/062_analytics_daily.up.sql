@@ -0,0 +1,23 @@
+-- Nightly rollups of call metrics by prompt and by inbound number, so
+-- dashboards compare them over long ranges without scanning every call
+CREATE TABLE IF NOT EXISTS analytics_daily (
+    day DATE NOT NULL,
+    dimension VARCHAR(20) NOT NULL,
+    dimension_key VARCHAR(255) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    completed_calls INTEGER NOT NULL DEFAULT 0,
+    no_answer_calls INTEGER NOT NULL DEFAULT 0,
+    quoted_calls INTEGER NOT NULL DEFAULT 0,
+    accepted_quotes INTEGER NOT NULL DEFAULT 0,
+    completed_duration_seconds BIGINT NOT NULL DEFAULT 0,
+    rolled_up_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (dimension, day, dimension_key, provider),
+    CONSTRAINT analytics_daily_dimension_check CHECK (dimension IN ('prompt', 'number'))
+);
+
+COMMENT ON TABLE analytics_daily IS 'Call metrics for each UTC day by prompt or inbound number, recomputed nightly';
+COMMENT ON COLUMN analytics_daily.dimension_key IS 'Prompt ID, empty for calls without a known prompt, or the number called';
+COMMENT ON COLUMN analytics_daily.accepted_quotes IS 'Quotes of the day''s calls accepted by the time of the rollup';
+COMMENT ON COLUMN analytics_daily.completed_duration_seconds IS 'Total length of completed calls';
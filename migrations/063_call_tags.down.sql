@@ -0,0 +1,9 @@
+-- Rollback call tags
+DROP INDEX IF EXISTS idx_calls_sentiment;
+DROP INDEX IF EXISTS idx_calls_intent;
+
+ALTER TABLE calls
+    DROP COLUMN IF EXISTS tagged_at,
+    DROP COLUMN IF EXISTS urgency,
+    DROP COLUMN IF EXISTS intent,
+    DROP COLUMN IF EXISTS sentiment;
@@ -0,0 +1,15 @@
+-- Sentiment, intent and urgency classified from each completed call's
+-- transcript
+ALTER TABLE calls
+    ADD COLUMN IF NOT EXISTS sentiment VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS intent VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS urgency VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS tagged_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_calls_intent ON calls (intent, created_at) WHERE intent IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_calls_sentiment ON calls (sentiment, created_at) WHERE sentiment IS NOT NULL;
+
+COMMENT ON COLUMN calls.sentiment IS 'positive, neutral or negative; NULL until the call is tagged';
+COMMENT ON COLUMN calls.intent IS 'new_project, pricing_question, complaint, spam or other';
+COMMENT ON COLUMN calls.urgency IS 'low, medium or high';
+COMMENT ON COLUMN calls.tagged_at IS 'When the tags were classified from the transcript';
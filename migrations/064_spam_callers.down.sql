@@ -0,0 +1,6 @@
+-- Rollback spam scoring
+DROP TABLE IF EXISTS spam_callers;
+
+DROP INDEX IF EXISTS idx_calls_spam_score;
+
+ALTER TABLE calls DROP COLUMN IF EXISTS spam_score;
@@ -0,0 +1,29 @@
+-- Spam scores of inbound calls, and the review queue of callers flagged as
+-- spam
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS spam_score SMALLINT;
+
+CREATE INDEX IF NOT EXISTS idx_calls_spam_score ON calls (spam_score, created_at) WHERE spam_score IS NOT NULL;
+
+COMMENT ON COLUMN calls.spam_score IS '0 to 100, higher is more likely spam; NULL for outbound calls and calls not screened';
+
+CREATE TABLE IF NOT EXISTS spam_callers (
+    id UUID PRIMARY KEY,
+    phone_number VARCHAR(50) NOT NULL UNIQUE,
+    score SMALLINT NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    flagged_calls INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'blocked', 'dismissed')),
+    auto_blocked BOOLEAN NOT NULL DEFAULT false,
+    first_flagged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_flagged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_spam_callers_status ON spam_callers (status, last_flagged_at DESC);
+
+COMMENT ON TABLE spam_callers IS 'Callers whose inbound calls were scored as spam, for review';
+COMMENT ON COLUMN spam_callers.score IS 'Spam score of the latest flagged call';
+COMMENT ON COLUMN spam_callers.reasons IS 'Why the latest flagged call was scored as spam';
+COMMENT ON COLUMN spam_callers.auto_blocked IS 'Blocked automatically after repeated flagged calls rather than by a reviewer';
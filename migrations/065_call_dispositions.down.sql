@@ -0,0 +1,6 @@
+-- Rollback call dispositions
+DROP INDEX IF EXISTS idx_calls_disposition;
+
+ALTER TABLE calls
+    DROP COLUMN IF EXISTS disposition_source,
+    DROP COLUMN IF EXISTS disposition;
@@ -0,0 +1,10 @@
+-- How each call ended for the business, in one vocabulary across voice
+-- providers
+ALTER TABLE calls
+    ADD COLUMN IF NOT EXISTS disposition VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS disposition_source VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS idx_calls_disposition ON calls (disposition, created_at) WHERE disposition IS NOT NULL;
+
+COMMENT ON COLUMN calls.disposition IS 'quoted, callback_scheduled, not_interested, wrong_number, voicemail or spam';
+COMMENT ON COLUMN calls.disposition_source IS 'auto (provider data, spam score or quote), ai (inferred from the transcript) or manual';
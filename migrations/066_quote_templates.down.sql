@@ -0,0 +1,2 @@
+-- Rollback quote templates
+DROP TABLE IF EXISTS quote_templates;
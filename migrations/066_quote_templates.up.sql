@@ -0,0 +1,19 @@
+-- Quote templates: the structure of quotes per project type.
+-- sections is a JSON array of {heading, body}; default_line_items is a JSON
+-- array of {description, quantity, unit_price}. The template for project
+-- type 'default' is used for calls whose project type has none of its own.
+CREATE TABLE IF NOT EXISTS quote_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_type VARCHAR(100) NOT NULL,  -- lowercase, single-spaced
+    name VARCHAR(255) NOT NULL,
+    sections JSONB NOT NULL DEFAULT '[]',
+    default_line_items JSONB NOT NULL DEFAULT '[]',
+    assumptions TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT quote_templates_project_type_key UNIQUE (project_type)
+);
+
+COMMENT ON TABLE quote_templates IS 'Sections, default line items and assumptions applied to quotes by project type';
@@ -0,0 +1,5 @@
+-- Rollback WhatsApp
+DROP TABLE IF EXISTS conversation_messages;
+DROP TABLE IF EXISTS whatsapp_opt_ins;
+
+ALTER TABLE customers DROP COLUMN IF EXISTS preferred_channel;
@@ -0,0 +1,36 @@
+-- WhatsApp alongside SMS: each customer's preferred messaging channel,
+-- their WhatsApp opt-in, and the messages exchanged on either channel
+ALTER TABLE customers
+    ADD COLUMN IF NOT EXISTS preferred_channel VARCHAR(20) NOT NULL DEFAULT 'sms';
+
+COMMENT ON COLUMN customers.preferred_channel IS 'sms or whatsapp; whatsapp is only used while the customer is opted in';
+
+-- WhatsApp requires a recorded opt-in before business-initiated messages.
+-- Opting out keeps the row with opted_out_at set.
+CREATE TABLE IF NOT EXISTS whatsapp_opt_ins (
+    phone_number VARCHAR(20) PRIMARY KEY,  -- E.164
+    source VARCHAR(20) NOT NULL,           -- staff, whatsapp
+    opted_in_at TIMESTAMPTZ NOT NULL,
+    opted_out_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE whatsapp_opt_ins IS 'Customers who agreed to receive WhatsApp messages, and when they withdrew';
+
+CREATE TABLE IF NOT EXISTS conversation_messages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    phone_number VARCHAR(20) NOT NULL,  -- E.164 number of the customer
+    channel VARCHAR(20) NOT NULL,       -- sms, whatsapp
+    direction VARCHAR(10) NOT NULL,     -- inbound, outbound
+    body TEXT NOT NULL,
+    provider_message_id VARCHAR(255),
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,  -- Quote the message was about
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_messages_phone ON conversation_messages (phone_number, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conversation_messages_created_at ON conversation_messages (created_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversation_messages_provider_id
+    ON conversation_messages (channel, provider_message_id) WHERE provider_message_id IS NOT NULL;
+
+COMMENT ON TABLE conversation_messages IS 'Texts and WhatsApp messages sent to and received from customers';
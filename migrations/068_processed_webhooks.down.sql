@@ -0,0 +1,2 @@
+-- Rollback processed webhooks
+DROP TABLE IF EXISTS processed_webhooks;
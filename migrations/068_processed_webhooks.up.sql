@@ -0,0 +1,14 @@
+-- Provider webhooks already processed, so redeliveries are skipped
+CREATE TABLE IF NOT EXISTS processed_webhooks (
+    provider VARCHAR(50) NOT NULL,
+    event_key TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (provider, event_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_webhooks_expires_at
+    ON processed_webhooks (expires_at);
+
+COMMENT ON TABLE processed_webhooks IS 'Provider webhooks already processed, remembered until they expire so redeliveries are skipped';
+COMMENT ON COLUMN processed_webhooks.event_key IS 'Provider event ID, or the SHA-256 of the payload when the provider sends none';
@@ -0,0 +1,2 @@
+-- Rollback webhook archive
+DROP TABLE IF EXISTS webhook_archive;
@@ -0,0 +1,20 @@
+-- Raw bodies of incoming provider webhooks, kept to debug integrations
+CREATE TABLE IF NOT EXISTS webhook_archive (
+    id UUID PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    path TEXT NOT NULL,
+    content_type TEXT,
+    provider_call_id TEXT,
+    body BYTEA NOT NULL,
+    size INTEGER NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_archive_received_at ON webhook_archive(received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_archive_provider_received_at ON webhook_archive(provider, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_archive_provider_call_id ON webhook_archive(provider_call_id)
+    WHERE provider_call_id IS NOT NULL;
+
+COMMENT ON TABLE webhook_archive IS 'Raw provider webhook bodies as received, removed after the retention period';
+COMMENT ON COLUMN webhook_archive.body IS 'Gzip-compressed request body';
+COMMENT ON COLUMN webhook_archive.size IS 'Uncompressed body size in bytes';
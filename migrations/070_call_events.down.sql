@@ -0,0 +1,3 @@
+-- Rollback call events
+DROP TABLE IF EXISTS call_events;
+DROP FUNCTION IF EXISTS reject_call_event_update();
@@ -0,0 +1,36 @@
+-- Append-only log of the statuses each call was reported in; the call's
+-- status is derived by replaying it
+CREATE TABLE IF NOT EXISTS call_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    call_id UUID NOT NULL REFERENCES calls(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    detail TEXT,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT call_events_source_check CHECK (source IN ('webhook', 'manual', 'system'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_call_events_call_id ON call_events(call_id, created_at);
+
+CREATE OR REPLACE FUNCTION reject_call_event_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'call_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER call_events_append_only
+    BEFORE UPDATE ON call_events
+    FOR EACH ROW EXECUTE FUNCTION reject_call_event_update();
+
+-- Start existing calls' logs from the status they have now
+INSERT INTO call_events (call_id, status, source, detail, occurred_at, created_at)
+SELECT id, status, 'system', 'backfilled', COALESCE(ended_at, started_at, created_at), NOW()
+FROM calls;
+
+COMMENT ON TABLE call_events IS 'Append-only log of call status reports; rows are removed only with their call';
+COMMENT ON COLUMN call_events.source IS 'What reported the status: webhook, manual or system';
+COMMENT ON COLUMN call_events.occurred_at IS 'When the status took effect according to its source';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_calls_archived_at;
+DROP INDEX IF EXISTS idx_calls_active_created_at;
+
+ALTER TABLE quotes
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS archived_at;
+
+ALTER TABLE calls DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,18 @@
+-- Archived calls and quotes are kept but left out of everyday lists, so the
+-- indexes those lists use stay small
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+ALTER TABLE quotes
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Everyday call lists: neither deleted nor archived
+CREATE INDEX IF NOT EXISTS idx_calls_active_created_at ON calls (created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND archived_at IS NULL;
+
+-- The archived view and the archival policy's next batch
+CREATE INDEX IF NOT EXISTS idx_calls_archived_at ON calls (archived_at) WHERE archived_at IS NOT NULL;
+
+COMMENT ON COLUMN calls.archived_at IS 'When the call was archived; archived calls are only listed on request';
+COMMENT ON COLUMN quotes.archived_at IS 'When the quote was archived; archived quotes cannot be edited or moved through the workflow';
+COMMENT ON COLUMN quotes.deleted_at IS 'Set with the call''s deleted_at; a quote is deleted and restored with its call';
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_calls_transcript_retention;
+DROP INDEX IF EXISTS idx_customers_legal_hold;
+DROP INDEX IF EXISTS idx_calls_legal_hold;
+
+ALTER TABLE customers
+    DROP COLUMN IF EXISTS legal_hold_reason,
+    DROP COLUMN IF EXISTS legal_hold_at;
+
+ALTER TABLE calls
+    DROP COLUMN IF EXISTS transcript_purged_at,
+    DROP COLUMN IF EXISTS legal_hold_reason,
+    DROP COLUMN IF EXISTS legal_hold_at;
@@ -0,0 +1,23 @@
+-- Legal holds exempt a call, or every call of a customer, from the retention
+-- rules until the hold is released
+ALTER TABLE calls
+    ADD COLUMN IF NOT EXISTS legal_hold_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS legal_hold_reason TEXT,
+    ADD COLUMN IF NOT EXISTS transcript_purged_at TIMESTAMPTZ;
+
+ALTER TABLE customers
+    ADD COLUMN IF NOT EXISTS legal_hold_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS legal_hold_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_calls_legal_hold ON calls (legal_hold_at) WHERE legal_hold_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_customers_legal_hold ON customers (legal_hold_at) WHERE legal_hold_at IS NOT NULL;
+
+-- The transcript rule's next batch: calls that still hold a transcript
+CREATE INDEX IF NOT EXISTS idx_calls_transcript_retention ON calls (created_at)
+    WHERE transcript_purged_at IS NULL;
+
+COMMENT ON COLUMN calls.legal_hold_at IS 'When the call was placed on legal hold; held calls are exempt from retention';
+COMMENT ON COLUMN calls.legal_hold_reason IS 'Why the call is on legal hold';
+COMMENT ON COLUMN calls.transcript_purged_at IS 'When the retention rule removed the call''s transcript';
+COMMENT ON COLUMN customers.legal_hold_at IS 'When the customer was placed on legal hold; their calls are exempt from retention';
+COMMENT ON COLUMN customers.legal_hold_reason IS 'Why the customer is on legal hold';
@@ -0,0 +1,2 @@
+-- Rollback feature flags
+DROP TABLE IF EXISTS feature_flags;
@@ -0,0 +1,18 @@
+-- Feature flags gate risky features so they can be rolled out to some users
+-- and switched off at runtime without a deploy
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent SMALLINT NOT NULL DEFAULT 0
+        CHECK (rollout_percent BETWEEN 0 AND 100),
+    user_ids UUID[] NOT NULL DEFAULT '{}',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feature_flags IS 'Runtime switches for features being rolled out';
+COMMENT ON COLUMN feature_flags.enabled IS 'Master switch; a disabled flag is off for everyone';
+COMMENT ON COLUMN feature_flags.rollout_percent IS 'Share of users the flag is on for, picked by a stable hash of the flag key and user ID';
+COMMENT ON COLUMN feature_flags.user_ids IS 'Users the flag is always on for while enabled';
@@ -0,0 +1,2 @@
+-- Rollback provider credentials
+DROP TABLE IF EXISTS provider_credentials;
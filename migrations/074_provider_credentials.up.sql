@@ -0,0 +1,16 @@
+-- Provider API keys set at runtime, encrypted with AES-256-GCM under the
+-- server's master key. A stored key overrides the one in the environment.
+CREATE TABLE IF NOT EXISTS provider_credentials (
+    provider VARCHAR(50) PRIMARY KEY,
+    ciphertext BYTEA NOT NULL,
+    key_id VARCHAR(16) NOT NULL,
+    hint VARCHAR(8) NOT NULL DEFAULT '',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE provider_credentials IS 'Encrypted API keys for voice and AI providers';
+COMMENT ON COLUMN provider_credentials.ciphertext IS 'Nonce followed by the AES-GCM sealed key; the provider name is the additional data';
+COMMENT ON COLUMN provider_credentials.key_id IS 'Fingerprint of the master key the row was sealed under';
+COMMENT ON COLUMN provider_credentials.hint IS 'Last characters of the key, to tell keys apart without decrypting them';
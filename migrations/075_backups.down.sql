@@ -0,0 +1,2 @@
+-- Rollback database backups
+DROP TABLE IF EXISTS backups;
@@ -0,0 +1,24 @@
+-- Logical database backups taken with pg_dump and kept in backup storage.
+-- The dump files live in storage; these rows record where, and how each
+-- backup went.
+CREATE TABLE IF NOT EXISTS backups (
+    id UUID PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    triggered_by VARCHAR(20) NOT NULL,
+    storage_backend VARCHAR(20) NOT NULL,
+    storage_key TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    sha256 VARCHAR(64) NOT NULL DEFAULT '',
+    error TEXT,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_backups_started_at ON backups(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_backups_expires_at ON backups(expires_at) WHERE expires_at IS NOT NULL AND status <> 'running';
+
+COMMENT ON TABLE backups IS 'Logical database backups and where their dump files are stored';
+COMMENT ON COLUMN backups.sha256 IS 'Hex SHA-256 digest of the stored dump file';
+COMMENT ON COLUMN backups.expires_at IS 'When the retention policy deletes the backup; NULL keeps it forever';
@@ -0,0 +1,7 @@
+-- Rollback tags
+DROP TRIGGER IF EXISTS customers_delete_entity_tags ON customers;
+DROP TRIGGER IF EXISTS calls_delete_entity_tags ON calls;
+DROP FUNCTION IF EXISTS delete_entity_tags();
+DROP TABLE IF EXISTS tag_views;
+DROP TABLE IF EXISTS entity_tags;
+DROP TABLE IF EXISTS tags;
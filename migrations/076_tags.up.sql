@@ -0,0 +1,74 @@
+-- Tags label calls, quotes and customers. Managed tags are curated by
+-- admins; free-form tags are created when someone first applies them.
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    managed BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The records each tag is applied to. Quotes are keyed by their call's ID.
+CREATE TABLE IF NOT EXISTS entity_tags (
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('call', 'quote', 'customer')),
+    entity_id UUID NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tag_id, entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_tags_entity ON entity_tags(entity_type, entity_id);
+
+-- Saved tag views shown on the dashboard
+CREATE TABLE IF NOT EXISTS tag_views (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(20) NOT NULL CHECK (entity_type IN ('call', 'quote', 'customer')),
+    tags TEXT[] NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_views_user_id ON tag_views(user_id);
+
+-- Tags go with the records they label
+CREATE OR REPLACE FUNCTION delete_entity_tags() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'calls' THEN
+        DELETE FROM entity_tags WHERE entity_type IN ('call', 'quote') AND entity_id = OLD.id;
+    ELSE
+        DELETE FROM entity_tags WHERE entity_type = 'customer' AND entity_id = OLD.id;
+    END IF;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS calls_delete_entity_tags ON calls;
+CREATE TRIGGER calls_delete_entity_tags
+    AFTER DELETE ON calls
+    FOR EACH ROW EXECUTE FUNCTION delete_entity_tags();
+
+DROP TRIGGER IF EXISTS customers_delete_entity_tags ON customers;
+CREATE TRIGGER customers_delete_entity_tags
+    AFTER DELETE ON customers
+    FOR EACH ROW EXECUTE FUNCTION delete_entity_tags();
+
+-- Starter tags and shared views; admins can change or delete them
+INSERT INTO tags (id, name, description, color, managed) VALUES
+    ('6d1f5c3e-8a44-4f0e-9c1a-7e2b9d4a1001', 'hot-lead', 'Ready to buy; follow up first', '#dc2626', true),
+    ('6d1f5c3e-8a44-4f0e-9c1a-7e2b9d4a1002', 'follow-up-needed', 'Waiting on us for a next step', '#d97706', true)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO tag_views (id, name, entity_type, tags) VALUES
+    ('6d1f5c3e-8a44-4f0e-9c1a-7e2b9d4a2001', 'Hot leads', 'call', '{hot-lead}'),
+    ('6d1f5c3e-8a44-4f0e-9c1a-7e2b9d4a2002', 'Follow-up needed', 'call', '{follow-up-needed}')
+ON CONFLICT (id) DO NOTHING;
+
+COMMENT ON TABLE tags IS 'Labels for calls, quotes and customers';
+COMMENT ON COLUMN tags.managed IS 'Curated by admins; other tags were created by applying a new name';
+COMMENT ON TABLE entity_tags IS 'Tags applied to calls, quotes (by call ID) and customers';
+COMMENT ON TABLE tag_views IS 'Saved lists of records carrying all of a set of tags; user_id NULL is shared';
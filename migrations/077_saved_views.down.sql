@@ -0,0 +1,2 @@
+-- Rollback saved views
+DROP TABLE IF EXISTS saved_views;
@@ -0,0 +1,16 @@
+-- Saved configurations of the calls list: filters, sort order and columns.
+-- Each user may mark one of their views as the default the list opens with.
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}',
+    sort VARCHAR(20) NOT NULL DEFAULT 'newest',
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_views_default ON saved_views(user_id) WHERE is_default;
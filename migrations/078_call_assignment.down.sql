@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_calls_assigned_to;
+
+ALTER TABLE calls DROP COLUMN IF EXISTS assigned_to;
@@ -0,0 +1,6 @@
+-- Calls (and the quotes on them) can be assigned to a user to follow up
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS assigned_to UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_calls_assigned_to ON calls (assigned_to) WHERE assigned_to IS NOT NULL;
+
+COMMENT ON COLUMN calls.assigned_to IS 'User following up the call and its quote';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_sso_identity;
+ALTER TABLE users DROP COLUMN IF EXISTS sso_subject;
+ALTER TABLE users DROP COLUMN IF EXISTS sso_issuer;
@@ -0,0 +1,7 @@
+-- Single sign-on users are matched by the identity provider's issuer and
+-- subject, which never change, rather than by email address
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sso_issuer TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sso_subject TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_sso_identity ON users (sso_issuer, sso_subject)
+    WHERE sso_subject <> '' AND deleted_at IS NULL;
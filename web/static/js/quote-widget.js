@@ -0,0 +1,181 @@
+/*
+ * QuickQuote quote request widget.
+ *
+ * Embed on any website to collect quote requests:
+ *
+ *   <div id="quickquote-form"></div>
+ *   <script src="https://quotes.example.com/static/js/quote-widget.js"
+ *           data-target="#quickquote-form" async></script>
+ *
+ * The form is rendered into the element matched by data-target (default
+ * "#quickquote-form") and posts to the QuickQuote server the script was
+ * loaded from. Set data-title to change the heading, or data-title="" to
+ * hide it.
+ */
+(function() {
+    'use strict';
+
+    var script = document.currentScript;
+    if (!script) return;
+
+    var baseURL = new URL(script.src).origin;
+    var endpoint = baseURL + '/api/public/quote-requests';
+    var target = document.querySelector(script.getAttribute('data-target') || '#quickquote-form');
+    if (!target) return;
+    var title = script.hasAttribute('data-title') ? script.getAttribute('data-title') : 'Request a Quote';
+
+    var captchas = {
+        turnstile: { src: 'https://challenges.cloudflare.com/turnstile/v0/api.js', widget: 'cf-turnstile', field: 'cf-turnstile-response', api: 'turnstile' },
+        hcaptcha: { src: 'https://js.hcaptcha.com/1/api.js', widget: 'h-captcha', field: 'h-captcha-response', api: 'hcaptcha' },
+        recaptcha: { src: 'https://www.google.com/recaptcha/api.js', widget: 'g-recaptcha', field: 'g-recaptcha-response', api: 'grecaptcha' }
+    };
+
+    var style = [
+        '.qq-form{font-family:inherit;max-width:32rem}',
+        '.qq-form label{display:block;margin:.75rem 0 .25rem;font-weight:600}',
+        '.qq-form input,.qq-form select,.qq-form textarea{width:100%;box-sizing:border-box;padding:.5rem;border:1px solid #ccc;border-radius:4px;font:inherit}',
+        '.qq-form .qq-check{display:flex;gap:.5rem;align-items:center;margin-top:.75rem;font-weight:normal}',
+        '.qq-form .qq-check input{width:auto}',
+        '.qq-form button{margin-top:1rem;padding:.6rem 1.2rem;border:0;border-radius:4px;background:#2563eb;color:#fff;font:inherit;cursor:pointer}',
+        '.qq-form button:disabled{opacity:.6;cursor:default}',
+        '.qq-form .qq-captcha{margin-top:1rem}',
+        '.qq-message{margin-top:1rem;padding:.75rem;border-radius:4px}',
+        '.qq-message.qq-error{background:#fee2e2;color:#991b1b}',
+        '.qq-message.qq-success{background:#dcfce7;color:#166534}'
+    ].join('');
+
+    function el(tag, attrs, children) {
+        var node = document.createElement(tag);
+        Object.keys(attrs || {}).forEach(function(key) {
+            if (key === 'text') {
+                node.textContent = attrs[key];
+            } else {
+                node.setAttribute(key, attrs[key]);
+            }
+        });
+        (children || []).forEach(function(child) { node.appendChild(child); });
+        return node;
+    }
+
+    function field(label, input) {
+        var id = 'qq-' + input.getAttribute('name');
+        input.setAttribute('id', id);
+        return [el('label', { 'for': id, text: label }), input];
+    }
+
+    function render(config) {
+        var form = el('form', { 'class': 'qq-form', novalidate: '' });
+        if (title) form.appendChild(el('h3', { text: title }));
+
+        var rows = [
+            field('Name *', el('input', { name: 'name', type: 'text', required: '', maxlength: '200', autocomplete: 'name' })),
+            field('Email', el('input', { name: 'email', type: 'email', maxlength: '200', autocomplete: 'email' })),
+            field('Phone', el('input', { name: 'phone', type: 'tel', maxlength: '200', autocomplete: 'tel' })),
+            field('Company', el('input', { name: 'company', type: 'text', maxlength: '200', autocomplete: 'organization' }))
+        ];
+        if (config.project_types.length) {
+            var select = el('select', { name: 'project_type' }, [el('option', { value: '', text: 'Choose one' })]);
+            config.project_types.forEach(function(type) {
+                select.appendChild(el('option', { value: type, text: type }));
+            });
+            rows.push(field('Project type', select));
+        } else {
+            rows.push(field('Project type', el('input', { name: 'project_type', type: 'text', maxlength: '200' })));
+        }
+        rows.push(
+            field('Describe your project *', el('textarea', { name: 'description', rows: '5', required: '', maxlength: '5000' })),
+            field('Timeline', el('input', { name: 'timeline', type: 'text', maxlength: '200', placeholder: 'e.g. Within 3 months' })),
+            field('Budget', el('input', { name: 'budget', type: 'text', maxlength: '200' }))
+        );
+        rows.forEach(function(pair) { pair.forEach(function(node) { form.appendChild(node); }); });
+
+        if (config.callbacks) {
+            form.appendChild(el('label', { 'class': 'qq-check' }, [
+                el('input', { name: 'callback', type: 'checkbox' }),
+                document.createTextNode('Call me to talk it through')
+            ]));
+        }
+
+        var captcha = captchas[config.captcha_provider];
+        if (captcha) {
+            form.appendChild(el('div', { 'class': 'qq-captcha ' + captcha.widget, 'data-sitekey': config.captcha_site_key }));
+        }
+
+        var button = el('button', { type: 'submit', text: 'Request Quote' });
+        var message = el('div', { 'class': 'qq-message', role: 'status', hidden: '' });
+        form.appendChild(button);
+        form.appendChild(message);
+
+        form.addEventListener('submit', function(event) {
+            event.preventDefault();
+            submit(form, button, message, captcha);
+        });
+
+        target.innerHTML = '';
+        target.appendChild(el('style', { text: style }));
+        target.appendChild(form);
+
+        if (captcha && !document.querySelector('script[src^="' + captcha.src + '"]')) {
+            document.head.appendChild(el('script', { src: captcha.src, async: '', defer: '' }));
+        }
+    }
+
+    function show(message, text, isError) {
+        message.textContent = text;
+        message.className = 'qq-message ' + (isError ? 'qq-error' : 'qq-success');
+        message.hidden = false;
+    }
+
+    function submit(form, button, message, captcha) {
+        var data = {};
+        ['name', 'email', 'phone', 'company', 'project_type', 'description', 'timeline', 'budget'].forEach(function(name) {
+            data[name] = form.elements[name].value;
+        });
+        data.callback = !!(form.elements.callback && form.elements.callback.checked);
+        if (!data.name.trim() || !data.description.trim()) {
+            show(message, 'Please enter your name and describe your project.', true);
+            return;
+        }
+        if (!data.email.trim() && !data.phone.trim()) {
+            show(message, 'Please enter an email address or phone number.', true);
+            return;
+        }
+        if (captcha) {
+            var token = form.querySelector('[name="' + captcha.field + '"]');
+            data.captcha_token = token ? token.value : '';
+        }
+
+        button.disabled = true;
+        fetch(endpoint, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify(data)
+        }).then(function(resp) {
+            return resp.json().catch(function() { return {}; }).then(function(body) {
+                if (!resp.ok) throw new Error(body.message || 'Something went wrong. Please try again.');
+                return body;
+            });
+        }).then(function(body) {
+            form.reset();
+            Array.prototype.forEach.call(form.querySelectorAll('input,select,textarea,button'), function(input) {
+                input.disabled = true;
+            });
+            show(message, body.callback
+                ? 'Thanks! We\'ll call you shortly and send your quote once it\'s ready.'
+                : 'Thanks! We\'ll be in touch with your quote.', false);
+        }).catch(function(err) {
+            button.disabled = false;
+            show(message, err.message, true);
+            if (captcha && window[captcha.api] && window[captcha.api].reset) {
+                window[captcha.api].reset();
+            }
+        });
+    }
+
+    fetch(endpoint + '/config').then(function(resp) {
+        if (!resp.ok) throw new Error('quote form unavailable');
+        return resp.json();
+    }).then(render).catch(function() {
+        target.textContent = 'The quote request form is unavailable right now.';
+    });
+})();